@@ -4,6 +4,7 @@ import (
 	"log"
 
 	"github.com/subculture-collective/clipper/internal/handlers"
+	"github.com/subculture-collective/clipper/internal/openapi"
 )
 
 // Handlers holds all HTTP handler instances.
@@ -11,6 +12,7 @@ type Handlers struct {
 	Auth                *handlers.AuthHandler
 	MFA                 *handlers.MFAHandler
 	Monitoring          *handlers.MonitoringHandler
+	Maintenance         *handlers.MaintenanceHandler
 	WebhookMonitoring   *handlers.WebhookMonitoringHandler
 	Comment             *handlers.CommentHandler
 	Clip                *handlers.ClipHandler
@@ -26,27 +28,47 @@ type Handlers struct {
 	Subscription        *handlers.SubscriptionHandler
 	User                *handlers.UserHandler
 	AdminUser           *handlers.AdminUserHandler
+	UserBan             *handlers.UserBanHandler
+	I18n                *handlers.I18nHandler
 	UserSettings        *handlers.UserSettingsHandler
+	ProfileView         *handlers.ProfileViewHandler
 	Consent             *handlers.ConsentHandler
 	Contact             *handlers.ContactHandler
+	DMCA                *handlers.DMCAHandler
+	BanEvasion          *handlers.BanEvasionHandler
+	VoteRing            *handlers.VoteRingHandler
 	SEO                 *handlers.SEOHandler
 	Pages               *handlers.PagesHandler
 	Docs                *handlers.DocsHandler
 	Revenue             *handlers.RevenueHandler
 	Ad                  *handlers.AdHandler
 	Export              *handlers.ExportHandler
+	CreatorClaim        *handlers.CreatorClaimHandler
 	WebhookSubscription *handlers.WebhookSubscriptionHandler
 	WebhookDLQ          *handlers.WebhookDLQHandler
 	Config              *handlers.ConfigHandler
 	Broadcaster         *handlers.BroadcasterHandler
+	BroadcasterSchedule *handlers.BroadcasterScheduleHandler
+	ClipChangefeed      *handlers.ClipChangefeedHandler
+	ClipTitle           *handlers.ClipTitleHandler
+	ClipIngestionRule   *handlers.ClipIngestionRuleHandler
+	Automod             *handlers.AutomodHandler
+	KarmaRule           *handlers.KarmaRuleHandler
+	ClipBroadcaster     *handlers.ClipBroadcasterHandler
 	EmailMetrics        *handlers.EmailMetricsHandler
 	SendGridWebhook     *handlers.SendGridWebhookHandler
 	Feed                *handlers.FeedHandler
+	Syndication         *handlers.SyndicationHandler
 	FilterPreset        *handlers.FilterPresetHandler
 	Community           *handlers.CommunityHandler
+	CommunityDigest     *handlers.CommunityDigestHandler
+	CommunityToxicity   *handlers.CommunityToxicityHandler
+	DataActivity        *handlers.DataActivityHandler
 	DiscoveryList       *handlers.DiscoveryListHandler
+	CommunityPick       *handlers.CommunityPickHandler
 	Category            *handlers.CategoryHandler
 	Game                *handlers.GameHandler
+	GamePatch           *handlers.GamePatchHandler
 	AccountType         *handlers.AccountTypeHandler
 	Verification        *handlers.VerificationHandler
 	Chat                *handlers.ChatHandler
@@ -56,19 +78,37 @@ type Handlers struct {
 	Recommendation      *handlers.RecommendationHandler
 	Playlist            *handlers.PlaylistHandler
 	PlaylistScript      *handlers.PlaylistScriptHandler
+	PlaylistBundle      *handlers.PlaylistBundleHandler
+	FeatureFlag         *handlers.FeatureFlagHandler
+	RequestCapture      *handlers.RequestCaptureHandler
+	SearchSynonym       *handlers.SearchSynonymHandler
+	SearchAnalytics     *handlers.SearchAnalyticsHandler
 	Queue               *handlers.QueueHandler
 	WatchHistory        *handlers.WatchHistoryHandler
 	WatchParty          *handlers.WatchPartyHandler
 	Event               *handlers.EventHandler
 	ClipSync            *handlers.ClipSyncHandler       // may be nil
 	Submission          *handlers.SubmissionHandler      // may be nil
+	BroadcasterApproval *handlers.BroadcasterApprovalHandler // may be nil
 	Moderation          *handlers.ModerationHandler      // may be nil
 	LiveStatus          *handlers.LiveStatusHandler      // may be nil
+	TwitchEventSub      *handlers.TwitchEventSubHandler  // may be nil
 	Stream              *handlers.StreamHandler          // may be nil
 	TwitchOAuth         *handlers.TwitchOAuthHandler     // may be nil
+	Upload              *handlers.UploadHandler          // may be nil
 	Forum               *handlers.ForumHandler
 	ForumModeration     *handlers.ForumModerationHandler
 	NSFW                *handlers.NSFWHandler
+	ShareLink           *handlers.ShareLinkHandler
+	EmailLink           *handlers.EmailLinkHandler
+	APIKey              *handlers.APIKeyHandler
+	DeveloperUsage      *handlers.DeveloperUsageHandler
+	ClipEmbed           *handlers.ClipEmbedHandler
+	SimilarCases        *handlers.ModerationSimilarityHandler
+	ModerationShift     *handlers.ModerationShiftHandler
+	Session             *handlers.SessionHandler
+	JWKS                *handlers.JWKSHandler
+	OpenAPI             *handlers.OpenAPIHandler
 }
 
 func initHandlers(svcs *Services, repos *Repositories, infra *Infrastructure) *Handlers {
@@ -76,17 +116,27 @@ func initHandlers(svcs *Services, repos *Repositories, infra *Infrastructure) *H
 	cfg := infra.Config
 
 	authHandler := handlers.NewAuthHandler(svcs.Auth, cfg)
-	mfaHandler := handlers.NewMFAHandler(svcs.MFA, cfg)
+	authHandler.SetShareLinkService(svcs.ShareLink)
+	authHandler.SetSessionService(svcs.Session)
+	authHandler.SetBanEvasionService(svcs.BanEvasion)
+	authHandler.SetMFAService(svcs.MFA)
+	mfaHandler := handlers.NewMFAHandler(svcs.MFA, svcs.Auth, cfg)
+	mfaHandler.SetSessionService(svcs.Session)
 	monitoringHandler := handlers.NewMonitoringHandler(infra.Redis)
+	monitoringHandler.SetHealthHistoryService(svcs.HealthHistory)
+	maintenanceHandler := handlers.NewMaintenanceHandler(svcs.Maintenance)
 	webhookMonitoringHandler := handlers.NewWebhookMonitoringHandler(svcs.WebhookRetry, svcs.OutboundWebhook)
 	commentHandler := handlers.NewCommentHandler(svcs.Comment)
+	commentHandler.SetStreamHub(svcs.CommentStream)
 	clipHandler := handlers.NewClipHandler(
 		svcs.Clip,
 		svcs.Auth,
 		handlers.WithClipExtractionJobService(svcs.ClipExtractionJob),
+		handlers.WithClipPlaybackService(svcs.ClipPlayback),
 	)
 	favoriteHandler := handlers.NewFavoriteHandler(repos.Favorite, repos.Vote, svcs.Clip)
 	tagHandler := handlers.NewTagHandler(repos.Tag, repos.Clip, svcs.AutoTag)
+	tagHandler.SetTagGraphService(svcs.TagGraph)
 	searchHandler := handlers.NewSearchHandler(repos.Search, svcs.Auth)
 	if svcs.HybridSearch != nil {
 		// Use hybrid search (BM25 + vector similarity)
@@ -99,36 +149,75 @@ func initHandlers(svcs *Services, repos *Repositories, infra *Infrastructure) *H
 	} else {
 		log.Println("Using PostgreSQL FTS handler (fallback)")
 	}
+	searchHandler.SetFeatureFlags(svcs.FeatureFlag)
+	searchHandler.SetGamePatchService(svcs.GamePatch)
+	searchHandler.SetTagGraph(svcs.TagGraph)
 	reportHandler := handlers.NewReportHandler(repos.Report, repos.Clip, repos.Comment, repos.User, svcs.Auth)
+	reportHandler.SetUserBanService(svcs.UserBan)
+	reportHandler.SetUploadService(svcs.Upload)
+	reportHandler.SetReportQueueService(svcs.ReportQueue)
+	reportHandler.SetReportOutcomeService(svcs.ReportOutcome)
 	reputationHandler := handlers.NewReputationHandler(svcs.Reputation, svcs.Auth)
+	reputationHandler.SetAchievementService(svcs.Achievement)
+	reputationHandler.SetLeaderboardService(svcs.Leaderboard)
 	notificationHandler := handlers.NewNotificationHandler(svcs.Notification, svcs.Email)
+	notificationHandler.SetStreamHub(svcs.NotificationStream)
 	analyticsHandler := handlers.NewAnalyticsHandler(svcs.Analytics, svcs.Auth)
+	analyticsHandler.SetShareLinkService(svcs.ShareLink)
 	engagementHandler := handlers.NewEngagementHandler(svcs.Engagement, svcs.Auth)
 	auditLogHandler := handlers.NewAuditLogHandler(svcs.AuditLog)
 	subscriptionHandler := handlers.NewSubscriptionHandler(svcs.Subscription)
 	userHandler := handlers.NewUserHandler(repos.Clip, repos.Vote, repos.Comment, repos.User, repos.Broadcaster, svcs.AccountMerge)
-	adminUserHandler := handlers.NewAdminUserHandler(repos.User, repos.AuditLog, svcs.Auth)
+	adminUserHandler := handlers.NewAdminUserHandler(repos.User, repos.AuditLog, svcs.Auth, svcs.UserBan)
+	userBanHandler := handlers.NewUserBanHandler(svcs.UserBan)
+	i18nHandler := handlers.NewI18nHandler(svcs.I18n)
+	adminUserHandler.SetSessionService(svcs.Session)
+	adminUserHandler.SetShadowBanService(svcs.ShadowBan)
+	userHandler.SetShadowBanChecker(svcs.ShadowBan)
 	userSettingsHandler := handlers.NewUserSettingsHandler(svcs.UserSettings, svcs.Auth)
+	profileViewHandler := handlers.NewProfileViewHandler(svcs.ProfileView)
 	consentHandler := handlers.NewConsentHandler(repos.Consent)
 	contactHandler := handlers.NewContactHandler(repos.Contact, svcs.Auth)
+	dmcaHandler := handlers.NewDMCAHandler(svcs.DMCA, svcs.Auth)
+	banEvasionHandler := handlers.NewBanEvasionHandler(svcs.BanEvasion)
+	voteRingHandler := handlers.NewVoteRingHandler(svcs.KarmaSafeguard)
 	seoHandler := handlers.NewSEOHandler(repos.Clip, repos.Game)
 	pagesHandler := handlers.NewPagesHandler(repos.Clip, repos.Broadcaster, repos.Game)
 	docsHandler := handlers.NewDocsHandler(cfg.Server.DocsPath, "subculture-collective", "clipper", "main")
 	revenueHandler := handlers.NewRevenueHandler(svcs.Revenue)
 	adHandler := handlers.NewAdHandler(svcs.Ad)
+	adHandler.SetUploadService(svcs.Upload)
 	exportHandler := handlers.NewExportHandler(svcs.Export, svcs.Auth, repos.User)
+	creatorClaimHandler := handlers.NewCreatorClaimHandler(svcs.CreatorClaim)
 	webhookSubscriptionHandler := handlers.NewWebhookSubscriptionHandler(svcs.OutboundWebhook)
 	webhookDLQHandler := handlers.NewWebhookDLQHandler(svcs.OutboundWebhook)
 	configHandler := handlers.NewConfigHandler(cfg)
+	configHandler.SetMaintenance(svcs.Maintenance)
 	broadcasterHandler := handlers.NewBroadcasterHandler(repos.Broadcaster, repos.Clip, infra.TwitchClient, svcs.Auth)
+	broadcasterScheduleHandler := handlers.NewBroadcasterScheduleHandler(svcs.BroadcasterSchedule)
+	clipChangefeedHandler := handlers.NewClipChangefeedHandler(svcs.ClipChangefeed)
+	clipTitleHandler := handlers.NewClipTitleHandler(svcs.ClipTitle)
+	clipIngestionRuleHandler := handlers.NewClipIngestionRuleHandler(svcs.ClipIngestionRule)
+	automodHandler := handlers.NewAutomodHandler(svcs.Automod)
+	karmaRuleHandler := handlers.NewKarmaRuleHandler(svcs.Reputation)
+	clipBroadcasterHandler := handlers.NewClipBroadcasterHandler(svcs.ClipBroadcaster)
 	emailMetricsHandler := handlers.NewEmailMetricsHandler(svcs.EmailMetrics, repos.EmailLog)
+	emailMetricsHandler.SetReengagementService(svcs.Reengagement)
+	emailMetricsHandler.SetThrottleService(svcs.EmailThrottle)
 	sendgridWebhookHandler := handlers.NewSendGridWebhookHandler(repos.EmailLog, cfg.Email.SendGridWebhookPublicKey)
 	feedHandler := handlers.NewFeedHandler(svcs.Feed, svcs.Auth, repos.Vote, repos.Favorite, repos.User)
+	feedHandler.SetSocialFeedService(svcs.SocialFeed)
+	syndicationHandler := handlers.NewSyndicationHandler(svcs.Syndication)
 	filterPresetHandler := handlers.NewFilterPresetHandler(svcs.FilterPreset)
 	communityHandler := handlers.NewCommunityHandler(svcs.Community, svcs.Auth)
+	communityDigestHandler := handlers.NewCommunityDigestHandler(svcs.CommunityDigest)
+	communityToxicityHandler := handlers.NewCommunityToxicityHandler(svcs.CommunityToxicity)
+	dataActivityHandler := handlers.NewDataActivityHandler(svcs.DataActivity)
 	discoveryListHandler := handlers.NewDiscoveryListHandler(repos.DiscoveryList, repos.Analytics)
+	communityPickHandler := handlers.NewCommunityPickHandler(svcs.CommunityPick)
 	categoryHandler := handlers.NewCategoryHandler(repos.Category, repos.Clip)
 	gameHandler := handlers.NewGameHandler(repos.Game, repos.Clip, svcs.Auth)
+	gamePatchHandler := handlers.NewGamePatchHandler(svcs.GamePatch)
 	accountTypeHandler := handlers.NewAccountTypeHandler(svcs.AccountType, svcs.Auth)
 	verificationHandler := handlers.NewVerificationHandler(repos.Verification, svcs.Notification, pool)
 	chatHandler := handlers.NewChatHandler(pool)
@@ -141,6 +230,11 @@ func initHandlers(svcs *Services, repos *Repositories, infra *Infrastructure) *H
 	recommendationHandler := handlers.NewRecommendationHandler(svcs.Recommendation, svcs.Auth)
 	playlistHandler := handlers.NewPlaylistHandler(svcs.Playlist)
 	playlistScriptHandler := handlers.NewPlaylistScriptHandler(svcs.PlaylistScript)
+	playlistBundleHandler := handlers.NewPlaylistBundleHandler(svcs.PlaylistBundle)
+	featureFlagHandler := handlers.NewFeatureFlagHandler(svcs.FeatureFlag)
+	requestCaptureHandler := handlers.NewRequestCaptureHandler(svcs.RequestCapture)
+	searchSynonymHandler := handlers.NewSearchSynonymHandler(svcs.SearchSynonym)
+	searchAnalyticsHandler := handlers.NewSearchAnalyticsHandler(svcs.SearchAnalytics)
 	queueHandler := handlers.NewQueueHandler(svcs.Queue)
 	watchHistoryHandler := handlers.NewWatchHistoryHandler(repos.WatchHistory)
 	watchPartyHandler := handlers.NewWatchPartyHandler(svcs.WatchParty, svcs.WatchPartyHubManager, repos.WatchParty, repos.Analytics, cfg)
@@ -148,10 +242,13 @@ func initHandlers(svcs *Services, repos *Repositories, infra *Infrastructure) *H
 
 	var clipSyncHandler *handlers.ClipSyncHandler
 	var submissionHandler *handlers.SubmissionHandler
+	var broadcasterApprovalHandler *handlers.BroadcasterApprovalHandler
 	var moderationHandler *handlers.ModerationHandler
 	var liveStatusHandler *handlers.LiveStatusHandler
+	var twitchEventSubHandler *handlers.TwitchEventSubHandler
 	var streamHandler *handlers.StreamHandler
 	var twitchOAuthHandler *handlers.TwitchOAuthHandler
+	var uploadHandler *handlers.UploadHandler
 
 	if svcs.ClipSync != nil {
 		clipSyncHandler = handlers.NewClipSyncHandler(svcs.ClipSync, cfg)
@@ -161,12 +258,24 @@ func initHandlers(svcs *Services, repos *Repositories, infra *Infrastructure) *H
 		liveStatusHandler = handlers.NewLiveStatusHandler(svcs.LiveStatus, svcs.Auth)
 	}
 
+	if svcs.TwitchEventSub != nil {
+		twitchEventSubHandler = handlers.NewTwitchEventSubHandler(svcs.TwitchEventSub)
+	}
+
 	// Initialize Twitch-related handlers
 	if infra.TwitchClient != nil {
 		streamHandler = handlers.NewStreamHandler(infra.TwitchClient, repos.Stream, repos.Clip, repos.StreamFollow, svcs.ClipExtractionJob)
 		twitchOAuthHandler = handlers.NewTwitchOAuthHandler(repos.TwitchAuth)
 	}
 
+	if svcs.Upload != nil {
+		uploadHandler = handlers.NewUploadHandler(svcs.Upload)
+	}
+
+	if svcs.BroadcasterApproval != nil {
+		broadcasterApprovalHandler = handlers.NewBroadcasterApprovalHandler(svcs.BroadcasterApproval)
+	}
+
 	if svcs.Submission != nil {
 		submissionHandler = handlers.NewSubmissionHandler(svcs.Submission)
 		// Create moderation handler using services from submission service
@@ -178,6 +287,8 @@ func initHandlers(svcs *Services, repos *Repositories, infra *Infrastructure) *H
 			if svcs.TwitchModeration != nil {
 				moderationHandler.SetTwitchModerationService(svcs.TwitchModeration)
 			}
+			moderationHandler.SetAppealService(svcs.ModerationAppeal)
+			moderationHandler.SetClaimService(svcs.ModerationClaim)
 		}
 	}
 
@@ -188,10 +299,33 @@ func initHandlers(svcs *Services, repos *Repositories, infra *Infrastructure) *H
 	// Initialize NSFW handler
 	nsfwHandler := handlers.NewNSFWHandler(svcs.NSFWDetector)
 
+	// Initialize share link handler
+	shareLinkHandler := handlers.NewShareLinkHandler(svcs.ShareLink, cfg)
+
+	// Initialize tracked email link handler
+	emailLinkHandler := handlers.NewEmailLinkHandler(svcs.EmailLink)
+
+	// Initialize API key handler
+	apiKeyHandler := handlers.NewAPIKeyHandler(svcs.APIKey)
+	developerUsageHandler := handlers.NewDeveloperUsageHandler(svcs.DeveloperUsage)
+	clipEmbedHandler := handlers.NewClipEmbedHandler(svcs.ClipEmbed, repos.Clip, cfg)
+	moderationSimilarityHandler := handlers.NewModerationSimilarityHandler(svcs.ModerationSimilarity)
+	moderationShiftHandler := handlers.NewModerationShiftHandler(svcs.ModerationShift)
+
+	// Initialize session handler
+	sessionHandler := handlers.NewSessionHandler(svcs.Session)
+
+	// Initialize JWKS handler
+	jwksHandler := handlers.NewJWKSHandler(infra.JWTManager)
+
+	// Initialize OpenAPI handler with the spec generated by cmd/gen-openapi
+	openAPIHandler := handlers.NewOpenAPIHandler(openapi.JSON())
+
 	return &Handlers{
 		Auth:                authHandler,
 		MFA:                 mfaHandler,
 		Monitoring:          monitoringHandler,
+		Maintenance:         maintenanceHandler,
 		WebhookMonitoring:   webhookMonitoringHandler,
 		Comment:             commentHandler,
 		Clip:                clipHandler,
@@ -207,27 +341,47 @@ func initHandlers(svcs *Services, repos *Repositories, infra *Infrastructure) *H
 		Subscription:        subscriptionHandler,
 		User:                userHandler,
 		AdminUser:           adminUserHandler,
+		UserBan:             userBanHandler,
+		I18n:                i18nHandler,
 		UserSettings:        userSettingsHandler,
+		ProfileView:         profileViewHandler,
 		Consent:             consentHandler,
 		Contact:             contactHandler,
+		DMCA:                dmcaHandler,
+		BanEvasion:          banEvasionHandler,
+		VoteRing:            voteRingHandler,
 		SEO:                 seoHandler,
 		Pages:               pagesHandler,
 		Docs:                docsHandler,
 		Revenue:             revenueHandler,
 		Ad:                  adHandler,
 		Export:              exportHandler,
+		CreatorClaim:        creatorClaimHandler,
 		WebhookSubscription: webhookSubscriptionHandler,
 		WebhookDLQ:          webhookDLQHandler,
 		Config:              configHandler,
 		Broadcaster:         broadcasterHandler,
+		BroadcasterSchedule: broadcasterScheduleHandler,
+		ClipChangefeed:      clipChangefeedHandler,
+		ClipTitle:           clipTitleHandler,
+		ClipIngestionRule:   clipIngestionRuleHandler,
+		Automod:             automodHandler,
+		KarmaRule:           karmaRuleHandler,
+		ClipBroadcaster:     clipBroadcasterHandler,
 		EmailMetrics:        emailMetricsHandler,
 		SendGridWebhook:     sendgridWebhookHandler,
 		Feed:                feedHandler,
+		Syndication:         syndicationHandler,
 		FilterPreset:        filterPresetHandler,
 		Community:           communityHandler,
+		CommunityDigest:     communityDigestHandler,
+		CommunityToxicity:   communityToxicityHandler,
+		DataActivity:        dataActivityHandler,
 		DiscoveryList:       discoveryListHandler,
+		CommunityPick:       communityPickHandler,
 		Category:            categoryHandler,
 		Game:                gameHandler,
+		GamePatch:           gamePatchHandler,
 		AccountType:         accountTypeHandler,
 		Verification:        verificationHandler,
 		Chat:                chatHandler,
@@ -237,18 +391,36 @@ func initHandlers(svcs *Services, repos *Repositories, infra *Infrastructure) *H
 		Recommendation:      recommendationHandler,
 		Playlist:            playlistHandler,
 		PlaylistScript:      playlistScriptHandler,
+		PlaylistBundle:      playlistBundleHandler,
+		FeatureFlag:         featureFlagHandler,
+		RequestCapture:      requestCaptureHandler,
+		SearchSynonym:       searchSynonymHandler,
+		SearchAnalytics:     searchAnalyticsHandler,
 		Queue:               queueHandler,
 		WatchHistory:        watchHistoryHandler,
 		WatchParty:          watchPartyHandler,
 		Event:               eventHandler,
 		ClipSync:            clipSyncHandler,
 		Submission:          submissionHandler,
+		BroadcasterApproval: broadcasterApprovalHandler,
 		Moderation:          moderationHandler,
 		LiveStatus:          liveStatusHandler,
+		TwitchEventSub:      twitchEventSubHandler,
 		Stream:              streamHandler,
 		TwitchOAuth:         twitchOAuthHandler,
+		Upload:              uploadHandler,
 		Forum:               forumHandler,
 		ForumModeration:     forumModerationHandler,
 		NSFW:                nsfwHandler,
+		ShareLink:           shareLinkHandler,
+		EmailLink:           emailLinkHandler,
+		APIKey:              apiKeyHandler,
+		DeveloperUsage:      developerUsageHandler,
+		ClipEmbed:           clipEmbedHandler,
+		SimilarCases:        moderationSimilarityHandler,
+		ModerationShift:     moderationShiftHandler,
+		Session:             sessionHandler,
+		JWKS:                jwksHandler,
+		OpenAPI:             openAPIHandler,
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/pagination"
 	"github.com/subculture-collective/clipper/pkg/database"
 	jwtpkg "github.com/subculture-collective/clipper/pkg/jwt"
 	opensearchpkg "github.com/subculture-collective/clipper/pkg/opensearch"
@@ -86,6 +87,13 @@ func initInfrastructure(cfg *config.Config) *Infrastructure {
 		jwtManager = manager
 	}
 
+	// Pagination cursors are signed so clients can't forge positions
+	if cfg.Security.CursorSigningKey != "" {
+		pagination.SetSigningKey([]byte(cfg.Security.CursorSigningKey))
+	} else {
+		log.Println("WARNING: No CURSOR_SIGNING_KEY provided. Pagination cursors are only valid on the instance that issued them")
+	}
+
 	// Initialize Twitch client
 	twitchClient, err := twitch.NewClient(&cfg.Twitch, redisClient)
 	if err != nil {
@@ -89,6 +89,15 @@ func applyGlobalMiddleware(r *gin.Engine, cfg *config.Config, infra *Infrastruct
 
 	// Apply metrics middleware for Prometheus
 	r.Use(middleware.MetricsMiddleware())
+	// Count server errors per endpoint group for uptime history
+	r.Use(middleware.HealthHistoryMiddleware(svcs.HealthHistory))
+
+	// Negotiate gzip response compression and apply ?fields= sparse fieldsets
+	r.Use(middleware.CompressionMiddleware())
+	// Sample requests to routes with an admin capture session, seeing
+	// responses as sent but before compression
+	r.Use(middleware.RequestCaptureMiddleware(svcs.RequestCapture))
+	r.Use(middleware.SparseFieldsetMiddleware())
 
 	// Apply CORS middleware
 	r.Use(middleware.CORSMiddleware(cfg))
@@ -105,6 +114,9 @@ func applyGlobalMiddleware(r *gin.Engine, cfg *config.Config, infra *Infrastruct
 	// Apply CSRF protection middleware (secure in production)
 	r.Use(middleware.CSRFMiddleware(infra.Redis, infra.IsProduction))
 
+	// Reject writes while in read-only maintenance mode
+	r.Use(middleware.MaintenanceModeMiddleware(svcs.Maintenance))
+
 	// Add middleware to inject base URL and environment into context
 	r.Use(func(c *gin.Context) {
 		c.Set("base_url", cfg.Server.BaseURL)
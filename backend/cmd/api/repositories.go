@@ -10,6 +10,7 @@ type Repositories struct {
 	User                  *repository.UserRepository
 	RefreshToken          *repository.RefreshTokenRepository
 	UserSettings          *repository.UserSettingsRepository
+	ProfileView           *repository.ProfileViewRepository
 	AccountDeletion       *repository.AccountDeletionRepository
 	Consent               *repository.ConsentRepository
 	Clip                  *repository.ClipRepository
@@ -23,8 +24,17 @@ type Repositories struct {
 	Reputation            *repository.ReputationRepository
 	Notification          *repository.NotificationRepository
 	EmailNotification     *repository.EmailNotificationRepository
+	NotificationDigest    *repository.NotificationDigestRepository
+	Reengagement          *repository.ReengagementRepository
 	Analytics             *repository.AnalyticsRepository
 	AuditLog              *repository.AuditLogRepository
+	UserBan               *repository.UserBanRepository
+	UserShadowBan         *repository.UserShadowBanRepository
+	ModerationAppeal      *repository.ModerationAppealRepository
+	ModerationClaim       *repository.ModerationClaimRepository
+	DMCA                  *repository.DMCARepository
+	BanEvasion            *repository.BanEvasionRepository
+	I18n                  *repository.I18nRepository
 	Subscription          *repository.SubscriptionRepository
 	Webhook               *repository.WebhookRepository
 	OutboundWebhook       *repository.OutboundWebhookRepository
@@ -34,19 +44,42 @@ type Repositories struct {
 	Ad                    *repository.AdRepository
 	Export                *repository.ExportRepository
 	Broadcaster           *repository.BroadcasterRepository
+	BroadcasterSchedule   *repository.BroadcasterScheduleRepository
+	ClipChangefeed        *repository.ClipChangefeedRepository
+	ClipTitle             *repository.ClipTitleRepository
+	ClipIngestionRule     *repository.ClipIngestionRuleRepository
+	AutomodRule           *repository.AutomodRuleRepository
+	KarmaRule             *repository.KarmaRuleRepository
+	Achievement           *repository.AchievementRepository
+	LeaderboardSnapshot   *repository.LeaderboardSnapshotRepository
+	KarmaSafeguard        *repository.KarmaSafeguardRepository
+	ClipBroadcaster       *repository.ClipBroadcasterRepository
+	SearchPersonalization *repository.SearchPersonalizationRepository
 	EmailLog              *repository.EmailLogRepository
+	EmailThrottle         *repository.EmailThrottleRepository
 	Feed                  *repository.FeedRepository
+	SocialFeed            *repository.SocialFeedRepository
 	FilterPreset          *repository.FilterPresetRepository
 	DiscoveryList         *repository.DiscoveryListRepository
+	CommunityPick         *repository.CommunityPickRepository
 	Category              *repository.CategoryRepository
 	Game                  *repository.GameRepository
+	GamePatch             *repository.GamePatchRepository
 	Community             *repository.CommunityRepository
+	CommunityDigest       *repository.CommunityDigestRepository
+	CommunityToxicity     *repository.CommunityToxicityRepository
+	DataActivity          *repository.DataActivityRepository
 	AccountTypeConversion *repository.AccountTypeConversionRepository
 	Verification          *repository.VerificationRepository
 	Recommendation        *repository.RecommendationRepository
+	ClipEmbeddingColumn   *repository.ClipEmbeddingColumnRepository
 	Playlist              *repository.PlaylistRepository
 	PlaylistScript        *repository.PlaylistScriptRepository
 	PlaylistCuration      *repository.PlaylistCurationRepository
+	PlaylistBundle        *repository.PlaylistBundleRepository
+	FeatureFlag           *repository.FeatureFlagRepository
+	SearchSynonym         *repository.SearchSynonymRepository
+	SearchAnalytics       *repository.SearchAnalyticsRepository
 	Queue                 *repository.QueueRepository
 	WatchHistory          *repository.WatchHistoryRepository
 	Stream                *repository.StreamRepository
@@ -58,6 +91,23 @@ type Repositories struct {
 	BanReasonTemplate     *repository.BanReasonTemplateRepository
 	MFA                   *repository.MFARepository
 	DiscoveryClip         *repository.DiscoveryClipRepository
+	AuthIdentity          *repository.AuthIdentityRepository
+	ShareLink             *repository.ShareLinkRepository
+	EmailTrackedLink      *repository.EmailTrackedLinkRepository
+	APIKey                *repository.APIKeyRepository
+	DeveloperUsage        *repository.DeveloperUsageRepository
+	ClipEmbedHealth       *repository.ClipEmbedHealthRepository
+	ClipEmbed             *repository.ClipEmbedRepository
+	ClipAccessibility     *repository.ClipAccessibilityRepository
+	ClipHype              *repository.ClipHypeRepository
+	HealthHistory         *repository.HealthHistoryRepository
+	CreatorClaim          *repository.CreatorClaimRepository
+	ModerationCase        *repository.ModerationCaseRepository
+	ModerationShift       *repository.ModerationShiftRepository
+	Session               *repository.SessionRepository
+	JWTSigningKey         *repository.JWTSigningKeyRepository
+	BroadcasterApproval   *repository.BroadcasterApprovalRepository
+	Upload                *repository.UploadRepository
 }
 
 func initRepositories(pool *pgxpool.Pool) *Repositories {
@@ -65,6 +115,7 @@ func initRepositories(pool *pgxpool.Pool) *Repositories {
 		User:                  repository.NewUserRepository(pool),
 		RefreshToken:          repository.NewRefreshTokenRepository(pool),
 		UserSettings:          repository.NewUserSettingsRepository(pool),
+		ProfileView:           repository.NewProfileViewRepository(pool),
 		AccountDeletion:       repository.NewAccountDeletionRepository(pool),
 		Consent:               repository.NewConsentRepository(pool),
 		Clip:                  repository.NewClipRepository(pool),
@@ -78,8 +129,17 @@ func initRepositories(pool *pgxpool.Pool) *Repositories {
 		Reputation:            repository.NewReputationRepository(pool),
 		Notification:          repository.NewNotificationRepository(pool),
 		EmailNotification:     repository.NewEmailNotificationRepository(pool),
+		NotificationDigest:    repository.NewNotificationDigestRepository(pool),
+		Reengagement:          repository.NewReengagementRepository(pool),
 		Analytics:             repository.NewAnalyticsRepository(pool),
 		AuditLog:              repository.NewAuditLogRepository(pool),
+		UserBan:               repository.NewUserBanRepository(pool),
+		UserShadowBan:         repository.NewUserShadowBanRepository(pool),
+		ModerationAppeal:      repository.NewModerationAppealRepository(pool),
+		ModerationClaim:       repository.NewModerationClaimRepository(pool),
+		DMCA:                  repository.NewDMCARepository(pool),
+		BanEvasion:            repository.NewBanEvasionRepository(pool),
+		I18n:                  repository.NewI18nRepository(pool),
 		Subscription:          repository.NewSubscriptionRepository(pool),
 		Webhook:               repository.NewWebhookRepository(pool),
 		OutboundWebhook:       repository.NewOutboundWebhookRepository(pool),
@@ -89,19 +149,42 @@ func initRepositories(pool *pgxpool.Pool) *Repositories {
 		Ad:                    repository.NewAdRepository(pool),
 		Export:                repository.NewExportRepository(pool),
 		Broadcaster:           repository.NewBroadcasterRepository(pool),
+		BroadcasterSchedule:   repository.NewBroadcasterScheduleRepository(pool),
+		ClipChangefeed:        repository.NewClipChangefeedRepository(pool),
+		ClipTitle:             repository.NewClipTitleRepository(pool),
+		ClipIngestionRule:     repository.NewClipIngestionRuleRepository(pool),
+		AutomodRule:           repository.NewAutomodRuleRepository(pool),
+		KarmaRule:             repository.NewKarmaRuleRepository(pool),
+		Achievement:           repository.NewAchievementRepository(pool),
+		LeaderboardSnapshot:   repository.NewLeaderboardSnapshotRepository(pool),
+		KarmaSafeguard:        repository.NewKarmaSafeguardRepository(pool),
+		ClipBroadcaster:       repository.NewClipBroadcasterRepository(pool),
+		SearchPersonalization: repository.NewSearchPersonalizationRepository(pool),
 		EmailLog:              repository.NewEmailLogRepository(pool),
+		EmailThrottle:         repository.NewEmailThrottleRepository(pool),
 		Feed:                  repository.NewFeedRepository(pool),
+		SocialFeed:            repository.NewSocialFeedRepository(pool),
 		FilterPreset:          repository.NewFilterPresetRepository(pool),
 		DiscoveryList:         repository.NewDiscoveryListRepository(pool),
+		CommunityPick:         repository.NewCommunityPickRepository(pool),
 		Category:              repository.NewCategoryRepository(pool),
 		Game:                  repository.NewGameRepository(pool),
+		GamePatch:             repository.NewGamePatchRepository(pool),
 		Community:             repository.NewCommunityRepository(pool),
+		CommunityDigest:       repository.NewCommunityDigestRepository(pool),
+		CommunityToxicity:     repository.NewCommunityToxicityRepository(pool),
+		DataActivity:          repository.NewDataActivityRepository(pool),
 		AccountTypeConversion: repository.NewAccountTypeConversionRepository(pool),
 		Verification:          repository.NewVerificationRepository(pool),
 		Recommendation:        repository.NewRecommendationRepository(pool),
+		ClipEmbeddingColumn:   repository.NewClipEmbeddingColumnRepository(pool),
 		Playlist:              repository.NewPlaylistRepository(pool),
 		PlaylistScript:        repository.NewPlaylistScriptRepository(pool),
 		PlaylistCuration:      repository.NewPlaylistCurationRepository(pool),
+		PlaylistBundle:        repository.NewPlaylistBundleRepository(pool),
+		FeatureFlag:           repository.NewFeatureFlagRepository(pool),
+		SearchSynonym:         repository.NewSearchSynonymRepository(pool),
+		SearchAnalytics:       repository.NewSearchAnalyticsRepository(pool),
 		Queue:                 repository.NewQueueRepository(pool),
 		WatchHistory:          repository.NewWatchHistoryRepository(pool),
 		Stream:                repository.NewStreamRepository(pool),
@@ -113,5 +196,22 @@ func initRepositories(pool *pgxpool.Pool) *Repositories {
 		BanReasonTemplate:     repository.NewBanReasonTemplateRepository(pool),
 		MFA:                   repository.NewMFARepository(pool),
 		DiscoveryClip:         repository.NewDiscoveryClipRepository(pool),
+		AuthIdentity:          repository.NewAuthIdentityRepository(pool),
+		ShareLink:             repository.NewShareLinkRepository(pool),
+		EmailTrackedLink:      repository.NewEmailTrackedLinkRepository(pool),
+		APIKey:                repository.NewAPIKeyRepository(pool),
+		DeveloperUsage:        repository.NewDeveloperUsageRepository(pool),
+		ClipEmbedHealth:       repository.NewClipEmbedHealthRepository(pool),
+		ClipEmbed:             repository.NewClipEmbedRepository(pool),
+		ClipAccessibility:     repository.NewClipAccessibilityRepository(pool),
+		ClipHype:              repository.NewClipHypeRepository(pool),
+		HealthHistory:         repository.NewHealthHistoryRepository(pool),
+		CreatorClaim:          repository.NewCreatorClaimRepository(pool),
+		ModerationCase:        repository.NewModerationCaseRepository(pool),
+		ModerationShift:       repository.NewModerationShiftRepository(pool),
+		Session:               repository.NewSessionRepository(pool),
+		JWTSigningKey:         repository.NewJWTSigningKeyRepository(pool),
+		BroadcasterApproval:   repository.NewBroadcasterApprovalRepository(pool),
+		Upload:                repository.NewUploadRepository(pool),
 	}
 }
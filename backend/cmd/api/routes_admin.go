@@ -10,29 +10,84 @@ func registerAdminRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 	// Admin routes
 	admin := v1.Group("/admin")
 	admin.Use(middleware.AuthMiddleware(svcs.Auth))
-	admin.Use(middleware.RequireRole("admin", "moderator"))
+	admin.Use(middleware.RequireAdminAccess())                   // Admins, moderators, and admin sub-role holders; sections below are permission-scoped
 	admin.Use(middleware.RequireMFAForAdminMiddleware(svcs.MFA)) // Enforce MFA for admin/moderator actions
 	{
 		// Clip sync (if available)
 		if h.ClipSync != nil {
-			sync := admin.Group("/sync")
+			sync := admin.Group("/sync", middleware.RequirePermission(models.PermissionManageSystem))
 			{
 				sync.POST("/clips", h.ClipSync.TriggerSync)
 				sync.GET("/status", h.ClipSync.GetSyncStatus)
 			}
 		}
 
+		// Clip ingestion rules routing synced clips into lists, feeds and communities
+		adminIngestionRules := admin.Group("/ingestion-rules", middleware.RequirePermission(models.PermissionManageSystem))
+		{
+			adminIngestionRules.GET("", h.ClipIngestionRule.ListRules)
+			adminIngestionRules.POST("", h.ClipIngestionRule.CreateRule)
+			adminIngestionRules.GET("/:id", h.ClipIngestionRule.GetRule)
+			adminIngestionRules.PUT("/:id", h.ClipIngestionRule.UpdateRule)
+			adminIngestionRules.DELETE("/:id", h.ClipIngestionRule.DeleteRule)
+		}
+
+		// Automoderation rules for new comments and clip submissions
+		adminAutomod := admin.Group("/automod/rules", middleware.RequirePermission(models.PermissionModerateContent))
+		{
+			adminAutomod.GET("", h.Automod.ListRules)
+			adminAutomod.POST("", h.Automod.CreateRule)
+			adminAutomod.GET("/:id", h.Automod.GetRule)
+			adminAutomod.PUT("/:id", h.Automod.UpdateRule)
+			adminAutomod.DELETE("/:id", h.Automod.DeleteRule)
+		}
+
+		// Karma rules: points, daily caps and cooldowns per karma event
+		adminKarmaRules := admin.Group("/karma-rules", middleware.RequirePermission(models.PermissionManageSystem))
+		{
+			adminKarmaRules.GET("", h.KarmaRule.ListRules)
+			adminKarmaRules.POST("", h.KarmaRule.CreateRule)
+			adminKarmaRules.GET("/:event_type", h.KarmaRule.GetRule)
+			adminKarmaRules.PUT("/:event_type", h.KarmaRule.UpdateRule)
+			adminKarmaRules.DELETE("/:event_type", h.KarmaRule.DeleteRule)
+		}
+
+		// Fix which broadcasters a clip is credited to
+		admin.PUT("/clips/:id/broadcasters", middleware.RequirePermission(models.PermissionModerateContent), h.ClipBroadcaster.UpdateClipBroadcasters)
+
+		// Translation catalog management
+		adminI18n := admin.Group("/i18n", middleware.RequirePermission(models.PermissionManageSystem))
+		{
+			adminI18n.GET("/locales", h.I18n.ListLocales)
+			adminI18n.PUT("/locales/:locale", h.I18n.UpsertLocale)
+			adminI18n.GET("/locales/:locale/strings", h.I18n.GetLocaleStrings)
+			adminI18n.PUT("/locales/:locale/strings", h.I18n.ImportStrings)
+			adminI18n.DELETE("/locales/:locale/strings/:key", h.I18n.DeleteString)
+			adminI18n.GET("/missing", h.I18n.ListMissingKeys)
+		}
+
 		// Admin tag management
-		adminTags := admin.Group("/tags")
+		adminTags := admin.Group("/tags", middleware.RequirePermission(models.PermissionModerateContent))
 		{
 			adminTags.POST("", h.Tag.CreateTag)
 			adminTags.PUT("/:id", h.Tag.UpdateTag)
 			adminTags.DELETE("/:id", h.Tag.DeleteTag)
 		}
 
+		// Game patch management
+		adminGames := admin.Group("/games", middleware.RequirePermission(models.PermissionModerateContent))
+		{
+			adminGames.POST("/:gameId/patches", h.GamePatch.AdminCreatePatch)
+			adminGames.PATCH("/patches/:id", h.GamePatch.AdminUpdatePatch)
+			adminGames.DELETE("/patches/:id", h.GamePatch.AdminDeletePatch)
+		}
+
+		// Share link abuse controls
+		admin.POST("/share-links/:code/disable", middleware.RequirePermission(models.PermissionModerateContent), h.ShareLink.DisableShareLink)
+
 		// Submission moderation (if available)
 		if h.Submission != nil {
-			adminSubmissions := admin.Group("/submissions")
+			adminSubmissions := admin.Group("/submissions", middleware.RequirePermission(models.PermissionModerateContent))
 			{
 				adminSubmissions.GET("", h.Submission.ListPendingSubmissions)
 				adminSubmissions.GET("/rejection-reasons", h.Submission.GetRejectionReasonTemplates)
@@ -40,30 +95,38 @@ func registerAdminRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 				adminSubmissions.POST("/:id/reject", h.Submission.RejectSubmission)
 				adminSubmissions.POST("/bulk-approve", h.Submission.BulkApproveSubmissions)
 				adminSubmissions.POST("/bulk-reject", h.Submission.BulkRejectSubmissions)
+				adminSubmissions.GET("/bulk-jobs/:id", h.Submission.GetBulkModerationJob)
 			}
 		}
 
 		// Audit log routes
-		auditLogs := admin.Group("/audit-logs")
+		auditLogs := admin.Group("/audit-logs", middleware.RequireAnyPermission(models.PermissionModerateContent, models.PermissionManageSystem))
 		{
 			auditLogs.GET("", h.AuditLog.ListAuditLogs)
 			auditLogs.GET("/export", h.AuditLog.ExportAuditLogs)
 		}
 
 		// Report management
-		adminReports := admin.Group("/reports")
+		adminReports := admin.Group("/reports", middleware.RequirePermission(models.PermissionModerateContent))
 		{
 			adminReports.GET("", h.Report.ListReports)
+			adminReports.GET("/queue", h.Report.ListReportQueue)
+			adminReports.GET("/groups/:id", h.Report.GetReportGroup)
+			adminReports.POST("/groups/:id/resolve", h.Report.ResolveReportGroup)
 			adminReports.GET("/:id", h.Report.GetReport)
 			adminReports.PUT("/:id", h.Report.UpdateReport)
 		}
 
-		// User management (admin only - requires PermissionManageUsers)
+		// User management (lookups and bans are shared with support and trust & safety)
 		adminUsers := admin.Group("/users")
 		{
-			adminUsers.GET("", middleware.RequirePermission(models.PermissionManageUsers), h.AdminUser.ListUsers)
-			adminUsers.POST("/:id/ban", middleware.RequirePermission(models.PermissionManageUsers), h.AdminUser.BanUser)
-			adminUsers.POST("/:id/unban", middleware.RequirePermission(models.PermissionManageUsers), h.AdminUser.UnbanUser)
+			adminUsers.GET("", middleware.RequirePermission(models.PermissionViewUsers), h.AdminUser.ListUsers)
+			adminUsers.POST("/:id/ban", middleware.RequirePermission(models.PermissionManageBans), h.AdminUser.BanUser)
+			adminUsers.POST("/:id/unban", middleware.RequirePermission(models.PermissionManageBans), h.AdminUser.UnbanUser)
+			adminUsers.GET("/:id/bans", middleware.RequirePermission(models.PermissionManageBans), h.AdminUser.ListUserBans)
+			adminUsers.POST("/:id/shadow-ban", middleware.RequirePermission(models.PermissionManageBans), h.AdminUser.ShadowBanUser)
+			adminUsers.POST("/:id/lift-shadow-ban", middleware.RequirePermission(models.PermissionManageBans), h.AdminUser.LiftShadowBan)
+			adminUsers.GET("/:id/shadow-bans", middleware.RequirePermission(models.PermissionManageBans), h.AdminUser.ListShadowBans)
 			adminUsers.PATCH("/:id/role", middleware.RequirePermission(models.PermissionManageUsers), h.AdminUser.UpdateUserRole)
 			adminUsers.PATCH("/:id/karma", middleware.RequirePermission(models.PermissionManageUsers), h.AdminUser.UpdateUserKarma)
 			adminUsers.POST("/:id/badges", middleware.RequirePermission(models.PermissionManageUsers), h.Reputation.AwardBadge)
@@ -73,6 +136,9 @@ func registerAdminRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 			adminUsers.POST("/:id/lift-comment-suspension", middleware.RequirePermission(models.PermissionManageUsers), h.AdminUser.LiftCommentSuspension)
 			adminUsers.GET("/:id/comment-suspension-history", middleware.RequirePermission(models.PermissionManageUsers), h.AdminUser.GetCommentSuspensionHistory)
 			adminUsers.POST("/:id/toggle-comment-review", middleware.RequirePermission(models.PermissionManageUsers), h.AdminUser.ToggleCommentReview)
+			// Admin sub-role assignment and support impersonation
+			adminUsers.PUT("/:id/admin-roles", middleware.RequirePermission(models.PermissionManageAdminRoles), h.AdminUser.UpdateAdminRoles)
+			adminUsers.POST("/:id/impersonate", middleware.RequirePermission(models.PermissionImpersonateUsers), h.AdminUser.ImpersonateUser)
 		}
 
 		// Account type management (admin only)
@@ -84,7 +150,7 @@ func registerAdminRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 		}
 
 		// Analytics routes (admin only)
-		analytics := admin.Group("/analytics")
+		analytics := admin.Group("/analytics", middleware.RequirePermission(models.PermissionViewAnalyticsDashboard))
 		{
 			analytics.GET("/overview", h.Analytics.GetPlatformOverview)
 			analytics.GET("/content", h.Analytics.GetContentMetrics)
@@ -95,20 +161,56 @@ func registerAdminRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 			analytics.GET("/trending", h.Engagement.GetTrendingMetrics)
 			analytics.GET("/alerts", h.Engagement.CheckAlerts)
 			analytics.GET("/export", h.Engagement.ExportEngagementData)
+			analytics.GET("/email-attribution", h.Engagement.GetEmailAttribution)
 		}
 
-		// Revenue metrics (admin only)
-		admin.GET("/revenue", h.Revenue.GetRevenueMetrics)
+		// Revenue metrics (admin and finance)
+		admin.GET("/revenue", middleware.RequirePermission(models.PermissionViewRevenue), h.Revenue.GetRevenueMetrics)
+
+		// DMCA takedowns, counter-notices and strikes (admins only)
+		adminDMCA := admin.Group("/dmca", middleware.RequirePermission(models.PermissionModerateOverride))
+		{
+			adminDMCA.GET("/dashboard", h.DMCA.GetDashboardStats)
+			adminDMCA.GET("/notices", h.DMCA.ListDMCANotices)
+			adminDMCA.GET("/notices/:id", h.DMCA.GetDMCANotice)
+			adminDMCA.PATCH("/notices/:id/review", h.DMCA.ReviewNotice)
+			adminDMCA.POST("/notices/:id/process", h.DMCA.ProcessTakedown)
+			adminDMCA.GET("/counter-notices", h.DMCA.ListCounterNotices)
+			adminDMCA.GET("/counter-notices/:id", h.DMCA.GetCounterNotice)
+			adminDMCA.POST("/counter-notices/:id/forward", h.DMCA.ForwardCounterNotice)
+			adminDMCA.POST("/counter-notices/:id/reject", h.DMCA.RejectCounterNotice)
+			adminDMCA.POST("/counter-notices/:id/lawsuit", h.DMCA.MarkLawsuitFiled)
+			adminDMCA.POST("/strikes/:id/remove", h.DMCA.RemoveStrike)
+		}
+
+		// Ban evasion review (shared with trust & safety)
+		adminBanEvasion := admin.Group("/ban-evasion", middleware.RequirePermission(models.PermissionManageBans))
+		{
+			adminBanEvasion.GET("/flags", h.BanEvasion.ListFlags)
+			adminBanEvasion.GET("/flags/:id", h.BanEvasion.GetFlag)
+			adminBanEvasion.POST("/flags/:id/confirm", h.BanEvasion.ConfirmFlag)
+			adminBanEvasion.POST("/flags/:id/dismiss", h.BanEvasion.DismissFlag)
+			adminBanEvasion.GET("/users/:id/linked-accounts", h.BanEvasion.ListLinkedAccounts)
+		}
+
+		// Vote ring review (shared with trust & safety)
+		adminVoteRings := admin.Group("/vote-rings", middleware.RequirePermission(models.PermissionManageBans))
+		{
+			adminVoteRings.GET("", h.VoteRing.ListVoteRings)
+			adminVoteRings.GET("/:id", h.VoteRing.GetVoteRing)
+			adminVoteRings.POST("/:id/confirm", h.VoteRing.ConfirmVoteRing)
+			adminVoteRings.POST("/:id/dismiss", h.VoteRing.DismissVoteRing)
+		}
 
-		// Contact message management (admin only)
-		adminContact := admin.Group("/contact")
+		// Contact message management (admin and support)
+		adminContact := admin.Group("/contact", middleware.RequirePermission(models.PermissionManageContact))
 		{
 			adminContact.GET("", h.Contact.GetContactMessages)
 			adminContact.PUT("/:id/status", h.Contact.UpdateContactMessageStatus)
 		}
 
-		// Ad Campaign management (admin only)
-		adminAds := admin.Group("/ads")
+		// Ad Campaign management (admin and finance)
+		adminAds := admin.Group("/ads", middleware.RequirePermission(models.PermissionManageBilling))
 		{
 			// Campaign CRUD
 			adminAds.GET("/campaigns", h.Ad.ListCampaigns)
@@ -131,17 +233,36 @@ func registerAdminRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 			adminAds.GET("/experiments/:id/report", h.Ad.GetExperimentReport)
 		}
 
+		// Uptime history and SLA attainment of dependencies and endpoint groups
+		adminHealth := admin.Group("/health", middleware.RequirePermission(models.PermissionManageSystem))
+		{
+			adminHealth.GET("/uptime", h.Monitoring.GetUptime)
+			adminHealth.GET("/sla", h.Monitoring.GetSLAReport)
+		}
+
+		// Break-glass read-only maintenance mode
+		adminMaintenance := admin.Group("/maintenance", middleware.RequirePermission(models.PermissionManageSystem))
+		{
+			adminMaintenance.GET("", h.Maintenance.GetStatus)
+			adminMaintenance.PUT("", h.Maintenance.UpdateStatus)
+		}
+
 		// Email monitoring and metrics (admin only)
-		adminEmail := admin.Group("/email")
+		adminEmail := admin.Group("/email", middleware.RequirePermission(models.PermissionManageSystem))
 		{
 			// Dashboard and metrics
 			adminEmail.GET("/metrics/dashboard", h.EmailMetrics.GetDashboardMetrics)
 			adminEmail.GET("/metrics", h.EmailMetrics.GetMetrics)
 			adminEmail.GET("/metrics/templates", h.EmailMetrics.GetTemplateMetrics)
+			adminEmail.GET("/metrics/campaigns", h.EmailMetrics.GetCampaignMetrics)
 
 			// Email logs
 			adminEmail.GET("/logs", h.EmailMetrics.SearchEmailLogs)
 
+			// Bulk email throttle
+			adminEmail.GET("/throttle", h.EmailMetrics.GetThrottle)
+			adminEmail.PUT("/throttle", h.EmailMetrics.UpdateThrottle)
+
 			// Alerts
 			adminEmail.GET("/alerts", h.EmailMetrics.GetAlerts)
 			adminEmail.POST("/alerts/:id/acknowledge", h.EmailMetrics.AcknowledgeAlert)
@@ -150,13 +271,15 @@ func registerAdminRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 
 		// Moderation queue management (admin/moderator only)
 		if h.Moderation != nil {
-			moderation := admin.Group("/moderation")
+			moderation := admin.Group("/moderation", middleware.RequirePermission(models.PermissionModerateContent))
 			{
 				// Event management (existing)
 				moderation.GET("/events", h.Moderation.GetPendingEvents)
 				moderation.GET("/events/:type", h.Moderation.GetEventsByType)
 				moderation.POST("/events/:id/review", h.Moderation.MarkEventReviewed)
 				moderation.POST("/events/:id/process", h.Moderation.ProcessEvent)
+				moderation.POST("/events/:id/claim", h.Moderation.ClaimEvent)
+				moderation.DELETE("/events/:id/claim", h.Moderation.ReleaseEventClaim)
 				moderation.GET("/stats", h.Moderation.GetEventStats)
 
 				// Abuse detection (existing)
@@ -169,8 +292,16 @@ func registerAdminRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 				moderation.POST("/bulk", h.Moderation.BulkModerate)
 				moderation.GET("/queue/stats", h.Moderation.GetModerationStats)
 
+				// Claims on events and pending submissions, and moderator workload
+				moderation.POST("/submissions/:id/claim", h.Moderation.ClaimSubmission)
+				moderation.DELETE("/submissions/:id/claim", h.Moderation.ReleaseSubmissionClaim)
+				moderation.GET("/claims", h.Moderation.ListClaims)
+				moderation.GET("/workload", h.Moderation.GetModerationWorkload)
+
 				// Appeals management (admin)
 				moderation.GET("/appeals", h.Moderation.GetAppeals)
+				moderation.GET("/appeals/sla", h.Moderation.GetAppealSLA)
+				moderation.POST("/appeals/:id/assign", h.Moderation.AssignAppeal)
 				moderation.POST("/appeals/:id/resolve", h.Moderation.ResolveAppeal)
 
 				// Audit logs and analytics
@@ -179,11 +310,23 @@ func registerAdminRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 
 				// Toxicity classification metrics
 				moderation.GET("/toxicity/metrics", h.Moderation.GetToxicityMetrics)
+
+				// Similar past decisions for the case under review
+				moderation.GET("/similar-cases", h.SimilarCases.GetSimilarCases)
+
+				// Shift schedules and end-of-shift handoff reports
+				moderation.GET("/handoff-report", h.ModerationShift.GetHandoffReport)
+				moderation.GET("/shifts", h.ModerationShift.ListShifts)
+				moderation.POST("/shifts", middleware.RequirePermission(models.PermissionManageSystem), h.ModerationShift.CreateShift)
+				moderation.GET("/shifts/:id", h.ModerationShift.GetShift)
+				moderation.PATCH("/shifts/:id", middleware.RequirePermission(models.PermissionManageSystem), h.ModerationShift.UpdateShift)
+				moderation.DELETE("/shifts/:id", middleware.RequirePermission(models.PermissionManageSystem), h.ModerationShift.DeleteShift)
+				moderation.GET("/shifts/:id/report", h.ModerationShift.GetShiftReport)
 			}
 		}
 
 		// NSFW detection routes (admin only)
-		nsfw := admin.Group("/nsfw")
+		nsfw := admin.Group("/nsfw", middleware.RequirePermission(models.PermissionModerateContent))
 		{
 			nsfw.POST("/detect", h.NSFW.DetectImage)
 			nsfw.POST("/batch-detect", h.NSFW.BatchDetect)
@@ -194,7 +337,7 @@ func registerAdminRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 		}
 
 		// Creator verification management (admin only)
-		adminVerification := admin.Group("/verification")
+		adminVerification := admin.Group("/verification", middleware.RequirePermission(models.PermissionManageUsers))
 		{
 			adminVerification.GET("/applications", h.Verification.ListApplications)
 			adminVerification.GET("/applications/:id", h.Verification.GetApplicationByID)
@@ -205,7 +348,7 @@ func registerAdminRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 		}
 
 		// Discovery list management (admin/moderator only)
-		adminDiscoveryLists := admin.Group("/discovery-lists")
+		adminDiscoveryLists := admin.Group("/discovery-lists", middleware.RequirePermission(models.PermissionCreateDiscoveryLists))
 		{
 			adminDiscoveryLists.GET("", h.DiscoveryList.AdminListDiscoveryLists)
 			adminDiscoveryLists.POST("", h.DiscoveryList.AdminCreateDiscoveryList)
@@ -216,10 +359,17 @@ func registerAdminRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 			adminDiscoveryLists.POST("/:id/clips", h.DiscoveryList.AdminAddClipToList)
 			adminDiscoveryLists.DELETE("/:id/clips/:clipId", h.DiscoveryList.AdminRemoveClipFromList)
 			adminDiscoveryLists.PUT("/:id/clips/reorder", h.DiscoveryList.AdminReorderListClips)
+			adminDiscoveryLists.POST("/:id/community-picks", h.CommunityPick.AdminCreateRound)
+		}
+
+		// Community pick round management (admin/moderator only)
+		adminCommunityPicks := admin.Group("/community-picks", middleware.RequirePermission(models.PermissionCreateDiscoveryLists))
+		{
+			adminCommunityPicks.POST("/:id/cancel", h.CommunityPick.AdminCancelRound)
 		}
 
 		// Playlist script management (admin/moderator only)
-		adminPlaylistScripts := admin.Group("/playlist-scripts")
+		adminPlaylistScripts := admin.Group("/playlist-scripts", middleware.RequirePermission(models.PermissionCreateDiscoveryLists))
 		{
 			adminPlaylistScripts.GET("", h.PlaylistScript.ListScripts)
 			adminPlaylistScripts.POST("", h.PlaylistScript.CreateScript)
@@ -228,8 +378,51 @@ func registerAdminRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 			adminPlaylistScripts.POST("/:id/generate", h.PlaylistScript.GeneratePlaylist)
 		}
 
+		// Feature flag management (admin only)
+		adminFeatureFlags := admin.Group("/feature-flags", middleware.RequirePermission(models.PermissionManageSystem))
+		{
+			adminFeatureFlags.GET("", h.FeatureFlag.AdminListFlags)
+			adminFeatureFlags.POST("", h.FeatureFlag.AdminCreateFlag)
+			adminFeatureFlags.GET("/:key", h.FeatureFlag.AdminGetFlag)
+			adminFeatureFlags.PATCH("/:key", h.FeatureFlag.AdminUpdateFlag)
+			adminFeatureFlags.DELETE("/:key", h.FeatureFlag.AdminDeleteFlag)
+			adminFeatureFlags.POST("/:key/evaluate", h.FeatureFlag.AdminEvaluateFlag)
+		}
+
+		// Diagnostic request capture for debugging production issues (admin only)
+		adminCaptures := admin.Group("/diagnostics/captures", middleware.RequirePermission(models.PermissionManageSystem))
+		{
+			adminCaptures.GET("", h.RequestCapture.ListSessions)
+			adminCaptures.POST("", h.RequestCapture.StartSession)
+			adminCaptures.GET("/:id", h.RequestCapture.ListCaptures)
+			adminCaptures.POST("/:id/stop", h.RequestCapture.StopSession)
+		}
+
+		// Clip embed domain rules and partner embed reports (admin only)
+		adminEmbeds := admin.Group("/embeds", middleware.RequirePermission(models.PermissionManageSystem))
+		{
+			adminEmbeds.GET("/domains", h.ClipEmbed.ListDomainRules)
+			adminEmbeds.POST("/domains", h.ClipEmbed.SetDomainRule)
+			adminEmbeds.DELETE("/domains/:id", h.ClipEmbed.DeleteDomainRule)
+			adminEmbeds.GET("/report", h.ClipEmbed.GetPartnerReport)
+		}
+
+		// Search synonym management (admin only)
+		adminSynonyms := admin.Group("/search/synonyms", middleware.RequirePermission(models.PermissionManageSystem))
+		{
+			adminSynonyms.GET("", h.SearchSynonym.AdminListSynonyms)
+			adminSynonyms.POST("", h.SearchSynonym.AdminCreateSynonym)
+			adminSynonyms.GET("/rules", h.SearchSynonym.AdminGetRules)
+			adminSynonyms.GET("/:id", h.SearchSynonym.AdminGetSynonym)
+			adminSynonyms.PATCH("/:id", h.SearchSynonym.AdminUpdateSynonym)
+			adminSynonyms.DELETE("/:id", h.SearchSynonym.AdminDeleteSynonym)
+		}
+
+		// Search analytics dashboard (admin only)
+		admin.GET("/search/analytics", middleware.RequirePermission(models.PermissionViewAnalyticsDashboard), h.SearchAnalytics.AdminGetAnalytics)
+
 		// Forum moderation management (admin/moderator only)
-		adminForum := admin.Group("/forum")
+		adminForum := admin.Group("/forum", middleware.RequirePermission(models.PermissionModerateContent))
 		{
 			adminForum.GET("/flagged", h.ForumModeration.GetFlaggedContent)
 			adminForum.POST("/threads/:id/lock", h.ForumModeration.LockThread)
@@ -241,7 +434,7 @@ func registerAdminRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 		}
 
 		// Webhook dead-letter queue management (admin only)
-		webhookDLQ := admin.Group("/webhooks")
+		webhookDLQ := admin.Group("/webhooks", middleware.RequirePermission(models.PermissionManageSystem))
 		{
 			webhookDLQ.GET("/dlq", h.WebhookDLQ.GetDeadLetterQueue)
 			webhookDLQ.POST("/dlq/:id/replay", h.WebhookDLQ.ReplayDeadLetterQueueItem)
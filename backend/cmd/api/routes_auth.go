@@ -27,6 +27,15 @@ func registerAuthRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 		auth.GET("/me", middleware.AuthMiddleware(svcs.Auth), h.Auth.GetCurrentUser)
 		auth.POST("/twitch/reauthorize", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 3, time.Hour), h.Auth.ReauthorizeTwitch)
 
+		// Additional login providers (Google, Discord) and account linking
+		auth.GET("/providers", h.Auth.ListOAuthProviders)
+		auth.GET("/identities", middleware.AuthMiddleware(svcs.Auth), h.Auth.ListIdentities)
+		auth.DELETE("/identities/:provider", middleware.AuthMiddleware(svcs.Auth), h.Auth.UnlinkIdentity)
+		auth.GET("/:provider", middleware.RateLimitMiddleware(infra.Redis, 30, time.Minute), h.Auth.InitiateProviderOAuth)
+		auth.GET("/:provider/callback", middleware.RateLimitMiddleware(infra.Redis, 50, time.Minute), middleware.OptionalAuthMiddleware(svcs.Auth), h.Auth.HandleProviderCallback)
+		auth.POST("/:provider/callback", middleware.RateLimitMiddleware(infra.Redis, 50, time.Minute), h.Auth.HandleProviderPKCECallback)
+		auth.POST("/:provider/link", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 10, time.Hour), h.Auth.LinkProvider)
+
 		// MFA routes (protected)
 		mfa := auth.Group("/mfa")
 		mfa.Use(middleware.AuthMiddleware(svcs.Auth))
@@ -35,6 +44,9 @@ func registerAuthRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 			mfa.POST("/enroll", middleware.RateLimitMiddleware(infra.Redis, 3, time.Hour), h.MFA.StartEnrollment)
 			mfa.POST("/verify-enrollment", middleware.RateLimitMiddleware(infra.Redis, 10, time.Minute), h.MFA.VerifyEnrollment)
 
+			// Second factor for a session that signed in with its first factor
+			mfa.POST("/verify-login", middleware.RateLimitMiddleware(infra.Redis, 10, time.Minute), h.MFA.VerifyLogin)
+
 			// MFA status
 			mfa.GET("/status", h.MFA.GetStatus)
 
@@ -47,7 +59,18 @@ func registerAuthRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 			mfa.DELETE("/trusted-devices/:id", h.MFA.RevokeTrustedDevice)
 		}
 
-		// MFA login verification (special case - uses different middleware)
-		auth.POST("/mfa/verify-login", middleware.RateLimitMiddleware(infra.Redis, 10, time.Minute), h.MFA.VerifyLogin)
+		// MFA recovery when both the authenticator and backup codes are lost. Public:
+		// a locked-out user cannot pass the second factor, so the emailed token
+		// identifies the account instead of a session.
+		auth.POST("/mfa/recovery/request", middleware.RateLimitMiddleware(infra.Redis, 3, time.Hour), h.MFA.RequestRecovery)
+		auth.POST("/mfa/recovery/complete", middleware.RateLimitMiddleware(infra.Redis, 5, time.Hour), h.MFA.CompleteRecovery)
+
+		// TOTP two-factor aliases
+		twoFactor := auth.Group("/2fa")
+		twoFactor.Use(middleware.AuthMiddleware(svcs.Auth))
+		{
+			twoFactor.POST("/setup", middleware.RateLimitMiddleware(infra.Redis, 3, time.Hour), h.MFA.StartEnrollment)
+			twoFactor.POST("/verify", middleware.RateLimitMiddleware(infra.Redis, 10, time.Minute), h.MFA.VerifyEnrollment)
+		}
 	}
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/subculture-collective/clipper/internal/middleware"
+	"github.com/subculture-collective/clipper/internal/models"
 )
 
 func registerClipRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra *Infrastructure) {
@@ -12,21 +13,32 @@ func registerClipRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 	clips := v1.Group("/clips")
 	{
 		// Public clip endpoints
-		clips.GET("", h.Clip.ListClips)
-		clips.GET("/:id", h.Clip.GetClip)
+		clips.GET("", middleware.APIKeyMiddleware(svcs.APIKey, models.APIKeyScopeReadClips), h.Clip.ListClips)
+		clips.GET("/:id", middleware.APIKeyMiddleware(svcs.APIKey, models.APIKeyScopeReadClips), h.Clip.GetClip)
 		clips.GET("/:id/related", h.Clip.GetRelatedClips)
 		clips.GET("/:id/processing-status", middleware.RateLimitMiddleware(infra.Redis, 60, time.Minute), h.Clip.GetClipProcessingStatus)
 
+		// Playback fallback chain and player-reported embed outcomes
+		clips.GET("/:id/playback", h.Clip.GetClipPlayback)
+		clips.POST("/:id/playback/events", middleware.RateLimitMiddleware(infra.Redis, 30, time.Minute), h.Clip.ReportClipPlaybackEvent)
+
 		// Batch endpoint for media URLs (public, rate limited)
 		clips.POST("/batch-media", middleware.RateLimitMiddleware(infra.Redis, 60, time.Minute), h.Clip.BatchGetClipMedia)
 
 		// Clip tags (public)
 		clips.GET("/:id/tags", h.Tag.GetClipTags)
 
+		// Primary and featured broadcasters credited on the clip
+		clips.GET("/:id/broadcasters", h.ClipBroadcaster.GetClipBroadcasters)
+
 		// Clip analytics (public)
 		clips.GET("/:id/analytics", h.Analytics.GetClipAnalytics)
 		clips.POST("/:id/track-view", h.Analytics.TrackClipView)
 
+		// Share links (optional authentication, rate limited)
+		clips.POST("/:id/share", middleware.OptionalAuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 30, time.Minute), h.ShareLink.CreateShareLink)
+		clips.GET("/:id/share-analytics", h.ShareLink.GetClipShareAnalytics)
+
 		// Clip engagement score (public)
 		clips.GET("/:id/engagement", h.Engagement.GetContentEngagementScore)
 
@@ -35,10 +47,15 @@ func registerClipRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 
 		// List comments for a clip (public or authenticated)
 		clips.GET("/:id/comments", h.Comment.ListComments)
+		clips.GET("/:id/comments/stream", middleware.OptionalAuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 30, time.Minute), h.Comment.StreamComments)
 
 		// Create comment (authenticated, rate limited)
 		clips.POST("/:id/comments", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 10, time.Minute), h.Comment.CreateComment)
 
+		// Pin comments to the top of a clip (broadcaster, creator or moderators)
+		clips.POST("/:id/comments/:commentId/pin", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 30, time.Minute), h.Comment.PinComment)
+		clips.DELETE("/:id/comments/:commentId/pin", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 30, time.Minute), h.Comment.UnpinComment)
+
 		// Protected clip endpoints (require authentication)
 		clips.POST("/:id/vote", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 20, time.Minute), h.Clip.VoteOnClip)
 		clips.POST("/:id/favorite", middleware.AuthMiddleware(svcs.Auth), h.Clip.AddFavorite)
@@ -53,6 +70,10 @@ func registerClipRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 		clips.PUT("/:id/metadata", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 10, time.Minute), h.Clip.UpdateClipMetadata)
 		clips.PUT("/:id/visibility", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 10, time.Minute), h.Clip.UpdateClipVisibility)
 
+		// Accessibility metadata (public read, creator or moderator write)
+		clips.GET("/:id/accessibility", h.Clip.GetClipAccessibility)
+		clips.PUT("/:id/accessibility", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 10, time.Minute), h.Clip.UpdateClipAccessibility)
+
 		// User clip submission with rate limiting (10 per hour) - if Twitch client is available
 		if h.ClipSync != nil {
 			clips.POST("/request", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 10, time.Hour), h.ClipSync.RequestClip)
@@ -79,7 +100,10 @@ func registerClipRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 		// Protected comment endpoints
 		comments.PUT("/:id", middleware.AuthMiddleware(svcs.Auth), h.Comment.UpdateComment)
 		comments.DELETE("/:id", middleware.AuthMiddleware(svcs.Auth), h.Comment.DeleteComment)
+		comments.GET("/:id/history", middleware.AuthMiddleware(svcs.Auth), h.Comment.GetCommentHistory)
 		comments.POST("/:id/vote", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 20, time.Minute), h.Comment.VoteOnComment)
+		comments.POST("/:id/reactions", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 30, time.Minute), h.Comment.ReactToComment)
+		comments.DELETE("/:id/reactions", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 30, time.Minute), h.Comment.RemoveCommentReaction)
 	}
 
 	// Favorite routes
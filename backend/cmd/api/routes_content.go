@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/subculture-collective/clipper/internal/middleware"
+	"github.com/subculture-collective/clipper/internal/models"
 )
 
 func registerContentRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra *Infrastructure) {
@@ -16,13 +17,15 @@ func registerContentRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, inf
 		tags.GET("/search", middleware.RateLimitMiddleware(infra.Redis, 60, time.Minute), h.Tag.SearchTags)
 		tags.GET("/:slug", h.Tag.GetTag)
 		tags.GET("/:slug/clips", h.Tag.GetClipsByTag)
+		tags.GET("/:slug/clips.rss", h.Syndication.GetTagRSS)
+		tags.GET("/:slug/related", h.Tag.GetRelatedTags)
 	}
 
 	// Search routes
 	search := v1.Group("/search")
 	{
 		// Public search endpoints with rate limiting (60 requests/minute = 1 per second)
-		search.GET("", middleware.RateLimitMiddleware(infra.Redis, 60, time.Minute), h.Search.Search)
+		search.GET("", middleware.OptionalAuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 60, time.Minute), h.Search.Search)
 		search.GET("/suggestions", middleware.RateLimitMiddleware(infra.Redis, 60, time.Minute), h.Search.GetSuggestions)
 		search.GET("/scores", middleware.RateLimitMiddleware(infra.Redis, 60, time.Minute), h.Search.SearchWithScores) // Hybrid search with similarity scores
 
@@ -30,6 +33,9 @@ func registerContentRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, inf
 		search.GET("/trending", middleware.RateLimitMiddleware(infra.Redis, 30, time.Minute), h.Search.GetTrendingSearches) // Popular searches (public)
 		search.GET("/history", middleware.AuthMiddleware(svcs.Auth), h.Search.GetSearchHistory)                              // User search history (authenticated)
 
+		// Result clicks of tracked searches, for click-through rates (public)
+		search.POST("/clicks", middleware.OptionalAuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 120, time.Minute), h.SearchAnalytics.RecordClick)
+
 		// Admin-only analytics endpoints
 		searchAdmin := search.Group("")
 		searchAdmin.Use(middleware.AuthMiddleware(svcs.Auth))
@@ -43,6 +49,7 @@ func registerContentRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, inf
 	// Submission routes (if submission handler is available)
 	if h.Submission != nil {
 		submissions := v1.Group("/submissions")
+		submissions.Use(middleware.APIKeyMiddleware(svcs.APIKey, models.APIKeyScopeSubmitClips))
 		submissions.Use(middleware.AuthMiddleware(svcs.Auth))
 		{
 			// User submission endpoints (10 submissions per hour per user)
@@ -65,6 +72,12 @@ func registerContentRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, inf
 
 	// Moderation appeal routes (user-facing)
 	if h.Moderation != nil {
+		appeals := v1.Group("/appeals")
+		{
+			appeals.POST("", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 5, time.Hour), h.Moderation.CreateAppeal)
+			appeals.GET("", middleware.AuthMiddleware(svcs.Auth), h.Moderation.GetUserAppeals)
+		}
+
 		moderationAppeals := v1.Group("/moderation")
 		{
 			moderationAppeals.POST("/appeals", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 5, time.Hour), h.Moderation.CreateAppeal)
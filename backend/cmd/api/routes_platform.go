@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/subculture-collective/clipper/internal/middleware"
+	"github.com/subculture-collective/clipper/internal/models"
 )
 
 func registerPlatformRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra *Infrastructure) {
@@ -51,6 +52,8 @@ func registerPlatformRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, in
 		games.GET("/trending", h.Game.GetTrendingGames)
 		games.GET("/:gameId", middleware.OptionalAuthMiddleware(svcs.Auth), h.Game.GetGame)
 		games.GET("/:gameId/clips", h.Game.ListGameClips)
+		games.GET("/:gameId/patches", h.GamePatch.ListPatches)
+		games.GET("/:gameId/patches/current", h.GamePatch.GetCurrentPatch)
 
 		// Protected game endpoints (require authentication)
 		games.POST("/:gameId/follow", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 20, time.Minute), h.Game.FollowGame)
@@ -70,6 +73,21 @@ func registerPlatformRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, in
 		discoveryLists.DELETE("/:id/follow", middleware.AuthMiddleware(svcs.Auth), h.DiscoveryList.UnfollowDiscoveryList)
 		discoveryLists.POST("/:id/bookmark", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 20, time.Minute), h.DiscoveryList.BookmarkDiscoveryList)
 		discoveryLists.DELETE("/:id/bookmark", middleware.AuthMiddleware(svcs.Auth), h.DiscoveryList.UnbookmarkDiscoveryList)
+
+		// Community pick rounds for a list, including the results archive
+		discoveryLists.GET("/:id/community-picks", h.CommunityPick.ListRounds)
+	}
+
+	// Community pick routes (nominate and vote on clips for a themed list)
+	communityPicks := v1.Group("/community-picks")
+	{
+		communityPicks.GET("/:id", middleware.OptionalAuthMiddleware(svcs.Auth), h.CommunityPick.GetRound)
+		communityPicks.GET("/:id/results", h.CommunityPick.GetResults)
+
+		// Protected community pick endpoints (require authentication)
+		communityPicks.POST("/:id/nominations", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 10, time.Minute), h.CommunityPick.Nominate)
+		communityPicks.POST("/:id/nominations/:nominationId/vote", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 30, time.Minute), h.CommunityPick.Vote)
+		communityPicks.DELETE("/:id/nominations/:nominationId/vote", middleware.AuthMiddleware(svcs.Auth), h.CommunityPick.Unvote)
 	}
 
 	// Leaderboard routes
@@ -95,6 +113,15 @@ func registerPlatformRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, in
 		// Following feed (authenticated)
 		feeds.GET("/following", middleware.AuthMiddleware(svcs.Auth), h.Feed.GetFollowingFeed)
 
+		// Social feed: what followed users did (authenticated)
+		feeds.GET("/social", middleware.AuthMiddleware(svcs.Auth), h.Feed.GetSocialFeed)
+
+		// Feeds the current user is invited to co-curate (authenticated)
+		feeds.GET("/collaborations", middleware.AuthMiddleware(svcs.Auth), h.Feed.ListFeedCollaborations)
+
+		// RSS/Atom feed of a public custom feed
+		feeds.GET("/:id/rss", h.Syndication.GetFeedRSS)
+
 		// Feed analytics routes (admin only)
 		feeds.GET("/analytics", middleware.AuthMiddleware(svcs.Auth), middleware.RequireRole("admin"), h.Event.GetFeedMetrics)
 		feeds.GET("/analytics/hourly", middleware.AuthMiddleware(svcs.Auth), middleware.RequireRole("admin"), h.Event.GetHourlyMetrics)
@@ -108,10 +135,16 @@ func registerPlatformRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, in
 		v1.GET("/feed/live", middleware.AuthMiddleware(svcs.Auth), h.LiveStatus.GetFollowedLiveBroadcasters)
 	}
 
+	// Upcoming scheduled streams from followed broadcasters (authenticated)
+	v1.GET("/feed/upcoming", middleware.AuthMiddleware(svcs.Auth), h.BroadcasterSchedule.GetUpcomingFeed)
+
 	// Recommendation routes
 	recommendations := v1.Group("/recommendations")
 	{
-		// All recommendation endpoints require authentication
+		// Homepage feed: personalized when signed in, trending for visitors
+		recommendations.GET("", middleware.OptionalAuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 60, time.Minute), h.Recommendation.GetHomepageRecommendations)
+
+		// All other recommendation endpoints require authentication
 		recommendations.Use(middleware.AuthMiddleware(svcs.Auth))
 
 		// Get personalized clip recommendations
@@ -148,6 +181,9 @@ func registerPlatformRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, in
 		// Get unread count
 		notifications.GET("/count", h.Notification.GetUnreadCount)
 
+		// Real-time notifications and unread counts over Server-Sent Events
+		notifications.GET("/stream", h.Notification.StreamNotifications)
+
 		// Mark notification as read
 		notifications.PUT("/:id/read", h.Notification.MarkAsRead)
 
@@ -183,6 +219,10 @@ func registerPlatformRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, in
 		v1.POST("/webhooks/stripe", h.Subscription.HandleWebhook)
 		// SendGrid webhook endpoint (public, no auth required, signature verified internally)
 		v1.POST("/webhooks/sendgrid", h.SendGridWebhook.HandleWebhook)
+		// Twitch EventSub endpoint (public, no auth required, signature verified internally)
+		if h.TwitchEventSub != nil {
+			v1.POST("/webhooks/twitch/eventsub", h.TwitchEventSub.HandleWebhook)
+		}
 
 		// Protected subscription endpoints (require authentication)
 		subscriptions.Use(middleware.AuthMiddleware(svcs.Auth))
@@ -202,6 +242,7 @@ func registerPlatformRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, in
 		webhooks.GET("/events", middleware.RateLimitMiddleware(infra.Redis, 60, time.Minute), h.WebhookSubscription.GetSupportedEvents)
 
 		// Protected webhook subscription endpoints (require authentication)
+		webhooks.Use(middleware.APIKeyMiddleware(svcs.APIKey, models.APIKeyScopeManageWebhooks))
 		webhooks.Use(middleware.AuthMiddleware(svcs.Auth))
 
 		// CRUD operations for webhook subscriptions
@@ -218,6 +259,24 @@ func registerPlatformRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, in
 		webhooks.GET("/:id/deliveries", h.WebhookSubscription.GetSubscriptionDeliveries)
 	}
 
+	// Changefeed of public clip changes for data partners
+	changefeed := v1.Group("/changefeed")
+	{
+		changefeed.Use(middleware.APIKeyMiddleware(svcs.APIKey, models.APIKeyScopeReadClips))
+		changefeed.Use(middleware.AuthMiddleware(svcs.Auth))
+
+		changefeed.GET("/clips", h.ClipChangefeed.GetClipChanges)
+	}
+
+	// Developer routes for API key and webhook consumers
+	developer := v1.Group("/developer")
+	{
+		developer.Use(middleware.AuthMiddleware(svcs.Auth))
+
+		// Usage of the caller's API keys and webhooks, bucketed by hour
+		developer.GET("/usage", h.DeveloperUsage.GetUsage)
+	}
+
 	// Contact routes
 	contact := v1.Group("/contact")
 	{
@@ -225,6 +284,14 @@ func registerPlatformRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, in
 		contact.POST("", middleware.RateLimitMiddleware(infra.Redis, 3, time.Hour), h.Contact.SubmitContactMessage)
 	}
 
+	// DMCA takedown notices and counter-notices
+	dmca := v1.Group("/dmca")
+	{
+		dmca.POST("/takedown", middleware.RateLimitMiddleware(infra.Redis, 3, time.Hour), h.DMCA.SubmitTakedownNotice)
+		// Signed-in users can only file counter-notices for their own clips
+		dmca.POST("/counter-notice", middleware.OptionalAuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 3, time.Hour), h.DMCA.SubmitCounterNotice)
+	}
+
 	// Ad routes
 	ads := v1.Group("/ads")
 	{
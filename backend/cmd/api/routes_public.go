@@ -36,6 +36,19 @@ func registerPublicRoutes(r *gin.Engine, v1 *gin.RouterGroup, h *Handlers, svcs
 	})
 	r.GET("/clips/streamer/:broadcasterName/:gameSlug", h.Pages.GetStreamerGamePage)
 
+	// Share short links (redirect with click attribution)
+	r.GET("/s/:code", middleware.RateLimitMiddleware(infra.Redis, 120, time.Minute), h.ShareLink.RedirectShareLink)
+
+	// Embeddable clip player for other sites, and its link back to the clip
+	r.GET("/embed/clips/:id", h.ClipEmbed.GetEmbedPlayer)
+	r.GET("/embed/clips/:id/open", middleware.RateLimitMiddleware(infra.Redis, 120, time.Minute), h.ClipEmbed.OpenEmbeddedClip)
+
+	// Tracked email links (redirect with click logging)
+	r.GET("/r/:token", middleware.RateLimitMiddleware(infra.Redis, 120, time.Minute), h.EmailLink.RedirectEmailLink)
+
+	// Public keys for verifying Clipper-issued JWTs
+	r.GET("/.well-known/jwks.json", h.JWKS.GetJWKS)
+
 	// Health check endpoints (additional checks requiring middleware)
 
 	// Basic health check (used by Docker HEALTHCHECK)
@@ -61,21 +74,20 @@ func registerPublicRoutes(r *gin.Engine, v1 *gin.RouterGroup, h *Handlers, svcs
 			return
 		}
 
-		// Check Redis connection
-		redisErr := infra.Redis.HealthCheck(ctx)
-
-		if redisErr != nil {
-			c.JSON(http.StatusServiceUnavailable, gin.H{
-				"status": "not ready",
-				"error":  "redis unavailable",
-			})
-			return
-		}
-
 		checks := gin.H{
 			"database": "ok",
 			"redis":    "ok",
 		}
+		var degraded []string
+
+		// Check Redis connection. Redis outages degrade the service instead
+		// of taking it out of rotation: caches are bypassed and rate limits
+		// fall back to per-instance in-memory limits.
+		if redisErr := infra.Redis.HealthCheck(ctx); redisErr != nil {
+			checks["redis"] = "degraded"
+			degraded = append(degraded, "cache", "rate_limiting")
+			log.Printf("Redis health check failed (%s mode): %v", infra.Redis.Mode(), redisErr)
+		}
 
 		// Check OpenSearch connection (optional)
 		if infra.OpenSearch != nil {
@@ -89,10 +101,19 @@ func registerPublicRoutes(r *gin.Engine, v1 *gin.RouterGroup, h *Handlers, svcs
 			}
 		}
 
-		c.JSON(http.StatusOK, gin.H{
-			"status": "ready",
-			"checks": checks,
-		})
+		response := gin.H{
+			"status":     "ready",
+			"checks":     checks,
+			"redis_mode": infra.Redis.Mode(),
+		}
+		if len(degraded) > 0 {
+			response["status"] = "degraded"
+			response["degraded"] = degraded
+			if since := infra.Redis.Status().DegradedSince; since != nil {
+				response["degraded_since"] = since
+			}
+		}
+		c.JSON(http.StatusOK, response)
 	})
 
 	// Liveness check - indicates if the application is alive
@@ -162,6 +183,20 @@ func registerPublicRoutes(r *gin.Engine, v1 *gin.RouterGroup, h *Handlers, svcs
 	// Public config endpoint
 	v1.GET("/config", h.Config.GetPublicConfig)
 
+	// Feature flags evaluated for the caller, for gating client UI
+	v1.GET("/feature-flags", middleware.OptionalAuthMiddleware(svcs.Auth), h.FeatureFlag.GetFlags)
+
+	// Generated OpenAPI spec and a Swagger UI page for browsing it
+	v1.GET("/openapi.json", h.OpenAPI.GetSpec)
+	v1.GET("/swagger", h.OpenAPI.GetSwaggerUI)
+
+	// UI string catalog (ETag-cached) and client missing-key reports
+	i18n := v1.Group("/i18n")
+	{
+		i18n.GET("/:locale", h.I18n.GetCatalog)
+		i18n.POST("/:locale/missing", middleware.RateLimitMiddleware(infra.Redis, 30, time.Minute), h.I18n.ReportMissingKeys)
+	}
+
 	// Application logs endpoint (with rate limiting)
 	// Rate limit: 100 requests/minute per endpoint per IP address
 	// Authenticated and anonymous users behind the same IP share this limit
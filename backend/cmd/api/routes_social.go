@@ -83,6 +83,14 @@ func registerSocialRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infr
 		communities.DELETE("/:id/ban/:userId", middleware.AuthMiddleware(svcs.Auth), h.Community.UnbanMember)
 		communities.GET("/:id/bans", middleware.AuthMiddleware(svcs.Auth), h.Community.GetBannedMembers)
 
+		// Weekly digest settings (owner only)
+		communities.GET("/:id/digest-settings", middleware.AuthMiddleware(svcs.Auth), h.CommunityDigest.GetSettings)
+		communities.PUT("/:id/digest-settings", middleware.AuthMiddleware(svcs.Auth), h.CommunityDigest.UpdateSettings)
+
+		// Toxic comment flagging sensitivity (owner and moderators)
+		communities.GET("/:id/toxicity-settings", middleware.AuthMiddleware(svcs.Auth), h.CommunityToxicity.GetSettings)
+		communities.PUT("/:id/toxicity-settings", middleware.AuthMiddleware(svcs.Auth), h.CommunityToxicity.UpdateSettings)
+
 		// Community feed management
 		communities.POST("/:id/clips", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 20, time.Minute), h.Community.AddClipToCommunity)
 		communities.DELETE("/:id/clips/:clipId", middleware.AuthMiddleware(svcs.Auth), h.Community.RemoveClipFromCommunity)
@@ -91,6 +99,13 @@ func registerSocialRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infr
 		communities.POST("/:id/discussions", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 10, time.Minute), h.Community.CreateDiscussion)
 		communities.PUT("/:id/discussions/:discussionId", middleware.AuthMiddleware(svcs.Auth), h.Community.UpdateDiscussion)
 		communities.DELETE("/:id/discussions/:discussionId", middleware.AuthMiddleware(svcs.Auth), h.Community.DeleteDiscussion)
+
+		// Uploaded community assets (admins manage them)
+		if h.Upload != nil {
+			communities.GET("/:id/assets", h.Community.ListAssets)
+			communities.POST("/:id/assets", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 20, time.Minute), h.Community.AddAsset)
+			communities.DELETE("/:id/assets/:uploadId", middleware.AuthMiddleware(svcs.Auth), h.Community.RemoveAsset)
+		}
 	}
 
 	// Playlist routes
@@ -128,6 +143,13 @@ func registerSocialRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infr
 		playlists.POST("/:id/collaborators", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 20, time.Hour), h.Playlist.AddCollaborator)
 		playlists.DELETE("/:id/collaborators/:user_id", middleware.AuthMiddleware(svcs.Auth), h.Playlist.RemoveCollaborator)
 		playlists.PATCH("/:id/collaborators/:user_id", middleware.AuthMiddleware(svcs.Auth), h.Playlist.UpdateCollaboratorPermission)
+
+		// Premium playlist bundles (one-time purchases)
+		playlists.GET("/earnings", middleware.AuthMiddleware(svcs.Auth), h.PlaylistBundle.ListEarnings)
+		playlists.GET("/:id/bundle", middleware.OptionalAuthMiddleware(svcs.Auth), h.PlaylistBundle.GetBundle)
+		playlists.PUT("/:id/bundle", middleware.AuthMiddleware(svcs.Auth), h.PlaylistBundle.SetBundle)
+		playlists.DELETE("/:id/bundle", middleware.AuthMiddleware(svcs.Auth), h.PlaylistBundle.RemoveBundle)
+		playlists.POST("/:id/purchase", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 10, time.Minute), h.PlaylistBundle.Purchase)
 	}
 
 	// User-scoped playlist script routes (smart playlists)
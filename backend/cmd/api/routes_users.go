@@ -16,6 +16,7 @@ func registerUserRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 
 		// User autocomplete for mentions/suggestions - must be before /:id to avoid route conflicts
 		users.GET("/autocomplete", middleware.RateLimitMiddleware(infra.Redis, 100, time.Hour), h.User.SearchUsersAutocomplete)
+		users.GET("/mention-suggestions", middleware.OptionalAuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 300, time.Hour), h.User.GetMentionSuggestions)
 
 		users.GET("/:id", middleware.OptionalAuthMiddleware(svcs.Auth), h.User.GetUserProfile)
 
@@ -26,14 +27,22 @@ func registerUserRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 		users.GET("/:id/reputation", h.Reputation.GetUserReputation)
 		users.GET("/:id/karma", h.Reputation.GetUserKarma)
 		users.GET("/:id/badges", h.Reputation.GetUserBadges)
+		users.GET("/:id/achievements", h.Reputation.GetUserAchievements)
+
+		// Profile view counter; views are counted by the profile page
+		users.POST("/:id/track-view", middleware.OptionalAuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 60, time.Minute), h.ProfileView.TrackProfileView)
+		users.GET("/:id/profile-views", middleware.OptionalAuthMiddleware(svcs.Auth), h.ProfileView.GetProfileViews)
 
 		// User activity endpoints
-		users.GET("/:id/comments", h.User.GetUserComments)
+		users.GET("/:id/comments", middleware.OptionalAuthMiddleware(svcs.Auth), h.User.GetUserComments)
 		users.GET("/:id/clips", middleware.OptionalAuthMiddleware(svcs.Auth), h.User.GetUserClips)
 		users.GET("/:id/activity", middleware.OptionalAuthMiddleware(svcs.Auth), h.User.GetUserActivity)
 		users.GET("/:id/upvoted", h.User.GetUserUpvotedClips)
 		users.GET("/:id/downvoted", h.User.GetUserDownvotedClips)
 
+		// Copyright strikes, visible to the user and to staff
+		users.GET("/:id/dmca-strikes", middleware.AuthMiddleware(svcs.Auth), h.DMCA.GetUserStrikes)
+
 		// User social connections
 		users.GET("/:id/followers", middleware.OptionalAuthMiddleware(svcs.Auth), h.User.GetUserFollowers)
 		users.GET("/:id/following", middleware.OptionalAuthMiddleware(svcs.Auth), h.User.GetUserFollowing)
@@ -46,6 +55,12 @@ func registerUserRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 		users.DELETE("/:id/block", middleware.AuthMiddleware(svcs.Auth), h.User.UnblockUser)
 		users.GET("/me/blocked", middleware.AuthMiddleware(svcs.Auth), h.User.GetBlockedUsers)
 
+		// Own site-wide ban status and expiry
+		users.GET("/me/ban", middleware.AuthMiddleware(svcs.Auth), h.UserBan.GetMyBanStatus)
+
+		// Own reports and their outcomes
+		users.GET("/me/reports", middleware.AuthMiddleware(svcs.Auth), h.Report.ListMyReports)
+
 		// Personal statistics (authenticated)
 		users.GET("/me/stats", middleware.AuthMiddleware(svcs.Auth), h.Analytics.GetUserStats)
 
@@ -55,12 +70,18 @@ func registerUserRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 		// Profile management (authenticated)
 		users.PUT("/me/profile", middleware.AuthMiddleware(svcs.Auth), h.UserSettings.UpdateProfile)
 		users.PUT("/me/social-links", middleware.AuthMiddleware(svcs.Auth), h.UserSettings.UpdateSocialLinks)
+		if h.Upload != nil {
+			users.PUT("/me/avatar", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 10, time.Minute), h.UserSettings.UpdateAvatar)
+		}
 		users.GET("/me/settings", middleware.AuthMiddleware(svcs.Auth), h.UserSettings.GetSettings)
 		users.PUT("/me/settings", middleware.AuthMiddleware(svcs.Auth), h.UserSettings.UpdateSettings)
 
 		// Data export (authenticated, rate limited)
 		users.GET("/me/export", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 1, time.Hour), h.UserSettings.ExportData)
 
+		// Processing on the user's data: emails, exports, analytics, consent (authenticated, rate limited)
+		users.GET("/me/data-activity", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 30, time.Minute), h.DataActivity.GetDataActivity)
+
 		// Cookie consent management (authenticated, rate limited)
 		users.GET("/me/consent", middleware.AuthMiddleware(svcs.Auth), h.Consent.GetConsent)
 		users.POST("/me/consent", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 30, time.Minute), h.Consent.SaveConsent)
@@ -81,6 +102,16 @@ func registerUserRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 		// Discovery list follows for current user (authenticated)
 		users.GET("/me/discovery-list-follows", middleware.AuthMiddleware(svcs.Auth), h.DiscoveryList.GetUserFollowedLists)
 
+		// Personal API keys (JWT only; keys cannot manage other keys)
+		users.GET("/me/api-keys", middleware.AuthMiddleware(svcs.Auth), h.APIKey.ListAPIKeys)
+		users.POST("/me/api-keys", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 10, time.Hour), h.APIKey.CreateAPIKey)
+		users.DELETE("/me/api-keys/:id", middleware.AuthMiddleware(svcs.Auth), h.APIKey.RevokeAPIKey)
+
+		// Signed-in devices (sessions backed by refresh tokens)
+		users.GET("/me/sessions", middleware.AuthMiddleware(svcs.Auth), h.Session.ListSessions)
+		users.DELETE("/me/sessions", middleware.AuthMiddleware(svcs.Auth), h.Session.RevokeOtherSessions)
+		users.DELETE("/me/sessions/:id", middleware.AuthMiddleware(svcs.Auth), h.Session.RevokeSession)
+
 		// Game follows for a user
 		users.GET("/:id/games/following", h.Game.GetFollowedGames)
 		// User feeds routes
@@ -93,6 +124,15 @@ func registerUserRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 		users.POST("/:id/feeds/:feedId/clips", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 20, time.Minute), h.Feed.AddClipToFeed)
 		users.DELETE("/:id/feeds/:feedId/clips/:clipId", middleware.AuthMiddleware(svcs.Auth), h.Feed.RemoveClipFromFeed)
 		users.PUT("/:id/feeds/:feedId/clips/reorder", middleware.AuthMiddleware(svcs.Auth), h.Feed.ReorderFeedClips)
+		users.PATCH("/:id/feeds/:feedId/clips/reorder", middleware.AuthMiddleware(svcs.Auth), h.Feed.MoveFeedClip)
+		users.GET("/:id/feeds/:feedId/rules", middleware.OptionalAuthMiddleware(svcs.Auth), h.Feed.GetSmartFeedRules)
+		users.PUT("/:id/feeds/:feedId/rules", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 20, time.Minute), h.Feed.SetSmartFeedRules)
+		users.DELETE("/:id/feeds/:feedId/rules", middleware.AuthMiddleware(svcs.Auth), h.Feed.DeleteSmartFeedRules)
+		users.GET("/:id/feeds/:feedId/collaborators", middleware.AuthMiddleware(svcs.Auth), h.Feed.ListFeedCollaborators)
+		users.POST("/:id/feeds/:feedId/collaborators", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 20, time.Minute), h.Feed.InviteFeedCollaborator)
+		users.POST("/:id/feeds/:feedId/collaborators/accept", middleware.AuthMiddleware(svcs.Auth), h.Feed.AcceptFeedInvite)
+		users.PUT("/:id/feeds/:feedId/collaborators/:userId", middleware.AuthMiddleware(svcs.Auth), h.Feed.UpdateFeedCollaborator)
+		users.DELETE("/:id/feeds/:feedId/collaborators/:userId", middleware.AuthMiddleware(svcs.Auth), h.Feed.RemoveFeedCollaborator)
 		users.POST("/:id/feeds/:feedId/follow", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 20, time.Minute), h.Feed.FollowFeed)
 		users.DELETE("/:id/feeds/:feedId/follow", middleware.AuthMiddleware(svcs.Auth), h.Feed.UnfollowFeed)
 
@@ -112,6 +152,7 @@ func registerUserRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 		creators.GET("/:creatorName/analytics/clips", h.Analytics.GetCreatorTopClips)
 		creators.GET("/:creatorName/analytics/trends", h.Analytics.GetCreatorTrends)
 		creators.GET("/:creatorName/analytics/audience", h.Analytics.GetCreatorAudienceInsights)
+		creators.GET("/:creatorName/analytics/shares", h.ShareLink.GetCreatorShareAnalytics)
 
 		// Creator clips listing (shows hidden clips if authenticated as creator)
 		creators.GET("/:creatorName/clips", middleware.OptionalAuthMiddleware(svcs.Auth), h.Clip.ListCreatorClips)
@@ -121,6 +162,23 @@ func registerUserRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 		creators.GET("/me/exports", middleware.AuthMiddleware(svcs.Auth), h.Export.ListExportRequests)
 		creators.GET("/me/export/status/:id", middleware.AuthMiddleware(svcs.Auth), h.Export.GetExportStatus)
 		creators.GET("/me/export/download/:id", middleware.AuthMiddleware(svcs.Auth), h.Export.DownloadExport)
+
+		// Claims to a Twitch channel's clips, verified by a code in its description
+		creators.POST("/me/claims", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 10, time.Hour), h.CreatorClaim.CreateClaim)
+		creators.GET("/me/claims", middleware.AuthMiddleware(svcs.Auth), h.CreatorClaim.ListClaims)
+		creators.POST("/me/claims/:id/verify", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 30, time.Hour), h.CreatorClaim.VerifyClaim)
+	}
+
+	// Presigned file uploads, used by avatars, ad creatives, report evidence
+	// and community assets
+	if h.Upload != nil {
+		uploads := v1.Group("/uploads")
+		uploads.Use(middleware.AuthMiddleware(svcs.Auth))
+		{
+			uploads.POST("", middleware.RateLimitMiddleware(infra.Redis, 30, time.Hour), h.Upload.CreateUpload)
+			uploads.GET("/:id", h.Upload.GetUpload)
+			uploads.POST("/:id/complete", middleware.RateLimitMiddleware(infra.Redis, 30, time.Hour), h.Upload.CompleteUpload)
+		}
 	}
 
 	// Broadcaster routes
@@ -140,12 +198,38 @@ func registerUserRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra
 
 		// Public broadcaster clips endpoint
 		broadcasters.GET("/:id/clips", h.Broadcaster.ListBroadcasterClips)
+		broadcasters.GET("/:id/clips.rss", h.Syndication.GetBroadcasterRSS)
 
 		// Live status for specific broadcaster
 		if h.LiveStatus != nil {
 			broadcasters.GET("/:id/live-status", h.LiveStatus.GetBroadcasterLiveStatus)
 		}
 
+		// Stream schedule; stale schedules are pulled from Twitch on request
+		broadcasters.GET("/:id/schedule", middleware.RateLimitMiddleware(infra.Redis, 60, time.Minute), h.BroadcasterSchedule.GetSchedule)
+		broadcasters.GET("/:id/schedule/reminder", middleware.AuthMiddleware(svcs.Auth), h.BroadcasterSchedule.GetReminder)
+		broadcasters.PUT("/:id/schedule/reminder", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 30, time.Minute), h.BroadcasterSchedule.SetReminder)
+		broadcasters.DELETE("/:id/schedule/reminder", middleware.AuthMiddleware(svcs.Auth), h.BroadcasterSchedule.DeleteReminder)
+
+		// Clip approval for verified broadcasters ("me" is matched before /:id)
+		if h.BroadcasterApproval != nil {
+			broadcasters.GET("/me/approval-policy", middleware.AuthMiddleware(svcs.Auth), h.BroadcasterApproval.GetPolicy)
+			broadcasters.PUT("/me/approval-policy", middleware.AuthMiddleware(svcs.Auth), h.BroadcasterApproval.UpdatePolicy)
+			broadcasters.GET("/me/approval-queue", middleware.AuthMiddleware(svcs.Auth), h.BroadcasterApproval.ListQueue)
+			broadcasters.POST("/me/approval-queue/:submissionId/approve", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 60, time.Minute), h.BroadcasterApproval.ApproveClip)
+			broadcasters.POST("/me/approval-queue/:submissionId/reject", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 60, time.Minute), h.BroadcasterApproval.RejectClip)
+		}
+
+		// Embed domain rules and embed traffic for verified broadcasters
+		broadcasters.GET("/me/embed-domains", middleware.AuthMiddleware(svcs.Auth), h.ClipEmbed.ListMyDomainRules)
+		broadcasters.POST("/me/embed-domains", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 30, time.Minute), h.ClipEmbed.SetMyDomainRule)
+		broadcasters.DELETE("/me/embed-domains/:id", middleware.AuthMiddleware(svcs.Auth), h.ClipEmbed.DeleteMyDomainRule)
+		broadcasters.GET("/me/embed-report", middleware.AuthMiddleware(svcs.Auth), h.ClipEmbed.GetMyEmbedReport)
+
+		// Title normalization opt-out for verified broadcasters
+		broadcasters.GET("/me/title-normalization", middleware.AuthMiddleware(svcs.Auth), h.ClipTitle.GetPreference)
+		broadcasters.PUT("/me/title-normalization", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 10, time.Minute), h.ClipTitle.UpdatePreference)
+
 		// Protected broadcaster endpoints (require authentication)
 		broadcasters.POST("/:id/follow", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 20, time.Minute), h.Broadcaster.FollowBroadcaster)
 		broadcasters.DELETE("/:id/follow", middleware.AuthMiddleware(svcs.Auth), h.Broadcaster.UnfollowBroadcaster)
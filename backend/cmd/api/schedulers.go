@@ -1,7 +1,6 @@
 package main
 
 import (
-	"context"
 	"time"
 
 	"github.com/subculture-collective/clipper/internal/scheduler"
@@ -9,76 +8,238 @@ import (
 
 // SchedulerGroup holds all background scheduler instances for graceful shutdown.
 type SchedulerGroup struct {
-	ClipSync        *scheduler.ClipSyncScheduler
-	Reputation      *scheduler.ReputationScheduler
-	HotScore        *scheduler.HotScoreScheduler
-	TrendingScore   *scheduler.TrendingScoreScheduler
-	WebhookRetry    *scheduler.WebhookRetryScheduler
-	OutboundWebhook *scheduler.OutboundWebhookScheduler
-	Embedding       *scheduler.EmbeddingScheduler // may be nil
-	Export          *scheduler.ExportScheduler
-	EmailMetrics    *scheduler.EmailMetricsScheduler
-	LiveStatus      *scheduler.LiveStatusScheduler       // may be nil
-	PlaylistScript  *scheduler.PlaylistScriptScheduler
+	ClipSync            *scheduler.ClipSyncScheduler // may be nil
+	Reputation          *scheduler.ReputationScheduler
+	HotScore            *scheduler.HotScoreScheduler
+	TrendingScore       *scheduler.TrendingScoreScheduler
+	WebhookRetry        *scheduler.WebhookRetryScheduler // may be nil
+	OutboundWebhook     *scheduler.OutboundWebhookScheduler
+	Embedding           *scheduler.EmbeddingScheduler // may be nil
+	Export              *scheduler.ExportScheduler    // may be nil
+	EmailMetrics        *scheduler.EmailMetricsScheduler
+	LiveStatus          *scheduler.LiveStatusScheduler // may be nil
+	EventSub            *scheduler.EventSubScheduler   // may be nil
+	PlaylistScript      *scheduler.PlaylistScriptScheduler
+	JWTKeyRotation      *scheduler.JWTKeyRotationScheduler      // may be nil
+	BroadcasterApproval *scheduler.BroadcasterApprovalScheduler // may be nil
+	NotificationDigest  *scheduler.NotificationDigestScheduler
+	Reengagement        *scheduler.ReengagementScheduler
+	BanExpiry           *scheduler.BanExpiryScheduler
+	CommunityPick       *scheduler.CommunityPickScheduler
+	BroadcasterSchedule *scheduler.BroadcasterScheduleScheduler
+	ClipChangefeed      *scheduler.ClipChangefeedScheduler
+	ModerationShift     *scheduler.ModerationShiftReportScheduler
+	ModerationClaim     *scheduler.ModerationClaimScheduler
+	DMCA                *scheduler.DMCAScheduler
+	BanEvasion          *scheduler.BanEvasionScheduler
+	ReportPriority      *scheduler.ReportPriorityScheduler
+	Leaderboard         *scheduler.LeaderboardScheduler
+	KarmaSafeguard      *scheduler.KarmaSafeguardScheduler
+	SmartFeed           *scheduler.SmartFeedScheduler
+	CommunityDigest     *scheduler.CommunityDigestScheduler
+	ProfileViewRollup   *scheduler.ProfileViewRollupScheduler
+	ClipHype            *scheduler.ClipHypeScheduler
+	HealthHistory       *scheduler.HealthHistoryScheduler
+	TagGraph            *scheduler.TagGraphScheduler
+	UploadCleanup       *scheduler.UploadCleanupScheduler // may be nil
+
+	// Drainer tracks the running schedulers so shutdown can wait for in-flight runs
+	Drainer *scheduler.Drainer
 }
 
 func startSchedulers(svcs *Services, repos *Repositories, infra *Infrastructure) *SchedulerGroup {
 	cfg := infra.Config
-	sg := &SchedulerGroup{}
+	sg := &SchedulerGroup{Drainer: scheduler.NewDrainer()}
+
+	// With the job queue enabled, clip sync, webhook retries, embeddings and
+	// exports run in cmd/worker instead of in this process
+	inProcess := !cfg.Jobs.QueueEnabled
 
 	// Start background scheduler if Twitch client is available
-	if svcs.ClipSync != nil {
+	if inProcess && svcs.ClipSync != nil {
 		// Start scheduler to run every 15 minutes
 		sg.ClipSync = scheduler.NewClipSyncScheduler(svcs.ClipSync, 15)
-		go sg.ClipSync.Start(context.Background())
+		sg.Drainer.Go("clip_sync", sg.ClipSync.Start)
 	}
 
 	// Start reputation scheduler (runs every 6 hours)
 	sg.Reputation = scheduler.NewReputationScheduler(svcs.Reputation, repos.User, 6)
-	go sg.Reputation.Start(context.Background())
+	sg.Drainer.Go("reputation", sg.Reputation.Start)
 
 	// Start hot score scheduler (runs every 5 minutes)
 	sg.HotScore = scheduler.NewHotScoreScheduler(repos.Clip, cfg.Jobs.HotClipsRefreshIntervalMinutes)
-	go sg.HotScore.Start(context.Background())
+	sg.Drainer.Go("hot_score", sg.HotScore.Start)
 
 	// Start trending score scheduler (runs every 60 minutes)
 	sg.TrendingScore = scheduler.NewTrendingScoreScheduler(repos.Clip, 60)
-	go sg.TrendingScore.Start(context.Background())
+	sg.Drainer.Go("trending_score", sg.TrendingScore.Start)
 
 	// Start webhook retry scheduler (runs every 1 minute)
-	sg.WebhookRetry = scheduler.NewWebhookRetryScheduler(svcs.WebhookRetry, cfg.Jobs.WebhookRetryIntervalMinutes, cfg.Jobs.WebhookRetryBatchSize)
-	go sg.WebhookRetry.Start(context.Background())
+	if inProcess {
+		sg.WebhookRetry = scheduler.NewWebhookRetryScheduler(svcs.WebhookRetry, cfg.Jobs.WebhookRetryIntervalMinutes, cfg.Jobs.WebhookRetryBatchSize)
+		sg.Drainer.Go("webhook_retry", sg.WebhookRetry.Start)
+	}
 
 	// Start outbound webhook delivery scheduler (runs every 30 seconds, batch size 50)
 	sg.OutboundWebhook = scheduler.NewOutboundWebhookScheduler(svcs.OutboundWebhook, 30*time.Second, 50)
-	go sg.OutboundWebhook.Start(context.Background())
+	sg.Drainer.Go("outbound_webhook", sg.OutboundWebhook.Start)
 
 	// Start embedding scheduler if embedding service is available (runs based on configured interval)
-	if svcs.Embedding != nil {
+	if inProcess && svcs.Embedding != nil {
 		sg.Embedding = scheduler.NewEmbeddingScheduler(infra.DB, svcs.Embedding, cfg.Embedding.SchedulerIntervalMinutes, cfg.Embedding.Model)
-		go sg.Embedding.Start(context.Background())
+		sg.Embedding.SetColumn(cfg.Embedding.Column)
+		if svcs.NextEmbedding != nil {
+			sg.Embedding.SetDualWrite(svcs.NextEmbedding, cfg.Embedding.NextColumn, cfg.Embedding.NextModel)
+		}
+		if svcs.SearchIndexer != nil && svcs.SearchIndexer.KNNEnabled() {
+			sg.Embedding.SetSearchIndexer(svcs.SearchIndexer)
+		}
+		sg.Drainer.Go("embedding", sg.Embedding.Start)
 	}
 
 	// Start export scheduler (runs every 2 minutes, batch size 10)
-	sg.Export = scheduler.NewExportScheduler(svcs.Export, repos.Export, 2, 10)
-	go sg.Export.Start(context.Background())
+	if inProcess {
+		sg.Export = scheduler.NewExportScheduler(svcs.Export, repos.Export, 2, 10)
+		sg.Drainer.Go("export", sg.Export.Start)
+	}
 
 	// Start email metrics scheduler
 	// - Calculate daily metrics every 24 hours
 	// - Check alerts every 30 minutes
 	// - Cleanup old logs every 7 days
 	sg.EmailMetrics = scheduler.NewEmailMetricsScheduler(svcs.EmailMetrics, 24, 30, 7)
-	go sg.EmailMetrics.Start(context.Background())
+	sg.Drainer.Go("email_metrics", sg.EmailMetrics.Start)
 
-	// Start live status scheduler (runs every 30 seconds if Twitch client is available)
+	// Start live status scheduler (runs every 30 seconds if Twitch client is available).
+	// With EventSub, status changes arrive by webhook and polling only
+	// reconciles events that were missed.
 	if svcs.LiveStatus != nil {
-		sg.LiveStatus = scheduler.NewLiveStatusScheduler(svcs.LiveStatus, repos.Broadcaster, 30)
-		go sg.LiveStatus.Start(context.Background())
+		liveStatusIntervalSeconds := 30
+		if svcs.TwitchEventSub != nil {
+			liveStatusIntervalSeconds = cfg.Twitch.LiveStatusReconcileMinutes * 60
+		}
+		sg.LiveStatus = scheduler.NewLiveStatusScheduler(svcs.LiveStatus, repos.Broadcaster, liveStatusIntervalSeconds)
+		sg.Drainer.Go("live_status", sg.LiveStatus.Start)
+	}
+
+	// Start EventSub subscription scheduler to subscribe to newly followed broadcasters
+	if svcs.TwitchEventSub != nil {
+		sg.EventSub = scheduler.NewEventSubScheduler(svcs.TwitchEventSub, cfg.Twitch.EventSubSyncIntervalMinutes)
+		sg.Drainer.Go("event_sub", sg.EventSub.Start)
 	}
 
 	// Start playlist script scheduler (checks every 5 minutes for due scripts)
 	sg.PlaylistScript = scheduler.NewPlaylistScriptScheduler(svcs.PlaylistScript, 5)
-	go sg.PlaylistScript.Start(context.Background())
+	sg.Drainer.Go("playlist_script", sg.PlaylistScript.Start)
+
+	// Start JWT key rotation scheduler if key rotation is enabled
+	if svcs.JWTKey != nil {
+		sg.JWTKeyRotation = scheduler.NewJWTKeyRotationScheduler(svcs.JWTKey, cfg.JWT.KeySyncIntervalMinutes)
+		sg.Drainer.Go("jwt_key_rotation", sg.JWTKeyRotation.Start)
+	}
+
+	// Start broadcaster approval scheduler to auto-approve clips past their review deadline
+	if svcs.BroadcasterApproval != nil {
+		sg.BroadcasterApproval = scheduler.NewBroadcasterApprovalScheduler(svcs.BroadcasterApproval, cfg.Jobs.BroadcasterApprovalIntervalMinutes)
+		sg.Drainer.Go("broadcaster_approval", sg.BroadcasterApproval.Start)
+	}
+
+	// Start notification digest scheduler to send daily and weekly email digests
+	sg.NotificationDigest = scheduler.NewNotificationDigestScheduler(svcs.NotificationDigest, cfg.Jobs.NotificationDigestIntervalMinutes)
+	sg.Drainer.Go("notification_digest", sg.NotificationDigest.Start)
+
+	// Start re-engagement scheduler to email users who reached an inactivity milestone
+	sg.Reengagement = scheduler.NewReengagementScheduler(svcs.Reengagement, cfg.Jobs.ReengagementIntervalMinutes)
+	sg.Drainer.Go("reengagement", sg.Reengagement.Start)
+
+	// Start ban expiry scheduler to reinstate users whose temporary ban or
+	// shadow ban has ended
+	sg.BanExpiry = scheduler.NewBanExpiryScheduler(svcs.UserBan, cfg.Jobs.BanExpiryIntervalMinutes)
+	sg.BanExpiry.SetShadowBanService(svcs.ShadowBan)
+	sg.Drainer.Go("ban_expiry", sg.BanExpiry.Start)
+
+	// Start community pick scheduler to publish rounds whose voting window has ended
+	sg.CommunityPick = scheduler.NewCommunityPickScheduler(svcs.CommunityPick, cfg.Jobs.CommunityPickIntervalMinutes)
+	sg.Drainer.Go("community_pick", sg.CommunityPick.Start)
+
+	// Start broadcaster schedule scheduler to pull followed broadcasters'
+	// schedules and send reminders before scheduled streams
+	sg.BroadcasterSchedule = scheduler.NewBroadcasterScheduleScheduler(svcs.BroadcasterSchedule, cfg.Twitch.ScheduleSyncIntervalMinutes, cfg.Jobs.ScheduleReminderIntervalMinutes)
+	sg.Drainer.Go("broadcaster_schedule", sg.BroadcasterSchedule.Start)
+
+	// Start clip changefeed scheduler to sequence new clip outbox events and
+	// queue changefeed webhooks
+	sg.ClipChangefeed = scheduler.NewClipChangefeedScheduler(svcs.ClipChangefeed, cfg.Jobs.ChangefeedRelayIntervalSeconds)
+	sg.Drainer.Go("clip_changefeed", sg.ClipChangefeed.Start)
+
+	// Start moderation shift report scheduler to email handoff reports when
+	// shifts end
+	sg.ModerationShift = scheduler.NewModerationShiftReportScheduler(svcs.ModerationShift, cfg.Jobs.ShiftReportIntervalMinutes)
+	sg.Drainer.Go("moderation_shift", sg.ModerationShift.Start)
+
+	// Start moderation claim scheduler to release stale claims so their
+	// items go back to the queue
+	sg.ModerationClaim = scheduler.NewModerationClaimScheduler(svcs.ModerationClaim, cfg.Jobs.ClaimExpiryIntervalMinutes)
+	sg.Drainer.Go("moderation_claim", sg.ModerationClaim.Start)
+
+	// Start DMCA scheduler to reinstate content once counter-notice waiting
+	// periods end without a lawsuit, and to expire old strikes
+	sg.DMCA = scheduler.NewDMCAScheduler(svcs.DMCA, cfg.Jobs.DMCAIntervalMinutes)
+	sg.Drainer.Go("dmca", sg.DMCA.Start)
+
+	// Start ban evasion scheduler to flag new accounts that share sign-in
+	// signals with banned accounts
+	sg.BanEvasion = scheduler.NewBanEvasionScheduler(svcs.BanEvasion, cfg.Jobs.BanEvasionIntervalMinutes)
+	sg.Drainer.Go("ban_evasion", sg.BanEvasion.Start)
+
+	// Start report priority scheduler to rescore open report groups as the
+	// reported content's reach changes
+	sg.ReportPriority = scheduler.NewReportPriorityScheduler(svcs.ReportQueue, cfg.Jobs.ReportPriorityIntervalMinutes)
+	sg.Drainer.Go("report_priority", sg.ReportPriority.Start)
+
+	// Start leaderboard scheduler to compute weekly, monthly and seasonal
+	// leaderboards and award season-end badges
+	sg.Leaderboard = scheduler.NewLeaderboardScheduler(svcs.Leaderboard, cfg.Jobs.LeaderboardSnapshotIntervalMinutes)
+	sg.Drainer.Go("leaderboard", sg.Leaderboard.Start)
+
+	// Start karma safeguard scheduler to decay inactive accounts' karma and
+	// flag vote rings for review
+	sg.KarmaSafeguard = scheduler.NewKarmaSafeguardScheduler(svcs.KarmaSafeguard, cfg.Jobs.KarmaSafeguardIntervalMinutes)
+	sg.Drainer.Go("karma_safeguards", sg.KarmaSafeguard.Start)
+
+	// Start smart feed scheduler to add newly matching clips to rule-based
+	// feeds and drop clips that no longer match
+	sg.SmartFeed = scheduler.NewSmartFeedScheduler(svcs.Feed, cfg.Jobs.SmartFeedIntervalMinutes)
+	sg.Drainer.Go("smart_feeds", sg.SmartFeed.Start)
+
+	// Start community digest scheduler to post last week's digest in active
+	// communities
+	sg.CommunityDigest = scheduler.NewCommunityDigestScheduler(svcs.CommunityDigest, cfg.Jobs.CommunityDigestIntervalMinutes)
+	sg.Drainer.Go("community_digest", sg.CommunityDigest.Start)
+
+	// Start profile view rollup scheduler to store daily profile view counts
+	// in daily_analytics
+	sg.ProfileViewRollup = scheduler.NewProfileViewRollupScheduler(svcs.ProfileView, cfg.Jobs.ProfileViewRollupIntervalMinutes)
+	sg.Drainer.Go("profile_view_rollup", sg.ProfileViewRollup.Start)
+
+	// Start clip hype scheduler to score clips from chat recorded around them
+	sg.ClipHype = scheduler.NewClipHypeScheduler(svcs.ClipHype, cfg.Jobs.ClipHypeIntervalMinutes)
+	sg.Drainer.Go("clip_hype", sg.ClipHype.Start)
+
+	// Start health history scheduler to probe dependencies and record
+	// endpoint group errors for uptime reports
+	sg.HealthHistory = scheduler.NewHealthHistoryScheduler(svcs.HealthHistory, cfg.HealthHistory.ProbeIntervalSeconds)
+	sg.Drainer.Go("health_history", sg.HealthHistory.Start)
+
+	// Start tag graph scheduler to rebuild related tags nightly
+	sg.TagGraph = scheduler.NewTagGraphScheduler(svcs.TagGraph, cfg.Jobs.TagGraphIntervalMinutes)
+	sg.Drainer.Go("tag_graph", sg.TagGraph.Start)
+
+	// Start upload cleanup scheduler to delete orphaned uploads and their files
+	if svcs.Upload != nil {
+		sg.UploadCleanup = scheduler.NewUploadCleanupScheduler(svcs.Upload, cfg.Upload.CleanupIntervalMinutes)
+		sg.Drainer.Go("upload_cleanup", sg.UploadCleanup.Start)
+	}
 
 	return sg
 }
@@ -3,9 +3,12 @@ package main
 import (
 	"context"
 	"log"
+	"net/url"
 	"time"
 
 	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
 	"github.com/subculture-collective/clipper/internal/services"
 	"github.com/subculture-collective/clipper/internal/websocket"
 	"github.com/subculture-collective/clipper/pkg/utils"
@@ -17,51 +20,113 @@ type Services struct {
 	Email                 *services.EmailService
 	MFA                   *services.MFAService
 	Notification          *services.NotificationService
+	NotificationStream    *services.NotificationStreamHub
+	CommentStream         *services.CommentStreamHub
+	CacheInvalidation     *services.CacheInvalidationBus
+	NotificationDigest    *services.NotificationDigestService
+	Reengagement          *services.ReengagementService
+	EmailThrottle         *services.EmailThrottleService
+	PushNotification      *services.PushNotificationService
 	ToxicityClassifier    *services.ToxicityClassifier
 	NSFWDetector          *services.NSFWDetector
 	Comment               *services.CommentService
 	Clip                  *services.ClipService
 	AutoTag               *services.AutoTagService
+	TagGraph              *services.TagGraphService
 	Reputation            *services.ReputationService
 	Analytics             *services.AnalyticsService
 	Engagement            *services.EngagementService
 	AuditLog              *services.AuditLogService
+	UserBan               *services.UserBanService
+	ShadowBan             *services.ShadowBanService
+	ModerationAppeal      *services.ModerationAppealService
+	ModerationClaim       *services.ModerationClaimService
+	DMCA                  *services.DMCAService
+	BanEvasion            *services.BanEvasionService
+	ReportQueue           *services.ReportQueueService
+	ReportOutcome         *services.ReportOutcomeService
+	Achievement           *services.AchievementService
+	Leaderboard           *services.LeaderboardService
+	KarmaSafeguard        *services.KarmaSafeguardService
+	CommunityPick         *services.CommunityPickService
+	BroadcasterSchedule   *services.BroadcasterScheduleService
+	ClipChangefeed        *services.ClipChangefeedService
+	ClipTitle             *services.ClipTitleService
+	ClipIngestionRule     *services.ClipIngestionRuleService
+	Automod               *services.AutomodService
+	ClipBroadcaster       *services.ClipBroadcasterService
+	I18n                  *services.I18nService
 	AccountMerge          *services.AccountMergeService
 	Dunning               *services.DunningService
 	Subscription          *services.SubscriptionService
 	WebhookRetry          *services.WebhookRetryService
 	UserSettings          *services.UserSettingsService
+	ProfileView           *services.ProfileViewService
 	Revenue               *services.RevenueService
 	Ad                    *services.AdService
 	EmailMetrics          *services.EmailMetricsService
 	Cache                 *services.CacheService
 	Feed                  *services.FeedService
+	SocialFeed            *services.SocialFeedService
+	Syndication           *services.SyndicationService
 	FilterPreset          *services.FilterPresetService
 	Community             *services.CommunityService
+	CommunityDigest       *services.CommunityDigestService
+	CommunityToxicity     *services.CommunityToxicityService
+	DataActivity          *services.DataActivityService
 	Moderation            *services.ModerationService
 	BanReasonTemplate     *services.BanReasonTemplateService
 	AccountType           *services.AccountTypeService
 	Recommendation        *services.RecommendationService
 	Playlist              *services.PlaylistService
 	PlaylistScript        *services.PlaylistScriptService
+	PlaylistBundle        *services.PlaylistBundleService
+	FeatureFlag           *services.FeatureFlagService
+	RequestCapture        *services.RequestCaptureService
+	SearchSynonym         *services.SearchSynonymService
+	SearchAnalytics       *services.SearchAnalyticsService
+	GamePatch             *services.GamePatchService
 	Queue                 *services.QueueService
 	ClipExtractionJob     *services.ClipExtractionJobService
+	ClipPlayback          *services.ClipPlaybackService
 	WatchParty            *services.WatchPartyService
 	WatchPartyHubManager  *services.WatchPartyHubManager
 	EventTracker          *services.EventTracker
 	Export                *services.ExportService
+	ShareLink             *services.ShareLinkService
+	EmailLink             *services.EmailLinkService
+	APIKey                *services.APIKeyService
+	DeveloperUsage        *services.DeveloperUsageService
+	ClipEmbed             *services.ClipEmbedService
+	ClipHype              *services.ClipHypeService
+	HealthHistory         *services.HealthHistoryService
+	Maintenance           *services.MaintenanceService
+	CreatorClaim          *services.CreatorClaimService
+	Session               *services.SessionService
+	Upload                *services.UploadService          // may be nil
+	JWTKey                *services.JWTKeyService          // may be nil
 	SearchIndexer         *services.SearchIndexerService   // may be nil
 	OpenSearch            *services.OpenSearchService      // may be nil
 	HybridSearch          *services.HybridSearchService    // may be nil
 	Embedding             *services.EmbeddingService       // may be nil
+	NextEmbedding         *services.EmbeddingService       // may be nil, set while migrating embedding columns
 	ClipSync              *services.ClipSyncService        // may be nil
 	Submission            *services.SubmissionService      // may be nil
+	BroadcasterApproval   *services.BroadcasterApprovalService // may be nil
 	LiveStatus            *services.LiveStatusService      // may be nil
+	TwitchEventSub        *services.TwitchEventSubService  // may be nil
+	ModerationSimilarity  *services.ModerationSimilarityService
+	ModerationShift       *services.ModerationShiftService
 	OutboundWebhook       *services.OutboundWebhookService
 	TwitchBanSync         *services.TwitchBanSyncService         // may be nil
 	TwitchModeration      *services.TwitchModerationService      // may be nil
 	WSServer              *websocket.Server
 	CancelEventTracker    context.CancelFunc
+	CancelDeveloperUsage  context.CancelFunc
+	CancelClipEmbed       context.CancelFunc
+	CancelClipHype        context.CancelFunc
+	CancelPush            context.CancelFunc
+	CancelModSimilarity   context.CancelFunc
 	Logger                *utils.StructuredLogger
 }
 
@@ -70,6 +135,12 @@ func initServices(cfg *config.Config, repos *Repositories, infra *Infrastructure
 
 	authService := services.NewAuthService(cfg, repos.User, repos.RefreshToken, infra.Redis, infra.JWTManager)
 
+	// Enable Google/Discord login alongside Twitch when credentials are configured
+	if oauthProviders := services.NewOAuthProviders(cfg.OAuth); len(oauthProviders) > 0 {
+		authService.SetOAuthProviders(repos.AuthIdentity, oauthProviders)
+		log.Printf("External login providers enabled: %v", authService.OAuthProviderNames())
+	}
+
 	// Initialize email service with notification repo for preference checking
 	emailService := services.NewEmailService(&services.EmailConfig{
 		SendGridAPIKey:   cfg.Email.SendGridAPIKey,
@@ -81,6 +152,16 @@ func initServices(cfg *config.Config, repos *Repositories, infra *Infrastructure
 		MaxEmailsPerHour: cfg.Email.MaxEmailsPerHour,
 	}, repos.EmailNotification, repos.Notification)
 
+	// First-party email link tracking replaces SendGrid click tracking
+	emailLinkService := services.NewEmailLinkService(repos.EmailTrackedLink, repos.EmailLog, cfg.Server.BaseURL)
+	if cfg.Email.LinkTracking {
+		emailService.SetLinkService(emailLinkService)
+	}
+
+	// Notification subjects come from the shared translation catalog when set there
+	i18nService := services.NewI18nService(repos.I18n)
+	emailService.SetTranslator(i18nService)
+
 	// Initialize MFA service
 	mfaService, mfaErr := services.NewMFAService(cfg, repos.MFA, repos.User, emailService)
 	if mfaErr != nil {
@@ -88,6 +169,53 @@ func initServices(cfg *config.Config, repos *Repositories, infra *Infrastructure
 	}
 
 	notificationService := services.NewNotificationService(repos.Notification, repos.User, repos.Comment, repos.Clip, repos.Favorite, emailService)
+	authService.SetNotificationService(notificationService)
+
+	// Batch notifications into daily or weekly emails for users who chose a digest
+	notificationDigestService := services.NewNotificationDigestService(repos.NotificationDigest, repos.Notification, repos.User, emailService)
+
+	// Email users who have gone quiet with the top clips they missed
+	reengagementService := services.NewReengagementService(repos.Reengagement, repos.Notification, repos.User, emailService)
+
+	// Throttle bulk email by rate, ramp-up and recipients' local send windows
+	emailThrottleService := services.NewEmailThrottleService(repos.EmailThrottle, infra.Redis)
+	notificationDigestService.SetThrottle(emailThrottleService)
+	reengagementService.SetThrottle(emailThrottleService)
+
+	// Push notifications to open streams on every instance via Redis pub/sub
+	notificationStreamHub := services.NewNotificationStreamHub(infra.Redis.GetClient())
+	notificationStreamHub.Start(context.Background())
+	notificationService.SetStreamPublisher(notificationStreamHub)
+
+	// Deliver notifications to registered devices through FCM and APNs
+	pushCtx, cancelPush := context.WithCancel(context.Background())
+	var pushNotificationService *services.PushNotificationService
+	if cfg.FeatureFlags.PushNotifications {
+		pushNotificationService = services.NewPushNotificationService(repos.User, cfg.Push.MaxRetries, cfg.Push.Workers)
+		if cfg.Push.FCMCredentialsJSON != "" {
+			fcmProvider, fcmErr := services.NewFCMProvider(cfg.Push.FCMProjectID, cfg.Push.FCMCredentialsJSON)
+			if fcmErr != nil {
+				log.Printf("WARNING: FCM push provider disabled: %v", fcmErr)
+			} else {
+				pushNotificationService.RegisterProvider("android", fcmProvider)
+				pushNotificationService.RegisterProvider("web", fcmProvider)
+			}
+		}
+		if cfg.Push.APNsPrivateKey != "" {
+			apnsProvider, apnsErr := services.NewAPNsProvider(cfg.Push.APNsKeyID, cfg.Push.APNsTeamID, cfg.Push.APNsPrivateKey, cfg.Push.APNsBundleID, cfg.Push.APNsProduction)
+			if apnsErr != nil {
+				log.Printf("WARNING: APNs push provider disabled: %v", apnsErr)
+			} else {
+				pushNotificationService.RegisterProvider("ios", apnsProvider)
+			}
+		}
+		if pushNotificationService.HasProviders() {
+			go pushNotificationService.Start(pushCtx)
+			notificationService.SetPushSender(pushNotificationService)
+		} else {
+			log.Println("WARNING: Push notifications are enabled but no FCM or APNs credentials are configured")
+		}
+	}
 
 	// Initialize toxicity classifier
 	toxicityClassifier := services.NewToxicityClassifier(
@@ -111,13 +239,77 @@ func initServices(cfg *config.Config, repos *Repositories, infra *Infrastructure
 		pool,
 	)
 
-	commentService := services.NewCommentService(repos.Comment, repos.Clip, repos.User, notificationService, toxicityClassifier)
+	// Comments and community discussions share one Markdown renderer and link policy
+	markdownRenderer := services.NewMarkdownRenderer(services.MarkdownLinkPolicy{
+		SiteHosts:    siteHosts(cfg.Server.BaseURL),
+		BlockedHosts: cfg.Markdown.BlockedLinkHosts,
+		UnfurlHosts:  cfg.Markdown.UnfurlHosts,
+		MaxUnfurls:   cfg.Markdown.MaxUnfurls,
+	})
+	commentService := services.NewCommentService(repos.Comment, repos.Clip, repos.User, notificationService, toxicityClassifier, markdownRenderer)
+	// Push new comments and vote scores to clip pages on every instance via Redis pub/sub
+	commentStreamHub := services.NewCommentStreamHub(infra.Redis.GetClient())
+	commentStreamHub.Start(context.Background())
+	commentService.SetStreamPublisher(commentStreamHub)
 	clipService := services.NewClipService(repos.Clip, repos.DiscoveryClip, repos.Vote, repos.Favorite, repos.User, repos.WatchHistory, infra.Redis, repos.AuditLog, notificationService)
+	clipService.SetAccessibilityRepository(repos.ClipAccessibility)
+	// Clip creators, broadcasters and moderators pin comments; pins are audited
+	commentService.SetClipManager(clipService)
+	commentService.SetAuditLogRepository(repos.AuditLog)
+	// Admin automoderation rules for new comments and clip submissions
+	automodService := services.NewAutomodService(repos.AutomodRule, repos.User)
+	commentService.SetAutomod(automodService)
+	// Communities tune how readily comments on their clips are flagged as toxic
+	communityToxicityService := services.NewCommunityToxicityService(repos.CommunityToxicity, cfg.Toxicity.Threshold)
+	commentService.SetToxicityThresholds(communityToxicityService)
+	// Primary and featured broadcasters credited on clips, corrected by moderators
+	clipBroadcasterService := services.NewClipBroadcasterService(repos.ClipBroadcaster, repos.AuditLog)
 	autoTagService := services.NewAutoTagService(repos.Tag)
+	// Related tags from tags found together on clips, for discovery and search expansion
+	tagGraphService := services.NewTagGraphService(repos.Tag)
 	reputationService := services.NewReputationService(repos.Reputation, repos.User)
+	// Admin karma rules set what comments and submissions award, once per event
+	reputationService.SetKarmaRuleRepository(repos.KarmaRule)
+	commentService.SetKarmaAwarder(reputationService)
+	// Achievements track progress as comments, upvotes and approvals happen
+	achievementService := services.NewAchievementService(repos.Achievement, notificationService)
+	commentService.SetAchievementTracker(achievementService)
+	// Weekly, monthly and seasonal leaderboards, with season-end badges
+	leaderboardService := services.NewLeaderboardService(repos.LeaderboardSnapshot, reputationService, notificationService)
 	analyticsService := services.NewAnalyticsService(repos.Analytics, repos.Clip)
 	engagementService := services.NewEngagementService(repos.Analytics, repos.User, repos.Clip)
+	engagementService.SetEmailClickSource(repos.EmailTrackedLink)
 	auditLogService := services.NewAuditLogService(repos.AuditLog)
+	userBanService := services.NewUserBanService(repos.UserBan, auditLogService)
+	shadowBanService := services.NewShadowBanService(repos.UserShadowBan, auditLogService)
+	notificationService.SetShadowBanChecker(shadowBanService)
+	commentService.SetShadowBanChecker(shadowBanService)
+	moderationAppealService := services.NewModerationAppealService(repos.ModerationAppeal, repos.User, notificationService, auditLogService, cfg.Appeals.AssignmentSLAHours, cfg.Appeals.ResolutionSLAHours)
+	moderationClaimService := services.NewModerationClaimService(repos.ModerationClaim, repos.Submission, cfg.Claims.TTLMinutes)
+	banEvasionService := services.NewBanEvasionService(repos.BanEvasion, userBanService, auditLogService, services.BanEvasionServiceConfig{
+		HashSecret:          cfg.BanEvasion.HashSecret,
+		NewAccountDays:      cfg.BanEvasion.NewAccountDays,
+		MinConfidence:       cfg.BanEvasion.MinConfidence,
+		SignalRetentionDays: cfg.BanEvasion.SignalRetentionDays,
+	})
+	// Inactivity karma decay and vote ring detection; diminishing returns for
+	// repeated votes are applied by the vote karma triggers
+	karmaSafeguardService := services.NewKarmaSafeguardService(repos.KarmaSafeguard, auditLogService, services.KarmaSafeguardServiceConfig{
+		DecayInactiveDays:  cfg.KarmaSafeguards.DecayInactiveDays,
+		DecayPeriodDays:    cfg.KarmaSafeguards.DecayPeriodDays,
+		DecayPercent:       cfg.KarmaSafeguards.DecayPercent,
+		DecayFloor:         cfg.KarmaSafeguards.DecayFloor,
+		VoteRingWindowDays: cfg.KarmaSafeguards.VoteRingWindowDays,
+		VoteRingMinVotes:   cfg.KarmaSafeguards.VoteRingMinVotes,
+		VoteRingMinSize:    cfg.KarmaSafeguards.VoteRingMinSize,
+	})
+	reportQueueService := services.NewReportQueueService(repos.Report)
+	trustScoreService := services.NewTrustScoreService(repos.Reputation, repos.User, infra.Redis)
+	reportOutcomeService := services.NewReportOutcomeService(repos.Report, notificationService, trustScoreService)
+	communityPickService := services.NewCommunityPickService(repos.CommunityPick, repos.User)
+	// Schedules are served as last synced when Twitch isn't configured
+	broadcasterScheduleService := services.NewBroadcasterScheduleService(repos.BroadcasterSchedule, repos.Broadcaster, infra.TwitchClient)
+	broadcasterScheduleService.SetNotificationService(notificationService)
 
 	// Initialize account merge service
 	accountMergeService := services.NewAccountMergeService(
@@ -137,6 +329,9 @@ func initServices(cfg *config.Config, repos *Repositories, infra *Infrastructure
 	subscriptionService := services.NewSubscriptionService(repos.Subscription, repos.User, repos.Webhook, cfg, auditLogService, dunningService, emailService)
 	webhookRetryService := services.NewWebhookRetryService(repos.Webhook, subscriptionService)
 	userSettingsService := services.NewUserSettingsService(repos.User, repos.UserSettings, repos.AccountDeletion, repos.Clip, repos.Vote, repos.Favorite, repos.Comment, repos.Submission, repos.Subscription, repos.Consent, auditLogService)
+	// Users see the processing on their data (GDPR Article 15) without a support request
+	dataActivityService := services.NewDataActivityService(repos.DataActivity, repos.Consent)
+	profileViewService := services.NewProfileViewService(repos.ProfileView, services.NewRedisProfileViewStore(infra.Redis))
 	revenueService := services.NewRevenueService(repos.Revenue, cfg)
 	adService := services.NewAdService(repos.Ad, infra.Redis)
 
@@ -148,12 +343,20 @@ func initServices(cfg *config.Config, repos *Repositories, infra *Infrastructure
 
 	// Initialize feed service
 	feedService := services.NewFeedService(repos.Feed, repos.Clip, repos.User, repos.Broadcaster, repos.Vote, repos.Favorite)
+	feedService.SetNotificationService(notificationService)
+
+	// Initialize social feed service (followed users' activity, cached per user in Redis)
+	socialFeedService := services.NewSocialFeedService(repos.SocialFeed, services.NewRedisSocialActivityCache(infra.Redis))
+
+	// Initialize syndication service (RSS/Atom feeds of clips, cached in Redis)
+	syndicationService := services.NewSyndicationService(feedService, repos.Clip, repos.Tag, infra.Redis, cfg.Server.BaseURL)
 
 	// Initialize filter preset service
 	filterPresetService := services.NewFilterPresetService(repos.FilterPreset)
 
 	// Initialize community service
-	communityService := services.NewCommunityService(repos.Community, repos.Clip, repos.User, notificationService)
+	communityService := services.NewCommunityService(repos.Community, repos.Clip, repos.User, notificationService, markdownRenderer)
+	communityDigestService := services.NewCommunityDigestService(repos.CommunityDigest)
 
 	// Initialize moderation service for ban management
 	moderationService := services.NewModerationService(pool, repos.Community, repos.User, repos.AuditLog)
@@ -181,6 +384,20 @@ func initServices(cfg *config.Config, repos *Repositories, infra *Infrastructure
 
 	// Initialize playlist service
 	playlistService := services.NewPlaylistService(repos.Playlist, repos.Clip, cfg.Server.BaseURL)
+	// Premium playlists are sold as bundles; purchases and refunds arrive on the Stripe webhook
+	playlistBundleService := services.NewPlaylistBundleService(repos.PlaylistBundle, repos.Playlist, cfg)
+	playlistService.SetBundleAccessChecker(playlistBundleService)
+	subscriptionService.SetBundlePurchaseHandler(playlistBundleService)
+
+	// Feature flags gate new functionality at runtime; flags are cached in Redis and in memory
+	featureFlagService := services.NewFeatureFlagService(repos.FeatureFlag, repos.User, infra.Redis)
+	// Admin-started sampling of requests to a route, for debugging production issues
+	requestCaptureService := services.NewRequestCaptureService(infra.Redis)
+	// Break-glass read-only mode: starts from config, switched by admins through Redis
+	maintenanceService := services.NewMaintenanceService(infra.Redis, auditLogService, cfg.Maintenance.ReadOnly, cfg.Maintenance.Message)
+	searchSynonymService := services.NewSearchSynonymService(repos.SearchSynonym)
+	searchAnalyticsService := services.NewSearchAnalyticsService(repos.SearchAnalytics)
+	gamePatchService := services.NewGamePatchService(repos.GamePatch, repos.Game)
 	// Note: clipSyncService is initialized later (line ~268) and may be nil when Twitch is not configured.
 	// We set it on playlistScriptService after clipSyncService is created.
 	playlistScriptService := services.NewPlaylistScriptService(repos.PlaylistScript, repos.Playlist, repos.Clip, repos.PlaylistCuration, nil)
@@ -191,6 +408,9 @@ func initServices(cfg *config.Config, repos *Repositories, infra *Infrastructure
 	// Initialize clip extraction job service for FFmpeg processing
 	clipExtractionJobService := services.NewClipExtractionJobService(infra.Redis)
 
+	// Initialize clip playback service for embed fallback and failure tracking
+	clipPlaybackService := services.NewClipPlaybackService(repos.ClipEmbedHealth, &cfg.Mirror)
+
 	// Initialize watch party service and hub manager
 	watchPartyService := services.NewWatchPartyService(repos.WatchParty, repos.Playlist, repos.Clip, cfg.Server.BaseURL)
 
@@ -216,36 +436,164 @@ func initServices(cfg *config.Config, repos *Repositories, infra *Infrastructure
 	// Default retention period is 7 days
 	exportRetentionDays := 7
 	exportService := services.NewExportService(repos.Export, repos.User, emailService, notificationService, exportDir, cfg.Server.BaseURL, exportRetentionDays)
+	shareLinkService := services.NewShareLinkService(repos.ShareLink, repos.Clip, infra.Redis, cfg.Server.BaseURL)
+	apiKeyService := services.NewAPIKeyService(repos.APIKey, repos.User, infra.Redis)
+
+	// Count API key requests into hourly rollups for developer usage reports
+	developerUsageService := services.NewDeveloperUsageService(repos.DeveloperUsage, repos.APIKey, repos.OutboundWebhook)
+	apiKeyService.SetUsageRecorder(developerUsageService)
+	developerUsageCtx, cancelDeveloperUsage := context.WithCancel(context.Background())
+	go developerUsageService.Start(developerUsageCtx)
+
+	// Decide which sites may embed clips and count embed traffic into daily rollups
+	clipEmbedService := services.NewClipEmbedService(repos.ClipEmbed, repos.User)
+	clipEmbedCtx, cancelClipEmbed := context.WithCancel(context.Background())
+	go clipEmbedService.Start(clipEmbedCtx)
+
+	// Count chat recorded through EventSub and score how hyped chat was around each clip
+	clipHypeService := services.NewClipHypeService(repos.ClipHype)
+	clipHypeCtx, cancelClipHype := context.WithCancel(context.Background())
+	go clipHypeService.Start(clipHypeCtx)
+	repos.Clip.SetTrendingHypeWeight(cfg.Jobs.TrendingHypeWeight)
+
+	// Keep a history of dependency probes and endpoint group errors for uptime reports
+	healthHistoryService := services.NewHealthHistoryService(repos.HealthHistory, cfg.HealthHistory.SLATargetPercent, cfg.HealthHistory.ErrorRateThreshold, cfg.HealthHistory.RetentionDays)
+	healthHistoryService.AddDependency("database", infra.DB.HealthCheck)
+	healthHistoryService.AddDependency("redis", infra.Redis.HealthCheck)
+	if infra.OpenSearch != nil {
+		healthHistoryService.AddDependency("opensearch", infra.OpenSearch.Ping)
+	}
+
+	sessionService := services.NewSessionService(repos.Session)
+
+	// Initialize JWT key rotation (keys are shared across instances via the database)
+	var jwtKeyService *services.JWTKeyService
+	if cfg.JWT.KeyRotationEnabled {
+		keySyncInterval := time.Duration(cfg.JWT.KeySyncIntervalMinutes) * time.Minute
+		// Publish new keys for two sync cycles before they sign so every instance knows them
+		svc, jwtKeyErr := services.NewJWTKeyService(
+			repos.JWTSigningKey,
+			infra.JWTManager,
+			cfg.JWT.KeyEncryptionKey,
+			time.Duration(cfg.JWT.KeyRotationIntervalDays)*24*time.Hour,
+			2*keySyncInterval,
+		)
+		if jwtKeyErr != nil {
+			log.Printf("WARNING: JWT key rotation disabled: %v", jwtKeyErr)
+		} else if bootstrapErr := svc.Bootstrap(context.Background()); bootstrapErr != nil {
+			log.Printf("WARNING: Failed to load JWT signing keys, rotation disabled: %v", bootstrapErr)
+		} else {
+			jwtKeyService = svc
+			log.Printf("JWT key rotation enabled (active kid: %s)", infra.JWTManager.ActiveKeyID())
+		}
+	}
+
+	// Initialize presigned uploads for avatars, ad creatives, report evidence
+	// and community assets
+	var uploadService *services.UploadService
+	if cfg.Upload.Enabled {
+		storage, storageErr := services.NewS3UploadStorage(services.S3StorageConfig{
+			Endpoint:        cfg.Upload.StorageEndpoint,
+			Region:          cfg.Upload.StorageRegion,
+			Bucket:          cfg.Upload.StorageBucket,
+			AccessKeyID:     cfg.Upload.StorageAccessKeyID,
+			SecretAccessKey: cfg.Upload.StorageSecretKey,
+			UsePathStyle:    cfg.Upload.StoragePathStyle,
+			PublicBaseURL:   cfg.Upload.PublicBaseURL,
+		})
+		if storageErr != nil {
+			log.Printf("WARNING: Uploads disabled: %v", storageErr)
+		} else {
+			uploadService = services.NewUploadService(
+				repos.Upload,
+				storage,
+				time.Duration(cfg.Upload.PresignExpiryMinutes)*time.Minute,
+				time.Duration(cfg.Upload.OrphanTTLHours)*time.Hour,
+			)
+			if cfg.Upload.ClamAVAddress != "" {
+				uploadService.SetScanner(services.NewClamAVScanner(cfg.Upload.ClamAVAddress, time.Duration(cfg.Upload.ScanTimeoutSeconds)*time.Second))
+				log.Printf("Upload virus scanning enabled (clamd: %s)", cfg.Upload.ClamAVAddress)
+			}
+			userSettingsService.SetUploadService(uploadService)
+			communityService.SetUploadService(uploadService)
+			log.Println("Uploads enabled")
+		}
+	}
 
 	// Initialize search and embedding services
 	var searchIndexerService *services.SearchIndexerService
 	var openSearchService *services.OpenSearchService
 	var hybridSearchService *services.HybridSearchService
 	var embeddingService *services.EmbeddingService
+	var nextEmbeddingService *services.EmbeddingService
+	var embeddingDualRead *services.EmbeddingDualReadConfig // may be nil
 
 	// Initialize embedding service if enabled and configured
 	if cfg.Embedding.Enabled {
-		if cfg.Embedding.OpenAIAPIKey == "" && cfg.Embedding.APIBaseURL == "" {
-			log.Println("WARNING: Embedding is enabled but OPENAI_API_KEY is not set; disabling embeddings")
+		if !cfg.Embedding.Configured() {
+			log.Printf("WARNING: Embedding is enabled but the %s provider is not configured; disabling embeddings", cfg.Embedding.Provider)
+		} else if err := checkEmbeddingColumn(repos.ClipEmbeddingColumn, cfg.Embedding); err != nil {
+			// Query vectors of another model would be compared with the stored ones
+			log.Printf("ERROR: Embedding column %s does not match the embedding model, disabling embeddings: %v", cfg.Embedding.Column, err)
 		} else {
-			embeddingService = services.NewEmbeddingService(&services.EmbeddingConfig{
-				APIKey:            cfg.Embedding.OpenAIAPIKey,
-				APIBaseURL:        cfg.Embedding.APIBaseURL,
-				Model:             cfg.Embedding.Model,
-				RedisClient:       infra.Redis.GetClient(),
-				RequestsPerMinute: cfg.Embedding.RequestsPerMinute,
-			})
-			log.Printf("Embedding service initialized (model: %s)", cfg.Embedding.Model)
+			embeddingService = services.NewEmbeddingService(services.ConfiguredEmbeddingConfig(&cfg.Embedding, infra.Redis.GetClient()))
+			repos.Recommendation.SetEmbeddingColumn(cfg.Embedding.Column)
+			repos.PlaylistCuration.SetEmbeddingColumn(cfg.Embedding.Column)
+			log.Printf("Embedding service initialized (provider: %s, model: %s, column: %s)", cfg.Embedding.Provider, cfg.Embedding.Model, cfg.Embedding.Column)
+
+			// New clips are also embedded into the column being migrated to,
+			// and a sample of searches compares its rankings with the active one
+			if next, ok := cfg.Embedding.Next(); ok {
+				if err := checkEmbeddingColumn(repos.ClipEmbeddingColumn, next); err != nil {
+					log.Printf("WARNING: Not migrating to embedding column %s: %v", next.Column, err)
+				} else {
+					nextEmbeddingService = services.NewEmbeddingService(services.ConfiguredEmbeddingConfig(&next, infra.Redis.GetClient()))
+					log.Printf("Migrating to embedding column %s (provider: %s, model: %s)", next.Column, next.Provider, next.Model)
+					if cfg.Embedding.DualReadSampleRate > 0 {
+						embeddingDualRead = &services.EmbeddingDualReadConfig{
+							Column:           next.Column,
+							EmbeddingService: nextEmbeddingService,
+							SampleRate:       cfg.Embedding.DualReadSampleRate,
+						}
+					}
+				}
+			}
 		}
 	}
+
+	// Similar past moderation decisions match on metadata alone when embeddings are off
+	var caseEmbedder services.TextEmbedder
+	if embeddingService != nil {
+		caseEmbedder = embeddingService
+	}
+	moderationSimilarityService := services.NewModerationSimilarityService(repos.ModerationCase, caseEmbedder)
+	modSimilarityCtx, cancelModSimilarity := context.WithCancel(context.Background())
+	go moderationSimilarityService.Start(modSimilarityCtx)
+	moderationShiftService := services.NewModerationShiftService(repos.ModerationShift, emailService)
 	if infra.OpenSearch != nil {
 		searchIndexerService = services.NewSearchIndexerService(infra.OpenSearch)
+		// OpenSearch indexes the vectors of the original embedding column only
+		openSearchKNN := cfg.HybridSearch.OpenSearchKNN
+		if openSearchKNN && cfg.Embedding.Column != models.DefaultClipEmbeddingColumn {
+			log.Printf("WARNING: OpenSearch kNN only indexes the %s column; ranking %s with pgvector instead", models.DefaultClipEmbeddingColumn, cfg.Embedding.Column)
+			openSearchKNN = false
+		}
+		if openSearchKNN {
+			searchIndexerService.EnableKNN(cfg.HybridSearch.RRFRankConstant)
+		}
 		openSearchService = services.NewOpenSearchService(infra.OpenSearch)
+		openSearchService.SetSpellCorrection(cfg.HybridSearch.SpellCorrection, cfg.HybridSearch.AutoCorrectThreshold)
 
 		// Initialize indices in background
 		go func() {
 			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 			defer cancel()
+			// Indices created here start with the current synonyms
+			if rules, err := searchSynonymService.GetRules(ctx); err != nil {
+				log.Printf("WARNING: Failed to load search synonyms: %v", err)
+			} else {
+				searchIndexerService.SetSynonyms(rules)
+			}
 			if err := searchIndexerService.InitializeIndices(ctx); err != nil {
 				log.Printf("WARNING: Failed to initialize search indices: %v", err)
 			} else {
@@ -253,29 +601,121 @@ func initServices(cfg *config.Config, repos *Repositories, infra *Infrastructure
 			}
 		}()
 
+		// Re-rank with learning-to-rank only once a trained model loads
+		var ltrService *services.SearchLTRService
+		if cfg.HybridSearch.LTR {
+			if model, err := services.LoadLTRModel(cfg.HybridSearch.LTRModelPath); err != nil {
+				log.Printf("WARNING: Learning-to-rank is enabled but its model failed to load, searching without it: %v", err)
+			} else {
+				ltrService = services.NewSearchLTRService(repos.SearchAnalytics, model, cfg.HybridSearch.LTRTrafficPercent)
+				log.Printf("Learning-to-rank model %s loaded (%d%% of signed-in users)", model.Version, cfg.HybridSearch.LTRTrafficPercent)
+			}
+		}
+
 		// Initialize hybrid search when OpenSearch is available
 		hybridSearchService = services.NewHybridSearchService(&services.HybridSearchConfig{
 			Pool:              pool,
 			OpenSearchService: openSearchService,
 			EmbeddingService:  embeddingService,
 			RedisClient:       infra.Redis.GetClient(),
+			EmbeddingColumn:   cfg.Embedding.Column,
+			DualRead:          embeddingDualRead,
+			OpenSearchKNN:     openSearchKNN,
+			Personalization:   services.ConfiguredPersonalizationWeights(&cfg.HybridSearch),
+			Personalizer: services.NewSearchPersonalizationService(
+				repos.SearchPersonalization,
+				cfg.HybridSearch.DownvotedGameMinVotes,
+				cfg.HybridSearch.DownvotedGameShare,
+				cfg.HybridSearch.LanguageShare,
+			),
+			LTR: ltrService,
 		})
 	}
 
+	// DMCA takedowns drop clips from search and reinstatements restore them
+	var dmcaSearchIndexer services.SearchIndexer
+	if searchIndexerService != nil {
+		dmcaSearchIndexer = searchIndexerService
+	}
+	dmcaService := services.NewDMCAService(repos.DMCA, repos.Clip, repos.User, repos.AuditLog, emailService, dmcaSearchIndexer, pool, &services.DMCAServiceConfig{
+		BaseURL:            cfg.Server.BaseURL,
+		DMCAAgentEmail:     cfg.DMCA.AgentEmail,
+		StrikeExpiryMonths: cfg.DMCA.StrikeExpiryMonths,
+	})
+
 	var clipSyncService *services.ClipSyncService
 	var submissionService *services.SubmissionService
+	var broadcasterApprovalService *services.BroadcasterApprovalService
 	var liveStatusService *services.LiveStatusService
+	var twitchEventSubService *services.TwitchEventSubService
 	outboundWebhookService := services.NewOutboundWebhookService(repos.OutboundWebhook)
+	clipChangefeedService := services.NewClipChangefeedService(repos.ClipChangefeed)
+	clipChangefeedService.SetWebhookTrigger(outboundWebhookService)
+	// Clean display titles for search and SEO, unless the broadcaster opted out
+	clipTitleService := services.NewClipTitleService(repos.ClipTitle, repos.User, cfg.FeatureFlags.TitleNormalization)
+	// Admin rules auto-curating synced clips into lists, feeds and communities
+	clipIngestionRuleService := services.NewClipIngestionRuleService(repos.ClipIngestionRule)
 	if infra.TwitchClient != nil {
 		clipSyncService = services.NewClipSyncService(infra.TwitchClient, repos.Clip, repos.Tag, repos.User, infra.Redis)
+		clipSyncService.SetTitleNormalizer(clipTitleService)
+		clipSyncService.SetIngestionRouter(clipIngestionRuleService)
 		submissionService = services.NewSubmissionService(repos.Submission, repos.Clip, repos.DiscoveryClip, repos.User, repos.Vote, repos.AuditLog, infra.TwitchClient, notificationService, infra.Redis, outboundWebhookService, cacheService, cfg)
+		submissionService.SetTitleNormalizer(clipTitleService)
+		submissionService.SetAutomod(automodService)
+		submissionService.SetKarmaAwarder(reputationService)
+		submissionService.SetAchievementTracker(achievementService)
+		// Moderators claim submissions and events so they aren't reviewed twice
+		submissionService.SetClaimGuard(moderationClaimService)
+		if moderationEvents := submissionService.GetModerationEventService(); moderationEvents != nil {
+			moderationEvents.SetClaimGuard(moderationClaimService)
+			moderationClaimService.SetEventReader(moderationEvents)
+		}
+		// Toxic comments are emitted as moderation events next to submissions
+		commentService.SetModerationEvents(submissionService.GetModerationEventService())
+		// Hold clips for broadcasters who approve clips of their channel before they go public
+		broadcasterApprovalService = services.NewBroadcasterApprovalService(repos.BroadcasterApproval, repos.User, notificationService, cfg.Jobs.BroadcasterAutoApproveDays)
+		broadcasterApprovalService.SetHoldReleaser(submissionService)
+		submissionService.SetBroadcasterApprovalService(broadcasterApprovalService)
 		liveStatusService = services.NewLiveStatusService(repos.Broadcaster, repos.StreamFollow, infra.TwitchClient)
 		// Set notification service for live status notifications
 		liveStatusService.SetNotificationService(notificationService)
+		// Stream and clip events arrive by webhook when EventSub is configured
+		if cfg.Twitch.EventSubEnabled() {
+			twitchEventSubService = services.NewTwitchEventSubService(cfg.Twitch.EventSubSecret, cfg.Twitch.EventSubCallbackURL, liveStatusService, infra.TwitchClient, repos.Broadcaster)
+			twitchEventSubService.SetClipImporter(clipSyncService)
+			twitchEventSubService.SetDeduper(infra.Redis)
+			// Chat is only recorded with a bot account to read it as
+			if cfg.Twitch.ChatBotUserID != "" {
+				twitchEventSubService.SetChatRecorder(clipHypeService, cfg.Twitch.ChatBotUserID)
+			}
+		}
 		// Enable Twitch-powered playlist strategies
 		playlistScriptService.SetClipSyncService(clipSyncService)
 	}
 
+	// Clip changes clear the clip list, feed and recommendation caches on every instance
+	cacheInvalidationBus := services.NewCacheInvalidationBus(infra.Redis.GetClient())
+	cacheInvalidationBus.Subscribe("clip_lists", clipService.HandleCacheInvalidation,
+		services.CacheTopicClipUpdated, services.CacheTopicClipRemoved, services.CacheTopicClipVoted)
+	cacheInvalidationBus.Subscribe("feeds", cacheService.HandleCacheInvalidation,
+		services.CacheTopicClipCreated, services.CacheTopicClipUpdated, services.CacheTopicClipRemoved, services.CacheTopicClipVoted)
+	cacheInvalidationBus.Subscribe("recommendations", recommendationService.HandleCacheInvalidation,
+		services.CacheTopicClipUpdated, services.CacheTopicClipRemoved)
+	clipService.SetCacheInvalidationBus(cacheInvalidationBus)
+	clipBroadcasterService.SetCacheInvalidationBus(cacheInvalidationBus)
+	if submissionService != nil {
+		submissionService.SetCacheInvalidationBus(cacheInvalidationBus)
+	}
+	cacheInvalidationBus.Start(context.Background())
+
+	// Users prove control of a Twitch channel to be credited with its clips
+	clipService.SetCreatorClaimRepository(repos.CreatorClaim)
+	var creatorClaimTwitch services.CreatorClaimTwitchClient
+	if infra.TwitchClient != nil {
+		creatorClaimTwitch = infra.TwitchClient
+	}
+	creatorClaimService := services.NewCreatorClaimService(repos.CreatorClaim, repos.User, creatorClaimTwitch)
+
 	// Initialize Twitch-related services
 	var twitchBanSyncService *services.TwitchBanSyncService
 	var twitchModerationService *services.TwitchModerationService
@@ -292,51 +732,131 @@ func initServices(cfg *config.Config, repos *Repositories, infra *Infrastructure
 		Email:                emailService,
 		MFA:                  mfaService,
 		Notification:         notificationService,
+		NotificationStream:   notificationStreamHub,
+		CommentStream:        commentStreamHub,
+		CacheInvalidation:    cacheInvalidationBus,
+		NotificationDigest:   notificationDigestService,
+		Reengagement:         reengagementService,
+		EmailThrottle:        emailThrottleService,
+		PushNotification:     pushNotificationService,
 		ToxicityClassifier:   toxicityClassifier,
 		NSFWDetector:         nsfwDetector,
 		Comment:              commentService,
 		Clip:                 clipService,
 		AutoTag:              autoTagService,
+		TagGraph:             tagGraphService,
 		Reputation:           reputationService,
 		Analytics:            analyticsService,
 		Engagement:           engagementService,
 		AuditLog:             auditLogService,
+		UserBan:              userBanService,
+		ShadowBan:            shadowBanService,
+		ModerationAppeal:     moderationAppealService,
+		ModerationClaim:      moderationClaimService,
+		DMCA:                 dmcaService,
+		BanEvasion:           banEvasionService,
+		ReportQueue:          reportQueueService,
+		ReportOutcome:        reportOutcomeService,
+		Achievement:          achievementService,
+		Leaderboard:          leaderboardService,
+		KarmaSafeguard:       karmaSafeguardService,
+		CommunityPick:        communityPickService,
+		BroadcasterSchedule:  broadcasterScheduleService,
+		ClipChangefeed:       clipChangefeedService,
+		ClipTitle:            clipTitleService,
+		ClipIngestionRule:    clipIngestionRuleService,
+		Automod:              automodService,
+		ClipBroadcaster:      clipBroadcasterService,
+		I18n:                 i18nService,
 		AccountMerge:         accountMergeService,
 		Dunning:              dunningService,
 		Subscription:         subscriptionService,
 		WebhookRetry:         webhookRetryService,
 		UserSettings:         userSettingsService,
+		ProfileView:          profileViewService,
 		Revenue:              revenueService,
 		Ad:                   adService,
 		EmailMetrics:         emailMetricsService,
 		Cache:                cacheService,
 		Feed:                 feedService,
+		SocialFeed:           socialFeedService,
+		Syndication:          syndicationService,
 		FilterPreset:         filterPresetService,
 		Community:            communityService,
+		CommunityDigest:      communityDigestService,
+		CommunityToxicity:    communityToxicityService,
+		DataActivity:         dataActivityService,
 		Moderation:           moderationService,
 		BanReasonTemplate:    banReasonTemplateService,
 		AccountType:          accountTypeService,
 		Recommendation:       recommendationService,
 		Playlist:             playlistService,
 		PlaylistScript:       playlistScriptService,
+		PlaylistBundle:       playlistBundleService,
+		FeatureFlag:          featureFlagService,
+		RequestCapture:       requestCaptureService,
+		SearchSynonym:        searchSynonymService,
+		SearchAnalytics:      searchAnalyticsService,
+		GamePatch:            gamePatchService,
 		Queue:                queueService,
 		ClipExtractionJob:    clipExtractionJobService,
+		ClipPlayback:         clipPlaybackService,
 		WatchParty:           watchPartyService,
 		WatchPartyHubManager: watchPartyHubManager,
 		EventTracker:         eventTracker,
 		Export:               exportService,
+		ShareLink:            shareLinkService,
+		EmailLink:            emailLinkService,
+		APIKey:               apiKeyService,
+		DeveloperUsage:       developerUsageService,
+		ClipEmbed:            clipEmbedService,
+		ClipHype:             clipHypeService,
+		HealthHistory:        healthHistoryService,
+		Maintenance:          maintenanceService,
+		CreatorClaim:         creatorClaimService,
+		Session:              sessionService,
+		Upload:               uploadService,
+		JWTKey:               jwtKeyService,
 		SearchIndexer:        searchIndexerService,
 		OpenSearch:           openSearchService,
 		HybridSearch:         hybridSearchService,
 		Embedding:            embeddingService,
+		NextEmbedding:        nextEmbeddingService,
 		ClipSync:             clipSyncService,
 		Submission:           submissionService,
+		BroadcasterApproval:  broadcasterApprovalService,
 		LiveStatus:           liveStatusService,
+		TwitchEventSub:       twitchEventSubService,
+		ModerationSimilarity: moderationSimilarityService,
+		ModerationShift:      moderationShiftService,
 		OutboundWebhook:      outboundWebhookService,
 		TwitchBanSync:        twitchBanSyncService,
 		TwitchModeration:     twitchModerationService,
 		WSServer:             wsServer,
 		CancelEventTracker:   cancelEventTracker,
+		CancelDeveloperUsage: cancelDeveloperUsage,
+		CancelClipEmbed:      cancelClipEmbed,
+		CancelClipHype:       cancelClipHype,
+		CancelPush:           cancelPush,
+		CancelModSimilarity:  cancelModSimilarity,
 		Logger:               logger,
 	}
 }
+
+// checkEmbeddingColumn verifies that the configured clips embedding column is
+// registered for the configured model
+func checkEmbeddingColumn(repo *repository.ClipEmbeddingColumnRepository, cfg config.EmbeddingConfig) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return repo.CheckModel(ctx, cfg.Column, cfg.Model, cfg.Dimensions)
+}
+
+// siteHosts returns the host of the site's base URL, for links in user content
+// that point back at the site
+func siteHosts(baseURL string) []string {
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Hostname() == "" {
+		return nil
+	}
+	return []string{u.Hostname()}
+}
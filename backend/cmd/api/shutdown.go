@@ -20,19 +20,50 @@ func gracefulShutdown(srv *http.Server, svcs *Services, schedulers *SchedulerGro
 	// Shutdown WebSocket server first to close all connections
 	svcs.WSServer.Shutdown()
 
+	// End notification and comment streams so the HTTP server is not held open by them
+	if err := svcs.NotificationStream.Close(); err != nil {
+		log.Printf("Failed to close notification stream hub: %v", err)
+	}
+	if err := svcs.CommentStream.Close(); err != nil {
+		log.Printf("Failed to close comment stream hub: %v", err)
+	}
+	if err := svcs.CacheInvalidation.Close(); err != nil {
+		log.Printf("Failed to close cache invalidation bus: %v", err)
+	}
+
 	// Stop event tracker
 	svcs.CancelEventTracker()
 
-	// Stop schedulers
+	// Flush buffered API key usage
+	svcs.CancelDeveloperUsage()
+
+	// Flush buffered clip embed traffic
+	svcs.CancelClipEmbed()
+
+	// Flush recorded chat activity
+	svcs.CancelClipHype()
+
+	// Stop push notification workers
+	svcs.CancelPush()
+
+	// Stop moderation case embedding backfill
+	svcs.CancelModSimilarity()
+
+	// Stop schedulers so no new runs start; runs already in progress are
+	// drained below
 	if schedulers.ClipSync != nil {
 		schedulers.ClipSync.Stop()
 	}
 	schedulers.Reputation.Stop()
 	schedulers.HotScore.Stop()
 	schedulers.TrendingScore.Stop()
-	schedulers.WebhookRetry.Stop()
+	if schedulers.WebhookRetry != nil {
+		schedulers.WebhookRetry.Stop()
+	}
 	schedulers.OutboundWebhook.Stop()
-	schedulers.Export.Stop()
+	if schedulers.Export != nil {
+		schedulers.Export.Stop()
+	}
 	schedulers.EmailMetrics.Stop()
 	if schedulers.Embedding != nil {
 		schedulers.Embedding.Stop()
@@ -40,15 +71,65 @@ func gracefulShutdown(srv *http.Server, svcs *Services, schedulers *SchedulerGro
 	if schedulers.LiveStatus != nil {
 		schedulers.LiveStatus.Stop()
 	}
+	if schedulers.EventSub != nil {
+		schedulers.EventSub.Stop()
+	}
 	schedulers.PlaylistScript.Stop()
+	if schedulers.JWTKeyRotation != nil {
+		schedulers.JWTKeyRotation.Stop()
+	}
+	if schedulers.BroadcasterApproval != nil {
+		schedulers.BroadcasterApproval.Stop()
+	}
+	schedulers.NotificationDigest.Stop()
+	schedulers.Reengagement.Stop()
+	schedulers.BanExpiry.Stop()
+	schedulers.CommunityPick.Stop()
+	schedulers.BroadcasterSchedule.Stop()
+	schedulers.ClipChangefeed.Stop()
+	schedulers.ModerationShift.Stop()
+	schedulers.ModerationClaim.Stop()
+	schedulers.DMCA.Stop()
+	schedulers.BanEvasion.Stop()
+	schedulers.ReportPriority.Stop()
+	schedulers.Leaderboard.Stop()
+	schedulers.KarmaSafeguard.Stop()
+	schedulers.SmartFeed.Stop()
+	schedulers.CommunityDigest.Stop()
+	schedulers.ProfileViewRollup.Stop()
+	schedulers.ClipHype.Stop()
+	schedulers.HealthHistory.Stop()
+	schedulers.TagGraph.Stop()
+	if schedulers.UploadCleanup != nil {
+		schedulers.UploadCleanup.Stop()
+	}
+
+	// Wait for in-flight scheduler runs. Runs still going at the deadline are
+	// cancelled and checkpoint their unfinished work for the next start.
+	drainTimeout := time.Duration(infra.Config.Jobs.ShutdownDrainSeconds) * time.Second
+	log.Printf("Draining schedulers (timeout %s, running: %v)", drainTimeout, schedulers.Drainer.Running())
+	report := schedulers.Drainer.Drain(drainTimeout)
+	switch {
+	case len(report.Abandoned) > 0:
+		log.Printf("Scheduler drain timed out after %s: %d drained, interrupted %v, still running %v",
+			report.Duration.Round(time.Millisecond), report.Drained, report.Interrupted, report.Abandoned)
+	case report.TimedOut():
+		log.Printf("Scheduler drain timed out after %s: %d drained, interrupted and checkpointed %v",
+			report.Duration.Round(time.Millisecond), report.Drained, report.Interrupted)
+	default:
+		log.Printf("Schedulers drained in %s: %d drained", report.Duration.Round(time.Millisecond), report.Drained)
+	}
 
 	// Close embedding service if running
 	if svcs.Embedding != nil {
 		svcs.Embedding.Close()
 	}
+	if svcs.NextEmbedding != nil {
+		svcs.NextEmbedding.Close()
+	}
 
-	// Graceful shutdown with 5 second timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	// Graceful shutdown with the configured timeout
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(infra.Config.Server.ShutdownTimeoutSeconds)*time.Second)
 	defer cancel()
 
 	if err := srv.Shutdown(ctx); err != nil {
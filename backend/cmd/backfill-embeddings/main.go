@@ -2,37 +2,40 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
+	"github.com/google/uuid"
 	pgvector "github.com/pgvector/pgvector-go"
 	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/backfill"
 	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
 	"github.com/subculture-collective/clipper/internal/services"
 	"github.com/subculture-collective/clipper/pkg/database"
 	"github.com/subculture-collective/clipper/pkg/redis"
 )
 
-type BackfillStats struct {
-	TotalClips     int
-	ProcessedClips int
-	SkippedClips   int
-	FailedClips    int
-	StartTime      time.Time
-	EndTime        time.Time
-	LastError      error
-}
-
 func main() {
-	batchSize := flag.Int("batch", 50, "Number of clips to process in each batch")
+	batchSize := flag.Int("batch", 500, "Number of clips to process in each batch")
+	maxInputs := flag.Int("max-inputs", 0, "Most clips per embedding API request (default EMBEDDING_BATCH_MAX_INPUTS)")
 	forceUpdate := flag.Bool("force", false, "Force update existing embeddings")
 	dryRun := flag.Bool("dry-run", false, "Dry run mode - don't save embeddings")
+	workers := flag.Int("workers", 1, "Number of batches to embed at once")
+	rateLimit := flag.Float64("rate", 0, "Most clips to embed per second (0 for no limit)")
+	resume := flag.Bool("resume", false, "Continue from the last checkpoint instead of starting over")
 	flag.Parse()
 
 	log.Println("Starting embedding backfill job...")
-	log.Printf("Configuration: batch_size=%d, force_update=%t, dry_run=%t", *batchSize, *forceUpdate, *dryRun)
+	log.Printf("Configuration: batch_size=%d, max_inputs=%d, force_update=%t, dry_run=%t, workers=%d, rate=%.1f/s, resume=%t",
+		*batchSize, *maxInputs, *forceUpdate, *dryRun, *workers, *rateLimit, *resume)
 
 	// Load configuration
 	cfg, err := config.Load()
@@ -45,8 +48,12 @@ func main() {
 		log.Println("Set EMBEDDING_ENABLED=true to enable embedding generation")
 	}
 
-	if cfg.Embedding.OpenAIAPIKey == "" {
-		log.Fatalf("OPENAI_API_KEY is not set. Please set it in your environment or .env file")
+	if !cfg.Embedding.Configured() {
+		log.Fatalf("The %s embedding provider is not configured. Set OPENAI_API_KEY, COHERE_API_KEY or EMBEDDING_API_BASE_URL for it in your environment or .env file", cfg.Embedding.Provider)
+	}
+
+	if cfg.Embedding.Column != models.DefaultClipEmbeddingColumn {
+		log.Fatalf("This job fills the %s column; backfill %s with cmd/migrate-embeddings instead", models.DefaultClipEmbeddingColumn, cfg.Embedding.Column)
 	}
 
 	// Initialize database connection
@@ -65,191 +72,208 @@ func main() {
 	defer redisClient.Close()
 	log.Println("Redis connection established")
 
+	if *maxInputs > 0 {
+		cfg.Embedding.BatchMaxInputs = *maxInputs
+	}
+
 	// Initialize embedding service
-	embeddingService := services.NewEmbeddingService(&services.EmbeddingConfig{
-		APIKey:            cfg.Embedding.OpenAIAPIKey,
-		APIBaseURL:        cfg.Embedding.APIBaseURL,
-		Model:             cfg.Embedding.Model,
-		RedisClient:       redisClient.GetClient(),
-		RequestsPerMinute: cfg.Embedding.RequestsPerMinute,
-	})
+	embeddingService := services.NewEmbeddingService(services.ConfiguredEmbeddingConfig(&cfg.Embedding, redisClient.GetClient()))
 	defer embeddingService.Close()
-	log.Printf("Embedding service initialized (model: %s)", cfg.Embedding.Model)
+	log.Printf("Embedding service initialized (provider: %s, model: %s)", cfg.Embedding.Provider, cfg.Embedding.Model)
+
+	// Stop at the next batch on Ctrl+C or SIGTERM, keeping the checkpoint
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM clips WHERE is_removed = false`
+	if !*forceUpdate {
+		countQuery += ` AND embedding IS NULL`
+	}
+	if err := db.Pool.QueryRow(ctx, countQuery).Scan(&total); err != nil {
+		log.Fatalf("Failed to count clips: %v", err)
+	}
+	log.Printf("Found %d clips to process", total)
+
+	// Dry runs save nothing, so they leave no checkpoint to resume from
+	var store backfill.StateStore
+	if !*dryRun {
+		store = repository.NewBackfillStateRepository(db.Pool)
+	}
 
 	// Run backfill
-	ctx := context.Background()
-	stats, err := backfillEmbeddings(ctx, db, embeddingService, *batchSize, *forceUpdate, *dryRun)
-	if err != nil {
+	start := time.Now()
+	state, err := backfill.Run(ctx, store, embeddingsJob(db, embeddingService, *forceUpdate, *dryRun), backfill.Options{
+		BatchSize: *batchSize,
+		Workers:   *workers,
+		Rate:      *rateLimit,
+		Resume:    *resume,
+	})
+	budgetExceeded := errors.Is(err, services.ErrEmbeddingBudgetExceeded)
+	switch {
+	case budgetExceeded:
+		log.Printf("WARNING: Monthly embedding budget exhausted; run again with -resume once it resets")
+	case backfill.IsStopped(err):
+		log.Printf("Interrupted; run again with -resume to continue")
+		os.Exit(1)
+	case err != nil:
 		log.Fatalf("Backfill failed: %v", err)
 	}
 
 	// Print summary
-	duration := stats.EndTime.Sub(stats.StartTime)
+	duration := time.Since(start)
 	log.Println("\n=== Backfill Summary ===")
-	log.Printf("Total clips: %d", stats.TotalClips)
-	log.Printf("Processed: %d", stats.ProcessedClips)
-	log.Printf("Skipped: %d", stats.SkippedClips)
-	log.Printf("Failed: %d", stats.FailedClips)
+	log.Printf("Total clips: %d", total)
+	log.Printf("Processed: %d", state.Processed)
+	log.Printf("Failed: %d", state.Failed)
 	log.Printf("Duration: %v", duration)
 
-	if stats.ProcessedClips > 0 {
-		avgTime := duration / time.Duration(stats.ProcessedClips)
+	if state.Processed > 0 {
+		avgTime := duration / time.Duration(state.Processed)
 		log.Printf("Average time per clip: %v", avgTime)
 	}
 
-	if stats.LastError != nil {
-		log.Printf("Last error: %v", stats.LastError)
+	if budgetExceeded {
+		os.Exit(1)
 	}
-
 	log.Println("\n✓ Backfill completed successfully!")
 }
 
-func backfillEmbeddings(
-	ctx context.Context,
-	db *database.DB,
-	embeddingService *services.EmbeddingService,
-	batchSize int,
-	forceUpdate bool,
-	dryRun bool,
-) (*BackfillStats, error) {
-	stats := &BackfillStats{
-		StartTime: time.Now(),
-	}
-
-	// Count total clips
-	countQuery := `SELECT COUNT(*) FROM clips WHERE is_removed = false`
-	if !forceUpdate {
-		countQuery += ` AND embedding IS NULL`
+// embeddingsJob embeds clips newest first. Without force, only clips without
+// an embedding are read, so a rerun retries the clips that failed.
+func embeddingsJob(db *database.DB, embeddingService *services.EmbeddingService, forceUpdate, dryRun bool) backfill.Job[models.Clip] {
+	name := "embeddings"
+	if forceUpdate {
+		name = "embeddings:force"
 	}
-
-	if err := db.Pool.QueryRow(ctx, countQuery).Scan(&stats.TotalClips); err != nil {
-		return nil, fmt.Errorf("failed to count clips: %w", err)
-	}
-
-	log.Printf("Found %d clips to process", stats.TotalClips)
-
-	if stats.TotalClips == 0 {
-		stats.EndTime = time.Now()
-		return stats, nil
-	}
-
-	offset := 0
-	for {
-		// Fetch batch of clips
-		query := `
-			SELECT id, twitch_clip_id, title, creator_name, broadcaster_name,
-			       game_id, game_name, embedding, embedding_generated_at, embedding_model
-			FROM clips
-			WHERE is_removed = false
-		`
-		if !forceUpdate {
-			query += ` AND embedding IS NULL`
-		}
-		query += `
-			ORDER BY created_at DESC
-			LIMIT $1 OFFSET $2
-		`
-
-		rows, err := db.Pool.Query(ctx, query, batchSize, offset)
-		if err != nil {
-			return stats, fmt.Errorf("failed to fetch clips: %w", err)
-		}
-		defer rows.Close()
-
-		var clips []models.Clip
-		for rows.Next() {
-			var clip models.Clip
-			err := rows.Scan(
-				&clip.ID,
-				&clip.TwitchClipID,
-				&clip.Title,
-				&clip.CreatorName,
-				&clip.BroadcasterName,
-				&clip.GameID,
-				&clip.GameName,
-				&clip.Embedding,
-				&clip.EmbeddingGeneratedAt,
-				&clip.EmbeddingModel,
-			)
-			if err != nil {
-				return stats, fmt.Errorf("failed to scan clip: %w", err)
+	return backfill.Job[models.Clip]{
+		Name: name,
+		Fetch: func(ctx context.Context, after string, limit int) ([]models.Clip, string, error) {
+			query := `
+				SELECT id, twitch_clip_id, title, creator_name, broadcaster_name,
+				       game_id, game_name, created_at
+				FROM clips
+				WHERE is_removed = false`
+			if !forceUpdate {
+				query += ` AND embedding IS NULL`
 			}
-			clips = append(clips, clip)
-		}
-
-		if len(clips) == 0 {
-			break
-		}
-
-		// Process batch
-		log.Printf("Processing batch of %d clips (offset: %d, progress: %.1f%%)",
-			len(clips), offset, float64(stats.ProcessedClips+stats.SkippedClips+stats.FailedClips)/float64(stats.TotalClips)*100)
-
-		for i := range clips {
-			clip := &clips[i]
-
-			// Skip if already has embedding and not forcing update
-			if !forceUpdate && len(clip.Embedding) > 0 {
-				stats.SkippedClips++
-				continue
+			args := []interface{}{limit}
+			if after != "" {
+				createdAt, id, err := parseClipCursor(after)
+				if err != nil {
+					return nil, "", err
+				}
+				query += ` AND (created_at, id) < ($2, $3)`
+				args = append(args, createdAt, id)
 			}
+			query += `
+				ORDER BY created_at DESC, id DESC
+				LIMIT $1`
 
-			// Generate embedding
-			embedding, err := embeddingService.GenerateClipEmbedding(ctx, clip)
+			rows, err := db.Pool.Query(ctx, query, args...)
 			if err != nil {
-				log.Printf("WARNING: Failed to generate embedding for clip %s: %v", clip.ID, err)
-				stats.FailedClips++
-				stats.LastError = err
-				continue
+				return nil, "", fmt.Errorf("failed to fetch clips: %w", err)
 			}
-
-			if dryRun {
-				log.Printf("DRY RUN: Would update clip %s with embedding (length: %d)", clip.ID, len(embedding))
-				stats.ProcessedClips++
-				continue
+			defer rows.Close()
+
+			var clips []models.Clip
+			for rows.Next() {
+				var clip models.Clip
+				err := rows.Scan(
+					&clip.ID,
+					&clip.TwitchClipID,
+					&clip.Title,
+					&clip.CreatorName,
+					&clip.BroadcasterName,
+					&clip.GameID,
+					&clip.GameName,
+					&clip.CreatedAt,
+				)
+				if err != nil {
+					return nil, "", fmt.Errorf("failed to scan clip: %w", err)
+				}
+				clips = append(clips, clip)
+			}
+			if err := rows.Err(); err != nil {
+				return nil, "", fmt.Errorf("failed to fetch clips: %w", err)
+			}
+			if len(clips) == 0 {
+				return nil, after, nil
+			}
+			last := clips[len(clips)-1]
+			return clips, last.CreatedAt.UTC().Format(time.RFC3339Nano) + "," + last.ID.String(), nil
+		},
+		Process: func(ctx context.Context, clips []models.Clip) (int, error) {
+			// Generate the batch's embeddings in as few API requests as possible.
+			// Clips whose requests failed come back without an embedding.
+			embeddings, genErr := embeddingService.GenerateClipEmbeddings(ctx, clips)
+			var batchErr *services.EmbeddingBatchError
+			if genErr != nil && !errors.As(genErr, &batchErr) {
+				if ctx.Err() != nil || errors.Is(genErr, services.ErrEmbeddingBudgetExceeded) {
+					return 0, genErr
+				}
+				log.Printf("WARNING: Failed to generate embeddings for batch of %d clips: %v", len(clips), genErr)
+				return len(clips), nil
+			}
+			failed := 0
+			if batchErr != nil {
+				log.Printf("WARNING: Failed to generate %d of %d embeddings: %v", len(batchErr.Failed), len(clips), batchErr.Err)
+				failed += len(batchErr.Failed)
 			}
 
-			// Save embedding to database
-			now := time.Now()
 			model := embeddingService.GetModel()
-			updateQuery := `
-				UPDATE clips
-				SET embedding = $1,
-				    embedding_generated_at = $2,
-				    embedding_model = $3
-				WHERE id = $4
-			`
-
-			_, err = db.Pool.Exec(ctx, updateQuery, pgvector.NewVector(embedding), now, model, clip.ID)
-			if err != nil {
-				log.Printf("WARNING: Failed to save embedding for clip %s: %v", clip.ID, err)
-				stats.FailedClips++
-				stats.LastError = err
-				continue
+			for i := range clips {
+				clip := &clips[i]
+				embedding := embeddings[i]
+				if embedding == nil {
+					continue
+				}
+
+				if dryRun {
+					log.Printf("DRY RUN: Would update clip %s with embedding (length: %d)", clip.ID, len(embedding))
+					continue
+				}
+
+				// Save embedding to database
+				updateQuery := `
+					UPDATE clips
+					SET embedding = $1,
+					    embedding_generated_at = $2,
+					    embedding_model = $3
+					WHERE id = $4
+				`
+				if _, err := db.Pool.Exec(ctx, updateQuery, pgvector.NewVector(embedding), time.Now(), model, clip.ID); err != nil {
+					if ctx.Err() != nil {
+						return failed, ctx.Err()
+					}
+					log.Printf("WARNING: Failed to save embedding for clip %s: %v", clip.ID, err)
+					failed++
+				}
 			}
 
-			stats.ProcessedClips++
-
-			// Log progress every 10 clips
-			if stats.ProcessedClips%10 == 0 {
-				progress := float64(stats.ProcessedClips+stats.SkippedClips+stats.FailedClips) / float64(stats.TotalClips) * 100
-				log.Printf("Progress: %d/%d (%.1f%%) - processed: %d, skipped: %d, failed: %d",
-					stats.ProcessedClips+stats.SkippedClips+stats.FailedClips,
-					stats.TotalClips,
-					progress,
-					stats.ProcessedClips,
-					stats.SkippedClips,
-					stats.FailedClips,
-				)
+			// The clips saved before the budget ran out stay saved; the rest
+			// of the batch is retried on resume
+			if errors.Is(genErr, services.ErrEmbeddingBudgetExceeded) {
+				return failed, genErr
 			}
-		}
-
-		offset += batchSize
-
-		// Small delay between batches to avoid overwhelming the API
-		time.Sleep(100 * time.Millisecond)
+			return failed, nil
+		},
 	}
+}
 
-	stats.EndTime = time.Now()
-	return stats, nil
+// parseClipCursor reads a created_at,id cursor
+func parseClipCursor(cursor string) (time.Time, uuid.UUID, error) {
+	createdAtText, idText, ok := strings.Cut(cursor, ",")
+	if !ok {
+		return time.Time{}, uuid.Nil, fmt.Errorf("invalid clip cursor %q", cursor)
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, createdAtText)
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("invalid clip cursor %q: %w", cursor, err)
+	}
+	id, err := uuid.Parse(idText)
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("invalid clip cursor %q: %w", cursor, err)
+	}
+	return createdAt, id, nil
 }
@@ -5,10 +5,15 @@ import (
 	"flag"
 	"fmt"
 	"log"
-	"time"
+	"os"
+	"os/signal"
+	"syscall"
 
+	pgvector "github.com/pgvector/pgvector-go"
 	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/backfill"
 	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
 	"github.com/subculture-collective/clipper/internal/services"
 	"github.com/subculture-collective/clipper/pkg/database"
 	opensearchpkg "github.com/subculture-collective/clipper/pkg/opensearch"
@@ -16,9 +21,13 @@ import (
 
 func main() {
 	batchSize := flag.Int("batch", 100, "Number of records to process in each batch")
+	workers := flag.Int("workers", 1, "Number of batches to index at once")
+	rateLimit := flag.Float64("rate", 0, "Most records to index per second (0 for no limit)")
+	resume := flag.Bool("resume", false, "Continue from the last checkpoint instead of starting over")
 	flag.Parse()
 
 	log.Println("Starting search index backfill...")
+	log.Printf("Configuration: batch_size=%d, workers=%d, rate=%.1f/s, resume=%t", *batchSize, *workers, *rateLimit, *resume)
 
 	cfg, err := config.Load()
 	if err != nil {
@@ -41,13 +50,19 @@ func main() {
 		log.Fatalf("Failed to initialize OpenSearch client: %v", err)
 	}
 
-	ctx := context.Background()
+	// Stop at the next batch on Ctrl+C or SIGTERM, keeping the checkpoint
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
 	if err := osClient.Ping(ctx); err != nil {
 		log.Fatalf("OpenSearch ping failed: %v", err)
 	}
 	log.Println("OpenSearch connection established")
 
 	indexer := services.NewSearchIndexerService(osClient)
+	if cfg.HybridSearch.OpenSearchKNN {
+		indexer.EnableKNN(cfg.HybridSearch.RRFRankConstant)
+	}
 
 	log.Println("Initializing search indices...")
 	if err := indexer.InitializeIndices(ctx); err != nil {
@@ -55,245 +70,283 @@ func main() {
 	}
 	log.Println("Indices initialized successfully")
 
+	store := repository.NewBackfillStateRepository(db.Pool)
+	opts := backfill.Options{BatchSize: *batchSize, Workers: *workers, Rate: *rateLimit, Resume: *resume}
+
 	log.Println("Backfilling clips...")
-	if err := backfillClips(ctx, db, indexer, *batchSize); err != nil {
-		log.Fatalf("Failed to backfill clips: %v", err)
-	}
+	state, err := backfill.Run(ctx, store, clipsJob(db, indexer), opts)
+	finish("clips", state, err)
 
 	log.Println("Backfilling users...")
-	if err := backfillUsers(ctx, db, indexer, *batchSize); err != nil {
-		log.Fatalf("Failed to backfill users: %v", err)
-	}
+	state, err = backfill.Run(ctx, store, usersJob(db, indexer), opts)
+	finish("users", state, err)
 
 	log.Println("Backfilling tags...")
-	if err := backfillTags(ctx, db, indexer, *batchSize); err != nil {
-		log.Fatalf("Failed to backfill tags: %v", err)
-	}
+	state, err = backfill.Run(ctx, store, tagsJob(db, indexer), opts)
+	finish("tags", state, err)
 
 	log.Println("Backfilling games...")
-	if err := backfillGames(ctx, db, indexer, *batchSize); err != nil {
-		log.Fatalf("Failed to backfill games: %v", err)
-	}
+	state, err = backfill.Run(ctx, store, gamesJob(db, indexer), opts)
+	finish("games", state, err)
 
 	log.Println("Backfill completed successfully!")
 }
 
-func backfillClips(ctx context.Context, db *database.DB, indexer *services.SearchIndexerService, batchSize int) error {
-	offset := 0
-	totalIndexed := 0
-
-	for {
-		query := `
-			SELECT id, twitch_clip_id, twitch_clip_url, embed_url, title, 
-			       creator_name, creator_id, broadcaster_name, broadcaster_id,
-			       game_id, game_name, language, thumbnail_url, duration,
-			       view_count, created_at, imported_at, vote_score,
-			       comment_count, favorite_count, is_featured, is_nsfw,
-			       is_removed, removed_reason
-			FROM clips
-			WHERE is_removed = false
-			ORDER BY id
-			LIMIT $1 OFFSET $2
-		`
+// finish reports a finished job and exits if it didn't complete
+func finish(kind string, state *models.BackfillState, err error) {
+	if backfill.IsStopped(err) {
+		log.Printf("Interrupted while indexing %s; run again with -resume to continue", kind)
+		os.Exit(1)
+	}
+	if err != nil {
+		log.Fatalf("Failed to backfill %s: %v", kind, err)
+	}
+	log.Printf("Completed indexing %d %s (%d failed)", state.Processed, kind, state.Failed)
+}
 
-		rows, err := db.Pool.Query(ctx, query, batchSize, offset)
-		if err != nil {
-			return fmt.Errorf("failed to fetch clips: %w", err)
-		}
+func clipsJob(db *database.DB, indexer *services.SearchIndexerService) backfill.Job[models.Clip] {
+	return backfill.Job[models.Clip]{
+		Name: "search:clips",
+		Fetch: func(ctx context.Context, after string, limit int) ([]models.Clip, string, error) {
+			query := `
+				SELECT c.id, c.twitch_clip_id, c.twitch_clip_url, c.embed_url, c.title,
+				       c.creator_name, c.creator_id, c.broadcaster_name, c.broadcaster_id,
+				       c.game_id, c.game_name, c.language, c.thumbnail_url, c.duration,
+				       c.view_count, c.created_at, c.imported_at, c.vote_score,
+				       c.comment_count, c.favorite_count, c.is_featured, c.is_nsfw,
+				       c.is_removed, c.removed_reason, c.submitted_by_user_id, c.embedding, c.display_title,
+				       COALESCE(a.has_captions, false), COALESCE(a.photosensitivity_warning, false),
+				       COALESCE(a.audio_description, false), h.hype_score
+				FROM clips c
+				LEFT JOIN clip_accessibility a ON a.clip_id = c.id
+				LEFT JOIN clip_hype_scores h ON h.clip_id = c.id
+				LEFT JOIN users su ON su.id = c.submitted_by_user_id
+				WHERE c.is_removed = false AND NOT COALESCE(su.is_shadow_banned, false)`
+			args := []interface{}{limit}
+			if after != "" {
+				query += ` AND c.id > $2`
+				args = append(args, after)
+			}
+			query += `
+				ORDER BY c.id
+				LIMIT $1`
 
-		var clips []models.Clip
-		for rows.Next() {
-			var clip models.Clip
-			err := rows.Scan(
-				&clip.ID, &clip.TwitchClipID, &clip.TwitchClipURL, &clip.EmbedURL,
-				&clip.Title, &clip.CreatorName, &clip.CreatorID, &clip.BroadcasterName,
-				&clip.BroadcasterID, &clip.GameID, &clip.GameName, &clip.Language,
-				&clip.ThumbnailURL, &clip.Duration, &clip.ViewCount, &clip.CreatedAt,
-				&clip.ImportedAt, &clip.VoteScore, &clip.CommentCount, &clip.FavoriteCount,
-				&clip.IsFeatured, &clip.IsNSFW, &clip.IsRemoved, &clip.RemovedReason,
-			)
+			rows, err := db.Pool.Query(ctx, query, args...)
 			if err != nil {
-				rows.Close()
-				return fmt.Errorf("failed to scan clip: %w", err)
+				return nil, "", fmt.Errorf("failed to fetch clips: %w", err)
 			}
-			clips = append(clips, clip)
-		}
-		rows.Close()
-
-		if len(clips) == 0 {
-			break
-		}
-
-		if err := indexer.BulkIndexClips(ctx, clips); err != nil {
-			return fmt.Errorf("failed to index clips batch: %w", err)
-		}
-
-		totalIndexed += len(clips)
-		log.Printf("Indexed %d clips (total: %d)", len(clips), totalIndexed)
-
-		offset += batchSize
-		time.Sleep(100 * time.Millisecond)
+			defer rows.Close()
+
+			var clips []models.Clip
+			for rows.Next() {
+				var clip models.Clip
+				var embedding *pgvector.Vector
+				var accessibility models.ClipAccessibility
+				err := rows.Scan(
+					&clip.ID, &clip.TwitchClipID, &clip.TwitchClipURL, &clip.EmbedURL,
+					&clip.Title, &clip.CreatorName, &clip.CreatorID, &clip.BroadcasterName,
+					&clip.BroadcasterID, &clip.GameID, &clip.GameName, &clip.Language,
+					&clip.ThumbnailURL, &clip.Duration, &clip.ViewCount, &clip.CreatedAt,
+					&clip.ImportedAt, &clip.VoteScore, &clip.CommentCount, &clip.FavoriteCount,
+					&clip.IsFeatured, &clip.IsNSFW, &clip.IsRemoved, &clip.RemovedReason,
+					&clip.SubmittedByUserID, &embedding, &clip.DisplayTitle,
+					&accessibility.HasCaptions, &accessibility.PhotosensitivityWarning,
+					&accessibility.AudioDescription, &clip.HypeScore,
+				)
+				if err != nil {
+					return nil, "", fmt.Errorf("failed to scan clip: %w", err)
+				}
+				if embedding != nil {
+					clip.Embedding = embedding.Slice()
+				}
+				accessibility.ClipID = clip.ID
+				clip.Accessibility = &accessibility
+				clips = append(clips, clip)
+			}
+			if err := rows.Err(); err != nil {
+				return nil, "", fmt.Errorf("failed to fetch clips: %w", err)
+			}
+			if len(clips) == 0 {
+				return nil, after, nil
+			}
+			return clips, clips[len(clips)-1].ID.String(), nil
+		},
+		Process: func(ctx context.Context, clips []models.Clip) (int, error) {
+			if err := indexer.BulkIndexClips(ctx, clips); err != nil {
+				return 0, fmt.Errorf("failed to index clips batch: %w", err)
+			}
+			return 0, nil
+		},
 	}
-
-	log.Printf("Completed indexing %d clips", totalIndexed)
-	return nil
 }
 
-func backfillUsers(ctx context.Context, db *database.DB, indexer *services.SearchIndexerService, batchSize int) error {
-	offset := 0
-	totalIndexed := 0
-
-	for {
-		query := `
-			SELECT id, twitch_id, username, display_name, email, avatar_url,
-			       bio, karma_points, role, is_banned, created_at, updated_at, last_login_at
-			FROM users
-			WHERE is_banned = false
-			ORDER BY id
-			LIMIT $1 OFFSET $2
-		`
-
-		rows, err := db.Pool.Query(ctx, query, batchSize, offset)
-		if err != nil {
-			return fmt.Errorf("failed to fetch users: %w", err)
-		}
+func usersJob(db *database.DB, indexer *services.SearchIndexerService) backfill.Job[models.User] {
+	return backfill.Job[models.User]{
+		Name: "search:users",
+		Fetch: func(ctx context.Context, after string, limit int) ([]models.User, string, error) {
+			query := `
+				SELECT id, twitch_id, username, display_name, email, avatar_url,
+				       bio, karma_points, role, is_banned, created_at, updated_at, last_login_at
+				FROM users
+				WHERE is_banned = false`
+			args := []interface{}{limit}
+			if after != "" {
+				query += ` AND id > $2`
+				args = append(args, after)
+			}
+			query += `
+				ORDER BY id
+				LIMIT $1`
 
-		count := 0
-		for rows.Next() {
-			var user models.User
-			err := rows.Scan(
-				&user.ID, &user.TwitchID, &user.Username, &user.DisplayName,
-				&user.Email, &user.AvatarURL, &user.Bio, &user.KarmaPoints,
-				&user.Role, &user.IsBanned, &user.CreatedAt, &user.UpdatedAt,
-				&user.LastLoginAt,
-			)
+			rows, err := db.Pool.Query(ctx, query, args...)
 			if err != nil {
-				rows.Close()
-				return fmt.Errorf("failed to scan user: %w", err)
+				return nil, "", fmt.Errorf("failed to fetch users: %w", err)
 			}
-
-			if err := indexer.IndexUser(ctx, &user); err != nil {
-				log.Printf("WARNING: Failed to index user %s: %v", user.ID, err)
-			} else {
-				count++
+			defer rows.Close()
+
+			var users []models.User
+			for rows.Next() {
+				var user models.User
+				err := rows.Scan(
+					&user.ID, &user.TwitchID, &user.Username, &user.DisplayName,
+					&user.Email, &user.AvatarURL, &user.Bio, &user.KarmaPoints,
+					&user.Role, &user.IsBanned, &user.CreatedAt, &user.UpdatedAt,
+					&user.LastLoginAt,
+				)
+				if err != nil {
+					return nil, "", fmt.Errorf("failed to scan user: %w", err)
+				}
+				users = append(users, user)
 			}
-		}
-		rows.Close()
-
-		if count == 0 {
-			break
-		}
-
-		totalIndexed += count
-		log.Printf("Indexed %d users (total: %d)", count, totalIndexed)
-
-		offset += batchSize
-		time.Sleep(100 * time.Millisecond)
+			if err := rows.Err(); err != nil {
+				return nil, "", fmt.Errorf("failed to fetch users: %w", err)
+			}
+			if len(users) == 0 {
+				return nil, after, nil
+			}
+			return users, users[len(users)-1].ID.String(), nil
+		},
+		Process: func(ctx context.Context, users []models.User) (int, error) {
+			failed := 0
+			for i := range users {
+				if err := indexer.IndexUser(ctx, &users[i]); err != nil {
+					if ctx.Err() != nil {
+						return failed, ctx.Err()
+					}
+					log.Printf("WARNING: Failed to index user %s: %v", users[i].ID, err)
+					failed++
+				}
+			}
+			return failed, nil
+		},
 	}
-
-	log.Printf("Completed indexing %d users", totalIndexed)
-	return nil
 }
 
-func backfillTags(ctx context.Context, db *database.DB, indexer *services.SearchIndexerService, batchSize int) error {
-	offset := 0
-	totalIndexed := 0
-
-	for {
-		query := `
-			SELECT id, name, slug, description, color, usage_count, created_at
-			FROM tags
-			ORDER BY id
-			LIMIT $1 OFFSET $2
-		`
-
-		rows, err := db.Pool.Query(ctx, query, batchSize, offset)
-		if err != nil {
-			return fmt.Errorf("failed to fetch tags: %w", err)
-		}
+func tagsJob(db *database.DB, indexer *services.SearchIndexerService) backfill.Job[models.Tag] {
+	return backfill.Job[models.Tag]{
+		Name: "search:tags",
+		Fetch: func(ctx context.Context, after string, limit int) ([]models.Tag, string, error) {
+			query := `
+				SELECT id, name, slug, description, color, usage_count, created_at
+				FROM tags`
+			args := []interface{}{limit}
+			if after != "" {
+				query += ` WHERE id > $2`
+				args = append(args, after)
+			}
+			query += `
+				ORDER BY id
+				LIMIT $1`
 
-		count := 0
-		for rows.Next() {
-			var tag models.Tag
-			err := rows.Scan(
-				&tag.ID, &tag.Name, &tag.Slug, &tag.Description,
-				&tag.Color, &tag.UsageCount, &tag.CreatedAt,
-			)
+			rows, err := db.Pool.Query(ctx, query, args...)
 			if err != nil {
-				rows.Close()
-				return fmt.Errorf("failed to scan tag: %w", err)
+				return nil, "", fmt.Errorf("failed to fetch tags: %w", err)
 			}
-
-			if err := indexer.IndexTag(ctx, &tag); err != nil {
-				log.Printf("WARNING: Failed to index tag %s: %v", tag.ID, err)
-			} else {
-				count++
+			defer rows.Close()
+
+			var tags []models.Tag
+			for rows.Next() {
+				var tag models.Tag
+				err := rows.Scan(
+					&tag.ID, &tag.Name, &tag.Slug, &tag.Description,
+					&tag.Color, &tag.UsageCount, &tag.CreatedAt,
+				)
+				if err != nil {
+					return nil, "", fmt.Errorf("failed to scan tag: %w", err)
+				}
+				tags = append(tags, tag)
 			}
-		}
-		rows.Close()
-
-		if count == 0 {
-			break
-		}
-
-		totalIndexed += count
-		log.Printf("Indexed %d tags (total: %d)", count, totalIndexed)
-
-		offset += batchSize
-		time.Sleep(100 * time.Millisecond)
+			if err := rows.Err(); err != nil {
+				return nil, "", fmt.Errorf("failed to fetch tags: %w", err)
+			}
+			if len(tags) == 0 {
+				return nil, after, nil
+			}
+			return tags, tags[len(tags)-1].ID.String(), nil
+		},
+		Process: func(ctx context.Context, tags []models.Tag) (int, error) {
+			failed := 0
+			for i := range tags {
+				if err := indexer.IndexTag(ctx, &tags[i]); err != nil {
+					if ctx.Err() != nil {
+						return failed, ctx.Err()
+					}
+					log.Printf("WARNING: Failed to index tag %s: %v", tags[i].ID, err)
+					failed++
+				}
+			}
+			return failed, nil
+		},
 	}
-
-	log.Printf("Completed indexing %d tags", totalIndexed)
-	return nil
 }
 
-func backfillGames(ctx context.Context, db *database.DB, indexer *services.SearchIndexerService, batchSize int) error {
-	offset := 0
-	totalIndexed := 0
-
-	for {
-		query := `
-			SELECT game_id, game_name, COUNT(*) as clip_count
-			FROM clips
-			WHERE game_id IS NOT NULL AND game_name IS NOT NULL AND is_removed = false
-			GROUP BY game_id, game_name
-			ORDER BY game_id
-			LIMIT $1 OFFSET $2
-		`
-
-		rows, err := db.Pool.Query(ctx, query, batchSize, offset)
-		if err != nil {
-			return fmt.Errorf("failed to fetch games: %w", err)
-		}
-
-		count := 0
-		for rows.Next() {
-			var game models.GameSearchResult
-			err := rows.Scan(&game.ID, &game.Name, &game.ClipCount)
+func gamesJob(db *database.DB, indexer *services.SearchIndexerService) backfill.Job[models.GameSearchResult] {
+	return backfill.Job[models.GameSearchResult]{
+		Name: "search:games",
+		Fetch: func(ctx context.Context, after string, limit int) ([]models.GameSearchResult, string, error) {
+			query := `
+				SELECT game_id, game_name, COUNT(*) as clip_count
+				FROM clips
+				WHERE game_id IS NOT NULL AND game_name IS NOT NULL AND is_removed = false
+				  AND game_id > $2
+				GROUP BY game_id, game_name
+				ORDER BY game_id
+				LIMIT $1`
+
+			rows, err := db.Pool.Query(ctx, query, limit, after)
 			if err != nil {
-				rows.Close()
-				return fmt.Errorf("failed to scan game: %w", err)
+				return nil, "", fmt.Errorf("failed to fetch games: %w", err)
 			}
-
-			if err := indexer.IndexGameSearchResult(ctx, &game); err != nil {
-				log.Printf("WARNING: Failed to index game %s: %v", game.ID, err)
-			} else {
-				count++
+			defer rows.Close()
+
+			var games []models.GameSearchResult
+			for rows.Next() {
+				var game models.GameSearchResult
+				if err := rows.Scan(&game.ID, &game.Name, &game.ClipCount); err != nil {
+					return nil, "", fmt.Errorf("failed to scan game: %w", err)
+				}
+				games = append(games, game)
 			}
-		}
-		rows.Close()
-
-		if count == 0 {
-			break
-		}
-
-		totalIndexed += count
-		log.Printf("Indexed %d games (total: %d)", count, totalIndexed)
-
-		offset += batchSize
-		time.Sleep(100 * time.Millisecond)
+			if err := rows.Err(); err != nil {
+				return nil, "", fmt.Errorf("failed to fetch games: %w", err)
+			}
+			if len(games) == 0 {
+				return nil, after, nil
+			}
+			return games, games[len(games)-1].ID, nil
+		},
+		Process: func(ctx context.Context, games []models.GameSearchResult) (int, error) {
+			failed := 0
+			for i := range games {
+				if err := indexer.IndexGameSearchResult(ctx, &games[i]); err != nil {
+					if ctx.Err() != nil {
+						return failed, ctx.Err()
+					}
+					log.Printf("WARNING: Failed to index game %s: %v", games[i].ID, err)
+					failed++
+				}
+			}
+			return failed, nil
+		},
 	}
-
-	log.Printf("Completed indexing %d games", totalIndexed)
-	return nil
 }
@@ -3,36 +3,33 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/stripe/stripe-go/v81"
 	"github.com/stripe/stripe-go/v81/invoice"
 	stripeSub "github.com/stripe/stripe-go/v81/subscription"
 	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/backfill"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
 	"github.com/subculture-collective/clipper/pkg/database"
 )
 
-// BackfillStats tracks statistics for the backfill operation
-type BackfillStats struct {
-	TotalSubscriptions     int
-	ProcessedSubscriptions int
-	FailedSubscriptions    int
-	TotalInvoices          int
-	ProcessedInvoices      int
-	FailedInvoices         int
-	StartTime              time.Time
-	EndTime                time.Time
-	LastError              error
-}
-
 func main() {
 	dryRun := flag.Bool("dry-run", false, "Dry run mode - don't save to database")
-	limit := flag.Int("limit", 100, "Maximum number of records to sync per type")
+	limit := flag.Int("limit", 100, "Number of records to fetch from Stripe per page")
+	workers := flag.Int("workers", 1, "Number of pages to sync at once")
+	rateLimit := flag.Float64("rate", 0, "Most records to sync per second (0 for no limit)")
+	resume := flag.Bool("resume", false, "Continue from the last checkpoint instead of starting over")
 	flag.Parse()
 
 	log.Println("Starting Stripe metrics backfill job...")
-	log.Printf("Configuration: dry_run=%t, limit=%d", *dryRun, *limit)
+	log.Printf("Configuration: dry_run=%t, limit=%d, workers=%d, rate=%.1f/s, resume=%t", *dryRun, *limit, *workers, *rateLimit, *resume)
 
 	// Load configuration
 	cfg, err := config.Load()
@@ -56,259 +53,282 @@ func main() {
 	defer db.Close()
 	log.Println("Database connection established")
 
-	// Run backfill
-	ctx := context.Background()
-	stats, err := backfillStripeMetrics(ctx, db, *dryRun, *limit)
-	if err != nil {
-		log.Fatalf("Backfill failed: %v", err)
-	}
-
-	// Print summary
-	duration := stats.EndTime.Sub(stats.StartTime)
-	log.Println("\n=== Backfill Summary ===")
-	log.Printf("Subscriptions processed: %d/%d (failed: %d)", stats.ProcessedSubscriptions, stats.TotalSubscriptions, stats.FailedSubscriptions)
-	log.Printf("Invoices processed: %d/%d (failed: %d)", stats.ProcessedInvoices, stats.TotalInvoices, stats.FailedInvoices)
-	log.Printf("Duration: %v", duration)
-
-	if stats.LastError != nil {
-		log.Printf("Last error: %v", stats.LastError)
-	}
-
-	log.Println("\n✓ Backfill completed successfully!")
-}
+	// Stop at the next page on Ctrl+C or SIGTERM, keeping the checkpoint
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-func backfillStripeMetrics(
-	ctx context.Context,
-	db *database.DB,
-	dryRun bool,
-	limit int,
-) (*BackfillStats, error) {
-	stats := &BackfillStats{
-		StartTime: time.Now(),
+	// Dry runs save nothing, so they leave no checkpoint to resume from
+	var store backfill.StateStore
+	if !*dryRun {
+		store = repository.NewBackfillStateRepository(db.Pool)
 	}
+	opts := backfill.Options{BatchSize: *limit, Workers: *workers, Rate: *rateLimit, Resume: *resume}
+	start := time.Now()
 
 	// Sync subscriptions from Stripe
 	log.Println("\n--- Syncing Subscriptions ---")
-	if err := syncSubscriptions(ctx, db, stats, dryRun, limit); err != nil {
-		log.Printf("WARNING: Error syncing subscriptions: %v", err)
-		stats.LastError = err
+	subscriptions, subErr := backfill.Run(ctx, store, subscriptionsJob(db, *dryRun), opts)
+	if subErr != nil {
+		log.Printf("WARNING: Error syncing subscriptions: %v", subErr)
 	}
 
 	// Sync paid invoices from Stripe
-	log.Println("\n--- Syncing Invoices ---")
-	if err := syncInvoices(ctx, db, stats, dryRun, limit); err != nil {
-		log.Printf("WARNING: Error syncing invoices: %v", err)
-		stats.LastError = err
+	var invoices *models.BackfillState
+	var invErr error
+	if !backfill.IsStopped(subErr) {
+		log.Println("\n--- Syncing Invoices ---")
+		invoices, invErr = backfill.Run(ctx, store, invoicesJob(db, *dryRun), opts)
+		if invErr != nil {
+			log.Printf("WARNING: Error syncing invoices: %v", invErr)
+		}
 	}
 
-	stats.EndTime = time.Now()
-	return stats, nil
+	// Print summary
+	log.Println("\n=== Backfill Summary ===")
+	if subscriptions != nil {
+		log.Printf("Subscriptions processed: %d (failed: %d)", subscriptions.Processed, subscriptions.Failed)
+	}
+	if invoices != nil {
+		log.Printf("Invoices processed: %d (failed: %d)", invoices.Processed, invoices.Failed)
+	}
+	log.Printf("Duration: %v", time.Since(start))
+
+	if subErr != nil || invErr != nil {
+		log.Println("\nBackfill did not finish; run again with -resume to continue")
+		os.Exit(1)
+	}
+	log.Println("\n✓ Backfill completed successfully!")
 }
 
-func syncSubscriptions(
-	ctx context.Context,
-	db *database.DB,
-	stats *BackfillStats,
-	dryRun bool,
-	limit int,
-) error {
-	// List subscriptions from Stripe
-	params := &stripe.SubscriptionListParams{
-		ListParams: stripe.ListParams{
-			Limit: stripe.Int64(int64(limit)),
+// subscriptionsJob syncs subscriptions page by page, newest first
+func subscriptionsJob(db *database.DB, dryRun bool) backfill.Job[*stripe.Subscription] {
+	return backfill.Job[*stripe.Subscription]{
+		Name: "stripe-metrics:subscriptions",
+		Fetch: func(ctx context.Context, after string, limit int) ([]*stripe.Subscription, string, error) {
+			params := &stripe.SubscriptionListParams{
+				ListParams: stripe.ListParams{
+					Context: ctx,
+					Limit:   stripe.Int64(int64(limit)),
+					Single:  true,
+				},
+			}
+			if after != "" {
+				params.StartingAfter = stripe.String(after)
+			}
+			params.AddExpand("data.customer")
+
+			var subs []*stripe.Subscription
+			i := stripeSub.List(params)
+			for i.Next() {
+				subs = append(subs, i.Subscription())
+			}
+			if err := i.Err(); err != nil {
+				return nil, "", err
+			}
+			if len(subs) == 0 {
+				return nil, after, nil
+			}
+			return subs, subs[len(subs)-1].ID, nil
+		},
+		Process: func(ctx context.Context, subs []*stripe.Subscription) (int, error) {
+			failed := 0
+			for _, sub := range subs {
+				if err := syncSubscription(ctx, db, sub, dryRun); err != nil {
+					if ctx.Err() != nil {
+						return failed, ctx.Err()
+					}
+					log.Printf("Failed to sync subscription %s: %v", sub.ID, err)
+					failed++
+				}
+			}
+			return failed, nil
 		},
 	}
-	params.AddExpand("data.customer")
-
-	i := stripeSub.List(params)
-	for i.Next() {
-		stats.TotalSubscriptions++
-		sub := i.Subscription()
-
-		log.Printf("Processing subscription: %s (status: %s, customer: %s)",
-			sub.ID, sub.Status, sub.Customer.ID)
-
-		if dryRun {
-			stats.ProcessedSubscriptions++
-			continue
-		}
+}
 
-		// Check if we have this customer in our database
-		var userID string
-		query := `SELECT user_id::text FROM subscriptions WHERE stripe_customer_id = $1`
-		err := db.Pool.QueryRow(ctx, query, sub.Customer.ID).Scan(&userID)
-		if err != nil {
-			log.Printf("No local user found for customer %s, skipping", sub.Customer.ID)
-			stats.FailedSubscriptions++
-			continue
-		}
+// invoicesJob syncs paid invoices page by page, newest first
+func invoicesJob(db *database.DB, dryRun bool) backfill.Job[*stripe.Invoice] {
+	return backfill.Job[*stripe.Invoice]{
+		Name: "stripe-metrics:invoices",
+		Fetch: func(ctx context.Context, after string, limit int) ([]*stripe.Invoice, string, error) {
+			params := &stripe.InvoiceListParams{
+				ListParams: stripe.ListParams{
+					Context: ctx,
+					Limit:   stripe.Int64(int64(limit)),
+					Single:  true,
+				},
+				Status: stripe.String("paid"),
+			}
+			if after != "" {
+				params.StartingAfter = stripe.String(after)
+			}
+			params.AddExpand("data.subscription")
+			params.AddExpand("data.customer")
+
+			var invoices []*stripe.Invoice
+			i := invoice.List(params)
+			for i.Next() {
+				invoices = append(invoices, i.Invoice())
+			}
+			if err := i.Err(); err != nil {
+				return nil, "", err
+			}
+			if len(invoices) == 0 {
+				return nil, after, nil
+			}
+			return invoices, invoices[len(invoices)-1].ID, nil
+		},
+		Process: func(ctx context.Context, invoices []*stripe.Invoice) (int, error) {
+			failed := 0
+			for _, inv := range invoices {
+				if err := syncInvoice(ctx, db, inv, dryRun); err != nil {
+					if ctx.Err() != nil {
+						return failed, ctx.Err()
+					}
+					log.Printf("Failed to sync invoice %s: %v", inv.ID, err)
+					failed++
+				}
+			}
+			return failed, nil
+		},
+	}
+}
 
-		// Update subscription in our database
-		updateQuery := `
-			UPDATE subscriptions
-			SET stripe_subscription_id = $1,
-			    stripe_price_id = $2,
-			    status = $3,
-			    tier = $4,
-			    current_period_start = $5,
-			    current_period_end = $6,
-			    cancel_at_period_end = $7,
-			    canceled_at = $8,
-			    trial_start = $9,
-			    trial_end = $10,
-			    updated_at = NOW()
-			WHERE stripe_customer_id = $11
-		`
-
-		var priceID string
-		if len(sub.Items.Data) > 0 && sub.Items.Data[0].Price != nil {
-			priceID = sub.Items.Data[0].Price.ID
-		}
+func syncSubscription(ctx context.Context, db *database.DB, sub *stripe.Subscription, dryRun bool) error {
+	log.Printf("Processing subscription: %s (status: %s, customer: %s)",
+		sub.ID, sub.Status, sub.Customer.ID)
 
-		tier := "free"
-		if sub.Status == stripe.SubscriptionStatusActive || sub.Status == stripe.SubscriptionStatusTrialing || sub.Status == stripe.SubscriptionStatusCanceled {
-			tier = "pro" // Preserve pro tier for canceled subs for historical revenue data
-		}
+	if dryRun {
+		return nil
+	}
 
-		var canceledAt *time.Time
-		if sub.CanceledAt > 0 {
-			t := time.Unix(sub.CanceledAt, 0)
-			canceledAt = &t
-		}
+	// Check if we have this customer in our database
+	var userID string
+	query := `SELECT user_id::text FROM subscriptions WHERE stripe_customer_id = $1`
+	err := db.Pool.QueryRow(ctx, query, sub.Customer.ID).Scan(&userID)
+	if err != nil {
+		return fmt.Errorf("no local user found for customer %s: %w", sub.Customer.ID, err)
+	}
 
-		var trialStart, trialEnd *time.Time
-		if sub.TrialStart > 0 {
-			t := time.Unix(sub.TrialStart, 0)
-			trialStart = &t
-		}
-		if sub.TrialEnd > 0 {
-			t := time.Unix(sub.TrialEnd, 0)
-			trialEnd = &t
-		}
+	// Update subscription in our database
+	updateQuery := `
+		UPDATE subscriptions
+		SET stripe_subscription_id = $1,
+		    stripe_price_id = $2,
+		    status = $3,
+		    tier = $4,
+		    current_period_start = $5,
+		    current_period_end = $6,
+		    cancel_at_period_end = $7,
+		    canceled_at = $8,
+		    trial_start = $9,
+		    trial_end = $10,
+		    updated_at = NOW()
+		WHERE stripe_customer_id = $11
+	`
+
+	var priceID string
+	if len(sub.Items.Data) > 0 && sub.Items.Data[0].Price != nil {
+		priceID = sub.Items.Data[0].Price.ID
+	}
 
-		periodStart := time.Unix(sub.CurrentPeriodStart, 0)
-		periodEnd := time.Unix(sub.CurrentPeriodEnd, 0)
-
-		_, err = db.Pool.Exec(ctx, updateQuery,
-			sub.ID,
-			priceID,
-			string(sub.Status),
-			tier,
-			periodStart,
-			periodEnd,
-			sub.CancelAtPeriodEnd,
-			canceledAt,
-			trialStart,
-			trialEnd,
-			sub.Customer.ID,
-		)
-		if err != nil {
-			log.Printf("Failed to update subscription %s: %v", sub.ID, err)
-			stats.FailedSubscriptions++
-			stats.LastError = err
-			continue
-		}
+	tier := "free"
+	if sub.Status == stripe.SubscriptionStatusActive || sub.Status == stripe.SubscriptionStatusTrialing || sub.Status == stripe.SubscriptionStatusCanceled {
+		tier = "pro" // Preserve pro tier for canceled subs for historical revenue data
+	}
 
-		stats.ProcessedSubscriptions++
+	var canceledAt *time.Time
+	if sub.CanceledAt > 0 {
+		t := time.Unix(sub.CanceledAt, 0)
+		canceledAt = &t
 	}
 
-	if err := i.Err(); err != nil {
-		return err
+	var trialStart, trialEnd *time.Time
+	if sub.TrialStart > 0 {
+		t := time.Unix(sub.TrialStart, 0)
+		trialStart = &t
+	}
+	if sub.TrialEnd > 0 {
+		t := time.Unix(sub.TrialEnd, 0)
+		trialEnd = &t
 	}
 
+	periodStart := time.Unix(sub.CurrentPeriodStart, 0)
+	periodEnd := time.Unix(sub.CurrentPeriodEnd, 0)
+
+	_, err = db.Pool.Exec(ctx, updateQuery,
+		sub.ID,
+		priceID,
+		string(sub.Status),
+		tier,
+		periodStart,
+		periodEnd,
+		sub.CancelAtPeriodEnd,
+		canceledAt,
+		trialStart,
+		trialEnd,
+		sub.Customer.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update subscription %s: %w", sub.ID, err)
+	}
 	return nil
 }
 
-func syncInvoices(
-	ctx context.Context,
-	db *database.DB,
-	stats *BackfillStats,
-	dryRun bool,
-	limit int,
-) error {
-	// List paid invoices from Stripe
-	params := &stripe.InvoiceListParams{
-		ListParams: stripe.ListParams{
-			Limit: stripe.Int64(int64(limit)),
-		},
-		Status: stripe.String("paid"),
+func syncInvoice(ctx context.Context, db *database.DB, inv *stripe.Invoice, dryRun bool) error {
+	if inv.Subscription == nil {
+		log.Printf("Invoice %s has no subscription, skipping", inv.ID)
+		return nil
 	}
-	params.AddExpand("data.subscription")
-	params.AddExpand("data.customer")
 
-	i := invoice.List(params)
-	for i.Next() {
-		stats.TotalInvoices++
-		inv := i.Invoice()
+	log.Printf("Processing invoice: %s (amount: %d, customer: %s)",
+		inv.ID, inv.AmountPaid, inv.Customer.ID)
 
-		if inv.Subscription == nil {
-			log.Printf("Invoice %s has no subscription, skipping", inv.ID)
-			continue
-		}
-
-		log.Printf("Processing invoice: %s (amount: %d, customer: %s)",
-			inv.ID, inv.AmountPaid, inv.Customer.ID)
-
-		if dryRun {
-			stats.ProcessedInvoices++
-			continue
-		}
-
-		// Check if we have this subscription in our database
-		var subscriptionID string
-		query := `SELECT id::text FROM subscriptions WHERE stripe_subscription_id = $1`
-		err := db.Pool.QueryRow(ctx, query, inv.Subscription.ID).Scan(&subscriptionID)
-		if err != nil {
-			log.Printf("No local subscription found for Stripe subscription %s, skipping", inv.Subscription.ID)
-			stats.FailedInvoices++
-			continue
-		}
-
-		// Check if we already have this event logged
-		eventQuery := `SELECT id FROM subscription_events WHERE stripe_event_id = $1`
-		var existingID string
-		err = db.Pool.QueryRow(ctx, eventQuery, inv.ID).Scan(&existingID)
-		if err == nil {
-			log.Printf("Invoice %s already logged, skipping", inv.ID)
-			stats.ProcessedInvoices++
-			continue
-		}
-
-		// Log the invoice as a subscription event
-		insertQuery := `
-			INSERT INTO subscription_events (subscription_id, event_type, stripe_event_id, payload)
-			VALUES ($1::uuid, $2, $3, $4::jsonb)
-		`
-
-		// Create a simple payload
-		payload := map[string]interface{}{
-			"invoice_id":      inv.ID,
-			"amount_paid":     inv.AmountPaid,
-			"currency":        inv.Currency,
-			"customer_id":     inv.Customer.ID,
-			"subscription_id": inv.Subscription.ID,
-			"created":         time.Unix(inv.Created, 0).Format(time.RFC3339),
-		}
+	if dryRun {
+		return nil
+	}
 
-		_, err = db.Pool.Exec(ctx, insertQuery,
-			subscriptionID,
-			"invoice_paid",
-			inv.ID,
-			payload,
-		)
-		if err != nil {
-			log.Printf("Failed to log invoice %s: %v", inv.ID, err)
-			stats.FailedInvoices++
-			stats.LastError = err
-			continue
-		}
+	// Check if we have this subscription in our database
+	var subscriptionID string
+	query := `SELECT id::text FROM subscriptions WHERE stripe_subscription_id = $1`
+	err := db.Pool.QueryRow(ctx, query, inv.Subscription.ID).Scan(&subscriptionID)
+	if err != nil {
+		return fmt.Errorf("no local subscription found for Stripe subscription %s: %w", inv.Subscription.ID, err)
+	}
 
-		stats.ProcessedInvoices++
+	// Check if we already have this event logged
+	eventQuery := `SELECT id FROM subscription_events WHERE stripe_event_id = $1`
+	var existingID string
+	err = db.Pool.QueryRow(ctx, eventQuery, inv.ID).Scan(&existingID)
+	if err == nil {
+		log.Printf("Invoice %s already logged, skipping", inv.ID)
+		return nil
 	}
 
-	if err := i.Err(); err != nil {
-		return err
+	// Log the invoice as a subscription event
+	insertQuery := `
+		INSERT INTO subscription_events (subscription_id, event_type, stripe_event_id, payload)
+		VALUES ($1::uuid, $2, $3, $4::jsonb)
+	`
+
+	// Create a simple payload
+	payload := map[string]interface{}{
+		"invoice_id":      inv.ID,
+		"amount_paid":     inv.AmountPaid,
+		"currency":        inv.Currency,
+		"customer_id":     inv.Customer.ID,
+		"subscription_id": inv.Subscription.ID,
+		"created":         time.Unix(inv.Created, 0).Format(time.RFC3339),
 	}
 
+	_, err = db.Pool.Exec(ctx, insertQuery,
+		subscriptionID,
+		"invoice_paid",
+		inv.ID,
+		payload,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to log invoice %s: %w", inv.ID, err)
+	}
 	return nil
 }
@@ -9,6 +9,8 @@ import (
 	"os"
 	"time"
 
+	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/searchlive"
 	"github.com/subculture-collective/clipper/internal/services"
 )
 
@@ -31,6 +33,7 @@ type BaselineConfiguration struct {
 	GameBoost       float64 `json:"game_boost"`
 	EngagementBoost float64 `json:"engagement_boost"`
 	RecencyBoost    float64 `json:"recency_boost"`
+	HypeBoost       float64 `json:"hype_boost"`
 	Description     string  `json:"description"`
 }
 
@@ -39,6 +42,7 @@ func main() {
 	datasetPath := flag.String("dataset", "testdata/search_evaluation_dataset.yaml", "Path to evaluation dataset YAML file")
 	outputPath := flag.String("output", "baseline-search-metrics.json", "Path to output JSON file")
 	notes := flag.String("notes", "Baseline capture for hybrid search weight optimization", "Notes about this baseline capture")
+	liveMode := flag.Bool("live", false, "Run queries against live search using the database, OpenSearch and embedding settings from the environment")
 	help := flag.Bool("help", false, "Show help message")
 
 	flag.Parse()
@@ -51,8 +55,26 @@ func main() {
 	log.Println("Capturing baseline search metrics...")
 	log.Printf("Dataset: %s", *datasetPath)
 
-	// Create evaluation service
-	evalService := services.NewSearchEvaluationService(nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	// Create evaluation service, connected to live search when requested
+	var hybridSearch *services.HybridSearchService
+	weights := services.DefaultConfigs()[0]
+	if *liveMode {
+		cfg, err := config.Load()
+		if err != nil {
+			log.Fatalf("Failed to load configuration: %v", err)
+		}
+		stack, err := searchlive.Connect(ctx, cfg)
+		if err != nil {
+			log.Fatalf("Failed to connect to live search: %v", err)
+		}
+		defer stack.Close()
+		hybridSearch = stack.Search
+		weights = services.ConfiguredWeights(&cfg.HybridSearch)
+	}
+	evalService := services.NewSearchEvaluationService(hybridSearch)
 
 	// Load dataset
 	if err := evalService.LoadDataset(*datasetPath); err != nil {
@@ -63,25 +85,30 @@ func main() {
 	log.Printf("Loaded %d evaluation queries", len(dataset.EvaluationQueries))
 
 	// Run evaluation with current/baseline configuration
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-	defer cancel()
-
-	report, err := evalService.EvaluateWithSimulatedResults(ctx)
+	var report *services.EvaluationReport
+	var err error
+	description := "Current production configuration (baseline)"
+	if *liveMode {
+		log.Println("Running evaluation against live search...")
+		report, err = evalService.EvaluateWithLiveSearch(ctx, weights)
+		description = "HYBRID_SEARCH_* settings evaluated against live search"
+	} else {
+		report, err = evalService.EvaluateWithSimulatedResults(ctx)
+	}
 	if err != nil {
 		log.Fatalf("Evaluation failed: %v", err)
 	}
 
-	// Get current configuration from search_ab_testing.go DefaultConfigs
-	// Using "baseline" configuration as reference
 	currentConfig := BaselineConfiguration{
-		BM25Weight:      0.7,
-		VectorWeight:    0.3,
-		TitleBoost:      3.0,
-		CreatorBoost:    2.0,
-		GameBoost:       1.0,
-		EngagementBoost: 0.1,
-		RecencyBoost:    0.5,
-		Description:     "Current production configuration (baseline)",
+		BM25Weight:      weights.BM25Weight,
+		VectorWeight:    weights.VectorWeight,
+		TitleBoost:      weights.TitleBoost,
+		CreatorBoost:    weights.CreatorBoost,
+		GameBoost:       weights.GameBoost,
+		EngagementBoost: weights.EngagementBoost,
+		RecencyBoost:    weights.RecencyBoost,
+		HypeBoost:       weights.HypeBoost,
+		Description:     description,
 	}
 
 	// Create baseline report
@@ -124,6 +151,9 @@ func printUsage() {
 	fmt.Println("  # Capture baseline with custom notes")
 	fmt.Println("  capture-baseline-search -notes \"Pre-optimization baseline\" -output baseline.json")
 	fmt.Println()
+	fmt.Println("  # Capture a live baseline for a live grid search")
+	fmt.Println("  capture-baseline-search -live -output baseline.json")
+	fmt.Println()
 	fmt.Println("  # Use baseline for comparison")
 	fmt.Println("  grid-search-hybrid-search -baseline baseline.json")
 }
@@ -144,6 +174,7 @@ func printSummary(report *BaselineReport) {
 	fmt.Printf("  Game Boost:       %.1f\n", report.Configuration.GameBoost)
 	fmt.Printf("  Engagement Boost: %.2f\n", report.Configuration.EngagementBoost)
 	fmt.Printf("  Recency Boost:    %.2f\n", report.Configuration.RecencyBoost)
+	fmt.Printf("  Hype Boost:       %.2f\n", report.Configuration.HypeBoost)
 	fmt.Println()
 
 	fmt.Println("Baseline Metrics:")
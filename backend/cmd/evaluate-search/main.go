@@ -9,6 +9,8 @@ import (
 	"os"
 	"time"
 
+	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/searchlive"
 	"github.com/subculture-collective/clipper/internal/services"
 )
 
@@ -16,7 +18,9 @@ func main() {
 	// Command line flags
 	datasetPath := flag.String("dataset", "testdata/search_evaluation_dataset.yaml", "Path to evaluation dataset YAML file")
 	outputPath := flag.String("output", "", "Path to output JSON file (optional, defaults to stdout)")
-	simulateMode := flag.Bool("simulate", true, "Use simulated results (no live search)")
+	simulateMode := flag.Bool("simulate", true, "Use simulated results (no live search); -simulate=false is the same as -live")
+	liveMode := flag.Bool("live", false, "Run queries against live search using the database, OpenSearch and embedding settings from the environment")
+	personalization := flag.Bool("personalization", false, "With -live, compare the queries that have a personalization profile searched anonymously and personalized")
 	verbose := flag.Bool("verbose", false, "Print detailed results for each query")
 	help := flag.Bool("help", false, "Show help message")
 
@@ -27,8 +31,28 @@ func main() {
 		os.Exit(0)
 	}
 
-	// Create evaluation service
-	evalService := services.NewSearchEvaluationService(nil)
+	useLive := *liveMode || !*simulateMode
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	// Create evaluation service, connected to live search when requested
+	var hybridSearch *services.HybridSearchService
+	var weights services.SearchWeightConfig
+	if useLive {
+		cfg, err := config.Load()
+		if err != nil {
+			log.Fatalf("Failed to load configuration: %v", err)
+		}
+		stack, err := searchlive.Connect(ctx, cfg)
+		if err != nil {
+			log.Fatalf("Failed to connect to live search: %v", err)
+		}
+		defer stack.Close()
+		hybridSearch = stack.Search
+		weights = services.ConfiguredWeights(&cfg.HybridSearch)
+	}
+	evalService := services.NewSearchEvaluationService(hybridSearch)
 
 	// Load dataset
 	log.Printf("Loading evaluation dataset from: %s", *datasetPath)
@@ -39,20 +63,23 @@ func main() {
 	dataset := evalService.GetDataset()
 	log.Printf("Loaded %d evaluation queries", len(dataset.EvaluationQueries))
 
-	// Run evaluation
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-	defer cancel()
+	if *personalization {
+		if !useLive {
+			log.Fatal("-personalization requires -live")
+		}
+		runPersonalizationComparison(ctx, evalService, weights, *outputPath, *verbose)
+		return
+	}
 
+	// Run evaluation
 	var report *services.EvaluationReport
 	var err error
 
-	if *simulateMode {
-		log.Println("Running evaluation with simulated (ideal) results...")
-		report, err = evalService.EvaluateWithSimulatedResults(ctx)
+	if useLive {
+		log.Printf("Running evaluation against live search (BM25=%.2f, Vector=%.2f)...", weights.BM25Weight, weights.VectorWeight)
+		report, err = evalService.EvaluateWithLiveSearch(ctx, weights)
 	} else {
-		// Live mode would require database connection
-		// For now, fall back to simulated mode
-		log.Println("Live search mode not implemented, using simulated results...")
+		log.Println("Running evaluation with simulated (ideal) results...")
 		report, err = evalService.EvaluateWithSimulatedResults(ctx)
 	}
 
@@ -100,6 +127,40 @@ func printUsage() {
 	fmt.Println()
 	fmt.Println("  # Run with verbose output")
 	fmt.Println("  evaluate-search -verbose")
+	fmt.Println()
+	fmt.Println("  # Score the HYBRID_SEARCH_* weights against live search")
+	fmt.Println("  evaluate-search -live -verbose")
+	fmt.Println()
+	fmt.Println("  # Measure how personalization changes the personalized queries")
+	fmt.Println("  evaluate-search -live -personalization")
+}
+
+// runPersonalizationComparison evaluates the personalized queries of the
+// dataset with and without their profile and prints the difference
+func runPersonalizationComparison(ctx context.Context, evalService *services.SearchEvaluationService, weights services.SearchWeightConfig, outputPath string, verbose bool) {
+	log.Println("Comparing anonymous and personalized live search...")
+	comparison, err := evalService.EvaluatePersonalization(ctx, weights)
+	if err != nil {
+		log.Fatalf("Evaluation failed: %v", err)
+	}
+
+	fmt.Println()
+	fmt.Println("Without personalization:")
+	printResults(comparison.Baseline, verbose)
+	fmt.Println("With personalization:")
+	printResults(comparison.Personalized, verbose)
+	fmt.Println("Personalization Lift:")
+	fmt.Println("-----------------------------------------")
+	fmt.Printf("  nDCG@10: %+.4f\n", comparison.NDCG10Lift)
+	fmt.Printf("  MRR:     %+.4f\n", comparison.MRRLift)
+	fmt.Println()
+
+	if outputPath != "" {
+		if err := writeOutputFile(outputPath, comparison); err != nil {
+			log.Fatalf("Failed to write output file: %v", err)
+		}
+		log.Printf("Results written to: %s", outputPath)
+	}
 }
 
 func printResults(report *services.EvaluationReport, verbose bool) {
@@ -207,7 +268,7 @@ func hasFailures(report *services.EvaluationReport) bool {
 	return false
 }
 
-func writeOutputFile(path string, report *services.EvaluationReport) error {
+func writeOutputFile(path string, report any) error {
 	data, err := json.MarshalIndent(report, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal report: %w", err)
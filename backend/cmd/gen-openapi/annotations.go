@@ -0,0 +1,160 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+)
+
+// annotations are the swag-style @ lines of a handler doc comment
+type annotations struct {
+	Summary     string
+	Description string
+	Params      []annotationParam
+	Responses   []annotationResponse
+}
+
+// annotationParam is an @Param line: name in type required "description"
+type annotationParam struct {
+	Name        string
+	In          string
+	Type        string
+	Required    bool
+	Description string
+}
+
+// annotationResponse is an @Success or @Failure line: status {kind} type "description"
+type annotationResponse struct {
+	Status      int
+	Kind        string
+	Type        string
+	Description string
+}
+
+// parseAnnotations parses the @ lines of a doc comment, returning nil when it
+// has none
+func parseAnnotations(doc string) *annotations {
+	var a *annotations
+	for _, line := range strings.Split(doc, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "@") {
+			continue
+		}
+		if a == nil {
+			a = &annotations{}
+		}
+		tag, rest, _ := strings.Cut(line, " ")
+		rest = strings.TrimSpace(rest)
+		switch tag {
+		case "@Summary":
+			a.Summary = rest
+		case "@Description":
+			if a.Description != "" {
+				a.Description += "\n"
+			}
+			a.Description += rest
+		case "@Param":
+			fields := splitAnnotation(rest)
+			if len(fields) < 3 {
+				continue
+			}
+			p := annotationParam{Name: fields[0], In: fields[1], Type: fields[2]}
+			if len(fields) > 3 {
+				p.Required, _ = strconv.ParseBool(fields[3])
+			}
+			if len(fields) > 4 {
+				p.Description = fields[4]
+			}
+			a.Params = append(a.Params, p)
+		case "@Success", "@Failure":
+			fields := splitAnnotation(rest)
+			if len(fields) == 0 {
+				continue
+			}
+			status, err := strconv.Atoi(fields[0])
+			if err != nil {
+				continue
+			}
+			r := annotationResponse{Status: status}
+			for _, f := range fields[1:] {
+				switch {
+				case strings.HasPrefix(f, "{") && strings.HasSuffix(f, "}"):
+					r.Kind = strings.Trim(f, "{}")
+				case r.Kind != "" && r.Type == "":
+					r.Type = f
+				default:
+					r.Description = f
+				}
+			}
+			a.Responses = append(a.Responses, r)
+		}
+	}
+	return a
+}
+
+// splitAnnotation splits on whitespace, keeping double-quoted strings whole
+func splitAnnotation(s string) []string {
+	var fields []string
+	var current strings.Builder
+	inQuotes := false
+	flush := func() {
+		if current.Len() > 0 {
+			fields = append(fields, current.String())
+			current.Reset()
+		}
+	}
+	for _, r := range s {
+		switch {
+		case r == '"':
+			if inQuotes {
+				fields = append(fields, current.String())
+				current.Reset()
+			} else {
+				flush()
+			}
+			inQuotes = !inQuotes
+		case (r == ' ' || r == '\t') && !inQuotes:
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+	return fields
+}
+
+// docSummary returns the summary and description of a handler from its doc
+// comment, skipping @ annotations and "METHOD /path" lines. A leading method
+// name is dropped, so "GetRound returns a round" becomes "Returns a round".
+func docSummary(doc, method string) (string, string) {
+	var lines []string
+	for _, line := range strings.Split(doc, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "@") || isRouteLine(line) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return "", ""
+	}
+
+	summary := lines[0]
+	if rest, ok := strings.CutPrefix(summary, method+" "); ok {
+		summary = rest
+	}
+	// "ListClips handles GET /clips" says nothing the route doesn't
+	if rest, ok := strings.CutPrefix(summary, "handles "); ok && isRouteLine(rest) {
+		return "", strings.Join(lines[1:], "\n")
+	}
+	summary = strings.TrimSuffix(summary, ".")
+	if summary != "" {
+		summary = strings.ToUpper(summary[:1]) + summary[1:]
+	}
+	return summary, strings.Join(lines[1:], "\n")
+}
+
+// isRouteLine matches doc lines such as "GET /api/v1/clips/:id"
+func isRouteLine(line string) bool {
+	method, path, ok := strings.Cut(line, " ")
+	return ok && routeMethods[method] && strings.HasPrefix(path, "/")
+}
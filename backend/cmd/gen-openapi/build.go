@@ -0,0 +1,447 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/subculture-collective/clipper/internal/openapi"
+)
+
+const (
+	bearerScheme = "bearerAuth"
+	apiKeyScheme = "apiKeyAuth"
+)
+
+// generate builds the specification from the backend source tree at root
+func generate(root string) (*openapi.Document, []string, error) {
+	routes, err := parseRoutes(filepath.Join(root, "cmd", "api"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse routes: %w", err)
+	}
+	fields, err := handlerFields(filepath.Join(root, "cmd", "api", "handlers.go"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse handler fields: %w", err)
+	}
+	handlers, err := parseHandlers(filepath.Join(root, "internal", "handlers"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse handlers: %w", err)
+	}
+	types := newTypeIndex()
+	for _, pkg := range []string{"models", "handlers"} {
+		if err := types.addPackage(filepath.Join(root, "internal", pkg), pkg); err != nil {
+			return nil, nil, fmt.Errorf("parse %s: %w", pkg, err)
+		}
+	}
+
+	b := &docBuilder{
+		fields:   fields,
+		handlers: handlers,
+		types:    types,
+		schemas:  newSchemaBuilder(types),
+		opIDs:    map[string]int{},
+
+		errorResponses: map[string]*openapi.Response{},
+	}
+	doc := b.build(routes)
+	return doc, b.warnings, nil
+}
+
+// docBuilder turns parsed routes into an OpenAPI document
+type docBuilder struct {
+	fields   map[string]string
+	handlers *handlerIndex
+	types    *typeIndex
+	schemas  *schemaBuilder
+	opIDs    map[string]int
+	warnings []string
+
+	errorResponses map[string]*openapi.Response
+}
+
+func (b *docBuilder) build(routes []route) *openapi.Document {
+	doc := &openapi.Document{
+		OpenAPI: openapi.Version,
+		Info: openapi.Info{
+			Title:       "Clipper API",
+			Description: "Generated from the API route table by cmd/gen-openapi. Do not edit by hand.",
+			Version:     "1.0.0",
+		},
+		Servers: []openapi.Server{{URL: "/", Description: "Current host"}},
+		Paths:   map[string]*openapi.PathItem{},
+	}
+
+	tags := map[string]bool{}
+	for _, r := range routes {
+		path, params := openAPIPath(r.Path)
+		item := doc.Paths[path]
+		if item == nil {
+			item = &openapi.PathItem{}
+			doc.Paths[path] = item
+		}
+		if existing := operationFor(item, r.Method); existing != nil {
+			// Routes registered in alternative branches share a path; keep the first
+			continue
+		}
+
+		op := b.operation(r, params)
+		if !item.SetOperation(r.Method, op) {
+			b.warnings = append(b.warnings, fmt.Sprintf("%s: unsupported method %s", r.Pos, r.Method))
+			continue
+		}
+		for _, tag := range op.Tags {
+			tags[tag] = true
+		}
+	}
+
+	names := make([]string, 0, len(tags))
+	for tag := range tags {
+		names = append(names, tag)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		doc.Tags = append(doc.Tags, openapi.Tag{Name: name})
+	}
+
+	b.schemas.components[errorSchemaName] = &openapi.Schema{
+		Type:       "object",
+		Properties: map[string]*openapi.Schema{"error": {Type: "string"}},
+		Required:   []string{"error"},
+	}
+	doc.Components = openapi.Components{
+		Schemas:   b.schemas.components,
+		Responses: b.errorResponses,
+		SecuritySchemes: map[string]*openapi.SecurityScheme{
+			bearerScheme: {Type: "http", Scheme: "bearer", BearerFormat: "JWT", Description: "Access token from the auth endpoints"},
+			apiKeyScheme: {Type: "apiKey", In: "header", Name: "X-API-Key", Description: "Personal API key with the scope listed on the operation"},
+		},
+	}
+	return doc
+}
+
+// operation builds the operation for a route
+func (b *docBuilder) operation(r route, pathParams []string) *openapi.Operation {
+	op := &openapi.Operation{
+		Tags:      []string{routeTag(r.Path)},
+		Responses: map[string]*openapi.Response{},
+	}
+
+	var fn *handlerFunc
+	name := ""
+	if r.Handler != nil {
+		name = r.Handler.Field + "." + r.Handler.Method
+		op.Handler = b.fields[r.Handler.Field] + "." + r.Handler.Method
+		fn = b.handlers.method(b.fields[r.Handler.Field], r.Handler.Method)
+		if fn == nil {
+			b.warnings = append(b.warnings, fmt.Sprintf("%s: handler %s not found", r.Pos, op.Handler))
+		}
+	}
+	op.OperationID = b.operationID(r, name)
+
+	var ann *annotations
+	if fn != nil {
+		ann = fn.Annotations
+		op.Summary, op.Description = docSummary(fn.Doc, r.Handler.Method)
+	}
+	if ann != nil && ann.Summary != "" {
+		op.Summary = ann.Summary
+	}
+	if ann != nil && ann.Description != "" {
+		op.Description = ann.Description
+	}
+	if op.Summary == "" && r.Handler != nil {
+		op.Summary = humanize(r.Handler.Method)
+	}
+	if op.Summary == "" {
+		op.Summary = humanize(op.OperationID)
+	}
+
+	b.addParameters(op, r, pathParams, fn, ann)
+	b.addResponses(op, fn, ann)
+	b.applyMiddleware(op, r)
+
+	if len(op.Responses) == 0 {
+		op.Responses["200"] = &openapi.Response{Description: http.StatusText(http.StatusOK)}
+	}
+	return op
+}
+
+// addParameters adds path and query parameters and the request body
+func (b *docBuilder) addParameters(op *openapi.Operation, r route, pathParams []string, fn *handlerFunc, ann *annotations) {
+	annotated := map[string]annotationParam{}
+	if ann != nil {
+		for _, p := range ann.Params {
+			annotated[p.In+":"+p.Name] = p
+		}
+	}
+
+	for _, name := range pathParams {
+		p := openapi.Parameter{Name: name, In: "path", Required: true, Schema: &openapi.Schema{Type: "string"}}
+		if a, ok := annotated["path:"+name]; ok {
+			p.Description = a.Description
+			if s := primitiveSchema(a.Type); s != nil {
+				p.Schema = s
+			}
+		}
+		op.Parameters = append(op.Parameters, p)
+	}
+
+	seen := map[string]bool{}
+	if ann != nil {
+		for _, a := range ann.Params {
+			switch a.In {
+			case "query", "header":
+				schema := primitiveSchema(a.Type)
+				if schema == nil {
+					schema = &openapi.Schema{Type: "string"}
+				}
+				op.Parameters = append(op.Parameters, openapi.Parameter{
+					Name: a.Name, In: a.In, Description: a.Description, Required: a.Required, Schema: schema,
+				})
+				seen[a.In+":"+a.Name] = true
+			case "body":
+				op.RequestBody = jsonBody(b.schemas.refByName(a.Type), a.Required, a.Description)
+			}
+		}
+	}
+
+	if fn == nil {
+		return
+	}
+	for _, q := range fn.Query {
+		if seen["query:"+q] {
+			continue
+		}
+		op.Parameters = append(op.Parameters, openapi.Parameter{Name: q, In: "query", Schema: &openapi.Schema{Type: "string"}})
+	}
+	if op.RequestBody == nil && fn.Body.valid() && r.Method != http.MethodGet {
+		op.RequestBody = jsonBody(b.schemas.ref(fn.Body), true, "")
+	}
+}
+
+// addResponses adds annotated responses, then the statuses found in the
+// handler body
+func (b *docBuilder) addResponses(op *openapi.Operation, fn *handlerFunc, ann *annotations) {
+	if ann != nil {
+		for _, a := range ann.Responses {
+			resp := &openapi.Response{Description: a.Description}
+			if resp.Description == "" {
+				resp.Description = http.StatusText(a.Status)
+			}
+			if schema := b.schemas.refByName(a.Type); schema != nil {
+				if a.Kind == "array" {
+					schema = &openapi.Schema{Type: "array", Items: schema}
+				}
+				resp.Content = jsonContent(schema)
+			}
+			op.Responses[strconv.Itoa(a.Status)] = resp
+		}
+	}
+	if fn == nil {
+		return
+	}
+	for status, isError := range fn.Statuses {
+		if isError {
+			b.addErrorResponse(op, status)
+			continue
+		}
+		key := strconv.Itoa(status)
+		if _, ok := op.Responses[key]; !ok {
+			op.Responses[key] = &openapi.Response{Description: http.StatusText(status)}
+		}
+	}
+}
+
+// addErrorResponse adds a reference to the shared {"error": "..."} response
+// for a status, unless the operation already documents that status
+func (b *docBuilder) addErrorResponse(op *openapi.Operation, status int) {
+	key := strconv.Itoa(status)
+	if _, ok := op.Responses[key]; ok {
+		return
+	}
+	name := strings.ReplaceAll(http.StatusText(status), " ", "")
+	if _, ok := b.errorResponses[name]; !ok {
+		b.errorResponses[name] = &openapi.Response{
+			Description: http.StatusText(status),
+			Content:     jsonContent(openapi.Ref(errorSchemaName)),
+		}
+	}
+	op.Responses[key] = &openapi.Response{Ref: "#/components/responses/" + name}
+}
+
+// applyMiddleware adds security requirements and the responses implied by
+// the route's middleware
+func (b *docBuilder) applyMiddleware(op *openapi.Operation, r route) {
+	requiresAuth, optionalAuth := false, false
+	var apiKeyScopes []string
+	rateLimit := ""
+	for _, mw := range r.Middleware {
+		switch mw.Name {
+		case "AuthMiddleware":
+			requiresAuth = true
+		case "OptionalAuthMiddleware":
+			optionalAuth = true
+		case "APIKeyMiddleware":
+			if len(mw.Args) > 1 {
+				if scope := b.types.constValue(mw.Args[1]); scope != "" {
+					apiKeyScopes = append(apiKeyScopes, scope)
+				}
+			}
+		case "RequirePermission", "RequireAnyPermission":
+			for _, arg := range mw.Args {
+				if p := b.types.constValue(arg); p != "" {
+					op.RequiredPermissions = append(op.RequiredPermissions, p)
+				}
+			}
+		case "RequireRole":
+			for _, arg := range mw.Args {
+				if role := stringLit(arg); role != "" {
+					op.RequiredRoles = append(op.RequiredRoles, role)
+				}
+			}
+		case "RequireAdminAccess":
+			op.RequiredRoles = append(op.RequiredRoles, "admin", "moderator")
+		case "RateLimitMiddleware":
+			if len(mw.Args) == 3 {
+				rateLimit = rateLimitText(mw.Args[1], mw.Args[2])
+			}
+		}
+	}
+	op.RateLimit = rateLimit
+
+	switch {
+	case requiresAuth:
+		op.Security = []openapi.SecurityRequirement{{bearerScheme: {}}}
+	case optionalAuth:
+		op.Security = []openapi.SecurityRequirement{{}, {bearerScheme: {}}}
+	}
+	if len(apiKeyScopes) > 0 {
+		if len(op.Security) == 0 {
+			op.Security = []openapi.SecurityRequirement{{}}
+		}
+		op.Security = append(op.Security, openapi.SecurityRequirement{apiKeyScheme: apiKeyScopes})
+	}
+
+	if requiresAuth {
+		b.addErrorResponse(op, http.StatusUnauthorized)
+	}
+	if len(op.RequiredPermissions) > 0 || len(op.RequiredRoles) > 0 {
+		b.addErrorResponse(op, http.StatusForbidden)
+	}
+	if rateLimit != "" {
+		b.addErrorResponse(op, http.StatusTooManyRequests)
+	}
+}
+
+// operationID returns a unique operation ID, e.g. communityPickVote. Handlers
+// mounted on several routes get a numeric suffix after the first.
+func (b *docBuilder) operationID(r route, handlerName string) string {
+	id := ""
+	if handlerName != "" {
+		field, method, _ := strings.Cut(handlerName, ".")
+		id = lowerFirst(field) + method
+	} else {
+		id = strings.ToLower(r.Method)
+		for _, part := range strings.FieldsFunc(r.Path, func(c rune) bool { return !unicode.IsLetter(c) && !unicode.IsDigit(c) }) {
+			id += strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	b.opIDs[id]++
+	if n := b.opIDs[id]; n > 1 {
+		return id + strconv.Itoa(n)
+	}
+	return id
+}
+
+// routeTag groups a route by its first path segment after the API prefix
+func routeTag(path string) string {
+	rest := strings.TrimPrefix(path, apiPrefix)
+	for _, seg := range strings.Split(rest, "/") {
+		if seg != "" && seg[0] != ':' && seg[0] != '*' {
+			return seg
+		}
+	}
+	return "root"
+}
+
+// rateLimitText renders RateLimitMiddleware(redis, n, window) arguments,
+// e.g. "20 per minute"
+func rateLimitText(count, window ast.Expr) string {
+	lit, ok := count.(*ast.BasicLit)
+	if !ok || lit.Kind != token.INT {
+		return ""
+	}
+	return lit.Value + " per " + windowText(window)
+}
+
+// windowText renders a time.Duration expression such as time.Minute or
+// 24*time.Hour
+func windowText(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.SelectorExpr:
+		if exprName(e.X) == "time" {
+			return strings.ToLower(e.Sel.Name)
+		}
+	case *ast.BinaryExpr:
+		if lit, ok := e.X.(*ast.BasicLit); ok && e.Op == token.MUL {
+			return lit.Value + " " + windowText(e.Y) + "s"
+		}
+	}
+	return "window"
+}
+
+func jsonBody(schema *openapi.Schema, required bool, description string) *openapi.RequestBody {
+	return &openapi.RequestBody{Description: description, Required: required, Content: jsonContent(schema)}
+}
+
+func jsonContent(schema *openapi.Schema) map[string]openapi.MediaType {
+	return map[string]openapi.MediaType{"application/json": {Schema: schema}}
+}
+
+func operationFor(item *openapi.PathItem, method string) *openapi.Operation {
+	switch method {
+	case "GET":
+		return item.Get
+	case "PUT":
+		return item.Put
+	case "POST":
+		return item.Post
+	case "DELETE":
+		return item.Delete
+	case "OPTIONS":
+		return item.Options
+	case "HEAD":
+		return item.Head
+	case "PATCH":
+		return item.Patch
+	}
+	return nil
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// humanize turns an operation ID such as getHealthReady into "Get health ready"
+func humanize(id string) string {
+	var words []string
+	start := 0
+	for i, r := range id {
+		if i > 0 && unicode.IsUpper(r) {
+			words = append(words, strings.ToLower(id[start:i]))
+			start = i
+		}
+	}
+	words = append(words, strings.ToLower(id[start:]))
+	s := strings.Join(words, " ")
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const sampleRoutes = `package main
+
+func registerSampleRoutes(v1 *gin.RouterGroup, h *Handlers, svcs *Services, infra *Infrastructure) {
+	picks := v1.Group("/community-picks")
+	{
+		picks.GET("/:id", middleware.OptionalAuthMiddleware(svcs.Auth), h.CommunityPick.GetRound)
+		picks.POST("/:id/nominations", middleware.AuthMiddleware(svcs.Auth), middleware.RateLimitMiddleware(infra.Redis, 10, time.Minute), h.CommunityPick.Nominate)
+	}
+
+	admin := v1.Group("/admin")
+	admin.Use(middleware.AuthMiddleware(svcs.Auth))
+	{
+		lists := admin.Group("/discovery-lists", middleware.RequirePermission(models.PermissionCreateDiscoveryLists))
+		lists.POST("/:id/community-picks", h.CommunityPick.AdminCreateRound)
+	}
+
+	v1.GET("/ping", func(c *gin.Context) {})
+}
+`
+
+func parseSource(t *testing.T, src string) (*token.FileSet, *ast.File) {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "routes_sample.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	return fset, file
+}
+
+func TestRoutesInFile(t *testing.T) {
+	fset, file := parseSource(t, sampleRoutes)
+	routes := routesInFile(fset, file)
+	if len(routes) != 4 {
+		t.Fatalf("expected 4 routes, got %d", len(routes))
+	}
+
+	nominate := routes[1]
+	if nominate.Method != "POST" || nominate.Path != "/api/v1/community-picks/:id/nominations" {
+		t.Fatalf("unexpected route %s %s", nominate.Method, nominate.Path)
+	}
+	if nominate.Handler == nil || nominate.Handler.Field != "CommunityPick" || nominate.Handler.Method != "Nominate" {
+		t.Fatalf("unexpected handler %+v", nominate.Handler)
+	}
+	if len(nominate.Middleware) != 2 || nominate.Middleware[1].Name != "RateLimitMiddleware" {
+		t.Fatalf("unexpected middleware %+v", nominate.Middleware)
+	}
+
+	// Group middleware, including Use, is inherited by nested groups
+	create := routes[2]
+	if create.Path != "/api/v1/admin/discovery-lists/:id/community-picks" {
+		t.Fatalf("unexpected path %s", create.Path)
+	}
+	if len(create.Middleware) != 2 || create.Middleware[0].Name != "AuthMiddleware" || create.Middleware[1].Name != "RequirePermission" {
+		t.Fatalf("unexpected middleware %+v", create.Middleware)
+	}
+
+	if routes[3].Handler != nil {
+		t.Fatalf("expected inline handler to have no handler ref")
+	}
+}
+
+func TestOpenAPIPath(t *testing.T) {
+	path, params := openAPIPath("/api/v1/community-picks/:id/nominations/:nominationId/vote")
+	if path != "/api/v1/community-picks/{id}/nominations/{nominationId}/vote" {
+		t.Fatalf("unexpected path %s", path)
+	}
+	if len(params) != 2 || params[0] != "id" || params[1] != "nominationId" {
+		t.Fatalf("unexpected params %v", params)
+	}
+}
+
+func TestDocSummary(t *testing.T) {
+	tests := []struct {
+		doc     string
+		method  string
+		summary string
+	}{
+		{"GetRound returns a round with its nominations\nGET /api/v1/community-picks/:id\n", "GetRound", "Returns a round with its nominations"},
+		{"ListClips handles GET /clips\n", "ListClips", ""},
+		{"Get something.\n@Summary Ignored here\n", "Fetch", "Get something"},
+		{"", "Get", ""},
+	}
+	for _, tt := range tests {
+		summary, _ := docSummary(tt.doc, tt.method)
+		if summary != tt.summary {
+			t.Errorf("docSummary(%q) = %q, want %q", tt.doc, summary, tt.summary)
+		}
+	}
+}
+
+func TestParseAnnotations(t *testing.T) {
+	doc := `CreateCheckoutSession creates a checkout session
+@Summary Create checkout session
+@Param request body models.CreateCheckoutSessionRequest true "Checkout session request"
+@Param page query int false "Page number"
+@Success 200 {object} models.CreateCheckoutSessionResponse
+@Failure 401 {object} map[string]string "Not signed in"
+@Router /api/v1/subscriptions/checkout [post]
+`
+	a := parseAnnotations(doc)
+	if a == nil || a.Summary != "Create checkout session" {
+		t.Fatalf("unexpected annotations %+v", a)
+	}
+	if len(a.Params) != 2 || a.Params[0].In != "body" || a.Params[0].Type != "models.CreateCheckoutSessionRequest" || !a.Params[0].Required {
+		t.Fatalf("unexpected params %+v", a.Params)
+	}
+	if a.Params[1].Description != "Page number" {
+		t.Fatalf("expected quoted description, got %q", a.Params[1].Description)
+	}
+	if len(a.Responses) != 2 || a.Responses[1].Status != 401 || a.Responses[1].Type != "map[string]string" || a.Responses[1].Description != "Not signed in" {
+		t.Fatalf("unexpected responses %+v", a.Responses)
+	}
+
+	if parseAnnotations("Plain doc comment\n") != nil {
+		t.Fatalf("expected nil annotations for a plain comment")
+	}
+}
+
+const sampleHandler = `package handlers
+
+// Nominate nominates a clip to an open round
+func (h *CommunityPickHandler) Nominate(c *gin.Context) {
+	if c.Query("dry_run") != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid"})
+		return
+	}
+	var req models.NominateCommunityPickRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, req)
+}
+
+func (h *CommunityPickHandler) respondError(c *gin.Context, err error) {
+	c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+}
+`
+
+func TestAnalyzeHandler(t *testing.T) {
+	_, file := parseSource(t, sampleHandler)
+	idx := &handlerIndex{methods: map[string]map[string]*handlerFunc{"CommunityPickHandler": {}}, funcs: map[string]*handlerFunc{}}
+	for _, decl := range file.Decls {
+		fn := decl.(*ast.FuncDecl)
+		idx.methods["CommunityPickHandler"][fn.Name.Name] = analyzeHandler(fn, contextParam(fn.Type))
+	}
+
+	fn := idx.method("CommunityPickHandler", "Nominate")
+	if fn.Body != (typeRef{Pkg: "models", Name: "NominateCommunityPickRequest"}) {
+		t.Fatalf("unexpected body type %+v", fn.Body)
+	}
+	if len(fn.Query) != 1 || fn.Query[0] != "dry_run" {
+		t.Fatalf("unexpected query params %v", fn.Query)
+	}
+	// 409 comes from the respondError helper
+	want := map[int]bool{400: true, 201: false, 409: true}
+	if len(fn.Statuses) != len(want) {
+		t.Fatalf("unexpected statuses %v", fn.Statuses)
+	}
+	for status, isError := range want {
+		if got, ok := fn.Statuses[status]; !ok || got != isError {
+			t.Fatalf("status %d: got %v (present %v), want %v", status, got, ok, isError)
+		}
+	}
+}
+
+const sampleModels = `package models
+
+type NominateRequest struct {
+	ClipID uuid.UUID ` + "`json:\"clip_id\" binding:\"required\"`" + `
+	Reason *string   ` + "`json:\"reason,omitempty\" binding:\"omitempty,max=200\"`" + `
+	Kind   string    ` + "`json:\"kind\" binding:\"required,oneof=clip stream\"`" + `
+	Base
+}
+
+type Base struct {
+	CreatedAt time.Time ` + "`json:\"created_at\"`" + `
+	internal  string
+}
+`
+
+func TestStructSchema(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "models.go"), []byte(sampleModels), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	types := newTypeIndex()
+	if err := types.addPackage(dir, "models"); err != nil {
+		t.Fatal(err)
+	}
+
+	b := newSchemaBuilder(types)
+	ref := b.ref(typeRef{Pkg: "models", Name: "NominateRequest"})
+	if ref.Ref != "#/components/schemas/NominateRequest" {
+		t.Fatalf("unexpected ref %q", ref.Ref)
+	}
+	schema := b.components["NominateRequest"]
+
+	if got := schema.Properties["clip_id"]; got.Type != "string" || got.Format != "uuid" {
+		t.Fatalf("unexpected clip_id schema %+v", got)
+	}
+	if got := schema.Properties["reason"]; got.MaxLength == nil || *got.MaxLength != 200 {
+		t.Fatalf("unexpected reason schema %+v", got)
+	}
+	if got := schema.Properties["kind"]; len(got.Enum) != 2 || got.Enum[1] != "stream" {
+		t.Fatalf("unexpected kind schema %+v", got)
+	}
+	if got := schema.Properties["created_at"]; got == nil || got.Format != "date-time" {
+		t.Fatalf("expected embedded created_at, got %+v", got)
+	}
+	if _, ok := schema.Properties["internal"]; ok {
+		t.Fatalf("unexported field should be skipped")
+	}
+	if len(schema.Required) != 2 || schema.Required[0] != "clip_id" || schema.Required[1] != "kind" {
+		t.Fatalf("unexpected required %v", schema.Required)
+	}
+}
+
+// TestSpecUpToDate fails when routes or handlers change without regenerating
+// internal/openapi/openapi.json
+func TestSpecUpToDate(t *testing.T) {
+	root := filepath.Join("..", "..")
+	doc, _, err := generate(root)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = append(data, '\n')
+
+	existing, err := os.ReadFile(filepath.Join(root, "internal", "openapi", "openapi.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(existing, data) {
+		t.Fatalf("internal/openapi/openapi.json is out of date; run `make openapi-gen`")
+	}
+}
@@ -0,0 +1,372 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// httpStatuses maps the net/http status constants used by handlers to codes
+var httpStatuses = map[string]int{
+	"StatusOK":                    200,
+	"StatusCreated":               201,
+	"StatusAccepted":              202,
+	"StatusNoContent":             204,
+	"StatusPartialContent":        206,
+	"StatusMovedPermanently":      301,
+	"StatusFound":                 302,
+	"StatusSeeOther":              303,
+	"StatusNotModified":           304,
+	"StatusTemporaryRedirect":     307,
+	"StatusPermanentRedirect":     308,
+	"StatusBadRequest":            400,
+	"StatusUnauthorized":          401,
+	"StatusPaymentRequired":       402,
+	"StatusForbidden":             403,
+	"StatusNotFound":              404,
+	"StatusMethodNotAllowed":      405,
+	"StatusConflict":              409,
+	"StatusGone":                  410,
+	"StatusPreconditionFailed":    412,
+	"StatusRequestEntityTooLarge": 413,
+	"StatusUnsupportedMediaType":  415,
+	"StatusUnprocessableEntity":   422,
+	"StatusLocked":                423,
+	"StatusTooManyRequests":       429,
+	"StatusInternalServerError":   500,
+	"StatusNotImplemented":        501,
+	"StatusBadGateway":            502,
+	"StatusServiceUnavailable":    503,
+	"StatusGatewayTimeout":        504,
+}
+
+// contextStatusMethods are gin.Context methods whose first argument is the
+// response status
+var contextStatusMethods = map[string]bool{
+	"JSON": true, "IndentedJSON": true, "PureJSON": true, "AbortWithStatusJSON": true,
+	"Data": true, "String": true, "HTML": true, "XML": true, "Redirect": true,
+	"Status": true, "AbortWithStatus": true,
+}
+
+var bindMethods = map[string]bool{
+	"ShouldBindJSON": true, "BindJSON": true, "ShouldBind": true, "Bind": true,
+}
+
+var queryMethods = map[string]bool{
+	"Query": true, "DefaultQuery": true, "GetQuery": true, "QueryArray": true, "GetQueryArray": true,
+}
+
+// typeRef names a declared type, e.g. models.Clip
+type typeRef struct {
+	Pkg  string
+	Name string
+}
+
+func (t typeRef) valid() bool { return t.Name != "" }
+
+// handlerFunc is what the generator learned about one handler method or
+// helper function taking a *gin.Context
+type handlerFunc struct {
+	Doc         string
+	Body        typeRef
+	Query       []string
+	Statuses    map[int]bool // status -> whether the payload was an error
+	Annotations *annotations
+
+	// Helpers called with the context, merged into the caller's statuses
+	helperMethods []string
+	helperFuncs   []string
+}
+
+// handlerIndex holds the handler methods keyed by type and method name, and
+// package-level helpers keyed by function name
+type handlerIndex struct {
+	methods map[string]map[string]*handlerFunc
+	funcs   map[string]*handlerFunc
+}
+
+// method returns a handler method with the statuses of the helpers it calls
+// merged in
+func (idx *handlerIndex) method(typeName, name string) *handlerFunc {
+	fn := idx.methods[typeName][name]
+	if fn == nil {
+		return nil
+	}
+	merged := *fn
+	merged.Statuses = map[int]bool{}
+	idx.collectStatuses(typeName, fn, merged.Statuses, map[*handlerFunc]bool{})
+	return &merged
+}
+
+func (idx *handlerIndex) collectStatuses(typeName string, fn *handlerFunc, into map[int]bool, seen map[*handlerFunc]bool) {
+	if fn == nil || seen[fn] {
+		return
+	}
+	seen[fn] = true
+	for status, isError := range fn.Statuses {
+		into[status] = into[status] || isError
+	}
+	for _, name := range fn.helperMethods {
+		idx.collectStatuses(typeName, idx.methods[typeName][name], into, seen)
+	}
+	for _, name := range fn.helperFuncs {
+		idx.collectStatuses(typeName, idx.funcs[name], into, seen)
+	}
+}
+
+// parseHandlers indexes the functions in dir that take a *gin.Context
+func parseHandlers(dir string) (*handlerIndex, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	idx := &handlerIndex{
+		methods: map[string]map[string]*handlerFunc{},
+		funcs:   map[string]*handlerFunc{},
+	}
+	fset := token.NewFileSet()
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			ctxName := contextParam(fn.Type)
+			if ctxName == "" {
+				continue
+			}
+			info := analyzeHandler(fn, ctxName)
+			if fn.Recv == nil {
+				idx.funcs[fn.Name.Name] = info
+				continue
+			}
+			typeName := receiverType(fn.Recv)
+			if idx.methods[typeName] == nil {
+				idx.methods[typeName] = map[string]*handlerFunc{}
+			}
+			idx.methods[typeName][fn.Name.Name] = info
+		}
+	}
+	return idx, nil
+}
+
+// contextParam returns the name of the first *gin.Context parameter
+func contextParam(ft *ast.FuncType) string {
+	for _, field := range ft.Params.List {
+		star, ok := field.Type.(*ast.StarExpr)
+		if !ok {
+			continue
+		}
+		sel, ok := star.X.(*ast.SelectorExpr)
+		if !ok || exprName(sel.X) != "gin" || sel.Sel.Name != "Context" || len(field.Names) == 0 {
+			continue
+		}
+		return field.Names[0].Name
+	}
+	return ""
+}
+
+// receiverType returns the type name of a method receiver
+func receiverType(recv *ast.FieldList) string {
+	if len(recv.List) == 0 {
+		return ""
+	}
+	expr := recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	return exprName(expr)
+}
+
+// analyzeHandler collects the request body type, query parameters and
+// response statuses of a handler
+func analyzeHandler(fn *ast.FuncDecl, ctxName string) *handlerFunc {
+	info := &handlerFunc{Statuses: map[int]bool{}}
+	if fn.Doc != nil {
+		info.Doc = fn.Doc.Text()
+		info.Annotations = parseAnnotations(info.Doc)
+	}
+
+	recvName := ""
+	if fn.Recv != nil && len(fn.Recv.List) > 0 && len(fn.Recv.List[0].Names) > 0 {
+		recvName = fn.Recv.List[0].Names[0].Name
+	}
+
+	vars := map[string]typeRef{}
+	seenQuery := map[string]bool{}
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ValueSpec:
+			if ref := typeRefOf(n.Type); ref.valid() {
+				for _, name := range n.Names {
+					vars[name.Name] = ref
+				}
+			}
+		case *ast.AssignStmt:
+			for i, lhs := range n.Lhs {
+				if i >= len(n.Rhs) || len(n.Lhs) != len(n.Rhs) {
+					break
+				}
+				if ref := compositeType(n.Rhs[i]); ref.valid() {
+					if name := exprName(lhs); name != "" {
+						vars[name] = ref
+					}
+				}
+			}
+		case *ast.CallExpr:
+			if name := exprName(n.Fun); name != "" && passesContext(n, ctxName) {
+				info.helperFuncs = append(info.helperFuncs, name)
+				return true
+			}
+			sel, ok := n.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			recv := exprName(sel.X)
+			if recv != "" && recv == recvName && passesContext(n, ctxName) {
+				info.helperMethods = append(info.helperMethods, sel.Sel.Name)
+				return true
+			}
+			if recv != ctxName {
+				return true
+			}
+			method := sel.Sel.Name
+			switch {
+			case bindMethods[method] && len(n.Args) == 1:
+				if unary, ok := n.Args[0].(*ast.UnaryExpr); ok && unary.Op == token.AND {
+					if ref, ok := vars[exprName(unary.X)]; ok {
+						info.Body = ref
+					}
+				}
+			case queryMethods[method] && len(n.Args) >= 1:
+				if q := stringLit(n.Args[0]); q != "" && !seenQuery[q] {
+					seenQuery[q] = true
+					info.Query = append(info.Query, q)
+				}
+			case contextStatusMethods[method] && len(n.Args) >= 1:
+				if status := statusCode(n.Args[0]); status != 0 {
+					isError := len(n.Args) > 1 && hasErrorKey(n.Args[1])
+					info.Statuses[status] = info.Statuses[status] || isError
+				}
+			}
+		}
+		return true
+	})
+	return info
+}
+
+// passesContext reports whether a call passes the gin context as an argument
+func passesContext(call *ast.CallExpr, ctxName string) bool {
+	for _, arg := range call.Args {
+		if exprName(arg) == ctxName {
+			return true
+		}
+	}
+	return false
+}
+
+// typeRefOf returns the named type of a declaration, e.g. models.X or X
+func typeRefOf(expr ast.Expr) typeRef {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if ast.IsExported(t.Name) {
+			return typeRef{Pkg: "handlers", Name: t.Name}
+		}
+	case *ast.SelectorExpr:
+		if pkg := exprName(t.X); pkg != "" {
+			return typeRef{Pkg: pkg, Name: t.Sel.Name}
+		}
+	}
+	return typeRef{}
+}
+
+// compositeType returns the type of a T{} or &T{} expression
+func compositeType(expr ast.Expr) typeRef {
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = unary.X
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return typeRef{}
+	}
+	return typeRefOf(lit.Type)
+}
+
+// statusCode resolves http.StatusX constants and integer literals
+func statusCode(expr ast.Expr) int {
+	switch e := expr.(type) {
+	case *ast.SelectorExpr:
+		if exprName(e.X) == "http" {
+			return httpStatuses[e.Sel.Name]
+		}
+	case *ast.BasicLit:
+		if e.Kind == token.INT {
+			code, _ := strconv.Atoi(e.Value)
+			return code
+		}
+	}
+	return 0
+}
+
+// hasErrorKey reports whether a response payload is a gin.H with an "error" key
+func hasErrorKey(expr ast.Expr) bool {
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return false
+	}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if ok && stringLit(kv.Key) == "error" {
+			return true
+		}
+	}
+	return false
+}
+
+// handlerFields maps the fields of the Handlers struct in cmd/api/handlers.go
+// to their handler type names
+func handlerFields(path string) (map[string]string, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]string{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		spec, ok := n.(*ast.TypeSpec)
+		if !ok || spec.Name.Name != "Handlers" {
+			return true
+		}
+		st, ok := spec.Type.(*ast.StructType)
+		if !ok {
+			return false
+		}
+		for _, field := range st.Fields.List {
+			ref := typeRefOf(derefType(field.Type))
+			for _, name := range field.Names {
+				fields[name.Name] = ref.Name
+			}
+		}
+		return false
+	})
+	return fields, nil
+}
+
+func derefType(expr ast.Expr) ast.Expr {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		return star.X
+	}
+	return expr
+}
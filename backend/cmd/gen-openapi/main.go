@@ -0,0 +1,65 @@
+// Command gen-openapi generates the OpenAPI 3.1 specification served at
+// /api/v1/openapi.json.
+//
+// It reads the route table in cmd/api/routes_*.go, the handler methods in
+// internal/handlers and the request/response types in internal/models, and
+// writes internal/openapi/openapi.json, which the API embeds. Handlers can
+// refine their operation with swag-style annotations (@Summary, @Param,
+// @Success, ...); otherwise the summary comes from the doc comment and the
+// parameters, body and status codes are inferred from the handler body.
+//
+// Usage (from backend/):
+//
+//	go run ./cmd/gen-openapi          # regenerate the spec
+//	go run ./cmd/gen-openapi -check   # fail if the committed spec is stale
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	root := flag.String("root", ".", "Path to the backend module root")
+	out := flag.String("out", "internal/openapi/openapi.json", "Output path, relative to -root")
+	check := flag.Bool("check", false, "Exit non-zero if the output file is not up to date instead of writing it")
+	flag.Parse()
+
+	doc, warnings, err := generate(*root)
+	if err != nil {
+		log.Fatalf("Failed to generate OpenAPI spec: %v", err)
+	}
+	for _, w := range warnings {
+		log.Printf("WARNING: %s", w)
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to encode OpenAPI spec: %v", err)
+	}
+	data = append(data, '\n')
+
+	outPath := filepath.Join(*root, *out)
+	if *check {
+		existing, err := os.ReadFile(outPath)
+		if err != nil {
+			log.Fatalf("Failed to read %s: %v", outPath, err)
+		}
+		if !bytes.Equal(existing, data) {
+			fmt.Fprintf(os.Stderr, "%s is out of date; run `make openapi-gen` and commit the result\n", outPath)
+			os.Exit(1)
+		}
+		fmt.Printf("%s is up to date (%d paths)\n", outPath, len(doc.Paths))
+		return
+	}
+
+	if err := os.WriteFile(outPath, data, 0o644); err != nil {
+		log.Fatalf("Failed to write %s: %v", outPath, err)
+	}
+	fmt.Printf("Wrote %s (%d paths)\n", outPath, len(doc.Paths))
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// apiPrefix is the prefix of the v1 group that cmd/api/main.go passes to the
+// register*Routes functions as their *gin.RouterGroup parameter
+const apiPrefix = "/api/v1"
+
+var routeMethods = map[string]bool{
+	"GET": true, "POST": true, "PUT": true, "PATCH": true, "DELETE": true, "HEAD": true, "OPTIONS": true,
+}
+
+// route is one registered endpoint
+type route struct {
+	Method     string
+	Path       string      // gin syntax, e.g. /api/v1/clips/:id
+	Handler    *handlerRef // nil for inline handler funcs
+	Middleware []middlewareRef
+	Pos        string
+}
+
+// handlerRef is a handler referenced as h.<Field>.<Method>
+type handlerRef struct {
+	Field  string
+	Method string
+}
+
+// middlewareRef is a call to a constructor in the middleware package
+type middlewareRef struct {
+	Name string
+	Args []ast.Expr
+}
+
+// routeGroup is a gin router group variable in a register function
+type routeGroup struct {
+	prefix     string
+	middleware []middlewareRef
+}
+
+// parseRoutes parses every routes_*.go file in dir, sorted by file name
+func parseRoutes(dir string) ([]route, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "routes_*.go"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	fset := token.NewFileSet()
+	var routes []route
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, routesInFile(fset, file)...)
+	}
+	return routes, nil
+}
+
+// routesInFile extracts the routes registered by the register*Routes
+// functions of a file, tracking group prefixes and group middleware
+func routesInFile(fset *token.FileSet, file *ast.File) []route {
+	var routes []route
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil || !strings.HasPrefix(fn.Name.Name, "register") {
+			continue
+		}
+
+		groups := map[string]*routeGroup{}
+		for _, field := range fn.Type.Params.List {
+			prefix, ok := routerPrefix(field.Type)
+			if !ok {
+				continue
+			}
+			for _, name := range field.Names {
+				groups[name.Name] = &routeGroup{prefix: prefix}
+			}
+		}
+
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				if len(n.Lhs) != 1 || len(n.Rhs) != 1 {
+					return true
+				}
+				lhs, ok := n.Lhs[0].(*ast.Ident)
+				if !ok {
+					return true
+				}
+				parent, method, args := groupCall(n.Rhs[0], groups)
+				if parent == nil || method != "Group" || len(args) == 0 {
+					return true
+				}
+				child := &routeGroup{
+					prefix:     parent.prefix + stringLit(args[0]),
+					middleware: append(append([]middlewareRef{}, parent.middleware...), middlewareRefs(args[1:])...),
+				}
+				groups[lhs.Name] = child
+			case *ast.CallExpr:
+				group, method, args := groupCall(n, groups)
+				if group == nil {
+					return true
+				}
+				switch {
+				case method == "Use":
+					group.middleware = append(group.middleware, middlewareRefs(args)...)
+				case method == "Handle" && len(args) >= 3:
+					routes = append(routes, newRoute(fset, group, strings.ToUpper(stringLit(args[0])), args[1:], n))
+				case routeMethods[method] && len(args) >= 2:
+					routes = append(routes, newRoute(fset, group, method, args, n))
+				}
+			}
+			return true
+		})
+	}
+	return routes
+}
+
+// routerPrefix returns the path prefix of a register function parameter
+func routerPrefix(expr ast.Expr) (string, bool) {
+	star, ok := expr.(*ast.StarExpr)
+	if !ok {
+		return "", false
+	}
+	sel, ok := star.X.(*ast.SelectorExpr)
+	if !ok || exprName(sel.X) != "gin" {
+		return "", false
+	}
+	switch sel.Sel.Name {
+	case "Engine":
+		return "", true
+	case "RouterGroup":
+		return apiPrefix, true
+	}
+	return "", false
+}
+
+// groupCall matches <group>.<method>(args...) on a known router group
+func groupCall(expr ast.Expr, groups map[string]*routeGroup) (*routeGroup, string, []ast.Expr) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return nil, "", nil
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, "", nil
+	}
+	recv, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return nil, "", nil
+	}
+	group, ok := groups[recv.Name]
+	if !ok {
+		return nil, "", nil
+	}
+	return group, sel.Sel.Name, call.Args
+}
+
+// newRoute builds a route from the path, middleware and handler arguments
+func newRoute(fset *token.FileSet, group *routeGroup, method string, args []ast.Expr, call *ast.CallExpr) route {
+	pos := fset.Position(call.Pos())
+	r := route{
+		Method:     method,
+		Path:       group.prefix + stringLit(args[0]),
+		Middleware: append(append([]middlewareRef{}, group.middleware...), middlewareRefs(args[1:len(args)-1])...),
+		Pos:        filepath.Base(pos.Filename) + ":" + strconv.Itoa(pos.Line),
+	}
+	if r.Path == "" {
+		r.Path = "/"
+	}
+
+	// Handlers are referenced as h.<Field>.<Method>
+	if sel, ok := args[len(args)-1].(*ast.SelectorExpr); ok {
+		if inner, ok := sel.X.(*ast.SelectorExpr); ok {
+			r.Handler = &handlerRef{Field: inner.Sel.Name, Method: sel.Sel.Name}
+		}
+	}
+	return r
+}
+
+// middlewareRefs returns the middleware package constructor calls among args
+func middlewareRefs(args []ast.Expr) []middlewareRef {
+	var refs []middlewareRef
+	for _, arg := range args {
+		call, ok := arg.(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || exprName(sel.X) != "middleware" {
+			continue
+		}
+		refs = append(refs, middlewareRef{Name: sel.Sel.Name, Args: call.Args})
+	}
+	return refs
+}
+
+// stringLit returns the value of a string literal, or "" for anything else
+func stringLit(expr ast.Expr) string {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return ""
+	}
+	s, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return ""
+	}
+	return s
+}
+
+// exprName returns the name of an identifier, or "" for anything else
+func exprName(expr ast.Expr) string {
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// openAPIPath converts a gin path to OpenAPI syntax and returns its parameters
+func openAPIPath(ginPath string) (string, []string) {
+	segments := strings.Split(ginPath, "/")
+	var params []string
+	for i, seg := range segments {
+		if len(seg) > 1 && (seg[0] == ':' || seg[0] == '*') {
+			params = append(params, seg[1:])
+			segments[i] = "{" + seg[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
@@ -0,0 +1,328 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/subculture-collective/clipper/internal/openapi"
+)
+
+// errorSchemaName is the component schema for {"error": "..."} responses
+const errorSchemaName = "Error"
+
+// typeIndex holds the declared types and string constants of the packages
+// request and response types come from
+type typeIndex struct {
+	types  map[typeRef]*ast.TypeSpec
+	consts map[typeRef]string
+}
+
+func newTypeIndex() *typeIndex {
+	return &typeIndex{
+		types:  map[typeRef]*ast.TypeSpec{},
+		consts: map[typeRef]string{},
+	}
+}
+
+// addPackage indexes the non-test files of a package directory under pkg
+func (ti *typeIndex) addPackage(dir, pkg string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	fset := token.NewFileSet()
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					ti.types[typeRef{Pkg: pkg, Name: s.Name.Name}] = s
+				case *ast.ValueSpec:
+					if gen.Tok != token.CONST {
+						continue
+					}
+					for i, name := range s.Names {
+						if i < len(s.Values) {
+							if v := stringLit(s.Values[i]); v != "" {
+								ti.consts[typeRef{Pkg: pkg, Name: name.Name}] = v
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// constValue resolves a pkg.Name selector to its string constant
+func (ti *typeIndex) constValue(expr ast.Expr) string {
+	if s := stringLit(expr); s != "" {
+		return s
+	}
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	return ti.consts[typeRef{Pkg: exprName(sel.X), Name: sel.Sel.Name}]
+}
+
+// schemaBuilder converts Go types to JSON schemas, registering structs as
+// component schemas
+type schemaBuilder struct {
+	types      *typeIndex
+	components map[string]*openapi.Schema
+	names      map[typeRef]string
+}
+
+func newSchemaBuilder(types *typeIndex) *schemaBuilder {
+	return &schemaBuilder{
+		types:      types,
+		components: map[string]*openapi.Schema{},
+		names:      map[typeRef]string{},
+	}
+}
+
+// ref returns a schema for a declared type. Structs become component schemas
+// referenced by name; other named types are inlined.
+func (b *schemaBuilder) ref(ref typeRef) *openapi.Schema {
+	spec, ok := b.types.types[ref]
+	if !ok {
+		return &openapi.Schema{}
+	}
+	st, isStruct := spec.Type.(*ast.StructType)
+	if !isStruct {
+		return b.schemaFor(spec.Type, ref.Pkg)
+	}
+
+	if name, ok := b.names[ref]; ok {
+		return openapi.Ref(name)
+	}
+	name := ref.Name
+	if ref.Pkg != "models" {
+		name = strings.ToUpper(ref.Pkg[:1]) + ref.Pkg[1:] + ref.Name
+	}
+	b.names[ref] = name
+	// Register before building so self-referencing types terminate
+	b.components[name] = &openapi.Schema{Type: "object"}
+	b.components[name] = b.structSchema(st, ref.Pkg)
+	return openapi.Ref(name)
+}
+
+// refByName resolves an annotation type such as models.Clip, Clip or
+// map[string]string
+func (b *schemaBuilder) refByName(name string) *openapi.Schema {
+	switch {
+	case name == "":
+		return nil
+	case strings.HasPrefix(name, "[]"):
+		return &openapi.Schema{Type: "array", Items: b.refByName(name[2:])}
+	case strings.HasPrefix(name, "map[string]"):
+		return &openapi.Schema{Type: "object", AdditionalProperties: b.refByName(strings.TrimPrefix(name, "map[string]"))}
+	}
+	if s := primitiveSchema(name); s != nil {
+		return s
+	}
+	pkg, typeName, ok := strings.Cut(name, ".")
+	if !ok {
+		pkg, typeName = "handlers", name
+	}
+	return b.ref(typeRef{Pkg: pkg, Name: typeName})
+}
+
+// schemaFor converts a type expression declared in pkg
+func (b *schemaBuilder) schemaFor(expr ast.Expr, pkg string) *openapi.Schema {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if s := primitiveSchema(t.Name); s != nil {
+			return s
+		}
+		return b.ref(typeRef{Pkg: pkg, Name: t.Name})
+	case *ast.SelectorExpr:
+		if s := externalSchema(exprName(t.X), t.Sel.Name); s != nil {
+			return s
+		}
+		return b.ref(typeRef{Pkg: exprName(t.X), Name: t.Sel.Name})
+	case *ast.StarExpr:
+		return b.schemaFor(t.X, pkg)
+	case *ast.ArrayType:
+		if exprName(t.Elt) == "byte" {
+			return &openapi.Schema{Type: "string", Format: "byte"}
+		}
+		return &openapi.Schema{Type: "array", Items: b.schemaFor(t.Elt, pkg)}
+	case *ast.MapType:
+		return &openapi.Schema{Type: "object", AdditionalProperties: b.schemaFor(t.Value, pkg)}
+	case *ast.StructType:
+		return b.structSchema(t, pkg)
+	}
+	return &openapi.Schema{}
+}
+
+// structSchema builds an object schema from json and binding struct tags
+func (b *schemaBuilder) structSchema(st *ast.StructType, pkg string) *openapi.Schema {
+	schema := &openapi.Schema{Type: "object", Properties: map[string]*openapi.Schema{}}
+	for _, field := range st.Fields.List {
+		tag := reflect.StructTag("")
+		if field.Tag != nil {
+			if raw, err := strconv.Unquote(field.Tag.Value); err == nil {
+				tag = reflect.StructTag(raw)
+			}
+		}
+		jsonName, _, _ := strings.Cut(tag.Get("json"), ",")
+		if jsonName == "-" {
+			continue
+		}
+
+		// Embedded structs without a json name are flattened into the parent
+		if len(field.Names) == 0 {
+			if jsonName == "" {
+				b.embed(schema, field.Type, pkg)
+				continue
+			}
+			schema.Properties[jsonName] = b.schemaFor(field.Type, pkg)
+			continue
+		}
+
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+			propName := jsonName
+			if propName == "" {
+				propName = name.Name
+			}
+			prop := b.schemaFor(field.Type, pkg)
+			if applyBinding(prop, tag.Get("binding")) {
+				schema.Required = append(schema.Required, propName)
+			}
+			schema.Properties[propName] = prop
+		}
+	}
+	return schema
+}
+
+// embed copies the properties of an embedded struct into schema
+func (b *schemaBuilder) embed(schema *openapi.Schema, expr ast.Expr, pkg string) {
+	ref := typeRefOf(derefType(expr))
+	if ref.Pkg == "handlers" {
+		ref.Pkg = pkg
+	}
+	spec, ok := b.types.types[ref]
+	if !ok {
+		return
+	}
+	st, ok := spec.Type.(*ast.StructType)
+	if !ok {
+		return
+	}
+	embedded := b.structSchema(st, ref.Pkg)
+	for name, prop := range embedded.Properties {
+		schema.Properties[name] = prop
+	}
+	schema.Required = append(schema.Required, embedded.Required...)
+}
+
+// applyBinding applies gin binding constraints to a property schema and
+// reports whether the property is required
+func applyBinding(prop *openapi.Schema, binding string) bool {
+	required := false
+	for _, rule := range strings.Split(binding, ",") {
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case "required":
+			required = true
+		case "oneof":
+			if prop.Type == "string" {
+				prop.Enum = strings.Fields(value)
+			}
+		case "uuid":
+			prop.Format = "uuid"
+		case "url":
+			prop.Format = "uri"
+		case "email":
+			prop.Format = "email"
+		case "min", "max":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			switch prop.Type {
+			case "string":
+				length := int(n)
+				if key == "min" {
+					prop.MinLength = &length
+				} else {
+					prop.MaxLength = &length
+				}
+			case "integer", "number":
+				if key == "min" {
+					prop.Minimum = &n
+				} else {
+					prop.Maximum = &n
+				}
+			}
+		}
+	}
+	return required
+}
+
+// primitiveSchema returns the schema for a Go builtin or annotation type name
+func primitiveSchema(name string) *openapi.Schema {
+	switch name {
+	case "string":
+		return &openapi.Schema{Type: "string"}
+	case "bool", "boolean":
+		return &openapi.Schema{Type: "boolean"}
+	case "int", "int8", "int16", "int32", "uint", "uint8", "uint16", "uint32", "integer", "byte", "rune":
+		return &openapi.Schema{Type: "integer"}
+	case "int64", "uint64":
+		return &openapi.Schema{Type: "integer", Format: "int64"}
+	case "float32", "float64", "number":
+		return &openapi.Schema{Type: "number"}
+	case "any", "interface{}", "object", "file":
+		return &openapi.Schema{}
+	}
+	return nil
+}
+
+// externalSchema returns the schema for well-known types from other modules
+func externalSchema(pkg, name string) *openapi.Schema {
+	switch pkg + "." + name {
+	case "time.Time":
+		return &openapi.Schema{Type: "string", Format: "date-time"}
+	case "time.Duration":
+		return &openapi.Schema{Type: "integer", Format: "int64"}
+	case "uuid.UUID":
+		return &openapi.Schema{Type: "string", Format: "uuid"}
+	case "json.RawMessage":
+		return &openapi.Schema{}
+	case "sql.NullString":
+		return &openapi.Schema{Type: "string"}
+	case "sql.NullInt64", "sql.NullInt32":
+		return &openapi.Schema{Type: "integer"}
+	case "sql.NullBool":
+		return &openapi.Schema{Type: "boolean"}
+	case "sql.NullTime":
+		return &openapi.Schema{Type: "string", Format: "date-time"}
+	}
+	return nil
+}
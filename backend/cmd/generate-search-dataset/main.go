@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+	"github.com/subculture-collective/clipper/pkg/database"
+	"gopkg.in/yaml.v3"
+)
+
+// generate-search-dataset mines tracked searches and the clips clicked from
+// their results into an evaluation dataset for evaluate-search, so offline
+// metrics reflect real traffic. Only queries searched by several signed-in
+// users are kept, and no user data is written.
+func main() {
+	days := flag.Int("days", 90, "Number of days of searches to mine")
+	minUsers := flag.Int("min-users", 3, "Minimum number of distinct signed-in users who searched a query")
+	minClicks := flag.Int("min-clicks", 2, "Minimum number of searches that clicked a clip for it to be judged relevant")
+	queries := flag.Int("queries", 200, "Maximum number of queries, most searched first")
+	maxDocs := flag.Int("max-docs", 20, "Maximum number of relevant clips per query")
+	targetsPath := flag.String("targets", "", "Dataset YAML file to copy metric targets and guidelines from (optional)")
+	outputPath := flag.String("output", "", "Path to output YAML file (optional, defaults to stdout)")
+	flag.Parse()
+
+	if *days <= 0 || *minUsers <= 0 || *queries <= 0 {
+		log.Fatal("-days, -min-users and -queries must be positive")
+	}
+
+	opts := services.ClickDatasetOptions{MinClicks: *minClicks, MaxDocuments: *maxDocs}
+	if *targetsPath != "" {
+		evalService := services.NewSearchEvaluationService(nil)
+		if err := evalService.LoadDataset(*targetsPath); err != nil {
+			log.Fatalf("Failed to load targets: %v", err)
+		}
+		opts.Targets = evalService.GetDataset().MetricTargets
+		opts.Guidelines = evalService.GetDataset().EvaluationGuidelines
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	db, err := database.NewDB(&cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	end := time.Now()
+	start := end.AddDate(0, 0, -*days)
+	log.Printf("Mining searches from %s to %s...", start.Format(time.DateOnly), end.Format(time.DateOnly))
+	repo := repository.NewSearchAnalyticsRepository(db.Pool)
+	judgments, err := repo.ListClipClickJudgments(ctx, start, end, *minUsers, *queries)
+	if err != nil {
+		log.Fatalf("Failed to mine search clicks: %v", err)
+	}
+
+	opts.Description = fmt.Sprintf("Generated from search logs between %s and %s; clicks are implicit relevance judgments",
+		start.Format(time.DateOnly), end.Format(time.DateOnly))
+	dataset := services.BuildClickEvaluationDataset(judgments, opts)
+
+	data, err := yaml.Marshal(dataset)
+	if err != nil {
+		log.Fatalf("Failed to encode dataset: %v", err)
+	}
+	if *outputPath == "" {
+		if _, err := os.Stdout.Write(data); err != nil {
+			log.Fatalf("Failed to write dataset: %v", err)
+		}
+	} else if err := os.WriteFile(*outputPath, data, 0o644); err != nil {
+		log.Fatalf("Failed to write dataset: %v", err)
+	}
+
+	documents := 0
+	for _, q := range dataset.EvaluationQueries {
+		documents += len(q.RelevantDocuments)
+	}
+	log.Printf("Generated %d queries with %d relevant clips", len(dataset.EvaluationQueries), documents)
+}
@@ -9,6 +9,8 @@ import (
 	"os"
 	"time"
 
+	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/searchlive"
 	"github.com/subculture-collective/clipper/internal/services"
 )
 
@@ -45,6 +47,7 @@ func main() {
 	verbose := flag.Bool("verbose", false, "Print detailed results for each configuration")
 	help := flag.Bool("help", false, "Show help message")
 	quick := flag.Bool("quick", false, "Quick mode - test fewer combinations")
+	liveMode := flag.Bool("live", false, "Run queries against live search using the database, OpenSearch and embedding settings from the environment")
 
 	flag.Parse()
 
@@ -82,8 +85,30 @@ func main() {
 	log.Printf("Starting grid search with %d parameter combinations...",
 		len(gridConfig.BM25Weights)*len(gridConfig.VectorWeights))
 
+	ctx := context.Background()
+
+	// Boosts stay fixed while the BM25/vector split varies. Live runs use the
+	// HYBRID_SEARCH_* settings; simulated runs ignore weights entirely.
+	base := services.DefaultConfigs()[0]
+	var hybridSearch *services.HybridSearchService
+	if *liveMode {
+		cfg, err := config.Load()
+		if err != nil {
+			log.Fatalf("Failed to load configuration: %v", err)
+		}
+		stack, err := searchlive.Connect(ctx, cfg)
+		if err != nil {
+			log.Fatalf("Failed to connect to live search: %v", err)
+		}
+		defer stack.Close()
+		hybridSearch = stack.Search
+		base = services.ConfiguredWeights(&cfg.HybridSearch)
+	} else {
+		log.Println("Using simulated results; every combination will score the same. Use -live to search for real.")
+	}
+
 	// Load evaluation dataset
-	evalService := services.NewSearchEvaluationService(nil)
+	evalService := services.NewSearchEvaluationService(hybridSearch)
 	if err := evalService.LoadDataset(*datasetPath); err != nil {
 		log.Fatalf("Failed to load dataset: %v", err)
 	}
@@ -92,7 +117,6 @@ func main() {
 	log.Printf("Loaded %d evaluation queries", len(dataset.EvaluationQueries))
 
 	// Run grid search
-	ctx := context.Background()
 	results := []GridSearchResult{}
 
 	for _, bm25Weight := range gridConfig.BM25Weights {
@@ -110,8 +134,10 @@ func main() {
 			result, err := evaluateConfiguration(
 				ctx,
 				evalService,
+				base,
 				bm25Weight,
 				vectorWeight,
+				*liveMode,
 			)
 			if err != nil {
 				log.Printf("Error evaluating configuration: %v", err)
@@ -178,33 +204,30 @@ func printUsage() {
 	fmt.Println()
 	fmt.Println("  # Compare against baseline")
 	fmt.Println("  grid-search-hybrid-search -baseline baseline.json -output optimized.json")
+	fmt.Println()
+	fmt.Println("  # Search weights against live search")
+	fmt.Println("  grid-search-hybrid-search -live -quick -baseline baseline.json")
 }
 
 func evaluateConfiguration(
 	ctx context.Context,
 	evalService *services.SearchEvaluationService,
+	base services.SearchWeightConfig,
 	bm25Weight float64,
 	vectorWeight float64,
+	live bool,
 ) (GridSearchResult, error) {
-	// TODO: For production use, integrate with actual HybridSearchService
-	// This requires:
-	// 1. Create HybridSearchService with the specified weights
-	// 2. Configure search weights: config.HybridSearch.BM25Weight = bm25Weight
-	// 3. Run actual searches for each evaluation query
-	// 4. Collect and return real metrics
-	//
-	// Example implementation:
-	//   searchConfig := services.SearchWeightConfig{
-	//       BM25Weight:   bm25Weight,
-	//       VectorWeight: vectorWeight,
-	//       // ... other parameters
-	//   }
-	//   report, err := evalService.EvaluateWithLiveSearch(ctx, searchConfig)
-	//
-	// For now, using simulated results to demonstrate the framework.
-	// All metrics will be identical until live search integration is complete.
-
-	report, err := evalService.EvaluateWithSimulatedResults(ctx)
+	var report *services.EvaluationReport
+	var err error
+	if live {
+		weights := base
+		weights.Name = fmt.Sprintf("bm25-%.2f-vector-%.2f", bm25Weight, vectorWeight)
+		weights.BM25Weight = bm25Weight
+		weights.VectorWeight = vectorWeight
+		report, err = evalService.EvaluateWithLiveSearch(ctx, weights)
+	} else {
+		report, err = evalService.EvaluateWithSimulatedResults(ctx)
+	}
 	if err != nil {
 		return GridSearchResult{}, err
 	}
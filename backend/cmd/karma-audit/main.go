@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+	"github.com/subculture-collective/clipper/pkg/database"
+)
+
+// karma-audit recomputes each user's karma from the votes on their clips and
+// comments and their comment count, and reports users whose stored karma
+// differs by more than the tolerance. With -apply, the differences are
+// corrected and recorded in karma history as reconciliations.
+func main() {
+	tolerance := flag.Int("tolerance", 0, "Largest karma difference left unreported")
+	apply := flag.Bool("apply", false, "Correct reported karma (default is a dry run)")
+	batchSize := flag.Int("batch", 500, "Number of users audited and corrected per transaction")
+	user := flag.String("user", "", "Audit a single user ID (optional)")
+	outputPath := flag.String("output", "", "Path to output JSON report (optional, defaults to stdout)")
+	flag.Parse()
+
+	if *tolerance < 0 || *batchSize <= 0 {
+		log.Fatal("-tolerance must not be negative and -batch must be positive")
+	}
+
+	opts := services.KarmaAuditOptions{Tolerance: *tolerance, Apply: *apply, BatchSize: *batchSize}
+	if *user != "" {
+		userID, err := uuid.Parse(*user)
+		if err != nil {
+			log.Fatalf("Invalid -user: %v", err)
+		}
+		opts.UserID = &userID
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	db, err := database.NewDB(&cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	if *apply {
+		log.Printf("Auditing karma with tolerance %d and applying corrections...", *tolerance)
+	} else {
+		log.Printf("Auditing karma with tolerance %d (dry run)...", *tolerance)
+	}
+	auditService := services.NewKarmaAuditService(repository.NewKarmaAuditRepository(db.Pool))
+	report, err := auditService.Audit(ctx, opts)
+	if err != nil {
+		log.Fatalf("Failed to audit karma: %v", err)
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to encode report: %v", err)
+	}
+	if *outputPath == "" {
+		if _, err := os.Stdout.Write(append(data, '\n')); err != nil {
+			log.Fatalf("Failed to write report: %v", err)
+		}
+	} else if err := os.WriteFile(*outputPath, data, 0o644); err != nil {
+		log.Fatalf("Failed to write report: %v", err)
+	}
+
+	log.Printf("Audited %d users: %d discrepancies, %d corrected",
+		report.UsersAudited, len(report.Discrepancies), report.Corrected)
+}
@@ -0,0 +1,343 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+	"github.com/subculture-collective/clipper/pkg/database"
+	"github.com/subculture-collective/clipper/pkg/redis"
+)
+
+const usage = `Embedding Migration Tool - Move clip embeddings to a new model
+
+Usage:
+    migrate-embeddings <command> [options]
+
+Commands:
+    status      Show the registered embedding columns and their coverage
+    create      Add a clips column for the model in EMBEDDING_NEXT_*
+    backfill    Embed clips missing from a column, then index it and mark it ready
+    activate    Record a ready column as the one search reads
+    drop        Remove a retired column and its index
+
+Options:
+    -column string      Embedding column (default: EMBEDDING_NEXT_COLUMN)
+    -batch int          Clips per batch for backfill (default: 500)
+    -max-inputs int     Most clips per embedding API request (default: EMBEDDING_BATCH_MAX_INPUTS)
+    -min-coverage float Share of clips a column must cover to be activated (default: 0.99)
+    -dry-run            Show what would be done without making changes
+    -help               Show this help message
+
+A migration, with the new model configured in EMBEDDING_NEXT_COLUMN,
+EMBEDDING_NEXT_PROVIDER, EMBEDDING_NEXT_MODEL and EMBEDDING_NEXT_DIMENSIONS:
+
+    # Add the column, then deploy with the EMBEDDING_NEXT_* settings so new
+    # clips are embedded into it and searches are sampled against it
+    migrate-embeddings create
+
+    # Embed existing clips, then build the column's index
+    migrate-embeddings backfill
+
+    # Check coverage and the embedding_dual_read_overlap_ratio metric
+    migrate-embeddings status
+
+    # Record the switch, then deploy with the printed EMBEDDING_* settings
+    migrate-embeddings activate
+
+    # Roll back by activating the previous column
+    migrate-embeddings activate -column embedding
+
+    # Drop a retired column once the new one has settled
+    migrate-embeddings drop -column embedding_bge_base
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Print(usage)
+		os.Exit(1)
+	}
+
+	command := os.Args[1]
+	if command == "-help" || command == "--help" || command == "help" {
+		fmt.Print(usage)
+		os.Exit(0)
+	}
+
+	// Set up flags
+	flagSet := flag.NewFlagSet(command, flag.ExitOnError)
+	column := flagSet.String("column", "", "Embedding column (default: EMBEDDING_NEXT_COLUMN)")
+	batchSize := flagSet.Int("batch", 500, "Clips per batch for backfill")
+	maxInputs := flagSet.Int("max-inputs", 0, "Most clips per embedding API request")
+	minCoverage := flagSet.Float64("min-coverage", 0.99, "Share of clips a column must cover to be activated")
+	dryRun := flagSet.Bool("dry-run", false, "Show what would be done")
+
+	// Parse flags
+	if len(os.Args) > 2 {
+		if err := flagSet.Parse(os.Args[2:]); err != nil {
+			log.Fatalf("Failed to parse flags: %v", err)
+		}
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	if *column == "" {
+		*column = cfg.Embedding.NextColumn
+	}
+	if command != "status" {
+		if err := repository.ValidateEmbeddingColumn(*column); err != nil {
+			log.Fatalf("Invalid -column %q (or EMBEDDING_NEXT_COLUMN): %v", *column, err)
+		}
+	}
+	if *maxInputs > 0 {
+		cfg.Embedding.BatchMaxInputs = *maxInputs
+	}
+
+	// Initialize database connection
+	db, err := database.NewDB(&cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := repository.NewClipEmbeddingColumnRepository(db.Pool)
+
+	// Execute command
+	switch command {
+	case "status":
+		executeStatus(ctx, repo)
+	case "create":
+		executeCreate(ctx, repo, cfg, *column, *dryRun)
+	case "backfill":
+		executeBackfill(ctx, repo, cfg, *column, *batchSize, *dryRun)
+	case "activate":
+		executeActivate(ctx, repo, *column, *minCoverage, *dryRun)
+	case "drop":
+		executeDrop(ctx, repo, *column, *dryRun)
+	default:
+		fmt.Printf("Unknown command: %s\n\n", command)
+		fmt.Print(usage)
+		os.Exit(1)
+	}
+}
+
+// embeddingConfigFor returns the configured model of a column, which must be
+// EMBEDDING_COLUMN or EMBEDDING_NEXT_COLUMN
+func embeddingConfigFor(cfg *config.Config, column string) (config.EmbeddingConfig, error) {
+	if next, ok := cfg.Embedding.Next(); ok && next.Column == column {
+		return next, nil
+	}
+	if cfg.Embedding.Column == column {
+		return cfg.Embedding, nil
+	}
+	return config.EmbeddingConfig{}, fmt.Errorf("no model is configured for %s; set EMBEDDING_NEXT_COLUMN=%s and the EMBEDDING_NEXT_* settings of its model", column, column)
+}
+
+func executeStatus(ctx context.Context, repo *repository.ClipEmbeddingColumnRepository) {
+	columns, err := repo.List(ctx)
+	if err != nil {
+		log.Fatalf("Failed to list embedding columns: %v", err)
+	}
+
+	fmt.Printf("%-24s %-12s %-10s %-32s %6s %9s %10s\n", "COLUMN", "STATUS", "PROVIDER", "MODEL", "DIMS", "COVERAGE", "MISSING")
+	for _, c := range columns {
+		provider := "-"
+		if c.Provider != nil {
+			provider = *c.Provider
+		}
+		fmt.Printf("%-24s %-12s %-10s %-32s %6d %8.2f%% %10d\n",
+			c.ColumnName, c.Status, provider, c.Model, c.Dimensions, c.Coverage()*100, c.Missing)
+	}
+}
+
+func executeCreate(ctx context.Context, repo *repository.ClipEmbeddingColumnRepository, cfg *config.Config, column string, dryRun bool) {
+	embeddingCfg, err := embeddingConfigFor(cfg, column)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	c := &models.ClipEmbeddingColumn{
+		ColumnName: column,
+		Provider:   &embeddingCfg.Provider,
+		Model:      embeddingCfg.Model,
+		Dimensions: embeddingCfg.Dimensions,
+	}
+	if dryRun {
+		fmt.Printf("DRY RUN: Would add column %s vector(%d) for %s model %s\n", c.ColumnName, c.Dimensions, embeddingCfg.Provider, c.Model)
+		return
+	}
+
+	if err := repo.Create(ctx, c); err != nil {
+		log.Fatalf("Failed to create embedding column: %v", err)
+	}
+	fmt.Printf("✓ Added column %s vector(%d) for %s model %s\n", c.ColumnName, c.Dimensions, embeddingCfg.Provider, c.Model)
+	fmt.Println("Deploy with the EMBEDDING_NEXT_* settings so new clips are embedded into it, then run backfill")
+}
+
+func executeBackfill(ctx context.Context, repo *repository.ClipEmbeddingColumnRepository, cfg *config.Config, column string, batchSize int, dryRun bool) {
+	embeddingCfg, err := embeddingConfigFor(cfg, column)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !embeddingCfg.Configured() {
+		log.Fatalf("The %s embedding provider is not configured for %s", embeddingCfg.Provider, column)
+	}
+	// The registry is the source of truth for what a column holds
+	if err := repo.CheckModel(ctx, column, embeddingCfg.Model, embeddingCfg.Dimensions); err != nil {
+		log.Fatalf("Refusing to backfill: %v", err)
+	}
+
+	redisClient, err := redis.NewClient(&cfg.Redis)
+	if err != nil {
+		log.Fatalf("Failed to connect to Redis: %v", err)
+	}
+	defer redisClient.Close()
+
+	embeddingService := services.NewEmbeddingService(services.ConfiguredEmbeddingConfig(&embeddingCfg, redisClient.GetClient()))
+	defer embeddingService.Close()
+	log.Printf("Backfilling %s with %s model %s (batch_size=%d, dry_run=%t)", column, embeddingCfg.Provider, embeddingCfg.Model, batchSize, dryRun)
+
+	start := time.Now()
+	processed, failed := 0, 0
+	stopped := false
+	offset := 0
+	for {
+		clips, err := repo.ListMissing(ctx, column, nil, batchSize, offset)
+		if err != nil {
+			log.Fatalf("Failed to list clips: %v", err)
+		}
+		if len(clips) == 0 {
+			break
+		}
+
+		// Clips whose requests failed come back without an embedding
+		embeddings, genErr := embeddingService.GenerateClipEmbeddings(ctx, clips)
+		var batchErr *services.EmbeddingBatchError
+		if genErr != nil && !errors.As(genErr, &batchErr) {
+			if errors.Is(genErr, services.ErrEmbeddingBudgetExceeded) {
+				log.Printf("WARNING: Monthly embedding budget exhausted; stopping backfill")
+				stopped = true
+				break
+			}
+			log.Printf("WARNING: Failed to generate embeddings for batch at offset %d: %v", offset, genErr)
+			failed += len(clips)
+			offset += len(clips)
+			continue
+		}
+		if batchErr != nil {
+			log.Printf("WARNING: Failed to generate %d of %d embeddings for batch at offset %d: %v",
+				len(batchErr.Failed), len(clips), offset, batchErr.Err)
+			failed += len(batchErr.Failed)
+		}
+
+		saved := 0
+		for i := range clips {
+			embedding := embeddings[i]
+			if embedding == nil {
+				continue
+			}
+			if len(embedding) != embeddingCfg.Dimensions {
+				log.Fatalf("Model %s returned %d dimensions, but %s holds %d", embeddingCfg.Model, len(embedding), column, embeddingCfg.Dimensions)
+			}
+			if dryRun {
+				processed++
+				continue
+			}
+			if err := repo.SaveEmbedding(ctx, column, clips[i].ID, embedding, embeddingCfg.Model); err != nil {
+				log.Printf("WARNING: Failed to save embedding for clip %s: %v", clips[i].ID, err)
+				failed++
+				continue
+			}
+			processed++
+			saved++
+		}
+		log.Printf("Progress: processed %d, failed %d", processed, failed)
+
+		if errors.Is(genErr, services.ErrEmbeddingBudgetExceeded) {
+			log.Printf("WARNING: Monthly embedding budget exhausted; stopping backfill")
+			stopped = true
+			break
+		}
+
+		// Saved clips drop out of the missing list, so only the clips still
+		// without an embedding move the offset
+		offset += len(clips) - saved
+
+		// Small delay between batches to avoid overwhelming the API
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	log.Printf("Backfill of %s finished in %v: processed %d, failed %d", column, time.Since(start).Round(time.Second), processed, failed)
+	if dryRun {
+		return
+	}
+	if stopped || failed > 0 {
+		log.Fatalf("%s is incomplete; run backfill again to retry the remaining clips", column)
+	}
+
+	// The index is built once, after the bulk of the writes
+	log.Printf("Building the index on %s...", column)
+	if err := repo.CreateIndex(ctx, column); err != nil {
+		log.Fatalf("Failed to index %s: %v", column, err)
+	}
+	if err := repo.MarkReady(ctx, column); err != nil {
+		log.Fatalf("Failed to mark %s ready: %v", column, err)
+	}
+	fmt.Printf("✓ %s is backfilled and indexed\n", column)
+}
+
+func executeActivate(ctx context.Context, repo *repository.ClipEmbeddingColumnRepository, column string, minCoverage float64, dryRun bool) {
+	c, err := repo.GetWithCoverage(ctx, column)
+	if err != nil {
+		log.Fatalf("Failed to get %s: %v", column, err)
+	}
+	if c.Coverage() < minCoverage {
+		log.Fatalf("%s covers %.2f%% of clips, below the %.2f%% required; run backfill first",
+			column, c.Coverage()*100, minCoverage*100)
+	}
+
+	if dryRun {
+		fmt.Printf("DRY RUN: Would activate %s (%s, %d dimensions, %.2f%% coverage)\n", column, c.Model, c.Dimensions, c.Coverage()*100)
+		return
+	}
+	if err := repo.Activate(ctx, column); err != nil {
+		log.Fatalf("Failed to activate %s: %v", column, err)
+	}
+
+	fmt.Printf("✓ %s is active. Deploy every instance with:\n\n", column)
+	fmt.Printf("    EMBEDDING_COLUMN=%s\n", column)
+	if c.Provider != nil {
+		fmt.Printf("    EMBEDDING_PROVIDER=%s\n", *c.Provider)
+	}
+	fmt.Printf("    EMBEDDING_MODEL=%s\n", c.Model)
+	fmt.Printf("    EMBEDDING_DIMENSIONS=%d\n", c.Dimensions)
+	fmt.Println("\nand EMBEDDING_NEXT_COLUMN unset, or set to the previous column to keep it filled for a rollback.")
+	fmt.Println("Instances read the column they are configured with, so search switches as each one restarts.")
+}
+
+func executeDrop(ctx context.Context, repo *repository.ClipEmbeddingColumnRepository, column string, dryRun bool) {
+	c, err := repo.Get(ctx, column)
+	if err != nil {
+		log.Fatalf("Failed to get %s: %v", column, err)
+	}
+	if dryRun {
+		fmt.Printf("DRY RUN: Would drop %s column %s (%s)\n", c.Status, column, c.Model)
+		return
+	}
+	if err := repo.Drop(ctx, column); err != nil {
+		log.Fatalf("Failed to drop %s: %v", column, err)
+	}
+	fmt.Printf("✓ Dropped %s\n", column)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+	"github.com/subculture-collective/clipper/pkg/database"
+)
+
+// normalize-clip-titles recomputes the display titles of existing clips with
+// the current normalizer and broadcaster opt-outs. Search documents pick up the
+// new titles on the next backfill-search or search-index-manager rebuild.
+func main() {
+	batchSize := flag.Int("batch", 500, "Number of clips to process in each batch")
+	broadcasterID := flag.String("broadcaster", "", "Only reprocess clips of this Twitch broadcaster ID")
+	dryRun := flag.Bool("dry-run", false, "Report what would change without writing")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	db, err := database.NewDB(&cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	titleService := services.NewClipTitleService(
+		repository.NewClipTitleRepository(db.Pool),
+		repository.NewUserRepository(db.Pool),
+		cfg.FeatureFlags.TitleNormalization,
+	)
+	if !titleService.Enabled() {
+		log.Println("WARNING: FEATURE_TITLE_NORMALIZATION is off, existing display titles will be cleared")
+	}
+
+	log.Println("Reprocessing clip titles...")
+	result, err := titleService.Reprocess(context.Background(), *broadcasterID, *dryRun, *batchSize)
+	if err != nil {
+		log.Fatalf("Failed to reprocess clip titles: %v", err)
+	}
+
+	if *dryRun {
+		log.Printf("Dry run: %d of %d clips would get a new display title and %d would lose theirs", result.Updated, result.Scanned, result.Cleared)
+		return
+	}
+	log.Printf("Scanned %d clips: %d display titles updated, %d cleared", result.Scanned, result.Updated, result.Cleared)
+}
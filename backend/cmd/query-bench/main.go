@@ -0,0 +1,300 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/pkg/database"
+)
+
+// Report is the result of a query-bench run
+type Report struct {
+	Timestamp       string            `json:"timestamp"`
+	MaxCostIncrease float64           `json:"max_cost_increase"`
+	Results         []QueryPlanResult `json:"results"`
+	Skipped         []string          `json:"skipped,omitempty"`
+	Violations      []Violation       `json:"violations,omitempty"`
+}
+
+func main() {
+	// Command line flags
+	baselinePath := flag.String("baseline", "testdata/query_plan_baseline.json", "Path to the baseline plan costs JSON file")
+	update := flag.Bool("update", false, "Record the current plans as the new baseline instead of checking them")
+	maxCostIncrease := flag.Float64("max-cost-increase", 0.2, "Allowed plan cost increase over the baseline as a fraction (0.2 = 20%)")
+	outputPath := flag.String("output", "", "Path to output JSON report (optional)")
+	only := flag.String("only", "", "Comma-separated query names to run (default: all)")
+	notes := flag.String("notes", "", "Notes stored with the baseline when using -update")
+	verbose := flag.Bool("verbose", false, "Print the indexes used by each query")
+	help := flag.Bool("help", false, "Show help message")
+
+	flag.Parse()
+
+	if *help {
+		printUsage()
+		os.Exit(0)
+	}
+
+	queries, err := selectQueries(*only)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	db, err := database.NewDB(&cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	// Fresh statistics keep plans comparable with the baseline run
+	if _, err := db.Pool.Exec(ctx, "ANALYZE"); err != nil {
+		log.Fatalf("Failed to analyze database: %v", err)
+	}
+
+	report := &Report{
+		Timestamp:       time.Now().Format(time.RFC3339),
+		MaxCostIncrease: *maxCostIncrease,
+	}
+
+	var baseline *Baseline
+	if !*update {
+		baseline, err = loadBaseline(*baselinePath)
+		if err != nil {
+			log.Fatalf("Failed to load baseline: %v", err)
+		}
+		if baseline == nil {
+			log.Printf("No baseline at %s; only checking for sequential scans", *baselinePath)
+		}
+	}
+
+	for _, q := range queries {
+		result, err := explainQuery(ctx, db.Pool, q)
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Printf("Skipping %s: seeded database has no sample arguments", q.Name)
+			report.Skipped = append(report.Skipped, q.Name)
+			continue
+		}
+		if err != nil {
+			log.Fatalf("Failed to explain %s: %v", q.Name, err)
+		}
+		report.Results = append(report.Results, *result)
+		if !*update {
+			report.Violations = append(report.Violations, checkResult(q, result, baseline, *maxCostIncrease)...)
+		}
+	}
+
+	printResults(report, baseline, *verbose)
+
+	if *outputPath != "" {
+		if err := writeJSON(*outputPath, report); err != nil {
+			log.Fatalf("Failed to write output file: %v", err)
+		}
+		log.Printf("Report written to: %s", *outputPath)
+	}
+
+	if *update {
+		newBaseline := &Baseline{
+			Timestamp: report.Timestamp,
+			Notes:     *notes,
+			Queries:   map[string]QueryPlanResult{},
+		}
+		for _, result := range report.Results {
+			newBaseline.Queries[result.Name] = result
+		}
+		if err := writeJSON(*baselinePath, newBaseline); err != nil {
+			log.Fatalf("Failed to write baseline: %v", err)
+		}
+		log.Printf("Baseline saved to: %s", *baselinePath)
+		return
+	}
+
+	// Exit with non-zero code if any guardrail is breached
+	if len(report.Violations) > 0 {
+		os.Exit(1)
+	}
+}
+
+func printUsage() {
+	fmt.Println("Query Plan Guardrails")
+	fmt.Println()
+	fmt.Println("Runs EXPLAIN ANALYZE on repository hot-path queries against a seeded database")
+	fmt.Println("and fails when a plan uses a sequential scan on an indexed table, stops using")
+	fmt.Println("a baseline index, or costs more than the allowed increase over the baseline.")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  query-bench [options]")
+	fmt.Println()
+	fmt.Println("Options:")
+	flag.PrintDefaults()
+	fmt.Println()
+	fmt.Println("Queries:")
+	for _, q := range hotPathQueries {
+		fmt.Printf("  %-26s %s\n", q.Name, q.Description)
+	}
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  # Check plans against the committed baseline")
+	fmt.Println("  query-bench")
+	fmt.Println()
+	fmt.Println("  # Record a new baseline after an intentional plan change")
+	fmt.Println("  query-bench -update -notes \"Add clips hot score index\"")
+	fmt.Println()
+	fmt.Println("  # Check only the feed queries with a stricter threshold")
+	fmt.Println("  query-bench -only clip_list_hot,following_feed -max-cost-increase 0.1")
+}
+
+// selectQueries returns the hot-path queries named in only, or all of them
+func selectQueries(only string) ([]BenchQuery, error) {
+	if only == "" {
+		return hotPathQueries, nil
+	}
+
+	byName := map[string]BenchQuery{}
+	for _, q := range hotPathQueries {
+		byName[q.Name] = q
+	}
+
+	var queries []BenchQuery
+	for _, name := range strings.Split(only, ",") {
+		name = strings.TrimSpace(name)
+		q, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown query %q", name)
+		}
+		queries = append(queries, q)
+	}
+	return queries, nil
+}
+
+// explainQuery runs EXPLAIN ANALYZE for a query inside a transaction that is
+// always rolled back. Sequential scans are disabled for the transaction so
+// the planner uses any index that can serve a table; a sequential scan that
+// remains means no index can, regardless of how small the seeded tables are.
+func explainQuery(ctx context.Context, pool *pgxpool.Pool, q BenchQuery) (*QueryPlanResult, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, "SET LOCAL enable_seqscan = off"); err != nil {
+		return nil, fmt.Errorf("failed to disable sequential scans: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, q.ArgsSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select sample arguments: %w", err)
+	}
+	if !rows.Next() {
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("failed to select sample arguments: %w", err)
+		}
+		return nil, pgx.ErrNoRows
+	}
+	args, err := rows.Values()
+	rows.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sample arguments: %w", err)
+	}
+
+	var raw []byte
+	if err := tx.QueryRow(ctx, "EXPLAIN (ANALYZE, BUFFERS, FORMAT JSON) "+q.SQL, args...).Scan(&raw); err != nil {
+		return nil, fmt.Errorf("failed to explain query: %w", err)
+	}
+
+	return parseExplain(q.Name, raw)
+}
+
+func printResults(report *Report, baseline *Baseline, verbose bool) {
+	fmt.Println()
+	fmt.Println("========================================")
+	fmt.Println("     Query Plan Guardrails")
+	fmt.Println("========================================")
+	fmt.Println()
+
+	fmt.Printf("  %-26s %12s %12s %10s\n", "Query", "Cost", "Baseline", "Exec (ms)")
+	fmt.Println("-----------------------------------------------------------------")
+	for _, result := range report.Results {
+		baselineCost := "-"
+		if baseline != nil {
+			if base, ok := baseline.Queries[result.Name]; ok {
+				baselineCost = fmt.Sprintf("%.2f", base.TotalCost)
+			}
+		}
+		fmt.Printf("  %-26s %12.2f %12s %10.3f\n", result.Name, result.TotalCost, baselineCost, result.ExecutionTimeMs)
+		if verbose && len(result.Indexes) > 0 {
+			fmt.Printf("    indexes: %s\n", strings.Join(result.Indexes, ", "))
+		}
+	}
+	fmt.Println()
+
+	if len(report.Skipped) > 0 {
+		fmt.Printf("Skipped (no sample data): %s\n\n", strings.Join(report.Skipped, ", "))
+	}
+
+	if len(report.Violations) == 0 {
+		fmt.Println("✅ No plan regressions")
+		fmt.Println()
+		return
+	}
+
+	fmt.Println("Violations:")
+	fmt.Println("-----------------------------------------")
+	for _, v := range report.Violations {
+		fmt.Printf("  ❌ %s: %s\n", v.Query, v.Reason)
+	}
+	fmt.Println()
+}
+
+// loadBaseline reads the baseline file, returning nil if it does not exist
+func loadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var baseline Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline: %w", err)
+	}
+	return &baseline, nil
+}
+
+func writeJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// PlanNode is one node of a PostgreSQL EXPLAIN (FORMAT JSON) plan
+type PlanNode struct {
+	NodeType        string     `json:"Node Type"`
+	RelationName    string     `json:"Relation Name,omitempty"`
+	IndexName       string     `json:"Index Name,omitempty"`
+	TotalCost       float64    `json:"Total Cost"`
+	ActualTotalTime float64    `json:"Actual Total Time"`
+	Plans           []PlanNode `json:"Plans,omitempty"`
+}
+
+// explainOutput is the top-level element of EXPLAIN (FORMAT JSON) output
+type explainOutput struct {
+	Plan          PlanNode `json:"Plan"`
+	PlanningTime  float64  `json:"Planning Time"`
+	ExecutionTime float64  `json:"Execution Time"`
+}
+
+// QueryPlanResult is the measured plan of one benchmark query
+type QueryPlanResult struct {
+	Name            string   `json:"name"`
+	TotalCost       float64  `json:"total_cost"`
+	PlanningTimeMs  float64  `json:"planning_time_ms"`
+	ExecutionTimeMs float64  `json:"execution_time_ms"`
+	SeqScans        []string `json:"seq_scans,omitempty"`
+	Indexes         []string `json:"indexes,omitempty"`
+}
+
+// Baseline holds the recorded plan costs that later runs are compared against
+type Baseline struct {
+	Timestamp string                     `json:"timestamp"`
+	Notes     string                     `json:"notes,omitempty"`
+	Queries   map[string]QueryPlanResult `json:"queries"`
+}
+
+// Violation describes a guardrail a query failed
+type Violation struct {
+	Query  string `json:"query"`
+	Reason string `json:"reason"`
+}
+
+// parseExplain reads the JSON output of EXPLAIN (ANALYZE, FORMAT JSON)
+func parseExplain(name string, raw []byte) (*QueryPlanResult, error) {
+	var outputs []explainOutput
+	if err := json.Unmarshal(raw, &outputs); err != nil {
+		return nil, fmt.Errorf("failed to parse plan: %w", err)
+	}
+	if len(outputs) == 0 {
+		return nil, fmt.Errorf("empty plan")
+	}
+
+	out := outputs[0]
+	result := &QueryPlanResult{
+		Name:            name,
+		TotalCost:       out.Plan.TotalCost,
+		PlanningTimeMs:  out.PlanningTime,
+		ExecutionTimeMs: out.ExecutionTime,
+	}
+
+	seqScans := map[string]bool{}
+	indexes := map[string]bool{}
+	walkPlan(&out.Plan, func(node *PlanNode) {
+		if node.NodeType == "Seq Scan" && node.RelationName != "" {
+			seqScans[node.RelationName] = true
+		}
+		if node.IndexName != "" {
+			indexes[node.IndexName] = true
+		}
+	})
+	result.SeqScans = sortedKeys(seqScans)
+	result.Indexes = sortedKeys(indexes)
+
+	return result, nil
+}
+
+// walkPlan visits every node of a plan depth-first
+func walkPlan(node *PlanNode, visit func(*PlanNode)) {
+	visit(node)
+	for i := range node.Plans {
+		walkPlan(&node.Plans[i], visit)
+	}
+}
+
+// checkResult applies the guardrails for one query. A sequential scan on any
+// of the query's indexed tables always fails. When the baseline has an entry
+// for the query, dropping an index it used or exceeding its cost also fails.
+// maxCostIncrease is a fraction, so 0.2 allows plans up to 20% more expensive
+// than the baseline.
+func checkResult(q BenchQuery, result *QueryPlanResult, baseline *Baseline, maxCostIncrease float64) []Violation {
+	var violations []Violation
+
+	for _, table := range result.SeqScans {
+		for _, indexed := range q.IndexedTables {
+			if table == indexed {
+				violations = append(violations, Violation{
+					Query:  q.Name,
+					Reason: fmt.Sprintf("sequential scan on %s", table),
+				})
+			}
+		}
+	}
+
+	if baseline == nil {
+		return violations
+	}
+	base, ok := baseline.Queries[q.Name]
+	if !ok || base.TotalCost <= 0 {
+		return violations
+	}
+
+	used := map[string]bool{}
+	for _, index := range result.Indexes {
+		used[index] = true
+	}
+	for _, index := range base.Indexes {
+		if !used[index] {
+			violations = append(violations, Violation{
+				Query:  q.Name,
+				Reason: fmt.Sprintf("no longer uses index %s", index),
+			})
+		}
+	}
+
+	limit := base.TotalCost * (1 + maxCostIncrease)
+	if result.TotalCost > limit {
+		violations = append(violations, Violation{
+			Query: q.Name,
+			Reason: fmt.Sprintf("cost %.2f exceeds baseline %.2f by %.1f%% (allowed %.1f%%)",
+				result.TotalCost, base.TotalCost,
+				(result.TotalCost/base.TotalCost-1)*100, maxCostIncrease*100),
+		})
+	}
+
+	return violations
+}
+
+func sortedKeys(m map[string]bool) []string {
+	if len(m) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+const samplePlan = `[
+  {
+    "Plan": {
+      "Node Type": "Limit",
+      "Total Cost": 120.5,
+      "Actual Total Time": 0.42,
+      "Plans": [
+        {
+          "Node Type": "Nested Loop",
+          "Total Cost": 118.0,
+          "Plans": [
+            {"Node Type": "Index Scan", "Relation Name": "clips", "Index Name": "idx_clips_created_at", "Total Cost": 80.0},
+            {"Node Type": "Seq Scan", "Relation Name": "users", "Total Cost": 30.0}
+          ]
+        }
+      ]
+    },
+    "Planning Time": 0.2,
+    "Execution Time": 0.5
+  }
+]`
+
+func TestParseExplain(t *testing.T) {
+	result, err := parseExplain("clip_list_new", []byte(samplePlan))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.TotalCost != 120.5 {
+		t.Fatalf("expected total cost 120.5, got %v", result.TotalCost)
+	}
+	if result.ExecutionTimeMs != 0.5 {
+		t.Fatalf("expected execution time 0.5, got %v", result.ExecutionTimeMs)
+	}
+	if len(result.SeqScans) != 1 || result.SeqScans[0] != "users" {
+		t.Fatalf("expected seq scan on users, got %v", result.SeqScans)
+	}
+	if len(result.Indexes) != 1 || result.Indexes[0] != "idx_clips_created_at" {
+		t.Fatalf("expected idx_clips_created_at, got %v", result.Indexes)
+	}
+}
+
+func TestParseExplainEmpty(t *testing.T) {
+	if _, err := parseExplain("empty", []byte(`[]`)); err == nil {
+		t.Fatal("expected error for empty plan")
+	}
+}
+
+func TestCheckResultSeqScanOnIndexedTable(t *testing.T) {
+	q := BenchQuery{Name: "clip_list_new", IndexedTables: []string{"clips"}}
+
+	ok := &QueryPlanResult{Name: q.Name, TotalCost: 100, SeqScans: []string{"users"}}
+	if violations := checkResult(q, ok, nil, 0.2); len(violations) != 0 {
+		t.Fatalf("seq scan on a table outside IndexedTables should pass, got %v", violations)
+	}
+
+	bad := &QueryPlanResult{Name: q.Name, TotalCost: 100, SeqScans: []string{"clips"}}
+	violations := checkResult(q, bad, nil, 0.2)
+	if len(violations) != 1 || !strings.Contains(violations[0].Reason, "sequential scan on clips") {
+		t.Fatalf("expected sequential scan violation, got %v", violations)
+	}
+}
+
+func TestCheckResultAgainstBaseline(t *testing.T) {
+	q := BenchQuery{Name: "clip_list_hot", IndexedTables: []string{"clips"}}
+	baseline := &Baseline{Queries: map[string]QueryPlanResult{
+		q.Name: {Name: q.Name, TotalCost: 100, Indexes: []string{"idx_clips_hot"}},
+	}}
+
+	tests := []struct {
+		name       string
+		result     QueryPlanResult
+		violations int
+		reason     string
+	}{
+		{
+			name:   "within threshold",
+			result: QueryPlanResult{TotalCost: 119, Indexes: []string{"idx_clips_hot"}},
+		},
+		{
+			name:       "cost regression",
+			result:     QueryPlanResult{TotalCost: 121, Indexes: []string{"idx_clips_hot"}},
+			violations: 1,
+			reason:     "exceeds baseline",
+		},
+		{
+			name:       "index dropped",
+			result:     QueryPlanResult{TotalCost: 90, Indexes: []string{"clips_pkey"}},
+			violations: 1,
+			reason:     "no longer uses index idx_clips_hot",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.result.Name = q.Name
+			violations := checkResult(q, &tt.result, baseline, 0.2)
+			if len(violations) != tt.violations {
+				t.Fatalf("expected %d violations, got %v", tt.violations, violations)
+			}
+			if tt.reason != "" && !strings.Contains(violations[0].Reason, tt.reason) {
+				t.Fatalf("expected reason containing %q, got %q", tt.reason, violations[0].Reason)
+			}
+		})
+	}
+}
+
+func TestCheckResultIgnoresQueriesMissingFromBaseline(t *testing.T) {
+	q := BenchQuery{Name: "comments_by_clip", IndexedTables: []string{"comments"}}
+	baseline := &Baseline{Queries: map[string]QueryPlanResult{}}
+
+	result := &QueryPlanResult{Name: q.Name, TotalCost: 1e6}
+	if violations := checkResult(q, result, baseline, 0.2); len(violations) != 0 {
+		t.Fatalf("expected no violations for a query without a baseline, got %v", violations)
+	}
+}
+
+func TestSelectQueries(t *testing.T) {
+	all, err := selectQueries("")
+	if err != nil || len(all) != len(hotPathQueries) {
+		t.Fatalf("expected all queries, got %d (err %v)", len(all), err)
+	}
+
+	some, err := selectQueries("clip_list_hot, following_feed")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(some) != 2 || some[0].Name != "clip_list_hot" || some[1].Name != "following_feed" {
+		t.Fatalf("unexpected selection: %v", some)
+	}
+
+	if _, err := selectQueries("nope"); err == nil {
+		t.Fatal("expected error for unknown query")
+	}
+}
+
+func TestHotPathQueriesAreWellFormed(t *testing.T) {
+	seen := map[string]bool{}
+	for _, q := range hotPathQueries {
+		if seen[q.Name] {
+			t.Fatalf("duplicate query name %q", q.Name)
+		}
+		seen[q.Name] = true
+		if q.SQL == "" || q.ArgsSQL == "" || len(q.IndexedTables) == 0 {
+			t.Fatalf("query %q is missing SQL, ArgsSQL or IndexedTables", q.Name)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+// BenchQuery is a repository hot-path query whose plan is guarded
+type BenchQuery struct {
+	Name        string
+	Description string
+	// SQL mirrors the query issued by the repository
+	SQL string
+	// ArgsSQL selects one row of sample arguments for SQL from the seeded
+	// database. The query is skipped when it returns no rows.
+	ArgsSQL string
+	// IndexedTables must be read through an index; a sequential scan on any
+	// of them fails the run
+	IndexedTables []string
+}
+
+// clipListColumns is the column list used by ClipRepository.ListWithFilters,
+// which follows it with the sort key columns of the cursor
+const clipListColumns = `
+	c.id, c.twitch_clip_id, c.twitch_clip_url, c.embed_url, c.title,
+	c.creator_name, c.creator_id, c.broadcaster_name, c.broadcaster_id,
+	c.game_id, c.game_name, c.language, c.thumbnail_url, c.duration,
+	c.view_count, c.created_at, c.imported_at, c.vote_score, c.comment_count,
+	c.favorite_count, c.is_featured, c.is_nsfw, c.is_removed, c.removed_reason, c.is_hidden,
+	c.submitted_by_user_id, c.submitted_at,
+	c.trending_score, c.hot_score, c.popularity_index, c.engagement_count`
+
+// hotPathQueries are the queries checked by query-bench. Keep them in sync
+// with the repository methods named in each description.
+var hotPathQueries = []BenchQuery{
+	{
+		Name:        "clip_list_hot",
+		Description: "ClipRepository.ListWithFilters, sort=hot (home feed)",
+		SQL: `SELECT ` + clipListColumns + `
+			FROM clips c
+			WHERE c.is_removed = false AND c.is_hidden = false
+			ORDER BY calculate_hot_score(c.vote_score, c.created_at) DESC, c.created_at DESC, c.id DESC
+			LIMIT $1 OFFSET $2`,
+		ArgsSQL:       `SELECT 25, 0`,
+		IndexedTables: []string{"clips"},
+	},
+	{
+		Name:        "clip_list_new",
+		Description: "ClipRepository.ListWithFilters, sort=new",
+		SQL: `SELECT ` + clipListColumns + `
+			FROM clips c
+			WHERE c.is_removed = false AND c.is_hidden = false
+			ORDER BY COALESCE(c.submitted_at, c.created_at) DESC, c.id DESC
+			LIMIT $1 OFFSET $2`,
+		ArgsSQL:       `SELECT 25, 0`,
+		IndexedTables: []string{"clips"},
+	},
+	{
+		Name:        "clip_list_top",
+		Description: "ClipRepository.ListWithFilters, sort=top",
+		SQL: `SELECT ` + clipListColumns + `
+			FROM clips c
+			WHERE c.is_removed = false AND c.is_hidden = false
+			ORDER BY c.vote_score DESC, c.created_at DESC, c.id DESC
+			LIMIT $1 OFFSET $2`,
+		ArgsSQL:       `SELECT 25, 0`,
+		IndexedTables: []string{"clips"},
+	},
+	{
+		Name:        "clip_list_top_cursor",
+		Description: "ClipRepository.ListWithFiltersCursor, sort=top, continuing after a cursor",
+		SQL: `SELECT ` + clipListColumns + `
+			FROM clips c
+			WHERE c.is_removed = false AND c.is_hidden = false
+				AND (c.vote_score, c.created_at, c.id) < ($1, $2, $3)
+			ORDER BY c.vote_score DESC, c.created_at DESC, c.id DESC
+			LIMIT $4 OFFSET 0`,
+		ArgsSQL:       `SELECT vote_score, created_at, id, 26 FROM clips ORDER BY vote_score DESC, created_at DESC, id DESC OFFSET 100 LIMIT 1`,
+		IndexedTables: []string{"clips"},
+	},
+	{
+		Name:        "clip_list_by_game",
+		Description: "ClipRepository.ListWithFilters, game filter",
+		SQL: `SELECT ` + clipListColumns + `
+			FROM clips c
+			WHERE c.is_removed = false AND c.is_hidden = false AND c.game_id = $1
+			ORDER BY calculate_hot_score(c.vote_score, c.created_at) DESC, c.created_at DESC, c.id DESC
+			LIMIT $2 OFFSET $3`,
+		ArgsSQL:       `SELECT game_id, 25, 0 FROM clips WHERE game_id IS NOT NULL GROUP BY game_id ORDER BY COUNT(*) DESC LIMIT 1`,
+		IndexedTables: []string{"clips"},
+	},
+	{
+		Name:        "clip_list_by_broadcaster",
+		Description: "ClipRepository.ListClipsByBroadcaster, sort=recent",
+		SQL: `SELECT ` + clipListColumns + `
+			FROM clips c
+			WHERE (c.broadcaster_id = $1 OR EXISTS (
+				SELECT 1 FROM clip_featured_broadcasters cfb WHERE cfb.clip_id = c.id AND cfb.broadcaster_id = $1
+			)) AND c.is_removed = false
+			ORDER BY c.created_at DESC
+			LIMIT $2 OFFSET $3`,
+		ArgsSQL:       `SELECT broadcaster_id, 25, 0 FROM clips WHERE broadcaster_id IS NOT NULL GROUP BY broadcaster_id ORDER BY COUNT(*) DESC LIMIT 1`,
+		IndexedTables: []string{"clips"},
+	},
+	{
+		Name:        "clip_by_twitch_id",
+		Description: "ClipRepository.GetByTwitchClipID",
+		SQL: `SELECT ` + clipListColumns + `
+			FROM clips c
+			WHERE c.twitch_clip_id = $1`,
+		ArgsSQL:       `SELECT twitch_clip_id FROM clips ORDER BY created_at DESC LIMIT 1`,
+		IndexedTables: []string{"clips"},
+	},
+	{
+		Name:        "following_feed",
+		Description: "ClipRepository.GetFollowingFeedClips",
+		SQL: `WITH followed_users AS (
+				SELECT following_id FROM user_follows WHERE follower_id = $1
+			),
+			followed_broadcasters AS (
+				SELECT broadcaster_id FROM broadcaster_follows WHERE user_id = $1
+			),
+			blocked_users AS (
+				SELECT blocked_user_id FROM user_blocks WHERE user_id = $1
+			)
+			SELECT ` + clipListColumns + `,
+				u.id, u.username, u.display_name, u.avatar_url
+			FROM clips c
+			LEFT JOIN users u ON c.submitted_by_user_id = u.id
+			WHERE c.is_removed = false
+			AND c.is_hidden = false
+			AND (
+				c.submitted_by_user_id IN (SELECT following_id FROM followed_users)
+				OR c.broadcaster_id IN (SELECT broadcaster_id FROM followed_broadcasters)
+			)
+			AND c.submitted_by_user_id NOT IN (SELECT blocked_user_id FROM blocked_users)
+			ORDER BY c.created_at DESC
+			LIMIT $2 OFFSET $3`,
+		ArgsSQL:       `SELECT user_id, 25, 0 FROM broadcaster_follows GROUP BY user_id ORDER BY COUNT(*) DESC LIMIT 1`,
+		IndexedTables: []string{"clips", "user_follows", "broadcaster_follows"},
+	},
+	{
+		Name:        "comments_by_clip",
+		Description: "CommentRepository.ListByClipID, top-level comments",
+		SQL: `SELECT
+				c.id, c.clip_id, c.user_id, c.parent_comment_id, c.content,
+				c.vote_score, c.reply_count, c.is_edited, c.is_removed, c.removed_reason,
+				c.created_at, c.updated_at,
+				u.username, u.display_name, u.avatar_url, u.karma_points, u.role
+			FROM comments c
+			INNER JOIN users u ON c.user_id = u.id
+			WHERE c.clip_id = $1 AND c.parent_comment_id IS NULL
+			ORDER BY c.vote_score DESC, c.created_at DESC
+			LIMIT $2 OFFSET $3`,
+		ArgsSQL:       `SELECT clip_id, 50, 0 FROM comments GROUP BY clip_id ORDER BY COUNT(*) DESC LIMIT 1`,
+		IndexedTables: []string{"comments"},
+	},
+	{
+		Name:        "notifications_by_user",
+		Description: "NotificationRepository.ListByUserID, filter=unread",
+		SQL: `SELECT
+				n.id, n.user_id, n.type, n.title, n.message, n.link, n.is_read,
+				n.created_at, n.expires_at, n.source_user_id, n.source_content_id, n.source_content_type,
+				u.username, u.display_name, u.avatar_url
+			FROM notifications n
+			LEFT JOIN users u ON n.source_user_id = u.id
+			WHERE n.user_id = $1 AND n.is_read = false
+			ORDER BY n.created_at DESC
+			LIMIT $2 OFFSET $3`,
+		ArgsSQL:       `SELECT user_id, 20, 0 FROM notifications GROUP BY user_id ORDER BY COUNT(*) DESC LIMIT 1`,
+		IndexedTables: []string{"notifications"},
+	},
+}
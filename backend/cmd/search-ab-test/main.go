@@ -9,6 +9,8 @@ import (
 	"os"
 	"time"
 
+	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/searchlive"
 	"github.com/subculture-collective/clipper/internal/services"
 )
 
@@ -19,6 +21,7 @@ func main() {
 	configAName := flag.String("config-a", "baseline", "Name of configuration A")
 	configBName := flag.String("config-b", "semantic-heavy", "Name of configuration B")
 	listConfigs := flag.Bool("list-configs", false, "List available configurations and exit")
+	liveMode := flag.Bool("live", false, "Run queries against live search using the database, OpenSearch and embedding settings from the environment")
 	help := flag.Bool("help", false, "Show help message")
 
 	flag.Parse()
@@ -34,8 +37,24 @@ func main() {
 		os.Exit(0)
 	}
 
-	// Create evaluation service
-	evalService := services.NewSearchEvaluationService(nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	// Create evaluation service, connected to live search when requested
+	var hybridSearch *services.HybridSearchService
+	if *liveMode {
+		cfg, err := config.Load()
+		if err != nil {
+			log.Fatalf("Failed to load configuration: %v", err)
+		}
+		stack, err := searchlive.Connect(ctx, cfg)
+		if err != nil {
+			log.Fatalf("Failed to connect to live search: %v", err)
+		}
+		defer stack.Close()
+		hybridSearch = stack.Search
+	}
+	evalService := services.NewSearchEvaluationService(hybridSearch)
 
 	// Load dataset
 	log.Printf("Loading evaluation dataset from: %s", *datasetPath)
@@ -69,10 +88,15 @@ func main() {
 	log.Printf("Comparing configurations: %s vs %s", configA.Name, configB.Name)
 
 	// Run A/B test
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
-	defer cancel()
-
-	result, err := abHarness.EvaluateWithSimulated(ctx, configA, configB)
+	var result *services.ABTestResult
+	var err error
+	if *liveMode {
+		log.Println("Running A/B test against live search...")
+		result, err = abHarness.EvaluateWithLiveSearch(ctx, configA, configB)
+	} else {
+		log.Println("Running A/B test with simulated (ideal) results...")
+		result, err = abHarness.EvaluateWithSimulated(ctx, configA, configB)
+	}
 	if err != nil {
 		log.Fatalf("A/B test failed: %v", err)
 	}
@@ -109,6 +133,9 @@ func printUsage() {
 	fmt.Println()
 	fmt.Println("  # Compare and save results")
 	fmt.Println("  search-ab-test -config-a baseline -config-b engagement-focused -output results.json")
+	fmt.Println()
+	fmt.Println("  # Compare against live search")
+	fmt.Println("  search-ab-test -live -config-a baseline -config-b text-heavy")
 }
 
 func printConfigurations() {
@@ -123,8 +150,8 @@ func printConfigurations() {
 		fmt.Printf("  BM25 Weight: %.2f | Vector Weight: %.2f\n", c.BM25Weight, c.VectorWeight)
 		fmt.Printf("  Title Boost: %.1f | Creator Boost: %.1f | Game Boost: %.1f\n",
 			c.TitleBoost, c.CreatorBoost, c.GameBoost)
-		fmt.Printf("  Engagement Boost: %.2f | Recency Boost: %.2f\n",
-			c.EngagementBoost, c.RecencyBoost)
+		fmt.Printf("  Engagement Boost: %.2f | Recency Boost: %.2f | Hype Boost: %.2f\n",
+			c.EngagementBoost, c.RecencyBoost, c.HypeBoost)
 		fmt.Println()
 	}
 }
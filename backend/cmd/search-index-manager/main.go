@@ -3,13 +3,16 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/repository"
 	"github.com/subculture-collective/clipper/internal/services"
 	"github.com/subculture-collective/clipper/pkg/database"
 	opensearchpkg "github.com/subculture-collective/clipper/pkg/opensearch"
@@ -21,18 +24,29 @@ Usage:
     search-index-manager <command> [options]
 
 Commands:
-    status      Show status of all index versions
-    rebuild     Rebuild search index with zero-downtime swap
-    swap        Swap alias to a specific index version
-    rollback    Rollback alias to a previous version
-    cleanup     Delete old index versions
+    status           Show status of all index versions
+    rebuild          Rebuild search index with zero-downtime swap
+    incremental      Reindex documents changed since the last checkpoint
+    swap             Swap alias to a specific index version
+    rollback         Rollback alias to a previous version
+    cleanup          Delete old index versions
+    snapshot-repo    Register the snapshot repository
+    snapshot         Take a named snapshot of search indices
+    snapshots        List snapshots in the repository
+    restore          Restore an index version from a snapshot
+    snapshot-policy  Create or update the scheduled snapshot policy
+    synonyms         Show whether indices use the current search synonyms
+    sync-synonyms    Rebuild indices whose search synonyms are out of date
 
 Options:
     -index string     Index name (clips, users, tags, games, or 'all')
-    -version int      Target version for swap/rollback (default: latest)
+    -version int      Target version for swap/rollback/restore (default: latest)
     -batch int        Batch size for rebuild (default: 100)
     -keep int         Number of old versions to keep (default: 2)
+    -min-age int      Keep old versions until they are this many hours old (cleanup)
     -no-swap          Skip alias swap after rebuild
+    -no-snapshot      Skip the pre-rebuild snapshot
+    -name string      Snapshot name for snapshot/restore (default: generated/latest)
     -dry-run          Show what would be done without making changes
     -json             Output results as JSON
     -help             Show this help message
@@ -50,6 +64,9 @@ Examples:
     # Rebuild all indices
     search-index-manager rebuild -index all
 
+    # Reindex everything changed since the last rebuild or incremental run
+    search-index-manager incremental -index all
+
     # Rebuild without swapping alias (for testing)
     search-index-manager rebuild -index clips -no-swap
 
@@ -61,6 +78,22 @@ Examples:
 
     # Clean up old versions, keeping 2 most recent
     search-index-manager cleanup -index clips -keep 2
+
+    # Clean up old versions beyond the 2 most recent once they are 3 days old
+    search-index-manager cleanup -index all -keep 2 -min-age 72
+
+    # Register the snapshot repository and scheduled snapshot policy
+    search-index-manager snapshot-repo
+    search-index-manager snapshot-policy
+
+    # Take a named snapshot of all indices
+    search-index-manager snapshot -name before-migration
+
+    # Restore clips v3 from the latest snapshot containing it
+    search-index-manager restore -index clips -version 3
+
+    # Apply synonym changes made through the admin API
+    search-index-manager sync-synonyms
 `
 
 func main() {
@@ -81,7 +114,10 @@ func main() {
 	version := flagSet.Int("version", 0, "Target version for swap/rollback")
 	batchSize := flagSet.Int("batch", 100, "Batch size for rebuild")
 	keepVersions := flagSet.Int("keep", 2, "Number of old versions to keep")
+	minAgeHours := flagSet.Int("min-age", 0, "Keep old versions until they are this many hours old")
 	noSwap := flagSet.Bool("no-swap", false, "Skip alias swap after rebuild")
+	noSnapshot := flagSet.Bool("no-snapshot", false, "Skip the pre-rebuild snapshot")
+	snapshotName := flagSet.String("name", "", "Snapshot name for snapshot/restore")
 	dryRun := flagSet.Bool("dry-run", false, "Show what would be done")
 	jsonOutput := flagSet.Bool("json", false, "Output as JSON")
 
@@ -123,20 +159,60 @@ func main() {
 
 	// Initialize services
 	rebuildService := services.NewIndexRebuildService(db, osClient)
+	if cfg.HybridSearch.OpenSearchKNN {
+		rebuildService.EnableKNN(cfg.HybridSearch.RRFRankConstant)
+	}
+	rebuildService.SetSynonymSource(repository.NewSearchSynonymRepository(db.Pool))
+	rebuildService.SetRetentionPolicy(services.IndexRetentionPolicy{
+		KeepVersions: cfg.OpenSearch.IndexKeepVersions,
+		MinAge:       time.Duration(cfg.OpenSearch.IndexMinAgeHours) * time.Hour,
+	})
 	versionService := rebuildService.GetVersionService()
+	snapshotService := services.NewIndexSnapshotService(osClient, services.SnapshotConfig{
+		Repository:     cfg.OpenSearch.SnapshotRepository,
+		RepositoryType: cfg.OpenSearch.SnapshotRepositoryType,
+		Location:       cfg.OpenSearch.SnapshotLocation,
+		BasePath:       cfg.OpenSearch.SnapshotBasePath,
+		Schedule:       cfg.OpenSearch.SnapshotSchedule,
+		RetentionCount: cfg.OpenSearch.SnapshotRetentionCount,
+		RetentionAge:   cfg.OpenSearch.SnapshotRetentionAge,
+	})
 
 	// Execute command
 	switch command {
 	case "status":
-		executeStatus(ctx, versionService, *indexName, *jsonOutput)
+		executeStatus(ctx, versionService, snapshotService, *indexName, *jsonOutput)
 	case "rebuild":
+		if !*noSnapshot && !*dryRun {
+			takePreRebuildSnapshot(ctx, versionService, snapshotService, *indexName, *jsonOutput)
+		}
 		executeRebuild(ctx, rebuildService, *indexName, *batchSize, *keepVersions, !*noSwap, *dryRun, *jsonOutput)
+	case "incremental":
+		executeIncremental(ctx, rebuildService, *indexName, *batchSize, *dryRun, *jsonOutput)
 	case "swap":
 		executeSwap(ctx, versionService, *indexName, *version, *dryRun, *jsonOutput)
 	case "rollback":
-		executeRollback(ctx, versionService, *indexName, *version, *dryRun, *jsonOutput)
+		executeRollback(ctx, versionService, snapshotService, *indexName, *version, *dryRun, *jsonOutput)
 	case "cleanup":
-		executeCleanup(ctx, versionService, *indexName, *keepVersions, *dryRun, *jsonOutput)
+		policy := services.IndexRetentionPolicy{
+			KeepVersions: *keepVersions,
+			MinAge:       time.Duration(*minAgeHours) * time.Hour,
+		}
+		executeCleanup(ctx, versionService, *indexName, policy, *dryRun, *jsonOutput)
+	case "snapshot-repo":
+		executeSnapshotRepo(ctx, snapshotService, *dryRun, *jsonOutput)
+	case "snapshot":
+		executeSnapshot(ctx, snapshotService, *indexName, *snapshotName, *dryRun, *jsonOutput)
+	case "snapshots":
+		executeListSnapshots(ctx, snapshotService, *jsonOutput)
+	case "restore":
+		executeRestore(ctx, versionService, snapshotService, *indexName, *version, *snapshotName, *dryRun, *jsonOutput)
+	case "snapshot-policy":
+		executeSnapshotPolicy(ctx, snapshotService, *dryRun, *jsonOutput)
+	case "synonyms":
+		executeSynonymStatus(ctx, rebuildService, *indexName, *jsonOutput)
+	case "sync-synonyms":
+		executeSyncSynonyms(ctx, rebuildService, versionService, snapshotService, *indexName, *batchSize, *keepVersions, *noSnapshot, *dryRun, *jsonOutput)
 	default:
 		fmt.Printf("Unknown command: %s\n\n", command)
 		fmt.Print(usage)
@@ -168,7 +244,7 @@ func getTargetIndices(indexName string) []string {
 	return []string{indexName}
 }
 
-func executeStatus(ctx context.Context, versionService *services.IndexVersionService, indexName string, jsonOutput bool) {
+func executeStatus(ctx context.Context, versionService *services.IndexVersionService, snapshotService *services.IndexSnapshotService, indexName string, jsonOutput bool) {
 	indices := getTargetIndices(indexName)
 	allInfo := make(map[string]*services.IndexVersionInfo)
 
@@ -181,12 +257,20 @@ func executeStatus(ctx context.Context, versionService *services.IndexVersionSer
 		allInfo[idx] = info
 	}
 
+	snapshotStatus, err := snapshotService.GetSnapshotStatus(ctx)
+	if err != nil {
+		log.Printf("WARNING: Failed to get snapshot status: %v", err)
+	}
+
 	if jsonOutput {
-		output, err := json.MarshalIndent(allInfo, "", "  ")
-		if err != nil {
-			log.Fatalf("Failed to marshal results to JSON: %v", err)
+		output := make(map[string]interface{}, len(allInfo)+1)
+		for idx, info := range allInfo {
+			output[idx] = info
 		}
-		fmt.Println(string(output))
+		if snapshotStatus != nil {
+			output["snapshots"] = snapshotStatus
+		}
+		printJSON(output)
 		return
 	}
 
@@ -224,6 +308,10 @@ func executeStatus(ctx context.Context, versionService *services.IndexVersionSer
 
 		fmt.Println()
 	}
+
+	if snapshotStatus != nil {
+		printSnapshotStatus(snapshotStatus)
+	}
 }
 
 func executeRebuild(ctx context.Context, rebuildService *services.IndexRebuildService, indexName string, batchSize, keepVersions int, swapAlias, dryRun, jsonOutput bool) {
@@ -285,6 +373,71 @@ func executeRebuild(ctx context.Context, rebuildService *services.IndexRebuildSe
 	}
 }
 
+// executeIncremental reindexes the documents changed since each index's
+// checkpoint into its active version, then applies the configured
+// retention policy to old versions
+func executeIncremental(ctx context.Context, rebuildService *services.IndexRebuildService, indexName string, batchSize int, dryRun, jsonOutput bool) {
+	indices := getTargetIndices(indexName)
+
+	if dryRun {
+		fmt.Println("DRY RUN: Would reindex documents changed since the checkpoint of:")
+		for _, idx := range indices {
+			state, err := rebuildService.GetSyncState(ctx, idx)
+			if err != nil {
+				log.Fatalf("Failed to get sync state for %s: %v", idx, err)
+			}
+			if state == nil {
+				fmt.Printf("  - %s: no checkpoint, every document (batch size: %d)\n", idx, batchSize)
+				continue
+			}
+			fmt.Printf("  - %s: synced through %s by %s on %s (batch size: %d)\n",
+				idx, state.SyncedThrough.Format(time.RFC3339), state.LastMode, state.LastRunAt.Format(time.RFC3339), batchSize)
+		}
+		return
+	}
+
+	config := services.DefaultRebuildConfig()
+	config.BatchSize = batchSize
+	config.Verbose = !jsonOutput
+
+	result := &services.IncrementalAllResult{
+		Results: []services.IncrementalResult{},
+		Success: true,
+	}
+	start := time.Now()
+	for _, idx := range indices {
+		indexResult, err := rebuildService.IncrementalReindex(ctx, idx, config)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", idx, err))
+			result.Success = false
+		}
+		result.Results = append(result.Results, *indexResult)
+	}
+	result.TotalDuration = time.Since(start)
+
+	if jsonOutput {
+		printJSON(result)
+	} else {
+		for _, r := range result.Results {
+			if r.Error != "" {
+				continue
+			}
+			fmt.Printf("  %s: %d indexed, %d deleted in %v", r.BaseIndex, r.DocsIndexed, r.DocsDeleted, r.Duration.Round(time.Millisecond))
+			if len(r.DeletedIndices) > 0 {
+				fmt.Printf(", removed old versions: %s", strings.Join(r.DeletedIndices, ", "))
+			}
+			fmt.Println()
+		}
+	}
+
+	if !result.Success {
+		log.Fatalf("Incremental reindex failed: %s", strings.Join(result.Errors, "; "))
+	}
+	if !jsonOutput {
+		fmt.Println("Incremental reindex completed successfully!")
+	}
+}
+
 func executeSwap(ctx context.Context, versionService *services.IndexVersionService, indexName string, version int, dryRun, jsonOutput bool) {
 	if indexName == "" || indexName == "all" {
 		log.Fatal("Index name is required for swap operation (cannot swap 'all')")
@@ -331,7 +484,7 @@ func executeSwap(ctx context.Context, versionService *services.IndexVersionServi
 	}
 }
 
-func executeRollback(ctx context.Context, versionService *services.IndexVersionService, indexName string, version int, dryRun, jsonOutput bool) {
+func executeRollback(ctx context.Context, versionService *services.IndexVersionService, snapshotService *services.IndexSnapshotService, indexName string, version int, dryRun, jsonOutput bool) {
 	if indexName == "" || indexName == "all" {
 		log.Fatal("Index name is required for rollback operation")
 	}
@@ -365,21 +518,34 @@ func executeRollback(ctx context.Context, versionService *services.IndexVersionS
 		}
 	}
 
-	if !found {
-		log.Fatalf("Version v%d does not exist for index %s", version, idx)
-	}
-
 	if dryRun {
 		currentVersion := 0
 		if info.ActiveVersion != nil {
 			currentVersion = info.ActiveVersion.Version
 		}
-		fmt.Printf("DRY RUN: Would rollback %s from v%d to v%d\n", idx, currentVersion, version)
+		source := "existing index"
+		if !found {
+			source = "latest snapshot"
+		}
+		fmt.Printf("DRY RUN: Would rollback %s from v%d to v%d (from %s)\n", idx, currentVersion, version, source)
 		return
 	}
 
+	// The target version may have been cleaned up; restore it from a snapshot
+	// before retrying the alias swap.
+	restoredFrom := ""
 	if err := versionService.RollbackAlias(ctx, idx, version); err != nil {
-		log.Fatalf("Rollback failed: %v", err)
+		targetName := versionService.GetVersionedIndexName(idx, version)
+		log.Printf("WARNING: Rollback to %s failed (%v), attempting restore from snapshot", targetName, err)
+
+		snapshot, restoreErr := restoreVersionFromSnapshot(ctx, snapshotService, targetName, "", found)
+		if restoreErr != nil {
+			log.Fatalf("Rollback failed: %v (snapshot restore failed: %v)", err, restoreErr)
+		}
+		if err := versionService.RollbackAlias(ctx, idx, version); err != nil {
+			log.Fatalf("Rollback failed after restoring %s from snapshot %s: %v", targetName, snapshot, err)
+		}
+		restoredFrom = snapshot
 	}
 
 	result := map[string]interface{}{
@@ -387,6 +553,9 @@ func executeRollback(ctx context.Context, versionService *services.IndexVersionS
 		"new_version": version,
 		"success":     true,
 	}
+	if restoredFrom != "" {
+		result["restored_from_snapshot"] = restoredFrom
+	}
 
 	if jsonOutput {
 		output, err := json.MarshalIndent(result, "", "  ")
@@ -399,7 +568,7 @@ func executeRollback(ctx context.Context, versionService *services.IndexVersionS
 	}
 }
 
-func executeCleanup(ctx context.Context, versionService *services.IndexVersionService, indexName string, keepVersions int, dryRun, jsonOutput bool) {
+func executeCleanup(ctx context.Context, versionService *services.IndexVersionService, indexName string, policy services.IndexRetentionPolicy, dryRun, jsonOutput bool) {
 	indices := getTargetIndices(indexName)
 	allDeleted := make(map[string][]string)
 
@@ -412,10 +581,7 @@ func executeCleanup(ctx context.Context, versionService *services.IndexVersionSe
 
 		// Calculate which versions would be deleted
 		toDelete := []string{}
-		for i, v := range info.AllVersions {
-			if i < keepVersions || v.IsActive {
-				continue
-			}
+		for _, v := range policy.ExpiredVersions(info.AllVersions, time.Now()) {
 			toDelete = append(toDelete, v.Name)
 		}
 
@@ -428,7 +594,7 @@ func executeCleanup(ctx context.Context, versionService *services.IndexVersionSe
 			continue
 		}
 
-		deleted, err := versionService.DeleteOldVersions(ctx, idx, keepVersions)
+		deleted, err := versionService.ApplyRetentionPolicy(ctx, idx, policy)
 		if err != nil {
 			log.Printf("WARNING: Failed to cleanup %s: %v", idx, err)
 			continue
@@ -453,3 +619,333 @@ func executeCleanup(ctx context.Context, versionService *services.IndexVersionSe
 		}
 	}
 }
+
+// restoreVersionFromSnapshot restores a versioned index from the named snapshot, or the
+// latest snapshot containing it. Existing indices are never overwritten.
+func restoreVersionFromSnapshot(ctx context.Context, snapshotService *services.IndexSnapshotService, indexName, snapshotName string, indexExists bool) (string, error) {
+	if indexExists {
+		return "", fmt.Errorf("index %s already exists; delete it before restoring", indexName)
+	}
+
+	if snapshotName == "" {
+		snapshot, err := snapshotService.FindLatestSnapshotWithIndex(ctx, indexName)
+		if err != nil {
+			return "", fmt.Errorf("no snapshot contains %s: %w", indexName, err)
+		}
+		snapshotName = snapshot.Name
+	}
+
+	if err := snapshotService.RestoreIndex(ctx, snapshotName, indexName); err != nil {
+		return "", err
+	}
+
+	return snapshotName, nil
+}
+
+// takePreRebuildSnapshot snapshots the active versions of the indices about to be rebuilt
+func takePreRebuildSnapshot(ctx context.Context, versionService *services.IndexVersionService, snapshotService *services.IndexSnapshotService, indexName string, jsonOutput bool) {
+	activeIndices := []string{}
+	for _, idx := range getTargetIndices(indexName) {
+		info, err := versionService.GetIndexVersionInfo(ctx, idx)
+		if err != nil {
+			log.Fatalf("Failed to get index info for %s: %v", idx, err)
+		}
+		if info.ActiveVersion != nil {
+			activeIndices = append(activeIndices, info.ActiveVersion.Name)
+		}
+	}
+
+	if len(activeIndices) == 0 {
+		return
+	}
+
+	name := services.SnapshotName("pre-rebuild", indexName, time.Now())
+	snapshot, err := snapshotService.CreateSnapshot(ctx, name, activeIndices)
+	if err != nil {
+		if errors.Is(err, services.ErrSnapshotRepositoryMissing) {
+			log.Printf("WARNING: Snapshot repository %s is not registered, skipping pre-rebuild snapshot (run snapshot-repo)", snapshotService.Config().Repository)
+			return
+		}
+		log.Fatalf("Pre-rebuild snapshot failed (use -no-snapshot to skip): %v", err)
+	}
+
+	if !jsonOutput {
+		fmt.Printf("Created pre-rebuild snapshot %s (%s)\n", snapshot.Name, strings.Join(snapshot.Indices, ", "))
+	}
+}
+
+func executeSnapshotRepo(ctx context.Context, snapshotService *services.IndexSnapshotService, dryRun, jsonOutput bool) {
+	config := snapshotService.Config()
+
+	if dryRun {
+		fmt.Printf("DRY RUN: Would register %s snapshot repository %s at %s\n", config.RepositoryType, config.Repository, config.Location)
+		return
+	}
+
+	if err := snapshotService.RegisterRepository(ctx); err != nil {
+		log.Fatalf("Snapshot repository registration failed: %v", err)
+	}
+
+	if jsonOutput {
+		printJSON(map[string]interface{}{
+			"repository": config.Repository,
+			"type":       config.RepositoryType,
+			"location":   config.Location,
+			"success":    true,
+		})
+	} else {
+		fmt.Printf("Registered %s snapshot repository %s\n", config.RepositoryType, config.Repository)
+	}
+}
+
+func executeSnapshot(ctx context.Context, snapshotService *services.IndexSnapshotService, indexName, name string, dryRun, jsonOutput bool) {
+	patterns := []string{}
+	for _, idx := range getTargetIndices(indexName) {
+		patterns = append(patterns, idx+"_v*")
+	}
+
+	if name == "" {
+		name = services.SnapshotName("manual", indexName, time.Now())
+	}
+
+	if dryRun {
+		fmt.Printf("DRY RUN: Would create snapshot %s of %s\n", name, strings.Join(patterns, ", "))
+		return
+	}
+
+	snapshot, err := snapshotService.CreateSnapshot(ctx, name, patterns)
+	if err != nil {
+		log.Fatalf("Snapshot failed: %v", err)
+	}
+
+	if jsonOutput {
+		printJSON(snapshot)
+	} else {
+		fmt.Printf("Created snapshot %s (%s)\n", snapshot.Name, strings.Join(snapshot.Indices, ", "))
+	}
+}
+
+func executeListSnapshots(ctx context.Context, snapshotService *services.IndexSnapshotService, jsonOutput bool) {
+	snapshots, err := snapshotService.ListSnapshots(ctx)
+	if err != nil {
+		log.Fatalf("Failed to list snapshots: %v", err)
+	}
+
+	if jsonOutput {
+		printJSON(snapshots)
+		return
+	}
+
+	fmt.Printf("=== Snapshots in %s ===\n", snapshotService.Config().Repository)
+	fmt.Println()
+	for _, snapshot := range snapshots {
+		fmt.Printf("  - %s [%s] %s: %s\n", snapshot.Name, snapshot.State, snapshot.StartTime.Format(time.RFC3339), strings.Join(snapshot.Indices, ", "))
+	}
+	if len(snapshots) == 0 {
+		fmt.Println("  (none)")
+	}
+}
+
+func executeRestore(ctx context.Context, versionService *services.IndexVersionService, snapshotService *services.IndexSnapshotService, indexName string, version int, snapshotName string, dryRun, jsonOutput bool) {
+	if indexName == "" || indexName == "all" || version == 0 {
+		log.Fatal("Index name and version are required for restore operation")
+	}
+
+	idx := getTargetIndices(indexName)[0]
+	versionedName := versionService.GetVersionedIndexName(idx, version)
+
+	info, err := versionService.GetIndexVersionInfo(ctx, idx)
+	if err != nil {
+		log.Fatalf("Failed to get index info: %v", err)
+	}
+	exists := false
+	for _, v := range info.AllVersions {
+		if v.Version == version {
+			exists = true
+			break
+		}
+	}
+
+	if dryRun {
+		source := snapshotName
+		if source == "" {
+			source = "latest snapshot containing it"
+		}
+		fmt.Printf("DRY RUN: Would restore %s from %s\n", versionedName, source)
+		return
+	}
+
+	restoredFrom, err := restoreVersionFromSnapshot(ctx, snapshotService, versionedName, snapshotName, exists)
+	if err != nil {
+		log.Fatalf("Restore failed: %v", err)
+	}
+
+	if jsonOutput {
+		printJSON(map[string]interface{}{
+			"index":    versionedName,
+			"snapshot": restoredFrom,
+			"success":  true,
+		})
+	} else {
+		fmt.Printf("Restored %s from snapshot %s\n", versionedName, restoredFrom)
+	}
+}
+
+func executeSnapshotPolicy(ctx context.Context, snapshotService *services.IndexSnapshotService, dryRun, jsonOutput bool) {
+	config := snapshotService.Config()
+
+	if dryRun {
+		fmt.Printf("DRY RUN: Would apply snapshot policy %s (schedule: %s, keep: %d, max age: %s)\n",
+			snapshotService.PolicyName(), config.Schedule, config.RetentionCount, config.RetentionAge)
+		return
+	}
+
+	if err := snapshotService.ApplySnapshotPolicy(ctx); err != nil {
+		log.Fatalf("Snapshot policy failed: %v", err)
+	}
+
+	if jsonOutput {
+		printJSON(map[string]interface{}{
+			"policy":          snapshotService.PolicyName(),
+			"schedule":        config.Schedule,
+			"retention_count": config.RetentionCount,
+			"retention_age":   config.RetentionAge,
+			"success":         true,
+		})
+	} else {
+		fmt.Printf("Applied snapshot policy %s (schedule: %s UTC)\n", snapshotService.PolicyName(), config.Schedule)
+	}
+}
+
+// getSynonymStatuses returns the synonym status of the target indices
+func getSynonymStatuses(ctx context.Context, rebuildService *services.IndexRebuildService, indexName string) []*services.SynonymStatus {
+	statuses := []*services.SynonymStatus{}
+	for _, idx := range getTargetIndices(indexName) {
+		status, err := rebuildService.GetSynonymStatus(ctx, idx)
+		if err != nil {
+			log.Fatalf("Failed to get synonym status for %s: %v", idx, err)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+func executeSynonymStatus(ctx context.Context, rebuildService *services.IndexRebuildService, indexName string, jsonOutput bool) {
+	statuses := getSynonymStatuses(ctx, rebuildService, indexName)
+
+	if jsonOutput {
+		printJSON(statuses)
+		return
+	}
+
+	fmt.Println("=== Search Synonyms ===")
+	fmt.Println()
+	for _, status := range statuses {
+		indexVersion := status.IndexVersion
+		if indexVersion == "" {
+			indexVersion = "none"
+		}
+		state := "up to date"
+		if status.Outdated {
+			state = "OUTDATED (run sync-synonyms)"
+		}
+		fmt.Printf("  - %s: built with %s, current %s (%d rules): %s\n",
+			status.BaseIndex, indexVersion, status.CurrentVersion, status.CurrentRules, state)
+	}
+}
+
+// executeSyncSynonyms rebuilds, with a zero-downtime alias swap, each index
+// whose analyzers were built with different synonyms than the current ones
+func executeSyncSynonyms(ctx context.Context, rebuildService *services.IndexRebuildService, versionService *services.IndexVersionService, snapshotService *services.IndexSnapshotService, indexName string, batchSize, keepVersions int, noSnapshot, dryRun, jsonOutput bool) {
+	outdated := []string{}
+	for _, status := range getSynonymStatuses(ctx, rebuildService, indexName) {
+		if status.Outdated {
+			outdated = append(outdated, status.BaseIndex)
+		}
+	}
+
+	if len(outdated) == 0 {
+		if jsonOutput {
+			printJSON(map[string]interface{}{"rebuilt": outdated, "success": true})
+		} else {
+			fmt.Println("All indices use the current search synonyms")
+		}
+		return
+	}
+
+	if dryRun {
+		fmt.Printf("DRY RUN: Would rebuild %s to apply the current search synonyms\n", strings.Join(outdated, ", "))
+		return
+	}
+
+	results := []*services.RebuildResult{}
+	for _, idx := range outdated {
+		if !noSnapshot {
+			takePreRebuildSnapshot(ctx, versionService, snapshotService, idx, jsonOutput)
+		}
+
+		config := &services.RebuildConfig{
+			BatchSize:       batchSize,
+			KeepOldVersions: keepVersions,
+			SwapAfterBuild:  true,
+			Verbose:         !jsonOutput,
+		}
+
+		var result *services.RebuildResult
+		var err error
+		switch idx {
+		case services.ClipsIndex:
+			result, err = rebuildService.RebuildClipsIndex(ctx, config)
+		case services.UsersIndex:
+			result, err = rebuildService.RebuildUsersIndex(ctx, config)
+		case services.TagsIndex:
+			result, err = rebuildService.RebuildTagsIndex(ctx, config)
+		case services.GamesIndex:
+			result, err = rebuildService.RebuildGamesIndex(ctx, config)
+		}
+		if err != nil {
+			log.Fatalf("Rebuild of %s failed: %v", idx, err)
+		}
+		results = append(results, result)
+	}
+
+	if jsonOutput {
+		printJSON(map[string]interface{}{"rebuilt": outdated, "results": results, "success": true})
+	} else {
+		fmt.Printf("Rebuilt %s with the current search synonyms\n", strings.Join(outdated, ", "))
+	}
+}
+
+func printSnapshotStatus(status *services.SnapshotStatus) {
+	fmt.Println("=== Snapshots ===")
+	fmt.Println()
+	fmt.Printf("Repository: %s\n", status.Repository)
+	if !status.Registered {
+		fmt.Println("  Registered: no (run snapshot-repo)")
+		fmt.Println()
+		return
+	}
+
+	fmt.Printf("  Total Snapshots: %d\n", status.TotalSnapshots)
+	if status.LatestSnapshot != nil {
+		fmt.Printf("  Latest Snapshot: %s [%s] %s\n", status.LatestSnapshot.Name, status.LatestSnapshot.State, status.LatestSnapshot.StartTime.Format(time.RFC3339))
+	}
+
+	switch {
+	case !status.PolicyExists:
+		fmt.Printf("  Scheduled Policy: none (run snapshot-policy)\n")
+	case status.PolicyEnabled:
+		fmt.Printf("  Scheduled Policy: %s (enabled)\n", status.PolicyName)
+	default:
+		fmt.Printf("  Scheduled Policy: %s (disabled)\n", status.PolicyName)
+	}
+	fmt.Println()
+}
+
+func printJSON(v interface{}) {
+	output, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to marshal results to JSON: %v", err)
+	}
+	fmt.Println(string(output))
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/searchlive"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// train-ltr trains the learning-to-rank model hybrid search re-ranks with.
+// Each clicked search by a signed-in user becomes a training search: its
+// query's top fused candidates, as live search ranks them now, labeled by
+// whether the user clicked them. CTR features only count clicks from before
+// each search, and follow affinity uses the user's current profile.
+func main() {
+	days := flag.Int("days", 30, "Number of days of searches to train on")
+	minUsers := flag.Int("min-users", 3, "Minimum number of distinct signed-in users who searched a query")
+	queries := flag.Int("queries", 500, "Maximum number of queries, most searched first")
+	defaults := services.DefaultLTRTrainingOptions()
+	epochs := flag.Int("epochs", defaults.Epochs, "Gradient descent passes over the training searches")
+	learningRate := flag.Float64("learning-rate", defaults.LearningRate, "Gradient descent step size")
+	l2 := flag.Float64("l2", defaults.L2, "L2 regularization of the feature weights")
+	holdout := flag.Float64("holdout", defaults.HoldoutShare, "Share (0-1) of searches held out to compare the model with the fused ranking")
+	outputPath := flag.String("output", "ltr-model.json", "Path to write the model to")
+	flag.Parse()
+
+	if *days <= 0 || *minUsers <= 0 || *queries <= 0 || *epochs <= 0 {
+		log.Fatal("-days, -min-users, -queries and -epochs must be positive")
+	}
+	if *holdout < 0 || *holdout >= 1 {
+		log.Fatal("-holdout must be at least 0 and below 1")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	stack, err := searchlive.Connect(ctx, cfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to live search: %v", err)
+	}
+	defer stack.Close()
+
+	analytics := repository.NewSearchAnalyticsRepository(stack.Pool())
+	personalizer := services.NewSearchPersonalizationService(
+		repository.NewSearchPersonalizationRepository(stack.Pool()),
+		cfg.HybridSearch.DownvotedGameMinVotes,
+		cfg.HybridSearch.DownvotedGameShare,
+		cfg.HybridSearch.LanguageShare,
+	)
+	ltr := services.NewSearchLTRService(analytics, nil, 0)
+
+	end := time.Now()
+	start := end.AddDate(0, 0, -*days)
+	log.Printf("Mining clicked searches from %s to %s...", start.Format(time.DateOnly), end.Format(time.DateOnly))
+	searches, err := analytics.ListClickedSearches(ctx, start, end, *minUsers, *queries)
+	if err != nil {
+		log.Fatalf("Failed to mine clicked searches: %v", err)
+	}
+
+	candidates := map[string][]models.Clip{}
+	profiles := map[uuid.UUID]*models.SearchPersonalizationProfile{}
+	var examples []services.LTRExample
+	used := 0
+	for _, search := range searches {
+		clips, ok := candidates[search.Query]
+		if !ok {
+			resp, err := stack.Search.Search(ctx, &models.SearchRequest{
+				Query: search.Query,
+				Type:  "clips",
+				Sort:  "relevance",
+				Page:  1,
+				Limit: services.LTRCandidates,
+			})
+			if err != nil {
+				log.Fatalf("Failed to search %q: %v", search.Query, err)
+			}
+			clips = resp.Results.Clips
+			candidates[search.Query] = clips
+		}
+
+		clicked := map[uuid.UUID]bool{}
+		for _, id := range search.ClipIDs {
+			clicked[id] = true
+		}
+		searchExamples := make([]services.LTRExample, len(clips))
+		hasClick := false
+		for i, clip := range clips {
+			searchExamples[i] = services.LTRExample{SearchID: search.ID.String(), Clicked: clicked[clip.ID]}
+			hasClick = hasClick || clicked[clip.ID]
+		}
+		// Searches whose clicks fell outside today's candidates can't be labeled
+		if !hasClick {
+			continue
+		}
+
+		profile, ok := profiles[search.UserID]
+		if !ok {
+			profile, err = personalizer.ProfileFor(ctx, search.UserID)
+			if err != nil {
+				log.Fatalf("Failed to load the profile of a searching user: %v", err)
+			}
+			profiles[search.UserID] = profile
+		}
+
+		features, err := ltr.Features(ctx, search.Query, clips, profile, search.CreatedAt)
+		if err != nil {
+			log.Fatalf("Failed to compute features for %q: %v", search.Query, err)
+		}
+		for i := range searchExamples {
+			searchExamples[i].Features = features[i]
+		}
+		examples = append(examples, searchExamples...)
+		used++
+	}
+	log.Printf("Labeled %d of %d clicked searches across %d queries", used, len(searches), len(candidates))
+
+	model, err := services.TrainLTRModel(examples, services.LTRTrainingOptions{
+		Epochs:       *epochs,
+		LearningRate: *learningRate,
+		L2:           *l2,
+		HoldoutShare: *holdout,
+	})
+	if err != nil {
+		log.Fatalf("Failed to train model: %v", err)
+	}
+	model.TrainedAt = end.UTC()
+	model.Version = model.TrainedAt.Format("20060102-150405")
+
+	data, err := json.MarshalIndent(model, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to encode model: %v", err)
+	}
+	if err := os.WriteFile(*outputPath, data, 0o644); err != nil {
+		log.Fatalf("Failed to write model: %v", err)
+	}
+
+	report := model.Report
+	log.Printf("Trained model %s on %d searches (%d candidates, %d clicked), log loss %.4f",
+		model.Version, report.Searches, report.Examples, report.Positives, report.LogLoss)
+	for i, name := range model.Features {
+		log.Printf("  %-16s weight %+.4f", name, model.Weights[i])
+	}
+	if report.HoldoutSearches > 0 {
+		log.Printf("Holdout MRR over %d searches: fused %.4f, model %.4f",
+			report.HoldoutSearches, report.BaselineMRR, report.ModelMRR)
+	}
+	log.Printf("Model written to %s", *outputPath)
+}
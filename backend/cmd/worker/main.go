@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/scheduler"
+	"github.com/subculture-collective/clipper/internal/services"
+	"github.com/subculture-collective/clipper/pkg/database"
+	opensearchpkg "github.com/subculture-collective/clipper/pkg/opensearch"
+	redispkg "github.com/subculture-collective/clipper/pkg/redis"
+	"github.com/subculture-collective/clipper/pkg/twitch"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+// Job kinds run by the worker. Each is also the name of its periodic schedule.
+const (
+	jobKindClipSync     = "clip_sync"
+	jobKindWebhookRetry = "webhook_retry"
+	jobKindExports      = "exports"
+	jobKindEmbeddings   = "embeddings"
+	jobKindSearchSync   = "search_incremental"
+)
+
+// runner is a scheduler that can run a single pass on demand
+type runner interface {
+	RunOnce(ctx context.Context) error
+}
+
+func main() {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	logLevel := utils.LogLevelInfo
+	if cfg.Server.GinMode == "debug" {
+		logLevel = utils.LogLevelDebug
+	}
+	utils.InitLogger(logLevel)
+
+	if !cfg.Jobs.QueueEnabled {
+		log.Println("WARNING: JOB_QUEUE_ENABLED is false; the API is still running these jobs in-process")
+	}
+
+	// Initialize database connection
+	db, err := database.NewDB(&cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	// Initialize Redis client
+	redisClient, err := redispkg.NewClient(&cfg.Redis)
+	if err != nil {
+		log.Fatalf("Failed to connect to Redis: %v", err)
+	}
+	defer redisClient.Close()
+
+	pool := db.Pool
+	userRepo := repository.NewUserRepository(pool)
+	notificationRepo := repository.NewNotificationRepository(pool)
+	exportRepo := repository.NewExportRepository(pool)
+
+	emailService := services.NewEmailService(&services.EmailConfig{
+		SendGridAPIKey:   cfg.Email.SendGridAPIKey,
+		FromEmail:        cfg.Email.FromEmail,
+		FromName:         cfg.Email.FromName,
+		BaseURL:          cfg.Server.BaseURL,
+		Enabled:          cfg.Email.Enabled,
+		SandboxMode:      cfg.Email.SandboxMode,
+		MaxEmailsPerHour: cfg.Email.MaxEmailsPerHour,
+	}, repository.NewEmailNotificationRepository(pool), notificationRepo)
+	if cfg.Email.LinkTracking {
+		emailService.SetLinkService(services.NewEmailLinkService(
+			repository.NewEmailTrackedLinkRepository(pool),
+			repository.NewEmailLogRepository(pool),
+			cfg.Server.BaseURL,
+		))
+	}
+	notificationService := services.NewNotificationService(
+		notificationRepo,
+		userRepo,
+		repository.NewCommentRepository(pool),
+		repository.NewClipRepository(pool),
+		repository.NewFavoriteRepository(pool),
+		emailService,
+	)
+
+	queue := services.NewJobQueueService(
+		repository.NewJobRepository(pool),
+		workerID(),
+		cfg.Jobs.QueueWorkers,
+		time.Duration(cfg.Jobs.QueuePollIntervalSeconds)*time.Second,
+		time.Duration(cfg.Jobs.QueueStaleAfterMinutes)*time.Minute,
+		time.Duration(cfg.Jobs.QueueRetentionDays)*24*time.Hour,
+	)
+
+	// Webhook retries
+	subscriptionRepo := repository.NewSubscriptionRepository(pool)
+	webhookRepo := repository.NewWebhookRepository(pool)
+	auditLogService := services.NewAuditLogService(repository.NewAuditLogRepository(pool))
+	dunningService := services.NewDunningService(repository.NewDunningRepository(pool), subscriptionRepo, userRepo, emailService, auditLogService)
+	subscriptionService := services.NewSubscriptionService(subscriptionRepo, userRepo, webhookRepo, cfg, auditLogService, dunningService, emailService)
+	webhookRetry := scheduler.NewWebhookRetryScheduler(
+		services.NewWebhookRetryService(webhookRepo, subscriptionService),
+		cfg.Jobs.WebhookRetryIntervalMinutes,
+		cfg.Jobs.WebhookRetryBatchSize,
+	)
+	register(queue, jobKindWebhookRetry, webhookRetry, time.Duration(cfg.Jobs.WebhookRetryIntervalMinutes)*time.Minute)
+
+	// Exports (every 2 minutes, batch size 10)
+	exportDir := cfg.Server.ExportDir
+	if exportDir == "" {
+		exportDir = "./exports"
+	}
+	exportService := services.NewExportService(exportRepo, userRepo, emailService, notificationService, exportDir, cfg.Server.BaseURL, 7)
+	register(queue, jobKindExports, scheduler.NewExportScheduler(exportService, exportRepo, 2, 10), 2*time.Minute)
+
+	// Clip sync (every 15 minutes) needs Twitch credentials
+	twitchClient, err := twitch.NewClient(&cfg.Twitch, redisClient)
+	if err != nil {
+		log.Printf("WARNING: Failed to initialize Twitch client, clip sync jobs will not run: %v", err)
+	} else {
+		clipSyncService := services.NewClipSyncService(
+			twitchClient,
+			repository.NewClipRepository(pool),
+			repository.NewTagRepository(pool),
+			userRepo,
+			redisClient,
+		)
+		clipSyncService.SetTitleNormalizer(services.NewClipTitleService(repository.NewClipTitleRepository(pool), userRepo, cfg.FeatureFlags.TitleNormalization))
+		clipSyncService.SetIngestionRouter(services.NewClipIngestionRuleService(repository.NewClipIngestionRuleRepository(pool)))
+		register(queue, jobKindClipSync, scheduler.NewClipSyncScheduler(clipSyncService, 15), 15*time.Minute)
+	}
+
+	// Embeddings need an embedding API
+	var embeddingService *services.EmbeddingService
+	embeddingEnabled := cfg.Embedding.Enabled && cfg.Embedding.Configured()
+	embeddingColumnRepo := repository.NewClipEmbeddingColumnRepository(pool)
+	if embeddingEnabled {
+		// Vectors of another model must not be written into the column
+		if err := embeddingColumnRepo.CheckModel(context.Background(), cfg.Embedding.Column, cfg.Embedding.Model, cfg.Embedding.Dimensions); err != nil {
+			log.Printf("ERROR: Embedding column %s does not match the embedding model, embedding jobs will not run: %v", cfg.Embedding.Column, err)
+			embeddingEnabled = false
+		}
+	}
+	if embeddingEnabled {
+		embeddingService = services.NewEmbeddingService(services.ConfiguredEmbeddingConfig(&cfg.Embedding, redisClient.GetClient()))
+		defer embeddingService.Close()
+		embedding := scheduler.NewEmbeddingScheduler(db, embeddingService, cfg.Embedding.SchedulerIntervalMinutes, cfg.Embedding.Model)
+		embedding.SetColumn(cfg.Embedding.Column)
+		// New clips are also embedded into the column being migrated to
+		if next, ok := cfg.Embedding.Next(); ok {
+			if err := embeddingColumnRepo.CheckModel(context.Background(), next.Column, next.Model, next.Dimensions); err != nil {
+				log.Printf("WARNING: Not migrating to embedding column %s: %v", next.Column, err)
+			} else {
+				nextEmbeddingService := services.NewEmbeddingService(services.ConfiguredEmbeddingConfig(&next, redisClient.GetClient()))
+				defer nextEmbeddingService.Close()
+				embedding.SetDualWrite(nextEmbeddingService, next.Column, next.Model)
+			}
+		}
+		if cfg.HybridSearch.OpenSearchKNN {
+			// New embeddings go to OpenSearch too so hybrid search can query them there
+			osClient, err := opensearchpkg.NewClient(&opensearchpkg.Config{
+				URL:                cfg.OpenSearch.URL,
+				Username:           cfg.OpenSearch.Username,
+				Password:           cfg.OpenSearch.Password,
+				InsecureSkipVerify: cfg.OpenSearch.InsecureSkipVerify,
+			})
+			if err != nil {
+				log.Printf("WARNING: Failed to initialize OpenSearch client, embeddings will not be indexed: %v", err)
+			} else {
+				indexer := services.NewSearchIndexerService(osClient)
+				indexer.EnableKNN(cfg.HybridSearch.RRFRankConstant)
+				embedding.SetSearchIndexer(indexer)
+			}
+		}
+		register(queue, jobKindEmbeddings, embedding, time.Duration(cfg.Embedding.SchedulerIntervalMinutes)*time.Minute)
+	}
+
+	// Incremental search reindexing keeps indices current between rebuilds
+	if cfg.Jobs.SearchIncrementalIntervalMinutes > 0 {
+		osClient, err := opensearchpkg.NewClient(&opensearchpkg.Config{
+			URL:                cfg.OpenSearch.URL,
+			Username:           cfg.OpenSearch.Username,
+			Password:           cfg.OpenSearch.Password,
+			InsecureSkipVerify: cfg.OpenSearch.InsecureSkipVerify,
+		})
+		if err != nil {
+			log.Printf("WARNING: Failed to initialize OpenSearch client, incremental search reindexing will not run: %v", err)
+		} else {
+			rebuildService := services.NewIndexRebuildService(db, osClient)
+			if cfg.HybridSearch.OpenSearchKNN {
+				rebuildService.EnableKNN(cfg.HybridSearch.RRFRankConstant)
+			}
+			rebuildService.SetRetentionPolicy(services.IndexRetentionPolicy{
+				KeepVersions: cfg.OpenSearch.IndexKeepVersions,
+				MinAge:       time.Duration(cfg.OpenSearch.IndexMinAgeHours) * time.Hour,
+			})
+			register(queue, jobKindSearchSync,
+				scheduler.NewSearchIncrementalScheduler(rebuildService, cfg.Jobs.SearchIncrementalIntervalMinutes),
+				time.Duration(cfg.Jobs.SearchIncrementalIntervalMinutes)*time.Minute)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		queue.Start(ctx)
+		close(done)
+	}()
+
+	// Wait for interrupt signal, then let running jobs finish
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+	log.Println("Shutting down worker...")
+	cancel()
+
+	select {
+	case <-done:
+		log.Println("Worker exited")
+	case <-time.After(time.Duration(cfg.Jobs.ShutdownDrainSeconds) * time.Second):
+		// Jobs still running are rescued by another worker once they go stale
+		log.Println("Worker forced to shutdown with jobs still running")
+	}
+}
+
+// register runs a scheduler's single pass as a periodic job
+func register(queue *services.JobQueueService, kind string, r runner, interval time.Duration) {
+	queue.RegisterHandler(kind, func(ctx context.Context, job *models.Job) error {
+		return r.RunOnce(ctx)
+	})
+	queue.RegisterPeriodic(kind, kind, interval)
+}
+
+// workerID identifies this process in the locked_by column of running jobs
+func workerID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "worker"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
@@ -16,12 +16,14 @@ type Config struct {
 	Redis           RedisConfig
 	JWT             JWTConfig
 	Twitch          TwitchConfig
+	OAuth           OAuthConfig
 	CORS            CORSConfig
 	WebSocket       WebSocketConfig
 	OpenSearch      OpenSearchConfig
 	Stripe          StripeConfig
 	Sentry          SentryConfig
 	Email           EmailConfig
+	Push            PushConfig
 	Embedding       EmbeddingConfig
 	FeatureFlags    FeatureFlagsConfig
 	Karma           KarmaConfig
@@ -37,16 +39,26 @@ type Config struct {
 	Toxicity        ToxicityConfig
 	NSFW            NSFWConfig
 	Telemetry       TelemetryConfig
+	Upload          UploadConfig
+	Markdown        MarkdownConfig
+	HealthHistory   HealthHistoryConfig
+	Maintenance     MaintenanceConfig
+	Appeals         AppealsConfig
+	Claims          ClaimsConfig
+	DMCA            DMCAConfig
+	BanEvasion      BanEvasionConfig
+	KarmaSafeguards KarmaSafeguardsConfig
 }
 
 // ServerConfig holds server-specific configuration
 type ServerConfig struct {
-	Port        string
-	GinMode     string
-	BaseURL     string
-	Environment string
-	ExportDir   string
-	DocsPath    string
+	Port                   string
+	GinMode                string
+	BaseURL                string
+	Environment            string
+	ExportDir              string
+	DocsPath               string
+	ShutdownTimeoutSeconds int // How long in-flight requests get to finish on shutdown
 }
 
 // DatabaseConfig holds database connection configuration
@@ -65,12 +77,32 @@ type RedisConfig struct {
 	Port     string
 	Password string
 	DB       int
+
+	// Mode selects the deployment: "standalone" (default), "sentinel" or "cluster"
+	Mode string
+	// Addrs lists sentinel addresses in sentinel mode and seed nodes in
+	// cluster mode; Host and Port are used when empty
+	Addrs            []string
+	MasterName       string // Sentinel master set name
+	SentinelPassword string // Password for the sentinels, if different from Password
+
+	// OperationTimeoutMs bounds each command; a slow Redis fails the command
+	// so callers fall back instead of blocking requests (default: 1000)
+	OperationTimeoutMs int
+	// DegradedRetrySeconds is how long commands fail fast after Redis became
+	// unavailable before it is tried again (default: 5)
+	DegradedRetrySeconds int
 }
 
 // JWTConfig holds JWT authentication configuration
 type JWTConfig struct {
 	PrivateKey string
 	PublicKey  string
+	// KeyRotationEnabled stores signing keys in the database and rotates them on a schedule
+	KeyRotationEnabled      bool
+	KeyEncryptionKey        string // 32-byte key for AES-256 encryption of stored signing keys
+	KeyRotationIntervalDays int
+	KeySyncIntervalMinutes  int
 }
 
 // TwitchConfig holds Twitch API configuration
@@ -78,6 +110,40 @@ type TwitchConfig struct {
 	ClientID     string
 	ClientSecret string
 	RedirectURI  string
+	// EventSub webhooks replace live status polling when both the secret
+	// (10-100 characters) and the public callback URL are set
+	EventSubSecret              string
+	EventSubCallbackURL         string
+	EventSubSyncIntervalMinutes int // How often missing subscriptions are requested
+	LiveStatusReconcileMinutes  int // Polling fallback for missed events while EventSub is on
+	ScheduleSyncIntervalMinutes int // How often followed broadcasters' schedules are pulled
+	// ChatBotUserID is the Twitch account chat is read through for clip hype
+	// scores, over EventSub; chat isn't recorded when empty
+	ChatBotUserID string
+}
+
+// EventSubEnabled reports whether Twitch EventSub webhooks are configured
+func (c TwitchConfig) EventSubEnabled() bool {
+	return c.EventSubSecret != "" && c.EventSubCallbackURL != ""
+}
+
+// OAuthConfig holds configuration for the additional (non-Twitch) login providers.
+// A provider is enabled when its client ID and secret are both set.
+type OAuthConfig struct {
+	Google  OAuthProviderConfig
+	Discord OAuthProviderConfig
+}
+
+// OAuthProviderConfig holds OAuth client credentials for a single provider
+type OAuthProviderConfig struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURI  string
+}
+
+// Enabled reports whether the provider has credentials configured
+func (c OAuthProviderConfig) Enabled() bool {
+	return c.ClientID != "" && c.ClientSecret != ""
 }
 
 // CORSConfig holds CORS configuration
@@ -96,6 +162,18 @@ type OpenSearchConfig struct {
 	Username           string
 	Password           string
 	InsecureSkipVerify bool
+	// Snapshot repository and scheduled snapshot policy for the search indices
+	SnapshotRepository     string
+	SnapshotRepositoryType string // fs or s3
+	SnapshotLocation       string // Filesystem path (fs) or bucket name (s3)
+	SnapshotBasePath       string
+	SnapshotSchedule       string // Cron expression (UTC)
+	SnapshotRetentionCount int
+	SnapshotRetentionAge   string
+	// Old index versions kept after incremental reindexing: the newest
+	// IndexKeepVersions always, older ones until IndexMinAgeHours old
+	IndexKeepVersions int
+	IndexMinAgeHours  int
 }
 
 // StripeConfig holds Stripe payment configuration
@@ -110,6 +188,7 @@ type StripeConfig struct {
 	CancelURL            string
 	TaxEnabled           bool // Enable automatic tax calculation via Stripe Tax
 	InvoicePDFEnabled    bool // Enable sending invoice PDFs via email
+	BundleCreatorShare   int  // Creator's percentage of playlist bundle sales; the platform keeps the rest
 }
 
 // SentryConfig holds Sentry error tracking configuration
@@ -130,16 +209,102 @@ type EmailConfig struct {
 	Enabled                  bool
 	SandboxMode              bool // Enable sandbox mode for testing (logs emails without sending)
 	MaxEmailsPerHour         int
+	LinkTracking             bool // Rewrite links through first-party /r/:token redirects instead of SendGrid click tracking
+}
+
+// PushConfig holds push notification provider configuration. Delivery is
+// gated by FeatureFlags.PushNotifications; providers without credentials are skipped.
+type PushConfig struct {
+	FCMProjectID       string // Firebase project ID
+	FCMCredentialsJSON string // Service account JSON used to authorize FCM HTTP v1 requests
+	APNsKeyID          string // Key ID of the APNs auth key
+	APNsTeamID         string // Apple developer team ID
+	APNsPrivateKey     string // APNs auth key (.p8) in PEM format
+	APNsBundleID       string // App bundle ID, sent as the apns-topic
+	APNsProduction     bool   // Use the production APNs endpoint instead of the sandbox
+	MaxRetries         int    // Retries for transient provider errors (default: 3)
+	Workers            int    // Concurrent delivery workers (default: 4)
 }
 
 // EmbeddingConfig holds embedding service configuration
 type EmbeddingConfig struct {
+	Provider                 string // openai, tei or cohere
 	OpenAIAPIKey             string
+	CohereAPIKey             string
+	APIKey                   string // Overrides the provider's key, e.g. for a self-hosted endpoint behind auth
 	APIBaseURL               string
 	Model                    string
 	RequestsPerMinute        int
 	SchedulerIntervalMinutes int
 	Enabled                  bool
+	BatchMaxInputs           int
+	BatchMaxTokens           int
+	MonthlyBudgetUSD         float64 // 0 disables the hard stop
+	BudgetAlertThreshold     float64 // Share of the budget that triggers a warning
+	PricePerMillionTokens    float64 // Overrides the model's list price when set
+	Column                   string  // clips column search and recommendations read (default: embedding)
+	Dimensions               int     // Vector size of Model, which must match Column (default: 768)
+
+	// A column being migrated to with cmd/migrate-embeddings. While set, the
+	// embedding scheduler also fills it and hybrid search compares it with
+	// Column on a sample of queries.
+	NextColumn         string
+	NextProvider       string
+	NextModel          string
+	NextDimensions     int
+	NextAPIBaseURL     string
+	NextAPIKey         string
+	DualReadSampleRate float64 // Share of searches also ranked with NextColumn (0 disables)
+}
+
+// defaultEmbeddingModels are the models used per provider when
+// EMBEDDING_MODEL is not set. All produce 768-dimensional vectors.
+var defaultEmbeddingModels = map[string]string{
+	"openai": "text-embedding-3-small",
+	"tei":    "BAAI/bge-base-en-v1.5",
+	"cohere": "embed-multilingual-v2.0",
+}
+
+// ProviderAPIKey returns the API key sent to the configured provider
+func (c EmbeddingConfig) ProviderAPIKey() string {
+	if c.APIKey != "" {
+		return c.APIKey
+	}
+	switch c.Provider {
+	case "cohere":
+		return c.CohereAPIKey
+	case "tei":
+		return ""
+	default:
+		return c.OpenAIAPIKey
+	}
+}
+
+// Configured reports whether the provider can be reached: a self-hosted TEI
+// endpoint needs its URL, hosted providers an API key or a custom URL
+func (c EmbeddingConfig) Configured() bool {
+	if c.Provider == "tei" {
+		return c.APIBaseURL != ""
+	}
+	return c.ProviderAPIKey() != "" || c.APIBaseURL != ""
+}
+
+// Next returns the configuration of the column being migrated to, sharing
+// the rate limits and budget of the current one. It reports false when no
+// migration is in progress.
+func (c EmbeddingConfig) Next() (EmbeddingConfig, bool) {
+	if c.NextColumn == "" {
+		return EmbeddingConfig{}, false
+	}
+	next := c
+	next.Column = c.NextColumn
+	next.Provider = c.NextProvider
+	next.Model = c.NextModel
+	next.Dimensions = c.NextDimensions
+	next.APIBaseURL = c.NextAPIBaseURL
+	next.APIKey = c.NextAPIKey
+	next.NextColumn = ""
+	return next, true
 }
 
 // FeatureFlagsConfig holds feature flag configuration
@@ -151,6 +316,7 @@ type FeatureFlagsConfig struct {
 	Analytics            bool
 	Moderation           bool
 	DiscoveryLists       bool
+	TitleNormalization   bool // Give imported clips a cleaned-up display title for search and SEO
 }
 
 // KarmaConfig holds karma system configuration
@@ -162,9 +328,46 @@ type KarmaConfig struct {
 
 // JobsConfig holds background job interval configuration
 type JobsConfig struct {
-	HotClipsRefreshIntervalMinutes int
-	WebhookRetryIntervalMinutes    int
-	WebhookRetryBatchSize          int
+	HotClipsRefreshIntervalMinutes     int
+	WebhookRetryIntervalMinutes        int
+	WebhookRetryBatchSize              int
+	BroadcasterApprovalIntervalMinutes int // How often held clips past their deadline are auto-approved
+	BroadcasterAutoApproveDays         int // Default days a broadcaster has to review a held clip
+	NotificationDigestIntervalMinutes  int // How often due daily and weekly email digests are sent
+	ReengagementIntervalMinutes        int // How often inactive users are checked for re-engagement emails
+	BanExpiryIntervalMinutes           int // How often users whose ban has expired are reinstated
+	CommunityPickIntervalMinutes       int // How often community pick rounds past their deadline are published
+	ScheduleReminderIntervalMinutes    int // How often reminders for upcoming scheduled streams are sent
+	ChangefeedRelayIntervalSeconds     int // How often new clip outbox events are sequenced for the changefeed
+	ShiftReportIntervalMinutes         int // How often ended moderation shifts are checked for handoff report emails
+	CommunityDigestIntervalMinutes     int // How often active communities are checked for last week's digest post
+	ProfileViewRollupIntervalMinutes   int // How often profile view counts are rolled up into daily analytics
+	SearchIncrementalIntervalMinutes   int // How often search indices are incrementally reindexed by the worker (0 disables)
+	ClipHypeIntervalMinutes            int // How often new clips are scored by the chat activity around them
+	TagGraphIntervalMinutes            int // How often the tag co-occurrence graph is rebuilt
+	ClaimExpiryIntervalMinutes         int // How often stale moderation claims are released
+	DMCAIntervalMinutes                int // How often content is reinstated after counter-notice waiting periods and old strikes expire
+	BanEvasionIntervalMinutes          int // How often new accounts are checked for sign-in signals shared with banned accounts
+	ReportPriorityIntervalMinutes      int // How often open report groups are rescored
+	LeaderboardSnapshotIntervalMinutes int // How often weekly, monthly and seasonal leaderboards are recomputed
+	KarmaSafeguardIntervalMinutes      int // How often inactive karma is decayed and vote rings are detected
+	SmartFeedIntervalMinutes           int // How often smart feeds are materialized from their rules
+
+	// TrendingHypeWeight (0-1) multiplies trending scores by 1 + weight ×
+	// chat hype score; 0 leaves trending unchanged
+	TrendingHypeWeight float64
+
+	// QueueEnabled moves clip sync, webhook retries, exports and embeddings from
+	// in-process schedulers to the persistent job queue run by cmd/worker
+	QueueEnabled             bool
+	QueueWorkers             int // Jobs a worker process runs concurrently
+	QueuePollIntervalSeconds int // How often idle workers poll for due jobs
+	QueueStaleAfterMinutes   int // Running jobs not finished after this long are retried
+	QueueRetentionDays       int // How long completed and failed jobs are kept
+
+	// ShutdownDrainSeconds is how long in-flight scheduler runs and queued
+	// jobs get to finish on shutdown before they are interrupted
+	ShutdownDrainSeconds int
 }
 
 // RateLimitConfig holds rate limiting configuration
@@ -197,6 +400,7 @@ type RateLimitConfig struct {
 // SecurityConfig holds security-related configuration
 type SecurityConfig struct {
 	MFAEncryptionKey string // 32-byte key for AES-256 encryption of MFA secrets
+	CursorSigningKey string // HMAC key for pagination cursors, shared by all instances
 }
 
 // QueryLimitsConfig holds database query limits
@@ -242,6 +446,8 @@ type MirrorConfig struct {
 	SyncIntervalMinutes    int      // Interval to check for popular clips (default: 60)
 	CleanupIntervalMinutes int      // Interval to cleanup expired mirrors (default: 1440, 24 hours)
 	MinMirrorHitRate       float64  // Minimum mirror hit rate percentage (default: 60.0)
+	EmbedFailureMinEvents  int      // Minimum reported embed loads before a clip's failure rate counts (default: 20)
+	EmbedFailureRate       float64  // Embed failure rate that triggers mirroring and video-first playback (default: 0.25)
 }
 
 // RecommendationsConfig holds recommendation algorithm configuration
@@ -282,6 +488,31 @@ type HybridSearchConfig struct {
 	// Scoring boost parameters
 	EngagementBoost float64 // Boost factor for engagement score (default: 0.1)
 	RecencyBoost    float64 // Boost factor for recency (default: 0.5)
+	HypeBoost       float64 // Boost factor for the chat hype score (default: 0, off)
+
+	// OpenSearch kNN settings
+	OpenSearchKNN   bool // Index embeddings in OpenSearch and fuse BM25 and kNN there with RRF (default: false)
+	RRFRankConstant int  // Rank constant for reciprocal rank fusion (default: 60)
+
+	// Spell correction settings
+	SpellCorrection      bool    // Suggest corrections for misspelled queries as did_you_mean (default: true)
+	AutoCorrectThreshold float64 // Minimum suggestion score (0-1) to search the correction when a query has no clip hits; 0 disables (default: 0.75)
+
+	// Personalization settings
+	Personalization          bool    // Re-rank relevance results for signed-in users by their follows, downvotes, interests and languages (default: true)
+	FollowedBroadcasterBoost float64 // Score added to clips from followed broadcasters (default: 0.3)
+	FollowedGameBoost        float64 // Score added to clips of followed games (default: 0.15)
+	DownvotedGamePenalty     float64 // Score taken from clips of consistently downvoted games (default: 0.3)
+	DownvotedGameMinVotes    int     // Downvotes on a game's clips before it counts as consistently downvoted (default: 3)
+	DownvotedGameShare       float64 // Share (0-1) of the user's votes on a game's clips that must be downvotes (default: 0.7)
+	InterestBoost            float64 // Score added to clips of streamers and games the user picked as interests (default: 0.1)
+	LanguageBoost            float64 // Score added to clips in languages the user watches (default: 0.1)
+	LanguageShare            float64 // Share (0-1) of the clips a user watched or upvoted that must be in a language for it to count (default: 0.2)
+
+	// Learning-to-rank settings
+	LTR               bool   // Re-rank the top fused candidates with a learning-to-rank model in an A/B test (default: false)
+	LTRModelPath      string // Path to the model exported by train-ltr (default: ltr-model.json)
+	LTRTrafficPercent int    // Percentage (0-100) of signed-in users in the learning-to-rank arm (default: 50)
 }
 
 // ToxicityConfig holds toxicity detection configuration
@@ -315,6 +546,84 @@ type TelemetryConfig struct {
 	Environment      string  // Environment name (development, staging, production)
 }
 
+// UploadConfig holds presigned file upload configuration
+type UploadConfig struct {
+	Enabled                bool
+	StorageEndpoint        string // S3-compatible endpoint, e.g. https://s3.us-east-1.amazonaws.com
+	StorageRegion          string
+	StorageBucket          string
+	StorageAccessKeyID     string
+	StorageSecretKey       string
+	StoragePathStyle       bool   // Address the bucket in the path (MinIO) instead of the host name
+	PublicBaseURL          string // Where uploaded files are served from, e.g. a CDN (default: the bucket URL)
+	PresignExpiryMinutes   int    // How long presigned upload URLs are valid (default: 15)
+	OrphanTTLHours         int    // How long a completed upload may stay unused before it is deleted (default: 24)
+	ClamAVAddress          string // clamd host:port or unix socket path; scanning is disabled when empty
+	ScanTimeoutSeconds     int    // Timeout of one virus scan (default: 60)
+	CleanupIntervalMinutes int    // How often orphaned uploads are deleted (default: 60)
+}
+
+// MarkdownConfig holds the link policy of Markdown in comments and discussions
+type MarkdownConfig struct {
+	UnfurlHosts      []string // Hosts whose links may get preview cards, subdomains included
+	BlockedLinkHosts []string // Hosts whose links are shown as plain text, subdomains included
+	MaxUnfurls       int      // Most links per comment or discussion marked for previews (default: 1)
+}
+
+// HealthHistoryConfig holds health probe history and uptime SLA configuration
+type HealthHistoryConfig struct {
+	ProbeIntervalSeconds int     // How often dependencies are probed and endpoint group traffic is recorded (default: 60)
+	RetentionDays        int     // How long probe results are kept (default: 90)
+	SLATargetPercent     float64 // Uptime each dependency and endpoint group should reach, below 100 (default: 99.9)
+	ErrorRateThreshold   float64 // Share (0-1) of server errors above which an endpoint group is down for an interval (default: 0.05)
+}
+
+// MaintenanceConfig holds the maintenance mode the API starts in. Admins can
+// switch it at runtime, overriding this on every instance.
+type MaintenanceConfig struct {
+	ReadOnly bool   // Reject mutating requests, except admin and auth endpoints (default: false)
+	Message  string // Shown to users while read-only
+}
+
+// AppealsConfig holds the moderation appeal SLAs
+type AppealsConfig struct {
+	AssignmentSLAHours int // How soon a moderator should take a new appeal into review (default: 24)
+	ResolutionSLAHours int // How soon an appeal should be decided after it is filed (default: 72)
+}
+
+// ClaimsConfig holds how long moderators keep items they claim for review
+type ClaimsConfig struct {
+	TTLMinutes int // How long a claim lasts before it's released as stale (default: 30)
+}
+
+// DMCAConfig holds the DMCA agent contact and how long copyright strikes last
+type DMCAConfig struct {
+	AgentEmail         string // Designated agent notified of new takedown notices
+	StrikeExpiryMonths int    // How long a strike counts against a user (default: 12)
+}
+
+// BanEvasionConfig holds how sign-in signals are collected and how new
+// accounts are matched against banned accounts
+type BanEvasionConfig struct {
+	HashSecret          string  // Key for hashing IP addresses and device fingerprints; set it so hashes can't be reversed by brute force
+	FingerprintHeader   string  // Request header carrying the client's device fingerprint (default: X-Device-Fingerprint)
+	NewAccountDays      int     // How old an account can be and still be checked against banned accounts (default: 30)
+	MinConfidence       float64 // Confidence (0-1) a match needs to be flagged for review (default: 0.5)
+	SignalRetentionDays int     // How long signals are kept after they were last seen; banned accounts keep theirs (default: 180)
+}
+
+// KarmaSafeguardsConfig holds how inactive accounts' karma decays and how
+// vote rings are detected
+type KarmaSafeguardsConfig struct {
+	DecayInactiveDays  int // How long an account must be inactive before its karma decays; 0 disables decay (default: 90)
+	DecayPeriodDays    int // How often an inactive account's karma decays (default: 30)
+	DecayPercent       int // Percent of karma above the floor taken by each decay (default: 10)
+	DecayFloor         int // Karma that never decays (default: 100)
+	VoteRingWindowDays int // How far back votes are checked for vote rings (default: 30)
+	VoteRingMinVotes   int // Upvotes two accounts must each give the other to be linked (default: 5)
+	VoteRingMinSize    int // Linked accounts needed to flag a vote ring (default: 3)
+}
+
 // getEnvBool gets a boolean environment variable with a fallback default value
 func getEnvBool(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
@@ -351,6 +660,9 @@ func Load() (*Config, error) {
 		redisDB = 0
 	}
 
+	embeddingProvider := strings.ToLower(getEnv("EMBEDDING_PROVIDER", "openai"))
+	nextEmbeddingProvider := strings.ToLower(getEnv("EMBEDDING_NEXT_PROVIDER", embeddingProvider))
+
 	config := &Config{
 		Server: ServerConfig{
 			Port:        getEnv("PORT", "8080"),
@@ -359,6 +671,8 @@ func Load() (*Config, error) {
 			Environment: getEnv("ENVIRONMENT", "development"),
 			ExportDir:   getEnv("EXPORT_DIR", "./exports"),
 			DocsPath:    getEnv("DOCS_PATH", "../docs"),
+			// Requests get this long once background jobs have drained
+			ShutdownTimeoutSeconds: getEnvInt("SHUTDOWN_TIMEOUT_SECONDS", 5),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -373,15 +687,47 @@ func Load() (*Config, error) {
 			Port:     getEnv("REDIS_PORT", "6379"),
 			Password: getEnv("REDIS_PASSWORD", ""),
 			DB:       redisDB,
+
+			Mode:             getEnv("REDIS_MODE", "standalone"),
+			Addrs:            splitEnvList(getEnv("REDIS_ADDRS", "")),
+			MasterName:       getEnv("REDIS_SENTINEL_MASTER", ""),
+			SentinelPassword: getEnv("REDIS_SENTINEL_PASSWORD", ""),
+
+			OperationTimeoutMs:   getEnvInt("REDIS_OPERATION_TIMEOUT_MS", 1000),
+			DegradedRetrySeconds: getEnvInt("REDIS_DEGRADED_RETRY_SECONDS", 5),
 		},
 		JWT: JWTConfig{
 			PrivateKey: getEnv("JWT_PRIVATE_KEY", ""),
 			PublicKey:  getEnv("JWT_PUBLIC_KEY", ""),
+
+			KeyRotationEnabled:      getEnvBool("JWT_KEY_ROTATION_ENABLED", false),
+			KeyEncryptionKey:        getEnv("JWT_KEY_ENCRYPTION_KEY", ""),
+			KeyRotationIntervalDays: getEnvInt("JWT_KEY_ROTATION_INTERVAL_DAYS", 30),
+			KeySyncIntervalMinutes:  getEnvInt("JWT_KEY_SYNC_INTERVAL_MINUTES", 5),
 		},
 		Twitch: TwitchConfig{
 			ClientID:     getEnv("TWITCH_CLIENT_ID", ""),
 			ClientSecret: getEnv("TWITCH_CLIENT_SECRET", ""),
 			RedirectURI:  getEnv("TWITCH_REDIRECT_URI", "http://localhost:8080/api/v1/auth/twitch/callback"),
+
+			EventSubSecret:              getEnv("TWITCH_EVENTSUB_SECRET", ""),
+			EventSubCallbackURL:         getEnv("TWITCH_EVENTSUB_CALLBACK_URL", ""),
+			EventSubSyncIntervalMinutes: getEnvInt("TWITCH_EVENTSUB_SYNC_INTERVAL_MINUTES", 60),
+			LiveStatusReconcileMinutes:  getEnvInt("TWITCH_LIVE_STATUS_RECONCILE_MINUTES", 15),
+			ScheduleSyncIntervalMinutes: getEnvInt("TWITCH_SCHEDULE_SYNC_INTERVAL_MINUTES", 60),
+			ChatBotUserID:               getEnv("TWITCH_CHAT_BOT_USER_ID", ""),
+		},
+		OAuth: OAuthConfig{
+			Google: OAuthProviderConfig{
+				ClientID:     getEnv("GOOGLE_CLIENT_ID", ""),
+				ClientSecret: getEnv("GOOGLE_CLIENT_SECRET", ""),
+				RedirectURI:  getEnv("GOOGLE_REDIRECT_URI", "http://localhost:8080/api/v1/auth/google/callback"),
+			},
+			Discord: OAuthProviderConfig{
+				ClientID:     getEnv("DISCORD_CLIENT_ID", ""),
+				ClientSecret: getEnv("DISCORD_CLIENT_SECRET", ""),
+				RedirectURI:  getEnv("DISCORD_REDIRECT_URI", "http://localhost:8080/api/v1/auth/discord/callback"),
+			},
 		},
 		CORS: CORSConfig{
 			AllowedOrigins: getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:5173,http://localhost:3000"),
@@ -394,6 +740,17 @@ func Load() (*Config, error) {
 			Username:           getEnv("OPENSEARCH_USERNAME", ""),
 			Password:           getEnv("OPENSEARCH_PASSWORD", ""),
 			InsecureSkipVerify: getEnv("OPENSEARCH_INSECURE_SKIP_VERIFY", "true") == "true",
+
+			SnapshotRepository:     getEnv("OPENSEARCH_SNAPSHOT_REPOSITORY", "clipper-search-snapshots"),
+			SnapshotRepositoryType: getEnv("OPENSEARCH_SNAPSHOT_REPOSITORY_TYPE", "fs"),
+			SnapshotLocation:       getEnv("OPENSEARCH_SNAPSHOT_LOCATION", "/usr/share/opensearch/snapshots"),
+			SnapshotBasePath:       getEnv("OPENSEARCH_SNAPSHOT_BASE_PATH", ""),
+			SnapshotSchedule:       getEnv("OPENSEARCH_SNAPSHOT_SCHEDULE", "0 3 * * *"),
+			SnapshotRetentionCount: getEnvInt("OPENSEARCH_SNAPSHOT_RETENTION_COUNT", 14),
+			SnapshotRetentionAge:   getEnv("OPENSEARCH_SNAPSHOT_RETENTION_AGE", "30d"),
+
+			IndexKeepVersions: getEnvInt("OPENSEARCH_INDEX_KEEP_VERSIONS", 2),
+			IndexMinAgeHours:  getEnvInt("OPENSEARCH_INDEX_MIN_AGE_HOURS", 72),
 		},
 		Stripe: StripeConfig{
 			SecretKey:            getEnv("STRIPE_SECRET_KEY", ""),
@@ -406,6 +763,7 @@ func Load() (*Config, error) {
 			CancelURL:            getEnv("STRIPE_CANCEL_URL", "http://localhost:5173/subscription/cancel"),
 			TaxEnabled:           getEnv("STRIPE_TAX_ENABLED", "false") == "true",
 			InvoicePDFEnabled:    getEnv("STRIPE_INVOICE_PDF_ENABLED", "false") == "true",
+			BundleCreatorShare:   getEnvInt("STRIPE_BUNDLE_CREATOR_SHARE_PERCENT", 80),
 		},
 		Sentry: SentryConfig{
 			DSN:              getEnv("SENTRY_DSN", ""),
@@ -422,14 +780,43 @@ func Load() (*Config, error) {
 			Enabled:                  getEnv("EMAIL_ENABLED", "false") == "true",
 			SandboxMode:              getEnv("EMAIL_SANDBOX_MODE", "false") == "true",
 			MaxEmailsPerHour:         getEnvInt("EMAIL_MAX_PER_HOUR", 10),
+			LinkTracking:             getEnv("EMAIL_LINK_TRACKING", "true") == "true",
+		},
+		Push: PushConfig{
+			FCMProjectID:       getEnv("FCM_PROJECT_ID", ""),
+			FCMCredentialsJSON: getEnv("FCM_CREDENTIALS_JSON", ""),
+			APNsKeyID:          getEnv("APNS_KEY_ID", ""),
+			APNsTeamID:         getEnv("APNS_TEAM_ID", ""),
+			APNsPrivateKey:     getEnv("APNS_PRIVATE_KEY", ""),
+			APNsBundleID:       getEnv("APNS_BUNDLE_ID", ""),
+			APNsProduction:     getEnvBool("APNS_PRODUCTION", false),
+			MaxRetries:         getEnvInt("PUSH_MAX_RETRIES", 3),
+			Workers:            getEnvInt("PUSH_WORKERS", 4),
 		},
 		Embedding: EmbeddingConfig{
+			Provider:                 embeddingProvider,
 			OpenAIAPIKey:             getEnv("OPENAI_API_KEY", ""),
+			CohereAPIKey:             getEnv("COHERE_API_KEY", ""),
+			APIKey:                   getEnv("EMBEDDING_API_KEY", ""),
 			APIBaseURL:               getEnv("EMBEDDING_API_BASE_URL", ""),
-			Model:                    getEnv("EMBEDDING_MODEL", "text-embedding-3-small"),
+			Model:                    getEnv("EMBEDDING_MODEL", defaultEmbeddingModels[embeddingProvider]),
 			RequestsPerMinute:        getEnvInt("EMBEDDING_REQUESTS_PER_MINUTE", 500),
 			SchedulerIntervalMinutes: getEnvInt("EMBEDDING_SCHEDULER_INTERVAL_MINUTES", 360),
 			Enabled:                  getEnv("EMBEDDING_ENABLED", "false") == "true",
+			BatchMaxInputs:           getEnvInt("EMBEDDING_BATCH_MAX_INPUTS", 100),
+			BatchMaxTokens:           getEnvInt("EMBEDDING_BATCH_MAX_TOKENS", 100000),
+			MonthlyBudgetUSD:         getEnvFloat("EMBEDDING_MONTHLY_BUDGET_USD", 0),
+			BudgetAlertThreshold:     getEnvFloat("EMBEDDING_BUDGET_ALERT_THRESHOLD", 0.8),
+			PricePerMillionTokens:    getEnvFloat("EMBEDDING_PRICE_PER_MILLION_TOKENS", 0),
+			Column:                   getEnv("EMBEDDING_COLUMN", "embedding"),
+			Dimensions:               getEnvInt("EMBEDDING_DIMENSIONS", 768),
+			NextColumn:               getEnv("EMBEDDING_NEXT_COLUMN", ""),
+			NextProvider:             nextEmbeddingProvider,
+			NextModel:                getEnv("EMBEDDING_NEXT_MODEL", defaultEmbeddingModels[nextEmbeddingProvider]),
+			NextDimensions:           getEnvInt("EMBEDDING_NEXT_DIMENSIONS", 768),
+			NextAPIBaseURL:           getEnv("EMBEDDING_NEXT_API_BASE_URL", ""),
+			NextAPIKey:               getEnv("EMBEDDING_NEXT_API_KEY", ""),
+			DualReadSampleRate:       clampFloat(getEnvFloat("EMBEDDING_DUAL_READ_SAMPLE_RATE", 0), 0, 1),
 		},
 		FeatureFlags: FeatureFlagsConfig{
 			SemanticSearch:       getEnv("FEATURE_SEMANTIC_SEARCH", "false") == "true",
@@ -439,6 +826,7 @@ func Load() (*Config, error) {
 			Analytics:            getEnv("FEATURE_ANALYTICS", "true") == "true",
 			Moderation:           getEnv("FEATURE_MODERATION", "true") == "true",
 			DiscoveryLists:       getEnv("FEATURE_DISCOVERY_LISTS", "false") == "true",
+			TitleNormalization:   getEnv("FEATURE_TITLE_NORMALIZATION", "false") == "true",
 		},
 		Karma: KarmaConfig{
 			InitialKarmaPoints:        getEnvInt("KARMA_INITIAL_POINTS", 100),
@@ -446,9 +834,37 @@ func Load() (*Config, error) {
 			RequireKarmaForSubmission: getEnv("KARMA_REQUIRE_FOR_SUBMISSION", "true") == "true",
 		},
 		Jobs: JobsConfig{
-			HotClipsRefreshIntervalMinutes: getEnvInt("HOT_CLIPS_REFRESH_INTERVAL_MINUTES", 5),
-			WebhookRetryIntervalMinutes:    getEnvInt("WEBHOOK_RETRY_INTERVAL_MINUTES", 1),
-			WebhookRetryBatchSize:          getEnvInt("WEBHOOK_RETRY_BATCH_SIZE", 100),
+			HotClipsRefreshIntervalMinutes:     getEnvInt("HOT_CLIPS_REFRESH_INTERVAL_MINUTES", 5),
+			WebhookRetryIntervalMinutes:        getEnvInt("WEBHOOK_RETRY_INTERVAL_MINUTES", 1),
+			WebhookRetryBatchSize:              getEnvInt("WEBHOOK_RETRY_BATCH_SIZE", 100),
+			BroadcasterApprovalIntervalMinutes: getEnvInt("BROADCASTER_APPROVAL_INTERVAL_MINUTES", 15),
+			BroadcasterAutoApproveDays:         getEnvInt("BROADCASTER_AUTO_APPROVE_DAYS", 7),
+			NotificationDigestIntervalMinutes:  getEnvInt("NOTIFICATION_DIGEST_INTERVAL_MINUTES", 15),
+			ReengagementIntervalMinutes:        getEnvInt("REENGAGEMENT_INTERVAL_MINUTES", 60),
+			BanExpiryIntervalMinutes:           getEnvInt("BAN_EXPIRY_INTERVAL_MINUTES", 5),
+			CommunityPickIntervalMinutes:       getEnvInt("COMMUNITY_PICK_INTERVAL_MINUTES", 5),
+			ScheduleReminderIntervalMinutes:    getEnvInt("SCHEDULE_REMINDER_INTERVAL_MINUTES", 1),
+			ChangefeedRelayIntervalSeconds:     getEnvInt("CHANGEFEED_RELAY_INTERVAL_SECONDS", 5),
+			ShiftReportIntervalMinutes:         getEnvInt("MODERATION_SHIFT_REPORT_INTERVAL_MINUTES", 10),
+			CommunityDigestIntervalMinutes:     getEnvInt("COMMUNITY_DIGEST_INTERVAL_MINUTES", 60),
+			ProfileViewRollupIntervalMinutes:   getEnvInt("PROFILE_VIEW_ROLLUP_INTERVAL_MINUTES", 60),
+			SearchIncrementalIntervalMinutes:   getEnvInt("SEARCH_INCREMENTAL_INTERVAL_MINUTES", 15),
+			ClipHypeIntervalMinutes:            getEnvInt("CLIP_HYPE_INTERVAL_MINUTES", 5),
+			TagGraphIntervalMinutes:            getEnvInt("TAG_GRAPH_INTERVAL_MINUTES", 1440),
+			ClaimExpiryIntervalMinutes:         getEnvInt("MODERATION_CLAIM_EXPIRY_INTERVAL_MINUTES", 1),
+			DMCAIntervalMinutes:                getEnvInt("DMCA_INTERVAL_MINUTES", 60),
+			BanEvasionIntervalMinutes:          getEnvInt("BAN_EVASION_INTERVAL_MINUTES", 30),
+			ReportPriorityIntervalMinutes:      getEnvInt("REPORT_PRIORITY_INTERVAL_MINUTES", 15),
+			LeaderboardSnapshotIntervalMinutes: getEnvInt("LEADERBOARD_SNAPSHOT_INTERVAL_MINUTES", 60),
+			KarmaSafeguardIntervalMinutes:      getEnvInt("KARMA_SAFEGUARD_INTERVAL_MINUTES", 60),
+			SmartFeedIntervalMinutes:           getEnvInt("SMART_FEED_INTERVAL_MINUTES", 15),
+			TrendingHypeWeight:                 clampFloat(getEnvFloat("TRENDING_HYPE_WEIGHT", 0), 0, 1),
+			QueueEnabled:                       getEnvBool("JOB_QUEUE_ENABLED", false),
+			QueueWorkers:                       getEnvInt("JOB_QUEUE_WORKERS", 4),
+			QueuePollIntervalSeconds:           getEnvInt("JOB_QUEUE_POLL_INTERVAL_SECONDS", 5),
+			QueueStaleAfterMinutes:             getEnvInt("JOB_QUEUE_STALE_AFTER_MINUTES", 30),
+			QueueRetentionDays:                 getEnvInt("JOB_QUEUE_RETENTION_DAYS", 7),
+			ShutdownDrainSeconds:               getEnvInt("JOB_SHUTDOWN_DRAIN_SECONDS", 20),
 		},
 		RateLimit: RateLimitConfig{
 			// Unauthenticated: 100 requests per 15 minutes per IP
@@ -478,6 +894,7 @@ func Load() (*Config, error) {
 		},
 		Security: SecurityConfig{
 			MFAEncryptionKey: getEnv("MFA_ENCRYPTION_KEY", ""),
+			CursorSigningKey: getEnv("CURSOR_SIGNING_KEY", ""),
 		},
 		QueryLimits: QueryLimitsConfig{
 			MaxResultSize:   getEnvInt("QUERY_MAX_RESULT_SIZE", 1000),
@@ -515,6 +932,8 @@ func Load() (*Config, error) {
 			SyncIntervalMinutes:    getEnvInt("MIRROR_SYNC_INTERVAL_MINUTES", 60),
 			CleanupIntervalMinutes: getEnvInt("MIRROR_CLEANUP_INTERVAL_MINUTES", 1440),
 			MinMirrorHitRate:       getEnvFloat("MIRROR_MIN_HIT_RATE", 60.0),
+			EmbedFailureMinEvents:  getEnvInt("MIRROR_EMBED_FAILURE_MIN_EVENTS", 20),
+			EmbedFailureRate:       getEnvFloat("MIRROR_EMBED_FAILURE_RATE", 0.25),
 		},
 		Recommendations: RecommendationsConfig{
 			// Hybrid algorithm weights
@@ -551,6 +970,31 @@ func Load() (*Config, error) {
 			// Scoring boost parameters
 			EngagementBoost: getEnvFloat("HYBRID_SEARCH_ENGAGEMENT_BOOST", 0.1),
 			RecencyBoost:    getEnvFloat("HYBRID_SEARCH_RECENCY_BOOST", 0.5),
+			HypeBoost:       getEnvFloat("HYBRID_SEARCH_HYPE_BOOST", 0),
+
+			// OpenSearch kNN settings
+			OpenSearchKNN:   getEnvBool("HYBRID_SEARCH_OPENSEARCH_KNN", false),
+			RRFRankConstant: getEnvInt("HYBRID_SEARCH_RRF_RANK_CONSTANT", 60),
+
+			// Spell correction settings
+			SpellCorrection:      getEnvBool("HYBRID_SEARCH_SPELL_CORRECTION", true),
+			AutoCorrectThreshold: clampFloat(getEnvFloat("HYBRID_SEARCH_AUTOCORRECT_THRESHOLD", 0.75), 0, 1),
+
+			// Personalization settings
+			Personalization:          getEnvBool("HYBRID_SEARCH_PERSONALIZATION", true),
+			FollowedBroadcasterBoost: getEnvFloat("HYBRID_SEARCH_FOLLOWED_BROADCASTER_BOOST", 0.3),
+			FollowedGameBoost:        getEnvFloat("HYBRID_SEARCH_FOLLOWED_GAME_BOOST", 0.15),
+			DownvotedGamePenalty:     getEnvFloat("HYBRID_SEARCH_DOWNVOTED_GAME_PENALTY", 0.3),
+			DownvotedGameMinVotes:    getEnvInt("HYBRID_SEARCH_DOWNVOTED_GAME_MIN_VOTES", 3),
+			DownvotedGameShare:       clampFloat(getEnvFloat("HYBRID_SEARCH_DOWNVOTED_GAME_SHARE", 0.7), 0, 1),
+			InterestBoost:            getEnvFloat("HYBRID_SEARCH_INTEREST_BOOST", 0.1),
+			LanguageBoost:            getEnvFloat("HYBRID_SEARCH_LANGUAGE_BOOST", 0.1),
+			LanguageShare:            clampFloat(getEnvFloat("HYBRID_SEARCH_LANGUAGE_SHARE", 0.2), 0, 1),
+
+			// Learning-to-rank settings
+			LTR:               getEnvBool("HYBRID_SEARCH_LTR", false),
+			LTRModelPath:      getEnv("HYBRID_SEARCH_LTR_MODEL_PATH", "ltr-model.json"),
+			LTRTrafficPercent: getEnvInt("HYBRID_SEARCH_LTR_TRAFFIC_PERCENT", 50),
 		},
 		Toxicity: ToxicityConfig{
 			Enabled:   getEnvBool("TOXICITY_ENABLED", false),
@@ -577,6 +1021,63 @@ func Load() (*Config, error) {
 			TracesSampleRate: clampFloat(getEnvFloat("TELEMETRY_TRACES_SAMPLE_RATE", 0.1), 0.0, 1.0),
 			Environment:      getEnv("TELEMETRY_ENVIRONMENT", getEnv("ENVIRONMENT", "development")),
 		},
+		Upload: UploadConfig{
+			Enabled:                getEnvBool("UPLOAD_ENABLED", false),
+			StorageEndpoint:        getEnv("UPLOAD_STORAGE_ENDPOINT", ""),
+			StorageRegion:          getEnv("UPLOAD_STORAGE_REGION", "us-east-1"),
+			StorageBucket:          getEnv("UPLOAD_STORAGE_BUCKET", ""),
+			StorageAccessKeyID:     getEnv("UPLOAD_STORAGE_ACCESS_KEY_ID", ""),
+			StorageSecretKey:       getEnv("UPLOAD_STORAGE_SECRET_ACCESS_KEY", ""),
+			StoragePathStyle:       getEnvBool("UPLOAD_STORAGE_PATH_STYLE", false),
+			PublicBaseURL:          getEnv("UPLOAD_PUBLIC_BASE_URL", ""),
+			PresignExpiryMinutes:   getEnvInt("UPLOAD_PRESIGN_EXPIRY_MINUTES", 15),
+			OrphanTTLHours:         getEnvInt("UPLOAD_ORPHAN_TTL_HOURS", 24),
+			ClamAVAddress:          getEnv("UPLOAD_CLAMAV_ADDRESS", ""),
+			ScanTimeoutSeconds:     getEnvInt("UPLOAD_SCAN_TIMEOUT_SECONDS", 60),
+			CleanupIntervalMinutes: getEnvInt("UPLOAD_CLEANUP_INTERVAL_MINUTES", 60),
+		},
+		Markdown: MarkdownConfig{
+			UnfurlHosts:      splitEnvList(getEnv("MARKDOWN_UNFURL_HOSTS", "twitch.tv,youtube.com,youtu.be")),
+			BlockedLinkHosts: splitEnvList(getEnv("MARKDOWN_BLOCKED_LINK_HOSTS", "")),
+			MaxUnfurls:       getEnvInt("MARKDOWN_MAX_UNFURLS", 1),
+		},
+		HealthHistory: HealthHistoryConfig{
+			ProbeIntervalSeconds: getEnvInt("HEALTH_PROBE_INTERVAL_SECONDS", 60),
+			RetentionDays:        getEnvInt("HEALTH_HISTORY_RETENTION_DAYS", 90),
+			SLATargetPercent:     clampFloat(getEnvFloat("HEALTH_SLA_TARGET_PERCENT", 99.9), 0, 99.999),
+			ErrorRateThreshold:   clampFloat(getEnvFloat("HEALTH_ERROR_RATE_THRESHOLD", 0.05), 0, 1),
+		},
+		Maintenance: MaintenanceConfig{
+			ReadOnly: getEnvBool("MAINTENANCE_READ_ONLY", false),
+			Message:  getEnv("MAINTENANCE_MESSAGE", ""),
+		},
+		Appeals: AppealsConfig{
+			AssignmentSLAHours: getEnvInt("APPEAL_ASSIGNMENT_SLA_HOURS", 24),
+			ResolutionSLAHours: getEnvInt("APPEAL_RESOLUTION_SLA_HOURS", 72),
+		},
+		Claims: ClaimsConfig{
+			TTLMinutes: getEnvInt("MODERATION_CLAIM_TTL_MINUTES", 30),
+		},
+		DMCA: DMCAConfig{
+			AgentEmail:         getEnv("DMCA_AGENT_EMAIL", "dmca@clpr.tv"),
+			StrikeExpiryMonths: getEnvInt("DMCA_STRIKE_EXPIRY_MONTHS", 12),
+		},
+		BanEvasion: BanEvasionConfig{
+			HashSecret:          getEnv("BAN_EVASION_HASH_SECRET", ""),
+			FingerprintHeader:   getEnv("BAN_EVASION_FINGERPRINT_HEADER", "X-Device-Fingerprint"),
+			NewAccountDays:      getEnvInt("BAN_EVASION_NEW_ACCOUNT_DAYS", 30),
+			MinConfidence:       clampFloat(getEnvFloat("BAN_EVASION_MIN_CONFIDENCE", 0.5), 0, 1),
+			SignalRetentionDays: getEnvInt("BAN_EVASION_SIGNAL_RETENTION_DAYS", 180),
+		},
+		KarmaSafeguards: KarmaSafeguardsConfig{
+			DecayInactiveDays:  getEnvInt("KARMA_DECAY_INACTIVE_DAYS", 90),
+			DecayPeriodDays:    getEnvInt("KARMA_DECAY_PERIOD_DAYS", 30),
+			DecayPercent:       getEnvInt("KARMA_DECAY_PERCENT", 10),
+			DecayFloor:         getEnvInt("KARMA_DECAY_FLOOR", 100),
+			VoteRingWindowDays: getEnvInt("VOTE_RING_WINDOW_DAYS", 30),
+			VoteRingMinVotes:   getEnvInt("VOTE_RING_MIN_VOTES", 5),
+			VoteRingMinSize:    getEnvInt("VOTE_RING_MIN_SIZE", 3),
+		},
 	}
 
 	return config, nil
@@ -634,6 +1135,17 @@ func clampFloat(value, min, max float64) float64 {
 	return value
 }
 
+// splitEnvList splits a comma-separated environment value, dropping blanks
+func splitEnvList(raw string) []string {
+	var items []string
+	for _, part := range strings.Split(raw, ",") {
+		if v := strings.TrimSpace(part); v != "" {
+			items = append(items, v)
+		}
+	}
+	return items
+}
+
 // collectStripeWebhookSecrets gathers the configured Stripe webhook secrets, supporting
 // one primary secret plus optional alternates without requiring multiple endpoints.
 func collectStripeWebhookSecrets() []string {
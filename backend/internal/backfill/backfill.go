@@ -0,0 +1,262 @@
+// Package backfill runs long backfill jobs in batches, with progress
+// checkpoints, parallel workers and rate limiting. A job interrupted by a
+// crash, deploy or Ctrl+C resumes after the last batch it finished instead of
+// starting over.
+package backfill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/subculture-collective/clipper/internal/models"
+	"golang.org/x/time/rate"
+)
+
+// StateStore persists job checkpoints
+type StateStore interface {
+	// GetBackfillState returns a job's checkpoint, or nil if the job never ran
+	GetBackfillState(ctx context.Context, name string) (*models.BackfillState, error)
+	SaveBackfillState(ctx context.Context, state *models.BackfillState) error
+}
+
+// FetchFunc returns up to limit items following the cursor after, in a stable
+// order, and the cursor of the last item returned. An empty cursor is the
+// start. An empty batch ends the job.
+type FetchFunc[T any] func(ctx context.Context, after string, limit int) ([]T, string, error)
+
+// ProcessFunc handles a batch and returns how many of its items failed. Items
+// that fail are counted and skipped; an error stops the job.
+type ProcessFunc[T any] func(ctx context.Context, batch []T) (int, error)
+
+// Job is a backfill over items read in batches
+type Job[T any] struct {
+	Name    string // Checkpoint name, such as search:clips
+	Fetch   FetchFunc[T]
+	Process ProcessFunc[T]
+}
+
+// Options configure a run
+type Options struct {
+	BatchSize int
+	Workers   int     // Batches processed at once; batches are fetched one at a time
+	Rate      float64 // Most items processed per second across workers, 0 for no limit
+	Resume    bool    // Continue from the job's checkpoint rather than the start
+}
+
+// batch is a fetched batch, numbered in fetch order
+type batch[T any] struct {
+	seq    int
+	items  []T
+	cursor string
+}
+
+// result is a processed batch, or the error that stopped the job
+type result struct {
+	seq    int
+	count  int
+	failed int
+	cursor string
+	err    error
+}
+
+// Run runs a job to the end and returns its final state. Progress is saved
+// after each batch, up to the last batch with every earlier batch done, so
+// batches finished out of order by parallel workers are never skipped on
+// resume; a resumed job may redo the few batches after its checkpoint, so
+// Process must be idempotent. A nil store runs without checkpoints, as for
+// dry runs.
+func Run[T any](ctx context.Context, store StateStore, job Job[T], opts Options) (*models.BackfillState, error) {
+	if opts.BatchSize < 1 {
+		opts.BatchSize = 100
+	}
+	if opts.Workers < 1 {
+		opts.Workers = 1
+	}
+
+	state, err := startState(ctx, store, job.Name, opts.Resume)
+	if err != nil {
+		return nil, err
+	}
+	if state.Status == models.BackfillStatusCompleted {
+		log.Printf("%s: already completed at %s; run without -resume to start over", job.Name, state.CompletedAt.Format(time.RFC3339))
+		return state, nil
+	}
+	if state.Cursor != "" {
+		log.Printf("%s: resuming after %s (%d processed, %d failed so far)", job.Name, state.Cursor, state.Processed, state.Failed)
+	}
+
+	var limiter *rate.Limiter
+	if opts.Rate > 0 {
+		limiter = rate.NewLimiter(rate.Limit(opts.Rate), opts.BatchSize)
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	batches := make(chan batch[T], opts.Workers)
+	results := make(chan result, opts.Workers)
+	var wg sync.WaitGroup
+
+	// Fetch batches in order, each after the last
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(batches)
+		after := state.Cursor
+		for seq := 0; ; seq++ {
+			items, next, err := job.Fetch(runCtx, after, opts.BatchSize)
+			if err != nil {
+				results <- result{err: fmt.Errorf("failed to fetch batch after %q: %w", after, err)}
+				return
+			}
+			if len(items) == 0 {
+				return
+			}
+			select {
+			case batches <- batch[T]{seq: seq, items: items, cursor: next}:
+			case <-runCtx.Done():
+				return
+			}
+			after = next
+		}
+	}()
+
+	for i := 0; i < opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for b := range batches {
+				if runCtx.Err() != nil {
+					continue
+				}
+				if limiter != nil {
+					if err := limiter.WaitN(runCtx, min(len(b.items), opts.BatchSize)); err != nil {
+						results <- result{err: err}
+						continue
+					}
+				}
+				failed, err := job.Process(runCtx, b.items)
+				if err != nil {
+					err = fmt.Errorf("failed to process batch after %q: %w", b.cursor, err)
+				}
+				results <- result{seq: b.seq, count: len(b.items), failed: failed, cursor: b.cursor, err: err}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// Advance the checkpoint over batches done in fetch order
+	var runErr error
+	done := make(map[int]result)
+	next := 0
+	for r := range results {
+		if r.err != nil {
+			if runErr == nil {
+				runErr = r.err
+				cancel()
+			}
+			continue
+		}
+		done[r.seq] = r
+		advanced := false
+		for {
+			d, ok := done[next]
+			if !ok {
+				break
+			}
+			delete(done, next)
+			state.Cursor = d.cursor
+			state.Processed += int64(d.count - d.failed)
+			state.Failed += int64(d.failed)
+			next++
+			advanced = true
+		}
+		if advanced {
+			if err := saveState(ctx, store, state); err != nil && runErr == nil {
+				runErr = err
+				cancel()
+			}
+			log.Printf("%s: %d processed, %d failed", job.Name, state.Processed, state.Failed)
+		}
+	}
+	if runErr == nil {
+		runErr = ctx.Err()
+	}
+
+	// Record the outcome even when ctx was cancelled
+	saveCtx, saveCancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer saveCancel()
+	if runErr != nil {
+		message := runErr.Error()
+		state.Status = models.BackfillStatusFailed
+		state.LastError = &message
+		if err := saveState(saveCtx, store, state); err != nil {
+			log.Printf("%s: failed to save checkpoint: %v", job.Name, err)
+		}
+		return state, runErr
+	}
+	now := time.Now()
+	state.Status = models.BackfillStatusCompleted
+	state.CompletedAt = &now
+	if err := saveState(saveCtx, store, state); err != nil {
+		return state, err
+	}
+	return state, nil
+}
+
+// startState returns the state a run starts from: the job's checkpoint when
+// resuming, and a fresh state otherwise
+func startState(ctx context.Context, store StateStore, name string, resume bool) (*models.BackfillState, error) {
+	if store != nil {
+		previous, err := store.GetBackfillState(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if previous != nil {
+			if resume {
+				if previous.Status != models.BackfillStatusCompleted {
+					previous.Status = models.BackfillStatusRunning
+					previous.LastError = nil
+				}
+				return previous, nil
+			}
+			if previous.Status != models.BackfillStatusCompleted {
+				log.Printf("%s: starting over; the checkpoint after %q is discarded (use -resume to continue it)", name, previous.Cursor)
+			}
+		}
+	}
+
+	state := &models.BackfillState{
+		Name:      name,
+		Status:    models.BackfillStatusRunning,
+		StartedAt: time.Now(),
+	}
+	if err := saveState(ctx, store, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func saveState(ctx context.Context, store StateStore, state *models.BackfillState) error {
+	if store == nil {
+		return nil
+	}
+	state.UpdatedAt = time.Now()
+	if err := store.SaveBackfillState(ctx, state); err != nil {
+		return fmt.Errorf("failed to save checkpoint of %s: %w", state.Name, err)
+	}
+	return nil
+}
+
+// IsStopped reports whether err stopped a job because it was interrupted
+func IsStopped(err error) bool {
+	return errors.Is(err, context.Canceled)
+}
@@ -0,0 +1,182 @@
+package backfill
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+type memoryStateStore struct {
+	mu     sync.Mutex
+	states map[string]models.BackfillState
+	saves  []string
+}
+
+func newMemoryStateStore() *memoryStateStore {
+	return &memoryStateStore{states: map[string]models.BackfillState{}}
+}
+
+func (m *memoryStateStore) GetBackfillState(ctx context.Context, name string) (*models.BackfillState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state, ok := m.states[name]
+	if !ok {
+		return nil, nil
+	}
+	return &state, nil
+}
+
+func (m *memoryStateStore) SaveBackfillState(ctx context.Context, state *models.BackfillState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.states[state.Name] = *state
+	m.saves = append(m.saves, state.Cursor)
+	return nil
+}
+
+// numbersJob backfills the numbers 1 to n, with cursors holding the last number
+func numbersJob(n int, process ProcessFunc[int]) Job[int] {
+	return Job[int]{
+		Name: "test:numbers",
+		Fetch: func(ctx context.Context, after string, limit int) ([]int, string, error) {
+			start := 0
+			if after != "" {
+				start, _ = strconv.Atoi(after)
+			}
+			var items []int
+			for i := start + 1; i <= n && len(items) < limit; i++ {
+				items = append(items, i)
+			}
+			if len(items) == 0 {
+				return nil, after, nil
+			}
+			return items, strconv.Itoa(items[len(items)-1]), nil
+		},
+		Process: process,
+	}
+}
+
+func TestRun_ProcessesEveryItemWithParallelWorkers(t *testing.T) {
+	store := newMemoryStateStore()
+	var mu sync.Mutex
+	seen := map[int]bool{}
+	job := numbersJob(95, func(ctx context.Context, batch []int) (int, error) {
+		// Finish early batches last so checkpoints must wait for them
+		if batch[0] == 1 {
+			time.Sleep(20 * time.Millisecond)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		failed := 0
+		for _, item := range batch {
+			seen[item] = true
+			if item%10 == 0 {
+				failed++
+			}
+		}
+		return failed, nil
+	})
+
+	state, err := Run(context.Background(), store, job, Options{BatchSize: 10, Workers: 4})
+	require.NoError(t, err)
+	assert.Len(t, seen, 95)
+	assert.Equal(t, models.BackfillStatusCompleted, state.Status)
+	assert.Equal(t, int64(86), state.Processed)
+	assert.Equal(t, int64(9), state.Failed)
+	assert.Equal(t, "95", state.Cursor)
+
+	// Checkpoints only move forward over finished batches
+	previous := 0
+	for _, cursor := range store.saves {
+		if cursor == "" {
+			continue
+		}
+		n, _ := strconv.Atoi(cursor)
+		assert.GreaterOrEqual(t, n, previous)
+		previous = n
+	}
+}
+
+func TestRun_ResumesAfterCheckpoint(t *testing.T) {
+	store := newMemoryStateStore()
+	var processed []int
+	failAt := 35
+	job := numbersJob(50, func(ctx context.Context, batch []int) (int, error) {
+		for _, item := range batch {
+			if item == failAt {
+				return 0, errors.New("search cluster unavailable")
+			}
+		}
+		processed = append(processed, batch...)
+		return 0, nil
+	})
+
+	state, err := Run(context.Background(), store, job, Options{BatchSize: 10, Workers: 1})
+	require.Error(t, err)
+	assert.Equal(t, models.BackfillStatusFailed, state.Status)
+	assert.Equal(t, "30", state.Cursor)
+	require.NotNil(t, state.LastError)
+
+	failAt = 0
+	processed = nil
+	state, err = Run(context.Background(), store, job, Options{BatchSize: 10, Workers: 1, Resume: true})
+	require.NoError(t, err)
+	assert.Equal(t, models.BackfillStatusCompleted, state.Status)
+	assert.Equal(t, int64(50), state.Processed)
+	assert.Nil(t, state.LastError)
+	require.NotEmpty(t, processed)
+	assert.Equal(t, 31, processed[0])
+
+	// A completed job isn't run again on resume, and starts over without it
+	processed = nil
+	_, err = Run(context.Background(), store, job, Options{BatchSize: 10, Resume: true})
+	require.NoError(t, err)
+	assert.Empty(t, processed)
+
+	state, err = Run(context.Background(), store, job, Options{BatchSize: 10})
+	require.NoError(t, err)
+	assert.Len(t, processed, 50)
+	assert.Equal(t, int64(50), state.Processed)
+}
+
+func TestRun_InterruptedJobKeepsCheckpoint(t *testing.T) {
+	store := newMemoryStateStore()
+	ctx, cancel := context.WithCancel(context.Background())
+	job := numbersJob(100, func(ctx context.Context, batch []int) (int, error) {
+		if batch[0] == 21 {
+			cancel()
+			return 0, ctx.Err()
+		}
+		return 0, nil
+	})
+
+	state, err := Run(ctx, store, job, Options{BatchSize: 10})
+	require.Error(t, err)
+	assert.True(t, IsStopped(err))
+	assert.Equal(t, "20", state.Cursor)
+
+	saved, err := store.GetBackfillState(context.Background(), "test:numbers")
+	require.NoError(t, err)
+	assert.Equal(t, models.BackfillStatusFailed, saved.Status)
+	assert.Equal(t, "20", saved.Cursor)
+}
+
+func TestRun_NilStoreRunsWithoutCheckpoints(t *testing.T) {
+	count := 0
+	job := numbersJob(25, func(ctx context.Context, batch []int) (int, error) {
+		count += len(batch)
+		return 0, nil
+	})
+
+	state, err := Run(context.Background(), nil, job, Options{BatchSize: 10, Rate: 1000})
+	require.NoError(t, err)
+	assert.Equal(t, 25, count)
+	assert.Equal(t, int64(25), state.Processed)
+}
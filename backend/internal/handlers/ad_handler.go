@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -8,12 +9,15 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
 	"github.com/subculture-collective/clipper/internal/services"
+	"github.com/subculture-collective/clipper/pkg/utils"
 )
 
 // AdHandler handles ad delivery endpoints
 type AdHandler struct {
-	adService *services.AdService
+	adService     *services.AdService
+	uploadService *services.UploadService
 }
 
 // NewAdHandler creates a new AdHandler
@@ -23,6 +27,59 @@ func NewAdHandler(adService *services.AdService) *AdHandler {
 	}
 }
 
+// SetUploadService tracks uploaded creatives, so replaced ones are cleaned up
+func (h *AdHandler) SetUploadService(uploadService *services.UploadService) {
+	h.uploadService = uploadService
+}
+
+// resolveCreative checks the upload a campaign's content URL points to, if
+// any. attachedID is the campaign's ID, empty for a new campaign.
+// Returns false if the error response was already sent.
+func (h *AdHandler) resolveCreative(c *gin.Context, contentURL, attachedID string) (*models.Upload, bool) {
+	if h.uploadService == nil {
+		return nil, true
+	}
+	userID, _ := c.Get("user_id")
+	adminID, _ := userID.(uuid.UUID)
+
+	upload, err := h.uploadService.ResolveURL(c.Request.Context(), adminID, contentURL, models.UploadContextAdCreative)
+	if err == nil && upload != nil && upload.Status == models.UploadStatusAttached &&
+		(upload.AttachedID == nil || *upload.AttachedID != attachedID) {
+		err = services.ErrUploadInUse
+	}
+	if err != nil {
+		status, code := http.StatusBadRequest, "INVALID_CREATIVE"
+		if !errors.Is(err, repository.ErrUploadNotFound) && !errors.Is(err, services.ErrUploadInvalid) &&
+			!errors.Is(err, services.ErrUploadNotReady) && !errors.Is(err, services.ErrUploadInUse) {
+			status, code = http.StatusInternalServerError, "CREATIVE_LOOKUP_FAILED"
+		}
+		c.JSON(status, StandardResponse{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    code,
+				Message: err.Error(),
+			},
+		})
+		return nil, false
+	}
+	return upload, true
+}
+
+// attachCreative marks an uploaded creative as used by the campaign. The
+// campaign is already saved, so failures are only logged.
+func (h *AdHandler) attachCreative(c *gin.Context, upload *models.Upload, ad *models.Ad) {
+	if upload == nil {
+		return
+	}
+	if _, err := h.uploadService.Attach(c.Request.Context(), *upload.UserID, upload.ID, models.UploadContextAdCreative, "ad", ad.ID.String()); err != nil {
+		utils.Warn("Failed to attach ad creative upload", map[string]interface{}{
+			"ad_id":     ad.ID.String(),
+			"upload_id": upload.ID.String(),
+			"error":     err.Error(),
+		})
+	}
+}
+
 // parseDaysParameter parses and validates the days query parameter
 // Returns the number of days and true if valid, or 0 and false if invalid (error already sent)
 func parseDaysParameter(c *gin.Context, defaultDays int) (int, bool) {
@@ -505,6 +562,11 @@ func (h *AdHandler) CreateCampaign(c *gin.Context) {
 		return
 	}
 
+	creative, ok := h.resolveCreative(c, req.ContentURL, "")
+	if !ok {
+		return
+	}
+
 	// Validate creative if it's a banner
 	if req.AdType == "banner" {
 		if err := h.adService.ValidateCreative(c.Request.Context(), req.ContentURL, req.AdType, req.Width, req.Height); err != nil {
@@ -550,6 +612,8 @@ func (h *AdHandler) CreateCampaign(c *gin.Context) {
 		return
 	}
 
+	h.attachCreative(c, creative, ad)
+
 	c.JSON(http.StatusCreated, StandardResponse{
 		Success: true,
 		Data:    ad,
@@ -652,6 +716,11 @@ func (h *AdHandler) UpdateCampaign(c *gin.Context) {
 		ad.TargetingCriteria = req.TargetingCriteria
 	}
 
+	creative, ok := h.resolveCreative(c, ad.ContentURL, ad.ID.String())
+	if !ok {
+		return
+	}
+
 	// Validate creative if it's a banner
 	if ad.AdType == "banner" {
 		if err := h.adService.ValidateCreative(c.Request.Context(), ad.ContentURL, ad.AdType, ad.Width, ad.Height); err != nil {
@@ -677,6 +746,8 @@ func (h *AdHandler) UpdateCampaign(c *gin.Context) {
 		return
 	}
 
+	h.attachCreative(c, creative, ad)
+
 	c.JSON(http.StatusOK, StandardResponse{
 		Success: true,
 		Data:    ad,
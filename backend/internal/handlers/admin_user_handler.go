@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -13,9 +15,12 @@ import (
 
 // AdminUserHandler handles admin user management endpoints
 type AdminUserHandler struct {
-	userRepo     *repository.UserRepository
-	auditLogRepo *repository.AuditLogRepository
-	authService  *services.AuthService
+	userRepo         *repository.UserRepository
+	auditLogRepo     *repository.AuditLogRepository
+	authService      *services.AuthService
+	banService       *services.UserBanService
+	shadowBanService *services.ShadowBanService
+	sessionService   *services.SessionService
 }
 
 // NewAdminUserHandler creates a new admin user handler
@@ -23,14 +28,26 @@ func NewAdminUserHandler(
 	userRepo *repository.UserRepository,
 	auditLogRepo *repository.AuditLogRepository,
 	authService *services.AuthService,
+	banService *services.UserBanService,
 ) *AdminUserHandler {
 	return &AdminUserHandler{
 		userRepo:     userRepo,
 		auditLogRepo: auditLogRepo,
 		authService:  authService,
+		banService:   banService,
 	}
 }
 
+// SetSessionService enables signing users out when their roles change
+func (h *AdminUserHandler) SetSessionService(sessionService *services.SessionService) {
+	h.sessionService = sessionService
+}
+
+// SetShadowBanService enables shadow banning users
+func (h *AdminUserHandler) SetShadowBanService(shadowBanService *services.ShadowBanService) {
+	h.shadowBanService = shadowBanService
+}
+
 // ListUsers handles GET /api/v1/admin/users
 func (h *AdminUserHandler) ListUsers(c *gin.Context) {
 	// Get query parameters
@@ -76,6 +93,8 @@ func (h *AdminUserHandler) ListUsers(c *gin.Context) {
 }
 
 // BanUser handles POST /api/v1/admin/users/:id/ban
+// Without duration_hours or permanent, the ban length follows the ban ladder
+// for the user's offense count.
 func (h *AdminUserHandler) BanUser(c *gin.Context) {
 	userIDStr := c.Param("id")
 	userID, err := uuid.Parse(userIDStr)
@@ -86,9 +105,11 @@ func (h *AdminUserHandler) BanUser(c *gin.Context) {
 		return
 	}
 
-	// Get reason from request body
+	// Get reason and optional duration from request body
 	var req struct {
-		Reason string `json:"reason" binding:"required"`
+		Reason        string `json:"reason" binding:"required"`
+		DurationHours *int   `json:"duration_hours"`
+		Permanent     bool   `json:"permanent"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
@@ -96,6 +117,12 @@ func (h *AdminUserHandler) BanUser(c *gin.Context) {
 		})
 		return
 	}
+	if req.DurationHours != nil && req.Permanent {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "A ban cannot have both a duration and be permanent",
+		})
+		return
+	}
 
 	// Get admin user ID
 	adminUserID, exists := c.Get("user_id")
@@ -106,37 +133,38 @@ func (h *AdminUserHandler) BanUser(c *gin.Context) {
 		return
 	}
 
+	banReq := services.BanRequest{
+		Reason:    req.Reason,
+		Permanent: req.Permanent,
+	}
+	if req.DurationHours != nil {
+		duration := time.Duration(*req.DurationHours) * time.Hour
+		banReq.Duration = &duration
+	}
+
 	// Ban the user
-	err = h.userRepo.BanUser(c.Request.Context(), userID)
+	ban, err := h.banService.BanUser(c.Request.Context(), userID, adminUserID.(uuid.UUID), banReq)
 	if err != nil {
-		if err == repository.ErrUserNotFound {
+		switch {
+		case errors.Is(err, services.ErrBanReasonRequired), errors.Is(err, services.ErrInvalidBanDuration):
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+		case errors.Is(err, repository.ErrUserNotFound):
 			c.JSON(http.StatusNotFound, gin.H{
 				"error": "User not found",
 			})
-			return
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to ban user",
+			})
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to ban user",
-		})
 		return
 	}
 
-	// Log audit event
-	auditLog := &models.ModerationAuditLog{
-		ID:          uuid.New(),
-		Action:      "ban_user",
-		EntityType:  "user",
-		EntityID:    userID,
-		ModeratorID: adminUserID.(uuid.UUID),
-		Reason:      &req.Reason,
-	}
-	if err := h.auditLogRepo.Create(c.Request.Context(), auditLog); err != nil {
-		// Record audit log failure without affecting the main operation
-		_ = c.Error(err)
-	}
-
 	c.JSON(http.StatusOK, gin.H{
 		"message": "User banned successfully",
+		"ban":     ban,
 	})
 }
 
@@ -167,9 +195,9 @@ func (h *AdminUserHandler) UnbanUser(c *gin.Context) {
 	}
 
 	// Unban the user
-	err = h.userRepo.UnbanUser(c.Request.Context(), userID)
+	err = h.banService.UnbanUser(c.Request.Context(), userID, adminUserID.(uuid.UUID), req.Reason)
 	if err != nil {
-		if err == repository.ErrUserNotFound {
+		if errors.Is(err, repository.ErrUserNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{
 				"error": "User not found",
 			})
@@ -181,26 +209,168 @@ func (h *AdminUserHandler) UnbanUser(c *gin.Context) {
 		return
 	}
 
-	// Log audit event
-	reason := "No reason provided"
-	if req.Reason != "" {
-		reason = req.Reason
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User unbanned successfully",
+	})
+}
+
+// ListUserBans handles GET /api/v1/admin/users/:id/bans
+func (h *AdminUserHandler) ListUserBans(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user ID",
+		})
+		return
 	}
-	auditLog := &models.ModerationAuditLog{
-		ID:          uuid.New(),
-		Action:      "unban_user",
-		EntityType:  "user",
-		EntityID:    userID,
-		ModeratorID: adminUserID.(uuid.UUID),
-		Reason:      &reason,
+
+	bans, err := h.banService.ListBans(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve bans",
+		})
+		return
 	}
-	if err := h.auditLogRepo.Create(c.Request.Context(), auditLog); err != nil {
-		// Record audit log failure without affecting the main operation
-		_ = c.Error(err)
+
+	c.JSON(http.StatusOK, gin.H{
+		"bans": bans,
+	})
+}
+
+// ShadowBanUser handles POST /api/v1/admin/users/:id/shadow-ban
+// Without duration_hours the shadow ban lasts until it is lifted.
+func (h *AdminUserHandler) ShadowBanUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user ID",
+		})
+		return
+	}
+
+	var req struct {
+		Reason        string `json:"reason" binding:"required"`
+		DurationHours *int   `json:"duration_hours"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Shadow ban reason is required",
+		})
+		return
+	}
+
+	adminUserID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Unauthorized",
+		})
+		return
+	}
+
+	shadowBanReq := services.ShadowBanRequest{Reason: req.Reason}
+	if req.DurationHours != nil {
+		duration := time.Duration(*req.DurationHours) * time.Hour
+		shadowBanReq.Duration = &duration
+	}
+
+	ban, err := h.shadowBanService.ShadowBanUser(c.Request.Context(), userID, adminUserID.(uuid.UUID), shadowBanReq)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrBanReasonRequired), errors.Is(err, services.ErrInvalidBanDuration):
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+		case errors.Is(err, repository.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "User not found",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to shadow ban user",
+			})
+		}
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "User unbanned successfully",
+		"message":    "User shadow banned successfully",
+		"shadow_ban": ban,
+	})
+}
+
+// LiftShadowBan handles POST /api/v1/admin/users/:id/lift-shadow-ban
+func (h *AdminUserHandler) LiftShadowBan(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user ID",
+		})
+		return
+	}
+
+	// Get optional reason from request body
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	_ = c.ShouldBindJSON(&req)
+
+	adminUserID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Unauthorized",
+		})
+		return
+	}
+
+	err = h.shadowBanService.LiftShadowBan(c.Request.Context(), userID, adminUserID.(uuid.UUID), req.Reason)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserShadowBanNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "User is not shadow banned",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to lift shadow ban",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Shadow ban lifted successfully",
+	})
+}
+
+// ListShadowBans handles GET /api/v1/admin/users/:id/shadow-bans
+// The active shadow ban, if any, is returned alongside the history.
+func (h *AdminUserHandler) ListShadowBans(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user ID",
+		})
+		return
+	}
+
+	active, err := h.shadowBanService.GetShadowBan(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve shadow bans",
+		})
+		return
+	}
+
+	bans, err := h.shadowBanService.ListShadowBans(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve shadow bans",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"active":      active,
+		"shadow_bans": bans,
 	})
 }
 
@@ -277,6 +447,8 @@ func (h *AdminUserHandler) UpdateUserRole(c *gin.Context) {
 		_ = c.Error(err)
 	}
 
+	h.revokeSessionsAfterScopeChange(c, userID)
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "User role updated successfully",
 	})
@@ -600,3 +772,193 @@ func (h *AdminUserHandler) ToggleCommentReview(c *gin.Context) {
 		"require_review": req.RequireReview,
 	})
 }
+
+// UpdateAdminRoles handles PUT /api/v1/admin/users/:id/admin-roles
+func (h *AdminUserHandler) UpdateAdminRoles(c *gin.Context) {
+	userIDStr := c.Param("id")
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user ID",
+		})
+		return
+	}
+
+	var req struct {
+		AdminRoles []string `json:"admin_roles"`
+		Reason     string   `json:"reason"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	// Validate and de-duplicate sub-roles
+	adminRoles := make([]string, 0, len(req.AdminRoles))
+	seen := make(map[string]bool, len(req.AdminRoles))
+	for _, adminRole := range req.AdminRoles {
+		if !models.IsValidAdminRole(adminRole) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid admin role. Must be support, trust_safety, or finance",
+			})
+			return
+		}
+		if !seen[adminRole] {
+			seen[adminRole] = true
+			adminRoles = append(adminRoles, adminRole)
+		}
+	}
+
+	// Get admin user ID
+	adminUserID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Unauthorized",
+		})
+		return
+	}
+
+	user, err := h.userRepo.GetByID(c.Request.Context(), userID)
+	if err != nil {
+		if err == repository.ErrUserNotFound {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "User not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get user",
+		})
+		return
+	}
+	previousRoles := user.AdminRoles
+	if previousRoles == nil {
+		previousRoles = []string{}
+	}
+
+	if err := h.userRepo.UpdateAdminRoles(c.Request.Context(), userID, adminRoles); err != nil {
+		if err == repository.ErrUserNotFound {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "User not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update admin roles",
+		})
+		return
+	}
+
+	// Log audit event
+	reason := req.Reason
+	if reason == "" {
+		reason = "Admin roles updated"
+	}
+	auditLog := &models.ModerationAuditLog{
+		ID:          uuid.New(),
+		Action:      "update_admin_roles",
+		EntityType:  "user",
+		EntityID:    userID,
+		ModeratorID: adminUserID.(uuid.UUID),
+		Reason:      &reason,
+		Metadata: map[string]interface{}{
+			"previous_admin_roles": previousRoles,
+			"admin_roles":          adminRoles,
+		},
+	}
+	if err := h.auditLogRepo.Create(c.Request.Context(), auditLog); err != nil {
+		// Record audit log failure without affecting the main operation
+		_ = c.Error(err)
+	}
+
+	h.revokeSessionsAfterScopeChange(c, userID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Admin roles updated successfully",
+		"admin_roles": adminRoles,
+	})
+}
+
+// ImpersonateUser handles POST /api/v1/admin/users/:id/impersonate
+func (h *AdminUserHandler) ImpersonateUser(c *gin.Context) {
+	userIDStr := c.Param("id")
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user ID",
+		})
+		return
+	}
+
+	var req struct {
+		Reason string `json:"reason" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "A reason is required to impersonate a user",
+		})
+		return
+	}
+
+	// Get admin user ID
+	adminUserID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Unauthorized",
+		})
+		return
+	}
+
+	token, user, err := h.authService.ImpersonateUser(c.Request.Context(), adminUserID.(uuid.UUID), userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		case errors.Is(err, services.ErrImpersonationForbidden), errors.Is(err, services.ErrUserBanned):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to impersonate user"})
+		}
+		return
+	}
+
+	// Log audit event
+	ip := c.ClientIP()
+	userAgent := c.Request.UserAgent()
+	auditLog := &models.ModerationAuditLog{
+		ID:          uuid.New(),
+		Action:      "impersonate_user",
+		EntityType:  "user",
+		EntityID:    userID,
+		ModeratorID: adminUserID.(uuid.UUID),
+		Reason:      &req.Reason,
+		IPAddress:   &ip,
+		UserAgent:   &userAgent,
+	}
+	if err := h.auditLogRepo.Create(c.Request.Context(), auditLog); err != nil {
+		// Impersonation must always leave an audit trail
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to record impersonation audit log",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"access_token": token,
+		"expires_in":   int((15 * time.Minute).Seconds()),
+		"user":         user,
+	})
+}
+
+// revokeSessionsAfterScopeChange signs the user out everywhere so new tokens carry the updated role
+func (h *AdminUserHandler) revokeSessionsAfterScopeChange(c *gin.Context, userID uuid.UUID) {
+	if h.sessionService == nil {
+		return
+	}
+	if _, err := h.sessionService.RevokeAllSessions(c.Request.Context(), userID); err != nil {
+		// Record the failure without affecting the role change
+		_ = c.Error(err)
+	}
+}
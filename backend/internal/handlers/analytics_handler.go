@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"log"
 	"net/http"
 	"strconv"
 
@@ -14,6 +15,7 @@ import (
 type AnalyticsHandler struct {
 	analyticsService *services.AnalyticsService
 	authService      *services.AuthService
+	shareLinkService *services.ShareLinkService
 }
 
 // NewAnalyticsHandler creates a new analytics handler
@@ -24,6 +26,11 @@ func NewAnalyticsHandler(analyticsService *services.AnalyticsService, authServic
 	}
 }
 
+// SetShareLinkService enables attributing clip views to share links
+func (h *AnalyticsHandler) SetShareLinkService(shareLinkService *services.ShareLinkService) {
+	h.shareLinkService = shareLinkService
+}
+
 // GetCreatorAnalyticsOverview returns summary metrics for a creator
 // GET /api/v1/creators/:creatorName/analytics/overview
 func (h *AnalyticsHandler) GetCreatorAnalyticsOverview(c *gin.Context) {
@@ -239,5 +246,18 @@ func (h *AnalyticsHandler) TrackClipView(c *gin.Context) {
 		return
 	}
 
+	// Attribute the view to the share link the viewer arrived through
+	if h.shareLinkService != nil {
+		code := c.Query("ref")
+		if code == "" {
+			code, _ = c.Cookie(ShareRefCookie)
+		}
+		if code != "" {
+			if err := h.shareLinkService.RecordView(c.Request.Context(), code, clipID, userID, c.ClientIP()); err != nil {
+				log.Printf("Failed to attribute view to share link %s: %v", code, err)
+			}
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{"message": "view tracked"})
 }
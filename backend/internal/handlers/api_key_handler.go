@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// APIKeyHandler handles personal API key management
+type APIKeyHandler struct {
+	apiKeyService *services.APIKeyService
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(apiKeyService *services.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// CreateAPIKey creates a new personal API key
+// POST /api/v1/users/me/api-keys
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	var req models.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	resp, err := h.apiKeyService.CreateKey(c.Request.Context(), userID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidAPIKeyScope), errors.Is(err, services.ErrInvalidAPIKeyRequest):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "valid_scopes": models.ValidAPIKeyScopes})
+		case errors.Is(err, services.ErrAPIKeyLimitReached):
+			c.JSON(http.StatusConflict, gin.H{"error": "Maximum number of active API keys reached"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create API key"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// ListAPIKeys lists the current user's API keys
+// GET /api/v1/users/me/api-keys
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	keys, err := h.apiKeyService.ListKeys(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list API keys"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"api_keys": keys})
+}
+
+// RevokeAPIKey revokes one of the current user's API keys
+// DELETE /api/v1/users/me/api-keys/:id
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	keyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid API key ID"})
+		return
+	}
+
+	if err := h.apiKeyService.RevokeKey(c.Request.Context(), userID, keyID); err != nil {
+		if errors.Is(err, repository.ErrAPIKeyNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke API key"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "API key revoked"})
+}
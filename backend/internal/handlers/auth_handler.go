@@ -3,7 +3,10 @@ package handlers
 import (
 	"errors"
 	"fmt"
+	"log"
 	"net/http"
+	"net/url"
+	"sort"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -14,10 +17,17 @@ import (
 	"github.com/subculture-collective/clipper/internal/services"
 )
 
+// providerLinkCookie binds a provider link flow to the browser that started it
+const providerLinkCookie = "provider_link_nonce"
+
 // AuthHandler handles authentication endpoints
 type AuthHandler struct {
-	authService *services.AuthService
-	cfg         *config.Config
+	authService       *services.AuthService
+	shareLinkService  *services.ShareLinkService
+	sessionService    *services.SessionService
+	banEvasionService *services.BanEvasionService
+	mfaService        *services.MFAService
+	cfg               *config.Config
 }
 
 // NewAuthHandler creates a new auth handler
@@ -28,6 +38,26 @@ func NewAuthHandler(authService *services.AuthService, cfg *config.Config) *Auth
 	}
 }
 
+// SetShareLinkService enables attributing new sign-ups to share links
+func (h *AuthHandler) SetShareLinkService(shareLinkService *services.ShareLinkService) {
+	h.shareLinkService = shareLinkService
+}
+
+// SetSessionService enables signing out other devices after login methods change
+func (h *AuthHandler) SetSessionService(sessionService *services.SessionService) {
+	h.sessionService = sessionService
+}
+
+// SetBanEvasionService enables recording sign-in signals for ban evasion detection
+func (h *AuthHandler) SetBanEvasionService(banEvasionService *services.BanEvasionService) {
+	h.banEvasionService = banEvasionService
+}
+
+// SetMFAService enables MFA challenges for accounts with MFA enabled when they sign in
+func (h *AuthHandler) SetMFAService(mfaService *services.MFAService) {
+	h.mfaService = mfaService
+}
+
 // InitiateOAuth handles GET /auth/twitch
 // Supports PKCE (code_challenge, code_challenge_method parameters)
 func (h *AuthHandler) InitiateOAuth(c *gin.Context) {
@@ -90,7 +120,7 @@ func (h *AuthHandler) HandleCallback(c *gin.Context) {
 	}
 
 	// Non-PKCE flow: complete authentication directly
-	_, accessToken, refreshToken, err := h.authService.HandleCallback(c.Request.Context(), code, state, "")
+	user, accessToken, refreshToken, err := h.authService.HandleCallback(c.Request.Context(), code, state, "", sessionClient(c))
 	if err != nil {
 		if err == services.ErrInvalidState {
 			c.JSON(http.StatusBadRequest, gin.H{
@@ -112,8 +142,12 @@ func (h *AuthHandler) HandleCallback(c *gin.Context) {
 		return
 	}
 
+	accessToken, mfaRequired := h.startMFAChallenge(c, user, accessToken, refreshToken)
+
 	// Set HTTP-only secure cookies
 	h.setAuthCookies(c, accessToken, refreshToken)
+	h.attributeShareSignup(c, user)
+	h.recordAccountSignals(c, user)
 
 	// Get frontend URL from allowed origins (first one)
 	frontendURL := "http://localhost:3000"
@@ -123,7 +157,7 @@ func (h *AuthHandler) HandleCallback(c *gin.Context) {
 	}
 
 	// Redirect to frontend with success
-	c.Redirect(http.StatusTemporaryRedirect, frontendURL+"/auth/success?success=true")
+	c.Redirect(http.StatusTemporaryRedirect, frontendURL+"/auth/success?success=true"+mfaRequiredQuery(mfaRequired))
 }
 
 // HandlePKCECallback handles POST /auth/twitch/callback
@@ -143,7 +177,7 @@ func (h *AuthHandler) HandlePKCECallback(c *gin.Context) {
 	}
 
 	// Handle OAuth callback with PKCE
-	_, accessToken, refreshToken, err := h.authService.HandleCallback(c.Request.Context(), body.Code, body.State, body.CodeVerifier)
+	user, accessToken, refreshToken, err := h.authService.HandleCallback(c.Request.Context(), body.Code, body.State, body.CodeVerifier, sessionClient(c))
 	if err != nil {
 		if err == services.ErrInvalidState {
 			c.JSON(http.StatusBadRequest, gin.H{
@@ -169,11 +203,16 @@ func (h *AuthHandler) HandlePKCECallback(c *gin.Context) {
 		return
 	}
 
+	accessToken, mfaRequired := h.startMFAChallenge(c, user, accessToken, refreshToken)
+
 	// Set HTTP-only secure cookies
 	h.setAuthCookies(c, accessToken, refreshToken)
+	h.attributeShareSignup(c, user)
+	h.recordAccountSignals(c, user)
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Authentication successful",
+		"message":      "Authentication successful",
+		"mfa_required": mfaRequired,
 	})
 }
 
@@ -220,7 +259,7 @@ func (h *AuthHandler) TestLogin(c *gin.Context) {
 		return
 	}
 
-	accessToken, refreshToken, err := h.authService.GenerateTokensForUser(ctx, user)
+	accessToken, refreshToken, err := h.authService.GenerateTokensForUser(ctx, user, sessionClient(c))
 	if err != nil {
 		if errors.Is(err, services.ErrUserBanned) {
 			c.JSON(http.StatusForbidden, gin.H{"error": "User is banned"})
@@ -257,8 +296,12 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	}
 
 	// Refresh tokens
-	newAccessToken, newRefreshToken, err := h.authService.RefreshAccessToken(c.Request.Context(), refreshToken)
+	newAccessToken, newRefreshToken, err := h.authService.RefreshAccessToken(c.Request.Context(), refreshToken, sessionClient(c))
 	if err != nil {
+		if errors.Is(err, services.ErrRefreshTokenReused) {
+			// The session was revoked; drop its cookies so the client signs in again
+			h.clearAuthCookies(c)
+		}
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"error": "Failed to refresh token",
 		})
@@ -340,6 +383,335 @@ func (h *AuthHandler) ReauthorizeTwitch(c *gin.Context) {
 	})
 }
 
+// ListOAuthProviders handles GET /auth/providers
+// Returns the login providers available in addition to Twitch
+func (h *AuthHandler) ListOAuthProviders(c *gin.Context) {
+	providers := append([]string{models.AuthProviderTwitch}, h.authService.OAuthProviderNames()...)
+	sort.Strings(providers[1:])
+
+	c.JSON(http.StatusOK, gin.H{
+		"providers": providers,
+	})
+}
+
+// InitiateProviderOAuth handles GET /auth/:provider
+// Starts a Google/Discord login. Supports PKCE like the Twitch flow.
+func (h *AuthHandler) InitiateProviderOAuth(c *gin.Context) {
+	provider := c.Param("provider")
+
+	authURL, err := h.authService.GenerateProviderAuthURL(
+		c.Request.Context(),
+		provider,
+		c.Query("code_challenge"),
+		c.Query("code_challenge_method"),
+		c.Query("state"),
+	)
+	if err != nil {
+		if errors.Is(err, services.ErrUnknownOAuthProvider) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Unsupported login provider",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to generate auth URL",
+		})
+		return
+	}
+
+	c.Redirect(http.StatusTemporaryRedirect, authURL)
+}
+
+// HandleProviderCallback handles GET /auth/:provider/callback
+// For PKCE flow: redirects to the frontend to complete the exchange
+// For non-PKCE flow: directly authenticates and sets cookies
+func (h *AuthHandler) HandleProviderCallback(c *gin.Context) {
+	provider := c.Param("provider")
+	code := c.Query("code")
+	state := c.Query("state")
+
+	if code == "" || state == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Missing code or state parameter",
+		})
+		return
+	}
+
+	stored, err := h.authService.GetProviderState(c.Request.Context(), provider, state)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid state parameter",
+		})
+		return
+	}
+
+	frontendURL := h.frontendURL()
+
+	if stored.LinkUserID != nil {
+		h.completeProviderLink(c, provider, code, state)
+		return
+	}
+
+	if stored.UsesPKCE() {
+		// Frontend will POST to /auth/:provider/callback with code_verifier
+		params := url.Values{}
+		params.Set("code", code)
+		params.Set("state", state)
+		params.Set("provider", provider)
+		c.Redirect(http.StatusTemporaryRedirect, frontendURL+"/auth/success?"+params.Encode())
+		return
+	}
+
+	user, accessToken, refreshToken, err := h.authService.HandleProviderCallback(c.Request.Context(), provider, code, state, "", sessionClient(c))
+	if err != nil {
+		h.respondProviderError(c, err)
+		return
+	}
+
+	accessToken, mfaRequired := h.startMFAChallenge(c, user, accessToken, refreshToken)
+
+	h.setAuthCookies(c, accessToken, refreshToken)
+	h.attributeShareSignup(c, user)
+	h.recordAccountSignals(c, user)
+
+	c.Redirect(http.StatusTemporaryRedirect, frontendURL+"/auth/success?success=true"+mfaRequiredQuery(mfaRequired))
+}
+
+// HandleProviderPKCECallback handles POST /auth/:provider/callback
+// For PKCE flow with code_verifier
+func (h *AuthHandler) HandleProviderPKCECallback(c *gin.Context) {
+	var body struct {
+		Code         string `json:"code" binding:"required"`
+		State        string `json:"state" binding:"required"`
+		CodeVerifier string `json:"code_verifier" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	user, accessToken, refreshToken, err := h.authService.HandleProviderCallback(
+		c.Request.Context(), c.Param("provider"), body.Code, body.State, body.CodeVerifier, sessionClient(c),
+	)
+	if err != nil {
+		h.respondProviderError(c, err)
+		return
+	}
+
+	accessToken, mfaRequired := h.startMFAChallenge(c, user, accessToken, refreshToken)
+
+	h.setAuthCookies(c, accessToken, refreshToken)
+	h.attributeShareSignup(c, user)
+	h.recordAccountSignals(c, user)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":      "Authentication successful",
+		"mfa_required": mfaRequired,
+	})
+}
+
+// LinkProvider handles POST /auth/:provider/link
+// Returns an authorization URL that links the provider account to the current user.
+// The flow is bound to this browser by a short-lived cookie.
+func (h *AuthHandler) LinkProvider(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	authURL, nonce, err := h.authService.GenerateProviderLinkURL(c.Request.Context(), c.Param("provider"), userID)
+	if err != nil {
+		if errors.Is(err, services.ErrUnknownOAuthProvider) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Unsupported login provider",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to generate auth URL",
+		})
+		return
+	}
+
+	// Lives as long as the flow's state
+	c.SetCookie(providerLinkCookie, nonce, 300, "/", "", h.cfg.Server.GinMode == "release", true)
+
+	c.JSON(http.StatusOK, gin.H{
+		"auth_url": authURL,
+	})
+}
+
+// completeProviderLink completes a link flow from its callback. Only the session and
+// browser that started the flow can complete it, and the session's tokens are kept.
+func (h *AuthHandler) completeProviderLink(c *gin.Context, provider, code, state string) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	nonce, _ := c.Cookie(providerLinkCookie)
+	c.SetCookie(providerLinkCookie, "", -1, "/", "", false, true)
+
+	if _, err := h.authService.CompleteProviderLink(c.Request.Context(), provider, code, state, userID, nonce); err != nil {
+		h.respondProviderError(c, err)
+		return
+	}
+
+	c.Redirect(http.StatusTemporaryRedirect, h.frontendURL()+"/settings?linked="+url.QueryEscape(provider))
+}
+
+// ListIdentities handles GET /auth/identities
+// Lists the external login identities linked to the current user
+func (h *AuthHandler) ListIdentities(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	identities, err := h.authService.ListIdentities(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to list linked accounts",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"identities": identities,
+	})
+}
+
+// UnlinkIdentity handles DELETE /auth/identities/:provider
+func (h *AuthHandler) UnlinkIdentity(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	err := h.authService.UnlinkIdentity(c.Request.Context(), userID, c.Param("provider"))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrLastLoginMethod):
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Cannot unlink your only login method",
+			})
+		case errors.Is(err, repository.ErrAuthIdentityNotFound), errors.Is(err, services.ErrUnknownOAuthProvider):
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Linked account not found",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to unlink account",
+			})
+		}
+		return
+	}
+
+	// Devices signed in through the removed provider must sign in again
+	if h.sessionService != nil {
+		if _, err := h.sessionService.RevokeOtherSessions(c.Request.Context(), userID, requestRefreshToken(c)); err != nil {
+			log.Printf("Failed to revoke sessions after unlinking identity for %s: %v", userID, err)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Account unlinked",
+	})
+}
+
+// respondProviderError maps provider login errors to HTTP responses
+func (h *AuthHandler) respondProviderError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrUnknownOAuthProvider):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unsupported login provider"})
+	case errors.Is(err, services.ErrInvalidState):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid state parameter"})
+	case errors.Is(err, services.ErrInvalidCodeVerifier):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid code verifier"})
+	case errors.Is(err, services.ErrIdentityAlreadyLinked), errors.Is(err, services.ErrProviderAlreadyLinked):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrUserBanned):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Your account has been banned"})
+	case errors.Is(err, services.ErrLinkNotAuthorized):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Account linking must be completed from the session that started it"})
+	default:
+		c.Error(err) // This will be logged by Gin's logger middleware
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Authentication failed"})
+	}
+}
+
+// currentUserID extracts the authenticated user ID, writing an error response when missing
+func (h *AuthHandler) currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Not authenticated",
+		})
+		return uuid.Nil, false
+	}
+
+	id, ok := userID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Invalid user ID format",
+		})
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+// frontendURL returns the first configured CORS origin, used for post-login redirects
+func (h *AuthHandler) frontendURL() string {
+	frontendURL := "http://localhost:3000"
+	origins := strings.Split(h.cfg.CORS.AllowedOrigins, ",")
+	if len(origins) > 0 {
+		frontendURL = origins[0]
+	}
+	return frontendURL
+}
+
+// startMFAChallenge decides whether a new session must pass MFA before it counts
+// as verified. Sessions on a trusted device are verified straight away and get
+// an upgraded access token; other accounts with MFA enabled are sent to
+// POST /auth/mfa/verify-login.
+func (h *AuthHandler) startMFAChallenge(c *gin.Context, user *models.User, accessToken, refreshToken string) (string, bool) {
+	if h.mfaService == nil {
+		return accessToken, false
+	}
+
+	ctx := c.Request.Context()
+	_, enabled, _, err := h.mfaService.CheckMFARequired(ctx, user.ID)
+	if err != nil {
+		// The session stays unverified; ask for the code rather than guess
+		log.Printf("Failed to check MFA status for user %s: %v", user.ID, err)
+		return accessToken, true
+	}
+	if !enabled {
+		return accessToken, false
+	}
+
+	fingerprint := services.GenerateDeviceFingerprint(c.GetHeader("User-Agent"), c.ClientIP())
+	if trusted, err := h.mfaService.IsTrustedDevice(ctx, user.ID, fingerprint); err == nil && trusted {
+		if verifiedToken, err := h.authService.VerifySessionMFA(ctx, user.ID, refreshToken); err == nil {
+			return verifiedToken, false
+		}
+	}
+
+	return accessToken, true
+}
+
+// mfaRequiredQuery flags a pending MFA challenge on a post-login redirect
+func mfaRequiredQuery(mfaRequired bool) string {
+	if !mfaRequired {
+		return ""
+	}
+	return "&mfa_required=true"
+}
+
 // setAuthCookies sets authentication cookies
 func (h *AuthHandler) setAuthCookies(c *gin.Context, accessToken, refreshToken string) {
 	isProduction := h.cfg.Server.GinMode == "release"
@@ -367,6 +739,38 @@ func (h *AuthHandler) setAuthCookies(c *gin.Context, accessToken, refreshToken s
 	)
 }
 
+// attributeShareSignup credits the share link a new user arrived through
+func (h *AuthHandler) attributeShareSignup(c *gin.Context, user *models.User) {
+	if h.shareLinkService == nil {
+		return
+	}
+	code, err := c.Cookie(ShareRefCookie)
+	if err != nil || code == "" {
+		return
+	}
+	if err := h.shareLinkService.AttributeSignup(c.Request.Context(), code, user); err != nil {
+		log.Printf("Failed to attribute sign-up to share link %s: %v", code, err)
+	}
+}
+
+// recordAccountSignals links the sign-in's IP address, device fingerprint and
+// email domain to the user for ban evasion detection
+func (h *AuthHandler) recordAccountSignals(c *gin.Context, user *models.User) {
+	if h.banEvasionService == nil {
+		return
+	}
+	signals := services.SignInSignals{
+		IPAddress: c.ClientIP(),
+		Email:     user.Email,
+	}
+	if header := h.cfg.BanEvasion.FingerprintHeader; header != "" {
+		signals.DeviceFingerprint = c.GetHeader(header)
+	}
+	if err := h.banEvasionService.RecordSignIn(c.Request.Context(), user.ID, signals); err != nil {
+		log.Printf("Failed to record sign-in signals for user %s: %v", user.ID, err)
+	}
+}
+
 // clearAuthCookies clears authentication cookies
 func (h *AuthHandler) clearAuthCookies(c *gin.Context) {
 	c.SetCookie("access_token", "", -1, "/", "", false, true)
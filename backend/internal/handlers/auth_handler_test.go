@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/services"
+	jwtpkg "github.com/subculture-collective/clipper/pkg/jwt"
+)
+
+// MockRefreshTokenRepository is a mock implementation of services.RefreshTokenRepositoryInterface
+type MockRefreshTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockRefreshTokenRepository) Create(ctx context.Context, userID, sessionID uuid.UUID, tokenHash string, expiresAt time.Time, client models.SessionClient) error {
+	args := m.Called(ctx, userID, sessionID, tokenHash, expiresAt, client)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (uuid.UUID, time.Time, bool, error) {
+	args := m.Called(ctx, tokenHash)
+	return args.Get(0).(uuid.UUID), args.Get(1).(time.Time), args.Bool(2), args.Error(3)
+}
+
+func (m *MockRefreshTokenRepository) GetSessionID(ctx context.Context, tokenHash string) (uuid.UUID, error) {
+	args := m.Called(ctx, tokenHash)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) Revoke(ctx context.Context, tokenHash string) error {
+	args := m.Called(ctx, tokenHash)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenRepository) Rotate(ctx context.Context, oldTokenHash string, userID, sessionID uuid.UUID, newTokenHash string, expiresAt time.Time, client models.SessionClient) error {
+	args := m.Called(ctx, oldTokenHash, userID, sessionID, newTokenHash, expiresAt, client)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenRepository) IsRotated(ctx context.Context, tokenHash string) (bool, error) {
+	args := m.Called(ctx, tokenHash)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) IsMFAVerified(ctx context.Context, tokenHash string) (bool, error) {
+	args := m.Called(ctx, tokenHash)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) MarkSessionMFAVerified(ctx context.Context, sessionID uuid.UUID) error {
+	args := m.Called(ctx, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenRepository) RevokeSession(ctx context.Context, sessionID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, sessionID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestAuthHandler_RefreshTokenReuseClearsCookies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	privateKeyPEM, _, err := jwtpkg.GenerateRSAKeyPair()
+	require.NoError(t, err)
+	manager, err := jwtpkg.NewManager(privateKeyPEM)
+	require.NoError(t, err)
+
+	userID := uuid.New()
+	sessionID := uuid.New()
+	refreshToken, err := manager.GenerateRefreshToken(userID)
+	require.NoError(t, err)
+	tokenHash := jwtpkg.HashToken(refreshToken)
+
+	// The presented token was already rotated, so it is a replay
+	refreshTokenRepo := new(MockRefreshTokenRepository)
+	refreshTokenRepo.On("GetByHash", mock.Anything, tokenHash).Return(userID, time.Now().Add(time.Hour), true, nil)
+	refreshTokenRepo.On("GetSessionID", mock.Anything, tokenHash).Return(sessionID, nil)
+	refreshTokenRepo.On("IsRotated", mock.Anything, tokenHash).Return(true, nil)
+	refreshTokenRepo.On("RevokeSession", mock.Anything, sessionID).Return(int64(1), nil).Once()
+
+	authService := services.NewAuthService(&config.Config{}, nil, refreshTokenRepo, nil, manager)
+	handler := NewAuthHandler(authService, &config.Config{})
+
+	router := gin.New()
+	router.POST("/auth/refresh", handler.RefreshToken)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/refresh", nil)
+	req.AddCookie(&http.Cookie{Name: "refresh_token", Value: refreshToken})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	cleared := map[string]bool{}
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.MaxAge < 0 && cookie.Value == "" {
+			cleared[cookie.Name] = true
+		}
+	}
+	assert.True(t, cleared["access_token"], "access token cookie is cleared")
+	assert.True(t, cleared["refresh_token"], "refresh token cookie is cleared")
+
+	refreshTokenRepo.AssertExpectations(t)
+}
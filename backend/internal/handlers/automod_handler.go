@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// AutomodHandler handles admin management of automoderation rules
+type AutomodHandler struct {
+	automodService *services.AutomodService
+}
+
+// NewAutomodHandler creates a new automoderation handler
+func NewAutomodHandler(automodService *services.AutomodService) *AutomodHandler {
+	return &AutomodHandler{
+		automodService: automodService,
+	}
+}
+
+// ListRules lists automoderation rules with their match counts
+// GET /api/v1/admin/automod/rules
+func (h *AutomodHandler) ListRules(c *gin.Context) {
+	rules, err := h.automodService.ListRules(c.Request.Context())
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve automod rules")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": rules})
+}
+
+// GetRule returns an automoderation rule
+// GET /api/v1/admin/automod/rules/:id
+func (h *AutomodHandler) GetRule(c *gin.Context) {
+	ruleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rule ID"})
+		return
+	}
+
+	rule, err := h.automodService.GetRule(c.Request.Context(), ruleID)
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve automod rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// CreateRule creates a rule flagging, holding, shadow-hiding or removing matching comments and submissions
+// POST /api/v1/admin/automod/rules
+func (h *AutomodHandler) CreateRule(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	adminID := userIDVal.(uuid.UUID)
+
+	var req models.AutomodRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.automodService.CreateRule(c.Request.Context(), adminID, &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to create automod rule")
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+// UpdateRule replaces an automoderation rule's conditions, action and status
+// PUT /api/v1/admin/automod/rules/:id
+func (h *AutomodHandler) UpdateRule(c *gin.Context) {
+	ruleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rule ID"})
+		return
+	}
+
+	var req models.AutomodRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.automodService.UpdateRule(c.Request.Context(), ruleID, &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to update automod rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// DeleteRule deletes an automoderation rule and the community overrides of it
+// DELETE /api/v1/admin/automod/rules/:id
+func (h *AutomodHandler) DeleteRule(c *gin.Context) {
+	ruleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rule ID"})
+		return
+	}
+
+	if err := h.automodService.DeleteRule(c.Request.Context(), ruleID); err != nil {
+		h.respondError(c, err, "Failed to delete automod rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Automod rule deleted"})
+}
+
+// respondError maps automoderation rule errors to HTTP responses
+func (h *AutomodHandler) respondError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, repository.ErrAutomodRuleNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrAutomodCommunityNotFound),
+		errors.Is(err, services.ErrAutomodRuleNoConditions),
+		errors.Is(err, services.ErrAutomodRuleInvalidPattern),
+		errors.Is(err, services.ErrAutomodRuleNoLinkDomains),
+		errors.Is(err, services.ErrAutomodRuleInvalidOverride):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// banEvasionFlagStatuses are the status filters accepted when listing flags
+var banEvasionFlagStatuses = map[string]bool{
+	"":                             true,
+	models.BanEvasionFlagPending:   true,
+	models.BanEvasionFlagConfirmed: true,
+	models.BanEvasionFlagDismissed: true,
+}
+
+// BanEvasionHandler serves the admin review of accounts flagged for evading bans
+type BanEvasionHandler struct {
+	banEvasionService *services.BanEvasionService
+}
+
+// NewBanEvasionHandler creates a new ban evasion handler
+func NewBanEvasionHandler(banEvasionService *services.BanEvasionService) *BanEvasionHandler {
+	return &BanEvasionHandler{banEvasionService: banEvasionService}
+}
+
+// ListFlags handles GET /api/v1/admin/ban-evasion/flags
+// Query: status (pending, confirmed, dismissed; default pending), user_id, limit, offset
+func (h *BanEvasionHandler) ListFlags(c *gin.Context) {
+	status := c.DefaultQuery("status", models.BanEvasionFlagPending)
+	if status == "all" {
+		status = ""
+	}
+	if !banEvasionFlagStatuses[status] {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid status. Must be pending, confirmed, dismissed or all",
+		})
+		return
+	}
+
+	filters := models.BanEvasionFlagFilters{Status: status}
+	if userIDStr := c.Query("user_id"); userIDStr != "" {
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid user_id",
+			})
+			return
+		}
+		filters.UserID = &userID
+	}
+	filters.Limit, _ = strconv.Atoi(c.DefaultQuery("limit", "50"))
+	filters.Offset, _ = strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	flags, err := h.banEvasionService.ListFlags(c.Request.Context(), filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve ban evasion flags",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    flags,
+		"count":   len(flags),
+	})
+}
+
+// GetFlag handles GET /api/v1/admin/ban-evasion/flags/:id
+func (h *BanEvasionHandler) GetFlag(c *gin.Context) {
+	flagID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid flag ID",
+		})
+		return
+	}
+
+	flag, err := h.banEvasionService.GetFlag(c.Request.Context(), flagID)
+	if err != nil {
+		if errors.Is(err, repository.ErrBanEvasionFlagNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Flag not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve ban evasion flag",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    flag,
+	})
+}
+
+// ConfirmFlag handles POST /api/v1/admin/ban-evasion/flags/:id/confirm
+// Confirms the account is evading a ban and bans it permanently
+func (h *BanEvasionHandler) ConfirmFlag(c *gin.Context) {
+	h.reviewFlag(c, h.banEvasionService.ConfirmFlag)
+}
+
+// DismissFlag handles POST /api/v1/admin/ban-evasion/flags/:id/dismiss
+func (h *BanEvasionHandler) DismissFlag(c *gin.Context) {
+	h.reviewFlag(c, h.banEvasionService.DismissFlag)
+}
+
+// banEvasionReviewFunc confirms or dismisses a flag
+type banEvasionReviewFunc func(ctx context.Context, flagID, moderatorID uuid.UUID, notes *string) (*models.BanEvasionFlag, error)
+
+func (h *BanEvasionHandler) reviewFlag(c *gin.Context, review banEvasionReviewFunc) {
+	flagID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid flag ID",
+		})
+		return
+	}
+
+	moderatorIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Unauthorized",
+		})
+		return
+	}
+	moderatorID := moderatorIDVal.(uuid.UUID)
+
+	var req models.ReviewBanEvasionFlagRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	flag, err := review(c.Request.Context(), flagID, moderatorID, req.Notes)
+	if err != nil {
+		if errors.Is(err, repository.ErrBanEvasionFlagNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Flag not found or already reviewed",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to review ban evasion flag",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    flag,
+	})
+}
+
+// ListLinkedAccounts handles GET /api/v1/admin/ban-evasion/users/:id/linked-accounts
+// Lists the accounts that share sign-in signals with the user
+func (h *BanEvasionHandler) ListLinkedAccounts(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user ID",
+		})
+		return
+	}
+
+	accounts, err := h.banEvasionService.ListLinkedAccounts(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve linked accounts",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    accounts,
+		"count":   len(accounts),
+	})
+}
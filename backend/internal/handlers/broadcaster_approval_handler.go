@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// BroadcasterApprovalHandler handles the broadcaster-facing clip approval endpoints
+type BroadcasterApprovalHandler struct {
+	approvalService *services.BroadcasterApprovalService
+}
+
+// NewBroadcasterApprovalHandler creates a new broadcaster approval handler
+func NewBroadcasterApprovalHandler(approvalService *services.BroadcasterApprovalService) *BroadcasterApprovalHandler {
+	return &BroadcasterApprovalHandler{
+		approvalService: approvalService,
+	}
+}
+
+// GetPolicy returns the caller's clip approval policy
+// GET /api/v1/broadcasters/me/approval-policy
+func (h *BroadcasterApprovalHandler) GetPolicy(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	policy, err := h.approvalService.GetPolicy(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, err, "Failed to get approval policy")
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// UpdatePolicy turns clip approval on or off for the caller's channel
+// PUT /api/v1/broadcasters/me/approval-policy
+func (h *BroadcasterApprovalHandler) UpdatePolicy(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	var req models.UpdateBroadcasterApprovalPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	policy, err := h.approvalService.UpdatePolicy(c.Request.Context(), userID, &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to update approval policy")
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// ListQueue lists clips of the caller's channel awaiting their approval
+// GET /api/v1/broadcasters/me/approval-queue
+func (h *BroadcasterApprovalHandler) ListQueue(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	reviews, total, err := h.approvalService.ListQueue(c.Request.Context(), userID, page, limit)
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve approval queue")
+		return
+	}
+
+	totalPages := (total + limit - 1) / limit
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    reviews,
+		"meta": gin.H{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	})
+}
+
+// ApproveClip approves a held clip of the caller's channel
+// POST /api/v1/broadcasters/me/approval-queue/:submissionId/approve
+func (h *BroadcasterApprovalHandler) ApproveClip(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	submissionID, err := uuid.Parse(c.Param("submissionId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid submission ID"})
+		return
+	}
+
+	if err := h.approvalService.Approve(c.Request.Context(), userID, submissionID); err != nil {
+		h.respondError(c, err, "Failed to approve clip")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Clip approved"})
+}
+
+// RejectClip rejects a held clip of the caller's channel
+// POST /api/v1/broadcasters/me/approval-queue/:submissionId/reject
+func (h *BroadcasterApprovalHandler) RejectClip(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	submissionID, err := uuid.Parse(c.Param("submissionId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid submission ID"})
+		return
+	}
+
+	var req models.RejectBroadcasterClipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A rejection reason is required"})
+		return
+	}
+
+	if err := h.approvalService.Reject(c.Request.Context(), userID, submissionID, req.Reason); err != nil {
+		h.respondError(c, err, "Failed to reject clip")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Clip rejected"})
+}
+
+// respondError maps broadcaster approval errors to HTTP responses
+func (h *BroadcasterApprovalHandler) respondError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrNotVerifiedBroadcaster):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidAutoApproveDays):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrBroadcasterReviewNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Clip not found in your approval queue"})
+	case errors.Is(err, services.ErrBroadcasterReviewResolved):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
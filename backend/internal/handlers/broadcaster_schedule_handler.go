@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// BroadcasterScheduleHandler handles broadcaster schedules, the upcoming
+// streams feed and schedule reminders
+type BroadcasterScheduleHandler struct {
+	scheduleService *services.BroadcasterScheduleService
+}
+
+// NewBroadcasterScheduleHandler creates a new broadcaster schedule handler
+func NewBroadcasterScheduleHandler(scheduleService *services.BroadcasterScheduleService) *BroadcasterScheduleHandler {
+	return &BroadcasterScheduleHandler{
+		scheduleService: scheduleService,
+	}
+}
+
+// GetSchedule returns a broadcaster's scheduled streams for the next days
+// GET /api/v1/broadcasters/:id/schedule
+func (h *BroadcasterScheduleHandler) GetSchedule(c *gin.Context) {
+	broadcasterID := c.Param("id")
+	if broadcasterID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "broadcaster_id is required"})
+		return
+	}
+
+	days, _ := strconv.Atoi(c.DefaultQuery("days", strconv.Itoa(services.UpcomingFeedDefaultDays)))
+
+	schedule, err := h.scheduleService.GetSchedule(c.Request.Context(), broadcasterID, days)
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve broadcaster schedule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    schedule,
+	})
+}
+
+// GetUpcomingFeed returns scheduled streams of the broadcasters the user follows
+// GET /api/v1/feed/upcoming
+func (h *BroadcasterScheduleHandler) GetUpcomingFeed(c *gin.Context) {
+	userID, ok := scheduleUserID(c)
+	if !ok {
+		return
+	}
+
+	days, _ := strconv.Atoi(c.DefaultQuery("days", strconv.Itoa(services.UpcomingFeedDefaultDays)))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit < 1 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	streams, err := h.scheduleService.GetUpcomingFeed(c.Request.Context(), userID, days, limit, offset)
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve upcoming streams")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    streams,
+		"meta": gin.H{
+			"limit":  limit,
+			"offset": offset,
+			"count":  len(streams),
+		},
+	})
+}
+
+// GetReminder returns the user's reminder setting for a broadcaster; data is
+// null when reminders are off
+// GET /api/v1/broadcasters/:id/schedule/reminder
+func (h *BroadcasterScheduleHandler) GetReminder(c *gin.Context) {
+	userID, ok := scheduleUserID(c)
+	if !ok {
+		return
+	}
+
+	reminder, err := h.scheduleService.GetReminder(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrScheduleReminderNotFound) {
+			c.JSON(http.StatusOK, gin.H{"success": true, "data": nil})
+			return
+		}
+		h.respondError(c, err, "Failed to retrieve schedule reminder")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    reminder,
+	})
+}
+
+// SetReminder turns on reminders before a followed broadcaster's scheduled streams
+// PUT /api/v1/broadcasters/:id/schedule/reminder
+func (h *BroadcasterScheduleHandler) SetReminder(c *gin.Context) {
+	userID, ok := scheduleUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateScheduleReminderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": services.ErrScheduleReminderInvalidMinutes.Error()})
+		return
+	}
+
+	reminder, err := h.scheduleService.SetReminder(c.Request.Context(), userID, c.Param("id"), req.MinutesBefore)
+	if err != nil {
+		h.respondError(c, err, "Failed to save schedule reminder")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    reminder,
+	})
+}
+
+// DeleteReminder turns off reminders for a broadcaster
+// DELETE /api/v1/broadcasters/:id/schedule/reminder
+func (h *BroadcasterScheduleHandler) DeleteReminder(c *gin.Context) {
+	userID, ok := scheduleUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.scheduleService.DeleteReminder(c.Request.Context(), userID, c.Param("id")); err != nil {
+		h.respondError(c, err, "Failed to delete schedule reminder")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Schedule reminders turned off",
+	})
+}
+
+// scheduleUserID returns the authenticated user's ID, writing the error
+// response when there is none
+func scheduleUserID(c *gin.Context) (uuid.UUID, bool) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return uuid.Nil, false
+	}
+	userID, ok := userIDVal.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid user ID"})
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+func (h *BroadcasterScheduleHandler) respondError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, repository.ErrScheduleReminderNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrScheduleReminderInvalidMinutes):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrScheduleReminderNotFollowing):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
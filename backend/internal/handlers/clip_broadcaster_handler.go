@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// ClipBroadcasterHandler handles the broadcasters credited on clips
+type ClipBroadcasterHandler struct {
+	clipBroadcasterService *services.ClipBroadcasterService
+}
+
+// NewClipBroadcasterHandler creates a new clip broadcaster handler
+func NewClipBroadcasterHandler(clipBroadcasterService *services.ClipBroadcasterService) *ClipBroadcasterHandler {
+	return &ClipBroadcasterHandler{
+		clipBroadcasterService: clipBroadcasterService,
+	}
+}
+
+// GetClipBroadcasters returns a clip's primary broadcaster and the broadcasters featured in it
+// GET /api/v1/clips/:id/broadcasters
+func (h *ClipBroadcasterHandler) GetClipBroadcasters(c *gin.Context) {
+	clipID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid clip ID"})
+		return
+	}
+
+	attribution, err := h.clipBroadcasterService.GetClipBroadcasters(c.Request.Context(), clipID)
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve clip broadcasters")
+		return
+	}
+
+	c.JSON(http.StatusOK, attribution)
+}
+
+// UpdateClipBroadcasters replaces a clip's primary and featured broadcasters
+// PUT /api/v1/admin/clips/:id/broadcasters
+func (h *ClipBroadcasterHandler) UpdateClipBroadcasters(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	moderatorID := userIDVal.(uuid.UUID)
+
+	clipID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid clip ID"})
+		return
+	}
+
+	var req models.UpdateClipBroadcastersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	attribution, err := h.clipBroadcasterService.UpdateClipBroadcasters(c.Request.Context(), moderatorID, clipID, &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to update clip broadcasters")
+		return
+	}
+
+	c.JSON(http.StatusOK, attribution)
+}
+
+// respondError maps clip attribution errors to HTTP responses
+func (h *ClipBroadcasterHandler) respondError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, repository.ErrClipBroadcastersClipNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrClipBroadcasterMissing),
+		errors.Is(err, services.ErrClipBroadcasterDuplicate),
+		errors.Is(err, services.ErrClipBroadcasterTooMany):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// ClipChangefeedHandler serves the changefeed of public clip changes to data partners
+type ClipChangefeedHandler struct {
+	changefeedService *services.ClipChangefeedService
+}
+
+// NewClipChangefeedHandler creates a new clip changefeed handler
+func NewClipChangefeedHandler(changefeedService *services.ClipChangefeedService) *ClipChangefeedHandler {
+	return &ClipChangefeedHandler{
+		changefeedService: changefeedService,
+	}
+}
+
+// GetClipChanges returns create, update and delete events for public clips
+// after the since sequence number, oldest first. Clients resume by passing
+// meta.next_since back as since.
+// GET /api/v1/changefeed/clips
+func (h *ClipChangefeedHandler) GetClipChanges(c *gin.Context) {
+	since, err := strconv.ParseInt(c.DefaultQuery("since", "0"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": services.ErrInvalidChangefeedCursor.Error()})
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(models.ClipChangefeedDefaultLimit)))
+
+	page, err := h.changefeedService.GetChanges(c.Request.Context(), since, limit)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidChangefeedCursor) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve clip changes"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    page.Events,
+		"meta": gin.H{
+			"since":      since,
+			"next_since": page.NextSince,
+			"has_more":   page.HasMore,
+			"count":      len(page.Events),
+		},
+	})
+}
@@ -0,0 +1,353 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// ClipRepositoryForEmbeds defines clip repo methods needed by ClipEmbedHandler.
+type ClipRepositoryForEmbeds interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Clip, error)
+}
+
+// ClipEmbedHandler serves the embeddable clip player and manages which sites may embed it
+type ClipEmbedHandler struct {
+	embedService *services.ClipEmbedService
+	clipRepo     ClipRepositoryForEmbeds
+	cfg          *config.Config
+}
+
+// NewClipEmbedHandler creates a new clip embed handler
+func NewClipEmbedHandler(embedService *services.ClipEmbedService, clipRepo ClipRepositoryForEmbeds, cfg *config.Config) *ClipEmbedHandler {
+	return &ClipEmbedHandler{
+		embedService: embedService,
+		clipRepo:     clipRepo,
+		cfg:          cfg,
+	}
+}
+
+// GetEmbedPlayer renders the player for embedding a clip in an iframe on another site
+// GET /embed/clips/:id
+func (h *ClipEmbedHandler) GetEmbedPlayer(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	clip := h.embeddableClip(c)
+	if clip == nil {
+		c.String(http.StatusNotFound, "Clip not found")
+		return
+	}
+
+	// Fail open: a rules lookup error shouldn't take down every embed
+	domain := services.EmbedDomainFromReferrer(c.Request.Referer())
+	allowed, err := h.embedService.CheckEmbed(ctx, clip.BroadcasterID, domain)
+	if err != nil {
+		log.Printf("Failed to check embed rules for clip %s: %v", clip.ID, err)
+		allowed = true
+	}
+	ancestors, err := h.embedService.AllowedAncestors(ctx, clip.BroadcasterID)
+	if err != nil {
+		log.Printf("Failed to list embed ancestors for clip %s: %v", clip.ID, err)
+	}
+	h.embedService.RecordLoad(clip, domain, !allowed)
+
+	// The global security headers forbid framing; this page exists to be framed
+	frameAncestors := "*"
+	if len(ancestors) > 0 {
+		frameAncestors = strings.Join(ancestors, " ")
+	}
+	c.Writer.Header().Del("X-Frame-Options")
+	c.Header("Content-Security-Policy", "default-src 'self'; "+
+		"style-src 'self' 'unsafe-inline'; "+
+		"img-src 'self' data: https:; "+
+		"frame-src https://clips.twitch.tv; "+
+		"object-src 'none'; "+
+		"base-uri 'none'; "+
+		"frame-ancestors "+frameAncestors)
+
+	data := models.ClipEmbedPageData{
+		Title:   clip.Title,
+		OpenURL: "/embed/clips/" + clip.ID.String() + "/open?domain=" + url.QueryEscape(domain),
+		Blocked: !allowed,
+	}
+	if !allowed {
+		c.HTML(http.StatusForbidden, "embed.html", data)
+		return
+	}
+
+	player := url.Values{}
+	player.Set("clip", clip.TwitchClipID)
+	player.Add("parent", h.host())
+	if domain != models.EmbedDomainUnknown {
+		player.Add("parent", domain)
+	}
+	data.PlayerURL = "https://clips.twitch.tv/embed?" + player.Encode()
+
+	c.HTML(http.StatusOK, "embed.html", data)
+}
+
+// OpenEmbeddedClip records a click from the embed player and opens the clip on clpr.tv
+// GET /embed/clips/:id/open
+func (h *ClipEmbedHandler) OpenEmbeddedClip(c *gin.Context) {
+	clip := h.embeddableClip(c)
+	if clip == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "clip not found"})
+		return
+	}
+
+	// The click comes from inside our iframe, so the player passes on the
+	// embedding site
+	domain, err := services.NormalizeEmbedDomain(c.Query("domain"))
+	if err != nil {
+		domain = models.EmbedDomainUnknown
+	}
+	h.embedService.RecordClick(clip, domain)
+
+	target := url.Values{}
+	target.Set("utm_source", domain)
+	target.Set("utm_medium", "embed")
+	c.Redirect(http.StatusFound, h.baseURL()+"/clip/"+clip.ID.String()+"?"+target.Encode())
+}
+
+// embeddableClip returns the clip named in the path, or nil if it can't be shown
+func (h *ClipEmbedHandler) embeddableClip(c *gin.Context) *models.Clip {
+	clipID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return nil
+	}
+	clip, err := h.clipRepo.GetByID(c.Request.Context(), clipID)
+	if err != nil || clip.IsRemoved || clip.IsHidden {
+		return nil
+	}
+	return clip
+}
+
+func (h *ClipEmbedHandler) baseURL() string {
+	baseURL := strings.TrimRight(h.cfg.Server.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = "https://clpr.tv"
+	}
+	return baseURL
+}
+
+// host returns our own hostname, which Twitch requires as a parent of its player
+func (h *ClipEmbedHandler) host() string {
+	parsed, err := url.Parse(h.baseURL())
+	if err != nil || parsed.Hostname() == "" {
+		return "clpr.tv"
+	}
+	return parsed.Hostname()
+}
+
+// ListMyDomainRules returns the embed domain rules of the caller's channel
+// GET /api/v1/broadcasters/me/embed-domains
+func (h *ClipEmbedHandler) ListMyDomainRules(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	rules, err := h.embedService.ListBroadcasterRules(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve embed domain rules")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": rules})
+}
+
+// SetMyDomainRule allows or blocks a site from embedding the caller's clips
+// POST /api/v1/broadcasters/me/embed-domains
+func (h *ClipEmbedHandler) SetMyDomainRule(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	var req models.CreateEmbedDomainRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	rule, err := h.embedService.SetBroadcasterRule(c.Request.Context(), userID, &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to save embed domain rule")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": rule})
+}
+
+// DeleteMyDomainRule deletes one of the caller's embed domain rules
+// DELETE /api/v1/broadcasters/me/embed-domains/:id
+func (h *ClipEmbedHandler) DeleteMyDomainRule(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	ruleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rule ID"})
+		return
+	}
+
+	if err := h.embedService.DeleteBroadcasterRule(c.Request.Context(), userID, ruleID); err != nil {
+		h.respondError(c, err, "Failed to delete embed domain rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Embed domain rule deleted"})
+}
+
+// GetMyEmbedReport returns the top sites embedding the caller's clips
+// GET /api/v1/broadcasters/me/embed-report
+func (h *ClipEmbedHandler) GetMyEmbedReport(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	days, limit, ok := parseEmbedReportQuery(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.embedService.GetBroadcasterReport(c.Request.Context(), userID, days, limit)
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve embed report")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
+
+// ListDomainRules returns the platform-wide embed domain rules, or a broadcaster's
+// GET /api/v1/admin/embeds/domains
+func (h *ClipEmbedHandler) ListDomainRules(c *gin.Context) {
+	rules, err := h.embedService.ListRules(c.Request.Context(), optionalQuery(c, "broadcaster_id"))
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve embed domain rules")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": rules})
+}
+
+// SetDomainRule allows or blocks a site platform-wide, or for one broadcaster
+// POST /api/v1/admin/embeds/domains
+func (h *ClipEmbedHandler) SetDomainRule(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	adminID := userIDVal.(uuid.UUID)
+
+	var req models.CreateEmbedDomainRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	rule, err := h.embedService.SetRule(c.Request.Context(), adminID, &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to save embed domain rule")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": rule})
+}
+
+// DeleteDomainRule deletes an embed domain rule of any scope
+// DELETE /api/v1/admin/embeds/domains/:id
+func (h *ClipEmbedHandler) DeleteDomainRule(c *gin.Context) {
+	ruleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rule ID"})
+		return
+	}
+
+	if err := h.embedService.DeleteRule(c.Request.Context(), ruleID); err != nil {
+		h.respondError(c, err, "Failed to delete embed domain rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Embed domain rule deleted"})
+}
+
+// GetPartnerReport returns the top sites embedding clips, for one broadcaster or all
+// GET /api/v1/admin/embeds/report
+func (h *ClipEmbedHandler) GetPartnerReport(c *gin.Context) {
+	days, limit, ok := parseEmbedReportQuery(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.embedService.GetPartnerReport(c.Request.Context(), optionalQuery(c, "broadcaster_id"), days, limit)
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve embed report")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
+
+// parseEmbedReportQuery reads the days and limit of a report, leaving zero for defaults
+func parseEmbedReportQuery(c *gin.Context) (days, limit int, ok bool) {
+	var err error
+	if value := c.Query("days"); value != "" {
+		if days, err = strconv.Atoi(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid days"})
+			return 0, 0, false
+		}
+	}
+	if value := c.Query("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return 0, 0, false
+		}
+	}
+	return days, limit, true
+}
+
+func optionalQuery(c *gin.Context, key string) *string {
+	value := c.Query(key)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// respondError maps clip embed errors to HTTP responses
+func (h *ClipEmbedHandler) respondError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrEmbedNotVerifiedBroadcaster):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidEmbedDomain), errors.Is(err, services.ErrInvalidEmbedReportDays):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrEmbedDomainRuleNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
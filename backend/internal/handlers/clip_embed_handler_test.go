@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/middleware"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// MockClipEmbedRepository is a mock implementation of services.ClipEmbedRepositoryInterface
+type MockClipEmbedRepository struct {
+	mock.Mock
+}
+
+func (m *MockClipEmbedRepository) ListRules(ctx context.Context, broadcasterID *string) ([]models.EmbedDomainRule, error) {
+	args := m.Called(ctx, broadcasterID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.EmbedDomainRule), args.Error(1)
+}
+
+func (m *MockClipEmbedRepository) ListRulesForEmbed(ctx context.Context, broadcasterID string) ([]models.EmbedDomainRule, error) {
+	args := m.Called(ctx, broadcasterID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.EmbedDomainRule), args.Error(1)
+}
+
+func (m *MockClipEmbedRepository) UpsertRule(ctx context.Context, rule *models.EmbedDomainRule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockClipEmbedRepository) DeleteRule(ctx context.Context, id uuid.UUID) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockClipEmbedRepository) DeleteBroadcasterRule(ctx context.Context, id uuid.UUID, broadcasterID string) (bool, error) {
+	args := m.Called(ctx, id, broadcasterID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockClipEmbedRepository) AddDailyStats(ctx context.Context, stats []models.ClipEmbedDailyStat) error {
+	args := m.Called(ctx, stats)
+	return args.Error(0)
+}
+
+func (m *MockClipEmbedRepository) ListSiteStats(ctx context.Context, broadcasterID *string, since time.Time) ([]models.EmbedSiteStats, error) {
+	args := m.Called(ctx, broadcasterID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.EmbedSiteStats), args.Error(1)
+}
+
+func (m *MockClipEmbedRepository) DeleteDailyStatsBefore(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockClipRepositoryForEmbeds is a mock implementation of ClipRepositoryForEmbeds
+type MockClipRepositoryForEmbeds struct {
+	mock.Mock
+}
+
+func (m *MockClipRepositoryForEmbeds) GetByID(ctx context.Context, id uuid.UUID) (*models.Clip, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Clip), args.Error(1)
+}
+
+func setupClipEmbedRouter(rules []models.EmbedDomainRule, clip *models.Clip) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{}
+	cfg.Server.BaseURL = "https://clpr.tv"
+
+	ruleRepo := new(MockClipEmbedRepository)
+	ruleRepo.On("ListRules", mock.Anything, mock.Anything).Return(rules, nil)
+	ruleRepo.On("ListRulesForEmbed", mock.Anything, mock.Anything).Return(rules, nil)
+	clipRepo := new(MockClipRepositoryForEmbeds)
+	clipRepo.On("GetByID", mock.Anything, clip.ID).Return(clip, nil)
+	clipRepo.On("GetByID", mock.Anything, mock.Anything).Return(nil, errors.New("clip not found"))
+
+	handler := NewClipEmbedHandler(services.NewClipEmbedService(ruleRepo, nil), clipRepo, cfg)
+
+	r := gin.New()
+	r.SetHTMLTemplate(template.Must(template.New("embed.html").Parse(`{{if .Blocked}}blocked{{else}}{{.PlayerURL}}{{end}}`)))
+	r.Use(middleware.SecurityHeadersMiddleware(cfg))
+	r.GET("/embed/clips/:id", handler.GetEmbedPlayer)
+	r.GET("/embed/clips/:id/open", handler.OpenEmbeddedClip)
+	return r
+}
+
+func TestClipEmbedHandler_GetEmbedPlayer_AllowsFraming(t *testing.T) {
+	broadcasterID := "12345"
+	clip := &models.Clip{ID: uuid.New(), TwitchClipID: "FunnyClip-abc", BroadcasterID: &broadcasterID}
+	r := setupClipEmbedRouter(nil, clip)
+
+	req := httptest.NewRequest(http.MethodGet, "/embed/clips/"+clip.ID.String(), nil)
+	req.Header.Set("Referer", "https://www.fansite.com/")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("X-Frame-Options"))
+	assert.Contains(t, w.Header().Get("Content-Security-Policy"), "frame-ancestors *")
+	assert.NotContains(t, w.Header().Get("Content-Security-Policy"), "frame-ancestors 'none'")
+	assert.Contains(t, w.Body.String(), "clip=FunnyClip-abc")
+	assert.Contains(t, w.Body.String(), "parent=clpr.tv")
+	assert.Contains(t, w.Body.String(), "parent=fansite.com")
+}
+
+func TestClipEmbedHandler_GetEmbedPlayer_EnforcesAllowlist(t *testing.T) {
+	broadcasterID := "12345"
+	clip := &models.Clip{ID: uuid.New(), TwitchClipID: "FunnyClip-abc", BroadcasterID: &broadcasterID}
+	rules := []models.EmbedDomainRule{
+		{ID: uuid.New(), BroadcasterID: &broadcasterID, Domain: "fansite.com", Rule: models.EmbedDomainRuleAllow},
+	}
+	r := setupClipEmbedRouter(rules, clip)
+
+	req := httptest.NewRequest(http.MethodGet, "/embed/clips/"+clip.ID.String(), nil)
+	req.Header.Set("Referer", "https://rival.com/")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, "blocked", w.Body.String())
+	assert.Contains(t, w.Header().Get("Content-Security-Policy"), "frame-ancestors https://fansite.com https://*.fansite.com")
+}
+
+func TestClipEmbedHandler_GetEmbedPlayer_HiddenClip(t *testing.T) {
+	clip := &models.Clip{ID: uuid.New(), IsHidden: true}
+	r := setupClipEmbedRouter(nil, clip)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/embed/clips/"+clip.ID.String(), nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestClipEmbedHandler_OpenEmbeddedClip(t *testing.T) {
+	clip := &models.Clip{ID: uuid.New()}
+	r := setupClipEmbedRouter(nil, clip)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/embed/clips/"+clip.ID.String()+"/open?domain=fansite.com", nil))
+
+	require.Equal(t, http.StatusFound, w.Code)
+	location, err := url.Parse(w.Header().Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, "/clip/"+clip.ID.String(), location.Path)
+	assert.Equal(t, "fansite.com", location.Query().Get("utm_source"))
+	assert.Equal(t, "embed", location.Query().Get("utm_medium"))
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/subculture-collective/clipper/config"
 	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/pagination"
 	"github.com/subculture-collective/clipper/internal/repository"
 	"github.com/subculture-collective/clipper/internal/services"
 )
@@ -112,6 +113,7 @@ func (h *ClipSyncHandler) TriggerSync(c *gin.Context) {
 		"clips_created": stats.ClipsCreated,
 		"clips_updated": stats.ClipsUpdated,
 		"clips_skipped": stats.ClipsSkipped,
+		"clips_routed":  stats.ClipsRouted,
 		"errors":        stats.Errors,
 		"duration_ms":   stats.EndTime.Sub(stats.StartTime).Milliseconds(),
 		"started_at":    stats.StartTime,
@@ -166,6 +168,7 @@ type ClipHandler struct {
 	authService *services.AuthService
 	cdnProvider services.CDNProvider
 	jobService  *services.ClipExtractionJobService
+	playback    *services.ClipPlaybackService
 }
 
 // NewClipHandler creates a new ClipHandler
@@ -201,6 +204,13 @@ func WithClipExtractionJobService(service *services.ClipExtractionJobService) Cl
 	}
 }
 
+// WithClipPlaybackService enables playback fallback configs and embed failure reporting.
+func WithClipPlaybackService(service *services.ClipPlaybackService) ClipHandlerOption {
+	return func(h *ClipHandler) {
+		h.playback = service
+	}
+}
+
 // StandardResponse represents a standard API response
 type StandardResponse struct {
 	Success bool        `json:"success"`
@@ -225,6 +235,12 @@ type PaginationMeta struct {
 	HasPrev    bool `json:"has_prev"`
 }
 
+// CursorPaginationMeta contains pagination metadata for cursor-paginated lists
+type CursorPaginationMeta struct {
+	Limit int `json:"limit"`
+	pagination.PageInfo
+}
+
 // ListClips handles GET /clips
 func (h *ClipHandler) ListClips(c *gin.Context) {
 	// Parse query parameters
@@ -238,6 +254,12 @@ func (h *ClipHandler) ListClips(c *gin.Context) {
 	language := c.Query("language")
 	submittedByUserID := c.Query("submitted_by_user_id")
 	top10kStreamers := c.Query("top10k_streamers") == "true"
+	// Game patch filters: one patch, or the patch each game is on now
+	patchID := c.Query("patch_id")
+	currentPatch := c.Query("current_patch") == "true"
+	// Accessibility filters: only captioned clips, or no clips with a photosensitivity warning
+	captioned := c.Query("captioned") == "true"
+	hidePhotosensitive := c.Query("hide_photosensitive") == "true"
 	// By default, only show user-submitted clips. Set show_all_clips=true to include scraped clips (for discovery)
 	showAllClips := c.Query("show_all_clips") == "true"
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
@@ -265,11 +287,30 @@ func (h *ClipHandler) ListClips(c *gin.Context) {
 		}
 	}
 
+	if patchID != "" {
+		if _, err := uuid.Parse(patchID); err != nil {
+			c.JSON(http.StatusBadRequest, StandardResponse{
+				Success: false,
+				Error: &ErrorInfo{
+					Code:    "INVALID_UUID",
+					Message: "Invalid patch_id: must be a valid UUID",
+				},
+			})
+			return
+		}
+	}
+
 	// Build filters
 	filters := repository.ClipFilters{
-		Sort:              sort,
-		Top10kStreamers:   top10kStreamers,
-		UserSubmittedOnly: !showAllClips, // Only show user-submitted unless explicitly requesting all
+		Sort:               sort,
+		Top10kStreamers:    top10kStreamers,
+		UserSubmittedOnly:  !showAllClips, // Only show user-submitted unless explicitly requesting all
+		CurrentPatch:       currentPatch,
+		CaptionedOnly:      captioned,
+		HidePhotosensitive: hidePhotosensitive,
+	}
+	if patchID != "" {
+		filters.PatchID = &patchID
 	}
 
 	if gameID != "" {
@@ -318,6 +359,13 @@ func (h *ClipHandler) ListClips(c *gin.Context) {
 			userID = &uid
 		}
 	}
+	filters.ViewerID = userID
+
+	// A cursor parameter, empty for the first page, selects cursor pagination
+	if cursor, ok := c.GetQuery("cursor"); ok {
+		h.listClipsCursor(c, filters, cursor, limit, userID)
+		return
+	}
 
 	// Fetch clips
 	clips, total, err := h.clipService.ListClips(c.Request.Context(), filters, page, limit, userID)
@@ -350,6 +398,43 @@ func (h *ClipHandler) ListClips(c *gin.Context) {
 	})
 }
 
+// listClipsCursor responds to ListClips with a page of clips continuing after
+// a cursor from a previous page
+func (h *ClipHandler) listClipsCursor(c *gin.Context, filters repository.ClipFilters, cursor string, limit int, userID *uuid.UUID) {
+	if cursor != "" {
+		decoded, err := pagination.Decode(cursor, filters.Sort)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, StandardResponse{
+				Success: false,
+				Error: &ErrorInfo{
+					Code:    "INVALID_CURSOR",
+					Message: "Invalid or expired cursor",
+				},
+			})
+			return
+		}
+		filters.Cursor = decoded
+	}
+
+	clips, page, err := h.clipService.ListClipsCursor(c.Request.Context(), filters, limit, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, StandardResponse{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "INTERNAL_ERROR",
+				Message: "Failed to fetch clips",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, StandardResponse{
+		Success: true,
+		Data:    clips,
+		Meta:    CursorPaginationMeta{Limit: limit, PageInfo: page},
+	})
+}
+
 // ListScrapedClips handles GET /scraped-clips
 // Returns clips that have not been claimed/submitted by any user (submitted_by_user_id IS NULL)
 func (h *ClipHandler) ListScrapedClips(c *gin.Context) {
@@ -1477,3 +1562,200 @@ func (h *ClipHandler) ListCreatorClips(c *gin.Context) {
 		Meta:    meta,
 	})
 }
+
+// GetClipPlayback handles GET /clips/:id/playback
+// Returns the sources a player should fall back through when the Twitch embed fails.
+func (h *ClipHandler) GetClipPlayback(c *gin.Context) {
+	if h.playback == nil {
+		c.JSON(http.StatusServiceUnavailable, StandardResponse{
+			Success: false,
+			Error:   &ErrorInfo{Code: "PLAYBACK_UNAVAILABLE", Message: "Clip playback is not configured"},
+		})
+		return
+	}
+
+	clipIDParam := c.Param("id")
+
+	var clip *services.ClipWithUserData
+	var err error
+
+	if clipID, parseErr := uuid.Parse(clipIDParam); parseErr == nil {
+		clip, err = h.clipService.GetClip(c.Request.Context(), clipID, nil)
+	} else {
+		clip, err = h.clipService.GetClipByTwitchID(c.Request.Context(), clipIDParam, nil)
+	}
+
+	if err != nil {
+		c.JSON(http.StatusNotFound, StandardResponse{
+			Success: false,
+			Error:   &ErrorInfo{Code: "CLIP_NOT_FOUND", Message: "Clip not found or has been removed"},
+		})
+		return
+	}
+
+	playback, err := h.playback.GetPlaybackConfig(c.Request.Context(), &clip.Clip)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, StandardResponse{
+			Success: false,
+			Error:   &ErrorInfo{Code: "INTERNAL_ERROR", Message: "Failed to build playback config"},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, StandardResponse{
+		Success: true,
+		Data:    playback,
+	})
+}
+
+// ReportClipPlaybackEvent handles POST /clips/:id/playback/events
+// Players report whether a playback source loaded or failed, feeding embed failure rates.
+func (h *ClipHandler) ReportClipPlaybackEvent(c *gin.Context) {
+	if h.playback == nil {
+		c.JSON(http.StatusServiceUnavailable, StandardResponse{
+			Success: false,
+			Error:   &ErrorInfo{Code: "PLAYBACK_UNAVAILABLE", Message: "Clip playback is not configured"},
+		})
+		return
+	}
+
+	clipID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, StandardResponse{
+			Success: false,
+			Error:   &ErrorInfo{Code: "INVALID_CLIP_ID", Message: "Invalid clip ID"},
+		})
+		return
+	}
+
+	var req models.ReportPlaybackEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, StandardResponse{
+			Success: false,
+			Error:   &ErrorInfo{Code: "INVALID_REQUEST", Message: "source and outcome (loaded or failed) are required"},
+		})
+		return
+	}
+
+	// Make sure the clip exists so arbitrary IDs cannot create health rows
+	if _, err := h.clipService.GetClip(c.Request.Context(), clipID, nil); err != nil {
+		c.JSON(http.StatusNotFound, StandardResponse{
+			Success: false,
+			Error:   &ErrorInfo{Code: "CLIP_NOT_FOUND", Message: "Clip not found or has been removed"},
+		})
+		return
+	}
+
+	if err := h.playback.ReportEvent(c.Request.Context(), clipID, &req); err != nil {
+		if errors.Is(err, services.ErrInvalidPlaybackEvent) {
+			c.JSON(http.StatusBadRequest, StandardResponse{
+				Success: false,
+				Error:   &ErrorInfo{Code: "INVALID_REQUEST", Message: err.Error()},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, StandardResponse{
+			Success: false,
+			Error:   &ErrorInfo{Code: "INTERNAL_ERROR", Message: "Failed to record playback event"},
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetClipAccessibility handles GET /clips/:id/accessibility
+func (h *ClipHandler) GetClipAccessibility(c *gin.Context) {
+	clipID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, StandardResponse{
+			Success: false,
+			Error:   &ErrorInfo{Code: "INVALID_CLIP_ID", Message: "Invalid clip ID format"},
+		})
+		return
+	}
+
+	accessibility, err := h.clipService.GetClipAccessibility(c.Request.Context(), clipID)
+	if err != nil {
+		if errors.Is(err, services.ErrClipAccessibilityUnavailable) {
+			c.JSON(http.StatusServiceUnavailable, StandardResponse{
+				Success: false,
+				Error:   &ErrorInfo{Code: "ACCESSIBILITY_UNAVAILABLE", Message: "Clip accessibility is not configured"},
+			})
+			return
+		}
+		c.JSON(http.StatusNotFound, StandardResponse{
+			Success: false,
+			Error:   &ErrorInfo{Code: "CLIP_NOT_FOUND", Message: "Clip not found or has been removed"},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, StandardResponse{
+		Success: true,
+		Data:    accessibility,
+	})
+}
+
+// UpdateClipAccessibility handles PUT /clips/:id/accessibility
+// Lets the clip's creator or a moderator set captions, photosensitivity and audio description flags.
+func (h *ClipHandler) UpdateClipAccessibility(c *gin.Context) {
+	clipID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, StandardResponse{
+			Success: false,
+			Error:   &ErrorInfo{Code: "INVALID_CLIP_ID", Message: "Invalid clip ID format"},
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, StandardResponse{
+			Success: false,
+			Error:   &ErrorInfo{Code: "UNAUTHORIZED", Message: "Authentication required"},
+		})
+		return
+	}
+
+	var req models.UpdateClipAccessibilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, StandardResponse{
+			Success: false,
+			Error:   &ErrorInfo{Code: "INVALID_REQUEST", Message: "Invalid request body"},
+		})
+		return
+	}
+
+	accessibility, err := h.clipService.UpdateClipAccessibility(c.Request.Context(), userID.(uuid.UUID), clipID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoAccessibilityFields):
+			c.JSON(http.StatusBadRequest, StandardResponse{
+				Success: false,
+				Error:   &ErrorInfo{Code: "INVALID_REQUEST", Message: err.Error()},
+			})
+		case errors.Is(err, services.ErrUnauthorized):
+			c.JSON(http.StatusForbidden, StandardResponse{
+				Success: false,
+				Error:   &ErrorInfo{Code: "FORBIDDEN", Message: err.Error()},
+			})
+		case errors.Is(err, services.ErrClipAccessibilityUnavailable):
+			c.JSON(http.StatusServiceUnavailable, StandardResponse{
+				Success: false,
+				Error:   &ErrorInfo{Code: "ACCESSIBILITY_UNAVAILABLE", Message: "Clip accessibility is not configured"},
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, StandardResponse{
+				Success: false,
+				Error:   &ErrorInfo{Code: "UPDATE_FAILED", Message: "Failed to update clip accessibility"},
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, StandardResponse{
+		Success: true,
+		Data:    accessibility,
+	})
+}
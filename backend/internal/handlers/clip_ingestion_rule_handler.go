@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// ClipIngestionRuleHandler handles admin management of clip ingestion rules
+type ClipIngestionRuleHandler struct {
+	ruleService *services.ClipIngestionRuleService
+}
+
+// NewClipIngestionRuleHandler creates a new clip ingestion rule handler
+func NewClipIngestionRuleHandler(ruleService *services.ClipIngestionRuleService) *ClipIngestionRuleHandler {
+	return &ClipIngestionRuleHandler{
+		ruleService: ruleService,
+	}
+}
+
+// ListRules lists ingestion rules with their hit stats
+// GET /api/v1/admin/ingestion-rules
+func (h *ClipIngestionRuleHandler) ListRules(c *gin.Context) {
+	rules, err := h.ruleService.ListRules(c.Request.Context())
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve ingestion rules")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": rules})
+}
+
+// GetRule returns an ingestion rule with its hit stats
+// GET /api/v1/admin/ingestion-rules/:id
+func (h *ClipIngestionRuleHandler) GetRule(c *gin.Context) {
+	ruleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rule ID"})
+		return
+	}
+
+	rule, err := h.ruleService.GetRule(c.Request.Context(), ruleID)
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve ingestion rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// CreateRule creates a rule routing matching synced clips into a list, feed or community
+// POST /api/v1/admin/ingestion-rules
+func (h *ClipIngestionRuleHandler) CreateRule(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	adminID := userIDVal.(uuid.UUID)
+
+	var req models.ClipIngestionRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.ruleService.CreateRule(c.Request.Context(), adminID, &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to create ingestion rule")
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+// UpdateRule replaces an ingestion rule's conditions, target and status
+// PUT /api/v1/admin/ingestion-rules/:id
+func (h *ClipIngestionRuleHandler) UpdateRule(c *gin.Context) {
+	ruleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rule ID"})
+		return
+	}
+
+	var req models.ClipIngestionRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.ruleService.UpdateRule(c.Request.Context(), ruleID, &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to update ingestion rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// DeleteRule deletes an ingestion rule; clips it routed are kept
+// DELETE /api/v1/admin/ingestion-rules/:id
+func (h *ClipIngestionRuleHandler) DeleteRule(c *gin.Context) {
+	ruleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rule ID"})
+		return
+	}
+
+	if err := h.ruleService.DeleteRule(c.Request.Context(), ruleID); err != nil {
+		h.respondError(c, err, "Failed to delete ingestion rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Ingestion rule deleted"})
+}
+
+// respondError maps ingestion rule errors to HTTP responses
+func (h *ClipIngestionRuleHandler) respondError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, repository.ErrClipIngestionRuleNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrClipIngestionTargetNotFound),
+		errors.Is(err, services.ErrClipIngestionRuleNoConditions),
+		errors.Is(err, services.ErrClipIngestionRuleInvalidViews):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
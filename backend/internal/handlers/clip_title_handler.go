@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// ClipTitleHandler handles the broadcaster-facing title normalization endpoints
+type ClipTitleHandler struct {
+	titleService *services.ClipTitleService
+}
+
+// NewClipTitleHandler creates a new clip title handler
+func NewClipTitleHandler(titleService *services.ClipTitleService) *ClipTitleHandler {
+	return &ClipTitleHandler{
+		titleService: titleService,
+	}
+}
+
+// GetPreference returns whether the caller's clip titles are normalized
+// GET /api/v1/broadcasters/me/title-normalization
+func (h *ClipTitleHandler) GetPreference(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	pref, err := h.titleService.GetPreference(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, err, "Failed to get title normalization preference")
+		return
+	}
+
+	c.JSON(http.StatusOK, pref)
+}
+
+// UpdatePreference opts the caller's channel in or out of title
+// normalization. Existing clips are updated before the response.
+// PUT /api/v1/broadcasters/me/title-normalization
+func (h *ClipTitleHandler) UpdatePreference(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	var req models.UpdateBroadcasterTitlePreferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	pref, err := h.titleService.UpdatePreference(c.Request.Context(), userID, &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to update title normalization preference")
+		return
+	}
+
+	c.JSON(http.StatusOK, pref)
+}
+
+// respondError maps title normalization errors to HTTP responses
+func (h *ClipTitleHandler) respondError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrNotTitleBroadcaster):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -1,19 +1,35 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/pagination"
+	"github.com/subculture-collective/clipper/internal/repository"
 	"github.com/subculture-collective/clipper/internal/services"
 )
 
 // CommentHandler handles comment-related HTTP requests
 type CommentHandler struct {
 	commentService *services.CommentService
+	streamHub      *services.CommentStreamHub
 }
 
+const (
+	// commentStreamHeartbeat keeps idle streams open through proxies
+	commentStreamHeartbeat = 25 * time.Second
+	// commentStreamMaxDuration ends streams so abandoned clip pages let go of them
+	commentStreamMaxDuration = 30 * time.Minute
+	// commentStreamRetryMillis tells EventSource clients how long to wait before reconnecting
+	commentStreamRetryMillis = 5000
+)
+
 // NewCommentHandler creates a new CommentHandler
 func NewCommentHandler(commentService *services.CommentService) *CommentHandler {
 	return &CommentHandler{
@@ -21,6 +37,11 @@ func NewCommentHandler(commentService *services.CommentService) *CommentHandler
 	}
 }
 
+// SetStreamHub enables live updates on GET /clips/:id/comments/stream
+func (h *CommentHandler) SetStreamHub(hub *services.CommentStreamHub) {
+	h.streamHub = hub
+}
+
 // ListComments handles GET /clips/:id/comments
 func (h *CommentHandler) ListComments(c *gin.Context) {
 	// Parse clip ID
@@ -36,7 +57,7 @@ func (h *CommentHandler) ListComments(c *gin.Context) {
 	// Parse query parameters
 	sortBy := c.DefaultQuery("sort", "best")
 	limitStr := c.DefaultQuery("limit", "50")
-	cursorStr := c.DefaultQuery("cursor", "0")
+	cursorStr := c.Query("cursor")
 	includeRepliesStr := c.DefaultQuery("include_replies", "false")
 
 	limit, err := strconv.Atoi(limitStr)
@@ -44,9 +65,15 @@ func (h *CommentHandler) ListComments(c *gin.Context) {
 		limit = 50
 	}
 
-	offset, err := strconv.Atoi(cursorStr)
-	if err != nil || offset < 0 {
-		offset = 0
+	var cursor *pagination.Cursor
+	if cursorStr != "" {
+		cursor, err = pagination.Decode(cursorStr, sortBy)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid or expired cursor",
+			})
+			return
+		}
 	}
 
 	includeReplies := includeRepliesStr == "true"
@@ -60,7 +87,7 @@ func (h *CommentHandler) ListComments(c *gin.Context) {
 	}
 
 	// List comments with optional nested replies
-	comments, err := h.commentService.ListCommentsWithReplies(c.Request.Context(), clipID, sortBy, limit, offset, userID, includeReplies)
+	comments, page, err := h.commentService.ListCommentsWithReplies(c.Request.Context(), clipID, sortBy, cursor, limit, userID, includeReplies)
 	if err != nil {
 		// Log the actual error for debugging
 		_ = c.Error(err)
@@ -70,14 +97,10 @@ func (h *CommentHandler) ListComments(c *gin.Context) {
 		return
 	}
 
-	// Calculate next cursor
-	nextCursor := offset + len(comments)
-	hasMore := len(comments) == limit
-
 	c.JSON(http.StatusOK, gin.H{
 		"comments":    comments,
-		"next_cursor": nextCursor,
-		"has_more":    hasMore,
+		"next_cursor": page.NextCursor,
+		"has_more":    page.HasMore,
 	})
 }
 
@@ -292,7 +315,228 @@ func (h *CommentHandler) VoteOnComment(c *gin.Context) {
 	})
 }
 
+// ReactToComment handles POST /comments/:id/reactions
+func (h *CommentHandler) ReactToComment(c *gin.Context) {
+	commentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid comment ID",
+		})
+		return
+	}
+
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+		})
+		return
+	}
+
+	userID, ok := userIDVal.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Invalid user ID",
+		})
+		return
+	}
+
+	var req struct {
+		Reaction string `json:"reaction" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	reactions, err := h.commentService.ReactToComment(c.Request.Context(), commentID, userID, req.Reaction)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reactions": reactions,
+	})
+}
+
+// RemoveCommentReaction handles DELETE /comments/:id/reactions?reaction=
+func (h *CommentHandler) RemoveCommentReaction(c *gin.Context) {
+	commentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid comment ID",
+		})
+		return
+	}
+
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+		})
+		return
+	}
+
+	userID, ok := userIDVal.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Invalid user ID",
+		})
+		return
+	}
+
+	reaction := c.Query("reaction")
+	if reaction == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "reaction is required",
+		})
+		return
+	}
+
+	reactions, err := h.commentService.RemoveCommentReaction(c.Request.Context(), commentID, userID, reaction)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reactions": reactions,
+	})
+}
+
+// GetCommentHistory handles GET /comments/:id/history
+func (h *CommentHandler) GetCommentHistory(c *gin.Context) {
+	commentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid comment ID",
+		})
+		return
+	}
+
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+		})
+		return
+	}
+
+	userID, ok := userIDVal.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Invalid user ID",
+		})
+		return
+	}
+
+	role, _ := c.Get("user_role")
+	roleStr, _ := role.(string)
+
+	revisions, err := h.commentService.GetCommentHistory(c.Request.Context(), commentID, userID, roleStr)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrCommentNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrCommentHistoryForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve comment history"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"revisions": revisions,
+	})
+}
+
+// PinComment handles POST /clips/:id/comments/:commentId/pin
+func (h *CommentHandler) PinComment(c *gin.Context) {
+	h.setCommentPinned(c, true)
+}
+
+// UnpinComment handles DELETE /clips/:id/comments/:commentId/pin
+func (h *CommentHandler) UnpinComment(c *gin.Context) {
+	h.setCommentPinned(c, false)
+}
+
+// setCommentPinned pins or unpins a clip's comment for the authenticated user
+func (h *CommentHandler) setCommentPinned(c *gin.Context, pinned bool) {
+	clipID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid clip ID",
+		})
+		return
+	}
+
+	commentID, err := uuid.Parse(c.Param("commentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid comment ID",
+		})
+		return
+	}
+
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+		})
+		return
+	}
+
+	userID, ok := userIDVal.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Invalid user ID",
+		})
+		return
+	}
+
+	role, _ := c.Get("user_role")
+	roleStr, _ := role.(string)
+
+	if pinned {
+		err = h.commentService.PinComment(c.Request.Context(), clipID, commentID, userID, roleStr)
+	} else {
+		err = h.commentService.UnpinComment(c.Request.Context(), clipID, commentID, userID, roleStr)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrCommentNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrCommentPinForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrCommentNotPinnable):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrTooManyPinnedComments):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update pinned comments"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"comment_id": commentID,
+		"pinned":     pinned,
+	})
+}
+
 // GetReplies handles GET /comments/:id/replies
+//
+// Query parameters:
+//   - sort: top (default), new or controversial
+//   - depth: levels of replies to load, 1 (default) to services.MaxReplyTreeDepth
 func (h *CommentHandler) GetReplies(c *gin.Context) {
 	// Parse comment ID
 	commentIDStr := c.Param("id")
@@ -306,16 +550,40 @@ func (h *CommentHandler) GetReplies(c *gin.Context) {
 
 	// Parse query parameters
 	limitStr := c.DefaultQuery("limit", "50")
-	cursorStr := c.DefaultQuery("cursor", "0")
+	cursorStr := c.Query("cursor")
+	sortBy := c.DefaultQuery("sort", repository.CommentReplySortTop)
+
+	switch sortBy {
+	case repository.CommentReplySortTop, repository.CommentReplySortNew, repository.CommentReplySortControversial:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid sort: must be top, new or controversial",
+		})
+		return
+	}
 
 	limit, err := strconv.Atoi(limitStr)
 	if err != nil || limit < 1 || limit > 100 {
 		limit = 50
 	}
 
-	offset, err := strconv.Atoi(cursorStr)
-	if err != nil || offset < 0 {
-		offset = 0
+	depth, err := strconv.Atoi(c.DefaultQuery("depth", "1"))
+	if err != nil || depth < 1 || depth > services.MaxReplyTreeDepth {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("Invalid depth: must be between 1 and %d", services.MaxReplyTreeDepth),
+		})
+		return
+	}
+
+	var cursor *pagination.Cursor
+	if cursorStr != "" {
+		cursor, err = pagination.Decode(cursorStr, repository.CommentRepliesCursorSort(sortBy))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid or expired cursor",
+			})
+			return
+		}
 	}
 
 	// Get user ID if authenticated
@@ -327,7 +595,7 @@ func (h *CommentHandler) GetReplies(c *gin.Context) {
 	}
 
 	// Get replies
-	replies, err := h.commentService.GetReplies(c.Request.Context(), commentID, limit, offset, userID)
+	replies, page, err := h.commentService.GetReplies(c.Request.Context(), commentID, sortBy, cursor, limit, depth, userID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to retrieve replies",
@@ -335,13 +603,85 @@ func (h *CommentHandler) GetReplies(c *gin.Context) {
 		return
 	}
 
-	// Calculate next cursor
-	nextCursor := offset + len(replies)
-	hasMore := len(replies) == limit
-
 	c.JSON(http.StatusOK, gin.H{
 		"replies":     replies,
-		"next_cursor": nextCursor,
-		"has_more":    hasMore,
+		"next_cursor": page.NextCursor,
+		"has_more":    page.HasMore,
+	})
+}
+
+// StreamComments handles GET /clips/:id/comments/stream. New comments and vote
+// score changes on the clip are pushed as Server-Sent Events, so clip pages
+// update without polling. Authentication is optional; streams are limited per
+// user, or per client address for anonymous viewers.
+func (h *CommentHandler) StreamComments(c *gin.Context) {
+	clipID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid clip ID",
+		})
+		return
+	}
+
+	if h.streamHub == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Comment stream unavailable",
+		})
+		return
+	}
+
+	viewer := "ip:" + c.ClientIP()
+	if userID, exists := c.Get("user_id"); exists {
+		if id, ok := userID.(uuid.UUID); ok {
+			viewer = "user:" + id.String()
+		}
+	}
+
+	ctx := c.Request.Context()
+	events, unsubscribe, err := h.streamHub.Subscribe(ctx, clipID, viewer)
+	if err != nil {
+		if errors.Is(err, services.ErrTooManyCommentStreams) {
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error": "Too many open comment streams",
+			})
+			return
+		}
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Comment stream unavailable",
+		})
+		return
+	}
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", commentStreamRetryMillis)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(commentStreamHeartbeat)
+	defer heartbeat.Stop()
+	deadline := time.NewTimer(commentStreamMaxDuration)
+	defer deadline.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(event.Type, event)
+			return true
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": heartbeat\n\n")
+			return err == nil
+		case <-deadline.C:
+			return false
+		case <-ctx.Done():
+			return false
+		}
 	})
 }
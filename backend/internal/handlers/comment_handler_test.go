@@ -4,9 +4,11 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 // TestListComments_InvalidClipID tests that invalid clip IDs are rejected
@@ -74,3 +76,185 @@ func TestGetReplies_InvalidCommentID(t *testing.T) {
 		t.Error("expected error field in response")
 	}
 }
+
+// TestListComments_InvalidCursor tests that tampered cursors are rejected before querying
+func TestListComments_InvalidCursor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := &CommentHandler{
+		commentService: nil,
+	}
+
+	clipID := "550e8400-e29b-41d4-a716-446655440000"
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/clips/"+clipID+"/comments?cursor=20", http.NoBody)
+	w := httptest.NewRecorder()
+
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{
+		{Key: "id", Value: clipID},
+	}
+
+	handler.ListComments(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+// TestReactToComment_InvalidCommentID tests that invalid comment IDs are rejected
+func TestReactToComment_InvalidCommentID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := &CommentHandler{
+		commentService: nil,
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/comments/not-a-uuid/reactions", strings.NewReader(`{"reaction":"fire"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{
+		{Key: "id", Value: "not-a-uuid"},
+	}
+
+	handler.ReactToComment(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+// TestRemoveCommentReaction_MissingReaction tests that a reaction must be named
+func TestRemoveCommentReaction_MissingReaction(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := &CommentHandler{
+		commentService: nil,
+	}
+
+	commentID := "550e8400-e29b-41d4-a716-446655440000"
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/comments/"+commentID+"/reactions", http.NoBody)
+	w := httptest.NewRecorder()
+
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{
+		{Key: "id", Value: commentID},
+	}
+	c.Set("user_id", uuid.New())
+
+	handler.RemoveCommentReaction(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+// TestGetCommentHistory_RequiresAuth tests that history needs a signed-in user
+func TestGetCommentHistory_RequiresAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := &CommentHandler{
+		commentService: nil,
+	}
+
+	commentID := "550e8400-e29b-41d4-a716-446655440000"
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/comments/"+commentID+"/history", http.NoBody)
+	w := httptest.NewRecorder()
+
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{
+		{Key: "id", Value: commentID},
+	}
+
+	handler.GetCommentHistory(c)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
+
+// TestPinComment_InvalidCommentID tests that pinning rejects invalid comment IDs
+func TestPinComment_InvalidCommentID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := &CommentHandler{
+		commentService: nil,
+	}
+
+	clipID := "550e8400-e29b-41d4-a716-446655440000"
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/clips/"+clipID+"/comments/not-a-uuid/pin", http.NoBody)
+	w := httptest.NewRecorder()
+
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{
+		{Key: "id", Value: clipID},
+		{Key: "commentId", Value: "not-a-uuid"},
+	}
+
+	handler.PinComment(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+// TestUnpinComment_RequiresAuth tests that unpinning needs a signed-in user
+func TestUnpinComment_RequiresAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := &CommentHandler{
+		commentService: nil,
+	}
+
+	clipID := "550e8400-e29b-41d4-a716-446655440000"
+	commentID := "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/clips/"+clipID+"/comments/"+commentID+"/pin", http.NoBody)
+	w := httptest.NewRecorder()
+
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{
+		{Key: "id", Value: clipID},
+		{Key: "commentId", Value: commentID},
+	}
+
+	handler.UnpinComment(c)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
+
+// TestGetReplies_InvalidSortAndDepth tests that reply trees reject unknown
+// sorts and depths outside the allowed range
+func TestGetReplies_InvalidSortAndDepth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := &CommentHandler{
+		commentService: nil,
+	}
+
+	commentID := "550e8400-e29b-41d4-a716-446655440000"
+	for _, query := range []string{"sort=random", "depth=0", "depth=6", "depth=deep"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/comments/"+commentID+"/replies?"+query, http.NoBody)
+		w := httptest.NewRecorder()
+
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{
+			{Key: "id", Value: commentID},
+		}
+
+		handler.GetReplies(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status %d, got %d", query, http.StatusBadRequest, w.Code)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// CommunityDigestHandler handles owner settings for weekly community digest posts
+type CommunityDigestHandler struct {
+	digestService *services.CommunityDigestService
+}
+
+// NewCommunityDigestHandler creates a new community digest handler
+func NewCommunityDigestHandler(digestService *services.CommunityDigestService) *CommunityDigestHandler {
+	return &CommunityDigestHandler{
+		digestService: digestService,
+	}
+}
+
+// GetSettings returns a community's digest settings to its owner
+// GET /api/v1/communities/:id/digest-settings
+func (h *CommunityDigestHandler) GetSettings(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	communityID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid community ID"})
+		return
+	}
+
+	settings, err := h.digestService.GetSettings(c.Request.Context(), communityID, userID)
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve digest settings")
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateSettings opts a community in or out of weekly digests and sets their templates
+// PUT /api/v1/communities/:id/digest-settings
+func (h *CommunityDigestHandler) UpdateSettings(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	communityID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid community ID"})
+		return
+	}
+
+	var req models.UpdateCommunityDigestSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings, err := h.digestService.UpdateSettings(c.Request.Context(), communityID, userID, &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to update digest settings")
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+func (h *CommunityDigestHandler) respondError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, repository.ErrCommunityNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrCommunityDigestForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrCommunityDigestInvalidTemplate):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
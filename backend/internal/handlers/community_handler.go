@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -654,3 +655,85 @@ func (h *CommunityHandler) DeleteDiscussion(c *gin.Context) {
 
 	c.JSON(http.StatusNoContent, nil)
 }
+
+// ListAssets lists a community's uploaded assets
+func (h *CommunityHandler) ListAssets(c *gin.Context) {
+	communityID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid community ID"})
+		return
+	}
+
+	assets, err := h.communityService.ListAssets(c.Request.Context(), communityID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list assets"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"assets": assets})
+}
+
+// AddAsset attaches an uploaded asset to a community
+func (h *CommunityHandler) AddAsset(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	communityID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid community ID"})
+		return
+	}
+
+	var req models.AttachUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	asset, err := h.communityService.AddAsset(c.Request.Context(), communityID, userID.(uuid.UUID), req.UploadID)
+	if err != nil {
+		if errors.Is(err, services.ErrCommunityAssetsForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		respondUploadError(c, err, "Failed to add asset")
+		return
+	}
+
+	c.JSON(http.StatusCreated, asset)
+}
+
+// RemoveAsset detaches an uploaded asset from a community
+func (h *CommunityHandler) RemoveAsset(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	communityID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid community ID"})
+		return
+	}
+	uploadID, err := uuid.Parse(c.Param("uploadId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid upload ID"})
+		return
+	}
+
+	err = h.communityService.RemoveAsset(c.Request.Context(), communityID, userID.(uuid.UUID), uploadID)
+	if err != nil {
+		if errors.Is(err, services.ErrCommunityAssetsForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		respondUploadError(c, err, "Failed to remove asset")
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// CommunityPickHandler handles community nomination and voting for discovery lists
+type CommunityPickHandler struct {
+	pickService *services.CommunityPickService
+}
+
+// NewCommunityPickHandler creates a new community pick handler
+func NewCommunityPickHandler(pickService *services.CommunityPickService) *CommunityPickHandler {
+	return &CommunityPickHandler{
+		pickService: pickService,
+	}
+}
+
+// ListRounds lists a discovery list's community pick rounds, including past ones
+// GET /api/v1/discovery-lists/:id/community-picks
+func (h *CommunityPickHandler) ListRounds(c *gin.Context) {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid list ID"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	rounds, err := h.pickService.ListRounds(c.Request.Context(), listID, limit, offset)
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve community pick rounds")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": rounds,
+		"meta": gin.H{
+			"limit":  limit,
+			"offset": offset,
+			"count":  len(rounds),
+		},
+	})
+}
+
+// GetRound returns a round with its nominations
+// GET /api/v1/community-picks/:id
+func (h *CommunityPickHandler) GetRound(c *gin.Context) {
+	roundID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid round ID"})
+		return
+	}
+
+	var userID *uuid.UUID
+	if userIDVal, exists := c.Get("user_id"); exists {
+		if id, ok := userIDVal.(uuid.UUID); ok {
+			userID = &id
+		}
+	}
+
+	round, err := h.pickService.GetRound(c.Request.Context(), roundID, userID)
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve community pick round")
+		return
+	}
+
+	c.JSON(http.StatusOK, round)
+}
+
+// GetResults returns the archived standings of a published round
+// GET /api/v1/community-picks/:id/results
+func (h *CommunityPickHandler) GetResults(c *gin.Context) {
+	roundID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid round ID"})
+		return
+	}
+
+	results, err := h.pickService.GetResults(c.Request.Context(), roundID)
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve community pick results")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": results})
+}
+
+// Nominate nominates a clip to an open round
+// POST /api/v1/community-picks/:id/nominations
+func (h *CommunityPickHandler) Nominate(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	roundID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid round ID"})
+		return
+	}
+
+	var req models.NominateCommunityPickRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	nomination, err := h.pickService.Nominate(c.Request.Context(), roundID, userID, req.ClipID)
+	if err != nil {
+		h.respondError(c, err, "Failed to nominate clip")
+		return
+	}
+
+	c.JSON(http.StatusCreated, nomination)
+}
+
+// Vote votes for a nomination
+// POST /api/v1/community-picks/:id/nominations/:nominationId/vote
+func (h *CommunityPickHandler) Vote(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	nominationID, err := uuid.Parse(c.Param("nominationId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid nomination ID"})
+		return
+	}
+
+	if _, err := h.pickService.Vote(c.Request.Context(), nominationID, userID); err != nil {
+		h.respondError(c, err, "Failed to record vote")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Vote recorded"})
+}
+
+// Unvote retracts a vote for a nomination
+// DELETE /api/v1/community-picks/:id/nominations/:nominationId/vote
+func (h *CommunityPickHandler) Unvote(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	nominationID, err := uuid.Parse(c.Param("nominationId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid nomination ID"})
+		return
+	}
+
+	if err := h.pickService.Unvote(c.Request.Context(), nominationID, userID); err != nil {
+		h.respondError(c, err, "Failed to retract vote")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Vote retracted"})
+}
+
+// AdminCreateRound starts a community pick round for a discovery list
+// POST /api/v1/admin/discovery-lists/:id/community-picks
+func (h *CommunityPickHandler) AdminCreateRound(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	adminID := userIDVal.(uuid.UUID)
+
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid list ID"})
+		return
+	}
+
+	var req models.CreateCommunityPickRoundRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	round, err := h.pickService.CreateRound(c.Request.Context(), listID, adminID, &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to start community pick round")
+		return
+	}
+
+	c.JSON(http.StatusCreated, round)
+}
+
+// AdminCancelRound closes a round without publishing its picks
+// POST /api/v1/admin/community-picks/:id/cancel
+func (h *CommunityPickHandler) AdminCancelRound(c *gin.Context) {
+	roundID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid round ID"})
+		return
+	}
+
+	if err := h.pickService.CancelRound(c.Request.Context(), roundID); err != nil {
+		h.respondError(c, err, "Failed to cancel community pick round")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Community pick round cancelled"})
+}
+
+// respondError maps community pick errors to HTTP responses
+func (h *CommunityPickHandler) respondError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, repository.ErrDiscoveryListNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Discovery list not found"})
+	case errors.Is(err, repository.ErrCommunityPickRoundNotFound),
+		errors.Is(err, repository.ErrCommunityPickNominationNotFound),
+		errors.Is(err, repository.ErrCommunityPickClipUnavailable),
+		errors.Is(err, repository.ErrCommunityPickVoteNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrCommunityPickInvalidDeadline):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrCommunityPickOwnNomination):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrCommunityPickNominationLimit),
+		errors.Is(err, services.ErrCommunityPickVoteLimit):
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrCommunityPickClosed),
+		errors.Is(err, services.ErrCommunityPickResultsPending),
+		errors.Is(err, services.ErrCommunityPickClipAlreadyListed),
+		errors.Is(err, repository.ErrCommunityPickRoundNotOpen),
+		errors.Is(err, repository.ErrCommunityPickAlreadyNominated),
+		errors.Is(err, repository.ErrCommunityPickAlreadyVoted):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// CommunityToxicityHandler handles moderator settings for toxic comment flagging in communities
+type CommunityToxicityHandler struct {
+	toxicityService *services.CommunityToxicityService
+}
+
+// NewCommunityToxicityHandler creates a new community toxicity handler
+func NewCommunityToxicityHandler(toxicityService *services.CommunityToxicityService) *CommunityToxicityHandler {
+	return &CommunityToxicityHandler{
+		toxicityService: toxicityService,
+	}
+}
+
+// GetSettings returns a community's toxicity sensitivity to its moderators
+// GET /api/v1/communities/:id/toxicity-settings
+func (h *CommunityToxicityHandler) GetSettings(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	communityID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid community ID"})
+		return
+	}
+
+	settings, err := h.toxicityService.GetSettings(c.Request.Context(), communityID, userID)
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve toxicity settings")
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateSettings sets how readily comments on a community's clips are flagged as toxic
+// PUT /api/v1/communities/:id/toxicity-settings
+func (h *CommunityToxicityHandler) UpdateSettings(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	communityID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid community ID"})
+		return
+	}
+
+	var req models.UpdateCommunityToxicitySettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings, err := h.toxicityService.UpdateSettings(c.Request.Context(), communityID, userID, &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to update toxicity settings")
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+func (h *CommunityToxicityHandler) respondError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, repository.ErrCommunityNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrCommunityToxicityForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
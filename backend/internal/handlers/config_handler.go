@@ -1,15 +1,23 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/models"
 )
 
 // ConfigHandler handles public configuration endpoints
 type ConfigHandler struct {
-	cfg *config.Config
+	cfg         *config.Config
+	maintenance MaintenanceStatusProvider
+}
+
+// MaintenanceStatusProvider reports the API's maintenance mode
+type MaintenanceStatusProvider interface {
+	Status(ctx context.Context) models.MaintenanceStatus
 }
 
 // NewConfigHandler creates a new config handler
@@ -19,9 +27,16 @@ func NewConfigHandler(cfg *config.Config) *ConfigHandler {
 	}
 }
 
+// SetMaintenance reports maintenance mode in the public config, so the
+// frontend can show a banner and disable writes
+func (h *ConfigHandler) SetMaintenance(maintenance MaintenanceStatusProvider) {
+	h.maintenance = maintenance
+}
+
 // PublicConfigResponse represents public configuration data exposed to frontend
 type PublicConfigResponse struct {
-	Karma KarmaConfigResponse `json:"karma"`
+	Karma       KarmaConfigResponse       `json:"karma"`
+	Maintenance MaintenanceConfigResponse `json:"maintenance"`
 }
 
 // KarmaConfigResponse represents public karma configuration
@@ -31,14 +46,29 @@ type KarmaConfigResponse struct {
 	RequireKarmaForSubmission bool `json:"require_karma_for_submission"`
 }
 
+// MaintenanceConfigResponse represents public maintenance mode status
+type MaintenanceConfigResponse struct {
+	ReadOnly bool   `json:"read_only"`
+	Message  string `json:"message,omitempty"`
+}
+
 // GetPublicConfig returns public configuration for the frontend
 // GET /api/v1/config
 func (h *ConfigHandler) GetPublicConfig(c *gin.Context) {
-	c.JSON(http.StatusOK, PublicConfigResponse{
+	response := PublicConfigResponse{
 		Karma: KarmaConfigResponse{
 			InitialKarmaPoints:        h.cfg.Karma.InitialKarmaPoints,
 			SubmissionKarmaRequired:   h.cfg.Karma.SubmissionKarmaRequired,
 			RequireKarmaForSubmission: h.cfg.Karma.RequireKarmaForSubmission,
 		},
-	})
+	}
+	if h.maintenance != nil {
+		status := h.maintenance.Status(c.Request.Context())
+		response.Maintenance = MaintenanceConfigResponse{
+			ReadOnly: status.ReadOnly,
+			Message:  status.Message,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
 }
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// CreatorClaimHandler handles claims to Twitch creators' clips
+type CreatorClaimHandler struct {
+	claimService *services.CreatorClaimService
+}
+
+// NewCreatorClaimHandler creates a new creator claim handler
+func NewCreatorClaimHandler(claimService *services.CreatorClaimService) *CreatorClaimHandler {
+	return &CreatorClaimHandler{
+		claimService: claimService,
+	}
+}
+
+// CreateClaim starts a claim to a Twitch channel's clips and returns the
+// code to put in the channel's description
+// POST /api/v1/creators/me/claims
+func (h *CreatorClaimHandler) CreateClaim(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req models.CreateCreatorClaimRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request", "details": err.Error()})
+		return
+	}
+
+	claim, err := h.claimService.StartClaim(c.Request.Context(), userID.(uuid.UUID), req.TwitchLogin)
+	if err != nil {
+		h.respondClaimError(c, err, "failed to create claim")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"claim":   claim,
+		"message": "Add the verification code to your Twitch channel description, then verify the claim before it expires.",
+	})
+}
+
+// ListClaims lists the authenticated user's claims
+// GET /api/v1/creators/me/claims
+func (h *CreatorClaimHandler) ListClaims(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	claims, err := h.claimService.ListClaims(c.Request.Context(), userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list claims"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"claims": claims})
+}
+
+// VerifyClaim checks the channel description for the claim's code and
+// attributes the channel's clips to the user when it is found
+// POST /api/v1/creators/me/claims/:id/verify
+func (h *CreatorClaimHandler) VerifyClaim(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	claimID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid claim ID"})
+		return
+	}
+
+	claim, err := h.claimService.VerifyClaim(c.Request.Context(), userID.(uuid.UUID), claimID)
+	if err != nil {
+		h.respondClaimError(c, err, "failed to verify claim")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"claim":   claim,
+		"message": "Claim verified. The channel's clips are now attributed to you and the code can be removed.",
+	})
+}
+
+// respondClaimError maps claim errors to HTTP responses
+func (h *CreatorClaimHandler) respondClaimError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, repository.ErrCreatorClaimNotFound), errors.Is(err, services.ErrCreatorNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrCreatorHasNoClips), errors.Is(err, services.ErrCreatorClaimCodeMissing):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrCreatorAlreadyAttributed), errors.Is(err, services.ErrCreatorClaimNotPending):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrCreatorClaimExpired):
+		c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrCreatorClaimUnavailable):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
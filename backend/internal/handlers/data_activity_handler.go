@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// DataActivityHandler handles users' requests to see the processing on their data
+type DataActivityHandler struct {
+	dataActivityService *services.DataActivityService
+}
+
+// NewDataActivityHandler creates a new data activity handler
+func NewDataActivityHandler(dataActivityService *services.DataActivityService) *DataActivityHandler {
+	return &DataActivityHandler{
+		dataActivityService: dataActivityService,
+	}
+}
+
+// GetDataActivity summarizes the emails, exports, analytics, consent and
+// account events recorded for the current user
+// GET /api/v1/users/me/data-activity
+func (h *DataActivityHandler) GetDataActivity(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	activity, err := h.dataActivityService.GetDataActivity(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve data activity"})
+		return
+	}
+
+	c.JSON(http.StatusOK, activity)
+}
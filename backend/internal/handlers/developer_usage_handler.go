@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// DeveloperUsageHandler reports API key and webhook usage to developers
+type DeveloperUsageHandler struct {
+	usageService *services.DeveloperUsageService
+}
+
+// NewDeveloperUsageHandler creates a new developer usage handler
+func NewDeveloperUsageHandler(usageService *services.DeveloperUsageService) *DeveloperUsageHandler {
+	return &DeveloperUsageHandler{
+		usageService: usageService,
+	}
+}
+
+// GetUsage returns the caller's request counts, error rates, rate-limit hits and
+// webhook delivery stats over a window of 24h, 7d or 30d, bucketed by hour
+// GET /api/v1/developer/usage?window=24h
+func (h *DeveloperUsageHandler) GetUsage(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	usage, err := h.usageService.GetUsage(c.Request.Context(), userID, c.DefaultQuery("window", "24h"))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidUsageWindow) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get usage"})
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
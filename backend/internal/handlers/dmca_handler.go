@@ -1,15 +1,24 @@
 package handlers
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
 	"github.com/subculture-collective/clipper/internal/services"
 )
 
+// dmcaListStatuses are the status filters accepted by the admin DMCA lists
+var dmcaListStatuses = map[string]map[string]bool{
+	"notices":         {"": true, "all": true, "pending": true, "valid": true, "invalid": true, "processed": true},
+	"counter_notices": {"": true, "all": true, "pending": true, "forwarded": true, "waiting": true, "reinstated": true, "rejected": true},
+}
+
 // DMCAHandler handles DMCA-related HTTP requests
 type DMCAHandler struct {
 	dmcaService *services.DMCAService
@@ -79,7 +88,14 @@ func (h *DMCAHandler) SubmitCounterNotice(c *gin.Context) {
 	// Submit counter-notice
 	counterNotice, err := h.dmcaService.SubmitCounterNotice(c.Request.Context(), &req, userID, ipAddress, userAgent)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		switch {
+		case errors.Is(err, services.ErrDMCACounterNoticeForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrDMCACounterNoticeExists):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			respondDMCAError(c, err, http.StatusBadRequest)
+		}
 		return
 	}
 
@@ -158,33 +174,36 @@ func (h *DMCAHandler) GetUserStrikes(c *gin.Context) {
 // ListDMCANotices lists all DMCA notices (admin only)
 // GET /api/admin/dmca/notices
 func (h *DMCAHandler) ListDMCANotices(c *gin.Context) {
-	// Parse query parameters
-	page := 1
-	limit := 20
-	status := c.Query("status")
+	page, limit, status, ok := parseDMCAListParams(c, "notices")
+	if !ok {
+		return
+	}
 
-	if pageStr := c.Query("page"); pageStr != "" {
-		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
-			page = p
-		}
+	notices, err := h.dmcaService.ListNotices(c.Request.Context(), status, page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list DMCA notices"})
+		return
 	}
 
-	if limitStr := c.Query("limit"); limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
-			limit = l
-		}
+	c.JSON(http.StatusOK, notices)
+}
+
+// GetDMCANotice returns a notice with its counter-notices and case history (admin only)
+// GET /api/admin/dmca/notices/:id
+func (h *DMCAHandler) GetDMCANotice(c *gin.Context) {
+	noticeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notice ID"})
+		return
 	}
 
-	// Get notices from service
-	// In a full implementation, create a service method for this
-	// For now, showing the expected response structure
-	c.JSON(http.StatusOK, gin.H{
-		"notices": []interface{}{},
-		"total":   0,
-		"page":    page,
-		"limit":   limit,
-		"status":  status,
-	})
+	detail, err := h.dmcaService.GetNoticeDetail(c.Request.Context(), noticeID)
+	if err != nil {
+		respondDMCAError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, detail)
 }
 
 // ReviewNotice allows admin to mark a notice as valid or invalid
@@ -212,7 +231,7 @@ func (h *DMCAHandler) ReviewNotice(c *gin.Context) {
 
 	// Review the notice
 	if err := h.dmcaService.ReviewNotice(c.Request.Context(), noticeID, reviewerID.(uuid.UUID), req.Status, req.Notes); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondDMCAError(c, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -241,7 +260,7 @@ func (h *DMCAHandler) ProcessTakedown(c *gin.Context) {
 
 	// Process takedown
 	if err := h.dmcaService.ProcessTakedown(c.Request.Context(), noticeID, adminID.(uuid.UUID)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondDMCAError(c, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -268,29 +287,197 @@ func (h *DMCAHandler) ForwardCounterNotice(c *gin.Context) {
 	}
 
 	// Forward counter-notice
-	if err := h.dmcaService.ForwardCounterNoticeToComplainant(c.Request.Context(), counterNoticeID, adminID.(uuid.UUID)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	counterNotice, err := h.dmcaService.ForwardCounterNoticeToComplainant(c.Request.Context(), counterNoticeID, adminID.(uuid.UUID))
+	if err != nil {
+		respondDMCAError(c, err, http.StatusInternalServerError)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Counter-notice forwarded to complainant. Waiting period has begun.",
+		"message":             "Counter-notice forwarded to complainant. Waiting period has begun.",
+		"waiting_period_ends": counterNotice.WaitingPeriodEnds,
 	})
 }
 
+// ListCounterNotices lists DMCA counter-notices (admin only)
+// GET /api/admin/dmca/counter-notices
+func (h *DMCAHandler) ListCounterNotices(c *gin.Context) {
+	page, limit, status, ok := parseDMCAListParams(c, "counter_notices")
+	if !ok {
+		return
+	}
+
+	counterNotices, err := h.dmcaService.ListCounterNotices(c.Request.Context(), status, page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list counter-notices"})
+		return
+	}
+
+	c.JSON(http.StatusOK, counterNotices)
+}
+
+// GetCounterNotice returns a counter-notice (admin only)
+// GET /api/admin/dmca/counter-notices/:id
+func (h *DMCAHandler) GetCounterNotice(c *gin.Context) {
+	counterNoticeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid counter-notice ID"})
+		return
+	}
+
+	counterNotice, err := h.dmcaService.GetCounterNotice(c.Request.Context(), counterNoticeID)
+	if err != nil {
+		respondDMCAError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, counterNotice)
+}
+
+// RejectCounterNotice rejects a pending counter-notice (admin only)
+// POST /api/admin/dmca/counter-notices/:id/reject
+func (h *DMCAHandler) RejectCounterNotice(c *gin.Context) {
+	counterNoticeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid counter-notice ID"})
+		return
+	}
+
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var req models.ResolveDMCACounterNoticeRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.dmcaService.RejectCounterNotice(c.Request.Context(), counterNoticeID, adminID.(uuid.UUID), req.Notes); err != nil {
+		respondDMCAError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Counter-notice rejected. The content stays removed.",
+	})
+}
+
+// MarkLawsuitFiled records that the complainant filed suit during the waiting period (admin only)
+// POST /api/admin/dmca/counter-notices/:id/lawsuit
+func (h *DMCAHandler) MarkLawsuitFiled(c *gin.Context) {
+	counterNoticeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid counter-notice ID"})
+		return
+	}
+
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var req models.ResolveDMCACounterNoticeRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.dmcaService.MarkLawsuitFiled(c.Request.Context(), counterNoticeID, adminID.(uuid.UUID), req.Notes); err != nil {
+		respondDMCAError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Lawsuit recorded. The content will not be reinstated.",
+	})
+}
+
+// RemoveStrike lifts an active DMCA strike (admin only)
+// POST /api/admin/dmca/strikes/:id/remove
+func (h *DMCAHandler) RemoveStrike(c *gin.Context) {
+	strikeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid strike ID"})
+		return
+	}
+
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var req models.RemoveDMCAStrikeRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	strike, err := h.dmcaService.RemoveStrike(c.Request.Context(), strikeID, adminID.(uuid.UUID), req.Notes)
+	if err != nil {
+		respondDMCAError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, strike)
+}
+
 // GetDashboardStats returns DMCA dashboard statistics (admin only)
 // GET /api/admin/dmca/dashboard
 func (h *DMCAHandler) GetDashboardStats(c *gin.Context) {
-	// Get dashboard stats from service
-	// In a full implementation, create a service method that calls repo.GetDashboardStats
-	c.JSON(http.StatusOK, gin.H{
-		"pending_notices":                  0,
-		"pending_counter_notices":          0,
-		"content_awaiting_removal":         0,
-		"content_awaiting_restore":         0,
-		"users_with_active_strikes":        0,
-		"users_with_two_strikes":           0,
-		"total_takedowns_this_month":       0,
-		"total_counter_notices_this_month": 0,
-	})
+	stats, err := h.dmcaService.GetDashboardStats(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get DMCA dashboard statistics"})
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// parseDMCAListParams reads the page, limit and status filter of an admin
+// DMCA list, responding with 400 when the status filter isn't known
+func parseDMCAListParams(c *gin.Context, list string) (page, limit int, status string, ok bool) {
+	page = 1
+	limit = 20
+	status = c.Query("status")
+
+	if !dmcaListStatuses[list][status] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status filter"})
+		return 0, 0, "", false
+	}
+
+	if pageStr := c.Query("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+
+	return page, limit, status, true
+}
+
+// respondDMCAError maps DMCA workflow errors to status codes, falling back
+// to fallback for anything else
+func respondDMCAError(c *gin.Context, err error, fallback int) {
+	switch {
+	case errors.Is(err, repository.ErrDMCANoticeNotFound),
+		errors.Is(err, repository.ErrDMCACounterNoticeNotFound),
+		errors.Is(err, repository.ErrDMCAStrikeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrDMCAInvalidTransition):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrDMCAClipNotRemoved):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(fallback, gin.H{"error": err.Error()})
+	}
 }
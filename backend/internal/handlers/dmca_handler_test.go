@@ -413,32 +413,52 @@ func TestForwardCounterNotice_InvalidCounterNoticeID(t *testing.T) {
 func TestListDMCANotices_ValidPaginationParams(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	handler := NewDMCAHandler(nil, nil)
-
 	tests := []struct {
-		name   string
-		query  string
-		status int
+		name      string
+		query     string
+		wantPage  int
+		wantLimit int
+		wantOK    bool
 	}{
 		{
-			name:   "Default pagination",
-			query:  "",
-			status: http.StatusOK,
+			name:      "Default pagination",
+			query:     "",
+			wantPage:  1,
+			wantLimit: 20,
+			wantOK:    true,
+		},
+		{
+			name:      "Valid page and limit",
+			query:     "?page=2&limit=10",
+			wantPage:  2,
+			wantLimit: 10,
+			wantOK:    true,
+		},
+		{
+			name:      "Valid status filter",
+			query:     "?status=pending",
+			wantPage:  1,
+			wantLimit: 20,
+			wantOK:    true,
 		},
 		{
-			name:   "Valid page and limit",
-			query:  "?page=2&limit=10",
-			status: http.StatusOK,
+			name:      "Max limit boundary",
+			query:     "?limit=100",
+			wantPage:  1,
+			wantLimit: 100,
+			wantOK:    true,
 		},
 		{
-			name:   "Valid status filter",
-			query:  "?status=pending",
-			status: http.StatusOK,
+			name:      "Out of range limit falls back to default",
+			query:     "?limit=500&page=0",
+			wantPage:  1,
+			wantLimit: 20,
+			wantOK:    true,
 		},
 		{
-			name:   "Max limit boundary",
-			query:  "?limit=100",
-			status: http.StatusOK,
+			name:   "Unknown status filter",
+			query:  "?status=waiting",
+			wantOK: false,
 		},
 	}
 
@@ -448,11 +468,72 @@ func TestListDMCANotices_ValidPaginationParams(t *testing.T) {
 			c, _ := gin.CreateTestContext(w)
 			c.Request = httptest.NewRequest(http.MethodGet, "/api/admin/dmca/notices"+tt.query, nil)
 
-			handler.ListDMCANotices(c)
+			page, limit, _, ok := parseDMCAListParams(c, "notices")
 
-			if w.Code != tt.status {
-				t.Errorf("Expected status %d, got %d", tt.status, w.Code)
+			if ok != tt.wantOK {
+				t.Fatalf("Expected ok %v, got %v", tt.wantOK, ok)
+			}
+			if !ok {
+				if w.Code != http.StatusBadRequest {
+					t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+				}
+				return
+			}
+			if page != tt.wantPage || limit != tt.wantLimit {
+				t.Errorf("Expected page %d limit %d, got page %d limit %d", tt.wantPage, tt.wantLimit, page, limit)
 			}
 		})
 	}
 }
+
+func TestListCounterNotices_InvalidStatusFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := NewDMCAHandler(nil, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/admin/dmca/counter-notices?status=valid", nil)
+
+	handler.ListCounterNotices(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for invalid status filter, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestRejectCounterNotice_Unauthorized(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := NewDMCAHandler(nil, nil)
+
+	counterNoticeID := uuid.New()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/admin/dmca/counter-notices/"+counterNoticeID.String()+"/reject", nil)
+	c.Params = gin.Params{{Key: "id", Value: counterNoticeID.String()}}
+
+	handler.RejectCounterNotice(c)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d for unauthenticated request, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
+
+func TestRemoveStrike_InvalidStrikeID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := NewDMCAHandler(nil, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/admin/dmca/strikes/invalid-id/remove", nil)
+	c.Params = gin.Params{{Key: "id", Value: "invalid-id"}}
+	c.Set("user_id", uuid.New())
+
+	handler.RemoveStrike(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for invalid strike ID, got %d", http.StatusBadRequest, w.Code)
+	}
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// EmailLinkHandler serves first-party tracked email links
+type EmailLinkHandler struct {
+	emailLinkService *services.EmailLinkService
+}
+
+// NewEmailLinkHandler creates a new email link handler
+func NewEmailLinkHandler(emailLinkService *services.EmailLinkService) *EmailLinkHandler {
+	return &EmailLinkHandler{
+		emailLinkService: emailLinkService,
+	}
+}
+
+// RedirectEmailLink records an email click and redirects to the link target
+// GET /r/:token
+func (h *EmailLinkHandler) RedirectEmailLink(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	link, err := h.emailLinkService.Resolve(ctx, c.Param("token"))
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrEmailTrackedLinkNotFound), errors.Is(err, services.ErrEmailLinkExpired):
+			c.Redirect(http.StatusFound, h.emailLinkService.FallbackURL())
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to resolve link"})
+		}
+		return
+	}
+
+	if err := h.emailLinkService.RecordClick(ctx, link, c.ClientIP(), c.Request.UserAgent()); err != nil {
+		log.Printf("Failed to record email click for %s: %v", link.Token, err)
+	}
+
+	c.Redirect(http.StatusFound, link.TargetURL)
+}
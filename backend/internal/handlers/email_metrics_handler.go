@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -17,6 +18,8 @@ import (
 type EmailMetricsHandler struct {
 	emailMetricsService *services.EmailMetricsService
 	emailLogRepo        *repository.EmailLogRepository
+	reengagementService *services.ReengagementService  // may be nil
+	throttleService     *services.EmailThrottleService // may be nil
 	logger              *utils.StructuredLogger
 }
 
@@ -29,6 +32,16 @@ func NewEmailMetricsHandler(emailMetricsService *services.EmailMetricsService, e
 	}
 }
 
+// SetReengagementService enables re-engagement campaign metrics
+func (h *EmailMetricsHandler) SetReengagementService(reengagementService *services.ReengagementService) {
+	h.reengagementService = reengagementService
+}
+
+// SetThrottleService enables the bulk email throttle controls
+func (h *EmailMetricsHandler) SetThrottleService(throttleService *services.EmailThrottleService) {
+	h.throttleService = throttleService
+}
+
 // GetDashboardMetrics returns email metrics for the dashboard
 // @Summary Get email dashboard metrics
 // @Description Returns email delivery metrics for the dashboard including 7-day trends
@@ -86,15 +99,60 @@ func (h *EmailMetricsHandler) GetDashboardMetrics(c *gin.Context) {
 		alerts = make([]models.EmailAlert, 0) // Return empty array on error
 	}
 
+	// Get re-engagement campaign metrics for the same period as the trends
+	campaignMetrics := make([]models.ReengagementCampaignMetrics, 0)
+	if h.reengagementService != nil {
+		campaignMetrics, err = h.reengagementService.CampaignMetrics(c.Request.Context(), now.AddDate(0, 0, -days), now)
+		if err != nil {
+			h.logger.Error("Failed to get campaign metrics", err)
+			campaignMetrics = make([]models.ReengagementCampaignMetrics, 0) // Return empty array on error
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"daily_metrics":    dailyMetrics,
 		"current_metrics":  currentMetrics,
 		"template_metrics": templateMetrics,
+		"campaign_metrics": campaignMetrics,
 		"recent_bounces":   recentBounces,
 		"alerts":           alerts,
 	})
 }
 
+// GetCampaignMetrics returns re-engagement campaign metrics by milestone
+// @Summary Get re-engagement campaign metrics
+// @Description Returns sent, suppressed, clicked and returned counts of re-engagement emails by inactivity milestone
+// @Tags email-metrics
+// @Produce json
+// @Param days query int false "Number of days to include (default: 30, max: 90)"
+// @Success 200 {array} models.ReengagementCampaignMetrics
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/v1/admin/email/metrics/campaigns [get]
+func (h *EmailMetricsHandler) GetCampaignMetrics(c *gin.Context) {
+	if h.reengagementService == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Re-engagement emails are not available"})
+		return
+	}
+
+	days := 30
+	if daysParam := c.Query("days"); daysParam != "" {
+		if d, err := strconv.Atoi(daysParam); err == nil && d > 0 && d <= 90 {
+			days = d
+		}
+	}
+
+	now := time.Now()
+	metrics, err := h.reengagementService.CampaignMetrics(c.Request.Context(), now.AddDate(0, 0, -days), now)
+	if err != nil {
+		h.logger.Error("Failed to get campaign metrics", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve metrics"})
+		return
+	}
+
+	c.JSON(http.StatusOK, metrics)
+}
+
 // GetMetrics returns email metrics for a specific period
 // @Summary Get email metrics
 // @Description Returns email metrics for a specific time period
@@ -408,3 +466,74 @@ func (h *EmailMetricsHandler) ResolveAlert(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Alert resolved"})
 }
+
+// GetThrottle returns the bulk email throttle settings and current send rate
+// @Summary Get bulk email throttle
+// @Description Returns the bulk email throttle settings with the current per-minute cap and sends this minute
+// @Tags email-metrics
+// @Produce json
+// @Success 200 {object} models.EmailThrottleStatus
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/v1/admin/email/throttle [get]
+func (h *EmailMetricsHandler) GetThrottle(c *gin.Context) {
+	if h.throttleService == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Email throttling is not available"})
+		return
+	}
+
+	status, err := h.throttleService.GetStatus(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to get email throttle", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve email throttle"})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// UpdateThrottle replaces the bulk email throttle settings. Email workers
+// obey the new settings within 30 seconds.
+// @Summary Update bulk email throttle
+// @Description Pauses or resumes bulk email and sets its rate, ramp-up and send windows
+// @Tags email-metrics
+// @Accept json
+// @Produce json
+// @Param request body models.UpdateEmailThrottleRequest true "Throttle settings"
+// @Success 200 {object} models.EmailThrottleSettings
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/v1/admin/email/throttle [put]
+func (h *EmailMetricsHandler) UpdateThrottle(c *gin.Context) {
+	if h.throttleService == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Email throttling is not available"})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req models.UpdateEmailThrottleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings, err := h.throttleService.UpdateSettings(c.Request.Context(), &req, userID.(uuid.UUID))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidEmailThrottle) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.Error("Failed to update email throttle", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update email throttle"})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
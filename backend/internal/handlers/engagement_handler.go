@@ -140,6 +140,24 @@ func (h *EngagementHandler) GetTrendingMetrics(c *gin.Context) {
 	c.JSON(http.StatusOK, trending)
 }
 
+// GetEmailAttribution returns email click attribution joined to on-site engagement
+// GET /api/v1/admin/analytics/email-attribution?days=30
+func (h *EngagementHandler) GetEmailAttribution(c *gin.Context) {
+	days, _ := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if days <= 0 || days > 365 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "days must be between 1 and 365"})
+		return
+	}
+
+	attribution, err := h.engagementService.GetEmailClickAttribution(c.Request.Context(), days)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve email attribution"})
+		return
+	}
+
+	c.JSON(http.StatusOK, attribution)
+}
+
 // GetContentEngagementScore returns engagement score for a clip
 // GET /api/v1/clips/:id/engagement
 func (h *EngagementHandler) GetContentEngagementScore(c *gin.Context) {
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// FeatureFlagHandler handles feature flag management and evaluation
+type FeatureFlagHandler struct {
+	flagService *services.FeatureFlagService
+}
+
+// NewFeatureFlagHandler creates a new feature flag handler
+func NewFeatureFlagHandler(flagService *services.FeatureFlagService) *FeatureFlagHandler {
+	return &FeatureFlagHandler{
+		flagService: flagService,
+	}
+}
+
+// GetFlags returns which flags are on for the caller, so clients can gate UI
+// GET /api/v1/feature-flags
+func (h *FeatureFlagHandler) GetFlags(c *gin.Context) {
+	var user *models.User
+	if userVal, exists := c.Get("user"); exists {
+		user, _ = userVal.(*models.User)
+	}
+
+	flags, err := h.flagService.EvaluateAll(c.Request.Context(), models.NewFeatureFlagSubject(user))
+	if err != nil {
+		h.respondError(c, err, "Failed to evaluate feature flags")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": flags})
+}
+
+// AdminListFlags returns every flag
+// GET /api/v1/admin/feature-flags
+func (h *FeatureFlagHandler) AdminListFlags(c *gin.Context) {
+	flags, err := h.flagService.ListFlags(c.Request.Context())
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve feature flags")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": flags})
+}
+
+// AdminCreateFlag creates a flag
+// POST /api/v1/admin/feature-flags
+func (h *FeatureFlagHandler) AdminCreateFlag(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	adminID := userIDVal.(uuid.UUID)
+
+	var req models.CreateFeatureFlagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	flag, err := h.flagService.CreateFlag(c.Request.Context(), adminID, &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to create feature flag")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": flag})
+}
+
+// AdminGetFlag returns a flag
+// GET /api/v1/admin/feature-flags/:key
+func (h *FeatureFlagHandler) AdminGetFlag(c *gin.Context) {
+	flag, err := h.flagService.GetFlag(c.Request.Context(), c.Param("key"))
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve feature flag")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": flag})
+}
+
+// AdminUpdateFlag changes a flag's switch, rollout or rules
+// PATCH /api/v1/admin/feature-flags/:key
+func (h *FeatureFlagHandler) AdminUpdateFlag(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	adminID := userIDVal.(uuid.UUID)
+
+	var req models.UpdateFeatureFlagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	flag, err := h.flagService.UpdateFlag(c.Request.Context(), c.Param("key"), adminID, &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to update feature flag")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": flag})
+}
+
+// AdminDeleteFlag removes a flag
+// DELETE /api/v1/admin/feature-flags/:key
+func (h *FeatureFlagHandler) AdminDeleteFlag(c *gin.Context) {
+	if err := h.flagService.DeleteFlag(c.Request.Context(), c.Param("key")); err != nil {
+		h.respondError(c, err, "Failed to delete feature flag")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Feature flag deleted"})
+}
+
+// AdminEvaluateFlag shows how a flag evaluates for a user and why
+// POST /api/v1/admin/feature-flags/:key/evaluate
+func (h *FeatureFlagHandler) AdminEvaluateFlag(c *gin.Context) {
+	var req models.EvaluateFeatureFlagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	evaluation, err := h.flagService.EvaluateForUser(c.Request.Context(), c.Param("key"), req.UserID)
+	if err != nil {
+		h.respondError(c, err, "Failed to evaluate feature flag")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": evaluation})
+}
+
+func (h *FeatureFlagHandler) respondError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, repository.ErrFeatureFlagNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+	case errors.Is(err, repository.ErrFeatureFlagKeyTaken):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrFeatureFlagInvalidKey),
+		errors.Is(err, services.ErrFeatureFlagInvalidRule):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
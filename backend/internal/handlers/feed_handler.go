@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
@@ -9,9 +10,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/pagination"
 	"github.com/subculture-collective/clipper/internal/repository"
 	"github.com/subculture-collective/clipper/internal/services"
-	"github.com/subculture-collective/clipper/internal/utils"
 )
 
 // validateDateFilter validates and normalizes a date string expected to be in ISO 8601 format
@@ -38,6 +39,8 @@ type FeedHandler struct {
 	voteRepo     *repository.VoteRepository
 	favoriteRepo *repository.FavoriteRepository
 	userRepo     *repository.UserRepository
+
+	socialFeedService *services.SocialFeedService
 }
 
 func NewFeedHandler(
@@ -56,6 +59,11 @@ func NewFeedHandler(
 	}
 }
 
+// SetSocialFeedService enables the social feed of followed users' activity
+func (h *FeedHandler) SetSocialFeedService(socialFeedService *services.SocialFeedService) {
+	h.socialFeedService = socialFeedService
+}
+
 // CreateFeed creates a new feed
 func (h *FeedHandler) CreateFeed(c *gin.Context) {
 	userID, exists := c.Get("user_id")
@@ -149,6 +157,10 @@ func (h *FeedHandler) UpdateFeed(c *gin.Context) {
 	}
 
 	feed, err := h.feedService.UpdateFeed(c.Request.Context(), feedID, userID.(uuid.UUID), &req)
+	if errors.Is(err, services.ErrFeedPermissionDenied) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -202,7 +214,19 @@ func (h *FeedHandler) AddClipToFeed(c *gin.Context) {
 		return
 	}
 
-	feedItem, err := h.feedService.AddClipToFeed(c.Request.Context(), feedID, userID.(uuid.UUID), req.ClipID)
+	feedItem, err := h.feedService.AddClipToFeed(c.Request.Context(), feedID, userID.(uuid.UUID), req.ClipID, req.Version)
+	if errors.Is(err, repository.ErrFeedVersionConflict) {
+		h.respondFeedConflict(c, feedID, userID.(uuid.UUID))
+		return
+	}
+	if errors.Is(err, services.ErrSmartFeedManaged) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, services.ErrFeedPermissionDenied) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -233,13 +257,36 @@ func (h *FeedHandler) RemoveClipFromFeed(c *gin.Context) {
 		return
 	}
 
-	err = h.feedService.RemoveClipFromFeed(c.Request.Context(), feedID, userID.(uuid.UUID), clipID)
+	// DELETE has no body, so the expected version comes in the query
+	var expectedVersion *int
+	if value := c.Query("version"); value != "" {
+		version, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid version"})
+			return
+		}
+		expectedVersion = &version
+	}
+
+	version, err := h.feedService.RemoveClipFromFeed(c.Request.Context(), feedID, userID.(uuid.UUID), clipID, expectedVersion)
+	if errors.Is(err, repository.ErrFeedVersionConflict) {
+		h.respondFeedConflict(c, feedID, userID.(uuid.UUID))
+		return
+	}
+	if errors.Is(err, services.ErrSmartFeedManaged) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, services.ErrFeedPermissionDenied) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Clip removed from feed successfully"})
+	c.JSON(http.StatusOK, gin.H{"message": "Clip removed from feed successfully", "version": version})
 }
 
 // GetFeedClips retrieves all clips in a feed
@@ -287,13 +334,350 @@ func (h *FeedHandler) ReorderFeedClips(c *gin.Context) {
 		return
 	}
 
-	err = h.feedService.ReorderFeedClips(c.Request.Context(), feedID, userID.(uuid.UUID), req.ClipIDs)
+	version, err := h.feedService.ReorderFeedClips(c.Request.Context(), feedID, userID.(uuid.UUID), req.ClipIDs, req.Version)
+	if errors.Is(err, repository.ErrFeedVersionConflict) {
+		h.respondFeedConflict(c, feedID, userID.(uuid.UUID))
+		return
+	}
+	if errors.Is(err, services.ErrSmartFeedManaged) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, services.ErrFeedPermissionDenied) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Clips reordered successfully", "version": version})
+}
+
+// MoveFeedClip moves one clip of a feed before another, or to the end
+func (h *FeedHandler) MoveFeedClip(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	feedIDParam := c.Param("feedId")
+	feedID, err := uuid.Parse(feedIDParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid feed ID"})
+		return
+	}
+
+	var req models.MoveFeedClipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	version, err := h.feedService.MoveFeedClip(c.Request.Context(), feedID, userID.(uuid.UUID), &req)
+	switch {
+	case errors.Is(err, repository.ErrFeedVersionConflict):
+		h.respondFeedConflict(c, feedID, userID.(uuid.UUID))
+		return
+	case errors.Is(err, repository.ErrFeedItemNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, services.ErrSmartFeedManaged):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, services.ErrFeedPermissionDenied):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Clip moved successfully", "version": version})
+}
+
+// GetSmartFeedRules returns the rules that pick a smart feed's clips
+// GET /api/v1/users/:id/feeds/:feedId/rules
+func (h *FeedHandler) GetSmartFeedRules(c *gin.Context) {
+	feedID, err := uuid.Parse(c.Param("feedId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid feed ID"})
+		return
+	}
+
+	var requestingUserID *uuid.UUID
+	if id, exists := c.Get("user_id"); exists {
+		uid := id.(uuid.UUID)
+		requestingUserID = &uid
+	}
+
+	rules, err := h.feedService.GetSmartFeedRules(c.Request.Context(), feedID, requestingUserID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+// SetSmartFeedRules turns a feed into a smart feed, or replaces its rules
+// PUT /api/v1/users/:id/feeds/:feedId/rules
+func (h *FeedHandler) SetSmartFeedRules(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	feedID, err := uuid.Parse(c.Param("feedId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid feed ID"})
+		return
+	}
+
+	var req models.SmartFeedRulesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rules, err := h.feedService.SetSmartFeedRules(c.Request.Context(), feedID, userID.(uuid.UUID), &req)
+	if errors.Is(err, services.ErrSmartFeedNoConditions) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+// DeleteSmartFeedRules turns a smart feed back into a manually curated feed
+// DELETE /api/v1/users/:id/feeds/:feedId/rules
+func (h *FeedHandler) DeleteSmartFeedRules(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	feedID, err := uuid.Parse(c.Param("feedId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid feed ID"})
+		return
+	}
+
+	err = h.feedService.DeleteSmartFeedRules(c.Request.Context(), feedID, userID.(uuid.UUID))
+	if errors.Is(err, repository.ErrSmartFeedRulesNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Smart feed rules removed successfully"})
+}
+
+// ListFeedCollaborators lists a feed's collaborators and pending invites
+// GET /api/v1/users/:id/feeds/:feedId/collaborators
+func (h *FeedHandler) ListFeedCollaborators(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	feedID, err := uuid.Parse(c.Param("feedId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid feed ID"})
+		return
+	}
+
+	collaborators, err := h.feedService.ListFeedCollaborators(c.Request.Context(), feedID, userID.(uuid.UUID))
+	if err != nil {
+		h.respondCollaboratorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, collaborators)
+}
+
+// InviteFeedCollaborator invites a user to co-curate a feed
+// POST /api/v1/users/:id/feeds/:feedId/collaborators
+func (h *FeedHandler) InviteFeedCollaborator(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	feedID, err := uuid.Parse(c.Param("feedId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid feed ID"})
+		return
+	}
+
+	var req models.InviteFeedCollaboratorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	collaborator, err := h.feedService.InviteFeedCollaborator(c.Request.Context(), feedID, userID.(uuid.UUID), &req)
+	if err != nil {
+		h.respondCollaboratorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, collaborator)
+}
+
+// UpdateFeedCollaborator replaces a collaborator's roles
+// PUT /api/v1/users/:id/feeds/:feedId/collaborators/:userId
+func (h *FeedHandler) UpdateFeedCollaborator(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	feedID, err := uuid.Parse(c.Param("feedId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid feed ID"})
+		return
+	}
+	collaboratorID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req models.UpdateFeedCollaboratorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	collaborator, err := h.feedService.UpdateFeedCollaborator(c.Request.Context(), feedID, userID.(uuid.UUID), collaboratorID, req.Roles)
+	if err != nil {
+		h.respondCollaboratorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, collaborator)
+}
+
+// RemoveFeedCollaborator removes a collaborator, or lets invitees decline or leave
+// DELETE /api/v1/users/:id/feeds/:feedId/collaborators/:userId
+func (h *FeedHandler) RemoveFeedCollaborator(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	feedID, err := uuid.Parse(c.Param("feedId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid feed ID"})
+		return
+	}
+	collaboratorID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	err = h.feedService.RemoveFeedCollaborator(c.Request.Context(), feedID, userID.(uuid.UUID), collaboratorID)
+	if err != nil {
+		h.respondCollaboratorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Collaborator removed successfully"})
+}
+
+// AcceptFeedInvite accepts the current user's invite to co-curate a feed
+// POST /api/v1/users/:id/feeds/:feedId/collaborators/accept
+func (h *FeedHandler) AcceptFeedInvite(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	feedID, err := uuid.Parse(c.Param("feedId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid feed ID"})
+		return
+	}
+
+	collaborator, err := h.feedService.AcceptFeedInvite(c.Request.Context(), feedID, userID.(uuid.UUID))
+	if err != nil {
+		h.respondCollaboratorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, collaborator)
+}
+
+// ListFeedCollaborations lists the feeds the current user is invited to co-curate
+// GET /api/v1/feeds/collaborations?status=pending|accepted
+func (h *FeedHandler) ListFeedCollaborations(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	status := c.Query("status")
+	if status != "" && status != models.FeedCollaboratorPending && status != models.FeedCollaboratorAccepted {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be pending or accepted"})
+		return
+	}
+
+	collaborations, err := h.feedService.ListFeedCollaborations(c.Request.Context(), userID.(uuid.UUID), status)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Clips reordered successfully"})
+	c.JSON(http.StatusOK, collaborations)
+}
+
+// respondCollaboratorError maps feed collaboration errors to responses
+func (h *FeedHandler) respondCollaboratorError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrFeedPermissionDenied):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrFeedCollaboratorSelf):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrFeedCollaboratorExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrFeedCollaboratorNotFound), errors.Is(err, repository.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// respondFeedConflict refuses an edit made against an old version of a feed
+// with the feed's latest state, so the client can reapply it
+func (h *FeedHandler) respondFeedConflict(c *gin.Context, feedID, userID uuid.UUID) {
+	response := gin.H{"error": repository.ErrFeedVersionConflict.Error()}
+	ctx := c.Request.Context()
+	if feed, err := h.feedService.GetFeed(ctx, feedID, &userID); err == nil {
+		response["feed"] = feed
+	}
+	if clips, err := h.feedService.GetFeedClips(ctx, feedID, &userID); err == nil {
+		response["clips"] = clips
+	}
+	c.JSON(http.StatusConflict, response)
 }
 
 // FollowFeed follows a feed
@@ -449,6 +833,57 @@ func (h *FeedHandler) GetFollowingFeed(c *gin.Context) {
 	})
 }
 
+// GetSocialFeed retrieves recent activity of followed users: clips they
+// submitted, public feeds they created and badges they earned
+// GET /api/v1/feeds/social
+func (h *FeedHandler) GetSocialFeed(c *gin.Context) {
+	if h.socialFeedService == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "social feed is not available"})
+		return
+	}
+
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	userID := userIDInterface.(uuid.UUID)
+
+	limit := 20
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 && parsedLimit <= 100 {
+			limit = parsedLimit
+		}
+	}
+
+	var cursor *pagination.Cursor
+	if cursorStr := c.Query("cursor"); cursorStr != "" {
+		decoded, err := pagination.Decode(cursorStr, services.SocialFeedSort)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or expired cursor"})
+			return
+		}
+		cursor = decoded
+	}
+
+	activity, page, err := h.socialFeedService.GetFeed(c.Request.Context(), userID, cursor, limit)
+	if err != nil {
+		log.Printf("Error retrieving social feed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve social feed"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    activity,
+		"pagination": gin.H{
+			"limit":       limit,
+			"has_more":    page.HasMore,
+			"next_cursor": page.NextCursor,
+		},
+	})
+}
+
 // GetFilteredClips handles comprehensive feed filtering with multiple criteria
 // GET /api/v1/feeds/clips
 // Supports both offset-based (legacy) and cursor-based pagination
@@ -459,6 +894,8 @@ func (h *FeedHandler) GetFilteredClips(c *gin.Context) {
 	tags := c.QueryArray("filter[tags]")
 	dateFrom := c.Query("filter[date_from]")
 	dateTo := c.Query("filter[date_to]")
+	patch := c.Query("filter[patch]")
+	currentPatch := c.Query("filter[current_patch]") == "true"
 	sort := c.DefaultQuery("sort", "trending")
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
@@ -499,15 +936,31 @@ func (h *FeedHandler) GetFilteredClips(c *gin.Context) {
 		}
 	}
 
+	if patch != "" {
+		if _, err := uuid.Parse(patch); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid patch. Use a game patch ID"})
+			return
+		}
+	}
+
 	// Build filters for clip repository
 	filters := repository.ClipFilters{
 		Sort:              sort,
 		UserSubmittedOnly: true, // Only show user-submitted clips in feed
+		CurrentPatch:      currentPatch,
+	}
+	if patch != "" {
+		filters.PatchID = &patch
 	}
 
 	// Apply cursor if provided (takes precedence over offset)
 	if cursor != "" {
-		filters.Cursor = &cursor
+		decoded, err := pagination.Decode(cursor, sort)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or expired cursor"})
+			return
+		}
+		filters.Cursor = decoded
 		offset = 0 // Ignore offset when using cursor
 	}
 
@@ -541,84 +994,39 @@ func (h *FeedHandler) GetFilteredClips(c *gin.Context) {
 		userID = &uid
 	}
 
-	// Fetch clips using feed service with user data enrichment (fetch limit+1 to check if there are more)
-	fetchLimit := limit + 1
-	clips, total, err := h.feedService.GetFilteredClipsWithUserData(c.Request.Context(), filters, fetchLimit, offset, userID)
-	if err != nil {
-		log.Printf("Error fetching filtered clips: %v", err)
-		// Check if error is cursor-related (client error)
-		if filters.Cursor != nil && *filters.Cursor != "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor: " + err.Error()})
-		} else {
+	paginationResponse := gin.H{
+		"limit":  limit,
+		"offset": offset,
+	}
+	var clips []services.ClipWithUserContext
+	if offset > 0 {
+		// Legacy offset pagination (fetch limit+1 to check if there are more)
+		var total int
+		clips, total, err = h.feedService.GetFilteredClipsWithUserData(c.Request.Context(), filters, limit+1, offset, userID)
+		if err != nil {
+			log.Printf("Error fetching filtered clips: %v", err)
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve filtered clips"})
+			return
 		}
-		return
-	}
-	// This will be implemented in a follow-up change
-
-	// Determine if there are more results
-	hasMore := len(clips) > limit
-	if hasMore {
-		clips = clips[:limit] // Trim to requested limit
-	}
-
-	// Generate next cursor from the last clip
-	var nextCursor *string
-	if hasMore && len(clips) > 0 {
-		lastClip := clips[len(clips)-1]
-		var sortValue float64
-		switch sort {
-		case "trending":
-			// Use coalesced value to match query behavior
-			if lastClip.TrendingScore != 0 {
-				sortValue = lastClip.TrendingScore
-			} else {
-				// Fallback: estimate trending score if not calculated
-				// This matches the COALESCE behavior in the query
-				sortValue = float64(lastClip.CreatedAt.Unix())
-			}
-		case "popular":
-			// Use coalesced value to match query behavior
-			if lastClip.PopularityIndex != 0 {
-				sortValue = float64(lastClip.PopularityIndex)
-			} else if lastClip.EngagementCount != 0 {
-				sortValue = float64(lastClip.EngagementCount)
-			} else {
-				// Fallback calculation matching COALESCE
-				sortValue = float64(lastClip.ViewCount + lastClip.VoteScore*2 + lastClip.CommentCount*3 + lastClip.FavoriteCount*2)
-			}
-		case "new":
-			sortValue = float64(lastClip.CreatedAt.Unix())
-		case "top":
-			sortValue = float64(lastClip.VoteScore)
-		case "discussed":
-			sortValue = float64(lastClip.CommentCount)
-		case "hot", "rising":
-			sortValue = float64(lastClip.CreatedAt.Unix())
-		default:
-			sortValue = float64(lastClip.CreatedAt.Unix())
+		hasMore := len(clips) > limit
+		if hasMore {
+			clips = clips[:limit]
 		}
-		encodedCursor := utils.EncodeCursor(sort, sortValue, lastClip.ID, lastClip.CreatedAt.Unix())
-		nextCursor = &encodedCursor
-	}
-
-	// Build pagination response
-	paginationResponse := gin.H{
-		"limit":    limit,
-		"offset":   offset,
-		"has_more": hasMore,
-	}
-
-	// Only include total and total_pages for offset-based pagination to avoid COUNT(*) overhead with cursors
-	if cursor == "" {
-		totalPages := (total + limit - 1) / limit
+		paginationResponse["has_more"] = hasMore
+		paginationResponse["next_cursor"] = nil
 		paginationResponse["total"] = total
-		paginationResponse["total_pages"] = totalPages
-	}
-
-	// Add cursor to response if generated
-	if nextCursor != nil {
-		paginationResponse["cursor"] = *nextCursor
+		paginationResponse["total_pages"] = (total + limit - 1) / limit
+	} else {
+		// Cursor pagination skips the COUNT(*), so no total is returned
+		var page pagination.PageInfo
+		clips, page, err = h.feedService.GetFilteredClipsCursor(c.Request.Context(), filters, limit, userID)
+		if err != nil {
+			log.Printf("Error fetching filtered clips: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve filtered clips"})
+			return
+		}
+		paginationResponse["has_more"] = page.HasMore
+		paginationResponse["next_cursor"] = page.NextCursor
 	}
 
 	// Return response with filter metadata
@@ -627,12 +1035,14 @@ func (h *FeedHandler) GetFilteredClips(c *gin.Context) {
 		"clips":      clips,
 		"pagination": paginationResponse,
 		"filters_applied": gin.H{
-			"games":     games,
-			"streamers": streamers,
-			"tags":      tags,
-			"date_from": dateFrom,
-			"date_to":   dateTo,
-			"sort":      sort,
+			"games":         games,
+			"streamers":     streamers,
+			"tags":          tags,
+			"date_from":     dateFrom,
+			"date_to":       dateTo,
+			"patch":         patch,
+			"current_patch": currentPatch,
+			"sort":          sort,
 		},
 	})
 }
@@ -79,6 +79,17 @@ func (h *GameHandler) ListGameClips(c *gin.Context) {
 	pageStr := c.DefaultQuery("page", "1")
 	sort := c.DefaultQuery("sort", "hot")
 	timeframe := c.Query("timeframe")
+	patchID := c.Query("patch_id")
+	currentPatch := c.Query("current_patch") == "true"
+
+	if patchID != "" {
+		if _, err := uuid.Parse(patchID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid patch_id",
+			})
+			return
+		}
+	}
 
 	limit, err := strconv.Atoi(limitStr)
 	if err != nil || limit < 1 || limit > 100 {
@@ -112,9 +123,13 @@ func (h *GameHandler) ListGameClips(c *gin.Context) {
 
 	// Build filters for clips
 	filters := repository.ClipFilters{
-		GameID:    &twitchGameID,
-		Sort:      sort,
-		Timeframe: &timeframe,
+		GameID:       &twitchGameID,
+		Sort:         sort,
+		Timeframe:    &timeframe,
+		CurrentPatch: currentPatch,
+	}
+	if patchID != "" {
+		filters.PatchID = &patchID
 	}
 
 	clips, total, err := h.clipRepo.ListWithFilters(c.Request.Context(), filters, limit, offset)
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// GamePatchHandler handles game patches
+type GamePatchHandler struct {
+	patchService *services.GamePatchService
+}
+
+// NewGamePatchHandler creates a new game patch handler
+func NewGamePatchHandler(patchService *services.GamePatchService) *GamePatchHandler {
+	return &GamePatchHandler{
+		patchService: patchService,
+	}
+}
+
+// ListPatches returns a game's patches, newest first
+// GET /api/v1/games/:gameId/patches
+func (h *GamePatchHandler) ListPatches(c *gin.Context) {
+	patches, err := h.patchService.ListPatches(c.Request.Context(), c.Param("gameId"))
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve game patches")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": patches})
+}
+
+// GetCurrentPatch returns the patch a game is on now
+// GET /api/v1/games/:gameId/patches/current
+func (h *GamePatchHandler) GetCurrentPatch(c *gin.Context) {
+	patch, err := h.patchService.CurrentPatch(c.Request.Context(), c.Param("gameId"))
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve current game patch")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": patch})
+}
+
+// AdminCreatePatch records a patch of a game and tags the game's clips
+// POST /api/v1/admin/games/:gameId/patches
+func (h *GamePatchHandler) AdminCreatePatch(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	adminID := userIDVal.(uuid.UUID)
+
+	var req models.CreateGamePatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	patch, err := h.patchService.CreatePatch(c.Request.Context(), c.Param("gameId"), adminID, &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to create game patch")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": patch})
+}
+
+// AdminUpdatePatch changes a patch and retags its game's clips
+// PATCH /api/v1/admin/games/patches/:id
+func (h *GamePatchHandler) AdminUpdatePatch(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid patch ID"})
+		return
+	}
+
+	var req models.UpdateGamePatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	patch, err := h.patchService.UpdatePatch(c.Request.Context(), id, &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to update game patch")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": patch})
+}
+
+// AdminDeletePatch removes a patch and retags its clips
+// DELETE /api/v1/admin/games/patches/:id
+func (h *GamePatchHandler) AdminDeletePatch(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid patch ID"})
+		return
+	}
+
+	if err := h.patchService.DeletePatch(c.Request.Context(), id); err != nil {
+		h.respondError(c, err, "Failed to delete game patch")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Game patch deleted"})
+}
+
+func (h *GamePatchHandler) respondError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrGameNotFound),
+		errors.Is(err, repository.ErrGamePatchNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrGamePatchExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// I18nHandler serves the UI string catalog and its admin endpoints
+type I18nHandler struct {
+	i18nService *services.I18nService
+}
+
+// NewI18nHandler creates a new i18n handler
+func NewI18nHandler(i18nService *services.I18nService) *I18nHandler {
+	return &I18nHandler{i18nService: i18nService}
+}
+
+// GetCatalog returns a locale's strings merged over its fallback chain
+// GET /api/v1/i18n/:locale
+// Honors If-None-Match, answering 304 when the client's copy is current
+func (h *I18nHandler) GetCatalog(c *gin.Context) {
+	catalog, err := h.i18nService.GetCatalog(c.Request.Context(), c.Param("locale"))
+	if err != nil {
+		h.respondError(c, err, "Failed to load translations")
+		return
+	}
+
+	etag := `"` + catalog.Version + `"`
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "public, max-age=300")
+	c.Header("Content-Language", catalog.Locale)
+
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.JSON(http.StatusOK, catalog)
+}
+
+// etagMatches reports whether an If-None-Match header names etag
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// ReportMissingKeys records keys a client could not translate
+// POST /api/v1/i18n/:locale/missing
+func (h *I18nHandler) ReportMissingKeys(c *gin.Context) {
+	var req models.ReportMissingI18nKeysRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	if err := h.i18nService.ReportMissingKeys(c.Request.Context(), c.Param("locale"), req.Keys); err != nil {
+		h.respondError(c, err, "Failed to record missing keys")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Missing keys recorded",
+	})
+}
+
+// ListLocales returns every locale, including disabled ones
+// GET /api/v1/admin/i18n/locales
+func (h *I18nHandler) ListLocales(c *gin.Context) {
+	locales, err := h.i18nService.ListLocales(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to list locales",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"locales": locales,
+	})
+}
+
+// UpsertLocale creates or updates a locale
+// PUT /api/v1/admin/i18n/locales/:locale
+func (h *I18nHandler) UpsertLocale(c *gin.Context) {
+	var req models.UpsertI18nLocaleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	locale, err := h.i18nService.UpsertLocale(c.Request.Context(), c.Param("locale"), &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to save locale")
+		return
+	}
+
+	c.JSON(http.StatusOK, locale)
+}
+
+// GetLocaleStrings returns the strings stored for a locale, without fallbacks
+// GET /api/v1/admin/i18n/locales/:locale/strings
+func (h *I18nHandler) GetLocaleStrings(c *gin.Context) {
+	values, err := h.i18nService.GetLocaleStrings(c.Request.Context(), c.Param("locale"))
+	if err != nil {
+		h.respondError(c, err, "Failed to load strings")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"strings": values,
+	})
+}
+
+// ImportStrings saves a locale file. The body is the file itself, either flat
+// dotted keys or nested objects.
+// PUT /api/v1/admin/i18n/locales/:locale/strings
+func (h *I18nHandler) ImportStrings(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Unauthorized",
+		})
+		return
+	}
+	userID, ok := userIDVal.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Invalid user ID",
+		})
+		return
+	}
+
+	var file map[string]interface{}
+	if err := c.ShouldBindJSON(&file); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid locale file",
+		})
+		return
+	}
+
+	saved, err := h.i18nService.ImportStrings(c.Request.Context(), c.Param("locale"), file, userID)
+	if err != nil {
+		h.respondError(c, err, "Failed to save strings")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Strings saved",
+		"saved":   saved,
+	})
+}
+
+// DeleteString removes a locale's string so the key falls back again
+// DELETE /api/v1/admin/i18n/locales/:locale/strings/:key
+func (h *I18nHandler) DeleteString(c *gin.Context) {
+	if err := h.i18nService.DeleteString(c.Request.Context(), c.Param("locale"), c.Param("key")); err != nil {
+		h.respondError(c, err, "Failed to delete string")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "String deleted",
+	})
+}
+
+// ListMissingKeys returns keys clients reported as untranslated
+// GET /api/v1/admin/i18n/missing?locale=es&page=1&limit=50
+func (h *I18nHandler) ListMissingKeys(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 50
+	}
+
+	missing, err := h.i18nService.ListMissingKeys(c.Request.Context(), c.Query("locale"), limit, (page-1)*limit)
+	if err != nil {
+		h.respondError(c, err, "Failed to list missing keys")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"missing": missing,
+		"page":    page,
+		"limit":   limit,
+	})
+}
+
+// respondError maps catalog errors to status codes
+func (h *I18nHandler) respondError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrInvalidLocale),
+		errors.Is(err, services.ErrInvalidI18nKey),
+		errors.Is(err, services.ErrInvalidLocaleFile),
+		errors.Is(err, services.ErrI18nFallbackCycle):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrLocaleNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Locale not found",
+		})
+	case errors.Is(err, repository.ErrI18nStringNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Translation not found",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fallback,
+		})
+	}
+}
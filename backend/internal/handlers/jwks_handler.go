@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	jwtpkg "github.com/subculture-collective/clipper/pkg/jwt"
+)
+
+// JWKSHandler publishes the public keys that verify Clipper-issued JWTs
+type JWKSHandler struct {
+	jwtManager *jwtpkg.Manager
+}
+
+// NewJWKSHandler creates a new JWKS handler
+func NewJWKSHandler(jwtManager *jwtpkg.Manager) *JWKSHandler {
+	return &JWKSHandler{
+		jwtManager: jwtManager,
+	}
+}
+
+// GetJWKS returns the JSON Web Key Set for token verification
+// GET /.well-known/jwks.json
+func (h *JWKSHandler) GetJWKS(c *gin.Context) {
+	// Short cache so verifiers pick up newly published keys before they start signing
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.jwtManager.JWKS())
+}
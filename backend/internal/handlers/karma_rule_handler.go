@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// KarmaRuleHandler handles admin management of karma rules
+type KarmaRuleHandler struct {
+	reputationService *services.ReputationService
+}
+
+// NewKarmaRuleHandler creates a new karma rule handler
+func NewKarmaRuleHandler(reputationService *services.ReputationService) *KarmaRuleHandler {
+	return &KarmaRuleHandler{
+		reputationService: reputationService,
+	}
+}
+
+// ListRules lists karma rules
+// GET /api/v1/admin/karma-rules
+func (h *KarmaRuleHandler) ListRules(c *gin.Context) {
+	rules, err := h.reputationService.ListKarmaRules(c.Request.Context())
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve karma rules")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": rules})
+}
+
+// GetRule returns the karma rule for an event type
+// GET /api/v1/admin/karma-rules/:event_type
+func (h *KarmaRuleHandler) GetRule(c *gin.Context) {
+	rule, err := h.reputationService.GetKarmaRule(c.Request.Context(), c.Param("event_type"))
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve karma rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// CreateRule creates the karma rule for an event type
+// POST /api/v1/admin/karma-rules
+func (h *KarmaRuleHandler) CreateRule(c *gin.Context) {
+	adminID, ok := h.adminID(c)
+	if !ok {
+		return
+	}
+
+	var req models.KarmaRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.reputationService.CreateKarmaRule(c.Request.Context(), adminID, &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to create karma rule")
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+// UpdateRule replaces a karma rule's points, cap, cooldown and status
+// PUT /api/v1/admin/karma-rules/:event_type
+func (h *KarmaRuleHandler) UpdateRule(c *gin.Context) {
+	adminID, ok := h.adminID(c)
+	if !ok {
+		return
+	}
+
+	var req models.KarmaRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.reputationService.UpdateKarmaRule(c.Request.Context(), adminID, c.Param("event_type"), &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to update karma rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// DeleteRule deletes a karma rule, so its event type awards no karma
+// DELETE /api/v1/admin/karma-rules/:event_type
+func (h *KarmaRuleHandler) DeleteRule(c *gin.Context) {
+	if err := h.reputationService.DeleteKarmaRule(c.Request.Context(), c.Param("event_type")); err != nil {
+		h.respondError(c, err, "Failed to delete karma rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Karma rule deleted"})
+}
+
+func (h *KarmaRuleHandler) adminID(c *gin.Context) (uuid.UUID, bool) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return uuid.Nil, false
+	}
+	return userIDVal.(uuid.UUID), true
+}
+
+// respondError maps karma rule errors to HTTP responses
+func (h *KarmaRuleHandler) respondError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, repository.ErrKarmaRuleNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrKarmaRuleExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrKarmaRuleInvalidEventType):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrKarmaRulesUnavailable):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
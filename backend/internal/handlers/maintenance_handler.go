@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// MaintenanceHandler lets admins switch the API's read-only maintenance mode
+type MaintenanceHandler struct {
+	maintenanceService *services.MaintenanceService
+}
+
+// NewMaintenanceHandler creates a new maintenance handler
+func NewMaintenanceHandler(maintenanceService *services.MaintenanceService) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		maintenanceService: maintenanceService,
+	}
+}
+
+// GetStatus returns the current maintenance mode and who set it
+// GET /api/v1/admin/maintenance
+func (h *MaintenanceHandler) GetStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"data": h.maintenanceService.Status(c.Request.Context())})
+}
+
+// UpdateStatus turns read-only maintenance mode on or off
+// PUT /api/v1/admin/maintenance
+func (h *MaintenanceHandler) UpdateStatus(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	adminID := userIDVal.(uuid.UUID)
+
+	var req models.UpdateMaintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	status, err := h.maintenanceService.SetStatus(c.Request.Context(), adminID, &req, c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		if errors.Is(err, services.ErrMaintenanceUnavailable) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update maintenance mode"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": status})
+}
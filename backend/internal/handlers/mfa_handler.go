@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -8,23 +9,32 @@ import (
 	"github.com/google/uuid"
 	"github.com/subculture-collective/clipper/config"
 	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
 	"github.com/subculture-collective/clipper/internal/services"
 )
 
 // MFAHandler handles MFA-related endpoints
 type MFAHandler struct {
-	mfaService *services.MFAService
-	cfg        *config.Config
+	mfaService     *services.MFAService
+	authService    *services.AuthService
+	sessionService *services.SessionService
+	cfg            *config.Config
 }
 
 // NewMFAHandler creates a new MFA handler
-func NewMFAHandler(mfaService *services.MFAService, cfg *config.Config) *MFAHandler {
+func NewMFAHandler(mfaService *services.MFAService, authService *services.AuthService, cfg *config.Config) *MFAHandler {
 	return &MFAHandler{
-		mfaService: mfaService,
-		cfg:        cfg,
+		mfaService:  mfaService,
+		authService: authService,
+		cfg:         cfg,
 	}
 }
 
+// SetSessionService enables signing out other devices when MFA is removed
+func (h *MFAHandler) SetSessionService(sessionService *services.SessionService) {
+	h.sessionService = sessionService
+}
+
 // StartEnrollment handles POST /api/v1/auth/mfa/enroll
 // Initiates MFA enrollment and returns QR code + backup codes
 func (h *MFAHandler) StartEnrollment(c *gin.Context) {
@@ -115,19 +125,25 @@ func (h *MFAHandler) VerifyEnrollment(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	// The code just checked is a second factor, so this session counts as verified
+	response := gin.H{
 		"message": "MFA enabled successfully",
-	})
+	}
+	if accessToken, err := h.verifySession(c, userIDUUID); err == nil {
+		response["access_token"] = accessToken
+	}
+
+	c.JSON(http.StatusOK, response)
 }
 
 // VerifyLogin handles POST /api/v1/auth/mfa/verify-login
-// Verifies MFA code during login
+// Verifies the MFA code for a session that signed in with its first factor and
+// marks the session MFA-verified
 func (h *MFAHandler) VerifyLogin(c *gin.Context) {
-	// This endpoint is called during login flow, user_id comes from session
-	userID, exists := c.Get("pending_user_id")
+	userID, exists := c.Get("user_id")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "No pending MFA verification",
+			"error": "Unauthorized",
 		})
 		return
 	}
@@ -188,10 +204,25 @@ func (h *MFAHandler) VerifyLogin(c *gin.Context) {
 		_ = h.mfaService.CreateTrustedDevice(c.Request.Context(), userIDUUID, fingerprint, deviceName, &ipAddress, &userAgent)
 	}
 
-	// MFA verification successful - auth middleware will handle session creation
+	accessToken, err := h.verifySession(c, userIDUUID)
+	if err != nil {
+		if err == services.ErrSessionNotFound {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "No active session to verify",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to verify MFA code",
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"message": "MFA verification successful",
-		"user_id": userIDUUID,
+		"message":      "MFA verification successful",
+		"user_id":      userIDUUID,
+		"access_token": accessToken,
 	})
 }
 
@@ -359,11 +390,79 @@ func (h *MFAHandler) DisableMFA(c *gin.Context) {
 		return
 	}
 
+	h.revokeOtherSessions(c, userIDUUID)
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "MFA disabled successfully",
 	})
 }
 
+// RequestRecovery handles POST /api/v1/auth/mfa/recovery/request
+// Emails a one-time recovery link when the authenticator and backup codes are lost.
+// Needs no session; the response is the same whether or not the account exists.
+func (h *MFAHandler) RequestRecovery(c *gin.Context) {
+	var req models.RequestMFARecoveryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	err := h.mfaService.RequestRecovery(c.Request.Context(), req.Username)
+	if err != nil &&
+		!errors.Is(err, repository.ErrUserNotFound) &&
+		err != services.ErrMFANotEnabled &&
+		err != services.ErrMFARecoveryUnavailable {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to start MFA recovery",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "If the account has MFA enabled and a verified email address, recovery instructions have been sent to it",
+	})
+}
+
+// CompleteRecovery handles POST /api/v1/auth/mfa/recovery/complete
+// Removes MFA from the account the emailed recovery token was issued for and
+// signs out every session, so no existing session outlives the removed factor
+func (h *MFAHandler) CompleteRecovery(c *gin.Context) {
+	var req models.CompleteMFARecoveryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	userID, err := h.mfaService.CompleteRecovery(c.Request.Context(), req.Token)
+	if err != nil {
+		if err == services.ErrInvalidRecoveryToken {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid or expired recovery token",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to complete MFA recovery",
+		})
+		return
+	}
+
+	if h.sessionService != nil {
+		if _, err := h.sessionService.RevokeAllSessions(c.Request.Context(), userID); err != nil {
+			_ = c.Error(err)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "MFA has been removed from your account. Please sign in and set it up again.",
+	})
+}
+
 // GetStatus handles GET /api/v1/auth/mfa/status
 // Returns the current MFA status for the user
 func (h *MFAHandler) GetStatus(c *gin.Context) {
@@ -393,3 +492,34 @@ func (h *MFAHandler) GetStatus(c *gin.Context) {
 
 	c.JSON(http.StatusOK, status)
 }
+
+// revokeOtherSessions signs out every device but the caller's after MFA is removed
+func (h *MFAHandler) revokeOtherSessions(c *gin.Context, userID uuid.UUID) {
+	if h.sessionService == nil {
+		return
+	}
+	if _, err := h.sessionService.RevokeOtherSessions(c.Request.Context(), userID, requestRefreshToken(c)); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// verifySession marks the caller's session as having passed MFA and replaces the
+// access token cookie with one that says so
+func (h *MFAHandler) verifySession(c *gin.Context, userID uuid.UUID) (string, error) {
+	accessToken, err := h.authService.VerifySessionMFA(c.Request.Context(), userID, requestRefreshToken(c))
+	if err != nil {
+		return "", err
+	}
+
+	c.SetCookie(
+		"access_token",
+		accessToken,
+		900, // 15 minutes
+		"/",
+		"",
+		h.cfg.Server.GinMode == "release", // Secure only in production
+		true,                              // HttpOnly
+	)
+
+	return accessToken, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/subculture-collective/clipper/internal/models"
 	"github.com/subculture-collective/clipper/internal/repository"
@@ -37,6 +39,8 @@ type ModerationHandler struct {
 	toxicityClassifier      *services.ToxicityClassifier
 	twitchBanSyncService    *services.TwitchBanSyncService
 	twitchModerationService TwitchModerationService
+	appealService           *services.ModerationAppealService
+	claimService            *services.ModerationClaimService
 	communityRepo           *repository.CommunityRepository
 	auditLogRepo            *repository.AuditLogRepository
 	db                      *pgxpool.Pool
@@ -62,6 +66,16 @@ func (h *ModerationHandler) SetTwitchModerationService(service TwitchModerationS
 	h.twitchModerationService = service
 }
 
+// SetAppealService sets the moderation appeal service
+func (h *ModerationHandler) SetAppealService(service *services.ModerationAppealService) {
+	h.appealService = service
+}
+
+// SetClaimService sets the moderation claim service
+func (h *ModerationHandler) SetClaimService(service *services.ModerationClaimService) {
+	h.claimService = service
+}
+
 // GetPendingEvents retrieves pending moderation events
 // GET /admin/moderation/events
 func (h *ModerationHandler) GetPendingEvents(c *gin.Context) {
@@ -139,9 +153,16 @@ func (h *ModerationHandler) MarkEventReviewed(c *gin.Context) {
 
 	err = h.moderationEventService.MarkEventReviewed(c.Request.Context(), eventID, reviewerID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to mark event as reviewed",
-		})
+		switch {
+		case errors.Is(err, services.ErrModerationEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, repository.ErrModerationItemClaimed):
+			c.JSON(http.StatusConflict, gin.H{"error": "Event is claimed by another moderator"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to mark event as reviewed",
+			})
+		}
 		return
 	}
 
@@ -184,9 +205,16 @@ func (h *ModerationHandler) ProcessEvent(c *gin.Context) {
 
 	err = h.moderationEventService.ProcessEvent(c.Request.Context(), eventID, reviewerID, req.Action)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to process event",
-		})
+		switch {
+		case errors.Is(err, services.ErrModerationEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, repository.ErrModerationItemClaimed):
+			c.JSON(http.StatusConflict, gin.H{"error": "Event is claimed by another moderator"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to process event",
+			})
+		}
 		return
 	}
 
@@ -214,6 +242,199 @@ func (h *ModerationHandler) GetEventStats(c *gin.Context) {
 	})
 }
 
+// claimServiceAvailable reports whether claims are configured, responding
+// with 503 when they aren't
+func (h *ModerationHandler) claimServiceAvailable(c *gin.Context) bool {
+	if h.claimService == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Moderation claims are not available",
+		})
+		return false
+	}
+	return true
+}
+
+// ClaimEvent claims a pending moderation event for the current moderator
+// POST /admin/moderation/events/:id/claim
+func (h *ModerationHandler) ClaimEvent(c *gin.Context) {
+	h.claimItem(c, models.ModerationClaimItemEvent)
+}
+
+// ReleaseEventClaim gives back the current moderator's claim on an event
+// DELETE /admin/moderation/events/:id/claim
+func (h *ModerationHandler) ReleaseEventClaim(c *gin.Context) {
+	h.releaseItem(c, models.ModerationClaimItemEvent)
+}
+
+// ClaimSubmission claims a pending submission for the current moderator
+// POST /admin/moderation/submissions/:id/claim
+func (h *ModerationHandler) ClaimSubmission(c *gin.Context) {
+	h.claimItem(c, models.ModerationClaimItemSubmission)
+}
+
+// ReleaseSubmissionClaim gives back the current moderator's claim on a submission
+// DELETE /admin/moderation/submissions/:id/claim
+func (h *ModerationHandler) ReleaseSubmissionClaim(c *gin.Context) {
+	h.releaseItem(c, models.ModerationClaimItemSubmission)
+}
+
+// claimItem claims an event or submission. Claiming an item the moderator
+// already holds extends the claim; an item held by someone else is refused
+// with 409 and the holder's claim.
+func (h *ModerationHandler) claimItem(c *gin.Context, itemType string) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + itemType + " ID"})
+		return
+	}
+
+	moderatorIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	moderatorID := moderatorIDVal.(uuid.UUID)
+
+	if !h.claimServiceAvailable(c) {
+		return
+	}
+
+	var claim *models.ModerationClaim
+	if itemType == models.ModerationClaimItemEvent {
+		claim, err = h.claimService.ClaimEvent(c.Request.Context(), itemID, moderatorID)
+	} else {
+		claim, err = h.claimService.ClaimSubmission(c.Request.Context(), itemID, moderatorID)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrModerationItemClaimed):
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Already claimed by another moderator",
+				"data":  claim,
+			})
+		case errors.Is(err, services.ErrModerationEventNotFound), errors.Is(err, repository.ErrSubmissionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+		case errors.Is(err, services.ErrModerationItemNotClaimable):
+			c.JSON(http.StatusConflict, gin.H{"error": "Item is not awaiting review"})
+		case errors.Is(err, services.ErrModerationEventsUnavailable):
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Moderation events are not available"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to claim item"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    claim,
+	})
+}
+
+// releaseItem gives back the moderator's claim on an event or submission
+func (h *ModerationHandler) releaseItem(c *gin.Context, itemType string) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + itemType + " ID"})
+		return
+	}
+
+	moderatorIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	moderatorID := moderatorIDVal.(uuid.UUID)
+
+	if !h.claimServiceAvailable(c) {
+		return
+	}
+
+	if err := h.claimService.Release(c.Request.Context(), itemType, itemID, moderatorID); err != nil {
+		if errors.Is(err, repository.ErrModerationClaimNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "You have no claim on this item"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to release claim"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Claim released",
+	})
+}
+
+// ListClaims lists the active claims, oldest first. moderator_id narrows
+// them to one moderator, or "me" for the current moderator.
+// GET /admin/moderation/claims
+func (h *ModerationHandler) ListClaims(c *gin.Context) {
+	var moderatorID *uuid.UUID
+	if filter := c.Query("moderator_id"); filter != "" {
+		if filter == "me" {
+			userIDVal, exists := c.Get("user_id")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+				return
+			}
+			userID := userIDVal.(uuid.UUID)
+			moderatorID = &userID
+		} else {
+			id, err := uuid.Parse(filter)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid moderator_id. Must be a user ID or me"})
+				return
+			}
+			moderatorID = &id
+		}
+	}
+
+	if !h.claimServiceAvailable(c) {
+		return
+	}
+
+	claims, err := h.claimService.ListClaims(c.Request.Context(), moderatorID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve claims"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    claims,
+		"count":   len(claims),
+	})
+}
+
+// GetModerationWorkload returns each moderator's open claims and the items
+// they resolved over the last `days` days (default 7), with time to resolution
+// GET /admin/moderation/workload
+func (h *ModerationHandler) GetModerationWorkload(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "7"))
+	if err != nil || days < 1 || days > 90 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid days. Must be between 1 and 90",
+		})
+		return
+	}
+
+	if !h.claimServiceAvailable(c) {
+		return
+	}
+
+	workload, err := h.claimService.GetWorkload(c.Request.Context(), days)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve moderation workload",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    workload,
+	})
+}
+
 // GetUserAbuseStats returns abuse statistics for a specific user
 // GET /admin/moderation/abuse/:userId
 func (h *ModerationHandler) GetUserAbuseStats(c *gin.Context) {
@@ -247,6 +468,7 @@ func (h *ModerationHandler) GetModerationQueue(c *gin.Context) {
 
 	// Parse and validate query parameters
 	contentType := c.Query("type")
+	reason := c.Query("reason")
 	status := c.DefaultQuery("status", "pending")
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
 	if limit < 1 || limit > 100 {
@@ -288,7 +510,8 @@ func (h *ModerationHandler) GetModerationQueue(c *gin.Context) {
 		SELECT mq.id, mq.content_type, mq.content_id, mq.reason, mq.priority,
 		       mq.status, mq.assigned_to, mq.reported_by, mq.report_count,
 		       mq.auto_flagged, mq.confidence_score, mq.created_at,
-		       mq.reviewed_at, mq.reviewed_by
+		       mq.reviewed_at, mq.reviewed_by, mq.metadata->'automod',
+		       mq.metadata->'toxicity'
 		FROM moderation_queue mq
 		WHERE mq.status = $1
 	`
@@ -301,6 +524,12 @@ func (h *ModerationHandler) GetModerationQueue(c *gin.Context) {
 		argIdx++
 	}
 
+	if reason != "" {
+		query += fmt.Sprintf(" AND mq.reason = $%d", argIdx)
+		args = append(args, reason)
+		argIdx++
+	}
+
 	query += fmt.Sprintf(" ORDER BY mq.priority DESC, mq.created_at ASC LIMIT $%d", argIdx)
 	args = append(args, limit)
 
@@ -320,7 +549,8 @@ func (h *ModerationHandler) GetModerationQueue(c *gin.Context) {
 			&item.ID, &item.ContentType, &item.ContentID, &item.Reason,
 			&item.Priority, &item.Status, &item.AssignedTo, &item.ReportedBy,
 			&item.ReportCount, &item.AutoFlagged, &item.ConfidenceScore,
-			&item.CreatedAt, &item.ReviewedAt, &item.ReviewedBy,
+			&item.CreatedAt, &item.ReviewedAt, &item.ReviewedBy, &item.Automod,
+			&item.Toxicity,
 		)
 		if err != nil {
 			// Log scan error for debugging but continue processing other rows
@@ -374,11 +604,20 @@ func (h *ModerationHandler) ApproveContent(c *gin.Context) {
 	defer tx.Rollback(ctx)
 
 	// Update queue item
-	cmdTag, err := tx.Exec(ctx, `
+	var contentType string
+	var contentID uuid.UUID
+	err = tx.QueryRow(ctx, `
 		UPDATE moderation_queue
 		SET status = 'approved', reviewed_by = $1
 		WHERE id = $2 AND status = 'pending'
-	`, moderatorID, itemID)
+		RETURNING content_type, content_id
+	`, moderatorID, itemID).Scan(&contentType, &contentID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Item not found or not in pending status",
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to approve item",
@@ -386,12 +625,14 @@ func (h *ModerationHandler) ApproveContent(c *gin.Context) {
 		return
 	}
 
-	// Check if any rows were updated
-	if cmdTag.RowsAffected() == 0 {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": "Item not found or not in pending status",
-		})
-		return
+	// Approved comments automoderation held or shadow-hid are shown to everyone
+	if contentType == "comment" {
+		if _, err := tx.Exec(ctx, `UPDATE comments SET automod_state = NULL WHERE id = $1`, contentID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to release comment",
+			})
+			return
+		}
 	}
 
 	// Record decision
@@ -717,11 +958,22 @@ func (h *ModerationHandler) GetModerationStats(c *gin.Context) {
 	})
 }
 
+// appealServiceAvailable reports whether appeals are configured, responding
+// with 503 when they aren't
+func (h *ModerationHandler) appealServiceAvailable(c *gin.Context) bool {
+	if h.appealService == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Appeals are not available",
+		})
+		return false
+	}
+	return true
+}
+
 // CreateAppeal creates a new appeal for a moderation decision
-// POST /api/moderation/appeals
+// POST /api/v1/appeals
+// POST /api/v1/moderation/appeals
 func (h *ModerationHandler) CreateAppeal(c *gin.Context) {
-	ctx := c.Request.Context()
-
 	// Get user ID from context
 	userIDVal, exists := c.Get("user_id")
 	if !exists {
@@ -748,93 +1000,40 @@ func (h *ModerationHandler) CreateAppeal(c *gin.Context) {
 		return
 	}
 
-	// Verify the moderation action exists and get content details for ownership check
-	var contentType string
-	var contentID uuid.UUID
-	err = h.db.QueryRow(ctx, `
-		SELECT mq.content_type, mq.content_id
-		FROM moderation_decisions md
-		JOIN moderation_queue mq ON md.queue_item_id = mq.id
-		WHERE md.id = $1
-	`, moderationActionID).Scan(&contentType, &contentID)
-	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": "Moderation action not found",
-		})
-		return
-	}
-
-	// Verify content ownership based on content type
-	var ownsContent bool
-	switch contentType {
-	case "comment":
-		err = h.db.QueryRow(ctx, `
-			SELECT EXISTS(SELECT 1 FROM comments WHERE id = $1 AND user_id = $2)
-		`, contentID, userID).Scan(&ownsContent)
-	case "clip":
-		err = h.db.QueryRow(ctx, `
-			SELECT EXISTS(SELECT 1 FROM clips WHERE id = $1 AND submitted_by_user_id = $2)
-		`, contentID, userID).Scan(&ownsContent)
-	case "user":
-		// For user moderation actions, check if the moderated user is the same as the requester
-		err = h.db.QueryRow(ctx, `
-			SELECT EXISTS(SELECT 1 FROM users WHERE id = $1 AND id = $2)
-		`, contentID, userID).Scan(&ownsContent)
-	default:
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": fmt.Sprintf("Unsupported content type for appeals: %s", contentType),
-		})
-		return
-	}
-
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to verify content ownership",
-		})
-		return
-	}
-
-	if !ownsContent {
-		c.JSON(http.StatusForbidden, gin.H{
-			"error": "You do not have permission to appeal this moderation action",
-		})
+	if !h.appealServiceAvailable(c) {
 		return
 	}
 
-	// Insert appeal
-	var appealID uuid.UUID
-	err = h.db.QueryRow(ctx, `
-		INSERT INTO moderation_appeals (user_id, moderation_action_id, reason)
-		VALUES ($1, $2, $3)
-		RETURNING id
-	`, userID, moderationActionID, req.Reason).Scan(&appealID)
+	appeal, err := h.appealService.CreateAppeal(c.Request.Context(), userID, moderationActionID, req.Reason)
 	if err != nil {
-		// Check for unique constraint violation (duplicate appeal)
-		if strings.Contains(err.Error(), "duplicate") || strings.Contains(err.Error(), "uq_appeals_action_pending") {
-			c.JSON(http.StatusConflict, gin.H{
-				"error": "An appeal for this moderation action is already pending",
-			})
-			return
+		switch {
+		case errors.Is(err, repository.ErrModerationActionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Moderation action not found"})
+		case errors.Is(err, services.ErrAppealForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to appeal this moderation action"})
+		case errors.Is(err, repository.ErrAppealContentUnsupported):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "This moderation action cannot be appealed"})
+		case errors.Is(err, repository.ErrModerationAppealOpen):
+			c.JSON(http.StatusConflict, gin.H{"error": "An appeal for this moderation action is already open"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create appeal"})
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to create appeal",
-		})
 		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
 		"success":   true,
-		"appeal_id": appealID,
+		"appeal_id": appeal.ID,
 		"message":   "Appeal submitted successfully",
 	})
 }
 
-// GetAppeals retrieves appeals for admin review
+// GetAppeals retrieves appeals for admin review. status is pending (the
+// default), in_review, approved, rejected or open; assigned_to is a moderator
+// ID or "me", and unassigned=true lists appeals no one has taken.
 // GET /admin/moderation/appeals
 func (h *ModerationHandler) GetAppeals(c *gin.Context) {
-	ctx := c.Request.Context()
-
-	status := c.DefaultQuery("status", "pending")
+	status := c.DefaultQuery("status", models.AppealStatusPending)
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
 	if limit < 1 || limit > 100 {
 		limit = 50
@@ -842,69 +1041,54 @@ func (h *ModerationHandler) GetAppeals(c *gin.Context) {
 
 	// Validate status parameter
 	validStatuses := map[string]bool{
-		"pending":  true,
-		"approved": true,
-		"rejected": true,
+		models.AppealStatusPending:  true,
+		models.AppealStatusInReview: true,
+		models.AppealStatusApproved: true,
+		models.AppealStatusRejected: true,
+		"open":                      true,
 	}
 	if !validStatuses[status] {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid status. Must be one of: pending, approved, rejected",
+			"error": "Invalid status. Must be one of: pending, in_review, approved, rejected, open",
 		})
 		return
 	}
 
-	query := `
-		SELECT ma.id, ma.user_id, ma.moderation_action_id, ma.reason,
-		       ma.status, ma.resolved_by, ma.resolution,
-		       ma.created_at, ma.resolved_at,
-		       u.username, u.display_name,
-		       md.action, md.reason as decision_reason,
-		       mq.content_type, mq.content_id
-		FROM moderation_appeals ma
-		JOIN users u ON ma.user_id = u.id
-		JOIN moderation_decisions md ON ma.moderation_action_id = md.id
-		JOIN moderation_queue mq ON md.queue_item_id = mq.id
-		WHERE ma.status = $1
-		ORDER BY ma.created_at ASC
-		LIMIT $2
-	`
+	filters := models.AppealQueueFilters{
+		Status:     status,
+		Unassigned: c.Query("unassigned") == "true",
+		Limit:      limit,
+	}
+	if assignedTo := c.Query("assigned_to"); assignedTo != "" {
+		if assignedTo == "me" {
+			userIDVal, exists := c.Get("user_id")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+				return
+			}
+			userID := userIDVal.(uuid.UUID)
+			filters.AssignedTo = &userID
+		} else {
+			moderatorID, err := uuid.Parse(assignedTo)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid assigned_to. Must be a user ID or me"})
+				return
+			}
+			filters.AssignedTo = &moderatorID
+		}
+	}
+
+	if !h.appealServiceAvailable(c) {
+		return
+	}
 
-	rows, err := h.db.Query(ctx, query, status, limit)
+	appeals, err := h.appealService.ListQueue(c.Request.Context(), filters)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to retrieve appeals",
 		})
 		return
 	}
-	defer rows.Close()
-
-	type AppealWithDetails struct {
-		models.ModerationAppeal
-		Username       string    `json:"username"`
-		DisplayName    string    `json:"display_name"`
-		DecisionAction string    `json:"decision_action"`
-		DecisionReason *string   `json:"decision_reason,omitempty"`
-		ContentType    string    `json:"content_type"`
-		ContentID      uuid.UUID `json:"content_id"`
-	}
-
-	var appeals []AppealWithDetails
-	for rows.Next() {
-		var appeal AppealWithDetails
-		err := rows.Scan(
-			&appeal.ID, &appeal.UserID, &appeal.ModerationActionID,
-			&appeal.Reason, &appeal.Status, &appeal.ResolvedBy,
-			&appeal.Resolution, &appeal.CreatedAt, &appeal.ResolvedAt,
-			&appeal.Username, &appeal.DisplayName,
-			&appeal.DecisionAction, &appeal.DecisionReason,
-			&appeal.ContentType, &appeal.ContentID,
-		)
-		if err != nil {
-			c.Error(fmt.Errorf("failed to scan appeal: %w", err))
-			continue
-		}
-		appeals = append(appeals, appeal)
-	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
@@ -917,11 +1101,72 @@ func (h *ModerationHandler) GetAppeals(c *gin.Context) {
 	})
 }
 
-// ResolveAppeal resolves an appeal
+// AssignAppeal takes an appeal into review, assigned to the caller or to the
+// moderator in the request
+// POST /admin/moderation/appeals/:id/assign
+func (h *ModerationHandler) AssignAppeal(c *gin.Context) {
+	appealID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid appeal ID",
+		})
+		return
+	}
+
+	adminIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Unauthorized",
+		})
+		return
+	}
+	adminID := adminIDVal.(uuid.UUID)
+
+	// The body is optional; without one the appeal is assigned to the caller
+	var req models.AssignAppealRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request: " + err.Error(),
+		})
+		return
+	}
+	assigneeID := adminID
+	if req.AssigneeID != nil {
+		assigneeID, err = uuid.Parse(*req.AssigneeID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid assignee ID",
+			})
+			return
+		}
+	}
+
+	if !h.appealServiceAvailable(c) {
+		return
+	}
+
+	appeal, err := h.appealService.AssignAppeal(c.Request.Context(), appealID, assigneeID, adminID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrModerationAppealNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Appeal not found or already resolved"})
+		case errors.Is(err, services.ErrAppealAssigneeNotModerator):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Appeals can only be assigned to moderators"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to assign appeal"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    appeal,
+	})
+}
+
+// ResolveAppeal resolves an appeal and notifies the appellant of the decision
 // POST /admin/moderation/appeals/:id/resolve
 func (h *ModerationHandler) ResolveAppeal(c *gin.Context) {
-	ctx := c.Request.Context()
-
 	appealID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
@@ -948,44 +1193,65 @@ func (h *ModerationHandler) ResolveAppeal(c *gin.Context) {
 		return
 	}
 
-	// Map decision to status
-	status := "rejected"
-	if req.Decision == "approve" {
-		status = "approved"
+	if !h.appealServiceAvailable(c) {
+		return
 	}
 
-	// Update appeal
-	cmdTag, err := h.db.Exec(ctx, `
-		UPDATE moderation_appeals
-		SET status = $1, resolved_by = $2, resolution = $3
-		WHERE id = $4 AND status = 'pending'
-	`, status, adminID, req.Resolution, appealID)
+	appeal, err := h.appealService.ResolveAppeal(c.Request.Context(), appealID, adminID, req.Decision, req.Resolution)
 	if err != nil {
+		if errors.Is(err, repository.ErrModerationAppealNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Appeal not found or already resolved",
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to resolve appeal",
 		})
 		return
 	}
 
-	if cmdTag.RowsAffected() == 0 {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": "Appeal not found or not in pending status",
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Appeal resolved successfully",
+		"status":  appeal.Status,
+	})
+}
+
+// GetAppealSLA reports how quickly appeals are assigned and resolved against
+// their SLAs, over the last days (default 30)
+// GET /admin/moderation/appeals/sla
+func (h *ModerationHandler) GetAppealSLA(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days < 1 || days > 365 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid days. Must be between 1 and 365",
+		})
+		return
+	}
+
+	if !h.appealServiceAvailable(c) {
+		return
+	}
+
+	metrics, err := h.appealService.GetSLAMetrics(c.Request.Context(), days)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve appeal SLA metrics",
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"message": "Appeal resolved successfully",
-		"status":  status,
+		"data":    metrics,
 	})
 }
 
 // GetUserAppeals retrieves appeals for the authenticated user
-// GET /api/moderation/appeals
+// GET /api/v1/appeals
+// GET /api/v1/moderation/appeals
 func (h *ModerationHandler) GetUserAppeals(c *gin.Context) {
-	ctx := c.Request.Context()
-
 	// Get user ID from context
 	userIDVal, exists := c.Get("user_id")
 	if !exists {
@@ -996,53 +1262,17 @@ func (h *ModerationHandler) GetUserAppeals(c *gin.Context) {
 	}
 	userID := userIDVal.(uuid.UUID)
 
-	query := `
-		SELECT ma.id, ma.user_id, ma.moderation_action_id, ma.reason,
-		       ma.status, ma.resolved_by, ma.resolution,
-		       ma.created_at, ma.resolved_at,
-		       md.action, md.reason as decision_reason,
-		       mq.content_type, mq.content_id
-		FROM moderation_appeals ma
-		JOIN moderation_decisions md ON ma.moderation_action_id = md.id
-		JOIN moderation_queue mq ON md.queue_item_id = mq.id
-		WHERE ma.user_id = $1
-		ORDER BY ma.created_at DESC
-		LIMIT 50
-	`
+	if !h.appealServiceAvailable(c) {
+		return
+	}
 
-	rows, err := h.db.Query(ctx, query, userID)
+	appeals, err := h.appealService.ListUserAppeals(c.Request.Context(), userID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to retrieve appeals",
 		})
 		return
 	}
-	defer rows.Close()
-
-	type UserAppealWithDetails struct {
-		models.ModerationAppeal
-		DecisionAction string    `json:"decision_action"`
-		DecisionReason *string   `json:"decision_reason,omitempty"`
-		ContentType    string    `json:"content_type"`
-		ContentID      uuid.UUID `json:"content_id"`
-	}
-
-	var appeals []UserAppealWithDetails
-	for rows.Next() {
-		var appeal UserAppealWithDetails
-		err := rows.Scan(
-			&appeal.ID, &appeal.UserID, &appeal.ModerationActionID,
-			&appeal.Reason, &appeal.Status, &appeal.ResolvedBy,
-			&appeal.Resolution, &appeal.CreatedAt, &appeal.ResolvedAt,
-			&appeal.DecisionAction, &appeal.DecisionReason,
-			&appeal.ContentType, &appeal.ContentID,
-		)
-		if err != nil {
-			c.Error(fmt.Errorf("failed to scan appeal: %w", err))
-			continue
-		}
-		appeals = append(appeals, appeal)
-	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
@@ -1426,7 +1656,7 @@ func (h *ModerationHandler) GetModerationAnalytics(c *gin.Context) {
 	err = h.db.QueryRow(ctx, `
 		SELECT
 			COUNT(*) as total,
-			COUNT(*) FILTER (WHERE status = 'pending') as pending,
+			COUNT(*) FILTER (WHERE status IN ('pending', 'in_review')) as pending,
 			COUNT(*) FILTER (WHERE status = 'approved') as approved,
 			COUNT(*) FILTER (WHERE status = 'rejected') as rejected
 		FROM moderation_appeals
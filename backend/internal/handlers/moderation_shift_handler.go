@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// ModerationShiftHandler handles moderator shifts and their handoff reports
+type ModerationShiftHandler struct {
+	shiftService *services.ModerationShiftService
+}
+
+// NewModerationShiftHandler creates a new moderation shift handler
+func NewModerationShiftHandler(shiftService *services.ModerationShiftService) *ModerationShiftHandler {
+	return &ModerationShiftHandler{
+		shiftService: shiftService,
+	}
+}
+
+// ListShifts returns every moderation shift
+// GET /api/v1/admin/moderation/shifts
+func (h *ModerationShiftHandler) ListShifts(c *gin.Context) {
+	shifts, err := h.shiftService.ListShifts(c.Request.Context())
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve moderation shifts")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": shifts})
+}
+
+// CreateShift creates a moderation shift
+// POST /api/v1/admin/moderation/shifts
+func (h *ModerationShiftHandler) CreateShift(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	adminID := userIDVal.(uuid.UUID)
+
+	var req models.CreateModerationShiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	shift, err := h.shiftService.CreateShift(c.Request.Context(), adminID, &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to create moderation shift")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": shift})
+}
+
+// GetShift returns a moderation shift
+// GET /api/v1/admin/moderation/shifts/:id
+func (h *ModerationShiftHandler) GetShift(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid shift ID"})
+		return
+	}
+
+	shift, err := h.shiftService.GetShift(c.Request.Context(), id)
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve moderation shift")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": shift})
+}
+
+// UpdateShift changes a moderation shift's schedule, team or email settings
+// PATCH /api/v1/admin/moderation/shifts/:id
+func (h *ModerationShiftHandler) UpdateShift(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid shift ID"})
+		return
+	}
+
+	var req models.UpdateModerationShiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	shift, err := h.shiftService.UpdateShift(c.Request.Context(), id, &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to update moderation shift")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": shift})
+}
+
+// DeleteShift removes a moderation shift
+// DELETE /api/v1/admin/moderation/shifts/:id
+func (h *ModerationShiftHandler) DeleteShift(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid shift ID"})
+		return
+	}
+
+	if err := h.shiftService.DeleteShift(c.Request.Context(), id); err != nil {
+		h.respondError(c, err, "Failed to delete moderation shift")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Moderation shift deleted"})
+}
+
+// GetShiftReport returns the handoff report of the shift that started on
+// ?date= (YYYY-MM-DD in the shift's time zone), or of the most recently ended
+// shift. ?moderator_id= narrows it to one moderator.
+// GET /api/v1/admin/moderation/shifts/:id/report
+func (h *ModerationShiftHandler) GetShiftReport(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid shift ID"})
+		return
+	}
+	moderatorID, ok := h.moderatorIDParam(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.shiftService.ShiftReport(c.Request.Context(), id, c.Query("date"), moderatorID)
+	if err != nil {
+		h.respondError(c, err, "Failed to build moderation shift report")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
+
+// GetHandoffReport returns a handoff report for the RFC 3339 ?start= and
+// ?end= window, for every moderator or ?moderator_id=. ?timezone= sets the
+// zone the report is presented in.
+// GET /api/v1/admin/moderation/handoff-report
+func (h *ModerationShiftHandler) GetHandoffReport(c *gin.Context) {
+	start, err := time.Parse(time.RFC3339, c.Query("start"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "start must be an RFC 3339 time"})
+		return
+	}
+	end, err := time.Parse(time.RFC3339, c.Query("end"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "end must be an RFC 3339 time"})
+		return
+	}
+	moderatorID, ok := h.moderatorIDParam(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.shiftService.Report(c.Request.Context(), start, end, c.Query("timezone"), moderatorID)
+	if err != nil {
+		h.respondError(c, err, "Failed to build moderation handoff report")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
+
+// moderatorIDParam parses the optional ?moderator_id=, responding with 400
+// when it is not a UUID
+func (h *ModerationShiftHandler) moderatorIDParam(c *gin.Context) (*uuid.UUID, bool) {
+	raw := c.Query("moderator_id")
+	if raw == "" {
+		return nil, true
+	}
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid moderator ID"})
+		return nil, false
+	}
+	return &id, true
+}
+
+func (h *ModerationShiftHandler) respondError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, repository.ErrModerationShiftNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrModerationShiftInvalid):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
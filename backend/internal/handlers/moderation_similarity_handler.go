@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// ModerationSimilarityHandler shows moderators past decisions on cases like the one under review
+type ModerationSimilarityHandler struct {
+	similarityService *services.ModerationSimilarityService
+}
+
+// NewModerationSimilarityHandler creates a new moderation similarity handler
+func NewModerationSimilarityHandler(similarityService *services.ModerationSimilarityService) *ModerationSimilarityHandler {
+	return &ModerationSimilarityHandler{
+		similarityService: similarityService,
+	}
+}
+
+// GetSimilarCases returns decided submissions or reports similar to the given one,
+// with their outcomes and a count of each outcome
+// GET /admin/moderation/similar-cases?type=submission&id=<uuid>&limit=10
+func (h *ModerationSimilarityHandler) GetSimilarCases(c *gin.Context) {
+	caseID, err := uuid.Parse(c.Query("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid case ID"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	result, err := h.similarityService.GetSimilarCases(c.Request.Context(), c.Query("type"), caseID, limit)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidModerationCaseType):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, repository.ErrModerationCaseNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Case not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to find similar cases"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
@@ -5,12 +5,17 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/subculture-collective/clipper/internal/services"
 	redispkg "github.com/subculture-collective/clipper/pkg/redis"
 )
 
+// maxSLAReportDays is the longest period an SLA report may cover
+const maxSLAReportDays = 90
+
 // MonitoringHandler handles monitoring and health check endpoints
 type MonitoringHandler struct {
-	redis *redispkg.Client
+	redis         *redispkg.Client
+	healthHistory *services.HealthHistoryService // may be nil
 }
 
 // NewMonitoringHandler creates a new monitoring handler
@@ -20,6 +25,11 @@ func NewMonitoringHandler(redis *redispkg.Client) *MonitoringHandler {
 	}
 }
 
+// SetHealthHistoryService enables uptime history and SLA reports
+func (h *MonitoringHandler) SetHealthHistoryService(healthHistory *services.HealthHistoryService) {
+	h.healthHistory = healthHistory
+}
+
 // GetCacheStats returns Redis cache statistics
 // GET /health/cache
 func (h *MonitoringHandler) GetCacheStats(c *gin.Context) {
@@ -86,3 +96,47 @@ func (h *MonitoringHandler) GetCacheHealth(c *gin.Context) {
 		"cache":  "ok",
 	})
 }
+
+// GetUptime returns each dependency's and endpoint group's uptime over the
+// last 24 hours, 7 days and 30 days
+// GET /api/v1/admin/health/uptime
+func (h *MonitoringHandler) GetUptime(c *gin.Context) {
+	if h.healthHistory == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Health history is not available"})
+		return
+	}
+
+	uptimes, err := h.healthHistory.GetUptime(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve uptime"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": uptimes,
+	})
+}
+
+// GetSLAReport reports whether each dependency and endpoint group met the
+// uptime SLA over the last ?days= days (default 30)
+// GET /api/v1/admin/health/sla
+func (h *MonitoringHandler) GetSLAReport(c *gin.Context) {
+	if h.healthHistory == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Health history is not available"})
+		return
+	}
+
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days < 1 || days > maxSLAReportDays {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "days must be between 1 and 90"})
+		return
+	}
+
+	report, err := h.healthHistory.GetSLAReport(c.Request.Context(), days)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve SLA report"})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -1,12 +1,18 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/pagination"
+	"github.com/subculture-collective/clipper/internal/repository"
 	"github.com/subculture-collective/clipper/internal/services"
 )
 
@@ -14,8 +20,18 @@ import (
 type NotificationHandler struct {
 	notificationService *services.NotificationService
 	emailService        *services.EmailService
+	streamHub           *services.NotificationStreamHub
 }
 
+const (
+	// notificationStreamHeartbeat keeps idle streams open through proxies
+	notificationStreamHeartbeat = 25 * time.Second
+	// notificationStreamMaxDuration ends streams so reconnecting clients are authenticated again
+	notificationStreamMaxDuration = 15 * time.Minute
+	// notificationStreamRetryMillis tells EventSource clients how long to wait before reconnecting
+	notificationStreamRetryMillis = 5000
+)
+
 // NewNotificationHandler creates a new NotificationHandler
 func NewNotificationHandler(notificationService *services.NotificationService, emailService *services.EmailService) *NotificationHandler {
 	return &NotificationHandler{
@@ -24,6 +40,11 @@ func NewNotificationHandler(notificationService *services.NotificationService, e
 	}
 }
 
+// SetStreamHub enables real-time delivery on GET /notifications/stream
+func (h *NotificationHandler) SetStreamHub(hub *services.NotificationStreamHub) {
+	h.streamHub = hub
+}
+
 // ListNotifications handles GET /notifications
 func (h *NotificationHandler) ListNotifications(c *gin.Context) {
 	// Get user ID from context (set by auth middleware)
@@ -47,6 +68,7 @@ func (h *NotificationHandler) ListNotifications(c *gin.Context) {
 	filter := c.DefaultQuery("filter", "all") // all, unread, read
 	limitStr := c.DefaultQuery("limit", "50")
 	pageStr := c.DefaultQuery("page", "1")
+	cursorStr := c.Query("cursor") // Takes precedence over page
 
 	limit, err := strconv.Atoi(limitStr)
 	if err != nil || limit < 1 || limit > 100 {
@@ -58,13 +80,25 @@ func (h *NotificationHandler) ListNotifications(c *gin.Context) {
 		page = 1
 	}
 
+	var cursor *pagination.Cursor
+	if cursorStr != "" {
+		cursor, err = pagination.Decode(cursorStr, repository.NotificationsSort)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid or expired cursor",
+			})
+			return
+		}
+	}
+
 	offset := (page - 1) * limit
 
 	// Get notifications
-	notifications, err := h.notificationService.GetUserNotifications(
+	notifications, pageInfo, err := h.notificationService.GetUserNotifications(
 		c.Request.Context(),
 		userID,
 		filter,
+		cursor,
 		limit,
 		offset,
 	)
@@ -89,7 +123,8 @@ func (h *NotificationHandler) ListNotifications(c *gin.Context) {
 		"unread_count":  unreadCount,
 		"page":          page,
 		"limit":         limit,
-		"has_more":      len(notifications) == limit,
+		"has_more":      pageInfo.HasMore,
+		"next_cursor":   pageInfo.NextCursor,
 	})
 }
 
@@ -304,6 +339,17 @@ func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
 		return
 	}
 
+	if prefs.Timezone != nil {
+		if *prefs.Timezone == "" {
+			prefs.Timezone = nil
+		} else if _, err := time.LoadLocation(*prefs.Timezone); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid timezone",
+			})
+			return
+		}
+	}
+
 	// Set user ID
 	prefs.UserID = userID
 
@@ -402,6 +448,10 @@ func (h *NotificationHandler) Unsubscribe(c *gin.Context) {
 			prefs.NotifyReplies = false
 		case models.NotificationTypeMention:
 			prefs.NotifyMentions = false
+		case models.NotificationTypeDigest:
+			prefs.EmailDigest = models.EmailDigestNever
+		case models.NotificationTypeReengagement:
+			prefs.NotifyReengagement = false
 		}
 	}
 
@@ -569,3 +619,91 @@ func (h *NotificationHandler) UnregisterDeviceToken(c *gin.Context) {
 		"message": "Device token unregistered successfully",
 	})
 }
+
+// StreamNotifications handles GET /notifications/stream. New notifications and
+// unread count updates are pushed as Server-Sent Events; clients that cannot open
+// the stream keep polling GET /notifications/count.
+func (h *NotificationHandler) StreamNotifications(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+		})
+		return
+	}
+
+	userID, ok := userIDVal.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Invalid user ID",
+		})
+		return
+	}
+
+	if h.streamHub == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Notification stream unavailable",
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	events, unsubscribe, err := h.streamHub.Subscribe(ctx, userID)
+	if err != nil {
+		if errors.Is(err, services.ErrTooManyNotificationStreams) {
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error": "Too many open notification streams",
+			})
+			return
+		}
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Notification stream unavailable",
+		})
+		return
+	}
+	defer unsubscribe()
+
+	count, err := h.notificationService.GetUnreadCount(ctx, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve unread count",
+		})
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", notificationStreamRetryMillis)
+	c.SSEvent(services.NotificationStreamEventUnreadCount, services.NotificationStreamEvent{
+		Type:        services.NotificationStreamEventUnreadCount,
+		UnreadCount: &count,
+	})
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(notificationStreamHeartbeat)
+	defer heartbeat.Stop()
+	deadline := time.NewTimer(notificationStreamMaxDuration)
+	defer deadline.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(event.Type, event)
+			return true
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": heartbeat\n\n")
+			return err == nil
+		case <-deadline.C:
+			return false
+		case <-ctx.Done():
+			return false
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// swaggerUIVersion pins the swagger-ui-dist release loaded by the docs page
+const swaggerUIVersion = "5.17.14"
+
+// swaggerUICSP relaxes the API's default Content-Security-Policy just enough
+// for the Swagger UI assets served from jsDelivr
+const swaggerUICSP = "default-src 'self'; " +
+	"script-src 'self' 'unsafe-inline' https://cdn.jsdelivr.net; " +
+	"style-src 'self' 'unsafe-inline' https://cdn.jsdelivr.net; " +
+	"img-src 'self' data: https:; " +
+	"connect-src 'self'; " +
+	"object-src 'none'; " +
+	"base-uri 'self'; " +
+	"frame-ancestors 'none'"
+
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="UTF-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1.0">
+  <title>Clipper API Reference</title>
+  <link rel="stylesheet" href="https://cdn.jsdelivr.net/npm/swagger-ui-dist@` + swaggerUIVersion + `/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://cdn.jsdelivr.net/npm/swagger-ui-dist@` + swaggerUIVersion + `/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = function () {
+      window.ui = SwaggerUIBundle({
+        url: "openapi.json",
+        dom_id: "#swagger-ui",
+        deepLinking: true,
+        persistAuthorization: true,
+        filter: true,
+        displayOperationId: true,
+        docExpansion: "none"
+      });
+    };
+  </script>
+</body>
+</html>
+`
+
+// OpenAPIHandler serves the generated OpenAPI specification and a Swagger UI
+// page for browsing it
+type OpenAPIHandler struct {
+	spec []byte
+}
+
+// NewOpenAPIHandler creates a new OpenAPI handler for a generated spec
+func NewOpenAPIHandler(spec []byte) *OpenAPIHandler {
+	return &OpenAPIHandler{
+		spec: spec,
+	}
+}
+
+// GetSpec returns the OpenAPI 3.1 specification
+// GET /api/v1/openapi.json
+func (h *OpenAPIHandler) GetSpec(c *gin.Context) {
+	// The spec only changes with a deploy
+	c.Header("Cache-Control", "public, max-age=300")
+	c.Data(http.StatusOK, "application/json; charset=utf-8", h.spec)
+}
+
+// GetSwaggerUI returns a Swagger UI page for the OpenAPI specification
+// GET /api/v1/swagger
+func (h *OpenAPIHandler) GetSwaggerUI(c *gin.Context) {
+	c.Header("Content-Security-Policy", swaggerUICSP)
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUIPage))
+}
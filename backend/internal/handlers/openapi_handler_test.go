@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestOpenAPIHandler_GetSpec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	spec := []byte(`{"openapi":"3.1.0"}`)
+	handler := NewOpenAPIHandler(spec)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil)
+	handler.GetSpec(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Fatalf("expected JSON content type, got %q", ct)
+	}
+	if w.Body.String() != string(spec) {
+		t.Fatalf("unexpected body %q", w.Body.String())
+	}
+}
+
+func TestOpenAPIHandler_GetSwaggerUI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := NewOpenAPIHandler(nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/swagger", nil)
+	handler.GetSwaggerUI(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), `url: "openapi.json"`) {
+		t.Fatal("expected the page to load the spec from openapi.json")
+	}
+	// The page loads its assets from jsDelivr, which the default policy blocks for styles
+	if csp := w.Header().Get("Content-Security-Policy"); !strings.Contains(csp, "style-src 'self' 'unsafe-inline' https://cdn.jsdelivr.net") {
+		t.Fatalf("unexpected Content-Security-Policy %q", csp)
+	}
+}
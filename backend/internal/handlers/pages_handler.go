@@ -403,7 +403,7 @@ func buildItemListSchema(clips []models.Clip, baseURL string) string {
 			"@type":    "ListItem",
 			"position": i + 1,
 			"url":      fmt.Sprintf("%s/clip/%s", baseURL, clip.ID),
-			"name":     clip.Title,
+			"name":     clip.PreferredTitle(),
 		}
 		items = append(items, item)
 	}
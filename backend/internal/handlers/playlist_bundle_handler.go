@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// PlaylistBundleHandler handles selling premium playlists as one-time purchases
+type PlaylistBundleHandler struct {
+	bundleService *services.PlaylistBundleService
+}
+
+// NewPlaylistBundleHandler creates a new playlist bundle handler
+func NewPlaylistBundleHandler(bundleService *services.PlaylistBundleService) *PlaylistBundleHandler {
+	return &PlaylistBundleHandler{
+		bundleService: bundleService,
+	}
+}
+
+// GetBundle returns a playlist's price and whether the viewer has access
+// GET /api/v1/playlists/:id/bundle
+func (h *PlaylistBundleHandler) GetBundle(c *gin.Context) {
+	playlistID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid playlist ID"})
+		return
+	}
+
+	var userID *uuid.UUID
+	if userIDVal, exists := c.Get("user_id"); exists {
+		if id, ok := userIDVal.(uuid.UUID); ok {
+			userID = &id
+		}
+	}
+
+	access, err := h.bundleService.GetAccess(c.Request.Context(), playlistID, userID)
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve playlist bundle")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": access})
+}
+
+// SetBundle puts the caller's playlist up for sale or changes its price
+// PUT /api/v1/playlists/:id/bundle
+func (h *PlaylistBundleHandler) SetBundle(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	playlistID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid playlist ID"})
+		return
+	}
+
+	var req models.SetPlaylistBundleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	bundle, err := h.bundleService.SetBundle(c.Request.Context(), playlistID, userID, &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to save playlist bundle")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": bundle})
+}
+
+// RemoveBundle stops selling the caller's playlist
+// DELETE /api/v1/playlists/:id/bundle
+func (h *PlaylistBundleHandler) RemoveBundle(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	playlistID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid playlist ID"})
+		return
+	}
+
+	if err := h.bundleService.RemoveBundle(c.Request.Context(), playlistID, userID); err != nil {
+		h.respondError(c, err, "Failed to remove playlist bundle")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Playlist is no longer for sale"})
+}
+
+// Purchase starts a Stripe Checkout for a premium playlist
+// POST /api/v1/playlists/:id/purchase
+func (h *PlaylistBundleHandler) Purchase(c *gin.Context) {
+	userVal, exists := c.Get("user")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	user, ok := userVal.(*models.User)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user information"})
+		return
+	}
+
+	playlistID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid playlist ID"})
+		return
+	}
+
+	response, err := h.bundleService.CreateCheckoutSession(c.Request.Context(), playlistID, user)
+	if err != nil {
+		h.respondError(c, err, "Failed to create checkout session")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ListEarnings returns the caller's bundle sales and refunds with totals
+// GET /api/v1/playlists/earnings
+func (h *PlaylistBundleHandler) ListEarnings(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	entries, summary, err := h.bundleService.ListLedger(c.Request.Context(), userID, limit, offset)
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve earnings")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    entries,
+		"summary": summary,
+		"meta": gin.H{
+			"limit":  limit,
+			"offset": offset,
+			"count":  len(entries),
+		},
+	})
+}
+
+func (h *PlaylistBundleHandler) respondError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrPlaylistBundlePlaylistNotFound),
+		errors.Is(err, repository.ErrPlaylistBundleNotFound),
+		errors.Is(err, services.ErrPlaylistBundleNotForSale):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrPlaylistBundleNotOwner):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrPlaylistBundlePrivate),
+		errors.Is(err, services.ErrPlaylistBundleOwnPlaylist):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrPlaylistBundleAlreadyPurchased):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
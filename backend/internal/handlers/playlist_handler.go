@@ -405,6 +405,16 @@ func (h *PlaylistHandler) CopyPlaylist(c *gin.Context) {
 			})
 			return
 		}
+		if errors.Is(err, services.ErrPlaylistPurchaseRequired) {
+			c.JSON(http.StatusForbidden, StandardResponse{
+				Success: false,
+				Error: &ErrorInfo{
+					Code:    "PURCHASE_REQUIRED",
+					Message: "This playlist must be purchased before it can be copied",
+				},
+			})
+			return
+		}
 		if strings.Contains(err.Error(), "unauthorized") {
 			c.JSON(http.StatusForbidden, StandardResponse{
 				Success: false,
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// ProfileViewHandler handles public profile view counting
+type ProfileViewHandler struct {
+	profileViewService *services.ProfileViewService
+}
+
+// NewProfileViewHandler creates a new profile view handler
+func NewProfileViewHandler(profileViewService *services.ProfileViewService) *ProfileViewHandler {
+	return &ProfileViewHandler{
+		profileViewService: profileViewService,
+	}
+}
+
+// TrackProfileView counts a view of a user's profile
+// POST /api/v1/users/:id/track-view
+func (h *ProfileViewHandler) TrackProfileView(c *gin.Context) {
+	profileID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	// Browser prefetches and prerenders aren't views
+	if isPrefetch(c.Request) {
+		c.JSON(http.StatusOK, gin.H{"counted": false})
+		return
+	}
+
+	counted, err := h.profileViewService.RecordView(c.Request.Context(), profileID, optionalUserID(c), c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		h.respondError(c, err, "Failed to track profile view")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"counted": counted})
+}
+
+// GetProfileViews returns a user's profile view counter
+// GET /api/v1/users/:id/profile-views
+func (h *ProfileViewHandler) GetProfileViews(c *gin.Context) {
+	profileID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	stats, err := h.profileViewService.GetStats(c.Request.Context(), profileID, optionalUserID(c))
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve profile views")
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+func (h *ProfileViewHandler) respondError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, repository.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+	case errors.Is(err, services.ErrProfileViewsHidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+// optionalUserID returns the signed-in user's ID on routes with optional auth
+func optionalUserID(c *gin.Context) *uuid.UUID {
+	if userIDInterface, exists := c.Get("user_id"); exists {
+		if uid, ok := userIDInterface.(uuid.UUID); ok {
+			return &uid
+		}
+	}
+	return nil
+}
+
+// isPrefetch reports whether a request is a speculative browser prefetch
+func isPrefetch(r *http.Request) bool {
+	for _, header := range []string{"Purpose", "Sec-Purpose", "X-Purpose", "X-Moz"} {
+		value := strings.ToLower(r.Header.Get(header))
+		if strings.Contains(value, "prefetch") || strings.Contains(value, "preview") || strings.Contains(value, "prerender") {
+			return true
+		}
+	}
+	return false
+}
@@ -82,6 +82,29 @@ func (h *RecommendationHandler) GetRecommendations(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// GetHomepageRecommendations handles GET /api/v1/recommendations
+// Signed-in users get hybrid recommendations; visitors get trending clips.
+func (h *RecommendationHandler) GetHomepageRecommendations(c *gin.Context) {
+	var userID *uuid.UUID
+	if userIDValue, exists := c.Get("user_id"); exists {
+		if id, ok := userIDValue.(uuid.UUID); ok {
+			userID = &id
+		}
+	}
+
+	limit := parseIntQueryParam(c, "limit", 20, 1, 100)
+
+	response, err := h.service.GetHomepageRecommendations(c.Request.Context(), userID, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to get recommendations",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // SubmitFeedback handles POST /api/v1/recommendations/feedback
 func (h *RecommendationHandler) SubmitFeedback(c *gin.Context) {
 	// Get user ID from context
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -11,15 +12,20 @@ import (
 	"github.com/subculture-collective/clipper/internal/models"
 	"github.com/subculture-collective/clipper/internal/repository"
 	"github.com/subculture-collective/clipper/internal/services"
+	"github.com/subculture-collective/clipper/pkg/utils"
 )
 
 // ReportHandler handles report-related HTTP requests
 type ReportHandler struct {
-	reportRepo  *repository.ReportRepository
-	clipRepo    *repository.ClipRepository
-	commentRepo *repository.CommentRepository
-	userRepo    *repository.UserRepository
-	authService *services.AuthService
+	reportRepo    *repository.ReportRepository
+	clipRepo      *repository.ClipRepository
+	commentRepo   *repository.CommentRepository
+	userRepo      *repository.UserRepository
+	authService   *services.AuthService
+	banService    *services.UserBanService
+	uploadService *services.UploadService
+	queueService  *services.ReportQueueService
+	outcomes      *services.ReportOutcomeService
 }
 
 // NewReportHandler creates a new report handler
@@ -39,12 +45,36 @@ func NewReportHandler(
 	}
 }
 
+// SetUserBanService makes report bans follow the ban ladder and expire
+func (h *ReportHandler) SetUserBanService(banService *services.UserBanService) {
+	h.banService = banService
+}
+
+// SetUploadService lets reports carry uploaded evidence
+func (h *ReportHandler) SetUploadService(uploadService *services.UploadService) {
+	h.uploadService = uploadService
+}
+
+// SetReportQueueService scores report groups as reports come in and serves
+// the prioritized report queue
+func (h *ReportHandler) SetReportQueueService(queueService *services.ReportQueueService) {
+	h.queueService = queueService
+}
+
+// SetReportOutcomeService tells reporters how their reports were resolved
+// and tracks their report accuracy
+func (h *ReportHandler) SetReportOutcomeService(outcomes *services.ReportOutcomeService) {
+	h.outcomes = outcomes
+}
+
 // CreateReportRequest represents the request body for creating a report
 type CreateReportRequest struct {
 	ReportableType string  `json:"reportable_type" binding:"required,oneof=clip comment user"`
 	ReportableID   string  `json:"reportable_id" binding:"required,uuid"`
 	Reason         string  `json:"reason" binding:"required,oneof=spam harassment nsfw violence copyright other"`
 	Description    *string `json:"description"`
+	// Completed report_evidence uploads, such as screenshots
+	EvidenceUploadIDs []uuid.UUID `json:"evidence_upload_ids" binding:"omitempty,max=5"`
 }
 
 // UpdateReportRequest represents the request body for updating a report
@@ -112,6 +142,26 @@ func (h *ReportHandler) SubmitReport(c *gin.Context) {
 		return
 	}
 
+	// Check evidence before creating the report
+	var evidence []*models.Upload
+	if len(req.EvidenceUploadIDs) > 0 {
+		if h.uploadService == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Evidence uploads are not enabled"})
+			return
+		}
+		for _, uploadID := range req.EvidenceUploadIDs {
+			upload, err := h.uploadService.Resolve(c.Request.Context(), userID.(uuid.UUID), uploadID, models.UploadContextReportEvidence)
+			if err == nil && upload.Status == models.UploadStatusAttached {
+				err = services.ErrUploadInUse
+			}
+			if err != nil {
+				respondUploadError(c, err, "Failed to check report evidence")
+				return
+			}
+			evidence = append(evidence, upload)
+		}
+	}
+
 	// Create the report
 	report := &models.Report{
 		ID:             uuid.New(),
@@ -129,9 +179,34 @@ func (h *ReportHandler) SubmitReport(c *gin.Context) {
 		return
 	}
 
+	// The scheduler rescores the group if this fails
+	if h.queueService != nil && report.GroupID != nil {
+		if err := h.queueService.ScoreGroup(c.Request.Context(), *report.GroupID); err != nil {
+			utils.Warn("Failed to score report group", map[string]interface{}{
+				"report_id": report.ID.String(),
+				"group_id":  report.GroupID.String(),
+				"error":     err.Error(),
+			})
+		}
+	}
+
+	attached := []*models.Upload{}
+	for _, upload := range evidence {
+		attachedUpload, err := h.uploadService.Attach(c.Request.Context(), userID.(uuid.UUID), upload.ID, models.UploadContextReportEvidence, "report", report.ID.String())
+		if err != nil {
+			utils.Warn("Failed to attach report evidence", map[string]interface{}{
+				"report_id": report.ID.String(),
+				"error":     err.Error(),
+			})
+			continue
+		}
+		attached = append(attached, attachedUpload)
+	}
+
 	c.JSON(http.StatusCreated, gin.H{
-		"message": "Report submitted successfully. Thank you for helping keep our community safe.",
-		"report":  report,
+		"message":  "Report submitted successfully. Thank you for helping keep our community safe.",
+		"report":   report,
+		"evidence": attached,
 	})
 }
 
@@ -193,10 +268,27 @@ func (h *ReportHandler) GetReport(c *gin.Context) {
 	// Get reporter information
 	reporter, _ := h.userRepo.GetByID(c.Request.Context(), report.ReporterID)
 
+	evidence := []models.Upload{}
+	if h.uploadService != nil {
+		if uploads, err := h.uploadService.ListAttached(c.Request.Context(), "report", report.ID.String()); err == nil {
+			evidence = uploads
+		}
+	}
+
+	// Reported comments may have been edited since; show what they said before
+	revisions := []models.CommentRevision{}
+	if report.ReportableType == "comment" {
+		if revs, err := h.commentRepo.ListRevisions(c.Request.Context(), report.ReportableID); err == nil {
+			revisions = revs
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"report":          report,
-		"reporter":        reporter,
-		"related_reports": relatedReports,
+		"report":            report,
+		"reporter":          reporter,
+		"related_reports":   relatedReports,
+		"evidence":          evidence,
+		"comment_revisions": revisions,
 	})
 }
 
@@ -234,6 +326,11 @@ func (h *ReportHandler) UpdateReport(c *gin.Context) {
 		return
 	}
 
+	// Only a report's first resolution counts toward its reporter's accuracy
+	if h.outcomes != nil && (report.Status == "pending" || report.Status == "reviewed") {
+		h.outcomes.RecordOutcomes(c.Request.Context(), []models.Report{*report}, req.Status)
+	}
+
 	// Take action if specified
 	if req.Action != nil {
 		if err := h.takeAction(c, report, *req.Action, userID.(uuid.UUID)); err != nil {
@@ -247,6 +344,197 @@ func (h *ReportHandler) UpdateReport(c *gin.Context) {
 	})
 }
 
+// ListReportQueue lists report groups, highest priority first, with each
+// group's report reasons rolled up (admin/moderator only)
+// Query: status (open, resolved or all; default open), type, page, limit
+func (h *ReportHandler) ListReportQueue(c *gin.Context) {
+	if h.queueService == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Report queue is not available"})
+		return
+	}
+
+	status := c.DefaultQuery("status", models.ReportGroupOpen)
+	if status == "all" {
+		status = ""
+	}
+	if status != "" && status != models.ReportGroupOpen && status != models.ReportGroupResolved {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status. Must be open, resolved or all"})
+		return
+	}
+	reportableType := c.DefaultQuery("type", "")
+	if reportableType != "" && reportableType != "clip" && reportableType != "comment" && reportableType != "user" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid type. Must be clip, comment or user"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	groups, total, err := h.queueService.ListQueue(c.Request.Context(), models.ReportQueueFilters{
+		Status:         status,
+		ReportableType: reportableType,
+		Limit:          limit,
+		Offset:         (page - 1) * limit,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch report queue"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": groups,
+		"meta": gin.H{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": (total + limit - 1) / limit,
+		},
+	})
+}
+
+// GetReportGroup retrieves a report group with its reports (admin/moderator only)
+func (h *ReportHandler) GetReportGroup(c *gin.Context) {
+	if h.queueService == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Report queue is not available"})
+		return
+	}
+
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid report group ID"})
+		return
+	}
+
+	group, err := h.queueService.GetGroup(c.Request.Context(), groupID)
+	if err != nil {
+		if errors.Is(err, repository.ErrReportGroupNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Report group not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch report group"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": group,
+	})
+}
+
+// ResolveReportGroup resolves every open report in a group and takes action
+// on the reported item (admin/moderator only)
+func (h *ReportHandler) ResolveReportGroup(c *gin.Context) {
+	if h.queueService == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Report queue is not available"})
+		return
+	}
+
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid report group ID"})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var req models.ResolveReportGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	group, resolved, err := h.queueService.ResolveGroup(c.Request.Context(), groupID, req.Status, userID.(uuid.UUID))
+	if err != nil {
+		if errors.Is(err, repository.ErrReportGroupNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Report group not found or already resolved"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve report group"})
+		return
+	}
+
+	if h.outcomes != nil {
+		h.outcomes.RecordOutcomes(c.Request.Context(), resolved, req.Status)
+	}
+
+	// Take action if specified, citing the most reported reason
+	if req.Action != nil {
+		report := &models.Report{
+			ReportableType: group.ReportableType,
+			ReportableID:   group.ReportableID,
+		}
+		if len(group.Reasons) > 0 {
+			report.Reason = group.Reasons[0].Reason
+		}
+		if err := h.takeAction(c, report, *req.Action, userID.(uuid.UUID)); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to take action: " + err.Error()})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Report group resolved successfully",
+		"data":    group,
+	})
+}
+
+// ListMyReports lists the current user's reports and their status, newest first
+// Query: status (pending, reviewed, actioned, dismissed), page, limit
+func (h *ReportHandler) ListMyReports(c *gin.Context) {
+	if h.outcomes == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Report history is not available"})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	status := c.DefaultQuery("status", "")
+	switch status {
+	case "", "pending", "reviewed", "actioned", "dismissed":
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status. Must be pending, reviewed, actioned or dismissed"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	reports, total, err := h.outcomes.ListMyReports(c.Request.Context(), userID.(uuid.UUID), status, limit, (page-1)*limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch reports"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": reports,
+		"meta": gin.H{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": (total + limit - 1) / limit,
+		},
+	})
+}
+
 // validateReportable checks if the reportable item exists
 func (h *ReportHandler) validateReportable(ctx context.Context, reportableType string, reportableID uuid.UUID) (bool, error) {
 	switch reportableType {
@@ -274,7 +562,7 @@ func (h *ReportHandler) validateReportable(ctx context.Context, reportableType s
 }
 
 // takeAction performs moderation actions based on the report
-func (h *ReportHandler) takeAction(c *gin.Context, report *models.Report, action string, _ uuid.UUID) error {
+func (h *ReportHandler) takeAction(c *gin.Context, report *models.Report, action string, moderatorID uuid.UUID) error {
 	ctx := c.Request.Context()
 
 	switch action {
@@ -332,6 +620,12 @@ func (h *ReportHandler) takeAction(c *gin.Context, report *models.Report, action
 		}
 
 		// Ban the user
+		if h.banService != nil {
+			_, err := h.banService.BanUser(ctx, targetUserID, moderatorID, services.BanRequest{
+				Reason: "Reported for " + report.Reason,
+			})
+			return err
+		}
 		return h.userRepo.BanUser(ctx, targetUserID)
 
 	case "mark_false":
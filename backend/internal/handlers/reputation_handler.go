@@ -1,18 +1,23 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
 	"github.com/subculture-collective/clipper/internal/services"
 )
 
 // ReputationHandler handles reputation-related HTTP requests
 type ReputationHandler struct {
-	reputationService *services.ReputationService
-	authService       *services.AuthService
+	reputationService  *services.ReputationService
+	authService        *services.AuthService
+	achievementService *services.AchievementService
+	leaderboardService *services.LeaderboardService
 }
 
 // NewReputationHandler creates a new reputation handler
@@ -23,6 +28,17 @@ func NewReputationHandler(reputationService *services.ReputationService, authSer
 	}
 }
 
+// SetAchievementService enables the achievements endpoint
+func (h *ReputationHandler) SetAchievementService(achievementService *services.AchievementService) {
+	h.achievementService = achievementService
+}
+
+// SetLeaderboardService enables weekly, monthly, seasonal, per-game and
+// per-community leaderboards
+func (h *ReputationHandler) SetLeaderboardService(leaderboardService *services.LeaderboardService) {
+	h.leaderboardService = leaderboardService
+}
+
 // GetUserReputation retrieves complete reputation info for a user
 // GET /users/:id/reputation
 func (h *ReputationHandler) GetUserReputation(c *gin.Context) {
@@ -151,8 +167,59 @@ func (h *ReputationHandler) GetUserBadges(c *gin.Context) {
 	})
 }
 
-// GetLeaderboard retrieves leaderboard by type
-// GET /leaderboards/:type
+// GetUserAchievements retrieves every achievement with a user's progress toward it
+// GET /users/:id/achievements
+func (h *ReputationHandler) GetUserAchievements(c *gin.Context) {
+	userIDStr := c.Param("id")
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid user ID",
+			"code":    "INVALID_USER_ID",
+			"message": "The provided user ID is not valid",
+		})
+		return
+	}
+
+	if h.achievementService == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error":   "Achievements are not available",
+			"code":    "ACHIEVEMENTS_UNAVAILABLE",
+			"message": "Achievements are not available right now.",
+		})
+		return
+	}
+
+	achievements, err := h.achievementService.ListUserAchievements(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to get user achievements",
+			"code":    "ACHIEVEMENTS_FETCH_ERROR",
+			"message": "Unable to retrieve user achievements. Please try again later.",
+		})
+		return
+	}
+
+	completed := 0
+	for _, achievement := range achievements {
+		if achievement.Completed {
+			completed++
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"achievements": achievements,
+		"completed":    completed,
+		"total":        len(achievements),
+	})
+}
+
+// GetLeaderboard retrieves leaderboard by type. The period query parameter
+// selects a weekly, monthly or seasonal leaderboard instead of the all-time
+// one, and game_id or community_id narrow a periodic leaderboard to a game or
+// community.
+// GET /leaderboards/:type?period=weekly&game_id=
 func (h *ReputationHandler) GetLeaderboard(c *gin.Context) {
 	leaderboardType := c.Param("type")
 
@@ -170,6 +237,14 @@ func (h *ReputationHandler) GetLeaderboard(c *gin.Context) {
 	}
 	offset := (page - 1) * limit
 
+	period := c.DefaultQuery("period", models.LeaderboardPeriodAllTime)
+	gameID := c.Query("game_id")
+	communityID := c.Query("community_id")
+	if period != models.LeaderboardPeriodAllTime || gameID != "" || communityID != "" {
+		h.getPeriodLeaderboard(c, leaderboardType, period, gameID, communityID, page, limit, offset)
+		return
+	}
+
 	var entries interface{}
 	switch leaderboardType {
 	case "karma":
@@ -204,6 +279,103 @@ func (h *ReputationHandler) GetLeaderboard(c *gin.Context) {
 	})
 }
 
+// getPeriodLeaderboard serves a leaderboard snapshot
+func (h *ReputationHandler) getPeriodLeaderboard(c *gin.Context, leaderboardType, period, gameID, communityID string, page, limit, offset int) {
+	if h.leaderboardService == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error":   "Leaderboards unavailable",
+			"code":    "LEADERBOARDS_UNAVAILABLE",
+			"message": "Periodic leaderboards are not enabled",
+		})
+		return
+	}
+
+	if period == models.LeaderboardPeriodAllTime {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid leaderboard scope",
+			"code":    "INVALID_LEADERBOARD_SCOPE",
+			"message": "Game and community leaderboards need a weekly, monthly or season period",
+		})
+		return
+	}
+	if gameID != "" && communityID != "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid leaderboard scope",
+			"code":    "INVALID_LEADERBOARD_SCOPE",
+			"message": "Specify game_id or community_id, not both",
+		})
+		return
+	}
+
+	scope, scopeID := models.LeaderboardScopeGlobal, ""
+	switch {
+	case gameID != "":
+		scope, scopeID = models.LeaderboardScopeGame, gameID
+	case communityID != "":
+		id, err := uuid.Parse(communityID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid community ID",
+				"code":    "INVALID_COMMUNITY_ID",
+				"message": "The provided community ID is not valid",
+			})
+			return
+		}
+		scope, scopeID = models.LeaderboardScopeCommunity, id.String()
+	}
+
+	snapshot, entries, err := h.leaderboardService.GetLeaderboard(c.Request.Context(), leaderboardType, period, c.Query("key"), scope, scopeID, limit, offset)
+	switch {
+	case errors.Is(err, services.ErrInvalidLeaderboardType):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid leaderboard type",
+			"code":    "INVALID_LEADERBOARD_TYPE",
+			"message": err.Error(),
+		})
+		return
+	case errors.Is(err, services.ErrInvalidLeaderboardPeriod):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid leaderboard period",
+			"code":    "INVALID_LEADERBOARD_PERIOD",
+			"message": err.Error(),
+		})
+		return
+	case errors.Is(err, repository.ErrLeaderboardSnapshotNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Leaderboard not found",
+			"code":    "LEADERBOARD_NOT_FOUND",
+			"message": "No leaderboard was computed for this period",
+		})
+		return
+	case err != nil:
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve leaderboard",
+			"code":    "LEADERBOARD_FETCH_ERROR",
+			"message": "Unable to retrieve leaderboard data. Please try again later.",
+		})
+		return
+	}
+
+	response := gin.H{
+		"type":         leaderboardType,
+		"period":       snapshot.Period,
+		"period_key":   snapshot.PeriodKey,
+		"period_start": snapshot.PeriodStart,
+		"period_end":   snapshot.PeriodEnd,
+		"scope":        snapshot.Scope,
+		"scope_id":     snapshot.ScopeID,
+		"finalized":    snapshot.Finalized,
+		"page":         page,
+		"limit":        limit,
+		"entries":      entries,
+	}
+	if !snapshot.ComputedAt.IsZero() {
+		response["computed_at"] = snapshot.ComputedAt
+	}
+	c.JSON(http.StatusOK, response)
+}
+
 // AwardBadge awards a badge to a user (admin only)
 // POST /admin/users/:id/badges
 func (h *ReputationHandler) AwardBadge(c *gin.Context) {
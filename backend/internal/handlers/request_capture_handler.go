@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// RequestCaptureHandler manages diagnostic request capture sessions
+type RequestCaptureHandler struct {
+	captureService *services.RequestCaptureService
+}
+
+// NewRequestCaptureHandler creates a new request capture handler
+func NewRequestCaptureHandler(captureService *services.RequestCaptureService) *RequestCaptureHandler {
+	return &RequestCaptureHandler{
+		captureService: captureService,
+	}
+}
+
+// StartSession starts sampling requests to a route
+// POST /api/v1/admin/diagnostics/captures
+func (h *RequestCaptureHandler) StartSession(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	adminID := userIDVal.(uuid.UUID)
+
+	var req models.StartRequestCaptureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	session, err := h.captureService.StartSession(c.Request.Context(), adminID, &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to start request capture")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": session})
+}
+
+// ListSessions returns the sessions whose captures are still readable
+// GET /api/v1/admin/diagnostics/captures
+func (h *RequestCaptureHandler) ListSessions(c *gin.Context) {
+	sessions, err := h.captureService.ListSessions(c.Request.Context())
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve request capture sessions")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": sessions})
+}
+
+// ListCaptures returns a session's captured requests, newest first
+// GET /api/v1/admin/diagnostics/captures/:id
+func (h *RequestCaptureHandler) ListCaptures(c *gin.Context) {
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid session ID"})
+		return
+	}
+
+	captures, err := h.captureService.ListCaptures(c.Request.Context(), sessionID)
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve request captures")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": captures})
+}
+
+// StopSession stops sampling before the session expires
+// POST /api/v1/admin/diagnostics/captures/:id/stop
+func (h *RequestCaptureHandler) StopSession(c *gin.Context) {
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid session ID"})
+		return
+	}
+
+	session, err := h.captureService.StopSession(c.Request.Context(), sessionID)
+	if err != nil {
+		h.respondError(c, err, "Failed to stop request capture")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": session})
+}
+
+func (h *RequestCaptureHandler) respondError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrRequestCaptureNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrRequestCaptureInvalid):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// SearchAnalyticsHandler handles search click tracking and the search
+// analytics dashboard
+type SearchAnalyticsHandler struct {
+	analyticsService *services.SearchAnalyticsService
+}
+
+// NewSearchAnalyticsHandler creates a new search analytics handler
+func NewSearchAnalyticsHandler(analyticsService *services.SearchAnalyticsService) *SearchAnalyticsHandler {
+	return &SearchAnalyticsHandler{
+		analyticsService: analyticsService,
+	}
+}
+
+// RecordClick records that a search result was opened. search_id is the ID
+// returned with the search's results and position the result's 1-based rank.
+// POST /api/v1/search/clicks
+func (h *SearchAnalyticsHandler) RecordClick(c *gin.Context) {
+	var req models.SearchClickRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	var userID *uuid.UUID
+	if id, ok := c.Get("user_id"); ok {
+		if uid, ok := id.(uuid.UUID); ok {
+			userID = &uid
+		}
+	}
+
+	if err := h.analyticsService.RecordClick(c.Request.Context(), userID, &req); err != nil {
+		h.respondError(c, err, "Failed to record search click")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AdminGetAnalytics returns the top queries, zero-result queries,
+// click-through rate by result position and search trends of the last ?days=
+// (default 30). ?limit= caps the query lists, ?positions= the positions and
+// ?interval= (day or week) sets the trend buckets.
+// GET /api/v1/admin/search/analytics
+func (h *SearchAnalyticsHandler) AdminGetAnalytics(c *gin.Context) {
+	report, err := h.analyticsService.Report(c.Request.Context(), services.SearchAnalyticsOptions{
+		Days:      parseIntQueryParam(c, "days", 30, 1, 365),
+		Limit:     parseIntQueryParam(c, "limit", 20, 1, 100),
+		Positions: parseIntQueryParam(c, "positions", 10, 1, 50),
+		Interval:  c.Query("interval"),
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to build search analytics")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
+
+func (h *SearchAnalyticsHandler) respondError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, repository.ErrSearchNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrSearchAnalyticsInvalid):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/subculture-collective/clipper/internal/models"
 	"github.com/subculture-collective/clipper/internal/repository"
 	"github.com/subculture-collective/clipper/internal/services"
@@ -23,6 +24,9 @@ type SearchHandler struct {
 	authService         *services.AuthService
 	useOpenSearch       bool
 	useHybridSearch     bool
+	featureFlags        featureFlagChecker
+	gamePatches         gamePatchResolver
+	tagGraph            tagQueryExpander
 }
 
 // openSearchProvider defines the subset of methods needed from the OpenSearch service.
@@ -37,6 +41,24 @@ type hybridSearchProvider interface {
 	SearchWithScores(ctx context.Context, req *models.SearchRequest) (*models.SearchResponseWithScores, error)
 }
 
+// featureFlagChecker reports whether a feature flag is on for a subject.
+type featureFlagChecker interface {
+	Enabled(ctx context.Context, key string, subject *models.FeatureFlagSubject, fallback bool) bool
+}
+
+// errInvalidPatchFilter is returned for a malformed or unknown patch_id
+var errInvalidPatchFilter = errors.New("Invalid patch_id")
+
+// gamePatchResolver resolves the patch_id filter for search backends
+type gamePatchResolver interface {
+	Window(ctx context.Context, patchID uuid.UUID) (*models.GamePatchWindow, error)
+}
+
+// tagQueryExpander finds related tags to expand searches for a tag
+type tagQueryExpander interface {
+	ExpandQuery(ctx context.Context, query string) []string
+}
+
 // NewSearchHandler creates a new SearchHandler with PostgreSQL FTS
 func NewSearchHandler(searchRepo *repository.SearchRepository, authService *services.AuthService) *SearchHandler {
 	return &SearchHandler{
@@ -91,6 +113,37 @@ func NewSearchHandlerWithHybridProvider(searchRepo *repository.SearchRepository,
 	}
 }
 
+// SetFeatureFlags lets the search.hybrid flag turn hybrid search off, or roll
+// it out gradually, without a redeploy
+func (h *SearchHandler) SetFeatureFlags(flags featureFlagChecker) {
+	h.featureFlags = flags
+}
+
+// SetGamePatchService enables the patch_id filter
+func (h *SearchHandler) SetGamePatchService(gamePatches gamePatchResolver) {
+	h.gamePatches = gamePatches
+}
+
+// SetTagGraph expands clip searches for a tag with its related tags
+func (h *SearchHandler) SetTagGraph(tagGraph tagQueryExpander) {
+	h.tagGraph = tagGraph
+}
+
+// hybridSearchEnabled reports whether this request should use hybrid search
+func (h *SearchHandler) hybridSearchEnabled(c *gin.Context) bool {
+	if !h.useHybridSearch || h.hybridSearchService == nil {
+		return false
+	}
+	if h.featureFlags == nil {
+		return true
+	}
+	var user *models.User
+	if userVal, exists := c.Get("user"); exists {
+		user, _ = userVal.(*models.User)
+	}
+	return h.featureFlags.Enabled(c.Request.Context(), models.FeatureFlagHybridSearch, models.NewFeatureFlagSubject(user), true)
+}
+
 // parseIntQueryParam safely parses an integer query parameter with default value and bounds
 func parseIntQueryParam(c *gin.Context, key string, defaultValue, min, max int) int {
 	valueStr := c.Query(key)
@@ -131,14 +184,33 @@ func (h *SearchHandler) Search(c *gin.Context) {
 		return
 	}
 
+	if err := h.resolvePatchFilter(c.Request.Context(), &req); err != nil {
+		if errors.Is(err, errInvalidPatchFilter) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve patch filter"})
+		}
+		return
+	}
+	h.expandQuery(c.Request.Context(), &req)
+
+	// Let hybrid search personalize results for signed-in users
+	if userVal, exists := c.Get("user"); exists {
+		if user, ok := userVal.(*models.User); ok {
+			req.UserID = &user.ID
+		}
+	}
+
 	// Perform search using hybrid search, OpenSearch, or PostgreSQL fallback
 	var results *models.SearchResponse
 	var err error
 	var usedFallback bool
 	var failoverReason string
 	var fallbackStartTime time.Time
+	backend := "postgres"
 
-	if h.useHybridSearch && h.hybridSearchService != nil {
+	if h.hybridSearchEnabled(c) {
+		backend = "hybrid"
 		// Use hybrid BM25 + vector similarity search
 		// Note: Hybrid search does not have a fallback - it requires both OpenSearch and embeddings
 		results, err = h.hybridSearchService.Search(c.Request.Context(), &req)
@@ -148,6 +220,7 @@ func (h *SearchHandler) Search(c *gin.Context) {
 			failoverReason = getFailoverReason(err)
 			// Track hybrid search unavailability separately (not a true failover since no fallback)
 			metrics.SearchQueriesTotal.WithLabelValues("hybrid", "unavailable").Inc()
+			metrics.RecordSearch(backend, "unavailable")
 
 			c.Header("Retry-After", "60") // Suggest retry after 60 seconds
 			c.JSON(http.StatusServiceUnavailable, gin.H{
@@ -157,6 +230,7 @@ func (h *SearchHandler) Search(c *gin.Context) {
 		}
 	} else if h.useOpenSearch && h.openSearchService != nil {
 		// Use OpenSearch BM25 only
+		backend = "opensearch"
 		results, err = h.openSearchService.Search(c.Request.Context(), &req)
 		if err != nil {
 			// Fall back to PostgreSQL FTS
@@ -164,6 +238,7 @@ func (h *SearchHandler) Search(c *gin.Context) {
 			failoverReason = getFailoverReason(err)
 			usedFallback = true
 			fallbackStartTime = time.Now()
+			backend = "postgres"
 
 			// Track failover event
 			metrics.SearchFallbackTotal.WithLabelValues(failoverReason).Inc()
@@ -172,6 +247,7 @@ func (h *SearchHandler) Search(c *gin.Context) {
 			if err != nil {
 				// PostgreSQL fallback also failed
 				fmt.Printf("PostgreSQL fallback error: %v\n", err)
+				metrics.RecordSearch(backend, "error")
 				c.JSON(http.StatusInternalServerError, gin.H{
 					"error": "Failed to perform search",
 				})
@@ -187,6 +263,7 @@ func (h *SearchHandler) Search(c *gin.Context) {
 		results, err = h.searchRepo.Search(c.Request.Context(), &req)
 		if err != nil {
 			fmt.Printf("Search error: %v\n", err)
+			metrics.RecordSearch(backend, "error")
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error": "Failed to perform search",
 			})
@@ -196,24 +273,16 @@ func (h *SearchHandler) Search(c *gin.Context) {
 
 	// Add failover headers if fallback was used
 	if usedFallback {
+		metrics.RecordSearch(backend, "fallback")
 		c.Header("X-Search-Failover", "true")
 		c.Header("X-Search-Failover-Reason", failoverReason)
 		c.Header("X-Search-Failover-Service", "opensearch")
+	} else {
+		metrics.RecordSearch(backend, "success")
 	}
 
 	// Track search analytics (optional, get user ID if authenticated)
-	totalResults := results.Counts.Clips + results.Counts.Creators + results.Counts.Games + results.Counts.Tags
-
-	// Try to get user from context (if authenticated)
-	if userVal, exists := c.Get("user"); exists {
-		if user, ok := userVal.(*models.User); ok {
-			// Track search with user ID
-			_ = h.searchRepo.TrackSearch(c.Request.Context(), &user.ID, req.Query, totalResults)
-		}
-	} else {
-		// Track anonymous search
-		_ = h.searchRepo.TrackSearch(c.Request.Context(), nil, req.Query, totalResults)
-	}
+	results.SearchID = h.trackSearch(c, req.Query, results)
 
 	c.JSON(http.StatusOK, results)
 }
@@ -264,9 +333,55 @@ func (h *SearchHandler) validateAndSetDefaults(req *models.SearchRequest) error
 		req.Sort = "relevance"
 	}
 
+	if _, _, err := services.ResolveHighlightTags(req.HighlightPreTag, req.HighlightPostTag); err != nil {
+		return err
+	}
+
+	if err := services.ValidateSearchRanges(req); err != nil {
+		return err
+	}
+
+	switch req.Personalization {
+	case "", models.SearchPersonalizationOn, models.SearchPersonalizationOff:
+	default:
+		return fmt.Errorf("personalization must be 'on' or 'off'")
+	}
+
+	return nil
+}
+
+// resolvePatchFilter looks up when the requested patch was in effect
+func (h *SearchHandler) resolvePatchFilter(ctx context.Context, req *models.SearchRequest) error {
+	if req.PatchID == nil || *req.PatchID == "" {
+		req.PatchID = nil
+		return nil
+	}
+	patchID, err := uuid.Parse(*req.PatchID)
+	if err != nil || h.gamePatches == nil {
+		return errInvalidPatchFilter
+	}
+	window, err := h.gamePatches.Window(ctx, patchID)
+	if err != nil {
+		if errors.Is(err, repository.ErrGamePatchNotFound) {
+			return errInvalidPatchFilter
+		}
+		return err
+	}
+	req.PatchWindow = window
 	return nil
 }
 
+// expandQuery adds the tags related to the tag a clip search names
+func (h *SearchHandler) expandQuery(ctx context.Context, req *models.SearchRequest) {
+	if h.tagGraph == nil || req.Query == "" {
+		return
+	}
+	if req.Type != "" && req.Type != "all" && req.Type != "clips" {
+		return
+	}
+	req.ExpansionTerms = h.tagGraph.ExpandQuery(ctx, req.Query)
+}
+
 // GetSuggestions handles autocomplete suggestions
 // GET /api/v1/search/suggestions
 func (h *SearchHandler) GetSuggestions(c *gin.Context) {
@@ -356,6 +471,16 @@ func (h *SearchHandler) SearchWithScores(c *gin.Context) {
 		return
 	}
 
+	if err := h.resolvePatchFilter(c.Request.Context(), &req); err != nil {
+		if errors.Is(err, errInvalidPatchFilter) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve patch filter"})
+		}
+		return
+	}
+	h.expandQuery(c.Request.Context(), &req)
+
 	// Only hybrid search supports scores
 	if !h.useHybridSearch || h.hybridSearchService == nil {
 		c.JSON(http.StatusBadRequest, gin.H{
@@ -374,18 +499,30 @@ func (h *SearchHandler) SearchWithScores(c *gin.Context) {
 	}
 
 	// Track search analytics
+	results.SearchID = h.trackSearch(c, req.Query, &results.SearchResponse)
+
+	c.JSON(http.StatusOK, results)
+}
+
+// trackSearch records a search for analytics, with the user's ID when
+// authenticated, and returns its ID for click tracking. Failures are ignored.
+func (h *SearchHandler) trackSearch(c *gin.Context, query string, results *models.SearchResponse) *uuid.UUID {
 	totalResults := results.Counts.Clips + results.Counts.Creators + results.Counts.Games + results.Counts.Tags
 
-	// Try to get user from context (if authenticated)
+	var userID *uuid.UUID
 	if userVal, exists := c.Get("user"); exists {
-		if user, ok := userVal.(*models.User); ok {
-			_ = h.searchRepo.TrackSearch(c.Request.Context(), &user.ID, req.Query, totalResults)
+		user, ok := userVal.(*models.User)
+		if !ok {
+			return nil
 		}
-	} else {
-		_ = h.searchRepo.TrackSearch(c.Request.Context(), nil, req.Query, totalResults)
+		userID = &user.ID
 	}
 
-	c.JSON(http.StatusOK, results)
+	id, err := h.searchRepo.TrackSearch(c.Request.Context(), userID, query, totalResults, results.Ranker)
+	if err != nil {
+		return nil
+	}
+	return &id
 }
 
 // GetTrendingSearches returns the most popular search queries
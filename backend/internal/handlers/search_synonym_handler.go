@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// SearchSynonymHandler handles search synonym management
+type SearchSynonymHandler struct {
+	synonymService *services.SearchSynonymService
+}
+
+// NewSearchSynonymHandler creates a new search synonym handler
+func NewSearchSynonymHandler(synonymService *services.SearchSynonymService) *SearchSynonymHandler {
+	return &SearchSynonymHandler{
+		synonymService: synonymService,
+	}
+}
+
+// AdminListSynonyms returns every synonym, or those containing ?term=
+// GET /api/v1/admin/search/synonyms
+func (h *SearchSynonymHandler) AdminListSynonyms(c *gin.Context) {
+	synonyms, err := h.synonymService.ListSynonyms(c.Request.Context(), c.Query("term"))
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve search synonyms")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": synonyms})
+}
+
+// AdminGetRules returns the synonym filter rules new indices are built with
+// and their version
+// GET /api/v1/admin/search/synonyms/rules
+func (h *SearchSynonymHandler) AdminGetRules(c *gin.Context) {
+	rules, err := h.synonymService.GetRules(c.Request.Context())
+	if err != nil {
+		h.respondError(c, err, "Failed to build search synonym rules")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": rules})
+}
+
+// AdminCreateSynonym creates a synonym
+// POST /api/v1/admin/search/synonyms
+func (h *SearchSynonymHandler) AdminCreateSynonym(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	adminID := userIDVal.(uuid.UUID)
+
+	var req models.CreateSearchSynonymRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	synonym, err := h.synonymService.CreateSynonym(c.Request.Context(), adminID, &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to create search synonym")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": synonym})
+}
+
+// AdminGetSynonym returns a synonym
+// GET /api/v1/admin/search/synonyms/:id
+func (h *SearchSynonymHandler) AdminGetSynonym(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid synonym ID"})
+		return
+	}
+
+	synonym, err := h.synonymService.GetSynonym(c.Request.Context(), id)
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve search synonym")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": synonym})
+}
+
+// AdminUpdateSynonym changes a synonym's terms, target or switch
+// PATCH /api/v1/admin/search/synonyms/:id
+func (h *SearchSynonymHandler) AdminUpdateSynonym(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	adminID := userIDVal.(uuid.UUID)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid synonym ID"})
+		return
+	}
+
+	var req models.UpdateSearchSynonymRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	synonym, err := h.synonymService.UpdateSynonym(c.Request.Context(), id, adminID, &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to update search synonym")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": synonym})
+}
+
+// AdminDeleteSynonym removes a synonym
+// DELETE /api/v1/admin/search/synonyms/:id
+func (h *SearchSynonymHandler) AdminDeleteSynonym(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid synonym ID"})
+		return
+	}
+
+	if err := h.synonymService.DeleteSynonym(c.Request.Context(), id); err != nil {
+		h.respondError(c, err, "Failed to delete search synonym")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Search synonym deleted"})
+}
+
+func (h *SearchSynonymHandler) respondError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, repository.ErrSearchSynonymNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrSearchSynonymInvalid):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
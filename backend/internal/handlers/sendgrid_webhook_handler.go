@@ -19,6 +19,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/subculture-collective/clipper/internal/models"
 	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/pkg/metrics"
 	"github.com/subculture-collective/clipper/pkg/utils"
 )
 
@@ -66,6 +67,8 @@ func NewSendGridWebhookHandler(emailLogRepo *repository.EmailLogRepository, send
 // @Failure 500 {object} map[string]string
 // @Router /api/v1/webhooks/sendgrid [post]
 func (h *SendGridWebhookHandler) HandleWebhook(c *gin.Context) {
+	defer func() { metrics.RecordWebhookReceived("sendgrid", c.Writer.Status()) }()
+
 	// Read request body
 	body, err := io.ReadAll(c.Request.Body)
 	if err != nil {
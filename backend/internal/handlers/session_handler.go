@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// refreshTokenHeader lets clients without cookies identify their current session
+const refreshTokenHeader = "X-Refresh-Token"
+
+// SessionHandler handles listing and revoking signed-in devices
+type SessionHandler struct {
+	sessionService *services.SessionService
+}
+
+// NewSessionHandler creates a new session handler
+func NewSessionHandler(sessionService *services.SessionService) *SessionHandler {
+	return &SessionHandler{
+		sessionService: sessionService,
+	}
+}
+
+// ListSessions lists the current user's active sessions
+// GET /api/v1/users/me/sessions
+func (h *SessionHandler) ListSessions(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	sessions, err := h.sessionService.ListSessions(c.Request.Context(), userID, requestRefreshToken(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list sessions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"sessions": sessions})
+}
+
+// RevokeSession signs one of the current user's devices out
+// DELETE /api/v1/users/me/sessions/:id
+func (h *SessionHandler) RevokeSession(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid session ID"})
+		return
+	}
+
+	if err := h.sessionService.RevokeSession(c.Request.Context(), userID, sessionID); err != nil {
+		if errors.Is(err, repository.ErrSessionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke session"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Session revoked"})
+}
+
+// RevokeOtherSessions signs out every device except the current one
+// DELETE /api/v1/users/me/sessions
+func (h *SessionHandler) RevokeOtherSessions(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	revoked, err := h.sessionService.RevokeOtherSessions(c.Request.Context(), userID, requestRefreshToken(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke sessions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Other sessions revoked",
+		"revoked": revoked,
+	})
+}
+
+// sessionClient captures the device metadata stored with a session
+func sessionClient(c *gin.Context) models.SessionClient {
+	return models.SessionClient{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
+
+// requestRefreshToken returns the caller's refresh token from the cookie or header, if any
+func requestRefreshToken(c *gin.Context) string {
+	if token, err := c.Cookie("refresh_token"); err == nil && token != "" {
+		return token
+	}
+	return c.GetHeader(refreshTokenHeader)
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+const (
+	// ShareRefCookie carries the share code a visitor arrived through
+	ShareRefCookie = "share_ref"
+	// shareRefCookieMaxAge keeps sign-up attribution open for 7 days
+	shareRefCookieMaxAge = 604800
+)
+
+// ShareLinkHandler handles clip short links and share analytics
+type ShareLinkHandler struct {
+	shareLinkService *services.ShareLinkService
+	cfg              *config.Config
+}
+
+// NewShareLinkHandler creates a new share link handler
+func NewShareLinkHandler(shareLinkService *services.ShareLinkService, cfg *config.Config) *ShareLinkHandler {
+	return &ShareLinkHandler{
+		shareLinkService: shareLinkService,
+		cfg:              cfg,
+	}
+}
+
+// CreateShareLink creates a short link for sharing a clip
+// POST /api/v1/clips/:id/share
+func (h *ShareLinkHandler) CreateShareLink(c *gin.Context) {
+	clipID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid clip ID"})
+		return
+	}
+
+	var req struct {
+		Channel string `json:"channel"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+	}
+
+	var userID *uuid.UUID
+	if value, exists := c.Get("user_id"); exists {
+		if id, ok := value.(uuid.UUID); ok {
+			userID = &id
+		}
+	}
+
+	link, err := h.shareLinkService.CreateShareLink(c.Request.Context(), clipID, userID, req.Channel)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidShareChannel):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid share channel"})
+		case errors.Is(err, services.ErrShareClipUnavailable):
+			c.JSON(http.StatusNotFound, gin.H{"error": "clip not found"})
+		case errors.Is(err, services.ErrShareLinkRateLimited):
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "too many share links created, try again later"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create share link"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, link)
+}
+
+// RedirectShareLink records a click and redirects to the shared clip
+// GET /s/:code
+func (h *ShareLinkHandler) RedirectShareLink(c *gin.Context) {
+	ctx := c.Request.Context()
+	code := c.Param("code")
+
+	link, err := h.shareLinkService.Resolve(ctx, code)
+	if err != nil {
+		baseURL := strings.TrimRight(h.cfg.Server.BaseURL, "/")
+		switch {
+		case errors.Is(err, repository.ErrShareLinkNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "share link not found"})
+		case errors.Is(err, services.ErrShareLinkExpired), errors.Is(err, services.ErrShareLinkDisabled):
+			c.Redirect(http.StatusFound, baseURL+"/?share=expired")
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to resolve share link"})
+		}
+		return
+	}
+
+	if err := h.shareLinkService.RecordClick(ctx, link, c.ClientIP(), c.Request.Referer()); err != nil {
+		log.Printf("Failed to record share click for %s: %v", link.Code, err)
+	}
+
+	isProduction := h.cfg.Server.GinMode == "release"
+	c.SetCookie(ShareRefCookie, link.Code, shareRefCookieMaxAge, "/", "", isProduction, true)
+
+	c.Redirect(http.StatusFound, h.shareLinkService.ClipURL(link))
+}
+
+// GetClipShareAnalytics returns share analytics for a clip
+// GET /api/v1/clips/:id/share-analytics
+func (h *ShareLinkHandler) GetClipShareAnalytics(c *gin.Context) {
+	clipID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid clip ID"})
+		return
+	}
+
+	analytics, err := h.shareLinkService.GetClipAnalytics(c.Request.Context(), clipID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve share analytics"})
+		return
+	}
+
+	c.JSON(http.StatusOK, analytics)
+}
+
+// GetCreatorShareAnalytics returns share analytics across a creator's clips
+// GET /api/v1/creators/:creatorName/analytics/shares
+func (h *ShareLinkHandler) GetCreatorShareAnalytics(c *gin.Context) {
+	creatorName := c.Param("creatorName")
+	if creatorName == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "creator name is required"})
+		return
+	}
+
+	analytics, err := h.shareLinkService.GetCreatorAnalytics(c.Request.Context(), creatorName)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve share analytics"})
+		return
+	}
+
+	c.JSON(http.StatusOK, analytics)
+}
+
+// DisableShareLink disables an abusive share link
+// POST /api/v1/admin/share-links/:code/disable
+func (h *ShareLinkHandler) DisableShareLink(c *gin.Context) {
+	var req struct {
+		Reason string `json:"reason" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "reason is required"})
+		return
+	}
+
+	if err := h.shareLinkService.DisableShareLink(c.Request.Context(), c.Param("code"), req.Reason); err != nil {
+		if errors.Is(err, repository.ErrShareLinkNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "share link not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to disable share link"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "share link disabled"})
+}
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -84,6 +86,8 @@ func (h *SubmissionHandler) SubmitClip(c *gin.Context) {
 	message := "Clip submitted for review"
 	if submission.Status == "approved" {
 		message = "Clip submitted and auto-approved!"
+	} else if submission.Status == "rejected" {
+		message = "Clip submission was rejected"
 	}
 
 	c.JSON(status, gin.H{
@@ -371,6 +375,12 @@ func (h *SubmissionHandler) ApproveSubmission(c *gin.Context) {
 	reviewerID := reviewerIDVal.(uuid.UUID)
 
 	if err := h.submissionService.ApproveSubmission(c.Request.Context(), submissionID, reviewerID); err != nil {
+		if errors.Is(err, repository.ErrModerationItemClaimed) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Submission is claimed by another moderator",
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to approve submission: " + err.Error(),
 		})
@@ -416,6 +426,12 @@ func (h *SubmissionHandler) RejectSubmission(c *gin.Context) {
 	}
 
 	if err := h.submissionService.RejectSubmission(c.Request.Context(), submissionID, reviewerID, req.Reason); err != nil {
+		if errors.Is(err, repository.ErrModerationItemClaimed) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Submission is claimed by another moderator",
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to reject submission: " + err.Error(),
 		})
@@ -428,7 +444,9 @@ func (h *SubmissionHandler) RejectSubmission(c *gin.Context) {
 	})
 }
 
-// BulkApproveSubmissions approves multiple submissions (admin/moderator only)
+// BulkApproveSubmissions approves multiple submissions (admin/moderator only).
+// Each submission is approved on its own and reported in the per-item
+// results; requests over the async threshold run as a background job.
 // POST /admin/submissions/bulk-approve
 func (h *SubmissionHandler) BulkApproveSubmissions(c *gin.Context) {
 	// Get reviewer ID from context
@@ -451,41 +469,16 @@ func (h *SubmissionHandler) BulkApproveSubmissions(c *gin.Context) {
 		return
 	}
 
-	if len(req.SubmissionIDs) == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "At least one submission ID is required",
-		})
-		return
-	}
-
-	// Parse UUIDs
-	submissionIDs := make([]uuid.UUID, 0, len(req.SubmissionIDs))
-	for _, idStr := range req.SubmissionIDs {
-		id, err := uuid.Parse(idStr)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "Invalid submission ID: " + idStr,
-			})
-			return
-		}
-		submissionIDs = append(submissionIDs, id)
-	}
-
-	if err := h.submissionService.BulkApproveSubmissions(c.Request.Context(), submissionIDs, reviewerID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to bulk approve submissions: " + err.Error(),
-		})
+	submissionIDs, ok := parseBulkSubmissionIDs(c, req.SubmissionIDs)
+	if !ok {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"message": "Submissions approved",
-		"count":   len(submissionIDs),
-	})
+	h.runBulkModeration(c, models.BulkSubmissionActionApprove, submissionIDs, reviewerID, "")
 }
 
-// BulkRejectSubmissions rejects multiple submissions (admin/moderator only)
+// BulkRejectSubmissions rejects multiple submissions (admin/moderator only),
+// reporting per-item results like BulkApproveSubmissions
 // POST /admin/submissions/bulk-reject
 func (h *SubmissionHandler) BulkRejectSubmissions(c *gin.Context) {
 	// Get reviewer ID from context
@@ -509,37 +502,126 @@ func (h *SubmissionHandler) BulkRejectSubmissions(c *gin.Context) {
 		return
 	}
 
-	if len(req.SubmissionIDs) == 0 {
+	submissionIDs, ok := parseBulkSubmissionIDs(c, req.SubmissionIDs)
+	if !ok {
+		return
+	}
+
+	h.runBulkModeration(c, models.BulkSubmissionActionReject, submissionIDs, reviewerID, req.Reason)
+}
+
+// GetBulkModerationJob returns the progress and results of a background bulk moderation job
+// GET /admin/submissions/bulk-jobs/:id
+func (h *SubmissionHandler) GetBulkModerationJob(c *gin.Context) {
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "At least one submission ID is required",
+			"error": "Invalid job ID",
 		})
 		return
 	}
 
+	job, err := h.submissionService.GetBulkModerationJob(c.Request.Context(), jobID)
+	if err != nil {
+		if errors.Is(err, services.ErrBulkSubmissionJobNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Bulk moderation job not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get bulk moderation job",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    job,
+	})
+}
+
+// parseBulkSubmissionIDs validates and parses the IDs of a bulk request,
+// writing the error response when they're invalid
+func parseBulkSubmissionIDs(c *gin.Context, rawIDs []string) ([]uuid.UUID, bool) {
+	if len(rawIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "At least one submission ID is required",
+		})
+		return nil, false
+	}
+	if len(rawIDs) > services.BulkSubmissionMaxItems {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("At most %d submission IDs can be moderated at once", services.BulkSubmissionMaxItems),
+		})
+		return nil, false
+	}
+
 	// Parse UUIDs
-	submissionIDs := make([]uuid.UUID, 0, len(req.SubmissionIDs))
-	for _, idStr := range req.SubmissionIDs {
+	submissionIDs := make([]uuid.UUID, 0, len(rawIDs))
+	for _, idStr := range rawIDs {
 		id, err := uuid.Parse(idStr)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error": "Invalid submission ID: " + idStr,
 			})
-			return
+			return nil, false
 		}
 		submissionIDs = append(submissionIDs, id)
 	}
+	return submissionIDs, true
+}
+
+// runBulkModeration moderates the submissions inline, or starts a background
+// job when there are more than the async threshold and jobs are available
+func (h *SubmissionHandler) runBulkModeration(c *gin.Context, action string, submissionIDs []uuid.UUID, reviewerID uuid.UUID, reason string) {
+	ctx := c.Request.Context()
+
+	if len(submissionIDs) > services.BulkSubmissionAsyncThreshold && h.submissionService.BulkJobsAvailable() {
+		job, err := h.submissionService.StartBulkModerationJob(ctx, action, submissionIDs, reviewerID, reason)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to start bulk moderation job",
+			})
+			return
+		}
+
+		c.JSON(http.StatusAccepted, gin.H{
+			"success":    true,
+			"message":    "Bulk moderation job started",
+			"data":       job,
+			"status_url": "/api/v1/admin/submissions/bulk-jobs/" + job.ID.String(),
+		})
+		return
+	}
 
-	if err := h.submissionService.BulkRejectSubmissions(c.Request.Context(), submissionIDs, reviewerID, req.Reason); err != nil {
+	var result *models.BulkSubmissionResult
+	var err error
+	if action == models.BulkSubmissionActionApprove {
+		result, err = h.submissionService.BulkApproveSubmissions(ctx, submissionIDs, reviewerID)
+	} else {
+		result, err = h.submissionService.BulkRejectSubmissions(ctx, submissionIDs, reviewerID, reason)
+	}
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to bulk reject submissions: " + err.Error(),
+			"error": "Failed to bulk " + action + " submissions",
 		})
 		return
 	}
 
+	message := "Submissions approved"
+	if action == models.BulkSubmissionActionReject {
+		message = "Submissions rejected"
+	}
+	if result.Failed > 0 {
+		message = fmt.Sprintf("%d of %d submissions failed", result.Failed, result.Total)
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"message": "Submissions rejected",
-		"count":   len(submissionIDs),
+		"success": result.Failed == 0,
+		"message": message,
+		"count":   result.Succeeded,
+		"data":    result,
 	})
 }
 
@@ -9,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/subculture-collective/clipper/internal/models"
 	"github.com/subculture-collective/clipper/internal/services"
+	"github.com/subculture-collective/clipper/pkg/metrics"
 )
 
 // SubscriptionHandler handles subscription-related HTTP requests
@@ -160,6 +161,8 @@ func (h *SubscriptionHandler) GetSubscription(c *gin.Context) {
 // @Failure 500 {object} map[string]string
 // @Router /api/v1/webhooks/stripe [post]
 func (h *SubscriptionHandler) HandleWebhook(c *gin.Context) {
+	defer func() { metrics.RecordWebhookReceived("stripe", c.Writer.Status()) }()
+
 	// Read the request body
 	payload, err := io.ReadAll(c.Request.Body)
 	if err != nil {
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/services"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+// SyndicationHandler serves RSS and Atom feeds of clips
+type SyndicationHandler struct {
+	syndicationService *services.SyndicationService
+}
+
+// NewSyndicationHandler creates a new syndication handler
+func NewSyndicationHandler(syndicationService *services.SyndicationService) *SyndicationHandler {
+	return &SyndicationHandler{syndicationService: syndicationService}
+}
+
+// GetFeedRSS returns a public custom feed's clips as RSS, or Atom with ?format=atom
+// GET /api/v1/feeds/:id/rss
+func (h *SyndicationHandler) GetFeedRSS(c *gin.Context) {
+	feedID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid feed ID"})
+		return
+	}
+
+	doc, err := h.syndicationService.FeedDocument(c.Request.Context(), feedID, syndicationFormat(c))
+	h.respond(c, doc, err)
+}
+
+// GetBroadcasterRSS returns a broadcaster's newest clips as RSS, or Atom with ?format=atom
+// GET /api/v1/broadcasters/:id/clips.rss
+func (h *SyndicationHandler) GetBroadcasterRSS(c *gin.Context) {
+	doc, err := h.syndicationService.BroadcasterDocument(c.Request.Context(), c.Param("id"), syndicationFormat(c))
+	h.respond(c, doc, err)
+}
+
+// GetTagRSS returns the clips most recently given a tag as RSS, or Atom with ?format=atom
+// GET /api/v1/tags/:slug/clips.rss
+func (h *SyndicationHandler) GetTagRSS(c *gin.Context) {
+	doc, err := h.syndicationService.TagDocument(c.Request.Context(), c.Param("slug"), syndicationFormat(c))
+	h.respond(c, doc, err)
+}
+
+// respond writes a syndication feed, honoring If-None-Match and answering
+// 304 when the reader's copy is current
+func (h *SyndicationHandler) respond(c *gin.Context, doc *services.SyndicationDocument, err error) {
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrSyndicationFormat):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrSyndicationNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Feed not found"})
+		default:
+			utils.GetLogger().Error("Failed to render syndication feed", err, map[string]interface{}{
+				"path": c.Request.URL.Path,
+			})
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render feed"})
+		}
+		return
+	}
+
+	c.Header("ETag", doc.ETag)
+	c.Header("Last-Modified", doc.Updated.UTC().Format(http.TimeFormat))
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", services.SyndicationMaxAge))
+
+	if etagMatches(c.GetHeader("If-None-Match"), doc.ETag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, doc.ContentType, doc.Body)
+}
+
+// syndicationFormat reads the requested feed format, RSS unless asked otherwise
+func syndicationFormat(c *gin.Context) string {
+	return strings.ToLower(strings.TrimSpace(c.DefaultQuery("format", services.SyndicationFormatRSS)))
+}
@@ -17,6 +17,7 @@ type TagHandler struct {
 	tagRepo        *repository.TagRepository
 	clipRepo       *repository.ClipRepository
 	autoTagService *services.AutoTagService
+	tagGraph       *services.TagGraphService
 }
 
 // NewTagHandler creates a new TagHandler
@@ -32,6 +33,11 @@ func NewTagHandler(
 	}
 }
 
+// SetTagGraphService enables GET /tags/:slug/related
+func (h *TagHandler) SetTagGraphService(tagGraph *services.TagGraphService) {
+	h.tagGraph = tagGraph
+}
+
 // ListTags handles GET /tags
 func (h *TagHandler) ListTags(c *gin.Context) {
 	// Parse query parameters
@@ -102,6 +108,42 @@ func (h *TagHandler) GetTag(c *gin.Context) {
 	})
 }
 
+// GetRelatedTags handles GET /tags/:slug/related
+func (h *TagHandler) GetRelatedTags(c *gin.Context) {
+	if h.tagGraph == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Related tags unavailable",
+		})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 25 {
+		limit = 10
+	}
+
+	tag, err := h.tagRepo.GetBySlug(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Tag not found",
+		})
+		return
+	}
+
+	related, err := h.tagGraph.ListRelatedTags(c.Request.Context(), tag.ID, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch related tags",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tag":     tag,
+		"related": related,
+	})
+}
+
 // GetClipsByTag handles GET /tags/:slug/clips
 func (h *TagHandler) GetClipsByTag(c *gin.Context) {
 	slug := c.Param("slug")
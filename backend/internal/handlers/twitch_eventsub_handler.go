@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/subculture-collective/clipper/internal/services"
+	"github.com/subculture-collective/clipper/pkg/metrics"
+	"github.com/subculture-collective/clipper/pkg/twitch"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const (
+	// eventSubMaxBodyBytes caps webhook bodies; EventSub messages are small
+	eventSubMaxBodyBytes = 1 << 20
+	// eventSubProcessTimeout bounds the background work for one notification
+	eventSubProcessTimeout = 2 * time.Minute
+)
+
+// TwitchEventSubHandler receives Twitch EventSub webhook messages
+type TwitchEventSubHandler struct {
+	eventSubService *services.TwitchEventSubService
+	logger          *utils.StructuredLogger
+}
+
+// NewTwitchEventSubHandler creates a new Twitch EventSub handler
+func NewTwitchEventSubHandler(eventSubService *services.TwitchEventSubService) *TwitchEventSubHandler {
+	return &TwitchEventSubHandler{
+		eventSubService: eventSubService,
+		logger:          utils.GetLogger(),
+	}
+}
+
+// HandleWebhook processes Twitch EventSub webhook messages
+// @Summary Handle Twitch EventSub webhook messages
+// @Description Answers verification challenges and applies stream and clip events
+// @Tags webhooks
+// @Accept json
+// @Param Twitch-Eventsub-Message-Id header string true "Message ID"
+// @Param Twitch-Eventsub-Message-Timestamp header string true "Message timestamp"
+// @Param Twitch-Eventsub-Message-Signature header string true "HMAC-SHA256 signature"
+// @Param Twitch-Eventsub-Message-Type header string true "Message type"
+// @Success 200 {string} string "Verification challenge"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /api/v1/webhooks/twitch/eventsub [post]
+func (h *TwitchEventSubHandler) HandleWebhook(c *gin.Context) {
+	defer func() { metrics.RecordWebhookReceived("twitch_eventsub", c.Writer.Status()) }()
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, eventSubMaxBodyBytes))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read request body"})
+		return
+	}
+
+	messageID := c.GetHeader(twitch.EventSubHeaderMessageID)
+	timestamp := c.GetHeader(twitch.EventSubHeaderMessageTimestamp)
+	signature := c.GetHeader(twitch.EventSubHeaderMessageSignature)
+	if messageID == "" || timestamp == "" || signature == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing EventSub headers"})
+		return
+	}
+
+	if err := h.eventSubService.VerifyMessage(messageID, timestamp, body, signature); err != nil {
+		h.logger.Warn("Rejected EventSub message", map[string]interface{}{
+			"message_id": messageID,
+			"error":      err.Error(),
+		})
+		status := http.StatusForbidden
+		if errors.Is(err, services.ErrEventSubStaleMessage) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": "Invalid EventSub message"})
+		return
+	}
+
+	var msg twitch.EventSubMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid message format"})
+		return
+	}
+
+	switch c.GetHeader(twitch.EventSubHeaderMessageType) {
+	case twitch.EventSubMessageVerification:
+		// Twitch expects the raw challenge back as plain text
+		c.Data(http.StatusOK, "text/plain", []byte(msg.Challenge))
+
+	case twitch.EventSubMessageNotification:
+		// Twitch redelivers messages that aren't acknowledged quickly, so
+		// acknowledge first and do the work in the background
+		if !h.eventSubService.SeenMessage(c.Request.Context(), messageID) {
+			go func() {
+				ctx, cancel := context.WithTimeout(context.Background(), eventSubProcessTimeout)
+				defer cancel()
+				if err := h.eventSubService.HandleNotification(ctx, &msg); err != nil {
+					h.logger.Error("Failed to handle EventSub notification", err, map[string]interface{}{
+						"message_id": messageID,
+						"type":       msg.Subscription.Type,
+					})
+				}
+			}()
+		}
+		c.Status(http.StatusNoContent)
+
+	case twitch.EventSubMessageRevocation:
+		h.eventSubService.HandleRevocation(&msg)
+		c.Status(http.StatusNoContent)
+
+	default:
+		c.Status(http.StatusNoContent)
+	}
+}
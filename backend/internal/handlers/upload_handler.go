@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// UploadHandler handles presigned file uploads
+type UploadHandler struct {
+	uploadService *services.UploadService
+}
+
+// NewUploadHandler creates a new upload handler
+func NewUploadHandler(uploadService *services.UploadService) *UploadHandler {
+	return &UploadHandler{
+		uploadService: uploadService,
+	}
+}
+
+// CreateUpload returns a presigned URL to upload a file to
+// POST /api/v1/uploads
+func (h *UploadHandler) CreateUpload(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+
+	var req models.CreateUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ticket, err := h.uploadService.CreateUpload(c.Request.Context(), userID, &req)
+	if err != nil {
+		respondUploadError(c, err, "Failed to create upload")
+		return
+	}
+
+	c.JSON(http.StatusCreated, ticket)
+}
+
+// CompleteUpload checks an uploaded file and makes it usable
+// POST /api/v1/uploads/:id/complete
+func (h *UploadHandler) CompleteUpload(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+	uploadID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid upload ID"})
+		return
+	}
+
+	upload, err := h.uploadService.CompleteUpload(c.Request.Context(), userID, uploadID)
+	if err != nil {
+		if errors.Is(err, services.ErrUploadRejected) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error(), "upload": upload})
+			return
+		}
+		respondUploadError(c, err, "Failed to complete upload")
+		return
+	}
+
+	c.JSON(http.StatusOK, upload)
+}
+
+// GetUpload returns one of the user's uploads
+// GET /api/v1/uploads/:id
+func (h *UploadHandler) GetUpload(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+	uploadID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid upload ID"})
+		return
+	}
+
+	upload, err := h.uploadService.GetUpload(c.Request.Context(), userID, uploadID)
+	if err != nil {
+		respondUploadError(c, err, "Failed to retrieve upload")
+		return
+	}
+
+	c.JSON(http.StatusOK, upload)
+}
+
+func (h *UploadHandler) userID(c *gin.Context) (uuid.UUID, bool) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return uuid.Nil, false
+	}
+	userID, ok := userIDVal.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// respondUploadError maps upload errors to responses. Handlers attaching
+// uploads to their own resources share it.
+func respondUploadError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, repository.ErrUploadNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Upload not found"})
+	case errors.Is(err, services.ErrUploadInvalid):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrUploadRejected):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrUploadNotUploaded),
+		errors.Is(err, services.ErrUploadNotReady),
+		errors.Is(err, services.ErrUploadInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
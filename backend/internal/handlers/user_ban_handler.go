@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// UserBanHandler shows users their own site-wide ban status
+type UserBanHandler struct {
+	banService *services.UserBanService
+}
+
+// NewUserBanHandler creates a new user ban handler
+func NewUserBanHandler(banService *services.UserBanService) *UserBanHandler {
+	return &UserBanHandler{banService: banService}
+}
+
+// GetMyBanStatus handles GET /api/v1/users/me/ban
+func (h *UserBanHandler) GetMyBanStatus(c *gin.Context) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Unauthorized",
+		})
+		return
+	}
+
+	userID, ok := userIDVal.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Invalid user ID",
+		})
+		return
+	}
+
+	status, err := h.banService.GetBanStatus(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get ban status",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
@@ -4,6 +4,7 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -20,6 +21,7 @@ type UserHandler struct {
 	userRepo            *repository.UserRepository
 	broadcasterRepo     *repository.BroadcasterRepository
 	accountMergeService *services.AccountMergeService
+	shadowBans          services.ShadowBanChecker
 }
 
 // NewUserHandler creates a new user handler
@@ -41,6 +43,24 @@ func NewUserHandler(
 	}
 }
 
+// SetShadowBanChecker hides shadow-banned users' comments on their profile
+// from everyone but themselves
+func (h *UserHandler) SetShadowBanChecker(shadowBans services.ShadowBanChecker) {
+	h.shadowBans = shadowBans
+}
+
+// hiddenByShadowBan reports whether a user's comments and activity are hidden
+// from the requesting viewer because the user is shadow banned
+func (h *UserHandler) hiddenByShadowBan(c *gin.Context, userID uuid.UUID) bool {
+	if h.shadowBans == nil {
+		return false
+	}
+	if viewerID, ok := c.Get("user_id"); ok && viewerID == userID {
+		return false
+	}
+	return h.shadowBans.IsShadowBanned(c.Request.Context(), userID)
+}
+
 // GetUserByUsername retrieves a user's public profile by username
 // GET /api/v1/users/by-username/:username
 func (h *UserHandler) GetUserByUsername(c *gin.Context) {
@@ -154,6 +174,71 @@ func (h *UserHandler) SearchUsersAutocomplete(c *gin.Context) {
 	})
 }
 
+// GetMentionSuggestions suggests users for @mention autocomplete in comments,
+// leaving out users with a block between them and the caller and listing
+// commenters on clip_id first
+// GET /api/v1/users/mention-suggestions?q=username_prefix&clip_id=
+func (h *UserHandler) GetMentionSuggestions(c *gin.Context) {
+	query := strings.TrimPrefix(c.Query("q"), "@")
+	if query == "" {
+		c.JSON(http.StatusOK, gin.H{
+			"success": true,
+			"data":    []gin.H{},
+		})
+		return
+	}
+
+	if len(query) > 50 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "query parameter must be 50 characters or less",
+		})
+		return
+	}
+
+	var clipID *uuid.UUID
+	if value := c.Query("clip_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "invalid clip ID"})
+			return
+		}
+		clipID = &id
+	}
+
+	var viewerID *uuid.UUID
+	if value, exists := c.Get("user_id"); exists {
+		if id, ok := value.(uuid.UUID); ok {
+			viewerID = &id
+		}
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	users, err := h.userRepo.SearchMentionSuggestions(c.Request.Context(), query, viewerID, clipID, limit)
+	if err != nil {
+		log.Printf("Failed to search mention suggestions: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to search users"})
+		return
+	}
+
+	suggestions := make([]gin.H, 0, len(users))
+	for _, user := range users {
+		suggestions = append(suggestions, gin.H{
+			"id":           user.ID,
+			"username":     user.Username,
+			"display_name": user.DisplayName,
+			"avatar_url":   user.AvatarURL,
+			"is_verified":  user.IsVerified,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    suggestions,
+	})
+}
+
 // GetUserComments retrieves comments by a user
 // GET /api/v1/users/:id/comments
 func (h *UserHandler) GetUserComments(c *gin.Context) {
@@ -175,11 +260,16 @@ func (h *UserHandler) GetUserComments(c *gin.Context) {
 	}
 	offset := (page - 1) * limit
 
-	// Get user comments
-	comments, total, err := h.commentRepo.ListByUserID(c.Request.Context(), userID, limit, offset)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve comments"})
-		return
+	// Get user comments. A shadow-banned user's comments are listed only for
+	// the user themselves.
+	var comments []repository.CommentWithAuthor
+	var total int
+	if !h.hiddenByShadowBan(c, userID) {
+		comments, total, err = h.commentRepo.ListByUserID(c.Request.Context(), userID, limit, offset)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve comments"})
+			return
+		}
 	}
 
 	// Transform to response format
@@ -436,6 +526,7 @@ func (h *UserHandler) GetUserClips(c *gin.Context) {
 	filters := repository.ClipFilters{
 		SubmittedByUserID: &userIDStr,
 		ShowHidden:        currentUserID != nil, // Show hidden clips if authenticated
+		ViewerID:          currentUserID,
 	}
 
 	clips, total, err := h.clipRepo.ListWithFilters(c.Request.Context(), filters, limit, offset)
@@ -480,10 +571,15 @@ func (h *UserHandler) GetUserActivity(c *gin.Context) {
 	}
 	offset := (page - 1) * limit
 
-	activities, total, err := h.userRepo.GetUserActivity(c.Request.Context(), userID, limit, offset)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve user activity"})
-		return
+	// A shadow-banned user's activity is listed only for the user themselves
+	activities := []models.UserActivityItem{}
+	var total int
+	if !h.hiddenByShadowBan(c, userID) {
+		activities, total, err = h.userRepo.GetUserActivity(c.Request.Context(), userID, limit, offset)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve user activity"})
+			return
+		}
 	}
 
 	totalPages := (total + limit - 1) / limit
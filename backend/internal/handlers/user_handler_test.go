@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -85,3 +86,35 @@ func TestGetUserClips_MultipleInvalidUserIDs(t *testing.T) {
 		})
 	}
 }
+
+// TestGetMentionSuggestions_RejectedBeforeSearch tests requests answered without searching
+func TestGetMentionSuggestions_RejectedBeforeSearch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+	}{
+		{"empty query", "", http.StatusOK},
+		{"bare at sign", "?q=@", http.StatusOK},
+		{"invalid clip ID", "?q=al&clip_id=not-a-uuid", http.StatusBadRequest},
+		{"query too long", "?q=" + strings.Repeat("a", 51), http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &UserHandler{} // nil repos: the search is never reached
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/users/mention-suggestions"+tt.query, http.NoBody)
+
+			handler.GetMentionSuggestions(c)
+
+			if w.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, w.Code)
+			}
+		})
+	}
+}
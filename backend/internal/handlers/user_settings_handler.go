@@ -65,6 +65,45 @@ func (h *UserSettingsHandler) UpdateProfile(c *gin.Context) {
 	})
 }
 
+// UpdateAvatar handles PUT /api/v1/users/me/avatar
+func (h *UserSettingsHandler) UpdateAvatar(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Unauthorized",
+		})
+		return
+	}
+
+	userID, ok := userIDVal.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Invalid user ID",
+		})
+		return
+	}
+
+	var req models.AttachUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	upload, err := h.userSettingsService.UpdateAvatar(c.Request.Context(), userID, req.UploadID)
+	if err != nil {
+		respondUploadError(c, err, "Failed to update avatar")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Avatar updated successfully",
+		"avatar_url": upload.URL,
+	})
+}
+
 // GetSettings handles GET /api/v1/users/me/settings
 func (h *UserSettingsHandler) GetSettings(c *gin.Context) {
 	// Get user ID from context
@@ -127,7 +166,7 @@ func (h *UserSettingsHandler) UpdateSettings(c *gin.Context) {
 	}
 
 	// Update settings
-	err := h.userSettingsService.UpdateSettings(c.Request.Context(), userID, req.ProfileVisibility, req.ShowKarmaPublicly)
+	err := h.userSettingsService.UpdateSettings(c.Request.Context(), userID, req.ProfileVisibility, req.ShowKarmaPublicly, req.PersonalizedSearch, req.ShowProfileViews, req.ShareActivity)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// voteRingStatuses are the status filters accepted when listing vote rings
+var voteRingStatuses = map[string]bool{
+	"":                       true,
+	models.VoteRingPending:   true,
+	models.VoteRingConfirmed: true,
+	models.VoteRingDismissed: true,
+}
+
+// VoteRingHandler serves the admin review of vote rings, clusters of
+// accounts that upvote each other's content
+type VoteRingHandler struct {
+	karmaSafeguardService *services.KarmaSafeguardService
+}
+
+// NewVoteRingHandler creates a new vote ring handler
+func NewVoteRingHandler(karmaSafeguardService *services.KarmaSafeguardService) *VoteRingHandler {
+	return &VoteRingHandler{karmaSafeguardService: karmaSafeguardService}
+}
+
+// ListVoteRings handles GET /api/v1/admin/vote-rings
+// Query: status (pending, confirmed, dismissed; default pending), user_id, limit, offset
+func (h *VoteRingHandler) ListVoteRings(c *gin.Context) {
+	status := c.DefaultQuery("status", models.VoteRingPending)
+	if status == "all" {
+		status = ""
+	}
+	if !voteRingStatuses[status] {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid status. Must be pending, confirmed, dismissed or all",
+		})
+		return
+	}
+
+	filters := models.VoteRingFilters{Status: status}
+	if userIDStr := c.Query("user_id"); userIDStr != "" {
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid user_id",
+			})
+			return
+		}
+		filters.UserID = &userID
+	}
+	filters.Limit, _ = strconv.Atoi(c.DefaultQuery("limit", "50"))
+	filters.Offset, _ = strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	rings, err := h.karmaSafeguardService.ListVoteRings(c.Request.Context(), filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve vote rings",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    rings,
+		"count":   len(rings),
+	})
+}
+
+// GetVoteRing handles GET /api/v1/admin/vote-rings/:id
+func (h *VoteRingHandler) GetVoteRing(c *gin.Context) {
+	ringID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid vote ring ID",
+		})
+		return
+	}
+
+	ring, err := h.karmaSafeguardService.GetVoteRing(c.Request.Context(), ringID)
+	if err != nil {
+		if errors.Is(err, repository.ErrVoteRingNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Vote ring not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve vote ring",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    ring,
+	})
+}
+
+// ConfirmVoteRing handles POST /api/v1/admin/vote-rings/:id/confirm
+// Confirms the ring and reverses the karma its members' votes on each other awarded
+func (h *VoteRingHandler) ConfirmVoteRing(c *gin.Context) {
+	h.reviewVoteRing(c, h.karmaSafeguardService.ConfirmVoteRing)
+}
+
+// DismissVoteRing handles POST /api/v1/admin/vote-rings/:id/dismiss
+func (h *VoteRingHandler) DismissVoteRing(c *gin.Context) {
+	h.reviewVoteRing(c, h.karmaSafeguardService.DismissVoteRing)
+}
+
+// voteRingReviewFunc confirms or dismisses a vote ring
+type voteRingReviewFunc func(ctx context.Context, ringID, moderatorID uuid.UUID, notes *string) (*models.VoteRing, error)
+
+func (h *VoteRingHandler) reviewVoteRing(c *gin.Context, review voteRingReviewFunc) {
+	ringID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid vote ring ID",
+		})
+		return
+	}
+
+	moderatorIDVal, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Unauthorized",
+		})
+		return
+	}
+	moderatorID := moderatorIDVal.(uuid.UUID)
+
+	var req models.ReviewVoteRingRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	ring, err := review(c.Request.Context(), ringID, moderatorID, req.Notes)
+	if err != nil {
+		if errors.Is(err, repository.ErrVoteRingNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Vote ring not found or already reviewed",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to review vote ring",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    ring,
+	})
+}
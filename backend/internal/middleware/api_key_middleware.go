@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// APIKeyHeader is the header third-party tools send their personal API key in
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyAuthenticator defines the API key operations used by middleware
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, rawKey string) (*models.APIKey, *models.User, error)
+	AllowRequest(ctx context.Context, key *models.APIKey) (bool, int, time.Time)
+	RecordUsage(key *models.APIKey, status int)
+}
+
+// APIKeyMiddleware authenticates requests that carry a personal API key, enforcing the
+// required scope and the key's own rate limit. Requests without a key pass through so
+// AuthMiddleware can handle JWT authentication. Requests made with a key are counted
+// for the owner's usage report.
+func APIKeyMiddleware(apiKeys APIKeyAuthenticator, requiredScope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rawKey := c.GetHeader(APIKeyHeader)
+		if rawKey == "" || apiKeys == nil {
+			c.Next()
+			return
+		}
+
+		key, user, err := apiKeys.Authenticate(c.Request.Context(), rawKey)
+		if err != nil {
+			message := "Invalid or expired API key"
+			if errors.Is(err, services.ErrUserBanned) {
+				message = "Account is banned"
+			}
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"error": gin.H{
+					"code":    "UNAUTHORIZED",
+					"message": message,
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		if !key.HasScope(requiredScope) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"success": false,
+				"error": gin.H{
+					"code":    "INSUFFICIENT_SCOPE",
+					"message": fmt.Sprintf("API key is missing the %s scope", requiredScope),
+				},
+			})
+			c.Abort()
+			apiKeys.RecordUsage(key, http.StatusForbidden)
+			return
+		}
+
+		allowed, remaining, resetAt := apiKeys.AllowRequest(c.Request.Context(), key)
+		c.Header("X-RateLimit-Limit", fmt.Sprintf("%d", key.RateLimitPerMinute))
+		c.Header("X-RateLimit-Remaining", fmt.Sprintf("%d", remaining))
+		c.Header("X-RateLimit-Reset", fmt.Sprintf("%d", resetAt.Unix()))
+		if !allowed {
+			retryAfter := int(time.Until(resetAt).Seconds()) + 1
+			c.Header("Retry-After", fmt.Sprintf("%d", retryAfter))
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error":       "API key rate limit exceeded. Please try again later.",
+				"retry_after": retryAfter,
+			})
+			c.Abort()
+			apiKeys.RecordUsage(key, http.StatusTooManyRequests)
+			return
+		}
+
+		c.Set("user", user)
+		c.Set("user_id", user.ID)
+		c.Set("user_role", user.Role)
+		c.Set("api_key_id", key.ID)
+
+		c.Next()
+
+		apiKeys.RecordUsage(key, c.Writer.Status())
+	}
+}
@@ -0,0 +1,152 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// mockAPIKeyAuthenticator is a mock implementation of APIKeyAuthenticator for testing
+type mockAPIKeyAuthenticator struct {
+	key      *models.APIKey
+	user     *models.User
+	err      error
+	allowed  bool
+	recorded []int
+}
+
+func (m *mockAPIKeyAuthenticator) Authenticate(ctx context.Context, rawKey string) (*models.APIKey, *models.User, error) {
+	if m.err != nil {
+		return nil, nil, m.err
+	}
+	return m.key, m.user, nil
+}
+
+func (m *mockAPIKeyAuthenticator) AllowRequest(ctx context.Context, key *models.APIKey) (bool, int, time.Time) {
+	if !m.allowed {
+		return false, 0, time.Now().Add(30 * time.Second)
+	}
+	return true, key.RateLimitPerMinute - 1, time.Now().Add(30 * time.Second)
+}
+
+func (m *mockAPIKeyAuthenticator) RecordUsage(key *models.APIKey, status int) {
+	m.recorded = append(m.recorded, status)
+}
+
+func newAPIKeyTestRouter(apiKeys APIKeyAuthenticator) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/test", APIKeyMiddleware(apiKeys, models.APIKeyScopeReadClips), func(c *gin.Context) {
+		_, hasKey := c.Get("api_key_id")
+		c.JSON(http.StatusOK, gin.H{"api_key": hasKey})
+	})
+	return router
+}
+
+func newMockAPIKey(scopes ...string) *mockAPIKeyAuthenticator {
+	user := &models.User{ID: uuid.New(), Username: "integration", Role: models.RoleUser}
+	return &mockAPIKeyAuthenticator{
+		key: &models.APIKey{
+			ID:                 uuid.New(),
+			UserID:             user.ID,
+			Scopes:             scopes,
+			RateLimitPerMinute: 60,
+		},
+		user:    user,
+		allowed: true,
+	}
+}
+
+func TestAPIKeyMiddleware_NoKeyPassesThrough(t *testing.T) {
+	router := newAPIKeyTestRouter(newMockAPIKey())
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+	if w.Body.String() != `{"api_key":false}` {
+		t.Errorf("Expected request to continue unauthenticated, got %s", w.Body.String())
+	}
+}
+
+func TestAPIKeyMiddleware_ValidKey(t *testing.T) {
+	apiKeys := newMockAPIKey(models.APIKeyScopeReadClips)
+	router := newAPIKeyTestRouter(apiKeys)
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set(APIKeyHeader, "clpr_key")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+	if w.Header().Get("X-RateLimit-Limit") != "60" {
+		t.Errorf("Expected per-key rate limit header, got %q", w.Header().Get("X-RateLimit-Limit"))
+	}
+	if len(apiKeys.recorded) != 1 || apiKeys.recorded[0] != http.StatusOK {
+		t.Errorf("Expected request to be recorded with status 200, got %v", apiKeys.recorded)
+	}
+}
+
+func TestAPIKeyMiddleware_InvalidKey(t *testing.T) {
+	apiKeys := newMockAPIKey(models.APIKeyScopeReadClips)
+	apiKeys.err = services.ErrInvalidAPIKey
+	router := newAPIKeyTestRouter(apiKeys)
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set(APIKeyHeader, "clpr_bad")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401, got %d", w.Code)
+	}
+	if len(apiKeys.recorded) != 0 {
+		t.Errorf("Expected unknown keys not to be recorded, got %v", apiKeys.recorded)
+	}
+}
+
+func TestAPIKeyMiddleware_MissingScope(t *testing.T) {
+	router := newAPIKeyTestRouter(newMockAPIKey(models.APIKeyScopeManageWebhooks))
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set(APIKeyHeader, "clpr_key")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403, got %d", w.Code)
+	}
+}
+
+func TestAPIKeyMiddleware_RateLimited(t *testing.T) {
+	apiKeys := newMockAPIKey(models.APIKeyScopeReadClips)
+	apiKeys.allowed = false
+	router := newAPIKeyTestRouter(apiKeys)
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set(APIKeyHeader, "clpr_key")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected status 429, got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("Expected Retry-After header")
+	}
+	if len(apiKeys.recorded) != 1 || apiKeys.recorded[0] != http.StatusTooManyRequests {
+		t.Errorf("Expected rate-limited request to be recorded, got %v", apiKeys.recorded)
+	}
+}
@@ -13,6 +13,12 @@ import (
 // AuthMiddleware creates middleware that requires authentication
 func AuthMiddleware(authService *services.AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// Already authenticated by APIKeyMiddleware
+		if _, ok := c.Get("api_key_id"); ok {
+			c.Next()
+			return
+		}
+
 		token := extractToken(c)
 		if token == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{
@@ -27,7 +33,7 @@ func AuthMiddleware(authService *services.AuthService) gin.HandlerFunc {
 		}
 
 		// Get user from token
-		user, err := authService.GetUserFromToken(c.Request.Context(), token)
+		user, claims, err := authService.ValidateAccessToken(c.Request.Context(), token)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"success": false,
@@ -44,6 +50,7 @@ func AuthMiddleware(authService *services.AuthService) gin.HandlerFunc {
 		c.Set("user", user)
 		c.Set("user_id", user.ID)
 		c.Set("user_role", user.Role)
+		c.Set("mfa_verified", claims.MFAVerified)
 
 		// Set user context in Sentry for error tracking
 		sentrypkg.SetUser(c, user.ID.String(), user.Username)
@@ -57,11 +64,12 @@ func OptionalAuthMiddleware(authService *services.AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		token := extractToken(c)
 		if token != "" {
-			user, err := authService.GetUserFromToken(c.Request.Context(), token)
+			user, claims, err := authService.ValidateAccessToken(c.Request.Context(), token)
 			if err == nil {
 				c.Set("user", user)
 				c.Set("user_id", user.ID)
 				c.Set("user_role", user.Role)
+				c.Set("mfa_verified", claims.MFAVerified)
 
 				// Set user context in Sentry for error tracking
 				sentrypkg.SetUser(c, user.ID.String(), user.Username)
@@ -0,0 +1,310 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// compressionMinSize is the smallest response body worth compressing
+const compressionMinSize = 1024
+
+var (
+	httpResponseUncompressedSize = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "http_response_uncompressed_size_bytes",
+			Help:    "Size of HTTP response bodies before compression in bytes",
+			Buckets: prometheus.ExponentialBuckets(100, 10, 8),
+		},
+		[]string{"method", "path"},
+	)
+
+	httpResponseBytesSaved = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_response_bytes_saved_total",
+			Help: "Response bytes saved by compression and sparse fieldsets",
+		},
+		[]string{"method", "path", "reason"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(httpResponseUncompressedSize)
+	prometheus.MustRegister(httpResponseBytesSaved)
+}
+
+// compressionEncoding is the only content encoding the API produces. Brotli and
+// other encodings are deliberately out of scope; clients that only accept those
+// receive uncompressed responses.
+const compressionEncoding = "gzip"
+
+var gzipWriterPool = sync.Pool{
+	New: func() interface{} {
+		w, _ := gzip.NewWriterLevel(io.Discard, gzip.DefaultCompression)
+		return w
+	},
+}
+
+// pooledGzipWriter returns its gzip.Writer to the pool on Close
+type pooledGzipWriter struct {
+	*gzip.Writer
+}
+
+func newGzipWriter(w io.Writer) *pooledGzipWriter {
+	gz := gzipWriterPool.Get().(*gzip.Writer)
+	gz.Reset(w)
+	return &pooledGzipWriter{Writer: gz}
+}
+
+func (w *pooledGzipWriter) Close() error {
+	err := w.Writer.Close()
+	gzipWriterPool.Put(w.Writer)
+	return err
+}
+
+// CompressionMiddleware gzips JSON and text responses larger than
+// compressionMinSize when Accept-Encoding allows it.
+// Streaming responses (WebSockets, server-sent events) are passed through untouched.
+func CompressionMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodHead ||
+			c.GetHeader("Upgrade") != "" ||
+			strings.Contains(c.GetHeader("Accept"), "text/event-stream") {
+			c.Next()
+			return
+		}
+
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if encoding == "" {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		cw := &compressResponseWriter{
+			ResponseWriter: original,
+			encoding:       encoding,
+			status:         http.StatusOK,
+		}
+		c.Writer = cw
+		defer func() {
+			cw.finish()
+			c.Writer = original
+
+			path := c.FullPath()
+			if path == "" {
+				path = c.Request.URL.Path
+			}
+			if cw.uncompressed > 0 {
+				httpResponseUncompressedSize.WithLabelValues(c.Request.Method, path).Observe(float64(cw.uncompressed))
+			}
+			if cw.compressed && cw.uncompressed > original.Size() {
+				httpResponseBytesSaved.WithLabelValues(c.Request.Method, path, "compression").
+					Add(float64(cw.uncompressed - original.Size()))
+			}
+		}()
+
+		c.Next()
+	}
+}
+
+// negotiateEncoding returns compressionEncoding if an Accept-Encoding header
+// allows it, or "" if the response must be sent uncompressed
+func negotiateEncoding(header string) string {
+	if header == "" {
+		return ""
+	}
+
+	q, wildcard := -1.0, -1.0
+	for _, part := range strings.Split(header, ",") {
+		name, quality := parseEncodingQuality(part)
+		switch name {
+		case compressionEncoding:
+			q = quality
+		case "*":
+			wildcard = quality
+		}
+	}
+	if q < 0 {
+		q = wildcard
+	}
+	if q <= 0 {
+		return ""
+	}
+	return compressionEncoding
+}
+
+// parseEncodingQuality parses one "name;q=0.5" Accept-Encoding entry
+func parseEncodingQuality(part string) (string, float64) {
+	segments := strings.Split(part, ";")
+	name := strings.ToLower(strings.TrimSpace(segments[0]))
+	q := 1.0
+	for _, param := range segments[1:] {
+		key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+		if !found || strings.ToLower(strings.TrimSpace(key)) != "q" {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return "", 0
+		}
+		q = parsed
+	}
+	return name, q
+}
+
+// isCompressibleContentType reports whether a response of this type benefits from compression
+func isCompressibleContentType(contentType string) bool {
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	switch {
+	case mediaType == "":
+		return false
+	case mediaType == "text/event-stream":
+		return false
+	case strings.HasPrefix(mediaType, "text/"):
+		return true
+	case mediaType == "application/json",
+		mediaType == "application/javascript",
+		mediaType == "application/xml",
+		mediaType == "image/svg+xml",
+		strings.HasSuffix(mediaType, "+json"),
+		strings.HasSuffix(mediaType, "+xml"):
+		return true
+	}
+	return false
+}
+
+// compressResponseWriter buffers the start of a response until it knows
+// whether the body is large and compressible enough to encode
+type compressResponseWriter struct {
+	gin.ResponseWriter
+	encoding string
+
+	status        int
+	headerWritten bool
+	decided       bool
+	compressed    bool
+	buf           bytes.Buffer
+	writer        *pooledGzipWriter
+	uncompressed  int
+}
+
+func (w *compressResponseWriter) WriteHeader(code int) {
+	if w.decided || code <= 0 {
+		return
+	}
+	w.status = code
+}
+
+func (w *compressResponseWriter) WriteHeaderNow() {
+	w.headerWritten = true
+}
+
+func (w *compressResponseWriter) Status() int {
+	return w.status
+}
+
+func (w *compressResponseWriter) Written() bool {
+	return w.headerWritten || w.decided
+}
+
+func (w *compressResponseWriter) Size() int {
+	if !w.decided && !w.headerWritten {
+		return -1
+	}
+	return w.uncompressed
+}
+
+func (w *compressResponseWriter) Write(data []byte) (int, error) {
+	w.headerWritten = true
+	w.uncompressed += len(data)
+
+	if !w.decided {
+		w.buf.Write(data)
+		if w.buf.Len() < compressionMinSize {
+			return len(data), nil
+		}
+		if err := w.decide(); err != nil {
+			return 0, err
+		}
+		return len(data), nil
+	}
+
+	if w.compressed {
+		return w.writer.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *compressResponseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush commits to a decision so streamed responses are not held back
+func (w *compressResponseWriter) Flush() {
+	if !w.decided {
+		_ = w.decide()
+	}
+	if w.compressed {
+		_ = w.writer.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// decide sends the status line and any buffered bytes, compressing them if worthwhile
+func (w *compressResponseWriter) decide() error {
+	w.decided = true
+	header := w.ResponseWriter.Header()
+
+	w.compressed = w.buf.Len() >= compressionMinSize &&
+		header.Get("Content-Encoding") == "" &&
+		w.status != http.StatusNoContent &&
+		w.status != http.StatusNotModified &&
+		isCompressibleContentType(header.Get("Content-Type"))
+
+	if w.compressed {
+		header.Set("Content-Encoding", w.encoding)
+		header.Del("Content-Length")
+		header.Del("Accept-Ranges")
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.WriteHeaderNow()
+
+	if w.buf.Len() == 0 {
+		return nil
+	}
+
+	var err error
+	if w.compressed {
+		w.writer = newGzipWriter(w.ResponseWriter)
+		_, err = w.writer.Write(w.buf.Bytes())
+	} else {
+		_, err = w.ResponseWriter.Write(w.buf.Bytes())
+	}
+	w.buf.Reset()
+	return err
+}
+
+// finish flushes anything still buffered and closes the encoder
+func (w *compressResponseWriter) finish() {
+	if !w.decided {
+		// Nothing was written; let gin send its default status itself
+		if !w.headerWritten && w.status == http.StatusOK {
+			return
+		}
+		_ = w.decide()
+	}
+	if w.compressed && w.writer != nil {
+		_ = w.writer.Close()
+	}
+}
@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newCompressionTestRouter(body string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(CompressionMiddleware(), SparseFieldsetMiddleware())
+	r.GET("/json", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json; charset=utf-8", []byte(body))
+	})
+	r.GET("/binary", func(c *gin.Context) {
+		c.Data(http.StatusOK, "image/png", []byte(body))
+	})
+	return r
+}
+
+func TestCompressionMiddleware_GzipsLargeJSON(t *testing.T) {
+	body := `{"data":"` + strings.Repeat("clip", 1000) + `"}`
+	r := newCompressionTestRouter(body)
+
+	req := httptest.NewRequest(http.MethodGet, "/json", nil)
+	req.Header.Set("Accept-Encoding", "br;q=0.5, gzip")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("Expected gzip encoding, got %q", got)
+	}
+	if got := w.Header().Get("Vary"); got != "Accept-Encoding" {
+		t.Errorf("Expected Vary: Accept-Encoding, got %q", got)
+	}
+	if w.Body.Len() >= len(body) {
+		t.Errorf("Expected compressed body smaller than %d bytes, got %d", len(body), w.Body.Len())
+	}
+
+	reader, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatalf("Failed to open gzip body: %v", err)
+	}
+	decoded, _ := io.ReadAll(reader)
+	if string(decoded) != body {
+		t.Error("Decompressed body does not match original")
+	}
+}
+
+func TestCompressionMiddleware_SkipsSmallAndBinaryResponses(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		body           string
+		acceptEncoding string
+	}{
+		{"small body", "/json", `{"ok":true}`, "gzip"},
+		{"binary body", "/binary", strings.Repeat("x", 4096), "gzip"},
+		{"no accept-encoding", "/json", strings.Repeat("x", 4096), ""},
+		{"gzip refused", "/json", strings.Repeat("x", 4096), "gzip;q=0, identity"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newCompressionTestRouter(tt.body)
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if got := w.Header().Get("Content-Encoding"); got != "" {
+				t.Errorf("Expected no content encoding, got %q", got)
+			}
+			if w.Body.String() != tt.body {
+				t.Error("Expected body to pass through unchanged")
+			}
+		})
+	}
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected string
+	}{
+		{"gzip, deflate", "gzip"},
+		{"*", "gzip"},
+		{"*;q=0", ""},
+		{"deflate", ""},
+		{"GZIP;q=0.8", "gzip"},
+		{"", ""},
+		{"br", ""},
+		{"br, gzip;q=0", ""},
+		{"br, *;q=0.1", "gzip"},
+		{"gzip;q=0, *", ""},
+	}
+
+	for _, tt := range tests {
+		if got := negotiateEncoding(tt.header); got != tt.expected {
+			t.Errorf("negotiateEncoding(%q) = %q, want %q", tt.header, got, tt.expected)
+		}
+	}
+}
+
+func TestSparseFieldsetMiddleware(t *testing.T) {
+	body := `{"success":true,"data":[{"id":"1","title":"A","embed_url":"x","creator":{"name":"c","bio":"b"}}],"meta":{"total":1}}`
+	r := newCompressionTestRouter(body)
+
+	req := httptest.NewRequest(http.MethodGet, "/json?fields=title,creator.name", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var resp struct {
+		Success bool                     `json:"success"`
+		Data    []map[string]interface{} `json:"data"`
+		Meta    map[string]interface{}   `json:"meta"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if !resp.Success || resp.Meta["total"] == nil {
+		t.Error("Expected envelope fields outside data to be preserved")
+	}
+	if len(resp.Data) != 1 {
+		t.Fatalf("Expected 1 item, got %d", len(resp.Data))
+	}
+	item := resp.Data[0]
+	if item["id"] != "1" || item["title"] != "A" {
+		t.Errorf("Expected id and title to be kept, got %v", item)
+	}
+	if _, ok := item["embed_url"]; ok {
+		t.Error("Expected embed_url to be trimmed")
+	}
+	creator, _ := item["creator"].(map[string]interface{})
+	if creator["name"] != "c" || creator["bio"] != nil {
+		t.Errorf("Expected only creator.name, got %v", creator)
+	}
+}
@@ -15,6 +15,12 @@ func CORSMiddleware(cfg *config.Config) gin.HandlerFunc {
 		originsMap[strings.TrimSpace(origin)] = true
 	}
 
+	allowedHeaders := "Content-Type, Content-Length, Accept-Encoding, Authorization, accept, origin, Cache-Control, X-Requested-With, X-CSRF-Token"
+	// Browsers send the device fingerprint used for ban evasion detection at sign-in
+	if header := cfg.BanEvasion.FingerprintHeader; header != "" {
+		allowedHeaders += ", " + header
+	}
+
 	return func(c *gin.Context) {
 		origin := c.Request.Header.Get("Origin")
 
@@ -22,7 +28,7 @@ func CORSMiddleware(cfg *config.Config) gin.HandlerFunc {
 		if originsMap[origin] {
 			c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
 			c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-			c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, Authorization, accept, origin, Cache-Control, X-Requested-With, X-CSRF-Token")
+			c.Writer.Header().Set("Access-Control-Allow-Headers", allowedHeaders)
 			c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH")
 			c.Writer.Header().Set("Access-Control-Expose-Headers", "X-CSRF-Token")
 		}
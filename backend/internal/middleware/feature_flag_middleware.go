@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// RequireFeatureFlag hides a route behind a feature flag. Requests the flag
+// is off for get a 404, as if the route didn't exist; a flag that hasn't been
+// created is treated as off. Place it after AuthMiddleware or
+// OptionalAuthMiddleware so user targeting rules apply.
+func RequireFeatureFlag(flags FeatureFlagChecker, key string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var user *models.User
+		if userVal, exists := c.Get("user"); exists {
+			user, _ = userVal.(*models.User)
+		}
+
+		if !flags.Enabled(c.Request.Context(), key, models.NewFeatureFlagSubject(user), false) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,204 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxFieldsetFields caps how many paths a single fields= parameter may select
+const maxFieldsetFields = 50
+
+// fieldTree is a parsed fields= selection; a node with no children keeps its whole value
+type fieldTree map[string]fieldTree
+
+// SparseFieldsetMiddleware trims successful JSON responses down to the fields
+// listed in the "fields" query parameter (e.g. ?fields=title,creator_name,submitted_by.username).
+// For StandardResponse envelopes only "data" is filtered so success/meta/error survive.
+// The "id" field of every selected object is always kept.
+func SparseFieldsetMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		fields := parseFieldset(c.Query("fields"))
+		if fields == nil {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		bw := &bufferedResponseWriter{ResponseWriter: original, status: http.StatusOK}
+		c.Writer = bw
+		defer func() {
+			c.Writer = original
+
+			body := bw.buf.Bytes()
+			status := bw.status
+			if status >= 200 && status < 300 &&
+				strings.Contains(original.Header().Get("Content-Type"), "application/json") {
+				if trimmed, ok := applyFieldset(body, fields); ok {
+					path := c.FullPath()
+					if path == "" {
+						path = c.Request.URL.Path
+					}
+					if saved := len(body) - len(trimmed); saved > 0 {
+						httpResponseBytesSaved.WithLabelValues(c.Request.Method, path, "fieldset").Add(float64(saved))
+					}
+					body = trimmed
+				}
+			}
+
+			if !bw.written && len(body) == 0 && status == http.StatusOK {
+				return
+			}
+			original.Header().Del("Content-Length")
+			original.WriteHeader(status)
+			original.WriteHeaderNow()
+			if len(body) > 0 {
+				_, _ = original.Write(body)
+			}
+		}()
+
+		c.Next()
+	}
+}
+
+// parseFieldset turns "a,b.c" into a field tree, or nil when no fields are requested
+func parseFieldset(raw string) fieldTree {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+
+	tree := fieldTree{}
+	count := 0
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		count++
+		if count > maxFieldsetFields {
+			break
+		}
+
+		node := tree
+		for _, segment := range strings.Split(field, ".") {
+			if segment == "" {
+				break
+			}
+			child, ok := node[segment]
+			if !ok {
+				child = fieldTree{}
+				node[segment] = child
+			}
+			node = child
+		}
+	}
+	if len(tree) == 0 {
+		return nil
+	}
+	return tree
+}
+
+// applyFieldset filters a JSON body; ok is false when the body is left untouched
+func applyFieldset(body []byte, fields fieldTree) ([]byte, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var payload interface{}
+	if err := decoder.Decode(&payload); err != nil {
+		return nil, false
+	}
+
+	if envelope, isObject := payload.(map[string]interface{}); isObject {
+		if data, hasData := envelope["data"]; hasData {
+			envelope["data"] = filterFields(data, fields)
+			payload = envelope
+		} else {
+			payload = filterFields(envelope, fields)
+		}
+	} else {
+		payload = filterFields(payload, fields)
+	}
+
+	trimmed, err := json.Marshal(payload)
+	if err != nil {
+		return nil, false
+	}
+	return trimmed, true
+}
+
+// filterFields keeps only the selected keys of objects, applying the selection to each array element
+func filterFields(value interface{}, fields fieldTree) interface{} {
+	if len(fields) == 0 {
+		return value
+	}
+
+	switch v := value.(type) {
+	case []interface{}:
+		for i, item := range v {
+			v[i] = filterFields(item, fields)
+		}
+		return v
+	case map[string]interface{}:
+		filtered := make(map[string]interface{}, len(fields)+1)
+		if id, ok := v["id"]; ok {
+			filtered["id"] = id
+		}
+		for key, children := range fields {
+			if item, ok := v[key]; ok {
+				filtered[key] = filterFields(item, children)
+			}
+		}
+		return filtered
+	default:
+		return value
+	}
+}
+
+// bufferedResponseWriter holds the whole response so it can be rewritten before sending
+type bufferedResponseWriter struct {
+	gin.ResponseWriter
+	status  int
+	written bool
+	buf     bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) WriteHeader(code int) {
+	if code > 0 {
+		w.status = code
+	}
+}
+
+func (w *bufferedResponseWriter) WriteHeaderNow() {
+	w.written = true
+}
+
+func (w *bufferedResponseWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedResponseWriter) Written() bool {
+	return w.written
+}
+
+func (w *bufferedResponseWriter) Size() int {
+	if !w.written {
+		return -1
+	}
+	return w.buf.Len()
+}
+
+func (w *bufferedResponseWriter) Write(data []byte) (int, error) {
+	w.written = true
+	return w.buf.Write(data)
+}
+
+func (w *bufferedResponseWriter) WriteString(s string) (int, error) {
+	w.written = true
+	return w.buf.WriteString(s)
+}
+
+// Flush is a no-op; the buffered body is sent once the handler returns
+func (w *bufferedResponseWriter) Flush() {}
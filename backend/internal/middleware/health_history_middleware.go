@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// HealthRequestRecorder counts served requests toward endpoint group uptime
+type HealthRequestRecorder interface {
+	RecordRequest(route string, status int)
+}
+
+// HealthHistoryMiddleware counts each request to a matched route, and whether
+// it failed with a server error, toward its endpoint group's uptime
+func HealthHistoryMiddleware(recorder HealthRequestRecorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if route := c.FullPath(); route != "" {
+			recorder.RecordRequest(route, c.Writer.Status())
+		}
+	}
+}
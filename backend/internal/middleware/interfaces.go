@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
 )
 
 // SubscriptionChecker defines the interface for subscription checking
@@ -16,3 +17,8 @@ type SubscriptionChecker interface {
 type AuditLogger interface {
 	LogEntitlementDenial(ctx context.Context, userID uuid.UUID, action string, metadata map[string]interface{}) error
 }
+
+// FeatureFlagChecker defines the interface for feature flag checks
+type FeatureFlagChecker interface {
+	Enabled(ctx context.Context, key string, subject *models.FeatureFlagSubject, fallback bool) bool
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+const (
+	// maintenanceRetryAfterSeconds tells clients when to retry a rejected write
+	maintenanceRetryAfterSeconds = "300"
+	defaultMaintenanceMessage    = "Clipper is in read-only maintenance mode. Please try again later."
+)
+
+// maintenanceExemptPrefixes stay writable in maintenance mode, so staff can
+// sign in, work the incident and switch the mode off
+var maintenanceExemptPrefixes = []string{
+	"/api/v1/admin",
+	"/api/v1/auth",
+}
+
+// MaintenanceModeChecker reports the API's maintenance mode
+type MaintenanceModeChecker interface {
+	Status(ctx context.Context) models.MaintenanceStatus
+}
+
+// MaintenanceModeMiddleware rejects mutating requests with a 503 while the
+// API is in read-only maintenance mode. Reads, admin and auth endpoints pass.
+func MaintenanceModeMiddleware(mode MaintenanceModeChecker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+		if isMaintenanceExempt(c.Request.URL.Path) {
+			c.Next()
+			return
+		}
+
+		status := mode.Status(c.Request.Context())
+		if !status.ReadOnly {
+			c.Next()
+			return
+		}
+
+		message := status.Message
+		if message == "" {
+			message = defaultMaintenanceMessage
+		}
+		c.Header("Retry-After", maintenanceRetryAfterSeconds)
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "MAINTENANCE_MODE",
+				"message": message,
+			},
+		})
+		c.Abort()
+	}
+}
+
+func isMaintenanceExempt(path string) bool {
+	for _, prefix := range maintenanceExemptPrefixes {
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// mockMaintenanceMode is a mock implementation of MaintenanceModeChecker for testing
+type mockMaintenanceMode struct {
+	mock.Mock
+}
+
+func (m *mockMaintenanceMode) Status(ctx context.Context) models.MaintenanceStatus {
+	args := m.Called(ctx)
+	return args.Get(0).(models.MaintenanceStatus)
+}
+
+// newMockMaintenanceMode reports the given maintenance status
+func newMockMaintenanceMode(status models.MaintenanceStatus) *mockMaintenanceMode {
+	mode := new(mockMaintenanceMode)
+	mode.On("Status", mock.Anything).Return(status)
+	return mode
+}
+
+func newMaintenanceTestRouter(mode MaintenanceModeChecker) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(MaintenanceModeMiddleware(mode))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/api/v1/clips", ok)
+	r.POST("/api/v1/clips/:id/vote", ok)
+	r.POST("/api/v1/auth/refresh", ok)
+	r.PUT("/api/v1/admin/maintenance", ok)
+	r.POST("/api/v1/administrators", ok)
+	return r
+}
+
+func TestMaintenanceModeMiddleware_RejectsWritesWhenReadOnly(t *testing.T) {
+	r := newMaintenanceTestRouter(newMockMaintenanceMode(models.MaintenanceStatus{ReadOnly: true, Message: "Database failover"}))
+
+	tests := []struct {
+		method string
+		path   string
+		want   int
+	}{
+		{http.MethodGet, "/api/v1/clips", http.StatusOK},
+		{http.MethodPost, "/api/v1/clips/42/vote", http.StatusServiceUnavailable},
+		{http.MethodPost, "/api/v1/auth/refresh", http.StatusOK},
+		{http.MethodPut, "/api/v1/admin/maintenance", http.StatusOK},
+		// Only whole path segments are exempt
+		{http.MethodPost, "/api/v1/administrators", http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, http.NoBody))
+		if w.Code != tt.want {
+			t.Errorf("%s %s: expected status %d, got %d", tt.method, tt.path, tt.want, w.Code)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/clips/42/vote", http.NoBody))
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("Expected Retry-After header on rejected writes")
+	}
+	if !strings.Contains(w.Body.String(), "MAINTENANCE_MODE") || !strings.Contains(w.Body.String(), "Database failover") {
+		t.Errorf("Expected structured maintenance error, got %s", w.Body.String())
+	}
+}
+
+func TestMaintenanceModeMiddleware_AllowsWritesWhenOff(t *testing.T) {
+	r := newMaintenanceTestRouter(newMockMaintenanceMode(models.MaintenanceStatus{}))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/clips/42/vote", http.NoBody))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+}
@@ -47,12 +47,31 @@ func RequireMFAForAdminMiddleware(mfaService MFAServiceInterface) gin.HandlerFun
 			return
 		}
 
-		// Only enforce MFA for admin and moderator roles
-		if !user.IsModeratorOrAdmin() {
+		// Only enforce MFA for staff (admin, moderator, or admin sub-roles)
+		if !user.IsStaff() {
 			c.Next()
 			return
 		}
 
+		// Staff not yet marked as requiring MFA start their grace period here. Once
+		// marked, the deadline is never moved, so disabling or recovering MFA does
+		// not earn a new grace period.
+		required, enabled, _, err := mfaService.CheckMFARequired(c.Request.Context(), user.ID)
+		if err == nil && !required {
+			err = mfaService.SetMFARequired(c.Request.Context(), user.ID)
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error": gin.H{
+					"code":    "INTERNAL_ERROR",
+					"message": "Failed to check MFA status",
+				},
+			})
+			c.Abort()
+			return
+		}
+
 		// Check if admin action is allowed based on MFA status
 		allowed, message, err := mfaService.IsAdminActionAllowed(c.Request.Context(), user.ID)
 		if err != nil {
@@ -79,6 +98,20 @@ func RequireMFAForAdminMiddleware(mfaService MFAServiceInterface) gin.HandlerFun
 			return
 		}
 
+		// Enrolled staff must have passed their second factor in this session;
+		// holding a token from the first factor alone is not enough
+		if enabled && !c.GetBool("mfa_verified") {
+			c.JSON(http.StatusForbidden, gin.H{
+				"success": false,
+				"error": gin.H{
+					"code":    "MFA_VERIFICATION_REQUIRED",
+					"message": "Verify your MFA code to continue.",
+				},
+			})
+			c.Abort()
+			return
+		}
+
 		// If there's a warning message (grace period), add it to response headers
 		if message != "" {
 			c.Header("X-MFA-Warning", message)
@@ -114,10 +147,10 @@ func CheckMFARequirementMiddleware(mfaService MFAServiceInterface) gin.HandlerFu
 			return
 		}
 
-		// Check if user is now admin or moderator
-		if user.IsModeratorOrAdmin() {
+		// Check if user is now staff
+		if user.IsStaff() {
 			// Check if MFA is already set as required
-			required, enabled, _, err := mfaService.CheckMFARequired(c.Request.Context(), user.ID)
+			required, _, _, err := mfaService.CheckMFARequired(c.Request.Context(), user.ID)
 			if err != nil {
 				// Fail closed - abort the request if we cannot verify MFA requirement
 				c.Error(fmt.Errorf("SECURITY WARNING: Failed to check MFA requirement for user %s: %w", user.ID, err))
@@ -127,8 +160,8 @@ func CheckMFARequirementMiddleware(mfaService MFAServiceInterface) gin.HandlerFu
 				return
 			}
 
-			// If MFA is not yet required and not enabled, set it as required
-			if !required && !enabled {
+			// Mark MFA as required once; an existing requirement keeps its deadline
+			if !required {
 				if err := mfaService.SetMFARequired(c.Request.Context(), user.ID); err != nil {
 					// Log error - this is a security critical operation
 					c.Error(fmt.Errorf("SECURITY WARNING: Failed to set MFA requirement for user %s: %w", user.ID, err))
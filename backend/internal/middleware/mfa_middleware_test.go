@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -18,6 +19,8 @@ type mockMFAService struct {
 	inGracePeriod bool
 	shouldError   bool
 	errorMessage  error
+
+	setRequiredCalls int
 }
 
 func (m *mockMFAService) IsAdminActionAllowed(ctx context.Context, userID uuid.UUID) (bool, string, error) {
@@ -51,7 +54,12 @@ func (m *mockMFAService) SetMFARequired(ctx context.Context, userID uuid.UUID) e
 	if m.shouldError {
 		return m.errorMessage
 	}
+	// Like the repository, an existing requirement keeps its grace deadline
+	if !m.required {
+		m.inGracePeriod = true
+	}
 	m.required = true
+	m.setRequiredCalls++
 	return nil
 }
 
@@ -119,6 +127,7 @@ func TestRequireMFAForAdminMiddleware_AdminWithMFA(t *testing.T) {
 			AccountType: models.AccountTypeAdmin,
 		}
 		c.Set("user", user)
+		c.Set("mfa_verified", true)
 		c.Next()
 	})
 
@@ -142,6 +151,46 @@ func TestRequireMFAForAdminMiddleware_AdminWithMFA(t *testing.T) {
 	}
 }
 
+func TestRequireMFAForAdminMiddleware_AdminWithMFANotVerified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	// Set up an admin user whose session has not passed the second factor
+	router.Use(func(c *gin.Context) {
+		user := &models.User{
+			ID:          uuid.New(),
+			Username:    "admin",
+			Role:        models.RoleAdmin,
+			AccountType: models.AccountTypeAdmin,
+		}
+		c.Set("user", user)
+		c.Set("mfa_verified", false)
+		c.Next()
+	})
+
+	// Mock MFA service - MFA is required and enabled
+	mfaService := &mockMFAService{
+		required: true,
+		enabled:  true,
+	}
+	router.Use(RequireMFAForAdminMiddleware(mfaService))
+	router.GET("/test", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Enrollment alone is not enough; the session must be MFA-verified
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "MFA_VERIFICATION_REQUIRED") {
+		t.Errorf("Expected MFA_VERIFICATION_REQUIRED error, got %s", w.Body.String())
+	}
+}
+
 func TestRequireMFAForAdminMiddleware_AdminInGracePeriod(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -235,6 +284,7 @@ func TestRequireMFAForAdminMiddleware_ModeratorWithMFA(t *testing.T) {
 			AccountType: models.AccountTypeModerator,
 		}
 		c.Set("user", user)
+		c.Set("mfa_verified", true)
 		c.Next()
 	})
 
@@ -295,6 +345,83 @@ func TestRequireMFAForAdminMiddleware_ModeratorWithoutMFAExpiredGracePeriod(t *t
 	}
 }
 
+func TestRequireMFAForAdminMiddleware_StaffWithoutMFARecordStartsGracePeriod(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	// Set up a finance sub-role user who has never enrolled
+	router.Use(func(c *gin.Context) {
+		user := &models.User{
+			ID:          uuid.New(),
+			Username:    "finance",
+			Role:        models.RoleUser,
+			AccountType: models.AccountTypeMember,
+			AdminRoles:  []string{models.AdminRoleFinance},
+		}
+		c.Set("user", user)
+		c.Next()
+	})
+
+	// Mock MFA service - no MFA record yet
+	mfaService := &mockMFAService{}
+	router.Use(RequireMFAForAdminMiddleware(mfaService))
+	router.GET("/test", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+	if mfaService.setRequiredCalls != 1 {
+		t.Errorf("Expected MFA requirement to be set once, got %d", mfaService.setRequiredCalls)
+	}
+	if w.Header().Get("X-MFA-Warning") == "" {
+		t.Error("Expected grace period warning header")
+	}
+}
+
+func TestRequireMFAForAdminMiddleware_DisabledMFADoesNotRestartGracePeriod(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(func(c *gin.Context) {
+		user := &models.User{
+			ID:          uuid.New(),
+			Username:    "admin",
+			Role:        models.RoleAdmin,
+			AccountType: models.AccountTypeAdmin,
+		}
+		c.Set("user", user)
+		c.Next()
+	})
+
+	// Mock MFA service - requirement set long ago, MFA since disabled
+	mfaService := &mockMFAService{
+		required:      true,
+		enabled:       false,
+		inGracePeriod: false,
+	}
+	router.Use(RequireMFAForAdminMiddleware(mfaService))
+	router.GET("/test", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403, got %d", w.Code)
+	}
+	if mfaService.setRequiredCalls != 0 {
+		t.Errorf("Expected MFA requirement to be left alone, got %d calls", mfaService.setRequiredCalls)
+	}
+}
+
 func TestCheckMFARequirementMiddleware(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
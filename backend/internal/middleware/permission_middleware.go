@@ -328,3 +328,49 @@ func RequireAccountType(accountTypes ...string) gin.HandlerFunc {
 		c.Abort()
 	}
 }
+
+// RequireAdminAccess creates middleware that admits admins, moderators and holders of an
+// admin sub-role to the admin area. Individual routes narrow access with RequirePermission.
+func RequireAdminAccess() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userInterface, exists := c.Get("user")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"error": gin.H{
+					"code":    "UNAUTHORIZED",
+					"message": "Authentication required",
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		user, ok := userInterface.(*models.User)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error": gin.H{
+					"code":    "INTERNAL_ERROR",
+					"message": "Invalid user format",
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		if !user.IsStaff() {
+			c.JSON(http.StatusForbidden, gin.H{
+				"success": false,
+				"error": gin.H{
+					"code":    "FORBIDDEN",
+					"message": "Insufficient permissions",
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
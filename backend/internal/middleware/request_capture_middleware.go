@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/services"
+	"github.com/subculture-collective/clipper/pkg/utils"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// requestCaptureSaveTimeout bounds saving a capture after the response is sent
+const requestCaptureSaveTimeout = 5 * time.Second
+
+// RequestCapturer samples requests into capture sessions
+type RequestCapturer interface {
+	Sample(ctx context.Context, method, route string) *models.RequestCaptureSession
+	Record(ctx context.Context, session *models.RequestCaptureSession, raw *services.RawRequestCapture)
+}
+
+// RequestCaptureMiddleware records sampled requests to routes with an active
+// capture session, with their responses, for debugging. Requests outside a
+// session pass through untouched. Captures are saved after the response, so
+// they never slow down or fail a request.
+func RequestCaptureMiddleware(capturer RequestCapturer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			c.Next()
+			return
+		}
+		session := capturer.Sample(c.Request.Context(), c.Request.Method, route)
+		if session == nil {
+			c.Next()
+			return
+		}
+
+		raw := &services.RawRequestCapture{
+			Method:         c.Request.Method,
+			Route:          route,
+			Path:           c.Request.URL.Path,
+			Query:          c.Request.URL.RawQuery,
+			RequestHeaders: c.Request.Header.Clone(),
+		}
+
+		// Keep the start of the body and hand the handler all of it
+		if c.Request.Body != nil {
+			body, _ := io.ReadAll(io.LimitReader(c.Request.Body, services.RequestCaptureMaxBodyBytes+1))
+			c.Request.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(body), c.Request.Body), c.Request.Body}
+			raw.RequestBody, raw.RequestBodyTruncated = capBody(body)
+		}
+
+		writer := &captureResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		start := time.Now()
+
+		c.Next()
+
+		raw.Duration = time.Since(start)
+		raw.Status = writer.Status()
+		raw.ResponseHeaders = writer.Header().Clone()
+		raw.ResponseBody, raw.ResponseBodyTruncated = capBody(writer.body.Bytes())
+		raw.RequestID = requestid.Get(c)
+		if spanContext := trace.SpanContextFromContext(c.Request.Context()); spanContext.HasTraceID() {
+			raw.TraceID = spanContext.TraceID().String()
+		}
+		if userID, exists := c.Get("user_id"); exists {
+			raw.UserHash = utils.HashForLogging(fmt.Sprintf("%v", userID))
+		}
+		for _, e := range c.Errors {
+			raw.Errors = append(raw.Errors, e.Error())
+		}
+
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), requestCaptureSaveTimeout)
+			defer cancel()
+			capturer.Record(ctx, session, raw)
+		}()
+	}
+}
+
+// capBody cuts a body at the capture limit, reporting whether it was cut
+func capBody(body []byte) ([]byte, bool) {
+	if len(body) > services.RequestCaptureMaxBodyBytes {
+		return body[:services.RequestCaptureMaxBodyBytes], true
+	}
+	return body, false
+}
+
+// captureResponseWriter passes a response through while keeping a copy of
+// its first bytes
+type captureResponseWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *captureResponseWriter) Write(data []byte) (int, error) {
+	w.keep(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *captureResponseWriter) WriteString(s string) (int, error) {
+	w.keep([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *captureResponseWriter) keep(data []byte) {
+	if remaining := services.RequestCaptureMaxBodyBytes + 1 - w.body.Len(); remaining > 0 {
+		if len(data) > remaining {
+			data = data[:remaining]
+		}
+		w.body.Write(data)
+	}
+}
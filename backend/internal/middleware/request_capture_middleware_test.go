@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/services"
+)
+
+// mockRequestCapturer is a mock implementation of RequestCapturer for testing
+type mockRequestCapturer struct {
+	mock.Mock
+}
+
+func (m *mockRequestCapturer) Sample(ctx context.Context, method, route string) *models.RequestCaptureSession {
+	args := m.Called(ctx, method, route)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(*models.RequestCaptureSession)
+}
+
+func (m *mockRequestCapturer) Record(ctx context.Context, session *models.RequestCaptureSession, raw *services.RawRequestCapture) {
+	m.Called(ctx, session, raw)
+}
+
+// newMockRequestCapturer samples every request to the route and sends what
+// it records to the returned channel
+func newMockRequestCapturer(route string) (*mockRequestCapturer, chan *services.RawRequestCapture) {
+	capturer := new(mockRequestCapturer)
+	recorded := make(chan *services.RawRequestCapture, 1)
+	capturer.On("Sample", mock.Anything, mock.Anything, route).
+		Return(&models.RequestCaptureSession{ID: uuid.New(), Method: http.MethodPost, Route: route})
+	capturer.On("Sample", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	capturer.On("Record", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		recorded <- args.Get(2).(*services.RawRequestCapture)
+	})
+	return capturer, recorded
+}
+
+func newRequestCaptureTestRouter(capturer RequestCapturer) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequestCaptureMiddleware(capturer))
+	r.POST("/clips/:id", func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.Data(http.StatusCreated, "text/plain", body)
+	})
+	r.POST("/other", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	return r
+}
+
+func TestRequestCaptureMiddleware_RecordsSampledRoute(t *testing.T) {
+	capturer, recorded := newMockRequestCapturer("/clips/:id")
+	r := newRequestCaptureTestRouter(capturer)
+
+	req := httptest.NewRequest(http.MethodPost, "/clips/42?page=1", strings.NewReader("hello"))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Body.String() != "hello" {
+		t.Fatalf("Expected handler to read the full body, got %q", w.Body.String())
+	}
+
+	select {
+	case raw := <-recorded:
+		if raw.Route != "/clips/:id" || raw.Path != "/clips/42" || raw.Query != "page=1" {
+			t.Errorf("Unexpected request in capture: %s %s %s", raw.Route, raw.Path, raw.Query)
+		}
+		if raw.Status != http.StatusCreated {
+			t.Errorf("Expected status %d, got %d", http.StatusCreated, raw.Status)
+		}
+		if string(raw.RequestBody) != "hello" || string(raw.ResponseBody) != "hello" {
+			t.Errorf("Expected bodies to be captured, got %q and %q", raw.RequestBody, raw.ResponseBody)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the request to be recorded")
+	}
+}
+
+func TestRequestCaptureMiddleware_TruncatesLargeBodies(t *testing.T) {
+	capturer, recorded := newMockRequestCapturer("/clips/:id")
+	r := newRequestCaptureTestRouter(capturer)
+
+	body := strings.Repeat("a", services.RequestCaptureMaxBodyBytes+100)
+	req := httptest.NewRequest(http.MethodPost, "/clips/42", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Body.Len() != len(body) {
+		t.Fatalf("Expected handler to read %d bytes, got %d", len(body), w.Body.Len())
+	}
+
+	raw := <-recorded
+	if len(raw.RequestBody) != services.RequestCaptureMaxBodyBytes || !raw.RequestBodyTruncated {
+		t.Errorf("Expected request body cut at %d bytes, got %d (truncated=%v)", services.RequestCaptureMaxBodyBytes, len(raw.RequestBody), raw.RequestBodyTruncated)
+	}
+	if len(raw.ResponseBody) != services.RequestCaptureMaxBodyBytes || !raw.ResponseBodyTruncated {
+		t.Errorf("Expected response body cut at %d bytes, got %d (truncated=%v)", services.RequestCaptureMaxBodyBytes, len(raw.ResponseBody), raw.ResponseBodyTruncated)
+	}
+}
+
+func TestRequestCaptureMiddleware_SkipsUnsampledRoutes(t *testing.T) {
+	capturer, recorded := newMockRequestCapturer("/clips/:id")
+	r := newRequestCaptureTestRouter(capturer)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/other", nil))
+
+	select {
+	case <-recorded:
+		t.Fatal("Expected no capture for an unsampled route")
+	case <-time.After(50 * time.Millisecond):
+	}
+	capturer.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Metrics achievements measure progress with
+const (
+	AchievementMetricCommentsPosted      = "comments_posted"      // Source: the comment
+	AchievementMetricUpvotedComments     = "upvoted_comments"     // Source: the comment, on its first upvote by another user
+	AchievementMetricSubmissionsApproved = "submissions_approved" // Source: the submission
+)
+
+// Achievement is a goal completed when a user's metric reaches the target.
+// Completing it awards the badge, if set.
+type Achievement struct {
+	ID          string  `json:"id" db:"id"`
+	Name        string  `json:"name" db:"name"`
+	Description string  `json:"description" db:"description"`
+	Icon        string  `json:"icon" db:"icon"`
+	Metric      string  `json:"metric" db:"metric"`
+	Target      int     `json:"target" db:"target"`
+	BadgeID     *string `json:"badge_id,omitempty" db:"badge_id"`
+}
+
+// UserAchievement is an achievement with a user's progress toward it
+type UserAchievement struct {
+	Achievement
+	UserID      uuid.UUID  `json:"user_id"`
+	Progress    int        `json:"progress"` // Metric value, up to the target
+	Percent     int        `json:"percent"`  // Progress as a percentage of the target, for progress bars
+	Completed   bool       `json:"completed"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// NewUserAchievement returns a user's progress toward an achievement, given
+// their metric value and when they completed it. Completed achievements stay
+// at full progress.
+func NewUserAchievement(achievement Achievement, userID uuid.UUID, value int, completedAt *time.Time) UserAchievement {
+	progress := max(0, min(value, achievement.Target))
+	if completedAt != nil {
+		progress = achievement.Target
+	}
+	percent := 0
+	if achievement.Target > 0 {
+		percent = progress * 100 / achievement.Target
+	}
+	return UserAchievement{
+		Achievement: achievement,
+		UserID:      userID,
+		Progress:    progress,
+		Percent:     percent,
+		Completed:   completedAt != nil,
+		CompletedAt: completedAt,
+	}
+}
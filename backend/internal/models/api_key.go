@@ -0,0 +1,68 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// API key scope constants
+const (
+	APIKeyScopeReadClips      = "read-clips"
+	APIKeyScopeSubmitClips    = "submit-clips"
+	APIKeyScopeManageWebhooks = "manage-webhooks"
+)
+
+// ValidAPIKeyScopes lists the scopes a personal API key can be granted
+var ValidAPIKeyScopes = []string{
+	APIKeyScopeReadClips,
+	APIKeyScopeSubmitClips,
+	APIKeyScopeManageWebhooks,
+}
+
+// APIKey represents a personal API key used by third-party tools
+type APIKey struct {
+	ID                 uuid.UUID  `json:"id" db:"id"`
+	UserID             uuid.UUID  `json:"user_id" db:"user_id"`
+	Name               string     `json:"name" db:"name"`
+	KeyPrefix          string     `json:"key_prefix" db:"key_prefix"`
+	KeyHash            string     `json:"-" db:"key_hash"`
+	Scopes             []string   `json:"scopes" db:"scopes"`
+	RateLimitPerMinute int        `json:"rate_limit_per_minute" db:"rate_limit_per_minute"`
+	LastUsedAt         *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+	ExpiresAt          *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	RevokedAt          *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt          time.Time  `json:"created_at" db:"created_at"`
+}
+
+// HasScope checks if the key was granted a scope
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsActive reports whether the key is neither revoked nor expired
+func (k *APIKey) IsActive(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+// CreateAPIKeyRequest is the request body for creating an API key
+type CreateAPIKeyRequest struct {
+	Name               string   `json:"name" binding:"required,min=1,max=100"`
+	Scopes             []string `json:"scopes" binding:"required,min=1"`
+	RateLimitPerMinute int      `json:"rate_limit_per_minute,omitempty"`
+	ExpiresInDays      int      `json:"expires_in_days,omitempty"`
+}
+
+// CreateAPIKeyResponse returns the plaintext key exactly once
+type CreateAPIKeyResponse struct {
+	APIKey *APIKey `json:"api_key"`
+	Key    string  `json:"key"`
+}
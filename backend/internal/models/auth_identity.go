@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Authentication provider constants
+const (
+	AuthProviderTwitch  = "twitch"
+	AuthProviderGoogle  = "google"
+	AuthProviderDiscord = "discord"
+)
+
+// UserAuthIdentity represents an external login identity linked to a user account
+type UserAuthIdentity struct {
+	ID             uuid.UUID              `json:"id" db:"id"`
+	UserID         uuid.UUID              `json:"user_id" db:"user_id"`
+	Provider       string                 `json:"provider" db:"provider"`
+	ProviderUserID string                 `json:"provider_user_id" db:"provider_user_id"`
+	Email          *string                `json:"email,omitempty" db:"email"`
+	EmailVerified  bool                   `json:"email_verified" db:"email_verified"`
+	DisplayName    *string                `json:"display_name,omitempty" db:"display_name"`
+	AvatarURL      *string                `json:"avatar_url,omitempty" db:"avatar_url"`
+	Metadata       map[string]interface{} `json:"metadata,omitempty" db:"metadata"` // JSONB
+	CreatedAt      time.Time              `json:"created_at" db:"created_at"`
+	LastLoginAt    *time.Time             `json:"last_login_at,omitempty" db:"last_login_at"`
+}
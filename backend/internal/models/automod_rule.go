@@ -0,0 +1,108 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Content automoderation rules are evaluated against
+const (
+	AutomodTargetComment    = "comment"
+	AutomodTargetSubmission = "submission"
+)
+
+// Automoderation actions, weakest first. When several rules match, the
+// strongest action is taken.
+const (
+	AutomodActionFlag       = "flag"        // Queue for review, leave visible
+	AutomodActionHold       = "hold"        // Hide until a moderator approves it
+	AutomodActionShadowHide = "shadow_hide" // Hide from everyone but the author
+	AutomodActionRemove     = "remove"      // Remove at once
+)
+
+// Automoderation link policies
+const (
+	AutomodLinkPolicyNone      = "none"      // Links aren't checked
+	AutomodLinkPolicyBlock     = "block"     // Any link matches
+	AutomodLinkPolicyAllowlist = "allowlist" // Links outside link_domains match
+	AutomodLinkPolicyBlocklist = "blocklist" // Links to link_domains match
+)
+
+// Comment automoderation states, kept in comments.automod_state
+const (
+	CommentAutomodHeld         = "held"
+	CommentAutomodShadowHidden = "shadow_hidden"
+)
+
+// AutomodModerationReason is the moderation queue reason of automoderated content
+const AutomodModerationReason = "automod"
+
+// AutomodRule flags, hides or removes comments and submissions matching all
+// of its conditions. Keywords and patterns match when any entry matches.
+// Community rules only apply to clips in the community; a community rule
+// overriding a global rule replaces it there, or turns it off when disabled.
+type AutomodRule struct {
+	ID                uuid.UUID  `json:"id" db:"id"`
+	Name              string     `json:"name" db:"name"`
+	Enabled           bool       `json:"enabled" db:"enabled"`
+	Targets           []string   `json:"targets" db:"targets"`
+	Keywords          []string   `json:"keywords" db:"keywords"`
+	Patterns          []string   `json:"patterns" db:"patterns"`
+	LinkPolicy        string     `json:"link_policy" db:"link_policy"`
+	LinkDomains       []string   `json:"link_domains" db:"link_domains"`
+	MinKarma          *int       `json:"min_karma,omitempty" db:"min_karma"`
+	MinAccountAgeDays *int       `json:"min_account_age_days,omitempty" db:"min_account_age_days"`
+	Action            string     `json:"action" db:"action"`
+	CommunityID       *uuid.UUID `json:"community_id,omitempty" db:"community_id"`
+	OverridesRuleID   *uuid.UUID `json:"overrides_rule_id,omitempty" db:"overrides_rule_id"`
+	CreatedBy         *uuid.UUID `json:"created_by,omitempty" db:"created_by"`
+	MatchCount        int64      `json:"match_count" db:"match_count"`
+	LastMatchedAt     *time.Time `json:"last_matched_at,omitempty" db:"last_matched_at"`
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// HasConditions reports whether the rule has at least one condition
+func (r *AutomodRule) HasConditions() bool {
+	return len(r.Keywords) > 0 || len(r.Patterns) > 0 || r.LinkPolicy != AutomodLinkPolicyNone ||
+		r.MinKarma != nil || r.MinAccountAgeDays != nil
+}
+
+// AutomodRuleRequest creates an automoderation rule or replaces an existing
+// rule's settings
+type AutomodRuleRequest struct {
+	Name              string     `json:"name" binding:"required,min=1,max=200"`
+	Enabled           *bool      `json:"enabled,omitempty"`
+	Targets           []string   `json:"targets,omitempty" binding:"omitempty,max=2,dive,oneof=comment submission"`
+	Keywords          []string   `json:"keywords,omitempty" binding:"omitempty,max=500,dive,min=1,max=100"`
+	Patterns          []string   `json:"patterns,omitempty" binding:"omitempty,max=50,dive,min=1,max=500"`
+	LinkPolicy        string     `json:"link_policy,omitempty" binding:"omitempty,oneof=none block allowlist blocklist"`
+	LinkDomains       []string   `json:"link_domains,omitempty" binding:"omitempty,max=500,dive,min=1,max=253"`
+	MinKarma          *int       `json:"min_karma,omitempty"`
+	MinAccountAgeDays *int       `json:"min_account_age_days,omitempty" binding:"omitempty,min=1,max=3650"`
+	Action            string     `json:"action" binding:"required,oneof=flag hold shadow_hide remove"`
+	CommunityID       *uuid.UUID `json:"community_id,omitempty"`
+	OverridesRuleID   *uuid.UUID `json:"overrides_rule_id,omitempty"`
+}
+
+// AutomodMatch is a rule that matched a comment or submission
+type AutomodMatch struct {
+	RuleID   uuid.UUID `json:"rule_id"`
+	RuleName string    `json:"rule_name"`
+	Action   string    `json:"action"`
+	// Reason names the condition that matched, e.g. the keyword or link
+	Reason string `json:"reason"`
+}
+
+// AutomodVerdict is the outcome of evaluating content against the
+// automoderation rules: the strongest matching action and every match
+type AutomodVerdict struct {
+	Action  string         `json:"action"`
+	Matches []AutomodMatch `json:"matches"`
+}
+
+// Hides reports whether the content is kept from other users
+func (v *AutomodVerdict) Hides() bool {
+	return v != nil && v.Action != AutomodActionFlag
+}
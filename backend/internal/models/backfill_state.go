@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// Backfill job statuses
+const (
+	BackfillStatusRunning   = "running"
+	BackfillStatusCompleted = "completed"
+	BackfillStatusFailed    = "failed"
+)
+
+// BackfillState is the checkpoint of a backfill job. Every batch up to Cursor
+// is done, so a resumed job continues after it.
+type BackfillState struct {
+	Name        string     `json:"name" db:"name"`
+	Status      string     `json:"status" db:"status"`
+	Cursor      string     `json:"cursor" db:"cursor"`
+	Processed   int64      `json:"processed" db:"processed"`
+	Failed      int64      `json:"failed" db:"failed"`
+	LastError   *string    `json:"last_error,omitempty" db:"last_error"`
+	StartedAt   time.Time  `json:"started_at" db:"started_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+}
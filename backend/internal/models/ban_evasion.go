@@ -0,0 +1,92 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Signals linked to accounts for ban evasion detection
+const (
+	AccountSignalIP          = "ip"           // Keyed hash of the sign-in IP address
+	AccountSignalDevice      = "device"       // Keyed hash of the device fingerprint header
+	AccountSignalEmailDomain = "email_domain" // Domain of the account's email address
+)
+
+// Review states of a ban evasion flag
+const (
+	BanEvasionFlagPending   = "pending"   // Awaiting review
+	BanEvasionFlagConfirmed = "confirmed" // The account was found to be evading a ban
+	BanEvasionFlagDismissed = "dismissed" // The accounts aren't related
+)
+
+// BanEvasionQueueReason is the moderation queue reason for ban evasion flags
+const BanEvasionQueueReason = "ban_evasion"
+
+// AccountSignal is a sign-in signal seen for an account
+type AccountSignal struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	UserID      uuid.UUID `json:"user_id" db:"user_id"`
+	SignalType  string    `json:"signal_type" db:"signal_type"`
+	SignalValue string    `json:"-" db:"signal_value"`
+	FirstSeenAt time.Time `json:"first_seen_at" db:"first_seen_at"`
+	LastSeenAt  time.Time `json:"last_seen_at" db:"last_seen_at"`
+	SeenCount   int       `json:"seen_count" db:"seen_count"`
+}
+
+// BanEvasionMatch is one signal a new account shares with a banned account
+type BanEvasionMatch struct {
+	UserID       uuid.UUID
+	BannedUserID uuid.UUID
+	SignalType   string
+	SignalValue  string
+}
+
+// BanEvasionFlag links a new account to a banned account it shares signals
+// with, scored by how confident the match is
+type BanEvasionFlag struct {
+	ID             uuid.UUID  `json:"id" db:"id"`
+	UserID         uuid.UUID  `json:"user_id" db:"user_id"`
+	BannedUserID   uuid.UUID  `json:"banned_user_id" db:"banned_user_id"`
+	Confidence     float64    `json:"confidence" db:"confidence"`
+	MatchedSignals []string   `json:"matched_signals" db:"matched_signals"`
+	Status         string     `json:"status" db:"status"`
+	QueueItemID    *uuid.UUID `json:"queue_item_id,omitempty" db:"queue_item_id"`
+	ReviewedBy     *uuid.UUID `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewedAt     *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	ReviewNotes    *string    `json:"review_notes,omitempty" db:"review_notes"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+}
+
+// BanEvasionFlagWithUsers is a ban evasion flag with both accounts' details
+type BanEvasionFlagWithUsers struct {
+	BanEvasionFlag
+	Username         string    `json:"username"`
+	UserCreatedAt    time.Time `json:"user_created_at"`
+	BannedUsername   string    `json:"banned_username"`
+	BanReason        *string   `json:"ban_reason,omitempty"` // The banned account's active ban
+	ReviewerUsername *string   `json:"reviewer_username,omitempty"`
+}
+
+// LinkedAccount is another account that shares sign-in signals with a user
+type LinkedAccount struct {
+	UserID         uuid.UUID `json:"user_id"`
+	Username       string    `json:"username"`
+	IsBanned       bool      `json:"is_banned"`
+	CreatedAt      time.Time `json:"created_at"`
+	MatchedSignals []string  `json:"matched_signals"`
+	LastSharedAt   time.Time `json:"last_shared_at"`
+}
+
+// BanEvasionFlagFilters narrows the ban evasion review list
+type BanEvasionFlagFilters struct {
+	Status string
+	UserID *uuid.UUID // Flags on this suspect account
+	Limit  int
+	Offset int
+}
+
+// ReviewBanEvasionFlagRequest is the body for confirming or dismissing a flag
+type ReviewBanEvasionFlagRequest struct {
+	Notes *string `json:"notes,omitempty" binding:"omitempty,max=2000"`
+}
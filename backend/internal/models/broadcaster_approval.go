@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SubmissionStatusAwaitingBroadcaster marks a submission held for broadcaster approval
+const SubmissionStatusAwaitingBroadcaster = "awaiting_broadcaster"
+
+// Broadcaster clip review statuses
+const (
+	BroadcasterReviewStatusPending  = "pending"
+	BroadcasterReviewStatusApproved = "approved"
+	BroadcasterReviewStatusRejected = "rejected"
+	BroadcasterReviewStatusExpired  = "expired"
+)
+
+// BroadcasterApprovalPolicy controls whether clips of a channel need the broadcaster's approval
+type BroadcasterApprovalPolicy struct {
+	BroadcasterID        string    `json:"broadcaster_id" db:"broadcaster_id"`
+	UserID               uuid.UUID `json:"user_id" db:"user_id"`
+	RequireApproval      bool      `json:"require_approval" db:"require_approval"`
+	AutoApproveAfterDays *int      `json:"auto_approve_after_days,omitempty" db:"auto_approve_after_days"`
+	CreatedAt            time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// BroadcasterClipReview is a submission waiting on (or decided by) a broadcaster
+type BroadcasterClipReview struct {
+	SubmissionID    uuid.UUID  `json:"submission_id" db:"submission_id"`
+	BroadcasterID   string     `json:"broadcaster_id" db:"broadcaster_id"`
+	Status          string     `json:"status" db:"status"`
+	AutoApproveAt   time.Time  `json:"auto_approve_at" db:"auto_approve_at"`
+	ReviewedBy      *uuid.UUID `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	RejectionReason *string    `json:"rejection_reason,omitempty" db:"rejection_reason"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+}
+
+// BroadcasterClipReviewWithSubmission is a queue entry with the held submission
+type BroadcasterClipReviewWithSubmission struct {
+	BroadcasterClipReview
+	Submission *ClipSubmission `json:"submission"`
+}
+
+// UpdateBroadcasterApprovalPolicyRequest updates the caller's approval policy
+type UpdateBroadcasterApprovalPolicyRequest struct {
+	RequireApproval      bool `json:"require_approval"`
+	AutoApproveAfterDays *int `json:"auto_approve_after_days,omitempty" binding:"omitempty,min=1,max=30"`
+}
+
+// RejectBroadcasterClipRequest is the body for a broadcaster rejecting a held clip
+type RejectBroadcasterClipRequest struct {
+	Reason string `json:"reason" binding:"required,min=1,max=500"`
+}
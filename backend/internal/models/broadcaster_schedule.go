@@ -0,0 +1,84 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Allowed range for how long before a scheduled stream a reminder is sent
+const (
+	ScheduleReminderMinMinutes = 5
+	ScheduleReminderMaxMinutes = 1440
+)
+
+// BroadcasterScheduleSegment is one scheduled stream occurrence from a
+// broadcaster's Twitch schedule
+type BroadcasterScheduleSegment struct {
+	ID            uuid.UUID  `json:"id" db:"id"`
+	BroadcasterID string     `json:"broadcaster_id" db:"broadcaster_id"`
+	SegmentID     string     `json:"segment_id" db:"segment_id"`
+	Title         string     `json:"title" db:"title"`
+	CategoryID    *string    `json:"category_id,omitempty" db:"category_id"`
+	CategoryName  *string    `json:"category_name,omitempty" db:"category_name"`
+	StartTime     time.Time  `json:"start_time" db:"start_time"`
+	EndTime       *time.Time `json:"end_time,omitempty" db:"end_time"`
+	IsRecurring   bool       `json:"is_recurring" db:"is_recurring"`
+	IsCanceled    bool       `json:"is_canceled" db:"is_canceled"`
+}
+
+// BroadcasterSchedule is a broadcaster's upcoming schedule. SyncedAt is nil
+// when it has never been pulled from Twitch.
+type BroadcasterSchedule struct {
+	BroadcasterID    string                       `json:"broadcaster_id"`
+	BroadcasterName  *string                      `json:"broadcaster_name,omitempty"`
+	BroadcasterLogin *string                      `json:"broadcaster_login,omitempty"`
+	VacationStart    *time.Time                   `json:"vacation_start,omitempty"`
+	VacationEnd      *time.Time                   `json:"vacation_end,omitempty"`
+	Segments         []BroadcasterScheduleSegment `json:"segments"`
+	SyncedAt         *time.Time                   `json:"synced_at,omitempty"`
+}
+
+// BroadcasterScheduleSync records when a broadcaster's schedule was last pulled
+type BroadcasterScheduleSync struct {
+	BroadcasterID    string     `json:"broadcaster_id" db:"broadcaster_id"`
+	BroadcasterName  *string    `json:"broadcaster_name,omitempty" db:"broadcaster_name"`
+	BroadcasterLogin *string    `json:"broadcaster_login,omitempty" db:"broadcaster_login"`
+	VacationStart    *time.Time `json:"vacation_start,omitempty" db:"vacation_start"`
+	VacationEnd      *time.Time `json:"vacation_end,omitempty" db:"vacation_end"`
+	SyncedAt         time.Time  `json:"synced_at" db:"synced_at"`
+}
+
+// UpcomingStream is a scheduled stream in a user's upcoming feed
+type UpcomingStream struct {
+	BroadcasterScheduleSegment
+	BroadcasterName  string  `json:"broadcaster_name" db:"broadcaster_name"`
+	BroadcasterLogin *string `json:"broadcaster_login,omitempty" db:"broadcaster_login"`
+	ReminderMinutes  *int    `json:"reminder_minutes,omitempty" db:"reminder_minutes"` // Set when the user has reminders on for this broadcaster
+}
+
+// BroadcasterScheduleReminder is a user's reminder setting for a followed broadcaster
+type BroadcasterScheduleReminder struct {
+	UserID        uuid.UUID `json:"user_id" db:"user_id"`
+	BroadcasterID string    `json:"broadcaster_id" db:"broadcaster_id"`
+	MinutesBefore int       `json:"minutes_before" db:"minutes_before"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// UpdateScheduleReminderRequest turns on or changes reminders for a broadcaster
+type UpdateScheduleReminderRequest struct {
+	MinutesBefore int `json:"minutes_before" binding:"required,min=5,max=1440"`
+}
+
+// DueScheduleReminder is a reminder ready to be sent for a scheduled stream
+type DueScheduleReminder struct {
+	UserID           uuid.UUID `db:"user_id"`
+	ScheduleID       uuid.UUID `db:"schedule_id"`
+	BroadcasterID    string    `db:"broadcaster_id"`
+	BroadcasterName  string    `db:"broadcaster_name"`
+	BroadcasterLogin *string   `db:"broadcaster_login"`
+	Title            string    `db:"title"`
+	CategoryName     *string   `db:"category_name"`
+	StartTime        time.Time `db:"start_time"`
+}
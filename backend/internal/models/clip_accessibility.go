@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Caption sources for clip accessibility metadata
+const (
+	CaptionsSourceManual     = "manual"     // Set by the creator or a moderator
+	CaptionsSourceTranscript = "transcript" // Detected once a transcript exists
+)
+
+// ClipAccessibility describes a clip's accessibility features
+type ClipAccessibility struct {
+	ClipID                  uuid.UUID  `json:"clip_id" db:"clip_id"`
+	HasCaptions             bool       `json:"has_captions" db:"has_captions"`
+	CaptionsSource          *string    `json:"captions_source,omitempty" db:"captions_source"`
+	PhotosensitivityWarning bool       `json:"photosensitivity_warning" db:"photosensitivity_warning"`
+	AudioDescription        bool       `json:"audio_description" db:"audio_description"`
+	UpdatedBy               *uuid.UUID `json:"-" db:"updated_by"`
+	UpdatedAt               time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// UpdateClipAccessibilityRequest sets a clip's accessibility fields; omitted fields are left unchanged
+type UpdateClipAccessibilityRequest struct {
+	HasCaptions             *bool `json:"has_captions,omitempty"`
+	PhotosensitivityWarning *bool `json:"photosensitivity_warning,omitempty"`
+	AudioDescription        *bool `json:"audio_description,omitempty"`
+}
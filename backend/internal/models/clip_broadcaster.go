@@ -0,0 +1,26 @@
+package models
+
+import "github.com/google/uuid"
+
+// MaxClipFeaturedBroadcasters caps how many broadcasters can be featured on a clip
+const MaxClipFeaturedBroadcasters = 10
+
+// ClipBroadcaster is a broadcaster credited on a clip
+type ClipBroadcaster struct {
+	BroadcasterID   string `json:"broadcaster_id" binding:"required,max=50"`
+	BroadcasterName string `json:"broadcaster_name" binding:"required,max=100"`
+}
+
+// ClipBroadcasters is a clip's attribution: the broadcaster whose channel it
+// was clipped from and the broadcasters featured in it
+type ClipBroadcasters struct {
+	ClipID   uuid.UUID         `json:"clip_id"`
+	Primary  ClipBroadcaster   `json:"primary"`
+	Featured []ClipBroadcaster `json:"featured"`
+}
+
+// UpdateClipBroadcastersRequest replaces a clip's attribution
+type UpdateClipBroadcastersRequest struct {
+	Primary  ClipBroadcaster   `json:"primary"`
+	Featured []ClipBroadcaster `json:"featured" binding:"max=10,dive"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Clip changefeed event types
+const (
+	ClipChangeCreate = "create"
+	ClipChangeUpdate = "update"
+	ClipChangeDelete = "delete"
+)
+
+// Page size limits of the clip changefeed
+const (
+	ClipChangefeedDefaultLimit = 100
+	ClipChangefeedMaxLimit     = 1000
+)
+
+// ClipChangeEvent is one change to a public clip. A clip becoming public is a
+// create and a clip being removed or hidden is a delete.
+type ClipChangeEvent struct {
+	Sequence   int64           `json:"sequence" db:"sequence"`
+	EventID    uuid.UUID       `json:"event_id" db:"event_id"`
+	Type       string          `json:"type" db:"event_type"`
+	ClipID     uuid.UUID       `json:"clip_id" db:"clip_id"`
+	Clip       json.RawMessage `json:"clip,omitempty" db:"document"` // Public clip fields after the change; omitted for deletes
+	OccurredAt time.Time       `json:"occurred_at" db:"occurred_at"`
+}
+
+// ClipChangefeedPage is the events after a sequence number
+type ClipChangefeedPage struct {
+	Events    []ClipChangeEvent `json:"events"`
+	NextSince int64             `json:"next_since"` // Pass as since to read the events after this page
+	HasMore   bool              `json:"has_more"`
+}
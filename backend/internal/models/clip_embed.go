@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Embed domain rules
+const (
+	EmbedDomainRuleAllow = "allow"
+	EmbedDomainRuleBlock = "block"
+)
+
+// EmbedDomainUnknown is the domain embed traffic is counted under when the
+// embedding page sent no referrer
+const EmbedDomainUnknown = "unknown"
+
+// EmbedDomainRule allows or blocks a domain, and its subdomains, from
+// embedding clips. Once a scope has an allow rule, only allowed domains may
+// embed its clips.
+type EmbedDomainRule struct {
+	ID            uuid.UUID  `json:"id" db:"id"`
+	BroadcasterID *string    `json:"broadcaster_id,omitempty" db:"broadcaster_id"` // Nil for platform-wide rules
+	Domain        string     `json:"domain" db:"domain"`
+	Rule          string     `json:"rule" db:"rule"`
+	CreatedBy     *uuid.UUID `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+}
+
+// CreateEmbedDomainRuleRequest allows or blocks a domain. Setting a rule on a
+// domain that already has one replaces it.
+type CreateEmbedDomainRuleRequest struct {
+	Domain        string  `json:"domain" binding:"required,max=253"`
+	Rule          string  `json:"rule" binding:"required,oneof=allow block"`
+	BroadcasterID *string `json:"broadcaster_id,omitempty" binding:"omitempty,max=50"` // Admins only; omit for a platform-wide rule
+}
+
+// ClipEmbedDailyStat is a day of embed traffic for a clip on one site
+type ClipEmbedDailyStat struct {
+	Day           time.Time `json:"day" db:"day"`
+	Domain        string    `json:"domain" db:"domain"`
+	ClipID        uuid.UUID `json:"clip_id" db:"clip_id"`
+	BroadcasterID *string   `json:"broadcaster_id,omitempty" db:"broadcaster_id"`
+	Loads         int64     `json:"loads" db:"load_count"`
+	Clicks        int64     `json:"clicks" db:"click_count"`
+	Blocked       int64     `json:"blocked" db:"blocked_count"`
+}
+
+// EmbedSiteStats is the embed traffic of one site over a report's window
+type EmbedSiteStats struct {
+	Domain           string  `json:"domain"`
+	Loads            int64   `json:"loads"`           // Embedded player views
+	Clicks           int64   `json:"clicks"`          // Visits to clpr.tv from the player
+	Blocked          int64   `json:"blocked"`         // Player loads refused by a domain rule
+	Clips            int     `json:"clips,omitempty"` // Distinct clips embedded; not set on totals
+	ClickThroughRate float64 `json:"click_through_rate"`
+}
+
+// EmbedPartnerReport lists the top sites embedding clips and the traffic
+// they drive, for one broadcaster or the whole platform
+type EmbedPartnerReport struct {
+	BroadcasterID *string          `json:"broadcaster_id,omitempty"`
+	Days          int              `json:"days"`
+	From          time.Time        `json:"from"`
+	To            time.Time        `json:"to"`
+	Totals        EmbedSiteStats   `json:"totals"` // Over every site, not just those listed
+	Sites         []EmbedSiteStats `json:"sites"`
+}
+
+// ClipEmbedPageData is the template data for /embed/clips/:id
+type ClipEmbedPageData struct {
+	Title     string
+	PlayerURL string // Twitch clip player
+	OpenURL   string // Counts a click, then opens the clip on clpr.tv
+	Blocked   bool
+}
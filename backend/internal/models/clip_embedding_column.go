@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// DefaultClipEmbeddingColumn is the clips column embeddings were first stored in
+const DefaultClipEmbeddingColumn = "embedding"
+
+// Clip embedding column statuses
+const (
+	ClipEmbeddingColumnBackfilling = "backfilling"
+	ClipEmbeddingColumnReady       = "ready"
+	ClipEmbeddingColumnActive      = "active"
+	ClipEmbeddingColumnRetired     = "retired"
+)
+
+// ClipEmbeddingColumn is a clips column holding embeddings of one model
+type ClipEmbeddingColumn struct {
+	ColumnName   string     `json:"column_name" db:"column_name"`
+	Provider     *string    `json:"provider,omitempty" db:"provider"`
+	Model        string     `json:"model" db:"model"`
+	Dimensions   int        `json:"dimensions" db:"dimensions"`
+	Status       string     `json:"status" db:"status"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	BackfilledAt *time.Time `json:"backfilled_at,omitempty" db:"backfilled_at"`
+	ActivatedAt  *time.Time `json:"activated_at,omitempty" db:"activated_at"`
+	RetiredAt    *time.Time `json:"retired_at,omitempty" db:"retired_at"`
+
+	// Coverage of clips that are not removed
+	Embedded int64 `json:"embedded" db:"-"`
+	Missing  int64 `json:"missing" db:"-"`
+}
+
+// Coverage returns the share of clips with an embedding in the column
+func (c *ClipEmbeddingColumn) Coverage() float64 {
+	total := c.Embedded + c.Missing
+	if total == 0 {
+		return 1
+	}
+	return float64(c.Embedded) / float64(total)
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChatActivityBucket counts the chat messages sent in a broadcaster's channel
+// during a 10-second bucket, and the emotes in them
+type ChatActivityBucket struct {
+	BroadcasterID string    `json:"broadcaster_id" db:"broadcaster_id"`
+	BucketStart   time.Time `json:"bucket_start" db:"bucket_start"`
+	Messages      int       `json:"message_count" db:"message_count"`
+	Emotes        int       `json:"emote_count" db:"emote_count"`
+}
+
+// ClipHypeCandidate is a clip waiting for a hype score whose channel's chat
+// was recorded around the time it was clipped
+type ClipHypeCandidate struct {
+	ClipID        uuid.UUID `db:"id"`
+	BroadcasterID string    `db:"broadcaster_id"`
+	CreatedAt     time.Time `db:"created_at"`
+	Duration      *float64  `db:"duration"`
+}
+
+// ClipHypeScore rates from 0 to 1 how hyped chat was while a clip's moment
+// played: how far chat outpaced its usual rate, amplified by emote use
+type ClipHypeScore struct {
+	ClipID                    uuid.UUID `json:"clip_id" db:"clip_id"`
+	HypeScore                 float64   `json:"hype_score" db:"hype_score"`
+	Messages                  int       `json:"message_count" db:"message_count"`
+	Emotes                    int       `json:"emote_count" db:"emote_count"`
+	MessagesPerMinute         float64   `json:"messages_per_minute" db:"messages_per_minute"`
+	BaselineMessagesPerMinute float64   `json:"baseline_messages_per_minute" db:"baseline_messages_per_minute"`
+	ScoredAt                  time.Time `json:"scored_at" db:"scored_at"`
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Clip ingestion rule targets
+const (
+	ClipIngestionTargetDiscoveryList = "discovery_list" // Curated discovery list
+	ClipIngestionTargetFeed          = "feed"           // Custom feed
+	ClipIngestionTargetCommunity     = "community"      // Community clip feed
+)
+
+// ClipIngestionRule routes synced clips matching all of its conditions into a
+// discovery list, feed or community. Empty condition lists match anything.
+type ClipIngestionRule struct {
+	ID             uuid.UUID              `json:"id" db:"id"`
+	Name           string                 `json:"name" db:"name"`
+	Enabled        bool                   `json:"enabled" db:"enabled"`
+	GameIDs        []string               `json:"game_ids" db:"game_ids"`
+	BroadcasterIDs []string               `json:"broadcaster_ids" db:"broadcaster_ids"`
+	Languages      []string               `json:"languages" db:"languages"`
+	MinViews       *int                   `json:"min_views,omitempty" db:"min_views"`
+	MaxViews       *int                   `json:"max_views,omitempty" db:"max_views"`
+	TargetType     string                 `json:"target_type" db:"target_type"`
+	TargetID       uuid.UUID              `json:"target_id" db:"target_id"`
+	CreatedBy      *uuid.UUID             `json:"created_by,omitempty" db:"created_by"`
+	Stats          ClipIngestionRuleStats `json:"stats"`
+	CreatedAt      time.Time              `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at" db:"updated_at"`
+}
+
+// ClipIngestionRuleStats counts the clips a rule routed
+type ClipIngestionRuleStats struct {
+	MatchCount     int64      `json:"match_count" db:"match_count"`
+	MatchesLast24h int        `json:"matches_last_24h"`
+	MatchesLast7d  int        `json:"matches_last_7d"`
+	LastMatchedAt  *time.Time `json:"last_matched_at,omitempty" db:"last_matched_at"`
+}
+
+// HasConditions reports whether the rule has at least one condition
+func (r *ClipIngestionRule) HasConditions() bool {
+	return len(r.GameIDs) > 0 || len(r.BroadcasterIDs) > 0 || len(r.Languages) > 0 ||
+		r.MinViews != nil || r.MaxViews != nil
+}
+
+// Matches reports whether a clip meets all of the rule's conditions.
+// Languages match case-insensitively, and a rule language without a region
+// matches every region of it.
+func (r *ClipIngestionRule) Matches(clip *Clip) bool {
+	if len(r.GameIDs) > 0 && (clip.GameID == nil || !slices.Contains(r.GameIDs, *clip.GameID)) {
+		return false
+	}
+	if len(r.BroadcasterIDs) > 0 && (clip.BroadcasterID == nil || !slices.Contains(r.BroadcasterIDs, *clip.BroadcasterID)) {
+		return false
+	}
+	if len(r.Languages) > 0 {
+		if clip.Language == nil {
+			return false
+		}
+		language := strings.ToLower(*clip.Language)
+		primary, _, _ := strings.Cut(language, "-")
+		if !slices.Contains(r.Languages, language) && !slices.Contains(r.Languages, primary) {
+			return false
+		}
+	}
+	if r.MinViews != nil && clip.ViewCount < *r.MinViews {
+		return false
+	}
+	if r.MaxViews != nil && clip.ViewCount > *r.MaxViews {
+		return false
+	}
+	return true
+}
+
+// ClipIngestionRuleRequest creates an ingestion rule or replaces an
+// existing rule's settings
+type ClipIngestionRuleRequest struct {
+	Name           string    `json:"name" binding:"required,min=1,max=200"`
+	Enabled        *bool     `json:"enabled,omitempty"`
+	GameIDs        []string  `json:"game_ids,omitempty" binding:"omitempty,max=500,dive,min=1,max=100"`
+	BroadcasterIDs []string  `json:"broadcaster_ids,omitempty" binding:"omitempty,max=1000,dive,min=1,max=100"`
+	Languages      []string  `json:"languages,omitempty" binding:"omitempty,max=50,dive,min=2,max=10"`
+	MinViews       *int      `json:"min_views,omitempty" binding:"omitempty,min=0"`
+	MaxViews       *int      `json:"max_views,omitempty" binding:"omitempty,min=0"`
+	TargetType     string    `json:"target_type" binding:"required,oneof=discovery_list feed community"`
+	TargetID       uuid.UUID `json:"target_id" binding:"required"`
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Clip playback source types, in the order players fall back through them
+const (
+	PlaybackSourceTwitchEmbed = "twitch_embed"
+	PlaybackSourceVideo       = "video"
+	PlaybackSourceThumbnail   = "thumbnail"
+)
+
+// Clip playback event outcomes
+const (
+	PlaybackOutcomeLoaded = "loaded"
+	PlaybackOutcomeFailed = "failed"
+)
+
+// Embed failure reasons reported by players
+const (
+	EmbedFailureRegionRestricted = "region_restricted"
+	EmbedFailureAgeRestricted    = "age_restricted"
+	EmbedFailureUnavailable      = "unavailable"
+	EmbedFailureUnknown          = "unknown"
+)
+
+// ValidEmbedFailureReasons lists the failure reasons players can report
+var ValidEmbedFailureReasons = []string{
+	EmbedFailureRegionRestricted,
+	EmbedFailureAgeRestricted,
+	EmbedFailureUnavailable,
+	EmbedFailureUnknown,
+}
+
+// ClipPlaybackSource is one way of playing a clip. Thumbnail sources link out
+// to Twitch instead of playing inline.
+type ClipPlaybackSource struct {
+	Type         string  `json:"type"`
+	URL          string  `json:"url"`
+	ThumbnailURL *string `json:"thumbnail_url,omitempty"`
+}
+
+// ClipPlaybackConfig lists a clip's playback sources in the order players should try them
+type ClipPlaybackConfig struct {
+	ClipID        uuid.UUID            `json:"clip_id"`
+	Sources       []ClipPlaybackSource `json:"sources"`
+	EmbedDegraded bool                 `json:"embed_degraded"`
+}
+
+// ClipEmbedHealth counts how often a clip's Twitch embed loads or fails for viewers
+type ClipEmbedHealth struct {
+	ClipID            uuid.UUID  `json:"clip_id" db:"clip_id"`
+	EmbedLoads        int64      `json:"embed_loads" db:"embed_loads"`
+	EmbedFailures     int64      `json:"embed_failures" db:"embed_failures"`
+	LastFailureReason *string    `json:"last_failure_reason,omitempty" db:"last_failure_reason"`
+	LastFailureAt     *time.Time `json:"last_failure_at,omitempty" db:"last_failure_at"`
+	UpdatedAt         time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// FailureRate returns the share of reported embed attempts that failed
+func (h *ClipEmbedHealth) FailureRate() float64 {
+	total := h.EmbedLoads + h.EmbedFailures
+	if total == 0 {
+		return 0
+	}
+	return float64(h.EmbedFailures) / float64(total)
+}
+
+// ReportPlaybackEventRequest is sent by players when a playback source loads or fails
+type ReportPlaybackEventRequest struct {
+	Source  string `json:"source" binding:"required"`
+	Outcome string `json:"outcome" binding:"required,oneof=loaded failed"`
+	Reason  string `json:"reason,omitempty"`
+}
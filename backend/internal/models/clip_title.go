@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PreferredTitle returns the normalized display title when the clip has one,
+// otherwise the original Twitch title
+func (c *Clip) PreferredTitle() string {
+	if c.DisplayTitle != nil && *c.DisplayTitle != "" {
+		return *c.DisplayTitle
+	}
+	return c.Title
+}
+
+// BroadcasterTitlePreference records whether a broadcaster's clip titles are normalized
+type BroadcasterTitlePreference struct {
+	BroadcasterID   string    `json:"broadcaster_id" db:"broadcaster_id"`
+	UserID          uuid.UUID `json:"user_id" db:"user_id"`
+	NormalizeTitles bool      `json:"normalize_titles" db:"normalize_titles"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// UpdateBroadcasterTitlePreferenceRequest is the body for a broadcaster opting in or out of title normalization
+type UpdateBroadcasterTitlePreferenceRequest struct {
+	NormalizeTitles bool `json:"normalize_titles"`
+}
+
+// ClipTitle is the slice of a clip the title normalizer reads and writes
+type ClipTitle struct {
+	ID            uuid.UUID `db:"id"`
+	BroadcasterID *string   `db:"broadcaster_id"`
+	Title         string    `db:"title"`
+	DisplayTitle  *string   `db:"display_title"`
+}
+
+// ClipTitleReprocessResult summarizes a pass of the title normalizer over existing clips
+type ClipTitleReprocessResult struct {
+	Scanned int `json:"scanned"`
+	Updated int `json:"updated"`
+	Cleared int `json:"cleared"`
+}
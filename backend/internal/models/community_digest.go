@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CommunityDigestSettings controls a community's weekly digest post. Nil
+// templates use the defaults.
+type CommunityDigestSettings struct {
+	CommunityID   uuid.UUID `json:"community_id" db:"community_id"`
+	Enabled       bool      `json:"enabled" db:"enabled"`
+	TitleTemplate *string   `json:"title_template,omitempty" db:"title_template"`
+	BodyTemplate  *string   `json:"body_template,omitempty" db:"body_template"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// UpdateCommunityDigestSettingsRequest updates a community's digest settings.
+// An empty template resets it to the default.
+type UpdateCommunityDigestSettingsRequest struct {
+	Enabled       *bool   `json:"enabled,omitempty"`
+	TitleTemplate *string `json:"title_template,omitempty" binding:"omitempty,max=500"`
+	BodyTemplate  *string `json:"body_template,omitempty" binding:"omitempty,max=10000"`
+}
+
+// CommunityDigest records the digest discussion posted in a community for a week
+type CommunityDigest struct {
+	ID           uuid.UUID `json:"id" db:"id"`
+	CommunityID  uuid.UUID `json:"community_id" db:"community_id"`
+	DiscussionID uuid.UUID `json:"discussion_id" db:"discussion_id"`
+	WeekStart    time.Time `json:"week_start" db:"week_start"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+// CommunityDigestClip is one of the week's top clips in a community
+type CommunityDigestClip struct {
+	ClipID          uuid.UUID `json:"clip_id" db:"clip_id"`
+	Title           string    `json:"title" db:"title"`
+	BroadcasterName string    `json:"broadcaster_name" db:"broadcaster_name"`
+	VoteScore       int       `json:"vote_score" db:"vote_score"`
+}
+
+// CommunityDigestContributor is one of the week's most active members of a
+// community, counting clips added, discussions started and comments posted
+type CommunityDigestContributor struct {
+	UserID      uuid.UUID `json:"user_id" db:"user_id"`
+	Username    string    `json:"username" db:"username"`
+	Clips       int       `json:"clips" db:"clips"`
+	Discussions int       `json:"discussions" db:"discussions"`
+	Comments    int       `json:"comments" db:"comments"`
+}
+
+// Contributions is the contributor's total activity for the week
+func (c CommunityDigestContributor) Contributions() int {
+	return c.Clips + c.Discussions + c.Comments
+}
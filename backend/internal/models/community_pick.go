@@ -0,0 +1,105 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Community pick round statuses
+const (
+	CommunityPickStatusOpen      = "open"      // Taking nominations and votes
+	CommunityPickStatusPublished = "published" // Top picks were added to the list
+	CommunityPickStatusCancelled = "cancelled" // Closed by an admin without publishing
+)
+
+// CommunityPickRound is a themed nomination and voting window for a
+// discovery list. When voting ends the top N clips are added to the list.
+type CommunityPickRound struct {
+	ID                    uuid.UUID  `json:"id" db:"id"`
+	ListID                uuid.UUID  `json:"list_id" db:"list_id"`
+	Theme                 string     `json:"theme" db:"theme"`
+	Description           *string    `json:"description,omitempty" db:"description"`
+	Status                string     `json:"status" db:"status"`
+	TopN                  int        `json:"top_n" db:"top_n"`
+	MaxNominationsPerUser int        `json:"max_nominations_per_user" db:"max_nominations_per_user"`
+	MaxVotesPerUser       int        `json:"max_votes_per_user" db:"max_votes_per_user"`
+	VotingEndsAt          time.Time  `json:"voting_ends_at" db:"voting_ends_at"`
+	CreatedBy             *uuid.UUID `json:"created_by,omitempty" db:"created_by"`
+	PublishedAt           *time.Time `json:"published_at,omitempty" db:"published_at"`
+	CreatedAt             time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// IsOpenAt reports whether the round takes nominations and votes at t
+func (r *CommunityPickRound) IsOpenAt(t time.Time) bool {
+	return r.Status == CommunityPickStatusOpen && t.Before(r.VotingEndsAt)
+}
+
+// CommunityPickNomination is a clip nominated to a round. Tallies are only
+// filled in once the round is published so open standings can't steer votes.
+type CommunityPickNomination struct {
+	ID              uuid.UUID  `json:"id" db:"id"`
+	RoundID         uuid.UUID  `json:"round_id" db:"round_id"`
+	ClipID          uuid.UUID  `json:"clip_id" db:"clip_id"`
+	NominatedBy     *uuid.UUID `json:"nominated_by,omitempty" db:"nominated_by"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	ClipTitle       string     `json:"clip_title" db:"clip_title"`
+	BroadcasterName string     `json:"broadcaster_name" db:"broadcaster_name"`
+	ThumbnailURL    *string    `json:"thumbnail_url,omitempty" db:"thumbnail_url"`
+	HasVoted        bool       `json:"has_voted"`
+}
+
+// CommunityPickVote is one user's vote for a nomination. Weight discounts
+// votes from accounts that look like brigading.
+type CommunityPickVote struct {
+	ID           uuid.UUID `json:"id" db:"id"`
+	RoundID      uuid.UUID `json:"round_id" db:"round_id"`
+	NominationID uuid.UUID `json:"nomination_id" db:"nomination_id"`
+	UserID       uuid.UUID `json:"user_id" db:"user_id"`
+	Weight       float64   `json:"weight" db:"weight"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+// CommunityPickTally is a nomination's vote totals
+type CommunityPickTally struct {
+	NominationID  uuid.UUID `json:"nomination_id" db:"nomination_id"`
+	ClipID        uuid.UUID `json:"clip_id" db:"clip_id"`
+	VoteCount     int       `json:"vote_count" db:"vote_count"`
+	WeightedScore float64   `json:"weighted_score" db:"weighted_score"`
+	NominatedAt   time.Time `json:"nominated_at" db:"nominated_at"`
+}
+
+// CommunityPickResult is a clip's final standing in a published round
+type CommunityPickResult struct {
+	RoundID         uuid.UUID `json:"round_id" db:"round_id"`
+	ClipID          uuid.UUID `json:"clip_id" db:"clip_id"`
+	Rank            int       `json:"rank" db:"rank"`
+	VoteCount       int       `json:"vote_count" db:"vote_count"`
+	WeightedScore   float64   `json:"weighted_score" db:"weighted_score"`
+	Picked          bool      `json:"picked" db:"picked"`
+	ClipTitle       string    `json:"clip_title" db:"clip_title"`
+	BroadcasterName string    `json:"broadcaster_name" db:"broadcaster_name"`
+	ThumbnailURL    *string   `json:"thumbnail_url,omitempty" db:"thumbnail_url"`
+}
+
+// CommunityPickRoundDetail is a round with its nominations
+type CommunityPickRoundDetail struct {
+	CommunityPickRound
+	Nominations []CommunityPickNomination `json:"nominations"`
+}
+
+// CreateCommunityPickRoundRequest starts a community pick round for a list
+type CreateCommunityPickRoundRequest struct {
+	Theme                 string    `json:"theme" binding:"required,min=1,max=200"`
+	Description           *string   `json:"description,omitempty" binding:"omitempty,max=1000"`
+	VotingEndsAt          time.Time `json:"voting_ends_at" binding:"required"`
+	TopN                  *int      `json:"top_n,omitempty" binding:"omitempty,min=1,max=100"`
+	MaxNominationsPerUser *int      `json:"max_nominations_per_user,omitempty" binding:"omitempty,min=1,max=20"`
+	MaxVotesPerUser       *int      `json:"max_votes_per_user,omitempty" binding:"omitempty,min=1,max=50"`
+}
+
+// NominateCommunityPickRequest nominates a clip to a round
+type NominateCommunityPickRequest struct {
+	ClipID uuid.UUID `json:"clip_id" binding:"required"`
+}
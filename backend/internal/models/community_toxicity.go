@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Toxicity sensitivities a community can set for comments on its clips
+const (
+	ToxicitySensitivityOff    = "off"    // Comments aren't auto-flagged
+	ToxicitySensitivityLow    = "low"    // Only the most toxic comments are flagged
+	ToxicitySensitivityMedium = "medium" // The site-wide threshold
+	ToxicitySensitivityHigh   = "high"   // Borderline comments are flagged too
+)
+
+// CommunityToxicitySettings is how sensitive toxicity flagging is for comments
+// on a community's clips
+type CommunityToxicitySettings struct {
+	CommunityID uuid.UUID  `json:"community_id" db:"community_id"`
+	Sensitivity string     `json:"sensitivity" db:"sensitivity"`
+	Threshold   *float64   `json:"threshold,omitempty" db:"-"` // Nil when flagging is off
+	UpdatedBy   *uuid.UUID `json:"updated_by,omitempty" db:"updated_by"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// UpdateCommunityToxicitySettingsRequest sets a community's toxicity sensitivity
+type UpdateCommunityToxicitySettingsRequest struct {
+	Sensitivity string `json:"sensitivity" binding:"required,oneof=off low medium high"`
+}
+
+// ToxicityAssessment is a comment's toxicity score as shown to moderators
+type ToxicityAssessment struct {
+	ConfidenceScore float64            `json:"confidence_score"`
+	Threshold       float64            `json:"threshold"`
+	Categories      map[string]float64 `json:"categories"`
+	ReasonCodes     []string           `json:"reason_codes"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Creator claim status constants
+const (
+	CreatorClaimStatusPending    = "pending"    // Waiting for the code to appear in the channel description
+	CreatorClaimStatusVerified   = "verified"   // Attributes the creator's clips to the user
+	CreatorClaimStatusExpired    = "expired"    // Not verified in time, or replaced by a newer claim
+	CreatorClaimStatusSuperseded = "superseded" // Verified, then another user verified the same creator
+)
+
+// CreatorClaim is a user's claim to be the Twitch creator of clips. The user
+// proves control of the channel by putting the verification code in its
+// Twitch description; once verified, the creator's clips are attributed to
+// the user as if their Twitch account were linked.
+type CreatorClaim struct {
+	ID               uuid.UUID  `json:"id" db:"id"`
+	UserID           uuid.UUID  `json:"user_id" db:"user_id"`
+	TwitchCreatorID  string     `json:"twitch_creator_id" db:"twitch_creator_id"`
+	TwitchLogin      string     `json:"twitch_login" db:"twitch_login"`
+	VerificationCode string     `json:"verification_code" db:"verification_code"`
+	Status           string     `json:"status" db:"status"`
+	ExpiresAt        time.Time  `json:"expires_at" db:"expires_at"`
+	VerifiedAt       *time.Time `json:"verified_at,omitempty" db:"verified_at"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// CreateCreatorClaimRequest starts a claim to a Twitch channel's clips
+type CreateCreatorClaimRequest struct {
+	TwitchLogin string `json:"twitch_login" binding:"required,min=1,max=100"`
+}
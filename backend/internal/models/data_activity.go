@@ -0,0 +1,83 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of data export listed in a user's data activity
+const (
+	DataExportKindAccountData      = "account_data"      // GET /users/me/export archive
+	DataExportKindCreatorAnalytics = "creator_analytics" // Creator clip analytics export
+)
+
+// DataActivity summarizes the processing that has happened on a user's data,
+// answering a GDPR Article 15 access request
+type DataActivity struct {
+	UserID            uuid.UUID                     `json:"user_id"`
+	GeneratedAt       time.Time                     `json:"generated_at"`
+	Emails            DataActivityEmails            `json:"emails"`
+	Exports           []DataActivityExport          `json:"exports"`
+	Analytics         []DataActivityCategory        `json:"analytics"`
+	Consent           *DataActivityConsent          `json:"consent"`
+	AdPersonalization DataActivityAdPersonalization `json:"ad_personalization"`
+	AccountEvents     []DataActivityEvent           `json:"account_events"`
+}
+
+// DataActivityEmails counts the emails sent to the user
+type DataActivityEmails struct {
+	Total      int                     `json:"total"`
+	LastSentAt *time.Time              `json:"last_sent_at,omitempty"`
+	ByType     []DataActivityEmailType `json:"by_type"`
+}
+
+// DataActivityEmailType counts the emails of one notification type sent to the user
+type DataActivityEmailType struct {
+	Type       string    `json:"type"`
+	Count      int       `json:"count"`
+	LastSentAt time.Time `json:"last_sent_at"`
+}
+
+// DataActivityExport is a copy of the user's data that was generated
+type DataActivityExport struct {
+	Kind        string     `json:"kind"`
+	Format      string     `json:"format"`
+	Status      string     `json:"status"`
+	RequestedAt time.Time  `json:"requested_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// DataActivityCategory is a category of analytics data collected about the user
+type DataActivityCategory struct {
+	Category         string    `json:"category"`
+	Description      string    `json:"description"`
+	Records          int       `json:"records"`
+	FirstCollectedAt time.Time `json:"first_collected_at"`
+	LastCollectedAt  time.Time `json:"last_collected_at"`
+}
+
+// DataActivityConsent is the user's current cookie consent
+type DataActivityConsent struct {
+	Functional  bool      `json:"functional"`
+	Analytics   bool      `json:"analytics"`
+	Advertising bool      `json:"advertising"`
+	ConsentedAt time.Time `json:"consented_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	Expired     bool      `json:"expired"`
+}
+
+// DataActivityAdPersonalization reports whether ads are personalized for the
+// user and how many ads were shown to them
+type DataActivityAdPersonalization struct {
+	Enabled          bool       `json:"enabled"`
+	Basis            string     `json:"basis"`
+	Impressions      int        `json:"impressions"`
+	LastImpressionAt *time.Time `json:"last_impression_at,omitempty"`
+}
+
+// DataActivityEvent is an action the user took on their account or data
+type DataActivityEvent struct {
+	Action     string    `json:"action"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
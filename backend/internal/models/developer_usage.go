@@ -0,0 +1,82 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeveloperUsageGranularity is the bucket size of developer usage reports
+const DeveloperUsageGranularity = "hour"
+
+// DeveloperUsageWindows maps the selectable report windows to their length
+var DeveloperUsageWindows = map[string]time.Duration{
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+	"30d": 30 * 24 * time.Hour,
+}
+
+// APIKeyUsageBucket holds one hour of usage for an API key.
+// Errors count 4xx and 5xx responses other than rate-limited requests.
+type APIKeyUsageBucket struct {
+	APIKeyID    uuid.UUID `json:"-" db:"api_key_id"`
+	Hour        time.Time `json:"hour" db:"hour"`
+	Requests    int64     `json:"requests" db:"request_count"`
+	Errors      int64     `json:"errors" db:"error_count"`
+	RateLimited int64     `json:"rate_limited" db:"rate_limited_count"`
+}
+
+// APIKeyUsage summarises an API key's usage over a report window
+type APIKeyUsage struct {
+	APIKeyID    uuid.UUID           `json:"api_key_id"`
+	Name        string              `json:"name"`
+	KeyPrefix   string              `json:"key_prefix"`
+	Requests    int64               `json:"requests"`
+	Errors      int64               `json:"errors"`
+	RateLimited int64               `json:"rate_limited"`
+	ErrorRate   float64             `json:"error_rate"`
+	Hourly      []APIKeyUsageBucket `json:"hourly"`
+}
+
+// WebhookDeliveryBucket holds one hour of deliveries for a webhook subscription
+type WebhookDeliveryBucket struct {
+	SubscriptionID uuid.UUID `json:"-" db:"subscription_id"`
+	Hour           time.Time `json:"hour" db:"hour"`
+	Total          int64     `json:"total" db:"total"`
+	Delivered      int64     `json:"delivered" db:"delivered"`
+	Failed         int64     `json:"failed" db:"failed"`
+	Pending        int64     `json:"pending" db:"pending"`
+}
+
+// WebhookDeliveryUsage summarises a webhook subscription's deliveries over a report window
+type WebhookDeliveryUsage struct {
+	SubscriptionID uuid.UUID               `json:"subscription_id"`
+	URL            string                  `json:"url"`
+	Total          int64                   `json:"total"`
+	Delivered      int64                   `json:"delivered"`
+	Failed         int64                   `json:"failed"`
+	Pending        int64                   `json:"pending"`
+	SuccessRate    float64                 `json:"success_rate"`
+	Hourly         []WebhookDeliveryBucket `json:"hourly"`
+}
+
+// DeveloperUsageTotals sums usage across all of a developer's keys and webhooks
+type DeveloperUsageTotals struct {
+	Requests          int64   `json:"requests"`
+	Errors            int64   `json:"errors"`
+	RateLimited       int64   `json:"rate_limited"`
+	ErrorRate         float64 `json:"error_rate"`
+	WebhookDeliveries int64   `json:"webhook_deliveries"`
+	WebhookFailures   int64   `json:"webhook_failures"`
+}
+
+// DeveloperUsage is a developer's API key and webhook usage over a report window
+type DeveloperUsage struct {
+	Window      string                 `json:"window"`
+	Granularity string                 `json:"granularity"`
+	From        time.Time              `json:"from"`
+	To          time.Time              `json:"to"`
+	Totals      DeveloperUsageTotals   `json:"totals"`
+	APIKeys     []APIKeyUsage          `json:"api_keys"`
+	Webhooks    []WebhookDeliveryUsage `json:"webhooks"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Email UTM constants applied to every first-party link in outgoing email
+const (
+	EmailUTMSource = "clipper"
+	EmailUTMMedium = "email"
+)
+
+// EmailClickEventType marks email_logs rows recorded by the first-party redirect
+const EmailClickEventType = "first_party_click"
+
+// EmailTrackedLink is a link rewritten into an email and served through /r/:token
+type EmailTrackedLink struct {
+	ID                     uuid.UUID  `json:"id" db:"id"`
+	Token                  string     `json:"token" db:"token"`
+	EmailNotificationLogID *uuid.UUID `json:"email_notification_log_id,omitempty" db:"email_notification_log_id"`
+	UserID                 *uuid.UUID `json:"user_id,omitempty" db:"user_id"`
+	Recipient              string     `json:"recipient" db:"recipient"`
+	Template               string     `json:"template" db:"template"`
+	TargetURL              string     `json:"target_url" db:"target_url"`
+	ClickCount             int        `json:"click_count" db:"click_count"`
+	FirstClickedAt         *time.Time `json:"first_clicked_at,omitempty" db:"first_clicked_at"`
+	LastClickedAt          *time.Time `json:"last_clicked_at,omitempty" db:"last_clicked_at"`
+	ExpiresAt              time.Time  `json:"expires_at" db:"expires_at"`
+	CreatedAt              time.Time  `json:"created_at" db:"created_at"`
+}
+
+// EmailTemplateAttribution holds click and follow-on engagement totals for one email template
+type EmailTemplateAttribution struct {
+	Template       string  `json:"template" db:"template"`
+	Clicks         int     `json:"clicks" db:"clicks"`
+	UniqueClickers int     `json:"unique_clickers" db:"unique_clickers"`
+	EngagedUsers   int     `json:"engaged_users" db:"engaged_users"`
+	EngagementRate float64 `json:"engagement_rate"`
+}
+
+// EmailClickAttribution joins first-party email clicks to on-site engagement that followed them
+type EmailClickAttribution struct {
+	PeriodDays       int                        `json:"period_days"`
+	AttributionHours int                        `json:"attribution_hours"`
+	TotalClicks      int                        `json:"total_clicks"`
+	UniqueClickers   int                        `json:"unique_clickers"`
+	EngagedUsers     int                        `json:"engaged_users"`
+	Templates        []EmailTemplateAttribution `json:"templates"`
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailSendWindow is the local hours, [StartHour, EndHour), in which bulk
+// email may reach recipients in a time zone. Equal hours allow the whole day,
+// and a start after the end wraps past midnight.
+type EmailSendWindow struct {
+	Timezone  string `json:"timezone"`
+	StartHour int    `json:"start_hour"`
+	EndHour   int    `json:"end_hour"`
+}
+
+// EmailThrottleSettings controls how fast bulk email (re-engagement emails
+// and notification digests) is sent. Workers reload them every 30 seconds,
+// so changes apply live.
+type EmailThrottleSettings struct {
+	// Paused stops bulk sends; due emails wait until sends resume
+	Paused bool `json:"paused" db:"paused"`
+	// MaxPerMinute caps bulk emails sent per minute across all instances
+	MaxPerMinute int `json:"max_per_minute" db:"max_per_minute"`
+	// RampStartPerMinute is the cap when sending starts after being idle for
+	// RampMinutes. It rises linearly to MaxPerMinute over RampMinutes.
+	RampStartPerMinute int `json:"ramp_start_per_minute" db:"ramp_start_per_minute"`
+	RampMinutes        int `json:"ramp_minutes" db:"ramp_minutes"`
+	// DefaultWindow applies to recipients whose time zone has no window of
+	// its own. Its Timezone is used for recipients who haven't set one.
+	DefaultWindow EmailSendWindow   `json:"default_window" db:"default_window"`
+	Windows       []EmailSendWindow `json:"windows" db:"windows"`
+	UpdatedBy     *uuid.UUID        `json:"updated_by,omitempty" db:"updated_by"`
+	UpdatedAt     time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+// EmailThrottleStatus is the throttle's settings and what it allows right now
+type EmailThrottleStatus struct {
+	Settings EmailThrottleSettings `json:"settings"`
+	// CurrentPerMinute is the cap now, below MaxPerMinute while ramping up
+	CurrentPerMinute int `json:"current_per_minute"`
+	// SentThisMinute counts bulk emails let through in the current minute
+	SentThisMinute int64 `json:"sent_this_minute"`
+	// RampStartedAt is when the current run of sends began, if one is going
+	RampStartedAt *time.Time `json:"ramp_started_at,omitempty"`
+}
+
+// UpdateEmailThrottleRequest replaces the bulk email throttle settings
+type UpdateEmailThrottleRequest struct {
+	Paused             bool              `json:"paused"`
+	MaxPerMinute       int               `json:"max_per_minute" binding:"min=1,max=100000"`
+	RampStartPerMinute int               `json:"ramp_start_per_minute" binding:"min=0,max=100000"`
+	RampMinutes        int               `json:"ramp_minutes" binding:"min=0,max=10080"`
+	DefaultWindow      EmailSendWindow   `json:"default_window"`
+	Windows            []EmailSendWindow `json:"windows" binding:"max=100"`
+}
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Well-known feature flag keys. Code that checks one of these passes a
+// fallback, so a flag that hasn't been created keeps today's behavior.
+const (
+	FeatureFlagHybridSearch = "search.hybrid" // Serve search from hybrid BM25 + vector search when available
+)
+
+// Feature flag rule attributes
+const (
+	FeatureFlagAttributeUserID      = "user_id"
+	FeatureFlagAttributeRole        = "role"
+	FeatureFlagAttributeAccountType = "account_type"
+)
+
+// Feature flag rule operators
+const (
+	FeatureFlagOperatorIn    = "in"
+	FeatureFlagOperatorNotIn = "not_in"
+)
+
+// FeatureFlag gates a piece of functionality at runtime. A disabled flag is
+// off for everyone. An enabled flag checks its rules in order, and the first
+// matching rule decides; users no rule matched are bucketed into the rollout
+// percentage by a stable hash of the flag key and their ID.
+type FeatureFlag struct {
+	ID                uuid.UUID         `json:"id" db:"id"`
+	Key               string            `json:"key" db:"key"`
+	Description       *string           `json:"description,omitempty" db:"description"`
+	Enabled           bool              `json:"enabled" db:"enabled"`
+	RolloutPercentage int               `json:"rollout_percentage" db:"rollout_percentage"`
+	Rules             []FeatureFlagRule `json:"rules" db:"rules"`
+	CreatedBy         *uuid.UUID        `json:"created_by,omitempty" db:"created_by"`
+	UpdatedBy         *uuid.UUID        `json:"updated_by,omitempty" db:"updated_by"`
+	CreatedAt         time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+// FeatureFlagRule targets users by an attribute. When the rule matches, the
+// flag evaluates to Serve.
+type FeatureFlagRule struct {
+	Attribute string   `json:"attribute"` // user_id, role, account_type
+	Operator  string   `json:"operator"`  // in, not_in
+	Values    []string `json:"values"`
+	Serve     bool     `json:"serve"`
+}
+
+// FeatureFlagSubject is who a flag is evaluated for. A nil subject is an
+// anonymous visitor.
+type FeatureFlagSubject struct {
+	UserID      *uuid.UUID
+	Role        string
+	AccountType string
+}
+
+// NewFeatureFlagSubject returns the subject for a user, or nil for a visitor
+func NewFeatureFlagSubject(user *User) *FeatureFlagSubject {
+	if user == nil {
+		return nil
+	}
+	return &FeatureFlagSubject{
+		UserID:      &user.ID,
+		Role:        user.Role,
+		AccountType: user.AccountType,
+	}
+}
+
+// FeatureFlagEvaluation is the outcome of evaluating a flag and why
+type FeatureFlagEvaluation struct {
+	Key     string `json:"key"`
+	Enabled bool   `json:"enabled"`
+	Reason  string `json:"reason"` // not_found, disabled, rule, rollout
+	Rule    *int   `json:"rule,omitempty"`
+}
+
+// CreateFeatureFlagRequest represents the request to create a feature flag
+type CreateFeatureFlagRequest struct {
+	Key               string            `json:"key" binding:"required,min=1,max=100"`
+	Description       *string           `json:"description,omitempty" binding:"omitempty,max=500"`
+	Enabled           bool              `json:"enabled"`
+	RolloutPercentage *int              `json:"rollout_percentage,omitempty" binding:"omitempty,min=0,max=100"`
+	Rules             []FeatureFlagRule `json:"rules,omitempty"`
+}
+
+// UpdateFeatureFlagRequest represents the request to change a feature flag.
+// Omitted fields are left as they are; Rules replaces the whole rule list.
+type UpdateFeatureFlagRequest struct {
+	Description       *string            `json:"description,omitempty" binding:"omitempty,max=500"`
+	Enabled           *bool              `json:"enabled,omitempty"`
+	RolloutPercentage *int               `json:"rollout_percentage,omitempty" binding:"omitempty,min=0,max=100"`
+	Rules             *[]FeatureFlagRule `json:"rules,omitempty"`
+}
+
+// EvaluateFeatureFlagRequest asks how a flag evaluates for a user, or for an
+// anonymous visitor when UserID is omitted
+type EvaluateFeatureFlagRequest struct {
+	UserID *uuid.UUID `json:"user_id,omitempty"`
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Feed collaborator roles: what a co-curator may do to a feed
+const (
+	FeedRoleAddClips     = "add_clips"
+	FeedRoleRemoveClips  = "remove_clips"
+	FeedRoleReorderClips = "reorder_clips"
+	FeedRoleEditMetadata = "edit_metadata"
+)
+
+// FeedRoles are all feed collaborator roles
+var FeedRoles = []string{FeedRoleAddClips, FeedRoleRemoveClips, FeedRoleReorderClips, FeedRoleEditMetadata}
+
+// Feed collaborator invite statuses
+const (
+	FeedCollaboratorPending  = "pending"
+	FeedCollaboratorAccepted = "accepted"
+)
+
+// FeedCollaborator is a user invited to co-curate a feed
+type FeedCollaborator struct {
+	FeedID     uuid.UUID          `json:"feed_id" db:"feed_id"`
+	UserID     uuid.UUID          `json:"user_id" db:"user_id"`
+	User       *ClipSubmitterInfo `json:"user,omitempty"`
+	InvitedBy  *uuid.UUID         `json:"invited_by,omitempty" db:"invited_by"`
+	Roles      []string           `json:"roles" db:"roles"`
+	Status     string             `json:"status" db:"status"`
+	InvitedAt  time.Time          `json:"invited_at" db:"invited_at"`
+	AcceptedAt *time.Time         `json:"accepted_at,omitempty" db:"accepted_at"`
+	UpdatedAt  time.Time          `json:"updated_at" db:"updated_at"`
+}
+
+// Can reports whether the collaborator has accepted the invite and has the role
+func (c *FeedCollaborator) Can(role string) bool {
+	if c.Status != FeedCollaboratorAccepted {
+		return false
+	}
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// FeedCollaboration is a feed a user was invited to co-curate
+type FeedCollaboration struct {
+	FeedCollaborator
+	Feed *Feed `json:"feed"`
+}
+
+// InviteFeedCollaboratorRequest invites a user to co-curate a feed
+type InviteFeedCollaboratorRequest struct {
+	UserID uuid.UUID `json:"user_id" binding:"required"`
+	Roles  []string  `json:"roles" binding:"required,min=1,max=4,dive,oneof=add_clips remove_clips reorder_clips edit_metadata"`
+}
+
+// UpdateFeedCollaboratorRequest replaces a collaborator's roles
+type UpdateFeedCollaboratorRequest struct {
+	Roles []string `json:"roles" binding:"required,min=1,max=4,dive,oneof=add_clips remove_clips reorder_clips edit_metadata"`
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Game patch sources
+const (
+	GamePatchSourceAdmin = "admin"
+	GamePatchSourceIGDB  = "igdb"
+)
+
+// GamePatch is a patch (version) of a game. It is in effect from ReleasedAt
+// until the game's next patch. Clips are tagged with the patch in effect when
+// they were created on Twitch.
+type GamePatch struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	GameID     uuid.UUID  `json:"game_id" db:"game_id"`
+	Version    string     `json:"version" db:"version"`
+	Name       *string    `json:"name,omitempty" db:"name"`
+	NotesURL   *string    `json:"notes_url,omitempty" db:"notes_url"`
+	ReleasedAt time.Time  `json:"released_at" db:"released_at"`
+	EndedAt    *time.Time `json:"ended_at,omitempty" db:"-"` // Release of the next patch, unset for the current one
+	IsCurrent  bool       `json:"is_current" db:"-"`
+	Source     string     `json:"source" db:"source"`
+	IGDBID     *string    `json:"igdb_id,omitempty" db:"igdb_id"`
+	ClipCount  int        `json:"clip_count" db:"-"`
+	CreatedBy  *uuid.UUID `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// GamePatchWindow is when a patch was in effect for a Twitch game, used to
+// filter search results by patch
+type GamePatchWindow struct {
+	TwitchGameID string
+	From         time.Time
+	Until        *time.Time // Exclusive, unset for the current patch
+}
+
+// CreateGamePatchRequest records a patch of a game. IGDB-sourced patches are
+// matched by version, so importing one again updates it.
+type CreateGamePatchRequest struct {
+	Version    string    `json:"version" binding:"required,min=1,max=50"`
+	Name       *string   `json:"name" binding:"omitempty,max=200"`
+	NotesURL   *string   `json:"notes_url" binding:"omitempty,url"`
+	ReleasedAt time.Time `json:"released_at" binding:"required"`
+	Source     string    `json:"source" binding:"omitempty,oneof=admin igdb"`
+	IGDBID     *string   `json:"igdb_id" binding:"omitempty,max=50"`
+}
+
+// UpdateGamePatchRequest changes a patch. Unset fields are kept.
+type UpdateGamePatchRequest struct {
+	Version    *string    `json:"version" binding:"omitempty,min=1,max=50"`
+	Name       *string    `json:"name" binding:"omitempty,max=200"`
+	NotesURL   *string    `json:"notes_url" binding:"omitempty,url"`
+	ReleasedAt *time.Time `json:"released_at"`
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of targets whose health is recorded
+const (
+	HealthTargetDependency    = "dependency"     // Database, Redis, OpenSearch
+	HealthTargetEndpointGroup = "endpoint_group" // API routes sharing their first path segment
+)
+
+// HealthProbeResult is one probe of a dependency, or one interval of traffic
+// served by an endpoint group
+type HealthProbeResult struct {
+	ID           uuid.UUID `json:"id" db:"id"`
+	TargetType   string    `json:"target_type" db:"target_type"`
+	Target       string    `json:"target" db:"target"`
+	Healthy      bool      `json:"healthy" db:"healthy"`
+	LatencyMs    *int      `json:"latency_ms,omitempty" db:"latency_ms"` // Dependencies only
+	Requests     int       `json:"requests" db:"requests"`               // Endpoint groups only
+	ServerErrors int       `json:"server_errors" db:"server_errors"`     // Endpoint groups only
+	Error        *string   `json:"error,omitempty" db:"error"`
+	CheckedAt    time.Time `json:"checked_at" db:"checked_at"`
+}
+
+// HealthUptime is the share of a target's probes that found it healthy over
+// the last 24 hours, 7 days and 30 days, as percentages. A window without
+// probes has no uptime.
+type HealthUptime struct {
+	TargetType    string    `json:"target_type" db:"target_type"`
+	Target        string    `json:"target" db:"target"`
+	Uptime24h     *float64  `json:"uptime_24h" db:"uptime_24h"`
+	Uptime7d      *float64  `json:"uptime_7d" db:"uptime_7d"`
+	Uptime30d     *float64  `json:"uptime_30d" db:"uptime_30d"`
+	LastCheckedAt time.Time `json:"last_checked_at" db:"last_checked_at"`
+}
+
+// HealthProbeCounts counts a target's probes over a period
+type HealthProbeCounts struct {
+	TargetType string `db:"target_type"`
+	Target     string `db:"target"`
+	Probes     int    `db:"probes"`
+	Failed     int    `db:"failed"`
+}
+
+// HealthSLATarget is one target's uptime against the SLA over a report period
+type HealthSLATarget struct {
+	TargetType    string  `json:"target_type"`
+	Target        string  `json:"target"`
+	Probes        int     `json:"probes"`
+	FailedProbes  int     `json:"failed_probes"`
+	UptimePercent float64 `json:"uptime_percent"`
+	// ErrorBudgetUsed is the share of failures the SLA allows that were
+	// used, as a percentage; above 100 the SLA was missed
+	ErrorBudgetUsed float64 `json:"error_budget_used"`
+	Met             bool    `json:"met"`
+}
+
+// HealthSLAReport reports SLA attainment of every dependency and endpoint
+// group probed during a period
+type HealthSLAReport struct {
+	From          time.Time         `json:"from"`
+	To            time.Time         `json:"to"`
+	TargetPercent float64           `json:"target_percent"`
+	Targets       []HealthSLATarget `json:"targets"`
+	Attained      bool              `json:"attained"` // Whether every target met the SLA
+}
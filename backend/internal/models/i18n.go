@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// I18nLocale is a locale the UI string catalog is served in
+type I18nLocale struct {
+	Code           string    `json:"code" db:"code"`
+	Name           string    `json:"name" db:"name"`
+	FallbackLocale *string   `json:"fallback_locale,omitempty" db:"fallback_locale"`
+	IsEnabled      bool      `json:"is_enabled" db:"is_enabled"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// I18nString is a single translated string
+type I18nString struct {
+	Locale    string     `json:"locale" db:"locale"`
+	Key       string     `json:"key" db:"key"`
+	Value     string     `json:"value" db:"value"`
+	UpdatedBy *uuid.UUID `json:"updated_by,omitempty" db:"updated_by"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// I18nMissingKey is a key clients reported as untranslated for a locale
+type I18nMissingKey struct {
+	Locale      string    `json:"locale" db:"locale"`
+	Key         string    `json:"key" db:"key"`
+	Occurrences int64     `json:"occurrences" db:"occurrences"`
+	FirstSeenAt time.Time `json:"first_seen_at" db:"first_seen_at"`
+	LastSeenAt  time.Time `json:"last_seen_at" db:"last_seen_at"`
+}
+
+// I18nCatalog is a locale's strings merged with its fallback chain. Version
+// changes whenever any string in the chain does and is served as the ETag.
+type I18nCatalog struct {
+	Locale    string            `json:"locale"`
+	Fallbacks []string          `json:"fallbacks"`
+	Version   string            `json:"version"`
+	Strings   map[string]string `json:"strings"`
+}
+
+// UpsertI18nLocaleRequest creates or updates a locale
+type UpsertI18nLocaleRequest struct {
+	Name           string  `json:"name" binding:"required,max=100"`
+	FallbackLocale *string `json:"fallback_locale,omitempty"`
+	IsEnabled      *bool   `json:"is_enabled,omitempty"`
+}
+
+// ReportMissingI18nKeysRequest reports keys a client could not resolve
+type ReportMissingI18nKeysRequest struct {
+	Keys []string `json:"keys" binding:"required,min=1,max=100"`
+}
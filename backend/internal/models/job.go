@@ -0,0 +1,34 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Job statuses
+const (
+	JobStatusPending   = "pending"
+	JobStatusRunning   = "running"
+	JobStatusCompleted = "completed"
+	JobStatusFailed    = "failed" // Out of attempts
+)
+
+// Job is a unit of background work in the persistent job queue
+type Job struct {
+	ID          uuid.UUID       `json:"id" db:"id"`
+	Kind        string          `json:"kind" db:"kind"`
+	Payload     json.RawMessage `json:"payload" db:"payload"`
+	Status      string          `json:"status" db:"status"`
+	Attempts    int             `json:"attempts" db:"attempts"`
+	MaxAttempts int             `json:"max_attempts" db:"max_attempts"`
+	RunAt       time.Time       `json:"run_at" db:"run_at"`
+	UniqueKey   *string         `json:"unique_key,omitempty" db:"unique_key"` // At most one pending or running job per key
+	LockedBy    *string         `json:"locked_by,omitempty" db:"locked_by"`
+	LockedAt    *time.Time      `json:"locked_at,omitempty" db:"locked_at"`
+	LastError   *string         `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at" db:"updated_at"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty" db:"completed_at"`
+}
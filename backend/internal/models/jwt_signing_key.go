@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// JWTSigningKey is a stored RSA key used to sign and verify JWTs
+type JWTSigningKey struct {
+	KID                 string     `json:"kid" db:"kid"`
+	PrivateKeyEncrypted string     `json:"-" db:"private_key_encrypted"`
+	ActivatesAt         time.Time  `json:"activates_at" db:"activates_at"`
+	ExpiresAt           *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	CreatedAt           time.Time  `json:"created_at" db:"created_at"`
+}
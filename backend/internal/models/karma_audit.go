@@ -0,0 +1,37 @@
+package models
+
+import "github.com/google/uuid"
+
+// KarmaSourceReconciliation is the karma history source of audit corrections
+const KarmaSourceReconciliation = "reconciliation"
+
+// KarmaAuditEntry holds a user's stored karma alongside the source events
+// it should be derived from
+type KarmaAuditEntry struct {
+	UserID           uuid.UUID `json:"user_id"`
+	Username         string    `json:"username"`
+	CurrentKarma     int       `json:"current_karma"`
+	ClipVoteKarma    int       `json:"clip_vote_karma"`
+	CommentVoteKarma int       `json:"comment_vote_karma"`
+	Comments         int       `json:"comments"`
+	DecayKarma       int       `json:"decay_karma"` // Karma lost to inactivity decay, negative
+}
+
+// KarmaDiscrepancy is a user whose stored karma differs from the recomputed value
+type KarmaDiscrepancy struct {
+	UserID        uuid.UUID `json:"user_id"`
+	Username      string    `json:"username"`
+	CurrentKarma  int       `json:"current_karma"`
+	ExpectedKarma int       `json:"expected_karma"`
+	Difference    int       `json:"difference"`
+	Applied       bool      `json:"applied"`
+}
+
+// KarmaAuditReport summarizes a karma audit run
+type KarmaAuditReport struct {
+	UsersAudited  int                `json:"users_audited"`
+	Tolerance     int                `json:"tolerance"`
+	Applied       bool               `json:"applied"`
+	Corrected     int                `json:"corrected"`
+	Discrepancies []KarmaDiscrepancy `json:"discrepancies"`
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Events that award karma through karma rules
+const (
+	KarmaEventCommentCreated     = "comment_created"     // Source: the comment
+	KarmaEventSubmissionApproved = "submission_approved" // Source: the submission
+	KarmaEventSubmissionRejected = "submission_rejected" // Source: the submission
+	KarmaEventClipClaimed        = "clip_claimed"        // Source: the claimed clip
+)
+
+// KarmaRule sets the karma an event awards. A daily cap bounds the positive
+// karma a user earns from the event per UTC day, and a cooldown spaces out
+// awards to the same user.
+type KarmaRule struct {
+	EventType       string     `json:"event_type" db:"event_type"`
+	Points          int        `json:"points" db:"points"`
+	DailyCap        *int       `json:"daily_cap,omitempty" db:"daily_cap"`
+	CooldownSeconds int        `json:"cooldown_seconds" db:"cooldown_seconds"`
+	Enabled         bool       `json:"enabled" db:"enabled"`
+	Description     *string    `json:"description,omitempty" db:"description"`
+	UpdatedBy       *uuid.UUID `json:"updated_by,omitempty" db:"updated_by"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// PointsFor returns the karma the rule awards a user who has already earned
+// awardedToday from the event today and was last awarded at lastAwardedAt
+func (r *KarmaRule) PointsFor(awardedToday int, lastAwardedAt *time.Time, now time.Time) int {
+	if !r.Enabled || r.Points == 0 {
+		return 0
+	}
+	if r.CooldownSeconds > 0 && lastAwardedAt != nil &&
+		now.Sub(*lastAwardedAt) < time.Duration(r.CooldownSeconds)*time.Second {
+		return 0
+	}
+	if r.Points > 0 && r.DailyCap != nil {
+		return max(0, min(r.Points, *r.DailyCap-awardedToday))
+	}
+	return r.Points
+}
+
+// KarmaRuleRequest creates a karma rule or replaces an existing rule's settings
+type KarmaRuleRequest struct {
+	EventType       string  `json:"event_type,omitempty" binding:"omitempty,min=2,max=50"` // Required when creating
+	Points          int     `json:"points" binding:"min=-1000,max=1000"`
+	DailyCap        *int    `json:"daily_cap,omitempty" binding:"omitempty,min=1,max=100000"`
+	CooldownSeconds int     `json:"cooldown_seconds" binding:"min=0,max=604800"`
+	Enabled         *bool   `json:"enabled,omitempty"`
+	Description     *string `json:"description,omitempty" binding:"omitempty,max=500"`
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// KarmaSourceInactivityDecay is the karma history source of inactivity decay
+const KarmaSourceInactivityDecay = "inactivity_decay"
+
+// KarmaSourceVoteRingReversal is the karma history source of karma reversed
+// from a confirmed vote ring
+const KarmaSourceVoteRingReversal = "vote_ring_reversal"
+
+// Review states of a vote ring
+const (
+	VoteRingPending   = "pending"   // Awaiting review
+	VoteRingConfirmed = "confirmed" // The members were found to be farming karma
+	VoteRingDismissed = "dismissed" // The members' votes are genuine
+)
+
+// VoteRingQueueReason is the moderation queue reason for vote ring members
+const VoteRingQueueReason = "vote_ring"
+
+// ReciprocalVotePair is two users who both upvoted each other's content at
+// least the minimum number of times within the detection window
+type ReciprocalVotePair struct {
+	UserA     uuid.UUID
+	UserB     uuid.UUID
+	VotesAToB int
+	VotesBToA int
+}
+
+// VoteRing is a cluster of accounts that upvote each other's content
+type VoteRing struct {
+	ID              uuid.UUID        `json:"id" db:"id"`
+	MemberCount     int              `json:"member_count" db:"member_count"`
+	ReciprocalVotes int              `json:"reciprocal_votes" db:"reciprocal_votes"`
+	WindowStart     time.Time        `json:"window_start" db:"window_start"`
+	Status          string           `json:"status" db:"status"`
+	KarmaReversed   int              `json:"karma_reversed" db:"karma_reversed"`
+	ReviewedBy      *uuid.UUID       `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewedAt      *time.Time       `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	ReviewNotes     *string          `json:"review_notes,omitempty" db:"review_notes"`
+	CreatedAt       time.Time        `json:"created_at" db:"created_at"`
+	Members         []VoteRingMember `json:"members,omitempty"`
+}
+
+// VoteRingMember is an account in a vote ring
+type VoteRingMember struct {
+	UserID        uuid.UUID  `json:"user_id" db:"user_id"`
+	Username      string     `json:"username" db:"username"`
+	VotesGiven    int        `json:"votes_given" db:"votes_given"`       // Upvotes on other members' content
+	VotesReceived int        `json:"votes_received" db:"votes_received"` // Upvotes from other members
+	QueueItemID   *uuid.UUID `json:"queue_item_id,omitempty" db:"queue_item_id"`
+}
+
+// VoteRingFilters narrows the vote ring review list
+type VoteRingFilters struct {
+	Status string
+	UserID *uuid.UUID // Rings this account is a member of
+	Limit  int
+	Offset int
+}
+
+// ReviewVoteRingRequest is the body for confirming or dismissing a vote ring
+type ReviewVoteRingRequest struct {
+	Notes *string `json:"notes,omitempty" binding:"omitempty,max=2000"`
+}
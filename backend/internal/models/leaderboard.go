@@ -0,0 +1,108 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Leaderboard types
+const (
+	LeaderboardTypeKarma      = "karma"
+	LeaderboardTypeEngagement = "engagement"
+)
+
+// Leaderboard periods. All-time leaderboards are live; the others are
+// snapshots computed by the leaderboard scheduler.
+const (
+	LeaderboardPeriodAllTime = "all_time"
+	LeaderboardPeriodWeekly  = "weekly"  // ISO weeks, starting Monday UTC
+	LeaderboardPeriodMonthly = "monthly" // Calendar months, UTC
+	LeaderboardPeriodSeason  = "season"  // Calendar quarters, UTC
+)
+
+// Leaderboard scopes
+const (
+	LeaderboardScopeGlobal    = "global"
+	LeaderboardScopeGame      = "game"      // Activity on clips of a game
+	LeaderboardScopeCommunity = "community" // Activity on clips in a community
+)
+
+// LeaderboardWindow is one weekly, monthly or seasonal leaderboard period
+type LeaderboardWindow struct {
+	Period string    `json:"period"`
+	Key    string    `json:"period_key"` // "2026-W42", "2026-10", "2026-Q4"
+	Start  time.Time `json:"period_start"`
+	End    time.Time `json:"period_end"` // Exclusive
+}
+
+// LeaderboardWindowFor returns the period containing t. It returns false for
+// unknown and all-time periods.
+func LeaderboardWindowFor(period string, t time.Time) (LeaderboardWindow, bool) {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch period {
+	case LeaderboardPeriodWeekly:
+		// Weeks start on Monday
+		start := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		year, week := start.ISOWeek()
+		return LeaderboardWindow{
+			Period: period,
+			Key:    fmt.Sprintf("%d-W%02d", year, week),
+			Start:  start,
+			End:    start.AddDate(0, 0, 7),
+		}, true
+	case LeaderboardPeriodMonthly:
+		start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return LeaderboardWindow{
+			Period: period,
+			Key:    start.Format("2006-01"),
+			Start:  start,
+			End:    start.AddDate(0, 1, 0),
+		}, true
+	case LeaderboardPeriodSeason:
+		quarter := (int(t.Month()) - 1) / 3
+		start := time.Date(t.Year(), time.Month(quarter*3+1), 1, 0, 0, 0, 0, time.UTC)
+		return LeaderboardWindow{
+			Period: period,
+			Key:    fmt.Sprintf("%d-Q%d", t.Year(), quarter+1),
+			Start:  start,
+			End:    start.AddDate(0, 3, 0),
+		}, true
+	default:
+		return LeaderboardWindow{}, false
+	}
+}
+
+// Previous returns the period before this one
+func (w LeaderboardWindow) Previous() LeaderboardWindow {
+	prev, _ := LeaderboardWindowFor(w.Period, w.Start.Add(-time.Nanosecond))
+	return prev
+}
+
+// LeaderboardSnapshot is a computed weekly, monthly or seasonal leaderboard,
+// global or for one game or community
+type LeaderboardSnapshot struct {
+	ID              uuid.UUID  `json:"id" db:"id"`
+	Type            string     `json:"type" db:"leaderboard_type"`
+	Period          string     `json:"period" db:"period"`
+	PeriodKey       string     `json:"period_key" db:"period_key"`
+	PeriodStart     time.Time  `json:"period_start" db:"period_start"`
+	PeriodEnd       time.Time  `json:"period_end" db:"period_end"`
+	Scope           string     `json:"scope" db:"scope"`
+	ScopeID         string     `json:"scope_id,omitempty" db:"scope_id"`
+	EntryCount      int        `json:"entry_count" db:"entry_count"`
+	Finalized       bool       `json:"finalized" db:"finalized"`
+	BadgesAwardedAt *time.Time `json:"-" db:"badges_awarded_at"`
+	ComputedAt      time.Time  `json:"computed_at" db:"computed_at"`
+}
+
+// LeaderboardScore is a user's score in a computed leaderboard
+type LeaderboardScore struct {
+	ScopeID string
+	Rank    int
+	UserID  uuid.UUID
+	Score   int
+}
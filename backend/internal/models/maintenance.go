@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Where the current maintenance mode comes from
+const (
+	MaintenanceSourceConfig = "config"
+	MaintenanceSourceAdmin  = "admin"
+)
+
+// MaintenanceStatus is the API's maintenance mode. In read-only mode
+// mutating requests are rejected while reads keep working.
+type MaintenanceStatus struct {
+	ReadOnly  bool       `json:"read_only"`
+	Message   string     `json:"message,omitempty"`
+	Source    string     `json:"source"`
+	UpdatedBy *uuid.UUID `json:"updated_by,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// UpdateMaintenanceRequest turns read-only maintenance mode on or off
+type UpdateMaintenanceRequest struct {
+	ReadOnly *bool   `json:"read_only" binding:"required"`
+	Message  string  `json:"message" binding:"max=500"`
+	Reason   *string `json:"reason,omitempty" binding:"omitempty,max=1000"`
+}
@@ -27,7 +27,9 @@ type User struct {
 	ModeratorScope      string      `json:"moderator_scope,omitempty" db:"moderator_scope"`
 	ModerationChannels  []uuid.UUID `json:"moderation_channels,omitempty" db:"moderation_channels"`
 	ModerationStartedAt *time.Time  `json:"moderation_started_at,omitempty" db:"moderation_started_at"`
-	AccountStatus       string      `json:"account_status" db:"account_status"` // active, unclaimed, pending
+	AdminRoles          []string    `json:"admin_roles,omitempty" db:"admin_roles"`     // scoped admin sub-roles
+	AccountStatus       string      `json:"account_status" db:"account_status"`         // active, unclaimed, pending
+	AuthProvider        string      `json:"auth_provider,omitempty" db:"auth_provider"` // provider the account signed up with
 	IsBanned            bool        `json:"is_banned" db:"is_banned"`
 	DeviceToken         *string     `json:"device_token,omitempty" db:"device_token"`
 	DevicePlatform      *string     `json:"device_platform,omitempty" db:"device_platform"`
@@ -55,8 +57,15 @@ type UserSettings struct {
 	UserID            uuid.UUID `json:"user_id" db:"user_id"`
 	ProfileVisibility string    `json:"profile_visibility" db:"profile_visibility"` // public, private, followers
 	ShowKarmaPublicly bool      `json:"show_karma_publicly" db:"show_karma_publicly"`
-	CreatedAt         time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt         time.Time `json:"updated_at" db:"updated_at"`
+	// PersonalizedSearch re-ranks search results by the user's follows and downvotes
+	PersonalizedSearch bool      `json:"personalized_search" db:"personalized_search"`
+	// ShowProfileViews shows the profile view counter to other users
+	ShowProfileViews bool      `json:"show_profile_views" db:"show_profile_views"`
+	// ShareActivity shows the user's submitted clips, created feeds and
+	// earned badges in their followers' social feeds
+	ShareActivity bool      `json:"share_activity" db:"share_activity"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
 }
 
 // AccountDeletion represents a pending account deletion request
@@ -79,8 +88,11 @@ type UpdateProfileRequest struct {
 
 // UpdateUserSettingsRequest represents the request to update user settings
 type UpdateUserSettingsRequest struct {
-	ProfileVisibility *string `json:"profile_visibility,omitempty" binding:"omitempty,oneof=public private followers"`
-	ShowKarmaPublicly *bool   `json:"show_karma_publicly,omitempty"`
+	ProfileVisibility  *string `json:"profile_visibility,omitempty" binding:"omitempty,oneof=public private followers"`
+	ShowKarmaPublicly  *bool   `json:"show_karma_publicly,omitempty"`
+	PersonalizedSearch *bool   `json:"personalized_search,omitempty"`
+	ShowProfileViews   *bool   `json:"show_profile_views,omitempty"`
+	ShareActivity      *bool   `json:"share_activity,omitempty"`
 }
 
 // DeleteAccountRequest represents the request to delete an account
@@ -120,12 +132,15 @@ type Clip struct {
 	TwitchClipURL        string     `json:"twitch_clip_url" db:"twitch_clip_url"`
 	EmbedURL             string     `json:"embed_url" db:"embed_url"`
 	Title                string     `json:"title" db:"title"`
+	DisplayTitle         *string    `json:"display_title,omitempty" db:"display_title"` // Normalized title for search and SEO
 	CreatorName          string     `json:"creator_name" db:"creator_name"`
 	CreatorID            *string    `json:"creator_id,omitempty" db:"creator_id"`
 	BroadcasterName      string     `json:"broadcaster_name" db:"broadcaster_name"`
 	BroadcasterID        *string    `json:"broadcaster_id,omitempty" db:"broadcaster_id"`
 	GameID               *string    `json:"game_id,omitempty" db:"game_id"`
 	GameName             *string    `json:"game_name,omitempty" db:"game_name"`
+	GamePatchID          *uuid.UUID `json:"game_patch_id,omitempty" db:"game_patch_id"` // Patch of the game in effect when the clip was created
+	GamePatch            *string    `json:"game_patch,omitempty" db:"-"`                // Version of GamePatchID
 	Language             *string    `json:"language,omitempty" db:"language"`
 	ThumbnailURL         *string    `json:"thumbnail_url,omitempty" db:"thumbnail_url"`
 	Duration             *float64   `json:"duration,omitempty" db:"duration"`
@@ -140,9 +155,9 @@ type Clip struct {
 	IsRemoved            bool       `json:"is_removed" db:"is_removed"`
 	RemovedReason        *string    `json:"removed_reason,omitempty" db:"removed_reason"`
 	IsHidden             bool       `json:"is_hidden" db:"is_hidden"`
-	Embedding            []float32  `json:"embedding,omitempty" db:"embedding"`
-	EmbeddingGeneratedAt *time.Time `json:"embedding_generated_at,omitempty" db:"embedding_generated_at"`
-	EmbeddingModel       *string    `json:"embedding_model,omitempty" db:"embedding_model"`
+	Embedding            []float32  `json:"-" db:"embedding"`
+	EmbeddingGeneratedAt *time.Time `json:"-" db:"embedding_generated_at"`
+	EmbeddingModel       *string    `json:"-" db:"embedding_model"`
 	SubmittedByUserID    *uuid.UUID `json:"submitted_by_user_id,omitempty" db:"submitted_by_user_id"`
 	SubmittedAt          *time.Time `json:"submitted_at,omitempty" db:"submitted_at"`
 	// Trending and popularity metrics
@@ -171,6 +186,10 @@ type Clip struct {
 	LastMirrorSyncAt *time.Time `json:"last_mirror_sync_at,omitempty" db:"last_mirror_sync_at"`
 	// Watch progress (populated from watch history, not in database)
 	WatchProgress *WatchProgressInfo `json:"watch_progress,omitempty" db:"-"`
+	// Accessibility metadata (from clip_accessibility, not in the clips table)
+	Accessibility *ClipAccessibility `json:"accessibility,omitempty" db:"-"`
+	// Chat hype score (from clip_hype_scores, not in the clips table)
+	HypeScore *float64 `json:"hype_score,omitempty" db:"-"`
 }
 
 // WatchProgressInfo represents watch progress for a clip (used in API responses)
@@ -225,15 +244,60 @@ type Comment struct {
 	UserID          uuid.UUID  `json:"user_id" db:"user_id"`
 	ParentCommentID *uuid.UUID `json:"parent_comment_id,omitempty" db:"parent_comment_id"`
 	Content         string     `json:"content" db:"content"`
+	RenderedContent *string    `json:"-" db:"rendered_content"`
 	VoteScore       int        `json:"vote_score" db:"vote_score"`
 	ReplyCount      int        `json:"reply_count" db:"reply_count"`
 	IsEdited        bool       `json:"is_edited" db:"is_edited"`
 	IsRemoved       bool       `json:"is_removed" db:"is_removed"`
 	RemovedReason   *string    `json:"removed_reason,omitempty" db:"removed_reason"`
+	AutomodState    *string    `json:"-" db:"automod_state"` // held or shadow_hidden by automoderation
 	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
 }
 
+// CommentMention is a user mentioned with @username in a comment
+type CommentMention struct {
+	UserID   uuid.UUID `json:"user_id" db:"user_id"`
+	Username string    `json:"username" db:"username"`
+}
+
+// CommentRevision is a comment's content as it was before one of its edits
+type CommentRevision struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	CommentID uuid.UUID  `json:"comment_id" db:"comment_id"`
+	Content   string     `json:"content" db:"content"`
+	EditedBy  *uuid.UUID `json:"edited_by,omitempty" db:"edited_by"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"` // When the edit replaced it
+}
+
+// Comment reactions, a fixed emoji set
+const (
+	CommentReactionThumbsUp = "thumbs_up" // 👍
+	CommentReactionHeart    = "heart"     // ❤️
+	CommentReactionLaugh    = "laugh"     // 😂
+	CommentReactionWow      = "wow"       // 😮
+	CommentReactionSad      = "sad"       // 😢
+	CommentReactionFire     = "fire"      // 🔥
+)
+
+// ValidCommentReactions lists the reactions comments accept, in display order
+var ValidCommentReactions = []string{
+	CommentReactionThumbsUp,
+	CommentReactionHeart,
+	CommentReactionLaugh,
+	CommentReactionWow,
+	CommentReactionSad,
+	CommentReactionFire,
+}
+
+// CommentReactionCount is how many users gave a comment one reaction, and
+// whether the viewing user is among them
+type CommentReactionCount struct {
+	Reaction string `json:"reaction" db:"reaction"`
+	Count    int    `json:"count" db:"count"`
+	Reacted  bool   `json:"reacted" db:"reacted"`
+}
+
 // CommentVote represents a user's vote on a comment
 type CommentVote struct {
 	ID        uuid.UUID `json:"id" db:"id"`
@@ -262,6 +326,14 @@ type Tag struct {
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 }
 
+// RelatedTag is a tag that often tags the same clips as another, from the
+// tag co-occurrence graph
+type RelatedTag struct {
+	Tag
+	ClipCount int     `json:"clip_count" db:"clip_count"` // Clips tagged with both tags
+	Score     float64 `json:"score" db:"score"`           // Jaccard similarity of the tags' clips, 0-1
+}
+
 // ClipTag represents the many-to-many relationship between clips and tags
 type ClipTag struct {
 	ClipID    uuid.UUID `json:"clip_id" db:"clip_id"`
@@ -281,6 +353,20 @@ type Report struct {
 	ReviewedBy     *uuid.UUID `json:"reviewed_by,omitempty" db:"reviewed_by"`
 	ReviewedAt     *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at"`
 	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	GroupID        *uuid.UUID `json:"group_id,omitempty" db:"group_id"` // Open report group the report joined
+}
+
+// ReporterReport is a report as its reporter sees it, without the reviewing
+// moderator
+type ReporterReport struct {
+	ID             uuid.UUID  `json:"id"`
+	ReportableType string     `json:"reportable_type"`
+	ReportableID   uuid.UUID  `json:"reportable_id"`
+	Reason         string     `json:"reason"`
+	Description    *string    `json:"description,omitempty"`
+	Status         string     `json:"status"`
+	ReviewedAt     *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
 }
 
 // ClipWithHotScore represents a clip with calculated hot score
@@ -317,7 +403,52 @@ type SearchRequest struct {
 	DateTo    *string  `json:"date_to" form:"date_to"`
 	Page      int      `json:"page" form:"page"`
 	Limit     int      `json:"limit" form:"limit"`
-}
+	// Duration (seconds) and view count ranges, inclusive
+	MinDuration *float64 `json:"min_duration" form:"min_duration"`
+	MaxDuration *float64 `json:"max_duration" form:"max_duration"`
+	MinViews    *int     `json:"min_views" form:"min_views"`
+	MaxViews    *int     `json:"max_views" form:"max_views"`
+	// Only clips tagged with this game patch
+	PatchID *string `json:"patch_id" form:"patch_id"`
+	// PatchWindow is when PatchID was in effect, resolved by the handler for
+	// search backends that filter by game and creation time
+	PatchWindow *GamePatchWindow `json:"-" form:"-"`
+	// Accessibility filters
+	Captioned          bool `json:"captioned" form:"captioned"`                     // Only clips with captions
+	HidePhotosensitive bool `json:"hide_photosensitive" form:"hide_photosensitive"` // Exclude clips with a photosensitivity warning
+
+	// UserID is the signed-in user searching, set by the handler; hybrid
+	// search personalizes relevance results for them
+	UserID *uuid.UUID `json:"-" form:"-"`
+
+	// Personalization set to "off" ranks this search for no one in
+	// particular, even for signed-in users who personalize their searches
+	Personalization string `json:"personalization" form:"personalization"`
+
+	// PreferredLanguage ranks clips with titles in this language first
+	// without excluding others, unlike the Language filter
+	PreferredLanguage *string `json:"preferred_language" form:"preferred_language"`
+
+	// QueryLanguage is the language the query is written in, set by hybrid
+	// search; its titles are matched with that language's analyzer
+	QueryLanguage string `json:"-" form:"-"`
+
+	// ExpansionTerms are the names of tags related to the tag the query
+	// names, set by the handler from the tag co-occurrence graph; clips
+	// matching them are found too, ranked below direct matches
+	ExpansionTerms []string `json:"-" form:"-"`
+
+	// HighlightPreTag and HighlightPostTag wrap matched terms in highlights,
+	// <mark> and </mark> by default
+	HighlightPreTag  *string `json:"highlight_pre_tag" form:"highlight_pre_tag"`
+	HighlightPostTag *string `json:"highlight_post_tag" form:"highlight_post_tag"`
+}
+
+// Search rankers, the arms of the learning-to-rank A/B test
+const (
+	SearchRankerLTR     = "ltr"
+	SearchRankerControl = "control"
+)
 
 // SearchResponse represents search results
 type SearchResponse struct {
@@ -326,8 +457,32 @@ type SearchResponse struct {
 	Counts  SearchCounts        `json:"counts"`
 	Facets  SearchFacets        `json:"facets,omitempty"`
 	Meta    SearchMeta          `json:"meta"`
+
+	// DidYouMean is a spelling correction of Query. When AutoCorrected is
+	// set, Query had no clip hits and the results are for DidYouMean instead.
+	DidYouMean    *string `json:"did_you_mean,omitempty"`
+	AutoCorrected bool    `json:"auto_corrected,omitempty"`
+
+	// Personalized is set when clips were re-ranked for the signed-in user
+	Personalized bool `json:"personalized,omitempty"`
+
+	// Ranker is the A/B arm of a relevance search while learning-to-rank is
+	// tested: "ltr" when the model re-ranked the clips, "control" otherwise
+	Ranker string `json:"ranker,omitempty"`
+
+	// SearchID identifies the tracked search, for reporting result clicks
+	SearchID *uuid.UUID `json:"search_id,omitempty"`
+
+	// Highlights holds the matched fragments of each clip result, keyed by
+	// clip ID. Only clips with a matching title, creator, broadcaster or
+	// game are included.
+	Highlights map[string]SearchHighlights `json:"highlights,omitempty"`
 }
 
+// SearchHighlights maps a result field to its fragments with matched terms
+// wrapped in the highlight tags. Field text is HTML-escaped.
+type SearchHighlights map[string][]string
+
 // SearchResultsByType groups results by type
 type SearchResultsByType struct {
 	Clips    []Clip             `json:"clips,omitempty"`
@@ -354,10 +509,11 @@ type SearchMeta struct {
 
 // SearchFacets holds aggregated facet data for filtering
 type SearchFacets struct {
-	Languages []FacetBucket  `json:"languages,omitempty"`
-	Games     []FacetBucket  `json:"games,omitempty"`
-	Tags      []FacetBucket  `json:"tags,omitempty"`
-	DateRange DateRangeFacet `json:"date_range,omitempty"`
+	Languages []FacetBucket         `json:"languages,omitempty"`
+	Games     []FacetBucket         `json:"games,omitempty"`
+	Tags      []FacetBucket         `json:"tags,omitempty"`
+	DateRange DateRangeFacet        `json:"date_range,omitempty"`
+	Durations []DurationFacetBucket `json:"durations,omitempty"`
 }
 
 // FacetBucket represents a single facet value with its count
@@ -367,6 +523,16 @@ type FacetBucket struct {
 	Count int    `json:"count"`
 }
 
+// DurationFacetBucket counts clips in a duration range. Min and Max bound the
+// range in seconds; Max is exclusive and unset for the last bucket.
+type DurationFacetBucket struct {
+	Key   string   `json:"key"`
+	Label string   `json:"label"`
+	Min   float64  `json:"min"`
+	Max   *float64 `json:"max,omitempty"`
+	Count int      `json:"count"`
+}
+
 // DateRangeFacet represents date range distribution
 type DateRangeFacet struct {
 	LastHour  int `json:"last_hour"`
@@ -458,7 +624,7 @@ type ClipSubmission struct {
 	Tags                    []string   `json:"tags,omitempty" db:"tags"`
 	IsNSFW                  bool       `json:"is_nsfw" db:"is_nsfw"`
 	SubmissionReason        *string    `json:"submission_reason,omitempty" db:"submission_reason"`
-	Status                  string     `json:"status" db:"status"` // pending, approved, rejected
+	Status                  string     `json:"status" db:"status"` // awaiting_broadcaster, pending, approved, rejected
 	RejectionReason         *string    `json:"rejection_reason,omitempty" db:"rejection_reason"`
 	ReviewedBy              *uuid.UUID `json:"reviewed_by,omitempty" db:"reviewed_by"`
 	ReviewedAt              *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at"`
@@ -638,6 +804,23 @@ type Notification struct {
 	SourceUserID      *uuid.UUID `json:"source_user_id,omitempty" db:"source_user_id"`
 	SourceContentID   *uuid.UUID `json:"source_content_id,omitempty" db:"source_content_id"`
 	SourceContentType *string    `json:"source_content_type,omitempty" db:"source_content_type"`
+
+	// Coalescing: events sharing a group key inside the rolling window are folded into one row
+	GroupKey       *string                `json:"group_key,omitempty" db:"group_key"`
+	AggregateCount int                    `json:"aggregate_count" db:"aggregate_count"`
+	Aggregate      *NotificationAggregate `json:"aggregate,omitempty" db:"aggregate_data"`
+	UpdatedAt      *time.Time             `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// NotificationAggregate is the structured payload of a coalesced notification,
+// e.g. "alice, bob and +483 more commented on your clip"
+type NotificationAggregate struct {
+	ActorIDs     []uuid.UUID `json:"actor_ids"`
+	ActorNames   []string    `json:"actor_names"`
+	OthersCount  int         `json:"others_count"`
+	TotalEvents  int         `json:"total_events"`
+	FirstEventAt time.Time   `json:"first_event_at"`
+	LastEventAt  time.Time   `json:"last_event_at"`
 }
 
 // NotificationWithSource includes source user information
@@ -654,6 +837,7 @@ type NotificationPreferences struct {
 	InAppEnabled bool      `json:"in_app_enabled" db:"in_app_enabled"`
 	EmailEnabled bool      `json:"email_enabled" db:"email_enabled"`
 	EmailDigest  string    `json:"email_digest" db:"email_digest"` // immediate, daily, weekly, never
+	PushEnabled  bool      `json:"push_enabled" db:"push_enabled"`
 
 	// Account & Security
 	NotifyLoginNewDevice  bool `json:"notify_login_new_device" db:"notify_login_new_device"`
@@ -696,6 +880,10 @@ type NotificationPreferences struct {
 	NotifyMarketing             bool `json:"notify_marketing" db:"notify_marketing"`
 	NotifyPolicyUpdates         bool `json:"notify_policy_updates" db:"notify_policy_updates"`
 	NotifyPlatformAnnouncements bool `json:"notify_platform_announcements" db:"notify_platform_announcements"`
+	NotifyReengagement          bool `json:"notify_reengagement" db:"notify_reengagement"`
+
+	// Timezone is the IANA time zone bulk email send windows are applied in
+	Timezone *string `json:"timezone,omitempty" db:"timezone"`
 
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
 }
@@ -705,7 +893,10 @@ const (
 	NotificationTypeReply                = "reply"
 	NotificationTypeMention              = "mention"
 	NotificationTypeVoteMilestone        = "vote_milestone"
+	NotificationTypeCommentReaction      = "comment_reaction"
+	NotificationTypeCommentPinned        = "comment_pinned"
 	NotificationTypeBadgeEarned          = "badge_earned"
+	NotificationTypeAchievementCompleted = "achievement_completed"
 	NotificationTypeRankUp               = "rank_up"
 	NotificationTypeFavoritedClipComment = "favorited_clip_comment"
 	NotificationTypeContentRemoved       = "content_removed"
@@ -715,6 +906,7 @@ const (
 	NotificationTypeSubmissionApproved   = "submission_approved"
 	NotificationTypeSubmissionRejected   = "submission_rejected"
 	NotificationTypeNewReport            = "new_report"
+	NotificationTypeReportOutcome        = "report_outcome"
 	NotificationTypePendingSubmissions   = "pending_submissions"
 	NotificationTypeSystemAlert          = "system_alert"
 	// Dunning notification types
@@ -731,21 +923,30 @@ const (
 	NotificationTypeClipComment       = "clip_comment"
 	NotificationTypeClipViewThreshold = "clip_view_threshold"
 	NotificationTypeClipVoteThreshold = "clip_vote_threshold"
+	// Broadcaster clip approval notification types
+	NotificationTypeSubmissionAwaitingBroadcaster = "submission_awaiting_broadcaster"
+	NotificationTypeSubmissionBroadcasterApproved = "submission_broadcaster_approved"
+	NotificationTypeBroadcasterReviewRequested    = "broadcaster_review_requested"
 	// Account & Security notification types
 	NotificationTypeLoginNewDevice  = "login_new_device"
 	NotificationTypeFailedLogin     = "failed_login"
 	NotificationTypePasswordChanged = "password_changed"
 	NotificationTypeEmailChanged    = "email_changed"
+	// NotificationTypeRefreshTokenReuse alerts that a replayed refresh token signed a session out.
+	// It is a security alert and cannot be turned off.
+	NotificationTypeRefreshTokenReuse = "refresh_token_reuse"
 	// Content notification types (additional)
 	NotificationTypeContentTrending = "content_trending"
 	NotificationTypeContentFlagged  = "content_flagged"
 	// Community notification types (additional)
 	NotificationTypeModeratorMessage = "moderator_message"
 	NotificationTypeUserFollowed     = "user_followed"
+	NotificationTypeFeedInvite       = "feed_invite"
 	NotificationTypeCommentOnContent = "comment_on_content"
 	NotificationTypeDiscussionReply  = "discussion_reply"
 	// Broadcaster notification types
-	NotificationTypeBroadcasterLive = "broadcaster_live"
+	NotificationTypeBroadcasterLive             = "broadcaster_live"
+	NotificationTypeBroadcasterScheduleReminder = "broadcaster_schedule_reminder"
 	// Stream notification types
 	NotificationTypeStreamLive = "stream_live"
 	// Global/Marketing notification types
@@ -1670,6 +1871,11 @@ const (
 	WebhookEventClipSubmitted = "clip.submitted"
 	WebhookEventClipApproved  = "clip.approved"
 	WebhookEventClipRejected  = "clip.rejected"
+
+	// Changefeed events for public clips
+	WebhookEventClipCreated = "clip.created"
+	WebhookEventClipUpdated = "clip.updated"
+	WebhookEventClipDeleted = "clip.deleted"
 )
 
 // GetSupportedWebhookEvents returns the list of supported webhook events
@@ -1678,6 +1884,9 @@ func GetSupportedWebhookEvents() []string {
 		WebhookEventClipSubmitted,
 		WebhookEventClipApproved,
 		WebhookEventClipRejected,
+		WebhookEventClipCreated,
+		WebhookEventClipUpdated,
+		WebhookEventClipDeleted,
 	}
 }
 
@@ -1745,6 +1954,7 @@ const (
 	TrustScoreReasonSubmissionApproved = "submission_approved"
 	TrustScoreReasonSubmissionRejected = "submission_rejected"
 	TrustScoreReasonReportActioned     = "report_actioned"
+	TrustScoreReasonReportDismissed    = "report_dismissed"
 	TrustScoreReasonManualAdjustment   = "manual_adjustment"
 	TrustScoreReasonNewActivity        = "new_activity"
 	TrustScoreReasonBanned             = "banned"
@@ -1960,6 +2170,8 @@ type Feed struct {
 	Icon          *string   `json:"icon,omitempty" db:"icon"`
 	IsPublic      bool      `json:"is_public" db:"is_public"`
 	FollowerCount int       `json:"follower_count" db:"follower_count"`
+	Version       int       `json:"version" db:"version"` // Bumped by every change to the feed's clips
+	IsSmart       bool      `json:"is_smart" db:"is_smart"` // Clips are picked by the feed's smart rules
 	CreatedAt     time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
 }
@@ -1977,12 +2189,15 @@ type FeedItem struct {
 	ClipID   uuid.UUID `json:"clip_id" db:"clip_id"`
 	Position int       `json:"position" db:"position"`
 	AddedAt  time.Time `json:"added_at" db:"added_at"`
+	// AddedByUserID is who added the clip; unset for clips added by smart feed rules
+	AddedByUserID *uuid.UUID `json:"added_by_user_id,omitempty" db:"added_by_user_id"`
 }
 
 // FeedItemWithClip includes clip information
 type FeedItemWithClip struct {
 	FeedItem
-	Clip *Clip `json:"clip,omitempty"`
+	AddedBy *ClipSubmitterInfo `json:"added_by,omitempty"`
+	Clip    *Clip              `json:"clip,omitempty"`
 }
 
 // FeedFollow represents a user following a feed
@@ -2011,12 +2226,28 @@ type UpdateFeedRequest struct {
 
 // AddClipToFeedRequest represents the request to add a clip to a feed
 type AddClipToFeedRequest struct {
-	ClipID uuid.UUID `json:"clip_id" binding:"required"`
+	ClipID  uuid.UUID `json:"clip_id" binding:"required"`
+	Version *int      `json:"version,omitempty"` // Feed version the change was made against; omit to skip the check
+}
+
+// AddClipToFeedResponse is the added feed item and the feed's new version
+type AddClipToFeedResponse struct {
+	FeedItem
+	FeedVersion int `json:"feed_version"`
 }
 
 // ReorderFeedClipsRequest represents the request to reorder clips in a feed
 type ReorderFeedClipsRequest struct {
 	ClipIDs []uuid.UUID `json:"clip_ids" binding:"required"`
+	Version *int        `json:"version,omitempty"` // Feed version the change was made against; omit to skip the check
+}
+
+// MoveFeedClipRequest moves one clip of a feed before another, leaving the
+// rest in place, so edits to other clips don't conflict with it
+type MoveFeedClipRequest struct {
+	ClipID       uuid.UUID  `json:"clip_id" binding:"required"`
+	BeforeClipID *uuid.UUID `json:"before_clip_id,omitempty"` // Nil moves the clip to the end
+	Version      *int       `json:"version,omitempty"`        // Feed version the change was made against; omit to skip the check
 }
 
 // UserFollow represents a user following another user
@@ -2442,17 +2673,18 @@ type CommunityClipWithClip struct {
 
 // CommunityDiscussion represents a discussion thread in a community
 type CommunityDiscussion struct {
-	ID           uuid.UUID `json:"id" db:"id"`
-	CommunityID  uuid.UUID `json:"community_id" db:"community_id"`
-	UserID       uuid.UUID `json:"user_id" db:"user_id"`
-	Title        string    `json:"title" db:"title"`
-	Content      string    `json:"content" db:"content"`
-	IsPinned     bool      `json:"is_pinned" db:"is_pinned"`
-	IsResolved   bool      `json:"is_resolved" db:"is_resolved"`
-	VoteScore    int       `json:"vote_score" db:"vote_score"`
-	CommentCount int       `json:"comment_count" db:"comment_count"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	ID              uuid.UUID `json:"id" db:"id"`
+	CommunityID     uuid.UUID `json:"community_id" db:"community_id"`
+	UserID          uuid.UUID `json:"user_id" db:"user_id"`
+	Title           string    `json:"title" db:"title"`
+	Content         string    `json:"content" db:"content"`
+	RenderedContent string    `json:"rendered_content" db:"rendered_content"`
+	IsPinned        bool      `json:"is_pinned" db:"is_pinned"`
+	IsResolved      bool      `json:"is_resolved" db:"is_resolved"`
+	VoteScore       int       `json:"vote_score" db:"vote_score"`
+	CommentCount    int       `json:"comment_count" db:"comment_count"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
 }
 
 // CommunityDiscussionWithUser includes user information
@@ -2694,6 +2926,16 @@ type DisableMFARequest struct {
 	Code string `json:"code" binding:"required"`
 }
 
+// RequestMFARecoveryRequest represents the request to email an MFA recovery link
+type RequestMFARecoveryRequest struct {
+	Username string `json:"username" binding:"required"`
+}
+
+// CompleteMFARecoveryRequest represents the request to finish MFA recovery with an emailed token
+type CompleteMFARecoveryRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
 // MFAStatusResponse represents the current MFA status for a user
 type MFAStatusResponse struct {
 	Enabled              bool       `json:"enabled"`
@@ -2813,7 +3055,7 @@ type SubmitDMCACounterNoticeRequest struct {
 
 // UpdateDMCANoticeStatusRequest represents admin request to update notice status
 type UpdateDMCANoticeStatusRequest struct {
-	Status string  `json:"status" binding:"required,oneof=pending valid invalid processed"`
+	Status string  `json:"status" binding:"required,oneof=valid invalid"`
 	Notes  *string `json:"notes,omitempty" binding:"omitempty,max=5000"`
 }
 
@@ -2845,6 +3087,64 @@ type DMCADashboardStats struct {
 	TotalCounterNoticesThisMonth int `json:"total_counter_notices_this_month"`
 }
 
+// DMCA case events recorded in the case history
+const (
+	DMCAEventNoticeReceived         = "notice_received"
+	DMCAEventNoticeReviewed         = "notice_reviewed"
+	DMCAEventTakedownProcessed      = "takedown_processed"
+	DMCAEventStrikeIssued           = "strike_issued"
+	DMCAEventCounterNoticeReceived  = "counter_notice_received"
+	DMCAEventCounterNoticeForwarded = "counter_notice_forwarded"
+	DMCAEventCounterNoticeRejected  = "counter_notice_rejected"
+	DMCAEventLawsuitFiled           = "lawsuit_filed"
+	DMCAEventContentReinstated      = "content_reinstated"
+	DMCAEventStrikeRemoved          = "strike_removed"
+	DMCAEventStrikeExpired          = "strike_expired"
+)
+
+// DMCACaseEvent is one step in the history of a DMCA case. ActorID is nil
+// for steps taken by the DMCA job or by anonymous submitters.
+type DMCACaseEvent struct {
+	ID              uuid.UUID              `json:"id" db:"id"`
+	DMCANoticeID    uuid.UUID              `json:"dmca_notice_id" db:"dmca_notice_id"`
+	CounterNoticeID *uuid.UUID             `json:"counter_notice_id,omitempty" db:"counter_notice_id"`
+	StrikeID        *uuid.UUID             `json:"strike_id,omitempty" db:"strike_id"`
+	Event           string                 `json:"event" db:"event"`
+	ActorID         *uuid.UUID             `json:"actor_id,omitempty" db:"actor_id"`
+	FromStatus      *string                `json:"from_status,omitempty" db:"from_status"`
+	ToStatus        *string                `json:"to_status,omitempty" db:"to_status"`
+	Notes           *string                `json:"notes,omitempty" db:"notes"`
+	Metadata        map[string]interface{} `json:"metadata,omitempty" db:"metadata"`
+	CreatedAt       time.Time              `json:"created_at" db:"created_at"`
+}
+
+// DMCANoticeDetail is a takedown notice with its counter-notices and case
+// history, for the admin review panel
+type DMCANoticeDetail struct {
+	Notice         DMCANotice          `json:"notice"`
+	CounterNotices []DMCACounterNotice `json:"counter_notices"`
+	Events         []DMCACaseEvent     `json:"events"`
+}
+
+// DMCACounterNoticeListResponse represents a list of counter-notices for admin panel
+type DMCACounterNoticeListResponse struct {
+	CounterNotices []DMCACounterNotice `json:"counter_notices"`
+	TotalCount     int                 `json:"total_count"`
+	Page           int                 `json:"page"`
+	PageSize       int                 `json:"page_size"`
+}
+
+// ResolveDMCACounterNoticeRequest represents admin request to reject a
+// counter-notice or record the complainant's lawsuit
+type ResolveDMCACounterNoticeRequest struct {
+	Notes *string `json:"notes,omitempty" binding:"omitempty,max=5000"`
+}
+
+// RemoveDMCAStrikeRequest represents admin request to remove a strike
+type RemoveDMCAStrikeRequest struct {
+	Notes *string `json:"notes,omitempty" binding:"omitempty,max=5000"`
+}
+
 // ModerationQueueItem represents an item in the moderation queue
 type ModerationQueueItem struct {
 	ID              uuid.UUID  `json:"id" db:"id"`
@@ -2861,6 +3161,10 @@ type ModerationQueueItem struct {
 	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
 	ReviewedAt      *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at"`
 	ReviewedBy      *uuid.UUID `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	// Automod holds the automoderation rules the content matched, if any
+	Automod *AutomodVerdict `json:"automod,omitempty" db:"-"`
+	// Toxicity holds the classifier's scores for comments flagged as toxic
+	Toxicity *ToxicityAssessment `json:"toxicity,omitempty" db:"-"`
 	// Content will be joined separately
 	Content interface{} `json:"content,omitempty" db:"-"`
 }
@@ -2897,19 +3201,75 @@ type BulkModerationRequest struct {
 	Reason  *string  `json:"reason,omitempty" binding:"omitempty,max=1000"`
 }
 
+// Moderation appeal statuses. Pending and in-review appeals are open.
+const (
+	AppealStatusPending  = "pending"
+	AppealStatusInReview = "in_review"
+	AppealStatusApproved = "approved"
+	AppealStatusRejected = "rejected"
+)
+
 // ModerationAppeal represents an appeal of a moderation decision
 type ModerationAppeal struct {
 	ID                 uuid.UUID  `json:"id" db:"id"`
 	UserID             uuid.UUID  `json:"user_id" db:"user_id"`
 	ModerationActionID uuid.UUID  `json:"moderation_action_id" db:"moderation_action_id"`
 	Reason             string     `json:"reason" db:"reason"`
-	Status             string     `json:"status" db:"status"` // pending, approved, rejected
+	Status             string     `json:"status" db:"status"` // pending, in_review, approved, rejected
+	AssignedTo         *uuid.UUID `json:"assigned_to,omitempty" db:"assigned_to"`
+	AssignedAt         *time.Time `json:"assigned_at,omitempty" db:"assigned_at"`
 	ResolvedBy         *uuid.UUID `json:"resolved_by,omitempty" db:"resolved_by"`
 	Resolution         *string    `json:"resolution,omitempty" db:"resolution"`
 	CreatedAt          time.Time  `json:"created_at" db:"created_at"`
 	ResolvedAt         *time.Time `json:"resolved_at,omitempty" db:"resolved_at"`
 }
 
+// ModerationAppealWithDetails is an appeal with the decision it contests.
+// Username and DisplayName are only filled in for the admin queue.
+type ModerationAppealWithDetails struct {
+	ModerationAppeal
+	Username       string    `json:"username,omitempty"`
+	DisplayName    string    `json:"display_name,omitempty"`
+	DecisionAction string    `json:"decision_action"`
+	DecisionReason *string   `json:"decision_reason,omitempty"`
+	ContentType    string    `json:"content_type"`
+	ContentID      uuid.UUID `json:"content_id"`
+}
+
+// AppealQueueFilters filters the admin appeal queue
+type AppealQueueFilters struct {
+	Status     string     // A status, or "open" for pending and in-review appeals
+	AssignedTo *uuid.UUID // Only appeals assigned to this moderator
+	Unassigned bool       // Only appeals no one has taken
+	Limit      int
+}
+
+// AssignAppealRequest represents the request to assign an appeal for review.
+// Without an assignee the appeal is assigned to the caller.
+type AssignAppealRequest struct {
+	AssigneeID *string `json:"assignee_id,omitempty" binding:"omitempty,uuid"`
+}
+
+// AppealSLAMetrics reports how quickly appeals are picked up and decided
+type AppealSLAMetrics struct {
+	WindowDays              int      `json:"window_days"`
+	AssignmentSLAHours      int      `json:"assignment_sla_hours"`
+	ResolutionSLAHours      int      `json:"resolution_sla_hours"`
+	Open                    int      `json:"open"`
+	Unassigned              int      `json:"unassigned"`
+	OpenBreachingAssignment int      `json:"open_breaching_assignment"` // Unassigned appeals older than the assignment SLA
+	OpenBreachingResolution int      `json:"open_breaching_resolution"` // Open appeals older than the resolution SLA
+	OldestOpenHours         *float64 `json:"oldest_open_hours,omitempty"`
+	Resolved                int      `json:"resolved"` // Appeals resolved in the window
+	Approved                int      `json:"approved"`
+	Rejected                int      `json:"rejected"`
+	ResolvedWithinSLA       int      `json:"resolved_within_sla"`
+	ResolutionSLAPercent    *float64 `json:"resolution_sla_percent,omitempty"`
+	MedianAssignmentHours   *float64 `json:"median_assignment_hours,omitempty"`
+	MedianResolutionHours   *float64 `json:"median_resolution_hours,omitempty"`
+	P90ResolutionHours      *float64 `json:"p90_resolution_hours,omitempty"`
+}
+
 // CreateAppealRequest represents the request to create an appeal
 type CreateAppealRequest struct {
 	ModerationActionID string `json:"moderation_action_id" binding:"required,uuid"`
@@ -3575,6 +3935,8 @@ type PlaylistListItem struct {
 	IsLiked             bool   `json:"is_liked"`
 	IsBookmarked        bool   `json:"is_bookmarked"`
 	PreviewClips        []Clip `json:"preview_clips,omitempty"`
+	// IsLocked means PreviewClips were withheld until the premium bundle is purchased
+	IsLocked            bool   `json:"is_locked,omitempty"`
 }
 
 // PlaylistWithClips represents a playlist with its clips
@@ -3588,6 +3950,9 @@ type PlaylistWithClips struct {
 	IsBookmarked bool              `json:"is_bookmarked"`
 	Creator      *User             `json:"creator,omitempty"`
 	CurrentUserPermission *string  `json:"current_user_permission,omitempty"`
+	// Bundle is set for premium playlists; IsLocked means Clips were withheld until purchase
+	Bundle                *PlaylistBundleAccess `json:"bundle,omitempty"`
+	IsLocked              bool                  `json:"is_locked,omitempty"`
 }
 
 // PlaylistClipRef represents a clip reference in a playlist with ordering
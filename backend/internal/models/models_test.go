@@ -229,3 +229,123 @@ func TestUserModeratorFieldsJSONMarshaling(t *testing.T) {
 		})
 	}
 }
+
+func TestKarmaRulePointsFor(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	recent := now.Add(-30 * time.Second)
+	earlier := now.Add(-2 * time.Minute)
+	cap5 := 5
+
+	tests := []struct {
+		name          string
+		rule          KarmaRule
+		awardedToday  int
+		lastAwardedAt *time.Time
+		want          int
+	}{
+		{"Uncapped rule awards its points", KarmaRule{Points: 2, Enabled: true}, 100, nil, 2},
+		{"Disabled rule awards nothing", KarmaRule{Points: 2}, 0, nil, 0},
+		{"Cap limits the award", KarmaRule{Points: 2, DailyCap: &cap5, Enabled: true}, 4, nil, 1},
+		{"Reached cap awards nothing", KarmaRule{Points: 2, DailyCap: &cap5, Enabled: true}, 5, nil, 0},
+		{"Cap doesn't limit penalties", KarmaRule{Points: -5, DailyCap: &cap5, Enabled: true}, 5, nil, -5},
+		{"Cooldown blocks a recent repeat", KarmaRule{Points: 1, CooldownSeconds: 60, Enabled: true}, 1, &recent, 0},
+		{"Cooldown allows a later repeat", KarmaRule{Points: 1, CooldownSeconds: 60, Enabled: true}, 1, &earlier, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.rule.PointsFor(tt.awardedToday, tt.lastAwardedAt, now)
+			if got != tt.want {
+				t.Errorf("PointsFor(%d) = %d, want %d", tt.awardedToday, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewUserAchievement(t *testing.T) {
+	achievement := Achievement{ID: "well_received", Metric: AchievementMetricUpvotedComments, Target: 100}
+	userID := uuid.New()
+	completedAt := time.Now()
+
+	tests := []struct {
+		name          string
+		value         int
+		completedAt   *time.Time
+		wantProgress  int
+		wantPercent   int
+		wantCompleted bool
+	}{
+		{"No progress", 0, nil, 0, 0, false},
+		{"Partial progress", 37, nil, 37, 37, false},
+		{"Progress is capped at the target", 150, nil, 100, 100, false},
+		{"Completed stays at full progress", 90, &completedAt, 100, 100, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewUserAchievement(achievement, userID, tt.value, tt.completedAt)
+			if got.Progress != tt.wantProgress || got.Percent != tt.wantPercent || got.Completed != tt.wantCompleted {
+				t.Errorf("NewUserAchievement(%d) = progress %d, percent %d, completed %v; want %d, %d, %v",
+					tt.value, got.Progress, got.Percent, got.Completed, tt.wantProgress, tt.wantPercent, tt.wantCompleted)
+			}
+		})
+	}
+}
+
+func TestLeaderboardWindowFor(t *testing.T) {
+	// A Wednesday
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		period    string
+		wantKey   string
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{LeaderboardPeriodWeekly, "2026-W42", time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		{LeaderboardPeriodMonthly, "2026-10", time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{LeaderboardPeriodSeason, "2026-Q4", time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.period, func(t *testing.T) {
+			w, ok := LeaderboardWindowFor(tt.period, now)
+			if !ok {
+				t.Fatalf("LeaderboardWindowFor(%q) not ok", tt.period)
+			}
+			if w.Key != tt.wantKey || !w.Start.Equal(tt.wantStart) || !w.End.Equal(tt.wantEnd) {
+				t.Errorf("LeaderboardWindowFor(%q) = %s [%v, %v), want %s [%v, %v)", tt.period, w.Key, w.Start, w.End, tt.wantKey, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+
+	if _, ok := LeaderboardWindowFor(LeaderboardPeriodAllTime, now); ok {
+		t.Error("Expected all-time period to have no window")
+	}
+}
+
+func TestLeaderboardWindowPrevious(t *testing.T) {
+	tests := []struct {
+		period  string
+		now     time.Time
+		wantKey string
+	}{
+		// ISO week 1 of 2027 starts on Monday 2027-01-04
+		{LeaderboardPeriodWeekly, time.Date(2027, 1, 4, 0, 0, 0, 0, time.UTC), "2026-W53"},
+		{LeaderboardPeriodMonthly, time.Date(2027, 1, 15, 0, 0, 0, 0, time.UTC), "2026-12"},
+		{LeaderboardPeriodSeason, time.Date(2027, 2, 1, 0, 0, 0, 0, time.UTC), "2026-Q4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.period, func(t *testing.T) {
+			w, _ := LeaderboardWindowFor(tt.period, tt.now)
+			prev := w.Previous()
+			if prev.Key != tt.wantKey {
+				t.Errorf("Previous() key = %s, want %s", prev.Key, tt.wantKey)
+			}
+			if !prev.End.Equal(w.Start) {
+				t.Errorf("Previous() ends at %v, want %v", prev.End, w.Start)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Items a moderator can claim for review
+const (
+	ModerationClaimItemEvent      = "event"      // Moderation event from the event queue
+	ModerationClaimItemSubmission = "submission" // Pending clip submission
+)
+
+// Reasons a claim stops being active
+const (
+	ModerationClaimReleaseResolved = "resolved" // The claimant reviewed the item
+	ModerationClaimReleaseReleased = "released" // The claimant gave the item back
+	ModerationClaimReleaseExpired  = "expired"  // The claim wasn't resolved in time
+)
+
+// ModerationClaim is a moderator's lock on an item while they review it
+type ModerationClaim struct {
+	ID            uuid.UUID  `json:"id" db:"id"`
+	ItemType      string     `json:"item_type" db:"item_type"`
+	ItemID        uuid.UUID  `json:"item_id" db:"item_id"`
+	ClaimedBy     uuid.UUID  `json:"claimed_by" db:"claimed_by"`
+	ClaimedAt     time.Time  `json:"claimed_at" db:"claimed_at"`
+	ExpiresAt     time.Time  `json:"expires_at" db:"expires_at"`
+	ReleasedAt    *time.Time `json:"released_at,omitempty" db:"released_at"`
+	ReleaseReason *string    `json:"release_reason,omitempty" db:"release_reason"`
+}
+
+// ModeratorWorkload is a moderator's open claims and the items they resolved
+// in the reporting window
+type ModeratorWorkload struct {
+	ModeratorID              uuid.UUID `json:"moderator_id"`
+	Username                 string    `json:"username"`
+	ActiveClaims             int       `json:"active_claims"`
+	Resolved                 int       `json:"resolved"`
+	Released                 int       `json:"released"`
+	Expired                  int       `json:"expired"`
+	MedianResolutionMinutes  *float64  `json:"median_resolution_minutes,omitempty"`
+	AverageResolutionMinutes *float64  `json:"average_resolution_minutes,omitempty"`
+}
+
+// ModerationWorkload is the claim workload across moderators
+type ModerationWorkload struct {
+	WindowDays              int                 `json:"window_days"`
+	ClaimTTLMinutes         int                 `json:"claim_ttl_minutes"`
+	ActiveClaims            int                 `json:"active_claims"`
+	Resolved                int                 `json:"resolved"`
+	Expired                 int                 `json:"expired"`
+	MedianResolutionMinutes *float64            `json:"median_resolution_minutes,omitempty"`
+	P90ResolutionMinutes    *float64            `json:"p90_resolution_minutes,omitempty"`
+	Moderators              []ModeratorWorkload `json:"moderators"`
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ModerationShiftHighPriority is the queue priority from which a pending
+// item counts as high priority in handoff reports
+const ModerationShiftHighPriority = 75
+
+// ModerationShift is a recurring daily shift of a moderator team. Shifts
+// start at StartTime in Timezone and may run past midnight.
+type ModerationShift struct {
+	ID              uuid.UUID   `json:"id" db:"id"`
+	Name            string      `json:"name" db:"name"`
+	Timezone        string      `json:"timezone" db:"timezone"`
+	StartTime       string      `json:"start_time" db:"start_time"` // Local start time, HH:MM
+	DurationMinutes int         `json:"duration_minutes" db:"duration_minutes"`
+	ModeratorIDs    []uuid.UUID `json:"moderator_ids" db:"moderator_ids"` // Empty means every moderator
+	EmailRecipients []string    `json:"email_recipients" db:"email_recipients"`
+	EmailEnabled    bool        `json:"email_enabled" db:"email_enabled"`
+	LastReportedEnd *time.Time  `json:"last_reported_end,omitempty" db:"last_reported_end"`
+	CreatedBy       *uuid.UUID  `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt       time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// CreateModerationShiftRequest represents the request to create a moderation shift
+type CreateModerationShiftRequest struct {
+	Name            string      `json:"name" binding:"required,min=1,max=100"`
+	Timezone        string      `json:"timezone" binding:"omitempty,max=64"`
+	StartTime       string      `json:"start_time" binding:"required"`
+	DurationMinutes int         `json:"duration_minutes" binding:"required,min=15,max=1440"`
+	ModeratorIDs    []uuid.UUID `json:"moderator_ids,omitempty" binding:"omitempty,max=100"`
+	EmailRecipients []string    `json:"email_recipients,omitempty" binding:"omitempty,max=20"`
+	EmailEnabled    *bool       `json:"email_enabled,omitempty"`
+}
+
+// UpdateModerationShiftRequest represents the request to change a moderation
+// shift. Omitted fields are left as they are; list fields replace the list.
+type UpdateModerationShiftRequest struct {
+	Name            *string      `json:"name,omitempty" binding:"omitempty,min=1,max=100"`
+	Timezone        *string      `json:"timezone,omitempty" binding:"omitempty,max=64"`
+	StartTime       *string      `json:"start_time,omitempty"`
+	DurationMinutes *int         `json:"duration_minutes,omitempty" binding:"omitempty,min=15,max=1440"`
+	ModeratorIDs    *[]uuid.UUID `json:"moderator_ids,omitempty" binding:"omitempty,max=100"`
+	EmailRecipients *[]string    `json:"email_recipients,omitempty" binding:"omitempty,max=20"`
+	EmailEnabled    *bool        `json:"email_enabled,omitempty"`
+}
+
+// ModerationShiftReport is an end-of-shift handoff summary: what the shift
+// handled, and what the next shift inherits
+type ModerationShiftReport struct {
+	ShiftID     *uuid.UUID                  `json:"shift_id,omitempty"`
+	ShiftName   *string                     `json:"shift_name,omitempty"`
+	Timezone    string                      `json:"timezone"`
+	ModeratorID *uuid.UUID                  `json:"moderator_id,omitempty"` // Set when the report covers one moderator
+	PeriodStart time.Time                   `json:"period_start"`
+	PeriodEnd   time.Time                   `json:"period_end"`
+	Totals      ModerationShiftActivity     `json:"totals"`
+	Moderators  []ModerationShiftActivity   `json:"moderators"`
+	Queue       ModerationShiftQueue        `json:"queue"`
+	Escalations []ModerationShiftEscalation `json:"escalations"`
+	Appeals     ModerationShiftAppeals      `json:"appeals"`
+	GeneratedAt time.Time                   `json:"generated_at"`
+}
+
+// ModerationShiftActivity counts the decisions a moderator made during a
+// shift. In report totals ModeratorID is nil.
+type ModerationShiftActivity struct {
+	ModeratorID     *uuid.UUID `json:"moderator_id,omitempty"`
+	Username        *string    `json:"username,omitempty"`
+	ItemsHandled    int        `json:"items_handled"` // Distinct queue items decided
+	Approved        int        `json:"approved"`
+	Rejected        int        `json:"rejected"`
+	Escalated       int        `json:"escalated"`
+	Banned          int        `json:"banned"`
+	AppealsResolved int        `json:"appeals_resolved"`
+}
+
+// ModerationShiftQueue is the moderation queue as the shift hands it over
+type ModerationShiftQueue struct {
+	Pending            int      `json:"pending"`
+	HighPriority       int      `json:"high_priority"` // Pending items at ModerationShiftHighPriority or above
+	Escalated          int      `json:"escalated"`
+	OldestPendingHours *float64 `json:"oldest_pending_hours,omitempty"`
+}
+
+// ModerationShiftEscalation is a queue item escalated during a shift
+type ModerationShiftEscalation struct {
+	QueueItemID         uuid.UUID  `json:"queue_item_id"`
+	ContentType         string     `json:"content_type"`
+	ContentID           uuid.UUID  `json:"content_id"`
+	Reason              string     `json:"reason"`
+	Priority            int        `json:"priority"`
+	Status              string     `json:"status"` // Current queue status
+	EscalatedBy         *uuid.UUID `json:"escalated_by,omitempty"`
+	EscalatedByUsername *string    `json:"escalated_by_username,omitempty"`
+	Note                *string    `json:"note,omitempty"` // Reason given when escalating
+	EscalatedAt         time.Time  `json:"escalated_at"`
+}
+
+// ModerationShiftAppeals summarizes appeals for a handoff report
+type ModerationShiftAppeals struct {
+	Open                int                `json:"open"`
+	OpenedDuringShift   int                `json:"opened_during_shift"`
+	ResolvedDuringShift int                `json:"resolved_during_shift"`
+	OldestOpenHours     *float64           `json:"oldest_open_hours,omitempty"`
+	OldestOpen          []ModerationAppeal `json:"oldest_open"`
+}
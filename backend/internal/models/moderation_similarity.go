@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Moderation case types that can be compared against past decisions
+const (
+	ModerationCaseSubmission = "submission"
+	ModerationCaseReport     = "report"
+)
+
+// ModerationCase is a submission or report together with its outcome, if decided
+type ModerationCase struct {
+	Type            string     `json:"type"`
+	ID              uuid.UUID  `json:"id"`
+	Title           string     `json:"title"`
+	BroadcasterID   *string    `json:"broadcaster_id,omitempty"`
+	BroadcasterName *string    `json:"broadcaster_name,omitempty"`
+	GameID          *string    `json:"game_id,omitempty"`
+	GameName        *string    `json:"game_name,omitempty"`
+	SubjectUserID   *uuid.UUID `json:"subject_user_id,omitempty"` // Submitter, or author of the reported content
+	ContentType     *string    `json:"content_type,omitempty"`    // Reported content type (clip, comment, user)
+	Reason          *string    `json:"reason,omitempty"`          // Report reason
+	Outcome         string     `json:"outcome"`                   // Submission or report status
+	DecisionReason  *string    `json:"decision_reason,omitempty"` // Rejection reason for submissions
+	DecidedAt       *time.Time `json:"decided_at,omitempty"`
+	DecidedBy       *uuid.UUID `json:"decided_by,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// SimilarModerationCase is a past decided case ranked by how closely it matches another case
+type SimilarModerationCase struct {
+	ModerationCase
+	Similarity *float64 `json:"similarity,omitempty"` // Cosine similarity of titles, when embeddings are available
+	MatchedOn  []string `json:"matched_on"`           // title, broadcaster, game, user, reason
+	Score      float64  `json:"score"`
+}
+
+// SimilarModerationCases is the response for a similar past decisions lookup
+type SimilarModerationCases struct {
+	Case           *ModerationCase         `json:"case"`
+	SimilarCases   []SimilarModerationCase `json:"similar_cases"`
+	OutcomeSummary map[string]int          `json:"outcome_summary"`
+	UsedEmbeddings bool                    `json:"used_embeddings"`
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Email digest frequencies (NotificationPreferences.EmailDigest)
+const (
+	EmailDigestImmediate = "immediate"
+	EmailDigestDaily     = "daily"
+	EmailDigestWeekly    = "weekly"
+	EmailDigestNever     = "never"
+)
+
+// Notification digest statuses
+const (
+	NotificationDigestStatusPending = "pending"
+	NotificationDigestStatusSent    = "sent"
+	NotificationDigestStatusSkipped = "skipped" // Nothing to include for the period
+	NotificationDigestStatusFailed  = "failed"
+)
+
+// NotificationTypeDigest is the email type of digest emails, used in email logs and unsubscribe tokens
+const NotificationTypeDigest = "notification_digest"
+
+// NotificationDigest records a daily or weekly summary email for one user and period
+type NotificationDigest struct {
+	ID                uuid.UUID  `json:"id" db:"id"`
+	UserID            uuid.UUID  `json:"user_id" db:"user_id"`
+	Frequency         string     `json:"frequency" db:"frequency"`
+	PeriodStart       time.Time  `json:"period_start" db:"period_start"`
+	PeriodEnd         time.Time  `json:"period_end" db:"period_end"`
+	Status            string     `json:"status" db:"status"`
+	NotificationCount int        `json:"notification_count" db:"notification_count"`
+	Attempts          int        `json:"attempts" db:"attempts"`
+	ErrorMessage      *string    `json:"error_message,omitempty" db:"error_message"`
+	SentAt            *time.Time `json:"sent_at,omitempty" db:"sent_at"`
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+}
+
+// NotificationDigestItem is one line of a digest email. Repeated notifications
+// about the same thing are folded into one item.
+type NotificationDigestItem struct {
+	Type      string
+	Title     string
+	Message   string
+	Link      string
+	Count     int
+	CreatedAt time.Time
+}
+
+// NotificationDigestSection groups digest items under a heading
+type NotificationDigestSection struct {
+	Heading string
+	Items   []NotificationDigestItem
+}
+
+// NotificationDigestContent is the data rendered into a digest email
+type NotificationDigestContent struct {
+	Frequency   string
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Total       int // Notifications covered, including folded repeats and omitted items
+	Sections    []NotificationDigestSection
+	Omitted     int // Items left out to keep the email short
+}
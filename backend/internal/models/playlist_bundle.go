@@ -0,0 +1,85 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PlaylistBundleCurrency is the currency bundles are priced in
+const PlaylistBundleCurrency = "usd"
+
+// Playlist bundle entitlement statuses
+const (
+	PlaylistBundleEntitlementActive   = "active"   // Buyer can see the playlist's clips
+	PlaylistBundleEntitlementRefunded = "refunded" // Payment was fully refunded and access revoked
+)
+
+// Creator ledger entry types
+const (
+	CreatorLedgerEntryBundleSale   = "bundle_sale"
+	CreatorLedgerEntryBundleRefund = "bundle_refund"
+)
+
+// PlaylistBundle marks a playlist as premium. Its clips are only listed to the
+// owner, collaborators and users who bought it with a one-time payment.
+type PlaylistBundle struct {
+	PlaylistID uuid.UUID `json:"playlist_id" db:"playlist_id"`
+	PriceCents int       `json:"price_cents" db:"price_cents"`
+	Currency   string    `json:"currency" db:"currency"`
+	IsActive   bool      `json:"is_active" db:"is_active"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// PlaylistBundleEntitlement records a user's purchase of a playlist bundle
+type PlaylistBundleEntitlement struct {
+	ID                      uuid.UUID  `json:"id" db:"id"`
+	PlaylistID              uuid.UUID  `json:"playlist_id" db:"playlist_id"`
+	UserID                  uuid.UUID  `json:"user_id" db:"user_id"`
+	Status                  string     `json:"status" db:"status"`
+	AmountCents             int64      `json:"amount_cents" db:"amount_cents"`
+	Currency                string     `json:"currency" db:"currency"`
+	StripeCheckoutSessionID *string    `json:"-" db:"stripe_checkout_session_id"`
+	StripePaymentIntentID   *string    `json:"-" db:"stripe_payment_intent_id"`
+	GrantedAt               time.Time  `json:"granted_at" db:"granted_at"`
+	RevokedAt               *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// CreatorLedgerEntry is one line of a creator's earnings. Sales credit the
+// creator's share of a payment and refunds debit it with negative amounts.
+type CreatorLedgerEntry struct {
+	ID               uuid.UUID  `json:"id" db:"id"`
+	CreatorID        uuid.UUID  `json:"creator_id" db:"creator_id"`
+	EntitlementID    *uuid.UUID `json:"entitlement_id,omitempty" db:"entitlement_id"`
+	EntryType        string     `json:"entry_type" db:"entry_type"`
+	GrossCents       int64      `json:"gross_cents" db:"gross_cents"`
+	PlatformFeeCents int64      `json:"platform_fee_cents" db:"platform_fee_cents"`
+	CreatorCents     int64      `json:"creator_cents" db:"creator_cents"`
+	Currency         string     `json:"currency" db:"currency"`
+	StripeEventID    *string    `json:"-" db:"stripe_event_id"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+}
+
+// CreatorLedgerSummary totals a creator's ledger in one currency
+type CreatorLedgerSummary struct {
+	Currency         string `json:"currency" db:"currency"`
+	GrossCents       int64  `json:"gross_cents" db:"gross_cents"`
+	PlatformFeeCents int64  `json:"platform_fee_cents" db:"platform_fee_cents"`
+	CreatorCents     int64  `json:"creator_cents" db:"creator_cents"`
+	Sales            int    `json:"sales" db:"sales"`
+	Refunds          int    `json:"refunds" db:"refunds"`
+}
+
+// PlaylistBundleAccess describes a premium playlist's price and whether the
+// viewer may see its clips
+type PlaylistBundleAccess struct {
+	Bundle    *PlaylistBundle `json:"bundle,omitempty"`
+	HasAccess bool            `json:"has_access"`
+	Purchased bool            `json:"purchased"`
+}
+
+// SetPlaylistBundleRequest represents the request to sell a playlist as a bundle
+type SetPlaylistBundleRequest struct {
+	PriceCents int `json:"price_cents" binding:"required,min=100,max=50000"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProfileViewDay is a profile's view count and estimated unique viewers for a day
+type ProfileViewDay struct {
+	Date          time.Time `json:"date"`
+	Views         int64     `json:"views"`
+	UniqueViewers int64     `json:"unique_viewers"`
+}
+
+// ProfileViewStats is a profile's view counter. Unique viewers are a
+// HyperLogLog estimate over the last 30 days, and the daily breakdown is
+// only returned to the profile's owner.
+type ProfileViewStats struct {
+	UserID              uuid.UUID        `json:"user_id"`
+	TotalViews          int64            `json:"total_views"`
+	UniqueViewers30Days int64            `json:"unique_viewers_30d"`
+	Daily               []ProfileViewDay `json:"daily,omitempty"`
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReengagementMilestones are the days without activity after which an
+// inactive user is emailed, in ascending order
+var ReengagementMilestones = []int{14, 30, 60}
+
+// Re-engagement email statuses
+const (
+	ReengagementStatusPending = "pending"
+	ReengagementStatusSent    = "sent"
+	ReengagementStatusSkipped = "skipped"
+	ReengagementStatusFailed  = "failed"
+)
+
+// Reasons a due re-engagement email was not sent
+const (
+	ReengagementSkipActive   = "active"    // The user came back before the email went out
+	ReengagementSkipOptedOut = "opted_out" // Email or re-engagement emails are turned off
+	ReengagementSkipNoEmail  = "no_email"
+	ReengagementSkipNoClips  = "no_clips" // Nothing new to show
+)
+
+// NotificationTypeReengagement is the email type of re-engagement emails, used
+// in email logs, tracked links and unsubscribe tokens
+const NotificationTypeReengagement = "reengagement"
+
+// ReengagementCandidate is an inactive user due a milestone email
+type ReengagementCandidate struct {
+	UserID        uuid.UUID
+	MilestoneDays int
+	InactiveSince time.Time // Last activity, which identifies the inactivity streak
+}
+
+// ReengagementEmail records a re-engagement email for one user, inactivity
+// streak and milestone
+type ReengagementEmail struct {
+	ID            uuid.UUID  `json:"id" db:"id"`
+	UserID        uuid.UUID  `json:"user_id" db:"user_id"`
+	MilestoneDays int        `json:"milestone_days" db:"milestone_days"`
+	InactiveSince time.Time  `json:"inactive_since" db:"inactive_since"`
+	Status        string     `json:"status" db:"status"`
+	SkipReason    *string    `json:"skip_reason,omitempty" db:"skip_reason"`
+	ClipCount     int        `json:"clip_count" db:"clip_count"`
+	FromFollows   bool       `json:"from_follows" db:"from_follows"`
+	EmailLogID    *uuid.UUID `json:"email_log_id,omitempty" db:"email_log_id"`
+	Attempts      int        `json:"attempts" db:"attempts"`
+	ErrorMessage  *string    `json:"error_message,omitempty" db:"error_message"`
+	SentAt        *time.Time `json:"sent_at,omitempty" db:"sent_at"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+}
+
+// ReengagementClip is a clip shown in a re-engagement email
+type ReengagementClip struct {
+	ID              uuid.UUID
+	Title           string
+	BroadcasterName string
+	GameName        *string
+	ThumbnailURL    *string
+	VoteScore       int
+}
+
+// ReengagementEmailContent is the data rendered into a re-engagement email
+type ReengagementEmailContent struct {
+	MilestoneDays int
+	InactiveSince time.Time
+	Clips         []ReengagementClip
+	FromFollows   bool // Clips come from the user's follows rather than the whole site
+}
+
+// ReengagementCampaignMetrics summarizes one milestone's emails over a period.
+// A user returned when they were active within the return window after the email.
+type ReengagementCampaignMetrics struct {
+	MilestoneDays int      `json:"milestone_days"`
+	Sent          int      `json:"sent"`
+	Skipped       int      `json:"skipped"`
+	Suppressed    int      `json:"suppressed"` // Skipped because the user came back first
+	Failed        int      `json:"failed"`
+	Clicked       int      `json:"clicked"`
+	Returned      int      `json:"returned"`
+	ClickRate     *float64 `json:"click_rate,omitempty"`  // Percent of sent
+	ReturnRate    *float64 `json:"return_rate,omitempty"` // Percent of sent
+}
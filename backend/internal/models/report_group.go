@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Report group states
+const (
+	ReportGroupOpen     = "open"     // Awaiting review; new reports on the item join the group
+	ReportGroupResolved = "resolved" // Reviewed; the next report on the item opens a new group
+)
+
+// ReportGroup collects the open reports against one clip, comment or user so
+// the item is reviewed once, however many users reported it
+type ReportGroup struct {
+	ID               uuid.UUID           `json:"id" db:"id"`
+	ReportableType   string              `json:"reportable_type" db:"reportable_type"`
+	ReportableID     uuid.UUID           `json:"reportable_id" db:"reportable_id"`
+	Status           string              `json:"status" db:"status"`
+	ReportCount      int                 `json:"report_count" db:"report_count"`
+	AvgReporterTrust float64             `json:"avg_reporter_trust" db:"avg_reporter_trust"`
+	Reach            int64               `json:"reach" db:"reach"`
+	PriorityScore    float64             `json:"priority_score" db:"priority_score"`
+	ScoredAt         *time.Time          `json:"scored_at,omitempty" db:"scored_at"`
+	FirstReportedAt  time.Time           `json:"first_reported_at" db:"first_reported_at"`
+	LastReportedAt   time.Time           `json:"last_reported_at" db:"last_reported_at"`
+	Resolution       *string             `json:"resolution,omitempty" db:"resolution"`
+	ResolvedBy       *uuid.UUID          `json:"resolved_by,omitempty" db:"resolved_by"`
+	ResolvedAt       *time.Time          `json:"resolved_at,omitempty" db:"resolved_at"`
+	Reasons          []ReportReasonCount `json:"reasons" db:"-"`
+}
+
+// ReportReasonCount is how many of a group's reports gave a reason
+type ReportReasonCount struct {
+	Reason string `json:"reason"`
+	Count  int    `json:"count"`
+}
+
+// ReportGroupStats are the inputs to a report group's priority score
+type ReportGroupStats struct {
+	GroupID          uuid.UUID
+	ReportCount      int
+	AvgReporterTrust float64 // Mean trust score (0-100) of the reporters
+	Reach            int64
+}
+
+// ReportGroupDetail is a report group with its individual reports
+type ReportGroupDetail struct {
+	ReportGroup
+	Reports []Report `json:"reports"`
+}
+
+// ReportQueueFilters narrows the prioritized report queue
+type ReportQueueFilters struct {
+	Status         string
+	ReportableType string
+	Limit          int
+	Offset         int
+}
+
+// ResolveReportGroupRequest resolves every open report in a group at once
+type ResolveReportGroupRequest struct {
+	Status string  `json:"status" binding:"required,oneof=actioned dismissed"`
+	Action *string `json:"action,omitempty"` // remove_content, warn_user, ban_user, mark_false
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RequestCaptureSession samples requests to one route so hard-to-reproduce
+// API issues can be debugged from real traffic. Sessions end on their own,
+// and their captures are kept a while longer for reading.
+type RequestCaptureSession struct {
+	ID            uuid.UUID  `json:"id"`
+	Method        string     `json:"method"`
+	Route         string     `json:"route"` // Route pattern, e.g. /api/v1/clips/:id
+	SamplePercent float64    `json:"sample_percent"`
+	MaxCaptures   int        `json:"max_captures"` // Ring buffer size; older captures are dropped
+	Reason        *string    `json:"reason,omitempty"`
+	CreatedBy     uuid.UUID  `json:"created_by"`
+	CreatedAt     time.Time  `json:"created_at"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	StoppedAt     *time.Time `json:"stopped_at,omitempty"`
+}
+
+// Active reports whether the session still samples requests at now
+func (s *RequestCaptureSession) Active(now time.Time) bool {
+	return s.StoppedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// StartRequestCaptureRequest starts a capture session
+type StartRequestCaptureRequest struct {
+	Method          string  `json:"method" binding:"required,oneof=GET POST PUT PATCH DELETE"`
+	Route           string  `json:"route" binding:"required,startswith=/,max=200"`
+	SamplePercent   float64 `json:"sample_percent" binding:"required,gt=0,lte=100"`
+	DurationMinutes int     `json:"duration_minutes" binding:"required,min=1"`
+	MaxCaptures     int     `json:"max_captures,omitempty" binding:"omitempty,min=1"`
+	Reason          *string `json:"reason,omitempty" binding:"omitempty,max=500"`
+}
+
+// RequestCapture is one sampled request and its response. Credentials,
+// cookies and PII are redacted, and bodies are cut at a size limit.
+type RequestCapture struct {
+	ID                    uuid.UUID         `json:"id"`
+	SessionID             uuid.UUID         `json:"session_id"`
+	RequestID             string            `json:"request_id,omitempty"`
+	TraceID               string            `json:"trace_id,omitempty"`
+	Method                string            `json:"method"`
+	Route                 string            `json:"route"`
+	Path                  string            `json:"path"`
+	Query                 string            `json:"query,omitempty"`
+	Status                int               `json:"status"`
+	DurationMs            float64           `json:"duration_ms"`
+	UserHash              string            `json:"user_hash,omitempty"` // Hashed user ID, as in request logs
+	RequestHeaders        map[string]string `json:"request_headers"`
+	RequestBody           string            `json:"request_body,omitempty"`
+	RequestBodyTruncated  bool              `json:"request_body_truncated,omitempty"`
+	ResponseHeaders       map[string]string `json:"response_headers"`
+	ResponseBody          string            `json:"response_body,omitempty"`
+	ResponseBodyTruncated bool              `json:"response_body_truncated,omitempty"`
+	Errors                []string          `json:"errors,omitempty"`
+	CapturedAt            time.Time         `json:"captured_at"`
+}
@@ -16,6 +16,14 @@ const (
 	AccountTypeAdmin              = "admin"
 )
 
+// Admin sub-role constants. Sub-roles grant a scoped slice of admin access
+// without the full admin role.
+const (
+	AdminRoleSupport     = "support"
+	AdminRoleTrustSafety = "trust_safety"
+	AdminRoleFinance     = "finance"
+)
+
 // Moderator scope constants
 const (
 	ModeratorScopeSite      = "site"
@@ -49,8 +57,37 @@ const (
 	PermissionManageSystem           = "manage:system"
 	PermissionViewAnalyticsDashboard = "view:analytics_dashboard"
 	PermissionModerateOverride       = "moderate:override"
+	PermissionManageAdminRoles       = "manage:admin_roles"
+
+	// Admin sub-role permissions
+	PermissionViewUsers        = "view:users"
+	PermissionImpersonateUsers = "impersonate:users"
+	PermissionManageContact    = "manage:contact"
+	PermissionManageBans       = "manage:bans"
+	PermissionViewRevenue      = "view:revenue"
+	PermissionManageBilling    = "manage:billing"
 )
 
+// adminRolePermissions maps admin sub-roles to their permission bundles
+var adminRolePermissions = map[string][]string{
+	AdminRoleSupport: {
+		PermissionViewUsers,
+		PermissionImpersonateUsers,
+		PermissionManageContact,
+	},
+	AdminRoleTrustSafety: {
+		PermissionViewUsers,
+		PermissionModerateContent,
+		PermissionModerateUsers,
+		PermissionManageBans,
+		PermissionCommunityModerate,
+	},
+	AdminRoleFinance: {
+		PermissionViewRevenue,
+		PermissionManageBilling,
+	},
+}
+
 // accountTypePermissions maps account types to their permissions
 var accountTypePermissions = map[string][]string{
 	AccountTypeMember: {
@@ -83,6 +120,8 @@ var accountTypePermissions = map[string][]string{
 		PermissionCreateDiscoveryLists,
 		// User management permissions
 		PermissionManageUsers,
+		PermissionViewUsers,
+		PermissionManageBans,
 	},
 	// Community Moderator: Channel-scoped moderator with limited permissions
 	// Has exactly 4 permissions for managing their assigned channel(s):
@@ -119,6 +158,14 @@ var accountTypePermissions = map[string][]string{
 		PermissionManageSystem,
 		PermissionViewAnalyticsDashboard,
 		PermissionModerateOverride,
+		PermissionManageAdminRoles,
+		// Admin sub-role permissions
+		PermissionViewUsers,
+		PermissionImpersonateUsers,
+		PermissionManageContact,
+		PermissionManageBans,
+		PermissionViewRevenue,
+		PermissionManageBilling,
 	},
 }
 
@@ -142,6 +189,17 @@ func IsValidAccountType(accountType string) bool {
 	}
 }
 
+// IsValidAdminRole checks if an admin sub-role string is valid
+func IsValidAdminRole(adminRole string) bool {
+	_, ok := adminRolePermissions[adminRole]
+	return ok
+}
+
+// GetAdminRolePermissions returns the permission bundle for an admin sub-role
+func GetAdminRolePermissions(adminRole string) []string {
+	return adminRolePermissions[adminRole]
+}
+
 // GetAccountTypePermissions returns all permissions for a given account type
 func GetAccountTypePermissions(accountType string) []string {
 	if permissions, ok := accountTypePermissions[accountType]; ok {
@@ -181,11 +239,27 @@ func (u *User) IsModeratorOrAdmin() bool {
 	return u.Role == RoleModerator || u.Role == RoleAdmin
 }
 
+// HasAdminRole checks if the user holds a specific admin sub-role
+func (u *User) HasAdminRole(adminRole string) bool {
+	for _, r := range u.AdminRoles {
+		if r == adminRole {
+			return true
+		}
+	}
+	return false
+}
+
+// IsStaff checks if the user can access the admin area through their role or a sub-role
+func (u *User) IsStaff() bool {
+	return u.IsModeratorOrAdmin() || len(u.AdminRoles) > 0
+}
+
 // Can checks if a user has a specific permission based on their account type.
 // Note: This system supports dual permission paths for backward compatibility:
 // - Role (admin/moderator/user): Legacy system for basic access control
 // - AccountType (admin/moderator/broadcaster/member): New granular permission system
-// Both Role=admin and AccountType=admin grant all permissions.
+// Both Role=admin and AccountType=admin grant all permissions, and
+// Role=moderator grants the moderator permissions.
 // In most cases, AccountType should be the primary source of permissions,
 // while Role is used for basic authentication and route protection.
 func (u *User) Can(permission string) bool {
@@ -194,11 +268,11 @@ func (u *User) Can(permission string) bool {
 		return true
 	}
 
-	// Check if the user's account type has this permission
-	permissions := GetAccountTypePermissions(u.AccountType)
-	for _, p := range permissions {
-		if p == permission {
-			return true
+	for _, bundle := range u.permissionBundles() {
+		for _, p := range bundle {
+			if p == permission {
+				return true
+			}
 		}
 	}
 	return false
@@ -212,10 +286,39 @@ func (u *User) GetAccountType() string {
 	return u.AccountType
 }
 
-// GetPermissions returns all permissions for the user's account type
+// GetPermissions returns all permissions for the user's account type, legacy
+// moderator role and admin sub-roles
 func (u *User) GetPermissions() []string {
-	accountType := u.GetAccountType()
-	return GetAccountTypePermissions(accountType)
+	bundles := u.permissionBundles()
+	if len(bundles) == 1 {
+		return bundles[0]
+	}
+
+	seen := make(map[string]bool)
+	var merged []string
+	for _, bundle := range bundles {
+		for _, p := range bundle {
+			if !seen[p] {
+				seen[p] = true
+				merged = append(merged, p)
+			}
+		}
+	}
+	return merged
+}
+
+// permissionBundles returns the permission bundles the user holds. Users given
+// the moderator role alone, without the moderator account type, hold the
+// moderator bundle too.
+func (u *User) permissionBundles() [][]string {
+	bundles := [][]string{GetAccountTypePermissions(u.GetAccountType())}
+	if u.IsModerator() {
+		bundles = append(bundles, GetAccountTypePermissions(AccountTypeModerator))
+	}
+	for _, adminRole := range u.AdminRoles {
+		bundles = append(bundles, GetAdminRolePermissions(adminRole))
+	}
+	return bundles
 }
 
 // IsValidModerator checks if the user has valid moderator configuration
@@ -228,7 +228,7 @@ func TestGetAccountTypePermissions(t *testing.T) {
 		{
 			name:          "moderator permissions",
 			accountType:   AccountTypeModerator,
-			expectedCount: 10,
+			expectedCount: 12,
 			mustHavePerms: []string{
 				PermissionCreateSubmission,
 				PermissionViewBroadcasterAnalytics,
@@ -236,9 +236,13 @@ func TestGetAccountTypePermissions(t *testing.T) {
 				PermissionModerateUsers,
 				PermissionCreateDiscoveryLists,
 				PermissionManageUsers,
+				PermissionViewUsers,
+				PermissionManageBans,
 			},
 			mustNotHavePerms: []string{
 				PermissionManageSystem,
+				PermissionImpersonateUsers,
+				PermissionViewRevenue,
 			},
 		},
 		{
@@ -264,7 +268,7 @@ func TestGetAccountTypePermissions(t *testing.T) {
 		{
 			name:          "admin permissions",
 			accountType:   AccountTypeAdmin,
-			expectedCount: 16,
+			expectedCount: 23,
 			mustHavePerms: []string{
 				PermissionCreateSubmission,
 				PermissionModerateContent,
@@ -274,6 +278,9 @@ func TestGetAccountTypePermissions(t *testing.T) {
 				PermissionCommunityModerate,
 				PermissionViewChannelAnalytics,
 				PermissionManageModerators,
+				PermissionManageAdminRoles,
+				PermissionImpersonateUsers,
+				PermissionViewRevenue,
 			},
 		},
 		{
@@ -374,6 +381,20 @@ func TestUserCan(t *testing.T) {
 			permission:  PermissionManageSystem,
 			expected:    false,
 		},
+		{
+			name:        "moderator role with member account type can moderate content",
+			accountType: AccountTypeMember,
+			role:        RoleModerator,
+			permission:  PermissionModerateContent,
+			expected:    true,
+		},
+		{
+			name:        "moderator role with member account type cannot manage system",
+			accountType: AccountTypeMember,
+			role:        RoleModerator,
+			permission:  PermissionManageSystem,
+			expected:    false,
+		},
 		// Community Moderator tests
 		{
 			name:        "community moderator can use community:moderate permission",
@@ -650,3 +671,82 @@ func TestUserIsValidModerator(t *testing.T) {
 		})
 	}
 }
+
+func TestAdminRolePermissions(t *testing.T) {
+	tests := []struct {
+		name        string
+		adminRoles  []string
+		permission  string
+		expectedCan bool
+	}{
+		{"support can view users", []string{AdminRoleSupport}, PermissionViewUsers, true},
+		{"support can impersonate users", []string{AdminRoleSupport}, PermissionImpersonateUsers, true},
+		{"support cannot manage bans", []string{AdminRoleSupport}, PermissionManageBans, false},
+		{"support cannot view revenue", []string{AdminRoleSupport}, PermissionViewRevenue, false},
+		{"trust and safety can moderate content", []string{AdminRoleTrustSafety}, PermissionModerateContent, true},
+		{"trust and safety can manage bans", []string{AdminRoleTrustSafety}, PermissionManageBans, true},
+		{"trust and safety cannot impersonate", []string{AdminRoleTrustSafety}, PermissionImpersonateUsers, false},
+		{"finance can view revenue", []string{AdminRoleFinance}, PermissionViewRevenue, true},
+		{"finance can manage billing", []string{AdminRoleFinance}, PermissionManageBilling, true},
+		{"finance cannot moderate", []string{AdminRoleFinance}, PermissionModerateContent, false},
+		{"combined roles merge bundles", []string{AdminRoleSupport, AdminRoleFinance}, PermissionViewRevenue, true},
+		{"no sub-role cannot assign admin roles", []string{AdminRoleSupport, AdminRoleTrustSafety, AdminRoleFinance}, PermissionManageAdminRoles, false},
+		{"unknown sub-role grants nothing", []string{"janitor"}, PermissionViewUsers, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{
+				ID:          uuid.New(),
+				Role:        RoleUser,
+				AccountType: AccountTypeMember,
+				AdminRoles:  tt.adminRoles,
+			}
+			if got := user.Can(tt.permission); got != tt.expectedCan {
+				t.Errorf("Can(%q) = %v, want %v", tt.permission, got, tt.expectedCan)
+			}
+		})
+	}
+}
+
+func TestUserGetPermissions_WithAdminRoles(t *testing.T) {
+	user := &User{
+		ID:          uuid.New(),
+		AccountType: AccountTypeModerator,
+		AdminRoles:  []string{AdminRoleTrustSafety, AdminRoleSupport},
+	}
+
+	perms := user.GetPermissions()
+	seen := make(map[string]int)
+	for _, p := range perms {
+		seen[p]++
+	}
+
+	for p, count := range seen {
+		if count > 1 {
+			t.Errorf("permission %q listed %d times", p, count)
+		}
+	}
+	if seen[PermissionImpersonateUsers] != 1 {
+		t.Error("expected support bundle permissions to be merged")
+	}
+	if seen[PermissionModerateContent] != 1 {
+		t.Error("expected account type permissions to be kept")
+	}
+}
+
+func TestUserIsStaff(t *testing.T) {
+	if (&User{Role: RoleUser}).IsStaff() {
+		t.Error("regular user should not be staff")
+	}
+	if !(&User{Role: RoleModerator}).IsStaff() {
+		t.Error("moderator should be staff")
+	}
+	financeUser := &User{Role: RoleUser, AdminRoles: []string{AdminRoleFinance}}
+	if !financeUser.IsStaff() || !financeUser.HasAdminRole(AdminRoleFinance) {
+		t.Error("user with an admin sub-role should be staff")
+	}
+	if !IsValidAdminRole(AdminRoleTrustSafety) || IsValidAdminRole("janitor") {
+		t.Error("IsValidAdminRole returned unexpected result")
+	}
+}
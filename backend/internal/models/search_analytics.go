@@ -0,0 +1,83 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SearchClickRequest records that a result of a tracked search was opened.
+// SearchID is the search_id returned with the search's results.
+type SearchClickRequest struct {
+	SearchID   uuid.UUID `json:"search_id" binding:"required"`
+	ResultID   uuid.UUID `json:"result_id" binding:"required"`
+	ResultType string    `json:"result_type" binding:"required"`
+	Position   int       `json:"position" binding:"required"` // 1-based rank in the results
+}
+
+// SearchAnalyticsReport is the search analytics dashboard for a period
+type SearchAnalyticsReport struct {
+	Start             time.Time               `json:"start"`
+	End               time.Time               `json:"end"`
+	Interval          string                  `json:"interval"`
+	Summary           SearchAnalyticsTotals   `json:"summary"`
+	TopQueries        []SearchQueryStats      `json:"top_queries"`
+	ZeroResultQueries []SearchQueryStats      `json:"zero_result_queries"`
+	CTRByPosition     []SearchPositionCTR     `json:"ctr_by_position"`
+	Trends            []SearchAnalyticsTotals `json:"trends"`
+}
+
+// SearchAnalyticsTotals counts searches and clicks over a period. In trends,
+// PeriodStart is the start of the day or week counted.
+type SearchAnalyticsTotals struct {
+	PeriodStart        *time.Time `json:"period_start,omitempty"`
+	Searches           int        `json:"searches"`
+	UniqueUsers        int        `json:"unique_users"`
+	ZeroResultSearches int        `json:"zero_result_searches"`
+	ClickedSearches    int        `json:"clicked_searches"`
+	ZeroResultRate     float64    `json:"zero_result_rate"`
+	CTR                float64    `json:"ctr"`
+}
+
+// SearchQueryStats aggregates the searches for one query, compared
+// case-insensitively
+type SearchQueryStats struct {
+	Query              string    `json:"query"`
+	Searches           int       `json:"searches"`
+	UniqueUsers        int       `json:"unique_users"`
+	AvgResults         float64   `json:"avg_results"`
+	ZeroResultSearches int       `json:"zero_result_searches"`
+	ClickedSearches    int       `json:"clicked_searches"`
+	CTR                float64   `json:"ctr"`
+	LastSearched       time.Time `json:"last_searched"`
+}
+
+// SearchPositionCTR is the click-through rate of a result position. A search
+// counts as an impression for every position up to its result count.
+type SearchPositionCTR struct {
+	Position    int     `json:"position"`
+	Impressions int     `json:"impressions"`
+	Clicks      int     `json:"clicks"`
+	CTR         float64 `json:"ctr"`
+}
+
+// SearchClickJudgment is how often the searches for a query clicked a clip.
+// Searches and UniqueUsers count all the query's searches, clicked or not.
+type SearchClickJudgment struct {
+	Query           string
+	Searches        int
+	UniqueUsers     int
+	ClipID          uuid.UUID
+	ClickedSearches int
+	AvgPosition     float64
+}
+
+// SearchClickedSearch is a tracked search by a signed-in user and the clips
+// clicked from its results. Query is normalized to lower case.
+type SearchClickedSearch struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Query     string
+	CreatedAt time.Time
+	ClipIDs   []uuid.UUID
+}
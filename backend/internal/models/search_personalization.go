@@ -0,0 +1,43 @@
+package models
+
+// Values of the search personalization parameter
+const (
+	SearchPersonalizationOn  = "on"
+	SearchPersonalizationOff = "off"
+)
+
+// SearchPersonalizationProfile holds the signals used to re-rank a user's
+// search results. Broadcasters are keyed by Twitch broadcaster ID and games
+// by Twitch game ID, matching the clip fields. Languages are lowercase clip
+// language codes.
+type SearchPersonalizationProfile struct {
+	FollowedBroadcasters map[string]bool `json:"followed_broadcasters"`
+	FollowedGames        map[string]bool `json:"followed_games"`
+	DownvotedGames       map[string]bool `json:"downvoted_games"`
+
+	// InterestBroadcasters and InterestGames are the streamers and games the
+	// user picked as interests, e.g. during onboarding
+	InterestBroadcasters map[string]bool `json:"interest_broadcasters"`
+	InterestGames        map[string]bool `json:"interest_games"`
+
+	// Languages are the languages of the clips the user watches and upvotes
+	Languages map[string]bool `json:"languages"`
+}
+
+// NewSearchPersonalizationProfile returns an empty profile
+func NewSearchPersonalizationProfile() *SearchPersonalizationProfile {
+	return &SearchPersonalizationProfile{
+		FollowedBroadcasters: map[string]bool{},
+		FollowedGames:        map[string]bool{},
+		DownvotedGames:       map[string]bool{},
+		InterestBroadcasters: map[string]bool{},
+		InterestGames:        map[string]bool{},
+		Languages:            map[string]bool{},
+	}
+}
+
+// IsEmpty reports whether the profile has no signals to rank with
+func (p *SearchPersonalizationProfile) IsEmpty() bool {
+	return p == nil || len(p.FollowedBroadcasters)+len(p.FollowedGames)+len(p.DownvotedGames)+
+		len(p.InterestBroadcasters)+len(p.InterestGames)+len(p.Languages) == 0
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Search index sync modes
+const (
+	SearchSyncModeRebuild     = "rebuild"
+	SearchSyncModeIncremental = "incremental"
+)
+
+// SearchSyncState is the incremental reindexing checkpoint of a search index.
+// Rows changed at or after SyncedThrough haven't been indexed yet.
+type SearchSyncState struct {
+	IndexName        string    `json:"index_name" db:"index_name"`
+	SyncedThrough    time.Time `json:"synced_through" db:"synced_through"`
+	LastRunAt        time.Time `json:"last_run_at" db:"last_run_at"`
+	LastMode         string    `json:"last_mode" db:"last_mode"`
+	DocumentsIndexed int64     `json:"documents_indexed" db:"documents_indexed"`
+	DocumentsDeleted int64     `json:"documents_deleted" db:"documents_deleted"`
+}
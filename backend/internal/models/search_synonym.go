@@ -0,0 +1,65 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Search synonym kinds
+const (
+	SearchSynonymEquivalent = "equivalent" // Every term matches every other term
+	SearchSynonymAlias      = "alias"      // Terms also match the target, but not the reverse
+)
+
+// SearchSynonym makes search terms match each other, such as "gta" and
+// "grand theft auto v" or a streamer's nickname and channel name. Terms are
+// lowercase and may be several words.
+type SearchSynonym struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	Kind        string     `json:"kind" db:"kind"`
+	Terms       []string   `json:"terms" db:"terms"`
+	Target      *string    `json:"target,omitempty" db:"target"`
+	Description *string    `json:"description,omitempty" db:"description"`
+	Enabled     bool       `json:"enabled" db:"enabled"`
+	CreatedBy   *uuid.UUID `json:"created_by,omitempty" db:"created_by"`
+	UpdatedBy   *uuid.UUID `json:"updated_by,omitempty" db:"updated_by"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// Rule returns the synonym in Solr format, as used by OpenSearch synonym
+// filters. An alias keeps its own terms so they still match themselves.
+func (s *SearchSynonym) Rule() string {
+	terms := strings.Join(s.Terms, ", ")
+	if s.Kind == SearchSynonymAlias && s.Target != nil {
+		return terms + " => " + terms + ", " + *s.Target
+	}
+	return terms
+}
+
+// CreateSearchSynonymRequest represents the request to create a search synonym
+type CreateSearchSynonymRequest struct {
+	Kind        string   `json:"kind" binding:"required,oneof=equivalent alias"`
+	Terms       []string `json:"terms" binding:"required,min=1,max=50"`
+	Target      *string  `json:"target,omitempty" binding:"omitempty,max=100"`
+	Description *string  `json:"description,omitempty" binding:"omitempty,max=500"`
+	Enabled     *bool    `json:"enabled,omitempty"`
+}
+
+// UpdateSearchSynonymRequest represents the request to change a search
+// synonym. Omitted fields are left as they are; Terms replaces every term.
+type UpdateSearchSynonymRequest struct {
+	Terms       *[]string `json:"terms,omitempty" binding:"omitempty,min=1,max=50"`
+	Target      *string   `json:"target,omitempty" binding:"omitempty,max=100"`
+	Description *string   `json:"description,omitempty" binding:"omitempty,max=500"`
+	Enabled     *bool     `json:"enabled,omitempty"`
+}
+
+// SearchSynonymRules are the enabled synonyms as search analyzer rules, with
+// a version that changes whenever the rules do
+type SearchSynonymRules struct {
+	Rules   []string `json:"rules"`
+	Version string   `json:"version"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SessionClient describes the device a login or token refresh came from
+type SessionClient struct {
+	IPAddress string
+	UserAgent string
+}
+
+// UserSession is a signed-in device, backed by the session's current refresh token
+type UserSession struct {
+	ID           uuid.UUID `json:"id" db:"session_id"`
+	DeviceName   string    `json:"device_name" db:"-"`
+	IPAddress    *string   `json:"ip_address,omitempty" db:"ip_address"`
+	UserAgent    *string   `json:"user_agent,omitempty" db:"user_agent"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	LastActiveAt time.Time `json:"last_active_at" db:"last_active_at"`
+	ExpiresAt    time.Time `json:"expires_at" db:"expires_at"`
+	Current      bool      `json:"current" db:"-"`
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Share channel constants
+const (
+	ShareChannelCopy    = "copy"
+	ShareChannelDiscord = "discord"
+	ShareChannelTwitter = "twitter"
+	ShareChannelReddit  = "reddit"
+	ShareChannelEmbed   = "embed"
+	ShareChannelOther   = "other"
+)
+
+// Share link event type constants
+const (
+	ShareEventClick  = "click"
+	ShareEventView   = "view"
+	ShareEventSignup = "signup"
+)
+
+// ShareLink represents a short link created when a clip is shared
+type ShareLink struct {
+	ID             uuid.UUID  `json:"id" db:"id"`
+	Code           string     `json:"code" db:"code"`
+	ClipID         uuid.UUID  `json:"clip_id" db:"clip_id"`
+	UserID         *uuid.UUID `json:"user_id,omitempty" db:"user_id"`
+	Channel        string     `json:"channel" db:"channel"`
+	ClickCount     int        `json:"click_count" db:"click_count"`
+	ViewCount      int        `json:"view_count" db:"view_count"`
+	SignupCount    int        `json:"signup_count" db:"signup_count"`
+	IsDisabled     bool       `json:"is_disabled" db:"is_disabled"`
+	DisabledReason *string    `json:"disabled_reason,omitempty" db:"disabled_reason"`
+	ExpiresAt      time.Time  `json:"expires_at" db:"expires_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	LastClickedAt  *time.Time `json:"last_clicked_at,omitempty" db:"last_clicked_at"`
+	URL            string     `json:"url,omitempty" db:"-"`
+}
+
+// ShareChannelStats holds share totals for a single channel
+type ShareChannelStats struct {
+	Channel string `json:"channel" db:"channel"`
+	Shares  int    `json:"shares" db:"shares"`
+	Clicks  int    `json:"clicks" db:"clicks"`
+	Views   int    `json:"views" db:"views"`
+	Signups int    `json:"signups" db:"signups"`
+}
+
+// ShareAnalytics aggregates share performance for a clip or creator
+type ShareAnalytics struct {
+	TotalShares  int                 `json:"total_shares"`
+	TotalClicks  int                 `json:"total_clicks"`
+	TotalViews   int                 `json:"total_views"`
+	TotalSignups int                 `json:"total_signups"`
+	Channels     []ShareChannelStats `json:"channels"`
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Smart feed size bounds
+const (
+	SmartFeedDefaultMaxClips = 100
+	SmartFeedMaxClipsLimit   = 500
+)
+
+// SmartFeedRules pick the clips of a smart feed: the newest clips meeting all
+// of the rules, up to MaxClips. Empty lists match anything.
+type SmartFeedRules struct {
+	FeedID         uuid.UUID `json:"feed_id" db:"feed_id"`
+	GameIDs        []string  `json:"game_ids" db:"game_ids"`
+	BroadcasterIDs []string  `json:"broadcaster_ids" db:"broadcaster_ids"`
+	// Tags are tag slugs; a clip matches when it has any of them
+	Tags               []string   `json:"tags" db:"tags"`
+	Languages          []string   `json:"languages" db:"languages"`
+	MinVotes           *int       `json:"min_votes,omitempty" db:"min_votes"`
+	MaxClips           int        `json:"max_clips" db:"max_clips"`
+	RulesChangedAt     time.Time  `json:"rules_changed_at" db:"rules_changed_at"`
+	LastMaterializedAt *time.Time `json:"last_materialized_at,omitempty" db:"last_materialized_at"`
+	CreatedAt          time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// HasConditions reports whether the rules have at least one condition
+func (r *SmartFeedRules) HasConditions() bool {
+	return len(r.GameIDs) > 0 || len(r.BroadcasterIDs) > 0 || len(r.Tags) > 0 ||
+		len(r.Languages) > 0 || r.MinVotes != nil
+}
+
+// SmartFeedRulesRequest turns a feed into a smart feed or replaces its rules
+type SmartFeedRulesRequest struct {
+	GameIDs        []string `json:"game_ids,omitempty" binding:"omitempty,max=100,dive,min=1,max=100"`
+	BroadcasterIDs []string `json:"broadcaster_ids,omitempty" binding:"omitempty,max=200,dive,min=1,max=100"`
+	Tags           []string `json:"tags,omitempty" binding:"omitempty,max=50,dive,min=1,max=50"`
+	Languages      []string `json:"languages,omitempty" binding:"omitempty,max=50,dive,min=2,max=10"`
+	MinVotes       *int     `json:"min_votes,omitempty"`
+	MaxClips       *int     `json:"max_clips,omitempty" binding:"omitempty,min=1,max=500"`
+}
+
+// SmartFeedMaterialization is the change a materialization run made to a
+// smart feed's clips
+type SmartFeedMaterialization struct {
+	Added   int `json:"added"`
+	Removed int `json:"removed"`
+	Version int `json:"version"`
+}
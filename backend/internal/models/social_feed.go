@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Social feed activity types
+const (
+	SocialActivityClipSubmitted = "clip_submitted"
+	SocialActivityFeedCreated   = "feed_created"
+	SocialActivityBadgeEarned   = "badge_earned"
+)
+
+// SocialActivity is something a followed user did, shown in the social feed
+type SocialActivity struct {
+	// ID identifies the activity; it is the clip ID, feed ID or badge award ID
+	ID         uuid.UUID           `json:"id"`
+	Type       string              `json:"type"`
+	Actor      ClipSubmitterInfo   `json:"actor"`
+	OccurredAt time.Time           `json:"occurred_at"`
+	Clip       *SocialActivityClip `json:"clip,omitempty"`
+	Feed       *SocialActivityFeed `json:"feed,omitempty"`
+	Badge      *Badge              `json:"badge,omitempty"`
+}
+
+// SocialActivityClip is the clip a followed user submitted
+type SocialActivityClip struct {
+	ID              uuid.UUID `json:"id"`
+	Title           string    `json:"title"`
+	BroadcasterName string    `json:"broadcaster_name"`
+	GameName        *string   `json:"game_name,omitempty"`
+	ThumbnailURL    *string   `json:"thumbnail_url,omitempty"`
+}
+
+// SocialActivityFeed is the public feed a followed user created
+type SocialActivityFeed struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description,omitempty"`
+	Icon        *string   `json:"icon,omitempty"`
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Bulk submission moderation actions
+const (
+	BulkSubmissionActionApprove = "approve"
+	BulkSubmissionActionReject  = "reject"
+)
+
+// Bulk submission item outcomes
+const (
+	BulkSubmissionItemApproved = "approved"
+	BulkSubmissionItemRejected = "rejected"
+	BulkSubmissionItemFailed   = "failed"
+)
+
+// Bulk submission item error codes
+const (
+	BulkSubmissionErrorNotFound   = "not_found"
+	BulkSubmissionErrorNotPending = "not_pending" // Already reviewed, possibly by an earlier attempt
+	BulkSubmissionErrorClipExists = "clip_exists" // The clip is already on the site
+	BulkSubmissionErrorClaimed    = "claimed"     // Another moderator has claimed the submission
+	BulkSubmissionErrorInternal   = "internal_error"
+)
+
+// Bulk submission job statuses
+const (
+	BulkSubmissionJobQueued    = "queued"
+	BulkSubmissionJobRunning   = "running"
+	BulkSubmissionJobCompleted = "completed"
+)
+
+// BulkSubmissionItemResult is the outcome of moderating one submission in a
+// bulk request. Failed items with Retryable set can be sent again.
+type BulkSubmissionItemResult struct {
+	SubmissionID uuid.UUID  `json:"submission_id"`
+	Status       string     `json:"status"` // approved, rejected, failed
+	ClipID       *uuid.UUID `json:"clip_id,omitempty"`
+	ErrorCode    string     `json:"error_code,omitempty"`
+	Error        string     `json:"error,omitempty"`
+	Retryable    bool       `json:"retryable"`
+	Attempts     int        `json:"attempts"`
+}
+
+// BulkSubmissionResult summarizes a bulk moderation request. Each item is
+// applied on its own, so one failure doesn't undo the others.
+type BulkSubmissionResult struct {
+	Action    string                     `json:"action"`
+	Total     int                        `json:"total"`
+	Succeeded int                        `json:"succeeded"`
+	Failed    int                        `json:"failed"`
+	Items     []BulkSubmissionItemResult `json:"items"`
+}
+
+// BulkSubmissionJob tracks a bulk moderation request large enough to run in
+// the background
+type BulkSubmissionJob struct {
+	ID          uuid.UUID             `json:"id"`
+	Action      string                `json:"action"`
+	Status      string                `json:"status"` // queued, running, completed
+	ReviewerID  uuid.UUID             `json:"reviewer_id"`
+	Total       int                   `json:"total"`
+	Processed   int                   `json:"processed"`
+	Result      *BulkSubmissionResult `json:"result,omitempty"`
+	CreatedAt   time.Time             `json:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at"`
+	CompletedAt *time.Time            `json:"completed_at,omitempty"`
+}
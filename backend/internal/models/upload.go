@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Upload contexts, each with its own size limit and allowed types
+const (
+	UploadContextAvatar         = "avatar"
+	UploadContextAdCreative     = "ad_creative"
+	UploadContextReportEvidence = "report_evidence"
+	UploadContextCommunityAsset = "community_asset"
+)
+
+// Upload statuses
+const (
+	UploadStatusPending  = "pending"
+	UploadStatusReady    = "ready"
+	UploadStatusRejected = "rejected"
+	UploadStatusAttached = "attached"
+	UploadStatusDetached = "detached"
+)
+
+// Upload is a file uploaded to object storage through a presigned URL
+type Upload struct {
+	ID                  uuid.UUID  `json:"id" db:"id"`
+	UserID              *uuid.UUID `json:"user_id,omitempty" db:"user_id"`
+	Context             string     `json:"context" db:"context"`
+	ObjectKey           string     `json:"-" db:"object_key"`
+	Filename            string     `json:"filename" db:"filename"`
+	DeclaredContentType string     `json:"declared_content_type" db:"declared_content_type"`
+	ContentType         *string    `json:"content_type,omitempty" db:"content_type"`
+	SizeBytes           int64      `json:"size_bytes" db:"size_bytes"`
+	Status              string     `json:"status" db:"status"`
+	RejectionReason     *string    `json:"rejection_reason,omitempty" db:"rejection_reason"`
+	AttachedType        *string    `json:"attached_type,omitempty" db:"attached_type"`
+	AttachedID          *string    `json:"attached_id,omitempty" db:"attached_id"`
+	URL                 string     `json:"url,omitempty" db:"-"` // Set once the upload is ready
+	ExpiresAt           time.Time  `json:"expires_at" db:"expires_at"`
+	CreatedAt           time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// CreateUploadRequest asks for a presigned URL to upload a file to
+type CreateUploadRequest struct {
+	Context     string `json:"context" binding:"required,oneof=avatar ad_creative report_evidence community_asset"`
+	Filename    string `json:"filename" binding:"required,min=1,max=255"`
+	ContentType string `json:"content_type" binding:"required,max=100"`
+	SizeBytes   int64  `json:"size_bytes" binding:"required,min=1"`
+}
+
+// UploadTicket tells the client where and how to upload a file. The file
+// must be sent with the given method and headers before the URL expires.
+type UploadTicket struct {
+	Upload    *Upload           `json:"upload"`
+	UploadURL string            `json:"upload_url"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+// AttachUploadRequest uses a completed upload, e.g. as the user's avatar
+type AttachUploadRequest struct {
+	UploadID uuid.UUID `json:"upload_id" binding:"required"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// How a site-wide ban ended
+const (
+	UserBanLiftExpired    = "expired"    // Reached its expiry and was lifted automatically
+	UserBanLiftLifted     = "lifted"     // Lifted early by an admin
+	UserBanLiftSuperseded = "superseded" // Replaced by a newer ban
+)
+
+// UserBan is a site-wide ban. A nil ExpiresAt means the ban is permanent.
+type UserBan struct {
+	ID            uuid.UUID  `json:"id" db:"id"`
+	UserID        uuid.UUID  `json:"user_id" db:"user_id"`
+	Reason        string     `json:"reason" db:"reason"`
+	BannedBy      *uuid.UUID `json:"banned_by,omitempty" db:"banned_by"`
+	OffenseNumber int        `json:"offense_number" db:"offense_number"`
+	StartsAt      time.Time  `json:"starts_at" db:"starts_at"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	LiftedAt      *time.Time `json:"lifted_at,omitempty" db:"lifted_at"`
+	LiftedBy      *uuid.UUID `json:"lifted_by,omitempty" db:"lifted_by"`
+	LiftType      *string    `json:"lift_type,omitempty" db:"lift_type"`
+	LiftReason    *string    `json:"lift_reason,omitempty" db:"lift_reason"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+}
+
+// IsPermanent reports whether the ban has no expiry
+func (b *UserBan) IsPermanent() bool {
+	return b.ExpiresAt == nil
+}
+
+// UserBanStatus is the ban status shown to the banned user
+type UserBanStatus struct {
+	Banned    bool       `json:"banned"`
+	Reason    string     `json:"reason,omitempty"`
+	BannedAt  *time.Time `json:"banned_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Permanent bool       `json:"permanent"`
+}
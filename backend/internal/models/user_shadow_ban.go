@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserShadowBan hides a user's comments, clip submissions and the
+// notifications they trigger from everyone else. The user still sees their
+// own content as usual. A nil ExpiresAt means the shadow ban has no expiry.
+// Shadow bans end the same ways site-wide bans do (UserBanLift*).
+type UserShadowBan struct {
+	ID             uuid.UUID  `json:"id" db:"id"`
+	UserID         uuid.UUID  `json:"user_id" db:"user_id"`
+	Reason         string     `json:"reason" db:"reason"`
+	ShadowBannedBy *uuid.UUID `json:"shadow_banned_by,omitempty" db:"shadow_banned_by"`
+	StartsAt       time.Time  `json:"starts_at" db:"starts_at"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	LiftedAt       *time.Time `json:"lifted_at,omitempty" db:"lifted_at"`
+	LiftedBy       *uuid.UUID `json:"lifted_by,omitempty" db:"lifted_by"`
+	LiftType       *string    `json:"lift_type,omitempty" db:"lift_type"`
+	LiftReason     *string    `json:"lift_reason,omitempty" db:"lift_reason"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+}
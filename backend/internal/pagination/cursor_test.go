@@ -0,0 +1,117 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	c := Cursor{
+		Sort:   "trending",
+		Scores: []float64{123.456789012345, -42},
+		Time:   time.Date(2021, 1, 1, 12, 30, 0, 123456000, time.UTC),
+		ID:     uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"),
+	}
+
+	token := Encode(c)
+	decoded, err := Decode(token, "trending")
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if len(decoded.Scores) != 2 || decoded.Scores[0] != c.Scores[0] || decoded.Scores[1] != c.Scores[1] {
+		t.Errorf("scores = %v, want %v", decoded.Scores, c.Scores)
+	}
+	if !decoded.Time.Equal(c.Time) {
+		t.Errorf("time = %v, want %v", decoded.Time, c.Time)
+	}
+	if decoded.ID != c.ID {
+		t.Errorf("id = %v, want %v", decoded.ID, c.ID)
+	}
+}
+
+func TestDecodeRejectsInvalidCursors(t *testing.T) {
+	token := Encode(Cursor{Sort: "new", Time: time.Now(), ID: uuid.New()})
+	payload, sig, _ := strings.Cut(token, ".")
+	other := Encode(Cursor{Sort: "new", Time: time.Now(), ID: uuid.New()})
+	_, otherSig, _ := strings.Cut(other, ".")
+
+	tests := map[string]string{
+		"empty":              "",
+		"no signature":       payload,
+		"not base64":         "not base64!." + sig,
+		"swapped signature":  payload + "." + otherSig,
+		"truncated":          token[:len(token)-2],
+		"legacy unsigned":    "dHJlbmRpbmc6MTAwOjU1MGU4NDAwOjE2MDk0NTkyMDA",
+		"signed non-cursor":  signed([]byte(`"hello"`)),
+		"signed extra field": signed([]byte(`{"s":"new","x":1}`)),
+	}
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Decode(token, "new"); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("Decode(%q) error = %v, want ErrInvalidCursor", token, err)
+			}
+		})
+	}
+}
+
+func TestDecodeRejectsOtherSort(t *testing.T) {
+	token := Encode(Cursor{Sort: "top", Scores: []float64{10}, Time: time.Now(), ID: uuid.New()})
+	if _, err := Decode(token, "new"); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("expected ErrInvalidCursor for a cursor from another sort, got %v", err)
+	}
+}
+
+func TestSetSigningKey(t *testing.T) {
+	keyMu.RLock()
+	original := signingKey
+	keyMu.RUnlock()
+	defer SetSigningKey(original)
+
+	SetSigningKey([]byte("first-key"))
+	token := Encode(Cursor{Sort: "new", Time: time.Now(), ID: uuid.New()})
+	if _, err := Decode(token, "new"); err != nil {
+		t.Fatalf("Decode with the signing key: %v", err)
+	}
+
+	SetSigningKey([]byte("rotated-key"))
+	if _, err := Decode(token, "new"); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("expected cursors signed with an old key to be rejected, got %v", err)
+	}
+
+	// An empty key keeps the current one
+	SetSigningKey(nil)
+	if _, err := Decode(Encode(Cursor{Sort: "new"}), "new"); err != nil {
+		t.Errorf("Decode after empty SetSigningKey: %v", err)
+	}
+}
+
+func TestTrim(t *testing.T) {
+	rows := []string{"a", "b", "c"}
+	keys := []Cursor{{Sort: "new", ID: uuid.New()}, {Sort: "new", ID: uuid.New()}, {Sort: "new", ID: uuid.New()}}
+
+	page, info := Trim(rows, keys, 2)
+	if len(page) != 2 || !info.HasMore || info.NextCursor == nil {
+		t.Fatalf("Trim with an extra row = %v, %+v", page, info)
+	}
+	next, err := Decode(*info.NextCursor, "new")
+	if err != nil {
+		t.Fatalf("Decode next cursor: %v", err)
+	}
+	if next.ID != keys[1].ID {
+		t.Errorf("next cursor continues after %v, want the last row kept %v", next.ID, keys[1].ID)
+	}
+
+	page, info = Trim(rows, keys, 3)
+	if len(page) != 3 || info.HasMore || info.NextCursor != nil {
+		t.Errorf("Trim on the last page = %v, %+v", page, info)
+	}
+}
+
+func signed(payload []byte) string {
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(sign(payload))
+}
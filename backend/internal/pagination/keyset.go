@@ -0,0 +1,80 @@
+package pagination
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Keyset describes the order of a cursor-paginated query. Rows are ordered by
+// the score expressions, then the time expression, then the ID column, all in
+// one direction, so the continuation after a cursor is a single row comparison.
+type Keyset struct {
+	Sort   string   // Sort order cursors are issued for
+	Scores []string // Numeric sort key expressions, most significant first
+	Time   string   // Timestamp sort key expression
+	ID     string   // Unique tie-breaker column
+	Asc    bool     // Ascending rather than descending order
+}
+
+// OrderBy returns the ORDER BY list for the keyset
+func (k Keyset) OrderBy() string {
+	dir := " DESC"
+	if k.Asc {
+		dir = " ASC"
+	}
+	keys := append(append([]string{}, k.Scores...), k.Time, k.ID)
+	for i := range keys {
+		keys[i] += dir
+	}
+	return strings.Join(keys, ", ")
+}
+
+// Columns returns the select list of a row's key, scanned with Dest. Scores
+// are read as float8, which holds integer scores exactly; a score computed as
+// numeric should be cast to float8 in its expression so it round-trips.
+func (k Keyset) Columns() string {
+	keys := make([]string, 0, len(k.Scores)+2)
+	for _, score := range k.Scores {
+		keys = append(keys, "("+score+")::float8")
+	}
+	return strings.Join(append(keys, k.Time, k.ID), ", ")
+}
+
+// Dest prepares c to hold the key of a row and returns the scan destinations
+// for the columns listed by Columns
+func (k Keyset) Dest(c *Cursor) []interface{} {
+	c.Sort = k.Sort
+	c.Scores = make([]float64, len(k.Scores))
+	dest := make([]interface{}, 0, len(k.Scores)+2)
+	for i := range c.Scores {
+		dest = append(dest, &c.Scores[i])
+	}
+	return append(dest, &c.Time, &c.ID)
+}
+
+// After returns the WHERE condition selecting the rows that follow c, with
+// placeholders numbered from argIndex, and the arguments they bind
+func (k Keyset) After(c *Cursor, argIndex int) (string, []interface{}, error) {
+	if c.Sort != k.Sort || len(c.Scores) != len(k.Scores) {
+		return "", nil, ErrInvalidCursor
+	}
+
+	// Placeholders are left untyped so Postgres binds them as the type of the
+	// matching key and can use an index on the keys
+	placeholders := make([]string, 0, len(k.Scores)+2)
+	args := make([]interface{}, 0, len(k.Scores)+2)
+	for _, score := range c.Scores {
+		placeholders = append(placeholders, fmt.Sprintf("$%d", argIndex))
+		args = append(args, score)
+		argIndex++
+	}
+	placeholders = append(placeholders, fmt.Sprintf("$%d", argIndex), fmt.Sprintf("$%d", argIndex+1))
+	args = append(args, c.Time, c.ID)
+
+	op := "<"
+	if k.Asc {
+		op = ">"
+	}
+	keys := append(append([]string{}, k.Scores...), k.Time, k.ID)
+	return fmt.Sprintf("(%s) %s (%s)", strings.Join(keys, ", "), op, strings.Join(placeholders, ", ")), args, nil
+}
@@ -0,0 +1,73 @@
+package pagination
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestKeysetOrderBy(t *testing.T) {
+	k := Keyset{Sort: "top", Scores: []string{"c.vote_score"}, Time: "c.created_at", ID: "c.id"}
+	if got, want := k.OrderBy(), "c.vote_score DESC, c.created_at DESC, c.id DESC"; got != want {
+		t.Errorf("OrderBy() = %q, want %q", got, want)
+	}
+
+	k = Keyset{Sort: "old", Time: "created_at", ID: "id", Asc: true}
+	if got, want := k.OrderBy(), "created_at ASC, id ASC"; got != want {
+		t.Errorf("OrderBy() = %q, want %q", got, want)
+	}
+}
+
+func TestKeysetColumnsAndDest(t *testing.T) {
+	k := Keyset{Sort: "top", Scores: []string{"c.vote_score"}, Time: "c.created_at", ID: "c.id"}
+	if got, want := k.Columns(), "(c.vote_score)::float8, c.created_at, c.id"; got != want {
+		t.Errorf("Columns() = %q, want %q", got, want)
+	}
+
+	var c Cursor
+	dest := k.Dest(&c)
+	if len(dest) != 3 || c.Sort != "top" || len(c.Scores) != 1 {
+		t.Fatalf("Dest() = %d destinations, cursor %+v", len(dest), c)
+	}
+	*dest[0].(*float64) = 7
+	if c.Scores[0] != 7 {
+		t.Errorf("score destination does not point into the cursor")
+	}
+}
+
+func TestKeysetAfter(t *testing.T) {
+	k := Keyset{Sort: "top", Scores: []string{"c.vote_score"}, Time: "c.created_at", ID: "c.id"}
+	c := &Cursor{Sort: "top", Scores: []float64{12}, Time: time.Now(), ID: uuid.New()}
+
+	where, args, err := k.After(c, 3)
+	if err != nil {
+		t.Fatalf("After: %v", err)
+	}
+	if want := "(c.vote_score, c.created_at, c.id) < ($3, $4, $5)"; where != want {
+		t.Errorf("After() = %q, want %q", where, want)
+	}
+	if len(args) != 3 || args[0] != 12.0 || args[2] != c.ID {
+		t.Errorf("unexpected args %v", args)
+	}
+
+	k.Asc = true
+	if where, _, _ := k.After(c, 1); where != "(c.vote_score, c.created_at, c.id) > ($1, $2, $3)" {
+		t.Errorf("ascending After() = %q", where)
+	}
+}
+
+func TestKeysetAfterRejectsMismatchedCursor(t *testing.T) {
+	k := Keyset{Sort: "top", Scores: []string{"c.vote_score"}, Time: "c.created_at", ID: "c.id"}
+	cursors := []*Cursor{
+		{Sort: "new", Scores: []float64{1}},
+		{Sort: "top"},
+		{Sort: "top", Scores: []float64{1, 2}},
+	}
+	for _, c := range cursors {
+		if _, _, err := k.After(c, 1); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("After(%+v) error = %v, want ErrInvalidCursor", c, err)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// AchievementRepository handles database operations for achievements and
+// users' progress toward them
+type AchievementRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewAchievementRepository creates a new AchievementRepository
+func NewAchievementRepository(pool *pgxpool.Pool) *AchievementRepository {
+	return &AchievementRepository{pool: pool}
+}
+
+// ListUserAchievements returns every achievement with a user's progress toward it
+func (r *AchievementRepository) ListUserAchievements(ctx context.Context, userID uuid.UUID) ([]models.UserAchievement, error) {
+	query := `
+		SELECT a.id, a.name, a.description, a.icon, a.metric, a.target, a.badge_id,
+			COALESCE(m.value, 0), ua.completed_at
+		FROM achievements a
+		LEFT JOIN user_achievement_metrics m ON m.user_id = $1 AND m.metric = a.metric
+		LEFT JOIN user_achievements ua ON ua.user_id = $1 AND ua.achievement_id = a.id
+		ORDER BY a.sort_order, a.id
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user achievements: %w", err)
+	}
+	defer rows.Close()
+
+	achievements := []models.UserAchievement{}
+	for rows.Next() {
+		var a models.Achievement
+		var value int
+		var completedAt *time.Time
+		if err := rows.Scan(
+			&a.ID, &a.Name, &a.Description, &a.Icon, &a.Metric, &a.Target, &a.BadgeID,
+			&value, &completedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan user achievement: %w", err)
+		}
+		achievements = append(achievements, models.NewUserAchievement(a, userID, value, completedAt))
+	}
+	return achievements, rows.Err()
+}
+
+// RecordEvent counts an event toward a user's metric, completes the
+// achievements the metric reached and awards their badges. An event already
+// recorded for the same source counts nothing. It returns the achievements
+// the event completed.
+func (r *AchievementRepository) RecordEvent(ctx context.Context, userID uuid.UUID, metric string, sourceID uuid.UUID) ([]models.Achievement, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	result, err := tx.Exec(ctx, `
+		INSERT INTO achievement_events (user_id, metric, source_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, metric, source_id) DO NOTHING`,
+		userID, metric, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record achievement event: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return nil, nil
+	}
+
+	var value int
+	err = tx.QueryRow(ctx, `
+		INSERT INTO user_achievement_metrics (user_id, metric, value)
+		VALUES ($1, $2, 1)
+		ON CONFLICT (user_id, metric) DO UPDATE
+		SET value = user_achievement_metrics.value + 1, updated_at = NOW()
+		RETURNING value`,
+		userID, metric).Scan(&value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update achievement metric: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, `
+		WITH completed AS (
+			INSERT INTO user_achievements (user_id, achievement_id)
+			SELECT $1, id FROM achievements WHERE metric = $2 AND target <= $3
+			ON CONFLICT (user_id, achievement_id) DO NOTHING
+			RETURNING achievement_id
+		)
+		SELECT a.id, a.name, a.description, a.icon, a.metric, a.target, a.badge_id
+		FROM achievements a
+		JOIN completed c ON c.achievement_id = a.id
+		ORDER BY a.sort_order, a.id`,
+		userID, metric, value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete achievements: %w", err)
+	}
+	var completed []models.Achievement
+	for rows.Next() {
+		var a models.Achievement
+		if err := rows.Scan(&a.ID, &a.Name, &a.Description, &a.Icon, &a.Metric, &a.Target, &a.BadgeID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan completed achievement: %w", err)
+		}
+		completed = append(completed, a)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to complete achievements: %w", err)
+	}
+
+	for _, a := range completed {
+		if a.BadgeID == nil {
+			continue
+		}
+		_, err := tx.Exec(ctx, `
+			INSERT INTO user_badges (user_id, badge_id)
+			VALUES ($1, $2)
+			ON CONFLICT (user_id, badge_id) DO NOTHING`,
+			userID, *a.BadgeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to award achievement badge: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit achievement event: %w", err)
+	}
+	return completed, nil
+}
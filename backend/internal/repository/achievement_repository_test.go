@@ -0,0 +1,121 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/testutil"
+)
+
+// achievementIDs returns the IDs of achievements, in order
+func achievementIDs(achievements []models.Achievement) []string {
+	ids := make([]string, 0, len(achievements))
+	for _, a := range achievements {
+		ids = append(ids, a.ID)
+	}
+	return ids
+}
+
+func TestAchievementRepository_RecordEventCountsEachSourceOnce(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	pool := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, pool)
+
+	repo := NewAchievementRepository(pool)
+	ctx := context.Background()
+	userID := uuid.New()
+	insertTestUser(t, pool, userID)
+
+	source := uuid.New()
+	completed, err := repo.RecordEvent(ctx, userID, models.AchievementMetricCommentsPosted, source)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first_comment"}, achievementIDs(completed))
+
+	// Replaying the same event counts nothing and completes nothing
+	completed, err = repo.RecordEvent(ctx, userID, models.AchievementMetricCommentsPosted, source)
+	require.NoError(t, err)
+	assert.Empty(t, completed)
+
+	// A later event doesn't complete first_comment again
+	completed, err = repo.RecordEvent(ctx, userID, models.AchievementMetricCommentsPosted, uuid.New())
+	require.NoError(t, err)
+	assert.Empty(t, completed)
+
+	var value int
+	err = pool.QueryRow(ctx, `
+		SELECT value FROM user_achievement_metrics WHERE user_id = $1 AND metric = $2
+	`, userID, models.AchievementMetricCommentsPosted).Scan(&value)
+	require.NoError(t, err)
+	assert.Equal(t, 2, value)
+}
+
+func TestAchievementRepository_RecordEventOnlyCountsItsMetric(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	pool := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, pool)
+
+	repo := NewAchievementRepository(pool)
+	ctx := context.Background()
+	userID := uuid.New()
+	insertTestUser(t, pool, userID)
+
+	completed, err := repo.RecordEvent(ctx, userID, models.AchievementMetricCommentsPosted, uuid.New())
+	require.NoError(t, err)
+	assert.NotContains(t, achievementIDs(completed), "first_approved_submission")
+
+	achievements, err := repo.ListUserAchievements(ctx, userID)
+	require.NoError(t, err)
+	for _, a := range achievements {
+		if a.Metric == models.AchievementMetricSubmissionsApproved {
+			assert.Zero(t, a.Progress, a.ID)
+			assert.Nil(t, a.CompletedAt, a.ID)
+		}
+	}
+}
+
+func TestAchievementRepository_RecordEventCompletesAtTargetAndAwardsBadge(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	pool := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, pool)
+
+	repo := NewAchievementRepository(pool)
+	ctx := context.Background()
+	userID := uuid.New()
+	insertTestUser(t, pool, userID)
+
+	// One comment short of conversationalist (target 100)
+	_, err := pool.Exec(ctx, `
+		INSERT INTO user_achievement_metrics (user_id, metric, value) VALUES ($1, $2, 98)
+	`, userID, models.AchievementMetricCommentsPosted)
+	require.NoError(t, err)
+
+	completed, err := repo.RecordEvent(ctx, userID, models.AchievementMetricCommentsPosted, uuid.New())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first_comment"}, achievementIDs(completed), "below target completes only the lower achievement")
+
+	completed, err = repo.RecordEvent(ctx, userID, models.AchievementMetricCommentsPosted, uuid.New())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"conversationalist"}, achievementIDs(completed))
+
+	var badges int
+	err = pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM user_badges WHERE user_id = $1 AND badge_id = 'conversationalist'
+	`, userID).Scan(&badges)
+	require.NoError(t, err)
+	assert.Equal(t, 1, badges)
+}
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+var (
+	// ErrAuthIdentityNotFound is returned when no linked identity matches the lookup
+	ErrAuthIdentityNotFound = errors.New("auth identity not found")
+)
+
+// AuthIdentityRepository handles database operations for external login identities
+type AuthIdentityRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewAuthIdentityRepository creates a new auth identity repository
+func NewAuthIdentityRepository(db *pgxpool.Pool) *AuthIdentityRepository {
+	return &AuthIdentityRepository{db: db}
+}
+
+// Create links a new external identity to a user
+func (r *AuthIdentityRepository) Create(ctx context.Context, identity *models.UserAuthIdentity) error {
+	metadataJSON, err := json.Marshal(identity.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal identity metadata: %w", err)
+	}
+
+	query := `
+		INSERT INTO user_auth_identities (
+			id, user_id, provider, provider_user_id, email, email_verified,
+			display_name, avatar_url, metadata, last_login_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING created_at
+	`
+
+	err = r.db.QueryRow(ctx, query,
+		identity.ID,
+		identity.UserID,
+		identity.Provider,
+		identity.ProviderUserID,
+		identity.Email,
+		identity.EmailVerified,
+		identity.DisplayName,
+		identity.AvatarURL,
+		metadataJSON,
+		identity.LastLoginAt,
+	).Scan(&identity.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create auth identity: %w", err)
+	}
+
+	return nil
+}
+
+// GetByProviderUserID retrieves an identity by provider and the provider's user ID
+func (r *AuthIdentityRepository) GetByProviderUserID(ctx context.Context, provider, providerUserID string) (*models.UserAuthIdentity, error) {
+	query := `
+		SELECT id, user_id, provider, provider_user_id, email, email_verified,
+		       display_name, avatar_url, metadata, created_at, last_login_at
+		FROM user_auth_identities
+		WHERE provider = $1 AND provider_user_id = $2
+	`
+
+	identity, err := scanAuthIdentity(r.db.QueryRow(ctx, query, provider, providerUserID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAuthIdentityNotFound
+		}
+		return nil, fmt.Errorf("failed to get auth identity: %w", err)
+	}
+
+	return identity, nil
+}
+
+// ListByUserID retrieves all identities linked to a user
+func (r *AuthIdentityRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*models.UserAuthIdentity, error) {
+	query := `
+		SELECT id, user_id, provider, provider_user_id, email, email_verified,
+		       display_name, avatar_url, metadata, created_at, last_login_at
+		FROM user_auth_identities
+		WHERE user_id = $1
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list auth identities: %w", err)
+	}
+	defer rows.Close()
+
+	identities := []*models.UserAuthIdentity{}
+	for rows.Next() {
+		identity, err := scanAuthIdentity(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan auth identity: %w", err)
+		}
+		identities = append(identities, identity)
+	}
+
+	return identities, rows.Err()
+}
+
+// UpdateProfile refreshes the provider profile snapshot and last login time
+func (r *AuthIdentityRepository) UpdateProfile(ctx context.Context, identity *models.UserAuthIdentity) error {
+	metadataJSON, err := json.Marshal(identity.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal identity metadata: %w", err)
+	}
+
+	query := `
+		UPDATE user_auth_identities
+		SET email = $2, email_verified = $3, display_name = $4, avatar_url = $5,
+		    metadata = $6, last_login_at = NOW()
+		WHERE id = $1
+	`
+
+	_, err = r.db.Exec(ctx, query,
+		identity.ID,
+		identity.Email,
+		identity.EmailVerified,
+		identity.DisplayName,
+		identity.AvatarURL,
+		metadataJSON,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update auth identity: %w", err)
+	}
+
+	return nil
+}
+
+// Delete unlinks a provider identity from a user
+func (r *AuthIdentityRepository) Delete(ctx context.Context, userID uuid.UUID, provider string) error {
+	query := `DELETE FROM user_auth_identities WHERE user_id = $1 AND provider = $2`
+
+	result, err := r.db.Exec(ctx, query, userID, provider)
+	if err != nil {
+		return fmt.Errorf("failed to delete auth identity: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrAuthIdentityNotFound
+	}
+
+	return nil
+}
+
+func scanAuthIdentity(row pgx.Row) (*models.UserAuthIdentity, error) {
+	var identity models.UserAuthIdentity
+	var metadataJSON []byte
+
+	err := row.Scan(
+		&identity.ID,
+		&identity.UserID,
+		&identity.Provider,
+		&identity.ProviderUserID,
+		&identity.Email,
+		&identity.EmailVerified,
+		&identity.DisplayName,
+		&identity.AvatarURL,
+		&metadataJSON,
+		&identity.CreatedAt,
+		&identity.LastLoginAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(metadataJSON) > 0 {
+		if err := json.Unmarshal(metadataJSON, &identity.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal identity metadata: %w", err)
+		}
+	}
+
+	return &identity, nil
+}
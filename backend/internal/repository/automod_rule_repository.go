@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// Sentinel errors for automoderation rule operations
+var (
+	// ErrAutomodRuleNotFound is returned when a rule does not exist
+	ErrAutomodRuleNotFound = errors.New("automod rule not found")
+	// ErrAutomodCommunityNotFound is returned when a rule's community does not exist
+	ErrAutomodCommunityNotFound = errors.New("automod rule community not found")
+)
+
+const automodRuleColumns = `
+	id, name, enabled, targets, keywords, patterns, link_policy, link_domains, min_karma,
+	min_account_age_days, action, community_id, overrides_rule_id, created_by, match_count,
+	last_matched_at, created_at, updated_at`
+
+// AutomodRuleRepository handles database operations for automoderation rules
+type AutomodRuleRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewAutomodRuleRepository creates a new AutomodRuleRepository
+func NewAutomodRuleRepository(pool *pgxpool.Pool) *AutomodRuleRepository {
+	return &AutomodRuleRepository{pool: pool}
+}
+
+func scanAutomodRule(row pgx.Row) (*models.AutomodRule, error) {
+	var rule models.AutomodRule
+	err := row.Scan(
+		&rule.ID, &rule.Name, &rule.Enabled, &rule.Targets, &rule.Keywords, &rule.Patterns,
+		&rule.LinkPolicy, &rule.LinkDomains, &rule.MinKarma, &rule.MinAccountAgeDays, &rule.Action,
+		&rule.CommunityID, &rule.OverridesRuleID, &rule.CreatedBy, &rule.MatchCount,
+		&rule.LastMatchedAt, &rule.CreatedAt, &rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *AutomodRuleRepository) queryRules(ctx context.Context, query string, args ...interface{}) ([]models.AutomodRule, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list automod rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []models.AutomodRule{}
+	for rows.Next() {
+		rule, err := scanAutomodRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan automod rule: %w", err)
+		}
+		rules = append(rules, *rule)
+	}
+
+	return rules, rows.Err()
+}
+
+// ListRules returns all rules, newest first
+func (r *AutomodRuleRepository) ListRules(ctx context.Context) ([]models.AutomodRule, error) {
+	query := `SELECT ` + automodRuleColumns + `
+		FROM automod_rules
+		ORDER BY created_at DESC`
+	return r.queryRules(ctx, query)
+}
+
+// ListEvaluatedRules returns the rules content is evaluated against: enabled
+// rules, and disabled overrides, which turn global rules off in a community
+func (r *AutomodRuleRepository) ListEvaluatedRules(ctx context.Context) ([]models.AutomodRule, error) {
+	query := `SELECT ` + automodRuleColumns + `
+		FROM automod_rules
+		WHERE enabled = true OR overrides_rule_id IS NOT NULL
+		ORDER BY created_at`
+	return r.queryRules(ctx, query)
+}
+
+// GetRule returns a rule
+func (r *AutomodRuleRepository) GetRule(ctx context.Context, ruleID uuid.UUID) (*models.AutomodRule, error) {
+	query := `SELECT ` + automodRuleColumns + `
+		FROM automod_rules
+		WHERE id = $1`
+
+	rule, err := scanAutomodRule(r.pool.QueryRow(ctx, query, ruleID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAutomodRuleNotFound
+		}
+		return nil, fmt.Errorf("failed to get automod rule: %w", err)
+	}
+	return rule, nil
+}
+
+// CommunityExists reports whether a community exists
+func (r *AutomodRuleRepository) CommunityExists(ctx context.Context, communityID uuid.UUID) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM communities WHERE id = $1)`, communityID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check automod rule community: %w", err)
+	}
+	return exists, nil
+}
+
+// CreateRule inserts a rule
+func (r *AutomodRuleRepository) CreateRule(ctx context.Context, rule *models.AutomodRule) error {
+	query := `
+		INSERT INTO automod_rules (
+			name, enabled, targets, keywords, patterns, link_policy, link_domains, min_karma,
+			min_account_age_days, action, community_id, overrides_rule_id, created_by
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.pool.QueryRow(ctx, query,
+		rule.Name, rule.Enabled, rule.Targets, rule.Keywords, rule.Patterns, rule.LinkPolicy,
+		rule.LinkDomains, rule.MinKarma, rule.MinAccountAgeDays, rule.Action, rule.CommunityID,
+		rule.OverridesRuleID, rule.CreatedBy,
+	).Scan(&rule.ID, &rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create automod rule: %w", err)
+	}
+	return nil
+}
+
+// UpdateRule replaces a rule's settings. Match stats are kept.
+func (r *AutomodRuleRepository) UpdateRule(ctx context.Context, rule *models.AutomodRule) error {
+	query := `
+		UPDATE automod_rules
+		SET name = $2, enabled = $3, targets = $4, keywords = $5, patterns = $6, link_policy = $7,
+			link_domains = $8, min_karma = $9, min_account_age_days = $10, action = $11,
+			community_id = $12, overrides_rule_id = $13, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at
+	`
+
+	err := r.pool.QueryRow(ctx, query,
+		rule.ID, rule.Name, rule.Enabled, rule.Targets, rule.Keywords, rule.Patterns, rule.LinkPolicy,
+		rule.LinkDomains, rule.MinKarma, rule.MinAccountAgeDays, rule.Action, rule.CommunityID,
+		rule.OverridesRuleID,
+	).Scan(&rule.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrAutomodRuleNotFound
+		}
+		return fmt.Errorf("failed to update automod rule: %w", err)
+	}
+	return nil
+}
+
+// DeleteRule deletes a rule and the community overrides of it. Content it
+// matched keeps its state and queue entries.
+func (r *AutomodRuleRepository) DeleteRule(ctx context.Context, ruleID uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM automod_rules WHERE id = $1`, ruleID)
+	if err != nil {
+		return fmt.Errorf("failed to delete automod rule: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrAutomodRuleNotFound
+	}
+	return nil
+}
+
+// ListClipCommunityIDs returns the communities a clip was added to
+func (r *AutomodRuleRepository) ListClipCommunityIDs(ctx context.Context, clipID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := r.pool.Query(ctx, `SELECT community_id FROM community_clips WHERE clip_id = $1`, clipID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clip communities: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan clip community: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// RecordMatches queues automoderated content for moderators, with the
+// verdict in the queue entry's metadata, and counts the matching rules'
+// hits. Content already pending review keeps its entry, raised to priority.
+func (r *AutomodRuleRepository) RecordMatches(ctx context.Context, contentType string, contentID uuid.UUID, verdict *models.AutomodVerdict, priority int) error {
+	metadata, err := json.Marshal(map[string]interface{}{"automod": verdict})
+	if err != nil {
+		return fmt.Errorf("failed to encode automod verdict: %w", err)
+	}
+
+	ruleIDs := make([]uuid.UUID, 0, len(verdict.Matches))
+	for _, match := range verdict.Matches {
+		ruleIDs = append(ruleIDs, match.RuleID)
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO moderation_queue (
+			content_type, content_id, reason, priority, status, auto_flagged, metadata
+		) VALUES ($1, $2, $3, $4, 'pending', true, $5)
+		ON CONFLICT (content_type, content_id) WHERE status = 'pending'
+		DO UPDATE SET
+			priority = GREATEST(moderation_queue.priority, EXCLUDED.priority),
+			metadata = COALESCE(moderation_queue.metadata, '{}'::jsonb) || EXCLUDED.metadata
+	`, contentType, contentID, models.AutomodModerationReason, priority, metadata)
+	if err != nil {
+		return fmt.Errorf("failed to queue automoderated content: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE automod_rules
+		SET match_count = match_count + 1, last_matched_at = NOW()
+		WHERE id = ANY($1)
+	`, ruleIDs); err != nil {
+		return fmt.Errorf("failed to count automod rule matches: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit automod matches: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// BackfillStateRepository stores the checkpoints of backfill jobs
+type BackfillStateRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewBackfillStateRepository creates a new BackfillStateRepository
+func NewBackfillStateRepository(pool *pgxpool.Pool) *BackfillStateRepository {
+	return &BackfillStateRepository{pool: pool}
+}
+
+// GetBackfillState returns a job's checkpoint, or nil if the job never ran
+func (r *BackfillStateRepository) GetBackfillState(ctx context.Context, name string) (*models.BackfillState, error) {
+	var s models.BackfillState
+	err := r.pool.QueryRow(ctx, `
+		SELECT name, status, cursor, processed, failed, last_error,
+		       started_at, updated_at, completed_at
+		FROM backfill_state
+		WHERE name = $1`, name).Scan(
+		&s.Name, &s.Status, &s.Cursor, &s.Processed, &s.Failed, &s.LastError,
+		&s.StartedAt, &s.UpdatedAt, &s.CompletedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get backfill state: %w", err)
+	}
+	return &s, nil
+}
+
+// SaveBackfillState creates or replaces a job's checkpoint
+func (r *BackfillStateRepository) SaveBackfillState(ctx context.Context, s *models.BackfillState) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO backfill_state (name, status, cursor, processed, failed, last_error,
+		                            started_at, updated_at, completed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), $8)
+		ON CONFLICT (name) DO UPDATE SET
+			status = EXCLUDED.status,
+			cursor = EXCLUDED.cursor,
+			processed = EXCLUDED.processed,
+			failed = EXCLUDED.failed,
+			last_error = EXCLUDED.last_error,
+			started_at = EXCLUDED.started_at,
+			updated_at = NOW(),
+			completed_at = EXCLUDED.completed_at`,
+		s.Name, s.Status, s.Cursor, s.Processed, s.Failed, s.LastError,
+		s.StartedAt, s.CompletedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save backfill state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,293 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// Audit log actions for ban evasion reviews
+const (
+	AuditActionConfirmBanEvasion = "confirm_ban_evasion"
+	AuditActionDismissBanEvasion = "dismiss_ban_evasion"
+)
+
+// ErrBanEvasionFlagNotFound is returned when a flag doesn't exist or has already been reviewed
+var ErrBanEvasionFlagNotFound = errors.New("ban evasion flag not found")
+
+const banEvasionFlagColumns = `
+	f.id, f.user_id, f.banned_user_id, f.confidence, f.matched_signals, f.status,
+	f.queue_item_id, f.reviewed_by, f.reviewed_at, f.review_notes, f.created_at`
+
+const banEvasionFlagWithUsersQuery = `
+	SELECT ` + banEvasionFlagColumns + `,
+		u.username, u.created_at, bu.username, ban.reason, rv.username
+	FROM ban_evasion_flags f
+	JOIN users u ON u.id = f.user_id
+	JOIN users bu ON bu.id = f.banned_user_id
+	LEFT JOIN user_bans ban ON ban.user_id = f.banned_user_id AND ban.lifted_at IS NULL
+	LEFT JOIN users rv ON rv.id = f.reviewed_by`
+
+// BanEvasionRepository handles database operations for account signals and
+// ban evasion flags
+type BanEvasionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewBanEvasionRepository creates a new BanEvasionRepository
+func NewBanEvasionRepository(pool *pgxpool.Pool) *BanEvasionRepository {
+	return &BanEvasionRepository{pool: pool}
+}
+
+// RecordSignals links sign-in signals to a user, refreshing when signals the
+// user was already seen with were last seen
+func (r *BanEvasionRepository) RecordSignals(ctx context.Context, userID uuid.UUID, signals []models.AccountSignal) error {
+	for _, signal := range signals {
+		_, err := r.pool.Exec(ctx, `
+			INSERT INTO account_signals (user_id, signal_type, signal_value)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (user_id, signal_type, signal_value)
+			DO UPDATE SET last_seen_at = NOW(), seen_count = account_signals.seen_count + 1
+		`, userID, signal.SignalType, signal.SignalValue)
+		if err != nil {
+			return fmt.Errorf("failed to record %s signal: %w", signal.SignalType, err)
+		}
+	}
+	return nil
+}
+
+// FindBannedMatches returns the signals that accounts created since the given
+// time share with banned accounts, skipping pairs that were already flagged
+func (r *BanEvasionRepository) FindBannedMatches(ctx context.Context, createdSince time.Time) ([]models.BanEvasionMatch, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT s.user_id, b.user_id, s.signal_type, s.signal_value
+		FROM account_signals s
+		JOIN users u ON u.id = s.user_id
+		JOIN account_signals b
+			ON b.signal_type = s.signal_type AND b.signal_value = s.signal_value AND b.user_id <> s.user_id
+		JOIN users bu ON bu.id = b.user_id
+		WHERE u.created_at >= $1
+		  AND COALESCE(u.is_banned, false) = false
+		  AND bu.is_banned = true
+		  AND NOT EXISTS (
+			SELECT 1 FROM ban_evasion_flags f
+			WHERE f.user_id = s.user_id AND f.banned_user_id = b.user_id
+		  )
+		ORDER BY s.user_id, b.user_id
+	`, createdSince)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find banned account matches: %w", err)
+	}
+	defer rows.Close()
+
+	matches := []models.BanEvasionMatch{}
+	for rows.Next() {
+		var m models.BanEvasionMatch
+		if err := rows.Scan(&m.UserID, &m.BannedUserID, &m.SignalType, &m.SignalValue); err != nil {
+			return nil, fmt.Errorf("failed to scan banned account match: %w", err)
+		}
+		matches = append(matches, m)
+	}
+	return matches, rows.Err()
+}
+
+// CreateFlag flags a suspect account and adds it to the moderation queue,
+// raising the priority of a pending queue entry the account already has. The
+// returned bool is false when the pair was already flagged.
+func (r *BanEvasionRepository) CreateFlag(ctx context.Context, flag *models.BanEvasionFlag, priority int) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO ban_evasion_flags (user_id, banned_user_id, confidence, matched_signals)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, banned_user_id) DO NOTHING
+		RETURNING id, status, created_at
+	`, flag.UserID, flag.BannedUserID, flag.Confidence, flag.MatchedSignals).Scan(&flag.ID, &flag.Status, &flag.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to create ban evasion flag: %w", err)
+	}
+
+	var queueID uuid.UUID
+	err = tx.QueryRow(ctx, `
+		INSERT INTO moderation_queue (
+			content_type, content_id, reason, priority, status,
+			auto_flagged, confidence_score, report_count, created_at
+		) VALUES ('user', $1, $2, $3, 'pending', true, $4, 1, NOW())
+		ON CONFLICT (content_type, content_id) WHERE status = 'pending'
+		DO UPDATE SET
+			priority = GREATEST(moderation_queue.priority, EXCLUDED.priority),
+			confidence_score = GREATEST(moderation_queue.confidence_score, EXCLUDED.confidence_score),
+			report_count = moderation_queue.report_count + 1
+		RETURNING id
+	`, flag.UserID, models.BanEvasionQueueReason, priority, flag.Confidence).Scan(&queueID)
+	if err != nil {
+		return false, fmt.Errorf("failed to queue ban evasion flag: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE ban_evasion_flags SET queue_item_id = $2 WHERE id = $1`, flag.ID, queueID)
+	if err != nil {
+		return false, fmt.Errorf("failed to link ban evasion flag to queue: %w", err)
+	}
+	flag.QueueItemID = &queueID
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit ban evasion flag: %w", err)
+	}
+	return true, nil
+}
+
+// ListFlags returns ban evasion flags, most confident first
+func (r *BanEvasionRepository) ListFlags(ctx context.Context, filters models.BanEvasionFlagFilters) ([]models.BanEvasionFlagWithUsers, error) {
+	rows, err := r.pool.Query(ctx, banEvasionFlagWithUsersQuery+`
+		WHERE ($1::text = '' OR f.status = $1)
+		  AND ($2::uuid IS NULL OR f.user_id = $2)
+		ORDER BY f.confidence DESC, f.created_at
+		LIMIT $3 OFFSET $4
+	`, filters.Status, filters.UserID, filters.Limit, filters.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ban evasion flags: %w", err)
+	}
+	defer rows.Close()
+
+	flags := []models.BanEvasionFlagWithUsers{}
+	for rows.Next() {
+		flag, err := scanBanEvasionFlagWithUsers(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ban evasion flag: %w", err)
+		}
+		flags = append(flags, *flag)
+	}
+	return flags, rows.Err()
+}
+
+// GetFlag returns a ban evasion flag with both accounts' details
+func (r *BanEvasionRepository) GetFlag(ctx context.Context, id uuid.UUID) (*models.BanEvasionFlagWithUsers, error) {
+	row := r.pool.QueryRow(ctx, banEvasionFlagWithUsersQuery+` WHERE f.id = $1`, id)
+	flag, err := scanBanEvasionFlagWithUsers(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrBanEvasionFlagNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ban evasion flag: %w", err)
+	}
+	return flag, nil
+}
+
+// ReviewFlag confirms or dismisses a pending flag. The suspect's moderation
+// queue entry is resolved with queueStatus once none of their flags are
+// pending.
+func (r *BanEvasionRepository) ReviewFlag(ctx context.Context, id uuid.UUID, status, queueStatus string, reviewerID uuid.UUID, notes *string) (*models.BanEvasionFlag, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var f models.BanEvasionFlag
+	err = tx.QueryRow(ctx, `
+		UPDATE ban_evasion_flags f
+		SET status = $2, reviewed_by = $3, reviewed_at = NOW(), review_notes = $4
+		WHERE f.id = $1 AND f.status = 'pending'
+		RETURNING `+banEvasionFlagColumns, id, status, reviewerID, notes).Scan(
+		&f.ID, &f.UserID, &f.BannedUserID, &f.Confidence, &f.MatchedSignals, &f.Status,
+		&f.QueueItemID, &f.ReviewedBy, &f.ReviewedAt, &f.ReviewNotes, &f.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrBanEvasionFlagNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to review ban evasion flag: %w", err)
+	}
+
+	if f.QueueItemID != nil {
+		_, err = tx.Exec(ctx, `
+			UPDATE moderation_queue
+			SET status = $2, reviewed_by = $3, reviewed_at = NOW()
+			WHERE id = $1 AND status = 'pending'
+			  AND NOT EXISTS (
+				SELECT 1 FROM ban_evasion_flags
+				WHERE queue_item_id = $1 AND status = 'pending'
+			  )
+		`, *f.QueueItemID, queueStatus, reviewerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve ban evasion queue entry: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit ban evasion review: %w", err)
+	}
+	return &f, nil
+}
+
+// ListLinkedAccounts returns the other accounts that share sign-in signals
+// with a user, banned accounts and the strongest links first
+func (r *BanEvasionRepository) ListLinkedAccounts(ctx context.Context, userID uuid.UUID, limit int) ([]models.LinkedAccount, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT b.user_id, u.username, COALESCE(u.is_banned, false), u.created_at,
+			array_agg(DISTINCT b.signal_type ORDER BY b.signal_type),
+			MAX(LEAST(s.last_seen_at, b.last_seen_at))
+		FROM account_signals s
+		JOIN account_signals b
+			ON b.signal_type = s.signal_type AND b.signal_value = s.signal_value AND b.user_id <> s.user_id
+		JOIN users u ON u.id = b.user_id
+		WHERE s.user_id = $1
+		GROUP BY b.user_id, u.username, u.is_banned, u.created_at
+		ORDER BY 3 DESC, COUNT(DISTINCT b.signal_type) DESC, 6 DESC
+		LIMIT $2
+	`, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list linked accounts: %w", err)
+	}
+	defer rows.Close()
+
+	accounts := []models.LinkedAccount{}
+	for rows.Next() {
+		var a models.LinkedAccount
+		if err := rows.Scan(&a.UserID, &a.Username, &a.IsBanned, &a.CreatedAt, &a.MatchedSignals, &a.LastSharedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan linked account: %w", err)
+		}
+		accounts = append(accounts, a)
+	}
+	return accounts, rows.Err()
+}
+
+// PruneSignals deletes signals last seen before the cutoff. Banned accounts
+// keep their signals so accounts created to evade the ban can still be matched.
+func (r *BanEvasionRepository) PruneSignals(ctx context.Context, lastSeenBefore time.Time) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `
+		DELETE FROM account_signals s
+		WHERE s.last_seen_at < $1
+		  AND NOT EXISTS (SELECT 1 FROM users u WHERE u.id = s.user_id AND u.is_banned = true)
+	`, lastSeenBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune account signals: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+func scanBanEvasionFlagWithUsers(row pgx.Row) (*models.BanEvasionFlagWithUsers, error) {
+	var f models.BanEvasionFlagWithUsers
+	err := row.Scan(
+		&f.ID, &f.UserID, &f.BannedUserID, &f.Confidence, &f.MatchedSignals, &f.Status,
+		&f.QueueItemID, &f.ReviewedBy, &f.ReviewedAt, &f.ReviewNotes, &f.CreatedAt,
+		&f.Username, &f.UserCreatedAt, &f.BannedUsername, &f.BanReason, &f.ReviewerUsername,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// ErrBroadcasterReviewNotFound is returned when a broadcaster clip review does not exist
+var ErrBroadcasterReviewNotFound = errors.New("broadcaster clip review not found")
+
+// BroadcasterApprovalRepository handles database operations for broadcaster clip approval
+type BroadcasterApprovalRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewBroadcasterApprovalRepository creates a new broadcaster approval repository
+func NewBroadcasterApprovalRepository(db *pgxpool.Pool) *BroadcasterApprovalRepository {
+	return &BroadcasterApprovalRepository{db: db}
+}
+
+// GetPolicy returns the approval policy for a broadcaster, or nil if none is set
+func (r *BroadcasterApprovalRepository) GetPolicy(ctx context.Context, broadcasterID string) (*models.BroadcasterApprovalPolicy, error) {
+	query := `
+		SELECT broadcaster_id, user_id, require_approval, auto_approve_after_days, created_at, updated_at
+		FROM broadcaster_approval_policies
+		WHERE broadcaster_id = $1
+	`
+
+	var policy models.BroadcasterApprovalPolicy
+	err := r.db.QueryRow(ctx, query, broadcasterID).Scan(
+		&policy.BroadcasterID,
+		&policy.UserID,
+		&policy.RequireApproval,
+		&policy.AutoApproveAfterDays,
+		&policy.CreatedAt,
+		&policy.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get broadcaster approval policy: %w", err)
+	}
+
+	return &policy, nil
+}
+
+// UpsertPolicy creates or replaces a broadcaster's approval policy
+func (r *BroadcasterApprovalRepository) UpsertPolicy(ctx context.Context, policy *models.BroadcasterApprovalPolicy) error {
+	query := `
+		INSERT INTO broadcaster_approval_policies (broadcaster_id, user_id, require_approval, auto_approve_after_days)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (broadcaster_id) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			require_approval = EXCLUDED.require_approval,
+			auto_approve_after_days = EXCLUDED.auto_approve_after_days,
+			updated_at = NOW()
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		policy.BroadcasterID,
+		policy.UserID,
+		policy.RequireApproval,
+		policy.AutoApproveAfterDays,
+	).Scan(&policy.CreatedAt, &policy.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save broadcaster approval policy: %w", err)
+	}
+
+	return nil
+}
+
+// CreateReview adds a held submission to the broadcaster's approval queue
+func (r *BroadcasterApprovalRepository) CreateReview(ctx context.Context, review *models.BroadcasterClipReview) error {
+	query := `
+		INSERT INTO broadcaster_clip_reviews (submission_id, broadcaster_id, status, auto_approve_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		review.SubmissionID,
+		review.BroadcasterID,
+		review.Status,
+		review.AutoApproveAt,
+	).Scan(&review.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create broadcaster clip review: %w", err)
+	}
+
+	return nil
+}
+
+// GetReview returns the broadcaster review for a submission
+func (r *BroadcasterApprovalRepository) GetReview(ctx context.Context, submissionID uuid.UUID) (*models.BroadcasterClipReview, error) {
+	query := `
+		SELECT submission_id, broadcaster_id, status, auto_approve_at, reviewed_by, reviewed_at, rejection_reason, created_at
+		FROM broadcaster_clip_reviews
+		WHERE submission_id = $1
+	`
+
+	review, err := scanBroadcasterClipReview(r.db.QueryRow(ctx, query, submissionID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBroadcasterReviewNotFound
+		}
+		return nil, fmt.Errorf("failed to get broadcaster clip review: %w", err)
+	}
+
+	return review, nil
+}
+
+// ListPendingReviews returns a broadcaster's pending reviews with their submissions, oldest first
+func (r *BroadcasterApprovalRepository) ListPendingReviews(ctx context.Context, broadcasterID string, limit, offset int) ([]*models.BroadcasterClipReviewWithSubmission, int, error) {
+	var total int
+	err := r.db.QueryRow(ctx,
+		"SELECT COUNT(*) FROM broadcaster_clip_reviews WHERE broadcaster_id = $1 AND status = 'pending'",
+		broadcasterID,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count broadcaster clip reviews: %w", err)
+	}
+
+	query := `
+		SELECT bcr.submission_id, bcr.broadcaster_id, bcr.status, bcr.auto_approve_at,
+			bcr.reviewed_by, bcr.reviewed_at, bcr.rejection_reason, bcr.created_at,
+			cs.id, cs.user_id, cs.twitch_clip_id, cs.twitch_clip_url, cs.title, cs.custom_title,
+			cs.tags, cs.is_nsfw, cs.submission_reason, cs.status, cs.created_at, cs.updated_at,
+			cs.creator_name, cs.broadcaster_name, cs.broadcaster_id, cs.game_name,
+			cs.thumbnail_url, cs.duration, cs.view_count
+		FROM broadcaster_clip_reviews bcr
+		JOIN clip_submissions cs ON cs.id = bcr.submission_id
+		WHERE bcr.broadcaster_id = $1 AND bcr.status = 'pending'
+		ORDER BY bcr.created_at ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(ctx, query, broadcasterID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list broadcaster clip reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := []*models.BroadcasterClipReviewWithSubmission{}
+	for rows.Next() {
+		var entry models.BroadcasterClipReviewWithSubmission
+		var submission models.ClipSubmission
+		if err := rows.Scan(
+			&entry.SubmissionID,
+			&entry.BroadcasterID,
+			&entry.Status,
+			&entry.AutoApproveAt,
+			&entry.ReviewedBy,
+			&entry.ReviewedAt,
+			&entry.RejectionReason,
+			&entry.CreatedAt,
+			&submission.ID,
+			&submission.UserID,
+			&submission.TwitchClipID,
+			&submission.TwitchClipURL,
+			&submission.Title,
+			&submission.CustomTitle,
+			&submission.Tags,
+			&submission.IsNSFW,
+			&submission.SubmissionReason,
+			&submission.Status,
+			&submission.CreatedAt,
+			&submission.UpdatedAt,
+			&submission.CreatorName,
+			&submission.BroadcasterName,
+			&submission.BroadcasterID,
+			&submission.GameName,
+			&submission.ThumbnailURL,
+			&submission.Duration,
+			&submission.ViewCount,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan broadcaster clip review: %w", err)
+		}
+		entry.Submission = &submission
+		reviews = append(reviews, &entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate broadcaster clip reviews: %w", err)
+	}
+
+	return reviews, total, nil
+}
+
+// ResolveReview moves a pending review to its final status. It returns false
+// when the review was already resolved, so concurrent decisions apply only once.
+func (r *BroadcasterApprovalRepository) ResolveReview(ctx context.Context, submissionID uuid.UUID, status string, reviewedBy *uuid.UUID, rejectionReason *string) (bool, error) {
+	query := `
+		UPDATE broadcaster_clip_reviews
+		SET status = $1, reviewed_by = $2, reviewed_at = NOW(), rejection_reason = $3
+		WHERE submission_id = $4 AND status = 'pending'
+	`
+
+	result, err := r.db.Exec(ctx, query, status, reviewedBy, rejectionReason, submissionID)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve broadcaster clip review: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// ListExpiredReviews returns pending reviews whose auto-approval deadline has passed
+func (r *BroadcasterApprovalRepository) ListExpiredReviews(ctx context.Context, now time.Time, limit int) ([]*models.BroadcasterClipReview, error) {
+	query := `
+		SELECT submission_id, broadcaster_id, status, auto_approve_at, reviewed_by, reviewed_at, rejection_reason, created_at
+		FROM broadcaster_clip_reviews
+		WHERE status = 'pending' AND auto_approve_at <= $1
+		ORDER BY auto_approve_at ASC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired broadcaster clip reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := []*models.BroadcasterClipReview{}
+	for rows.Next() {
+		review, err := scanBroadcasterClipReview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan broadcaster clip review: %w", err)
+		}
+		reviews = append(reviews, review)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate broadcaster clip reviews: %w", err)
+	}
+
+	return reviews, nil
+}
+
+func scanBroadcasterClipReview(row pgx.Row) (*models.BroadcasterClipReview, error) {
+	var review models.BroadcasterClipReview
+	err := row.Scan(
+		&review.SubmissionID,
+		&review.BroadcasterID,
+		&review.Status,
+		&review.AutoApproveAt,
+		&review.ReviewedBy,
+		&review.ReviewedAt,
+		&review.RejectionReason,
+		&review.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
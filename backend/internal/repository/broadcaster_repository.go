@@ -82,15 +82,16 @@ func (r *BroadcasterRepository) GetFollowerCount(ctx context.Context, broadcaste
 	return count, nil
 }
 
-// GetBroadcasterStats returns statistics for a broadcaster from the clips table
+// GetBroadcasterStats returns statistics for a broadcaster from the clips
+// table, counting the clips they are the primary or a featured broadcaster of
 func (r *BroadcasterRepository) GetBroadcasterStats(ctx context.Context, broadcasterID string) (totalClips int, totalViews int64, avgVoteScore float64, err error) {
 	query := `
 		SELECT
 			COUNT(*) as total_clips,
-			COALESCE(SUM(view_count), 0) as total_views,
-			COALESCE(AVG(vote_score), 0) as avg_vote_score
-		FROM clips
-		WHERE broadcaster_id = $1 AND is_removed = false
+			COALESCE(SUM(c.view_count), 0) as total_views,
+			COALESCE(AVG(c.vote_score), 0) as avg_vote_score
+		FROM clips c
+		WHERE ` + clipCreditsBroadcaster + ` AND c.is_removed = false
 	`
 	err = r.pool.QueryRow(ctx, query, broadcasterID).Scan(&totalClips, &totalViews, &avgVoteScore)
 	if err != nil && err != pgx.ErrNoRows {
@@ -118,13 +119,16 @@ func (r *BroadcasterRepository) GetBroadcasterByName(ctx context.Context, broadc
 	return broadcasterID, nil
 }
 
-// GetBroadcasterByID returns broadcaster name from clips.
+// GetBroadcasterByID returns broadcaster name from clips, including clips
+// the broadcaster is featured in.
 // Display name should be fetched from Twitch API separately.
 func (r *BroadcasterRepository) GetBroadcasterByID(ctx context.Context, broadcasterID string) (broadcasterName string, err error) {
 	query := `
-		SELECT broadcaster_name
-		FROM clips
-		WHERE broadcaster_id = $1
+		SELECT broadcaster_name FROM (
+			(SELECT broadcaster_name FROM clips WHERE broadcaster_id = $1 LIMIT 1)
+			UNION ALL
+			(SELECT broadcaster_name FROM clip_featured_broadcasters WHERE broadcaster_id = $1 LIMIT 1)
+		) names
 		LIMIT 1
 	`
 	err = r.pool.QueryRow(ctx, query, broadcasterID).Scan(&broadcasterName)
@@ -506,13 +510,14 @@ WHERE broadcaster_id = $1
 	return userIDs, nil
 }
 
-// ListBroadcasterGames returns games a broadcaster has clips in, ordered by clip count.
+// ListBroadcasterGames returns games a broadcaster has clips in, as the
+// primary or a featured broadcaster, ordered by clip count.
 func (r *BroadcasterRepository) ListBroadcasterGames(ctx context.Context, broadcasterID string) ([]models.GameWithClipCount, error) {
 	query := `
 		SELECT c.game_id, COALESCE(g.name, c.game_name, 'Unknown'), COUNT(*) as clip_count, g.box_art_url
 		FROM clips c
 		LEFT JOIN games g ON g.twitch_game_id = c.game_id
-		WHERE c.broadcaster_id = $1 AND c.is_removed = false AND c.game_id IS NOT NULL
+		WHERE ` + clipCreditsBroadcaster + ` AND c.is_removed = false AND c.game_id IS NOT NULL
 		GROUP BY c.game_id, g.name, c.game_name, g.box_art_url
 		ORDER BY clip_count DESC
 		LIMIT 50
@@ -537,16 +542,25 @@ func (r *BroadcasterRepository) ListBroadcasterGames(ctx context.Context, broadc
 	return games, nil
 }
 
-// ListPopularBroadcasters returns broadcasters ordered by clip count
+// ListPopularBroadcasters returns broadcasters ordered by clip count,
+// counting clips they are featured in
 func (r *BroadcasterRepository) ListPopularBroadcasters(ctx context.Context, limit int) ([]models.PopularBroadcaster, error) {
 	if limit < 1 || limit > 50 {
 		limit = 15
 	}
 	query := `
-		SELECT broadcaster_id, broadcaster_name, COUNT(*) as clip_count
-		FROM clips
-		WHERE is_removed = false AND is_hidden = false AND broadcaster_id IS NOT NULL AND broadcaster_name != ''
-		GROUP BY broadcaster_id, broadcaster_name
+		SELECT broadcaster_id, MAX(broadcaster_name) as broadcaster_name, COUNT(*) as clip_count
+		FROM (
+			SELECT broadcaster_id, broadcaster_name
+			FROM clips
+			WHERE is_removed = false AND is_hidden = false AND broadcaster_id IS NOT NULL AND broadcaster_name != ''
+			UNION ALL
+			SELECT cfb.broadcaster_id, cfb.broadcaster_name
+			FROM clip_featured_broadcasters cfb
+			JOIN clips c ON c.id = cfb.clip_id
+			WHERE c.is_removed = false AND c.is_hidden = false
+		) credits
+		GROUP BY broadcaster_id
 		ORDER BY clip_count DESC
 		LIMIT $1
 	`
@@ -0,0 +1,315 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// Sentinel errors for broadcaster schedule operations
+var (
+	// ErrScheduleNotSynced is returned when a broadcaster's schedule was never pulled from Twitch
+	ErrScheduleNotSynced = errors.New("broadcaster schedule not synced")
+	// ErrScheduleReminderNotFound is returned when a user has no reminder for a broadcaster
+	ErrScheduleReminderNotFound = errors.New("schedule reminder not found")
+)
+
+// scheduleRetention is how long past segments are kept before a sync prunes them
+const scheduleRetention = 7 * 24 * time.Hour
+
+const scheduleSegmentColumns = `
+	s.id, s.broadcaster_id, s.segment_id, s.title, s.category_id, s.category_name,
+	s.start_time, s.end_time, s.is_recurring, s.is_canceled`
+
+// BroadcasterScheduleRepository handles database operations for broadcaster schedules and reminders
+type BroadcasterScheduleRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewBroadcasterScheduleRepository creates a new BroadcasterScheduleRepository
+func NewBroadcasterScheduleRepository(pool *pgxpool.Pool) *BroadcasterScheduleRepository {
+	return &BroadcasterScheduleRepository{pool: pool}
+}
+
+func scanScheduleSegment(row pgx.Row, dest ...any) (*models.BroadcasterScheduleSegment, error) {
+	var segment models.BroadcasterScheduleSegment
+	err := row.Scan(append([]any{
+		&segment.ID, &segment.BroadcasterID, &segment.SegmentID, &segment.Title,
+		&segment.CategoryID, &segment.CategoryName, &segment.StartTime, &segment.EndTime,
+		&segment.IsRecurring, &segment.IsCanceled,
+	}, dest...)...)
+	if err != nil {
+		return nil, err
+	}
+	return &segment, nil
+}
+
+// ReplaceUpcoming stores a freshly pulled schedule. Segments starting at or
+// after from that Twitch no longer returns are removed, existing segments
+// keep their IDs so sent reminders aren't repeated, and long-past segments
+// are pruned.
+func (r *BroadcasterScheduleRepository) ReplaceUpcoming(ctx context.Context, sync *models.BroadcasterScheduleSync, segments []models.BroadcasterScheduleSegment, from time.Time) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	segmentIDs := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO broadcaster_schedules (
+				broadcaster_id, segment_id, title, category_id, category_name,
+				start_time, end_time, is_recurring, is_canceled
+			)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			ON CONFLICT (broadcaster_id, segment_id) DO UPDATE SET
+				title = EXCLUDED.title,
+				category_id = EXCLUDED.category_id,
+				category_name = EXCLUDED.category_name,
+				start_time = EXCLUDED.start_time,
+				end_time = EXCLUDED.end_time,
+				is_recurring = EXCLUDED.is_recurring,
+				is_canceled = EXCLUDED.is_canceled,
+				updated_at = NOW()
+		`, sync.BroadcasterID, segment.SegmentID, segment.Title, segment.CategoryID, segment.CategoryName,
+			segment.StartTime, segment.EndTime, segment.IsRecurring, segment.IsCanceled)
+		if err != nil {
+			return fmt.Errorf("failed to upsert schedule segment: %w", err)
+		}
+		segmentIDs = append(segmentIDs, segment.SegmentID)
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM broadcaster_schedules
+		WHERE broadcaster_id = $1
+		  AND ((start_time >= $2 AND NOT (segment_id = ANY($3))) OR start_time < $4)
+	`, sync.BroadcasterID, from, segmentIDs, from.Add(-scheduleRetention))
+	if err != nil {
+		return fmt.Errorf("failed to remove stale schedule segments: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO broadcaster_schedule_syncs (
+			broadcaster_id, broadcaster_name, broadcaster_login, vacation_start, vacation_end, synced_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (broadcaster_id) DO UPDATE SET
+			broadcaster_name = COALESCE(EXCLUDED.broadcaster_name, broadcaster_schedule_syncs.broadcaster_name),
+			broadcaster_login = COALESCE(EXCLUDED.broadcaster_login, broadcaster_schedule_syncs.broadcaster_login),
+			vacation_start = EXCLUDED.vacation_start,
+			vacation_end = EXCLUDED.vacation_end,
+			synced_at = EXCLUDED.synced_at
+	`, sync.BroadcasterID, sync.BroadcasterName, sync.BroadcasterLogin, sync.VacationStart, sync.VacationEnd, sync.SyncedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record schedule sync: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit schedule: %w", err)
+	}
+	return nil
+}
+
+// GetSync returns when a broadcaster's schedule was last pulled
+func (r *BroadcasterScheduleRepository) GetSync(ctx context.Context, broadcasterID string) (*models.BroadcasterScheduleSync, error) {
+	var sync models.BroadcasterScheduleSync
+	err := r.pool.QueryRow(ctx, `
+		SELECT broadcaster_id, broadcaster_name, broadcaster_login, vacation_start, vacation_end, synced_at
+		FROM broadcaster_schedule_syncs
+		WHERE broadcaster_id = $1
+	`, broadcasterID).Scan(
+		&sync.BroadcasterID, &sync.BroadcasterName, &sync.BroadcasterLogin,
+		&sync.VacationStart, &sync.VacationEnd, &sync.SyncedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrScheduleNotSynced
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule sync: %w", err)
+	}
+	return &sync, nil
+}
+
+// ListSegments returns a broadcaster's segments that haven't ended by from
+// and start before until, including canceled ones
+func (r *BroadcasterScheduleRepository) ListSegments(ctx context.Context, broadcasterID string, from, until time.Time, limit int) ([]models.BroadcasterScheduleSegment, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+scheduleSegmentColumns+`
+		FROM broadcaster_schedules s
+		WHERE s.broadcaster_id = $1
+		  AND COALESCE(s.end_time, s.start_time) >= $2
+		  AND s.start_time < $3
+		ORDER BY s.start_time
+		LIMIT $4
+	`, broadcasterID, from, until, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schedule segments: %w", err)
+	}
+	defer rows.Close()
+
+	segments := []models.BroadcasterScheduleSegment{}
+	for rows.Next() {
+		segment, err := scanScheduleSegment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan schedule segment: %w", err)
+		}
+		segments = append(segments, *segment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating schedule segments: %w", err)
+	}
+	return segments, nil
+}
+
+// ListUpcomingForUser returns scheduled streams of the broadcasters a user
+// follows that haven't ended by from and start before until. Canceled
+// occurrences are left out.
+func (r *BroadcasterScheduleRepository) ListUpcomingForUser(ctx context.Context, userID uuid.UUID, from, until time.Time, limit, offset int) ([]models.UpcomingStream, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+scheduleSegmentColumns+`,
+		       COALESCE(sy.broadcaster_name, bf.broadcaster_name), sy.broadcaster_login, rem.minutes_before
+		FROM broadcaster_follows bf
+		INNER JOIN broadcaster_schedules s ON s.broadcaster_id = bf.broadcaster_id
+		LEFT JOIN broadcaster_schedule_syncs sy ON sy.broadcaster_id = bf.broadcaster_id
+		LEFT JOIN broadcaster_schedule_reminders rem ON rem.user_id = bf.user_id AND rem.broadcaster_id = bf.broadcaster_id
+		WHERE bf.user_id = $1
+		  AND NOT s.is_canceled
+		  AND COALESCE(s.end_time, s.start_time) >= $2
+		  AND s.start_time < $3
+		ORDER BY s.start_time, s.id
+		LIMIT $4 OFFSET $5
+	`, userID, from, until, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list upcoming streams: %w", err)
+	}
+	defer rows.Close()
+
+	streams := []models.UpcomingStream{}
+	for rows.Next() {
+		var stream models.UpcomingStream
+		segment, err := scanScheduleSegment(rows, &stream.BroadcasterName, &stream.BroadcasterLogin, &stream.ReminderMinutes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan upcoming stream: %w", err)
+		}
+		stream.BroadcasterScheduleSegment = *segment
+		streams = append(streams, stream)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating upcoming streams: %w", err)
+	}
+	return streams, nil
+}
+
+// GetReminder returns a user's reminder setting for a broadcaster
+func (r *BroadcasterScheduleRepository) GetReminder(ctx context.Context, userID uuid.UUID, broadcasterID string) (*models.BroadcasterScheduleReminder, error) {
+	var reminder models.BroadcasterScheduleReminder
+	err := r.pool.QueryRow(ctx, `
+		SELECT user_id, broadcaster_id, minutes_before, created_at, updated_at
+		FROM broadcaster_schedule_reminders
+		WHERE user_id = $1 AND broadcaster_id = $2
+	`, userID, broadcasterID).Scan(
+		&reminder.UserID, &reminder.BroadcasterID, &reminder.MinutesBefore, &reminder.CreatedAt, &reminder.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrScheduleReminderNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule reminder: %w", err)
+	}
+	return &reminder, nil
+}
+
+// UpsertReminder turns on reminders for a broadcaster or changes how early they're sent
+func (r *BroadcasterScheduleRepository) UpsertReminder(ctx context.Context, reminder *models.BroadcasterScheduleReminder) error {
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO broadcaster_schedule_reminders (user_id, broadcaster_id, minutes_before)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, broadcaster_id) DO UPDATE SET
+			minutes_before = EXCLUDED.minutes_before,
+			updated_at = NOW()
+		RETURNING created_at, updated_at
+	`, reminder.UserID, reminder.BroadcasterID, reminder.MinutesBefore).Scan(&reminder.CreatedAt, &reminder.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save schedule reminder: %w", err)
+	}
+	return nil
+}
+
+// DeleteReminder turns off reminders for a broadcaster
+func (r *BroadcasterScheduleRepository) DeleteReminder(ctx context.Context, userID uuid.UUID, broadcasterID string) error {
+	result, err := r.pool.Exec(ctx, `
+		DELETE FROM broadcaster_schedule_reminders
+		WHERE user_id = $1 AND broadcaster_id = $2
+	`, userID, broadcasterID)
+	if err != nil {
+		return fmt.Errorf("failed to delete schedule reminder: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrScheduleReminderNotFound
+	}
+	return nil
+}
+
+// ListDueReminders returns reminders whose stream starts within the user's
+// lead time of now and that haven't been sent. Users who unfollowed the
+// broadcaster are skipped.
+func (r *BroadcasterScheduleRepository) ListDueReminders(ctx context.Context, now time.Time, limit int) ([]models.DueScheduleReminder, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT rem.user_id, s.id, s.broadcaster_id, COALESCE(sy.broadcaster_name, bf.broadcaster_name),
+		       sy.broadcaster_login, s.title, s.category_name, s.start_time
+		FROM broadcaster_schedule_reminders rem
+		INNER JOIN broadcaster_follows bf ON bf.user_id = rem.user_id AND bf.broadcaster_id = rem.broadcaster_id
+		INNER JOIN broadcaster_schedules s ON s.broadcaster_id = rem.broadcaster_id
+		LEFT JOIN broadcaster_schedule_syncs sy ON sy.broadcaster_id = rem.broadcaster_id
+		WHERE NOT s.is_canceled
+		  AND s.start_time > $1
+		  AND s.start_time <= $1 + rem.minutes_before * INTERVAL '1 minute'
+		  AND NOT EXISTS (
+			SELECT 1 FROM broadcaster_schedule_reminder_deliveries d
+			WHERE d.user_id = rem.user_id AND d.schedule_id = s.id
+		  )
+		ORDER BY s.start_time
+		LIMIT $2
+	`, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due schedule reminders: %w", err)
+	}
+	defer rows.Close()
+
+	var reminders []models.DueScheduleReminder
+	for rows.Next() {
+		var reminder models.DueScheduleReminder
+		if err := rows.Scan(
+			&reminder.UserID, &reminder.ScheduleID, &reminder.BroadcasterID, &reminder.BroadcasterName,
+			&reminder.BroadcasterLogin, &reminder.Title, &reminder.CategoryName, &reminder.StartTime,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan due schedule reminder: %w", err)
+		}
+		reminders = append(reminders, reminder)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating due schedule reminders: %w", err)
+	}
+	return reminders, nil
+}
+
+// MarkReminderSent records a reminder as sent, reporting false when it
+// already was so concurrent senders don't both deliver it
+func (r *BroadcasterScheduleRepository) MarkReminderSent(ctx context.Context, userID, scheduleID uuid.UUID) (bool, error) {
+	result, err := r.pool.Exec(ctx, `
+		INSERT INTO broadcaster_schedule_reminder_deliveries (user_id, schedule_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`, userID, scheduleID)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark schedule reminder sent: %w", err)
+	}
+	return result.RowsAffected() == 1, nil
+}
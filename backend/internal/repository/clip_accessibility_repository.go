@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// ClipAccessibilityRepository handles database operations for clip accessibility metadata
+type ClipAccessibilityRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewClipAccessibilityRepository creates a new clip accessibility repository
+func NewClipAccessibilityRepository(db *pgxpool.Pool) *ClipAccessibilityRepository {
+	return &ClipAccessibilityRepository{db: db}
+}
+
+// GetByClipID returns a clip's accessibility metadata, or nil if none was set
+func (r *ClipAccessibilityRepository) GetByClipID(ctx context.Context, clipID uuid.UUID) (*models.ClipAccessibility, error) {
+	query := `
+		SELECT clip_id, has_captions, captions_source, photosensitivity_warning,
+		       audio_description, updated_by, updated_at
+		FROM clip_accessibility
+		WHERE clip_id = $1
+	`
+
+	var a models.ClipAccessibility
+	err := r.db.QueryRow(ctx, query, clipID).Scan(
+		&a.ClipID,
+		&a.HasCaptions,
+		&a.CaptionsSource,
+		&a.PhotosensitivityWarning,
+		&a.AudioDescription,
+		&a.UpdatedBy,
+		&a.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get clip accessibility: %w", err)
+	}
+
+	return &a, nil
+}
+
+// GetByClipIDs returns accessibility metadata keyed by clip ID; clips without any are omitted
+func (r *ClipAccessibilityRepository) GetByClipIDs(ctx context.Context, clipIDs []uuid.UUID) (map[uuid.UUID]*models.ClipAccessibility, error) {
+	result := make(map[uuid.UUID]*models.ClipAccessibility, len(clipIDs))
+	if len(clipIDs) == 0 {
+		return result, nil
+	}
+
+	query := `
+		SELECT clip_id, has_captions, captions_source, photosensitivity_warning,
+		       audio_description, updated_by, updated_at
+		FROM clip_accessibility
+		WHERE clip_id = ANY($1)
+	`
+
+	rows, err := r.db.Query(ctx, query, clipIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get clip accessibility: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a models.ClipAccessibility
+		if err := rows.Scan(
+			&a.ClipID,
+			&a.HasCaptions,
+			&a.CaptionsSource,
+			&a.PhotosensitivityWarning,
+			&a.AudioDescription,
+			&a.UpdatedBy,
+			&a.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan clip accessibility: %w", err)
+		}
+		result[a.ClipID] = &a
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating clip accessibility: %w", err)
+	}
+
+	return result, nil
+}
+
+// Upsert stores a clip's accessibility metadata
+func (r *ClipAccessibilityRepository) Upsert(ctx context.Context, a *models.ClipAccessibility) error {
+	query := `
+		INSERT INTO clip_accessibility (
+			clip_id, has_captions, captions_source, photosensitivity_warning,
+			audio_description, updated_by, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT (clip_id) DO UPDATE SET
+			has_captions = EXCLUDED.has_captions,
+			captions_source = EXCLUDED.captions_source,
+			photosensitivity_warning = EXCLUDED.photosensitivity_warning,
+			audio_description = EXCLUDED.audio_description,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		a.ClipID, a.HasCaptions, a.CaptionsSource, a.PhotosensitivityWarning,
+		a.AudioDescription, a.UpdatedBy,
+	).Scan(&a.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert clip accessibility: %w", err)
+	}
+	return nil
+}
+
+// MarkCaptionsFromTranscript records that a clip has captions because a
+// transcript exists. Clips whose captions were set manually are left alone.
+// Returns whether the clip was updated.
+func (r *ClipAccessibilityRepository) MarkCaptionsFromTranscript(ctx context.Context, clipID uuid.UUID) (bool, error) {
+	query := `
+		INSERT INTO clip_accessibility (clip_id, has_captions, captions_source, updated_at)
+		VALUES ($1, true, 'transcript', NOW())
+		ON CONFLICT (clip_id) DO UPDATE SET
+			has_captions = true,
+			captions_source = 'transcript',
+			updated_at = NOW()
+		WHERE clip_accessibility.captions_source IS DISTINCT FROM 'manual'
+			AND clip_accessibility.has_captions = false
+	`
+
+	tag, err := r.db.Exec(ctx, query, clipID)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark clip captions from transcript: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,105 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/testutil"
+)
+
+func TestClipAccessibilityRepository_FiltersAndTranscriptDetection(t *testing.T) {
+	// Skip if not in integration test mode
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	pool := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, pool)
+	testutil.TruncateTables(t, pool, "clips", "clip_accessibility")
+
+	clipRepo := NewClipRepository(pool)
+	repo := NewClipAccessibilityRepository(pool)
+	ctx := context.Background()
+
+	newClip := func(title string) *models.Clip {
+		clip := &models.Clip{
+			ID:              uuid.New(),
+			TwitchClipID:    fmt.Sprintf("a11y-%s", uuid.NewString()),
+			TwitchClipURL:   "https://clips.twitch.tv/a11y",
+			EmbedURL:        "https://clips.twitch.tv/embed?clip=a11y",
+			Title:           title,
+			CreatorName:     "creator",
+			BroadcasterName: "Streamer",
+			CreatedAt:       time.Now().Add(-1 * time.Hour),
+			ImportedAt:      time.Now(),
+		}
+		if err := clipRepo.Create(ctx, clip); err != nil {
+			t.Fatalf("Failed to create clip: %v", err)
+		}
+		return clip
+	}
+
+	manual := models.CaptionsSourceManual
+	captioned := newClip("Captioned")
+	flashing := newClip("Flashing")
+	transcribed := newClip("Transcribed")
+	optedOut := newClip("Manually uncaptioned")
+
+	if err := repo.Upsert(ctx, &models.ClipAccessibility{ClipID: captioned.ID, HasCaptions: true, CaptionsSource: &manual}); err != nil {
+		t.Fatalf("Failed to upsert accessibility: %v", err)
+	}
+	if err := repo.Upsert(ctx, &models.ClipAccessibility{ClipID: flashing.ID, PhotosensitivityWarning: true}); err != nil {
+		t.Fatalf("Failed to upsert accessibility: %v", err)
+	}
+	if err := repo.Upsert(ctx, &models.ClipAccessibility{ClipID: optedOut.ID, HasCaptions: false, CaptionsSource: &manual}); err != nil {
+		t.Fatalf("Failed to upsert accessibility: %v", err)
+	}
+
+	// Transcripts mark captions unless they were set manually
+	updated, err := repo.MarkCaptionsFromTranscript(ctx, transcribed.ID)
+	if err != nil || !updated {
+		t.Fatalf("Expected transcript to mark captions, updated=%v err=%v", updated, err)
+	}
+	updated, err = repo.MarkCaptionsFromTranscript(ctx, optedOut.ID)
+	if err != nil || updated {
+		t.Fatalf("Expected manual setting to win over transcript, updated=%v err=%v", updated, err)
+	}
+
+	clips, total, err := clipRepo.ListWithFilters(ctx, ClipFilters{Sort: "new", CaptionedOnly: true}, 10, 0)
+	if err != nil {
+		t.Fatalf("Failed to list captioned clips: %v", err)
+	}
+	if total != 2 {
+		t.Errorf("Expected 2 captioned clips, got %d", total)
+	}
+	for _, clip := range clips {
+		if clip.ID != captioned.ID && clip.ID != transcribed.ID {
+			t.Errorf("Unexpected clip in captioned feed: %s", clip.Title)
+		}
+	}
+
+	_, total, err = clipRepo.ListWithFilters(ctx, ClipFilters{Sort: "new", HidePhotosensitive: true}, 10, 0)
+	if err != nil {
+		t.Fatalf("Failed to list clips without photosensitivity warnings: %v", err)
+	}
+	if total != 3 {
+		t.Errorf("Expected 3 clips without photosensitivity warnings, got %d", total)
+	}
+
+	byClip, err := repo.GetByClipIDs(ctx, []uuid.UUID{captioned.ID, flashing.ID, transcribed.ID, optedOut.ID})
+	if err != nil {
+		t.Fatalf("Failed to get accessibility: %v", err)
+	}
+	if got := byClip[transcribed.ID]; got == nil || got.CaptionsSource == nil || *got.CaptionsSource != models.CaptionsSourceTranscript {
+		t.Errorf("Expected transcript caption source, got %+v", got)
+	}
+	if got := byClip[optedOut.ID]; got == nil || got.HasCaptions {
+		t.Errorf("Expected manually uncaptioned clip to stay uncaptioned, got %+v", got)
+	}
+}
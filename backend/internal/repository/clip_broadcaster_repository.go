@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// ErrClipBroadcastersClipNotFound is returned when the attributed clip does not exist
+var ErrClipBroadcastersClipNotFound = errors.New("clip not found")
+
+// clipCreditsBroadcaster matches clips c that broadcaster $1 is the primary or
+// a featured broadcaster of
+const clipCreditsBroadcaster = `(c.broadcaster_id = $1 OR EXISTS (
+	SELECT 1 FROM clip_featured_broadcasters cfb WHERE cfb.clip_id = c.id AND cfb.broadcaster_id = $1
+))`
+
+// ClipBroadcasterRepository handles database operations for clip broadcaster attribution
+type ClipBroadcasterRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewClipBroadcasterRepository creates a new ClipBroadcasterRepository
+func NewClipBroadcasterRepository(pool *pgxpool.Pool) *ClipBroadcasterRepository {
+	return &ClipBroadcasterRepository{pool: pool}
+}
+
+// GetClipBroadcasters returns a clip's primary broadcaster and, in order, the
+// broadcasters featured in it
+func (r *ClipBroadcasterRepository) GetClipBroadcasters(ctx context.Context, clipID uuid.UUID) (*models.ClipBroadcasters, error) {
+	attribution := &models.ClipBroadcasters{ClipID: clipID, Featured: []models.ClipBroadcaster{}}
+
+	err := r.pool.QueryRow(ctx, `
+		SELECT COALESCE(broadcaster_id, ''), broadcaster_name
+		FROM clips
+		WHERE id = $1
+	`, clipID).Scan(&attribution.Primary.BroadcasterID, &attribution.Primary.BroadcasterName)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrClipBroadcastersClipNotFound
+		}
+		return nil, fmt.Errorf("failed to get clip broadcaster: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT broadcaster_id, broadcaster_name
+		FROM clip_featured_broadcasters
+		WHERE clip_id = $1
+		ORDER BY position, created_at
+	`, clipID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list featured broadcasters: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var featured models.ClipBroadcaster
+		if err := rows.Scan(&featured.BroadcasterID, &featured.BroadcasterName); err != nil {
+			return nil, fmt.Errorf("failed to scan featured broadcaster: %w", err)
+		}
+		attribution.Featured = append(attribution.Featured, featured)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating featured broadcasters: %w", err)
+	}
+
+	return attribution, nil
+}
+
+// SetClipBroadcasters replaces a clip's primary broadcaster and featured
+// broadcasters in one transaction
+func (r *ClipBroadcasterRepository) SetClipBroadcasters(ctx context.Context, attribution *models.ClipBroadcasters, updatedBy uuid.UUID) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	result, err := tx.Exec(ctx, `
+		UPDATE clips
+		SET broadcaster_id = $2, broadcaster_name = $3
+		WHERE id = $1
+	`, attribution.ClipID, attribution.Primary.BroadcasterID, attribution.Primary.BroadcasterName)
+	if err != nil {
+		return fmt.Errorf("failed to update clip broadcaster: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrClipBroadcastersClipNotFound
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM clip_featured_broadcasters WHERE clip_id = $1`, attribution.ClipID); err != nil {
+		return fmt.Errorf("failed to clear featured broadcasters: %w", err)
+	}
+
+	for i, featured := range attribution.Featured {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO clip_featured_broadcasters (clip_id, broadcaster_id, broadcaster_name, position, added_by)
+			VALUES ($1, $2, $3, $4, $5)
+		`, attribution.ClipID, featured.BroadcasterID, featured.BroadcasterName, i, updatedBy)
+		if err != nil {
+			return fmt.Errorf("failed to add featured broadcaster: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit clip broadcasters: %w", err)
+	}
+	return nil
+}
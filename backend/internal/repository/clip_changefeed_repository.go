@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// clipChangefeedRelayLockKey serializes sequence assignment across API instances
+const clipChangefeedRelayLockKey int64 = 0x636c6970_66656564 // "clipfeed"
+
+const clipChangeEventColumns = `sequence, event_id, event_type, clip_id, document, occurred_at`
+
+// ClipChangefeedRepository handles the clip outbox behind the public changefeed
+type ClipChangefeedRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewClipChangefeedRepository creates a new ClipChangefeedRepository
+func NewClipChangefeedRepository(pool *pgxpool.Pool) *ClipChangefeedRepository {
+	return &ClipChangefeedRepository{pool: pool}
+}
+
+func scanClipChangeEvents(rows pgx.Rows) ([]models.ClipChangeEvent, error) {
+	defer rows.Close()
+
+	events := []models.ClipChangeEvent{}
+	for rows.Next() {
+		var event models.ClipChangeEvent
+		var document []byte
+		if err := rows.Scan(&event.Sequence, &event.EventID, &event.Type, &event.ClipID, &document, &event.OccurredAt); err != nil {
+			return nil, fmt.Errorf("failed to scan clip change event: %w", err)
+		}
+		event.Clip = document
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating clip change events: %w", err)
+	}
+	return events, nil
+}
+
+// ListSince returns up to limit sequenced events after the since sequence number
+func (r *ClipChangefeedRepository) ListSince(ctx context.Context, since int64, limit int) ([]models.ClipChangeEvent, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+clipChangeEventColumns+`
+		FROM clip_outbox
+		WHERE sequence > $1
+		ORDER BY sequence
+		LIMIT $2`, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clip change events: %w", err)
+	}
+	return scanClipChangeEvents(rows)
+}
+
+// AssignSequences numbers up to limit committed events that have no sequence
+// yet, continuing from the highest assigned sequence. Only one instance
+// assigns at a time, so sequences are gapless and become visible in order.
+// It returns 0 without waiting when another instance holds the lock.
+func (r *ClipChangefeedRepository) AssignSequences(ctx context.Context, limit int) (int, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var locked bool
+	if err := tx.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock($1)`, clipChangefeedRelayLockKey).Scan(&locked); err != nil {
+		return 0, fmt.Errorf("failed to acquire changefeed lock: %w", err)
+	}
+	if !locked {
+		return 0, nil
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE clip_outbox o
+		SET sequence = n.sequence
+		FROM (
+			SELECT id,
+			       (SELECT COALESCE(MAX(sequence), 0) FROM clip_outbox) + ROW_NUMBER() OVER (ORDER BY id) AS sequence
+			FROM clip_outbox
+			WHERE sequence IS NULL
+			ORDER BY id
+			LIMIT $1
+		) n
+		WHERE o.id = n.id`, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to assign changefeed sequences: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit changefeed sequences: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// ClaimUnpublished marks up to limit sequenced events as published and
+// returns them in sequence order. Locked rows are skipped, so concurrent
+// relays never claim the same event.
+func (r *ClipChangefeedRepository) ClaimUnpublished(ctx context.Context, limit int) ([]models.ClipChangeEvent, error) {
+	rows, err := r.pool.Query(ctx, `
+		WITH claimed AS (
+			UPDATE clip_outbox
+			SET published_at = NOW()
+			WHERE id IN (
+				SELECT id FROM clip_outbox
+				WHERE sequence IS NOT NULL AND published_at IS NULL
+				ORDER BY sequence
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING `+clipChangeEventColumns+`
+		)
+		SELECT `+clipChangeEventColumns+` FROM claimed ORDER BY sequence`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim unpublished clip change events: %w", err)
+	}
+	return scanClipChangeEvents(rows)
+}
+
+// ReleaseUnpublished returns claimed events to the unpublished queue so the
+// next relay pass retries them
+func (r *ClipChangefeedRepository) ReleaseUnpublished(ctx context.Context, sequences []int64) error {
+	if len(sequences) == 0 {
+		return nil
+	}
+	_, err := r.pool.Exec(ctx, `UPDATE clip_outbox SET published_at = NULL WHERE sequence = ANY($1)`, sequences)
+	if err != nil {
+		return fmt.Errorf("failed to release clip change events: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// ClipEmbedHealthRepository handles database operations for clip embed health
+type ClipEmbedHealthRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewClipEmbedHealthRepository creates a new clip embed health repository
+func NewClipEmbedHealthRepository(db *pgxpool.Pool) *ClipEmbedHealthRepository {
+	return &ClipEmbedHealthRepository{db: db}
+}
+
+// RecordEmbedLoad counts a successful Twitch embed load for a clip
+func (r *ClipEmbedHealthRepository) RecordEmbedLoad(ctx context.Context, clipID uuid.UUID) error {
+	query := `
+		INSERT INTO clip_embed_health (clip_id, embed_loads)
+		VALUES ($1, 1)
+		ON CONFLICT (clip_id) DO UPDATE SET
+			embed_loads = clip_embed_health.embed_loads + 1,
+			updated_at = NOW()
+	`
+
+	if _, err := r.db.Exec(ctx, query, clipID); err != nil {
+		return fmt.Errorf("failed to record embed load: %w", err)
+	}
+	return nil
+}
+
+// RecordEmbedFailure counts a failed Twitch embed load for a clip
+func (r *ClipEmbedHealthRepository) RecordEmbedFailure(ctx context.Context, clipID uuid.UUID, reason string) error {
+	query := `
+		INSERT INTO clip_embed_health (clip_id, embed_failures, last_failure_reason, last_failure_at)
+		VALUES ($1, 1, $2, NOW())
+		ON CONFLICT (clip_id) DO UPDATE SET
+			embed_failures = clip_embed_health.embed_failures + 1,
+			last_failure_reason = EXCLUDED.last_failure_reason,
+			last_failure_at = EXCLUDED.last_failure_at,
+			updated_at = NOW()
+	`
+
+	if _, err := r.db.Exec(ctx, query, clipID, reason); err != nil {
+		return fmt.Errorf("failed to record embed failure: %w", err)
+	}
+	return nil
+}
+
+// GetByClipID returns a clip's embed health, or nil if nothing was reported for it
+func (r *ClipEmbedHealthRepository) GetByClipID(ctx context.Context, clipID uuid.UUID) (*models.ClipEmbedHealth, error) {
+	query := `
+		SELECT clip_id, embed_loads, embed_failures, last_failure_reason, last_failure_at, updated_at
+		FROM clip_embed_health
+		WHERE clip_id = $1
+	`
+
+	var health models.ClipEmbedHealth
+	err := r.db.QueryRow(ctx, query, clipID).Scan(
+		&health.ClipID,
+		&health.EmbedLoads,
+		&health.EmbedFailures,
+		&health.LastFailureReason,
+		&health.LastFailureAt,
+		&health.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get clip embed health: %w", err)
+	}
+
+	return &health, nil
+}
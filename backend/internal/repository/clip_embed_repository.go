@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// ClipEmbedRepository handles embed domain rules and embed traffic rollups
+type ClipEmbedRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewClipEmbedRepository creates a new clip embed repository
+func NewClipEmbedRepository(db *pgxpool.Pool) *ClipEmbedRepository {
+	return &ClipEmbedRepository{db: db}
+}
+
+const embedDomainRuleFields = `id, broadcaster_id, domain, rule, created_by, created_at`
+
+func (r *ClipEmbedRepository) queryRules(ctx context.Context, query string, args ...interface{}) ([]models.EmbedDomainRule, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list embed domain rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []models.EmbedDomainRule{}
+	for rows.Next() {
+		var rule models.EmbedDomainRule
+		if err := rows.Scan(&rule.ID, &rule.BroadcasterID, &rule.Domain, &rule.Rule, &rule.CreatedBy, &rule.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan embed domain rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+// ListRules returns a broadcaster's rules, or the platform-wide rules when
+// broadcasterID is nil
+func (r *ClipEmbedRepository) ListRules(ctx context.Context, broadcasterID *string) ([]models.EmbedDomainRule, error) {
+	if broadcasterID == nil {
+		return r.queryRules(ctx, `
+			SELECT `+embedDomainRuleFields+`
+			FROM embed_domain_rules
+			WHERE broadcaster_id IS NULL
+			ORDER BY domain`)
+	}
+	return r.queryRules(ctx, `
+		SELECT `+embedDomainRuleFields+`
+		FROM embed_domain_rules
+		WHERE broadcaster_id = $1
+		ORDER BY domain`, *broadcasterID)
+}
+
+// ListRulesForEmbed returns the rules deciding where a broadcaster's clips may
+// be embedded: the platform-wide rules and the broadcaster's own
+func (r *ClipEmbedRepository) ListRulesForEmbed(ctx context.Context, broadcasterID string) ([]models.EmbedDomainRule, error) {
+	return r.queryRules(ctx, `
+		SELECT `+embedDomainRuleFields+`
+		FROM embed_domain_rules
+		WHERE broadcaster_id IS NULL OR broadcaster_id = $1`, broadcasterID)
+}
+
+// UpsertRule creates a rule, or replaces the rule already set on its domain
+func (r *ClipEmbedRepository) UpsertRule(ctx context.Context, rule *models.EmbedDomainRule) error {
+	query := `
+		INSERT INTO embed_domain_rules (id, broadcaster_id, domain, rule, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT ((COALESCE(broadcaster_id, '')), domain) DO UPDATE SET
+			rule = EXCLUDED.rule,
+			created_by = EXCLUDED.created_by,
+			created_at = EXCLUDED.created_at
+		RETURNING id
+	`
+	err := r.db.QueryRow(ctx, query, rule.ID, rule.BroadcasterID, rule.Domain, rule.Rule, rule.CreatedBy, rule.CreatedAt).Scan(&rule.ID)
+	if err != nil {
+		return fmt.Errorf("failed to save embed domain rule: %w", err)
+	}
+	return nil
+}
+
+// DeleteRule deletes a rule of any scope, reporting whether it existed
+func (r *ClipEmbedRepository) DeleteRule(ctx context.Context, id uuid.UUID) (bool, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM embed_domain_rules WHERE id = $1`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete embed domain rule: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// DeleteBroadcasterRule deletes one of a broadcaster's rules, reporting whether it existed
+func (r *ClipEmbedRepository) DeleteBroadcasterRule(ctx context.Context, id uuid.UUID, broadcasterID string) (bool, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM embed_domain_rules WHERE id = $1 AND broadcaster_id = $2`, id, broadcasterID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete embed domain rule: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// AddDailyStats adds counts to the daily embed traffic rollups
+func (r *ClipEmbedRepository) AddDailyStats(ctx context.Context, stats []models.ClipEmbedDailyStat) error {
+	if len(stats) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO clip_embed_daily_stats (day, domain, clip_id, broadcaster_id, load_count, click_count, blocked_count)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (day, domain, clip_id) DO UPDATE SET
+			load_count = clip_embed_daily_stats.load_count + EXCLUDED.load_count,
+			click_count = clip_embed_daily_stats.click_count + EXCLUDED.click_count,
+			blocked_count = clip_embed_daily_stats.blocked_count + EXCLUDED.blocked_count
+	`
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, stat := range stats {
+		_, err := tx.Exec(ctx, query, stat.Day, stat.Domain, stat.ClipID, stat.BroadcasterID, stat.Loads, stat.Clicks, stat.Blocked)
+		if err != nil {
+			return fmt.Errorf("failed to add embed stats: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit embed stats: %w", err)
+	}
+
+	return nil
+}
+
+// ListSiteStats returns embed traffic since a day grouped by site, most
+// loaded first, for a broadcaster's clips or all clips when broadcasterID is nil
+func (r *ClipEmbedRepository) ListSiteStats(ctx context.Context, broadcasterID *string, since time.Time) ([]models.EmbedSiteStats, error) {
+	query := `
+		SELECT domain, SUM(load_count), SUM(click_count), SUM(blocked_count),
+		       COUNT(DISTINCT clip_id) FILTER (WHERE load_count > 0)
+		FROM clip_embed_daily_stats
+		WHERE day >= $1 AND ($2::text IS NULL OR broadcaster_id = $2)
+		GROUP BY domain
+		ORDER BY SUM(load_count) DESC, SUM(click_count) DESC, domain
+	`
+
+	rows, err := r.db.Query(ctx, query, since, broadcasterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list embed site stats: %w", err)
+	}
+	defer rows.Close()
+
+	sites := []models.EmbedSiteStats{}
+	for rows.Next() {
+		var site models.EmbedSiteStats
+		if err := rows.Scan(&site.Domain, &site.Loads, &site.Clicks, &site.Blocked, &site.Clips); err != nil {
+			return nil, fmt.Errorf("failed to scan embed site stats: %w", err)
+		}
+		sites = append(sites, site)
+	}
+	return sites, rows.Err()
+}
+
+// DeleteDailyStatsBefore prunes rollups older than the given day
+func (r *ClipEmbedRepository) DeleteDailyStatsBefore(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM clip_embed_daily_stats WHERE day < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune embed stats: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
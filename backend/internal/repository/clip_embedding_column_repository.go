@@ -0,0 +1,363 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	pgvector "github.com/pgvector/pgvector-go"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// MaxIndexedEmbeddingDimensions is the most dimensions a pgvector HNSW index
+// supports
+const MaxIndexedEmbeddingDimensions = 2000
+
+var (
+	// ErrInvalidEmbeddingColumn is returned for a column name that isn't embedding or embedding_<suffix>
+	ErrInvalidEmbeddingColumn = errors.New("embedding column must be named embedding or embedding_<suffix> in lowercase letters, digits and underscores")
+	// ErrEmbeddingColumnNotFound is returned when a column isn't registered
+	ErrEmbeddingColumnNotFound = errors.New("embedding column not found")
+	// ErrEmbeddingColumnExists is returned when creating a registered column
+	ErrEmbeddingColumnExists = errors.New("embedding column already exists")
+	// ErrEmbeddingColumnNotReady is returned when activating a column that isn't backfilled
+	ErrEmbeddingColumnNotReady = errors.New("embedding column is not backfilled")
+	// ErrEmbeddingColumnInUse is returned when dropping the active or original column
+	ErrEmbeddingColumnInUse = errors.New("embedding column is in use")
+	// ErrEmbeddingColumnMismatch is returned when a column holds another model's embeddings
+	ErrEmbeddingColumnMismatch = errors.New("embedding column holds embeddings of another model")
+)
+
+var embeddingColumnPattern = regexp.MustCompile(`^embedding(_[a-z0-9]+)*$`)
+
+// ValidateEmbeddingColumn checks that column can be used as a clips embedding
+// column. Column names are written into queries, so they are restricted to
+// embedding and embedding_<suffix>.
+func ValidateEmbeddingColumn(column string) error {
+	if len(column) > 50 || !embeddingColumnPattern.MatchString(column) {
+		return ErrInvalidEmbeddingColumn
+	}
+	return nil
+}
+
+// embeddingIndexName returns the name of the HNSW index on an embedding column
+func embeddingIndexName(column string) string {
+	return "idx_clips_" + column + "_hnsw"
+}
+
+// ClipEmbeddingColumnRepository manages the clips columns holding embeddings
+// and their registry
+type ClipEmbeddingColumnRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewClipEmbeddingColumnRepository creates a new ClipEmbeddingColumnRepository
+func NewClipEmbeddingColumnRepository(pool *pgxpool.Pool) *ClipEmbeddingColumnRepository {
+	return &ClipEmbeddingColumnRepository{pool: pool}
+}
+
+const clipEmbeddingColumnFields = `column_name, provider, model, dimensions, status,
+	created_at, backfilled_at, activated_at, retired_at`
+
+func scanClipEmbeddingColumn(row pgx.Row) (*models.ClipEmbeddingColumn, error) {
+	var c models.ClipEmbeddingColumn
+	err := row.Scan(&c.ColumnName, &c.Provider, &c.Model, &c.Dimensions, &c.Status,
+		&c.CreatedAt, &c.BackfilledAt, &c.ActivatedAt, &c.RetiredAt)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Get returns a registered column without its coverage
+func (r *ClipEmbeddingColumnRepository) Get(ctx context.Context, column string) (*models.ClipEmbeddingColumn, error) {
+	c, err := scanClipEmbeddingColumn(r.pool.QueryRow(ctx,
+		`SELECT `+clipEmbeddingColumnFields+` FROM clip_embedding_columns WHERE column_name = $1`, column))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrEmbeddingColumnNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get embedding column: %w", err)
+	}
+	return c, nil
+}
+
+// CheckModel verifies that a registered column holds embeddings of the given
+// model and dimensions, so vectors of another model are never compared with
+// or written into it
+func (r *ClipEmbeddingColumnRepository) CheckModel(ctx context.Context, column, model string, dimensions int) error {
+	if err := ValidateEmbeddingColumn(column); err != nil {
+		return err
+	}
+	c, err := r.Get(ctx, column)
+	if err != nil {
+		return err
+	}
+	if c.Model != model || c.Dimensions != dimensions {
+		return fmt.Errorf("%w: %s holds %s (%d dimensions), configured %s (%d dimensions)",
+			ErrEmbeddingColumnMismatch, column, c.Model, c.Dimensions, model, dimensions)
+	}
+	return nil
+}
+
+// List returns the registered columns with their coverage, oldest first
+func (r *ClipEmbeddingColumnRepository) List(ctx context.Context) ([]*models.ClipEmbeddingColumn, error) {
+	rows, err := r.pool.Query(ctx, `SELECT `+clipEmbeddingColumnFields+` FROM clip_embedding_columns ORDER BY created_at`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list embedding columns: %w", err)
+	}
+	defer rows.Close()
+
+	columns := []*models.ClipEmbeddingColumn{}
+	for rows.Next() {
+		c, err := scanClipEmbeddingColumn(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan embedding column: %w", err)
+		}
+		columns = append(columns, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate embedding columns: %w", err)
+	}
+
+	for _, c := range columns {
+		if err := r.loadCoverage(ctx, c); err != nil {
+			return nil, err
+		}
+	}
+	return columns, nil
+}
+
+// loadCoverage counts the clips that are not removed with and without an
+// embedding in the column
+func (r *ClipEmbeddingColumnRepository) loadCoverage(ctx context.Context, c *models.ClipEmbeddingColumn) error {
+	if err := ValidateEmbeddingColumn(c.ColumnName); err != nil {
+		return err
+	}
+	query := fmt.Sprintf(`
+		SELECT COUNT(*) FILTER (WHERE %[1]s IS NOT NULL), COUNT(*) FILTER (WHERE %[1]s IS NULL)
+		FROM clips
+		WHERE is_removed = false
+	`, c.ColumnName)
+	if err := r.pool.QueryRow(ctx, query).Scan(&c.Embedded, &c.Missing); err != nil {
+		return fmt.Errorf("failed to count embeddings in %s: %w", c.ColumnName, err)
+	}
+	return nil
+}
+
+// GetWithCoverage returns a registered column with its coverage
+func (r *ClipEmbeddingColumnRepository) GetWithCoverage(ctx context.Context, column string) (*models.ClipEmbeddingColumn, error) {
+	c, err := r.Get(ctx, column)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadCoverage(ctx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Create registers a column and adds it to clips. The column's HNSW index is
+// built by CreateIndex once it is backfilled, which is much faster than
+// updating the index on every write.
+func (r *ClipEmbeddingColumnRepository) Create(ctx context.Context, c *models.ClipEmbeddingColumn) error {
+	if err := ValidateEmbeddingColumn(c.ColumnName); err != nil {
+		return err
+	}
+	if c.Dimensions <= 0 || c.Dimensions > MaxIndexedEmbeddingDimensions {
+		return fmt.Errorf("embedding dimensions must be between 1 and %d", MaxIndexedEmbeddingDimensions)
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO clip_embedding_columns (column_name, provider, model, dimensions, status)
+		VALUES ($1, $2, $3, $4, 'backfilling')
+		RETURNING status, created_at
+	`, c.ColumnName, c.Provider, c.Model, c.Dimensions).Scan(&c.Status, &c.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrEmbeddingColumnExists
+		}
+		return fmt.Errorf("failed to register embedding column: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, fmt.Sprintf(`ALTER TABLE clips ADD COLUMN %s vector(%d)`, c.ColumnName, c.Dimensions)); err != nil {
+		return fmt.Errorf("failed to add embedding column: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit embedding column: %w", err)
+	}
+	return nil
+}
+
+// CreateIndex builds the column's HNSW index without blocking writes to clips
+func (r *ClipEmbeddingColumnRepository) CreateIndex(ctx context.Context, column string) error {
+	if err := ValidateEmbeddingColumn(column); err != nil {
+		return err
+	}
+	query := fmt.Sprintf(`
+		CREATE INDEX CONCURRENTLY IF NOT EXISTS %s ON clips
+		USING hnsw (%s vector_cosine_ops)
+		WITH (m = 16, ef_construction = 64)
+	`, embeddingIndexName(column), column)
+	if _, err := r.pool.Exec(ctx, query); err != nil {
+		return fmt.Errorf("failed to create embedding index: %w", err)
+	}
+	return nil
+}
+
+// MarkReady records that a backfilling column is filled and indexed
+func (r *ClipEmbeddingColumnRepository) MarkReady(ctx context.Context, column string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE clip_embedding_columns SET status = 'ready', backfilled_at = NOW()
+		WHERE column_name = $1 AND status = 'backfilling'
+	`, column)
+	if err != nil {
+		return fmt.Errorf("failed to mark embedding column ready: %w", err)
+	}
+	return nil
+}
+
+// Activate records a ready or retired column as the one search reads, retiring
+// the column active before. Search follows EMBEDDING_COLUMN; the registry
+// lets instances check that their configuration matches it.
+func (r *ClipEmbeddingColumnRepository) Activate(ctx context.Context, column string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var status string
+	err = tx.QueryRow(ctx, `SELECT status FROM clip_embedding_columns WHERE column_name = $1 FOR UPDATE`, column).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrEmbeddingColumnNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get embedding column: %w", err)
+	}
+	switch status {
+	case models.ClipEmbeddingColumnActive:
+		return nil
+	case models.ClipEmbeddingColumnBackfilling:
+		return ErrEmbeddingColumnNotReady
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE clip_embedding_columns SET status = 'retired', retired_at = NOW()
+		WHERE status = 'active'
+	`); err != nil {
+		return fmt.Errorf("failed to retire embedding column: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE clip_embedding_columns SET status = 'active', activated_at = NOW(), retired_at = NULL
+		WHERE column_name = $1
+	`, column); err != nil {
+		return fmt.Errorf("failed to activate embedding column: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit embedding column activation: %w", err)
+	}
+	return nil
+}
+
+// Drop removes a retired or abandoned column, with its index, and its
+// registry entry. The active column and the original embedding column, which the
+// search index rebuild reads, are kept.
+func (r *ClipEmbeddingColumnRepository) Drop(ctx context.Context, column string) error {
+	c, err := r.Get(ctx, column)
+	if err != nil {
+		return err
+	}
+	if c.Status == models.ClipEmbeddingColumnActive || column == models.DefaultClipEmbeddingColumn {
+		return ErrEmbeddingColumnInUse
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, `DELETE FROM clip_embedding_columns WHERE column_name = $1`, column); err != nil {
+		return fmt.Errorf("failed to unregister embedding column: %w", err)
+	}
+	if _, err := tx.Exec(ctx, fmt.Sprintf(`ALTER TABLE clips DROP COLUMN IF EXISTS %s`, column)); err != nil {
+		return fmt.Errorf("failed to drop embedding column: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit embedding column drop: %w", err)
+	}
+	return nil
+}
+
+// ListMissing returns clips that are not removed and have no embedding in the
+// column, newest first, with the fields embeddings are generated from. Only
+// clips created after since are listed when since is set.
+func (r *ClipEmbeddingColumnRepository) ListMissing(ctx context.Context, column string, since *time.Time, limit, offset int) ([]models.Clip, error) {
+	if err := ValidateEmbeddingColumn(column); err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf(`
+		SELECT id, twitch_clip_id, title, creator_name, broadcaster_name, game_id, game_name
+		FROM clips
+		WHERE is_removed = false
+		  AND %s IS NULL
+		  AND ($1::timestamp IS NULL OR created_at > $1)
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`, column)
+
+	rows, err := r.pool.Query(ctx, query, since, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clips without embeddings: %w", err)
+	}
+	defer rows.Close()
+
+	clips := []models.Clip{}
+	for rows.Next() {
+		var clip models.Clip
+		if err := rows.Scan(&clip.ID, &clip.TwitchClipID, &clip.Title, &clip.CreatorName,
+			&clip.BroadcasterName, &clip.GameID, &clip.GameName); err != nil {
+			return nil, fmt.Errorf("failed to scan clip: %w", err)
+		}
+		clips = append(clips, clip)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate clips: %w", err)
+	}
+	return clips, nil
+}
+
+// SaveEmbedding stores a clip's embedding in the column. The original column
+// also records when and by which model its embedding was generated.
+func (r *ClipEmbeddingColumnRepository) SaveEmbedding(ctx context.Context, column string, clipID uuid.UUID, embedding []float32, model string) error {
+	if err := ValidateEmbeddingColumn(column); err != nil {
+		return err
+	}
+	query := fmt.Sprintf(`UPDATE clips SET %s = $1 WHERE id = $2`, column)
+	args := []interface{}{pgvector.NewVector(embedding), clipID}
+	if column == models.DefaultClipEmbeddingColumn {
+		query = `UPDATE clips SET embedding = $1, embedding_generated_at = $3, embedding_model = $4 WHERE id = $2`
+		args = append(args, time.Now(), model)
+	}
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to save embedding: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateEmbeddingColumn(t *testing.T) {
+	valid := []string{"embedding", "embedding_v2", "embedding_bge_base", "embedding_3_large"}
+	for _, column := range valid {
+		assert.NoError(t, ValidateEmbeddingColumn(column), column)
+	}
+
+	invalid := []string{
+		"",
+		"title",
+		"embedding_",
+		"embedding__v2",
+		"Embedding_v2",
+		"embedding-v2",
+		"embedding_v2; DROP TABLE clips",
+		"embedding_" + strings.Repeat("a", 50),
+	}
+	for _, column := range invalid {
+		assert.ErrorIs(t, ValidateEmbeddingColumn(column), ErrInvalidEmbeddingColumn, column)
+	}
+}
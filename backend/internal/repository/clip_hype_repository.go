@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// ClipHypeRepository handles recorded chat activity and clip hype scores
+type ClipHypeRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewClipHypeRepository creates a new clip hype repository
+func NewClipHypeRepository(db *pgxpool.Pool) *ClipHypeRepository {
+	return &ClipHypeRepository{db: db}
+}
+
+// AddChatActivity adds counted chat messages to their buckets
+func (r *ClipHypeRepository) AddChatActivity(ctx context.Context, buckets []models.ChatActivityBucket) error {
+	if len(buckets) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO chat_activity (broadcaster_id, bucket_start, message_count, emote_count)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (broadcaster_id, bucket_start) DO UPDATE SET
+			message_count = chat_activity.message_count + EXCLUDED.message_count,
+			emote_count = chat_activity.emote_count + EXCLUDED.emote_count
+	`
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, bucket := range buckets {
+		_, err := tx.Exec(ctx, query, bucket.BroadcasterID, bucket.BucketStart, bucket.Messages, bucket.Emotes)
+		if err != nil {
+			return fmt.Errorf("failed to add chat activity: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit chat activity: %w", err)
+	}
+
+	return nil
+}
+
+// ListChatActivity returns a broadcaster's chat buckets starting in [from, to), oldest first
+func (r *ClipHypeRepository) ListChatActivity(ctx context.Context, broadcasterID string, from, to time.Time) ([]models.ChatActivityBucket, error) {
+	query := `
+		SELECT broadcaster_id, bucket_start, message_count, emote_count
+		FROM chat_activity
+		WHERE broadcaster_id = $1 AND bucket_start >= $2 AND bucket_start < $3
+		ORDER BY bucket_start
+	`
+
+	rows, err := r.db.Query(ctx, query, broadcasterID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chat activity: %w", err)
+	}
+	defer rows.Close()
+
+	buckets := []models.ChatActivityBucket{}
+	for rows.Next() {
+		var bucket models.ChatActivityBucket
+		if err := rows.Scan(&bucket.BroadcasterID, &bucket.BucketStart, &bucket.Messages, &bucket.Emotes); err != nil {
+			return nil, fmt.Errorf("failed to scan chat activity: %w", err)
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets, rows.Err()
+}
+
+// DeleteChatActivityBefore deletes chat buckets that started before a time
+func (r *ClipHypeRepository) DeleteChatActivityBefore(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM chat_activity WHERE bucket_start < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune chat activity: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// ListHypeCandidates returns unscored clips created in [createdAfter,
+// createdBefore) whose channel has chat recorded from lookback before the
+// clip was created up to its creation, newest first
+func (r *ClipHypeRepository) ListHypeCandidates(ctx context.Context, createdAfter, createdBefore time.Time, lookback time.Duration, limit int) ([]models.ClipHypeCandidate, error) {
+	query := `
+		SELECT c.id, c.broadcaster_id, c.created_at, c.duration
+		FROM clips c
+		WHERE c.broadcaster_id IS NOT NULL
+			AND c.is_removed = false
+			AND c.created_at >= $1 AND c.created_at < $2
+			AND NOT EXISTS (SELECT 1 FROM clip_hype_scores h WHERE h.clip_id = c.id)
+			AND EXISTS (
+				SELECT 1 FROM chat_activity a
+				WHERE a.broadcaster_id = c.broadcaster_id
+					AND a.bucket_start >= c.created_at - $3 * INTERVAL '1 second'
+					AND a.bucket_start <= c.created_at
+			)
+		ORDER BY c.created_at DESC
+		LIMIT $4
+	`
+
+	rows, err := r.db.Query(ctx, query, createdAfter, createdBefore, lookback.Seconds(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list hype candidates: %w", err)
+	}
+	defer rows.Close()
+
+	candidates := []models.ClipHypeCandidate{}
+	for rows.Next() {
+		var candidate models.ClipHypeCandidate
+		if err := rows.Scan(&candidate.ClipID, &candidate.BroadcasterID, &candidate.CreatedAt, &candidate.Duration); err != nil {
+			return nil, fmt.Errorf("failed to scan hype candidate: %w", err)
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, rows.Err()
+}
+
+// UpsertHypeScore stores a clip's hype score, replacing any earlier one
+func (r *ClipHypeRepository) UpsertHypeScore(ctx context.Context, score *models.ClipHypeScore) error {
+	query := `
+		INSERT INTO clip_hype_scores (clip_id, hype_score, message_count, emote_count, messages_per_minute, baseline_messages_per_minute, scored_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (clip_id) DO UPDATE SET
+			hype_score = EXCLUDED.hype_score,
+			message_count = EXCLUDED.message_count,
+			emote_count = EXCLUDED.emote_count,
+			messages_per_minute = EXCLUDED.messages_per_minute,
+			baseline_messages_per_minute = EXCLUDED.baseline_messages_per_minute,
+			scored_at = EXCLUDED.scored_at
+	`
+
+	_, err := r.db.Exec(ctx, query, score.ClipID, score.HypeScore, score.Messages, score.Emotes,
+		score.MessagesPerMinute, score.BaselineMessagesPerMinute, score.ScoredAt)
+	if err != nil {
+		return fmt.Errorf("failed to store hype score: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// Sentinel errors for clip ingestion rule operations
+var (
+	// ErrClipIngestionRuleNotFound is returned when a rule does not exist
+	ErrClipIngestionRuleNotFound = errors.New("clip ingestion rule not found")
+	// ErrClipIngestionTargetNotFound is returned when a rule's list, feed or community does not exist
+	ErrClipIngestionTargetNotFound = errors.New("clip ingestion rule target not found")
+)
+
+const clipIngestionRuleColumns = `
+	r.id, r.name, r.enabled, r.game_ids, r.broadcaster_ids, r.languages, r.min_views,
+	r.max_views, r.target_type, r.target_id, r.created_by, r.match_count, r.last_matched_at,
+	r.created_at, r.updated_at`
+
+const clipIngestionRuleStatsColumns = `,
+	(SELECT COUNT(*) FROM clip_ingestion_rule_hits h
+	 WHERE h.rule_id = r.id AND h.matched_at > NOW() - INTERVAL '24 hours'),
+	(SELECT COUNT(*) FROM clip_ingestion_rule_hits h
+	 WHERE h.rule_id = r.id AND h.matched_at > NOW() - INTERVAL '7 days')`
+
+// ClipIngestionRuleRepository handles database operations for clip ingestion rules
+type ClipIngestionRuleRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewClipIngestionRuleRepository creates a new ClipIngestionRuleRepository
+func NewClipIngestionRuleRepository(pool *pgxpool.Pool) *ClipIngestionRuleRepository {
+	return &ClipIngestionRuleRepository{pool: pool}
+}
+
+func scanClipIngestionRule(row pgx.Row, withStats bool) (*models.ClipIngestionRule, error) {
+	var rule models.ClipIngestionRule
+	dest := []interface{}{
+		&rule.ID, &rule.Name, &rule.Enabled, &rule.GameIDs, &rule.BroadcasterIDs, &rule.Languages,
+		&rule.MinViews, &rule.MaxViews, &rule.TargetType, &rule.TargetID, &rule.CreatedBy,
+		&rule.Stats.MatchCount, &rule.Stats.LastMatchedAt, &rule.CreatedAt, &rule.UpdatedAt,
+	}
+	if withStats {
+		dest = append(dest, &rule.Stats.MatchesLast24h, &rule.Stats.MatchesLast7d)
+	}
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *ClipIngestionRuleRepository) queryRules(ctx context.Context, query string, withStats bool, args ...interface{}) ([]models.ClipIngestionRule, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clip ingestion rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []models.ClipIngestionRule{}
+	for rows.Next() {
+		rule, err := scanClipIngestionRule(rows, withStats)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan clip ingestion rule: %w", err)
+		}
+		rules = append(rules, *rule)
+	}
+
+	return rules, rows.Err()
+}
+
+// ListRules returns all rules with their hit stats, newest first
+func (r *ClipIngestionRuleRepository) ListRules(ctx context.Context) ([]models.ClipIngestionRule, error) {
+	query := `SELECT ` + clipIngestionRuleColumns + clipIngestionRuleStatsColumns + `
+		FROM clip_ingestion_rules r
+		ORDER BY r.created_at DESC`
+	return r.queryRules(ctx, query, true)
+}
+
+// ListEnabledRules returns the rules evaluated during clip sync
+func (r *ClipIngestionRuleRepository) ListEnabledRules(ctx context.Context) ([]models.ClipIngestionRule, error) {
+	query := `SELECT ` + clipIngestionRuleColumns + `
+		FROM clip_ingestion_rules r
+		WHERE r.enabled = true
+		ORDER BY r.created_at`
+	return r.queryRules(ctx, query, false)
+}
+
+// GetRule returns a rule with its hit stats
+func (r *ClipIngestionRuleRepository) GetRule(ctx context.Context, ruleID uuid.UUID) (*models.ClipIngestionRule, error) {
+	query := `SELECT ` + clipIngestionRuleColumns + clipIngestionRuleStatsColumns + `
+		FROM clip_ingestion_rules r
+		WHERE r.id = $1`
+
+	rule, err := scanClipIngestionRule(r.pool.QueryRow(ctx, query, ruleID), true)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrClipIngestionRuleNotFound
+		}
+		return nil, fmt.Errorf("failed to get clip ingestion rule: %w", err)
+	}
+	return rule, nil
+}
+
+// clipIngestionTargetQuery returns the query checking a rule target exists:
+// a curated discovery list, a custom feed or a community
+func clipIngestionTargetQuery(targetType string) (string, bool) {
+	switch targetType {
+	case models.ClipIngestionTargetDiscoveryList:
+		return `SELECT EXISTS (SELECT 1 FROM playlists WHERE id = $1 AND is_curated = true AND deleted_at IS NULL)`, true
+	case models.ClipIngestionTargetFeed:
+		return `SELECT EXISTS (SELECT 1 FROM feeds WHERE id = $1)`, true
+	case models.ClipIngestionTargetCommunity:
+		return `SELECT EXISTS (SELECT 1 FROM communities WHERE id = $1)`, true
+	default:
+		return "", false
+	}
+}
+
+// TargetExists reports whether a rule target exists
+func (r *ClipIngestionRuleRepository) TargetExists(ctx context.Context, targetType string, targetID uuid.UUID) (bool, error) {
+	query, ok := clipIngestionTargetQuery(targetType)
+	if !ok {
+		return false, nil
+	}
+
+	var exists bool
+	if err := r.pool.QueryRow(ctx, query, targetID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check clip ingestion rule target: %w", err)
+	}
+	return exists, nil
+}
+
+// CreateRule inserts a rule
+func (r *ClipIngestionRuleRepository) CreateRule(ctx context.Context, rule *models.ClipIngestionRule) error {
+	query := `
+		INSERT INTO clip_ingestion_rules (
+			name, enabled, game_ids, broadcaster_ids, languages, min_views, max_views,
+			target_type, target_id, created_by
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.pool.QueryRow(ctx, query,
+		rule.Name, rule.Enabled, rule.GameIDs, rule.BroadcasterIDs, rule.Languages, rule.MinViews,
+		rule.MaxViews, rule.TargetType, rule.TargetID, rule.CreatedBy,
+	).Scan(&rule.ID, &rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create clip ingestion rule: %w", err)
+	}
+	return nil
+}
+
+// UpdateRule replaces a rule's settings. Hit stats are kept.
+func (r *ClipIngestionRuleRepository) UpdateRule(ctx context.Context, rule *models.ClipIngestionRule) error {
+	query := `
+		UPDATE clip_ingestion_rules
+		SET name = $2, enabled = $3, game_ids = $4, broadcaster_ids = $5, languages = $6,
+			min_views = $7, max_views = $8, target_type = $9, target_id = $10, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at
+	`
+
+	err := r.pool.QueryRow(ctx, query,
+		rule.ID, rule.Name, rule.Enabled, rule.GameIDs, rule.BroadcasterIDs, rule.Languages,
+		rule.MinViews, rule.MaxViews, rule.TargetType, rule.TargetID,
+	).Scan(&rule.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrClipIngestionRuleNotFound
+		}
+		return fmt.Errorf("failed to update clip ingestion rule: %w", err)
+	}
+	return nil
+}
+
+// DeleteRule deletes a rule and its hits. Clips it routed stay where they are.
+func (r *ClipIngestionRuleRepository) DeleteRule(ctx context.Context, ruleID uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM clip_ingestion_rules WHERE id = $1`, ruleID)
+	if err != nil {
+		return fmt.Errorf("failed to delete clip ingestion rule: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrClipIngestionRuleNotFound
+	}
+	return nil
+}
+
+// RecordMatch routes a clip into a rule's target and counts the hit, in one
+// transaction. It returns false when the rule already routed the clip, and
+// ErrClipIngestionTargetNotFound when the target no longer exists.
+func (r *ClipIngestionRuleRepository) RecordMatch(ctx context.Context, rule *models.ClipIngestionRule, clipID uuid.UUID) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	result, err := tx.Exec(ctx, `
+		INSERT INTO clip_ingestion_rule_hits (rule_id, clip_id)
+		VALUES ($1, $2)
+		ON CONFLICT (rule_id, clip_id) DO NOTHING
+	`, rule.ID, clipID)
+	if err != nil {
+		return false, fmt.Errorf("failed to record clip ingestion rule hit: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	targetQuery, ok := clipIngestionTargetQuery(rule.TargetType)
+	if !ok {
+		return false, ErrClipIngestionTargetNotFound
+	}
+	var targetExists bool
+	if err := tx.QueryRow(ctx, targetQuery, rule.TargetID).Scan(&targetExists); err != nil {
+		return false, fmt.Errorf("failed to check clip ingestion rule target: %w", err)
+	}
+	if !targetExists {
+		return false, ErrClipIngestionTargetNotFound
+	}
+
+	// Clips already in the target, e.g. added by hand, are left in place
+	switch rule.TargetType {
+	case models.ClipIngestionTargetDiscoveryList:
+		_, err = tx.Exec(ctx, `
+			INSERT INTO playlist_items (playlist_id, clip_id, order_index)
+			SELECT $1, $2::uuid, COALESCE(MAX(order_index), -1) + 1 FROM playlist_items WHERE playlist_id = $1
+			ON CONFLICT (playlist_id, clip_id) DO NOTHING
+		`, rule.TargetID, clipID)
+		if err == nil {
+			_, err = tx.Exec(ctx, `UPDATE playlists SET updated_at = NOW() WHERE id = $1`, rule.TargetID)
+		}
+	case models.ClipIngestionTargetFeed:
+		_, err = tx.Exec(ctx, `
+			INSERT INTO feed_items (feed_id, clip_id, position)
+			SELECT $1, $2::uuid, COALESCE(MAX(position), -1) + 1 FROM feed_items WHERE feed_id = $1
+			ON CONFLICT (feed_id, clip_id) DO NOTHING
+		`, rule.TargetID, clipID)
+	case models.ClipIngestionTargetCommunity:
+		_, err = tx.Exec(ctx, `
+			INSERT INTO community_clips (community_id, clip_id, added_by_user_id)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (community_id, clip_id) DO NOTHING
+		`, rule.TargetID, clipID, rule.CreatedBy)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to route clip: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE clip_ingestion_rules
+		SET match_count = match_count + 1, last_matched_at = NOW()
+		WHERE id = $1
+	`, rule.ID); err != nil {
+		return false, fmt.Errorf("failed to count clip ingestion rule hit: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit clip ingestion rule hit: %w", err)
+	}
+	return true, nil
+}
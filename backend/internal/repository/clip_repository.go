@@ -3,11 +3,14 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/pagination"
 	"github.com/subculture-collective/clipper/internal/utils"
 )
 
@@ -25,6 +28,10 @@ const (
 type ClipRepository struct {
 	pool   *pgxpool.Pool
 	helper *RepositoryHelper
+
+	// trendingHypeWeight scales how much a clip's chat hype score lifts its
+	// trending score; 0 leaves trending unchanged
+	trendingHypeWeight float64
 }
 
 // NewClipRepository creates a new ClipRepository
@@ -37,6 +44,21 @@ func NewClipRepository(pool *pgxpool.Pool) *ClipRepository {
 
 // Create inserts a new clip into the database
 func (r *ClipRepository) Create(ctx context.Context, clip *models.Clip) error {
+	if err := insertClip(ctx, r.pool, clip); err != nil {
+		return fmt.Errorf("failed to create clip: %w", err)
+	}
+
+	return nil
+}
+
+// dbExecer is satisfied by both *pgxpool.Pool and pgx.Tx
+type dbExecer interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+}
+
+// insertClip inserts a clip through the pool or a transaction, so other
+// repositories can create a clip atomically with their own writes
+func insertClip(ctx context.Context, db dbExecer, clip *models.Clip) error {
 	query := `
 		INSERT INTO clips (
 			id, twitch_clip_id, twitch_clip_url, embed_url, title,
@@ -44,28 +66,23 @@ func (r *ClipRepository) Create(ctx context.Context, clip *models.Clip) error {
 			game_id, game_name, language, thumbnail_url, duration,
 			view_count, created_at, imported_at, vote_score, comment_count, favorite_count,
 			is_featured, is_nsfw, is_removed, is_hidden,
-			submitted_by_user_id, submitted_at
+			submitted_by_user_id, submitted_at, display_title
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17,
-			$18, $19, $20, $21, $22, $23, $24, $25, $26
+			$18, $19, $20, $21, $22, $23, $24, $25, $26, $27
 		)
 	`
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err := db.Exec(ctx, query,
 		clip.ID, clip.TwitchClipID, clip.TwitchClipURL, clip.EmbedURL,
 		clip.Title, clip.CreatorName, clip.CreatorID, clip.BroadcasterName,
 		clip.BroadcasterID, clip.GameID, clip.GameName, clip.Language,
 		clip.ThumbnailURL, clip.Duration, clip.ViewCount, clip.CreatedAt,
 		clip.ImportedAt, clip.VoteScore, clip.CommentCount, clip.FavoriteCount,
 		clip.IsFeatured, clip.IsNSFW, clip.IsRemoved, clip.IsHidden,
-		clip.SubmittedByUserID, clip.SubmittedAt,
+		clip.SubmittedByUserID, clip.SubmittedAt, clip.DisplayTitle,
 	)
-
-	if err != nil {
-		return fmt.Errorf("failed to create clip: %w", err)
-	}
-
-	return nil
+	return err
 }
 
 // CreateStreamClip inserts a new clip created from a stream into the database
@@ -298,7 +315,9 @@ func (r *ClipRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Cli
 			view_count, created_at, imported_at, vote_score, comment_count,
 			favorite_count, is_featured, is_nsfw, is_removed, removed_reason, is_hidden,
 			submitted_by_user_id, submitted_at,
-			stream_source, status, video_url, processed_at, quality, start_time, end_time
+			stream_source, status, video_url, processed_at, quality, start_time, end_time,
+			display_title, game_patch_id,
+			(SELECT gp.version FROM game_patches gp WHERE gp.id = clips.game_patch_id)
 		FROM clips
 		WHERE id = $1 AND is_removed = false
 	`
@@ -313,6 +332,7 @@ func (r *ClipRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Cli
 		&clip.IsFeatured, &clip.IsNSFW, &clip.IsRemoved, &clip.RemovedReason, &clip.IsHidden,
 		&clip.SubmittedByUserID, &clip.SubmittedAt,
 		&clip.StreamSource, &clip.Status, &clip.VideoURL, &clip.ProcessedAt, &clip.Quality, &clip.StartTime, &clip.EndTime,
+		&clip.DisplayTitle, &clip.GamePatchID, &clip.GamePatch,
 	)
 
 	if err != nil {
@@ -423,17 +443,23 @@ type ClipFilters struct {
 	Tag               *string
 	ExcludeTags       []string // Exclude clips with any of these tag slugs
 	Search            *string
-	Language          *string // Language code (e.g., en, es, fr)
-	Timeframe         *string // hour, day, week, month, year, all
-	DateFrom          *string // ISO 8601 date string for custom date range start
-	DateTo            *string // ISO 8601 date string for custom date range end
-	Sort              string  // hot, new, top, rising, discussed, trending
-	Top10kStreamers   bool    // Filter clips to only top 10k streamers
-	ShowHidden        bool    // If true, include hidden clips (for owners/admins)
-	CreatorID         *string // Filter by creator ID (for creator dashboard)
-	SubmittedByUserID *string // Filter by submitted_by_user_id (for user profile submissions)
-	UserSubmittedOnly bool    // If true, only show clips with submitted_by_user_id IS NOT NULL
-	Cursor            *string // Cursor for cursor-based pagination (base64 encoded)
+	Language          *string            // Language code (e.g., en, es, fr)
+	Timeframe         *string            // hour, day, week, month, year, all
+	DateFrom          *string            // ISO 8601 date string for custom date range start
+	DateTo            *string            // ISO 8601 date string for custom date range end
+	Sort              string             // hot, new, top, rising, discussed, trending
+	Top10kStreamers   bool               // Filter clips to only top 10k streamers
+	ShowHidden        bool               // If true, include hidden clips (for owners/admins)
+	CreatorID         *string            // Filter by creator ID (for creator dashboard)
+	SubmittedByUserID *string            // Filter by submitted_by_user_id (for user profile submissions)
+	UserSubmittedOnly bool               // If true, only show clips with submitted_by_user_id IS NOT NULL
+	ViewerID          *uuid.UUID         // Shadow-banned submitters still see their own clips
+	Cursor            *pagination.Cursor // Continue after this position (cursor pagination)
+	PatchID           *string            // Only clips tagged with this game patch
+	CurrentPatch      bool               // Only clips from the patch each game is on now
+	// Accessibility filters
+	CaptionedOnly      bool // Only clips with captions
+	HidePhotosensitive bool // Exclude clips with a photosensitivity warning
 }
 
 // buildDateFilterClauses adds date range and timeframe filtering clauses
@@ -497,12 +523,150 @@ func buildDateFilterClauses(filters ClipFilters, whereClauses []string, args []i
 	return whereClauses, args, argIndex
 }
 
-// ListWithFilters retrieves clips with filters, sorting, and pagination
+// clipKeyset returns the sort order of a clip listing as a keyset, so each
+// sort can be paginated with cursors as well as offsets
+func clipKeyset(sort string) pagination.Keyset {
+	k := pagination.Keyset{Sort: sort, Time: "c.created_at", ID: "c.id"}
+	switch sort {
+	case "new":
+		k.Time = "COALESCE(c.submitted_at, c.created_at)"
+	case "top":
+		k.Scores = []string{"c.vote_score"}
+	case "trending":
+		// Trending: uses pre-calculated trending_score (engagement/age) with fallback to real-time calculation
+		k.Scores = []string{"COALESCE(c.trending_score, calculate_trending_score(c.view_count, c.vote_score, c.comment_count, c.favorite_count, c.created_at))"}
+	case "popular":
+		// Popular: uses pre-calculated popularity_index (total engagement) with fallback
+		k.Scores = []string{"COALESCE(c.popularity_index, c.engagement_count, (c.view_count + c.vote_score * 2 + c.comment_count * 3 + c.favorite_count * 2))"}
+	case "rising":
+		// Rising: recent clips with high velocity (view_count + vote_score combined with recency).
+		// The score decays with NOW(), so pages requested far apart may overlap slightly.
+		k.Scores = []string{"((c.vote_score + (c.view_count / 100)) * (1 + 1.0 / (EXTRACT(EPOCH FROM (NOW() - c.created_at)) / 3600.0 + 2)))::float8"}
+	case "discussed":
+		// Discussed: clips with most comments, breaking ties by creation date
+		k.Scores = []string{"c.comment_count"}
+	default:
+		k.Scores = []string{"calculate_hot_score(c.vote_score, c.created_at)"}
+	}
+	return k
+}
+
+// ListWithFilters retrieves clips with filters, sorting, and offset pagination
 func (r *ClipRepository) ListWithFilters(ctx context.Context, filters ClipFilters, limit, offset int) ([]models.Clip, int, error) {
 	// Enforce pagination limits
 	r.helper.EnforcePaginationLimits(&limit, &offset)
 
-	// Build WHERE clause
+	clips, _, total, err := r.listWithFilters(ctx, filters, limit, offset, true)
+	return clips, total, err
+}
+
+// ListWithFiltersCursor retrieves a page of clips with filters and sorting,
+// continuing after filters.Cursor when set. Unlike ListWithFilters it skips
+// the total count, so deep pages cost the same as the first.
+func (r *ClipRepository) ListWithFiltersCursor(ctx context.Context, filters ClipFilters, limit int) ([]models.Clip, pagination.PageInfo, error) {
+	offset := 0
+	r.helper.EnforcePaginationLimits(&limit, &offset)
+
+	// Fetch one extra row to tell whether another page follows
+	clips, keys, _, err := r.listWithFilters(ctx, filters, limit+1, 0, false)
+	if err != nil {
+		return nil, pagination.PageInfo{}, err
+	}
+	clips, page := pagination.Trim(clips, keys, limit)
+	return clips, page, nil
+}
+
+// listWithFilters runs a filtered clip listing, returning the clips, the
+// cursor of each clip and, when count is set, the total matching clips
+func (r *ClipRepository) listWithFilters(ctx context.Context, filters ClipFilters, limit, offset int, count bool) ([]models.Clip, []pagination.Cursor, int, error) {
+	whereClauses, args, argIndex := buildClipFilterClauses(filters)
+	keyset := clipKeyset(filters.Sort)
+
+	// Continue after the cursor when provided
+	if filters.Cursor != nil {
+		cursorClause, cursorArgs, err := keyset.After(filters.Cursor, argIndex)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		whereClauses = append(whereClauses, cursorClause)
+		args = append(args, cursorArgs...)
+		argIndex += len(cursorArgs)
+	}
+
+	whereClause := "WHERE " + strings.Join(whereClauses, " AND ")
+
+	var total int
+	if count {
+		countQuery := fmt.Sprintf("SELECT COUNT(*) FROM clips c %s", whereClause)
+		if err := r.pool.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+			return nil, nil, 0, fmt.Errorf("failed to count clips: %w", err)
+		}
+	}
+
+	// Main query
+	args = append(args, limit, offset)
+	query := fmt.Sprintf(`
+		SELECT
+			c.id, c.twitch_clip_id, c.twitch_clip_url, c.embed_url, c.title,
+			c.creator_name, c.creator_id, c.broadcaster_name, c.broadcaster_id,
+			c.game_id, c.game_name, c.language, c.thumbnail_url, c.duration,
+			c.view_count, c.created_at, c.imported_at, c.vote_score, c.comment_count,
+			c.favorite_count, c.is_featured, c.is_nsfw, c.is_removed, c.removed_reason, c.is_hidden,
+			c.submitted_by_user_id, c.submitted_at,
+			c.trending_score, c.hot_score, c.popularity_index, c.engagement_count,
+			c.game_patch_id, (SELECT gp.version FROM game_patches gp WHERE gp.id = c.game_patch_id),
+			%s
+		FROM clips c
+		%s
+		ORDER BY %s
+		LIMIT %s OFFSET %s
+	`, keyset.Columns(), whereClause, keyset.OrderBy(), utils.SQLPlaceholder(argIndex), utils.SQLPlaceholder(argIndex+1))
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to list clips: %w", err)
+	}
+	defer rows.Close()
+
+	var clips []models.Clip
+	var keys []pagination.Cursor
+	for rows.Next() {
+		var clip models.Clip
+		var key pagination.Cursor
+		dest := []interface{}{
+			&clip.ID, &clip.TwitchClipID, &clip.TwitchClipURL, &clip.EmbedURL,
+			&clip.Title, &clip.CreatorName, &clip.CreatorID, &clip.BroadcasterName,
+			&clip.BroadcasterID, &clip.GameID, &clip.GameName, &clip.Language,
+			&clip.ThumbnailURL, &clip.Duration, &clip.ViewCount, &clip.CreatedAt,
+			&clip.ImportedAt, &clip.VoteScore, &clip.CommentCount, &clip.FavoriteCount,
+			&clip.IsFeatured, &clip.IsNSFW, &clip.IsRemoved, &clip.RemovedReason, &clip.IsHidden,
+			&clip.SubmittedByUserID, &clip.SubmittedAt,
+			&clip.TrendingScore, &clip.HotScore, &clip.PopularityIndex, &clip.EngagementCount,
+			&clip.GamePatchID, &clip.GamePatch,
+		}
+		if err := rows.Scan(append(dest, keyset.Dest(&key)...)...); err != nil {
+			return nil, nil, 0, fmt.Errorf("failed to scan clip: %w", err)
+		}
+		clips = append(clips, clip)
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, 0, fmt.Errorf("error iterating clips: %w", err)
+	}
+
+	return clips, keys, total, nil
+}
+
+// clipNotShadowBanned excludes clips submitted by shadow-banned users from
+// public listings and search. The clips table must be aliased c.
+const clipNotShadowBanned = `NOT EXISTS (
+	SELECT 1 FROM users su WHERE su.id = c.submitted_by_user_id AND su.is_shadow_banned
+)`
+
+// buildClipFilterClauses builds the WHERE clauses and arguments of a filtered
+// clip listing, returning the next placeholder index
+func buildClipFilterClauses(filters ClipFilters) ([]string, []interface{}, int) {
 	whereClauses := []string{"c.is_removed = false"}
 
 	// Filter hidden clips unless ShowHidden is true
@@ -518,6 +682,15 @@ func (r *ClipRepository) ListWithFilters(ctx context.Context, filters ClipFilter
 	args := []interface{}{}
 	argIndex := 1
 
+	// Clips submitted by shadow-banned users are listed only for the submitter
+	shadowBanClause := clipNotShadowBanned
+	if filters.ViewerID != nil {
+		shadowBanClause = fmt.Sprintf("(%s OR c.submitted_by_user_id = %s)", shadowBanClause, utils.SQLPlaceholder(argIndex))
+		args = append(args, *filters.ViewerID)
+		argIndex++
+	}
+	whereClauses = append(whereClauses, shadowBanClause)
+
 	if filters.GameID != nil {
 		whereClauses = append(whereClauses, fmt.Sprintf("c.game_id = %s", utils.SQLPlaceholder(argIndex)))
 		args = append(args, *filters.GameID)
@@ -584,161 +757,46 @@ func (r *ClipRepository) ListWithFilters(ctx context.Context, filters ClipFilter
 		)`)
 	}
 
-	// Add date range and timeframe filtering
-	whereClauses, args, argIndex = buildDateFilterClauses(filters, whereClauses, args, argIndex)
-
-	// Add cursor-based filtering if cursor is provided
-	if filters.Cursor != nil && *filters.Cursor != "" {
-		cursor, err := utils.DecodeCursor(*filters.Cursor)
-		if err != nil {
-			return nil, 0, fmt.Errorf("invalid cursor: %w", err)
-		}
-
-		// Validate that cursor sort key matches requested sort
-		if cursor.SortKey != filters.Sort {
-			return nil, 0, fmt.Errorf("cursor sort key %q does not match requested sort %q", cursor.SortKey, filters.Sort)
-		}
-
-		// Add cursor WHERE clause based on sort type
-		// For DESC sorts: WHERE (sort_field < cursor_value) OR (sort_field = cursor_value AND id < cursor_id)
-		// This ensures stable pagination even with duplicate sort values
-		cursorTimestamp := time.Unix(cursor.CreatedAt, 0)
-
-		switch filters.Sort {
-		case "trending":
-			whereClauses = append(whereClauses, fmt.Sprintf(
-				"(COALESCE(c.trending_score, calculate_trending_score(c.view_count, c.vote_score, c.comment_count, c.favorite_count, c.created_at)) < %s OR (COALESCE(c.trending_score, calculate_trending_score(c.view_count, c.vote_score, c.comment_count, c.favorite_count, c.created_at)) = %s AND (c.created_at < %s OR (c.created_at = %s AND c.id < %s))))",
-				utils.SQLPlaceholder(argIndex), utils.SQLPlaceholder(argIndex+1), utils.SQLPlaceholder(argIndex+2), utils.SQLPlaceholder(argIndex+3), utils.SQLPlaceholder(argIndex+4)))
-			args = append(args, cursor.SortValue, cursor.SortValue, cursorTimestamp, cursorTimestamp, cursor.ClipID)
-			argIndex += 5
-		case "popular":
-			whereClauses = append(whereClauses, fmt.Sprintf(
-				"(COALESCE(c.popularity_index, c.engagement_count, (c.view_count + c.vote_score * 2 + c.comment_count * 3 + c.favorite_count * 2)) < %s OR (COALESCE(c.popularity_index, c.engagement_count, (c.view_count + c.vote_score * 2 + c.comment_count * 3 + c.favorite_count * 2)) = %s AND (c.created_at < %s OR (c.created_at = %s AND c.id < %s))))",
-				utils.SQLPlaceholder(argIndex), utils.SQLPlaceholder(argIndex+1), utils.SQLPlaceholder(argIndex+2), utils.SQLPlaceholder(argIndex+3), utils.SQLPlaceholder(argIndex+4)))
-			args = append(args, cursor.SortValue, cursor.SortValue, cursorTimestamp, cursorTimestamp, cursor.ClipID)
-			argIndex += 5
-		case "new":
-			whereClauses = append(whereClauses, fmt.Sprintf(
-				"(c.created_at < %s OR (c.created_at = %s AND c.id < %s))",
-				utils.SQLPlaceholder(argIndex), utils.SQLPlaceholder(argIndex+1), utils.SQLPlaceholder(argIndex+2)))
-			args = append(args, cursorTimestamp, cursorTimestamp, cursor.ClipID)
-			argIndex += 3
-		case "top":
-			whereClauses = append(whereClauses, fmt.Sprintf(
-				"(c.vote_score < %s OR (c.vote_score = %s AND (c.created_at < %s OR (c.created_at = %s AND c.id < %s))))",
-				utils.SQLPlaceholder(argIndex), utils.SQLPlaceholder(argIndex+1), utils.SQLPlaceholder(argIndex+2), utils.SQLPlaceholder(argIndex+3), utils.SQLPlaceholder(argIndex+4)))
-			args = append(args, cursor.SortValue, cursor.SortValue, cursorTimestamp, cursorTimestamp, cursor.ClipID)
-			argIndex += 5
-		case "discussed":
-			whereClauses = append(whereClauses, fmt.Sprintf(
-				"(c.comment_count < %s OR (c.comment_count = %s AND (c.created_at < %s OR (c.created_at = %s AND c.id < %s))))",
-				utils.SQLPlaceholder(argIndex), utils.SQLPlaceholder(argIndex+1), utils.SQLPlaceholder(argIndex+2), utils.SQLPlaceholder(argIndex+3), utils.SQLPlaceholder(argIndex+4)))
-			args = append(args, cursor.SortValue, cursor.SortValue, cursorTimestamp, cursorTimestamp, cursor.ClipID)
-			argIndex += 5
-		case "hot", "rising":
-			// For hot and rising, we use created_at as the cursor since the score is dynamically calculated
-			// Note: This means pagination is based on created_at, not the hot/rising score
-			// This is a known limitation but avoids storing calculated scores
-			whereClauses = append(whereClauses, fmt.Sprintf(
-				"(c.created_at < %s OR (c.created_at = %s AND c.id < %s))",
-				utils.SQLPlaceholder(argIndex), utils.SQLPlaceholder(argIndex+1), utils.SQLPlaceholder(argIndex+2)))
-			args = append(args, cursorTimestamp, cursorTimestamp, cursor.ClipID)
-			argIndex += 3
-		default:
-			// Default to hot score behavior
-			whereClauses = append(whereClauses, fmt.Sprintf(
-				"(c.created_at < %s OR (c.created_at = %s AND c.id < %s))",
-				utils.SQLPlaceholder(argIndex), utils.SQLPlaceholder(argIndex+1), utils.SQLPlaceholder(argIndex+2)))
-			args = append(args, cursorTimestamp, cursorTimestamp, cursor.ClipID)
-			argIndex += 3
-		}
-	}
-
-	whereClause := "WHERE " + whereClauses[0]
-	for i := 1; i < len(whereClauses); i++ {
-		whereClause += " AND " + whereClauses[i]
-	}
-
-	// Build ORDER BY clause
-	var orderBy string
-	switch filters.Sort {
-	case "hot":
-		orderBy = "ORDER BY calculate_hot_score(c.vote_score, c.created_at) DESC, c.created_at DESC, c.id DESC"
-	case "new":
-		orderBy = "ORDER BY COALESCE(c.submitted_at, c.created_at) DESC, c.id DESC"
-	case "top":
-		orderBy = "ORDER BY c.vote_score DESC, c.created_at DESC, c.id DESC"
-	case "trending":
-		// Trending: uses pre-calculated trending_score (engagement/age) with fallback to real-time calculation
-		orderBy = "ORDER BY COALESCE(c.trending_score, calculate_trending_score(c.view_count, c.vote_score, c.comment_count, c.favorite_count, c.created_at)) DESC, c.created_at DESC, c.id DESC"
-	case "popular":
-		// Popular: uses pre-calculated popularity_index (total engagement) with fallback
-		orderBy = "ORDER BY COALESCE(c.popularity_index, c.engagement_count, (c.view_count + c.vote_score * 2 + c.comment_count * 3 + c.favorite_count * 2)) DESC, c.created_at DESC, c.id DESC"
-	case "rising":
-		// Rising: recent clips with high velocity (view_count + vote_score combined with recency)
-		orderBy = "ORDER BY (c.vote_score + (c.view_count / 100)) * (1 + 1.0 / (EXTRACT(EPOCH FROM (NOW() - c.created_at)) / 3600.0 + 2)) DESC, c.created_at DESC, c.id DESC"
-	case "discussed":
-		// Discussed: clips with most comments, breaking ties by creation date
-		orderBy = "ORDER BY c.comment_count DESC, c.created_at DESC, c.id DESC"
-	default:
-		orderBy = "ORDER BY calculate_hot_score(c.vote_score, c.created_at) DESC, c.created_at DESC, c.id DESC"
+	if filters.PatchID != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("c.game_patch_id = %s", utils.SQLPlaceholder(argIndex)))
+		args = append(args, *filters.PatchID)
+		argIndex++
 	}
 
-	// Count query
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM clips c %s", whereClause)
-	var total int
-	err := r.pool.QueryRow(ctx, countQuery, args...).Scan(&total)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count clips: %w", err)
+	// Clips of games without patches have none, so they are left out
+	if filters.CurrentPatch {
+		whereClauses = append(whereClauses, `c.game_patch_id IN (
+			SELECT DISTINCT ON (gp.game_id) gp.id
+			FROM game_patches gp
+			WHERE gp.released_at <= NOW()
+			ORDER BY gp.game_id, gp.released_at DESC
+		)`)
 	}
 
-	// Main query
-	args = append(args, limit, offset)
-	query := fmt.Sprintf(`
-		SELECT
-			c.id, c.twitch_clip_id, c.twitch_clip_url, c.embed_url, c.title,
-			c.creator_name, c.creator_id, c.broadcaster_name, c.broadcaster_id,
-			c.game_id, c.game_name, c.language, c.thumbnail_url, c.duration,
-			c.view_count, c.created_at, c.imported_at, c.vote_score, c.comment_count,
-			c.favorite_count, c.is_featured, c.is_nsfw, c.is_removed, c.removed_reason, c.is_hidden,
-			c.submitted_by_user_id, c.submitted_at,
-			c.trending_score, c.hot_score, c.popularity_index, c.engagement_count
-		FROM clips c
-		%s
-		%s
-		LIMIT %s OFFSET %s
-	`, whereClause, orderBy, utils.SQLPlaceholder(argIndex), utils.SQLPlaceholder(argIndex+1))
+	whereClauses = append(whereClauses, buildAccessibilityFilterClauses(filters.CaptionedOnly, filters.HidePhotosensitive)...)
 
-	rows, err := r.pool.Query(ctx, query, args...)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to list clips: %w", err)
-	}
-	defer rows.Close()
+	// Add date range and timeframe filtering
+	return buildDateFilterClauses(filters, whereClauses, args, argIndex)
+}
 
-	var clips []models.Clip
-	for rows.Next() {
-		var clip models.Clip
-		err := rows.Scan(
-			&clip.ID, &clip.TwitchClipID, &clip.TwitchClipURL, &clip.EmbedURL,
-			&clip.Title, &clip.CreatorName, &clip.CreatorID, &clip.BroadcasterName,
-			&clip.BroadcasterID, &clip.GameID, &clip.GameName, &clip.Language,
-			&clip.ThumbnailURL, &clip.Duration, &clip.ViewCount, &clip.CreatedAt,
-			&clip.ImportedAt, &clip.VoteScore, &clip.CommentCount, &clip.FavoriteCount,
-			&clip.IsFeatured, &clip.IsNSFW, &clip.IsRemoved, &clip.RemovedReason, &clip.IsHidden,
-			&clip.SubmittedByUserID, &clip.SubmittedAt,
-			&clip.TrendingScore, &clip.HotScore, &clip.PopularityIndex, &clip.EngagementCount,
-		)
-		if err != nil {
-			return nil, 0, fmt.Errorf("failed to scan clip: %w", err)
-		}
-		clips = append(clips, clip)
+// buildAccessibilityFilterClauses returns the WHERE clauses for the
+// accessibility filters shared by clip listings and search. Clips without
+// accessibility metadata have no captions and no photosensitivity warning.
+func buildAccessibilityFilterClauses(captionedOnly, hidePhotosensitive bool) []string {
+	var clauses []string
+	if captionedOnly {
+		clauses = append(clauses, `EXISTS (
+			SELECT 1 FROM clip_accessibility ca
+			WHERE ca.clip_id = c.id AND ca.has_captions = true
+		)`)
 	}
-
-	if err := rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("error iterating clips: %w", err)
+	if hidePhotosensitive {
+		clauses = append(clauses, `NOT EXISTS (
+			SELECT 1 FROM clip_accessibility ca
+			WHERE ca.clip_id = c.id AND ca.photosensitivity_warning = true
+		)`)
 	}
-
-	return clips, total, nil
+	return clauses
 }
 
 // ListScrapedClipsWithFilters retrieves only scraped clips (submitted_by_user_id IS NULL) with filters, sorting, and pagination
@@ -1180,7 +1238,7 @@ func (r *ClipRepository) GetByIDs(ctx context.Context, clipIDs []uuid.UUID) ([]m
 			creator_name, creator_id, broadcaster_name, broadcaster_id,
 			game_id, game_name, language, thumbnail_url, duration,
 			view_count, created_at, imported_at, vote_score, comment_count,
-			favorite_count, is_featured, is_nsfw, is_removed, removed_reason,
+			favorite_count, is_featured, is_nsfw, is_removed, removed_reason, is_hidden,
 			submitted_by_user_id, submitted_at
 		FROM clips
 		WHERE id = ANY($1)
@@ -1202,7 +1260,7 @@ func (r *ClipRepository) GetByIDs(ctx context.Context, clipIDs []uuid.UUID) ([]m
 			&clip.BroadcasterID, &clip.GameID, &clip.GameName, &clip.Language,
 			&clip.ThumbnailURL, &clip.Duration, &clip.ViewCount, &clip.CreatedAt,
 			&clip.ImportedAt, &clip.VoteScore, &clip.CommentCount, &clip.FavoriteCount,
-			&clip.IsFeatured, &clip.IsNSFW, &clip.IsRemoved, &clip.RemovedReason,
+			&clip.IsFeatured, &clip.IsNSFW, &clip.IsRemoved, &clip.RemovedReason, &clip.IsHidden,
 			&clip.SubmittedByUserID, &clip.SubmittedAt,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan clip: %w", err)
@@ -1265,8 +1323,8 @@ func (r *ClipRepository) ListClipsByBroadcaster(ctx context.Context, broadcaster
 	// Get total count
 	countQuery := `
 		SELECT COUNT(*)
-		FROM clips
-		WHERE broadcaster_id = $1 AND is_removed = false
+		FROM clips c
+		WHERE ` + clipCreditsBroadcaster + ` AND c.is_removed = false AND ` + clipNotShadowBanned + `
 	`
 	var total int
 	if err := r.pool.QueryRow(ctx, countQuery, broadcasterID).Scan(&total); err != nil {
@@ -1291,9 +1349,9 @@ func (r *ClipRepository) ListClipsByBroadcaster(ctx context.Context, broadcaster
 			game_id, game_name, language, thumbnail_url, duration,
 			view_count, created_at, imported_at, vote_score, comment_count,
 			favorite_count, is_featured, is_nsfw, is_removed, removed_reason, is_hidden,
-			submitted_by_user_id, submitted_at
-		FROM clips
-		WHERE broadcaster_id = $1 AND is_removed = false
+			submitted_by_user_id, submitted_at, display_title
+		FROM clips c
+		WHERE `+clipCreditsBroadcaster+` AND is_removed = false AND `+clipNotShadowBanned+`
 		ORDER BY %s
 		LIMIT $2 OFFSET $3
 	`, orderBy)
@@ -1314,7 +1372,7 @@ func (r *ClipRepository) ListClipsByBroadcaster(ctx context.Context, broadcaster
 			&clip.ThumbnailURL, &clip.Duration, &clip.ViewCount, &clip.CreatedAt,
 			&clip.ImportedAt, &clip.VoteScore, &clip.CommentCount, &clip.FavoriteCount,
 			&clip.IsFeatured, &clip.IsNSFW, &clip.IsRemoved, &clip.RemovedReason, &clip.IsHidden,
-			&clip.SubmittedByUserID, &clip.SubmittedAt,
+			&clip.SubmittedByUserID, &clip.SubmittedAt, &clip.DisplayTitle,
 		); err != nil {
 			return nil, 0, fmt.Errorf("failed to scan clip: %w", err)
 		}
@@ -1371,7 +1429,8 @@ WHERE id = $1
 	return nil
 }
 
-// GetFollowingFeedClips retrieves clips from users and broadcasters that the user follows
+// GetFollowingFeedClips retrieves clips from users and broadcasters that the user
+// follows, including clips followed broadcasters are featured in
 func (r *ClipRepository) GetFollowingFeedClips(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.ClipWithSubmitter, int, error) {
 	query := `
 WITH followed_users AS (
@@ -1400,6 +1459,10 @@ AND c.is_hidden = false
 AND (
 c.submitted_by_user_id IN (SELECT following_id FROM followed_users)
 OR c.broadcaster_id IN (SELECT broadcaster_id FROM followed_broadcasters)
+OR EXISTS (
+SELECT 1 FROM clip_featured_broadcasters cfb
+WHERE cfb.clip_id = c.id AND cfb.broadcaster_id IN (SELECT broadcaster_id FROM followed_broadcasters)
+)
 )
 AND c.submitted_by_user_id NOT IN (SELECT blocked_user_id FROM blocked_users)
 ORDER BY c.created_at DESC
@@ -1466,6 +1529,10 @@ AND c.is_hidden = false
 AND (
 c.submitted_by_user_id IN (SELECT following_id FROM followed_users)
 OR c.broadcaster_id IN (SELECT broadcaster_id FROM followed_broadcasters)
+OR EXISTS (
+SELECT 1 FROM clip_featured_broadcasters cfb
+WHERE cfb.clip_id = c.id AND cfb.broadcaster_id IN (SELECT broadcaster_id FROM followed_broadcasters)
+)
 )
 AND c.submitted_by_user_id NOT IN (SELECT blocked_user_id FROM blocked_users)
 `
@@ -1479,6 +1546,18 @@ AND c.submitted_by_user_id NOT IN (SELECT blocked_user_id FROM blocked_users)
 	return clips, total, nil
 }
 
+// SetTrendingHypeWeight sets how much chat hype lifts trending scores: a clip
+// with hype score h trends at (1 + weight*h) times its engagement score
+func (r *ClipRepository) SetTrendingHypeWeight(weight float64) {
+	r.trendingHypeWeight = weight
+}
+
+// trendingHypeFactor multiplies calculate_trending_score by the clip's chat
+// hype, reading the weight from the given parameter
+func trendingHypeFactor(param int) string {
+	return fmt.Sprintf("(1 + $%d * COALESCE((SELECT h.hype_score FROM clip_hype_scores h WHERE h.clip_id = clips.id), 0))", param)
+}
+
 // UpdateTrendingScores updates trending_score, hot_score, popularity_index, and engagement_count for all clips
 // This should be called periodically (e.g., hourly) by a scheduler job
 func (r *ClipRepository) UpdateTrendingScores(ctx context.Context) (int64, error) {
@@ -1486,13 +1565,13 @@ func (r *ClipRepository) UpdateTrendingScores(ctx context.Context) (int64, error
 UPDATE clips
 SET
 engagement_count = view_count + (vote_score * 2) + (comment_count * 3) + (favorite_count * 2),
-trending_score = calculate_trending_score(view_count, vote_score, comment_count, favorite_count, created_at),
+trending_score = calculate_trending_score(view_count, vote_score, comment_count, favorite_count, created_at) * ` + trendingHypeFactor(1) + `,
 hot_score = trending_score,
 popularity_index = view_count + (vote_score * 2) + (comment_count * 3) + (favorite_count * 2)
 WHERE is_removed = false AND is_hidden = false
 `
 
-	result, err := r.pool.Exec(ctx, query)
+	result, err := r.pool.Exec(ctx, query, r.trendingHypeWeight)
 	if err != nil {
 		return 0, fmt.Errorf("failed to update trending scores: %w", err)
 	}
@@ -1507,7 +1586,7 @@ func (r *ClipRepository) UpdateTrendingScoresForTimeWindow(ctx context.Context,
 UPDATE clips
 SET
 engagement_count = view_count + (vote_score * 2) + (comment_count * 3) + (favorite_count * 2),
-trending_score = calculate_trending_score(view_count, vote_score, comment_count, favorite_count, created_at),
+trending_score = calculate_trending_score(view_count, vote_score, comment_count, favorite_count, created_at) * ` + trendingHypeFactor(2) + `,
 hot_score = trending_score,
 popularity_index = view_count + (vote_score * 2) + (comment_count * 3) + (favorite_count * 2)
 WHERE is_removed = false
@@ -1515,7 +1594,7 @@ AND is_hidden = false
 AND created_at > NOW() - INTERVAL '1 hour' * $1
 `
 
-	result, err := r.pool.Exec(ctx, query, hours)
+	result, err := r.pool.Exec(ctx, query, hours, r.trendingHypeWeight)
 	if err != nil {
 		return 0, fmt.Errorf("failed to update trending scores for time window: %w", err)
 	}
@@ -1606,8 +1685,8 @@ func (r *ClipRepository) ListForSitemapBroadcasters(ctx context.Context) ([]mode
 func (r *ClipRepository) ListClipsForBestOf(ctx context.Context, startDate, endDate time.Time, limit, offset int) ([]models.Clip, int, error) {
 	countQuery := `
 		SELECT COUNT(*)
-		FROM clips
-		WHERE is_removed = false AND created_at >= $1 AND created_at < $2
+		FROM clips c
+		WHERE is_removed = false AND created_at >= $1 AND created_at < $2 AND ` + clipNotShadowBanned + `
 	`
 	var total int
 	if err := r.pool.QueryRow(ctx, countQuery, startDate, endDate).Scan(&total); err != nil {
@@ -1621,9 +1700,9 @@ func (r *ClipRepository) ListClipsForBestOf(ctx context.Context, startDate, endD
 			game_id, game_name, language, thumbnail_url, duration,
 			view_count, created_at, imported_at, vote_score, comment_count,
 			favorite_count, is_featured, is_nsfw, is_removed, removed_reason, is_hidden,
-			submitted_by_user_id, submitted_at
-		FROM clips
-		WHERE is_removed = false AND created_at >= $1 AND created_at < $2
+			submitted_by_user_id, submitted_at, display_title
+		FROM clips c
+		WHERE is_removed = false AND created_at >= $1 AND created_at < $2 AND ` + clipNotShadowBanned + `
 		ORDER BY vote_score DESC, view_count DESC
 		LIMIT $3 OFFSET $4
 	`
@@ -1643,7 +1722,7 @@ func (r *ClipRepository) ListClipsForBestOf(ctx context.Context, startDate, endD
 			&clip.ThumbnailURL, &clip.Duration, &clip.ViewCount, &clip.CreatedAt,
 			&clip.ImportedAt, &clip.VoteScore, &clip.CommentCount, &clip.FavoriteCount,
 			&clip.IsFeatured, &clip.IsNSFW, &clip.IsRemoved, &clip.RemovedReason, &clip.IsHidden,
-			&clip.SubmittedByUserID, &clip.SubmittedAt,
+			&clip.SubmittedByUserID, &clip.SubmittedAt, &clip.DisplayTitle,
 		); err != nil {
 			return nil, 0, fmt.Errorf("failed to scan clip: %w", err)
 		}
@@ -1659,8 +1738,8 @@ func (r *ClipRepository) ListClipsForBestOf(ctx context.Context, startDate, endD
 func (r *ClipRepository) ListClipsByGame(ctx context.Context, gameID string, limit, offset int) ([]models.Clip, int, error) {
 	countQuery := `
 		SELECT COUNT(*)
-		FROM clips
-		WHERE game_id = $1 AND is_removed = false
+		FROM clips c
+		WHERE game_id = $1 AND is_removed = false AND ` + clipNotShadowBanned + `
 	`
 	var total int
 	if err := r.pool.QueryRow(ctx, countQuery, gameID).Scan(&total); err != nil {
@@ -1674,9 +1753,9 @@ func (r *ClipRepository) ListClipsByGame(ctx context.Context, gameID string, lim
 			game_id, game_name, language, thumbnail_url, duration,
 			view_count, created_at, imported_at, vote_score, comment_count,
 			favorite_count, is_featured, is_nsfw, is_removed, removed_reason, is_hidden,
-			submitted_by_user_id, submitted_at
-		FROM clips
-		WHERE game_id = $1 AND is_removed = false
+			submitted_by_user_id, submitted_at, display_title
+		FROM clips c
+		WHERE game_id = $1 AND is_removed = false AND ` + clipNotShadowBanned + `
 		ORDER BY vote_score DESC, view_count DESC
 		LIMIT $2 OFFSET $3
 	`
@@ -1696,7 +1775,7 @@ func (r *ClipRepository) ListClipsByGame(ctx context.Context, gameID string, lim
 			&clip.ThumbnailURL, &clip.Duration, &clip.ViewCount, &clip.CreatedAt,
 			&clip.ImportedAt, &clip.VoteScore, &clip.CommentCount, &clip.FavoriteCount,
 			&clip.IsFeatured, &clip.IsNSFW, &clip.IsRemoved, &clip.RemovedReason, &clip.IsHidden,
-			&clip.SubmittedByUserID, &clip.SubmittedAt,
+			&clip.SubmittedByUserID, &clip.SubmittedAt, &clip.DisplayTitle,
 		); err != nil {
 			return nil, 0, fmt.Errorf("failed to scan clip: %w", err)
 		}
@@ -1712,8 +1791,8 @@ func (r *ClipRepository) ListClipsByGame(ctx context.Context, gameID string, lim
 func (r *ClipRepository) ListClipsForStreamerGame(ctx context.Context, broadcasterID, gameID string, limit, offset int) ([]models.Clip, int, error) {
 	countQuery := `
 		SELECT COUNT(*)
-		FROM clips
-		WHERE broadcaster_id = $1 AND game_id = $2 AND is_removed = false
+		FROM clips c
+		WHERE broadcaster_id = $1 AND game_id = $2 AND is_removed = false AND ` + clipNotShadowBanned + `
 	`
 	var total int
 	if err := r.pool.QueryRow(ctx, countQuery, broadcasterID, gameID).Scan(&total); err != nil {
@@ -1727,9 +1806,9 @@ func (r *ClipRepository) ListClipsForStreamerGame(ctx context.Context, broadcast
 			game_id, game_name, language, thumbnail_url, duration,
 			view_count, created_at, imported_at, vote_score, comment_count,
 			favorite_count, is_featured, is_nsfw, is_removed, removed_reason, is_hidden,
-			submitted_by_user_id, submitted_at
-		FROM clips
-		WHERE broadcaster_id = $1 AND game_id = $2 AND is_removed = false
+			submitted_by_user_id, submitted_at, display_title
+		FROM clips c
+		WHERE broadcaster_id = $1 AND game_id = $2 AND is_removed = false AND ` + clipNotShadowBanned + `
 		ORDER BY vote_score DESC, view_count DESC
 		LIMIT $3 OFFSET $4
 	`
@@ -1749,7 +1828,7 @@ func (r *ClipRepository) ListClipsForStreamerGame(ctx context.Context, broadcast
 			&clip.ThumbnailURL, &clip.Duration, &clip.ViewCount, &clip.CreatedAt,
 			&clip.ImportedAt, &clip.VoteScore, &clip.CommentCount, &clip.FavoriteCount,
 			&clip.IsFeatured, &clip.IsNSFW, &clip.IsRemoved, &clip.RemovedReason, &clip.IsHidden,
-			&clip.SubmittedByUserID, &clip.SubmittedAt,
+			&clip.SubmittedByUserID, &clip.SubmittedAt, &clip.DisplayTitle,
 		); err != nil {
 			return nil, 0, fmt.Errorf("failed to scan clip: %w", err)
 		}
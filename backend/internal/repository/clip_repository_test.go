@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/pagination"
 	"github.com/subculture-collective/clipper/internal/testutil"
 )
 
@@ -532,3 +533,144 @@ func TestClipRepository_ListWithFilters_SubmittedByUserID(t *testing.T) {
 		t.Errorf("Expected 0 clips for non-existent user, got %d", total)
 	}
 }
+
+func TestClipRepository_ListWithFiltersCursor(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	pool := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, pool)
+	testutil.TruncateTables(t, pool, "clips", "top_streamers", "clip_tags", "favorites", "comments", "votes", "comment_votes")
+
+	repo := NewClipRepository(pool)
+	ctx := context.Background()
+
+	// Equal scores and timestamps make the ID the only tie-breaker
+	createdAt := time.Now().Add(-1 * time.Hour).Truncate(time.Second)
+	for i := 0; i < 5; i++ {
+		clip := &models.Clip{
+			ID:              uuid.New(),
+			TwitchClipID:    fmt.Sprintf("cursor-clip-%d-%s", i, uuid.NewString()),
+			TwitchClipURL:   "https://clips.twitch.tv/cursor",
+			EmbedURL:        "https://clips.twitch.tv/embed?clip=cursor",
+			Title:           fmt.Sprintf("Cursor Clip %d", i),
+			CreatorName:     "creator",
+			BroadcasterName: "broadcaster",
+			CreatedAt:       createdAt,
+			ImportedAt:      time.Now(),
+			VoteScore:       i % 2,
+		}
+		if err := repo.Create(ctx, clip); err != nil {
+			t.Fatalf("Failed to create clip: %v", err)
+		}
+	}
+
+	for _, sort := range []string{"top", "new", "hot", "trending", "discussed"} {
+		t.Run(sort, func(t *testing.T) {
+			expected, _, err := repo.ListWithFilters(ctx, ClipFilters{Sort: sort}, 10, 0)
+			if err != nil {
+				t.Fatalf("Failed to list clips: %v", err)
+			}
+
+			var paged []uuid.UUID
+			filters := ClipFilters{Sort: sort}
+			for pages := 0; pages < 10; pages++ {
+				clips, page, err := repo.ListWithFiltersCursor(ctx, filters, 2)
+				if err != nil {
+					t.Fatalf("Failed to list page: %v", err)
+				}
+				for _, clip := range clips {
+					paged = append(paged, clip.ID)
+				}
+				if !page.HasMore {
+					break
+				}
+				cursor, err := pagination.Decode(*page.NextCursor, sort)
+				if err != nil {
+					t.Fatalf("Failed to decode next cursor: %v", err)
+				}
+				filters.Cursor = cursor
+			}
+
+			if len(paged) != len(expected) {
+				t.Fatalf("Expected %d clips across pages, got %d", len(expected), len(paged))
+			}
+			for i, clip := range expected {
+				if paged[i] != clip.ID {
+					t.Errorf("Clip %d: cursor pages returned %s, offset listing %s", i, paged[i], clip.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestClipRepository_ListingsExcludeShadowBannedSubmitters(t *testing.T) {
+	// Skip if not in integration test mode
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	pool := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, pool)
+	testutil.TruncateTables(t, pool, "clips", "users")
+
+	repo := NewClipRepository(pool)
+	ctx := context.Background()
+
+	visibleID, bannedID := uuid.New(), uuid.New()
+	insertTestUser(t, pool, visibleID)
+	insertTestUser(t, pool, bannedID)
+	if _, err := pool.Exec(ctx, `UPDATE users SET is_shadow_banned = true WHERE id = $1`, bannedID); err != nil {
+		t.Fatalf("Failed to shadow ban user: %v", err)
+	}
+
+	makeClip := func(title string, submitterID uuid.UUID) *models.Clip {
+		return &models.Clip{
+			ID:                uuid.New(),
+			TwitchClipID:      fmt.Sprintf("%s-%s", title, uuid.NewString()),
+			TwitchClipURL:     "https://clips.twitch.tv/" + title,
+			EmbedURL:          "https://clips.twitch.tv/embed?clip=" + title,
+			Title:             title,
+			CreatorName:       "creator",
+			BroadcasterName:   "broadcaster",
+			BroadcasterID:     testutil.StringPtr("12345"),
+			GameID:            testutil.StringPtr("509658"),
+			CreatedAt:         time.Now().Add(-time.Hour),
+			ImportedAt:        time.Now(),
+			SubmittedByUserID: &submitterID,
+			SubmittedAt:       testutil.TimePtr(time.Now().Add(-time.Hour)),
+		}
+	}
+	visible := makeClip("visible", visibleID)
+	hidden := makeClip("hidden", bannedID)
+	for _, clip := range []*models.Clip{visible, hidden} {
+		if err := repo.Create(ctx, clip); err != nil {
+			t.Fatalf("Failed to create clip: %v", err)
+		}
+	}
+
+	listings := map[string]func() ([]models.Clip, int, error){
+		"broadcaster": func() ([]models.Clip, int, error) {
+			return repo.ListClipsByBroadcaster(ctx, "12345", "recent", 10, 0)
+		},
+		"game": func() ([]models.Clip, int, error) {
+			return repo.ListClipsByGame(ctx, "509658", 10, 0)
+		},
+		"best of": func() ([]models.Clip, int, error) {
+			return repo.ListClipsForBestOf(ctx, time.Now().Add(-24*time.Hour), time.Now(), 10, 0)
+		},
+		"streamer game": func() ([]models.Clip, int, error) {
+			return repo.ListClipsForStreamerGame(ctx, "12345", "509658", 10, 0)
+		},
+	}
+	for name, list := range listings {
+		clips, total, err := list()
+		if err != nil {
+			t.Fatalf("%s: failed to list clips: %v", name, err)
+		}
+		if total != 1 || len(clips) != 1 || clips[0].ID != visible.ID {
+			t.Errorf("%s: got %d clips (total %d), want only the visible clip", name, len(clips), total)
+		}
+	}
+}
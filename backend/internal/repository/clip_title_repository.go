@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// ClipTitleRepository handles database operations for normalized clip titles
+type ClipTitleRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewClipTitleRepository creates a new clip title repository
+func NewClipTitleRepository(db *pgxpool.Pool) *ClipTitleRepository {
+	return &ClipTitleRepository{db: db}
+}
+
+// GetPreference returns a broadcaster's title normalization preference, or nil if none is set
+func (r *ClipTitleRepository) GetPreference(ctx context.Context, broadcasterID string) (*models.BroadcasterTitlePreference, error) {
+	query := `
+		SELECT broadcaster_id, user_id, normalize_titles, created_at, updated_at
+		FROM broadcaster_title_preferences
+		WHERE broadcaster_id = $1
+	`
+
+	var pref models.BroadcasterTitlePreference
+	err := r.db.QueryRow(ctx, query, broadcasterID).Scan(
+		&pref.BroadcasterID,
+		&pref.UserID,
+		&pref.NormalizeTitles,
+		&pref.CreatedAt,
+		&pref.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get broadcaster title preference: %w", err)
+	}
+
+	return &pref, nil
+}
+
+// UpsertPreference creates or replaces a broadcaster's title normalization preference
+func (r *ClipTitleRepository) UpsertPreference(ctx context.Context, pref *models.BroadcasterTitlePreference) error {
+	query := `
+		INSERT INTO broadcaster_title_preferences (broadcaster_id, user_id, normalize_titles)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (broadcaster_id) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			normalize_titles = EXCLUDED.normalize_titles,
+			updated_at = NOW()
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query, pref.BroadcasterID, pref.UserID, pref.NormalizeTitles).
+		Scan(&pref.CreatedAt, &pref.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save broadcaster title preference: %w", err)
+	}
+
+	return nil
+}
+
+// ListOptedOutBroadcasters returns the IDs of broadcasters who turned title normalization off
+func (r *ClipTitleRepository) ListOptedOutBroadcasters(ctx context.Context) ([]string, error) {
+	rows, err := r.db.Query(ctx, `SELECT broadcaster_id FROM broadcaster_title_preferences WHERE normalize_titles = false`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list opted-out broadcasters: %w", err)
+	}
+	defer rows.Close()
+
+	broadcasterIDs := []string{}
+	for rows.Next() {
+		var broadcasterID string
+		if err := rows.Scan(&broadcasterID); err != nil {
+			return nil, fmt.Errorf("failed to scan broadcaster ID: %w", err)
+		}
+		broadcasterIDs = append(broadcasterIDs, broadcasterID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating opted-out broadcasters: %w", err)
+	}
+
+	return broadcasterIDs, nil
+}
+
+// ListClipTitles returns up to limit clips with IDs after afterID, in ID
+// order, optionally only those of one broadcaster
+func (r *ClipTitleRepository) ListClipTitles(ctx context.Context, broadcasterID string, afterID uuid.UUID, limit int) ([]models.ClipTitle, error) {
+	query := `
+		SELECT id, broadcaster_id, title, display_title
+		FROM clips
+		WHERE id > $1 AND ($2 = '' OR broadcaster_id = $2)
+		ORDER BY id
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(ctx, query, afterID, broadcasterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clip titles: %w", err)
+	}
+	defer rows.Close()
+
+	clips := []models.ClipTitle{}
+	for rows.Next() {
+		var clip models.ClipTitle
+		if err := rows.Scan(&clip.ID, &clip.BroadcasterID, &clip.Title, &clip.DisplayTitle); err != nil {
+			return nil, fmt.Errorf("failed to scan clip title: %w", err)
+		}
+		clips = append(clips, clip)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating clip titles: %w", err)
+	}
+
+	return clips, nil
+}
+
+// SetDisplayTitle stores a clip's normalized title; nil clears it
+func (r *ClipTitleRepository) SetDisplayTitle(ctx context.Context, clipID uuid.UUID, displayTitle *string) error {
+	_, err := r.db.Exec(ctx, `UPDATE clips SET display_title = $2 WHERE id = $1`, clipID, displayTitle)
+	if err != nil {
+		return fmt.Errorf("failed to set clip display title: %w", err)
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/pagination"
 )
 
 // CommentRepository handles database operations for comments
@@ -33,39 +34,79 @@ type CommentWithAuthor struct {
 	AuthorRole        string  `json:"author_role" db:"author_role"`
 	ReplyCount        int     `json:"reply_count" db:"reply_count"`
 	UserVote          *int16  `json:"user_vote,omitempty" db:"user_vote"`
+	// Mentions lists the users mentioned in the comment, set by CommentService
+	Mentions []models.CommentMention `json:"mentions,omitempty" db:"-"`
+	// Reactions counts the comment's emoji reactions, set by CommentService
+	Reactions []models.CommentReactionCount `json:"reactions,omitempty" db:"-"`
+	// Pinned marks comments pinned to the top of their clip, set by CommentService
+	Pinned bool `json:"pinned" db:"-"`
+	// HeldForReview marks the author's comments automoderation holds until
+	// a moderator approves them
+	HeldForReview bool `json:"held_for_review,omitempty" db:"held_for_review"`
 }
 
-// ListByClipID retrieves comments for a clip with sorting and pagination
-func (r *CommentRepository) ListByClipID(ctx context.Context, clipID uuid.UUID, sortBy string, limit, offset int, userID *uuid.UUID) ([]CommentWithAuthor, error) {
-	var orderClause string
+// commentVisibleTo restricts comments to those other users can see, and the
+// viewer's ($2) own comments automoderation holds or shadow-hides or that they
+// wrote while shadow banned
+const commentVisibleTo = "((c.automod_state IS NULL AND NOT u.is_shadow_banned) OR c.user_id = $2)"
 
+// CommentRepliesSort is the sort order cursors over comment replies are issued for
+const CommentRepliesSort = "replies"
+
+// commentKeyset returns the sort order of top-level comments as a keyset over
+// the columns of the comment_tree CTE in ListByClipID
+func commentKeyset(sortBy string) pagination.Keyset {
+	k := pagination.Keyset{Sort: sortBy, Time: "created_at", ID: "id"}
 	switch sortBy {
 	case "new":
-		orderClause = "created_at DESC"
+		// Newest first, the keyset default
 	case "old":
-		orderClause = "created_at ASC"
+		k.Asc = true
 	case "controversial":
 		// Controversial: high vote count but score near zero
-		orderClause = `
-			(ABS(vote_score) / NULLIF(GREATEST(ABS(vote_score), 1), 0)) DESC,
-			ABS(vote_score) DESC
-		`
-	case "best":
-		fallthrough
+		k.Scores = []string{
+			"(ABS(vote_score) / NULLIF(GREATEST(ABS(vote_score), 1), 0))",
+			"ABS(vote_score)",
+		}
 	default:
 		// Wilson score confidence interval for "best" sorting
-		// Uses vote counts from the CTE to avoid N+1 query pattern
-		orderClause = `
-			CASE
+		// Uses vote counts from the CTE to avoid N+1 query pattern; cast to
+		// float8 so the score round-trips through a cursor
+		k.Scores = []string{
+			`(CASE
 				WHEN total_votes = 0 THEN 0
 				ELSE (
 					((upvotes + 1.9208) / total_votes -
 					1.96 * SQRT((upvotes * downvotes) / total_votes + 0.9604) / total_votes)
 				) / (1 + 3.8416 / total_votes)
-			END DESC,
-			vote_score DESC,
-			created_at DESC
-		`
+			END)::float8`,
+			"vote_score",
+		}
+	}
+	return k
+}
+
+// ListByClipID retrieves a page of top-level comments for a clip with sorting,
+// continuing after cursor when set
+func (r *CommentRepository) ListByClipID(ctx context.Context, clipID uuid.UUID, sortBy string, cursor *pagination.Cursor, limit int, userID *uuid.UUID) ([]CommentWithAuthor, pagination.PageInfo, error) {
+	keyset := commentKeyset(sortBy)
+
+	// Use a nil UUID for non-authenticated requests
+	viewerID := uuid.Nil
+	if userID != nil {
+		viewerID = *userID
+	}
+	// Fetch one extra row to tell whether another page follows
+	args := []interface{}{clipID, viewerID, limit + 1}
+
+	cursorClause := ""
+	if cursor != nil {
+		where, cursorArgs, err := keyset.After(cursor, len(args)+1)
+		if err != nil {
+			return nil, pagination.PageInfo{}, err
+		}
+		cursorClause = "WHERE " + where
+		args = append(args, cursorArgs...)
 	}
 
 	query := fmt.Sprintf(`
@@ -85,7 +126,7 @@ func (r *CommentRepository) ListByClipID(ctx context.Context, clipID uuid.UUID,
 		comment_tree AS (
 			-- Get top-level comments for this clip
 			SELECT
-				c.id, c.clip_id, c.user_id, c.parent_comment_id, c.content,
+				c.id, c.clip_id, c.user_id, c.parent_comment_id, c.content, c.rendered_content,
 				c.vote_score, c.reply_count, c.is_edited, c.is_removed, c.removed_reason,
 				c.created_at, c.updated_at,
 				u.username AS author_username,
@@ -94,6 +135,7 @@ func (r *CommentRepository) ListByClipID(ctx context.Context, clipID uuid.UUID,
 				u.karma_points AS author_karma,
 				u.role AS author_role,
 				COALESCE(cv.vote_type, NULL) AS user_vote,
+				COALESCE(c.automod_state = 'held', false) AS held_for_review,
 				0 AS depth,
 				COALESCE(vc.total_votes, 0) AS total_votes,
 				COALESCE(vc.upvotes, 0) AS upvotes,
@@ -102,115 +144,237 @@ func (r *CommentRepository) ListByClipID(ctx context.Context, clipID uuid.UUID,
 			INNER JOIN users u ON c.user_id = u.id
 			LEFT JOIN comment_votes cv ON c.id = cv.comment_id AND cv.user_id = $2
 			LEFT JOIN vote_counts vc ON c.id = vc.comment_id
-			WHERE c.clip_id = $1 AND c.parent_comment_id IS NULL
+			WHERE c.clip_id = $1 AND c.parent_comment_id IS NULL AND `+commentVisibleTo+`
 		)
-		SELECT * FROM comment_tree
+		SELECT *, %s FROM comment_tree
+		%s
 		ORDER BY %s
-		LIMIT $3 OFFSET $4
-	`, orderClause)
-
-	var args []interface{}
-	if userID != nil {
-		args = []interface{}{clipID, *userID, limit, offset}
-	} else {
-		// Use a nil UUID for non-authenticated requests
-		args = []interface{}{clipID, uuid.Nil, limit, offset}
-	}
+		LIMIT $3
+	`, keyset.Columns(), cursorClause, keyset.OrderBy())
 
 	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list comments: %w", err)
+		return nil, pagination.PageInfo{}, fmt.Errorf("failed to list comments: %w", err)
 	}
 	defer rows.Close()
 
 	var comments []CommentWithAuthor
+	var keys []pagination.Cursor
 	for rows.Next() {
 		var c CommentWithAuthor
+		var key pagination.Cursor
 		var depth int
 		var totalVotes, upvotes, downvotes int // Vote count columns from CTE
-		err := rows.Scan(
-			&c.ID, &c.ClipID, &c.UserID, &c.ParentCommentID, &c.Content,
+		dest := []interface{}{
+			&c.ID, &c.ClipID, &c.UserID, &c.ParentCommentID, &c.Content, &c.RenderedContent,
 			&c.VoteScore, &c.ReplyCount, &c.IsEdited, &c.IsRemoved, &c.RemovedReason,
 			&c.CreatedAt, &c.UpdatedAt,
 			&c.AuthorUsername, &c.AuthorDisplayName, &c.AuthorAvatarURL,
-			&c.AuthorKarma, &c.AuthorRole, &c.UserVote,
+			&c.AuthorKarma, &c.AuthorRole, &c.UserVote, &c.HeldForReview,
 			&depth, &totalVotes, &upvotes, &downvotes,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan comment: %w", err)
+		}
+		if err := rows.Scan(append(dest, keyset.Dest(&key)...)...); err != nil {
+			return nil, pagination.PageInfo{}, fmt.Errorf("failed to scan comment: %w", err)
 		}
 		comments = append(comments, c)
+		keys = append(keys, key)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating comments: %w", err)
+		return nil, pagination.PageInfo{}, fmt.Errorf("error iterating comments: %w", err)
 	}
 
-	return comments, nil
+	comments, page := pagination.Trim(comments, keys, limit)
+	return comments, page, nil
 }
 
-// GetReplies retrieves replies to a comment
-func (r *CommentRepository) GetReplies(ctx context.Context, parentID uuid.UUID, limit, offset int, userID *uuid.UUID) ([]CommentWithAuthor, error) {
-	query := `
-		SELECT
-			c.id, c.clip_id, c.user_id, c.parent_comment_id, c.content,
-			c.vote_score, c.reply_count, c.is_edited, c.is_removed, c.removed_reason,
-			c.created_at, c.updated_at,
-			u.username AS author_username,
-			u.display_name AS author_display_name,
-			u.avatar_url AS author_avatar_url,
-			u.karma_points AS author_karma,
-			u.role AS author_role,
-			COALESCE(cv.vote_type, NULL) AS user_vote
-		FROM comments c
-		INNER JOIN users u ON c.user_id = u.id
-		LEFT JOIN comment_votes cv ON c.id = cv.comment_id AND cv.user_id = $2
-		WHERE c.parent_comment_id = $1
-		ORDER BY c.vote_score DESC, c.created_at DESC
-		LIMIT $3 OFFSET $4
-	`
+// Orders replies to a comment can be listed in
+const (
+	CommentReplySortTop           = "top"
+	CommentReplySortNew           = "new"
+	CommentReplySortControversial = "controversial"
+)
 
-	var args []interface{}
+// commentRepliesKeyset returns the order replies to a comment are listed in,
+// top replies by default. Cursors over top replies keep the sort name they
+// were first issued with.
+func commentRepliesKeyset(sortBy string) pagination.Keyset {
+	k := pagination.Keyset{
+		Sort:   CommentRepliesSort,
+		Scores: []string{"c.vote_score"},
+		Time:   "c.created_at",
+		ID:     "c.id",
+	}
+	switch sortBy {
+	case CommentReplySortNew:
+		k.Sort = CommentRepliesSort + ":" + CommentReplySortNew
+		k.Scores = nil
+	case CommentReplySortControversial:
+		// Many votes with a score near zero
+		k.Sort = CommentRepliesSort + ":" + CommentReplySortControversial
+		k.Scores = []string{"vc.total_votes::float8 / (ABS(c.vote_score) + 1)"}
+	}
+	return k
+}
+
+// CommentRepliesCursorSort returns the sort order cursors over replies listed
+// in sortBy order are issued for
+func CommentRepliesCursorSort(sortBy string) string {
+	return commentRepliesKeyset(sortBy).Sort
+}
+
+// commentReplySelect selects replies with their authors, the viewer's vote
+// ($2) and the vote counts the reply keysets order by
+const commentReplySelect = `
+	SELECT
+		c.id, c.clip_id, c.user_id, c.parent_comment_id, c.content, c.rendered_content,
+		c.vote_score, c.reply_count, c.is_edited, c.is_removed, c.removed_reason,
+		c.created_at, c.updated_at,
+		u.username AS author_username,
+		u.display_name AS author_display_name,
+		u.avatar_url AS author_avatar_url,
+		u.karma_points AS author_karma,
+		u.role AS author_role,
+		COALESCE(cv.vote_type, NULL) AS user_vote,
+		COALESCE(c.automod_state = 'held', false) AS held_for_review,
+		%s
+	FROM comments c
+	INNER JOIN users u ON c.user_id = u.id
+	LEFT JOIN comment_votes cv ON c.id = cv.comment_id AND cv.user_id = $2
+	LEFT JOIN LATERAL (
+		SELECT COUNT(*) AS total_votes FROM comment_votes WHERE comment_id = c.id
+	) vc ON true
+`
+
+// scanReply scans a row selected by commentReplySelect, with extra holding
+// the destinations of any columns selected after the keyset
+func scanReply(rows pgx.Rows, keyset pagination.Keyset, extra ...interface{}) (CommentWithAuthor, pagination.Cursor, error) {
+	var c CommentWithAuthor
+	var key pagination.Cursor
+	dest := []interface{}{
+		&c.ID, &c.ClipID, &c.UserID, &c.ParentCommentID, &c.Content, &c.RenderedContent,
+		&c.VoteScore, &c.ReplyCount, &c.IsEdited, &c.IsRemoved, &c.RemovedReason,
+		&c.CreatedAt, &c.UpdatedAt,
+		&c.AuthorUsername, &c.AuthorDisplayName, &c.AuthorAvatarURL,
+		&c.AuthorKarma, &c.AuthorRole, &c.UserVote, &c.HeldForReview,
+	}
+	dest = append(dest, keyset.Dest(&key)...)
+	err := rows.Scan(append(dest, extra...)...)
+	return c, key, err
+}
+
+// GetReplies retrieves a page of replies to a comment in sortBy order,
+// continuing after cursor when set
+func (r *CommentRepository) GetReplies(ctx context.Context, parentID uuid.UUID, sortBy string, cursor *pagination.Cursor, limit int, userID *uuid.UUID) ([]CommentWithAuthor, pagination.PageInfo, error) {
+	keyset := commentRepliesKeyset(sortBy)
+
+	viewerID := uuid.Nil
 	if userID != nil {
-		args = []interface{}{parentID, *userID, limit, offset}
-	} else {
-		args = []interface{}{parentID, uuid.Nil, limit, offset}
+		viewerID = *userID
+	}
+	// Fetch one extra row to tell whether another page follows
+	args := []interface{}{parentID, viewerID, limit + 1}
+
+	cursorClause := ""
+	if cursor != nil {
+		where, cursorArgs, err := keyset.After(cursor, len(args)+1)
+		if err != nil {
+			return nil, pagination.PageInfo{}, err
+		}
+		cursorClause = "AND " + where
+		args = append(args, cursorArgs...)
 	}
 
+	query := fmt.Sprintf(commentReplySelect, keyset.Columns()) + fmt.Sprintf(`
+		WHERE c.parent_comment_id = $1 AND `+commentVisibleTo+` %s
+		ORDER BY %s
+		LIMIT $3
+	`, cursorClause, keyset.OrderBy())
+
 	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get replies: %w", err)
+		return nil, pagination.PageInfo{}, fmt.Errorf("failed to get replies: %w", err)
 	}
 	defer rows.Close()
 
 	var comments []CommentWithAuthor
+	var keys []pagination.Cursor
 	for rows.Next() {
-		var c CommentWithAuthor
-		err := rows.Scan(
-			&c.ID, &c.ClipID, &c.UserID, &c.ParentCommentID, &c.Content,
-			&c.VoteScore, &c.ReplyCount, &c.IsEdited, &c.IsRemoved, &c.RemovedReason,
-			&c.CreatedAt, &c.UpdatedAt,
-			&c.AuthorUsername, &c.AuthorDisplayName, &c.AuthorAvatarURL,
-			&c.AuthorKarma, &c.AuthorRole, &c.UserVote,
-		)
+		c, key, err := scanReply(rows, keyset)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan reply: %w", err)
+			return nil, pagination.PageInfo{}, fmt.Errorf("failed to scan reply: %w", err)
 		}
 		comments = append(comments, c)
+		keys = append(keys, key)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating replies: %w", err)
+		return nil, pagination.PageInfo{}, fmt.Errorf("error iterating replies: %w", err)
 	}
 
-	return comments, nil
+	comments, page := pagination.Trim(comments, keys, limit)
+	return comments, page, nil
+}
+
+// ListReplyBranches retrieves the first page of replies to each of the given
+// comments in one query, in sortBy order with up to perParent replies each.
+// Each branch's page info continues it through GetReplies.
+func (r *CommentRepository) ListReplyBranches(ctx context.Context, parentIDs []uuid.UUID, sortBy string, perParent int, userID *uuid.UUID) (map[uuid.UUID][]CommentWithAuthor, map[uuid.UUID]pagination.PageInfo, error) {
+	branches := make(map[uuid.UUID][]CommentWithAuthor)
+	pages := make(map[uuid.UUID]pagination.PageInfo)
+	if len(parentIDs) == 0 {
+		return branches, pages, nil
+	}
+
+	keyset := commentRepliesKeyset(sortBy)
+	viewerID := uuid.Nil
+	if userID != nil {
+		viewerID = *userID
+	}
+
+	// Rank replies within each branch, fetching one extra per branch to tell
+	// whether the branch has another page
+	query := fmt.Sprintf(`
+		SELECT * FROM (%s, ROW_NUMBER() OVER (PARTITION BY c.parent_comment_id ORDER BY %s) AS branch_rank
+			WHERE c.parent_comment_id = ANY($1) AND `+commentVisibleTo+`
+		) branches
+		WHERE branch_rank <= $3
+		ORDER BY parent_comment_id, branch_rank
+	`, fmt.Sprintf(commentReplySelect, keyset.Columns()), keyset.OrderBy())
+
+	rows, err := r.pool.Query(ctx, query, parentIDs, viewerID, perParent+1)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list reply branches: %w", err)
+	}
+	defer rows.Close()
+
+	keys := make(map[uuid.UUID][]pagination.Cursor)
+	for rows.Next() {
+		var rank int
+		c, key, err := scanReply(rows, keyset, &rank)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan reply: %w", err)
+		}
+		parentID := *c.ParentCommentID
+		branches[parentID] = append(branches[parentID], c)
+		keys[parentID] = append(keys[parentID], key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error iterating reply branches: %w", err)
+	}
+
+	for parentID, replies := range branches {
+		branches[parentID], pages[parentID] = pagination.Trim(replies, keys[parentID], perParent)
+	}
+	return branches, pages, nil
 }
 
 // GetByID retrieves a comment by ID with author info
 func (r *CommentRepository) GetByID(ctx context.Context, id uuid.UUID, userID *uuid.UUID) (*CommentWithAuthor, error) {
 	query := `
 		SELECT
-			c.id, c.clip_id, c.user_id, c.parent_comment_id, c.content,
+			c.id, c.clip_id, c.user_id, c.parent_comment_id, c.content, c.rendered_content,
 			c.vote_score, c.reply_count, c.is_edited, c.is_removed, c.removed_reason,
 			c.created_at, c.updated_at,
 			u.username AS author_username,
@@ -218,7 +382,8 @@ func (r *CommentRepository) GetByID(ctx context.Context, id uuid.UUID, userID *u
 			u.avatar_url AS author_avatar_url,
 			u.karma_points AS author_karma,
 			u.role AS author_role,
-			COALESCE(cv.vote_type, NULL) AS user_vote
+			COALESCE(cv.vote_type, NULL) AS user_vote,
+			COALESCE(c.automod_state = 'held', false) AS held_for_review
 		FROM comments c
 		INNER JOIN users u ON c.user_id = u.id
 		LEFT JOIN comment_votes cv ON c.id = cv.comment_id AND cv.user_id = $2
@@ -234,11 +399,11 @@ func (r *CommentRepository) GetByID(ctx context.Context, id uuid.UUID, userID *u
 	}
 
 	err := r.pool.QueryRow(ctx, query, args...).Scan(
-		&c.ID, &c.ClipID, &c.UserID, &c.ParentCommentID, &c.Content,
+		&c.ID, &c.ClipID, &c.UserID, &c.ParentCommentID, &c.Content, &c.RenderedContent,
 		&c.VoteScore, &c.ReplyCount, &c.IsEdited, &c.IsRemoved, &c.RemovedReason,
 		&c.CreatedAt, &c.UpdatedAt,
 		&c.AuthorUsername, &c.AuthorDisplayName, &c.AuthorAvatarURL,
-		&c.AuthorKarma, &c.AuthorRole, &c.UserVote,
+		&c.AuthorKarma, &c.AuthorRole, &c.UserVote, &c.HeldForReview,
 	)
 
 	if err != nil {
@@ -255,17 +420,18 @@ func (r *CommentRepository) GetByID(ctx context.Context, id uuid.UUID, userID *u
 func (r *CommentRepository) Create(ctx context.Context, comment *models.Comment) error {
 	query := `
 		INSERT INTO comments (
-			id, clip_id, user_id, parent_comment_id, content,
-			vote_score, reply_count, is_edited, is_removed, created_at, updated_at
+			id, clip_id, user_id, parent_comment_id, content, rendered_content,
+			vote_score, reply_count, is_edited, is_removed, removed_reason, automod_state,
+			created_at, updated_at
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
 		)
 	`
 
 	_, err := r.pool.Exec(ctx, query,
 		comment.ID, comment.ClipID, comment.UserID, comment.ParentCommentID,
-		comment.Content, comment.VoteScore, comment.ReplyCount, comment.IsEdited, comment.IsRemoved,
-		comment.CreatedAt, comment.UpdatedAt,
+		comment.Content, comment.RenderedContent, comment.VoteScore, comment.ReplyCount, comment.IsEdited, comment.IsRemoved,
+		comment.RemovedReason, comment.AutomodState, comment.CreatedAt, comment.UpdatedAt,
 	)
 
 	if err != nil {
@@ -275,16 +441,33 @@ func (r *CommentRepository) Create(ctx context.Context, comment *models.Comment)
 	return nil
 }
 
-// Update updates a comment's content
+// Update updates a comment's content and its rendered HTML, keeping the
+// content it replaces as a revision edited by editorID
 // Note: updated_at is automatically updated by the update_comments_updated_at database trigger
-func (r *CommentRepository) Update(ctx context.Context, id uuid.UUID, content string) error {
+func (r *CommentRepository) Update(ctx context.Context, id, editorID uuid.UUID, content, renderedContent string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Unchanged content isn't a revision
+	_, err = tx.Exec(ctx, `
+		INSERT INTO comment_revisions (comment_id, content, edited_by)
+		SELECT id, content, $2 FROM comments
+		WHERE id = $1 AND content <> $3
+	`, id, editorID, content)
+	if err != nil {
+		return fmt.Errorf("failed to record comment revision: %w", err)
+	}
+
 	query := `
 		UPDATE comments
-		SET content = $2, is_edited = true
+		SET content = $2, rendered_content = $3, is_edited = true
 		WHERE id = $1
 	`
 
-	result, err := r.pool.Exec(ctx, query, id, content)
+	result, err := tx.Exec(ctx, query, id, content, renderedContent)
 	if err != nil {
 		return fmt.Errorf("failed to update comment: %w", err)
 	}
@@ -293,9 +476,40 @@ func (r *CommentRepository) Update(ctx context.Context, id uuid.UUID, content st
 		return fmt.Errorf("comment not found")
 	}
 
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit comment update: %w", err)
+	}
+
 	return nil
 }
 
+// ListRevisions returns a comment's earlier versions, newest first
+func (r *CommentRepository) ListRevisions(ctx context.Context, commentID uuid.UUID) ([]models.CommentRevision, error) {
+	query := `
+		SELECT id, comment_id, content, edited_by, created_at
+		FROM comment_revisions
+		WHERE comment_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.pool.Query(ctx, query, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comment revisions: %w", err)
+	}
+	defer rows.Close()
+
+	revisions := []models.CommentRevision{}
+	for rows.Next() {
+		var rev models.CommentRevision
+		if err := rows.Scan(&rev.ID, &rev.CommentID, &rev.Content, &rev.EditedBy, &rev.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan comment revision: %w", err)
+		}
+		revisions = append(revisions, rev)
+	}
+
+	return revisions, rows.Err()
+}
+
 // Delete soft-deletes a comment
 func (r *CommentRepository) Delete(ctx context.Context, id uuid.UUID, isModAction bool, reason *string) error {
 	var content string
@@ -307,7 +521,7 @@ func (r *CommentRepository) Delete(ctx context.Context, id uuid.UUID, isModActio
 
 	query := `
 		UPDATE comments
-		SET content = $2, is_removed = true, removed_reason = $3
+		SET content = $2, rendered_content = NULL, is_removed = true, removed_reason = $3
 		WHERE id = $1
 	`
 
@@ -450,7 +664,7 @@ func (r *CommentRepository) GetCommentTree(ctx context.Context, parentID uuid.UU
 		WITH RECURSIVE comment_tree AS (
 			-- Base case: get the parent comment
 			SELECT
-				c.id, c.clip_id, c.user_id, c.parent_comment_id, c.content,
+				c.id, c.clip_id, c.user_id, c.parent_comment_id, c.content, c.rendered_content,
 				c.vote_score, c.reply_count, c.is_edited, c.is_removed, c.removed_reason,
 				c.created_at, c.updated_at,
 				u.username AS author_username,
@@ -470,7 +684,7 @@ func (r *CommentRepository) GetCommentTree(ctx context.Context, parentID uuid.UU
 
 			-- Recursive case: get all replies
 			SELECT
-				c.id, c.clip_id, c.user_id, c.parent_comment_id, c.content,
+				c.id, c.clip_id, c.user_id, c.parent_comment_id, c.content, c.rendered_content,
 				c.vote_score, c.reply_count, c.is_edited, c.is_removed, c.removed_reason,
 				c.created_at, c.updated_at,
 				u.username AS author_username,
@@ -514,7 +728,7 @@ func (r *CommentRepository) GetCommentTree(ctx context.Context, parentID uuid.UU
 	for rows.Next() {
 		var c CommentWithAuthor
 		err := rows.Scan(
-			&c.ID, &c.ClipID, &c.UserID, &c.ParentCommentID, &c.Content,
+			&c.ID, &c.ClipID, &c.UserID, &c.ParentCommentID, &c.Content, &c.RenderedContent,
 			&c.VoteScore, &c.ReplyCount, &c.IsEdited, &c.IsRemoved, &c.RemovedReason,
 			&c.CreatedAt, &c.UpdatedAt,
 			&c.AuthorUsername, &c.AuthorDisplayName, &c.AuthorAvatarURL,
@@ -538,7 +752,7 @@ func (r *CommentRepository) GetCommentTree(ctx context.Context, parentID uuid.UU
 func (r *CommentRepository) GetTopLevelComments(ctx context.Context, clipID uuid.UUID, limit, offset int, userID *uuid.UUID) ([]CommentWithAuthor, error) {
 	query := `
 		SELECT
-			c.id, c.clip_id, c.user_id, c.parent_comment_id, c.content,
+			c.id, c.clip_id, c.user_id, c.parent_comment_id, c.content, c.rendered_content,
 			c.vote_score, c.reply_count, c.is_edited, c.is_removed, c.removed_reason,
 			c.created_at, c.updated_at,
 			u.username AS author_username,
@@ -572,7 +786,7 @@ func (r *CommentRepository) GetTopLevelComments(ctx context.Context, clipID uuid
 	for rows.Next() {
 		var c CommentWithAuthor
 		err := rows.Scan(
-			&c.ID, &c.ClipID, &c.UserID, &c.ParentCommentID, &c.Content,
+			&c.ID, &c.ClipID, &c.UserID, &c.ParentCommentID, &c.Content, &c.RenderedContent,
 			&c.VoteScore, &c.ReplyCount, &c.IsEdited, &c.IsRemoved, &c.RemovedReason,
 			&c.CreatedAt, &c.UpdatedAt,
 			&c.AuthorUsername, &c.AuthorDisplayName, &c.AuthorAvatarURL,
@@ -603,7 +817,7 @@ func (r *CommentRepository) ListByUserID(ctx context.Context, userID uuid.UUID,
 	// Get comments
 	query := `
 		SELECT
-			c.id, c.clip_id, c.user_id, c.parent_comment_id, c.content,
+			c.id, c.clip_id, c.user_id, c.parent_comment_id, c.content, c.rendered_content,
 			c.vote_score, c.reply_count, c.is_edited, c.is_removed, c.removed_reason,
 			c.created_at, c.updated_at,
 			u.username AS author_username,
@@ -629,7 +843,7 @@ func (r *CommentRepository) ListByUserID(ctx context.Context, userID uuid.UUID,
 	for rows.Next() {
 		var c CommentWithAuthor
 		if err := rows.Scan(
-			&c.ID, &c.ClipID, &c.UserID, &c.ParentCommentID, &c.Content,
+			&c.ID, &c.ClipID, &c.UserID, &c.ParentCommentID, &c.Content, &c.RenderedContent,
 			&c.VoteScore, &c.ReplyCount, &c.IsEdited, &c.IsRemoved, &c.RemovedReason,
 			&c.CreatedAt, &c.UpdatedAt,
 			&c.AuthorUsername, &c.AuthorDisplayName, &c.AuthorAvatarURL,
@@ -646,3 +860,205 @@ func (r *CommentRepository) ListByUserID(ctx context.Context, userID uuid.UUID,
 
 	return comments, total, nil
 }
+
+// SetMentions makes the users mentioned in a comment those among usernames,
+// matched case-insensitively, and returns the users newly mentioned
+func (r *CommentRepository) SetMentions(ctx context.Context, commentID uuid.UUID, usernames []string) ([]uuid.UUID, error) {
+	if usernames == nil {
+		// A nil array is NULL, which would match nothing to drop
+		usernames = []string{}
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Drop users no longer mentioned, e.g. after an edit
+	_, err = tx.Exec(ctx, `
+		DELETE FROM comment_mentions cm
+		USING users u
+		WHERE cm.comment_id = $1 AND cm.user_id = u.id AND NOT (LOWER(u.username) = ANY($2))
+	`, commentID, usernames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove comment mentions: %w", err)
+	}
+
+	added := []uuid.UUID{}
+	if len(usernames) > 0 {
+		rows, err := tx.Query(ctx, `
+			INSERT INTO comment_mentions (comment_id, user_id)
+			SELECT $1, id FROM users
+			WHERE LOWER(username) = ANY($2) AND is_banned = false
+			ON CONFLICT (comment_id, user_id) DO NOTHING
+			RETURNING user_id
+		`, commentID, usernames)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add comment mentions: %w", err)
+		}
+		for rows.Next() {
+			var userID uuid.UUID
+			if err := rows.Scan(&userID); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to scan comment mention: %w", err)
+			}
+			added = append(added, userID)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("failed to add comment mentions: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit comment mentions: %w", err)
+	}
+
+	return added, nil
+}
+
+// ListMentions returns the users mentioned in each of the given comments
+func (r *CommentRepository) ListMentions(ctx context.Context, commentIDs []uuid.UUID) (map[uuid.UUID][]models.CommentMention, error) {
+	mentions := make(map[uuid.UUID][]models.CommentMention)
+	if len(commentIDs) == 0 {
+		return mentions, nil
+	}
+
+	query := `
+		SELECT cm.comment_id, u.id, u.username
+		FROM comment_mentions cm
+		INNER JOIN users u ON cm.user_id = u.id
+		WHERE cm.comment_id = ANY($1)
+		ORDER BY cm.created_at, u.username
+	`
+
+	rows, err := r.pool.Query(ctx, query, commentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comment mentions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var commentID uuid.UUID
+		var mention models.CommentMention
+		if err := rows.Scan(&commentID, &mention.UserID, &mention.Username); err != nil {
+			return nil, fmt.Errorf("failed to scan comment mention: %w", err)
+		}
+		mentions[commentID] = append(mentions[commentID], mention)
+	}
+
+	return mentions, rows.Err()
+}
+
+// AddReaction gives a comment a reaction from a user, returning false if the
+// user had already given it
+func (r *CommentRepository) AddReaction(ctx context.Context, commentID, userID uuid.UUID, reaction string) (bool, error) {
+	query := `
+		INSERT INTO comment_reactions (comment_id, user_id, reaction)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (comment_id, user_id, reaction) DO NOTHING
+	`
+
+	result, err := r.pool.Exec(ctx, query, commentID, userID, reaction)
+	if err != nil {
+		return false, fmt.Errorf("failed to add comment reaction: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// RemoveReaction takes back a user's reaction to a comment
+func (r *CommentRepository) RemoveReaction(ctx context.Context, commentID, userID uuid.UUID, reaction string) error {
+	query := `DELETE FROM comment_reactions WHERE comment_id = $1 AND user_id = $2 AND reaction = $3`
+
+	if _, err := r.pool.Exec(ctx, query, commentID, userID, reaction); err != nil {
+		return fmt.Errorf("failed to remove comment reaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListReactionCounts returns the reaction counts of each of the given
+// comments in display order, marking those the viewer gave when set
+func (r *CommentRepository) ListReactionCounts(ctx context.Context, commentIDs []uuid.UUID, viewerID *uuid.UUID) (map[uuid.UUID][]models.CommentReactionCount, error) {
+	counts := make(map[uuid.UUID][]models.CommentReactionCount)
+	if len(commentIDs) == 0 {
+		return counts, nil
+	}
+
+	query := `
+		SELECT comment_id, reaction, COUNT(*), COALESCE(BOOL_OR(user_id = $2), false)
+		FROM comment_reactions
+		WHERE comment_id = ANY($1)
+		GROUP BY comment_id, reaction
+		ORDER BY comment_id, array_position($3::text[], reaction)
+	`
+
+	rows, err := r.pool.Query(ctx, query, commentIDs, viewerID, models.ValidCommentReactions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comment reactions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var commentID uuid.UUID
+		var count models.CommentReactionCount
+		if err := rows.Scan(&commentID, &count.Reaction, &count.Count, &count.Reacted); err != nil {
+			return nil, fmt.Errorf("failed to scan comment reaction: %w", err)
+		}
+		counts[commentID] = append(counts[commentID], count)
+	}
+
+	return counts, rows.Err()
+}
+
+// PinComment pins a comment to the top of its clip's comments, returning
+// false if it was already pinned
+func (r *CommentRepository) PinComment(ctx context.Context, commentID, clipID, pinnedBy uuid.UUID) (bool, error) {
+	query := `
+		INSERT INTO comment_pins (comment_id, clip_id, pinned_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (comment_id) DO NOTHING
+	`
+
+	result, err := r.pool.Exec(ctx, query, commentID, clipID, pinnedBy)
+	if err != nil {
+		return false, fmt.Errorf("failed to pin comment: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// UnpinComment unpins a comment, returning false if it wasn't pinned
+func (r *CommentRepository) UnpinComment(ctx context.Context, commentID uuid.UUID) (bool, error) {
+	result, err := r.pool.Exec(ctx, `DELETE FROM comment_pins WHERE comment_id = $1`, commentID)
+	if err != nil {
+		return false, fmt.Errorf("failed to unpin comment: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// ListPinnedIDs returns the IDs of a clip's pinned comments, most recently
+// pinned first
+func (r *CommentRepository) ListPinnedIDs(ctx context.Context, clipID uuid.UUID) ([]uuid.UUID, error) {
+	query := `SELECT comment_id FROM comment_pins WHERE clip_id = $1 ORDER BY created_at DESC`
+
+	rows, err := r.pool.Query(ctx, query, clipID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pinned comments: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan pinned comment: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
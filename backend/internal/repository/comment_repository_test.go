@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommentRepliesKeyset(t *testing.T) {
+	// Cursors issued before reply sorts existed keep working for top replies
+	assert.Equal(t, CommentRepliesSort, CommentRepliesCursorSort(CommentReplySortTop))
+	assert.Equal(t, CommentRepliesSort, CommentRepliesCursorSort("unknown"))
+
+	// Each order issues its own cursors, so they can't be mixed up
+	sorts := map[string]bool{}
+	for _, sortBy := range []string{CommentReplySortTop, CommentReplySortNew, CommentReplySortControversial} {
+		sorts[CommentRepliesCursorSort(sortBy)] = true
+	}
+	assert.Len(t, sorts, 3)
+
+	assert.Equal(t, "c.created_at DESC, c.id DESC", commentRepliesKeyset(CommentReplySortNew).OrderBy())
+	assert.Contains(t, commentRepliesKeyset(CommentReplySortControversial).OrderBy(), "vc.total_votes")
+}
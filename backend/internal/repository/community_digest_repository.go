@@ -0,0 +1,316 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// Sentinel errors for community digest operations
+var (
+	// ErrCommunityNotFound is returned when a community does not exist
+	ErrCommunityNotFound = errors.New("community not found")
+	// ErrCommunityDigestExists is returned when a community already has a digest for the week
+	ErrCommunityDigestExists = errors.New("community digest already posted for this week")
+)
+
+// notDigestDiscussion excludes digest posts from a community_discussions
+// query aliased cd, so a digest isn't counted as activity
+const notDigestDiscussion = `NOT EXISTS (SELECT 1 FROM community_digests dg WHERE dg.discussion_id = cd.id)`
+
+// CommunityDigestRepository handles database operations for weekly community digests
+type CommunityDigestRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCommunityDigestRepository creates a new CommunityDigestRepository
+func NewCommunityDigestRepository(pool *pgxpool.Pool) *CommunityDigestRepository {
+	return &CommunityDigestRepository{pool: pool}
+}
+
+// GetCommunity returns a community by ID
+func (r *CommunityDigestRepository) GetCommunity(ctx context.Context, communityID uuid.UUID) (*models.Community, error) {
+	query := `
+		SELECT id, name, slug, description, icon, owner_id, is_public, member_count, rules, created_at, updated_at
+		FROM communities
+		WHERE id = $1
+	`
+	community, err := scanDigestCommunity(r.pool.QueryRow(ctx, query, communityID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrCommunityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get community: %w", err)
+	}
+	return community, nil
+}
+
+// GetSettings returns a community's digest settings, or the defaults when
+// the owner never changed them
+func (r *CommunityDigestRepository) GetSettings(ctx context.Context, communityID uuid.UUID) (*models.CommunityDigestSettings, error) {
+	query := `
+		SELECT community_id, enabled, title_template, body_template, updated_at
+		FROM community_digest_settings
+		WHERE community_id = $1
+	`
+	var settings models.CommunityDigestSettings
+	err := r.pool.QueryRow(ctx, query, communityID).Scan(
+		&settings.CommunityID, &settings.Enabled, &settings.TitleTemplate, &settings.BodyTemplate, &settings.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &models.CommunityDigestSettings{CommunityID: communityID, Enabled: true}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get community digest settings: %w", err)
+	}
+	return &settings, nil
+}
+
+// UpsertSettings saves a community's digest settings
+func (r *CommunityDigestRepository) UpsertSettings(ctx context.Context, settings *models.CommunityDigestSettings) error {
+	query := `
+		INSERT INTO community_digest_settings (community_id, enabled, title_template, body_template, updated_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (community_id) DO UPDATE
+		SET enabled = EXCLUDED.enabled,
+			title_template = EXCLUDED.title_template,
+			body_template = EXCLUDED.body_template,
+			updated_at = NOW()
+		RETURNING updated_at
+	`
+	err := r.pool.QueryRow(ctx, query,
+		settings.CommunityID, settings.Enabled, settings.TitleTemplate, settings.BodyTemplate,
+	).Scan(&settings.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save community digest settings: %w", err)
+	}
+	return nil
+}
+
+// ListDueCommunities returns communities that were active in the week
+// starting weekStart, haven't opted out and have no digest for it yet, most
+// members first. A community is active when clips were added, discussions
+// started or members joined during the week.
+func (r *CommunityDigestRepository) ListDueCommunities(ctx context.Context, weekStart time.Time, limit int) ([]models.Community, error) {
+	query := `
+		SELECT c.id, c.name, c.slug, c.description, c.icon, c.owner_id, c.is_public, c.member_count, c.rules, c.created_at, c.updated_at
+		FROM communities c
+		LEFT JOIN community_digest_settings s ON s.community_id = c.id
+		WHERE COALESCE(s.enabled, true)
+			AND NOT EXISTS (
+				SELECT 1 FROM community_digests d WHERE d.community_id = c.id AND d.week_start = $1::date
+			)
+			AND (
+				EXISTS (
+					SELECT 1 FROM community_clips cc
+					WHERE cc.community_id = c.id AND cc.added_at >= $1 AND cc.added_at < $2
+				)
+				OR EXISTS (
+					SELECT 1 FROM community_discussions cd
+					WHERE cd.community_id = c.id AND cd.created_at >= $1 AND cd.created_at < $2 AND ` + notDigestDiscussion + `
+				)
+				OR EXISTS (
+					SELECT 1 FROM community_members cm
+					WHERE cm.community_id = c.id AND cm.joined_at >= $1 AND cm.joined_at < $2
+				)
+			)
+		ORDER BY c.member_count DESC, c.id
+		LIMIT $3
+	`
+	rows, err := r.pool.Query(ctx, query, weekStart, weekStart.AddDate(0, 0, 7), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list communities due a digest: %w", err)
+	}
+	defer rows.Close()
+
+	communities := []models.Community{}
+	for rows.Next() {
+		community, err := scanDigestCommunity(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan community: %w", err)
+		}
+		communities = append(communities, *community)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating communities: %w", err)
+	}
+	return communities, nil
+}
+
+// ListTopClips returns the highest scoring visible clips added to a
+// community in [since, until)
+func (r *CommunityDigestRepository) ListTopClips(ctx context.Context, communityID uuid.UUID, since, until time.Time, limit int) ([]models.CommunityDigestClip, error) {
+	query := `
+		SELECT cl.id, cl.title, cl.broadcaster_name, cl.vote_score
+		FROM community_clips cc
+		JOIN clips cl ON cl.id = cc.clip_id AND cl.is_removed = false AND cl.is_hidden = false
+		WHERE cc.community_id = $1 AND cc.added_at >= $2 AND cc.added_at < $3
+		ORDER BY cl.vote_score DESC, cc.added_at ASC
+		LIMIT $4
+	`
+	rows, err := r.pool.Query(ctx, query, communityID, since, until, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list top community clips: %w", err)
+	}
+	defer rows.Close()
+
+	clips := []models.CommunityDigestClip{}
+	for rows.Next() {
+		var clip models.CommunityDigestClip
+		if err := rows.Scan(&clip.ClipID, &clip.Title, &clip.BroadcasterName, &clip.VoteScore); err != nil {
+			return nil, fmt.Errorf("failed to scan community clip: %w", err)
+		}
+		clips = append(clips, clip)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating community clips: %w", err)
+	}
+	return clips, nil
+}
+
+// ListTopContributors returns the members who added the most clips, started
+// the most discussions and posted the most comments in a community in
+// [since, until). Banned users are left out.
+func (r *CommunityDigestRepository) ListTopContributors(ctx context.Context, communityID uuid.UUID, since, until time.Time, limit int) ([]models.CommunityDigestContributor, error) {
+	query := `
+		WITH activity AS (
+			SELECT cc.added_by_user_id AS user_id, 1 AS clips, 0 AS discussions, 0 AS comments
+			FROM community_clips cc
+			WHERE cc.community_id = $1 AND cc.added_at >= $2 AND cc.added_at < $3 AND cc.added_by_user_id IS NOT NULL
+			UNION ALL
+			SELECT cd.user_id, 0, 1, 0
+			FROM community_discussions cd
+			WHERE cd.community_id = $1 AND cd.created_at >= $2 AND cd.created_at < $3 AND ` + notDigestDiscussion + `
+			UNION ALL
+			SELECT cm.user_id, 0, 0, 1
+			FROM community_discussion_comments cm
+			JOIN community_discussions cd ON cd.id = cm.discussion_id
+			WHERE cd.community_id = $1 AND cm.created_at >= $2 AND cm.created_at < $3 AND cm.is_removed = false
+		)
+		SELECT a.user_id, u.username, SUM(a.clips)::int, SUM(a.discussions)::int, SUM(a.comments)::int
+		FROM activity a
+		JOIN users u ON u.id = a.user_id
+		WHERE NOT EXISTS (
+			SELECT 1 FROM community_bans b WHERE b.community_id = $1 AND b.banned_user_id = a.user_id
+		)
+		GROUP BY a.user_id, u.username
+		ORDER BY SUM(a.clips + a.discussions + a.comments) DESC, u.username ASC
+		LIMIT $4
+	`
+	rows, err := r.pool.Query(ctx, query, communityID, since, until, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list top community contributors: %w", err)
+	}
+	defer rows.Close()
+
+	contributors := []models.CommunityDigestContributor{}
+	for rows.Next() {
+		var c models.CommunityDigestContributor
+		if err := rows.Scan(&c.UserID, &c.Username, &c.Clips, &c.Discussions, &c.Comments); err != nil {
+			return nil, fmt.Errorf("failed to scan community contributor: %w", err)
+		}
+		contributors = append(contributors, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating community contributors: %w", err)
+	}
+	return contributors, nil
+}
+
+// CountNewMembers counts the members who joined a community in [since, until)
+func (r *CommunityDigestRepository) CountNewMembers(ctx context.Context, communityID uuid.UUID, since, until time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM community_members
+		WHERE community_id = $1 AND joined_at >= $2 AND joined_at < $3
+	`
+	var count int
+	if err := r.pool.QueryRow(ctx, query, communityID, since, until).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count new community members: %w", err)
+	}
+	return count, nil
+}
+
+// CreateDigest posts a digest discussion, pinned, in place of the
+// community's previous digest, and records it for the week. It returns
+// ErrCommunityDigestExists, posting nothing, when the week already has one.
+func (r *CommunityDigestRepository) CreateDigest(ctx context.Context, digest *models.CommunityDigest, discussion *models.CommunityDiscussion) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx, `
+		UPDATE community_discussions
+		SET is_pinned = false
+		WHERE is_pinned = true
+			AND id IN (SELECT discussion_id FROM community_digests WHERE community_id = $1)
+	`, digest.CommunityID)
+	if err != nil {
+		return fmt.Errorf("failed to unpin previous community digest: %w", err)
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO community_discussions (community_id, user_id, title, content, is_pinned)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`, discussion.CommunityID, discussion.UserID, discussion.Title, discussion.Content, discussion.IsPinned,
+	).Scan(&discussion.ID, &discussion.CreatedAt, &discussion.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create community digest discussion: %w", err)
+	}
+
+	digest.DiscussionID = discussion.ID
+	err = tx.QueryRow(ctx, `
+		INSERT INTO community_digests (community_id, discussion_id, week_start)
+		VALUES ($1, $2, $3::date)
+		ON CONFLICT (community_id, week_start) DO NOTHING
+		RETURNING id, created_at
+	`, digest.CommunityID, digest.DiscussionID, digest.WeekStart).Scan(&digest.ID, &digest.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrCommunityDigestExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record community digest: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit community digest: %w", err)
+	}
+	return nil
+}
+
+// UnpinExpiredDigests unpins digest discussions posted before the cutoff,
+// returning how many were unpinned
+func (r *CommunityDigestRepository) UnpinExpiredDigests(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE community_discussions
+		SET is_pinned = false
+		WHERE is_pinned = true
+			AND id IN (SELECT discussion_id FROM community_digests WHERE created_at < $1)
+	`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to unpin expired community digests: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+func scanDigestCommunity(row pgx.Row) (*models.Community, error) {
+	var community models.Community
+	err := row.Scan(
+		&community.ID, &community.Name, &community.Slug, &community.Description, &community.Icon,
+		&community.OwnerID, &community.IsPublic, &community.MemberCount, &community.Rules,
+		&community.CreatedAt, &community.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &community, nil
+}
@@ -0,0 +1,462 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// Sentinel errors for community pick operations
+var (
+	// ErrCommunityPickRoundNotFound is returned when a round does not exist
+	ErrCommunityPickRoundNotFound = errors.New("community pick round not found")
+	// ErrCommunityPickRoundNotOpen is returned when a round was already published or cancelled
+	ErrCommunityPickRoundNotOpen = errors.New("community pick round is not open")
+	// ErrCommunityPickNominationNotFound is returned when a nomination does not exist
+	ErrCommunityPickNominationNotFound = errors.New("community pick nomination not found")
+	// ErrCommunityPickClipUnavailable is returned when a nominated clip does not exist or is hidden
+	ErrCommunityPickClipUnavailable = errors.New("clip not found")
+	// ErrCommunityPickAlreadyNominated is returned when a clip is already nominated to a round
+	ErrCommunityPickAlreadyNominated = errors.New("clip is already nominated")
+	// ErrCommunityPickAlreadyVoted is returned when a user already voted for a nomination
+	ErrCommunityPickAlreadyVoted = errors.New("already voted for this nomination")
+	// ErrCommunityPickVoteNotFound is returned when a user has no vote to retract
+	ErrCommunityPickVoteNotFound = errors.New("community pick vote not found")
+)
+
+const communityPickRoundColumns = `
+	id, list_id, theme, description, status, top_n, max_nominations_per_user,
+	max_votes_per_user, voting_ends_at, created_by, published_at, created_at, updated_at`
+
+// CommunityPickRepository handles database operations for community pick rounds
+type CommunityPickRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCommunityPickRepository creates a new CommunityPickRepository
+func NewCommunityPickRepository(pool *pgxpool.Pool) *CommunityPickRepository {
+	return &CommunityPickRepository{pool: pool}
+}
+
+func scanCommunityPickRound(row pgx.Row) (*models.CommunityPickRound, error) {
+	var round models.CommunityPickRound
+	err := row.Scan(
+		&round.ID, &round.ListID, &round.Theme, &round.Description, &round.Status,
+		&round.TopN, &round.MaxNominationsPerUser, &round.MaxVotesPerUser,
+		&round.VotingEndsAt, &round.CreatedBy, &round.PublishedAt, &round.CreatedAt, &round.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &round, nil
+}
+
+// CreateRound starts a round for a curated discovery list
+func (r *CommunityPickRepository) CreateRound(ctx context.Context, round *models.CommunityPickRound) error {
+	query := `
+		INSERT INTO community_pick_rounds (
+			list_id, theme, description, top_n, max_nominations_per_user,
+			max_votes_per_user, voting_ends_at, created_by
+		)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8
+		FROM playlists
+		WHERE id = $1 AND is_curated = true AND deleted_at IS NULL
+		RETURNING id, status, created_at, updated_at
+	`
+
+	err := r.pool.QueryRow(ctx, query,
+		round.ListID, round.Theme, round.Description, round.TopN, round.MaxNominationsPerUser,
+		round.MaxVotesPerUser, round.VotingEndsAt, round.CreatedBy,
+	).Scan(&round.ID, &round.Status, &round.CreatedAt, &round.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrDiscoveryListNotFound
+		}
+		return fmt.Errorf("failed to create community pick round: %w", err)
+	}
+	return nil
+}
+
+// GetRound returns a round by ID
+func (r *CommunityPickRepository) GetRound(ctx context.Context, roundID uuid.UUID) (*models.CommunityPickRound, error) {
+	query := `SELECT ` + communityPickRoundColumns + ` FROM community_pick_rounds WHERE id = $1`
+
+	round, err := scanCommunityPickRound(r.pool.QueryRow(ctx, query, roundID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCommunityPickRoundNotFound
+		}
+		return nil, fmt.Errorf("failed to get community pick round: %w", err)
+	}
+	return round, nil
+}
+
+// ListRoundsForList returns a list's rounds, newest first
+func (r *CommunityPickRepository) ListRoundsForList(ctx context.Context, listID uuid.UUID, limit, offset int) ([]models.CommunityPickRound, error) {
+	query := `
+		SELECT ` + communityPickRoundColumns + `
+		FROM community_pick_rounds
+		WHERE list_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	return r.queryRounds(ctx, query, listID, limit, offset)
+}
+
+// ListDueRounds returns open rounds whose voting ended at or before now
+func (r *CommunityPickRepository) ListDueRounds(ctx context.Context, now time.Time, limit int) ([]models.CommunityPickRound, error) {
+	query := `
+		SELECT ` + communityPickRoundColumns + `
+		FROM community_pick_rounds
+		WHERE status = 'open' AND voting_ends_at <= $1
+		ORDER BY voting_ends_at ASC
+		LIMIT $2
+	`
+	return r.queryRounds(ctx, query, now, limit)
+}
+
+func (r *CommunityPickRepository) queryRounds(ctx context.Context, query string, args ...interface{}) ([]models.CommunityPickRound, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list community pick rounds: %w", err)
+	}
+	defer rows.Close()
+
+	rounds := []models.CommunityPickRound{}
+	for rows.Next() {
+		round, err := scanCommunityPickRound(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan community pick round: %w", err)
+		}
+		rounds = append(rounds, *round)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating community pick rounds: %w", err)
+	}
+	return rounds, nil
+}
+
+// CancelRound closes an open round without publishing it
+func (r *CommunityPickRepository) CancelRound(ctx context.Context, roundID uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE community_pick_rounds
+		SET status = 'cancelled', updated_at = NOW()
+		WHERE id = $1 AND status = 'open'
+	`, roundID)
+	if err != nil {
+		return fmt.Errorf("failed to cancel community pick round: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		if _, err := r.GetRound(ctx, roundID); err != nil {
+			return err
+		}
+		return ErrCommunityPickRoundNotOpen
+	}
+	return nil
+}
+
+// CreateNomination nominates a visible clip to a round
+func (r *CommunityPickRepository) CreateNomination(ctx context.Context, nomination *models.CommunityPickNomination) error {
+	query := `
+		INSERT INTO community_pick_nominations (round_id, clip_id, nominated_by)
+		SELECT $1, c.id, $3
+		FROM clips c
+		WHERE c.id = $2 AND c.is_removed = false AND c.is_hidden = false
+		ON CONFLICT (round_id, clip_id) DO NOTHING
+		RETURNING id, created_at
+	`
+
+	err := r.pool.QueryRow(ctx, query, nomination.RoundID, nomination.ClipID, nomination.NominatedBy).
+		Scan(&nomination.ID, &nomination.CreatedAt)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to create nomination: %w", err)
+	}
+
+	// Nothing was inserted: either the clip is already nominated or it can't be
+	var exists bool
+	err = r.pool.QueryRow(ctx,
+		`SELECT EXISTS(SELECT 1 FROM community_pick_nominations WHERE round_id = $1 AND clip_id = $2)`,
+		nomination.RoundID, nomination.ClipID,
+	).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check nomination: %w", err)
+	}
+	if exists {
+		return ErrCommunityPickAlreadyNominated
+	}
+	return ErrCommunityPickClipUnavailable
+}
+
+// GetNomination returns a nomination by ID
+func (r *CommunityPickRepository) GetNomination(ctx context.Context, nominationID uuid.UUID) (*models.CommunityPickNomination, error) {
+	query := `
+		SELECT n.id, n.round_id, n.clip_id, n.nominated_by, n.created_at,
+			c.title, c.broadcaster_name, c.thumbnail_url
+		FROM community_pick_nominations n
+		JOIN clips c ON c.id = n.clip_id
+		WHERE n.id = $1
+	`
+
+	var n models.CommunityPickNomination
+	err := r.pool.QueryRow(ctx, query, nominationID).Scan(
+		&n.ID, &n.RoundID, &n.ClipID, &n.NominatedBy, &n.CreatedAt,
+		&n.ClipTitle, &n.BroadcasterName, &n.ThumbnailURL,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCommunityPickNominationNotFound
+		}
+		return nil, fmt.Errorf("failed to get nomination: %w", err)
+	}
+	return &n, nil
+}
+
+// ListNominations returns a round's nominations, oldest first. HasVoted is
+// set for userID when given.
+func (r *CommunityPickRepository) ListNominations(ctx context.Context, roundID uuid.UUID, userID *uuid.UUID) ([]models.CommunityPickNomination, error) {
+	query := `
+		SELECT n.id, n.round_id, n.clip_id, n.nominated_by, n.created_at,
+			c.title, c.broadcaster_name, c.thumbnail_url,
+			EXISTS(
+				SELECT 1 FROM community_pick_votes v
+				WHERE v.nomination_id = n.id AND v.user_id = $2
+			) AS has_voted
+		FROM community_pick_nominations n
+		JOIN clips c ON c.id = n.clip_id
+		WHERE n.round_id = $1 AND c.is_removed = false
+		ORDER BY n.created_at ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, roundID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nominations: %w", err)
+	}
+	defer rows.Close()
+
+	nominations := []models.CommunityPickNomination{}
+	for rows.Next() {
+		var n models.CommunityPickNomination
+		if err := rows.Scan(
+			&n.ID, &n.RoundID, &n.ClipID, &n.NominatedBy, &n.CreatedAt,
+			&n.ClipTitle, &n.BroadcasterName, &n.ThumbnailURL, &n.HasVoted,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan nomination: %w", err)
+		}
+		nominations = append(nominations, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating nominations: %w", err)
+	}
+	return nominations, nil
+}
+
+// IsClipInList reports whether a clip is already on a discovery list
+func (r *CommunityPickRepository) IsClipInList(ctx context.Context, listID, clipID uuid.UUID) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx,
+		`SELECT EXISTS(SELECT 1 FROM playlist_items WHERE playlist_id = $1 AND clip_id = $2)`,
+		listID, clipID,
+	).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check list clip: %w", err)
+	}
+	return exists, nil
+}
+
+// CountNominationsByUser counts a user's nominations in a round
+func (r *CommunityPickRepository) CountNominationsByUser(ctx context.Context, roundID, userID uuid.UUID) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM community_pick_nominations WHERE round_id = $1 AND nominated_by = $2`,
+		roundID, userID,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count nominations: %w", err)
+	}
+	return count, nil
+}
+
+// CountVotesByUser counts a user's votes in a round
+func (r *CommunityPickRepository) CountVotesByUser(ctx context.Context, roundID, userID uuid.UUID) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM community_pick_votes WHERE round_id = $1 AND user_id = $2`,
+		roundID, userID,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count votes: %w", err)
+	}
+	return count, nil
+}
+
+// CreateVote records a user's vote for a nomination
+func (r *CommunityPickRepository) CreateVote(ctx context.Context, vote *models.CommunityPickVote) error {
+	query := `
+		INSERT INTO community_pick_votes (round_id, nomination_id, user_id, weight)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (nomination_id, user_id) DO NOTHING
+		RETURNING id, created_at
+	`
+
+	err := r.pool.QueryRow(ctx, query, vote.RoundID, vote.NominationID, vote.UserID, vote.Weight).
+		Scan(&vote.ID, &vote.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrCommunityPickAlreadyVoted
+		}
+		return fmt.Errorf("failed to create vote: %w", err)
+	}
+	return nil
+}
+
+// DeleteVote retracts a user's vote for a nomination
+func (r *CommunityPickRepository) DeleteVote(ctx context.Context, nominationID, userID uuid.UUID) error {
+	result, err := r.pool.Exec(ctx,
+		`DELETE FROM community_pick_votes WHERE nomination_id = $1 AND user_id = $2`,
+		nominationID, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete vote: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrCommunityPickVoteNotFound
+	}
+	return nil
+}
+
+// GetTallies returns the vote totals of every visible nomination in a round
+func (r *CommunityPickRepository) GetTallies(ctx context.Context, roundID uuid.UUID) ([]models.CommunityPickTally, error) {
+	query := `
+		SELECT n.id, n.clip_id, COUNT(v.id), COALESCE(SUM(v.weight), 0)::float8, n.created_at
+		FROM community_pick_nominations n
+		JOIN clips c ON c.id = n.clip_id
+		LEFT JOIN community_pick_votes v ON v.nomination_id = n.id
+		WHERE n.round_id = $1 AND c.is_removed = false AND c.is_hidden = false
+		GROUP BY n.id
+	`
+
+	rows, err := r.pool.Query(ctx, query, roundID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to tally votes: %w", err)
+	}
+	defer rows.Close()
+
+	tallies := []models.CommunityPickTally{}
+	for rows.Next() {
+		var t models.CommunityPickTally
+		if err := rows.Scan(&t.NominationID, &t.ClipID, &t.VoteCount, &t.WeightedScore, &t.NominatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan tally: %w", err)
+		}
+		tallies = append(tallies, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tallies: %w", err)
+	}
+	return tallies, nil
+}
+
+// PublishRound archives a round's results and appends its picked clips to
+// the list, in rank order. Only one caller can publish a round.
+func (r *CommunityPickRepository) PublishRound(ctx context.Context, round *models.CommunityPickRound, results []models.CommunityPickResult) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	result, err := tx.Exec(ctx, `
+		UPDATE community_pick_rounds
+		SET status = 'published', published_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = 'open'
+	`, round.ID)
+	if err != nil {
+		return fmt.Errorf("failed to publish community pick round: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrCommunityPickRoundNotOpen
+	}
+
+	var maxOrder int
+	err = tx.QueryRow(ctx,
+		"SELECT COALESCE(MAX(order_index), -1) FROM playlist_items WHERE playlist_id = $1", round.ListID).Scan(&maxOrder)
+	if err != nil {
+		return fmt.Errorf("failed to get max display order: %w", err)
+	}
+
+	for _, res := range results {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO community_pick_results (round_id, clip_id, rank, vote_count, weighted_score, picked)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, round.ID, res.ClipID, res.Rank, res.VoteCount, res.WeightedScore, res.Picked)
+		if err != nil {
+			return fmt.Errorf("failed to save community pick result: %w", err)
+		}
+
+		if !res.Picked {
+			continue
+		}
+		tag, err := tx.Exec(ctx, `
+			INSERT INTO playlist_items (playlist_id, clip_id, order_index)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (playlist_id, clip_id) DO NOTHING
+		`, round.ListID, res.ClipID, maxOrder+1)
+		if err != nil {
+			return fmt.Errorf("failed to add picked clip to list: %w", err)
+		}
+		if tag.RowsAffected() > 0 {
+			maxOrder++
+		}
+	}
+
+	if _, err := tx.Exec(ctx, "UPDATE playlists SET updated_at = NOW() WHERE id = $1", round.ListID); err != nil {
+		return fmt.Errorf("failed to update list timestamp: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit community pick results: %w", err)
+	}
+	return nil
+}
+
+// ListResults returns a published round's standings by rank
+func (r *CommunityPickRepository) ListResults(ctx context.Context, roundID uuid.UUID) ([]models.CommunityPickResult, error) {
+	query := `
+		SELECT res.round_id, res.clip_id, res.rank, res.vote_count, res.weighted_score::float8, res.picked,
+			c.title, c.broadcaster_name, c.thumbnail_url
+		FROM community_pick_results res
+		JOIN clips c ON c.id = res.clip_id
+		WHERE res.round_id = $1
+		ORDER BY res.rank ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, roundID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list community pick results: %w", err)
+	}
+	defer rows.Close()
+
+	results := []models.CommunityPickResult{}
+	for rows.Next() {
+		var res models.CommunityPickResult
+		if err := rows.Scan(
+			&res.RoundID, &res.ClipID, &res.Rank, &res.VoteCount, &res.WeightedScore, &res.Picked,
+			&res.ClipTitle, &res.BroadcasterName, &res.ThumbnailURL,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan community pick result: %w", err)
+		}
+		results = append(results, res)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating community pick results: %w", err)
+	}
+	return results, nil
+}
@@ -529,12 +529,12 @@ func (r *CommunityRepository) GetCommunityClips(ctx context.Context, communityID
 // CreateDiscussion creates a new discussion thread
 func (r *CommunityRepository) CreateDiscussion(ctx context.Context, discussion *models.CommunityDiscussion) error {
 	query := `
-		INSERT INTO community_discussions (id, community_id, user_id, title, content, is_pinned, is_resolved, vote_score, comment_count, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		INSERT INTO community_discussions (id, community_id, user_id, title, content, rendered_content, is_pinned, is_resolved, vote_score, comment_count, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 		RETURNING id, created_at, updated_at
 	`
 	return r.pool.QueryRow(ctx, query,
-		discussion.ID, discussion.CommunityID, discussion.UserID, discussion.Title, discussion.Content,
+		discussion.ID, discussion.CommunityID, discussion.UserID, discussion.Title, discussion.Content, discussion.RenderedContent,
 		discussion.IsPinned, discussion.IsResolved, discussion.VoteScore, discussion.CommentCount,
 		discussion.CreatedAt, discussion.UpdatedAt,
 	).Scan(&discussion.ID, &discussion.CreatedAt, &discussion.UpdatedAt)
@@ -543,13 +543,13 @@ func (r *CommunityRepository) CreateDiscussion(ctx context.Context, discussion *
 // GetDiscussion retrieves a discussion thread by ID
 func (r *CommunityRepository) GetDiscussion(ctx context.Context, id uuid.UUID) (*models.CommunityDiscussion, error) {
 	query := `
-		SELECT id, community_id, user_id, title, content, is_pinned, is_resolved, vote_score, comment_count, created_at, updated_at
+		SELECT id, community_id, user_id, title, content, COALESCE(rendered_content, ''), is_pinned, is_resolved, vote_score, comment_count, created_at, updated_at
 		FROM community_discussions
 		WHERE id = $1
 	`
 	discussion := &models.CommunityDiscussion{}
 	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&discussion.ID, &discussion.CommunityID, &discussion.UserID, &discussion.Title, &discussion.Content,
+		&discussion.ID, &discussion.CommunityID, &discussion.UserID, &discussion.Title, &discussion.Content, &discussion.RenderedContent,
 		&discussion.IsPinned, &discussion.IsResolved, &discussion.VoteScore, &discussion.CommentCount,
 		&discussion.CreatedAt, &discussion.UpdatedAt,
 	)
@@ -580,7 +580,7 @@ func (r *CommunityRepository) ListDiscussions(ctx context.Context, communityID u
 
 	// Query discussions
 	query := fmt.Sprintf(`
-		SELECT id, community_id, user_id, title, content, is_pinned, is_resolved, vote_score, comment_count, created_at, updated_at
+		SELECT id, community_id, user_id, title, content, COALESCE(rendered_content, ''), is_pinned, is_resolved, vote_score, comment_count, created_at, updated_at
 		FROM community_discussions
 		WHERE community_id = $1
 		%s
@@ -597,7 +597,7 @@ func (r *CommunityRepository) ListDiscussions(ctx context.Context, communityID u
 	for rows.Next() {
 		discussion := &models.CommunityDiscussion{}
 		err := rows.Scan(
-			&discussion.ID, &discussion.CommunityID, &discussion.UserID, &discussion.Title, &discussion.Content,
+			&discussion.ID, &discussion.CommunityID, &discussion.UserID, &discussion.Title, &discussion.Content, &discussion.RenderedContent,
 			&discussion.IsPinned, &discussion.IsResolved, &discussion.VoteScore, &discussion.CommentCount,
 			&discussion.CreatedAt, &discussion.UpdatedAt,
 		)
@@ -613,12 +613,12 @@ func (r *CommunityRepository) ListDiscussions(ctx context.Context, communityID u
 func (r *CommunityRepository) UpdateDiscussion(ctx context.Context, discussion *models.CommunityDiscussion) error {
 	query := `
 		UPDATE community_discussions
-		SET title = $2, content = $3, is_pinned = $4, is_resolved = $5, updated_at = NOW()
+		SET title = $2, content = $3, rendered_content = $4, is_pinned = $5, is_resolved = $6, updated_at = NOW()
 		WHERE id = $1
 		RETURNING updated_at
 	`
 	return r.pool.QueryRow(ctx, query,
-		discussion.ID, discussion.Title, discussion.Content, discussion.IsPinned, discussion.IsResolved,
+		discussion.ID, discussion.Title, discussion.Content, discussion.RenderedContent, discussion.IsPinned, discussion.IsResolved,
 	).Scan(&discussion.UpdatedAt)
 }
 
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// CommunityToxicityRepository handles database operations for per-community toxicity settings
+type CommunityToxicityRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCommunityToxicityRepository creates a new CommunityToxicityRepository
+func NewCommunityToxicityRepository(pool *pgxpool.Pool) *CommunityToxicityRepository {
+	return &CommunityToxicityRepository{pool: pool}
+}
+
+// GetCommunityOwner returns a community's owner
+func (r *CommunityToxicityRepository) GetCommunityOwner(ctx context.Context, communityID uuid.UUID) (uuid.UUID, error) {
+	var ownerID uuid.UUID
+	err := r.pool.QueryRow(ctx, `SELECT owner_id FROM communities WHERE id = $1`, communityID).Scan(&ownerID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, ErrCommunityNotFound
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to get community: %w", err)
+	}
+	return ownerID, nil
+}
+
+// GetMemberRole returns a user's role in a community, or "" for non-members
+func (r *CommunityToxicityRepository) GetMemberRole(ctx context.Context, communityID, userID uuid.UUID) (string, error) {
+	var role string
+	err := r.pool.QueryRow(ctx, `
+		SELECT role FROM community_members WHERE community_id = $1 AND user_id = $2
+	`, communityID, userID).Scan(&role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get community member role: %w", err)
+	}
+	return role, nil
+}
+
+// GetSettings returns a community's toxicity settings, or medium
+// sensitivity when they were never changed
+func (r *CommunityToxicityRepository) GetSettings(ctx context.Context, communityID uuid.UUID) (*models.CommunityToxicitySettings, error) {
+	settings := models.CommunityToxicitySettings{CommunityID: communityID}
+	err := r.pool.QueryRow(ctx, `
+		SELECT sensitivity, updated_by, updated_at
+		FROM community_toxicity_settings
+		WHERE community_id = $1
+	`, communityID).Scan(&settings.Sensitivity, &settings.UpdatedBy, &settings.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		settings.Sensitivity = models.ToxicitySensitivityMedium
+		return &settings, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get community toxicity settings: %w", err)
+	}
+	return &settings, nil
+}
+
+// UpsertSettings saves a community's toxicity settings
+func (r *CommunityToxicityRepository) UpsertSettings(ctx context.Context, settings *models.CommunityToxicitySettings) error {
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO community_toxicity_settings (community_id, sensitivity, updated_by, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (community_id) DO UPDATE
+		SET sensitivity = EXCLUDED.sensitivity,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+		RETURNING updated_at
+	`, settings.CommunityID, settings.Sensitivity, settings.UpdatedBy).Scan(&settings.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save community toxicity settings: %w", err)
+	}
+	return nil
+}
+
+// ListClipSensitivities returns the toxicity sensitivity of each community a
+// clip is in
+func (r *CommunityToxicityRepository) ListClipSensitivities(ctx context.Context, clipID uuid.UUID) ([]string, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT COALESCE(cts.sensitivity, 'medium')
+		FROM community_clips cc
+		LEFT JOIN community_toxicity_settings cts ON cts.community_id = cc.community_id
+		WHERE cc.clip_id = $1
+	`, clipID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clip community sensitivities: %w", err)
+	}
+	defer rows.Close()
+
+	var sensitivities []string
+	for rows.Next() {
+		var sensitivity string
+		if err := rows.Scan(&sensitivity); err != nil {
+			return nil, fmt.Errorf("failed to scan community sensitivity: %w", err)
+		}
+		sensitivities = append(sensitivities, sensitivity)
+	}
+	return sensitivities, rows.Err()
+}
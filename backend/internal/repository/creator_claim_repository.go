@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// ErrCreatorClaimNotFound is returned when no creator claim matches the ID
+var ErrCreatorClaimNotFound = errors.New("creator claim not found")
+
+const creatorClaimColumns = `id, user_id, twitch_creator_id, twitch_login, verification_code, status,
+	expires_at, verified_at, created_at, updated_at`
+
+// CreatorClaimRepository handles database operations for claims to Twitch creators' clips
+type CreatorClaimRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewCreatorClaimRepository creates a new creator claim repository
+func NewCreatorClaimRepository(db *pgxpool.Pool) *CreatorClaimRepository {
+	return &CreatorClaimRepository{db: db}
+}
+
+func scanCreatorClaim(row pgx.Row) (*models.CreatorClaim, error) {
+	var claim models.CreatorClaim
+	err := row.Scan(
+		&claim.ID,
+		&claim.UserID,
+		&claim.TwitchCreatorID,
+		&claim.TwitchLogin,
+		&claim.VerificationCode,
+		&claim.Status,
+		&claim.ExpiresAt,
+		&claim.VerifiedAt,
+		&claim.CreatedAt,
+		&claim.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &claim, nil
+}
+
+// Create stores a new pending claim, expiring the user's earlier pending
+// claims to the same creator
+func (r *CreatorClaimRepository) Create(ctx context.Context, claim *models.CreatorClaim) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		UPDATE creator_claims
+		SET status = 'expired', updated_at = NOW()
+		WHERE user_id = $1 AND twitch_creator_id = $2 AND status = 'pending'
+	`, claim.UserID, claim.TwitchCreatorID)
+	if err != nil {
+		return fmt.Errorf("failed to expire earlier creator claims: %w", err)
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO creator_claims (user_id, twitch_creator_id, twitch_login, verification_code, status, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at
+	`, claim.UserID, claim.TwitchCreatorID, claim.TwitchLogin, claim.VerificationCode, claim.Status, claim.ExpiresAt,
+	).Scan(&claim.ID, &claim.CreatedAt, &claim.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create creator claim: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit creator claim: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a claim by ID
+func (r *CreatorClaimRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.CreatorClaim, error) {
+	query := `SELECT ` + creatorClaimColumns + ` FROM creator_claims WHERE id = $1`
+
+	claim, err := scanCreatorClaim(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCreatorClaimNotFound
+		}
+		return nil, fmt.Errorf("failed to get creator claim: %w", err)
+	}
+
+	return claim, nil
+}
+
+// ListByUser returns a user's claims, newest first
+func (r *CreatorClaimRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]models.CreatorClaim, error) {
+	query := `SELECT ` + creatorClaimColumns + ` FROM creator_claims WHERE user_id = $1 ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list creator claims: %w", err)
+	}
+	defer rows.Close()
+
+	claims := []models.CreatorClaim{}
+	for rows.Next() {
+		claim, err := scanCreatorClaim(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan creator claim: %w", err)
+		}
+		claims = append(claims, *claim)
+	}
+
+	return claims, rows.Err()
+}
+
+// MarkVerified verifies a pending claim, superseding any other user's
+// verified claim to the same creator, so attribution moves to this user
+func (r *CreatorClaimRepository) MarkVerified(ctx context.Context, id uuid.UUID) (*models.CreatorClaim, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		UPDATE creator_claims
+		SET status = 'superseded', updated_at = NOW()
+		WHERE status = 'verified'
+			AND twitch_creator_id = (SELECT twitch_creator_id FROM creator_claims WHERE id = $1)
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to supersede creator claims: %w", err)
+	}
+
+	claim, err := scanCreatorClaim(tx.QueryRow(ctx, `
+		UPDATE creator_claims
+		SET status = 'verified', verified_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+		RETURNING `+creatorClaimColumns, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCreatorClaimNotFound
+		}
+		return nil, fmt.Errorf("failed to verify creator claim: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit creator claim: %w", err)
+	}
+
+	return claim, nil
+}
+
+// MarkExpired expires a pending claim
+func (r *CreatorClaimRepository) MarkExpired(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE creator_claims
+		SET status = 'expired', updated_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to expire creator claim: %w", err)
+	}
+	return nil
+}
+
+// HasVerifiedClaim reports whether the user holds the verified claim to a creator
+func (r *CreatorClaimRepository) HasVerifiedClaim(ctx context.Context, userID uuid.UUID, twitchCreatorID string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM creator_claims
+			WHERE user_id = $1 AND twitch_creator_id = $2 AND status = 'verified'
+		)
+	`, userID, twitchCreatorID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check creator claim: %w", err)
+	}
+	return exists, nil
+}
+
+// CountCreatorClips counts the clips a Twitch user created
+func (r *CreatorClaimRepository) CountCreatorClips(ctx context.Context, twitchCreatorID string) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM clips WHERE creator_id = $1`, twitchCreatorID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count creator clips: %w", err)
+	}
+	return count, nil
+}
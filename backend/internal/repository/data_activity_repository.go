@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// AuditActionDataExportGenerated is the audit log action recorded when a user
+// downloads an export of their account data
+const AuditActionDataExportGenerated = "data_export_generated"
+
+// dataActivityMaxAccountEvents caps the account events listed in a user's data activity
+const dataActivityMaxAccountEvents = 100
+
+// DataActivityRepository reads the records of processing on a user's data
+// from the email, export, analytics, ad and audit tables
+type DataActivityRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewDataActivityRepository creates a new DataActivityRepository
+func NewDataActivityRepository(pool *pgxpool.Pool) *DataActivityRepository {
+	return &DataActivityRepository{pool: pool}
+}
+
+// ListEmailTypes counts the emails sent to a user by notification type, most
+// recently sent first
+func (r *DataActivityRepository) ListEmailTypes(ctx context.Context, userID uuid.UUID) ([]models.DataActivityEmailType, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT notification_type, COUNT(*), MAX(COALESCE(sent_at, created_at))
+		FROM email_notification_logs
+		WHERE user_id = $1 AND status = 'sent'
+		GROUP BY notification_type
+		ORDER BY MAX(COALESCE(sent_at, created_at)) DESC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count sent emails: %w", err)
+	}
+	defer rows.Close()
+
+	types := []models.DataActivityEmailType{}
+	for rows.Next() {
+		var t models.DataActivityEmailType
+		if err := rows.Scan(&t.Type, &t.Count, &t.LastSentAt); err != nil {
+			return nil, fmt.Errorf("failed to scan sent email count: %w", err)
+		}
+		types = append(types, t)
+	}
+	return types, rows.Err()
+}
+
+// ListExports returns the account data exports a user downloaded and the
+// creator analytics exports they requested, newest first
+func (r *DataActivityRepository) ListExports(ctx context.Context, userID uuid.UUID) ([]models.DataActivityExport, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT $3::text, 'zip', 'completed', created_at, created_at
+		FROM moderation_audit_logs
+		WHERE entity_type = 'user' AND entity_id = $1 AND action = $2
+		UNION ALL
+		SELECT $4::text, format, status, created_at, completed_at
+		FROM export_requests
+		WHERE user_id = $1
+		ORDER BY 4 DESC
+	`, userID, AuditActionDataExportGenerated, models.DataExportKindAccountData, models.DataExportKindCreatorAnalytics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list data exports: %w", err)
+	}
+	defer rows.Close()
+
+	exports := []models.DataActivityExport{}
+	for rows.Next() {
+		var e models.DataActivityExport
+		if err := rows.Scan(&e.Kind, &e.Format, &e.Status, &e.RequestedAt, &e.CompletedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan data export: %w", err)
+		}
+		exports = append(exports, e)
+	}
+	return exports, rows.Err()
+}
+
+// ListAnalyticsCategories counts the analytics records kept about a user by
+// category. Categories with no records are left out.
+func (r *DataActivityRepository) ListAnalyticsCategories(ctx context.Context, userID uuid.UUID) ([]models.DataActivityCategory, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT category, records, first_collected_at, last_collected_at
+		FROM (
+			SELECT 'engagement_events' AS category, COUNT(*) AS records,
+			       MIN(created_at) AS first_collected_at, MAX(created_at) AS last_collected_at
+			FROM analytics_events WHERE user_id = $1
+			UNION ALL
+			SELECT 'feed_events', COUNT(*), MIN(timestamp), MAX(timestamp)
+			FROM events WHERE user_id = $1
+			UNION ALL
+			SELECT 'search_history', COUNT(*), MIN(created_at), MAX(created_at)
+			FROM search_queries WHERE user_id = $1
+			UNION ALL
+			SELECT 'search_clicks', COUNT(*), MIN(created_at), MAX(created_at)
+			FROM search_clicks WHERE user_id = $1
+			UNION ALL
+			SELECT 'watch_history', COUNT(*), MIN(watched_at), MAX(watched_at)
+			FROM watch_history WHERE user_id = $1
+		) categories
+		WHERE records > 0
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count analytics records: %w", err)
+	}
+	defer rows.Close()
+
+	categories := []models.DataActivityCategory{}
+	for rows.Next() {
+		var c models.DataActivityCategory
+		if err := rows.Scan(&c.Category, &c.Records, &c.FirstCollectedAt, &c.LastCollectedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan analytics category: %w", err)
+		}
+		categories = append(categories, c)
+	}
+	return categories, rows.Err()
+}
+
+// CountAdImpressions returns how many ads were shown to a user and when the
+// last one was
+func (r *DataActivityRepository) CountAdImpressions(ctx context.Context, userID uuid.UUID) (int, *time.Time, error) {
+	var count int
+	var last *time.Time
+	err := r.pool.QueryRow(ctx, `
+		SELECT COUNT(*), MAX(created_at) FROM ad_impressions WHERE user_id = $1
+	`, userID).Scan(&count, &last)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to count ad impressions: %w", err)
+	}
+	return count, last, nil
+}
+
+// ListAccountEvents returns the audited actions on a user's account and
+// subscription, newest first. Data exports are listed by ListExports instead,
+// and shadow bans are left out.
+func (r *DataActivityRepository) ListAccountEvents(ctx context.Context, userID uuid.UUID) ([]models.DataActivityEvent, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT action, created_at
+		FROM moderation_audit_logs
+		WHERE entity_type IN ('user', 'subscription') AND entity_id = $1 AND action <> ALL($2)
+		ORDER BY created_at DESC
+		LIMIT $3
+	`, userID, []string{AuditActionDataExportGenerated, AuditActionShadowBanUser, AuditActionLiftShadowBan}, dataActivityMaxAccountEvents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list account events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []models.DataActivityEvent{}
+	for rows.Next() {
+		var e models.DataActivityEvent
+		if err := rows.Scan(&e.Action, &e.OccurredAt); err != nil {
+			return nil, fmt.Errorf("failed to scan account event: %w", err)
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// DeveloperUsageRepository handles database operations for developer usage reports
+type DeveloperUsageRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewDeveloperUsageRepository creates a new developer usage repository
+func NewDeveloperUsageRepository(db *pgxpool.Pool) *DeveloperUsageRepository {
+	return &DeveloperUsageRepository{db: db}
+}
+
+// AddAPIKeyUsage adds counts to the hourly usage rollups of API keys
+func (r *DeveloperUsageRepository) AddAPIKeyUsage(ctx context.Context, buckets []models.APIKeyUsageBucket) error {
+	if len(buckets) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO api_key_usage_hourly (api_key_id, hour, request_count, error_count, rate_limited_count)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (api_key_id, hour) DO UPDATE SET
+			request_count = api_key_usage_hourly.request_count + EXCLUDED.request_count,
+			error_count = api_key_usage_hourly.error_count + EXCLUDED.error_count,
+			rate_limited_count = api_key_usage_hourly.rate_limited_count + EXCLUDED.rate_limited_count
+	`
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, bucket := range buckets {
+		_, err := tx.Exec(ctx, query, bucket.APIKeyID, bucket.Hour, bucket.Requests, bucket.Errors, bucket.RateLimited)
+		if err != nil {
+			return fmt.Errorf("failed to add api key usage: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit api key usage: %w", err)
+	}
+
+	return nil
+}
+
+// ListAPIKeyUsage returns the hourly usage of a user's API keys since the given hour, oldest first
+func (r *DeveloperUsageRepository) ListAPIKeyUsage(ctx context.Context, userID uuid.UUID, since time.Time) ([]models.APIKeyUsageBucket, error) {
+	query := `
+		SELECT u.api_key_id, u.hour, u.request_count, u.error_count, u.rate_limited_count
+		FROM api_key_usage_hourly u
+		JOIN api_keys k ON k.id = u.api_key_id
+		WHERE k.user_id = $1 AND u.hour >= $2
+		ORDER BY u.hour ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api key usage: %w", err)
+	}
+	defer rows.Close()
+
+	buckets := []models.APIKeyUsageBucket{}
+	for rows.Next() {
+		var bucket models.APIKeyUsageBucket
+		if err := rows.Scan(&bucket.APIKeyID, &bucket.Hour, &bucket.Requests, &bucket.Errors, &bucket.RateLimited); err != nil {
+			return nil, fmt.Errorf("failed to scan api key usage: %w", err)
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets, rows.Err()
+}
+
+// ListWebhookDeliveryUsage returns a user's webhook deliveries grouped by
+// subscription and hour since the given hour, oldest first
+func (r *DeveloperUsageRepository) ListWebhookDeliveryUsage(ctx context.Context, userID uuid.UUID, since time.Time) ([]models.WebhookDeliveryBucket, error) {
+	query := `
+		SELECT
+			d.subscription_id,
+			date_trunc('hour', d.created_at) AS hour,
+			COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE d.status = 'delivered') AS delivered,
+			COUNT(*) FILTER (WHERE d.status = 'failed') AS failed,
+			COUNT(*) FILTER (WHERE d.status = 'pending') AS pending
+		FROM webhook_deliveries d
+		JOIN webhook_subscriptions s ON s.id = d.subscription_id
+		WHERE s.user_id = $1 AND d.created_at >= $2
+		GROUP BY d.subscription_id, hour
+		ORDER BY hour ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook delivery usage: %w", err)
+	}
+	defer rows.Close()
+
+	buckets := []models.WebhookDeliveryBucket{}
+	for rows.Next() {
+		var bucket models.WebhookDeliveryBucket
+		if err := rows.Scan(&bucket.SubscriptionID, &bucket.Hour, &bucket.Total, &bucket.Delivered, &bucket.Failed, &bucket.Pending); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery usage: %w", err)
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets, rows.Err()
+}
+
+// DeleteAPIKeyUsageBefore removes hourly usage rollups older than the given time
+func (r *DeveloperUsageRepository) DeleteAPIKeyUsageBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.Exec(ctx, `DELETE FROM api_key_usage_hourly WHERE hour < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete api key usage: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
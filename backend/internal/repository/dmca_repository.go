@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -11,6 +13,17 @@ import (
 	"github.com/subculture-collective/clipper/internal/models"
 )
 
+var (
+	// ErrDMCANoticeNotFound is returned when a DMCA notice doesn't exist
+	ErrDMCANoticeNotFound = errors.New("DMCA notice not found")
+	// ErrDMCACounterNoticeNotFound is returned when a counter-notice doesn't exist
+	ErrDMCACounterNoticeNotFound = errors.New("counter-notice not found")
+	// ErrDMCAStrikeNotFound is returned when a strike doesn't exist or is no longer active
+	ErrDMCAStrikeNotFound = errors.New("active DMCA strike not found")
+	// ErrDMCAClipNotRemoved is returned when a clip wasn't taken down under a notice
+	ErrDMCAClipNotRemoved = errors.New("clip was not removed under this DMCA notice")
+)
+
 // DMCARepository handles database operations for DMCA notices, counter-notices, and strikes
 type DMCARepository struct {
 	db *pgxpool.Pool
@@ -92,7 +105,7 @@ func (r *DMCARepository) GetNoticeByID(ctx context.Context, id uuid.UUID) (*mode
 	)
 
 	if err == pgx.ErrNoRows {
-		return nil, fmt.Errorf("DMCA notice not found")
+		return nil, ErrDMCANoticeNotFound
 	}
 	return notice, err
 }
@@ -270,7 +283,7 @@ func (r *DMCARepository) GetCounterNoticeByID(ctx context.Context, id uuid.UUID)
 	)
 
 	if err == pgx.ErrNoRows {
-		return nil, fmt.Errorf("counter-notice not found")
+		return nil, ErrDMCACounterNoticeNotFound
 	}
 	return cn, err
 }
@@ -365,15 +378,129 @@ func (r *DMCARepository) UpdateCounterNoticeStatus(ctx context.Context, id uuid.
 	return err
 }
 
-// MarkCounterNoticeForwarded marks a counter-notice as forwarded to complainant
-func (r *DMCARepository) MarkCounterNoticeForwarded(ctx context.Context, id uuid.UUID) error {
+// MarkCounterNoticeForwarded marks a pending counter-notice as forwarded to
+// the complainant and starts its waiting period. It reports false when the
+// counter-notice is no longer pending.
+func (r *DMCARepository) MarkCounterNoticeForwarded(ctx context.Context, id uuid.UUID, waitingPeriodEnds time.Time) (bool, error) {
 	query := `
 		UPDATE dmca_counter_notices
-		SET status = 'forwarded', forwarded_at = NOW(), updated_at = NOW()
-		WHERE id = $1`
+		SET status = 'waiting', forwarded_at = NOW(), waiting_period_ends = $2, updated_at = NOW()
+		WHERE id = $1 AND status = 'pending'`
 
-	_, err := r.db.Exec(ctx, query, id)
-	return err
+	result, err := r.db.Exec(ctx, query, id, waitingPeriodEnds)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// TransitionCounterNoticeStatus moves a counter-notice from one status to
+// another. It reports false when the counter-notice is no longer in the
+// expected status.
+func (r *DMCARepository) TransitionCounterNoticeStatus(ctx context.Context, id uuid.UUID, from, to string, notes *string) (bool, error) {
+	query := `
+		UPDATE dmca_counter_notices
+		SET status = $3, notes = COALESCE($4, notes), updated_at = NOW()
+		WHERE id = $1 AND status = $2`
+
+	result, err := r.db.Exec(ctx, query, id, from, to, notes)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// MarkLawsuitFiled records that the complainant filed suit over a
+// counter-notice in its waiting period, which keeps the content removed. It
+// reports false when the counter-notice isn't waiting.
+func (r *DMCARepository) MarkLawsuitFiled(ctx context.Context, id uuid.UUID, notes *string) (bool, error) {
+	query := `
+		UPDATE dmca_counter_notices
+		SET lawsuit_filed = true,
+		    lawsuit_filed_at = NOW(),
+		    status = 'rejected',
+		    notes = COALESCE($2, notes),
+		    updated_at = NOW()
+		WHERE id = $1 AND status = 'waiting'`
+
+	result, err := r.db.Exec(ctx, query, id, notes)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// ListCounterNoticesByNotice returns the counter-notices filed against a notice, oldest first
+func (r *DMCARepository) ListCounterNoticesByNotice(ctx context.Context, noticeID uuid.UUID) ([]models.DMCACounterNotice, error) {
+	query := `
+		SELECT id, dmca_notice_id, user_id, user_name, user_email, user_address, user_phone,
+			removed_material_url, removed_material_description,
+			good_faith_statement, consent_to_jurisdiction, consent_to_service,
+			signature, submitted_at, forwarded_at, waiting_period_ends,
+			status, lawsuit_filed, lawsuit_filed_at, notes,
+			ip_address::text AS ip_address, user_agent, created_at, updated_at
+		FROM dmca_counter_notices
+		WHERE dmca_notice_id = $1
+		ORDER BY submitted_at ASC`
+
+	rows, err := r.db.Query(ctx, query, noticeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counterNotices := []models.DMCACounterNotice{}
+	for rows.Next() {
+		cn := models.DMCACounterNotice{}
+		err := rows.Scan(
+			&cn.ID,
+			&cn.DMCANoticeID,
+			&cn.UserID,
+			&cn.UserName,
+			&cn.UserEmail,
+			&cn.UserAddress,
+			&cn.UserPhone,
+			&cn.RemovedMaterialURL,
+			&cn.RemovedMaterialDescription,
+			&cn.GoodFaithStatement,
+			&cn.ConsentToJurisdiction,
+			&cn.ConsentToService,
+			&cn.Signature,
+			&cn.SubmittedAt,
+			&cn.ForwardedAt,
+			&cn.WaitingPeriodEnds,
+			&cn.Status,
+			&cn.LawsuitFiled,
+			&cn.LawsuitFiledAt,
+			&cn.Notes,
+			&cn.IPAddress,
+			&cn.UserAgent,
+			&cn.CreatedAt,
+			&cn.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		counterNotices = append(counterNotices, cn)
+	}
+
+	return counterNotices, rows.Err()
+}
+
+// GetRemovedClipSubmitter returns who submitted a clip taken down under a
+// notice, or ErrDMCAClipNotRemoved when the clip isn't removed under it
+func (r *DMCARepository) GetRemovedClipSubmitter(ctx context.Context, clipID, noticeID uuid.UUID) (*uuid.UUID, error) {
+	query := `
+		SELECT submitted_by_user_id
+		FROM clips
+		WHERE id = $1 AND dmca_notice_id = $2 AND dmca_removed = true`
+
+	var submittedBy *uuid.UUID
+	err := r.db.QueryRow(ctx, query, clipID, noticeID).Scan(&submittedBy)
+	if err == pgx.ErrNoRows {
+		return nil, ErrDMCAClipNotRemoved
+	}
+	return submittedBy, err
 }
 
 // GetCounterNoticesAwaitingRestore returns counter-notices past their waiting period
@@ -552,31 +679,62 @@ func (r *DMCARepository) GetUserAllStrikes(ctx context.Context, userID uuid.UUID
 	return strikes, rows.Err()
 }
 
-// RemoveStrike removes a strike (marks as removed with reason)
-func (r *DMCARepository) RemoveStrike(ctx context.Context, strikeID uuid.UUID, reason string) error {
+// RemoveStrike removes an active strike, recording why
+func (r *DMCARepository) RemoveStrike(ctx context.Context, strikeID uuid.UUID, reason string) (*models.DMCAStrike, error) {
 	query := `
 		UPDATE dmca_strikes
 		SET status = 'removed', removal_reason = $1, removed_at = NOW(), updated_at = NOW()
-		WHERE id = $2`
-
-	_, err := r.db.Exec(ctx, query, reason, strikeID)
-	return err
+		WHERE id = $2 AND status = 'active'
+		RETURNING id, user_id, dmca_notice_id, strike_number, expires_at`
+
+	strike := &models.DMCAStrike{Status: "removed", RemovalReason: &reason}
+	err := r.db.QueryRow(ctx, query, reason, strikeID).Scan(
+		&strike.ID,
+		&strike.UserID,
+		&strike.DMCANoticeID,
+		&strike.StrikeNumber,
+		&strike.ExpiresAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, ErrDMCAStrikeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return strike, nil
 }
 
-// ExpireOldStrikes marks strikes older than 12 months as expired
-func (r *DMCARepository) ExpireOldStrikes(ctx context.Context) (int, error) {
+// ExpireOldStrikes marks active strikes past their expiry as expired and
+// returns the strikes it expired
+func (r *DMCARepository) ExpireOldStrikes(ctx context.Context) ([]models.DMCAStrike, error) {
 	query := `
 		UPDATE dmca_strikes
 		SET status = 'expired', removal_reason = 'expired', updated_at = NOW()
-		WHERE status = 'active' AND expires_at <= NOW()`
+		WHERE status = 'active' AND expires_at <= NOW()
+		RETURNING id, user_id, dmca_notice_id, strike_number, expires_at`
 
-	result, err := r.db.Exec(ctx, query)
+	rows, err := r.db.Query(ctx, query)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
+	defer rows.Close()
 
-	count := result.RowsAffected()
-	return int(count), nil
+	strikes := []models.DMCAStrike{}
+	for rows.Next() {
+		strike := models.DMCAStrike{Status: "expired"}
+		if err := rows.Scan(
+			&strike.ID,
+			&strike.UserID,
+			&strike.DMCANoticeID,
+			&strike.StrikeNumber,
+			&strike.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		strikes = append(strikes, strike)
+	}
+
+	return strikes, rows.Err()
 }
 
 // GetUsersWithStrikes returns users with active strikes
@@ -671,3 +829,84 @@ func (r *DMCARepository) GetDashboardStats(ctx context.Context) (*models.DMCADas
 
 	return stats, nil
 }
+
+// ==============================================================================
+// DMCA Case History
+// ==============================================================================
+
+// CreateCaseEvent records a step in a DMCA case's history
+func (r *DMCARepository) CreateCaseEvent(ctx context.Context, event *models.DMCACaseEvent) error {
+	var metadataJSON []byte
+	if event.Metadata != nil {
+		var err error
+		metadataJSON, err = json.Marshal(event.Metadata)
+		if err != nil {
+			return fmt.Errorf("failed to marshal metadata: %w", err)
+		}
+	}
+
+	query := `
+		INSERT INTO dmca_case_events (
+			dmca_notice_id, counter_notice_id, strike_id, event, actor_id,
+			from_status, to_status, notes, metadata
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at`
+
+	return r.db.QueryRow(ctx, query,
+		event.DMCANoticeID,
+		event.CounterNoticeID,
+		event.StrikeID,
+		event.Event,
+		event.ActorID,
+		event.FromStatus,
+		event.ToStatus,
+		event.Notes,
+		metadataJSON,
+	).Scan(&event.ID, &event.CreatedAt)
+}
+
+// ListCaseEvents returns a DMCA case's history, oldest first
+func (r *DMCARepository) ListCaseEvents(ctx context.Context, noticeID uuid.UUID) ([]models.DMCACaseEvent, error) {
+	query := `
+		SELECT id, dmca_notice_id, counter_notice_id, strike_id, event, actor_id,
+			from_status, to_status, notes, metadata, created_at
+		FROM dmca_case_events
+		WHERE dmca_notice_id = $1
+		ORDER BY created_at ASC`
+
+	rows, err := r.db.Query(ctx, query, noticeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []models.DMCACaseEvent{}
+	for rows.Next() {
+		event := models.DMCACaseEvent{}
+		var metadataJSON []byte
+		err := rows.Scan(
+			&event.ID,
+			&event.DMCANoticeID,
+			&event.CounterNoticeID,
+			&event.StrikeID,
+			&event.Event,
+			&event.ActorID,
+			&event.FromStatus,
+			&event.ToStatus,
+			&event.Notes,
+			&metadataJSON,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if len(metadataJSON) > 0 {
+			if err := json.Unmarshal(metadataJSON, &event.Metadata); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+			}
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
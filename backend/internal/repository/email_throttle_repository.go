@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// EmailThrottleRepository handles the bulk email throttle settings
+type EmailThrottleRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewEmailThrottleRepository creates a new email throttle repository
+func NewEmailThrottleRepository(db *pgxpool.Pool) *EmailThrottleRepository {
+	return &EmailThrottleRepository{db: db}
+}
+
+// GetSettings returns the bulk email throttle settings
+func (r *EmailThrottleRepository) GetSettings(ctx context.Context) (*models.EmailThrottleSettings, error) {
+	query := `
+		SELECT paused, max_per_minute, ramp_start_per_minute, ramp_minutes,
+		       default_window, windows, updated_by, updated_at
+		FROM email_throttle_settings
+		WHERE id = 1
+	`
+
+	var settings models.EmailThrottleSettings
+	var defaultWindowJSON, windowsJSON []byte
+	err := r.db.QueryRow(ctx, query).Scan(
+		&settings.Paused, &settings.MaxPerMinute, &settings.RampStartPerMinute, &settings.RampMinutes,
+		&defaultWindowJSON, &windowsJSON, &settings.UpdatedBy, &settings.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email throttle settings: %w", err)
+	}
+
+	if err := json.Unmarshal(defaultWindowJSON, &settings.DefaultWindow); err != nil {
+		return nil, fmt.Errorf("failed to decode default send window: %w", err)
+	}
+	if err := json.Unmarshal(windowsJSON, &settings.Windows); err != nil {
+		return nil, fmt.Errorf("failed to decode send windows: %w", err)
+	}
+
+	return &settings, nil
+}
+
+// UpdateSettings replaces the bulk email throttle settings
+func (r *EmailThrottleRepository) UpdateSettings(ctx context.Context, settings *models.EmailThrottleSettings) error {
+	defaultWindowJSON, err := json.Marshal(settings.DefaultWindow)
+	if err != nil {
+		return fmt.Errorf("failed to encode default send window: %w", err)
+	}
+	windows := settings.Windows
+	if windows == nil {
+		windows = []models.EmailSendWindow{}
+	}
+	windowsJSON, err := json.Marshal(windows)
+	if err != nil {
+		return fmt.Errorf("failed to encode send windows: %w", err)
+	}
+
+	query := `
+		INSERT INTO email_throttle_settings (id, paused, max_per_minute, ramp_start_per_minute, ramp_minutes, default_window, windows, updated_by, updated_at)
+		VALUES (1, $1, $2, $3, $4, $5, $6, $7, NOW())
+		ON CONFLICT (id) DO UPDATE SET
+			paused = EXCLUDED.paused,
+			max_per_minute = EXCLUDED.max_per_minute,
+			ramp_start_per_minute = EXCLUDED.ramp_start_per_minute,
+			ramp_minutes = EXCLUDED.ramp_minutes,
+			default_window = EXCLUDED.default_window,
+			windows = EXCLUDED.windows,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+		RETURNING updated_at
+	`
+
+	err = r.db.QueryRow(ctx, query, settings.Paused, settings.MaxPerMinute, settings.RampStartPerMinute, settings.RampMinutes,
+		defaultWindowJSON, windowsJSON, settings.UpdatedBy).Scan(&settings.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update email throttle settings: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+var (
+	// ErrEmailTrackedLinkNotFound is returned when no tracked link matches the token
+	ErrEmailTrackedLinkNotFound = errors.New("email tracked link not found")
+	// ErrEmailTrackedLinkTokenTaken is returned when a generated token collides with an existing link
+	ErrEmailTrackedLinkTokenTaken = errors.New("email tracked link token already exists")
+)
+
+// EmailTrackedLinkRepository handles database operations for first-party email links
+type EmailTrackedLinkRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewEmailTrackedLinkRepository creates a new email tracked link repository
+func NewEmailTrackedLinkRepository(db *pgxpool.Pool) *EmailTrackedLinkRepository {
+	return &EmailTrackedLinkRepository{db: db}
+}
+
+// Create inserts a new tracked link
+func (r *EmailTrackedLinkRepository) Create(ctx context.Context, link *models.EmailTrackedLink) error {
+	query := `
+		INSERT INTO email_tracked_links (
+			id, token, email_notification_log_id, user_id, recipient, template, target_url, expires_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (token) DO NOTHING
+		RETURNING created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		link.ID,
+		link.Token,
+		link.EmailNotificationLogID,
+		link.UserID,
+		link.Recipient,
+		link.Template,
+		link.TargetURL,
+		link.ExpiresAt,
+	).Scan(&link.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrEmailTrackedLinkTokenTaken
+		}
+		return fmt.Errorf("failed to create email tracked link: %w", err)
+	}
+
+	return nil
+}
+
+// GetByToken retrieves a tracked link by its token
+func (r *EmailTrackedLinkRepository) GetByToken(ctx context.Context, token string) (*models.EmailTrackedLink, error) {
+	query := `
+		SELECT id, token, email_notification_log_id, user_id, recipient, template, target_url,
+		       click_count, first_clicked_at, last_clicked_at, expires_at, created_at
+		FROM email_tracked_links
+		WHERE token = $1
+	`
+
+	var link models.EmailTrackedLink
+	err := r.db.QueryRow(ctx, query, token).Scan(
+		&link.ID,
+		&link.Token,
+		&link.EmailNotificationLogID,
+		&link.UserID,
+		&link.Recipient,
+		&link.Template,
+		&link.TargetURL,
+		&link.ClickCount,
+		&link.FirstClickedAt,
+		&link.LastClickedAt,
+		&link.ExpiresAt,
+		&link.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEmailTrackedLinkNotFound
+		}
+		return nil, fmt.Errorf("failed to get email tracked link: %w", err)
+	}
+
+	return &link, nil
+}
+
+// RecordClick increments the click counter and click timestamps for a link
+func (r *EmailTrackedLinkRepository) RecordClick(ctx context.Context, linkID uuid.UUID, clickedAt time.Time) error {
+	query := `
+		UPDATE email_tracked_links
+		SET click_count = click_count + 1,
+		    first_clicked_at = COALESCE(first_clicked_at, $2),
+		    last_clicked_at = $2
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, linkID, clickedAt); err != nil {
+		return fmt.Errorf("failed to record email link click: %w", err)
+	}
+
+	return nil
+}
+
+// GetClickAttribution aggregates first-party email clicks since the given time and
+// counts clickers with any analytics event inside the attribution window after their click
+func (r *EmailTrackedLinkRepository) GetClickAttribution(ctx context.Context, since time.Time, windowHours int) (*models.EmailClickAttribution, error) {
+	query := `
+		WITH clicks AS (
+			SELECT COALESCE(l.template, 'unknown') AS template,
+			       l.user_id,
+			       EXISTS (
+			           SELECT 1
+			           FROM analytics_events ae
+			           WHERE ae.user_id = l.user_id
+			             AND ae.created_at > l.clicked_at
+			             AND ae.created_at <= l.clicked_at + $2 * INTERVAL '1 hour'
+			       ) AS engaged
+			FROM email_logs l
+			WHERE l.status = 'click'
+			  AND l.event_type = $3
+			  AND l.clicked_at >= $1
+		)
+		SELECT template,
+		       COUNT(*) AS clicks,
+		       COUNT(DISTINCT user_id) AS unique_clickers,
+		       COUNT(DISTINCT user_id) FILTER (WHERE engaged) AS engaged_users
+		FROM clicks
+		GROUP BY GROUPING SETS ((template), ())
+		ORDER BY clicks DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, since, windowHours, models.EmailClickEventType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email click attribution: %w", err)
+	}
+	defer rows.Close()
+
+	attribution := &models.EmailClickAttribution{
+		AttributionHours: windowHours,
+		Templates:        []models.EmailTemplateAttribution{},
+	}
+	for rows.Next() {
+		var (
+			template *string
+			stats    models.EmailTemplateAttribution
+		)
+		if err := rows.Scan(&template, &stats.Clicks, &stats.UniqueClickers, &stats.EngagedUsers); err != nil {
+			return nil, fmt.Errorf("failed to scan email click attribution: %w", err)
+		}
+
+		// The grand total row has no template
+		if template == nil {
+			attribution.TotalClicks = stats.Clicks
+			attribution.UniqueClickers = stats.UniqueClickers
+			attribution.EngagedUsers = stats.EngagedUsers
+			continue
+		}
+		stats.Template = *template
+		attribution.Templates = append(attribution.Templates, stats)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate email click attribution: %w", err)
+	}
+
+	return attribution, nil
+}
@@ -9,4 +9,6 @@ var (
 	ErrPresetNotFound = errors.New("preset not found")
 	// ErrUnauthorizedPresetAccess is returned when a user tries to access another user's preset
 	ErrUnauthorizedPresetAccess = errors.New("unauthorized access to preset")
+	// ErrModerationCaseNotFound is returned when a submission or report to compare does not exist
+	ErrModerationCaseNotFound = errors.New("moderation case not found")
 )
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+var (
+	// ErrFeatureFlagNotFound is returned when no flag has the key
+	ErrFeatureFlagNotFound = errors.New("feature flag not found")
+	// ErrFeatureFlagKeyTaken is returned when creating a flag whose key is in use
+	ErrFeatureFlagKeyTaken = errors.New("feature flag key already exists")
+)
+
+const featureFlagColumns = `
+	id, key, description, enabled, rollout_percentage, rules, created_by, updated_by, created_at, updated_at`
+
+// FeatureFlagRepository handles database operations for feature flags
+type FeatureFlagRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewFeatureFlagRepository creates a new FeatureFlagRepository
+func NewFeatureFlagRepository(pool *pgxpool.Pool) *FeatureFlagRepository {
+	return &FeatureFlagRepository{pool: pool}
+}
+
+func scanFeatureFlag(row pgx.Row) (*models.FeatureFlag, error) {
+	var flag models.FeatureFlag
+	var rulesJSON []byte
+	err := row.Scan(
+		&flag.ID, &flag.Key, &flag.Description, &flag.Enabled, &flag.RolloutPercentage,
+		&rulesJSON, &flag.CreatedBy, &flag.UpdatedBy, &flag.CreatedAt, &flag.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(rulesJSON, &flag.Rules); err != nil {
+		return nil, fmt.Errorf("failed to decode rules of feature flag %s: %w", flag.Key, err)
+	}
+	if flag.Rules == nil {
+		flag.Rules = []models.FeatureFlagRule{}
+	}
+	return &flag, nil
+}
+
+func encodeFeatureFlagRules(rules []models.FeatureFlagRule) ([]byte, error) {
+	if rules == nil {
+		rules = []models.FeatureFlagRule{}
+	}
+	rulesJSON, err := json.Marshal(rules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode feature flag rules: %w", err)
+	}
+	return rulesJSON, nil
+}
+
+// List returns every flag ordered by key
+func (r *FeatureFlagRepository) List(ctx context.Context) ([]models.FeatureFlag, error) {
+	rows, err := r.pool.Query(ctx, `SELECT `+featureFlagColumns+` FROM feature_flags ORDER BY key`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feature flags: %w", err)
+	}
+	defer rows.Close()
+
+	flags := []models.FeatureFlag{}
+	for rows.Next() {
+		flag, err := scanFeatureFlag(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan feature flag: %w", err)
+		}
+		flags = append(flags, *flag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating feature flags: %w", err)
+	}
+	return flags, nil
+}
+
+// GetByKey returns a flag by key
+func (r *FeatureFlagRepository) GetByKey(ctx context.Context, key string) (*models.FeatureFlag, error) {
+	flag, err := scanFeatureFlag(r.pool.QueryRow(ctx, `SELECT `+featureFlagColumns+` FROM feature_flags WHERE key = $1`, key))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrFeatureFlagNotFound
+		}
+		return nil, fmt.Errorf("failed to get feature flag: %w", err)
+	}
+	return flag, nil
+}
+
+// Create stores a new flag
+func (r *FeatureFlagRepository) Create(ctx context.Context, flag *models.FeatureFlag) error {
+	rulesJSON, err := encodeFeatureFlagRules(flag.Rules)
+	if err != nil {
+		return err
+	}
+
+	err = r.pool.QueryRow(ctx, `
+		INSERT INTO feature_flags (key, description, enabled, rollout_percentage, rules, created_by, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+		ON CONFLICT (key) DO NOTHING
+		RETURNING id, created_at, updated_at
+	`, flag.Key, flag.Description, flag.Enabled, flag.RolloutPercentage, rulesJSON, flag.CreatedBy,
+	).Scan(&flag.ID, &flag.CreatedAt, &flag.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrFeatureFlagKeyTaken
+		}
+		return fmt.Errorf("failed to create feature flag: %w", err)
+	}
+	flag.UpdatedBy = flag.CreatedBy
+	return nil
+}
+
+// Update saves a flag's description, switch, rollout and rules
+func (r *FeatureFlagRepository) Update(ctx context.Context, flag *models.FeatureFlag) error {
+	rulesJSON, err := encodeFeatureFlagRules(flag.Rules)
+	if err != nil {
+		return err
+	}
+
+	err = r.pool.QueryRow(ctx, `
+		UPDATE feature_flags
+		SET description = $2, enabled = $3, rollout_percentage = $4, rules = $5,
+			updated_by = $6, updated_at = NOW()
+		WHERE key = $1
+		RETURNING updated_at
+	`, flag.Key, flag.Description, flag.Enabled, flag.RolloutPercentage, rulesJSON, flag.UpdatedBy,
+	).Scan(&flag.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrFeatureFlagNotFound
+		}
+		return fmt.Errorf("failed to update feature flag: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a flag
+func (r *FeatureFlagRepository) Delete(ctx context.Context, key string) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM feature_flags WHERE key = $1`, key)
+	if err != nil {
+		return fmt.Errorf("failed to delete feature flag: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrFeatureFlagNotFound
+	}
+	return nil
+}
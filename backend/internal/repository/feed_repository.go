@@ -2,7 +2,9 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -10,6 +12,19 @@ import (
 	"github.com/subculture-collective/clipper/internal/models"
 )
 
+var (
+	// ErrFeedVersionConflict is returned when a feed changed since the version an edit was made against
+	ErrFeedVersionConflict = errors.New("feed was changed by another edit")
+	// ErrFeedItemNotFound is returned when moving a clip that isn't in the feed, or before one that isn't
+	ErrFeedItemNotFound = errors.New("clip not found in feed")
+	// ErrSmartFeedRulesNotFound is returned when a feed has no smart rules
+	ErrSmartFeedRulesNotFound = errors.New("smart feed rules not found")
+	// ErrFeedCollaboratorNotFound is returned when a user isn't invited to a feed
+	ErrFeedCollaboratorNotFound = errors.New("feed collaborator not found")
+	// ErrFeedCollaboratorExists is returned when inviting a user already invited to a feed
+	ErrFeedCollaboratorExists = errors.New("user is already invited to this feed")
+)
+
 type FeedRepository struct {
 	pool *pgxpool.Pool
 }
@@ -23,25 +38,25 @@ func (r *FeedRepository) CreateFeed(ctx context.Context, feed *models.Feed) erro
 	query := `
 		INSERT INTO feeds (id, user_id, name, description, icon, is_public, follower_count, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-		RETURNING id, created_at, updated_at
+		RETURNING id, version, is_smart, created_at, updated_at
 	`
 	return r.pool.QueryRow(ctx, query,
 		feed.ID, feed.UserID, feed.Name, feed.Description, feed.Icon,
 		feed.IsPublic, feed.FollowerCount, feed.CreatedAt, feed.UpdatedAt,
-	).Scan(&feed.ID, &feed.CreatedAt, &feed.UpdatedAt)
+	).Scan(&feed.ID, &feed.Version, &feed.IsSmart, &feed.CreatedAt, &feed.UpdatedAt)
 }
 
 // GetFeedByID retrieves a feed by ID
 func (r *FeedRepository) GetFeedByID(ctx context.Context, feedID uuid.UUID) (*models.Feed, error) {
 	query := `
-		SELECT id, user_id, name, description, icon, is_public, follower_count, created_at, updated_at
+		SELECT id, user_id, name, description, icon, is_public, follower_count, version, is_smart, created_at, updated_at
 		FROM feeds
 		WHERE id = $1
 	`
 	feed := &models.Feed{}
 	err := r.pool.QueryRow(ctx, query, feedID).Scan(
 		&feed.ID, &feed.UserID, &feed.Name, &feed.Description, &feed.Icon,
-		&feed.IsPublic, &feed.FollowerCount, &feed.CreatedAt, &feed.UpdatedAt,
+		&feed.IsPublic, &feed.FollowerCount, &feed.Version, &feed.IsSmart, &feed.CreatedAt, &feed.UpdatedAt,
 	)
 	if err == pgx.ErrNoRows {
 		return nil, fmt.Errorf("feed not found")
@@ -52,7 +67,7 @@ func (r *FeedRepository) GetFeedByID(ctx context.Context, feedID uuid.UUID) (*mo
 // GetFeedsByUserID retrieves all feeds for a user
 func (r *FeedRepository) GetFeedsByUserID(ctx context.Context, userID uuid.UUID, includePrivate bool) ([]*models.Feed, error) {
 	query := `
-		SELECT id, user_id, name, description, icon, is_public, follower_count, created_at, updated_at
+		SELECT id, user_id, name, description, icon, is_public, follower_count, version, is_smart, created_at, updated_at
 		FROM feeds
 		WHERE user_id = $1
 	`
@@ -72,7 +87,7 @@ func (r *FeedRepository) GetFeedsByUserID(ctx context.Context, userID uuid.UUID,
 		feed := &models.Feed{}
 		err := rows.Scan(
 			&feed.ID, &feed.UserID, &feed.Name, &feed.Description, &feed.Icon,
-			&feed.IsPublic, &feed.FollowerCount, &feed.CreatedAt, &feed.UpdatedAt,
+			&feed.IsPublic, &feed.FollowerCount, &feed.Version, &feed.IsSmart, &feed.CreatedAt, &feed.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -102,13 +117,42 @@ func (r *FeedRepository) DeleteFeed(ctx context.Context, feedID uuid.UUID) error
 	return err
 }
 
-// AddClipToFeed adds a clip to a feed
-func (r *FeedRepository) AddClipToFeed(ctx context.Context, feedItem *models.FeedItem) error {
+// bumpFeedVersion locks a feed for a change to its clips and bumps its
+// version, refusing the change if expectedVersion is set and stale. The lock
+// serializes concurrent edits to the same feed until tx ends.
+func bumpFeedVersion(ctx context.Context, tx pgx.Tx, feedID uuid.UUID, expectedVersion *int) (int, error) {
+	query := `
+		UPDATE feeds
+		SET version = version + 1, updated_at = NOW()
+		WHERE id = $1 AND ($2::int IS NULL OR version = $2)
+		RETURNING version
+	`
+	var version int
+	err := tx.QueryRow(ctx, query, feedID, expectedVersion).Scan(&version)
+	if err == pgx.ErrNoRows {
+		return 0, ErrFeedVersionConflict
+	}
+	return version, err
+}
+
+// AddClipToFeed adds a clip to the end of a feed, returning the feed's new version
+func (r *FeedRepository) AddClipToFeed(ctx context.Context, feedItem *models.FeedItem, expectedVersion *int) (int, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	version, err := bumpFeedVersion(ctx, tx, feedItem.FeedID, expectedVersion)
+	if err != nil {
+		return 0, err
+	}
+
 	// Get the next position
 	var maxPosition *int
-	err := r.pool.QueryRow(ctx, `SELECT MAX(position) FROM feed_items WHERE feed_id = $1`, feedItem.FeedID).Scan(&maxPosition)
+	err = tx.QueryRow(ctx, `SELECT MAX(position) FROM feed_items WHERE feed_id = $1`, feedItem.FeedID).Scan(&maxPosition)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	position := 0
@@ -118,28 +162,48 @@ func (r *FeedRepository) AddClipToFeed(ctx context.Context, feedItem *models.Fee
 	feedItem.Position = position
 
 	query := `
-		INSERT INTO feed_items (id, feed_id, clip_id, position, added_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO feed_items (id, feed_id, clip_id, position, added_at, added_by_user_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		ON CONFLICT (feed_id, clip_id) DO UPDATE SET position = EXCLUDED.position
-		RETURNING id, position, added_at
+		RETURNING id, position, added_at, added_by_user_id
 	`
-	return r.pool.QueryRow(ctx, query,
-		feedItem.ID, feedItem.FeedID, feedItem.ClipID, feedItem.Position, feedItem.AddedAt,
-	).Scan(&feedItem.ID, &feedItem.Position, &feedItem.AddedAt)
+	err = tx.QueryRow(ctx, query,
+		feedItem.ID, feedItem.FeedID, feedItem.ClipID, feedItem.Position, feedItem.AddedAt, feedItem.AddedByUserID,
+	).Scan(&feedItem.ID, &feedItem.Position, &feedItem.AddedAt, &feedItem.AddedByUserID)
+	if err != nil {
+		return 0, err
+	}
+
+	return version, tx.Commit(ctx)
 }
 
-// RemoveClipFromFeed removes a clip from a feed
-func (r *FeedRepository) RemoveClipFromFeed(ctx context.Context, feedID, clipID uuid.UUID) error {
+// RemoveClipFromFeed removes a clip from a feed, returning the feed's new version
+func (r *FeedRepository) RemoveClipFromFeed(ctx context.Context, feedID, clipID uuid.UUID, expectedVersion *int) (int, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	version, err := bumpFeedVersion(ctx, tx, feedID, expectedVersion)
+	if err != nil {
+		return 0, err
+	}
+
 	query := `DELETE FROM feed_items WHERE feed_id = $1 AND clip_id = $2`
-	_, err := r.pool.Exec(ctx, query, feedID, clipID)
-	return err
+	if _, err := tx.Exec(ctx, query, feedID, clipID); err != nil {
+		return 0, err
+	}
+
+	return version, tx.Commit(ctx)
 }
 
 // GetFeedClips retrieves all clips in a feed
 func (r *FeedRepository) GetFeedClips(ctx context.Context, feedID uuid.UUID) ([]*models.FeedItemWithClip, error) {
 	query := `
 		SELECT 
-			fi.id, fi.feed_id, fi.clip_id, fi.position, fi.added_at,
+			fi.id, fi.feed_id, fi.clip_id, fi.position, fi.added_at, fi.added_by_user_id,
+			u.username, COALESCE(u.display_name, u.username), u.avatar_url,
 			c.id, c.twitch_clip_id, c.twitch_clip_url, c.embed_url, c.title,
 			c.creator_name, c.creator_id, c.broadcaster_name, c.broadcaster_id,
 			c.game_id, c.game_name, c.language, c.thumbnail_url, c.duration,
@@ -147,6 +211,7 @@ func (r *FeedRepository) GetFeedClips(ctx context.Context, feedID uuid.UUID) ([]
 			c.favorite_count, c.is_featured, c.is_nsfw, c.is_removed, c.removed_reason, c.is_hidden
 		FROM feed_items fi
 		JOIN clips c ON fi.clip_id = c.id
+		LEFT JOIN users u ON u.id = fi.added_by_user_id
 		WHERE fi.feed_id = $1
 		ORDER BY fi.position ASC
 	`
@@ -161,8 +226,10 @@ func (r *FeedRepository) GetFeedClips(ctx context.Context, feedID uuid.UUID) ([]
 		item := &models.FeedItemWithClip{
 			Clip: &models.Clip{},
 		}
+		var addedByName, addedByDisplayName, addedByAvatar *string
 		err := rows.Scan(
-			&item.ID, &item.FeedID, &item.ClipID, &item.Position, &item.AddedAt,
+			&item.ID, &item.FeedID, &item.ClipID, &item.Position, &item.AddedAt, &item.AddedByUserID,
+			&addedByName, &addedByDisplayName, &addedByAvatar,
 			&item.Clip.ID, &item.Clip.TwitchClipID, &item.Clip.TwitchClipURL, &item.Clip.EmbedURL,
 			&item.Clip.Title, &item.Clip.CreatorName, &item.Clip.CreatorID, &item.Clip.BroadcasterName,
 			&item.Clip.BroadcasterID, &item.Clip.GameID, &item.Clip.GameName, &item.Clip.Language,
@@ -173,28 +240,126 @@ func (r *FeedRepository) GetFeedClips(ctx context.Context, feedID uuid.UUID) ([]
 		if err != nil {
 			return nil, err
 		}
+		if item.AddedByUserID != nil && addedByName != nil {
+			item.AddedBy = &models.ClipSubmitterInfo{
+				ID:          *item.AddedByUserID,
+				Username:    *addedByName,
+				DisplayName: *addedByDisplayName,
+				AvatarURL:   addedByAvatar,
+			}
+		}
 		items = append(items, item)
 	}
 	return items, rows.Err()
 }
 
-// ReorderFeedClips reorders clips in a feed
-func (r *FeedRepository) ReorderFeedClips(ctx context.Context, feedID uuid.UUID, clipIDs []uuid.UUID) error {
+// ReorderFeedClips puts a feed's clips in the given order, returning the feed's new version
+func (r *FeedRepository) ReorderFeedClips(ctx context.Context, feedID uuid.UUID, clipIDs []uuid.UUID, expectedVersion *int) (int, error) {
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer tx.Rollback(ctx)
 
+	version, err := bumpFeedVersion(ctx, tx, feedID, expectedVersion)
+	if err != nil {
+		return 0, err
+	}
+
 	for i, clipID := range clipIDs {
 		query := `UPDATE feed_items SET position = $1 WHERE feed_id = $2 AND clip_id = $3`
 		_, err := tx.Exec(ctx, query, i, feedID, clipID)
 		if err != nil {
-			return err
+			return 0, err
 		}
 	}
 
-	return tx.Commit(ctx)
+	return version, tx.Commit(ctx)
+}
+
+// MoveFeedClip moves one clip of a feed before another, or to the end when
+// beforeClipID is nil, returning the feed's new version. The move applies to
+// the feed's current order, so only clips whose position changes are written.
+func (r *FeedRepository) MoveFeedClip(ctx context.Context, feedID, clipID uuid.UUID, beforeClipID *uuid.UUID, expectedVersion *int) (int, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	version, err := bumpFeedVersion(ctx, tx, feedID, expectedVersion)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := tx.Query(ctx, `SELECT clip_id, position FROM feed_items WHERE feed_id = $1 ORDER BY position ASC, added_at ASC`, feedID)
+	if err != nil {
+		return 0, err
+	}
+	var order []uuid.UUID
+	positions := map[uuid.UUID]int{}
+	for rows.Next() {
+		var id uuid.UUID
+		var position int
+		if err := rows.Scan(&id, &position); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		order = append(order, id)
+		positions[id] = position
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	moved, err := moveFeedClip(order, clipID, beforeClipID)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, id := range moved {
+		if positions[id] == i {
+			continue
+		}
+		query := `UPDATE feed_items SET position = $1 WHERE feed_id = $2 AND clip_id = $3`
+		if _, err := tx.Exec(ctx, query, i, feedID, id); err != nil {
+			return 0, err
+		}
+	}
+
+	return version, tx.Commit(ctx)
+}
+
+// moveFeedClip returns order with clipID moved before beforeClipID, or to the
+// end when beforeClipID is nil
+func moveFeedClip(order []uuid.UUID, clipID uuid.UUID, beforeClipID *uuid.UUID) ([]uuid.UUID, error) {
+	moved := make([]uuid.UUID, 0, len(order))
+	found := false
+	for _, id := range order {
+		if id == clipID {
+			found = true
+			continue
+		}
+		moved = append(moved, id)
+	}
+	if !found {
+		return nil, ErrFeedItemNotFound
+	}
+
+	if beforeClipID == nil {
+		return append(moved, clipID), nil
+	}
+	if *beforeClipID == clipID {
+		return order, nil
+	}
+	for i, id := range moved {
+		if id == *beforeClipID {
+			moved = append(moved[:i], append([]uuid.UUID{clipID}, moved[i:]...)...)
+			return moved, nil
+		}
+	}
+	return nil, ErrFeedItemNotFound
 }
 
 // FollowFeed adds a follow relationship
@@ -268,7 +433,7 @@ func (r *FeedRepository) IsFollowingFeed(ctx context.Context, userID, feedID uui
 // GetFollowedFeeds retrieves all feeds a user is following
 func (r *FeedRepository) GetFollowedFeeds(ctx context.Context, userID uuid.UUID) ([]*models.Feed, error) {
 	query := `
-		SELECT f.id, f.user_id, f.name, f.description, f.icon, f.is_public, f.follower_count, f.created_at, f.updated_at
+		SELECT f.id, f.user_id, f.name, f.description, f.icon, f.is_public, f.follower_count, f.version, f.is_smart, f.created_at, f.updated_at
 		FROM feeds f
 		JOIN feed_follows ff ON f.id = ff.feed_id
 		WHERE ff.user_id = $1
@@ -285,7 +450,7 @@ func (r *FeedRepository) GetFollowedFeeds(ctx context.Context, userID uuid.UUID)
 		feed := &models.Feed{}
 		err := rows.Scan(
 			&feed.ID, &feed.UserID, &feed.Name, &feed.Description, &feed.Icon,
-			&feed.IsPublic, &feed.FollowerCount, &feed.CreatedAt, &feed.UpdatedAt,
+			&feed.IsPublic, &feed.FollowerCount, &feed.Version, &feed.IsSmart, &feed.CreatedAt, &feed.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -299,7 +464,7 @@ func (r *FeedRepository) GetFollowedFeeds(ctx context.Context, userID uuid.UUID)
 func (r *FeedRepository) DiscoverPublicFeeds(ctx context.Context, limit, offset int) ([]*models.FeedWithOwner, error) {
 	query := `
 		SELECT 
-			f.id, f.user_id, f.name, f.description, f.icon, f.is_public, f.follower_count, f.created_at, f.updated_at,
+			f.id, f.user_id, f.name, f.description, f.icon, f.is_public, f.follower_count, f.version, f.is_smart, f.created_at, f.updated_at,
 			u.id, u.username, u.display_name, u.avatar_url
 		FROM feeds f
 		JOIN users u ON f.user_id = u.id
@@ -320,7 +485,7 @@ func (r *FeedRepository) DiscoverPublicFeeds(ctx context.Context, limit, offset
 		}
 		err := rows.Scan(
 			&feed.ID, &feed.UserID, &feed.Name, &feed.Description, &feed.Icon,
-			&feed.IsPublic, &feed.FollowerCount, &feed.CreatedAt, &feed.UpdatedAt,
+			&feed.IsPublic, &feed.FollowerCount, &feed.Version, &feed.IsSmart, &feed.CreatedAt, &feed.UpdatedAt,
 			&feed.Owner.ID, &feed.Owner.Username, &feed.Owner.DisplayName, &feed.Owner.AvatarURL,
 		)
 		if err != nil {
@@ -335,7 +500,7 @@ func (r *FeedRepository) DiscoverPublicFeeds(ctx context.Context, limit, offset
 func (r *FeedRepository) SearchFeeds(ctx context.Context, query string, limit, offset int) ([]*models.FeedWithOwner, error) {
 	searchQuery := `
 		SELECT 
-			f.id, f.user_id, f.name, f.description, f.icon, f.is_public, f.follower_count, f.created_at, f.updated_at,
+			f.id, f.user_id, f.name, f.description, f.icon, f.is_public, f.follower_count, f.version, f.is_smart, f.created_at, f.updated_at,
 			u.id, u.username, u.display_name, u.avatar_url
 		FROM feeds f
 		JOIN users u ON f.user_id = u.id
@@ -357,7 +522,7 @@ func (r *FeedRepository) SearchFeeds(ctx context.Context, query string, limit, o
 		}
 		err := rows.Scan(
 			&feed.ID, &feed.UserID, &feed.Name, &feed.Description, &feed.Icon,
-			&feed.IsPublic, &feed.FollowerCount, &feed.CreatedAt, &feed.UpdatedAt,
+			&feed.IsPublic, &feed.FollowerCount, &feed.Version, &feed.IsSmart, &feed.CreatedAt, &feed.UpdatedAt,
 			&feed.Owner.ID, &feed.Owner.Username, &feed.Owner.DisplayName, &feed.Owner.AvatarURL,
 		)
 		if err != nil {
@@ -367,3 +532,385 @@ func (r *FeedRepository) SearchFeeds(ctx context.Context, query string, limit, o
 	}
 	return feeds, rows.Err()
 }
+
+// smartFeedRulesColumns are the feed_smart_rules columns scanned by scanSmartFeedRules
+const smartFeedRulesColumns = `feed_id, game_ids, broadcaster_ids, tags, languages, min_votes, max_clips,
+		rules_changed_at, last_materialized_at, created_at, updated_at`
+
+func scanSmartFeedRules(row pgx.Row) (*models.SmartFeedRules, error) {
+	var rules models.SmartFeedRules
+	err := row.Scan(
+		&rules.FeedID, &rules.GameIDs, &rules.BroadcasterIDs, &rules.Tags, &rules.Languages,
+		&rules.MinVotes, &rules.MaxClips, &rules.RulesChangedAt, &rules.LastMaterializedAt,
+		&rules.CreatedAt, &rules.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &rules, nil
+}
+
+// GetSmartFeedRules returns a smart feed's rules
+func (r *FeedRepository) GetSmartFeedRules(ctx context.Context, feedID uuid.UUID) (*models.SmartFeedRules, error) {
+	query := `SELECT ` + smartFeedRulesColumns + ` FROM feed_smart_rules WHERE feed_id = $1`
+	rules, err := scanSmartFeedRules(r.pool.QueryRow(ctx, query, feedID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrSmartFeedRulesNotFound
+	}
+	return rules, err
+}
+
+// UpsertSmartFeedRules sets a feed's smart rules, making it a smart feed. The
+// rules are marked changed, so the feed is materialized again.
+func (r *FeedRepository) UpsertSmartFeedRules(ctx context.Context, rules *models.SmartFeedRules) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO feed_smart_rules (feed_id, game_ids, broadcaster_ids, tags, languages, min_votes, max_clips)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (feed_id) DO UPDATE SET
+			game_ids = EXCLUDED.game_ids,
+			broadcaster_ids = EXCLUDED.broadcaster_ids,
+			tags = EXCLUDED.tags,
+			languages = EXCLUDED.languages,
+			min_votes = EXCLUDED.min_votes,
+			max_clips = EXCLUDED.max_clips,
+			rules_changed_at = NOW(),
+			updated_at = NOW()
+		RETURNING ` + smartFeedRulesColumns
+	saved, err := scanSmartFeedRules(tx.QueryRow(ctx, query,
+		rules.FeedID, rules.GameIDs, rules.BroadcasterIDs, rules.Tags, rules.Languages,
+		rules.MinVotes, rules.MaxClips,
+	))
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE feeds SET is_smart = true, updated_at = NOW() WHERE id = $1`, rules.FeedID); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	*rules = *saved
+	return nil
+}
+
+// DeleteSmartFeedRules removes a feed's smart rules, making it a manually
+// curated feed again. Its clips are kept.
+func (r *FeedRepository) DeleteSmartFeedRules(ctx context.Context, feedID uuid.UUID) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `DELETE FROM feed_smart_rules WHERE feed_id = $1`, feedID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrSmartFeedRulesNotFound
+	}
+	if _, err := tx.Exec(ctx, `UPDATE feeds SET is_smart = false, updated_at = NOW() WHERE id = $1`, feedID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ListDueSmartFeeds returns the rules of smart feeds that were never
+// materialized, whose rules changed since, or that were last materialized
+// before staleBefore, least recently materialized first
+func (r *FeedRepository) ListDueSmartFeeds(ctx context.Context, staleBefore time.Time, limit int) ([]models.SmartFeedRules, error) {
+	query := `
+		SELECT ` + smartFeedRulesColumns + `
+		FROM feed_smart_rules
+		WHERE last_materialized_at IS NULL
+		   OR last_materialized_at < rules_changed_at
+		   OR last_materialized_at < $1
+		ORDER BY last_materialized_at NULLS FIRST
+		LIMIT $2
+	`
+	rows, err := r.pool.Query(ctx, query, staleBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var due []models.SmartFeedRules
+	for rows.Next() {
+		rules, err := scanSmartFeedRules(rows)
+		if err != nil {
+			return nil, err
+		}
+		due = append(due, *rules)
+	}
+	return due, rows.Err()
+}
+
+// MaterializeSmartFeed brings a smart feed's clips in step with its rules:
+// the newest visible clips meeting all of them, up to max_clips, newest
+// first. Only the difference is written: matching clips not yet in the feed
+// are added and clips that no longer match or fell out of the newest
+// max_clips are removed. The feed's version is bumped only when its clips
+// changed.
+func (r *FeedRepository) MaterializeSmartFeed(ctx context.Context, rules *models.SmartFeedRules) (*models.SmartFeedMaterialization, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock the feed against concurrent edits and runs, and check it's still smart
+	var isSmart bool
+	var version int
+	err = tx.QueryRow(ctx, `SELECT is_smart, version FROM feeds WHERE id = $1 FOR UPDATE`, rules.FeedID).Scan(&isSmart, &version)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && !isSmart) {
+		return nil, ErrSmartFeedRulesNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	matchQuery := `
+		CREATE TEMP TABLE smart_feed_matches ON COMMIT DROP AS
+		SELECT c.id AS clip_id, ROW_NUMBER() OVER (ORDER BY c.created_at DESC, c.id DESC) - 1 AS position
+		FROM clips c
+		WHERE c.is_removed = false
+		  AND c.is_hidden = false
+		  AND (cardinality($1::text[]) = 0 OR c.game_id = ANY($1))
+		  AND (cardinality($2::text[]) = 0 OR c.broadcaster_id = ANY($2))
+		  AND (cardinality($3::text[]) = 0 OR EXISTS (
+			SELECT 1 FROM clip_tags ct
+			JOIN tags t ON t.id = ct.tag_id
+			WHERE ct.clip_id = c.id AND t.slug = ANY($3)
+		  ))
+		  AND (cardinality($4::text[]) = 0
+			OR LOWER(c.language) = ANY($4)
+			OR SPLIT_PART(LOWER(c.language), '-', 1) = ANY($4))
+		  AND ($5::int IS NULL OR c.vote_score >= $5)
+		ORDER BY c.created_at DESC, c.id DESC
+		LIMIT $6
+	`
+	if _, err := tx.Exec(ctx, matchQuery,
+		rules.GameIDs, rules.BroadcasterIDs, rules.Tags, rules.Languages, rules.MinVotes, rules.MaxClips,
+	); err != nil {
+		return nil, err
+	}
+
+	removed, err := tx.Exec(ctx, `
+		DELETE FROM feed_items fi
+		WHERE fi.feed_id = $1
+		  AND NOT EXISTS (SELECT 1 FROM smart_feed_matches m WHERE m.clip_id = fi.clip_id)
+	`, rules.FeedID)
+	if err != nil {
+		return nil, err
+	}
+	added, err := tx.Exec(ctx, `
+		INSERT INTO feed_items (feed_id, clip_id, position)
+		SELECT $1, m.clip_id, m.position
+		FROM smart_feed_matches m
+		ON CONFLICT (feed_id, clip_id) DO NOTHING
+	`, rules.FeedID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.SmartFeedMaterialization{
+		Added:   int(added.RowsAffected()),
+		Removed: int(removed.RowsAffected()),
+		Version: version,
+	}
+	if result.Added > 0 || result.Removed > 0 {
+		// Kept clips move down as newer ones are added
+		if _, err := tx.Exec(ctx, `
+			UPDATE feed_items fi
+			SET position = m.position
+			FROM smart_feed_matches m
+			WHERE fi.feed_id = $1 AND fi.clip_id = m.clip_id AND fi.position <> m.position
+		`, rules.FeedID); err != nil {
+			return nil, err
+		}
+		if result.Version, err = bumpFeedVersion(ctx, tx, rules.FeedID, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE feed_smart_rules SET last_materialized_at = NOW() WHERE feed_id = $1`, rules.FeedID); err != nil {
+		return nil, err
+	}
+	return result, tx.Commit(ctx)
+}
+
+const feedCollaboratorColumns = `fc.feed_id, fc.user_id, fc.invited_by, fc.roles, fc.status,
+		fc.invited_at, fc.accepted_at, fc.updated_at,
+		u.username, COALESCE(u.display_name, u.username), u.avatar_url`
+
+func scanFeedCollaborator(row pgx.Row, extra ...any) (*models.FeedCollaborator, error) {
+	var collaborator models.FeedCollaborator
+	user := &models.ClipSubmitterInfo{}
+	dest := []any{
+		&collaborator.FeedID, &collaborator.UserID, &collaborator.InvitedBy, &collaborator.Roles, &collaborator.Status,
+		&collaborator.InvitedAt, &collaborator.AcceptedAt, &collaborator.UpdatedAt,
+		&user.Username, &user.DisplayName, &user.AvatarURL,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	user.ID = collaborator.UserID
+	collaborator.User = user
+	return &collaborator, nil
+}
+
+// CreateFeedCollaborator invites a user to a feed, returning
+// ErrFeedCollaboratorExists if they already are
+func (r *FeedRepository) CreateFeedCollaborator(ctx context.Context, collaborator *models.FeedCollaborator) error {
+	query := `
+		WITH fc AS (
+			INSERT INTO feed_collaborators (feed_id, user_id, invited_by, roles)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (feed_id, user_id) DO NOTHING
+			RETURNING *
+		)
+		SELECT ` + feedCollaboratorColumns + `
+		FROM fc
+		JOIN users u ON u.id = fc.user_id
+	`
+	saved, err := scanFeedCollaborator(r.pool.QueryRow(ctx, query,
+		collaborator.FeedID, collaborator.UserID, collaborator.InvitedBy, collaborator.Roles,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrFeedCollaboratorExists
+	}
+	if err != nil {
+		return err
+	}
+	*collaborator = *saved
+	return nil
+}
+
+// GetFeedCollaborator returns a user's invite to a feed
+func (r *FeedRepository) GetFeedCollaborator(ctx context.Context, feedID, userID uuid.UUID) (*models.FeedCollaborator, error) {
+	query := `
+		SELECT ` + feedCollaboratorColumns + `
+		FROM feed_collaborators fc
+		JOIN users u ON u.id = fc.user_id
+		WHERE fc.feed_id = $1 AND fc.user_id = $2
+	`
+	collaborator, err := scanFeedCollaborator(r.pool.QueryRow(ctx, query, feedID, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrFeedCollaboratorNotFound
+	}
+	return collaborator, err
+}
+
+// ListFeedCollaborators returns a feed's collaborators and pending invites,
+// oldest invite first
+func (r *FeedRepository) ListFeedCollaborators(ctx context.Context, feedID uuid.UUID) ([]*models.FeedCollaborator, error) {
+	query := `
+		SELECT ` + feedCollaboratorColumns + `
+		FROM feed_collaborators fc
+		JOIN users u ON u.id = fc.user_id
+		WHERE fc.feed_id = $1
+		ORDER BY fc.invited_at ASC
+	`
+	rows, err := r.pool.Query(ctx, query, feedID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	collaborators := []*models.FeedCollaborator{}
+	for rows.Next() {
+		collaborator, err := scanFeedCollaborator(rows)
+		if err != nil {
+			return nil, err
+		}
+		collaborators = append(collaborators, collaborator)
+	}
+	return collaborators, rows.Err()
+}
+
+// UpdateFeedCollaboratorRoles replaces a collaborator's roles
+func (r *FeedRepository) UpdateFeedCollaboratorRoles(ctx context.Context, feedID, userID uuid.UUID, roles []string) error {
+	query := `
+		UPDATE feed_collaborators
+		SET roles = $3, updated_at = NOW()
+		WHERE feed_id = $1 AND user_id = $2
+	`
+	result, err := r.pool.Exec(ctx, query, feedID, userID, roles)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrFeedCollaboratorNotFound
+	}
+	return nil
+}
+
+// AcceptFeedCollaborator accepts a pending invite. Accepting an invite twice
+// is harmless.
+func (r *FeedRepository) AcceptFeedCollaborator(ctx context.Context, feedID, userID uuid.UUID) error {
+	query := `
+		UPDATE feed_collaborators
+		SET status = 'accepted', accepted_at = COALESCE(accepted_at, NOW()), updated_at = NOW()
+		WHERE feed_id = $1 AND user_id = $2
+	`
+	result, err := r.pool.Exec(ctx, query, feedID, userID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrFeedCollaboratorNotFound
+	}
+	return nil
+}
+
+// DeleteFeedCollaborator removes a collaborator or withdraws an invite
+func (r *FeedRepository) DeleteFeedCollaborator(ctx context.Context, feedID, userID uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM feed_collaborators WHERE feed_id = $1 AND user_id = $2`, feedID, userID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrFeedCollaboratorNotFound
+	}
+	return nil
+}
+
+// ListUserFeedCollaborations returns the feeds a user is invited to, newest
+// invite first, optionally only those with the given status
+func (r *FeedRepository) ListUserFeedCollaborations(ctx context.Context, userID uuid.UUID, status string) ([]*models.FeedCollaboration, error) {
+	query := `
+		SELECT ` + feedCollaboratorColumns + `,
+			f.id, f.user_id, f.name, f.description, f.icon, f.is_public, f.follower_count, f.version, f.is_smart, f.created_at, f.updated_at
+		FROM feed_collaborators fc
+		JOIN users u ON u.id = fc.user_id
+		JOIN feeds f ON f.id = fc.feed_id
+		WHERE fc.user_id = $1 AND ($2::text = '' OR fc.status = $2)
+		ORDER BY fc.invited_at DESC
+	`
+	rows, err := r.pool.Query(ctx, query, userID, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	collaborations := []*models.FeedCollaboration{}
+	for rows.Next() {
+		feed := &models.Feed{}
+		collaborator, err := scanFeedCollaborator(rows,
+			&feed.ID, &feed.UserID, &feed.Name, &feed.Description, &feed.Icon,
+			&feed.IsPublic, &feed.FollowerCount, &feed.Version, &feed.IsSmart, &feed.CreatedAt, &feed.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		collaborations = append(collaborations, &models.FeedCollaboration{FeedCollaborator: *collaborator, Feed: feed})
+	}
+	return collaborations, rows.Err()
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoveFeedClip(t *testing.T) {
+	a, b, c, d := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	order := []uuid.UUID{a, b, c, d}
+
+	tests := []struct {
+		name   string
+		clip   uuid.UUID
+		before *uuid.UUID
+		want   []uuid.UUID
+	}{
+		{"move forward", d, &b, []uuid.UUID{a, d, b, c}},
+		{"move back", a, &d, []uuid.UUID{b, c, a, d}},
+		{"move to start", c, &a, []uuid.UUID{c, a, b, d}},
+		{"move to end", b, nil, []uuid.UUID{a, c, d, b}},
+		{"before itself", b, &b, []uuid.UUID{a, b, c, d}},
+		{"already in place", a, &b, []uuid.UUID{a, b, c, d}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := moveFeedClip(order, tt.clip, tt.before)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// The input order is left alone
+	assert.Equal(t, []uuid.UUID{a, b, c, d}, order)
+
+	missing := uuid.New()
+	_, err := moveFeedClip(order, missing, nil)
+	assert.ErrorIs(t, err, ErrFeedItemNotFound)
+	_, err = moveFeedClip(order, a, &missing)
+	assert.ErrorIs(t, err, ErrFeedItemNotFound)
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+var (
+	// ErrGamePatchNotFound is returned when a game patch does not exist
+	ErrGamePatchNotFound = errors.New("game patch not found")
+	// ErrGamePatchExists is returned when a game already has a patch with the version
+	ErrGamePatchExists = errors.New("game already has a patch with this version")
+)
+
+// gamePatchColumns selects a patch with the release of the game's next patch
+// and its tagged clips. Queries select from game_patches as gp.
+const gamePatchColumns = `
+	gp.id, gp.game_id, gp.version, gp.name, gp.notes_url, gp.released_at,
+	(SELECT MIN(n.released_at) FROM game_patches n
+	 WHERE n.game_id = gp.game_id AND n.released_at > gp.released_at) AS ended_at,
+	gp.source, gp.igdb_id,
+	(SELECT COUNT(*) FROM clips c WHERE c.game_patch_id = gp.id AND c.is_removed = false) AS clip_count,
+	gp.created_by, gp.created_at, gp.updated_at`
+
+// GamePatchRepository handles database operations for game patches
+type GamePatchRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewGamePatchRepository creates a new GamePatchRepository
+func NewGamePatchRepository(pool *pgxpool.Pool) *GamePatchRepository {
+	return &GamePatchRepository{pool: pool}
+}
+
+func scanGamePatch(row pgx.Row) (*models.GamePatch, error) {
+	var patch models.GamePatch
+	err := row.Scan(
+		&patch.ID, &patch.GameID, &patch.Version, &patch.Name, &patch.NotesURL, &patch.ReleasedAt,
+		&patch.EndedAt, &patch.Source, &patch.IGDBID, &patch.ClipCount,
+		&patch.CreatedBy, &patch.CreatedAt, &patch.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &patch, nil
+}
+
+// Create inserts a patch, returning ErrGamePatchExists when the game already
+// has a patch with its version
+func (r *GamePatchRepository) Create(ctx context.Context, patch *models.GamePatch) error {
+	query := `
+		INSERT INTO game_patches (id, game_id, version, name, notes_url, released_at, source, igdb_id, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING created_at, updated_at
+	`
+	err := r.pool.QueryRow(ctx, query,
+		patch.ID, patch.GameID, patch.Version, patch.Name, patch.NotesURL, patch.ReleasedAt,
+		patch.Source, patch.IGDBID, patch.CreatedBy,
+	).Scan(&patch.CreatedAt, &patch.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrGamePatchExists
+		}
+		return fmt.Errorf("failed to create game patch: %w", err)
+	}
+	return nil
+}
+
+// Upsert inserts a patch, or updates the game's patch with the same version.
+// patch.ID is set to the stored patch's ID.
+func (r *GamePatchRepository) Upsert(ctx context.Context, patch *models.GamePatch) error {
+	query := `
+		INSERT INTO game_patches (id, game_id, version, name, notes_url, released_at, source, igdb_id, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (game_id, version) DO UPDATE SET
+			name = COALESCE(EXCLUDED.name, game_patches.name),
+			notes_url = COALESCE(EXCLUDED.notes_url, game_patches.notes_url),
+			released_at = EXCLUDED.released_at,
+			source = EXCLUDED.source,
+			igdb_id = COALESCE(EXCLUDED.igdb_id, game_patches.igdb_id)
+		RETURNING id, created_at, updated_at
+	`
+	err := r.pool.QueryRow(ctx, query,
+		patch.ID, patch.GameID, patch.Version, patch.Name, patch.NotesURL, patch.ReleasedAt,
+		patch.Source, patch.IGDBID, patch.CreatedBy,
+	).Scan(&patch.ID, &patch.CreatedAt, &patch.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert game patch: %w", err)
+	}
+	return nil
+}
+
+// GetByID returns a patch by ID
+func (r *GamePatchRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.GamePatch, error) {
+	patch, err := scanGamePatch(r.pool.QueryRow(ctx, `SELECT `+gamePatchColumns+` FROM game_patches gp WHERE gp.id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrGamePatchNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game patch: %w", err)
+	}
+	return patch, nil
+}
+
+// ListByGame returns a game's patches, newest first
+func (r *GamePatchRepository) ListByGame(ctx context.Context, gameID uuid.UUID) ([]*models.GamePatch, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+gamePatchColumns+`
+		FROM game_patches gp
+		WHERE gp.game_id = $1
+		ORDER BY gp.released_at DESC
+	`, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list game patches: %w", err)
+	}
+	defer rows.Close()
+
+	patches := []*models.GamePatch{}
+	for rows.Next() {
+		patch, err := scanGamePatch(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan game patch: %w", err)
+		}
+		patches = append(patches, patch)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating game patches: %w", err)
+	}
+	return patches, nil
+}
+
+// Update saves a patch's version, name, notes URL and release time,
+// returning ErrGamePatchExists when another patch of the game has the version
+func (r *GamePatchRepository) Update(ctx context.Context, patch *models.GamePatch) error {
+	query := `
+		UPDATE game_patches SET version = $2, name = $3, notes_url = $4, released_at = $5
+		WHERE id = $1
+		RETURNING updated_at
+	`
+	err := r.pool.QueryRow(ctx, query, patch.ID, patch.Version, patch.Name, patch.NotesURL, patch.ReleasedAt).Scan(&patch.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrGamePatchNotFound
+	}
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrGamePatchExists
+		}
+		return fmt.Errorf("failed to update game patch: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a patch. Its clips are untagged until RetagClips runs.
+func (r *GamePatchRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM game_patches WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete game patch: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrGamePatchNotFound
+	}
+	return nil
+}
+
+// RetagClips tags every clip of a Twitch game with the patch in effect when
+// it was created, after the game's patches changed. It returns how many
+// clips changed patch.
+func (r *GamePatchRepository) RetagClips(ctx context.Context, twitchGameID string) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE clips c SET game_patch_id = p.patch_id
+		FROM (
+			SELECT id, game_patch_for(game_id, created_at) AS patch_id
+			FROM clips
+			WHERE game_id = $1
+		) p
+		WHERE c.id = p.id AND c.game_patch_id IS DISTINCT FROM p.patch_id
+	`, twitchGameID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to retag clips: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// HealthHistoryRepository handles persisted health probe results
+type HealthHistoryRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewHealthHistoryRepository creates a new health history repository
+func NewHealthHistoryRepository(db *pgxpool.Pool) *HealthHistoryRepository {
+	return &HealthHistoryRepository{db: db}
+}
+
+// RecordResults stores the results of one round of probes
+func (r *HealthHistoryRepository) RecordResults(ctx context.Context, results []models.HealthProbeResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO health_probe_results
+			(target_type, target, healthy, latency_ms, requests, server_errors, error, checked_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, result := range results {
+		_, err := tx.Exec(ctx, query, result.TargetType, result.Target, result.Healthy, result.LatencyMs,
+			result.Requests, result.ServerErrors, result.Error, result.CheckedAt)
+		if err != nil {
+			return fmt.Errorf("failed to record health probe result: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit health probe results: %w", err)
+	}
+
+	return nil
+}
+
+// GetUptime returns the uptime of every target probed in the last 30 days
+// as of now, ordered by target type and name
+func (r *HealthHistoryRepository) GetUptime(ctx context.Context, now time.Time) ([]models.HealthUptime, error) {
+	query := `
+		SELECT target_type, target,
+			ROUND(100.0 * COUNT(*) FILTER (WHERE healthy AND checked_at >= $1)
+				/ NULLIF(COUNT(*) FILTER (WHERE checked_at >= $1), 0), 3) AS uptime_24h,
+			ROUND(100.0 * COUNT(*) FILTER (WHERE healthy AND checked_at >= $2)
+				/ NULLIF(COUNT(*) FILTER (WHERE checked_at >= $2), 0), 3) AS uptime_7d,
+			ROUND(100.0 * COUNT(*) FILTER (WHERE healthy) / COUNT(*), 3) AS uptime_30d,
+			MAX(checked_at) AS last_checked_at
+		FROM health_probe_results
+		WHERE checked_at >= $3
+		GROUP BY target_type, target
+		ORDER BY target_type, target
+	`
+
+	rows, err := r.db.Query(ctx, query, now.Add(-24*time.Hour), now.AddDate(0, 0, -7), now.AddDate(0, 0, -30))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get uptime: %w", err)
+	}
+	defer rows.Close()
+
+	uptimes := []models.HealthUptime{}
+	for rows.Next() {
+		var u models.HealthUptime
+		if err := rows.Scan(&u.TargetType, &u.Target, &u.Uptime24h, &u.Uptime7d, &u.Uptime30d, &u.LastCheckedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan uptime: %w", err)
+		}
+		uptimes = append(uptimes, u)
+	}
+
+	return uptimes, rows.Err()
+}
+
+// GetProbeCounts counts each target's probes, and the failed ones, checked
+// in [from, to), ordered by target type and name
+func (r *HealthHistoryRepository) GetProbeCounts(ctx context.Context, from, to time.Time) ([]models.HealthProbeCounts, error) {
+	query := `
+		SELECT target_type, target, COUNT(*) AS probes, COUNT(*) FILTER (WHERE NOT healthy) AS failed
+		FROM health_probe_results
+		WHERE checked_at >= $1 AND checked_at < $2
+		GROUP BY target_type, target
+		ORDER BY target_type, target
+	`
+
+	rows, err := r.db.Query(ctx, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count health probes: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []models.HealthProbeCounts{}
+	for rows.Next() {
+		var c models.HealthProbeCounts
+		if err := rows.Scan(&c.TargetType, &c.Target, &c.Probes, &c.Failed); err != nil {
+			return nil, fmt.Errorf("failed to scan health probe counts: %w", err)
+		}
+		counts = append(counts, c)
+	}
+
+	return counts, rows.Err()
+}
+
+// DeleteBefore deletes probe results checked before a time and returns how
+// many were deleted
+func (r *HealthHistoryRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.Exec(ctx, `DELETE FROM health_probe_results WHERE checked_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete old health probe results: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// ErrI18nStringNotFound is returned when a locale has no string for a key
+var ErrI18nStringNotFound = errors.New("translation not found")
+
+// I18nRepository handles database operations for the UI string catalog
+type I18nRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewI18nRepository creates a new I18nRepository
+func NewI18nRepository(pool *pgxpool.Pool) *I18nRepository {
+	return &I18nRepository{pool: pool}
+}
+
+// ListLocales returns all locales, enabled or not
+func (r *I18nRepository) ListLocales(ctx context.Context) ([]models.I18nLocale, error) {
+	query := `
+		SELECT code, name, fallback_locale, is_enabled, created_at, updated_at
+		FROM i18n_locales
+		ORDER BY code
+	`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list locales: %w", err)
+	}
+	defer rows.Close()
+
+	locales := []models.I18nLocale{}
+	for rows.Next() {
+		var locale models.I18nLocale
+		if err := rows.Scan(
+			&locale.Code,
+			&locale.Name,
+			&locale.FallbackLocale,
+			&locale.IsEnabled,
+			&locale.CreatedAt,
+			&locale.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan locale: %w", err)
+		}
+		locales = append(locales, locale)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate locales: %w", err)
+	}
+	return locales, nil
+}
+
+// UpsertLocale creates a locale or updates its name, fallback and enabled flag
+func (r *I18nRepository) UpsertLocale(ctx context.Context, locale *models.I18nLocale) error {
+	query := `
+		INSERT INTO i18n_locales (code, name, fallback_locale, is_enabled)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (code) DO UPDATE SET
+			name = EXCLUDED.name,
+			fallback_locale = EXCLUDED.fallback_locale,
+			is_enabled = EXCLUDED.is_enabled,
+			updated_at = NOW()
+		RETURNING created_at, updated_at
+	`
+
+	err := r.pool.QueryRow(ctx, query, locale.Code, locale.Name, locale.FallbackLocale, locale.IsEnabled).
+		Scan(&locale.CreatedAt, &locale.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save locale: %w", err)
+	}
+	return nil
+}
+
+// GetStrings returns every string for a locale as a key to value map
+func (r *I18nRepository) GetStrings(ctx context.Context, locale string) (map[string]string, error) {
+	rows, err := r.pool.Query(ctx, `SELECT key, value FROM i18n_strings WHERE locale = $1`, locale)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get strings: %w", err)
+	}
+	defer rows.Close()
+
+	values := map[string]string{}
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan string: %w", err)
+		}
+		values[key] = value
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate strings: %w", err)
+	}
+	return values, nil
+}
+
+// UpsertStrings saves a batch of strings for a locale and clears any missing
+// key reports they resolve
+func (r *I18nRepository) UpsertStrings(ctx context.Context, locale string, values map[string]string, updatedBy *uuid.UUID) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	batch := &pgx.Batch{}
+	keys := make([]string, 0, len(values))
+	for key, value := range values {
+		batch.Queue(`
+			INSERT INTO i18n_strings (locale, key, value, updated_by)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (locale, key) DO UPDATE SET
+				value = EXCLUDED.value,
+				updated_by = EXCLUDED.updated_by,
+				updated_at = NOW()
+		`, locale, key, value, updatedBy)
+		keys = append(keys, key)
+	}
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("failed to save strings: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM i18n_missing_keys WHERE locale = $1 AND key = ANY($2)`, locale, keys)
+	if err != nil {
+		return fmt.Errorf("failed to clear missing keys: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit strings: %w", err)
+	}
+	return nil
+}
+
+// DeleteString removes a locale's string for a key
+func (r *I18nRepository) DeleteString(ctx context.Context, locale, key string) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM i18n_strings WHERE locale = $1 AND key = $2`, locale, key)
+	if err != nil {
+		return fmt.Errorf("failed to delete string: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrI18nStringNotFound
+	}
+	return nil
+}
+
+// RecordMissingKeys counts a report of keys a client could not resolve
+func (r *I18nRepository) RecordMissingKeys(ctx context.Context, locale string, keys []string) error {
+	query := `
+		INSERT INTO i18n_missing_keys (locale, key)
+		SELECT $1, unnest($2::text[])
+		ON CONFLICT (locale, key) DO UPDATE SET
+			occurrences = i18n_missing_keys.occurrences + 1,
+			last_seen_at = NOW()
+	`
+
+	if _, err := r.pool.Exec(ctx, query, locale, keys); err != nil {
+		return fmt.Errorf("failed to record missing keys: %w", err)
+	}
+	return nil
+}
+
+// ListMissingKeys returns reported missing keys, most recently seen first. An
+// empty locale lists every locale.
+func (r *I18nRepository) ListMissingKeys(ctx context.Context, locale string, limit, offset int) ([]models.I18nMissingKey, error) {
+	query := `
+		SELECT locale, key, occurrences, first_seen_at, last_seen_at
+		FROM i18n_missing_keys
+		WHERE $1 = '' OR locale = $1
+		ORDER BY last_seen_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, locale, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list missing keys: %w", err)
+	}
+	defer rows.Close()
+
+	missing := []models.I18nMissingKey{}
+	for rows.Next() {
+		var m models.I18nMissingKey
+		if err := rows.Scan(&m.Locale, &m.Key, &m.Occurrences, &m.FirstSeenAt, &m.LastSeenAt); err != nil {
+			return nil, fmt.Errorf("failed to scan missing key: %w", err)
+		}
+		missing = append(missing, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate missing keys: %w", err)
+	}
+	return missing, nil
+}
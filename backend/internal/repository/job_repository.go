@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+const jobColumns = `
+	id, kind, payload, status, attempts, max_attempts, run_at, unique_key,
+	locked_by, locked_at, last_error, created_at, updated_at, completed_at`
+
+// JobRepository handles database operations for the persistent job queue
+type JobRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewJobRepository creates a new JobRepository
+func NewJobRepository(pool *pgxpool.Pool) *JobRepository {
+	return &JobRepository{pool: pool}
+}
+
+// Enqueue inserts a pending job. It returns false without inserting when a
+// pending or running job with the same unique key already exists.
+func (r *JobRepository) Enqueue(ctx context.Context, job *models.Job) (bool, error) {
+	query := `
+		INSERT INTO jobs (kind, payload, max_attempts, run_at, unique_key)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (unique_key) WHERE unique_key IS NOT NULL AND status IN ('pending', 'running') DO NOTHING
+		RETURNING id, status, attempts, created_at, updated_at
+	`
+
+	err := r.pool.QueryRow(ctx, query, job.Kind, job.Payload, job.MaxAttempts, job.RunAt, job.UniqueKey).
+		Scan(&job.ID, &job.Status, &job.Attempts, &job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to enqueue job: %w", err)
+	}
+
+	return true, nil
+}
+
+// Dequeue claims up to limit due pending jobs of the given kinds for a worker,
+// counting an attempt for each. Jobs locked by other workers are skipped.
+func (r *JobRepository) Dequeue(ctx context.Context, workerID string, kinds []string, limit int) ([]models.Job, error) {
+	query := fmt.Sprintf(`
+		UPDATE jobs
+		SET status = 'running',
+			attempts = attempts + 1,
+			locked_by = $1,
+			locked_at = NOW(),
+			updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM jobs
+			WHERE status = 'pending' AND run_at <= NOW() AND kind = ANY($2)
+			ORDER BY run_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING %s
+	`, jobColumns)
+
+	rows, err := r.pool.Query(ctx, query, workerID, kinds, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dequeue jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []models.Job{}
+	for rows.Next() {
+		var job models.Job
+		err := rows.Scan(
+			&job.ID,
+			&job.Kind,
+			&job.Payload,
+			&job.Status,
+			&job.Attempts,
+			&job.MaxAttempts,
+			&job.RunAt,
+			&job.UniqueKey,
+			&job.LockedBy,
+			&job.LockedAt,
+			&job.LastError,
+			&job.CreatedAt,
+			&job.UpdatedAt,
+			&job.CompletedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// Complete marks a running job as completed
+func (r *JobRepository) Complete(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE jobs
+		SET status = 'completed', locked_by = NULL, locked_at = NULL, completed_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+	`
+
+	if _, err := r.pool.Exec(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to complete job: %w", err)
+	}
+	return nil
+}
+
+// Retry returns a failed attempt to the queue to run again at runAt
+func (r *JobRepository) Retry(ctx context.Context, id uuid.UUID, runAt time.Time, lastError string) error {
+	query := `
+		UPDATE jobs
+		SET status = 'pending', run_at = $2, last_error = $3, locked_by = NULL, locked_at = NULL, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	if _, err := r.pool.Exec(ctx, query, id, runAt, lastError); err != nil {
+		return fmt.Errorf("failed to schedule job retry: %w", err)
+	}
+	return nil
+}
+
+// Fail marks a job as failed for good
+func (r *JobRepository) Fail(ctx context.Context, id uuid.UUID, lastError string) error {
+	query := `
+		UPDATE jobs
+		SET status = 'failed', last_error = $2, locked_by = NULL, locked_at = NULL, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	if _, err := r.pool.Exec(ctx, query, id, lastError); err != nil {
+		return fmt.Errorf("failed to mark job failed: %w", err)
+	}
+	return nil
+}
+
+// RescueStale returns jobs left running longer than staleAfter, by a worker
+// that crashed or was killed, to the queue. Jobs out of attempts are failed.
+func (r *JobRepository) RescueStale(ctx context.Context, staleAfter time.Duration) (int64, error) {
+	query := `
+		UPDATE jobs
+		SET status = CASE WHEN attempts >= max_attempts THEN 'failed' ELSE 'pending' END,
+			last_error = 'worker stopped before the job finished',
+			locked_by = NULL,
+			locked_at = NULL,
+			updated_at = NOW()
+		WHERE status = 'running' AND locked_at < $1
+	`
+
+	result, err := r.pool.Exec(ctx, query, time.Now().Add(-staleAfter))
+	if err != nil {
+		return 0, fmt.Errorf("failed to rescue stale jobs: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// DeleteFinished deletes completed and failed jobs last updated before the cutoff
+func (r *JobRepository) DeleteFinished(ctx context.Context, olderThan time.Duration) (int64, error) {
+	query := `
+		DELETE FROM jobs
+		WHERE status IN ('completed', 'failed') AND updated_at < $1
+	`
+
+	result, err := r.pool.Exec(ctx, query, time.Now().Add(-olderThan))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete finished jobs: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// CountPendingByKind counts pending jobs per kind
+func (r *JobRepository) CountPendingByKind(ctx context.Context) (map[string]int, error) {
+	rows, err := r.pool.Query(ctx, `SELECT kind, COUNT(*) FROM jobs WHERE status = 'pending' GROUP BY kind`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count pending jobs: %w", err)
+	}
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var kind string
+		var count int
+		if err := rows.Scan(&kind, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan pending job count: %w", err)
+		}
+		counts[kind] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate pending job counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// ClaimDueSchedule reports whether the periodic job is due and, if so, moves
+// its next run one interval ahead. Only one caller can claim each run, so every
+// worker can call this without electing a leader. A new schedule is due at once.
+func (r *JobRepository) ClaimDueSchedule(ctx context.Context, name string, interval time.Duration) (bool, error) {
+	query := `
+		INSERT INTO job_schedules (name, next_run_at, last_run_at)
+		VALUES ($1, NOW() + make_interval(secs => $2), NOW())
+		ON CONFLICT (name) DO UPDATE SET
+			next_run_at = NOW() + make_interval(secs => $2),
+			last_run_at = NOW()
+		WHERE job_schedules.next_run_at <= NOW()
+		RETURNING name
+	`
+
+	var claimed string
+	err := r.pool.QueryRow(ctx, query, name, interval.Seconds()).Scan(&claimed)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to claim job schedule: %w", err)
+	}
+
+	return true, nil
+}
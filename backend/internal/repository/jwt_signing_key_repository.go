@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// JWTSigningKeyRepository handles database operations for JWT signing keys
+type JWTSigningKeyRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewJWTSigningKeyRepository creates a new JWT signing key repository
+func NewJWTSigningKeyRepository(db *pgxpool.Pool) *JWTSigningKeyRepository {
+	return &JWTSigningKeyRepository{db: db}
+}
+
+// ListValid returns keys that have not expired, newest activation first
+func (r *JWTSigningKeyRepository) ListValid(ctx context.Context, now time.Time) ([]*models.JWTSigningKey, error) {
+	query := `
+		SELECT kid, private_key_encrypted, activates_at, expires_at, created_at
+		FROM jwt_signing_keys
+		WHERE expires_at IS NULL OR expires_at > $1
+		ORDER BY activates_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jwt signing keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []*models.JWTSigningKey{}
+	for rows.Next() {
+		var key models.JWTSigningKey
+		if err := rows.Scan(
+			&key.KID,
+			&key.PrivateKeyEncrypted,
+			&key.ActivatesAt,
+			&key.ExpiresAt,
+			&key.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan jwt signing key: %w", err)
+		}
+		keys = append(keys, &key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate jwt signing keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Create stores a key, returning false if a key with the same kid already exists
+func (r *JWTSigningKeyRepository) Create(ctx context.Context, key *models.JWTSigningKey) (bool, error) {
+	query := `
+		INSERT INTO jwt_signing_keys (kid, private_key_encrypted, activates_at, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (kid) DO NOTHING
+	`
+
+	result, err := r.db.Exec(ctx, query, key.KID, key.PrivateKeyEncrypted, key.ActivatesAt, key.ExpiresAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to create jwt signing key: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// Rotate stores a new key unless another instance already rotated after
+// rotatedSince, and schedules every older key to expire at retireAt.
+// Returns false when the rotation was skipped.
+func (r *JWTSigningKeyRepository) Rotate(ctx context.Context, key *models.JWTSigningKey, rotatedSince, retireAt time.Time) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// Serialize rotations across instances for the rest of this transaction
+	if _, err := tx.Exec(ctx, "LOCK TABLE jwt_signing_keys IN SHARE ROW EXCLUSIVE MODE"); err != nil {
+		return false, fmt.Errorf("failed to lock jwt signing keys: %w", err)
+	}
+
+	var alreadyRotated bool
+	err = tx.QueryRow(ctx,
+		"SELECT EXISTS (SELECT 1 FROM jwt_signing_keys WHERE created_at > $1)",
+		rotatedSince,
+	).Scan(&alreadyRotated)
+	if err != nil {
+		return false, fmt.Errorf("failed to check latest jwt signing key: %w", err)
+	}
+	if alreadyRotated {
+		return false, nil
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE jwt_signing_keys
+		SET expires_at = $1
+		WHERE expires_at IS NULL OR expires_at > $1
+	`, retireAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to retire jwt signing keys: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO jwt_signing_keys (kid, private_key_encrypted, activates_at)
+		VALUES ($1, $2, $3)
+	`, key.KID, key.PrivateKeyEncrypted, key.ActivatesAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to create jwt signing key: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit jwt key rotation: %w", err)
+	}
+
+	return true, nil
+}
+
+// DeleteExpired removes keys that expired before the given time
+func (r *JWTSigningKeyRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.Exec(ctx, "DELETE FROM jwt_signing_keys WHERE expires_at IS NOT NULL AND expires_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired jwt signing keys: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// KarmaAuditRepository recomputes karma from votes and comments and applies corrections
+type KarmaAuditRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewKarmaAuditRepository creates a new karma audit repository
+func NewKarmaAuditRepository(pool *pgxpool.Pool) *KarmaAuditRepository {
+	return &KarmaAuditRepository{pool: pool}
+}
+
+// ListKarmaSources returns the stored karma and karma source totals of up to
+// limit users ordered by ID after afterID, or only of userID when set. Clips
+// are owned by their first submitter, as in the vote trigger, and removed
+// clips and comments no longer earn karma. Votes in the karma vote ledger
+// count what they awarded after diminishing returns and vote ring reversals.
+func (r *KarmaAuditRepository) ListKarmaSources(ctx context.Context, afterID uuid.UUID, userID *uuid.UUID, limit int) ([]models.KarmaAuditEntry, error) {
+	query := `
+		WITH batch AS (
+			SELECT id, username, karma_points
+			FROM users
+			WHERE id > $1 AND ($2::uuid IS NULL OR id = $2)
+			ORDER BY id
+			LIMIT $3
+		),
+		owned_clips AS (
+			SELECT DISTINCT ON (c.id) c.id AS clip_id, s.user_id
+			FROM clips c
+			JOIN clip_submissions s ON s.twitch_clip_id = c.twitch_clip_id
+			WHERE c.is_removed = false
+			  AND c.twitch_clip_id IN (
+				SELECT twitch_clip_id FROM clip_submissions WHERE user_id IN (SELECT id FROM batch)
+			  )
+			ORDER BY c.id, s.created_at
+		),
+		clip_vote_totals AS (
+			SELECT o.user_id, SUM(COALESCE(a.amount, v.vote_type)) AS karma
+			FROM owned_clips o
+			JOIN votes v ON v.clip_id = o.clip_id
+			LEFT JOIN karma_vote_awards a
+				ON a.voter_id = v.user_id AND a.source = 'clip_vote' AND a.source_id = v.clip_id
+			GROUP BY o.user_id
+		),
+		comment_totals AS (
+			SELECT cm.user_id,
+				COUNT(DISTINCT cm.id) AS comments,
+				COALESCE(SUM(COALESCE(a.amount, cv.vote_type)), 0) AS karma
+			FROM comments cm
+			LEFT JOIN comment_votes cv ON cv.comment_id = cm.id
+			LEFT JOIN karma_vote_awards a
+				ON a.voter_id = cv.user_id AND a.source = 'comment_vote' AND a.source_id = cm.id
+			WHERE cm.user_id IN (SELECT id FROM batch) AND cm.is_removed = false
+			GROUP BY cm.user_id
+		),
+		decay_totals AS (
+			SELECT user_id, SUM(amount) AS karma
+			FROM karma_history
+			WHERE source = '` + models.KarmaSourceInactivityDecay + `' AND user_id IN (SELECT id FROM batch)
+			GROUP BY user_id
+		)
+		SELECT b.id, b.username, b.karma_points,
+			COALESCE(cvt.karma, 0), COALESCE(ct.karma, 0), COALESCE(ct.comments, 0), COALESCE(dt.karma, 0)
+		FROM batch b
+		LEFT JOIN clip_vote_totals cvt ON cvt.user_id = b.id
+		LEFT JOIN comment_totals ct ON ct.user_id = b.id
+		LEFT JOIN decay_totals dt ON dt.user_id = b.id
+		ORDER BY b.id
+	`
+
+	rows, err := r.pool.Query(ctx, query, afterID, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list karma sources: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []models.KarmaAuditEntry
+	for rows.Next() {
+		var entry models.KarmaAuditEntry
+		if err := rows.Scan(
+			&entry.UserID, &entry.Username, &entry.CurrentKarma,
+			&entry.ClipVoteKarma, &entry.CommentVoteKarma, &entry.Comments, &entry.DecayKarma,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan karma sources: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// ApplyCorrections sets each user's karma to its expected value and records
+// the change in karma history, in a single transaction. Users whose karma
+// changed since the audit are skipped; the rest are marked as applied.
+func (r *KarmaAuditRepository) ApplyCorrections(ctx context.Context, discrepancies []models.KarmaDiscrepancy) (int, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	var applied []int
+	for i := range discrepancies {
+		d := &discrepancies[i]
+		result, err := tx.Exec(ctx, `
+			UPDATE users
+			SET karma_points = $2, updated_at = NOW()
+			WHERE id = $1 AND karma_points = $3
+		`, d.UserID, d.ExpectedKarma, d.CurrentKarma)
+		if err != nil {
+			return 0, fmt.Errorf("failed to correct karma: %w", err)
+		}
+		if result.RowsAffected() == 0 {
+			continue
+		}
+
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO karma_history (user_id, amount, source)
+			VALUES ($1, $2, $3)
+		`, d.UserID, d.ExpectedKarma-d.CurrentKarma, models.KarmaSourceReconciliation); err != nil {
+			return 0, fmt.Errorf("failed to record karma correction: %w", err)
+		}
+		applied = append(applied, i)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit karma corrections: %w", err)
+	}
+
+	for _, i := range applied {
+		discrepancies[i].Applied = true
+	}
+	return len(applied), nil
+}
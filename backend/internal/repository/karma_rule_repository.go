@@ -0,0 +1,248 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// Sentinel errors for karma rule operations
+var (
+	// ErrKarmaRuleNotFound is returned when a rule does not exist
+	ErrKarmaRuleNotFound = errors.New("karma rule not found")
+	// ErrKarmaRuleExists is returned when creating a rule for an event type that already has one
+	ErrKarmaRuleExists = errors.New("karma rule already exists")
+)
+
+const karmaRuleColumns = `
+	event_type, points, daily_cap, cooldown_seconds, enabled, description, updated_by,
+	created_at, updated_at`
+
+// KarmaRuleRepository handles database operations for karma rules and the
+// karma events awarded under them
+type KarmaRuleRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewKarmaRuleRepository creates a new KarmaRuleRepository
+func NewKarmaRuleRepository(pool *pgxpool.Pool) *KarmaRuleRepository {
+	return &KarmaRuleRepository{pool: pool}
+}
+
+func scanKarmaRule(row pgx.Row) (*models.KarmaRule, error) {
+	var rule models.KarmaRule
+	err := row.Scan(
+		&rule.EventType, &rule.Points, &rule.DailyCap, &rule.CooldownSeconds, &rule.Enabled,
+		&rule.Description, &rule.UpdatedBy, &rule.CreatedAt, &rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// ListRules returns all rules by event type
+func (r *KarmaRuleRepository) ListRules(ctx context.Context) ([]models.KarmaRule, error) {
+	query := `SELECT ` + karmaRuleColumns + `
+		FROM karma_rules
+		ORDER BY event_type`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list karma rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []models.KarmaRule{}
+	for rows.Next() {
+		rule, err := scanKarmaRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan karma rule: %w", err)
+		}
+		rules = append(rules, *rule)
+	}
+	return rules, rows.Err()
+}
+
+// GetRule returns the rule for an event type
+func (r *KarmaRuleRepository) GetRule(ctx context.Context, eventType string) (*models.KarmaRule, error) {
+	query := `SELECT ` + karmaRuleColumns + `
+		FROM karma_rules
+		WHERE event_type = $1`
+
+	rule, err := scanKarmaRule(r.pool.QueryRow(ctx, query, eventType))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrKarmaRuleNotFound
+		}
+		return nil, fmt.Errorf("failed to get karma rule: %w", err)
+	}
+	return rule, nil
+}
+
+// CreateRule inserts a rule
+func (r *KarmaRuleRepository) CreateRule(ctx context.Context, rule *models.KarmaRule) error {
+	query := `
+		INSERT INTO karma_rules (event_type, points, daily_cap, cooldown_seconds, enabled, description, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at, updated_at
+	`
+
+	err := r.pool.QueryRow(ctx, query,
+		rule.EventType, rule.Points, rule.DailyCap, rule.CooldownSeconds, rule.Enabled,
+		rule.Description, rule.UpdatedBy,
+	).Scan(&rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrKarmaRuleExists
+		}
+		return fmt.Errorf("failed to create karma rule: %w", err)
+	}
+	return nil
+}
+
+// UpdateRule replaces a rule's settings
+func (r *KarmaRuleRepository) UpdateRule(ctx context.Context, rule *models.KarmaRule) error {
+	query := `
+		UPDATE karma_rules
+		SET points = $2, daily_cap = $3, cooldown_seconds = $4, enabled = $5, description = $6,
+			updated_by = $7, updated_at = NOW()
+		WHERE event_type = $1
+		RETURNING updated_at
+	`
+
+	err := r.pool.QueryRow(ctx, query,
+		rule.EventType, rule.Points, rule.DailyCap, rule.CooldownSeconds, rule.Enabled,
+		rule.Description, rule.UpdatedBy,
+	).Scan(&rule.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrKarmaRuleNotFound
+		}
+		return fmt.Errorf("failed to update karma rule: %w", err)
+	}
+	return nil
+}
+
+// DeleteRule deletes a rule. Karma already awarded under it is kept.
+func (r *KarmaRuleRepository) DeleteRule(ctx context.Context, eventType string) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM karma_rules WHERE event_type = $1`, eventType)
+	if err != nil {
+		return fmt.Errorf("failed to delete karma rule: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrKarmaRuleNotFound
+	}
+	return nil
+}
+
+// ApplyEvent records a karma event for a user and awards the karma the rule
+// allows, given what the user already earned from the event type today. An
+// event already recorded for the same source awards nothing, so replays are
+// safe. It returns the karma awarded and whether the event was new.
+func (r *KarmaRuleRepository) ApplyEvent(ctx context.Context, userID, sourceID uuid.UUID, rule *models.KarmaRule, now time.Time) (int, bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// Lock the user so concurrent events see each other's awards when
+	// applying caps and cooldowns
+	if _, err := tx.Exec(ctx, `SELECT 1 FROM users WHERE id = $1 FOR UPDATE`, userID); err != nil {
+		return 0, false, fmt.Errorf("failed to lock user for karma event: %w", err)
+	}
+
+	var exists bool
+	err = tx.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM karma_events WHERE event_type = $1 AND user_id = $2 AND source_id = $3
+		)`, rule.EventType, userID, sourceID).Scan(&exists)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to check karma event: %w", err)
+	}
+	if exists {
+		return 0, false, nil
+	}
+
+	dayStart := now.UTC().Truncate(24 * time.Hour)
+	var awardedToday int
+	var lastAwardedAt *time.Time
+	err = tx.QueryRow(ctx, `
+		SELECT
+			COALESCE(SUM(points) FILTER (WHERE created_at >= $3 AND points > 0 AND revoked_at IS NULL), 0),
+			MAX(created_at) FILTER (WHERE points <> 0)
+		FROM karma_events
+		WHERE event_type = $1 AND user_id = $2`,
+		rule.EventType, userID, dayStart).Scan(&awardedToday, &lastAwardedAt)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get karma event usage: %w", err)
+	}
+
+	points := rule.PointsFor(awardedToday, lastAwardedAt, now)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO karma_events (event_type, user_id, source_id, points, created_at)
+		VALUES ($1, $2, $3, $4, $5)`,
+		rule.EventType, userID, sourceID, points, now)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to record karma event: %w", err)
+	}
+
+	if points != 0 {
+		_, err = tx.Exec(ctx, `SELECT update_user_karma($1, $2, $3, $4)`, userID, points, rule.EventType, sourceID)
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to award karma: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, false, fmt.Errorf("failed to commit karma event: %w", err)
+	}
+	return points, true, nil
+}
+
+// RevokeEvent takes back the karma a recorded event awarded. Events that
+// were never recorded or were already revoked take back nothing. It returns
+// the karma taken back.
+func (r *KarmaRuleRepository) RevokeEvent(ctx context.Context, userID uuid.UUID, eventType string, sourceID uuid.UUID) (int, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var points int
+	err = tx.QueryRow(ctx, `
+		UPDATE karma_events
+		SET revoked_at = NOW()
+		WHERE event_type = $1 AND user_id = $2 AND source_id = $3 AND revoked_at IS NULL
+		RETURNING points`,
+		eventType, userID, sourceID).Scan(&points)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to revoke karma event: %w", err)
+	}
+
+	if points != 0 {
+		_, err = tx.Exec(ctx, `SELECT update_user_karma($1, $2, $3, $4)`, userID, -points, eventType, sourceID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to revoke karma: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit karma revocation: %w", err)
+	}
+	return points, nil
+}
@@ -0,0 +1,370 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// Audit log actions for vote ring reviews
+const (
+	AuditActionConfirmVoteRing = "confirm_vote_ring"
+	AuditActionDismissVoteRing = "dismiss_vote_ring"
+)
+
+// ErrVoteRingNotFound is returned when a vote ring doesn't exist or has already been reviewed
+var ErrVoteRingNotFound = errors.New("vote ring not found")
+
+const voteRingColumns = `
+	id, member_count, reciprocal_votes, window_start, status, karma_reversed,
+	reviewed_by, reviewed_at, review_notes, created_at`
+
+// KarmaSafeguardRepository handles inactivity karma decay and vote ring
+// detection and review
+type KarmaSafeguardRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewKarmaSafeguardRepository creates a new KarmaSafeguardRepository
+func NewKarmaSafeguardRepository(pool *pgxpool.Pool) *KarmaSafeguardRepository {
+	return &KarmaSafeguardRepository{pool: pool}
+}
+
+// DecayInactiveKarma takes percent of their karma above the floor, at least 1
+// point, from up to limit users last active before inactiveBefore who haven't
+// decayed since decayedSince. It returns the number of users decayed.
+func (r *KarmaSafeguardRepository) DecayInactiveKarma(ctx context.Context, inactiveBefore, decayedSince time.Time, percent, floor, limit int) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `
+		WITH eligible AS (
+			SELECT u.id,
+				LEAST(u.karma_points - $4, GREATEST(1, u.karma_points * $3 / 100)) AS amount
+			FROM users u
+			LEFT JOIN user_stats us ON us.user_id = u.id
+			WHERE COALESCE(u.karma_points, 0) > $4
+			  AND GREATEST(
+				COALESCE(u.created_at, '-infinity'),
+				COALESCE(u.last_login_at, '-infinity'),
+				COALESCE(us.last_active_date::timestamp, '-infinity')
+			  ) < $1
+			  AND NOT EXISTS (
+				SELECT 1 FROM karma_history kh
+				WHERE kh.user_id = u.id AND kh.source = '`+models.KarmaSourceInactivityDecay+`'
+				  AND kh.created_at >= $2
+			  )
+			ORDER BY u.id
+			LIMIT $5
+			FOR UPDATE OF u SKIP LOCKED
+		), decayed AS (
+			UPDATE users u
+			SET karma_points = u.karma_points - e.amount
+			FROM eligible e
+			WHERE u.id = e.id
+			RETURNING u.id, e.amount
+		)
+		INSERT INTO karma_history (user_id, amount, source)
+		SELECT id, -amount, '`+models.KarmaSourceInactivityDecay+`' FROM decayed
+	`, inactiveBefore, decayedSince, percent, floor, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decay inactive karma: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// FindReciprocalVotePairs returns the pairs of users who each upvoted the
+// other's content at least minVotes times since the given time
+func (r *KarmaSafeguardRepository) FindReciprocalVotePairs(ctx context.Context, since time.Time, minVotes int) ([]models.ReciprocalVotePair, error) {
+	rows, err := r.pool.Query(ctx, `
+		WITH given AS (
+			SELECT voter_id, recipient_id, COUNT(*) AS votes
+			FROM karma_vote_awards
+			WHERE vote_type = 1 AND created_at >= $1 AND voter_id <> recipient_id
+			GROUP BY voter_id, recipient_id
+			HAVING COUNT(*) >= $2
+		)
+		SELECT a.voter_id, a.recipient_id, a.votes, b.votes
+		FROM given a
+		JOIN given b ON b.voter_id = a.recipient_id AND b.recipient_id = a.voter_id
+		WHERE a.voter_id < a.recipient_id
+		ORDER BY a.voter_id, a.recipient_id
+	`, since, minVotes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find reciprocal vote pairs: %w", err)
+	}
+	defer rows.Close()
+
+	pairs := []models.ReciprocalVotePair{}
+	for rows.Next() {
+		var p models.ReciprocalVotePair
+		if err := rows.Scan(&p.UserA, &p.UserB, &p.VotesAToB, &p.VotesBToA); err != nil {
+			return nil, fmt.Errorf("failed to scan reciprocal vote pair: %w", err)
+		}
+		pairs = append(pairs, p)
+	}
+	return pairs, rows.Err()
+}
+
+// CreateVoteRing records a vote ring and adds each member to the moderation
+// queue, raising the priority of a pending queue entry a member already has.
+// The returned bool is false when a ring with the same members exists.
+func (r *KarmaSafeguardRepository) CreateVoteRing(ctx context.Context, ring *models.VoteRing, memberKey string, priority int) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO vote_rings (member_key, member_count, reciprocal_votes, window_start)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (member_key) DO NOTHING
+		RETURNING id, status, created_at
+	`, memberKey, ring.MemberCount, ring.ReciprocalVotes, ring.WindowStart).Scan(&ring.ID, &ring.Status, &ring.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to create vote ring: %w", err)
+	}
+
+	for i := range ring.Members {
+		member := &ring.Members[i]
+		var queueID uuid.UUID
+		err = tx.QueryRow(ctx, `
+			INSERT INTO moderation_queue (
+				content_type, content_id, reason, priority, status,
+				auto_flagged, report_count, created_at
+			) VALUES ('user', $1, $2, $3, 'pending', true, 1, NOW())
+			ON CONFLICT (content_type, content_id) WHERE status = 'pending'
+			DO UPDATE SET
+				priority = GREATEST(moderation_queue.priority, EXCLUDED.priority),
+				report_count = moderation_queue.report_count + 1
+			RETURNING id
+		`, member.UserID, models.VoteRingQueueReason, priority).Scan(&queueID)
+		if err != nil {
+			return false, fmt.Errorf("failed to queue vote ring member: %w", err)
+		}
+		member.QueueItemID = &queueID
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO vote_ring_members (ring_id, user_id, votes_given, votes_received, queue_item_id)
+			VALUES ($1, $2, $3, $4, $5)
+		`, ring.ID, member.UserID, member.VotesGiven, member.VotesReceived, queueID)
+		if err != nil {
+			return false, fmt.Errorf("failed to add vote ring member: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit vote ring: %w", err)
+	}
+	return true, nil
+}
+
+// ListVoteRings returns vote rings with their members, those with the most
+// reciprocal votes first
+func (r *KarmaSafeguardRepository) ListVoteRings(ctx context.Context, filters models.VoteRingFilters) ([]models.VoteRing, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+voteRingColumns+`
+		FROM vote_rings vr
+		WHERE ($1::text = '' OR vr.status = $1)
+		  AND ($2::uuid IS NULL OR EXISTS (
+			SELECT 1 FROM vote_ring_members m WHERE m.ring_id = vr.id AND m.user_id = $2
+		  ))
+		ORDER BY vr.reciprocal_votes DESC, vr.created_at
+		LIMIT $3 OFFSET $4
+	`, filters.Status, filters.UserID, filters.Limit, filters.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list vote rings: %w", err)
+	}
+
+	rings := []models.VoteRing{}
+	for rows.Next() {
+		ring, err := scanVoteRing(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan vote ring: %w", err)
+		}
+		rings = append(rings, *ring)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list vote rings: %w", err)
+	}
+
+	for i := range rings {
+		if rings[i].Members, err = r.listVoteRingMembers(ctx, rings[i].ID); err != nil {
+			return nil, err
+		}
+	}
+	return rings, nil
+}
+
+// GetVoteRing returns a vote ring with its members
+func (r *KarmaSafeguardRepository) GetVoteRing(ctx context.Context, id uuid.UUID) (*models.VoteRing, error) {
+	ring, err := scanVoteRing(r.pool.QueryRow(ctx, `SELECT `+voteRingColumns+` FROM vote_rings WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrVoteRingNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vote ring: %w", err)
+	}
+	if ring.Members, err = r.listVoteRingMembers(ctx, id); err != nil {
+		return nil, err
+	}
+	return ring, nil
+}
+
+func (r *KarmaSafeguardRepository) listVoteRingMembers(ctx context.Context, ringID uuid.UUID) ([]models.VoteRingMember, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT m.user_id, u.username, m.votes_given, m.votes_received, m.queue_item_id
+		FROM vote_ring_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.ring_id = $1
+		ORDER BY m.votes_given + m.votes_received DESC, u.username
+	`, ringID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list vote ring members: %w", err)
+	}
+	defer rows.Close()
+
+	members := []models.VoteRingMember{}
+	for rows.Next() {
+		var m models.VoteRingMember
+		if err := rows.Scan(&m.UserID, &m.Username, &m.VotesGiven, &m.VotesReceived, &m.QueueItemID); err != nil {
+			return nil, fmt.Errorf("failed to scan vote ring member: %w", err)
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
+
+// ReviewVoteRing confirms or dismisses a pending vote ring. Confirming
+// reverses the karma members' upvotes on each other's content awarded since
+// the ring's window started. Members' moderation queue entries are resolved
+// with queueStatus once nothing else pending refers to them.
+func (r *KarmaSafeguardRepository) ReviewVoteRing(ctx context.Context, id uuid.UUID, status, queueStatus string, reviewerID uuid.UUID, notes *string) (*models.VoteRing, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	ring, err := scanVoteRing(tx.QueryRow(ctx, `
+		UPDATE vote_rings
+		SET status = $2, reviewed_by = $3, reviewed_at = NOW(), review_notes = $4
+		WHERE id = $1 AND status = 'pending'
+		RETURNING `+voteRingColumns, id, status, reviewerID, notes))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrVoteRingNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to review vote ring: %w", err)
+	}
+
+	if status == models.VoteRingConfirmed {
+		if ring.KarmaReversed, err = reverseVoteRingKarma(ctx, tx, ring); err != nil {
+			return nil, err
+		}
+		_, err = tx.Exec(ctx, `UPDATE vote_rings SET karma_reversed = $2 WHERE id = $1`, id, ring.KarmaReversed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record reversed vote ring karma: %w", err)
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE moderation_queue q
+		SET status = $2, reviewed_by = $3, reviewed_at = NOW()
+		WHERE q.id IN (
+			SELECT queue_item_id FROM vote_ring_members
+			WHERE ring_id = $1 AND queue_item_id IS NOT NULL
+		  )
+		  AND q.status = 'pending'
+		  AND NOT EXISTS (
+			SELECT 1 FROM vote_ring_members m
+			JOIN vote_rings vr ON vr.id = m.ring_id
+			WHERE m.queue_item_id = q.id AND vr.status = 'pending'
+		  )
+		  AND NOT EXISTS (
+			SELECT 1 FROM ban_evasion_flags f
+			WHERE f.queue_item_id = q.id AND f.status = 'pending'
+		  )
+	`, id, queueStatus, reviewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve vote ring queue entries: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit vote ring review: %w", err)
+	}
+	return ring, nil
+}
+
+// reverseVoteRingKarma takes back the karma members' upvotes on each other's
+// content awarded, zeroing the votes in the ledger so removing them later
+// doesn't reverse them again. It returns the karma reversed.
+func reverseVoteRingKarma(ctx context.Context, tx pgx.Tx, ring *models.VoteRing) (int, error) {
+	rows, err := tx.Query(ctx, `
+		WITH ring_votes AS (
+			SELECT a.voter_id, a.source, a.source_id, a.recipient_id, a.amount
+			FROM karma_vote_awards a
+			JOIN vote_ring_members v ON v.ring_id = $1 AND v.user_id = a.voter_id
+			JOIN vote_ring_members rc ON rc.ring_id = $1 AND rc.user_id = a.recipient_id
+			WHERE a.created_at >= $2 AND a.amount > 0
+			FOR UPDATE OF a
+		), zeroed AS (
+			UPDATE karma_vote_awards a
+			SET amount = 0
+			FROM ring_votes rv
+			WHERE a.voter_id = rv.voter_id AND a.source = rv.source AND a.source_id = rv.source_id
+			RETURNING rv.recipient_id, rv.amount
+		)
+		SELECT recipient_id, SUM(amount)::int FROM zeroed GROUP BY recipient_id
+	`, ring.ID, ring.WindowStart)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reverse vote ring karma: %w", err)
+	}
+
+	reversals := map[uuid.UUID]int{}
+	for rows.Next() {
+		var userID uuid.UUID
+		var amount int
+		if err := rows.Scan(&userID, &amount); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan vote ring karma: %w", err)
+		}
+		reversals[userID] = amount
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to reverse vote ring karma: %w", err)
+	}
+
+	total := 0
+	for userID, amount := range reversals {
+		_, err := tx.Exec(ctx, `SELECT update_user_karma($1, $2, $3, $4)`,
+			userID, -amount, models.KarmaSourceVoteRingReversal, ring.ID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to reverse vote ring karma: %w", err)
+		}
+		total += amount
+	}
+	return total, nil
+}
+
+func scanVoteRing(row pgx.Row) (*models.VoteRing, error) {
+	var ring models.VoteRing
+	err := row.Scan(
+		&ring.ID, &ring.MemberCount, &ring.ReciprocalVotes, &ring.WindowStart, &ring.Status, &ring.KarmaReversed,
+		&ring.ReviewedBy, &ring.ReviewedAt, &ring.ReviewNotes, &ring.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &ring, nil
+}
@@ -0,0 +1,309 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// ErrLeaderboardSnapshotNotFound is returned when a leaderboard hasn't been computed
+var ErrLeaderboardSnapshotNotFound = errors.New("leaderboard snapshot not found")
+
+const leaderboardSnapshotColumns = `
+	id, leaderboard_type, period, period_key, period_start, period_end, scope, scope_id,
+	entry_count, finalized, badges_awarded_at, computed_at`
+
+// Activity counted toward each leaderboard type, with the clip it was on when
+// known. Karma from votes, comments and submissions is traced back to the clip.
+var leaderboardActivityQueries = map[string]string{
+	models.LeaderboardTypeKarma: `
+		SELECT kh.user_id, kh.amount AS points,
+			CASE
+				WHEN kh.source IN ('clip_vote', 'clip_claimed') THEN kh.source_id
+				WHEN kh.source IN ('comment_vote', 'comment_created') THEN (SELECT clip_id FROM comments WHERE id = kh.source_id)
+				WHEN kh.source = 'submission_approved' THEN (SELECT clip_id FROM clip_submissions WHERE id = kh.source_id)
+			END AS clip_id
+		FROM karma_history kh
+		WHERE kh.created_at >= $1 AND kh.created_at < $2`,
+	// Weighted like the all-time engagement score: 2 per comment, 1 per vote, 5 per submission
+	models.LeaderboardTypeEngagement: `
+		SELECT user_id, 2 AS points, clip_id FROM comments
+		WHERE created_at >= $1 AND created_at < $2
+		UNION ALL
+		SELECT user_id, 1, clip_id FROM votes
+		WHERE created_at >= $1 AND created_at < $2
+		UNION ALL
+		SELECT cv.user_id, 1, cm.clip_id FROM comment_votes cv
+		JOIN comments cm ON cm.id = cv.comment_id
+		WHERE cv.created_at >= $1 AND cv.created_at < $2
+		UNION ALL
+		SELECT user_id, 5, clip_id FROM clip_submissions
+		WHERE created_at >= $1 AND created_at < $2`,
+}
+
+// How activity is grouped into each leaderboard scope
+var leaderboardScopeQueries = map[string]string{
+	models.LeaderboardScopeGlobal: `
+		SELECT '' AS scope_id, a.user_id, a.points FROM activity a`,
+	models.LeaderboardScopeGame: `
+		SELECT c.game_id AS scope_id, a.user_id, a.points FROM activity a
+		JOIN clips c ON c.id = a.clip_id
+		WHERE c.game_id IS NOT NULL AND c.game_id <> ''`,
+	models.LeaderboardScopeCommunity: `
+		SELECT cc.community_id::text AS scope_id, a.user_id, a.points FROM activity a
+		JOIN community_clips cc ON cc.clip_id = a.clip_id`,
+}
+
+// LeaderboardSnapshotRepository computes and stores weekly, monthly and
+// seasonal leaderboards
+type LeaderboardSnapshotRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewLeaderboardSnapshotRepository creates a new LeaderboardSnapshotRepository
+func NewLeaderboardSnapshotRepository(pool *pgxpool.Pool) *LeaderboardSnapshotRepository {
+	return &LeaderboardSnapshotRepository{pool: pool}
+}
+
+func scanLeaderboardSnapshot(row pgx.Row) (*models.LeaderboardSnapshot, error) {
+	var s models.LeaderboardSnapshot
+	err := row.Scan(
+		&s.ID, &s.Type, &s.Period, &s.PeriodKey, &s.PeriodStart, &s.PeriodEnd, &s.Scope, &s.ScopeID,
+		&s.EntryCount, &s.Finalized, &s.BadgesAwardedAt, &s.ComputedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// ComputeScores returns the top limit users of every leaderboard of a type and
+// scope for activity between start and end, ordered by scope and rank. Banned
+// users and users without a positive score are left out.
+func (r *LeaderboardSnapshotRepository) ComputeScores(ctx context.Context, leaderboardType, scope string, start, end time.Time, limit int) ([]models.LeaderboardScore, error) {
+	activity, ok := leaderboardActivityQueries[leaderboardType]
+	if !ok {
+		return nil, fmt.Errorf("unknown leaderboard type %q", leaderboardType)
+	}
+	scoped, ok := leaderboardScopeQueries[scope]
+	if !ok {
+		return nil, fmt.Errorf("unknown leaderboard scope %q", scope)
+	}
+
+	query := `
+		WITH activity AS (` + activity + `
+		),
+		scoped AS (` + scoped + `
+		),
+		totals AS (
+			SELECT scope_id, user_id, SUM(points)::int AS score
+			FROM scoped
+			GROUP BY scope_id, user_id
+			HAVING SUM(points) > 0
+		),
+		ranked AS (
+			SELECT t.scope_id, t.user_id, t.score,
+				ROW_NUMBER() OVER (PARTITION BY t.scope_id ORDER BY t.score DESC, t.user_id) AS rank
+			FROM totals t
+			JOIN users u ON u.id = t.user_id
+			WHERE u.is_banned = false
+		)
+		SELECT scope_id, rank, user_id, score
+		FROM ranked
+		WHERE rank <= $3
+		ORDER BY scope_id, rank
+	`
+
+	rows, err := r.pool.Query(ctx, query, start, end, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute leaderboard: %w", err)
+	}
+	defer rows.Close()
+
+	var scores []models.LeaderboardScore
+	for rows.Next() {
+		var s models.LeaderboardScore
+		if err := rows.Scan(&s.ScopeID, &s.Rank, &s.UserID, &s.Score); err != nil {
+			return nil, fmt.Errorf("failed to scan leaderboard score: %w", err)
+		}
+		scores = append(scores, s)
+	}
+	return scores, rows.Err()
+}
+
+// SaveSnapshot stores a leaderboard and its entries, replacing the previous
+// computation of the same leaderboard. Finalized snapshots are left as they are.
+func (r *LeaderboardSnapshotRepository) SaveSnapshot(ctx context.Context, snapshot *models.LeaderboardSnapshot, scores []models.LeaderboardScore) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO leaderboard_snapshots (
+			leaderboard_type, period, period_key, period_start, period_end, scope, scope_id,
+			entry_count, finalized, computed_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (leaderboard_type, period, period_key, scope, scope_id) DO UPDATE
+		SET entry_count = EXCLUDED.entry_count, finalized = EXCLUDED.finalized,
+			computed_at = EXCLUDED.computed_at
+		WHERE leaderboard_snapshots.finalized = false
+		RETURNING id`,
+		snapshot.Type, snapshot.Period, snapshot.PeriodKey, snapshot.PeriodStart, snapshot.PeriodEnd,
+		snapshot.Scope, snapshot.ScopeID, len(scores), snapshot.Finalized, snapshot.ComputedAt,
+	).Scan(&snapshot.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Already finalized
+			return nil
+		}
+		return fmt.Errorf("failed to save leaderboard snapshot: %w", err)
+	}
+	snapshot.EntryCount = len(scores)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM leaderboard_snapshot_entries WHERE snapshot_id = $1`, snapshot.ID); err != nil {
+		return fmt.Errorf("failed to clear leaderboard snapshot entries: %w", err)
+	}
+
+	if len(scores) > 0 {
+		ranks := make([]int, len(scores))
+		userIDs := make([]uuid.UUID, len(scores))
+		points := make([]int, len(scores))
+		for i, s := range scores {
+			ranks[i], userIDs[i], points[i] = s.Rank, s.UserID, s.Score
+		}
+		_, err := tx.Exec(ctx, `
+			INSERT INTO leaderboard_snapshot_entries (snapshot_id, rank, user_id, score)
+			SELECT $1, rank, user_id, score
+			FROM unnest($2::int[], $3::uuid[], $4::int[]) AS e(rank, user_id, score)`,
+			snapshot.ID, ranks, userIDs, points)
+		if err != nil {
+			return fmt.Errorf("failed to save leaderboard snapshot entries: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit leaderboard snapshot: %w", err)
+	}
+	return nil
+}
+
+// DeleteStaleSnapshots deletes the unfinalized leaderboards of a type, period
+// and scope that weren't computed since before, such as a community whose
+// clips were removed. It returns the number deleted.
+func (r *LeaderboardSnapshotRepository) DeleteStaleSnapshots(ctx context.Context, leaderboardType, period, periodKey, scope string, before time.Time) (int64, error) {
+	result, err := r.pool.Exec(ctx, `
+		DELETE FROM leaderboard_snapshots
+		WHERE leaderboard_type = $1 AND period = $2 AND period_key = $3 AND scope = $4
+		  AND finalized = false AND computed_at < $5`,
+		leaderboardType, period, periodKey, scope, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete stale leaderboard snapshots: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// HasFinalizedPeriod reports whether any leaderboard of a period was finalized
+func (r *LeaderboardSnapshotRepository) HasFinalizedPeriod(ctx context.Context, period, periodKey string) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM leaderboard_snapshots
+			WHERE period = $1 AND period_key = $2 AND finalized = true
+		)`, period, periodKey).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check finalized leaderboards: %w", err)
+	}
+	return exists, nil
+}
+
+// GetSnapshot returns a computed leaderboard
+func (r *LeaderboardSnapshotRepository) GetSnapshot(ctx context.Context, leaderboardType, period, periodKey, scope, scopeID string) (*models.LeaderboardSnapshot, error) {
+	query := `SELECT ` + leaderboardSnapshotColumns + `
+		FROM leaderboard_snapshots
+		WHERE leaderboard_type = $1 AND period = $2 AND period_key = $3 AND scope = $4 AND scope_id = $5`
+
+	snapshot, err := scanLeaderboardSnapshot(r.pool.QueryRow(ctx, query, leaderboardType, period, periodKey, scope, scopeID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrLeaderboardSnapshotNotFound
+		}
+		return nil, fmt.Errorf("failed to get leaderboard snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+// ListSnapshotEntries returns a page of a computed leaderboard's entries, by rank
+func (r *LeaderboardSnapshotRepository) ListSnapshotEntries(ctx context.Context, snapshotID uuid.UUID, limit, offset int) ([]models.LeaderboardEntry, error) {
+	query := `
+		SELECT e.rank, u.id, u.username, u.display_name, u.avatar_url, e.score,
+			get_user_rank(u.karma_points)
+		FROM leaderboard_snapshot_entries e
+		JOIN users u ON u.id = e.user_id
+		WHERE e.snapshot_id = $1
+		ORDER BY e.rank
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, snapshotID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list leaderboard snapshot entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []models.LeaderboardEntry{}
+	for rows.Next() {
+		var entry models.LeaderboardEntry
+		if err := rows.Scan(
+			&entry.Rank, &entry.UserID, &entry.Username, &entry.DisplayName, &entry.AvatarURL,
+			&entry.Score, &entry.UserRank,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan leaderboard snapshot entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// ListSnapshotsAwaitingBadges returns the finalized leaderboards of a type,
+// period and scope whose badges haven't been awarded
+func (r *LeaderboardSnapshotRepository) ListSnapshotsAwaitingBadges(ctx context.Context, leaderboardType, period, scope string) ([]models.LeaderboardSnapshot, error) {
+	query := `SELECT ` + leaderboardSnapshotColumns + `
+		FROM leaderboard_snapshots
+		WHERE leaderboard_type = $1 AND period = $2 AND scope = $3
+		  AND finalized = true AND badges_awarded_at IS NULL
+		ORDER BY period_start`
+
+	rows, err := r.pool.Query(ctx, query, leaderboardType, period, scope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list leaderboard snapshots awaiting badges: %w", err)
+	}
+	defer rows.Close()
+
+	snapshots := []models.LeaderboardSnapshot{}
+	for rows.Next() {
+		snapshot, err := scanLeaderboardSnapshot(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan leaderboard snapshot: %w", err)
+		}
+		snapshots = append(snapshots, *snapshot)
+	}
+	return snapshots, rows.Err()
+}
+
+// MarkBadgesAwarded records that a leaderboard's badges were awarded
+func (r *LeaderboardSnapshotRepository) MarkBadgesAwarded(ctx context.Context, snapshotID uuid.UUID) error {
+	_, err := r.pool.Exec(ctx, `UPDATE leaderboard_snapshots SET badges_awarded_at = NOW() WHERE id = $1`, snapshotID)
+	if err != nil {
+		return fmt.Errorf("failed to mark leaderboard badges awarded: %w", err)
+	}
+	return nil
+}
@@ -122,9 +122,27 @@ func (r *MFARepository) UpdateMFA(ctx context.Context, mfa *models.UserMFA) erro
 	return nil
 }
 
-// DeleteMFA deletes MFA configuration for a user
+// DeleteMFA removes a user's MFA enrollment. When MFA is required for the user
+// the row is kept with its requirement and grace deadline so that disabling or
+// recovering MFA never starts a new grace period.
 func (r *MFARepository) DeleteMFA(ctx context.Context, userID uuid.UUID) error {
-	query := `DELETE FROM user_mfa WHERE user_id = $1`
+	query := `
+		WITH cleared AS (
+			UPDATE user_mfa
+			SET secret = '',
+			    enabled = false,
+			    enrolled_at = NULL,
+			    backup_codes = ARRAY[]::TEXT[],
+			    backup_codes_generated_at = NULL,
+			    recovery_token_hash = NULL,
+			    recovery_token_expires_at = NULL,
+			    updated_at = NOW()
+			WHERE user_id = $1 AND mfa_required = true
+			RETURNING user_id
+		)
+		DELETE FROM user_mfa
+		WHERE user_id = $1 AND mfa_required = false
+	`
 
 	_, err := r.db.Exec(ctx, query, userID)
 	if err != nil {
@@ -151,6 +169,49 @@ func (r *MFARepository) ConsumeBackupCode(ctx context.Context, userID uuid.UUID,
 	return nil
 }
 
+// SetRecoveryToken stores the hash of a pending MFA recovery token, replacing any previous one
+func (r *MFARepository) SetRecoveryToken(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) error {
+	query := `
+		UPDATE user_mfa
+		SET recovery_token_hash = $1,
+		    recovery_token_expires_at = $2,
+		    updated_at = NOW()
+		WHERE user_id = $3
+	`
+
+	_, err := r.db.Exec(ctx, query, tokenHash, expiresAt, userID)
+	if err != nil {
+		return fmt.Errorf("failed to set recovery token: %w", err)
+	}
+
+	return nil
+}
+
+// ConsumeRecoveryToken atomically clears a matching, unexpired recovery token and
+// returns the user it belonged to. Returns false if no valid token matched.
+func (r *MFARepository) ConsumeRecoveryToken(ctx context.Context, tokenHash string) (uuid.UUID, bool, error) {
+	query := `
+		UPDATE user_mfa
+		SET recovery_token_hash = NULL,
+		    recovery_token_expires_at = NULL,
+		    updated_at = NOW()
+		WHERE recovery_token_hash = $1
+		  AND recovery_token_expires_at > NOW()
+		RETURNING user_id
+	`
+
+	var userID uuid.UUID
+	err := r.db.QueryRow(ctx, query, tokenHash).Scan(&userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, false, nil
+	}
+	if err != nil {
+		return uuid.Nil, false, fmt.Errorf("failed to consume recovery token: %w", err)
+	}
+
+	return userID, true, nil
+}
+
 // GetTrustedDevices retrieves all trusted devices for a user
 func (r *MFARepository) GetTrustedDevices(ctx context.Context, userID uuid.UUID) ([]*models.MFATrustedDevice, error) {
 	query := `
@@ -406,7 +467,9 @@ func (r *MFARepository) CleanupExpiredTrustedDevices(ctx context.Context) error
 	return nil
 }
 
-// SetMFARequired marks MFA as required for a user and sets grace period
+// SetMFARequired marks MFA as required for a user and sets grace period.
+// The first requirement date and grace deadline are kept once set, so calling
+// this again never extends the grace period.
 func (r *MFARepository) SetMFARequired(ctx context.Context, userID uuid.UUID, gracePeriodDays int) error {
 	now := time.Now()
 	gracePeriodEnd := now.AddDate(0, 0, gracePeriodDays)
@@ -417,14 +480,8 @@ func (r *MFARepository) SetMFARequired(ctx context.Context, userID uuid.UUID, gr
 		ON CONFLICT (user_id) 
 		DO UPDATE SET 
 			mfa_required = true,
-			mfa_required_at = CASE 
-				WHEN user_mfa.mfa_required = false THEN $2 
-				ELSE user_mfa.mfa_required_at 
-			END,
-			grace_period_end = CASE 
-				WHEN user_mfa.mfa_required = false OR user_mfa.grace_period_end IS NULL OR user_mfa.grace_period_end < NOW() THEN $3
-				ELSE user_mfa.grace_period_end 
-			END,
+			mfa_required_at = COALESCE(user_mfa.mfa_required_at, $2),
+			grace_period_end = COALESCE(user_mfa.grace_period_end, $3),
 			updated_at = NOW()
 	`
 
@@ -278,3 +278,39 @@ func (r *MirrorRepository) GetPopularClipsForMirroring(ctx context.Context, thre
 
 	return clipIDs, rows.Err()
 }
+
+// GetClipsWithFailingEmbeds returns clips whose Twitch embed fails for at least
+// minFailureRate of the minEvents or more loads players reported, worst first
+func (r *MirrorRepository) GetClipsWithFailingEmbeds(ctx context.Context, minEvents int, minFailureRate float64, maxMirrors int, limit int) ([]uuid.UUID, error) {
+	query := `
+		SELECT c.id
+		FROM clips c
+		JOIN clip_embed_health h ON h.clip_id = c.id
+		LEFT JOIN clip_mirrors cm ON c.id = cm.clip_id AND cm.status = 'active'
+		WHERE h.embed_loads + h.embed_failures >= $1
+			AND h.embed_failures::float / (h.embed_loads + h.embed_failures) >= $2
+			AND c.is_removed = false
+			AND c.dmca_removed = false
+		GROUP BY c.id, h.embed_failures, h.embed_loads
+		HAVING COUNT(cm.id) < $3
+		ORDER BY h.embed_failures::float / (h.embed_loads + h.embed_failures) DESC, h.embed_failures DESC
+		LIMIT $4
+	`
+
+	rows, err := r.db.Query(ctx, query, minEvents, minFailureRate, maxMirrors, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var clipIDs []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		clipIDs = append(clipIDs, id)
+	}
+
+	return clipIDs, rows.Err()
+}
@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// Audit log actions for the appeal workflow, recorded against the appeal
+const (
+	AuditActionAssignAppeal  = "assign_appeal"
+	AuditActionResolveAppeal = "resolve_appeal"
+)
+
+var (
+	// ErrModerationActionNotFound is returned when an appeal names a moderation decision that doesn't exist
+	ErrModerationActionNotFound = errors.New("moderation action not found")
+	// ErrModerationAppealNotFound is returned when an appeal doesn't exist or is no longer open
+	ErrModerationAppealNotFound = errors.New("moderation appeal not found")
+	// ErrModerationAppealOpen is returned when a moderation decision already has an open appeal
+	ErrModerationAppealOpen = errors.New("moderation action already has an open appeal")
+	// ErrAppealContentUnsupported is returned for decisions on content that can't be appealed
+	ErrAppealContentUnsupported = errors.New("content type cannot be appealed")
+)
+
+const moderationAppealColumns = `
+	id, user_id, moderation_action_id, reason, status, assigned_to, assigned_at,
+	resolved_by, resolution, created_at, resolved_at`
+
+// appealDetailsQuery selects appeals with the decision they contest
+const appealDetailsQuery = `
+	SELECT ma.id, ma.user_id, ma.moderation_action_id, ma.reason, ma.status,
+	       ma.assigned_to, ma.assigned_at, ma.resolved_by, ma.resolution,
+	       ma.created_at, ma.resolved_at,
+	       u.username, u.display_name,
+	       md.action, md.reason, mq.content_type, mq.content_id
+	FROM moderation_appeals ma
+	JOIN users u ON ma.user_id = u.id
+	JOIN moderation_decisions md ON ma.moderation_action_id = md.id
+	JOIN moderation_queue mq ON md.queue_item_id = mq.id`
+
+// ModerationAppealRepository handles database operations for moderation appeals
+type ModerationAppealRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewModerationAppealRepository creates a new ModerationAppealRepository
+func NewModerationAppealRepository(pool *pgxpool.Pool) *ModerationAppealRepository {
+	return &ModerationAppealRepository{pool: pool}
+}
+
+// GetActionContent returns the content a moderation decision was made on
+func (r *ModerationAppealRepository) GetActionContent(ctx context.Context, actionID uuid.UUID) (string, uuid.UUID, error) {
+	var contentType string
+	var contentID uuid.UUID
+	err := r.pool.QueryRow(ctx, `
+		SELECT mq.content_type, mq.content_id
+		FROM moderation_decisions md
+		JOIN moderation_queue mq ON md.queue_item_id = mq.id
+		WHERE md.id = $1
+	`, actionID).Scan(&contentType, &contentID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", uuid.Nil, ErrModerationActionNotFound
+	}
+	if err != nil {
+		return "", uuid.Nil, fmt.Errorf("failed to get moderation action: %w", err)
+	}
+	return contentType, contentID, nil
+}
+
+// OwnsContent reports whether a user wrote the comment, submitted the clip or
+// is the user a moderation decision was made on
+func (r *ModerationAppealRepository) OwnsContent(ctx context.Context, userID uuid.UUID, contentType string, contentID uuid.UUID) (bool, error) {
+	var query string
+	switch contentType {
+	case "comment":
+		query = `SELECT EXISTS(SELECT 1 FROM comments WHERE id = $1 AND user_id = $2)`
+	case "clip":
+		query = `SELECT EXISTS(SELECT 1 FROM clips WHERE id = $1 AND submitted_by_user_id = $2)`
+	case "user":
+		return contentID == userID, nil
+	default:
+		return false, ErrAppealContentUnsupported
+	}
+
+	var owns bool
+	if err := r.pool.QueryRow(ctx, query, contentID, userID).Scan(&owns); err != nil {
+		return false, fmt.Errorf("failed to verify content ownership: %w", err)
+	}
+	return owns, nil
+}
+
+// Create inserts a pending appeal
+func (r *ModerationAppealRepository) Create(ctx context.Context, appeal *models.ModerationAppeal) error {
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO moderation_appeals (user_id, moderation_action_id, reason)
+		VALUES ($1, $2, $3)
+		RETURNING id, status, created_at
+	`, appeal.UserID, appeal.ModerationActionID, appeal.Reason).Scan(&appeal.ID, &appeal.Status, &appeal.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrModerationAppealOpen
+		}
+		return fmt.Errorf("failed to create appeal: %w", err)
+	}
+	return nil
+}
+
+// ListQueue returns the appeals matching the filters, oldest first
+func (r *ModerationAppealRepository) ListQueue(ctx context.Context, filters models.AppealQueueFilters) ([]models.ModerationAppealWithDetails, error) {
+	statuses := []string{filters.Status}
+	if filters.Status == "open" {
+		statuses = []string{models.AppealStatusPending, models.AppealStatusInReview}
+	}
+
+	rows, err := r.pool.Query(ctx, appealDetailsQuery+`
+		WHERE ma.status = ANY($1)
+		  AND ($2::uuid IS NULL OR ma.assigned_to = $2)
+		  AND (NOT $3 OR ma.assigned_to IS NULL)
+		ORDER BY ma.created_at ASC
+		LIMIT $4
+	`, statuses, filters.AssignedTo, filters.Unassigned, filters.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list appeals: %w", err)
+	}
+	return collectAppealDetails(rows)
+}
+
+// ListForUser returns a user's appeals, newest first. The appellant's own
+// name is left out.
+func (r *ModerationAppealRepository) ListForUser(ctx context.Context, userID uuid.UUID, limit int) ([]models.ModerationAppealWithDetails, error) {
+	rows, err := r.pool.Query(ctx, appealDetailsQuery+`
+		WHERE ma.user_id = $1
+		ORDER BY ma.created_at DESC
+		LIMIT $2
+	`, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user appeals: %w", err)
+	}
+	appeals, err := collectAppealDetails(rows)
+	if err != nil {
+		return nil, err
+	}
+	for i := range appeals {
+		appeals[i].Username = ""
+		appeals[i].DisplayName = ""
+	}
+	return appeals, nil
+}
+
+// Assign puts an open appeal in review with a moderator and reports whether
+// it is the appeal's first assignment. Reassigning an appeal already in review
+// keeps its original assignment time, so the SLA measures how long the
+// appellant waited for someone to pick it up.
+func (r *ModerationAppealRepository) Assign(ctx context.Context, id, assigneeID uuid.UUID) (*models.ModerationAppeal, bool, error) {
+	var a models.ModerationAppeal
+	var first bool
+	err := r.pool.QueryRow(ctx, `
+		WITH previous AS (
+			SELECT assigned_at FROM moderation_appeals WHERE id = $1 FOR UPDATE
+		)
+		UPDATE moderation_appeals
+		SET status = 'in_review', assigned_to = $2, assigned_at = COALESCE(assigned_at, NOW())
+		WHERE id = $1 AND status IN ('pending', 'in_review')
+		RETURNING `+moderationAppealColumns+`, (SELECT assigned_at IS NULL FROM previous)
+	`, id, assigneeID).Scan(
+		&a.ID, &a.UserID, &a.ModerationActionID, &a.Reason, &a.Status, &a.AssignedTo, &a.AssignedAt,
+		&a.ResolvedBy, &a.Resolution, &a.CreatedAt, &a.ResolvedAt, &first,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, ErrModerationAppealNotFound
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to assign appeal: %w", err)
+	}
+	return &a, first, nil
+}
+
+// Resolve approves or rejects an open appeal
+func (r *ModerationAppealRepository) Resolve(ctx context.Context, id uuid.UUID, status string, resolvedBy uuid.UUID, resolution *string) (*models.ModerationAppeal, error) {
+	row := r.pool.QueryRow(ctx, `
+		UPDATE moderation_appeals
+		SET status = $2, resolved_by = $3, resolution = $4
+		WHERE id = $1 AND status IN ('pending', 'in_review')
+		RETURNING `+moderationAppealColumns, id, status, resolvedBy, resolution)
+	appeal, err := scanModerationAppeal(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrModerationAppealNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve appeal: %w", err)
+	}
+	return appeal, nil
+}
+
+// GetSLAMetrics measures the open appeals against the assignment and
+// resolution SLAs, and how quickly appeals resolved in the last windowDays
+// were picked up and decided
+func (r *ModerationAppealRepository) GetSLAMetrics(ctx context.Context, windowDays, assignmentSLAHours, resolutionSLAHours int) (*models.AppealSLAMetrics, error) {
+	metrics := &models.AppealSLAMetrics{
+		WindowDays:         windowDays,
+		AssignmentSLAHours: assignmentSLAHours,
+		ResolutionSLAHours: resolutionSLAHours,
+	}
+
+	err := r.pool.QueryRow(ctx, `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE assigned_to IS NULL),
+			COUNT(*) FILTER (WHERE assigned_to IS NULL AND created_at < NOW() - make_interval(hours => $1)),
+			COUNT(*) FILTER (WHERE created_at < NOW() - make_interval(hours => $2)),
+			EXTRACT(EPOCH FROM NOW() - MIN(created_at))::float8 / 3600
+		FROM moderation_appeals
+		WHERE status IN ('pending', 'in_review')
+	`, assignmentSLAHours, resolutionSLAHours).Scan(
+		&metrics.Open, &metrics.Unassigned, &metrics.OpenBreachingAssignment,
+		&metrics.OpenBreachingResolution, &metrics.OldestOpenHours,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to measure open appeals: %w", err)
+	}
+
+	err = r.pool.QueryRow(ctx, `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE status = 'approved'),
+			COUNT(*) FILTER (WHERE status = 'rejected'),
+			COUNT(*) FILTER (WHERE resolved_at - created_at <= make_interval(hours => $2)),
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM assigned_at - created_at)::float8 / 3600)
+				FILTER (WHERE assigned_at IS NOT NULL),
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM resolved_at - created_at)::float8 / 3600),
+			percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM resolved_at - created_at)::float8 / 3600)
+		FROM moderation_appeals
+		WHERE status IN ('approved', 'rejected') AND resolved_at >= NOW() - make_interval(days => $1)
+	`, windowDays, resolutionSLAHours).Scan(
+		&metrics.Resolved, &metrics.Approved, &metrics.Rejected, &metrics.ResolvedWithinSLA,
+		&metrics.MedianAssignmentHours, &metrics.MedianResolutionHours, &metrics.P90ResolutionHours,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to measure resolved appeals: %w", err)
+	}
+
+	if metrics.Resolved > 0 {
+		percent := float64(metrics.ResolvedWithinSLA) / float64(metrics.Resolved) * 100
+		metrics.ResolutionSLAPercent = &percent
+	}
+	return metrics, nil
+}
+
+func scanModerationAppeal(row pgx.Row) (*models.ModerationAppeal, error) {
+	var a models.ModerationAppeal
+	err := row.Scan(
+		&a.ID, &a.UserID, &a.ModerationActionID, &a.Reason, &a.Status, &a.AssignedTo, &a.AssignedAt,
+		&a.ResolvedBy, &a.Resolution, &a.CreatedAt, &a.ResolvedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func collectAppealDetails(rows pgx.Rows) ([]models.ModerationAppealWithDetails, error) {
+	defer rows.Close()
+
+	appeals := []models.ModerationAppealWithDetails{}
+	for rows.Next() {
+		var a models.ModerationAppealWithDetails
+		err := rows.Scan(
+			&a.ID, &a.UserID, &a.ModerationActionID, &a.Reason, &a.Status,
+			&a.AssignedTo, &a.AssignedAt, &a.ResolvedBy, &a.Resolution,
+			&a.CreatedAt, &a.ResolvedAt,
+			&a.Username, &a.DisplayName,
+			&a.DecisionAction, &a.DecisionReason, &a.ContentType, &a.ContentID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan appeal: %w", err)
+		}
+		appeals = append(appeals, a)
+	}
+	return appeals, rows.Err()
+}
@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	pgvector "github.com/pgvector/pgvector-go"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// moderationCaseSource describes how to select cases of one type in a common shape
+type moderationCaseSource struct {
+	columns string
+	from    string
+	alias   string
+	decided string
+}
+
+var moderationCaseSources = map[string]moderationCaseSource{
+	models.ModerationCaseSubmission: {
+		columns: `
+			'submission', s.id, COALESCE(NULLIF(s.custom_title, ''), s.title, ''),
+			s.broadcaster_id, s.broadcaster_name, s.game_id, s.game_name, s.user_id,
+			NULL::text, NULL::text, COALESCE(s.status, 'pending'), s.rejection_reason, s.reviewed_at, s.reviewed_by, s.created_at`,
+		from:    `clip_submissions s`,
+		alias:   "s",
+		decided: `s.status IN ('approved', 'rejected')`,
+	},
+	models.ModerationCaseReport: {
+		columns: `
+			'report', r.id, COALESCE(c.title, LEFT(cm.content, 200), u.username, ''),
+			c.broadcaster_id, c.broadcaster_name, c.game_id, c.game_name,
+			COALESCE(c.submitted_by_user_id, cm.user_id, u.id),
+			r.reportable_type, r.reason, COALESCE(r.status, 'pending'), NULL::text, r.reviewed_at, r.reviewed_by, r.created_at`,
+		from: `reports r
+			LEFT JOIN clips c ON r.reportable_type = 'clip' AND c.id = r.reportable_id
+			LEFT JOIN comments cm ON r.reportable_type = 'comment' AND cm.id = r.reportable_id
+			LEFT JOIN users u ON r.reportable_type = 'user' AND u.id = r.reportable_id`,
+		alias:   "r",
+		decided: `r.status <> 'pending'`,
+	},
+}
+
+// ModerationCaseRepository reads submissions and reports as moderation cases and
+// stores their title embeddings for similar-case lookups
+type ModerationCaseRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewModerationCaseRepository creates a new ModerationCaseRepository
+func NewModerationCaseRepository(pool *pgxpool.Pool) *ModerationCaseRepository {
+	return &ModerationCaseRepository{pool: pool}
+}
+
+func moderationCaseSourceFor(caseType string) (moderationCaseSource, error) {
+	source, ok := moderationCaseSources[caseType]
+	if !ok {
+		return moderationCaseSource{}, fmt.Errorf("unknown moderation case type %q", caseType)
+	}
+	return source, nil
+}
+
+// GetCase retrieves a submission or report as a moderation case
+func (r *ModerationCaseRepository) GetCase(ctx context.Context, caseType string, id uuid.UUID) (*models.ModerationCase, error) {
+	source, err := moderationCaseSourceFor(caseType)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE %s.id = $1`, source.columns, source.from, source.alias)
+
+	rows, err := r.pool.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get moderation case: %w", err)
+	}
+	cases, err := scanModerationCases(rows, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(cases) == 0 {
+		return nil, ErrModerationCaseNotFound
+	}
+
+	return &cases[0].ModerationCase, nil
+}
+
+// ListSimilarByEmbedding lists decided cases of the same type whose title embeddings
+// are closest to the given case's embedding
+func (r *ModerationCaseRepository) ListSimilarByEmbedding(ctx context.Context, caseType string, caseID uuid.UUID, limit int) ([]models.SimilarModerationCase, error) {
+	source, err := moderationCaseSourceFor(caseType)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s, 1 - (e.embedding <=> seed.embedding)
+		FROM %s
+		JOIN moderation_case_embeddings e ON e.case_type = $1 AND e.case_id = %s.id,
+		     (SELECT embedding FROM moderation_case_embeddings WHERE case_type = $1 AND case_id = $2) seed
+		WHERE %s
+		  AND %s.id <> $2
+		ORDER BY e.embedding <=> seed.embedding ASC
+		LIMIT $3
+	`, source.columns, source.from, source.alias, source.decided, source.alias)
+
+	rows, err := r.pool.Query(ctx, query, caseType, caseID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list similar moderation cases: %w", err)
+	}
+	return scanModerationCases(rows, true)
+}
+
+// ListMetadataMatches lists the most recent decided cases of the same type that
+// share a broadcaster, game or user with the given case, or a reason for reports
+func (r *ModerationCaseRepository) ListMetadataMatches(ctx context.Context, target *models.ModerationCase, limit int) ([]models.SimilarModerationCase, error) {
+	source, err := moderationCaseSourceFor(target.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT * FROM (
+			SELECT %s
+			FROM %s
+			WHERE %s AND %s.id <> $1
+		) cases (case_type, id, title, broadcaster_id, broadcaster_name, game_id, game_name, subject_user_id,
+		         content_type, reason, outcome, decision_reason, decided_at, decided_by, created_at)
+		WHERE (broadcaster_id IS NOT NULL AND broadcaster_id = $2)
+		   OR (game_id IS NOT NULL AND game_id = $3)
+		   OR (subject_user_id IS NOT NULL AND subject_user_id = $4)
+		   OR (reason IS NOT NULL AND reason = $5 AND content_type = $6)
+		ORDER BY decided_at DESC NULLS LAST
+		LIMIT $7
+	`, source.columns, source.from, source.decided, source.alias)
+
+	rows, err := r.pool.Query(ctx, query,
+		target.ID, target.BroadcasterID, target.GameID, target.SubjectUserID, target.Reason, target.ContentType, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list moderation cases by metadata: %w", err)
+	}
+	return scanModerationCases(rows, false)
+}
+
+// HasEmbedding reports whether a title embedding is stored for a case
+func (r *ModerationCaseRepository) HasEmbedding(ctx context.Context, caseType string, caseID uuid.UUID) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM moderation_case_embeddings WHERE case_type = $1 AND case_id = $2)
+	`, caseType, caseID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check moderation case embedding: %w", err)
+	}
+	return exists, nil
+}
+
+// UpsertEmbedding stores the title embedding of a case
+func (r *ModerationCaseRepository) UpsertEmbedding(ctx context.Context, caseType string, caseID uuid.UUID, embedding []float32, model string) error {
+	query := `
+		INSERT INTO moderation_case_embeddings (case_type, case_id, embedding, embedding_model)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (case_type, case_id) DO UPDATE SET
+			embedding = EXCLUDED.embedding,
+			embedding_model = EXCLUDED.embedding_model,
+			created_at = NOW()
+	`
+
+	if _, err := r.pool.Exec(ctx, query, caseType, caseID, pgvector.NewVector(embedding), model); err != nil {
+		return fmt.Errorf("failed to store moderation case embedding: %w", err)
+	}
+	return nil
+}
+
+// ListDecidedWithoutEmbedding lists recently decided cases with a title but no stored embedding
+func (r *ModerationCaseRepository) ListDecidedWithoutEmbedding(ctx context.Context, caseType string, limit int) ([]models.ModerationCase, error) {
+	source, err := moderationCaseSourceFor(caseType)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE %s
+		  AND NOT EXISTS (
+			SELECT 1 FROM moderation_case_embeddings e WHERE e.case_type = $1 AND e.case_id = %s.id
+		  )
+		ORDER BY %s.reviewed_at DESC NULLS LAST
+		LIMIT $2
+	`, source.columns, source.from, source.decided, source.alias, source.alias)
+
+	rows, err := r.pool.Query(ctx, query, caseType, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list moderation cases without embeddings: %w", err)
+	}
+	similar, err := scanModerationCases(rows, false)
+	if err != nil {
+		return nil, err
+	}
+
+	cases := make([]models.ModerationCase, 0, len(similar))
+	for _, c := range similar {
+		if c.Title != "" {
+			cases = append(cases, c.ModerationCase)
+		}
+	}
+	return cases, nil
+}
+
+// scanModerationCases scans rows selected with a moderationCaseSource, optionally
+// followed by a similarity column
+func scanModerationCases(rows pgx.Rows, withSimilarity bool) ([]models.SimilarModerationCase, error) {
+	defer rows.Close()
+
+	cases := []models.SimilarModerationCase{}
+	for rows.Next() {
+		var c models.SimilarModerationCase
+		dest := []interface{}{
+			&c.Type,
+			&c.ID,
+			&c.Title,
+			&c.BroadcasterID,
+			&c.BroadcasterName,
+			&c.GameID,
+			&c.GameName,
+			&c.SubjectUserID,
+			&c.ContentType,
+			&c.Reason,
+			&c.Outcome,
+			&c.DecisionReason,
+			&c.DecidedAt,
+			&c.DecidedBy,
+			&c.CreatedAt,
+		}
+		if withSimilarity {
+			dest = append(dest, &c.Similarity)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("failed to scan moderation case: %w", err)
+		}
+		cases = append(cases, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate moderation cases: %w", err)
+	}
+
+	return cases, nil
+}
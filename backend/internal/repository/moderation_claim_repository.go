@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+var (
+	// ErrModerationClaimNotFound is returned when an item has no active claim by the moderator
+	ErrModerationClaimNotFound = errors.New("moderation claim not found")
+	// ErrModerationItemClaimed is returned when another moderator holds the claim on an item
+	ErrModerationItemClaimed = errors.New("item is claimed by another moderator")
+)
+
+const moderationClaimColumns = `
+	id, item_type, item_id, claimed_by, claimed_at, expires_at, released_at, release_reason`
+
+// ModerationClaimRepository handles database operations for moderation claims
+type ModerationClaimRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewModerationClaimRepository creates a new ModerationClaimRepository
+func NewModerationClaimRepository(pool *pgxpool.Pool) *ModerationClaimRepository {
+	return &ModerationClaimRepository{pool: pool}
+}
+
+// Claim claims an item for a moderator until ttl from now. An expired claim
+// on the item is released first, and claiming an item the moderator already
+// holds extends their claim. When another moderator holds the item, their
+// claim is returned with ErrModerationItemClaimed. The returned bool reports
+// whether a new claim was made.
+func (r *ModerationClaimRepository) Claim(ctx context.Context, itemType string, itemID, moderatorID uuid.UUID, ttl time.Duration) (*models.ModerationClaim, bool, error) {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE moderation_claims
+		SET released_at = NOW(), release_reason = $3
+		WHERE item_type = $1 AND item_id = $2 AND released_at IS NULL AND expires_at <= NOW()
+	`, itemType, itemID, models.ModerationClaimReleaseExpired)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to release expired claim: %w", err)
+	}
+
+	var c models.ModerationClaim
+	var created bool
+	err = r.pool.QueryRow(ctx, `
+		INSERT INTO moderation_claims (item_type, item_id, claimed_by, expires_at)
+		VALUES ($1, $2, $3, NOW() + make_interval(secs => $4))
+		ON CONFLICT (item_type, item_id) WHERE released_at IS NULL
+		DO UPDATE SET expires_at = EXCLUDED.expires_at
+		WHERE moderation_claims.claimed_by = EXCLUDED.claimed_by
+		RETURNING `+moderationClaimColumns+`, (xmax = 0)
+	`, itemType, itemID, moderatorID, ttl.Seconds()).Scan(
+		&c.ID, &c.ItemType, &c.ItemID, &c.ClaimedBy, &c.ClaimedAt, &c.ExpiresAt,
+		&c.ReleasedAt, &c.ReleaseReason, &created,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		holder, err := r.GetActive(ctx, itemType, itemID)
+		if err != nil {
+			return nil, false, err
+		}
+		return holder, false, ErrModerationItemClaimed
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to claim item: %w", err)
+	}
+	return &c, created, nil
+}
+
+// GetActive returns the unexpired claim on an item
+func (r *ModerationClaimRepository) GetActive(ctx context.Context, itemType string, itemID uuid.UUID) (*models.ModerationClaim, error) {
+	row := r.pool.QueryRow(ctx, `
+		SELECT `+moderationClaimColumns+`
+		FROM moderation_claims
+		WHERE item_type = $1 AND item_id = $2 AND released_at IS NULL AND expires_at > NOW()
+	`, itemType, itemID)
+	claim, err := scanModerationClaim(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrModerationClaimNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get claim: %w", err)
+	}
+	return claim, nil
+}
+
+// Release ends a moderator's claim on an item, recording why
+func (r *ModerationClaimRepository) Release(ctx context.Context, itemType string, itemID, moderatorID uuid.UUID, reason string) (*models.ModerationClaim, error) {
+	row := r.pool.QueryRow(ctx, `
+		UPDATE moderation_claims
+		SET released_at = NOW(), release_reason = $4
+		WHERE item_type = $1 AND item_id = $2 AND claimed_by = $3 AND released_at IS NULL
+		RETURNING `+moderationClaimColumns, itemType, itemID, moderatorID, reason)
+	claim, err := scanModerationClaim(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrModerationClaimNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to release claim: %w", err)
+	}
+	return claim, nil
+}
+
+// ListActive returns the unexpired claims, oldest first, optionally only
+// those held by one moderator
+func (r *ModerationClaimRepository) ListActive(ctx context.Context, moderatorID *uuid.UUID) ([]models.ModerationClaim, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+moderationClaimColumns+`
+		FROM moderation_claims
+		WHERE released_at IS NULL AND expires_at > NOW()
+		  AND ($1::uuid IS NULL OR claimed_by = $1)
+		ORDER BY claimed_at
+	`, moderatorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list claims: %w", err)
+	}
+	defer rows.Close()
+
+	claims := []models.ModerationClaim{}
+	for rows.Next() {
+		claim, err := scanModerationClaim(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan claim: %w", err)
+		}
+		claims = append(claims, *claim)
+	}
+	return claims, rows.Err()
+}
+
+// ReleaseExpired releases the claims that weren't resolved before they expired
+func (r *ModerationClaimRepository) ReleaseExpired(ctx context.Context) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE moderation_claims
+		SET released_at = NOW(), release_reason = $1
+		WHERE released_at IS NULL AND expires_at <= NOW()
+	`, models.ModerationClaimReleaseExpired)
+	if err != nil {
+		return 0, fmt.Errorf("failed to release expired claims: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// GetWorkload reports the open claims and the claims that ended in the last
+// windowDays, overall and per moderator. Time to resolution is measured from
+// claim to resolution.
+func (r *ModerationClaimRepository) GetWorkload(ctx context.Context, windowDays int) (*models.ModerationWorkload, error) {
+	workload := &models.ModerationWorkload{WindowDays: windowDays}
+
+	err := r.pool.QueryRow(ctx, `
+		SELECT
+			COUNT(*) FILTER (WHERE released_at IS NULL AND expires_at > NOW()),
+			COUNT(*) FILTER (WHERE release_reason = 'resolved'),
+			COUNT(*) FILTER (WHERE release_reason = 'expired'),
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM released_at - claimed_at)::float8 / 60)
+				FILTER (WHERE release_reason = 'resolved'),
+			percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM released_at - claimed_at)::float8 / 60)
+				FILTER (WHERE release_reason = 'resolved')
+		FROM moderation_claims
+		WHERE released_at IS NULL OR released_at >= NOW() - make_interval(days => $1)
+	`, windowDays).Scan(
+		&workload.ActiveClaims, &workload.Resolved, &workload.Expired,
+		&workload.MedianResolutionMinutes, &workload.P90ResolutionMinutes,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to measure claims: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT mc.claimed_by, u.username,
+			COUNT(*) FILTER (WHERE mc.released_at IS NULL AND mc.expires_at > NOW()),
+			COUNT(*) FILTER (WHERE mc.release_reason = 'resolved'),
+			COUNT(*) FILTER (WHERE mc.release_reason = 'released'),
+			COUNT(*) FILTER (WHERE mc.release_reason = 'expired'),
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM mc.released_at - mc.claimed_at)::float8 / 60)
+				FILTER (WHERE mc.release_reason = 'resolved'),
+			AVG(EXTRACT(EPOCH FROM mc.released_at - mc.claimed_at)::float8 / 60)
+				FILTER (WHERE mc.release_reason = 'resolved')
+		FROM moderation_claims mc
+		JOIN users u ON mc.claimed_by = u.id
+		WHERE mc.released_at IS NULL OR mc.released_at >= NOW() - make_interval(days => $1)
+		GROUP BY mc.claimed_by, u.username
+		ORDER BY 3 DESC, 4 DESC, u.username
+	`, windowDays)
+	if err != nil {
+		return nil, fmt.Errorf("failed to measure moderator workload: %w", err)
+	}
+	defer rows.Close()
+
+	workload.Moderators = []models.ModeratorWorkload{}
+	for rows.Next() {
+		var m models.ModeratorWorkload
+		err := rows.Scan(
+			&m.ModeratorID, &m.Username, &m.ActiveClaims, &m.Resolved, &m.Released, &m.Expired,
+			&m.MedianResolutionMinutes, &m.AverageResolutionMinutes,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan moderator workload: %w", err)
+		}
+		workload.Moderators = append(workload.Moderators, m)
+	}
+	return workload, rows.Err()
+}
+
+func scanModerationClaim(row pgx.Row) (*models.ModerationClaim, error) {
+	var c models.ModerationClaim
+	err := row.Scan(
+		&c.ID, &c.ItemType, &c.ItemID, &c.ClaimedBy, &c.ClaimedAt, &c.ExpiresAt,
+		&c.ReleasedAt, &c.ReleaseReason,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,363 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// ErrModerationShiftNotFound is returned when no moderation shift has the ID
+var ErrModerationShiftNotFound = errors.New("moderation shift not found")
+
+const moderationShiftColumns = `
+	id, name, timezone, start_time, duration_minutes, moderator_ids, email_recipients,
+	email_enabled, last_reported_end, created_by, created_at, updated_at`
+
+// ModerationShiftRepository handles database operations for moderation
+// shifts and the activity their handoff reports summarize. The moderation
+// tables store UTC times without a time zone, so report windows are passed
+// in UTC.
+type ModerationShiftRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewModerationShiftRepository creates a new ModerationShiftRepository
+func NewModerationShiftRepository(pool *pgxpool.Pool) *ModerationShiftRepository {
+	return &ModerationShiftRepository{pool: pool}
+}
+
+func scanModerationShift(row pgx.Row) (*models.ModerationShift, error) {
+	var shift models.ModerationShift
+	err := row.Scan(
+		&shift.ID, &shift.Name, &shift.Timezone, &shift.StartTime, &shift.DurationMinutes, &shift.ModeratorIDs,
+		&shift.EmailRecipients, &shift.EmailEnabled, &shift.LastReportedEnd, &shift.CreatedBy, &shift.CreatedAt, &shift.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &shift, nil
+}
+
+func (r *ModerationShiftRepository) list(ctx context.Context, query string, args ...interface{}) ([]models.ModerationShift, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list moderation shifts: %w", err)
+	}
+	defer rows.Close()
+
+	shifts := []models.ModerationShift{}
+	for rows.Next() {
+		shift, err := scanModerationShift(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan moderation shift: %w", err)
+		}
+		shifts = append(shifts, *shift)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating moderation shifts: %w", err)
+	}
+	return shifts, nil
+}
+
+// List returns every moderation shift by name
+func (r *ModerationShiftRepository) List(ctx context.Context) ([]models.ModerationShift, error) {
+	return r.list(ctx, `SELECT `+moderationShiftColumns+` FROM moderation_shifts ORDER BY name, created_at`)
+}
+
+// ListEmailEnabled returns the shifts whose handoff reports are emailed
+func (r *ModerationShiftRepository) ListEmailEnabled(ctx context.Context) ([]models.ModerationShift, error) {
+	return r.list(ctx, `SELECT `+moderationShiftColumns+` FROM moderation_shifts WHERE email_enabled ORDER BY created_at, id`)
+}
+
+// GetByID returns a moderation shift by ID
+func (r *ModerationShiftRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ModerationShift, error) {
+	shift, err := scanModerationShift(r.pool.QueryRow(ctx, `SELECT `+moderationShiftColumns+` FROM moderation_shifts WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrModerationShiftNotFound
+		}
+		return nil, fmt.Errorf("failed to get moderation shift: %w", err)
+	}
+	return shift, nil
+}
+
+// Create stores a new moderation shift
+func (r *ModerationShiftRepository) Create(ctx context.Context, shift *models.ModerationShift) error {
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO moderation_shifts (
+			name, timezone, start_time, duration_minutes, moderator_ids, email_recipients,
+			email_enabled, last_reported_end, created_by
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at, updated_at
+	`, shift.Name, shift.Timezone, shift.StartTime, shift.DurationMinutes, shift.ModeratorIDs, shift.EmailRecipients,
+		shift.EmailEnabled, shift.LastReportedEnd, shift.CreatedBy,
+	).Scan(&shift.ID, &shift.CreatedAt, &shift.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create moderation shift: %w", err)
+	}
+	return nil
+}
+
+// Update saves a moderation shift's schedule, team and email settings
+func (r *ModerationShiftRepository) Update(ctx context.Context, shift *models.ModerationShift) error {
+	err := r.pool.QueryRow(ctx, `
+		UPDATE moderation_shifts
+		SET name = $2, timezone = $3, start_time = $4, duration_minutes = $5, moderator_ids = $6,
+			email_recipients = $7, email_enabled = $8, last_reported_end = $9, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at
+	`, shift.ID, shift.Name, shift.Timezone, shift.StartTime, shift.DurationMinutes, shift.ModeratorIDs,
+		shift.EmailRecipients, shift.EmailEnabled, shift.LastReportedEnd,
+	).Scan(&shift.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrModerationShiftNotFound
+		}
+		return fmt.Errorf("failed to update moderation shift: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a moderation shift
+func (r *ModerationShiftRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM moderation_shifts WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete moderation shift: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrModerationShiftNotFound
+	}
+	return nil
+}
+
+// ClaimReport records that the handoff report of the shift ending at end is
+// being sent, returning false when it already was. Claiming first keeps API
+// replicas from sending the same report twice.
+func (r *ModerationShiftRepository) ClaimReport(ctx context.Context, id uuid.UUID, end time.Time) (bool, error) {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE moderation_shifts
+		SET last_reported_end = $2
+		WHERE id = $1 AND email_enabled AND (last_reported_end IS NULL OR last_reported_end < $2)
+	`, id, end)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim moderation shift report: %w", err)
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// ReleaseReport gives back a claimed report that failed to send, so the next
+// run retries it. previous is the end of the report sent before.
+func (r *ModerationShiftRepository) ReleaseReport(ctx context.Context, id uuid.UUID, end time.Time, previous *time.Time) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE moderation_shifts SET last_reported_end = $3 WHERE id = $1 AND last_reported_end = $2
+	`, id, end, previous)
+	if err != nil {
+		return fmt.Errorf("failed to release moderation shift report: %w", err)
+	}
+	return nil
+}
+
+// shiftModerators returns the moderator filter of a report query, where an
+// empty list matches every moderator
+func shiftModerators(moderatorIDs []uuid.UUID) []uuid.UUID {
+	if moderatorIDs == nil {
+		return []uuid.UUID{}
+	}
+	return moderatorIDs
+}
+
+// GetActivity counts the queue decisions and appeal resolutions of each
+// moderator in [start, end), busiest first. An empty moderatorIDs covers
+// every moderator.
+func (r *ModerationShiftRepository) GetActivity(ctx context.Context, start, end time.Time, moderatorIDs []uuid.UUID) ([]models.ModerationShiftActivity, error) {
+	query := `
+		WITH decisions AS (
+			SELECT
+				moderator_id,
+				COUNT(DISTINCT queue_item_id) AS items_handled,
+				COUNT(*) FILTER (WHERE action = 'approve') AS approved,
+				COUNT(*) FILTER (WHERE action = 'reject') AS rejected,
+				COUNT(*) FILTER (WHERE action = 'escalate') AS escalated,
+				COUNT(*) FILTER (WHERE action = 'ban_user') AS banned
+			FROM moderation_decisions
+			WHERE created_at >= $1 AND created_at < $2 AND moderator_id IS NOT NULL
+				AND (cardinality($3::uuid[]) = 0 OR moderator_id = ANY($3::uuid[]))
+			GROUP BY moderator_id
+		),
+		appeals AS (
+			SELECT resolved_by AS moderator_id, COUNT(*) AS appeals_resolved
+			FROM moderation_appeals
+			WHERE resolved_at >= $1 AND resolved_at < $2 AND resolved_by IS NOT NULL
+				AND (cardinality($3::uuid[]) = 0 OR resolved_by = ANY($3::uuid[]))
+			GROUP BY resolved_by
+		)
+		SELECT
+			COALESCE(d.moderator_id, a.moderator_id) AS moderator_id,
+			u.username,
+			COALESCE(d.items_handled, 0),
+			COALESCE(d.approved, 0),
+			COALESCE(d.rejected, 0),
+			COALESCE(d.escalated, 0),
+			COALESCE(d.banned, 0),
+			COALESCE(a.appeals_resolved, 0)
+		FROM decisions d
+		FULL OUTER JOIN appeals a ON a.moderator_id = d.moderator_id
+		LEFT JOIN users u ON u.id = COALESCE(d.moderator_id, a.moderator_id)
+		ORDER BY 3 DESC, 8 DESC, 2
+	`
+
+	rows, err := r.pool.Query(ctx, query, start.UTC(), end.UTC(), shiftModerators(moderatorIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get moderation shift activity: %w", err)
+	}
+	defer rows.Close()
+
+	activity := []models.ModerationShiftActivity{}
+	for rows.Next() {
+		var a models.ModerationShiftActivity
+		if err := rows.Scan(
+			&a.ModeratorID, &a.Username, &a.ItemsHandled, &a.Approved, &a.Rejected,
+			&a.Escalated, &a.Banned, &a.AppealsResolved,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan moderation shift activity: %w", err)
+		}
+		activity = append(activity, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating moderation shift activity: %w", err)
+	}
+	return activity, nil
+}
+
+// GetQueueSnapshot returns the current size of the moderation queue, with the
+// age at asOf of its oldest pending item
+func (r *ModerationShiftRepository) GetQueueSnapshot(ctx context.Context, asOf time.Time) (*models.ModerationShiftQueue, error) {
+	var queue models.ModerationShiftQueue
+	var oldestPending *time.Time
+	err := r.pool.QueryRow(ctx, `
+		SELECT
+			COUNT(*) FILTER (WHERE status = 'pending'),
+			COUNT(*) FILTER (WHERE status = 'pending' AND priority >= $1),
+			COUNT(*) FILTER (WHERE status = 'escalated'),
+			MIN(created_at) FILTER (WHERE status = 'pending')
+		FROM moderation_queue
+		WHERE status IN ('pending', 'escalated')
+	`, models.ModerationShiftHighPriority).Scan(&queue.Pending, &queue.HighPriority, &queue.Escalated, &oldestPending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get moderation queue snapshot: %w", err)
+	}
+	queue.OldestPendingHours = hoursSince(oldestPending, asOf)
+	return &queue, nil
+}
+
+// ListEscalations returns up to limit queue items escalated in [start, end),
+// highest priority first
+func (r *ModerationShiftRepository) ListEscalations(ctx context.Context, start, end time.Time, moderatorIDs []uuid.UUID, limit int) ([]models.ModerationShiftEscalation, error) {
+	query := `
+		SELECT
+			q.id, q.content_type, q.content_id, q.reason, COALESCE(q.priority, 0), COALESCE(q.status, 'pending'),
+			md.moderator_id, u.username, md.reason, md.created_at
+		FROM moderation_decisions md
+		JOIN moderation_queue q ON q.id = md.queue_item_id
+		LEFT JOIN users u ON u.id = md.moderator_id
+		WHERE md.action = 'escalate' AND md.created_at >= $1 AND md.created_at < $2
+			AND (cardinality($3::uuid[]) = 0 OR md.moderator_id = ANY($3::uuid[]))
+		ORDER BY q.priority DESC NULLS LAST, md.created_at DESC
+		LIMIT $4
+	`
+
+	rows, err := r.pool.Query(ctx, query, start.UTC(), end.UTC(), shiftModerators(moderatorIDs), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list moderation shift escalations: %w", err)
+	}
+	defer rows.Close()
+
+	escalations := []models.ModerationShiftEscalation{}
+	for rows.Next() {
+		var e models.ModerationShiftEscalation
+		if err := rows.Scan(
+			&e.QueueItemID, &e.ContentType, &e.ContentID, &e.Reason, &e.Priority, &e.Status,
+			&e.EscalatedBy, &e.EscalatedByUsername, &e.Note, &e.EscalatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan moderation shift escalation: %w", err)
+		}
+		escalations = append(escalations, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating moderation shift escalations: %w", err)
+	}
+	return escalations, nil
+}
+
+// GetAppealSummary counts the open appeals, those opened in [start, end) and
+// those moderatorIDs resolved then, and lists up to limit of the oldest
+// open appeals
+func (r *ModerationShiftRepository) GetAppealSummary(ctx context.Context, start, end time.Time, moderatorIDs []uuid.UUID, asOf time.Time, limit int) (*models.ModerationShiftAppeals, error) {
+	var appeals models.ModerationShiftAppeals
+	var oldestOpen *time.Time
+	err := r.pool.QueryRow(ctx, `
+		SELECT
+			COUNT(*) FILTER (WHERE status IN ('pending', 'in_review')),
+			COUNT(*) FILTER (WHERE created_at >= $1 AND created_at < $2),
+			COUNT(*) FILTER (
+				WHERE resolved_at >= $1 AND resolved_at < $2
+					AND (cardinality($3::uuid[]) = 0 OR resolved_by = ANY($3::uuid[]))
+			),
+			MIN(created_at) FILTER (WHERE status IN ('pending', 'in_review'))
+		FROM moderation_appeals
+		WHERE status IN ('pending', 'in_review') OR created_at >= $1 OR resolved_at >= $1
+	`, start.UTC(), end.UTC(), shiftModerators(moderatorIDs),
+	).Scan(&appeals.Open, &appeals.OpenedDuringShift, &appeals.ResolvedDuringShift, &oldestOpen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get moderation shift appeal summary: %w", err)
+	}
+	appeals.OldestOpenHours = hoursSince(oldestOpen, asOf)
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, user_id, moderation_action_id, reason, status, assigned_to, assigned_at,
+		       resolved_by, resolution, created_at, resolved_at
+		FROM moderation_appeals
+		WHERE status IN ('pending', 'in_review')
+		ORDER BY created_at
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open appeals: %w", err)
+	}
+	defer rows.Close()
+
+	appeals.OldestOpen = []models.ModerationAppeal{}
+	for rows.Next() {
+		var a models.ModerationAppeal
+		if err := rows.Scan(
+			&a.ID, &a.UserID, &a.ModerationActionID, &a.Reason, &a.Status,
+			&a.AssignedTo, &a.AssignedAt, &a.ResolvedBy, &a.Resolution, &a.CreatedAt, &a.ResolvedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan open appeal: %w", err)
+		}
+		appeals.OldestOpen = append(appeals.OldestOpen, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating open appeals: %w", err)
+	}
+	return &appeals, nil
+}
+
+// hoursSince returns the hours from t to asOf, or nil without t
+func hoursSince(t *time.Time, asOf time.Time) *float64 {
+	if t == nil {
+		return nil
+	}
+	hours := asOf.Sub(*t).Hours()
+	if hours < 0 {
+		hours = 0
+	}
+	return &hours
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// NotificationDigestRepository handles database operations for notification digests
+type NotificationDigestRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewNotificationDigestRepository creates a new NotificationDigestRepository
+func NewNotificationDigestRepository(pool *pgxpool.Pool) *NotificationDigestRepository {
+	return &NotificationDigestRepository{pool: pool}
+}
+
+// ListDueUserIDs lists users on the given digest frequency who have unread
+// notifications in the period and no digest for it yet. Users whose digest
+// failed fewer than maxAttempts times are listed again.
+func (r *NotificationDigestRepository) ListDueUserIDs(ctx context.Context, frequency string, periodStart, periodEnd time.Time, maxAttempts, limit int) ([]uuid.UUID, error) {
+	query := `
+		SELECT p.user_id
+		FROM notification_preferences p
+		JOIN users u ON u.id = p.user_id
+		WHERE p.email_enabled = true
+		  AND p.email_digest = $1
+		  AND u.email IS NOT NULL AND u.email <> ''
+		  AND EXISTS (
+			SELECT 1 FROM notifications n
+			WHERE n.user_id = p.user_id
+			  AND n.is_read = false
+			  AND COALESCE(n.updated_at, n.created_at) >= $2
+			  AND COALESCE(n.updated_at, n.created_at) < $3
+		  )
+		  AND NOT EXISTS (
+			SELECT 1 FROM notification_digests d
+			WHERE d.user_id = p.user_id
+			  AND d.frequency = $1
+			  AND d.period_end = $3
+			  AND (d.status <> 'failed' OR d.attempts >= $4)
+		  )
+		ORDER BY p.user_id
+		LIMIT $5
+	`
+
+	rows, err := r.pool.Query(ctx, query, frequency, periodStart, periodEnd, maxAttempts, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users due a digest: %w", err)
+	}
+	defer rows.Close()
+
+	userIDs := []uuid.UUID{}
+	for rows.Next() {
+		var userID uuid.UUID
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan digest user: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate digest users: %w", err)
+	}
+
+	return userIDs, nil
+}
+
+// Claim records a pending digest for the user and period. It returns false if
+// the digest was already sent or skipped, is being sent by another instance, or
+// has failed maxAttempts times; a failed digest with attempts left is claimed again.
+func (r *NotificationDigestRepository) Claim(ctx context.Context, digest *models.NotificationDigest, maxAttempts int) (bool, error) {
+	query := `
+		INSERT INTO notification_digests (user_id, frequency, period_start, period_end)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, frequency, period_end) DO UPDATE SET
+			status = 'pending',
+			attempts = notification_digests.attempts + 1,
+			error_message = NULL
+		WHERE notification_digests.status = 'failed' AND notification_digests.attempts < $5
+		RETURNING id, status, attempts, created_at
+	`
+
+	err := r.pool.QueryRow(ctx, query, digest.UserID, digest.Frequency, digest.PeriodStart, digest.PeriodEnd, maxAttempts).
+		Scan(&digest.ID, &digest.Status, &digest.Attempts, &digest.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to claim notification digest: %w", err)
+	}
+
+	return true, nil
+}
+
+// ListUnreadForDigest lists a user's unread notifications with activity in the
+// period, newest first. Notifications already covered by a sent digest are left out.
+func (r *NotificationDigestRepository) ListUnreadForDigest(ctx context.Context, userID uuid.UUID, periodStart, periodEnd time.Time, limit int) ([]models.Notification, error) {
+	query := `
+		SELECT
+			n.id, n.user_id, n.type, n.title, n.message, n.link, n.is_read,
+			n.created_at, n.expires_at, n.source_user_id, n.source_content_id, n.source_content_type,
+			n.group_key, n.aggregate_count, n.aggregate_data, n.updated_at
+		FROM notifications n
+		WHERE n.user_id = $1
+		  AND n.is_read = false
+		  AND (n.expires_at IS NULL OR n.expires_at > NOW())
+		  AND COALESCE(n.updated_at, n.created_at) >= GREATEST($2, COALESCE((
+			SELECT MAX(d.period_end) FROM notification_digests d
+			WHERE d.user_id = $1 AND d.status = 'sent'
+		  ), $2))
+		  AND COALESCE(n.updated_at, n.created_at) < $3
+		ORDER BY COALESCE(n.updated_at, n.created_at) DESC
+		LIMIT $4
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID, periodStart, periodEnd, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications for digest: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := []models.Notification{}
+	for rows.Next() {
+		var n models.Notification
+		var aggregateJSON []byte
+		err := rows.Scan(
+			&n.ID,
+			&n.UserID,
+			&n.Type,
+			&n.Title,
+			&n.Message,
+			&n.Link,
+			&n.IsRead,
+			&n.CreatedAt,
+			&n.ExpiresAt,
+			&n.SourceUserID,
+			&n.SourceContentID,
+			&n.SourceContentType,
+			&n.GroupKey,
+			&n.AggregateCount,
+			&aggregateJSON,
+			&n.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		if n.Aggregate, err = unmarshalNotificationAggregate(aggregateJSON); err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate notifications for digest: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// Complete records the outcome of a claimed digest
+func (r *NotificationDigestRepository) Complete(ctx context.Context, id uuid.UUID, status string, notificationCount int, errorMessage *string) error {
+	query := `
+		UPDATE notification_digests
+		SET status = $2,
+			notification_count = $3,
+			error_message = $4,
+			sent_at = CASE WHEN $2 = 'sent' THEN NOW() ELSE sent_at END
+		WHERE id = $1
+	`
+
+	if _, err := r.pool.Exec(ctx, query, id, status, notificationCount, errorMessage); err != nil {
+		return fmt.Errorf("failed to update notification digest: %w", err)
+	}
+	return nil
+}
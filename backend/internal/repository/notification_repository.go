@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/pagination"
 )
 
 // NotificationRepository handles database operations for notifications
@@ -25,15 +27,34 @@ func NewNotificationRepository(pool *pgxpool.Pool) *NotificationRepository {
 
 // Create creates a new notification
 func (r *NotificationRepository) Create(ctx context.Context, notification *models.Notification) error {
+	return r.insert(ctx, r.pool, notification)
+}
+
+// notificationExecutor is satisfied by both the pool and a transaction
+type notificationExecutor interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+func (r *NotificationRepository) insert(ctx context.Context, db notificationExecutor, notification *models.Notification) error {
 	query := `
 		INSERT INTO notifications (
 			id, user_id, type, title, message, link, is_read, created_at, expires_at,
-			source_user_id, source_content_id, source_content_type
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			source_user_id, source_content_id, source_content_type,
+			group_key, aggregate_count, aggregate_data
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 		RETURNING id, created_at
 	`
 
-	err := r.pool.QueryRow(ctx, query,
+	if notification.AggregateCount < 1 {
+		notification.AggregateCount = 1
+	}
+
+	aggregateJSON, err := marshalNotificationAggregate(notification.Aggregate)
+	if err != nil {
+		return err
+	}
+
+	err = db.QueryRow(ctx, query,
 		notification.ID,
 		notification.UserID,
 		notification.Type,
@@ -46,6 +67,9 @@ func (r *NotificationRepository) Create(ctx context.Context, notification *model
 		notification.SourceUserID,
 		notification.SourceContentID,
 		notification.SourceContentType,
+		notification.GroupKey,
+		notification.AggregateCount,
+		aggregateJSON,
 	).Scan(&notification.ID, &notification.CreatedAt)
 
 	if err != nil {
@@ -55,12 +79,153 @@ func (r *NotificationRepository) Create(ctx context.Context, notification *model
 	return nil
 }
 
+// CreateOrCoalesce inserts the notification unless an unread notification with the same
+// group key has seen activity since the given time (a rolling window). In that case merge
+// is called with the existing row, locked for the duration of the transaction, and the
+// merged row is persisted instead. Returns the stored notification and whether it was coalesced.
+func (r *NotificationRepository) CreateOrCoalesce(
+	ctx context.Context,
+	notification *models.Notification,
+	since time.Time,
+	merge func(existing *models.Notification),
+) (*models.Notification, bool, error) {
+	if notification.GroupKey == nil {
+		if err := r.Create(ctx, notification); err != nil {
+			return nil, false, err
+		}
+		return notification, false, nil
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// Serialize writers for this user and group. FOR UPDATE below only locks a row
+	// that already exists, so without this concurrent first events would each
+	// find nothing and insert their own aggregate. Released at transaction end.
+	_, err = tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1::text || ':' || $2))`,
+		notification.UserID, *notification.GroupKey)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to acquire notification group lock: %w", err)
+	}
+
+	query := `
+		SELECT
+			id, user_id, type, title, message, link, is_read, created_at, expires_at,
+			source_user_id, source_content_id, source_content_type,
+			group_key, aggregate_count, aggregate_data, updated_at
+		FROM notifications
+		WHERE user_id = $1 AND group_key = $2 AND is_read = false
+			AND COALESCE(updated_at, created_at) >= $3
+		ORDER BY created_at DESC
+		LIMIT 1
+		FOR UPDATE
+	`
+
+	var existing models.Notification
+	var aggregateJSON []byte
+	err = tx.QueryRow(ctx, query, notification.UserID, *notification.GroupKey, since).Scan(
+		&existing.ID,
+		&existing.UserID,
+		&existing.Type,
+		&existing.Title,
+		&existing.Message,
+		&existing.Link,
+		&existing.IsRead,
+		&existing.CreatedAt,
+		&existing.ExpiresAt,
+		&existing.SourceUserID,
+		&existing.SourceContentID,
+		&existing.SourceContentType,
+		&existing.GroupKey,
+		&existing.AggregateCount,
+		&aggregateJSON,
+		&existing.UpdatedAt,
+	)
+
+	if err == pgx.ErrNoRows {
+		if err := r.insert(ctx, tx, notification); err != nil {
+			return nil, false, err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return nil, false, fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return notification, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to find coalescable notification: %w", err)
+	}
+
+	if existing.Aggregate, err = unmarshalNotificationAggregate(aggregateJSON); err != nil {
+		return nil, false, err
+	}
+
+	merge(&existing)
+
+	updatedJSON, err := marshalNotificationAggregate(existing.Aggregate)
+	if err != nil {
+		return nil, false, err
+	}
+
+	updateQuery := `
+		UPDATE notifications
+		SET title = $2, message = $3, link = $4, source_user_id = $5,
+			aggregate_count = $6, aggregate_data = $7, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at
+	`
+
+	err = tx.QueryRow(ctx, updateQuery,
+		existing.ID,
+		existing.Title,
+		existing.Message,
+		existing.Link,
+		existing.SourceUserID,
+		existing.AggregateCount,
+		updatedJSON,
+	).Scan(&existing.UpdatedAt)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to coalesce notification: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &existing, true, nil
+}
+
+func marshalNotificationAggregate(aggregate *models.NotificationAggregate) ([]byte, error) {
+	if aggregate == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(aggregate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal notification aggregate: %w", err)
+	}
+	return data, nil
+}
+
+func unmarshalNotificationAggregate(data []byte) (*models.NotificationAggregate, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var aggregate models.NotificationAggregate
+	if err := json.Unmarshal(data, &aggregate); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal notification aggregate: %w", err)
+	}
+	return &aggregate, nil
+}
+
 // GetByID retrieves a notification by ID
 func (r *NotificationRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.NotificationWithSource, error) {
 	query := `
 		SELECT
 			n.id, n.user_id, n.type, n.title, n.message, n.link, n.is_read,
 			n.created_at, n.expires_at, n.source_user_id, n.source_content_id, n.source_content_type,
+			n.group_key, n.aggregate_count, n.aggregate_data, n.updated_at,
 			u.username AS source_username,
 			u.display_name AS source_display_name,
 			u.avatar_url AS source_avatar_url
@@ -70,6 +235,7 @@ func (r *NotificationRepository) GetByID(ctx context.Context, id uuid.UUID) (*mo
 	`
 
 	var notification models.NotificationWithSource
+	var aggregateJSON []byte
 	err := r.pool.QueryRow(ctx, query, id).Scan(
 		&notification.ID,
 		&notification.UserID,
@@ -83,6 +249,10 @@ func (r *NotificationRepository) GetByID(ctx context.Context, id uuid.UUID) (*mo
 		&notification.SourceUserID,
 		&notification.SourceContentID,
 		&notification.SourceContentType,
+		&notification.GroupKey,
+		&notification.AggregateCount,
+		&aggregateJSON,
+		&notification.UpdatedAt,
 		&notification.SourceUsername,
 		&notification.SourceDisplayName,
 		&notification.SourceAvatarURL,
@@ -95,11 +265,26 @@ func (r *NotificationRepository) GetByID(ctx context.Context, id uuid.UUID) (*mo
 		return nil, fmt.Errorf("failed to get notification: %w", err)
 	}
 
+	if notification.Aggregate, err = unmarshalNotificationAggregate(aggregateJSON); err != nil {
+		return nil, err
+	}
+
 	return &notification, nil
 }
 
-// ListByUserID retrieves notifications for a user with pagination and filtering
-func (r *NotificationRepository) ListByUserID(ctx context.Context, userID uuid.UUID, filter string, limit, offset int) ([]models.NotificationWithSource, error) {
+// NotificationsSort is the sort order cursors over a user's notifications are issued for
+const NotificationsSort = "notifications"
+
+// notificationsKeyset lists notifications newest first
+var notificationsKeyset = pagination.Keyset{
+	Sort: NotificationsSort,
+	Time: "n.created_at",
+	ID:   "n.id",
+}
+
+// ListByUserID retrieves a page of notifications for a user with filtering,
+// continuing after cursor when set and otherwise skipping offset rows
+func (r *NotificationRepository) ListByUserID(ctx context.Context, userID uuid.UUID, filter string, cursor *pagination.Cursor, limit, offset int) ([]models.NotificationWithSource, pagination.PageInfo, error) {
 	var whereClause string
 	switch filter {
 	case "unread":
@@ -110,29 +295,43 @@ func (r *NotificationRepository) ListByUserID(ctx context.Context, userID uuid.U
 		whereClause = ""
 	}
 
+	// Fetch one extra row to tell whether another page follows
+	args := []interface{}{userID, limit + 1, offset}
+	if cursor != nil {
+		where, cursorArgs, err := notificationsKeyset.After(cursor, len(args)+1)
+		if err != nil {
+			return nil, pagination.PageInfo{}, err
+		}
+		whereClause += " AND " + where
+		args = append(args, cursorArgs...)
+	}
+
 	query := fmt.Sprintf(`
 		SELECT
 			n.id, n.user_id, n.type, n.title, n.message, n.link, n.is_read,
 			n.created_at, n.expires_at, n.source_user_id, n.source_content_id, n.source_content_type,
+			n.group_key, n.aggregate_count, n.aggregate_data, n.updated_at,
 			u.username AS source_username,
 			u.display_name AS source_display_name,
 			u.avatar_url AS source_avatar_url
 		FROM notifications n
 		LEFT JOIN users u ON n.source_user_id = u.id
 		WHERE n.user_id = $1 %s
-		ORDER BY n.created_at DESC
+		ORDER BY %s
 		LIMIT $2 OFFSET $3
-	`, whereClause)
+	`, whereClause, notificationsKeyset.OrderBy())
 
-	rows, err := r.pool.Query(ctx, query, userID, limit, offset)
+	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list notifications: %w", err)
+		return nil, pagination.PageInfo{}, fmt.Errorf("failed to list notifications: %w", err)
 	}
 	defer rows.Close()
 
 	var notifications []models.NotificationWithSource
+	var keys []pagination.Cursor
 	for rows.Next() {
 		var notification models.NotificationWithSource
+		var aggregateJSON []byte
 		err := rows.Scan(
 			&notification.ID,
 			&notification.UserID,
@@ -146,17 +345,30 @@ func (r *NotificationRepository) ListByUserID(ctx context.Context, userID uuid.U
 			&notification.SourceUserID,
 			&notification.SourceContentID,
 			&notification.SourceContentType,
+			&notification.GroupKey,
+			&notification.AggregateCount,
+			&aggregateJSON,
+			&notification.UpdatedAt,
 			&notification.SourceUsername,
 			&notification.SourceDisplayName,
 			&notification.SourceAvatarURL,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan notification: %w", err)
+			return nil, pagination.PageInfo{}, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		if notification.Aggregate, err = unmarshalNotificationAggregate(aggregateJSON); err != nil {
+			return nil, pagination.PageInfo{}, err
 		}
 		notifications = append(notifications, notification)
+		keys = append(keys, pagination.Cursor{Sort: NotificationsSort, Time: notification.CreatedAt, ID: notification.ID})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, pagination.PageInfo{}, fmt.Errorf("error iterating notifications: %w", err)
 	}
 
-	return notifications, nil
+	notifications, page := pagination.Trim(notifications, keys, limit)
+	return notifications, page, nil
 }
 
 // CountUnread counts unread notifications for a user
@@ -250,7 +462,7 @@ func (r *NotificationRepository) DeleteExpired(ctx context.Context) (int64, erro
 func (r *NotificationRepository) GetPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error) {
 	query := `
 		SELECT
-			user_id, in_app_enabled, email_enabled, email_digest,
+			user_id, in_app_enabled, email_enabled, email_digest, push_enabled,
 			notify_login_new_device, notify_failed_login, notify_password_changed, notify_email_changed,
 			notify_replies, notify_mentions, notify_submission_approved, notify_submission_rejected,
 			notify_content_trending, notify_content_flagged, notify_votes, notify_favorited_clip_comment,
@@ -258,7 +470,7 @@ func (r *NotificationRepository) GetPreferences(ctx context.Context, userID uuid
 			notify_badges, notify_rank_up, notify_moderation,
 			notify_clip_approved, notify_clip_rejected, notify_clip_comments, notify_clip_threshold,
 			notify_marketing, notify_policy_updates, notify_platform_announcements,
-			updated_at
+			notify_reengagement, timezone, updated_at
 		FROM notification_preferences
 		WHERE user_id = $1
 	`
@@ -269,6 +481,7 @@ func (r *NotificationRepository) GetPreferences(ctx context.Context, userID uuid
 		&prefs.InAppEnabled,
 		&prefs.EmailEnabled,
 		&prefs.EmailDigest,
+		&prefs.PushEnabled,
 		&prefs.NotifyLoginNewDevice,
 		&prefs.NotifyFailedLogin,
 		&prefs.NotifyPasswordChanged,
@@ -295,6 +508,8 @@ func (r *NotificationRepository) GetPreferences(ctx context.Context, userID uuid
 		&prefs.NotifyMarketing,
 		&prefs.NotifyPolicyUpdates,
 		&prefs.NotifyPlatformAnnouncements,
+		&prefs.NotifyReengagement,
+		&prefs.Timezone,
 		&prefs.UpdatedAt,
 	)
 
@@ -316,7 +531,7 @@ func (r *NotificationRepository) CreateDefaultPreferences(ctx context.Context, u
 		VALUES ($1)
 		ON CONFLICT (user_id) DO UPDATE SET user_id = $1
 		RETURNING
-			user_id, in_app_enabled, email_enabled, email_digest,
+			user_id, in_app_enabled, email_enabled, email_digest, push_enabled,
 			notify_login_new_device, notify_failed_login, notify_password_changed, notify_email_changed,
 			notify_replies, notify_mentions, notify_submission_approved, notify_submission_rejected,
 			notify_content_trending, notify_content_flagged, notify_votes, notify_favorited_clip_comment,
@@ -324,7 +539,7 @@ func (r *NotificationRepository) CreateDefaultPreferences(ctx context.Context, u
 			notify_badges, notify_rank_up, notify_moderation,
 			notify_clip_approved, notify_clip_rejected, notify_clip_comments, notify_clip_threshold,
 			notify_marketing, notify_policy_updates, notify_platform_announcements,
-			updated_at
+			notify_reengagement, timezone, updated_at
 	`
 
 	var prefs models.NotificationPreferences
@@ -333,6 +548,7 @@ func (r *NotificationRepository) CreateDefaultPreferences(ctx context.Context, u
 		&prefs.InAppEnabled,
 		&prefs.EmailEnabled,
 		&prefs.EmailDigest,
+		&prefs.PushEnabled,
 		&prefs.NotifyLoginNewDevice,
 		&prefs.NotifyFailedLogin,
 		&prefs.NotifyPasswordChanged,
@@ -359,6 +575,8 @@ func (r *NotificationRepository) CreateDefaultPreferences(ctx context.Context, u
 		&prefs.NotifyMarketing,
 		&prefs.NotifyPolicyUpdates,
 		&prefs.NotifyPlatformAnnouncements,
+		&prefs.NotifyReengagement,
+		&prefs.Timezone,
 		&prefs.UpdatedAt,
 	)
 
@@ -403,6 +621,9 @@ func (r *NotificationRepository) UpdatePreferences(ctx context.Context, prefs *m
 			notify_marketing = $28,
 			notify_policy_updates = $29,
 			notify_platform_announcements = $30,
+			push_enabled = $31,
+			notify_reengagement = $32,
+			timezone = $33,
 			updated_at = NOW()
 		WHERE user_id = $1
 	`
@@ -438,6 +659,9 @@ func (r *NotificationRepository) UpdatePreferences(ctx context.Context, prefs *m
 		prefs.NotifyMarketing,
 		prefs.NotifyPolicyUpdates,
 		prefs.NotifyPlatformAnnouncements,
+		prefs.PushEnabled,
+		prefs.NotifyReengagement,
+		prefs.Timezone,
 	)
 
 	if err != nil {
@@ -0,0 +1,86 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/testutil"
+)
+
+func TestNotificationRepository_CreateOrCoalesceConcurrentFirstEvents(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	pool := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, pool)
+
+	repo := NewNotificationRepository(pool)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	insertTestUser(t, pool, userID)
+	t.Cleanup(func() {
+		_, _ = pool.Exec(context.Background(), `DELETE FROM notifications WHERE user_id = $1`, userID)
+	})
+
+	groupKey := "clip_comment:" + uuid.NewString()
+	since := time.Now().Add(-time.Hour)
+
+	const events = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, events)
+	start := make(chan struct{})
+	for i := 0; i < events; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			key := groupKey
+			notification := &models.Notification{
+				UserID:         userID,
+				Type:           models.NotificationTypeClipComment,
+				Title:          "New comment",
+				Message:        "Someone commented on your clip",
+				GroupKey:       &key,
+				AggregateCount: 1,
+			}
+			_, _, err := repo.CreateOrCoalesce(ctx, notification, since, func(existing *models.Notification) {
+				existing.AggregateCount++
+			})
+			errs <- err
+		}()
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("CreateOrCoalesce failed: %v", err)
+		}
+	}
+
+	var rows, total int
+	err := pool.QueryRow(ctx, `
+		SELECT COUNT(*), COALESCE(SUM(aggregate_count), 0)
+		FROM notifications
+		WHERE user_id = $1 AND group_key = $2
+	`, userID, groupKey).Scan(&rows, &total)
+	if err != nil {
+		t.Fatalf("Failed to count notifications: %v", err)
+	}
+
+	if rows != 1 {
+		t.Errorf("Expected concurrent first events to share one aggregate, got %d rows", rows)
+	}
+	if total != events {
+		t.Errorf("Expected aggregate count %d, got %d", events, total)
+	}
+}
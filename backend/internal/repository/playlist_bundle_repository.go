@@ -0,0 +1,293 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// Sentinel errors for playlist bundle operations
+var (
+	// ErrPlaylistBundleNotFound is returned when a playlist is not sold as a bundle
+	ErrPlaylistBundleNotFound = errors.New("playlist bundle not found")
+	// ErrPlaylistBundleAlreadyGranted is returned when a checkout session already granted an entitlement
+	ErrPlaylistBundleAlreadyGranted = errors.New("playlist bundle entitlement already granted")
+)
+
+const playlistBundleEntitlementColumns = `
+	id, playlist_id, user_id, status, amount_cents, currency, stripe_checkout_session_id,
+	stripe_payment_intent_id, granted_at, revoked_at`
+
+// PlaylistBundleRepository handles database operations for playlist bundles,
+// their entitlements and the creator ledger
+type PlaylistBundleRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPlaylistBundleRepository creates a new PlaylistBundleRepository
+func NewPlaylistBundleRepository(pool *pgxpool.Pool) *PlaylistBundleRepository {
+	return &PlaylistBundleRepository{pool: pool}
+}
+
+// UpsertBundle prices a playlist as a bundle, reactivating it if it was withdrawn
+func (r *PlaylistBundleRepository) UpsertBundle(ctx context.Context, bundle *models.PlaylistBundle) error {
+	query := `
+		INSERT INTO playlist_bundles (playlist_id, price_cents, currency, is_active)
+		VALUES ($1, $2, $3, true)
+		ON CONFLICT (playlist_id) DO UPDATE
+		SET price_cents = EXCLUDED.price_cents, currency = EXCLUDED.currency,
+			is_active = true, updated_at = NOW()
+		RETURNING is_active, created_at, updated_at
+	`
+
+	err := r.pool.QueryRow(ctx, query, bundle.PlaylistID, bundle.PriceCents, bundle.Currency).
+		Scan(&bundle.IsActive, &bundle.CreatedAt, &bundle.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save playlist bundle: %w", err)
+	}
+	return nil
+}
+
+// DeactivateBundle stops selling a playlist. Existing entitlements are kept so
+// buyers keep access if the playlist is sold again.
+func (r *PlaylistBundleRepository) DeactivateBundle(ctx context.Context, playlistID uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE playlist_bundles
+		SET is_active = false, updated_at = NOW()
+		WHERE playlist_id = $1 AND is_active = true
+	`, playlistID)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate playlist bundle: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrPlaylistBundleNotFound
+	}
+	return nil
+}
+
+// GetActiveBundle returns a playlist's bundle, or nil if it isn't for sale
+func (r *PlaylistBundleRepository) GetActiveBundle(ctx context.Context, playlistID uuid.UUID) (*models.PlaylistBundle, error) {
+	query := `
+		SELECT playlist_id, price_cents, currency, is_active, created_at, updated_at
+		FROM playlist_bundles
+		WHERE playlist_id = $1 AND is_active = true
+	`
+
+	var bundle models.PlaylistBundle
+	err := r.pool.QueryRow(ctx, query, playlistID).Scan(
+		&bundle.PlaylistID, &bundle.PriceCents, &bundle.Currency, &bundle.IsActive,
+		&bundle.CreatedAt, &bundle.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get playlist bundle: %w", err)
+	}
+	return &bundle, nil
+}
+
+// HasActiveEntitlement reports whether a user bought a playlist and wasn't refunded
+func (r *PlaylistBundleRepository) HasActiveEntitlement(ctx context.Context, playlistID, userID uuid.UUID) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM playlist_bundle_entitlements
+			WHERE playlist_id = $1 AND user_id = $2 AND status = 'active'
+		)
+	`, playlistID, userID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check playlist bundle entitlement: %w", err)
+	}
+	return exists, nil
+}
+
+// GrantEntitlement records a completed purchase and its ledger entry. A
+// checkout session grants at most once; replays return ErrPlaylistBundleAlreadyGranted.
+func (r *PlaylistBundleRepository) GrantEntitlement(ctx context.Context, entitlement *models.PlaylistBundleEntitlement, entry *models.CreatorLedgerEntry) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO playlist_bundle_entitlements (
+			playlist_id, user_id, status, amount_cents, currency,
+			stripe_checkout_session_id, stripe_payment_intent_id
+		)
+		VALUES ($1, $2, 'active', $3, $4, $5, $6)
+		ON CONFLICT DO NOTHING
+		RETURNING id, status, granted_at
+	`, entitlement.PlaylistID, entitlement.UserID, entitlement.AmountCents, entitlement.Currency,
+		entitlement.StripeCheckoutSessionID, entitlement.StripePaymentIntentID,
+	).Scan(&entitlement.ID, &entitlement.Status, &entitlement.GrantedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrPlaylistBundleAlreadyGranted
+		}
+		return fmt.Errorf("failed to grant playlist bundle entitlement: %w", err)
+	}
+
+	entry.EntitlementID = &entitlement.ID
+	if err := insertLedgerEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// GetEntitlementByPaymentIntent returns the entitlement bought with a payment
+// intent, or nil if the payment wasn't for a bundle
+func (r *PlaylistBundleRepository) GetEntitlementByPaymentIntent(ctx context.Context, paymentIntentID string) (*models.PlaylistBundleEntitlement, error) {
+	query := `SELECT ` + playlistBundleEntitlementColumns + `
+		FROM playlist_bundle_entitlements WHERE stripe_payment_intent_id = $1`
+
+	var e models.PlaylistBundleEntitlement
+	err := r.pool.QueryRow(ctx, query, paymentIntentID).Scan(
+		&e.ID, &e.PlaylistID, &e.UserID, &e.Status, &e.AmountCents, &e.Currency,
+		&e.StripeCheckoutSessionID, &e.StripePaymentIntentID, &e.GrantedAt, &e.RevokedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get playlist bundle entitlement: %w", err)
+	}
+	return &e, nil
+}
+
+// GetRefundedCents returns how much of an entitlement's payment has been refunded so far
+func (r *PlaylistBundleRepository) GetRefundedCents(ctx context.Context, entitlementID uuid.UUID) (int64, error) {
+	var refunded int64
+	err := r.pool.QueryRow(ctx, `
+		SELECT COALESCE(-SUM(gross_cents), 0)
+		FROM creator_ledger_entries
+		WHERE entitlement_id = $1 AND entry_type = 'bundle_refund'
+	`, entitlementID).Scan(&refunded)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get refunded amount: %w", err)
+	}
+	return refunded, nil
+}
+
+// RecordRefund debits a refund from the creator ledger and, for a full
+// refund, revokes the entitlement
+func (r *PlaylistBundleRepository) RecordRefund(ctx context.Context, entitlementID uuid.UUID, revoke bool, entry *models.CreatorLedgerEntry) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if revoke {
+		_, err := tx.Exec(ctx, `
+			UPDATE playlist_bundle_entitlements
+			SET status = 'refunded', revoked_at = NOW()
+			WHERE id = $1 AND status = 'active'
+		`, entitlementID)
+		if err != nil {
+			return fmt.Errorf("failed to revoke playlist bundle entitlement: %w", err)
+		}
+	}
+
+	if entry != nil {
+		entry.EntitlementID = &entitlementID
+		if err := insertLedgerEntry(ctx, tx, entry); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func insertLedgerEntry(ctx context.Context, tx pgx.Tx, entry *models.CreatorLedgerEntry) error {
+	err := tx.QueryRow(ctx, `
+		INSERT INTO creator_ledger_entries (
+			creator_id, entitlement_id, entry_type, gross_cents, platform_fee_cents,
+			creator_cents, currency, stripe_event_id
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (stripe_event_id) DO NOTHING
+		RETURNING id, created_at
+	`, entry.CreatorID, entry.EntitlementID, entry.EntryType, entry.GrossCents, entry.PlatformFeeCents,
+		entry.CreatorCents, entry.Currency, entry.StripeEventID,
+	).Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to create ledger entry: %w", err)
+	}
+	return nil
+}
+
+// ListLedgerEntries returns a creator's ledger, newest first
+func (r *PlaylistBundleRepository) ListLedgerEntries(ctx context.Context, creatorID uuid.UUID, limit, offset int) ([]models.CreatorLedgerEntry, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, creator_id, entitlement_id, entry_type, gross_cents, platform_fee_cents,
+			creator_cents, currency, stripe_event_id, created_at
+		FROM creator_ledger_entries
+		WHERE creator_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`, creatorID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ledger entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []models.CreatorLedgerEntry{}
+	for rows.Next() {
+		var e models.CreatorLedgerEntry
+		if err := rows.Scan(
+			&e.ID, &e.CreatorID, &e.EntitlementID, &e.EntryType, &e.GrossCents, &e.PlatformFeeCents,
+			&e.CreatorCents, &e.Currency, &e.StripeEventID, &e.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan ledger entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating ledger entries: %w", err)
+	}
+	return entries, nil
+}
+
+// GetLedgerSummary totals a creator's ledger by currency
+func (r *PlaylistBundleRepository) GetLedgerSummary(ctx context.Context, creatorID uuid.UUID) ([]models.CreatorLedgerSummary, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT currency, SUM(gross_cents), SUM(platform_fee_cents), SUM(creator_cents),
+			COUNT(*) FILTER (WHERE entry_type = 'bundle_sale'),
+			COUNT(*) FILTER (WHERE entry_type = 'bundle_refund')
+		FROM creator_ledger_entries
+		WHERE creator_id = $1
+		GROUP BY currency
+		ORDER BY currency
+	`, creatorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize ledger: %w", err)
+	}
+	defer rows.Close()
+
+	summaries := []models.CreatorLedgerSummary{}
+	for rows.Next() {
+		var s models.CreatorLedgerSummary
+		if err := rows.Scan(&s.Currency, &s.GrossCents, &s.PlatformFeeCents, &s.CreatorCents, &s.Sales, &s.Refunds); err != nil {
+			return nil, fmt.Errorf("failed to scan ledger summary: %w", err)
+		}
+		summaries = append(summaries, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating ledger summary: %w", err)
+	}
+	return summaries, nil
+}
@@ -11,12 +11,19 @@ import (
 
 // PlaylistCurationRepository provides strategy-based clip queries for automated playlist curation.
 type PlaylistCurationRepository struct {
-	pool *pgxpool.Pool
+	pool            *pgxpool.Pool
+	embeddingColumn string
 }
 
 // NewPlaylistCurationRepository creates a new PlaylistCurationRepository
 func NewPlaylistCurationRepository(pool *pgxpool.Pool) *PlaylistCurationRepository {
-	return &PlaylistCurationRepository{pool: pool}
+	return &PlaylistCurationRepository{pool: pool, embeddingColumn: models.DefaultClipEmbeddingColumn}
+}
+
+// SetEmbeddingColumn sets the clips column the similar vibes strategy reads,
+// which must be a valid embedding column
+func (r *PlaylistCurationRepository) SetEmbeddingColumn(column string) {
+	r.embeddingColumn = column
 }
 
 // baseClipFilter returns the common WHERE clause fragment that all strategies share.
@@ -265,13 +272,13 @@ func (r *PlaylistCurationRepository) SimilarVibes(ctx context.Context, script *m
 	query := fmt.Sprintf(`
 		SELECT c.id
 		FROM clips c,
-		     (SELECT embedding FROM clips WHERE id = $%d AND embedding IS NOT NULL) seed
-		WHERE %s
-		  AND c.id != $%d
-		  AND c.embedding IS NOT NULL
-		ORDER BY c.embedding <=> seed.embedding ASC
-		LIMIT $%d
-	`, nextArg, where, nextArg, nextArg+1)
+		     (SELECT %[1]s AS embedding FROM clips WHERE id = $%[2]d AND %[1]s IS NOT NULL) seed
+		WHERE %[3]s
+		  AND c.id != $%[2]d
+		  AND c.%[1]s IS NOT NULL
+		ORDER BY c.%[1]s <=> seed.embedding ASC
+		LIMIT $%[4]d
+	`, r.embeddingColumn, nextArg, where, nextArg+1)
 	args = append(args, seedClipID, script.ClipLimit)
 
 	return r.scanClipIDs(ctx, query, args)
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// Profile view rollups are stored in daily_analytics under this metric, with
+// the user ID as the entity and unique viewers in the metadata
+const (
+	profileViewMetricType = "profile_views"
+	profileViewEntityType = "user"
+)
+
+// ProfileViewRepository handles daily profile view rollups and counter visibility
+type ProfileViewRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewProfileViewRepository creates a new ProfileViewRepository
+func NewProfileViewRepository(pool *pgxpool.Pool) *ProfileViewRepository {
+	return &ProfileViewRepository{pool: pool}
+}
+
+// GetShowProfileViews returns whether a user shows their profile view
+// counter to others. Users without settings keep it hidden.
+func (r *ProfileViewRepository) GetShowProfileViews(ctx context.Context, userID uuid.UUID) (bool, error) {
+	query := `
+		SELECT COALESCE(us.show_profile_views, FALSE)
+		FROM users u
+		LEFT JOIN user_settings us ON us.user_id = u.id
+		WHERE u.id = $1
+	`
+	var show bool
+	err := r.pool.QueryRow(ctx, query, userID).Scan(&show)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, ErrUserNotFound
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get profile view visibility: %w", err)
+	}
+	return show, nil
+}
+
+// UpsertDaily stores a profile's views and unique viewers for a day,
+// replacing an earlier rollup of the same day
+func (r *ProfileViewRepository) UpsertDaily(ctx context.Context, userID uuid.UUID, day time.Time, views, uniqueViewers int64) error {
+	query := `
+		INSERT INTO daily_analytics (date, metric_type, entity_type, entity_id, value, metadata)
+		VALUES ($1, $2, $3, $4, $5, jsonb_build_object('unique_viewers', $6::bigint))
+		ON CONFLICT (date, metric_type, entity_type, entity_id) DO UPDATE
+		SET value = EXCLUDED.value, metadata = EXCLUDED.metadata, updated_at = NOW()
+	`
+	_, err := r.pool.Exec(ctx, query, day, profileViewMetricType, profileViewEntityType, userID.String(), views, uniqueViewers)
+	if err != nil {
+		return fmt.Errorf("failed to upsert profile views: %w", err)
+	}
+	return nil
+}
+
+// GetTotalViewsBefore returns a profile's rolled up views from days before the given day
+func (r *ProfileViewRepository) GetTotalViewsBefore(ctx context.Context, userID uuid.UUID, day time.Time) (int64, error) {
+	query := `
+		SELECT COALESCE(SUM(value), 0)::bigint
+		FROM daily_analytics
+		WHERE metric_type = $1 AND entity_type = $2 AND entity_id = $3 AND date < $4
+	`
+	var total int64
+	if err := r.pool.QueryRow(ctx, query, profileViewMetricType, profileViewEntityType, userID.String(), day).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to get total profile views: %w", err)
+	}
+	return total, nil
+}
+
+// ListDaily returns a profile's rolled up days from the given day on, oldest first
+func (r *ProfileViewRepository) ListDaily(ctx context.Context, userID uuid.UUID, since time.Time) ([]models.ProfileViewDay, error) {
+	query := `
+		SELECT date, value, COALESCE((metadata->>'unique_viewers')::bigint, 0)
+		FROM daily_analytics
+		WHERE metric_type = $1 AND entity_type = $2 AND entity_id = $3 AND date >= $4
+		ORDER BY date ASC
+	`
+	rows, err := r.pool.Query(ctx, query, profileViewMetricType, profileViewEntityType, userID.String(), since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list profile views: %w", err)
+	}
+	defer rows.Close()
+
+	var days []models.ProfileViewDay
+	for rows.Next() {
+		var day models.ProfileViewDay
+		if err := rows.Scan(&day.Date, &day.Views, &day.UniqueViewers); err != nil {
+			return nil, fmt.Errorf("failed to scan profile views: %w", err)
+		}
+		days = append(days, day)
+	}
+	return days, rows.Err()
+}
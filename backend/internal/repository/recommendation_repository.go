@@ -13,16 +13,24 @@ import (
 
 // RecommendationRepository handles database operations for recommendations
 type RecommendationRepository struct {
-	pool *pgxpool.Pool
+	pool            *pgxpool.Pool
+	embeddingColumn string
 }
 
 // NewRecommendationRepository creates a new recommendation repository
 func NewRecommendationRepository(pool *pgxpool.Pool) *RecommendationRepository {
 	return &RecommendationRepository{
-		pool: pool,
+		pool:            pool,
+		embeddingColumn: models.DefaultClipEmbeddingColumn,
 	}
 }
 
+// SetEmbeddingColumn sets the clips column embedding recommendations read,
+// which must be a valid embedding column
+func (r *RecommendationRepository) SetEmbeddingColumn(column string) {
+	r.embeddingColumn = column
+}
+
 // GetUserPreferences retrieves user preferences
 func (r *RecommendationRepository) GetUserPreferences(ctx context.Context, userID uuid.UUID) (*models.UserPreference, error) {
 	query := `
@@ -267,7 +275,9 @@ func (r *RecommendationRepository) GetContentBasedRecommendations(
 	return scores, nil
 }
 
-// GetCollaborativeRecommendations gets recommendations based on similar users
+// GetCollaborativeRecommendations gets recommendations based on similar users.
+// Users are similar when they liked, upvoted or favorited the same clips, and
+// a clip scores by the share of similar users who liked it.
 func (r *RecommendationRepository) GetCollaborativeRecommendations(
 	ctx context.Context,
 	userID uuid.UUID,
@@ -275,35 +285,42 @@ func (r *RecommendationRepository) GetCollaborativeRecommendations(
 	limit int,
 ) ([]models.ClipScore, error) {
 	query := `
-		WITH user_likes AS (
-			SELECT clip_id FROM user_clip_interactions
-			WHERE user_id = $1 AND interaction_type = 'like'
+		WITH positive_signals AS (
+			SELECT user_id, clip_id FROM user_clip_interactions WHERE interaction_type = 'like'
+			UNION
+			SELECT user_id, clip_id FROM votes WHERE vote_type = 1
+			UNION
+			SELECT user_id, clip_id FROM favorites
+		),
+		user_likes AS (
+			SELECT clip_id FROM positive_signals
+			WHERE user_id = $1
 		),
 		similar_users AS (
 			SELECT
-				uci.user_id,
+				ps.user_id,
 				COUNT(*) as common_likes
-			FROM user_clip_interactions uci
-			WHERE uci.interaction_type = 'like'
-			  AND uci.clip_id IN (SELECT clip_id FROM user_likes)
-			  AND uci.user_id != $1
-			GROUP BY uci.user_id
+			FROM positive_signals ps
+			WHERE ps.clip_id IN (SELECT clip_id FROM user_likes)
+			  AND ps.user_id != $1
+			GROUP BY ps.user_id
 			ORDER BY common_likes DESC
 			LIMIT 50
 		),
 		user_excluded AS (
 			SELECT clip_id FROM user_clip_interactions
 			WHERE user_id = $1
+			UNION
+			SELECT clip_id FROM user_likes
 		)
 		SELECT
 			c.id as clip_id,
 			COALESCE(COUNT(*) * 1.0 / NULLIF((SELECT COUNT(*) FROM similar_users), 0), 0) as similarity_score,
 			ROW_NUMBER() OVER (ORDER BY COUNT(*) DESC) as similarity_rank
 		FROM clips c
-		JOIN user_clip_interactions uci ON c.id = uci.clip_id
-		JOIN similar_users su ON uci.user_id = su.user_id
-		WHERE uci.interaction_type = 'like'
-		  AND c.created_at > NOW() - INTERVAL '30 days'
+		JOIN positive_signals ps ON c.id = ps.clip_id
+		JOIN similar_users su ON ps.user_id = su.user_id
+		WHERE c.created_at > NOW() - INTERVAL '30 days'
 		  AND c.is_removed = false
 		  AND c.dmca_removed = false
 		  AND c.id NOT IN (SELECT clip_id FROM user_excluded)
@@ -331,6 +348,80 @@ func (r *RecommendationRepository) GetCollaborativeRecommendations(
 	return scores, nil
 }
 
+// GetEmbeddingRecommendations gets clips whose embeddings are closest to the
+// user's taste, the average embedding of the last 50 clips they liked,
+// upvoted or favorited. Users with no embedded positive signals get no rows.
+func (r *RecommendationRepository) GetEmbeddingRecommendations(
+	ctx context.Context,
+	userID uuid.UUID,
+	excludeClipIDs []uuid.UUID,
+	limit int,
+) ([]models.ClipScore, error) {
+	query := fmt.Sprintf(`
+		WITH positive_signals AS (
+			SELECT clip_id, timestamp AS signaled_at FROM user_clip_interactions
+			WHERE user_id = $1 AND interaction_type = 'like'
+			UNION ALL
+			SELECT clip_id, created_at FROM votes
+			WHERE user_id = $1 AND vote_type = 1
+			UNION ALL
+			SELECT clip_id, created_at FROM favorites
+			WHERE user_id = $1
+		),
+		recent_signals AS (
+			SELECT clip_id, MAX(signaled_at) AS signaled_at
+			FROM positive_signals
+			GROUP BY clip_id
+			ORDER BY signaled_at DESC NULLS LAST
+			LIMIT 50
+		),
+		taste AS (
+			SELECT AVG(c.%[1]s) AS embedding
+			FROM recent_signals rs
+			JOIN clips c ON c.id = rs.clip_id
+			WHERE c.%[1]s IS NOT NULL
+		),
+		user_excluded AS (
+			SELECT clip_id FROM positive_signals
+			UNION
+			SELECT clip_id FROM user_clip_interactions
+			WHERE user_id = $1 AND interaction_type IN ('view', 'dislike')
+		)
+		SELECT
+			c.id as clip_id,
+			1 - (c.%[1]s <=> t.embedding) as similarity_score,
+			ROW_NUMBER() OVER (ORDER BY c.%[1]s <=> t.embedding) as similarity_rank
+		FROM clips c
+		CROSS JOIN taste t
+		WHERE t.embedding IS NOT NULL
+		  AND c.%[1]s IS NOT NULL
+		  AND c.created_at > NOW() - INTERVAL '30 days'
+		  AND c.is_removed = false
+		  AND c.dmca_removed = false
+		  AND c.id NOT IN (SELECT clip_id FROM user_excluded)
+		  AND ($2::uuid[] IS NULL OR c.id != ALL($2::uuid[]))
+		ORDER BY c.%[1]s <=> t.embedding
+		LIMIT $3
+	`, r.embeddingColumn)
+
+	rows, err := r.pool.Query(ctx, query, userID, excludeClipIDs, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get embedding recommendations: %w", err)
+	}
+	defer rows.Close()
+
+	var scores []models.ClipScore
+	for rows.Next() {
+		var score models.ClipScore
+		if err := rows.Scan(&score.ClipID, &score.SimilarityScore, &score.SimilarityRank); err != nil {
+			return nil, fmt.Errorf("failed to scan clip score: %w", err)
+		}
+		scores = append(scores, score)
+	}
+
+	return scores, nil
+}
+
 // GetTrendingClips gets trending clips for cold start
 func (r *RecommendationRepository) GetTrendingClips(
 	ctx context.Context,
@@ -464,13 +555,21 @@ func (r *RecommendationRepository) GetClipsByIDs(ctx context.Context, clipIDs []
 	return clips, nil
 }
 
-// HasUserInteractions checks if a user has any interaction history
+// HasUserInteractions checks if a user has any interaction, upvote or favorite history
 func (r *RecommendationRepository) HasUserInteractions(ctx context.Context, userID uuid.UUID) (bool, error) {
 	query := `
 		SELECT EXISTS(
 			SELECT 1 FROM user_clip_interactions
 			WHERE user_id = $1
 			LIMIT 1
+		) OR EXISTS(
+			SELECT 1 FROM votes
+			WHERE user_id = $1 AND vote_type = 1
+			LIMIT 1
+		) OR EXISTS(
+			SELECT 1 FROM favorites
+			WHERE user_id = $1
+			LIMIT 1
 		)
 	`
 
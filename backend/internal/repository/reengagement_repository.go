@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// userLastActivity is a user's latest sign-in, token refresh or signup, the
+// activity re-engagement emails measure inactivity from. Access tokens are
+// refreshed while the site is open, so refreshes track visits. Queries select
+// from users as u.
+const userLastActivity = `GREATEST(
+	u.created_at,
+	COALESCE(u.last_login_at, u.created_at),
+	COALESCE((SELECT MAX(rt.created_at) FROM refresh_tokens rt WHERE rt.user_id = u.id), u.created_at)
+)`
+
+// ReengagementRepository handles database operations for re-engagement emails
+type ReengagementRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewReengagementRepository creates a new ReengagementRepository
+func NewReengagementRepository(pool *pgxpool.Pool) *ReengagementRepository {
+	return &ReengagementRepository{pool: pool}
+}
+
+// ListDue lists users with email on and re-engagement emails allowed who
+// have been inactive for at least one of milestones, with the highest
+// milestone they reached. Users are left out when that milestone or a higher
+// one was already handled in their inactivity streak, or when they got a
+// re-engagement email within minGap, so a milestone missed while a higher one
+// was due is never sent late. Failed emails with attempts left are listed again.
+func (r *ReengagementRepository) ListDue(ctx context.Context, milestones []int, now time.Time, minGap time.Duration, maxAttempts, limit int) ([]models.ReengagementCandidate, error) {
+	query := `
+		WITH inactive AS (
+			SELECT u.id AS user_id, ` + userLastActivity + ` AS inactive_since
+			FROM users u
+			JOIN notification_preferences p ON p.user_id = u.id
+			WHERE p.email_enabled = true
+			  AND p.notify_reengagement = true
+			  AND p.email_digest <> 'never'
+			  AND u.email IS NOT NULL AND u.email <> ''
+			  AND u.is_banned = false
+		),
+		due AS (
+			SELECT i.user_id, i.inactive_since,
+				(SELECT MAX(m) FROM unnest($1::int[]) m
+				 WHERE i.inactive_since <= $2::timestamp - make_interval(days => m)) AS milestone_days
+			FROM inactive i
+		)
+		SELECT d.user_id, d.milestone_days, d.inactive_since
+		FROM due d
+		WHERE d.milestone_days IS NOT NULL
+		  AND NOT EXISTS (
+			SELECT 1 FROM reengagement_emails e
+			WHERE e.user_id = d.user_id
+			  AND e.inactive_since = d.inactive_since
+			  AND e.milestone_days >= d.milestone_days
+			  AND (e.status <> 'failed' OR e.attempts >= $4)
+		  )
+		  AND NOT EXISTS (
+			SELECT 1 FROM reengagement_emails e
+			WHERE e.user_id = d.user_id
+			  AND e.status = 'sent'
+			  AND e.sent_at > $2::timestamp - $3::interval
+		  )
+		ORDER BY d.inactive_since DESC
+		LIMIT $5
+	`
+
+	rows, err := r.pool.Query(ctx, query, milestones, now, minGap, maxAttempts, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users due a re-engagement email: %w", err)
+	}
+	defer rows.Close()
+
+	candidates := []models.ReengagementCandidate{}
+	for rows.Next() {
+		var c models.ReengagementCandidate
+		if err := rows.Scan(&c.UserID, &c.MilestoneDays, &c.InactiveSince); err != nil {
+			return nil, fmt.Errorf("failed to scan re-engagement candidate: %w", err)
+		}
+		candidates = append(candidates, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate re-engagement candidates: %w", err)
+	}
+
+	return candidates, nil
+}
+
+// Claim records a pending email for the user, streak and milestone. It
+// returns false if the email was already sent or skipped, is being sent by
+// another instance, or has failed maxAttempts times; a failed email with
+// attempts left is claimed again.
+func (r *ReengagementRepository) Claim(ctx context.Context, email *models.ReengagementEmail, maxAttempts int) (bool, error) {
+	query := `
+		INSERT INTO reengagement_emails (user_id, milestone_days, inactive_since)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, inactive_since, milestone_days) DO UPDATE SET
+			status = 'pending',
+			attempts = reengagement_emails.attempts + 1,
+			error_message = NULL
+		WHERE reengagement_emails.status = 'failed' AND reengagement_emails.attempts < $4
+		RETURNING id, status, attempts, created_at
+	`
+
+	err := r.pool.QueryRow(ctx, query, email.UserID, email.MilestoneDays, email.InactiveSince, maxAttempts).
+		Scan(&email.ID, &email.Status, &email.Attempts, &email.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to claim re-engagement email: %w", err)
+	}
+
+	return true, nil
+}
+
+// Complete records the outcome of a claimed email
+func (r *ReengagementRepository) Complete(ctx context.Context, email *models.ReengagementEmail) error {
+	query := `
+		UPDATE reengagement_emails
+		SET status = $2,
+			skip_reason = $3,
+			clip_count = $4,
+			from_follows = $5,
+			email_log_id = $6,
+			error_message = $7,
+			sent_at = CASE WHEN $2 = 'sent' THEN NOW() ELSE sent_at END
+		WHERE id = $1
+	`
+
+	_, err := r.pool.Exec(ctx, query, email.ID, email.Status, email.SkipReason, email.ClipCount,
+		email.FromFollows, email.EmailLogID, email.ErrorMessage)
+	if err != nil {
+		return fmt.Errorf("failed to update re-engagement email: %w", err)
+	}
+	return nil
+}
+
+// LastActivity returns a user's latest activity
+func (r *ReengagementRepository) LastActivity(ctx context.Context, userID uuid.UUID) (time.Time, error) {
+	var lastActivity time.Time
+	err := r.pool.QueryRow(ctx, `SELECT `+userLastActivity+` FROM users u WHERE u.id = $1`, userID).Scan(&lastActivity)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get last activity: %w", err)
+	}
+	return lastActivity, nil
+}
+
+// TopClipsFromFollows returns the highest voted clips created since the given
+// time by or featuring broadcasters the user follows, or submitted by users
+// they follow
+func (r *ReengagementRepository) TopClipsFromFollows(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]models.ReengagementClip, error) {
+	query := `
+		SELECT c.id, c.title, c.broadcaster_name, c.game_name, c.thumbnail_url, c.vote_score
+		FROM clips c
+		WHERE c.is_removed = false
+		  AND c.is_hidden = false
+		  AND c.is_nsfw = false
+		  AND c.created_at >= $2
+		  AND (
+			c.broadcaster_id IN (SELECT broadcaster_id FROM broadcaster_follows WHERE user_id = $1)
+			OR EXISTS (
+				SELECT 1 FROM clip_featured_broadcasters cfb
+				JOIN broadcaster_follows bf ON bf.broadcaster_id = cfb.broadcaster_id AND bf.user_id = $1
+				WHERE cfb.clip_id = c.id
+			)
+			OR c.submitted_by_user_id IN (SELECT following_id FROM user_follows WHERE follower_id = $1)
+		  )
+		  AND (c.submitted_by_user_id IS NULL
+			OR c.submitted_by_user_id NOT IN (SELECT blocked_user_id FROM user_blocks WHERE user_id = $1))
+		ORDER BY c.vote_score DESC, c.view_count DESC
+		LIMIT $3
+	`
+	return r.queryClips(ctx, query, userID, since, limit)
+}
+
+// TopClips returns the highest voted clips created since the given time
+func (r *ReengagementRepository) TopClips(ctx context.Context, since time.Time, limit int) ([]models.ReengagementClip, error) {
+	query := `
+		SELECT c.id, c.title, c.broadcaster_name, c.game_name, c.thumbnail_url, c.vote_score
+		FROM clips c
+		WHERE c.is_removed = false
+		  AND c.is_hidden = false
+		  AND c.is_nsfw = false
+		  AND c.created_at >= $1
+		ORDER BY c.vote_score DESC, c.view_count DESC
+		LIMIT $2
+	`
+	return r.queryClips(ctx, query, since, limit)
+}
+
+func (r *ReengagementRepository) queryClips(ctx context.Context, query string, args ...interface{}) ([]models.ReengagementClip, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list re-engagement clips: %w", err)
+	}
+	defer rows.Close()
+
+	clips := []models.ReengagementClip{}
+	for rows.Next() {
+		var clip models.ReengagementClip
+		if err := rows.Scan(&clip.ID, &clip.Title, &clip.BroadcasterName, &clip.GameName, &clip.ThumbnailURL, &clip.VoteScore); err != nil {
+			return nil, fmt.Errorf("failed to scan re-engagement clip: %w", err)
+		}
+		clips = append(clips, clip)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate re-engagement clips: %w", err)
+	}
+	return clips, nil
+}
+
+// CampaignMetrics summarizes re-engagement emails created in [start, end) by
+// milestone. Clicks come from first-party tracked links; a user returned when
+// they were active within returnWindow after the email was sent.
+func (r *ReengagementRepository) CampaignMetrics(ctx context.Context, start, end time.Time, returnWindow time.Duration) ([]models.ReengagementCampaignMetrics, error) {
+	query := `
+		SELECT e.milestone_days,
+			COUNT(*) FILTER (WHERE e.status = 'sent'),
+			COUNT(*) FILTER (WHERE e.status = 'skipped'),
+			COUNT(*) FILTER (WHERE e.status = 'skipped' AND e.skip_reason = 'active'),
+			COUNT(*) FILTER (WHERE e.status = 'failed'),
+			COUNT(*) FILTER (WHERE e.status = 'sent' AND EXISTS (
+				SELECT 1 FROM email_tracked_links l
+				WHERE l.email_notification_log_id = e.email_log_id AND l.first_clicked_at IS NOT NULL
+			)),
+			COUNT(*) FILTER (WHERE e.status = 'sent' AND EXISTS (
+				SELECT 1 FROM users u
+				WHERE u.id = e.user_id
+				  AND (
+					u.last_login_at BETWEEN e.sent_at AND e.sent_at + $3::interval
+					OR EXISTS (
+						SELECT 1 FROM refresh_tokens rt
+						WHERE rt.user_id = u.id AND rt.created_at BETWEEN e.sent_at AND e.sent_at + $3::interval
+					)
+				  )
+			))
+		FROM reengagement_emails e
+		WHERE e.created_at >= $1 AND e.created_at < $2
+		GROUP BY e.milestone_days
+		ORDER BY e.milestone_days
+	`
+
+	rows, err := r.pool.Query(ctx, query, start, end, returnWindow)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get re-engagement campaign metrics: %w", err)
+	}
+	defer rows.Close()
+
+	metrics := []models.ReengagementCampaignMetrics{}
+	for rows.Next() {
+		var m models.ReengagementCampaignMetrics
+		if err := rows.Scan(&m.MilestoneDays, &m.Sent, &m.Skipped, &m.Suppressed, &m.Failed, &m.Clicked, &m.Returned); err != nil {
+			return nil, fmt.Errorf("failed to scan re-engagement campaign metrics: %w", err)
+		}
+		metrics = append(metrics, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate re-engagement campaign metrics: %w", err)
+	}
+	return metrics, nil
+}
@@ -2,10 +2,13 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/subculture-collective/clipper/internal/models"
 	"github.com/subculture-collective/clipper/internal/utils"
@@ -21,16 +24,38 @@ func NewReportRepository(db *pgxpool.Pool) *ReportRepository {
 	return &ReportRepository{db: db}
 }
 
-// CreateReport creates a new report
+// CreateReport creates a new report, adding it to the open report group for
+// the reported item or opening one
 func (r *ReportRepository) CreateReport(ctx context.Context, report *models.Report) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var groupID uuid.UUID
+	err = tx.QueryRow(ctx, `
+		INSERT INTO report_groups (reportable_type, reportable_id, report_count, first_reported_at, last_reported_at)
+		VALUES ($1, $2, 1, $3, $3)
+		ON CONFLICT (reportable_type, reportable_id) WHERE status = 'open'
+		DO UPDATE SET
+			report_count = report_groups.report_count + 1,
+			last_reported_at = GREATEST(report_groups.last_reported_at, EXCLUDED.last_reported_at)
+		RETURNING id
+	`, report.ReportableType, report.ReportableID, report.CreatedAt).Scan(&groupID)
+	if err != nil {
+		return fmt.Errorf("failed to group report: %w", err)
+	}
+	report.GroupID = &groupID
+
 	query := `
 		INSERT INTO reports (
 			id, reporter_id, reportable_type, reportable_id,
-			reason, description, status, created_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			reason, description, status, created_at, group_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 
-	_, err := r.db.Exec(ctx, query,
+	_, err = tx.Exec(ctx, query,
 		report.ID,
 		report.ReporterID,
 		report.ReportableType,
@@ -39,16 +64,20 @@ func (r *ReportRepository) CreateReport(ctx context.Context, report *models.Repo
 		report.Description,
 		report.Status,
 		report.CreatedAt,
+		report.GroupID,
 	)
+	if err != nil {
+		return err
+	}
 
-	return err
+	return tx.Commit(ctx)
 }
 
 // GetReportByID retrieves a report by ID
 func (r *ReportRepository) GetReportByID(ctx context.Context, reportID uuid.UUID) (*models.Report, error) {
 	query := `
 		SELECT id, reporter_id, reportable_type, reportable_id,
-			reason, description, status, reviewed_by, reviewed_at, created_at
+			reason, description, status, reviewed_by, reviewed_at, created_at, group_id
 		FROM reports
 		WHERE id = $1
 	`
@@ -65,6 +94,7 @@ func (r *ReportRepository) GetReportByID(ctx context.Context, reportID uuid.UUID
 		&report.ReviewedBy,
 		&report.ReviewedAt,
 		&report.CreatedAt,
+		&report.GroupID,
 	)
 
 	if err != nil {
@@ -105,7 +135,7 @@ func (r *ReportRepository) ListReports(ctx context.Context, status, reportableTy
 	offset := (page - 1) * limit
 	query := fmt.Sprintf(`
 		SELECT id, reporter_id, reportable_type, reportable_id,
-			reason, description, status, reviewed_by, reviewed_at, created_at
+			reason, description, status, reviewed_by, reviewed_at, created_at, group_id
 		FROM reports
 		%s
 		ORDER BY created_at DESC
@@ -134,6 +164,7 @@ func (r *ReportRepository) ListReports(ctx context.Context, status, reportableTy
 			&report.ReviewedBy,
 			&report.ReviewedAt,
 			&report.CreatedAt,
+			&report.GroupID,
 		)
 		if err != nil {
 			return nil, 0, err
@@ -144,7 +175,8 @@ func (r *ReportRepository) ListReports(ctx context.Context, status, reportableTy
 	return reports, total, nil
 }
 
-// UpdateReportStatus updates the status of a report
+// UpdateReportStatus updates the status of a report. Its report group is
+// resolved once none of the group's reports are left open.
 func (r *ReportRepository) UpdateReportStatus(ctx context.Context, reportID uuid.UUID, status string, reviewerID uuid.UUID) error {
 	query := `
 		UPDATE reports
@@ -153,7 +185,27 @@ func (r *ReportRepository) UpdateReportStatus(ctx context.Context, reportID uuid
 	`
 
 	_, err := r.db.Exec(ctx, query, status, reviewerID, time.Now(), reportID)
-	return err
+	if err != nil {
+		return err
+	}
+
+	if status != "actioned" && status != "dismissed" {
+		return nil
+	}
+	_, err = r.db.Exec(ctx, `
+		UPDATE report_groups g
+		SET status = 'resolved', resolution = $2, resolved_by = $3, resolved_at = NOW()
+		WHERE g.id = (SELECT group_id FROM reports WHERE id = $1)
+		  AND g.status = 'open'
+		  AND NOT EXISTS (
+			SELECT 1 FROM reports
+			WHERE group_id = g.id AND status IN ('pending', 'reviewed')
+		  )
+	`, reportID, status, reviewerID)
+	if err != nil {
+		return fmt.Errorf("failed to resolve report group: %w", err)
+	}
+	return nil
 }
 
 // CheckDuplicateReport checks if a user has already reported the same item
@@ -190,7 +242,7 @@ func (r *ReportRepository) GetReportCountByUser(ctx context.Context, userID uuid
 func (r *ReportRepository) GetReportsByReportable(ctx context.Context, reportableID uuid.UUID, reportableType string) ([]models.Report, error) {
 	query := `
 		SELECT id, reporter_id, reportable_type, reportable_id,
-			reason, description, status, reviewed_by, reviewed_at, created_at
+			reason, description, status, reviewed_by, reviewed_at, created_at, group_id
 		FROM reports
 		WHERE reportable_id = $1 AND reportable_type = $2
 		ORDER BY created_at DESC
@@ -216,6 +268,7 @@ func (r *ReportRepository) GetReportsByReportable(ctx context.Context, reportabl
 			&report.ReviewedBy,
 			&report.ReviewedAt,
 			&report.CreatedAt,
+			&report.GroupID,
 		)
 		if err != nil {
 			return nil, err
@@ -225,3 +278,308 @@ func (r *ReportRepository) GetReportsByReportable(ctx context.Context, reportabl
 
 	return reports, nil
 }
+
+// ErrReportGroupNotFound is returned when a report group doesn't exist or has already been resolved
+var ErrReportGroupNotFound = errors.New("report group not found")
+
+// reportGroupColumns are the report_groups columns read by scanReportGroup,
+// followed by the group's reasons rolled up as a JSON array
+const reportGroupColumns = `
+	g.id, g.reportable_type, g.reportable_id, g.status, g.report_count,
+	g.avg_reporter_trust::float8, g.reach, g.priority_score::float8, g.scored_at,
+	g.first_reported_at, g.last_reported_at, g.resolution, g.resolved_by, g.resolved_at,
+	COALESCE((
+		SELECT json_agg(json_build_object('reason', rc.reason, 'count', rc.n) ORDER BY rc.n DESC, rc.reason)
+		FROM (
+			SELECT reason, COUNT(*) AS n FROM reports
+			WHERE group_id = g.id
+			GROUP BY reason
+		) rc
+	), '[]'::json)
+`
+
+func scanReportGroup(row pgx.Row) (*models.ReportGroup, error) {
+	var g models.ReportGroup
+	var reasons []byte
+	err := row.Scan(
+		&g.ID, &g.ReportableType, &g.ReportableID, &g.Status, &g.ReportCount,
+		&g.AvgReporterTrust, &g.Reach, &g.PriorityScore, &g.ScoredAt,
+		&g.FirstReportedAt, &g.LastReportedAt, &g.Resolution, &g.ResolvedBy, &g.ResolvedAt,
+		&reasons,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(reasons, &g.Reasons); err != nil {
+		return nil, fmt.Errorf("failed to decode report reasons: %w", err)
+	}
+	return &g, nil
+}
+
+// ListReportGroupStats returns the priority score inputs of open report
+// groups: the number of open reports, their reporters' mean trust score and
+// the reported item's reach. A nil groupID covers every open group.
+func (r *ReportRepository) ListReportGroupStats(ctx context.Context, groupID *uuid.UUID) ([]models.ReportGroupStats, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT g.id, COUNT(rp.id),
+			COALESCE(AVG(COALESCE(u.trust_score, 0)), 0)::float8,
+			COALESCE(CASE g.reportable_type
+				WHEN 'clip' THEN (SELECT view_count FROM clips WHERE id = g.reportable_id)
+				WHEN 'comment' THEN (
+					SELECT c.view_count FROM comments cm
+					JOIN clips c ON c.id = cm.clip_id
+					WHERE cm.id = g.reportable_id
+				)
+				WHEN 'user' THEN (SELECT follower_count FROM users WHERE id = g.reportable_id)
+			END, 0)::bigint
+		FROM report_groups g
+		LEFT JOIN reports rp ON rp.group_id = g.id AND rp.status IN ('pending', 'reviewed')
+		LEFT JOIN users u ON u.id = rp.reporter_id
+		WHERE g.status = 'open' AND ($1::uuid IS NULL OR g.id = $1)
+		GROUP BY g.id
+	`, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load report group stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []models.ReportGroupStats
+	for rows.Next() {
+		var s models.ReportGroupStats
+		if err := rows.Scan(&s.GroupID, &s.ReportCount, &s.AvgReporterTrust, &s.Reach); err != nil {
+			return nil, fmt.Errorf("failed to scan report group stats: %w", err)
+		}
+		stats = append(stats, s)
+	}
+	return stats, rows.Err()
+}
+
+// SetReportGroupPriority stores an open group's priority score and the
+// inputs it was computed from
+func (r *ReportRepository) SetReportGroupPriority(ctx context.Context, stats models.ReportGroupStats, score float64) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE report_groups
+		SET avg_reporter_trust = $2, reach = $3, priority_score = $4, scored_at = NOW()
+		WHERE id = $1 AND status = 'open'
+	`, stats.GroupID, stats.AvgReporterTrust, stats.Reach, score)
+	if err != nil {
+		return fmt.Errorf("failed to set report group priority: %w", err)
+	}
+	return nil
+}
+
+// ListReportQueue returns report groups, highest priority first, and the
+// total number of matching groups
+func (r *ReportRepository) ListReportQueue(ctx context.Context, filters models.ReportQueueFilters) ([]models.ReportGroup, int, error) {
+	where := `WHERE ($1::text = '' OR g.status = $1) AND ($2::text = '' OR g.reportable_type = $2)`
+
+	var total int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM report_groups g `+where,
+		filters.Status, filters.ReportableType).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count report groups: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT `+reportGroupColumns+`
+		FROM report_groups g
+		`+where+`
+		ORDER BY g.priority_score DESC, g.last_reported_at DESC
+		LIMIT $3 OFFSET $4
+	`, filters.Status, filters.ReportableType, filters.Limit, filters.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list report queue: %w", err)
+	}
+	defer rows.Close()
+
+	groups := []models.ReportGroup{}
+	for rows.Next() {
+		g, err := scanReportGroup(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan report group: %w", err)
+		}
+		groups = append(groups, *g)
+	}
+	return groups, total, rows.Err()
+}
+
+// GetReportGroup retrieves a report group by ID
+func (r *ReportRepository) GetReportGroup(ctx context.Context, id uuid.UUID) (*models.ReportGroup, error) {
+	row := r.db.QueryRow(ctx, `SELECT `+reportGroupColumns+` FROM report_groups g WHERE g.id = $1`, id)
+	g, err := scanReportGroup(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrReportGroupNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report group: %w", err)
+	}
+	return g, nil
+}
+
+// ListReportsByGroup retrieves the reports in a group, newest first
+func (r *ReportRepository) ListReportsByGroup(ctx context.Context, groupID uuid.UUID) ([]models.Report, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, reporter_id, reportable_type, reportable_id,
+			reason, description, status, reviewed_by, reviewed_at, created_at, group_id
+		FROM reports
+		WHERE group_id = $1
+		ORDER BY created_at DESC
+	`, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group reports: %w", err)
+	}
+	defer rows.Close()
+
+	reports := []models.Report{}
+	for rows.Next() {
+		var report models.Report
+		err := rows.Scan(
+			&report.ID,
+			&report.ReporterID,
+			&report.ReportableType,
+			&report.ReportableID,
+			&report.Reason,
+			&report.Description,
+			&report.Status,
+			&report.ReviewedBy,
+			&report.ReviewedAt,
+			&report.CreatedAt,
+			&report.GroupID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan report: %w", err)
+		}
+		reports = append(reports, report)
+	}
+	return reports, rows.Err()
+}
+
+// ResolveReportGroup resolves an open group and gives every one of its open
+// reports the same status. It returns the reports resolved.
+func (r *ReportRepository) ResolveReportGroup(ctx context.Context, id uuid.UUID, resolution string, reviewerID uuid.UUID) ([]models.Report, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE report_groups
+		SET status = 'resolved', resolution = $2, resolved_by = $3, resolved_at = NOW()
+		WHERE id = $1 AND status = 'open'
+	`, id, resolution, reviewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve report group: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, ErrReportGroupNotFound
+	}
+
+	rows, err := tx.Query(ctx, `
+		UPDATE reports
+		SET status = $2, reviewed_by = $3, reviewed_at = NOW()
+		WHERE group_id = $1 AND status IN ('pending', 'reviewed')
+		RETURNING id, reporter_id, reportable_type, reportable_id,
+			reason, description, status, reviewed_by, reviewed_at, created_at, group_id
+	`, id, resolution, reviewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve group reports: %w", err)
+	}
+	reports := []models.Report{}
+	for rows.Next() {
+		var report models.Report
+		err := rows.Scan(
+			&report.ID,
+			&report.ReporterID,
+			&report.ReportableType,
+			&report.ReportableID,
+			&report.Reason,
+			&report.Description,
+			&report.Status,
+			&report.ReviewedBy,
+			&report.ReviewedAt,
+			&report.CreatedAt,
+			&report.GroupID,
+		)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan resolved report: %w", err)
+		}
+		reports = append(reports, report)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to resolve group reports: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit report group resolution: %w", err)
+	}
+	return reports, nil
+}
+
+// RecordReporterOutcome counts a resolved report toward its reporter's
+// correct or incorrect reports, which feed the reporter's trust score
+func (r *ReportRepository) RecordReporterOutcome(ctx context.Context, reporterID uuid.UUID, actioned bool) error {
+	correct, incorrect := 0, 1
+	if actioned {
+		correct, incorrect = 1, 0
+	}
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_stats (user_id, correct_reports, incorrect_reports, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			correct_reports = COALESCE(user_stats.correct_reports, 0) + EXCLUDED.correct_reports,
+			incorrect_reports = COALESCE(user_stats.incorrect_reports, 0) + EXCLUDED.incorrect_reports,
+			updated_at = NOW()
+	`, reporterID, correct, incorrect)
+	if err != nil {
+		return fmt.Errorf("failed to record reporter outcome: %w", err)
+	}
+	return nil
+}
+
+// ListReportsByReporter retrieves a user's own reports, newest first, and the
+// total number of matching reports
+func (r *ReportRepository) ListReportsByReporter(ctx context.Context, reporterID uuid.UUID, status string, limit, offset int) ([]models.ReporterReport, int, error) {
+	var total int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM reports
+		WHERE reporter_id = $1 AND ($2::text = '' OR status = $2)
+	`, reporterID, status).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count reports: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, reportable_type, reportable_id, reason, description, status, reviewed_at, created_at
+		FROM reports
+		WHERE reporter_id = $1 AND ($2::text = '' OR status = $2)
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4
+	`, reporterID, status, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list reports: %w", err)
+	}
+	defer rows.Close()
+
+	reports := []models.ReporterReport{}
+	for rows.Next() {
+		var report models.ReporterReport
+		err := rows.Scan(
+			&report.ID,
+			&report.ReportableType,
+			&report.ReportableID,
+			&report.Reason,
+			&report.Description,
+			&report.Status,
+			&report.ReviewedAt,
+			&report.CreatedAt,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan report: %w", err)
+		}
+		reports = append(reports, report)
+	}
+	return reports, total, rows.Err()
+}
@@ -0,0 +1,330 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// ErrSearchNotFound is returned when a click refers to a search that wasn't tracked
+var ErrSearchNotFound = errors.New("search not found")
+
+// searchesInWindow selects the tracked searches made in [$1, $2), with their
+// query normalized and whether any of their results was clicked
+const searchesInWindow = `
+	WITH s AS (
+		SELECT q.id, q.user_id, lower(btrim(q.query)) AS query, COALESCE(q.result_count, 0) AS result_count, q.created_at,
+		       EXISTS (SELECT 1 FROM search_clicks c WHERE c.search_query_id = q.id) AS clicked
+		FROM search_queries q
+		WHERE q.created_at >= $1 AND q.created_at < $2 AND btrim(q.query) != ''
+	)`
+
+// SearchAnalyticsRepository aggregates tracked searches and their clicks
+type SearchAnalyticsRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewSearchAnalyticsRepository creates a new SearchAnalyticsRepository
+func NewSearchAnalyticsRepository(pool *pgxpool.Pool) *SearchAnalyticsRepository {
+	return &SearchAnalyticsRepository{pool: pool}
+}
+
+// RecordClick records that a result of a tracked search was opened
+func (r *SearchAnalyticsRepository) RecordClick(ctx context.Context, userID *uuid.UUID, click *models.SearchClickRequest) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO search_clicks (search_query_id, user_id, result_id, result_type, position)
+		VALUES ($1, $2, $3, $4, $5)
+	`, click.SearchID, userID, click.ResultID, click.ResultType, click.Position)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return ErrSearchNotFound
+		}
+		return fmt.Errorf("failed to record search click: %w", err)
+	}
+	return nil
+}
+
+// GetTotals counts the searches made in [start, end)
+func (r *SearchAnalyticsRepository) GetTotals(ctx context.Context, start, end time.Time) (*models.SearchAnalyticsTotals, error) {
+	var totals models.SearchAnalyticsTotals
+	err := r.pool.QueryRow(ctx, searchesInWindow+`
+		SELECT COUNT(*), COUNT(DISTINCT user_id),
+		       COUNT(*) FILTER (WHERE result_count = 0), COUNT(*) FILTER (WHERE clicked)
+		FROM s
+	`, start.UTC(), end.UTC()).Scan(&totals.Searches, &totals.UniqueUsers, &totals.ZeroResultSearches, &totals.ClickedSearches)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count searches: %w", err)
+	}
+	return &totals, nil
+}
+
+func (r *SearchAnalyticsRepository) listQueryStats(ctx context.Context, query string, args ...interface{}) ([]models.SearchQueryStats, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list search queries: %w", err)
+	}
+	defer rows.Close()
+
+	stats := []models.SearchQueryStats{}
+	for rows.Next() {
+		var s models.SearchQueryStats
+		if err := rows.Scan(
+			&s.Query, &s.Searches, &s.UniqueUsers, &s.AvgResults,
+			&s.ZeroResultSearches, &s.ClickedSearches, &s.LastSearched,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan search query: %w", err)
+		}
+		stats = append(stats, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating search queries: %w", err)
+	}
+	return stats, nil
+}
+
+const searchQueryStatsColumns = `
+	query, COUNT(*), COUNT(DISTINCT user_id), AVG(result_count)::float8,
+	COUNT(*) FILTER (WHERE result_count = 0), COUNT(*) FILTER (WHERE clicked), MAX(created_at)`
+
+// ListTopQueries returns the most searched queries in [start, end)
+func (r *SearchAnalyticsRepository) ListTopQueries(ctx context.Context, start, end time.Time, limit int) ([]models.SearchQueryStats, error) {
+	return r.listQueryStats(ctx, searchesInWindow+`
+		SELECT `+searchQueryStatsColumns+`
+		FROM s
+		GROUP BY query
+		ORDER BY COUNT(*) DESC, query
+		LIMIT $3
+	`, start.UTC(), end.UTC(), limit)
+}
+
+// ListZeroResultQueries returns the queries that most often found nothing in
+// [start, end), counting only their searches without results
+func (r *SearchAnalyticsRepository) ListZeroResultQueries(ctx context.Context, start, end time.Time, limit int) ([]models.SearchQueryStats, error) {
+	return r.listQueryStats(ctx, searchesInWindow+`
+		SELECT `+searchQueryStatsColumns+`
+		FROM s
+		WHERE result_count = 0
+		GROUP BY query
+		ORDER BY COUNT(*) DESC, query
+		LIMIT $3
+	`, start.UTC(), end.UTC(), limit)
+}
+
+// GetPositionClicks returns the impressions and clicked searches of result
+// positions 1 to positions for the searches made in [start, end)
+func (r *SearchAnalyticsRepository) GetPositionClicks(ctx context.Context, start, end time.Time, positions int) ([]models.SearchPositionCTR, error) {
+	stats := make([]models.SearchPositionCTR, positions)
+	for i := range stats {
+		stats[i].Position = i + 1
+	}
+
+	// A search with n results is an impression for positions 1 to n
+	rows, err := r.pool.Query(ctx, searchesInWindow+`
+		SELECT LEAST(result_count, $3), COUNT(*)
+		FROM s
+		WHERE result_count > 0
+		GROUP BY 1
+	`, start.UTC(), end.UTC(), positions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count search impressions: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var resultCount, searches int
+		if err := rows.Scan(&resultCount, &searches); err != nil {
+			return nil, fmt.Errorf("failed to scan search impressions: %w", err)
+		}
+		for i := 0; i < resultCount; i++ {
+			stats[i].Impressions += searches
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating search impressions: %w", err)
+	}
+
+	rows, err = r.pool.Query(ctx, `
+		SELECT c.position, COUNT(DISTINCT c.search_query_id)
+		FROM search_clicks c
+		JOIN search_queries q ON q.id = c.search_query_id
+		WHERE q.created_at >= $1 AND q.created_at < $2 AND btrim(q.query) != '' AND c.position <= $3
+		GROUP BY c.position
+	`, start.UTC(), end.UTC(), positions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count search clicks: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var position, clicks int
+		if err := rows.Scan(&position, &clicks); err != nil {
+			return nil, fmt.Errorf("failed to scan search clicks: %w", err)
+		}
+		stats[position-1].Clicks = clicks
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating search clicks: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetTrends counts the searches in [start, end) per day or week, including
+// periods without searches. interval must be "day" or "week".
+func (r *SearchAnalyticsRepository) GetTrends(ctx context.Context, start, end time.Time, interval string) ([]models.SearchAnalyticsTotals, error) {
+	rows, err := r.pool.Query(ctx, searchesInWindow+`
+		SELECT p.period_start, COUNT(s.id), COUNT(DISTINCT s.user_id),
+		       COUNT(s.id) FILTER (WHERE s.result_count = 0), COUNT(s.id) FILTER (WHERE s.clicked)
+		FROM generate_series(date_trunc($3::text, $1::timestamp), $2::timestamp - INTERVAL '1 microsecond', ('1 ' || $3::text)::interval) AS p(period_start)
+		LEFT JOIN s ON s.created_at >= p.period_start AND s.created_at < p.period_start + ('1 ' || $3::text)::interval
+		GROUP BY p.period_start
+		ORDER BY p.period_start
+	`, start.UTC(), end.UTC(), interval)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get search trends: %w", err)
+	}
+	defer rows.Close()
+
+	trends := []models.SearchAnalyticsTotals{}
+	for rows.Next() {
+		var periodStart time.Time
+		var t models.SearchAnalyticsTotals
+		if err := rows.Scan(&periodStart, &t.Searches, &t.UniqueUsers, &t.ZeroResultSearches, &t.ClickedSearches); err != nil {
+			return nil, fmt.Errorf("failed to scan search trend: %w", err)
+		}
+		t.PeriodStart = &periodStart
+		trends = append(trends, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating search trends: %w", err)
+	}
+	return trends, nil
+}
+
+// ListClipClickJudgments returns, for the limit most searched queries of
+// [start, end) searched by at least minUsers signed-in users, each public clip
+// clicked from their results. Rows are ordered by query popularity, then by
+// clicks.
+func (r *SearchAnalyticsRepository) ListClipClickJudgments(ctx context.Context, start, end time.Time, minUsers, limit int) ([]models.SearchClickJudgment, error) {
+	rows, err := r.pool.Query(ctx, searchesInWindow+`,
+	queries AS (
+		SELECT query, COUNT(*) AS searches, COUNT(DISTINCT user_id) AS users
+		FROM s
+		GROUP BY query
+		HAVING COUNT(DISTINCT user_id) >= $3
+		ORDER BY COUNT(*) DESC, query
+		LIMIT $4
+	)
+		SELECT qs.query, qs.searches, qs.users, c.result_id,
+		       COUNT(DISTINCT c.search_query_id), AVG(c.position)::float8
+		FROM queries qs
+		JOIN s ON s.query = qs.query
+		JOIN search_clicks c ON c.search_query_id = s.id AND c.result_type = 'clip'
+		JOIN clips cl ON cl.id = c.result_id AND cl.is_removed = false AND cl.is_hidden = false
+		GROUP BY qs.query, qs.searches, qs.users, c.result_id
+		ORDER BY qs.searches DESC, qs.query, COUNT(DISTINCT c.search_query_id) DESC, c.result_id
+	`, start.UTC(), end.UTC(), minUsers, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list search click judgments: %w", err)
+	}
+	defer rows.Close()
+
+	judgments := []models.SearchClickJudgment{}
+	for rows.Next() {
+		var j models.SearchClickJudgment
+		if err := rows.Scan(&j.Query, &j.Searches, &j.UniqueUsers, &j.ClipID, &j.ClickedSearches, &j.AvgPosition); err != nil {
+			return nil, fmt.Errorf("failed to scan search click judgment: %w", err)
+		}
+		judgments = append(judgments, j)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating search click judgments: %w", err)
+	}
+	return judgments, nil
+}
+
+// GetQueryClipClicks counts the searches for query made in [start, end), and
+// how many of them clicked each of clipIDs. query is matched like the
+// analytics, case-insensitively and trimmed.
+func (r *SearchAnalyticsRepository) GetQueryClipClicks(ctx context.Context, query string, clipIDs []uuid.UUID, start, end time.Time) (int, map[uuid.UUID]int, error) {
+	rows, err := r.pool.Query(ctx, searchesInWindow+`
+		SELECT NULL::uuid, COUNT(*)
+		FROM s
+		WHERE s.query = lower(btrim($3))
+		UNION ALL
+		SELECT c.result_id, COUNT(DISTINCT c.search_query_id)
+		FROM s
+		JOIN search_clicks c ON c.search_query_id = s.id AND c.result_type = 'clip'
+		WHERE s.query = lower(btrim($3)) AND c.result_id = ANY($4)
+		GROUP BY c.result_id
+	`, start.UTC(), end.UTC(), query, clipIDs)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to count query clip clicks: %w", err)
+	}
+	defer rows.Close()
+
+	searches := 0
+	clicks := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var clipID *uuid.UUID
+		var count int
+		if err := rows.Scan(&clipID, &count); err != nil {
+			return 0, nil, fmt.Errorf("failed to scan query clip clicks: %w", err)
+		}
+		if clipID == nil {
+			searches = count
+		} else {
+			clicks[*clipID] = count
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, nil, fmt.Errorf("error iterating query clip clicks: %w", err)
+	}
+	return searches, clicks, nil
+}
+
+// ListClickedSearches returns the searches of [start, end) by signed-in users
+// that clicked at least one public clip, for the limit most searched queries
+// searched by at least minUsers users. Searches are ordered by query
+// popularity, then by time.
+func (r *SearchAnalyticsRepository) ListClickedSearches(ctx context.Context, start, end time.Time, minUsers, limit int) ([]models.SearchClickedSearch, error) {
+	rows, err := r.pool.Query(ctx, searchesInWindow+`,
+	queries AS (
+		SELECT query, COUNT(*) AS searches
+		FROM s
+		GROUP BY query
+		HAVING COUNT(DISTINCT user_id) >= $3
+		ORDER BY COUNT(*) DESC, query
+		LIMIT $4
+	)
+		SELECT s.id, s.user_id, s.query, s.created_at, array_agg(DISTINCT c.result_id)
+		FROM queries qs
+		JOIN s ON s.query = qs.query AND s.clicked AND s.user_id IS NOT NULL
+		JOIN search_clicks c ON c.search_query_id = s.id AND c.result_type = 'clip'
+		JOIN clips cl ON cl.id = c.result_id AND cl.is_removed = false AND cl.is_hidden = false
+		GROUP BY qs.searches, s.id, s.user_id, s.query, s.created_at
+		ORDER BY qs.searches DESC, s.query, s.created_at
+	`, start.UTC(), end.UTC(), minUsers, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clicked searches: %w", err)
+	}
+	defer rows.Close()
+
+	searches := []models.SearchClickedSearch{}
+	for rows.Next() {
+		var search models.SearchClickedSearch
+		if err := rows.Scan(&search.ID, &search.UserID, &search.Query, &search.CreatedAt, &search.ClipIDs); err != nil {
+			return nil, fmt.Errorf("failed to scan clicked search: %w", err)
+		}
+		searches = append(searches, search)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating clicked searches: %w", err)
+	}
+	return searches, nil
+}
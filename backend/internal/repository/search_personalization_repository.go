@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// searchProfileMinLanguageClips is how many clips in a language a user must
+// have watched or upvoted before their searches favor it
+const searchProfileMinLanguageClips = 3
+
+// SearchPersonalizationRepository loads the follow, vote, interest and
+// language signals used to personalize search results
+type SearchPersonalizationRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewSearchPersonalizationRepository creates a new search personalization repository
+func NewSearchPersonalizationRepository(db *pgxpool.Pool) *SearchPersonalizationRepository {
+	return &SearchPersonalizationRepository{db: db}
+}
+
+// GetProfile returns the broadcasters and games a user follows and the games
+// they consistently downvote: at least minDownvotes downvotes making up at
+// least downvoteShare of their votes on the game's clips. It also returns the
+// streamers and games they picked as interests, and the languages making up
+// at least languageShare of the clips they watched in the last 90 days or
+// upvoted. The profile is empty when the user turned personalized search off.
+func (r *SearchPersonalizationRepository) GetProfile(ctx context.Context, userID uuid.UUID, minDownvotes int, downvoteShare, languageShare float64) (*models.SearchPersonalizationProfile, error) {
+	query := `
+		WITH enabled AS (
+			SELECT COALESCE(
+				(SELECT personalized_search FROM user_settings WHERE user_id = $1),
+				TRUE
+			) AS is_enabled
+		)
+		SELECT 'broadcaster', bf.broadcaster_id
+		FROM broadcaster_follows bf
+		CROSS JOIN enabled
+		WHERE bf.user_id = $1 AND enabled.is_enabled
+		UNION ALL
+		SELECT 'game', g.twitch_game_id
+		FROM game_follows gf
+		JOIN games g ON g.id = gf.game_id
+		CROSS JOIN enabled
+		WHERE gf.user_id = $1 AND enabled.is_enabled
+		UNION ALL
+		SELECT 'downvoted_game', c.game_id
+		FROM votes v
+		JOIN clips c ON c.id = v.clip_id
+		CROSS JOIN enabled
+		WHERE v.user_id = $1 AND c.game_id IS NOT NULL AND enabled.is_enabled
+		GROUP BY c.game_id
+		HAVING COUNT(*) FILTER (WHERE v.vote_type < 0) >= $2
+			AND COUNT(*) FILTER (WHERE v.vote_type < 0) >= $3::float8 * COUNT(*)
+		UNION ALL
+		SELECT 'interest_broadcaster', UNNEST(up.followed_streamers)
+		FROM user_preferences up
+		CROSS JOIN enabled
+		WHERE up.user_id = $1 AND enabled.is_enabled
+		UNION ALL
+		SELECT 'interest_game', UNNEST(up.favorite_games)
+		FROM user_preferences up
+		CROSS JOIN enabled
+		WHERE up.user_id = $1 AND enabled.is_enabled
+		UNION ALL
+		SELECT 'language', engaged.language
+		FROM (
+			SELECT LOWER(c.language) AS language,
+			       COUNT(*) AS clips,
+			       SUM(COUNT(*)) OVER () AS total
+			FROM clips c
+			WHERE c.language IS NOT NULL AND c.id IN (
+				SELECT wh.clip_id FROM watch_history wh
+				WHERE wh.user_id = $1 AND wh.watched_at > NOW() - INTERVAL '90 days'
+				UNION
+				SELECT v.clip_id FROM votes v
+				WHERE v.user_id = $1 AND v.vote_type > 0
+			)
+			GROUP BY LOWER(c.language)
+		) engaged
+		CROSS JOIN enabled
+		WHERE enabled.is_enabled
+			AND engaged.clips >= $5
+			AND engaged.clips >= $4::float8 * engaged.total
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, minDownvotes, downvoteShare, languageShare, searchProfileMinLanguageClips)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load search personalization profile: %w", err)
+	}
+	defer rows.Close()
+
+	profile := models.NewSearchPersonalizationProfile()
+	for rows.Next() {
+		var kind, id string
+		if err := rows.Scan(&kind, &id); err != nil {
+			return nil, fmt.Errorf("failed to scan search personalization signal: %w", err)
+		}
+		switch kind {
+		case "broadcaster":
+			profile.FollowedBroadcasters[id] = true
+		case "game":
+			profile.FollowedGames[id] = true
+		case "downvoted_game":
+			profile.DownvotedGames[id] = true
+		case "interest_broadcaster":
+			profile.InterestBroadcasters[id] = true
+		case "interest_game":
+			profile.InterestGames[id] = true
+		case "language":
+			profile.Languages[id] = true
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating search personalization signals: %w", err)
+	}
+
+	return profile, nil
+}
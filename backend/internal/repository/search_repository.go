@@ -97,7 +97,7 @@ func (r *SearchRepository) Search(ctx context.Context, req *models.SearchRequest
 func (r *SearchRepository) searchClips(ctx context.Context, tsQuery string, req *models.SearchRequest) ([]models.Clip, int, error) {
 	// Build WHERE clause with filters
 	// Belt-and-suspenders: only search user-submitted clips (scraped clips are in discovery_clips)
-	whereClause := "c.is_removed = false AND c.submitted_by_user_id IS NOT NULL"
+	whereClause := "c.is_removed = false AND c.submitted_by_user_id IS NOT NULL AND " + clipNotShadowBanned
 	args := []interface{}{}
 	argPos := 1
 
@@ -145,6 +145,40 @@ func (r *SearchRepository) searchClips(ctx context.Context, tsQuery string, req
 		argPos++
 	}
 
+	if req.PatchID != nil {
+		whereClause += fmt.Sprintf(" AND c.game_patch_id = %s", utils.SQLPlaceholder(argPos))
+		args = append(args, *req.PatchID)
+		argPos++
+	}
+
+	if req.MinDuration != nil {
+		whereClause += fmt.Sprintf(" AND c.duration >= %s", utils.SQLPlaceholder(argPos))
+		args = append(args, *req.MinDuration)
+		argPos++
+	}
+
+	if req.MaxDuration != nil {
+		whereClause += fmt.Sprintf(" AND c.duration <= %s", utils.SQLPlaceholder(argPos))
+		args = append(args, *req.MaxDuration)
+		argPos++
+	}
+
+	if req.MinViews != nil {
+		whereClause += fmt.Sprintf(" AND c.view_count >= %s", utils.SQLPlaceholder(argPos))
+		args = append(args, *req.MinViews)
+		argPos++
+	}
+
+	if req.MaxViews != nil {
+		whereClause += fmt.Sprintf(" AND c.view_count <= %s", utils.SQLPlaceholder(argPos))
+		args = append(args, *req.MaxViews)
+		argPos++
+	}
+
+	for _, clause := range buildAccessibilityFilterClauses(req.Captioned, req.HidePhotosensitive) {
+		whereClause += " AND " + clause
+	}
+
 	// Handle tag filters
 	if len(req.Tags) > 0 {
 		whereClause += fmt.Sprintf(` AND c.id IN (
@@ -477,13 +511,16 @@ func (r *SearchRepository) GetSuggestions(ctx context.Context, query string, lim
 	return suggestions, nil
 }
 
-// TrackSearch records a search query for analytics
-func (r *SearchRepository) TrackSearch(ctx context.Context, userID *uuid.UUID, query string, resultCount int) error {
-	_, err := r.db.Exec(ctx, `
-		INSERT INTO search_queries (user_id, query, result_count)
-		VALUES ($1, $2, $3)
-	`, userID, query, resultCount)
-	return err
+// TrackSearch records a search query for analytics and returns its ID.
+// ranker is the search's learning-to-rank arm, empty outside the A/B test.
+func (r *SearchRepository) TrackSearch(ctx context.Context, userID *uuid.UUID, query string, resultCount int, ranker string) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO search_queries (user_id, query, result_count, ranker)
+		VALUES ($1, $2, $3, NULLIF($4, ''))
+		RETURNING id
+	`, userID, query, resultCount, ranker).Scan(&id)
+	return id, err
 }
 
 // GetTrendingSearches returns the most popular search queries in a given time period
@@ -0,0 +1,67 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/testutil"
+)
+
+func TestSearchRepository_SearchExcludesShadowBannedSubmitters(t *testing.T) {
+	// Skip if not in integration test mode
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	pool := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, pool)
+	testutil.TruncateTables(t, pool, "clips", "users")
+
+	clipRepo := NewClipRepository(pool)
+	repo := NewSearchRepository(pool)
+	ctx := context.Background()
+
+	visibleID, bannedID := uuid.New(), uuid.New()
+	insertTestUser(t, pool, visibleID)
+	insertTestUser(t, pool, bannedID)
+	if _, err := pool.Exec(ctx, `UPDATE users SET is_shadow_banned = true WHERE id = $1`, bannedID); err != nil {
+		t.Fatalf("Failed to shadow ban user: %v", err)
+	}
+
+	var visible *models.Clip
+	for _, submitterID := range []uuid.UUID{visibleID, bannedID} {
+		clip := &models.Clip{
+			ID:                uuid.New(),
+			TwitchClipID:      fmt.Sprintf("clutch-%s", uuid.NewString()),
+			TwitchClipURL:     "https://clips.twitch.tv/clutch",
+			EmbedURL:          "https://clips.twitch.tv/embed?clip=clutch",
+			Title:             "Incredible clutch ace",
+			CreatorName:       "creator",
+			BroadcasterName:   "broadcaster",
+			CreatedAt:         time.Now().Add(-time.Hour),
+			ImportedAt:        time.Now(),
+			SubmittedByUserID: &submitterID,
+			SubmittedAt:       testutil.TimePtr(time.Now().Add(-time.Hour)),
+		}
+		if err := clipRepo.Create(ctx, clip); err != nil {
+			t.Fatalf("Failed to create clip: %v", err)
+		}
+		if submitterID == visibleID {
+			visible = clip
+		}
+	}
+
+	response, err := repo.Search(ctx, &models.SearchRequest{Query: "clutch", Type: "clips", Page: 1, Limit: 10})
+	if err != nil {
+		t.Fatalf("Failed to search: %v", err)
+	}
+	if response.Counts.Clips != 1 || len(response.Results.Clips) != 1 || response.Results.Clips[0].ID != visible.ID {
+		t.Errorf("got %d clips (count %d), want only the visible clip", len(response.Results.Clips), response.Counts.Clips)
+	}
+}
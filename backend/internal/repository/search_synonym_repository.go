@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// ErrSearchSynonymNotFound is returned when no synonym has the ID
+var ErrSearchSynonymNotFound = errors.New("search synonym not found")
+
+const searchSynonymColumns = `
+	id, kind, terms, target, description, enabled, created_by, updated_by, created_at, updated_at`
+
+// SearchSynonymRepository handles database operations for search synonyms
+type SearchSynonymRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewSearchSynonymRepository creates a new SearchSynonymRepository
+func NewSearchSynonymRepository(pool *pgxpool.Pool) *SearchSynonymRepository {
+	return &SearchSynonymRepository{pool: pool}
+}
+
+func scanSearchSynonym(row pgx.Row) (*models.SearchSynonym, error) {
+	var synonym models.SearchSynonym
+	err := row.Scan(
+		&synonym.ID, &synonym.Kind, &synonym.Terms, &synonym.Target, &synonym.Description,
+		&synonym.Enabled, &synonym.CreatedBy, &synonym.UpdatedBy, &synonym.CreatedAt, &synonym.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &synonym, nil
+}
+
+func (r *SearchSynonymRepository) list(ctx context.Context, query string, args ...interface{}) ([]models.SearchSynonym, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list search synonyms: %w", err)
+	}
+	defer rows.Close()
+
+	synonyms := []models.SearchSynonym{}
+	for rows.Next() {
+		synonym, err := scanSearchSynonym(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan search synonym: %w", err)
+		}
+		synonyms = append(synonyms, *synonym)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating search synonyms: %w", err)
+	}
+	return synonyms, nil
+}
+
+// List returns every synonym, optionally only those containing term
+func (r *SearchSynonymRepository) List(ctx context.Context, term string) ([]models.SearchSynonym, error) {
+	if term != "" {
+		return r.list(ctx, `SELECT `+searchSynonymColumns+` FROM search_synonyms WHERE $1 = ANY(terms) OR target = $1 ORDER BY created_at`, term)
+	}
+	return r.list(ctx, `SELECT `+searchSynonymColumns+` FROM search_synonyms ORDER BY created_at`)
+}
+
+// ListEnabled returns the synonyms applied to search, oldest first
+func (r *SearchSynonymRepository) ListEnabled(ctx context.Context) ([]models.SearchSynonym, error) {
+	return r.list(ctx, `SELECT `+searchSynonymColumns+` FROM search_synonyms WHERE enabled ORDER BY created_at, id`)
+}
+
+// GetByID returns a synonym by ID
+func (r *SearchSynonymRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.SearchSynonym, error) {
+	synonym, err := scanSearchSynonym(r.pool.QueryRow(ctx, `SELECT `+searchSynonymColumns+` FROM search_synonyms WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSearchSynonymNotFound
+		}
+		return nil, fmt.Errorf("failed to get search synonym: %w", err)
+	}
+	return synonym, nil
+}
+
+// Create stores a new synonym
+func (r *SearchSynonymRepository) Create(ctx context.Context, synonym *models.SearchSynonym) error {
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO search_synonyms (kind, terms, target, description, enabled, created_by, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+		RETURNING id, created_at, updated_at
+	`, synonym.Kind, synonym.Terms, synonym.Target, synonym.Description, synonym.Enabled, synonym.CreatedBy,
+	).Scan(&synonym.ID, &synonym.CreatedAt, &synonym.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create search synonym: %w", err)
+	}
+	synonym.UpdatedBy = synonym.CreatedBy
+	return nil
+}
+
+// Update saves a synonym's terms, target, description and switch
+func (r *SearchSynonymRepository) Update(ctx context.Context, synonym *models.SearchSynonym) error {
+	err := r.pool.QueryRow(ctx, `
+		UPDATE search_synonyms
+		SET terms = $2, target = $3, description = $4, enabled = $5, updated_by = $6, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at
+	`, synonym.ID, synonym.Terms, synonym.Target, synonym.Description, synonym.Enabled, synonym.UpdatedBy,
+	).Scan(&synonym.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrSearchSynonymNotFound
+		}
+		return fmt.Errorf("failed to update search synonym: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a synonym
+func (r *SearchSynonymRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM search_synonyms WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete search synonym: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrSearchSynonymNotFound
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// ErrSessionNotFound is returned when a user has no active session with the given ID
+var ErrSessionNotFound = errors.New("session not found")
+
+// SessionRepository reads and revokes login sessions stored as refresh tokens
+type SessionRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewSessionRepository creates a new session repository
+func NewSessionRepository(db *pgxpool.Pool) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+// ListActive returns the user's sessions that still hold an unrevoked, unexpired refresh token
+func (r *SessionRepository) ListActive(ctx context.Context, userID uuid.UUID) ([]*models.UserSession, error) {
+	query := `
+		SELECT rt.session_id, rt.ip_address, rt.user_agent,
+		       started.created_at, rt.created_at AS last_active_at, rt.expires_at
+		FROM refresh_tokens rt
+		JOIN (
+			SELECT session_id, MIN(created_at) AS created_at
+			FROM refresh_tokens
+			WHERE user_id = $1
+			GROUP BY session_id
+		) started ON started.session_id = rt.session_id
+		WHERE rt.user_id = $1
+		  AND rt.is_revoked = false
+		  AND rt.expires_at > NOW()
+		ORDER BY rt.created_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []*models.UserSession{}
+	seen := make(map[uuid.UUID]bool)
+	for rows.Next() {
+		var session models.UserSession
+		if err := rows.Scan(
+			&session.ID,
+			&session.IPAddress,
+			&session.UserAgent,
+			&session.CreatedAt,
+			&session.LastActiveAt,
+			&session.ExpiresAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+
+		// A rotation race can briefly leave two live tokens in one session; keep the newest
+		if seen[session.ID] {
+			continue
+		}
+		seen[session.ID] = true
+		sessions = append(sessions, &session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// Revoke revokes every live refresh token in one of the user's sessions
+func (r *SessionRepository) Revoke(ctx context.Context, userID, sessionID uuid.UUID) error {
+	query := `
+		UPDATE refresh_tokens
+		SET is_revoked = true, revoked_at = NOW()
+		WHERE user_id = $1 AND session_id = $2 AND is_revoked = false
+	`
+
+	result, err := r.db.Exec(ctx, query, userID, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrSessionNotFound
+	}
+
+	return nil
+}
+
+// RevokeAllExcept revokes all of the user's sessions other than keepSessionID.
+// Pass uuid.Nil to revoke every session.
+func (r *SessionRepository) RevokeAllExcept(ctx context.Context, userID, keepSessionID uuid.UUID) (int, error) {
+	query := `
+		UPDATE refresh_tokens
+		SET is_revoked = true, revoked_at = NOW()
+		WHERE user_id = $1 AND session_id <> $2 AND is_revoked = false
+	`
+
+	result, err := r.db.Exec(ctx, query, userID, keepSessionID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
+
+// GetSessionIDByTokenHash returns the user's session that a refresh token belongs to
+func (r *SessionRepository) GetSessionIDByTokenHash(ctx context.Context, userID uuid.UUID, tokenHash string) (uuid.UUID, error) {
+	query := `
+		SELECT session_id
+		FROM refresh_tokens
+		WHERE user_id = $1 AND token_hash = $2
+	`
+
+	var sessionID uuid.UUID
+	if err := r.db.QueryRow(ctx, query, userID, tokenHash).Scan(&sessionID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, ErrSessionNotFound
+		}
+		return uuid.Nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return sessionID, nil
+}
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+var (
+	// ErrShareLinkNotFound is returned when no share link matches the code
+	ErrShareLinkNotFound = errors.New("share link not found")
+	// ErrShareLinkCodeTaken is returned when a generated code collides with an existing link
+	ErrShareLinkCodeTaken = errors.New("share link code already exists")
+)
+
+// shareLinkCounterColumns maps event types to the counter they increment
+var shareLinkCounterColumns = map[string]string{
+	models.ShareEventClick:  "click_count",
+	models.ShareEventView:   "view_count",
+	models.ShareEventSignup: "signup_count",
+}
+
+// ShareLinkRepository handles database operations for clip share links
+type ShareLinkRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewShareLinkRepository creates a new share link repository
+func NewShareLinkRepository(db *pgxpool.Pool) *ShareLinkRepository {
+	return &ShareLinkRepository{db: db}
+}
+
+// Create inserts a new share link
+func (r *ShareLinkRepository) Create(ctx context.Context, link *models.ShareLink) error {
+	query := `
+		INSERT INTO share_links (id, code, clip_id, user_id, channel, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (code) DO NOTHING
+		RETURNING created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		link.ID,
+		link.Code,
+		link.ClipID,
+		link.UserID,
+		link.Channel,
+		link.ExpiresAt,
+	).Scan(&link.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrShareLinkCodeTaken
+		}
+		return fmt.Errorf("failed to create share link: %w", err)
+	}
+
+	return nil
+}
+
+// GetByCode retrieves a share link by its short code
+func (r *ShareLinkRepository) GetByCode(ctx context.Context, code string) (*models.ShareLink, error) {
+	query := `
+		SELECT id, code, clip_id, user_id, channel, click_count, view_count, signup_count,
+		       is_disabled, disabled_reason, expires_at, created_at, last_clicked_at
+		FROM share_links
+		WHERE code = $1
+	`
+
+	var link models.ShareLink
+	err := r.db.QueryRow(ctx, query, code).Scan(
+		&link.ID,
+		&link.Code,
+		&link.ClipID,
+		&link.UserID,
+		&link.Channel,
+		&link.ClickCount,
+		&link.ViewCount,
+		&link.SignupCount,
+		&link.IsDisabled,
+		&link.DisabledReason,
+		&link.ExpiresAt,
+		&link.CreatedAt,
+		&link.LastClickedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrShareLinkNotFound
+		}
+		return nil, fmt.Errorf("failed to get share link: %w", err)
+	}
+
+	return &link, nil
+}
+
+// FindActive returns an existing usable link for the same sharer, clip and channel
+func (r *ShareLinkRepository) FindActive(ctx context.Context, userID, clipID uuid.UUID, channel string) (*models.ShareLink, error) {
+	query := `
+		SELECT code
+		FROM share_links
+		WHERE user_id = $1 AND clip_id = $2 AND channel = $3
+		  AND is_disabled = false AND expires_at > NOW()
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	var code string
+	if err := r.db.QueryRow(ctx, query, userID, clipID, channel).Scan(&code); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrShareLinkNotFound
+		}
+		return nil, fmt.Errorf("failed to find share link: %w", err)
+	}
+
+	return r.GetByCode(ctx, code)
+}
+
+// CountCreatedSince counts the links a user created after the given time
+func (r *ShareLinkRepository) CountCreatedSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx,
+		`SELECT COUNT(*) FROM share_links WHERE user_id = $1 AND created_at >= $2`,
+		userID, since,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count share links: %w", err)
+	}
+	return count, nil
+}
+
+// RecordEvent stores an attributed event and bumps the matching counter.
+// It returns false when the event was already recorded (repeat sign-up attribution).
+func (r *ShareLinkRepository) RecordEvent(ctx context.Context, linkID uuid.UUID, eventType string, userID *uuid.UUID, ipHash, referrer string) (bool, error) {
+	column, ok := shareLinkCounterColumns[eventType]
+	if !ok {
+		return false, fmt.Errorf("unknown share event type: %s", eventType)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		INSERT INTO share_link_events (share_link_id, event_type, user_id, ip_hash, referrer)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''))
+		ON CONFLICT DO NOTHING
+	`, linkID, eventType, userID, ipHash, referrer)
+	if err != nil {
+		return false, fmt.Errorf("failed to record share event: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	update := fmt.Sprintf(`UPDATE share_links SET %s = %s + 1`, column, column)
+	if eventType == models.ShareEventClick {
+		update += `, last_clicked_at = NOW()`
+	}
+	if _, err := tx.Exec(ctx, update+` WHERE id = $1`, linkID); err != nil {
+		return false, fmt.Errorf("failed to update share link counters: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit share event: %w", err)
+	}
+
+	return true, nil
+}
+
+// Disable blocks a share link from resolving
+func (r *ShareLinkRepository) Disable(ctx context.Context, code, reason string) error {
+	result, err := r.db.Exec(ctx,
+		`UPDATE share_links SET is_disabled = true, disabled_reason = $2 WHERE code = $1`,
+		code, reason,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to disable share link: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrShareLinkNotFound
+	}
+	return nil
+}
+
+// GetClipAnalytics aggregates share performance per channel for a clip
+func (r *ShareLinkRepository) GetClipAnalytics(ctx context.Context, clipID uuid.UUID) (*models.ShareAnalytics, error) {
+	query := `
+		SELECT channel, COUNT(*), COALESCE(SUM(click_count), 0),
+		       COALESCE(SUM(view_count), 0), COALESCE(SUM(signup_count), 0)
+		FROM share_links
+		WHERE clip_id = $1
+		GROUP BY channel
+		ORDER BY COUNT(*) DESC
+	`
+	return r.queryAnalytics(ctx, query, clipID)
+}
+
+// GetCreatorAnalytics aggregates share performance per channel across a creator's clips
+func (r *ShareLinkRepository) GetCreatorAnalytics(ctx context.Context, creatorName string) (*models.ShareAnalytics, error) {
+	query := `
+		SELECT sl.channel, COUNT(*), COALESCE(SUM(sl.click_count), 0),
+		       COALESCE(SUM(sl.view_count), 0), COALESCE(SUM(sl.signup_count), 0)
+		FROM share_links sl
+		JOIN clips c ON c.id = sl.clip_id
+		WHERE LOWER(c.creator_name) = LOWER($1)
+		GROUP BY sl.channel
+		ORDER BY COUNT(*) DESC
+	`
+	return r.queryAnalytics(ctx, query, creatorName)
+}
+
+func (r *ShareLinkRepository) queryAnalytics(ctx context.Context, query string, arg interface{}) (*models.ShareAnalytics, error) {
+	rows, err := r.db.Query(ctx, query, arg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get share analytics: %w", err)
+	}
+	defer rows.Close()
+
+	analytics := &models.ShareAnalytics{Channels: []models.ShareChannelStats{}}
+	for rows.Next() {
+		var stats models.ShareChannelStats
+		if err := rows.Scan(&stats.Channel, &stats.Shares, &stats.Clicks, &stats.Views, &stats.Signups); err != nil {
+			return nil, fmt.Errorf("failed to scan share analytics: %w", err)
+		}
+		analytics.TotalShares += stats.Shares
+		analytics.TotalClicks += stats.Clicks
+		analytics.TotalViews += stats.Views
+		analytics.TotalSignups += stats.Signups
+		analytics.Channels = append(analytics.Channels, stats)
+	}
+
+	return analytics, rows.Err()
+}
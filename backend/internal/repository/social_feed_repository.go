@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// SocialFeedRepository reads the activity shown in users' social feeds
+type SocialFeedRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewSocialFeedRepository creates a new SocialFeedRepository
+func NewSocialFeedRepository(pool *pgxpool.Pool) *SocialFeedRepository {
+	return &SocialFeedRepository{pool: pool}
+}
+
+// ListActivitySources returns the users a viewer follows whose activity the
+// viewer may see, most recently followed first. Users who don't share their
+// activity, have a private profile or are banned are left out, as are users
+// the viewer blocked or who blocked the viewer.
+func (r *SocialFeedRepository) ListActivitySources(ctx context.Context, viewerID uuid.UUID, limit int) ([]uuid.UUID, error) {
+	query := `
+		SELECT uf.following_id
+		FROM user_follows uf
+		JOIN users u ON u.id = uf.following_id
+		LEFT JOIN user_settings us ON us.user_id = uf.following_id
+		WHERE uf.follower_id = $1
+		  AND COALESCE(u.is_banned, false) = false
+		  AND COALESCE(us.share_activity, true) = true
+		  AND COALESCE(us.profile_visibility, 'public') <> 'private'
+		  AND NOT EXISTS (
+			SELECT 1 FROM user_blocks ub
+			WHERE (ub.user_id = $1 AND ub.blocked_user_id = uf.following_id)
+			   OR (ub.user_id = uf.following_id AND ub.blocked_user_id = $1)
+		  )
+		ORDER BY uf.created_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.pool.Query(ctx, query, viewerID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, id)
+	}
+	return userIDs, rows.Err()
+}
+
+// ListRecentActivity returns the clips users submitted, the public feeds they
+// created and the badges they earned since a time, newest first. Each user's
+// activity is capped at perUser items.
+func (r *SocialFeedRepository) ListRecentActivity(ctx context.Context, userIDs []uuid.UUID, since time.Time, perUser int) ([]models.SocialActivity, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		WITH activity AS (
+			SELECT 'clip_submitted' AS type, c.id, c.submitted_by_user_id AS actor_id, c.submitted_at AS occurred_at,
+				c.title, c.broadcaster_name, c.game_name, c.thumbnail_url,
+				NULL::text AS feed_name, NULL::text AS feed_description, NULL::text AS feed_icon,
+				NULL::text AS badge_id
+			FROM clips c
+			WHERE c.submitted_by_user_id = ANY($1)
+			  AND c.submitted_at >= $2
+			  AND c.is_removed = false
+			  AND c.is_hidden = false
+			UNION ALL
+			SELECT 'feed_created', f.id, f.user_id, f.created_at,
+				NULL, NULL, NULL, NULL,
+				f.name, f.description, f.icon,
+				NULL
+			FROM feeds f
+			WHERE f.user_id = ANY($1)
+			  AND f.created_at >= $2
+			  AND f.is_public = true
+			UNION ALL
+			SELECT 'badge_earned', ub.id, ub.user_id, ub.awarded_at,
+				NULL, NULL, NULL, NULL,
+				NULL, NULL, NULL,
+				ub.badge_id
+			FROM user_badges ub
+			WHERE ub.user_id = ANY($1)
+			  AND ub.awarded_at >= $2
+		),
+		ranked AS (
+			SELECT a.*, ROW_NUMBER() OVER (PARTITION BY a.actor_id ORDER BY a.occurred_at DESC, a.id DESC) AS rn
+			FROM activity a
+		)
+		SELECT ra.type, ra.id, ra.occurred_at,
+			u.id, u.username, COALESCE(u.display_name, u.username), u.avatar_url,
+			ra.title, ra.broadcaster_name, ra.game_name, ra.thumbnail_url,
+			ra.feed_name, ra.feed_description, ra.feed_icon,
+			ra.badge_id
+		FROM ranked ra
+		JOIN users u ON u.id = ra.actor_id
+		WHERE ra.rn <= $3
+		ORDER BY ra.occurred_at DESC, ra.id DESC
+	`
+
+	rows, err := r.pool.Query(ctx, query, userIDs, since, perUser)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var activity []models.SocialActivity
+	for rows.Next() {
+		var a models.SocialActivity
+		var title, broadcasterName, feedName, badgeID *string
+		var gameName, thumbnailURL, feedDescription, feedIcon *string
+		if err := rows.Scan(
+			&a.Type, &a.ID, &a.OccurredAt,
+			&a.Actor.ID, &a.Actor.Username, &a.Actor.DisplayName, &a.Actor.AvatarURL,
+			&title, &broadcasterName, &gameName, &thumbnailURL,
+			&feedName, &feedDescription, &feedIcon,
+			&badgeID,
+		); err != nil {
+			return nil, err
+		}
+
+		switch a.Type {
+		case models.SocialActivityClipSubmitted:
+			a.Clip = &models.SocialActivityClip{
+				ID:           a.ID,
+				GameName:     gameName,
+				ThumbnailURL: thumbnailURL,
+			}
+			if title != nil {
+				a.Clip.Title = *title
+			}
+			if broadcasterName != nil {
+				a.Clip.BroadcasterName = *broadcasterName
+			}
+		case models.SocialActivityFeedCreated:
+			a.Feed = &models.SocialActivityFeed{
+				ID:          a.ID,
+				Description: feedDescription,
+				Icon:        feedIcon,
+			}
+			if feedName != nil {
+				a.Feed.Name = *feedName
+			}
+		case models.SocialActivityBadgeEarned:
+			if badgeID != nil {
+				a.Badge = &models.Badge{ID: *badgeID}
+			}
+		}
+		activity = append(activity, a)
+	}
+	return activity, rows.Err()
+}
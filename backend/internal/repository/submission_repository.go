@@ -2,16 +2,27 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/subculture-collective/clipper/internal/models"
 	"github.com/subculture-collective/clipper/internal/utils"
 )
 
+var (
+	// ErrSubmissionNotFound is returned when a submission doesn't exist
+	ErrSubmissionNotFound = errors.New("submission not found")
+	// ErrSubmissionNotPending is returned when reviewing a submission that is no longer pending
+	ErrSubmissionNotPending = errors.New("submission is not pending")
+	// ErrSubmissionClipExists is returned when approving a submission whose clip is already on the site
+	ErrSubmissionClipExists = errors.New("clip already exists")
+)
+
 // SubmissionRepository handles database operations for clip submissions
 type SubmissionRepository struct {
 	db *pgxpool.Pool
@@ -107,7 +118,7 @@ func (r *SubmissionRepository) GetByID(ctx context.Context, id uuid.UUID) (*mode
 	)
 
 	if err == pgx.ErrNoRows {
-		return nil, fmt.Errorf("submission not found")
+		return nil, ErrSubmissionNotFound
 	}
 	if err != nil {
 		return nil, err
@@ -395,6 +406,17 @@ func (r *SubmissionRepository) UpdateStatus(ctx context.Context, id uuid.UUID, s
 	return err
 }
 
+// SetStatus updates the status of a submission without recording a review
+func (r *SubmissionRepository) SetStatus(ctx context.Context, id uuid.UUID, status string) error {
+	query := `
+		UPDATE clip_submissions
+		SET status = $1, updated_at = $2
+		WHERE id = $3`
+
+	_, err := r.db.Exec(ctx, query, status, time.Now(), id)
+	return err
+}
+
 // UpdateClipID updates the clip_id for a submission
 func (r *SubmissionRepository) UpdateClipID(ctx context.Context, submissionID uuid.UUID, clipID uuid.UUID) error {
 	query := `
@@ -502,6 +524,62 @@ func (r *SubmissionRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([
 	return submissions, rows.Err()
 }
 
+// ApproveWithClip creates the submission's clip and marks the submission
+// approved in one transaction. It returns ErrSubmissionNotPending if the
+// submission was reviewed in the meantime, and nothing is written.
+func (r *SubmissionRepository) ApproveWithClip(ctx context.Context, submissionID, reviewedBy uuid.UUID, clip *models.Clip) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := insertClip(ctx, tx, clip); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrSubmissionClipExists
+		}
+		return fmt.Errorf("failed to create clip: %w", err)
+	}
+
+	now := time.Now()
+	result, err := tx.Exec(ctx, `
+		UPDATE clip_submissions
+		SET status = 'approved', reviewed_by = $1, reviewed_at = $2, rejection_reason = NULL,
+			clip_id = $3, updated_at = $2
+		WHERE id = $4 AND status = 'pending'`,
+		reviewedBy, now, clip.ID, submissionID)
+	if err != nil {
+		return fmt.Errorf("failed to update submission status: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrSubmissionNotPending
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit approval: %w", err)
+	}
+	return nil
+}
+
+// RejectPending marks a pending submission rejected. It returns
+// ErrSubmissionNotPending if the submission was reviewed in the meantime.
+func (r *SubmissionRepository) RejectPending(ctx context.Context, id uuid.UUID, reviewedBy uuid.UUID, rejectionReason string) error {
+	query := `
+		UPDATE clip_submissions
+		SET status = 'rejected', reviewed_by = $1, reviewed_at = $2, rejection_reason = $3, updated_at = $2
+		WHERE id = $4 AND status = 'pending'`
+
+	result, err := r.db.Exec(ctx, query, reviewedBy, time.Now(), rejectionReason, id)
+	if err != nil {
+		return fmt.Errorf("failed to update submission status: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrSubmissionNotPending
+	}
+	return nil
+}
+
 // BulkUpdateStatus updates the status of multiple submissions
 func (r *SubmissionRepository) BulkUpdateStatus(ctx context.Context, ids []uuid.UUID, status string, reviewedBy uuid.UUID, rejectionReason *string) error {
 	query := `
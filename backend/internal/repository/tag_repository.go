@@ -397,3 +397,103 @@ func (r *TagRepository) GetClipTagCount(ctx context.Context, clipID uuid.UUID) (
 	}
 	return count, nil
 }
+
+// RebuildCooccurrences replaces the tag co-occurrence graph. Tag pairs on at
+// least minClips visible clips are scored by the Jaccard similarity of their
+// clips, and each tag keeps its perTag best related tags.
+func (r *TagRepository) RebuildCooccurrences(ctx context.Context, minClips, perTag int) (int64, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM tag_cooccurrences`); err != nil {
+		return 0, fmt.Errorf("failed to clear tag co-occurrences: %w", err)
+	}
+
+	result, err := tx.Exec(ctx, `
+		WITH clip_tag AS (
+			SELECT ct.clip_id, ct.tag_id
+			FROM clip_tags ct
+			INNER JOIN clips c ON c.id = ct.clip_id
+			WHERE c.is_removed = false AND c.is_hidden = false
+		),
+		tag_counts AS (
+			SELECT tag_id, COUNT(*) AS clips
+			FROM clip_tag
+			GROUP BY tag_id
+		),
+		pairs AS (
+			SELECT a.tag_id, b.tag_id AS related_tag_id, COUNT(*) AS clip_count
+			FROM clip_tag a
+			INNER JOIN clip_tag b ON b.clip_id = a.clip_id AND b.tag_id <> a.tag_id
+			GROUP BY a.tag_id, b.tag_id
+			HAVING COUNT(*) >= $1
+		),
+		scored AS (
+			SELECT p.tag_id, p.related_tag_id, p.clip_count,
+				p.clip_count::float8 / (ta.clips + tb.clips - p.clip_count) AS score
+			FROM pairs p
+			INNER JOIN tag_counts ta ON ta.tag_id = p.tag_id
+			INNER JOIN tag_counts tb ON tb.tag_id = p.related_tag_id
+		),
+		ranked AS (
+			SELECT *, ROW_NUMBER() OVER (PARTITION BY tag_id ORDER BY score DESC, clip_count DESC) AS rank
+			FROM scored
+		)
+		INSERT INTO tag_cooccurrences (tag_id, related_tag_id, clip_count, score, computed_at)
+		SELECT tag_id, related_tag_id, clip_count, score, NOW()
+		FROM ranked
+		WHERE rank <= $2
+	`, minClips, perTag)
+	if err != nil {
+		return 0, fmt.Errorf("failed to build tag co-occurrences: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit tag co-occurrences: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// ListRelatedTags returns the tags most often found together with a tag,
+// strongest first
+func (r *TagRepository) ListRelatedTags(ctx context.Context, tagID uuid.UUID, limit int) ([]models.RelatedTag, error) {
+	query := `
+		SELECT t.id, t.name, t.slug, t.description, t.color, t.usage_count, t.created_at,
+			tc.clip_count, tc.score
+		FROM tag_cooccurrences tc
+		INNER JOIN tags t ON t.id = tc.related_tag_id
+		WHERE tc.tag_id = $1
+		ORDER BY tc.score DESC, tc.clip_count DESC
+		LIMIT $2
+	`
+
+	rows, err := r.pool.Query(ctx, query, tagID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list related tags: %w", err)
+	}
+	defer rows.Close()
+
+	related := []models.RelatedTag{}
+	for rows.Next() {
+		var tag models.RelatedTag
+		err := rows.Scan(
+			&tag.ID, &tag.Name, &tag.Slug, &tag.Description,
+			&tag.Color, &tag.UsageCount, &tag.CreatedAt,
+			&tag.ClipCount, &tag.Score,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan related tag: %w", err)
+		}
+		related = append(related, tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating related tags: %w", err)
+	}
+
+	return related, nil
+}
@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// ErrUploadNotFound is returned when an upload does not exist
+var ErrUploadNotFound = errors.New("upload not found")
+
+const uploadColumns = `
+	id, user_id, context, object_key, filename, declared_content_type, content_type,
+	size_bytes, status, rejection_reason, attached_type, attached_id, expires_at,
+	created_at, updated_at`
+
+// UploadRepository handles database operations for presigned file uploads
+type UploadRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewUploadRepository creates a new UploadRepository
+func NewUploadRepository(pool *pgxpool.Pool) *UploadRepository {
+	return &UploadRepository{pool: pool}
+}
+
+func scanUpload(row pgx.Row) (*models.Upload, error) {
+	var upload models.Upload
+	err := row.Scan(
+		&upload.ID, &upload.UserID, &upload.Context, &upload.ObjectKey, &upload.Filename,
+		&upload.DeclaredContentType, &upload.ContentType, &upload.SizeBytes, &upload.Status,
+		&upload.RejectionReason, &upload.AttachedType, &upload.AttachedID, &upload.ExpiresAt,
+		&upload.CreatedAt, &upload.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &upload, nil
+}
+
+func (r *UploadRepository) getOne(ctx context.Context, query string, args ...interface{}) (*models.Upload, error) {
+	upload, err := scanUpload(r.pool.QueryRow(ctx, query, args...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrUploadNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upload: %w", err)
+	}
+	return upload, nil
+}
+
+// Create inserts a pending upload
+func (r *UploadRepository) Create(ctx context.Context, upload *models.Upload) error {
+	query := `
+		INSERT INTO uploads (id, user_id, context, object_key, filename, declared_content_type, size_bytes, status, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING created_at, updated_at
+	`
+	err := r.pool.QueryRow(ctx, query,
+		upload.ID, upload.UserID, upload.Context, upload.ObjectKey, upload.Filename,
+		upload.DeclaredContentType, upload.SizeBytes, upload.Status, upload.ExpiresAt,
+	).Scan(&upload.CreatedAt, &upload.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create upload: %w", err)
+	}
+	return nil
+}
+
+// GetByID returns an upload by ID
+func (r *UploadRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Upload, error) {
+	return r.getOne(ctx, `SELECT `+uploadColumns+` FROM uploads WHERE id = $1`, id)
+}
+
+// GetByObjectKey returns the upload stored under an object key
+func (r *UploadRepository) GetByObjectKey(ctx context.Context, objectKey string) (*models.Upload, error) {
+	return r.getOne(ctx, `SELECT `+uploadColumns+` FROM uploads WHERE object_key = $1`, objectKey)
+}
+
+// MarkReady records the checked type and size of a pending upload
+func (r *UploadRepository) MarkReady(ctx context.Context, id uuid.UUID, contentType string, sizeBytes int64) error {
+	query := `
+		UPDATE uploads SET status = $2, content_type = $3, size_bytes = $4
+		WHERE id = $1 AND status = $5
+	`
+	tag, err := r.pool.Exec(ctx, query, id, models.UploadStatusReady, contentType, sizeBytes, models.UploadStatusPending)
+	if err != nil {
+		return fmt.Errorf("failed to mark upload ready: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrUploadNotFound
+	}
+	return nil
+}
+
+// MarkRejected records why a pending upload was rejected
+func (r *UploadRepository) MarkRejected(ctx context.Context, id uuid.UUID, reason string) error {
+	query := `
+		UPDATE uploads SET status = $2, rejection_reason = $3
+		WHERE id = $1 AND status = $4
+	`
+	tag, err := r.pool.Exec(ctx, query, id, models.UploadStatusRejected, reason, models.UploadStatusPending)
+	if err != nil {
+		return fmt.Errorf("failed to mark upload rejected: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrUploadNotFound
+	}
+	return nil
+}
+
+// Attach marks a ready upload as used by the given target. With replace,
+// uploads of the same context attached to the target before are detached,
+// so the cleanup job deletes them.
+func (r *UploadRepository) Attach(ctx context.Context, id uuid.UUID, attachedType, attachedID string, replace bool) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if replace {
+		query := `
+			UPDATE uploads SET status = $1, attached_type = NULL, attached_id = NULL
+			WHERE status = $2 AND attached_type = $3 AND attached_id = $4 AND id <> $5
+			  AND context = (SELECT context FROM uploads WHERE id = $5)
+		`
+		if _, err := tx.Exec(ctx, query, models.UploadStatusDetached, models.UploadStatusAttached, attachedType, attachedID, id); err != nil {
+			return fmt.Errorf("failed to detach replaced uploads: %w", err)
+		}
+	}
+
+	query := `
+		UPDATE uploads SET status = $2, attached_type = $3, attached_id = $4
+		WHERE id = $1 AND status IN ($5, $2)
+	`
+	tag, err := tx.Exec(ctx, query, id, models.UploadStatusAttached, attachedType, attachedID, models.UploadStatusReady)
+	if err != nil {
+		return fmt.Errorf("failed to attach upload: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrUploadNotFound
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Detach marks an upload attached to the target as no longer used
+func (r *UploadRepository) Detach(ctx context.Context, id uuid.UUID, attachedType, attachedID string) error {
+	query := `
+		UPDATE uploads SET status = $2, attached_type = NULL, attached_id = NULL
+		WHERE id = $1 AND status = $3 AND attached_type = $4 AND attached_id = $5
+	`
+	tag, err := r.pool.Exec(ctx, query, id, models.UploadStatusDetached, models.UploadStatusAttached, attachedType, attachedID)
+	if err != nil {
+		return fmt.Errorf("failed to detach upload: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrUploadNotFound
+	}
+	return nil
+}
+
+// ListAttached returns the uploads attached to a target, oldest first
+func (r *UploadRepository) ListAttached(ctx context.Context, attachedType, attachedID string) ([]models.Upload, error) {
+	query := `SELECT ` + uploadColumns + `
+		FROM uploads
+		WHERE status = $1 AND attached_type = $2 AND attached_id = $3
+		ORDER BY created_at ASC
+	`
+	return r.list(ctx, query, models.UploadStatusAttached, attachedType, attachedID)
+}
+
+// ListOrphans returns uploads nothing uses anymore: pending ones past their
+// expiry, ready and rejected ones last changed before the cutoff, and
+// detached ones. Oldest first.
+func (r *UploadRepository) ListOrphans(ctx context.Context, cutoff time.Time, limit int) ([]models.Upload, error) {
+	query := `SELECT ` + uploadColumns + `
+		FROM uploads
+		WHERE (status = $1 AND expires_at < NOW())
+		   OR (status IN ($2, $3) AND updated_at < $4)
+		   OR status = $5
+		ORDER BY updated_at ASC
+		LIMIT $6
+	`
+	return r.list(ctx, query,
+		models.UploadStatusPending, models.UploadStatusReady, models.UploadStatusRejected,
+		cutoff, models.UploadStatusDetached, limit,
+	)
+}
+
+func (r *UploadRepository) list(ctx context.Context, query string, args ...interface{}) ([]models.Upload, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list uploads: %w", err)
+	}
+	defer rows.Close()
+
+	uploads := []models.Upload{}
+	for rows.Next() {
+		upload, err := scanUpload(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan upload: %w", err)
+		}
+		uploads = append(uploads, *upload)
+	}
+	return uploads, rows.Err()
+}
+
+// Delete removes an upload
+func (r *UploadRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, err := r.pool.Exec(ctx, `DELETE FROM uploads WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete upload: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// ErrUserBanNotFound is returned when a user has no active ban
+var ErrUserBanNotFound = errors.New("user ban not found")
+
+const userBanColumns = `
+	id, user_id, reason, banned_by, offense_number, starts_at, expires_at,
+	lifted_at, lifted_by, lift_type, lift_reason, created_at`
+
+// UserBanRepository handles database operations for site-wide user bans
+type UserBanRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewUserBanRepository creates a new UserBanRepository
+func NewUserBanRepository(pool *pgxpool.Pool) *UserBanRepository {
+	return &UserBanRepository{pool: pool}
+}
+
+// Create bans a user, replacing any active ban, and marks the user as banned
+func (r *UserBanRepository) Create(ctx context.Context, ban *models.UserBan) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	result, err := tx.Exec(ctx, `UPDATE users SET is_banned = true, updated_at = NOW() WHERE id = $1`, ban.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to ban user: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE user_bans
+		SET lifted_at = NOW(), lifted_by = $2, lift_type = 'superseded'
+		WHERE user_id = $1 AND lifted_at IS NULL
+	`, ban.UserID, ban.BannedBy)
+	if err != nil {
+		return fmt.Errorf("failed to supersede active ban: %w", err)
+	}
+
+	query := `
+		INSERT INTO user_bans (user_id, reason, banned_by, offense_number, starts_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+	err = tx.QueryRow(ctx, query, ban.UserID, ban.Reason, ban.BannedBy, ban.OffenseNumber, ban.StartsAt, ban.ExpiresAt).
+		Scan(&ban.ID, &ban.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create ban: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit ban: %w", err)
+	}
+	return nil
+}
+
+// Lift ends a user's active ban early and marks the user as not banned. The
+// returned ban is nil for users banned before ban records were kept.
+func (r *UserBanRepository) Lift(ctx context.Context, userID uuid.UUID, liftedBy *uuid.UUID, reason *string) (*models.UserBan, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	result, err := tx.Exec(ctx, `UPDATE users SET is_banned = false, updated_at = NOW() WHERE id = $1`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unban user: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return nil, ErrUserNotFound
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE user_bans
+		SET lifted_at = NOW(), lifted_by = $2, lift_type = 'lifted', lift_reason = $3
+		WHERE user_id = $1 AND lifted_at IS NULL
+		RETURNING %s
+	`, userBanColumns)
+	ban, err := scanUserBan(tx.QueryRow(ctx, query, userID, liftedBy, reason))
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to lift ban: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit unban: %w", err)
+	}
+	return ban, nil
+}
+
+// LiftExpired lifts up to limit bans that have reached their expiry and marks
+// their users as not banned. Bans claimed by another instance are skipped.
+func (r *UserBanRepository) LiftExpired(ctx context.Context, limit int) ([]models.UserBan, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := fmt.Sprintf(`
+		UPDATE user_bans
+		SET lifted_at = NOW(), lift_type = 'expired'
+		WHERE id IN (
+			SELECT id FROM user_bans
+			WHERE lifted_at IS NULL AND expires_at <= NOW()
+			ORDER BY expires_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING %s
+	`, userBanColumns)
+
+	rows, err := tx.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lift expired bans: %w", err)
+	}
+	bans, err := collectUserBans(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(bans) == 0 {
+		return bans, nil
+	}
+
+	userIDs := make([]uuid.UUID, len(bans))
+	for i, ban := range bans {
+		userIDs[i] = ban.UserID
+	}
+	_, err = tx.Exec(ctx, `UPDATE users SET is_banned = false, updated_at = NOW() WHERE id = ANY($1)`, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reinstate users: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit expired bans: %w", err)
+	}
+	return bans, nil
+}
+
+// GetActive returns a user's active ban
+func (r *UserBanRepository) GetActive(ctx context.Context, userID uuid.UUID) (*models.UserBan, error) {
+	query := fmt.Sprintf(`SELECT %s FROM user_bans WHERE user_id = $1 AND lifted_at IS NULL`, userBanColumns)
+
+	ban, err := scanUserBan(r.pool.QueryRow(ctx, query, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserBanNotFound
+		}
+		return nil, fmt.Errorf("failed to get active ban: %w", err)
+	}
+	return ban, nil
+}
+
+// ListForUser returns a user's bans, newest first
+func (r *UserBanRepository) ListForUser(ctx context.Context, userID uuid.UUID, limit int) ([]models.UserBan, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM user_bans
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`, userBanColumns)
+
+	rows, err := r.pool.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bans: %w", err)
+	}
+	return collectUserBans(rows)
+}
+
+// CountOffensesSince counts a user's bans created since the given time that
+// count towards the ban ladder. Bans an admin lifted early are not counted.
+func (r *UserBanRepository) CountOffensesSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*) FROM user_bans
+		WHERE user_id = $1 AND created_at >= $2 AND lift_type IS DISTINCT FROM 'lifted'
+	`
+
+	var count int
+	if err := r.pool.QueryRow(ctx, query, userID, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count bans: %w", err)
+	}
+	return count, nil
+}
+
+func scanUserBan(row pgx.Row) (*models.UserBan, error) {
+	var ban models.UserBan
+	err := row.Scan(
+		&ban.ID,
+		&ban.UserID,
+		&ban.Reason,
+		&ban.BannedBy,
+		&ban.OffenseNumber,
+		&ban.StartsAt,
+		&ban.ExpiresAt,
+		&ban.LiftedAt,
+		&ban.LiftedBy,
+		&ban.LiftType,
+		&ban.LiftReason,
+		&ban.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &ban, nil
+}
+
+func collectUserBans(rows pgx.Rows) ([]models.UserBan, error) {
+	defer rows.Close()
+
+	bans := []models.UserBan{}
+	for rows.Next() {
+		ban, err := scanUserBan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ban: %w", err)
+		}
+		bans = append(bans, *ban)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate bans: %w", err)
+	}
+	return bans, nil
+}
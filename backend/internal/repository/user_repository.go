@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,6 +21,8 @@ var (
 	ErrUserAlreadyExists = errors.New("user already exists")
 	// ErrBlockNotFound is returned when a block relationship is not found
 	ErrBlockNotFound = errors.New("block not found")
+	// ErrRefreshTokenAlreadyUsed is returned when a refresh token was revoked or rotated before it could be rotated
+	ErrRefreshTokenAlreadyUsed = errors.New("refresh token has already been used")
 )
 
 // UserRepository handles user database operations
@@ -44,11 +47,16 @@ func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
 		user.AccountStatus = "active"
 	}
 
+	// Set default auth_provider if not specified
+	if user.AuthProvider == "" {
+		user.AuthProvider = models.AuthProviderTwitch
+	}
+
 	query := `
 		INSERT INTO users (
 			id, twitch_id, username, display_name, email,
-			avatar_url, bio, role, account_type, account_status, last_login_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			avatar_url, bio, role, account_type, account_status, last_login_at, auth_provider
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 		RETURNING created_at, updated_at
 	`
 
@@ -56,6 +64,7 @@ func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
 		ctx, query,
 		user.ID, user.TwitchID, user.Username, user.DisplayName, user.Email,
 		user.AvatarURL, user.Bio, user.Role, user.AccountType, user.AccountStatus, user.LastLoginAt,
+		user.AuthProvider,
 	).Scan(&user.CreatedAt, &user.UpdatedAt)
 
 	if err != nil {
@@ -73,7 +82,7 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Use
 			karma_points, role, account_type, is_banned, created_at, updated_at, last_login_at,
 			COALESCE(moderator_scope, '') AS moderator_scope,
 			COALESCE(moderation_channels, '{}'::uuid[]) AS moderation_channels,
-			moderation_started_at
+			moderation_started_at, auth_provider, admin_roles
 		FROM users
 		WHERE id = $1
 	`
@@ -83,7 +92,8 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Use
 		&user.ID, &user.TwitchID, &user.Username, &user.DisplayName, &user.Email,
 		&user.AvatarURL, &user.Bio, &user.KarmaPoints, &user.Role, &user.AccountType, &user.IsBanned,
 		&user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt,
-		&user.ModeratorScope, &user.ModerationChannels, &user.ModerationStartedAt,
+		&user.ModeratorScope, &user.ModerationChannels, &user.ModerationStartedAt, &user.AuthProvider,
+		&user.AdminRoles,
 	)
 
 	if err != nil {
@@ -203,11 +213,13 @@ func (r *UserRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*mode
 	return users, nil
 }
 
-// Update updates an existing user
+// Update updates an existing user. An uploaded avatar is kept; use
+// UpdateAvatar to change it.
 func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
 	query := `
 		UPDATE users
-		SET username = $2, display_name = $3, email = $4, avatar_url = $5,
+		SET username = $2, display_name = $3, email = $4,
+		    avatar_url = CASE WHEN avatar_upload_id IS NULL THEN $5 ELSE avatar_url END,
 		    bio = $6, last_login_at = $7, updated_at = NOW()
 		WHERE id = $1
 	`
@@ -249,6 +261,26 @@ func (r *UserRepository) UpdateProfile(ctx context.Context, userID uuid.UUID, di
 	return nil
 }
 
+// UpdateAvatar sets a user's avatar to an upload
+func (r *UserRepository) UpdateAvatar(ctx context.Context, userID, uploadID uuid.UUID, avatarURL string) error {
+	query := `
+		UPDATE users
+		SET avatar_url = $2, avatar_upload_id = $3, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query, userID, avatarURL, uploadID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
 // UpdateLastLogin updates the user's last login timestamp
 func (r *UserRepository) UpdateLastLogin(ctx context.Context, userID uuid.UUID) error {
 	query := `
@@ -347,17 +379,37 @@ func NewRefreshTokenRepository(db *pgxpool.Pool) *RefreshTokenRepository {
 	return &RefreshTokenRepository{db: db}
 }
 
-// Create creates a new refresh token
-func (r *RefreshTokenRepository) Create(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) error {
+// Create creates a new refresh token belonging to a session
+func (r *RefreshTokenRepository) Create(ctx context.Context, userID, sessionID uuid.UUID, tokenHash string, expiresAt time.Time, client models.SessionClient) error {
 	query := `
-		INSERT INTO refresh_tokens (user_id, token_hash, expires_at)
-		VALUES ($1, $2, $3)
+		INSERT INTO refresh_tokens (user_id, session_id, token_hash, expires_at, ip_address, user_agent)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''))
 	`
 
-	_, err := r.db.Exec(ctx, query, userID, tokenHash, expiresAt)
+	_, err := r.db.Exec(ctx, query, userID, sessionID, tokenHash, expiresAt, client.IPAddress, client.UserAgent)
 	return err
 }
 
+// GetSessionID returns the session a refresh token belongs to
+func (r *RefreshTokenRepository) GetSessionID(ctx context.Context, tokenHash string) (uuid.UUID, error) {
+	query := `
+		SELECT session_id
+		FROM refresh_tokens
+		WHERE token_hash = $1
+	`
+
+	var sessionID uuid.UUID
+	err := r.db.QueryRow(ctx, query, tokenHash).Scan(&sessionID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, errors.New("refresh token not found")
+		}
+		return uuid.Nil, err
+	}
+
+	return sessionID, nil
+}
+
 // GetByHash retrieves a refresh token by its hash
 func (r *RefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (userID uuid.UUID, expiresAt time.Time, isRevoked bool, err error) {
 	query := `
@@ -389,6 +441,109 @@ func (r *RefreshTokenRepository) Revoke(ctx context.Context, tokenHash string) e
 	return err
 }
 
+// Rotate atomically consumes a live refresh token and stores its replacement in
+// the same session. Returns ErrRefreshTokenAlreadyUsed if the old token was
+// already revoked or rotated, e.g. by a concurrent refresh with the same token.
+func (r *RefreshTokenRepository) Rotate(ctx context.Context, oldTokenHash string, userID, sessionID uuid.UUID, newTokenHash string, expiresAt time.Time, client models.SessionClient) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	result, err := tx.Exec(ctx, `
+		UPDATE refresh_tokens
+		SET is_revoked = true, revoked_at = NOW(), rotated_at = NOW()
+		WHERE token_hash = $1 AND is_revoked = false
+	`, oldTokenHash)
+	if err != nil {
+		return fmt.Errorf("failed to consume refresh token: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrRefreshTokenAlreadyUsed
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO refresh_tokens (user_id, session_id, token_hash, expires_at, ip_address, user_agent, mfa_verified_at)
+		SELECT $1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), mfa_verified_at
+		FROM refresh_tokens
+		WHERE token_hash = $7
+	`, userID, sessionID, newTokenHash, expiresAt, client.IPAddress, client.UserAgent, oldTokenHash)
+	if err != nil {
+		return fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// IsMFAVerified reports whether the session a refresh token belongs to has passed a second factor
+func (r *RefreshTokenRepository) IsMFAVerified(ctx context.Context, tokenHash string) (bool, error) {
+	query := `
+		SELECT mfa_verified_at IS NOT NULL
+		FROM refresh_tokens
+		WHERE token_hash = $1
+	`
+
+	var verified bool
+	err := r.db.QueryRow(ctx, query, tokenHash).Scan(&verified)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, errors.New("refresh token not found")
+		}
+		return false, err
+	}
+
+	return verified, nil
+}
+
+// MarkSessionMFAVerified records that a session passed its second factor
+func (r *RefreshTokenRepository) MarkSessionMFAVerified(ctx context.Context, sessionID uuid.UUID) error {
+	query := `
+		UPDATE refresh_tokens
+		SET mfa_verified_at = NOW()
+		WHERE session_id = $1 AND is_revoked = false
+	`
+
+	_, err := r.db.Exec(ctx, query, sessionID)
+	return err
+}
+
+// IsRotated reports whether a refresh token was consumed by rotation rather than revoked by logout
+func (r *RefreshTokenRepository) IsRotated(ctx context.Context, tokenHash string) (bool, error) {
+	query := `
+		SELECT rotated_at IS NOT NULL
+		FROM refresh_tokens
+		WHERE token_hash = $1
+	`
+
+	var rotated bool
+	err := r.db.QueryRow(ctx, query, tokenHash).Scan(&rotated)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, errors.New("refresh token not found")
+		}
+		return false, err
+	}
+
+	return rotated, nil
+}
+
+// RevokeSession revokes every live refresh token in a session (a rotation family)
+func (r *RefreshTokenRepository) RevokeSession(ctx context.Context, sessionID uuid.UUID) (int64, error) {
+	query := `
+		UPDATE refresh_tokens
+		SET is_revoked = true, revoked_at = NOW()
+		WHERE session_id = $1 AND is_revoked = false
+	`
+
+	result, err := r.db.Exec(ctx, query, sessionID)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
+
 // RevokeAllForUser revokes all refresh tokens for a user
 func (r *RefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID uuid.UUID) error {
 	query := `
@@ -451,7 +606,7 @@ func NewUserSettingsRepository(db *pgxpool.Pool) *UserSettingsRepository {
 // GetByUserID retrieves user settings by user ID
 func (r *UserSettingsRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*models.UserSettings, error) {
 	query := `
-		SELECT user_id, profile_visibility, show_karma_publicly, created_at, updated_at
+		SELECT user_id, profile_visibility, show_karma_publicly, personalized_search, show_profile_views, share_activity, created_at, updated_at
 		FROM user_settings
 		WHERE user_id = $1
 	`
@@ -459,7 +614,7 @@ func (r *UserSettingsRepository) GetByUserID(ctx context.Context, userID uuid.UU
 	var settings models.UserSettings
 	err := r.db.QueryRow(ctx, query, userID).Scan(
 		&settings.UserID, &settings.ProfileVisibility, &settings.ShowKarmaPublicly,
-		&settings.CreatedAt, &settings.UpdatedAt,
+		&settings.PersonalizedSearch, &settings.ShowProfileViews, &settings.ShareActivity, &settings.CreatedAt, &settings.UpdatedAt,
 	)
 
 	if err != nil {
@@ -472,26 +627,45 @@ func (r *UserSettingsRepository) GetByUserID(ctx context.Context, userID uuid.UU
 	return &settings, nil
 }
 
-// Update updates user settings
-func (r *UserSettingsRepository) Update(ctx context.Context, userID uuid.UUID, profileVisibility *string, showKarmaPublicly *bool) error {
-	// Build query based on which fields are provided
-	var query string
-	var args []interface{}
-
-	if profileVisibility != nil && showKarmaPublicly != nil {
-		query = `UPDATE user_settings SET profile_visibility = $2, show_karma_publicly = $3, updated_at = NOW() WHERE user_id = $1`
-		args = []interface{}{userID, *profileVisibility, *showKarmaPublicly}
-	} else if profileVisibility != nil {
-		query = `UPDATE user_settings SET profile_visibility = $2, updated_at = NOW() WHERE user_id = $1`
-		args = []interface{}{userID, *profileVisibility}
-	} else if showKarmaPublicly != nil {
-		query = `UPDATE user_settings SET show_karma_publicly = $2, updated_at = NOW() WHERE user_id = $1`
-		args = []interface{}{userID, *showKarmaPublicly}
-	} else {
+// Update updates the user settings that are provided
+func (r *UserSettingsRepository) Update(ctx context.Context, userID uuid.UUID, profileVisibility *string, showKarmaPublicly *bool, personalizedSearch *bool, showProfileViews *bool, shareActivity *bool) error {
+	if profileVisibility == nil && showKarmaPublicly == nil && personalizedSearch == nil && showProfileViews == nil && shareActivity == nil {
 		// Nothing to update
 		return nil
 	}
 
+	// Build query based on which fields are provided
+	setClauses := []string{"updated_at = NOW()"}
+	args := []interface{}{userID}
+	argIdx := 2
+
+	if profileVisibility != nil {
+		setClauses = append(setClauses, fmt.Sprintf("profile_visibility = $%d", argIdx))
+		args = append(args, *profileVisibility)
+		argIdx++
+	}
+	if showKarmaPublicly != nil {
+		setClauses = append(setClauses, fmt.Sprintf("show_karma_publicly = $%d", argIdx))
+		args = append(args, *showKarmaPublicly)
+		argIdx++
+	}
+	if personalizedSearch != nil {
+		setClauses = append(setClauses, fmt.Sprintf("personalized_search = $%d", argIdx))
+		args = append(args, *personalizedSearch)
+		argIdx++
+	}
+	if showProfileViews != nil {
+		setClauses = append(setClauses, fmt.Sprintf("show_profile_views = $%d", argIdx))
+		args = append(args, *showProfileViews)
+		argIdx++
+	}
+	if shareActivity != nil {
+		setClauses = append(setClauses, fmt.Sprintf("share_activity = $%d", argIdx))
+		args = append(args, *shareActivity)
+	}
+
+	query := "UPDATE user_settings SET " + strings.Join(setClauses, ", ") + " WHERE user_id = $1"
+
 	result, err := r.db.Exec(ctx, query, args...)
 	if err != nil {
 		return err
@@ -642,6 +816,19 @@ func (r *UserRepository) ClearDeviceToken(ctx context.Context, userID uuid.UUID)
 	return err
 }
 
+// ClearDeviceTokenIfMatches clears a user's device token only if it is still the given
+// token, so a token the provider rejected does not wipe one registered since
+func (r *UserRepository) ClearDeviceTokenIfMatches(ctx context.Context, userID uuid.UUID, deviceToken string) error {
+	query := `
+		UPDATE users
+		SET device_token = NULL, device_platform = NULL, updated_at = NOW()
+		WHERE id = $1 AND device_token = $2
+	`
+
+	_, err := r.db.Exec(ctx, query, userID, deviceToken)
+	return err
+}
+
 // GetUserProfile retrieves a complete user profile with stats
 func (r *UserRepository) GetUserProfile(ctx context.Context, userID uuid.UUID, currentUserID *uuid.UUID) (*models.UserProfile, error) {
 	// First get basic user info
@@ -1116,7 +1303,8 @@ func (r *UserRepository) AdminSearchUsers(ctx context.Context, searchQuery strin
 	baseQuery := `
 		SELECT
 			id, twitch_id, username, display_name, email, avatar_url, bio,
-			karma_points, role, account_type, is_banned, account_status, created_at, updated_at, last_login_at
+			karma_points, role, account_type, is_banned, account_status, created_at, updated_at, last_login_at,
+			admin_roles
 		FROM users
 		WHERE 1=1
 	`
@@ -1177,6 +1365,7 @@ func (r *UserRepository) AdminSearchUsers(ctx context.Context, searchQuery strin
 			&user.ID, &user.TwitchID, &user.Username, &user.DisplayName, &user.Email,
 			&user.AvatarURL, &user.Bio, &user.KarmaPoints, &user.Role, &user.AccountType,
 			&user.IsBanned, &user.AccountStatus, &user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt,
+			&user.AdminRoles,
 		)
 		if err != nil {
 			return nil, 0, err
@@ -1254,6 +1443,65 @@ func (r *UserRepository) SearchUsersForAutocomplete(ctx context.Context, query s
 	return users, nil
 }
 
+// SearchMentionSuggestions returns users whose username starts with query, for
+// @mention autocomplete. When viewerID is set, users blocked by the viewer or
+// blocking them are left out; when clipID is set, users who commented on the
+// clip are listed first.
+func (r *UserRepository) SearchMentionSuggestions(ctx context.Context, query string, viewerID, clipID *uuid.UUID, limit int) ([]*models.User, error) {
+	if limit < 1 {
+		limit = 10
+	} else if limit > 20 {
+		limit = 20
+	}
+
+	if query == "" {
+		return []*models.User{}, nil
+	}
+
+	searchQuery := `
+		SELECT
+			u.id, u.username, u.display_name, u.avatar_url, u.is_verified
+		FROM users u
+		WHERE LOWER(u.username) LIKE $1
+			AND u.is_banned = false
+			AND u.account_status = 'active'
+			AND ($2::uuid IS NULL OR NOT EXISTS (
+				SELECT 1 FROM user_blocks b
+				WHERE (b.user_id = $2 AND b.blocked_user_id = u.id)
+					OR (b.user_id = u.id AND b.blocked_user_id = $2)
+			))
+		ORDER BY
+			CASE WHEN $3::uuid IS NOT NULL AND EXISTS (
+				SELECT 1 FROM comments c
+				WHERE c.clip_id = $3 AND c.user_id = u.id AND c.is_removed = false
+			) THEN 0 ELSE 1 END,
+			CASE WHEN u.is_verified THEN 0 ELSE 1 END,
+			LENGTH(u.username),
+			u.username
+		LIMIT $4
+	`
+
+	// Escape LIKE wildcards so they match literally
+	pattern := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(strings.ToLower(query)) + "%"
+
+	rows, err := r.db.Query(ctx, searchQuery, pattern, viewerID, clipID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []*models.User{}
+	for rows.Next() {
+		var user models.User
+		if err := rows.Scan(&user.ID, &user.Username, &user.DisplayName, &user.AvatarURL, &user.IsVerified); err != nil {
+			return nil, err
+		}
+		users = append(users, &user)
+	}
+
+	return users, rows.Err()
+}
+
 // UpdateUserRole updates a user's role (user, moderator, admin)
 func (r *UserRepository) UpdateUserRole(ctx context.Context, userID uuid.UUID, role string) error {
 	// Validate role before updating
@@ -1279,6 +1527,35 @@ func (r *UserRepository) UpdateUserRole(ctx context.Context, userID uuid.UUID, r
 	return nil
 }
 
+// UpdateAdminRoles replaces a user's admin sub-roles
+func (r *UserRepository) UpdateAdminRoles(ctx context.Context, userID uuid.UUID, adminRoles []string) error {
+	for _, adminRole := range adminRoles {
+		if !models.IsValidAdminRole(adminRole) {
+			return fmt.Errorf("invalid admin role: %s", adminRole)
+		}
+	}
+	if adminRoles == nil {
+		adminRoles = []string{}
+	}
+
+	query := `
+		UPDATE users
+		SET admin_roles = $2, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query, userID, adminRoles)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
 // SetUserKarma sets a user's karma points to a specific value (admin override)
 func (r *UserRepository) SetUserKarma(ctx context.Context, userID uuid.UUID, karma int) error {
 	query := `
@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// Audit log actions for shadow bans. They are kept out of the user's own
+// data activity so a shadow ban isn't revealed to the user.
+const (
+	AuditActionShadowBanUser = "shadow_ban_user"
+	AuditActionLiftShadowBan = "lift_shadow_ban"
+)
+
+// ErrUserShadowBanNotFound is returned when a user has no active shadow ban
+var ErrUserShadowBanNotFound = errors.New("user shadow ban not found")
+
+const userShadowBanColumns = `
+	id, user_id, reason, shadow_banned_by, starts_at, expires_at,
+	lifted_at, lifted_by, lift_type, lift_reason, created_at`
+
+// touchSubmittedClips bumps updated_at on the clips users submitted, so the
+// incremental search sync drops or restores them after a shadow ban changes
+const touchSubmittedClips = `UPDATE clips SET updated_at = NOW() WHERE submitted_by_user_id = ANY($1)`
+
+// UserShadowBanRepository handles database operations for shadow bans
+type UserShadowBanRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewUserShadowBanRepository creates a new UserShadowBanRepository
+func NewUserShadowBanRepository(pool *pgxpool.Pool) *UserShadowBanRepository {
+	return &UserShadowBanRepository{pool: pool}
+}
+
+// Create shadow bans a user, replacing any active shadow ban, and marks the
+// user as shadow banned
+func (r *UserShadowBanRepository) Create(ctx context.Context, ban *models.UserShadowBan) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	result, err := tx.Exec(ctx, `UPDATE users SET is_shadow_banned = true, updated_at = NOW() WHERE id = $1`, ban.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to shadow ban user: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE user_shadow_bans
+		SET lifted_at = NOW(), lifted_by = $2, lift_type = 'superseded'
+		WHERE user_id = $1 AND lifted_at IS NULL
+	`, ban.UserID, ban.ShadowBannedBy)
+	if err != nil {
+		return fmt.Errorf("failed to supersede active shadow ban: %w", err)
+	}
+
+	query := `
+		INSERT INTO user_shadow_bans (user_id, reason, shadow_banned_by, starts_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+	err = tx.QueryRow(ctx, query, ban.UserID, ban.Reason, ban.ShadowBannedBy, ban.StartsAt, ban.ExpiresAt).
+		Scan(&ban.ID, &ban.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create shadow ban: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, touchSubmittedClips, []uuid.UUID{ban.UserID}); err != nil {
+		return fmt.Errorf("failed to mark submitted clips for reindexing: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit shadow ban: %w", err)
+	}
+	return nil
+}
+
+// Lift ends a user's active shadow ban early and marks the user as not
+// shadow banned
+func (r *UserShadowBanRepository) Lift(ctx context.Context, userID uuid.UUID, liftedBy *uuid.UUID, reason *string) (*models.UserShadowBan, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := fmt.Sprintf(`
+		UPDATE user_shadow_bans
+		SET lifted_at = NOW(), lifted_by = $2, lift_type = 'lifted', lift_reason = $3
+		WHERE user_id = $1 AND lifted_at IS NULL
+		RETURNING %s
+	`, userShadowBanColumns)
+	ban, err := scanUserShadowBan(tx.QueryRow(ctx, query, userID, liftedBy, reason))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserShadowBanNotFound
+		}
+		return nil, fmt.Errorf("failed to lift shadow ban: %w", err)
+	}
+
+	if err := clearShadowBanFlags(ctx, tx, []uuid.UUID{userID}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit shadow ban lift: %w", err)
+	}
+	return ban, nil
+}
+
+// LiftExpired lifts up to limit shadow bans that have reached their expiry and
+// marks their users as not shadow banned. Shadow bans claimed by another
+// instance are skipped.
+func (r *UserShadowBanRepository) LiftExpired(ctx context.Context, limit int) ([]models.UserShadowBan, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := fmt.Sprintf(`
+		UPDATE user_shadow_bans
+		SET lifted_at = NOW(), lift_type = 'expired'
+		WHERE id IN (
+			SELECT id FROM user_shadow_bans
+			WHERE lifted_at IS NULL AND expires_at <= NOW()
+			ORDER BY expires_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING %s
+	`, userShadowBanColumns)
+
+	rows, err := tx.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lift expired shadow bans: %w", err)
+	}
+	bans, err := collectUserShadowBans(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(bans) == 0 {
+		return bans, nil
+	}
+
+	userIDs := make([]uuid.UUID, len(bans))
+	for i, ban := range bans {
+		userIDs[i] = ban.UserID
+	}
+	if err := clearShadowBanFlags(ctx, tx, userIDs); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit expired shadow bans: %w", err)
+	}
+	return bans, nil
+}
+
+// clearShadowBanFlags marks users as not shadow banned and queues their
+// submitted clips for reindexing
+func clearShadowBanFlags(ctx context.Context, tx pgx.Tx, userIDs []uuid.UUID) error {
+	_, err := tx.Exec(ctx, `UPDATE users SET is_shadow_banned = false, updated_at = NOW() WHERE id = ANY($1)`, userIDs)
+	if err != nil {
+		return fmt.Errorf("failed to clear shadow ban: %w", err)
+	}
+	if _, err := tx.Exec(ctx, touchSubmittedClips, userIDs); err != nil {
+		return fmt.Errorf("failed to mark submitted clips for reindexing: %w", err)
+	}
+	return nil
+}
+
+// GetActive returns a user's active shadow ban
+func (r *UserShadowBanRepository) GetActive(ctx context.Context, userID uuid.UUID) (*models.UserShadowBan, error) {
+	query := fmt.Sprintf(`SELECT %s FROM user_shadow_bans WHERE user_id = $1 AND lifted_at IS NULL`, userShadowBanColumns)
+
+	ban, err := scanUserShadowBan(r.pool.QueryRow(ctx, query, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserShadowBanNotFound
+		}
+		return nil, fmt.Errorf("failed to get active shadow ban: %w", err)
+	}
+	return ban, nil
+}
+
+// ListForUser returns a user's shadow bans, newest first
+func (r *UserShadowBanRepository) ListForUser(ctx context.Context, userID uuid.UUID, limit int) ([]models.UserShadowBan, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM user_shadow_bans
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`, userShadowBanColumns)
+
+	rows, err := r.pool.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shadow bans: %w", err)
+	}
+	return collectUserShadowBans(rows)
+}
+
+// IsShadowBanned reports whether a user is currently shadow banned
+func (r *UserShadowBanRepository) IsShadowBanned(ctx context.Context, userID uuid.UUID) (bool, error) {
+	var shadowBanned bool
+	err := r.pool.QueryRow(ctx, `SELECT is_shadow_banned FROM users WHERE id = $1`, userID).Scan(&shadowBanned)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check shadow ban: %w", err)
+	}
+	return shadowBanned, nil
+}
+
+func scanUserShadowBan(row pgx.Row) (*models.UserShadowBan, error) {
+	var ban models.UserShadowBan
+	err := row.Scan(
+		&ban.ID,
+		&ban.UserID,
+		&ban.Reason,
+		&ban.ShadowBannedBy,
+		&ban.StartsAt,
+		&ban.ExpiresAt,
+		&ban.LiftedAt,
+		&ban.LiftedBy,
+		&ban.LiftType,
+		&ban.LiftReason,
+		&ban.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &ban, nil
+}
+
+func collectUserShadowBans(rows pgx.Rows) ([]models.UserShadowBan, error) {
+	defer rows.Close()
+
+	bans := []models.UserShadowBan{}
+	for rows.Next() {
+		ban, err := scanUserShadowBan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan shadow ban: %w", err)
+		}
+		bans = append(bans, *ban)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate shadow bans: %w", err)
+	}
+	return bans, nil
+}
@@ -0,0 +1,104 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/subculture-collective/clipper/pkg/metrics"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const banEvasionSchedulerName = "ban_evasion_detection"
+
+// BanEvasionServiceInterface defines the interface required by the ban evasion scheduler
+type BanEvasionServiceInterface interface {
+	DetectEvasion(ctx context.Context) (int, error)
+	PruneSignals(ctx context.Context) (int, error)
+}
+
+// BanEvasionScheduler flags new accounts that share sign-in signals with
+// banned accounts, and prunes signals past their retention period
+type BanEvasionScheduler struct {
+	banEvasionService BanEvasionServiceInterface
+	interval          time.Duration
+	stopChan          chan struct{}
+	stopOnce          sync.Once
+}
+
+// NewBanEvasionScheduler creates a new ban evasion scheduler
+func NewBanEvasionScheduler(banEvasionService BanEvasionServiceInterface, intervalMinutes int) *BanEvasionScheduler {
+	return &BanEvasionScheduler{
+		banEvasionService: banEvasionService,
+		interval:          time.Duration(intervalMinutes) * time.Minute,
+		stopChan:          make(chan struct{}),
+	}
+}
+
+// Start begins running ban evasion detection periodically
+func (s *BanEvasionScheduler) Start(ctx context.Context) {
+	utils.Info("Starting ban evasion scheduler", map[string]interface{}{
+		"scheduler": banEvasionSchedulerName,
+		"interval":  s.interval.String(),
+	})
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run initial pass
+	s.run(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.run(ctx)
+		case <-s.stopChan:
+			utils.Info("Ban evasion scheduler stopped", map[string]interface{}{
+				"scheduler": banEvasionSchedulerName,
+			})
+			return
+		case <-ctx.Done():
+			utils.Info("Ban evasion scheduler stopped due to context cancellation", map[string]interface{}{
+				"scheduler": banEvasionSchedulerName,
+			})
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler in a thread-safe manner
+func (s *BanEvasionScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+}
+
+func (s *BanEvasionScheduler) run(ctx context.Context) {
+	start := time.Now()
+
+	flagged, err := s.banEvasionService.DetectEvasion(ctx)
+	if err != nil {
+		utils.Error("Failed to detect ban evasion", err, map[string]interface{}{
+			"scheduler": banEvasionSchedulerName,
+		})
+	}
+
+	pruned, pruneErr := s.banEvasionService.PruneSignals(ctx)
+	if pruneErr != nil {
+		utils.Error("Failed to prune account signals", pruneErr, map[string]interface{}{
+			"scheduler": banEvasionSchedulerName,
+		})
+		if err == nil {
+			err = pruneErr
+		}
+	}
+
+	metrics.ObserveJobRun(banEvasionSchedulerName, time.Since(start), err)
+	if flagged > 0 || pruned > 0 {
+		utils.Info("Ban evasion detection completed", map[string]interface{}{
+			"scheduler": banEvasionSchedulerName,
+			"flagged":   flagged,
+			"pruned":    pruned,
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/subculture-collective/clipper/pkg/metrics"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const (
+	banExpirySchedulerName = "ban_expiry"
+	// shadowBanExpiryJobName labels the shadow ban pass in job metrics
+	shadowBanExpiryJobName = "shadow_ban_expiry"
+)
+
+// BanExpiryServiceInterface defines the interface required by the ban expiry scheduler
+type BanExpiryServiceInterface interface {
+	LiftExpiredBans(ctx context.Context) (int, error)
+}
+
+// BanExpiryScheduler reinstates users whose time-boxed ban has expired
+type BanExpiryScheduler struct {
+	banService       BanExpiryServiceInterface
+	shadowBanService BanExpiryServiceInterface
+	interval         time.Duration
+	stopChan         chan struct{}
+	stopOnce         sync.Once
+}
+
+// NewBanExpiryScheduler creates a new ban expiry scheduler
+func NewBanExpiryScheduler(banService BanExpiryServiceInterface, intervalMinutes int) *BanExpiryScheduler {
+	return &BanExpiryScheduler{
+		banService: banService,
+		interval:   time.Duration(intervalMinutes) * time.Minute,
+		stopChan:   make(chan struct{}),
+	}
+}
+
+// SetShadowBanService also lifts expired shadow bans on each run
+func (s *BanExpiryScheduler) SetShadowBanService(shadowBanService BanExpiryServiceInterface) {
+	s.shadowBanService = shadowBanService
+}
+
+// Start begins lifting expired bans periodically
+func (s *BanExpiryScheduler) Start(ctx context.Context) {
+	utils.Info("Starting ban expiry scheduler", map[string]interface{}{
+		"scheduler": banExpirySchedulerName,
+		"interval":  s.interval.String(),
+	})
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run initial pass
+	s.liftExpired(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.liftExpired(ctx)
+		case <-s.stopChan:
+			utils.Info("Ban expiry scheduler stopped", map[string]interface{}{
+				"scheduler": banExpirySchedulerName,
+			})
+			return
+		case <-ctx.Done():
+			utils.Info("Ban expiry scheduler stopped due to context cancellation", map[string]interface{}{
+				"scheduler": banExpirySchedulerName,
+			})
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler in a thread-safe manner
+func (s *BanExpiryScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+}
+
+// liftExpired lifts expired bans and shadow bans. Each pass handles a batch,
+// so a backlog is worked through over several runs.
+func (s *BanExpiryScheduler) liftExpired(ctx context.Context) {
+	s.runPass(ctx, banExpirySchedulerName, "bans", s.banService)
+	if s.shadowBanService != nil {
+		s.runPass(ctx, shadowBanExpiryJobName, "shadow bans", s.shadowBanService)
+	}
+}
+
+// runPass lifts one batch of expired bans from a service
+func (s *BanExpiryScheduler) runPass(ctx context.Context, job, kind string, service BanExpiryServiceInterface) {
+	start := time.Now()
+	lifted, err := service.LiftExpiredBans(ctx)
+	metrics.ObserveJobRun(job, time.Since(start), err)
+	if err != nil {
+		utils.Error("Failed to lift expired "+kind, err, map[string]interface{}{
+			"scheduler": banExpirySchedulerName,
+		})
+		return
+	}
+	if lifted > 0 {
+		utils.Info("Lifted expired "+kind, map[string]interface{}{
+			"scheduler": banExpirySchedulerName,
+			"count":     lifted,
+		})
+	}
+}
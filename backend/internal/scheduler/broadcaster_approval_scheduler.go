@@ -0,0 +1,95 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/subculture-collective/clipper/pkg/metrics"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const broadcasterApprovalSchedulerName = "broadcaster_approval"
+
+// broadcasterApprovalBatchSize caps how many expired holds are released per run
+const broadcasterApprovalBatchSize = 100
+
+// BroadcasterApprovalServiceInterface defines the interface required by the broadcaster approval scheduler
+type BroadcasterApprovalServiceInterface interface {
+	ReleaseExpired(ctx context.Context, limit int) (int, error)
+}
+
+// BroadcasterApprovalScheduler auto-approves clips whose broadcaster did not
+// review them before the policy deadline
+type BroadcasterApprovalScheduler struct {
+	approvalService BroadcasterApprovalServiceInterface
+	interval        time.Duration
+	stopChan        chan struct{}
+	stopOnce        sync.Once
+}
+
+// NewBroadcasterApprovalScheduler creates a new broadcaster approval scheduler
+func NewBroadcasterApprovalScheduler(approvalService BroadcasterApprovalServiceInterface, intervalMinutes int) *BroadcasterApprovalScheduler {
+	return &BroadcasterApprovalScheduler{
+		approvalService: approvalService,
+		interval:        time.Duration(intervalMinutes) * time.Minute,
+		stopChan:        make(chan struct{}),
+	}
+}
+
+// Start begins the periodic expiry process
+func (s *BroadcasterApprovalScheduler) Start(ctx context.Context) {
+	utils.Info("Starting broadcaster approval scheduler", map[string]interface{}{
+		"scheduler": broadcasterApprovalSchedulerName,
+		"interval":  s.interval.String(),
+	})
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run initial release
+	s.releaseExpired(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.releaseExpired(ctx)
+		case <-s.stopChan:
+			utils.Info("Broadcaster approval scheduler stopped", map[string]interface{}{
+				"scheduler": broadcasterApprovalSchedulerName,
+			})
+			return
+		case <-ctx.Done():
+			utils.Info("Broadcaster approval scheduler stopped due to context cancellation", map[string]interface{}{
+				"scheduler": broadcasterApprovalSchedulerName,
+			})
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler in a thread-safe manner
+func (s *BroadcasterApprovalScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+}
+
+// releaseExpired releases held clips past their auto-approval deadline
+func (s *BroadcasterApprovalScheduler) releaseExpired(ctx context.Context) {
+	start := time.Now()
+	released, err := s.approvalService.ReleaseExpired(ctx, broadcasterApprovalBatchSize)
+	metrics.ObserveJobRun(broadcasterApprovalSchedulerName, time.Since(start), err)
+	if err != nil {
+		utils.Error("Failed to release expired broadcaster approvals", err, map[string]interface{}{
+			"scheduler": broadcasterApprovalSchedulerName,
+		})
+		return
+	}
+	if released > 0 {
+		utils.Info("Released clips past their broadcaster approval deadline", map[string]interface{}{
+			"scheduler": broadcasterApprovalSchedulerName,
+			"count":     released,
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/subculture-collective/clipper/pkg/metrics"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const (
+	broadcasterScheduleSyncName     = "broadcaster_schedule_sync"
+	broadcasterScheduleReminderName = "broadcaster_schedule_reminder"
+)
+
+// BroadcasterScheduleServiceInterface defines the interface required by the broadcaster schedule scheduler
+type BroadcasterScheduleServiceInterface interface {
+	SyncFollowedBroadcasters(ctx context.Context) (int, error)
+	SendDueReminders(ctx context.Context) (int, error)
+}
+
+// BroadcasterScheduleScheduler pulls followed broadcasters' schedules from
+// Twitch and sends reminders before scheduled streams
+type BroadcasterScheduleScheduler struct {
+	scheduleService  BroadcasterScheduleServiceInterface
+	syncInterval     time.Duration
+	reminderInterval time.Duration
+	syncing          atomic.Bool
+	stopChan         chan struct{}
+	stopOnce         sync.Once
+}
+
+// NewBroadcasterScheduleScheduler creates a new broadcaster schedule scheduler
+func NewBroadcasterScheduleScheduler(
+	scheduleService BroadcasterScheduleServiceInterface,
+	syncIntervalMinutes int,
+	reminderIntervalMinutes int,
+) *BroadcasterScheduleScheduler {
+	return &BroadcasterScheduleScheduler{
+		scheduleService:  scheduleService,
+		syncInterval:     time.Duration(syncIntervalMinutes) * time.Minute,
+		reminderInterval: time.Duration(reminderIntervalMinutes) * time.Minute,
+		stopChan:         make(chan struct{}),
+	}
+}
+
+// Start begins syncing schedules and sending reminders periodically
+func (s *BroadcasterScheduleScheduler) Start(ctx context.Context) {
+	utils.Info("Starting broadcaster schedule scheduler", map[string]interface{}{
+		"scheduler":         broadcasterScheduleSyncName,
+		"sync_interval":     s.syncInterval.String(),
+		"reminder_interval": s.reminderInterval.String(),
+	})
+
+	syncTicker := time.NewTicker(s.syncInterval)
+	reminderTicker := time.NewTicker(s.reminderInterval)
+	defer syncTicker.Stop()
+	defer reminderTicker.Stop()
+
+	// Run initial passes. A sync can take minutes with many broadcasters, so
+	// it runs in the background to keep reminders on time.
+	go s.syncSchedules(ctx)
+	s.sendReminders(ctx)
+
+	for {
+		select {
+		case <-syncTicker.C:
+			go s.syncSchedules(ctx)
+		case <-reminderTicker.C:
+			s.sendReminders(ctx)
+		case <-s.stopChan:
+			utils.Info("Broadcaster schedule scheduler stopped", map[string]interface{}{
+				"scheduler": broadcasterScheduleSyncName,
+			})
+			return
+		case <-ctx.Done():
+			utils.Info("Broadcaster schedule scheduler stopped due to context cancellation", map[string]interface{}{
+				"scheduler": broadcasterScheduleSyncName,
+			})
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler in a thread-safe manner
+func (s *BroadcasterScheduleScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+}
+
+// syncSchedules pulls schedules, skipping the run if the previous one is still going
+func (s *BroadcasterScheduleScheduler) syncSchedules(ctx context.Context) {
+	if !s.syncing.CompareAndSwap(false, true) {
+		return
+	}
+	defer s.syncing.Store(false)
+
+	start := time.Now()
+	synced, err := s.scheduleService.SyncFollowedBroadcasters(ctx)
+	metrics.ObserveJobRun(broadcasterScheduleSyncName, time.Since(start), err)
+	if err != nil {
+		utils.Error("Failed to sync broadcaster schedules", err, map[string]interface{}{
+			"scheduler": broadcasterScheduleSyncName,
+			"synced":    synced,
+		})
+		return
+	}
+	utils.Info("Synced broadcaster schedules", map[string]interface{}{
+		"scheduler": broadcasterScheduleSyncName,
+		"count":     synced,
+		"duration":  time.Since(start).String(),
+	})
+}
+
+// sendReminders sends due reminders. Each pass handles a batch, so a backlog
+// is worked through over several runs.
+func (s *BroadcasterScheduleScheduler) sendReminders(ctx context.Context) {
+	start := time.Now()
+	sent, err := s.scheduleService.SendDueReminders(ctx)
+	metrics.ObserveJobRun(broadcasterScheduleReminderName, time.Since(start), err)
+	if err != nil {
+		utils.Error("Failed to send schedule reminders", err, map[string]interface{}{
+			"scheduler": broadcasterScheduleReminderName,
+		})
+		return
+	}
+	if sent > 0 {
+		utils.Info("Sent schedule reminders", map[string]interface{}{
+			"scheduler": broadcasterScheduleReminderName,
+			"count":     sent,
+		})
+	}
+}
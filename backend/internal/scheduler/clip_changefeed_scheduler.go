@@ -0,0 +1,92 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/subculture-collective/clipper/pkg/metrics"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const clipChangefeedSchedulerName = "clip_changefeed"
+
+// ClipChangefeedServiceInterface defines the interface required by the clip changefeed scheduler
+type ClipChangefeedServiceInterface interface {
+	Relay(ctx context.Context) (int, error)
+}
+
+// ClipChangefeedScheduler relays clip outbox events to the changefeed and webhooks
+type ClipChangefeedScheduler struct {
+	changefeedService ClipChangefeedServiceInterface
+	interval          time.Duration
+	stopChan          chan struct{}
+	stopOnce          sync.Once
+}
+
+// NewClipChangefeedScheduler creates a new clip changefeed scheduler
+func NewClipChangefeedScheduler(changefeedService ClipChangefeedServiceInterface, intervalSeconds int) *ClipChangefeedScheduler {
+	return &ClipChangefeedScheduler{
+		changefeedService: changefeedService,
+		interval:          time.Duration(intervalSeconds) * time.Second,
+		stopChan:          make(chan struct{}),
+	}
+}
+
+// Start begins relaying outbox events periodically
+func (s *ClipChangefeedScheduler) Start(ctx context.Context) {
+	utils.Info("Starting clip changefeed scheduler", map[string]interface{}{
+		"scheduler": clipChangefeedSchedulerName,
+		"interval":  s.interval.String(),
+	})
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run initial pass
+	s.relay(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.relay(ctx)
+		case <-s.stopChan:
+			utils.Info("Clip changefeed scheduler stopped", map[string]interface{}{
+				"scheduler": clipChangefeedSchedulerName,
+			})
+			return
+		case <-ctx.Done():
+			utils.Info("Clip changefeed scheduler stopped due to context cancellation", map[string]interface{}{
+				"scheduler": clipChangefeedSchedulerName,
+			})
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler in a thread-safe manner
+func (s *ClipChangefeedScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+}
+
+// relay sequences new outbox events. Each pass handles a batch, so a backlog
+// is worked through over several runs.
+func (s *ClipChangefeedScheduler) relay(ctx context.Context) {
+	start := time.Now()
+	sequenced, err := s.changefeedService.Relay(ctx)
+	metrics.ObserveJobRun(clipChangefeedSchedulerName, time.Since(start), err)
+	if err != nil {
+		utils.Error("Failed to relay clip changefeed events", err, map[string]interface{}{
+			"scheduler": clipChangefeedSchedulerName,
+		})
+		return
+	}
+	if sequenced > 0 {
+		utils.Info("Relayed clip changefeed events", map[string]interface{}{
+			"scheduler": clipChangefeedSchedulerName,
+			"count":     sequenced,
+		})
+	}
+}
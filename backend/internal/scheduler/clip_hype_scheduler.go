@@ -0,0 +1,91 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/subculture-collective/clipper/pkg/metrics"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const clipHypeSchedulerName = "clip_hype"
+
+// ClipHypeServiceInterface defines the interface required by the clip hype scheduler
+type ClipHypeServiceInterface interface {
+	ScoreClips(ctx context.Context) (int, error)
+}
+
+// ClipHypeScheduler scores new clips by the chat activity around the moment they were clipped
+type ClipHypeScheduler struct {
+	hypeService ClipHypeServiceInterface
+	interval    time.Duration
+	stopChan    chan struct{}
+	stopOnce    sync.Once
+}
+
+// NewClipHypeScheduler creates a new clip hype scheduler
+func NewClipHypeScheduler(hypeService ClipHypeServiceInterface, intervalMinutes int) *ClipHypeScheduler {
+	return &ClipHypeScheduler{
+		hypeService: hypeService,
+		interval:    time.Duration(intervalMinutes) * time.Minute,
+		stopChan:    make(chan struct{}),
+	}
+}
+
+// Start begins scoring clips periodically
+func (s *ClipHypeScheduler) Start(ctx context.Context) {
+	utils.Info("Starting clip hype scheduler", map[string]interface{}{
+		"scheduler": clipHypeSchedulerName,
+		"interval":  s.interval.String(),
+	})
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run initial pass
+	s.score(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.score(ctx)
+		case <-s.stopChan:
+			utils.Info("Clip hype scheduler stopped", map[string]interface{}{
+				"scheduler": clipHypeSchedulerName,
+			})
+			return
+		case <-ctx.Done():
+			utils.Info("Clip hype scheduler stopped due to context cancellation", map[string]interface{}{
+				"scheduler": clipHypeSchedulerName,
+			})
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler in a thread-safe manner
+func (s *ClipHypeScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+}
+
+// score scores the clips whose chat reaction has settled
+func (s *ClipHypeScheduler) score(ctx context.Context) {
+	start := time.Now()
+	scored, err := s.hypeService.ScoreClips(ctx)
+	metrics.ObserveJobRun(clipHypeSchedulerName, time.Since(start), err)
+	if err != nil {
+		utils.Error("Failed to score clip hype", err, map[string]interface{}{
+			"scheduler": clipHypeSchedulerName,
+		})
+		return
+	}
+	if scored > 0 {
+		utils.Info("Scored clip hype", map[string]interface{}{
+			"scheduler": clipHypeSchedulerName,
+			"count":     scored,
+		})
+	}
+}
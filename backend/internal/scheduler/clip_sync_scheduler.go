@@ -48,12 +48,12 @@ func (s *ClipSyncScheduler) Start(ctx context.Context) {
 	defer ticker.Stop()
 
 	// Run initial sync
-	s.runSync(ctx)
+	_ = s.runSync(ctx)
 
 	for {
 		select {
 		case <-ticker.C:
-			s.runSync(ctx)
+			_ = s.runSync(ctx)
 		case <-s.stopChan:
 			utils.Info("Clip sync scheduler stopped", map[string]interface{}{
 				"scheduler": clipSyncSchedulerName,
@@ -75,8 +75,14 @@ func (s *ClipSyncScheduler) Stop() {
 	})
 }
 
+// RunOnce runs a single sync. It is used by the job queue worker, which
+// schedules runs itself instead of calling Start.
+func (s *ClipSyncScheduler) RunOnce(ctx context.Context) error {
+	return s.runSync(ctx)
+}
+
 // runSync executes a sync operation
-func (s *ClipSyncScheduler) runSync(ctx context.Context) {
+func (s *ClipSyncScheduler) runSync(ctx context.Context) error {
 	utils.Info("Starting scheduled clip sync", map[string]interface{}{
 		"scheduler": clipSyncSchedulerName,
 		"job":       clipSyncJobName,
@@ -97,7 +103,7 @@ func (s *ClipSyncScheduler) runSync(ctx context.Context) {
 			"job":       clipSyncJobName,
 		})
 		metrics.JobExecutionTotal.WithLabelValues(clipSyncJobName, "failed").Inc()
-		return
+		return err
 	}
 
 	metrics.JobExecutionTotal.WithLabelValues(clipSyncJobName, "success").Inc()
@@ -126,4 +132,6 @@ func (s *ClipSyncScheduler) runSync(ctx context.Context) {
 			"errors":    stats.Errors,
 		})
 	}
+
+	return nil
 }
@@ -0,0 +1,92 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/subculture-collective/clipper/pkg/metrics"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const communityDigestSchedulerName = "community_digest"
+
+// CommunityDigestServiceInterface defines the interface required by the community digest scheduler
+type CommunityDigestServiceInterface interface {
+	PostDueDigests(ctx context.Context) (int, error)
+}
+
+// CommunityDigestScheduler posts weekly digest discussions in active communities
+type CommunityDigestScheduler struct {
+	digestService CommunityDigestServiceInterface
+	interval      time.Duration
+	stopChan      chan struct{}
+	stopOnce      sync.Once
+}
+
+// NewCommunityDigestScheduler creates a new community digest scheduler
+func NewCommunityDigestScheduler(digestService CommunityDigestServiceInterface, intervalMinutes int) *CommunityDigestScheduler {
+	return &CommunityDigestScheduler{
+		digestService: digestService,
+		interval:      time.Duration(intervalMinutes) * time.Minute,
+		stopChan:      make(chan struct{}),
+	}
+}
+
+// Start begins posting due digests periodically
+func (s *CommunityDigestScheduler) Start(ctx context.Context) {
+	utils.Info("Starting community digest scheduler", map[string]interface{}{
+		"scheduler": communityDigestSchedulerName,
+		"interval":  s.interval.String(),
+	})
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run initial pass
+	s.postDue(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.postDue(ctx)
+		case <-s.stopChan:
+			utils.Info("Community digest scheduler stopped", map[string]interface{}{
+				"scheduler": communityDigestSchedulerName,
+			})
+			return
+		case <-ctx.Done():
+			utils.Info("Community digest scheduler stopped due to context cancellation", map[string]interface{}{
+				"scheduler": communityDigestSchedulerName,
+			})
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler in a thread-safe manner
+func (s *CommunityDigestScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+}
+
+// postDue posts last week's digests. Each pass handles a batch, so a
+// backlog is worked through over several runs.
+func (s *CommunityDigestScheduler) postDue(ctx context.Context) {
+	start := time.Now()
+	posted, err := s.digestService.PostDueDigests(ctx)
+	metrics.ObserveJobRun(communityDigestSchedulerName, time.Since(start), err)
+	if err != nil {
+		utils.Error("Failed to post community digests", err, map[string]interface{}{
+			"scheduler": communityDigestSchedulerName,
+		})
+		return
+	}
+	if posted > 0 {
+		utils.Info("Posted community digests", map[string]interface{}{
+			"scheduler": communityDigestSchedulerName,
+			"count":     posted,
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/subculture-collective/clipper/pkg/metrics"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const communityPickSchedulerName = "community_pick"
+
+// CommunityPickServiceInterface defines the interface required by the community pick scheduler
+type CommunityPickServiceInterface interface {
+	PublishDueRounds(ctx context.Context) (int, error)
+}
+
+// CommunityPickScheduler publishes community pick rounds whose voting window has ended
+type CommunityPickScheduler struct {
+	pickService CommunityPickServiceInterface
+	interval    time.Duration
+	stopChan    chan struct{}
+	stopOnce    sync.Once
+}
+
+// NewCommunityPickScheduler creates a new community pick scheduler
+func NewCommunityPickScheduler(pickService CommunityPickServiceInterface, intervalMinutes int) *CommunityPickScheduler {
+	return &CommunityPickScheduler{
+		pickService: pickService,
+		interval:    time.Duration(intervalMinutes) * time.Minute,
+		stopChan:    make(chan struct{}),
+	}
+}
+
+// Start begins publishing due rounds periodically
+func (s *CommunityPickScheduler) Start(ctx context.Context) {
+	utils.Info("Starting community pick scheduler", map[string]interface{}{
+		"scheduler": communityPickSchedulerName,
+		"interval":  s.interval.String(),
+	})
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run initial pass
+	s.publishDue(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.publishDue(ctx)
+		case <-s.stopChan:
+			utils.Info("Community pick scheduler stopped", map[string]interface{}{
+				"scheduler": communityPickSchedulerName,
+			})
+			return
+		case <-ctx.Done():
+			utils.Info("Community pick scheduler stopped due to context cancellation", map[string]interface{}{
+				"scheduler": communityPickSchedulerName,
+			})
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler in a thread-safe manner
+func (s *CommunityPickScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+}
+
+// publishDue publishes rounds whose voting has ended. Each pass handles a
+// batch, so a backlog is worked through over several runs.
+func (s *CommunityPickScheduler) publishDue(ctx context.Context) {
+	start := time.Now()
+	published, err := s.pickService.PublishDueRounds(ctx)
+	metrics.ObserveJobRun(communityPickSchedulerName, time.Since(start), err)
+	if err != nil {
+		utils.Error("Failed to publish community pick rounds", err, map[string]interface{}{
+			"scheduler": communityPickSchedulerName,
+		})
+		return
+	}
+	if published > 0 {
+		utils.Info("Published community pick rounds", map[string]interface{}{
+			"scheduler": communityPickSchedulerName,
+			"count":     published,
+		})
+	}
+}
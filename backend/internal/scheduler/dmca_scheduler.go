@@ -0,0 +1,104 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/subculture-collective/clipper/pkg/metrics"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const dmcaSchedulerName = "dmca_maintenance"
+
+// DMCAServiceInterface defines the interface required by the DMCA scheduler
+type DMCAServiceInterface interface {
+	ProcessExpiredWaitingPeriods(ctx context.Context) (int, error)
+	ExpireOldStrikes(ctx context.Context) (int, error)
+}
+
+// DMCAScheduler reinstates content whose counter-notice waiting period has
+// ended without a lawsuit, and expires strikes once they age out
+type DMCAScheduler struct {
+	dmcaService DMCAServiceInterface
+	interval    time.Duration
+	stopChan    chan struct{}
+	stopOnce    sync.Once
+}
+
+// NewDMCAScheduler creates a new DMCA scheduler
+func NewDMCAScheduler(dmcaService DMCAServiceInterface, intervalMinutes int) *DMCAScheduler {
+	return &DMCAScheduler{
+		dmcaService: dmcaService,
+		interval:    time.Duration(intervalMinutes) * time.Minute,
+		stopChan:    make(chan struct{}),
+	}
+}
+
+// Start begins running the DMCA maintenance periodically
+func (s *DMCAScheduler) Start(ctx context.Context) {
+	utils.Info("Starting DMCA scheduler", map[string]interface{}{
+		"scheduler": dmcaSchedulerName,
+		"interval":  s.interval.String(),
+	})
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run initial pass
+	s.run(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.run(ctx)
+		case <-s.stopChan:
+			utils.Info("DMCA scheduler stopped", map[string]interface{}{
+				"scheduler": dmcaSchedulerName,
+			})
+			return
+		case <-ctx.Done():
+			utils.Info("DMCA scheduler stopped due to context cancellation", map[string]interface{}{
+				"scheduler": dmcaSchedulerName,
+			})
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler in a thread-safe manner
+func (s *DMCAScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+}
+
+func (s *DMCAScheduler) run(ctx context.Context) {
+	start := time.Now()
+
+	reinstated, err := s.dmcaService.ProcessExpiredWaitingPeriods(ctx)
+	if err != nil {
+		utils.Error("Failed to reinstate content after counter-notice waiting periods", err, map[string]interface{}{
+			"scheduler": dmcaSchedulerName,
+		})
+	}
+
+	expired, strikeErr := s.dmcaService.ExpireOldStrikes(ctx)
+	if strikeErr != nil {
+		utils.Error("Failed to expire DMCA strikes", strikeErr, map[string]interface{}{
+			"scheduler": dmcaSchedulerName,
+		})
+		if err == nil {
+			err = strikeErr
+		}
+	}
+
+	metrics.ObserveJobRun(dmcaSchedulerName, time.Since(start), err)
+	if reinstated > 0 || expired > 0 {
+		utils.Info("DMCA maintenance completed", map[string]interface{}{
+			"scheduler":  dmcaSchedulerName,
+			"reinstated": reinstated,
+			"expired":    expired,
+		})
+	}
+}
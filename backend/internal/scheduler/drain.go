@@ -0,0 +1,116 @@
+package scheduler
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// drainInterruptGrace is how long interrupted schedulers get to checkpoint
+// their work and return once the drain deadline has passed
+const drainInterruptGrace = 5 * time.Second
+
+// Drainer runs schedulers so shutdown can wait for their in-flight runs.
+// Schedulers started with Go share a context that is only cancelled once the
+// drain deadline passes, so a run in progress when they are stopped is
+// allowed to finish.
+type Drainer struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	running map[string]int
+}
+
+// DrainReport describes how a drain ended
+type DrainReport struct {
+	Duration    time.Duration // How long the drain took
+	Drained     int           // Schedulers that returned on their own
+	Interrupted []string      // Schedulers still running at the deadline, which were cancelled
+	Abandoned   []string      // Interrupted schedulers that didn't return within the grace period
+}
+
+// TimedOut reports whether any scheduler had to be interrupted
+func (r DrainReport) TimedOut() bool {
+	return len(r.Interrupted) > 0
+}
+
+// NewDrainer creates a new Drainer
+func NewDrainer() *Drainer {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Drainer{
+		ctx:     ctx,
+		cancel:  cancel,
+		running: make(map[string]int),
+	}
+}
+
+// Go runs a scheduler's Start loop in a goroutine tracked by the drainer
+func (d *Drainer) Go(name string, start func(ctx context.Context)) {
+	d.mu.Lock()
+	d.running[name]++
+	d.mu.Unlock()
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		defer d.finish(name)
+		start(d.ctx)
+	}()
+}
+
+// finish records that a scheduler returned
+func (d *Drainer) finish(name string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.running[name]--
+	if d.running[name] == 0 {
+		delete(d.running, name)
+	}
+}
+
+// Running returns the names of the schedulers that haven't returned, sorted
+func (d *Drainer) Running() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	names := make([]string, 0, len(d.running))
+	for name := range d.running {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Drain waits up to timeout for every scheduler to return. Callers stop the
+// schedulers first so no new runs start. Schedulers still running at the
+// deadline are interrupted by cancelling their context, which jobs treat as a
+// signal to checkpoint and leave unfinished work queued for the next run.
+func (d *Drainer) Drain(timeout time.Duration) DrainReport {
+	start := time.Now()
+	total := len(d.Running())
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	report := DrainReport{}
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		report.Interrupted = d.Running()
+		d.cancel()
+		select {
+		case <-done:
+		case <-time.After(drainInterruptGrace):
+			report.Abandoned = d.Running()
+		}
+	}
+	d.cancel()
+
+	report.Duration = time.Since(start)
+	report.Drained = total - len(report.Interrupted)
+	return report
+}
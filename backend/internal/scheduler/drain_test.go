@@ -0,0 +1,54 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrainer_WaitsForInFlightRuns(t *testing.T) {
+	d := NewDrainer()
+	release := make(chan struct{})
+	finished := make(chan struct{})
+
+	d.Go("exports", func(ctx context.Context) {
+		<-release
+		close(finished)
+	})
+	d.Go("hot_score", func(ctx context.Context) {})
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+	}()
+
+	report := d.Drain(time.Second)
+	assert.False(t, report.TimedOut())
+	assert.Equal(t, 2, report.Drained)
+	assert.Empty(t, d.Running())
+	select {
+	case <-finished:
+	default:
+		t.Fatal("drain returned before the in-flight run finished")
+	}
+}
+
+func TestDrainer_InterruptsAtDeadline(t *testing.T) {
+	d := NewDrainer()
+	checkpointed := make(chan struct{})
+
+	d.Go("outbound_webhook", func(ctx context.Context) {
+		<-ctx.Done()
+		close(checkpointed)
+	})
+	d.Go("ban_expiry", func(ctx context.Context) {})
+
+	report := d.Drain(20 * time.Millisecond)
+	assert.True(t, report.TimedOut())
+	assert.Equal(t, []string{"outbound_webhook"}, report.Interrupted)
+	assert.Empty(t, report.Abandoned)
+	assert.Equal(t, 1, report.Drained)
+	<-checkpointed
+}
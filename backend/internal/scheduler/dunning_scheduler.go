@@ -5,6 +5,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/subculture-collective/clipper/pkg/metrics"
 	"github.com/subculture-collective/clipper/pkg/utils"
 )
 
@@ -155,7 +156,10 @@ func (s *DunningScheduler) processExpiredGracePeriods(ctx context.Context) {
 		"scheduler": dunningSchedulerName,
 	})
 
-	if err := s.dunningService.ProcessExpiredGracePeriods(ctx); err != nil {
+	start := time.Now()
+	err := s.dunningService.ProcessExpiredGracePeriods(ctx)
+	metrics.ObserveJobRun(dunningSchedulerName+"_grace_periods", time.Since(start), err)
+	if err != nil {
 		utils.Error("Error processing expired grace periods", err, map[string]interface{}{
 			"scheduler": dunningSchedulerName,
 		})
@@ -172,7 +176,10 @@ func (s *DunningScheduler) sendGracePeriodWarnings(ctx context.Context) {
 		"scheduler": dunningSchedulerName,
 	})
 
-	if err := s.dunningService.SendGracePeriodWarnings(ctx); err != nil {
+	start := time.Now()
+	err := s.dunningService.SendGracePeriodWarnings(ctx)
+	metrics.ObserveJobRun(dunningSchedulerName+"_warnings", time.Since(start), err)
+	if err != nil {
 		utils.Error("Error sending grace period warnings", err, map[string]interface{}{
 			"scheduler": dunningSchedulerName,
 		})
@@ -2,11 +2,16 @@ package scheduler
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	pgvector "github.com/pgvector/pgvector-go"
 	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/services"
 	"github.com/subculture-collective/clipper/pkg/database"
 	"github.com/subculture-collective/clipper/pkg/metrics"
 	"github.com/subculture-collective/clipper/pkg/utils"
@@ -16,18 +21,34 @@ const embeddingSchedulerName = "embedding_generation"
 
 // EmbeddingServiceInterface defines the interface required by the scheduler
 type EmbeddingServiceInterface interface {
-	GenerateClipEmbedding(ctx context.Context, clip *models.Clip) ([]float32, error)
+	GenerateClipEmbeddings(ctx context.Context, clips []models.Clip) ([][]float32, error)
 	Close()
 }
 
+// EmbeddingIndexer pushes generated clip embeddings to the search index
+type EmbeddingIndexer interface {
+	UpdateClipEmbeddings(ctx context.Context, embeddings map[uuid.UUID][]float32) error
+}
+
 // EmbeddingScheduler manages periodic embedding generation for new clips
 type EmbeddingScheduler struct {
 	db               *database.DB
 	embeddingService EmbeddingServiceInterface
+	searchIndexer    EmbeddingIndexer // optional
 	interval         time.Duration
 	stopChan         chan struct{}
 	stopOnce         sync.Once
 	model            string
+	column           string
+	dualWrite        *embeddingDualWrite // nil unless an embedding migration is running
+}
+
+// embeddingDualWrite is the column an embedding migration is filling and the
+// model it is filled with
+type embeddingDualWrite struct {
+	embeddingService EmbeddingServiceInterface
+	column           string
+	model            string
 }
 
 // NewEmbeddingScheduler creates a new scheduler
@@ -43,9 +64,33 @@ func NewEmbeddingScheduler(
 		interval:         time.Duration(intervalMinutes) * time.Minute,
 		stopChan:         make(chan struct{}),
 		model:            model,
+		column:           models.DefaultClipEmbeddingColumn,
 	}
 }
 
+// SetColumn sets the clips column embeddings are written to, which must hold
+// vectors of the scheduler's model
+func (s *EmbeddingScheduler) SetColumn(column string) {
+	s.column = column
+}
+
+// SetDualWrite also embeds new clips into the column an embedding migration
+// is filling, with that column's model, so clips created during the
+// migration don't need another backfill
+func (s *EmbeddingScheduler) SetDualWrite(embeddingService EmbeddingServiceInterface, column, model string) {
+	s.dualWrite = &embeddingDualWrite{
+		embeddingService: embeddingService,
+		column:           column,
+		model:            model,
+	}
+}
+
+// SetSearchIndexer makes the scheduler push each batch of new embeddings to
+// the search index, so OpenSearch can serve kNN queries
+func (s *EmbeddingScheduler) SetSearchIndexer(indexer EmbeddingIndexer) {
+	s.searchIndexer = indexer
+}
+
 // Start begins the periodic embedding generation process
 func (s *EmbeddingScheduler) Start(ctx context.Context) {
 	utils.Info("Starting embedding scheduler", map[string]interface{}{
@@ -58,12 +103,12 @@ func (s *EmbeddingScheduler) Start(ctx context.Context) {
 	defer ticker.Stop()
 
 	// Run initial embedding generation
-	s.runEmbedding(ctx)
+	_ = s.runEmbedding(ctx)
 
 	for {
 		select {
 		case <-ticker.C:
-			s.runEmbedding(ctx)
+			_ = s.runEmbedding(ctx)
 		case <-s.stopChan:
 			utils.Info("Embedding scheduler stopped", map[string]interface{}{
 				"scheduler": embeddingSchedulerName,
@@ -85,8 +130,15 @@ func (s *EmbeddingScheduler) Stop() {
 	})
 }
 
-// runEmbedding executes embedding generation for clips without embeddings
-func (s *EmbeddingScheduler) runEmbedding(ctx context.Context) {
+// RunOnce generates embeddings for one batch of clips. It is used by the job
+// queue worker, which schedules runs itself instead of calling Start.
+func (s *EmbeddingScheduler) RunOnce(ctx context.Context) error {
+	return s.runEmbedding(ctx)
+}
+
+// runEmbedding executes embedding generation for clips without embeddings.
+// Failures for individual clips are logged, so only a failed fetch is returned.
+func (s *EmbeddingScheduler) runEmbedding(ctx context.Context) error {
 	utils.Info("Starting scheduled embedding generation", map[string]interface{}{
 		"scheduler": embeddingSchedulerName,
 		"model":     s.model,
@@ -98,31 +150,24 @@ func (s *EmbeddingScheduler) runEmbedding(ctx context.Context) {
 			"scheduler": embeddingSchedulerName,
 			"model":     s.model,
 		})
-		return
+		return nil
 	}
 
 	startTime := time.Now()
 
-	// Fetch clips without embeddings (created in the last 7 days to avoid old clips)
-	query := `
-		SELECT id, twitch_clip_id, title, creator_name, broadcaster_name,
-		       game_id, game_name
-		FROM clips
-		WHERE is_removed = false
-		  AND embedding IS NULL
-		  AND created_at > NOW() - INTERVAL '7 days'
-		ORDER BY created_at DESC
-		LIMIT 100
-	`
+	if s.dualWrite != nil {
+		s.runDualWrite(ctx)
+	}
 
-	rows, err := s.db.Pool.Query(ctx, query)
+	// Fetch clips without embeddings (created in the last 7 days to avoid old clips)
+	rows, err := s.db.Pool.Query(ctx, recentClipsWithoutEmbeddingQuery(s.column))
 	if err != nil {
 		utils.Error("Failed to fetch clips for embedding", err, map[string]interface{}{
 			"scheduler": embeddingSchedulerName,
 			"model":     s.model,
 		})
 		metrics.IndexingJobsTotal.WithLabelValues("failed").Inc()
-		return
+		return err
 	}
 	defer rows.Close()
 
@@ -154,7 +199,7 @@ func (s *EmbeddingScheduler) runEmbedding(ctx context.Context) {
 		})
 		// Update embedding coverage metrics
 		s.updateEmbeddingCoverageMetrics(ctx)
-		return
+		return nil
 	}
 
 	utils.Info("Generating embeddings for clips", map[string]interface{}{
@@ -163,35 +208,57 @@ func (s *EmbeddingScheduler) runEmbedding(ctx context.Context) {
 		"model":     s.model,
 	})
 
+	// Generate every embedding in batched API requests. Clips whose requests
+	// failed come back without an embedding and are retried next run.
+	embeddings, err := s.embeddingService.GenerateClipEmbeddings(ctx, clips)
+	var batchErr *services.EmbeddingBatchError
+	if err != nil && !errors.As(err, &batchErr) {
+		if errors.Is(err, services.ErrEmbeddingBudgetExceeded) {
+			utils.Warn("Embedding budget exhausted, skipping embedding generation", map[string]interface{}{
+				"scheduler": embeddingSchedulerName,
+				"count":     len(clips),
+				"model":     s.model,
+			})
+			return nil
+		}
+		utils.Error("Failed to generate embeddings for clips", err, map[string]interface{}{
+			"scheduler": embeddingSchedulerName,
+			"count":     len(clips),
+			"model":     s.model,
+		})
+		metrics.IndexingJobsTotal.WithLabelValues("failed").Inc()
+		return err
+	}
+
 	processed := 0
 	failed := 0
+	saved := make(map[uuid.UUID][]float32, len(clips))
+
+	if batchErr != nil {
+		failed = len(batchErr.Failed)
+		fields := map[string]interface{}{
+			"scheduler": embeddingSchedulerName,
+			"count":     len(clips),
+			"failed":    failed,
+			"model":     s.model,
+			"error":     batchErr.Err.Error(),
+		}
+		if errors.Is(err, services.ErrEmbeddingBudgetExceeded) {
+			utils.Warn("Embedding budget exhausted, generated embeddings for part of the clips", fields)
+		} else {
+			utils.Warn("Failed to generate embeddings for some clips", fields)
+		}
+	}
 
 	for i := range clips {
 		clip := &clips[i]
-
-		// Generate embedding
-		embedding, err := s.embeddingService.GenerateClipEmbedding(ctx, clip)
-		if err != nil {
-			utils.Error("Failed to generate embedding for clip", err, map[string]interface{}{
-				"scheduler": embeddingSchedulerName,
-				"clip_id":   clip.ID,
-				"model":     s.model,
-			})
-			failed++
+		embedding := embeddings[i]
+		if embedding == nil {
 			continue
 		}
 
 		// Save to database
-		now := time.Now()
-		updateQuery := `
-			UPDATE clips
-			SET embedding = $1,
-			    embedding_generated_at = $2,
-			    embedding_model = $3
-			WHERE id = $4
-		`
-
-		_, err = s.db.Pool.Exec(ctx, updateQuery, pgvector.NewVector(embedding), now, s.model, clip.ID)
+		err = s.saveEmbedding(ctx, s.column, s.model, clip, embedding)
 		if err != nil {
 			utils.Error("Failed to save embedding for clip", err, map[string]interface{}{
 				"scheduler": embeddingSchedulerName,
@@ -202,9 +269,23 @@ func (s *EmbeddingScheduler) runEmbedding(ctx context.Context) {
 			continue
 		}
 
+		saved[clip.ID] = embedding
 		processed++
 	}
 
+	// The database is the source of truth; a clip whose vector fails to reach
+	// the index keeps it until the next index rebuild. The index holds vectors
+	// of the original column only.
+	if s.searchIndexer != nil && len(saved) > 0 && s.column == models.DefaultClipEmbeddingColumn {
+		if err := s.searchIndexer.UpdateClipEmbeddings(ctx, saved); err != nil {
+			utils.Error("Failed to index clip embeddings", err, map[string]interface{}{
+				"scheduler": embeddingSchedulerName,
+				"count":     len(saved),
+				"model":     s.model,
+			})
+		}
+	}
+
 	duration := time.Since(startTime)
 	utils.Info("Scheduled embedding generation completed", map[string]interface{}{
 		"scheduler": embeddingSchedulerName,
@@ -226,6 +307,8 @@ func (s *EmbeddingScheduler) runEmbedding(ctx context.Context) {
 
 	// Update embedding coverage metrics
 	s.updateEmbeddingCoverageMetrics(ctx)
+
+	return nil
 }
 
 // updateEmbeddingCoverageMetrics updates the Prometheus gauges for embedding coverage
@@ -237,9 +320,9 @@ func (s *EmbeddingScheduler) updateEmbeddingCoverageMetrics(ctx context.Context)
 	var withEmbeddings, withoutEmbeddings int64
 
 	// Count clips with embeddings
-	err := s.db.Pool.QueryRow(ctx, `
-		SELECT COUNT(*) FROM clips WHERE is_removed = false AND embedding IS NOT NULL
-	`).Scan(&withEmbeddings)
+	err := s.db.Pool.QueryRow(ctx, fmt.Sprintf(`
+		SELECT COUNT(*) FROM clips WHERE is_removed = false AND %s IS NOT NULL
+	`, s.column)).Scan(&withEmbeddings)
 	if err != nil {
 		utils.Error("Failed to count clips with embeddings", err, map[string]interface{}{
 			"scheduler": embeddingSchedulerName,
@@ -248,9 +331,9 @@ func (s *EmbeddingScheduler) updateEmbeddingCoverageMetrics(ctx context.Context)
 	}
 
 	// Count clips without embeddings
-	err = s.db.Pool.QueryRow(ctx, `
-		SELECT COUNT(*) FROM clips WHERE is_removed = false AND embedding IS NULL
-	`).Scan(&withoutEmbeddings)
+	err = s.db.Pool.QueryRow(ctx, fmt.Sprintf(`
+		SELECT COUNT(*) FROM clips WHERE is_removed = false AND %s IS NULL
+	`, s.column)).Scan(&withoutEmbeddings)
 	if err != nil {
 		utils.Error("Failed to count clips without embeddings", err, map[string]interface{}{
 			"scheduler": embeddingSchedulerName,
@@ -261,3 +344,110 @@ func (s *EmbeddingScheduler) updateEmbeddingCoverageMetrics(ctx context.Context)
 	metrics.ClipsWithEmbeddings.Set(float64(withEmbeddings))
 	metrics.ClipsWithoutEmbeddings.Set(float64(withoutEmbeddings))
 }
+
+// runDualWrite embeds recent clips into the column an embedding migration is
+// filling. Failures are logged and retried next run; the migration's
+// backfill picks up anything left.
+func (s *EmbeddingScheduler) runDualWrite(ctx context.Context) {
+	target := s.dualWrite
+	fields := func() map[string]interface{} {
+		return map[string]interface{}{
+			"scheduler": embeddingSchedulerName,
+			"column":    target.column,
+			"model":     target.model,
+		}
+	}
+
+	rows, err := s.db.Pool.Query(ctx, recentClipsWithoutEmbeddingQuery(target.column))
+	if err != nil {
+		utils.Error("Failed to fetch clips for dual-write embedding", err, fields())
+		return
+	}
+	clips, err := scanEmbeddingClips(rows)
+	if err != nil {
+		utils.Error("Failed to scan clips for dual-write embedding", err, fields())
+		return
+	}
+	if len(clips) == 0 {
+		return
+	}
+
+	embeddings, err := target.embeddingService.GenerateClipEmbeddings(ctx, clips)
+	var batchErr *services.EmbeddingBatchError
+	if err != nil && !errors.As(err, &batchErr) {
+		utils.Error("Failed to generate dual-write embeddings", err, fields())
+		return
+	}
+
+	saved := 0
+	for i := range clips {
+		if embeddings[i] == nil {
+			continue
+		}
+		if err := s.saveEmbedding(ctx, target.column, target.model, &clips[i], embeddings[i]); err != nil {
+			utils.Error("Failed to save dual-write embedding for clip", err, fields())
+			continue
+		}
+		saved++
+	}
+
+	logFields := fields()
+	logFields["saved"] = saved
+	logFields["count"] = len(clips)
+	utils.Info("Dual-write embedding generation completed", logFields)
+}
+
+// recentClipsWithoutEmbeddingQuery selects clips created in the last 7 days
+// with no embedding in column
+func recentClipsWithoutEmbeddingQuery(column string) string {
+	return fmt.Sprintf(`
+		SELECT id, twitch_clip_id, title, creator_name, broadcaster_name,
+		       game_id, game_name
+		FROM clips
+		WHERE is_removed = false
+		  AND %s IS NULL
+		  AND created_at > NOW() - INTERVAL '7 days'
+		ORDER BY created_at DESC
+		LIMIT 100
+	`, column)
+}
+
+func scanEmbeddingClips(rows pgx.Rows) ([]models.Clip, error) {
+	defer rows.Close()
+	var clips []models.Clip
+	for rows.Next() {
+		var clip models.Clip
+		if err := rows.Scan(
+			&clip.ID,
+			&clip.TwitchClipID,
+			&clip.Title,
+			&clip.CreatorName,
+			&clip.BroadcasterName,
+			&clip.GameID,
+			&clip.GameName,
+		); err != nil {
+			return nil, err
+		}
+		clips = append(clips, clip)
+	}
+	return clips, rows.Err()
+}
+
+// saveEmbedding stores a clip's embedding in column. Only the original
+// column records when and by which model its embedding was generated.
+func (s *EmbeddingScheduler) saveEmbedding(ctx context.Context, column, model string, clip *models.Clip, embedding []float32) error {
+	if column != models.DefaultClipEmbeddingColumn {
+		_, err := s.db.Pool.Exec(ctx, fmt.Sprintf(`UPDATE clips SET %s = $1 WHERE id = $2`, column),
+			pgvector.NewVector(embedding), clip.ID)
+		return err
+	}
+
+	_, err := s.db.Pool.Exec(ctx, `
+		UPDATE clips
+		SET embedding = $1,
+		    embedding_generated_at = $2,
+		    embedding_model = $3
+		WHERE id = $4
+	`, pgvector.NewVector(embedding), time.Now(), model, clip.ID)
+	return err
+}
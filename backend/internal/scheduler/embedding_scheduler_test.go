@@ -5,24 +5,29 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/subculture-collective/clipper/internal/models"
 )
 
 // MockEmbeddingService implements EmbeddingServiceInterface for testing
 type MockEmbeddingService struct {
-	GenerateClipEmbeddingFunc func(ctx context.Context, clip *models.Clip) ([]float32, error)
-	CloseFunc                 func()
-	CallCount                 int
+	GenerateClipEmbeddingsFunc func(ctx context.Context, clips []models.Clip) ([][]float32, error)
+	CloseFunc                  func()
+	CallCount                  int
 }
 
-func (m *MockEmbeddingService) GenerateClipEmbedding(ctx context.Context, clip *models.Clip) ([]float32, error) {
+func (m *MockEmbeddingService) GenerateClipEmbeddings(ctx context.Context, clips []models.Clip) ([][]float32, error) {
 	m.CallCount++
-	if m.GenerateClipEmbeddingFunc != nil {
-		return m.GenerateClipEmbeddingFunc(ctx, clip)
+	if m.GenerateClipEmbeddingsFunc != nil {
+		return m.GenerateClipEmbeddingsFunc(ctx, clips)
 	}
-	// Return a mock embedding
-	return make([]float32, 768), nil
+	// Return mock embeddings
+	embeddings := make([][]float32, len(clips))
+	for i := range embeddings {
+		embeddings[i] = make([]float32, 768)
+	}
+	return embeddings, nil
 }
 
 func (m *MockEmbeddingService) Close() {
@@ -31,6 +36,16 @@ func (m *MockEmbeddingService) Close() {
 	}
 }
 
+// MockEmbeddingIndexer implements EmbeddingIndexer for testing
+type MockEmbeddingIndexer struct {
+	Updates []map[uuid.UUID][]float32
+}
+
+func (m *MockEmbeddingIndexer) UpdateClipEmbeddings(ctx context.Context, embeddings map[uuid.UUID][]float32) error {
+	m.Updates = append(m.Updates, embeddings)
+	return nil
+}
+
 func TestNewEmbeddingScheduler(t *testing.T) {
 	mockService := &MockEmbeddingService{}
 
@@ -87,5 +102,14 @@ func TestRunEmbedding_NilDB(t *testing.T) {
 	scheduler := NewEmbeddingScheduler(nil, mockService, 60, "test-model")
 
 	// Should not panic and should return early with nil db
-	scheduler.runEmbedding(context.Background())
+	assert.NoError(t, scheduler.runEmbedding(context.Background()))
+}
+
+func TestRunEmbedding_NilDBSkipsIndexing(t *testing.T) {
+	indexer := &MockEmbeddingIndexer{}
+	scheduler := NewEmbeddingScheduler(nil, &MockEmbeddingService{}, 60, "test-model")
+	scheduler.SetSearchIndexer(indexer)
+
+	assert.NoError(t, scheduler.runEmbedding(context.Background()))
+	assert.Empty(t, indexer.Updates, "nothing is indexed when no embeddings were generated")
 }
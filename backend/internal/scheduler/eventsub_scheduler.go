@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/subculture-collective/clipper/pkg/metrics"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const eventSubSchedulerName = "eventsub_subscriptions"
+
+// EventSubServiceInterface defines the interface required by the EventSub subscription scheduler
+type EventSubServiceInterface interface {
+	SyncSubscriptions(ctx context.Context) (int, error)
+}
+
+// EventSubScheduler keeps Twitch EventSub subscriptions in place as users
+// follow new broadcasters and subscriptions are revoked
+type EventSubScheduler struct {
+	eventSubService EventSubServiceInterface
+	interval        time.Duration
+	stopChan        chan struct{}
+	stopOnce        sync.Once
+}
+
+// NewEventSubScheduler creates a new EventSub subscription scheduler
+func NewEventSubScheduler(eventSubService EventSubServiceInterface, intervalMinutes int) *EventSubScheduler {
+	return &EventSubScheduler{
+		eventSubService: eventSubService,
+		interval:        time.Duration(intervalMinutes) * time.Minute,
+		stopChan:        make(chan struct{}),
+	}
+}
+
+// Start begins syncing subscriptions periodically
+func (s *EventSubScheduler) Start(ctx context.Context) {
+	utils.Info("Starting EventSub subscription scheduler", map[string]interface{}{
+		"scheduler": eventSubSchedulerName,
+		"interval":  s.interval.String(),
+	})
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run initial pass
+	s.syncSubscriptions(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.syncSubscriptions(ctx)
+		case <-s.stopChan:
+			utils.Info("EventSub subscription scheduler stopped", map[string]interface{}{
+				"scheduler": eventSubSchedulerName,
+			})
+			return
+		case <-ctx.Done():
+			utils.Info("EventSub subscription scheduler stopped due to context cancellation", map[string]interface{}{
+				"scheduler": eventSubSchedulerName,
+			})
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler in a thread-safe manner
+func (s *EventSubScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+}
+
+// syncSubscriptions requests any missing subscriptions
+func (s *EventSubScheduler) syncSubscriptions(ctx context.Context) {
+	start := time.Now()
+	requested, err := s.eventSubService.SyncSubscriptions(ctx)
+	metrics.ObserveJobRun(eventSubSchedulerName, time.Since(start), err)
+	if err != nil {
+		utils.Error("Failed to sync EventSub subscriptions", err, map[string]interface{}{
+			"scheduler": eventSubSchedulerName,
+		})
+		return
+	}
+	utils.Info("Synced EventSub subscriptions", map[string]interface{}{
+		"scheduler": eventSubSchedulerName,
+		"count":     requested,
+	})
+}
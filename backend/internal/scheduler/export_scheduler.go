@@ -7,6 +7,7 @@ import (
 
 	"github.com/subculture-collective/clipper/internal/models"
 	"github.com/subculture-collective/clipper/internal/services"
+	"github.com/subculture-collective/clipper/pkg/metrics"
 	"github.com/subculture-collective/clipper/pkg/utils"
 )
 
@@ -51,12 +52,12 @@ func (s *ExportScheduler) Start(ctx context.Context) {
 	defer ticker.Stop()
 
 	// Run initial processing
-	s.processExports(ctx)
+	_ = s.processExports(ctx)
 
 	for {
 		select {
 		case <-ticker.C:
-			s.processExports(ctx)
+			_ = s.processExports(ctx)
 		case <-s.stopChan:
 			logger.Info("Export scheduler stopped", map[string]interface{}{
 				"scheduler": exportSchedulerName,
@@ -78,8 +79,19 @@ func (s *ExportScheduler) Stop() {
 	})
 }
 
-// processExports processes pending export requests
-func (s *ExportScheduler) processExports(ctx context.Context) {
+// RunOnce processes one batch of pending export requests. It is used by the
+// job queue worker, which schedules runs itself instead of calling Start.
+func (s *ExportScheduler) RunOnce(ctx context.Context) error {
+	return s.processExports(ctx)
+}
+
+// processExports processes pending export requests. Failures of individual
+// requests are recorded on the request, so only a failed fetch is returned.
+func (s *ExportScheduler) processExports(ctx context.Context) (err error) {
+	defer func(start time.Time) {
+		metrics.ObserveJobRun(exportSchedulerName, time.Since(start), err)
+	}(time.Now())
+
 	logger := utils.GetLogger()
 	logger.Debug("Processing pending export requests", map[string]interface{}{
 		"scheduler": exportSchedulerName,
@@ -92,14 +104,14 @@ func (s *ExportScheduler) processExports(ctx context.Context) {
 			"scheduler":  exportSchedulerName,
 			"batch_size": s.batchSize,
 		})
-		return
+		return err
 	}
 
 	if len(requests) == 0 {
 		logger.Debug("No pending export requests to process", map[string]interface{}{
 			"scheduler": exportSchedulerName,
 		})
-		return
+		return nil
 	}
 
 	logger.Info("Processing pending export requests", map[string]interface{}{
@@ -126,6 +138,10 @@ func (s *ExportScheduler) processExports(ctx context.Context) {
 		go func() {
 			defer wg.Done()
 			for req := range requestCh {
+				// Shutting down: leave the rest pending for the next run
+				if ctx.Err() != nil {
+					continue
+				}
 				if err := s.exportService.ProcessExportRequest(ctx, req); err != nil {
 					logger.Error("Failed to process export request", err, map[string]interface{}{
 						"scheduler": exportSchedulerName,
@@ -158,4 +174,6 @@ func (s *ExportScheduler) processExports(ctx context.Context) {
 			"scheduler": exportSchedulerName,
 		})
 	}
+
+	return nil
 }
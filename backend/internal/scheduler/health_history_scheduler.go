@@ -0,0 +1,85 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/subculture-collective/clipper/pkg/metrics"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const healthHistorySchedulerName = "health_history"
+
+// HealthHistoryServiceInterface defines the interface required by the health history scheduler
+type HealthHistoryServiceInterface interface {
+	ProbeAll(ctx context.Context) (int, error)
+}
+
+// HealthHistoryScheduler probes dependencies and records endpoint group
+// traffic periodically for uptime reporting
+type HealthHistoryScheduler struct {
+	healthService HealthHistoryServiceInterface
+	interval      time.Duration
+	stopChan      chan struct{}
+	stopOnce      sync.Once
+}
+
+// NewHealthHistoryScheduler creates a new health history scheduler
+func NewHealthHistoryScheduler(healthService HealthHistoryServiceInterface, intervalSeconds int) *HealthHistoryScheduler {
+	return &HealthHistoryScheduler{
+		healthService: healthService,
+		interval:      time.Duration(intervalSeconds) * time.Second,
+		stopChan:      make(chan struct{}),
+	}
+}
+
+// Start begins probing periodically
+func (s *HealthHistoryScheduler) Start(ctx context.Context) {
+	utils.Info("Starting health history scheduler", map[string]interface{}{
+		"scheduler": healthHistorySchedulerName,
+		"interval":  s.interval.String(),
+	})
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run initial pass
+	s.probe(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.probe(ctx)
+		case <-s.stopChan:
+			utils.Info("Health history scheduler stopped", map[string]interface{}{
+				"scheduler": healthHistorySchedulerName,
+			})
+			return
+		case <-ctx.Done():
+			utils.Info("Health history scheduler stopped due to context cancellation", map[string]interface{}{
+				"scheduler": healthHistorySchedulerName,
+			})
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler in a thread-safe manner
+func (s *HealthHistoryScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+}
+
+// probe records one round of health probes
+func (s *HealthHistoryScheduler) probe(ctx context.Context) {
+	start := time.Now()
+	_, err := s.healthService.ProbeAll(ctx)
+	metrics.ObserveJobRun(healthHistorySchedulerName, time.Since(start), err)
+	if err != nil {
+		utils.Error("Failed to record health probes", err, map[string]interface{}{
+			"scheduler": healthHistorySchedulerName,
+		})
+	}
+}
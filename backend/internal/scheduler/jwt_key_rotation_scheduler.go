@@ -0,0 +1,110 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const jwtKeyRotationSchedulerName = "jwt_key_rotation"
+
+// JWTKeyServiceInterface defines the interface required by the JWT key rotation scheduler
+type JWTKeyServiceInterface interface {
+	RotateIfDue(ctx context.Context) (bool, error)
+	Sync(ctx context.Context) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+// JWTKeyRotationScheduler rotates JWT signing keys when due and keeps every
+// instance's key set in sync with the shared key store
+type JWTKeyRotationScheduler struct {
+	jwtKeyService JWTKeyServiceInterface
+	interval      time.Duration
+	stopChan      chan struct{}
+	stopOnce      sync.Once
+}
+
+// NewJWTKeyRotationScheduler creates a new JWT key rotation scheduler
+func NewJWTKeyRotationScheduler(jwtKeyService JWTKeyServiceInterface, intervalMinutes int) *JWTKeyRotationScheduler {
+	return &JWTKeyRotationScheduler{
+		jwtKeyService: jwtKeyService,
+		interval:      time.Duration(intervalMinutes) * time.Minute,
+		stopChan:      make(chan struct{}),
+	}
+}
+
+// Start begins the periodic rotation and sync process
+func (s *JWTKeyRotationScheduler) Start(ctx context.Context) {
+	utils.Info("Starting JWT key rotation scheduler", map[string]interface{}{
+		"scheduler": jwtKeyRotationSchedulerName,
+		"interval":  s.interval.String(),
+	})
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run initial check
+	s.rotateAndSync(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.rotateAndSync(ctx)
+		case <-s.stopChan:
+			utils.Info("JWT key rotation scheduler stopped", map[string]interface{}{
+				"scheduler": jwtKeyRotationSchedulerName,
+			})
+			return
+		case <-ctx.Done():
+			utils.Info("JWT key rotation scheduler stopped due to context cancellation", map[string]interface{}{
+				"scheduler": jwtKeyRotationSchedulerName,
+			})
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler in a thread-safe manner
+func (s *JWTKeyRotationScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+}
+
+// rotateAndSync rotates the signing key if due, then reloads the shared key set
+func (s *JWTKeyRotationScheduler) rotateAndSync(ctx context.Context) {
+	rotated, err := s.jwtKeyService.RotateIfDue(ctx)
+	if err != nil {
+		utils.Error("JWT key rotation failed", err, map[string]interface{}{
+			"scheduler": jwtKeyRotationSchedulerName,
+		})
+	} else if rotated {
+		utils.Info("JWT signing key rotated", map[string]interface{}{
+			"scheduler": jwtKeyRotationSchedulerName,
+		})
+	}
+
+	// Sync even after a failed rotation so keys rotated by other instances are picked up
+	if err := s.jwtKeyService.Sync(ctx); err != nil {
+		utils.Error("JWT key sync failed", err, map[string]interface{}{
+			"scheduler": jwtKeyRotationSchedulerName,
+		})
+		return
+	}
+
+	deleted, err := s.jwtKeyService.DeleteExpired(ctx)
+	if err != nil {
+		utils.Error("Failed to delete expired JWT keys", err, map[string]interface{}{
+			"scheduler": jwtKeyRotationSchedulerName,
+		})
+		return
+	}
+	if deleted > 0 {
+		utils.Info("Deleted expired JWT signing keys", map[string]interface{}{
+			"scheduler": jwtKeyRotationSchedulerName,
+			"count":     deleted,
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/subculture-collective/clipper/pkg/metrics"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const karmaSafeguardSchedulerName = "karma_safeguards"
+
+// KarmaSafeguardServiceInterface defines the interface required by the karma safeguard scheduler
+type KarmaSafeguardServiceInterface interface {
+	DecayInactiveKarma(ctx context.Context) (int, error)
+	DetectVoteRings(ctx context.Context) (int, error)
+}
+
+// KarmaSafeguardScheduler decays inactive accounts' karma and flags vote
+// rings for review
+type KarmaSafeguardScheduler struct {
+	karmaSafeguardService KarmaSafeguardServiceInterface
+	interval              time.Duration
+	stopChan              chan struct{}
+	stopOnce              sync.Once
+}
+
+// NewKarmaSafeguardScheduler creates a new karma safeguard scheduler
+func NewKarmaSafeguardScheduler(karmaSafeguardService KarmaSafeguardServiceInterface, intervalMinutes int) *KarmaSafeguardScheduler {
+	return &KarmaSafeguardScheduler{
+		karmaSafeguardService: karmaSafeguardService,
+		interval:              time.Duration(intervalMinutes) * time.Minute,
+		stopChan:              make(chan struct{}),
+	}
+}
+
+// Start begins running karma decay and vote ring detection periodically
+func (s *KarmaSafeguardScheduler) Start(ctx context.Context) {
+	utils.Info("Starting karma safeguard scheduler", map[string]interface{}{
+		"scheduler": karmaSafeguardSchedulerName,
+		"interval":  s.interval.String(),
+	})
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run initial pass
+	s.run(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.run(ctx)
+		case <-s.stopChan:
+			utils.Info("Karma safeguard scheduler stopped", map[string]interface{}{
+				"scheduler": karmaSafeguardSchedulerName,
+			})
+			return
+		case <-ctx.Done():
+			utils.Info("Karma safeguard scheduler stopped due to context cancellation", map[string]interface{}{
+				"scheduler": karmaSafeguardSchedulerName,
+			})
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler in a thread-safe manner
+func (s *KarmaSafeguardScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+}
+
+func (s *KarmaSafeguardScheduler) run(ctx context.Context) {
+	start := time.Now()
+
+	decayed, err := s.karmaSafeguardService.DecayInactiveKarma(ctx)
+	if err != nil {
+		utils.Error("Failed to decay inactive karma", err, map[string]interface{}{
+			"scheduler": karmaSafeguardSchedulerName,
+		})
+	}
+
+	flagged, detectErr := s.karmaSafeguardService.DetectVoteRings(ctx)
+	if detectErr != nil {
+		utils.Error("Failed to detect vote rings", detectErr, map[string]interface{}{
+			"scheduler": karmaSafeguardSchedulerName,
+		})
+		if err == nil {
+			err = detectErr
+		}
+	}
+
+	metrics.ObserveJobRun(karmaSafeguardSchedulerName, time.Since(start), err)
+	if decayed > 0 || flagged > 0 {
+		utils.Info("Karma safeguards completed", map[string]interface{}{
+			"scheduler": karmaSafeguardSchedulerName,
+			"decayed":   decayed,
+			"flagged":   flagged,
+		})
+	}
+}
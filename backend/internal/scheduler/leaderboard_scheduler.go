@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/subculture-collective/clipper/pkg/metrics"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const leaderboardSchedulerName = "leaderboard"
+
+// LeaderboardServiceInterface defines the interface required by the leaderboard scheduler
+type LeaderboardServiceInterface interface {
+	RefreshSnapshots(ctx context.Context) (int, error)
+}
+
+// LeaderboardScheduler periodically recomputes the weekly, monthly and
+// seasonal leaderboard snapshots, finalizes ended periods and awards
+// season-end badges
+type LeaderboardScheduler struct {
+	leaderboardService LeaderboardServiceInterface
+	interval           time.Duration
+	stopChan           chan struct{}
+	stopOnce           sync.Once
+}
+
+// NewLeaderboardScheduler creates a new leaderboard scheduler
+func NewLeaderboardScheduler(leaderboardService LeaderboardServiceInterface, intervalMinutes int) *LeaderboardScheduler {
+	return &LeaderboardScheduler{
+		leaderboardService: leaderboardService,
+		interval:           time.Duration(intervalMinutes) * time.Minute,
+		stopChan:           make(chan struct{}),
+	}
+}
+
+// Start begins computing leaderboard snapshots periodically
+func (s *LeaderboardScheduler) Start(ctx context.Context) {
+	utils.Info("Starting leaderboard scheduler", map[string]interface{}{
+		"scheduler": leaderboardSchedulerName,
+		"interval":  s.interval.String(),
+	})
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run initial pass
+	s.run(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.run(ctx)
+		case <-s.stopChan:
+			utils.Info("Leaderboard scheduler stopped", map[string]interface{}{
+				"scheduler": leaderboardSchedulerName,
+			})
+			return
+		case <-ctx.Done():
+			utils.Info("Leaderboard scheduler stopped due to context cancellation", map[string]interface{}{
+				"scheduler": leaderboardSchedulerName,
+			})
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler in a thread-safe manner
+func (s *LeaderboardScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+}
+
+func (s *LeaderboardScheduler) run(ctx context.Context) {
+	start := time.Now()
+
+	saved, err := s.leaderboardService.RefreshSnapshots(ctx)
+	metrics.ObserveJobRun(leaderboardSchedulerName, time.Since(start), err)
+	if err != nil {
+		utils.Error("Failed to refresh leaderboard snapshots", err, map[string]interface{}{
+			"scheduler": leaderboardSchedulerName,
+		})
+		return
+	}
+
+	if saved > 0 {
+		utils.Debug("Leaderboard snapshots refreshed", map[string]interface{}{
+			"scheduler": leaderboardSchedulerName,
+			"saved":     saved,
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/subculture-collective/clipper/pkg/metrics"
 	"github.com/subculture-collective/clipper/pkg/utils"
 )
 
@@ -109,7 +110,9 @@ func (s *MirrorScheduler) syncMirrors(ctx context.Context) {
 	})
 	start := time.Now()
 
-	if err := s.mirrorService.SyncPopularClips(ctx); err != nil {
+	err := s.mirrorService.SyncPopularClips(ctx)
+	metrics.ObserveJobRun(mirrorSchedulerName+"_sync", time.Since(start), err)
+	if err != nil {
 		utils.Error("Mirror sync failed", err, map[string]interface{}{
 			"scheduler": mirrorSchedulerName,
 			"task":      "sync",
@@ -134,6 +137,7 @@ func (s *MirrorScheduler) cleanupMirrors(ctx context.Context) {
 	start := time.Now()
 
 	count, err := s.mirrorService.CleanupExpiredMirrors(ctx)
+	metrics.ObserveJobRun(mirrorSchedulerName+"_cleanup", time.Since(start), err)
 	if err != nil {
 		utils.Error("Mirror cleanup failed", err, map[string]interface{}{
 			"scheduler": mirrorSchedulerName,
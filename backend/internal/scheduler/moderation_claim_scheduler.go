@@ -0,0 +1,91 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/subculture-collective/clipper/pkg/metrics"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const moderationClaimSchedulerName = "moderation_claim_expiry"
+
+// ModerationClaimServiceInterface defines the interface required by the moderation claim scheduler
+type ModerationClaimServiceInterface interface {
+	ReleaseExpiredClaims(ctx context.Context) (int, error)
+}
+
+// ModerationClaimScheduler releases moderation claims that weren't resolved
+// before they expired, putting their items back in the queue
+type ModerationClaimScheduler struct {
+	claimService ModerationClaimServiceInterface
+	interval     time.Duration
+	stopChan     chan struct{}
+	stopOnce     sync.Once
+}
+
+// NewModerationClaimScheduler creates a new moderation claim scheduler
+func NewModerationClaimScheduler(claimService ModerationClaimServiceInterface, intervalMinutes int) *ModerationClaimScheduler {
+	return &ModerationClaimScheduler{
+		claimService: claimService,
+		interval:     time.Duration(intervalMinutes) * time.Minute,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Start begins releasing expired claims periodically
+func (s *ModerationClaimScheduler) Start(ctx context.Context) {
+	utils.Info("Starting moderation claim scheduler", map[string]interface{}{
+		"scheduler": moderationClaimSchedulerName,
+		"interval":  s.interval.String(),
+	})
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run initial pass
+	s.releaseExpired(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.releaseExpired(ctx)
+		case <-s.stopChan:
+			utils.Info("Moderation claim scheduler stopped", map[string]interface{}{
+				"scheduler": moderationClaimSchedulerName,
+			})
+			return
+		case <-ctx.Done():
+			utils.Info("Moderation claim scheduler stopped due to context cancellation", map[string]interface{}{
+				"scheduler": moderationClaimSchedulerName,
+			})
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler in a thread-safe manner
+func (s *ModerationClaimScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+}
+
+func (s *ModerationClaimScheduler) releaseExpired(ctx context.Context) {
+	start := time.Now()
+	released, err := s.claimService.ReleaseExpiredClaims(ctx)
+	metrics.ObserveJobRun(moderationClaimSchedulerName, time.Since(start), err)
+	if err != nil {
+		utils.Error("Failed to release expired moderation claims", err, map[string]interface{}{
+			"scheduler": moderationClaimSchedulerName,
+		})
+		return
+	}
+	if released > 0 {
+		utils.Info("Released expired moderation claims", map[string]interface{}{
+			"scheduler": moderationClaimSchedulerName,
+			"count":     released,
+		})
+	}
+}
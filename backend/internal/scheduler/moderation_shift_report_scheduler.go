@@ -0,0 +1,92 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/subculture-collective/clipper/pkg/metrics"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const moderationShiftReportSchedulerName = "moderation_shift_report"
+
+// ModerationShiftReportServiceInterface defines the interface required by the moderation shift report scheduler
+type ModerationShiftReportServiceInterface interface {
+	SendDueReports(ctx context.Context) (int, error)
+}
+
+// ModerationShiftReportScheduler emails end-of-shift handoff reports once
+// each moderation shift has ended
+type ModerationShiftReportScheduler struct {
+	shiftService ModerationShiftReportServiceInterface
+	interval     time.Duration
+	stopChan     chan struct{}
+	stopOnce     sync.Once
+}
+
+// NewModerationShiftReportScheduler creates a new moderation shift report scheduler
+func NewModerationShiftReportScheduler(shiftService ModerationShiftReportServiceInterface, intervalMinutes int) *ModerationShiftReportScheduler {
+	return &ModerationShiftReportScheduler{
+		shiftService: shiftService,
+		interval:     time.Duration(intervalMinutes) * time.Minute,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Start begins sending due shift reports periodically
+func (s *ModerationShiftReportScheduler) Start(ctx context.Context) {
+	utils.Info("Starting moderation shift report scheduler", map[string]interface{}{
+		"scheduler": moderationShiftReportSchedulerName,
+		"interval":  s.interval.String(),
+	})
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run initial send
+	s.sendDue(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.sendDue(ctx)
+		case <-s.stopChan:
+			utils.Info("Moderation shift report scheduler stopped", map[string]interface{}{
+				"scheduler": moderationShiftReportSchedulerName,
+			})
+			return
+		case <-ctx.Done():
+			utils.Info("Moderation shift report scheduler stopped due to context cancellation", map[string]interface{}{
+				"scheduler": moderationShiftReportSchedulerName,
+			})
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler in a thread-safe manner
+func (s *ModerationShiftReportScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+}
+
+// sendDue emails the reports of shifts that ended since their last report
+func (s *ModerationShiftReportScheduler) sendDue(ctx context.Context) {
+	start := time.Now()
+	sent, err := s.shiftService.SendDueReports(ctx)
+	metrics.ObserveJobRun(moderationShiftReportSchedulerName, time.Since(start), err)
+	if err != nil {
+		utils.Error("Failed to send moderation shift reports", err, map[string]interface{}{
+			"scheduler": moderationShiftReportSchedulerName,
+		})
+		return
+	}
+	if sent > 0 {
+		utils.Info("Sent moderation shift reports", map[string]interface{}{
+			"scheduler": moderationShiftReportSchedulerName,
+			"count":     sent,
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/pkg/metrics"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const notificationDigestSchedulerName = "notification_digest"
+
+// NotificationDigestServiceInterface defines the interface required by the notification digest scheduler
+type NotificationDigestServiceInterface interface {
+	SendDueDigests(ctx context.Context, frequency string, now time.Time) (int, error)
+}
+
+// NotificationDigestScheduler sends daily and weekly notification digest emails
+// once their period has ended
+type NotificationDigestScheduler struct {
+	digestService NotificationDigestServiceInterface
+	interval      time.Duration
+	stopChan      chan struct{}
+	stopOnce      sync.Once
+}
+
+// NewNotificationDigestScheduler creates a new notification digest scheduler
+func NewNotificationDigestScheduler(digestService NotificationDigestServiceInterface, intervalMinutes int) *NotificationDigestScheduler {
+	return &NotificationDigestScheduler{
+		digestService: digestService,
+		interval:      time.Duration(intervalMinutes) * time.Minute,
+		stopChan:      make(chan struct{}),
+	}
+}
+
+// Start begins sending due digests periodically
+func (s *NotificationDigestScheduler) Start(ctx context.Context) {
+	utils.Info("Starting notification digest scheduler", map[string]interface{}{
+		"scheduler": notificationDigestSchedulerName,
+		"interval":  s.interval.String(),
+	})
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run initial send
+	s.sendDue(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.sendDue(ctx)
+		case <-s.stopChan:
+			utils.Info("Notification digest scheduler stopped", map[string]interface{}{
+				"scheduler": notificationDigestSchedulerName,
+			})
+			return
+		case <-ctx.Done():
+			utils.Info("Notification digest scheduler stopped due to context cancellation", map[string]interface{}{
+				"scheduler": notificationDigestSchedulerName,
+			})
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler in a thread-safe manner
+func (s *NotificationDigestScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+}
+
+// sendDue sends the daily and weekly digests that are due. Each run handles a
+// batch of users, so large periods are worked through over several runs.
+func (s *NotificationDigestScheduler) sendDue(ctx context.Context) {
+	now := time.Now()
+	for _, frequency := range []string{models.EmailDigestDaily, models.EmailDigestWeekly} {
+		start := time.Now()
+		sent, err := s.digestService.SendDueDigests(ctx, frequency, now)
+		metrics.ObserveJobRun(notificationDigestSchedulerName+"_"+frequency, time.Since(start), err)
+		if err != nil {
+			utils.Error("Failed to send notification digests", err, map[string]interface{}{
+				"scheduler": notificationDigestSchedulerName,
+				"frequency": frequency,
+			})
+			continue
+		}
+		if sent > 0 {
+			utils.Info("Sent notification digests", map[string]interface{}{
+				"scheduler": notificationDigestSchedulerName,
+				"frequency": frequency,
+				"count":     sent,
+			})
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/subculture-collective/clipper/internal/services"
+	"github.com/subculture-collective/clipper/pkg/metrics"
 	"github.com/subculture-collective/clipper/pkg/utils"
 )
 
@@ -69,7 +70,10 @@ func (s *OutboundWebhookScheduler) Stop() {
 
 // processDeliveries processes pending webhook deliveries
 func (s *OutboundWebhookScheduler) processDeliveries(ctx context.Context) {
-	if err := s.webhookService.ProcessPendingDeliveries(ctx, s.batchSize); err != nil {
+	start := time.Now()
+	err := s.webhookService.ProcessPendingDeliveries(ctx, s.batchSize)
+	metrics.ObserveJobRun("outbound_webhook", time.Since(start), err)
+	if err != nil {
 		utils.Error("Error processing outbound webhook deliveries", err, map[string]interface{}{
 			"batch_size": s.batchSize,
 			"scheduler":  "outbound_webhook",
@@ -0,0 +1,91 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/subculture-collective/clipper/pkg/metrics"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const profileViewRollupSchedulerName = "profile_view_rollup"
+
+// ProfileViewRollupServiceInterface defines the interface required by the profile view rollup scheduler
+type ProfileViewRollupServiceInterface interface {
+	RollupDaily(ctx context.Context) (int, error)
+}
+
+// ProfileViewRollupScheduler stores daily profile view counts from Redis in daily_analytics
+type ProfileViewRollupScheduler struct {
+	profileViewService ProfileViewRollupServiceInterface
+	interval           time.Duration
+	stopChan           chan struct{}
+	stopOnce           sync.Once
+}
+
+// NewProfileViewRollupScheduler creates a new profile view rollup scheduler
+func NewProfileViewRollupScheduler(profileViewService ProfileViewRollupServiceInterface, intervalMinutes int) *ProfileViewRollupScheduler {
+	return &ProfileViewRollupScheduler{
+		profileViewService: profileViewService,
+		interval:           time.Duration(intervalMinutes) * time.Minute,
+		stopChan:           make(chan struct{}),
+	}
+}
+
+// Start begins rolling up profile views periodically
+func (s *ProfileViewRollupScheduler) Start(ctx context.Context) {
+	utils.Info("Starting profile view rollup scheduler", map[string]interface{}{
+		"scheduler": profileViewRollupSchedulerName,
+		"interval":  s.interval.String(),
+	})
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run initial pass
+	s.rollup(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.rollup(ctx)
+		case <-s.stopChan:
+			utils.Info("Profile view rollup scheduler stopped", map[string]interface{}{
+				"scheduler": profileViewRollupSchedulerName,
+			})
+			return
+		case <-ctx.Done():
+			utils.Info("Profile view rollup scheduler stopped due to context cancellation", map[string]interface{}{
+				"scheduler": profileViewRollupSchedulerName,
+			})
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler in a thread-safe manner
+func (s *ProfileViewRollupScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+}
+
+// rollup stores yesterday's and today's counts of the profiles viewed on them
+func (s *ProfileViewRollupScheduler) rollup(ctx context.Context) {
+	start := time.Now()
+	rolledUp, err := s.profileViewService.RollupDaily(ctx)
+	metrics.ObserveJobRun(profileViewRollupSchedulerName, time.Since(start), err)
+	if err != nil {
+		utils.Error("Failed to roll up profile views", err, map[string]interface{}{
+			"scheduler": profileViewRollupSchedulerName,
+		})
+		return
+	}
+	if rolledUp > 0 {
+		utils.Info("Rolled up profile views", map[string]interface{}{
+			"scheduler": profileViewRollupSchedulerName,
+			"count":     rolledUp,
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/subculture-collective/clipper/pkg/metrics"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const reengagementSchedulerName = "reengagement"
+
+// ReengagementServiceInterface defines the interface required by the re-engagement scheduler
+type ReengagementServiceInterface interface {
+	SendDue(ctx context.Context, now time.Time) (int, error)
+}
+
+// ReengagementScheduler emails inactive users when they reach an inactivity milestone
+type ReengagementScheduler struct {
+	reengagementService ReengagementServiceInterface
+	interval            time.Duration
+	stopChan            chan struct{}
+	stopOnce            sync.Once
+}
+
+// NewReengagementScheduler creates a new re-engagement scheduler
+func NewReengagementScheduler(reengagementService ReengagementServiceInterface, intervalMinutes int) *ReengagementScheduler {
+	return &ReengagementScheduler{
+		reengagementService: reengagementService,
+		interval:            time.Duration(intervalMinutes) * time.Minute,
+		stopChan:            make(chan struct{}),
+	}
+}
+
+// Start begins sending due re-engagement emails periodically
+func (s *ReengagementScheduler) Start(ctx context.Context) {
+	utils.Info("Starting re-engagement scheduler", map[string]interface{}{
+		"scheduler": reengagementSchedulerName,
+		"interval":  s.interval.String(),
+	})
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run initial send
+	s.sendDue(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.sendDue(ctx)
+		case <-s.stopChan:
+			utils.Info("Re-engagement scheduler stopped", map[string]interface{}{
+				"scheduler": reengagementSchedulerName,
+			})
+			return
+		case <-ctx.Done():
+			utils.Info("Re-engagement scheduler stopped due to context cancellation", map[string]interface{}{
+				"scheduler": reengagementSchedulerName,
+			})
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler in a thread-safe manner
+func (s *ReengagementScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+}
+
+// sendDue sends the re-engagement emails that are due. Each run handles a
+// batch of users, so a backlog is worked through over several runs.
+func (s *ReengagementScheduler) sendDue(ctx context.Context) {
+	start := time.Now()
+	sent, err := s.reengagementService.SendDue(ctx, start)
+	metrics.ObserveJobRun(reengagementSchedulerName, time.Since(start), err)
+	if err != nil {
+		utils.Error("Failed to send re-engagement emails", err, map[string]interface{}{
+			"scheduler": reengagementSchedulerName,
+		})
+		return
+	}
+	if sent > 0 {
+		utils.Info("Sent re-engagement emails", map[string]interface{}{
+			"scheduler": reengagementSchedulerName,
+			"count":     sent,
+		})
+	}
+}
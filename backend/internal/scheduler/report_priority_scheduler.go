@@ -0,0 +1,93 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/subculture-collective/clipper/pkg/metrics"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const reportPrioritySchedulerName = "report_priority"
+
+// ReportQueueServiceInterface defines the interface required by the report priority scheduler
+type ReportQueueServiceInterface interface {
+	RefreshPriorities(ctx context.Context) (int, error)
+}
+
+// ReportPriorityScheduler periodically rescores open report groups so their
+// priority follows the reported content's reach and the reporters' trust
+type ReportPriorityScheduler struct {
+	reportQueueService ReportQueueServiceInterface
+	interval           time.Duration
+	stopChan           chan struct{}
+	stopOnce           sync.Once
+}
+
+// NewReportPriorityScheduler creates a new report priority scheduler
+func NewReportPriorityScheduler(reportQueueService ReportQueueServiceInterface, intervalMinutes int) *ReportPriorityScheduler {
+	return &ReportPriorityScheduler{
+		reportQueueService: reportQueueService,
+		interval:           time.Duration(intervalMinutes) * time.Minute,
+		stopChan:           make(chan struct{}),
+	}
+}
+
+// Start begins rescoring report groups periodically
+func (s *ReportPriorityScheduler) Start(ctx context.Context) {
+	utils.Info("Starting report priority scheduler", map[string]interface{}{
+		"scheduler": reportPrioritySchedulerName,
+		"interval":  s.interval.String(),
+	})
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run initial pass
+	s.run(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.run(ctx)
+		case <-s.stopChan:
+			utils.Info("Report priority scheduler stopped", map[string]interface{}{
+				"scheduler": reportPrioritySchedulerName,
+			})
+			return
+		case <-ctx.Done():
+			utils.Info("Report priority scheduler stopped due to context cancellation", map[string]interface{}{
+				"scheduler": reportPrioritySchedulerName,
+			})
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler in a thread-safe manner
+func (s *ReportPriorityScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+}
+
+func (s *ReportPriorityScheduler) run(ctx context.Context) {
+	start := time.Now()
+
+	scored, err := s.reportQueueService.RefreshPriorities(ctx)
+	metrics.ObserveJobRun(reportPrioritySchedulerName, time.Since(start), err)
+	if err != nil {
+		utils.Error("Failed to refresh report priorities", err, map[string]interface{}{
+			"scheduler": reportPrioritySchedulerName,
+		})
+		return
+	}
+
+	if scored > 0 {
+		utils.Debug("Report priorities refreshed", map[string]interface{}{
+			"scheduler": reportPrioritySchedulerName,
+			"scored":    scored,
+		})
+	}
+}
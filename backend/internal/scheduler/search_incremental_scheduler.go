@@ -0,0 +1,122 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/subculture-collective/clipper/internal/services"
+	"github.com/subculture-collective/clipper/pkg/metrics"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const searchIncrementalSchedulerName = "search_incremental"
+
+// SearchIncrementalScheduler periodically reindexes search documents changed
+// since the last run and applies the index retention policy
+type SearchIncrementalScheduler struct {
+	rebuildService *services.IndexRebuildService
+	config         *services.RebuildConfig
+	interval       time.Duration
+	stopChan       chan struct{}
+	stopOnce       sync.Once
+}
+
+// NewSearchIncrementalScheduler creates a new incremental reindex scheduler
+func NewSearchIncrementalScheduler(rebuildService *services.IndexRebuildService, intervalMinutes int) *SearchIncrementalScheduler {
+	config := services.DefaultRebuildConfig()
+	config.Verbose = false
+	return &SearchIncrementalScheduler{
+		rebuildService: rebuildService,
+		config:         config,
+		interval:       time.Duration(intervalMinutes) * time.Minute,
+		stopChan:       make(chan struct{}),
+	}
+}
+
+// Start begins periodic incremental reindexing
+func (s *SearchIncrementalScheduler) Start(ctx context.Context) {
+	logger := utils.GetLogger()
+	logger.Info("Starting search incremental scheduler", map[string]interface{}{
+		"scheduler": searchIncrementalSchedulerName,
+		"interval":  s.interval.String(),
+	})
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run initial sync
+	_ = s.reindex(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			_ = s.reindex(ctx)
+		case <-s.stopChan:
+			logger.Info("Search incremental scheduler stopped", map[string]interface{}{
+				"scheduler": searchIncrementalSchedulerName,
+			})
+			return
+		case <-ctx.Done():
+			logger.Info("Search incremental scheduler stopped due to context cancellation", map[string]interface{}{
+				"scheduler": searchIncrementalSchedulerName,
+			})
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler in a thread-safe manner
+func (s *SearchIncrementalScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+}
+
+// RunOnce incrementally reindexes all search indices once. It is used by the
+// job queue worker, which schedules runs itself instead of calling Start.
+func (s *SearchIncrementalScheduler) RunOnce(ctx context.Context) error {
+	return s.reindex(ctx)
+}
+
+// reindex incrementally reindexes all search indices. An index that fails
+// keeps its checkpoint and is retried on the next run.
+func (s *SearchIncrementalScheduler) reindex(ctx context.Context) (err error) {
+	defer func(start time.Time) {
+		metrics.ObserveJobRun(searchIncrementalSchedulerName, time.Since(start), err)
+	}(time.Now())
+
+	logger := utils.GetLogger()
+	result, err := s.rebuildService.IncrementalReindexAll(ctx, s.config)
+	if err != nil {
+		logger.Error("Failed to incrementally reindex search indices", err, map[string]interface{}{
+			"scheduler": searchIncrementalSchedulerName,
+		})
+		return err
+	}
+
+	for _, index := range result.Results {
+		if index.Error != "" {
+			continue
+		}
+		logger.Info("Incrementally reindexed search index", map[string]interface{}{
+			"scheduler":       searchIncrementalSchedulerName,
+			"index":           index.BaseIndex,
+			"docs_indexed":    index.DocsIndexed,
+			"docs_deleted":    index.DocsDeleted,
+			"deleted_indices": index.DeletedIndices,
+			"duration":        index.Duration.String(),
+		})
+	}
+
+	if !result.Success {
+		err = errors.New(strings.Join(result.Errors, "; "))
+		logger.Error("Incremental reindex completed with errors", err, map[string]interface{}{
+			"scheduler": searchIncrementalSchedulerName,
+		})
+		return err
+	}
+	return nil
+}
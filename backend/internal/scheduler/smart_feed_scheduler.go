@@ -0,0 +1,95 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/subculture-collective/clipper/pkg/metrics"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const smartFeedSchedulerName = "smart_feeds"
+
+// SmartFeedServiceInterface defines the interface required by the smart feed scheduler
+type SmartFeedServiceInterface interface {
+	MaterializeSmartFeeds(ctx context.Context, staleBefore time.Time) (int, error)
+}
+
+// SmartFeedScheduler periodically materializes smart feeds, adding newly
+// matching clips and dropping clips that no longer match their rules. Feeds
+// whose rules changed are picked up on the next run.
+type SmartFeedScheduler struct {
+	feedService SmartFeedServiceInterface
+	interval    time.Duration
+	stopChan    chan struct{}
+	stopOnce    sync.Once
+}
+
+// NewSmartFeedScheduler creates a new smart feed scheduler
+func NewSmartFeedScheduler(feedService SmartFeedServiceInterface, intervalMinutes int) *SmartFeedScheduler {
+	return &SmartFeedScheduler{
+		feedService: feedService,
+		interval:    time.Duration(intervalMinutes) * time.Minute,
+		stopChan:    make(chan struct{}),
+	}
+}
+
+// Start begins materializing smart feeds periodically
+func (s *SmartFeedScheduler) Start(ctx context.Context) {
+	utils.Info("Starting smart feed scheduler", map[string]interface{}{
+		"scheduler": smartFeedSchedulerName,
+		"interval":  s.interval.String(),
+	})
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run initial pass
+	s.run(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.run(ctx)
+		case <-s.stopChan:
+			utils.Info("Smart feed scheduler stopped", map[string]interface{}{
+				"scheduler": smartFeedSchedulerName,
+			})
+			return
+		case <-ctx.Done():
+			utils.Info("Smart feed scheduler stopped due to context cancellation", map[string]interface{}{
+				"scheduler": smartFeedSchedulerName,
+			})
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler in a thread-safe manner
+func (s *SmartFeedScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+}
+
+func (s *SmartFeedScheduler) run(ctx context.Context) {
+	start := time.Now()
+
+	// Each feed is refreshed once per interval
+	changed, err := s.feedService.MaterializeSmartFeeds(ctx, start.Add(-s.interval))
+	metrics.ObserveJobRun(smartFeedSchedulerName, time.Since(start), err)
+	if err != nil {
+		utils.Error("Failed to materialize smart feeds", err, map[string]interface{}{
+			"scheduler": smartFeedSchedulerName,
+		})
+		return
+	}
+
+	if changed > 0 {
+		utils.Debug("Smart feeds materialized", map[string]interface{}{
+			"scheduler": smartFeedSchedulerName,
+			"changed":   changed,
+		})
+	}
+}
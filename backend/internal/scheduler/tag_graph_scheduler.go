@@ -0,0 +1,89 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/subculture-collective/clipper/pkg/metrics"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const tagGraphSchedulerName = "tag_graph"
+
+// TagGraphServiceInterface defines the interface required by the tag graph scheduler
+type TagGraphServiceInterface interface {
+	Rebuild(ctx context.Context) (int, error)
+}
+
+// TagGraphScheduler rebuilds the tag co-occurrence graph, nightly by default
+type TagGraphScheduler struct {
+	tagGraphService TagGraphServiceInterface
+	interval        time.Duration
+	stopChan        chan struct{}
+	stopOnce        sync.Once
+}
+
+// NewTagGraphScheduler creates a new tag graph scheduler
+func NewTagGraphScheduler(tagGraphService TagGraphServiceInterface, intervalMinutes int) *TagGraphScheduler {
+	return &TagGraphScheduler{
+		tagGraphService: tagGraphService,
+		interval:        time.Duration(intervalMinutes) * time.Minute,
+		stopChan:        make(chan struct{}),
+	}
+}
+
+// Start begins rebuilding the tag graph periodically
+func (s *TagGraphScheduler) Start(ctx context.Context) {
+	utils.Info("Starting tag graph scheduler", map[string]interface{}{
+		"scheduler": tagGraphSchedulerName,
+		"interval":  s.interval.String(),
+	})
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run initial pass
+	s.rebuild(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.rebuild(ctx)
+		case <-s.stopChan:
+			utils.Info("Tag graph scheduler stopped", map[string]interface{}{
+				"scheduler": tagGraphSchedulerName,
+			})
+			return
+		case <-ctx.Done():
+			utils.Info("Tag graph scheduler stopped due to context cancellation", map[string]interface{}{
+				"scheduler": tagGraphSchedulerName,
+			})
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler in a thread-safe manner
+func (s *TagGraphScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+}
+
+// rebuild recomputes the related tags from the current clip tags
+func (s *TagGraphScheduler) rebuild(ctx context.Context) {
+	start := time.Now()
+	pairs, err := s.tagGraphService.Rebuild(ctx)
+	metrics.ObserveJobRun(tagGraphSchedulerName, time.Since(start), err)
+	if err != nil {
+		utils.Error("Failed to rebuild tag graph", err, map[string]interface{}{
+			"scheduler": tagGraphSchedulerName,
+		})
+		return
+	}
+	utils.Info("Rebuilt tag graph", map[string]interface{}{
+		"scheduler": tagGraphSchedulerName,
+		"pairs":     pairs,
+	})
+}
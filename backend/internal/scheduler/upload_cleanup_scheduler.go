@@ -0,0 +1,95 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/subculture-collective/clipper/pkg/metrics"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const uploadCleanupSchedulerName = "upload_cleanup"
+
+// uploadCleanupBatchSize is how many orphaned uploads are deleted per run
+const uploadCleanupBatchSize = 500
+
+// UploadCleanupServiceInterface defines the interface required by the upload cleanup scheduler
+type UploadCleanupServiceInterface interface {
+	CleanupOrphans(ctx context.Context, limit int) (int, error)
+}
+
+// UploadCleanupScheduler deletes uploads that were never completed, never
+// used or replaced, along with their files
+type UploadCleanupScheduler struct {
+	uploadService UploadCleanupServiceInterface
+	interval      time.Duration
+	stopChan      chan struct{}
+	stopOnce      sync.Once
+}
+
+// NewUploadCleanupScheduler creates a new upload cleanup scheduler
+func NewUploadCleanupScheduler(uploadService UploadCleanupServiceInterface, intervalMinutes int) *UploadCleanupScheduler {
+	return &UploadCleanupScheduler{
+		uploadService: uploadService,
+		interval:      time.Duration(intervalMinutes) * time.Minute,
+		stopChan:      make(chan struct{}),
+	}
+}
+
+// Start begins deleting orphaned uploads periodically
+func (s *UploadCleanupScheduler) Start(ctx context.Context) {
+	utils.Info("Starting upload cleanup scheduler", map[string]interface{}{
+		"scheduler": uploadCleanupSchedulerName,
+		"interval":  s.interval.String(),
+	})
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run initial pass
+	s.cleanup(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.cleanup(ctx)
+		case <-s.stopChan:
+			utils.Info("Upload cleanup scheduler stopped", map[string]interface{}{
+				"scheduler": uploadCleanupSchedulerName,
+			})
+			return
+		case <-ctx.Done():
+			utils.Info("Upload cleanup scheduler stopped due to context cancellation", map[string]interface{}{
+				"scheduler": uploadCleanupSchedulerName,
+			})
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler in a thread-safe manner
+func (s *UploadCleanupScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+}
+
+// cleanup deletes one batch of orphaned uploads
+func (s *UploadCleanupScheduler) cleanup(ctx context.Context) {
+	start := time.Now()
+	deleted, err := s.uploadService.CleanupOrphans(ctx, uploadCleanupBatchSize)
+	metrics.ObserveJobRun(uploadCleanupSchedulerName, time.Since(start), err)
+	if err != nil {
+		utils.Error("Failed to clean up orphaned uploads", err, map[string]interface{}{
+			"scheduler": uploadCleanupSchedulerName,
+		})
+		return
+	}
+	if deleted > 0 {
+		utils.Info("Deleted orphaned uploads", map[string]interface{}{
+			"scheduler": uploadCleanupSchedulerName,
+			"count":     deleted,
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/subculture-collective/clipper/pkg/metrics"
 	"github.com/subculture-collective/clipper/pkg/utils"
 )
 
@@ -48,12 +49,12 @@ func (s *WebhookRetryScheduler) Start(ctx context.Context) {
 	defer ticker.Stop()
 
 	// Run initial processing
-	s.processRetries(ctx)
+	_ = s.processRetries(ctx)
 
 	for {
 		select {
 		case <-ticker.C:
-			s.processRetries(ctx)
+			_ = s.processRetries(ctx)
 		case <-s.stopChan:
 			utils.Info("Webhook retry scheduler stopped", map[string]interface{}{
 				"scheduler": "webhook_retry",
@@ -78,17 +79,28 @@ func (s *WebhookRetryScheduler) Stop() {
 	})
 }
 
+// RunOnce processes one batch of pending retries. It is used by the job queue
+// worker, which schedules runs itself instead of calling Start.
+func (s *WebhookRetryScheduler) RunOnce(ctx context.Context) error {
+	return s.processRetries(ctx)
+}
+
 // processRetries processes pending webhook retries
-func (s *WebhookRetryScheduler) processRetries(ctx context.Context) {
+func (s *WebhookRetryScheduler) processRetries(ctx context.Context) error {
 	utils.Info("Processing webhook retries", map[string]interface{}{
 		"batch_size": s.batchSize,
 		"scheduler":  "webhook_retry",
 	})
 
-	if err := s.webhookRetryService.ProcessPendingRetries(ctx, s.batchSize); err != nil {
+	start := time.Now()
+	err := s.webhookRetryService.ProcessPendingRetries(ctx, s.batchSize)
+	metrics.ObserveJobRun("webhook_retry", time.Since(start), err)
+	if err != nil {
 		utils.Error("Error processing webhook retries", err, map[string]interface{}{
 			"batch_size": s.batchSize,
 			"scheduler":  "webhook_retry",
 		})
+		return err
 	}
+	return nil
 }
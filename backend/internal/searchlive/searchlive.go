@@ -0,0 +1,110 @@
+// Package searchlive connects the search evaluation tools to the same
+// PostgreSQL, OpenSearch and embedding services the API uses, so weight
+// configurations can be scored against real rankings instead of simulated ones.
+package searchlive
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/services"
+	"github.com/subculture-collective/clipper/pkg/database"
+	opensearchpkg "github.com/subculture-collective/clipper/pkg/opensearch"
+	"github.com/subculture-collective/clipper/pkg/redis"
+)
+
+// Stack is a hybrid search service and the connections behind it
+type Stack struct {
+	Search *services.HybridSearchService
+
+	db        *database.DB
+	redis     *redis.Client
+	embedding *services.EmbeddingService
+}
+
+// Connect builds a hybrid search service from cfg. Redis only caches query
+// embeddings, so it is skipped when unreachable. Without embeddings, hybrid
+// search falls back to BM25 and vector weights have no effect.
+func Connect(ctx context.Context, cfg *config.Config) (*Stack, error) {
+	db, err := database.NewDB(&cfg.Database)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	stack := &Stack{db: db}
+
+	osClient, err := opensearchpkg.NewClient(&opensearchpkg.Config{
+		URL:                cfg.OpenSearch.URL,
+		Username:           cfg.OpenSearch.Username,
+		Password:           cfg.OpenSearch.Password,
+		InsecureSkipVerify: cfg.OpenSearch.InsecureSkipVerify,
+	})
+	if err != nil {
+		stack.Close()
+		return nil, fmt.Errorf("failed to initialize OpenSearch client: %w", err)
+	}
+	if err := osClient.Ping(ctx); err != nil {
+		stack.Close()
+		return nil, fmt.Errorf("OpenSearch ping failed: %w", err)
+	}
+
+	if cfg.Embedding.Enabled && cfg.Embedding.Configured() {
+		redisClient, err := redis.NewClient(&cfg.Redis)
+		if err != nil {
+			log.Printf("WARNING: Redis unavailable, query embeddings will not be cached: %v", err)
+		} else {
+			stack.redis = redisClient
+		}
+
+		embeddingConfig := &services.EmbeddingConfig{
+			Provider:              cfg.Embedding.Provider,
+			APIKey:                cfg.Embedding.ProviderAPIKey(),
+			APIBaseURL:            cfg.Embedding.APIBaseURL,
+			Model:                 cfg.Embedding.Model,
+			RequestsPerMinute:     cfg.Embedding.RequestsPerMinute,
+			MaxBatchInputs:        cfg.Embedding.BatchMaxInputs,
+			MaxBatchTokens:        cfg.Embedding.BatchMaxTokens,
+			PricePerMillionTokens: cfg.Embedding.PricePerMillionTokens,
+		}
+		if stack.redis != nil {
+			embeddingConfig.RedisClient = stack.redis.GetClient()
+		}
+		embeddingConfig.Budget = services.NewEmbeddingBudget(embeddingConfig.RedisClient, cfg.Embedding.MonthlyBudgetUSD, cfg.Embedding.BudgetAlertThreshold)
+		stack.embedding = services.NewEmbeddingService(embeddingConfig)
+	} else {
+		log.Println("WARNING: Embeddings are not configured; live search is BM25 only and vector weights have no effect")
+	}
+
+	// Correct spelling like the API so zero-hit queries are judged on the
+	// results users actually see
+	openSearchService := services.NewOpenSearchService(osClient)
+	openSearchService.SetSpellCorrection(cfg.HybridSearch.SpellCorrection, cfg.HybridSearch.AutoCorrectThreshold)
+
+	stack.Search = services.NewHybridSearchService(&services.HybridSearchConfig{
+		Pool:              db.Pool,
+		OpenSearchService: openSearchService,
+		EmbeddingService:  stack.embedding,
+		// Evaluation datasets supply the profiles to personalize with
+		Personalization: services.ConfiguredPersonalizationWeights(&cfg.HybridSearch),
+	})
+
+	return stack, nil
+}
+
+// Pool returns the stack's database pool, for tools that also read search logs
+func (s *Stack) Pool() *pgxpool.Pool {
+	return s.db.Pool
+}
+
+// Close releases the stack's connections
+func (s *Stack) Close() {
+	if s.embedding != nil {
+		s.embedding.Close()
+	}
+	if s.redis != nil {
+		s.redis.Close()
+	}
+	s.db.Close()
+}
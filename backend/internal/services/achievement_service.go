@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+var achievementsCompletedTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "achievements_completed_total",
+		Help: "Total number of achievements completed, by achievement",
+	},
+	[]string{"achievement"},
+)
+
+// AchievementRepositoryInterface defines the repository methods used by AchievementService
+type AchievementRepositoryInterface interface {
+	ListUserAchievements(ctx context.Context, userID uuid.UUID) ([]models.UserAchievement, error)
+	RecordEvent(ctx context.Context, userID uuid.UUID, metric string, sourceID uuid.UUID) ([]models.Achievement, error)
+}
+
+// AchievementNotifier tells users they completed an achievement
+type AchievementNotifier interface {
+	NotifyAchievementCompleted(ctx context.Context, userID uuid.UUID, achievement *models.Achievement) error
+}
+
+// AchievementTracker records progress toward achievements as events occur
+type AchievementTracker interface {
+	RecordProgress(ctx context.Context, userID uuid.UUID, metric string, sourceID uuid.UUID)
+}
+
+// AchievementService tracks users' progress toward achievements. Each event
+// counts toward a metric once per source; when a metric reaches an
+// achievement's target, the achievement is completed, its badge awarded and
+// the user notified.
+type AchievementService struct {
+	repo     AchievementRepositoryInterface
+	notifier AchievementNotifier
+}
+
+// NewAchievementService creates a new AchievementService. The notifier is optional.
+func NewAchievementService(repo AchievementRepositoryInterface, notifier AchievementNotifier) *AchievementService {
+	return &AchievementService{
+		repo:     repo,
+		notifier: notifier,
+	}
+}
+
+// ListUserAchievements returns every achievement with a user's progress toward it
+func (s *AchievementService) ListUserAchievements(ctx context.Context, userID uuid.UUID) ([]models.UserAchievement, error) {
+	return s.repo.ListUserAchievements(ctx, userID)
+}
+
+// RecordProgress counts an event toward a user's metric and completes the
+// achievements it reaches. Failures are logged rather than returned, since
+// the event itself has already happened.
+func (s *AchievementService) RecordProgress(ctx context.Context, userID uuid.UUID, metric string, sourceID uuid.UUID) {
+	completed, err := s.repo.RecordEvent(ctx, userID, metric, sourceID)
+	if err != nil {
+		utils.GetLogger().Error("Failed to record achievement progress", err, map[string]interface{}{
+			"user_id":   userID.String(),
+			"metric":    metric,
+			"source_id": sourceID.String(),
+		})
+		return
+	}
+
+	for i := range completed {
+		achievement := &completed[i]
+		achievementsCompletedTotal.WithLabelValues(achievement.ID).Inc()
+		if s.notifier == nil {
+			continue
+		}
+		if err := s.notifier.NotifyAchievementCompleted(ctx, userID, achievement); err != nil {
+			utils.GetLogger().Error("Failed to notify user of completed achievement", err, map[string]interface{}{
+				"user_id":        userID.String(),
+				"achievement_id": achievement.ID,
+			})
+		}
+	}
+}
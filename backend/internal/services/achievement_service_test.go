@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// MockAchievementRepository is a mock implementation of AchievementRepositoryInterface
+type MockAchievementRepository struct {
+	mock.Mock
+}
+
+func (m *MockAchievementRepository) ListUserAchievements(ctx context.Context, userID uuid.UUID) ([]models.UserAchievement, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.UserAchievement), args.Error(1)
+}
+
+func (m *MockAchievementRepository) RecordEvent(ctx context.Context, userID uuid.UUID, metric string, sourceID uuid.UUID) ([]models.Achievement, error) {
+	args := m.Called(ctx, userID, metric, sourceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Achievement), args.Error(1)
+}
+
+// MockAchievementNotifier is a mock implementation of AchievementNotifier
+type MockAchievementNotifier struct {
+	mock.Mock
+}
+
+func (m *MockAchievementNotifier) NotifyAchievementCompleted(ctx context.Context, userID uuid.UUID, achievement *models.Achievement) error {
+	args := m.Called(ctx, userID, achievement)
+	return args.Error(0)
+}
+
+// Deduping events and completing achievements at their target happen in SQL;
+// see the integration tests in repository/achievement_repository_test.go.
+
+func TestRecordProgressNotifiesCompletedAchievements(t *testing.T) {
+	ctx := context.Background()
+	userID, sourceID := uuid.New(), uuid.New()
+	firstComment := models.Achievement{ID: "first_comment", Metric: models.AchievementMetricCommentsPosted, Target: 1}
+	chatty := models.Achievement{ID: "chatty", Metric: models.AchievementMetricCommentsPosted, Target: 1}
+
+	repo := new(MockAchievementRepository)
+	repo.On("RecordEvent", ctx, userID, models.AchievementMetricCommentsPosted, sourceID).
+		Return([]models.Achievement{firstComment, chatty}, nil)
+	notifier := new(MockAchievementNotifier)
+	notifier.On("NotifyAchievementCompleted", ctx, userID, &firstComment).Return(nil).Once()
+	notifier.On("NotifyAchievementCompleted", ctx, userID, &chatty).Return(errors.New("queue full")).Once()
+
+	s := NewAchievementService(repo, notifier)
+	s.RecordProgress(ctx, userID, models.AchievementMetricCommentsPosted, sourceID)
+
+	repo.AssertExpectations(t)
+	notifier.AssertExpectations(t)
+}
+
+func TestRecordProgressWithoutCompletionsNotifiesNothing(t *testing.T) {
+	ctx := context.Background()
+	userID, sourceID := uuid.New(), uuid.New()
+
+	// A replayed or below-target event completes nothing
+	repo := new(MockAchievementRepository)
+	repo.On("RecordEvent", ctx, userID, models.AchievementMetricCommentsPosted, sourceID).Return(nil, nil)
+	notifier := new(MockAchievementNotifier)
+
+	s := NewAchievementService(repo, notifier)
+	s.RecordProgress(ctx, userID, models.AchievementMetricCommentsPosted, sourceID)
+
+	notifier.AssertNotCalled(t, "NotifyAchievementCompleted", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRecordProgressLogsFailures(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockAchievementRepository)
+	repo.On("RecordEvent", ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+	notifier := new(MockAchievementNotifier)
+	s := NewAchievementService(repo, notifier)
+
+	assert.NotPanics(t, func() {
+		s.RecordProgress(ctx, uuid.New(), models.AchievementMetricCommentsPosted, uuid.New())
+	})
+	notifier.AssertNotCalled(t, "NotifyAchievementCompleted", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRecordProgressWithoutNotifier(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	repo := new(MockAchievementRepository)
+	repo.On("RecordEvent", ctx, userID, models.AchievementMetricCommentsPosted, mock.Anything).
+		Return([]models.Achievement{{ID: "first_comment", Metric: models.AchievementMetricCommentsPosted, Target: 1}}, nil)
+	s := NewAchievementService(repo, nil)
+
+	assert.NotPanics(t, func() {
+		s.RecordProgress(ctx, userID, models.AchievementMetricCommentsPosted, uuid.New())
+	})
+	repo.AssertExpectations(t)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/subculture-collective/clipper/internal/models"
 	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/pkg/metrics"
 	redispkg "github.com/subculture-collective/clipper/pkg/redis"
 )
 
@@ -139,6 +140,7 @@ func (s *AdService) SelectAd(ctx context.Context, req models.AdSelectionRequest,
 	if err := s.adRepo.CreateImpression(ctx, impression); err != nil {
 		return nil, fmt.Errorf("failed to create impression: %w", err)
 	}
+	metrics.AdImpressionsTotal.WithLabelValues(metrics.AdLabels(impression.Platform, impression.SlotID)).Inc()
 
 	// Update frequency caps (async to not block response)
 	// Only if personalized
@@ -174,6 +176,15 @@ func (s *AdService) TrackImpression(ctx context.Context, req models.AdTrackingRe
 		return fmt.Errorf("failed to update impression: %w", err)
 	}
 
+	// Count each impression's first viewable report and first click only
+	platform, slot := metrics.AdLabels(impression.Platform, impression.SlotID)
+	if isViewable && !impression.IsViewable {
+		metrics.AdViewableImpressionsTotal.WithLabelValues(platform, slot).Inc()
+	}
+	if req.IsClicked && !impression.IsClicked {
+		metrics.AdClicksTotal.WithLabelValues(platform, slot).Inc()
+	}
+
 	// If viewable, charge the advertiser (CPM based)
 	if isViewable && !impression.IsViewable {
 		// Calculate cost (CPM / 1000 = cost per impression)
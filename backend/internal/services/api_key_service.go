@@ -0,0 +1,276 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	redispkg "github.com/subculture-collective/clipper/pkg/redis"
+)
+
+const (
+	apiKeyPrefix             = "clpr"
+	apiKeyMaxPerUser         = 10
+	apiKeyDefaultRateLimit   = 60
+	apiKeyMaxRateLimit       = 600
+	apiKeyMaxExpiryDays      = 365
+	apiKeyLastUsedResolution = time.Minute
+)
+
+var (
+	// ErrInvalidAPIKey is returned when an API key is malformed, unknown, revoked or expired
+	ErrInvalidAPIKey = errors.New("invalid api key")
+	// ErrInvalidAPIKeyScope is returned when a requested scope does not exist
+	ErrInvalidAPIKeyScope = errors.New("invalid api key scope")
+	// ErrInvalidAPIKeyRequest is returned when key settings are out of range
+	ErrInvalidAPIKeyRequest = errors.New("invalid api key request")
+	// ErrAPIKeyLimitReached is returned when a user already has the maximum number of active keys
+	ErrAPIKeyLimitReached = errors.New("api key limit reached")
+)
+
+// APIKeyRepositoryInterface defines the API key repository methods used by APIKeyService
+type APIKeyRepositoryInterface interface {
+	Create(ctx context.Context, key *models.APIKey) error
+	GetByPrefix(ctx context.Context, prefix string) (*models.APIKey, error)
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*models.APIKey, error)
+	CountActiveByUserID(ctx context.Context, userID uuid.UUID) (int, error)
+	Revoke(ctx context.Context, userID, keyID uuid.UUID) error
+	TouchLastUsed(ctx context.Context, keyID uuid.UUID) error
+}
+
+// APIKeyUsageRecorder counts requests made with API keys
+type APIKeyUsageRecorder interface {
+	Record(apiKeyID uuid.UUID, status int)
+}
+
+// APIKeyService manages personal API keys and their rate limits
+type APIKeyService struct {
+	repo     APIKeyRepositoryInterface
+	userRepo UserRepoInterface
+	redis    *redispkg.Client
+	usage    APIKeyUsageRecorder
+	now      func() time.Time
+}
+
+// NewAPIKeyService creates a new API key service
+func NewAPIKeyService(repo APIKeyRepositoryInterface, userRepo UserRepoInterface, redisClient *redispkg.Client) *APIKeyService {
+	return &APIKeyService{
+		repo:     repo,
+		userRepo: userRepo,
+		redis:    redisClient,
+		now:      time.Now,
+	}
+}
+
+// SetUsageRecorder sets the recorder that counts requests for developer usage reports
+func (s *APIKeyService) SetUsageRecorder(usage APIKeyUsageRecorder) {
+	s.usage = usage
+}
+
+// CreateKey issues a new API key. The plaintext key is only returned here.
+func (s *APIKeyService) CreateKey(ctx context.Context, userID uuid.UUID, req *models.CreateAPIKeyRequest) (*models.CreateAPIKeyResponse, error) {
+	scopes, err := normalizeAPIKeyScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	rateLimit := req.RateLimitPerMinute
+	if rateLimit == 0 {
+		rateLimit = apiKeyDefaultRateLimit
+	}
+	if rateLimit < 1 || rateLimit > apiKeyMaxRateLimit {
+		return nil, fmt.Errorf("%w: rate limit must be between 1 and %d", ErrInvalidAPIKeyRequest, apiKeyMaxRateLimit)
+	}
+	if req.ExpiresInDays < 0 || req.ExpiresInDays > apiKeyMaxExpiryDays {
+		return nil, fmt.Errorf("%w: expiry must be between 0 and %d days", ErrInvalidAPIKeyRequest, apiKeyMaxExpiryDays)
+	}
+
+	count, err := s.repo.CountActiveByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= apiKeyMaxPerUser {
+		return nil, ErrAPIKeyLimitReached
+	}
+
+	prefix, secret, err := generateAPIKeyParts()
+	if err != nil {
+		return nil, err
+	}
+	rawKey := formatAPIKey(prefix, secret)
+
+	key := &models.APIKey{
+		ID:                 uuid.New(),
+		UserID:             userID,
+		Name:               strings.TrimSpace(req.Name),
+		KeyPrefix:          prefix,
+		KeyHash:            hashAPIKey(rawKey),
+		Scopes:             scopes,
+		RateLimitPerMinute: rateLimit,
+	}
+	if req.ExpiresInDays > 0 {
+		expiresAt := s.now().Add(time.Duration(req.ExpiresInDays) * 24 * time.Hour)
+		key.ExpiresAt = &expiresAt
+	}
+
+	if err := s.repo.Create(ctx, key); err != nil {
+		return nil, err
+	}
+
+	return &models.CreateAPIKeyResponse{APIKey: key, Key: rawKey}, nil
+}
+
+// ListKeys returns a user's API keys without their secrets
+func (s *APIKeyService) ListKeys(ctx context.Context, userID uuid.UUID) ([]*models.APIKey, error) {
+	return s.repo.ListByUserID(ctx, userID)
+}
+
+// RevokeKey revokes one of the user's API keys
+func (s *APIKeyService) RevokeKey(ctx context.Context, userID, keyID uuid.UUID) error {
+	return s.repo.Revoke(ctx, userID, keyID)
+}
+
+// Authenticate validates a raw API key and returns the key and its owner
+func (s *APIKeyService) Authenticate(ctx context.Context, rawKey string) (*models.APIKey, *models.User, error) {
+	prefix, ok := parseAPIKeyPrefix(rawKey)
+	if !ok {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	key, err := s.repo.GetByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, repository.ErrAPIKeyNotFound) {
+			return nil, nil, ErrInvalidAPIKey
+		}
+		return nil, nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(key.KeyHash), []byte(hashAPIKey(rawKey))) != 1 {
+		return nil, nil, ErrInvalidAPIKey
+	}
+	now := s.now()
+	if !key.IsActive(now) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	user, err := s.userRepo.GetByID(ctx, key.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user.IsBanned {
+		return nil, nil, ErrUserBanned
+	}
+
+	// Only write usage when it moves meaningfully to avoid a write per request
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyLastUsedResolution {
+		_ = s.repo.TouchLastUsed(ctx, key.ID)
+	}
+
+	return key, user, nil
+}
+
+// AllowRequest applies the key's per-minute rate limit.
+// It returns whether the request is allowed, the remaining quota and when the window resets.
+func (s *APIKeyService) AllowRequest(ctx context.Context, key *models.APIKey) (bool, int, time.Time) {
+	now := s.now()
+	window := now.Truncate(time.Minute)
+	resetAt := window.Add(time.Minute)
+
+	if s.redis == nil {
+		return true, key.RateLimitPerMinute, resetAt
+	}
+
+	counterKey := fmt.Sprintf("apikey:ratelimit:%s:%d", key.ID, window.Unix())
+	count, err := s.redis.Increment(ctx, counterKey)
+	if err != nil {
+		// Fail open; the IP-based limiter still applies
+		return true, key.RateLimitPerMinute, resetAt
+	}
+	if count == 1 {
+		_ = s.redis.Expire(ctx, counterKey, 2*time.Minute)
+	}
+
+	remaining := key.RateLimitPerMinute - int(count)
+	if remaining < 0 {
+		return false, 0, resetAt
+	}
+	return true, remaining, resetAt
+}
+
+// RecordUsage counts a finished request made with the key
+func (s *APIKeyService) RecordUsage(key *models.APIKey, status int) {
+	if s.usage == nil {
+		return
+	}
+	s.usage.Record(key.ID, status)
+}
+
+// normalizeAPIKeyScopes validates and deduplicates requested scopes
+func normalizeAPIKeyScopes(requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return nil, ErrInvalidAPIKeyScope
+	}
+
+	seen := map[string]bool{}
+	scopes := make([]string, 0, len(requested))
+	for _, scope := range requested {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		valid := false
+		for _, known := range models.ValidAPIKeyScopes {
+			if scope == known {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidAPIKeyScope, scope)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes, nil
+}
+
+// generateAPIKeyParts returns a random public prefix and secret
+func generateAPIKeyParts() (string, string, error) {
+	prefix := make([]byte, 4)
+	secret := make([]byte, 24)
+	if _, err := rand.Read(prefix); err != nil {
+		return "", "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	return hex.EncodeToString(prefix), hex.EncodeToString(secret), nil
+}
+
+// formatAPIKey builds the key handed to the user: clpr_<prefix>_<secret>
+func formatAPIKey(prefix, secret string) string {
+	return fmt.Sprintf("%s_%s_%s", apiKeyPrefix, prefix, secret)
+}
+
+// parseAPIKeyPrefix extracts the lookup prefix from a raw key
+func parseAPIKeyPrefix(rawKey string) (string, bool) {
+	parts := strings.Split(rawKey, "_")
+	if len(parts) != 3 || parts[0] != apiKeyPrefix || len(parts[1]) != 8 || len(parts[2]) != 48 {
+		return "", false
+	}
+	return parts[1], true
+}
+
+// hashAPIKey hashes a raw key for storage
+func hashAPIKey(rawKey string) string {
+	hash := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(hash[:])
+}
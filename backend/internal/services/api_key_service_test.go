@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+// MockAPIKeyRepository is a mock implementation of APIKeyRepositoryInterface
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*models.APIKey, error) {
+	args := m.Called(ctx, prefix)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*models.APIKey, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) CountActiveByUserID(ctx context.Context, userID uuid.UUID) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Revoke(ctx context.Context, userID, keyID uuid.UUID) error {
+	args := m.Called(ctx, userID, keyID)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) TouchLastUsed(ctx context.Context, keyID uuid.UUID) error {
+	args := m.Called(ctx, keyID)
+	return args.Error(0)
+}
+
+// setupAPIKeyServiceTest builds an APIKeyService over mocks
+func setupAPIKeyServiceTest() (*APIKeyService, *MockAPIKeyRepository, *MockUserRepository) {
+	repo := new(MockAPIKeyRepository)
+	userRepo := new(MockUserRepository)
+	return NewAPIKeyService(repo, userRepo, nil), repo, userRepo
+}
+
+// createTestAPIKey creates a key through the service and returns it with the
+// stored record, as the repository would hand it back
+func createTestAPIKey(t *testing.T, service *APIKeyService, repo *MockAPIKeyRepository, userID uuid.UUID, req *models.CreateAPIKeyRequest) *models.CreateAPIKeyResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	repo.On("CountActiveByUserID", ctx, userID).Return(0, nil).Once()
+	repo.On("Create", ctx, mock.AnythingOfType("*models.APIKey")).Return(nil).Once()
+
+	resp, err := service.CreateKey(ctx, userID, req)
+	require.NoError(t, err)
+	repo.On("GetByPrefix", ctx, resp.APIKey.KeyPrefix).Return(resp.APIKey, nil)
+	return resp
+}
+
+func TestAPIKeyService_CreateAndAuthenticate(t *testing.T) {
+	user := &models.User{ID: uuid.New(), Username: "integrator"}
+	service, repo, userRepo := setupAPIKeyServiceTest()
+	ctx := context.Background()
+
+	resp := createTestAPIKey(t, service, repo, user.ID, &models.CreateAPIKeyRequest{
+		Name:   " CI bot ",
+		Scopes: []string{"read-clips", "Submit-Clips", "read-clips"},
+	})
+	assert.True(t, strings.HasPrefix(resp.Key, "clpr_"+resp.APIKey.KeyPrefix+"_"))
+	assert.Equal(t, "CI bot", resp.APIKey.Name)
+	assert.Equal(t, []string{models.APIKeyScopeReadClips, models.APIKeyScopeSubmitClips}, resp.APIKey.Scopes)
+	assert.Equal(t, apiKeyDefaultRateLimit, resp.APIKey.RateLimitPerMinute)
+	assert.Nil(t, resp.APIKey.ExpiresAt)
+	assert.NotContains(t, resp.APIKey.KeyHash, resp.Key)
+
+	userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	repo.On("TouchLastUsed", ctx, resp.APIKey.ID).Return(nil).Once()
+
+	key, owner, err := service.Authenticate(ctx, resp.Key)
+	require.NoError(t, err)
+	assert.Equal(t, resp.APIKey.ID, key.ID)
+	assert.Equal(t, user.ID, owner.ID)
+
+	// Same prefix with a different secret is rejected
+	tampered := resp.Key[:len(resp.Key)-1] + "0"
+	if tampered == resp.Key {
+		tampered = resp.Key[:len(resp.Key)-1] + "1"
+	}
+	_, _, err = service.Authenticate(ctx, tampered)
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+
+	repo.AssertExpectations(t)
+}
+
+func TestAPIKeyService_CreateValidation(t *testing.T) {
+	userID := uuid.New()
+	service, repo, _ := setupAPIKeyServiceTest()
+	ctx := context.Background()
+
+	_, err := service.CreateKey(ctx, userID, &models.CreateAPIKeyRequest{Name: "k", Scopes: []string{"admin"}})
+	assert.ErrorIs(t, err, ErrInvalidAPIKeyScope)
+
+	_, err = service.CreateKey(ctx, userID, &models.CreateAPIKeyRequest{Name: "k", Scopes: []string{"read-clips"}, RateLimitPerMinute: apiKeyMaxRateLimit + 1})
+	assert.ErrorIs(t, err, ErrInvalidAPIKeyRequest)
+
+	_, err = service.CreateKey(ctx, userID, &models.CreateAPIKeyRequest{Name: "k", Scopes: []string{"read-clips"}, ExpiresInDays: apiKeyMaxExpiryDays + 1})
+	assert.ErrorIs(t, err, ErrInvalidAPIKeyRequest)
+
+	repo.On("CountActiveByUserID", ctx, userID).Return(apiKeyMaxPerUser, nil)
+	_, err = service.CreateKey(ctx, userID, &models.CreateAPIKeyRequest{Name: "k", Scopes: []string{"read-clips"}})
+	assert.ErrorIs(t, err, ErrAPIKeyLimitReached)
+
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAPIKeyService_RevokedExpiredAndBanned(t *testing.T) {
+	user := &models.User{ID: uuid.New()}
+	service, repo, userRepo := setupAPIKeyServiceTest()
+	ctx := context.Background()
+
+	resp := createTestAPIKey(t, service, repo, user.ID, &models.CreateAPIKeyRequest{Name: "k", Scopes: []string{"read-clips"}, ExpiresInDays: 30})
+	require.NotNil(t, resp.APIKey.ExpiresAt)
+
+	service.now = func() time.Time { return time.Now().Add(31 * 24 * time.Hour) }
+	_, _, err := service.Authenticate(ctx, resp.Key)
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+	service.now = time.Now
+
+	userRepo.On("GetByID", ctx, user.ID).Return(&models.User{ID: user.ID, IsBanned: true}, nil).Once()
+	_, _, err = service.Authenticate(ctx, resp.Key)
+	assert.ErrorIs(t, err, ErrUserBanned)
+
+	repo.On("Revoke", ctx, user.ID, resp.APIKey.ID).Return(nil).Once()
+	require.NoError(t, service.RevokeKey(ctx, user.ID, resp.APIKey.ID))
+	revokedAt := time.Now()
+	resp.APIKey.RevokedAt = &revokedAt
+	_, _, err = service.Authenticate(ctx, resp.Key)
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+
+	otherUserID := uuid.New()
+	repo.On("Revoke", ctx, otherUserID, resp.APIKey.ID).Return(repository.ErrAPIKeyNotFound)
+	assert.ErrorIs(t, service.RevokeKey(ctx, otherUserID, resp.APIKey.ID), repository.ErrAPIKeyNotFound)
+
+	repo.AssertNotCalled(t, "TouchLastUsed", mock.Anything, mock.Anything)
+}
+
+func TestParseAPIKeyPrefix(t *testing.T) {
+	prefix, secret, err := generateAPIKeyParts()
+	require.NoError(t, err)
+
+	parsed, ok := parseAPIKeyPrefix(formatAPIKey(prefix, secret))
+	assert.True(t, ok)
+	assert.Equal(t, prefix, parsed)
+
+	for _, bad := range []string{"", "clpr_short_secret", "sk_" + prefix + "_" + secret, "clpr_" + prefix} {
+		_, ok := parseAPIKeyPrefix(bad)
+		assert.False(t, ok, bad)
+	}
+}
+
+func TestAPIKeyService_AllowRequestWithoutRedis(t *testing.T) {
+	service, _, _ := setupAPIKeyServiceTest()
+	key := &models.APIKey{ID: uuid.New(), RateLimitPerMinute: 5}
+
+	allowed, remaining, resetAt := service.AllowRequest(context.Background(), key)
+	assert.True(t, allowed)
+	assert.Equal(t, 5, remaining)
+	assert.True(t, resetAt.After(time.Now()))
+}
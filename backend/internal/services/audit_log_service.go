@@ -258,6 +258,18 @@ func (s *AuditLogService) LogAccountDeletionCancelled(ctx context.Context, userI
 	return s.auditLogRepo.Create(ctx, log)
 }
 
+// LogDataExportGenerated logs when a user downloads an export of their account data
+func (s *AuditLogService) LogDataExportGenerated(ctx context.Context, userID uuid.UUID) error {
+	log := &models.ModerationAuditLog{
+		Action:      repository.AuditActionDataExportGenerated,
+		EntityType:  "user",
+		EntityID:    userID,
+		ModeratorID: userID,
+	}
+
+	return s.auditLogRepo.Create(ctx, log)
+}
+
 // LogEntitlementDenial logs when a user is denied access to a feature due to lack of entitlement
 func (s *AuditLogService) LogEntitlementDenial(ctx context.Context, userID uuid.UUID, action string, metadata map[string]interface{}) error {
 	log := &models.ModerationAuditLog{
@@ -0,0 +1,493 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+var (
+	// ErrIdentityAlreadyLinked is returned when a provider account is already linked to another user
+	ErrIdentityAlreadyLinked = errors.New("provider account is already linked to another user")
+	// ErrProviderAlreadyLinked is returned when the user already has an identity for the provider
+	ErrProviderAlreadyLinked = errors.New("a different account for this provider is already linked")
+	// ErrLastLoginMethod is returned when unlinking would leave the user without any way to log in
+	ErrLastLoginMethod = errors.New("cannot unlink the only login method")
+	// ErrLinkNotAuthorized is returned when a link flow is completed by another session or browser
+	// than the one that started it
+	ErrLinkNotAuthorized = errors.New("account link was not started by this session")
+)
+
+const (
+	maxGeneratedUsernameLength = 25
+	minGeneratedUsernameLength = 3
+)
+
+// ProviderOAuthState is stored in Redis for the duration of a provider login/link flow
+type ProviderOAuthState struct {
+	CodeChallenge       string     `json:"code_challenge,omitempty"`
+	CodeChallengeMethod string     `json:"code_challenge_method,omitempty"`
+	LinkUserID          *uuid.UUID `json:"link_user_id,omitempty"`
+	// LinkNonce binds a link flow to the browser that started it
+	LinkNonce string `json:"link_nonce,omitempty"`
+}
+
+// UsesPKCE reports whether the flow was started with a PKCE code challenge
+func (s ProviderOAuthState) UsesPKCE() bool {
+	return s.CodeChallenge != ""
+}
+
+// SetOAuthProviders enables external login providers (Google, Discord) alongside Twitch
+func (s *AuthService) SetOAuthProviders(identityRepo *repository.AuthIdentityRepository, providers map[string]OAuthProvider) {
+	s.identityRepo = identityRepo
+	s.oauthProviders = providers
+}
+
+// OAuthProviderNames returns the configured external login providers
+func (s *AuthService) OAuthProviderNames() []string {
+	names := make([]string, 0, len(s.oauthProviders))
+	for name := range s.oauthProviders {
+		names = append(names, name)
+	}
+	return names
+}
+
+func (s *AuthService) getOAuthProvider(name string) (OAuthProvider, error) {
+	provider, ok := s.oauthProviders[name]
+	if !ok || s.identityRepo == nil {
+		return nil, ErrUnknownOAuthProvider
+	}
+	return provider, nil
+}
+
+func providerStateKey(provider, state string) string {
+	return fmt.Sprintf("oauth:state:%s:%s", provider, state)
+}
+
+// GenerateProviderAuthURL generates the authorization URL for logging in with an external provider
+func (s *AuthService) GenerateProviderAuthURL(
+	ctx context.Context,
+	providerName string,
+	codeChallenge string,
+	codeChallengeMethod string,
+	clientState string,
+) (string, error) {
+	if (codeChallenge != "" && codeChallengeMethod == "") || (codeChallenge == "" && codeChallengeMethod != "") {
+		return "", errors.New("both codeChallenge and codeChallengeMethod must be provided together for PKCE")
+	}
+
+	return s.startProviderFlow(ctx, providerName, clientState, ProviderOAuthState{
+		CodeChallenge:       codeChallenge,
+		CodeChallengeMethod: codeChallengeMethod,
+	})
+}
+
+// GenerateProviderLinkURL generates the authorization URL that links a provider account to
+// the user. The returned nonce must be kept by the user's browser and presented, along with
+// the user's session, when the flow completes.
+func (s *AuthService) GenerateProviderLinkURL(ctx context.Context, providerName string, userID uuid.UUID) (string, string, error) {
+	nonce, err := generateRandomState()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate link nonce: %w", err)
+	}
+
+	authURL, err := s.startProviderFlow(ctx, providerName, "", ProviderOAuthState{
+		LinkUserID: &userID,
+		LinkNonce:  nonce,
+	})
+	if err != nil {
+		return "", "", err
+	}
+	return authURL, nonce, nil
+}
+
+// startProviderFlow stores the state of a provider flow and returns its authorization URL
+func (s *AuthService) startProviderFlow(ctx context.Context, providerName, clientState string, stored ProviderOAuthState) (string, error) {
+	provider, err := s.getOAuthProvider(providerName)
+	if err != nil {
+		return "", err
+	}
+
+	state := clientState
+	if state == "" {
+		state, err = generateRandomState()
+		if err != nil {
+			return "", fmt.Errorf("failed to generate state: %w", err)
+		}
+	}
+
+	stateValue, err := json.Marshal(stored)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode state: %w", err)
+	}
+
+	// Store state in Redis with 5 minute expiration
+	if err := s.redis.Set(ctx, providerStateKey(providerName, state), string(stateValue), 5*time.Minute); err != nil {
+		return "", fmt.Errorf("failed to store state: %w", err)
+	}
+
+	return provider.AuthCodeURL(state, stored.CodeChallenge, stored.CodeChallengeMethod), nil
+}
+
+// GetProviderState returns the stored state of a provider flow without consuming it
+func (s *AuthService) GetProviderState(ctx context.Context, providerName, state string) (*ProviderOAuthState, error) {
+	value, err := s.redis.Get(ctx, providerStateKey(providerName, state))
+	if err != nil || value == "" {
+		return nil, ErrInvalidState
+	}
+
+	var stored ProviderOAuthState
+	if err := json.Unmarshal([]byte(value), &stored); err != nil {
+		return nil, ErrInvalidState
+	}
+
+	return &stored, nil
+}
+
+// HandleProviderCallback completes an external provider login flow and issues tokens
+func (s *AuthService) HandleProviderCallback(
+	ctx context.Context,
+	providerName string,
+	code string,
+	state string,
+	codeVerifier string,
+	client models.SessionClient,
+) (*models.User, string, string, error) {
+	provider, stored, err := s.consumeProviderState(ctx, providerName, state)
+	if err != nil {
+		return nil, "", "", err
+	}
+	// Link flows are completed by CompleteProviderLink only
+	if stored.LinkUserID != nil {
+		return nil, "", "", ErrInvalidState
+	}
+
+	if stored.UsesPKCE() {
+		if codeVerifier == "" {
+			return nil, "", "", ErrInvalidCodeVerifier
+		}
+		if err := verifyPKCE(codeVerifier, stored.CodeChallenge, stored.CodeChallengeMethod); err != nil {
+			return nil, "", "", ErrInvalidCodeVerifier
+		}
+	}
+
+	identity, err := provider.Exchange(ctx, code, codeVerifier)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to exchange code: %w", err)
+	}
+
+	user, err := s.findOrCreateProviderUser(ctx, identity)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	if user.IsBanned {
+		return nil, "", "", ErrUserBanned
+	}
+
+	accessToken, refreshToken, err := s.generateTokens(ctx, user, client)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	return user, accessToken, refreshToken, nil
+}
+
+// CompleteProviderLink completes a link flow, attaching the provider account to the user
+// that started it. The callback must come from that user's session and carry the nonce
+// issued to their browser. No tokens are issued; the user stays signed in as they were.
+func (s *AuthService) CompleteProviderLink(
+	ctx context.Context,
+	providerName string,
+	code string,
+	state string,
+	sessionUserID uuid.UUID,
+	nonce string,
+) (*models.User, error) {
+	provider, stored, err := s.consumeProviderState(ctx, providerName, state)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeProviderLink(stored, sessionUserID, nonce); err != nil {
+		return nil, err
+	}
+
+	identity, err := provider.Exchange(ctx, code, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange code: %w", err)
+	}
+
+	user, err := s.linkIdentity(ctx, sessionUserID, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsBanned {
+		return nil, ErrUserBanned
+	}
+
+	return user, nil
+}
+
+// consumeProviderState returns the provider and stored state of a flow, deleting the state
+// so it can't be reused
+func (s *AuthService) consumeProviderState(ctx context.Context, providerName, state string) (OAuthProvider, *ProviderOAuthState, error) {
+	provider, err := s.getOAuthProvider(providerName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stored, err := s.GetProviderState(ctx, providerName, state)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Delete state to prevent reuse
+	_ = s.redis.Delete(ctx, providerStateKey(providerName, state))
+
+	return provider, stored, nil
+}
+
+// authorizeProviderLink checks that a link flow is completed by the session and browser
+// that started it
+func authorizeProviderLink(stored *ProviderOAuthState, sessionUserID uuid.UUID, nonce string) error {
+	if stored.LinkUserID == nil {
+		return ErrInvalidState
+	}
+	if *stored.LinkUserID != sessionUserID || stored.LinkNonce == "" ||
+		subtle.ConstantTimeCompare([]byte(stored.LinkNonce), []byte(nonce)) != 1 {
+		return ErrLinkNotAuthorized
+	}
+	return nil
+}
+
+// ListIdentities returns the external identities linked to a user
+func (s *AuthService) ListIdentities(ctx context.Context, userID uuid.UUID) ([]*models.UserAuthIdentity, error) {
+	if s.identityRepo == nil {
+		return []*models.UserAuthIdentity{}, nil
+	}
+	return s.identityRepo.ListByUserID(ctx, userID)
+}
+
+// UnlinkIdentity removes a provider identity, refusing to remove the user's last login method
+func (s *AuthService) UnlinkIdentity(ctx context.Context, userID uuid.UUID, providerName string) error {
+	if s.identityRepo == nil {
+		return ErrUnknownOAuthProvider
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	identities, err := s.identityRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	loginMethods := len(identities)
+	if user.TwitchID != nil && *user.TwitchID != "" {
+		loginMethods++
+	}
+	if loginMethods <= 1 {
+		return ErrLastLoginMethod
+	}
+
+	return s.identityRepo.Delete(ctx, userID, providerName)
+}
+
+// linkIdentity attaches a provider identity to an already authenticated user
+func (s *AuthService) linkIdentity(ctx context.Context, userID uuid.UUID, identity *OAuthIdentity) (*models.User, error) {
+	existing, err := s.identityRepo.GetByProviderUserID(ctx, identity.Provider, identity.ProviderUserID)
+	if err == nil {
+		if existing.UserID != userID {
+			return nil, ErrIdentityAlreadyLinked
+		}
+		// Already linked to this user; just refresh the profile snapshot
+		s.refreshIdentity(ctx, existing, identity)
+		return s.userRepo.GetByID(ctx, userID)
+	}
+	if !errors.Is(err, repository.ErrAuthIdentityNotFound) {
+		return nil, err
+	}
+
+	linked, err := s.identityRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range linked {
+		if l.Provider == identity.Provider {
+			return nil, ErrProviderAlreadyLinked
+		}
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.identityRepo.Create(ctx, newUserAuthIdentity(user.ID, identity)); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// findOrCreateProviderUser logs in the user owning the identity, or signs up a new account.
+// Accounts are never merged implicitly by email; linking to an existing (e.g. Twitch) account
+// must be done explicitly while logged in.
+func (s *AuthService) findOrCreateProviderUser(ctx context.Context, identity *OAuthIdentity) (*models.User, error) {
+	existing, err := s.identityRepo.GetByProviderUserID(ctx, identity.Provider, identity.ProviderUserID)
+	if err == nil {
+		s.refreshIdentity(ctx, existing, identity)
+		return s.userRepo.GetByID(ctx, existing.UserID)
+	}
+	if !errors.Is(err, repository.ErrAuthIdentityNotFound) {
+		return nil, err
+	}
+
+	username, err := s.availableUsername(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	displayName := identity.DisplayName
+	if displayName == "" {
+		displayName = username
+	}
+
+	now := time.Now()
+	user := &models.User{
+		ID:            uuid.New(),
+		Username:      username,
+		DisplayName:   displayName,
+		Role:          "user",
+		AccountStatus: "active",
+		AuthProvider:  identity.Provider,
+		KarmaPoints:   s.cfg.Karma.InitialKarmaPoints,
+		LastLoginAt:   &now,
+	}
+	if identity.Email != "" && identity.EmailVerified {
+		email := identity.Email
+		user.Email = &email
+	}
+	if identity.AvatarURL != "" {
+		avatarURL := identity.AvatarURL
+		user.AvatarURL = &avatarURL
+	}
+
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+
+	if err := s.identityRepo.Create(ctx, newUserAuthIdentity(user.ID, identity)); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// refreshIdentity updates the stored provider profile snapshot (best effort)
+func (s *AuthService) refreshIdentity(ctx context.Context, existing *models.UserAuthIdentity, identity *OAuthIdentity) {
+	updated := newUserAuthIdentity(existing.UserID, identity)
+	updated.ID = existing.ID
+	_ = s.identityRepo.UpdateProfile(ctx, updated)
+}
+
+// availableUsername derives a unique username from the provider profile
+func (s *AuthService) availableUsername(ctx context.Context, identity *OAuthIdentity) (string, error) {
+	base := sanitizeUsername(identity.Username)
+	if len(base) < minGeneratedUsernameLength {
+		base = sanitizeUsername(identity.DisplayName)
+	}
+	if len(base) < minGeneratedUsernameLength {
+		base = identity.Provider + "_user"
+	}
+
+	candidate := base
+	for attempt := 0; attempt < 5; attempt++ {
+		_, err := s.userRepo.GetByUsername(ctx, candidate)
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		suffix, err := randomHex(2)
+		if err != nil {
+			return "", err
+		}
+		trimmed := base
+		if len(trimmed) > maxGeneratedUsernameLength-len(suffix)-1 {
+			trimmed = trimmed[:maxGeneratedUsernameLength-len(suffix)-1]
+		}
+		candidate = trimmed + "_" + suffix
+	}
+
+	return "", errors.New("failed to generate a unique username")
+}
+
+// sanitizeUsername lowercases a provider username and strips characters that are not
+// allowed in usernames (letters, digits and underscores)
+func sanitizeUsername(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_':
+			b.WriteRune(r)
+		case r == '.' || r == '-' || r == ' ':
+			b.WriteRune('_')
+		}
+	}
+
+	sanitized := strings.Trim(b.String(), "_")
+	if len(sanitized) > maxGeneratedUsernameLength {
+		sanitized = sanitized[:maxGeneratedUsernameLength]
+	}
+	return sanitized
+}
+
+func newUserAuthIdentity(userID uuid.UUID, identity *OAuthIdentity) *models.UserAuthIdentity {
+	now := time.Now()
+	record := &models.UserAuthIdentity{
+		ID:             uuid.New(),
+		UserID:         userID,
+		Provider:       identity.Provider,
+		ProviderUserID: identity.ProviderUserID,
+		EmailVerified:  identity.EmailVerified,
+		Metadata:       identity.Metadata,
+		LastLoginAt:    &now,
+	}
+	if identity.Email != "" {
+		email := identity.Email
+		record.Email = &email
+	}
+	if identity.DisplayName != "" {
+		displayName := identity.DisplayName
+		record.DisplayName = &displayName
+	}
+	if identity.AvatarURL != "" {
+		avatarURL := identity.AvatarURL
+		record.AvatarURL = &avatarURL
+	}
+	return record
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorizeProviderLink(t *testing.T) {
+	linkUserID, otherUserID := uuid.New(), uuid.New()
+	stored := &ProviderOAuthState{LinkUserID: &linkUserID, LinkNonce: "nonce"}
+
+	t.Run("Same session and browser", func(t *testing.T) {
+		assert.NoError(t, authorizeProviderLink(stored, linkUserID, "nonce"))
+	})
+
+	t.Run("Callback session is another user", func(t *testing.T) {
+		// e.g. a victim opening the link URL an attacker started for their own account
+		assert.ErrorIs(t, authorizeProviderLink(stored, otherUserID, "nonce"), ErrLinkNotAuthorized)
+	})
+
+	t.Run("Callback from another browser", func(t *testing.T) {
+		assert.ErrorIs(t, authorizeProviderLink(stored, linkUserID, ""), ErrLinkNotAuthorized)
+		assert.ErrorIs(t, authorizeProviderLink(stored, linkUserID, "other"), ErrLinkNotAuthorized)
+	})
+
+	t.Run("Login flow state", func(t *testing.T) {
+		assert.ErrorIs(t, authorizeProviderLink(&ProviderOAuthState{}, linkUserID, ""), ErrInvalidState)
+	})
+}
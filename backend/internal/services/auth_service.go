@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"net/url"
 	"strings"
@@ -31,6 +32,12 @@ var (
 	ErrUserBanned = errors.New("user is banned")
 	// ErrInvalidCodeVerifier is returned when PKCE code verifier validation fails
 	ErrInvalidCodeVerifier = errors.New("invalid code verifier")
+	// ErrImpersonationForbidden is returned when the target user cannot be impersonated
+	ErrImpersonationForbidden = errors.New("user cannot be impersonated")
+	// ErrRefreshTokenReused is returned when an already rotated refresh token is presented again
+	ErrRefreshTokenReused = errors.New("refresh token reuse detected")
+	// ErrSessionNotFound is returned when a refresh token does not identify a live session of the user
+	ErrSessionNotFound = errors.New("session not found")
 
 	// base64URLEncoder is a reusable base64 URL encoder without padding
 	base64URLEncoder = base64.URLEncoding.WithPadding(base64.NoPadding)
@@ -54,20 +61,51 @@ type TwitchTokenResponse struct {
 	TokenType    string `json:"token_type"`
 }
 
+// AuthUserRepositoryInterface defines the user repository methods used by AuthService
+type AuthUserRepositoryInterface interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+	GetByTwitchID(ctx context.Context, twitchID string) (*models.User, error)
+	GetByUsername(ctx context.Context, username string) (*models.User, error)
+	Create(ctx context.Context, user *models.User) error
+	Update(ctx context.Context, user *models.User) error
+	UpdateLastLogin(ctx context.Context, id uuid.UUID) error
+}
+
+// RefreshTokenRepositoryInterface defines the refresh token repository methods used by AuthService
+type RefreshTokenRepositoryInterface interface {
+	Create(ctx context.Context, userID, sessionID uuid.UUID, tokenHash string, expiresAt time.Time, client models.SessionClient) error
+	GetByHash(ctx context.Context, tokenHash string) (userID uuid.UUID, expiresAt time.Time, isRevoked bool, err error)
+	GetSessionID(ctx context.Context, tokenHash string) (uuid.UUID, error)
+	Revoke(ctx context.Context, tokenHash string) error
+	Rotate(ctx context.Context, oldTokenHash string, userID, sessionID uuid.UUID, newTokenHash string, expiresAt time.Time, client models.SessionClient) error
+	IsRotated(ctx context.Context, tokenHash string) (bool, error)
+	IsMFAVerified(ctx context.Context, tokenHash string) (bool, error)
+	MarkSessionMFAVerified(ctx context.Context, sessionID uuid.UUID) error
+	RevokeSession(ctx context.Context, sessionID uuid.UUID) (int64, error)
+}
+
+// SecurityAlertNotifier defines the notification methods used by AuthService for security alerts
+type SecurityAlertNotifier interface {
+	NotifyRefreshTokenReuse(ctx context.Context, userID uuid.UUID, deviceName string, ipAddress string) error
+}
+
 // AuthService handles authentication logic
 type AuthService struct {
-	cfg              *config.Config
-	userRepo         *repository.UserRepository
-	refreshTokenRepo *repository.RefreshTokenRepository
-	redis            *redispkg.Client
-	jwtManager       *jwtpkg.Manager
+	cfg                 *config.Config
+	userRepo            AuthUserRepositoryInterface
+	refreshTokenRepo    RefreshTokenRepositoryInterface
+	redis               *redispkg.Client
+	jwtManager          *jwtpkg.Manager
+	identityRepo        *repository.AuthIdentityRepository // nil unless external providers are configured
+	oauthProviders      map[string]OAuthProvider
+	notificationService SecurityAlertNotifier
 }
 
 // NewAuthService creates a new auth service
 func NewAuthService(
 	cfg *config.Config,
-	userRepo *repository.UserRepository,
-	refreshTokenRepo *repository.RefreshTokenRepository,
+	userRepo AuthUserRepositoryInterface,
+	refreshTokenRepo RefreshTokenRepositoryInterface,
 	redis *redispkg.Client,
 	jwtManager *jwtpkg.Manager,
 ) *AuthService {
@@ -80,6 +118,11 @@ func NewAuthService(
 	}
 }
 
+// SetNotificationService sets the notification service used for security alerts
+func (s *AuthService) SetNotificationService(notificationService SecurityAlertNotifier) {
+	s.notificationService = notificationService
+}
+
 // GetStateValue retrieves the state value from Redis without consuming it
 // Used by handlers to check if PKCE was used without deleting the state
 func (s *AuthService) GetStateValue(ctx context.Context, stateKey string) (string, error) {
@@ -131,7 +174,7 @@ func (s *AuthService) GenerateAuthURL(ctx context.Context, codeChallenge, codeCh
 
 // HandleCallback handles the OAuth callback
 // Supports PKCE: if codeVerifier is provided, validates it against stored challenge
-func (s *AuthService) HandleCallback(ctx context.Context, code, state, codeVerifier string) (*models.User, string, string, error) {
+func (s *AuthService) HandleCallback(ctx context.Context, code, state, codeVerifier string, client models.SessionClient) (*models.User, string, string, error) {
 	// Validate state and get PKCE challenge if exists
 	stateKey := fmt.Sprintf("oauth:state:%s", state)
 	stateValue, err := s.redis.Get(ctx, stateKey)
@@ -187,7 +230,7 @@ func (s *AuthService) HandleCallback(ctx context.Context, code, state, codeVerif
 	_ = s.userRepo.UpdateLastLogin(ctx, user.ID)
 
 	// Generate JWT tokens
-	accessToken, refreshToken, err := s.generateTokens(ctx, user)
+	accessToken, refreshToken, err := s.generateTokens(ctx, user, client)
 	if err != nil {
 		return nil, "", "", err
 	}
@@ -196,8 +239,8 @@ func (s *AuthService) HandleCallback(ctx context.Context, code, state, codeVerif
 }
 
 // GenerateTokensForUser issues fresh tokens for an existing user (used by non-production test logins)
-func (s *AuthService) GenerateTokensForUser(ctx context.Context, user *models.User) (string, string, error) {
-	return s.generateTokens(ctx, user)
+func (s *AuthService) GenerateTokensForUser(ctx context.Context, user *models.User, client models.SessionClient) (string, string, error) {
+	return s.generateTokens(ctx, user, client)
 }
 
 // GetUserByID returns a user by UUID
@@ -210,8 +253,11 @@ func (s *AuthService) GetUserByUsername(ctx context.Context, username string) (*
 	return s.userRepo.GetByUsername(ctx, username)
 }
 
-// RefreshAccessToken refreshes an access token using a refresh token
-func (s *AuthService) RefreshAccessToken(ctx context.Context, refreshToken string) (string, string, error) {
+// RefreshAccessToken refreshes an access token using a refresh token.
+// Refresh tokens are single use: each refresh consumes the presented token and
+// issues a new one in the same session. Presenting a consumed token again means
+// it was copied, so the whole session is revoked and the user is alerted.
+func (s *AuthService) RefreshAccessToken(ctx context.Context, refreshToken string, client models.SessionClient) (string, string, error) {
 	// Validate refresh token
 	_, err := s.jwtManager.ValidateToken(refreshToken)
 	if err != nil {
@@ -225,7 +271,20 @@ func (s *AuthService) RefreshAccessToken(ctx context.Context, refreshToken strin
 		return "", "", fmt.Errorf("refresh token not found: %w", err)
 	}
 
+	sessionID, err := s.refreshTokenRepo.GetSessionID(ctx, tokenHash)
+	if err != nil {
+		return "", "", fmt.Errorf("refresh token not found: %w", err)
+	}
+
 	if isRevoked {
+		rotated, err := s.refreshTokenRepo.IsRotated(ctx, tokenHash)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to check refresh token: %w", err)
+		}
+		if rotated {
+			s.handleRefreshTokenReuse(ctx, userID, sessionID, client)
+			return "", "", ErrRefreshTokenReused
+		}
 		return "", "", errors.New("refresh token has been revoked")
 	}
 
@@ -243,8 +302,14 @@ func (s *AuthService) RefreshAccessToken(ctx context.Context, refreshToken strin
 		return "", "", ErrUserBanned
 	}
 
+	// The new access token keeps the session's second-factor status
+	mfaVerified, err := s.refreshTokenRepo.IsMFAVerified(ctx, tokenHash)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to check refresh token: %w", err)
+	}
+
 	// Generate new tokens (refresh token rotation)
-	newAccessToken, err := s.jwtManager.GenerateAccessToken(user.ID, user.Role)
+	newAccessToken, err := s.generateAccessToken(user, mfaVerified)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to generate access token: %w", err)
 	}
@@ -254,19 +319,38 @@ func (s *AuthService) RefreshAccessToken(ctx context.Context, refreshToken strin
 		return "", "", fmt.Errorf("failed to generate refresh token: %w", err)
 	}
 
-	// Revoke old refresh token
-	_ = s.refreshTokenRepo.Revoke(ctx, tokenHash)
-
-	// Store new refresh token
+	// Consume the old refresh token and store its replacement
 	newTokenHash := jwtpkg.HashToken(newRefreshToken)
 	newExpiresAt := time.Now().Add(7 * 24 * time.Hour)
-	if err := s.refreshTokenRepo.Create(ctx, user.ID, newTokenHash, newExpiresAt); err != nil {
+	if err := s.refreshTokenRepo.Rotate(ctx, tokenHash, user.ID, sessionID, newTokenHash, newExpiresAt, client); err != nil {
+		if errors.Is(err, repository.ErrRefreshTokenAlreadyUsed) {
+			// Another request consumed this token between our read and the rotation
+			s.handleRefreshTokenReuse(ctx, user.ID, sessionID, client)
+			return "", "", ErrRefreshTokenReused
+		}
 		return "", "", fmt.Errorf("failed to store refresh token: %w", err)
 	}
 
 	return newAccessToken, newRefreshToken, nil
 }
 
+// handleRefreshTokenReuse revokes every token in the session a replayed refresh
+// token belongs to and alerts the user. Both the thief and the legitimate
+// client are signed out; the legitimate user simply signs in again.
+func (s *AuthService) handleRefreshTokenReuse(ctx context.Context, userID, sessionID uuid.UUID, client models.SessionClient) {
+	revoked, err := s.refreshTokenRepo.RevokeSession(ctx, sessionID)
+	if err != nil {
+		log.Printf("Failed to revoke session %s after refresh token reuse: %v", sessionID, err)
+	}
+	log.Printf("Refresh token reuse detected for user %s, session %s (revoked %d tokens)", userID, sessionID, revoked)
+
+	if s.notificationService != nil {
+		if err := s.notificationService.NotifyRefreshTokenReuse(ctx, userID, describeDevice(client.UserAgent), client.IPAddress); err != nil {
+			log.Printf("Failed to send refresh token reuse alert to user %s: %v", userID, err)
+		}
+	}
+}
+
 // Logout revokes a refresh token
 func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
 	tokenHash := jwtpkg.HashToken(refreshToken)
@@ -275,25 +359,89 @@ func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
 
 // GetUserFromToken retrieves a user from an access token
 func (s *AuthService) GetUserFromToken(ctx context.Context, token string) (*models.User, error) {
+	user, _, err := s.ValidateAccessToken(ctx, token)
+	return user, err
+}
+
+// ValidateAccessToken retrieves a user and the token's claims from an access token
+func (s *AuthService) ValidateAccessToken(ctx context.Context, token string) (*models.User, *jwtpkg.Claims, error) {
 	claims, err := s.jwtManager.ValidateToken(token)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	user, err := s.userRepo.GetByID(ctx, claims.UserID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if user.IsBanned {
-		return nil, ErrUserBanned
+		return nil, nil, ErrUserBanned
 	}
 
-	return user, nil
+	return user, claims, nil
 }
 
-// generateTokens centralizes token generation + persistence for a user
-func (s *AuthService) generateTokens(ctx context.Context, user *models.User) (string, string, error) {
+// VerifySessionMFA marks the session a refresh token belongs to as having passed
+// its second factor and returns an access token that says so. Callers must have
+// checked the user's TOTP or backup code first.
+func (s *AuthService) VerifySessionMFA(ctx context.Context, userID uuid.UUID, refreshToken string) (string, error) {
+	if refreshToken == "" {
+		return "", ErrSessionNotFound
+	}
+
+	tokenHash := jwtpkg.HashToken(refreshToken)
+	tokenUserID, expiresAt, isRevoked, err := s.refreshTokenRepo.GetByHash(ctx, tokenHash)
+	if err != nil || tokenUserID != userID || isRevoked || time.Now().After(expiresAt) {
+		return "", ErrSessionNotFound
+	}
+
+	sessionID, err := s.refreshTokenRepo.GetSessionID(ctx, tokenHash)
+	if err != nil {
+		return "", ErrSessionNotFound
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("user not found: %w", err)
+	}
+
+	if err := s.refreshTokenRepo.MarkSessionMFAVerified(ctx, sessionID); err != nil {
+		return "", fmt.Errorf("failed to mark session verified: %w", err)
+	}
+
+	return s.generateAccessToken(user, true)
+}
+
+// ImpersonateUser issues a short-lived access token that lets a support agent act as the
+// target user. Staff accounts cannot be impersonated.
+func (s *AuthService) ImpersonateUser(ctx context.Context, impersonatorID, targetID uuid.UUID) (string, *models.User, error) {
+	if impersonatorID == targetID {
+		return "", nil, ErrImpersonationForbidden
+	}
+
+	user, err := s.userRepo.GetByID(ctx, targetID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if user.IsStaff() {
+		return "", nil, ErrImpersonationForbidden
+	}
+	if user.IsBanned {
+		return "", nil, ErrUserBanned
+	}
+
+	token, err := s.jwtManager.GenerateImpersonationToken(user.ID, user.Role, impersonatorID)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate impersonation token: %w", err)
+	}
+
+	return token, user, nil
+}
+
+// generateTokens centralizes token generation + persistence for a user, starting a new session
+func (s *AuthService) generateTokens(ctx context.Context, user *models.User, client models.SessionClient) (string, string, error) {
 	if user.IsBanned {
 		return "", "", ErrUserBanned
 	}
@@ -301,7 +449,7 @@ func (s *AuthService) generateTokens(ctx context.Context, user *models.User) (st
 	// Update last login timestamp (best effort)
 	_ = s.userRepo.UpdateLastLogin(ctx, user.ID)
 
-	accessToken, err := s.jwtManager.GenerateAccessToken(user.ID, user.Role)
+	accessToken, err := s.generateAccessToken(user, false)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to generate access token: %w", err)
 	}
@@ -313,13 +461,21 @@ func (s *AuthService) generateTokens(ctx context.Context, user *models.User) (st
 
 	tokenHash := jwtpkg.HashToken(refreshToken)
 	expiresAt := time.Now().Add(7 * 24 * time.Hour)
-	if err := s.refreshTokenRepo.Create(ctx, user.ID, tokenHash, expiresAt); err != nil {
+	if err := s.refreshTokenRepo.Create(ctx, user.ID, uuid.New(), tokenHash, expiresAt, client); err != nil {
 		return "", "", fmt.Errorf("failed to store refresh token: %w", err)
 	}
 
 	return accessToken, refreshToken, nil
 }
 
+// generateAccessToken issues an access token carrying the session's second-factor status
+func (s *AuthService) generateAccessToken(user *models.User, mfaVerified bool) (string, error) {
+	if mfaVerified {
+		return s.jwtManager.GenerateMFAVerifiedAccessToken(user.ID, user.Role)
+	}
+	return s.jwtManager.GenerateAccessToken(user.ID, user.Role)
+}
+
 // exchangeCodeForToken exchanges an authorization code for an access token
 func (s *AuthService) exchangeCodeForToken(code string) (string, error) {
 	data := url.Values{}
@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	jwtpkg "github.com/subculture-collective/clipper/pkg/jwt"
+)
+
+// MockRefreshTokenRepository is a mock implementation of RefreshTokenRepositoryInterface
+type MockRefreshTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockRefreshTokenRepository) Create(ctx context.Context, userID, sessionID uuid.UUID, tokenHash string, expiresAt time.Time, client models.SessionClient) error {
+	args := m.Called(ctx, userID, sessionID, tokenHash, expiresAt, client)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (uuid.UUID, time.Time, bool, error) {
+	args := m.Called(ctx, tokenHash)
+	return args.Get(0).(uuid.UUID), args.Get(1).(time.Time), args.Bool(2), args.Error(3)
+}
+
+func (m *MockRefreshTokenRepository) GetSessionID(ctx context.Context, tokenHash string) (uuid.UUID, error) {
+	args := m.Called(ctx, tokenHash)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) Revoke(ctx context.Context, tokenHash string) error {
+	args := m.Called(ctx, tokenHash)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenRepository) Rotate(ctx context.Context, oldTokenHash string, userID, sessionID uuid.UUID, newTokenHash string, expiresAt time.Time, client models.SessionClient) error {
+	args := m.Called(ctx, oldTokenHash, userID, sessionID, newTokenHash, expiresAt, client)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenRepository) IsRotated(ctx context.Context, tokenHash string) (bool, error) {
+	args := m.Called(ctx, tokenHash)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) IsMFAVerified(ctx context.Context, tokenHash string) (bool, error) {
+	args := m.Called(ctx, tokenHash)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) MarkSessionMFAVerified(ctx context.Context, sessionID uuid.UUID) error {
+	args := m.Called(ctx, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenRepository) RevokeSession(ctx context.Context, sessionID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, sessionID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockSecurityAlertNotifier is a mock implementation of SecurityAlertNotifier
+type MockSecurityAlertNotifier struct {
+	mock.Mock
+}
+
+func (m *MockSecurityAlertNotifier) NotifyRefreshTokenReuse(ctx context.Context, userID uuid.UUID, deviceName string, ipAddress string) error {
+	args := m.Called(ctx, userID, deviceName, ipAddress)
+	return args.Error(0)
+}
+
+// setupAuthServiceTest builds an AuthService over mocks and a fresh signing key
+func setupAuthServiceTest(t *testing.T) (*AuthService, *MockUserRepository, *MockRefreshTokenRepository, *MockSecurityAlertNotifier, *jwtpkg.Manager) {
+	t.Helper()
+
+	privateKeyPEM, _, err := jwtpkg.GenerateRSAKeyPair()
+	require.NoError(t, err)
+	manager, err := jwtpkg.NewManager(privateKeyPEM)
+	require.NoError(t, err)
+
+	userRepo := new(MockUserRepository)
+	refreshTokenRepo := new(MockRefreshTokenRepository)
+	notifier := new(MockSecurityAlertNotifier)
+
+	service := NewAuthService(nil, userRepo, refreshTokenRepo, nil, manager)
+	service.SetNotificationService(notifier)
+	return service, userRepo, refreshTokenRepo, notifier, manager
+}
+
+func TestAuthService_RefreshAccessTokenIsSingleUse(t *testing.T) {
+	service, userRepo, refreshTokenRepo, notifier, manager := setupAuthServiceTest(t)
+	ctx := context.Background()
+	client := models.SessionClient{IPAddress: "203.0.113.7", UserAgent: "Mozilla/5.0 (X11; Linux x86_64) Firefox/120.0"}
+
+	user := &models.User{ID: uuid.New(), Username: "viewer", Role: models.RoleUser}
+	sessionID := uuid.New()
+	refreshToken, err := manager.GenerateRefreshToken(user.ID)
+	require.NoError(t, err)
+	tokenHash := jwtpkg.HashToken(refreshToken)
+	expiresAt := time.Now().Add(time.Hour)
+
+	// First use: the live token is consumed and replaced in the same session
+	refreshTokenRepo.On("GetByHash", ctx, tokenHash).Return(user.ID, expiresAt, false, nil).Once()
+	refreshTokenRepo.On("GetSessionID", ctx, tokenHash).Return(sessionID, nil)
+	refreshTokenRepo.On("IsMFAVerified", ctx, tokenHash).Return(false, nil).Once()
+	userRepo.On("GetByID", ctx, user.ID).Return(user, nil).Once()
+	refreshTokenRepo.On("Rotate", ctx, tokenHash, user.ID, sessionID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time"), client).Return(nil).Once()
+
+	accessToken, newRefreshToken, err := service.RefreshAccessToken(ctx, refreshToken, client)
+	require.NoError(t, err)
+	assert.NotEmpty(t, accessToken)
+	assert.NotEqual(t, refreshToken, newRefreshToken)
+
+	claims, err := manager.ValidateToken(accessToken)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, claims.UserID)
+	assert.False(t, claims.MFAVerified)
+
+	// Second use: the token was rotated, so it is a replay
+	refreshTokenRepo.On("GetByHash", ctx, tokenHash).Return(user.ID, expiresAt, true, nil).Once()
+	refreshTokenRepo.On("IsRotated", ctx, tokenHash).Return(true, nil).Once()
+	refreshTokenRepo.On("RevokeSession", ctx, sessionID).Return(int64(2), nil).Once()
+	notifier.On("NotifyRefreshTokenReuse", ctx, user.ID, "Firefox on Linux", client.IPAddress).Return(nil).Once()
+
+	_, _, err = service.RefreshAccessToken(ctx, refreshToken, client)
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+
+	userRepo.AssertExpectations(t)
+	refreshTokenRepo.AssertExpectations(t)
+	notifier.AssertExpectations(t)
+}
+
+func TestAuthService_RefreshAccessTokenConcurrentRotationIsReuse(t *testing.T) {
+	service, userRepo, refreshTokenRepo, notifier, manager := setupAuthServiceTest(t)
+	ctx := context.Background()
+	client := models.SessionClient{IPAddress: "198.51.100.4"}
+
+	user := &models.User{ID: uuid.New(), Username: "viewer", Role: models.RoleUser}
+	sessionID := uuid.New()
+	refreshToken, err := manager.GenerateRefreshToken(user.ID)
+	require.NoError(t, err)
+	tokenHash := jwtpkg.HashToken(refreshToken)
+
+	// Both requests read the token as live; the other one won the rotation
+	// (RowsAffected == 0 in the repository)
+	refreshTokenRepo.On("GetByHash", ctx, tokenHash).Return(user.ID, time.Now().Add(time.Hour), false, nil)
+	refreshTokenRepo.On("GetSessionID", ctx, tokenHash).Return(sessionID, nil)
+	refreshTokenRepo.On("IsMFAVerified", ctx, tokenHash).Return(false, nil)
+	userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	refreshTokenRepo.On("Rotate", ctx, tokenHash, user.ID, sessionID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time"), client).
+		Return(repository.ErrRefreshTokenAlreadyUsed)
+	refreshTokenRepo.On("RevokeSession", ctx, sessionID).Return(int64(1), nil).Once()
+	notifier.On("NotifyRefreshTokenReuse", ctx, user.ID, mock.AnythingOfType("string"), client.IPAddress).Return(nil).Once()
+
+	accessToken, newRefreshToken, err := service.RefreshAccessToken(ctx, refreshToken, client)
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+	assert.Empty(t, accessToken)
+	assert.Empty(t, newRefreshToken)
+
+	refreshTokenRepo.AssertExpectations(t)
+	notifier.AssertExpectations(t)
+}
+
+func TestAuthService_RefreshAccessTokenLoggedOutTokenIsNotReuse(t *testing.T) {
+	service, _, refreshTokenRepo, notifier, manager := setupAuthServiceTest(t)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	refreshToken, err := manager.GenerateRefreshToken(userID)
+	require.NoError(t, err)
+	tokenHash := jwtpkg.HashToken(refreshToken)
+
+	refreshTokenRepo.On("GetByHash", ctx, tokenHash).Return(userID, time.Now().Add(time.Hour), true, nil)
+	refreshTokenRepo.On("GetSessionID", ctx, tokenHash).Return(uuid.New(), nil)
+	refreshTokenRepo.On("IsRotated", ctx, tokenHash).Return(false, nil)
+
+	_, _, err = service.RefreshAccessToken(ctx, refreshToken, models.SessionClient{})
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, ErrRefreshTokenReused))
+
+	refreshTokenRepo.AssertNotCalled(t, "RevokeSession", mock.Anything, mock.Anything)
+	notifier.AssertNotCalled(t, "NotifyRefreshTokenReuse", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthService_RefreshAccessTokenKeepsMFAVerification(t *testing.T) {
+	service, userRepo, refreshTokenRepo, _, manager := setupAuthServiceTest(t)
+	ctx := context.Background()
+
+	user := &models.User{ID: uuid.New(), Username: "mod", Role: models.RoleModerator}
+	refreshToken, err := manager.GenerateRefreshToken(user.ID)
+	require.NoError(t, err)
+	tokenHash := jwtpkg.HashToken(refreshToken)
+	sessionID := uuid.New()
+
+	refreshTokenRepo.On("GetByHash", ctx, tokenHash).Return(user.ID, time.Now().Add(time.Hour), false, nil)
+	refreshTokenRepo.On("GetSessionID", ctx, tokenHash).Return(sessionID, nil)
+	refreshTokenRepo.On("IsMFAVerified", ctx, tokenHash).Return(true, nil)
+	userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	refreshTokenRepo.On("Rotate", ctx, tokenHash, user.ID, sessionID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time"), models.SessionClient{}).Return(nil)
+
+	accessToken, _, err := service.RefreshAccessToken(ctx, refreshToken, models.SessionClient{})
+	require.NoError(t, err)
+
+	claims, err := manager.ValidateToken(accessToken)
+	require.NoError(t, err)
+	assert.True(t, claims.MFAVerified, "refreshing keeps the session's second factor")
+}
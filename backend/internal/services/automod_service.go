@@ -0,0 +1,477 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+// automodRuleCacheTTL bounds how long rule changes take to reach other API
+// instances
+const automodRuleCacheTTL = time.Minute
+
+// Automoderation rule errors
+var (
+	// ErrAutomodRuleNoConditions is returned for rules that would match everything
+	ErrAutomodRuleNoConditions = errors.New("rule must have at least one keyword, pattern, link, karma or account age condition")
+	// ErrAutomodRuleInvalidPattern is returned for patterns that aren't valid regular expressions
+	ErrAutomodRuleInvalidPattern = errors.New("rule pattern is not a valid regular expression")
+	// ErrAutomodRuleNoLinkDomains is returned for allowlist and blocklist link policies without domains
+	ErrAutomodRuleNoLinkDomains = errors.New("allowlist and blocklist link policies need link_domains")
+	// ErrAutomodRuleInvalidOverride is returned when an override isn't a
+	// community rule replacing an existing global rule
+	ErrAutomodRuleInvalidOverride = errors.New("only community rules can override a rule, and only global rules can be overridden")
+)
+
+// automodActionStrength orders actions so the strongest matching one is taken
+var automodActionStrength = map[string]int{
+	models.AutomodActionFlag:       1,
+	models.AutomodActionHold:       2,
+	models.AutomodActionShadowHide: 3,
+	models.AutomodActionRemove:     4,
+}
+
+// automodQueuePriority is the moderation queue priority of content by the
+// action taken on it. Hidden content waits on review, so it comes first.
+var automodQueuePriority = map[string]int{
+	models.AutomodActionFlag:       50,
+	models.AutomodActionHold:       70,
+	models.AutomodActionShadowHide: 70,
+	models.AutomodActionRemove:     60,
+}
+
+// automodLinkPattern finds links, with or without a scheme
+var automodLinkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"'()\[\]]+`)
+
+// AutomodRuleRepositoryInterface defines the data access needed for automoderation
+type AutomodRuleRepositoryInterface interface {
+	ListRules(ctx context.Context) ([]models.AutomodRule, error)
+	ListEvaluatedRules(ctx context.Context) ([]models.AutomodRule, error)
+	GetRule(ctx context.Context, ruleID uuid.UUID) (*models.AutomodRule, error)
+	CommunityExists(ctx context.Context, communityID uuid.UUID) (bool, error)
+	CreateRule(ctx context.Context, rule *models.AutomodRule) error
+	UpdateRule(ctx context.Context, rule *models.AutomodRule) error
+	DeleteRule(ctx context.Context, ruleID uuid.UUID) error
+	ListClipCommunityIDs(ctx context.Context, clipID uuid.UUID) ([]uuid.UUID, error)
+	RecordMatches(ctx context.Context, contentType string, contentID uuid.UUID, verdict *models.AutomodVerdict, priority int) error
+}
+
+// AutomodUserRepository looks up the authors content is evaluated for
+type AutomodUserRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+}
+
+// AutomodContent is a comment or clip submission evaluated against the
+// automoderation rules
+type AutomodContent struct {
+	Target   string
+	Text     string
+	AuthorID uuid.UUID
+	// ClipID is the clip commented on; the rules of its communities apply too
+	ClipID *uuid.UUID
+}
+
+// ContentAutomod evaluates new comments and submissions against the
+// automoderation rules. Implemented by AutomodService.
+type ContentAutomod interface {
+	Evaluate(ctx context.Context, content *AutomodContent) *models.AutomodVerdict
+	Report(ctx context.Context, contentType string, contentID uuid.UUID, verdict *models.AutomodVerdict)
+}
+
+// compiledAutomodRule is a rule with its keywords and patterns compiled
+type compiledAutomodRule struct {
+	models.AutomodRule
+	keywords []*regexp.Regexp
+	patterns []*regexp.Regexp
+}
+
+// AutomodService manages admin automoderation rules and evaluates comments
+// and clip submissions against them
+type AutomodService struct {
+	repo  AutomodRuleRepositoryInterface
+	users AutomodUserRepository
+	now   func() time.Time
+
+	mu       sync.Mutex
+	rules    []compiledAutomodRule
+	loadedAt time.Time
+}
+
+// NewAutomodService creates a new AutomodService
+func NewAutomodService(repo AutomodRuleRepositoryInterface, users AutomodUserRepository) *AutomodService {
+	return &AutomodService{
+		repo:  repo,
+		users: users,
+		now:   time.Now,
+	}
+}
+
+// ListRules returns all rules
+func (s *AutomodService) ListRules(ctx context.Context) ([]models.AutomodRule, error) {
+	return s.repo.ListRules(ctx)
+}
+
+// GetRule returns a rule
+func (s *AutomodService) GetRule(ctx context.Context, ruleID uuid.UUID) (*models.AutomodRule, error) {
+	return s.repo.GetRule(ctx, ruleID)
+}
+
+// CreateRule validates and creates a rule
+func (s *AutomodService) CreateRule(ctx context.Context, adminID uuid.UUID, req *models.AutomodRuleRequest) (*models.AutomodRule, error) {
+	rule := &models.AutomodRule{CreatedBy: &adminID}
+	if err := s.applyRequest(ctx, rule, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+	return rule, nil
+}
+
+// UpdateRule replaces a rule's settings
+func (s *AutomodService) UpdateRule(ctx context.Context, ruleID uuid.UUID, req *models.AutomodRuleRequest) (*models.AutomodRule, error) {
+	rule, err := s.repo.GetRule(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyRequest(ctx, rule, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+	return rule, nil
+}
+
+// DeleteRule deletes a rule
+func (s *AutomodService) DeleteRule(ctx context.Context, ruleID uuid.UUID) error {
+	if err := s.repo.DeleteRule(ctx, ruleID); err != nil {
+		return err
+	}
+	s.invalidate()
+	return nil
+}
+
+func (s *AutomodService) applyRequest(ctx context.Context, rule *models.AutomodRule, req *models.AutomodRuleRequest) error {
+	rule.Name = strings.TrimSpace(req.Name)
+	rule.Enabled = req.Enabled == nil || *req.Enabled
+	rule.Targets = normalizeRuleValues(req.Targets, true)
+	if len(rule.Targets) == 0 {
+		rule.Targets = []string{models.AutomodTargetComment, models.AutomodTargetSubmission}
+	}
+	rule.Keywords = normalizeRuleValues(req.Keywords, true)
+	rule.Patterns = normalizeRuleValues(req.Patterns, false)
+	rule.LinkPolicy = req.LinkPolicy
+	if rule.LinkPolicy == "" {
+		rule.LinkPolicy = models.AutomodLinkPolicyNone
+	}
+	rule.LinkDomains = nil
+	if rule.LinkPolicy == models.AutomodLinkPolicyAllowlist || rule.LinkPolicy == models.AutomodLinkPolicyBlocklist {
+		rule.LinkDomains = normalizeRuleValues(req.LinkDomains, true)
+	}
+	rule.MinKarma = req.MinKarma
+	rule.MinAccountAgeDays = req.MinAccountAgeDays
+	rule.Action = req.Action
+	rule.CommunityID = req.CommunityID
+	rule.OverridesRuleID = req.OverridesRuleID
+
+	// A disabled override only turns the global rule off, so needs no conditions
+	turnsOff := rule.OverridesRuleID != nil && !rule.Enabled
+	if !rule.HasConditions() && !turnsOff {
+		return ErrAutomodRuleNoConditions
+	}
+	if rule.LinkDomains != nil && len(rule.LinkDomains) == 0 {
+		return ErrAutomodRuleNoLinkDomains
+	}
+	for _, pattern := range rule.Patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("%w: %s", ErrAutomodRuleInvalidPattern, pattern)
+		}
+	}
+
+	if rule.CommunityID != nil {
+		exists, err := s.repo.CommunityExists(ctx, *rule.CommunityID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return repository.ErrAutomodCommunityNotFound
+		}
+	}
+
+	if rule.OverridesRuleID != nil {
+		if rule.CommunityID == nil || *rule.OverridesRuleID == rule.ID {
+			return ErrAutomodRuleInvalidOverride
+		}
+		overridden, err := s.repo.GetRule(ctx, *rule.OverridesRuleID)
+		if errors.Is(err, repository.ErrAutomodRuleNotFound) {
+			return ErrAutomodRuleInvalidOverride
+		}
+		if err != nil {
+			return err
+		}
+		if overridden.CommunityID != nil {
+			return ErrAutomodRuleInvalidOverride
+		}
+	}
+	return nil
+}
+
+// Evaluate returns the verdict of the rules that apply to a comment or
+// submission, or nil when none match. Staff are exempt. Evaluation is best
+// effort: when rules or the author can't be loaded the content is allowed.
+func (s *AutomodService) Evaluate(ctx context.Context, content *AutomodContent) *models.AutomodVerdict {
+	rules, err := s.evaluatedRules(ctx)
+	if err != nil {
+		utils.Warn("Failed to load automod rules", map[string]interface{}{"error": err.Error()})
+		return nil
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	author, err := s.users.GetByID(ctx, content.AuthorID)
+	if err != nil {
+		utils.Warn("Failed to load author for automod", map[string]interface{}{
+			"user_id": content.AuthorID.String(),
+			"error":   err.Error(),
+		})
+		return nil
+	}
+	if author.IsStaff() {
+		return nil
+	}
+
+	var communityIDs []uuid.UUID
+	if content.ClipID != nil {
+		communityIDs, err = s.repo.ListClipCommunityIDs(ctx, *content.ClipID)
+		if err != nil {
+			// Global rules still apply
+			utils.Warn("Failed to load clip communities for automod", map[string]interface{}{
+				"clip_id": content.ClipID.String(),
+				"error":   err.Error(),
+			})
+		}
+	}
+
+	var verdict *models.AutomodVerdict
+	for _, rule := range applicableAutomodRules(rules, communityIDs) {
+		reason, ok := rule.match(content, author, s.now())
+		if !ok {
+			continue
+		}
+		if verdict == nil {
+			verdict = &models.AutomodVerdict{Action: rule.Action}
+		} else if automodActionStrength[rule.Action] > automodActionStrength[verdict.Action] {
+			verdict.Action = rule.Action
+		}
+		verdict.Matches = append(verdict.Matches, models.AutomodMatch{
+			RuleID:   rule.ID,
+			RuleName: rule.Name,
+			Action:   rule.Action,
+			Reason:   reason,
+		})
+	}
+	return verdict
+}
+
+// Report queues automoderated content for moderators with its verdict and
+// counts the matching rules' hits. Failures are logged rather than failing
+// the comment or submission.
+func (s *AutomodService) Report(ctx context.Context, contentType string, contentID uuid.UUID, verdict *models.AutomodVerdict) {
+	if verdict == nil {
+		return
+	}
+	if err := s.repo.RecordMatches(ctx, contentType, contentID, verdict, automodQueuePriority[verdict.Action]); err != nil {
+		utils.Warn("Failed to record automod matches", map[string]interface{}{
+			"content_type": contentType,
+			"content_id":   contentID.String(),
+			"error":        err.Error(),
+		})
+	}
+}
+
+// applicableAutomodRules returns the enabled rules that apply to content in
+// the given communities: global rules no community overrides, and the
+// communities' own rules. An override in any of the communities replaces
+// the global rule.
+func applicableAutomodRules(rules []compiledAutomodRule, communityIDs []uuid.UUID) []*compiledAutomodRule {
+	overridden := make(map[uuid.UUID]bool)
+	for i := range rules {
+		rule := &rules[i]
+		if rule.OverridesRuleID != nil && slices.Contains(communityIDs, *rule.CommunityID) {
+			overridden[*rule.OverridesRuleID] = true
+		}
+	}
+
+	var applicable []*compiledAutomodRule
+	for i := range rules {
+		rule := &rules[i]
+		if !rule.Enabled {
+			continue
+		}
+		if rule.CommunityID == nil {
+			if overridden[rule.ID] {
+				continue
+			}
+		} else if !slices.Contains(communityIDs, *rule.CommunityID) {
+			continue
+		}
+		applicable = append(applicable, rule)
+	}
+	return applicable
+}
+
+// match reports whether content meets all of the rule's conditions, with a
+// description of what matched
+func (r *compiledAutomodRule) match(content *AutomodContent, author *models.User, now time.Time) (string, bool) {
+	if !slices.Contains(r.Targets, content.Target) {
+		return "", false
+	}
+
+	var reasons []string
+	if len(r.keywords) > 0 {
+		keyword, ok := firstAutomodMatch(r.keywords, r.Keywords, content.Text)
+		if !ok {
+			return "", false
+		}
+		reasons = append(reasons, fmt.Sprintf("keyword %q", keyword))
+	}
+	if len(r.patterns) > 0 {
+		pattern, ok := firstAutomodMatch(r.patterns, r.Patterns, content.Text)
+		if !ok {
+			return "", false
+		}
+		reasons = append(reasons, fmt.Sprintf("pattern %q", pattern))
+	}
+	if r.LinkPolicy != models.AutomodLinkPolicyNone {
+		host, ok := r.matchLink(content.Text)
+		if !ok {
+			return "", false
+		}
+		reasons = append(reasons, "link to "+host)
+	}
+	if r.MinKarma != nil {
+		if author.KarmaPoints >= *r.MinKarma {
+			return "", false
+		}
+		reasons = append(reasons, fmt.Sprintf("karma below %d", *r.MinKarma))
+	}
+	if r.MinAccountAgeDays != nil {
+		if now.Sub(author.CreatedAt) >= time.Duration(*r.MinAccountAgeDays)*24*time.Hour {
+			return "", false
+		}
+		reasons = append(reasons, fmt.Sprintf("account younger than %d days", *r.MinAccountAgeDays))
+	}
+	return strings.Join(reasons, ", "), true
+}
+
+// matchLink returns the host of the first link in text the rule's link
+// policy matches
+func (r *compiledAutomodRule) matchLink(text string) (string, bool) {
+	for _, link := range automodLinkPattern.FindAllString(text, -1) {
+		host := automodLinkHost(link)
+		listed := slices.ContainsFunc(r.LinkDomains, func(domain string) bool {
+			return host == domain || strings.HasSuffix(host, "."+domain)
+		})
+		switch {
+		case r.LinkPolicy == models.AutomodLinkPolicyBlock,
+			r.LinkPolicy == models.AutomodLinkPolicyAllowlist && !listed,
+			r.LinkPolicy == models.AutomodLinkPolicyBlocklist && listed:
+			return host, true
+		}
+	}
+	return "", false
+}
+
+// automodLinkHost returns the lowercased host of a link found in text
+func automodLinkHost(link string) string {
+	link = strings.ToLower(link)
+	if _, rest, ok := strings.Cut(link, "://"); ok {
+		link = rest
+	}
+	if i := strings.IndexAny(link, "/?#:"); i >= 0 {
+		link = link[:i]
+	}
+	return strings.TrimSuffix(link, ".")
+}
+
+// firstAutomodMatch returns the source of the first compiled expression
+// matching text
+func firstAutomodMatch(compiled []*regexp.Regexp, sources []string, text string) (string, bool) {
+	for i, re := range compiled {
+		if re.MatchString(text) {
+			return sources[i], true
+		}
+	}
+	return "", false
+}
+
+// compileAutomodRule compiles a rule's keywords, matched as whole words
+// case-insensitively, and its patterns
+func compileAutomodRule(rule models.AutomodRule) (compiledAutomodRule, error) {
+	compiled := compiledAutomodRule{AutomodRule: rule}
+	for _, keyword := range rule.Keywords {
+		compiled.keywords = append(compiled.keywords, regexp.MustCompile(`(?i)(?:^|\W)`+regexp.QuoteMeta(keyword)+`(?:$|\W)`))
+	}
+	for _, pattern := range rule.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return compiled, fmt.Errorf("%w: %s", ErrAutomodRuleInvalidPattern, pattern)
+		}
+		compiled.patterns = append(compiled.patterns, re)
+	}
+	return compiled, nil
+}
+
+// evaluatedRules returns the compiled rules, reloading them once the cached
+// copy is older than automodRuleCacheTTL
+func (s *AutomodService) evaluatedRules(ctx context.Context) ([]compiledAutomodRule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.rules != nil && s.now().Sub(s.loadedAt) < automodRuleCacheTTL {
+		return s.rules, nil
+	}
+
+	rules, err := s.repo.ListEvaluatedRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	compiled := make([]compiledAutomodRule, 0, len(rules))
+	for _, rule := range rules {
+		c, err := compileAutomodRule(rule)
+		if err != nil {
+			// Rules are validated when saved, so skip rather than drop every rule
+			utils.Warn("Skipping invalid automod rule", map[string]interface{}{
+				"rule_id": rule.ID.String(),
+				"error":   err.Error(),
+			})
+			continue
+		}
+		compiled = append(compiled, c)
+	}
+	s.rules = compiled
+	s.loadedAt = s.now()
+	return compiled, nil
+}
+
+func (s *AutomodService) invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rules = nil
+}
@@ -0,0 +1,247 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+// MockAutomodRuleRepository is a mock implementation of AutomodRuleRepositoryInterface
+type MockAutomodRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockAutomodRuleRepository) ListRules(ctx context.Context) ([]models.AutomodRule, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.AutomodRule), args.Error(1)
+}
+
+func (m *MockAutomodRuleRepository) ListEvaluatedRules(ctx context.Context) ([]models.AutomodRule, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.AutomodRule), args.Error(1)
+}
+
+func (m *MockAutomodRuleRepository) GetRule(ctx context.Context, ruleID uuid.UUID) (*models.AutomodRule, error) {
+	args := m.Called(ctx, ruleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AutomodRule), args.Error(1)
+}
+
+func (m *MockAutomodRuleRepository) CommunityExists(ctx context.Context, communityID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, communityID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockAutomodRuleRepository) CreateRule(ctx context.Context, rule *models.AutomodRule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockAutomodRuleRepository) UpdateRule(ctx context.Context, rule *models.AutomodRule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockAutomodRuleRepository) DeleteRule(ctx context.Context, ruleID uuid.UUID) error {
+	args := m.Called(ctx, ruleID)
+	return args.Error(0)
+}
+
+func (m *MockAutomodRuleRepository) ListClipCommunityIDs(ctx context.Context, clipID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, clipID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockAutomodRuleRepository) RecordMatches(ctx context.Context, contentType string, contentID uuid.UUID, verdict *models.AutomodVerdict, priority int) error {
+	args := m.Called(ctx, contentType, contentID, verdict, priority)
+	return args.Error(0)
+}
+
+func setupAutomodServiceTest(t *testing.T) (*AutomodService, *MockAutomodRuleRepository, *models.User) {
+	t.Helper()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	author := &models.User{ID: uuid.New(), Role: models.RoleUser, KarmaPoints: 5, CreatedAt: now.Add(-48 * time.Hour)}
+	repo := new(MockAutomodRuleRepository)
+	users := new(MockUserRepository)
+	users.On("GetByID", mock.Anything, author.ID).Return(author, nil)
+	svc := NewAutomodService(repo, users)
+	svc.now = func() time.Time { return now }
+	return svc, repo, author
+}
+
+// createAutomodRule creates a rule through the service, which the repository
+// stores with a new ID
+func createAutomodRule(t *testing.T, svc *AutomodService, repo *MockAutomodRuleRepository, req models.AutomodRuleRequest) *models.AutomodRule {
+	t.Helper()
+	repo.On("CreateRule", mock.Anything, mock.AnythingOfType("*models.AutomodRule")).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.AutomodRule).ID = uuid.New()
+	}).Return(nil).Once()
+	rule, err := svc.CreateRule(context.Background(), uuid.New(), &req)
+	require.NoError(t, err)
+	return rule
+}
+
+// expectEvaluatedAutomodRules expects the rules to be loaded for evaluation
+func expectEvaluatedAutomodRules(repo *MockAutomodRuleRepository, rules ...*models.AutomodRule) {
+	evaluated := make([]models.AutomodRule, 0, len(rules))
+	for _, rule := range rules {
+		evaluated = append(evaluated, *rule)
+	}
+	repo.On("ListEvaluatedRules", mock.Anything).Return(evaluated, nil)
+}
+
+func TestAutomod_Conditions(t *testing.T) {
+	svc, repo, author := setupAutomodServiceTest(t)
+	ctx := context.Background()
+	comment := func(text string) *AutomodContent {
+		return &AutomodContent{Target: models.AutomodTargetComment, Text: text, AuthorID: author.ID}
+	}
+
+	scams := createAutomodRule(t, svc, repo, models.AutomodRuleRequest{Name: "Scams", Keywords: []string{"Free Skins"}, Action: models.AutomodActionRemove})
+	expectEvaluatedAutomodRules(repo, scams)
+	assert.Nil(t, svc.Evaluate(ctx, comment("free skinship")), "keywords match whole words")
+	verdict := svc.Evaluate(ctx, comment("Get FREE SKINS now!"))
+	require.NotNil(t, verdict)
+	assert.Equal(t, models.AutomodActionRemove, verdict.Action)
+	assert.Equal(t, `keyword "free skins"`, verdict.Matches[0].Reason)
+
+	svc, repo, author = setupAutomodServiceTest(t)
+	days := 7
+	newAccountLinks := createAutomodRule(t, svc, repo, models.AutomodRuleRequest{
+		Name:              "New account links",
+		LinkPolicy:        models.AutomodLinkPolicyAllowlist,
+		LinkDomains:       []string{"Twitch.tv", "youtube.com"},
+		MinAccountAgeDays: &days,
+		Action:            models.AutomodActionHold,
+	})
+	expectEvaluatedAutomodRules(repo, newAccountLinks)
+	assert.Nil(t, svc.Evaluate(ctx, comment("see https://clips.twitch.tv/abc and www.youtube.com/watch")))
+	verdict = svc.Evaluate(ctx, comment("see https://clips.twitch.tv/abc and http://Spam.example/x"))
+	require.NotNil(t, verdict)
+	assert.Equal(t, "link to spam.example, account younger than 7 days", verdict.Matches[0].Reason)
+
+	author.CreatedAt = author.CreatedAt.Add(-30 * 24 * time.Hour)
+	assert.Nil(t, svc.Evaluate(ctx, comment("http://spam.example/x")), "every condition must match")
+}
+
+func TestAutomod_StrongestActionWins(t *testing.T) {
+	svc, repo, author := setupAutomodServiceTest(t)
+	ctx := context.Background()
+
+	flag := createAutomodRule(t, svc, repo, models.AutomodRuleRequest{Name: "Flag", Patterns: []string{`(?i)disc(or)?d\.gg`}, Action: models.AutomodActionFlag})
+	shadow := createAutomodRule(t, svc, repo, models.AutomodRuleRequest{Name: "Shadow", Keywords: []string{"invite"}, Action: models.AutomodActionShadowHide})
+	minKarma := 10
+	lowKarma := createAutomodRule(t, svc, repo, models.AutomodRuleRequest{
+		Name:     "Low karma submissions",
+		Targets:  []string{models.AutomodTargetSubmission},
+		MinKarma: &minKarma,
+		Action:   models.AutomodActionRemove,
+	})
+	expectEvaluatedAutomodRules(repo, flag, shadow, lowKarma)
+
+	verdict := svc.Evaluate(ctx, &AutomodContent{Target: models.AutomodTargetComment, Text: "invite: discord.gg/x", AuthorID: author.ID})
+	require.NotNil(t, verdict)
+	assert.Equal(t, models.AutomodActionShadowHide, verdict.Action)
+	assert.Len(t, verdict.Matches, 2)
+	assert.True(t, verdict.Hides())
+
+	contentID := uuid.New()
+	repo.On("RecordMatches", mock.Anything, models.AutomodTargetComment, contentID, verdict, automodQueuePriority[models.AutomodActionShadowHide]).Return(nil).Once()
+	svc.Report(ctx, models.AutomodTargetComment, contentID, verdict)
+
+	verdict = svc.Evaluate(ctx, &AutomodContent{Target: models.AutomodTargetSubmission, Text: "great play", AuthorID: author.ID})
+	require.NotNil(t, verdict)
+	assert.Equal(t, models.AutomodActionRemove, verdict.Action)
+
+	author.Role = models.RoleModerator
+	assert.Nil(t, svc.Evaluate(ctx, &AutomodContent{Target: models.AutomodTargetSubmission, Text: "great play", AuthorID: author.ID}), "staff are exempt")
+	repo.AssertExpectations(t)
+}
+
+func TestAutomod_CommunityOverrides(t *testing.T) {
+	svc, repo, author := setupAutomodServiceTest(t)
+	ctx := context.Background()
+	communityID := uuid.New()
+	repo.On("CommunityExists", mock.Anything, communityID).Return(true, nil)
+	clipInCommunity := uuid.New()
+	repo.On("ListClipCommunityIDs", mock.Anything, clipInCommunity).Return([]uuid.UUID{communityID}, nil)
+	otherClip := uuid.New()
+	repo.On("ListClipCommunityIDs", mock.Anything, otherClip).Return([]uuid.UUID{}, nil)
+
+	global := createAutomodRule(t, svc, repo, models.AutomodRuleRequest{Name: "No links", LinkPolicy: models.AutomodLinkPolicyBlock, Action: models.AutomodActionHold})
+	repo.On("GetRule", mock.Anything, global.ID).Return(global, nil)
+	disabled := false
+	override := createAutomodRule(t, svc, repo, models.AutomodRuleRequest{
+		Name:            "Links are fine here",
+		Enabled:         &disabled,
+		Action:          models.AutomodActionHold,
+		CommunityID:     &communityID,
+		OverridesRuleID: &global.ID,
+	})
+	spoilers := createAutomodRule(t, svc, repo, models.AutomodRuleRequest{Name: "Spoilers", Keywords: []string{"spoiler"}, Action: models.AutomodActionFlag, CommunityID: &communityID})
+	expectEvaluatedAutomodRules(repo, global, override, spoilers)
+
+	evaluate := func(clipID uuid.UUID, text string) *models.AutomodVerdict {
+		return svc.Evaluate(ctx, &AutomodContent{Target: models.AutomodTargetComment, Text: text, AuthorID: author.ID, ClipID: &clipID})
+	}
+
+	assert.Nil(t, evaluate(clipInCommunity, "https://example.com"), "override turns the global rule off")
+	verdict := evaluate(otherClip, "https://example.com")
+	require.NotNil(t, verdict)
+	assert.Equal(t, models.AutomodActionHold, verdict.Action)
+
+	assert.Nil(t, evaluate(otherClip, "spoiler ahead"), "community rules stay in their community")
+	verdict = evaluate(clipInCommunity, "spoiler ahead")
+	require.NotNil(t, verdict)
+	assert.Equal(t, models.AutomodActionFlag, verdict.Action)
+}
+
+func TestAutomod_RuleValidation(t *testing.T) {
+	svc, repo, _ := setupAutomodServiceTest(t)
+	ctx := context.Background()
+	adminID := uuid.New()
+
+	_, err := svc.CreateRule(ctx, adminID, &models.AutomodRuleRequest{Name: "Empty", Action: models.AutomodActionFlag})
+	assert.ErrorIs(t, err, ErrAutomodRuleNoConditions)
+
+	_, err = svc.CreateRule(ctx, adminID, &models.AutomodRuleRequest{Name: "Bad", Patterns: []string{"(unclosed"}, Action: models.AutomodActionFlag})
+	assert.ErrorIs(t, err, ErrAutomodRuleInvalidPattern)
+
+	_, err = svc.CreateRule(ctx, adminID, &models.AutomodRuleRequest{Name: "Allow", LinkPolicy: models.AutomodLinkPolicyAllowlist, Action: models.AutomodActionFlag})
+	assert.ErrorIs(t, err, ErrAutomodRuleNoLinkDomains)
+
+	missing := uuid.New()
+	repo.On("CommunityExists", mock.Anything, missing).Return(false, nil).Once()
+	_, err = svc.CreateRule(ctx, adminID, &models.AutomodRuleRequest{Name: "Community", Keywords: []string{"x"}, Action: models.AutomodActionFlag, CommunityID: &missing})
+	assert.ErrorIs(t, err, repository.ErrAutomodCommunityNotFound)
+
+	global := createAutomodRule(t, svc, repo, models.AutomodRuleRequest{Name: "Global", Keywords: []string{"x"}, Action: models.AutomodActionFlag})
+	_, err = svc.CreateRule(ctx, adminID, &models.AutomodRuleRequest{Name: "Override", Keywords: []string{"y"}, Action: models.AutomodActionFlag, OverridesRuleID: &global.ID})
+	assert.ErrorIs(t, err, ErrAutomodRuleInvalidOverride, "overrides need a community")
+
+	communityID := uuid.New()
+	repo.On("CommunityExists", mock.Anything, communityID).Return(true, nil).Twice()
+	local := createAutomodRule(t, svc, repo, models.AutomodRuleRequest{Name: "Local", Keywords: []string{"x"}, Action: models.AutomodActionFlag, CommunityID: &communityID})
+	repo.On("GetRule", mock.Anything, local.ID).Return(local, nil).Once()
+	_, err = svc.CreateRule(ctx, adminID, &models.AutomodRuleRequest{Name: "Override", Keywords: []string{"y"}, Action: models.AutomodActionFlag, CommunityID: &communityID, OverridesRuleID: &local.ID})
+	assert.ErrorIs(t, err, ErrAutomodRuleInvalidOverride, "only global rules can be overridden")
+	repo.AssertExpectations(t)
+}
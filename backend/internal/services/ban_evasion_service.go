@@ -0,0 +1,351 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const (
+	// banEvasionFlagsListLimit caps how many flags are listed per page
+	banEvasionFlagsListLimit = 100
+	// linkedAccountsLimit caps how many linked accounts are listed for a user
+	linkedAccountsLimit = 50
+	// maxFingerprintLength caps the device fingerprint header value that is hashed
+	maxFingerprintLength = 512
+)
+
+// banEvasionSignalWeights is how strongly sharing each signal with a banned
+// account suggests the same person is behind both. A device fingerprint is
+// the strongest signal; IP addresses are shared by households and carriers.
+var banEvasionSignalWeights = map[string]float64{
+	models.AccountSignalDevice:      0.6,
+	models.AccountSignalIP:          0.35,
+	models.AccountSignalEmailDomain: 0.2,
+}
+
+// commonEmailDomains are the free mail providers whose domains say nothing
+// about who owns an account, so they aren't recorded as signals
+var commonEmailDomains = map[string]bool{
+	"gmail.com":      true,
+	"googlemail.com": true,
+	"outlook.com":    true,
+	"hotmail.com":    true,
+	"live.com":       true,
+	"msn.com":        true,
+	"yahoo.com":      true,
+	"icloud.com":     true,
+	"me.com":         true,
+	"aol.com":        true,
+	"proton.me":      true,
+	"protonmail.com": true,
+	"gmx.com":        true,
+	"mail.com":       true,
+	"yandex.com":     true,
+}
+
+var (
+	banEvasionFlagsTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "ban_evasion_flags_total",
+			Help: "Total number of new accounts flagged for sharing sign-in signals with banned accounts",
+		},
+	)
+
+	banEvasionReviewsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ban_evasion_reviews_total",
+			Help: "Total number of ban evasion flags reviewed",
+		},
+		[]string{"status"}, // "confirmed", "dismissed"
+	)
+)
+
+// BanEvasionRepositoryInterface defines the repository methods used by BanEvasionService
+type BanEvasionRepositoryInterface interface {
+	RecordSignals(ctx context.Context, userID uuid.UUID, signals []models.AccountSignal) error
+	FindBannedMatches(ctx context.Context, createdSince time.Time) ([]models.BanEvasionMatch, error)
+	CreateFlag(ctx context.Context, flag *models.BanEvasionFlag, priority int) (bool, error)
+	ListFlags(ctx context.Context, filters models.BanEvasionFlagFilters) ([]models.BanEvasionFlagWithUsers, error)
+	GetFlag(ctx context.Context, id uuid.UUID) (*models.BanEvasionFlagWithUsers, error)
+	ReviewFlag(ctx context.Context, id uuid.UUID, status, queueStatus string, reviewerID uuid.UUID, notes *string) (*models.BanEvasionFlag, error)
+	ListLinkedAccounts(ctx context.Context, userID uuid.UUID, limit int) ([]models.LinkedAccount, error)
+	PruneSignals(ctx context.Context, lastSeenBefore time.Time) (int64, error)
+}
+
+// BanEvasionBanner bans accounts confirmed to be evading a ban
+type BanEvasionBanner interface {
+	BanUser(ctx context.Context, userID, moderatorID uuid.UUID, req BanRequest) (*models.UserBan, error)
+}
+
+// SignInSignals are what a sign-in reveals about the account's owner
+type SignInSignals struct {
+	IPAddress         string
+	DeviceFingerprint string
+	Email             *string
+}
+
+// BanEvasionServiceConfig holds the ban evasion detection settings
+type BanEvasionServiceConfig struct {
+	HashSecret          string
+	NewAccountDays      int
+	MinConfidence       float64
+	SignalRetentionDays int
+}
+
+// BanEvasionService links sign-in signals to accounts and flags new accounts
+// that share them with banned accounts. Flags go to the moderation queue with
+// a confidence score, and moderators confirm them, banning the account, or
+// dismiss them.
+type BanEvasionService struct {
+	repo     BanEvasionRepositoryInterface
+	banner   BanEvasionBanner
+	auditLog UserBanAuditLogger
+	cfg      BanEvasionServiceConfig
+	now      func() time.Time
+}
+
+// NewBanEvasionService creates a new BanEvasionService
+func NewBanEvasionService(repo BanEvasionRepositoryInterface, banner BanEvasionBanner, auditLog UserBanAuditLogger, cfg BanEvasionServiceConfig) *BanEvasionService {
+	return &BanEvasionService{
+		repo:     repo,
+		banner:   banner,
+		auditLog: auditLog,
+		cfg:      cfg,
+		now:      time.Now,
+	}
+}
+
+// RecordSignIn links the signals from a sign-in to the user. IP addresses and
+// device fingerprints are stored as keyed hashes. Local and private addresses
+// and common free mail domains are left out, since they're shared by
+// unrelated accounts.
+func (s *BanEvasionService) RecordSignIn(ctx context.Context, userID uuid.UUID, in SignInSignals) error {
+	signals := s.signInSignals(in)
+	if len(signals) == 0 {
+		return nil
+	}
+	return s.repo.RecordSignals(ctx, userID, signals)
+}
+
+func (s *BanEvasionService) signInSignals(in SignInSignals) []models.AccountSignal {
+	var signals []models.AccountSignal
+	if ip := net.ParseIP(strings.TrimSpace(in.IPAddress)); ip != nil &&
+		!ip.IsLoopback() && !ip.IsPrivate() && !ip.IsUnspecified() && !ip.IsLinkLocalUnicast() {
+		signals = append(signals, models.AccountSignal{
+			SignalType:  models.AccountSignalIP,
+			SignalValue: s.hashSignal(ip.String()),
+		})
+	}
+	if fingerprint := strings.TrimSpace(in.DeviceFingerprint); fingerprint != "" && len(fingerprint) <= maxFingerprintLength {
+		signals = append(signals, models.AccountSignal{
+			SignalType:  models.AccountSignalDevice,
+			SignalValue: s.hashSignal(fingerprint),
+		})
+	}
+	if domain := emailDomain(in.Email); domain != "" && !commonEmailDomains[domain] {
+		signals = append(signals, models.AccountSignal{
+			SignalType:  models.AccountSignalEmailDomain,
+			SignalValue: domain,
+		})
+	}
+	return signals
+}
+
+// hashSignal hashes an IP address or device fingerprint with the configured key
+func (s *BanEvasionService) hashSignal(value string) string {
+	mac := hmac.New(sha256.New, []byte(s.cfg.HashSecret))
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// emailDomain returns the lowercased domain of an email address
+func emailDomain(email *string) string {
+	if email == nil {
+		return ""
+	}
+	at := strings.LastIndex(*email, "@")
+	if at < 0 || at == len(*email)-1 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace((*email)[at+1:]))
+}
+
+// banEvasionConfidence combines the kinds of signals two accounts share into
+// a 0-1 confidence that they belong to the same person. Each kind counts once,
+// however many values of it match.
+func banEvasionConfidence(signalTypes []string) float64 {
+	unrelated := 1.0
+	for _, signalType := range signalTypes {
+		unrelated *= 1 - banEvasionSignalWeights[signalType]
+	}
+	return math.Round((1-unrelated)*100) / 100
+}
+
+// DetectEvasion flags accounts created within the new account window that
+// share signals with banned accounts confidently enough, returning how many
+// were flagged. Each suspect and banned account pair is flagged once.
+func (s *BanEvasionService) DetectEvasion(ctx context.Context) (int, error) {
+	since := s.now().AddDate(0, 0, -s.cfg.NewAccountDays)
+	matches, err := s.repo.FindBannedMatches(ctx, since)
+	if err != nil {
+		return 0, err
+	}
+
+	type accountPair struct{ userID, bannedUserID uuid.UUID }
+	pairSignals := map[accountPair]map[string]bool{}
+	var pairs []accountPair
+	for _, m := range matches {
+		pair := accountPair{m.UserID, m.BannedUserID}
+		if pairSignals[pair] == nil {
+			pairSignals[pair] = map[string]bool{}
+			pairs = append(pairs, pair)
+		}
+		pairSignals[pair][m.SignalType] = true
+	}
+
+	flagged := 0
+	for _, pair := range pairs {
+		signalTypes := make([]string, 0, len(pairSignals[pair]))
+		for signalType := range pairSignals[pair] {
+			signalTypes = append(signalTypes, signalType)
+		}
+		sort.Strings(signalTypes)
+
+		confidence := banEvasionConfidence(signalTypes)
+		if confidence < s.cfg.MinConfidence {
+			continue
+		}
+
+		flag := &models.BanEvasionFlag{
+			UserID:         pair.userID,
+			BannedUserID:   pair.bannedUserID,
+			Confidence:     confidence,
+			MatchedSignals: signalTypes,
+		}
+		created, err := s.repo.CreateFlag(ctx, flag, int(math.Round(confidence*100)))
+		if err != nil {
+			return flagged, err
+		}
+		if created {
+			flagged++
+			banEvasionFlagsTotal.Inc()
+		}
+	}
+	return flagged, nil
+}
+
+// ListFlags returns ban evasion flags for review
+func (s *BanEvasionService) ListFlags(ctx context.Context, filters models.BanEvasionFlagFilters) ([]models.BanEvasionFlagWithUsers, error) {
+	if filters.Limit <= 0 || filters.Limit > banEvasionFlagsListLimit {
+		filters.Limit = banEvasionFlagsListLimit
+	}
+	if filters.Offset < 0 {
+		filters.Offset = 0
+	}
+	return s.repo.ListFlags(ctx, filters)
+}
+
+// GetFlag returns a ban evasion flag
+func (s *BanEvasionService) GetFlag(ctx context.Context, id uuid.UUID) (*models.BanEvasionFlagWithUsers, error) {
+	return s.repo.GetFlag(ctx, id)
+}
+
+// ListLinkedAccounts returns the accounts that share sign-in signals with a user
+func (s *BanEvasionService) ListLinkedAccounts(ctx context.Context, userID uuid.UUID) ([]models.LinkedAccount, error) {
+	return s.repo.ListLinkedAccounts(ctx, userID, linkedAccountsLimit)
+}
+
+// ConfirmFlag confirms a pending flag and permanently bans the suspect account
+func (s *BanEvasionService) ConfirmFlag(ctx context.Context, flagID, moderatorID uuid.UUID, notes *string) (*models.BanEvasionFlag, error) {
+	pending, err := s.repo.GetFlag(ctx, flagID)
+	if err != nil {
+		return nil, err
+	}
+	if pending.Status != models.BanEvasionFlagPending {
+		return nil, repository.ErrBanEvasionFlagNotFound
+	}
+
+	ban, err := s.banner.BanUser(ctx, pending.UserID, moderatorID, BanRequest{
+		Reason:    fmt.Sprintf("Ban evasion: linked to banned account %s", pending.BannedUsername),
+		Permanent: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	flag, err := s.repo.ReviewFlag(ctx, flagID, models.BanEvasionFlagConfirmed, "rejected", moderatorID, notes)
+	if err != nil {
+		return nil, err
+	}
+
+	banEvasionReviewsTotal.WithLabelValues(models.BanEvasionFlagConfirmed).Inc()
+	s.logAudit(ctx, repository.AuditActionConfirmBanEvasion, moderatorID, flag, notes, map[string]interface{}{
+		"ban_id": ban.ID.String(),
+	})
+	return flag, nil
+}
+
+// DismissFlag dismisses a pending flag, leaving the account as it is
+func (s *BanEvasionService) DismissFlag(ctx context.Context, flagID, moderatorID uuid.UUID, notes *string) (*models.BanEvasionFlag, error) {
+	flag, err := s.repo.ReviewFlag(ctx, flagID, models.BanEvasionFlagDismissed, "approved", moderatorID, notes)
+	if err != nil {
+		return nil, err
+	}
+
+	banEvasionReviewsTotal.WithLabelValues(models.BanEvasionFlagDismissed).Inc()
+	s.logAudit(ctx, repository.AuditActionDismissBanEvasion, moderatorID, flag, notes, nil)
+	return flag, nil
+}
+
+// PruneSignals deletes signals that haven't been seen within the retention
+// period, returning how many
+func (s *BanEvasionService) PruneSignals(ctx context.Context) (int, error) {
+	if s.cfg.SignalRetentionDays <= 0 {
+		return 0, nil
+	}
+	pruned, err := s.repo.PruneSignals(ctx, s.now().AddDate(0, 0, -s.cfg.SignalRetentionDays))
+	if err != nil {
+		return 0, err
+	}
+	return int(pruned), nil
+}
+
+// logAudit records a ban evasion review without failing the review itself
+func (s *BanEvasionService) logAudit(ctx context.Context, action string, moderatorID uuid.UUID, flag *models.BanEvasionFlag, notes *string, extra map[string]interface{}) {
+	if s.auditLog == nil {
+		return
+	}
+	metadata := map[string]interface{}{
+		"flag_id":         flag.ID.String(),
+		"banned_user_id":  flag.BannedUserID.String(),
+		"confidence":      flag.Confidence,
+		"matched_signals": flag.MatchedSignals,
+	}
+	for k, v := range extra {
+		metadata[k] = v
+	}
+	if err := s.auditLog.LogAction(ctx, action, moderatorID, flag.UserID, "user", AuditLogOptions{
+		Reason:   notes,
+		Metadata: metadata,
+	}); err != nil {
+		utils.GetLogger().Error("Failed to record ban evasion audit log", err, map[string]interface{}{
+			"action":  action,
+			"flag_id": flag.ID.String(),
+		})
+	}
+}
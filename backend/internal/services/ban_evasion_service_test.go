@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+// MockBanEvasionRepository is a mock implementation of BanEvasionRepositoryInterface
+type MockBanEvasionRepository struct {
+	mock.Mock
+}
+
+func (m *MockBanEvasionRepository) RecordSignals(ctx context.Context, userID uuid.UUID, signals []models.AccountSignal) error {
+	args := m.Called(ctx, userID, signals)
+	return args.Error(0)
+}
+
+func (m *MockBanEvasionRepository) FindBannedMatches(ctx context.Context, createdSince time.Time) ([]models.BanEvasionMatch, error) {
+	args := m.Called(ctx, createdSince)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.BanEvasionMatch), args.Error(1)
+}
+
+func (m *MockBanEvasionRepository) CreateFlag(ctx context.Context, flag *models.BanEvasionFlag, priority int) (bool, error) {
+	args := m.Called(ctx, flag, priority)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockBanEvasionRepository) ListFlags(ctx context.Context, filters models.BanEvasionFlagFilters) ([]models.BanEvasionFlagWithUsers, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.BanEvasionFlagWithUsers), args.Error(1)
+}
+
+func (m *MockBanEvasionRepository) GetFlag(ctx context.Context, id uuid.UUID) (*models.BanEvasionFlagWithUsers, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BanEvasionFlagWithUsers), args.Error(1)
+}
+
+func (m *MockBanEvasionRepository) ReviewFlag(ctx context.Context, id uuid.UUID, status, queueStatus string, reviewerID uuid.UUID, notes *string) (*models.BanEvasionFlag, error) {
+	args := m.Called(ctx, id, status, queueStatus, reviewerID, notes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BanEvasionFlag), args.Error(1)
+}
+
+func (m *MockBanEvasionRepository) ListLinkedAccounts(ctx context.Context, userID uuid.UUID, limit int) ([]models.LinkedAccount, error) {
+	args := m.Called(ctx, userID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.LinkedAccount), args.Error(1)
+}
+
+func (m *MockBanEvasionRepository) PruneSignals(ctx context.Context, lastSeenBefore time.Time) (int64, error) {
+	args := m.Called(ctx, lastSeenBefore)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockBanEvasionBanner is a mock implementation of BanEvasionBanner
+type MockBanEvasionBanner struct {
+	mock.Mock
+}
+
+func (m *MockBanEvasionBanner) BanUser(ctx context.Context, userID, moderatorID uuid.UUID, req BanRequest) (*models.UserBan, error) {
+	args := m.Called(ctx, userID, moderatorID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UserBan), args.Error(1)
+}
+
+func TestBanEvasionConfidence(t *testing.T) {
+	assert.Equal(t, 0.6, banEvasionConfidence([]string{models.AccountSignalDevice}))
+	assert.Equal(t, 0.35, banEvasionConfidence([]string{models.AccountSignalIP}))
+	assert.Equal(t, 0.74, banEvasionConfidence([]string{models.AccountSignalDevice, models.AccountSignalIP}))
+	assert.Equal(t, 0.79, banEvasionConfidence([]string{models.AccountSignalDevice, models.AccountSignalEmailDomain, models.AccountSignalIP}))
+	assert.Equal(t, 0.0, banEvasionConfidence(nil))
+}
+
+func TestBanEvasionService_RecordSignIn(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockBanEvasionRepository)
+	svc := NewBanEvasionService(repo, nil, nil, BanEvasionServiceConfig{HashSecret: "secret"})
+	userID := uuid.New()
+
+	var recorded []models.AccountSignal
+	repo.On("RecordSignals", ctx, userID, mock.Anything).Run(func(args mock.Arguments) {
+		recorded = args.Get(2).([]models.AccountSignal)
+	}).Return(nil).Once()
+	require.NoError(t, svc.RecordSignIn(ctx, userID, SignInSignals{
+		IPAddress:         "203.0.113.7",
+		DeviceFingerprint: "fp-123",
+		Email:             stringPtr("someone@Example.ORG"),
+	}))
+	require.Len(t, recorded, 3)
+	assert.Equal(t, models.AccountSignalIP, recorded[0].SignalType)
+	assert.NotContains(t, recorded[0].SignalValue, "203.0.113.7")
+	assert.Len(t, recorded[0].SignalValue, 64)
+	assert.Equal(t, models.AccountSignalDevice, recorded[1].SignalType)
+	assert.Equal(t, models.AccountSignalEmailDomain, recorded[2].SignalType)
+	assert.Equal(t, "example.org", recorded[2].SignalValue)
+
+	// Hashes are stable for the same key and differ across keys
+	other := NewBanEvasionService(repo, nil, nil, BanEvasionServiceConfig{HashSecret: "other"})
+	assert.Equal(t, recorded[0].SignalValue, svc.hashSignal("203.0.113.7"))
+	assert.NotEqual(t, svc.hashSignal("203.0.113.7"), other.hashSignal("203.0.113.7"))
+
+	// Local addresses and free mail domains are shared by unrelated accounts
+	require.NoError(t, svc.RecordSignIn(ctx, userID, SignInSignals{
+		IPAddress: "127.0.0.1",
+		Email:     stringPtr("someone@gmail.com"),
+	}))
+	require.NoError(t, svc.RecordSignIn(ctx, userID, SignInSignals{IPAddress: "10.1.2.3"}))
+	repo.AssertNumberOfCalls(t, "RecordSignals", 1)
+}
+
+func TestBanEvasionService_DetectEvasion(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockBanEvasionRepository)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	svc := NewBanEvasionService(repo, nil, nil, BanEvasionServiceConfig{NewAccountDays: 30, MinConfidence: 0.5})
+	svc.now = func() time.Time { return now }
+
+	deviceAndIP, ipOnly, banned := uuid.New(), uuid.New(), uuid.New()
+	repo.On("FindBannedMatches", ctx, now.AddDate(0, 0, -30)).Return([]models.BanEvasionMatch{
+		{UserID: deviceAndIP, BannedUserID: banned, SignalType: models.AccountSignalIP, SignalValue: "a"},
+		{UserID: deviceAndIP, BannedUserID: banned, SignalType: models.AccountSignalIP, SignalValue: "b"},
+		{UserID: deviceAndIP, BannedUserID: banned, SignalType: models.AccountSignalDevice, SignalValue: "c"},
+		{UserID: ipOnly, BannedUserID: banned, SignalType: models.AccountSignalIP, SignalValue: "a"},
+	}, nil).Twice()
+	var flag *models.BanEvasionFlag
+	repo.On("CreateFlag", ctx, mock.AnythingOfType("*models.BanEvasionFlag"), 74).Run(func(args mock.Arguments) {
+		flag = args.Get(1).(*models.BanEvasionFlag)
+	}).Return(true, nil).Once()
+
+	flagged, err := svc.DetectEvasion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, flagged)
+	require.NotNil(t, flag)
+	assert.Equal(t, deviceAndIP, flag.UserID)
+	assert.Equal(t, banned, flag.BannedUserID)
+	assert.Equal(t, 0.74, flag.Confidence)
+	assert.Equal(t, []string{models.AccountSignalDevice, models.AccountSignalIP}, flag.MatchedSignals)
+
+	// Pairs are flagged once
+	repo.On("CreateFlag", ctx, mock.AnythingOfType("*models.BanEvasionFlag"), 74).Return(false, nil).Once()
+	flagged, err = svc.DetectEvasion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, flagged)
+
+	repo.AssertExpectations(t)
+	repo.AssertNumberOfCalls(t, "CreateFlag", 2)
+}
+
+func TestBanEvasionService_ReviewFlags(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockBanEvasionRepository)
+	banner := new(MockBanEvasionBanner)
+	svc := NewBanEvasionService(repo, banner, nil, BanEvasionServiceConfig{})
+	moderatorID := uuid.New()
+	suspect, other := uuid.New(), uuid.New()
+
+	pending := &models.BanEvasionFlagWithUsers{
+		BanEvasionFlag: models.BanEvasionFlag{ID: uuid.New(), UserID: suspect, BannedUserID: uuid.New(), Confidence: 0.74, Status: models.BanEvasionFlagPending},
+		BannedUsername: "banned",
+	}
+	confirmedFlag := pending.BanEvasionFlag
+	confirmedFlag.Status = models.BanEvasionFlagConfirmed
+	repo.On("GetFlag", ctx, pending.ID).Return(pending, nil).Once()
+	banner.On("BanUser", ctx, suspect, moderatorID, mock.MatchedBy(func(req BanRequest) bool {
+		return req.Permanent
+	})).Return(&models.UserBan{ID: uuid.New(), UserID: suspect}, nil).Once()
+	repo.On("ReviewFlag", ctx, pending.ID, models.BanEvasionFlagConfirmed, "rejected", moderatorID, (*string)(nil)).Return(&confirmedFlag, nil).Once()
+
+	confirmed, err := svc.ConfirmFlag(ctx, pending.ID, moderatorID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, models.BanEvasionFlagConfirmed, confirmed.Status)
+
+	// A reviewed flag can't be reviewed again
+	repo.On("GetFlag", ctx, pending.ID).Return(&models.BanEvasionFlagWithUsers{BanEvasionFlag: confirmedFlag}, nil).Once()
+	_, err = svc.ConfirmFlag(ctx, pending.ID, moderatorID, nil)
+	assert.ErrorIs(t, err, repository.ErrBanEvasionFlagNotFound)
+	repo.On("ReviewFlag", ctx, pending.ID, models.BanEvasionFlagDismissed, "approved", moderatorID, (*string)(nil)).Return(nil, repository.ErrBanEvasionFlagNotFound).Once()
+	_, err = svc.DismissFlag(ctx, pending.ID, moderatorID, nil)
+	assert.ErrorIs(t, err, repository.ErrBanEvasionFlagNotFound)
+
+	dismissID := uuid.New()
+	notes := stringPtr("Shared office network")
+	repo.On("ReviewFlag", ctx, dismissID, models.BanEvasionFlagDismissed, "approved", moderatorID, notes).
+		Return(&models.BanEvasionFlag{ID: dismissID, UserID: other, Status: models.BanEvasionFlagDismissed, ReviewNotes: notes}, nil).Once()
+	dismissed, err := svc.DismissFlag(ctx, dismissID, moderatorID, notes)
+	require.NoError(t, err)
+	assert.Equal(t, models.BanEvasionFlagDismissed, dismissed.Status)
+
+	repo.AssertExpectations(t)
+	banner.AssertExpectations(t)
+	banner.AssertNotCalled(t, "BanUser", mock.Anything, other, mock.Anything, mock.Anything)
+}
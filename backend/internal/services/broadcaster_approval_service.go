@@ -0,0 +1,275 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+var (
+	// ErrNotVerifiedBroadcaster is returned when a user without a verified Twitch channel manages clip approval
+	ErrNotVerifiedBroadcaster = errors.New("only verified broadcasters can manage clip approval")
+	// ErrBroadcasterReviewResolved is returned when a held clip was already approved, rejected or expired
+	ErrBroadcasterReviewResolved = errors.New("clip has already been reviewed")
+	// ErrInvalidAutoApproveDays is returned when the auto-approval window is out of range
+	ErrInvalidAutoApproveDays = errors.New("auto_approve_after_days must be between 1 and 30")
+)
+
+// BroadcasterApprovalRepositoryInterface defines the repository methods used by BroadcasterApprovalService
+type BroadcasterApprovalRepositoryInterface interface {
+	GetPolicy(ctx context.Context, broadcasterID string) (*models.BroadcasterApprovalPolicy, error)
+	UpsertPolicy(ctx context.Context, policy *models.BroadcasterApprovalPolicy) error
+	CreateReview(ctx context.Context, review *models.BroadcasterClipReview) error
+	GetReview(ctx context.Context, submissionID uuid.UUID) (*models.BroadcasterClipReview, error)
+	ListPendingReviews(ctx context.Context, broadcasterID string, limit, offset int) ([]*models.BroadcasterClipReviewWithSubmission, int, error)
+	ResolveReview(ctx context.Context, submissionID uuid.UUID, status string, reviewedBy *uuid.UUID, rejectionReason *string) (bool, error)
+	ListExpiredReviews(ctx context.Context, now time.Time, limit int) ([]*models.BroadcasterClipReview, error)
+}
+
+// BroadcasterApprovalUserRepositoryInterface defines the user lookups used by BroadcasterApprovalService
+type BroadcasterApprovalUserRepositoryInterface interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+}
+
+// BroadcasterHoldReleaser moves held submissions on once the broadcaster step is decided.
+// Implemented by SubmissionService.
+type BroadcasterHoldReleaser interface {
+	ReleaseBroadcasterHold(ctx context.Context, submissionID uuid.UUID, expired bool) error
+	RejectBroadcasterHold(ctx context.Context, submissionID, broadcasterUserID uuid.UUID, reason string) error
+}
+
+// BroadcasterApprovalService lets verified broadcasters require their approval
+// before clips of their channel appear publicly
+type BroadcasterApprovalService struct {
+	repo                   BroadcasterApprovalRepositoryInterface
+	userRepo               BroadcasterApprovalUserRepositoryInterface
+	notificationService    *NotificationService
+	releaser               BroadcasterHoldReleaser
+	defaultAutoApproveDays int
+	now                    func() time.Time
+}
+
+// NewBroadcasterApprovalService creates a new BroadcasterApprovalService
+func NewBroadcasterApprovalService(
+	repo BroadcasterApprovalRepositoryInterface,
+	userRepo BroadcasterApprovalUserRepositoryInterface,
+	notificationService *NotificationService,
+	defaultAutoApproveDays int,
+) *BroadcasterApprovalService {
+	if defaultAutoApproveDays < 1 {
+		defaultAutoApproveDays = 7
+	}
+
+	return &BroadcasterApprovalService{
+		repo:                   repo,
+		userRepo:               userRepo,
+		notificationService:    notificationService,
+		defaultAutoApproveDays: defaultAutoApproveDays,
+		now:                    time.Now,
+	}
+}
+
+// SetHoldReleaser sets the service that releases or rejects held submissions
+func (s *BroadcasterApprovalService) SetHoldReleaser(releaser BroadcasterHoldReleaser) {
+	s.releaser = releaser
+}
+
+// GetPolicy returns the caller's approval policy, or the default (approval off) if none is set
+func (s *BroadcasterApprovalService) GetPolicy(ctx context.Context, userID uuid.UUID) (*models.BroadcasterApprovalPolicy, error) {
+	broadcasterID, err := verifiedBroadcasterID(ctx, s.userRepo, userID, ErrNotVerifiedBroadcaster)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := s.repo.GetPolicy(ctx, broadcasterID)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		days := s.defaultAutoApproveDays
+		policy = &models.BroadcasterApprovalPolicy{
+			BroadcasterID:        broadcasterID,
+			UserID:               userID,
+			AutoApproveAfterDays: &days,
+		}
+	}
+
+	return policy, nil
+}
+
+// UpdatePolicy turns clip approval on or off for the caller's channel. Clips
+// already held stay in the queue when approval is turned off.
+func (s *BroadcasterApprovalService) UpdatePolicy(ctx context.Context, userID uuid.UUID, req *models.UpdateBroadcasterApprovalPolicyRequest) (*models.BroadcasterApprovalPolicy, error) {
+	broadcasterID, err := verifiedBroadcasterID(ctx, s.userRepo, userID, ErrNotVerifiedBroadcaster)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.AutoApproveAfterDays != nil && (*req.AutoApproveAfterDays < 1 || *req.AutoApproveAfterDays > 30) {
+		return nil, ErrInvalidAutoApproveDays
+	}
+
+	policy := &models.BroadcasterApprovalPolicy{
+		BroadcasterID:        broadcasterID,
+		UserID:               userID,
+		RequireApproval:      req.RequireApproval,
+		AutoApproveAfterDays: req.AutoApproveAfterDays,
+	}
+	if err := s.repo.UpsertPolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// PolicyFor returns the policy that holds a submission of the broadcaster's
+// channel, or nil if the clip can skip broadcaster approval. Broadcasters never
+// need to approve clips they submit themselves.
+func (s *BroadcasterApprovalService) PolicyFor(ctx context.Context, broadcasterID string, submitter *models.User) (*models.BroadcasterApprovalPolicy, error) {
+	if broadcasterID == "" {
+		return nil, nil
+	}
+	if submitter != nil && submitter.TwitchID != nil && *submitter.TwitchID == broadcasterID {
+		return nil, nil
+	}
+
+	policy, err := s.repo.GetPolicy(ctx, broadcasterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get broadcaster approval policy: %w", err)
+	}
+	if policy == nil || !policy.RequireApproval {
+		return nil, nil
+	}
+
+	return policy, nil
+}
+
+// HoldSubmission adds a saved submission to the broadcaster's approval queue
+// and notifies both the submitter and the broadcaster
+func (s *BroadcasterApprovalService) HoldSubmission(ctx context.Context, policy *models.BroadcasterApprovalPolicy, submission *models.ClipSubmission) error {
+	days := s.defaultAutoApproveDays
+	if policy.AutoApproveAfterDays != nil {
+		days = *policy.AutoApproveAfterDays
+	}
+
+	review := &models.BroadcasterClipReview{
+		SubmissionID:  submission.ID,
+		BroadcasterID: policy.BroadcasterID,
+		Status:        models.BroadcasterReviewStatusPending,
+		AutoApproveAt: s.now().AddDate(0, 0, days),
+	}
+	if err := s.repo.CreateReview(ctx, review); err != nil {
+		return err
+	}
+
+	if s.notificationService != nil {
+		clipTitle := getClipTitle(submission)
+		broadcasterName := "The broadcaster"
+		if submission.BroadcasterName != nil && *submission.BroadcasterName != "" {
+			broadcasterName = *submission.BroadcasterName
+		}
+		if err := s.notificationService.NotifySubmissionAwaitingBroadcaster(ctx, submission.UserID, submission.ID, clipTitle, broadcasterName, review.AutoApproveAt); err != nil {
+			log.Printf("Failed to notify submitter of broadcaster hold: %v", err)
+		}
+		if err := s.notificationService.NotifyBroadcasterReviewRequested(ctx, policy.UserID, submission.ID, clipTitle, review.AutoApproveAt); err != nil {
+			log.Printf("Failed to notify broadcaster of pending clip: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// ListQueue returns the clips of the caller's channel awaiting their approval
+func (s *BroadcasterApprovalService) ListQueue(ctx context.Context, userID uuid.UUID, page, limit int) ([]*models.BroadcasterClipReviewWithSubmission, int, error) {
+	broadcasterID, err := verifiedBroadcasterID(ctx, s.userRepo, userID, ErrNotVerifiedBroadcaster)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	return s.repo.ListPendingReviews(ctx, broadcasterID, limit, offset)
+}
+
+// Approve lets a held clip continue. It goes live if the submitter would have
+// been auto-approved, otherwise it joins the regular moderation queue.
+func (s *BroadcasterApprovalService) Approve(ctx context.Context, userID, submissionID uuid.UUID) error {
+	if err := s.claimReview(ctx, userID, submissionID, models.BroadcasterReviewStatusApproved, nil); err != nil {
+		return err
+	}
+
+	return s.releaser.ReleaseBroadcasterHold(ctx, submissionID, false)
+}
+
+// Reject declines a held clip so it never appears publicly
+func (s *BroadcasterApprovalService) Reject(ctx context.Context, userID, submissionID uuid.UUID, reason string) error {
+	if err := s.claimReview(ctx, userID, submissionID, models.BroadcasterReviewStatusRejected, &reason); err != nil {
+		return err
+	}
+
+	return s.releaser.RejectBroadcasterHold(ctx, submissionID, userID, reason)
+}
+
+// ReleaseExpired releases held clips the broadcaster did not review before
+// their deadline, returning how many were released
+func (s *BroadcasterApprovalService) ReleaseExpired(ctx context.Context, limit int) (int, error) {
+	reviews, err := s.repo.ListExpiredReviews(ctx, s.now(), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for _, review := range reviews {
+		claimed, err := s.repo.ResolveReview(ctx, review.SubmissionID, models.BroadcasterReviewStatusExpired, nil, nil)
+		if err != nil {
+			return released, err
+		}
+		if !claimed {
+			// The broadcaster decided while we were processing
+			continue
+		}
+
+		if err := s.releaser.ReleaseBroadcasterHold(ctx, review.SubmissionID, true); err != nil {
+			log.Printf("Failed to release expired broadcaster hold for submission %s: %v", review.SubmissionID, err)
+			continue
+		}
+		released++
+	}
+
+	return released, nil
+}
+
+// claimReview checks the caller owns a pending review and records their decision on it
+func (s *BroadcasterApprovalService) claimReview(ctx context.Context, userID, submissionID uuid.UUID, status string, reason *string) error {
+	broadcasterID, err := verifiedBroadcasterID(ctx, s.userRepo, userID, ErrNotVerifiedBroadcaster)
+	if err != nil {
+		return err
+	}
+
+	review, err := s.repo.GetReview(ctx, submissionID)
+	if err != nil {
+		return err
+	}
+	// Hide other channels' queues behind not found
+	if review.BroadcasterID != broadcasterID {
+		return repository.ErrBroadcasterReviewNotFound
+	}
+	if review.Status != models.BroadcasterReviewStatusPending {
+		return ErrBroadcasterReviewResolved
+	}
+
+	claimed, err := s.repo.ResolveReview(ctx, submissionID, status, &userID, reason)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return ErrBroadcasterReviewResolved
+	}
+
+	return nil
+}
@@ -0,0 +1,346 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+// MockBroadcasterApprovalRepository is a mock implementation of BroadcasterApprovalRepositoryInterface
+type MockBroadcasterApprovalRepository struct {
+	mock.Mock
+}
+
+func (m *MockBroadcasterApprovalRepository) GetPolicy(ctx context.Context, broadcasterID string) (*models.BroadcasterApprovalPolicy, error) {
+	args := m.Called(ctx, broadcasterID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BroadcasterApprovalPolicy), args.Error(1)
+}
+
+func (m *MockBroadcasterApprovalRepository) UpsertPolicy(ctx context.Context, policy *models.BroadcasterApprovalPolicy) error {
+	args := m.Called(ctx, policy)
+	return args.Error(0)
+}
+
+func (m *MockBroadcasterApprovalRepository) CreateReview(ctx context.Context, review *models.BroadcasterClipReview) error {
+	args := m.Called(ctx, review)
+	return args.Error(0)
+}
+
+func (m *MockBroadcasterApprovalRepository) GetReview(ctx context.Context, submissionID uuid.UUID) (*models.BroadcasterClipReview, error) {
+	args := m.Called(ctx, submissionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BroadcasterClipReview), args.Error(1)
+}
+
+func (m *MockBroadcasterApprovalRepository) ListPendingReviews(ctx context.Context, broadcasterID string, limit, offset int) ([]*models.BroadcasterClipReviewWithSubmission, int, error) {
+	args := m.Called(ctx, broadcasterID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*models.BroadcasterClipReviewWithSubmission), args.Int(1), args.Error(2)
+}
+
+func (m *MockBroadcasterApprovalRepository) ResolveReview(ctx context.Context, submissionID uuid.UUID, status string, reviewedBy *uuid.UUID, rejectionReason *string) (bool, error) {
+	args := m.Called(ctx, submissionID, status, reviewedBy, rejectionReason)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockBroadcasterApprovalRepository) ListExpiredReviews(ctx context.Context, now time.Time, limit int) ([]*models.BroadcasterClipReview, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.BroadcasterClipReview), args.Error(1)
+}
+
+// MockBroadcasterHoldReleaser is a mock implementation of BroadcasterHoldReleaser
+type MockBroadcasterHoldReleaser struct {
+	mock.Mock
+}
+
+func (m *MockBroadcasterHoldReleaser) ReleaseBroadcasterHold(ctx context.Context, submissionID uuid.UUID, expired bool) error {
+	args := m.Called(ctx, submissionID, expired)
+	return args.Error(0)
+}
+
+func (m *MockBroadcasterHoldReleaser) RejectBroadcasterHold(ctx context.Context, submissionID, broadcasterUserID uuid.UUID, reason string) error {
+	args := m.Called(ctx, submissionID, broadcasterUserID, reason)
+	return args.Error(0)
+}
+
+var broadcasterApprovalTestNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// setupBroadcasterApprovalServiceTest builds a BroadcasterApprovalService over
+// mocks with the clock fixed at broadcasterApprovalTestNow
+func setupBroadcasterApprovalServiceTest() (*BroadcasterApprovalService, *MockBroadcasterApprovalRepository, *MockUserRepository, *MockBroadcasterHoldReleaser) {
+	repo := new(MockBroadcasterApprovalRepository)
+	users := new(MockUserRepository)
+	releaser := new(MockBroadcasterHoldReleaser)
+
+	svc := NewBroadcasterApprovalService(repo, users, nil, 7)
+	svc.SetHoldReleaser(releaser)
+	svc.now = func() time.Time { return broadcasterApprovalTestNow }
+
+	return svc, repo, users, releaser
+}
+
+// newTestBroadcaster returns a verified broadcaster of the Twitch channel
+func newTestBroadcaster(twitchID string) *models.User {
+	return &models.User{ID: uuid.New(), IsVerified: true, TwitchID: &twitchID}
+}
+
+// pendingBroadcasterReview returns a review awaiting the broadcaster's decision
+func pendingBroadcasterReview(broadcasterID string) *models.BroadcasterClipReview {
+	return &models.BroadcasterClipReview{
+		SubmissionID:  uuid.New(),
+		BroadcasterID: broadcasterID,
+		Status:        models.BroadcasterReviewStatusPending,
+		AutoApproveAt: broadcasterApprovalTestNow.AddDate(0, 0, 7),
+	}
+}
+
+func TestBroadcasterApprovalService_RequiresVerifiedBroadcaster(t *testing.T) {
+	svc, repo, users, _ := setupBroadcasterApprovalServiceTest()
+	ctx := context.Background()
+
+	submitter := &models.User{ID: uuid.New()}
+	users.On("GetByID", ctx, submitter.ID).Return(submitter, nil)
+
+	_, err := svc.UpdatePolicy(ctx, submitter.ID, &models.UpdateBroadcasterApprovalPolicyRequest{RequireApproval: true})
+	assert.ErrorIs(t, err, ErrNotVerifiedBroadcaster)
+
+	broadcaster := newTestBroadcaster("12345")
+	broadcaster.IsVerified = false
+	users.On("GetByID", ctx, broadcaster.ID).Return(broadcaster, nil)
+
+	_, _, err = svc.ListQueue(ctx, broadcaster.ID, 1, 20)
+	assert.ErrorIs(t, err, ErrNotVerifiedBroadcaster)
+
+	repo.AssertNotCalled(t, "UpsertPolicy", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "ListPendingReviews", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBroadcasterApprovalService_UpdatePolicyValidatesDays(t *testing.T) {
+	svc, repo, users, _ := setupBroadcasterApprovalServiceTest()
+	ctx := context.Background()
+	days := 45
+
+	broadcaster := newTestBroadcaster("12345")
+	users.On("GetByID", ctx, broadcaster.ID).Return(broadcaster, nil)
+
+	_, err := svc.UpdatePolicy(ctx, broadcaster.ID, &models.UpdateBroadcasterApprovalPolicyRequest{
+		RequireApproval:      true,
+		AutoApproveAfterDays: &days,
+	})
+	assert.ErrorIs(t, err, ErrInvalidAutoApproveDays)
+	repo.AssertNotCalled(t, "UpsertPolicy", mock.Anything, mock.Anything)
+}
+
+func TestBroadcasterApprovalService_UpdatePolicy(t *testing.T) {
+	svc, repo, users, _ := setupBroadcasterApprovalServiceTest()
+	ctx := context.Background()
+	days := 3
+
+	broadcaster := newTestBroadcaster("12345")
+	users.On("GetByID", ctx, broadcaster.ID).Return(broadcaster, nil)
+	repo.On("UpsertPolicy", ctx, mock.MatchedBy(func(p *models.BroadcasterApprovalPolicy) bool {
+		return p.BroadcasterID == "12345" && p.UserID == broadcaster.ID && p.RequireApproval &&
+			p.AutoApproveAfterDays != nil && *p.AutoApproveAfterDays == days
+	})).Return(nil).Once()
+
+	policy, err := svc.UpdatePolicy(ctx, broadcaster.ID, &models.UpdateBroadcasterApprovalPolicyRequest{
+		RequireApproval:      true,
+		AutoApproveAfterDays: &days,
+	})
+	require.NoError(t, err)
+	assert.True(t, policy.RequireApproval)
+	repo.AssertExpectations(t)
+}
+
+func TestBroadcasterApprovalService_PolicyFor(t *testing.T) {
+	svc, repo, _, _ := setupBroadcasterApprovalServiceTest()
+	ctx := context.Background()
+
+	broadcaster := newTestBroadcaster("12345")
+	submitter := &models.User{ID: uuid.New()}
+
+	// No policy: clips skip broadcaster approval
+	repo.On("GetPolicy", ctx, "12345").Return(nil, nil).Once()
+	policy, err := svc.PolicyFor(ctx, "12345", submitter)
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+
+	// Approval turned off: clips skip broadcaster approval
+	repo.On("GetPolicy", ctx, "12345").Return(&models.BroadcasterApprovalPolicy{BroadcasterID: "12345"}, nil).Once()
+	policy, err = svc.PolicyFor(ctx, "12345", submitter)
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+
+	repo.On("GetPolicy", ctx, "12345").Return(&models.BroadcasterApprovalPolicy{BroadcasterID: "12345", RequireApproval: true}, nil).Once()
+	policy, err = svc.PolicyFor(ctx, "12345", submitter)
+	require.NoError(t, err)
+	assert.NotNil(t, policy)
+
+	// Broadcasters never approve their own submissions
+	policy, err = svc.PolicyFor(ctx, "12345", broadcaster)
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+
+	repo.AssertNumberOfCalls(t, "GetPolicy", 3)
+}
+
+func TestBroadcasterApprovalService_HoldUsesPolicyDeadline(t *testing.T) {
+	svc, repo, _, _ := setupBroadcasterApprovalServiceTest()
+	ctx := context.Background()
+	days := 3
+
+	policy := &models.BroadcasterApprovalPolicy{
+		BroadcasterID:        "12345",
+		UserID:               uuid.New(),
+		RequireApproval:      true,
+		AutoApproveAfterDays: &days,
+	}
+	submission := &models.ClipSubmission{ID: uuid.New(), UserID: uuid.New()}
+
+	var review *models.BroadcasterClipReview
+	repo.On("CreateReview", ctx, mock.AnythingOfType("*models.BroadcasterClipReview")).
+		Run(func(args mock.Arguments) {
+			review = args.Get(1).(*models.BroadcasterClipReview)
+		}).
+		Return(nil).Once()
+
+	require.NoError(t, svc.HoldSubmission(ctx, policy, submission))
+
+	require.NotNil(t, review)
+	assert.Equal(t, submission.ID, review.SubmissionID)
+	assert.Equal(t, "12345", review.BroadcasterID)
+	assert.Equal(t, broadcasterApprovalTestNow.AddDate(0, 0, 3), review.AutoApproveAt)
+	assert.Equal(t, models.BroadcasterReviewStatusPending, review.Status)
+}
+
+func TestBroadcasterApprovalService_ListQueue(t *testing.T) {
+	svc, repo, users, _ := setupBroadcasterApprovalServiceTest()
+	ctx := context.Background()
+
+	broadcaster := newTestBroadcaster("12345")
+	users.On("GetByID", ctx, broadcaster.ID).Return(broadcaster, nil)
+
+	queue := []*models.BroadcasterClipReviewWithSubmission{
+		{BroadcasterClipReview: *pendingBroadcasterReview("12345")},
+		{BroadcasterClipReview: *pendingBroadcasterReview("12345")},
+	}
+	repo.On("ListPendingReviews", ctx, "12345", 20, 20).Return(queue, 22, nil).Once()
+
+	reviews, total, err := svc.ListQueue(ctx, broadcaster.ID, 2, 20)
+	require.NoError(t, err)
+	assert.Equal(t, 22, total)
+	assert.Len(t, reviews, 2)
+	repo.AssertExpectations(t)
+}
+
+func TestBroadcasterApprovalService_ApproveAndReject(t *testing.T) {
+	svc, repo, users, releaser := setupBroadcasterApprovalServiceTest()
+	ctx := context.Background()
+
+	broadcaster := newTestBroadcaster("12345")
+	users.On("GetByID", ctx, broadcaster.ID).Return(broadcaster, nil)
+
+	approved := pendingBroadcasterReview("12345")
+	repo.On("GetReview", ctx, approved.SubmissionID).Return(approved, nil).Once()
+	repo.On("ResolveReview", ctx, approved.SubmissionID, models.BroadcasterReviewStatusApproved, &broadcaster.ID, (*string)(nil)).
+		Return(true, nil).Once()
+	releaser.On("ReleaseBroadcasterHold", ctx, approved.SubmissionID, false).Return(nil).Once()
+
+	require.NoError(t, svc.Approve(ctx, broadcaster.ID, approved.SubmissionID))
+
+	rejected := pendingBroadcasterReview("12345")
+	repo.On("GetReview", ctx, rejected.SubmissionID).Return(rejected, nil).Once()
+	repo.On("ResolveReview", ctx, rejected.SubmissionID, models.BroadcasterReviewStatusRejected, &broadcaster.ID,
+		mock.MatchedBy(func(reason *string) bool { return reason != nil && *reason == "Spoils the ending" })).
+		Return(true, nil).Once()
+	releaser.On("RejectBroadcasterHold", ctx, rejected.SubmissionID, broadcaster.ID, "Spoils the ending").Return(nil).Once()
+
+	require.NoError(t, svc.Reject(ctx, broadcaster.ID, rejected.SubmissionID, "Spoils the ending"))
+
+	repo.AssertExpectations(t)
+	releaser.AssertExpectations(t)
+}
+
+func TestBroadcasterApprovalService_DecisionsApplyOnce(t *testing.T) {
+	svc, repo, users, releaser := setupBroadcasterApprovalServiceTest()
+	ctx := context.Background()
+
+	broadcaster := newTestBroadcaster("12345")
+	users.On("GetByID", ctx, broadcaster.ID).Return(broadcaster, nil)
+
+	// Already decided
+	resolved := pendingBroadcasterReview("12345")
+	resolved.Status = models.BroadcasterReviewStatusRejected
+	repo.On("GetReview", ctx, resolved.SubmissionID).Return(resolved, nil).Once()
+
+	assert.ErrorIs(t, svc.Approve(ctx, broadcaster.ID, resolved.SubmissionID), ErrBroadcasterReviewResolved)
+
+	// Decided or expired between the read and the claim
+	raced := pendingBroadcasterReview("12345")
+	repo.On("GetReview", ctx, raced.SubmissionID).Return(raced, nil).Once()
+	repo.On("ResolveReview", ctx, raced.SubmissionID, models.BroadcasterReviewStatusApproved, &broadcaster.ID, (*string)(nil)).
+		Return(false, nil).Once()
+
+	assert.ErrorIs(t, svc.Approve(ctx, broadcaster.ID, raced.SubmissionID), ErrBroadcasterReviewResolved)
+
+	repo.AssertNumberOfCalls(t, "ResolveReview", 1)
+	releaser.AssertNotCalled(t, "ReleaseBroadcasterHold", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBroadcasterApprovalService_CannotReviewOtherChannels(t *testing.T) {
+	svc, repo, users, releaser := setupBroadcasterApprovalServiceTest()
+	ctx := context.Background()
+
+	broadcaster := newTestBroadcaster("67890")
+	users.On("GetByID", ctx, broadcaster.ID).Return(broadcaster, nil)
+
+	review := pendingBroadcasterReview("12345")
+	repo.On("GetReview", ctx, review.SubmissionID).Return(review, nil).Once()
+
+	err := svc.Approve(ctx, broadcaster.ID, review.SubmissionID)
+	assert.ErrorIs(t, err, repository.ErrBroadcasterReviewNotFound)
+
+	repo.AssertNotCalled(t, "ResolveReview", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	releaser.AssertNotCalled(t, "ReleaseBroadcasterHold", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBroadcasterApprovalService_ReleaseExpired(t *testing.T) {
+	svc, repo, _, releaser := setupBroadcasterApprovalServiceTest()
+	ctx := context.Background()
+
+	expired := pendingBroadcasterReview("12345")
+	decided := pendingBroadcasterReview("12345")
+	repo.On("ListExpiredReviews", ctx, broadcasterApprovalTestNow, 100).
+		Return([]*models.BroadcasterClipReview{expired, decided}, nil).Once()
+	repo.On("ResolveReview", ctx, expired.SubmissionID, models.BroadcasterReviewStatusExpired, (*uuid.UUID)(nil), (*string)(nil)).
+		Return(true, nil).Once()
+	// The broadcaster decided while the expiry job was running
+	repo.On("ResolveReview", ctx, decided.SubmissionID, models.BroadcasterReviewStatusExpired, (*uuid.UUID)(nil), (*string)(nil)).
+		Return(false, nil).Once()
+	releaser.On("ReleaseBroadcasterHold", ctx, expired.SubmissionID, true).Return(nil).Once()
+
+	released, err := svc.ReleaseExpired(ctx, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 1, released)
+
+	repo.AssertExpectations(t)
+	releaser.AssertExpectations(t)
+	releaser.AssertNotCalled(t, "ReleaseBroadcasterHold", mock.Anything, decided.SubmissionID, mock.Anything)
+}
@@ -0,0 +1,359 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/pkg/twitch"
+)
+
+const (
+	// scheduleSyncHorizon is how far ahead schedules are pulled from Twitch
+	scheduleSyncHorizon = 14 * 24 * time.Hour
+	// scheduleSyncMaxPages caps the Twitch requests per broadcaster sync (25 segments each)
+	scheduleSyncMaxPages = 8
+	// scheduleStaleAfter is how old a stored schedule can be before a
+	// request for it pulls it again
+	scheduleStaleAfter = time.Hour
+	// scheduleListLimit caps the segments returned for one broadcaster
+	scheduleListLimit = 100
+	// scheduleReminderBatchSize caps the reminders sent in one pass
+	scheduleReminderBatchSize = 500
+
+	// UpcomingFeedDefaultDays is how far ahead the upcoming feed looks by default
+	UpcomingFeedDefaultDays = 7
+	// UpcomingFeedMaxDays is the furthest ahead the upcoming feed can look
+	UpcomingFeedMaxDays = 14
+)
+
+var (
+	// ErrScheduleReminderNotFollowing is returned when turning on reminders for a broadcaster the user doesn't follow
+	ErrScheduleReminderNotFollowing = errors.New("follow the broadcaster to get schedule reminders")
+	// ErrScheduleReminderInvalidMinutes is returned when a reminder's lead time is out of range
+	ErrScheduleReminderInvalidMinutes = fmt.Errorf("minutes_before must be between %d and %d",
+		models.ScheduleReminderMinMinutes, models.ScheduleReminderMaxMinutes)
+)
+
+// BroadcasterScheduleService pulls broadcaster schedules from Twitch, serves
+// the upcoming streams feed and sends reminders before scheduled streams
+type BroadcasterScheduleService struct {
+	scheduleRepo        *repository.BroadcasterScheduleRepository
+	broadcasterRepo     *repository.BroadcasterRepository
+	twitchClient        *twitch.Client // may be nil; schedules are then served as last synced
+	notificationService *NotificationService
+}
+
+// NewBroadcasterScheduleService creates a new broadcaster schedule service
+func NewBroadcasterScheduleService(
+	scheduleRepo *repository.BroadcasterScheduleRepository,
+	broadcasterRepo *repository.BroadcasterRepository,
+	twitchClient *twitch.Client,
+) *BroadcasterScheduleService {
+	return &BroadcasterScheduleService{
+		scheduleRepo:    scheduleRepo,
+		broadcasterRepo: broadcasterRepo,
+		twitchClient:    twitchClient,
+	}
+}
+
+// SetNotificationService sets the notification service used for reminders
+func (s *BroadcasterScheduleService) SetNotificationService(notificationService *NotificationService) {
+	s.notificationService = notificationService
+}
+
+// SyncBroadcaster pulls a broadcaster's schedule for the next two weeks from
+// Twitch and replaces the stored upcoming segments
+func (s *BroadcasterScheduleService) SyncBroadcaster(ctx context.Context, broadcasterID string) error {
+	if s.twitchClient == nil {
+		return nil
+	}
+
+	now := time.Now()
+	until := now.Add(scheduleSyncHorizon)
+
+	var schedule twitch.Schedule
+	var segments []twitch.ScheduleSegment
+	cursor := ""
+	for page := 0; page < scheduleSyncMaxPages; page++ {
+		resp, err := s.twitchClient.GetSchedule(ctx, broadcasterID, now, 25, cursor)
+		if err != nil {
+			if isTwitchNotFound(err) {
+				// The broadcaster has no schedule
+				break
+			}
+			return fmt.Errorf("failed to fetch schedule for broadcaster %s: %w", broadcasterID, err)
+		}
+		if page == 0 {
+			schedule = resp.Data
+		}
+		segments = append(segments, resp.Data.Segments...)
+
+		cursor = resp.Pagination.Cursor
+		fetched := resp.Data.Segments
+		if cursor == "" || len(fetched) == 0 || !fetched[len(fetched)-1].StartTime.Before(until) {
+			break
+		}
+	}
+
+	sync := &models.BroadcasterScheduleSync{
+		BroadcasterID:    broadcasterID,
+		BroadcasterName:  nonEmpty(schedule.BroadcasterName),
+		BroadcasterLogin: nonEmpty(schedule.BroadcasterLogin),
+		SyncedAt:         now,
+	}
+	if schedule.Vacation != nil {
+		sync.VacationStart = &schedule.Vacation.StartTime
+		sync.VacationEnd = &schedule.Vacation.EndTime
+	}
+
+	return s.scheduleRepo.ReplaceUpcoming(ctx, sync, scheduleSegmentsFromTwitch(segments, until), now)
+}
+
+// SyncFollowedBroadcasters pulls the schedule of every broadcaster someone
+// follows, returning how many were synced. A failing broadcaster is logged
+// and skipped.
+func (s *BroadcasterScheduleService) SyncFollowedBroadcasters(ctx context.Context) (int, error) {
+	if s.twitchClient == nil {
+		return 0, nil
+	}
+
+	broadcasterIDs, err := s.broadcasterRepo.GetAllFollowedBroadcasterIDs(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get followed broadcasters: %w", err)
+	}
+
+	synced := 0
+	for _, broadcasterID := range broadcasterIDs {
+		if ctx.Err() != nil {
+			return synced, ctx.Err()
+		}
+		if err := s.SyncBroadcaster(ctx, broadcasterID); err != nil {
+			log.Printf("Failed to sync schedule for broadcaster %s: %v", broadcasterID, err)
+			continue
+		}
+		synced++
+	}
+	return synced, nil
+}
+
+// GetSchedule returns a broadcaster's schedule for the next days. A schedule
+// that was never pulled or is over an hour old is pulled first; if that
+// fails the stored schedule is returned.
+func (s *BroadcasterScheduleService) GetSchedule(ctx context.Context, broadcasterID string, days int) (*models.BroadcasterSchedule, error) {
+	days = clampUpcomingDays(days)
+
+	sync, err := s.scheduleRepo.GetSync(ctx, broadcasterID)
+	if err != nil && !errors.Is(err, repository.ErrScheduleNotSynced) {
+		return nil, err
+	}
+	if s.twitchClient != nil && (sync == nil || time.Since(sync.SyncedAt) > scheduleStaleAfter) {
+		if err := s.SyncBroadcaster(ctx, broadcasterID); err != nil {
+			log.Printf("Failed to refresh schedule for broadcaster %s: %v", broadcasterID, err)
+		} else if sync, err = s.scheduleRepo.GetSync(ctx, broadcasterID); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	segments, err := s.scheduleRepo.ListSegments(ctx, broadcasterID, now, now.AddDate(0, 0, days), scheduleListLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := &models.BroadcasterSchedule{
+		BroadcasterID: broadcasterID,
+		Segments:      segments,
+	}
+	if sync != nil {
+		schedule.BroadcasterName = sync.BroadcasterName
+		schedule.BroadcasterLogin = sync.BroadcasterLogin
+		schedule.VacationStart = sync.VacationStart
+		schedule.VacationEnd = sync.VacationEnd
+		schedule.SyncedAt = &sync.SyncedAt
+	}
+	return schedule, nil
+}
+
+// GetUpcomingFeed returns scheduled streams of the broadcasters a user
+// follows, soonest first, including streams in progress
+func (s *BroadcasterScheduleService) GetUpcomingFeed(ctx context.Context, userID uuid.UUID, days, limit, offset int) ([]models.UpcomingStream, error) {
+	days = clampUpcomingDays(days)
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	now := time.Now()
+	return s.scheduleRepo.ListUpcomingForUser(ctx, userID, now, now.AddDate(0, 0, days), limit, offset)
+}
+
+// GetReminder returns a user's reminder setting for a broadcaster
+func (s *BroadcasterScheduleService) GetReminder(ctx context.Context, userID uuid.UUID, broadcasterID string) (*models.BroadcasterScheduleReminder, error) {
+	return s.scheduleRepo.GetReminder(ctx, userID, broadcasterID)
+}
+
+// SetReminder turns on reminders minutesBefore each scheduled stream of a
+// broadcaster the user follows
+func (s *BroadcasterScheduleService) SetReminder(ctx context.Context, userID uuid.UUID, broadcasterID string, minutesBefore int) (*models.BroadcasterScheduleReminder, error) {
+	if minutesBefore < models.ScheduleReminderMinMinutes || minutesBefore > models.ScheduleReminderMaxMinutes {
+		return nil, ErrScheduleReminderInvalidMinutes
+	}
+
+	following, err := s.broadcasterRepo.IsFollowing(ctx, userID, broadcasterID)
+	if err != nil {
+		return nil, err
+	}
+	if !following {
+		return nil, ErrScheduleReminderNotFollowing
+	}
+
+	reminder := &models.BroadcasterScheduleReminder{
+		UserID:        userID,
+		BroadcasterID: broadcasterID,
+		MinutesBefore: minutesBefore,
+	}
+	if err := s.scheduleRepo.UpsertReminder(ctx, reminder); err != nil {
+		return nil, err
+	}
+	return reminder, nil
+}
+
+// DeleteReminder turns off reminders for a broadcaster
+func (s *BroadcasterScheduleService) DeleteReminder(ctx context.Context, userID uuid.UUID, broadcasterID string) error {
+	return s.scheduleRepo.DeleteReminder(ctx, userID, broadcasterID)
+}
+
+// SendDueReminders notifies users whose reminder lead time before a
+// scheduled stream has been reached, returning how many were sent. Each
+// reminder is claimed before it's sent, so it goes out at most once.
+func (s *BroadcasterScheduleService) SendDueReminders(ctx context.Context) (int, error) {
+	if s.notificationService == nil {
+		return 0, nil
+	}
+
+	now := time.Now()
+	reminders, err := s.scheduleRepo.ListDueReminders(ctx, now, scheduleReminderBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, reminder := range reminders {
+		claimed, err := s.scheduleRepo.MarkReminderSent(ctx, reminder.UserID, reminder.ScheduleID)
+		if err != nil {
+			log.Printf("Failed to claim schedule reminder for user %s: %v", reminder.UserID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		title, message, link := scheduleReminderContent(&reminder, now)
+		_, err = s.notificationService.CreateNotification(
+			ctx,
+			reminder.UserID,
+			models.NotificationTypeBroadcasterScheduleReminder,
+			title,
+			message,
+			&link,
+			nil, // no source user
+			nil, // no source content
+			nil, // no source content type
+		)
+		if err != nil {
+			log.Printf("Failed to send schedule reminder to user %s for broadcaster %s: %v", reminder.UserID, reminder.BroadcasterID, err)
+			continue
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// scheduleSegmentsFromTwitch converts Twitch segments starting before until
+func scheduleSegmentsFromTwitch(segments []twitch.ScheduleSegment, until time.Time) []models.BroadcasterScheduleSegment {
+	converted := make([]models.BroadcasterScheduleSegment, 0, len(segments))
+	for _, segment := range segments {
+		if !segment.StartTime.Before(until) {
+			continue
+		}
+		stored := models.BroadcasterScheduleSegment{
+			SegmentID:   segment.ID,
+			Title:       segment.Title,
+			StartTime:   segment.StartTime,
+			EndTime:     segment.EndTime,
+			IsRecurring: segment.IsRecurring,
+			IsCanceled:  segment.CanceledUntil != nil,
+		}
+		if segment.Category != nil {
+			stored.CategoryID = nonEmpty(segment.Category.ID)
+			stored.CategoryName = nonEmpty(segment.Category.Name)
+		}
+		converted = append(converted, stored)
+	}
+	return converted
+}
+
+// scheduleReminderContent builds the title, message and link of a reminder
+func scheduleReminderContent(reminder *models.DueScheduleReminder, now time.Time) (string, string, string) {
+	title := fmt.Sprintf("%s goes live in %s", reminder.BroadcasterName, formatReminderLead(reminder.StartTime.Sub(now)))
+
+	message := reminder.Title
+	if message == "" {
+		message = "Scheduled stream on Twitch"
+	}
+	if reminder.CategoryName != nil && *reminder.CategoryName != "" {
+		message = fmt.Sprintf("%s - Playing %s", message, *reminder.CategoryName)
+	}
+
+	var link string
+	if reminder.BroadcasterLogin != nil && *reminder.BroadcasterLogin != "" {
+		link = fmt.Sprintf("https://twitch.tv/%s", *reminder.BroadcasterLogin)
+	}
+	return title, message, link
+}
+
+// formatReminderLead describes how long until a stream starts, in minutes
+// under two hours and in hours after that
+func formatReminderLead(d time.Duration) string {
+	minutes := int((d + 30*time.Second) / time.Minute)
+	switch {
+	case minutes <= 1:
+		return "1 minute"
+	case minutes < 120:
+		return fmt.Sprintf("%d minutes", minutes)
+	default:
+		return fmt.Sprintf("%d hours", (minutes+30)/60)
+	}
+}
+
+func clampUpcomingDays(days int) int {
+	if days <= 0 {
+		return UpcomingFeedDefaultDays
+	}
+	if days > UpcomingFeedMaxDays {
+		return UpcomingFeedMaxDays
+	}
+	return days
+}
+
+// isTwitchNotFound reports whether a Twitch request failed with 404
+func isTwitchNotFound(err error) bool {
+	var apiErr *twitch.APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+func nonEmpty(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/pkg/twitch"
+)
+
+func TestScheduleSegmentsFromTwitch(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	end := now.Add(3 * time.Hour)
+	canceledUntil := now.Add(2 * time.Hour)
+
+	segments := scheduleSegmentsFromTwitch([]twitch.ScheduleSegment{
+		{ID: "a", StartTime: now.Add(time.Hour), EndTime: &end, Title: "Ranked", Category: &twitch.ScheduleCategory{ID: "1", Name: "Valorant"}, IsRecurring: true},
+		{ID: "b", StartTime: now.Add(2 * time.Hour), CanceledUntil: &canceledUntil, Category: &twitch.ScheduleCategory{}},
+		{ID: "c", StartTime: now.Add(scheduleSyncHorizon)},
+	}, now.Add(scheduleSyncHorizon))
+
+	require.Len(t, segments, 2)
+
+	assert.Equal(t, "a", segments[0].SegmentID)
+	assert.Equal(t, &end, segments[0].EndTime)
+	require.NotNil(t, segments[0].CategoryName)
+	assert.Equal(t, "Valorant", *segments[0].CategoryName)
+	assert.True(t, segments[0].IsRecurring)
+	assert.False(t, segments[0].IsCanceled)
+
+	assert.True(t, segments[1].IsCanceled)
+	assert.Nil(t, segments[1].CategoryID)
+	assert.Nil(t, segments[1].CategoryName)
+}
+
+func TestScheduleReminderContent(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	login := "twitchdev"
+	category := "Just Chatting"
+
+	title, message, link := scheduleReminderContent(&models.DueScheduleReminder{
+		BroadcasterName:  "TwitchDev",
+		BroadcasterLogin: &login,
+		Title:            "Q&A",
+		CategoryName:     &category,
+		StartTime:        now.Add(15 * time.Minute),
+	}, now)
+
+	assert.Equal(t, "TwitchDev goes live in 15 minutes", title)
+	assert.Equal(t, "Q&A - Playing Just Chatting", message)
+	assert.Equal(t, "https://twitch.tv/twitchdev", link)
+
+	_, message, link = scheduleReminderContent(&models.DueScheduleReminder{
+		BroadcasterName: "TwitchDev",
+		StartTime:       now.Add(time.Hour),
+	}, now)
+
+	assert.Equal(t, "Scheduled stream on Twitch", message)
+	assert.Empty(t, link)
+}
+
+func TestFormatReminderLead(t *testing.T) {
+	tests := []struct {
+		lead time.Duration
+		want string
+	}{
+		{20 * time.Second, "1 minute"},
+		{4*time.Minute + 50*time.Second, "5 minutes"},
+		{90 * time.Minute, "90 minutes"},
+		{3*time.Hour + 20*time.Minute, "3 hours"},
+		{24 * time.Hour, "24 hours"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, formatReminderLead(tt.lead), fmt.Sprintf("lead %s", tt.lead))
+	}
+}
+
+func TestClampUpcomingDays(t *testing.T) {
+	assert.Equal(t, UpcomingFeedDefaultDays, clampUpcomingDays(0))
+	assert.Equal(t, 3, clampUpcomingDays(3))
+	assert.Equal(t, UpcomingFeedMaxDays, clampUpcomingDays(60))
+}
+
+func TestIsTwitchNotFound(t *testing.T) {
+	assert.True(t, isTwitchNotFound(fmt.Errorf("wrapped: %w", &twitch.APIError{StatusCode: 404})))
+	assert.False(t, isTwitchNotFound(&twitch.APIError{StatusCode: 500}))
+	assert.False(t, isTwitchNotFound(fmt.Errorf("network down")))
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/pkg/metrics"
+)
+
+// CacheInvalidationTopic names a kind of change that makes cached data stale
+type CacheInvalidationTopic string
+
+const (
+	// CacheTopicClipCreated is published when a clip is added to the site
+	CacheTopicClipCreated CacheInvalidationTopic = "clip.created"
+	// CacheTopicClipUpdated is published when a clip's metadata, flags or visibility change
+	CacheTopicClipUpdated CacheInvalidationTopic = "clip.updated"
+	// CacheTopicClipRemoved is published when a clip is removed
+	CacheTopicClipRemoved CacheInvalidationTopic = "clip.removed"
+	// CacheTopicClipVoted is published when a clip's vote score changes
+	CacheTopicClipVoted CacheInvalidationTopic = "clip.voted"
+
+	cacheInvalidationChannelPrefix = "cache:invalidation:"
+	// cacheInvalidationTimeout bounds how long a subscriber may take to clear its keys
+	cacheInvalidationTimeout = 10 * time.Second
+)
+
+// CacheInvalidationEvent describes a change whose cached data must be cleared.
+// The clip's game and creator let subscribers clear only the lists it is in.
+type CacheInvalidationEvent struct {
+	Topic       CacheInvalidationTopic `json:"topic"`
+	ClipID      uuid.UUID              `json:"clip_id"`
+	GameID      string                 `json:"game_id,omitempty"`
+	CreatorID   string                 `json:"creator_id,omitempty"`
+	PublishedAt time.Time              `json:"published_at"`
+}
+
+// NewClipInvalidationEvent creates an event for a change to clip
+func NewClipInvalidationEvent(topic CacheInvalidationTopic, clip *models.Clip) CacheInvalidationEvent {
+	event := CacheInvalidationEvent{Topic: topic, ClipID: clip.ID}
+	if clip.GameID != nil {
+		event.GameID = *clip.GameID
+	}
+	if clip.CreatorID != nil {
+		event.CreatorID = *clip.CreatorID
+	}
+	return event
+}
+
+// CacheInvalidationHandler clears the keys an event makes stale
+type CacheInvalidationHandler func(ctx context.Context, event CacheInvalidationEvent) error
+
+type cacheInvalidationSubscriber struct {
+	name    string
+	handler CacheInvalidationHandler
+}
+
+// CacheInvalidationBus carries cache invalidation events from the services
+// that change data to the services that cache it. With Redis, events are
+// published to a channel per topic so subscribers on every instance receive
+// them; without Redis, or when publishing fails, they are handled locally.
+type CacheInvalidationBus struct {
+	redis       redis.UniversalClient
+	mu          sync.RWMutex
+	subscribers map[CacheInvalidationTopic][]cacheInvalidationSubscriber
+	pubsub      *redis.PubSub
+	done        chan struct{}
+}
+
+// NewCacheInvalidationBus creates a new CacheInvalidationBus. redisClient may be nil.
+func NewCacheInvalidationBus(redisClient redis.UniversalClient) *CacheInvalidationBus {
+	return &CacheInvalidationBus{
+		redis:       redisClient,
+		subscribers: make(map[CacheInvalidationTopic][]cacheInvalidationSubscriber),
+	}
+}
+
+// Subscribe registers handler, named name in metrics and logs, for events on
+// topics. Subscribe before Start so the topics' channels are listened to.
+func (b *CacheInvalidationBus) Subscribe(name string, handler CacheInvalidationHandler, topics ...CacheInvalidationTopic) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, topic := range topics {
+		b.subscribers[topic] = append(b.subscribers[topic], cacheInvalidationSubscriber{name: name, handler: handler})
+	}
+}
+
+// Start listens for events on the subscribed topics until Close is called
+func (b *CacheInvalidationBus) Start(ctx context.Context) {
+	if b.redis == nil {
+		return
+	}
+
+	b.mu.Lock()
+	channels := make([]string, 0, len(b.subscribers))
+	for topic := range b.subscribers {
+		channels = append(channels, cacheInvalidationChannel(topic))
+	}
+	if len(channels) == 0 || b.pubsub != nil {
+		b.mu.Unlock()
+		return
+	}
+	b.pubsub = b.redis.Subscribe(ctx, channels...)
+	messages := b.pubsub.Channel()
+	b.done = make(chan struct{})
+	b.mu.Unlock()
+
+	go func() {
+		defer close(b.done)
+		for msg := range messages {
+			var event CacheInvalidationEvent
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				log.Printf("Failed to decode cache invalidation event: %v", err)
+				continue
+			}
+			event.Topic = CacheInvalidationTopic(strings.TrimPrefix(msg.Channel, cacheInvalidationChannelPrefix))
+			b.dispatch(event)
+		}
+	}()
+}
+
+// Publish sends an event to the topic's subscribers on every instance
+func (b *CacheInvalidationBus) Publish(ctx context.Context, event CacheInvalidationEvent) error {
+	if event.PublishedAt.IsZero() {
+		event.PublishedAt = time.Now()
+	}
+	topic := string(event.Topic)
+
+	if b.redis == nil {
+		metrics.CacheInvalidationsPublished.WithLabelValues(topic, "local").Inc()
+		b.dispatch(event)
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		metrics.CacheInvalidationsPublished.WithLabelValues(topic, "error").Inc()
+		return fmt.Errorf("failed to encode cache invalidation event: %w", err)
+	}
+	if err := b.redis.Publish(ctx, cacheInvalidationChannel(event.Topic), payload).Err(); err != nil {
+		// Cached data lives in the same Redis, so clearing it here still helps
+		// if Redis only failed to publish
+		metrics.CacheInvalidationsPublished.WithLabelValues(topic, "local").Inc()
+		b.dispatch(event)
+		return fmt.Errorf("failed to publish cache invalidation event: %w", err)
+	}
+
+	metrics.CacheInvalidationsPublished.WithLabelValues(topic, "success").Inc()
+	return nil
+}
+
+// Close stops listening for events
+func (b *CacheInvalidationBus) Close() error {
+	b.mu.Lock()
+	pubsub, done := b.pubsub, b.done
+	b.pubsub = nil
+	b.mu.Unlock()
+
+	if pubsub == nil {
+		return nil
+	}
+	err := pubsub.Close()
+	<-done
+	return err
+}
+
+// dispatch runs the event's subscribers, recording how long after publishing
+// each one finished
+func (b *CacheInvalidationBus) dispatch(event CacheInvalidationEvent) {
+	b.mu.RLock()
+	subscribers := b.subscribers[event.Topic]
+	b.mu.RUnlock()
+
+	topic := string(event.Topic)
+	for _, subscriber := range subscribers {
+		ctx, cancel := context.WithTimeout(context.Background(), cacheInvalidationTimeout)
+		err := subscriber.handler(ctx, event)
+		cancel()
+
+		if err != nil {
+			metrics.CacheInvalidationsHandled.WithLabelValues(topic, subscriber.name, "error").Inc()
+			log.Printf("Cache invalidation subscriber %s failed on %s for clip %s: %v", subscriber.name, topic, event.ClipID, err)
+			continue
+		}
+		metrics.CacheInvalidationsHandled.WithLabelValues(topic, subscriber.name, "success").Inc()
+		metrics.CacheInvalidationLag.WithLabelValues(topic, subscriber.name).Observe(time.Since(event.PublishedAt).Seconds())
+	}
+}
+
+func cacheInvalidationChannel(topic CacheInvalidationTopic) string {
+	return cacheInvalidationChannelPrefix + string(topic)
+}
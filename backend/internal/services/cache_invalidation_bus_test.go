@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+func TestNewClipInvalidationEvent(t *testing.T) {
+	gameID, creatorID := "509658", "44322889"
+	clip := &models.Clip{ID: uuid.New(), GameID: &gameID, CreatorID: &creatorID}
+
+	event := NewClipInvalidationEvent(CacheTopicClipUpdated, clip)
+	assert.Equal(t, CacheTopicClipUpdated, event.Topic)
+	assert.Equal(t, clip.ID, event.ClipID)
+	assert.Equal(t, gameID, event.GameID)
+	assert.Equal(t, creatorID, event.CreatorID)
+
+	event = NewClipInvalidationEvent(CacheTopicClipRemoved, &models.Clip{ID: clip.ID})
+	assert.Empty(t, event.GameID)
+	assert.Empty(t, event.CreatorID)
+}
+
+func TestCacheInvalidationBus_PublishRoutesByTopic(t *testing.T) {
+	bus := NewCacheInvalidationBus(nil)
+	var lists, feeds []CacheInvalidationEvent
+	bus.Subscribe("clip_lists", func(ctx context.Context, event CacheInvalidationEvent) error {
+		lists = append(lists, event)
+		return nil
+	}, CacheTopicClipUpdated, CacheTopicClipVoted)
+	bus.Subscribe("feeds", func(ctx context.Context, event CacheInvalidationEvent) error {
+		feeds = append(feeds, event)
+		return nil
+	}, CacheTopicClipCreated, CacheTopicClipVoted)
+	bus.Start(context.Background())
+	defer bus.Close()
+
+	clipID := uuid.New()
+	require.NoError(t, bus.Publish(context.Background(), CacheInvalidationEvent{Topic: CacheTopicClipVoted, ClipID: clipID}))
+	require.NoError(t, bus.Publish(context.Background(), CacheInvalidationEvent{Topic: CacheTopicClipCreated, ClipID: clipID}))
+	require.NoError(t, bus.Publish(context.Background(), CacheInvalidationEvent{Topic: CacheTopicClipRemoved, ClipID: clipID}))
+
+	require.Len(t, lists, 1)
+	assert.Equal(t, CacheTopicClipVoted, lists[0].Topic)
+	assert.False(t, lists[0].PublishedAt.IsZero())
+	require.Len(t, feeds, 2)
+	assert.Equal(t, CacheTopicClipVoted, feeds[0].Topic)
+	assert.Equal(t, CacheTopicClipCreated, feeds[1].Topic)
+}
+
+func TestCacheInvalidationBus_HandlerErrorDoesNotStopOthers(t *testing.T) {
+	bus := NewCacheInvalidationBus(nil)
+	called := false
+	bus.Subscribe("failing", func(ctx context.Context, event CacheInvalidationEvent) error {
+		return errors.New("redis unavailable")
+	}, CacheTopicClipUpdated)
+	bus.Subscribe("feeds", func(ctx context.Context, event CacheInvalidationEvent) error {
+		called = true
+		return nil
+	}, CacheTopicClipUpdated)
+
+	require.NoError(t, bus.Publish(context.Background(), CacheInvalidationEvent{Topic: CacheTopicClipUpdated, ClipID: uuid.New()}))
+	assert.True(t, called)
+}
@@ -519,6 +519,41 @@ func (s *CacheService) InvalidateOnVote(ctx context.Context, clipID uuid.UUID) e
 	return s.InvalidateClip(ctx, clipID)
 }
 
+// InvalidateOnClipChange invalidates caches when a clip is edited or removed
+func (s *CacheService) InvalidateOnClipChange(ctx context.Context, clip *models.Clip) error {
+	if err := s.InvalidateClip(ctx, clip.ID); err != nil {
+		return err
+	}
+
+	// Clear top feed; InvalidateOnNewClip clears the rest of the feeds it may be in
+	if err := s.InvalidateFeedTop(ctx); err != nil {
+		return err
+	}
+
+	return s.InvalidateOnNewClip(ctx, clip)
+}
+
+// HandleCacheInvalidation clears the feed and clip caches an invalidation
+// event makes stale
+func (s *CacheService) HandleCacheInvalidation(ctx context.Context, event CacheInvalidationEvent) error {
+	clip := &models.Clip{ID: event.ClipID}
+	if event.GameID != "" {
+		clip.GameID = &event.GameID
+	}
+	if event.CreatorID != "" {
+		clip.CreatorID = &event.CreatorID
+	}
+
+	switch event.Topic {
+	case CacheTopicClipCreated:
+		return s.InvalidateOnNewClip(ctx, clip)
+	case CacheTopicClipVoted:
+		return s.InvalidateOnVote(ctx, event.ClipID)
+	default:
+		return s.InvalidateOnClipChange(ctx, clip)
+	}
+}
+
 // InvalidateOnComment invalidates caches when a comment is added
 func (s *CacheService) InvalidateOnComment(ctx context.Context, clipID uuid.UUID) error {
 	// Clear comment tree
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+var (
+	// ErrClipAccessibilityUnavailable is returned when clip accessibility metadata is not configured
+	ErrClipAccessibilityUnavailable = errors.New("clip accessibility is not configured")
+	// ErrNoAccessibilityFields is returned when an accessibility update sets no fields
+	ErrNoAccessibilityFields = errors.New("at least one accessibility field must be provided")
+)
+
+// SetAccessibilityRepository enables accessibility metadata on clips
+func (s *ClipService) SetAccessibilityRepository(repo *repository.ClipAccessibilityRepository) {
+	s.accessibilityRepo = repo
+}
+
+// GetClipAccessibility returns a clip's accessibility metadata. Clips nobody
+// has described yet report every feature as absent.
+func (s *ClipService) GetClipAccessibility(ctx context.Context, clipID uuid.UUID) (*models.ClipAccessibility, error) {
+	if s.accessibilityRepo == nil {
+		return nil, ErrClipAccessibilityUnavailable
+	}
+
+	if _, err := s.clipRepo.GetByID(ctx, clipID); err != nil {
+		return nil, err
+	}
+
+	accessibility, err := s.accessibilityRepo.GetByClipID(ctx, clipID)
+	if err != nil {
+		return nil, err
+	}
+	if accessibility == nil {
+		accessibility = &models.ClipAccessibility{ClipID: clipID}
+	}
+	return accessibility, nil
+}
+
+// UpdateClipAccessibility sets a clip's accessibility metadata - only accessible by creator or admin/moderator
+func (s *ClipService) UpdateClipAccessibility(ctx context.Context, userID, clipID uuid.UUID, req *models.UpdateClipAccessibilityRequest) (*models.ClipAccessibility, error) {
+	if s.accessibilityRepo == nil {
+		return nil, ErrClipAccessibilityUnavailable
+	}
+	if req.HasCaptions == nil && req.PhotosensitivityWarning == nil && req.AudioDescription == nil {
+		return nil, ErrNoAccessibilityFields
+	}
+
+	// Check authorization
+	canManage, err := s.CanManageClip(ctx, userID, clipID)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, ErrUnauthorized
+	}
+
+	current, err := s.accessibilityRepo.GetByClipID(ctx, clipID)
+	if err != nil {
+		return nil, err
+	}
+
+	accessibility, changes := applyAccessibilityUpdate(current, clipID, req)
+	accessibility.UpdatedBy = &userID
+	if err := s.accessibilityRepo.Upsert(ctx, accessibility); err != nil {
+		return nil, err
+	}
+
+	// Log the change
+	auditLog := &models.ModerationAuditLog{
+		Action:      "clip_accessibility_updated",
+		EntityType:  "clip",
+		EntityID:    clipID,
+		ModeratorID: userID,
+		Metadata:    changes,
+	}
+	_ = s.auditLogRepo.Create(ctx, auditLog)
+
+	// Invalidate cache so accessibility feed filters pick up the change
+	s.invalidateCache(ctx)
+
+	return accessibility, nil
+}
+
+// MarkTranscriptAvailable records that a clip has captions once a transcript
+// exists for it. Captions set manually by a creator or moderator are kept,
+// including an explicit "no captions".
+func (s *ClipService) MarkTranscriptAvailable(ctx context.Context, clipID uuid.UUID) error {
+	if s.accessibilityRepo == nil {
+		return ErrClipAccessibilityUnavailable
+	}
+
+	updated, err := s.accessibilityRepo.MarkCaptionsFromTranscript(ctx, clipID)
+	if err != nil {
+		return err
+	}
+	if updated {
+		log.Printf("Marked clip %s as captioned from transcript", clipID)
+		s.invalidateCache(ctx)
+	}
+	return nil
+}
+
+// applyAccessibilityUpdate applies req to a clip's current accessibility
+// metadata (nil when none was set), returning the result and the changed
+// fields for the audit log. Setting captions by hand makes them manual, so
+// transcript detection no longer overrides them.
+func applyAccessibilityUpdate(current *models.ClipAccessibility, clipID uuid.UUID, req *models.UpdateClipAccessibilityRequest) (*models.ClipAccessibility, map[string]interface{}) {
+	updated := models.ClipAccessibility{ClipID: clipID}
+	if current != nil {
+		updated = *current
+	}
+
+	changes := make(map[string]interface{})
+	if req.HasCaptions != nil {
+		source := models.CaptionsSourceManual
+		updated.HasCaptions = *req.HasCaptions
+		updated.CaptionsSource = &source
+		changes["has_captions"] = *req.HasCaptions
+	}
+	if req.PhotosensitivityWarning != nil {
+		updated.PhotosensitivityWarning = *req.PhotosensitivityWarning
+		changes["photosensitivity_warning"] = *req.PhotosensitivityWarning
+	}
+	if req.AudioDescription != nil {
+		updated.AudioDescription = *req.AudioDescription
+		changes["audio_description"] = *req.AudioDescription
+	}
+
+	return &updated, changes
+}
+
+// attachAccessibility adds accessibility metadata to listed clips. Failures
+// only drop the metadata, like the rest of the list enrichment.
+func (s *ClipService) attachAccessibility(ctx context.Context, clips []ClipWithUserData) {
+	if s.accessibilityRepo == nil || len(clips) == 0 {
+		return
+	}
+
+	clipIDs := make([]uuid.UUID, len(clips))
+	for i := range clips {
+		clipIDs[i] = clips[i].ID
+	}
+
+	byClip, err := s.accessibilityRepo.GetByClipIDs(ctx, clipIDs)
+	if err != nil {
+		return
+	}
+	for i := range clips {
+		clips[i].Clip.Accessibility = byClip[clips[i].ID]
+	}
+}
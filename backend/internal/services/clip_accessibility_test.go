@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+func TestApplyAccessibilityUpdate(t *testing.T) {
+	clipID := uuid.New()
+	yes, no := true, false
+
+	t.Run("New metadata starts with every feature absent", func(t *testing.T) {
+		updated, changes := applyAccessibilityUpdate(nil, clipID, &models.UpdateClipAccessibilityRequest{
+			PhotosensitivityWarning: &yes,
+		})
+
+		if updated.ClipID != clipID {
+			t.Errorf("Expected clip ID %s, got %s", clipID, updated.ClipID)
+		}
+		if !updated.PhotosensitivityWarning || updated.HasCaptions || updated.AudioDescription {
+			t.Errorf("Unexpected accessibility %+v", updated)
+		}
+		if updated.CaptionsSource != nil {
+			t.Errorf("Expected no captions source, got %q", *updated.CaptionsSource)
+		}
+		if len(changes) != 1 || changes["photosensitivity_warning"] != true {
+			t.Errorf("Unexpected changes %v", changes)
+		}
+	})
+
+	t.Run("Manual captions override transcript detection", func(t *testing.T) {
+		transcript := models.CaptionsSourceTranscript
+		current := &models.ClipAccessibility{
+			ClipID:           clipID,
+			HasCaptions:      true,
+			CaptionsSource:   &transcript,
+			AudioDescription: true,
+		}
+
+		updated, changes := applyAccessibilityUpdate(current, clipID, &models.UpdateClipAccessibilityRequest{
+			HasCaptions: &no,
+		})
+
+		if updated.HasCaptions {
+			t.Error("Expected captions to be turned off")
+		}
+		if updated.CaptionsSource == nil || *updated.CaptionsSource != models.CaptionsSourceManual {
+			t.Errorf("Expected manual captions source, got %v", updated.CaptionsSource)
+		}
+		if !updated.AudioDescription {
+			t.Error("Expected omitted fields to be unchanged")
+		}
+		if changes["has_captions"] != false {
+			t.Errorf("Unexpected changes %v", changes)
+		}
+		if *current.CaptionsSource != models.CaptionsSourceTranscript {
+			t.Error("Expected current metadata not to be modified")
+		}
+	})
+}
+
+func TestUpdateClipAccessibility_RequiresFields(t *testing.T) {
+	svc := &ClipService{accessibilityRepo: &repository.ClipAccessibilityRepository{}}
+
+	_, err := svc.UpdateClipAccessibility(context.Background(), uuid.New(), uuid.New(), &models.UpdateClipAccessibilityRequest{})
+	if !errors.Is(err, ErrNoAccessibilityFields) {
+		t.Fatalf("Expected ErrNoAccessibilityFields, got %v", err)
+	}
+}
+
+func TestClipAccessibility_Unconfigured(t *testing.T) {
+	svc := &ClipService{}
+
+	if _, err := svc.GetClipAccessibility(context.Background(), uuid.New()); !errors.Is(err, ErrClipAccessibilityUnavailable) {
+		t.Errorf("Expected ErrClipAccessibilityUnavailable, got %v", err)
+	}
+	if err := svc.MarkTranscriptAvailable(context.Background(), uuid.New()); !errors.Is(err, ErrClipAccessibilityUnavailable) {
+		t.Errorf("Expected ErrClipAccessibilityUnavailable, got %v", err)
+	}
+}
+
+func TestBuildCacheKeySeparatesAccessibilityFilters(t *testing.T) {
+	svc := &ClipService{}
+
+	all := svc.buildCacheKey(repository.ClipFilters{Sort: "hot"}, 1, 25)
+	captioned := svc.buildCacheKey(repository.ClipFilters{Sort: "hot", CaptionedOnly: true}, 1, 25)
+	safe := svc.buildCacheKey(repository.ClipFilters{Sort: "hot", HidePhotosensitive: true}, 1, 25)
+
+	if all == captioned || all == safe || captioned == safe {
+		t.Fatalf("cache keys should differ by accessibility filter: %q, %q, %q", all, captioned, safe)
+	}
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// Clip broadcaster attribution errors
+var (
+	// ErrClipBroadcasterMissing is returned when a broadcaster has no ID or name
+	ErrClipBroadcasterMissing = errors.New("broadcaster ID and name are required")
+	// ErrClipBroadcasterDuplicate is returned when a broadcaster is credited twice on a clip
+	ErrClipBroadcasterDuplicate = errors.New("a broadcaster can only be credited once per clip")
+	// ErrClipBroadcasterTooMany is returned when too many broadcasters are featured on a clip
+	ErrClipBroadcasterTooMany = fmt.Errorf("at most %d broadcasters can be featured on a clip", models.MaxClipFeaturedBroadcasters)
+)
+
+// ClipBroadcasterRepositoryInterface defines the data access needed for clip attribution
+type ClipBroadcasterRepositoryInterface interface {
+	GetClipBroadcasters(ctx context.Context, clipID uuid.UUID) (*models.ClipBroadcasters, error)
+	SetClipBroadcasters(ctx context.Context, attribution *models.ClipBroadcasters, updatedBy uuid.UUID) error
+}
+
+// ClipBroadcasterService manages which broadcasters a clip is credited to:
+// the primary broadcaster it was clipped from and the broadcasters featured in it
+type ClipBroadcasterService struct {
+	repo            ClipBroadcasterRepositoryInterface
+	auditLogRepo    ModerationAuditRepo
+	invalidationBus *CacheInvalidationBus
+}
+
+// NewClipBroadcasterService creates a new clip broadcaster service
+func NewClipBroadcasterService(repo ClipBroadcasterRepositoryInterface, auditLogRepo ModerationAuditRepo) *ClipBroadcasterService {
+	return &ClipBroadcasterService{
+		repo:         repo,
+		auditLogRepo: auditLogRepo,
+	}
+}
+
+// SetCacheInvalidationBus clears cached clips when their attribution changes
+func (s *ClipBroadcasterService) SetCacheInvalidationBus(bus *CacheInvalidationBus) {
+	s.invalidationBus = bus
+}
+
+// GetClipBroadcasters returns a clip's primary and featured broadcasters
+func (s *ClipBroadcasterService) GetClipBroadcasters(ctx context.Context, clipID uuid.UUID) (*models.ClipBroadcasters, error) {
+	return s.repo.GetClipBroadcasters(ctx, clipID)
+}
+
+// UpdateClipBroadcasters replaces a clip's attribution on behalf of a
+// moderator. The change is recorded in the moderation audit log.
+func (s *ClipBroadcasterService) UpdateClipBroadcasters(ctx context.Context, moderatorID, clipID uuid.UUID, req *models.UpdateClipBroadcastersRequest) (*models.ClipBroadcasters, error) {
+	attribution, err := normalizeClipBroadcasters(clipID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := s.repo.GetClipBroadcasters(ctx, clipID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SetClipBroadcasters(ctx, attribution, moderatorID); err != nil {
+		return nil, err
+	}
+
+	_ = s.auditLogRepo.Create(ctx, &models.ModerationAuditLog{
+		Action:      "clip_broadcasters_updated",
+		EntityType:  "clip",
+		EntityID:    clipID,
+		ModeratorID: moderatorID,
+		Metadata: map[string]interface{}{
+			"previous_primary":  previous.Primary,
+			"previous_featured": previous.Featured,
+			"primary":           attribution.Primary,
+			"featured":          attribution.Featured,
+		},
+	})
+
+	if s.invalidationBus != nil {
+		if err := s.invalidationBus.Publish(ctx, NewClipInvalidationEvent(CacheTopicClipUpdated, &models.Clip{ID: clipID})); err != nil {
+			log.Printf("Failed to publish clip cache invalidation: %v", err)
+		}
+	}
+
+	return attribution, nil
+}
+
+// normalizeClipBroadcasters trims a requested attribution and checks that
+// every broadcaster is credited once
+func normalizeClipBroadcasters(clipID uuid.UUID, req *models.UpdateClipBroadcastersRequest) (*models.ClipBroadcasters, error) {
+	if len(req.Featured) > models.MaxClipFeaturedBroadcasters {
+		return nil, ErrClipBroadcasterTooMany
+	}
+
+	normalize := func(b models.ClipBroadcaster) (models.ClipBroadcaster, error) {
+		b.BroadcasterID = strings.TrimSpace(b.BroadcasterID)
+		b.BroadcasterName = strings.TrimSpace(b.BroadcasterName)
+		if b.BroadcasterID == "" || b.BroadcasterName == "" {
+			return b, ErrClipBroadcasterMissing
+		}
+		return b, nil
+	}
+
+	primary, err := normalize(req.Primary)
+	if err != nil {
+		return nil, err
+	}
+
+	attribution := &models.ClipBroadcasters{
+		ClipID:   clipID,
+		Primary:  primary,
+		Featured: make([]models.ClipBroadcaster, 0, len(req.Featured)),
+	}
+	credited := map[string]bool{primary.BroadcasterID: true}
+	for _, b := range req.Featured {
+		featured, err := normalize(b)
+		if err != nil {
+			return nil, err
+		}
+		if credited[featured.BroadcasterID] {
+			return nil, ErrClipBroadcasterDuplicate
+		}
+		credited[featured.BroadcasterID] = true
+		attribution.Featured = append(attribution.Featured, featured)
+	}
+
+	return attribution, nil
+}
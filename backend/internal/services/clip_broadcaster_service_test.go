@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+// MockClipBroadcasterRepository is a mock implementation of ClipBroadcasterRepositoryInterface
+type MockClipBroadcasterRepository struct {
+	mock.Mock
+}
+
+func (m *MockClipBroadcasterRepository) GetClipBroadcasters(ctx context.Context, clipID uuid.UUID) (*models.ClipBroadcasters, error) {
+	args := m.Called(ctx, clipID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ClipBroadcasters), args.Error(1)
+}
+
+func (m *MockClipBroadcasterRepository) SetClipBroadcasters(ctx context.Context, attribution *models.ClipBroadcasters, updatedBy uuid.UUID) error {
+	args := m.Called(ctx, attribution, updatedBy)
+	return args.Error(0)
+}
+
+func TestClipBroadcasterService_UpdateClipBroadcasters(t *testing.T) {
+	ctx := context.Background()
+	clipID := uuid.New()
+	moderatorID := uuid.New()
+	repo := new(MockClipBroadcasterRepository)
+	audit := new(MockModerationAuditLogRepository)
+	svc := NewClipBroadcasterService(repo, audit)
+
+	repo.On("GetClipBroadcasters", ctx, clipID).Return(&models.ClipBroadcasters{
+		ClipID:  clipID,
+		Primary: models.ClipBroadcaster{BroadcasterID: "1", BroadcasterName: "wrongstreamer"},
+	}, nil).Once()
+	var stored *models.ClipBroadcasters
+	repo.On("SetClipBroadcasters", ctx, mock.AnythingOfType("*models.ClipBroadcasters"), moderatorID).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*models.ClipBroadcasters)
+	}).Return(nil).Once()
+	var auditLog *models.ModerationAuditLog
+	audit.On("Create", ctx, mock.AnythingOfType("*models.ModerationAuditLog")).Run(func(args mock.Arguments) {
+		auditLog = args.Get(1).(*models.ModerationAuditLog)
+	}).Return(nil).Once()
+
+	attribution, err := svc.UpdateClipBroadcasters(ctx, moderatorID, clipID, &models.UpdateClipBroadcastersRequest{
+		Primary: models.ClipBroadcaster{BroadcasterID: " 2 ", BroadcasterName: " hoststreamer "},
+		Featured: []models.ClipBroadcaster{
+			{BroadcasterID: "1", BroadcasterName: "wrongstreamer"},
+			{BroadcasterID: "3", BroadcasterName: "guest"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.ClipBroadcaster{BroadcasterID: "2", BroadcasterName: "hoststreamer"}, attribution.Primary)
+	assert.Len(t, attribution.Featured, 2)
+	assert.Equal(t, attribution, stored)
+
+	require.NotNil(t, auditLog)
+	assert.Equal(t, "clip_broadcasters_updated", auditLog.Action)
+	assert.Equal(t, moderatorID, auditLog.ModeratorID)
+	assert.Equal(t, "wrongstreamer", auditLog.Metadata["previous_primary"].(models.ClipBroadcaster).BroadcasterName)
+
+	repo.AssertExpectations(t)
+	audit.AssertExpectations(t)
+}
+
+func TestClipBroadcasterService_Validation(t *testing.T) {
+	ctx := context.Background()
+	clipID := uuid.New()
+	repo := new(MockClipBroadcasterRepository)
+	audit := new(MockModerationAuditLogRepository)
+	svc := NewClipBroadcasterService(repo, audit)
+	primary := models.ClipBroadcaster{BroadcasterID: "1", BroadcasterName: "streamer"}
+
+	_, err := svc.UpdateClipBroadcasters(ctx, uuid.New(), clipID, &models.UpdateClipBroadcastersRequest{
+		Primary: models.ClipBroadcaster{BroadcasterID: " ", BroadcasterName: "streamer"},
+	})
+	assert.ErrorIs(t, err, ErrClipBroadcasterMissing)
+
+	_, err = svc.UpdateClipBroadcasters(ctx, uuid.New(), clipID, &models.UpdateClipBroadcastersRequest{
+		Primary:  primary,
+		Featured: []models.ClipBroadcaster{{BroadcasterID: "1", BroadcasterName: "streamer"}},
+	})
+	assert.ErrorIs(t, err, ErrClipBroadcasterDuplicate, "the primary broadcaster can't also be featured")
+
+	_, err = svc.UpdateClipBroadcasters(ctx, uuid.New(), clipID, &models.UpdateClipBroadcastersRequest{
+		Primary: primary,
+		Featured: []models.ClipBroadcaster{
+			{BroadcasterID: "2", BroadcasterName: "guest"},
+			{BroadcasterID: "2", BroadcasterName: "Guest"},
+		},
+	})
+	assert.ErrorIs(t, err, ErrClipBroadcasterDuplicate)
+
+	tooMany := make([]models.ClipBroadcaster, models.MaxClipFeaturedBroadcasters+1)
+	_, err = svc.UpdateClipBroadcasters(ctx, uuid.New(), clipID, &models.UpdateClipBroadcastersRequest{Primary: primary, Featured: tooMany})
+	assert.ErrorIs(t, err, ErrClipBroadcasterTooMany)
+
+	missingClip := uuid.New()
+	repo.On("GetClipBroadcasters", ctx, missingClip).Return(nil, repository.ErrClipBroadcastersClipNotFound).Once()
+	_, err = svc.UpdateClipBroadcasters(ctx, uuid.New(), missingClip, &models.UpdateClipBroadcastersRequest{Primary: primary})
+	assert.ErrorIs(t, err, repository.ErrClipBroadcastersClipNotFound)
+
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "SetClipBroadcasters", mock.Anything, mock.Anything, mock.Anything)
+	audit.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const (
+	clipChangefeedComponent = "clip_changefeed"
+
+	// clipChangefeedRelayBatchSize caps the events sequenced and published per relay pass
+	clipChangefeedRelayBatchSize = 500
+)
+
+// ErrInvalidChangefeedCursor is returned when a changefeed is read from a negative sequence
+var ErrInvalidChangefeedCursor = errors.New("since must be a non-negative sequence number")
+
+// clipChangeWebhookEvents maps changefeed event types to webhook events
+var clipChangeWebhookEvents = map[string]string{
+	models.ClipChangeCreate: models.WebhookEventClipCreated,
+	models.ClipChangeUpdate: models.WebhookEventClipUpdated,
+	models.ClipChangeDelete: models.WebhookEventClipDeleted,
+}
+
+// ClipChangefeedRepositoryInterface defines the clip outbox operations used by ClipChangefeedService
+type ClipChangefeedRepositoryInterface interface {
+	ListSince(ctx context.Context, since int64, limit int) ([]models.ClipChangeEvent, error)
+	AssignSequences(ctx context.Context, limit int) (int, error)
+	ClaimUnpublished(ctx context.Context, limit int) ([]models.ClipChangeEvent, error)
+	ReleaseUnpublished(ctx context.Context, sequences []int64) error
+}
+
+// ClipChangeWebhookTrigger queues webhook deliveries for changefeed events
+type ClipChangeWebhookTrigger interface {
+	TriggerEvent(ctx context.Context, eventType string, eventID uuid.UUID, data map[string]interface{}) error
+}
+
+// ClipChangefeedService serves the changefeed of public clip changes and
+// relays new outbox events to it and to webhook subscribers
+type ClipChangefeedService struct {
+	repo     ClipChangefeedRepositoryInterface
+	webhooks ClipChangeWebhookTrigger
+}
+
+// NewClipChangefeedService creates a new clip changefeed service
+func NewClipChangefeedService(repo ClipChangefeedRepositoryInterface) *ClipChangefeedService {
+	return &ClipChangefeedService{repo: repo}
+}
+
+// SetWebhookTrigger enables clip.created, clip.updated and clip.deleted webhooks
+func (s *ClipChangefeedService) SetWebhookTrigger(webhooks ClipChangeWebhookTrigger) {
+	s.webhooks = webhooks
+}
+
+// GetChanges returns the events after the since sequence number. Reading
+// from 0 replays the feed from the start.
+func (s *ClipChangefeedService) GetChanges(ctx context.Context, since int64, limit int) (*models.ClipChangefeedPage, error) {
+	if since < 0 {
+		return nil, ErrInvalidChangefeedCursor
+	}
+	if limit < 1 {
+		limit = models.ClipChangefeedDefaultLimit
+	}
+	if limit > models.ClipChangefeedMaxLimit {
+		limit = models.ClipChangefeedMaxLimit
+	}
+
+	// Fetch one extra event to know whether another page follows
+	events, err := s.repo.ListSince(ctx, since, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.ClipChangefeedPage{
+		Events:    events,
+		NextSince: since,
+	}
+	if len(events) > limit {
+		page.Events = events[:limit]
+		page.HasMore = true
+	}
+	if n := len(page.Events); n > 0 {
+		page.NextSince = page.Events[n-1].Sequence
+	}
+	return page, nil
+}
+
+// Relay sequences newly committed outbox events, then queues webhook
+// deliveries for them when webhooks are enabled. It returns the number of
+// events sequenced.
+func (s *ClipChangefeedService) Relay(ctx context.Context) (int, error) {
+	sequenced, err := s.repo.AssignSequences(ctx, clipChangefeedRelayBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	if s.webhooks != nil {
+		if err := s.publishWebhooks(ctx); err != nil {
+			return sequenced, err
+		}
+	}
+	return sequenced, nil
+}
+
+// publishWebhooks queues webhook deliveries for sequenced events not yet
+// published. Events whose delivery could not be queued are released for the
+// next pass.
+func (s *ClipChangefeedService) publishWebhooks(ctx context.Context) error {
+	events, err := s.repo.ClaimUnpublished(ctx, clipChangefeedRelayBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var failed []int64
+	for _, event := range events {
+		eventType, ok := clipChangeWebhookEvents[event.Type]
+		if !ok {
+			continue
+		}
+		if err := s.webhooks.TriggerEvent(ctx, eventType, event.EventID, clipChangeWebhookData(event)); err != nil {
+			utils.Error("Failed to queue clip changefeed webhook", err, map[string]interface{}{
+				"component": clipChangefeedComponent,
+				"sequence":  event.Sequence,
+				"clip_id":   event.ClipID,
+			})
+			failed = append(failed, event.Sequence)
+		}
+	}
+
+	if len(failed) > 0 {
+		if err := s.repo.ReleaseUnpublished(ctx, failed); err != nil {
+			return err
+		}
+		return fmt.Errorf("failed to queue webhooks for %d clip change events", len(failed))
+	}
+	return nil
+}
+
+// clipChangeWebhookData builds the webhook data of a changefeed event. The
+// sequence lets subscribers order deliveries and resume from the changefeed.
+func clipChangeWebhookData(event models.ClipChangeEvent) map[string]interface{} {
+	data := map[string]interface{}{
+		"sequence":    event.Sequence,
+		"event_id":    event.EventID,
+		"type":        event.Type,
+		"clip_id":     event.ClipID,
+		"occurred_at": event.OccurredAt,
+	}
+	if len(event.Clip) > 0 {
+		data["clip"] = json.RawMessage(event.Clip)
+	}
+	return data
+}
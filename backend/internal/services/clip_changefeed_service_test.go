@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+type mockClipChangefeedRepository struct {
+	events      []models.ClipChangeEvent
+	unpublished []models.ClipChangeEvent
+	released    []int64
+	lastLimit   int
+}
+
+func (m *mockClipChangefeedRepository) ListSince(ctx context.Context, since int64, limit int) ([]models.ClipChangeEvent, error) {
+	m.lastLimit = limit
+	result := []models.ClipChangeEvent{}
+	for _, event := range m.events {
+		if event.Sequence > since && len(result) < limit {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockClipChangefeedRepository) AssignSequences(ctx context.Context, limit int) (int, error) {
+	return len(m.unpublished), nil
+}
+
+func (m *mockClipChangefeedRepository) ClaimUnpublished(ctx context.Context, limit int) ([]models.ClipChangeEvent, error) {
+	claimed := m.unpublished
+	m.unpublished = nil
+	return claimed, nil
+}
+
+func (m *mockClipChangefeedRepository) ReleaseUnpublished(ctx context.Context, sequences []int64) error {
+	m.released = append(m.released, sequences...)
+	return nil
+}
+
+type mockClipChangeWebhookTrigger struct {
+	failClipID uuid.UUID
+	triggered  []string
+	data       []map[string]interface{}
+}
+
+func (m *mockClipChangeWebhookTrigger) TriggerEvent(ctx context.Context, eventType string, eventID uuid.UUID, data map[string]interface{}) error {
+	if data["clip_id"] == m.failClipID {
+		return errors.New("queue unavailable")
+	}
+	m.triggered = append(m.triggered, eventType)
+	m.data = append(m.data, data)
+	return nil
+}
+
+func newClipChangeEvent(sequence int64, eventType string) models.ClipChangeEvent {
+	return models.ClipChangeEvent{
+		Sequence:   sequence,
+		EventID:    uuid.New(),
+		Type:       eventType,
+		ClipID:     uuid.New(),
+		Clip:       json.RawMessage(`{"title":"clip"}`),
+		OccurredAt: time.Now(),
+	}
+}
+
+func TestClipChangefeedService_GetChanges(t *testing.T) {
+	repo := &mockClipChangefeedRepository{}
+	for i := int64(1); i <= 5; i++ {
+		repo.events = append(repo.events, newClipChangeEvent(i, models.ClipChangeCreate))
+	}
+	service := NewClipChangefeedService(repo)
+
+	t.Run("Pages from a cursor", func(t *testing.T) {
+		page, err := service.GetChanges(context.Background(), 1, 2)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(page.Events) != 2 || page.Events[0].Sequence != 2 {
+			t.Fatalf("Expected events 2-3, got %+v", page.Events)
+		}
+		if page.NextSince != 3 {
+			t.Errorf("Expected next_since 3, got %d", page.NextSince)
+		}
+		if !page.HasMore {
+			t.Error("Expected has_more")
+		}
+	})
+
+	t.Run("Last page", func(t *testing.T) {
+		page, err := service.GetChanges(context.Background(), 3, 10)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(page.Events) != 2 || page.NextSince != 5 || page.HasMore {
+			t.Errorf("Expected final events 4-5, got %+v", page)
+		}
+	})
+
+	t.Run("Caught up keeps the cursor", func(t *testing.T) {
+		page, err := service.GetChanges(context.Background(), 5, 10)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(page.Events) != 0 || page.NextSince != 5 || page.HasMore {
+			t.Errorf("Expected an empty page at 5, got %+v", page)
+		}
+	})
+
+	t.Run("Clamps the limit", func(t *testing.T) {
+		if _, err := service.GetChanges(context.Background(), 0, 50000); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if repo.lastLimit != models.ClipChangefeedMaxLimit+1 {
+			t.Errorf("Expected limit %d, got %d", models.ClipChangefeedMaxLimit+1, repo.lastLimit)
+		}
+		if _, err := service.GetChanges(context.Background(), 0, 0); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if repo.lastLimit != models.ClipChangefeedDefaultLimit+1 {
+			t.Errorf("Expected limit %d, got %d", models.ClipChangefeedDefaultLimit+1, repo.lastLimit)
+		}
+	})
+
+	t.Run("Rejects a negative cursor", func(t *testing.T) {
+		if _, err := service.GetChanges(context.Background(), -1, 10); !errors.Is(err, ErrInvalidChangefeedCursor) {
+			t.Errorf("Expected ErrInvalidChangefeedCursor, got %v", err)
+		}
+	})
+}
+
+func TestClipChangefeedService_Relay(t *testing.T) {
+	created := newClipChangeEvent(1, models.ClipChangeCreate)
+	deleted := newClipChangeEvent(2, models.ClipChangeDelete)
+	deleted.Clip = nil
+
+	t.Run("Queues webhooks for each event", func(t *testing.T) {
+		repo := &mockClipChangefeedRepository{unpublished: []models.ClipChangeEvent{created, deleted}}
+		webhooks := &mockClipChangeWebhookTrigger{}
+		service := NewClipChangefeedService(repo)
+		service.SetWebhookTrigger(webhooks)
+
+		sequenced, err := service.Relay(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if sequenced != 2 {
+			t.Errorf("Expected 2 events sequenced, got %d", sequenced)
+		}
+		if len(webhooks.triggered) != 2 || webhooks.triggered[0] != models.WebhookEventClipCreated || webhooks.triggered[1] != models.WebhookEventClipDeleted {
+			t.Errorf("Expected clip.created then clip.deleted, got %v", webhooks.triggered)
+		}
+		if webhooks.data[0]["sequence"] != int64(1) {
+			t.Errorf("Expected webhook data to carry the sequence, got %v", webhooks.data[0]["sequence"])
+		}
+		if _, ok := webhooks.data[1]["clip"]; ok {
+			t.Error("Expected no clip document for a delete event")
+		}
+		if len(repo.released) != 0 {
+			t.Errorf("Expected nothing released, got %v", repo.released)
+		}
+	})
+
+	t.Run("Releases events whose webhook could not be queued", func(t *testing.T) {
+		repo := &mockClipChangefeedRepository{unpublished: []models.ClipChangeEvent{created, deleted}}
+		webhooks := &mockClipChangeWebhookTrigger{failClipID: deleted.ClipID}
+		service := NewClipChangefeedService(repo)
+		service.SetWebhookTrigger(webhooks)
+
+		if _, err := service.Relay(context.Background()); err == nil {
+			t.Fatal("Expected an error when a webhook could not be queued")
+		}
+		if len(repo.released) != 1 || repo.released[0] != deleted.Sequence {
+			t.Errorf("Expected sequence %d released, got %v", deleted.Sequence, repo.released)
+		}
+	})
+
+	t.Run("Skips webhooks when disabled", func(t *testing.T) {
+		repo := &mockClipChangefeedRepository{unpublished: []models.ClipChangeEvent{created}}
+		service := NewClipChangefeedService(repo)
+
+		if _, err := service.Relay(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(repo.unpublished) != 1 {
+			t.Error("Expected events to stay unpublished without webhooks")
+		}
+	})
+}
@@ -0,0 +1,467 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+const (
+	clipEmbedFlushInterval = time.Minute
+	clipEmbedRetention     = 400 * 24 * time.Hour
+	// clipEmbedRulesTTL bounds how long an instance decides embeds from cached
+	// rules, so a rule set on another instance applies within this window
+	clipEmbedRulesTTL = time.Minute
+
+	clipEmbedDefaultReportDays  = 30
+	clipEmbedMaxReportDays      = 365
+	clipEmbedDefaultReportSites = 25
+	clipEmbedMaxReportSites     = 100
+)
+
+var (
+	// ErrInvalidEmbedDomain is returned for a rule domain that isn't a hostname
+	ErrInvalidEmbedDomain = errors.New("domain must be a hostname such as example.com")
+	// ErrEmbedDomainRuleNotFound is returned when deleting a rule that doesn't exist
+	ErrEmbedDomainRuleNotFound = errors.New("embed domain rule not found")
+	// ErrEmbedNotVerifiedBroadcaster is returned when a user without a verified Twitch channel manages embed domains
+	ErrEmbedNotVerifiedBroadcaster = errors.New("only verified broadcasters can manage embed domains")
+	// ErrInvalidEmbedReportDays is returned for a report window outside 1 to 365 days
+	ErrInvalidEmbedReportDays = errors.New("days must be between 1 and 365")
+)
+
+var embedHostnamePattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// ClipEmbedRepositoryInterface defines the repository methods used by ClipEmbedService
+type ClipEmbedRepositoryInterface interface {
+	ListRules(ctx context.Context, broadcasterID *string) ([]models.EmbedDomainRule, error)
+	ListRulesForEmbed(ctx context.Context, broadcasterID string) ([]models.EmbedDomainRule, error)
+	UpsertRule(ctx context.Context, rule *models.EmbedDomainRule) error
+	DeleteRule(ctx context.Context, id uuid.UUID) (bool, error)
+	DeleteBroadcasterRule(ctx context.Context, id uuid.UUID, broadcasterID string) (bool, error)
+	AddDailyStats(ctx context.Context, stats []models.ClipEmbedDailyStat) error
+	ListSiteStats(ctx context.Context, broadcasterID *string, since time.Time) ([]models.EmbedSiteStats, error)
+	DeleteDailyStatsBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// ClipEmbedUserRepositoryInterface defines the user lookups used by ClipEmbedService
+type ClipEmbedUserRepositoryInterface interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+}
+
+type clipEmbedStatKey struct {
+	day    time.Time
+	domain string
+	clipID uuid.UUID
+}
+
+type cachedEmbedRules struct {
+	rules    []models.EmbedDomainRule
+	loadedAt time.Time
+}
+
+// ClipEmbedService decides which sites may embed clips, from domain rules set
+// by admins and broadcasters, and records embed traffic per site into daily
+// rollups for partner reports. Traffic is counted in memory and flushed
+// periodically so the embed player never waits on a write.
+type ClipEmbedService struct {
+	repo     ClipEmbedRepositoryInterface
+	userRepo ClipEmbedUserRepositoryInterface
+	now      func() time.Time
+
+	stats *counterBuffer[clipEmbedStatKey, models.ClipEmbedDailyStat]
+
+	mu    sync.Mutex
+	rules map[string]cachedEmbedRules
+}
+
+// NewClipEmbedService creates a new ClipEmbedService
+func NewClipEmbedService(repo ClipEmbedRepositoryInterface, userRepo ClipEmbedUserRepositoryInterface) *ClipEmbedService {
+	return &ClipEmbedService{
+		repo:     repo,
+		userRepo: userRepo,
+		now:      time.Now,
+		stats:    newCounterBuffer[clipEmbedStatKey]("clip embed stats", repo.AddDailyStats, addClipEmbedStat),
+		rules:    make(map[string]cachedEmbedRules),
+	}
+}
+
+// Start flushes recorded traffic every minute and prunes old rollups hourly
+// until the context is cancelled, flushing once more on the way out
+func (s *ClipEmbedService) Start(ctx context.Context) {
+	s.stats.run(ctx, clipEmbedFlushInterval, func(ctx context.Context) {
+		if _, err := s.repo.DeleteDailyStatsBefore(ctx, s.today().Add(-clipEmbedRetention)); err != nil {
+			log.Printf("Failed to prune clip embed stats: %v", err)
+		}
+	})
+}
+
+// NormalizeEmbedDomain returns the hostname of a domain or URL entered for a
+// rule, lowercased and without a leading www.
+func NormalizeEmbedDomain(input string) (string, error) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if strings.Contains(input, "://") {
+		parsed, err := url.Parse(input)
+		if err != nil {
+			return "", ErrInvalidEmbedDomain
+		}
+		input = parsed.Host
+	}
+	if host, _, err := net.SplitHostPort(input); err == nil {
+		input = host
+	}
+	input = strings.TrimPrefix(strings.TrimSuffix(input, "."), "www.")
+	if len(input) > 253 || !embedHostnamePattern.MatchString(input) {
+		return "", ErrInvalidEmbedDomain
+	}
+	return input, nil
+}
+
+// EmbedDomainFromReferrer returns the site an embed request came from, or
+// models.EmbedDomainUnknown when the page sent no usable referrer
+func EmbedDomainFromReferrer(referrer string) string {
+	if referrer == "" {
+		return models.EmbedDomainUnknown
+	}
+	parsed, err := url.Parse(referrer)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return models.EmbedDomainUnknown
+	}
+	domain, err := NormalizeEmbedDomain(parsed.Hostname())
+	if err != nil {
+		return models.EmbedDomainUnknown
+	}
+	return domain
+}
+
+// embedDomainMatches reports whether a rule on ruleDomain covers domain
+func embedDomainMatches(ruleDomain, domain string) bool {
+	return domain == ruleDomain || strings.HasSuffix(domain, "."+ruleDomain)
+}
+
+// embedAllowedBy reports whether one scope's rules let domain embed. A block
+// rule always wins; once a scope has an allow rule, only allowed domains may
+// embed, and a page that sent no referrer can't be told apart from any other.
+func embedAllowedBy(rules []models.EmbedDomainRule, domain string) bool {
+	hasAllow, allowed := false, false
+	for _, rule := range rules {
+		switch rule.Rule {
+		case models.EmbedDomainRuleBlock:
+			if embedDomainMatches(rule.Domain, domain) {
+				return false
+			}
+		case models.EmbedDomainRuleAllow:
+			hasAllow = true
+			if embedDomainMatches(rule.Domain, domain) {
+				allowed = true
+			}
+		}
+	}
+	return !hasAllow || allowed
+}
+
+// AllowedAncestors returns the frame-ancestors sources of a broadcaster's
+// clips: nil when any site may embed them, or the allowed domains when the
+// platform or the broadcaster restricts embedding to an allowlist. Browsers
+// enforce these even for pages that send no referrer.
+func (s *ClipEmbedService) AllowedAncestors(ctx context.Context, broadcasterID *string) ([]string, error) {
+	rules, err := s.rulesFor(ctx, broadcasterID)
+	if err != nil {
+		return nil, err
+	}
+
+	// With allowlists in both scopes a site must be on both. The
+	// broadcaster's list is the one they chose, and the referrer check
+	// enforces the platform's on top of it.
+	var platform, broadcaster []string
+	for _, rule := range rules {
+		if rule.Rule != models.EmbedDomainRuleAllow {
+			continue
+		}
+		sources := []string{"https://" + rule.Domain, "https://*." + rule.Domain}
+		if rule.BroadcasterID == nil {
+			platform = append(platform, sources...)
+		} else {
+			broadcaster = append(broadcaster, sources...)
+		}
+	}
+	if len(broadcaster) > 0 {
+		return broadcaster, nil
+	}
+	return platform, nil
+}
+
+// CheckEmbed reports whether a site may embed a broadcaster's clips. The
+// platform-wide rules and the broadcaster's rules must both allow it.
+func (s *ClipEmbedService) CheckEmbed(ctx context.Context, broadcasterID *string, domain string) (bool, error) {
+	rules, err := s.rulesFor(ctx, broadcasterID)
+	if err != nil {
+		return false, err
+	}
+
+	var platform, broadcaster []models.EmbedDomainRule
+	for _, rule := range rules {
+		if rule.BroadcasterID == nil {
+			platform = append(platform, rule)
+		} else {
+			broadcaster = append(broadcaster, rule)
+		}
+	}
+	return embedAllowedBy(platform, domain) && embedAllowedBy(broadcaster, domain), nil
+}
+
+// rulesFor returns the rules covering a broadcaster's clips, cached for a minute
+func (s *ClipEmbedService) rulesFor(ctx context.Context, broadcasterID *string) ([]models.EmbedDomainRule, error) {
+	key := ""
+	if broadcasterID != nil {
+		key = *broadcasterID
+	}
+
+	s.mu.Lock()
+	cached, ok := s.rules[key]
+	s.mu.Unlock()
+	if ok && s.now().Sub(cached.loadedAt) < clipEmbedRulesTTL {
+		return cached.rules, nil
+	}
+
+	var rules []models.EmbedDomainRule
+	var err error
+	if broadcasterID != nil {
+		rules, err = s.repo.ListRulesForEmbed(ctx, *broadcasterID)
+	} else {
+		rules, err = s.repo.ListRules(ctx, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.rules[key] = cachedEmbedRules{rules: rules, loadedAt: s.now()}
+	s.mu.Unlock()
+	return rules, nil
+}
+
+// RecordLoad counts one load of the embed player on a site, or one refused by a rule
+func (s *ClipEmbedService) RecordLoad(clip *models.Clip, domain string, blocked bool) {
+	s.record(clip, domain, func(stat *models.ClipEmbedDailyStat) {
+		if blocked {
+			stat.Blocked++
+		} else {
+			stat.Loads++
+		}
+	})
+}
+
+// RecordClick counts one visit to clpr.tv from the embed player on a site
+func (s *ClipEmbedService) RecordClick(clip *models.Clip, domain string) {
+	s.record(clip, domain, func(stat *models.ClipEmbedDailyStat) {
+		stat.Clicks++
+	})
+}
+
+func (s *ClipEmbedService) record(clip *models.Clip, domain string, count func(*models.ClipEmbedDailyStat)) {
+	key := clipEmbedStatKey{day: s.today(), domain: domain, clipID: clip.ID}
+	s.stats.add(key, func() models.ClipEmbedDailyStat {
+		return models.ClipEmbedDailyStat{Day: key.day, Domain: domain, ClipID: clip.ID, BroadcasterID: clip.BroadcasterID}
+	}, count)
+}
+
+// Flush writes recorded traffic to the daily rollups. Counts that fail to
+// write are kept for the next flush.
+func (s *ClipEmbedService) Flush(ctx context.Context) error {
+	return s.stats.flush(ctx)
+}
+
+// addClipEmbedStat adds the counts in from to into
+func addClipEmbedStat(into, from *models.ClipEmbedDailyStat) {
+	into.Loads += from.Loads
+	into.Clicks += from.Clicks
+	into.Blocked += from.Blocked
+}
+
+// ListRules returns a broadcaster's rules, or the platform-wide rules when broadcasterID is nil
+func (s *ClipEmbedService) ListRules(ctx context.Context, broadcasterID *string) ([]models.EmbedDomainRule, error) {
+	return s.repo.ListRules(ctx, broadcasterID)
+}
+
+// SetRule allows or blocks a domain for the request's broadcaster, or
+// platform-wide when it has none
+func (s *ClipEmbedService) SetRule(ctx context.Context, adminID uuid.UUID, req *models.CreateEmbedDomainRuleRequest) (*models.EmbedDomainRule, error) {
+	return s.setRule(ctx, adminID, req.BroadcasterID, req)
+}
+
+// DeleteRule deletes a rule of any scope
+func (s *ClipEmbedService) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	deleted, err := s.repo.DeleteRule(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrEmbedDomainRuleNotFound
+	}
+	s.invalidateRules()
+	return nil
+}
+
+// ListBroadcasterRules returns the rules of the caller's channel
+func (s *ClipEmbedService) ListBroadcasterRules(ctx context.Context, userID uuid.UUID) ([]models.EmbedDomainRule, error) {
+	broadcasterID, err := verifiedBroadcasterID(ctx, s.userRepo, userID, ErrEmbedNotVerifiedBroadcaster)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListRules(ctx, &broadcasterID)
+}
+
+// SetBroadcasterRule allows or blocks a domain from embedding the caller's clips
+func (s *ClipEmbedService) SetBroadcasterRule(ctx context.Context, userID uuid.UUID, req *models.CreateEmbedDomainRuleRequest) (*models.EmbedDomainRule, error) {
+	broadcasterID, err := verifiedBroadcasterID(ctx, s.userRepo, userID, ErrEmbedNotVerifiedBroadcaster)
+	if err != nil {
+		return nil, err
+	}
+	return s.setRule(ctx, userID, &broadcasterID, req)
+}
+
+// DeleteBroadcasterRule deletes one of the caller's rules
+func (s *ClipEmbedService) DeleteBroadcasterRule(ctx context.Context, userID, id uuid.UUID) error {
+	broadcasterID, err := verifiedBroadcasterID(ctx, s.userRepo, userID, ErrEmbedNotVerifiedBroadcaster)
+	if err != nil {
+		return err
+	}
+	deleted, err := s.repo.DeleteBroadcasterRule(ctx, id, broadcasterID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrEmbedDomainRuleNotFound
+	}
+	s.invalidateRules()
+	return nil
+}
+
+func (s *ClipEmbedService) setRule(ctx context.Context, userID uuid.UUID, broadcasterID *string, req *models.CreateEmbedDomainRuleRequest) (*models.EmbedDomainRule, error) {
+	domain, err := NormalizeEmbedDomain(req.Domain)
+	if err != nil {
+		return nil, err
+	}
+	rule := &models.EmbedDomainRule{
+		ID:            uuid.New(),
+		BroadcasterID: broadcasterID,
+		Domain:        domain,
+		Rule:          req.Rule,
+		CreatedBy:     &userID,
+		CreatedAt:     s.now(),
+	}
+	if err := s.repo.UpsertRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	s.invalidateRules()
+	return rule, nil
+}
+
+// invalidateRules applies a rule change on this instance at once; others
+// pick it up within clipEmbedRulesTTL
+func (s *ClipEmbedService) invalidateRules() {
+	s.mu.Lock()
+	s.rules = make(map[string]cachedEmbedRules)
+	s.mu.Unlock()
+}
+
+// GetPartnerReport returns the top sites embedding a broadcaster's clips, or
+// all clips when broadcasterID is nil, over the last days days
+func (s *ClipEmbedService) GetPartnerReport(ctx context.Context, broadcasterID *string, days, limit int) (*models.EmbedPartnerReport, error) {
+	if days == 0 {
+		days = clipEmbedDefaultReportDays
+	}
+	if days < 1 || days > clipEmbedMaxReportDays {
+		return nil, ErrInvalidEmbedReportDays
+	}
+	if limit < 1 {
+		limit = clipEmbedDefaultReportSites
+	}
+	if limit > clipEmbedMaxReportSites {
+		limit = clipEmbedMaxReportSites
+	}
+
+	// Include today, which is still being counted
+	from := s.today().AddDate(0, 0, -(days - 1))
+	sites, err := s.repo.ListSiteStats(ctx, broadcasterID, from)
+	if err != nil {
+		return nil, err
+	}
+	sites = s.mergePending(sites, broadcasterID, from)
+
+	report := &models.EmbedPartnerReport{
+		BroadcasterID: broadcasterID,
+		Days:          days,
+		From:          from,
+		To:            s.now().UTC(),
+		Sites:         sites,
+	}
+	for i := range sites {
+		sites[i].ClickThroughRate = ratio(sites[i].Clicks, sites[i].Loads)
+		report.Totals.Loads += sites[i].Loads
+		report.Totals.Clicks += sites[i].Clicks
+		report.Totals.Blocked += sites[i].Blocked
+	}
+	report.Totals.ClickThroughRate = ratio(report.Totals.Clicks, report.Totals.Loads)
+	if len(report.Sites) > limit {
+		report.Sites = report.Sites[:limit]
+	}
+	return report, nil
+}
+
+// GetBroadcasterReport returns the top sites embedding the caller's clips
+func (s *ClipEmbedService) GetBroadcasterReport(ctx context.Context, userID uuid.UUID, days, limit int) (*models.EmbedPartnerReport, error) {
+	broadcasterID, err := verifiedBroadcasterID(ctx, s.userRepo, userID, ErrEmbedNotVerifiedBroadcaster)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetPartnerReport(ctx, &broadcasterID, days, limit)
+}
+
+// mergePending adds traffic recorded since the last flush to stored site stats
+func (s *ClipEmbedService) mergePending(sites []models.EmbedSiteStats, broadcasterID *string, from time.Time) []models.EmbedSiteStats {
+	index := make(map[string]int, len(sites))
+	for i, site := range sites {
+		index[site.Domain] = i
+	}
+	s.stats.each(func(key clipEmbedStatKey, stat *models.ClipEmbedDailyStat) {
+		if key.day.Before(from) {
+			return
+		}
+		if broadcasterID != nil && (stat.BroadcasterID == nil || *stat.BroadcasterID != *broadcasterID) {
+			return
+		}
+		i, ok := index[key.domain]
+		if !ok {
+			i = len(sites)
+			index[key.domain] = i
+			sites = append(sites, models.EmbedSiteStats{Domain: key.domain})
+		}
+		sites[i].Loads += stat.Loads
+		sites[i].Clicks += stat.Clicks
+		sites[i].Blocked += stat.Blocked
+	})
+
+	sort.SliceStable(sites, func(i, j int) bool {
+		if sites[i].Loads != sites[j].Loads {
+			return sites[i].Loads > sites[j].Loads
+		}
+		return sites[i].Clicks > sites[j].Clicks
+	})
+	return sites
+}
+
+func (s *ClipEmbedService) today() time.Time {
+	return s.now().UTC().Truncate(24 * time.Hour)
+}
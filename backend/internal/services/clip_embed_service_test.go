@@ -0,0 +1,303 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// MockClipEmbedRepository is a mock implementation of ClipEmbedRepositoryInterface
+type MockClipEmbedRepository struct {
+	mock.Mock
+}
+
+func (m *MockClipEmbedRepository) ListRules(ctx context.Context, broadcasterID *string) ([]models.EmbedDomainRule, error) {
+	args := m.Called(ctx, broadcasterID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.EmbedDomainRule), args.Error(1)
+}
+
+func (m *MockClipEmbedRepository) ListRulesForEmbed(ctx context.Context, broadcasterID string) ([]models.EmbedDomainRule, error) {
+	args := m.Called(ctx, broadcasterID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.EmbedDomainRule), args.Error(1)
+}
+
+func (m *MockClipEmbedRepository) UpsertRule(ctx context.Context, rule *models.EmbedDomainRule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockClipEmbedRepository) DeleteRule(ctx context.Context, id uuid.UUID) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockClipEmbedRepository) DeleteBroadcasterRule(ctx context.Context, id uuid.UUID, broadcasterID string) (bool, error) {
+	args := m.Called(ctx, id, broadcasterID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockClipEmbedRepository) AddDailyStats(ctx context.Context, stats []models.ClipEmbedDailyStat) error {
+	args := m.Called(ctx, stats)
+	return args.Error(0)
+}
+
+func (m *MockClipEmbedRepository) ListSiteStats(ctx context.Context, broadcasterID *string, since time.Time) ([]models.EmbedSiteStats, error) {
+	args := m.Called(ctx, broadcasterID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.EmbedSiteStats), args.Error(1)
+}
+
+func (m *MockClipEmbedRepository) DeleteDailyStatsBefore(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func setupClipEmbedServiceTest(users map[uuid.UUID]*models.User) (*ClipEmbedService, *MockClipEmbedRepository) {
+	repo := new(MockClipEmbedRepository)
+	userRepo := new(MockUserRepository)
+	for id, user := range users {
+		userRepo.On("GetByID", mock.Anything, id).Return(user, nil)
+	}
+	userRepo.On("GetByID", mock.Anything, mock.Anything).Return(nil, errors.New("user not found"))
+
+	svc := NewClipEmbedService(repo, userRepo)
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	return svc, repo
+}
+
+func TestNormalizeEmbedDomain(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"example.com", "example.com"},
+		{"  Blog.Example.COM ", "blog.example.com"},
+		{"https://www.example.com/posts/1", "example.com"},
+		{"example.com:8080", "example.com"},
+		{"example.com.", "example.com"},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeEmbedDomain(tt.input)
+		require.NoError(t, err, tt.input)
+		assert.Equal(t, tt.want, got, tt.input)
+	}
+
+	for _, input := range []string{"", "localhost", "not a domain", "https://", "-bad-.com", "exa_mple.com"} {
+		_, err := NormalizeEmbedDomain(input)
+		assert.ErrorIs(t, err, ErrInvalidEmbedDomain, input)
+	}
+}
+
+func TestEmbedDomainFromReferrer(t *testing.T) {
+	assert.Equal(t, "news.example.com", EmbedDomainFromReferrer("https://news.example.com/article?id=3"))
+	assert.Equal(t, "example.com", EmbedDomainFromReferrer("https://www.example.com/"))
+	assert.Equal(t, models.EmbedDomainUnknown, EmbedDomainFromReferrer(""))
+	assert.Equal(t, models.EmbedDomainUnknown, EmbedDomainFromReferrer("android-app://com.example"))
+}
+
+func TestClipEmbedService_CheckEmbed(t *testing.T) {
+	broadcasterID := "12345"
+	otherBroadcaster := "67890"
+	platformBlock := models.EmbedDomainRule{ID: uuid.New(), Domain: "spam.example", Rule: models.EmbedDomainRuleBlock}
+	svc, repo := setupClipEmbedServiceTest(nil)
+	repo.On("ListRulesForEmbed", mock.Anything, broadcasterID).Return([]models.EmbedDomainRule{
+		platformBlock,
+		{ID: uuid.New(), BroadcasterID: &broadcasterID, Domain: "fansite.com", Rule: models.EmbedDomainRuleAllow},
+		{ID: uuid.New(), BroadcasterID: &broadcasterID, Domain: "blocked.fansite.com", Rule: models.EmbedDomainRuleBlock},
+	}, nil).Once()
+	repo.On("ListRulesForEmbed", mock.Anything, otherBroadcaster).Return([]models.EmbedDomainRule{platformBlock}, nil).Once()
+	repo.On("ListRules", mock.Anything, (*string)(nil)).Return([]models.EmbedDomainRule{platformBlock}, nil).Once()
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		broadcaster *string
+		domain      string
+		want        bool
+	}{
+		{"platform block applies to every broadcaster", &otherBroadcaster, "spam.example", false},
+		{"platform block covers subdomains", &otherBroadcaster, "cdn.spam.example", false},
+		{"no allowlist lets any site embed", &otherBroadcaster, "anything.com", true},
+		{"no allowlist lets unknown pages embed", &otherBroadcaster, models.EmbedDomainUnknown, true},
+		{"allowlisted domain", &broadcasterID, "fansite.com", true},
+		{"allowlisted subdomain", &broadcasterID, "www2.fansite.com", true},
+		{"block beats allow", &broadcasterID, "blocked.fansite.com", false},
+		{"domain off the allowlist", &broadcasterID, "other.com", false},
+		{"suffix that isn't a subdomain", &broadcasterID, "notfansite.com", false},
+		{"unknown page with an allowlist", &broadcasterID, models.EmbedDomainUnknown, false},
+		{"clip without a broadcaster uses platform rules", nil, "spam.example", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, err := svc.CheckEmbed(ctx, tt.broadcaster, tt.domain)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, allowed)
+		})
+	}
+
+	ancestors, err := svc.AllowedAncestors(ctx, &broadcasterID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://fansite.com", "https://*.fansite.com"}, ancestors)
+
+	ancestors, err = svc.AllowedAncestors(ctx, &otherBroadcaster)
+	require.NoError(t, err)
+	assert.Empty(t, ancestors)
+
+	// Rules are loaded once per broadcaster
+	repo.AssertExpectations(t)
+}
+
+func TestClipEmbedService_RulesAreCachedUntilChanged(t *testing.T) {
+	broadcasterID := "12345"
+	userID := uuid.New()
+	svc, repo := setupClipEmbedServiceTest(map[uuid.UUID]*models.User{
+		userID: {ID: userID, IsVerified: true, TwitchID: &broadcasterID},
+	})
+	ctx := context.Background()
+
+	repo.On("ListRulesForEmbed", mock.Anything, broadcasterID).Return([]models.EmbedDomainRule{}, nil).Once()
+	allowed, err := svc.CheckEmbed(ctx, &broadcasterID, "rival.com")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	_, err = svc.CheckEmbed(ctx, &broadcasterID, "rival.com")
+	require.NoError(t, err)
+	repo.AssertNumberOfCalls(t, "ListRulesForEmbed", 1)
+
+	repo.On("UpsertRule", mock.Anything, mock.MatchedBy(func(rule *models.EmbedDomainRule) bool {
+		return rule.Domain == "rival.com" && rule.Rule == models.EmbedDomainRuleBlock
+	})).Return(nil).Once()
+	rule, err := svc.SetBroadcasterRule(ctx, userID, &models.CreateEmbedDomainRuleRequest{Domain: "https://www.Rival.com/", Rule: models.EmbedDomainRuleBlock})
+	require.NoError(t, err)
+	assert.Equal(t, "rival.com", rule.Domain)
+	require.NotNil(t, rule.BroadcasterID)
+	assert.Equal(t, broadcasterID, *rule.BroadcasterID)
+
+	repo.On("ListRulesForEmbed", mock.Anything, broadcasterID).Return([]models.EmbedDomainRule{*rule}, nil).Once()
+	allowed, err = svc.CheckEmbed(ctx, &broadcasterID, "rival.com")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	repo.On("DeleteBroadcasterRule", mock.Anything, rule.ID, broadcasterID).Return(true, nil).Once()
+	repo.On("ListRulesForEmbed", mock.Anything, broadcasterID).Return([]models.EmbedDomainRule{}, nil).Once()
+	require.NoError(t, svc.DeleteBroadcasterRule(ctx, userID, rule.ID))
+	allowed, err = svc.CheckEmbed(ctx, &broadcasterID, "rival.com")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	repo.On("DeleteBroadcasterRule", mock.Anything, rule.ID, broadcasterID).Return(false, nil).Once()
+	assert.ErrorIs(t, svc.DeleteBroadcasterRule(ctx, userID, rule.ID), ErrEmbedDomainRuleNotFound)
+	repo.AssertExpectations(t)
+}
+
+func TestClipEmbedService_OnlyVerifiedBroadcastersManageRules(t *testing.T) {
+	twitchID := "12345"
+	unverified := uuid.New()
+	svc, repo := setupClipEmbedServiceTest(map[uuid.UUID]*models.User{
+		unverified: {ID: unverified, IsVerified: false, TwitchID: &twitchID},
+	})
+
+	_, err := svc.SetBroadcasterRule(context.Background(), unverified, &models.CreateEmbedDomainRuleRequest{Domain: "example.com", Rule: models.EmbedDomainRuleAllow})
+	assert.ErrorIs(t, err, ErrEmbedNotVerifiedBroadcaster)
+
+	_, err = svc.GetBroadcasterReport(context.Background(), unverified, 30, 0)
+	assert.ErrorIs(t, err, ErrEmbedNotVerifiedBroadcaster)
+	repo.AssertNotCalled(t, "UpsertRule", mock.Anything, mock.Anything)
+}
+
+func TestClipEmbedService_PartnerReport(t *testing.T) {
+	broadcasterID := "12345"
+	otherBroadcaster := "67890"
+	svc, repo := setupClipEmbedServiceTest(nil)
+	ctx := context.Background()
+
+	clip := &models.Clip{ID: uuid.New(), BroadcasterID: &broadcasterID}
+	otherClip := &models.Clip{ID: uuid.New(), BroadcasterID: &otherBroadcaster}
+	for i := 0; i < 8; i++ {
+		svc.RecordLoad(clip, "fansite.com", false)
+	}
+	svc.RecordClick(clip, "fansite.com")
+	svc.RecordClick(clip, "fansite.com")
+	svc.RecordLoad(clip, "news.example.com", false)
+	svc.RecordLoad(clip, "rival.com", true)
+	svc.RecordLoad(otherClip, "fansite.com", false)
+
+	// A failed flush keeps its counts for the next one
+	var flushed []models.ClipEmbedDailyStat
+	repo.On("AddDailyStats", mock.Anything, mock.Anything).Return(errors.New("database unavailable")).Once()
+	repo.On("AddDailyStats", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		flushed = args.Get(1).([]models.ClipEmbedDailyStat)
+	}).Return(nil).Once()
+	require.Error(t, svc.Flush(ctx))
+	require.NoError(t, svc.Flush(ctx))
+	require.Len(t, flushed, 4)
+	var loads, clicks, blocked int64
+	for _, stat := range flushed {
+		loads, clicks, blocked = loads+stat.Loads, clicks+stat.Clicks, blocked+stat.Blocked
+	}
+	assert.Equal(t, []int64{10, 2, 1}, []int64{loads, clicks, blocked})
+
+	// Traffic since the last flush shows up in reports too
+	svc.RecordLoad(clip, "news.example.com", false)
+	svc.RecordClick(clip, "news.example.com")
+
+	repo.On("ListSiteStats", mock.Anything, &broadcasterID, time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)).Return([]models.EmbedSiteStats{
+		{Domain: "fansite.com", Loads: 8, Clicks: 2},
+		{Domain: "news.example.com", Loads: 1},
+		{Domain: "rival.com", Blocked: 1},
+	}, nil).Once()
+	report, err := svc.GetPartnerReport(ctx, &broadcasterID, 7, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 7, report.Days)
+	assert.Equal(t, time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC), report.From)
+	require.Len(t, report.Sites, 3)
+
+	assert.Equal(t, "fansite.com", report.Sites[0].Domain)
+	assert.Equal(t, int64(8), report.Sites[0].Loads)
+	assert.Equal(t, int64(2), report.Sites[0].Clicks)
+	assert.InDelta(t, 0.25, report.Sites[0].ClickThroughRate, 0.0001)
+
+	assert.Equal(t, "news.example.com", report.Sites[1].Domain)
+	assert.Equal(t, int64(2), report.Sites[1].Loads)
+	assert.Equal(t, int64(1), report.Sites[1].Clicks)
+
+	assert.Equal(t, "rival.com", report.Sites[2].Domain)
+	assert.Equal(t, int64(1), report.Sites[2].Blocked)
+
+	assert.Equal(t, int64(10), report.Totals.Loads)
+	assert.Equal(t, int64(3), report.Totals.Clicks)
+	assert.Equal(t, int64(1), report.Totals.Blocked)
+	assert.InDelta(t, 0.3, report.Totals.ClickThroughRate, 0.0001)
+
+	// The limit trims the listed sites, not the totals
+	repo.On("ListSiteStats", mock.Anything, (*string)(nil), time.Date(2026, 2, 9, 0, 0, 0, 0, time.UTC)).Return([]models.EmbedSiteStats{
+		{Domain: "fansite.com", Loads: 9, Clicks: 2},
+		{Domain: "news.example.com", Loads: 1},
+		{Domain: "rival.com", Blocked: 1},
+	}, nil).Once()
+	report, err = svc.GetPartnerReport(ctx, nil, 0, 1)
+	require.NoError(t, err)
+	assert.Equal(t, clipEmbedDefaultReportDays, report.Days)
+	require.Len(t, report.Sites, 1)
+	assert.Equal(t, int64(9), report.Sites[0].Loads)
+	assert.Equal(t, int64(11), report.Totals.Loads)
+
+	_, err = svc.GetPartnerReport(ctx, nil, 400, 0)
+	assert.ErrorIs(t, err, ErrInvalidEmbedReportDays)
+	repo.AssertExpectations(t)
+}
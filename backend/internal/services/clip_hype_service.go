@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"log"
+	"math"
+	"time"
+
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+const (
+	chatActivityBucketSize    = 10 * time.Second
+	chatActivityFlushInterval = 30 * time.Second
+	chatActivityRetention     = 7 * 24 * time.Hour
+
+	// clipHypeBaseline is how much chat before a clipped moment sets the
+	// channel's usual rate
+	clipHypeBaseline = 10 * time.Minute
+	// clipHypeReactionDelay extends a clip's window past its end, since chat
+	// reacts a few seconds behind the stream
+	clipHypeReactionDelay = 20 * time.Second
+	// clipHypeSettleDelay is how long after creation a clip is scored, so chat
+	// reacting to it has been recorded
+	clipHypeSettleDelay = 2 * time.Minute
+	// clipHypeMaxAge keeps scoring inside the chat retention window
+	clipHypeMaxAge          = 6 * 24 * time.Hour
+	clipHypeBatchSize       = 200
+	clipHypeDefaultDuration = 30 * time.Second
+
+	// clipHypeMinMessages is the fewest messages in a clip's window that can
+	// count as a spike
+	clipHypeMinMessages = 5
+	// clipHypeMinBaselineRate floors the usual rate in messages per minute,
+	// so a near-silent chat waking up isn't scored as peak hype
+	clipHypeMinBaselineRate = 6.0
+	// clipHypeEmoteSaturation is the emotes per message at which emote use
+	// stops adding to the score
+	clipHypeEmoteSaturation = 2.0
+)
+
+// ClipHypeRepositoryInterface defines the repository methods used by ClipHypeService
+type ClipHypeRepositoryInterface interface {
+	AddChatActivity(ctx context.Context, buckets []models.ChatActivityBucket) error
+	ListChatActivity(ctx context.Context, broadcasterID string, from, to time.Time) ([]models.ChatActivityBucket, error)
+	DeleteChatActivityBefore(ctx context.Context, before time.Time) (int64, error)
+	ListHypeCandidates(ctx context.Context, createdAfter, createdBefore time.Time, lookback time.Duration, limit int) ([]models.ClipHypeCandidate, error)
+	UpsertHypeScore(ctx context.Context, score *models.ClipHypeScore) error
+}
+
+type chatActivityKey struct {
+	broadcasterID string
+	bucketStart   time.Time
+}
+
+// ClipHypeService records chat activity in followed broadcasters' channels
+// and scores how hyped chat was around the moment each clip was taken.
+// Messages are counted in memory and flushed periodically so the EventSub
+// webhook never waits on a write.
+type ClipHypeService struct {
+	repo     ClipHypeRepositoryInterface
+	now      func() time.Time
+	activity *counterBuffer[chatActivityKey, models.ChatActivityBucket]
+}
+
+// NewClipHypeService creates a new ClipHypeService
+func NewClipHypeService(repo ClipHypeRepositoryInterface) *ClipHypeService {
+	return &ClipHypeService{
+		repo:     repo,
+		now:      time.Now,
+		activity: newCounterBuffer[chatActivityKey]("chat activity", repo.AddChatActivity, addChatActivity),
+	}
+}
+
+// Start flushes recorded chat every 30 seconds and prunes old chat hourly
+// until the context is cancelled, flushing once more on the way out
+func (s *ClipHypeService) Start(ctx context.Context) {
+	s.activity.run(ctx, chatActivityFlushInterval, func(ctx context.Context) {
+		if _, err := s.repo.DeleteChatActivityBefore(ctx, s.now().Add(-chatActivityRetention)); err != nil {
+			log.Printf("Failed to prune chat activity: %v", err)
+		}
+	})
+}
+
+// RecordChatMessage counts one chat message, with its emotes, in a
+// broadcaster's channel
+func (s *ClipHypeService) RecordChatMessage(broadcasterID string, emotes int) {
+	if broadcasterID == "" {
+		return
+	}
+	key := chatActivityKey{broadcasterID: broadcasterID, bucketStart: s.now().UTC().Truncate(chatActivityBucketSize)}
+
+	s.activity.add(key, func() models.ChatActivityBucket {
+		return models.ChatActivityBucket{BroadcasterID: broadcasterID, BucketStart: key.bucketStart}
+	}, func(bucket *models.ChatActivityBucket) {
+		bucket.Messages++
+		bucket.Emotes += emotes
+	})
+}
+
+// Flush writes recorded chat to its buckets. Counts that fail to write are
+// kept for the next flush.
+func (s *ClipHypeService) Flush(ctx context.Context) error {
+	return s.activity.flush(ctx)
+}
+
+// addChatActivity adds the counts in from to into
+func addChatActivity(into, from *models.ChatActivityBucket) {
+	into.Messages += from.Messages
+	into.Emotes += from.Emotes
+}
+
+// ScoreClips scores recent clips whose channel's chat was recorded when they
+// were clipped, returning how many were scored. Clips are scored once, a
+// couple of minutes after they are created.
+func (s *ClipHypeService) ScoreClips(ctx context.Context) (int, error) {
+	now := s.now().UTC()
+	candidates, err := s.repo.ListHypeCandidates(ctx, now.Add(-clipHypeMaxAge), now.Add(-clipHypeSettleDelay), clipHypeBaseline, clipHypeBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	scored := 0
+	for i := range candidates {
+		if err := ctx.Err(); err != nil {
+			return scored, err
+		}
+
+		candidate := &candidates[i]
+		windowStart, windowEnd := clipHypeWindow(candidate)
+		buckets, err := s.repo.ListChatActivity(ctx, candidate.BroadcasterID, windowStart.Add(-clipHypeBaseline), windowEnd)
+		if err != nil {
+			log.Printf("Failed to load chat activity for clip %s: %v", candidate.ClipID, err)
+			continue
+		}
+
+		score := scoreClipHype(candidate, buckets)
+		score.ScoredAt = now
+		if err := s.repo.UpsertHypeScore(ctx, &score); err != nil {
+			log.Printf("Failed to store hype score for clip %s: %v", candidate.ClipID, err)
+			continue
+		}
+		scored++
+	}
+
+	return scored, nil
+}
+
+// clipHypeWindow returns when a clip's moment played on stream, from the
+// start of the clip to a reaction delay after the moment it was clipped,
+// aligned to chat buckets
+func clipHypeWindow(candidate *models.ClipHypeCandidate) (time.Time, time.Time) {
+	duration := clipHypeDefaultDuration
+	if candidate.Duration != nil && *candidate.Duration > 0 {
+		duration = time.Duration(*candidate.Duration * float64(time.Second))
+	}
+	createdAt := candidate.CreatedAt.UTC()
+	return createdAt.Add(-duration).Truncate(chatActivityBucketSize), createdAt.Add(clipHypeReactionDelay).Truncate(chatActivityBucketSize)
+}
+
+// scoreClipHype scores a clip from the chat buckets of its window and the
+// baseline before it. The score is how far chat outpaced its usual rate,
+// 1 - baseline/rate, scaled from 75% to 100% by emotes per message, so emote
+// spam without a spike scores nothing.
+func scoreClipHype(candidate *models.ClipHypeCandidate, buckets []models.ChatActivityBucket) models.ClipHypeScore {
+	windowStart, windowEnd := clipHypeWindow(candidate)
+	score := models.ClipHypeScore{ClipID: candidate.ClipID}
+
+	var baselineMessages int
+	var baselineStart time.Time
+	for _, bucket := range buckets {
+		if bucket.BucketStart.Before(windowStart) {
+			if baselineStart.IsZero() || bucket.BucketStart.Before(baselineStart) {
+				baselineStart = bucket.BucketStart
+			}
+			baselineMessages += bucket.Messages
+			continue
+		}
+		if bucket.BucketStart.Before(windowEnd) {
+			score.Messages += bucket.Messages
+			score.Emotes += bucket.Emotes
+		}
+	}
+
+	score.MessagesPerMinute = float64(score.Messages) / windowEnd.Sub(windowStart).Minutes()
+	// The usual rate counts from the first recorded bucket, so a stream or a
+	// recording that began shortly before the clip isn't diluted by silence
+	if !baselineStart.IsZero() {
+		score.BaselineMessagesPerMinute = float64(baselineMessages) / math.Max(windowStart.Sub(baselineStart).Minutes(), 1)
+	}
+
+	if score.Messages < clipHypeMinMessages {
+		return score
+	}
+	lift := score.MessagesPerMinute / math.Max(score.BaselineMessagesPerMinute, clipHypeMinBaselineRate)
+	if lift <= 1 {
+		return score
+	}
+
+	emoteShare := math.Min(float64(score.Emotes)/float64(score.Messages), clipHypeEmoteSaturation) / clipHypeEmoteSaturation
+	score.HypeScore = (1 - 1/lift) * (0.75 + 0.25*emoteShare)
+	return score
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// MockClipHypeRepository is a mock implementation of ClipHypeRepositoryInterface
+type MockClipHypeRepository struct {
+	mock.Mock
+}
+
+func (m *MockClipHypeRepository) AddChatActivity(ctx context.Context, buckets []models.ChatActivityBucket) error {
+	args := m.Called(ctx, buckets)
+	return args.Error(0)
+}
+
+func (m *MockClipHypeRepository) ListChatActivity(ctx context.Context, broadcasterID string, from, to time.Time) ([]models.ChatActivityBucket, error) {
+	args := m.Called(ctx, broadcasterID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ChatActivityBucket), args.Error(1)
+}
+
+func (m *MockClipHypeRepository) DeleteChatActivityBefore(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockClipHypeRepository) ListHypeCandidates(ctx context.Context, createdAfter, createdBefore time.Time, lookback time.Duration, limit int) ([]models.ClipHypeCandidate, error) {
+	args := m.Called(ctx, createdAfter, createdBefore, lookback, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ClipHypeCandidate), args.Error(1)
+}
+
+func (m *MockClipHypeRepository) UpsertHypeScore(ctx context.Context, score *models.ClipHypeScore) error {
+	args := m.Called(ctx, score)
+	return args.Error(0)
+}
+
+// chatBuckets returns a broadcaster's buckets from start to end with the same counts
+func chatBuckets(broadcasterID string, start, end time.Time, messages, emotes int) []models.ChatActivityBucket {
+	buckets := []models.ChatActivityBucket{}
+	for at := start; at.Before(end); at = at.Add(chatActivityBucketSize) {
+		buckets = append(buckets, models.ChatActivityBucket{BroadcasterID: broadcasterID, BucketStart: at, Messages: messages, Emotes: emotes})
+	}
+	return buckets
+}
+
+func TestClipHypeService_RecordAndFlush(t *testing.T) {
+	repo := new(MockClipHypeRepository)
+	svc := NewClipHypeService(repo)
+	now := time.Date(2026, 10, 16, 12, 0, 3, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	svc.RecordChatMessage("1337", 2)
+	svc.RecordChatMessage("1337", 0)
+	svc.RecordChatMessage("", 1)
+
+	repo.On("AddChatActivity", mock.Anything, mock.Anything).Return(errors.New("db down")).Once()
+	require.Error(t, svc.Flush(context.Background()))
+
+	// Counts from the failed flush are merged with new ones
+	svc.RecordChatMessage("1337", 1)
+	repo.On("AddChatActivity", mock.Anything, []models.ChatActivityBucket{{
+		BroadcasterID: "1337",
+		BucketStart:   time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+		Messages:      3,
+		Emotes:        3,
+	}}).Return(nil).Once()
+	require.NoError(t, svc.Flush(context.Background()))
+	repo.AssertExpectations(t)
+}
+
+func TestScoreClipHype(t *testing.T) {
+	createdAt := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	duration := 30.0
+	candidate := &models.ClipHypeCandidate{ClipID: uuid.New(), BroadcasterID: "1337", CreatedAt: createdAt, Duration: &duration}
+	windowStart, windowEnd := clipHypeWindow(candidate)
+	assert.Equal(t, createdAt.Add(-30*time.Second), windowStart)
+	assert.Equal(t, createdAt.Add(20*time.Second), windowEnd)
+
+	// 2 messages per bucket (12/min) for 10 minutes before the clip
+	baseline := chatBuckets("1337", windowStart.Add(-clipHypeBaseline), windowStart, 2, 0)
+
+	t.Run("spike with emotes", func(t *testing.T) {
+		// 8 messages per bucket (48/min), 2 emotes each: lift 4, full emote share
+		buckets := append(append([]models.ChatActivityBucket{}, baseline...), chatBuckets("1337", windowStart, windowEnd, 8, 16)...)
+		score := scoreClipHype(candidate, buckets)
+		assert.Equal(t, 40, score.Messages)
+		assert.Equal(t, 80, score.Emotes)
+		assert.InDelta(t, 12.0, score.BaselineMessagesPerMinute, 0.001)
+		assert.InDelta(t, 0.75, score.HypeScore, 0.001)
+	})
+
+	t.Run("spike without emotes", func(t *testing.T) {
+		buckets := append(append([]models.ChatActivityBucket{}, baseline...), chatBuckets("1337", windowStart, windowEnd, 8, 0)...)
+		assert.InDelta(t, 0.75*0.75, scoreClipHype(candidate, buckets).HypeScore, 0.001)
+	})
+
+	t.Run("usual chat scores nothing", func(t *testing.T) {
+		buckets := append(append([]models.ChatActivityBucket{}, baseline...), chatBuckets("1337", windowStart, windowEnd, 2, 10)...)
+		assert.Zero(t, scoreClipHype(candidate, buckets).HypeScore)
+	})
+
+	t.Run("quiet chat waking up is floored", func(t *testing.T) {
+		// One message a minute before, then 12/min: the baseline is floored
+		// at 6/min, so the lift is 2 rather than 12
+		quiet := []models.ChatActivityBucket{}
+		for at := windowStart.Add(-clipHypeBaseline); at.Before(windowStart); at = at.Add(time.Minute) {
+			quiet = append(quiet, models.ChatActivityBucket{BroadcasterID: "1337", BucketStart: at, Messages: 1})
+		}
+		buckets := append(quiet, chatBuckets("1337", windowStart, windowEnd, 2, 0)...)
+		assert.InDelta(t, 0.5*0.75, scoreClipHype(candidate, buckets).HypeScore, 0.001)
+	})
+
+	t.Run("too few messages", func(t *testing.T) {
+		buckets := []models.ChatActivityBucket{{BroadcasterID: "1337", BucketStart: windowStart, Messages: 4, Emotes: 8}}
+		assert.Zero(t, scoreClipHype(candidate, buckets).HypeScore)
+	})
+}
+
+func TestClipHypeService_ScoreClips(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	settled := models.ClipHypeCandidate{ClipID: uuid.New(), BroadcasterID: "1337", CreatedAt: now.Add(-time.Hour)}
+	windowStart, windowEnd := clipHypeWindow(&settled)
+
+	// Clips are scored once chat reacting to them has settled
+	repo := new(MockClipHypeRepository)
+	repo.On("ListHypeCandidates", mock.Anything, now.Add(-clipHypeMaxAge), now.Add(-clipHypeSettleDelay), clipHypeBaseline, clipHypeBatchSize).
+		Return([]models.ClipHypeCandidate{settled}, nil).Once()
+	repo.On("ListChatActivity", mock.Anything, "1337", windowStart.Add(-clipHypeBaseline), windowEnd).
+		Return(append(chatBuckets("1337", windowStart.Add(-clipHypeBaseline), windowStart, 1, 0),
+			chatBuckets("1337", windowStart, windowEnd, 5, 5)...), nil).Once()
+	repo.On("UpsertHypeScore", mock.Anything, mock.MatchedBy(func(score *models.ClipHypeScore) bool {
+		return score.ClipID == settled.ClipID && score.HypeScore > 0 && score.ScoredAt.Equal(now)
+	})).Return(nil).Once()
+	svc := NewClipHypeService(repo)
+	svc.now = func() time.Time { return now }
+
+	scored, err := svc.ScoreClips(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, scored)
+
+	repo.On("ListHypeCandidates", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]models.ClipHypeCandidate{}, nil).Once()
+	scored, err = svc.ScoreClips(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, scored, "scored clips are not scored again")
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+// clipIngestionRuleCacheTTL bounds how long rule changes take to reach
+// syncs running in other processes
+const clipIngestionRuleCacheTTL = time.Minute
+
+// Clip ingestion rule errors
+var (
+	// ErrClipIngestionRuleNoConditions is returned for rules that would match every clip
+	ErrClipIngestionRuleNoConditions = errors.New("rule must have at least one game, broadcaster, language or view condition")
+	// ErrClipIngestionRuleInvalidViews is returned when min_views exceeds max_views
+	ErrClipIngestionRuleInvalidViews = errors.New("min_views must not exceed max_views")
+)
+
+// ClipIngestionRouter routes a synced clip by the ingestion rules it matches
+// and returns the number of targets it was added to. Implemented by
+// ClipIngestionRuleService.
+type ClipIngestionRouter interface {
+	RouteClip(ctx context.Context, clip *models.Clip) int
+}
+
+// ClipIngestionRuleRepositoryInterface defines the data access needed for ingestion rules
+type ClipIngestionRuleRepositoryInterface interface {
+	ListRules(ctx context.Context) ([]models.ClipIngestionRule, error)
+	ListEnabledRules(ctx context.Context) ([]models.ClipIngestionRule, error)
+	GetRule(ctx context.Context, ruleID uuid.UUID) (*models.ClipIngestionRule, error)
+	TargetExists(ctx context.Context, targetType string, targetID uuid.UUID) (bool, error)
+	CreateRule(ctx context.Context, rule *models.ClipIngestionRule) error
+	UpdateRule(ctx context.Context, rule *models.ClipIngestionRule) error
+	DeleteRule(ctx context.Context, ruleID uuid.UUID) error
+	RecordMatch(ctx context.Context, rule *models.ClipIngestionRule, clipID uuid.UUID) (bool, error)
+}
+
+// ClipIngestionRuleService manages admin rules that auto-curate synced clips
+// into discovery lists, feeds and communities, and evaluates them during sync
+type ClipIngestionRuleService struct {
+	repo ClipIngestionRuleRepositoryInterface
+	now  func() time.Time
+
+	mu       sync.Mutex
+	rules    []models.ClipIngestionRule
+	loadedAt time.Time
+}
+
+// NewClipIngestionRuleService creates a new clip ingestion rule service
+func NewClipIngestionRuleService(repo ClipIngestionRuleRepositoryInterface) *ClipIngestionRuleService {
+	return &ClipIngestionRuleService{
+		repo: repo,
+		now:  time.Now,
+	}
+}
+
+// ListRules returns all rules with their hit stats
+func (s *ClipIngestionRuleService) ListRules(ctx context.Context) ([]models.ClipIngestionRule, error) {
+	return s.repo.ListRules(ctx)
+}
+
+// GetRule returns a rule with its hit stats
+func (s *ClipIngestionRuleService) GetRule(ctx context.Context, ruleID uuid.UUID) (*models.ClipIngestionRule, error) {
+	return s.repo.GetRule(ctx, ruleID)
+}
+
+// CreateRule validates and creates a rule
+func (s *ClipIngestionRuleService) CreateRule(ctx context.Context, adminID uuid.UUID, req *models.ClipIngestionRuleRequest) (*models.ClipIngestionRule, error) {
+	rule := &models.ClipIngestionRule{CreatedBy: &adminID}
+	if err := s.applyRequest(ctx, rule, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+	return rule, nil
+}
+
+// UpdateRule replaces a rule's settings
+func (s *ClipIngestionRuleService) UpdateRule(ctx context.Context, ruleID uuid.UUID, req *models.ClipIngestionRuleRequest) (*models.ClipIngestionRule, error) {
+	rule, err := s.repo.GetRule(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyRequest(ctx, rule, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+	return rule, nil
+}
+
+// DeleteRule deletes a rule
+func (s *ClipIngestionRuleService) DeleteRule(ctx context.Context, ruleID uuid.UUID) error {
+	if err := s.repo.DeleteRule(ctx, ruleID); err != nil {
+		return err
+	}
+	s.invalidate()
+	return nil
+}
+
+func (s *ClipIngestionRuleService) applyRequest(ctx context.Context, rule *models.ClipIngestionRule, req *models.ClipIngestionRuleRequest) error {
+	rule.Name = strings.TrimSpace(req.Name)
+	rule.Enabled = req.Enabled == nil || *req.Enabled
+	rule.GameIDs = normalizeRuleValues(req.GameIDs, false)
+	rule.BroadcasterIDs = normalizeRuleValues(req.BroadcasterIDs, false)
+	rule.Languages = normalizeRuleValues(req.Languages, true)
+	rule.MinViews = req.MinViews
+	rule.MaxViews = req.MaxViews
+	rule.TargetType = req.TargetType
+	rule.TargetID = req.TargetID
+
+	if !rule.HasConditions() {
+		return ErrClipIngestionRuleNoConditions
+	}
+	if rule.MinViews != nil && rule.MaxViews != nil && *rule.MinViews > *rule.MaxViews {
+		return ErrClipIngestionRuleInvalidViews
+	}
+
+	exists, err := s.repo.TargetExists(ctx, rule.TargetType, rule.TargetID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return repository.ErrClipIngestionTargetNotFound
+	}
+	return nil
+}
+
+// normalizeRuleValues trims and de-duplicates condition values, lowercasing
+// them when asked
+func normalizeRuleValues(values []string, lower bool) []string {
+	normalized := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if lower {
+			value = strings.ToLower(value)
+		}
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		normalized = append(normalized, value)
+	}
+	return normalized
+}
+
+// RouteClip adds a synced clip to the target of every enabled rule it
+// matches. Each rule routes a clip once, so clips re-seen by later syncs,
+// e.g. after passing a view threshold, are only routed when first matched.
+// Failures are logged rather than failing the sync.
+func (s *ClipIngestionRuleService) RouteClip(ctx context.Context, clip *models.Clip) int {
+	if clip.IsRemoved || clip.IsHidden {
+		return 0
+	}
+
+	rules, err := s.enabledRules(ctx)
+	if err != nil {
+		utils.Warn("Failed to load clip ingestion rules", map[string]interface{}{"error": err.Error()})
+		return 0
+	}
+
+	routed := 0
+	for i := range rules {
+		rule := &rules[i]
+		if !rule.Matches(clip) {
+			continue
+		}
+		added, err := s.repo.RecordMatch(ctx, rule, clip.ID)
+		if err != nil {
+			utils.Warn("Failed to route clip by ingestion rule", map[string]interface{}{
+				"rule_id": rule.ID.String(),
+				"clip_id": clip.ID.String(),
+				"error":   err.Error(),
+			})
+			continue
+		}
+		if added {
+			routed++
+		}
+	}
+	return routed
+}
+
+// enabledRules returns the enabled rules, reloading them once the cached
+// copy is older than clipIngestionRuleCacheTTL
+func (s *ClipIngestionRuleService) enabledRules(ctx context.Context) ([]models.ClipIngestionRule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.rules != nil && s.now().Sub(s.loadedAt) < clipIngestionRuleCacheTTL {
+		return s.rules, nil
+	}
+
+	rules, err := s.repo.ListEnabledRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.rules = rules
+	s.loadedAt = s.now()
+	return rules, nil
+}
+
+func (s *ClipIngestionRuleService) invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rules = nil
+}
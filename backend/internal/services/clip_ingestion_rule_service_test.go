@@ -0,0 +1,218 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+// MockClipIngestionRuleRepository is a mock implementation of ClipIngestionRuleRepositoryInterface
+type MockClipIngestionRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockClipIngestionRuleRepository) ListRules(ctx context.Context) ([]models.ClipIngestionRule, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ClipIngestionRule), args.Error(1)
+}
+
+func (m *MockClipIngestionRuleRepository) ListEnabledRules(ctx context.Context) ([]models.ClipIngestionRule, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ClipIngestionRule), args.Error(1)
+}
+
+func (m *MockClipIngestionRuleRepository) GetRule(ctx context.Context, ruleID uuid.UUID) (*models.ClipIngestionRule, error) {
+	args := m.Called(ctx, ruleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ClipIngestionRule), args.Error(1)
+}
+
+func (m *MockClipIngestionRuleRepository) TargetExists(ctx context.Context, targetType string, targetID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, targetType, targetID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockClipIngestionRuleRepository) CreateRule(ctx context.Context, rule *models.ClipIngestionRule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockClipIngestionRuleRepository) UpdateRule(ctx context.Context, rule *models.ClipIngestionRule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockClipIngestionRuleRepository) DeleteRule(ctx context.Context, ruleID uuid.UUID) error {
+	args := m.Called(ctx, ruleID)
+	return args.Error(0)
+}
+
+func (m *MockClipIngestionRuleRepository) RecordMatch(ctx context.Context, rule *models.ClipIngestionRule, clipID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, rule, clipID)
+	return args.Bool(0), args.Error(1)
+}
+
+// ingestionRuleWithID matches the rule passed to the repository by ID
+func ingestionRuleWithID(ruleID uuid.UUID) interface{} {
+	return mock.MatchedBy(func(rule *models.ClipIngestionRule) bool { return rule.ID == ruleID })
+}
+
+func TestClipIngestionRule_Matches(t *testing.T) {
+	str := func(s string) *string { return &s }
+	minViews := 50000
+	rule := &models.ClipIngestionRule{
+		GameIDs:        []string{"516575"},
+		BroadcasterIDs: []string{"111", "222"},
+		Languages:      []string{"en", "pt-br"},
+		MinViews:       &minViews,
+	}
+	clip := func(game, broadcaster, language string, views int) *models.Clip {
+		return &models.Clip{GameID: str(game), BroadcasterID: str(broadcaster), Language: str(language), ViewCount: views}
+	}
+
+	assert.True(t, rule.Matches(clip("516575", "222", "EN", 50000)))
+	assert.True(t, rule.Matches(clip("516575", "111", "en-gb", 90000)), "rule languages match every region")
+	assert.True(t, rule.Matches(clip("516575", "111", "pt-br", 90000)))
+	assert.False(t, rule.Matches(clip("516575", "111", "pt", 90000)))
+	assert.False(t, rule.Matches(clip("516575", "333", "en", 90000)))
+	assert.False(t, rule.Matches(clip("33214", "111", "en", 90000)))
+	assert.False(t, rule.Matches(clip("516575", "111", "en", 49999)))
+	assert.False(t, rule.Matches(&models.Clip{ViewCount: 90000}))
+
+	maxViews := 100
+	assert.True(t, (&models.ClipIngestionRule{MaxViews: &maxViews}).Matches(&models.Clip{ViewCount: 100}))
+	assert.False(t, (&models.ClipIngestionRule{MaxViews: &maxViews}).Matches(&models.Clip{ViewCount: 101}))
+}
+
+func TestClipIngestionRuleService_CreateRule(t *testing.T) {
+	repo := new(MockClipIngestionRuleRepository)
+	listID := uuid.New()
+	repo.On("TargetExists", mock.Anything, models.ClipIngestionTargetDiscoveryList, listID).Return(true, nil)
+	repo.On("TargetExists", mock.Anything, models.ClipIngestionTargetDiscoveryList, mock.Anything).Return(false, nil)
+	repo.On("CreateRule", mock.Anything, mock.AnythingOfType("*models.ClipIngestionRule")).Return(nil).Once()
+	svc := NewClipIngestionRuleService(repo)
+	ctx := context.Background()
+	minViews, maxViews := 500, 100
+
+	_, err := svc.CreateRule(ctx, uuid.New(), &models.ClipIngestionRuleRequest{
+		Name: "Everything", TargetType: models.ClipIngestionTargetDiscoveryList, TargetID: listID,
+		GameIDs: []string{"  "},
+	})
+	assert.ErrorIs(t, err, ErrClipIngestionRuleNoConditions)
+
+	_, err = svc.CreateRule(ctx, uuid.New(), &models.ClipIngestionRuleRequest{
+		Name: "Backwards", TargetType: models.ClipIngestionTargetDiscoveryList, TargetID: listID,
+		MinViews: &minViews, MaxViews: &maxViews,
+	})
+	assert.ErrorIs(t, err, ErrClipIngestionRuleInvalidViews)
+
+	_, err = svc.CreateRule(ctx, uuid.New(), &models.ClipIngestionRuleRequest{
+		Name: "Missing list", TargetType: models.ClipIngestionTargetDiscoveryList, TargetID: uuid.New(),
+		MinViews: &minViews,
+	})
+	assert.ErrorIs(t, err, repository.ErrClipIngestionTargetNotFound)
+
+	adminID := uuid.New()
+	rule, err := svc.CreateRule(ctx, adminID, &models.ClipIngestionRuleRequest{
+		Name:           " Big Valorant clips ",
+		TargetType:     models.ClipIngestionTargetDiscoveryList,
+		TargetID:       listID,
+		BroadcasterIDs: []string{"111", " 111", "222"},
+		Languages:      []string{"EN", "en"},
+		MinViews:       &minViews,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Big Valorant clips", rule.Name)
+	assert.True(t, rule.Enabled)
+	assert.Equal(t, []string{"111", "222"}, rule.BroadcasterIDs)
+	assert.Equal(t, []string{"en"}, rule.Languages)
+	assert.Equal(t, &adminID, rule.CreatedBy)
+	repo.AssertExpectations(t)
+}
+
+func TestClipIngestionRuleService_RouteClip(t *testing.T) {
+	repo := new(MockClipIngestionRuleRepository)
+	listID, communityID := uuid.New(), uuid.New()
+	minViews := 50000
+	popular := models.ClipIngestionRule{
+		ID: uuid.New(), Name: "Popular", Enabled: true,
+		TargetType: models.ClipIngestionTargetDiscoveryList, TargetID: listID, MinViews: &minViews,
+	}
+	spanish := models.ClipIngestionRule{
+		ID: uuid.New(), Name: "Spanish", Enabled: true,
+		TargetType: models.ClipIngestionTargetCommunity, TargetID: communityID, Languages: []string{"es"},
+	}
+	svc := NewClipIngestionRuleService(repo)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// Rules are cached between clips
+	repo.On("ListEnabledRules", mock.Anything).Return([]models.ClipIngestionRule{popular, spanish}, nil).Once()
+
+	lang := "es"
+	clip := &models.Clip{ID: uuid.New(), Language: &lang, ViewCount: 1200}
+	repo.On("RecordMatch", mock.Anything, ingestionRuleWithID(spanish.ID), clip.ID).Return(true, nil).Once()
+	repo.On("RecordMatch", mock.Anything, ingestionRuleWithID(spanish.ID), clip.ID).Return(false, nil).Twice()
+	assert.Equal(t, 1, svc.RouteClip(ctx, clip))
+
+	// The clip passes the view threshold on a later sync; the language rule
+	// already routed it
+	clip.ViewCount = 64000
+	repo.On("RecordMatch", mock.Anything, ingestionRuleWithID(popular.ID), clip.ID).Return(true, nil).Once()
+	repo.On("RecordMatch", mock.Anything, ingestionRuleWithID(popular.ID), clip.ID).Return(false, nil).Once()
+	assert.Equal(t, 1, svc.RouteClip(ctx, clip))
+	assert.Equal(t, 0, svc.RouteClip(ctx, clip))
+
+	hidden := &models.Clip{ID: uuid.New(), Language: &lang, IsHidden: true}
+	assert.Equal(t, 0, svc.RouteClip(ctx, hidden))
+
+	// A deleted target is logged and skipped
+	orphaned := &models.Clip{ID: uuid.New(), Language: &lang}
+	repo.On("RecordMatch", mock.Anything, ingestionRuleWithID(spanish.ID), orphaned.ID).
+		Return(false, repository.ErrClipIngestionTargetNotFound).Once()
+	assert.Equal(t, 0, svc.RouteClip(ctx, orphaned))
+
+	// Rule changes reload the cache
+	stored := popular
+	repo.On("GetRule", mock.Anything, popular.ID).Return(&stored, nil).Once()
+	repo.On("TargetExists", mock.Anything, models.ClipIngestionTargetDiscoveryList, listID).Return(true, nil).Once()
+	repo.On("UpdateRule", mock.Anything, mock.MatchedBy(func(rule *models.ClipIngestionRule) bool {
+		return rule.ID == popular.ID && !rule.Enabled
+	})).Return(nil).Once()
+	disabled := false
+	_, err := svc.UpdateRule(ctx, popular.ID, &models.ClipIngestionRuleRequest{
+		Name: "Popular", Enabled: &disabled, TargetType: models.ClipIngestionTargetDiscoveryList, TargetID: listID, MinViews: &minViews,
+	})
+	require.NoError(t, err)
+
+	// And so does the TTL
+	repo.On("ListEnabledRules", mock.Anything).Return([]models.ClipIngestionRule{spanish}, nil).Twice()
+	assert.Equal(t, 0, svc.RouteClip(ctx, &models.Clip{ID: uuid.New(), ViewCount: 90000}))
+	now = now.Add(clipIngestionRuleCacheTTL)
+	svc.RouteClip(ctx, &models.Clip{ID: uuid.New()})
+
+	unknownID := uuid.New()
+	repo.On("GetRule", mock.Anything, unknownID).Return(nil, repository.ErrClipIngestionRuleNotFound).Once()
+	_, err = svc.UpdateRule(ctx, unknownID, &models.ClipIngestionRuleRequest{})
+	assert.True(t, errors.Is(err, repository.ErrClipIngestionRuleNotFound))
+
+	repo.AssertExpectations(t)
+	repo.AssertNumberOfCalls(t, "ListEnabledRules", 3)
+}
@@ -23,6 +23,7 @@ type MirrorRepositoryContract interface {
 	CreateMetric(ctx context.Context, metric *models.MirrorMetrics) error
 	GetMirrorHitRate(ctx context.Context, startTime time.Time) (float64, error)
 	GetPopularClipsForMirroring(ctx context.Context, threshold int, maxMirrors int, limit int) ([]uuid.UUID, error)
+	GetClipsWithFailingEmbeds(ctx context.Context, minEvents int, minFailureRate float64, maxMirrors int, limit int) ([]uuid.UUID, error)
 }
 
 // ClipRepositoryContract captures clip repository calls ClipMirrorService uses.
@@ -70,6 +71,26 @@ func (s *ClipMirrorService) IdentifyPopularClips(ctx context.Context) ([]uuid.UU
 	return clipIDs, nil
 }
 
+// IdentifyFailingEmbedClips identifies clips whose Twitch embed often fails for viewers
+func (s *ClipMirrorService) IdentifyFailingEmbedClips(ctx context.Context) ([]uuid.UUID, error) {
+	if !s.config.Enabled || s.config.EmbedFailureRate <= 0 {
+		return nil, nil
+	}
+
+	clipIDs, err := s.mirrorRepo.GetClipsWithFailingEmbeds(
+		ctx,
+		s.config.EmbedFailureMinEvents,
+		s.config.EmbedFailureRate,
+		s.config.MaxMirrorsPerClip,
+		100, // Process up to 100 clips per run
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to identify clips with failing embeds: %w", err)
+	}
+
+	return clipIDs, nil
+}
+
 // ReplicateClip replicates a clip to configured regions
 func (s *ClipMirrorService) ReplicateClip(ctx context.Context, clipID uuid.UUID) error {
 	if !s.config.Enabled {
@@ -247,6 +268,22 @@ func (s *ClipMirrorService) SyncPopularClips(ctx context.Context) error {
 		return fmt.Errorf("failed to identify popular clips: %w", err)
 	}
 
+	// Clips viewers cannot play through the Twitch embed need a mirror whatever their popularity
+	failingClipIDs, err := s.IdentifyFailingEmbedClips(ctx)
+	if err != nil {
+		utils.Warn("Failed to identify clips with failing embeds", map[string]interface{}{"error": err})
+	}
+	seen := make(map[uuid.UUID]bool, len(clipIDs))
+	for _, clipID := range clipIDs {
+		seen[clipID] = true
+	}
+	for _, clipID := range failingClipIDs {
+		if !seen[clipID] {
+			seen[clipID] = true
+			clipIDs = append(clipIDs, clipID)
+		}
+	}
+
 	utils.Info("Found clips to mirror", map[string]interface{}{"count": len(clipIDs)})
 
 	// Replicate each clip
@@ -79,6 +79,14 @@ func (m *MockMirrorRepository) GetPopularClipsForMirroring(ctx context.Context,
 	return args.Get(0).([]uuid.UUID), args.Error(1)
 }
 
+func (m *MockMirrorRepository) GetClipsWithFailingEmbeds(ctx context.Context, minEvents int, minFailureRate float64, maxMirrors int, limit int) ([]uuid.UUID, error) {
+	args := m.Called(ctx, minEvents, minFailureRate, maxMirrors, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
 // MockClipRepository is a mock implementation of ClipRepository
 type MockClipRepository struct {
 	mock.Mock
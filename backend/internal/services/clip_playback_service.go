@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// ErrInvalidPlaybackEvent is returned when a reported playback event has an unknown source or reason
+var ErrInvalidPlaybackEvent = errors.New("invalid playback event")
+
+// clipPlaybackEventsTotal counts playback outcomes reported by players. Per-clip
+// failure rates live in clip_embed_health to keep label cardinality bounded.
+var clipPlaybackEventsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "clip_playback_events_total",
+		Help: "Total number of clip playback outcomes reported by players",
+	},
+	[]string{"source", "outcome", "reason"},
+)
+
+func init() {
+	prometheus.MustRegister(clipPlaybackEventsTotal)
+}
+
+// ClipEmbedHealthRepositoryInterface defines the repository methods used by ClipPlaybackService
+type ClipEmbedHealthRepositoryInterface interface {
+	RecordEmbedLoad(ctx context.Context, clipID uuid.UUID) error
+	RecordEmbedFailure(ctx context.Context, clipID uuid.UUID, reason string) error
+	GetByClipID(ctx context.Context, clipID uuid.UUID) (*models.ClipEmbedHealth, error)
+}
+
+// ClipPlaybackService builds the playback fallback chain for clips and tracks
+// how often their Twitch embeds fail, so broken embeds get mirrored
+type ClipPlaybackService struct {
+	healthRepo ClipEmbedHealthRepositoryInterface
+	config     *config.MirrorConfig
+}
+
+// NewClipPlaybackService creates a new ClipPlaybackService
+func NewClipPlaybackService(healthRepo ClipEmbedHealthRepositoryInterface, cfg *config.MirrorConfig) *ClipPlaybackService {
+	return &ClipPlaybackService{
+		healthRepo: healthRepo,
+		config:     cfg,
+	}
+}
+
+// GetPlaybackConfig returns the sources a player should try for a clip, in order:
+// the Twitch embed, the self-hosted video once processed, and finally the
+// thumbnail linking out to Twitch. The video moves ahead of the embed when the
+// embed fails too often.
+func (s *ClipPlaybackService) GetPlaybackConfig(ctx context.Context, clip *models.Clip) (*models.ClipPlaybackConfig, error) {
+	playback := &models.ClipPlaybackConfig{ClipID: clip.ID}
+
+	var embed, video *models.ClipPlaybackSource
+	isStreamClip := clip.StreamSource != nil && *clip.StreamSource == "stream"
+	if clip.EmbedURL != "" && !isStreamClip {
+		embed = &models.ClipPlaybackSource{Type: models.PlaybackSourceTwitchEmbed, URL: clip.EmbedURL}
+	}
+	if clip.VideoURL != nil && *clip.VideoURL != "" && (clip.Status == nil || *clip.Status == "ready") {
+		video = &models.ClipPlaybackSource{
+			Type:         models.PlaybackSourceVideo,
+			URL:          *clip.VideoURL,
+			ThumbnailURL: clip.ThumbnailURL,
+		}
+	}
+
+	if embed != nil {
+		health, err := s.healthRepo.GetByClipID(ctx, clip.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get clip embed health: %w", err)
+		}
+		playback.EmbedDegraded = s.isDegraded(health)
+	}
+
+	if playback.EmbedDegraded && video != nil {
+		playback.Sources = append(playback.Sources, *video, *embed)
+	} else {
+		if embed != nil {
+			playback.Sources = append(playback.Sources, *embed)
+		}
+		if video != nil {
+			playback.Sources = append(playback.Sources, *video)
+		}
+	}
+
+	playback.Sources = append(playback.Sources, models.ClipPlaybackSource{
+		Type:         models.PlaybackSourceThumbnail,
+		URL:          clip.TwitchClipURL,
+		ThumbnailURL: clip.ThumbnailURL,
+	})
+
+	return playback, nil
+}
+
+// ReportEvent records a playback outcome reported by a player. Only Twitch embed
+// outcomes feed the per-clip failure rate.
+func (s *ClipPlaybackService) ReportEvent(ctx context.Context, clipID uuid.UUID, req *models.ReportPlaybackEventRequest) error {
+	switch req.Source {
+	case models.PlaybackSourceTwitchEmbed, models.PlaybackSourceVideo, models.PlaybackSourceThumbnail:
+	default:
+		return fmt.Errorf("%w: unknown source %q", ErrInvalidPlaybackEvent, req.Source)
+	}
+
+	reason := ""
+	if req.Outcome == models.PlaybackOutcomeFailed {
+		reason = req.Reason
+		if reason == "" {
+			reason = models.EmbedFailureUnknown
+		}
+		if !slices.Contains(models.ValidEmbedFailureReasons, reason) {
+			return fmt.Errorf("%w: unknown reason %q", ErrInvalidPlaybackEvent, reason)
+		}
+	}
+
+	clipPlaybackEventsTotal.WithLabelValues(req.Source, req.Outcome, reason).Inc()
+
+	if req.Source != models.PlaybackSourceTwitchEmbed {
+		return nil
+	}
+
+	if req.Outcome == models.PlaybackOutcomeFailed {
+		return s.healthRepo.RecordEmbedFailure(ctx, clipID, reason)
+	}
+	return s.healthRepo.RecordEmbedLoad(ctx, clipID)
+}
+
+// isDegraded reports whether enough embed attempts failed to prefer other sources
+func (s *ClipPlaybackService) isDegraded(health *models.ClipEmbedHealth) bool {
+	if health == nil || s.config == nil || s.config.EmbedFailureRate <= 0 {
+		return false
+	}
+	if health.EmbedLoads+health.EmbedFailures < int64(s.config.EmbedFailureMinEvents) {
+		return false
+	}
+	return health.FailureRate() >= s.config.EmbedFailureRate
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// MockClipEmbedHealthRepository is a mock implementation of ClipEmbedHealthRepositoryInterface
+type MockClipEmbedHealthRepository struct {
+	mock.Mock
+}
+
+func (m *MockClipEmbedHealthRepository) RecordEmbedLoad(ctx context.Context, clipID uuid.UUID) error {
+	args := m.Called(ctx, clipID)
+	return args.Error(0)
+}
+
+func (m *MockClipEmbedHealthRepository) RecordEmbedFailure(ctx context.Context, clipID uuid.UUID, reason string) error {
+	args := m.Called(ctx, clipID, reason)
+	return args.Error(0)
+}
+
+func (m *MockClipEmbedHealthRepository) GetByClipID(ctx context.Context, clipID uuid.UUID) (*models.ClipEmbedHealth, error) {
+	args := m.Called(ctx, clipID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ClipEmbedHealth), args.Error(1)
+}
+
+func newPlaybackTestClip() *models.Clip {
+	videoURL := "https://cdn.example.com/clips/abc.mp4"
+	status := "ready"
+	return &models.Clip{
+		ID:            uuid.New(),
+		TwitchClipURL: "https://clips.twitch.tv/abc",
+		EmbedURL:      "https://clips.twitch.tv/embed?clip=abc",
+		VideoURL:      &videoURL,
+		Status:        &status,
+	}
+}
+
+func sourceTypes(playback *models.ClipPlaybackConfig) []string {
+	types := make([]string, 0, len(playback.Sources))
+	for _, source := range playback.Sources {
+		types = append(types, source.Type)
+	}
+	return types
+}
+
+func TestClipPlaybackService_GetPlaybackConfig(t *testing.T) {
+	cfg := &config.MirrorConfig{EmbedFailureMinEvents: 4, EmbedFailureRate: 0.5}
+	ctx := context.Background()
+
+	t.Run("embed first when healthy", func(t *testing.T) {
+		repo := new(MockClipEmbedHealthRepository)
+		clip := newPlaybackTestClip()
+		repo.On("GetByClipID", ctx, clip.ID).Return(nil, nil)
+		svc := NewClipPlaybackService(repo, cfg)
+
+		playback, err := svc.GetPlaybackConfig(ctx, clip)
+		require.NoError(t, err)
+
+		assert.False(t, playback.EmbedDegraded)
+		assert.Equal(t, []string{models.PlaybackSourceTwitchEmbed, models.PlaybackSourceVideo, models.PlaybackSourceThumbnail}, sourceTypes(playback))
+		assert.Equal(t, "https://clips.twitch.tv/abc", playback.Sources[2].URL)
+	})
+
+	t.Run("unprocessed video is skipped", func(t *testing.T) {
+		repo := new(MockClipEmbedHealthRepository)
+		clip := newPlaybackTestClip()
+		processing := "processing"
+		clip.Status = &processing
+		repo.On("GetByClipID", ctx, clip.ID).Return(nil, nil)
+		svc := NewClipPlaybackService(repo, cfg)
+
+		playback, err := svc.GetPlaybackConfig(ctx, clip)
+		require.NoError(t, err)
+		assert.Equal(t, []string{models.PlaybackSourceTwitchEmbed, models.PlaybackSourceThumbnail}, sourceTypes(playback))
+	})
+
+	t.Run("video first when embed fails too often", func(t *testing.T) {
+		repo := new(MockClipEmbedHealthRepository)
+		clip := newPlaybackTestClip()
+		repo.On("GetByClipID", ctx, clip.ID).Return(&models.ClipEmbedHealth{ClipID: clip.ID, EmbedLoads: 1, EmbedFailures: 3}, nil)
+		svc := NewClipPlaybackService(repo, cfg)
+
+		playback, err := svc.GetPlaybackConfig(ctx, clip)
+		require.NoError(t, err)
+		assert.True(t, playback.EmbedDegraded)
+		assert.Equal(t, []string{models.PlaybackSourceVideo, models.PlaybackSourceTwitchEmbed, models.PlaybackSourceThumbnail}, sourceTypes(playback))
+	})
+
+	t.Run("too few events do not degrade the embed", func(t *testing.T) {
+		repo := new(MockClipEmbedHealthRepository)
+		clip := newPlaybackTestClip()
+		repo.On("GetByClipID", ctx, clip.ID).Return(&models.ClipEmbedHealth{ClipID: clip.ID, EmbedFailures: 3}, nil)
+		svc := NewClipPlaybackService(repo, cfg)
+
+		playback, err := svc.GetPlaybackConfig(ctx, clip)
+		require.NoError(t, err)
+		assert.False(t, playback.EmbedDegraded)
+	})
+}
+
+func TestClipPlaybackService_ReportEvent(t *testing.T) {
+	repo := new(MockClipEmbedHealthRepository)
+	svc := NewClipPlaybackService(repo, &config.MirrorConfig{})
+	ctx := context.Background()
+	clipID := uuid.New()
+
+	repo.On("RecordEmbedLoad", ctx, clipID).Return(nil).Once()
+	// Failures without a reason are recorded as unknown
+	repo.On("RecordEmbedFailure", ctx, clipID, models.EmbedFailureUnknown).Return(nil).Once()
+
+	require.NoError(t, svc.ReportEvent(ctx, clipID, &models.ReportPlaybackEventRequest{
+		Source: models.PlaybackSourceTwitchEmbed, Outcome: models.PlaybackOutcomeLoaded,
+	}))
+	require.NoError(t, svc.ReportEvent(ctx, clipID, &models.ReportPlaybackEventRequest{
+		Source: models.PlaybackSourceTwitchEmbed, Outcome: models.PlaybackOutcomeFailed,
+	}))
+	// Outcomes of other sources are counted in metrics only
+	require.NoError(t, svc.ReportEvent(ctx, clipID, &models.ReportPlaybackEventRequest{
+		Source: models.PlaybackSourceVideo, Outcome: models.PlaybackOutcomeFailed, Reason: models.EmbedFailureUnavailable,
+	}))
+
+	repo.AssertExpectations(t)
+
+	err := svc.ReportEvent(ctx, clipID, &models.ReportPlaybackEventRequest{Source: "youtube", Outcome: models.PlaybackOutcomeLoaded})
+	assert.ErrorIs(t, err, ErrInvalidPlaybackEvent)
+
+	err = svc.ReportEvent(ctx, clipID, &models.ReportPlaybackEventRequest{
+		Source: models.PlaybackSourceTwitchEmbed, Outcome: models.PlaybackOutcomeFailed, Reason: "bad_reason",
+	})
+	assert.ErrorIs(t, err, ErrInvalidPlaybackEvent)
+
+	repo.AssertNumberOfCalls(t, "RecordEmbedLoad", 1)
+	repo.AssertNumberOfCalls(t, "RecordEmbedFailure", 1)
+}
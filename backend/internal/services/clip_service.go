@@ -5,11 +5,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/pagination"
 	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/pkg/metrics"
 	redispkg "github.com/subculture-collective/clipper/pkg/redis"
 )
 
@@ -27,6 +30,9 @@ type ClipService struct {
 	redisClient         *redispkg.Client
 	auditLogRepo        *repository.AuditLogRepository
 	notificationService *NotificationService
+	accessibilityRepo   *repository.ClipAccessibilityRepository
+	invalidationBus     *CacheInvalidationBus
+	creatorClaimRepo    *repository.CreatorClaimRepository
 }
 
 // NewClipService creates a new ClipService
@@ -136,6 +142,13 @@ func (s *ClipService) GetClip(ctx context.Context, clipID uuid.UUID, userID *uui
 		}
 	}
 
+	// Get accessibility metadata
+	if s.accessibilityRepo != nil {
+		if accessibility, err := s.accessibilityRepo.GetByClipID(ctx, clipID); err == nil {
+			clipWithData.Clip.Accessibility = accessibility
+		}
+	}
+
 	// Increment view count and check for threshold notifications (async, don't block on errors)
 	go func() {
 		timeoutCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -201,6 +214,13 @@ func (s *ClipService) GetClipByTwitchID(ctx context.Context, twitchClipID string
 		}
 	}
 
+	// Get accessibility metadata
+	if s.accessibilityRepo != nil {
+		if accessibility, err := s.accessibilityRepo.GetByClipID(ctx, clip.ID); err == nil {
+			clipWithData.Clip.Accessibility = accessibility
+		}
+	}
+
 	// Increment view count and check for threshold notifications (async, don't block on errors)
 	go func() {
 		timeoutCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -267,6 +287,24 @@ func (s *ClipService) ListClips(ctx context.Context, filters repository.ClipFilt
 		}
 	}
 
+	return s.withUserData(ctx, clips, userID), total, nil
+}
+
+// ListClipsCursor retrieves a page of clips with filters, continuing after
+// filters.Cursor when set. Cursor pages are not cached: each cursor is
+// usually fetched once.
+func (s *ClipService) ListClipsCursor(ctx context.Context, filters repository.ClipFilters, limit int, userID *uuid.UUID) ([]ClipWithUserData, pagination.PageInfo, error) {
+	clips, page, err := s.clipRepo.ListWithFiltersCursor(ctx, filters, limit)
+	if err != nil {
+		return nil, pagination.PageInfo{}, err
+	}
+	return s.withUserData(ctx, clips, userID), page, nil
+}
+
+// withUserData enriches listed clips with submitter info, vote counts,
+// accessibility metadata and, for an authenticated user, their vote, favorite
+// and watch progress
+func (s *ClipService) withUserData(ctx context.Context, clips []models.Clip, userID *uuid.UUID) []ClipWithUserData {
 	// Collect unique submitter IDs for batch fetching
 	submitterIDSet := make(map[uuid.UUID]struct{})
 	for _, clip := range clips {
@@ -351,7 +389,9 @@ func (s *ClipService) ListClips(ctx context.Context, filters repository.ClipFilt
 		}
 	}
 
-	return clipsWithData, total, nil
+	s.attachAccessibility(ctx, clipsWithData)
+
+	return clipsWithData
 }
 
 // ListScrapedClips retrieves discovery clips (not yet claimed by users) with filters and pagination.
@@ -462,7 +502,7 @@ func (s *ClipService) VoteOnClip(ctx context.Context, userID, clipID uuid.UUID,
 	}
 
 	// Check if clip exists
-	_, err := s.clipRepo.GetByID(ctx, clipID)
+	votedClip, err := s.clipRepo.GetByID(ctx, clipID)
 	if err != nil {
 		return err
 	}
@@ -475,6 +515,7 @@ func (s *ClipService) VoteOnClip(ctx context.Context, userID, clipID uuid.UUID,
 			if err := s.voteRepo.DeleteVote(ctx, userID, clipID); err != nil {
 				return err
 			}
+			metrics.RecordVote("clip", 0)
 		}
 		return nil
 	}
@@ -492,6 +533,7 @@ func (s *ClipService) VoteOnClip(ctx context.Context, userID, clipID uuid.UUID,
 	if err != nil {
 		return err
 	}
+	metrics.RecordVote("clip", voteType)
 
 	// Only check for vote thresholds if the score increased
 	if scoreWillIncrease {
@@ -531,7 +573,7 @@ func (s *ClipService) VoteOnClip(ctx context.Context, userID, clipID uuid.UUID,
 	}()
 
 	// Invalidate cache
-	s.invalidateCache(ctx)
+	s.publishInvalidation(ctx, CacheTopicClipVoted, votedClip)
 
 	return nil
 }
@@ -573,26 +615,32 @@ func (s *ClipService) UpdateClip(ctx context.Context, clipID uuid.UUID, updates
 		}
 	}
 
+	clip := s.clipForInvalidation(ctx, clipID)
 	err := s.clipRepo.Update(ctx, clipID, updates)
 	if err != nil {
 		return err
 	}
 
 	// Invalidate cache
-	s.invalidateCache(ctx)
+	topic := CacheTopicClipUpdated
+	if removed, ok := updates["is_removed"].(bool); ok && removed {
+		topic = CacheTopicClipRemoved
+	}
+	s.publishInvalidation(ctx, topic, clip)
 
 	return nil
 }
 
 // DeleteClip soft deletes a clip (admin only)
 func (s *ClipService) DeleteClip(ctx context.Context, clipID uuid.UUID, reason string) error {
+	clip := s.clipForInvalidation(ctx, clipID)
 	err := s.clipRepo.SoftDelete(ctx, clipID, reason)
 	if err != nil {
 		return err
 	}
 
 	// Invalidate cache
-	s.invalidateCache(ctx)
+	s.publishInvalidation(ctx, CacheTopicClipRemoved, clip)
 
 	return nil
 }
@@ -623,6 +671,18 @@ func (s *ClipService) buildCacheKey(filters repository.ClipFilters, page, limit
 	if filters.Language != nil {
 		key += fmt.Sprintf(":language:%s", *filters.Language)
 	}
+	if filters.PatchID != nil {
+		key += fmt.Sprintf(":patch:%s", *filters.PatchID)
+	}
+	if filters.CurrentPatch {
+		key += ":current_patch"
+	}
+	if filters.CaptionedOnly {
+		key += ":captioned"
+	}
+	if filters.HidePhotosensitive {
+		key += ":hide_photosensitive"
+	}
 
 	key += fmt.Sprintf(":top10k:%t", filters.Top10kStreamers)
 	key += fmt.Sprintf(":show_hidden:%t", filters.ShowHidden)
@@ -652,6 +712,77 @@ func (s *ClipService) invalidateCache(ctx context.Context) {
 	_ = s.redisClient.DeletePattern(ctx, pattern)
 }
 
+// clipScoreSorts are the clip list sorts ordered by vote score; "" is the default sort
+var clipScoreSorts = []string{"", "hot", "top", "rising", "trending", "popular"}
+
+// SetCacheInvalidationBus makes clip changes publish invalidation events
+// instead of clearing the clip list caches directly
+func (s *ClipService) SetCacheInvalidationBus(bus *CacheInvalidationBus) {
+	s.invalidationBus = bus
+}
+
+// SetCreatorClaimRepository attributes clips to users holding a verified
+// claim to their Twitch creator, besides users logged in with that account
+func (s *ClipService) SetCreatorClaimRepository(repo *repository.CreatorClaimRepository) {
+	s.creatorClaimRepo = repo
+}
+
+// isClipCreator reports whether the user is credited as the Twitch creator,
+// by login or by a verified creator claim
+func (s *ClipService) isClipCreator(ctx context.Context, user *models.User, creatorTwitchID string) bool {
+	if user.TwitchID != nil && *user.TwitchID == creatorTwitchID {
+		return true
+	}
+	if s.creatorClaimRepo == nil {
+		return false
+	}
+	claimed, err := s.creatorClaimRepo.HasVerifiedClaim(ctx, user.ID, creatorTwitchID)
+	if err != nil {
+		log.Printf("Failed to check creator claim: %v", err)
+		return false
+	}
+	return claimed
+}
+
+// HandleCacheInvalidation clears the clip list caches a change makes stale.
+// Votes only reorder the lists sorted by score.
+func (s *ClipService) HandleCacheInvalidation(ctx context.Context, event CacheInvalidationEvent) error {
+	if event.Topic != CacheTopicClipVoted {
+		return s.redisClient.DeletePattern(ctx, "clips:list:*")
+	}
+	for _, sort := range clipScoreSorts {
+		if err := s.redisClient.DeletePattern(ctx, "clips:list:"+sort+":*"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clipForInvalidation loads a clip before it changes, so its invalidation
+// event names the game and creator lists it was in
+func (s *ClipService) clipForInvalidation(ctx context.Context, clipID uuid.UUID) *models.Clip {
+	if s.invalidationBus == nil {
+		return &models.Clip{ID: clipID}
+	}
+	clip, err := s.clipRepo.GetByID(ctx, clipID)
+	if err != nil {
+		return &models.Clip{ID: clipID}
+	}
+	return clip
+}
+
+// publishInvalidation tells the services caching clip that it changed.
+// Without a bus only the clip list caches are cleared.
+func (s *ClipService) publishInvalidation(ctx context.Context, topic CacheInvalidationTopic, clip *models.Clip) {
+	if s.invalidationBus == nil {
+		s.invalidateCache(ctx)
+		return
+	}
+	if err := s.invalidationBus.Publish(ctx, NewClipInvalidationEvent(topic, clip)); err != nil {
+		log.Printf("Failed to publish clip cache invalidation: %v", err)
+	}
+}
+
 // CanManageClip checks if a user can manage a specific clip
 func (s *ClipService) CanManageClip(ctx context.Context, userID uuid.UUID, clipID uuid.UUID) (bool, error) {
 	// Get user to check role
@@ -671,8 +802,8 @@ func (s *ClipService) CanManageClip(ctx context.Context, userID uuid.UUID, clipI
 		return false, fmt.Errorf("failed to get clip: %w", err)
 	}
 
-	// Check if user is the creator (by matching Twitch ID)
-	if clip.CreatorID != nil && user.TwitchID != nil && *user.TwitchID == *clip.CreatorID {
+	// Check if user is the creator (by matching Twitch ID or a verified claim)
+	if clip.CreatorID != nil && s.isClipCreator(ctx, user, *clip.CreatorID) {
 		return true, nil
 	}
 
@@ -691,6 +822,7 @@ func (s *ClipService) UpdateClipMetadata(ctx context.Context, userID uuid.UUID,
 	}
 
 	// Update metadata
+	clip := s.clipForInvalidation(ctx, clipID)
 	err = s.clipRepo.UpdateMetadata(ctx, clipID, title)
 	if err != nil {
 		return err
@@ -714,7 +846,7 @@ func (s *ClipService) UpdateClipMetadata(ctx context.Context, userID uuid.UUID,
 	}
 
 	// Invalidate cache
-	s.invalidateCache(ctx)
+	s.publishInvalidation(ctx, CacheTopicClipUpdated, clip)
 
 	return nil
 }
@@ -731,6 +863,7 @@ func (s *ClipService) UpdateClipVisibility(ctx context.Context, userID uuid.UUID
 	}
 
 	// Update visibility
+	clip := s.clipForInvalidation(ctx, clipID)
 	err = s.clipRepo.UpdateVisibility(ctx, clipID, isHidden)
 	if err != nil {
 		return err
@@ -752,7 +885,7 @@ func (s *ClipService) UpdateClipVisibility(ctx context.Context, userID uuid.UUID
 	_ = s.auditLogRepo.Create(ctx, auditLog)
 
 	// Invalidate cache
-	s.invalidateCache(ctx)
+	s.publishInvalidation(ctx, CacheTopicClipUpdated, clip)
 
 	return nil
 }
@@ -767,7 +900,7 @@ func (s *ClipService) ListCreatorClips(ctx context.Context, creatorTwitchID stri
 		user, err := s.userRepo.GetByID(ctx, *userID)
 		if err == nil {
 			// Show hidden clips if user is the creator or an admin/moderator
-			if (user.TwitchID != nil && *user.TwitchID == creatorTwitchID) || user.Role == "admin" || user.Role == "moderator" {
+			if user.Role == "admin" || user.Role == "moderator" || s.isClipCreator(ctx, user, creatorTwitchID) {
 				showHidden = true
 			}
 		}
@@ -813,6 +946,8 @@ func (s *ClipService) ListCreatorClips(ctx context.Context, creatorTwitchID stri
 		}
 	}
 
+	s.attachAccessibility(ctx, clipsWithData)
+
 	return clipsWithData, total, nil
 }
 
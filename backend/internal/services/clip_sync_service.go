@@ -72,6 +72,8 @@ type ClipSyncService struct {
 	stateStore   TrendingStateStore
 	maxPages     int
 	defaultLang  string
+	titles       ClipTitleNormalizer
+	ingestion    ClipIngestionRouter
 }
 
 // NewClipSyncService creates a new ClipSyncService
@@ -92,6 +94,31 @@ func NewClipSyncService(twitchClient *twitch.Client, clipRepo *repository.ClipRe
 	}
 }
 
+// SetTitleNormalizer sets the normalizer that gives imported clips a display title
+func (s *ClipSyncService) SetTitleNormalizer(titles ClipTitleNormalizer) {
+	s.titles = titles
+}
+
+// applyDisplayTitle gives a clip about to be saved its display title
+func (s *ClipSyncService) applyDisplayTitle(ctx context.Context, clip *models.Clip) {
+	if s.titles != nil {
+		s.titles.ApplyDisplayTitle(ctx, clip)
+	}
+}
+
+// SetIngestionRouter sets the router that adds synced clips matching
+// ingestion rules to lists, feeds and communities
+func (s *ClipSyncService) SetIngestionRouter(ingestion ClipIngestionRouter) {
+	s.ingestion = ingestion
+}
+
+// routeClip applies ingestion rules to a synced clip
+func (s *ClipSyncService) routeClip(ctx context.Context, clip *models.Clip, stats *SyncStats) {
+	if s.ingestion != nil {
+		stats.ClipsRouted += s.ingestion.RouteClip(ctx, clip)
+	}
+}
+
 // SetDefaultLanguage overrides the service-level language filter (use "all" or "" to disable)
 func (s *ClipSyncService) SetDefaultLanguage(lang string) {
 	s.defaultLang = normalizeLanguageFilter(lang)
@@ -103,6 +130,7 @@ type SyncStats struct {
 	ClipsCreated int
 	ClipsUpdated int
 	ClipsSkipped int
+	ClipsRouted  int
 	Errors       []string
 	StartTime    time.Time
 	EndTime      time.Time
@@ -215,6 +243,7 @@ func (s *ClipSyncService) SyncClipsByGame(ctx context.Context, gameID string, ho
 		"created":     stats.ClipsCreated,
 		"updated":     stats.ClipsUpdated,
 		"skipped":     stats.ClipsSkipped,
+		"routed":      stats.ClipsRouted,
 		"errors":      len(stats.Errors),
 		"duration":    stats.EndTime.Sub(stats.StartTime),
 		"next_cursor": nextCursor,
@@ -304,6 +333,7 @@ func (s *ClipSyncService) SyncClipsByBroadcaster(ctx context.Context, broadcaste
 		"created":  stats.ClipsCreated,
 		"updated":  stats.ClipsUpdated,
 		"skipped":  stats.ClipsSkipped,
+		"routed":   stats.ClipsRouted,
 		"errors":   len(stats.Errors),
 		"duration": stats.EndTime.Sub(stats.StartTime),
 	})
@@ -370,6 +400,7 @@ func (s *ClipSyncService) SyncTrendingClips(ctx context.Context, hours int, opts
 		stats.ClipsCreated += gameStats.ClipsCreated
 		stats.ClipsUpdated += gameStats.ClipsUpdated
 		stats.ClipsSkipped += gameStats.ClipsSkipped
+		stats.ClipsRouted += gameStats.ClipsRouted
 		stats.Errors = append(stats.Errors, gameStats.Errors...)
 
 		// For admin-triggered runs we force page 1 and leave stored cursors untouched
@@ -415,6 +446,7 @@ func (s *ClipSyncService) SyncTrendingClips(ctx context.Context, hours int, opts
 		"created":  stats.ClipsCreated,
 		"updated":  stats.ClipsUpdated,
 		"skipped":  stats.ClipsSkipped,
+		"routed":   stats.ClipsRouted,
 		"errors":   len(stats.Errors),
 		"duration": stats.EndTime.Sub(stats.StartTime),
 	})
@@ -581,6 +613,7 @@ func (s *ClipSyncService) FetchClipByURL(ctx context.Context, clipURLOrID string
 
 	// Transform and save
 	clip := transformTwitchClip(&twitchClip)
+	s.applyDisplayTitle(ctx, clip)
 	if err := s.clipRepo.Create(ctx, clip); err != nil {
 		return nil, fmt.Errorf("failed to save clip: %w", err)
 	}
@@ -608,6 +641,14 @@ func (s *ClipSyncService) processClip(ctx context.Context, twitchClip *twitch.Cl
 			return fmt.Errorf("failed to update view count: %w", err)
 		}
 		stats.ClipsUpdated++
+
+		// Views may now meet a rule's threshold
+		if s.ingestion != nil {
+			if existing, err := s.clipRepo.GetByTwitchClipID(ctx, twitchClip.ID); err == nil {
+				existing.ViewCount = twitchClip.ViewCount
+				s.routeClip(ctx, existing, stats)
+			}
+		}
 		return nil
 	}
 
@@ -623,6 +664,7 @@ func (s *ClipSyncService) processClip(ctx context.Context, twitchClip *twitch.Cl
 	}
 
 	// Save to database
+	s.applyDisplayTitle(ctx, clip)
 	if err := s.clipRepo.Create(ctx, clip); err != nil {
 		return fmt.Errorf("failed to create clip: %w", err)
 	}
@@ -634,6 +676,7 @@ func (s *ClipSyncService) processClip(ctx context.Context, twitchClip *twitch.Cl
 	}
 
 	stats.ClipsCreated++
+	s.routeClip(ctx, clip, stats)
 	return nil
 }
 
@@ -665,6 +708,8 @@ func (s *ClipSyncService) processClipAsPosted(ctx context.Context, twitchClip *t
 		}
 
 		stats.ClipsUpdated++
+		existing.ViewCount = twitchClip.ViewCount
+		s.routeClip(ctx, existing, stats)
 		return nil
 	}
 
@@ -682,6 +727,7 @@ func (s *ClipSyncService) processClipAsPosted(ctx context.Context, twitchClip *t
 		utils.Warn("Failed to ensure unclaimed user for broadcaster", map[string]interface{}{"broadcaster": twitchClip.BroadcasterName, "error": err})
 	}
 
+	s.applyDisplayTitle(ctx, clip)
 	if err := s.clipRepo.Create(ctx, clip); err != nil {
 		return fmt.Errorf("failed to create clip: %w", err)
 	}
@@ -693,6 +739,7 @@ func (s *ClipSyncService) processClipAsPosted(ctx context.Context, twitchClip *t
 	}
 
 	stats.ClipsCreated++
+	s.routeClip(ctx, clip, stats)
 	return nil
 }
 
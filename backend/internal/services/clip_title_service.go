@@ -0,0 +1,398 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const (
+	// clipTitleReprocessBatchSize is how many clips a reprocessing pass reads at a time
+	clipTitleReprocessBatchSize = 500
+	// shoutingMinUpper is how many capital letters a title needs before it can count as shouting
+	shoutingMinUpper = 8
+	// maxRepeatedLetters caps stretched words ("NOOOOOO" becomes "NOOO")
+	maxRepeatedLetters = 3
+	// maxRepeatedWords caps a word repeated back to back ("KEKW KEKW KEKW KEKW")
+	maxRepeatedWords = 2
+	// minTitleAlphanumerics is how many letters or digits a cleaned title needs to be used
+	minTitleAlphanumerics = 3
+)
+
+// ErrNotTitleBroadcaster is returned when a user without a verified Twitch channel manages title normalization
+var ErrNotTitleBroadcaster = errors.New("only verified broadcasters can manage title normalization")
+
+// shoutingAcronyms stay capitalized when a shouting title is sentence-cased
+var shoutingAcronyms = map[string]bool{
+	"AI": true, "AFK": true, "BRB": true, "CEO": true, "DPS": true, "EU": true, "FPS": true,
+	"GG": true, "GGS": true, "GTA": true, "HP": true, "IRL": true, "KO": true, "LOL": true,
+	"MMR": true, "MVP": true, "NA": true, "NPC": true, "OMG": true, "OP": true, "PC": true,
+	"PVE": true, "PVP": true, "RNG": true, "RPG": true, "TV": true, "UK": true, "USA": true,
+	"VOD": true, "WTF": true, "WW": true, "XP": true,
+}
+
+// ClipTitleRepositoryInterface defines the repository methods used by ClipTitleService
+type ClipTitleRepositoryInterface interface {
+	GetPreference(ctx context.Context, broadcasterID string) (*models.BroadcasterTitlePreference, error)
+	UpsertPreference(ctx context.Context, pref *models.BroadcasterTitlePreference) error
+	ListOptedOutBroadcasters(ctx context.Context) ([]string, error)
+	ListClipTitles(ctx context.Context, broadcasterID string, afterID uuid.UUID, limit int) ([]models.ClipTitle, error)
+	SetDisplayTitle(ctx context.Context, clipID uuid.UUID, displayTitle *string) error
+}
+
+// ClipTitleUserRepositoryInterface defines the user lookups used by ClipTitleService
+type ClipTitleUserRepositoryInterface interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+}
+
+// ClipTitleNormalizer sets the display title of a clip about to be saved.
+// Implemented by ClipTitleService.
+type ClipTitleNormalizer interface {
+	ApplyDisplayTitle(ctx context.Context, clip *models.Clip)
+}
+
+// ClipTitleService derives clean display titles from Twitch clip titles. The
+// original title is never changed; search and SEO use the display title when
+// a clip has one. Broadcasters can opt their channel out.
+type ClipTitleService struct {
+	repo     ClipTitleRepositoryInterface
+	userRepo ClipTitleUserRepositoryInterface
+	enabled  bool
+}
+
+// NewClipTitleService creates a new ClipTitleService. When enabled is false
+// new clips get no display title and reprocessing clears existing ones.
+func NewClipTitleService(repo ClipTitleRepositoryInterface, userRepo ClipTitleUserRepositoryInterface, enabled bool) *ClipTitleService {
+	return &ClipTitleService{
+		repo:     repo,
+		userRepo: userRepo,
+		enabled:  enabled,
+	}
+}
+
+// Enabled reports whether titles are normalized
+func (s *ClipTitleService) Enabled() bool {
+	return s.enabled
+}
+
+// ApplyDisplayTitle sets the display title of a clip about to be saved. A
+// failed preference lookup leaves the clip without one rather than failing
+// the import.
+func (s *ClipTitleService) ApplyDisplayTitle(ctx context.Context, clip *models.Clip) {
+	clip.DisplayTitle = nil
+	if !s.enabled {
+		return
+	}
+
+	if clip.BroadcasterID != nil && *clip.BroadcasterID != "" {
+		pref, err := s.repo.GetPreference(ctx, *clip.BroadcasterID)
+		if err != nil {
+			utils.Warn("Failed to get broadcaster title preference", map[string]interface{}{
+				"broadcaster_id": *clip.BroadcasterID,
+				"error":          err.Error(),
+			})
+			return
+		}
+		if pref != nil && !pref.NormalizeTitles {
+			return
+		}
+	}
+
+	clip.DisplayTitle = DisplayTitleFor(clip.Title)
+}
+
+// Reprocess recomputes the display titles of existing clips, or only those of
+// one broadcaster when broadcasterID is set. With dryRun nothing is written.
+func (s *ClipTitleService) Reprocess(ctx context.Context, broadcasterID string, dryRun bool, batchSize int) (*models.ClipTitleReprocessResult, error) {
+	if batchSize <= 0 {
+		batchSize = clipTitleReprocessBatchSize
+	}
+
+	optedOut := map[string]bool{}
+	if s.enabled {
+		broadcasterIDs, err := s.repo.ListOptedOutBroadcasters(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range broadcasterIDs {
+			optedOut[id] = true
+		}
+	}
+
+	result := &models.ClipTitleReprocessResult{}
+	afterID := uuid.Nil
+	for {
+		clips, err := s.repo.ListClipTitles(ctx, broadcasterID, afterID, batchSize)
+		if err != nil {
+			return result, err
+		}
+
+		for i := range clips {
+			clip := &clips[i]
+			result.Scanned++
+
+			var want *string
+			if s.enabled && (clip.BroadcasterID == nil || !optedOut[*clip.BroadcasterID]) {
+				want = DisplayTitleFor(clip.Title)
+			}
+			if sameDisplayTitle(want, clip.DisplayTitle) {
+				continue
+			}
+
+			if !dryRun {
+				if err := s.repo.SetDisplayTitle(ctx, clip.ID, want); err != nil {
+					return result, err
+				}
+			}
+			if want == nil {
+				result.Cleared++
+			} else {
+				result.Updated++
+			}
+		}
+
+		if len(clips) < batchSize {
+			return result, nil
+		}
+		afterID = clips[len(clips)-1].ID
+	}
+}
+
+// GetPreference returns the caller's title normalization preference.
+// Channels normalize titles until their broadcaster opts out.
+func (s *ClipTitleService) GetPreference(ctx context.Context, userID uuid.UUID) (*models.BroadcasterTitlePreference, error) {
+	broadcasterID, err := verifiedBroadcasterID(ctx, s.userRepo, userID, ErrNotTitleBroadcaster)
+	if err != nil {
+		return nil, err
+	}
+
+	pref, err := s.repo.GetPreference(ctx, broadcasterID)
+	if err != nil {
+		return nil, err
+	}
+	if pref == nil {
+		pref = &models.BroadcasterTitlePreference{
+			BroadcasterID:   broadcasterID,
+			UserID:          userID,
+			NormalizeTitles: true,
+		}
+	}
+
+	return pref, nil
+}
+
+// UpdatePreference turns title normalization on or off for the caller's
+// channel and reprocesses the channel's existing clips to match
+func (s *ClipTitleService) UpdatePreference(ctx context.Context, userID uuid.UUID, req *models.UpdateBroadcasterTitlePreferenceRequest) (*models.BroadcasterTitlePreference, error) {
+	broadcasterID, err := verifiedBroadcasterID(ctx, s.userRepo, userID, ErrNotTitleBroadcaster)
+	if err != nil {
+		return nil, err
+	}
+
+	pref := &models.BroadcasterTitlePreference{
+		BroadcasterID:   broadcasterID,
+		UserID:          userID,
+		NormalizeTitles: req.NormalizeTitles,
+	}
+	if err := s.repo.UpsertPreference(ctx, pref); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.Reprocess(ctx, broadcasterID, false, 0); err != nil {
+		return nil, fmt.Errorf("failed to reprocess clip titles: %w", err)
+	}
+
+	return pref, nil
+}
+
+// DisplayTitleFor returns the normalized form of a clip title, or nil when
+// normalization changes nothing or leaves too little of the title to use
+func DisplayTitleFor(title string) *string {
+	clean := NormalizeClipTitle(title)
+	if clean == "" || clean == title {
+		return nil
+	}
+	return &clean
+}
+
+// NormalizeClipTitle cleans up a Twitch clip title: emoji and other pictographs
+// are removed, stretched letters, repeated punctuation and back-to-back repeated
+// words are squeezed, and titles written in capitals are sentence-cased.
+// Returns "" when fewer than three letters or digits remain.
+func NormalizeClipTitle(title string) string {
+	words := strings.Fields(stripTitleSymbols(title))
+
+	kept := make([]string, 0, len(words))
+	repeats := 0
+	for _, word := range words {
+		word = squeezeTitleRuns(word)
+		if n := len(kept); n > 0 && strings.EqualFold(kept[n-1], word) {
+			repeats++
+			if repeats >= maxRepeatedWords {
+				continue
+			}
+		} else {
+			repeats = 0
+		}
+		kept = append(kept, word)
+	}
+
+	if isShouting(kept) {
+		sentenceCase(kept)
+	}
+
+	clean := strings.Join(kept, " ")
+	clean = strings.TrimLeft(clean, " -|:~•·,._/!?")
+	clean = strings.TrimRight(clean, " -|:~•·,_/")
+
+	alphanumerics := 0
+	for _, r := range clean {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			alphanumerics++
+		}
+	}
+	if alphanumerics < minTitleAlphanumerics {
+		return ""
+	}
+	return clean
+}
+
+// stripTitleSymbols replaces emoji, pictographs, invisible formatting
+// characters and emoji modifiers with spaces
+func stripTitleSymbols(title string) string {
+	var b strings.Builder
+	b.Grow(len(title))
+	for _, r := range title {
+		switch {
+		case r == '°':
+			b.WriteRune(r)
+		case unicode.In(r, unicode.So, unicode.Cf, unicode.Co, unicode.Cc, unicode.Me),
+			r >= 0xFE00 && r <= 0xFE0F,   // Variation selectors
+			r >= 0x1F3FB && r <= 0x1F3FF: // Skin tone modifiers
+			b.WriteRune(' ')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// squeezeTitleRuns caps runs of the same letter at three, shortens runs of
+// dots to an ellipsis, collapses other repeated punctuation to one mark and
+// mixed runs of ! and ? to one of each
+func squeezeTitleRuns(word string) string {
+	runes := []rune(word)
+	out := make([]rune, 0, len(runes))
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		if r == '!' || r == '?' {
+			j := i
+			seenBang, seenQuestion := false, false
+			for j < len(runes) && (runes[j] == '!' || runes[j] == '?') {
+				if runes[j] == '!' && !seenBang {
+					seenBang = true
+					out = append(out, '!')
+				} else if runes[j] == '?' && !seenQuestion {
+					seenQuestion = true
+					out = append(out, '?')
+				}
+				j++
+			}
+			i = j
+			continue
+		}
+
+		j := i
+		for j < len(runes) && runes[j] == r {
+			j++
+		}
+		run := j - i
+
+		limit := run
+		switch {
+		case r == '.':
+			limit = 3
+		case unicode.IsLetter(r):
+			limit = maxRepeatedLetters
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			limit = 1
+		}
+		if run > limit {
+			run = limit
+		}
+		for k := 0; k < run; k++ {
+			out = append(out, r)
+		}
+		i = j
+	}
+	return string(out)
+}
+
+// isShouting reports whether a title is mostly capital letters
+func isShouting(words []string) bool {
+	upper, lower := 0, 0
+	for _, word := range words {
+		for _, r := range word {
+			switch {
+			case unicode.IsUpper(r):
+				upper++
+			case unicode.IsLower(r):
+				lower++
+			}
+		}
+	}
+	return upper >= shoutingMinUpper && upper*5 >= (upper+lower)*4
+}
+
+// sentenceCase lowercases words written in capitals, except acronyms and
+// words with digits, then capitalizes the first word of each sentence and "I"
+func sentenceCase(words []string) {
+	startOfSentence := true
+	for i, word := range words {
+		core := strings.TrimFunc(word, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+		if core != "" && !hasLower(core) && !shoutingAcronyms[core] && !strings.ContainsFunc(core, unicode.IsDigit) {
+			word = strings.ToLower(word)
+		}
+
+		lowered := strings.ToLower(core)
+		if startOfSentence || lowered == "i" || strings.HasPrefix(lowered, "i'") {
+			word = capitalizeFirstLetter(word)
+		}
+		if core != "" {
+			startOfSentence = false
+		}
+		if strings.ContainsAny(word[len(word)-1:], ".!?") {
+			startOfSentence = true
+		}
+		words[i] = word
+	}
+}
+
+func hasLower(s string) bool {
+	return strings.ContainsFunc(s, unicode.IsLower)
+}
+
+func capitalizeFirstLetter(word string) string {
+	runes := []rune(word)
+	for i, r := range runes {
+		if unicode.IsLetter(r) {
+			runes[i] = unicode.ToUpper(r)
+			break
+		}
+	}
+	return string(runes)
+}
+
+func sameDisplayTitle(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// MockClipTitleRepository is a mock implementation of ClipTitleRepositoryInterface
+type MockClipTitleRepository struct {
+	mock.Mock
+}
+
+func (m *MockClipTitleRepository) GetPreference(ctx context.Context, broadcasterID string) (*models.BroadcasterTitlePreference, error) {
+	args := m.Called(ctx, broadcasterID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BroadcasterTitlePreference), args.Error(1)
+}
+
+func (m *MockClipTitleRepository) UpsertPreference(ctx context.Context, pref *models.BroadcasterTitlePreference) error {
+	args := m.Called(ctx, pref)
+	return args.Error(0)
+}
+
+func (m *MockClipTitleRepository) ListOptedOutBroadcasters(ctx context.Context) ([]string, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockClipTitleRepository) ListClipTitles(ctx context.Context, broadcasterID string, afterID uuid.UUID, limit int) ([]models.ClipTitle, error) {
+	args := m.Called(ctx, broadcasterID, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ClipTitle), args.Error(1)
+}
+
+func (m *MockClipTitleRepository) SetDisplayTitle(ctx context.Context, clipID uuid.UUID, displayTitle *string) error {
+	args := m.Called(ctx, clipID, displayTitle)
+	return args.Error(0)
+}
+
+// expectClipTitlePages serves clips to one Reprocess pass in pages of batchSize
+func expectClipTitlePages(repo *MockClipTitleRepository, broadcasterID string, batchSize int, clips ...models.ClipTitle) {
+	afterID := uuid.Nil
+	for start := 0; ; start += batchSize {
+		end := start + batchSize
+		if end > len(clips) {
+			end = len(clips)
+		}
+		page := clips[start:end]
+		repo.On("ListClipTitles", mock.Anything, broadcasterID, afterID, batchSize).Return(page, nil).Once()
+		if len(page) < batchSize {
+			return
+		}
+		afterID = page[len(page)-1].ID
+	}
+}
+
+func newTestClipTitle(broadcasterID, title string) models.ClipTitle {
+	return models.ClipTitle{ID: uuid.New(), BroadcasterID: &broadcasterID, Title: title}
+}
+
+func TestNormalizeClipTitle(t *testing.T) {
+	tests := []struct {
+		name  string
+		title string
+		want  string
+	}{
+		{"Leaves clean titles alone", "Insane clutch in overtime", "Insane clutch in overtime"},
+		{"Strips emoji", "😂😂 insane clutch 🔥🔥🔥", "insane clutch"},
+		{"Strips emoji sequences", "GG 👍🏽 team ❤️ 👨‍👩‍👧", "GG team"},
+		{"Sentence-cases shouting", "THIS IS THE CRAZIEST PLAY EVER", "This is the craziest play ever"},
+		{"Keeps acronyms and numbers when sentence-casing", "INSANE 1V1 CLUTCH GG WP FPS", "Insane 1V1 clutch GG wp FPS"},
+		{"Capitalizes sentences and I", "WHAT DID I JUST SEE. NO WAY", "What did I just see. No way"},
+		{"Leaves short capitalized titles alone", "POGGERS", "POGGERS"},
+		{"Leaves mostly lowercase titles alone", "Shroud vs NA pros", "Shroud vs NA pros"},
+		{"Squeezes stretched letters", "NOOOOOOOO way", "NOOO way"},
+		{"Squeezes repeated punctuation", "what!!!!!! how?!?!?! wait......", "what! how?! wait..."},
+		{"Squeezes repeated symbols", "best play ====> ever", "best play => ever"},
+		{"Squeezes repeated words", "KEKW KEKW KEKW KEKW so bad", "KEKW KEKW so bad"},
+		{"Trims separators", "| - best moment - |", "best moment"},
+		{"Keeps digits", "100000 viewers reached", "100000 viewers reached"},
+		{"Keeps degrees", "360° no scope", "360° no scope"},
+		{"Gives up on titles that are all emoji", "🔥🔥🔥 😂", ""},
+		{"Gives up on titles with too little text", "!! ok !!", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeClipTitle(tt.title))
+		})
+	}
+}
+
+func TestDisplayTitleFor(t *testing.T) {
+	assert.Nil(t, DisplayTitleFor("Already clean"))
+	assert.Nil(t, DisplayTitleFor("🔥🔥🔥"))
+
+	display := DisplayTitleFor("🔥 CRAZY ACE ON INFERNO 🔥")
+	require.NotNil(t, display)
+	assert.Equal(t, "Crazy ace on inferno", *display)
+}
+
+func TestClipTitleService_ApplyDisplayTitle(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockClipTitleRepository)
+	repo.On("GetPreference", ctx, "123").Return(nil, nil)
+	repo.On("GetPreference", ctx, "optout").Return(&models.BroadcasterTitlePreference{BroadcasterID: "optout", NormalizeTitles: false}, nil)
+	svc := NewClipTitleService(repo, new(MockUserRepository), true)
+
+	broadcasterID := "123"
+	clip := &models.Clip{Title: "😂 LMAO WHAT A PLAY 😂", BroadcasterID: &broadcasterID}
+	svc.ApplyDisplayTitle(ctx, clip)
+	require.NotNil(t, clip.DisplayTitle)
+	assert.Equal(t, "Lmao what a play", *clip.DisplayTitle)
+	assert.Equal(t, "Lmao what a play", clip.PreferredTitle())
+	assert.Equal(t, "😂 LMAO WHAT A PLAY 😂", clip.Title)
+
+	optedOut := "optout"
+	clip = &models.Clip{Title: "😂 LMAO WHAT A PLAY 😂", BroadcasterID: &optedOut}
+	svc.ApplyDisplayTitle(ctx, clip)
+	assert.Nil(t, clip.DisplayTitle)
+	assert.Equal(t, clip.Title, clip.PreferredTitle())
+
+	disabled := NewClipTitleService(repo, new(MockUserRepository), false)
+	clip = &models.Clip{Title: "😂 LMAO WHAT A PLAY 😂", BroadcasterID: &broadcasterID}
+	disabled.ApplyDisplayTitle(ctx, clip)
+	assert.Nil(t, clip.DisplayTitle)
+
+	repo.AssertExpectations(t)
+	repo.AssertNumberOfCalls(t, "GetPreference", 2)
+}
+
+func TestClipTitleService_Reprocess(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockClipTitleRepository)
+	messy := newTestClipTitle("1", "🔥 HUGE PLAY BY THE SUPPORT 🔥")
+	clean := newTestClipTitle("1", "Quiet round")
+	optedOut := newTestClipTitle("2", "🔥 HUGE PLAY BY THE SUPPORT 🔥")
+	optedOut.DisplayTitle = stringPtr("Stale title")
+	repo.On("ListOptedOutBroadcasters", ctx).Return([]string{"2"}, nil)
+
+	svc := NewClipTitleService(repo, new(MockUserRepository), true)
+
+	expectClipTitlePages(repo, "", 2, messy, clean, optedOut)
+	result, err := svc.Reprocess(ctx, "", true, 2)
+	require.NoError(t, err)
+	assert.Equal(t, &models.ClipTitleReprocessResult{Scanned: 3, Updated: 1, Cleared: 1}, result)
+	repo.AssertNotCalled(t, "SetDisplayTitle", mock.Anything, mock.Anything, mock.Anything)
+
+	expectClipTitlePages(repo, "", 2, messy, clean, optedOut)
+	repo.On("SetDisplayTitle", ctx, messy.ID, stringPtr("Huge play by the support")).Return(nil).Once()
+	repo.On("SetDisplayTitle", ctx, optedOut.ID, (*string)(nil)).Return(nil).Once()
+	result, err = svc.Reprocess(ctx, "", false, 2)
+	require.NoError(t, err)
+	assert.Equal(t, &models.ClipTitleReprocessResult{Scanned: 3, Updated: 1, Cleared: 1}, result)
+
+	messy.DisplayTitle = stringPtr("Huge play by the support")
+	optedOut.DisplayTitle = nil
+	expectClipTitlePages(repo, "", 2, messy, clean, optedOut)
+	result, err = svc.Reprocess(ctx, "", false, 2)
+	require.NoError(t, err)
+	assert.Equal(t, &models.ClipTitleReprocessResult{Scanned: 3}, result, "a second pass should change nothing")
+	repo.AssertNumberOfCalls(t, "SetDisplayTitle", 2)
+
+	expectClipTitlePages(repo, "1", clipTitleReprocessBatchSize, messy, clean)
+	repo.On("SetDisplayTitle", ctx, messy.ID, (*string)(nil)).Return(nil).Once()
+	result, err = NewClipTitleService(repo, new(MockUserRepository), false).Reprocess(ctx, "1", false, 0)
+	require.NoError(t, err)
+	assert.Equal(t, &models.ClipTitleReprocessResult{Scanned: 2, Cleared: 1}, result)
+
+	repo.AssertExpectations(t)
+	repo.AssertNumberOfCalls(t, "ListOptedOutBroadcasters", 3)
+}
+
+func TestClipTitleService_UpdatePreference(t *testing.T) {
+	ctx := context.Background()
+	twitchID := "555"
+	broadcaster := &models.User{ID: uuid.New(), IsVerified: true, TwitchID: &twitchID}
+	viewer := &models.User{ID: uuid.New()}
+	users := new(MockUserRepository)
+	users.On("GetByID", ctx, broadcaster.ID).Return(broadcaster, nil)
+	users.On("GetByID", ctx, viewer.ID).Return(viewer, nil)
+
+	repo := new(MockClipTitleRepository)
+	clip := newTestClipTitle(twitchID, "🔥 HUGE PLAY BY THE SUPPORT 🔥")
+	svc := NewClipTitleService(repo, users, true)
+
+	repo.On("GetPreference", ctx, twitchID).Return(nil, nil).Once()
+	pref, err := svc.GetPreference(ctx, broadcaster.ID)
+	require.NoError(t, err)
+	assert.True(t, pref.NormalizeTitles, "channels normalize titles by default")
+
+	_, err = svc.UpdatePreference(ctx, viewer.ID, &models.UpdateBroadcasterTitlePreferenceRequest{})
+	assert.ErrorIs(t, err, ErrNotTitleBroadcaster)
+
+	// Opting in normalizes existing clips
+	repo.On("UpsertPreference", ctx, mock.MatchedBy(func(p *models.BroadcasterTitlePreference) bool {
+		return p.BroadcasterID == twitchID && p.NormalizeTitles
+	})).Return(nil).Once()
+	repo.On("ListOptedOutBroadcasters", ctx).Return([]string{}, nil).Once()
+	expectClipTitlePages(repo, twitchID, clipTitleReprocessBatchSize, clip)
+	repo.On("SetDisplayTitle", ctx, clip.ID, stringPtr("Huge play by the support")).Return(nil).Once()
+
+	pref, err = svc.UpdatePreference(ctx, broadcaster.ID, &models.UpdateBroadcasterTitlePreferenceRequest{NormalizeTitles: true})
+	require.NoError(t, err)
+	assert.True(t, pref.NormalizeTitles)
+
+	// Opting out clears display titles
+	clip.DisplayTitle = stringPtr("Huge play by the support")
+	repo.On("UpsertPreference", ctx, mock.MatchedBy(func(p *models.BroadcasterTitlePreference) bool {
+		return p.BroadcasterID == twitchID && !p.NormalizeTitles
+	})).Return(nil).Once()
+	repo.On("ListOptedOutBroadcasters", ctx).Return([]string{twitchID}, nil).Once()
+	expectClipTitlePages(repo, twitchID, clipTitleReprocessBatchSize, clip)
+	repo.On("SetDisplayTitle", ctx, clip.ID, (*string)(nil)).Return(nil).Once()
+
+	pref, err = svc.UpdatePreference(ctx, broadcaster.ID, &models.UpdateBroadcasterTitlePreferenceRequest{NormalizeTitles: false})
+	require.NoError(t, err)
+	assert.False(t, pref.NormalizeTitles)
+
+	repo.AssertExpectations(t)
+	users.AssertExpectations(t)
+}
@@ -1,20 +1,20 @@
 package services
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/microcosm-cc/bluemonday"
 	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/pagination"
 	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/pkg/metrics"
 	"github.com/yuin/goldmark"
-	"github.com/yuin/goldmark/extension"
-	"github.com/yuin/goldmark/parser"
-	"github.com/yuin/goldmark/renderer/html"
 )
 
 const (
@@ -32,6 +32,30 @@ const (
 	KarmaPerUpvote = 1
 	// KarmaPerDownvote is karma removed when comment is downvoted
 	KarmaPerDownvote = -1
+	// MaxMentionsPerComment caps how many users one comment can mention
+	MaxMentionsPerComment = 10
+	// MaxPinnedCommentsPerClip caps how many comments a clip can have pinned
+	MaxPinnedCommentsPerClip = 3
+	// MaxReplyTreeDepth caps how many levels of replies one request can load
+	MaxReplyTreeDepth = 5
+	// ReplyBranchLimit is how many replies each nested branch of a reply tree
+	// loads; the rest are loaded through the branch's cursor
+	ReplyBranchLimit = 10
+)
+
+var (
+	// ErrCommentNotFound is returned when a comment doesn't exist
+	ErrCommentNotFound = errors.New("comment not found")
+	// ErrCommentHistoryForbidden is returned when someone other than the
+	// author or a moderator asks for a comment's edit history
+	ErrCommentHistoryForbidden = errors.New("only the author and moderators can view a comment's history")
+	// ErrCommentPinForbidden is returned when someone other than the clip's
+	// broadcaster, creator or a moderator pins or unpins a comment
+	ErrCommentPinForbidden = errors.New("only the clip's broadcaster, creator and moderators can pin comments")
+	// ErrCommentNotPinnable is returned when pinning a reply or removed comment
+	ErrCommentNotPinnable = errors.New("only top-level comments can be pinned")
+	// ErrTooManyPinnedComments is returned when a clip already has the maximum number of pinned comments
+	ErrTooManyPinnedComments = errors.New("clip already has the maximum number of pinned comments")
 )
 
 // CommentService handles comment business logic
@@ -43,53 +67,101 @@ type CommentService struct {
 	sanitizer           *bluemonday.Policy
 	notificationService *NotificationService
 	toxicityClassifier  *ToxicityClassifier
+	stream              CommentStreamPublisher
+	clipManager         ClipManager
+	auditLogRepo        *repository.AuditLogRepository
+	automod             ContentAutomod
+	toxicityThresholds  ToxicityThresholds
+	moderationEvents    *ModerationEventService
+	karma               KarmaAwarder
+	achievements        AchievementTracker
+	shadowBans          ShadowBanChecker
 }
 
-// NewCommentService creates a new CommentService
-func NewCommentService(repo *repository.CommentRepository, clipRepo *repository.ClipRepository, userRepo *repository.UserRepository, notificationService *NotificationService, toxicityClassifier *ToxicityClassifier) *CommentService {
-	// Configure markdown processor
-	md := goldmark.New(
-		goldmark.WithExtensions(
-			extension.Strikethrough,
-			extension.Linkify,
-			extension.Table,
-		),
-		goldmark.WithParserOptions(
-			parser.WithAutoHeadingID(),
-		),
-		goldmark.WithRendererOptions(
-			html.WithXHTML(),
-			html.WithUnsafe(), // We'll sanitize with bluemonday instead
-		),
-	)
-
-	// Configure HTML sanitizer - allow safe markdown subset
-	sanitizer := bluemonday.UGCPolicy()
-	sanitizer.AllowElements("p", "br", "strong", "em", "del", "code", "pre", "blockquote",
-		"ul", "ol", "li", "a", "h1", "h2", "h3", "h4", "h5", "h6", "table", "thead",
-		"tbody", "tr", "th", "td")
-	sanitizer.AllowAttrs("href").OnElements("a")
-	sanitizer.AllowAttrs("class").OnElements("code")
-	sanitizer.RequireNoFollowOnLinks(true)
-	sanitizer.RequireNoReferrerOnLinks(true)
-	sanitizer.AddTargetBlankToFullyQualifiedLinks(true)
+// ClipManager reports whether a user can manage a clip, as its creator or a
+// moderator
+type ClipManager interface {
+	CanManageClip(ctx context.Context, userID uuid.UUID, clipID uuid.UUID) (bool, error)
+}
+
+// CommentStreamPublisher pushes comment events to clips' open comment streams
+type CommentStreamPublisher interface {
+	Publish(ctx context.Context, clipID uuid.UUID, event CommentStreamEvent) error
+}
 
+// NewCommentService creates a new CommentService. Comments are rendered with
+// the Markdown renderer shared with community discussions.
+func NewCommentService(repo *repository.CommentRepository, clipRepo *repository.ClipRepository, userRepo *repository.UserRepository, notificationService *NotificationService, toxicityClassifier *ToxicityClassifier, renderer *MarkdownRenderer) *CommentService {
 	return &CommentService{
 		repo:                repo,
 		clipRepo:            clipRepo,
 		userRepo:            userRepo,
-		markdown:            md,
-		sanitizer:           sanitizer,
+		markdown:            renderer.markdown,
+		sanitizer:           renderer.sanitizer,
 		notificationService: notificationService,
 		toxicityClassifier:  toxicityClassifier,
 	}
 }
 
+// SetStreamPublisher pushes new comments and vote score changes to clips'
+// open comment streams
+func (s *CommentService) SetStreamPublisher(stream CommentStreamPublisher) {
+	s.stream = stream
+}
+
+// SetClipManager lets the clips' creators, including those verified by a
+// creator claim, pin comments. Without it only Twitch logins match creators.
+func (s *CommentService) SetClipManager(clipManager ClipManager) {
+	s.clipManager = clipManager
+}
+
+// SetAuditLogRepository records comment pins in the moderation audit log
+func (s *CommentService) SetAuditLogRepository(auditLogRepo *repository.AuditLogRepository) {
+	s.auditLogRepo = auditLogRepo
+}
+
+// SetToxicityThresholds flags toxic comments at the threshold set by the
+// communities of the clip they're on, instead of the site-wide one
+func (s *CommentService) SetToxicityThresholds(thresholds ToxicityThresholds) {
+	s.toxicityThresholds = thresholds
+}
+
+// SetModerationEvents emits a moderation event for each comment flagged as toxic
+func (s *CommentService) SetModerationEvents(events *ModerationEventService) {
+	s.moderationEvents = events
+}
+
+// SetAutomod evaluates new comments against the automoderation rules
+func (s *CommentService) SetAutomod(automod ContentAutomod) {
+	s.automod = automod
+}
+
+// SetKarmaAwarder awards karma for posting comments under the karma rules
+// instead of KarmaPerComment. Vote karma is unaffected.
+func (s *CommentService) SetKarmaAwarder(karma KarmaAwarder) {
+	s.karma = karma
+}
+
+// SetAchievementTracker counts posted and upvoted comments toward achievements
+func (s *CommentService) SetAchievementTracker(achievements AchievementTracker) {
+	s.achievements = achievements
+}
+
+// SetShadowBanChecker keeps shadow-banned users' new comments off other
+// viewers' comment streams
+func (s *CommentService) SetShadowBanChecker(shadowBans ShadowBanChecker) {
+	s.shadowBans = shadowBans
+}
+
 // CommentTreeNode represents a comment with nested replies
 type CommentTreeNode struct {
 	repository.CommentWithAuthor
 	RenderedContent string            `json:"rendered_content"`
 	Replies         []CommentTreeNode `json:"replies,omitempty"`
+	// HasMoreReplies is set when the comment has replies that weren't loaded
+	HasMoreReplies bool `json:"has_more_replies,omitempty"`
+	// RepliesCursor continues the loaded replies, when more follow in the same order
+	RepliesCursor *string `json:"replies_cursor,omitempty"`
 }
 
 // CreateCommentRequest represents a request to create a comment
@@ -166,13 +238,16 @@ func (s *CommentService) CreateComment(ctx context.Context, req *CreateCommentRe
 		return nil, err
 	}
 
-	// Create comment
+	// Create comment, storing its rendered HTML alongside the raw content
+	content := strings.TrimSpace(req.Content)
+	rendered := s.RenderMarkdown(content)
 	comment := &models.Comment{
 		ID:              uuid.New(),
 		ClipID:          clipID,
 		UserID:          userID,
 		ParentCommentID: req.ParentCommentID,
-		Content:         strings.TrimSpace(req.Content),
+		Content:         content,
+		RenderedContent: &rendered,
 		VoteScore:       0,
 		ReplyCount:      0,
 		IsEdited:        false,
@@ -181,11 +256,27 @@ func (s *CommentService) CreateComment(ctx context.Context, req *CreateCommentRe
 		UpdatedAt:       time.Now(),
 	}
 
+	// Removed, held and shadow-hidden comments are saved but kept from other users
+	var verdict *models.AutomodVerdict
+	if s.automod != nil {
+		verdict = s.automod.Evaluate(ctx, &AutomodContent{
+			Target:   models.AutomodTargetComment,
+			Text:     content,
+			AuthorID: userID,
+			ClipID:   &clipID,
+		})
+		applyCommentAutomod(comment, verdict)
+	}
+
 	// Save to database
 	if err := s.repo.Create(ctx, comment); err != nil {
 		return nil, fmt.Errorf("failed to create comment: %w", err)
 	}
 
+	if verdict != nil {
+		s.automod.Report(ctx, models.AutomodTargetComment, comment.ID, verdict)
+	}
+
 	// Auto-upvote: Create an upvote from the comment creator
 	// This encourages engagement and shows creator approval
 	// Note: We call the repository method directly instead of s.VoteOnComment() to avoid
@@ -197,14 +288,17 @@ func (s *CommentService) CreateComment(ctx context.Context, req *CreateCommentRe
 	}
 
 	// Award karma to user
-	if err := s.repo.UpdateUserKarma(ctx, userID, KarmaPerComment); err != nil {
+	if err := s.awardCommentKarma(ctx, userID, comment.ID); err != nil {
 		// Log error but don't fail the comment creation
 		fmt.Printf("Warning: failed to update karma for user %s: %v\n", userID, err)
 	}
+	if s.achievements != nil {
+		s.achievements.RecordProgress(ctx, userID, models.AchievementMetricCommentsPosted, comment.ID)
+	}
 
 	// Get clip for creator notification
 	clip, err := s.clipRepo.GetByID(ctx, clipID)
-	if err == nil && clip.CreatorID != nil && s.notificationService != nil {
+	if err == nil && clip.CreatorID != nil && s.notificationService != nil && !verdict.Hides() {
 		// Send notification to clip creator about the comment
 		if err := s.notificationService.NotifyClipComment(ctx, clipID, userID, *clip.CreatorID); err != nil {
 			// Log error but don't fail the comment creation
@@ -213,40 +307,21 @@ func (s *CommentService) CreateComment(ctx context.Context, req *CreateCommentRe
 	}
 
 	// Send notification for reply if this is a reply to a parent comment
-	if s.notificationService != nil && req.ParentCommentID != nil {
+	if s.notificationService != nil && req.ParentCommentID != nil && !verdict.Hides() {
 		if err := s.notificationService.NotifyCommentReply(ctx, clipID, *req.ParentCommentID, userID); err != nil {
 			// Log error but don't fail the comment creation
 			fmt.Printf("Warning: failed to send reply notification: %v\n", err)
 		}
 	}
 
-	// Perform toxicity classification (async - don't block comment creation)
-	if s.toxicityClassifier != nil {
-		go func() {
-			// Create a new context with timeout for async processing
-			asyncCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-			defer cancel()
-
-			// Classify the comment content
-			score, err := s.toxicityClassifier.ClassifyComment(asyncCtx, comment.Content)
-			if err != nil {
-				// Log error but don't fail - this is a non-critical enhancement
-				fmt.Printf("Warning: failed to classify comment %s for toxicity: %v\n", comment.ID, err)
-				return
-			}
-
-			// Record the prediction for metrics
-			if err := s.toxicityClassifier.RecordPrediction(asyncCtx, comment.ID, score); err != nil {
-				fmt.Printf("Warning: failed to record toxicity prediction for comment %s: %v\n", comment.ID, err)
-				// Continue even if recording fails
-			}
-
-			// Note: The database trigger will automatically add high-confidence toxic comments
-			// to the moderation queue when RecordPrediction inserts into toxicity_predictions.
-			// No need to call AddToModerationQueue explicitly here.
-		}()
+	// Record mentions and notify the mentioned users
+	if !verdict.Hides() {
+		s.syncMentions(ctx, comment.ID, clipID, userID, comment.Content)
 	}
 
+	// Perform toxicity classification (async - don't block comment creation)
+	s.scoreToxicity(*comment)
+
 	// Fetch the complete comment with author info and vote status
 	commentWithAuthor, err := s.repo.GetByID(ctx, comment.ID, &userID)
 	if err != nil {
@@ -255,8 +330,38 @@ func (s *CommentService) CreateComment(ctx context.Context, req *CreateCommentRe
 		// with author info and vote status, which we cannot provide.
 		return nil, fmt.Errorf("failed to fetch created comment with author info: %w", err)
 	}
+	created := []repository.CommentWithAuthor{*commentWithAuthor}
+	s.attachMentions(ctx, created)
 
-	return commentWithAuthor, nil
+	if !verdict.Hides() {
+		s.publishNewComment(ctx, created[0])
+	}
+
+	return &created[0], nil
+}
+
+// applyCommentAutomod removes, holds or shadow-hides a new comment by its
+// automoderation verdict. Flagged comments are only queued for review.
+func applyCommentAutomod(comment *models.Comment, verdict *models.AutomodVerdict) {
+	if verdict == nil {
+		return
+	}
+
+	var state string
+	switch verdict.Action {
+	case models.AutomodActionRemove:
+		reason := "Removed by automoderation"
+		comment.IsRemoved = true
+		comment.RemovedReason = &reason
+		return
+	case models.AutomodActionHold:
+		state = models.CommentAutomodHeld
+	case models.AutomodActionShadowHide:
+		state = models.CommentAutomodShadowHidden
+	default:
+		return
+	}
+	comment.AutomodState = &state
 }
 
 // UpdateComment updates a comment's content
@@ -293,13 +398,166 @@ func (s *CommentService) UpdateComment(ctx context.Context, commentID, userID uu
 	}
 
 	// Update the comment
-	if err := s.repo.Update(ctx, commentID, content); err != nil {
+	if err := s.repo.Update(ctx, commentID, userID, content, s.RenderMarkdown(content)); err != nil {
 		return fmt.Errorf("failed to update comment: %w", err)
 	}
 
+	// Only users newly mentioned by the edit are notified
+	s.syncMentions(ctx, commentID, comment.ClipID, comment.UserID, content)
+
+	// Score the edited content again
+	edited := comment.Comment
+	edited.Content = content
+	s.scoreToxicity(edited)
+
 	return nil
 }
 
+// scoreToxicity classifies a comment in the background and records its
+// score. Comments reaching the threshold for their clip are queued for
+// moderators with their scores and emitted as moderation events.
+func (s *CommentService) scoreToxicity(comment models.Comment) {
+	if s.toxicityClassifier == nil {
+		return
+	}
+
+	go func() {
+		// Create a new context with timeout for async processing
+		asyncCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		// Classify the comment content
+		score, err := s.toxicityClassifier.ClassifyComment(asyncCtx, comment.Content)
+		if err != nil {
+			// Log error but don't fail - this is a non-critical enhancement
+			fmt.Printf("Warning: failed to classify comment %s for toxicity: %v\n", comment.ID, err)
+			return
+		}
+
+		threshold, enabled := s.toxicityClassifier.Threshold(), true
+		if s.toxicityThresholds != nil {
+			threshold, enabled = s.toxicityThresholds.ThresholdForClip(asyncCtx, comment.ClipID)
+		}
+		if enabled {
+			score.ApplyThreshold(threshold)
+		} else {
+			score.Toxic = false
+			score.ReasonCodes = []string{}
+		}
+
+		// Record the prediction for metrics and moderator context
+		var recordedThreshold *float64
+		if enabled {
+			recordedThreshold = &threshold
+		}
+		if err := s.toxicityClassifier.RecordPrediction(asyncCtx, comment.ID, score, recordedThreshold); err != nil {
+			fmt.Printf("Warning: failed to record toxicity prediction for comment %s: %v\n", comment.ID, err)
+			// Continue even if recording fails
+		}
+
+		if !score.Toxic {
+			return
+		}
+
+		if err := s.toxicityClassifier.AddToModerationQueue(asyncCtx, comment.ID, score, threshold); err != nil {
+			fmt.Printf("Warning: failed to queue toxic comment %s: %v\n", comment.ID, err)
+		}
+
+		if s.moderationEvents != nil {
+			metadata := map[string]interface{}{
+				"confidence_score": score.ConfidenceScore,
+				"threshold":        threshold,
+				"reason_codes":     score.ReasonCodes,
+			}
+			if err := s.moderationEvents.EmitCommentEvent(asyncCtx, ModerationEventCommentToxic, &comment, metadata); err != nil {
+				fmt.Printf("Warning: failed to emit toxic comment event for %s: %v\n", comment.ID, err)
+			}
+		}
+	}()
+}
+
+// GetCommentHistory returns a comment's earlier versions, newest first. Only
+// its author and moderators may see them.
+func (s *CommentService) GetCommentHistory(ctx context.Context, commentID, userID uuid.UUID, role string) ([]models.CommentRevision, error) {
+	comment, err := s.repo.GetByID(ctx, commentID, nil)
+	if err != nil {
+		return nil, ErrCommentNotFound
+	}
+
+	isMod := role == "moderator" || role == "admin"
+	if !isMod && comment.UserID != userID {
+		return nil, ErrCommentHistoryForbidden
+	}
+
+	revisions, err := s.repo.ListRevisions(ctx, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment history: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// syncMentions records the users mentioned in a comment's content and
+// notifies those not mentioned in it before. Failures are logged, not
+// returned, so they never fail the comment itself.
+func (s *CommentService) syncMentions(ctx context.Context, commentID, clipID, authorID uuid.UUID, content string) {
+	usernames := extractMentions(content)
+	if len(usernames) > MaxMentionsPerComment {
+		usernames = usernames[:MaxMentionsPerComment]
+	}
+
+	added, err := s.repo.SetMentions(ctx, commentID, usernames)
+	if err != nil {
+		fmt.Printf("Warning: failed to record mentions for comment %s: %v\n", commentID, err)
+		return
+	}
+
+	if s.notificationService != nil && len(added) > 0 {
+		if err := s.notificationService.NotifyMentions(ctx, content, clipID, authorID, added); err != nil {
+			fmt.Printf("Warning: failed to send mention notifications: %v\n", err)
+		}
+	}
+}
+
+// attachReactions sets the reaction counts of listed comments, marking those
+// the viewer gave when set
+func (s *CommentService) attachReactions(ctx context.Context, comments []repository.CommentWithAuthor, userID *uuid.UUID) {
+	ids := make([]uuid.UUID, 0, len(comments))
+	for _, c := range comments {
+		ids = append(ids, c.ID)
+	}
+
+	counts, err := s.repo.ListReactionCounts(ctx, ids, userID)
+	if err != nil {
+		fmt.Printf("Warning: failed to list comment reactions: %v\n", err)
+		return
+	}
+	for i := range comments {
+		comments[i].Reactions = counts[comments[i].ID]
+	}
+}
+
+// attachMentions sets the mentioned users of listed comments
+func (s *CommentService) attachMentions(ctx context.Context, comments []repository.CommentWithAuthor) {
+	ids := make([]uuid.UUID, 0, len(comments))
+	for _, c := range comments {
+		ids = append(ids, c.ID)
+	}
+
+	mentions, err := s.repo.ListMentions(ctx, ids)
+	if err != nil {
+		fmt.Printf("Warning: failed to list comment mentions: %v\n", err)
+		return
+	}
+	for i := range comments {
+		// Removed comments don't reveal who they mentioned
+		if comments[i].IsRemoved {
+			continue
+		}
+		comments[i].Mentions = mentions[comments[i].ID]
+	}
+}
+
 // DeleteComment soft-deletes a comment
 func (s *CommentService) DeleteComment(ctx context.Context, commentID, userID uuid.UUID, role string, reason *string) error {
 	// Get the comment
@@ -323,7 +581,7 @@ func (s *CommentService) DeleteComment(ctx context.Context, commentID, userID uu
 
 	// Remove karma from user if author deleted their own comment
 	if isAuthor {
-		if err := s.repo.UpdateUserKarma(ctx, comment.UserID, -KarmaPerComment); err != nil {
+		if err := s.revokeCommentKarma(ctx, comment.UserID, commentID); err != nil {
 			fmt.Printf("Warning: failed to update karma for user %s: %v\n", comment.UserID, err)
 		}
 	}
@@ -331,6 +589,26 @@ func (s *CommentService) DeleteComment(ctx context.Context, commentID, userID uu
 	return nil
 }
 
+// awardCommentKarma awards karma for posting a comment, under the karma rules
+// when a karma awarder is set
+func (s *CommentService) awardCommentKarma(ctx context.Context, userID, commentID uuid.UUID) error {
+	if s.karma != nil {
+		_, err := s.karma.AwardKarma(ctx, userID, models.KarmaEventCommentCreated, commentID)
+		return err
+	}
+	return s.repo.UpdateUserKarma(ctx, userID, KarmaPerComment)
+}
+
+// revokeCommentKarma takes back the karma awarded for posting a comment. Under
+// the karma rules only what was actually awarded is taken back.
+func (s *CommentService) revokeCommentKarma(ctx context.Context, userID, commentID uuid.UUID) error {
+	if s.karma != nil {
+		_, err := s.karma.RevokeKarma(ctx, userID, models.KarmaEventCommentCreated, commentID)
+		return err
+	}
+	return s.repo.UpdateUserKarma(ctx, userID, -KarmaPerComment)
+}
+
 // VoteOnComment creates or updates a vote on a comment
 func (s *CommentService) VoteOnComment(ctx context.Context, commentID, userID uuid.UUID, voteType int16) error {
 	// Validate vote type
@@ -356,6 +634,7 @@ func (s *CommentService) VoteOnComment(ctx context.Context, commentID, userID uu
 			if err := s.repo.RemoveVote(ctx, userID, commentID); err != nil {
 				return fmt.Errorf("failed to remove vote: %w", err)
 			}
+			metrics.RecordVote("comment", 0)
 
 			// Update karma (reverse previous vote)
 			var karmaDelta int
@@ -367,6 +646,7 @@ func (s *CommentService) VoteOnComment(ctx context.Context, commentID, userID uu
 			if err := s.repo.UpdateUserKarma(ctx, comment.UserID, karmaDelta); err != nil {
 				fmt.Printf("Warning: failed to update karma for user %s: %v\n", comment.UserID, err)
 			}
+			s.publishVoteScore(ctx, comment.ClipID, commentID)
 		}
 		return nil
 	}
@@ -375,6 +655,7 @@ func (s *CommentService) VoteOnComment(ctx context.Context, commentID, userID uu
 	if err := s.repo.VoteOnComment(ctx, userID, commentID, voteType); err != nil {
 		return fmt.Errorf("failed to vote on comment: %w", err)
 	}
+	metrics.RecordVote("comment", voteType)
 
 	// Update karma
 	var karmaDelta int
@@ -399,55 +680,314 @@ func (s *CommentService) VoteOnComment(ctx context.Context, commentID, userID uu
 		}
 	}
 
+	// A comment counts as upvoted once, on its first upvote by another user
+	if voteType == 1 && userID != comment.UserID && s.achievements != nil {
+		s.achievements.RecordProgress(ctx, comment.UserID, models.AchievementMetricUpvotedComments, commentID)
+	}
+
+	s.publishVoteScore(ctx, comment.ClipID, commentID)
+
+	return nil
+}
+
+// publishNewComment pushes a new comment to its clip's open streams, without
+// the author's own vote. Comments by shadow-banned users aren't pushed.
+func (s *CommentService) publishNewComment(ctx context.Context, comment repository.CommentWithAuthor) {
+	if s.stream == nil {
+		return
+	}
+	if s.shadowBans != nil && s.shadowBans.IsShadowBanned(ctx, comment.UserID) {
+		return
+	}
+
+	comment.UserVote = nil
+	node := &CommentTreeNode{CommentWithAuthor: comment, RenderedContent: s.renderedContent(&comment)}
+	event := CommentStreamEvent{Type: CommentStreamEventComment, Comment: node}
+	if err := s.stream.Publish(ctx, comment.ClipID, event); err != nil {
+		fmt.Printf("Warning: failed to publish comment %s to stream: %v\n", comment.ID, err)
+	}
+}
+
+// publishVoteScore pushes a comment's current vote score to its clip's open streams
+func (s *CommentService) publishVoteScore(ctx context.Context, clipID, commentID uuid.UUID) {
+	if s.stream == nil {
+		return
+	}
+
+	comment, err := s.repo.GetByID(ctx, commentID, nil)
+	if err != nil {
+		fmt.Printf("Warning: failed to get vote score of comment %s: %v\n", commentID, err)
+		return
+	}
+	event := CommentStreamEvent{Type: CommentStreamEventVote, CommentID: &commentID, VoteScore: &comment.VoteScore}
+	if err := s.stream.Publish(ctx, clipID, event); err != nil {
+		fmt.Printf("Warning: failed to publish vote score of comment %s to stream: %v\n", commentID, err)
+	}
+}
+
+// ReactToComment gives a comment a reaction from a user and returns the
+// comment's reaction counts. The author is notified of new reactions.
+func (s *CommentService) ReactToComment(ctx context.Context, commentID, userID uuid.UUID, reaction string) ([]models.CommentReactionCount, error) {
+	if !slices.Contains(models.ValidCommentReactions, reaction) {
+		return nil, fmt.Errorf("invalid reaction: must be one of %s", strings.Join(models.ValidCommentReactions, ", "))
+	}
+
+	comment, err := s.repo.GetByID(ctx, commentID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("comment not found")
+	}
+	if comment.IsRemoved {
+		return nil, fmt.Errorf("cannot react to a removed comment")
+	}
+
+	added, err := s.repo.AddReaction(ctx, commentID, userID, reaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to react to comment: %w", err)
+	}
+
+	if added && s.notificationService != nil {
+		if err := s.notificationService.NotifyCommentReaction(ctx, comment, userID); err != nil {
+			// Log error but don't fail the reaction
+			fmt.Printf("Warning: failed to send comment reaction notification: %v\n", err)
+		}
+	}
+
+	return s.reactionCounts(ctx, commentID, userID)
+}
+
+// RemoveCommentReaction takes back a user's reaction to a comment and returns
+// the comment's reaction counts
+func (s *CommentService) RemoveCommentReaction(ctx context.Context, commentID, userID uuid.UUID, reaction string) ([]models.CommentReactionCount, error) {
+	if !slices.Contains(models.ValidCommentReactions, reaction) {
+		return nil, fmt.Errorf("invalid reaction: must be one of %s", strings.Join(models.ValidCommentReactions, ", "))
+	}
+
+	if _, err := s.repo.GetByID(ctx, commentID, nil); err != nil {
+		return nil, fmt.Errorf("comment not found")
+	}
+
+	if err := s.repo.RemoveReaction(ctx, commentID, userID, reaction); err != nil {
+		return nil, fmt.Errorf("failed to remove reaction: %w", err)
+	}
+
+	return s.reactionCounts(ctx, commentID, userID)
+}
+
+// reactionCounts returns one comment's reaction counts as a user sees them
+func (s *CommentService) reactionCounts(ctx context.Context, commentID, userID uuid.UUID) ([]models.CommentReactionCount, error) {
+	counts, err := s.repo.ListReactionCounts(ctx, []uuid.UUID{commentID}, &userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reactions: %w", err)
+	}
+	if counts[commentID] == nil {
+		return []models.CommentReactionCount{}, nil
+	}
+	return counts[commentID], nil
+}
+
+// PinComment pins a top-level comment to the top of its clip's comments and
+// notifies its author. The clip's broadcaster and creator and moderators can
+// pin comments.
+func (s *CommentService) PinComment(ctx context.Context, clipID, commentID, userID uuid.UUID, role string) error {
+	comment, err := s.pinnableComment(ctx, clipID, commentID, userID, role)
+	if err != nil {
+		return err
+	}
+	if comment.ParentCommentID != nil || comment.IsRemoved {
+		return ErrCommentNotPinnable
+	}
+
+	pinned, err := s.repo.ListPinnedIDs(ctx, comment.ClipID)
+	if err != nil {
+		return fmt.Errorf("failed to list pinned comments: %w", err)
+	}
+	if slices.Contains(pinned, commentID) {
+		return nil
+	}
+	if len(pinned) >= MaxPinnedCommentsPerClip {
+		return ErrTooManyPinnedComments
+	}
+
+	added, err := s.repo.PinComment(ctx, commentID, comment.ClipID, userID)
+	if err != nil {
+		return err
+	}
+	if !added {
+		return nil
+	}
+
+	s.auditPin(ctx, "comment_pinned", comment, userID)
+	if s.notificationService != nil {
+		if err := s.notificationService.NotifyCommentPinned(ctx, comment, userID); err != nil {
+			fmt.Printf("Warning: failed to send pin notification: %v\n", err)
+		}
+	}
+
 	return nil
 }
 
+// UnpinComment unpins a comment. The same users who can pin a clip's comments
+// can unpin them.
+func (s *CommentService) UnpinComment(ctx context.Context, clipID, commentID, userID uuid.UUID, role string) error {
+	comment, err := s.pinnableComment(ctx, clipID, commentID, userID, role)
+	if err != nil {
+		return err
+	}
+
+	removed, err := s.repo.UnpinComment(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	if removed {
+		s.auditPin(ctx, "comment_unpinned", comment, userID)
+	}
+
+	return nil
+}
+
+// pinnableComment loads a clip's comment a user wants to pin or unpin,
+// checking they may pin comments on the clip
+func (s *CommentService) pinnableComment(ctx context.Context, clipID, commentID, userID uuid.UUID, role string) (*repository.CommentWithAuthor, error) {
+	comment, err := s.repo.GetByID(ctx, commentID, nil)
+	if err != nil || comment.ClipID != clipID {
+		return nil, ErrCommentNotFound
+	}
+
+	if role == "moderator" || role == "admin" {
+		return comment, nil
+	}
+
+	clip, err := s.clipRepo.GetByID(ctx, comment.ClipID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get clip: %w", err)
+	}
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if user.TwitchID != nil && clip.BroadcasterID != nil && *user.TwitchID == *clip.BroadcasterID {
+		return comment, nil
+	}
+	if s.clipManager != nil {
+		canManage, err := s.clipManager.CanManageClip(ctx, userID, clip.ID)
+		if err != nil {
+			return nil, err
+		}
+		if canManage {
+			return comment, nil
+		}
+	} else if user.TwitchID != nil && clip.CreatorID != nil && *user.TwitchID == *clip.CreatorID {
+		return comment, nil
+	}
+
+	return nil, ErrCommentPinForbidden
+}
+
+// auditPin records a pin or unpin in the moderation audit log
+func (s *CommentService) auditPin(ctx context.Context, action string, comment *repository.CommentWithAuthor, userID uuid.UUID) {
+	if s.auditLogRepo == nil {
+		return
+	}
+
+	if err := s.auditLogRepo.Create(ctx, &models.ModerationAuditLog{
+		Action:      action,
+		EntityType:  "comment",
+		EntityID:    comment.ID,
+		ModeratorID: userID,
+		Metadata: map[string]interface{}{
+			"clip_id":   comment.ClipID.String(),
+			"author_id": comment.UserID.String(),
+		},
+	}); err != nil {
+		fmt.Printf("Warning: failed to audit comment pin: %v\n", err)
+	}
+}
+
+// withPinned lists a clip's pinned comments first on the first page of its
+// comments, dropping them from the pages they'd otherwise appear on
+func (s *CommentService) withPinned(ctx context.Context, clipID uuid.UUID, comments []repository.CommentWithAuthor, cursor *pagination.Cursor, userID *uuid.UUID) []repository.CommentWithAuthor {
+	pinnedIDs, err := s.repo.ListPinnedIDs(ctx, clipID)
+	if err != nil {
+		fmt.Printf("Warning: failed to list pinned comments: %v\n", err)
+		return comments
+	}
+	if len(pinnedIDs) == 0 {
+		return comments
+	}
+
+	listed := make([]repository.CommentWithAuthor, 0, len(comments)+len(pinnedIDs))
+	if cursor == nil {
+		for _, id := range pinnedIDs {
+			comment, err := s.repo.GetByID(ctx, id, userID)
+			if err != nil || comment.IsRemoved {
+				continue
+			}
+			comment.Pinned = true
+			listed = append(listed, *comment)
+		}
+	}
+
+	for _, c := range comments {
+		if !slices.Contains(pinnedIDs, c.ID) {
+			listed = append(listed, c)
+		}
+	}
+	return listed
+}
+
 // ListComments retrieves comments for a clip with sorting
-func (s *CommentService) ListComments(ctx context.Context, clipID uuid.UUID, sortBy string, limit, offset int, userID *uuid.UUID) ([]CommentTreeNode, error) {
+func (s *CommentService) ListComments(ctx context.Context, clipID uuid.UUID, sortBy string, cursor *pagination.Cursor, limit int, userID *uuid.UUID) ([]CommentTreeNode, pagination.PageInfo, error) {
 	// Get top-level comments
-	comments, err := s.repo.ListByClipID(ctx, clipID, sortBy, limit, offset, userID)
+	comments, page, err := s.repo.ListByClipID(ctx, clipID, sortBy, cursor, limit, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list comments: %w", err)
+		return nil, pagination.PageInfo{}, fmt.Errorf("failed to list comments: %w", err)
 	}
+	comments = s.withPinned(ctx, clipID, comments, cursor, userID)
 
 	// Return empty slice if no comments (not nil)
 	if len(comments) == 0 {
-		return []CommentTreeNode{}, nil
+		return []CommentTreeNode{}, page, nil
 	}
+	s.attachMentions(ctx, comments)
+	s.attachReactions(ctx, comments, userID)
 
 	// Build tree nodes with rendered content
 	var nodes []CommentTreeNode
 	for _, c := range comments {
 		node := CommentTreeNode{
 			CommentWithAuthor: c,
-			RenderedContent:   s.RenderMarkdown(c.Content),
+			RenderedContent:   s.renderedContent(&c),
 			Replies:           []CommentTreeNode{},
 		}
 		nodes = append(nodes, node)
 	}
 
-	return nodes, nil
+	return nodes, page, nil
 }
 
 // ListCommentsWithReplies retrieves comments for a clip with optional nested replies
-func (s *CommentService) ListCommentsWithReplies(ctx context.Context, clipID uuid.UUID, sortBy string, limit, offset int, userID *uuid.UUID, includeReplies bool) ([]CommentTreeNode, error) {
+func (s *CommentService) ListCommentsWithReplies(ctx context.Context, clipID uuid.UUID, sortBy string, cursor *pagination.Cursor, limit int, userID *uuid.UUID, includeReplies bool) ([]CommentTreeNode, pagination.PageInfo, error) {
 	// Get top-level comments
-	comments, err := s.repo.ListByClipID(ctx, clipID, sortBy, limit, offset, userID)
+	comments, page, err := s.repo.ListByClipID(ctx, clipID, sortBy, cursor, limit, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list comments: %w", err)
+		return nil, pagination.PageInfo{}, fmt.Errorf("failed to list comments: %w", err)
 	}
+	comments = s.withPinned(ctx, clipID, comments, cursor, userID)
 
 	// Return empty slice if no comments (not nil)
 	if len(comments) == 0 {
-		return []CommentTreeNode{}, nil
+		return []CommentTreeNode{}, page, nil
 	}
+	s.attachMentions(ctx, comments)
+	s.attachReactions(ctx, comments, userID)
 
 	// Build tree nodes with rendered content
 	var nodes []CommentTreeNode
 	for _, c := range comments {
 		node := CommentTreeNode{
 			CommentWithAuthor: c,
-			RenderedContent:   s.RenderMarkdown(c.Content),
+			RenderedContent:   s.renderedContent(&c),
 			Replies:           []CommentTreeNode{},
 		}
 
@@ -455,7 +995,7 @@ func (s *CommentService) ListCommentsWithReplies(ctx context.Context, clipID uui
 		if includeReplies {
 			replies, err := s.buildReplyTree(ctx, c.ID, userID, 1)
 			if err != nil {
-				return nil, fmt.Errorf("failed to build reply tree: %w", err)
+				return nil, pagination.PageInfo{}, fmt.Errorf("failed to build reply tree: %w", err)
 			}
 			node.Replies = replies
 		}
@@ -463,7 +1003,7 @@ func (s *CommentService) ListCommentsWithReplies(ctx context.Context, clipID uui
 		nodes = append(nodes, node)
 	}
 
-	return nodes, nil
+	return nodes, page, nil
 }
 
 // buildReplyTree recursively builds a tree of replies up to MaxNestingDepth
@@ -477,7 +1017,7 @@ func (s *CommentService) buildReplyTree(ctx context.Context, parentID uuid.UUID,
 	// Use a reasonable limit for nested replies to prevent performance issues
 	// We fetch more replies than typical pagination to provide a better UX for nested threads
 	const maxRepliesPerLevel = 50
-	replies, err := s.repo.GetReplies(ctx, parentID, maxRepliesPerLevel, 0, userID)
+	replies, _, err := s.repo.GetReplies(ctx, parentID, repository.CommentReplySortTop, nil, maxRepliesPerLevel, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get replies: %w", err)
 	}
@@ -486,13 +1026,15 @@ func (s *CommentService) buildReplyTree(ctx context.Context, parentID uuid.UUID,
 	if len(replies) == 0 {
 		return []CommentTreeNode{}, nil
 	}
+	s.attachMentions(ctx, replies)
+	s.attachReactions(ctx, replies, userID)
 
 	// Build tree nodes with rendered content and recursively load their replies
 	var nodes []CommentTreeNode
 	for _, r := range replies {
 		node := CommentTreeNode{
 			CommentWithAuthor: r,
-			RenderedContent:   s.RenderMarkdown(r.Content),
+			RenderedContent:   s.renderedContent(&r),
 			Replies:           []CommentTreeNode{},
 		}
 
@@ -509,41 +1051,105 @@ func (s *CommentService) buildReplyTree(ctx context.Context, parentID uuid.UUID,
 	return nodes, nil
 }
 
-// GetReplies retrieves replies to a comment
-func (s *CommentService) GetReplies(ctx context.Context, parentID uuid.UUID, limit, offset int, userID *uuid.UUID) ([]CommentTreeNode, error) {
-	replies, err := s.repo.GetReplies(ctx, parentID, limit, offset, userID)
+// GetReplies retrieves a page of replies to a comment in sortBy order with
+// depth levels of their replies. Nested branches load ReplyBranchLimit
+// replies each and carry a cursor when more follow; comments at the last
+// level with replies are marked so clients can load them on demand.
+func (s *CommentService) GetReplies(ctx context.Context, parentID uuid.UUID, sortBy string, cursor *pagination.Cursor, limit, depth int, userID *uuid.UUID) ([]CommentTreeNode, pagination.PageInfo, error) {
+	replies, page, err := s.repo.GetReplies(ctx, parentID, sortBy, cursor, limit, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get replies: %w", err)
+		return nil, pagination.PageInfo{}, fmt.Errorf("failed to get replies: %w", err)
 	}
 
-	// Build tree nodes with rendered content
-	var nodes []CommentTreeNode
+	nodes := s.replyNodes(ctx, replies, userID)
+	level := make([]*CommentTreeNode, 0, len(nodes))
+	for i := range nodes {
+		level = append(level, &nodes[i])
+	}
+
+	for d := 1; d < min(depth, MaxReplyTreeDepth) && len(level) > 0; d++ {
+		level, err = s.loadReplyBranches(ctx, level, sortBy, userID)
+		if err != nil {
+			return nil, pagination.PageInfo{}, err
+		}
+	}
+	for _, node := range level {
+		if node.ReplyCount > 0 && len(node.Replies) == 0 {
+			node.HasMoreReplies = true
+		}
+	}
+
+	return nodes, page, nil
+}
+
+// loadReplyBranches loads the first replies of each comment in a level of a
+// reply tree and returns the next level
+func (s *CommentService) loadReplyBranches(ctx context.Context, level []*CommentTreeNode, sortBy string, userID *uuid.UUID) ([]*CommentTreeNode, error) {
+	var parentIDs []uuid.UUID
+	for _, node := range level {
+		if node.ReplyCount > 0 {
+			parentIDs = append(parentIDs, node.ID)
+		}
+	}
+	if len(parentIDs) == 0 {
+		return nil, nil
+	}
+
+	branches, pages, err := s.repo.ListReplyBranches(ctx, parentIDs, sortBy, ReplyBranchLimit, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load reply branches: %w", err)
+	}
+
+	// Attach mentions and reactions for the whole level at once
+	var replies []repository.CommentWithAuthor
+	for _, node := range level {
+		replies = append(replies, branches[node.ID]...)
+	}
+	nodes := s.replyNodes(ctx, replies, userID)
+
+	var next []*CommentTreeNode
+	for _, node := range level {
+		count := len(branches[node.ID])
+		node.Replies, nodes = nodes[:count:count], nodes[count:]
+		node.HasMoreReplies = pages[node.ID].HasMore
+		node.RepliesCursor = pages[node.ID].NextCursor
+		for i := range node.Replies {
+			next = append(next, &node.Replies[i])
+		}
+	}
+	return next, nil
+}
+
+// replyNodes turns replies into tree nodes with their mentions, reactions and
+// rendered content
+func (s *CommentService) replyNodes(ctx context.Context, replies []repository.CommentWithAuthor, userID *uuid.UUID) []CommentTreeNode {
+	if len(replies) == 0 {
+		return []CommentTreeNode{}
+	}
+	s.attachMentions(ctx, replies)
+	s.attachReactions(ctx, replies, userID)
+
+	nodes := make([]CommentTreeNode, 0, len(replies))
 	for _, r := range replies {
-		node := CommentTreeNode{
+		nodes = append(nodes, CommentTreeNode{
 			CommentWithAuthor: r,
-			RenderedContent:   s.RenderMarkdown(r.Content),
+			RenderedContent:   s.renderedContent(&r),
 			Replies:           []CommentTreeNode{},
-		}
-		nodes = append(nodes, node)
+		})
 	}
-
-	return nodes, nil
+	return nodes
 }
 
 // RenderMarkdown processes and sanitizes markdown content
 func (s *CommentService) RenderMarkdown(content string) string {
-	// If content is removed/deleted, don't process markdown
-	if content == "[deleted]" || content == "[removed]" {
-		return content
-	}
+	return renderMarkdown(s.markdown, s.sanitizer, content)
+}
 
-	var buf bytes.Buffer
-	if err := s.markdown.Convert([]byte(content), &buf); err != nil {
-		// On error, return plain text
-		return content
+// renderedContent returns a comment's stored HTML, rendering comments written
+// before it was stored
+func (s *CommentService) renderedContent(c *repository.CommentWithAuthor) string {
+	if c.RenderedContent != nil {
+		return *c.RenderedContent
 	}
-
-	// Sanitize HTML
-	sanitized := s.sanitizer.Sanitize(buf.String())
-	return sanitized
+	return s.RenderMarkdown(c.Content)
 }
@@ -1,10 +1,15 @@
 package services
 
 import (
+	"context"
 	"strings"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/microcosm-cc/bluemonday"
+	"github.com/stretchr/testify/mock"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/parser"
@@ -259,3 +264,66 @@ func TestKarmaValues(t *testing.T) {
 		})
 	}
 }
+
+func TestCommentReactionValidation(t *testing.T) {
+	service := &CommentService{}
+	commentID, userID := uuid.New(), uuid.New()
+
+	// Reactions outside the fixed set are rejected before any lookup
+	for _, reaction := range []string{"", "party", "FIRE", "🔥"} {
+		if _, err := service.ReactToComment(context.Background(), commentID, userID, reaction); err == nil || !strings.Contains(err.Error(), "invalid reaction") {
+			t.Errorf("ReactToComment(%q) error = %v, want invalid reaction", reaction, err)
+		}
+		if _, err := service.RemoveCommentReaction(context.Background(), commentID, userID, reaction); err == nil || !strings.Contains(err.Error(), "invalid reaction") {
+			t.Errorf("RemoveCommentReaction(%q) error = %v, want invalid reaction", reaction, err)
+		}
+	}
+}
+
+// MockCommentStreamPublisher is a mock implementation of CommentStreamPublisher
+type MockCommentStreamPublisher struct {
+	mock.Mock
+}
+
+func (m *MockCommentStreamPublisher) Publish(ctx context.Context, clipID uuid.UUID, event CommentStreamEvent) error {
+	args := m.Called(ctx, clipID, event)
+	return args.Error(0)
+}
+
+// MockShadowBanChecker is a mock implementation of ShadowBanChecker
+type MockShadowBanChecker struct {
+	mock.Mock
+}
+
+func (m *MockShadowBanChecker) IsShadowBanned(ctx context.Context, userID uuid.UUID) bool {
+	args := m.Called(ctx, userID)
+	return args.Bool(0)
+}
+
+func TestCommentService_PublishNewCommentSkipsShadowBannedAuthors(t *testing.T) {
+	ctx := context.Background()
+	stream := new(MockCommentStreamPublisher)
+	shadowBans := new(MockShadowBanChecker)
+	svc := &CommentService{stream: stream, shadowBans: shadowBans}
+
+	clipID, authorID, bannedID := uuid.New(), uuid.New(), uuid.New()
+	rendered := "<p>hi</p>"
+	newComment := func(userID uuid.UUID) repository.CommentWithAuthor {
+		return repository.CommentWithAuthor{Comment: models.Comment{
+			ID: uuid.New(), ClipID: clipID, UserID: userID, Content: "hi", RenderedContent: &rendered,
+		}}
+	}
+	visible, hidden := newComment(authorID), newComment(bannedID)
+
+	shadowBans.On("IsShadowBanned", ctx, authorID).Return(false).Once()
+	shadowBans.On("IsShadowBanned", ctx, bannedID).Return(true).Once()
+	stream.On("Publish", ctx, clipID, mock.MatchedBy(func(event CommentStreamEvent) bool {
+		return event.Type == CommentStreamEventComment && event.Comment.ID == visible.ID
+	})).Return(nil).Once()
+
+	svc.publishNewComment(ctx, visible)
+	svc.publishNewComment(ctx, hidden)
+
+	shadowBans.AssertExpectations(t)
+	stream.AssertExpectations(t)
+}
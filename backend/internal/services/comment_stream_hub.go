@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// CommentStreamEventComment carries a newly posted comment
+	CommentStreamEventComment = "comment"
+	// CommentStreamEventVote carries a comment's new vote score
+	CommentStreamEventVote = "vote"
+
+	commentStreamChannelPrefix = "comments:clip:"
+	commentStreamBufferSize    = 32
+	maxCommentStreamsPerClip   = 500
+	maxCommentStreamsPerViewer = 3
+)
+
+var (
+	// ErrCommentStreamClosed is returned when subscribing to a hub that is shutting down
+	ErrCommentStreamClosed = errors.New("comment stream is closed")
+	// ErrTooManyCommentStreams is returned when a clip or viewer already has the maximum number of open streams
+	ErrTooManyCommentStreams = errors.New("too many open comment streams")
+)
+
+// CommentStreamEvent is pushed to a clip's open comment streams
+type CommentStreamEvent struct {
+	Type      string           `json:"type"`
+	Comment   *CommentTreeNode `json:"comment,omitempty"`
+	CommentID *uuid.UUID       `json:"comment_id,omitempty"`
+	VoteScore *int             `json:"vote_score,omitempty"`
+}
+
+// CommentStreamHub fans comment events out to the clip comment streams open on
+// this instance. With Redis, events are published to a per-clip channel so
+// streams on every API instance receive them; without Redis they are
+// delivered locally only.
+type CommentStreamHub struct {
+	redis       redis.UniversalClient
+	pubsub      *redis.PubSub
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[chan CommentStreamEvent]string
+	viewers     map[string]int
+	closed      bool
+}
+
+// NewCommentStreamHub creates a new CommentStreamHub. redisClient may be nil.
+func NewCommentStreamHub(redisClient redis.UniversalClient) *CommentStreamHub {
+	return &CommentStreamHub{
+		redis:       redisClient,
+		subscribers: make(map[uuid.UUID]map[chan CommentStreamEvent]string),
+		viewers:     make(map[string]int),
+	}
+}
+
+// Start listens for events published by other instances until Close is called
+func (h *CommentStreamHub) Start(ctx context.Context) {
+	if h.redis == nil {
+		return
+	}
+
+	h.mu.Lock()
+	h.pubsub = h.redis.Subscribe(ctx)
+	messages := h.pubsub.Channel()
+	h.mu.Unlock()
+
+	go func() {
+		for msg := range messages {
+			clipID, err := uuid.Parse(strings.TrimPrefix(msg.Channel, commentStreamChannelPrefix))
+			if err != nil {
+				continue
+			}
+
+			var event CommentStreamEvent
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				log.Printf("Failed to decode comment stream event: %v", err)
+				continue
+			}
+			h.deliver(clipID, event)
+		}
+	}()
+}
+
+// Subscribe opens a stream of a clip's comment events for a viewer, a user or
+// client address. Streams are limited per clip on this instance and per
+// viewer. The returned function must be called when the stream ends. The
+// channel is closed when the hub shuts down.
+func (h *CommentStreamHub) Subscribe(ctx context.Context, clipID uuid.UUID, viewer string) (<-chan CommentStreamEvent, func(), error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, nil, ErrCommentStreamClosed
+	}
+
+	streams := h.subscribers[clipID]
+	if len(streams) >= maxCommentStreamsPerClip || h.viewers[viewer] >= maxCommentStreamsPerViewer {
+		return nil, nil, ErrTooManyCommentStreams
+	}
+	if streams == nil {
+		if h.pubsub != nil {
+			if err := h.pubsub.Subscribe(ctx, commentStreamChannel(clipID)); err != nil {
+				return nil, nil, fmt.Errorf("failed to subscribe to comment channel: %w", err)
+			}
+		}
+		streams = make(map[chan CommentStreamEvent]string)
+		h.subscribers[clipID] = streams
+	}
+
+	events := make(chan CommentStreamEvent, commentStreamBufferSize)
+	streams[events] = viewer
+	h.viewers[viewer]++
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() { h.unsubscribe(clipID, events) })
+	}
+
+	return events, unsubscribe, nil
+}
+
+// Publish sends an event to every open stream of a clip
+func (h *CommentStreamHub) Publish(ctx context.Context, clipID uuid.UUID, event CommentStreamEvent) error {
+	if h.redis == nil {
+		h.deliver(clipID, event)
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode comment stream event: %w", err)
+	}
+	if err := h.redis.Publish(ctx, commentStreamChannel(clipID), payload).Err(); err != nil {
+		return fmt.Errorf("failed to publish comment stream event: %w", err)
+	}
+
+	return nil
+}
+
+// Close stops listening for events and ends every open stream
+func (h *CommentStreamHub) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil
+	}
+	h.closed = true
+
+	for clipID, streams := range h.subscribers {
+		for events := range streams {
+			close(events)
+		}
+		delete(h.subscribers, clipID)
+	}
+	h.viewers = make(map[string]int)
+
+	if h.pubsub != nil {
+		return h.pubsub.Close()
+	}
+	return nil
+}
+
+// deliver hands an event to the clip's streams on this instance. Slow streams
+// drop events rather than block delivery.
+func (h *CommentStreamHub) deliver(clipID uuid.UUID, event CommentStreamEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for events := range h.subscribers[clipID] {
+		select {
+		case events <- event:
+		default:
+		}
+	}
+}
+
+// unsubscribe removes a stream and drops the Redis subscription once the clip has none left
+func (h *CommentStreamHub) unsubscribe(clipID uuid.UUID, events chan CommentStreamEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	streams, ok := h.subscribers[clipID]
+	if !ok {
+		return
+	}
+	viewer, ok := streams[events]
+	if !ok {
+		return
+	}
+
+	delete(streams, events)
+	close(events)
+	h.viewers[viewer]--
+	if h.viewers[viewer] <= 0 {
+		delete(h.viewers, viewer)
+	}
+
+	if len(streams) > 0 {
+		return
+	}
+	delete(h.subscribers, clipID)
+	if h.pubsub != nil {
+		if err := h.pubsub.Unsubscribe(context.Background(), commentStreamChannel(clipID)); err != nil {
+			log.Printf("Failed to unsubscribe from comment channel: %v", err)
+		}
+	}
+}
+
+func commentStreamChannel(clipID uuid.UUID) string {
+	return commentStreamChannelPrefix + clipID.String()
+}
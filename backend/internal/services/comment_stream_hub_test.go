@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentStreamHub_DeliversToClipStreams(t *testing.T) {
+	hub := NewCommentStreamHub(nil)
+	ctx := context.Background()
+	clipID := uuid.New()
+	otherClipID := uuid.New()
+
+	first, unsubscribeFirst, err := hub.Subscribe(ctx, clipID, "ip:10.0.0.1")
+	require.NoError(t, err)
+	defer unsubscribeFirst()
+	second, unsubscribeSecond, err := hub.Subscribe(ctx, clipID, "user:"+uuid.NewString())
+	require.NoError(t, err)
+	defer unsubscribeSecond()
+	other, unsubscribeOther, err := hub.Subscribe(ctx, otherClipID, "ip:10.0.0.1")
+	require.NoError(t, err)
+	defer unsubscribeOther()
+
+	commentID := uuid.New()
+	score := 7
+	require.NoError(t, hub.Publish(ctx, clipID, CommentStreamEvent{Type: CommentStreamEventVote, CommentID: &commentID, VoteScore: &score}))
+
+	for _, events := range []<-chan CommentStreamEvent{first, second} {
+		select {
+		case event := <-events:
+			assert.Equal(t, CommentStreamEventVote, event.Type)
+			require.NotNil(t, event.VoteScore)
+			assert.Equal(t, 7, *event.VoteScore)
+			assert.Equal(t, commentID, *event.CommentID)
+		default:
+			t.Fatal("expected event on clip stream")
+		}
+	}
+
+	select {
+	case <-other:
+		t.Fatal("streams of other clips must not receive the event")
+	default:
+	}
+}
+
+func TestCommentStreamHub_LimitsStreamsPerViewer(t *testing.T) {
+	hub := NewCommentStreamHub(nil)
+	ctx := context.Background()
+	viewer := "ip:10.0.0.1"
+
+	// The limit spans clips
+	unsubscribes := []func(){}
+	for i := 0; i < maxCommentStreamsPerViewer; i++ {
+		_, unsubscribe, err := hub.Subscribe(ctx, uuid.New(), viewer)
+		require.NoError(t, err)
+		unsubscribes = append(unsubscribes, unsubscribe)
+	}
+
+	_, _, err := hub.Subscribe(ctx, uuid.New(), viewer)
+	assert.ErrorIs(t, err, ErrTooManyCommentStreams)
+
+	// Other viewers are unaffected
+	_, unsubscribeOther, err := hub.Subscribe(ctx, uuid.New(), "ip:10.0.0.2")
+	require.NoError(t, err)
+	unsubscribeOther()
+
+	// Closing a stream frees a slot
+	unsubscribes[0]()
+	unsubscribes[0]()
+	_, unsubscribe, err := hub.Subscribe(ctx, uuid.New(), viewer)
+	require.NoError(t, err)
+	unsubscribe()
+}
+
+func TestCommentStreamHub_LimitsStreamsPerClip(t *testing.T) {
+	hub := NewCommentStreamHub(nil)
+	ctx := context.Background()
+	clipID := uuid.New()
+
+	for i := 0; i < maxCommentStreamsPerClip; i++ {
+		_, _, err := hub.Subscribe(ctx, clipID, "user:"+uuid.NewString())
+		require.NoError(t, err)
+	}
+
+	_, _, err := hub.Subscribe(ctx, clipID, "user:"+uuid.NewString())
+	assert.ErrorIs(t, err, ErrTooManyCommentStreams)
+}
+
+func TestCommentStreamHub_CloseEndsStreams(t *testing.T) {
+	hub := NewCommentStreamHub(nil)
+	ctx := context.Background()
+	clipID := uuid.New()
+
+	events, unsubscribe, err := hub.Subscribe(ctx, clipID, "ip:10.0.0.1")
+	require.NoError(t, err)
+
+	require.NoError(t, hub.Close())
+	_, open := <-events
+	assert.False(t, open)
+
+	// Unsubscribing after close is a no-op
+	unsubscribe()
+
+	_, _, err = hub.Subscribe(ctx, clipID, "ip:10.0.0.1")
+	assert.ErrorIs(t, err, ErrCommentStreamClosed)
+}
@@ -0,0 +1,338 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const (
+	// communityDigestBatchSize caps how many digests are posted per run
+	communityDigestBatchSize = 50
+	// communityDigestTopClips and communityDigestTopContributors are how many
+	// clips and contributors a digest lists
+	communityDigestTopClips        = 5
+	communityDigestTopContributors = 5
+	// communityDigestPinDuration is how long a digest stays pinned
+	communityDigestPinDuration = 7 * 24 * time.Hour
+	// communityDigestMaxTitleLength matches the discussion title column
+	communityDigestMaxTitleLength = 500
+)
+
+const defaultCommunityDigestTitleTemplate = `{{.CommunityName}} weekly digest: {{.Period}}`
+
+const defaultCommunityDigestBodyTemplate = `Here's what happened in {{.CommunityName}} from {{.Period}}.
+
+Top clips
+{{range $i, $clip := .TopClips}}{{inc $i}}. {{$clip.Title}} by {{$clip.BroadcasterName}} ({{$clip.VoteScore}} votes)
+{{else}}No clips were added this week.
+{{end}}
+Top contributors
+{{range .TopContributors}}- {{.Username}}: {{.Contributions}} contribution{{if ne .Contributions 1}}s{{end}}
+{{else}}No one contributed this week.
+{{end}}
+Membership
+{{.NewMembers}} new member{{if ne .NewMembers 1}}s{{end}} joined this week, for {{.MemberCount}} in total{{if .GrowthPercent}} (+{{printf "%.1f" .GrowthPercent}}%){{end}}.`
+
+var (
+	// ErrCommunityDigestForbidden is returned when someone other than the owner manages digest settings
+	ErrCommunityDigestForbidden = errors.New("only the community owner can manage digest settings")
+	// ErrCommunityDigestInvalidTemplate is returned for a digest template that doesn't parse or render
+	ErrCommunityDigestInvalidTemplate = errors.New("invalid digest template")
+)
+
+var communityDigestTemplateFuncs = template.FuncMap{
+	"inc": func(i int) int { return i + 1 },
+}
+
+var (
+	defaultCommunityDigestTitle = template.Must(parseCommunityDigestTemplate("title", defaultCommunityDigestTitleTemplate))
+	defaultCommunityDigestBody  = template.Must(parseCommunityDigestTemplate("body", defaultCommunityDigestBodyTemplate))
+)
+
+// CommunityDigestData is the view digest templates are rendered with
+type CommunityDigestData struct {
+	CommunityName   string
+	CommunitySlug   string
+	Period          string
+	WeekStart       time.Time
+	WeekEnd         time.Time
+	TopClips        []models.CommunityDigestClip
+	TopContributors []models.CommunityDigestContributor
+	NewMembers      int
+	MemberCount     int
+	// GrowthPercent is the week's membership growth over the members the
+	// community had when the week started
+	GrowthPercent float64
+}
+
+// CommunityDigestRepositoryInterface defines the repository methods used by CommunityDigestService
+type CommunityDigestRepositoryInterface interface {
+	GetCommunity(ctx context.Context, communityID uuid.UUID) (*models.Community, error)
+	GetSettings(ctx context.Context, communityID uuid.UUID) (*models.CommunityDigestSettings, error)
+	UpsertSettings(ctx context.Context, settings *models.CommunityDigestSettings) error
+	ListDueCommunities(ctx context.Context, weekStart time.Time, limit int) ([]models.Community, error)
+	ListTopClips(ctx context.Context, communityID uuid.UUID, since, until time.Time, limit int) ([]models.CommunityDigestClip, error)
+	ListTopContributors(ctx context.Context, communityID uuid.UUID, since, until time.Time, limit int) ([]models.CommunityDigestContributor, error)
+	CountNewMembers(ctx context.Context, communityID uuid.UUID, since, until time.Time) (int, error)
+	CreateDigest(ctx context.Context, digest *models.CommunityDigest, discussion *models.CommunityDiscussion) error
+	UnpinExpiredDigests(ctx context.Context, before time.Time) (int64, error)
+}
+
+// CommunityDigestService posts weekly digest discussions in active communities
+type CommunityDigestService struct {
+	repo CommunityDigestRepositoryInterface
+	now  func() time.Time
+}
+
+// NewCommunityDigestService creates a new CommunityDigestService
+func NewCommunityDigestService(repo CommunityDigestRepositoryInterface) *CommunityDigestService {
+	return &CommunityDigestService{
+		repo: repo,
+		now:  time.Now,
+	}
+}
+
+// GetSettings returns a community's digest settings to its owner
+func (s *CommunityDigestService) GetSettings(ctx context.Context, communityID, userID uuid.UUID) (*models.CommunityDigestSettings, error) {
+	if _, err := s.ownedCommunity(ctx, communityID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetSettings(ctx, communityID)
+}
+
+// UpdateSettings opts a community in or out of digests and sets its
+// templates. Templates are checked by rendering them with sample data.
+func (s *CommunityDigestService) UpdateSettings(ctx context.Context, communityID, userID uuid.UUID, req *models.UpdateCommunityDigestSettingsRequest) (*models.CommunityDigestSettings, error) {
+	if _, err := s.ownedCommunity(ctx, communityID, userID); err != nil {
+		return nil, err
+	}
+
+	settings, err := s.repo.GetSettings(ctx, communityID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Enabled != nil {
+		settings.Enabled = *req.Enabled
+	}
+	if req.TitleTemplate != nil {
+		settings.TitleTemplate, err = validateCommunityDigestTemplate("title", *req.TitleTemplate)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.BodyTemplate != nil {
+		settings.BodyTemplate, err = validateCommunityDigestTemplate("body", *req.BodyTemplate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.UpsertSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// PostDueDigests posts last week's digest in each active community that
+// hasn't opted out, and unpins digests that have been up for a week. Each
+// pass handles a batch, so a backlog is worked through over several runs.
+func (s *CommunityDigestService) PostDueDigests(ctx context.Context) (int, error) {
+	now := s.now()
+	if _, err := s.repo.UnpinExpiredDigests(ctx, now.Add(-communityDigestPinDuration)); err != nil {
+		return 0, err
+	}
+
+	weekStart := communityDigestWeekStart(now)
+	communities, err := s.repo.ListDueCommunities(ctx, weekStart, communityDigestBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	posted := 0
+	for i := range communities {
+		community := &communities[i]
+		if err := s.postDigest(ctx, community, weekStart); err != nil {
+			if errors.Is(err, repository.ErrCommunityDigestExists) {
+				continue
+			}
+			utils.GetLogger().Error("Failed to post community digest", err, map[string]interface{}{
+				"community_id": community.ID.String(),
+			})
+			continue
+		}
+		posted++
+	}
+
+	return posted, nil
+}
+
+// postDigest gathers a community's stats for the week and posts its digest
+func (s *CommunityDigestService) postDigest(ctx context.Context, community *models.Community, weekStart time.Time) error {
+	weekEnd := weekStart.AddDate(0, 0, 7)
+
+	clips, err := s.repo.ListTopClips(ctx, community.ID, weekStart, weekEnd, communityDigestTopClips)
+	if err != nil {
+		return err
+	}
+	contributors, err := s.repo.ListTopContributors(ctx, community.ID, weekStart, weekEnd, communityDigestTopContributors)
+	if err != nil {
+		return err
+	}
+	newMembers, err := s.repo.CountNewMembers(ctx, community.ID, weekStart, weekEnd)
+	if err != nil {
+		return err
+	}
+	settings, err := s.repo.GetSettings(ctx, community.ID)
+	if err != nil {
+		return err
+	}
+
+	data := newCommunityDigestData(community, weekStart, clips, contributors, newMembers)
+	title, body := renderCommunityDigest(settings, data)
+
+	discussion := &models.CommunityDiscussion{
+		CommunityID: community.ID,
+		UserID:      community.OwnerID,
+		Title:       title,
+		Content:     body,
+		IsPinned:    true,
+	}
+	digest := &models.CommunityDigest{
+		CommunityID: community.ID,
+		WeekStart:   weekStart,
+	}
+	return s.repo.CreateDigest(ctx, digest, discussion)
+}
+
+// ownedCommunity returns a community if userID owns it
+func (s *CommunityDigestService) ownedCommunity(ctx context.Context, communityID, userID uuid.UUID) (*models.Community, error) {
+	community, err := s.repo.GetCommunity(ctx, communityID)
+	if err != nil {
+		return nil, err
+	}
+	if community.OwnerID != userID {
+		return nil, ErrCommunityDigestForbidden
+	}
+	return community, nil
+}
+
+// communityDigestWeekStart returns the start of the last full week before
+// now: midnight UTC on the Monday before this week's
+func communityDigestWeekStart(now time.Time) time.Time {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	sinceMonday := (int(today.Weekday()) + 6) % 7
+	return today.AddDate(0, 0, -sinceMonday-7)
+}
+
+func newCommunityDigestData(community *models.Community, weekStart time.Time, clips []models.CommunityDigestClip, contributors []models.CommunityDigestContributor, newMembers int) CommunityDigestData {
+	weekEnd := weekStart.AddDate(0, 0, 7)
+	data := CommunityDigestData{
+		CommunityName:   community.Name,
+		CommunitySlug:   community.Slug,
+		Period:          fmt.Sprintf("%s to %s", weekStart.Format("Jan 2"), weekEnd.AddDate(0, 0, -1).Format("Jan 2, 2006")),
+		WeekStart:       weekStart,
+		WeekEnd:         weekEnd,
+		TopClips:        clips,
+		TopContributors: contributors,
+		NewMembers:      newMembers,
+		MemberCount:     community.MemberCount,
+	}
+	if before := community.MemberCount - newMembers; before > 0 && newMembers > 0 {
+		data.GrowthPercent = float64(newMembers) / float64(before) * 100
+	}
+	return data
+}
+
+// renderCommunityDigest renders a digest's title and body with the
+// community's templates, falling back to the defaults for a template that
+// fails to render
+func renderCommunityDigest(settings *models.CommunityDigestSettings, data CommunityDigestData) (string, string) {
+	title := renderCommunityDigestTemplate(settings.CommunityID, "title", settings.TitleTemplate, defaultCommunityDigestTitle, data)
+	body := renderCommunityDigestTemplate(settings.CommunityID, "body", settings.BodyTemplate, defaultCommunityDigestBody, data)
+
+	title = strings.Join(strings.Fields(title), " ")
+	if runes := []rune(title); len(runes) > communityDigestMaxTitleLength {
+		title = string(runes[:communityDigestMaxTitleLength])
+	}
+	return title, body
+}
+
+func renderCommunityDigestTemplate(communityID uuid.UUID, name string, custom *string, fallback *template.Template, data CommunityDigestData) string {
+	if custom != nil {
+		tmpl, err := parseCommunityDigestTemplate(name, *custom)
+		var out string
+		if err == nil {
+			out, err = executeCommunityDigestTemplate(tmpl, data)
+		}
+		if err == nil && strings.TrimSpace(out) != "" {
+			return strings.TrimSpace(out)
+		}
+		if err == nil {
+			err = errors.New("template renders empty")
+		}
+		utils.GetLogger().Warn("Community digest template failed to render, using default", map[string]interface{}{
+			"community_id": communityID.String(),
+			"template":     name,
+			"error":        err.Error(),
+		})
+	}
+	out, _ := executeCommunityDigestTemplate(fallback, data)
+	return strings.TrimSpace(out)
+}
+
+// validateCommunityDigestTemplate checks a template renders to something
+// with sample data. An empty template resets to the default, returning nil.
+func validateCommunityDigestTemplate(name, text string) (*string, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	tmpl, err := parseCommunityDigestTemplate(name, text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCommunityDigestInvalidTemplate, err)
+	}
+	out, err := executeCommunityDigestTemplate(tmpl, sampleCommunityDigestData())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCommunityDigestInvalidTemplate, err)
+	}
+	if strings.TrimSpace(out) == "" {
+		return nil, fmt.Errorf("%w: %s template renders empty", ErrCommunityDigestInvalidTemplate, name)
+	}
+	return &text, nil
+}
+
+func parseCommunityDigestTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(communityDigestTemplateFuncs).Option("missingkey=error").Parse(text)
+}
+
+func executeCommunityDigestTemplate(tmpl *template.Template, data CommunityDigestData) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// sampleCommunityDigestData is the data templates are checked against
+func sampleCommunityDigestData() CommunityDigestData {
+	weekStart := communityDigestWeekStart(time.Now())
+	community := &models.Community{Name: "Sample Community", Slug: "sample-community", MemberCount: 120}
+	clips := []models.CommunityDigestClip{
+		{ClipID: uuid.New(), Title: "Sample clip", BroadcasterName: "broadcaster", VoteScore: 42},
+	}
+	contributors := []models.CommunityDigestContributor{
+		{UserID: uuid.New(), Username: "member", Clips: 2, Discussions: 1, Comments: 5},
+	}
+	return newCommunityDigestData(community, weekStart, clips, contributors, 20)
+}
@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+// MockCommunityDigestRepository is a mock implementation of CommunityDigestRepositoryInterface
+type MockCommunityDigestRepository struct {
+	mock.Mock
+}
+
+func (m *MockCommunityDigestRepository) GetCommunity(ctx context.Context, communityID uuid.UUID) (*models.Community, error) {
+	args := m.Called(ctx, communityID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Community), args.Error(1)
+}
+
+func (m *MockCommunityDigestRepository) GetSettings(ctx context.Context, communityID uuid.UUID) (*models.CommunityDigestSettings, error) {
+	args := m.Called(ctx, communityID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CommunityDigestSettings), args.Error(1)
+}
+
+func (m *MockCommunityDigestRepository) UpsertSettings(ctx context.Context, settings *models.CommunityDigestSettings) error {
+	args := m.Called(ctx, settings)
+	return args.Error(0)
+}
+
+func (m *MockCommunityDigestRepository) ListDueCommunities(ctx context.Context, weekStart time.Time, limit int) ([]models.Community, error) {
+	args := m.Called(ctx, weekStart, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Community), args.Error(1)
+}
+
+func (m *MockCommunityDigestRepository) ListTopClips(ctx context.Context, communityID uuid.UUID, since, until time.Time, limit int) ([]models.CommunityDigestClip, error) {
+	args := m.Called(ctx, communityID, since, until, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CommunityDigestClip), args.Error(1)
+}
+
+func (m *MockCommunityDigestRepository) ListTopContributors(ctx context.Context, communityID uuid.UUID, since, until time.Time, limit int) ([]models.CommunityDigestContributor, error) {
+	args := m.Called(ctx, communityID, since, until, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CommunityDigestContributor), args.Error(1)
+}
+
+func (m *MockCommunityDigestRepository) CountNewMembers(ctx context.Context, communityID uuid.UUID, since, until time.Time) (int, error) {
+	args := m.Called(ctx, communityID, since, until)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockCommunityDigestRepository) CreateDigest(ctx context.Context, digest *models.CommunityDigest, discussion *models.CommunityDiscussion) error {
+	args := m.Called(ctx, digest, discussion)
+	return args.Error(0)
+}
+
+func (m *MockCommunityDigestRepository) UnpinExpiredDigests(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func setupCommunityDigestServiceTest(now time.Time) (*CommunityDigestService, *MockCommunityDigestRepository) {
+	repo := new(MockCommunityDigestRepository)
+	svc := NewCommunityDigestService(repo)
+	svc.now = func() time.Time { return now }
+	return svc, repo
+}
+
+// expectCommunityDigestWeek serves the content of community's digest for the
+// week starting weekStart
+func expectCommunityDigestWeek(repo *MockCommunityDigestRepository, communityID uuid.UUID, weekStart time.Time, clips []models.CommunityDigestClip, contributors []models.CommunityDigestContributor, newMembers int) {
+	weekEnd := weekStart.AddDate(0, 0, 7)
+	repo.On("ListTopClips", mock.Anything, communityID, weekStart, weekEnd, communityDigestTopClips).Return(clips, nil)
+	repo.On("ListTopContributors", mock.Anything, communityID, weekStart, weekEnd, communityDigestTopContributors).Return(contributors, nil)
+	repo.On("CountNewMembers", mock.Anything, communityID, weekStart, weekEnd).Return(newMembers, nil)
+}
+
+func TestCommunityDigestWeekStart(t *testing.T) {
+	want := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	for _, now := range []time.Time{
+		time.Date(2026, 10, 12, 0, 30, 0, 0, time.UTC),  // Monday
+		time.Date(2026, 10, 14, 15, 0, 0, 0, time.UTC),  // Wednesday
+		time.Date(2026, 10, 18, 23, 59, 0, 0, time.UTC), // Sunday
+	} {
+		assert.Equal(t, want, communityDigestWeekStart(now), now.Weekday().String())
+	}
+}
+
+func TestCommunityDigestService_PostDueDigests(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	weekStart := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	svc, repo := setupCommunityDigestServiceTest(now)
+
+	community := models.Community{ID: uuid.New(), Name: "Speedrunners", OwnerID: uuid.New(), MemberCount: 60}
+	repo.On("UnpinExpiredDigests", ctx, now.Add(-communityDigestPinDuration)).Return(int64(0), nil)
+	repo.On("ListDueCommunities", ctx, weekStart, communityDigestBatchSize).Return([]models.Community{community}, nil)
+	expectCommunityDigestWeek(repo, community.ID, weekStart,
+		[]models.CommunityDigestClip{
+			{ClipID: uuid.New(), Title: "Any% world record", BroadcasterName: "runner", VoteScore: 88},
+		},
+		[]models.CommunityDigestContributor{
+			{UserID: uuid.New(), Username: "splits", Clips: 2, Comments: 3},
+		},
+		10)
+	repo.On("GetSettings", ctx, community.ID).Return(&models.CommunityDigestSettings{CommunityID: community.ID, Enabled: true}, nil)
+
+	var digest *models.CommunityDigest
+	var discussion *models.CommunityDiscussion
+	repo.On("CreateDigest", ctx, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			digest = args.Get(1).(*models.CommunityDigest)
+			discussion = args.Get(2).(*models.CommunityDiscussion)
+		}).
+		Return(nil).Once()
+
+	posted, err := svc.PostDueDigests(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, posted)
+
+	require.NotNil(t, discussion)
+	assert.True(t, discussion.IsPinned)
+	assert.Equal(t, community.OwnerID, discussion.UserID)
+	assert.Equal(t, "Speedrunners weekly digest: Oct 5 to Oct 11, 2026", discussion.Title)
+	assert.Contains(t, discussion.Content, "1. Any% world record by runner (88 votes)")
+	assert.Contains(t, discussion.Content, "- splits: 5 contributions")
+	assert.Contains(t, discussion.Content, "10 new members joined this week, for 60 in total (+20.0%)")
+	assert.Equal(t, weekStart, digest.WeekStart)
+
+	// A second run in the same week finds the digest already posted
+	repo.On("CreateDigest", ctx, mock.Anything, mock.Anything).Return(repository.ErrCommunityDigestExists).Once()
+	posted, err = svc.PostDueDigests(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, posted)
+
+	repo.AssertExpectations(t)
+}
+
+func TestCommunityDigestService_CustomTemplates(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	weekStart := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	svc, repo := setupCommunityDigestServiceTest(now)
+
+	community := models.Community{ID: uuid.New(), Name: "Clutch", OwnerID: uuid.New(), MemberCount: 5}
+	title := "This week in {{.CommunityName}}"
+	broken := "{{.CommunityName}} {{index .TopClips 3}}"
+	repo.On("UnpinExpiredDigests", ctx, mock.Anything).Return(int64(0), nil)
+	repo.On("ListDueCommunities", ctx, weekStart, communityDigestBatchSize).Return([]models.Community{community}, nil)
+	expectCommunityDigestWeek(repo, community.ID, weekStart, nil, nil, 0)
+	repo.On("GetSettings", ctx, community.ID).Return(&models.CommunityDigestSettings{
+		CommunityID: community.ID, Enabled: true, TitleTemplate: &title, BodyTemplate: &broken,
+	}, nil)
+
+	var discussion *models.CommunityDiscussion
+	repo.On("CreateDigest", ctx, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			discussion = args.Get(2).(*models.CommunityDiscussion)
+		}).
+		Return(nil).Once()
+
+	_, err := svc.PostDueDigests(ctx)
+	require.NoError(t, err)
+
+	require.NotNil(t, discussion)
+	assert.Equal(t, "This week in Clutch", discussion.Title)
+	// The body template fails with no clips, so the default is used
+	assert.Contains(t, discussion.Content, "No clips were added this week.")
+}
+
+func TestCommunityDigestService_UpdateSettings(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := setupCommunityDigestServiceTest(time.Now())
+	ownerID := uuid.New()
+	community := &models.Community{ID: uuid.New(), OwnerID: ownerID}
+	missing := uuid.New()
+	repo.On("GetCommunity", ctx, community.ID).Return(community, nil)
+	repo.On("GetCommunity", ctx, missing).Return(nil, repository.ErrCommunityNotFound)
+	defaults := func() *models.CommunityDigestSettings {
+		return &models.CommunityDigestSettings{CommunityID: community.ID, Enabled: true}
+	}
+
+	_, err := svc.UpdateSettings(ctx, community.ID, uuid.New(), &models.UpdateCommunityDigestSettingsRequest{})
+	assert.ErrorIs(t, err, ErrCommunityDigestForbidden)
+
+	_, err = svc.UpdateSettings(ctx, missing, ownerID, &models.UpdateCommunityDigestSettingsRequest{})
+	assert.ErrorIs(t, err, repository.ErrCommunityNotFound)
+
+	repo.On("GetSettings", ctx, community.ID).Return(defaults(), nil).Once()
+	invalid := "{{.Nope}}"
+	_, err = svc.UpdateSettings(ctx, community.ID, ownerID, &models.UpdateCommunityDigestSettingsRequest{BodyTemplate: &invalid})
+	assert.ErrorIs(t, err, ErrCommunityDigestInvalidTemplate)
+
+	repo.On("GetSettings", ctx, community.ID).Return(defaults(), nil).Once()
+	unclosed := "{{if .NewMembers}}growing"
+	_, err = svc.UpdateSettings(ctx, community.ID, ownerID, &models.UpdateCommunityDigestSettingsRequest{TitleTemplate: &unclosed})
+	assert.ErrorIs(t, err, ErrCommunityDigestInvalidTemplate)
+	repo.AssertNotCalled(t, "UpsertSettings", mock.Anything, mock.Anything)
+
+	disabled := false
+	title := "{{.CommunityName}} recap"
+	repo.On("GetSettings", ctx, community.ID).Return(defaults(), nil).Once()
+	repo.On("UpsertSettings", ctx, mock.AnythingOfType("*models.CommunityDigestSettings")).Return(nil)
+	settings, err := svc.UpdateSettings(ctx, community.ID, ownerID, &models.UpdateCommunityDigestSettingsRequest{
+		Enabled:       &disabled,
+		TitleTemplate: &title,
+	})
+	require.NoError(t, err)
+	assert.False(t, settings.Enabled)
+	require.NotNil(t, settings.TitleTemplate)
+	assert.Equal(t, title, *settings.TitleTemplate)
+
+	// An empty template resets to the default
+	stored := *settings
+	repo.On("GetSettings", ctx, community.ID).Return(&stored, nil).Once()
+	empty := " "
+	settings, err = svc.UpdateSettings(ctx, community.ID, ownerID, &models.UpdateCommunityDigestSettingsRequest{TitleTemplate: &empty})
+	require.NoError(t, err)
+	assert.Nil(t, settings.TitleTemplate)
+	assert.False(t, settings.Enabled)
+
+	repo.AssertExpectations(t)
+	repo.AssertNumberOfCalls(t, "UpsertSettings", 2)
+}
@@ -0,0 +1,344 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const (
+	// Defaults for rounds started without explicit limits
+	communityPickDefaultTopN           = 10
+	communityPickDefaultMaxNominations = 3
+	communityPickDefaultMaxVotes       = 5
+	// communityPickMaxDuration caps how long a round can take votes
+	communityPickMaxDuration = 60 * 24 * time.Hour
+	// communityPickPublishBatchSize caps how many due rounds are published per run
+	communityPickPublishBatchSize = 20
+
+	// Vote weights. Votes from accounts created after the round opened, from
+	// new accounts and from low-trust accounts count for less, which blunts
+	// brigades of fresh or throwaway accounts.
+	communityPickWeightJoinedDuringRound = 0.1
+	communityPickWeightNewAccount        = 0.25
+	communityPickWeightYoungAccount      = 0.5
+	communityPickLowTrustMultiplier      = 0.5
+	communityPickNewAccountAge           = 7 * 24 * time.Hour
+	communityPickYoungAccountAge         = 30 * 24 * time.Hour
+	communityPickLowTrustScore           = 30
+)
+
+var (
+	// ErrCommunityPickInvalidDeadline is returned for a voting deadline in the past or too far out
+	ErrCommunityPickInvalidDeadline = errors.New("voting must end in the future and within 60 days")
+	// ErrCommunityPickClosed is returned when nominating or voting after a round closed
+	ErrCommunityPickClosed = errors.New("community pick round is closed")
+	// ErrCommunityPickNominationLimit is returned when a user has used all their nominations
+	ErrCommunityPickNominationLimit = errors.New("nomination limit reached for this round")
+	// ErrCommunityPickVoteLimit is returned when a user has used all their votes
+	ErrCommunityPickVoteLimit = errors.New("vote limit reached for this round")
+	// ErrCommunityPickOwnNomination is returned when a user votes for their own nomination
+	ErrCommunityPickOwnNomination = errors.New("cannot vote for your own nomination")
+	// ErrCommunityPickClipAlreadyListed is returned when a nominated clip is already on the list
+	ErrCommunityPickClipAlreadyListed = errors.New("clip is already on this list")
+	// ErrCommunityPickResultsPending is returned for the results of a round that wasn't published
+	ErrCommunityPickResultsPending = errors.New("results are not available until the round is published")
+)
+
+// CommunityPickRepositoryInterface defines the repository methods used by CommunityPickService
+type CommunityPickRepositoryInterface interface {
+	CreateRound(ctx context.Context, round *models.CommunityPickRound) error
+	GetRound(ctx context.Context, roundID uuid.UUID) (*models.CommunityPickRound, error)
+	ListRoundsForList(ctx context.Context, listID uuid.UUID, limit, offset int) ([]models.CommunityPickRound, error)
+	ListDueRounds(ctx context.Context, now time.Time, limit int) ([]models.CommunityPickRound, error)
+	CancelRound(ctx context.Context, roundID uuid.UUID) error
+	CreateNomination(ctx context.Context, nomination *models.CommunityPickNomination) error
+	GetNomination(ctx context.Context, nominationID uuid.UUID) (*models.CommunityPickNomination, error)
+	ListNominations(ctx context.Context, roundID uuid.UUID, userID *uuid.UUID) ([]models.CommunityPickNomination, error)
+	IsClipInList(ctx context.Context, listID, clipID uuid.UUID) (bool, error)
+	CountNominationsByUser(ctx context.Context, roundID, userID uuid.UUID) (int, error)
+	CountVotesByUser(ctx context.Context, roundID, userID uuid.UUID) (int, error)
+	CreateVote(ctx context.Context, vote *models.CommunityPickVote) error
+	DeleteVote(ctx context.Context, nominationID, userID uuid.UUID) error
+	GetTallies(ctx context.Context, roundID uuid.UUID) ([]models.CommunityPickTally, error)
+	PublishRound(ctx context.Context, round *models.CommunityPickRound, results []models.CommunityPickResult) error
+	ListResults(ctx context.Context, roundID uuid.UUID) ([]models.CommunityPickResult, error)
+}
+
+// CommunityPickVoterSource looks up voters to weight their votes
+type CommunityPickVoterSource interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+}
+
+// CommunityPickService runs community nomination rounds for discovery lists
+type CommunityPickService struct {
+	repo   CommunityPickRepositoryInterface
+	voters CommunityPickVoterSource
+	now    func() time.Time
+}
+
+// NewCommunityPickService creates a new CommunityPickService
+func NewCommunityPickService(repo CommunityPickRepositoryInterface, voters CommunityPickVoterSource) *CommunityPickService {
+	return &CommunityPickService{
+		repo:   repo,
+		voters: voters,
+		now:    time.Now,
+	}
+}
+
+// CreateRound starts a community pick round for a discovery list
+func (s *CommunityPickService) CreateRound(ctx context.Context, listID, adminID uuid.UUID, req *models.CreateCommunityPickRoundRequest) (*models.CommunityPickRound, error) {
+	now := s.now()
+	if !req.VotingEndsAt.After(now) || req.VotingEndsAt.Sub(now) > communityPickMaxDuration {
+		return nil, ErrCommunityPickInvalidDeadline
+	}
+
+	round := &models.CommunityPickRound{
+		ListID:                listID,
+		Theme:                 req.Theme,
+		Description:           req.Description,
+		TopN:                  communityPickDefaultTopN,
+		MaxNominationsPerUser: communityPickDefaultMaxNominations,
+		MaxVotesPerUser:       communityPickDefaultMaxVotes,
+		VotingEndsAt:          req.VotingEndsAt,
+		CreatedBy:             &adminID,
+	}
+	if req.TopN != nil {
+		round.TopN = *req.TopN
+	}
+	if req.MaxNominationsPerUser != nil {
+		round.MaxNominationsPerUser = *req.MaxNominationsPerUser
+	}
+	if req.MaxVotesPerUser != nil {
+		round.MaxVotesPerUser = *req.MaxVotesPerUser
+	}
+
+	if err := s.repo.CreateRound(ctx, round); err != nil {
+		return nil, err
+	}
+	return round, nil
+}
+
+// GetRound returns a round with its nominations. userID, when given, marks
+// the nominations the user voted for.
+func (s *CommunityPickService) GetRound(ctx context.Context, roundID uuid.UUID, userID *uuid.UUID) (*models.CommunityPickRoundDetail, error) {
+	round, err := s.repo.GetRound(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+
+	nominations, err := s.repo.ListNominations(ctx, roundID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.CommunityPickRoundDetail{
+		CommunityPickRound: *round,
+		Nominations:        nominations,
+	}, nil
+}
+
+// ListRounds returns a discovery list's rounds, newest first
+func (s *CommunityPickService) ListRounds(ctx context.Context, listID uuid.UUID, limit, offset int) ([]models.CommunityPickRound, error) {
+	return s.repo.ListRoundsForList(ctx, listID, limit, offset)
+}
+
+// CancelRound closes a round without publishing any picks
+func (s *CommunityPickService) CancelRound(ctx context.Context, roundID uuid.UUID) error {
+	return s.repo.CancelRound(ctx, roundID)
+}
+
+// Nominate nominates a clip to an open round
+func (s *CommunityPickService) Nominate(ctx context.Context, roundID, userID, clipID uuid.UUID) (*models.CommunityPickNomination, error) {
+	round, err := s.openRound(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.repo.CountNominationsByUser(ctx, roundID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= round.MaxNominationsPerUser {
+		return nil, ErrCommunityPickNominationLimit
+	}
+
+	listed, err := s.repo.IsClipInList(ctx, round.ListID, clipID)
+	if err != nil {
+		return nil, err
+	}
+	if listed {
+		return nil, ErrCommunityPickClipAlreadyListed
+	}
+
+	nomination := &models.CommunityPickNomination{
+		RoundID:     roundID,
+		ClipID:      clipID,
+		NominatedBy: &userID,
+	}
+	if err := s.repo.CreateNomination(ctx, nomination); err != nil {
+		return nil, err
+	}
+	return nomination, nil
+}
+
+// Vote casts a user's vote for a nomination in an open round
+func (s *CommunityPickService) Vote(ctx context.Context, nominationID, userID uuid.UUID) (*models.CommunityPickVote, error) {
+	nomination, err := s.repo.GetNomination(ctx, nominationID)
+	if err != nil {
+		return nil, err
+	}
+	if nomination.NominatedBy != nil && *nomination.NominatedBy == userID {
+		return nil, ErrCommunityPickOwnNomination
+	}
+
+	round, err := s.openRound(ctx, nomination.RoundID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.repo.CountVotesByUser(ctx, round.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= round.MaxVotesPerUser {
+		return nil, ErrCommunityPickVoteLimit
+	}
+
+	voter, err := s.voters.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	vote := &models.CommunityPickVote{
+		RoundID:      round.ID,
+		NominationID: nominationID,
+		UserID:       userID,
+		Weight:       communityPickVoteWeight(voter, round, s.now()),
+	}
+	if err := s.repo.CreateVote(ctx, vote); err != nil {
+		return nil, err
+	}
+	return vote, nil
+}
+
+// Unvote retracts a user's vote while the round is open
+func (s *CommunityPickService) Unvote(ctx context.Context, nominationID, userID uuid.UUID) error {
+	nomination, err := s.repo.GetNomination(ctx, nominationID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.openRound(ctx, nomination.RoundID); err != nil {
+		return err
+	}
+	return s.repo.DeleteVote(ctx, nominationID, userID)
+}
+
+// GetResults returns the final standings of a published round
+func (s *CommunityPickService) GetResults(ctx context.Context, roundID uuid.UUID) ([]models.CommunityPickResult, error) {
+	round, err := s.repo.GetRound(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+	if round.Status != models.CommunityPickStatusPublished {
+		return nil, ErrCommunityPickResultsPending
+	}
+	return s.repo.ListResults(ctx, roundID)
+}
+
+// PublishDueRounds publishes rounds whose voting has ended, returning how
+// many were published. A round that fails is retried on the next run.
+func (s *CommunityPickService) PublishDueRounds(ctx context.Context) (int, error) {
+	rounds, err := s.repo.ListDueRounds(ctx, s.now(), communityPickPublishBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for i := range rounds {
+		round := &rounds[i]
+		tallies, err := s.repo.GetTallies(ctx, round.ID)
+		if err != nil {
+			utils.GetLogger().Error("Failed to tally community pick round", err, map[string]interface{}{
+				"round_id": round.ID.String(),
+			})
+			continue
+		}
+
+		results := rankCommunityPicks(round.ID, tallies, round.TopN)
+		if err := s.repo.PublishRound(ctx, round, results); err != nil {
+			utils.GetLogger().Error("Failed to publish community pick round", err, map[string]interface{}{
+				"round_id": round.ID.String(),
+			})
+			continue
+		}
+		published++
+	}
+
+	return published, nil
+}
+
+// openRound returns a round if it still takes nominations and votes
+func (s *CommunityPickService) openRound(ctx context.Context, roundID uuid.UUID) (*models.CommunityPickRound, error) {
+	round, err := s.repo.GetRound(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+	if !round.IsOpenAt(s.now()) {
+		return nil, ErrCommunityPickClosed
+	}
+	return round, nil
+}
+
+// communityPickVoteWeight is how much a vote counts towards a nomination's
+// score, based on the voter's account age and trust score
+func communityPickVoteWeight(voter *models.User, round *models.CommunityPickRound, now time.Time) float64 {
+	weight := 1.0
+	switch age := now.Sub(voter.CreatedAt); {
+	case voter.CreatedAt.After(round.CreatedAt):
+		weight = communityPickWeightJoinedDuringRound
+	case age < communityPickNewAccountAge:
+		weight = communityPickWeightNewAccount
+	case age < communityPickYoungAccountAge:
+		weight = communityPickWeightYoungAccount
+	}
+	if voter.TrustScore < communityPickLowTrustScore {
+		weight *= communityPickLowTrustMultiplier
+	}
+	return weight
+}
+
+// rankCommunityPicks orders nominations by weighted score, then raw votes,
+// then earliest nomination, and picks the top N that received any votes
+func rankCommunityPicks(roundID uuid.UUID, tallies []models.CommunityPickTally, topN int) []models.CommunityPickResult {
+	sorted := make([]models.CommunityPickTally, len(tallies))
+	copy(sorted, tallies)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].WeightedScore != sorted[j].WeightedScore {
+			return sorted[i].WeightedScore > sorted[j].WeightedScore
+		}
+		if sorted[i].VoteCount != sorted[j].VoteCount {
+			return sorted[i].VoteCount > sorted[j].VoteCount
+		}
+		return sorted[i].NominatedAt.Before(sorted[j].NominatedAt)
+	})
+
+	results := make([]models.CommunityPickResult, 0, len(sorted))
+	for i, t := range sorted {
+		rank := i + 1
+		results = append(results, models.CommunityPickResult{
+			RoundID:       roundID,
+			ClipID:        t.ClipID,
+			Rank:          rank,
+			VoteCount:     t.VoteCount,
+			WeightedScore: t.WeightedScore,
+			Picked:        rank <= topN && t.VoteCount > 0,
+		})
+	}
+	return results
+}
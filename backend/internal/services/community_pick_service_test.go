@@ -0,0 +1,391 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+// MockCommunityPickRepository is a mock implementation of CommunityPickRepositoryInterface
+type MockCommunityPickRepository struct {
+	mock.Mock
+}
+
+func (m *MockCommunityPickRepository) CreateRound(ctx context.Context, round *models.CommunityPickRound) error {
+	args := m.Called(ctx, round)
+	return args.Error(0)
+}
+
+func (m *MockCommunityPickRepository) GetRound(ctx context.Context, roundID uuid.UUID) (*models.CommunityPickRound, error) {
+	args := m.Called(ctx, roundID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CommunityPickRound), args.Error(1)
+}
+
+func (m *MockCommunityPickRepository) ListRoundsForList(ctx context.Context, listID uuid.UUID, limit, offset int) ([]models.CommunityPickRound, error) {
+	args := m.Called(ctx, listID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CommunityPickRound), args.Error(1)
+}
+
+func (m *MockCommunityPickRepository) ListDueRounds(ctx context.Context, now time.Time, limit int) ([]models.CommunityPickRound, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CommunityPickRound), args.Error(1)
+}
+
+func (m *MockCommunityPickRepository) CancelRound(ctx context.Context, roundID uuid.UUID) error {
+	args := m.Called(ctx, roundID)
+	return args.Error(0)
+}
+
+func (m *MockCommunityPickRepository) CreateNomination(ctx context.Context, nomination *models.CommunityPickNomination) error {
+	args := m.Called(ctx, nomination)
+	return args.Error(0)
+}
+
+func (m *MockCommunityPickRepository) GetNomination(ctx context.Context, nominationID uuid.UUID) (*models.CommunityPickNomination, error) {
+	args := m.Called(ctx, nominationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CommunityPickNomination), args.Error(1)
+}
+
+func (m *MockCommunityPickRepository) ListNominations(ctx context.Context, roundID uuid.UUID, userID *uuid.UUID) ([]models.CommunityPickNomination, error) {
+	args := m.Called(ctx, roundID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CommunityPickNomination), args.Error(1)
+}
+
+func (m *MockCommunityPickRepository) IsClipInList(ctx context.Context, listID, clipID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, listID, clipID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCommunityPickRepository) CountNominationsByUser(ctx context.Context, roundID, userID uuid.UUID) (int, error) {
+	args := m.Called(ctx, roundID, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockCommunityPickRepository) CountVotesByUser(ctx context.Context, roundID, userID uuid.UUID) (int, error) {
+	args := m.Called(ctx, roundID, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockCommunityPickRepository) CreateVote(ctx context.Context, vote *models.CommunityPickVote) error {
+	args := m.Called(ctx, vote)
+	return args.Error(0)
+}
+
+func (m *MockCommunityPickRepository) DeleteVote(ctx context.Context, nominationID, userID uuid.UUID) error {
+	args := m.Called(ctx, nominationID, userID)
+	return args.Error(0)
+}
+
+func (m *MockCommunityPickRepository) GetTallies(ctx context.Context, roundID uuid.UUID) ([]models.CommunityPickTally, error) {
+	args := m.Called(ctx, roundID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CommunityPickTally), args.Error(1)
+}
+
+func (m *MockCommunityPickRepository) PublishRound(ctx context.Context, round *models.CommunityPickRound, results []models.CommunityPickResult) error {
+	args := m.Called(ctx, round, results)
+	return args.Error(0)
+}
+
+func (m *MockCommunityPickRepository) ListResults(ctx context.Context, roundID uuid.UUID) ([]models.CommunityPickResult, error) {
+	args := m.Called(ctx, roundID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CommunityPickResult), args.Error(1)
+}
+
+func setupCommunityPickServiceTest() (*CommunityPickService, *MockCommunityPickRepository, *MockUserRepository) {
+	repo := new(MockCommunityPickRepository)
+	voters := new(MockUserRepository)
+	return NewCommunityPickService(repo, voters), repo, voters
+}
+
+// openCommunityPickRound returns a round that allows two nominations and two
+// votes per user and is open for another day
+func openCommunityPickRound() *models.CommunityPickRound {
+	return &models.CommunityPickRound{
+		ID:                    uuid.New(),
+		ListID:                uuid.New(),
+		Status:                models.CommunityPickStatusOpen,
+		TopN:                  3,
+		MaxNominationsPerUser: 2,
+		MaxVotesPerUser:       2,
+		VotingEndsAt:          time.Now().Add(24 * time.Hour),
+		CreatedAt:             time.Now().Add(-time.Hour),
+	}
+}
+
+func nominationBy(userID uuid.UUID) interface{} {
+	return mock.MatchedBy(func(n *models.CommunityPickNomination) bool {
+		return n.NominatedBy != nil && *n.NominatedBy == userID
+	})
+}
+
+func communityPickVoteFor(nominationID uuid.UUID) interface{} {
+	return mock.MatchedBy(func(v *models.CommunityPickVote) bool {
+		return v.NominationID == nominationID
+	})
+}
+
+func TestCommunityPickCreateRoundValidatesDeadline(t *testing.T) {
+	svc, repo, _ := setupCommunityPickServiceTest()
+	ctx := context.Background()
+
+	repo.On("CreateRound", ctx, mock.AnythingOfType("*models.CommunityPickRound")).Return(nil).Once()
+
+	_, err := svc.CreateRound(ctx, uuid.New(), uuid.New(), &models.CreateCommunityPickRoundRequest{
+		Theme:        "Past",
+		VotingEndsAt: time.Now().Add(-time.Hour),
+	})
+	assert.ErrorIs(t, err, ErrCommunityPickInvalidDeadline)
+
+	_, err = svc.CreateRound(ctx, uuid.New(), uuid.New(), &models.CreateCommunityPickRoundRequest{
+		Theme:        "Too long",
+		VotingEndsAt: time.Now().Add(90 * 24 * time.Hour),
+	})
+	assert.ErrorIs(t, err, ErrCommunityPickInvalidDeadline)
+
+	round, err := svc.CreateRound(ctx, uuid.New(), uuid.New(), &models.CreateCommunityPickRoundRequest{
+		Theme:        "Defaults",
+		VotingEndsAt: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, communityPickDefaultTopN, round.TopN)
+	assert.Equal(t, communityPickDefaultMaxNominations, round.MaxNominationsPerUser)
+	assert.Equal(t, communityPickDefaultMaxVotes, round.MaxVotesPerUser)
+
+	repo.AssertExpectations(t)
+}
+
+func TestCommunityPickNominate(t *testing.T) {
+	svc, repo, _ := setupCommunityPickServiceTest()
+	ctx := context.Background()
+	round := openCommunityPickRound()
+	userID, otherID := uuid.New(), uuid.New()
+	clipID, listed := uuid.New(), uuid.New()
+
+	repo.On("GetRound", ctx, round.ID).Return(round, nil)
+	repo.On("IsClipInList", ctx, round.ListID, listed).Return(true, nil)
+	repo.On("IsClipInList", ctx, round.ListID, mock.Anything).Return(false, nil)
+	repo.On("CountNominationsByUser", ctx, round.ID, otherID).Return(0, nil)
+	repo.On("CountNominationsByUser", ctx, round.ID, userID).Return(0, nil).Once()
+	repo.On("CountNominationsByUser", ctx, round.ID, userID).Return(1, nil).Twice()
+	repo.On("CountNominationsByUser", ctx, round.ID, userID).Return(2, nil).Once()
+	repo.On("CreateNomination", ctx, nominationBy(otherID)).Return(repository.ErrCommunityPickAlreadyNominated)
+	repo.On("CreateNomination", ctx, nominationBy(userID)).Return(nil)
+
+	nomination, err := svc.Nominate(ctx, round.ID, userID, clipID)
+	require.NoError(t, err)
+	assert.Equal(t, clipID, nomination.ClipID)
+
+	_, err = svc.Nominate(ctx, round.ID, otherID, clipID)
+	assert.ErrorIs(t, err, repository.ErrCommunityPickAlreadyNominated)
+
+	_, err = svc.Nominate(ctx, round.ID, userID, listed)
+	assert.ErrorIs(t, err, ErrCommunityPickClipAlreadyListed)
+
+	_, err = svc.Nominate(ctx, round.ID, userID, uuid.New())
+	require.NoError(t, err)
+	_, err = svc.Nominate(ctx, round.ID, userID, uuid.New())
+	assert.ErrorIs(t, err, ErrCommunityPickNominationLimit)
+
+	repo.AssertExpectations(t)
+	repo.AssertNumberOfCalls(t, "CreateNomination", 3)
+}
+
+func TestCommunityPickNominateClosedRound(t *testing.T) {
+	svc, repo, _ := setupCommunityPickServiceTest()
+	ctx := context.Background()
+
+	ended := openCommunityPickRound()
+	ended.VotingEndsAt = time.Now().Add(-time.Minute)
+	cancelled := openCommunityPickRound()
+	cancelled.Status = models.CommunityPickStatusCancelled
+
+	repo.On("GetRound", ctx, ended.ID).Return(ended, nil)
+	repo.On("GetRound", ctx, cancelled.ID).Return(cancelled, nil)
+
+	_, err := svc.Nominate(ctx, ended.ID, uuid.New(), uuid.New())
+	assert.ErrorIs(t, err, ErrCommunityPickClosed)
+
+	_, err = svc.Nominate(ctx, cancelled.ID, uuid.New(), uuid.New())
+	assert.ErrorIs(t, err, ErrCommunityPickClosed)
+
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "CreateNomination", mock.Anything, mock.Anything)
+}
+
+func TestCommunityPickVote(t *testing.T) {
+	svc, repo, voters := setupCommunityPickServiceTest()
+	ctx := context.Background()
+	round := openCommunityPickRound()
+
+	nominator, otherNominator, voter := uuid.New(), uuid.New(), uuid.New()
+	first := &models.CommunityPickNomination{ID: uuid.New(), RoundID: round.ID, NominatedBy: &nominator}
+	second := &models.CommunityPickNomination{ID: uuid.New(), RoundID: round.ID, NominatedBy: &nominator}
+	third := &models.CommunityPickNomination{ID: uuid.New(), RoundID: round.ID, NominatedBy: &otherNominator}
+
+	repo.On("GetRound", ctx, round.ID).Return(round, nil)
+	for _, n := range []*models.CommunityPickNomination{first, second, third} {
+		repo.On("GetNomination", ctx, n.ID).Return(n, nil)
+	}
+	voters.On("GetByID", ctx, voter).Return(&models.User{ID: voter, CreatedAt: time.Now().Add(-365 * 24 * time.Hour), TrustScore: 80}, nil)
+
+	_, err := svc.Vote(ctx, first.ID, nominator)
+	assert.ErrorIs(t, err, ErrCommunityPickOwnNomination)
+
+	repo.On("CountVotesByUser", ctx, round.ID, voter).Return(0, nil).Once()
+	repo.On("CreateVote", ctx, communityPickVoteFor(first.ID)).Return(nil).Once()
+	vote, err := svc.Vote(ctx, first.ID, voter)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, vote.Weight)
+
+	repo.On("CountVotesByUser", ctx, round.ID, voter).Return(1, nil).Twice()
+	repo.On("CreateVote", ctx, communityPickVoteFor(first.ID)).Return(repository.ErrCommunityPickAlreadyVoted).Once()
+	_, err = svc.Vote(ctx, first.ID, voter)
+	assert.ErrorIs(t, err, repository.ErrCommunityPickAlreadyVoted)
+
+	repo.On("CreateVote", ctx, communityPickVoteFor(second.ID)).Return(nil).Once()
+	_, err = svc.Vote(ctx, second.ID, voter)
+	require.NoError(t, err)
+
+	// Third nominee would exceed the two-vote limit
+	repo.On("CountVotesByUser", ctx, round.ID, voter).Return(2, nil).Once()
+	_, err = svc.Vote(ctx, third.ID, voter)
+	assert.ErrorIs(t, err, ErrCommunityPickVoteLimit)
+
+	// Retracting a vote frees it up
+	repo.On("DeleteVote", ctx, first.ID, voter).Return(nil).Once()
+	require.NoError(t, svc.Unvote(ctx, first.ID, voter))
+	repo.On("CountVotesByUser", ctx, round.ID, voter).Return(1, nil).Once()
+	repo.On("CreateVote", ctx, communityPickVoteFor(third.ID)).Return(nil).Once()
+	_, err = svc.Vote(ctx, third.ID, voter)
+	require.NoError(t, err)
+
+	repo.AssertExpectations(t)
+	voters.AssertNotCalled(t, "GetByID", ctx, nominator)
+}
+
+func TestCommunityPickVoteWeight(t *testing.T) {
+	now := time.Now()
+	round := &models.CommunityPickRound{CreatedAt: now.Add(-48 * time.Hour)}
+
+	tests := []struct {
+		name   string
+		age    time.Duration
+		trust  int
+		weight float64
+	}{
+		{"established", 365 * 24 * time.Hour, 80, 1.0},
+		{"established low trust", 365 * 24 * time.Hour, 10, 0.5},
+		{"young account", 20 * 24 * time.Hour, 80, 0.5},
+		{"new account", 3 * 24 * time.Hour, 80, 0.25},
+		{"joined during round", 24 * time.Hour, 80, 0.1},
+		{"joined during round low trust", 24 * time.Hour, 0, 0.05},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			voter := &models.User{CreatedAt: now.Add(-tt.age), TrustScore: tt.trust}
+			assert.InDelta(t, tt.weight, communityPickVoteWeight(voter, round, now), 1e-9)
+		})
+	}
+}
+
+func TestRankCommunityPicks(t *testing.T) {
+	roundID := uuid.New()
+	now := time.Now()
+	a, b, c, d := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	results := rankCommunityPicks(roundID, []models.CommunityPickTally{
+		{ClipID: a, VoteCount: 10, WeightedScore: 2.5, NominatedAt: now},
+		{ClipID: b, VoteCount: 4, WeightedScore: 4.0, NominatedAt: now},
+		{ClipID: c, VoteCount: 12, WeightedScore: 2.5, NominatedAt: now},
+		{ClipID: d, VoteCount: 0, WeightedScore: 0, NominatedAt: now.Add(-time.Hour)},
+	}, 3)
+
+	require.Len(t, results, 4)
+	assert.Equal(t, []uuid.UUID{b, c, a, d}, []uuid.UUID{results[0].ClipID, results[1].ClipID, results[2].ClipID, results[3].ClipID})
+	for i, res := range results {
+		assert.Equal(t, i+1, res.Rank)
+	}
+	assert.True(t, results[2].Picked)
+	assert.False(t, results[3].Picked, "clips without votes are never picked")
+}
+
+func TestCommunityPickPublishDueRounds(t *testing.T) {
+	svc, repo, _ := setupCommunityPickServiceTest()
+	ctx := context.Background()
+	round := openCommunityPickRound()
+
+	repo.On("ListDueRounds", ctx, mock.Anything, communityPickPublishBatchSize).Return([]models.CommunityPickRound{}, nil).Once()
+	n, err := svc.PublishDueRounds(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	repo.On("GetRound", ctx, round.ID).Return(round, nil).Once()
+	_, err = svc.GetResults(ctx, round.ID)
+	assert.ErrorIs(t, err, ErrCommunityPickResultsPending)
+
+	due := *round
+	due.VotingEndsAt = time.Now().Add(-time.Minute)
+	var published []models.CommunityPickResult
+	repo.On("ListDueRounds", ctx, mock.Anything, communityPickPublishBatchSize).Return([]models.CommunityPickRound{due}, nil).Once()
+	repo.On("GetTallies", ctx, round.ID).Return([]models.CommunityPickTally{
+		{ClipID: uuid.New(), VoteCount: 3, WeightedScore: 3},
+	}, nil).Once()
+	repo.On("PublishRound", ctx, mock.MatchedBy(func(r *models.CommunityPickRound) bool { return r.ID == round.ID }), mock.Anything).
+		Run(func(args mock.Arguments) {
+			published = args.Get(2).([]models.CommunityPickResult)
+		}).
+		Return(nil).Once()
+
+	n, err = svc.PublishDueRounds(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	require.Len(t, published, 1)
+	assert.True(t, published[0].Picked)
+
+	publishedRound := *round
+	publishedRound.Status = models.CommunityPickStatusPublished
+	repo.On("GetRound", ctx, round.ID).Return(&publishedRound, nil).Once()
+	repo.On("ListResults", ctx, round.ID).Return(published, nil).Once()
+	results, err := svc.GetResults(ctx, round.ID)
+	require.NoError(t, err)
+	assert.Equal(t, published, results)
+
+	// A round that fails to publish is left for the next run
+	repo.On("ListDueRounds", ctx, mock.Anything, communityPickPublishBatchSize).Return([]models.CommunityPickRound{due}, nil).Once()
+	repo.On("GetTallies", ctx, round.ID).Return([]models.CommunityPickTally{}, nil).Once()
+	repo.On("PublishRound", ctx, mock.Anything, mock.Anything).Return(repository.ErrCommunityPickRoundNotOpen).Once()
+	n, err = svc.PublishDueRounds(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	repo.AssertExpectations(t)
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -17,6 +18,8 @@ type CommunityService struct {
 	clipRepo      *repository.ClipRepository
 	userRepo      *repository.UserRepository
 	notifService  *NotificationService
+	uploadService *UploadService
+	markdown      *MarkdownRenderer
 }
 
 func NewCommunityService(
@@ -24,15 +27,68 @@ func NewCommunityService(
 	clipRepo *repository.ClipRepository,
 	userRepo *repository.UserRepository,
 	notifService *NotificationService,
+	markdown *MarkdownRenderer,
 ) *CommunityService {
 	return &CommunityService{
 		communityRepo: communityRepo,
 		clipRepo:      clipRepo,
 		userRepo:      userRepo,
 		notifService:  notifService,
+		markdown:      markdown,
 	}
 }
 
+// SetUploadService enables uploaded community assets such as banners
+func (s *CommunityService) SetUploadService(uploadService *UploadService) {
+	s.uploadService = uploadService
+}
+
+// ErrCommunityAssetsForbidden is returned when a user may not manage a
+// community's assets
+var ErrCommunityAssetsForbidden = errors.New("unauthorized to manage this community's assets")
+
+// maxCommunityAssets caps the uploaded assets of one community
+const maxCommunityAssets = 20
+
+// ListAssets returns a community's uploaded assets
+func (s *CommunityService) ListAssets(ctx context.Context, communityID uuid.UUID) ([]models.Upload, error) {
+	return s.uploadService.ListAttached(ctx, "community", communityID.String())
+}
+
+// AddAsset attaches a completed community_asset upload to a community
+func (s *CommunityService) AddAsset(ctx context.Context, communityID, userID, uploadID uuid.UUID) (*models.Upload, error) {
+	hasPermission, err := s.HasPermission(ctx, communityID, userID, models.CommunityRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrCommunityAssetsForbidden
+	}
+
+	assets, err := s.ListAssets(ctx, communityID)
+	if err != nil {
+		return nil, err
+	}
+	if len(assets) >= maxCommunityAssets {
+		return nil, fmt.Errorf("%w: communities can have at most %d assets", ErrUploadInvalid, maxCommunityAssets)
+	}
+
+	return s.uploadService.Attach(ctx, userID, uploadID, models.UploadContextCommunityAsset, "community", communityID.String())
+}
+
+// RemoveAsset detaches an asset from a community; the cleanup job deletes it
+func (s *CommunityService) RemoveAsset(ctx context.Context, communityID, userID, uploadID uuid.UUID) error {
+	hasPermission, err := s.HasPermission(ctx, communityID, userID, models.CommunityRoleAdmin)
+	if err != nil {
+		return err
+	}
+	if !hasPermission {
+		return ErrCommunityAssetsForbidden
+	}
+
+	return s.uploadService.Detach(ctx, uploadID, "community", communityID.String())
+}
+
 // Role hierarchy levels (admin > mod > member)
 var roleHierarchy = map[string]int{
 	models.CommunityRoleAdmin:  3,
@@ -492,17 +548,18 @@ func (s *CommunityService) CreateDiscussion(ctx context.Context, communityID, us
 	}
 
 	discussion := &models.CommunityDiscussion{
-		ID:           uuid.New(),
-		CommunityID:  communityID,
-		UserID:       userID,
-		Title:        req.Title,
-		Content:      req.Content,
-		IsPinned:     false,
-		IsResolved:   false,
-		VoteScore:    0,
-		CommentCount: 0,
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
+		ID:              uuid.New(),
+		CommunityID:     communityID,
+		UserID:          userID,
+		Title:           req.Title,
+		Content:         req.Content,
+		RenderedContent: s.markdown.Render(req.Content),
+		IsPinned:        false,
+		IsResolved:      false,
+		VoteScore:       0,
+		CommentCount:    0,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
 	}
 
 	err = s.communityRepo.CreateDiscussion(ctx, discussion)
@@ -515,13 +572,32 @@ func (s *CommunityService) CreateDiscussion(ctx context.Context, communityID, us
 
 // GetDiscussion retrieves a discussion thread
 func (s *CommunityService) GetDiscussion(ctx context.Context, discussionID uuid.UUID) (*models.CommunityDiscussion, error) {
-	return s.communityRepo.GetDiscussion(ctx, discussionID)
+	discussion, err := s.communityRepo.GetDiscussion(ctx, discussionID)
+	if err != nil {
+		return nil, err
+	}
+	s.renderDiscussion(discussion)
+	return discussion, nil
 }
 
 // ListDiscussions retrieves discussions for a community
 func (s *CommunityService) ListDiscussions(ctx context.Context, communityID uuid.UUID, sort string, page, limit int) ([]*models.CommunityDiscussion, int, error) {
 	offset := (page - 1) * limit
-	return s.communityRepo.ListDiscussions(ctx, communityID, sort, limit, offset)
+	discussions, total, err := s.communityRepo.ListDiscussions(ctx, communityID, sort, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, discussion := range discussions {
+		s.renderDiscussion(discussion)
+	}
+	return discussions, total, nil
+}
+
+// renderDiscussion renders discussions written before rendered HTML was stored
+func (s *CommunityService) renderDiscussion(discussion *models.CommunityDiscussion) {
+	if discussion.RenderedContent == "" {
+		discussion.RenderedContent = s.markdown.Render(discussion.Content)
+	}
 }
 
 // UpdateDiscussion updates a discussion thread
@@ -558,6 +634,8 @@ func (s *CommunityService) UpdateDiscussion(ctx context.Context, discussionID, u
 		discussion.IsResolved = *req.IsResolved
 	}
 
+	discussion.RenderedContent = s.markdown.Render(discussion.Content)
+
 	err = s.communityRepo.UpdateDiscussion(ctx, discussion)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update discussion: %w", err)
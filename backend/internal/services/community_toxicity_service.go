@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+// ErrCommunityToxicityForbidden is returned when a user who doesn't moderate a
+// community reads or changes its toxicity settings
+var ErrCommunityToxicityForbidden = errors.New("only community owners and moderators can manage toxicity settings")
+
+// ToxicityThresholds decides the toxicity threshold comments on a clip are
+// flagged at. Implemented by CommunityToxicityService.
+type ToxicityThresholds interface {
+	ThresholdForClip(ctx context.Context, clipID uuid.UUID) (threshold float64, enabled bool)
+}
+
+// CommunityToxicityRepositoryInterface defines the repository methods used by CommunityToxicityService
+type CommunityToxicityRepositoryInterface interface {
+	GetCommunityOwner(ctx context.Context, communityID uuid.UUID) (uuid.UUID, error)
+	GetMemberRole(ctx context.Context, communityID, userID uuid.UUID) (string, error)
+	GetSettings(ctx context.Context, communityID uuid.UUID) (*models.CommunityToxicitySettings, error)
+	UpsertSettings(ctx context.Context, settings *models.CommunityToxicitySettings) error
+	ListClipSensitivities(ctx context.Context, clipID uuid.UUID) ([]string, error)
+}
+
+// CommunityToxicityService manages per-community toxicity sensitivity and
+// turns it into the threshold comments are auto-flagged at
+type CommunityToxicityService struct {
+	repo          CommunityToxicityRepositoryInterface
+	baseThreshold float64
+}
+
+// NewCommunityToxicityService creates a new CommunityToxicityService.
+// baseThreshold is the site-wide threshold, used at medium sensitivity.
+func NewCommunityToxicityService(repo CommunityToxicityRepositoryInterface, baseThreshold float64) *CommunityToxicityService {
+	return &CommunityToxicityService{
+		repo:          repo,
+		baseThreshold: baseThreshold,
+	}
+}
+
+// GetSettings returns a community's toxicity settings to its moderators
+func (s *CommunityToxicityService) GetSettings(ctx context.Context, communityID, userID uuid.UUID) (*models.CommunityToxicitySettings, error) {
+	if err := s.requireModerator(ctx, communityID, userID); err != nil {
+		return nil, err
+	}
+
+	settings, err := s.repo.GetSettings(ctx, communityID)
+	if err != nil {
+		return nil, err
+	}
+	s.describeThreshold(settings)
+	return settings, nil
+}
+
+// UpdateSettings sets a community's toxicity sensitivity
+func (s *CommunityToxicityService) UpdateSettings(ctx context.Context, communityID, userID uuid.UUID, req *models.UpdateCommunityToxicitySettingsRequest) (*models.CommunityToxicitySettings, error) {
+	if err := s.requireModerator(ctx, communityID, userID); err != nil {
+		return nil, err
+	}
+
+	settings := &models.CommunityToxicitySettings{
+		CommunityID: communityID,
+		Sensitivity: req.Sensitivity,
+		UpdatedBy:   &userID,
+	}
+	if err := s.repo.UpsertSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+	s.describeThreshold(settings)
+	return settings, nil
+}
+
+// ThresholdForClip returns the threshold comments on a clip are flagged at.
+// Clips outside communities use the site-wide threshold; otherwise the
+// strictest of their communities' thresholds applies, and flagging is off
+// only when every community turned it off.
+func (s *CommunityToxicityService) ThresholdForClip(ctx context.Context, clipID uuid.UUID) (float64, bool) {
+	sensitivities, err := s.repo.ListClipSensitivities(ctx, clipID)
+	if err != nil {
+		utils.Warn("Failed to load community toxicity settings", map[string]interface{}{
+			"clip_id": clipID.String(),
+			"error":   err.Error(),
+		})
+		return s.baseThreshold, true
+	}
+	if len(sensitivities) == 0 {
+		return s.baseThreshold, true
+	}
+
+	threshold, enabled := 1.0, false
+	for _, sensitivity := range sensitivities {
+		if t, ok := s.threshold(sensitivity); ok {
+			threshold = math.Min(threshold, t)
+			enabled = true
+		}
+	}
+	return threshold, enabled
+}
+
+// threshold maps a sensitivity to a threshold around the site-wide one
+func (s *CommunityToxicityService) threshold(sensitivity string) (float64, bool) {
+	switch sensitivity {
+	case models.ToxicitySensitivityOff:
+		return 0, false
+	case models.ToxicitySensitivityLow:
+		return math.Min(math.Round((s.baseThreshold+0.10)*100)/100, 0.99), true
+	case models.ToxicitySensitivityHigh:
+		return math.Max(math.Round((s.baseThreshold-0.15)*100)/100, 0.50), true
+	default:
+		return s.baseThreshold, true
+	}
+}
+
+func (s *CommunityToxicityService) describeThreshold(settings *models.CommunityToxicitySettings) {
+	if threshold, ok := s.threshold(settings.Sensitivity); ok {
+		settings.Threshold = &threshold
+	}
+}
+
+// requireModerator checks that the user owns the community or is one of its
+// admins or mods
+func (s *CommunityToxicityService) requireModerator(ctx context.Context, communityID, userID uuid.UUID) error {
+	ownerID, err := s.repo.GetCommunityOwner(ctx, communityID)
+	if err != nil {
+		return err
+	}
+	if ownerID == userID {
+		return nil
+	}
+
+	role, err := s.repo.GetMemberRole(ctx, communityID, userID)
+	if err != nil {
+		return err
+	}
+	if role != models.CommunityRoleAdmin && role != models.CommunityRoleMod {
+		return ErrCommunityToxicityForbidden
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+// MockCommunityToxicityRepository is a mock implementation of CommunityToxicityRepositoryInterface
+type MockCommunityToxicityRepository struct {
+	mock.Mock
+}
+
+func (m *MockCommunityToxicityRepository) GetCommunityOwner(ctx context.Context, communityID uuid.UUID) (uuid.UUID, error) {
+	args := m.Called(ctx, communityID)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (m *MockCommunityToxicityRepository) GetMemberRole(ctx context.Context, communityID, userID uuid.UUID) (string, error) {
+	args := m.Called(ctx, communityID, userID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockCommunityToxicityRepository) GetSettings(ctx context.Context, communityID uuid.UUID) (*models.CommunityToxicitySettings, error) {
+	args := m.Called(ctx, communityID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CommunityToxicitySettings), args.Error(1)
+}
+
+func (m *MockCommunityToxicityRepository) UpsertSettings(ctx context.Context, settings *models.CommunityToxicitySettings) error {
+	args := m.Called(ctx, settings)
+	return args.Error(0)
+}
+
+func (m *MockCommunityToxicityRepository) ListClipSensitivities(ctx context.Context, clipID uuid.UUID) ([]string, error) {
+	args := m.Called(ctx, clipID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func TestCommunityToxicityService_Settings(t *testing.T) {
+	ctx := context.Background()
+	communityID := uuid.New()
+	ownerID, modID, memberID := uuid.New(), uuid.New(), uuid.New()
+	repo := new(MockCommunityToxicityRepository)
+	repo.On("GetCommunityOwner", ctx, communityID).Return(ownerID, nil)
+	repo.On("GetMemberRole", ctx, communityID, modID).Return(models.CommunityRoleMod, nil)
+	repo.On("GetMemberRole", ctx, communityID, memberID).Return(models.CommunityRoleMember, nil)
+	svc := NewCommunityToxicityService(repo, 0.85)
+
+	repo.On("GetSettings", ctx, communityID).Return(&models.CommunityToxicitySettings{
+		CommunityID: communityID,
+		Sensitivity: models.ToxicitySensitivityMedium,
+	}, nil).Once()
+	settings, err := svc.GetSettings(ctx, communityID, ownerID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ToxicitySensitivityMedium, settings.Sensitivity)
+	require.NotNil(t, settings.Threshold)
+	assert.Equal(t, 0.85, *settings.Threshold)
+
+	repo.On("UpsertSettings", ctx, mock.MatchedBy(func(settings *models.CommunityToxicitySettings) bool {
+		return settings.CommunityID == communityID && settings.Sensitivity == models.ToxicitySensitivityHigh && *settings.UpdatedBy == modID
+	})).Return(nil).Once()
+	settings, err = svc.UpdateSettings(ctx, communityID, modID, &models.UpdateCommunityToxicitySettingsRequest{Sensitivity: models.ToxicitySensitivityHigh})
+	require.NoError(t, err)
+	assert.Equal(t, 0.7, *settings.Threshold)
+
+	repo.On("UpsertSettings", ctx, mock.MatchedBy(func(settings *models.CommunityToxicitySettings) bool {
+		return settings.Sensitivity == models.ToxicitySensitivityOff
+	})).Return(nil).Once()
+	settings, err = svc.UpdateSettings(ctx, communityID, ownerID, &models.UpdateCommunityToxicitySettingsRequest{Sensitivity: models.ToxicitySensitivityOff})
+	require.NoError(t, err)
+	assert.Nil(t, settings.Threshold, "no threshold applies while flagging is off")
+
+	_, err = svc.UpdateSettings(ctx, communityID, memberID, &models.UpdateCommunityToxicitySettingsRequest{Sensitivity: models.ToxicitySensitivityLow})
+	assert.ErrorIs(t, err, ErrCommunityToxicityForbidden)
+
+	missing := uuid.New()
+	repo.On("GetCommunityOwner", ctx, missing).Return(uuid.Nil, repository.ErrCommunityNotFound).Once()
+	_, err = svc.GetSettings(ctx, missing, ownerID)
+	assert.ErrorIs(t, err, repository.ErrCommunityNotFound)
+
+	repo.AssertExpectations(t)
+	repo.AssertNumberOfCalls(t, "UpsertSettings", 2)
+}
+
+func TestCommunityToxicityService_ThresholdForClip(t *testing.T) {
+	tests := []struct {
+		name          string
+		sensitivities []string
+		listErr       error
+		wantThreshold float64
+		wantEnabled   bool
+	}{
+		{name: "clip outside communities", wantThreshold: 0.85, wantEnabled: true},
+		{name: "low sensitivity", sensitivities: []string{models.ToxicitySensitivityLow}, wantThreshold: 0.95, wantEnabled: true},
+		{name: "strictest community wins", sensitivities: []string{models.ToxicitySensitivityLow, models.ToxicitySensitivityHigh, models.ToxicitySensitivityOff}, wantThreshold: 0.7, wantEnabled: true},
+		{name: "every community off", sensitivities: []string{models.ToxicitySensitivityOff, models.ToxicitySensitivityOff}, wantEnabled: false},
+		{name: "settings unavailable", listErr: errors.New("connection refused"), wantThreshold: 0.85, wantEnabled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clipID := uuid.New()
+			repo := new(MockCommunityToxicityRepository)
+			repo.On("ListClipSensitivities", mock.Anything, clipID).Return(tt.sensitivities, tt.listErr).Once()
+			svc := NewCommunityToxicityService(repo, 0.85)
+
+			threshold, enabled := svc.ThresholdForClip(context.Background(), clipID)
+			assert.Equal(t, tt.wantEnabled, enabled)
+			if tt.wantEnabled {
+				assert.Equal(t, tt.wantThreshold, threshold)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// counterFinalFlushTimeout bounds the flush made after a counterBuffer's run
+// loop is cancelled
+const counterFinalFlushTimeout = 5 * time.Second
+
+// counterBuffer accumulates counters in memory by key and writes them in
+// batches, so the code that records them never waits on a write. Counts that
+// fail to write are merged back and retried on the next flush.
+type counterBuffer[K comparable, V any] struct {
+	// name identifies the counters in log messages
+	name string
+	// write stores one batch of counters
+	write func(ctx context.Context, batch []V) error
+	// merge adds the counts in from to into
+	merge func(into, from *V)
+
+	mu      sync.Mutex
+	pending map[K]*V
+}
+
+func newCounterBuffer[K comparable, V any](name string, write func(context.Context, []V) error, merge func(into, from *V)) *counterBuffer[K, V] {
+	return &counterBuffer[K, V]{
+		name:    name,
+		write:   write,
+		merge:   merge,
+		pending: make(map[K]*V),
+	}
+}
+
+// add counts into the counter for key, creating it with create if it isn't pending
+func (b *counterBuffer[K, V]) add(key K, create func() V, count func(*V)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	counter, ok := b.pending[key]
+	if !ok {
+		created := create()
+		counter = &created
+		b.pending[key] = counter
+	}
+	count(counter)
+}
+
+// each calls fn for every counter recorded since the last flush. fn must not
+// call back into the buffer.
+func (b *counterBuffer[K, V]) each(fn func(key K, counter *V)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for key, counter := range b.pending {
+		fn(key, counter)
+	}
+}
+
+// flush writes the pending counters. Counts that fail to write are kept for
+// the next flush.
+func (b *counterBuffer[K, V]) flush(ctx context.Context) error {
+	b.mu.Lock()
+	pending := b.pending
+	b.pending = make(map[K]*V)
+	b.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	batch := make([]V, 0, len(pending))
+	for _, counter := range pending {
+		batch = append(batch, *counter)
+	}
+
+	if err := b.write(ctx, batch); err != nil {
+		b.requeue(pending)
+		return err
+	}
+
+	return nil
+}
+
+// requeue puts counts from a failed flush back so they are written next time
+func (b *counterBuffer[K, V]) requeue(failed map[K]*V) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for key, counter := range failed {
+		existing, ok := b.pending[key]
+		if !ok {
+			b.pending[key] = counter
+			continue
+		}
+		b.merge(existing, counter)
+	}
+}
+
+// run flushes every interval and calls prune hourly until the context is
+// cancelled, flushing once more on the way out
+func (b *counterBuffer[K, V]) run(ctx context.Context, interval time.Duration, prune func(context.Context)) {
+	flushTicker := time.NewTicker(interval)
+	defer flushTicker.Stop()
+	pruneTicker := time.NewTicker(time.Hour)
+	defer pruneTicker.Stop()
+
+	for {
+		select {
+		case <-flushTicker.C:
+			if err := b.flush(ctx); err != nil {
+				log.Printf("Failed to flush %s: %v", b.name, err)
+			}
+		case <-pruneTicker.C:
+			prune(ctx)
+		case <-ctx.Done():
+			// The parent context is gone; give the final flush its own deadline
+			flushCtx, cancel := context.WithTimeout(context.Background(), counterFinalFlushTimeout)
+			if err := b.flush(flushCtx); err != nil {
+				log.Printf("Failed to flush %s on shutdown: %v", b.name, err)
+			}
+			cancel()
+			return
+		}
+	}
+}
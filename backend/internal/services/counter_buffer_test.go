@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCounter struct {
+	Key   string
+	Count int
+}
+
+func newTestCounterBuffer(write func(context.Context, []testCounter) error) *counterBuffer[string, testCounter] {
+	return newCounterBuffer[string]("test counters", write, func(into, from *testCounter) {
+		into.Count += from.Count
+	})
+}
+
+func addTestCount(b *counterBuffer[string, testCounter], key string, n int) {
+	b.add(key, func() testCounter { return testCounter{Key: key} }, func(c *testCounter) { c.Count += n })
+}
+
+func TestCounterBuffer_FlushRequeuesFailedCounts(t *testing.T) {
+	var written []testCounter
+	fail := true
+	b := newTestCounterBuffer(func(_ context.Context, batch []testCounter) error {
+		if fail {
+			return errors.New("db down")
+		}
+		written = append(written, batch...)
+		return nil
+	})
+
+	addTestCount(b, "a", 2)
+	require.Error(t, b.flush(context.Background()))
+
+	// Counts recorded after the failure are merged with the requeued ones
+	addTestCount(b, "a", 3)
+	fail = false
+	require.NoError(t, b.flush(context.Background()))
+	assert.Equal(t, []testCounter{{Key: "a", Count: 5}}, written)
+
+	// Nothing is pending after a successful flush
+	written = nil
+	require.NoError(t, b.flush(context.Background()))
+	assert.Empty(t, written)
+}
+
+func TestCounterBuffer_RunFlushesOnShutdown(t *testing.T) {
+	flushed := make(chan []testCounter, 1)
+	b := newTestCounterBuffer(func(ctx context.Context, batch []testCounter) error {
+		// The final flush must not use the cancelled parent context
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		flushed <- batch
+		return nil
+	})
+	addTestCount(b, "a", 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		b.run(ctx, time.Hour, func(context.Context) {})
+		close(done)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("run did not return after cancellation")
+	}
+	assert.Equal(t, []testCounter{{Key: "a", Count: 1}}, <-flushed)
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/pkg/twitch"
+)
+
+const (
+	// CreatorClaimTTL is how long a claim's code can be verified
+	CreatorClaimTTL = 24 * time.Hour
+	// creatorClaimCodePrefix starts verification codes, so they are easy to
+	// spot in a channel description
+	creatorClaimCodePrefix = "clipper-verify-"
+)
+
+var (
+	// ErrCreatorClaimUnavailable is returned when the Twitch API isn't configured
+	ErrCreatorClaimUnavailable = errors.New("creator claims are not available")
+	// ErrCreatorNotFound is returned when no Twitch channel has the login
+	ErrCreatorNotFound = errors.New("twitch channel not found")
+	// ErrCreatorHasNoClips is returned when the channel created no clips here
+	ErrCreatorHasNoClips = errors.New("no clips were created by this twitch channel")
+	// ErrCreatorAlreadyAttributed is returned when the user is already credited with the creator's clips
+	ErrCreatorAlreadyAttributed = errors.New("this channel's clips are already attributed to you")
+	// ErrCreatorClaimNotPending is returned when verifying a claim that isn't pending
+	ErrCreatorClaimNotPending = errors.New("claim is no longer pending")
+	// ErrCreatorClaimExpired is returned when verifying a claim past its expiry
+	ErrCreatorClaimExpired = errors.New("claim has expired, start a new one")
+	// ErrCreatorClaimCodeMissing is returned when the code isn't in the channel description
+	ErrCreatorClaimCodeMissing = errors.New("verification code not found in the channel description")
+)
+
+// CreatorClaimRepositoryInterface defines the repository methods used by CreatorClaimService
+type CreatorClaimRepositoryInterface interface {
+	Create(ctx context.Context, claim *models.CreatorClaim) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.CreatorClaim, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]models.CreatorClaim, error)
+	MarkVerified(ctx context.Context, id uuid.UUID) (*models.CreatorClaim, error)
+	MarkExpired(ctx context.Context, id uuid.UUID) error
+	HasVerifiedClaim(ctx context.Context, userID uuid.UUID, twitchCreatorID string) (bool, error)
+	CountCreatorClips(ctx context.Context, twitchCreatorID string) (int, error)
+}
+
+// CreatorClaimTwitchClient looks up Twitch channels for claim verification
+type CreatorClaimTwitchClient interface {
+	GetUsers(ctx context.Context, userIDs, logins []string) (*twitch.UsersResponse, error)
+}
+
+// CreatorClaimService lets users without a linked Twitch account claim the
+// clips they created. The user proves control of the Twitch channel by
+// adding a one-time code to its description, which is checked through the
+// Twitch API; then the channel's clips are attributed to the user.
+type CreatorClaimService struct {
+	repo     CreatorClaimRepositoryInterface
+	userRepo UserRepositoryInterface
+	twitch   CreatorClaimTwitchClient // may be nil
+	now      func() time.Time
+}
+
+// NewCreatorClaimService creates a new CreatorClaimService. Claims are
+// unavailable without a Twitch client.
+func NewCreatorClaimService(repo CreatorClaimRepositoryInterface, userRepo UserRepositoryInterface, twitchClient CreatorClaimTwitchClient) *CreatorClaimService {
+	return &CreatorClaimService{
+		repo:     repo,
+		userRepo: userRepo,
+		twitch:   twitchClient,
+		now:      time.Now,
+	}
+}
+
+// StartClaim starts a claim to the clips created by a Twitch channel and
+// returns it with the code to add to the channel's description
+func (s *CreatorClaimService) StartClaim(ctx context.Context, userID uuid.UUID, twitchLogin string) (*models.CreatorClaim, error) {
+	if s.twitch == nil {
+		return nil, ErrCreatorClaimUnavailable
+	}
+
+	channel, err := s.lookupChannel(ctx, nil, []string{strings.ToLower(strings.TrimSpace(twitchLogin))})
+	if err != nil {
+		return nil, err
+	}
+
+	// Users logged in with this Twitch account already have its clips
+	if owner, err := s.userRepo.GetByTwitchID(ctx, channel.ID); err == nil && owner.ID == userID {
+		return nil, ErrCreatorAlreadyAttributed
+	}
+	verified, err := s.repo.HasVerifiedClaim(ctx, userID, channel.ID)
+	if err != nil {
+		return nil, err
+	}
+	if verified {
+		return nil, ErrCreatorAlreadyAttributed
+	}
+
+	clips, err := s.repo.CountCreatorClips(ctx, channel.ID)
+	if err != nil {
+		return nil, err
+	}
+	if clips == 0 {
+		return nil, ErrCreatorHasNoClips
+	}
+
+	code, err := newCreatorClaimCode()
+	if err != nil {
+		return nil, err
+	}
+
+	claim := &models.CreatorClaim{
+		UserID:           userID,
+		TwitchCreatorID:  channel.ID,
+		TwitchLogin:      channel.Login,
+		VerificationCode: code,
+		Status:           models.CreatorClaimStatusPending,
+		ExpiresAt:        s.now().Add(CreatorClaimTTL),
+	}
+	if err := s.repo.Create(ctx, claim); err != nil {
+		return nil, err
+	}
+
+	return claim, nil
+}
+
+// VerifyClaim checks the channel's description for the claim's code and, when
+// found, verifies the claim, moving attribution of the channel's clips to the
+// user. The code can be removed from the description afterwards.
+func (s *CreatorClaimService) VerifyClaim(ctx context.Context, userID, claimID uuid.UUID) (*models.CreatorClaim, error) {
+	if s.twitch == nil {
+		return nil, ErrCreatorClaimUnavailable
+	}
+
+	claim, err := s.repo.GetByID(ctx, claimID)
+	if err != nil {
+		return nil, err
+	}
+	if claim.UserID != userID {
+		// Others' claims are reported as missing, not forbidden
+		return nil, repository.ErrCreatorClaimNotFound
+	}
+	if claim.Status != models.CreatorClaimStatusPending {
+		return nil, ErrCreatorClaimNotPending
+	}
+	if !s.now().Before(claim.ExpiresAt) {
+		if err := s.repo.MarkExpired(ctx, claim.ID); err != nil {
+			return nil, err
+		}
+		return nil, ErrCreatorClaimExpired
+	}
+
+	// Look the channel up by ID, which survives login renames
+	channel, err := s.lookupChannel(ctx, []string{claim.TwitchCreatorID}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.Contains(channel.Description, claim.VerificationCode) {
+		return nil, ErrCreatorClaimCodeMissing
+	}
+
+	return s.repo.MarkVerified(ctx, claim.ID)
+}
+
+// ListClaims returns the user's claims, newest first
+func (s *CreatorClaimService) ListClaims(ctx context.Context, userID uuid.UUID) ([]models.CreatorClaim, error) {
+	return s.repo.ListByUser(ctx, userID)
+}
+
+// IsAttributedCreator reports whether the user holds the verified claim to a
+// Twitch creator's clips
+func (s *CreatorClaimService) IsAttributedCreator(ctx context.Context, userID uuid.UUID, twitchCreatorID string) (bool, error) {
+	return s.repo.HasVerifiedClaim(ctx, userID, twitchCreatorID)
+}
+
+// lookupChannel fetches one Twitch channel, bypassing the user cache so
+// description changes are seen at once
+func (s *CreatorClaimService) lookupChannel(ctx context.Context, ids, logins []string) (*twitch.User, error) {
+	resp, err := s.twitch.GetUsers(ctx, ids, logins)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up twitch channel: %w", err)
+	}
+	if len(resp.Data) == 0 {
+		return nil, ErrCreatorNotFound
+	}
+	return &resp.Data[0], nil
+}
+
+// newCreatorClaimCode generates a random verification code
+func newCreatorClaimCode() (string, error) {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate verification code: %w", err)
+	}
+	return creatorClaimCodePrefix + hex.EncodeToString(b), nil
+}
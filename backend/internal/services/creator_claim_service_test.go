@@ -0,0 +1,212 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/pkg/twitch"
+)
+
+// MockCreatorClaimRepository is a mock implementation of CreatorClaimRepositoryInterface
+type MockCreatorClaimRepository struct {
+	mock.Mock
+}
+
+func (m *MockCreatorClaimRepository) Create(ctx context.Context, claim *models.CreatorClaim) error {
+	args := m.Called(ctx, claim)
+	return args.Error(0)
+}
+
+func (m *MockCreatorClaimRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.CreatorClaim, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CreatorClaim), args.Error(1)
+}
+
+func (m *MockCreatorClaimRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]models.CreatorClaim, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CreatorClaim), args.Error(1)
+}
+
+func (m *MockCreatorClaimRepository) MarkVerified(ctx context.Context, id uuid.UUID) (*models.CreatorClaim, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CreatorClaim), args.Error(1)
+}
+
+func (m *MockCreatorClaimRepository) MarkExpired(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockCreatorClaimRepository) HasVerifiedClaim(ctx context.Context, userID uuid.UUID, twitchCreatorID string) (bool, error) {
+	args := m.Called(ctx, userID, twitchCreatorID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCreatorClaimRepository) CountCreatorClips(ctx context.Context, twitchCreatorID string) (int, error) {
+	args := m.Called(ctx, twitchCreatorID)
+	return args.Int(0), args.Error(1)
+}
+
+// MockCreatorClaimTwitchClient is a mock implementation of CreatorClaimTwitchClient
+type MockCreatorClaimTwitchClient struct {
+	mock.Mock
+}
+
+func (m *MockCreatorClaimTwitchClient) GetUsers(ctx context.Context, userIDs, logins []string) (*twitch.UsersResponse, error) {
+	args := m.Called(ctx, userIDs, logins)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*twitch.UsersResponse), args.Error(1)
+}
+
+// claimChannel is the Twitch channel claimed in creator claim tests
+var claimChannel = twitch.User{ID: "1234", Login: "somestreamer", Description: "Variety streamer"}
+
+func setupCreatorClaimServiceTest() (*CreatorClaimService, *MockCreatorClaimRepository, *MockUserRepository, *MockCreatorClaimTwitchClient) {
+	repo := new(MockCreatorClaimRepository)
+	userRepo := new(MockUserRepository)
+	tw := new(MockCreatorClaimTwitchClient)
+	svc := NewCreatorClaimService(repo, userRepo, tw)
+	return svc, repo, userRepo, tw
+}
+
+// expectClaimChannelByLogin expects claimChannel to be looked up by login
+// and not to be the Twitch account of any user
+func expectClaimChannelByLogin(userRepo *MockUserRepository, tw *MockCreatorClaimTwitchClient) {
+	tw.On("GetUsers", mock.Anything, []string(nil), []string{claimChannel.Login}).
+		Return(&twitch.UsersResponse{Data: []twitch.User{claimChannel}}, nil)
+	userRepo.On("GetByTwitchID", mock.Anything, claimChannel.ID).Return(nil, errors.New("user not found"))
+}
+
+// expectClaimChannelByID expects claimChannel to be looked up by ID once,
+// with the given description
+func expectClaimChannelByID(tw *MockCreatorClaimTwitchClient, description string) {
+	channel := claimChannel
+	channel.Description = description
+	tw.On("GetUsers", mock.Anything, []string{claimChannel.ID}, []string(nil)).
+		Return(&twitch.UsersResponse{Data: []twitch.User{channel}}, nil).Once()
+}
+
+// expectCreatorClaimCreated expects a claim to be created, giving it claimID
+func expectCreatorClaimCreated(repo *MockCreatorClaimRepository, claimID uuid.UUID) {
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*models.CreatorClaim")).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.CreatorClaim).ID = claimID
+	}).Return(nil).Once()
+}
+
+func TestCreatorClaimStartAndVerify(t *testing.T) {
+	svc, repo, userRepo, tw := setupCreatorClaimServiceTest()
+	userID := uuid.New()
+	claimID := uuid.New()
+	expectClaimChannelByLogin(userRepo, tw)
+	repo.On("HasVerifiedClaim", mock.Anything, userID, claimChannel.ID).Return(false, nil).Once()
+	repo.On("CountCreatorClips", mock.Anything, claimChannel.ID).Return(3, nil).Once()
+	expectCreatorClaimCreated(repo, claimID)
+
+	claim, err := svc.StartClaim(context.Background(), userID, " SomeStreamer ")
+	require.NoError(t, err)
+	assert.Equal(t, claimID, claim.ID)
+	assert.Equal(t, "1234", claim.TwitchCreatorID)
+	assert.Equal(t, models.CreatorClaimStatusPending, claim.Status)
+	assert.Contains(t, claim.VerificationCode, creatorClaimCodePrefix)
+
+	pending := *claim
+	repo.On("GetByID", mock.Anything, claimID).Return(&pending, nil)
+	expectClaimChannelByID(tw, claimChannel.Description)
+	_, err = svc.VerifyClaim(context.Background(), userID, claim.ID)
+	assert.ErrorIs(t, err, ErrCreatorClaimCodeMissing)
+
+	_, err = svc.VerifyClaim(context.Background(), uuid.New(), claim.ID)
+	assert.ErrorIs(t, err, repository.ErrCreatorClaimNotFound, "others' claims are hidden")
+
+	verifiedClaim := pending
+	verifiedClaim.Status = models.CreatorClaimStatusVerified
+	expectClaimChannelByID(tw, claimChannel.Description+" "+claim.VerificationCode)
+	repo.On("MarkVerified", mock.Anything, claimID).Return(&verifiedClaim, nil).Once()
+	verified, err := svc.VerifyClaim(context.Background(), userID, claim.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.CreatorClaimStatusVerified, verified.Status)
+
+	repo.On("HasVerifiedClaim", mock.Anything, userID, claimChannel.ID).Return(true, nil)
+	attributed, err := svc.IsAttributedCreator(context.Background(), userID, "1234")
+	require.NoError(t, err)
+	assert.True(t, attributed)
+
+	_, err = svc.StartClaim(context.Background(), userID, "somestreamer")
+	assert.ErrorIs(t, err, ErrCreatorAlreadyAttributed)
+
+	repo.AssertExpectations(t)
+	tw.AssertExpectations(t)
+}
+
+func TestCreatorClaimExpires(t *testing.T) {
+	svc, repo, userRepo, tw := setupCreatorClaimServiceTest()
+	userID := uuid.New()
+	claimID := uuid.New()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	expectClaimChannelByLogin(userRepo, tw)
+	repo.On("HasVerifiedClaim", mock.Anything, userID, claimChannel.ID).Return(false, nil).Once()
+	repo.On("CountCreatorClips", mock.Anything, claimChannel.ID).Return(3, nil).Once()
+	expectCreatorClaimCreated(repo, claimID)
+
+	claim, err := svc.StartClaim(context.Background(), userID, "somestreamer")
+	require.NoError(t, err)
+
+	pending := *claim
+	repo.On("GetByID", mock.Anything, claimID).Return(&pending, nil).Once()
+	repo.On("MarkExpired", mock.Anything, claimID).Return(nil).Once()
+	now = now.Add(CreatorClaimTTL)
+	_, err = svc.VerifyClaim(context.Background(), userID, claim.ID)
+	assert.ErrorIs(t, err, ErrCreatorClaimExpired)
+
+	expired := pending
+	expired.Status = models.CreatorClaimStatusExpired
+	repo.On("GetByID", mock.Anything, claimID).Return(&expired, nil).Once()
+	_, err = svc.VerifyClaim(context.Background(), userID, claim.ID)
+	assert.ErrorIs(t, err, ErrCreatorClaimNotPending)
+
+	repo.AssertExpectations(t)
+	tw.AssertNumberOfCalls(t, "GetUsers", 1)
+}
+
+func TestCreatorClaimRejected(t *testing.T) {
+	svc, repo, userRepo, tw := setupCreatorClaimServiceTest()
+	userID := uuid.New()
+
+	tw.On("GetUsers", mock.Anything, []string(nil), []string{"unknown"}).Return(&twitch.UsersResponse{}, nil).Once()
+	_, err := svc.StartClaim(context.Background(), userID, "unknown")
+	assert.ErrorIs(t, err, ErrCreatorNotFound)
+
+	expectClaimChannelByLogin(userRepo, tw)
+	repo.On("HasVerifiedClaim", mock.Anything, userID, claimChannel.ID).Return(false, nil).Once()
+	repo.On("CountCreatorClips", mock.Anything, claimChannel.ID).Return(0, nil).Once()
+	_, err = svc.StartClaim(context.Background(), userID, "somestreamer")
+	assert.ErrorIs(t, err, ErrCreatorHasNoClips)
+
+	noTwitch := NewCreatorClaimService(repo, userRepo, nil)
+	_, err = noTwitch.StartClaim(context.Background(), userID, "somestreamer")
+	assert.ErrorIs(t, err, ErrCreatorClaimUnavailable)
+
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	tw.AssertExpectations(t)
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+// dataActivityCategoryDescriptions explains each analytics category in a
+// user's data activity
+var dataActivityCategoryDescriptions = map[string]string{
+	"engagement_events": "Clip views, votes, comments, favorites and shares, used for clip and platform analytics",
+	"feed_events":       "Feed impressions and interactions, used to measure and improve feeds",
+	"search_history":    "Searches you ran, used for search history, suggestions and search quality",
+	"search_clicks":     "Search results you opened, used to improve search ranking",
+	"watch_history":     "Clips you watched and how far, used for history and recommendations",
+}
+
+// Bases for ad personalization in a user's data activity
+const (
+	AdPersonalizationBasisConsent   = "consent"
+	AdPersonalizationBasisNoConsent = "no_consent"
+	AdPersonalizationBasisExpired   = "consent_expired"
+)
+
+// DataActivityRepositoryInterface defines the repository methods used by DataActivityService
+type DataActivityRepositoryInterface interface {
+	ListEmailTypes(ctx context.Context, userID uuid.UUID) ([]models.DataActivityEmailType, error)
+	ListExports(ctx context.Context, userID uuid.UUID) ([]models.DataActivityExport, error)
+	ListAnalyticsCategories(ctx context.Context, userID uuid.UUID) ([]models.DataActivityCategory, error)
+	CountAdImpressions(ctx context.Context, userID uuid.UUID) (int, *time.Time, error)
+	ListAccountEvents(ctx context.Context, userID uuid.UUID) ([]models.DataActivityEvent, error)
+}
+
+// ConsentReader reads a user's current cookie consent
+type ConsentReader interface {
+	GetConsent(ctx context.Context, userID uuid.UUID) (*models.CookieConsent, error)
+}
+
+// DataActivityService assembles a user's data activity from the audit, email,
+// export, analytics and consent records, so users can see what processing
+// happened on their data without a support request
+type DataActivityService struct {
+	repo    DataActivityRepositoryInterface
+	consent ConsentReader
+}
+
+// NewDataActivityService creates a new DataActivityService
+func NewDataActivityService(repo DataActivityRepositoryInterface, consent ConsentReader) *DataActivityService {
+	return &DataActivityService{
+		repo:    repo,
+		consent: consent,
+	}
+}
+
+// GetDataActivity returns the summary of processing on a user's data
+func (s *DataActivityService) GetDataActivity(ctx context.Context, userID uuid.UUID) (*models.DataActivity, error) {
+	now := time.Now()
+	activity := &models.DataActivity{
+		UserID:      userID,
+		GeneratedAt: now,
+	}
+
+	emailTypes, err := s.repo.ListEmailTypes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	activity.Emails.ByType = emailTypes
+	for _, t := range emailTypes {
+		activity.Emails.Total += t.Count
+		if activity.Emails.LastSentAt == nil || t.LastSentAt.After(*activity.Emails.LastSentAt) {
+			lastSentAt := t.LastSentAt
+			activity.Emails.LastSentAt = &lastSentAt
+		}
+	}
+
+	if activity.Exports, err = s.repo.ListExports(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if activity.Analytics, err = s.repo.ListAnalyticsCategories(ctx, userID); err != nil {
+		return nil, err
+	}
+	for i := range activity.Analytics {
+		activity.Analytics[i].Description = dataActivityCategoryDescriptions[activity.Analytics[i].Category]
+	}
+
+	consent, err := s.consent.GetConsent(ctx, userID)
+	if err != nil && !errors.Is(err, repository.ErrConsentNotFound) {
+		return nil, err
+	}
+	activity.AdPersonalization.Basis = AdPersonalizationBasisNoConsent
+	if consent != nil {
+		expired := now.After(consent.ExpiresAt)
+		activity.Consent = &models.DataActivityConsent{
+			Functional:  consent.Functional,
+			Analytics:   consent.Analytics,
+			Advertising: consent.Advertising,
+			ConsentedAt: consent.ConsentDate,
+			ExpiresAt:   consent.ExpiresAt,
+			Expired:     expired,
+		}
+		switch {
+		case expired:
+			activity.AdPersonalization.Basis = AdPersonalizationBasisExpired
+		case consent.Advertising:
+			activity.AdPersonalization.Enabled = true
+			activity.AdPersonalization.Basis = AdPersonalizationBasisConsent
+		}
+	}
+
+	impressions, lastImpressionAt, err := s.repo.CountAdImpressions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	activity.AdPersonalization.Impressions = impressions
+	activity.AdPersonalization.LastImpressionAt = lastImpressionAt
+
+	if activity.AccountEvents, err = s.repo.ListAccountEvents(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return activity, nil
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+// MockDataActivityRepository is a mock implementation of DataActivityRepositoryInterface
+type MockDataActivityRepository struct {
+	mock.Mock
+}
+
+func (m *MockDataActivityRepository) ListEmailTypes(ctx context.Context, userID uuid.UUID) ([]models.DataActivityEmailType, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.DataActivityEmailType), args.Error(1)
+}
+
+func (m *MockDataActivityRepository) ListExports(ctx context.Context, userID uuid.UUID) ([]models.DataActivityExport, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.DataActivityExport), args.Error(1)
+}
+
+func (m *MockDataActivityRepository) ListAnalyticsCategories(ctx context.Context, userID uuid.UUID) ([]models.DataActivityCategory, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.DataActivityCategory), args.Error(1)
+}
+
+func (m *MockDataActivityRepository) CountAdImpressions(ctx context.Context, userID uuid.UUID) (int, *time.Time, error) {
+	args := m.Called(ctx, userID)
+	var last *time.Time
+	if args.Get(1) != nil {
+		last = args.Get(1).(*time.Time)
+	}
+	return args.Int(0), last, args.Error(2)
+}
+
+func (m *MockDataActivityRepository) ListAccountEvents(ctx context.Context, userID uuid.UUID) ([]models.DataActivityEvent, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.DataActivityEvent), args.Error(1)
+}
+
+// MockConsentReader is a mock implementation of ConsentReader
+type MockConsentReader struct {
+	mock.Mock
+}
+
+func (m *MockConsentReader) GetConsent(ctx context.Context, userID uuid.UUID) (*models.CookieConsent, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CookieConsent), args.Error(1)
+}
+
+func TestDataActivityService_GetDataActivity(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	now := time.Now()
+	lastAd := now.Add(-time.Hour)
+	repo := new(MockDataActivityRepository)
+	repo.On("ListEmailTypes", ctx, userID).Return([]models.DataActivityEmailType{
+		{Type: "reply", Count: 3, LastSentAt: now.Add(-2 * time.Hour)},
+		{Type: "digest", Count: 2, LastSentAt: now.Add(-time.Hour)},
+	}, nil).Times(3)
+	repo.On("ListExports", ctx, userID).Return([]models.DataActivityExport{
+		{Kind: models.DataExportKindAccountData, Format: "zip", Status: "completed", RequestedAt: now},
+	}, nil)
+	repo.On("ListAnalyticsCategories", ctx, userID).Return([]models.DataActivityCategory{
+		{Category: "search_history", Records: 12, FirstCollectedAt: now, LastCollectedAt: now},
+	}, nil)
+	repo.On("CountAdImpressions", ctx, userID).Return(4, &lastAd, nil)
+	repo.On("ListAccountEvents", ctx, userID).Return([]models.DataActivityEvent{
+		{Action: "account_deletion_cancelled", OccurredAt: now},
+	}, nil)
+	consent := new(MockConsentReader)
+	current := &models.CookieConsent{
+		Analytics:   true,
+		Advertising: true,
+		ConsentDate: now.Add(-24 * time.Hour),
+		ExpiresAt:   now.Add(24 * time.Hour),
+	}
+	consent.On("GetConsent", ctx, userID).Return(current, nil).Once()
+	svc := NewDataActivityService(repo, consent)
+
+	activity, err := svc.GetDataActivity(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, userID, activity.UserID)
+	assert.Equal(t, 5, activity.Emails.Total)
+	require.NotNil(t, activity.Emails.LastSentAt)
+	assert.Equal(t, now.Add(-time.Hour), *activity.Emails.LastSentAt, "the latest email of any type")
+	assert.Len(t, activity.Exports, 1)
+	require.Len(t, activity.Analytics, 1)
+	assert.NotEmpty(t, activity.Analytics[0].Description)
+	require.NotNil(t, activity.Consent)
+	assert.True(t, activity.Consent.Analytics)
+	assert.False(t, activity.Consent.Expired)
+	assert.Equal(t, models.DataActivityAdPersonalization{
+		Enabled:          true,
+		Basis:            AdPersonalizationBasisConsent,
+		Impressions:      4,
+		LastImpressionAt: &lastAd,
+	}, activity.AdPersonalization)
+	assert.Len(t, activity.AccountEvents, 1)
+
+	expired := *current
+	expired.ExpiresAt = now.Add(-time.Minute)
+	consent.On("GetConsent", ctx, userID).Return(&expired, nil).Once()
+	activity, err = svc.GetDataActivity(ctx, userID)
+	require.NoError(t, err)
+	assert.True(t, activity.Consent.Expired)
+	assert.False(t, activity.AdPersonalization.Enabled, "expired consent doesn't personalize ads")
+	assert.Equal(t, AdPersonalizationBasisExpired, activity.AdPersonalization.Basis)
+
+	consent.On("GetConsent", ctx, userID).Return(nil, repository.ErrConsentNotFound).Once()
+	activity, err = svc.GetDataActivity(ctx, userID)
+	require.NoError(t, err)
+	assert.Nil(t, activity.Consent)
+	assert.False(t, activity.AdPersonalization.Enabled)
+	assert.Equal(t, AdPersonalizationBasisNoConsent, activity.AdPersonalization.Basis)
+
+	repo.On("ListEmailTypes", ctx, userID).Return(nil, errors.New("db down")).Once()
+	_, err = svc.GetDataActivity(ctx, userID)
+	assert.Error(t, err)
+
+	repo.AssertExpectations(t)
+	consent.AssertExpectations(t)
+}
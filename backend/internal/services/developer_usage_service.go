@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+const (
+	developerUsageFlushInterval = time.Minute
+	developerUsageRetention     = 90 * 24 * time.Hour
+)
+
+// ErrInvalidUsageWindow is returned when a usage report window is not one of models.DeveloperUsageWindows
+var ErrInvalidUsageWindow = errors.New("usage window must be one of 24h, 7d or 30d")
+
+// DeveloperUsageRepositoryInterface defines the repository methods used by DeveloperUsageService
+type DeveloperUsageRepositoryInterface interface {
+	AddAPIKeyUsage(ctx context.Context, buckets []models.APIKeyUsageBucket) error
+	ListAPIKeyUsage(ctx context.Context, userID uuid.UUID, since time.Time) ([]models.APIKeyUsageBucket, error)
+	ListWebhookDeliveryUsage(ctx context.Context, userID uuid.UUID, since time.Time) ([]models.WebhookDeliveryBucket, error)
+	DeleteAPIKeyUsageBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// DeveloperUsageAPIKeyLister lists the API keys a usage report covers
+type DeveloperUsageAPIKeyLister interface {
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*models.APIKey, error)
+}
+
+// DeveloperUsageWebhookLister lists the webhook subscriptions a usage report covers
+type DeveloperUsageWebhookLister interface {
+	GetSubscriptionsByUserID(ctx context.Context, userID uuid.UUID) ([]*models.WebhookSubscription, error)
+}
+
+type apiKeyUsageKey struct {
+	apiKeyID uuid.UUID
+	hour     time.Time
+}
+
+// DeveloperUsageService records API key traffic into hourly rollups and reports
+// API key and webhook usage back to developers. Requests are counted in memory
+// and flushed periodically so recording never adds a write to the request path.
+type DeveloperUsageService struct {
+	repo        DeveloperUsageRepositoryInterface
+	apiKeyRepo  DeveloperUsageAPIKeyLister
+	webhookRepo DeveloperUsageWebhookLister
+	usage       *counterBuffer[apiKeyUsageKey, models.APIKeyUsageBucket]
+	now         func() time.Time
+}
+
+// NewDeveloperUsageService creates a new DeveloperUsageService
+func NewDeveloperUsageService(
+	repo DeveloperUsageRepositoryInterface,
+	apiKeyRepo DeveloperUsageAPIKeyLister,
+	webhookRepo DeveloperUsageWebhookLister,
+) *DeveloperUsageService {
+	return &DeveloperUsageService{
+		repo:        repo,
+		apiKeyRepo:  apiKeyRepo,
+		webhookRepo: webhookRepo,
+		usage:       newCounterBuffer[apiKeyUsageKey]("api key usage", repo.AddAPIKeyUsage, addAPIKeyUsage),
+		now:         time.Now,
+	}
+}
+
+// Start flushes recorded usage every minute and prunes old rollups hourly until
+// the context is cancelled, flushing once more on the way out
+func (s *DeveloperUsageService) Start(ctx context.Context) {
+	s.usage.run(ctx, developerUsageFlushInterval, func(ctx context.Context) {
+		if _, err := s.repo.DeleteAPIKeyUsageBefore(ctx, s.now().UTC().Add(-developerUsageRetention)); err != nil {
+			log.Printf("Failed to prune api key usage: %v", err)
+		}
+	})
+}
+
+// Record counts one request made with an API key
+func (s *DeveloperUsageService) Record(apiKeyID uuid.UUID, status int) {
+	key := apiKeyUsageKey{apiKeyID: apiKeyID, hour: s.now().UTC().Truncate(time.Hour)}
+
+	s.usage.add(key, func() models.APIKeyUsageBucket {
+		return models.APIKeyUsageBucket{APIKeyID: apiKeyID, Hour: key.hour}
+	}, func(bucket *models.APIKeyUsageBucket) {
+		bucket.Requests++
+		switch {
+		case status == http.StatusTooManyRequests:
+			bucket.RateLimited++
+		case status >= http.StatusBadRequest:
+			bucket.Errors++
+		}
+	})
+}
+
+// Flush writes recorded usage to the hourly rollups. Counts that fail to write
+// are kept for the next flush.
+func (s *DeveloperUsageService) Flush(ctx context.Context) error {
+	return s.usage.flush(ctx)
+}
+
+// GetUsage reports a user's API key and webhook usage over a window, bucketed by hour
+func (s *DeveloperUsageService) GetUsage(ctx context.Context, userID uuid.UUID, window string) (*models.DeveloperUsage, error) {
+	length, ok := models.DeveloperUsageWindows[window]
+	if !ok {
+		return nil, ErrInvalidUsageWindow
+	}
+
+	to := s.now().UTC()
+	// Include the partial hour at the start of the window
+	from := to.Add(-length).Truncate(time.Hour)
+
+	keys, err := s.apiKeyRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	keyBuckets, err := s.repo.ListAPIKeyUsage(ctx, userID, from)
+	if err != nil {
+		return nil, err
+	}
+	subscriptions, err := s.webhookRepo.GetSubscriptionsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	deliveryBuckets, err := s.repo.ListWebhookDeliveryUsage(ctx, userID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &models.DeveloperUsage{
+		Window:      window,
+		Granularity: models.DeveloperUsageGranularity,
+		From:        from,
+		To:          to,
+		APIKeys:     make([]models.APIKeyUsage, 0, len(keys)),
+		Webhooks:    make([]models.WebhookDeliveryUsage, 0, len(subscriptions)),
+	}
+
+	// Hours not yet flushed are included so the current hour is up to date
+	keyBuckets = s.mergePending(keyBuckets, from)
+
+	keyIndex := make(map[uuid.UUID]int, len(keys))
+	for _, key := range keys {
+		keyIndex[key.ID] = len(usage.APIKeys)
+		usage.APIKeys = append(usage.APIKeys, models.APIKeyUsage{
+			APIKeyID:  key.ID,
+			Name:      key.Name,
+			KeyPrefix: key.KeyPrefix,
+			Hourly:    []models.APIKeyUsageBucket{},
+		})
+	}
+	for _, bucket := range keyBuckets {
+		i, ok := keyIndex[bucket.APIKeyID]
+		if !ok {
+			continue
+		}
+		keyUsage := &usage.APIKeys[i]
+		keyUsage.Requests += bucket.Requests
+		keyUsage.Errors += bucket.Errors
+		keyUsage.RateLimited += bucket.RateLimited
+		keyUsage.Hourly = append(keyUsage.Hourly, bucket)
+
+		usage.Totals.Requests += bucket.Requests
+		usage.Totals.Errors += bucket.Errors
+		usage.Totals.RateLimited += bucket.RateLimited
+	}
+	for i := range usage.APIKeys {
+		usage.APIKeys[i].ErrorRate = ratio(usage.APIKeys[i].Errors, usage.APIKeys[i].Requests)
+	}
+	usage.Totals.ErrorRate = ratio(usage.Totals.Errors, usage.Totals.Requests)
+
+	subscriptionIndex := make(map[uuid.UUID]int, len(subscriptions))
+	for _, subscription := range subscriptions {
+		subscriptionIndex[subscription.ID] = len(usage.Webhooks)
+		usage.Webhooks = append(usage.Webhooks, models.WebhookDeliveryUsage{
+			SubscriptionID: subscription.ID,
+			URL:            subscription.URL,
+			Hourly:         []models.WebhookDeliveryBucket{},
+		})
+	}
+	for _, bucket := range deliveryBuckets {
+		i, ok := subscriptionIndex[bucket.SubscriptionID]
+		if !ok {
+			continue
+		}
+		webhookUsage := &usage.Webhooks[i]
+		webhookUsage.Total += bucket.Total
+		webhookUsage.Delivered += bucket.Delivered
+		webhookUsage.Failed += bucket.Failed
+		webhookUsage.Pending += bucket.Pending
+		webhookUsage.Hourly = append(webhookUsage.Hourly, bucket)
+
+		usage.Totals.WebhookDeliveries += bucket.Total
+		usage.Totals.WebhookFailures += bucket.Failed
+	}
+	for i := range usage.Webhooks {
+		// Pending deliveries have not succeeded or failed yet
+		settled := usage.Webhooks[i].Delivered + usage.Webhooks[i].Failed
+		usage.Webhooks[i].SuccessRate = ratio(usage.Webhooks[i].Delivered, settled)
+	}
+
+	return usage, nil
+}
+
+// mergePending adds usage recorded since the last flush to stored hourly buckets
+func (s *DeveloperUsageService) mergePending(buckets []models.APIKeyUsageBucket, from time.Time) []models.APIKeyUsageBucket {
+	index := make(map[apiKeyUsageKey]int, len(buckets))
+	for i, bucket := range buckets {
+		index[apiKeyUsageKey{apiKeyID: bucket.APIKeyID, hour: bucket.Hour.UTC()}] = i
+	}
+
+	added := false
+	s.usage.each(func(key apiKeyUsageKey, pending *models.APIKeyUsageBucket) {
+		if key.hour.Before(from) {
+			return
+		}
+		if i, ok := index[key]; ok {
+			addAPIKeyUsage(&buckets[i], pending)
+			return
+		}
+		buckets = append(buckets, *pending)
+		added = true
+	})
+
+	if added {
+		sort.Slice(buckets, func(i, j int) bool { return buckets[i].Hour.Before(buckets[j].Hour) })
+	}
+
+	return buckets
+}
+
+// addAPIKeyUsage adds the counts in from to into
+func addAPIKeyUsage(into, from *models.APIKeyUsageBucket) {
+	into.Requests += from.Requests
+	into.Errors += from.Errors
+	into.RateLimited += from.RateLimited
+}
+
+// ratio returns part/total, or 0 when total is 0
+func ratio(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total)
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// MockDeveloperUsageRepository is a mock implementation of DeveloperUsageRepositoryInterface
+type MockDeveloperUsageRepository struct {
+	mock.Mock
+}
+
+func (m *MockDeveloperUsageRepository) AddAPIKeyUsage(ctx context.Context, buckets []models.APIKeyUsageBucket) error {
+	args := m.Called(ctx, buckets)
+	return args.Error(0)
+}
+
+func (m *MockDeveloperUsageRepository) ListAPIKeyUsage(ctx context.Context, userID uuid.UUID, since time.Time) ([]models.APIKeyUsageBucket, error) {
+	args := m.Called(ctx, userID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.APIKeyUsageBucket), args.Error(1)
+}
+
+func (m *MockDeveloperUsageRepository) ListWebhookDeliveryUsage(ctx context.Context, userID uuid.UUID, since time.Time) ([]models.WebhookDeliveryBucket, error) {
+	args := m.Called(ctx, userID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.WebhookDeliveryBucket), args.Error(1)
+}
+
+func (m *MockDeveloperUsageRepository) DeleteAPIKeyUsageBefore(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockDeveloperUsageWebhookLister is a mock implementation of DeveloperUsageWebhookLister
+type MockDeveloperUsageWebhookLister struct {
+	mock.Mock
+}
+
+func (m *MockDeveloperUsageWebhookLister) GetSubscriptionsByUserID(ctx context.Context, userID uuid.UUID) ([]*models.WebhookSubscription, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.WebhookSubscription), args.Error(1)
+}
+
+// setupDeveloperUsageServiceTest builds a DeveloperUsageService over mocks with the clock read from now
+func setupDeveloperUsageServiceTest(now *time.Time) (*DeveloperUsageService, *MockDeveloperUsageRepository, *MockAPIKeyRepository, *MockDeveloperUsageWebhookLister) {
+	repo := new(MockDeveloperUsageRepository)
+	keys := new(MockAPIKeyRepository)
+	webhooks := new(MockDeveloperUsageWebhookLister)
+
+	svc := NewDeveloperUsageService(repo, keys, webhooks)
+	svc.now = func() time.Time { return *now }
+	return svc, repo, keys, webhooks
+}
+
+// usageBucketAt returns the bucket for the hour, or an empty bucket if none was written
+func usageBucketAt(buckets []models.APIKeyUsageBucket, hour time.Time) models.APIKeyUsageBucket {
+	for _, bucket := range buckets {
+		if bucket.Hour.Equal(hour) {
+			return bucket
+		}
+	}
+	return models.APIKeyUsageBucket{}
+}
+
+func TestDeveloperUsageService_RecordAndFlush(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC)
+	svc, repo, _, _ := setupDeveloperUsageServiceTest(&now)
+	ctx := context.Background()
+	keyID := uuid.New()
+
+	// Nothing recorded: nothing to write
+	require.NoError(t, svc.Flush(ctx))
+	repo.AssertNotCalled(t, "AddAPIKeyUsage", mock.Anything, mock.Anything)
+
+	svc.Record(keyID, http.StatusOK)
+	svc.Record(keyID, http.StatusNotFound)
+	svc.Record(keyID, http.StatusTooManyRequests)
+	now = now.Add(time.Hour)
+	svc.Record(keyID, http.StatusInternalServerError)
+
+	var written []models.APIKeyUsageBucket
+	repo.On("AddAPIKeyUsage", ctx, mock.Anything).
+		Run(func(args mock.Arguments) {
+			written = args.Get(1).([]models.APIKeyUsageBucket)
+		}).
+		Return(nil).Once()
+
+	require.NoError(t, svc.Flush(ctx))
+	require.Len(t, written, 2)
+
+	first := usageBucketAt(written, time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	assert.Equal(t, keyID, first.APIKeyID)
+	assert.Equal(t, int64(3), first.Requests)
+	assert.Equal(t, int64(1), first.Errors, "rate-limited requests are not counted as errors")
+	assert.Equal(t, int64(1), first.RateLimited)
+
+	second := usageBucketAt(written, time.Date(2026, 3, 1, 13, 0, 0, 0, time.UTC))
+	assert.Equal(t, int64(1), second.Requests)
+	assert.Equal(t, int64(1), second.Errors)
+
+	// Flushed counts are not written again
+	require.NoError(t, svc.Flush(ctx))
+	repo.AssertNumberOfCalls(t, "AddAPIKeyUsage", 1)
+}
+
+func TestDeveloperUsageService_FailedFlushKeepsCounts(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc, repo, _, _ := setupDeveloperUsageServiceTest(&now)
+	ctx := context.Background()
+	keyID := uuid.New()
+
+	svc.Record(keyID, http.StatusOK)
+	repo.On("AddAPIKeyUsage", ctx, mock.Anything).Return(errors.New("database unavailable")).Once()
+	require.Error(t, svc.Flush(ctx))
+
+	svc.Record(keyID, http.StatusOK)
+	var written []models.APIKeyUsageBucket
+	repo.On("AddAPIKeyUsage", ctx, mock.Anything).
+		Run(func(args mock.Arguments) {
+			written = args.Get(1).([]models.APIKeyUsageBucket)
+		}).
+		Return(nil).Once()
+	require.NoError(t, svc.Flush(ctx))
+
+	require.Len(t, written, 1)
+	assert.Equal(t, int64(2), written[0].Requests)
+	repo.AssertExpectations(t)
+}
+
+func TestDeveloperUsageService_GetUsage(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 15, 0, 0, time.UTC)
+	svc, repo, keys, webhooks := setupDeveloperUsageServiceTest(&now)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	key := &models.APIKey{ID: uuid.New(), Name: "Bot", KeyPrefix: "abcd1234"}
+	idle := &models.APIKey{ID: uuid.New(), Name: "Idle", KeyPrefix: "ffff0000"}
+	subscription := &models.WebhookSubscription{ID: uuid.New(), URL: "https://example.com/hook"}
+
+	// The window starts at the top of the hour 24h ago
+	from := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	earlier := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	keys.On("ListByUserID", ctx, userID).Return([]*models.APIKey{key, idle}, nil)
+	webhooks.On("GetSubscriptionsByUserID", ctx, userID).Return([]*models.WebhookSubscription{subscription}, nil)
+	// Flushed usage from earlier today
+	repo.On("ListAPIKeyUsage", ctx, userID, from).Return([]models.APIKeyUsageBucket{
+		{APIKeyID: key.ID, Hour: earlier, Requests: 8, Errors: 1, RateLimited: 2},
+	}, nil)
+	repo.On("ListWebhookDeliveryUsage", ctx, userID, from).Return([]models.WebhookDeliveryBucket{
+		{SubscriptionID: subscription.ID, Hour: earlier, Total: 5, Delivered: 3, Failed: 1, Pending: 1},
+	}, nil)
+
+	// Unflushed usage from the current hour
+	svc.Record(key.ID, http.StatusOK)
+	svc.Record(key.ID, http.StatusBadRequest)
+
+	usage, err := svc.GetUsage(ctx, userID, "24h")
+	require.NoError(t, err)
+
+	assert.Equal(t, models.DeveloperUsageGranularity, usage.Granularity)
+	assert.Equal(t, from, usage.From)
+
+	require.Len(t, usage.APIKeys, 2)
+	assert.Equal(t, int64(10), usage.APIKeys[0].Requests)
+	assert.Equal(t, int64(2), usage.APIKeys[0].Errors)
+	assert.Equal(t, int64(2), usage.APIKeys[0].RateLimited)
+	assert.InDelta(t, 0.2, usage.APIKeys[0].ErrorRate, 0.0001)
+	require.Len(t, usage.APIKeys[0].Hourly, 2)
+	assert.Equal(t, earlier, usage.APIKeys[0].Hourly[0].Hour)
+	assert.Empty(t, usage.APIKeys[1].Hourly)
+	assert.Equal(t, int64(10), usage.Totals.Requests)
+
+	require.Len(t, usage.Webhooks, 1)
+	assert.Equal(t, int64(5), usage.Webhooks[0].Total)
+	assert.InDelta(t, 0.75, usage.Webhooks[0].SuccessRate, 0.0001)
+	assert.Equal(t, int64(5), usage.Totals.WebhookDeliveries)
+	assert.Equal(t, int64(1), usage.Totals.WebhookFailures)
+}
+
+func TestDeveloperUsageService_GetUsageRejectsUnknownWindow(t *testing.T) {
+	now := time.Now()
+	svc, repo, _, _ := setupDeveloperUsageServiceTest(&now)
+
+	_, err := svc.GetUsage(context.Background(), uuid.New(), "1y")
+	assert.ErrorIs(t, err, ErrInvalidUsageWindow)
+	repo.AssertNotCalled(t, "ListAPIKeyUsage", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"strings"
@@ -22,6 +23,41 @@ const (
 	StrikeRemovalReasonExpired       = "expired"
 )
 
+// counterNoticeMinWaitBusinessDays is the fewest business days content stays
+// down after a counter-notice is forwarded, giving the complainant time to
+// file suit
+const counterNoticeMinWaitBusinessDays = 10
+
+var (
+	// ErrDMCAInvalidTransition is returned when a notice, counter-notice or
+	// strike isn't in a status that allows the requested step
+	ErrDMCAInvalidTransition = errors.New("DMCA case is not in a status that allows this action")
+	// ErrDMCACounterNoticeExists is returned when the removed clip already has an open counter-notice
+	ErrDMCACounterNoticeExists = errors.New("a counter-notice for this material is already open")
+	// ErrDMCACounterNoticeForbidden is returned when a signed-in user files a counter-notice for a clip they didn't submit
+	ErrDMCACounterNoticeForbidden = errors.New("you can only file a counter-notice for your own content")
+)
+
+// counterNoticeTransitions lists the statuses a counter-notice can move to.
+// Pending counter-notices are reviewed: valid ones are forwarded to the
+// complainant and wait out the waiting period, others are rejected. Waiting
+// counter-notices are reinstated once the period ends, or rejected when the
+// complainant files suit.
+var counterNoticeTransitions = map[string][]string{
+	"pending": {"waiting", "rejected"},
+	"waiting": {"reinstated", "rejected"},
+}
+
+// canTransitionCounterNotice reports whether a counter-notice can move from one status to another
+func canTransitionCounterNotice(from, to string) bool {
+	for _, next := range counterNoticeTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
 // DMCAService handles DMCA takedown notices, counter-notices, and strike management
 type DMCAService struct {
 	repo           *repository.DMCARepository
@@ -33,18 +69,21 @@ type DMCAService struct {
 	db             *pgxpool.Pool
 	baseURL        string
 	dmcaAgentEmail string
+	strikeExpiry   int // months
 	logger         *utils.StructuredLogger
 }
 
-// SearchIndexer interface for removing content from search index
+// SearchIndexer interface for removing content from, and restoring it to, the search index
 type SearchIndexer interface {
-	DeleteClipFromIndex(ctx context.Context, clipID uuid.UUID) error
+	DeleteClip(ctx context.Context, clipID uuid.UUID) error
+	IndexClip(ctx context.Context, clip *models.Clip) error
 }
 
 // DMCAServiceConfig holds configuration for DMCA service
 type DMCAServiceConfig struct {
-	BaseURL        string
-	DMCAAgentEmail string
+	BaseURL            string
+	DMCAAgentEmail     string
+	StrikeExpiryMonths int // How long a strike counts against a user, 12 months when unset
 }
 
 // NewDMCAService creates a new DMCA service
@@ -58,6 +97,10 @@ func NewDMCAService(
 	db *pgxpool.Pool,
 	cfg *DMCAServiceConfig,
 ) *DMCAService {
+	strikeExpiry := cfg.StrikeExpiryMonths
+	if strikeExpiry <= 0 {
+		strikeExpiry = 12
+	}
 	return &DMCAService{
 		repo:           repo,
 		clipRepo:       clipRepo,
@@ -68,6 +111,7 @@ func NewDMCAService(
 		db:             db,
 		baseURL:        cfg.BaseURL,
 		dmcaAgentEmail: cfg.DMCAAgentEmail,
+		strikeExpiry:   strikeExpiry,
 		logger:         utils.GetLogger(),
 	}
 }
@@ -105,6 +149,12 @@ func (s *DMCAService) SubmitTakedownNotice(ctx context.Context, req *models.Subm
 		return nil, fmt.Errorf("failed to create notice: %w", err)
 	}
 
+	s.recordCaseEvent(ctx, &models.DMCACaseEvent{
+		DMCANoticeID: notice.ID,
+		Event:        models.DMCAEventNoticeReceived,
+		ToStatus:     stringPtr(notice.Status),
+	})
+
 	// Send confirmation email to complainant
 	if err := s.sendTakedownNoticeConfirmation(ctx, notice); err != nil {
 		s.logger.Error("Failed to send takedown notice confirmation email", nil, map[string]interface{}{
@@ -233,17 +283,23 @@ func (s *DMCAService) ReviewNotice(ctx context.Context, noticeID, reviewerID uui
 		return fmt.Errorf("invalid status: must be 'valid' or 'invalid'")
 	}
 
-	// Update notice status
-	if err := s.repo.UpdateNoticeStatus(ctx, noticeID, status, reviewerID, notes); err != nil {
-		return fmt.Errorf("failed to update notice status: %w", err)
-	}
-
 	// Get notice details
 	notice, err := s.repo.GetNoticeByID(ctx, noticeID)
 	if err != nil {
 		return fmt.Errorf("failed to get notice: %w", err)
 	}
 
+	// Processed notices have already taken content down
+	if notice.Status == "processed" {
+		return fmt.Errorf("%w: notice has already been processed", ErrDMCAInvalidTransition)
+	}
+	previousStatus := notice.Status
+
+	// Update notice status
+	if err := s.repo.UpdateNoticeStatus(ctx, noticeID, status, reviewerID, notes); err != nil {
+		return fmt.Errorf("failed to update notice status: %w", err)
+	}
+
 	// Send email based on status
 	if status == "invalid" {
 		if err := s.sendNoticeIncompleteEmail(ctx, notice); err != nil {
@@ -268,6 +324,15 @@ func (s *DMCAService) ReviewNotice(ctx context.Context, noticeID, reviewerID uui
 		s.logger.Error("Failed to create audit log", nil, map[string]interface{}{"error": err.Error()})
 	}
 
+	s.recordCaseEvent(ctx, &models.DMCACaseEvent{
+		DMCANoticeID: noticeID,
+		Event:        models.DMCAEventNoticeReviewed,
+		ActorID:      &reviewerID,
+		FromStatus:   &previousStatus,
+		ToStatus:     &status,
+		Notes:        notes,
+	})
+
 	return nil
 }
 
@@ -280,7 +345,7 @@ func (s *DMCAService) ProcessTakedown(ctx context.Context, noticeID, adminID uui
 	}
 
 	if notice.Status != "valid" {
-		return fmt.Errorf("notice must be validated before processing")
+		return fmt.Errorf("%w: notice must be validated before processing", ErrDMCAInvalidTransition)
 	}
 
 	// Start transaction
@@ -343,7 +408,7 @@ func (s *DMCAService) ProcessTakedown(ctx context.Context, noticeID, adminID uui
 
 	// Issue strikes and notify users (outside transaction)
 	for userID := range affectedUserIDs {
-		if err := s.issueStrikeAndNotify(ctx, userID, notice.ID); err != nil {
+		if err := s.issueStrikeAndNotify(ctx, userID, notice.ID, adminID); err != nil {
 			s.logger.Error("Failed to issue strike", nil, map[string]interface{}{
 				"user_id":   userID,
 				"notice_id": noticeID,
@@ -374,6 +439,18 @@ func (s *DMCAService) ProcessTakedown(ctx context.Context, noticeID, adminID uui
 		s.logger.Error("Failed to create audit log", nil, map[string]interface{}{"error": err.Error()})
 	}
 
+	s.recordCaseEvent(ctx, &models.DMCACaseEvent{
+		DMCANoticeID: noticeID,
+		Event:        models.DMCAEventTakedownProcessed,
+		ActorID:      &adminID,
+		FromStatus:   stringPtr("valid"),
+		ToStatus:     stringPtr("processed"),
+		Metadata: map[string]interface{}{
+			"clips_removed":  removedClipIDs,
+			"users_affected": len(affectedUserIDs),
+		},
+	})
+
 	return nil
 }
 
@@ -394,7 +471,7 @@ func (s *DMCAService) removeClipForDMCA(ctx context.Context, tx pgx.Tx, clipID,
 
 	// Remove from search index (best effort)
 	if s.searchIndexer != nil {
-		if err := s.searchIndexer.DeleteClipFromIndex(ctx, clipID); err != nil {
+		if err := s.searchIndexer.DeleteClip(ctx, clipID); err != nil {
 			s.logger.Warn("Failed to remove clip from search index", map[string]interface{}{
 				"clip_id": clipID,
 				"error":   err.Error(),
@@ -440,7 +517,7 @@ func (s *DMCAService) extractClipIDFromURL(urlStr string) (uuid.UUID, error) {
 // ==============================================================================
 
 // issueStrikeAndNotify issues a copyright strike to a user and applies penalties
-func (s *DMCAService) issueStrikeAndNotify(ctx context.Context, userID, noticeID uuid.UUID) error {
+func (s *DMCAService) issueStrikeAndNotify(ctx context.Context, userID, noticeID, adminID uuid.UUID) error {
 	// Get user's current active strikes
 	activeStrikes, err := s.repo.GetUserActiveStrikes(ctx, userID)
 	if err != nil {
@@ -455,7 +532,7 @@ func (s *DMCAService) issueStrikeAndNotify(ctx context.Context, userID, noticeID
 		DMCANoticeID: noticeID,
 		StrikeNumber: strikeNumber,
 		IssuedAt:     time.Now(),
-		ExpiresAt:    time.Now().AddDate(1, 0, 0), // Expires in 12 months
+		ExpiresAt:    time.Now().AddDate(0, s.strikeExpiry, 0),
 		Status:       "active",
 	}
 
@@ -477,7 +554,7 @@ func (s *DMCAService) issueStrikeAndNotify(ctx context.Context, userID, noticeID
 	case 2:
 		// Strike 2: 7-day suspension
 		suspendUntil := time.Now().AddDate(0, 0, 7)
-		if err := s.suspendUser(ctx, userID, suspendUntil); err != nil {
+		if err := s.suspendUser(ctx, userID, adminID, suspendUntil); err != nil {
 			return fmt.Errorf("failed to suspend user: %w", err)
 		}
 		if err := s.sendStrike2SuspensionEmail(ctx, userID, strike, suspendUntil); err != nil {
@@ -489,7 +566,7 @@ func (s *DMCAService) issueStrikeAndNotify(ctx context.Context, userID, noticeID
 
 	case 3:
 		// Strike 3: Permanent termination
-		if err := s.terminateUser(ctx, userID); err != nil {
+		if err := s.terminateUser(ctx, userID, adminID); err != nil {
 			return fmt.Errorf("failed to terminate user: %w", err)
 		}
 		if err := s.sendStrike3TerminationEmail(ctx, userID, strike); err != nil {
@@ -502,9 +579,10 @@ func (s *DMCAService) issueStrikeAndNotify(ctx context.Context, userID, noticeID
 
 	// Audit log
 	if err := s.auditLogRepo.Create(ctx, &models.ModerationAuditLog{
-		Action:     "dmca_strike_issued",
-		EntityType: "dmca_strike",
-		EntityID:   strike.ID,
+		Action:      "dmca_strike_issued",
+		EntityType:  "dmca_strike",
+		EntityID:    strike.ID,
+		ModeratorID: adminID,
 		Metadata: map[string]interface{}{
 			"user_id":       userID,
 			"strike_number": strikeNumber,
@@ -513,11 +591,24 @@ func (s *DMCAService) issueStrikeAndNotify(ctx context.Context, userID, noticeID
 		s.logger.Error("Failed to create audit log", nil, map[string]interface{}{"error": err.Error()})
 	}
 
+	s.recordCaseEvent(ctx, &models.DMCACaseEvent{
+		DMCANoticeID: noticeID,
+		StrikeID:     &strike.ID,
+		Event:        models.DMCAEventStrikeIssued,
+		ActorID:      &adminID,
+		ToStatus:     stringPtr(strike.Status),
+		Metadata: map[string]interface{}{
+			"user_id":       userID,
+			"strike_number": strikeNumber,
+			"expires_at":    strike.ExpiresAt,
+		},
+	})
+
 	return nil
 }
 
 // suspendUser temporarily suspends a user's account
-func (s *DMCAService) suspendUser(ctx context.Context, userID uuid.UUID, until time.Time) error {
+func (s *DMCAService) suspendUser(ctx context.Context, userID, adminID uuid.UUID, until time.Time) error {
 	query := `
 		UPDATE users
 		SET dmca_suspended_until = $1
@@ -530,9 +621,10 @@ func (s *DMCAService) suspendUser(ctx context.Context, userID uuid.UUID, until t
 
 	// Audit log
 	if err := s.auditLogRepo.Create(ctx, &models.ModerationAuditLog{
-		Action:     "dmca_user_suspended",
-		EntityType: "user",
-		EntityID:   userID,
+		Action:      "dmca_user_suspended",
+		EntityType:  "user",
+		EntityID:    userID,
+		ModeratorID: adminID,
 		Metadata: map[string]interface{}{
 			"suspended_until": until,
 		},
@@ -544,7 +636,7 @@ func (s *DMCAService) suspendUser(ctx context.Context, userID uuid.UUID, until t
 }
 
 // terminateUser permanently terminates a user's account
-func (s *DMCAService) terminateUser(ctx context.Context, userID uuid.UUID) error {
+func (s *DMCAService) terminateUser(ctx context.Context, userID, adminID uuid.UUID) error {
 	query := `
 		UPDATE users
 		SET dmca_terminated = true,
@@ -573,9 +665,10 @@ func (s *DMCAService) terminateUser(ctx context.Context, userID uuid.UUID) error
 
 	// Audit log
 	if err := s.auditLogRepo.Create(ctx, &models.ModerationAuditLog{
-		Action:     "dmca_user_terminated",
-		EntityType: "user",
-		EntityID:   userID,
+		Action:      "dmca_user_terminated",
+		EntityType:  "user",
+		EntityID:    userID,
+		ModeratorID: adminID,
 		Metadata: map[string]interface{}{
 			"reason": "repeat_copyright_infringement",
 		},
@@ -827,24 +920,54 @@ func (s *DMCAService) sendStrike3TerminationEmail(ctx context.Context, userID uu
 // Counter-Notice Submission and Processing
 // ==============================================================================
 
-// SubmitCounterNotice validates and creates a new DMCA counter-notice
+// SubmitCounterNotice validates and creates a new DMCA counter-notice. The
+// material must be a clip taken down under the notice, and a signed-in user
+// can only file for clips they submitted.
 func (s *DMCAService) SubmitCounterNotice(ctx context.Context, req *models.SubmitDMCACounterNoticeRequest, userID *uuid.UUID, ipAddress, userAgent string) (*models.DMCACounterNotice, error) {
 	// Validate counter-notice
 	if err := s.validateCounterNotice(req); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
+	clipID, err := s.extractClipIDFromURL(req.RemovedMaterialURL)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
 	// Verify the DMCA notice exists and content was actually removed
 	notice, err := s.repo.GetNoticeByID(ctx, req.DMCANoticeID)
 	if err != nil {
-		return nil, fmt.Errorf("DMCA notice not found: %w", err)
+		return nil, fmt.Errorf("failed to get notice: %w", err)
 	}
 
 	if notice.Status != "processed" {
-		return nil, fmt.Errorf("DMCA notice has not been processed yet")
+		return nil, fmt.Errorf("%w: DMCA notice has not been processed yet", ErrDMCAInvalidTransition)
+	}
+
+	submittedBy, err := s.repo.GetRemovedClipSubmitter(ctx, clipID, notice.ID)
+	if err != nil {
+		return nil, err
+	}
+	if userID != nil && (submittedBy == nil || *submittedBy != *userID) {
+		return nil, ErrDMCACounterNoticeForbidden
 	}
 
-	// Create counter-notice with 10-14 business day waiting period
+	// Only one counter-notice per removed clip can be open at a time
+	existing, err := s.repo.ListCounterNoticesByNotice(ctx, notice.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get counter-notices: %w", err)
+	}
+	for _, cn := range existing {
+		if cn.Status == "reinstated" || cn.Status == "rejected" {
+			continue
+		}
+		if existingClipID, err := s.extractClipIDFromURL(cn.RemovedMaterialURL); err == nil && existingClipID == clipID {
+			return nil, ErrDMCACounterNoticeExists
+		}
+	}
+
+	// Estimated end of the 10-14 business day waiting period; the hold
+	// starts once the counter-notice is forwarded to the complainant
 	waitingPeriodEnd := s.calculateWaitingPeriodEnd(time.Now())
 
 	counterNotice := &models.DMCACounterNotice{
@@ -892,6 +1015,17 @@ func (s *DMCAService) SubmitCounterNotice(ctx context.Context, req *models.Submi
 		s.logger.Error("Failed to create audit log", nil, map[string]interface{}{"error": err.Error()})
 	}
 
+	s.recordCaseEvent(ctx, &models.DMCACaseEvent{
+		DMCANoticeID:    counterNotice.DMCANoticeID,
+		CounterNoticeID: &counterNotice.ID,
+		Event:           models.DMCAEventCounterNoticeReceived,
+		ActorID:         userID,
+		ToStatus:        stringPtr(counterNotice.Status),
+		Metadata: map[string]interface{}{
+			"clip_id": clipID,
+		},
+	})
+
 	return counterNotice, nil
 }
 
@@ -925,8 +1059,12 @@ func (s *DMCAService) validateCounterNotice(req *models.SubmitDMCACounterNoticeR
 // Note: Calculations are performed in UTC for consistency across timezones
 func (s *DMCAService) calculateWaitingPeriodEnd(start time.Time) time.Time {
 	// Use 14 business days (approximately 20 calendar days accounting for weekends)
-	// Standardize to UTC for consistent legal compliance
-	businessDays := 14
+	return addBusinessDays(start, 14)
+}
+
+// addBusinessDays adds business days to a time, skipping weekends
+// Standardized to UTC for consistent legal compliance
+func addBusinessDays(start time.Time, businessDays int) time.Time {
 	daysAdded := 0
 	current := start.UTC()
 
@@ -941,38 +1079,53 @@ func (s *DMCAService) calculateWaitingPeriodEnd(start time.Time) time.Time {
 	return current
 }
 
-// ForwardCounterNoticeToComplainant forwards counter-notice to original complainant
-func (s *DMCAService) ForwardCounterNoticeToComplainant(ctx context.Context, counterNoticeID, adminID uuid.UUID) error {
+// forwardedWaitingPeriodEnd returns when content can be reinstated for a
+// counter-notice forwarded at forwardedAt: the end of the waiting period
+// set on submission, but never fewer than 10 business days after forwarding
+func forwardedWaitingPeriodEnd(submittedEnd *time.Time, forwardedAt time.Time) time.Time {
+	minimum := addBusinessDays(forwardedAt, counterNoticeMinWaitBusinessDays)
+	if submittedEnd != nil && submittedEnd.After(minimum) {
+		return submittedEnd.UTC()
+	}
+	return minimum
+}
+
+// ForwardCounterNoticeToComplainant forwards a pending counter-notice to the
+// original complainant, which starts the waiting period
+func (s *DMCAService) ForwardCounterNoticeToComplainant(ctx context.Context, counterNoticeID, adminID uuid.UUID) (*models.DMCACounterNotice, error) {
 	// Get counter-notice
 	cn, err := s.repo.GetCounterNoticeByID(ctx, counterNoticeID)
 	if err != nil {
-		return fmt.Errorf("failed to get counter-notice: %w", err)
+		return nil, fmt.Errorf("failed to get counter-notice: %w", err)
 	}
 
-	if cn.Status != "pending" {
-		return fmt.Errorf("counter-notice must be pending")
+	if !canTransitionCounterNotice(cn.Status, "waiting") {
+		return nil, fmt.Errorf("%w: counter-notice must be pending", ErrDMCAInvalidTransition)
 	}
 
 	// Get original notice
 	notice, err := s.repo.GetNoticeByID(ctx, cn.DMCANoticeID)
 	if err != nil {
-		return fmt.Errorf("failed to get original notice: %w", err)
+		return nil, fmt.Errorf("failed to get original notice: %w", err)
 	}
 
+	waitingPeriodEnd := forwardedWaitingPeriodEnd(cn.WaitingPeriodEnds, time.Now())
+	cn.WaitingPeriodEnds = &waitingPeriodEnd
+
 	// Send counter-notice to complainant
 	if err := s.sendCounterNoticeToComplainantEmail(ctx, cn, notice); err != nil {
-		return fmt.Errorf("failed to send counter-notice to complainant: %w", err)
+		return nil, fmt.Errorf("failed to send counter-notice to complainant: %w", err)
 	}
 
-	// Mark as forwarded and update status to waiting
-	if err := s.repo.MarkCounterNoticeForwarded(ctx, counterNoticeID); err != nil {
-		return fmt.Errorf("failed to mark counter-notice as forwarded: %w", err)
+	// Mark as forwarded and start the waiting period
+	forwarded, err := s.repo.MarkCounterNoticeForwarded(ctx, counterNoticeID, waitingPeriodEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark counter-notice as forwarded: %w", err)
 	}
-
-	// Update status to waiting
-	if err := s.repo.UpdateCounterNoticeStatus(ctx, counterNoticeID, "waiting", nil); err != nil {
-		return fmt.Errorf("failed to update counter-notice status: %w", err)
+	if !forwarded {
+		return nil, fmt.Errorf("%w: counter-notice is no longer pending", ErrDMCAInvalidTransition)
 	}
+	cn.Status = "waiting"
 
 	// Audit log
 	if err := s.auditLogRepo.Create(ctx, &models.ModerationAuditLog{
@@ -980,47 +1133,129 @@ func (s *DMCAService) ForwardCounterNoticeToComplainant(ctx context.Context, cou
 		EntityType:  "dmca_counter_notice",
 		EntityID:    counterNoticeID,
 		ModeratorID: adminID,
+		Metadata: map[string]interface{}{
+			"waiting_period_ends": waitingPeriodEnd,
+		},
+	}); err != nil {
+		s.logger.Error("Failed to create audit log", nil, map[string]interface{}{"error": err.Error()})
+	}
+
+	s.recordCaseEvent(ctx, &models.DMCACaseEvent{
+		DMCANoticeID:    cn.DMCANoticeID,
+		CounterNoticeID: &cn.ID,
+		Event:           models.DMCAEventCounterNoticeForwarded,
+		ActorID:         &adminID,
+		FromStatus:      stringPtr("pending"),
+		ToStatus:        stringPtr("waiting"),
+		Metadata: map[string]interface{}{
+			"waiting_period_ends": waitingPeriodEnd,
+		},
+	})
+
+	return cn, nil
+}
+
+// RejectCounterNotice rejects a pending counter-notice that isn't valid, so
+// it's never forwarded and the content stays removed
+func (s *DMCAService) RejectCounterNotice(ctx context.Context, counterNoticeID, adminID uuid.UUID, notes *string) error {
+	cn, err := s.repo.GetCounterNoticeByID(ctx, counterNoticeID)
+	if err != nil {
+		return fmt.Errorf("failed to get counter-notice: %w", err)
+	}
+
+	if cn.Status != "pending" {
+		return fmt.Errorf("%w: only pending counter-notices can be rejected", ErrDMCAInvalidTransition)
+	}
+
+	rejected, err := s.repo.TransitionCounterNoticeStatus(ctx, counterNoticeID, "pending", "rejected", notes)
+	if err != nil {
+		return fmt.Errorf("failed to reject counter-notice: %w", err)
+	}
+	if !rejected {
+		return fmt.Errorf("%w: counter-notice is no longer pending", ErrDMCAInvalidTransition)
+	}
+
+	// Audit log
+	if err := s.auditLogRepo.Create(ctx, &models.ModerationAuditLog{
+		Action:      "dmca_counter_notice_rejected",
+		EntityType:  "dmca_counter_notice",
+		EntityID:    counterNoticeID,
+		ModeratorID: adminID,
+		Reason:      notes,
 	}); err != nil {
 		s.logger.Error("Failed to create audit log", nil, map[string]interface{}{"error": err.Error()})
 	}
 
+	s.recordCaseEvent(ctx, &models.DMCACaseEvent{
+		DMCANoticeID:    cn.DMCANoticeID,
+		CounterNoticeID: &cn.ID,
+		Event:           models.DMCAEventCounterNoticeRejected,
+		ActorID:         &adminID,
+		FromStatus:      stringPtr("pending"),
+		ToStatus:        stringPtr("rejected"),
+		Notes:           notes,
+	})
+
 	return nil
 }
 
-// ProcessExpiredWaitingPeriods restores content for counter-notices past their waiting period
-func (s *DMCAService) ProcessExpiredWaitingPeriods(ctx context.Context) error {
+// ProcessExpiredWaitingPeriods restores content for counter-notices past
+// their waiting period and returns how many were reinstated
+func (s *DMCAService) ProcessExpiredWaitingPeriods(ctx context.Context) (int, error) {
 	// Get counter-notices awaiting restore
 	counterNotices, err := s.repo.GetCounterNoticesAwaitingRestore(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get counter-notices: %w", err)
+		return 0, fmt.Errorf("failed to get counter-notices: %w", err)
 	}
 
+	reinstated := 0
 	for _, cn := range counterNotices {
-		if err := s.reinstateContent(ctx, &cn); err != nil {
+		ok, err := s.reinstateContent(ctx, &cn)
+		if err != nil {
 			s.logger.Error("Failed to reinstate content", nil, map[string]interface{}{
 				"counter_notice_id": cn.ID,
 				"error":             err.Error(),
 			})
 			continue
 		}
+		if ok {
+			reinstated++
+		}
 	}
 
-	return nil
+	return reinstated, nil
 }
 
-// reinstateContent reinstates removed content after counter-notice waiting period
-func (s *DMCAService) reinstateContent(ctx context.Context, cn *models.DMCACounterNotice) error {
+// reinstateContent reinstates removed content after counter-notice waiting
+// period. It reports false when the counter-notice stopped waiting, e.g.
+// because a lawsuit was recorded in the meantime.
+func (s *DMCAService) reinstateContent(ctx context.Context, cn *models.DMCACounterNotice) (bool, error) {
+	// Extract clip ID from URL
+	clipID, err := s.extractClipIDFromURL(cn.RemovedMaterialURL)
+	if err != nil {
+		return false, fmt.Errorf("failed to extract clip ID: %w", err)
+	}
+
 	// Start transaction
 	tx, err := s.db.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
-	// Extract clip ID from URL
-	clipID, err := s.extractClipIDFromURL(cn.RemovedMaterialURL)
+	// Close the counter-notice first so a lawsuit recorded concurrently or a
+	// second run of the job can't reinstate the clip again
+	statusQuery := `
+		UPDATE dmca_counter_notices
+		SET status = 'reinstated', updated_at = NOW()
+		WHERE id = $1 AND status = 'waiting' AND lawsuit_filed = false`
+
+	result, err := tx.Exec(ctx, statusQuery, cn.ID)
 	if err != nil {
-		return fmt.Errorf("failed to extract clip ID: %w", err)
+		return false, fmt.Errorf("failed to update counter-notice status: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return false, nil
 	}
 
 	// Reinstate clip
@@ -1029,44 +1264,64 @@ func (s *DMCAService) reinstateContent(ctx context.Context, cn *models.DMCACount
 		SET dmca_removed = false,
 		    dmca_reinstated_at = NOW(),
 		    is_hidden = false
-		WHERE id = $1 AND dmca_removed = true`
+		WHERE id = $1 AND dmca_removed = true
+		RETURNING submitted_by_user_id`
 
-	_, err = tx.Exec(ctx, reinstateQuery, clipID)
-	if err != nil {
-		return fmt.Errorf("failed to reinstate clip: %w", err)
+	var submittedBy *uuid.UUID
+	err = tx.QueryRow(ctx, reinstateQuery, clipID).Scan(&submittedBy)
+	if err != nil && err != pgx.ErrNoRows {
+		return false, fmt.Errorf("failed to reinstate clip: %w", err)
 	}
 
-	// Remove strike if user has one related to this notice
-	if cn.UserID != nil {
+	// Remove the strike the takedown issued to the clip's submitter
+	strikeUserID := cn.UserID
+	if strikeUserID == nil {
+		strikeUserID = submittedBy
+	}
+	removedStrikes := []models.DMCAStrike{}
+	if strikeUserID != nil {
 		removeStrikeQuery := `
 			UPDATE dmca_strikes
 			SET status = 'removed', 
 			    removal_reason = $3,
-			    removed_at = NOW()
-			WHERE user_id = $1 AND dmca_notice_id = $2 AND status = 'active'`
+			    removed_at = NOW(),
+			    updated_at = NOW()
+			WHERE user_id = $1 AND dmca_notice_id = $2 AND status = 'active'
+			RETURNING id, user_id, strike_number`
 
-		_, err = tx.Exec(ctx, removeStrikeQuery, cn.UserID, cn.DMCANoticeID, StrikeRemovalReasonCounterNotice)
+		rows, err := tx.Query(ctx, removeStrikeQuery, strikeUserID, cn.DMCANoticeID, StrikeRemovalReasonCounterNotice)
 		if err != nil {
-			s.logger.Error("Failed to remove strike", nil, map[string]interface{}{
-				"user_id":   cn.UserID,
-				"notice_id": cn.DMCANoticeID,
-				"error":     err.Error(),
-			})
+			return false, fmt.Errorf("failed to remove strike: %w", err)
+		}
+		for rows.Next() {
+			strike := models.DMCAStrike{}
+			if err := rows.Scan(&strike.ID, &strike.UserID, &strike.StrikeNumber); err != nil {
+				rows.Close()
+				return false, fmt.Errorf("failed to scan removed strike: %w", err)
+			}
+			removedStrikes = append(removedStrikes, strike)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return false, fmt.Errorf("failed to remove strike: %w", err)
 		}
 	}
 
 	// Commit transaction
 	if err := tx.Commit(ctx); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	// Update counter-notice status (after transaction commits)
-	if err := s.repo.UpdateCounterNoticeStatus(ctx, cn.ID, "reinstated", nil); err != nil {
-		s.logger.Error("Failed to update counter-notice status after reinstatement", nil, map[string]interface{}{
-			"counter_notice_id": cn.ID,
-			"error":             err.Error(),
-		})
-		// Don't return error - content is already reinstated
+	// Put the clip back in search (best effort)
+	if s.searchIndexer != nil {
+		if clip, err := s.clipRepo.GetByID(ctx, clipID); err == nil {
+			if err := s.searchIndexer.IndexClip(ctx, clip); err != nil {
+				s.logger.Warn("Failed to restore clip to search index", map[string]interface{}{
+					"clip_id": clipID,
+					"error":   err.Error(),
+				})
+			}
+		}
 	}
 
 	// Send notification emails
@@ -1103,23 +1358,55 @@ func (s *DMCAService) reinstateContent(ctx context.Context, cn *models.DMCACount
 		s.logger.Error("Failed to create audit log", nil, map[string]interface{}{"error": err.Error()})
 	}
 
-	return nil
+	s.recordCaseEvent(ctx, &models.DMCACaseEvent{
+		DMCANoticeID:    cn.DMCANoticeID,
+		CounterNoticeID: &cn.ID,
+		Event:           models.DMCAEventContentReinstated,
+		FromStatus:      stringPtr("waiting"),
+		ToStatus:        stringPtr("reinstated"),
+		Metadata: map[string]interface{}{
+			"clip_id": clipID,
+		},
+	})
+	for i := range removedStrikes {
+		strike := removedStrikes[i]
+		s.recordCaseEvent(ctx, &models.DMCACaseEvent{
+			DMCANoticeID:    cn.DMCANoticeID,
+			CounterNoticeID: &cn.ID,
+			StrikeID:        &strike.ID,
+			Event:           models.DMCAEventStrikeRemoved,
+			FromStatus:      stringPtr("active"),
+			ToStatus:        stringPtr("removed"),
+			Metadata: map[string]interface{}{
+				"user_id":        strike.UserID,
+				"strike_number":  strike.StrikeNumber,
+				"removal_reason": StrikeRemovalReasonCounterNotice,
+			},
+		})
+	}
+
+	return true, nil
 }
 
-// MarkLawsuitFiled marks a counter-notice as having lawsuit filed (content stays removed)
-func (s *DMCAService) MarkLawsuitFiled(ctx context.Context, counterNoticeID, adminID uuid.UUID) error {
-	query := `
-		UPDATE dmca_counter_notices
-		SET lawsuit_filed = true,
-		    lawsuit_filed_at = NOW(),
-		    status = 'rejected',
-		    updated_at = NOW()
-		WHERE id = $1`
+// MarkLawsuitFiled records that the complainant filed suit during the
+// waiting period. The counter-notice is rejected and the content stays removed.
+func (s *DMCAService) MarkLawsuitFiled(ctx context.Context, counterNoticeID, adminID uuid.UUID, notes *string) error {
+	cn, err := s.repo.GetCounterNoticeByID(ctx, counterNoticeID)
+	if err != nil {
+		return fmt.Errorf("failed to get counter-notice: %w", err)
+	}
+
+	if cn.Status != "waiting" {
+		return fmt.Errorf("%w: lawsuits can only be recorded during the waiting period", ErrDMCAInvalidTransition)
+	}
 
-	_, err := s.db.Exec(ctx, query, counterNoticeID)
+	marked, err := s.repo.MarkLawsuitFiled(ctx, counterNoticeID, notes)
 	if err != nil {
 		return fmt.Errorf("failed to mark lawsuit filed: %w", err)
 	}
+	if !marked {
+		return fmt.Errorf("%w: counter-notice is no longer waiting", ErrDMCAInvalidTransition)
+	}
 
 	// Audit log
 	if err := s.auditLogRepo.Create(ctx, &models.ModerationAuditLog{
@@ -1127,29 +1414,173 @@ func (s *DMCAService) MarkLawsuitFiled(ctx context.Context, counterNoticeID, adm
 		EntityType:  "dmca_counter_notice",
 		EntityID:    counterNoticeID,
 		ModeratorID: adminID,
+		Reason:      notes,
 	}); err != nil {
 		s.logger.Error("Failed to create audit log", nil, map[string]interface{}{"error": err.Error()})
 	}
 
+	s.recordCaseEvent(ctx, &models.DMCACaseEvent{
+		DMCANoticeID:    cn.DMCANoticeID,
+		CounterNoticeID: &cn.ID,
+		Event:           models.DMCAEventLawsuitFiled,
+		ActorID:         &adminID,
+		FromStatus:      stringPtr("waiting"),
+		ToStatus:        stringPtr("rejected"),
+		Notes:           notes,
+	})
+
 	return nil
 }
 
+// RemoveStrike lifts an active strike by admin override
+func (s *DMCAService) RemoveStrike(ctx context.Context, strikeID, adminID uuid.UUID, notes *string) (*models.DMCAStrike, error) {
+	strike, err := s.repo.RemoveStrike(ctx, strikeID, StrikeRemovalReasonAdminOverride)
+	if err != nil {
+		return nil, err
+	}
+
+	// Audit log
+	if err := s.auditLogRepo.Create(ctx, &models.ModerationAuditLog{
+		Action:      "dmca_strike_removed",
+		EntityType:  "dmca_strike",
+		EntityID:    strike.ID,
+		ModeratorID: adminID,
+		Reason:      notes,
+		Metadata: map[string]interface{}{
+			"user_id":       strike.UserID,
+			"strike_number": strike.StrikeNumber,
+		},
+	}); err != nil {
+		s.logger.Error("Failed to create audit log", nil, map[string]interface{}{"error": err.Error()})
+	}
+
+	s.recordCaseEvent(ctx, &models.DMCACaseEvent{
+		DMCANoticeID: strike.DMCANoticeID,
+		StrikeID:     &strike.ID,
+		Event:        models.DMCAEventStrikeRemoved,
+		ActorID:      &adminID,
+		FromStatus:   stringPtr("active"),
+		ToStatus:     stringPtr("removed"),
+		Notes:        notes,
+		Metadata: map[string]interface{}{
+			"user_id":        strike.UserID,
+			"strike_number":  strike.StrikeNumber,
+			"removal_reason": StrikeRemovalReasonAdminOverride,
+		},
+	})
+
+	return strike, nil
+}
+
+// ==============================================================================
+// Admin Review
+// ==============================================================================
+
+// ListNotices lists DMCA notices for the admin panel
+func (s *DMCAService) ListNotices(ctx context.Context, status string, page, pageSize int) (*models.DMCANoticeListResponse, error) {
+	notices, total, err := s.repo.ListNotices(ctx, status, page, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notices: %w", err)
+	}
+	return &models.DMCANoticeListResponse{
+		Notices:    notices,
+		TotalCount: total,
+		Page:       page,
+		PageSize:   pageSize,
+	}, nil
+}
+
+// GetNoticeDetail returns a notice with its counter-notices and case history
+func (s *DMCAService) GetNoticeDetail(ctx context.Context, noticeID uuid.UUID) (*models.DMCANoticeDetail, error) {
+	notice, err := s.repo.GetNoticeByID(ctx, noticeID)
+	if err != nil {
+		return nil, err
+	}
+	counterNotices, err := s.repo.ListCounterNoticesByNotice(ctx, noticeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get counter-notices: %w", err)
+	}
+	events, err := s.repo.ListCaseEvents(ctx, noticeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get case history: %w", err)
+	}
+	return &models.DMCANoticeDetail{
+		Notice:         *notice,
+		CounterNotices: counterNotices,
+		Events:         events,
+	}, nil
+}
+
+// ListCounterNotices lists counter-notices for the admin panel
+func (s *DMCAService) ListCounterNotices(ctx context.Context, status string, page, pageSize int) (*models.DMCACounterNoticeListResponse, error) {
+	counterNotices, total, err := s.repo.ListCounterNotices(ctx, status, page, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list counter-notices: %w", err)
+	}
+	return &models.DMCACounterNoticeListResponse{
+		CounterNotices: counterNotices,
+		TotalCount:     total,
+		Page:           page,
+		PageSize:       pageSize,
+	}, nil
+}
+
+// GetCounterNotice retrieves a counter-notice
+func (s *DMCAService) GetCounterNotice(ctx context.Context, counterNoticeID uuid.UUID) (*models.DMCACounterNotice, error) {
+	return s.repo.GetCounterNoticeByID(ctx, counterNoticeID)
+}
+
+// GetDashboardStats returns statistics for the admin DMCA dashboard
+func (s *DMCAService) GetDashboardStats(ctx context.Context) (*models.DMCADashboardStats, error) {
+	return s.repo.GetDashboardStats(ctx)
+}
+
+// recordCaseEvent adds a step to the case history without failing the step itself
+func (s *DMCAService) recordCaseEvent(ctx context.Context, event *models.DMCACaseEvent) {
+	if err := s.repo.CreateCaseEvent(ctx, event); err != nil {
+		s.logger.Error("Failed to record DMCA case event", nil, map[string]interface{}{
+			"dmca_notice_id": event.DMCANoticeID,
+			"event":          event.Event,
+			"error":          err.Error(),
+		})
+	}
+}
+
 // ==============================================================================
 // Scheduled Jobs / Maintenance
 // ==============================================================================
 
-// ExpireOldStrikes marks strikes older than 12 months as expired
-func (s *DMCAService) ExpireOldStrikes(ctx context.Context) error {
-	count, err := s.repo.ExpireOldStrikes(ctx)
+// ExpireOldStrikes expires strikes older than the strike expiry so they no
+// longer count toward penalties, and returns how many expired
+func (s *DMCAService) ExpireOldStrikes(ctx context.Context) (int, error) {
+	strikes, err := s.repo.ExpireOldStrikes(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to expire strikes: %w", err)
+		return 0, fmt.Errorf("failed to expire strikes: %w", err)
+	}
+
+	for i := range strikes {
+		strike := strikes[i]
+		s.recordCaseEvent(ctx, &models.DMCACaseEvent{
+			DMCANoticeID: strike.DMCANoticeID,
+			StrikeID:     &strike.ID,
+			Event:        models.DMCAEventStrikeExpired,
+			FromStatus:   stringPtr("active"),
+			ToStatus:     stringPtr("expired"),
+			Metadata: map[string]interface{}{
+				"user_id":       strike.UserID,
+				"strike_number": strike.StrikeNumber,
+				"expires_at":    strike.ExpiresAt,
+			},
+		})
 	}
 
-	s.logger.Info("Expired old DMCA strikes", map[string]interface{}{
-		"count": count,
-	})
+	if len(strikes) > 0 {
+		s.logger.Info("Expired old DMCA strikes", map[string]interface{}{
+			"count": len(strikes),
+		})
+	}
 
-	return nil
+	return len(strikes), nil
 }
 
 // GetUserStrikes retrieves all strikes for a user
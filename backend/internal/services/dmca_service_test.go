@@ -444,6 +444,52 @@ func TestReviewNotice_InvalidStatus(t *testing.T) {
 	}
 }
 
+func TestCanTransitionCounterNotice(t *testing.T) {
+	tests := []struct {
+		from string
+		to   string
+		want bool
+	}{
+		{"pending", "waiting", true},
+		{"pending", "rejected", true},
+		{"pending", "reinstated", false},
+		{"waiting", "reinstated", true},
+		{"waiting", "rejected", true},
+		{"waiting", "pending", false},
+		{"reinstated", "waiting", false},
+		{"rejected", "waiting", false},
+		{"rejected", "reinstated", false},
+	}
+
+	for _, tt := range tests {
+		if got := canTransitionCounterNotice(tt.from, tt.to); got != tt.want {
+			t.Errorf("canTransitionCounterNotice(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestForwardedWaitingPeriodEnd(t *testing.T) {
+	submitted := time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC) // Monday
+	submittedEnd := addBusinessDays(submitted, 14)            // Friday Jan 24
+
+	// Forwarded promptly: the waiting period set on submission stands
+	forwardedAt := submitted.Add(2 * time.Hour)
+	if got := forwardedWaitingPeriodEnd(&submittedEnd, forwardedAt); !got.Equal(submittedEnd) {
+		t.Errorf("forwardedWaitingPeriodEnd() = %v, want %v", got, submittedEnd)
+	}
+
+	// Forwarded late: the complainant still gets 10 business days
+	forwardedAt = time.Date(2025, 1, 20, 10, 0, 0, 0, time.UTC) // Monday
+	want := time.Date(2025, 2, 3, 10, 0, 0, 0, time.UTC)
+	if got := forwardedWaitingPeriodEnd(&submittedEnd, forwardedAt); !got.Equal(want) {
+		t.Errorf("forwardedWaitingPeriodEnd() = %v, want %v", got, want)
+	}
+
+	if got := forwardedWaitingPeriodEnd(nil, forwardedAt); !got.Equal(want) {
+		t.Errorf("forwardedWaitingPeriodEnd() without a submitted end = %v, want %v", got, want)
+	}
+}
+
 // ==============================================================================
 // Helper Functions
 // ==============================================================================
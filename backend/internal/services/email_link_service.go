@@ -0,0 +1,276 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+const (
+	emailLinkTokenBytes = 16
+	emailLinkTTL        = 180 * 24 * time.Hour
+)
+
+// ErrEmailLinkExpired is returned when a tracked email link is past its expiry
+var ErrEmailLinkExpired = errors.New("email link has expired")
+
+var (
+	emailHrefPattern     = regexp.MustCompile(`href="(https?://[^"]+)"`)
+	emailTextURLPattern  = regexp.MustCompile(`https?://[^\s<>"']+`)
+	emailCampaignCleaner = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// EmailLinkRepositoryInterface defines the tracked link repository methods used by EmailLinkService
+type EmailLinkRepositoryInterface interface {
+	Create(ctx context.Context, link *models.EmailTrackedLink) error
+	GetByToken(ctx context.Context, token string) (*models.EmailTrackedLink, error)
+	RecordClick(ctx context.Context, linkID uuid.UUID, clickedAt time.Time) error
+}
+
+// EmailClickLogger records click events alongside SendGrid webhook events
+type EmailClickLogger interface {
+	CreateEmailLog(ctx context.Context, log *models.EmailLog) error
+}
+
+// EmailLinkContext identifies the email whose links are being rewritten
+type EmailLinkContext struct {
+	Template          string
+	Recipient         string
+	UserID            *uuid.UUID
+	NotificationLogID *uuid.UUID
+}
+
+// EmailLinkService rewrites email links into first-party tracked redirects
+type EmailLinkService struct {
+	repo    EmailLinkRepositoryInterface
+	logs    EmailClickLogger
+	baseURL string
+	host    string
+	now     func() time.Time
+}
+
+// NewEmailLinkService creates a new email link service
+func NewEmailLinkService(repo EmailLinkRepositoryInterface, logs EmailClickLogger, baseURL string) *EmailLinkService {
+	baseURL = strings.TrimRight(baseURL, "/")
+	host := ""
+	if parsed, err := url.Parse(baseURL); err == nil {
+		host = strings.ToLower(parsed.Host)
+	}
+
+	return &EmailLinkService{
+		repo:    repo,
+		logs:    logs,
+		baseURL: baseURL,
+		host:    host,
+		now:     time.Now,
+	}
+}
+
+// ApplyUTM replaces any UTM parameters on a first-party link with the normalized
+// set for the template. Links to other hosts are returned unchanged.
+func (s *EmailLinkService) ApplyUTM(rawURL, template string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || !strings.EqualFold(parsed.Host, s.host) {
+		return rawURL
+	}
+
+	query := parsed.Query()
+	for key := range query {
+		if strings.HasPrefix(strings.ToLower(key), "utm_") {
+			query.Del(key)
+		}
+	}
+	query.Set("utm_source", models.EmailUTMSource)
+	query.Set("utm_medium", models.EmailUTMMedium)
+	query.Set("utm_campaign", normalizeEmailCampaign(template))
+	parsed.RawQuery = query.Encode()
+
+	return parsed.String()
+}
+
+// RewriteLinks replaces links in the HTML and text bodies with tracked redirects.
+// A URL that appears in both bodies shares one tracked link.
+func (s *EmailLinkService) RewriteLinks(ctx context.Context, email EmailLinkContext, htmlBody, textBody string) (string, string, error) {
+	tracked := make(map[string]string)
+	var rewriteErr error
+
+	track := func(rawURL string) string {
+		if rewriteErr != nil || !s.isTrackable(rawURL) {
+			return rawURL
+		}
+		if trackedURL, ok := tracked[rawURL]; ok {
+			return trackedURL
+		}
+
+		link, err := s.createLink(ctx, email, s.ApplyUTM(rawURL, email.Template))
+		if err != nil {
+			rewriteErr = err
+			return rawURL
+		}
+		tracked[rawURL] = s.TrackedURL(link.Token)
+		return tracked[rawURL]
+	}
+
+	htmlBody = emailHrefPattern.ReplaceAllStringFunc(htmlBody, func(match string) string {
+		rawURL := html.UnescapeString(emailHrefPattern.FindStringSubmatch(match)[1])
+		return fmt.Sprintf(`href="%s"`, html.EscapeString(track(rawURL)))
+	})
+
+	textBody = emailTextURLPattern.ReplaceAllStringFunc(textBody, func(match string) string {
+		// Keep sentence punctuation outside of the link
+		rawURL := strings.TrimRight(match, ".,;:!?)")
+		return track(rawURL) + match[len(rawURL):]
+	})
+
+	if rewriteErr != nil {
+		return "", "", rewriteErr
+	}
+	return htmlBody, textBody, nil
+}
+
+// Resolve looks up an unexpired tracked link by token
+func (s *EmailLinkService) Resolve(ctx context.Context, token string) (*models.EmailTrackedLink, error) {
+	link, err := s.repo.GetByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if !s.now().Before(link.ExpiresAt) {
+		return nil, ErrEmailLinkExpired
+	}
+	return link, nil
+}
+
+// RecordClick updates the link counters and writes the click to the email log
+func (s *EmailLinkService) RecordClick(ctx context.Context, link *models.EmailTrackedLink, clientIP, userAgent string) error {
+	now := s.now()
+	if err := s.repo.RecordClick(ctx, link.ID, now); err != nil {
+		return err
+	}
+
+	metadata := map[string]interface{}{
+		"source": "first_party",
+		"token":  link.Token,
+	}
+	if link.EmailNotificationLogID != nil {
+		metadata["email_notification_log_id"] = link.EmailNotificationLogID.String()
+	}
+	metadataBytes, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to encode click metadata: %w", err)
+	}
+	metadataStr := string(metadataBytes)
+
+	template := link.Template
+	targetURL := link.TargetURL
+	clickLog := &models.EmailLog{
+		ID:        uuid.New(),
+		UserID:    link.UserID,
+		Template:  &template,
+		Recipient: link.Recipient,
+		Status:    "click",
+		EventType: models.EmailClickEventType,
+		LinkURL:   &targetURL,
+		Metadata:  &metadataStr,
+		ClickedAt: &now,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if clientIP != "" {
+		clickLog.IPAddress = &clientIP
+	}
+	if userAgent != "" {
+		clickLog.UserAgent = &userAgent
+	}
+
+	if err := s.logs.CreateEmailLog(ctx, clickLog); err != nil {
+		return fmt.Errorf("failed to log email click: %w", err)
+	}
+	return nil
+}
+
+// TrackedURL builds the public redirect URL for a token
+func (s *EmailLinkService) TrackedURL(token string) string {
+	return fmt.Sprintf("%s/r/%s", s.baseURL, token)
+}
+
+// FallbackURL is where expired or unknown tracked links send visitors
+func (s *EmailLinkService) FallbackURL() string {
+	return s.baseURL + "/"
+}
+
+// createLink stores a tracked link, retrying on the rare token collision
+func (s *EmailLinkService) createLink(ctx context.Context, email EmailLinkContext, targetURL string) (*models.EmailTrackedLink, error) {
+	link := &models.EmailTrackedLink{
+		ID:                     uuid.New(),
+		EmailNotificationLogID: email.NotificationLogID,
+		UserID:                 email.UserID,
+		Recipient:              email.Recipient,
+		Template:               email.Template,
+		TargetURL:              targetURL,
+		ExpiresAt:              s.now().Add(emailLinkTTL),
+	}
+
+	var err error
+	for attempt := 0; attempt < 3; attempt++ {
+		link.Token, err = generateEmailLinkToken()
+		if err != nil {
+			return nil, err
+		}
+		err = s.repo.Create(ctx, link)
+		if !errors.Is(err, repository.ErrEmailTrackedLinkTokenTaken) {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracked link: %w", err)
+	}
+
+	return link, nil
+}
+
+// isTrackable reports whether a link should be routed through the redirect.
+// Unsubscribe links stay direct so one-click unsubscribe keeps working.
+func (s *EmailLinkService) isTrackable(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return false
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return false
+	}
+	if strings.Contains(parsed.Path, "unsubscribe") {
+		return false
+	}
+	return !strings.HasPrefix(rawURL, s.baseURL+"/r/")
+}
+
+// normalizeEmailCampaign turns a template name into a stable utm_campaign value
+func normalizeEmailCampaign(template string) string {
+	campaign := emailCampaignCleaner.ReplaceAllString(strings.ToLower(template), "_")
+	campaign = strings.Trim(campaign, "_")
+	if campaign == "" {
+		return "transactional"
+	}
+	return campaign
+}
+
+// generateEmailLinkToken returns a random URL-safe token
+func generateEmailLinkToken() (string, error) {
+	b := make([]byte, emailLinkTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate email link token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
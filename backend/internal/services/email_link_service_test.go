@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+// MockEmailLinkRepository is a mock implementation of EmailLinkRepositoryInterface
+type MockEmailLinkRepository struct {
+	mock.Mock
+}
+
+func (m *MockEmailLinkRepository) Create(ctx context.Context, link *models.EmailTrackedLink) error {
+	args := m.Called(ctx, link)
+	return args.Error(0)
+}
+
+func (m *MockEmailLinkRepository) GetByToken(ctx context.Context, token string) (*models.EmailTrackedLink, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.EmailTrackedLink), args.Error(1)
+}
+
+func (m *MockEmailLinkRepository) RecordClick(ctx context.Context, linkID uuid.UUID, clickedAt time.Time) error {
+	args := m.Called(ctx, linkID, clickedAt)
+	return args.Error(0)
+}
+
+// MockEmailClickLogger is a mock implementation of EmailClickLogger
+type MockEmailClickLogger struct {
+	mock.Mock
+}
+
+func (m *MockEmailClickLogger) CreateEmailLog(ctx context.Context, log *models.EmailLog) error {
+	args := m.Called(ctx, log)
+	return args.Error(0)
+}
+
+func TestEmailLinkService_ApplyUTM(t *testing.T) {
+	svc := NewEmailLinkService(new(MockEmailLinkRepository), new(MockEmailClickLogger), "https://clpr.tv/")
+
+	tracked, err := url.Parse(svc.ApplyUTM("https://clpr.tv/clips/abc?sort=new&utm_source=sendgrid&UTM_Term=x", "Clip Trending"))
+	require.NoError(t, err)
+	query := tracked.Query()
+	assert.Equal(t, "new", query.Get("sort"))
+	assert.Equal(t, models.EmailUTMSource, query.Get("utm_source"))
+	assert.Equal(t, models.EmailUTMMedium, query.Get("utm_medium"))
+	assert.Equal(t, "clip_trending", query.Get("utm_campaign"))
+	assert.Empty(t, query.Get("UTM_Term"))
+
+	// External links are left alone
+	external := "https://twitch.tv/someone?utm_source=x"
+	assert.Equal(t, external, svc.ApplyUTM(external, "reply"))
+}
+
+func TestNormalizeEmailCampaign(t *testing.T) {
+	assert.Equal(t, "payment_failed", normalizeEmailCampaign("payment_failed"))
+	assert.Equal(t, "dmca_takedown", normalizeEmailCampaign("  DMCA--Takedown "))
+	assert.Equal(t, "transactional", normalizeEmailCampaign(""))
+}
+
+func TestEmailLinkService_RewriteLinks(t *testing.T) {
+	repo := new(MockEmailLinkRepository)
+	svc := NewEmailLinkService(repo, new(MockEmailClickLogger), "https://clpr.tv")
+	userID := uuid.New()
+
+	// The same target in both bodies shares one tracked link
+	var link *models.EmailTrackedLink
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*models.EmailTrackedLink")).
+		Run(func(args mock.Arguments) { link = args.Get(1).(*models.EmailTrackedLink) }).
+		Return(nil).Once()
+
+	htmlBody := `<a href="https://clpr.tv/clips/1?a=1&amp;b=2">View</a> ` +
+		`<a href="https://clpr.tv/api/v1/notifications/unsubscribe?token=t">Unsubscribe</a> ` +
+		`<a href="mailto:help@clpr.tv">Help</a>`
+	textBody := "View it: https://clpr.tv/clips/1?a=1&b=2.\nUnsubscribe: https://clpr.tv/api/v1/notifications/unsubscribe?token=t"
+
+	trackedHTML, trackedText, err := svc.RewriteLinks(context.Background(), EmailLinkContext{
+		Template:  "reply",
+		Recipient: "user@example.com",
+		UserID:    &userID,
+	}, htmlBody, textBody)
+	require.NoError(t, err)
+
+	repo.AssertExpectations(t)
+	require.NotNil(t, link)
+	trackedURL := "https://clpr.tv/r/" + link.Token
+
+	assert.Contains(t, trackedHTML, `href="`+trackedURL+`"`)
+	assert.Contains(t, trackedHTML, "notifications/unsubscribe?token=t")
+	assert.Contains(t, trackedHTML, `href="mailto:help@clpr.tv"`)
+	assert.Contains(t, trackedText, trackedURL+".\n")
+	assert.Contains(t, trackedText, "Unsubscribe: https://clpr.tv/api/v1/notifications/unsubscribe?token=t")
+
+	assert.Equal(t, "reply", link.Template)
+	assert.Equal(t, &userID, link.UserID)
+	assert.True(t, strings.HasPrefix(link.TargetURL, "https://clpr.tv/clips/1?"))
+	assert.Contains(t, link.TargetURL, "utm_campaign=reply")
+	assert.Contains(t, link.TargetURL, "b=2")
+}
+
+func TestEmailLinkService_ResolveAndRecordClick(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockEmailLinkRepository)
+	logger := new(MockEmailClickLogger)
+	svc := NewEmailLinkService(repo, logger, "https://clpr.tv")
+	userID := uuid.New()
+	logID := uuid.New()
+
+	repo.On("Create", ctx, mock.AnythingOfType("*models.EmailTrackedLink")).Return(nil).Once()
+	link, err := svc.createLink(ctx, EmailLinkContext{
+		Template:          "welcome",
+		Recipient:         "user@example.com",
+		UserID:            &userID,
+		NotificationLogID: &logID,
+	}, "https://clpr.tv/?utm_campaign=welcome")
+	require.NoError(t, err)
+
+	repo.On("GetByToken", ctx, link.Token).Return(link, nil)
+	repo.On("RecordClick", ctx, link.ID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+	var clickLog *models.EmailLog
+	logger.On("CreateEmailLog", ctx, mock.AnythingOfType("*models.EmailLog")).
+		Run(func(args mock.Arguments) { clickLog = args.Get(1).(*models.EmailLog) }).
+		Return(nil).Once()
+
+	resolved, err := svc.Resolve(ctx, link.Token)
+	require.NoError(t, err)
+	require.NoError(t, svc.RecordClick(ctx, resolved, "203.0.113.5", "Mail/1.0"))
+
+	repo.AssertExpectations(t)
+	logger.AssertExpectations(t)
+	require.NotNil(t, clickLog)
+	assert.Equal(t, "click", clickLog.Status)
+	assert.Equal(t, models.EmailClickEventType, clickLog.EventType)
+	assert.Equal(t, "welcome", *clickLog.Template)
+	assert.Equal(t, link.TargetURL, *clickLog.LinkURL)
+	assert.Equal(t, &userID, clickLog.UserID)
+	assert.NotNil(t, clickLog.ClickedAt)
+	assert.Contains(t, *clickLog.Metadata, logID.String())
+
+	repo.On("GetByToken", ctx, "missing").Return(nil, repository.ErrEmailTrackedLinkNotFound)
+	_, err = svc.Resolve(ctx, "missing")
+	assert.ErrorIs(t, err, repository.ErrEmailTrackedLinkNotFound)
+
+	svc.now = func() time.Time { return time.Now().Add(emailLinkTTL + time.Hour) }
+	_, err = svc.Resolve(ctx, link.Token)
+	assert.ErrorIs(t, err, ErrEmailLinkExpired)
+}
@@ -65,6 +65,27 @@ func (s *EmailService) SendMFABackupCodesRegeneratedEmail(ctx context.Context, t
 	return err
 }
 
+// SendMFARecoveryEmail sends a one-time link to recover an account that lost its second factor
+func (s *EmailService) SendMFARecoveryEmail(ctx context.Context, toEmail, username, token string) error {
+	if !s.enabled {
+		return nil
+	}
+
+	data := map[string]interface{}{
+		"username": username,
+		"base_url": s.baseURL,
+		"token":    token,
+	}
+
+	subject, htmlBody, textBody, err := s.prepareEmailContent("mfa_recovery", data, "")
+	if err != nil {
+		return fmt.Errorf("failed to prepare MFA recovery email: %w", err)
+	}
+
+	_, err = s.sendViaSendGrid(toEmail, subject, htmlBody, textBody)
+	return err
+}
+
 // prepareMFAEnabledEmail prepares the MFA enabled email content
 func (s *EmailService) prepareMFAEnabledEmail(data map[string]interface{}) (htmlContent, textContent string) {
 	username := data["username"].(string)
@@ -247,3 +268,65 @@ Manage Security Settings: %s/settings/security`, username, baseURL, baseURL)
 
 	return htmlContent, textContent
 }
+
+// prepareMFARecoveryEmail prepares the MFA recovery email content
+func (s *EmailService) prepareMFARecoveryEmail(data map[string]interface{}) (htmlContent, textContent string) {
+	username := data["username"].(string)
+	baseURL := data["base_url"].(string)
+	recoveryURL := fmt.Sprintf("%s/auth/mfa-recovery?token=%s", baseURL, data["token"].(string))
+
+	htmlContent = fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>MFA Recovery</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h1 style="color: #f59e0b;">Multi-Factor Authentication Recovery</h1>
+        
+        <p>Hi %s,</p>
+        
+        <p>We received a request to recover your account because your authenticator app and backup codes are unavailable.</p>
+        
+        <p>Confirming will remove multi-factor authentication from your account so you can set it up again. This link expires in 1 hour.</p>
+        
+        <div style="margin: 30px 0;">
+            <a href="%s" style="display: inline-block; padding: 12px 24px; background-color: #f59e0b; color: white; text-decoration: none; border-radius: 4px;">Recover Account</a>
+        </div>
+        
+        <div style="margin: 30px 0; padding: 15px; background-color: #fef2f2; border-left: 4px solid #ef4444;">
+            <p style="margin: 0;"><strong>Didn't request this?</strong></p>
+            <p style="margin: 10px 0 0 0;">Ignore this email and <a href="%s/settings/security" style="color: #3b82f6;">review your security settings</a>. Your MFA settings will not change.</p>
+        </div>
+        
+        <hr style="border: 1px solid #e5e7eb; margin: 30px 0;">
+        
+        <p style="color: #6b7280; font-size: 14px;">
+            <a href="%s/settings/security">Manage Security Settings</a>
+        </p>
+    </div>
+</body>
+</html>`, username, recoveryURL, baseURL, baseURL)
+
+	textContent = fmt.Sprintf(`Multi-Factor Authentication Recovery
+
+Hi %s,
+
+We received a request to recover your account because your authenticator app and backup codes are unavailable.
+
+Confirming will remove multi-factor authentication from your account so you can set it up again. This link expires in 1 hour.
+
+Recover your account:
+%s
+
+Didn't request this?
+Ignore this email and review your security settings. Your MFA settings will not change:
+%s/settings/security
+
+---
+Manage Security Settings: %s/settings/security`, username, recoveryURL, baseURL, baseURL)
+
+	return htmlContent, textContent
+}
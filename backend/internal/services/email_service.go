@@ -19,6 +19,7 @@ import (
 	sendgridmail "github.com/sendgrid/sendgrid-go/helpers/mail"
 	"github.com/subculture-collective/clipper/internal/models"
 	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/pkg/metrics"
 	"github.com/subculture-collective/clipper/pkg/utils"
 )
 
@@ -35,6 +36,8 @@ type EmailService struct {
 	maxEmailsPerHour    int
 	tokenExpiryDuration time.Duration
 	logger              *utils.StructuredLogger
+	links               *EmailLinkService
+	translator          EmailTranslator
 	wg                  sync.WaitGroup
 	shutdown            chan struct{}
 }
@@ -97,6 +100,37 @@ func NewEmailService(cfg *EmailConfig, repo *repository.EmailNotificationReposit
 	}
 }
 
+// SetLinkService enables first-party link tracking for outgoing email.
+// SendGrid click tracking is turned off while it is set.
+func (s *EmailService) SetLinkService(links *EmailLinkService) {
+	s.links = links
+}
+
+// EmailTranslator looks up strings in the shared translation catalog
+type EmailTranslator interface {
+	Translate(ctx context.Context, locale, key string, data map[string]interface{}) (string, bool)
+}
+
+// SetTranslator lets the translation catalog override notification subjects.
+// A subject is overridden by the "email.<notification type>.subject" key in
+// the locale named by the "Locale" email data field, or the default locale.
+func (s *EmailService) SetTranslator(translator EmailTranslator) {
+	s.translator = translator
+}
+
+// localizeSubject returns the catalog's subject for a notification type,
+// keeping the built-in subject when the catalog has none
+func (s *EmailService) localizeSubject(ctx context.Context, notificationType, subject string, data map[string]interface{}) string {
+	if s.translator == nil {
+		return subject
+	}
+	locale, _ := data["Locale"].(string)
+	if translated, ok := s.translator.Translate(ctx, locale, "email."+notificationType+".subject", data); ok {
+		return translated
+	}
+	return subject
+}
+
 // SendNotificationEmail sends an email for a notification
 func (s *EmailService) SendNotificationEmail(
 	ctx context.Context,
@@ -126,18 +160,26 @@ func (s *EmailService) SendNotificationEmail(
 		} else {
 			// Check if email notifications are globally disabled
 			if !prefs.EmailEnabled {
+				metrics.RecordEmail("suppressed")
 				return nil // User has disabled all email notifications
 			}
 
 			// Check if email digest is set to "never"
-			if prefs.EmailDigest == "never" {
+			if prefs.EmailDigest == models.EmailDigestNever {
+				metrics.RecordEmail("suppressed")
 				return nil // User has disabled all email delivery
 			}
 
 			// Check specific notification type preferences
-			if !s.shouldSendEmailForType(prefs, notificationType) {
+			if !emailEnabledForType(prefs, notificationType) {
+				metrics.RecordEmail("suppressed")
 				return nil // User has disabled this type of notification
 			}
+
+			// Daily and weekly digest users get this type in their next digest
+			if heldForDigest(prefs, notificationType) {
+				return nil
+			}
 		}
 	}
 
@@ -147,6 +189,7 @@ func (s *EmailService) SendNotificationEmail(
 		return fmt.Errorf("failed to check rate limit: %w", err)
 	}
 	if !canSend {
+		metrics.RecordEmail("rate_limited")
 		return fmt.Errorf("rate limit exceeded for user %s", user.ID)
 	}
 
@@ -162,6 +205,7 @@ func (s *EmailService) SendNotificationEmail(
 	if err != nil {
 		return fmt.Errorf("failed to prepare email content: %w", err)
 	}
+	subject = s.localizeSubject(ctx, notificationType, subject, emailData)
 
 	// Create audit log entry
 	logEntry := &models.EmailNotificationLog{
@@ -181,6 +225,13 @@ func (s *EmailService) SendNotificationEmail(
 		return fmt.Errorf("failed to create email log: %w", err)
 	}
 
+	htmlBody, textBody = s.trackLinks(ctx, EmailLinkContext{
+		Template:          notificationType,
+		Recipient:         *user.Email,
+		UserID:            &user.ID,
+		NotificationLogID: &logEntry.ID,
+	}, htmlBody, textBody)
+
 	// Send via SendGrid
 	messageID, err := s.sendViaSendGrid(*user.Email, subject, htmlBody, textBody)
 	if err != nil {
@@ -221,6 +272,16 @@ func (s *EmailService) SendNotificationEmail(
 
 // sendViaSendGrid sends an email using SendGrid API
 func (s *EmailService) sendViaSendGrid(to, subject, htmlContent, textContent string) (string, error) {
+	messageID, err := s.deliverViaSendGrid(to, subject, htmlContent, textContent)
+	if err != nil {
+		metrics.RecordEmail("failed")
+	} else {
+		metrics.RecordEmail("sent")
+	}
+	return messageID, err
+}
+
+func (s *EmailService) deliverViaSendGrid(to, subject, htmlContent, textContent string) (string, error) {
 	// Sandbox mode: log the email but don't actually send it
 	if s.sandboxMode {
 		s.logger.Info("SANDBOX MODE: Email would be sent", map[string]interface{}{
@@ -239,6 +300,11 @@ func (s *EmailService) sendViaSendGrid(to, subject, htmlContent, textContent str
 	toEmail := sendgridmail.NewEmail("", to)
 
 	message := sendgridmail.NewSingleEmail(from, subject, toEmail, textContent, htmlContent)
+	if s.links != nil {
+		// Links are already rewritten to first-party redirects
+		message.SetTrackingSettings(sendgridmail.NewTrackingSettings().
+			SetClickTracking(sendgridmail.NewClickTrackingSetting().SetEnable(false).SetEnableText(false)))
+	}
 	client := sendgrid.NewSendClient(s.apiKey)
 
 	response, err := client.Send(message)
@@ -276,8 +342,26 @@ func (s *EmailService) sendViaSendGrid(to, subject, htmlContent, textContent str
 	return messageID, nil
 }
 
-// shouldSendEmailForType checks if the user wants emails for a specific notification type
-func (s *EmailService) shouldSendEmailForType(prefs *models.NotificationPreferences, notificationType string) bool {
+// trackLinks rewrites links through the first-party redirect when link tracking
+// is enabled, falling back to the original bodies if rewriting fails
+func (s *EmailService) trackLinks(ctx context.Context, email EmailLinkContext, htmlBody, textBody string) (string, string) {
+	if s.links == nil || email.Template == "" {
+		return htmlBody, textBody
+	}
+
+	trackedHTML, trackedText, err := s.links.RewriteLinks(ctx, email, htmlBody, textBody)
+	if err != nil {
+		s.logger.Warn("Failed to rewrite email links, sending untracked", map[string]interface{}{
+			"template": email.Template,
+			"error":    err.Error(),
+		})
+		return htmlBody, textBody
+	}
+	return trackedHTML, trackedText
+}
+
+// emailEnabledForType checks if the user wants emails for a specific notification type
+func emailEnabledForType(prefs *models.NotificationPreferences, notificationType string) bool {
 	// Map notification types to preference fields
 	switch notificationType {
 	// Account & Security
@@ -291,7 +375,7 @@ func (s *EmailService) shouldSendEmailForType(prefs *models.NotificationPreferen
 		return prefs.NotifyEmailChanged
 
 	// Content notifications
-	case models.NotificationTypeReply:
+	case models.NotificationTypeReply, models.NotificationTypeCommentPinned:
 		return prefs.NotifyReplies
 	case models.NotificationTypeMention:
 		return prefs.NotifyMentions
@@ -299,7 +383,7 @@ func (s *EmailService) shouldSendEmailForType(prefs *models.NotificationPreferen
 		return prefs.NotifyContentTrending
 	case models.NotificationTypeContentFlagged:
 		return prefs.NotifyContentFlagged
-	case models.NotificationTypeVoteMilestone:
+	case models.NotificationTypeVoteMilestone, models.NotificationTypeCommentReaction:
 		return prefs.NotifyVotes
 	case models.NotificationTypeFavoritedClipComment:
 		return prefs.NotifyFavoritedClipComment
@@ -313,7 +397,7 @@ func (s *EmailService) shouldSendEmailForType(prefs *models.NotificationPreferen
 		return prefs.NotifyCommentOnContent
 	case models.NotificationTypeDiscussionReply:
 		return prefs.NotifyDiscussionReply
-	case models.NotificationTypeBadgeEarned:
+	case models.NotificationTypeBadgeEarned, models.NotificationTypeAchievementCompleted:
 		return prefs.NotifyBadges
 	case models.NotificationTypeRankUp:
 		return prefs.NotifyRankUp
@@ -337,6 +421,8 @@ func (s *EmailService) shouldSendEmailForType(prefs *models.NotificationPreferen
 		return prefs.NotifyPolicyUpdates
 	case models.NotificationTypePlatformAnnouncement:
 		return prefs.NotifyPlatformAnnouncements
+	case models.NotificationTypeReengagement:
+		return prefs.NotifyReengagement
 
 	default:
 		// For unknown types, allow the email (safer default)
@@ -396,6 +482,9 @@ func (s *EmailService) prepareEmailContent(
 	case models.NotificationTypeLoginNewDevice:
 		subject = "⚠️ New Login Detected"
 		htmlBody, textBody = s.prepareSecurityAlertEmail(data)
+	case models.NotificationTypeRefreshTokenReuse:
+		subject = "⚠️ Suspicious Sign-In Blocked"
+		htmlBody, textBody = s.prepareSecurityAlertEmail(data)
 	case "policy_update":
 		subject = "Important Update to Our Policies"
 		htmlBody, textBody = s.preparePolicyUpdateEmail(data)
@@ -410,6 +499,9 @@ func (s *EmailService) prepareEmailContent(
 	case "mfa_backup_codes_regenerated":
 		subject = "MFA Backup Codes Regenerated"
 		htmlBody, textBody = s.prepareMFABackupCodesRegeneratedEmail(data)
+	case "mfa_recovery":
+		subject = "Recover Your Account's Multi-Factor Authentication"
+		htmlBody, textBody = s.prepareMFARecoveryEmail(data)
 
 	// Payment notifications
 	case models.NotificationTypePaymentFailed:
@@ -673,7 +765,12 @@ func (s *EmailService) SendEmail(ctx context.Context, req EmailRequest) error {
 	// Send to each recipient
 	var sendErrors []error
 	for _, recipient := range req.To {
-		messageID, err := s.sendViaSendGrid(recipient, req.Subject, htmlBody, textBody)
+		recipientHTML, recipientText := s.trackLinks(ctx, EmailLinkContext{
+			Template:  req.Template,
+			Recipient: recipient,
+		}, htmlBody, textBody)
+
+		messageID, err := s.sendViaSendGrid(recipient, req.Subject, recipientHTML, recipientText)
 		if err != nil {
 			s.logger.Error("Failed to send email", err, map[string]interface{}{
 				"to":       recipient,
@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/subculture-collective/clipper/internal/models"
 )
 
@@ -1353,3 +1354,18 @@ func TestPrepareExportFailedEmail(t *testing.T) {
 	assert.Contains(t, textBody, "Failed to retrieve clips: database connection timeout")
 	assert.Contains(t, textBody, "January 15, 2024 at 2:30 PM")
 }
+
+// TestLocalizeSubject tests that catalog subjects override built-in ones
+func TestLocalizeSubject(t *testing.T) {
+	service := NewEmailService(&EmailConfig{BaseURL: "http://localhost:5173"}, nil, nil)
+	data := map[string]interface{}{"AuthorName": "ana", "Locale": "es"}
+
+	assert.Equal(t, "built-in", service.localizeSubject(context.Background(), models.NotificationTypeReply, "built-in", data))
+
+	translator, repo := setupI18nServiceTest()
+	repo.On("GetStrings", mock.Anything, "es").Return(map[string]string{"email.reply.subject": "{{AuthorName}} respondió a tu comentario"}, nil)
+	service.SetTranslator(translator)
+
+	assert.Equal(t, "ana respondió a tu comentario", service.localizeSubject(context.Background(), models.NotificationTypeReply, "built-in", data))
+	assert.Equal(t, "built-in", service.localizeSubject(context.Background(), models.NotificationTypeMention, "built-in", data))
+}
@@ -0,0 +1,358 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const (
+	// emailThrottleSettingsTTL is how long workers use settings before
+	// reloading them, so admin changes reach every instance within it
+	emailThrottleSettingsTTL = 30 * time.Second
+
+	emailThrottleSentKeyPrefix = "email_throttle:sent:"
+	emailThrottleRampKey       = "email_throttle:ramp_started"
+)
+
+// ErrInvalidEmailThrottle is returned for throttle settings that can't be applied
+var ErrInvalidEmailThrottle = errors.New("invalid email throttle settings")
+
+// BulkEmailDecision is whether a bulk email may be sent now
+type BulkEmailDecision int
+
+const (
+	// BulkEmailAllowed means the email may be sent now
+	BulkEmailAllowed BulkEmailDecision = iota
+	// BulkEmailOutsideWindow means it is outside the recipient's send window;
+	// the email stays due and other recipients may still be emailed
+	BulkEmailOutsideWindow
+	// BulkEmailThrottled means sends are paused or at the rate cap; the run
+	// should stop and leave the rest due for the next one
+	BulkEmailThrottled
+)
+
+// BulkEmailThrottle decides whether a bulk email to a recipient may be sent now
+type BulkEmailThrottle interface {
+	AllowBulkEmail(ctx context.Context, timezone *string) (BulkEmailDecision, error)
+}
+
+// EmailThrottleRepositoryInterface defines the repository methods used by EmailThrottleService
+type EmailThrottleRepositoryInterface interface {
+	GetSettings(ctx context.Context) (*models.EmailThrottleSettings, error)
+	UpdateSettings(ctx context.Context, settings *models.EmailThrottleSettings) error
+}
+
+// EmailThrottleStore counts bulk sends across instances
+type EmailThrottleStore interface {
+	Get(ctx context.Context, key string) (string, error)
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
+	Increment(ctx context.Context, key string) (int64, error)
+	Decrement(ctx context.Context, key string) (int64, error)
+	Expire(ctx context.Context, key string, expiration time.Duration) error
+}
+
+// EmailThrottleService throttles bulk email so large sends don't spike
+// bounce rates: a global per-minute cap that ramps up after sends have been
+// idle, pausing, and send windows in each recipient's local time
+type EmailThrottleService struct {
+	repo  EmailThrottleRepositoryInterface
+	store EmailThrottleStore
+	now   func() time.Time
+
+	mu       sync.Mutex
+	settings *models.EmailThrottleSettings
+	loadedAt time.Time
+}
+
+// NewEmailThrottleService creates a new EmailThrottleService
+func NewEmailThrottleService(repo EmailThrottleRepositoryInterface, store EmailThrottleStore) *EmailThrottleService {
+	return &EmailThrottleService{
+		repo:  repo,
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// AllowBulkEmail decides whether a bulk email to a recipient in the given time
+// zone may be sent now, taking a slot of the per-minute cap when it may
+func (s *EmailThrottleService) AllowBulkEmail(ctx context.Context, timezone *string) (BulkEmailDecision, error) {
+	settings, err := s.currentSettings(ctx)
+	if err != nil {
+		return BulkEmailThrottled, err
+	}
+	if settings.Paused {
+		return BulkEmailThrottled, nil
+	}
+
+	now := s.now()
+	if !inSendWindow(settings, timezone, now) {
+		return BulkEmailOutsideWindow, nil
+	}
+
+	rampStarted, err := s.startRamp(ctx, settings, now)
+	if err != nil {
+		return BulkEmailThrottled, err
+	}
+	limit := rampedLimit(settings, rampStarted, now)
+
+	key := emailThrottleSentKey(now)
+	sent, err := s.store.Increment(ctx, key)
+	if err != nil {
+		return BulkEmailThrottled, fmt.Errorf("failed to count bulk email: %w", err)
+	}
+	if sent == 1 {
+		if err := s.store.Expire(ctx, key, 2*time.Minute); err != nil {
+			return BulkEmailThrottled, fmt.Errorf("failed to expire bulk email count: %w", err)
+		}
+	}
+	if sent > int64(limit) {
+		// Give the slot back so the count is of emails let through
+		if _, err := s.store.Decrement(ctx, key); err != nil {
+			utils.GetLogger().Error("Failed to release bulk email slot", err)
+		}
+		return BulkEmailThrottled, nil
+	}
+
+	// Sending keeps the ramp going; it restarts once sends are idle for as long as it lasts
+	if rampStarted != nil {
+		if err := s.store.Expire(ctx, emailThrottleRampKey, time.Duration(settings.RampMinutes)*time.Minute); err != nil {
+			utils.GetLogger().Error("Failed to extend bulk email ramp", err)
+		}
+	}
+	return BulkEmailAllowed, nil
+}
+
+// GetStatus returns the throttle settings and the cap and count of the current minute
+func (s *EmailThrottleService) GetStatus(ctx context.Context) (*models.EmailThrottleStatus, error) {
+	settings, err := s.repo.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.cacheSettings(settings)
+
+	now := s.now()
+	status := &models.EmailThrottleStatus{Settings: *settings}
+
+	if sent, err := s.store.Get(ctx, emailThrottleSentKey(now)); err == nil {
+		status.SentThisMinute, _ = strconv.ParseInt(sent, 10, 64)
+	}
+	if rampsUp(settings) {
+		if started, err := s.store.Get(ctx, emailThrottleRampKey); err == nil {
+			if unix, err := strconv.ParseInt(started, 10, 64); err == nil {
+				at := time.Unix(unix, 0)
+				status.RampStartedAt = &at
+			}
+		}
+	}
+	// Without a run going, the next send starts at the bottom of the ramp
+	if rampsUp(settings) && status.RampStartedAt == nil {
+		status.CurrentPerMinute = rampedLimit(settings, &now, now)
+	} else {
+		status.CurrentPerMinute = rampedLimit(settings, status.RampStartedAt, now)
+	}
+
+	return status, nil
+}
+
+// UpdateSettings validates and saves new throttle settings. They apply at
+// once on this instance and within 30 seconds on the others.
+func (s *EmailThrottleService) UpdateSettings(ctx context.Context, req *models.UpdateEmailThrottleRequest, adminID uuid.UUID) (*models.EmailThrottleSettings, error) {
+	settings := &models.EmailThrottleSettings{
+		Paused:             req.Paused,
+		MaxPerMinute:       req.MaxPerMinute,
+		RampStartPerMinute: req.RampStartPerMinute,
+		RampMinutes:        req.RampMinutes,
+		DefaultWindow:      req.DefaultWindow,
+		Windows:            req.Windows,
+		UpdatedBy:          &adminID,
+	}
+	if settings.DefaultWindow.Timezone == "" {
+		settings.DefaultWindow.Timezone = "UTC"
+	}
+	if settings.Windows == nil {
+		settings.Windows = []models.EmailSendWindow{}
+	}
+	if err := validateEmailThrottle(settings); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+	s.cacheSettings(settings)
+
+	return settings, nil
+}
+
+// currentSettings returns the cached settings, reloading them once they are
+// stale. If reloading fails the stale settings are kept.
+func (s *EmailThrottleService) currentSettings(ctx context.Context) (*models.EmailThrottleSettings, error) {
+	s.mu.Lock()
+	settings, loadedAt := s.settings, s.loadedAt
+	s.mu.Unlock()
+
+	if settings != nil && s.now().Sub(loadedAt) < emailThrottleSettingsTTL {
+		return settings, nil
+	}
+
+	fresh, err := s.repo.GetSettings(ctx)
+	if err != nil {
+		if settings != nil {
+			utils.GetLogger().Error("Failed to reload email throttle settings, keeping the previous ones", err)
+			return settings, nil
+		}
+		return nil, err
+	}
+	s.cacheSettings(fresh)
+	return fresh, nil
+}
+
+func (s *EmailThrottleService) cacheSettings(settings *models.EmailThrottleSettings) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.settings = settings
+	s.loadedAt = s.now()
+}
+
+// startRamp returns when the current run of sends began, starting one now if
+// sends have been idle. It returns nil when the settings don't ramp.
+func (s *EmailThrottleService) startRamp(ctx context.Context, settings *models.EmailThrottleSettings, now time.Time) (*time.Time, error) {
+	if !rampsUp(settings) {
+		return nil, nil
+	}
+
+	idle := time.Duration(settings.RampMinutes) * time.Minute
+	started, err := s.store.SetNX(ctx, emailThrottleRampKey, now.Unix(), idle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start bulk email ramp: %w", err)
+	}
+	if started {
+		return &now, nil
+	}
+
+	value, err := s.store.Get(ctx, emailThrottleRampKey)
+	if err != nil {
+		// The run ended between the two calls; ramp from now
+		return &now, nil
+	}
+	unix, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return &now, nil
+	}
+	at := time.Unix(unix, 0)
+	return &at, nil
+}
+
+// rampsUp reports whether sends start below the cap
+func rampsUp(settings *models.EmailThrottleSettings) bool {
+	return settings.RampMinutes > 0 && settings.RampStartPerMinute < settings.MaxPerMinute
+}
+
+// rampedLimit returns the per-minute cap for a run of sends that began at
+// rampStarted, rising linearly from the ramp start to the maximum
+func rampedLimit(settings *models.EmailThrottleSettings, rampStarted *time.Time, now time.Time) int {
+	if rampStarted == nil || !rampsUp(settings) {
+		return settings.MaxPerMinute
+	}
+	ramp := time.Duration(settings.RampMinutes) * time.Minute
+	elapsed := now.Sub(*rampStarted)
+	if elapsed >= ramp {
+		return settings.MaxPerMinute
+	}
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	span := settings.MaxPerMinute - settings.RampStartPerMinute
+	return settings.RampStartPerMinute + int(float64(span)*elapsed.Seconds()/ramp.Seconds())
+}
+
+// inSendWindow reports whether it is within the send window of a recipient's
+// time zone, using the default window's time zone when the recipient has none
+func inSendWindow(settings *models.EmailThrottleSettings, timezone *string, now time.Time) bool {
+	tz := settings.DefaultWindow.Timezone
+	if timezone != nil && *timezone != "" {
+		tz = *timezone
+	}
+
+	window := settings.DefaultWindow
+	for _, w := range settings.Windows {
+		if w.Timezone == tz {
+			window = w
+			break
+		}
+	}
+
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		loc = time.UTC
+	}
+	hour := now.In(loc).Hour()
+
+	switch {
+	case window.StartHour == window.EndHour:
+		return true
+	case window.StartHour < window.EndHour:
+		return hour >= window.StartHour && hour < window.EndHour
+	default:
+		// Wraps past midnight, e.g. 20 to 2
+		return hour >= window.StartHour || hour < window.EndHour
+	}
+}
+
+// validateEmailThrottle checks throttle settings can be applied
+func validateEmailThrottle(settings *models.EmailThrottleSettings) error {
+	if settings.MaxPerMinute < 1 {
+		return fmt.Errorf("%w: max_per_minute must be at least 1", ErrInvalidEmailThrottle)
+	}
+	if settings.RampStartPerMinute < 0 || settings.RampMinutes < 0 {
+		return fmt.Errorf("%w: ramp settings can't be negative", ErrInvalidEmailThrottle)
+	}
+	if settings.RampStartPerMinute > settings.MaxPerMinute {
+		return fmt.Errorf("%w: ramp_start_per_minute can't exceed max_per_minute", ErrInvalidEmailThrottle)
+	}
+
+	seen := make(map[string]bool, len(settings.Windows))
+	for _, window := range append([]models.EmailSendWindow{settings.DefaultWindow}, settings.Windows...) {
+		if _, err := time.LoadLocation(window.Timezone); err != nil || window.Timezone == "" {
+			return fmt.Errorf("%w: unknown time zone %q", ErrInvalidEmailThrottle, window.Timezone)
+		}
+		if window.StartHour < 0 || window.StartHour > 23 || window.EndHour < 0 || window.EndHour > 23 {
+			return fmt.Errorf("%w: window hours must be 0 to 23", ErrInvalidEmailThrottle)
+		}
+	}
+	for _, window := range settings.Windows {
+		if seen[window.Timezone] {
+			return fmt.Errorf("%w: %s has more than one window", ErrInvalidEmailThrottle, window.Timezone)
+		}
+		seen[window.Timezone] = true
+	}
+
+	return nil
+}
+
+// emailThrottleSentKey is the key bulk sends in now's minute are counted under
+func emailThrottleSentKey(now time.Time) string {
+	return emailThrottleSentKeyPrefix + strconv.FormatInt(now.Unix()/60, 10)
+}
+
+// checkBulkEmail asks the throttle whether a bulk email to a user may be sent
+// now. Without a throttle every email may.
+func checkBulkEmail(ctx context.Context, throttle BulkEmailThrottle, prefsRepo NotificationPreferencesReader, userID uuid.UUID) (BulkEmailDecision, error) {
+	if throttle == nil {
+		return BulkEmailAllowed, nil
+	}
+
+	prefs, err := prefsRepo.GetPreferences(ctx, userID)
+	if err != nil {
+		return BulkEmailThrottled, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	return throttle.AllowBulkEmail(ctx, prefs.Timezone)
+}
@@ -0,0 +1,260 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// MockEmailThrottleRepository is a mock implementation of EmailThrottleRepositoryInterface
+type MockEmailThrottleRepository struct {
+	mock.Mock
+}
+
+func (m *MockEmailThrottleRepository) GetSettings(ctx context.Context) (*models.EmailThrottleSettings, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.EmailThrottleSettings), args.Error(1)
+}
+
+func (m *MockEmailThrottleRepository) UpdateSettings(ctx context.Context, settings *models.EmailThrottleSettings) error {
+	args := m.Called(ctx, settings)
+	return args.Error(0)
+}
+
+// MockBulkEmailThrottle is a mock implementation of BulkEmailThrottle
+type MockBulkEmailThrottle struct {
+	mock.Mock
+}
+
+func (m *MockBulkEmailThrottle) AllowBulkEmail(ctx context.Context, timezone *string) (BulkEmailDecision, error) {
+	args := m.Called(ctx, timezone)
+	return args.Get(0).(BulkEmailDecision), args.Error(1)
+}
+
+func setupEmailThrottleServiceTest(now *time.Time) (*EmailThrottleService, *MockEmailThrottleRepository, *MockRedisClient) {
+	repo := new(MockEmailThrottleRepository)
+	store := new(MockRedisClient)
+	svc := NewEmailThrottleService(repo, store)
+	svc.now = func() time.Time { return *now }
+	return svc, repo, store
+}
+
+// expectBulkEmailSends expects n bulk emails in now's minute to be counted,
+// the count rising from 1
+func expectBulkEmailSends(store *MockRedisClient, now time.Time, n int) {
+	key := emailThrottleSentKey(now)
+	for sent := 1; sent <= n; sent++ {
+		store.On("Increment", mock.Anything, key).Return(int64(sent), nil).Once()
+	}
+	store.On("Expire", mock.Anything, key, 2*time.Minute).Return(nil).Once()
+}
+
+// allowedBulkEmails counts how many of n bulk emails the throttle lets through
+func allowedBulkEmails(t *testing.T, svc *EmailThrottleService, n int) int {
+	allowed := 0
+	for i := 0; i < n; i++ {
+		decision, err := svc.AllowBulkEmail(context.Background(), nil)
+		require.NoError(t, err)
+		if decision == BulkEmailAllowed {
+			allowed++
+		}
+	}
+	return allowed
+}
+
+func TestEmailThrottleRateAndRamp(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	started := now
+	svc, repo, store := setupEmailThrottleServiceTest(&now)
+	repo.On("GetSettings", mock.Anything).Return(&models.EmailThrottleSettings{
+		MaxPerMinute:       100,
+		RampStartPerMinute: 10,
+		RampMinutes:        60,
+		DefaultWindow:      models.EmailSendWindow{Timezone: "UTC"},
+	}, nil)
+
+	// The first send starts the ramp and every send keeps it going
+	store.On("SetNX", mock.Anything, emailThrottleRampKey, started.Unix(), time.Hour).Return(true, nil).Once()
+	store.On("SetNX", mock.Anything, emailThrottleRampKey, mock.Anything, time.Hour).Return(false, nil)
+	store.On("Get", mock.Anything, emailThrottleRampKey).Return(strconv.FormatInt(started.Unix(), 10), nil)
+	store.On("Expire", mock.Anything, emailThrottleRampKey, time.Hour).Return(nil)
+	// Sends over the limit are handed back
+	store.On("Decrement", mock.Anything, mock.Anything).Return(int64(0), nil)
+
+	expectBulkEmailSends(store, now, 50)
+	assert.Equal(t, 10, allowedBulkEmails(t, svc, 50), "a new run starts at the bottom of the ramp")
+
+	now = now.Add(30 * time.Minute)
+	expectBulkEmailSends(store, now, 100)
+	assert.Equal(t, 55, allowedBulkEmails(t, svc, 100), "halfway up the ramp")
+
+	now = now.Add(time.Hour)
+	expectBulkEmailSends(store, now, 150)
+	assert.Equal(t, 100, allowedBulkEmails(t, svc, 150), "the ramp ends at the maximum")
+
+	store.On("Get", mock.Anything, emailThrottleSentKey(now)).Return("100", nil).Once()
+	status, err := svc.GetStatus(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 100, status.CurrentPerMinute)
+	assert.EqualValues(t, 100, status.SentThisMinute)
+	require.NotNil(t, status.RampStartedAt)
+	assert.True(t, status.RampStartedAt.Equal(started))
+	store.AssertExpectations(t)
+}
+
+func TestEmailThrottlePauseAppliesLive(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	svc, repo, store := setupEmailThrottleServiceTest(&now)
+	unpaused := &models.EmailThrottleSettings{
+		MaxPerMinute:  100,
+		DefaultWindow: models.EmailSendWindow{Timezone: "UTC"},
+	}
+	repo.On("GetSettings", mock.Anything).Return(unpaused, nil).Once()
+	expectBulkEmailSends(store, now, 2)
+
+	assert.Equal(t, 1, allowedBulkEmails(t, svc, 1))
+
+	repo.On("UpdateSettings", mock.Anything, mock.MatchedBy(func(settings *models.EmailThrottleSettings) bool {
+		return settings.Paused && settings.UpdatedBy != nil
+	})).Return(nil).Once()
+	_, err := svc.UpdateSettings(context.Background(), &models.UpdateEmailThrottleRequest{
+		Paused:        true,
+		MaxPerMinute:  100,
+		DefaultWindow: models.EmailSendWindow{Timezone: "UTC"},
+	}, uuid.New())
+	require.NoError(t, err)
+	assert.Zero(t, allowedBulkEmails(t, svc, 1), "paused at once on the instance that changed it")
+
+	// Other instances pick changes up when their settings go stale
+	repo.On("GetSettings", mock.Anything).Return(unpaused, nil).Once()
+	assert.Zero(t, allowedBulkEmails(t, svc, 1))
+	now = now.Add(emailThrottleSettingsTTL)
+	assert.Equal(t, 1, allowedBulkEmails(t, svc, 1))
+
+	repo.AssertExpectations(t)
+	store.AssertExpectations(t)
+}
+
+func TestEmailThrottleSendWindows(t *testing.T) {
+	settings := &models.EmailThrottleSettings{
+		DefaultWindow: models.EmailSendWindow{Timezone: "UTC", StartHour: 9, EndHour: 21},
+		Windows: []models.EmailSendWindow{
+			{Timezone: "America/New_York", StartHour: 10, EndHour: 18},
+			{Timezone: "Asia/Tokyo", StartHour: 20, EndHour: 2},
+		},
+	}
+	newYork, tokyo, berlin := "America/New_York", "Asia/Tokyo", "Europe/Berlin"
+
+	tests := []struct {
+		name     string
+		timezone *string
+		at       time.Time
+		want     bool
+	}{
+		{"default zone inside", nil, time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC), true},
+		{"default zone end is exclusive", nil, time.Date(2026, 10, 16, 21, 0, 0, 0, time.UTC), false},
+		{"own window, 11:00 in New York", &newYork, time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC), true},
+		{"own window, 18:00 in New York", &newYork, time.Date(2026, 10, 16, 22, 0, 0, 0, time.UTC), false},
+		{"default hours in local time, 8:00 in Berlin", &berlin, time.Date(2026, 10, 16, 6, 0, 0, 0, time.UTC), false},
+		{"default hours in local time, 20:00 in Berlin", &berlin, time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC), true},
+		{"wraps past midnight, 1:00 in Tokyo", &tokyo, time.Date(2026, 10, 16, 16, 0, 0, 0, time.UTC), true},
+		{"wraps past midnight, 12:00 in Tokyo", &tokyo, time.Date(2026, 10, 16, 3, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, inSendWindow(settings, tt.timezone, tt.at))
+		})
+	}
+
+	allDay := &models.EmailThrottleSettings{DefaultWindow: models.EmailSendWindow{Timezone: "UTC", StartHour: 0, EndHour: 0}}
+	assert.True(t, inSendWindow(allDay, nil, time.Date(2026, 10, 16, 3, 0, 0, 0, time.UTC)))
+}
+
+func TestEmailThrottleValidation(t *testing.T) {
+	now := time.Now()
+	svc, repo, _ := setupEmailThrottleServiceTest(&now)
+	repo.On("UpdateSettings", mock.Anything, mock.AnythingOfType("*models.EmailThrottleSettings")).Return(nil).Once()
+	valid := func() *models.UpdateEmailThrottleRequest {
+		return &models.UpdateEmailThrottleRequest{
+			MaxPerMinute:       100,
+			RampStartPerMinute: 10,
+			RampMinutes:        60,
+			DefaultWindow:      models.EmailSendWindow{Timezone: "UTC", StartHour: 9, EndHour: 21},
+			Windows:            []models.EmailSendWindow{{Timezone: "Europe/Berlin", StartHour: 8, EndHour: 20}},
+		}
+	}
+
+	_, err := svc.UpdateSettings(context.Background(), valid(), uuid.New())
+	require.NoError(t, err)
+
+	invalid := map[string]func(*models.UpdateEmailThrottleRequest){
+		"ramp above max":     func(r *models.UpdateEmailThrottleRequest) { r.RampStartPerMinute = 200 },
+		"unknown time zone":  func(r *models.UpdateEmailThrottleRequest) { r.Windows[0].Timezone = "Mars/Olympus" },
+		"hour out of range":  func(r *models.UpdateEmailThrottleRequest) { r.DefaultWindow.EndHour = 24 },
+		"duplicate timezone": func(r *models.UpdateEmailThrottleRequest) { r.Windows = append(r.Windows, r.Windows[0]) },
+	}
+	for name, mutate := range invalid {
+		t.Run(name, func(t *testing.T) {
+			req := valid()
+			mutate(req)
+			_, err := svc.UpdateSettings(context.Background(), req, uuid.New())
+			assert.ErrorIs(t, err, ErrInvalidEmailThrottle)
+		})
+	}
+	repo.AssertExpectations(t)
+}
+
+func TestReengagementObeysThrottle(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	inactiveSince := now.AddDate(0, 0, -31)
+	userIDs := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+
+	prefs := map[uuid.UUID]*models.NotificationPreferences{}
+	var candidates []models.ReengagementCandidate
+	for _, userID := range userIDs {
+		candidates = append(candidates, models.ReengagementCandidate{UserID: userID, MilestoneDays: 30, InactiveSince: inactiveSince})
+		prefs[userID] = reengagementPrefs()
+	}
+	svc, repo, sender := setupReengagementServiceTest(prefs)
+	expectReengagementDue(repo, now, candidates)
+	emails := make([]*models.ReengagementEmail, len(userIDs))
+	for i, userID := range userIDs {
+		emails[i] = expectReengagementEmail(repo, userID)
+		repo.On("LastActivity", mock.Anything, userID).Return(inactiveSince, nil).Once()
+		repo.On("TopClipsFromFollows", mock.Anything, userID, inactiveSince, reengagementMaxClips).
+			Return([]models.ReengagementClip{{ID: uuid.New()}}, nil).Once()
+	}
+	logID := uuid.New()
+	sender.On("SendReengagementEmail", mock.Anything, mock.Anything, mock.Anything).Return(&logID, nil)
+	throttle := new(MockBulkEmailThrottle)
+	throttle.On("AllowBulkEmail", mock.Anything, mock.Anything).Return(BulkEmailAllowed, nil).Once()
+	throttle.On("AllowBulkEmail", mock.Anything, mock.Anything).Return(BulkEmailOutsideWindow, nil).Once()
+	throttle.On("AllowBulkEmail", mock.Anything, mock.Anything).Return(BulkEmailThrottled, nil).Once()
+	throttle.On("AllowBulkEmail", mock.Anything, mock.Anything).Return(BulkEmailAllowed, nil)
+	svc.SetThrottle(throttle)
+
+	sent, err := svc.SendDue(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	sender.AssertNumberOfCalls(t, "SendReengagementEmail", 1)
+	assert.Equal(t, models.ReengagementStatusSent, emails[0].Status)
+	assert.Empty(t, emails[1].Status, "outside its window, left due")
+	assert.Empty(t, emails[2].Status, "throttled, left due")
+
+	// The next run picks up where the throttle stopped
+	repo.On("Claim", mock.Anything, reengagementEmailFor(userIDs[0]), reengagementMaxAttempts).Return(false, nil).Once()
+	sent, err = svc.SendDue(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	repo.AssertExpectations(t)
+}
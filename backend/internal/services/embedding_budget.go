@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/subculture-collective/clipper/pkg/metrics"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const (
+	// embeddingSpendKeyPrefix prefixes the Redis key holding a month's spend
+	embeddingSpendKeyPrefix = "embedding:spend:"
+	// embeddingSpendKeyTTL keeps a month's spend a little past the month end
+	embeddingSpendKeyTTL = 40 * 24 * time.Hour
+	// DefaultEmbeddingBudgetAlertThreshold is the share of the budget spent
+	// at which a warning is logged
+	DefaultEmbeddingBudgetAlertThreshold = 0.8
+)
+
+// ErrEmbeddingBudgetExceeded is returned instead of calling the embedding API
+// once the monthly budget is spent. Cached embeddings are still served.
+var ErrEmbeddingBudgetExceeded = errors.New("monthly embedding budget exceeded")
+
+// embeddingPricesPerMillionTokens are OpenAI's and Cohere's list prices in US
+// dollars. Self-hosted models cost nothing per token.
+var embeddingPricesPerMillionTokens = map[string]float64{
+	"text-embedding-3-small":  0.02,
+	"text-embedding-3-large":  0.13,
+	"text-embedding-ada-002":  0.10,
+	"embed-multilingual-v2.0": 0.10,
+}
+
+// EmbeddingPricePerMillionTokens returns the price of a model in US dollars
+// per million tokens: override when set, otherwise the known list price, or 0
+// for unknown models
+func EmbeddingPricePerMillionTokens(model string, override float64) float64 {
+	if override > 0 {
+		return override
+	}
+	return embeddingPricesPerMillionTokens[model]
+}
+
+// EmbeddingBudget tracks embedding API spend against a monthly budget. Spend
+// is kept in Redis so every API and worker process shares it, or in memory
+// without Redis. Months are calendar months in UTC.
+type EmbeddingBudget struct {
+	redisClient    redis.UniversalClient
+	monthlyUSD     float64
+	alertThreshold float64
+	now            func() time.Time
+
+	mu         sync.Mutex
+	month      string
+	localSpend float64
+	alerted    map[string]bool
+}
+
+// NewEmbeddingBudget creates a budget of monthlyUSD, or an unlimited one that
+// only tracks spend when monthlyUSD is 0. A warning is logged once spend
+// reaches alertThreshold of the budget.
+func NewEmbeddingBudget(redisClient redis.UniversalClient, monthlyUSD, alertThreshold float64) *EmbeddingBudget {
+	if alertThreshold <= 0 || alertThreshold > 1 {
+		alertThreshold = DefaultEmbeddingBudgetAlertThreshold
+	}
+	metrics.EmbeddingMonthlyBudgetUSD.Set(monthlyUSD)
+	return &EmbeddingBudget{
+		redisClient:    redisClient,
+		monthlyUSD:     monthlyUSD,
+		alertThreshold: alertThreshold,
+		now:            time.Now,
+		alerted:        map[string]bool{},
+	}
+}
+
+// currentMonth returns the month spend is counted in, resetting the local
+// spend when a new month starts
+func (b *EmbeddingBudget) currentMonth() string {
+	month := b.now().UTC().Format("2006-01")
+	b.mu.Lock()
+	if b.month != month {
+		b.month = month
+		b.localSpend = 0
+	}
+	b.mu.Unlock()
+	return month
+}
+
+// Spent returns this month's spend in US dollars
+func (b *EmbeddingBudget) Spent(ctx context.Context) (float64, error) {
+	month := b.currentMonth()
+	if b.redisClient == nil {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		return b.localSpend, nil
+	}
+
+	spent, err := b.redisClient.Get(ctx, embeddingSpendKeyPrefix+month).Float64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read embedding spend: %w", err)
+	}
+	return spent, nil
+}
+
+// Check returns ErrEmbeddingBudgetExceeded when spending estimatedUSD more
+// would go over the monthly budget. When the spend can't be read the call is
+// allowed, so a Redis outage doesn't stop embeddings.
+func (b *EmbeddingBudget) Check(ctx context.Context, estimatedUSD float64) error {
+	if b == nil || b.monthlyUSD <= 0 {
+		return nil
+	}
+
+	spent, err := b.Spent(ctx)
+	if err != nil {
+		utils.GetLogger().Warn("Failed to check embedding budget", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return nil
+	}
+	if spent+estimatedUSD > b.monthlyUSD {
+		metrics.EmbeddingBudgetRejections.Inc()
+		b.alertOnce("exhausted", func() {
+			utils.GetLogger().Error("Monthly embedding budget exhausted; embedding API calls are stopped until next month", ErrEmbeddingBudgetExceeded, map[string]interface{}{
+				"spent_usd":  spent,
+				"budget_usd": b.monthlyUSD,
+			})
+		})
+		return ErrEmbeddingBudgetExceeded
+	}
+	return nil
+}
+
+// Record adds the cost of an embedding API call to this month's spend
+func (b *EmbeddingBudget) Record(ctx context.Context, costUSD float64) {
+	if b == nil {
+		return
+	}
+
+	month := b.currentMonth()
+	var spent float64
+	if b.redisClient == nil {
+		b.mu.Lock()
+		b.localSpend += costUSD
+		spent = b.localSpend
+		b.mu.Unlock()
+	} else {
+		key := embeddingSpendKeyPrefix + month
+		pipe := b.redisClient.TxPipeline()
+		incr := pipe.IncrByFloat(ctx, key, costUSD)
+		pipe.Expire(ctx, key, embeddingSpendKeyTTL)
+		if _, err := pipe.Exec(ctx); err != nil {
+			utils.GetLogger().Warn("Failed to record embedding spend", map[string]interface{}{
+				"error":    err.Error(),
+				"cost_usd": costUSD,
+			})
+			return
+		}
+		spent = incr.Val()
+	}
+	metrics.EmbeddingMonthlySpendUSD.Set(spent)
+
+	if b.monthlyUSD > 0 && spent >= b.alertThreshold*b.monthlyUSD {
+		b.alertOnce("threshold", func() {
+			utils.GetLogger().Warn("Embedding spend is nearing the monthly budget", map[string]interface{}{
+				"spent_usd":  spent,
+				"budget_usd": b.monthlyUSD,
+				"threshold":  b.alertThreshold,
+			})
+		})
+	}
+}
+
+// alertOnce runs alert the first time level is reached this month in this process
+func (b *EmbeddingBudget) alertOnce(level string, alert func()) {
+	key := b.currentMonth() + ":" + level
+	b.mu.Lock()
+	seen := b.alerted[key]
+	b.alerted[key] = true
+	b.mu.Unlock()
+	if !seen {
+		alert()
+	}
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddingPricePerMillionTokens(t *testing.T) {
+	assert.Equal(t, 0.02, EmbeddingPricePerMillionTokens("text-embedding-3-small", 0))
+	assert.Equal(t, 0.13, EmbeddingPricePerMillionTokens("text-embedding-3-large", 0))
+	assert.Equal(t, 0.5, EmbeddingPricePerMillionTokens("text-embedding-3-small", 0.5))
+	assert.Equal(t, 0.0, EmbeddingPricePerMillionTokens("custom-model", 0))
+}
+
+func TestEmbeddingBudget_HardStop(t *testing.T) {
+	ctx := context.Background()
+	budget := NewEmbeddingBudget(nil, 1.0, 0.8)
+
+	require.NoError(t, budget.Check(ctx, 0.5))
+	budget.Record(ctx, 0.7)
+
+	assert.NoError(t, budget.Check(ctx, 0.3))
+	assert.ErrorIs(t, budget.Check(ctx, 0.31), ErrEmbeddingBudgetExceeded)
+
+	spent, err := budget.Spent(ctx)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.7, spent, 1e-9)
+}
+
+func TestEmbeddingBudget_ResetsEachMonth(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 9, 30, 23, 0, 0, 0, time.UTC)
+	budget := NewEmbeddingBudget(nil, 1.0, 0)
+	budget.now = func() time.Time { return now }
+
+	budget.Record(ctx, 1.0)
+	assert.ErrorIs(t, budget.Check(ctx, 0.01), ErrEmbeddingBudgetExceeded)
+
+	now = now.Add(2 * time.Hour)
+	assert.NoError(t, budget.Check(ctx, 0.01))
+	spent, err := budget.Spent(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, spent)
+}
+
+func TestEmbeddingBudget_Unlimited(t *testing.T) {
+	ctx := context.Background()
+	budget := NewEmbeddingBudget(nil, 0, 0.8)
+
+	budget.Record(ctx, 100)
+	assert.NoError(t, budget.Check(ctx, 100))
+
+	spent, err := budget.Spent(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 100.0, spent)
+}
+
+func TestEmbeddingBudget_Nil(t *testing.T) {
+	var budget *EmbeddingBudget
+
+	assert.NoError(t, budget.Check(context.Background(), 1))
+	budget.Record(context.Background(), 1)
+}
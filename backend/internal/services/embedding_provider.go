@@ -0,0 +1,345 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/subculture-collective/clipper/config"
+)
+
+// Embedding providers, selected with EMBEDDING_PROVIDER
+const (
+	EmbeddingProviderOpenAI = "openai"
+	EmbeddingProviderTEI    = "tei"
+	EmbeddingProviderCohere = "cohere"
+)
+
+const (
+	// DefaultCohereEmbeddingModel is the default Cohere model, one with
+	// EmbeddingDimensions dimensions
+	DefaultCohereEmbeddingModel = "embed-multilingual-v2.0"
+	// DefaultTEIEmbeddingModel names the model a TEI server is expected to
+	// run when EMBEDDING_MODEL isn't set. It only labels metrics and cache keys.
+	DefaultTEIEmbeddingModel = "BAAI/bge-base-en-v1.5"
+	// CohereMaxBatchInputs is the most texts Cohere accepts per request
+	CohereMaxBatchInputs = 96
+
+	defaultOpenAIEmbeddingURL = "https://api.openai.com/v1/embeddings"
+	defaultCohereEmbeddingURL = "https://api.cohere.com/v2/embed"
+)
+
+// EmbeddingInputType tells a provider whether texts are search queries or
+// documents being indexed. Asymmetric models such as Cohere's embed them
+// differently.
+type EmbeddingInputType string
+
+// Embedding input types
+const (
+	EmbeddingInputQuery    EmbeddingInputType = "query"
+	EmbeddingInputDocument EmbeddingInputType = "document"
+)
+
+// EmbeddingProvider generates embeddings with a hosted or self-hosted model.
+// EmbeddingService adds caching, batching, rate limiting, retries and budget
+// tracking on top, so providers only make requests.
+type EmbeddingProvider interface {
+	// Name identifies the provider, e.g. "openai"
+	Name() string
+	// Embed returns one embedding per text, in order, and the tokens the
+	// provider billed, or 0 if it doesn't report them
+	Embed(ctx context.Context, texts []string, inputType EmbeddingInputType) ([][]float32, int, error)
+}
+
+// inputTypeAwareProvider is implemented by providers whose embeddings depend
+// on the input type, so queries and documents are cached apart
+type inputTypeAwareProvider interface {
+	EmbedsInputTypesDifferently() bool
+}
+
+// NewEmbeddingProvider creates the provider with the given name. An empty
+// name is OpenAI. baseURL overrides the provider's API URL and is required
+// for a self-hosted TEI endpoint.
+func NewEmbeddingProvider(name, apiKey, baseURL, model string, httpClient *http.Client) (EmbeddingProvider, error) {
+	switch strings.ToLower(name) {
+	case "", EmbeddingProviderOpenAI:
+		if baseURL == "" {
+			baseURL = defaultOpenAIEmbeddingURL
+		}
+		return &OpenAIEmbeddingProvider{apiKey: apiKey, url: baseURL, model: model, httpClient: httpClient}, nil
+	case EmbeddingProviderTEI:
+		if baseURL == "" {
+			return nil, fmt.Errorf("the %s embedding provider needs EMBEDDING_API_BASE_URL", EmbeddingProviderTEI)
+		}
+		url := strings.TrimSuffix(baseURL, "/")
+		if !strings.HasSuffix(url, "/embed") {
+			url += "/embed"
+		}
+		return &TEIEmbeddingProvider{apiKey: apiKey, url: url, httpClient: httpClient}, nil
+	case EmbeddingProviderCohere:
+		if baseURL == "" {
+			baseURL = defaultCohereEmbeddingURL
+		}
+		return &CohereEmbeddingProvider{apiKey: apiKey, url: baseURL, model: model, httpClient: httpClient}, nil
+	default:
+		return nil, fmt.Errorf("unknown embedding provider %q", name)
+	}
+}
+
+// DefaultEmbeddingModelFor returns the default model of a provider
+func DefaultEmbeddingModelFor(provider string) string {
+	switch strings.ToLower(provider) {
+	case EmbeddingProviderCohere:
+		return DefaultCohereEmbeddingModel
+	case EmbeddingProviderTEI:
+		return DefaultTEIEmbeddingModel
+	default:
+		return DefaultEmbeddingModel
+	}
+}
+
+// maxEmbeddingBatchInputsFor returns the most texts a provider accepts per
+// request
+func maxEmbeddingBatchInputsFor(provider string) int {
+	if provider == EmbeddingProviderCohere {
+		return CohereMaxBatchInputs
+	}
+	return MaxEmbeddingBatchInputs
+}
+
+// unavailableEmbeddingProvider stands in for a misconfigured provider and
+// fails every request with the configuration error
+type unavailableEmbeddingProvider struct {
+	name string
+	err  error
+}
+
+func (p *unavailableEmbeddingProvider) Name() string {
+	return p.name
+}
+
+func (p *unavailableEmbeddingProvider) Embed(ctx context.Context, texts []string, inputType EmbeddingInputType) ([][]float32, int, error) {
+	return nil, 0, p.err
+}
+
+// EmbeddingAPIError is an embedding API response with an error status
+type EmbeddingAPIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *EmbeddingAPIError) Error() string {
+	return fmt.Sprintf("API returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// rejectsInput reports whether the API refused the request's texts, so
+// sending them again can't succeed. Rate limits and server errors are
+// worth retrying.
+func (e *EmbeddingAPIError) rejectsInput() bool {
+	return e.StatusCode >= 400 && e.StatusCode < 500 &&
+		e.StatusCode != http.StatusTooManyRequests &&
+		e.StatusCode != http.StatusUnauthorized &&
+		e.StatusCode != http.StatusForbidden
+}
+
+// postEmbeddingJSON posts a JSON request to an embedding API and decodes the
+// JSON response into out
+func postEmbeddingJSON(ctx context.Context, httpClient *http.Client, url, apiKey string, body, out interface{}) error {
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("API request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return &EmbeddingAPIError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// OpenAIEmbeddingProvider uses the OpenAI embeddings API, or any API
+// compatible with it
+type OpenAIEmbeddingProvider struct {
+	apiKey     string
+	url        string
+	model      string
+	dimensions int // Requested from text-embedding-3 models, default EmbeddingDimensions
+	httpClient *http.Client
+}
+
+// Name returns "openai"
+func (p *OpenAIEmbeddingProvider) Name() string {
+	return EmbeddingProviderOpenAI
+}
+
+// Embed embeds texts in one request. text-embedding-3 models are asked for
+// the configured dimensions to match the stored vectors.
+func (p *OpenAIEmbeddingProvider) Embed(ctx context.Context, texts []string, inputType EmbeddingInputType) ([][]float32, int, error) {
+	reqBody := EmbeddingRequest{
+		Input: texts,
+		Model: p.model,
+	}
+	if strings.HasPrefix(p.model, "text-embedding-3") {
+		reqBody.Dimensions = p.dimensions
+		if reqBody.Dimensions == 0 {
+			reqBody.Dimensions = EmbeddingDimensions
+		}
+	}
+
+	var embeddingResp EmbeddingResponse
+	if err := postEmbeddingJSON(ctx, p.httpClient, p.url, p.apiKey, reqBody, &embeddingResp); err != nil {
+		return nil, 0, err
+	}
+	if len(embeddingResp.Data) != len(texts) {
+		return nil, 0, fmt.Errorf("expected %d embeddings, got %d", len(texts), len(embeddingResp.Data))
+	}
+
+	sort.SliceStable(embeddingResp.Data, func(i, j int) bool {
+		return embeddingResp.Data[i].Index < embeddingResp.Data[j].Index
+	})
+	embeddings := make([][]float32, len(embeddingResp.Data))
+	for i, data := range embeddingResp.Data {
+		embeddings[i] = data.Embedding
+	}
+	return embeddings, embeddingResp.Usage.TotalTokens, nil
+}
+
+// TEIEmbeddingProvider uses a self-hosted Hugging Face text-embeddings-inference
+// server, or a sentence-transformers server with the same /embed API. The
+// model is whatever the server runs.
+type TEIEmbeddingProvider struct {
+	apiKey     string
+	url        string
+	httpClient *http.Client
+}
+
+// teiEmbedRequest is the body of a TEI /embed request
+type teiEmbedRequest struct {
+	Inputs   []string `json:"inputs"`
+	Truncate bool     `json:"truncate"`
+}
+
+// Name returns "tei"
+func (p *TEIEmbeddingProvider) Name() string {
+	return EmbeddingProviderTEI
+}
+
+// Embed embeds texts in one request. Self-hosted models aren't billed, so no
+// tokens are reported.
+func (p *TEIEmbeddingProvider) Embed(ctx context.Context, texts []string, inputType EmbeddingInputType) ([][]float32, int, error) {
+	var embeddings [][]float32
+	if err := postEmbeddingJSON(ctx, p.httpClient, p.url, p.apiKey, teiEmbedRequest{Inputs: texts, Truncate: true}, &embeddings); err != nil {
+		return nil, 0, err
+	}
+	if len(embeddings) != len(texts) {
+		return nil, 0, fmt.Errorf("expected %d embeddings, got %d", len(texts), len(embeddings))
+	}
+	return embeddings, 0, nil
+}
+
+// CohereEmbeddingProvider uses the Cohere v2 embed API
+type CohereEmbeddingProvider struct {
+	apiKey     string
+	url        string
+	model      string
+	httpClient *http.Client
+}
+
+// cohereEmbedRequest is the body of a Cohere embed request
+type cohereEmbedRequest struct {
+	Model          string   `json:"model"`
+	Texts          []string `json:"texts"`
+	InputType      string   `json:"input_type"`
+	EmbeddingTypes []string `json:"embedding_types"`
+	Truncate       string   `json:"truncate"`
+}
+
+// cohereEmbedResponse is the response of a Cohere embed request
+type cohereEmbedResponse struct {
+	Embeddings struct {
+		Float [][]float32 `json:"float"`
+	} `json:"embeddings"`
+	Meta struct {
+		BilledUnits struct {
+			InputTokens int `json:"input_tokens"`
+		} `json:"billed_units"`
+	} `json:"meta"`
+}
+
+// Name returns "cohere"
+func (p *CohereEmbeddingProvider) Name() string {
+	return EmbeddingProviderCohere
+}
+
+// EmbedsInputTypesDifferently reports that Cohere embeds queries and
+// documents differently
+func (p *CohereEmbeddingProvider) EmbedsInputTypesDifferently() bool {
+	return true
+}
+
+// Embed embeds texts in one request of at most CohereMaxBatchInputs texts
+func (p *CohereEmbeddingProvider) Embed(ctx context.Context, texts []string, inputType EmbeddingInputType) ([][]float32, int, error) {
+	cohereInputType := "search_query"
+	if inputType == EmbeddingInputDocument {
+		cohereInputType = "search_document"
+	}
+	reqBody := cohereEmbedRequest{
+		Model:          p.model,
+		Texts:          texts,
+		InputType:      cohereInputType,
+		EmbeddingTypes: []string{"float"},
+		Truncate:       "END",
+	}
+
+	var embedResp cohereEmbedResponse
+	if err := postEmbeddingJSON(ctx, p.httpClient, p.url, p.apiKey, reqBody, &embedResp); err != nil {
+		return nil, 0, err
+	}
+	if len(embedResp.Embeddings.Float) != len(texts) {
+		return nil, 0, fmt.Errorf("expected %d embeddings, got %d", len(texts), len(embedResp.Embeddings.Float))
+	}
+	return embedResp.Embeddings.Float, embedResp.Meta.BilledUnits.InputTokens, nil
+}
+
+// ConfiguredEmbeddingConfig builds the embedding service configuration for
+// the provider, model and dimensions in cfg
+func ConfiguredEmbeddingConfig(cfg *config.EmbeddingConfig, redisClient redis.UniversalClient) *EmbeddingConfig {
+	return &EmbeddingConfig{
+		Provider:              cfg.Provider,
+		APIKey:                cfg.ProviderAPIKey(),
+		APIBaseURL:            cfg.APIBaseURL,
+		Model:                 cfg.Model,
+		Dimensions:            cfg.Dimensions,
+		RedisClient:           redisClient,
+		RequestsPerMinute:     cfg.RequestsPerMinute,
+		MaxBatchInputs:        cfg.BatchMaxInputs,
+		MaxBatchTokens:        cfg.BatchMaxTokens,
+		PricePerMillionTokens: cfg.PricePerMillionTokens,
+		Budget:                NewEmbeddingBudget(redisClient, cfg.MonthlyBudgetUSD, cfg.BudgetAlertThreshold),
+	}
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEmbeddingProvider(t *testing.T) {
+	client := &http.Client{}
+
+	provider, err := NewEmbeddingProvider("", "key", "", DefaultEmbeddingModel, client)
+	require.NoError(t, err)
+	assert.Equal(t, EmbeddingProviderOpenAI, provider.Name())
+
+	provider, err = NewEmbeddingProvider("Cohere", "key", "", DefaultCohereEmbeddingModel, client)
+	require.NoError(t, err)
+	assert.Equal(t, EmbeddingProviderCohere, provider.Name())
+
+	_, err = NewEmbeddingProvider(EmbeddingProviderTEI, "", "", "", client)
+	assert.Error(t, err, "TEI needs an endpoint")
+
+	provider, err = NewEmbeddingProvider(EmbeddingProviderTEI, "", "http://tei:8080/", "", client)
+	require.NoError(t, err)
+	assert.Equal(t, "http://tei:8080/embed", provider.(*TEIEmbeddingProvider).url)
+
+	_, err = NewEmbeddingProvider("unknown", "key", "", "", client)
+	assert.Error(t, err)
+}
+
+func TestDefaultEmbeddingModelFor(t *testing.T) {
+	assert.Equal(t, DefaultEmbeddingModel, DefaultEmbeddingModelFor(EmbeddingProviderOpenAI))
+	assert.Equal(t, DefaultEmbeddingModel, DefaultEmbeddingModelFor(""))
+	assert.Equal(t, DefaultCohereEmbeddingModel, DefaultEmbeddingModelFor(EmbeddingProviderCohere))
+	assert.Equal(t, DefaultTEIEmbeddingModel, DefaultEmbeddingModelFor(EmbeddingProviderTEI))
+}
+
+func TestOpenAIEmbeddingProvider_Embed(t *testing.T) {
+	var got EmbeddingRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		// Out of order, as the API doesn't promise order
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{
+				{"embedding": []float32{2}, "index": 1},
+				{"embedding": []float32{1}, "index": 0},
+			},
+			"usage": map[string]int{"total_tokens": 7},
+		})
+	}))
+	defer server.Close()
+
+	provider, err := NewEmbeddingProvider(EmbeddingProviderOpenAI, "key", server.URL, DefaultEmbeddingModel, server.Client())
+	require.NoError(t, err)
+
+	embeddings, tokens, err := provider.Embed(context.Background(), []string{"a", "b"}, EmbeddingInputQuery)
+	require.NoError(t, err)
+	assert.Equal(t, [][]float32{{1}, {2}}, embeddings)
+	assert.Equal(t, 7, tokens)
+	assert.Equal(t, EmbeddingDimensions, got.Dimensions)
+	assert.Equal(t, DefaultEmbeddingModel, got.Model)
+}
+
+func TestTEIEmbeddingProvider_Embed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/embed", r.URL.Path)
+		assert.Empty(t, r.Header.Get("Authorization"))
+
+		var req teiEmbedRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.True(t, req.Truncate)
+
+		embeddings := make([][]float32, len(req.Inputs))
+		for i, input := range req.Inputs {
+			embeddings[i] = []float32{float32(len(input))}
+		}
+		_ = json.NewEncoder(w).Encode(embeddings)
+	}))
+	defer server.Close()
+
+	provider, err := NewEmbeddingProvider(EmbeddingProviderTEI, "", server.URL, "", server.Client())
+	require.NoError(t, err)
+
+	embeddings, tokens, err := provider.Embed(context.Background(), []string{"a", "bb"}, EmbeddingInputDocument)
+	require.NoError(t, err)
+	assert.Equal(t, [][]float32{{1}, {2}}, embeddings)
+	assert.Zero(t, tokens)
+}
+
+func TestTEIEmbeddingProvider_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "model overloaded", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	provider, err := NewEmbeddingProvider(EmbeddingProviderTEI, "", server.URL, "", server.Client())
+	require.NoError(t, err)
+
+	_, _, err = provider.Embed(context.Background(), []string{"a"}, EmbeddingInputQuery)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "503")
+}
+
+func TestCohereEmbeddingProvider_Embed(t *testing.T) {
+	var inputTypes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+
+		var req cohereEmbedRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, DefaultCohereEmbeddingModel, req.Model)
+		assert.Equal(t, []string{"float"}, req.EmbeddingTypes)
+		inputTypes = append(inputTypes, req.InputType)
+
+		embeddings := make([][]float32, len(req.Texts))
+		for i := range req.Texts {
+			embeddings[i] = []float32{float32(i)}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"embeddings": map[string]interface{}{"float": embeddings},
+			"meta":       map[string]interface{}{"billed_units": map[string]int{"input_tokens": 12}},
+		})
+	}))
+	defer server.Close()
+
+	provider, err := NewEmbeddingProvider(EmbeddingProviderCohere, "key", server.URL, DefaultCohereEmbeddingModel, server.Client())
+	require.NoError(t, err)
+
+	embeddings, tokens, err := provider.Embed(context.Background(), []string{"a", "b"}, EmbeddingInputDocument)
+	require.NoError(t, err)
+	assert.Equal(t, [][]float32{{0}, {1}}, embeddings)
+	assert.Equal(t, 12, tokens)
+
+	_, _, err = provider.Embed(context.Background(), []string{"a"}, EmbeddingInputQuery)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"search_document", "search_query"}, inputTypes)
+}
+
+func TestEmbeddingService_CacheKeyByProvider(t *testing.T) {
+	openAI := &EmbeddingService{model: DefaultEmbeddingModel, provider: &OpenAIEmbeddingProvider{}}
+	assert.Equal(t, openAI.getCacheKey("text"), openAI.cacheKey("text", EmbeddingInputDocument))
+	assert.Equal(t, openAI.cacheKey("text", EmbeddingInputQuery), openAI.cacheKey("text", EmbeddingInputDocument))
+
+	cohere := &EmbeddingService{model: DefaultCohereEmbeddingModel, provider: &CohereEmbeddingProvider{}}
+	assert.NotEqual(t, cohere.cacheKey("text", EmbeddingInputQuery), cohere.cacheKey("text", EmbeddingInputDocument))
+}
+
+func TestNewEmbeddingService_Providers(t *testing.T) {
+	service := NewEmbeddingService(&EmbeddingConfig{Provider: EmbeddingProviderCohere, APIKey: "key", MaxBatchInputs: 500})
+	defer service.Close()
+	assert.Equal(t, EmbeddingProviderCohere, service.GetProvider())
+	assert.Equal(t, DefaultCohereEmbeddingModel, service.GetModel())
+	assert.Equal(t, CohereMaxBatchInputs, service.maxBatchInputs)
+
+	// A misconfigured provider fails its requests instead of falling back
+	service = NewEmbeddingService(&EmbeddingConfig{Provider: EmbeddingProviderTEI})
+	defer service.Close()
+	_, _, err := service.provider.Embed(context.Background(), []string{"a"}, EmbeddingInputQuery)
+	assert.Error(t, err)
+}
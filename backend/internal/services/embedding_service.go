@@ -1,17 +1,17 @@
 package services
 
 import (
-	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/redis/go-redis/v9"
 	"github.com/subculture-collective/clipper/internal/models"
@@ -29,16 +29,46 @@ const (
 	MaxRetries = 3
 	// RetryDelay between retries
 	RetryDelay = 2 * time.Second
+	// DefaultEmbeddingBatchInputs is the default number of texts per API request
+	DefaultEmbeddingBatchInputs = 100
+	// MaxEmbeddingBatchInputs is the most texts the API accepts per request
+	MaxEmbeddingBatchInputs = 2048
+	// DefaultEmbeddingBatchTokens is the default estimated token total per API request
+	DefaultEmbeddingBatchTokens = 100000
+	// MaxEmbeddingBatchTokens is the most tokens the API accepts per request
+	MaxEmbeddingBatchTokens = 300000
+	// MaxEmbeddingInputTokens is the most tokens the API accepts per text
+	MaxEmbeddingInputTokens = 8191
 )
 
+// EmbeddingBatchError reports the texts of a batch that got no embedding.
+// Their results are nil; the other results are usable.
+type EmbeddingBatchError struct {
+	Failed []int // Indexes into the batch's texts
+	Err    error // The first failure
+}
+
+func (e *EmbeddingBatchError) Error() string {
+	return fmt.Sprintf("failed to generate %d batch embeddings: %v", len(e.Failed), e.Err)
+}
+
+func (e *EmbeddingBatchError) Unwrap() error {
+	return e.Err
+}
+
 // EmbeddingService handles generating and caching text embeddings
 type EmbeddingService struct {
-	apiKey      string
-	apiBaseURL  string
+	provider    EmbeddingProvider
 	model       string
-	redisClient *redis.Client
+	dimensions  int
+	redisClient redis.UniversalClient
 	httpClient  *http.Client
 	rateLimiter *time.Ticker
+
+	maxBatchInputs  int
+	maxBatchTokens  int
+	pricePerMillion float64
+	budget          *EmbeddingBudget // nil when spend is not tracked
 }
 
 // EmbeddingRequest represents OpenAI embedding API request
@@ -46,6 +76,7 @@ type EmbeddingRequest struct {
 	Input          interface{} `json:"input"`
 	Model          string      `json:"model"`
 	EncodingFormat string      `json:"encoding_format,omitempty"`
+	Dimensions     int         `json:"dimensions,omitempty"`
 }
 
 // EmbeddingResponse represents OpenAI embedding API response
@@ -65,18 +96,35 @@ type EmbeddingResponse struct {
 
 // EmbeddingConfig holds configuration for the embedding service
 type EmbeddingConfig struct {
+	Provider          string // openai (default), tei or cohere
 	APIKey            string
 	APIBaseURL        string
 	Model             string
-	RedisClient       *redis.Client
+	Dimensions        int // Vector size the model is asked for, default EmbeddingDimensions
+	RedisClient       redis.UniversalClient
 	RequestsPerMinute int // Rate limiting
+
+	MaxBatchInputs        int              // Texts per API request, default 100
+	MaxBatchTokens        int              // Estimated tokens per API request, default 100000
+	PricePerMillionTokens float64          // Overrides the model's list price in US dollars
+	Budget                *EmbeddingBudget // Monthly spend limit, optional
 }
 
 // NewEmbeddingService creates a new embedding service
 func NewEmbeddingService(config *EmbeddingConfig) *EmbeddingService {
+	providerName := strings.ToLower(config.Provider)
+	if providerName == "" {
+		providerName = EmbeddingProviderOpenAI
+	}
+
 	model := config.Model
 	if model == "" {
-		model = DefaultEmbeddingModel
+		model = DefaultEmbeddingModelFor(providerName)
+	}
+
+	dimensions := config.Dimensions
+	if dimensions <= 0 {
+		dimensions = EmbeddingDimensions
 	}
 
 	rpm := config.RequestsPerMinute
@@ -84,25 +132,54 @@ func NewEmbeddingService(config *EmbeddingConfig) *EmbeddingService {
 		rpm = 500 // Default: 500 requests per minute for tier 1
 	}
 
-	// Validate API key
-	apiBaseURL := config.APIBaseURL
-	if apiBaseURL == "" {
-		apiBaseURL = "https://api.openai.com/v1/embeddings"
+	httpClient := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+	provider, err := NewEmbeddingProvider(providerName, config.APIKey, config.APIBaseURL, model, httpClient)
+	if err != nil {
+		log.Printf("WARNING: %v - embedding service will fail at runtime", err)
+		provider = &unavailableEmbeddingProvider{name: providerName, err: err}
+	}
+	if p, ok := provider.(*OpenAIEmbeddingProvider); ok {
+		p.dimensions = dimensions
 	}
 
-	if config.APIKey == "" && apiBaseURL == "https://api.openai.com/v1/embeddings" {
+	// Validate API key. Self-hosted endpoints may not need one.
+	if config.APIKey == "" && config.APIBaseURL == "" && providerName != EmbeddingProviderTEI {
 		log.Println("WARNING: Embedding API key is empty - embedding service will fail at runtime")
 	}
 
+	maxBatchInputs := config.MaxBatchInputs
+	if maxBatchInputs <= 0 {
+		maxBatchInputs = DefaultEmbeddingBatchInputs
+	}
+	if limit := maxEmbeddingBatchInputsFor(providerName); maxBatchInputs > limit {
+		maxBatchInputs = limit
+	}
+	maxBatchTokens := config.MaxBatchTokens
+	if maxBatchTokens <= 0 {
+		maxBatchTokens = DefaultEmbeddingBatchTokens
+	}
+	if maxBatchTokens > MaxEmbeddingBatchTokens {
+		maxBatchTokens = MaxEmbeddingBatchTokens
+	}
+
+	pricePerMillion := EmbeddingPricePerMillionTokens(model, config.PricePerMillionTokens)
+	if pricePerMillion == 0 && config.Budget != nil && providerName != EmbeddingProviderTEI {
+		log.Printf("WARNING: No price known for embedding model %s - set EMBEDDING_PRICE_PER_MILLION_TOKENS for the budget to count its spend", model)
+	}
+
 	return &EmbeddingService{
-		apiKey:      config.APIKey,
-		apiBaseURL:  apiBaseURL,
-		model:       model,
-		redisClient: config.RedisClient,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		rateLimiter: time.NewTicker(time.Minute / time.Duration(rpm)),
+		provider:        provider,
+		model:           model,
+		dimensions:      dimensions,
+		redisClient:     config.RedisClient,
+		httpClient:      httpClient,
+		rateLimiter:     time.NewTicker(time.Minute / time.Duration(rpm)),
+		maxBatchInputs:  maxBatchInputs,
+		maxBatchTokens:  maxBatchTokens,
+		pricePerMillion: pricePerMillion,
+		budget:          config.Budget,
 	}
 }
 
@@ -114,15 +191,22 @@ func (s *EmbeddingService) GenerateEmbedding(ctx context.Context, text string) (
 // generateEmbeddingWithType is the core embedding generation logic with metrics tracking
 func (s *EmbeddingService) generateEmbeddingWithType(ctx context.Context, text string, embeddingType string) ([]float32, error) {
 	start := time.Now()
+	inputType := embeddingInputTypeOf(embeddingType)
 
 	// Check cache first
-	cacheKey := s.getCacheKey(text)
+	cacheKey := s.cacheKey(text, inputType)
 	if cached, err := s.getFromCache(ctx, cacheKey); err == nil && cached != nil {
 		recordEmbeddingCacheHit()
 		return cached, nil
 	}
 	recordEmbeddingCacheMiss()
 
+	text = truncateEmbeddingInput(text)
+	if err := s.checkBudget(ctx, []string{text}); err != nil {
+		recordEmbeddingGenerationError(embeddingType)
+		return nil, err
+	}
+
 	// Rate limit
 	select {
 	case <-s.rateLimiter.C:
@@ -132,7 +216,7 @@ func (s *EmbeddingService) generateEmbeddingWithType(ctx context.Context, text s
 	}
 
 	// Generate embedding with retries
-	var embedding []float32
+	var embeddings [][]float32
 	var lastErr error
 
 	for attempt := 0; attempt < MaxRetries; attempt++ {
@@ -141,8 +225,8 @@ func (s *EmbeddingService) generateEmbeddingWithType(ctx context.Context, text s
 			time.Sleep(RetryDelay * time.Duration(1<<uint(attempt)))
 		}
 
-		embedding, lastErr = s.callEmbeddingAPI(ctx, text)
-		if lastErr == nil {
+		embeddings, lastErr = s.embed(ctx, []string{text}, inputType)
+		if lastErr == nil || !retryableEmbeddingError(lastErr) {
 			break
 		}
 	}
@@ -155,6 +239,7 @@ func (s *EmbeddingService) generateEmbeddingWithType(ctx context.Context, text s
 	}
 
 	recordEmbeddingGeneration(embeddingType, duration)
+	embedding := embeddings[0]
 
 	// Cache the result
 	if err := s.saveToCache(ctx, cacheKey, embedding); err != nil {
@@ -165,49 +250,124 @@ func (s *EmbeddingService) generateEmbeddingWithType(ctx context.Context, text s
 	return embedding, nil
 }
 
-// GenerateBatchEmbeddings generates embeddings for multiple texts in a single API call
+// GenerateBatchEmbeddings generates embeddings for multiple texts, sending
+// as few API requests as the batch limits allow. When some texts fail, it
+// returns the other embeddings with an *EmbeddingBatchError.
 func (s *EmbeddingService) GenerateBatchEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	return s.generateBatchEmbeddingsWithType(ctx, texts, "query")
+}
+
+// generateBatchEmbeddingsWithType splits texts into batches of at most
+// maxBatchInputs texts and maxBatchTokens estimated tokens. A failed batch
+// doesn't stop the batches after it, unless the budget ran out or ctx ended.
+func (s *EmbeddingService) generateBatchEmbeddingsWithType(ctx context.Context, texts []string, embeddingType string) ([][]float32, error) {
 	if len(texts) == 0 {
 		return nil, nil
 	}
 
-	// OpenAI supports up to 2048 inputs per request, but we'll use smaller batches
-	// to avoid timeouts and make caching more effective
-	const batchSize = 100
+	inputs := make([]string, len(texts))
+	tokens := make([]int, len(texts))
+	for i, text := range texts {
+		inputs[i] = truncateEmbeddingInput(text)
+		tokens[i] = estimateEmbeddingTokens(inputs[i])
+	}
 
 	results := make([][]float32, len(texts))
-
-	for i := 0; i < len(texts); i += batchSize {
-		end := i + batchSize
-		if end > len(texts) {
-			end = len(texts)
+	var failed []int
+	var firstErr error
+	for _, batch := range planEmbeddingBatches(tokens, s.maxBatchInputs, s.maxBatchTokens) {
+		start, end := batch[0], batch[1]
+		if firstErr != nil && !embeddingBatchesCanContinue(firstErr) {
+			failed = append(failed, indexRange(start, end)...)
+			continue
 		}
 
-		batch := texts[i:end]
-		batchResults, err := s.generateBatch(ctx, batch)
-		if err != nil {
-			return nil, fmt.Errorf("failed to generate batch embeddings (batch %d-%d): %w", i, end, err)
+		batchFailed, err := s.generateRange(ctx, inputs, start, end, embeddingType, results)
+		failed = append(failed, batchFailed...)
+		if firstErr == nil {
+			firstErr = err
 		}
-
-		copy(results[i:end], batchResults)
 	}
 
+	if len(failed) > 0 {
+		return results, &EmbeddingBatchError{Failed: failed, Err: firstErr}
+	}
 	return results, nil
 }
 
+// generateRange fills results[start:end] with one API request. When the API
+// rejects the request, it splits the range in half and tries each, so one
+// text the API refuses doesn't fail the texts batched with it. It returns
+// the indexes still without an embedding and their first error.
+func (s *EmbeddingService) generateRange(ctx context.Context, inputs []string, start, end int, embeddingType string, results [][]float32) ([]int, error) {
+	batchResults, err := s.generateBatch(ctx, inputs[start:end], embeddingType)
+	if err == nil {
+		copy(results[start:end], batchResults)
+		return nil, nil
+	}
+	err = fmt.Errorf("failed to generate batch embeddings (batch %d-%d): %w", start, end, err)
+	if end-start == 1 || !rejectedEmbeddingInput(err) {
+		return indexRange(start, end), err
+	}
+
+	mid := start + (end-start)/2
+	failed, firstErr := s.generateRange(ctx, inputs, start, mid, embeddingType, results)
+	if firstErr != nil && !embeddingBatchesCanContinue(firstErr) {
+		return append(failed, indexRange(mid, end)...), firstErr
+	}
+	moreFailed, err := s.generateRange(ctx, inputs, mid, end, embeddingType, results)
+	if firstErr == nil {
+		firstErr = err
+	}
+	return append(failed, moreFailed...), firstErr
+}
+
+// retryableEmbeddingError reports whether an API request may succeed when
+// sent again
+func retryableEmbeddingError(err error) bool {
+	return !rejectedEmbeddingInput(err)
+}
+
+// rejectedEmbeddingInput reports whether the API refused the texts themselves
+func rejectedEmbeddingInput(err error) bool {
+	var apiErr *EmbeddingAPIError
+	return errors.As(err, &apiErr) && apiErr.rejectsInput()
+}
+
+// embeddingBatchesCanContinue reports whether the batches after a failed one
+// are still worth sending
+func embeddingBatchesCanContinue(err error) bool {
+	return !errors.Is(err, ErrEmbeddingBudgetExceeded) &&
+		!errors.Is(err, context.Canceled) &&
+		!errors.Is(err, context.DeadlineExceeded)
+}
+
+func indexRange(start, end int) []int {
+	indexes := make([]int, 0, end-start)
+	for i := start; i < end; i++ {
+		indexes = append(indexes, i)
+	}
+	return indexes
+}
+
 // generateBatch generates embeddings for a batch of texts
-func (s *EmbeddingService) generateBatch(ctx context.Context, texts []string) ([][]float32, error) {
+func (s *EmbeddingService) generateBatch(ctx context.Context, texts []string, embeddingType string) ([][]float32, error) {
+	start := time.Now()
+	inputType := embeddingInputTypeOf(embeddingType)
+
 	// Check cache once and store results
 	needsGeneration := make([]string, 0, len(texts))
 	cachedResults := make(map[int][]float32) // maps index to cached embedding
 	cacheKeys := make([]string, len(texts))
 
 	for i, text := range texts {
-		cacheKeys[i] = s.getCacheKey(text)
+		cacheKeys[i] = s.cacheKey(text, inputType)
 
 		if cached, err := s.getFromCache(ctx, cacheKeys[i]); err == nil {
+			recordEmbeddingCacheHit()
 			cachedResults[i] = cached
 		} else {
+			recordEmbeddingCacheMiss()
 			needsGeneration = append(needsGeneration, text)
 		}
 	}
@@ -221,6 +381,11 @@ func (s *EmbeddingService) generateBatch(ctx context.Context, texts []string) ([
 		return results, nil
 	}
 
+	if err := s.checkBudget(ctx, needsGeneration); err != nil {
+		recordEmbeddingGenerationError(embeddingType)
+		return nil, err
+	}
+
 	// Rate limit
 	select {
 	case <-s.rateLimiter.C:
@@ -239,19 +404,22 @@ func (s *EmbeddingService) generateBatch(ctx context.Context, texts []string) ([
 			time.Sleep(RetryDelay * time.Duration(1<<uint(attempt)))
 		}
 
-		embeddings, lastErr = s.callBatchEmbeddingAPI(ctx, needsGeneration)
-		if lastErr == nil {
+		embeddings, lastErr = s.embed(ctx, needsGeneration, inputType)
+		if lastErr == nil || !retryableEmbeddingError(lastErr) {
 			break
 		}
 	}
 
 	if lastErr != nil {
+		recordEmbeddingGenerationError(embeddingType)
 		return nil, fmt.Errorf("failed to generate batch embeddings after %d attempts: %w", MaxRetries, lastErr)
 	}
 
+	recordEmbeddingBatchGeneration(embeddingType, len(needsGeneration), float64(time.Since(start).Milliseconds()))
+
 	// Cache the new embeddings
 	for i, text := range needsGeneration {
-		cacheKey := s.getCacheKey(text)
+		cacheKey := s.cacheKey(text, inputType)
 		if err := s.saveToCache(ctx, cacheKey, embeddings[i]); err != nil {
 			log.Printf("Warning: failed to cache embedding: %v", err)
 		}
@@ -279,6 +447,17 @@ func (s *EmbeddingService) GenerateClipEmbedding(ctx context.Context, clip *mode
 	return s.generateEmbeddingWithType(ctx, text, "clip")
 }
 
+// GenerateClipEmbeddings generates embeddings for several clips in batched
+// API requests. The results are in the order of clips. When some clips fail,
+// it returns the other embeddings with an *EmbeddingBatchError.
+func (s *EmbeddingService) GenerateClipEmbeddings(ctx context.Context, clips []models.Clip) ([][]float32, error) {
+	texts := make([]string, len(clips))
+	for i := range clips {
+		texts[i] = s.buildClipText(&clips[i])
+	}
+	return s.generateBatchEmbeddingsWithType(ctx, texts, "clip")
+}
+
 // buildClipText constructs the text representation of a clip for embedding
 func (s *EmbeddingService) buildClipText(clip *models.Clip) string {
 	var parts []string
@@ -304,106 +483,143 @@ func (s *EmbeddingService) buildClipText(clip *models.Clip) string {
 	return strings.Join(parts, ". ")
 }
 
-// callEmbeddingAPI makes a single API call to OpenAI
-func (s *EmbeddingService) callEmbeddingAPI(ctx context.Context, text string) ([]float32, error) {
-	reqBody := EmbeddingRequest{
-		Input: text,
-		Model: s.model,
-	}
-
-	embeddingResp, err := s.executeAPIRequest(ctx, reqBody)
+// embed makes one provider request and records its outcome and usage
+func (s *EmbeddingService) embed(ctx context.Context, texts []string, inputType EmbeddingInputType) ([][]float32, error) {
+	embeddings, billedTokens, err := s.provider.Embed(ctx, texts, inputType)
 	if err != nil {
+		metrics.EmbeddingAPIRequests.WithLabelValues(s.model, "error").Inc()
 		return nil, err
 	}
 
-	if len(embeddingResp.Data) == 0 {
-		return nil, fmt.Errorf("no embeddings returned")
-	}
+	metrics.EmbeddingAPIRequests.WithLabelValues(s.model, "success").Inc()
+	s.recordUsage(ctx, texts, billedTokens)
 
-	return embeddingResp.Data[0].Embedding, nil
+	return embeddings, nil
 }
 
-// callBatchEmbeddingAPI makes a batch API call to OpenAI
-func (s *EmbeddingService) callBatchEmbeddingAPI(ctx context.Context, texts []string) ([][]float32, error) {
-	reqBody := EmbeddingRequest{
-		Input: texts,
-		Model: s.model,
+// checkBudget refuses an API request for texts that the monthly budget can't
+// cover, estimating its cost from the texts' token counts
+func (s *EmbeddingService) checkBudget(ctx context.Context, texts []string) error {
+	if s.budget == nil {
+		return nil
 	}
-
-	embeddingResp, err := s.executeAPIRequest(ctx, reqBody)
-	if err != nil {
-		return nil, err
+	tokens := 0
+	for _, text := range texts {
+		tokens += estimateEmbeddingTokens(text)
 	}
+	return s.budget.Check(ctx, s.costOf(tokens))
+}
 
-	if len(embeddingResp.Data) != len(texts) {
-		return nil, fmt.Errorf("expected %d embeddings, got %d", len(texts), len(embeddingResp.Data))
-	}
+// recordUsage records the tokens billed for an API request and their cost.
+// Responses without usage are counted by estimate.
+func (s *EmbeddingService) recordUsage(ctx context.Context, texts []string, billedTokens int) {
+	metrics.EmbeddingBatchInputs.WithLabelValues(s.model).Observe(float64(len(texts)))
 
-	result := make([][]float32, len(embeddingResp.Data))
-	for i, data := range embeddingResp.Data {
-		result[i] = data.Embedding
+	tokens := billedTokens
+	if tokens <= 0 {
+		for _, text := range texts {
+			tokens += estimateEmbeddingTokens(text)
+		}
 	}
 
-	return result, nil
+	cost := s.costOf(tokens)
+	metrics.EmbeddingTokensTotal.WithLabelValues(s.model).Add(float64(tokens))
+	metrics.EmbeddingCostUSDTotal.WithLabelValues(s.model).Add(cost)
+	s.budget.Record(ctx, cost)
 }
 
-// executeAPIRequest performs the common HTTP request logic to OpenAI
-func (s *EmbeddingService) executeAPIRequest(ctx context.Context, reqBody EmbeddingRequest) (*EmbeddingResponse, error) {
-	jsonData, err := json.Marshal(reqBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
-	}
+// costOf returns the price of tokens with the service's model in US dollars
+func (s *EmbeddingService) costOf(tokens int) float64 {
+	return float64(tokens) * s.pricePerMillion / 1_000_000
+}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", s.apiBaseURL, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+// estimateEmbeddingTokens estimates how many tokens a text is without a
+// tokenizer: about four ASCII characters per token, and a token for every
+// other character. It errs high for non-Latin scripts.
+func estimateEmbeddingTokens(text string) int {
+	ascii, other := 0, 0
+	for _, r := range text {
+		if r < utf8.RuneSelf {
+			ascii++
+		} else {
+			other++
+		}
 	}
-
-	req.Header.Set("Content-Type", "application/json")
-	if s.apiKey != "" {
-		req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	tokens := (ascii+3)/4 + other
+	if tokens < 1 {
+		tokens = 1
 	}
+	return tokens
+}
 
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("API request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+// truncateEmbeddingInput shortens a text estimated over the API's per-text
+// token limit, keeping its start
+func truncateEmbeddingInput(text string) string {
+	if estimateEmbeddingTokens(text) <= MaxEmbeddingInputTokens {
+		return text
 	}
-
-	var embeddingResp EmbeddingResponse
-	if err := json.NewDecoder(resp.Body).Decode(&embeddingResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	budget := MaxEmbeddingInputTokens * 4
+	for i, r := range text {
+		if r < utf8.RuneSelf {
+			budget--
+		} else {
+			budget -= 4
+		}
+		if budget < 0 {
+			return text[:i]
+		}
 	}
-
-	return &embeddingResp, nil
+	return text
 }
 
-// doAPICall is deprecated - kept for backward compatibility
-// Use callEmbeddingAPI instead
-func (s *EmbeddingService) doAPICall(ctx context.Context, reqBody EmbeddingRequest) ([]float32, error) {
-	embeddingResp, err := s.executeAPIRequest(ctx, reqBody)
-	if err != nil {
-		return nil, err
+// planEmbeddingBatches splits texts with the given token counts into
+// consecutive [start, end) batches of at most maxInputs texts and maxTokens
+// tokens. A text over maxTokens gets a batch of its own.
+func planEmbeddingBatches(tokens []int, maxInputs, maxTokens int) [][2]int {
+	var batches [][2]int
+	start, batchTokens := 0, 0
+	for i, t := range tokens {
+		if i > start && (i-start >= maxInputs || batchTokens+t > maxTokens) {
+			batches = append(batches, [2]int{start, i})
+			start, batchTokens = i, 0
+		}
+		batchTokens += t
 	}
-
-	if len(embeddingResp.Data) == 0 {
-		return nil, fmt.Errorf("no embeddings returned")
+	if start < len(tokens) {
+		batches = append(batches, [2]int{start, len(tokens)})
 	}
-
-	return embeddingResp.Data[0].Embedding, nil
+	return batches
 }
 
 // getCacheKey generates a cache key for a text
 func (s *EmbeddingService) getCacheKey(text string) string {
-	hash := sha256.Sum256([]byte(s.model + ":" + text))
+	model := s.model
+	if s.dimensions != 0 && s.dimensions != EmbeddingDimensions {
+		// The same model can produce vectors of several sizes
+		model = fmt.Sprintf("%s@%d", model, s.dimensions)
+	}
+	hash := sha256.Sum256([]byte(model + ":" + text))
 	return "embedding:" + hex.EncodeToString(hash[:])
 }
 
+// cacheKey generates the cache key for a text of an input type. Only
+// providers embedding queries and documents differently key on the type.
+func (s *EmbeddingService) cacheKey(text string, inputType EmbeddingInputType) string {
+	if p, ok := s.provider.(inputTypeAwareProvider); ok && p.EmbedsInputTypesDifferently() {
+		return s.getCacheKey(string(inputType) + ":" + text)
+	}
+	return s.getCacheKey(text)
+}
+
+// embeddingInputTypeOf maps an embedding type to the provider input type:
+// clips are documents, everything else is a query
+func embeddingInputTypeOf(embeddingType string) EmbeddingInputType {
+	if embeddingType == "clip" {
+		return EmbeddingInputDocument
+	}
+	return EmbeddingInputQuery
+}
+
 // getFromCache retrieves an embedding from Redis cache
 func (s *EmbeddingService) getFromCache(ctx context.Context, key string) ([]float32, error) {
 	if s.redisClient == nil {
@@ -449,6 +665,16 @@ func (s *EmbeddingService) GetModel() string {
 	return s.model
 }
 
+// GetDimensions returns the size of the vectors the model is asked for
+func (s *EmbeddingService) GetDimensions() int {
+	return s.dimensions
+}
+
+// GetProvider returns the name of the embedding provider being used
+func (s *EmbeddingService) GetProvider() string {
+	return s.provider.Name()
+}
+
 // Metrics helper functions - wrappers for recording metrics
 
 func recordEmbeddingCacheHit() {
@@ -464,6 +690,11 @@ func recordEmbeddingGeneration(embeddingType string, durationMs float64) {
 	metrics.EmbeddingGenerationDuration.WithLabelValues(embeddingType).Observe(durationMs)
 }
 
+func recordEmbeddingBatchGeneration(embeddingType string, count int, durationMs float64) {
+	metrics.EmbeddingGenerationTotal.WithLabelValues(embeddingType).Add(float64(count))
+	metrics.EmbeddingGenerationDuration.WithLabelValues(embeddingType).Observe(durationMs)
+}
+
 func recordEmbeddingGenerationError(embeddingType string) {
 	metrics.EmbeddingGenerationErrors.WithLabelValues(embeddingType).Inc()
 }
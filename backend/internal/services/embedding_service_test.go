@@ -2,11 +2,17 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/subculture-collective/clipper/internal/models"
 )
 
@@ -162,3 +168,173 @@ func TestRecordEmbeddingMetrics(t *testing.T) {
 
 // Note: Testing actual API calls would require mocking the HTTP client
 // or using integration tests with a real API key
+
+func TestEstimateEmbeddingTokens(t *testing.T) {
+	assert.Equal(t, 1, estimateEmbeddingTokens(""))
+	assert.Equal(t, 1, estimateEmbeddingTokens("abc"))
+	assert.Equal(t, 3, estimateEmbeddingTokens("Great play!"))
+	// Non-ASCII characters count a token each
+	assert.Equal(t, 5, estimateEmbeddingTokens("すごいプレ"))
+}
+
+func TestTruncateEmbeddingInput(t *testing.T) {
+	short := "Amazing pentakill!"
+	assert.Equal(t, short, truncateEmbeddingInput(short))
+
+	long := strings.Repeat("a", MaxEmbeddingInputTokens*4+100)
+	truncated := truncateEmbeddingInput(long)
+	assert.LessOrEqual(t, estimateEmbeddingTokens(truncated), MaxEmbeddingInputTokens)
+
+	wide := strings.Repeat("é", MaxEmbeddingInputTokens+10)
+	truncated = truncateEmbeddingInput(wide)
+	assert.LessOrEqual(t, estimateEmbeddingTokens(truncated), MaxEmbeddingInputTokens)
+	assert.True(t, utf8.ValidString(truncated))
+}
+
+func TestPlanEmbeddingBatches(t *testing.T) {
+	tests := []struct {
+		name      string
+		tokens    []int
+		maxInputs int
+		maxTokens int
+		want      [][2]int
+	}{
+		{"empty", nil, 2, 100, nil},
+		{"single batch", []int{10, 10, 10}, 5, 100, [][2]int{{0, 3}}},
+		{"input limit", []int{1, 1, 1, 1, 1}, 2, 100, [][2]int{{0, 2}, {2, 4}, {4, 5}}},
+		{"token limit", []int{40, 40, 40, 10}, 10, 100, [][2]int{{0, 2}, {2, 4}}},
+		{"oversized text", []int{10, 500, 10}, 10, 100, [][2]int{{0, 1}, {1, 2}, {2, 3}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, planEmbeddingBatches(tt.tokens, tt.maxInputs, tt.maxTokens))
+		})
+	}
+}
+
+func newEmbeddingTestServer(t *testing.T, requests *[]EmbeddingRequest) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Input []string `json:"input"`
+			Model string   `json:"model"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		*requests = append(*requests, EmbeddingRequest{Input: req.Input, Model: req.Model})
+
+		data := make([]map[string]interface{}, len(req.Input))
+		for i, input := range req.Input {
+			data[i] = map[string]interface{}{"embedding": []float32{float32(len(input))}, "index": i}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data":  data,
+			"usage": map[string]int{"total_tokens": 1_000_000},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGenerateBatchEmbeddings_BatchesRequests(t *testing.T) {
+	var requests []EmbeddingRequest
+	server := newEmbeddingTestServer(t, &requests)
+	budget := NewEmbeddingBudget(nil, 0, 0)
+
+	service := NewEmbeddingService(&EmbeddingConfig{
+		APIBaseURL:        server.URL,
+		RequestsPerMinute: 60000,
+		MaxBatchInputs:    2,
+		Budget:            budget,
+	})
+	defer service.Close()
+
+	result, err := service.GenerateBatchEmbeddings(context.Background(), []string{"a", "bb", "ccc"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]float32{{1}, {2}, {3}}, result)
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, []string{"a", "bb"}, requests[0].Input)
+	assert.Equal(t, []string{"ccc"}, requests[1].Input)
+
+	// Each request is billed a million tokens of text-embedding-3-small
+	spent, err := budget.Spent(context.Background())
+	require.NoError(t, err)
+	assert.InDelta(t, 0.04, spent, 1e-9)
+}
+
+func TestGenerateBatchEmbeddings_BudgetExceeded(t *testing.T) {
+	var requests []EmbeddingRequest
+	server := newEmbeddingTestServer(t, &requests)
+	budget := NewEmbeddingBudget(nil, 0.01, 0)
+	budget.Record(context.Background(), 0.01)
+
+	service := NewEmbeddingService(&EmbeddingConfig{
+		APIBaseURL:        server.URL,
+		RequestsPerMinute: 60000,
+		Budget:            budget,
+	})
+	defer service.Close()
+
+	_, err := service.GenerateBatchEmbeddings(context.Background(), []string{"a", "b"})
+	assert.ErrorIs(t, err, ErrEmbeddingBudgetExceeded)
+
+	_, err = service.GenerateEmbedding(context.Background(), "c")
+	assert.ErrorIs(t, err, ErrEmbeddingBudgetExceeded)
+
+	assert.Empty(t, requests)
+}
+
+func TestGenerateBatchEmbeddings_PartialFailure(t *testing.T) {
+	var requests [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req teiEmbedRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		requests = append(requests, req.Inputs)
+		for _, input := range req.Inputs {
+			if input == "bad" {
+				http.Error(w, "input rejected", http.StatusUnprocessableEntity)
+				return
+			}
+		}
+		embeddings := make([][]float32, len(req.Inputs))
+		for i, input := range req.Inputs {
+			embeddings[i] = []float32{float32(len(input))}
+		}
+		_ = json.NewEncoder(w).Encode(embeddings)
+	}))
+	t.Cleanup(server.Close)
+
+	service := NewEmbeddingService(&EmbeddingConfig{
+		Provider:          EmbeddingProviderTEI,
+		APIBaseURL:        server.URL,
+		RequestsPerMinute: 60000,
+		MaxBatchInputs:    4,
+	})
+	defer service.Close()
+
+	result, err := service.GenerateBatchEmbeddings(context.Background(), []string{"a", "bad", "ccc", "dddd", "eeeee"})
+	var batchErr *EmbeddingBatchError
+	require.ErrorAs(t, err, &batchErr)
+	assert.Equal(t, []int{1}, batchErr.Failed)
+	assert.Equal(t, [][]float32{{1}, nil, {3}, {4}, {5}}, result)
+
+	// The rejected batch is split until the bad text is alone, without retries
+	assert.Equal(t, [][]string{
+		{"a", "bad", "ccc", "dddd"},
+		{"a", "bad"},
+		{"a"},
+		{"bad"},
+		{"ccc", "dddd"},
+		{"eeeee"},
+	}, requests)
+}
+
+func TestEmbeddingAPIError_RejectsInput(t *testing.T) {
+	assert.True(t, (&EmbeddingAPIError{StatusCode: http.StatusBadRequest}).rejectsInput())
+	assert.True(t, (&EmbeddingAPIError{StatusCode: http.StatusRequestEntityTooLarge}).rejectsInput())
+	assert.False(t, (&EmbeddingAPIError{StatusCode: http.StatusTooManyRequests}).rejectsInput())
+	assert.False(t, (&EmbeddingAPIError{StatusCode: http.StatusUnauthorized}).rejectsInput())
+	assert.False(t, (&EmbeddingAPIError{StatusCode: http.StatusBadGateway}).rejectsInput())
+}
@@ -23,11 +23,20 @@ const (
 	BenchmarkShares = 50
 )
 
+// emailAttributionWindowHours is how long after an email click on-site activity is credited to the email
+const emailAttributionWindowHours = 24
+
+// EmailClickAttributionSource provides first-party email clicks joined to later activity
+type EmailClickAttributionSource interface {
+	GetClickAttribution(ctx context.Context, since time.Time, windowHours int) (*models.EmailClickAttribution, error)
+}
+
 // EngagementService handles engagement metrics calculations
 type EngagementService struct {
 	analyticsRepo *repository.AnalyticsRepository
 	userRepo      *repository.UserRepository
 	clipRepo      *repository.ClipRepository
+	emailClicks   EmailClickAttributionSource
 }
 
 // NewEngagementService creates a new engagement service
@@ -43,6 +52,11 @@ func NewEngagementService(
 	}
 }
 
+// SetEmailClickSource enables email click attribution in engagement analytics
+func (s *EngagementService) SetEmailClickSource(source EmailClickAttributionSource) {
+	s.emailClicks = source
+}
+
 // GetUserEngagementScore calculates and returns a user's engagement score
 func (s *EngagementService) GetUserEngagementScore(ctx context.Context, userID uuid.UUID) (*models.UserEngagementScore, error) {
 	now := time.Now()
@@ -263,6 +277,35 @@ func (s *EngagementService) GetTrendingMetrics(ctx context.Context, metric strin
 	}, nil
 }
 
+// GetEmailClickAttribution returns email clicks per template and how many clickers
+// went on to engage with the site within the attribution window
+func (s *EngagementService) GetEmailClickAttribution(ctx context.Context, days int) (*models.EmailClickAttribution, error) {
+	if days <= 0 || days > 365 {
+		days = 30
+	}
+
+	attribution := &models.EmailClickAttribution{
+		PeriodDays:       days,
+		AttributionHours: emailAttributionWindowHours,
+		Templates:        []models.EmailTemplateAttribution{},
+	}
+	if s.emailClicks == nil {
+		return attribution, nil
+	}
+
+	since := time.Now().AddDate(0, 0, -days)
+	result, err := s.emailClicks.GetClickAttribution(ctx, since, emailAttributionWindowHours)
+	if err != nil {
+		return nil, err
+	}
+
+	result.PeriodDays = days
+	for i := range result.Templates {
+		result.Templates[i].EngagementRate = engagementRate(result.Templates[i].EngagedUsers, result.Templates[i].UniqueClickers)
+	}
+	return result, nil
+}
+
 // GetContentEngagementScore calculates engagement score for a clip
 func (s *EngagementService) GetContentEngagementScore(ctx context.Context, clipID uuid.UUID) (*models.ContentEngagementScore, error) {
 	clip, err := s.clipRepo.GetByID(ctx, clipID)
@@ -495,6 +538,14 @@ func calculateTrendSummary(points []models.TrendingDataPoint) models.TrendSummar
 	}
 }
 
+// engagementRate returns engaged users as a percentage of unique clickers
+func engagementRate(engaged, clickers int) float64 {
+	if clickers == 0 {
+		return 0
+	}
+	return float64(engaged) / float64(clickers) * 100
+}
+
 func normalizeMetric(value, max int64) int {
 	if max == 0 {
 		return 0
@@ -123,6 +123,9 @@ func (s *ExportService) ProcessExportRequest(ctx context.Context, req *models.Ex
 
 	// Get clips for export
 	clips, err := s.exportRepo.GetCreatorClipsForExport(ctx, req.CreatorName)
+	if err != nil && ctx.Err() != nil {
+		return s.requeueExportRequest(ctx, req)
+	}
 	if err != nil {
 		errMsg := fmt.Sprintf("Failed to retrieve clips: %v", err)
 		s.exportRepo.UpdateExportStatus(ctx, req.ID, models.ExportStatusFailed, &errMsg)
@@ -156,6 +159,12 @@ func (s *ExportService) ProcessExportRequest(ctx context.Context, req *models.Ex
 		return fmt.Errorf("failed to generate export file: %w", err)
 	}
 
+	// Shutting down: leave the request for the next run rather than completing
+	// it with a context that can no longer reach the database
+	if ctx.Err() != nil {
+		return s.requeueExportRequest(ctx, req)
+	}
+
 	// Set expiration time based on configured retention period
 	expiresAt := time.Now().Add(time.Duration(s.retentionDays) * 24 * time.Hour)
 
@@ -177,6 +186,20 @@ func (s *ExportService) ProcessExportRequest(ctx context.Context, req *models.Ex
 	return nil
 }
 
+// requeueExportRequest puts an export request interrupted by shutdown back to
+// pending so the next run picks it up instead of leaving it stuck in processing
+func (s *ExportService) requeueExportRequest(ctx context.Context, req *models.ExportRequest) error {
+	checkpointCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+
+	if err := s.exportRepo.UpdateExportStatus(checkpointCtx, req.ID, models.ExportStatusPending, nil); err != nil {
+		utils.GetLogger().Error("Failed to requeue interrupted export request", err, map[string]interface{}{
+			"export_id": req.ID.String(),
+		})
+	}
+	return fmt.Errorf("export interrupted: %w", ctx.Err())
+}
+
 // generateCSVExport generates a CSV export file
 func (s *ExportService) generateCSVExport(exportID uuid.UUID, clips []*models.Clip) (string, int64, error) {
 	filename := fmt.Sprintf("export_%s.csv", exportID.String())
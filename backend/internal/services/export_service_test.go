@@ -495,6 +495,28 @@ func TestExportService_FailedExportHandling(t *testing.T) {
 	assert.NotNil(t, updated.ErrorMessage)
 }
 
+func TestExportService_ProcessExportRequest_Interrupted(t *testing.T) {
+	tmpDir := t.TempDir()
+	mockRepo := newMockExportRepository()
+	service := NewExportService(mockRepo, nil, nil, nil, tmpDir, "http://localhost:8080", 7)
+
+	mockRepo.addTestClips("testcreator", 3)
+	req, err := service.CreateExportRequest(context.Background(), uuid.New(), "testcreator", models.ExportFormatCSV)
+	require.NoError(t, err)
+
+	// Process with a context cancelled by shutdown
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = service.ProcessExportRequest(ctx, req)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// Verify the request was put back for the next run
+	updated, err := mockRepo.GetExportRequestByID(context.Background(), req.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ExportStatusPending, updated.Status)
+	assert.Nil(t, updated.ErrorMessage)
+}
+
 func TestFormatFileSize(t *testing.T) {
 	tests := []struct {
 		name     string
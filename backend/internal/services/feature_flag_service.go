@@ -0,0 +1,361 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const (
+	// featureFlagsCacheKey holds every flag, so one cache read serves any evaluation
+	featureFlagsCacheKey = "feature_flags:all"
+	featureFlagsCacheTTL = time.Minute
+	// featureFlagsLocalTTL bounds how long an instance serves flags from memory.
+	// Writes clear Redis, so other instances pick up a change within this window.
+	featureFlagsLocalTTL = 5 * time.Second
+
+	featureFlagMaxRules      = 50
+	featureFlagMaxRuleValues = 1000
+)
+
+var (
+	// ErrFeatureFlagInvalidKey is returned for a key that isn't lowercase letters, digits, '.', '_' or '-'
+	ErrFeatureFlagInvalidKey = errors.New("feature flag key must be lowercase letters, digits, '.', '_' or '-'")
+	// ErrFeatureFlagInvalidRule is returned for a malformed targeting rule
+	ErrFeatureFlagInvalidRule = errors.New("invalid feature flag rule")
+)
+
+var featureFlagKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]*$`)
+
+// FeatureFlagRepositoryInterface defines the repository methods used by FeatureFlagService
+type FeatureFlagRepositoryInterface interface {
+	List(ctx context.Context) ([]models.FeatureFlag, error)
+	GetByKey(ctx context.Context, key string) (*models.FeatureFlag, error)
+	Create(ctx context.Context, flag *models.FeatureFlag) error
+	Update(ctx context.Context, flag *models.FeatureFlag) error
+	Delete(ctx context.Context, key string) error
+}
+
+// FeatureFlagUserSource looks up users to evaluate flags for them
+type FeatureFlagUserSource interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+}
+
+// FeatureFlagService manages feature flags and evaluates them for users.
+// Flags are read through an in-memory snapshot backed by Redis, so checking
+// a flag on a hot path doesn't reach Postgres.
+type FeatureFlagService struct {
+	repo  FeatureFlagRepositoryInterface
+	users FeatureFlagUserSource
+	cache RedisCache
+	now   func() time.Time
+
+	mu       sync.RWMutex
+	snapshot map[string]models.FeatureFlag
+	loadedAt time.Time
+}
+
+// NewFeatureFlagService creates a new FeatureFlagService
+func NewFeatureFlagService(repo FeatureFlagRepositoryInterface, users FeatureFlagUserSource, cache RedisCache) *FeatureFlagService {
+	return &FeatureFlagService{
+		repo:  repo,
+		users: users,
+		cache: cache,
+		now:   time.Now,
+	}
+}
+
+// ListFlags returns every flag
+func (s *FeatureFlagService) ListFlags(ctx context.Context) ([]models.FeatureFlag, error) {
+	return s.repo.List(ctx)
+}
+
+// GetFlag returns a flag by key
+func (s *FeatureFlagService) GetFlag(ctx context.Context, key string) (*models.FeatureFlag, error) {
+	return s.repo.GetByKey(ctx, key)
+}
+
+// CreateFlag creates a flag
+func (s *FeatureFlagService) CreateFlag(ctx context.Context, adminID uuid.UUID, req *models.CreateFeatureFlagRequest) (*models.FeatureFlag, error) {
+	if !featureFlagKeyPattern.MatchString(req.Key) {
+		return nil, ErrFeatureFlagInvalidKey
+	}
+	if err := validateFeatureFlagRules(req.Rules); err != nil {
+		return nil, err
+	}
+
+	flag := &models.FeatureFlag{
+		Key:         req.Key,
+		Description: req.Description,
+		Enabled:     req.Enabled,
+		Rules:       req.Rules,
+		CreatedBy:   &adminID,
+	}
+	if req.RolloutPercentage != nil {
+		flag.RolloutPercentage = *req.RolloutPercentage
+	}
+	if flag.Rules == nil {
+		flag.Rules = []models.FeatureFlagRule{}
+	}
+
+	if err := s.repo.Create(ctx, flag); err != nil {
+		return nil, err
+	}
+	s.invalidate(ctx)
+	return flag, nil
+}
+
+// UpdateFlag changes a flag's description, switch, rollout or rules
+func (s *FeatureFlagService) UpdateFlag(ctx context.Context, key string, adminID uuid.UUID, req *models.UpdateFeatureFlagRequest) (*models.FeatureFlag, error) {
+	flag, err := s.repo.GetByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Description != nil {
+		flag.Description = req.Description
+	}
+	if req.Enabled != nil {
+		flag.Enabled = *req.Enabled
+	}
+	if req.RolloutPercentage != nil {
+		flag.RolloutPercentage = *req.RolloutPercentage
+	}
+	if req.Rules != nil {
+		if err := validateFeatureFlagRules(*req.Rules); err != nil {
+			return nil, err
+		}
+		flag.Rules = *req.Rules
+	}
+	flag.UpdatedBy = &adminID
+
+	if err := s.repo.Update(ctx, flag); err != nil {
+		return nil, err
+	}
+	s.invalidate(ctx)
+	return flag, nil
+}
+
+// DeleteFlag removes a flag. Code still checking it gets its fallback.
+func (s *FeatureFlagService) DeleteFlag(ctx context.Context, key string) error {
+	if err := s.repo.Delete(ctx, key); err != nil {
+		return err
+	}
+	s.invalidate(ctx)
+	return nil
+}
+
+func validateFeatureFlagRules(rules []models.FeatureFlagRule) error {
+	if len(rules) > featureFlagMaxRules {
+		return fmt.Errorf("%w: at most %d rules are allowed", ErrFeatureFlagInvalidRule, featureFlagMaxRules)
+	}
+	for i, rule := range rules {
+		switch rule.Attribute {
+		case models.FeatureFlagAttributeUserID, models.FeatureFlagAttributeRole, models.FeatureFlagAttributeAccountType:
+		default:
+			return fmt.Errorf("%w: rule %d has unknown attribute %q", ErrFeatureFlagInvalidRule, i, rule.Attribute)
+		}
+		if rule.Operator != models.FeatureFlagOperatorIn && rule.Operator != models.FeatureFlagOperatorNotIn {
+			return fmt.Errorf("%w: rule %d has unknown operator %q", ErrFeatureFlagInvalidRule, i, rule.Operator)
+		}
+		if len(rule.Values) == 0 || len(rule.Values) > featureFlagMaxRuleValues {
+			return fmt.Errorf("%w: rule %d must have between 1 and %d values", ErrFeatureFlagInvalidRule, i, featureFlagMaxRuleValues)
+		}
+		if rule.Attribute == models.FeatureFlagAttributeUserID {
+			for _, v := range rule.Values {
+				if _, err := uuid.Parse(v); err != nil {
+					return fmt.Errorf("%w: rule %d has invalid user ID %q", ErrFeatureFlagInvalidRule, i, v)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Evaluate evaluates a flag for a subject, which is nil for an anonymous visitor
+func (s *FeatureFlagService) Evaluate(ctx context.Context, key string, subject *models.FeatureFlagSubject) (*models.FeatureFlagEvaluation, error) {
+	flags, err := s.loadFlags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	flag, ok := flags[key]
+	if !ok {
+		return &models.FeatureFlagEvaluation{Key: key, Reason: "not_found"}, nil
+	}
+	return evaluateFeatureFlag(&flag, subject), nil
+}
+
+// EvaluateForUser evaluates a flag as it would be for a user, or for an
+// anonymous visitor when userID is nil
+func (s *FeatureFlagService) EvaluateForUser(ctx context.Context, key string, userID *uuid.UUID) (*models.FeatureFlagEvaluation, error) {
+	var subject *models.FeatureFlagSubject
+	if userID != nil {
+		user, err := s.users.GetByID(ctx, *userID)
+		if err != nil {
+			return nil, err
+		}
+		subject = models.NewFeatureFlagSubject(user)
+	}
+	return s.Evaluate(ctx, key, subject)
+}
+
+// EvaluateAll returns whether each flag is on for a subject
+func (s *FeatureFlagService) EvaluateAll(ctx context.Context, subject *models.FeatureFlagSubject) (map[string]bool, error) {
+	flags, err := s.loadFlags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]bool, len(flags))
+	for key, flag := range flags {
+		result[key] = evaluateFeatureFlag(&flag, subject).Enabled
+	}
+	return result, nil
+}
+
+// Enabled reports whether a flag is on for a subject. It returns fallback
+// when the flag doesn't exist or flags can't be loaded, so callers keep a
+// safe default when the flag store is unavailable.
+func (s *FeatureFlagService) Enabled(ctx context.Context, key string, subject *models.FeatureFlagSubject, fallback bool) bool {
+	evaluation, err := s.Evaluate(ctx, key, subject)
+	if err != nil {
+		utils.Warn("Failed to evaluate feature flag, using fallback", map[string]interface{}{
+			"flag":     key,
+			"fallback": fallback,
+			"error":    err.Error(),
+		})
+		return fallback
+	}
+	if evaluation.Reason == "not_found" {
+		return fallback
+	}
+	return evaluation.Enabled
+}
+
+// evaluateFeatureFlag applies a flag's switch, rules and rollout to a subject
+func evaluateFeatureFlag(flag *models.FeatureFlag, subject *models.FeatureFlagSubject) *models.FeatureFlagEvaluation {
+	evaluation := &models.FeatureFlagEvaluation{Key: flag.Key}
+	if !flag.Enabled {
+		evaluation.Reason = "disabled"
+		return evaluation
+	}
+
+	for i, rule := range flag.Rules {
+		if featureFlagRuleMatches(rule, subject) {
+			index := i
+			evaluation.Enabled = rule.Serve
+			evaluation.Reason = "rule"
+			evaluation.Rule = &index
+			return evaluation
+		}
+	}
+
+	evaluation.Reason = "rollout"
+	switch {
+	case flag.RolloutPercentage >= 100:
+		evaluation.Enabled = true
+	case flag.RolloutPercentage <= 0 || subject == nil || subject.UserID == nil:
+		// Visitors can't be bucketed stably, so they only see full rollouts
+		evaluation.Enabled = false
+	default:
+		evaluation.Enabled = featureFlagBucket(flag.Key, *subject.UserID) < flag.RolloutPercentage
+	}
+	return evaluation
+}
+
+func featureFlagRuleMatches(rule models.FeatureFlagRule, subject *models.FeatureFlagSubject) bool {
+	var value string
+	if subject != nil {
+		switch rule.Attribute {
+		case models.FeatureFlagAttributeUserID:
+			if subject.UserID != nil {
+				value = subject.UserID.String()
+			}
+		case models.FeatureFlagAttributeRole:
+			value = subject.Role
+		case models.FeatureFlagAttributeAccountType:
+			value = subject.AccountType
+		}
+	}
+
+	found := false
+	if value != "" {
+		for _, v := range rule.Values {
+			if v == value {
+				found = true
+				break
+			}
+		}
+	}
+
+	if rule.Operator == models.FeatureFlagOperatorNotIn {
+		return !found
+	}
+	return found
+}
+
+// featureFlagBucket places a user in [0, 100) for a flag. Hashing the key
+// with the user ID keeps a user's bucket stable as the rollout grows, and
+// independent between flags.
+func featureFlagBucket(key string, userID uuid.UUID) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	_, _ = h.Write([]byte{':'})
+	_, _ = h.Write(userID[:])
+	return int(h.Sum32() % 100)
+}
+
+// loadFlags returns every flag by key from memory, Redis or Postgres
+func (s *FeatureFlagService) loadFlags(ctx context.Context) (map[string]models.FeatureFlag, error) {
+	s.mu.RLock()
+	if s.snapshot != nil && s.now().Sub(s.loadedAt) < featureFlagsLocalTTL {
+		snapshot := s.snapshot
+		s.mu.RUnlock()
+		return snapshot, nil
+	}
+	s.mu.RUnlock()
+
+	var flags []models.FeatureFlag
+	if s.cache == nil || s.cache.GetJSON(ctx, featureFlagsCacheKey, &flags) != nil {
+		var err error
+		flags, err = s.repo.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if s.cache != nil {
+			_ = s.cache.SetJSON(ctx, featureFlagsCacheKey, flags, featureFlagsCacheTTL)
+		}
+	}
+
+	snapshot := make(map[string]models.FeatureFlag, len(flags))
+	for _, flag := range flags {
+		snapshot[flag.Key] = flag
+	}
+
+	s.mu.Lock()
+	s.snapshot = snapshot
+	s.loadedAt = s.now()
+	s.mu.Unlock()
+	return snapshot, nil
+}
+
+// invalidate drops cached flags after a write
+func (s *FeatureFlagService) invalidate(ctx context.Context) {
+	if s.cache != nil {
+		if err := s.cache.Delete(ctx, featureFlagsCacheKey); err != nil {
+			utils.Warn("Failed to clear feature flag cache", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}
+	s.mu.Lock()
+	s.snapshot = nil
+	s.mu.Unlock()
+}
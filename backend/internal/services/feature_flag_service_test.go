@@ -0,0 +1,273 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+// MockFeatureFlagRepository is a mock implementation of FeatureFlagRepositoryInterface
+type MockFeatureFlagRepository struct {
+	mock.Mock
+}
+
+func (m *MockFeatureFlagRepository) List(ctx context.Context) ([]models.FeatureFlag, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.FeatureFlag), args.Error(1)
+}
+
+func (m *MockFeatureFlagRepository) GetByKey(ctx context.Context, key string) (*models.FeatureFlag, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.FeatureFlag), args.Error(1)
+}
+
+func (m *MockFeatureFlagRepository) Create(ctx context.Context, flag *models.FeatureFlag) error {
+	args := m.Called(ctx, flag)
+	return args.Error(0)
+}
+
+func (m *MockFeatureFlagRepository) Update(ctx context.Context, flag *models.FeatureFlag) error {
+	args := m.Called(ctx, flag)
+	return args.Error(0)
+}
+
+func (m *MockFeatureFlagRepository) Delete(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func featureFlagSubject(role, accountType string) *models.FeatureFlagSubject {
+	id := uuid.New()
+	return &models.FeatureFlagSubject{UserID: &id, Role: role, AccountType: accountType}
+}
+
+func TestEvaluateFeatureFlag_DisabledIsOffForEveryone(t *testing.T) {
+	flag := &models.FeatureFlag{Key: "feed.v2", Enabled: false, RolloutPercentage: 100}
+
+	evaluation := evaluateFeatureFlag(flag, featureFlagSubject(models.RoleUser, "free"))
+
+	assert.False(t, evaluation.Enabled)
+	assert.Equal(t, "disabled", evaluation.Reason)
+}
+
+func TestEvaluateFeatureFlag_FirstMatchingRuleWins(t *testing.T) {
+	flag := &models.FeatureFlag{
+		Key:     "feed.v2",
+		Enabled: true,
+		Rules: []models.FeatureFlagRule{
+			{Attribute: models.FeatureFlagAttributeAccountType, Operator: models.FeatureFlagOperatorIn, Values: []string{"pro"}, Serve: false},
+			{Attribute: models.FeatureFlagAttributeRole, Operator: models.FeatureFlagOperatorIn, Values: []string{models.RoleAdmin}, Serve: true},
+		},
+	}
+
+	evaluation := evaluateFeatureFlag(flag, featureFlagSubject(models.RoleAdmin, "free"))
+	assert.True(t, evaluation.Enabled)
+	assert.Equal(t, "rule", evaluation.Reason)
+	require.NotNil(t, evaluation.Rule)
+	assert.Equal(t, 1, *evaluation.Rule)
+
+	evaluation = evaluateFeatureFlag(flag, featureFlagSubject(models.RoleAdmin, "pro"))
+	assert.False(t, evaluation.Enabled)
+	assert.Equal(t, 0, *evaluation.Rule)
+}
+
+func TestEvaluateFeatureFlag_NotInMatchesVisitors(t *testing.T) {
+	flag := &models.FeatureFlag{
+		Key:     "feed.v2",
+		Enabled: true,
+		Rules: []models.FeatureFlagRule{
+			{Attribute: models.FeatureFlagAttributeRole, Operator: models.FeatureFlagOperatorNotIn, Values: []string{models.RoleAdmin}, Serve: false},
+		},
+		RolloutPercentage: 100,
+	}
+
+	assert.False(t, evaluateFeatureFlag(flag, nil).Enabled)
+	assert.True(t, evaluateFeatureFlag(flag, featureFlagSubject(models.RoleAdmin, "free")).Enabled)
+}
+
+func TestEvaluateFeatureFlag_RolloutIsStableAndProportional(t *testing.T) {
+	flag := &models.FeatureFlag{Key: "search.hybrid", Enabled: true, RolloutPercentage: 30}
+
+	enabled := 0
+	for i := 0; i < 2000; i++ {
+		subject := featureFlagSubject(models.RoleUser, "free")
+		first := evaluateFeatureFlag(flag, subject)
+		assert.Equal(t, "rollout", first.Reason)
+		assert.Equal(t, first.Enabled, evaluateFeatureFlag(flag, subject).Enabled)
+		if first.Enabled {
+			enabled++
+		}
+	}
+	assert.InDelta(t, 600, enabled, 120)
+
+	// Growing the rollout never turns the flag off for a user who had it
+	subject := featureFlagSubject(models.RoleUser, "free")
+	wasEnabled := false
+	for pct := 0; pct <= 100; pct += 10 {
+		flag.RolloutPercentage = pct
+		isEnabled := evaluateFeatureFlag(flag, subject).Enabled
+		if wasEnabled {
+			assert.True(t, isEnabled)
+		}
+		wasEnabled = isEnabled
+	}
+	assert.True(t, wasEnabled)
+}
+
+func TestEvaluateFeatureFlag_VisitorsOnlySeeFullRollouts(t *testing.T) {
+	flag := &models.FeatureFlag{Key: "feed.v2", Enabled: true, RolloutPercentage: 99}
+	assert.False(t, evaluateFeatureFlag(flag, nil).Enabled)
+
+	flag.RolloutPercentage = 100
+	assert.True(t, evaluateFeatureFlag(flag, nil).Enabled)
+}
+
+func TestFeatureFlagService_EnabledFallsBack(t *testing.T) {
+	repo := new(MockFeatureFlagRepository)
+	cache := new(MockRedisClient)
+	service := NewFeatureFlagService(repo, nil, cache)
+	ctx := context.Background()
+
+	flags := []models.FeatureFlag{{Key: "feed.v2", Enabled: false}}
+	cache.On("GetJSON", ctx, featureFlagsCacheKey, mock.Anything).Return(errors.New("cache miss"))
+	cache.On("SetJSON", ctx, featureFlagsCacheKey, flags, featureFlagsCacheTTL).Return(nil)
+	repo.On("List", ctx).Return(flags, nil).Once()
+
+	assert.True(t, service.Enabled(ctx, "missing.flag", nil, true))
+	assert.False(t, service.Enabled(ctx, "missing.flag", nil, false))
+	assert.False(t, service.Enabled(ctx, "feed.v2", nil, true))
+
+	repo.On("List", ctx).Return(nil, errors.New("database down")).Once()
+	service = NewFeatureFlagService(repo, nil, nil)
+	assert.True(t, service.Enabled(ctx, "feed.v2", nil, true))
+
+	repo.AssertExpectations(t)
+}
+
+func TestFeatureFlagService_CachesAndInvalidatesOnWrite(t *testing.T) {
+	repo := new(MockFeatureFlagRepository)
+	cache := new(MockRedisClient)
+	service := NewFeatureFlagService(repo, nil, cache)
+	ctx := context.Background()
+	adminID := uuid.New()
+
+	disabled := []models.FeatureFlag{{Key: "feed.v2", Enabled: false}}
+	cache.On("GetJSON", ctx, featureFlagsCacheKey, mock.Anything).Return(errors.New("cache miss"))
+	cache.On("SetJSON", ctx, featureFlagsCacheKey, disabled, featureFlagsCacheTTL).Return(nil).Once()
+	repo.On("List", ctx).Return(disabled, nil).Once()
+
+	assert.False(t, service.Enabled(ctx, "feed.v2", nil, false))
+	assert.False(t, service.Enabled(ctx, "feed.v2", nil, false))
+	repo.AssertNumberOfCalls(t, "List", 1)
+
+	enabled := true
+	rollout := 100
+	repo.On("GetByKey", ctx, "feed.v2").Return(&models.FeatureFlag{Key: "feed.v2", Enabled: false}, nil).Once()
+	repo.On("Update", ctx, mock.MatchedBy(func(flag *models.FeatureFlag) bool {
+		return flag.Enabled && flag.RolloutPercentage == 100 && *flag.UpdatedBy == adminID
+	})).Return(nil).Once()
+	cache.On("Delete", ctx, featureFlagsCacheKey).Return(nil).Once()
+	_, err := service.UpdateFlag(ctx, "feed.v2", adminID, &models.UpdateFeatureFlagRequest{Enabled: &enabled, RolloutPercentage: &rollout})
+	require.NoError(t, err)
+
+	updated := []models.FeatureFlag{{Key: "feed.v2", Enabled: true, RolloutPercentage: 100}}
+	cache.On("SetJSON", ctx, featureFlagsCacheKey, updated, featureFlagsCacheTTL).Return(nil).Once()
+	repo.On("List", ctx).Return(updated, nil).Once()
+	assert.True(t, service.Enabled(ctx, "feed.v2", nil, false))
+
+	repo.AssertExpectations(t)
+	cache.AssertExpectations(t)
+}
+
+func TestFeatureFlagService_ReadsFromRedis(t *testing.T) {
+	repo := new(MockFeatureFlagRepository)
+	cache := new(MockRedisClient)
+	service := NewFeatureFlagService(repo, nil, cache)
+	ctx := context.Background()
+
+	cache.On("GetJSON", ctx, featureFlagsCacheKey, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(2).(*[]models.FeatureFlag) = []models.FeatureFlag{{Key: "feed.v2", Enabled: true, RolloutPercentage: 100}}
+		}).
+		Return(nil).Once()
+
+	assert.True(t, service.Enabled(ctx, "feed.v2", nil, false))
+
+	cache.AssertExpectations(t)
+	repo.AssertNotCalled(t, "List", mock.Anything)
+}
+
+func TestFeatureFlagService_LocalSnapshotExpires(t *testing.T) {
+	repo := new(MockFeatureFlagRepository)
+	service := NewFeatureFlagService(repo, nil, nil)
+	now := time.Now()
+	service.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	repo.On("List", ctx).Return([]models.FeatureFlag{{Key: "feed.v2", Enabled: true, RolloutPercentage: 100}}, nil).Once()
+	assert.True(t, service.Enabled(ctx, "feed.v2", nil, false))
+
+	repo.On("List", ctx).Return([]models.FeatureFlag{{Key: "feed.v2", Enabled: false}}, nil).Once()
+	assert.True(t, service.Enabled(ctx, "feed.v2", nil, false))
+
+	now = now.Add(featureFlagsLocalTTL)
+	assert.False(t, service.Enabled(ctx, "feed.v2", nil, false))
+
+	repo.AssertExpectations(t)
+}
+
+func TestFeatureFlagService_CreateFlagValidates(t *testing.T) {
+	repo := new(MockFeatureFlagRepository)
+	service := NewFeatureFlagService(repo, nil, nil)
+	ctx := context.Background()
+	adminID := uuid.New()
+
+	repo.On("Create", ctx, mock.MatchedBy(func(flag *models.FeatureFlag) bool { return flag.Key == "feed.v2" })).Return(nil).Once()
+	repo.On("Create", ctx, mock.Anything).Return(repository.ErrFeatureFlagKeyTaken).Once()
+
+	_, err := service.CreateFlag(ctx, adminID, &models.CreateFeatureFlagRequest{Key: "Feed V2"})
+	assert.ErrorIs(t, err, ErrFeatureFlagInvalidKey)
+
+	tests := []models.FeatureFlagRule{
+		{Attribute: "country", Operator: models.FeatureFlagOperatorIn, Values: []string{"us"}},
+		{Attribute: models.FeatureFlagAttributeRole, Operator: "matches", Values: []string{"admin"}},
+		{Attribute: models.FeatureFlagAttributeRole, Operator: models.FeatureFlagOperatorIn},
+		{Attribute: models.FeatureFlagAttributeUserID, Operator: models.FeatureFlagOperatorIn, Values: []string{"not-a-uuid"}},
+	}
+	for _, rule := range tests {
+		_, err := service.CreateFlag(ctx, adminID, &models.CreateFeatureFlagRequest{Key: "feed.v2", Rules: []models.FeatureFlagRule{rule}})
+		assert.ErrorIs(t, err, ErrFeatureFlagInvalidRule)
+	}
+
+	rollout := 25
+	flag, err := service.CreateFlag(ctx, adminID, &models.CreateFeatureFlagRequest{
+		Key:               "feed.v2",
+		Enabled:           true,
+		RolloutPercentage: &rollout,
+		Rules: []models.FeatureFlagRule{
+			{Attribute: models.FeatureFlagAttributeUserID, Operator: models.FeatureFlagOperatorIn, Values: []string{uuid.NewString()}, Serve: true},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 25, flag.RolloutPercentage)
+	assert.Equal(t, &adminID, flag.CreatedBy)
+
+	_, err = service.CreateFlag(ctx, adminID, &models.CreateFeatureFlagRequest{Key: "feed.v2"})
+	assert.ErrorIs(t, err, repository.ErrFeatureFlagKeyTaken)
+
+	repo.AssertExpectations(t)
+}
@@ -2,27 +2,98 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/pagination"
 	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/pkg/utils"
 )
 
+// smartFeedBatchSize caps how many due smart feeds one materialization run handles
+const smartFeedBatchSize = 200
+
+// Smart feed errors
+var (
+	// ErrSmartFeedManaged is returned for manual clip edits to a smart feed
+	ErrSmartFeedManaged = errors.New("smart feed clips are managed by its rules")
+	// ErrSmartFeedNoConditions is returned for smart feed rules that would match every clip
+	ErrSmartFeedNoConditions = errors.New("smart feed rules must have at least one game, broadcaster, tag, vote or language condition")
+)
+
+// Feed collaboration errors
+var (
+	// ErrFeedPermissionDenied is returned for edits a user's feed roles don't allow
+	ErrFeedPermissionDenied = errors.New("you don't have permission to make this change to the feed")
+	// ErrFeedCollaboratorSelf is returned when a feed's owner invites themselves
+	ErrFeedCollaboratorSelf = errors.New("feed owners can't be invited to their own feed")
+)
+
+var smartFeedClipChangesTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "smart_feed_clip_changes_total",
+		Help: "Total number of clips added to or removed from smart feeds by materialization",
+	},
+	[]string{"change"}, // "added", "removed"
+)
+
+// FeedRepositoryInterface defines the feed repository methods used by FeedService
+type FeedRepositoryInterface interface {
+	CreateFeed(ctx context.Context, feed *models.Feed) error
+	GetFeedByID(ctx context.Context, feedID uuid.UUID) (*models.Feed, error)
+	GetFeedsByUserID(ctx context.Context, userID uuid.UUID, includePrivate bool) ([]*models.Feed, error)
+	UpdateFeed(ctx context.Context, feed *models.Feed) error
+	DeleteFeed(ctx context.Context, feedID uuid.UUID) error
+	AddClipToFeed(ctx context.Context, feedItem *models.FeedItem, expectedVersion *int) (int, error)
+	RemoveClipFromFeed(ctx context.Context, feedID, clipID uuid.UUID, expectedVersion *int) (int, error)
+	GetFeedClips(ctx context.Context, feedID uuid.UUID) ([]*models.FeedItemWithClip, error)
+	ReorderFeedClips(ctx context.Context, feedID uuid.UUID, clipIDs []uuid.UUID, expectedVersion *int) (int, error)
+	MoveFeedClip(ctx context.Context, feedID, clipID uuid.UUID, beforeClipID *uuid.UUID, expectedVersion *int) (int, error)
+	CreateFeedCollaborator(ctx context.Context, collaborator *models.FeedCollaborator) error
+	GetFeedCollaborator(ctx context.Context, feedID, userID uuid.UUID) (*models.FeedCollaborator, error)
+	ListFeedCollaborators(ctx context.Context, feedID uuid.UUID) ([]*models.FeedCollaborator, error)
+	UpdateFeedCollaboratorRoles(ctx context.Context, feedID, userID uuid.UUID, roles []string) error
+	AcceptFeedCollaborator(ctx context.Context, feedID, userID uuid.UUID) error
+	DeleteFeedCollaborator(ctx context.Context, feedID, userID uuid.UUID) error
+	ListUserFeedCollaborations(ctx context.Context, userID uuid.UUID, status string) ([]*models.FeedCollaboration, error)
+	FollowFeed(ctx context.Context, feedFollow *models.FeedFollow) error
+	UnfollowFeed(ctx context.Context, userID, feedID uuid.UUID) error
+	IsFollowingFeed(ctx context.Context, userID, feedID uuid.UUID) (bool, error)
+	GetFollowedFeeds(ctx context.Context, userID uuid.UUID) ([]*models.Feed, error)
+	DiscoverPublicFeeds(ctx context.Context, limit, offset int) ([]*models.FeedWithOwner, error)
+	SearchFeeds(ctx context.Context, query string, limit, offset int) ([]*models.FeedWithOwner, error)
+	GetSmartFeedRules(ctx context.Context, feedID uuid.UUID) (*models.SmartFeedRules, error)
+	UpsertSmartFeedRules(ctx context.Context, rules *models.SmartFeedRules) error
+	DeleteSmartFeedRules(ctx context.Context, feedID uuid.UUID) error
+	ListDueSmartFeeds(ctx context.Context, staleBefore time.Time, limit int) ([]models.SmartFeedRules, error)
+	MaterializeSmartFeed(ctx context.Context, rules *models.SmartFeedRules) (*models.SmartFeedMaterialization, error)
+}
+
+// FeedUserRepositoryInterface defines the user repository methods used by FeedService
+type FeedUserRepositoryInterface interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+}
+
 type FeedService struct {
-	feedRepo        *repository.FeedRepository
+	feedRepo        FeedRepositoryInterface
 	clipRepo        *repository.ClipRepository
-	userRepo        *repository.UserRepository
+	userRepo        FeedUserRepositoryInterface
 	broadcasterRepo *repository.BroadcasterRepository
 	voteRepo        *repository.VoteRepository
 	favoriteRepo    *repository.FavoriteRepository
+
+	notificationService *NotificationService
 }
 
 func NewFeedService(
-	feedRepo *repository.FeedRepository,
+	feedRepo FeedRepositoryInterface,
 	clipRepo *repository.ClipRepository,
-	userRepo *repository.UserRepository,
+	userRepo FeedUserRepositoryInterface,
 	broadcasterRepo *repository.BroadcasterRepository,
 	voteRepo *repository.VoteRepository,
 	favoriteRepo *repository.FavoriteRepository,
@@ -37,6 +108,11 @@ func NewFeedService(
 	}
 }
 
+// SetNotificationService enables notifying users invited to co-curate a feed
+func (s *FeedService) SetNotificationService(notificationService *NotificationService) {
+	s.notificationService = notificationService
+}
+
 // CreateFeed creates a new feed for a user
 func (s *FeedService) CreateFeed(ctx context.Context, userID uuid.UUID, req *models.CreateFeedRequest) (*models.Feed, error) {
 	feed := &models.Feed{
@@ -71,10 +147,8 @@ func (s *FeedService) GetFeed(ctx context.Context, feedID uuid.UUID, requestingU
 	}
 
 	// Check if the requesting user has access to this feed
-	if !feed.IsPublic {
-		if requestingUserID == nil || *requestingUserID != feed.UserID {
-			return nil, fmt.Errorf("unauthorized access to private feed")
-		}
+	if !s.canViewFeed(ctx, feed, requestingUserID) {
+		return nil, fmt.Errorf("unauthorized access to private feed")
 	}
 
 	return feed, nil
@@ -93,8 +167,12 @@ func (s *FeedService) UpdateFeed(ctx context.Context, feedID, userID uuid.UUID,
 		return nil, err
 	}
 
-	if feed.UserID != userID {
-		return nil, fmt.Errorf("unauthorized to update this feed")
+	if err := s.authorizeFeedEdit(ctx, feed, userID, models.FeedRoleEditMetadata); err != nil {
+		return nil, err
+	}
+	// Only the owner decides who can see the feed
+	if req.IsPublic != nil && *req.IsPublic != feed.IsPublic && feed.UserID != userID {
+		return nil, ErrFeedPermissionDenied
 	}
 
 	if req.Name != nil {
@@ -132,15 +210,19 @@ func (s *FeedService) DeleteFeed(ctx context.Context, feedID, userID uuid.UUID)
 	return s.feedRepo.DeleteFeed(ctx, feedID)
 }
 
-// AddClipToFeed adds a clip to a feed
-func (s *FeedService) AddClipToFeed(ctx context.Context, feedID, userID, clipID uuid.UUID) (*models.FeedItem, error) {
+// AddClipToFeed adds a clip to a feed. When expectedVersion is set and the
+// feed has changed since, it returns repository.ErrFeedVersionConflict.
+func (s *FeedService) AddClipToFeed(ctx context.Context, feedID, userID, clipID uuid.UUID, expectedVersion *int) (*models.AddClipToFeedResponse, error) {
 	feed, err := s.feedRepo.GetFeedByID(ctx, feedID)
 	if err != nil {
 		return nil, err
 	}
 
-	if feed.UserID != userID {
-		return nil, fmt.Errorf("unauthorized to add clips to this feed")
+	if err := s.authorizeFeedEdit(ctx, feed, userID, models.FeedRoleAddClips); err != nil {
+		return nil, err
+	}
+	if feed.IsSmart {
+		return nil, ErrSmartFeedManaged
 	}
 
 	// Verify clip exists
@@ -150,32 +232,36 @@ func (s *FeedService) AddClipToFeed(ctx context.Context, feedID, userID, clipID
 	}
 
 	feedItem := &models.FeedItem{
-		ID:      uuid.New(),
-		FeedID:  feedID,
-		ClipID:  clipID,
-		AddedAt: time.Now(),
+		ID:            uuid.New(),
+		FeedID:        feedID,
+		ClipID:        clipID,
+		AddedAt:       time.Now(),
+		AddedByUserID: &userID,
 	}
 
-	err = s.feedRepo.AddClipToFeed(ctx, feedItem)
+	version, err := s.feedRepo.AddClipToFeed(ctx, feedItem, expectedVersion)
 	if err != nil {
 		return nil, fmt.Errorf("failed to add clip to feed: %w", err)
 	}
 
-	return feedItem, nil
+	return &models.AddClipToFeedResponse{FeedItem: *feedItem, FeedVersion: version}, nil
 }
 
-// RemoveClipFromFeed removes a clip from a feed
-func (s *FeedService) RemoveClipFromFeed(ctx context.Context, feedID, userID, clipID uuid.UUID) error {
+// RemoveClipFromFeed removes a clip from a feed, returning the feed's new version
+func (s *FeedService) RemoveClipFromFeed(ctx context.Context, feedID, userID, clipID uuid.UUID, expectedVersion *int) (int, error) {
 	feed, err := s.feedRepo.GetFeedByID(ctx, feedID)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	if feed.UserID != userID {
-		return fmt.Errorf("unauthorized to remove clips from this feed")
+	if err := s.authorizeFeedEdit(ctx, feed, userID, models.FeedRoleRemoveClips); err != nil {
+		return 0, err
+	}
+	if feed.IsSmart {
+		return 0, ErrSmartFeedManaged
 	}
 
-	return s.feedRepo.RemoveClipFromFeed(ctx, feedID, clipID)
+	return s.feedRepo.RemoveClipFromFeed(ctx, feedID, clipID, expectedVersion)
 }
 
 // GetFeedClips retrieves all clips in a feed
@@ -186,27 +272,198 @@ func (s *FeedService) GetFeedClips(ctx context.Context, feedID uuid.UUID, reques
 	}
 
 	// Check if the requesting user has access to this feed
-	if !feed.IsPublic {
-		if requestingUserID == nil || *requestingUserID != feed.UserID {
-			return nil, fmt.Errorf("unauthorized access to private feed")
-		}
+	if !s.canViewFeed(ctx, feed, requestingUserID) {
+		return nil, fmt.Errorf("unauthorized access to private feed")
 	}
 
 	return s.feedRepo.GetFeedClips(ctx, feedID)
 }
 
-// ReorderFeedClips reorders clips in a feed
-func (s *FeedService) ReorderFeedClips(ctx context.Context, feedID, userID uuid.UUID, clipIDs []uuid.UUID) error {
+// ReorderFeedClips reorders clips in a feed, returning the feed's new version
+func (s *FeedService) ReorderFeedClips(ctx context.Context, feedID, userID uuid.UUID, clipIDs []uuid.UUID, expectedVersion *int) (int, error) {
+	feed, err := s.feedRepo.GetFeedByID(ctx, feedID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.authorizeFeedEdit(ctx, feed, userID, models.FeedRoleReorderClips); err != nil {
+		return 0, err
+	}
+	if feed.IsSmart {
+		return 0, ErrSmartFeedManaged
+	}
+
+	return s.feedRepo.ReorderFeedClips(ctx, feedID, clipIDs, expectedVersion)
+}
+
+// MoveFeedClip moves one clip of a feed before another, or to the end,
+// returning the feed's new version
+func (s *FeedService) MoveFeedClip(ctx context.Context, feedID, userID uuid.UUID, req *models.MoveFeedClipRequest) (int, error) {
 	feed, err := s.feedRepo.GetFeedByID(ctx, feedID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.authorizeFeedEdit(ctx, feed, userID, models.FeedRoleReorderClips); err != nil {
+		return 0, err
+	}
+	if feed.IsSmart {
+		return 0, ErrSmartFeedManaged
+	}
+
+	return s.feedRepo.MoveFeedClip(ctx, feedID, req.ClipID, req.BeforeClipID, req.Version)
+}
+
+// canViewFeed reports whether a user may see a feed: public feeds are visible
+// to everyone, private feeds to their owner and accepted collaborators
+func (s *FeedService) canViewFeed(ctx context.Context, feed *models.Feed, requestingUserID *uuid.UUID) bool {
+	if feed.IsPublic {
+		return true
+	}
+	if requestingUserID == nil {
+		return false
+	}
+	if *requestingUserID == feed.UserID {
+		return true
+	}
+	collaborator, err := s.feedRepo.GetFeedCollaborator(ctx, feed.ID, *requestingUserID)
+	return err == nil && collaborator.Status == models.FeedCollaboratorAccepted
+}
+
+// authorizeFeedEdit checks that a user may make an edit needing role: a
+// feed's owner may make any edit, its collaborators those their roles allow
+func (s *FeedService) authorizeFeedEdit(ctx context.Context, feed *models.Feed, userID uuid.UUID, role string) error {
+	if feed.UserID == userID {
+		return nil
+	}
+	collaborator, err := s.feedRepo.GetFeedCollaborator(ctx, feed.ID, userID)
+	if errors.Is(err, repository.ErrFeedCollaboratorNotFound) {
+		return ErrFeedPermissionDenied
+	}
 	if err != nil {
 		return err
 	}
+	if !collaborator.Can(role) {
+		return ErrFeedPermissionDenied
+	}
+	return nil
+}
 
+// feedOwnedBy returns a feed, or ErrFeedPermissionDenied if the user doesn't own it
+func (s *FeedService) feedOwnedBy(ctx context.Context, feedID, userID uuid.UUID) (*models.Feed, error) {
+	feed, err := s.feedRepo.GetFeedByID(ctx, feedID)
+	if err != nil {
+		return nil, err
+	}
 	if feed.UserID != userID {
-		return fmt.Errorf("unauthorized to reorder clips in this feed")
+		return nil, ErrFeedPermissionDenied
+	}
+	return feed, nil
+}
+
+// InviteFeedCollaborator invites a user to co-curate a feed with the given
+// roles. Only the feed's owner may invite; the invite is pending until the
+// user accepts it.
+func (s *FeedService) InviteFeedCollaborator(ctx context.Context, feedID, ownerID uuid.UUID, req *models.InviteFeedCollaboratorRequest) (*models.FeedCollaborator, error) {
+	feed, err := s.feedOwnedBy(ctx, feedID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if req.UserID == ownerID {
+		return nil, ErrFeedCollaboratorSelf
+	}
+	if _, err := s.userRepo.GetByID(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	collaborator := &models.FeedCollaborator{
+		FeedID:    feedID,
+		UserID:    req.UserID,
+		InvitedBy: &ownerID,
+		Roles:     normalizeFeedRoles(req.Roles),
+	}
+	if err := s.feedRepo.CreateFeedCollaborator(ctx, collaborator); err != nil {
+		return nil, err
 	}
 
-	return s.feedRepo.ReorderFeedClips(ctx, feedID, clipIDs)
+	if s.notificationService != nil {
+		if err := s.notificationService.NotifyFeedInvite(ctx, req.UserID, ownerID, feed); err != nil {
+			utils.GetLogger().Warn("Failed to notify feed invite", map[string]interface{}{
+				"feed_id": feedID.String(),
+				"user_id": req.UserID.String(),
+				"error":   err.Error(),
+			})
+		}
+	}
+	return collaborator, nil
+}
+
+// ListFeedCollaborators returns a feed's collaborators and pending invites.
+// The owner and anyone invited to the feed may list them.
+func (s *FeedService) ListFeedCollaborators(ctx context.Context, feedID, requestingUserID uuid.UUID) ([]*models.FeedCollaborator, error) {
+	feed, err := s.feedRepo.GetFeedByID(ctx, feedID)
+	if err != nil {
+		return nil, err
+	}
+	if feed.UserID != requestingUserID {
+		if _, err := s.feedRepo.GetFeedCollaborator(ctx, feedID, requestingUserID); err != nil {
+			if errors.Is(err, repository.ErrFeedCollaboratorNotFound) {
+				return nil, ErrFeedPermissionDenied
+			}
+			return nil, err
+		}
+	}
+	return s.feedRepo.ListFeedCollaborators(ctx, feedID)
+}
+
+// UpdateFeedCollaborator replaces a collaborator's roles. Only the feed's owner may.
+func (s *FeedService) UpdateFeedCollaborator(ctx context.Context, feedID, ownerID, collaboratorID uuid.UUID, roles []string) (*models.FeedCollaborator, error) {
+	if _, err := s.feedOwnedBy(ctx, feedID, ownerID); err != nil {
+		return nil, err
+	}
+	if err := s.feedRepo.UpdateFeedCollaboratorRoles(ctx, feedID, collaboratorID, normalizeFeedRoles(roles)); err != nil {
+		return nil, err
+	}
+	return s.feedRepo.GetFeedCollaborator(ctx, feedID, collaboratorID)
+}
+
+// AcceptFeedInvite accepts a user's invite to co-curate a feed
+func (s *FeedService) AcceptFeedInvite(ctx context.Context, feedID, userID uuid.UUID) (*models.FeedCollaborator, error) {
+	if err := s.feedRepo.AcceptFeedCollaborator(ctx, feedID, userID); err != nil {
+		return nil, err
+	}
+	return s.feedRepo.GetFeedCollaborator(ctx, feedID, userID)
+}
+
+// RemoveFeedCollaborator removes a collaborator from a feed. The owner may
+// remove anyone; users may remove themselves, declining an invite or leaving.
+func (s *FeedService) RemoveFeedCollaborator(ctx context.Context, feedID, requestingUserID, collaboratorID uuid.UUID) error {
+	if requestingUserID != collaboratorID {
+		if _, err := s.feedOwnedBy(ctx, feedID, requestingUserID); err != nil {
+			return err
+		}
+	}
+	return s.feedRepo.DeleteFeedCollaborator(ctx, feedID, collaboratorID)
+}
+
+// ListFeedCollaborations returns the feeds a user is invited to co-curate,
+// optionally only pending or accepted ones
+func (s *FeedService) ListFeedCollaborations(ctx context.Context, userID uuid.UUID, status string) ([]*models.FeedCollaboration, error) {
+	return s.feedRepo.ListUserFeedCollaborations(ctx, userID, status)
+}
+
+// normalizeFeedRoles dedupes feed roles, keeping them in a fixed order
+func normalizeFeedRoles(roles []string) []string {
+	normalized := make([]string, 0, len(models.FeedRoles))
+	for _, role := range models.FeedRoles {
+		for _, r := range roles {
+			if r == role {
+				normalized = append(normalized, role)
+				break
+			}
+		}
+	}
+	return normalized
 }
 
 // FollowFeed adds a follow relationship
@@ -299,7 +556,22 @@ func (s *FeedService) GetFilteredClipsWithUserData(ctx context.Context, filters
 	if err != nil {
 		return nil, 0, err
 	}
+	return s.withUserContext(ctx, clips, userID), total, nil
+}
+
+// GetFilteredClipsCursor retrieves a page of filtered clips with user-specific
+// data, continuing after filters.Cursor when set
+func (s *FeedService) GetFilteredClipsCursor(ctx context.Context, filters repository.ClipFilters, limit int, userID *uuid.UUID) ([]ClipWithUserContext, pagination.PageInfo, error) {
+	clips, page, err := s.clipRepo.ListWithFiltersCursor(ctx, filters, limit)
+	if err != nil {
+		return nil, pagination.PageInfo{}, err
+	}
+	return s.withUserContext(ctx, clips, userID), page, nil
+}
 
+// withUserContext adds vote counts and, when authenticated, the user's vote
+// and favorite to listed clips
+func (s *FeedService) withUserContext(ctx context.Context, clips []models.Clip, userID *uuid.UUID) []ClipWithUserContext {
 	// Convert to ClipWithUserContext and enrich with user data if authenticated
 	enrichedClips := make([]ClipWithUserContext, len(clips))
 	for i, clip := range clips {
@@ -332,5 +604,122 @@ func (s *FeedService) GetFilteredClipsWithUserData(ctx context.Context, filters
 		enrichedClips[i] = enrichedClip
 	}
 
-	return enrichedClips, total, nil
+	return enrichedClips
+}
+
+// GetSmartFeedRules returns a smart feed's rules. Rules of private feeds are
+// only visible to their owner.
+func (s *FeedService) GetSmartFeedRules(ctx context.Context, feedID uuid.UUID, requestingUserID *uuid.UUID) (*models.SmartFeedRules, error) {
+	if _, err := s.GetFeed(ctx, feedID, requestingUserID); err != nil {
+		return nil, err
+	}
+	return s.feedRepo.GetSmartFeedRules(ctx, feedID)
+}
+
+// SetSmartFeedRules turns a feed into a smart feed, or replaces its rules, and
+// materializes it at once. From then on the feed's clips are picked by its
+// rules and can't be edited by hand.
+func (s *FeedService) SetSmartFeedRules(ctx context.Context, feedID, userID uuid.UUID, req *models.SmartFeedRulesRequest) (*models.SmartFeedRules, error) {
+	feed, err := s.feedRepo.GetFeedByID(ctx, feedID)
+	if err != nil {
+		return nil, err
+	}
+
+	if feed.UserID != userID {
+		return nil, fmt.Errorf("unauthorized to change the rules of this feed")
+	}
+
+	rules, err := smartFeedRulesFromRequest(feedID, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.feedRepo.UpsertSmartFeedRules(ctx, rules); err != nil {
+		return nil, fmt.Errorf("failed to save smart feed rules: %w", err)
+	}
+
+	// The scheduler retries feeds that fail here, since their rules changed
+	if _, err := s.materializeSmartFeed(ctx, rules); err != nil {
+		utils.GetLogger().Error("Failed to materialize smart feed", err, map[string]interface{}{
+			"feed_id": feedID.String(),
+		})
+	}
+	return rules, nil
+}
+
+// DeleteSmartFeedRules turns a smart feed back into a manually curated feed.
+// Its clips are kept.
+func (s *FeedService) DeleteSmartFeedRules(ctx context.Context, feedID, userID uuid.UUID) error {
+	feed, err := s.feedRepo.GetFeedByID(ctx, feedID)
+	if err != nil {
+		return err
+	}
+
+	if feed.UserID != userID {
+		return fmt.Errorf("unauthorized to change the rules of this feed")
+	}
+
+	return s.feedRepo.DeleteSmartFeedRules(ctx, feedID)
+}
+
+// MaterializeSmartFeeds updates the clips of smart feeds that were never
+// materialized, whose rules changed, or that were last materialized before
+// staleBefore. It returns the number of feeds whose clips changed.
+func (s *FeedService) MaterializeSmartFeeds(ctx context.Context, staleBefore time.Time) (int, error) {
+	due, err := s.feedRepo.ListDueSmartFeeds(ctx, staleBefore, smartFeedBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	for i := range due {
+		if ctx.Err() != nil {
+			return changed, ctx.Err()
+		}
+		result, err := s.materializeSmartFeed(ctx, &due[i])
+		if errors.Is(err, repository.ErrSmartFeedRulesNotFound) {
+			continue
+		}
+		if err != nil {
+			utils.GetLogger().Error("Failed to materialize smart feed", err, map[string]interface{}{
+				"feed_id": due[i].FeedID.String(),
+			})
+			continue
+		}
+		if result.Added > 0 || result.Removed > 0 {
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+func (s *FeedService) materializeSmartFeed(ctx context.Context, rules *models.SmartFeedRules) (*models.SmartFeedMaterialization, error) {
+	result, err := s.feedRepo.MaterializeSmartFeed(ctx, rules)
+	if err != nil {
+		return nil, err
+	}
+	smartFeedClipChangesTotal.WithLabelValues("added").Add(float64(result.Added))
+	smartFeedClipChangesTotal.WithLabelValues("removed").Add(float64(result.Removed))
+	return result, nil
+}
+
+// smartFeedRulesFromRequest validates and normalizes smart feed rules. Values
+// are trimmed and de-duplicated, and tags and languages are lowercased.
+func smartFeedRulesFromRequest(feedID uuid.UUID, req *models.SmartFeedRulesRequest) (*models.SmartFeedRules, error) {
+	rules := &models.SmartFeedRules{
+		FeedID:         feedID,
+		GameIDs:        normalizeRuleValues(req.GameIDs, false),
+		BroadcasterIDs: normalizeRuleValues(req.BroadcasterIDs, false),
+		Tags:           normalizeRuleValues(req.Tags, true),
+		Languages:      normalizeRuleValues(req.Languages, true),
+		MinVotes:       req.MinVotes,
+		MaxClips:       models.SmartFeedDefaultMaxClips,
+	}
+	if req.MaxClips != nil {
+		rules.MaxClips = min(max(*req.MaxClips, 1), models.SmartFeedMaxClipsLimit)
+	}
+
+	if !rules.HasConditions() {
+		return nil, ErrSmartFeedNoConditions
+	}
+	return rules, nil
 }
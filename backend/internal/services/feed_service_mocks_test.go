@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// MockFeedRepository is a mock implementation of FeedRepositoryInterface
+type MockFeedRepository struct {
+	mock.Mock
+}
+
+func (m *MockFeedRepository) CreateFeed(ctx context.Context, feed *models.Feed) error {
+	args := m.Called(ctx, feed)
+	return args.Error(0)
+}
+
+func (m *MockFeedRepository) GetFeedByID(ctx context.Context, feedID uuid.UUID) (*models.Feed, error) {
+	args := m.Called(ctx, feedID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Feed), args.Error(1)
+}
+
+func (m *MockFeedRepository) GetFeedsByUserID(ctx context.Context, userID uuid.UUID, includePrivate bool) ([]*models.Feed, error) {
+	args := m.Called(ctx, userID, includePrivate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Feed), args.Error(1)
+}
+
+func (m *MockFeedRepository) UpdateFeed(ctx context.Context, feed *models.Feed) error {
+	args := m.Called(ctx, feed)
+	return args.Error(0)
+}
+
+func (m *MockFeedRepository) DeleteFeed(ctx context.Context, feedID uuid.UUID) error {
+	args := m.Called(ctx, feedID)
+	return args.Error(0)
+}
+
+func (m *MockFeedRepository) AddClipToFeed(ctx context.Context, feedItem *models.FeedItem, expectedVersion *int) (int, error) {
+	args := m.Called(ctx, feedItem, expectedVersion)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockFeedRepository) RemoveClipFromFeed(ctx context.Context, feedID, clipID uuid.UUID, expectedVersion *int) (int, error) {
+	args := m.Called(ctx, feedID, clipID, expectedVersion)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockFeedRepository) GetFeedClips(ctx context.Context, feedID uuid.UUID) ([]*models.FeedItemWithClip, error) {
+	args := m.Called(ctx, feedID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.FeedItemWithClip), args.Error(1)
+}
+
+func (m *MockFeedRepository) ReorderFeedClips(ctx context.Context, feedID uuid.UUID, clipIDs []uuid.UUID, expectedVersion *int) (int, error) {
+	args := m.Called(ctx, feedID, clipIDs, expectedVersion)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockFeedRepository) MoveFeedClip(ctx context.Context, feedID, clipID uuid.UUID, beforeClipID *uuid.UUID, expectedVersion *int) (int, error) {
+	args := m.Called(ctx, feedID, clipID, beforeClipID, expectedVersion)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockFeedRepository) CreateFeedCollaborator(ctx context.Context, collaborator *models.FeedCollaborator) error {
+	args := m.Called(ctx, collaborator)
+	return args.Error(0)
+}
+
+func (m *MockFeedRepository) GetFeedCollaborator(ctx context.Context, feedID, userID uuid.UUID) (*models.FeedCollaborator, error) {
+	args := m.Called(ctx, feedID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.FeedCollaborator), args.Error(1)
+}
+
+func (m *MockFeedRepository) ListFeedCollaborators(ctx context.Context, feedID uuid.UUID) ([]*models.FeedCollaborator, error) {
+	args := m.Called(ctx, feedID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.FeedCollaborator), args.Error(1)
+}
+
+func (m *MockFeedRepository) UpdateFeedCollaboratorRoles(ctx context.Context, feedID, userID uuid.UUID, roles []string) error {
+	args := m.Called(ctx, feedID, userID, roles)
+	return args.Error(0)
+}
+
+func (m *MockFeedRepository) AcceptFeedCollaborator(ctx context.Context, feedID, userID uuid.UUID) error {
+	args := m.Called(ctx, feedID, userID)
+	return args.Error(0)
+}
+
+func (m *MockFeedRepository) DeleteFeedCollaborator(ctx context.Context, feedID, userID uuid.UUID) error {
+	args := m.Called(ctx, feedID, userID)
+	return args.Error(0)
+}
+
+func (m *MockFeedRepository) ListUserFeedCollaborations(ctx context.Context, userID uuid.UUID, status string) ([]*models.FeedCollaboration, error) {
+	args := m.Called(ctx, userID, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.FeedCollaboration), args.Error(1)
+}
+
+func (m *MockFeedRepository) FollowFeed(ctx context.Context, feedFollow *models.FeedFollow) error {
+	args := m.Called(ctx, feedFollow)
+	return args.Error(0)
+}
+
+func (m *MockFeedRepository) UnfollowFeed(ctx context.Context, userID, feedID uuid.UUID) error {
+	args := m.Called(ctx, userID, feedID)
+	return args.Error(0)
+}
+
+func (m *MockFeedRepository) IsFollowingFeed(ctx context.Context, userID, feedID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, userID, feedID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockFeedRepository) GetFollowedFeeds(ctx context.Context, userID uuid.UUID) ([]*models.Feed, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Feed), args.Error(1)
+}
+
+func (m *MockFeedRepository) DiscoverPublicFeeds(ctx context.Context, limit, offset int) ([]*models.FeedWithOwner, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.FeedWithOwner), args.Error(1)
+}
+
+func (m *MockFeedRepository) SearchFeeds(ctx context.Context, query string, limit, offset int) ([]*models.FeedWithOwner, error) {
+	args := m.Called(ctx, query, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.FeedWithOwner), args.Error(1)
+}
+
+func (m *MockFeedRepository) GetSmartFeedRules(ctx context.Context, feedID uuid.UUID) (*models.SmartFeedRules, error) {
+	args := m.Called(ctx, feedID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SmartFeedRules), args.Error(1)
+}
+
+func (m *MockFeedRepository) UpsertSmartFeedRules(ctx context.Context, rules *models.SmartFeedRules) error {
+	args := m.Called(ctx, rules)
+	return args.Error(0)
+}
+
+func (m *MockFeedRepository) DeleteSmartFeedRules(ctx context.Context, feedID uuid.UUID) error {
+	args := m.Called(ctx, feedID)
+	return args.Error(0)
+}
+
+func (m *MockFeedRepository) ListDueSmartFeeds(ctx context.Context, staleBefore time.Time, limit int) ([]models.SmartFeedRules, error) {
+	args := m.Called(ctx, staleBefore, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.SmartFeedRules), args.Error(1)
+}
+
+func (m *MockFeedRepository) MaterializeSmartFeed(ctx context.Context, rules *models.SmartFeedRules) (*models.SmartFeedMaterialization, error) {
+	args := m.Called(ctx, rules)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SmartFeedMaterialization), args.Error(1)
+}
@@ -0,0 +1,242 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+func TestSmartFeedRulesFromRequest(t *testing.T) {
+	feedID := uuid.New()
+	minVotes := 10
+
+	rules, err := smartFeedRulesFromRequest(feedID, &models.SmartFeedRulesRequest{
+		GameIDs:   []string{" 516575 ", "516575", ""},
+		Tags:      []string{"Funny", "funny", "clutch"},
+		Languages: []string{"EN"},
+		MinVotes:  &minVotes,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, feedID, rules.FeedID)
+	assert.Equal(t, []string{"516575"}, rules.GameIDs)
+	assert.Empty(t, rules.BroadcasterIDs)
+	assert.Equal(t, []string{"funny", "clutch"}, rules.Tags)
+	assert.Equal(t, []string{"en"}, rules.Languages)
+	assert.Equal(t, &minVotes, rules.MinVotes)
+	assert.Equal(t, models.SmartFeedDefaultMaxClips, rules.MaxClips)
+}
+
+func TestSmartFeedRulesFromRequestMaxClips(t *testing.T) {
+	tooMany := 10000
+	rules, err := smartFeedRulesFromRequest(uuid.New(), &models.SmartFeedRulesRequest{
+		Tags:     []string{"funny"},
+		MaxClips: &tooMany,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.SmartFeedMaxClipsLimit, rules.MaxClips)
+}
+
+func TestSmartFeedRulesFromRequestNeedsConditions(t *testing.T) {
+	_, err := smartFeedRulesFromRequest(uuid.New(), &models.SmartFeedRulesRequest{
+		GameIDs: []string{" "},
+	})
+	assert.ErrorIs(t, err, ErrSmartFeedNoConditions)
+
+	zero := 0
+	_, err = smartFeedRulesFromRequest(uuid.New(), &models.SmartFeedRulesRequest{MinVotes: &zero})
+	assert.NoError(t, err, "a vote threshold is a condition, even at zero")
+}
+
+func TestNormalizeFeedRoles(t *testing.T) {
+	roles := normalizeFeedRoles([]string{
+		models.FeedRoleEditMetadata, models.FeedRoleAddClips, models.FeedRoleEditMetadata,
+	})
+	assert.Equal(t, []string{models.FeedRoleAddClips, models.FeedRoleEditMetadata}, roles)
+}
+
+func TestFeedCollaboratorCan(t *testing.T) {
+	collaborator := &models.FeedCollaborator{
+		Roles:  []string{models.FeedRoleAddClips, models.FeedRoleReorderClips},
+		Status: models.FeedCollaboratorPending,
+	}
+	assert.False(t, collaborator.Can(models.FeedRoleAddClips), "pending invites grant nothing")
+
+	collaborator.Status = models.FeedCollaboratorAccepted
+	assert.True(t, collaborator.Can(models.FeedRoleAddClips))
+	assert.True(t, collaborator.Can(models.FeedRoleReorderClips))
+	assert.False(t, collaborator.Can(models.FeedRoleRemoveClips))
+	assert.False(t, collaborator.Can(models.FeedRoleEditMetadata))
+}
+
+// setupFeedServiceTest builds a FeedService over a mock feed repository
+func setupFeedServiceTest() (*FeedService, *MockFeedRepository, *MockUserRepository) {
+	feedRepo := new(MockFeedRepository)
+	userRepo := new(MockUserRepository)
+	return NewFeedService(feedRepo, nil, userRepo, nil, nil, nil), feedRepo, userRepo
+}
+
+func TestFeedService_AuthorizeFeedEdit(t *testing.T) {
+	ctx := context.Background()
+	feed := &models.Feed{ID: uuid.New(), UserID: uuid.New()}
+
+	tests := []struct {
+		name         string
+		collaborator *models.FeedCollaborator
+		lookupErr    error
+		wantErr      error
+	}{
+		{
+			name:         "accepted editor",
+			collaborator: &models.FeedCollaborator{Roles: models.FeedRoles, Status: models.FeedCollaboratorAccepted},
+		},
+		{
+			name:         "accepted viewer",
+			collaborator: &models.FeedCollaborator{Roles: []string{}, Status: models.FeedCollaboratorAccepted},
+			wantErr:      ErrFeedPermissionDenied,
+		},
+		{
+			name:         "pending invite",
+			collaborator: &models.FeedCollaborator{Roles: models.FeedRoles, Status: models.FeedCollaboratorPending},
+			wantErr:      ErrFeedPermissionDenied,
+		},
+		{
+			name:      "not invited",
+			lookupErr: repository.ErrFeedCollaboratorNotFound,
+			wantErr:   ErrFeedPermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, feedRepo, _ := setupFeedServiceTest()
+			userID := uuid.New()
+			feedRepo.On("GetFeedCollaborator", ctx, feed.ID, userID).Return(tt.collaborator, tt.lookupErr)
+
+			err := service.authorizeFeedEdit(ctx, feed, userID, models.FeedRoleAddClips)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("owner", func(t *testing.T) {
+		service, feedRepo, _ := setupFeedServiceTest()
+		assert.NoError(t, service.authorizeFeedEdit(ctx, feed, feed.UserID, models.FeedRoleEditMetadata))
+		feedRepo.AssertNotCalled(t, "GetFeedCollaborator", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestFeedService_CanViewPrivateFeed(t *testing.T) {
+	ctx := context.Background()
+	service, feedRepo, _ := setupFeedServiceTest()
+	feed := &models.Feed{ID: uuid.New(), UserID: uuid.New(), IsPublic: false}
+
+	accepted, pending, stranger := uuid.New(), uuid.New(), uuid.New()
+	feedRepo.On("GetFeedCollaborator", ctx, feed.ID, accepted).
+		Return(&models.FeedCollaborator{Status: models.FeedCollaboratorAccepted}, nil)
+	feedRepo.On("GetFeedCollaborator", ctx, feed.ID, pending).
+		Return(&models.FeedCollaborator{Roles: models.FeedRoles, Status: models.FeedCollaboratorPending}, nil)
+	feedRepo.On("GetFeedCollaborator", ctx, feed.ID, stranger).
+		Return(nil, repository.ErrFeedCollaboratorNotFound)
+
+	assert.True(t, service.canViewFeed(ctx, feed, &feed.UserID), "owner")
+	assert.True(t, service.canViewFeed(ctx, feed, &accepted), "accepted collaborator")
+	assert.False(t, service.canViewFeed(ctx, feed, &pending), "pending invites grant nothing")
+	assert.False(t, service.canViewFeed(ctx, feed, &stranger))
+	assert.False(t, service.canViewFeed(ctx, feed, nil), "anonymous")
+
+	feed.IsPublic = true
+	assert.True(t, service.canViewFeed(ctx, feed, nil))
+}
+
+func TestFeedService_UpdateFeedOnlyOwnerChangesVisibility(t *testing.T) {
+	ctx := context.Background()
+	service, feedRepo, _ := setupFeedServiceTest()
+	feed := &models.Feed{ID: uuid.New(), UserID: uuid.New(), Name: "Highlights", IsPublic: false}
+	editorID := uuid.New()
+
+	feedRepo.On("GetFeedByID", ctx, feed.ID).Return(feed, nil)
+	feedRepo.On("GetFeedCollaborator", ctx, feed.ID, editorID).Return(&models.FeedCollaborator{
+		Roles:  []string{models.FeedRoleEditMetadata},
+		Status: models.FeedCollaboratorAccepted,
+	}, nil)
+	feedRepo.On("UpdateFeed", ctx, feed).Return(nil)
+
+	public := true
+	_, err := service.UpdateFeed(ctx, feed.ID, editorID, &models.UpdateFeedRequest{IsPublic: &public})
+	assert.ErrorIs(t, err, ErrFeedPermissionDenied)
+	assert.False(t, feed.IsPublic)
+	feedRepo.AssertNotCalled(t, "UpdateFeed", mock.Anything, mock.Anything)
+
+	name := "Best of the week"
+	updated, err := service.UpdateFeed(ctx, feed.ID, editorID, &models.UpdateFeedRequest{Name: &name})
+	require.NoError(t, err)
+	assert.Equal(t, name, updated.Name)
+
+	updated, err = service.UpdateFeed(ctx, feed.ID, feed.UserID, &models.UpdateFeedRequest{IsPublic: &public})
+	require.NoError(t, err)
+	assert.True(t, updated.IsPublic)
+}
+
+func TestFeedService_AcceptFeedInviteOnlyByInvitee(t *testing.T) {
+	ctx := context.Background()
+	service, feedRepo, _ := setupFeedServiceTest()
+	feedID, inviteeID, otherID := uuid.New(), uuid.New(), uuid.New()
+
+	// The repository only accepts the caller's own invite row
+	feedRepo.On("AcceptFeedCollaborator", ctx, feedID, otherID).Return(repository.ErrFeedCollaboratorNotFound)
+	feedRepo.On("AcceptFeedCollaborator", ctx, feedID, inviteeID).Return(nil)
+	feedRepo.On("GetFeedCollaborator", ctx, feedID, inviteeID).Return(&models.FeedCollaborator{
+		FeedID: feedID,
+		UserID: inviteeID,
+		Status: models.FeedCollaboratorAccepted,
+	}, nil)
+
+	_, err := service.AcceptFeedInvite(ctx, feedID, otherID)
+	assert.ErrorIs(t, err, repository.ErrFeedCollaboratorNotFound)
+	feedRepo.AssertNotCalled(t, "AcceptFeedCollaborator", ctx, feedID, inviteeID)
+
+	collaborator, err := service.AcceptFeedInvite(ctx, feedID, inviteeID)
+	require.NoError(t, err)
+	assert.Equal(t, models.FeedCollaboratorAccepted, collaborator.Status)
+}
+
+func TestFeedService_RemoveFeedCollaborator(t *testing.T) {
+	ctx := context.Background()
+	feed := &models.Feed{ID: uuid.New(), UserID: uuid.New()}
+	collaboratorID, otherCollaboratorID := uuid.New(), uuid.New()
+
+	t.Run("owner", func(t *testing.T) {
+		service, feedRepo, _ := setupFeedServiceTest()
+		feedRepo.On("GetFeedByID", ctx, feed.ID).Return(feed, nil)
+		feedRepo.On("DeleteFeedCollaborator", ctx, feed.ID, collaboratorID).Return(nil).Once()
+
+		assert.NoError(t, service.RemoveFeedCollaborator(ctx, feed.ID, feed.UserID, collaboratorID))
+		feedRepo.AssertExpectations(t)
+	})
+
+	t.Run("collaborator leaves", func(t *testing.T) {
+		service, feedRepo, _ := setupFeedServiceTest()
+		feedRepo.On("DeleteFeedCollaborator", ctx, feed.ID, collaboratorID).Return(nil).Once()
+
+		assert.NoError(t, service.RemoveFeedCollaborator(ctx, feed.ID, collaboratorID, collaboratorID))
+		feedRepo.AssertExpectations(t)
+	})
+
+	t.Run("another collaborator", func(t *testing.T) {
+		service, feedRepo, _ := setupFeedServiceTest()
+		feedRepo.On("GetFeedByID", ctx, feed.ID).Return(feed, nil)
+
+		err := service.RemoveFeedCollaborator(ctx, feed.ID, otherCollaboratorID, collaboratorID)
+		assert.ErrorIs(t, err, ErrFeedPermissionDenied)
+		feedRepo.AssertNotCalled(t, "DeleteFeedCollaborator", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
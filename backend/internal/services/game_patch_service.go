@@ -0,0 +1,218 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+// ErrGameNotFound is returned when a game's patches are requested for an
+// unknown game
+var ErrGameNotFound = errors.New("game not found")
+
+// GamePatchService manages game patches and tags clips with the patch in
+// effect when they were created
+type GamePatchService struct {
+	patchRepo *repository.GamePatchRepository
+	gameRepo  *repository.GameRepository
+	now       func() time.Time
+}
+
+// NewGamePatchService creates a new GamePatchService
+func NewGamePatchService(patchRepo *repository.GamePatchRepository, gameRepo *repository.GameRepository) *GamePatchService {
+	return &GamePatchService{
+		patchRepo: patchRepo,
+		gameRepo:  gameRepo,
+		now:       time.Now,
+	}
+}
+
+// GetGame returns a game by internal UUID or Twitch game ID, the two forms
+// game routes accept
+func (s *GamePatchService) GetGame(ctx context.Context, gameRef string) (*models.Game, error) {
+	var game *models.Game
+	var err error
+	if gameID, parseErr := uuid.Parse(gameRef); parseErr == nil {
+		game, err = s.gameRepo.GetByID(ctx, gameID)
+	} else {
+		game, err = s.gameRepo.GetByTwitchGameID(ctx, gameRef)
+	}
+	if err != nil || game == nil {
+		return nil, ErrGameNotFound
+	}
+	return game, nil
+}
+
+// ListPatches returns a game's patches, newest first, marking the one in
+// effect now. Patches released in the future are listed but not current.
+func (s *GamePatchService) ListPatches(ctx context.Context, gameRef string) ([]*models.GamePatch, error) {
+	game, err := s.GetGame(ctx, gameRef)
+	if err != nil {
+		return nil, err
+	}
+	patches, err := s.patchRepo.ListByGame(ctx, game.ID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	for _, patch := range patches {
+		patch.IsCurrent = isCurrentPatch(patch, now)
+	}
+	return patches, nil
+}
+
+// CurrentPatch returns the patch of a game in effect now, or
+// repository.ErrGamePatchNotFound when the game has none yet
+func (s *GamePatchService) CurrentPatch(ctx context.Context, gameRef string) (*models.GamePatch, error) {
+	patches, err := s.ListPatches(ctx, gameRef)
+	if err != nil {
+		return nil, err
+	}
+	for _, patch := range patches {
+		if patch.IsCurrent {
+			return patch, nil
+		}
+	}
+	return nil, repository.ErrGamePatchNotFound
+}
+
+// CreatePatch records a patch of a game and retags the game's clips. Patches
+// from IGDB replace the game's patch with the same version, so imports can
+// be repeated.
+func (s *GamePatchService) CreatePatch(ctx context.Context, gameRef string, userID uuid.UUID, req *models.CreateGamePatchRequest) (*models.GamePatch, error) {
+	game, err := s.GetGame(ctx, gameRef)
+	if err != nil {
+		return nil, err
+	}
+
+	source := req.Source
+	if source == "" {
+		source = models.GamePatchSourceAdmin
+	}
+	patch := &models.GamePatch{
+		ID:         uuid.New(),
+		GameID:     game.ID,
+		Version:    strings.TrimSpace(req.Version),
+		Name:       req.Name,
+		NotesURL:   req.NotesURL,
+		ReleasedAt: req.ReleasedAt.UTC(),
+		Source:     source,
+		IGDBID:     req.IGDBID,
+		CreatedBy:  &userID,
+	}
+	if source == models.GamePatchSourceIGDB {
+		err = s.patchRepo.Upsert(ctx, patch)
+	} else {
+		err = s.patchRepo.Create(ctx, patch)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.retag(ctx, game.TwitchGameID)
+	return s.get(ctx, patch.ID)
+}
+
+// UpdatePatch changes a patch and retags its game's clips
+func (s *GamePatchService) UpdatePatch(ctx context.Context, patchID uuid.UUID, req *models.UpdateGamePatchRequest) (*models.GamePatch, error) {
+	patch, err := s.patchRepo.GetByID(ctx, patchID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Version != nil {
+		patch.Version = strings.TrimSpace(*req.Version)
+	}
+	if req.Name != nil {
+		patch.Name = req.Name
+	}
+	if req.NotesURL != nil {
+		patch.NotesURL = req.NotesURL
+	}
+	if req.ReleasedAt != nil {
+		patch.ReleasedAt = req.ReleasedAt.UTC()
+	}
+	if err := s.patchRepo.Update(ctx, patch); err != nil {
+		return nil, err
+	}
+
+	if game, err := s.gameRepo.GetByID(ctx, patch.GameID); err == nil {
+		s.retag(ctx, game.TwitchGameID)
+	}
+	return s.get(ctx, patch.ID)
+}
+
+// DeletePatch removes a patch and retags its clips with the patch before it
+func (s *GamePatchService) DeletePatch(ctx context.Context, patchID uuid.UUID) error {
+	patch, err := s.patchRepo.GetByID(ctx, patchID)
+	if err != nil {
+		return err
+	}
+	if err := s.patchRepo.Delete(ctx, patchID); err != nil {
+		return err
+	}
+	if game, err := s.gameRepo.GetByID(ctx, patch.GameID); err == nil {
+		s.retag(ctx, game.TwitchGameID)
+	}
+	return nil
+}
+
+// Window returns when a patch was in effect, for filtering search results
+// by patch
+func (s *GamePatchService) Window(ctx context.Context, patchID uuid.UUID) (*models.GamePatchWindow, error) {
+	patch, err := s.patchRepo.GetByID(ctx, patchID)
+	if err != nil {
+		return nil, err
+	}
+	game, err := s.gameRepo.GetByID(ctx, patch.GameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get patch game: %w", err)
+	}
+	return &models.GamePatchWindow{
+		TwitchGameID: game.TwitchGameID,
+		From:         patch.ReleasedAt,
+		Until:        patch.EndedAt,
+	}, nil
+}
+
+func (s *GamePatchService) get(ctx context.Context, patchID uuid.UUID) (*models.GamePatch, error) {
+	patch, err := s.patchRepo.GetByID(ctx, patchID)
+	if err != nil {
+		return nil, err
+	}
+	patch.IsCurrent = isCurrentPatch(patch, s.now())
+	return patch, nil
+}
+
+// retag tags a game's clips with their patches again. Failures are logged:
+// the patch change is saved and the next change retags the game again.
+func (s *GamePatchService) retag(ctx context.Context, twitchGameID string) {
+	retagged, err := s.patchRepo.RetagClips(ctx, twitchGameID)
+	if err != nil {
+		utils.Warn("Failed to retag clips with game patches", map[string]interface{}{
+			"game_id": twitchGameID,
+			"error":   err.Error(),
+		})
+		return
+	}
+	if retagged > 0 {
+		utils.Info("Retagged clips with game patches", map[string]interface{}{
+			"game_id": twitchGameID,
+			"clips":   retagged,
+		})
+	}
+}
+
+// isCurrentPatch reports whether a patch is in effect at now
+func isCurrentPatch(patch *models.GamePatch, now time.Time) bool {
+	if patch.ReleasedAt.After(now) {
+		return false
+	}
+	return patch.EndedAt == nil || patch.EndedAt.After(now)
+}
@@ -0,0 +1,22 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+func TestIsCurrentPatch(t *testing.T) {
+	now := time.Date(2026, 8, 1, 12, 0, 0, 0, time.UTC)
+	ended := now.Add(-time.Hour)
+	endsLater := now.Add(time.Hour)
+
+	assert.True(t, isCurrentPatch(&models.GamePatch{ReleasedAt: now.AddDate(0, -1, 0)}, now), "latest released patch")
+	assert.True(t, isCurrentPatch(&models.GamePatch{ReleasedAt: now}, now), "patch released just now")
+	assert.True(t, isCurrentPatch(&models.GamePatch{ReleasedAt: now.AddDate(0, -1, 0), EndedAt: &endsLater}, now),
+		"patch whose successor is scheduled")
+	assert.False(t, isCurrentPatch(&models.GamePatch{ReleasedAt: now.AddDate(0, -1, 0), EndedAt: &ended}, now), "superseded patch")
+	assert.False(t, isCurrentPatch(&models.GamePatch{ReleasedAt: endsLater}, now), "scheduled patch")
+}
@@ -0,0 +1,273 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const (
+	// healthProbeTimeout bounds one dependency probe; slower counts as down
+	healthProbeTimeout = 5 * time.Second
+	// healthCleanupInterval is how often probe results past retention are deleted
+	healthCleanupInterval = time.Hour
+)
+
+// HealthProbe checks that a dependency is reachable
+type HealthProbe func(ctx context.Context) error
+
+// HealthHistoryRepositoryInterface defines the repository methods used by HealthHistoryService
+type HealthHistoryRepositoryInterface interface {
+	RecordResults(ctx context.Context, results []models.HealthProbeResult) error
+	GetUptime(ctx context.Context, now time.Time) ([]models.HealthUptime, error)
+	GetProbeCounts(ctx context.Context, from, to time.Time) ([]models.HealthProbeCounts, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// endpointGroupTraffic counts one endpoint group's requests since the last probe
+type endpointGroupTraffic struct {
+	requests     int
+	serverErrors int
+}
+
+// HealthHistoryService keeps a history of health probes for uptime and SLA
+// reporting. Dependencies are probed directly; endpoint groups are judged by
+// the share of server errors in the traffic they served since the last probe.
+type HealthHistoryService struct {
+	repo               HealthHistoryRepositoryInterface
+	slaTargetPercent   float64
+	errorRateThreshold float64
+	retention          time.Duration
+	now                func() time.Time
+
+	probeNames []string
+	probes     map[string]HealthProbe
+
+	mu          sync.Mutex
+	traffic     map[string]*endpointGroupTraffic
+	lastCleanup time.Time
+}
+
+// NewHealthHistoryService creates a new HealthHistoryService. An endpoint
+// group is down for an interval when more than errorRateThreshold (0-1) of
+// its requests failed with a server error.
+func NewHealthHistoryService(repo HealthHistoryRepositoryInterface, slaTargetPercent, errorRateThreshold float64, retentionDays int) *HealthHistoryService {
+	return &HealthHistoryService{
+		repo:               repo,
+		slaTargetPercent:   slaTargetPercent,
+		errorRateThreshold: errorRateThreshold,
+		retention:          time.Duration(retentionDays) * 24 * time.Hour,
+		now:                time.Now,
+		probes:             make(map[string]HealthProbe),
+		traffic:            make(map[string]*endpointGroupTraffic),
+	}
+}
+
+// AddDependency registers a dependency to probe. Call before probing starts.
+func (s *HealthHistoryService) AddDependency(name string, probe HealthProbe) {
+	if _, ok := s.probes[name]; !ok {
+		s.probeNames = append(s.probeNames, name)
+	}
+	s.probes[name] = probe
+}
+
+// RecordRequest counts a served request toward its endpoint group. Requests
+// outside /api/v1 aren't grouped.
+func (s *HealthHistoryService) RecordRequest(route string, status int) {
+	group := endpointGroup(route)
+	if group == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.traffic[group]
+	if !ok {
+		t = &endpointGroupTraffic{}
+		s.traffic[group] = t
+	}
+	t.requests++
+	if status >= 500 {
+		t.serverErrors++
+	}
+}
+
+// endpointGroup returns the group of an /api/v1 route: its next path
+// segment, e.g. "clips" for /api/v1/clips/:id
+func endpointGroup(route string) string {
+	rest, ok := strings.CutPrefix(route, "/api/v1/")
+	if !ok {
+		return ""
+	}
+	group, _, _ := strings.Cut(rest, "/")
+	if group == "" || strings.HasPrefix(group, ":") || strings.HasPrefix(group, "*") {
+		return ""
+	}
+	return group
+}
+
+// ProbeAll probes every dependency, records the traffic endpoint groups served
+// since the last call, and deletes results past retention. It returns how
+// many results were recorded.
+func (s *HealthHistoryService) ProbeAll(ctx context.Context) (int, error) {
+	checkedAt := s.now()
+
+	results := make([]models.HealthProbeResult, len(s.probeNames))
+	var wg sync.WaitGroup
+	for i, name := range s.probeNames {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			results[i] = s.probeDependency(ctx, name, checkedAt)
+		}(i, name)
+	}
+	wg.Wait()
+
+	results = append(results, s.takeTraffic(checkedAt)...)
+
+	if err := s.repo.RecordResults(ctx, results); err != nil {
+		return 0, fmt.Errorf("failed to record health probes: %w", err)
+	}
+
+	s.cleanup(ctx, checkedAt)
+	return len(results), nil
+}
+
+// probeDependency runs one dependency's probe with a timeout
+func (s *HealthHistoryService) probeDependency(ctx context.Context, name string, checkedAt time.Time) models.HealthProbeResult {
+	probeCtx, cancel := context.WithTimeout(ctx, healthProbeTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := s.probes[name](probeCtx)
+	latency := int(time.Since(start).Milliseconds())
+
+	result := models.HealthProbeResult{
+		TargetType: models.HealthTargetDependency,
+		Target:     name,
+		Healthy:    err == nil,
+		LatencyMs:  &latency,
+		CheckedAt:  checkedAt,
+	}
+	if err != nil {
+		msg := err.Error()
+		result.Error = &msg
+	}
+	return result
+}
+
+// takeTraffic turns the traffic counted since the last call into results,
+// one per endpoint group that served requests, and starts counting afresh
+func (s *HealthHistoryService) takeTraffic(checkedAt time.Time) []models.HealthProbeResult {
+	s.mu.Lock()
+	traffic := s.traffic
+	s.traffic = make(map[string]*endpointGroupTraffic)
+	s.mu.Unlock()
+
+	groups := make([]string, 0, len(traffic))
+	for group := range traffic {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	results := make([]models.HealthProbeResult, 0, len(groups))
+	for _, group := range groups {
+		t := traffic[group]
+		results = append(results, models.HealthProbeResult{
+			TargetType:   models.HealthTargetEndpointGroup,
+			Target:       group,
+			Healthy:      float64(t.serverErrors) <= s.errorRateThreshold*float64(t.requests),
+			Requests:     t.requests,
+			ServerErrors: t.serverErrors,
+			CheckedAt:    checkedAt,
+		})
+	}
+	return results
+}
+
+// cleanup deletes results past retention, at most once per cleanup interval
+func (s *HealthHistoryService) cleanup(ctx context.Context, now time.Time) {
+	if s.retention <= 0 || now.Sub(s.lastCleanup) < healthCleanupInterval {
+		return
+	}
+	s.lastCleanup = now
+
+	deleted, err := s.repo.DeleteBefore(ctx, now.Add(-s.retention))
+	if err != nil {
+		utils.Warn("Failed to delete old health probe results", map[string]interface{}{"error": err})
+		return
+	}
+	if deleted > 0 {
+		utils.Info("Deleted old health probe results", map[string]interface{}{"count": deleted})
+	}
+}
+
+// GetUptime returns the 24-hour, 7-day and 30-day uptime of every dependency
+// and endpoint group probed in the last 30 days
+func (s *HealthHistoryService) GetUptime(ctx context.Context) ([]models.HealthUptime, error) {
+	uptimes, err := s.repo.GetUptime(ctx, s.now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get uptime: %w", err)
+	}
+	return uptimes, nil
+}
+
+// GetSLAReport reports whether each target met the uptime SLA over the last
+// given number of days
+func (s *HealthHistoryService) GetSLAReport(ctx context.Context, days int) (*models.HealthSLAReport, error) {
+	to := s.now()
+	from := to.AddDate(0, 0, -days)
+
+	counts, err := s.repo.GetProbeCounts(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SLA report: %w", err)
+	}
+
+	report := &models.HealthSLAReport{
+		From:          from,
+		To:            to,
+		TargetPercent: s.slaTargetPercent,
+		Targets:       make([]models.HealthSLATarget, 0, len(counts)),
+		Attained:      true,
+	}
+	for _, c := range counts {
+		target := slaTarget(c, s.slaTargetPercent)
+		if !target.Met {
+			report.Attained = false
+		}
+		report.Targets = append(report.Targets, target)
+	}
+	return report, nil
+}
+
+// slaTarget measures one target's probe counts against the SLA
+func slaTarget(c models.HealthProbeCounts, targetPercent float64) models.HealthSLATarget {
+	uptime := 100.0
+	if c.Probes > 0 {
+		uptime = 100 * float64(c.Probes-c.Failed) / float64(c.Probes)
+	}
+
+	// The error budget is the failures the SLA allows in this many probes.
+	// Targets are below 100%, so any probes leave some budget.
+	var used float64
+	if budget := float64(c.Probes) * (100 - targetPercent) / 100; budget > 0 {
+		used = 100 * float64(c.Failed) / budget
+	}
+
+	return models.HealthSLATarget{
+		TargetType:      c.TargetType,
+		Target:          c.Target,
+		Probes:          c.Probes,
+		FailedProbes:    c.Failed,
+		UptimePercent:   math.Round(uptime*1000) / 1000,
+		ErrorBudgetUsed: math.Round(used*10) / 10,
+		Met:             uptime >= targetPercent,
+	}
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// MockHealthHistoryRepository is a mock implementation of HealthHistoryRepositoryInterface
+type MockHealthHistoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockHealthHistoryRepository) RecordResults(ctx context.Context, results []models.HealthProbeResult) error {
+	args := m.Called(ctx, results)
+	return args.Error(0)
+}
+
+func (m *MockHealthHistoryRepository) GetUptime(ctx context.Context, now time.Time) ([]models.HealthUptime, error) {
+	args := m.Called(ctx, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.HealthUptime), args.Error(1)
+}
+
+func (m *MockHealthHistoryRepository) GetProbeCounts(ctx context.Context, from, to time.Time) ([]models.HealthProbeCounts, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.HealthProbeCounts), args.Error(1)
+}
+
+func (m *MockHealthHistoryRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestHealthHistoryProbeAll(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	repo := new(MockHealthHistoryRepository)
+	var recorded []models.HealthProbeResult
+	repo.On("RecordResults", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		recorded = args.Get(1).([]models.HealthProbeResult)
+	}).Return(nil).Once()
+	repo.On("DeleteBefore", mock.Anything, now.AddDate(0, 0, -90)).Return(int64(0), nil).Once()
+	svc := NewHealthHistoryService(repo, 99.9, 0.05, 90)
+	svc.now = func() time.Time { return now }
+	svc.AddDependency("database", func(ctx context.Context) error { return nil })
+	svc.AddDependency("redis", func(ctx context.Context) error { return errors.New("connection refused") })
+
+	for i := 0; i < 20; i++ {
+		svc.RecordRequest("/api/v1/clips/:id", 200)
+		svc.RecordRequest("/api/v1/search", 200)
+	}
+	svc.RecordRequest("/api/v1/clips/:id", 500)
+	svc.RecordRequest("/api/v1/search", 503)
+	svc.RecordRequest("/api/v1/search", 502)
+	svc.RecordRequest("/health", 500)
+
+	count, err := svc.ProbeAll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+	require.Len(t, recorded, 4)
+
+	db, redis, clips, search := recorded[0], recorded[1], recorded[2], recorded[3]
+	assert.Equal(t, "database", db.Target)
+	assert.True(t, db.Healthy)
+	assert.NotNil(t, db.LatencyMs)
+	assert.Equal(t, "redis", redis.Target)
+	assert.False(t, redis.Healthy)
+	require.NotNil(t, redis.Error)
+	assert.Equal(t, "connection refused", *redis.Error)
+
+	assert.Equal(t, models.HealthTargetEndpointGroup, clips.TargetType)
+	assert.Equal(t, "clips", clips.Target)
+	assert.Equal(t, 21, clips.Requests)
+	assert.True(t, clips.Healthy, "1 of 21 failed, under the 5% threshold")
+	assert.Equal(t, "search", search.Target)
+	assert.Equal(t, 2, search.ServerErrors)
+	assert.False(t, search.Healthy, "2 of 22 failed, over the 5% threshold")
+
+	// Traffic counts start afresh, and cleanup waits for its interval
+	repo.On("RecordResults", mock.Anything, mock.Anything).Return(nil).Once()
+	now = now.Add(time.Minute)
+	count, err = svc.ProbeAll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, count, "only dependencies without new traffic")
+	repo.AssertNumberOfCalls(t, "DeleteBefore", 1)
+	repo.AssertExpectations(t)
+}
+
+func TestHealthHistorySLAReport(t *testing.T) {
+	repo := new(MockHealthHistoryRepository)
+	repo.On("GetProbeCounts", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return([]models.HealthProbeCounts{
+		{TargetType: models.HealthTargetDependency, Target: "database", Probes: 10000, Failed: 5},
+		{TargetType: models.HealthTargetDependency, Target: "redis", Probes: 10000, Failed: 20},
+		{TargetType: models.HealthTargetEndpointGroup, Target: "clips", Probes: 10000, Failed: 0},
+	}, nil).Once()
+	svc := NewHealthHistoryService(repo, 99.9, 0.05, 90)
+
+	report, err := svc.GetSLAReport(context.Background(), 30)
+	require.NoError(t, err)
+	assert.Equal(t, 30*24*time.Hour, report.To.Sub(report.From))
+	assert.False(t, report.Attained)
+	require.Len(t, report.Targets, 3)
+
+	database, redis, clips := report.Targets[0], report.Targets[1], report.Targets[2]
+	assert.True(t, database.Met)
+	assert.Equal(t, 99.95, database.UptimePercent)
+	assert.Equal(t, 50.0, database.ErrorBudgetUsed)
+	assert.False(t, redis.Met)
+	assert.Equal(t, 99.8, redis.UptimePercent)
+	assert.Equal(t, 200.0, redis.ErrorBudgetUsed)
+	assert.True(t, clips.Met)
+	assert.Zero(t, clips.ErrorBudgetUsed)
+}
+
+func TestEndpointGroup(t *testing.T) {
+	tests := map[string]string{
+		"/api/v1/clips/:id/comments": "clips",
+		"/api/v1/search":             "search",
+		"/api/v1/":                   "",
+		"/api/v1/:id":                "",
+		"/health/ready":              "",
+		"/embed/clips/:id":           "",
+	}
+	for route, want := range tests {
+		assert.Equal(t, want, endpointGroup(route), route)
+	}
+}
@@ -1,6 +1,12 @@
 package services
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+)
 
 // stringPtr is a helper to create string pointers
 func stringPtr(s string) *string {
@@ -23,3 +29,22 @@ func formatFileSize(bytes int64) string {
 	}
 	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
 }
+
+// userByIDReader looks up a user by ID
+type userByIDReader interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+}
+
+// verifiedBroadcasterID returns a user's Twitch channel ID if they are a
+// verified broadcaster, or notVerified if they are not
+func verifiedBroadcasterID(ctx context.Context, users userByIDReader, userID uuid.UUID, notVerified error) (string, error) {
+	user, err := users.GetByID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+	if !user.IsVerified || user.TwitchID == nil || *user.TwitchID == "" {
+		return "", notVerified
+	}
+
+	return *user.TwitchID, nil
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math/rand"
+	"sort"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/pgvector/pgvector-go"
 	"github.com/redis/go-redis/v9"
@@ -18,32 +21,239 @@ type HybridSearchService struct {
 	pool              *pgxpool.Pool
 	openSearchService *OpenSearchService
 	embeddingService  *EmbeddingService
-	redisClient       *redis.Client
+	redisClient       redis.UniversalClient
+	weights           *SearchWeightConfig
+	openSearchKNN     bool
+	personalization   *SearchPersonalizationWeights
+	personalizer      SearchPersonalizer
+	ltr               *SearchLTRService
+	embeddingColumn   string
+	dualRead          *EmbeddingDualReadConfig
 }
 
+// EmbeddingDualReadConfig configures comparing search rankings against the
+// clips column an embedding model migration is filling
+type EmbeddingDualReadConfig struct {
+	Column           string
+	EmbeddingService *EmbeddingService // The model the column is filled with
+	SampleRate       float64           // Share of searches compared, from 0 to 1
+}
+
+// embeddingDualReadTimeout bounds a dual-read comparison, which runs after
+// the search has been answered
+const embeddingDualReadTimeout = 10 * time.Second
+
 // HybridSearchConfig holds configuration for hybrid search
 type HybridSearchConfig struct {
 	Pool              *pgxpool.Pool
 	OpenSearchService *OpenSearchService
 	EmbeddingService  *EmbeddingService
-	RedisClient       *redis.Client
+	RedisClient       redis.UniversalClient
+
+	// Weights blends BM25 rank and vector similarity when re-ranking and sets
+	// the OpenSearch boosts. Nil re-ranks BM25 candidates by vector similarity alone.
+	Weights *SearchWeightConfig
+
+	// OpenSearchKNN runs relevance searches as one OpenSearch hybrid query
+	// fused with RRF instead of re-ranking BM25 candidates in Postgres. It
+	// requires clip embeddings indexed in OpenSearch, and weighted ranking
+	// keeps the pgvector path.
+	OpenSearchKNN bool
+
+	// Personalization re-ranks relevance results for signed-in users by the
+	// profile Personalizer loads. Nil turns personalization off.
+	Personalization *SearchPersonalizationWeights
+	Personalizer    SearchPersonalizer
+
+	// LTR re-ranks the top fused candidates of relevance searches with a
+	// learning-to-rank model for its A/B test's treatment arm. Nil turns
+	// learning-to-rank off.
+	LTR *SearchLTRService
+
+	// EmbeddingColumn is the clips column re-ranking reads, default
+	// "embedding". EmbeddingService must embed queries with the model that
+	// filled it, so both change together.
+	EmbeddingColumn string
+
+	// DualRead also ranks a sample of searches by the column an embedding
+	// migration is filling and records how well the rankings agree. Nil
+	// turns it off.
+	DualRead *EmbeddingDualReadConfig
 }
 
 // NewHybridSearchService creates a new hybrid search service
 func NewHybridSearchService(config *HybridSearchConfig) *HybridSearchService {
-	return &HybridSearchService{
+	s := &HybridSearchService{
 		pool:              config.Pool,
 		openSearchService: config.OpenSearchService,
 		embeddingService:  config.EmbeddingService,
 		redisClient:       config.RedisClient,
+		openSearchKNN:     config.OpenSearchKNN,
+		personalizer:      config.Personalizer,
+		ltr:               config.LTR,
+		embeddingColumn:   config.EmbeddingColumn,
+		dualRead:          config.DualRead,
+	}
+	if s.embeddingColumn == "" {
+		s.embeddingColumn = models.DefaultClipEmbeddingColumn
+	}
+	if config.Personalization != nil {
+		personalization := *config.Personalization
+		s.personalization = &personalization
 	}
+	if config.Weights != nil {
+		weights := *config.Weights
+		s.weights = &weights
+		if s.openSearchService != nil {
+			s.openSearchService = s.openSearchService.WithRankingWeights(weights)
+		}
+	}
+	return s
+}
+
+// WithWeights returns a hybrid search service on the same connections that
+// ranks with weights
+func (s *HybridSearchService) WithWeights(weights SearchWeightConfig) *HybridSearchService {
+	return NewHybridSearchService(&HybridSearchConfig{
+		Pool:              s.pool,
+		OpenSearchService: s.openSearchService,
+		EmbeddingService:  s.embeddingService,
+		RedisClient:       s.redisClient,
+		Weights:           &weights,
+		OpenSearchKNN:     s.openSearchKNN,
+		Personalization:   s.personalization,
+		Personalizer:      s.personalizer,
+		LTR:               s.ltr,
+		EmbeddingColumn:   s.embeddingColumn,
+		DualRead:          s.dualRead,
+	})
 }
 
-// Search performs hybrid search combining BM25 and vector similarity
+// Search performs hybrid search combining BM25 and vector similarity. When
+// req.UserID is set, relevance results are personalized for that user.
 func (s *HybridSearchService) Search(ctx context.Context, req *models.SearchRequest) (*models.SearchResponse, error) {
+	return s.SearchWithProfile(ctx, req, s.personalizationProfile(ctx, req))
+}
+
+// SearchWithProfile performs hybrid search and re-ranks the clips of a
+// relevance search by profile. A nil profile, or personalization being off,
+// leaves the ranking unchanged. In the learning-to-rank arm, the model
+// re-ranks the clips instead, with the profile as one of its features.
+func (s *HybridSearchService) SearchWithProfile(ctx context.Context, req *models.SearchRequest, profile *models.SearchPersonalizationProfile) (*models.SearchResponse, error) {
+	ranker := s.ranker(req)
+	if ranker == models.SearchRankerLTR {
+		result, err := s.searchWithLTR(ctx, req, profile)
+		if err == nil {
+			return result, nil
+		}
+		// The fallback ranking isn't the control arm's, so it's left out of the test
+		log.Printf("Warning: learning-to-rank re-ranking failed, searching without it: %v", err)
+		metrics.SearchFallbackTotal.WithLabelValues("ltr_error").Inc()
+		ranker = ""
+	}
+
+	result, err := s.search(ctx, req)
+	if err != nil {
+		return result, err
+	}
+	result.Ranker = ranker
+	if !s.personalizes(req, profile) || len(result.Results.Clips) == 0 {
+		return result, nil
+	}
+
+	result.Results.Clips = personalizeClips(result.Results.Clips, profile, *s.personalization)
+	result.Personalized = true
+	return result, nil
+}
+
+// personalizes reports whether results for req are re-ranked by profile.
+// Only relevance ranking is personalized; the other sorts are explicit orders.
+func (s *HybridSearchService) personalizes(req *models.SearchRequest, profile *models.SearchPersonalizationProfile) bool {
+	return s.personalization != nil && !profile.IsEmpty() && (req.Sort == "" || req.Sort == "relevance")
+}
+
+// ranker returns the learning-to-rank A/B arm of req, or "" when it isn't
+// part of the test: learning-to-rank is off, or req isn't a relevance
+// search with a query whose page falls within the re-ranked candidates
+func (s *HybridSearchService) ranker(req *models.SearchRequest) string {
+	if s.ltr == nil || req.Query == "" || (req.Sort != "" && req.Sort != "relevance") {
+		return ""
+	}
+	if req.Page*req.Limit > LTRCandidates {
+		return ""
+	}
+	return s.ltr.Ranker(req.UserID)
+}
+
+// searchWithLTR fetches the top fused candidates of req, has the
+// learning-to-rank model re-rank them and returns the requested page
+func (s *HybridSearchService) searchWithLTR(ctx context.Context, req *models.SearchRequest, profile *models.SearchPersonalizationProfile) (*models.SearchResponse, error) {
+	candidateReq := *req
+	candidateReq.Page = 1
+	candidateReq.Limit = LTRCandidates
+	candidates, err := s.search(ctx, &candidateReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// Follow affinity is a personalization signal, so it honors the same switches
+	if !s.personalizes(req, profile) {
+		profile = nil
+	}
+	ranked, err := s.ltr.Rerank(ctx, req.Query, candidates.Results.Clips, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	start := min((req.Page-1)*req.Limit, len(ranked))
+	end := min(start+req.Limit, len(ranked))
+	clips := ranked[start:end]
+
+	response := *candidates
+	response.Results.Clips = clips
+	response.Highlights = highlightsForClips(candidates.Highlights, clips)
+	response.Meta.Page = req.Page
+	response.Meta.Limit = req.Limit
+	if req.Limit > 0 {
+		response.Meta.TotalPages = (response.Meta.TotalItems + req.Limit - 1) / req.Limit
+	}
+	response.Personalized = !profile.IsEmpty()
+	response.Ranker = models.SearchRankerLTR
+	return &response, nil
+}
+
+// personalizationProfile loads the profile of the user searching, or nil
+// for anonymous searches and searches with personalization=off. Failing to
+// load it only skips personalization.
+func (s *HybridSearchService) personalizationProfile(ctx context.Context, req *models.SearchRequest) *models.SearchPersonalizationProfile {
+	if s.personalization == nil || s.personalizer == nil || req.UserID == nil {
+		return nil
+	}
+	if req.Sort != "" && req.Sort != "relevance" {
+		return nil
+	}
+	if req.Personalization == models.SearchPersonalizationOff {
+		return nil
+	}
+
+	profile, err := s.personalizer.ProfileFor(ctx, *req.UserID)
+	if err != nil {
+		log.Printf("Warning: failed to load search personalization profile, searching without it: %v", err)
+		return nil
+	}
+	return profile
+}
+
+// search runs the hybrid search without personalization
+func (s *HybridSearchService) search(ctx context.Context, req *models.SearchRequest) (*models.SearchResponse, error) {
 	searchStart := time.Now()
 	searchType := "hybrid"
 
+	// Resolve the query's language once for the searches below
+	if req.QueryLanguage == "" {
+		req.QueryLanguage = ResolveQueryLanguage(req)
+	}
+
 	// If semantic search is disabled or embedding service not available, fall back to BM25 only
 	if s.embeddingService == nil || req.Query == "" {
 		searchType = "bm25"
@@ -52,6 +262,17 @@ func (s *HybridSearchService) Search(ctx context.Context, req *models.SearchRequ
 		return result, err
 	}
 
+	// Fuse BM25 and kNN in OpenSearch when embeddings are indexed there
+	if s.useOpenSearchKNN(req) {
+		result, err := s.searchWithOpenSearchKNN(ctx, req)
+		if err == nil {
+			s.recordSearchMetrics(searchType, searchStart, result, nil)
+			return result, nil
+		}
+		log.Printf("Warning: OpenSearch kNN search failed, falling back to pgvector re-ranking: %v", err)
+		metrics.SearchFallbackTotal.WithLabelValues("knn_error").Inc()
+	}
+
 	// Get BM25 candidates and query embedding
 	candidates, queryEmbedding, err := s.getBM25CandidatesWithEmbedding(ctx, req)
 	if err != nil {
@@ -77,7 +298,12 @@ func (s *HybridSearchService) Search(ctx context.Context, req *models.SearchRequ
 
 	// Re-rank using vector similarity - note: no offset, we select from all candidates
 	vectorStart := time.Now()
-	rerankedClips, err := s.rerankByVectorSimilarity(ctx, candidateIDs, queryEmbedding, req.Limit, 0)
+	var rerankedClips []models.Clip
+	if s.weights != nil {
+		rerankedClips, err = s.rerankByWeightedScore(ctx, candidates.Results.Clips, candidateIDs, queryEmbedding, req.Limit, 0)
+	} else {
+		rerankedClips, err = s.rerankByVectorSimilarity(ctx, candidateIDs, queryEmbedding, req.Limit, 0)
+	}
 	metrics.VectorSearchDuration.Observe(float64(time.Since(vectorStart).Milliseconds()))
 
 	if err != nil {
@@ -90,6 +316,10 @@ func (s *HybridSearchService) Search(ctx context.Context, req *models.SearchRequ
 		return result, err
 	}
 
+	if s.sampleDualRead() {
+		go s.compareDualRead(embeddingQueryFor(req, candidates), candidateIDs, queryEmbedding, req.Limit)
+	}
+
 	// Step 5: Build response
 	response := &models.SearchResponse{
 		Query: req.Query,
@@ -105,6 +335,9 @@ func (s *HybridSearchService) Search(ctx context.Context, req *models.SearchRequ
 			Limit:      req.Limit,
 			TotalItems: candidates.Counts.Clips,
 		},
+		DidYouMean:    candidates.DidYouMean,
+		AutoCorrected: candidates.AutoCorrected,
+		Highlights:    highlightsForClips(candidates.Highlights, rerankedClips),
 	}
 
 	// Calculate total pages
@@ -116,6 +349,27 @@ func (s *HybridSearchService) Search(ctx context.Context, req *models.SearchRequ
 	return response, nil
 }
 
+// useOpenSearchKNN reports whether req can be served by a single OpenSearch
+// hybrid query. RRF only ranks by relevance, and weighted ranking blends raw
+// similarity, so both keep the pgvector path.
+func (s *HybridSearchService) useOpenSearchKNN(req *models.SearchRequest) bool {
+	return s.openSearchKNN && s.weights == nil && (req.Sort == "" || req.Sort == "relevance")
+}
+
+// searchWithOpenSearchKNN embeds the query and runs the BM25 and kNN
+// sub-queries in OpenSearch, which fuses them with RRF
+func (s *HybridSearchService) searchWithOpenSearchKNN(ctx context.Context, req *models.SearchRequest) (*models.SearchResponse, error) {
+	queryEmbedding, err := s.embeddingService.GenerateEmbedding(ctx, req.Query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate query embedding: %w", err)
+	}
+
+	vectorStart := time.Now()
+	result, err := s.openSearchService.SearchClipsHybrid(ctx, req, queryEmbedding)
+	metrics.VectorSearchDuration.Observe(float64(time.Since(vectorStart).Milliseconds()))
+	return result, err
+}
+
 // getBM25CandidatesWithEmbedding retrieves BM25 candidates and generates query embedding
 // This helper method extracts common logic used by both Search and SearchWithScores
 func (s *HybridSearchService) getBM25CandidatesWithEmbedding(ctx context.Context, req *models.SearchRequest) (*models.SearchResponse, []float32, error) {
@@ -142,8 +396,7 @@ func (s *HybridSearchService) getBM25CandidatesWithEmbedding(ctx context.Context
 		return nil, nil, fmt.Errorf("BM25 search failed: %w", err)
 	}
 
-	// Generate query embedding
-	queryEmbedding, err := s.embeddingService.GenerateEmbedding(ctx, req.Query)
+	queryEmbedding, err := s.embeddingService.GenerateEmbedding(ctx, embeddingQueryFor(req, bm25Results))
 	if err != nil {
 		log.Printf("Warning: failed to generate query embedding: %v", err)
 		return nil, nil, err
@@ -152,6 +405,101 @@ func (s *HybridSearchService) getBM25CandidatesWithEmbedding(ctx context.Context
 	return bm25Results, queryEmbedding, nil
 }
 
+// embeddingQueryFor returns the text a search's query embedding is generated
+// from: the corrected query when BM25 searched that instead
+func embeddingQueryFor(req *models.SearchRequest, bm25Results *models.SearchResponse) string {
+	if bm25Results.AutoCorrected && bm25Results.DidYouMean != nil {
+		return *bm25Results.DidYouMean
+	}
+	return req.Query
+}
+
+// sampleDualRead reports whether this search's ranking is compared with the
+// column an embedding migration is filling
+func (s *HybridSearchService) sampleDualRead() bool {
+	return s.dualRead != nil && s.dualRead.EmbeddingService != nil && rand.Float64() < s.dualRead.SampleRate
+}
+
+// compareDualRead ranks a search's candidates by the active column and by
+// the column being migrated to, and records how much their top results
+// overlap. It runs after the search has been answered, so failures are only
+// counted.
+func (s *HybridSearchService) compareDualRead(query string, candidateIDs []string, queryEmbedding []float32, limit int) {
+	ctx, cancel := context.WithTimeout(context.Background(), embeddingDualReadTimeout)
+	defer cancel()
+
+	active, err := s.rankCandidateIDs(ctx, s.embeddingColumn, candidateIDs, queryEmbedding, limit)
+	if err != nil {
+		metrics.EmbeddingDualReadTotal.WithLabelValues("error").Inc()
+		return
+	}
+	nextEmbedding, err := s.dualRead.EmbeddingService.GenerateEmbedding(ctx, query)
+	if err != nil {
+		metrics.EmbeddingDualReadTotal.WithLabelValues("error").Inc()
+		return
+	}
+	next, err := s.rankCandidateIDs(ctx, s.dualRead.Column, candidateIDs, nextEmbedding, limit)
+	if err != nil {
+		metrics.EmbeddingDualReadTotal.WithLabelValues("error").Inc()
+		return
+	}
+
+	metrics.EmbeddingDualReadTotal.WithLabelValues("success").Inc()
+	metrics.EmbeddingDualReadOverlap.Observe(rankingOverlap(active, next))
+}
+
+// rankCandidateIDs returns the IDs of the candidates closest to
+// queryEmbedding by the given embedding column
+func (s *HybridSearchService) rankCandidateIDs(ctx context.Context, column string, candidateIDs []string, queryEmbedding []float32, limit int) ([]uuid.UUID, error) {
+	query := fmt.Sprintf(`
+		SELECT id
+		FROM clips
+		WHERE id = ANY($2)
+			AND %[1]s IS NOT NULL
+			AND is_removed = false
+		ORDER BY %[1]s <=> $1
+		LIMIT $3
+	`, column)
+
+	rows, err := s.pool.Query(ctx, query, pgvector.NewVector(queryEmbedding), candidateIDs, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rank by %s: %w", column, err)
+	}
+	defer rows.Close()
+
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan clip ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// rankingOverlap returns the share of active's results that next also
+// returned. Two empty rankings agree.
+func rankingOverlap(active, next []uuid.UUID) float64 {
+	if len(active) == 0 {
+		if len(next) == 0 {
+			return 1
+		}
+		return 0
+	}
+	inNext := make(map[uuid.UUID]bool, len(next))
+	for _, id := range next {
+		inNext[id] = true
+	}
+	shared := 0
+	for _, id := range active {
+		if inNext[id] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(active))
+}
+
 // rerankByVectorSimilarity re-ranks clips using pgvector similarity
 func (s *HybridSearchService) rerankByVectorSimilarity(ctx context.Context, candidateIDs []string, queryEmbedding []float32, limit, offset int) ([]models.Clip, error) {
 	if len(candidateIDs) == 0 {
@@ -163,21 +511,21 @@ func (s *HybridSearchService) rerankByVectorSimilarity(ctx context.Context, cand
 
 	// Query with vector similarity re-ranking
 	// Using cosine distance operator <=> for similarity
-	query := `
+	query := fmt.Sprintf(`
 		SELECT 
 			id, twitch_clip_id, twitch_clip_url, embed_url, title,
 			creator_name, creator_id, broadcaster_name, broadcaster_id,
 			game_id, game_name, language, thumbnail_url, duration,
 			view_count, created_at, imported_at, vote_score, comment_count,
 			favorite_count, is_featured, is_nsfw, is_removed, removed_reason,
-			embedding <=> $1 AS similarity_distance
+			%[1]s <=> $1 AS similarity_distance
 		FROM clips
 		WHERE id = ANY($2)
-			AND embedding IS NOT NULL
+			AND %[1]s IS NOT NULL
 			AND is_removed = false
-		ORDER BY embedding <=> $1
+		ORDER BY %[1]s <=> $1
 		LIMIT $3 OFFSET $4
-	`
+	`, s.embeddingColumn)
 
 	rows, err := s.pool.Query(ctx, query, queryVector, candidateIDs, limit, offset)
 	if err != nil {
@@ -214,8 +562,88 @@ func (s *HybridSearchService) rerankByVectorSimilarity(ctx context.Context, cand
 	return clips, nil
 }
 
+// rerankByWeightedScore re-ranks BM25 candidates by a weighted blend of their
+// BM25 rank and vector similarity
+func (s *HybridSearchService) rerankByWeightedScore(ctx context.Context, candidates []models.Clip, candidateIDs []string, queryEmbedding []float32, limit, offset int) ([]models.Clip, error) {
+	query := fmt.Sprintf(`
+		SELECT id, %[1]s <=> $1 AS similarity_distance
+		FROM clips
+		WHERE id = ANY($2)
+			AND %[1]s IS NOT NULL
+	`, s.embeddingColumn)
+
+	rows, err := s.pool.Query(ctx, query, pgvector.NewVector(queryEmbedding), candidateIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query vector similarity: %w", err)
+	}
+	defer rows.Close()
+
+	distances := make(map[uuid.UUID]float64, len(candidateIDs))
+	for rows.Next() {
+		var id uuid.UUID
+		var distance float64
+		if err := rows.Scan(&id, &distance); err != nil {
+			return nil, fmt.Errorf("failed to scan similarity: %w", err)
+		}
+		distances[id] = distance
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating similarities: %w", err)
+	}
+
+	return weightedRerank(candidates, distances, *s.weights, limit, offset), nil
+}
+
+// weightedRerank orders candidates by BM25Weight * BM25 score + VectorWeight *
+// similarity. OpenSearch results don't carry their scores, so the BM25 score
+// is the candidate's rank scaled to (0,1]. Similarity is 1 - distance/2 as in
+// rerankByVectorSimilarityWithScores; candidates without an embedding get 0.
+func weightedRerank(candidates []models.Clip, distances map[uuid.UUID]float64, weights SearchWeightConfig, limit, offset int) []models.Clip {
+	type scoredClip struct {
+		clip  models.Clip
+		score float64
+	}
+
+	n := len(candidates)
+	scored := make([]scoredClip, n)
+	for i, clip := range candidates {
+		bm25Score := 1.0 - float64(i)/float64(n)
+		var similarity float64
+		if distance, ok := distances[clip.ID]; ok {
+			similarity = 1.0 - distance/2.0
+		}
+		scored[i] = scoredClip{
+			clip:  clip,
+			score: weights.BM25Weight*bm25Score + weights.VectorWeight*similarity,
+		}
+	}
+
+	// Stable so ties keep BM25 order
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].score > scored[j].score
+	})
+
+	if offset >= n {
+		return []models.Clip{}
+	}
+	end := n
+	if limit > 0 && offset+limit < n {
+		end = offset + limit
+	}
+
+	clips := make([]models.Clip, 0, end-offset)
+	for _, sc := range scored[offset:end] {
+		clips = append(clips, sc.clip)
+	}
+	return clips
+}
+
 // SearchWithScores performs hybrid search and includes similarity scores in response
 func (s *HybridSearchService) SearchWithScores(ctx context.Context, req *models.SearchRequest) (*models.SearchResponseWithScores, error) {
+	if req.QueryLanguage == "" {
+		req.QueryLanguage = ResolveQueryLanguage(req)
+	}
+
 	// If semantic search is disabled or embedding service not available, fall back to BM25 only
 	if s.embeddingService == nil || req.Query == "" {
 		baseResponse, err := s.openSearchService.Search(ctx, req)
@@ -288,6 +716,9 @@ func (s *HybridSearchService) SearchWithScores(ctx context.Context, req *models.
 				Limit:      req.Limit,
 				TotalItems: candidates.Counts.Clips,
 			},
+			DidYouMean:    candidates.DidYouMean,
+			AutoCorrected: candidates.AutoCorrected,
+			Highlights:    highlightsForClips(candidates.Highlights, rerankedClips),
 		},
 		Scores: scores,
 	}
@@ -307,21 +738,21 @@ func (s *HybridSearchService) rerankByVectorSimilarityWithScores(ctx context.Con
 
 	queryVector := pgvector.NewVector(queryEmbedding)
 
-	query := `
+	query := fmt.Sprintf(`
 		SELECT 
 			id, twitch_clip_id, twitch_clip_url, embed_url, title,
 			creator_name, creator_id, broadcaster_name, broadcaster_id,
 			game_id, game_name, language, thumbnail_url, duration,
 			view_count, created_at, imported_at, vote_score, comment_count,
 			favorite_count, is_featured, is_nsfw, is_removed, removed_reason,
-			embedding <=> $1 AS similarity_distance
+			%[1]s <=> $1 AS similarity_distance
 		FROM clips
 		WHERE id = ANY($2)
-			AND embedding IS NOT NULL
+			AND %[1]s IS NOT NULL
 			AND is_removed = false
-		ORDER BY embedding <=> $1
+		ORDER BY %[1]s <=> $1
 		LIMIT $3 OFFSET $4
-	`
+	`, s.embeddingColumn)
 
 	rows, err := s.pool.Query(ctx, query, queryVector, candidateIDs, limit, offset)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/subculture-collective/clipper/internal/models"
 )
@@ -36,6 +37,98 @@ func TestHybridSearchService_NewHybridSearchService(t *testing.T) {
 	assert.Nil(t, service.redisClient)
 }
 
+func TestHybridSearchService_WithWeights(t *testing.T) {
+	base := NewHybridSearchService(&HybridSearchConfig{
+		OpenSearchService: &OpenSearchService{},
+	})
+	assert.Nil(t, base.weights)
+
+	weighted := base.WithWeights(DefaultConfigs()[1])
+	if assert.NotNil(t, weighted.weights) {
+		assert.Equal(t, 0.6, weighted.weights.VectorWeight)
+	}
+	if assert.NotNil(t, weighted.openSearchService.weights) {
+		assert.Equal(t, "semantic-heavy", weighted.openSearchService.weights.Name)
+	}
+	assert.Nil(t, base.openSearchService.weights, "base service must keep its own boosts")
+}
+
+func TestHybridSearchService_UseOpenSearchKNN(t *testing.T) {
+	disabled := NewHybridSearchService(&HybridSearchConfig{OpenSearchService: &OpenSearchService{}})
+	assert.False(t, disabled.useOpenSearchKNN(&models.SearchRequest{Query: "clutch"}))
+
+	enabled := NewHybridSearchService(&HybridSearchConfig{
+		OpenSearchService: &OpenSearchService{},
+		OpenSearchKNN:     true,
+	})
+	assert.True(t, enabled.useOpenSearchKNN(&models.SearchRequest{Query: "clutch"}))
+	assert.True(t, enabled.useOpenSearchKNN(&models.SearchRequest{Query: "clutch", Sort: "relevance"}))
+	assert.False(t, enabled.useOpenSearchKNN(&models.SearchRequest{Query: "clutch", Sort: "recent"}),
+		"RRF only ranks by relevance")
+
+	weighted := enabled.WithWeights(DefaultConfigs()[1])
+	assert.True(t, weighted.openSearchKNN)
+	assert.False(t, weighted.useOpenSearchKNN(&models.SearchRequest{Query: "clutch"}),
+		"weighted ranking keeps the pgvector path")
+}
+
+func TestHybridSearchService_Ranker(t *testing.T) {
+	off := NewHybridSearchService(&HybridSearchConfig{OpenSearchService: &OpenSearchService{}})
+	assert.Empty(t, off.ranker(&models.SearchRequest{Query: "clutch", Page: 1, Limit: 20}))
+
+	all := NewHybridSearchService(&HybridSearchConfig{
+		OpenSearchService: &OpenSearchService{},
+		LTR:               NewSearchLTRService(nil, &LTRModel{}, 100),
+	})
+	assert.Equal(t, models.SearchRankerLTR, all.ranker(&models.SearchRequest{Query: "clutch", Page: 5, Limit: 20}))
+	assert.Equal(t, models.SearchRankerLTR, all.WithWeights(DefaultConfigs()[1]).ranker(&models.SearchRequest{Query: "clutch", Page: 1, Limit: 20}))
+	assert.Empty(t, all.ranker(&models.SearchRequest{Query: "clutch", Page: 6, Limit: 20}),
+		"pages past the re-ranked candidates keep the fused ranking")
+	assert.Empty(t, all.ranker(&models.SearchRequest{Query: "clutch", Sort: "recent", Page: 1, Limit: 20}))
+	assert.Empty(t, all.ranker(&models.SearchRequest{Page: 1, Limit: 20}))
+
+	none := NewHybridSearchService(&HybridSearchConfig{
+		OpenSearchService: &OpenSearchService{},
+		LTR:               NewSearchLTRService(nil, &LTRModel{}, 0),
+	})
+	userID := uuid.New()
+	assert.Equal(t, models.SearchRankerControl, none.ranker(&models.SearchRequest{Query: "clutch", Page: 1, Limit: 20, UserID: &userID}))
+}
+
+func TestWeightedRerank(t *testing.T) {
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New(), uuid.New()}
+	candidates := make([]models.Clip, len(ids))
+	for i, id := range ids {
+		candidates[i] = models.Clip{ID: id}
+	}
+	// Candidate 3 is last by BM25 but the closest match by embedding;
+	// candidate 1 has no embedding
+	distances := map[uuid.UUID]float64{
+		ids[0]: 1.0,
+		ids[2]: 0.8,
+		ids[3]: 0.0,
+	}
+
+	order := func(clips []models.Clip) []uuid.UUID {
+		out := make([]uuid.UUID, len(clips))
+		for i, clip := range clips {
+			out[i] = clip.ID
+		}
+		return out
+	}
+
+	textHeavy := weightedRerank(candidates, distances, SearchWeightConfig{BM25Weight: 0.9, VectorWeight: 0.1}, 10, 0)
+	assert.Equal(t, ids, order(textHeavy))
+
+	semanticHeavy := weightedRerank(candidates, distances, SearchWeightConfig{BM25Weight: 0.2, VectorWeight: 0.8}, 10, 0)
+	assert.Equal(t, []uuid.UUID{ids[3], ids[0], ids[2], ids[1]}, order(semanticHeavy))
+
+	page := weightedRerank(candidates, distances, SearchWeightConfig{BM25Weight: 0.2, VectorWeight: 0.8}, 2, 1)
+	assert.Equal(t, []uuid.UUID{ids[0], ids[2]}, order(page))
+
+	assert.Empty(t, weightedRerank(candidates, distances, SearchWeightConfig{BM25Weight: 1}, 10, 4))
+}
+
 func TestClipScore_Structure(t *testing.T) {
 	// Test that ClipScore model is properly structured
 	score := models.ClipScore{
@@ -112,3 +205,21 @@ func TestRecordSearchMetrics(t *testing.T) {
 		service.recordSearchMetrics("bm25", start, response, nil)
 	})
 }
+
+func TestRankingOverlap(t *testing.T) {
+	a, b, c, d := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	assert.Equal(t, 1.0, rankingOverlap(nil, nil))
+	assert.Equal(t, 0.0, rankingOverlap(nil, []uuid.UUID{a}))
+	assert.Equal(t, 1.0, rankingOverlap([]uuid.UUID{a, b}, []uuid.UUID{b, a}))
+	assert.Equal(t, 0.5, rankingOverlap([]uuid.UUID{a, b, c, d}, []uuid.UUID{d, a}))
+	assert.Equal(t, 0.0, rankingOverlap([]uuid.UUID{a}, []uuid.UUID{b}))
+}
+
+func TestNewHybridSearchService_DefaultEmbeddingColumn(t *testing.T) {
+	service := NewHybridSearchService(&HybridSearchConfig{})
+	assert.Equal(t, models.DefaultClipEmbeddingColumn, service.embeddingColumn)
+
+	service = NewHybridSearchService(&HybridSearchConfig{EmbeddingColumn: "embedding_bge_base"})
+	assert.Equal(t, "embedding_bge_base", service.embeddingColumn)
+}
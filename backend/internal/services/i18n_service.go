@@ -0,0 +1,427 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+const (
+	// i18nDefaultLocale ends every fallback chain
+	i18nDefaultLocale = "en"
+	// i18nCatalogCacheTTL bounds how stale another instance's edits can look
+	i18nCatalogCacheTTL = 5 * time.Minute
+	// i18nMaxKeyLength matches the key column width
+	i18nMaxKeyLength = 255
+)
+
+var (
+	// ErrLocaleNotFound is returned for a locale the catalog is not served in
+	ErrLocaleNotFound = errors.New("locale not found")
+	// ErrInvalidLocale is returned for a malformed locale code
+	ErrInvalidLocale = errors.New("invalid locale code")
+	// ErrInvalidI18nKey is returned for a malformed translation key
+	ErrInvalidI18nKey = errors.New("invalid translation key")
+	// ErrInvalidLocaleFile is returned for a locale file with non-string values
+	ErrInvalidLocaleFile = errors.New("locale file values must be strings or nested objects")
+	// ErrI18nFallbackCycle is returned when a fallback would loop back to the locale
+	ErrI18nFallbackCycle = errors.New("fallback locale would create a cycle")
+)
+
+var (
+	localeCodePattern   = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+	i18nKeyPattern      = regexp.MustCompile(`^[A-Za-z0-9_.:-]+$`)
+	i18nPlaceholderExpr = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+)\s*\}\}`)
+)
+
+// I18nRepositoryInterface defines the repository methods used by I18nService
+type I18nRepositoryInterface interface {
+	ListLocales(ctx context.Context) ([]models.I18nLocale, error)
+	UpsertLocale(ctx context.Context, locale *models.I18nLocale) error
+	GetStrings(ctx context.Context, locale string) (map[string]string, error)
+	UpsertStrings(ctx context.Context, locale string, values map[string]string, updatedBy *uuid.UUID) error
+	DeleteString(ctx context.Context, locale, key string) error
+	RecordMissingKeys(ctx context.Context, locale string, keys []string) error
+	ListMissingKeys(ctx context.Context, locale string, limit, offset int) ([]models.I18nMissingKey, error)
+}
+
+type cachedI18nCatalog struct {
+	catalog *models.I18nCatalog
+	builtAt time.Time
+}
+
+// I18nService serves the UI string catalog shared by the frontend and emails
+type I18nService struct {
+	repo     I18nRepositoryInterface
+	cacheTTL time.Duration
+	mu       sync.RWMutex
+	cache    map[string]cachedI18nCatalog
+}
+
+// NewI18nService creates a new I18nService
+func NewI18nService(repo I18nRepositoryInterface) *I18nService {
+	return &I18nService{
+		repo:     repo,
+		cacheTTL: i18nCatalogCacheTTL,
+		cache:    make(map[string]cachedI18nCatalog),
+	}
+}
+
+// GetCatalog returns the strings for a locale merged over its fallback chain.
+// A regional locale that isn't served (e.g. "es-MX") resolves to its language.
+func (s *I18nService) GetCatalog(ctx context.Context, locale string) (*models.I18nCatalog, error) {
+	code, err := normalizeLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	cached, ok := s.cache[code]
+	s.mu.RUnlock()
+	if ok && time.Since(cached.builtAt) < s.cacheTTL {
+		return cached.catalog, nil
+	}
+
+	catalog, err := s.buildCatalog(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.cache[code] = cachedI18nCatalog{catalog: catalog, builtAt: time.Now()}
+	s.mu.Unlock()
+
+	return catalog, nil
+}
+
+func (s *I18nService) buildCatalog(ctx context.Context, code string) (*models.I18nCatalog, error) {
+	locales, err := s.localesByCode(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	chain, err := fallbackChain(locales, code)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply the chain from the last fallback up so nearer locales win
+	merged := map[string]string{}
+	for i := len(chain) - 1; i >= 0; i-- {
+		values, err := s.repo.GetStrings(ctx, chain[i])
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range values {
+			merged[key] = value
+		}
+	}
+
+	return &models.I18nCatalog{
+		Locale:    chain[0],
+		Fallbacks: chain[1:],
+		Version:   catalogVersion(chain, merged),
+		Strings:   merged,
+	}, nil
+}
+
+// fallbackChain returns the served locale for code followed by its fallbacks,
+// always ending with the default locale
+func fallbackChain(locales map[string]models.I18nLocale, code string) ([]string, error) {
+	start := ""
+	for _, candidate := range []string{code, strings.SplitN(code, "-", 2)[0]} {
+		if locale, ok := locales[candidate]; ok && locale.IsEnabled {
+			start = candidate
+			break
+		}
+	}
+	if start == "" {
+		return nil, ErrLocaleNotFound
+	}
+
+	chain := []string{start}
+	seen := map[string]bool{start: true}
+	for next := locales[start].FallbackLocale; next != nil && !seen[*next]; next = locales[*next].FallbackLocale {
+		if _, ok := locales[*next]; !ok {
+			break
+		}
+		chain = append(chain, *next)
+		seen[*next] = true
+	}
+	if !seen[i18nDefaultLocale] {
+		chain = append(chain, i18nDefaultLocale)
+	}
+	return chain, nil
+}
+
+// catalogVersion hashes a merged catalog so clients can revalidate cheaply
+func catalogVersion(chain []string, values map[string]string) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	h.Write([]byte(strings.Join(chain, ",")))
+	for _, key := range keys {
+		h.Write([]byte{0})
+		h.Write([]byte(key))
+		h.Write([]byte{0})
+		h.Write([]byte(values[key]))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// Translate looks up a key for a locale and fills in {{name}} placeholders
+// from data. It reports false when no locale in the chain has the key, so
+// callers can keep their built-in text.
+func (s *I18nService) Translate(ctx context.Context, locale, key string, data map[string]interface{}) (string, bool) {
+	if locale == "" {
+		locale = i18nDefaultLocale
+	}
+	catalog, err := s.GetCatalog(ctx, locale)
+	if err != nil && locale != i18nDefaultLocale {
+		catalog, err = s.GetCatalog(ctx, i18nDefaultLocale)
+	}
+	if err != nil {
+		return "", false
+	}
+
+	value, ok := catalog.Strings[key]
+	if !ok {
+		return "", false
+	}
+	return interpolate(value, data), true
+}
+
+// interpolate replaces {{name}} placeholders, leaving unknown ones in place
+func interpolate(value string, data map[string]interface{}) string {
+	if len(data) == 0 {
+		return value
+	}
+	return i18nPlaceholderExpr.ReplaceAllStringFunc(value, func(match string) string {
+		name := i18nPlaceholderExpr.FindStringSubmatch(match)[1]
+		if v, ok := data[name]; ok {
+			return fmt.Sprint(v)
+		}
+		return match
+	})
+}
+
+// ListLocales returns every locale, including disabled ones
+func (s *I18nService) ListLocales(ctx context.Context) ([]models.I18nLocale, error) {
+	return s.repo.ListLocales(ctx)
+}
+
+// UpsertLocale creates or updates a locale
+func (s *I18nService) UpsertLocale(ctx context.Context, code string, req *models.UpsertI18nLocaleRequest) (*models.I18nLocale, error) {
+	code, err := normalizeLocale(code)
+	if err != nil {
+		return nil, err
+	}
+
+	locales, err := s.localesByCode(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	locale := models.I18nLocale{Code: code, Name: req.Name, IsEnabled: true}
+	if existing, ok := locales[code]; ok {
+		locale.IsEnabled = existing.IsEnabled
+	}
+	if req.IsEnabled != nil {
+		locale.IsEnabled = *req.IsEnabled
+	}
+
+	if req.FallbackLocale != nil && *req.FallbackLocale != "" {
+		fallback, err := normalizeLocale(*req.FallbackLocale)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := locales[fallback]; !ok {
+			return nil, ErrLocaleNotFound
+		}
+		// Walk the fallback's own chain to make sure it never reaches code
+		seen := map[string]bool{}
+		for next := &fallback; next != nil && !seen[*next]; next = locales[*next].FallbackLocale {
+			if *next == code {
+				return nil, ErrI18nFallbackCycle
+			}
+			seen[*next] = true
+		}
+		locale.FallbackLocale = &fallback
+	}
+
+	if err := s.repo.UpsertLocale(ctx, &locale); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+	return &locale, nil
+}
+
+// GetLocaleStrings returns the strings stored for a locale, without fallbacks
+func (s *I18nService) GetLocaleStrings(ctx context.Context, code string) (map[string]string, error) {
+	code, err := s.existingLocale(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetStrings(ctx, code)
+}
+
+// ImportStrings saves a locale file. Nested objects are flattened into dotted
+// keys, so i18next resource files can be imported as they are. Keys not in
+// the file are left untouched. It returns how many strings were saved.
+func (s *I18nService) ImportStrings(ctx context.Context, code string, file map[string]interface{}, updatedBy uuid.UUID) (int, error) {
+	code, err := s.existingLocale(ctx, code)
+	if err != nil {
+		return 0, err
+	}
+
+	values := map[string]string{}
+	if err := flattenLocaleFile("", file, values); err != nil {
+		return 0, err
+	}
+	if len(values) == 0 {
+		return 0, nil
+	}
+	for key := range values {
+		if err := validateI18nKey(key); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := s.repo.UpsertStrings(ctx, code, values, &updatedBy); err != nil {
+		return 0, err
+	}
+	s.invalidate()
+	return len(values), nil
+}
+
+// flattenLocaleFile copies nested string values into out under dotted keys
+func flattenLocaleFile(prefix string, file map[string]interface{}, out map[string]string) error {
+	for key, value := range file {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case string:
+			out[key] = v
+		case map[string]interface{}:
+			if err := flattenLocaleFile(key, v, out); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%w: %s", ErrInvalidLocaleFile, key)
+		}
+	}
+	return nil
+}
+
+// DeleteString removes a locale's string so the key falls back again
+func (s *I18nService) DeleteString(ctx context.Context, code, key string) error {
+	code, err := normalizeLocale(code)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteString(ctx, code, key); err != nil {
+		return err
+	}
+	s.invalidate()
+	return nil
+}
+
+// ReportMissingKeys records keys a client could not resolve for a locale
+func (s *I18nService) ReportMissingKeys(ctx context.Context, locale string, keys []string) error {
+	code, err := s.existingLocale(ctx, locale)
+	if err != nil {
+		return err
+	}
+
+	seen := map[string]bool{}
+	unique := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if err := validateI18nKey(key); err != nil {
+			return err
+		}
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, key)
+		}
+	}
+	return s.repo.RecordMissingKeys(ctx, code, unique)
+}
+
+// ListMissingKeys returns reported missing keys. An empty locale lists all.
+func (s *I18nService) ListMissingKeys(ctx context.Context, locale string, limit, offset int) ([]models.I18nMissingKey, error) {
+	if locale != "" {
+		code, err := normalizeLocale(locale)
+		if err != nil {
+			return nil, err
+		}
+		locale = code
+	}
+	return s.repo.ListMissingKeys(ctx, locale, limit, offset)
+}
+
+// existingLocale normalizes code and checks the locale exists
+func (s *I18nService) existingLocale(ctx context.Context, code string) (string, error) {
+	code, err := normalizeLocale(code)
+	if err != nil {
+		return "", err
+	}
+	locales, err := s.localesByCode(ctx)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := locales[code]; !ok {
+		return "", ErrLocaleNotFound
+	}
+	return code, nil
+}
+
+func (s *I18nService) localesByCode(ctx context.Context) (map[string]models.I18nLocale, error) {
+	list, err := s.repo.ListLocales(ctx)
+	if err != nil {
+		return nil, err
+	}
+	locales := make(map[string]models.I18nLocale, len(list))
+	for _, locale := range list {
+		locales[locale.Code] = locale
+	}
+	return locales, nil
+}
+
+// invalidate drops cached catalogs. A change to one locale can reach every
+// locale that falls back to it, so the whole cache goes.
+func (s *I18nService) invalidate() {
+	s.mu.Lock()
+	s.cache = make(map[string]cachedI18nCatalog)
+	s.mu.Unlock()
+}
+
+// normalizeLocale turns "en_US" and "EN-us" into "en-us"
+func normalizeLocale(locale string) (string, error) {
+	code := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+	if !localeCodePattern.MatchString(code) {
+		return "", ErrInvalidLocale
+	}
+	return code, nil
+}
+
+func validateI18nKey(key string) error {
+	if len(key) > i18nMaxKeyLength || !i18nKeyPattern.MatchString(key) {
+		return fmt.Errorf("%w: %q", ErrInvalidI18nKey, key)
+	}
+	return nil
+}
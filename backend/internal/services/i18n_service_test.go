@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// MockI18nRepository is a mock implementation of I18nRepositoryInterface
+type MockI18nRepository struct {
+	mock.Mock
+}
+
+func (m *MockI18nRepository) ListLocales(ctx context.Context) ([]models.I18nLocale, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.I18nLocale), args.Error(1)
+}
+
+func (m *MockI18nRepository) UpsertLocale(ctx context.Context, locale *models.I18nLocale) error {
+	args := m.Called(ctx, locale)
+	return args.Error(0)
+}
+
+func (m *MockI18nRepository) GetStrings(ctx context.Context, locale string) (map[string]string, error) {
+	args := m.Called(ctx, locale)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+func (m *MockI18nRepository) UpsertStrings(ctx context.Context, locale string, values map[string]string, updatedBy *uuid.UUID) error {
+	args := m.Called(ctx, locale, values, updatedBy)
+	return args.Error(0)
+}
+
+func (m *MockI18nRepository) DeleteString(ctx context.Context, locale, key string) error {
+	args := m.Called(ctx, locale, key)
+	return args.Error(0)
+}
+
+func (m *MockI18nRepository) RecordMissingKeys(ctx context.Context, locale string, keys []string) error {
+	args := m.Called(ctx, locale, keys)
+	return args.Error(0)
+}
+
+func (m *MockI18nRepository) ListMissingKeys(ctx context.Context, locale string, limit, offset int) ([]models.I18nMissingKey, error) {
+	args := m.Called(ctx, locale, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.I18nMissingKey), args.Error(1)
+}
+
+// setupI18nServiceTest builds an I18nService over a mock repository serving
+// English, Spanish and Argentinian Spanish, with French disabled
+func setupI18nServiceTest() (*I18nService, *MockI18nRepository) {
+	en := "en"
+	es := "es"
+	repo := new(MockI18nRepository)
+	repo.On("ListLocales", mock.Anything).Return([]models.I18nLocale{
+		{Code: "en", Name: "English", IsEnabled: true},
+		{Code: "es", Name: "Español", FallbackLocale: &en, IsEnabled: true},
+		{Code: "es-ar", Name: "Español (Argentina)", FallbackLocale: &es, IsEnabled: true},
+		{Code: "fr", Name: "Français", FallbackLocale: &en, IsEnabled: false},
+	}, nil)
+	repo.On("GetStrings", mock.Anything, "en").Return(map[string]string{"nav.home": "Home", "nav.search": "Search", "greeting": "Hi {{name}}"}, nil)
+	repo.On("GetStrings", mock.Anything, "es-ar").Return(map[string]string{"nav.search": "Buscá"}, nil)
+
+	return NewI18nService(repo), repo
+}
+
+func TestI18nService_GetCatalogMergesFallbackChain(t *testing.T) {
+	svc, repo := setupI18nServiceTest()
+	repo.On("GetStrings", mock.Anything, "es").Return(map[string]string{"nav.home": "Inicio"}, nil)
+
+	catalog, err := svc.GetCatalog(context.Background(), "es_AR")
+	require.NoError(t, err)
+	assert.Equal(t, "es-ar", catalog.Locale)
+	assert.Equal(t, []string{"es", "en"}, catalog.Fallbacks)
+	assert.Equal(t, "Inicio", catalog.Strings["nav.home"])
+	assert.Equal(t, "Buscá", catalog.Strings["nav.search"])
+	assert.Equal(t, "Hi {{name}}", catalog.Strings["greeting"])
+}
+
+func TestI18nService_GetCatalogResolvesLocale(t *testing.T) {
+	svc, repo := setupI18nServiceTest()
+	repo.On("GetStrings", mock.Anything, "es").Return(map[string]string{"nav.home": "Inicio"}, nil)
+
+	catalog, err := svc.GetCatalog(context.Background(), "es-MX")
+	require.NoError(t, err)
+	assert.Equal(t, "es", catalog.Locale, "unserved region falls back to its language")
+
+	_, err = svc.GetCatalog(context.Background(), "fr")
+	assert.ErrorIs(t, err, ErrLocaleNotFound, "disabled locales are not served")
+
+	_, err = svc.GetCatalog(context.Background(), "de")
+	assert.ErrorIs(t, err, ErrLocaleNotFound)
+
+	_, err = svc.GetCatalog(context.Background(), "../etc")
+	assert.ErrorIs(t, err, ErrInvalidLocale)
+}
+
+func TestI18nService_ImportStringsChangesVersion(t *testing.T) {
+	svc, repo := setupI18nServiceTest()
+	ctx := context.Background()
+	editorID := uuid.New()
+
+	repo.On("GetStrings", ctx, "es").Return(map[string]string{"nav.home": "Inicio"}, nil).Once()
+
+	before, err := svc.GetCatalog(ctx, "es")
+	require.NoError(t, err)
+
+	cached, err := svc.GetCatalog(ctx, "es")
+	require.NoError(t, err)
+	assert.Equal(t, before.Version, cached.Version)
+	repo.AssertNumberOfCalls(t, "GetStrings", 2)
+
+	repo.On("UpsertStrings", ctx, "es", map[string]string{"nav.search": "Buscar"}, &editorID).Return(nil).Once()
+	saved, err := svc.ImportStrings(ctx, "es", map[string]interface{}{
+		"nav": map[string]interface{}{"search": "Buscar"},
+	}, editorID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, saved)
+
+	// The import invalidates cached catalogs
+	repo.On("GetStrings", ctx, "es").Return(map[string]string{"nav.home": "Inicio", "nav.search": "Buscar"}, nil)
+
+	after, err := svc.GetCatalog(ctx, "es")
+	require.NoError(t, err)
+	assert.NotEqual(t, before.Version, after.Version)
+	assert.Equal(t, "Buscar", after.Strings["nav.search"])
+
+	// Locales falling back to the edited one see the change too
+	regional, err := svc.GetCatalog(ctx, "es-ar")
+	require.NoError(t, err)
+	assert.Equal(t, "Buscá", regional.Strings["nav.search"])
+	assert.Equal(t, "Inicio", regional.Strings["nav.home"])
+
+	repo.AssertExpectations(t)
+}
+
+func TestI18nService_ImportStringsValidation(t *testing.T) {
+	svc, repo := setupI18nServiceTest()
+	ctx := context.Background()
+
+	_, err := svc.ImportStrings(ctx, "es", map[string]interface{}{"count": 3}, uuid.New())
+	assert.ErrorIs(t, err, ErrInvalidLocaleFile)
+
+	_, err = svc.ImportStrings(ctx, "es", map[string]interface{}{"bad key": "x"}, uuid.New())
+	assert.ErrorIs(t, err, ErrInvalidI18nKey)
+
+	_, err = svc.ImportStrings(ctx, "de", map[string]interface{}{"nav.home": "Start"}, uuid.New())
+	assert.ErrorIs(t, err, ErrLocaleNotFound)
+
+	repo.AssertNotCalled(t, "UpsertStrings", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestI18nService_UpsertLocaleRejectsFallbackCycle(t *testing.T) {
+	svc, repo := setupI18nServiceTest()
+	ctx := context.Background()
+
+	esAR := "es-ar"
+	_, err := svc.UpsertLocale(ctx, "es", &models.UpsertI18nLocaleRequest{Name: "Español", FallbackLocale: &esAR})
+	assert.ErrorIs(t, err, ErrI18nFallbackCycle)
+
+	unknown := "de"
+	_, err = svc.UpsertLocale(ctx, "pt", &models.UpsertI18nLocaleRequest{Name: "Português", FallbackLocale: &unknown})
+	assert.ErrorIs(t, err, ErrLocaleNotFound)
+
+	repo.AssertNotCalled(t, "UpsertLocale", mock.Anything, mock.Anything)
+
+	es := "es"
+	repo.On("UpsertLocale", ctx, mock.MatchedBy(func(locale *models.I18nLocale) bool {
+		return locale.Code == "pt" && locale.FallbackLocale != nil && *locale.FallbackLocale == "es"
+	})).Return(nil).Once()
+
+	locale, err := svc.UpsertLocale(ctx, "pt", &models.UpsertI18nLocaleRequest{Name: "Português", FallbackLocale: &es})
+	require.NoError(t, err)
+	assert.True(t, locale.IsEnabled)
+	repo.AssertNumberOfCalls(t, "UpsertLocale", 1)
+}
+
+func TestI18nService_ReportMissingKeys(t *testing.T) {
+	svc, repo := setupI18nServiceTest()
+	ctx := context.Background()
+
+	repo.On("RecordMissingKeys", ctx, "es", []string{"clip.share", "clip.embed"}).Return(nil).Once()
+
+	require.NoError(t, svc.ReportMissingKeys(ctx, "es", []string{"clip.share", "clip.share", "clip.embed"}))
+
+	err := svc.ReportMissingKeys(ctx, "es", []string{"<script>"})
+	assert.ErrorIs(t, err, ErrInvalidI18nKey)
+
+	err = svc.ReportMissingKeys(ctx, "de", []string{"clip.share"})
+	assert.ErrorIs(t, err, ErrLocaleNotFound)
+
+	repo.AssertNumberOfCalls(t, "RecordMissingKeys", 1)
+}
+
+func TestI18nService_Translate(t *testing.T) {
+	svc, repo := setupI18nServiceTest()
+	ctx := context.Background()
+	repo.On("GetStrings", mock.Anything, "es").Return(map[string]string{"nav.home": "Inicio"}, nil)
+
+	value, ok := svc.Translate(ctx, "es", "greeting", map[string]interface{}{"name": "Ana"})
+	assert.True(t, ok)
+	assert.Equal(t, "Hi Ana", value)
+
+	value, ok = svc.Translate(ctx, "de", "nav.home", nil)
+	assert.True(t, ok, "unknown locales use the default catalog")
+	assert.Equal(t, "Home", value)
+
+	_, ok = svc.Translate(ctx, "es", "email.unknown.subject", nil)
+	assert.False(t, ok)
+}
@@ -0,0 +1,564 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// incrementalSyncOverlap is taken off checkpoints so rows committed late by
+// transactions that started before the last run aren't missed
+const incrementalSyncOverlap = time.Minute
+
+// IncrementalResult contains the result of an incremental reindex
+type IncrementalResult struct {
+	BaseIndex      string        `json:"base_index"`
+	IndexName      string        `json:"index_name"`
+	Since          time.Time     `json:"since"` // Zero when the index had no checkpoint
+	SyncedThrough  time.Time     `json:"synced_through"`
+	DocsIndexed    int64         `json:"docs_indexed"`
+	DocsDeleted    int64         `json:"docs_deleted"`
+	Duration       time.Duration `json:"duration"`
+	StartTime      time.Time     `json:"start_time"`
+	EndTime        time.Time     `json:"end_time"`
+	DeletedIndices []string      `json:"deleted_indices,omitempty"`
+	Error          string        `json:"error,omitempty"`
+}
+
+// IncrementalAllResult contains results for incrementally reindexing all indices
+type IncrementalAllResult struct {
+	Results       []IncrementalResult `json:"results"`
+	TotalDuration time.Duration       `json:"total_duration"`
+	Success       bool                `json:"success"`
+	Errors        []string            `json:"errors,omitempty"`
+}
+
+// SetRetentionPolicy deletes old index versions the policy expires after
+// each incremental reindex
+func (s *IndexRebuildService) SetRetentionPolicy(policy IndexRetentionPolicy) {
+	s.retention = &policy
+}
+
+// incrementalSince returns when rows must have changed to be reindexed after
+// a checkpoint. Without one every row is reindexed.
+func incrementalSince(state *models.SearchSyncState) time.Time {
+	if state == nil {
+		return time.Time{}
+	}
+	return state.SyncedThrough.Add(-incrementalSyncOverlap)
+}
+
+// databaseNow returns the database's clock, which updated_at columns are set from
+func (s *IndexRebuildService) databaseNow(ctx context.Context) (time.Time, error) {
+	var now time.Time
+	if err := s.db.Pool.QueryRow(ctx, `SELECT LOCALTIMESTAMP`).Scan(&now); err != nil {
+		return time.Time{}, err
+	}
+	return now, nil
+}
+
+// GetSyncState returns the checkpoint of an index, or nil if it has none
+func (s *IndexRebuildService) GetSyncState(ctx context.Context, baseIndex string) (*models.SearchSyncState, error) {
+	query := `
+		SELECT index_name, synced_through, last_run_at, last_mode, documents_indexed, documents_deleted
+		FROM search_sync_state
+		WHERE index_name = $1
+	`
+	var state models.SearchSyncState
+	err := s.db.Pool.QueryRow(ctx, query, baseIndex).Scan(
+		&state.IndexName, &state.SyncedThrough, &state.LastRunAt,
+		&state.LastMode, &state.DocumentsIndexed, &state.DocumentsDeleted,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sync state: %w", err)
+	}
+	return &state, nil
+}
+
+// saveSyncState stores the checkpoint of an index
+func (s *IndexRebuildService) saveSyncState(ctx context.Context, state *models.SearchSyncState) error {
+	query := `
+		INSERT INTO search_sync_state (index_name, synced_through, last_run_at, last_mode, documents_indexed, documents_deleted)
+		VALUES ($1, $2, NOW(), $3, $4, $5)
+		ON CONFLICT (index_name) DO UPDATE
+		SET synced_through = EXCLUDED.synced_through,
+		    last_run_at = EXCLUDED.last_run_at,
+		    last_mode = EXCLUDED.last_mode,
+		    documents_indexed = EXCLUDED.documents_indexed,
+		    documents_deleted = EXCLUDED.documents_deleted
+	`
+	_, err := s.db.Pool.Exec(ctx, query, state.IndexName, state.SyncedThrough, state.LastMode, state.DocumentsIndexed, state.DocumentsDeleted)
+	if err != nil {
+		return fmt.Errorf("failed to save sync state: %w", err)
+	}
+	return nil
+}
+
+// saveRebuildCheckpoint resets the checkpoint of an index after a rebuild
+// went live. A failure only means the next incremental run does more work.
+func (s *IndexRebuildService) saveRebuildCheckpoint(ctx context.Context, baseIndex string, syncedThrough time.Time, docCount int64) {
+	err := s.saveSyncState(ctx, &models.SearchSyncState{
+		IndexName:        baseIndex,
+		SyncedThrough:    syncedThrough,
+		LastMode:         models.SearchSyncModeRebuild,
+		DocumentsIndexed: docCount,
+	})
+	if err != nil {
+		log.Printf("WARNING: Failed to save sync checkpoint for %s: %v", baseIndex, err)
+	}
+}
+
+// IncrementalReindex reindexes the documents of an index whose rows changed
+// since its checkpoint into the active version, and deletes those that no
+// longer belong in it. Rows deleted outright and games a clip moved away
+// from are only caught by the next rebuild.
+func (s *IndexRebuildService) IncrementalReindex(ctx context.Context, baseIndex string, config *RebuildConfig) (*IncrementalResult, error) {
+	if config == nil {
+		config = DefaultRebuildConfig()
+	}
+
+	result := &IncrementalResult{
+		BaseIndex: baseIndex,
+		StartTime: time.Now(),
+	}
+
+	info, err := s.versionService.GetIndexVersionInfo(ctx, baseIndex)
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to get index info: %v", err)
+		return result, fmt.Errorf("failed to get index info: %w", err)
+	}
+	if info.ActiveVersion == nil {
+		result.Error = fmt.Sprintf("no active version of %s, run rebuild first", baseIndex)
+		return result, fmt.Errorf("no active version of %s, run rebuild first", baseIndex)
+	}
+	result.IndexName = info.ActiveVersion.Name
+
+	state, err := s.GetSyncState(ctx, baseIndex)
+	if err != nil {
+		result.Error = err.Error()
+		return result, err
+	}
+	result.Since = incrementalSince(state)
+
+	// Rows changed from here on are left to the next run
+	result.SyncedThrough, err = s.databaseNow(ctx)
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to read database time: %v", err)
+		return result, fmt.Errorf("failed to read database time: %w", err)
+	}
+
+	log.Printf("Starting incremental reindex of %s into %s (changed since %s)", baseIndex, result.IndexName, result.Since.Format(time.RFC3339))
+
+	switch baseIndex {
+	case ClipsIndex:
+		result.DocsIndexed, result.DocsDeleted, err = s.syncChangedClips(ctx, result.IndexName, result.Since, config)
+	case UsersIndex:
+		result.DocsIndexed, result.DocsDeleted, err = s.syncChangedUsers(ctx, result.IndexName, result.Since, config)
+	case TagsIndex:
+		result.DocsIndexed, err = s.syncChangedTags(ctx, result.IndexName, result.Since, config)
+	case GamesIndex:
+		result.DocsIndexed, result.DocsDeleted, err = s.syncChangedGames(ctx, result.IndexName, result.Since, config)
+	default:
+		err = fmt.Errorf("unknown index: %s", baseIndex)
+	}
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to reindex %s: %v", baseIndex, err)
+		return result, fmt.Errorf("failed to reindex %s: %w", baseIndex, err)
+	}
+
+	err = s.saveSyncState(ctx, &models.SearchSyncState{
+		IndexName:        baseIndex,
+		SyncedThrough:    result.SyncedThrough,
+		LastMode:         models.SearchSyncModeIncremental,
+		DocumentsIndexed: result.DocsIndexed,
+		DocumentsDeleted: result.DocsDeleted,
+	})
+	if err != nil {
+		result.Error = err.Error()
+		return result, err
+	}
+
+	if s.retention != nil {
+		deleted, err := s.versionService.ApplyRetentionPolicy(ctx, baseIndex, *s.retention)
+		if err != nil {
+			log.Printf("WARNING: Failed to apply retention policy: %v", err)
+		}
+		result.DeletedIndices = deleted
+	}
+
+	result.EndTime = time.Now()
+	result.Duration = result.EndTime.Sub(result.StartTime)
+
+	log.Printf("Completed incremental reindex of %s: %d indexed, %d deleted in %v", baseIndex, result.DocsIndexed, result.DocsDeleted, result.Duration)
+
+	return result, nil
+}
+
+// IncrementalReindexAll incrementally reindexes all search indices
+func (s *IndexRebuildService) IncrementalReindexAll(ctx context.Context, config *RebuildConfig) (*IncrementalAllResult, error) {
+	startTime := time.Now()
+	result := &IncrementalAllResult{
+		Results: []IncrementalResult{},
+		Success: true,
+	}
+
+	for _, baseIndex := range []string{ClipsIndex, UsersIndex, TagsIndex, GamesIndex} {
+		indexResult, err := s.IncrementalReindex(ctx, baseIndex, config)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", baseIndex, err))
+			result.Success = false
+		}
+		result.Results = append(result.Results, *indexResult)
+	}
+
+	result.TotalDuration = time.Since(startTime)
+
+	if !result.Success {
+		log.Printf("Incremental reindex completed with errors in %v: %v", result.TotalDuration, result.Errors)
+	}
+
+	return result, nil
+}
+
+// syncChangedClips reindexes clips changed since the given time, including
+// their accessibility flags and hype scores, and deletes removed ones and those
+// submitted by shadow-banned users
+func (s *IndexRebuildService) syncChangedClips(ctx context.Context, indexName string, since time.Time, config *RebuildConfig) (int64, int64, error) {
+	var totalIndexed, totalDeleted int64
+	lastID := uuid.Nil
+
+	for {
+		query := indexClipsQuery + `
+			WHERE c.id IN (
+				SELECT id FROM clips WHERE updated_at >= $1
+				UNION
+				SELECT clip_id FROM clip_accessibility WHERE updated_at >= $1
+				UNION
+				SELECT clip_id FROM clip_hype_scores WHERE scored_at >= $1
+			) AND c.id > $2
+			ORDER BY c.id
+			LIMIT $3
+		`
+
+		rows, err := s.db.Pool.Query(ctx, query, since, lastID, config.BatchSize)
+		if err != nil {
+			return totalIndexed, totalDeleted, fmt.Errorf("failed to fetch clips: %w", err)
+		}
+
+		var clips []models.Clip
+		var removed []string
+		fetched := 0
+		for rows.Next() {
+			clip, shadowBanned, err := scanIndexClip(rows)
+			if err != nil {
+				rows.Close()
+				return totalIndexed, totalDeleted, fmt.Errorf("failed to scan clip: %w", err)
+			}
+			fetched++
+			lastID = clip.ID
+			// Clips of shadow-banned submitters leave the index until the shadow ban ends
+			if clip.IsRemoved || shadowBanned {
+				removed = append(removed, clip.ID.String())
+			} else {
+				clips = append(clips, clip)
+			}
+		}
+		rows.Close()
+
+		if fetched == 0 {
+			break
+		}
+
+		if err := s.bulkIndexClipsToIndex(ctx, indexName, clips); err != nil {
+			return totalIndexed, totalDeleted, fmt.Errorf("failed to bulk index clips: %w", err)
+		}
+		if err := s.bulkDeleteFromIndex(ctx, indexName, removed); err != nil {
+			return totalIndexed, totalDeleted, fmt.Errorf("failed to bulk delete clips: %w", err)
+		}
+
+		totalIndexed += int64(len(clips))
+		totalDeleted += int64(len(removed))
+
+		if config.Verbose {
+			log.Printf("Reindexed %d clips and deleted %d from %s", len(clips), len(removed), indexName)
+		}
+
+		if config.BatchDelay > 0 {
+			time.Sleep(config.BatchDelay)
+		}
+	}
+
+	return totalIndexed, totalDeleted, nil
+}
+
+// syncChangedUsers reindexes users changed since the given time and deletes
+// banned ones
+func (s *IndexRebuildService) syncChangedUsers(ctx context.Context, indexName string, since time.Time, config *RebuildConfig) (int64, int64, error) {
+	var totalIndexed, totalDeleted int64
+	lastID := uuid.Nil
+
+	for {
+		query := `
+			SELECT id, twitch_id, username, display_name, email, avatar_url,
+			       bio, karma_points, role, is_banned, created_at, updated_at, last_login_at
+			FROM users
+			WHERE updated_at >= $1 AND id > $2
+			ORDER BY id
+			LIMIT $3
+		`
+
+		rows, err := s.db.Pool.Query(ctx, query, since, lastID, config.BatchSize)
+		if err != nil {
+			return totalIndexed, totalDeleted, fmt.Errorf("failed to fetch users: %w", err)
+		}
+
+		var users []models.User
+		var banned []string
+		fetched := 0
+		for rows.Next() {
+			var user models.User
+			err := rows.Scan(
+				&user.ID, &user.TwitchID, &user.Username, &user.DisplayName,
+				&user.Email, &user.AvatarURL, &user.Bio, &user.KarmaPoints,
+				&user.Role, &user.IsBanned, &user.CreatedAt, &user.UpdatedAt,
+				&user.LastLoginAt,
+			)
+			if err != nil {
+				rows.Close()
+				return totalIndexed, totalDeleted, fmt.Errorf("failed to scan user: %w", err)
+			}
+			fetched++
+			lastID = user.ID
+			if user.IsBanned {
+				banned = append(banned, user.ID.String())
+			} else {
+				users = append(users, user)
+			}
+		}
+		rows.Close()
+
+		if fetched == 0 {
+			break
+		}
+
+		if err := s.bulkIndexUsersToIndex(ctx, indexName, users); err != nil {
+			return totalIndexed, totalDeleted, fmt.Errorf("failed to bulk index users: %w", err)
+		}
+		if err := s.bulkDeleteFromIndex(ctx, indexName, banned); err != nil {
+			return totalIndexed, totalDeleted, fmt.Errorf("failed to bulk delete users: %w", err)
+		}
+
+		totalIndexed += int64(len(users))
+		totalDeleted += int64(len(banned))
+
+		if config.Verbose {
+			log.Printf("Reindexed %d users and deleted %d from %s", len(users), len(banned), indexName)
+		}
+
+		if config.BatchDelay > 0 {
+			time.Sleep(config.BatchDelay)
+		}
+	}
+
+	return totalIndexed, totalDeleted, nil
+}
+
+// syncChangedTags reindexes tags changed since the given time
+func (s *IndexRebuildService) syncChangedTags(ctx context.Context, indexName string, since time.Time, config *RebuildConfig) (int64, error) {
+	var totalIndexed int64
+	lastID := uuid.Nil
+
+	for {
+		query := `
+			SELECT id, name, slug, description, color, usage_count, created_at
+			FROM tags
+			WHERE updated_at >= $1 AND id > $2
+			ORDER BY id
+			LIMIT $3
+		`
+
+		rows, err := s.db.Pool.Query(ctx, query, since, lastID, config.BatchSize)
+		if err != nil {
+			return totalIndexed, fmt.Errorf("failed to fetch tags: %w", err)
+		}
+
+		var tags []models.Tag
+		for rows.Next() {
+			var tag models.Tag
+			err := rows.Scan(
+				&tag.ID, &tag.Name, &tag.Slug, &tag.Description,
+				&tag.Color, &tag.UsageCount, &tag.CreatedAt,
+			)
+			if err != nil {
+				rows.Close()
+				return totalIndexed, fmt.Errorf("failed to scan tag: %w", err)
+			}
+			lastID = tag.ID
+			tags = append(tags, tag)
+		}
+		rows.Close()
+
+		if len(tags) == 0 {
+			break
+		}
+
+		if err := s.bulkIndexTagsToIndex(ctx, indexName, tags); err != nil {
+			return totalIndexed, fmt.Errorf("failed to bulk index tags: %w", err)
+		}
+
+		totalIndexed += int64(len(tags))
+
+		if config.Verbose {
+			log.Printf("Reindexed %d tags in %s", len(tags), indexName)
+		}
+
+		if config.BatchDelay > 0 {
+			time.Sleep(config.BatchDelay)
+		}
+	}
+
+	return totalIndexed, nil
+}
+
+// syncChangedGames recounts the games of clips changed since the given time
+// and deletes games left without clips
+func (s *IndexRebuildService) syncChangedGames(ctx context.Context, indexName string, since time.Time, config *RebuildConfig) (int64, int64, error) {
+	var totalIndexed, totalDeleted int64
+	lastID := ""
+
+	for {
+		query := `
+			SELECT DISTINCT game_id
+			FROM clips
+			WHERE updated_at >= $1 AND game_id IS NOT NULL AND game_id > $2
+			ORDER BY game_id
+			LIMIT $3
+		`
+
+		rows, err := s.db.Pool.Query(ctx, query, since, lastID, config.BatchSize)
+		if err != nil {
+			return totalIndexed, totalDeleted, fmt.Errorf("failed to fetch changed games: %w", err)
+		}
+		var gameIDs []string
+		for rows.Next() {
+			var gameID string
+			if err := rows.Scan(&gameID); err != nil {
+				rows.Close()
+				return totalIndexed, totalDeleted, fmt.Errorf("failed to scan game: %w", err)
+			}
+			gameIDs = append(gameIDs, gameID)
+		}
+		rows.Close()
+
+		if len(gameIDs) == 0 {
+			break
+		}
+		lastID = gameIDs[len(gameIDs)-1]
+
+		countQuery := `
+			SELECT game_id, game_name, COUNT(*) as clip_count
+			FROM clips
+			WHERE game_id = ANY($1) AND game_name IS NOT NULL AND is_removed = false
+			GROUP BY game_id, game_name
+			ORDER BY game_id
+		`
+
+		rows, err = s.db.Pool.Query(ctx, countQuery, gameIDs)
+		if err != nil {
+			return totalIndexed, totalDeleted, fmt.Errorf("failed to count games: %w", err)
+		}
+		var games []models.GameSearchResult
+		counted := map[string]bool{}
+		for rows.Next() {
+			var game models.GameSearchResult
+			if err := rows.Scan(&game.ID, &game.Name, &game.ClipCount); err != nil {
+				rows.Close()
+				return totalIndexed, totalDeleted, fmt.Errorf("failed to scan game: %w", err)
+			}
+			counted[game.ID] = true
+			games = append(games, game)
+		}
+		rows.Close()
+
+		var emptied []string
+		for _, gameID := range gameIDs {
+			if !counted[gameID] {
+				emptied = append(emptied, gameID)
+			}
+		}
+
+		if err := s.bulkIndexGamesToIndex(ctx, indexName, games); err != nil {
+			return totalIndexed, totalDeleted, fmt.Errorf("failed to bulk index games: %w", err)
+		}
+		if err := s.bulkDeleteFromIndex(ctx, indexName, emptied); err != nil {
+			return totalIndexed, totalDeleted, fmt.Errorf("failed to bulk delete games: %w", err)
+		}
+
+		totalIndexed += int64(len(games))
+		totalDeleted += int64(len(emptied))
+
+		if config.Verbose {
+			log.Printf("Reindexed %d games and deleted %d from %s", len(games), len(emptied), indexName)
+		}
+
+		if config.BatchDelay > 0 {
+			time.Sleep(config.BatchDelay)
+		}
+	}
+
+	return totalIndexed, totalDeleted, nil
+}
+
+// bulkDeleteFromIndex deletes documents by ID from a specific index.
+// Documents that were never indexed are skipped by OpenSearch.
+func (s *IndexRebuildService) bulkDeleteFromIndex(ctx context.Context, indexName string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, id := range ids {
+		meta := map[string]interface{}{
+			"delete": map[string]interface{}{
+				"_index": indexName,
+				"_id":    id,
+			},
+		}
+		metaJSON, err := json.Marshal(meta)
+		if err != nil {
+			return fmt.Errorf("failed to marshal metadata for %s: %w", id, err)
+		}
+		buf.Write(metaJSON)
+		buf.WriteByte('\n')
+	}
+
+	req := opensearchapi.BulkRequest{
+		Body: &buf,
+	}
+
+	res, err := req.Do(ctx, s.osClient.GetClient())
+	if err != nil {
+		return fmt.Errorf("bulk request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("bulk request error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
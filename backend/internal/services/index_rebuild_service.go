@@ -9,7 +9,9 @@ import (
 	"log"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+	pgvector "github.com/pgvector/pgvector-go"
 	"github.com/subculture-collective/clipper/internal/models"
 	"github.com/subculture-collective/clipper/pkg/database"
 	"github.com/subculture-collective/clipper/pkg/opensearch"
@@ -21,6 +23,23 @@ type IndexRebuildService struct {
 	osClient       *opensearch.Client
 	indexer        *SearchIndexerService
 	versionService *IndexVersionService
+	synonyms       SearchSynonymSource
+	retention      *IndexRetentionPolicy
+}
+
+// SearchSynonymSource lists the synonyms built into rebuilt indices
+type SearchSynonymSource interface {
+	ListEnabled(ctx context.Context) ([]models.SearchSynonym, error)
+}
+
+// SynonymStatus compares the synonyms an index was built with to the
+// current ones
+type SynonymStatus struct {
+	BaseIndex      string `json:"base_index"`
+	IndexVersion   string `json:"index_version,omitempty"` // Empty when the index predates synonyms
+	CurrentVersion string `json:"current_version"`
+	CurrentRules   int    `json:"current_rules"`
+	Outdated       bool   `json:"outdated"`
 }
 
 // RebuildConfig contains configuration for index rebuild
@@ -78,6 +97,55 @@ func NewIndexRebuildService(db *database.DB, osClient *opensearch.Client) *Index
 	}
 }
 
+// EnableKNN builds the clips index with the kNN vector field and indexes clip
+// embeddings, see SearchIndexerService.EnableKNN
+func (s *IndexRebuildService) EnableKNN(rrfRankConstant int) {
+	s.indexer.EnableKNN(rrfRankConstant)
+}
+
+// SetSynonymSource builds the enabled search synonyms into rebuilt indices
+func (s *IndexRebuildService) SetSynonymSource(synonyms SearchSynonymSource) {
+	s.synonyms = synonyms
+}
+
+// loadSynonyms hands the current synonym rules to the indexer before an
+// index is created
+func (s *IndexRebuildService) loadSynonyms(ctx context.Context) (*models.SearchSynonymRules, error) {
+	if s.synonyms == nil {
+		return s.indexer.synonymRules(), nil
+	}
+	synonyms, err := s.synonyms.ListEnabled(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rules := BuildSearchSynonymRules(synonyms)
+	s.indexer.SetSynonyms(rules)
+	return rules, nil
+}
+
+// GetSynonymStatus reports whether the active version of an index was built
+// with the current synonyms. An outdated index needs a rebuild to apply them.
+func (s *IndexRebuildService) GetSynonymStatus(ctx context.Context, baseIndex string) (*SynonymStatus, error) {
+	rules, err := s.loadSynonyms(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load search synonyms: %w", err)
+	}
+
+	meta, err := s.versionService.GetIndexMeta(ctx, baseIndex)
+	if err != nil {
+		return nil, err
+	}
+	indexVersion, _ := meta[synonymsVersionMeta].(string)
+
+	return &SynonymStatus{
+		BaseIndex:      baseIndex,
+		IndexVersion:   indexVersion,
+		CurrentVersion: rules.Version,
+		CurrentRules:   len(rules.Rules),
+		Outdated:       indexVersion != rules.Version,
+	}, nil
+}
+
 // RebuildClipsIndex rebuilds the clips index with zero-downtime swap
 func (s *IndexRebuildService) RebuildClipsIndex(ctx context.Context, config *RebuildConfig) (*RebuildResult, error) {
 	if config == nil {
@@ -89,6 +157,11 @@ func (s *IndexRebuildService) RebuildClipsIndex(ctx context.Context, config *Reb
 		StartTime: time.Now(),
 	}
 
+	if _, err := s.loadSynonyms(ctx); err != nil {
+		result.Error = fmt.Sprintf("failed to load search synonyms: %v", err)
+		return result, fmt.Errorf("failed to load search synonyms: %w", err)
+	}
+
 	// Get next version
 	nextVersion, err := s.versionService.GetNextVersion(ctx, ClipsIndex)
 	if err != nil {
@@ -102,12 +175,19 @@ func (s *IndexRebuildService) RebuildClipsIndex(ctx context.Context, config *Reb
 	log.Printf("Starting rebuild of %s index (new version: %d)", ClipsIndex, nextVersion)
 
 	// Create new versioned index
-	mapping := getClipIndexMapping()
+	mapping := s.indexer.clipIndexMapping()
 	if err := s.versionService.CreateVersionedIndex(ctx, ClipsIndex, nextVersion, mapping); err != nil {
 		result.Error = fmt.Sprintf("failed to create versioned index: %v", err)
 		return result, fmt.Errorf("failed to create versioned index: %w", err)
 	}
 
+	// Rows changed from here on are left to the next incremental run
+	syncedThrough, err := s.databaseNow(ctx)
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to read database time: %v", err)
+		return result, fmt.Errorf("failed to read database time: %w", err)
+	}
+
 	// Index all clips to the new index
 	docCount, err := s.indexClipsToVersionedIndex(ctx, result.NewIndexName, config)
 	if err != nil {
@@ -129,6 +209,7 @@ func (s *IndexRebuildService) RebuildClipsIndex(ctx context.Context, config *Reb
 			return result, fmt.Errorf("failed to swap alias: %w", err)
 		}
 		result.SwappedAlias = true
+		s.saveRebuildCheckpoint(ctx, ClipsIndex, syncedThrough, docCount)
 	}
 
 	// Clean up old versions
@@ -148,22 +229,63 @@ func (s *IndexRebuildService) RebuildClipsIndex(ctx context.Context, config *Reb
 	return result, nil
 }
 
+// indexClipsQuery selects clips as scanIndexClip reads them, with their
+// accessibility flags, hype score and whether their submitter is shadow banned
+const indexClipsQuery = `
+	SELECT c.id, c.twitch_clip_id, c.twitch_clip_url, c.embed_url, c.title,
+	       c.creator_name, c.creator_id, c.broadcaster_name, c.broadcaster_id,
+	       c.game_id, c.game_name, c.language, c.thumbnail_url, c.duration,
+	       c.view_count, c.created_at, c.imported_at, c.vote_score,
+	       c.comment_count, c.favorite_count, c.is_featured, c.is_nsfw,
+	       c.is_removed, c.removed_reason, c.submitted_by_user_id, c.embedding, c.display_title,
+	       COALESCE(a.has_captions, false), COALESCE(a.photosensitivity_warning, false),
+	       COALESCE(a.audio_description, false), h.hype_score,
+	       COALESCE(su.is_shadow_banned, false)
+	FROM clips c
+	LEFT JOIN clip_accessibility a ON a.clip_id = c.id
+	LEFT JOIN clip_hype_scores h ON h.clip_id = c.id
+	LEFT JOIN users su ON su.id = c.submitted_by_user_id
+`
+
+// scanIndexClip scans a row of indexClipsQuery, reporting whether the clip
+// was submitted by a shadow-banned user and is kept out of search
+func scanIndexClip(rows pgx.Rows) (models.Clip, bool, error) {
+	var clip models.Clip
+	var embedding *pgvector.Vector
+	var accessibility models.ClipAccessibility
+	var shadowBanned bool
+	err := rows.Scan(
+		&clip.ID, &clip.TwitchClipID, &clip.TwitchClipURL, &clip.EmbedURL,
+		&clip.Title, &clip.CreatorName, &clip.CreatorID, &clip.BroadcasterName,
+		&clip.BroadcasterID, &clip.GameID, &clip.GameName, &clip.Language,
+		&clip.ThumbnailURL, &clip.Duration, &clip.ViewCount, &clip.CreatedAt,
+		&clip.ImportedAt, &clip.VoteScore, &clip.CommentCount, &clip.FavoriteCount,
+		&clip.IsFeatured, &clip.IsNSFW, &clip.IsRemoved, &clip.RemovedReason,
+		&clip.SubmittedByUserID, &embedding, &clip.DisplayTitle,
+		&accessibility.HasCaptions, &accessibility.PhotosensitivityWarning,
+		&accessibility.AudioDescription, &clip.HypeScore,
+		&shadowBanned,
+	)
+	if err != nil {
+		return clip, false, err
+	}
+	if embedding != nil {
+		clip.Embedding = embedding.Slice()
+	}
+	accessibility.ClipID = clip.ID
+	clip.Accessibility = &accessibility
+	return clip, shadowBanned, nil
+}
+
 // indexClipsToVersionedIndex indexes all clips to a specific versioned index
 func (s *IndexRebuildService) indexClipsToVersionedIndex(ctx context.Context, indexName string, config *RebuildConfig) (int64, error) {
 	var totalIndexed int64
 	offset := 0
 
 	for {
-		query := `
-			SELECT id, twitch_clip_id, twitch_clip_url, embed_url, title, 
-			       creator_name, creator_id, broadcaster_name, broadcaster_id,
-			       game_id, game_name, language, thumbnail_url, duration,
-			       view_count, created_at, imported_at, vote_score,
-			       comment_count, favorite_count, is_featured, is_nsfw,
-			       is_removed, removed_reason
-			FROM clips
-			WHERE is_removed = false
-			ORDER BY id
+		query := indexClipsQuery + `
+			WHERE c.is_removed = false AND NOT COALESCE(su.is_shadow_banned, false)
+			ORDER BY c.id
 			LIMIT $1 OFFSET $2
 		`
 
@@ -174,15 +296,7 @@ func (s *IndexRebuildService) indexClipsToVersionedIndex(ctx context.Context, in
 
 		var clips []models.Clip
 		for rows.Next() {
-			var clip models.Clip
-			err := rows.Scan(
-				&clip.ID, &clip.TwitchClipID, &clip.TwitchClipURL, &clip.EmbedURL,
-				&clip.Title, &clip.CreatorName, &clip.CreatorID, &clip.BroadcasterName,
-				&clip.BroadcasterID, &clip.GameID, &clip.GameName, &clip.Language,
-				&clip.ThumbnailURL, &clip.Duration, &clip.ViewCount, &clip.CreatedAt,
-				&clip.ImportedAt, &clip.VoteScore, &clip.CommentCount, &clip.FavoriteCount,
-				&clip.IsFeatured, &clip.IsNSFW, &clip.IsRemoved, &clip.RemovedReason,
-			)
+			clip, _, err := scanIndexClip(rows)
 			if err != nil {
 				rows.Close()
 				return totalIndexed, fmt.Errorf("failed to scan clip: %w", err)
@@ -222,54 +336,10 @@ func (s *IndexRebuildService) bulkIndexClipsToIndex(ctx context.Context, indexNa
 	}
 
 	var buf bytes.Buffer
-	for _, clip := range clips {
-		// Action metadata
-		meta := map[string]interface{}{
-			"index": map[string]interface{}{
-				"_index": indexName,
-				"_id":    clip.ID.String(),
-			},
-		}
-		metaJSON, err := json.Marshal(meta)
-		if err != nil {
-			return fmt.Errorf("failed to marshal metadata for clip %s: %w", clip.ID, err)
-		}
-		buf.Write(metaJSON)
-		buf.WriteByte('\n')
-
-		// Document data
-		engagementScore := calculateEngagementScore(&clip)
-		recencyScore := calculateRecencyScore(&clip)
-
-		doc := map[string]interface{}{
-			"id":               clip.ID.String(),
-			"twitch_clip_id":   clip.TwitchClipID,
-			"title":            clip.Title,
-			"creator_name":     clip.CreatorName,
-			"creator_id":       clip.CreatorID,
-			"broadcaster_name": clip.BroadcasterName,
-			"broadcaster_id":   clip.BroadcasterID,
-			"game_id":          clip.GameID,
-			"game_name":        clip.GameName,
-			"language":         clip.Language,
-			"view_count":       clip.ViewCount,
-			"vote_score":       clip.VoteScore,
-			"comment_count":    clip.CommentCount,
-			"favorite_count":   clip.FavoriteCount,
-			"is_featured":      clip.IsFeatured,
-			"is_nsfw":          clip.IsNSFW,
-			"is_removed":       clip.IsRemoved,
-			"created_at":       clip.CreatedAt,
-			"imported_at":      clip.ImportedAt,
-			"engagement_score": engagementScore,
-			"recency_score":    recencyScore,
-		}
-		docJSON, err := json.Marshal(doc)
-		if err != nil {
-			return fmt.Errorf("failed to marshal document for clip %s: %w", clip.ID, err)
+	for i := range clips {
+		if err := s.indexer.writeClipBulkAction(&buf, indexName, &clips[i]); err != nil {
+			return err
 		}
-		buf.Write(docJSON)
-		buf.WriteByte('\n')
 	}
 
 	req := opensearchapi.BulkRequest{
@@ -301,6 +371,11 @@ func (s *IndexRebuildService) RebuildUsersIndex(ctx context.Context, config *Reb
 		StartTime: time.Now(),
 	}
 
+	if _, err := s.loadSynonyms(ctx); err != nil {
+		result.Error = fmt.Sprintf("failed to load search synonyms: %v", err)
+		return result, fmt.Errorf("failed to load search synonyms: %w", err)
+	}
+
 	nextVersion, err := s.versionService.GetNextVersion(ctx, UsersIndex)
 	if err != nil {
 		result.Error = fmt.Sprintf("failed to get next version: %v", err)
@@ -312,12 +387,19 @@ func (s *IndexRebuildService) RebuildUsersIndex(ctx context.Context, config *Reb
 
 	log.Printf("Starting rebuild of %s index (new version: %d)", UsersIndex, nextVersion)
 
-	mapping := getUserIndexMapping()
+	mapping := s.indexer.userIndexMapping()
 	if err := s.versionService.CreateVersionedIndex(ctx, UsersIndex, nextVersion, mapping); err != nil {
 		result.Error = fmt.Sprintf("failed to create versioned index: %v", err)
 		return result, fmt.Errorf("failed to create versioned index: %w", err)
 	}
 
+	// Rows changed from here on are left to the next incremental run
+	syncedThrough, err := s.databaseNow(ctx)
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to read database time: %v", err)
+		return result, fmt.Errorf("failed to read database time: %w", err)
+	}
+
 	docCount, err := s.indexUsersToVersionedIndex(ctx, result.NewIndexName, config)
 	if err != nil {
 		result.Error = fmt.Sprintf("failed to index users: %v", err)
@@ -336,6 +418,7 @@ func (s *IndexRebuildService) RebuildUsersIndex(ctx context.Context, config *Reb
 			return result, fmt.Errorf("failed to swap alias: %w", err)
 		}
 		result.SwappedAlias = true
+		s.saveRebuildCheckpoint(ctx, UsersIndex, syncedThrough, docCount)
 	}
 
 	if config.KeepOldVersions > 0 {
@@ -482,6 +565,11 @@ func (s *IndexRebuildService) RebuildTagsIndex(ctx context.Context, config *Rebu
 		StartTime: time.Now(),
 	}
 
+	if _, err := s.loadSynonyms(ctx); err != nil {
+		result.Error = fmt.Sprintf("failed to load search synonyms: %v", err)
+		return result, fmt.Errorf("failed to load search synonyms: %w", err)
+	}
+
 	nextVersion, err := s.versionService.GetNextVersion(ctx, TagsIndex)
 	if err != nil {
 		result.Error = fmt.Sprintf("failed to get next version: %v", err)
@@ -493,12 +581,19 @@ func (s *IndexRebuildService) RebuildTagsIndex(ctx context.Context, config *Rebu
 
 	log.Printf("Starting rebuild of %s index (new version: %d)", TagsIndex, nextVersion)
 
-	mapping := getTagIndexMapping()
+	mapping := s.indexer.tagIndexMapping()
 	if err := s.versionService.CreateVersionedIndex(ctx, TagsIndex, nextVersion, mapping); err != nil {
 		result.Error = fmt.Sprintf("failed to create versioned index: %v", err)
 		return result, fmt.Errorf("failed to create versioned index: %w", err)
 	}
 
+	// Rows changed from here on are left to the next incremental run
+	syncedThrough, err := s.databaseNow(ctx)
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to read database time: %v", err)
+		return result, fmt.Errorf("failed to read database time: %w", err)
+	}
+
 	docCount, err := s.indexTagsToVersionedIndex(ctx, result.NewIndexName, config)
 	if err != nil {
 		result.Error = fmt.Sprintf("failed to index tags: %v", err)
@@ -517,6 +612,7 @@ func (s *IndexRebuildService) RebuildTagsIndex(ctx context.Context, config *Rebu
 			return result, fmt.Errorf("failed to swap alias: %w", err)
 		}
 		result.SwappedAlias = true
+		s.saveRebuildCheckpoint(ctx, TagsIndex, syncedThrough, docCount)
 	}
 
 	if config.KeepOldVersions > 0 {
@@ -655,6 +751,11 @@ func (s *IndexRebuildService) RebuildGamesIndex(ctx context.Context, config *Reb
 		StartTime: time.Now(),
 	}
 
+	if _, err := s.loadSynonyms(ctx); err != nil {
+		result.Error = fmt.Sprintf("failed to load search synonyms: %v", err)
+		return result, fmt.Errorf("failed to load search synonyms: %w", err)
+	}
+
 	nextVersion, err := s.versionService.GetNextVersion(ctx, GamesIndex)
 	if err != nil {
 		result.Error = fmt.Sprintf("failed to get next version: %v", err)
@@ -666,12 +767,19 @@ func (s *IndexRebuildService) RebuildGamesIndex(ctx context.Context, config *Reb
 
 	log.Printf("Starting rebuild of %s index (new version: %d)", GamesIndex, nextVersion)
 
-	mapping := getGameIndexMapping()
+	mapping := s.indexer.gameIndexMapping()
 	if err := s.versionService.CreateVersionedIndex(ctx, GamesIndex, nextVersion, mapping); err != nil {
 		result.Error = fmt.Sprintf("failed to create versioned index: %v", err)
 		return result, fmt.Errorf("failed to create versioned index: %w", err)
 	}
 
+	// Rows changed from here on are left to the next incremental run
+	syncedThrough, err := s.databaseNow(ctx)
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to read database time: %v", err)
+		return result, fmt.Errorf("failed to read database time: %w", err)
+	}
+
 	docCount, err := s.indexGamesToVersionedIndex(ctx, result.NewIndexName, config)
 	if err != nil {
 		result.Error = fmt.Sprintf("failed to index games: %v", err)
@@ -690,6 +798,7 @@ func (s *IndexRebuildService) RebuildGamesIndex(ctx context.Context, config *Reb
 			return result, fmt.Errorf("failed to swap alias: %w", err)
 		}
 		result.SwappedAlias = true
+		s.saveRebuildCheckpoint(ctx, GamesIndex, syncedThrough, docCount)
 	}
 
 	if config.KeepOldVersions > 0 {
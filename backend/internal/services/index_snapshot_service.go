@@ -0,0 +1,582 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+	"github.com/subculture-collective/clipper/pkg/opensearch"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+var (
+	// ErrSnapshotRepositoryMissing is returned when the snapshot repository has not been registered
+	ErrSnapshotRepositoryMissing = errors.New("snapshot repository is not registered")
+	// ErrSnapshotNotFound is returned when no snapshot matches the request
+	ErrSnapshotNotFound = errors.New("snapshot not found")
+)
+
+// Snapshot repository types supported by the snapshot service
+const (
+	SnapshotRepositoryTypeFS = "fs"
+	SnapshotRepositoryTypeS3 = "s3"
+)
+
+// IndexSnapshotService manages OpenSearch snapshots of the versioned search indices
+type IndexSnapshotService struct {
+	osClient *opensearch.Client
+	config   SnapshotConfig
+}
+
+// SnapshotConfig contains snapshot repository and policy settings
+type SnapshotConfig struct {
+	Repository     string `json:"repository"`
+	RepositoryType string `json:"repository_type"`
+	Location       string `json:"location"`  // Filesystem path (fs) or bucket name (s3)
+	BasePath       string `json:"base_path"` // Key prefix inside the bucket (s3 only)
+	Schedule       string `json:"schedule"`  // Cron expression for the scheduled snapshot policy
+	RetentionCount int    `json:"retention_count"`
+	RetentionAge   string `json:"retention_age"`
+}
+
+// SnapshotInfo describes a single snapshot
+type SnapshotInfo struct {
+	Name      string    `json:"name"`
+	State     string    `json:"state"`
+	Indices   []string  `json:"indices"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time,omitempty"`
+	Failures  int       `json:"failures"`
+}
+
+// SnapshotStatus summarizes the snapshot repository for status output
+type SnapshotStatus struct {
+	Repository     string        `json:"repository"`
+	Registered     bool          `json:"registered"`
+	TotalSnapshots int           `json:"total_snapshots"`
+	LatestSnapshot *SnapshotInfo `json:"latest_snapshot,omitempty"`
+	PolicyName     string        `json:"policy_name"`
+	PolicyEnabled  bool          `json:"policy_enabled"`
+	PolicyExists   bool          `json:"policy_exists"`
+}
+
+// DefaultSnapshotConfig returns the default snapshot configuration
+func DefaultSnapshotConfig() SnapshotConfig {
+	return SnapshotConfig{
+		Repository:     "clipper-search-snapshots",
+		RepositoryType: SnapshotRepositoryTypeFS,
+		Location:       "/usr/share/opensearch/snapshots",
+		Schedule:       "0 3 * * *",
+		RetentionCount: 14,
+		RetentionAge:   "30d",
+	}
+}
+
+// NewIndexSnapshotService creates a new IndexSnapshotService
+func NewIndexSnapshotService(osClient *opensearch.Client, config SnapshotConfig) *IndexSnapshotService {
+	defaults := DefaultSnapshotConfig()
+	if config.Repository == "" {
+		config.Repository = defaults.Repository
+	}
+	if config.RepositoryType == "" {
+		config.RepositoryType = defaults.RepositoryType
+	}
+	if config.Schedule == "" {
+		config.Schedule = defaults.Schedule
+	}
+	if config.RetentionCount <= 0 {
+		config.RetentionCount = defaults.RetentionCount
+	}
+	if config.RetentionAge == "" {
+		config.RetentionAge = defaults.RetentionAge
+	}
+
+	return &IndexSnapshotService{
+		osClient: osClient,
+		config:   config,
+	}
+}
+
+// Config returns the effective snapshot configuration
+func (s *IndexSnapshotService) Config() SnapshotConfig {
+	return s.config
+}
+
+// PolicyName returns the name of the scheduled snapshot policy
+func (s *IndexSnapshotService) PolicyName() string {
+	return s.config.Repository + "-daily"
+}
+
+// buildRepositoryBody returns the repository registration request body
+func buildRepositoryBody(config SnapshotConfig) (map[string]interface{}, error) {
+	settings := map[string]interface{}{}
+
+	switch config.RepositoryType {
+	case SnapshotRepositoryTypeFS:
+		if config.Location == "" {
+			return nil, fmt.Errorf("location is required for %s repositories", config.RepositoryType)
+		}
+		settings["location"] = config.Location
+	case SnapshotRepositoryTypeS3:
+		if config.Location == "" {
+			return nil, fmt.Errorf("bucket is required for %s repositories", config.RepositoryType)
+		}
+		settings["bucket"] = config.Location
+		if config.BasePath != "" {
+			settings["base_path"] = config.BasePath
+		}
+	default:
+		return nil, fmt.Errorf("unsupported snapshot repository type: %s", config.RepositoryType)
+	}
+
+	return map[string]interface{}{
+		"type":     config.RepositoryType,
+		"settings": settings,
+	}, nil
+}
+
+// snapshotIndexPatterns returns the index patterns covered by snapshots
+func snapshotIndexPatterns() []string {
+	return []string{ClipsIndex + "_v*", UsersIndex + "_v*", TagsIndex + "_v*", GamesIndex + "_v*"}
+}
+
+// buildSnapshotPolicyBody returns the snapshot management policy request body
+func buildSnapshotPolicyBody(config SnapshotConfig) map[string]interface{} {
+	return map[string]interface{}{
+		"description": "Scheduled snapshots of versioned search indices",
+		"enabled":     true,
+		"creation": map[string]interface{}{
+			"schedule": map[string]interface{}{
+				"cron": map[string]interface{}{
+					"expression": config.Schedule,
+					"timezone":   "UTC",
+				},
+			},
+		},
+		"deletion": map[string]interface{}{
+			"schedule": map[string]interface{}{
+				"cron": map[string]interface{}{
+					"expression": config.Schedule,
+					"timezone":   "UTC",
+				},
+			},
+			"condition": map[string]interface{}{
+				"max_age":   config.RetentionAge,
+				"max_count": config.RetentionCount,
+				"min_count": 1,
+			},
+		},
+		"snapshot_config": map[string]interface{}{
+			"date_format":          "yyyy-MM-dd-HH-mm",
+			"timezone":             "UTC",
+			"indices":              strings.Join(snapshotIndexPatterns(), ","),
+			"repository":           config.Repository,
+			"ignore_unavailable":   "true",
+			"include_global_state": "false",
+		},
+	}
+}
+
+// SnapshotName builds a snapshot name such as "pre-rebuild-clips-20260101-030000"
+func SnapshotName(prefix, baseIndex string, at time.Time) string {
+	if baseIndex == "" {
+		baseIndex = "all"
+	}
+	return strings.ToLower(fmt.Sprintf("%s-%s-%s", prefix, baseIndex, at.UTC().Format("20060102-150405")))
+}
+
+// RegisterRepository registers (or updates) the snapshot repository
+func (s *IndexSnapshotService) RegisterRepository(ctx context.Context) error {
+	body, err := buildRepositoryBody(s.config)
+	if err != nil {
+		return err
+	}
+
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal repository body: %w", err)
+	}
+
+	verify := true
+	req := opensearchapi.SnapshotCreateRepositoryRequest{
+		Repository: s.config.Repository,
+		Body:       bytes.NewReader(bodyBytes),
+		Verify:     &verify,
+	}
+
+	res, err := req.Do(ctx, s.osClient.GetClient())
+	if err != nil {
+		return fmt.Errorf("failed to register snapshot repository: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		resBody, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("failed to register snapshot repository: %s - %s", res.Status(), string(resBody))
+	}
+
+	utils.Info("Registered snapshot repository", map[string]interface{}{
+		"repository": s.config.Repository,
+		"type":       s.config.RepositoryType,
+	})
+	return nil
+}
+
+// RepositoryExists checks whether the snapshot repository is registered
+func (s *IndexSnapshotService) RepositoryExists(ctx context.Context) (bool, error) {
+	req := opensearchapi.SnapshotGetRepositoryRequest{
+		Repository: []string{s.config.Repository},
+	}
+
+	res, err := req.Do(ctx, s.osClient.GetClient())
+	if err != nil {
+		return false, fmt.Errorf("failed to get snapshot repository: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return false, fmt.Errorf("failed to get snapshot repository: %s - %s", res.Status(), string(body))
+	}
+
+	return true, nil
+}
+
+// CreateSnapshot takes a named snapshot of the given indices and waits for it to complete
+func (s *IndexSnapshotService) CreateSnapshot(ctx context.Context, name string, indices []string) (*SnapshotInfo, error) {
+	exists, err := s.RepositoryExists(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrSnapshotRepositoryMissing
+	}
+
+	if len(indices) == 0 {
+		indices = snapshotIndexPatterns()
+	}
+
+	bodyBytes, err := json.Marshal(map[string]interface{}{
+		"indices":              strings.Join(indices, ","),
+		"ignore_unavailable":   true,
+		"include_global_state": false,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal snapshot body: %w", err)
+	}
+
+	wait := true
+	req := opensearchapi.SnapshotCreateRequest{
+		Repository:        s.config.Repository,
+		Snapshot:          name,
+		Body:              bytes.NewReader(bodyBytes),
+		WaitForCompletion: &wait,
+	}
+
+	res, err := req.Do(ctx, s.osClient.GetClient())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create snapshot: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("failed to create snapshot: %s - %s", res.Status(), string(body))
+	}
+
+	var created struct {
+		Snapshot rawSnapshot `json:"snapshot"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&created); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot response: %w", err)
+	}
+
+	info := created.Snapshot.toInfo()
+	if info.State != "SUCCESS" {
+		return &info, fmt.Errorf("snapshot %s finished in state %s", name, info.State)
+	}
+
+	utils.Info("Created search index snapshot", map[string]interface{}{
+		"repository": s.config.Repository,
+		"snapshot":   name,
+		"indices":    info.Indices,
+	})
+	return &info, nil
+}
+
+// ListSnapshots returns all snapshots in the repository, newest first
+func (s *IndexSnapshotService) ListSnapshots(ctx context.Context) ([]SnapshotInfo, error) {
+	req := opensearchapi.SnapshotGetRequest{
+		Repository: s.config.Repository,
+		Snapshot:   []string{"_all"},
+	}
+
+	res, err := req.Do(ctx, s.osClient.GetClient())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, ErrSnapshotRepositoryMissing
+	}
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("failed to list snapshots: %s - %s", res.Status(), string(body))
+	}
+
+	var listed struct {
+		Snapshots []rawSnapshot `json:"snapshots"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&listed); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshots response: %w", err)
+	}
+
+	snapshots := make([]SnapshotInfo, 0, len(listed.Snapshots))
+	for _, raw := range listed.Snapshots {
+		snapshots = append(snapshots, raw.toInfo())
+	}
+	sortSnapshotsNewestFirst(snapshots)
+
+	return snapshots, nil
+}
+
+// FindLatestSnapshotWithIndex returns the newest successful snapshot containing the index
+func (s *IndexSnapshotService) FindLatestSnapshotWithIndex(ctx context.Context, indexName string) (*SnapshotInfo, error) {
+	snapshots, err := s.ListSnapshots(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return latestSnapshotWithIndex(snapshots, indexName)
+}
+
+// latestSnapshotWithIndex picks the newest successful snapshot containing the index
+func latestSnapshotWithIndex(snapshots []SnapshotInfo, indexName string) (*SnapshotInfo, error) {
+	sorted := append([]SnapshotInfo(nil), snapshots...)
+	sortSnapshotsNewestFirst(sorted)
+
+	for i := range sorted {
+		if sorted[i].State != "SUCCESS" {
+			continue
+		}
+		for _, idx := range sorted[i].Indices {
+			if idx == indexName {
+				return &sorted[i], nil
+			}
+		}
+	}
+
+	return nil, ErrSnapshotNotFound
+}
+
+// RestoreIndex restores a single index from a snapshot and waits for completion.
+// The index must not exist in the cluster.
+func (s *IndexSnapshotService) RestoreIndex(ctx context.Context, snapshotName, indexName string) error {
+	bodyBytes, err := json.Marshal(map[string]interface{}{
+		"indices":              indexName,
+		"include_global_state": false,
+		"include_aliases":      false,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal restore body: %w", err)
+	}
+
+	wait := true
+	req := opensearchapi.SnapshotRestoreRequest{
+		Repository:        s.config.Repository,
+		Snapshot:          snapshotName,
+		Body:              bytes.NewReader(bodyBytes),
+		WaitForCompletion: &wait,
+	}
+
+	res, err := req.Do(ctx, s.osClient.GetClient())
+	if err != nil {
+		return fmt.Errorf("failed to restore snapshot: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return ErrSnapshotNotFound
+	}
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("failed to restore snapshot: %s - %s", res.Status(), string(body))
+	}
+
+	utils.Info("Restored search index from snapshot", map[string]interface{}{
+		"repository": s.config.Repository,
+		"snapshot":   snapshotName,
+		"index":      indexName,
+	})
+	return nil
+}
+
+// ApplySnapshotPolicy creates or updates the scheduled snapshot management policy
+func (s *IndexSnapshotService) ApplySnapshotPolicy(ctx context.Context) error {
+	bodyBytes, err := json.Marshal(buildSnapshotPolicyBody(s.config))
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot policy: %w", err)
+	}
+
+	path := "/_plugins/_sm/policies/" + s.PolicyName()
+	method := http.MethodPost
+
+	// Updating an existing policy requires its sequence number and primary term
+	existing, err := s.getPolicy(ctx)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		method = http.MethodPut
+		path = fmt.Sprintf("%s?if_seq_no=%d&if_primary_term=%d", path, existing.SeqNo, existing.PrimaryTerm)
+	}
+
+	res, err := s.perform(ctx, method, path, bodyBytes)
+	if err != nil {
+		return fmt.Errorf("failed to apply snapshot policy: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		body, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("failed to apply snapshot policy: %s - %s", res.Status, string(body))
+	}
+
+	utils.Info("Applied snapshot policy", map[string]interface{}{
+		"policy":   s.PolicyName(),
+		"schedule": s.config.Schedule,
+	})
+	return nil
+}
+
+// GetSnapshotStatus summarizes the repository, latest snapshot, and scheduled policy
+func (s *IndexSnapshotService) GetSnapshotStatus(ctx context.Context) (*SnapshotStatus, error) {
+	status := &SnapshotStatus{
+		Repository: s.config.Repository,
+		PolicyName: s.PolicyName(),
+	}
+
+	registered, err := s.RepositoryExists(ctx)
+	if err != nil {
+		return nil, err
+	}
+	status.Registered = registered
+	if !registered {
+		return status, nil
+	}
+
+	snapshots, err := s.ListSnapshots(ctx)
+	if err != nil {
+		return nil, err
+	}
+	status.TotalSnapshots = len(snapshots)
+	if len(snapshots) > 0 {
+		status.LatestSnapshot = &snapshots[0]
+	}
+
+	policy, err := s.getPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if policy != nil {
+		status.PolicyExists = true
+		status.PolicyEnabled = policy.Policy.Enabled
+	}
+
+	return status, nil
+}
+
+// snapshotPolicy is the subset of the snapshot management policy response we use
+type snapshotPolicy struct {
+	SeqNo       int64 `json:"_seq_no"`
+	PrimaryTerm int64 `json:"_primary_term"`
+	Policy      struct {
+		Enabled bool `json:"enabled"`
+	} `json:"sm_policy"`
+}
+
+// getPolicy fetches the snapshot management policy, returning nil if it does not exist
+func (s *IndexSnapshotService) getPolicy(ctx context.Context) (*snapshotPolicy, error) {
+	res, err := s.perform(ctx, http.MethodGet, "/_plugins/_sm/policies/"+s.PolicyName(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshot policy: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.StatusCode >= 300 {
+		body, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("failed to get snapshot policy: %s - %s", res.Status, string(body))
+	}
+
+	var policy snapshotPolicy
+	if err := json.NewDecoder(res.Body).Decode(&policy); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot policy: %w", err)
+	}
+	return &policy, nil
+}
+
+// perform sends a raw request for plugin APIs that have no typed client
+func (s *IndexSnapshotService) perform(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, path, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return s.osClient.GetClient().Perform(req)
+}
+
+// rawSnapshot is a snapshot entry as returned by the OpenSearch snapshot API
+type rawSnapshot struct {
+	Snapshot          string        `json:"snapshot"`
+	State             string        `json:"state"`
+	Indices           []string      `json:"indices"`
+	StartTimeInMillis int64         `json:"start_time_in_millis"`
+	EndTimeInMillis   int64         `json:"end_time_in_millis"`
+	Failures          []interface{} `json:"failures"`
+}
+
+// toInfo converts a raw snapshot entry to SnapshotInfo
+func (r rawSnapshot) toInfo() SnapshotInfo {
+	info := SnapshotInfo{
+		Name:      r.Snapshot,
+		State:     r.State,
+		Indices:   r.Indices,
+		StartTime: time.UnixMilli(r.StartTimeInMillis).UTC(),
+		Failures:  len(r.Failures),
+	}
+	if r.EndTimeInMillis > 0 {
+		info.EndTime = time.UnixMilli(r.EndTimeInMillis).UTC()
+	}
+	sort.Strings(info.Indices)
+	return info
+}
+
+// sortSnapshotsNewestFirst sorts snapshots by start time, newest first
+func sortSnapshotsNewestFirst(snapshots []SnapshotInfo) {
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].StartTime.After(snapshots[j].StartTime)
+	})
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotName(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	assert.Equal(t, "pre-rebuild-clips-20260102-030405", SnapshotName("pre-rebuild", "clips", at))
+	assert.Equal(t, "manual-all-20260102-030405", SnapshotName("manual", "", at))
+	assert.Equal(t, "pre-rebuild-all-20260102-030405", SnapshotName("Pre-Rebuild", "all", at))
+}
+
+func TestBuildRepositoryBody(t *testing.T) {
+	body, err := buildRepositoryBody(SnapshotConfig{RepositoryType: SnapshotRepositoryTypeFS, Location: "/snapshots"})
+	require.NoError(t, err)
+	assert.Equal(t, "fs", body["type"])
+	assert.Equal(t, map[string]interface{}{"location": "/snapshots"}, body["settings"])
+
+	body, err = buildRepositoryBody(SnapshotConfig{RepositoryType: SnapshotRepositoryTypeS3, Location: "clipper-backups", BasePath: "search"})
+	require.NoError(t, err)
+	assert.Equal(t, "s3", body["type"])
+	assert.Equal(t, map[string]interface{}{"bucket": "clipper-backups", "base_path": "search"}, body["settings"])
+
+	_, err = buildRepositoryBody(SnapshotConfig{RepositoryType: SnapshotRepositoryTypeFS})
+	assert.Error(t, err)
+
+	_, err = buildRepositoryBody(SnapshotConfig{RepositoryType: "hdfs", Location: "/x"})
+	assert.Error(t, err)
+}
+
+func TestBuildSnapshotPolicyBody(t *testing.T) {
+	config := DefaultSnapshotConfig()
+	body := buildSnapshotPolicyBody(config)
+
+	snapshotConfig := body["snapshot_config"].(map[string]interface{})
+	assert.Equal(t, config.Repository, snapshotConfig["repository"])
+	assert.Equal(t, "clips_v*,users_v*,tags_v*,games_v*", snapshotConfig["indices"])
+
+	deletion := body["deletion"].(map[string]interface{})
+	condition := deletion["condition"].(map[string]interface{})
+	assert.Equal(t, config.RetentionCount, condition["max_count"])
+	assert.Equal(t, config.RetentionAge, condition["max_age"])
+}
+
+func TestNewIndexSnapshotServiceDefaults(t *testing.T) {
+	service := NewIndexSnapshotService(nil, SnapshotConfig{Location: "/snapshots"})
+	config := service.Config()
+
+	assert.Equal(t, "clipper-search-snapshots", config.Repository)
+	assert.Equal(t, SnapshotRepositoryTypeFS, config.RepositoryType)
+	assert.Equal(t, "/snapshots", config.Location)
+	assert.Equal(t, 14, config.RetentionCount)
+	assert.Equal(t, "clipper-search-snapshots-daily", service.PolicyName())
+}
+
+func TestLatestSnapshotWithIndex(t *testing.T) {
+	now := time.Now()
+	snapshots := []SnapshotInfo{
+		{Name: "old", State: "SUCCESS", Indices: []string{"clips_v2", "users_v1"}, StartTime: now.Add(-48 * time.Hour)},
+		{Name: "newer", State: "SUCCESS", Indices: []string{"clips_v2", "clips_v3"}, StartTime: now.Add(-24 * time.Hour)},
+		{Name: "newest-failed", State: "FAILED", Indices: []string{"clips_v2"}, StartTime: now},
+	}
+
+	snapshot, err := latestSnapshotWithIndex(snapshots, "clips_v2")
+	require.NoError(t, err)
+	assert.Equal(t, "newer", snapshot.Name)
+
+	snapshot, err = latestSnapshotWithIndex(snapshots, "users_v1")
+	require.NoError(t, err)
+	assert.Equal(t, "old", snapshot.Name)
+
+	_, err = latestSnapshotWithIndex(snapshots, "tags_v1")
+	assert.ErrorIs(t, err, ErrSnapshotNotFound)
+}
+
+func TestRawSnapshotToInfo(t *testing.T) {
+	raw := rawSnapshot{
+		Snapshot:          "pre-rebuild-clips",
+		State:             "SUCCESS",
+		Indices:           []string{"users_v1", "clips_v2"},
+		StartTimeInMillis: 1700000000000,
+		EndTimeInMillis:   1700000005000,
+	}
+
+	info := raw.toInfo()
+	assert.Equal(t, "pre-rebuild-clips", info.Name)
+	assert.Equal(t, []string{"clips_v2", "users_v1"}, info.Indices)
+	assert.Equal(t, 5*time.Second, info.EndTime.Sub(info.StartTime))
+	assert.Equal(t, 0, info.Failures)
+}
@@ -75,6 +75,7 @@ func (s *IndexVersionService) GetIndexVersionInfo(ctx context.Context, baseIndex
 	req := opensearchapi.CatIndicesRequest{
 		Index:  []string{pattern},
 		Format: "json",
+		H:      []string{"index", "docs.count", "store.size", "pri.store.size", "creation.date"},
 	}
 
 	res, err := req.Do(ctx, s.osClient.GetClient())
@@ -97,6 +98,8 @@ func (s *IndexVersionService) GetIndexVersionInfo(ctx context.Context, baseIndex
 		DocsCount  string `json:"docs.count"`
 		StoreSize  string `json:"store.size"`
 		StoreBytes string `json:"pri.store.size"`
+		// CreationDate is in epoch milliseconds
+		CreationDate string `json:"creation.date"`
 	}
 
 	if err := json.NewDecoder(res.Body).Decode(&indices); err != nil {
@@ -144,10 +147,17 @@ func (s *IndexVersionService) GetIndexVersionInfo(ctx context.Context, baseIndex
 		var sizeBytes int64
 		fmt.Sscanf(idx.StoreBytes, "%d", &sizeBytes)
 
+		var createdAt time.Time
+		var creationMillis int64
+		if _, err := fmt.Sscanf(idx.CreationDate, "%d", &creationMillis); err == nil && creationMillis > 0 {
+			createdAt = time.UnixMilli(creationMillis).UTC()
+		}
+
 		indexVersion := IndexVersion{
 			Name:      idx.Index,
 			Version:   version,
 			Alias:     baseIndex,
+			CreatedAt: createdAt,
 			DocCount:  docCount,
 			SizeBytes: sizeBytes,
 			IsActive:  idx.Index == activeIndex,
@@ -325,32 +335,49 @@ func (s *IndexVersionService) RollbackAlias(ctx context.Context, baseIndex strin
 	return s.SwapAlias(ctx, baseIndex, targetVersion)
 }
 
+// IndexRetentionPolicy decides which old versions of an index are deleted.
+// The active version is never deleted.
+type IndexRetentionPolicy struct {
+	// KeepVersions is how many of the newest versions are always kept
+	KeepVersions int `json:"keep_versions"`
+	// MinAge keeps older versions until they are this old, so a recent
+	// rebuild can still be rolled back. Zero deletes them right away.
+	MinAge time.Duration `json:"min_age"`
+}
+
+// ExpiredVersions returns the versions the policy deletes from versions
+// sorted newest first. With a MinAge, versions of unknown age are kept.
+func (p IndexRetentionPolicy) ExpiredVersions(versions []IndexVersion, now time.Time) []IndexVersion {
+	expired := []IndexVersion{}
+	for i, version := range versions {
+		if i < p.KeepVersions || version.IsActive {
+			continue
+		}
+		if p.MinAge > 0 && (version.CreatedAt.IsZero() || now.Sub(version.CreatedAt) < p.MinAge) {
+			continue
+		}
+		expired = append(expired, version)
+	}
+	return expired
+}
+
 // DeleteOldVersions deletes old index versions, keeping the specified number of recent versions
 func (s *IndexVersionService) DeleteOldVersions(ctx context.Context, baseIndex string, keepCount int) ([]string, error) {
+	return s.ApplyRetentionPolicy(ctx, baseIndex, IndexRetentionPolicy{KeepVersions: keepCount})
+}
+
+// ApplyRetentionPolicy deletes the versions of an index the policy expires
+// and returns their names
+func (s *IndexVersionService) ApplyRetentionPolicy(ctx context.Context, baseIndex string, policy IndexRetentionPolicy) ([]string, error) {
 	info, err := s.GetIndexVersionInfo(ctx, baseIndex)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get index info: %w", err)
 	}
 
-	// Sort by version (already sorted descending)
-	// Skip first keepCount versions and active version
 	deletedIndices := []string{}
 
-	versionsToDelete := []IndexVersion{}
-	for i, version := range info.AllVersions {
-		// Skip if within keepCount
-		if i < keepCount {
-			continue
-		}
-		// Skip if active
-		if version.IsActive {
-			continue
-		}
-		versionsToDelete = append(versionsToDelete, version)
-	}
-
 	// Delete old versions
-	for _, version := range versionsToDelete {
+	for _, version := range policy.ExpiredVersions(info.AllVersions, time.Now()) {
 		deleteReq := opensearchapi.IndicesDeleteRequest{
 			Index: []string{version.Name},
 		}
@@ -407,6 +434,43 @@ func (s *IndexVersionService) RefreshIndex(ctx context.Context, indexName string
 	return nil
 }
 
+// GetIndexMeta returns the _meta of an index mapping, resolving aliases. It
+// returns nil when the index doesn't exist.
+func (s *IndexVersionService) GetIndexMeta(ctx context.Context, index string) (map[string]interface{}, error) {
+	req := opensearchapi.IndicesGetMappingRequest{
+		Index: []string{index},
+	}
+
+	res, err := req.Do(ctx, s.osClient.GetClient())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get index mapping: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 404 {
+		return nil, nil
+	}
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("failed to get index mapping: %s - %s", res.Status(), string(body))
+	}
+
+	var mappings map[string]struct {
+		Mappings struct {
+			Meta map[string]interface{} `json:"_meta"`
+		} `json:"mappings"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&mappings); err != nil {
+		return nil, fmt.Errorf("failed to parse index mapping: %w", err)
+	}
+
+	// An alias resolves to the single index it points at
+	for _, mapping := range mappings {
+		return mapping.Mappings.Meta, nil
+	}
+	return nil, nil
+}
+
 // GetVersionedIndexName returns the versioned index name for a given base index and version
 func (s *IndexVersionService) GetVersionedIndexName(baseIndex string, version int) string {
 	return getVersionedIndexName(baseIndex, version)
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/subculture-collective/clipper/internal/models"
 )
 
 func TestGetVersionedIndexName(t *testing.T) {
@@ -257,3 +258,40 @@ func TestIndexConstants(t *testing.T) {
 	assert.Equal(t, "games", GamesIndex)
 	assert.Equal(t, "tags", TagsIndex)
 }
+
+func TestIndexRetentionPolicy_ExpiredVersions(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	versions := []IndexVersion{
+		{Name: "clips_v5", Version: 5, CreatedAt: now.Add(-time.Hour)},
+		{Name: "clips_v4", Version: 4, CreatedAt: now.Add(-24 * time.Hour), IsActive: true},
+		{Name: "clips_v3", Version: 3, CreatedAt: now.Add(-48 * time.Hour)},
+		{Name: "clips_v2", Version: 2, CreatedAt: now.Add(-96 * time.Hour)},
+		{Name: "clips_v1", Version: 1},
+	}
+	names := func(expired []IndexVersion) []string {
+		result := []string{}
+		for _, v := range expired {
+			result = append(result, v.Name)
+		}
+		return result
+	}
+
+	// Without a minimum age everything past the newest versions goes, except the active one
+	policy := IndexRetentionPolicy{KeepVersions: 1}
+	assert.Equal(t, []string{"clips_v3", "clips_v2", "clips_v1"}, names(policy.ExpiredVersions(versions, now)))
+
+	// Recent versions and those of unknown age are kept for rollback
+	policy = IndexRetentionPolicy{KeepVersions: 1, MinAge: 72 * time.Hour}
+	assert.Equal(t, []string{"clips_v2"}, names(policy.ExpiredVersions(versions, now)))
+
+	policy = IndexRetentionPolicy{KeepVersions: 5}
+	assert.Empty(t, policy.ExpiredVersions(versions, now))
+}
+
+func TestIncrementalSince(t *testing.T) {
+	assert.True(t, incrementalSince(nil).IsZero())
+
+	syncedThrough := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	since := incrementalSince(&models.SearchSyncState{SyncedThrough: syncedThrough})
+	assert.Equal(t, syncedThrough.Add(-incrementalSyncOverlap), since)
+}
@@ -0,0 +1,404 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/pkg/metrics"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const (
+	// jobQueueDefaultMaxAttempts is how many times a job runs before it is failed
+	jobQueueDefaultMaxAttempts = 5
+	// jobQueueBaseBackoff is the delay before the first retry; it doubles per attempt
+	jobQueueBaseBackoff = 30 * time.Second
+	// jobQueueMaxBackoff caps the delay between retries
+	jobQueueMaxBackoff = time.Hour
+	// jobQueueMaintenanceInterval is how often stale jobs are rescued and old jobs deleted
+	jobQueueMaintenanceInterval = time.Minute
+	// jobQueueMetricName labels the queue's job metrics
+	jobQueueMetricName = "job_queue"
+)
+
+// JobHandler runs one job. A returned error schedules a retry with backoff
+// until the job runs out of attempts.
+type JobHandler func(ctx context.Context, job *models.Job) error
+
+// JobRepositoryInterface defines the repository methods used by JobQueueService
+type JobRepositoryInterface interface {
+	Enqueue(ctx context.Context, job *models.Job) (bool, error)
+	Dequeue(ctx context.Context, workerID string, kinds []string, limit int) ([]models.Job, error)
+	Complete(ctx context.Context, id uuid.UUID) error
+	Retry(ctx context.Context, id uuid.UUID, runAt time.Time, lastError string) error
+	Fail(ctx context.Context, id uuid.UUID, lastError string) error
+	RescueStale(ctx context.Context, staleAfter time.Duration) (int64, error)
+	DeleteFinished(ctx context.Context, olderThan time.Duration) (int64, error)
+	CountPendingByKind(ctx context.Context) (map[string]int, error)
+	ClaimDueSchedule(ctx context.Context, name string, interval time.Duration) (bool, error)
+}
+
+// EnqueueOption customizes a job being enqueued
+type EnqueueOption func(*models.Job)
+
+// WithRunAt delays a job until the given time
+func WithRunAt(runAt time.Time) EnqueueOption {
+	return func(job *models.Job) {
+		job.RunAt = runAt
+	}
+}
+
+// WithMaxAttempts overrides how many times a job runs before it is failed
+func WithMaxAttempts(maxAttempts int) EnqueueOption {
+	return func(job *models.Job) {
+		job.MaxAttempts = maxAttempts
+	}
+}
+
+// WithUniqueKey skips the enqueue while a pending or running job has the same key
+func WithUniqueKey(key string) EnqueueOption {
+	return func(job *models.Job) {
+		job.UniqueKey = &key
+	}
+}
+
+// periodicJob is a job enqueued on a fixed interval
+type periodicJob struct {
+	name     string
+	kind     string
+	interval time.Duration
+}
+
+// JobQueueService runs jobs from the persistent job queue. Any number of worker
+// processes can run it against the same database: jobs are claimed with row
+// locks, and periodic jobs are enqueued by whichever worker claims the schedule.
+type JobQueueService struct {
+	repo         JobRepositoryInterface
+	workerID     string
+	workers      int
+	pollInterval time.Duration
+	staleAfter   time.Duration
+	retention    time.Duration
+
+	mu       sync.RWMutex
+	handlers map[string]JobHandler
+	periodic []periodicJob
+}
+
+// NewJobQueueService creates a new JobQueueService
+func NewJobQueueService(
+	repo JobRepositoryInterface,
+	workerID string,
+	workers int,
+	pollInterval time.Duration,
+	staleAfter time.Duration,
+	retention time.Duration,
+) *JobQueueService {
+	if workers < 1 {
+		workers = 1
+	}
+	return &JobQueueService{
+		repo:         repo,
+		workerID:     workerID,
+		workers:      workers,
+		pollInterval: pollInterval,
+		staleAfter:   staleAfter,
+		retention:    retention,
+		handlers:     map[string]JobHandler{},
+	}
+}
+
+// RegisterHandler sets the handler for a job kind. Only registered kinds are
+// picked up by this process.
+func (s *JobQueueService) RegisterHandler(kind string, handler JobHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[kind] = handler
+}
+
+// RegisterPeriodic enqueues a job of the given kind every interval. The name
+// identifies the schedule across workers and doubles as the job's unique key,
+// so a run is skipped while the previous one is still queued or running.
+func (s *JobQueueService) RegisterPeriodic(name, kind string, interval time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.periodic = append(s.periodic, periodicJob{name: name, kind: kind, interval: interval})
+}
+
+// Enqueue adds a job to the queue, reporting false when it was skipped because
+// of its unique key
+func (s *JobQueueService) Enqueue(ctx context.Context, kind string, payload interface{}, opts ...EnqueueOption) (bool, error) {
+	if payload == nil {
+		payload = map[string]interface{}{}
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal job payload: %w", err)
+	}
+
+	job := &models.Job{
+		Kind:        kind,
+		Payload:     data,
+		MaxAttempts: jobQueueDefaultMaxAttempts,
+		RunAt:       time.Now(),
+	}
+	for _, opt := range opts {
+		opt(job)
+	}
+
+	return s.repo.Enqueue(ctx, job)
+}
+
+// Start runs the workers and schedules until the context is cancelled, then
+// waits for running jobs to return
+func (s *JobQueueService) Start(ctx context.Context) {
+	kinds := s.kinds()
+	logger := utils.GetLogger()
+	logger.Info("Starting job queue workers", map[string]interface{}{
+		"worker_id":     s.workerID,
+		"workers":       s.workers,
+		"kinds":         kinds,
+		"poll_interval": s.pollInterval.String(),
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < s.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.work(ctx, kinds)
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.maintain(ctx)
+	}()
+
+	wg.Wait()
+	logger.Info("Job queue workers stopped", map[string]interface{}{
+		"worker_id": s.workerID,
+	})
+}
+
+// kinds returns the registered job kinds in a stable order
+func (s *JobQueueService) kinds() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	kinds := make([]string, 0, len(s.handlers))
+	for kind := range s.handlers {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// work runs due jobs one at a time, polling while the queue is empty
+func (s *JobQueueService) work(ctx context.Context, kinds []string) {
+	for ctx.Err() == nil {
+		ran, err := s.runNext(ctx, kinds)
+		if err != nil && ctx.Err() == nil {
+			utils.GetLogger().Error("Failed to dequeue job", err, map[string]interface{}{
+				"worker_id": s.workerID,
+			})
+		}
+		if ran {
+			continue
+		}
+
+		select {
+		case <-time.After(s.pollInterval):
+		case <-ctx.Done():
+		}
+	}
+}
+
+// runNext claims and runs one due job, reporting whether there was one
+func (s *JobQueueService) runNext(ctx context.Context, kinds []string) (bool, error) {
+	if len(kinds) == 0 {
+		return false, nil
+	}
+
+	jobs, err := s.repo.Dequeue(ctx, s.workerID, kinds, 1)
+	if err != nil {
+		return false, err
+	}
+	if len(jobs) == 0 {
+		return false, nil
+	}
+
+	s.runJob(ctx, &jobs[0])
+	return true, nil
+}
+
+// runJob runs a claimed job and records the outcome
+func (s *JobQueueService) runJob(ctx context.Context, job *models.Job) {
+	logger := utils.GetLogger()
+	start := time.Now()
+	err := s.callHandler(ctx, job)
+	metrics.JobExecutionDuration.WithLabelValues(jobQueueMetricName).Observe(time.Since(start).Seconds())
+
+	// Record the outcome even when the worker is shutting down
+	recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+
+	if err == nil {
+		if err := s.repo.Complete(recordCtx, job.ID); err != nil {
+			logger.Error("Failed to mark job completed", err, map[string]interface{}{
+				"job_id": job.ID.String(),
+				"kind":   job.Kind,
+			})
+		}
+		metrics.JobItemsProcessed.WithLabelValues(jobQueueMetricName, "success").Inc()
+		return
+	}
+
+	fields := map[string]interface{}{
+		"job_id":       job.ID.String(),
+		"kind":         job.Kind,
+		"attempt":      job.Attempts,
+		"max_attempts": job.MaxAttempts,
+	}
+
+	if job.Attempts >= job.MaxAttempts {
+		logger.Error("Job failed and is out of attempts", err, fields)
+		metrics.JobItemsProcessed.WithLabelValues(jobQueueMetricName, "failed").Inc()
+		if err := s.repo.Fail(recordCtx, job.ID, err.Error()); err != nil {
+			logger.Error("Failed to mark job failed", err, fields)
+		}
+		return
+	}
+
+	runAt := time.Now().Add(jobBackoff(job.Attempts))
+	fields["retry_at"] = runAt
+	fields["error"] = err.Error()
+	logger.Warn("Job failed, will retry", fields)
+	metrics.JobItemsProcessed.WithLabelValues(jobQueueMetricName, "retried").Inc()
+	if err := s.repo.Retry(recordCtx, job.ID, runAt, err.Error()); err != nil {
+		logger.Error("Failed to schedule job retry", err, fields)
+	}
+}
+
+// callHandler runs the job's handler, turning a panic into an error so one bad
+// job cannot take down the worker
+func (s *JobQueueService) callHandler(ctx context.Context, job *models.Job) (err error) {
+	s.mu.RLock()
+	handler, ok := s.handlers[job.Kind]
+	s.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("no handler registered for job kind %q", job.Kind)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return handler(ctx, job)
+}
+
+// jobBackoff returns the delay before retrying a job that failed on the given attempt
+func jobBackoff(attempt int) time.Duration {
+	delay := jobQueueBaseBackoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= jobQueueMaxBackoff {
+			return jobQueueMaxBackoff
+		}
+	}
+	return delay
+}
+
+// maintain enqueues due periodic jobs every poll interval and rescues stale
+// jobs and deletes old ones every maintenance interval
+func (s *JobQueueService) maintain(ctx context.Context) {
+	scheduleTicker := time.NewTicker(s.pollInterval)
+	defer scheduleTicker.Stop()
+	maintenanceTicker := time.NewTicker(jobQueueMaintenanceInterval)
+	defer maintenanceTicker.Stop()
+
+	s.enqueueDue(ctx)
+	s.cleanup(ctx)
+
+	for {
+		select {
+		case <-scheduleTicker.C:
+			s.enqueueDue(ctx)
+		case <-maintenanceTicker.C:
+			s.cleanup(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// enqueueDue enqueues the periodic jobs whose schedule this worker claims
+func (s *JobQueueService) enqueueDue(ctx context.Context) {
+	s.mu.RLock()
+	periodic := append([]periodicJob(nil), s.periodic...)
+	s.mu.RUnlock()
+
+	logger := utils.GetLogger()
+	for _, p := range periodic {
+		claimed, err := s.repo.ClaimDueSchedule(ctx, p.name, p.interval)
+		if err != nil {
+			logger.Error("Failed to claim job schedule", err, map[string]interface{}{
+				"schedule": p.name,
+			})
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		enqueued, err := s.Enqueue(ctx, p.kind, nil, WithUniqueKey(p.name))
+		if err != nil {
+			logger.Error("Failed to enqueue periodic job", err, map[string]interface{}{
+				"schedule": p.name,
+				"kind":     p.kind,
+			})
+			continue
+		}
+		if !enqueued {
+			logger.Debug("Previous run still queued or running, skipping periodic job", map[string]interface{}{
+				"schedule": p.name,
+				"kind":     p.kind,
+			})
+		}
+	}
+}
+
+// cleanup rescues jobs abandoned by dead workers, deletes finished jobs past
+// retention and refreshes the queue size metrics
+func (s *JobQueueService) cleanup(ctx context.Context) {
+	logger := utils.GetLogger()
+
+	rescued, err := s.repo.RescueStale(ctx, s.staleAfter)
+	if err != nil {
+		logger.Error("Failed to rescue stale jobs", err, nil)
+	} else if rescued > 0 {
+		logger.Warn("Rescued stale jobs", map[string]interface{}{
+			"count": rescued,
+		})
+	}
+
+	if _, err := s.repo.DeleteFinished(ctx, s.retention); err != nil {
+		logger.Error("Failed to delete finished jobs", err, nil)
+	}
+
+	counts, err := s.repo.CountPendingByKind(ctx)
+	if err != nil {
+		logger.Error("Failed to count pending jobs", err, nil)
+		return
+	}
+	for _, kind := range s.kinds() {
+		metrics.JobQueueSize.WithLabelValues(kind).Set(float64(counts[kind]))
+	}
+}
@@ -0,0 +1,279 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// MockJobRepository is a mock implementation of JobRepositoryInterface
+type MockJobRepository struct {
+	mock.Mock
+}
+
+func (m *MockJobRepository) Enqueue(ctx context.Context, job *models.Job) (bool, error) {
+	args := m.Called(ctx, job)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockJobRepository) Dequeue(ctx context.Context, workerID string, kinds []string, limit int) ([]models.Job, error) {
+	args := m.Called(ctx, workerID, kinds, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Job), args.Error(1)
+}
+
+func (m *MockJobRepository) Complete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockJobRepository) Retry(ctx context.Context, id uuid.UUID, runAt time.Time, lastError string) error {
+	args := m.Called(ctx, id, runAt, lastError)
+	return args.Error(0)
+}
+
+func (m *MockJobRepository) Fail(ctx context.Context, id uuid.UUID, lastError string) error {
+	args := m.Called(ctx, id, lastError)
+	return args.Error(0)
+}
+
+func (m *MockJobRepository) RescueStale(ctx context.Context, staleAfter time.Duration) (int64, error) {
+	args := m.Called(ctx, staleAfter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockJobRepository) DeleteFinished(ctx context.Context, olderThan time.Duration) (int64, error) {
+	args := m.Called(ctx, olderThan)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockJobRepository) CountPendingByKind(ctx context.Context) (map[string]int, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (m *MockJobRepository) ClaimDueSchedule(ctx context.Context, name string, interval time.Duration) (bool, error) {
+	args := m.Called(ctx, name, interval)
+	return args.Bool(0), args.Error(1)
+}
+
+// setupJobQueueServiceTest builds a JobQueueService with two workers over a mock repository
+func setupJobQueueServiceTest() (*JobQueueService, *MockJobRepository) {
+	repo := new(MockJobRepository)
+	return NewJobQueueService(repo, "test-worker", 2, 10*time.Millisecond, time.Minute, time.Hour), repo
+}
+
+// captureEnqueuedJobs records the jobs passed to Enqueue, which reports enqueued
+func captureEnqueuedJobs(repo *MockJobRepository, enqueued bool) *[]*models.Job {
+	jobs := &[]*models.Job{}
+	repo.On("Enqueue", mock.Anything, mock.AnythingOfType("*models.Job")).
+		Run(func(args mock.Arguments) {
+			*jobs = append(*jobs, args.Get(1).(*models.Job))
+		}).
+		Return(enqueued, nil)
+	return jobs
+}
+
+func TestJobBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, jobBackoff(1))
+	assert.Equal(t, time.Minute, jobBackoff(2))
+	assert.Equal(t, 4*time.Minute, jobBackoff(4))
+	assert.Equal(t, time.Hour, jobBackoff(20))
+}
+
+func TestJobQueueService_Enqueue(t *testing.T) {
+	svc, repo := setupJobQueueServiceTest()
+	jobs := captureEnqueuedJobs(repo, true)
+	runAt := time.Now().Add(time.Hour)
+
+	ok, err := svc.Enqueue(context.Background(), "export", map[string]string{"id": "42"}, WithRunAt(runAt), WithMaxAttempts(2), WithUniqueKey("export:42"))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	require.Len(t, *jobs, 1)
+	job := (*jobs)[0]
+	assert.Equal(t, "export", job.Kind)
+	assert.JSONEq(t, `{"id":"42"}`, string(job.Payload))
+	assert.Equal(t, runAt, job.RunAt)
+	assert.Equal(t, 2, job.MaxAttempts)
+	require.NotNil(t, job.UniqueKey)
+	assert.Equal(t, "export:42", *job.UniqueKey)
+
+	// Jobs without options run now with the default attempts and an empty payload
+	_, err = svc.Enqueue(context.Background(), "export", nil)
+	require.NoError(t, err)
+	require.Len(t, *jobs, 2)
+	job = (*jobs)[1]
+	assert.JSONEq(t, `{}`, string(job.Payload))
+	assert.Equal(t, jobQueueDefaultMaxAttempts, job.MaxAttempts)
+	assert.WithinDuration(t, time.Now(), job.RunAt, time.Minute)
+	assert.Nil(t, job.UniqueKey)
+}
+
+func TestJobQueueService_EnqueueSkipped(t *testing.T) {
+	svc, repo := setupJobQueueServiceTest()
+	// Another pending job has the same unique key
+	repo.On("Enqueue", mock.Anything, mock.Anything).Return(false, nil)
+
+	ok, err := svc.Enqueue(context.Background(), "export", nil, WithUniqueKey("export:42"))
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestJobQueueService_RunNext(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxAttempts int
+		handler     JobHandler
+		wantStatus  string
+		wantError   string
+	}{
+		{
+			name:        "success completes the job",
+			maxAttempts: 3,
+			handler:     func(ctx context.Context, job *models.Job) error { return nil },
+			wantStatus:  models.JobStatusCompleted,
+		},
+		{
+			name:        "error schedules a retry",
+			maxAttempts: 3,
+			handler:     func(ctx context.Context, job *models.Job) error { return errors.New("twitch unavailable") },
+			wantStatus:  models.JobStatusPending,
+			wantError:   "twitch unavailable",
+		},
+		{
+			name:        "error on last attempt fails the job",
+			maxAttempts: 1,
+			handler:     func(ctx context.Context, job *models.Job) error { return errors.New("twitch unavailable") },
+			wantStatus:  models.JobStatusFailed,
+			wantError:   "twitch unavailable",
+		},
+		{
+			name:        "panic is recovered and retried",
+			maxAttempts: 3,
+			handler:     func(ctx context.Context, job *models.Job) error { panic("boom") },
+			wantStatus:  models.JobStatusPending,
+			wantError:   "job panicked: boom",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo := setupJobQueueServiceTest()
+			svc.RegisterHandler("clip_sync", tt.handler)
+
+			job := models.Job{ID: uuid.New(), Kind: "clip_sync", Status: models.JobStatusRunning, Attempts: 1, MaxAttempts: tt.maxAttempts}
+			repo.On("Dequeue", mock.Anything, "test-worker", []string{"clip_sync"}, 1).Return([]models.Job{job}, nil).Once()
+			switch tt.wantStatus {
+			case models.JobStatusCompleted:
+				repo.On("Complete", mock.Anything, job.ID).Return(nil).Once()
+			case models.JobStatusPending:
+				retryAt := mock.MatchedBy(func(runAt time.Time) bool {
+					// The retry waits for backoff
+					return runAt.After(time.Now().Add(20 * time.Second))
+				})
+				repo.On("Retry", mock.Anything, job.ID, retryAt, tt.wantError).Return(nil).Once()
+			case models.JobStatusFailed:
+				repo.On("Fail", mock.Anything, job.ID, tt.wantError).Return(nil).Once()
+			}
+
+			ran, err := svc.runNext(context.Background(), svc.kinds())
+			require.NoError(t, err)
+			assert.True(t, ran)
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestJobQueueService_RunNextClaimsRegisteredKinds(t *testing.T) {
+	svc, repo := setupJobQueueServiceTest()
+	svc.RegisterHandler("exports", func(ctx context.Context, job *models.Job) error { return nil })
+	repo.On("Dequeue", mock.Anything, "test-worker", []string{"exports"}, 1).Return([]models.Job{}, nil).Once()
+
+	ran, err := svc.runNext(context.Background(), svc.kinds())
+	require.NoError(t, err)
+	assert.False(t, ran)
+	repo.AssertExpectations(t)
+
+	// Without handlers there is nothing to claim
+	ran, err = NewJobQueueService(repo, "test-worker", 1, time.Second, time.Minute, time.Hour).runNext(context.Background(), nil)
+	require.NoError(t, err)
+	assert.False(t, ran)
+	repo.AssertNumberOfCalls(t, "Dequeue", 1)
+}
+
+func TestJobQueueService_EnqueueDue(t *testing.T) {
+	svc, repo := setupJobQueueServiceTest()
+	svc.RegisterPeriodic("clip_sync", "clip_sync", time.Hour)
+	ctx := context.Background()
+
+	repo.On("ClaimDueSchedule", ctx, "clip_sync", time.Hour).Return(true, nil).Once()
+	jobs := captureEnqueuedJobs(repo, true)
+
+	svc.enqueueDue(ctx)
+	require.Len(t, *jobs, 1)
+	assert.Equal(t, "clip_sync", (*jobs)[0].Kind)
+	require.NotNil(t, (*jobs)[0].UniqueKey)
+	assert.Equal(t, "clip_sync", *(*jobs)[0].UniqueKey)
+
+	// Not due again until the interval passes, or claimed by another worker
+	repo.On("ClaimDueSchedule", ctx, "clip_sync", time.Hour).Return(false, nil).Once()
+	svc.enqueueDue(ctx)
+	assert.Len(t, *jobs, 1)
+	repo.AssertExpectations(t)
+}
+
+func TestJobQueueService_Start(t *testing.T) {
+	svc, repo := setupJobQueueServiceTest()
+
+	ran := make(chan struct{}, 1)
+	svc.RegisterHandler("webhook_retry", func(ctx context.Context, job *models.Job) error {
+		ran <- struct{}{}
+		return nil
+	})
+	svc.RegisterPeriodic("webhook_retry", "webhook_retry", time.Hour)
+
+	job := models.Job{ID: uuid.New(), Kind: "webhook_retry", Status: models.JobStatusRunning, Attempts: 1, MaxAttempts: 3}
+	repo.On("ClaimDueSchedule", mock.Anything, "webhook_retry", time.Hour).Return(true, nil).Once()
+	repo.On("ClaimDueSchedule", mock.Anything, "webhook_retry", time.Hour).Return(false, nil)
+	repo.On("Enqueue", mock.Anything, mock.Anything).Return(true, nil).Once()
+	repo.On("Dequeue", mock.Anything, "test-worker", []string{"webhook_retry"}, 1).Return([]models.Job{job}, nil).Once()
+	repo.On("Dequeue", mock.Anything, "test-worker", []string{"webhook_retry"}, 1).Return([]models.Job{}, nil)
+	repo.On("Complete", mock.Anything, job.ID).Return(nil).Once()
+	repo.On("RescueStale", mock.Anything, time.Minute).Return(int64(0), nil)
+	repo.On("DeleteFinished", mock.Anything, time.Hour).Return(int64(0), nil)
+	repo.On("CountPendingByKind", mock.Anything).Return(map[string]int{}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.Start(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-ran:
+	case <-time.After(2 * time.Second):
+		t.Fatal("job did not run")
+	}
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("workers did not stop")
+	}
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/subculture-collective/clipper/internal/models"
+	jwtpkg "github.com/subculture-collective/clipper/pkg/jwt"
+)
+
+// jwtKeyRetirementGrace keeps a rotated-out key verifying until every token it signed has expired
+const jwtKeyRetirementGrace = jwtpkg.RefreshTokenTTL + time.Hour
+
+// JWTKeyRepositoryInterface defines the signing key repository methods used by JWTKeyService
+type JWTKeyRepositoryInterface interface {
+	ListValid(ctx context.Context, now time.Time) ([]*models.JWTSigningKey, error)
+	Create(ctx context.Context, key *models.JWTSigningKey) (bool, error)
+	Rotate(ctx context.Context, key *models.JWTSigningKey, rotatedSince, retireAt time.Time) (bool, error)
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+// JWTKeyService rotates the JWT signing keys shared by all API instances and
+// keeps the in-process JWT manager in sync with the database
+type JWTKeyService struct {
+	repo             JWTKeyRepositoryInterface
+	manager          *jwtpkg.Manager
+	encryptionKey    []byte
+	rotationInterval time.Duration
+	propagationDelay time.Duration
+	now              func() time.Time
+}
+
+// NewJWTKeyService creates a new JWT key service. New keys are published in the
+// JWKS propagationDelay before they start signing so every instance (and any
+// service caching the JWKS) knows them before tokens signed with them appear.
+func NewJWTKeyService(
+	repo JWTKeyRepositoryInterface,
+	manager *jwtpkg.Manager,
+	rawEncryptionKey string,
+	rotationInterval time.Duration,
+	propagationDelay time.Duration,
+) (*JWTKeyService, error) {
+	encryptionKey := []byte(rawEncryptionKey)
+	if decoded, err := base64.StdEncoding.DecodeString(rawEncryptionKey); err == nil && len(decoded) == 32 {
+		encryptionKey = decoded
+	}
+	if len(encryptionKey) != 32 {
+		return nil, fmt.Errorf("JWT_KEY_ENCRYPTION_KEY must be exactly 32 bytes for AES-256 after optional base64 decoding, got %d bytes", len(encryptionKey))
+	}
+	if rotationInterval <= 0 {
+		return nil, errors.New("jwt key rotation interval must be positive")
+	}
+
+	return &JWTKeyService{
+		repo:             repo,
+		manager:          manager,
+		encryptionKey:    encryptionKey,
+		rotationInterval: rotationInterval,
+		propagationDelay: propagationDelay,
+		now:              time.Now,
+	}, nil
+}
+
+// Bootstrap seeds the key store with the configured static key, so tokens it
+// already signed stay valid, and loads every stored key into the manager
+func (s *JWTKeyService) Bootstrap(ctx context.Context) error {
+	keys, err := s.repo.ListValid(ctx, s.now())
+	if err != nil {
+		return err
+	}
+
+	// Only seed an empty store; otherwise the static key would displace a rotated one
+	if len(keys) == 0 {
+		encrypted, err := s.encryptPrivateKey(s.manager.ActivePrivateKey())
+		if err != nil {
+			return err
+		}
+		if _, err := s.repo.Create(ctx, &models.JWTSigningKey{
+			KID:                 s.manager.ActiveKeyID(),
+			PrivateKeyEncrypted: encrypted,
+			ActivatesAt:         s.now(),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return s.Sync(ctx)
+}
+
+// Sync loads all unexpired keys into the manager, activates the newest key
+// whose activation time has passed and drops keys that have expired
+func (s *JWTKeyService) Sync(ctx context.Context) error {
+	now := s.now()
+	keys, err := s.repo.ListValid(ctx, now)
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	valid := make(map[string]bool, len(keys))
+	activeKID := ""
+	var activeSince time.Time
+	for _, key := range keys {
+		privateKey, err := s.decryptPrivateKey(key.PrivateKeyEncrypted)
+		if err != nil {
+			return fmt.Errorf("failed to load jwt signing key %s: %w", key.KID, err)
+		}
+		kid := s.manager.AddKey(privateKey)
+		valid[kid] = true
+
+		if !key.ActivatesAt.After(now) && (activeKID == "" || key.ActivatesAt.After(activeSince)) {
+			activeKID, activeSince = kid, key.ActivatesAt
+		}
+	}
+
+	if activeKID != "" {
+		if err := s.manager.SetActiveKey(activeKID); err != nil {
+			return err
+		}
+	}
+
+	for _, kid := range s.manager.KeyIDs() {
+		if !valid[kid] && kid != s.manager.ActiveKeyID() {
+			_ = s.manager.RemoveKey(kid)
+		}
+	}
+
+	return nil
+}
+
+// RotateIfDue creates a new signing key when the newest stored key is older than
+// the rotation interval. It returns whether this call performed the rotation.
+func (s *JWTKeyService) RotateIfDue(ctx context.Context) (bool, error) {
+	now := s.now()
+	keys, err := s.repo.ListValid(ctx, now)
+	if err != nil {
+		return false, err
+	}
+	if len(keys) > 0 && now.Sub(keys[0].CreatedAt) < s.rotationInterval {
+		return false, nil
+	}
+
+	return s.rotate(ctx, now.Add(-s.rotationInterval))
+}
+
+// Rotate creates a new signing key immediately, e.g. after a suspected key compromise
+func (s *JWTKeyService) Rotate(ctx context.Context) error {
+	_, err := s.rotate(ctx, s.now())
+	return err
+}
+
+// rotate generates and stores a new key unless another instance created one after rotatedSince
+func (s *JWTKeyService) rotate(ctx context.Context, rotatedSince time.Time) (bool, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return false, fmt.Errorf("failed to generate jwt signing key: %w", err)
+	}
+	encrypted, err := s.encryptPrivateKey(privateKey)
+	if err != nil {
+		return false, err
+	}
+
+	activatesAt := s.now().Add(s.propagationDelay)
+	rotated, err := s.repo.Rotate(ctx, &models.JWTSigningKey{
+		KID:                 jwtpkg.KeyID(&privateKey.PublicKey),
+		PrivateKeyEncrypted: encrypted,
+		ActivatesAt:         activatesAt,
+	}, rotatedSince, activatesAt.Add(jwtKeyRetirementGrace))
+	if err != nil {
+		return false, err
+	}
+
+	if err := s.Sync(ctx); err != nil {
+		return rotated, err
+	}
+	return rotated, nil
+}
+
+// DeleteExpired removes keys that can no longer verify any token
+func (s *JWTKeyService) DeleteExpired(ctx context.Context) (int64, error) {
+	return s.repo.DeleteExpired(ctx, s.now())
+}
+
+// encryptPrivateKey encrypts a PEM encoded private key using AES-256-GCM
+func (s *JWTKeyService) encryptPrivateKey(privateKey *rsa.PrivateKey) (string, error) {
+	block, err := aes.NewCipher(s.encryptionKey)
+	if err != nil {
+		return "", err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	ciphertext := gcm.Seal(nonce, nonce, []byte(jwtpkg.EncodePrivateKey(privateKey)), nil)
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// decryptPrivateKey decrypts and parses a stored private key
+func (s *JWTKeyService) decryptPrivateKey(encrypted string) (*rsa.PrivateKey, error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(s.encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	nonceSize := gcm.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	nonce, ciphertext := ciphertext[:nonceSize], ciphertext[nonceSize:]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return jwtpkg.ParsePrivateKey(string(plaintext))
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	jwtpkg "github.com/subculture-collective/clipper/pkg/jwt"
+)
+
+// MockJWTKeyRepository is a mock implementation of JWTKeyRepositoryInterface
+type MockJWTKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockJWTKeyRepository) ListValid(ctx context.Context, now time.Time) ([]*models.JWTSigningKey, error) {
+	args := m.Called(ctx, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.JWTSigningKey), args.Error(1)
+}
+
+func (m *MockJWTKeyRepository) Create(ctx context.Context, key *models.JWTSigningKey) (bool, error) {
+	args := m.Called(ctx, key)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockJWTKeyRepository) Rotate(ctx context.Context, key *models.JWTSigningKey, rotatedSince, retireAt time.Time) (bool, error) {
+	args := m.Called(ctx, key, rotatedSince, retireAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockJWTKeyRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func setupJWTKeyServiceTest(t *testing.T) (*JWTKeyService, *MockJWTKeyRepository, *jwtpkg.Manager, *time.Time) {
+	t.Helper()
+
+	privateKeyPEM, _, err := jwtpkg.GenerateRSAKeyPair()
+	require.NoError(t, err)
+	manager, err := jwtpkg.NewManager(privateKeyPEM)
+	require.NoError(t, err)
+
+	clock := time.Now()
+	repo := new(MockJWTKeyRepository)
+	svc, err := NewJWTKeyService(repo, manager, "0123456789abcdef0123456789abcdef", 30*24*time.Hour, 10*time.Minute)
+	require.NoError(t, err)
+	svc.now = func() time.Time { return clock }
+
+	return svc, repo, manager, &clock
+}
+
+// expectStoredJWTKeys makes ListValid return keys, newest first, in place of
+// whatever it returned before
+func expectStoredJWTKeys(repo *MockJWTKeyRepository, keys ...*models.JWTSigningKey) {
+	calls := repo.ExpectedCalls[:0]
+	for _, call := range repo.ExpectedCalls {
+		if call.Method != "ListValid" {
+			calls = append(calls, call)
+		}
+	}
+	repo.ExpectedCalls = calls
+	repo.On("ListValid", mock.Anything, mock.AnythingOfType("time.Time")).Return(keys, nil)
+}
+
+// bootstrapTestJWTKey bootstraps an empty key store and returns the key it seeds
+func bootstrapTestJWTKey(t *testing.T, svc *JWTKeyService, repo *MockJWTKeyRepository, now time.Time) *models.JWTSigningKey {
+	t.Helper()
+
+	var seeded *models.JWTSigningKey
+	expectStoredJWTKeys(repo)
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*models.JWTSigningKey")).
+		Run(func(args mock.Arguments) {
+			seeded = args.Get(1).(*models.JWTSigningKey)
+			seeded.CreatedAt = now
+			expectStoredJWTKeys(repo, seeded)
+		}).
+		Return(true, nil).Once()
+
+	require.NoError(t, svc.Bootstrap(context.Background()))
+	require.NotNil(t, seeded)
+	return seeded
+}
+
+// expectJWTKeyRotation stores the next rotated key in front of current,
+// retiring current as the repository would
+func expectJWTKeyRotation(repo *MockJWTKeyRepository, current *models.JWTSigningKey, now time.Time, rotated **models.JWTSigningKey) {
+	repo.On("Rotate", mock.Anything, mock.AnythingOfType("*models.JWTSigningKey"), mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) {
+			key := args.Get(1).(*models.JWTSigningKey)
+			key.CreatedAt = now
+			retireAt := args.Get(3).(time.Time)
+			current.ExpiresAt = &retireAt
+			*rotated = key
+			expectStoredJWTKeys(repo, key, current)
+		}).
+		Return(true, nil).Once()
+}
+
+func TestNewJWTKeyService_RejectsShortKey(t *testing.T) {
+	_, err := NewJWTKeyService(new(MockJWTKeyRepository), nil, "too-short", time.Hour, 0)
+	assert.Error(t, err)
+}
+
+func TestJWTKeyService_BootstrapSeedsStaticKey(t *testing.T) {
+	svc, repo, manager, clock := setupJWTKeyServiceTest(t)
+	staticKID := manager.ActiveKeyID()
+
+	seeded := bootstrapTestJWTKey(t, svc, repo, *clock)
+	assert.Equal(t, staticKID, seeded.KID)
+	assert.NotContains(t, seeded.PrivateKeyEncrypted, "PRIVATE KEY")
+	assert.Equal(t, staticKID, manager.ActiveKeyID())
+
+	// A second bootstrap does not add another key
+	require.NoError(t, svc.Bootstrap(context.Background()))
+	repo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestJWTKeyService_RotationLifecycle(t *testing.T) {
+	svc, repo, manager, clock := setupJWTKeyServiceTest(t)
+	ctx := context.Background()
+	oldKey := bootstrapTestJWTKey(t, svc, repo, *clock)
+	oldKID := manager.ActiveKeyID()
+
+	oldToken, err := manager.GenerateAccessToken(uuid.New(), "user")
+	require.NoError(t, err)
+
+	// Not due yet
+	rotated, err := svc.RotateIfDue(ctx)
+	require.NoError(t, err)
+	assert.False(t, rotated)
+	repo.AssertNotCalled(t, "Rotate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	*clock = clock.Add(31 * 24 * time.Hour)
+	var newKey *models.JWTSigningKey
+	expectJWTKeyRotation(repo, oldKey, *clock, &newKey)
+	rotated, err = svc.RotateIfDue(ctx)
+	require.NoError(t, err)
+	require.True(t, rotated)
+	require.NotNil(t, newKey)
+	newKID := newKey.KID
+
+	// The old key retires a grace period after the new one starts signing
+	require.NotNil(t, oldKey.ExpiresAt)
+	assert.Equal(t, clock.Add(10*time.Minute+jwtKeyRetirementGrace), *oldKey.ExpiresAt)
+
+	// The new key is published but does not sign until the propagation delay passes
+	assert.Equal(t, oldKID, manager.ActiveKeyID())
+	assert.ElementsMatch(t, []string{oldKID, newKID}, manager.KeyIDs())
+
+	*clock = clock.Add(11 * time.Minute)
+	require.NoError(t, svc.Sync(ctx))
+	assert.Equal(t, newKID, manager.ActiveKeyID())
+
+	_, err = manager.ValidateToken(oldToken)
+	assert.NoError(t, err, "tokens signed by the retired key stay valid during the grace period")
+
+	// After the grace period the old key is dropped everywhere
+	*clock = clock.Add(jwtKeyRetirementGrace)
+	expectStoredJWTKeys(repo, newKey)
+	require.NoError(t, svc.Sync(ctx))
+	assert.Equal(t, []string{newKID}, manager.KeyIDs())
+
+	repo.On("DeleteExpired", ctx, *clock).Return(int64(1), nil).Once()
+	deleted, err := svc.DeleteExpired(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
+
+func TestJWTKeyService_RotateIfDueSkipsAfterRecentRotation(t *testing.T) {
+	svc, repo, _, clock := setupJWTKeyServiceTest(t)
+	ctx := context.Background()
+	oldKey := bootstrapTestJWTKey(t, svc, repo, *clock)
+
+	*clock = clock.Add(31 * 24 * time.Hour)
+	var newKey *models.JWTSigningKey
+	expectJWTKeyRotation(repo, oldKey, *clock, &newKey)
+	require.NoError(t, svc.Rotate(ctx))
+
+	rotated, err := svc.RotateIfDue(ctx)
+	require.NoError(t, err)
+	assert.False(t, rotated)
+	repo.AssertNumberOfCalls(t, "Rotate", 1)
+}
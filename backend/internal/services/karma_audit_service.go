@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+const defaultKarmaAuditBatchSize = 500
+
+// KarmaAuditRepositoryInterface defines the data access needed to audit karma
+type KarmaAuditRepositoryInterface interface {
+	ListKarmaSources(ctx context.Context, afterID uuid.UUID, userID *uuid.UUID, limit int) ([]models.KarmaAuditEntry, error)
+	ApplyCorrections(ctx context.Context, discrepancies []models.KarmaDiscrepancy) (int, error)
+}
+
+// KarmaAuditOptions configures a karma audit run
+type KarmaAuditOptions struct {
+	// Tolerance is the largest difference left unreported
+	Tolerance int
+	// Apply corrects reported users' karma
+	Apply     bool
+	BatchSize int
+	// UserID limits the audit to one user
+	UserID *uuid.UUID
+}
+
+// KarmaAuditService recomputes user karma from its source events and
+// reconciles drift left by deleted content and reversed votes
+type KarmaAuditService struct {
+	repo KarmaAuditRepositoryInterface
+}
+
+// NewKarmaAuditService creates a new karma audit service
+func NewKarmaAuditService(repo KarmaAuditRepositoryInterface) *KarmaAuditService {
+	return &KarmaAuditService{repo: repo}
+}
+
+// ExpectedKarma recomputes a user's karma: the net votes on their clips and
+// comments plus KarmaPerComment per comment, less inactivity decay. Karma
+// never goes below zero.
+func ExpectedKarma(entry models.KarmaAuditEntry) int {
+	karma := entry.ClipVoteKarma + entry.CommentVoteKarma + entry.Comments*KarmaPerComment + entry.DecayKarma
+	if karma < 0 {
+		return 0
+	}
+	return karma
+}
+
+// Audit compares every user's karma to its recomputed value in batches and
+// reports differences beyond the tolerance, correcting them when applying
+func (s *KarmaAuditService) Audit(ctx context.Context, opts KarmaAuditOptions) (*models.KarmaAuditReport, error) {
+	if opts.Tolerance < 0 {
+		return nil, fmt.Errorf("tolerance must not be negative")
+	}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultKarmaAuditBatchSize
+	}
+
+	report := &models.KarmaAuditReport{
+		Tolerance:     opts.Tolerance,
+		Applied:       opts.Apply,
+		Discrepancies: []models.KarmaDiscrepancy{},
+	}
+
+	afterID := uuid.Nil
+	for {
+		entries, err := s.repo.ListKarmaSources(ctx, afterID, opts.UserID, batchSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) == 0 {
+			break
+		}
+		report.UsersAudited += len(entries)
+		afterID = entries[len(entries)-1].UserID
+
+		var batch []models.KarmaDiscrepancy
+		for _, entry := range entries {
+			expected := ExpectedKarma(entry)
+			difference := expected - entry.CurrentKarma
+			if difference <= opts.Tolerance && difference >= -opts.Tolerance {
+				continue
+			}
+			batch = append(batch, models.KarmaDiscrepancy{
+				UserID:        entry.UserID,
+				Username:      entry.Username,
+				CurrentKarma:  entry.CurrentKarma,
+				ExpectedKarma: expected,
+				Difference:    difference,
+			})
+		}
+
+		if opts.Apply && len(batch) > 0 {
+			corrected, err := s.repo.ApplyCorrections(ctx, batch)
+			if err != nil {
+				return nil, err
+			}
+			report.Corrected += corrected
+		}
+		report.Discrepancies = append(report.Discrepancies, batch...)
+
+		if len(entries) < batchSize {
+			break
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// MockKarmaAuditRepository is a mock implementation of KarmaAuditRepositoryInterface
+type MockKarmaAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockKarmaAuditRepository) ListKarmaSources(ctx context.Context, afterID uuid.UUID, userID *uuid.UUID, limit int) ([]models.KarmaAuditEntry, error) {
+	args := m.Called(ctx, afterID, userID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.KarmaAuditEntry), args.Error(1)
+}
+
+func (m *MockKarmaAuditRepository) ApplyCorrections(ctx context.Context, discrepancies []models.KarmaDiscrepancy) (int, error) {
+	args := m.Called(ctx, discrepancies)
+	return args.Int(0), args.Error(1)
+}
+
+// expectKarmaAuditPages serves entries to one audit in pages of batchSize
+func expectKarmaAuditPages(repo *MockKarmaAuditRepository, userID *uuid.UUID, batchSize int, entries ...models.KarmaAuditEntry) {
+	afterID := uuid.Nil
+	for start := 0; ; start += batchSize {
+		end := start + batchSize
+		if end > len(entries) {
+			end = len(entries)
+		}
+		page := entries[start:end]
+		repo.On("ListKarmaSources", mock.Anything, afterID, userID, batchSize).Return(page, nil).Once()
+		if len(page) < batchSize {
+			return
+		}
+		afterID = page[len(page)-1].UserID
+	}
+}
+
+func karmaAuditUserID(n byte) uuid.UUID {
+	var id uuid.UUID
+	id[15] = n
+	return id
+}
+
+func TestExpectedKarma(t *testing.T) {
+	assert.Equal(t, 12, ExpectedKarma(models.KarmaAuditEntry{ClipVoteKarma: 8, CommentVoteKarma: 1, Comments: 3}))
+	assert.Equal(t, 0, ExpectedKarma(models.KarmaAuditEntry{ClipVoteKarma: -9, Comments: 2}))
+	assert.Equal(t, 7, ExpectedKarma(models.KarmaAuditEntry{ClipVoteKarma: 8, Comments: 1, DecayKarma: -2}))
+}
+
+func TestKarmaAuditService_Audit(t *testing.T) {
+	ctx := context.Background()
+	entries := []models.KarmaAuditEntry{
+		{UserID: karmaAuditUserID(1), Username: "exact", CurrentKarma: 5, ClipVoteKarma: 5},
+		{UserID: karmaAuditUserID(2), Username: "within", CurrentKarma: 7, ClipVoteKarma: 4, Comments: 1},
+		{UserID: karmaAuditUserID(3), Username: "inflated", CurrentKarma: 20, CommentVoteKarma: 6, Comments: 2},
+		{UserID: karmaAuditUserID(4), Username: "deflated", CurrentKarma: 0, ClipVoteKarma: 10},
+		{UserID: karmaAuditUserID(5), Username: "racing", CurrentKarma: 50, ClipVoteKarma: 1},
+	}
+	repo := new(MockKarmaAuditRepository)
+	svc := NewKarmaAuditService(repo)
+
+	expectKarmaAuditPages(repo, nil, 2, entries...)
+	report, err := svc.Audit(ctx, KarmaAuditOptions{Tolerance: 2, BatchSize: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 5, report.UsersAudited)
+	require.Len(t, report.Discrepancies, 3)
+	assert.Equal(t, "inflated", report.Discrepancies[0].Username)
+	assert.Equal(t, 8, report.Discrepancies[0].ExpectedKarma)
+	assert.Equal(t, -12, report.Discrepancies[0].Difference)
+	assert.Equal(t, 10, report.Discrepancies[1].Difference)
+	repo.AssertNotCalled(t, "ApplyCorrections", mock.Anything, mock.Anything)
+
+	// Each batch is corrected on its own. The racing user's karma changed
+	// since the audit, so the repository leaves it alone.
+	expectKarmaAuditPages(repo, nil, 2, entries...)
+	repo.On("ApplyCorrections", ctx, mock.MatchedBy(func(batch []models.KarmaDiscrepancy) bool { return len(batch) == 2 })).
+		Run(func(args mock.Arguments) {
+			batch := args.Get(1).([]models.KarmaDiscrepancy)
+			for i := range batch {
+				batch[i].Applied = true
+			}
+		}).
+		Return(2, nil).Once()
+	repo.On("ApplyCorrections", ctx, mock.MatchedBy(func(batch []models.KarmaDiscrepancy) bool {
+		return len(batch) == 1 && batch[0].UserID == karmaAuditUserID(5)
+	})).Return(0, nil).Once()
+
+	report, err = svc.Audit(ctx, KarmaAuditOptions{Tolerance: 2, BatchSize: 2, Apply: true})
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Corrected)
+	assert.True(t, report.Discrepancies[0].Applied)
+	assert.False(t, report.Discrepancies[2].Applied, "karma changed since the audit is left alone")
+
+	userID := karmaAuditUserID(4)
+	expectKarmaAuditPages(repo, &userID, defaultKarmaAuditBatchSize, entries[3])
+	report, err = svc.Audit(ctx, KarmaAuditOptions{UserID: &userID})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.UsersAudited)
+	require.Len(t, report.Discrepancies, 1)
+
+	_, err = svc.Audit(ctx, KarmaAuditOptions{Tolerance: -1})
+	assert.Error(t, err)
+
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,281 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const (
+	// voteRingsListLimit caps how many vote rings are listed per page
+	voteRingsListLimit = 100
+	// karmaDecayBatchSize is how many users are decayed per statement
+	karmaDecayBatchSize = 500
+)
+
+var (
+	karmaDecayedUsersTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "karma_decayed_users_total",
+			Help: "Total number of inactivity karma decays applied to users",
+		},
+	)
+
+	voteRingsDetectedTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "vote_rings_detected_total",
+			Help: "Total number of vote rings flagged for review",
+		},
+	)
+
+	voteRingReviewsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "vote_ring_reviews_total",
+			Help: "Total number of vote rings reviewed",
+		},
+		[]string{"status"}, // "confirmed", "dismissed"
+	)
+)
+
+// KarmaSafeguardRepositoryInterface defines the repository methods used by KarmaSafeguardService
+type KarmaSafeguardRepositoryInterface interface {
+	DecayInactiveKarma(ctx context.Context, inactiveBefore, decayedSince time.Time, percent, floor, limit int) (int64, error)
+	FindReciprocalVotePairs(ctx context.Context, since time.Time, minVotes int) ([]models.ReciprocalVotePair, error)
+	CreateVoteRing(ctx context.Context, ring *models.VoteRing, memberKey string, priority int) (bool, error)
+	ListVoteRings(ctx context.Context, filters models.VoteRingFilters) ([]models.VoteRing, error)
+	GetVoteRing(ctx context.Context, id uuid.UUID) (*models.VoteRing, error)
+	ReviewVoteRing(ctx context.Context, id uuid.UUID, status, queueStatus string, reviewerID uuid.UUID, notes *string) (*models.VoteRing, error)
+}
+
+// KarmaSafeguardServiceConfig holds the karma decay and vote ring detection settings
+type KarmaSafeguardServiceConfig struct {
+	DecayInactiveDays  int // 0 disables decay
+	DecayPeriodDays    int
+	DecayPercent       int
+	DecayFloor         int
+	VoteRingWindowDays int
+	VoteRingMinVotes   int
+	VoteRingMinSize    int
+}
+
+// KarmaSafeguardService keeps karma meaningful. Inactive accounts' karma
+// decays, and clusters of accounts that upvote each other are flagged as vote
+// rings into the moderation queue. Confirming a ring reverses the karma its
+// members' votes on each other awarded. Diminishing returns for repeated
+// votes between the same users are applied by the vote karma triggers.
+type KarmaSafeguardService struct {
+	repo     KarmaSafeguardRepositoryInterface
+	auditLog UserBanAuditLogger
+	cfg      KarmaSafeguardServiceConfig
+	now      func() time.Time
+}
+
+// NewKarmaSafeguardService creates a new KarmaSafeguardService. The audit
+// logger is optional.
+func NewKarmaSafeguardService(repo KarmaSafeguardRepositoryInterface, auditLog UserBanAuditLogger, cfg KarmaSafeguardServiceConfig) *KarmaSafeguardService {
+	return &KarmaSafeguardService{
+		repo:     repo,
+		auditLog: auditLog,
+		cfg:      cfg,
+		now:      time.Now,
+	}
+}
+
+// DecayInactiveKarma decays the karma of users inactive for the configured
+// number of days, once per decay period, returning how many were decayed
+func (s *KarmaSafeguardService) DecayInactiveKarma(ctx context.Context) (int, error) {
+	if s.cfg.DecayInactiveDays <= 0 || s.cfg.DecayPercent <= 0 {
+		return 0, nil
+	}
+	now := s.now()
+	inactiveBefore := now.AddDate(0, 0, -s.cfg.DecayInactiveDays)
+	decayedSince := now.AddDate(0, 0, -s.cfg.DecayPeriodDays)
+
+	total := 0
+	for {
+		decayed, err := s.repo.DecayInactiveKarma(ctx, inactiveBefore, decayedSince, s.cfg.DecayPercent, s.cfg.DecayFloor, karmaDecayBatchSize)
+		if err != nil {
+			return total, err
+		}
+		total += int(decayed)
+		karmaDecayedUsersTotal.Add(float64(decayed))
+		if decayed < karmaDecayBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// voteRingClusters groups reciprocal vote pairs into clusters of users
+// connected by them, keeping clusters of at least minSize users. Each cluster
+// lists its members in ID order, with the upvotes they gave and received
+// within the cluster.
+func voteRingClusters(pairs []models.ReciprocalVotePair, minSize int) []models.VoteRing {
+	parent := map[uuid.UUID]uuid.UUID{}
+	var find func(id uuid.UUID) uuid.UUID
+	find = func(id uuid.UUID) uuid.UUID {
+		if parent[id] != id {
+			parent[id] = find(parent[id])
+		}
+		return parent[id]
+	}
+	for _, p := range pairs {
+		for _, id := range []uuid.UUID{p.UserA, p.UserB} {
+			if _, ok := parent[id]; !ok {
+				parent[id] = id
+			}
+		}
+		parent[find(p.UserA)] = find(p.UserB)
+	}
+
+	members := map[uuid.UUID]map[uuid.UUID]*models.VoteRingMember{}
+	votes := map[uuid.UUID]int{}
+	for _, p := range pairs {
+		root := find(p.UserA)
+		if members[root] == nil {
+			members[root] = map[uuid.UUID]*models.VoteRingMember{}
+		}
+		for _, id := range []uuid.UUID{p.UserA, p.UserB} {
+			if members[root][id] == nil {
+				members[root][id] = &models.VoteRingMember{UserID: id}
+			}
+		}
+		members[root][p.UserA].VotesGiven += p.VotesAToB
+		members[root][p.UserA].VotesReceived += p.VotesBToA
+		members[root][p.UserB].VotesGiven += p.VotesBToA
+		members[root][p.UserB].VotesReceived += p.VotesAToB
+		votes[root] += p.VotesAToB + p.VotesBToA
+	}
+
+	var rings []models.VoteRing
+	for root, byID := range members {
+		if len(byID) < minSize {
+			continue
+		}
+		ring := models.VoteRing{
+			MemberCount:     len(byID),
+			ReciprocalVotes: votes[root],
+			Members:         make([]models.VoteRingMember, 0, len(byID)),
+		}
+		for _, m := range byID {
+			ring.Members = append(ring.Members, *m)
+		}
+		sort.Slice(ring.Members, func(i, j int) bool {
+			return ring.Members[i].UserID.String() < ring.Members[j].UserID.String()
+		})
+		rings = append(rings, ring)
+	}
+	sort.Slice(rings, func(i, j int) bool {
+		return voteRingMemberKey(rings[i]) < voteRingMemberKey(rings[j])
+	})
+	return rings
+}
+
+// voteRingMemberKey identifies a ring by its sorted member IDs
+func voteRingMemberKey(ring models.VoteRing) string {
+	ids := make([]string, len(ring.Members))
+	for i, m := range ring.Members {
+		ids[i] = m.UserID.String()
+	}
+	return strings.Join(ids, ",")
+}
+
+// voteRingPriority is the moderation queue priority of a ring's members.
+// Larger rings are more urgent.
+func voteRingPriority(ring models.VoteRing) int {
+	priority := 40 + 10*ring.MemberCount
+	if priority > 90 {
+		priority = 90
+	}
+	return priority
+}
+
+// DetectVoteRings flags clusters of users who upvoted each other's content
+// within the detection window, returning how many rings were flagged. Each
+// set of members is flagged once.
+func (s *KarmaSafeguardService) DetectVoteRings(ctx context.Context) (int, error) {
+	since := s.now().AddDate(0, 0, -s.cfg.VoteRingWindowDays)
+	pairs, err := s.repo.FindReciprocalVotePairs(ctx, since, s.cfg.VoteRingMinVotes)
+	if err != nil {
+		return 0, err
+	}
+
+	flagged := 0
+	for _, ring := range voteRingClusters(pairs, s.cfg.VoteRingMinSize) {
+		ring.WindowStart = since
+		created, err := s.repo.CreateVoteRing(ctx, &ring, voteRingMemberKey(ring), voteRingPriority(ring))
+		if err != nil {
+			return flagged, err
+		}
+		if created {
+			flagged++
+			voteRingsDetectedTotal.Inc()
+		}
+	}
+	return flagged, nil
+}
+
+// ListVoteRings returns vote rings for review
+func (s *KarmaSafeguardService) ListVoteRings(ctx context.Context, filters models.VoteRingFilters) ([]models.VoteRing, error) {
+	if filters.Limit <= 0 || filters.Limit > voteRingsListLimit {
+		filters.Limit = voteRingsListLimit
+	}
+	if filters.Offset < 0 {
+		filters.Offset = 0
+	}
+	return s.repo.ListVoteRings(ctx, filters)
+}
+
+// GetVoteRing returns a vote ring with its members
+func (s *KarmaSafeguardService) GetVoteRing(ctx context.Context, id uuid.UUID) (*models.VoteRing, error) {
+	return s.repo.GetVoteRing(ctx, id)
+}
+
+// ConfirmVoteRing confirms a pending ring, reversing the karma its members'
+// votes on each other awarded
+func (s *KarmaSafeguardService) ConfirmVoteRing(ctx context.Context, ringID, moderatorID uuid.UUID, notes *string) (*models.VoteRing, error) {
+	return s.reviewVoteRing(ctx, ringID, models.VoteRingConfirmed, "rejected", repository.AuditActionConfirmVoteRing, moderatorID, notes)
+}
+
+// DismissVoteRing dismisses a pending ring, leaving the members' karma as it is
+func (s *KarmaSafeguardService) DismissVoteRing(ctx context.Context, ringID, moderatorID uuid.UUID, notes *string) (*models.VoteRing, error) {
+	return s.reviewVoteRing(ctx, ringID, models.VoteRingDismissed, "approved", repository.AuditActionDismissVoteRing, moderatorID, notes)
+}
+
+func (s *KarmaSafeguardService) reviewVoteRing(ctx context.Context, ringID uuid.UUID, status, queueStatus, action string, moderatorID uuid.UUID, notes *string) (*models.VoteRing, error) {
+	ring, err := s.repo.ReviewVoteRing(ctx, ringID, status, queueStatus, moderatorID, notes)
+	if err != nil {
+		return nil, err
+	}
+
+	voteRingReviewsTotal.WithLabelValues(status).Inc()
+	s.logAudit(ctx, action, moderatorID, ring, notes)
+	return ring, nil
+}
+
+// logAudit records a vote ring review without failing the review itself
+func (s *KarmaSafeguardService) logAudit(ctx context.Context, action string, moderatorID uuid.UUID, ring *models.VoteRing, notes *string) {
+	if s.auditLog == nil {
+		return
+	}
+	if err := s.auditLog.LogAction(ctx, action, moderatorID, ring.ID, "vote_ring", AuditLogOptions{
+		Reason: notes,
+		Metadata: map[string]interface{}{
+			"member_count":     ring.MemberCount,
+			"reciprocal_votes": ring.ReciprocalVotes,
+			"karma_reversed":   ring.KarmaReversed,
+		},
+	}); err != nil {
+		utils.GetLogger().Error("Failed to record vote ring audit log", err, map[string]interface{}{
+			"action":  action,
+			"ring_id": ring.ID.String(),
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+// MockKarmaSafeguardRepository is a mock implementation of KarmaSafeguardRepositoryInterface
+type MockKarmaSafeguardRepository struct {
+	mock.Mock
+}
+
+func (m *MockKarmaSafeguardRepository) DecayInactiveKarma(ctx context.Context, inactiveBefore, decayedSince time.Time, percent, floor, limit int) (int64, error) {
+	args := m.Called(ctx, inactiveBefore, decayedSince, percent, floor, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockKarmaSafeguardRepository) FindReciprocalVotePairs(ctx context.Context, since time.Time, minVotes int) ([]models.ReciprocalVotePair, error) {
+	args := m.Called(ctx, since, minVotes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ReciprocalVotePair), args.Error(1)
+}
+
+func (m *MockKarmaSafeguardRepository) CreateVoteRing(ctx context.Context, ring *models.VoteRing, memberKey string, priority int) (bool, error) {
+	args := m.Called(ctx, ring, memberKey, priority)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockKarmaSafeguardRepository) ListVoteRings(ctx context.Context, filters models.VoteRingFilters) ([]models.VoteRing, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.VoteRing), args.Error(1)
+}
+
+func (m *MockKarmaSafeguardRepository) GetVoteRing(ctx context.Context, id uuid.UUID) (*models.VoteRing, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.VoteRing), args.Error(1)
+}
+
+func (m *MockKarmaSafeguardRepository) ReviewVoteRing(ctx context.Context, id uuid.UUID, status, queueStatus string, reviewerID uuid.UUID, notes *string) (*models.VoteRing, error) {
+	args := m.Called(ctx, id, status, queueStatus, reviewerID, notes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.VoteRing), args.Error(1)
+}
+
+func setupKarmaSafeguardServiceTest() (*KarmaSafeguardService, *MockKarmaSafeguardRepository) {
+	repo := new(MockKarmaSafeguardRepository)
+	svc := NewKarmaSafeguardService(repo, nil, KarmaSafeguardServiceConfig{
+		DecayInactiveDays:  90,
+		DecayPeriodDays:    30,
+		DecayPercent:       10,
+		DecayFloor:         100,
+		VoteRingWindowDays: 30,
+		VoteRingMinVotes:   5,
+		VoteRingMinSize:    3,
+	})
+	svc.now = func() time.Time { return time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC) }
+	return svc, repo
+}
+
+func TestVoteRingClusters(t *testing.T) {
+	a, b, c, d, e := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	pairs := []models.ReciprocalVotePair{
+		{UserA: a, UserB: b, VotesAToB: 6, VotesBToA: 5},
+		{UserA: b, UserB: c, VotesAToB: 7, VotesBToA: 8},
+		// A separate pair, too small to be a ring
+		{UserA: d, UserB: e, VotesAToB: 9, VotesBToA: 9},
+	}
+
+	rings := voteRingClusters(pairs, 3)
+	require.Len(t, rings, 1)
+	ring := rings[0]
+	assert.Equal(t, 3, ring.MemberCount)
+	assert.Equal(t, 26, ring.ReciprocalVotes)
+
+	byID := map[uuid.UUID]models.VoteRingMember{}
+	for _, m := range ring.Members {
+		byID[m.UserID] = m
+	}
+	assert.Equal(t, 12, byID[b].VotesGiven)
+	assert.Equal(t, 14, byID[b].VotesReceived)
+	assert.Equal(t, 6, byID[a].VotesGiven)
+	assert.Equal(t, 5, byID[a].VotesReceived)
+
+	assert.Len(t, voteRingClusters(pairs, 2), 2)
+	assert.Empty(t, voteRingClusters(nil, 2))
+}
+
+func TestKarmaSafeguardService_DetectVoteRings(t *testing.T) {
+	ctx := context.Background()
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	svc, repo := setupKarmaSafeguardServiceTest()
+	since := svc.now().AddDate(0, 0, -30)
+
+	memberIDs := []string{a.String(), b.String(), c.String()}
+	sort.Strings(memberIDs)
+	memberKey := strings.Join(memberIDs, ",")
+	ring := mock.MatchedBy(func(ring *models.VoteRing) bool {
+		return ring.MemberCount == 3 && ring.WindowStart.Equal(since)
+	})
+
+	repo.On("FindReciprocalVotePairs", ctx, since, 5).Return([]models.ReciprocalVotePair{
+		{UserA: a, UserB: b, VotesAToB: 5, VotesBToA: 5},
+		{UserA: a, UserB: c, VotesAToB: 5, VotesBToA: 5},
+	}, nil).Twice()
+	repo.On("CreateVoteRing", ctx, ring, memberKey, 70).Return(true, nil).Once()
+	// The same members aren't flagged twice
+	repo.On("CreateVoteRing", ctx, ring, memberKey, 70).Return(false, nil).Once()
+
+	flagged, err := svc.DetectVoteRings(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, flagged)
+
+	flagged, err = svc.DetectVoteRings(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, flagged)
+	repo.AssertExpectations(t)
+}
+
+func TestKarmaSafeguardService_DecayInactiveKarma(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Decays in batches until a partial batch", func(t *testing.T) {
+		svc, repo := setupKarmaSafeguardServiceTest()
+		now := svc.now()
+		inactiveBefore, decayedSince := now.AddDate(0, 0, -90), now.AddDate(0, 0, -30)
+		repo.On("DecayInactiveKarma", ctx, inactiveBefore, decayedSince, 10, 100, karmaDecayBatchSize).Return(int64(karmaDecayBatchSize), nil).Once()
+		repo.On("DecayInactiveKarma", ctx, inactiveBefore, decayedSince, 10, 100, karmaDecayBatchSize).Return(int64(12), nil).Once()
+
+		decayed, err := svc.DecayInactiveKarma(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, karmaDecayBatchSize+12, decayed)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Disabled without inactive days", func(t *testing.T) {
+		svc, repo := setupKarmaSafeguardServiceTest()
+		svc.cfg.DecayInactiveDays = 0
+
+		decayed, err := svc.DecayInactiveKarma(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, decayed)
+		repo.AssertNotCalled(t, "DecayInactiveKarma", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestKarmaSafeguardService_ReviewVoteRing(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := setupKarmaSafeguardServiceTest()
+	ringID, moderatorID := uuid.New(), uuid.New()
+
+	repo.On("ReviewVoteRing", ctx, ringID, models.VoteRingConfirmed, "rejected", moderatorID, (*string)(nil)).
+		Return(&models.VoteRing{ID: ringID, Status: models.VoteRingConfirmed}, nil).Once()
+	// Only pending rings can be reviewed
+	repo.On("ReviewVoteRing", ctx, ringID, models.VoteRingDismissed, "approved", moderatorID, (*string)(nil)).
+		Return(nil, repository.ErrVoteRingNotFound).Once()
+
+	ring, err := svc.ConfirmVoteRing(ctx, ringID, moderatorID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, models.VoteRingConfirmed, ring.Status)
+
+	_, err = svc.DismissVoteRing(ctx, ringID, moderatorID, nil)
+	assert.ErrorIs(t, err, repository.ErrVoteRingNotFound)
+	repo.AssertExpectations(t)
+}
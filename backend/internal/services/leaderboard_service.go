@@ -0,0 +1,263 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const (
+	// leaderboardSnapshotSize is the number of top users stored per leaderboard
+	leaderboardSnapshotSize = 100
+	// seasonTopTenSize is the number of top users of a season awarded a badge
+	seasonTopTenSize = 10
+
+	seasonChampionBadgeID = "season_champion"
+	seasonTopTenBadgeID   = "season_top_ten"
+)
+
+// Leaderboard errors
+var (
+	// ErrInvalidLeaderboardType is returned for unknown leaderboard types
+	ErrInvalidLeaderboardType = errors.New("leaderboard type must be 'karma' or 'engagement'")
+	// ErrInvalidLeaderboardPeriod is returned for unknown leaderboard periods
+	ErrInvalidLeaderboardPeriod = errors.New("leaderboard period must be 'all_time', 'weekly', 'monthly' or 'season'")
+)
+
+var (
+	leaderboardTypes   = []string{models.LeaderboardTypeKarma, models.LeaderboardTypeEngagement}
+	leaderboardPeriods = []string{models.LeaderboardPeriodWeekly, models.LeaderboardPeriodMonthly, models.LeaderboardPeriodSeason}
+	leaderboardScopes  = []string{models.LeaderboardScopeGlobal, models.LeaderboardScopeGame, models.LeaderboardScopeCommunity}
+)
+
+// LeaderboardSnapshotRepositoryInterface defines the repository methods used by LeaderboardService
+type LeaderboardSnapshotRepositoryInterface interface {
+	ComputeScores(ctx context.Context, leaderboardType, scope string, start, end time.Time, limit int) ([]models.LeaderboardScore, error)
+	SaveSnapshot(ctx context.Context, snapshot *models.LeaderboardSnapshot, scores []models.LeaderboardScore) error
+	DeleteStaleSnapshots(ctx context.Context, leaderboardType, period, periodKey, scope string, before time.Time) (int64, error)
+	HasFinalizedPeriod(ctx context.Context, period, periodKey string) (bool, error)
+	GetSnapshot(ctx context.Context, leaderboardType, period, periodKey, scope, scopeID string) (*models.LeaderboardSnapshot, error)
+	ListSnapshotEntries(ctx context.Context, snapshotID uuid.UUID, limit, offset int) ([]models.LeaderboardEntry, error)
+	ListSnapshotsAwaitingBadges(ctx context.Context, leaderboardType, period, scope string) ([]models.LeaderboardSnapshot, error)
+	MarkBadgesAwarded(ctx context.Context, snapshotID uuid.UUID) error
+}
+
+// LeaderboardBadgeAwarder awards season-end badges
+type LeaderboardBadgeAwarder interface {
+	AwardBadge(ctx context.Context, userID uuid.UUID, badgeID string, awardedBy *uuid.UUID) error
+}
+
+// LeaderboardBadgeNotifier tells users they earned a season-end badge
+type LeaderboardBadgeNotifier interface {
+	NotifyBadgeEarned(ctx context.Context, userID uuid.UUID, badgeName string) error
+}
+
+// LeaderboardService computes weekly, monthly and seasonal leaderboards,
+// global and per game and community, into snapshots, and serves them. When a
+// season ends, the top users of the global karma leaderboard earn badges.
+type LeaderboardService struct {
+	repo     LeaderboardSnapshotRepositoryInterface
+	badges   LeaderboardBadgeAwarder
+	notifier LeaderboardBadgeNotifier
+	now      func() time.Time
+}
+
+// NewLeaderboardService creates a new LeaderboardService. The notifier is optional.
+func NewLeaderboardService(repo LeaderboardSnapshotRepositoryInterface, badges LeaderboardBadgeAwarder, notifier LeaderboardBadgeNotifier) *LeaderboardService {
+	return &LeaderboardService{
+		repo:     repo,
+		badges:   badges,
+		notifier: notifier,
+		now:      time.Now,
+	}
+}
+
+// RefreshSnapshots computes the current period's leaderboards and, once, the
+// final leaderboards of the period just ended, then awards the badges of
+// seasons that ended. It returns the number of leaderboards saved.
+func (s *LeaderboardService) RefreshSnapshots(ctx context.Context) (int, error) {
+	// Stored timestamps have microsecond precision
+	now := s.now().UTC().Truncate(time.Microsecond)
+
+	saved := 0
+	for _, period := range leaderboardPeriods {
+		current, _ := models.LeaderboardWindowFor(period, now)
+		previous := current.Previous()
+
+		finalized, err := s.repo.HasFinalizedPeriod(ctx, period, previous.Key)
+		if err != nil {
+			return saved, err
+		}
+		if !finalized {
+			n, err := s.computeWindow(ctx, previous, true, now)
+			saved += n
+			if err != nil {
+				return saved, err
+			}
+		}
+
+		n, err := s.computeWindow(ctx, current, false, now)
+		saved += n
+		if err != nil {
+			return saved, err
+		}
+	}
+
+	if err := s.awardSeasonBadges(ctx); err != nil {
+		return saved, err
+	}
+	return saved, nil
+}
+
+// computeWindow computes and saves every leaderboard of a period
+func (s *LeaderboardService) computeWindow(ctx context.Context, window models.LeaderboardWindow, finalized bool, now time.Time) (int, error) {
+	saved := 0
+	for _, leaderboardType := range leaderboardTypes {
+		for _, scope := range leaderboardScopes {
+			scores, err := s.repo.ComputeScores(ctx, leaderboardType, scope, window.Start, window.End, leaderboardSnapshotSize)
+			if err != nil {
+				return saved, err
+			}
+
+			for _, group := range groupLeaderboardScores(scores) {
+				snapshot := &models.LeaderboardSnapshot{
+					Type:        leaderboardType,
+					Period:      window.Period,
+					PeriodKey:   window.Key,
+					PeriodStart: window.Start,
+					PeriodEnd:   window.End,
+					Scope:       scope,
+					ScopeID:     group[0].ScopeID,
+					Finalized:   finalized,
+					ComputedAt:  now,
+				}
+				if err := s.repo.SaveSnapshot(ctx, snapshot, group); err != nil {
+					return saved, err
+				}
+				saved++
+			}
+
+			if _, err := s.repo.DeleteStaleSnapshots(ctx, leaderboardType, window.Period, window.Key, scope, now); err != nil {
+				return saved, err
+			}
+		}
+	}
+	return saved, nil
+}
+
+// groupLeaderboardScores splits scores ordered by scope into one group per scope
+func groupLeaderboardScores(scores []models.LeaderboardScore) [][]models.LeaderboardScore {
+	var groups [][]models.LeaderboardScore
+	for i := 0; i < len(scores); {
+		j := i + 1
+		for j < len(scores) && scores[j].ScopeID == scores[i].ScopeID {
+			j++
+		}
+		groups = append(groups, scores[i:j])
+		i = j
+	}
+	return groups
+}
+
+// awardSeasonBadges awards the top users of each ended season's global karma
+// leaderboard: the Season Champion badge to the winner and the Season Top 10
+// badge to the top ten
+func (s *LeaderboardService) awardSeasonBadges(ctx context.Context) error {
+	if s.badges == nil {
+		return nil
+	}
+	snapshots, err := s.repo.ListSnapshotsAwaitingBadges(ctx, models.LeaderboardTypeKarma, models.LeaderboardPeriodSeason, models.LeaderboardScopeGlobal)
+	if err != nil {
+		return err
+	}
+
+	for _, snapshot := range snapshots {
+		entries, err := s.repo.ListSnapshotEntries(ctx, snapshot.ID, seasonTopTenSize, 0)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			badgeIDs := []string{seasonTopTenBadgeID}
+			if entry.Rank == 1 {
+				badgeIDs = append(badgeIDs, seasonChampionBadgeID)
+			}
+			for _, badgeID := range badgeIDs {
+				if err := s.badges.AwardBadge(ctx, entry.UserID, badgeID, nil); err != nil {
+					return fmt.Errorf("failed to award %s badge for season %s: %w", badgeID, snapshot.PeriodKey, err)
+				}
+				s.notifyBadge(ctx, entry.UserID, badgeID)
+			}
+		}
+		if err := s.repo.MarkBadgesAwarded(ctx, snapshot.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *LeaderboardService) notifyBadge(ctx context.Context, userID uuid.UUID, badgeID string) {
+	if s.notifier == nil {
+		return
+	}
+	badge, err := GetBadgeDefinition(badgeID)
+	if err != nil {
+		return
+	}
+	if err := s.notifier.NotifyBadgeEarned(ctx, userID, badge.Name); err != nil {
+		utils.GetLogger().Error("Failed to notify user of season badge", err, map[string]interface{}{
+			"user_id":  userID.String(),
+			"badge_id": badgeID,
+		})
+	}
+}
+
+// GetLeaderboard returns a page of a weekly, monthly or seasonal leaderboard,
+// global or for a game or community. Without a period key, the current
+// period's leaderboard is returned, empty until it's first computed.
+func (s *LeaderboardService) GetLeaderboard(ctx context.Context, leaderboardType, period, periodKey, scope, scopeID string, limit, offset int) (*models.LeaderboardSnapshot, []models.LeaderboardEntry, error) {
+	if !containsString(leaderboardTypes, leaderboardType) {
+		return nil, nil, ErrInvalidLeaderboardType
+	}
+	current, ok := models.LeaderboardWindowFor(period, s.now())
+	if !ok {
+		return nil, nil, ErrInvalidLeaderboardPeriod
+	}
+	if limit <= 0 || limit > leaderboardSnapshotSize {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	key := periodKey
+	if key == "" {
+		key = current.Key
+	}
+	snapshot, err := s.repo.GetSnapshot(ctx, leaderboardType, period, key, scope, scopeID)
+	if errors.Is(err, repository.ErrLeaderboardSnapshotNotFound) && periodKey == "" {
+		return &models.LeaderboardSnapshot{
+			Type:        leaderboardType,
+			Period:      period,
+			PeriodKey:   current.Key,
+			PeriodStart: current.Start,
+			PeriodEnd:   current.End,
+			Scope:       scope,
+			ScopeID:     scopeID,
+		}, []models.LeaderboardEntry{}, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entries, err := s.repo.ListSnapshotEntries(ctx, snapshot.ID, limit, offset)
+	if err != nil {
+		return nil, nil, err
+	}
+	return snapshot, entries, nil
+}
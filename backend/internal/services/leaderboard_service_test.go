@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+// MockLeaderboardSnapshotRepository is a mock implementation of LeaderboardSnapshotRepositoryInterface
+type MockLeaderboardSnapshotRepository struct {
+	mock.Mock
+}
+
+func (m *MockLeaderboardSnapshotRepository) ComputeScores(ctx context.Context, leaderboardType, scope string, start, end time.Time, limit int) ([]models.LeaderboardScore, error) {
+	args := m.Called(ctx, leaderboardType, scope, start, end, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.LeaderboardScore), args.Error(1)
+}
+
+func (m *MockLeaderboardSnapshotRepository) SaveSnapshot(ctx context.Context, snapshot *models.LeaderboardSnapshot, scores []models.LeaderboardScore) error {
+	args := m.Called(ctx, snapshot, scores)
+	return args.Error(0)
+}
+
+func (m *MockLeaderboardSnapshotRepository) DeleteStaleSnapshots(ctx context.Context, leaderboardType, period, periodKey, scope string, before time.Time) (int64, error) {
+	args := m.Called(ctx, leaderboardType, period, periodKey, scope, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockLeaderboardSnapshotRepository) HasFinalizedPeriod(ctx context.Context, period, periodKey string) (bool, error) {
+	args := m.Called(ctx, period, periodKey)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLeaderboardSnapshotRepository) GetSnapshot(ctx context.Context, leaderboardType, period, periodKey, scope, scopeID string) (*models.LeaderboardSnapshot, error) {
+	args := m.Called(ctx, leaderboardType, period, periodKey, scope, scopeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.LeaderboardSnapshot), args.Error(1)
+}
+
+func (m *MockLeaderboardSnapshotRepository) ListSnapshotEntries(ctx context.Context, snapshotID uuid.UUID, limit, offset int) ([]models.LeaderboardEntry, error) {
+	args := m.Called(ctx, snapshotID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.LeaderboardEntry), args.Error(1)
+}
+
+func (m *MockLeaderboardSnapshotRepository) ListSnapshotsAwaitingBadges(ctx context.Context, leaderboardType, period, scope string) ([]models.LeaderboardSnapshot, error) {
+	args := m.Called(ctx, leaderboardType, period, scope)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.LeaderboardSnapshot), args.Error(1)
+}
+
+func (m *MockLeaderboardSnapshotRepository) MarkBadgesAwarded(ctx context.Context, snapshotID uuid.UUID) error {
+	args := m.Called(ctx, snapshotID)
+	return args.Error(0)
+}
+
+// MockLeaderboardBadgeAwarder is a mock implementation of LeaderboardBadgeAwarder
+type MockLeaderboardBadgeAwarder struct {
+	mock.Mock
+}
+
+func (m *MockLeaderboardBadgeAwarder) AwardBadge(ctx context.Context, userID uuid.UUID, badgeID string, awardedBy *uuid.UUID) error {
+	args := m.Called(ctx, userID, badgeID, awardedBy)
+	return args.Error(0)
+}
+
+func setupLeaderboardServiceTest() (*LeaderboardService, *MockLeaderboardSnapshotRepository, *MockLeaderboardBadgeAwarder) {
+	repo := new(MockLeaderboardSnapshotRepository)
+	badges := new(MockLeaderboardBadgeAwarder)
+	svc := NewLeaderboardService(repo, badges, nil)
+	svc.now = func() time.Time { return time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC) }
+	return svc, repo, badges
+}
+
+func rankedScores(scopeID string, userIDs ...uuid.UUID) []models.LeaderboardScore {
+	scores := make([]models.LeaderboardScore, len(userIDs))
+	for i, userID := range userIDs {
+		scores[i] = models.LeaderboardScore{ScopeID: scopeID, Rank: i + 1, UserID: userID, Score: 100 - i}
+	}
+	return scores
+}
+
+func TestLeaderboardService_RefreshSnapshots(t *testing.T) {
+	ctx := context.Background()
+	first, second := uuid.New(), uuid.New()
+	seasonID := uuid.New()
+	svc, repo, badges := setupLeaderboardServiceTest()
+
+	// 3 periods, current and previous, 2 types on the first run, then the
+	// current periods only
+	previousKeys := map[string]string{
+		models.LeaderboardPeriodWeekly:  "2026-W41",
+		models.LeaderboardPeriodMonthly: "2026-09",
+		models.LeaderboardPeriodSeason:  "2026-Q3",
+	}
+	for period, key := range previousKeys {
+		repo.On("HasFinalizedPeriod", ctx, period, key).Return(false, nil).Once()
+		repo.On("HasFinalizedPeriod", ctx, period, key).Return(true, nil).Once()
+	}
+	repo.On("ComputeScores", ctx, mock.Anything, models.LeaderboardScopeGlobal, mock.Anything, mock.Anything, leaderboardSnapshotSize).
+		Return(rankedScores("", first, second), nil).Times(18)
+	repo.On("ComputeScores", ctx, mock.Anything, models.LeaderboardScopeGame, mock.Anything, mock.Anything, leaderboardSnapshotSize).
+		Return(append(rankedScores("509658", first), rankedScores("21779", second)...), nil).Times(18)
+	repo.On("ComputeScores", ctx, mock.Anything, models.LeaderboardScopeCommunity, mock.Anything, mock.Anything, leaderboardSnapshotSize).
+		Return(nil, nil).Times(18)
+
+	// Each game gets its own leaderboard
+	currentWeek := mock.MatchedBy(func(snapshot *models.LeaderboardSnapshot) bool {
+		return snapshot.Type == models.LeaderboardTypeKarma && snapshot.Period == models.LeaderboardPeriodWeekly &&
+			snapshot.PeriodKey == "2026-W42" && snapshot.ScopeID == "21779" && !snapshot.Finalized
+	})
+	repo.On("SaveSnapshot", ctx, currentWeek, rankedScores("21779", second)).Return(nil).Twice()
+	// The season that just ended is finalized once
+	endedSeason := mock.MatchedBy(func(snapshot *models.LeaderboardSnapshot) bool {
+		return snapshot.Type == models.LeaderboardTypeKarma && snapshot.Period == models.LeaderboardPeriodSeason &&
+			snapshot.PeriodKey == "2026-Q3" && snapshot.Scope == models.LeaderboardScopeGlobal && snapshot.Finalized
+	})
+	repo.On("SaveSnapshot", ctx, endedSeason, rankedScores("", first, second)).Return(nil).Once()
+	repo.On("SaveSnapshot", ctx, mock.Anything, mock.Anything).Return(nil).Times(51)
+	repo.On("DeleteStaleSnapshots", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(int64(0), nil).Times(54)
+
+	// Its top users get badges once
+	repo.On("ListSnapshotsAwaitingBadges", ctx, models.LeaderboardTypeKarma, models.LeaderboardPeriodSeason, models.LeaderboardScopeGlobal).
+		Return([]models.LeaderboardSnapshot{{ID: seasonID, PeriodKey: "2026-Q3", Finalized: true}}, nil).Once()
+	repo.On("ListSnapshotsAwaitingBadges", ctx, models.LeaderboardTypeKarma, models.LeaderboardPeriodSeason, models.LeaderboardScopeGlobal).
+		Return([]models.LeaderboardSnapshot{}, nil).Once()
+	repo.On("ListSnapshotEntries", ctx, seasonID, seasonTopTenSize, 0).
+		Return([]models.LeaderboardEntry{{Rank: 1, UserID: first, Score: 100}, {Rank: 2, UserID: second, Score: 99}}, nil).Once()
+	repo.On("MarkBadgesAwarded", ctx, seasonID).Return(nil).Once()
+	badges.On("AwardBadge", ctx, first, seasonTopTenBadgeID, (*uuid.UUID)(nil)).Return(nil).Once()
+	badges.On("AwardBadge", ctx, first, seasonChampionBadgeID, (*uuid.UUID)(nil)).Return(nil).Once()
+	badges.On("AwardBadge", ctx, second, seasonTopTenBadgeID, (*uuid.UUID)(nil)).Return(nil).Once()
+
+	saved, err := svc.RefreshSnapshots(ctx)
+	require.NoError(t, err)
+	// 1 global and 2 game leaderboards for each of the 12 windows
+	assert.Equal(t, 36, saved)
+
+	saved, err = svc.RefreshSnapshots(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 18, saved)
+
+	repo.AssertExpectations(t)
+	badges.AssertExpectations(t)
+}
+
+func TestLeaderboardService_GetLeaderboard(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, _ := setupLeaderboardServiceTest()
+
+	t.Run("Current period not computed yet is empty", func(t *testing.T) {
+		repo.On("GetSnapshot", ctx, models.LeaderboardTypeEngagement, models.LeaderboardPeriodMonthly, "2026-10", models.LeaderboardScopeGlobal, "").
+			Return(nil, repository.ErrLeaderboardSnapshotNotFound).Once()
+
+		snapshot, entries, err := svc.GetLeaderboard(ctx, models.LeaderboardTypeEngagement, models.LeaderboardPeriodMonthly, "", models.LeaderboardScopeGlobal, "", 50, 0)
+		require.NoError(t, err)
+		assert.Equal(t, "2026-10", snapshot.PeriodKey)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("Past period is paged", func(t *testing.T) {
+		snapshotID, userID := uuid.New(), uuid.New()
+		repo.On("GetSnapshot", ctx, models.LeaderboardTypeKarma, models.LeaderboardPeriodWeekly, "2026-W41", models.LeaderboardScopeGame, "21779").
+			Return(&models.LeaderboardSnapshot{ID: snapshotID, PeriodKey: "2026-W41", Finalized: true}, nil).Once()
+		repo.On("ListSnapshotEntries", ctx, snapshotID, 50, 10).
+			Return([]models.LeaderboardEntry{{Rank: 11, UserID: userID, Score: 7}}, nil).Once()
+
+		snapshot, entries, err := svc.GetLeaderboard(ctx, models.LeaderboardTypeKarma, models.LeaderboardPeriodWeekly, "2026-W41", models.LeaderboardScopeGame, "21779", 500, 10)
+		require.NoError(t, err)
+		assert.True(t, snapshot.Finalized)
+		require.Len(t, entries, 1)
+		assert.Equal(t, userID, entries[0].UserID)
+	})
+
+	t.Run("Unknown past period is not found", func(t *testing.T) {
+		repo.On("GetSnapshot", ctx, models.LeaderboardTypeEngagement, models.LeaderboardPeriodMonthly, "2020-01", models.LeaderboardScopeGlobal, "").
+			Return(nil, repository.ErrLeaderboardSnapshotNotFound).Once()
+
+		_, _, err := svc.GetLeaderboard(ctx, models.LeaderboardTypeEngagement, models.LeaderboardPeriodMonthly, "2020-01", models.LeaderboardScopeGlobal, "", 50, 0)
+		assert.ErrorIs(t, err, repository.ErrLeaderboardSnapshotNotFound)
+	})
+
+	t.Run("Invalid type and period", func(t *testing.T) {
+		_, _, err := svc.GetLeaderboard(ctx, "clips", models.LeaderboardPeriodWeekly, "", models.LeaderboardScopeGlobal, "", 50, 0)
+		assert.ErrorIs(t, err, ErrInvalidLeaderboardType)
+		_, _, err = svc.GetLeaderboard(ctx, models.LeaderboardTypeKarma, "daily", "", models.LeaderboardScopeGlobal, "", 50, 0)
+		assert.ErrorIs(t, err, ErrInvalidLeaderboardPeriod)
+	})
+
+	repo.AssertExpectations(t)
+}
@@ -100,80 +100,126 @@ func (s *LiveStatusService) UpdateLiveStatusForBroadcasters(ctx context.Context,
 		// Update status for each broadcaster in batch
 		now := time.Now()
 		for _, broadcasterID := range batch {
-			// Get previous sync status to detect changes
-			oldSyncStatus, err := s.broadcasterRepo.GetSyncStatus(ctx, broadcasterID)
-			if err != nil && err != sql.ErrNoRows {
-				log.Printf("Failed to get previous sync status for broadcaster %s: %v", broadcasterID, err)
+			stream := liveMap[broadcasterID]
+			if stream != nil && stream.Type != "live" {
+				stream = nil
 			}
+			s.applyStatus(ctx, broadcasterID, stream, now)
+		}
+	}
 
-			// Prepare new status
-			status := &models.BroadcasterLiveStatus{
-				BroadcasterID: broadcasterID,
-				IsLive:        false,
-				ViewerCount:   0,
-				LastChecked:   now,
-			}
+	return nil
+}
 
-			syncStatus := &models.BroadcasterSyncStatus{
-				BroadcasterID: broadcasterID,
-				IsLive:        false,
-				LastSynced:    now,
-				ViewerCount:   0,
-			}
+// HandleStreamOnline marks a broadcaster live from a stream.online event and
+// notifies followers if they were offline. Stream details come from Twitch
+// when available; otherwise the event's own fields are used.
+func (s *LiveStatusService) HandleStreamOnline(ctx context.Context, event *twitch.EventSubStreamOnlineEvent) error {
+	stream := &twitch.Stream{
+		ID:        event.ID,
+		UserID:    event.BroadcasterUserID,
+		UserLogin: event.BroadcasterUserLogin,
+		UserName:  event.BroadcasterUserName,
+		Type:      "live",
+		StartedAt: event.StartedAt,
+	}
 
-			var statusChange *string
-			if stream, isLive := liveMap[broadcasterID]; isLive && stream.Type == "live" {
-				status.IsLive = true
-				status.UserLogin = &stream.UserLogin
-				status.UserName = &stream.UserName
-				status.StreamTitle = &stream.Title
-				status.GameName = &stream.GameName
-				status.ViewerCount = stream.ViewerCount
-				status.StartedAt = &stream.StartedAt
-
-				syncStatus.IsLive = true
-				syncStatus.StreamStartedAt = &stream.StartedAt
-				syncStatus.GameName = &stream.GameName
-				syncStatus.ViewerCount = stream.ViewerCount
-				syncStatus.StreamTitle = &stream.Title
-
-				// Detect status change: offline -> live
-				if oldSyncStatus == nil || !oldSyncStatus.IsLive {
-					changeMsg := "went_live"
-					statusChange = &changeMsg
-					// Notify broadcaster followers
-					s.notifyFollowers(ctx, broadcasterID, stream)
-					// Notify stream followers
-					if stream.UserLogin != "" {
-						s.notifyStreamFollowers(ctx, stream.UserLogin, stream)
-					}
-				}
-			} else {
-				// Broadcaster is offline
-				if oldSyncStatus != nil && oldSyncStatus.IsLive {
-					changeMsg := "went_offline"
-					statusChange = &changeMsg
+	if s.twitchClient != nil {
+		streams, err := s.twitchClient.GetStreams(ctx, []string{event.BroadcasterUserID})
+		if err != nil {
+			log.Printf("Failed to fetch stream details for broadcaster %s: %v", event.BroadcasterUserID, err)
+		} else {
+			for i := range streams.Data {
+				if streams.Data[i].UserID == event.BroadcasterUserID && streams.Data[i].Type == "live" {
+					stream = &streams.Data[i]
 				}
 			}
+		}
+	}
 
-			// Update live status
-			if err := s.broadcasterRepo.UpsertLiveStatus(ctx, status); err != nil {
-				log.Printf("Failed to update live status for broadcaster %s: %v", broadcasterID, err)
-			}
+	s.applyStatus(ctx, event.BroadcasterUserID, stream, time.Now())
+	return nil
+}
 
-			// Update sync status
-			if err := s.broadcasterRepo.UpsertSyncStatus(ctx, syncStatus); err != nil {
-				log.Printf("Failed to update sync status for broadcaster %s: %v", broadcasterID, err)
-			}
+// HandleStreamOffline marks a broadcaster offline from a stream.offline event
+func (s *LiveStatusService) HandleStreamOffline(ctx context.Context, event *twitch.EventSubStreamOfflineEvent) error {
+	s.applyStatus(ctx, event.BroadcasterUserID, nil, time.Now())
+	return nil
+}
+
+// applyStatus stores a broadcaster's live status, notifying followers when
+// they go live. A nil stream means the broadcaster is offline.
+func (s *LiveStatusService) applyStatus(ctx context.Context, broadcasterID string, stream *twitch.Stream, now time.Time) {
+	// Get previous sync status to detect changes
+	oldSyncStatus, err := s.broadcasterRepo.GetSyncStatus(ctx, broadcasterID)
+	if err != nil && err != sql.ErrNoRows {
+		log.Printf("Failed to get previous sync status for broadcaster %s: %v", broadcasterID, err)
+	}
+
+	// Prepare new status
+	status := &models.BroadcasterLiveStatus{
+		BroadcasterID: broadcasterID,
+		IsLive:        false,
+		ViewerCount:   0,
+		LastChecked:   now,
+	}
+
+	syncStatus := &models.BroadcasterSyncStatus{
+		BroadcasterID: broadcasterID,
+		IsLive:        false,
+		LastSynced:    now,
+		ViewerCount:   0,
+	}
 
-			// Log sync event if there was a status change
-			if statusChange != nil {
-				s.logSyncEvent(ctx, broadcasterID, statusChange, nil)
+	var statusChange *string
+	if stream != nil {
+		status.IsLive = true
+		status.UserLogin = &stream.UserLogin
+		status.UserName = &stream.UserName
+		status.StreamTitle = &stream.Title
+		status.GameName = &stream.GameName
+		status.ViewerCount = stream.ViewerCount
+		status.StartedAt = &stream.StartedAt
+
+		syncStatus.IsLive = true
+		syncStatus.StreamStartedAt = &stream.StartedAt
+		syncStatus.GameName = &stream.GameName
+		syncStatus.ViewerCount = stream.ViewerCount
+		syncStatus.StreamTitle = &stream.Title
+
+		// Detect status change: offline -> live
+		if oldSyncStatus == nil || !oldSyncStatus.IsLive {
+			changeMsg := "went_live"
+			statusChange = &changeMsg
+			// Notify broadcaster followers
+			s.notifyFollowers(ctx, broadcasterID, stream)
+			// Notify stream followers
+			if stream.UserLogin != "" {
+				s.notifyStreamFollowers(ctx, stream.UserLogin, stream)
 			}
 		}
+	} else {
+		// Broadcaster is offline
+		if oldSyncStatus != nil && oldSyncStatus.IsLive {
+			changeMsg := "went_offline"
+			statusChange = &changeMsg
+		}
 	}
 
-	return nil
+	// Update live status
+	if err := s.broadcasterRepo.UpsertLiveStatus(ctx, status); err != nil {
+		log.Printf("Failed to update live status for broadcaster %s: %v", broadcasterID, err)
+	}
+
+	// Update sync status
+	if err := s.broadcasterRepo.UpsertSyncStatus(ctx, syncStatus); err != nil {
+		log.Printf("Failed to update sync status for broadcaster %s: %v", broadcasterID, err)
+	}
+
+	// Log sync event if there was a status change
+	if statusChange != nil {
+		s.logSyncEvent(ctx, broadcasterID, statusChange, nil)
+	}
 }
 
 // notifyFollowers sends notifications to all followers when a broadcaster goes live
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const (
+	// maintenanceStateKey holds the mode set by an admin, shared by every instance
+	maintenanceStateKey = "maintenance:state"
+	// maintenanceLocalTTL bounds how long an instance serves the mode from
+	// memory, so a toggle reaches every instance within this window
+	maintenanceLocalTTL = 5 * time.Second
+)
+
+// ErrMaintenanceUnavailable is returned when toggling maintenance mode without
+// Redis, where other instances couldn't see the change
+var ErrMaintenanceUnavailable = errors.New("maintenance mode can't be toggled without Redis")
+
+// MaintenanceAuditLogger records who toggled maintenance mode
+type MaintenanceAuditLogger interface {
+	LogAction(ctx context.Context, action string, actor uuid.UUID, target uuid.UUID, entityType string, opts AuditLogOptions) error
+}
+
+// MaintenanceService tracks the API's break-glass read-only maintenance
+// mode. The mode starts from config; admins can switch it at runtime, which
+// overrides config on every instance through Redis.
+type MaintenanceService struct {
+	cache    RedisCache
+	auditLog MaintenanceAuditLogger
+	fallback models.MaintenanceStatus
+	now      func() time.Time
+
+	mu       sync.RWMutex
+	status   *models.MaintenanceStatus
+	loadedAt time.Time
+}
+
+// NewMaintenanceService creates a new MaintenanceService. readOnly and
+// message are the configured mode, used until an admin switches it.
+func NewMaintenanceService(cache RedisCache, auditLog MaintenanceAuditLogger, readOnly bool, message string) *MaintenanceService {
+	return &MaintenanceService{
+		cache:    cache,
+		auditLog: auditLog,
+		fallback: models.MaintenanceStatus{
+			ReadOnly: readOnly,
+			Message:  message,
+			Source:   models.MaintenanceSourceConfig,
+		},
+		now: time.Now,
+	}
+}
+
+// Status returns the current maintenance mode. It is checked on every
+// mutating request, so it's served from memory; when Redis can't be read
+// the configured mode applies.
+func (s *MaintenanceService) Status(ctx context.Context) models.MaintenanceStatus {
+	s.mu.RLock()
+	if s.status != nil && s.now().Sub(s.loadedAt) < maintenanceLocalTTL {
+		status := *s.status
+		s.mu.RUnlock()
+		return status
+	}
+	s.mu.RUnlock()
+
+	status := s.fallback
+	if s.cache != nil {
+		var stored models.MaintenanceStatus
+		if err := s.cache.GetJSON(ctx, maintenanceStateKey, &stored); err == nil {
+			status = stored
+		}
+	}
+
+	s.remember(status)
+	return status
+}
+
+// SetStatus switches maintenance mode for every instance and records who did
+// it. The audit entry is best effort: the switch must work during incidents
+// that take the database down.
+func (s *MaintenanceService) SetStatus(ctx context.Context, adminID uuid.UUID, req *models.UpdateMaintenanceRequest, ipAddress, userAgent string) (*models.MaintenanceStatus, error) {
+	if s.cache == nil {
+		return nil, ErrMaintenanceUnavailable
+	}
+
+	now := s.now()
+	status := models.MaintenanceStatus{
+		ReadOnly:  *req.ReadOnly,
+		Message:   req.Message,
+		Source:    models.MaintenanceSourceAdmin,
+		UpdatedBy: &adminID,
+		UpdatedAt: &now,
+	}
+	if err := s.cache.SetJSON(ctx, maintenanceStateKey, status, 0); err != nil {
+		return nil, err
+	}
+	s.remember(status)
+
+	action := "maintenance_mode_disabled"
+	if status.ReadOnly {
+		action = "maintenance_mode_enabled"
+	}
+	// Maintenance mode isn't a stored entity, so the entry has no entity ID
+	if s.auditLog != nil {
+		if err := s.auditLog.LogAction(ctx, action, adminID, uuid.Nil, "system", AuditLogOptions{
+			Reason:    req.Reason,
+			Metadata:  map[string]interface{}{"message": req.Message},
+			IPAddress: &ipAddress,
+			UserAgent: &userAgent,
+		}); err != nil {
+			utils.Error("Failed to audit maintenance mode change", err, map[string]interface{}{
+				"action":   action,
+				"admin_id": adminID.String(),
+			})
+		}
+	}
+
+	utils.Warn("Maintenance mode changed", map[string]interface{}{
+		"read_only": status.ReadOnly,
+		"admin_id":  adminID.String(),
+	})
+	return &status, nil
+}
+
+// remember keeps the mode in memory for this instance
+func (s *MaintenanceService) remember(status models.MaintenanceStatus) {
+	s.mu.Lock()
+	s.status = &status
+	s.loadedAt = s.now()
+	s.mu.Unlock()
+}
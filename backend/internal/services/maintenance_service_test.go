@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+func TestMaintenanceService_AdminOverridesConfig(t *testing.T) {
+	cache := new(MockRedisClient)
+	audit := new(MockAuditLogService)
+	svc := NewMaintenanceService(cache, audit, false, "")
+	ctx := context.Background()
+
+	cache.On("GetJSON", ctx, maintenanceStateKey, mock.Anything).Return(errors.New("cache miss")).Once()
+	assert.False(t, svc.Status(ctx).ReadOnly)
+	assert.Equal(t, models.MaintenanceSourceConfig, svc.Status(ctx).Source)
+
+	adminID := uuid.New()
+	readOnly := true
+	var stored models.MaintenanceStatus
+	cache.On("SetJSON", ctx, maintenanceStateKey, mock.Anything, time.Duration(0)).
+		Run(func(args mock.Arguments) {
+			stored = args.Get(2).(models.MaintenanceStatus)
+		}).
+		Return(nil).Once()
+	audit.On("LogAction", ctx, "maintenance_mode_enabled", adminID, uuid.Nil, "system", mock.Anything).Return(nil).Once()
+
+	status, err := svc.SetStatus(ctx, adminID, &models.UpdateMaintenanceRequest{ReadOnly: &readOnly, Message: "Database failover"}, "10.0.0.1", "curl")
+	require.NoError(t, err)
+	assert.True(t, status.ReadOnly)
+	assert.Equal(t, adminID, *status.UpdatedBy)
+
+	// Another instance sees the switch through Redis
+	cache.On("GetJSON", ctx, maintenanceStateKey, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(2).(*models.MaintenanceStatus) = stored
+		}).
+		Return(nil).Once()
+	other := NewMaintenanceService(cache, audit, false, "")
+	current := other.Status(ctx)
+	assert.True(t, current.ReadOnly)
+	assert.Equal(t, "Database failover", current.Message)
+	assert.Equal(t, models.MaintenanceSourceAdmin, current.Source)
+
+	cache.AssertExpectations(t)
+	audit.AssertExpectations(t)
+}
+
+func TestMaintenanceService_ServesStatusFromMemory(t *testing.T) {
+	cache := new(MockRedisClient)
+	svc := NewMaintenanceService(cache, nil, false, "")
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	cache.On("GetJSON", ctx, maintenanceStateKey, mock.Anything).Return(errors.New("cache miss")).Once()
+	assert.False(t, svc.Status(ctx).ReadOnly)
+
+	cache.On("GetJSON", ctx, maintenanceStateKey, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(2).(*models.MaintenanceStatus) = models.MaintenanceStatus{ReadOnly: true, Source: models.MaintenanceSourceAdmin}
+		}).
+		Return(nil).Once()
+
+	assert.False(t, svc.Status(ctx).ReadOnly, "cached mode is served until it expires")
+	now = now.Add(maintenanceLocalTTL)
+	assert.True(t, svc.Status(ctx).ReadOnly)
+
+	cache.AssertExpectations(t)
+}
+
+func TestMaintenanceService_SwitchSurvivesAuditFailure(t *testing.T) {
+	cache := new(MockRedisClient)
+	audit := new(MockAuditLogService)
+	svc := NewMaintenanceService(cache, audit, true, "Planned upgrade")
+	ctx := context.Background()
+
+	cache.On("GetJSON", ctx, maintenanceStateKey, mock.Anything).Return(errors.New("cache miss")).Once()
+	assert.True(t, svc.Status(ctx).ReadOnly)
+
+	readOnly := false
+	cache.On("SetJSON", ctx, maintenanceStateKey, mock.Anything, time.Duration(0)).Return(nil).Once()
+	audit.On("LogAction", ctx, "maintenance_mode_disabled", mock.Anything, uuid.Nil, "system", mock.Anything).
+		Return(errors.New("database down")).Once()
+	_, err := svc.SetStatus(ctx, uuid.New(), &models.UpdateMaintenanceRequest{ReadOnly: &readOnly}, "10.0.0.1", "curl")
+	require.NoError(t, err)
+	assert.False(t, svc.Status(ctx).ReadOnly)
+
+	cache.AssertExpectations(t)
+	audit.AssertExpectations(t)
+}
+
+func TestMaintenanceService_RequiresRedisToSwitch(t *testing.T) {
+	svc := NewMaintenanceService(nil, nil, true, "")
+	assert.True(t, svc.Status(context.Background()).ReadOnly)
+
+	readOnly := false
+	_, err := svc.SetStatus(context.Background(), uuid.New(), &models.UpdateMaintenanceRequest{ReadOnly: &readOnly}, "", "")
+	assert.ErrorIs(t, err, ErrMaintenanceUnavailable)
+}
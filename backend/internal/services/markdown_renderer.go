@@ -0,0 +1,307 @@
+package services
+
+import (
+	"bytes"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// MarkdownLinkPolicy decides how links in user-written Markdown are rendered.
+// Hosts match their subdomains too.
+type MarkdownLinkPolicy struct {
+	// SiteHosts are the site's own hosts. Links to them are made relative, so
+	// they open in place and aren't marked nofollow.
+	SiteHosts []string
+	// BlockedHosts are hosts whose links are shown as plain text
+	BlockedHosts []string
+	// UnfurlHosts are hosts whose links may be shown with a preview card.
+	// Links to the site itself always may.
+	UnfurlHosts []string
+	// MaxUnfurls caps how many links in one text are marked for previews
+	MaxUnfurls int
+}
+
+// unfurlClass marks links the client may show a preview card for
+const unfurlClass = "unfurl"
+
+// MarkdownRenderer renders the Markdown subset allowed in comments and
+// community discussions to safe HTML: bold, italics, strikethrough, links,
+// spoilers (||text||), code, blockquotes and lists. Output is sanitized
+// against an allowlist, so anything else, raw HTML included, is dropped.
+type MarkdownRenderer struct {
+	markdown  goldmark.Markdown
+	sanitizer *bluemonday.Policy
+}
+
+// NewMarkdownRenderer creates a new MarkdownRenderer with a link policy
+func NewMarkdownRenderer(policy MarkdownLinkPolicy) *MarkdownRenderer {
+	return &MarkdownRenderer{
+		markdown:  newMarkdownProcessor(policy),
+		sanitizer: newMarkdownSanitizer(policy),
+	}
+}
+
+// Render renders Markdown to sanitized HTML
+func (r *MarkdownRenderer) Render(content string) string {
+	return renderMarkdown(r.markdown, r.sanitizer, content)
+}
+
+// renderMarkdown converts Markdown and sanitizes the HTML. Removed and deleted
+// placeholders are returned as they are, and so is text that fails to convert.
+func renderMarkdown(md goldmark.Markdown, sanitizer *bluemonday.Policy, content string) string {
+	if content == "[deleted]" || content == "[removed]" {
+		return content
+	}
+
+	var buf bytes.Buffer
+	if err := md.Convert([]byte(content), &buf); err != nil {
+		return content
+	}
+
+	return sanitizer.Sanitize(buf.String())
+}
+
+// newMarkdownProcessor builds a parser for the allowed subset. Headings,
+// thematic breaks and tables aren't parsed, so their markers stay as text.
+func newMarkdownProcessor(policy MarkdownLinkPolicy) goldmark.Markdown {
+	p := parser.NewParser(
+		parser.WithBlockParsers(
+			util.Prioritized(parser.NewListParser(), 300),
+			util.Prioritized(parser.NewListItemParser(), 400),
+			util.Prioritized(parser.NewCodeBlockParser(), 500),
+			util.Prioritized(parser.NewFencedCodeBlockParser(), 700),
+			util.Prioritized(parser.NewBlockquoteParser(), 800),
+			util.Prioritized(parser.NewHTMLBlockParser(), 900),
+			util.Prioritized(parser.NewParagraphParser(), 1000),
+		),
+		parser.WithInlineParsers(parser.DefaultInlineParsers()...),
+		parser.WithParagraphTransformers(parser.DefaultParagraphTransformers()...),
+		parser.WithASTTransformers(
+			util.Prioritized(&markdownLinkTransformer{policy: policy}, 100),
+		),
+	)
+
+	return goldmark.New(
+		goldmark.WithParser(p),
+		goldmark.WithExtensions(
+			extension.Strikethrough,
+			extension.Linkify,
+			markdownSpoilers,
+		),
+		goldmark.WithRendererOptions(
+			html.WithXHTML(),
+			html.WithUnsafe(), // Raw HTML is dropped by the sanitizer instead
+		),
+	)
+}
+
+// newMarkdownSanitizer builds the allowlist rendered Markdown is sanitized against
+func newMarkdownSanitizer(policy MarkdownLinkPolicy) *bluemonday.Policy {
+	sanitizer := bluemonday.NewPolicy()
+	sanitizer.AllowElements("p", "br", "strong", "em", "del", "code", "pre", "blockquote",
+		"ul", "ol", "li", "a", "span")
+	sanitizer.AllowAttrs("href").OnElements("a")
+	sanitizer.AllowAttrs("class").Matching(regexp.MustCompile(`^` + unfurlClass + `$`)).OnElements("a")
+	sanitizer.AllowAttrs("class").Matching(regexp.MustCompile(`^spoiler$`)).OnElements("span")
+	sanitizer.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]+$`)).OnElements("code")
+	sanitizer.AllowAttrs("start").Matching(bluemonday.Integer).OnElements("ol")
+
+	// Blocked hosts lose their href, which leaves the link text
+	allowHost := func(u *url.URL) bool {
+		return !matchesHost(u.Hostname(), policy.BlockedHosts)
+	}
+	sanitizer.RequireParseableURLs(true)
+	sanitizer.AllowRelativeURLs(true)
+	sanitizer.AllowURLSchemeWithCustomPolicy("http", allowHost)
+	sanitizer.AllowURLSchemeWithCustomPolicy("https", allowHost)
+	sanitizer.AllowURLSchemes("mailto")
+
+	sanitizer.RequireNoFollowOnFullyQualifiedLinks(true)
+	sanitizer.RequireNoReferrerOnFullyQualifiedLinks(true)
+	sanitizer.AddTargetBlankToFullyQualifiedLinks(true)
+	return sanitizer
+}
+
+// matchesHost reports whether host is one of hosts or a subdomain of one
+func matchesHost(host string, hosts []string) bool {
+	host = strings.ToLower(host)
+	for _, h := range hosts {
+		h = strings.ToLower(h)
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return true
+		}
+	}
+	return false
+}
+
+// markdownLinkTransformer applies the link policy: links to the site are
+// made relative, and the first links that may unfurl are marked for previews
+type markdownLinkTransformer struct {
+	policy MarkdownLinkPolicy
+}
+
+// Transform implements parser.ASTTransformer
+func (t *markdownLinkTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	source := reader.Source()
+
+	var links []ast.Node
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch link := n.(type) {
+		case *ast.Link:
+			links = append(links, link)
+		case *ast.AutoLink:
+			if link.AutoLinkType == ast.AutoLinkURL {
+				links = append(links, link)
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+
+	unfurls := 0
+	for _, n := range links {
+		var dest []byte
+		if link, ok := n.(*ast.Link); ok {
+			dest = link.Destination
+		} else {
+			dest = n.(*ast.AutoLink).URL(source)
+		}
+
+		u, err := url.Parse(string(dest))
+		if err != nil {
+			continue
+		}
+		host := u.Hostname()
+		if matchesHost(host, t.policy.BlockedHosts) {
+			continue
+		}
+
+		local := host == "" && u.Scheme == "" && strings.HasPrefix(u.Path, "/")
+		if host != "" && matchesHost(host, t.policy.SiteHosts) {
+			n = relativeLink(n, u, source)
+			local = true
+		}
+
+		if unfurls < t.policy.MaxUnfurls && (local || matchesHost(host, t.policy.UnfurlHosts)) {
+			n.SetAttributeString("class", []byte(unfurlClass))
+			unfurls++
+		}
+	}
+}
+
+// relativeLink points a link to the site at its path, replacing autolinks with
+// links so their destination can change. It returns the link now in the tree.
+func relativeLink(n ast.Node, u *url.URL, source []byte) ast.Node {
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	if u.Fragment != "" {
+		path += "#" + u.EscapedFragment()
+	}
+
+	if link, ok := n.(*ast.Link); ok {
+		link.Destination = []byte(path)
+		return link
+	}
+
+	auto := n.(*ast.AutoLink)
+	link := ast.NewLink()
+	link.Destination = []byte(path)
+	link.AppendChild(link, ast.NewString(auto.Label(source)))
+	auto.Parent().ReplaceChild(auto.Parent(), auto, link)
+	return link
+}
+
+// kindSpoiler is the node kind of ||spoiler|| text
+var kindSpoiler = ast.NewNodeKind("Spoiler")
+
+type spoilerNode struct {
+	ast.BaseInline
+}
+
+func (n *spoilerNode) Kind() ast.NodeKind {
+	return kindSpoiler
+}
+
+func (n *spoilerNode) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+type spoilerDelimiterProcessor struct{}
+
+func (p *spoilerDelimiterProcessor) IsDelimiter(b byte) bool {
+	return b == '|'
+}
+
+func (p *spoilerDelimiterProcessor) CanOpenCloser(opener, closer *parser.Delimiter) bool {
+	return opener.Char == closer.Char
+}
+
+func (p *spoilerDelimiterProcessor) OnMatch(consumes int) ast.Node {
+	return &spoilerNode{}
+}
+
+// spoilerParser parses text between double pipes as a spoiler
+type spoilerParser struct{}
+
+func (s *spoilerParser) Trigger() []byte {
+	return []byte{'|'}
+}
+
+func (s *spoilerParser) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	before := block.PrecendingCharacter()
+	line, segment := block.PeekLine()
+	node := parser.ScanDelimiter(line, before, 2, &spoilerDelimiterProcessor{})
+	if node == nil || node.OriginalLength != 2 || before == '|' {
+		return nil
+	}
+
+	node.Segment = segment.WithStop(segment.Start + node.OriginalLength)
+	block.Advance(node.OriginalLength)
+	pc.PushDelimiter(node)
+	return node
+}
+
+type spoilerHTMLRenderer struct{}
+
+func (r *spoilerHTMLRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(kindSpoiler, func(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if entering {
+			_, _ = w.WriteString(`<span class="spoiler">`)
+		} else {
+			_, _ = w.WriteString("</span>")
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+type spoilerExtension struct{}
+
+// markdownSpoilers adds ||spoiler|| text, rendered as <span class="spoiler">
+var markdownSpoilers = &spoilerExtension{}
+
+func (e *spoilerExtension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithInlineParsers(
+		util.Prioritized(&spoilerParser{}, 500),
+	))
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(
+		util.Prioritized(&spoilerHTMLRenderer{}, 500),
+	))
+}
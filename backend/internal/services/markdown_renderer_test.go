@@ -0,0 +1,56 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdownRenderer(t *testing.T) {
+	r := NewMarkdownRenderer(MarkdownLinkPolicy{
+		SiteHosts:    []string{"clpr.tv"},
+		BlockedHosts: []string{"spam.example"},
+		UnfurlHosts:  []string{"twitch.tv"},
+		MaxUnfurls:   1,
+	})
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"bold and italics", "**bold** and *italic*", "<p><strong>bold</strong> and <em>italic</em></p>\n"},
+		{"spoiler", "the ||butler did it||", `<p>the <span class="spoiler">butler did it</span></p>` + "\n"},
+		{"single pipes stay", "a | b", "<p>a | b</p>\n"},
+		{"code", "`x := 1`", "<p><code>x := 1</code></p>\n"},
+		{"fenced code", "```go\nx := 1\n```", "<pre><code class=\"language-go\">x := 1\n</code></pre>\n"},
+		{"headings stay text", "# Title", "<p># Title</p>\n"},
+		{"raw html dropped", "hi <script>alert(1)</script><b>there</b>", "<p>hi there</p>\n"},
+		{"images dropped", "![cat](https://example.com/cat.png)", "<p></p>\n"},
+		{"javascript links dropped", "[x](javascript:alert(1))", "<p>x</p>\n"},
+		{
+			"external links",
+			"[docs](https://example.com/docs)",
+			`<p><a href="https://example.com/docs" rel="nofollow noreferrer noopener" target="_blank">docs</a></p>` + "\n",
+		},
+		{
+			"site links made relative and unfurled",
+			"see https://www.clpr.tv/clips/abc?t=5",
+			`<p>see <a href="/clips/abc?t=5" class="unfurl">https://www.clpr.tv/clips/abc?t=5</a></p>` + "\n",
+		},
+		{
+			"only the first unfurl is marked",
+			"[one](https://clips.twitch.tv/a) [two](https://clips.twitch.tv/b)",
+			`<p><a href="https://clips.twitch.tv/a" class="unfurl" rel="nofollow noreferrer noopener" target="_blank">one</a> ` +
+				`<a href="https://clips.twitch.tv/b" rel="nofollow noreferrer noopener" target="_blank">two</a></p>` + "\n",
+		},
+		{"blocked hosts become text", "[win](https://free.spam.example/x)", "<p>win</p>\n"},
+		{"deleted placeholder", "[deleted]", "[deleted]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, r.Render(tt.input))
+		})
+	}
+}
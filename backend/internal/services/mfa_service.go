@@ -36,6 +36,10 @@ var (
 	ErrTooManyFailedAttempts = errors.New("too many failed attempts, account temporarily locked")
 	// ErrNoBackupCodesRemaining is returned when all backup codes have been used
 	ErrNoBackupCodesRemaining = errors.New("no backup codes remaining")
+	// ErrMFARecoveryUnavailable is returned when a recovery email cannot be sent
+	ErrMFARecoveryUnavailable = errors.New("MFA recovery requires a verified email address")
+	// ErrInvalidRecoveryToken is returned when a recovery token is invalid or expired
+	ErrInvalidRecoveryToken = errors.New("invalid or expired recovery token")
 )
 
 const (
@@ -53,6 +57,7 @@ const (
 	// maxFailedAttempts = 5
 	// lockoutDuration   = 1 * time.Hour
 	trustedDeviceTTL = 30 * 24 * time.Hour // 30 days
+	recoveryTokenTTL = 1 * time.Hour
 
 	// Rate limiting
 	rateLimitWindow = 15 * time.Minute
@@ -157,6 +162,13 @@ func (s *MFAService) StartEnrollment(ctx context.Context, userID uuid.UUID, emai
 		BackupCodesGeneratedAt: &now,
 	}
 
+	if existingMFA != nil {
+		// Re-enrolling keeps any staff requirement and its grace deadline
+		mfa.MFARequired = existingMFA.MFARequired
+		mfa.MFARequiredAt = existingMFA.MFARequiredAt
+		mfa.GracePeriodEnd = existingMFA.GracePeriodEnd
+	}
+
 	if existingMFA == nil {
 		err = s.mfaRepo.CreateMFA(ctx, mfa)
 	} else {
@@ -425,6 +437,84 @@ func (s *MFAService) DisableMFA(ctx context.Context, userID uuid.UUID, code stri
 	return nil
 }
 
+// RequestRecovery emails a one-time recovery link to a user who has lost both their
+// authenticator and backup codes. It needs no session, since such users cannot
+// pass the second factor.
+func (s *MFAService) RequestRecovery(ctx context.Context, username string) error {
+	user, err := s.userRepo.GetByUsername(ctx, strings.TrimSpace(username))
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	userID := user.ID
+
+	mfa, err := s.mfaRepo.GetMFAByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get MFA config: %w", err)
+	}
+	if mfa == nil || !mfa.Enabled {
+		return ErrMFANotEnabled
+	}
+
+	if user.Email == nil || *user.Email == "" {
+		_ = s.createAuditLog(ctx, userID, models.MFAActionRecoveryRequested, false, nil)
+		return ErrMFARecoveryUnavailable
+	}
+
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return fmt.Errorf("failed to generate recovery token: %w", err)
+	}
+	token := hex.EncodeToString(tokenBytes)
+
+	if err := s.mfaRepo.SetRecoveryToken(ctx, userID, hashRecoveryToken(token), time.Now().Add(recoveryTokenTTL)); err != nil {
+		return err
+	}
+
+	if err := s.emailSvc.SendMFARecoveryEmail(ctx, *user.Email, user.Username, token); err != nil {
+		return fmt.Errorf("failed to send recovery email: %w", err)
+	}
+
+	_ = s.createAuditLog(ctx, userID, models.MFAActionRecoveryRequested, true, nil)
+	return nil
+}
+
+// CompleteRecovery consumes a recovery token and removes MFA from the account it
+// was issued for so the user can enroll again. The emailed token alone identifies
+// the account. Staff accounts keep their original grace deadline.
+func (s *MFAService) CompleteRecovery(ctx context.Context, token string) (uuid.UUID, error) {
+	userID, ok, err := s.mfaRepo.ConsumeRecoveryToken(ctx, hashRecoveryToken(strings.TrimSpace(token)))
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !ok {
+		return uuid.Nil, ErrInvalidRecoveryToken
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := s.mfaRepo.DeleteMFA(ctx, userID); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to disable MFA: %w", err)
+	}
+	_ = s.mfaRepo.DeleteAllTrustedDevices(ctx, userID)
+
+	_ = s.createAuditLog(ctx, userID, models.MFAActionRecoveryUsed, true, nil)
+
+	if user.Email != nil {
+		_ = s.emailSvc.SendMFADisabledEmail(ctx, *user.Email, user.Username)
+	}
+
+	return userID, nil
+}
+
+// hashRecoveryToken returns the hex SHA-256 of a recovery token for storage
+func hashRecoveryToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
 // GetMFAStatus returns the current MFA status for a user
 func (s *MFAService) GetMFAStatus(ctx context.Context, userID uuid.UUID) (*models.MFAStatusResponse, error) {
 	mfa, err := s.mfaRepo.GetMFAByUserID(ctx, userID)
@@ -0,0 +1,247 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+// userAppealsLimit caps how many appeals are listed for a user
+const userAppealsLimit = 50
+
+var (
+	// ErrAppealForbidden is returned when a user appeals a decision on content that isn't theirs
+	ErrAppealForbidden = errors.New("cannot appeal this moderation action")
+	// ErrAppealAssigneeNotModerator is returned when an appeal is assigned to someone who can't moderate
+	ErrAppealAssigneeNotModerator = errors.New("appeals can only be assigned to moderators")
+)
+
+var (
+	appealsCreatedTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "moderation_appeals_created_total",
+			Help: "Total number of moderation appeals submitted",
+		},
+	)
+
+	appealsResolvedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "moderation_appeals_resolved_total",
+			Help: "Total number of moderation appeals resolved",
+		},
+		[]string{"decision"}, // "approved", "rejected"
+	)
+
+	appealAssignmentHours = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "moderation_appeal_assignment_hours",
+			Help:    "Hours from an appeal being submitted to a moderator taking it into review",
+			Buckets: []float64{1, 4, 8, 12, 24, 48, 72, 168},
+		},
+	)
+
+	appealResolutionHours = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "moderation_appeal_resolution_hours",
+			Help:    "Hours from an appeal being submitted to its decision",
+			Buckets: []float64{1, 4, 8, 12, 24, 48, 72, 168, 336},
+		},
+		[]string{"decision"},
+	)
+
+	appealSLABreachesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "moderation_appeal_sla_breaches_total",
+			Help: "Total number of appeals assigned or resolved after their SLA",
+		},
+		[]string{"stage"}, // "assignment", "resolution"
+	)
+)
+
+// ModerationAppealRepositoryInterface defines the repository methods used by ModerationAppealService
+type ModerationAppealRepositoryInterface interface {
+	GetActionContent(ctx context.Context, actionID uuid.UUID) (string, uuid.UUID, error)
+	OwnsContent(ctx context.Context, userID uuid.UUID, contentType string, contentID uuid.UUID) (bool, error)
+	Create(ctx context.Context, appeal *models.ModerationAppeal) error
+	ListQueue(ctx context.Context, filters models.AppealQueueFilters) ([]models.ModerationAppealWithDetails, error)
+	ListForUser(ctx context.Context, userID uuid.UUID, limit int) ([]models.ModerationAppealWithDetails, error)
+	Assign(ctx context.Context, id, assigneeID uuid.UUID) (*models.ModerationAppeal, bool, error)
+	Resolve(ctx context.Context, id uuid.UUID, status string, resolvedBy uuid.UUID, resolution *string) (*models.ModerationAppeal, error)
+	GetSLAMetrics(ctx context.Context, windowDays, assignmentSLAHours, resolutionSLAHours int) (*models.AppealSLAMetrics, error)
+}
+
+// AppealUserReader looks up the moderators appeals are assigned to
+type AppealUserReader interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+}
+
+// AppealDecisionNotifier tells appellants how their appeal was decided
+type AppealDecisionNotifier interface {
+	NotifyAppealDecision(ctx context.Context, userID, appealID uuid.UUID, approved bool, resolution *string) error
+}
+
+// ModerationAppealService runs the appeal workflow. Users appeal decisions on
+// their own content or account, moderators take pending appeals into review
+// and approve or reject them, and the appellant is notified of the decision.
+// Time to assignment and to resolution is measured against the SLAs.
+type ModerationAppealService struct {
+	repo               ModerationAppealRepositoryInterface
+	users              AppealUserReader
+	notifier           AppealDecisionNotifier
+	auditLog           UserBanAuditLogger
+	assignmentSLAHours int
+	resolutionSLAHours int
+}
+
+// NewModerationAppealService creates a new ModerationAppealService
+func NewModerationAppealService(
+	repo ModerationAppealRepositoryInterface,
+	users AppealUserReader,
+	notifier AppealDecisionNotifier,
+	auditLog UserBanAuditLogger,
+	assignmentSLAHours int,
+	resolutionSLAHours int,
+) *ModerationAppealService {
+	return &ModerationAppealService{
+		repo:               repo,
+		users:              users,
+		notifier:           notifier,
+		auditLog:           auditLog,
+		assignmentSLAHours: assignmentSLAHours,
+		resolutionSLAHours: resolutionSLAHours,
+	}
+}
+
+// CreateAppeal files an appeal against a moderation decision on the user's
+// own comment, clip or account
+func (s *ModerationAppealService) CreateAppeal(ctx context.Context, userID, actionID uuid.UUID, reason string) (*models.ModerationAppeal, error) {
+	contentType, contentID, err := s.repo.GetActionContent(ctx, actionID)
+	if err != nil {
+		return nil, err
+	}
+
+	owns, err := s.repo.OwnsContent(ctx, userID, contentType, contentID)
+	if err != nil {
+		return nil, err
+	}
+	if !owns {
+		return nil, ErrAppealForbidden
+	}
+
+	appeal := &models.ModerationAppeal{
+		UserID:             userID,
+		ModerationActionID: actionID,
+		Reason:             reason,
+	}
+	if err := s.repo.Create(ctx, appeal); err != nil {
+		return nil, err
+	}
+
+	appealsCreatedTotal.Inc()
+	return appeal, nil
+}
+
+// ListQueue returns the appeals for the admin queue
+func (s *ModerationAppealService) ListQueue(ctx context.Context, filters models.AppealQueueFilters) ([]models.ModerationAppealWithDetails, error) {
+	return s.repo.ListQueue(ctx, filters)
+}
+
+// ListUserAppeals returns the appeals a user filed
+func (s *ModerationAppealService) ListUserAppeals(ctx context.Context, userID uuid.UUID) ([]models.ModerationAppealWithDetails, error) {
+	return s.repo.ListForUser(ctx, userID, userAppealsLimit)
+}
+
+// AssignAppeal takes an open appeal into review with a moderator
+func (s *ModerationAppealService) AssignAppeal(ctx context.Context, appealID, assigneeID, actorID uuid.UUID) (*models.ModerationAppeal, error) {
+	if assigneeID != actorID {
+		assignee, err := s.users.GetByID(ctx, assigneeID)
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, ErrAppealAssigneeNotModerator
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !assignee.Can(models.PermissionModerateContent) {
+			return nil, ErrAppealAssigneeNotModerator
+		}
+	}
+
+	appeal, first, err := s.repo.Assign(ctx, appealID, assigneeID)
+	if err != nil {
+		return nil, err
+	}
+	if first && appeal.AssignedAt != nil {
+		hours := appeal.AssignedAt.Sub(appeal.CreatedAt).Hours()
+		appealAssignmentHours.Observe(hours)
+		if hours > float64(s.assignmentSLAHours) {
+			appealSLABreachesTotal.WithLabelValues("assignment").Inc()
+		}
+	}
+
+	s.logAudit(ctx, repository.AuditActionAssignAppeal, actorID, appeal.ID, AuditLogOptions{
+		Metadata: map[string]interface{}{"assigned_to": assigneeID.String()},
+	})
+	return appeal, nil
+}
+
+// ResolveAppeal approves or rejects an open appeal and notifies the appellant
+func (s *ModerationAppealService) ResolveAppeal(ctx context.Context, appealID, moderatorID uuid.UUID, decision string, resolution *string) (*models.ModerationAppeal, error) {
+	status := models.AppealStatusRejected
+	if decision == "approve" {
+		status = models.AppealStatusApproved
+	}
+
+	appeal, err := s.repo.Resolve(ctx, appealID, status, moderatorID, resolution)
+	if err != nil {
+		return nil, err
+	}
+
+	appealsResolvedTotal.WithLabelValues(status).Inc()
+	if appeal.ResolvedAt != nil {
+		hours := appeal.ResolvedAt.Sub(appeal.CreatedAt).Hours()
+		appealResolutionHours.WithLabelValues(status).Observe(hours)
+		if hours > float64(s.resolutionSLAHours) {
+			appealSLABreachesTotal.WithLabelValues("resolution").Inc()
+		}
+	}
+
+	s.logAudit(ctx, repository.AuditActionResolveAppeal, moderatorID, appeal.ID, AuditLogOptions{
+		Reason:   resolution,
+		Metadata: map[string]interface{}{"decision": status, "appellant_id": appeal.UserID.String()},
+	})
+
+	if s.notifier != nil {
+		if err := s.notifier.NotifyAppealDecision(ctx, appeal.UserID, appeal.ID, status == models.AppealStatusApproved, resolution); err != nil {
+			// The decision stands even if the notification fails
+			utils.GetLogger().Error("Failed to notify appeal decision", err, map[string]interface{}{
+				"appeal_id": appeal.ID.String(),
+				"user_id":   appeal.UserID.String(),
+			})
+		}
+	}
+	return appeal, nil
+}
+
+// GetSLAMetrics reports the appeal SLAs over the last windowDays
+func (s *ModerationAppealService) GetSLAMetrics(ctx context.Context, windowDays int) (*models.AppealSLAMetrics, error) {
+	return s.repo.GetSLAMetrics(ctx, windowDays, s.assignmentSLAHours, s.resolutionSLAHours)
+}
+
+// logAudit records an appeal workflow event without failing the change itself
+func (s *ModerationAppealService) logAudit(ctx context.Context, action string, actor, appealID uuid.UUID, opts AuditLogOptions) {
+	if s.auditLog == nil {
+		return
+	}
+	if err := s.auditLog.LogAction(ctx, action, actor, appealID, "appeal", opts); err != nil {
+		utils.GetLogger().Error("Failed to record appeal audit log", err, map[string]interface{}{
+			"action":    action,
+			"appeal_id": appealID.String(),
+		})
+	}
+}
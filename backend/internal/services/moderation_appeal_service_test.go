@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+// MockModerationAppealRepository is a mock implementation of ModerationAppealRepositoryInterface
+type MockModerationAppealRepository struct {
+	mock.Mock
+}
+
+func (m *MockModerationAppealRepository) GetActionContent(ctx context.Context, actionID uuid.UUID) (string, uuid.UUID, error) {
+	args := m.Called(ctx, actionID)
+	return args.String(0), args.Get(1).(uuid.UUID), args.Error(2)
+}
+
+func (m *MockModerationAppealRepository) OwnsContent(ctx context.Context, userID uuid.UUID, contentType string, contentID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, userID, contentType, contentID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockModerationAppealRepository) Create(ctx context.Context, appeal *models.ModerationAppeal) error {
+	args := m.Called(ctx, appeal)
+	return args.Error(0)
+}
+
+func (m *MockModerationAppealRepository) ListQueue(ctx context.Context, filters models.AppealQueueFilters) ([]models.ModerationAppealWithDetails, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ModerationAppealWithDetails), args.Error(1)
+}
+
+func (m *MockModerationAppealRepository) ListForUser(ctx context.Context, userID uuid.UUID, limit int) ([]models.ModerationAppealWithDetails, error) {
+	args := m.Called(ctx, userID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ModerationAppealWithDetails), args.Error(1)
+}
+
+func (m *MockModerationAppealRepository) Assign(ctx context.Context, id, assigneeID uuid.UUID) (*models.ModerationAppeal, bool, error) {
+	args := m.Called(ctx, id, assigneeID)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1), args.Error(2)
+	}
+	return args.Get(0).(*models.ModerationAppeal), args.Bool(1), args.Error(2)
+}
+
+func (m *MockModerationAppealRepository) Resolve(ctx context.Context, id uuid.UUID, status string, resolvedBy uuid.UUID, resolution *string) (*models.ModerationAppeal, error) {
+	args := m.Called(ctx, id, status, resolvedBy, resolution)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ModerationAppeal), args.Error(1)
+}
+
+func (m *MockModerationAppealRepository) GetSLAMetrics(ctx context.Context, windowDays, assignmentSLAHours, resolutionSLAHours int) (*models.AppealSLAMetrics, error) {
+	args := m.Called(ctx, windowDays, assignmentSLAHours, resolutionSLAHours)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AppealSLAMetrics), args.Error(1)
+}
+
+// MockAppealDecisionNotifier is a mock implementation of AppealDecisionNotifier
+type MockAppealDecisionNotifier struct {
+	mock.Mock
+}
+
+func (m *MockAppealDecisionNotifier) NotifyAppealDecision(ctx context.Context, userID, appealID uuid.UUID, approved bool, resolution *string) error {
+	args := m.Called(ctx, userID, appealID, approved, resolution)
+	return args.Error(0)
+}
+
+func TestModerationAppealService_Workflow(t *testing.T) {
+	ctx := context.Background()
+	appellantID, strangerID := uuid.New(), uuid.New()
+	modID, otherModID, memberID, unknownID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	actionID, contentID := uuid.New(), uuid.New()
+	repo := new(MockModerationAppealRepository)
+	repo.On("GetActionContent", ctx, actionID).Return("comment", contentID, nil)
+	repo.On("OwnsContent", ctx, appellantID, "comment", contentID).Return(true, nil)
+	repo.On("OwnsContent", ctx, strangerID, "comment", contentID).Return(false, nil)
+	users := new(MockUserRepository)
+	users.On("GetByID", ctx, otherModID).Return(&models.User{ID: otherModID, Role: models.RoleModerator, AccountType: models.AccountTypeModerator}, nil)
+	users.On("GetByID", ctx, memberID).Return(&models.User{ID: memberID, Role: models.RoleUser, AccountType: models.AccountTypeMember}, nil)
+	users.On("GetByID", ctx, unknownID).Return(nil, repository.ErrUserNotFound)
+	notifier := new(MockAppealDecisionNotifier)
+	audit := new(MockAuditLogService)
+	audit.On("LogAction", ctx, mock.Anything, mock.Anything, mock.Anything, "appeal", mock.Anything).Return(nil)
+	svc := NewModerationAppealService(repo, users, notifier, audit, 24, 72)
+
+	_, err := svc.CreateAppeal(ctx, strangerID, actionID, "This was not my comment to begin with")
+	assert.ErrorIs(t, err, ErrAppealForbidden)
+
+	repo.On("Create", ctx, mock.MatchedBy(func(appeal *models.ModerationAppeal) bool {
+		return appeal.UserID == appellantID && appeal.ModerationActionID == actionID
+	})).Run(func(args mock.Arguments) {
+		appeal := args.Get(1).(*models.ModerationAppeal)
+		appeal.ID = uuid.New()
+		appeal.Status = models.AppealStatusPending
+		appeal.CreatedAt = time.Now().Add(-30 * time.Hour)
+	}).Return(nil).Once()
+	appeal, err := svc.CreateAppeal(ctx, appellantID, actionID, "The comment was quoting the rules back")
+	require.NoError(t, err)
+	assert.Equal(t, models.AppealStatusPending, appeal.Status)
+
+	repo.On("Create", ctx, mock.AnythingOfType("*models.ModerationAppeal")).Return(repository.ErrModerationAppealOpen).Once()
+	_, err = svc.CreateAppeal(ctx, appellantID, actionID, "Filing the same appeal a second time")
+	assert.ErrorIs(t, err, repository.ErrModerationAppealOpen)
+
+	_, err = svc.AssignAppeal(ctx, appeal.ID, memberID, modID)
+	assert.ErrorIs(t, err, ErrAppealAssigneeNotModerator)
+	_, err = svc.AssignAppeal(ctx, appeal.ID, unknownID, modID)
+	assert.ErrorIs(t, err, ErrAppealAssigneeNotModerator)
+
+	assignedAt := time.Now()
+	inReview := *appeal
+	inReview.Status = models.AppealStatusInReview
+	inReview.AssignedTo = &modID
+	inReview.AssignedAt = &assignedAt
+	repo.On("Assign", ctx, appeal.ID, modID).Return(&inReview, true, nil).Once()
+	assigned, err := svc.AssignAppeal(ctx, appeal.ID, modID, modID)
+	require.NoError(t, err)
+	assert.Equal(t, models.AppealStatusInReview, assigned.Status)
+	assert.Equal(t, modID, *assigned.AssignedTo)
+
+	reassigned := inReview
+	reassigned.AssignedTo = &otherModID
+	repo.On("Assign", ctx, appeal.ID, otherModID).Return(&reassigned, false, nil).Once()
+	assigned, err = svc.AssignAppeal(ctx, appeal.ID, otherModID, modID)
+	require.NoError(t, err)
+	assert.Equal(t, otherModID, *assigned.AssignedTo)
+
+	resolvedAt := time.Now()
+	approved := reassigned
+	approved.Status = models.AppealStatusApproved
+	approved.ResolvedBy = &otherModID
+	approved.ResolvedAt = &resolvedAt
+	repo.On("Resolve", ctx, appeal.ID, models.AppealStatusApproved, otherModID, (*string)(nil)).Return(&approved, nil).Once()
+	notifier.On("NotifyAppealDecision", ctx, appellantID, appeal.ID, true, (*string)(nil)).Return(nil).Once()
+	resolved, err := svc.ResolveAppeal(ctx, appeal.ID, otherModID, "approve", nil)
+	require.NoError(t, err)
+	assert.Equal(t, models.AppealStatusApproved, resolved.Status)
+	notifier.AssertExpectations(t)
+	audit.AssertNumberOfCalls(t, "LogAction", 3)
+	audit.AssertCalled(t, "LogAction", ctx, repository.AuditActionAssignAppeal, modID, appeal.ID, "appeal", mock.Anything)
+	audit.AssertCalled(t, "LogAction", ctx, repository.AuditActionResolveAppeal, otherModID, appeal.ID, "appeal", mock.Anything)
+
+	repo.On("Resolve", ctx, appeal.ID, models.AppealStatusRejected, modID, (*string)(nil)).Return(nil, repository.ErrModerationAppealNotFound).Once()
+	_, err = svc.ResolveAppeal(ctx, appeal.ID, modID, "reject", nil)
+	assert.ErrorIs(t, err, repository.ErrModerationAppealNotFound)
+	repo.On("Assign", ctx, appeal.ID, modID).Return(nil, false, repository.ErrModerationAppealNotFound).Once()
+	_, err = svc.AssignAppeal(ctx, appeal.ID, modID, modID)
+	assert.ErrorIs(t, err, repository.ErrModerationAppealNotFound)
+
+	missingActionID := uuid.New()
+	repo.On("GetActionContent", ctx, missingActionID).Return("", uuid.Nil, repository.ErrModerationActionNotFound).Once()
+	_, err = svc.CreateAppeal(ctx, appellantID, missingActionID, "Appealing a decision that doesn't exist")
+	assert.ErrorIs(t, err, repository.ErrModerationActionNotFound)
+
+	repo.AssertExpectations(t)
+	notifier.AssertNumberOfCalls(t, "NotifyAppealDecision", 1)
+}
+
+func TestModerationAppealService_GetSLAMetrics(t *testing.T) {
+	repo := new(MockModerationAppealRepository)
+	repo.On("GetSLAMetrics", mock.Anything, 30, 12, 48).
+		Return(&models.AppealSLAMetrics{WindowDays: 30, AssignmentSLAHours: 12, ResolutionSLAHours: 48}, nil).Once()
+	svc := NewModerationAppealService(repo, new(MockUserRepository), nil, nil, 12, 48)
+
+	metrics, err := svc.GetSLAMetrics(context.Background(), 30)
+	require.NoError(t, err)
+	assert.Equal(t, 30, metrics.WindowDays)
+	assert.Equal(t, 12, metrics.AssignmentSLAHours)
+	assert.Equal(t, 48, metrics.ResolutionSLAHours)
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+var (
+	// ErrModerationItemNotClaimable is returned when an item has already been reviewed
+	ErrModerationItemNotClaimable = errors.New("item is not awaiting review")
+	// ErrModerationEventsUnavailable is returned when moderation events can't be claimed because the event queue isn't configured
+	ErrModerationEventsUnavailable = errors.New("moderation events are not available")
+)
+
+var (
+	moderationClaimsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "moderation_claims_total",
+			Help: "Total number of items claimed for review",
+		},
+		[]string{"item_type"},
+	)
+
+	moderationClaimConflictsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "moderation_claim_conflicts_total",
+			Help: "Total number of claims and actions refused because another moderator holds the item",
+		},
+		[]string{"item_type"},
+	)
+
+	moderationClaimsExpiredTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "moderation_claims_expired_total",
+			Help: "Total number of stale claims released by the claim expiry job",
+		},
+	)
+
+	moderationClaimResolutionMinutes = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "moderation_claim_resolution_minutes",
+			Help:    "Minutes from an item being claimed to the claimant resolving it",
+			Buckets: []float64{1, 2, 5, 10, 15, 30, 60, 120, 240},
+		},
+		[]string{"item_type"},
+	)
+)
+
+// ModerationClaimRepositoryInterface defines the repository methods used by ModerationClaimService
+type ModerationClaimRepositoryInterface interface {
+	Claim(ctx context.Context, itemType string, itemID, moderatorID uuid.UUID, ttl time.Duration) (*models.ModerationClaim, bool, error)
+	GetActive(ctx context.Context, itemType string, itemID uuid.UUID) (*models.ModerationClaim, error)
+	Release(ctx context.Context, itemType string, itemID, moderatorID uuid.UUID, reason string) (*models.ModerationClaim, error)
+	ListActive(ctx context.Context, moderatorID *uuid.UUID) ([]models.ModerationClaim, error)
+	ReleaseExpired(ctx context.Context) (int64, error)
+	GetWorkload(ctx context.Context, windowDays int) (*models.ModerationWorkload, error)
+}
+
+// ClaimSubmissionReader looks up the submissions moderators claim
+type ClaimSubmissionReader interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.ClipSubmission, error)
+}
+
+// ClaimEventReader looks up the moderation events moderators claim
+type ClaimEventReader interface {
+	GetEvent(ctx context.Context, eventID uuid.UUID) (*ModerationEvent, error)
+}
+
+// ModerationClaimGuard keeps moderators from acting on items another
+// moderator has claimed, and resolves the claim once the item is reviewed
+type ModerationClaimGuard interface {
+	CheckClaim(ctx context.Context, itemType string, itemID, moderatorID uuid.UUID) error
+	CompleteClaim(ctx context.Context, itemType string, itemID, moderatorID uuid.UUID)
+}
+
+// ModerationClaimService lets moderators claim moderation events and pending
+// submissions so they aren't reviewed twice. Claims expire after the claim
+// TTL unless the claimant resolves the item or releases it first, and the
+// time from claim to resolution is tracked per moderator.
+type ModerationClaimService struct {
+	repo        ModerationClaimRepositoryInterface
+	submissions ClaimSubmissionReader
+	events      ClaimEventReader
+	ttl         time.Duration
+}
+
+// NewModerationClaimService creates a new ModerationClaimService
+func NewModerationClaimService(repo ModerationClaimRepositoryInterface, submissions ClaimSubmissionReader, ttlMinutes int) *ModerationClaimService {
+	return &ModerationClaimService{
+		repo:        repo,
+		submissions: submissions,
+		ttl:         time.Duration(ttlMinutes) * time.Minute,
+	}
+}
+
+// SetEventReader enables claiming moderation events
+func (s *ModerationClaimService) SetEventReader(events ClaimEventReader) {
+	s.events = events
+}
+
+// TTL returns how long a claim lasts before it's released as stale
+func (s *ModerationClaimService) TTL() time.Duration {
+	return s.ttl
+}
+
+// ClaimEvent claims a pending moderation event for a moderator
+func (s *ModerationClaimService) ClaimEvent(ctx context.Context, eventID, moderatorID uuid.UUID) (*models.ModerationClaim, error) {
+	if s.events == nil {
+		return nil, ErrModerationEventsUnavailable
+	}
+	event, err := s.events.GetEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if event.Status != "pending" {
+		return nil, ErrModerationItemNotClaimable
+	}
+	return s.claim(ctx, models.ModerationClaimItemEvent, eventID, moderatorID)
+}
+
+// ClaimSubmission claims a pending submission for a moderator
+func (s *ModerationClaimService) ClaimSubmission(ctx context.Context, submissionID, moderatorID uuid.UUID) (*models.ModerationClaim, error) {
+	submission, err := s.submissions.GetByID(ctx, submissionID)
+	if err != nil {
+		return nil, err
+	}
+	if submission.Status != "pending" {
+		return nil, ErrModerationItemNotClaimable
+	}
+	return s.claim(ctx, models.ModerationClaimItemSubmission, submissionID, moderatorID)
+}
+
+func (s *ModerationClaimService) claim(ctx context.Context, itemType string, itemID, moderatorID uuid.UUID) (*models.ModerationClaim, error) {
+	claim, created, err := s.repo.Claim(ctx, itemType, itemID, moderatorID, s.ttl)
+	if errors.Is(err, repository.ErrModerationItemClaimed) {
+		moderationClaimConflictsTotal.WithLabelValues(itemType).Inc()
+		return claim, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	if created {
+		moderationClaimsTotal.WithLabelValues(itemType).Inc()
+	}
+	return claim, nil
+}
+
+// Release gives back a moderator's claim on an item without reviewing it
+func (s *ModerationClaimService) Release(ctx context.Context, itemType string, itemID, moderatorID uuid.UUID) error {
+	_, err := s.repo.Release(ctx, itemType, itemID, moderatorID, models.ModerationClaimReleaseReleased)
+	return err
+}
+
+// CheckClaim returns ErrModerationItemClaimed when another moderator holds
+// an unexpired claim on the item. Unclaimed items can be acted on by anyone.
+func (s *ModerationClaimService) CheckClaim(ctx context.Context, itemType string, itemID, moderatorID uuid.UUID) error {
+	claim, err := s.repo.GetActive(ctx, itemType, itemID)
+	if errors.Is(err, repository.ErrModerationClaimNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if claim.ClaimedBy != moderatorID {
+		moderationClaimConflictsTotal.WithLabelValues(itemType).Inc()
+		return repository.ErrModerationItemClaimed
+	}
+	return nil
+}
+
+// CompleteClaim resolves the moderator's claim on an item they reviewed and
+// records the time it took. Failures are logged, since the review has
+// already been applied.
+func (s *ModerationClaimService) CompleteClaim(ctx context.Context, itemType string, itemID, moderatorID uuid.UUID) {
+	claim, err := s.repo.Release(ctx, itemType, itemID, moderatorID, models.ModerationClaimReleaseResolved)
+	if errors.Is(err, repository.ErrModerationClaimNotFound) {
+		return
+	}
+	if err != nil {
+		utils.GetLogger().Error("Failed to resolve moderation claim", err, map[string]interface{}{
+			"item_type": itemType,
+			"item_id":   itemID.String(),
+		})
+		return
+	}
+	if claim.ReleasedAt != nil {
+		moderationClaimResolutionMinutes.WithLabelValues(itemType).Observe(claim.ReleasedAt.Sub(claim.ClaimedAt).Minutes())
+	}
+}
+
+// ListClaims returns the active claims, optionally only one moderator's
+func (s *ModerationClaimService) ListClaims(ctx context.Context, moderatorID *uuid.UUID) ([]models.ModerationClaim, error) {
+	return s.repo.ListActive(ctx, moderatorID)
+}
+
+// GetWorkload reports each moderator's open claims and resolutions over the
+// last windowDays
+func (s *ModerationClaimService) GetWorkload(ctx context.Context, windowDays int) (*models.ModerationWorkload, error) {
+	workload, err := s.repo.GetWorkload(ctx, windowDays)
+	if err != nil {
+		return nil, err
+	}
+	workload.ClaimTTLMinutes = int(s.ttl.Minutes())
+	return workload, nil
+}
+
+// ReleaseExpiredClaims releases stale claims so their items go back to the queue
+func (s *ModerationClaimService) ReleaseExpiredClaims(ctx context.Context) (int, error) {
+	released, err := s.repo.ReleaseExpired(ctx)
+	if err != nil {
+		return 0, err
+	}
+	moderationClaimsExpiredTotal.Add(float64(released))
+	return int(released), nil
+}
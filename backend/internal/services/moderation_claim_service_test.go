@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+// MockModerationClaimRepository is a mock implementation of ModerationClaimRepositoryInterface
+type MockModerationClaimRepository struct {
+	mock.Mock
+}
+
+func (m *MockModerationClaimRepository) Claim(ctx context.Context, itemType string, itemID, moderatorID uuid.UUID, ttl time.Duration) (*models.ModerationClaim, bool, error) {
+	args := m.Called(ctx, itemType, itemID, moderatorID, ttl)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1), args.Error(2)
+	}
+	return args.Get(0).(*models.ModerationClaim), args.Bool(1), args.Error(2)
+}
+
+func (m *MockModerationClaimRepository) GetActive(ctx context.Context, itemType string, itemID uuid.UUID) (*models.ModerationClaim, error) {
+	args := m.Called(ctx, itemType, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ModerationClaim), args.Error(1)
+}
+
+func (m *MockModerationClaimRepository) Release(ctx context.Context, itemType string, itemID, moderatorID uuid.UUID, reason string) (*models.ModerationClaim, error) {
+	args := m.Called(ctx, itemType, itemID, moderatorID, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ModerationClaim), args.Error(1)
+}
+
+func (m *MockModerationClaimRepository) ListActive(ctx context.Context, moderatorID *uuid.UUID) ([]models.ModerationClaim, error) {
+	args := m.Called(ctx, moderatorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ModerationClaim), args.Error(1)
+}
+
+func (m *MockModerationClaimRepository) ReleaseExpired(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockModerationClaimRepository) GetWorkload(ctx context.Context, windowDays int) (*models.ModerationWorkload, error) {
+	args := m.Called(ctx, windowDays)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ModerationWorkload), args.Error(1)
+}
+
+// MockClaimSubmissionReader is a mock implementation of ClaimSubmissionReader
+type MockClaimSubmissionReader struct {
+	mock.Mock
+}
+
+func (m *MockClaimSubmissionReader) GetByID(ctx context.Context, id uuid.UUID) (*models.ClipSubmission, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ClipSubmission), args.Error(1)
+}
+
+// newModerationClaim returns a claim a moderator just took on a submission
+func newModerationClaim(itemID, moderatorID uuid.UUID, ttl time.Duration) *models.ModerationClaim {
+	now := time.Now()
+	return &models.ModerationClaim{
+		ID:        uuid.New(),
+		ItemType:  models.ModerationClaimItemSubmission,
+		ItemID:    itemID,
+		ClaimedBy: moderatorID,
+		ClaimedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+}
+
+func TestModerationClaimService_ClaimSubmission(t *testing.T) {
+	ctx := context.Background()
+	pendingID, reviewedID, missingID := uuid.New(), uuid.New(), uuid.New()
+	modID, otherModID := uuid.New(), uuid.New()
+	item := models.ModerationClaimItemSubmission
+	repo := new(MockModerationClaimRepository)
+	submissions := new(MockClaimSubmissionReader)
+	submissions.On("GetByID", ctx, pendingID).Return(&models.ClipSubmission{ID: pendingID, Status: "pending"}, nil)
+	submissions.On("GetByID", ctx, reviewedID).Return(&models.ClipSubmission{ID: reviewedID, Status: "approved"}, nil)
+	submissions.On("GetByID", ctx, missingID).Return(nil, repository.ErrSubmissionNotFound)
+	svc := NewModerationClaimService(repo, submissions, 30)
+
+	_, err := svc.ClaimSubmission(ctx, reviewedID, modID)
+	assert.ErrorIs(t, err, ErrModerationItemNotClaimable)
+	_, err = svc.ClaimSubmission(ctx, missingID, modID)
+	assert.ErrorIs(t, err, repository.ErrSubmissionNotFound)
+	_, err = svc.ClaimEvent(ctx, uuid.New(), modID)
+	assert.ErrorIs(t, err, ErrModerationEventsUnavailable)
+
+	held := newModerationClaim(pendingID, modID, 30*time.Minute)
+	repo.On("Claim", ctx, item, pendingID, modID, 30*time.Minute).Return(held, true, nil).Once()
+	claim, err := svc.ClaimSubmission(ctx, pendingID, modID)
+	require.NoError(t, err)
+	assert.Equal(t, modID, claim.ClaimedBy)
+
+	repo.On("Claim", ctx, item, pendingID, otherModID, 30*time.Minute).Return(held, false, repository.ErrModerationItemClaimed).Once()
+	holder, err := svc.ClaimSubmission(ctx, pendingID, otherModID)
+	assert.ErrorIs(t, err, repository.ErrModerationItemClaimed)
+	require.NotNil(t, holder)
+	assert.Equal(t, modID, holder.ClaimedBy)
+
+	// Only the claimant can act on the item
+	repo.On("GetActive", ctx, item, pendingID).Return(held, nil).Twice()
+	repo.On("GetActive", ctx, item, mock.Anything).Return(nil, repository.ErrModerationClaimNotFound)
+	assert.ErrorIs(t, svc.CheckClaim(ctx, item, pendingID, otherModID), repository.ErrModerationItemClaimed)
+	assert.NoError(t, svc.CheckClaim(ctx, item, pendingID, modID))
+	assert.NoError(t, svc.CheckClaim(ctx, item, uuid.New(), otherModID))
+
+	resolved := *held
+	releasedAt := time.Now()
+	resolved.ReleasedAt = &releasedAt
+	repo.On("Release", ctx, item, pendingID, modID, models.ModerationClaimReleaseResolved).Return(&resolved, nil).Once()
+	svc.CompleteClaim(ctx, item, pendingID, modID)
+	assert.NoError(t, svc.CheckClaim(ctx, item, pendingID, otherModID))
+
+	// Completing an unclaimed review is a no-op
+	repo.On("Release", ctx, item, pendingID, otherModID, models.ModerationClaimReleaseResolved).Return(nil, repository.ErrModerationClaimNotFound).Once()
+	svc.CompleteClaim(ctx, item, pendingID, otherModID)
+
+	repo.On("Release", ctx, item, pendingID, modID, models.ModerationClaimReleaseReleased).Return(nil, repository.ErrModerationClaimNotFound).Once()
+	assert.ErrorIs(t, svc.Release(ctx, item, pendingID, modID), repository.ErrModerationClaimNotFound)
+
+	repo.AssertExpectations(t)
+}
+
+func TestModerationClaimService_StaleClaimsExpire(t *testing.T) {
+	ctx := context.Background()
+	submissionID := uuid.New()
+	modID, otherModID := uuid.New(), uuid.New()
+	item := models.ModerationClaimItemSubmission
+	repo := new(MockModerationClaimRepository)
+	submissions := new(MockClaimSubmissionReader)
+	submissions.On("GetByID", ctx, submissionID).Return(&models.ClipSubmission{ID: submissionID, Status: "pending"}, nil)
+	svc := NewModerationClaimService(repo, submissions, 15)
+
+	repo.On("Claim", ctx, item, submissionID, modID, 15*time.Minute).Return(newModerationClaim(submissionID, modID, 15*time.Minute), true, nil).Once()
+	_, err := svc.ClaimSubmission(ctx, submissionID, modID)
+	require.NoError(t, err)
+
+	// Expired claims are no longer active
+	repo.On("GetActive", ctx, item, submissionID).Return(nil, repository.ErrModerationClaimNotFound).Once()
+	assert.NoError(t, svc.CheckClaim(ctx, item, submissionID, otherModID))
+
+	repo.On("ReleaseExpired", ctx).Return(int64(1), nil).Once()
+	released, err := svc.ReleaseExpiredClaims(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, released)
+
+	repo.On("Claim", ctx, item, submissionID, otherModID, 15*time.Minute).Return(newModerationClaim(submissionID, otherModID, 15*time.Minute), true, nil).Once()
+	reclaimed, err := svc.ClaimSubmission(ctx, submissionID, otherModID)
+	require.NoError(t, err)
+	assert.Equal(t, otherModID, reclaimed.ClaimedBy)
+
+	repo.On("GetWorkload", ctx, 7).Return(&models.ModerationWorkload{WindowDays: 7}, nil).Once()
+	workload, err := svc.GetWorkload(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, 15, workload.ClaimTTLMinutes)
+
+	repo.AssertExpectations(t)
+}
@@ -3,11 +3,13 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
 	"github.com/subculture-collective/clipper/internal/models"
 	redispkg "github.com/subculture-collective/clipper/pkg/redis"
 )
@@ -31,6 +33,9 @@ const (
 	ModerationEventIPShareSuspicious     ModerationEventType = "ip_share_suspicious"
 	ModerationEventUserCooldownActivated ModerationEventType = "user_cooldown_activated"
 
+	// Comment events
+	ModerationEventCommentToxic ModerationEventType = "comment_toxic"
+
 	// Queue size limits to prevent unbounded growth
 	maxModerationQueueSize = 10000 // Maximum events in main moderation queue
 	maxTypeEventListSize   = 1000  // Maximum events per type-based list
@@ -51,10 +56,14 @@ type ModerationEvent struct {
 	Status       string                 `json:"status"` // "pending", "reviewed", "actioned"
 }
 
+// ErrModerationEventNotFound is returned when a moderation event doesn't exist or has expired
+var ErrModerationEventNotFound = errors.New("moderation event not found")
+
 // ModerationEventService handles moderation events
 type ModerationEventService struct {
 	redisClient         *redispkg.Client
 	notificationService *NotificationService
+	claims              ModerationClaimGuard
 }
 
 // NewModerationEventService creates a new moderation event service
@@ -65,6 +74,11 @@ func NewModerationEventService(redisClient *redispkg.Client, notificationService
 	}
 }
 
+// SetClaimGuard keeps moderators from reviewing events another moderator claimed
+func (s *ModerationEventService) SetClaimGuard(claims ModerationClaimGuard) {
+	s.claims = claims
+}
+
 // EmitEvent emits a moderation event
 func (s *ModerationEventService) EmitEvent(ctx context.Context, event *ModerationEvent) error {
 	// Set ID and timestamp if not set
@@ -158,6 +172,24 @@ func (s *ModerationEventService) EmitAbuseEvent(ctx context.Context, eventType M
 	return s.EmitEvent(ctx, event)
 }
 
+// EmitCommentEvent emits a comment-related moderation event
+func (s *ModerationEventService) EmitCommentEvent(ctx context.Context, eventType ModerationEventType, comment *models.Comment, metadata map[string]interface{}) error {
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	metadata["comment_id"] = comment.ID.String()
+	metadata["clip_id"] = comment.ClipID.String()
+
+	event := &ModerationEvent{
+		Type:     eventType,
+		Severity: "warning",
+		UserID:   comment.UserID,
+		Metadata: metadata,
+	}
+
+	return s.EmitEvent(ctx, event)
+}
+
 // GetPendingEvents retrieves pending moderation events
 func (s *ModerationEventService) GetPendingEvents(ctx context.Context, limit int) ([]*ModerationEvent, error) {
 	queueKey := "moderation:queue"
@@ -206,19 +238,39 @@ func (s *ModerationEventService) GetEventsByType(ctx context.Context, eventType
 	return events, nil
 }
 
-// MarkEventReviewed marks an event as reviewed
-func (s *ModerationEventService) MarkEventReviewed(ctx context.Context, eventID uuid.UUID, reviewerID uuid.UUID) error {
+// GetEvent retrieves a moderation event by ID
+func (s *ModerationEventService) GetEvent(ctx context.Context, eventID uuid.UUID) (*ModerationEvent, error) {
 	eventKey := fmt.Sprintf("moderation:event:%s", eventID.String())
 
-	// Get event
 	eventJSON, err := s.redisClient.Get(ctx, eventKey)
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrModerationEventNotFound
+	}
 	if err != nil {
-		return fmt.Errorf("failed to get event: %w", err)
+		return nil, fmt.Errorf("failed to get event: %w", err)
 	}
 
 	var event ModerationEvent
 	if err := json.Unmarshal([]byte(eventJSON), &event); err != nil {
-		return fmt.Errorf("failed to unmarshal event: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+	return &event, nil
+}
+
+// MarkEventReviewed marks an event as reviewed. Events claimed by another
+// moderator are refused with repository.ErrModerationItemClaimed.
+func (s *ModerationEventService) MarkEventReviewed(ctx context.Context, eventID uuid.UUID, reviewerID uuid.UUID) error {
+	eventKey := fmt.Sprintf("moderation:event:%s", eventID.String())
+
+	if s.claims != nil {
+		if err := s.claims.CheckClaim(ctx, models.ModerationClaimItemEvent, eventID, reviewerID); err != nil {
+			return err
+		}
+	}
+
+	event, err := s.GetEvent(ctx, eventID)
+	if err != nil {
+		return err
 	}
 
 	// Update event
@@ -233,7 +285,14 @@ func (s *ModerationEventService) MarkEventReviewed(ctx context.Context, eventID
 		return fmt.Errorf("failed to serialize updated event: %w", err)
 	}
 
-	return s.redisClient.Set(ctx, eventKey, string(updatedJSON), 30*24*time.Hour)
+	if err := s.redisClient.Set(ctx, eventKey, string(updatedJSON), 30*24*time.Hour); err != nil {
+		return err
+	}
+
+	if s.claims != nil {
+		s.claims.CompleteClaim(ctx, models.ModerationClaimItemEvent, eventID, reviewerID)
+	}
+	return nil
 }
 
 // GetEventStats returns statistics about moderation events
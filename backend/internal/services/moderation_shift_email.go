@@ -0,0 +1,183 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// moderationShiftEmailData is the view rendered by the handoff report templates
+type moderationShiftEmailData struct {
+	Title         string
+	Period        string
+	Report        *models.ModerationShiftReport
+	OldestPending string
+	OldestAppeal  string
+	ModerationURL string
+}
+
+var moderationShiftHTMLTemplate = htmltemplate.Must(htmltemplate.New("moderation_shift_html").Parse(`<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{.Title}}</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333; max-width: 600px; margin: 0 auto; padding: 20px;">
+    <div style="background: linear-gradient(135deg, #667eea 0%, #764ba2 100%); padding: 30px; text-align: center; border-radius: 10px 10px 0 0;">
+        <h1 style="color: white; margin: 0; font-size: 24px;">🛡️ {{.Title}}</h1>
+        <p style="color: white; margin: 10px 0 0 0; opacity: 0.9;">{{.Period}}</p>
+    </div>
+
+    <div style="background: #f9f9f9; padding: 30px; border-radius: 0 0 10px 10px;">
+        <h2 style="font-size: 18px; color: #667eea; margin: 0 0 10px 0;">Handled</h2>
+        <p style="margin: 0;">
+            <strong>{{.Report.Totals.ItemsHandled}}</strong> items: {{.Report.Totals.Approved}} approved, {{.Report.Totals.Rejected}} rejected, {{.Report.Totals.Escalated}} escalated, {{.Report.Totals.Banned}} bans.
+            <strong>{{.Report.Totals.AppealsResolved}}</strong> appeals resolved.
+        </p>
+{{if .Report.Moderators}}
+        <table style="width: 100%; border-collapse: collapse; margin: 15px 0; background: white;">
+            <tr style="text-align: left; color: #666;"><th style="padding: 6px;">Moderator</th><th style="padding: 6px;">Items</th><th style="padding: 6px;">Appeals</th></tr>
+{{range .Report.Moderators}}
+            <tr style="border-top: 1px solid #eee;"><td style="padding: 6px;">{{if .Username}}{{.Username}}{{else}}{{.ModeratorID}}{{end}}</td><td style="padding: 6px;">{{.ItemsHandled}}</td><td style="padding: 6px;">{{.AppealsResolved}}</td></tr>
+{{end}}
+        </table>
+{{end}}
+        <h2 style="font-size: 18px; color: #667eea; margin: 25px 0 10px 0;">Queue</h2>
+        <p style="margin: 0;">
+            <strong>{{.Report.Queue.Pending}}</strong> pending ({{.Report.Queue.HighPriority}} high priority), {{.Report.Queue.Escalated}} escalated.{{if .OldestPending}}
+            Oldest pending item: {{.OldestPending}}.{{end}}
+        </p>
+{{if .Report.Escalations}}
+        <h2 style="font-size: 18px; color: #667eea; margin: 25px 0 10px 0;">Escalations</h2>
+{{range .Report.Escalations}}
+        <div style="background: white; padding: 15px 20px; border-left: 4px solid #667eea; margin: 10px 0; border-radius: 5px;">
+            <p style="margin: 0; font-weight: bold;">{{.ContentType}} · {{.Reason}} · priority {{.Priority}} <span style="color: #999; font-weight: normal;">({{.Status}})</span></p>
+            {{if .Note}}<p style="margin: 5px 0 0 0; color: #666;">{{.Note}}</p>{{end}}
+        </div>
+{{end}}{{end}}
+        <h2 style="font-size: 18px; color: #667eea; margin: 25px 0 10px 0;">Appeals</h2>
+        <p style="margin: 0;">
+            <strong>{{.Report.Appeals.Open}}</strong> open, {{.Report.Appeals.OpenedDuringShift}} opened and {{.Report.Appeals.ResolvedDuringShift}} resolved this shift.{{if .OldestAppeal}}
+            Oldest open appeal: {{.OldestAppeal}}.{{end}}
+        </p>
+
+        <p style="text-align: center; margin-top: 30px;">
+            <a href="{{.ModerationURL}}" style="display: inline-block; background: #667eea; color: white; padding: 12px 30px; text-decoration: none; border-radius: 5px; font-weight: bold;">Open Moderation Queue</a>
+        </p>
+    </div>
+</body>
+</html>
+`))
+
+var moderationShiftTextTemplate = texttemplate.Must(texttemplate.New("moderation_shift_text").Parse(`{{.Title}}
+{{.Period}}
+
+HANDLED
+{{.Report.Totals.ItemsHandled}} items: {{.Report.Totals.Approved}} approved, {{.Report.Totals.Rejected}} rejected, {{.Report.Totals.Escalated}} escalated, {{.Report.Totals.Banned}} bans.
+{{.Report.Totals.AppealsResolved}} appeals resolved.
+{{range .Report.Moderators}}
+- {{if .Username}}{{.Username}}{{else}}{{.ModeratorID}}{{end}}: {{.ItemsHandled}} items, {{.AppealsResolved}} appeals{{end}}
+
+QUEUE
+{{.Report.Queue.Pending}} pending ({{.Report.Queue.HighPriority}} high priority), {{.Report.Queue.Escalated}} escalated.{{if .OldestPending}}
+Oldest pending item: {{.OldestPending}}.{{end}}
+{{if .Report.Escalations}}
+ESCALATIONS
+{{range .Report.Escalations}}- {{.ContentType}} / {{.Reason}} / priority {{.Priority}} ({{.Status}}){{if .Note}}
+  {{.Note}}{{end}}
+{{end}}{{end}}
+APPEALS
+{{.Report.Appeals.Open}} open, {{.Report.Appeals.OpenedDuringShift}} opened and {{.Report.Appeals.ResolvedDuringShift}} resolved this shift.{{if .OldestAppeal}}
+Oldest open appeal: {{.OldestAppeal}}.{{end}}
+
+Open the moderation queue: {{.ModerationURL}}
+`))
+
+// SendModerationShiftReport emails an end-of-shift handoff report to each
+// recipient. The hourly rate limit does not apply.
+func (s *EmailService) SendModerationShiftReport(ctx context.Context, recipients []string, report *models.ModerationShiftReport) error {
+	if !s.enabled || len(recipients) == 0 {
+		return nil
+	}
+
+	subject, htmlBody, textBody, err := s.prepareModerationShiftEmail(report)
+	if err != nil {
+		return fmt.Errorf("failed to prepare moderation shift email: %w", err)
+	}
+
+	var failed []string
+	for _, recipient := range recipients {
+		messageID, err := s.sendViaSendGrid(recipient, subject, htmlBody, textBody)
+		if err != nil {
+			s.logger.Error("Failed to send moderation shift report", err, map[string]interface{}{
+				"to":       recipient,
+				"shift_id": report.ShiftID,
+			})
+			failed = append(failed, recipient)
+			continue
+		}
+		s.logger.Info("Moderation shift report sent", map[string]interface{}{
+			"to":         recipient,
+			"shift_id":   report.ShiftID,
+			"message_id": messageID,
+		})
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to send moderation shift report to %d of %d recipients: %s",
+			len(failed), len(recipients), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// prepareModerationShiftEmail renders the handoff report subject and bodies,
+// with times in the shift's time zone
+func (s *EmailService) prepareModerationShiftEmail(report *models.ModerationShiftReport) (subject, htmlBody, textBody string, err error) {
+	loc, err := time.LoadLocation(report.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	start := report.PeriodStart.In(loc)
+	end := report.PeriodEnd.In(loc)
+
+	data := moderationShiftEmailData{
+		Title:         "Moderation shift handoff",
+		Period:        fmt.Sprintf("%s – %s %s", start.Format("Mon Jan 2, 15:04"), end.Format("Mon Jan 2, 15:04"), end.Format("MST")),
+		Report:        report,
+		OldestPending: formatAgeHours(report.Queue.OldestPendingHours),
+		OldestAppeal:  formatAgeHours(report.Appeals.OldestOpenHours),
+		ModerationURL: s.baseURL + "/admin/moderation",
+	}
+	if report.ShiftName != nil {
+		data.Title = *report.ShiftName + " handoff"
+	}
+
+	var htmlBuf, textBuf bytes.Buffer
+	if err := moderationShiftHTMLTemplate.Execute(&htmlBuf, data); err != nil {
+		return "", "", "", err
+	}
+	if err := moderationShiftTextTemplate.Execute(&textBuf, data); err != nil {
+		return "", "", "", err
+	}
+
+	subject = fmt.Sprintf("%s: %d handled, %d pending", data.Title, report.Totals.ItemsHandled, report.Queue.Pending)
+	return subject, htmlBuf.String(), textBuf.String(), nil
+}
+
+// formatAgeHours describes an age in hours, or returns "" without one
+func formatAgeHours(hours *float64) string {
+	if hours == nil {
+		return ""
+	}
+	if *hours < 48 {
+		return fmt.Sprintf("%.0fh old", *hours)
+	}
+	return fmt.Sprintf("%.0fd old", *hours/24)
+}
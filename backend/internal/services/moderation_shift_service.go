@@ -0,0 +1,457 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const (
+	// moderationShiftMaxEscalations caps how many escalations a handoff report lists
+	moderationShiftMaxEscalations = 20
+	// moderationShiftMaxOpenAppeals caps how many open appeals a handoff report lists
+	moderationShiftMaxOpenAppeals = 10
+	// moderationShiftMaxReportSpan caps the window of an ad hoc handoff report
+	moderationShiftMaxReportSpan = 7 * 24 * time.Hour
+)
+
+// ErrModerationShiftInvalid is returned for a shift or report window that can't be used
+var ErrModerationShiftInvalid = errors.New("invalid moderation shift")
+
+// ModerationShiftRepositoryInterface defines the repository methods used by ModerationShiftService
+type ModerationShiftRepositoryInterface interface {
+	List(ctx context.Context) ([]models.ModerationShift, error)
+	ListEmailEnabled(ctx context.Context) ([]models.ModerationShift, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.ModerationShift, error)
+	Create(ctx context.Context, shift *models.ModerationShift) error
+	Update(ctx context.Context, shift *models.ModerationShift) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ClaimReport(ctx context.Context, id uuid.UUID, end time.Time) (bool, error)
+	ReleaseReport(ctx context.Context, id uuid.UUID, end time.Time, previous *time.Time) error
+	GetActivity(ctx context.Context, start, end time.Time, moderatorIDs []uuid.UUID) ([]models.ModerationShiftActivity, error)
+	GetQueueSnapshot(ctx context.Context, asOf time.Time) (*models.ModerationShiftQueue, error)
+	ListEscalations(ctx context.Context, start, end time.Time, moderatorIDs []uuid.UUID, limit int) ([]models.ModerationShiftEscalation, error)
+	GetAppealSummary(ctx context.Context, start, end time.Time, moderatorIDs []uuid.UUID, asOf time.Time, limit int) (*models.ModerationShiftAppeals, error)
+}
+
+// ModerationShiftReportSender sends a handoff report email
+type ModerationShiftReportSender interface {
+	SendModerationShiftReport(ctx context.Context, recipients []string, report *models.ModerationShiftReport) error
+}
+
+// ModerationShiftService manages moderator shifts and builds their
+// end-of-shift handoff reports: what the shift's moderators handled, and the
+// queue, escalations and appeals the next shift inherits
+type ModerationShiftService struct {
+	repo   ModerationShiftRepositoryInterface
+	sender ModerationShiftReportSender
+	now    func() time.Time
+}
+
+// NewModerationShiftService creates a new ModerationShiftService
+func NewModerationShiftService(repo ModerationShiftRepositoryInterface, sender ModerationShiftReportSender) *ModerationShiftService {
+	return &ModerationShiftService{
+		repo:   repo,
+		sender: sender,
+		now:    time.Now,
+	}
+}
+
+// ListShifts returns every moderation shift
+func (s *ModerationShiftService) ListShifts(ctx context.Context) ([]models.ModerationShift, error) {
+	return s.repo.List(ctx)
+}
+
+// GetShift returns a moderation shift by ID
+func (s *ModerationShiftService) GetShift(ctx context.Context, id uuid.UUID) (*models.ModerationShift, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// CreateShift creates a moderation shift
+func (s *ModerationShiftService) CreateShift(ctx context.Context, adminID uuid.UUID, req *models.CreateModerationShiftRequest) (*models.ModerationShift, error) {
+	shift := &models.ModerationShift{
+		Name:            req.Name,
+		Timezone:        req.Timezone,
+		StartTime:       req.StartTime,
+		DurationMinutes: req.DurationMinutes,
+		ModeratorIDs:    req.ModeratorIDs,
+		EmailRecipients: req.EmailRecipients,
+		CreatedBy:       &adminID,
+	}
+	if req.EmailEnabled != nil {
+		shift.EmailEnabled = *req.EmailEnabled
+	}
+	if err := normalizeModerationShift(shift); err != nil {
+		return nil, err
+	}
+	if shift.EmailEnabled {
+		s.skipEndedReports(shift)
+	}
+
+	if err := s.repo.Create(ctx, shift); err != nil {
+		return nil, err
+	}
+	return shift, nil
+}
+
+// UpdateShift changes a moderation shift's schedule, team or email settings
+func (s *ModerationShiftService) UpdateShift(ctx context.Context, id uuid.UUID, req *models.UpdateModerationShiftRequest) (*models.ModerationShift, error) {
+	shift, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	wasEnabled := shift.EmailEnabled
+	schedule := fmt.Sprintf("%s %s %d", shift.Timezone, shift.StartTime, shift.DurationMinutes)
+
+	if req.Name != nil {
+		shift.Name = *req.Name
+	}
+	if req.Timezone != nil {
+		shift.Timezone = *req.Timezone
+	}
+	if req.StartTime != nil {
+		shift.StartTime = *req.StartTime
+	}
+	if req.DurationMinutes != nil {
+		shift.DurationMinutes = *req.DurationMinutes
+	}
+	if req.ModeratorIDs != nil {
+		shift.ModeratorIDs = *req.ModeratorIDs
+	}
+	if req.EmailRecipients != nil {
+		shift.EmailRecipients = *req.EmailRecipients
+	}
+	if req.EmailEnabled != nil {
+		shift.EmailEnabled = *req.EmailEnabled
+	}
+	if err := normalizeModerationShift(shift); err != nil {
+		return nil, err
+	}
+
+	// Reports resume with the next shift to end, not every shift ended while
+	// emails were off or on the old schedule
+	rescheduled := schedule != fmt.Sprintf("%s %s %d", shift.Timezone, shift.StartTime, shift.DurationMinutes)
+	if shift.EmailEnabled && (!wasEnabled || rescheduled) {
+		s.skipEndedReports(shift)
+	}
+
+	if err := s.repo.Update(ctx, shift); err != nil {
+		return nil, err
+	}
+	return shift, nil
+}
+
+// DeleteShift removes a moderation shift
+func (s *ModerationShiftService) DeleteShift(ctx context.Context, id uuid.UUID) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// skipEndedReports marks every shift that has already ended as reported, so
+// the first email covers the next shift to end
+func (s *ModerationShiftService) skipEndedReports(shift *models.ModerationShift) {
+	_, end, err := lastEndedModerationShift(shift, s.now())
+	if err != nil {
+		return
+	}
+	shift.LastReportedEnd = &end
+}
+
+// ShiftReport builds the handoff report of the shift that started on date
+// (YYYY-MM-DD in the shift's time zone), or of the most recently ended shift
+// when date is empty. moderatorID narrows the report to one moderator.
+func (s *ModerationShiftService) ShiftReport(ctx context.Context, id uuid.UUID, date string, moderatorID *uuid.UUID) (*models.ModerationShiftReport, error) {
+	shift, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var start, end time.Time
+	if date == "" {
+		start, end, err = lastEndedModerationShift(shift, s.now())
+	} else {
+		start, end, err = moderationShiftOn(shift, date)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.shiftReport(ctx, shift, start, end, moderatorID)
+}
+
+// Report builds a handoff report for any window of up to a week, covering
+// every moderator or only moderatorID
+func (s *ModerationShiftService) Report(ctx context.Context, start, end time.Time, timezone string, moderatorID *uuid.UUID) (*models.ModerationShiftReport, error) {
+	if !end.After(start) {
+		return nil, fmt.Errorf("%w: end must be after start", ErrModerationShiftInvalid)
+	}
+	if end.Sub(start) > moderationShiftMaxReportSpan {
+		return nil, fmt.Errorf("%w: reports cover at most 7 days", ErrModerationShiftInvalid)
+	}
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return nil, fmt.Errorf("%w: unknown time zone %q", ErrModerationShiftInvalid, timezone)
+	}
+
+	report, err := s.buildReport(ctx, start, end, moderatorIDsFor(nil, moderatorID))
+	if err != nil {
+		return nil, err
+	}
+	report.Timezone = timezone
+	report.ModeratorID = moderatorID
+	return report, nil
+}
+
+// SendDueReports emails the report of each email-enabled shift that has ended
+// since its last report, returning how many were sent
+func (s *ModerationShiftService) SendDueReports(ctx context.Context) (int, error) {
+	shifts, err := s.repo.ListEmailEnabled(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list emailed moderation shifts: %w", err)
+	}
+
+	now := s.now()
+	sent := 0
+	for i := range shifts {
+		if ctx.Err() != nil {
+			return sent, ctx.Err()
+		}
+
+		shift := &shifts[i]
+		if len(shift.EmailRecipients) == 0 {
+			continue
+		}
+		start, end, err := lastEndedModerationShift(shift, now)
+		if err != nil {
+			continue
+		}
+		if shift.LastReportedEnd != nil && !end.After(*shift.LastReportedEnd) {
+			continue
+		}
+
+		ok, err := s.sendReport(ctx, shift, start, end)
+		if err != nil {
+			utils.GetLogger().Error("Failed to send moderation shift report", err, map[string]interface{}{
+				"shift_id": shift.ID.String(),
+			})
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// sendReport claims, builds and emails one shift report, releasing the claim
+// when sending fails. It returns false when another run claimed it first.
+func (s *ModerationShiftService) sendReport(ctx context.Context, shift *models.ModerationShift, start, end time.Time) (bool, error) {
+	claimed, err := s.repo.ClaimReport(ctx, shift.ID, end)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	report, err := s.shiftReport(ctx, shift, start, end, nil)
+	if err == nil {
+		err = s.sender.SendModerationShiftReport(ctx, shift.EmailRecipients, report)
+	}
+	if err != nil {
+		if releaseErr := s.repo.ReleaseReport(ctx, shift.ID, end, shift.LastReportedEnd); releaseErr != nil {
+			utils.GetLogger().Error("Failed to release moderation shift report", releaseErr, map[string]interface{}{
+				"shift_id": shift.ID.String(),
+			})
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// shiftReport builds the report of one shift, for its team or one moderator
+func (s *ModerationShiftService) shiftReport(ctx context.Context, shift *models.ModerationShift, start, end time.Time, moderatorID *uuid.UUID) (*models.ModerationShiftReport, error) {
+	report, err := s.buildReport(ctx, start, end, moderatorIDsFor(shift.ModeratorIDs, moderatorID))
+	if err != nil {
+		return nil, err
+	}
+	report.ShiftID = &shift.ID
+	report.ShiftName = &shift.Name
+	report.Timezone = shift.Timezone
+	report.ModeratorID = moderatorID
+	return report, nil
+}
+
+// buildReport summarizes [start, end) for moderatorIDs, or every moderator
+// when empty. The queue and open appeals are as they stand now.
+func (s *ModerationShiftService) buildReport(ctx context.Context, start, end time.Time, moderatorIDs []uuid.UUID) (*models.ModerationShiftReport, error) {
+	now := s.now()
+
+	activity, err := s.repo.GetActivity(ctx, start, end, moderatorIDs)
+	if err != nil {
+		return nil, err
+	}
+	queue, err := s.repo.GetQueueSnapshot(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	escalations, err := s.repo.ListEscalations(ctx, start, end, moderatorIDs, moderationShiftMaxEscalations)
+	if err != nil {
+		return nil, err
+	}
+	appeals, err := s.repo.GetAppealSummary(ctx, start, end, moderatorIDs, now, moderationShiftMaxOpenAppeals)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ModerationShiftReport{
+		PeriodStart: start.UTC(),
+		PeriodEnd:   end.UTC(),
+		Totals:      sumModerationShiftActivity(activity),
+		Moderators:  activity,
+		Queue:       *queue,
+		Escalations: escalations,
+		Appeals:     *appeals,
+		GeneratedAt: now.UTC(),
+	}, nil
+}
+
+// sumModerationShiftActivity totals every moderator's activity. An item
+// decided by two moderators counts for both.
+func sumModerationShiftActivity(activity []models.ModerationShiftActivity) models.ModerationShiftActivity {
+	var totals models.ModerationShiftActivity
+	for _, a := range activity {
+		totals.ItemsHandled += a.ItemsHandled
+		totals.Approved += a.Approved
+		totals.Rejected += a.Rejected
+		totals.Escalated += a.Escalated
+		totals.Banned += a.Banned
+		totals.AppealsResolved += a.AppealsResolved
+	}
+	return totals
+}
+
+// moderatorIDsFor returns the moderators a report covers: moderatorID when
+// set, otherwise the shift's team
+func moderatorIDsFor(team []uuid.UUID, moderatorID *uuid.UUID) []uuid.UUID {
+	if moderatorID != nil {
+		return []uuid.UUID{*moderatorID}
+	}
+	return team
+}
+
+// normalizeModerationShift trims and validates a shift, defaulting its time
+// zone to UTC
+func normalizeModerationShift(shift *models.ModerationShift) error {
+	shift.Name = strings.TrimSpace(shift.Name)
+	if shift.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrModerationShiftInvalid)
+	}
+
+	shift.Timezone = strings.TrimSpace(shift.Timezone)
+	if shift.Timezone == "" {
+		shift.Timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(shift.Timezone); err != nil {
+		return fmt.Errorf("%w: unknown time zone %q", ErrModerationShiftInvalid, shift.Timezone)
+	}
+
+	shift.StartTime = strings.TrimSpace(shift.StartTime)
+	if _, err := time.Parse("15:04", shift.StartTime); err != nil || len(shift.StartTime) != 5 {
+		return fmt.Errorf("%w: start_time must be HH:MM", ErrModerationShiftInvalid)
+	}
+	if shift.DurationMinutes < 15 || shift.DurationMinutes > 24*60 {
+		return fmt.Errorf("%w: duration_minutes must be between 15 and 1440", ErrModerationShiftInvalid)
+	}
+
+	seen := map[uuid.UUID]bool{}
+	moderatorIDs := []uuid.UUID{}
+	for _, id := range shift.ModeratorIDs {
+		if id == uuid.Nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		moderatorIDs = append(moderatorIDs, id)
+	}
+	shift.ModeratorIDs = moderatorIDs
+
+	seenEmail := map[string]bool{}
+	recipients := []string{}
+	for _, recipient := range shift.EmailRecipients {
+		addr, err := mail.ParseAddress(strings.TrimSpace(recipient))
+		if err != nil {
+			return fmt.Errorf("%w: invalid email address %q", ErrModerationShiftInvalid, recipient)
+		}
+		key := strings.ToLower(addr.Address)
+		if seenEmail[key] {
+			continue
+		}
+		seenEmail[key] = true
+		recipients = append(recipients, addr.Address)
+	}
+	shift.EmailRecipients = recipients
+	if shift.EmailEnabled && len(recipients) == 0 {
+		return fmt.Errorf("%w: email_enabled needs at least one email recipient", ErrModerationShiftInvalid)
+	}
+
+	return nil
+}
+
+// moderationShiftStart returns when the shift starts on the given day in its
+// time zone. Shifts follow the local clock, so they keep their local start
+// time across daylight saving changes.
+func moderationShiftStart(shift *models.ModerationShift, loc *time.Location, year int, month time.Month, day int) (time.Time, error) {
+	clock, err := time.Parse("15:04", shift.StartTime)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: start_time must be HH:MM", ErrModerationShiftInvalid)
+	}
+	return time.Date(year, month, day, clock.Hour(), clock.Minute(), 0, 0, loc), nil
+}
+
+// moderationShiftOn returns the shift that started on date, YYYY-MM-DD in the
+// shift's time zone
+func moderationShiftOn(shift *models.ModerationShift, date string) (start, end time.Time, err error) {
+	loc, err := time.LoadLocation(shift.Timezone)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: unknown time zone %q", ErrModerationShiftInvalid, shift.Timezone)
+	}
+	day, err := time.ParseInLocation("2006-01-02", date, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: date must be YYYY-MM-DD", ErrModerationShiftInvalid)
+	}
+
+	start, err = moderationShiftStart(shift, loc, day.Year(), day.Month(), day.Day())
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return start, start.Add(time.Duration(shift.DurationMinutes) * time.Minute), nil
+}
+
+// lastEndedModerationShift returns the most recent shift that ended at or
+// before now
+func lastEndedModerationShift(shift *models.ModerationShift, now time.Time) (start, end time.Time, err error) {
+	loc, err := time.LoadLocation(shift.Timezone)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: unknown time zone %q", ErrModerationShiftInvalid, shift.Timezone)
+	}
+	local := now.In(loc)
+	duration := time.Duration(shift.DurationMinutes) * time.Minute
+
+	// Shifts last at most a day, so the one started the day before yesterday
+	// has always ended
+	for daysBack := 0; daysBack <= 2; daysBack++ {
+		start, err = moderationShiftStart(shift, loc, local.Year(), local.Month(), local.Day()-daysBack)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		if end = start.Add(duration); !end.After(now) {
+			return start, end, nil
+		}
+	}
+	return start, end, nil
+}
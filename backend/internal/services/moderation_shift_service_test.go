@@ -0,0 +1,386 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// MockModerationShiftRepository is a mock implementation of ModerationShiftRepositoryInterface
+type MockModerationShiftRepository struct {
+	mock.Mock
+}
+
+func (m *MockModerationShiftRepository) List(ctx context.Context) ([]models.ModerationShift, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ModerationShift), args.Error(1)
+}
+
+func (m *MockModerationShiftRepository) ListEmailEnabled(ctx context.Context) ([]models.ModerationShift, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ModerationShift), args.Error(1)
+}
+
+func (m *MockModerationShiftRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ModerationShift, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ModerationShift), args.Error(1)
+}
+
+func (m *MockModerationShiftRepository) Create(ctx context.Context, shift *models.ModerationShift) error {
+	args := m.Called(ctx, shift)
+	return args.Error(0)
+}
+
+func (m *MockModerationShiftRepository) Update(ctx context.Context, shift *models.ModerationShift) error {
+	args := m.Called(ctx, shift)
+	return args.Error(0)
+}
+
+func (m *MockModerationShiftRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockModerationShiftRepository) ClaimReport(ctx context.Context, id uuid.UUID, end time.Time) (bool, error) {
+	args := m.Called(ctx, id, end)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockModerationShiftRepository) ReleaseReport(ctx context.Context, id uuid.UUID, end time.Time, previous *time.Time) error {
+	args := m.Called(ctx, id, end, previous)
+	return args.Error(0)
+}
+
+func (m *MockModerationShiftRepository) GetActivity(ctx context.Context, start, end time.Time, moderatorIDs []uuid.UUID) ([]models.ModerationShiftActivity, error) {
+	args := m.Called(ctx, start, end, moderatorIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ModerationShiftActivity), args.Error(1)
+}
+
+func (m *MockModerationShiftRepository) GetQueueSnapshot(ctx context.Context, asOf time.Time) (*models.ModerationShiftQueue, error) {
+	args := m.Called(ctx, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ModerationShiftQueue), args.Error(1)
+}
+
+func (m *MockModerationShiftRepository) ListEscalations(ctx context.Context, start, end time.Time, moderatorIDs []uuid.UUID, limit int) ([]models.ModerationShiftEscalation, error) {
+	args := m.Called(ctx, start, end, moderatorIDs, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ModerationShiftEscalation), args.Error(1)
+}
+
+func (m *MockModerationShiftRepository) GetAppealSummary(ctx context.Context, start, end time.Time, moderatorIDs []uuid.UUID, asOf time.Time, limit int) (*models.ModerationShiftAppeals, error) {
+	args := m.Called(ctx, start, end, moderatorIDs, asOf, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ModerationShiftAppeals), args.Error(1)
+}
+
+// MockModerationShiftReportSender is a mock implementation of ModerationShiftReportSender
+type MockModerationShiftReportSender struct {
+	mock.Mock
+}
+
+func (m *MockModerationShiftReportSender) SendModerationShiftReport(ctx context.Context, recipients []string, report *models.ModerationShiftReport) error {
+	args := m.Called(ctx, recipients, report)
+	return args.Error(0)
+}
+
+// expectModerationShiftActivity serves activity to every report and records
+// the moderators each report was filtered to. The queue, escalations and
+// appeals are fixed.
+func expectModerationShiftActivity(repo *MockModerationShiftRepository, activity []models.ModerationShiftActivity) *[][]uuid.UUID {
+	filters := &[][]uuid.UUID{}
+	repo.On("GetActivity", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*filters = append(*filters, args.Get(3).([]uuid.UUID))
+		}).
+		Return(activity, nil)
+	repo.On("GetQueueSnapshot", mock.Anything, mock.Anything).Return(&models.ModerationShiftQueue{Pending: 12, HighPriority: 2}, nil)
+	repo.On("ListEscalations", mock.Anything, mock.Anything, mock.Anything, mock.Anything, moderationShiftMaxEscalations).
+		Return([]models.ModerationShiftEscalation{}, nil)
+	repo.On("GetAppealSummary", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, moderationShiftMaxOpenAppeals).
+		Return(&models.ModerationShiftAppeals{Open: 3, OldestOpen: []models.ModerationAppeal{}}, nil)
+	return filters
+}
+
+func testModerationShift(timezone, startTime string, durationMinutes int) *models.ModerationShift {
+	return &models.ModerationShift{
+		ID:              uuid.New(),
+		Name:            "EU day",
+		Timezone:        timezone,
+		StartTime:       startTime,
+		DurationMinutes: durationMinutes,
+		ModeratorIDs:    []uuid.UUID{},
+		EmailRecipients: []string{"leads@example.com"},
+		EmailEnabled:    true,
+	}
+}
+
+func TestLastEndedModerationShift(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	// A 09:00-17:00 shift in Berlin, seen at 18:00 and at 12:00 local time
+	shift := testModerationShift("Europe/Berlin", "09:00", 8*60)
+	start, end, err := lastEndedModerationShift(shift, time.Date(2026, 3, 10, 18, 0, 0, 0, berlin))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 10, 9, 0, 0, 0, berlin), start)
+	assert.Equal(t, time.Date(2026, 3, 10, 17, 0, 0, 0, berlin), end)
+
+	start, _, err = lastEndedModerationShift(shift, time.Date(2026, 3, 10, 12, 0, 0, 0, berlin))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 9, 9, 0, 0, 0, berlin), start, "the running shift hasn't ended")
+
+	// An overnight 22:00-06:00 shift seen at 07:00 started the evening before
+	night := testModerationShift("Europe/Berlin", "22:00", 8*60)
+	start, end, err = lastEndedModerationShift(night, time.Date(2026, 3, 10, 7, 0, 0, 0, berlin))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 9, 22, 0, 0, 0, berlin), start)
+	assert.Equal(t, time.Date(2026, 3, 10, 6, 0, 0, 0, berlin), end)
+
+	// Shifts keep their local start time across daylight saving changes
+	dst := testModerationShift("Europe/Berlin", "09:00", 60)
+	start, _, err = lastEndedModerationShift(dst, time.Date(2026, 3, 30, 12, 0, 0, 0, berlin))
+	require.NoError(t, err)
+	assert.Equal(t, 7, start.UTC().Hour(), "09:00 CEST")
+	start, _, err = lastEndedModerationShift(dst, time.Date(2026, 3, 27, 12, 0, 0, 0, berlin))
+	require.NoError(t, err)
+	assert.Equal(t, 8, start.UTC().Hour(), "09:00 CET")
+
+	_, _, err = lastEndedModerationShift(testModerationShift("Mars/Olympus", "09:00", 60), time.Now())
+	assert.ErrorIs(t, err, ErrModerationShiftInvalid)
+}
+
+func TestModerationShiftOn(t *testing.T) {
+	shift := testModerationShift("America/New_York", "20:00", 10*60)
+	start, end, err := moderationShiftOn(shift, "2026-07-04")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 7, 5, 0, 0, 0, 0, time.UTC), start.UTC())
+	assert.Equal(t, time.Date(2026, 7, 5, 10, 0, 0, 0, time.UTC), end.UTC())
+
+	_, _, err = moderationShiftOn(shift, "07/04/2026")
+	assert.ErrorIs(t, err, ErrModerationShiftInvalid)
+}
+
+func TestNormalizeModerationShift(t *testing.T) {
+	modID := uuid.New()
+	shift := &models.ModerationShift{
+		Name:            "  APAC  ",
+		StartTime:       "07:30",
+		DurationMinutes: 480,
+		ModeratorIDs:    []uuid.UUID{modID, modID, uuid.Nil},
+		EmailRecipients: []string{"Lead <lead@example.com>", "LEAD@example.com", " ops@example.com "},
+		EmailEnabled:    true,
+	}
+	require.NoError(t, normalizeModerationShift(shift))
+	assert.Equal(t, "APAC", shift.Name)
+	assert.Equal(t, "UTC", shift.Timezone)
+	assert.Equal(t, []uuid.UUID{modID}, shift.ModeratorIDs)
+	assert.Equal(t, []string{"lead@example.com", "ops@example.com"}, shift.EmailRecipients)
+
+	invalid := []func(s *models.ModerationShift){
+		func(s *models.ModerationShift) { s.Name = " " },
+		func(s *models.ModerationShift) { s.Timezone = "Nowhere/City" },
+		func(s *models.ModerationShift) { s.StartTime = "7:30" },
+		func(s *models.ModerationShift) { s.StartTime = "24:00" },
+		func(s *models.ModerationShift) { s.DurationMinutes = 5 },
+		func(s *models.ModerationShift) { s.DurationMinutes = 1500 },
+		func(s *models.ModerationShift) { s.EmailRecipients = []string{"not an email"} },
+		func(s *models.ModerationShift) { s.EmailRecipients = nil },
+	}
+	for i, mutate := range invalid {
+		shift := testModerationShift("UTC", "09:00", 60)
+		mutate(shift)
+		assert.ErrorIs(t, normalizeModerationShift(shift), ErrModerationShiftInvalid, "case %d", i)
+	}
+}
+
+func TestModerationShiftService_EnablingEmailSkipsEndedShifts(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 5, 4, 18, 0, 0, 0, time.UTC)
+	repo := new(MockModerationShiftRepository)
+	svc := NewModerationShiftService(repo, new(MockModerationShiftReportSender))
+	svc.now = func() time.Time { return now }
+
+	repo.On("Create", ctx, mock.AnythingOfType("*models.ModerationShift")).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*models.ModerationShift).ID = uuid.New()
+		}).
+		Return(nil).Once()
+
+	enabled := true
+	shift, err := svc.CreateShift(ctx, uuid.New(), &models.CreateModerationShiftRequest{
+		Name:            "Day",
+		StartTime:       "09:00",
+		DurationMinutes: 480,
+		EmailRecipients: []string{"leads@example.com"},
+		EmailEnabled:    &enabled,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, shift.LastReportedEnd)
+	assert.Equal(t, time.Date(2026, 5, 4, 17, 0, 0, 0, time.UTC), *shift.LastReportedEnd)
+
+	// Moving the shift re-baselines reports on the new schedule
+	stored := *shift
+	repo.On("GetByID", ctx, shift.ID).Return(&stored, nil).Once()
+	repo.On("Update", ctx, mock.AnythingOfType("*models.ModerationShift")).Return(nil)
+	later := "10:00"
+	shift, err = svc.UpdateShift(ctx, shift.ID, &models.UpdateModerationShiftRequest{StartTime: &later})
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 5, 4, 18, 0, 0, 0, time.UTC), *shift.LastReportedEnd)
+
+	stored = *shift
+	repo.On("GetByID", ctx, shift.ID).Return(&stored, nil).Once()
+	disabled := false
+	_, err = svc.UpdateShift(ctx, shift.ID, &models.UpdateModerationShiftRequest{EmailEnabled: &disabled})
+	require.NoError(t, err)
+
+	repo.AssertExpectations(t)
+	repo.AssertNumberOfCalls(t, "Update", 2)
+}
+
+func TestModerationShiftService_SendDueReports(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 5, 4, 18, 0, 0, 0, time.UTC)
+	reported := time.Date(2026, 5, 3, 17, 0, 0, 0, time.UTC)
+
+	due := testModerationShift("UTC", "09:00", 480)
+	due.LastReportedEnd = &reported
+	team := []uuid.UUID{uuid.New()}
+	due.ModeratorIDs = team
+
+	alreadySent := testModerationShift("UTC", "09:00", 480)
+	sentEnd := time.Date(2026, 5, 4, 17, 0, 0, 0, time.UTC)
+	alreadySent.LastReportedEnd = &sentEnd
+
+	noRecipients := testModerationShift("UTC", "09:00", 480)
+	noRecipients.EmailRecipients = nil
+
+	repo := new(MockModerationShiftRepository)
+	filters := expectModerationShiftActivity(repo, []models.ModerationShiftActivity{
+		{ModeratorID: &team[0], ItemsHandled: 30, Approved: 20, Rejected: 8, Escalated: 2, AppealsResolved: 1},
+	})
+	sender := new(MockModerationShiftReportSender)
+	svc := NewModerationShiftService(repo, sender)
+	svc.now = func() time.Time { return now }
+
+	var report *models.ModerationShiftReport
+	repo.On("ListEmailEnabled", ctx).Return([]models.ModerationShift{*due, *alreadySent, *noRecipients}, nil).Once()
+	repo.On("ClaimReport", ctx, due.ID, sentEnd).Return(true, nil).Once()
+	sender.On("SendModerationShiftReport", ctx, due.EmailRecipients, mock.Anything).
+		Run(func(args mock.Arguments) {
+			report = args.Get(2).(*models.ModerationShiftReport)
+		}).
+		Return(nil).Once()
+
+	sent, err := svc.SendDueReports(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+
+	require.NotNil(t, report)
+	assert.Equal(t, due.ID, *report.ShiftID)
+	assert.Equal(t, time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC), report.PeriodStart)
+	assert.Equal(t, sentEnd, report.PeriodEnd)
+	assert.Equal(t, 30, report.Totals.ItemsHandled)
+	assert.Equal(t, 12, report.Queue.Pending)
+	assert.Equal(t, team, (*filters)[0], "reports cover the shift's team")
+
+	// Nothing is due until the next shift ends
+	due.LastReportedEnd = &sentEnd
+	repo.On("ListEmailEnabled", ctx).Return([]models.ModerationShift{*due, *alreadySent, *noRecipients}, nil).Once()
+	sent, err = svc.SendDueReports(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+
+	// A failed send is released for the next run
+	nextEnd := sentEnd.Add(24 * time.Hour)
+	svc.now = func() time.Time { return now.Add(24 * time.Hour) }
+	repo.On("ListEmailEnabled", ctx).Return([]models.ModerationShift{*due, *alreadySent, *noRecipients}, nil).Once()
+	repo.On("ClaimReport", ctx, mock.Anything, nextEnd).Return(true, nil).Twice()
+	sender.On("SendModerationShiftReport", ctx, mock.Anything, mock.Anything).Return(errors.New("sendgrid down")).Twice()
+	repo.On("ReleaseReport", ctx, due.ID, nextEnd, &sentEnd).Return(nil).Once()
+	repo.On("ReleaseReport", ctx, alreadySent.ID, nextEnd, &sentEnd).Return(nil).Once()
+
+	sent, err = svc.SendDueReports(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+
+	repo.AssertExpectations(t)
+	sender.AssertExpectations(t)
+	repo.AssertNumberOfCalls(t, "ReleaseReport", 2)
+}
+
+func TestModerationShiftService_Report(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockModerationShiftRepository)
+	filters := expectModerationShiftActivity(repo, []models.ModerationShiftActivity{})
+	svc := NewModerationShiftService(repo, new(MockModerationShiftReportSender))
+
+	start := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	modID := uuid.New()
+	report, err := svc.Report(ctx, start, start.Add(8*time.Hour), "", &modID)
+	require.NoError(t, err)
+	assert.Equal(t, "UTC", report.Timezone)
+	assert.Equal(t, &modID, report.ModeratorID)
+	assert.Equal(t, []uuid.UUID{modID}, (*filters)[0])
+
+	_, err = svc.Report(ctx, start, start, "", nil)
+	assert.ErrorIs(t, err, ErrModerationShiftInvalid)
+	_, err = svc.Report(ctx, start, start.Add(8*24*time.Hour), "", nil)
+	assert.ErrorIs(t, err, ErrModerationShiftInvalid)
+	_, err = svc.Report(ctx, start, start.Add(time.Hour), "Nowhere/City", nil)
+	assert.ErrorIs(t, err, ErrModerationShiftInvalid)
+
+	repo.AssertNumberOfCalls(t, "GetActivity", 1)
+}
+
+func TestPrepareModerationShiftEmail(t *testing.T) {
+	svc := &EmailService{baseURL: "https://clpr.tv"}
+	name := "EU day"
+	hours := 50.0
+	note := "Possible ban evasion"
+	report := &models.ModerationShiftReport{
+		ShiftName:   &name,
+		Timezone:    "Europe/Berlin",
+		PeriodStart: time.Date(2026, 5, 4, 7, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2026, 5, 4, 15, 0, 0, 0, time.UTC),
+		Totals:      models.ModerationShiftActivity{ItemsHandled: 30},
+		Queue:       models.ModerationShiftQueue{Pending: 12, OldestPendingHours: &hours},
+		Escalations: []models.ModerationShiftEscalation{
+			{ContentType: "clip", Reason: "harassment", Priority: 90, Status: "escalated", Note: &note},
+		},
+	}
+
+	subject, htmlBody, textBody, err := svc.prepareModerationShiftEmail(report)
+	require.NoError(t, err)
+	assert.Equal(t, "EU day handoff: 30 handled, 12 pending", subject)
+	assert.Contains(t, htmlBody, "09:00")
+	assert.Contains(t, textBody, "Mon May 4, 09:00 – Mon May 4, 17:00 CEST")
+	assert.Contains(t, textBody, "Oldest pending item: 2d old.")
+	assert.Contains(t, textBody, "Possible ban evasion")
+	assert.Contains(t, htmlBody, "https://clpr.tv/admin/moderation")
+}
@@ -0,0 +1,257 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+const (
+	similarCasesDefaultLimit   = 10
+	similarCasesMaxLimit       = 50
+	similarCasesCandidateLimit = 50
+	// Embedding neighbours less similar than this only count if metadata matches too
+	similarCasesMinTitleSimilarity      = 0.5
+	moderationEmbeddingBackfillInterval = 15 * time.Minute
+	moderationEmbeddingBackfillBatch    = 100
+)
+
+// Weights of each signal in a similar case's score
+const (
+	similarCaseTitleWeight       = 0.6
+	similarCaseBroadcasterWeight = 0.2
+	similarCaseUserWeight        = 0.15
+	similarCaseGameWeight        = 0.1
+	similarCaseReasonWeight      = 0.1
+)
+
+// ErrInvalidModerationCaseType is returned when similar cases are requested for an unsupported case type
+var ErrInvalidModerationCaseType = errors.New("case type must be submission or report")
+
+// ModerationCaseRepositoryInterface defines the repository methods used by ModerationSimilarityService
+type ModerationCaseRepositoryInterface interface {
+	GetCase(ctx context.Context, caseType string, id uuid.UUID) (*models.ModerationCase, error)
+	ListSimilarByEmbedding(ctx context.Context, caseType string, caseID uuid.UUID, limit int) ([]models.SimilarModerationCase, error)
+	ListMetadataMatches(ctx context.Context, target *models.ModerationCase, limit int) ([]models.SimilarModerationCase, error)
+	HasEmbedding(ctx context.Context, caseType string, caseID uuid.UUID) (bool, error)
+	UpsertEmbedding(ctx context.Context, caseType string, caseID uuid.UUID, embedding []float32, model string) error
+	ListDecidedWithoutEmbedding(ctx context.Context, caseType string, limit int) ([]models.ModerationCase, error)
+}
+
+// TextEmbedder generates embeddings for free text
+type TextEmbedder interface {
+	GenerateEmbedding(ctx context.Context, text string) ([]float32, error)
+	GetModel() string
+}
+
+// ModerationSimilarityService finds past moderation decisions similar to a
+// submission or report under review, so moderators can decide consistently.
+// Cases are matched on title embeddings when embeddings are configured, and on
+// shared broadcaster, game, user and report reason.
+type ModerationSimilarityService struct {
+	repo     ModerationCaseRepositoryInterface
+	embedder TextEmbedder
+}
+
+// NewModerationSimilarityService creates a new ModerationSimilarityService. The
+// embedder may be nil, in which case only metadata is matched.
+func NewModerationSimilarityService(repo ModerationCaseRepositoryInterface, embedder TextEmbedder) *ModerationSimilarityService {
+	return &ModerationSimilarityService{
+		repo:     repo,
+		embedder: embedder,
+	}
+}
+
+// GetSimilarCases returns decided cases of the same type that most resemble the given case
+func (s *ModerationSimilarityService) GetSimilarCases(ctx context.Context, caseType string, caseID uuid.UUID, limit int) (*models.SimilarModerationCases, error) {
+	if caseType != models.ModerationCaseSubmission && caseType != models.ModerationCaseReport {
+		return nil, ErrInvalidModerationCaseType
+	}
+	if limit <= 0 {
+		limit = similarCasesDefaultLimit
+	}
+	if limit > similarCasesMaxLimit {
+		limit = similarCasesMaxLimit
+	}
+
+	target, err := s.repo.GetCase(ctx, caseType, caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := map[uuid.UUID]*models.SimilarModerationCase{}
+	usedEmbeddings := s.ensureEmbedding(ctx, target)
+	if usedEmbeddings {
+		neighbours, err := s.repo.ListSimilarByEmbedding(ctx, caseType, caseID, similarCasesCandidateLimit)
+		if err != nil {
+			return nil, err
+		}
+		for i := range neighbours {
+			candidates[neighbours[i].ID] = &neighbours[i]
+		}
+	}
+
+	matches, err := s.repo.ListMetadataMatches(ctx, target, similarCasesCandidateLimit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range matches {
+		if _, ok := candidates[matches[i].ID]; !ok {
+			candidates[matches[i].ID] = &matches[i]
+		}
+	}
+
+	similar := make([]models.SimilarModerationCase, 0, len(candidates))
+	for _, candidate := range candidates {
+		if scoreSimilarCase(target, candidate) > 0 {
+			similar = append(similar, *candidate)
+		}
+	}
+	sort.Slice(similar, func(i, j int) bool {
+		if similar[i].Score != similar[j].Score {
+			return similar[i].Score > similar[j].Score
+		}
+		return laterDecision(similar[i].DecidedAt, similar[j].DecidedAt)
+	})
+	if len(similar) > limit {
+		similar = similar[:limit]
+	}
+
+	summary := map[string]int{}
+	for _, c := range similar {
+		summary[c.Outcome]++
+	}
+
+	return &models.SimilarModerationCases{
+		Case:           target,
+		SimilarCases:   similar,
+		OutcomeSummary: summary,
+		UsedEmbeddings: usedEmbeddings,
+	}, nil
+}
+
+// Start embeds decided cases in the background so they can be matched by title,
+// until the context is cancelled
+func (s *ModerationSimilarityService) Start(ctx context.Context) {
+	if s.embedder == nil {
+		return
+	}
+
+	ticker := time.NewTicker(moderationEmbeddingBackfillInterval)
+	defer ticker.Stop()
+
+	for {
+		s.BackfillEmbeddings(ctx, moderationEmbeddingBackfillBatch)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// BackfillEmbeddings embeds up to limit decided cases of each type that have no embedding yet
+func (s *ModerationSimilarityService) BackfillEmbeddings(ctx context.Context, limit int) {
+	if s.embedder == nil {
+		return
+	}
+
+	for _, caseType := range []string{models.ModerationCaseSubmission, models.ModerationCaseReport} {
+		cases, err := s.repo.ListDecidedWithoutEmbedding(ctx, caseType, limit)
+		if err != nil {
+			log.Printf("Failed to list %s cases for embedding: %v", caseType, err)
+			continue
+		}
+		for i := range cases {
+			if err := s.embedCase(ctx, &cases[i]); err != nil {
+				log.Printf("Failed to embed %s case %s: %v", caseType, cases[i].ID, err)
+				// Stop this batch; the embedding API is likely unavailable or rate limited
+				break
+			}
+		}
+	}
+}
+
+// ensureEmbedding makes sure the case's title embedding is stored, reporting
+// whether title similarity can be used for it
+func (s *ModerationSimilarityService) ensureEmbedding(ctx context.Context, target *models.ModerationCase) bool {
+	if s.embedder == nil || target.Title == "" {
+		return false
+	}
+
+	exists, err := s.repo.HasEmbedding(ctx, target.Type, target.ID)
+	if err != nil {
+		log.Printf("Failed to check embedding for %s case %s: %v", target.Type, target.ID, err)
+		return false
+	}
+	if exists {
+		return true
+	}
+
+	if err := s.embedCase(ctx, target); err != nil {
+		log.Printf("Failed to embed %s case %s, matching on metadata only: %v", target.Type, target.ID, err)
+		return false
+	}
+	return true
+}
+
+// embedCase generates and stores the title embedding of a case
+func (s *ModerationSimilarityService) embedCase(ctx context.Context, c *models.ModerationCase) error {
+	embedding, err := s.embedder.GenerateEmbedding(ctx, c.Title)
+	if err != nil {
+		return err
+	}
+	return s.repo.UpsertEmbedding(ctx, c.Type, c.ID, embedding, s.embedder.GetModel())
+}
+
+// scoreSimilarCase sets the candidate's score and matched signals against the target and returns the score
+func scoreSimilarCase(target *models.ModerationCase, candidate *models.SimilarModerationCase) float64 {
+	score := 0.0
+	matched := []string{}
+
+	if candidate.Similarity != nil && *candidate.Similarity >= similarCasesMinTitleSimilarity {
+		score += similarCaseTitleWeight * *candidate.Similarity
+		matched = append(matched, "title")
+	}
+	if equalStringPtr(target.BroadcasterID, candidate.BroadcasterID) {
+		score += similarCaseBroadcasterWeight
+		matched = append(matched, "broadcaster")
+	}
+	if equalStringPtr(target.GameID, candidate.GameID) {
+		score += similarCaseGameWeight
+		matched = append(matched, "game")
+	}
+	if target.SubjectUserID != nil && candidate.SubjectUserID != nil && *target.SubjectUserID == *candidate.SubjectUserID {
+		score += similarCaseUserWeight
+		matched = append(matched, "user")
+	}
+	if equalStringPtr(target.Reason, candidate.Reason) && equalStringPtr(target.ContentType, candidate.ContentType) {
+		score += similarCaseReasonWeight
+		matched = append(matched, "reason")
+	}
+
+	candidate.Score = score
+	candidate.MatchedOn = matched
+	return score
+}
+
+// equalStringPtr reports whether both values are set and equal
+func equalStringPtr(a, b *string) bool {
+	return a != nil && b != nil && *a != "" && *a == *b
+}
+
+// laterDecision reports whether decision a was made after decision b, treating undecided as oldest
+func laterDecision(a, b *time.Time) bool {
+	if a == nil {
+		return false
+	}
+	if b == nil {
+		return true
+	}
+	return a.After(*b)
+}
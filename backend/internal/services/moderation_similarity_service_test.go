@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+// MockModerationCaseRepository is a mock implementation of ModerationCaseRepositoryInterface
+type MockModerationCaseRepository struct {
+	mock.Mock
+}
+
+func (m *MockModerationCaseRepository) GetCase(ctx context.Context, caseType string, id uuid.UUID) (*models.ModerationCase, error) {
+	args := m.Called(ctx, caseType, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ModerationCase), args.Error(1)
+}
+
+func (m *MockModerationCaseRepository) ListSimilarByEmbedding(ctx context.Context, caseType string, caseID uuid.UUID, limit int) ([]models.SimilarModerationCase, error) {
+	args := m.Called(ctx, caseType, caseID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.SimilarModerationCase), args.Error(1)
+}
+
+func (m *MockModerationCaseRepository) ListMetadataMatches(ctx context.Context, target *models.ModerationCase, limit int) ([]models.SimilarModerationCase, error) {
+	args := m.Called(ctx, target, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.SimilarModerationCase), args.Error(1)
+}
+
+func (m *MockModerationCaseRepository) HasEmbedding(ctx context.Context, caseType string, caseID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, caseType, caseID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockModerationCaseRepository) UpsertEmbedding(ctx context.Context, caseType string, caseID uuid.UUID, embedding []float32, model string) error {
+	args := m.Called(ctx, caseType, caseID, embedding, model)
+	return args.Error(0)
+}
+
+func (m *MockModerationCaseRepository) ListDecidedWithoutEmbedding(ctx context.Context, caseType string, limit int) ([]models.ModerationCase, error) {
+	args := m.Called(ctx, caseType, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ModerationCase), args.Error(1)
+}
+
+// MockTextEmbedder is a mock implementation of TextEmbedder
+type MockTextEmbedder struct {
+	mock.Mock
+}
+
+func (m *MockTextEmbedder) GenerateEmbedding(ctx context.Context, text string) ([]float32, error) {
+	args := m.Called(ctx, text)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]float32), args.Error(1)
+}
+
+func (m *MockTextEmbedder) GetModel() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func similarCase(outcome string, similarity *float64, broadcasterID string, decidedAt time.Time) models.SimilarModerationCase {
+	c := models.SimilarModerationCase{
+		ModerationCase: models.ModerationCase{
+			Type:      models.ModerationCaseSubmission,
+			ID:        uuid.New(),
+			Outcome:   outcome,
+			DecidedAt: &decidedAt,
+		},
+		Similarity: similarity,
+	}
+	if broadcasterID != "" {
+		c.BroadcasterID = &broadcasterID
+	}
+	return c
+}
+
+func TestModerationSimilarityService_GetSimilarCases(t *testing.T) {
+	repo := new(MockModerationCaseRepository)
+	embedder := new(MockTextEmbedder)
+	svc := NewModerationSimilarityService(repo, embedder)
+	ctx := context.Background()
+
+	broadcaster := "b1"
+	target := &models.ModerationCase{Type: models.ModerationCaseSubmission, ID: uuid.New(), Title: "Insane clutch", BroadcasterID: &broadcaster}
+	repo.On("GetCase", ctx, models.ModerationCaseSubmission, target.ID).Return(target, nil)
+
+	now := time.Now()
+	high, low := 0.9, 0.3
+	titleAndBroadcaster := similarCase("rejected", &high, "b1", now)
+	titleOnly := similarCase("approved", &high, "", now)
+	weakTitle := similarCase("approved", &low, "", now)
+	broadcasterOnly := similarCase("approved", nil, "b1", now.Add(-time.Hour))
+	repo.On("ListSimilarByEmbedding", ctx, models.ModerationCaseSubmission, target.ID, mock.Anything).
+		Return([]models.SimilarModerationCase{titleAndBroadcaster, titleOnly, weakTitle}, nil)
+	// Metadata match duplicating an embedding neighbour keeps its similarity
+	duplicate := similarCase("rejected", nil, "b1", now)
+	duplicate.ID = titleAndBroadcaster.ID
+	repo.On("ListMetadataMatches", ctx, target, mock.Anything).
+		Return([]models.SimilarModerationCase{duplicate, broadcasterOnly}, nil)
+
+	// The missing target embedding is generated once, then reused
+	repo.On("HasEmbedding", ctx, models.ModerationCaseSubmission, target.ID).Return(false, nil).Once()
+	embedder.On("GenerateEmbedding", ctx, "Insane clutch").Return([]float32{0.1, 0.2}, nil).Once()
+	embedder.On("GetModel").Return("test-model")
+	repo.On("UpsertEmbedding", ctx, models.ModerationCaseSubmission, target.ID, []float32{0.1, 0.2}, "test-model").Return(nil).Once()
+
+	result, err := svc.GetSimilarCases(ctx, models.ModerationCaseSubmission, target.ID, 10)
+	require.NoError(t, err)
+
+	assert.True(t, result.UsedEmbeddings)
+	require.Len(t, result.SimilarCases, 3, "weak title-only neighbours are dropped")
+	assert.Equal(t, titleAndBroadcaster.ID, result.SimilarCases[0].ID)
+	assert.Equal(t, []string{"title", "broadcaster"}, result.SimilarCases[0].MatchedOn)
+	assert.Equal(t, titleOnly.ID, result.SimilarCases[1].ID)
+	assert.Equal(t, broadcasterOnly.ID, result.SimilarCases[2].ID)
+	assert.Equal(t, map[string]int{"rejected": 1, "approved": 2}, result.OutcomeSummary)
+
+	// Stored embeddings are reused
+	repo.On("HasEmbedding", ctx, models.ModerationCaseSubmission, target.ID).Return(true, nil).Once()
+	_, err = svc.GetSimilarCases(ctx, models.ModerationCaseSubmission, target.ID, 1)
+	require.NoError(t, err)
+
+	repo.AssertExpectations(t)
+	embedder.AssertNumberOfCalls(t, "GenerateEmbedding", 1)
+}
+
+func TestModerationSimilarityService_MetadataOnly(t *testing.T) {
+	ctx := context.Background()
+	broadcaster := "b1"
+	target := &models.ModerationCase{Type: models.ModerationCaseSubmission, ID: uuid.New(), Title: "Clip", BroadcasterID: &broadcaster}
+	metadata := []models.SimilarModerationCase{similarCase("rejected", nil, "b1", time.Now())}
+
+	t.Run("without embedder", func(t *testing.T) {
+		repo := new(MockModerationCaseRepository)
+		repo.On("GetCase", ctx, models.ModerationCaseSubmission, target.ID).Return(target, nil)
+		repo.On("ListMetadataMatches", ctx, target, mock.Anything).Return(metadata, nil)
+
+		result, err := NewModerationSimilarityService(repo, nil).GetSimilarCases(ctx, models.ModerationCaseSubmission, target.ID, 10)
+		require.NoError(t, err)
+		assert.False(t, result.UsedEmbeddings)
+		require.Len(t, result.SimilarCases, 1)
+		assert.Equal(t, []string{"broadcaster"}, result.SimilarCases[0].MatchedOn)
+		repo.AssertNotCalled(t, "ListSimilarByEmbedding", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("embedding fails", func(t *testing.T) {
+		repo := new(MockModerationCaseRepository)
+		repo.On("GetCase", ctx, models.ModerationCaseSubmission, target.ID).Return(target, nil)
+		repo.On("HasEmbedding", ctx, models.ModerationCaseSubmission, target.ID).Return(false, nil)
+		repo.On("ListMetadataMatches", ctx, target, mock.Anything).Return(metadata, nil)
+		embedder := new(MockTextEmbedder)
+		embedder.On("GenerateEmbedding", ctx, "Clip").Return(nil, errors.New("rate limited"))
+
+		result, err := NewModerationSimilarityService(repo, embedder).GetSimilarCases(ctx, models.ModerationCaseSubmission, target.ID, 10)
+		require.NoError(t, err)
+		assert.False(t, result.UsedEmbeddings)
+		assert.Len(t, result.SimilarCases, 1)
+		repo.AssertNotCalled(t, "ListSimilarByEmbedding", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestModerationSimilarityService_Errors(t *testing.T) {
+	repo := new(MockModerationCaseRepository)
+	svc := NewModerationSimilarityService(repo, nil)
+	ctx := context.Background()
+
+	_, err := svc.GetSimilarCases(ctx, "comment", uuid.New(), 10)
+	assert.ErrorIs(t, err, ErrInvalidModerationCaseType)
+
+	missing := uuid.New()
+	repo.On("GetCase", ctx, models.ModerationCaseReport, missing).Return(nil, repository.ErrModerationCaseNotFound)
+	_, err = svc.GetSimilarCases(ctx, models.ModerationCaseReport, missing, 10)
+	assert.ErrorIs(t, err, repository.ErrModerationCaseNotFound)
+}
+
+func TestModerationSimilarityService_BackfillEmbeddings(t *testing.T) {
+	repo := new(MockModerationCaseRepository)
+	embedder := new(MockTextEmbedder)
+	ctx := context.Background()
+
+	submission := models.ModerationCase{Type: models.ModerationCaseSubmission, ID: uuid.New(), Title: "a"}
+	report := models.ModerationCase{Type: models.ModerationCaseReport, ID: uuid.New(), Title: "b"}
+	repo.On("ListDecidedWithoutEmbedding", ctx, models.ModerationCaseSubmission, 100).Return([]models.ModerationCase{submission}, nil)
+	repo.On("ListDecidedWithoutEmbedding", ctx, models.ModerationCaseReport, 100).Return([]models.ModerationCase{report}, nil)
+	embedder.On("GenerateEmbedding", ctx, mock.Anything).Return([]float32{0.1, 0.2}, nil)
+	embedder.On("GetModel").Return("test-model")
+	repo.On("UpsertEmbedding", ctx, models.ModerationCaseSubmission, submission.ID, []float32{0.1, 0.2}, "test-model").Return(nil).Once()
+	repo.On("UpsertEmbedding", ctx, models.ModerationCaseReport, report.ID, []float32{0.1, 0.2}, "test-model").Return(nil).Once()
+
+	NewModerationSimilarityService(repo, embedder).BackfillEmbeddings(ctx, 100)
+
+	repo.AssertExpectations(t)
+}
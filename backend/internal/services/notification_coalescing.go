@@ -0,0 +1,164 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+const (
+	// maxAggregateActorNames is the number of distinct actors remembered by name on a
+	// coalesced notification; everyone else is folded into OthersCount.
+	maxAggregateActorNames = 3
+
+	// maxAggregateTitleNames is the number of actor names rendered in a coalesced title
+	maxAggregateTitleNames = 2
+)
+
+// notificationCoalesceWindows lists the notification types that are coalesced during
+// event storms, along with the rolling window in which a new event is folded into the
+// most recent unread notification instead of creating a new one.
+var notificationCoalesceWindows = map[string]time.Duration{
+	models.NotificationTypeClipComment:          time.Hour,
+	models.NotificationTypeFavoritedClipComment: time.Hour,
+	models.NotificationTypeCommentOnContent:     time.Hour,
+	models.NotificationTypeReply:                30 * time.Minute,
+	models.NotificationTypeMention:              30 * time.Minute,
+	models.NotificationTypeVoteMilestone:        6 * time.Hour,
+	models.NotificationTypeCommentReaction:      time.Hour,
+	models.NotificationTypeClipVoteThreshold:    6 * time.Hour,
+	models.NotificationTypeClipViewThreshold:    6 * time.Hour,
+}
+
+// coalescedNotificationActions maps actor-driven notification types to the phrase used
+// when rendering an aggregated title ("alice, bob and +3 more commented on your clip").
+// Types without an entry (e.g. milestones) keep the title of the latest event.
+var coalescedNotificationActions = map[string]string{
+	models.NotificationTypeClipComment:          "commented on your clip",
+	models.NotificationTypeFavoritedClipComment: "commented on a clip you favorited",
+	models.NotificationTypeCommentOnContent:     "commented on your content",
+	models.NotificationTypeReply:                "replied to your comment",
+	models.NotificationTypeMention:              "mentioned you in a comment",
+	models.NotificationTypeCommentReaction:      "reacted to your comment",
+}
+
+// notificationGroupKey returns the coalescing window and key for a notification.
+// An empty key means the notification is never coalesced.
+func notificationGroupKey(notificationType string, sourceContentType *string, sourceContentID *uuid.UUID) (time.Duration, string) {
+	window, ok := notificationCoalesceWindows[notificationType]
+	if !ok || sourceContentID == nil {
+		return 0, ""
+	}
+
+	contentType := ""
+	if sourceContentType != nil {
+		contentType = *sourceContentType
+	}
+
+	return window, fmt.Sprintf("%s:%s:%s", notificationType, contentType, sourceContentID.String())
+}
+
+// newNotificationAggregate creates the aggregate payload for the first event of a group
+func newNotificationAggregate(actorID *uuid.UUID, actorName string, at time.Time) *models.NotificationAggregate {
+	aggregate := &models.NotificationAggregate{
+		ActorIDs:     []uuid.UUID{},
+		ActorNames:   []string{},
+		TotalEvents:  1,
+		FirstEventAt: at,
+		LastEventAt:  at,
+	}
+	if actorID != nil {
+		aggregate.ActorIDs = append(aggregate.ActorIDs, *actorID)
+		aggregate.ActorNames = append(aggregate.ActorNames, actorName)
+	}
+	return aggregate
+}
+
+// mergeNotificationEvent folds a new event into an existing coalesced notification
+func mergeNotificationEvent(
+	existing *models.Notification,
+	notificationType string,
+	title string,
+	message string,
+	link *string,
+	actorID *uuid.UUID,
+	actorName string,
+	at time.Time,
+) {
+	if existing.AggregateCount < 1 {
+		existing.AggregateCount = 1
+	}
+	if existing.Aggregate == nil {
+		// Row predates coalescing or was written without a payload; seed from the row itself
+		existing.Aggregate = newNotificationAggregate(existing.SourceUserID, "", existing.CreatedAt)
+		existing.Aggregate.TotalEvents = existing.AggregateCount
+	}
+
+	existing.AggregateCount++
+	aggregate := existing.Aggregate
+	aggregate.TotalEvents = existing.AggregateCount
+	aggregate.LastEventAt = at
+
+	if actorID != nil && !containsUUID(aggregate.ActorIDs, *actorID) {
+		if len(aggregate.ActorIDs) < maxAggregateActorNames {
+			aggregate.ActorIDs = append(aggregate.ActorIDs, *actorID)
+			aggregate.ActorNames = append(aggregate.ActorNames, actorName)
+		} else {
+			aggregate.OthersCount++
+		}
+	}
+
+	existing.Message = message
+	if link != nil {
+		existing.Link = link
+	}
+	if actorID != nil {
+		existing.SourceUserID = actorID
+	}
+	existing.Title = aggregateNotificationTitle(notificationType, aggregate, title)
+}
+
+// aggregateNotificationTitle renders the title of a coalesced notification, falling back
+// to the latest event's title for types that are not actor-driven.
+func aggregateNotificationTitle(notificationType string, aggregate *models.NotificationAggregate, fallback string) string {
+	action, ok := coalescedNotificationActions[notificationType]
+	if !ok || aggregate == nil || len(aggregate.ActorNames) == 0 {
+		return fallback
+	}
+
+	names := make([]string, 0, maxAggregateTitleNames)
+	for _, name := range aggregate.ActorNames {
+		if len(names) == maxAggregateTitleNames {
+			break
+		}
+		if name == "" {
+			name = "Someone"
+		}
+		names = append(names, name)
+	}
+	more := aggregate.OthersCount + len(aggregate.ActorNames) - len(names)
+
+	var actors string
+	switch {
+	case more > 0:
+		actors = fmt.Sprintf("%s and +%d more", strings.Join(names, ", "), more)
+	case len(names) > 1:
+		actors = strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+	default:
+		actors = names[0]
+	}
+
+	return fmt.Sprintf("%s %s", actors, action)
+}
+
+func containsUUID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, existing := range ids {
+		if existing == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+func TestNotificationGroupKey(t *testing.T) {
+	clipID := uuid.New()
+	contentType := "clip"
+
+	window, key := notificationGroupKey(models.NotificationTypeClipComment, &contentType, &clipID)
+	assert.Equal(t, time.Hour, window)
+	assert.Equal(t, fmt.Sprintf("clip_comment:clip:%s", clipID), key)
+
+	// Same user, type and content always maps to the same key
+	_, again := notificationGroupKey(models.NotificationTypeClipComment, &contentType, &clipID)
+	assert.Equal(t, key, again)
+
+	// Different content gets a different key
+	otherID := uuid.New()
+	_, other := notificationGroupKey(models.NotificationTypeClipComment, &contentType, &otherID)
+	assert.NotEqual(t, key, other)
+
+	// Types that are never coalesced
+	_, key = notificationGroupKey(models.NotificationTypeBan, &contentType, &clipID)
+	assert.Empty(t, key)
+
+	// Coalescable types without source content are not grouped
+	_, key = notificationGroupKey(models.NotificationTypeClipComment, &contentType, nil)
+	assert.Empty(t, key)
+}
+
+func newCoalescedClipComment(actorID uuid.UUID, actorName string, at time.Time) *models.Notification {
+	clipID := uuid.New()
+	contentType := "clip"
+	_, key := notificationGroupKey(models.NotificationTypeClipComment, &contentType, &clipID)
+	return &models.Notification{
+		ID:                uuid.New(),
+		Type:              models.NotificationTypeClipComment,
+		Title:             actorName + " commented on your clip",
+		Message:           "\"Insane clutch\"",
+		CreatedAt:         at,
+		SourceUserID:      &actorID,
+		SourceContentID:   &clipID,
+		SourceContentType: &contentType,
+		GroupKey:          &key,
+		AggregateCount:    1,
+		Aggregate:         newNotificationAggregate(&actorID, actorName, at),
+	}
+}
+
+func TestMergeNotificationEvent_BurstOfDistinctActors(t *testing.T) {
+	start := time.Now()
+	n := newCoalescedClipComment(uuid.New(), "alice", start)
+
+	bob := uuid.New()
+	mergeNotificationEvent(n, n.Type, "bob commented on your clip", n.Message, nil, &bob, "bob", start.Add(time.Second))
+	assert.Equal(t, "alice and bob commented on your clip", n.Title)
+
+	// A viral clip: 482 more distinct commenters in quick succession
+	var last time.Time
+	for i := 0; i < 482; i++ {
+		actor := uuid.New()
+		last = start.Add(time.Duration(i+2) * time.Second)
+		mergeNotificationEvent(n, n.Type, "x commented on your clip", n.Message, nil, &actor, fmt.Sprintf("user%d", i), last)
+	}
+
+	require.NotNil(t, n.Aggregate)
+	assert.Equal(t, 484, n.AggregateCount)
+	assert.Equal(t, 484, n.Aggregate.TotalEvents)
+	assert.Len(t, n.Aggregate.ActorIDs, maxAggregateActorNames)
+	assert.Equal(t, 481, n.Aggregate.OthersCount)
+	assert.Equal(t, start, n.Aggregate.FirstEventAt)
+	assert.Equal(t, last, n.Aggregate.LastEventAt)
+	assert.Equal(t, "alice, bob and +482 more commented on your clip", n.Title)
+}
+
+func TestMergeNotificationEvent_RepeatActor(t *testing.T) {
+	start := time.Now()
+	alice := uuid.New()
+	n := newCoalescedClipComment(alice, "alice", start)
+
+	for i := 0; i < 10; i++ {
+		mergeNotificationEvent(n, n.Type, "alice commented on your clip", n.Message, nil, &alice, "alice", start.Add(time.Minute))
+	}
+
+	assert.Equal(t, 11, n.AggregateCount)
+	assert.Len(t, n.Aggregate.ActorIDs, 1)
+	assert.Zero(t, n.Aggregate.OthersCount)
+	assert.Equal(t, "alice commented on your clip", n.Title)
+}
+
+func TestMergeNotificationEvent_Milestones(t *testing.T) {
+	start := time.Now()
+	commentID := uuid.New()
+	contentType := "comment"
+	_, key := notificationGroupKey(models.NotificationTypeVoteMilestone, &contentType, &commentID)
+	n := &models.Notification{
+		Type:            models.NotificationTypeVoteMilestone,
+		Title:           "Your comment received 10 upvotes!",
+		CreatedAt:       start,
+		SourceContentID: &commentID,
+		GroupKey:        &key,
+		AggregateCount:  1,
+		Aggregate:       newNotificationAggregate(nil, "", start),
+	}
+
+	for _, score := range []int{25, 50, 100} {
+		mergeNotificationEvent(n, n.Type, fmt.Sprintf("Your comment received %d upvotes!", score), n.Message, nil, nil, "", start)
+	}
+
+	assert.Equal(t, 4, n.AggregateCount)
+	assert.Empty(t, n.Aggregate.ActorIDs)
+	assert.Equal(t, "Your comment received 100 upvotes!", n.Title)
+}
+
+func TestMergeNotificationEvent_LegacyRowWithoutAggregate(t *testing.T) {
+	start := time.Now()
+	n := newCoalescedClipComment(uuid.New(), "alice", start)
+	n.Aggregate = nil
+	n.AggregateCount = 0
+
+	bob := uuid.New()
+	mergeNotificationEvent(n, n.Type, "bob commented on your clip", n.Message, nil, &bob, "bob", start.Add(time.Second))
+
+	assert.Equal(t, 2, n.AggregateCount)
+	require.NotNil(t, n.Aggregate)
+	assert.Equal(t, 2, n.Aggregate.TotalEvents)
+	assert.Equal(t, "Someone and bob commented on your clip", n.Title)
+	assert.Equal(t, &bob, n.SourceUserID)
+}
+
+func TestAggregateNotificationTitle(t *testing.T) {
+	tests := []struct {
+		name      string
+		aggregate *models.NotificationAggregate
+		expected  string
+	}{
+		{
+			name:      "single actor",
+			aggregate: &models.NotificationAggregate{ActorNames: []string{"alice"}},
+			expected:  "alice replied to your comment",
+		},
+		{
+			name:      "two actors",
+			aggregate: &models.NotificationAggregate{ActorNames: []string{"alice", "bob"}},
+			expected:  "alice and bob replied to your comment",
+		},
+		{
+			name:      "three named actors",
+			aggregate: &models.NotificationAggregate{ActorNames: []string{"alice", "bob", "carol"}},
+			expected:  "alice, bob and +1 more replied to your comment",
+		},
+		{
+			name:      "many others",
+			aggregate: &models.NotificationAggregate{ActorNames: []string{"alice", "bob", "carol"}, OthersCount: 482},
+			expected:  "alice, bob and +483 more replied to your comment",
+		},
+		{
+			name:      "no actors falls back",
+			aggregate: &models.NotificationAggregate{},
+			expected:  "fallback",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, aggregateNotificationTitle(models.NotificationTypeReply, tt.aggregate, "fallback"))
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// notificationDigestEmailData is the view rendered by the digest templates
+type notificationDigestEmailData struct {
+	Title            string
+	Frequency        string
+	Period           string
+	Total            int
+	Sections         []models.NotificationDigestSection
+	Omitted          int
+	NotificationsURL string
+	UnsubscribeURL   string
+	SettingsURL      string
+}
+
+var notificationDigestHTMLTemplate = htmltemplate.Must(htmltemplate.New("notification_digest_html").Parse(`<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{.Title}}</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333; max-width: 600px; margin: 0 auto; padding: 20px;">
+    <div style="background: linear-gradient(135deg, #667eea 0%, #764ba2 100%); padding: 30px; text-align: center; border-radius: 10px 10px 0 0;">
+        <h1 style="color: white; margin: 0; font-size: 24px;">📬 {{.Title}}</h1>
+        <p style="color: white; margin: 10px 0 0 0; opacity: 0.9;">{{.Period}}</p>
+    </div>
+
+    <div style="background: #f9f9f9; padding: 30px; border-radius: 0 0 10px 10px;">
+        <p style="font-size: 16px; margin-bottom: 20px;">
+            You have <strong>{{.Total}}</strong> unread notification{{if ne .Total 1}}s{{end}}.
+        </p>
+{{range .Sections}}
+        <h2 style="font-size: 18px; color: #667eea; margin: 25px 0 10px 0;">{{.Heading}}</h2>
+{{range .Items}}
+        <div style="background: white; padding: 15px 20px; border-left: 4px solid #667eea; margin: 10px 0; border-radius: 5px;">
+            <p style="margin: 0; font-weight: bold;">{{if .Link}}<a href="{{.Link}}" style="color: #333; text-decoration: none;">{{.Title}}</a>{{else}}{{.Title}}{{end}}{{if gt .Count 1}} <span style="color: #999; font-weight: normal;">×{{.Count}}</span>{{end}}</p>
+            {{if .Message}}<p style="margin: 5px 0 0 0; color: #666;">{{.Message}}</p>{{end}}
+        </div>
+{{end}}{{end}}
+{{if .Omitted}}
+        <p style="color: #666;">And {{.Omitted}} more.</p>
+{{end}}
+        <p style="text-align: center; margin-top: 30px;">
+            <a href="{{.NotificationsURL}}" style="display: inline-block; background: #667eea; color: white; padding: 12px 30px; text-decoration: none; border-radius: 5px; font-weight: bold;">View All Notifications</a>
+        </p>
+
+        <hr style="border: none; border-top: 1px solid #ddd; margin: 30px 0;">
+
+        <p style="font-size: 12px; color: #999; text-align: center;">
+            You're receiving this because you chose a {{.Frequency}} email digest.<br>
+            {{if .UnsubscribeURL}}<a href="{{.UnsubscribeURL}}" style="color: #667eea; text-decoration: none;">Unsubscribe</a> | {{end}}
+            <a href="{{.SettingsURL}}" style="color: #667eea; text-decoration: none;">Manage Preferences</a>
+        </p>
+    </div>
+</body>
+</html>
+`))
+
+var notificationDigestTextTemplate = texttemplate.Must(texttemplate.New("notification_digest_text").Parse(`{{.Title}}
+{{.Period}}
+
+You have {{.Total}} unread notification{{if ne .Total 1}}s{{end}}.
+{{range .Sections}}
+{{.Heading}}
+{{range .Items}}
+- {{.Title}}{{if gt .Count 1}} (x{{.Count}}){{end}}{{if .Message}}
+  {{.Message}}{{end}}{{if .Link}}
+  {{.Link}}{{end}}
+{{end}}{{end}}{{if .Omitted}}
+And {{.Omitted}} more.
+{{end}}
+View all notifications: {{.NotificationsURL}}
+
+---
+{{if .UnsubscribeURL}}Unsubscribe: {{.UnsubscribeURL}}
+{{end}}Manage preferences: {{.SettingsURL}}
+`))
+
+// SendNotificationDigest sends a daily or weekly summary of unread notifications.
+// Preferences are checked by the caller, and the hourly rate limit does not apply.
+func (s *EmailService) SendNotificationDigest(ctx context.Context, user *models.User, content *models.NotificationDigestContent) error {
+	if !s.enabled {
+		return nil // Email service disabled
+	}
+	if user.Email == nil || *user.Email == "" {
+		return nil // User has no email
+	}
+
+	digestType := models.NotificationTypeDigest
+	unsubToken, err := s.generateUnsubscribeToken(ctx, user.ID, &digestType)
+	if err != nil {
+		// Continue without unsubscribe link
+		unsubToken = ""
+	}
+
+	subject, htmlBody, textBody, err := s.prepareNotificationDigestEmail(content, unsubToken)
+	if err != nil {
+		return fmt.Errorf("failed to prepare digest email: %w", err)
+	}
+
+	logEntry := &models.EmailNotificationLog{
+		ID:               uuid.New(),
+		UserID:           user.ID,
+		NotificationType: models.NotificationTypeDigest,
+		RecipientEmail:   *user.Email,
+		Subject:          subject,
+		Status:           models.EmailStatusPending,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+	if err := s.repo.CreateLog(ctx, logEntry); err != nil {
+		return fmt.Errorf("failed to create email log: %w", err)
+	}
+
+	htmlBody, textBody = s.trackLinks(ctx, EmailLinkContext{
+		Template:          models.NotificationTypeDigest,
+		Recipient:         *user.Email,
+		UserID:            &user.ID,
+		NotificationLogID: &logEntry.ID,
+	}, htmlBody, textBody)
+
+	messageID, err := s.sendViaSendGrid(*user.Email, subject, htmlBody, textBody)
+	if err != nil {
+		logEntry.Status = models.EmailStatusFailed
+		errMsg := err.Error()
+		logEntry.ErrorMessage = &errMsg
+		logEntry.UpdatedAt = time.Now()
+		_ = s.repo.UpdateLog(ctx, logEntry)
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	logEntry.Status = models.EmailStatusSent
+	logEntry.ProviderMessageID = &messageID
+	now := time.Now()
+	logEntry.SentAt = &now
+	logEntry.UpdatedAt = now
+	if err := s.repo.UpdateLog(ctx, logEntry); err != nil {
+		s.logger.Error("Failed to update email log after successful send", err, map[string]interface{}{
+			"log_id":  logEntry.ID.String(),
+			"user_id": user.ID.String(),
+		})
+	}
+
+	return nil
+}
+
+// prepareNotificationDigestEmail renders the digest subject and bodies
+func (s *EmailService) prepareNotificationDigestEmail(content *models.NotificationDigestContent, unsubToken string) (subject, htmlBody, textBody string, err error) {
+	data := notificationDigestEmailData{
+		Frequency:        content.Frequency,
+		Total:            content.Total,
+		Omitted:          content.Omitted,
+		NotificationsURL: s.baseURL + "/notifications",
+		SettingsURL:      s.baseURL + "/settings",
+	}
+	if unsubToken != "" {
+		data.UnsubscribeURL = fmt.Sprintf("%s/api/v1/notifications/unsubscribe?token=%s", s.baseURL, unsubToken)
+	}
+
+	if content.Frequency == models.EmailDigestWeekly {
+		data.Title = "Your weekly clpr digest"
+		data.Period = fmt.Sprintf("%s – %s", content.PeriodStart.Format("Jan 2"), content.PeriodEnd.AddDate(0, 0, -1).Format("Jan 2, 2006"))
+	} else {
+		data.Title = "Your daily clpr digest"
+		data.Period = content.PeriodStart.Format("Monday, Jan 2, 2006")
+	}
+
+	// Notification links are site paths; emails need absolute URLs
+	data.Sections = make([]models.NotificationDigestSection, len(content.Sections))
+	for i, section := range content.Sections {
+		items := make([]models.NotificationDigestItem, len(section.Items))
+		for j, item := range section.Items {
+			if strings.HasPrefix(item.Link, "/") {
+				item.Link = s.baseURL + item.Link
+			}
+			items[j] = item
+		}
+		data.Sections[i] = models.NotificationDigestSection{Heading: section.Heading, Items: items}
+	}
+
+	var htmlBuf, textBuf bytes.Buffer
+	if err := notificationDigestHTMLTemplate.Execute(&htmlBuf, data); err != nil {
+		return "", "", "", err
+	}
+	if err := notificationDigestTextTemplate.Execute(&textBuf, data); err != nil {
+		return "", "", "", err
+	}
+
+	plural := "s"
+	if content.Total == 1 {
+		plural = ""
+	}
+	subject = fmt.Sprintf("%s: %d new notification%s", data.Title, content.Total, plural)
+
+	return subject, htmlBuf.String(), textBuf.String(), nil
+}
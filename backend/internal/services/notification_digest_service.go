@@ -0,0 +1,356 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const (
+	// notificationDigestBatchSize caps how many users get a digest per run
+	notificationDigestBatchSize = 200
+	// notificationDigestMaxAttempts is how many times a failed digest is retried
+	notificationDigestMaxAttempts = 3
+	// notificationDigestMaxNotifications caps how many notifications are read per digest
+	notificationDigestMaxNotifications = 200
+	// notificationDigestMaxItems caps how many items are shown in a digest email
+	notificationDigestMaxItems = 25
+)
+
+// ErrInvalidDigestFrequency is returned for digest frequencies other than daily and weekly
+var ErrInvalidDigestFrequency = errors.New("digest frequency must be daily or weekly")
+
+// Digest email sections, in display order. Notification types not listed here
+// are not digested: security, billing, moderation and export emails are sent
+// immediately whatever the digest preference.
+var notificationDigestSections = []struct {
+	heading string
+	types   []string
+}{
+	{
+		heading: "Replies & mentions",
+		types: []string{
+			models.NotificationTypeReply,
+			models.NotificationTypeMention,
+			models.NotificationTypeDiscussionReply,
+			models.NotificationTypeCommentOnContent,
+			models.NotificationTypeClipComment,
+			models.NotificationTypeFavoritedClipComment,
+		},
+	},
+	{
+		heading: "Your clips",
+		types: []string{
+			models.NotificationTypeSubmissionApproved,
+			models.NotificationTypeSubmissionRejected,
+			models.NotificationTypeSubmissionBroadcasterApproved,
+			models.NotificationTypeContentTrending,
+			models.NotificationTypeVoteMilestone,
+			models.NotificationTypeCommentReaction,
+			models.NotificationTypeClipVoteThreshold,
+			models.NotificationTypeClipViewThreshold,
+		},
+	},
+	{
+		heading: "Community",
+		types: []string{
+			models.NotificationTypeUserFollowed,
+			models.NotificationTypeFeedInvite,
+			models.NotificationTypeBadgeEarned,
+			models.NotificationTypeAchievementCompleted,
+			models.NotificationTypeRankUp,
+			models.NotificationTypeModeratorMessage,
+			models.NotificationTypeBroadcasterLive,
+			models.NotificationTypeStreamLive,
+		},
+	},
+	{
+		heading: "News",
+		types: []string{
+			models.NotificationTypePlatformAnnouncement,
+			models.NotificationTypeMarketing,
+		},
+	},
+}
+
+// notificationDigestSectionIndex maps each digested notification type to its section
+var notificationDigestSectionIndex = func() map[string]int {
+	index := map[string]int{}
+	for i, section := range notificationDigestSections {
+		for _, t := range section.types {
+			index[t] = i
+		}
+	}
+	return index
+}()
+
+// heldForDigest reports whether an email of this type waits for the user's daily or weekly digest
+func heldForDigest(prefs *models.NotificationPreferences, notificationType string) bool {
+	if prefs.EmailDigest != models.EmailDigestDaily && prefs.EmailDigest != models.EmailDigestWeekly {
+		return false
+	}
+	_, ok := notificationDigestSectionIndex[notificationType]
+	return ok
+}
+
+// NotificationDigestRepositoryInterface defines the repository methods used by NotificationDigestService
+type NotificationDigestRepositoryInterface interface {
+	ListDueUserIDs(ctx context.Context, frequency string, periodStart, periodEnd time.Time, maxAttempts, limit int) ([]uuid.UUID, error)
+	Claim(ctx context.Context, digest *models.NotificationDigest, maxAttempts int) (bool, error)
+	ListUnreadForDigest(ctx context.Context, userID uuid.UUID, periodStart, periodEnd time.Time, limit int) ([]models.Notification, error)
+	Complete(ctx context.Context, id uuid.UUID, status string, notificationCount int, errorMessage *string) error
+}
+
+// NotificationPreferencesReader reads a user's notification preferences
+type NotificationPreferencesReader interface {
+	GetPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error)
+}
+
+// NotificationDigestUserReader reads the recipient of a digest
+type NotificationDigestUserReader interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+}
+
+// NotificationDigestSender sends a rendered digest email
+type NotificationDigestSender interface {
+	SendNotificationDigest(ctx context.Context, user *models.User, content *models.NotificationDigestContent) error
+}
+
+// NotificationDigestService batches unread notifications into daily or weekly
+// summary emails for users whose email_digest preference asks for them
+type NotificationDigestService struct {
+	repo      NotificationDigestRepositoryInterface
+	prefsRepo NotificationPreferencesReader
+	userRepo  NotificationDigestUserReader
+	sender    NotificationDigestSender
+	throttle  BulkEmailThrottle
+}
+
+// NewNotificationDigestService creates a new NotificationDigestService
+func NewNotificationDigestService(
+	repo NotificationDigestRepositoryInterface,
+	prefsRepo NotificationPreferencesReader,
+	userRepo NotificationDigestUserReader,
+	sender NotificationDigestSender,
+) *NotificationDigestService {
+	return &NotificationDigestService{
+		repo:      repo,
+		prefsRepo: prefsRepo,
+		userRepo:  userRepo,
+		sender:    sender,
+	}
+}
+
+// DigestPeriod returns the most recent complete digest period before now. Daily
+// periods end at midnight UTC and weekly periods at midnight UTC on Monday.
+func DigestPeriod(frequency string, now time.Time) (start, end time.Time, err error) {
+	now = now.UTC()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch frequency {
+	case models.EmailDigestDaily:
+		return midnight.AddDate(0, 0, -1), midnight, nil
+	case models.EmailDigestWeekly:
+		daysSinceMonday := (int(midnight.Weekday()) + 6) % 7
+		end = midnight.AddDate(0, 0, -daysSinceMonday)
+		return end.AddDate(0, 0, -7), end, nil
+	default:
+		return time.Time{}, time.Time{}, ErrInvalidDigestFrequency
+	}
+}
+
+// SetThrottle sets the bulk email throttle the service obeys
+func (s *NotificationDigestService) SetThrottle(throttle BulkEmailThrottle) {
+	s.throttle = throttle
+}
+
+// SendDueDigests sends the digests of the most recent complete period to users
+// who have not had one yet, returning how many were sent. When the throttle
+// stops sends, the rest stay due for a later run.
+func (s *NotificationDigestService) SendDueDigests(ctx context.Context, frequency string, now time.Time) (int, error) {
+	periodStart, periodEnd, err := DigestPeriod(frequency, now)
+	if err != nil {
+		return 0, err
+	}
+
+	userIDs, err := s.repo.ListDueUserIDs(ctx, frequency, periodStart, periodEnd, notificationDigestMaxAttempts, notificationDigestBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list users due a digest: %w", err)
+	}
+
+	sent := 0
+	for _, userID := range userIDs {
+		if ctx.Err() != nil {
+			return sent, ctx.Err()
+		}
+
+		decision, err := checkBulkEmail(ctx, s.throttle, s.prefsRepo, userID)
+		if err != nil {
+			return sent, fmt.Errorf("failed to check email throttle: %w", err)
+		}
+		if decision == BulkEmailThrottled {
+			return sent, nil
+		}
+		if decision == BulkEmailOutsideWindow {
+			continue
+		}
+
+		digest := &models.NotificationDigest{
+			UserID:      userID,
+			Frequency:   frequency,
+			PeriodStart: periodStart,
+			PeriodEnd:   periodEnd,
+		}
+		ok, err := s.sendDigest(ctx, digest)
+		if err != nil {
+			utils.GetLogger().Error("Failed to send notification digest", err, map[string]interface{}{
+				"user_id":   userID.String(),
+				"frequency": frequency,
+			})
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+// sendDigest claims, builds and sends one digest, reporting whether an email was sent
+func (s *NotificationDigestService) sendDigest(ctx context.Context, digest *models.NotificationDigest) (bool, error) {
+	claimed, err := s.repo.Claim(ctx, digest, notificationDigestMaxAttempts)
+	if err != nil {
+		return false, err
+	}
+	if !claimed {
+		// Already handled for this period, possibly by another instance
+		return false, nil
+	}
+
+	status, count, sendErr := s.deliver(ctx, digest)
+
+	var errMsg *string
+	if sendErr != nil {
+		msg := sendErr.Error()
+		errMsg = &msg
+	}
+	if err := s.repo.Complete(ctx, digest.ID, status, count, errMsg); err != nil {
+		return false, err
+	}
+	if sendErr != nil {
+		return false, sendErr
+	}
+	return status == models.NotificationDigestStatusSent, nil
+}
+
+// deliver builds and sends a claimed digest, returning its final status and notification count
+func (s *NotificationDigestService) deliver(ctx context.Context, digest *models.NotificationDigest) (string, int, error) {
+	prefs, err := s.prefsRepo.GetPreferences(ctx, digest.UserID)
+	if err != nil {
+		return models.NotificationDigestStatusFailed, 0, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	// Preferences may have changed since the user was listed
+	if !prefs.EmailEnabled || prefs.EmailDigest != digest.Frequency {
+		return models.NotificationDigestStatusSkipped, 0, nil
+	}
+
+	user, err := s.userRepo.GetByID(ctx, digest.UserID)
+	if err != nil {
+		return models.NotificationDigestStatusFailed, 0, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Email == nil || *user.Email == "" {
+		return models.NotificationDigestStatusSkipped, 0, nil
+	}
+
+	notifications, err := s.repo.ListUnreadForDigest(ctx, digest.UserID, digest.PeriodStart, digest.PeriodEnd, notificationDigestMaxNotifications)
+	if err != nil {
+		return models.NotificationDigestStatusFailed, 0, err
+	}
+
+	content := buildNotificationDigest(digest, prefs, notifications)
+	if content.Total == 0 {
+		return models.NotificationDigestStatusSkipped, 0, nil
+	}
+
+	if err := s.sender.SendNotificationDigest(ctx, user, content); err != nil {
+		return models.NotificationDigestStatusFailed, content.Total, err
+	}
+	return models.NotificationDigestStatusSent, content.Total, nil
+}
+
+// buildNotificationDigest groups notifications into digest sections. Types that
+// are not digested or that the user turned off are left out, and notifications
+// about the same thing (same type and link) are folded into one item.
+func buildNotificationDigest(digest *models.NotificationDigest, prefs *models.NotificationPreferences, notifications []models.Notification) *models.NotificationDigestContent {
+	content := &models.NotificationDigestContent{
+		Frequency:   digest.Frequency,
+		PeriodStart: digest.PeriodStart,
+		PeriodEnd:   digest.PeriodEnd,
+	}
+
+	sections := make([][]models.NotificationDigestItem, len(notificationDigestSections))
+	folded := map[string]*models.NotificationDigestItem{}
+	itemKeys := make([][]string, len(notificationDigestSections))
+
+	// Notifications arrive newest first, so each item keeps its latest wording
+	for _, n := range notifications {
+		sectionIdx, ok := notificationDigestSectionIndex[n.Type]
+		if !ok || !emailEnabledForType(prefs, n.Type) {
+			continue
+		}
+
+		count := n.AggregateCount
+		if count < 1 {
+			count = 1
+		}
+		content.Total += count
+
+		key := n.Type + "|" + n.ID.String()
+		if n.Link != nil && *n.Link != "" {
+			key = n.Type + "|" + *n.Link
+		}
+		if item, ok := folded[key]; ok {
+			item.Count += count
+			continue
+		}
+
+		item := &models.NotificationDigestItem{
+			Type:      n.Type,
+			Title:     n.Title,
+			Message:   n.Message,
+			Count:     count,
+			CreatedAt: n.CreatedAt,
+		}
+		if n.Link != nil {
+			item.Link = *n.Link
+		}
+		folded[key] = item
+		itemKeys[sectionIdx] = append(itemKeys[sectionIdx], key)
+	}
+
+	shown := 0
+	for i, keys := range itemKeys {
+		for _, key := range keys {
+			if shown == notificationDigestMaxItems {
+				content.Omitted++
+				continue
+			}
+			sections[i] = append(sections[i], *folded[key])
+			shown++
+		}
+		if len(sections[i]) > 0 {
+			content.Sections = append(content.Sections, models.NotificationDigestSection{
+				Heading: notificationDigestSections[i].heading,
+				Items:   sections[i],
+			})
+		}
+	}
+
+	return content
+}
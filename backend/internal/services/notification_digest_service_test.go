@@ -0,0 +1,281 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// MockNotificationDigestRepository is a mock implementation of NotificationDigestRepositoryInterface
+type MockNotificationDigestRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationDigestRepository) ListDueUserIDs(ctx context.Context, frequency string, periodStart, periodEnd time.Time, maxAttempts, limit int) ([]uuid.UUID, error) {
+	args := m.Called(ctx, frequency, periodStart, periodEnd, maxAttempts, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockNotificationDigestRepository) Claim(ctx context.Context, digest *models.NotificationDigest, maxAttempts int) (bool, error) {
+	args := m.Called(ctx, digest, maxAttempts)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockNotificationDigestRepository) ListUnreadForDigest(ctx context.Context, userID uuid.UUID, periodStart, periodEnd time.Time, limit int) ([]models.Notification, error) {
+	args := m.Called(ctx, userID, periodStart, periodEnd, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Notification), args.Error(1)
+}
+
+func (m *MockNotificationDigestRepository) Complete(ctx context.Context, id uuid.UUID, status string, notificationCount int, errorMessage *string) error {
+	args := m.Called(ctx, id, status, notificationCount, errorMessage)
+	return args.Error(0)
+}
+
+// MockNotificationPreferencesReader is a mock implementation of NotificationPreferencesReader
+type MockNotificationPreferencesReader struct {
+	mock.Mock
+}
+
+func (m *MockNotificationPreferencesReader) GetPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.NotificationPreferences), args.Error(1)
+}
+
+// MockNotificationDigestSender is a mock implementation of NotificationDigestSender
+type MockNotificationDigestSender struct {
+	mock.Mock
+}
+
+func (m *MockNotificationDigestSender) SendNotificationDigest(ctx context.Context, user *models.User, content *models.NotificationDigestContent) error {
+	args := m.Called(ctx, user, content)
+	return args.Error(0)
+}
+
+// setupNotificationDigestServiceTest builds a NotificationDigestService over mocks
+func setupNotificationDigestServiceTest() (*NotificationDigestService, *MockNotificationDigestRepository, *MockNotificationPreferencesReader, *MockUserRepository, *MockNotificationDigestSender) {
+	repo := new(MockNotificationDigestRepository)
+	prefs := new(MockNotificationPreferencesReader)
+	users := new(MockUserRepository)
+	sender := new(MockNotificationDigestSender)
+	return NewNotificationDigestService(repo, prefs, users, sender), repo, prefs, users, sender
+}
+
+// expectEmailRecipients has the user repository return a user with an email address for each ID
+func expectEmailRecipients(users *MockUserRepository, userIDs ...uuid.UUID) {
+	email := "viewer@example.com"
+	for _, userID := range userIDs {
+		users.On("GetByID", mock.Anything, userID).Return(&models.User{ID: userID, Email: &email}, nil)
+	}
+}
+
+// expectDigestClaim has the repository claim (or find already claimed) the user's digest
+func expectDigestClaim(repo *MockNotificationDigestRepository, userID uuid.UUID, claimed bool) {
+	repo.On("Claim", mock.Anything, mock.MatchedBy(func(digest *models.NotificationDigest) bool {
+		return digest.UserID == userID
+	}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			digest := args.Get(1).(*models.NotificationDigest)
+			digest.ID = digest.UserID
+		}).
+		Return(claimed, nil).Once()
+}
+
+func digestPrefs(frequency string) *models.NotificationPreferences {
+	return &models.NotificationPreferences{
+		EmailEnabled:  true,
+		EmailDigest:   frequency,
+		NotifyReplies: true,
+		NotifyBadges:  true,
+	}
+}
+
+func digestNotification(notificationType, link string, count int) models.Notification {
+	n := models.Notification{
+		ID:             uuid.New(),
+		Type:           notificationType,
+		Title:          notificationType + " title",
+		AggregateCount: count,
+		CreatedAt:      time.Now(),
+	}
+	if link != "" {
+		n.Link = &link
+	}
+	return n
+}
+
+func TestDigestPeriod(t *testing.T) {
+	// Thursday afternoon UTC
+	now := time.Date(2026, 10, 15, 14, 30, 0, 0, time.UTC)
+
+	start, end, err := DigestPeriod(models.EmailDigestDaily, now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC), end)
+
+	start, end, err = DigestPeriod(models.EmailDigestWeekly, now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), end)
+
+	_, _, err = DigestPeriod(models.EmailDigestImmediate, now)
+	assert.ErrorIs(t, err, ErrInvalidDigestFrequency)
+}
+
+func TestHeldForDigest(t *testing.T) {
+	assert.True(t, heldForDigest(digestPrefs(models.EmailDigestDaily), models.NotificationTypeReply))
+	assert.False(t, heldForDigest(digestPrefs(models.EmailDigestImmediate), models.NotificationTypeReply))
+	// Security and billing emails are never held
+	assert.False(t, heldForDigest(digestPrefs(models.EmailDigestWeekly), models.NotificationTypeLoginNewDevice))
+	assert.False(t, heldForDigest(digestPrefs(models.EmailDigestWeekly), models.NotificationTypePaymentFailed))
+}
+
+func TestBuildNotificationDigest(t *testing.T) {
+	notifications := []models.Notification{
+		digestNotification(models.NotificationTypeBadgeEarned, "", 1),
+		digestNotification(models.NotificationTypeReply, "/clips/1#c", 2),
+		digestNotification(models.NotificationTypeReply, "/clips/1#c", 1),
+		digestNotification(models.NotificationTypeReply, "/clips/2", 0),
+		// Turned off by the user
+		digestNotification(models.NotificationTypeMention, "/clips/3", 1),
+		// Sent immediately instead
+		digestNotification(models.NotificationTypeLoginNewDevice, "", 1),
+	}
+
+	content := buildNotificationDigest(&models.NotificationDigest{Frequency: models.EmailDigestDaily}, digestPrefs(models.EmailDigestDaily), notifications)
+
+	assert.Equal(t, 5, content.Total)
+	require.Len(t, content.Sections, 2)
+	assert.Equal(t, "Replies & mentions", content.Sections[0].Heading)
+	require.Len(t, content.Sections[0].Items, 2)
+	assert.Equal(t, 3, content.Sections[0].Items[0].Count, "same type and link fold into one item")
+	assert.Equal(t, 1, content.Sections[0].Items[1].Count)
+	assert.Equal(t, "Community", content.Sections[1].Heading)
+	assert.Equal(t, 0, content.Omitted)
+}
+
+func TestBuildNotificationDigest_CapsItems(t *testing.T) {
+	notifications := []models.Notification{}
+	for i := 0; i < notificationDigestMaxItems+5; i++ {
+		notifications = append(notifications, digestNotification(models.NotificationTypeReply, "", 1))
+	}
+
+	content := buildNotificationDigest(&models.NotificationDigest{}, digestPrefs(models.EmailDigestDaily), notifications)
+
+	assert.Equal(t, notificationDigestMaxItems+5, content.Total)
+	assert.Len(t, content.Sections[0].Items, notificationDigestMaxItems)
+	assert.Equal(t, 5, content.Omitted)
+}
+
+func TestNotificationDigestService_SendDueDigests(t *testing.T) {
+	svc, repo, prefs, users, sender := setupNotificationDigestServiceTest()
+	ctx := context.Background()
+	withDigest, switchedToImmediate, nothingToSend := uuid.New(), uuid.New(), uuid.New()
+	notifications := []models.Notification{digestNotification(models.NotificationTypeReply, "/clips/1", 1)}
+
+	prefs.On("GetPreferences", ctx, withDigest).Return(digestPrefs(models.EmailDigestDaily), nil)
+	prefs.On("GetPreferences", ctx, switchedToImmediate).Return(digestPrefs(models.EmailDigestImmediate), nil)
+	prefs.On("GetPreferences", ctx, nothingToSend).Return(&models.NotificationPreferences{EmailEnabled: true, EmailDigest: models.EmailDigestDaily}, nil)
+	expectEmailRecipients(users, withDigest, nothingToSend)
+	repo.On("ListUnreadForDigest", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(notifications, nil)
+
+	repo.On("ListDueUserIDs", ctx, models.EmailDigestDaily, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]uuid.UUID{withDigest, switchedToImmediate}, nil).Once()
+	expectDigestClaim(repo, withDigest, true)
+	expectDigestClaim(repo, switchedToImmediate, true)
+	sender.On("SendNotificationDigest", ctx, mock.Anything, mock.Anything).Return(nil).Once()
+	repo.On("Complete", ctx, withDigest, models.NotificationDigestStatusSent, 1, (*string)(nil)).Return(nil).Once()
+	repo.On("Complete", ctx, switchedToImmediate, models.NotificationDigestStatusSkipped, 0, (*string)(nil)).Return(nil).Once()
+
+	sent, err := svc.SendDueDigests(ctx, models.EmailDigestDaily, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+
+	// Replies turned off leaves nothing to send
+	repo.On("ListDueUserIDs", ctx, models.EmailDigestDaily, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]uuid.UUID{nothingToSend}, nil).Once()
+	expectDigestClaim(repo, nothingToSend, true)
+	repo.On("Complete", ctx, nothingToSend, models.NotificationDigestStatusSkipped, 0, (*string)(nil)).Return(nil).Once()
+
+	sent, err = svc.SendDueDigests(ctx, models.EmailDigestDaily, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+
+	// A digest already claimed for the period is not sent again
+	repo.On("ListDueUserIDs", ctx, models.EmailDigestDaily, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]uuid.UUID{withDigest}, nil).Once()
+	expectDigestClaim(repo, withDigest, false)
+
+	sent, err = svc.SendDueDigests(ctx, models.EmailDigestDaily, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+
+	repo.AssertExpectations(t)
+	sender.AssertNumberOfCalls(t, "SendNotificationDigest", 1)
+	repo.AssertNumberOfCalls(t, "Complete", 3)
+}
+
+func TestNotificationDigestService_RecordsFailures(t *testing.T) {
+	svc, repo, prefs, users, sender := setupNotificationDigestServiceTest()
+	ctx := context.Background()
+	userID := uuid.New()
+
+	repo.On("ListDueUserIDs", ctx, models.EmailDigestWeekly, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]uuid.UUID{userID}, nil)
+	expectDigestClaim(repo, userID, true)
+	prefs.On("GetPreferences", ctx, userID).Return(digestPrefs(models.EmailDigestWeekly), nil)
+	expectEmailRecipients(users, userID)
+	repo.On("ListUnreadForDigest", ctx, userID, mock.Anything, mock.Anything, mock.Anything).
+		Return([]models.Notification{digestNotification(models.NotificationTypeReply, "", 1)}, nil)
+	sender.On("SendNotificationDigest", ctx, mock.Anything, mock.Anything).Return(errors.New("sendgrid down"))
+	repo.On("Complete", ctx, userID, models.NotificationDigestStatusFailed, 1, mock.MatchedBy(func(msg *string) bool {
+		return msg != nil && *msg == "sendgrid down"
+	})).Return(nil).Once()
+
+	sent, err := svc.SendDueDigests(ctx, models.EmailDigestWeekly, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	repo.AssertExpectations(t)
+}
+
+func TestPrepareNotificationDigestEmail(t *testing.T) {
+	s := &EmailService{baseURL: "https://clpr.tv"}
+	content := &models.NotificationDigestContent{
+		Frequency:   models.EmailDigestWeekly,
+		PeriodStart: time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC),
+		Total:       3,
+		Sections: []models.NotificationDigestSection{{
+			Heading: "Replies & mentions",
+			Items:   []models.NotificationDigestItem{{Title: "<b>sneaky</b> replied", Link: "/clips/1", Count: 3}},
+		}},
+	}
+
+	subject, htmlBody, textBody, err := s.prepareNotificationDigestEmail(content, "tok")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Your weekly clpr digest: 3 new notifications", subject)
+	assert.Contains(t, htmlBody, "Oct 5 – Oct 11, 2026")
+	assert.Contains(t, htmlBody, `href="https://clpr.tv/clips/1"`)
+	assert.Contains(t, htmlBody, "&lt;b&gt;sneaky&lt;/b&gt;")
+	assert.False(t, strings.Contains(htmlBody, "<b>sneaky"))
+	assert.Contains(t, htmlBody, "unsubscribe?token=tok")
+	assert.Contains(t, textBody, "- <b>sneaky</b> replied (x3)")
+	assert.Contains(t, textBody, "https://clpr.tv/clips/1")
+}
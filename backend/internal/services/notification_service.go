@@ -3,12 +3,14 @@ package services
 import (
 	"context"
 	"fmt"
+	"log"
 	"regexp"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/pagination"
 	"github.com/subculture-collective/clipper/internal/repository"
 )
 
@@ -20,6 +22,19 @@ type NotificationService struct {
 	clipRepo     *repository.ClipRepository
 	favoriteRepo *repository.FavoriteRepository
 	emailService *EmailService
+	stream       NotificationStreamPublisher
+	push         NotificationPushSender
+	shadowBans   ShadowBanChecker
+}
+
+// NotificationStreamPublisher pushes notification events to users' open streams
+type NotificationStreamPublisher interface {
+	Publish(ctx context.Context, userID uuid.UUID, event NotificationStreamEvent) error
+}
+
+// NotificationPushSender queues notifications for delivery to users' devices
+type NotificationPushSender interface {
+	Enqueue(notification *models.Notification)
 }
 
 // NewNotificationService creates a new NotificationService
@@ -41,6 +56,21 @@ func NewNotificationService(
 	}
 }
 
+// SetStreamPublisher sets the publisher that pushes notifications to open streams
+func (s *NotificationService) SetStreamPublisher(stream NotificationStreamPublisher) {
+	s.stream = stream
+}
+
+// SetPushSender sets the sender that delivers notifications to registered devices
+func (s *NotificationService) SetPushSender(push NotificationPushSender) {
+	s.push = push
+}
+
+// SetShadowBanChecker drops notifications triggered by shadow-banned users
+func (s *NotificationService) SetShadowBanChecker(shadowBans ShadowBanChecker) {
+	s.shadowBans = shadowBans
+}
+
 // CreateNotification creates a new notification
 func (s *NotificationService) CreateNotification(
 	ctx context.Context,
@@ -53,6 +83,11 @@ func (s *NotificationService) CreateNotification(
 	sourceContentID *uuid.UUID,
 	sourceContentType *string,
 ) (*models.Notification, error) {
+	// Activity of shadow-banned users doesn't reach anyone else
+	if s.shadowBans != nil && sourceUserID != nil && *sourceUserID != userID && s.shadowBans.IsShadowBanned(ctx, *sourceUserID) {
+		return nil, nil
+	}
+
 	// Check user's notification preferences
 	prefs, err := s.repo.GetPreferences(ctx, userID)
 	if err != nil {
@@ -69,6 +104,7 @@ func (s *NotificationService) CreateNotification(
 		return nil, nil // User has disabled this type of notification
 	}
 
+	now := time.Now()
 	notification := &models.Notification{
 		ID:                uuid.New(),
 		UserID:            userID,
@@ -77,18 +113,98 @@ func (s *NotificationService) CreateNotification(
 		Message:           message,
 		Link:              link,
 		IsRead:            false,
-		CreatedAt:         time.Now(),
+		CreatedAt:         now,
 		SourceUserID:      sourceUserID,
 		SourceContentID:   sourceContentID,
 		SourceContentType: sourceContentType,
+		AggregateCount:    1,
+	}
+
+	window, groupKey := notificationGroupKey(notificationType, sourceContentType, sourceContentID)
+	if groupKey == "" {
+		err = s.repo.Create(ctx, notification)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create notification: %w", err)
+		}
+		s.publishNotification(ctx, notification)
+		s.sendPush(prefs, notification)
+		return notification, nil
 	}
 
-	err = s.repo.Create(ctx, notification)
+	actorName := s.actorDisplayName(ctx, sourceUserID)
+	notification.GroupKey = &groupKey
+	notification.Aggregate = newNotificationAggregate(sourceUserID, actorName, now)
+
+	stored, coalesced, err := s.repo.CreateOrCoalesce(ctx, notification, now.Add(-window), func(existing *models.Notification) {
+		mergeNotificationEvent(existing, notificationType, title, message, link, sourceUserID, actorName, now)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create notification: %w", err)
 	}
 
-	return notification, nil
+	// Open streams receive coalesced updates so grouped notifications refresh in place
+	s.publishNotification(ctx, stored)
+
+	// Coalesced events update an existing notification; callers treat them as already
+	// delivered so side effects such as emails are not repeated for every event.
+	if coalesced {
+		return nil, nil
+	}
+
+	s.sendPush(prefs, stored)
+	return stored, nil
+}
+
+// sendPush queues a new notification for push delivery if the user allows push
+func (s *NotificationService) sendPush(prefs *models.NotificationPreferences, notification *models.Notification) {
+	if s.push == nil || !prefs.PushEnabled {
+		return
+	}
+	s.push.Enqueue(notification)
+}
+
+// publishNotification pushes a stored notification and the new unread count to
+// the user's open streams. Streams are best effort; clients fall back to polling.
+func (s *NotificationService) publishNotification(ctx context.Context, notification *models.Notification) {
+	if s.stream == nil || notification == nil {
+		return
+	}
+
+	event := NotificationStreamEvent{Type: NotificationStreamEventNotification, Notification: notification}
+	if err := s.stream.Publish(ctx, notification.UserID, event); err != nil {
+		log.Printf("Failed to publish notification to stream: %v", err)
+	}
+	s.publishUnreadCount(ctx, notification.UserID)
+}
+
+// publishUnreadCount pushes the user's current unread count to their open streams
+func (s *NotificationService) publishUnreadCount(ctx context.Context, userID uuid.UUID) {
+	if s.stream == nil {
+		return
+	}
+
+	count, err := s.repo.CountUnread(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to get unread count for notification stream: %v", err)
+		return
+	}
+
+	event := NotificationStreamEvent{Type: NotificationStreamEventUnreadCount, UnreadCount: &count}
+	if err := s.stream.Publish(ctx, userID, event); err != nil {
+		log.Printf("Failed to publish unread count to stream: %v", err)
+	}
+}
+
+// actorDisplayName resolves the display name of the user that triggered a notification
+func (s *NotificationService) actorDisplayName(ctx context.Context, sourceUserID *uuid.UUID) string {
+	if sourceUserID == nil || s.userRepo == nil {
+		return ""
+	}
+	user, err := s.userRepo.GetByID(ctx, *sourceUserID)
+	if err != nil {
+		return ""
+	}
+	return user.DisplayName
 }
 
 // CreateNotificationWithEmail creates a notification and optionally sends an email
@@ -146,11 +262,11 @@ func (s *NotificationService) shouldNotify(prefs *models.NotificationPreferences
 		return prefs.NotifyEmailChanged
 
 	// Content notifications
-	case models.NotificationTypeReply:
+	case models.NotificationTypeReply, models.NotificationTypeCommentPinned:
 		return prefs.NotifyReplies
 	case models.NotificationTypeMention:
 		return prefs.NotifyMentions
-	case models.NotificationTypeVoteMilestone:
+	case models.NotificationTypeVoteMilestone, models.NotificationTypeCommentReaction:
 		return prefs.NotifyVotes
 	case models.NotificationTypeFavoritedClipComment:
 		return prefs.NotifyFavoritedClipComment
@@ -168,12 +284,13 @@ func (s *NotificationService) shouldNotify(prefs *models.NotificationPreferences
 		return prefs.NotifyCommentOnContent
 	case models.NotificationTypeDiscussionReply:
 		return prefs.NotifyDiscussionReply
-	case models.NotificationTypeBadgeEarned:
+	case models.NotificationTypeBadgeEarned, models.NotificationTypeAchievementCompleted:
 		return prefs.NotifyBadges
 	case models.NotificationTypeRankUp:
 		return prefs.NotifyRankUp
 	case models.NotificationTypeContentRemoved, models.NotificationTypeWarning,
-		models.NotificationTypeBan, models.NotificationTypeAppealDecision:
+		models.NotificationTypeBan, models.NotificationTypeAppealDecision,
+		models.NotificationTypeReportOutcome:
 		return prefs.NotifyModeration
 
 	// Creator-specific notification preferences (including clip submissions)
@@ -181,6 +298,8 @@ func (s *NotificationService) shouldNotify(prefs *models.NotificationPreferences
 		return prefs.NotifyClipApproved
 	case models.NotificationTypeSubmissionRejected:
 		return prefs.NotifyClipRejected
+	case models.NotificationTypeSubmissionAwaitingBroadcaster, models.NotificationTypeSubmissionBroadcasterApproved:
+		return prefs.NotifyClipApproved
 	case models.NotificationTypeClipComment:
 		return prefs.NotifyClipComments
 	case models.NotificationTypeClipViewThreshold, models.NotificationTypeClipVoteThreshold:
@@ -203,26 +322,28 @@ func (s *NotificationService) shouldNotify(prefs *models.NotificationPreferences
 	}
 }
 
-// GetUserNotifications retrieves notifications for a user
+// GetUserNotifications retrieves a page of notifications for a user, after
+// cursor when set and otherwise at offset
 func (s *NotificationService) GetUserNotifications(
 	ctx context.Context,
 	userID uuid.UUID,
 	filter string,
+	cursor *pagination.Cursor,
 	limit, offset int,
-) ([]models.NotificationWithSource, error) {
+) ([]models.NotificationWithSource, pagination.PageInfo, error) {
 	if limit <= 0 || limit > 100 {
 		limit = 50
 	}
-	if offset < 0 {
+	if offset < 0 || cursor != nil {
 		offset = 0
 	}
 
-	notifications, err := s.repo.ListByUserID(ctx, userID, filter, limit, offset)
+	notifications, page, err := s.repo.ListByUserID(ctx, userID, filter, cursor, limit, offset)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user notifications: %w", err)
+		return nil, pagination.PageInfo{}, fmt.Errorf("failed to get user notifications: %w", err)
 	}
 
-	return notifications, nil
+	return notifications, page, nil
 }
 
 // GetUnreadCount returns the count of unread notifications for a user
@@ -242,6 +363,7 @@ func (s *NotificationService) MarkAsRead(ctx context.Context, notificationID uui
 		return fmt.Errorf("failed to mark notification as read: %w", err)
 	}
 
+	s.publishUnreadCount(ctx, userID)
 	return nil
 }
 
@@ -252,6 +374,7 @@ func (s *NotificationService) MarkAllAsRead(ctx context.Context, userID uuid.UUI
 		return fmt.Errorf("failed to mark all notifications as read: %w", err)
 	}
 
+	s.publishUnreadCount(ctx, userID)
 	return nil
 }
 
@@ -262,6 +385,7 @@ func (s *NotificationService) DeleteNotification(ctx context.Context, notificati
 		return fmt.Errorf("failed to delete notification: %w", err)
 	}
 
+	s.publishUnreadCount(ctx, userID)
 	return nil
 }
 
@@ -366,16 +490,16 @@ func (s *NotificationService) getBaseURL() string {
 	return "http://localhost:5173" // fallback
 }
 
-// NotifyMentions notifies users mentioned in a comment
+// NotifyMentions notifies users newly mentioned in a comment. Users who
+// blocked the author, or whom the author blocked, aren't notified.
 func (s *NotificationService) NotifyMentions(
 	ctx context.Context,
 	content string,
 	clipID uuid.UUID,
 	commentAuthorID uuid.UUID,
+	mentionedUserIDs []uuid.UUID,
 ) error {
-	// Extract mentions from content
-	mentions := extractMentions(content)
-	if len(mentions) == 0 {
+	if len(mentionedUserIDs) == 0 {
 		return nil
 	}
 
@@ -398,15 +522,17 @@ func (s *NotificationService) NotifyMentions(
 	}
 
 	// Notify each mentioned user
-	for _, username := range mentions {
-		// Get user by username
-		user, err := s.userRepo.GetByUsername(ctx, username)
-		if err != nil {
-			continue // Skip if user not found
+	for _, userID := range mentionedUserIDs {
+		// Don't notify if mentioning yourself
+		if userID == commentAuthorID {
+			continue
 		}
 
-		// Don't notify if mentioning yourself
-		if user.ID == commentAuthorID {
+		// Respect block lists in both directions
+		if blocked, err := s.userRepo.IsBlocked(ctx, userID, commentAuthorID); err != nil || blocked {
+			continue
+		}
+		if blocked, err := s.userRepo.IsBlocked(ctx, commentAuthorID, userID); err != nil || blocked {
 			continue
 		}
 
@@ -425,7 +551,7 @@ func (s *NotificationService) NotifyMentions(
 		contentType := "comment"
 		_, err = s.CreateNotificationWithEmail(
 			ctx,
-			user.ID,
+			userID,
 			models.NotificationTypeMention,
 			title,
 			message,
@@ -496,6 +622,158 @@ func (s *NotificationService) NotifyVoteMilestone(
 	return err
 }
 
+// NotifyCommentReaction notifies a user when someone reacts to their comment.
+// Reactions to the same comment coalesce into one notification.
+func (s *NotificationService) NotifyCommentReaction(
+	ctx context.Context,
+	comment *repository.CommentWithAuthor,
+	reactorID uuid.UUID,
+) error {
+	// Don't notify if reacting to own comment
+	if comment.UserID == reactorID {
+		return nil
+	}
+
+	// Authors who blocked the reactor don't hear from them
+	if blocked, err := s.userRepo.IsBlocked(ctx, comment.UserID, reactorID); err != nil || blocked {
+		return err
+	}
+
+	reactor, err := s.userRepo.GetByID(ctx, reactorID)
+	if err != nil {
+		return fmt.Errorf("failed to get reactor: %w", err)
+	}
+
+	clip, err := s.clipRepo.GetByID(ctx, comment.ClipID)
+	if err != nil {
+		return fmt.Errorf("failed to get clip: %w", err)
+	}
+
+	title := fmt.Sprintf("%s reacted to your comment", reactor.DisplayName)
+	message := fmt.Sprintf("on \"%s\"", clip.Title)
+	link := fmt.Sprintf("/clips/%s", comment.ClipID.String())
+
+	contentType := "comment"
+	_, err = s.CreateNotification(
+		ctx,
+		comment.UserID,
+		models.NotificationTypeCommentReaction,
+		title,
+		message,
+		&link,
+		&reactorID,
+		&comment.ID,
+		&contentType,
+	)
+
+	return err
+}
+
+// NotifyCommentPinned notifies a user when their comment is pinned to the
+// top of a clip's comments
+func (s *NotificationService) NotifyCommentPinned(
+	ctx context.Context,
+	comment *repository.CommentWithAuthor,
+	pinnerID uuid.UUID,
+) error {
+	// Don't notify if pinning own comment
+	if comment.UserID == pinnerID {
+		return nil
+	}
+
+	clip, err := s.clipRepo.GetByID(ctx, comment.ClipID)
+	if err != nil {
+		return fmt.Errorf("failed to get clip: %w", err)
+	}
+
+	title := "Your comment was pinned"
+	message := fmt.Sprintf("on \"%s\"", clip.Title)
+	link := fmt.Sprintf("/clips/%s", comment.ClipID.String())
+
+	contentType := "comment"
+	_, err = s.CreateNotification(
+		ctx,
+		comment.UserID,
+		models.NotificationTypeCommentPinned,
+		title,
+		message,
+		&link,
+		&pinnerID,
+		&comment.ID,
+		&contentType,
+	)
+
+	return err
+}
+
+// NotifyAppealDecision tells a user whether their appeal of a moderation
+// decision was approved or rejected
+func (s *NotificationService) NotifyAppealDecision(
+	ctx context.Context,
+	userID uuid.UUID,
+	appealID uuid.UUID,
+	approved bool,
+	resolution *string,
+) error {
+	title := "Your appeal was rejected"
+	message := "A moderator reviewed your appeal and upheld the decision"
+	if approved {
+		title = "Your appeal was approved"
+		message = "A moderator reviewed your appeal and agreed with you"
+	}
+	if resolution != nil && *resolution != "" {
+		message = *resolution
+	}
+	link := "/settings"
+
+	contentType := "appeal"
+	_, err := s.CreateNotification(
+		ctx,
+		userID,
+		models.NotificationTypeAppealDecision,
+		title,
+		message,
+		&link,
+		nil,
+		&appealID,
+		&contentType,
+	)
+
+	return err
+}
+
+// NotifyReportOutcome tells a reporter whether action was taken on the item
+// they reported
+func (s *NotificationService) NotifyReportOutcome(
+	ctx context.Context,
+	userID uuid.UUID,
+	reportID uuid.UUID,
+	reportableType string,
+	actioned bool,
+) error {
+	title := "Your report was reviewed"
+	message := fmt.Sprintf("A moderator reviewed the %s you reported and found it doesn't break the community guidelines", reportableType)
+	if actioned {
+		title = "Thanks for your report"
+		message = fmt.Sprintf("A moderator reviewed the %s you reported and took action", reportableType)
+	}
+
+	contentType := "report"
+	_, err := s.CreateNotification(
+		ctx,
+		userID,
+		models.NotificationTypeReportOutcome,
+		title,
+		message,
+		nil,
+		nil,
+		&reportID,
+		&contentType,
+	)
+
+	return err
+}
+
 // NotifyBadgeEarned notifies a user when they earn a badge
 func (s *NotificationService) NotifyBadgeEarned(
 	ctx context.Context,
@@ -521,6 +799,64 @@ func (s *NotificationService) NotifyBadgeEarned(
 	return err
 }
 
+// NotifyAchievementCompleted notifies a user when they complete an
+// achievement, naming the badge it awarded, if any
+func (s *NotificationService) NotifyAchievementCompleted(
+	ctx context.Context,
+	userID uuid.UUID,
+	achievement *models.Achievement,
+) error {
+	title := fmt.Sprintf("Achievement unlocked: %s", achievement.Name)
+	message := achievement.Description
+	if achievement.BadgeID != nil {
+		if badge, err := GetBadgeDefinition(*achievement.BadgeID); err == nil {
+			message = fmt.Sprintf("%s. You earned the %s badge!", achievement.Description, badge.Name)
+		}
+	}
+	link := "/profile"
+
+	_, err := s.CreateNotification(
+		ctx,
+		userID,
+		models.NotificationTypeAchievementCompleted,
+		title,
+		message,
+		&link,
+		nil,
+		nil,
+		nil,
+	)
+
+	return err
+}
+
+// NotifyFeedInvite tells a user they were invited to co-curate a feed
+func (s *NotificationService) NotifyFeedInvite(
+	ctx context.Context,
+	userID uuid.UUID,
+	ownerID uuid.UUID,
+	feed *models.Feed,
+) error {
+	title := "You were invited to curate a feed"
+	message := fmt.Sprintf("Help curate \"%s\"", feed.Name)
+	link := fmt.Sprintf("/feeds/%s", feed.ID.String())
+
+	contentType := "feed"
+	_, err := s.CreateNotification(
+		ctx,
+		userID,
+		models.NotificationTypeFeedInvite,
+		title,
+		message,
+		&link,
+		&ownerID,
+		&feed.ID,
+		&contentType,
+	)
+
+	return err
+}
+
 // NotifyRankUp notifies a user when they rank up
 func (s *NotificationService) NotifyRankUp(
 	ctx context.Context,
@@ -656,6 +992,135 @@ func (s *NotificationService) NotifySubmissionRejected(
 	return err
 }
 
+// NotifyRefreshTokenReuse alerts a user that a used sign-in token was replayed and the affected device was signed out
+func (s *NotificationService) NotifyRefreshTokenReuse(
+	ctx context.Context,
+	userID uuid.UUID,
+	deviceName string,
+	ipAddress string,
+) error {
+	title := "Suspicious sign-in blocked"
+	message := fmt.Sprintf("A sign-in token for your account was reused from %s, so we signed that session out. If this wasn't you, change your password and review your signed-in devices.", deviceName)
+	link := "/settings"
+
+	if ipAddress == "" {
+		ipAddress = "Unknown"
+	}
+	emailData := map[string]interface{}{
+		"DeviceName":       deviceName,
+		"Location":         "Unknown",
+		"IPAddress":        ipAddress,
+		"Timestamp":        time.Now().UTC().Format("Jan 2, 2006 15:04 MST"),
+		"SecureAccountURL": fmt.Sprintf("%s/settings", s.getBaseURL()),
+	}
+
+	_, err := s.CreateNotificationWithEmail(
+		ctx,
+		userID,
+		models.NotificationTypeRefreshTokenReuse,
+		title,
+		message,
+		&link,
+		nil,
+		nil,
+		nil,
+		emailData,
+	)
+
+	return err
+}
+
+// NotifySubmissionAwaitingBroadcaster notifies a user when their submission is held for the broadcaster's approval
+func (s *NotificationService) NotifySubmissionAwaitingBroadcaster(
+	ctx context.Context,
+	submitterID uuid.UUID,
+	submissionID uuid.UUID,
+	clipTitle string,
+	broadcasterName string,
+	autoApproveAt time.Time,
+) error {
+	title := "Your clip is waiting for the broadcaster's approval"
+	message := fmt.Sprintf("%s reviews clips of their channel before they go public. \"%s\" moves on automatically if it isn't reviewed by %s",
+		broadcasterName, clipTitle, autoApproveAt.Format("Jan 2, 2006"))
+	link := "/submissions"
+
+	contentType := "submission"
+	_, err := s.CreateNotification(
+		ctx,
+		submitterID,
+		models.NotificationTypeSubmissionAwaitingBroadcaster,
+		title,
+		message,
+		&link,
+		nil,
+		&submissionID,
+		&contentType,
+	)
+
+	return err
+}
+
+// NotifySubmissionBroadcasterApproved notifies a user when their held submission
+// passes the broadcaster step and moves on to moderator review
+func (s *NotificationService) NotifySubmissionBroadcasterApproved(
+	ctx context.Context,
+	submitterID uuid.UUID,
+	submissionID uuid.UUID,
+	clipTitle string,
+	expired bool,
+) error {
+	title := "The broadcaster approved your clip"
+	message := fmt.Sprintf("\"%s\" is now waiting for moderator review", clipTitle)
+	if expired {
+		title = "Your clip is moving on to moderator review"
+		message = fmt.Sprintf("The broadcaster didn't review \"%s\" in time, so it is now waiting for moderator review", clipTitle)
+	}
+	link := "/submissions"
+
+	contentType := "submission"
+	_, err := s.CreateNotification(
+		ctx,
+		submitterID,
+		models.NotificationTypeSubmissionBroadcasterApproved,
+		title,
+		message,
+		&link,
+		nil,
+		&submissionID,
+		&contentType,
+	)
+
+	return err
+}
+
+// NotifyBroadcasterReviewRequested notifies a broadcaster that a clip of their channel awaits approval
+func (s *NotificationService) NotifyBroadcasterReviewRequested(
+	ctx context.Context,
+	broadcasterUserID uuid.UUID,
+	submissionID uuid.UUID,
+	clipTitle string,
+	autoApproveAt time.Time,
+) error {
+	title := "A clip of your channel needs your approval"
+	message := fmt.Sprintf("\"%s\" will be approved automatically on %s if you don't review it", clipTitle, autoApproveAt.Format("Jan 2, 2006"))
+	link := "/broadcaster/approval-queue"
+
+	contentType := "submission"
+	_, err := s.CreateNotification(
+		ctx,
+		broadcasterUserID,
+		models.NotificationTypeBroadcasterReviewRequested,
+		title,
+		message,
+		&link,
+		nil,
+		&submissionID,
+		&contentType,
+	)
+
+	return err
+}
+
 // extractMentions extracts @username mentions from text
 func extractMentions(text string) []string {
 	// Match @username pattern (alphanumeric and underscore)
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+const (
+	// NotificationStreamEventNotification carries a newly created notification
+	NotificationStreamEventNotification = "notification"
+	// NotificationStreamEventUnreadCount carries the user's current unread count
+	NotificationStreamEventUnreadCount = "unread_count"
+
+	notificationStreamChannelPrefix = "notifications:user:"
+	notificationStreamBufferSize    = 16
+	maxNotificationStreamsPerUser   = 5
+)
+
+var (
+	// ErrNotificationStreamClosed is returned when subscribing to a hub that is shutting down
+	ErrNotificationStreamClosed = errors.New("notification stream is closed")
+	// ErrTooManyNotificationStreams is returned when a user already has the maximum number of open streams
+	ErrTooManyNotificationStreams = errors.New("too many open notification streams")
+)
+
+// NotificationStreamEvent is pushed to a user's open notification streams
+type NotificationStreamEvent struct {
+	Type         string               `json:"type"`
+	Notification *models.Notification `json:"notification,omitempty"`
+	UnreadCount  *int                 `json:"unread_count,omitempty"`
+}
+
+// NotificationStreamHub fans notification events out to the streams open on this
+// instance. With Redis, events are published to a per-user channel so streams on
+// every API instance receive them; without Redis they are delivered locally only.
+type NotificationStreamHub struct {
+	redis       redis.UniversalClient
+	pubsub      *redis.PubSub
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[chan NotificationStreamEvent]struct{}
+	closed      bool
+}
+
+// NewNotificationStreamHub creates a new NotificationStreamHub. redisClient may be nil.
+func NewNotificationStreamHub(redisClient redis.UniversalClient) *NotificationStreamHub {
+	return &NotificationStreamHub{
+		redis:       redisClient,
+		subscribers: make(map[uuid.UUID]map[chan NotificationStreamEvent]struct{}),
+	}
+}
+
+// Start listens for events published by other instances until Close is called
+func (h *NotificationStreamHub) Start(ctx context.Context) {
+	if h.redis == nil {
+		return
+	}
+
+	h.mu.Lock()
+	h.pubsub = h.redis.Subscribe(ctx)
+	messages := h.pubsub.Channel()
+	h.mu.Unlock()
+
+	go func() {
+		for msg := range messages {
+			userID, err := uuid.Parse(strings.TrimPrefix(msg.Channel, notificationStreamChannelPrefix))
+			if err != nil {
+				continue
+			}
+
+			var event NotificationStreamEvent
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				log.Printf("Failed to decode notification stream event: %v", err)
+				continue
+			}
+			h.deliver(userID, event)
+		}
+	}()
+}
+
+// Subscribe opens a stream of events for a user. The returned function must be
+// called when the stream ends. The channel is closed when the hub shuts down.
+func (h *NotificationStreamHub) Subscribe(ctx context.Context, userID uuid.UUID) (<-chan NotificationStreamEvent, func(), error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, nil, ErrNotificationStreamClosed
+	}
+
+	streams := h.subscribers[userID]
+	if len(streams) >= maxNotificationStreamsPerUser {
+		return nil, nil, ErrTooManyNotificationStreams
+	}
+	if streams == nil {
+		if h.pubsub != nil {
+			if err := h.pubsub.Subscribe(ctx, notificationStreamChannel(userID)); err != nil {
+				return nil, nil, fmt.Errorf("failed to subscribe to notification channel: %w", err)
+			}
+		}
+		streams = make(map[chan NotificationStreamEvent]struct{})
+		h.subscribers[userID] = streams
+	}
+
+	events := make(chan NotificationStreamEvent, notificationStreamBufferSize)
+	streams[events] = struct{}{}
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() { h.unsubscribe(userID, events) })
+	}
+
+	return events, unsubscribe, nil
+}
+
+// Publish sends an event to every open stream of a user
+func (h *NotificationStreamHub) Publish(ctx context.Context, userID uuid.UUID, event NotificationStreamEvent) error {
+	if h.redis == nil {
+		h.deliver(userID, event)
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification stream event: %w", err)
+	}
+	if err := h.redis.Publish(ctx, notificationStreamChannel(userID), payload).Err(); err != nil {
+		return fmt.Errorf("failed to publish notification stream event: %w", err)
+	}
+
+	return nil
+}
+
+// Close stops listening for events and ends every open stream
+func (h *NotificationStreamHub) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil
+	}
+	h.closed = true
+
+	for userID, streams := range h.subscribers {
+		for events := range streams {
+			close(events)
+		}
+		delete(h.subscribers, userID)
+	}
+
+	if h.pubsub != nil {
+		return h.pubsub.Close()
+	}
+	return nil
+}
+
+// deliver hands an event to the user's streams on this instance. Slow streams
+// drop events rather than block delivery; the next unread count resyncs them.
+func (h *NotificationStreamHub) deliver(userID uuid.UUID, event NotificationStreamEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for events := range h.subscribers[userID] {
+		select {
+		case events <- event:
+		default:
+		}
+	}
+}
+
+// unsubscribe removes a stream and drops the Redis subscription once the user has none left
+func (h *NotificationStreamHub) unsubscribe(userID uuid.UUID, events chan NotificationStreamEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	streams, ok := h.subscribers[userID]
+	if !ok {
+		return
+	}
+	if _, ok := streams[events]; !ok {
+		return
+	}
+
+	delete(streams, events)
+	close(events)
+
+	if len(streams) > 0 {
+		return
+	}
+	delete(h.subscribers, userID)
+	if h.pubsub != nil {
+		if err := h.pubsub.Unsubscribe(context.Background(), notificationStreamChannel(userID)); err != nil {
+			log.Printf("Failed to unsubscribe from notification channel: %v", err)
+		}
+	}
+}
+
+func notificationStreamChannel(userID uuid.UUID) string {
+	return notificationStreamChannelPrefix + userID.String()
+}
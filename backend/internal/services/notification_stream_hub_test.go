@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationStreamHub_DeliversToUserStreams(t *testing.T) {
+	hub := NewNotificationStreamHub(nil)
+	ctx := context.Background()
+	userID := uuid.New()
+	otherID := uuid.New()
+
+	first, unsubscribeFirst, err := hub.Subscribe(ctx, userID)
+	require.NoError(t, err)
+	defer unsubscribeFirst()
+	second, unsubscribeSecond, err := hub.Subscribe(ctx, userID)
+	require.NoError(t, err)
+	defer unsubscribeSecond()
+	other, unsubscribeOther, err := hub.Subscribe(ctx, otherID)
+	require.NoError(t, err)
+	defer unsubscribeOther()
+
+	count := 3
+	require.NoError(t, hub.Publish(ctx, userID, NotificationStreamEvent{Type: NotificationStreamEventUnreadCount, UnreadCount: &count}))
+
+	for _, events := range []<-chan NotificationStreamEvent{first, second} {
+		select {
+		case event := <-events:
+			assert.Equal(t, NotificationStreamEventUnreadCount, event.Type)
+			require.NotNil(t, event.UnreadCount)
+			assert.Equal(t, 3, *event.UnreadCount)
+		default:
+			t.Fatal("expected event on user stream")
+		}
+	}
+
+	select {
+	case <-other:
+		t.Fatal("other users must not receive the event")
+	default:
+	}
+}
+
+func TestNotificationStreamHub_LimitsStreamsPerUser(t *testing.T) {
+	hub := NewNotificationStreamHub(nil)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	unsubscribes := []func(){}
+	for i := 0; i < maxNotificationStreamsPerUser; i++ {
+		_, unsubscribe, err := hub.Subscribe(ctx, userID)
+		require.NoError(t, err)
+		unsubscribes = append(unsubscribes, unsubscribe)
+	}
+
+	_, _, err := hub.Subscribe(ctx, userID)
+	assert.ErrorIs(t, err, ErrTooManyNotificationStreams)
+
+	// Closing a stream frees a slot
+	unsubscribes[0]()
+	unsubscribes[0]()
+	_, unsubscribe, err := hub.Subscribe(ctx, userID)
+	require.NoError(t, err)
+	unsubscribe()
+}
+
+func TestNotificationStreamHub_CloseEndsStreams(t *testing.T) {
+	hub := NewNotificationStreamHub(nil)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	events, unsubscribe, err := hub.Subscribe(ctx, userID)
+	require.NoError(t, err)
+
+	require.NoError(t, hub.Close())
+	_, open := <-events
+	assert.False(t, open)
+
+	// Unsubscribing after close is a no-op
+	unsubscribe()
+
+	_, _, err = hub.Subscribe(ctx, userID)
+	assert.ErrorIs(t, err, ErrNotificationStreamClosed)
+}
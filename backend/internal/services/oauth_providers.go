@@ -0,0 +1,293 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+var (
+	// ErrUnknownOAuthProvider is returned when a login provider is not supported or not configured
+	ErrUnknownOAuthProvider = errors.New("unknown oauth provider")
+)
+
+// OAuthIdentity is the normalized profile returned by an external login provider
+type OAuthIdentity struct {
+	Provider       string
+	ProviderUserID string
+	Username       string
+	DisplayName    string
+	Email          string
+	EmailVerified  bool
+	AvatarURL      string
+	Metadata       map[string]interface{}
+}
+
+// OAuthProvider is implemented by each external login provider (Google, Discord, ...).
+// Twitch keeps its dedicated flow in AuthService because it also drives clip sync and moderation.
+type OAuthProvider interface {
+	// Name returns the provider identifier used in routes and storage
+	Name() string
+	// AuthCodeURL builds the authorization URL the browser is redirected to
+	AuthCodeURL(state, codeChallenge, codeChallengeMethod string) string
+	// Exchange trades an authorization code for the user's normalized identity
+	Exchange(ctx context.Context, code, codeVerifier string) (*OAuthIdentity, error)
+}
+
+// oauthEndpoints groups the URLs used by a standard authorization-code provider
+type oauthEndpoints struct {
+	authURL     string
+	tokenURL    string
+	userInfoURL string
+}
+
+// baseOAuthProvider implements the shared authorization-code flow
+type baseOAuthProvider struct {
+	name       string
+	cfg        config.OAuthProviderConfig
+	endpoints  oauthEndpoints
+	scopes     []string
+	extraAuth  url.Values
+	httpClient *http.Client
+}
+
+func (p *baseOAuthProvider) Name() string {
+	return p.name
+}
+
+func (p *baseOAuthProvider) AuthCodeURL(state, codeChallenge, codeChallengeMethod string) string {
+	params := url.Values{}
+	params.Add("client_id", p.cfg.ClientID)
+	params.Add("redirect_uri", p.cfg.RedirectURI)
+	params.Add("response_type", "code")
+	params.Add("scope", strings.Join(p.scopes, " "))
+	params.Add("state", state)
+	if codeChallenge != "" {
+		params.Add("code_challenge", codeChallenge)
+		params.Add("code_challenge_method", codeChallengeMethod)
+	}
+	for key, values := range p.extraAuth {
+		for _, value := range values {
+			params.Add(key, value)
+		}
+	}
+
+	return fmt.Sprintf("%s?%s", p.endpoints.authURL, params.Encode())
+}
+
+// exchangeToken exchanges an authorization code for the provider access token
+func (p *baseOAuthProvider) exchangeToken(ctx context.Context, code, codeVerifier string) (string, error) {
+	data := url.Values{}
+	data.Set("client_id", p.cfg.ClientID)
+	data.Set("client_secret", p.cfg.ClientSecret)
+	data.Set("code", code)
+	data.Set("grant_type", "authorization_code")
+	data.Set("redirect_uri", p.cfg.RedirectURI)
+	if codeVerifier != "" {
+		data.Set("code_verifier", codeVerifier)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.endpoints.tokenURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("%s token exchange failed: %s", p.name, string(body))
+	}
+
+	var tokenResp struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", err
+	}
+	if tokenResp.AccessToken == "" {
+		return "", fmt.Errorf("%s token exchange returned no access token", p.name)
+	}
+
+	return tokenResp.AccessToken, nil
+}
+
+// fetchUserInfo decodes the provider's user info endpoint into dest
+func (p *baseOAuthProvider) fetchUserInfo(ctx context.Context, accessToken string, dest interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", p.endpoints.userInfoURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s user fetch failed: %s", p.name, string(body))
+	}
+
+	return json.NewDecoder(resp.Body).Decode(dest)
+}
+
+// GoogleOAuthProvider implements Google sign-in via OpenID Connect
+type GoogleOAuthProvider struct {
+	baseOAuthProvider
+}
+
+// NewGoogleOAuthProvider creates a Google login provider
+func NewGoogleOAuthProvider(cfg config.OAuthProviderConfig) *GoogleOAuthProvider {
+	return &GoogleOAuthProvider{baseOAuthProvider{
+		name: models.AuthProviderGoogle,
+		cfg:  cfg,
+		endpoints: oauthEndpoints{
+			authURL:     "https://accounts.google.com/o/oauth2/v2/auth",
+			tokenURL:    "https://oauth2.googleapis.com/token",
+			userInfoURL: "https://openidconnect.googleapis.com/v1/userinfo",
+		},
+		scopes:     []string{"openid", "email", "profile"},
+		extraAuth:  url.Values{"prompt": {"select_account"}},
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}}
+}
+
+// Exchange trades a Google authorization code for the user's identity
+func (p *GoogleOAuthProvider) Exchange(ctx context.Context, code, codeVerifier string) (*OAuthIdentity, error) {
+	accessToken, err := p.exchangeToken(ctx, code, codeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	var info struct {
+		Sub           string `json:"sub"`
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		Name          string `json:"name"`
+		GivenName     string `json:"given_name"`
+		Picture       string `json:"picture"`
+		Locale        string `json:"locale"`
+	}
+	if err := p.fetchUserInfo(ctx, accessToken, &info); err != nil {
+		return nil, err
+	}
+	if info.Sub == "" {
+		return nil, errors.New("no user data returned from Google")
+	}
+
+	username := info.GivenName
+	if username == "" && info.Email != "" {
+		username = strings.SplitN(info.Email, "@", 2)[0]
+	}
+
+	return &OAuthIdentity{
+		Provider:       p.name,
+		ProviderUserID: info.Sub,
+		Username:       username,
+		DisplayName:    info.Name,
+		Email:          info.Email,
+		EmailVerified:  info.EmailVerified,
+		AvatarURL:      info.Picture,
+		Metadata: map[string]interface{}{
+			"locale": info.Locale,
+		},
+	}, nil
+}
+
+// DiscordOAuthProvider implements Discord login
+type DiscordOAuthProvider struct {
+	baseOAuthProvider
+}
+
+// NewDiscordOAuthProvider creates a Discord login provider
+func NewDiscordOAuthProvider(cfg config.OAuthProviderConfig) *DiscordOAuthProvider {
+	return &DiscordOAuthProvider{baseOAuthProvider{
+		name: models.AuthProviderDiscord,
+		cfg:  cfg,
+		endpoints: oauthEndpoints{
+			authURL:     "https://discord.com/oauth2/authorize",
+			tokenURL:    "https://discord.com/api/oauth2/token",
+			userInfoURL: "https://discord.com/api/users/@me",
+		},
+		scopes:     []string{"identify", "email"},
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}}
+}
+
+// Exchange trades a Discord authorization code for the user's identity
+func (p *DiscordOAuthProvider) Exchange(ctx context.Context, code, codeVerifier string) (*OAuthIdentity, error) {
+	accessToken, err := p.exchangeToken(ctx, code, codeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	var info struct {
+		ID         string `json:"id"`
+		Username   string `json:"username"`
+		GlobalName string `json:"global_name"`
+		Email      string `json:"email"`
+		Verified   bool   `json:"verified"`
+		Avatar     string `json:"avatar"`
+		Locale     string `json:"locale"`
+	}
+	if err := p.fetchUserInfo(ctx, accessToken, &info); err != nil {
+		return nil, err
+	}
+	if info.ID == "" {
+		return nil, errors.New("no user data returned from Discord")
+	}
+
+	displayName := info.GlobalName
+	if displayName == "" {
+		displayName = info.Username
+	}
+
+	avatarURL := ""
+	if info.Avatar != "" {
+		avatarURL = fmt.Sprintf("https://cdn.discordapp.com/avatars/%s/%s.png", info.ID, info.Avatar)
+	}
+
+	return &OAuthIdentity{
+		Provider:       p.name,
+		ProviderUserID: info.ID,
+		Username:       info.Username,
+		DisplayName:    displayName,
+		Email:          info.Email,
+		EmailVerified:  info.Verified,
+		AvatarURL:      avatarURL,
+		Metadata: map[string]interface{}{
+			"discord_username": info.Username,
+			"locale":           info.Locale,
+		},
+	}, nil
+}
+
+// NewOAuthProviders returns the external login providers that have credentials configured
+func NewOAuthProviders(cfg config.OAuthConfig) map[string]OAuthProvider {
+	providers := map[string]OAuthProvider{}
+	if cfg.Google.Enabled() {
+		providers[models.AuthProviderGoogle] = NewGoogleOAuthProvider(cfg.Google)
+	}
+	if cfg.Discord.Enabled() {
+		providers[models.AuthProviderDiscord] = NewDiscordOAuthProvider(cfg.Discord)
+	}
+	return providers
+}
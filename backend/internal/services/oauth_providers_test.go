@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// newTestOAuthServer serves a token endpoint and a user info endpoint
+func newTestOAuthServer(t *testing.T, userInfo map[string]interface{}) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		if r.Form.Get("code") != "good-code" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		assert.Equal(t, "authorization_code", r.Form.Get("grant_type"))
+		assert.Equal(t, "verifier", r.Form.Get("code_verifier"))
+		_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "provider-token"})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer provider-token", r.Header.Get("Authorization"))
+		_ = json.NewEncoder(w).Encode(userInfo)
+	})
+	return httptest.NewServer(mux)
+}
+
+func testEndpoints(server *httptest.Server) oauthEndpoints {
+	return oauthEndpoints{
+		authURL:     server.URL + "/authorize",
+		tokenURL:    server.URL + "/token",
+		userInfoURL: server.URL + "/userinfo",
+	}
+}
+
+func TestNewOAuthProviders_OnlyConfigured(t *testing.T) {
+	providers := NewOAuthProviders(config.OAuthConfig{
+		Google: config.OAuthProviderConfig{ClientID: "id", ClientSecret: "secret"},
+	})
+
+	assert.Len(t, providers, 1)
+	assert.Contains(t, providers, models.AuthProviderGoogle)
+	assert.NotContains(t, providers, models.AuthProviderDiscord)
+}
+
+func TestOAuthProvider_AuthCodeURL(t *testing.T) {
+	provider := NewGoogleOAuthProvider(config.OAuthProviderConfig{
+		ClientID:     "client-id",
+		ClientSecret: "secret",
+		RedirectURI:  "http://localhost:8080/api/v1/auth/google/callback",
+	})
+
+	authURL, err := url.Parse(provider.AuthCodeURL("state-123", "challenge", "S256"))
+	require.NoError(t, err)
+
+	query := authURL.Query()
+	assert.Equal(t, "accounts.google.com", authURL.Host)
+	assert.Equal(t, "client-id", query.Get("client_id"))
+	assert.Equal(t, "state-123", query.Get("state"))
+	assert.Equal(t, "openid email profile", query.Get("scope"))
+	assert.Equal(t, "challenge", query.Get("code_challenge"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	assert.Equal(t, "select_account", query.Get("prompt"))
+
+	// No PKCE parameters when no challenge is supplied
+	plain, err := url.Parse(provider.AuthCodeURL("state-123", "", ""))
+	require.NoError(t, err)
+	assert.Empty(t, plain.Query().Get("code_challenge"))
+}
+
+func TestGoogleOAuthProvider_Exchange(t *testing.T) {
+	server := newTestOAuthServer(t, map[string]interface{}{
+		"sub":            "1234567890",
+		"email":          "viewer@example.com",
+		"email_verified": true,
+		"name":           "Viewer McView",
+		"given_name":     "Viewer",
+		"picture":        "https://example.com/avatar.png",
+	})
+	defer server.Close()
+
+	provider := NewGoogleOAuthProvider(config.OAuthProviderConfig{ClientID: "id", ClientSecret: "secret"})
+	provider.endpoints = testEndpoints(server)
+
+	identity, err := provider.Exchange(context.Background(), "good-code", "verifier")
+	require.NoError(t, err)
+	assert.Equal(t, models.AuthProviderGoogle, identity.Provider)
+	assert.Equal(t, "1234567890", identity.ProviderUserID)
+	assert.Equal(t, "Viewer", identity.Username)
+	assert.Equal(t, "Viewer McView", identity.DisplayName)
+	assert.True(t, identity.EmailVerified)
+
+	_, err = provider.Exchange(context.Background(), "bad-code", "verifier")
+	assert.Error(t, err)
+}
+
+func TestDiscordOAuthProvider_Exchange(t *testing.T) {
+	server := newTestOAuthServer(t, map[string]interface{}{
+		"id":          "80351110224678912",
+		"username":    "nelly",
+		"global_name": "Nelly",
+		"email":       "nelly@example.com",
+		"verified":    false,
+		"avatar":      "8342729096ea3675442027381ff50dfe",
+	})
+	defer server.Close()
+
+	provider := NewDiscordOAuthProvider(config.OAuthProviderConfig{ClientID: "id", ClientSecret: "secret"})
+	provider.endpoints = testEndpoints(server)
+
+	identity, err := provider.Exchange(context.Background(), "good-code", "verifier")
+	require.NoError(t, err)
+	assert.Equal(t, models.AuthProviderDiscord, identity.Provider)
+	assert.Equal(t, "80351110224678912", identity.ProviderUserID)
+	assert.Equal(t, "nelly", identity.Username)
+	assert.Equal(t, "Nelly", identity.DisplayName)
+	assert.False(t, identity.EmailVerified)
+	assert.Equal(t, "https://cdn.discordapp.com/avatars/80351110224678912/8342729096ea3675442027381ff50dfe.png", identity.AvatarURL)
+}
+
+func TestSanitizeUsername(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Viewer", "viewer"},
+		{"john.doe", "john_doe"},
+		{"  spaced out  ", "spaced_out"},
+		{"émoji🔥name", "mojiname"},
+		{"__edge__", "edge"},
+		{"averyveryveryverylongusernamethatkeepsgoing", "averyveryveryverylonguser"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, sanitizeUsername(tt.input))
+		})
+	}
+}
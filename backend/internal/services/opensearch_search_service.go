@@ -6,6 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
+	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
@@ -17,6 +20,19 @@ import (
 type OpenSearchService struct {
 	osClient  *opensearch.Client
 	validator *SearchQueryValidator
+	weights   *SearchWeightConfig // Optional field and scoring boosts for clip queries
+
+	spellCorrection      bool    // Suggest corrections for misspelled clip queries
+	autoCorrectThreshold float64 // Minimum correction score to search the correction instead of a zero-hit query
+}
+
+// clipSearchResult is one page of clip search results
+type clipSearchResult struct {
+	clips      []models.Clip
+	total      int
+	facets     *models.SearchFacets
+	correction *spellCorrection
+	highlights map[string]models.SearchHighlights
 }
 
 // NewOpenSearchService creates a new OpenSearchService
@@ -35,6 +51,14 @@ func NewOpenSearchServiceWithLimits(osClient *opensearch.Client, limits SearchLi
 	}
 }
 
+// WithRankingWeights returns a copy of the service that builds clip queries
+// with the field and scoring boosts from weights
+func (s *OpenSearchService) WithRankingWeights(weights SearchWeightConfig) *OpenSearchService {
+	weighted := *s
+	weighted.weights = &weights
+	return &weighted
+}
+
 // Search performs a universal search using OpenSearch
 func (s *OpenSearchService) Search(ctx context.Context, req *models.SearchRequest) (*models.SearchResponse, error) {
 	// Calculate offset (from) and enforce search limits on offset and limit
@@ -66,17 +90,39 @@ func (s *OpenSearchService) Search(ctx context.Context, req *models.SearchReques
 
 	// Search clips (and get facets if searching clips)
 	if searchClips {
-		clips, count, facets, err := s.searchClipsWithFacets(ctx, req)
+		clipResult, err := s.executeClipSearch(ctx, req, s.spellCorrection && req.Query != "")
 		if err != nil {
 			return nil, fmt.Errorf("failed to search clips: %w", err)
 		}
-		response.Results.Clips = clips
-		response.Counts.Clips = count
-		totalCount += count
+
+		if correction := clipResult.correction; correction != nil {
+			response.DidYouMean = &correction.Text
+
+			// Search the correction instead when the query found nothing;
+			// the other result types then use it too
+			if clipResult.total == 0 && s.shouldAutoCorrect(correction) {
+				corrected := *req
+				corrected.Query = correction.Text
+				corrected.ExpansionTerms = nil
+				retry, err := s.executeClipSearch(ctx, &corrected, false)
+				if err != nil {
+					log.Printf("Warning: auto-corrected search for %q failed: %v", correction.Text, err)
+				} else if retry.total > 0 {
+					clipResult = retry
+					req = &corrected
+					response.AutoCorrected = true
+				}
+			}
+		}
+
+		response.Results.Clips = clipResult.clips
+		response.Counts.Clips = clipResult.total
+		response.Highlights = clipResult.highlights
+		totalCount += clipResult.total
 
 		// Include facets only when searching clips specifically or all
-		if facets != nil {
-			response.Facets = *facets
+		if clipResult.facets != nil {
+			response.Facets = *clipResult.facets
 		}
 	}
 
@@ -122,51 +168,28 @@ func (s *OpenSearchService) Search(ctx context.Context, req *models.SearchReques
 	return response, nil
 }
 
-// searchClipsWithFacets searches for clips with facet aggregations
-func (s *OpenSearchService) searchClipsWithFacets(ctx context.Context, req *models.SearchRequest) ([]models.Clip, int, *models.SearchFacets, error) {
+// executeClipSearch runs a clip search with facet aggregations and, when
+// withSpelling is set, term suggesters for a did_you_mean correction
+func (s *OpenSearchService) executeClipSearch(ctx context.Context, req *models.SearchRequest, withSpelling bool) (*clipSearchResult, error) {
 	// Start with the base query
 	baseQuery := s.buildClipQuery(req)
 
 	// Wrap with function_score only when sorting by relevance (default)
 	var finalQuery map[string]interface{}
 	if req.Sort == "" || req.Sort == "relevance" {
-		finalQuery = map[string]interface{}{
-			"function_score": map[string]interface{}{
-				"query": baseQuery,
-				"functions": []map[string]interface{}{
-					{
-						"field_value_factor": map[string]interface{}{
-							"field":    "engagement_score",
-							"modifier": "log1p",
-							"factor":   0.1,
-							"missing":  0,
-						},
-					},
-					{
-						"field_value_factor": map[string]interface{}{
-							"field":    "recency_score",
-							"modifier": "none",
-							"factor":   0.5,
-							"missing":  0,
-						},
-					},
-				},
-				"score_mode": "sum",
-				"boost_mode": "sum",
-			},
-		}
+		finalQuery = s.withRelevanceScoring(baseQuery)
 	} else {
 		finalQuery = baseQuery
 	}
 
 	// Validate query clauses
 	if err := s.validator.ValidateQueryClauses(finalQuery); err != nil {
-		return nil, 0, nil, fmt.Errorf("query clause validation failed: %w", err)
+		return nil, fmt.Errorf("query clause validation failed: %w", err)
 	}
 
 	// Validate query structure for security (fields, operators, dangerous patterns)
 	if err := s.validator.ValidateQueryStructure(finalQuery); err != nil {
-		return nil, 0, nil, fmt.Errorf("query structure validation failed: %w", err)
+		return nil, fmt.Errorf("query structure validation failed: %w", err)
 	}
 
 	// Build aggregations
@@ -174,7 +197,7 @@ func (s *OpenSearchService) searchClipsWithFacets(ctx context.Context, req *mode
 
 	// Validate aggregations
 	if err := s.validator.ValidateAggregations(aggs); err != nil {
-		return nil, 0, nil, fmt.Errorf("aggregation validation failed: %w", err)
+		return nil, fmt.Errorf("aggregation validation failed: %w", err)
 	}
 
 	from := (req.Page - 1) * req.Limit
@@ -184,11 +207,19 @@ func (s *OpenSearchService) searchClipsWithFacets(ctx context.Context, req *mode
 		"size":  req.Limit,
 		"sort":  s.buildSortClause(req.Sort),
 		"aggs":  aggs,
+		// Clip documents may carry their embedding vector, which results never need
+		"_source": map[string]interface{}{"excludes": []string{clipEmbeddingField}},
+	}
+	if withSpelling {
+		searchBody["suggest"] = buildSpellSuggest(req.Query)
+	}
+	if highlight := s.buildClipHighlight(req); highlight != nil {
+		searchBody["highlight"] = highlight
 	}
 
 	bodyJSON, err := json.Marshal(searchBody)
 	if err != nil {
-		return nil, 0, nil, fmt.Errorf("failed to marshal search body: %w", err)
+		return nil, fmt.Errorf("failed to marshal search body: %w", err)
 	}
 
 	// Apply timeout to search request
@@ -202,36 +233,229 @@ func (s *OpenSearchService) searchClipsWithFacets(ctx context.Context, req *mode
 
 	res, err := reqOS.Do(ctx, s.osClient.GetClient())
 	if err != nil {
-		return nil, 0, nil, fmt.Errorf("search request failed: %w", err)
+		return nil, fmt.Errorf("search request failed: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
 		bodyBytes, _ := io.ReadAll(res.Body)
-		return nil, 0, nil, fmt.Errorf("search error: %s - %s", res.Status(), string(bodyBytes))
+		return nil, fmt.Errorf("search error: %s - %s", res.Status(), string(bodyBytes))
 	}
 
 	var result map[string]interface{}
 	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
-		return nil, 0, nil, fmt.Errorf("failed to decode response: %w", err)
+		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	// Parse hits
 	clips, total, err := s.parseClipsFromResult(result)
 	if err != nil {
-		return nil, 0, nil, err
+		return nil, err
 	}
 
-	// Parse facets
-	facets := s.parseFacetsFromResult(result)
+	clipResult := &clipSearchResult{
+		clips:      clips,
+		total:      total,
+		facets:     s.parseFacetsFromResult(result),
+		highlights: parseClipHighlights(result),
+	}
+	if withSpelling {
+		clipResult.correction = parseSpellCorrection(req.Query, result)
+	}
 
-	return clips, total, facets, nil
+	return clipResult, nil
 }
 
 // searchClips searches for clips in OpenSearch (without facets)
 func (s *OpenSearchService) searchClips(ctx context.Context, req *models.SearchRequest) ([]models.Clip, int, error) {
-	clips, total, _, err := s.searchClipsWithFacets(ctx, req)
-	return clips, total, err
+	result, err := s.executeClipSearch(ctx, req, false)
+	if err != nil {
+		return nil, 0, err
+	}
+	return result.clips, result.total, nil
+}
+
+// withRelevanceScoring wraps a clip query in a function_score that adds the
+// engagement and recency boosts, and the chat hype boost when set, to its
+// relevance score
+func (s *OpenSearchService) withRelevanceScoring(query map[string]interface{}) map[string]interface{} {
+	engagementBoost, recencyBoost := s.clipScoreBoosts()
+	functions := []map[string]interface{}{
+		{
+			"field_value_factor": map[string]interface{}{
+				"field":    "engagement_score",
+				"modifier": "log1p",
+				"factor":   engagementBoost,
+				"missing":  0,
+			},
+		},
+		{
+			"field_value_factor": map[string]interface{}{
+				"field":    "recency_score",
+				"modifier": "none",
+				"factor":   recencyBoost,
+				"missing":  0,
+			},
+		},
+	}
+	if hypeBoost := s.clipHypeBoost(); hypeBoost > 0 {
+		functions = append(functions, map[string]interface{}{
+			"field_value_factor": map[string]interface{}{
+				"field":    "hype_score",
+				"modifier": "none",
+				"factor":   hypeBoost,
+				"missing":  0,
+			},
+		})
+	}
+	return map[string]interface{}{
+		"function_score": map[string]interface{}{
+			"query":      query,
+			"functions":  functions,
+			"score_mode": "sum",
+			"boost_mode": "sum",
+		},
+	}
+}
+
+// SearchClipsHybrid searches clips with the relevance-scored text query and a
+// kNN query on the clip embeddings in a single request. The clips hybrid
+// search pipeline fuses both rankings with reciprocal rank fusion, so the
+// clips index must have been created with kNN enabled.
+func (s *OpenSearchService) SearchClipsHybrid(ctx context.Context, req *models.SearchRequest, queryEmbedding []float32) (*models.SearchResponse, error) {
+	from := (req.Page - 1) * req.Limit
+	s.validator.EnforceSearchLimits(&req.Limit, &from)
+	if from != (req.Page-1)*req.Limit {
+		req.Page = (from / req.Limit) + 1
+	}
+
+	// Only the text query is user-shaped; the kNN clause reuses its filters
+	lexicalQuery := s.withRelevanceScoring(s.buildClipQuery(req))
+	if err := s.validator.ValidateQueryClauses(lexicalQuery); err != nil {
+		return nil, fmt.Errorf("query clause validation failed: %w", err)
+	}
+	if err := s.validator.ValidateQueryStructure(lexicalQuery); err != nil {
+		return nil, fmt.Errorf("query structure validation failed: %w", err)
+	}
+
+	aggs := s.buildFacetAggregations()
+	if err := s.validator.ValidateAggregations(aggs); err != nil {
+		return nil, fmt.Errorf("aggregation validation failed: %w", err)
+	}
+
+	searchBody := s.buildHybridClipSearchBody(req, lexicalQuery, queryEmbedding, from)
+	searchBody["aggs"] = aggs
+	if highlight := s.buildClipHighlight(req); highlight != nil {
+		searchBody["highlight"] = highlight
+	}
+	if s.spellCorrection {
+		// kNN always returns neighbours, so corrections are only suggested
+		searchBody["suggest"] = buildSpellSuggest(req.Query)
+	}
+
+	bodyJSON, err := json.Marshal(searchBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal search body: %w", err)
+	}
+
+	// The typed search request has no search pipeline parameter
+	params := url.Values{}
+	params.Set("search_pipeline", ClipsHybridSearchPipeline)
+	params.Set("timeout", fmt.Sprintf("%dms", s.validator.GetTimeout().Milliseconds()))
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, "/"+ClipsIndex+"/_search?"+params.Encode(), bytes.NewReader(bodyJSON))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build search request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	res, err := s.osClient.GetClient().Perform(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("hybrid search request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("hybrid search error: %s - %s", res.Status, string(bodyBytes))
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	clips, total, err := s.parseClipsFromResult(result)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.SearchResponse{
+		Query: req.Query,
+		Results: models.SearchResultsByType{
+			Clips: clips,
+		},
+		Counts: models.SearchCounts{
+			Clips: total,
+		},
+		Meta: models.SearchMeta{
+			Page:       req.Page,
+			Limit:      req.Limit,
+			TotalItems: total,
+		},
+		Highlights: parseClipHighlights(result),
+	}
+	if facets := s.parseFacetsFromResult(result); facets != nil {
+		response.Facets = *facets
+	}
+	if s.spellCorrection {
+		if correction := parseSpellCorrection(req.Query, result); correction != nil {
+			response.DidYouMean = &correction.Text
+		}
+	}
+	if req.Limit > 0 {
+		response.Meta.TotalPages = (total + req.Limit - 1) / req.Limit
+	}
+
+	return response, nil
+}
+
+// buildHybridClipSearchBody builds the body of a hybrid clip search. Each
+// sub-query returns enough hits to fill every page up to the requested one,
+// with at least 100 so fusion has candidates to work with.
+func (s *OpenSearchService) buildHybridClipSearchBody(req *models.SearchRequest, lexicalQuery map[string]interface{}, queryEmbedding []float32, from int) map[string]interface{} {
+	depth := from + req.Limit
+	if depth < 100 {
+		depth = 100
+	}
+
+	filter, mustNot := s.buildClipFilters(req)
+	knnFilter := map[string]interface{}{"filter": filter}
+	if len(mustNot) > 0 {
+		knnFilter["must_not"] = mustNot
+	}
+
+	return map[string]interface{}{
+		"query": map[string]interface{}{
+			"hybrid": map[string]interface{}{
+				"queries": []map[string]interface{}{
+					lexicalQuery,
+					{
+						"knn": map[string]interface{}{
+							clipEmbeddingField: map[string]interface{}{
+								"vector": queryEmbedding,
+								"k":      depth,
+								"filter": map[string]interface{}{"bool": knnFilter},
+							},
+						},
+					},
+				},
+				"pagination_depth": depth,
+			},
+		},
+		"from":    from,
+		"size":    req.Limit,
+		"_source": map[string]interface{}{"excludes": []string{clipEmbeddingField}},
+	}
 }
 
 // searchCreators searches for users/creators in OpenSearch
@@ -437,41 +661,96 @@ func (s *OpenSearchService) executeSearch(ctx context.Context, indexName string,
 // buildClipQuery builds a query for clips with filters and enhanced relevance
 func (s *OpenSearchService) buildClipQuery(req *models.SearchRequest) map[string]interface{} {
 	must := []map[string]interface{}{}
-	filter := []map[string]interface{}{
-		{"term": map[string]interface{}{"is_removed": false}},
-		{"exists": map[string]interface{}{"field": "submitted_by_user_id"}},
-	}
+	filter, mustNot := s.buildClipFilters(req)
 
 	// Add text search if query is provided with language-specific fields
 	if req.Query != "" {
-		// Build fields list based on language if specified
-		fields := []string{"title^3", "creator_name^2", "broadcaster_name^2", "game_name"}
-
-		// Add language-specific field with higher boost if language is specified
-		if req.Language != nil && *req.Language != "" {
-			switch *req.Language {
-			case "en":
-				fields = append([]string{"title.en^4"}, fields...)
-			case "es":
-				fields = append([]string{"title.es^4"}, fields...)
-			case "fr":
-				fields = append([]string{"title.fr^4"}, fields...)
-			case "de":
-				fields = append([]string{"title.de^4"}, fields...)
-			}
+		fields := s.clipQueryFields()
+
+		// Match the title with the analyzer of the query's language, so
+		// queries are stemmed the way titles in that language are
+		if language := clipQueryLanguage(req); language != "" {
+			fields = append([]string{"title." + language + "^4"}, fields...)
 		}
 
-		must = append(must, map[string]interface{}{
+		textQuery := map[string]interface{}{
 			"multi_match": map[string]interface{}{
 				"query":     req.Query,
 				"fields":    fields,
 				"fuzziness": "AUTO",
 				"operator":  "and",
 			},
+		}
+		if len(req.ExpansionTerms) > 0 {
+			textQuery = withQueryExpansion(textQuery, fields, req.ExpansionTerms)
+		}
+		must = append(must, textQuery)
+	}
+
+	// If no query text, use match_all
+	if len(must) == 0 {
+		must = append(must, map[string]interface{}{"match_all": map[string]interface{}{}})
+	}
+
+	// Base bool query used by clip search
+	boolQuery := map[string]interface{}{
+		"must":   must,
+		"filter": filter,
+	}
+	if req.PreferredLanguage != nil {
+		if language := NormalizeSearchLanguage(*req.PreferredLanguage); language != "" {
+			boolQuery["should"] = []map[string]interface{}{
+				{"term": map[string]interface{}{
+					"title_language": map[string]interface{}{"value": language, "boost": preferredLanguageBoost},
+				}},
+			}
+		}
+	}
+	if len(mustNot) > 0 {
+		boolQuery["must_not"] = mustNot
+	}
+	baseQuery := map[string]interface{}{
+		"bool": boolQuery,
+	}
+
+	// Return base query here; caller decides whether to wrap with function_score based on sort
+	return baseQuery
+}
+
+// queryExpansionBoost weights matches of expansion terms below matches of
+// the query itself
+const queryExpansionBoost = 0.3
+
+// withQueryExpansion lets a text query also match clips with any of the
+// expansion terms, boosted below the query itself so direct matches rank first
+func withQueryExpansion(textQuery map[string]interface{}, fields []string, terms []string) map[string]interface{} {
+	should := []map[string]interface{}{textQuery}
+	for _, term := range terms {
+		should = append(should, map[string]interface{}{
+			"multi_match": map[string]interface{}{
+				"query":    term,
+				"fields":   fields,
+				"operator": "and",
+				"boost":    queryExpansionBoost,
+			},
 		})
 	}
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"should":               should,
+			"minimum_should_match": 1,
+		},
+	}
+}
+
+// buildClipFilters builds the filter and must_not clauses that restrict which
+// clips a search may return
+func (s *OpenSearchService) buildClipFilters(req *models.SearchRequest) (filter, mustNot []map[string]interface{}) {
+	filter = []map[string]interface{}{
+		{"term": map[string]interface{}{"is_removed": false}},
+		{"exists": map[string]interface{}{"field": "submitted_by_user_id"}},
+	}
 
-	// Add filters
 	if req.GameID != nil && *req.GameID != "" {
 		filter = append(filter, map[string]interface{}{
 			"term": map[string]interface{}{"game_id": *req.GameID},
@@ -506,6 +785,18 @@ func (s *OpenSearchService) buildClipQuery(req *models.SearchRequest) map[string
 		})
 	}
 
+	if durationRange := rangeBounds(req.MinDuration, req.MaxDuration); durationRange != nil {
+		filter = append(filter, map[string]interface{}{
+			"range": map[string]interface{}{"duration": durationRange},
+		})
+	}
+
+	if viewRange := rangeBounds(req.MinViews, req.MaxViews); viewRange != nil {
+		filter = append(filter, map[string]interface{}{
+			"range": map[string]interface{}{"view_count": viewRange},
+		})
+	}
+
 	if req.DateTo != nil && *req.DateTo != "" {
 		filter = append(filter, map[string]interface{}{
 			"range": map[string]interface{}{
@@ -514,21 +805,81 @@ func (s *OpenSearchService) buildClipQuery(req *models.SearchRequest) map[string
 		})
 	}
 
-	// If no query text, use match_all
-	if len(must) == 0 {
-		must = append(must, map[string]interface{}{"match_all": map[string]interface{}{}})
+	// Clips are tagged with the patch in effect when they were created, so a
+	// patch filter is its game and release window
+	if window := req.PatchWindow; window != nil {
+		createdAt := map[string]interface{}{"gte": window.From.Format(time.RFC3339)}
+		if window.Until != nil {
+			createdAt["lt"] = window.Until.Format(time.RFC3339)
+		}
+		filter = append(filter,
+			map[string]interface{}{"term": map[string]interface{}{"game_id": window.TwitchGameID}},
+			map[string]interface{}{"range": map[string]interface{}{"created_at": createdAt}},
+		)
 	}
 
-	// Base bool query used by clip search
-	baseQuery := map[string]interface{}{
-		"bool": map[string]interface{}{
-			"must":   must,
-			"filter": filter,
-		},
+	// Accessibility filters; clips indexed before these fields existed count as
+	// uncaptioned and without a photosensitivity warning
+	if req.Captioned {
+		filter = append(filter, map[string]interface{}{
+			"term": map[string]interface{}{"has_captions": true},
+		})
 	}
 
-	// Return base query here; caller decides whether to wrap with function_score based on sort
-	return baseQuery
+	if req.HidePhotosensitive {
+		mustNot = append(mustNot, map[string]interface{}{
+			"term": map[string]interface{}{"photosensitivity_warning": true},
+		})
+	}
+
+	return filter, mustNot
+}
+
+// rangeBounds builds the bounds of an inclusive range query, or nil when
+// neither bound is set
+func rangeBounds[T int | float64](lower, upper *T) map[string]interface{} {
+	if lower == nil && upper == nil {
+		return nil
+	}
+	bounds := map[string]interface{}{}
+	if lower != nil {
+		bounds["gte"] = *lower
+	}
+	if upper != nil {
+		bounds["lte"] = *upper
+	}
+	return bounds
+}
+
+// clipQueryFields returns the clip fields matched by text search with their boosts
+func (s *OpenSearchService) clipQueryFields() []string {
+	if s.weights == nil {
+		return []string{"title^3", "creator_name^2", "broadcaster_name^2", "game_name"}
+	}
+	return []string{
+		fmt.Sprintf("title^%g", s.weights.TitleBoost),
+		fmt.Sprintf("creator_name^%g", s.weights.CreatorBoost),
+		fmt.Sprintf("broadcaster_name^%g", s.weights.CreatorBoost),
+		fmt.Sprintf("game_name^%g", s.weights.GameBoost),
+	}
+}
+
+// clipScoreBoosts returns the engagement and recency factors added to the
+// relevance score of clips
+func (s *OpenSearchService) clipScoreBoosts() (engagement, recency float64) {
+	if s.weights == nil {
+		return 0.1, 0.5
+	}
+	return s.weights.EngagementBoost, s.weights.RecencyBoost
+}
+
+// clipHypeBoost returns the factor the chat hype score adds to the relevance
+// score of clips. Hype is an optional signal, off unless weights set it.
+func (s *OpenSearchService) clipHypeBoost() float64 {
+	if s.weights == nil {
+		return 0
+	}
+	return s.weights.HypeBoost
 }
 
 // buildUserQuery builds a query for users
@@ -710,6 +1061,12 @@ func (s *OpenSearchService) buildFacetAggregations() map[string]interface{} {
 				"size":  aggsSize,
 			},
 		},
+		"durations": map[string]interface{}{
+			"range": map[string]interface{}{
+				"field":  "duration",
+				"ranges": durationFacetRanges(),
+			},
+		},
 		"date_ranges": map[string]interface{}{
 			"range": map[string]interface{}{
 				"field": "created_at",
@@ -842,6 +1199,8 @@ func (s *OpenSearchService) parseFacetsFromResult(result map[string]interface{})
 		}
 	}
 
+	facets.Durations = parseDurationFacets(aggs["durations"])
+
 	// Parse date range facets
 	if dateRangesRaw, ok := aggs["date_ranges"]; ok {
 		if dateRangesMap, ok := dateRangesRaw.(map[string]interface{}); ok {
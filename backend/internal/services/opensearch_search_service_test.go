@@ -2,6 +2,7 @@ package services
 
 import (
 	"testing"
+	"time"
 
 	"github.com/subculture-collective/clipper/internal/models"
 )
@@ -86,9 +87,229 @@ func TestOpenSearchService_BuildClipQuery(t *testing.T) {
 		if _, ok := must[0]["match_all"]; !ok {
 			t.Error("Expected match_all query for empty search")
 		}
+
+		if _, ok := boolQuery["must_not"]; ok {
+			t.Error("Expected no must_not clause without accessibility filters")
+		}
+	})
+
+	t.Run("Accessibility filters", func(t *testing.T) {
+		req := &models.SearchRequest{
+			Query:              "test",
+			Captioned:          true,
+			HidePhotosensitive: true,
+		}
+
+		query := service.buildClipQuery(req)
+		boolQuery := query["bool"].(map[string]interface{})
+
+		filter := boolQuery["filter"].([]map[string]interface{})
+		foundCaptions := false
+		for _, clause := range filter {
+			if term, ok := clause["term"].(map[string]interface{}); ok && term["has_captions"] == true {
+				foundCaptions = true
+			}
+		}
+		if !foundCaptions {
+			t.Error("Expected has_captions filter")
+		}
+
+		mustNot, ok := boolQuery["must_not"].([]map[string]interface{})
+		if !ok || len(mustNot) != 1 {
+			t.Fatalf("Expected 1 must_not clause, got %v", boolQuery["must_not"])
+		}
+		term := mustNot[0]["term"].(map[string]interface{})
+		if term["photosensitivity_warning"] != true {
+			t.Errorf("Expected photosensitivity_warning exclusion, got %v", term)
+		}
+	})
+
+	t.Run("Duration and view ranges", func(t *testing.T) {
+		minDuration, maxDuration := 10.5, 30.0
+		minViews := 1000
+		req := &models.SearchRequest{
+			Query:       "test",
+			MinDuration: &minDuration,
+			MaxDuration: &maxDuration,
+			MinViews:    &minViews,
+		}
+
+		query := service.buildClipQuery(req)
+		filter := query["bool"].(map[string]interface{})["filter"].([]map[string]interface{})
+		ranges := map[string]interface{}{}
+		for _, clause := range filter {
+			if r, ok := clause["range"].(map[string]interface{}); ok {
+				for field, bounds := range r {
+					ranges[field] = bounds
+				}
+			}
+		}
+
+		duration, ok := ranges["duration"].(map[string]interface{})
+		if !ok || duration["gte"] != 10.5 || duration["lte"] != 30.0 {
+			t.Errorf("Expected duration range 10.5 to 30, got %v", ranges["duration"])
+		}
+		views, ok := ranges["view_count"].(map[string]interface{})
+		if !ok || views["gte"] != 1000 {
+			t.Errorf("Expected view_count from 1000, got %v", ranges["view_count"])
+		}
+		if _, ok := views["lte"]; ok {
+			t.Error("Expected no upper view_count bound")
+		}
 	})
 }
 
+func TestOpenSearchService_BuildClipQueryLanguage(t *testing.T) {
+	service := &OpenSearchService{}
+	preferred := "pt-br"
+	req := &models.SearchRequest{Query: "la mejor jugada", PreferredLanguage: &preferred}
+
+	query := service.buildClipQuery(req)
+	boolQuery := query["bool"].(map[string]interface{})
+
+	multiMatch := boolQuery["must"].([]map[string]interface{})[0]["multi_match"].(map[string]interface{})
+	fields := multiMatch["fields"].([]string)
+	if fields[0] != "title.es^4" {
+		t.Errorf("Expected the Spanish title field first, got %v", fields)
+	}
+
+	should, ok := boolQuery["should"].([]map[string]interface{})
+	if !ok || len(should) != 1 {
+		t.Fatalf("Expected 1 should clause, got %v", boolQuery["should"])
+	}
+	term := should[0]["term"].(map[string]interface{})["title_language"].(map[string]interface{})
+	if term["value"] != "pt" {
+		t.Errorf("Expected preferred language boost for pt, got %v", term)
+	}
+
+	if err := NewSearchQueryValidator(DefaultSearchLimits()).ValidateQueryStructure(query); err != nil {
+		t.Errorf("Expected language query to pass validation, got %v", err)
+	}
+}
+
+func TestOpenSearchService_BuildClipQueryPatchWindow(t *testing.T) {
+	service := &OpenSearchService{}
+	from := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 7, 15, 0, 0, 0, 0, time.UTC)
+	req := &models.SearchRequest{
+		Query:       "ace",
+		PatchWindow: &models.GamePatchWindow{TwitchGameID: "516575", From: from, Until: &until},
+	}
+
+	query := service.buildClipQuery(req)
+	filter := query["bool"].(map[string]interface{})["filter"].([]map[string]interface{})
+
+	var gameTerm, createdAt map[string]interface{}
+	for _, clause := range filter {
+		if term, ok := clause["term"].(map[string]interface{}); ok && term["game_id"] != nil {
+			gameTerm = term
+		}
+		if rng, ok := clause["range"].(map[string]interface{}); ok && rng["created_at"] != nil {
+			createdAt = rng["created_at"].(map[string]interface{})
+		}
+	}
+	if gameTerm == nil || gameTerm["game_id"] != "516575" {
+		t.Fatalf("Expected a game_id term for the patch's game, got %v", filter)
+	}
+	if createdAt == nil {
+		t.Fatalf("Expected a created_at range for the patch window, got %v", filter)
+	}
+	if createdAt["gte"] != "2026-06-01T00:00:00Z" || createdAt["lt"] != "2026-07-15T00:00:00Z" {
+		t.Errorf("Expected the patch window as created_at range, got %v", createdAt)
+	}
+
+	// The current patch has no end
+	req.PatchWindow.Until = nil
+	query = service.buildClipQuery(req)
+	for _, clause := range query["bool"].(map[string]interface{})["filter"].([]map[string]interface{}) {
+		if rng, ok := clause["range"].(map[string]interface{}); ok && rng["created_at"] != nil {
+			if _, ok := rng["created_at"].(map[string]interface{})["lt"]; ok {
+				t.Error("Expected no upper created_at bound for the current patch")
+			}
+		}
+	}
+}
+
+func TestOpenSearchService_BuildHybridClipSearchBody(t *testing.T) {
+	service := &OpenSearchService{}
+	req := &models.SearchRequest{
+		Query:              "clutch",
+		Limit:              20,
+		HidePhotosensitive: true,
+	}
+	lexical := service.withRelevanceScoring(service.buildClipQuery(req))
+	embedding := []float32{0.1, 0.2}
+
+	body := service.buildHybridClipSearchBody(req, lexical, embedding, 0)
+
+	hybrid := body["query"].(map[string]interface{})["hybrid"].(map[string]interface{})
+	if hybrid["pagination_depth"] != 100 {
+		t.Errorf("Expected minimum pagination depth 100, got %v", hybrid["pagination_depth"])
+	}
+
+	queries := hybrid["queries"].([]map[string]interface{})
+	if len(queries) != 2 {
+		t.Fatalf("Expected lexical and kNN sub-queries, got %d", len(queries))
+	}
+	if _, ok := queries[0]["function_score"]; !ok {
+		t.Error("Expected relevance-scored lexical sub-query first")
+	}
+
+	knn := queries[1]["knn"].(map[string]interface{})[clipEmbeddingField].(map[string]interface{})
+	if knn["k"] != 100 {
+		t.Errorf("Expected k 100, got %v", knn["k"])
+	}
+	filter := knn["filter"].(map[string]interface{})["bool"].(map[string]interface{})
+	if len(filter["filter"].([]map[string]interface{})) < 2 {
+		t.Error("Expected kNN sub-query to share the clip filters")
+	}
+	if _, ok := filter["must_not"]; !ok {
+		t.Error("Expected kNN sub-query to exclude photosensitive clips")
+	}
+
+	// Deep pages need every earlier hit from both sub-queries
+	body = service.buildHybridClipSearchBody(req, lexical, embedding, 180)
+	hybrid = body["query"].(map[string]interface{})["hybrid"].(map[string]interface{})
+	if hybrid["pagination_depth"] != 200 {
+		t.Errorf("Expected pagination depth 200, got %v", hybrid["pagination_depth"])
+	}
+}
+
+func TestOpenSearchService_RankingWeights(t *testing.T) {
+	service := &OpenSearchService{}
+	weighted := service.WithRankingWeights(SearchWeightConfig{
+		TitleBoost:      4,
+		CreatorBoost:    1.5,
+		GameBoost:       2,
+		EngagementBoost: 0.3,
+		RecencyBoost:    0.8,
+	})
+
+	if got := service.clipQueryFields(); got[0] != "title^3" {
+		t.Errorf("Expected default title boost, got %v", got)
+	}
+
+	want := []string{"title^4", "creator_name^1.5", "broadcaster_name^1.5", "game_name^2"}
+	got := weighted.clipQueryFields()
+	if len(got) != len(want) {
+		t.Fatalf("Expected fields %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expected fields %v, got %v", want, got)
+			break
+		}
+	}
+
+	engagement, recency := weighted.clipScoreBoosts()
+	if engagement != 0.3 || recency != 0.8 {
+		t.Errorf("Expected boosts 0.3/0.8, got %v/%v", engagement, recency)
+	}
+	if engagement, recency := service.clipScoreBoosts(); engagement != 0.1 || recency != 0.5 {
+		t.Errorf("Expected default boosts 0.1/0.5, got %v/%v", engagement, recency)
+	}
+}
+
 func TestOpenSearchService_BuildSortClause(t *testing.T) {
 	service := &OpenSearchService{}
 
@@ -218,3 +439,36 @@ func TestOpenSearchService_BuildTagQuery(t *testing.T) {
 		}
 	})
 }
+
+func TestOpenSearchService_BuildClipQueryExpansion(t *testing.T) {
+	service := &OpenSearchService{}
+	req := &models.SearchRequest{Query: "speedrun", ExpansionTerms: []string{"World Record", "Any%"}}
+
+	query := service.buildClipQuery(req)
+	must := query["bool"].(map[string]interface{})["must"].([]map[string]interface{})
+	if len(must) != 1 {
+		t.Fatalf("Expected 1 must clause, got %d", len(must))
+	}
+
+	expanded := must[0]["bool"].(map[string]interface{})
+	should := expanded["should"].([]map[string]interface{})
+	if len(should) != 3 {
+		t.Fatalf("Expected the query and 2 expansion terms, got %d clauses", len(should))
+	}
+	if expanded["minimum_should_match"] != 1 {
+		t.Errorf("Expected any clause to match, got %v", expanded["minimum_should_match"])
+	}
+
+	original := should[0]["multi_match"].(map[string]interface{})
+	if original["query"] != "speedrun" {
+		t.Errorf("Expected the query first, got %v", original["query"])
+	}
+	term := should[1]["multi_match"].(map[string]interface{})
+	if term["query"] != "World Record" || term["boost"] != queryExpansionBoost {
+		t.Errorf("Expected a boosted-down expansion term, got %v", term)
+	}
+
+	if err := NewSearchQueryValidator(DefaultSearchLimits()).ValidateQueryStructure(query); err != nil {
+		t.Errorf("Expected expanded query to pass validation, got %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf16"
+)
+
+// clipSpellFields are the clip fields whose terms feed spelling suggestions.
+// They use the standard analyzer, so suggestions are whole indexed words.
+var clipSpellFields = []string{"title", "broadcaster_name", "game_name"}
+
+// spellSuggestionPrefix names the term suggesters in a clip search body
+const spellSuggestionPrefix = "spell_"
+
+// spellCorrection is a corrected query suggested by OpenSearch
+type spellCorrection struct {
+	Text  string
+	Score float64 // Lowest score among the corrected terms (0-1)
+}
+
+// spellOption is the best replacement found for one query term
+type spellOption struct {
+	length int
+	text   string
+	score  float64
+	freq   int
+}
+
+// SetSpellCorrection enables did_you_mean suggestions for clip searches. When
+// a query has no clip hits and its correction scores at least
+// autoCorrectThreshold, the correction is searched instead; 0 only suggests.
+func (s *OpenSearchService) SetSpellCorrection(enabled bool, autoCorrectThreshold float64) {
+	s.spellCorrection = enabled
+	s.autoCorrectThreshold = autoCorrectThreshold
+}
+
+// shouldAutoCorrect reports whether a zero-hit query should be replaced by c
+func (s *OpenSearchService) shouldAutoCorrect(c *spellCorrection) bool {
+	return c != nil && s.autoCorrectThreshold > 0 && c.Score >= s.autoCorrectThreshold
+}
+
+// buildSpellSuggest builds term suggesters over the clip text fields. Only
+// terms missing from a field get suggestions, so correctly spelled queries
+// produce none.
+func buildSpellSuggest(query string) map[string]interface{} {
+	suggest := map[string]interface{}{"text": query}
+	for _, field := range clipSpellFields {
+		suggest[spellSuggestionPrefix+field] = map[string]interface{}{
+			"term": map[string]interface{}{
+				"field":           field,
+				"suggest_mode":    "missing",
+				"max_edits":       2,
+				"prefix_length":   1,
+				"min_word_length": 4,
+				"size":            1,
+				"sort":            "score",
+			},
+		}
+	}
+	return suggest
+}
+
+// parseSpellCorrection builds a corrected query from the term suggestions in
+// a search response, taking the best option for each term across fields.
+// It returns nil when no term was corrected.
+func parseSpellCorrection(query string, result map[string]interface{}) *spellCorrection {
+	suggest, ok := result["suggest"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	best := map[int]spellOption{}
+	for name, raw := range suggest {
+		if !strings.HasPrefix(name, spellSuggestionPrefix) {
+			continue
+		}
+		entries, ok := raw.([]interface{})
+		if !ok {
+			continue
+		}
+		for _, rawEntry := range entries {
+			entry, ok := rawEntry.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			offset, _ := entry["offset"].(float64)
+			length, _ := entry["length"].(float64)
+			token, _ := entry["text"].(string)
+			options, _ := entry["options"].([]interface{})
+			if len(options) == 0 {
+				continue
+			}
+			option, ok := options[0].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			text, _ := option["text"].(string)
+			score, _ := option["score"].(float64)
+			freq, _ := option["freq"].(float64)
+			if text == "" || strings.EqualFold(text, token) {
+				continue
+			}
+
+			candidate := spellOption{length: int(length), text: text, score: score, freq: int(freq)}
+			current, seen := best[int(offset)]
+			if !seen || candidate.score > current.score || (candidate.score == current.score && candidate.freq > current.freq) {
+				best[int(offset)] = candidate
+			}
+		}
+	}
+	if len(best) == 0 {
+		return nil
+	}
+
+	offsets := make([]int, 0, len(best))
+	for offset := range best {
+		offsets = append(offsets, offset)
+	}
+	sort.Ints(offsets)
+
+	// OpenSearch reports offsets in UTF-16 code units
+	units := utf16.Encode([]rune(query))
+	var corrected strings.Builder
+	cursor := 0
+	minScore := 1.0
+	for _, offset := range offsets {
+		option := best[offset]
+		if offset < cursor || offset+option.length > len(units) {
+			continue
+		}
+		corrected.WriteString(string(utf16.Decode(units[cursor:offset])))
+		corrected.WriteString(option.text)
+		cursor = offset + option.length
+		if option.score < minScore {
+			minScore = option.score
+		}
+	}
+	if cursor == 0 {
+		return nil
+	}
+	corrected.WriteString(string(utf16.Decode(units[cursor:])))
+
+	return &spellCorrection{Text: corrected.String(), Score: minScore}
+}
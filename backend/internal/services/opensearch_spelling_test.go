@@ -0,0 +1,131 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestBuildSpellSuggest(t *testing.T) {
+	suggest := buildSpellSuggest("speedruun")
+
+	if suggest["text"] != "speedruun" {
+		t.Errorf("Expected suggest text 'speedruun', got %v", suggest["text"])
+	}
+
+	for _, field := range clipSpellFields {
+		suggester, ok := suggest[spellSuggestionPrefix+field].(map[string]interface{})
+		if !ok {
+			t.Fatalf("Expected a term suggester for %s", field)
+		}
+		term, ok := suggester["term"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("Expected term suggester options for %s", field)
+		}
+		if term["field"] != field {
+			t.Errorf("Expected suggester field %s, got %v", field, term["field"])
+		}
+		if term["suggest_mode"] != "missing" {
+			t.Errorf("Expected suggest_mode 'missing' for %s, got %v", field, term["suggest_mode"])
+		}
+	}
+}
+
+// decodeSuggestResult decodes a search response fragment as the client does
+func decodeSuggestResult(t *testing.T, raw string) map[string]interface{} {
+	t.Helper()
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &result); err != nil {
+		t.Fatalf("Failed to decode result: %v", err)
+	}
+	return result
+}
+
+func TestParseSpellCorrection(t *testing.T) {
+	t.Run("Corrects a single term", func(t *testing.T) {
+		result := decodeSuggestResult(t, `{"suggest": {
+			"spell_title": [{"text": "speedruun", "offset": 0, "length": 9,
+				"options": [{"text": "speedrun", "score": 0.875, "freq": 42}]}],
+			"spell_game_name": [{"text": "speedruun", "offset": 0, "length": 9, "options": []}]
+		}}`)
+
+		correction := parseSpellCorrection("speedruun", result)
+		if correction == nil {
+			t.Fatal("Expected a correction")
+		}
+		if correction.Text != "speedrun" {
+			t.Errorf("Expected 'speedrun', got %q", correction.Text)
+		}
+		if correction.Score != 0.875 {
+			t.Errorf("Expected score 0.875, got %v", correction.Score)
+		}
+	})
+
+	t.Run("Keeps untouched terms and picks the best field per term", func(t *testing.T) {
+		result := decodeSuggestResult(t, `{"suggest": {
+			"spell_title": [
+				{"text": "xqcc", "offset": 0, "length": 4, "options": [{"text": "xqd", "score": 0.5, "freq": 3}]},
+				{"text": "speedruun", "offset": 15, "length": 9, "options": [{"text": "speedrun", "score": 0.875, "freq": 42}]}
+			],
+			"spell_broadcaster_name": [
+				{"text": "xqcc", "offset": 0, "length": 4, "options": [{"text": "xqc", "score": 0.75, "freq": 120}]},
+				{"text": "speedruun", "offset": 15, "length": 9, "options": []}
+			]
+		}}`)
+
+		correction := parseSpellCorrection("xQcc minecraft speedruun", result)
+		if correction == nil {
+			t.Fatal("Expected a correction")
+		}
+		if correction.Text != "xqc minecraft speedrun" {
+			t.Errorf("Expected 'xqc minecraft speedrun', got %q", correction.Text)
+		}
+		if correction.Score != 0.75 {
+			t.Errorf("Expected the lowest term score 0.75, got %v", correction.Score)
+		}
+	})
+
+	t.Run("Uses UTF-16 offsets", func(t *testing.T) {
+		result := decodeSuggestResult(t, `{"suggest": {
+			"spell_title": [{"text": "speedruun", "offset": 3, "length": 9,
+				"options": [{"text": "speedrun", "score": 0.875, "freq": 42}]}]
+		}}`)
+
+		// The emoji is two UTF-16 code units
+		correction := parseSpellCorrection("🎮 speedruun", result)
+		if correction == nil || correction.Text != "🎮 speedrun" {
+			t.Errorf("Expected '🎮 speedrun', got %+v", correction)
+		}
+	})
+
+	t.Run("No suggestions", func(t *testing.T) {
+		result := decodeSuggestResult(t, `{"suggest": {
+			"spell_title": [{"text": "speedrun", "offset": 0, "length": 8, "options": []}]
+		}}`)
+		if correction := parseSpellCorrection("speedrun", result); correction != nil {
+			t.Errorf("Expected no correction, got %+v", correction)
+		}
+		if correction := parseSpellCorrection("speedrun", map[string]interface{}{}); correction != nil {
+			t.Errorf("Expected no correction without suggest, got %+v", correction)
+		}
+	})
+}
+
+func TestOpenSearchService_ShouldAutoCorrect(t *testing.T) {
+	service := &OpenSearchService{}
+	service.SetSpellCorrection(true, 0.75)
+
+	if !service.shouldAutoCorrect(&spellCorrection{Text: "speedrun", Score: 0.875}) {
+		t.Error("Expected a correction above the threshold to auto-correct")
+	}
+	if service.shouldAutoCorrect(&spellCorrection{Text: "xqd", Score: 0.5}) {
+		t.Error("Expected a correction below the threshold to only be suggested")
+	}
+	if service.shouldAutoCorrect(nil) {
+		t.Error("Expected no auto-correct without a correction")
+	}
+
+	service.SetSpellCorrection(true, 0)
+	if service.shouldAutoCorrect(&spellCorrection{Text: "speedrun", Score: 1}) {
+		t.Error("Expected a zero threshold to disable auto-correct")
+	}
+}
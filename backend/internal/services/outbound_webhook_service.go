@@ -272,7 +272,15 @@ func (s *OutboundWebhookService) ProcessPendingDeliveries(ctx context.Context, b
 		"count":     len(deliveries),
 	})
 
-	for _, delivery := range deliveries {
+	for i, delivery := range deliveries {
+		// Shutting down: the remaining deliveries stay pending for the next run
+		if ctx.Err() != nil {
+			utils.Info("Webhook delivery batch interrupted by shutdown", map[string]interface{}{
+				"component": webhookOutboundComponent,
+				"remaining": len(deliveries) - i,
+			})
+			break
+		}
 		if err := s.processDelivery(ctx, delivery); err != nil {
 			utils.Error("Failed to process webhook delivery", err, map[string]interface{}{
 				"component":   webhookOutboundComponent,
@@ -339,6 +347,11 @@ func (s *OutboundWebhookService) processDelivery(ctx context.Context, delivery *
 
 	// Send request
 	resp, err := s.httpClient.Do(req)
+	if err != nil && ctx.Err() != nil {
+		// Cancelled by shutdown rather than a receiver failure, so the attempt
+		// isn't counted and the delivery stays pending for the next run
+		return fmt.Errorf("webhook delivery interrupted: %w", ctx.Err())
+	}
 	if err != nil {
 		// Network error - schedule retry or move to DLQ
 		errMsg := fmt.Sprintf("network error: %v", err)
@@ -33,6 +33,49 @@ func (m *MockRedisClient) Delete(ctx context.Context, key string) error {
 	return args.Error(0)
 }
 
+func (m *MockRedisClient) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	args := m.Called(ctx, key, value, expiration)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRedisClient) Get(ctx context.Context, key string) (string, error) {
+	args := m.Called(ctx, key)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockRedisClient) Increment(ctx context.Context, key string) (int64, error) {
+	args := m.Called(ctx, key)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRedisClient) Decrement(ctx context.Context, key string) (int64, error) {
+	args := m.Called(ctx, key)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRedisClient) ListPush(ctx context.Context, key string, value interface{}) error {
+	args := m.Called(ctx, key, value)
+	return args.Error(0)
+}
+
+func (m *MockRedisClient) ListTrim(ctx context.Context, key string, start, stop int64) error {
+	args := m.Called(ctx, key, start, stop)
+	return args.Error(0)
+}
+
+func (m *MockRedisClient) ListRange(ctx context.Context, key string, start, stop int64) ([]string, error) {
+	args := m.Called(ctx, key, start, stop)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockRedisClient) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	args := m.Called(ctx, key, expiration)
+	return args.Error(0)
+}
+
 // TestCanBan_AdminCanBanAnyone tests that admins can ban any user in any channel
 func TestCanBan_AdminCanBanAnyone(t *testing.T) {
 	ctx := context.Background()
@@ -0,0 +1,420 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/checkout/session"
+	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+// playlistBundlePurpose tags bundle checkout sessions so webhooks can tell
+// them apart from subscription checkouts
+const playlistBundlePurpose = "playlist_bundle"
+
+var (
+	// ErrPlaylistBundlePlaylistNotFound is returned when the playlist does not exist
+	ErrPlaylistBundlePlaylistNotFound = errors.New("playlist not found")
+	// ErrPlaylistBundleNotOwner is returned when someone other than the owner prices a playlist
+	ErrPlaylistBundleNotOwner = errors.New("only the playlist owner can sell it")
+	// ErrPlaylistBundlePrivate is returned when pricing a private playlist
+	ErrPlaylistBundlePrivate = errors.New("private playlists can't be sold")
+	// ErrPlaylistBundleNotForSale is returned when buying a playlist that isn't sold as a bundle
+	ErrPlaylistBundleNotForSale = errors.New("playlist is not for sale")
+	// ErrPlaylistBundleOwnPlaylist is returned when a creator buys their own playlist
+	ErrPlaylistBundleOwnPlaylist = errors.New("cannot buy your own playlist")
+	// ErrPlaylistBundleAlreadyPurchased is returned when the buyer already has access
+	ErrPlaylistBundleAlreadyPurchased = errors.New("playlist already purchased")
+	// ErrPlaylistPurchaseRequired is returned when a premium playlist's clips are used without buying it
+	ErrPlaylistPurchaseRequired = errors.New("unauthorized: playlist must be purchased to access its clips")
+)
+
+// PlaylistBundleRepositoryInterface defines the repository methods used by PlaylistBundleService
+type PlaylistBundleRepositoryInterface interface {
+	UpsertBundle(ctx context.Context, bundle *models.PlaylistBundle) error
+	DeactivateBundle(ctx context.Context, playlistID uuid.UUID) error
+	GetActiveBundle(ctx context.Context, playlistID uuid.UUID) (*models.PlaylistBundle, error)
+	HasActiveEntitlement(ctx context.Context, playlistID, userID uuid.UUID) (bool, error)
+	GrantEntitlement(ctx context.Context, entitlement *models.PlaylistBundleEntitlement, entry *models.CreatorLedgerEntry) error
+	GetEntitlementByPaymentIntent(ctx context.Context, paymentIntentID string) (*models.PlaylistBundleEntitlement, error)
+	GetRefundedCents(ctx context.Context, entitlementID uuid.UUID) (int64, error)
+	RecordRefund(ctx context.Context, entitlementID uuid.UUID, revoke bool, entry *models.CreatorLedgerEntry) error
+	ListLedgerEntries(ctx context.Context, creatorID uuid.UUID, limit, offset int) ([]models.CreatorLedgerEntry, error)
+	GetLedgerSummary(ctx context.Context, creatorID uuid.UUID) ([]models.CreatorLedgerSummary, error)
+}
+
+// PlaylistBundlePlaylistSource looks up playlists and their collaborators
+type PlaylistBundlePlaylistSource interface {
+	GetByID(ctx context.Context, playlistID uuid.UUID) (*models.Playlist, error)
+	GetCollaboratorPermission(ctx context.Context, playlistID, userID uuid.UUID) (string, error)
+}
+
+// PlaylistBundleService sells premium playlists as one-time purchases. It
+// grants and revokes entitlements from Stripe webhooks and splits each
+// payment between the creator and the platform in the creator ledger.
+type PlaylistBundleService struct {
+	repo          PlaylistBundleRepositoryInterface
+	playlists     PlaylistBundlePlaylistSource
+	cfg           *config.Config
+	createSession func(params *stripe.CheckoutSessionParams) (*stripe.CheckoutSession, error)
+}
+
+// NewPlaylistBundleService creates a new PlaylistBundleService
+func NewPlaylistBundleService(
+	repo PlaylistBundleRepositoryInterface,
+	playlists PlaylistBundlePlaylistSource,
+	cfg *config.Config,
+) *PlaylistBundleService {
+	return &PlaylistBundleService{
+		repo:          repo,
+		playlists:     playlists,
+		cfg:           cfg,
+		createSession: session.New,
+	}
+}
+
+// SetBundle puts a playlist up for sale, or changes its price
+func (s *PlaylistBundleService) SetBundle(ctx context.Context, playlistID, userID uuid.UUID, req *models.SetPlaylistBundleRequest) (*models.PlaylistBundle, error) {
+	playlist, err := s.getOwnedPlaylist(ctx, playlistID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if playlist.Visibility == models.PlaylistVisibilityPrivate {
+		return nil, ErrPlaylistBundlePrivate
+	}
+
+	bundle := &models.PlaylistBundle{
+		PlaylistID: playlistID,
+		PriceCents: req.PriceCents,
+		Currency:   models.PlaylistBundleCurrency,
+	}
+	if err := s.repo.UpsertBundle(ctx, bundle); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// RemoveBundle stops selling a playlist, making its clips free to view again
+func (s *PlaylistBundleService) RemoveBundle(ctx context.Context, playlistID, userID uuid.UUID) error {
+	if _, err := s.getOwnedPlaylist(ctx, playlistID, userID); err != nil {
+		return err
+	}
+	return s.repo.DeactivateBundle(ctx, playlistID)
+}
+
+func (s *PlaylistBundleService) getOwnedPlaylist(ctx context.Context, playlistID, userID uuid.UUID) (*models.Playlist, error) {
+	playlist, err := s.playlists.GetByID(ctx, playlistID)
+	if err != nil {
+		return nil, err
+	}
+	if playlist == nil {
+		return nil, ErrPlaylistBundlePlaylistNotFound
+	}
+	if playlist.UserID != userID {
+		return nil, ErrPlaylistBundleNotOwner
+	}
+	return playlist, nil
+}
+
+// GetAccess returns a playlist's bundle and the viewer's access to it
+func (s *PlaylistBundleService) GetAccess(ctx context.Context, playlistID uuid.UUID, userID *uuid.UUID) (*models.PlaylistBundleAccess, error) {
+	playlist, err := s.playlists.GetByID(ctx, playlistID)
+	if err != nil {
+		return nil, err
+	}
+	if playlist == nil {
+		return nil, ErrPlaylistBundlePlaylistNotFound
+	}
+	return s.CheckAccess(ctx, playlist, userID)
+}
+
+// CheckAccess decides whether a viewer may see a playlist's clips. Playlists
+// that aren't for sale are open to everyone who can see them; premium ones
+// only to the owner, collaborators and buyers.
+func (s *PlaylistBundleService) CheckAccess(ctx context.Context, playlist *models.Playlist, userID *uuid.UUID) (*models.PlaylistBundleAccess, error) {
+	bundle, err := s.repo.GetActiveBundle(ctx, playlist.ID)
+	if err != nil {
+		return nil, err
+	}
+	access := &models.PlaylistBundleAccess{Bundle: bundle, HasAccess: bundle == nil}
+	if bundle == nil || userID == nil {
+		return access, nil
+	}
+
+	if *userID == playlist.UserID {
+		access.HasAccess = true
+		return access, nil
+	}
+
+	permission, err := s.playlists.GetCollaboratorPermission(ctx, playlist.ID, *userID)
+	if err != nil {
+		return nil, err
+	}
+	if permission != "" {
+		access.HasAccess = true
+		return access, nil
+	}
+
+	purchased, err := s.repo.HasActiveEntitlement(ctx, playlist.ID, *userID)
+	if err != nil {
+		return nil, err
+	}
+	access.HasAccess = purchased
+	access.Purchased = purchased
+	return access, nil
+}
+
+// CreateCheckoutSession starts a one-time Stripe Checkout for a premium
+// playlist. Access is granted when Stripe reports the session paid.
+func (s *PlaylistBundleService) CreateCheckoutSession(ctx context.Context, playlistID uuid.UUID, buyer *models.User) (*models.CreateCheckoutSessionResponse, error) {
+	userID := buyer.ID
+	playlist, err := s.playlists.GetByID(ctx, playlistID)
+	if err != nil {
+		return nil, err
+	}
+	if playlist == nil || playlist.Visibility == models.PlaylistVisibilityPrivate {
+		return nil, ErrPlaylistBundlePlaylistNotFound
+	}
+	if playlist.UserID == userID {
+		return nil, ErrPlaylistBundleOwnPlaylist
+	}
+
+	access, err := s.CheckAccess(ctx, playlist, &userID)
+	if err != nil {
+		return nil, err
+	}
+	if access.Bundle == nil {
+		return nil, ErrPlaylistBundleNotForSale
+	}
+	if access.HasAccess {
+		return nil, ErrPlaylistBundleAlreadyPurchased
+	}
+	bundle := access.Bundle
+
+	playlistURL := fmt.Sprintf("%s/playlists/%s", strings.TrimRight(s.cfg.Server.BaseURL, "/"), playlistID)
+
+	// Without Stripe keys, return a mock session like subscription checkout does
+	if s.cfg.Stripe.SecretKey == "" {
+		return &models.CreateCheckoutSessionResponse{
+			SessionID:  "cs_test_mock",
+			SessionURL: playlistURL + "?purchase=success&session_id=cs_test_mock",
+		}, nil
+	}
+
+	metadata := map[string]string{
+		"purpose":     playlistBundlePurpose,
+		"playlist_id": playlistID.String(),
+		"user_id":     userID.String(),
+	}
+	params := &stripe.CheckoutSessionParams{
+		Mode:              stripe.String(string(stripe.CheckoutSessionModePayment)),
+		ClientReferenceID: stripe.String(userID.String()),
+		LineItems: []*stripe.CheckoutSessionLineItemParams{
+			{
+				PriceData: &stripe.CheckoutSessionLineItemPriceDataParams{
+					Currency:   stripe.String(bundle.Currency),
+					UnitAmount: stripe.Int64(int64(bundle.PriceCents)),
+					ProductData: &stripe.CheckoutSessionLineItemPriceDataProductDataParams{
+						Name: stripe.String(playlist.Title),
+					},
+				},
+				Quantity: stripe.Int64(1),
+			},
+		},
+		SuccessURL: stripe.String(playlistURL + "?purchase=success&session_id={CHECKOUT_SESSION_ID}"),
+		CancelURL:  stripe.String(playlistURL + "?purchase=cancelled"),
+		Metadata:   metadata,
+		// Copied onto the payment so refunds can be traced back to the bundle
+		PaymentIntentData: &stripe.CheckoutSessionPaymentIntentDataParams{
+			Metadata: metadata,
+		},
+	}
+	if buyer.Email != nil && *buyer.Email != "" {
+		params.CustomerEmail = buyer.Email
+	}
+	params.SetIdempotencyKey(fmt.Sprintf("bundle_checkout_%s_%s_%d", userID, playlistID, bundle.PriceCents))
+
+	sess, err := s.createSession(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create checkout session: %w", err)
+	}
+
+	return &models.CreateCheckoutSessionResponse{
+		SessionID:  sess.ID,
+		SessionURL: sess.URL,
+	}, nil
+}
+
+// HandleCheckoutCompleted grants access for a paid bundle checkout and
+// credits the creator's share to the ledger. Other checkouts are ignored.
+func (s *PlaylistBundleService) HandleCheckoutCompleted(ctx context.Context, event stripe.Event) error {
+	var sess stripe.CheckoutSession
+	if err := json.Unmarshal(event.Data.Raw, &sess); err != nil {
+		return fmt.Errorf("failed to unmarshal checkout session: %w", err)
+	}
+	if sess.Metadata["purpose"] != playlistBundlePurpose {
+		return nil
+	}
+
+	// Delayed payment methods complete unpaid and follow up with
+	// checkout.session.async_payment_succeeded
+	if sess.PaymentStatus != stripe.CheckoutSessionPaymentStatusPaid {
+		logWebhookInfo("Playlist bundle checkout completed before payment, waiting", map[string]interface{}{
+			"event_id":   event.ID,
+			"session_id": sess.ID,
+		})
+		return nil
+	}
+
+	playlistID, err := uuid.Parse(sess.Metadata["playlist_id"])
+	if err != nil {
+		return fmt.Errorf("invalid playlist_id in checkout metadata: %w", err)
+	}
+	userID, err := uuid.Parse(sess.Metadata["user_id"])
+	if err != nil {
+		return fmt.Errorf("invalid user_id in checkout metadata: %w", err)
+	}
+
+	playlist, err := s.playlists.GetByID(ctx, playlistID)
+	if err != nil {
+		return err
+	}
+	if playlist == nil {
+		// Nothing to grant; the payment has to be refunded by hand
+		logWebhookWarn("Playlist bundle paid for a deleted playlist", map[string]interface{}{
+			"event_id":    event.ID,
+			"session_id":  sess.ID,
+			"playlist_id": playlistID.String(),
+			"user_id":     userID.String(),
+		})
+		return nil
+	}
+
+	currency := string(sess.Currency)
+	entitlement := &models.PlaylistBundleEntitlement{
+		PlaylistID:              playlistID,
+		UserID:                  userID,
+		AmountCents:             sess.AmountTotal,
+		Currency:                currency,
+		StripeCheckoutSessionID: &sess.ID,
+	}
+	if sess.PaymentIntent != nil && sess.PaymentIntent.ID != "" {
+		entitlement.StripePaymentIntentID = &sess.PaymentIntent.ID
+	}
+
+	entry := s.ledgerEntry(playlist.UserID, models.CreatorLedgerEntryBundleSale, sess.AmountTotal, currency, event.ID)
+	if err := s.repo.GrantEntitlement(ctx, entitlement, entry); err != nil {
+		if errors.Is(err, repository.ErrPlaylistBundleAlreadyGranted) {
+			return nil
+		}
+		return err
+	}
+
+	logWebhookInfo("Granted playlist bundle entitlement", map[string]interface{}{
+		"event_id":      event.ID,
+		"playlist_id":   playlistID.String(),
+		"user_id":       userID.String(),
+		"amount_cents":  sess.AmountTotal,
+		"creator_cents": entry.CreatorCents,
+	})
+	return nil
+}
+
+// HandleChargeRefunded debits refunds of bundle payments from the creator
+// ledger. A full refund also revokes the buyer's access.
+func (s *PlaylistBundleService) HandleChargeRefunded(ctx context.Context, event stripe.Event) error {
+	var charge stripe.Charge
+	if err := json.Unmarshal(event.Data.Raw, &charge); err != nil {
+		return fmt.Errorf("failed to unmarshal charge: %w", err)
+	}
+	if charge.PaymentIntent == nil || charge.PaymentIntent.ID == "" {
+		return nil
+	}
+
+	entitlement, err := s.repo.GetEntitlementByPaymentIntent(ctx, charge.PaymentIntent.ID)
+	if err != nil {
+		return err
+	}
+	if entitlement == nil {
+		return nil
+	}
+
+	playlist, err := s.playlists.GetByID(ctx, entitlement.PlaylistID)
+	if err != nil {
+		return err
+	}
+
+	// Stripe reports the cumulative refunded amount, so only the part not yet
+	// in the ledger is debited
+	alreadyRefunded, err := s.repo.GetRefundedCents(ctx, entitlement.ID)
+	if err != nil {
+		return err
+	}
+	var entry *models.CreatorLedgerEntry
+	if delta := charge.AmountRefunded - alreadyRefunded; delta > 0 && playlist != nil {
+		entry = s.ledgerEntry(playlist.UserID, models.CreatorLedgerEntryBundleRefund, -delta, entitlement.Currency, event.ID)
+	}
+	if entry == nil && !charge.Refunded {
+		return nil
+	}
+
+	if err := s.repo.RecordRefund(ctx, entitlement.ID, charge.Refunded, entry); err != nil {
+		return err
+	}
+
+	logWebhookInfo("Recorded playlist bundle refund", map[string]interface{}{
+		"event_id":        event.ID,
+		"playlist_id":     entitlement.PlaylistID.String(),
+		"user_id":         entitlement.UserID.String(),
+		"amount_refunded": charge.AmountRefunded,
+		"revoked":         charge.Refunded,
+	})
+	return nil
+}
+
+// ledgerEntry splits an amount between the creator and the platform. The
+// creator's share is rounded toward zero, so a full refund (a negative
+// amount) reverses its sale exactly.
+func (s *PlaylistBundleService) ledgerEntry(creatorID uuid.UUID, entryType string, amount int64, currency, eventID string) *models.CreatorLedgerEntry {
+	share := int64(s.cfg.Stripe.BundleCreatorShare)
+	if share < 0 {
+		share = 0
+	}
+	if share > 100 {
+		share = 100
+	}
+	creatorCents := amount * share / 100
+
+	entry := &models.CreatorLedgerEntry{
+		CreatorID:        creatorID,
+		EntryType:        entryType,
+		GrossCents:       amount,
+		PlatformFeeCents: amount - creatorCents,
+		CreatorCents:     creatorCents,
+		Currency:         currency,
+	}
+	if eventID != "" {
+		entry.StripeEventID = &eventID
+	}
+	return entry
+}
+
+// ListLedger returns a creator's bundle earnings and their totals
+func (s *PlaylistBundleService) ListLedger(ctx context.Context, creatorID uuid.UUID, limit, offset int) ([]models.CreatorLedgerEntry, []models.CreatorLedgerSummary, error) {
+	entries, err := s.repo.ListLedgerEntries(ctx, creatorID, limit, offset)
+	if err != nil {
+		return nil, nil, err
+	}
+	summary, err := s.repo.GetLedgerSummary(ctx, creatorID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return entries, summary, nil
+}
@@ -0,0 +1,351 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/v81"
+	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+// MockPlaylistBundleRepository is a mock implementation of PlaylistBundleRepositoryInterface
+type MockPlaylistBundleRepository struct {
+	mock.Mock
+}
+
+func (m *MockPlaylistBundleRepository) UpsertBundle(ctx context.Context, bundle *models.PlaylistBundle) error {
+	args := m.Called(ctx, bundle)
+	return args.Error(0)
+}
+
+func (m *MockPlaylistBundleRepository) DeactivateBundle(ctx context.Context, playlistID uuid.UUID) error {
+	args := m.Called(ctx, playlistID)
+	return args.Error(0)
+}
+
+func (m *MockPlaylistBundleRepository) GetActiveBundle(ctx context.Context, playlistID uuid.UUID) (*models.PlaylistBundle, error) {
+	args := m.Called(ctx, playlistID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PlaylistBundle), args.Error(1)
+}
+
+func (m *MockPlaylistBundleRepository) HasActiveEntitlement(ctx context.Context, playlistID, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, playlistID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPlaylistBundleRepository) GrantEntitlement(ctx context.Context, entitlement *models.PlaylistBundleEntitlement, entry *models.CreatorLedgerEntry) error {
+	args := m.Called(ctx, entitlement, entry)
+	return args.Error(0)
+}
+
+func (m *MockPlaylistBundleRepository) GetEntitlementByPaymentIntent(ctx context.Context, paymentIntentID string) (*models.PlaylistBundleEntitlement, error) {
+	args := m.Called(ctx, paymentIntentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PlaylistBundleEntitlement), args.Error(1)
+}
+
+func (m *MockPlaylistBundleRepository) GetRefundedCents(ctx context.Context, entitlementID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, entitlementID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockPlaylistBundleRepository) RecordRefund(ctx context.Context, entitlementID uuid.UUID, revoke bool, entry *models.CreatorLedgerEntry) error {
+	args := m.Called(ctx, entitlementID, revoke, entry)
+	return args.Error(0)
+}
+
+func (m *MockPlaylistBundleRepository) ListLedgerEntries(ctx context.Context, creatorID uuid.UUID, limit, offset int) ([]models.CreatorLedgerEntry, error) {
+	args := m.Called(ctx, creatorID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CreatorLedgerEntry), args.Error(1)
+}
+
+func (m *MockPlaylistBundleRepository) GetLedgerSummary(ctx context.Context, creatorID uuid.UUID) ([]models.CreatorLedgerSummary, error) {
+	args := m.Called(ctx, creatorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CreatorLedgerSummary), args.Error(1)
+}
+
+// MockPlaylistBundlePlaylistSource is a mock implementation of PlaylistBundlePlaylistSource
+type MockPlaylistBundlePlaylistSource struct {
+	mock.Mock
+}
+
+func (m *MockPlaylistBundlePlaylistSource) GetByID(ctx context.Context, playlistID uuid.UUID) (*models.Playlist, error) {
+	args := m.Called(ctx, playlistID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Playlist), args.Error(1)
+}
+
+func (m *MockPlaylistBundlePlaylistSource) GetCollaboratorPermission(ctx context.Context, playlistID, userID uuid.UUID) (string, error) {
+	args := m.Called(ctx, playlistID, userID)
+	return args.String(0), args.Error(1)
+}
+
+func setupPlaylistBundleServiceTest() (*PlaylistBundleService, *MockPlaylistBundleRepository, *MockPlaylistBundlePlaylistSource, *models.Playlist) {
+	playlist := &models.Playlist{
+		ID:         uuid.New(),
+		UserID:     uuid.New(),
+		Title:      "Best of the season",
+		Visibility: models.PlaylistVisibilityPublic,
+	}
+	playlists := new(MockPlaylistBundlePlaylistSource)
+	playlists.On("GetByID", mock.Anything, playlist.ID).Return(playlist, nil)
+
+	cfg := &config.Config{}
+	cfg.Server.BaseURL = "https://clipper.example"
+	cfg.Stripe.BundleCreatorShare = 80
+
+	repo := new(MockPlaylistBundleRepository)
+	return NewPlaylistBundleService(repo, playlists, cfg), repo, playlists, playlist
+}
+
+func stripeTestEvent(t *testing.T, id, eventType string, object interface{}) stripe.Event {
+	t.Helper()
+	raw, err := json.Marshal(object)
+	require.NoError(t, err)
+	return stripe.Event{ID: id, Type: stripe.EventType(eventType), Data: &stripe.EventData{Raw: raw}}
+}
+
+func bundleCheckoutEvent(t *testing.T, id string, playlistID, userID uuid.UUID, amount int64) stripe.Event {
+	return stripeTestEvent(t, id, "checkout.session.completed", map[string]interface{}{
+		"id":             "cs_" + id,
+		"object":         "checkout.session",
+		"payment_status": "paid",
+		"amount_total":   amount,
+		"currency":       "usd",
+		"payment_intent": "pi_" + id,
+		"metadata": map[string]string{
+			"purpose":     playlistBundlePurpose,
+			"playlist_id": playlistID.String(),
+			"user_id":     userID.String(),
+		},
+	})
+}
+
+func TestPlaylistBundleSetBundle(t *testing.T) {
+	svc, repo, _, playlist := setupPlaylistBundleServiceTest()
+	ctx := context.Background()
+
+	repo.On("UpsertBundle", ctx, mock.MatchedBy(func(b *models.PlaylistBundle) bool {
+		return b.PlaylistID == playlist.ID
+	})).Return(nil).Once()
+
+	_, err := svc.SetBundle(ctx, playlist.ID, uuid.New(), &models.SetPlaylistBundleRequest{PriceCents: 500})
+	assert.ErrorIs(t, err, ErrPlaylistBundleNotOwner)
+
+	bundle, err := svc.SetBundle(ctx, playlist.ID, playlist.UserID, &models.SetPlaylistBundleRequest{PriceCents: 500})
+	require.NoError(t, err)
+	assert.Equal(t, 500, bundle.PriceCents)
+	assert.Equal(t, models.PlaylistBundleCurrency, bundle.Currency)
+
+	playlist.Visibility = models.PlaylistVisibilityPrivate
+	_, err = svc.SetBundle(ctx, playlist.ID, playlist.UserID, &models.SetPlaylistBundleRequest{PriceCents: 500})
+	assert.ErrorIs(t, err, ErrPlaylistBundlePrivate)
+
+	repo.AssertExpectations(t)
+}
+
+func TestPlaylistBundleCheckAccess(t *testing.T) {
+	svc, repo, playlists, playlist := setupPlaylistBundleServiceTest()
+	ctx := context.Background()
+	viewer, collaborator := uuid.New(), uuid.New()
+
+	repo.On("GetActiveBundle", ctx, playlist.ID).Return(nil, nil).Once()
+	access, err := svc.CheckAccess(ctx, playlist, nil)
+	require.NoError(t, err)
+	assert.True(t, access.HasAccess, "playlists that aren't for sale are open")
+
+	bundle := &models.PlaylistBundle{PlaylistID: playlist.ID, PriceCents: 500, Currency: models.PlaylistBundleCurrency, IsActive: true}
+	repo.On("GetActiveBundle", ctx, playlist.ID).Return(bundle, nil)
+	playlists.On("GetCollaboratorPermission", ctx, playlist.ID, viewer).Return("", nil)
+	playlists.On("GetCollaboratorPermission", ctx, playlist.ID, collaborator).Return("view", nil)
+
+	access, err = svc.CheckAccess(ctx, playlist, nil)
+	require.NoError(t, err)
+	assert.False(t, access.HasAccess)
+	assert.NotNil(t, access.Bundle)
+
+	repo.On("HasActiveEntitlement", ctx, playlist.ID, viewer).Return(false, nil).Once()
+	access, err = svc.CheckAccess(ctx, playlist, &viewer)
+	require.NoError(t, err)
+	assert.False(t, access.HasAccess)
+
+	access, err = svc.CheckAccess(ctx, playlist, &playlist.UserID)
+	require.NoError(t, err)
+	assert.True(t, access.HasAccess, "owner always has access")
+
+	access, err = svc.CheckAccess(ctx, playlist, &collaborator)
+	require.NoError(t, err)
+	assert.True(t, access.HasAccess, "collaborators have access")
+
+	repo.On("HasActiveEntitlement", ctx, playlist.ID, viewer).Return(true, nil).Once()
+	access, err = svc.CheckAccess(ctx, playlist, &viewer)
+	require.NoError(t, err)
+	assert.True(t, access.HasAccess)
+	assert.True(t, access.Purchased)
+
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "HasActiveEntitlement", ctx, playlist.ID, collaborator)
+}
+
+func TestPlaylistBundleCreateCheckoutSession(t *testing.T) {
+	svc, repo, playlists, playlist := setupPlaylistBundleServiceTest()
+	ctx := context.Background()
+	svc.cfg.Stripe.SecretKey = "sk_test"
+	email := "buyer@example.com"
+	buyer := &models.User{ID: uuid.New(), Email: &email}
+
+	var params *stripe.CheckoutSessionParams
+	svc.createSession = func(p *stripe.CheckoutSessionParams) (*stripe.CheckoutSession, error) {
+		params = p
+		return &stripe.CheckoutSession{ID: "cs_new", URL: "https://checkout.stripe.test/cs_new"}, nil
+	}
+
+	repo.On("GetActiveBundle", ctx, playlist.ID).Return(nil, nil).Once()
+	_, err := svc.CreateCheckoutSession(ctx, playlist.ID, buyer)
+	assert.ErrorIs(t, err, ErrPlaylistBundleNotForSale)
+
+	repo.On("GetActiveBundle", ctx, playlist.ID).Return(&models.PlaylistBundle{
+		PlaylistID: playlist.ID, PriceCents: 750, Currency: models.PlaylistBundleCurrency, IsActive: true,
+	}, nil)
+	playlists.On("GetCollaboratorPermission", ctx, playlist.ID, buyer.ID).Return("", nil)
+	repo.On("HasActiveEntitlement", ctx, playlist.ID, buyer.ID).Return(false, nil)
+
+	_, err = svc.CreateCheckoutSession(ctx, playlist.ID, &models.User{ID: playlist.UserID})
+	assert.ErrorIs(t, err, ErrPlaylistBundleOwnPlaylist)
+
+	resp, err := svc.CreateCheckoutSession(ctx, playlist.ID, buyer)
+	require.NoError(t, err)
+	assert.Equal(t, "cs_new", resp.SessionID)
+	require.NotNil(t, params)
+	assert.Equal(t, string(stripe.CheckoutSessionModePayment), *params.Mode)
+	assert.Equal(t, int64(750), *params.LineItems[0].PriceData.UnitAmount)
+	assert.Equal(t, playlistBundlePurpose, params.Metadata["purpose"])
+	assert.Equal(t, playlist.ID.String(), params.PaymentIntentData.Metadata["playlist_id"])
+	assert.Equal(t, email, *params.CustomerEmail)
+
+	repo.AssertExpectations(t)
+}
+
+func TestPlaylistBundleCheckoutCompletedGrantsAndSplits(t *testing.T) {
+	svc, repo, _, playlist := setupPlaylistBundleServiceTest()
+	ctx := context.Background()
+	buyer := uuid.New()
+
+	var entitlement *models.PlaylistBundleEntitlement
+	var entry *models.CreatorLedgerEntry
+	repo.On("GrantEntitlement", ctx, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			entitlement = args.Get(1).(*models.PlaylistBundleEntitlement)
+			entry = args.Get(2).(*models.CreatorLedgerEntry)
+		}).
+		Return(nil).Once()
+	repo.On("GrantEntitlement", ctx, mock.Anything, mock.Anything).Return(repository.ErrPlaylistBundleAlreadyGranted).Once()
+
+	event := bundleCheckoutEvent(t, "evt_sale", playlist.ID, buyer, 999)
+	require.NoError(t, svc.HandleCheckoutCompleted(ctx, event))
+	// Stripe may deliver the same checkout more than once
+	require.NoError(t, svc.HandleCheckoutCompleted(ctx, event))
+
+	require.NotNil(t, entitlement)
+	assert.Equal(t, buyer, entitlement.UserID)
+	assert.Equal(t, "pi_evt_sale", *entitlement.StripePaymentIntentID)
+
+	require.NotNil(t, entry)
+	assert.Equal(t, playlist.UserID, entry.CreatorID)
+	assert.Equal(t, models.CreatorLedgerEntryBundleSale, entry.EntryType)
+	assert.Equal(t, int64(999), entry.GrossCents)
+	assert.Equal(t, int64(799), entry.CreatorCents)
+	assert.Equal(t, int64(200), entry.PlatformFeeCents)
+
+	repo.AssertExpectations(t)
+}
+
+func TestPlaylistBundleCheckoutCompletedIgnoresOtherCheckouts(t *testing.T) {
+	svc, repo, _, _ := setupPlaylistBundleServiceTest()
+
+	event := stripeTestEvent(t, "evt_sub", "checkout.session.completed", map[string]interface{}{
+		"id":             "cs_sub",
+		"payment_status": "paid",
+		"metadata":       map[string]string{"user_id": uuid.NewString()},
+	})
+	require.NoError(t, svc.HandleCheckoutCompleted(context.Background(), event))
+	repo.AssertNotCalled(t, "GrantEntitlement", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPlaylistBundleChargeRefunded(t *testing.T) {
+	svc, repo, _, playlist := setupPlaylistBundleServiceTest()
+	ctx := context.Background()
+	entitlement := &models.PlaylistBundleEntitlement{
+		ID:          uuid.New(),
+		PlaylistID:  playlist.ID,
+		UserID:      uuid.New(),
+		AmountCents: 1000,
+		Currency:    "usd",
+		Status:      models.PlaylistBundleEntitlementActive,
+	}
+	repo.On("GetEntitlementByPaymentIntent", ctx, "pi_evt_sale").Return(entitlement, nil)
+	repo.On("GetEntitlementByPaymentIntent", ctx, "pi_other").Return(nil, nil)
+
+	var refunds []*models.CreatorLedgerEntry
+	captureRefund := func(args mock.Arguments) {
+		refunds = append(refunds, args.Get(3).(*models.CreatorLedgerEntry))
+	}
+
+	refund := func(id string, refunded int64, full bool) stripe.Event {
+		return stripeTestEvent(t, id, "charge.refunded", map[string]interface{}{
+			"id":              "ch_1",
+			"payment_intent":  "pi_evt_sale",
+			"amount":          1000,
+			"amount_refunded": refunded,
+			"refunded":        full,
+		})
+	}
+
+	// A partial refund is debited but keeps access
+	repo.On("GetRefundedCents", ctx, entitlement.ID).Return(int64(0), nil).Once()
+	repo.On("RecordRefund", ctx, entitlement.ID, false, mock.Anything).Run(captureRefund).Return(nil).Once()
+	require.NoError(t, svc.HandleChargeRefunded(ctx, refund("evt_partial", 250, false)))
+	require.Len(t, refunds, 1)
+	assert.Equal(t, int64(-250), refunds[0].GrossCents)
+	assert.Equal(t, int64(-200), refunds[0].CreatorCents)
+
+	// The full refund only debits the remainder and revokes access
+	repo.On("GetRefundedCents", ctx, entitlement.ID).Return(int64(250), nil).Once()
+	repo.On("RecordRefund", ctx, entitlement.ID, true, mock.Anything).Run(captureRefund).Return(nil).Once()
+	require.NoError(t, svc.HandleChargeRefunded(ctx, refund("evt_full", 1000, true)))
+	require.Len(t, refunds, 2)
+	assert.Equal(t, int64(-750), refunds[1].GrossCents)
+	assert.Equal(t, int64(-600), refunds[1].CreatorCents)
+
+	// Together the refunds reverse the creator's 80% share of the sale
+	assert.Equal(t, int64(-800), refunds[0].CreatorCents+refunds[1].CreatorCents)
+
+	// Charges that didn't buy a bundle are ignored
+	other := stripeTestEvent(t, "evt_other", "charge.refunded", map[string]interface{}{
+		"id": "ch_2", "payment_intent": "pi_other", "amount_refunded": 500, "refunded": true,
+	})
+	require.NoError(t, svc.HandleChargeRefunded(ctx, other))
+
+	repo.AssertExpectations(t)
+	repo.AssertNumberOfCalls(t, "RecordRefund", 2)
+}
@@ -17,6 +17,12 @@ type PlaylistService struct {
 	playlistRepo *repository.PlaylistRepository
 	clipRepo     *repository.ClipRepository
 	baseURL      string
+	bundles      PlaylistBundleAccessChecker
+}
+
+// PlaylistBundleAccessChecker decides whether a viewer may see a premium playlist's clips
+type PlaylistBundleAccessChecker interface {
+	CheckAccess(ctx context.Context, playlist *models.Playlist, userID *uuid.UUID) (*models.PlaylistBundleAccess, error)
 }
 
 // NewPlaylistService creates a new PlaylistService
@@ -28,6 +34,24 @@ func NewPlaylistService(playlistRepo *repository.PlaylistRepository, clipRepo *r
 	}
 }
 
+// SetBundleAccessChecker sets the service that gates the clips of premium playlists
+func (s *PlaylistService) SetBundleAccessChecker(bundles PlaylistBundleAccessChecker) {
+	s.bundles = bundles
+}
+
+// checkBundleAccess returns the viewer's access to a premium playlist, or nil
+// when bundles aren't enabled
+func (s *PlaylistService) checkBundleAccess(ctx context.Context, playlist *models.Playlist, userID *uuid.UUID) (*models.PlaylistBundleAccess, error) {
+	if s.bundles == nil {
+		return nil, nil
+	}
+	access, err := s.bundles.CheckAccess(ctx, playlist, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check bundle access: %w", err)
+	}
+	return access, nil
+}
+
 // CreatePlaylist creates a new playlist
 func (s *PlaylistService) CreatePlaylist(ctx context.Context, userID uuid.UUID, req *models.CreatePlaylistRequest) (*models.Playlist, error) {
 	// Set default visibility if not provided
@@ -75,6 +99,15 @@ func (s *PlaylistService) CopyPlaylist(ctx context.Context, playlistID, userID u
 		}
 	}
 
+	// Copying a premium playlist would hand out its clips for free
+	access, err := s.checkBundleAccess(ctx, source, &userID)
+	if err != nil {
+		return nil, err
+	}
+	if access != nil && !access.HasAccess {
+		return nil, ErrPlaylistPurchaseRequired
+	}
+
 	// Build new playlist fields
 	newTitle := fmt.Sprintf("Copy of %s", source.Title)
 	if req != nil && req.Title != nil {
@@ -148,11 +181,21 @@ func (s *PlaylistService) GetPlaylist(ctx context.Context, playlistID uuid.UUID,
 		return nil, fmt.Errorf("failed to get clip count: %w", err)
 	}
 
-	// Get clips with pagination
-	offset := (page - 1) * limit
-	clips, _, err := s.playlistRepo.GetClips(ctx, playlistID, limit, offset)
+	// Premium playlists only list their clips to buyers
+	access, err := s.checkBundleAccess(ctx, playlist, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get clips: %w", err)
+		return nil, err
+	}
+	locked := access != nil && !access.HasAccess
+
+	// Get clips with pagination
+	var clips []models.PlaylistClipRef
+	if !locked {
+		offset := (page - 1) * limit
+		clips, _, err = s.playlistRepo.GetClips(ctx, playlistID, limit, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get clips: %w", err)
+		}
 	}
 
 	// Get creator information
@@ -184,6 +227,10 @@ func (s *PlaylistService) GetPlaylist(ctx context.Context, playlistID uuid.UUID,
 		Creator:   creator,
 	}
 	result.CurrentUserPermission = currentPermission
+	if access != nil && access.Bundle != nil {
+		result.Bundle = access
+		result.IsLocked = locked
+	}
 
 	return result, nil
 }
@@ -216,11 +263,21 @@ func (s *PlaylistService) GetPlaylistByShareToken(ctx context.Context, shareToke
 		return nil, fmt.Errorf("failed to get clip count: %w", err)
 	}
 
-	// Get clips with pagination
-	offset := (page - 1) * limit
-	clips, _, err := s.playlistRepo.GetClips(ctx, playlist.ID, limit, offset)
+	// Premium playlists only list their clips to buyers
+	access, err := s.checkBundleAccess(ctx, playlist, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get clips: %w", err)
+		return nil, err
+	}
+	locked := access != nil && !access.HasAccess
+
+	// Get clips with pagination
+	var clips []models.PlaylistClipRef
+	if !locked {
+		offset := (page - 1) * limit
+		clips, _, err = s.playlistRepo.GetClips(ctx, playlist.ID, limit, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get clips: %w", err)
+		}
 	}
 
 	// Get creator information
@@ -252,6 +309,10 @@ func (s *PlaylistService) GetPlaylistByShareToken(ctx context.Context, shareToke
 		Creator:   creator,
 	}
 	result.CurrentUserPermission = currentPermission
+	if access != nil && access.Bundle != nil {
+		result.Bundle = access
+		result.IsLocked = locked
+	}
 
 	return result, nil
 }
@@ -358,6 +419,9 @@ func (s *PlaylistService) ListPublicPlaylists(ctx context.Context, userID *uuid.
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to list public playlists: %w", err)
 	}
+	if err := s.withholdLockedPreviews(ctx, playlists, userID); err != nil {
+		return nil, 0, err
+	}
 
 	return playlists, total, nil
 }
@@ -369,12 +433,38 @@ func (s *PlaylistService) ListFeaturedPlaylists(ctx context.Context, userID *uui
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to list featured playlists: %w", err)
 	}
+	if err := s.withholdLockedPreviews(ctx, playlists, userID); err != nil {
+		return nil, 0, err
+	}
 	return playlists, total, nil
 }
 
 // GetPlaylistOfTheDay returns the most recent daily-generated playlist.
 func (s *PlaylistService) GetPlaylistOfTheDay(ctx context.Context, userID *uuid.UUID) (*models.PlaylistListItem, error) {
-	return s.playlistRepo.GetPlaylistOfTheDay(ctx, userID)
+	playlist, err := s.playlistRepo.GetPlaylistOfTheDay(ctx, userID)
+	if err != nil || playlist == nil {
+		return playlist, err
+	}
+	if err := s.withholdLockedPreviews(ctx, []*models.PlaylistListItem{playlist}, userID); err != nil {
+		return nil, err
+	}
+	return playlist, nil
+}
+
+// withholdLockedPreviews drops the preview clips of premium playlists the
+// viewer hasn't bought, the same gate GetPlaylist applies to the full listing
+func (s *PlaylistService) withholdLockedPreviews(ctx context.Context, playlists []*models.PlaylistListItem, userID *uuid.UUID) error {
+	for _, playlist := range playlists {
+		access, err := s.checkBundleAccess(ctx, &playlist.Playlist, userID)
+		if err != nil {
+			return err
+		}
+		if access != nil && !access.HasAccess {
+			playlist.PreviewClips = nil
+			playlist.IsLocked = true
+		}
+	}
+	return nil
 }
 
 // AddClipsToPlaylist adds multiple clips to a playlist
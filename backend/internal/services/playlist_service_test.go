@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// MockPlaylistBundleAccessChecker is a mock implementation of PlaylistBundleAccessChecker
+type MockPlaylistBundleAccessChecker struct {
+	mock.Mock
+}
+
+func (m *MockPlaylistBundleAccessChecker) CheckAccess(ctx context.Context, playlist *models.Playlist, userID *uuid.UUID) (*models.PlaylistBundleAccess, error) {
+	args := m.Called(ctx, playlist, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PlaylistBundleAccess), args.Error(1)
+}
+
+func TestPlaylistService_WithholdLockedPreviews(t *testing.T) {
+	ctx := context.Background()
+	viewerID := uuid.New()
+	bundle := &models.PlaylistBundle{PriceCents: 499}
+
+	free := &models.PlaylistListItem{
+		Playlist:     models.Playlist{ID: uuid.New()},
+		PreviewClips: []models.Clip{{ID: uuid.New(), TwitchClipID: "FreeClip"}},
+	}
+	locked := &models.PlaylistListItem{
+		Playlist:     models.Playlist{ID: uuid.New()},
+		PreviewClips: []models.Clip{{ID: uuid.New(), TwitchClipID: "PaidClip"}},
+	}
+	purchased := &models.PlaylistListItem{
+		Playlist:     models.Playlist{ID: uuid.New()},
+		PreviewClips: []models.Clip{{ID: uuid.New(), TwitchClipID: "BoughtClip"}},
+	}
+
+	bundles := new(MockPlaylistBundleAccessChecker)
+	bundles.On("CheckAccess", ctx, &free.Playlist, &viewerID).Return(&models.PlaylistBundleAccess{HasAccess: true}, nil)
+	bundles.On("CheckAccess", ctx, &locked.Playlist, &viewerID).Return(&models.PlaylistBundleAccess{Bundle: bundle}, nil)
+	bundles.On("CheckAccess", ctx, &purchased.Playlist, &viewerID).Return(&models.PlaylistBundleAccess{Bundle: bundle, HasAccess: true, Purchased: true}, nil)
+
+	service := &PlaylistService{}
+	service.SetBundleAccessChecker(bundles)
+
+	err := service.withholdLockedPreviews(ctx, []*models.PlaylistListItem{free, locked, purchased}, &viewerID)
+	require.NoError(t, err)
+
+	assert.Len(t, free.PreviewClips, 1)
+	assert.False(t, free.IsLocked)
+	assert.Empty(t, locked.PreviewClips, "a locked bundle must not leak its clips through previews")
+	assert.True(t, locked.IsLocked)
+	assert.Len(t, purchased.PreviewClips, 1)
+	assert.False(t, purchased.IsLocked)
+
+	bundles.AssertExpectations(t)
+}
+
+func TestPlaylistService_WithholdLockedPreviewsWithoutBundles(t *testing.T) {
+	item := &models.PlaylistListItem{
+		Playlist:     models.Playlist{ID: uuid.New()},
+		PreviewClips: []models.Clip{{ID: uuid.New()}},
+	}
+
+	service := &PlaylistService{}
+	err := service.withholdLockedPreviews(context.Background(), []*models.PlaylistListItem{item}, nil)
+	require.NoError(t, err)
+	assert.Len(t, item.PreviewClips, 1)
+	assert.False(t, item.IsLocked)
+}
@@ -0,0 +1,318 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	redisv9 "github.com/redis/go-redis/v9"
+	"github.com/subculture-collective/clipper/internal/models"
+	redispkg "github.com/subculture-collective/clipper/pkg/redis"
+)
+
+const (
+	// profileViewDedupeWindow is how long repeat views by the same viewer
+	// aren't counted again
+	profileViewDedupeWindow = 30 * time.Minute
+	// profileViewUniqueDays is the window unique viewers are estimated over
+	profileViewUniqueDays = 30
+	// profileViewKeyTTL keeps a day's counters long enough to be rolled up
+	// and counted in the unique viewer window
+	profileViewKeyTTL = (profileViewUniqueDays + 1) * 24 * time.Hour
+)
+
+// profileViewBotPattern matches user agents of crawlers, link previewers,
+// monitors and scripted clients, whose views aren't counted
+var profileViewBotPattern = regexp.MustCompile(`(?i)bot|crawl|spider|slurp|facebookexternalhit|embedly|preview|curl|wget|python-requests|python-urllib|go-http-client|java/|okhttp|axios|node-fetch|headless|phantomjs|lighthouse|pingdom|uptime|monitor`)
+
+// ErrProfileViewsHidden is returned when someone other than the owner asks
+// for a view counter the owner hasn't made public
+var ErrProfileViewsHidden = errors.New("profile view counter is hidden")
+
+// IsBotUserAgent reports whether a user agent is missing or belongs to a bot
+func IsBotUserAgent(userAgent string) bool {
+	userAgent = strings.TrimSpace(userAgent)
+	return userAgent == "" || profileViewBotPattern.MatchString(userAgent)
+}
+
+// ProfileViewStore counts profile views and unique viewers per day
+type ProfileViewStore interface {
+	// Record counts a view unless the viewer viewed the profile within the
+	// dedupe window, and reports whether it was counted
+	Record(ctx context.Context, profileID uuid.UUID, viewerKey string, day time.Time) (bool, error)
+	DayCounts(ctx context.Context, profileID uuid.UUID, day time.Time) (views, uniqueViewers int64, err error)
+	UniqueViewers(ctx context.Context, profileID uuid.UUID, days []time.Time) (int64, error)
+	// ViewedProfiles returns the profiles viewed on a day
+	ViewedProfiles(ctx context.Context, day time.Time) ([]uuid.UUID, error)
+}
+
+// RedisProfileViewStore counts profile views in Redis. Unique viewers are
+// kept in a HyperLogLog per profile and day, so viewers themselves aren't
+// stored. A profile's keys share a hash tag so they can be counted together
+// on a cluster.
+//
+//nolint:revive // exported type needed for wiring
+type RedisProfileViewStore struct {
+	client *redispkg.Client
+}
+
+// NewRedisProfileViewStore constructs a Redis-backed store
+func NewRedisProfileViewStore(client *redispkg.Client) *RedisProfileViewStore {
+	if client == nil {
+		return nil
+	}
+	return &RedisProfileViewStore{client: client}
+}
+
+func profileViewKey(profileID uuid.UUID, kind string, day time.Time) string {
+	return fmt.Sprintf("profile_views:{%s}:%s:%s", profileID, kind, day.Format(time.DateOnly))
+}
+
+func profileViewedKey(day time.Time) string {
+	return "profile_views:viewed:" + day.Format(time.DateOnly)
+}
+
+// Record counts a view unless the viewer viewed the profile within the dedupe window
+func (s *RedisProfileViewStore) Record(ctx context.Context, profileID uuid.UUID, viewerKey string, day time.Time) (bool, error) {
+	if s == nil || s.client == nil {
+		return false, nil
+	}
+
+	seenKey := fmt.Sprintf("profile_views:{%s}:seen:%s", profileID, viewerKey)
+	first, err := s.client.SetNX(ctx, seenKey, 1, profileViewDedupeWindow)
+	if err != nil || !first {
+		return false, err
+	}
+
+	viewsKey := profileViewKey(profileID, "views", day)
+	viewersKey := profileViewKey(profileID, "viewers", day)
+	viewedKey := profileViewedKey(day)
+
+	pipe := s.client.GetClient().TxPipeline()
+	pipe.Incr(ctx, viewsKey)
+	pipe.Expire(ctx, viewsKey, profileViewKeyTTL)
+	pipe.PFAdd(ctx, viewersKey, viewerKey)
+	pipe.Expire(ctx, viewersKey, profileViewKeyTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, err
+	}
+
+	pipe = s.client.GetClient().Pipeline()
+	pipe.SAdd(ctx, viewedKey, profileID.String())
+	pipe.Expire(ctx, viewedKey, profileViewKeyTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+// DayCounts returns a profile's views and estimated unique viewers on a day
+func (s *RedisProfileViewStore) DayCounts(ctx context.Context, profileID uuid.UUID, day time.Time) (int64, int64, error) {
+	if s == nil || s.client == nil {
+		return 0, 0, nil
+	}
+
+	pipe := s.client.GetClient().Pipeline()
+	views := pipe.Get(ctx, profileViewKey(profileID, "views", day))
+	viewers := pipe.PFCount(ctx, profileViewKey(profileID, "viewers", day))
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redisv9.Nil) {
+		return 0, 0, err
+	}
+
+	viewCount, err := views.Int64()
+	if err != nil && !errors.Is(err, redisv9.Nil) {
+		return 0, 0, err
+	}
+	return viewCount, viewers.Val(), nil
+}
+
+// UniqueViewers estimates a profile's unique viewers across the given days
+func (s *RedisProfileViewStore) UniqueViewers(ctx context.Context, profileID uuid.UUID, days []time.Time) (int64, error) {
+	if s == nil || s.client == nil || len(days) == 0 {
+		return 0, nil
+	}
+
+	keys := make([]string, len(days))
+	for i, day := range days {
+		keys[i] = profileViewKey(profileID, "viewers", day)
+	}
+	return s.client.GetClient().PFCount(ctx, keys...).Result()
+}
+
+// ViewedProfiles returns the profiles viewed on a day
+func (s *RedisProfileViewStore) ViewedProfiles(ctx context.Context, day time.Time) ([]uuid.UUID, error) {
+	if s == nil || s.client == nil {
+		return nil, nil
+	}
+
+	members, err := s.client.GetClient().SMembers(ctx, profileViewedKey(day)).Result()
+	if err != nil {
+		return nil, err
+	}
+	profileIDs := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		if id, err := uuid.Parse(member); err == nil {
+			profileIDs = append(profileIDs, id)
+		}
+	}
+	return profileIDs, nil
+}
+
+// ProfileViewRepositoryInterface defines the repository methods used by ProfileViewService
+type ProfileViewRepositoryInterface interface {
+	GetShowProfileViews(ctx context.Context, userID uuid.UUID) (bool, error)
+	UpsertDaily(ctx context.Context, userID uuid.UUID, day time.Time, views, uniqueViewers int64) error
+	GetTotalViewsBefore(ctx context.Context, userID uuid.UUID, day time.Time) (int64, error)
+	ListDaily(ctx context.Context, userID uuid.UUID, since time.Time) ([]models.ProfileViewDay, error)
+}
+
+// ProfileViewService counts public profile views. Bots and owners viewing
+// their own profile aren't counted, and repeat views by a viewer only count
+// once per dedupe window. Anonymous viewers are told apart by a hash of
+// their IP address and user agent that changes daily; raw addresses are
+// never stored. Counts live in Redis and are rolled up daily into
+// daily_analytics.
+type ProfileViewService struct {
+	repo  ProfileViewRepositoryInterface
+	store ProfileViewStore
+	now   func() time.Time
+}
+
+// NewProfileViewService creates a new ProfileViewService
+func NewProfileViewService(repo ProfileViewRepositoryInterface, store ProfileViewStore) *ProfileViewService {
+	return &ProfileViewService{
+		repo:  repo,
+		store: store,
+		now:   time.Now,
+	}
+}
+
+func (s *ProfileViewService) today() time.Time {
+	return s.now().UTC().Truncate(24 * time.Hour)
+}
+
+// profileViewerKey identifies a viewer for deduplication and unique counts
+func profileViewerKey(viewerID *uuid.UUID, clientIP, userAgent string, day time.Time) string {
+	if viewerID != nil {
+		return "u:" + viewerID.String()
+	}
+	sum := sha256.Sum256([]byte(clientIP + "|" + userAgent + "|" + day.Format(time.DateOnly)))
+	return "a:" + hex.EncodeToString(sum[:16])
+}
+
+// RecordView counts a view of a profile and reports whether it was counted
+func (s *ProfileViewService) RecordView(ctx context.Context, profileID uuid.UUID, viewerID *uuid.UUID, clientIP, userAgent string) (bool, error) {
+	if IsBotUserAgent(userAgent) {
+		return false, nil
+	}
+	if viewerID != nil && *viewerID == profileID {
+		return false, nil
+	}
+
+	if _, err := s.repo.GetShowProfileViews(ctx, profileID); err != nil {
+		return false, err
+	}
+
+	day := s.today()
+	return s.store.Record(ctx, profileID, profileViewerKey(viewerID, clientIP, userAgent, day), day)
+}
+
+// GetStats returns a profile's view counter. Owners always see theirs, with
+// a daily breakdown of the last 30 days; others only when the owner shows it.
+func (s *ProfileViewService) GetStats(ctx context.Context, profileID uuid.UUID, viewerID *uuid.UUID) (*models.ProfileViewStats, error) {
+	show, err := s.repo.GetShowProfileViews(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	isOwner := viewerID != nil && *viewerID == profileID
+	if !show && !isOwner {
+		return nil, ErrProfileViewsHidden
+	}
+
+	today := s.today()
+	yesterday := today.AddDate(0, 0, -1)
+	since := today.AddDate(0, 0, -(profileViewUniqueDays - 1))
+
+	total, err := s.repo.GetTotalViewsBefore(ctx, profileID, yesterday)
+	if err != nil {
+		return nil, err
+	}
+	rolledUp, err := s.repo.ListDaily(ctx, profileID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	// Yesterday may not be rolled up yet, and today never is, so their live
+	// counts are used when ahead of the rollup
+	daily := make(map[time.Time]models.ProfileViewDay, profileViewUniqueDays)
+	for _, day := range rolledUp {
+		date := day.Date.UTC().Truncate(24 * time.Hour)
+		day.Date = date
+		daily[date] = day
+	}
+	for _, date := range []time.Time{yesterday, today} {
+		views, uniqueViewers, err := s.store.DayCounts(ctx, profileID, date)
+		if err != nil {
+			return nil, err
+		}
+		if views > daily[date].Views {
+			daily[date] = models.ProfileViewDay{Date: date, Views: views, UniqueViewers: uniqueViewers}
+		}
+		total += daily[date].Views
+	}
+
+	days := make([]time.Time, 0, profileViewUniqueDays)
+	for date := since; !date.After(today); date = date.AddDate(0, 0, 1) {
+		days = append(days, date)
+	}
+	uniqueViewers, err := s.store.UniqueViewers(ctx, profileID, days)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &models.ProfileViewStats{
+		UserID:              profileID,
+		TotalViews:          total,
+		UniqueViewers30Days: uniqueViewers,
+	}
+	if isOwner {
+		stats.Daily = make([]models.ProfileViewDay, len(days))
+		for i, date := range days {
+			day := daily[date]
+			day.Date = date
+			stats.Daily[i] = day
+		}
+	}
+	return stats, nil
+}
+
+// RollupDaily stores yesterday's and today's counts of every viewed profile
+// in daily_analytics, and returns how many profile days were stored.
+// Rolling up yesterday again catches views counted after its last run.
+func (s *ProfileViewService) RollupDaily(ctx context.Context) (int, error) {
+	today := s.today()
+	rolledUp := 0
+	for _, day := range []time.Time{today.AddDate(0, 0, -1), today} {
+		profileIDs, err := s.store.ViewedProfiles(ctx, day)
+		if err != nil {
+			return rolledUp, err
+		}
+		for _, profileID := range profileIDs {
+			views, uniqueViewers, err := s.store.DayCounts(ctx, profileID, day)
+			if err != nil {
+				return rolledUp, err
+			}
+			if err := s.repo.UpsertDaily(ctx, profileID, day, views, uniqueViewers); err != nil {
+				return rolledUp, err
+			}
+			rolledUp++
+		}
+	}
+	return rolledUp, nil
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+// MockProfileViewRepository is a mock implementation of ProfileViewRepositoryInterface
+type MockProfileViewRepository struct {
+	mock.Mock
+}
+
+func (m *MockProfileViewRepository) GetShowProfileViews(ctx context.Context, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockProfileViewRepository) UpsertDaily(ctx context.Context, userID uuid.UUID, day time.Time, views, uniqueViewers int64) error {
+	args := m.Called(ctx, userID, day, views, uniqueViewers)
+	return args.Error(0)
+}
+
+func (m *MockProfileViewRepository) GetTotalViewsBefore(ctx context.Context, userID uuid.UUID, day time.Time) (int64, error) {
+	args := m.Called(ctx, userID, day)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockProfileViewRepository) ListDaily(ctx context.Context, userID uuid.UUID, since time.Time) ([]models.ProfileViewDay, error) {
+	args := m.Called(ctx, userID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ProfileViewDay), args.Error(1)
+}
+
+// MockProfileViewStore is a mock implementation of ProfileViewStore
+type MockProfileViewStore struct {
+	mock.Mock
+}
+
+func (m *MockProfileViewStore) Record(ctx context.Context, profileID uuid.UUID, viewerKey string, day time.Time) (bool, error) {
+	args := m.Called(ctx, profileID, viewerKey, day)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockProfileViewStore) DayCounts(ctx context.Context, profileID uuid.UUID, day time.Time) (int64, int64, error) {
+	args := m.Called(ctx, profileID, day)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockProfileViewStore) UniqueViewers(ctx context.Context, profileID uuid.UUID, days []time.Time) (int64, error) {
+	args := m.Called(ctx, profileID, days)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockProfileViewStore) ViewedProfiles(ctx context.Context, day time.Time) ([]uuid.UUID, error) {
+	args := m.Called(ctx, day)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+const browserUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/129.0 Safari/537.36"
+
+func TestIsBotUserAgent(t *testing.T) {
+	assert.False(t, IsBotUserAgent(browserUserAgent))
+	for _, ua := range []string{
+		"",
+		"  ",
+		"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+		"facebookexternalhit/1.1",
+		"curl/8.4.0",
+		"python-requests/2.31",
+		"Mozilla/5.0 HeadlessChrome/120.0",
+	} {
+		assert.True(t, IsBotUserAgent(ua), ua)
+	}
+}
+
+func TestProfileViewService_RecordView(t *testing.T) {
+	ctx := context.Background()
+	repo, store := new(MockProfileViewRepository), new(MockProfileViewStore)
+	svc := NewProfileViewService(repo, store)
+	profileID, viewerID := uuid.New(), uuid.New()
+	repo.On("GetShowProfileViews", mock.Anything, profileID).Return(false, nil)
+	repo.On("GetShowProfileViews", mock.Anything, mock.Anything).Return(false, repository.ErrUserNotFound)
+
+	viewerKey := "u:" + viewerID.String()
+	store.On("Record", mock.Anything, profileID, viewerKey, mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+	store.On("Record", mock.Anything, profileID, viewerKey, mock.AnythingOfType("time.Time")).Return(false, nil).Once()
+	var anonymousKeys []string
+	store.On("Record", mock.Anything, profileID, mock.MatchedBy(func(key string) bool { return strings.HasPrefix(key, "a:") }), mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) { anonymousKeys = append(anonymousKeys, args.String(2)) }).
+		Return(true, nil).Once()
+
+	counted, err := svc.RecordView(ctx, profileID, &viewerID, "203.0.113.7", browserUserAgent)
+	require.NoError(t, err)
+	assert.True(t, counted)
+
+	// Repeat views, bots and the owner aren't counted
+	counted, _ = svc.RecordView(ctx, profileID, &viewerID, "198.51.100.1", browserUserAgent)
+	assert.False(t, counted)
+	counted, _ = svc.RecordView(ctx, profileID, nil, "203.0.113.7", "Googlebot/2.1")
+	assert.False(t, counted)
+	counted, _ = svc.RecordView(ctx, profileID, &profileID, "203.0.113.7", browserUserAgent)
+	assert.False(t, counted)
+
+	// Anonymous viewers are told apart without storing their address
+	counted, _ = svc.RecordView(ctx, profileID, nil, "203.0.113.7", browserUserAgent)
+	assert.True(t, counted)
+	require.Len(t, anonymousKeys, 1)
+	assert.NotContains(t, anonymousKeys[0], "203.0.113.7")
+
+	_, err = svc.RecordView(ctx, uuid.New(), nil, "203.0.113.7", browserUserAgent)
+	assert.ErrorIs(t, err, repository.ErrUserNotFound)
+
+	repo.AssertExpectations(t)
+	store.AssertExpectations(t)
+	store.AssertNumberOfCalls(t, "Record", 3)
+}
+
+func TestProfileViewService_GetStats(t *testing.T) {
+	ctx := context.Background()
+	repo, store := new(MockProfileViewRepository), new(MockProfileViewStore)
+	svc := NewProfileViewService(repo, store)
+	now := time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	today := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	yesterday := today.AddDate(0, 0, -1)
+	since := today.AddDate(0, 0, -29)
+	profileID := uuid.New()
+
+	repo.On("GetShowProfileViews", mock.Anything, profileID).Return(false, nil).Times(3)
+	repo.On("GetShowProfileViews", mock.Anything, profileID).Return(true, nil).Once()
+
+	// Five days ago is rolled up; yesterday's rollup is behind its live count
+	repo.On("GetTotalViewsBefore", mock.Anything, profileID, yesterday).Return(int64(100+7), nil).Twice()
+	repo.On("ListDaily", mock.Anything, profileID, since).Return([]models.ProfileViewDay{
+		{Date: today.AddDate(0, 0, -5), Views: 7, UniqueViewers: 4},
+		{Date: yesterday, Views: 1, UniqueViewers: 1},
+	}, nil).Twice()
+	store.On("DayCounts", mock.Anything, profileID, yesterday).Return(int64(3), int64(3), nil).Twice()
+	store.On("DayCounts", mock.Anything, profileID, today).Return(int64(1), int64(1), nil).Twice()
+	store.On("UniqueViewers", mock.Anything, profileID, mock.MatchedBy(func(days []time.Time) bool {
+		return len(days) == 30 && days[0].Equal(since) && days[29].Equal(today)
+	})).Return(int64(4), nil).Twice()
+
+	stranger := uuid.New()
+	_, err := svc.GetStats(ctx, profileID, &stranger)
+	assert.ErrorIs(t, err, ErrProfileViewsHidden)
+	_, err = svc.GetStats(ctx, profileID, nil)
+	assert.ErrorIs(t, err, ErrProfileViewsHidden)
+
+	stats, err := svc.GetStats(ctx, profileID, &profileID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100+7+3+1), stats.TotalViews)
+	assert.Equal(t, int64(4), stats.UniqueViewers30Days)
+	require.Len(t, stats.Daily, 30)
+	assert.Equal(t, today, stats.Daily[29].Date)
+	assert.Equal(t, int64(1), stats.Daily[29].Views)
+	assert.Equal(t, int64(3), stats.Daily[28].Views)
+	assert.Equal(t, int64(7), stats.Daily[24].Views)
+	assert.Equal(t, int64(4), stats.Daily[24].UniqueViewers)
+
+	// Once shown, others see the counter without the daily breakdown
+	stats, err = svc.GetStats(ctx, profileID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(111), stats.TotalViews)
+	assert.Nil(t, stats.Daily)
+
+	repo.AssertExpectations(t)
+	store.AssertExpectations(t)
+}
+
+func TestProfileViewService_RollupDaily(t *testing.T) {
+	ctx := context.Background()
+	repo, store := new(MockProfileViewRepository), new(MockProfileViewStore)
+	svc := NewProfileViewService(repo, store)
+	svc.now = func() time.Time { return time.Date(2026, 10, 16, 0, 30, 0, 0, time.UTC) }
+	today := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	yesterday := today.AddDate(0, 0, -1)
+	a, b := uuid.New(), uuid.New()
+
+	store.On("ViewedProfiles", mock.Anything, yesterday).Return([]uuid.UUID{a}, nil).Once()
+	store.On("ViewedProfiles", mock.Anything, today).Return([]uuid.UUID{b}, nil).Once()
+	store.On("DayCounts", mock.Anything, a, yesterday).Return(int64(2), int64(2), nil).Once()
+	store.On("DayCounts", mock.Anything, b, today).Return(int64(1), int64(1), nil).Once()
+	repo.On("UpsertDaily", mock.Anything, a, yesterday, int64(2), int64(2)).Return(nil).Once()
+	repo.On("UpsertDaily", mock.Anything, b, today, int64(1), int64(1)).Return(nil).Once()
+
+	rolledUp, err := svc.RollupDaily(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, rolledUp)
+
+	repo.AssertExpectations(t)
+	store.AssertExpectations(t)
+}
@@ -0,0 +1,246 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+const (
+	pushQueueSize          = 1000
+	pushDefaultRetryDelay  = time.Second
+	pushDefaultMaxRetries  = 3
+	pushDefaultWorkerCount = 4
+)
+
+// ErrPushTokenInvalid is returned by push providers when a device token is no longer
+// registered with the provider and should be removed
+var ErrPushTokenInvalid = errors.New("push device token is invalid")
+
+// PushProviderError describes a rejected push delivery
+type PushProviderError struct {
+	StatusCode int
+	Reason     string
+	Retryable  bool
+}
+
+func (e *PushProviderError) Error() string {
+	return fmt.Sprintf("push provider returned %d: %s", e.StatusCode, e.Reason)
+}
+
+// PushMessage is the content of a push notification
+type PushMessage struct {
+	Title string
+	Body  string
+	Data  map[string]string
+}
+
+// PushProvider delivers push notifications to one kind of device token
+type PushProvider interface {
+	Send(ctx context.Context, deviceToken string, message *PushMessage) error
+}
+
+// PushUserRepository defines the user repository methods used by PushNotificationService
+type PushUserRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+	ClearDeviceTokenIfMatches(ctx context.Context, userID uuid.UUID, deviceToken string) error
+}
+
+var (
+	// pushNotificationsTotal tracks push delivery outcomes
+	pushNotificationsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "push_notifications_total",
+			Help: "Total number of push notifications by outcome",
+		},
+		[]string{"platform", "status"}, // status: delivered, failed, invalid_token, no_provider, dropped
+	)
+
+	// pushNotificationRetriesTotal tracks retried push deliveries
+	pushNotificationRetriesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "push_notification_retries_total",
+			Help: "Total number of push delivery retries after transient provider errors",
+		},
+		[]string{"platform"},
+	)
+
+	// pushNotificationDuration tracks the time taken to deliver a push notification, including retries
+	pushNotificationDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "push_notification_delivery_duration_seconds",
+			Help:    "Duration of push notification delivery in seconds, including retries",
+			Buckets: []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		},
+		[]string{"platform"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(pushNotificationsTotal)
+	prometheus.MustRegister(pushNotificationRetriesTotal)
+	prometheus.MustRegister(pushNotificationDuration)
+}
+
+// PushNotificationService fans notifications out to users' registered devices
+// through FCM and APNs. Deliveries are queued and sent by background workers so
+// creating a notification never waits on a provider.
+type PushNotificationService struct {
+	userRepo   PushUserRepository
+	providers  map[string]PushProvider
+	jobs       chan *models.Notification
+	maxRetries int
+	workers    int
+	retryDelay time.Duration
+}
+
+// NewPushNotificationService creates a new PushNotificationService
+func NewPushNotificationService(userRepo PushUserRepository, maxRetries, workers int) *PushNotificationService {
+	if maxRetries < 0 {
+		maxRetries = pushDefaultMaxRetries
+	}
+	if workers <= 0 {
+		workers = pushDefaultWorkerCount
+	}
+	return &PushNotificationService{
+		userRepo:   userRepo,
+		providers:  make(map[string]PushProvider),
+		jobs:       make(chan *models.Notification, pushQueueSize),
+		maxRetries: maxRetries,
+		workers:    workers,
+		retryDelay: pushDefaultRetryDelay,
+	}
+}
+
+// RegisterProvider sets the provider used for device tokens of a platform (ios, android, web)
+func (s *PushNotificationService) RegisterProvider(platform string, provider PushProvider) {
+	s.providers[platform] = provider
+}
+
+// HasProviders reports whether any push provider is configured
+func (s *PushNotificationService) HasProviders() bool {
+	return len(s.providers) > 0
+}
+
+// Start runs the delivery workers until the context is cancelled
+func (s *PushNotificationService) Start(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < s.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case notification := <-s.jobs:
+					s.deliver(ctx, notification)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Enqueue queues a notification for push delivery. Notifications are dropped
+// when the queue is full; they remain available in-app.
+func (s *PushNotificationService) Enqueue(notification *models.Notification) {
+	select {
+	case s.jobs <- notification:
+	default:
+		pushNotificationsTotal.WithLabelValues("unknown", "dropped").Inc()
+		log.Printf("Push queue full, dropping push for notification %s", notification.ID)
+	}
+}
+
+// deliver sends a notification to the user's registered device
+func (s *PushNotificationService) deliver(ctx context.Context, notification *models.Notification) {
+	user, err := s.userRepo.GetByID(ctx, notification.UserID)
+	if err != nil {
+		log.Printf("Failed to load user %s for push delivery: %v", notification.UserID, err)
+		return
+	}
+	if user.DeviceToken == nil || *user.DeviceToken == "" || user.DevicePlatform == nil {
+		return
+	}
+
+	platform := *user.DevicePlatform
+	provider, ok := s.providers[platform]
+	if !ok {
+		pushNotificationsTotal.WithLabelValues(platform, "no_provider").Inc()
+		return
+	}
+
+	start := time.Now()
+	err = s.sendWithRetry(ctx, provider, platform, *user.DeviceToken, pushMessageFor(notification))
+	pushNotificationDuration.WithLabelValues(platform).Observe(time.Since(start).Seconds())
+
+	switch {
+	case err == nil:
+		pushNotificationsTotal.WithLabelValues(platform, "delivered").Inc()
+	case errors.Is(err, ErrPushTokenInvalid):
+		pushNotificationsTotal.WithLabelValues(platform, "invalid_token").Inc()
+		if clearErr := s.userRepo.ClearDeviceTokenIfMatches(ctx, user.ID, *user.DeviceToken); clearErr != nil {
+			log.Printf("Failed to clear invalid device token for user %s: %v", user.ID, clearErr)
+		}
+	default:
+		pushNotificationsTotal.WithLabelValues(platform, "failed").Inc()
+		log.Printf("Failed to deliver push notification %s to %s device: %v", notification.ID, platform, err)
+	}
+}
+
+// sendWithRetry sends a message, retrying transient provider and network errors
+// with exponential backoff
+func (s *PushNotificationService) sendWithRetry(ctx context.Context, provider PushProvider, platform, token string, message *PushMessage) error {
+	delay := s.retryDelay
+	for attempt := 0; ; attempt++ {
+		err := provider.Send(ctx, token, message)
+		if err == nil || attempt >= s.maxRetries || !isRetryablePushError(err) {
+			return err
+		}
+
+		pushNotificationRetriesTotal.WithLabelValues(platform).Inc()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// isRetryablePushError reports whether a failed delivery may succeed if retried.
+// Errors without a provider response, such as timeouts, are retried.
+func isRetryablePushError(err error) bool {
+	if errors.Is(err, ErrPushTokenInvalid) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var providerErr *PushProviderError
+	if errors.As(err, &providerErr) {
+		return providerErr.Retryable
+	}
+	return true
+}
+
+// pushMessageFor builds the push message for a notification
+func pushMessageFor(notification *models.Notification) *PushMessage {
+	data := map[string]string{
+		"notification_id": notification.ID.String(),
+		"type":            notification.Type,
+	}
+	if notification.Link != nil {
+		data["link"] = *notification.Link
+	}
+	return &PushMessage{
+		Title: notification.Title,
+		Body:  notification.Message,
+		Data:  data,
+	}
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// MockPushProvider is a mock implementation of PushProvider
+type MockPushProvider struct {
+	mock.Mock
+}
+
+func (m *MockPushProvider) Send(ctx context.Context, deviceToken string, message *PushMessage) error {
+	args := m.Called(ctx, deviceToken, message)
+	return args.Error(0)
+}
+
+// setupPushNotificationServiceTest builds a PushNotificationService that retries
+// twice, with a provider registered for the platform of the notified user
+func setupPushNotificationServiceTest(platform string) (*PushNotificationService, *MockUserRepository, *MockPushProvider, *models.Notification) {
+	token := "device-token"
+	user := &models.User{ID: uuid.New(), DeviceToken: &token, DevicePlatform: &platform}
+	repo := new(MockUserRepository)
+	repo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	provider := new(MockPushProvider)
+
+	svc := NewPushNotificationService(repo, 2, 1)
+	svc.retryDelay = time.Millisecond
+	svc.RegisterProvider(platform, provider)
+
+	notification := &models.Notification{ID: uuid.New(), UserID: user.ID, Type: models.NotificationTypeReply, Title: "New reply", Message: "Someone replied"}
+	return svc, repo, provider, notification
+}
+
+func TestPushNotificationService_RetriesTransientErrors(t *testing.T) {
+	svc, _, provider, notification := setupPushNotificationServiceTest("android")
+	provider.On("Send", mock.Anything, "device-token", mock.Anything).
+		Return(&PushProviderError{StatusCode: http.StatusServiceUnavailable, Retryable: true}).Once()
+	provider.On("Send", mock.Anything, "device-token", mock.Anything).Return(errors.New("connection reset")).Once()
+	provider.On("Send", mock.Anything, "device-token", mock.Anything).Return(nil).Once()
+
+	svc.deliver(context.Background(), notification)
+
+	provider.AssertExpectations(t)
+	provider.AssertNumberOfCalls(t, "Send", 3)
+}
+
+func TestPushNotificationService_GivesUpAfterMaxRetries(t *testing.T) {
+	svc, repo, provider, notification := setupPushNotificationServiceTest("android")
+	transient := &PushProviderError{StatusCode: http.StatusTooManyRequests, Retryable: true}
+	provider.On("Send", mock.Anything, "device-token", mock.Anything).Return(transient)
+
+	svc.deliver(context.Background(), notification)
+
+	provider.AssertNumberOfCalls(t, "Send", 3)
+	repo.AssertNotCalled(t, "ClearDeviceTokenIfMatches", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPushNotificationService_DoesNotRetryPermanentErrors(t *testing.T) {
+	svc, repo, provider, notification := setupPushNotificationServiceTest("ios")
+	provider.On("Send", mock.Anything, "device-token", mock.Anything).
+		Return(&PushProviderError{StatusCode: http.StatusBadRequest, Reason: "PayloadTooLarge"})
+
+	svc.deliver(context.Background(), notification)
+
+	provider.AssertNumberOfCalls(t, "Send", 1)
+	repo.AssertNotCalled(t, "ClearDeviceTokenIfMatches", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPushNotificationService_ClearsInvalidToken(t *testing.T) {
+	svc, repo, provider, notification := setupPushNotificationServiceTest("ios")
+	provider.On("Send", mock.Anything, "device-token", mock.Anything).Return(classifyAPNsError(http.StatusGone, "Unregistered"))
+	repo.On("ClearDeviceTokenIfMatches", mock.Anything, notification.UserID, "device-token").Return(nil).Once()
+
+	svc.deliver(context.Background(), notification)
+
+	provider.AssertNumberOfCalls(t, "Send", 1)
+	repo.AssertExpectations(t)
+}
+
+func TestPushNotificationService_SkipsPlatformsWithoutProvider(t *testing.T) {
+	svc, _, provider, notification := setupPushNotificationServiceTest("android")
+	svc.providers = map[string]PushProvider{"ios": provider}
+
+	svc.deliver(context.Background(), notification)
+
+	provider.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPushNotificationService_EnqueueDelivers(t *testing.T) {
+	svc, _, provider, notification := setupPushNotificationServiceTest("android")
+	delivered := make(chan struct{}, 1)
+	provider.On("Send", mock.Anything, "device-token", mock.Anything).
+		Run(func(args mock.Arguments) {
+			delivered <- struct{}{}
+		}).
+		Return(nil).Once()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go svc.Start(ctx)
+
+	svc.Enqueue(notification)
+
+	select {
+	case <-delivered:
+	case <-time.After(time.Second):
+		require.Fail(t, "push was not delivered")
+	}
+}
@@ -0,0 +1,386 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	fcmEndpoint        = "https://fcm.googleapis.com"
+	fcmMessagingScope  = "https://www.googleapis.com/auth/firebase.messaging"
+	apnsEndpoint       = "https://api.push.apple.com"
+	apnsSandboxURL     = "https://api.sandbox.push.apple.com"
+	apnsTokenLifetime  = 50 * time.Minute // APNs rejects provider tokens older than an hour
+	pushRequestTimeout = 10 * time.Second
+)
+
+// FCMProvider sends push notifications through the Firebase Cloud Messaging HTTP v1 API
+type FCMProvider struct {
+	projectID   string
+	clientEmail string
+	tokenURI    string
+	privateKey  *rsa.PrivateKey
+	endpoint    string
+	httpClient  *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+// fcmServiceAccount holds the fields of a Google service account key used by FCMProvider
+type fcmServiceAccount struct {
+	ProjectID   string `json:"project_id"`
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// NewFCMProvider creates an FCM provider from a service account JSON key. The
+// project ID defaults to the one in the key.
+func NewFCMProvider(projectID, credentialsJSON string) (*FCMProvider, error) {
+	var account fcmServiceAccount
+	if err := json.Unmarshal([]byte(credentialsJSON), &account); err != nil {
+		return nil, fmt.Errorf("failed to parse FCM credentials: %w", err)
+	}
+	if account.ClientEmail == "" || account.PrivateKey == "" || account.TokenURI == "" {
+		return nil, fmt.Errorf("FCM credentials must include client_email, private_key and token_uri")
+	}
+	if projectID == "" {
+		projectID = account.ProjectID
+	}
+	if projectID == "" {
+		return nil, fmt.Errorf("FCM project ID is required")
+	}
+
+	privateKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(account.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse FCM private key: %w", err)
+	}
+
+	return &FCMProvider{
+		projectID:   projectID,
+		clientEmail: account.ClientEmail,
+		tokenURI:    account.TokenURI,
+		privateKey:  privateKey,
+		endpoint:    fcmEndpoint,
+		httpClient:  &http.Client{Timeout: pushRequestTimeout},
+	}, nil
+}
+
+// Send delivers a message to an FCM registration token
+func (p *FCMProvider) Send(ctx context.Context, deviceToken string, message *PushMessage) error {
+	accessToken, err := p.getAccessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]interface{}{
+		"message": map[string]interface{}{
+			"token": deviceToken,
+			"notification": map[string]string{
+				"title": message.Title,
+				"body":  message.Body,
+			},
+			"data": message.Data,
+		},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal FCM message: %w", err)
+	}
+
+	sendURL := fmt.Sprintf("%s/v1/projects/%s/messages:send", p.endpoint, url.PathEscape(p.projectID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sendURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create FCM request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send FCM request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode == http.StatusUnauthorized {
+		// The access token was revoked or expired early; fetch a new one on retry
+		p.resetAccessToken()
+	}
+	return classifyFCMError(resp.StatusCode, respBody)
+}
+
+// classifyFCMError maps an FCM error response to ErrPushTokenInvalid or a PushProviderError
+func classifyFCMError(statusCode int, body []byte) error {
+	var errResp struct {
+		Error struct {
+			Status  string `json:"status"`
+			Message string `json:"message"`
+			Details []struct {
+				ErrorCode string `json:"errorCode"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	_ = json.Unmarshal(body, &errResp)
+
+	reason := errResp.Error.Status
+	for _, detail := range errResp.Error.Details {
+		if detail.ErrorCode != "" {
+			reason = detail.ErrorCode
+			break
+		}
+	}
+
+	switch reason {
+	case "UNREGISTERED", "SENDER_ID_MISMATCH":
+		return fmt.Errorf("%w: %s", ErrPushTokenInvalid, reason)
+	}
+	if statusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrPushTokenInvalid, reason)
+	}
+
+	if reason == "" {
+		reason = http.StatusText(statusCode)
+	}
+	return &PushProviderError{
+		StatusCode: statusCode,
+		Reason:     reason,
+		Retryable:  statusCode == http.StatusTooManyRequests || statusCode == http.StatusUnauthorized || statusCode >= http.StatusInternalServerError,
+	}
+}
+
+// getAccessToken returns a cached OAuth access token, exchanging a signed
+// service account assertion for a new one when it is about to expire
+func (p *FCMProvider) getAccessToken(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if p.accessToken != "" && now.Before(p.expiresAt.Add(-time.Minute)) {
+		return p.accessToken, nil
+	}
+
+	assertion, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":   p.clientEmail,
+		"scope": fcmMessagingScope,
+		"aud":   p.tokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}).SignedString(p.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign FCM token assertion: %w", err)
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.tokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create FCM token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to request FCM access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", &PushProviderError{
+			StatusCode: resp.StatusCode,
+			Reason:     "access token request failed",
+			Retryable:  resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError,
+		}
+	}
+
+	var tokenResp struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", fmt.Errorf("failed to decode FCM access token: %w", err)
+	}
+
+	p.accessToken = tokenResp.AccessToken
+	p.expiresAt = now.Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	return p.accessToken, nil
+}
+
+// resetAccessToken discards the cached access token
+func (p *FCMProvider) resetAccessToken() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.accessToken = ""
+}
+
+// APNsProvider sends push notifications through Apple Push Notification service
+// using token-based authentication
+type APNsProvider struct {
+	keyID      string
+	teamID     string
+	bundleID   string
+	privateKey *ecdsa.PrivateKey
+	endpoint   string
+	httpClient *http.Client
+
+	mu       sync.Mutex
+	token    string
+	issuedAt time.Time
+}
+
+// NewAPNsProvider creates an APNs provider from a .p8 auth key
+func NewAPNsProvider(keyID, teamID, privateKeyPEM, bundleID string, production bool) (*APNsProvider, error) {
+	if keyID == "" || teamID == "" || bundleID == "" {
+		return nil, fmt.Errorf("APNs key ID, team ID and bundle ID are required")
+	}
+
+	privateKey, err := jwt.ParseECPrivateKeyFromPEM([]byte(privateKeyPEM))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse APNs private key: %w", err)
+	}
+
+	endpoint := apnsSandboxURL
+	if production {
+		endpoint = apnsEndpoint
+	}
+
+	return &APNsProvider{
+		keyID:      keyID,
+		teamID:     teamID,
+		bundleID:   bundleID,
+		privateKey: privateKey,
+		endpoint:   endpoint,
+		// APNs requires HTTP/2, which the default transport negotiates over TLS
+		httpClient: &http.Client{Timeout: pushRequestTimeout},
+	}, nil
+}
+
+// Send delivers a message to an APNs device token
+func (p *APNsProvider) Send(ctx context.Context, deviceToken string, message *PushMessage) error {
+	providerToken, err := p.getProviderToken()
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]interface{}{
+		"aps": map[string]interface{}{
+			"alert": map[string]string{
+				"title": message.Title,
+				"body":  message.Body,
+			},
+			"sound": "default",
+		},
+	}
+	for key, value := range message.Data {
+		payload[key] = value
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal APNs payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint+"/3/device/"+url.PathEscape(deviceToken), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create APNs request: %w", err)
+	}
+	req.Header.Set("Authorization", "bearer "+providerToken)
+	req.Header.Set("apns-topic", p.bundleID)
+	req.Header.Set("apns-push-type", "alert")
+	req.Header.Set("apns-priority", "10")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send APNs request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var errResp struct {
+		Reason string `json:"reason"`
+	}
+	_ = json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&errResp)
+	if errResp.Reason == "ExpiredProviderToken" {
+		p.resetProviderToken()
+	}
+	return classifyAPNsError(resp.StatusCode, errResp.Reason)
+}
+
+// classifyAPNsError maps an APNs error response to ErrPushTokenInvalid or a PushProviderError
+func classifyAPNsError(statusCode int, reason string) error {
+	switch {
+	case statusCode == http.StatusGone,
+		reason == "BadDeviceToken",
+		reason == "DeviceTokenNotForTopic",
+		reason == "Unregistered":
+		return fmt.Errorf("%w: %s", ErrPushTokenInvalid, reason)
+	}
+
+	if reason == "" {
+		reason = http.StatusText(statusCode)
+	}
+	return &PushProviderError{
+		StatusCode: statusCode,
+		Reason:     reason,
+		Retryable: statusCode == http.StatusTooManyRequests ||
+			statusCode >= http.StatusInternalServerError ||
+			reason == "ExpiredProviderToken",
+	}
+}
+
+// getProviderToken returns the cached provider JWT, signing a new one when it is
+// close to the age APNs rejects
+func (p *APNsProvider) getProviderToken() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if p.token != "" && now.Sub(p.issuedAt) < apnsTokenLifetime {
+		return p.token, nil
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"iss": p.teamID,
+		"iat": now.Unix(),
+	})
+	token.Header["kid"] = p.keyID
+
+	signed, err := token.SignedString(p.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign APNs provider token: %w", err)
+	}
+
+	p.token = signed
+	p.issuedAt = now
+	return p.token, nil
+}
+
+// resetProviderToken discards the cached provider JWT
+func (p *APNsProvider) resetProviderToken() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.token = ""
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAPNsProvider(t *testing.T, endpoint string) *APNsProvider {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	provider, err := NewAPNsProvider("KEY123", "TEAM123", string(keyPEM), "gg.clipper.app", false)
+	require.NoError(t, err)
+	provider.endpoint = endpoint
+	return provider
+}
+
+func TestAPNsProvider_Send(t *testing.T) {
+	var gotPath, gotTopic, gotAuth string
+	var gotPayload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotTopic = r.Header.Get("apns-topic")
+		gotAuth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&gotPayload)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	provider := newTestAPNsProvider(t, server.URL)
+	err := provider.Send(context.Background(), "abc123", &PushMessage{Title: "Hi", Body: "There", Data: map[string]string{"type": "reply"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, "/3/device/abc123", gotPath)
+	assert.Equal(t, "gg.clipper.app", gotTopic)
+	assert.True(t, strings.HasPrefix(gotAuth, "bearer "))
+	assert.Equal(t, "reply", gotPayload["type"])
+	assert.Contains(t, gotPayload, "aps")
+}
+
+func TestAPNsProvider_ClassifiesErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		reason    string
+		invalid   bool
+		retryable bool
+	}{
+		{name: "unregistered", status: http.StatusGone, reason: "Unregistered", invalid: true},
+		{name: "bad token", status: http.StatusBadRequest, reason: "BadDeviceToken", invalid: true},
+		{name: "throttled", status: http.StatusTooManyRequests, reason: "TooManyRequests", retryable: true},
+		{name: "expired provider token", status: http.StatusForbidden, reason: "ExpiredProviderToken", retryable: true},
+		{name: "bad payload", status: http.StatusBadRequest, reason: "PayloadTooLarge"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_ = json.NewEncoder(w).Encode(map[string]string{"reason": tt.reason})
+			}))
+			defer server.Close()
+
+			err := newTestAPNsProvider(t, server.URL).Send(context.Background(), "abc123", &PushMessage{Title: "Hi"})
+			require.Error(t, err)
+			assert.Equal(t, tt.invalid, errors.Is(err, ErrPushTokenInvalid))
+			if !tt.invalid {
+				assert.Equal(t, tt.retryable, isRetryablePushError(err))
+			}
+		})
+	}
+}
+
+func TestFCMProvider_Send(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	tokenRequests := 0
+	var gotAuth string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "access-token", "expires_in": 3600})
+	})
+	mux.HandleFunc("/v1/projects/clipper-test/messages:send", func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		var body struct {
+			Message struct {
+				Token string `json:"token"`
+			} `json:"message"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body.Message.Token == "stale" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":404,"status":"NOT_FOUND","details":[{"errorCode":"UNREGISTERED"}]}}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	credentials, err := json.Marshal(map[string]string{
+		"project_id":   "clipper-test",
+		"client_email": "push@clipper-test.iam.gserviceaccount.com",
+		"private_key":  string(keyPEM),
+		"token_uri":    server.URL + "/token",
+	})
+	require.NoError(t, err)
+
+	provider, err := NewFCMProvider("", string(credentials))
+	require.NoError(t, err)
+	provider.endpoint = server.URL
+
+	require.NoError(t, provider.Send(context.Background(), "fresh", &PushMessage{Title: "Hi"}))
+	assert.Equal(t, "Bearer access-token", gotAuth)
+
+	err = provider.Send(context.Background(), "stale", &PushMessage{Title: "Hi"})
+	assert.ErrorIs(t, err, ErrPushTokenInvalid)
+	assert.Equal(t, 1, tokenRequests, "access token is cached")
+}
+
+func TestClassifyFCMError(t *testing.T) {
+	err := classifyFCMError(http.StatusServiceUnavailable, []byte(`{"error":{"status":"UNAVAILABLE"}}`))
+	assert.True(t, isRetryablePushError(err))
+
+	err = classifyFCMError(http.StatusBadRequest, []byte(`{"error":{"status":"INVALID_ARGUMENT"}}`))
+	assert.False(t, isRetryablePushError(err))
+	assert.NotErrorIs(t, err, ErrPushTokenInvalid)
+}
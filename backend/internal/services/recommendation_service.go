@@ -13,10 +13,14 @@ import (
 	"github.com/subculture-collective/clipper/internal/repository"
 )
 
+// homepageTrendingCacheTTL bounds how stale the trending list shown to
+// signed-out visitors can get; it is shared by every visitor
+const homepageTrendingCacheTTL = 15 * time.Minute
+
 // RecommendationService handles recommendation logic
 type RecommendationService struct {
 	repo                 *repository.RecommendationRepository
-	redisClient          *redis.Client
+	redisClient          redis.UniversalClient
 	contentWeight        float64
 	collaborativeWeight  float64
 	trendingWeight       float64
@@ -31,7 +35,7 @@ type RecommendationService struct {
 // NewRecommendationService creates a new recommendation service
 func NewRecommendationService(
 	repo *repository.RecommendationRepository,
-	redisClient *redis.Client,
+	redisClient redis.UniversalClient,
 ) *RecommendationService {
 	return &RecommendationService{
 		repo:                 repo,
@@ -51,7 +55,7 @@ func NewRecommendationService(
 // NewRecommendationServiceWithConfig creates a new recommendation service with custom config
 func NewRecommendationServiceWithConfig(
 	repo *repository.RecommendationRepository,
-	redisClient *redis.Client,
+	redisClient redis.UniversalClient,
 	contentWeight float64,
 	collaborativeWeight float64,
 	trendingWeight float64,
@@ -211,6 +215,58 @@ func (s *RecommendationService) GetRecommendations(
 	return response, nil
 }
 
+// GetHomepageRecommendations returns the homepage feed. Signed-in users get
+// their hybrid recommendations; visitors (nil userID) get trending clips,
+// cached once for everyone.
+func (s *RecommendationService) GetHomepageRecommendations(
+	ctx context.Context,
+	userID *uuid.UUID,
+	limit int,
+) (*models.RecommendationResponse, error) {
+	if userID != nil {
+		return s.GetRecommendations(ctx, *userID, models.AlgorithmHybrid, limit)
+	}
+
+	startTime := time.Now()
+
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	cacheKey := fmt.Sprintf("recommendations:trending:%d", limit)
+	cachedData, err := s.redisClient.Get(ctx, cacheKey).Result()
+	if err == nil && cachedData != "" {
+		var response models.RecommendationResponse
+		if err := json.Unmarshal([]byte(cachedData), &response); err == nil {
+			response.Metadata.CacheHit = true
+			response.Metadata.ProcessingTimeMs = time.Since(startTime).Milliseconds()
+			return &response, nil
+		}
+	}
+
+	recommendations, err := s.getColdStartRecommendations(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.RecommendationResponse{
+		Recommendations: recommendations,
+		Metadata: models.RecommendationMetadata{
+			AlgorithmUsed:    models.AlgorithmTrending,
+			DiversityApplied: false,
+			ColdStart:        true,
+			CacheHit:         false,
+			ProcessingTimeMs: time.Since(startTime).Milliseconds(),
+		},
+	}
+
+	if responseJSON, err := json.Marshal(response); err == nil {
+		s.redisClient.Set(ctx, cacheKey, responseJSON, homepageTrendingCacheTTL)
+	}
+
+	return response, nil
+}
+
 // getContentBasedRecommendations generates content-based recommendations
 func (s *RecommendationService) getContentBasedRecommendations(
 	ctx context.Context,
@@ -236,6 +292,10 @@ func (s *RecommendationService) getContentBasedRecommendations(
 		return nil, fmt.Errorf("failed to get content-based recommendations: %w", err)
 	}
 
+	// Embedding similarity is best effort: clips may not be embedded yet
+	embeddingScores, _ := s.repo.GetEmbeddingRecommendations(ctx, userID, nil, limit*2)
+	scores = blendContentScores(scores, embeddingScores)
+
 	return s.buildRecommendations(ctx, scores, "content", limit)
 }
 
@@ -331,7 +391,12 @@ func (s *RecommendationService) getScoresForHybrid(
 		if err != nil {
 			return nil, err
 		}
-		return s.repo.GetContentBasedRecommendations(ctx, userID, preferences, nil, limit)
+		scores, err := s.repo.GetContentBasedRecommendations(ctx, userID, preferences, nil, limit)
+		if err != nil {
+			return nil, err
+		}
+		embeddingScores, _ := s.repo.GetEmbeddingRecommendations(ctx, userID, nil, limit)
+		return blendContentScores(scores, embeddingScores), nil
 	case models.AlgorithmCollaborative:
 		return s.repo.GetCollaborativeRecommendations(ctx, userID, nil, limit)
 	case models.AlgorithmTrending:
@@ -382,6 +447,45 @@ func (s *RecommendationService) mergeAndRank(
 	return merged
 }
 
+// blendContentScores combines metadata matches (game, streamer, tags) with
+// embedding similarity into one content signal. A clip keeps the higher of
+// its two scores, so a clip found by only one of them isn't penalized.
+func blendContentScores(metadataScores, embeddingScores []models.ClipScore) []models.ClipScore {
+	if len(embeddingScores) == 0 {
+		return metadataScores
+	}
+
+	scoreMap := make(map[uuid.UUID]float64, len(metadataScores)+len(embeddingScores))
+	for _, scores := range [][]models.ClipScore{metadataScores, embeddingScores} {
+		for _, score := range scores {
+			if current, ok := scoreMap[score.ClipID]; !ok || score.SimilarityScore > current {
+				scoreMap[score.ClipID] = score.SimilarityScore
+			}
+		}
+	}
+
+	blended := make([]models.ClipScore, 0, len(scoreMap))
+	for clipID, score := range scoreMap {
+		blended = append(blended, models.ClipScore{
+			ClipID:          clipID,
+			SimilarityScore: score,
+		})
+	}
+
+	sort.Slice(blended, func(i, j int) bool {
+		if blended[i].SimilarityScore != blended[j].SimilarityScore {
+			return blended[i].SimilarityScore > blended[j].SimilarityScore
+		}
+		return blended[i].ClipID.String() < blended[j].ClipID.String()
+	})
+
+	for i := range blended {
+		blended[i].SimilarityRank = i + 1
+	}
+
+	return blended
+}
+
 // buildRecommendations converts ClipScores to ClipRecommendations
 func (s *RecommendationService) buildRecommendations(
 	ctx context.Context,
@@ -573,6 +677,18 @@ func (s *RecommendationService) invalidateUserCache(ctx context.Context, userID
 	_ = iter.Err()
 }
 
+// HandleCacheInvalidation clears the cached homepage trending lists when a
+// clip in them may have been edited or removed
+func (s *RecommendationService) HandleCacheInvalidation(ctx context.Context, event CacheInvalidationEvent) error {
+	iter := s.redisClient.Scan(ctx, 0, "recommendations:trending:*", 100).Iterator()
+	for iter.Next(ctx) {
+		if err := s.redisClient.Del(ctx, iter.Val()).Err(); err != nil {
+			return err
+		}
+	}
+	return iter.Err()
+}
+
 // GetUserPreferences retrieves user preferences
 func (s *RecommendationService) GetUserPreferences(ctx context.Context, userID uuid.UUID) (*models.UserPreference, error) {
 	return s.repo.GetUserPreferences(ctx, userID)
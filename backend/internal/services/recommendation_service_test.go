@@ -304,3 +304,39 @@ func TestMergeAndRankEmptyScores(t *testing.T) {
 
 	assert.Empty(t, merged, "Should return empty list for empty scores")
 }
+
+// TestBlendContentScores tests that embedding similarity is blended into the content signal
+func TestBlendContentScores(t *testing.T) {
+	clipID1 := uuid.New()
+	clipID2 := uuid.New()
+	clipID3 := uuid.New()
+
+	metadataScores := []models.ClipScore{
+		{ClipID: clipID1, SimilarityScore: 0.6, SimilarityRank: 1},
+		{ClipID: clipID2, SimilarityScore: 0.4, SimilarityRank: 2},
+	}
+	embeddingScores := []models.ClipScore{
+		{ClipID: clipID2, SimilarityScore: 0.9, SimilarityRank: 1},
+		{ClipID: clipID3, SimilarityScore: 0.5, SimilarityRank: 2},
+	}
+
+	blended := blendContentScores(metadataScores, embeddingScores)
+
+	require.Len(t, blended, 3, "Should have one score per clip")
+	assert.Equal(t, clipID2, blended[0].ClipID, "Clip with the highest score should rank first")
+	assert.InDelta(t, 0.9, blended[0].SimilarityScore, 0.001, "Clip should keep its higher score")
+	assert.Equal(t, clipID1, blended[1].ClipID)
+	assert.Equal(t, clipID3, blended[2].ClipID)
+	for i, score := range blended {
+		assert.Equal(t, i+1, score.SimilarityRank, "Ranks should be reassigned")
+	}
+}
+
+// TestBlendContentScoresWithoutEmbeddings tests that metadata scores pass through unchanged
+func TestBlendContentScoresWithoutEmbeddings(t *testing.T) {
+	metadataScores := []models.ClipScore{
+		{ClipID: uuid.New(), SimilarityScore: 0.6, SimilarityRank: 1},
+	}
+
+	assert.Equal(t, metadataScores, blendContentScores(metadataScores, nil))
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// reengagementEmailData is the view rendered by the re-engagement templates
+type reengagementEmailData struct {
+	Title          string
+	Intro          string
+	Clips          []reengagementEmailClip
+	SiteURL        string
+	UnsubscribeURL string
+	SettingsURL    string
+}
+
+type reengagementEmailClip struct {
+	Title        string
+	Broadcaster  string
+	Game         string
+	ThumbnailURL string
+	URL          string
+	VoteScore    int
+}
+
+var reengagementHTMLTemplate = htmltemplate.Must(htmltemplate.New("reengagement_html").Parse(`<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{.Title}}</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333; max-width: 600px; margin: 0 auto; padding: 20px;">
+    <div style="background: linear-gradient(135deg, #667eea 0%, #764ba2 100%); padding: 30px; text-align: center; border-radius: 10px 10px 0 0;">
+        <h1 style="color: white; margin: 0; font-size: 24px;">🎬 {{.Title}}</h1>
+    </div>
+
+    <div style="background: #f9f9f9; padding: 30px; border-radius: 0 0 10px 10px;">
+        <p style="font-size: 16px; margin-bottom: 20px;">{{.Intro}}</p>
+{{range .Clips}}
+        <div style="background: white; padding: 15px 20px; border-left: 4px solid #667eea; margin: 10px 0; border-radius: 5px;">
+            {{if .ThumbnailURL}}<a href="{{.URL}}"><img src="{{.ThumbnailURL}}" alt="" style="width: 100%; border-radius: 5px; margin-bottom: 10px;"></a>{{end}}
+            <p style="margin: 0; font-weight: bold;"><a href="{{.URL}}" style="color: #333; text-decoration: none;">{{.Title}}</a></p>
+            <p style="margin: 5px 0 0 0; color: #666;">{{.Broadcaster}}{{if .Game}} · {{.Game}}{{end}} · {{.VoteScore}} votes</p>
+        </div>
+{{end}}
+        <p style="text-align: center; margin-top: 30px;">
+            <a href="{{.SiteURL}}" style="display: inline-block; background: #667eea; color: white; padding: 12px 30px; text-decoration: none; border-radius: 5px; font-weight: bold;">See What's New</a>
+        </p>
+
+        <hr style="border: none; border-top: 1px solid #ddd; margin: 30px 0;">
+
+        <p style="font-size: 12px; color: #999; text-align: center;">
+            You're receiving this because you haven't visited clpr in a while.<br>
+            {{if .UnsubscribeURL}}<a href="{{.UnsubscribeURL}}" style="color: #667eea; text-decoration: none;">Unsubscribe</a> | {{end}}
+            <a href="{{.SettingsURL}}" style="color: #667eea; text-decoration: none;">Manage Preferences</a>
+        </p>
+    </div>
+</body>
+</html>
+`))
+
+var reengagementTextTemplate = texttemplate.Must(texttemplate.New("reengagement_text").Parse(`{{.Title}}
+
+{{.Intro}}
+{{range .Clips}}
+- {{.Title}}
+  {{.Broadcaster}}{{if .Game}} · {{.Game}}{{end}} · {{.VoteScore}} votes
+  {{.URL}}
+{{end}}
+See what's new: {{.SiteURL}}
+
+---
+You're receiving this because you haven't visited clpr in a while.
+{{if .UnsubscribeURL}}Unsubscribe: {{.UnsubscribeURL}}
+{{end}}Manage preferences: {{.SettingsURL}}
+`))
+
+// SendReengagementEmail sends an inactive user the top clips they missed,
+// returning the ID of the email log. Preferences and frequency caps are
+// checked by the caller, and the hourly rate limit does not apply.
+func (s *EmailService) SendReengagementEmail(ctx context.Context, user *models.User, content *models.ReengagementEmailContent) (*uuid.UUID, error) {
+	if !s.enabled {
+		return nil, nil // Email service disabled
+	}
+	if user.Email == nil || *user.Email == "" {
+		return nil, nil // User has no email
+	}
+
+	reengagementType := models.NotificationTypeReengagement
+	unsubToken, err := s.generateUnsubscribeToken(ctx, user.ID, &reengagementType)
+	if err != nil {
+		// Continue without unsubscribe link
+		unsubToken = ""
+	}
+
+	subject, htmlBody, textBody, err := s.prepareReengagementEmail(user, content, unsubToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare re-engagement email: %w", err)
+	}
+
+	logEntry := &models.EmailNotificationLog{
+		ID:               uuid.New(),
+		UserID:           user.ID,
+		NotificationType: models.NotificationTypeReengagement,
+		RecipientEmail:   *user.Email,
+		Subject:          subject,
+		Status:           models.EmailStatusPending,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+	if err := s.repo.CreateLog(ctx, logEntry); err != nil {
+		return nil, fmt.Errorf("failed to create email log: %w", err)
+	}
+
+	htmlBody, textBody = s.trackLinks(ctx, EmailLinkContext{
+		Template:          models.NotificationTypeReengagement,
+		Recipient:         *user.Email,
+		UserID:            &user.ID,
+		NotificationLogID: &logEntry.ID,
+	}, htmlBody, textBody)
+
+	messageID, err := s.sendViaSendGrid(*user.Email, subject, htmlBody, textBody)
+	if err != nil {
+		logEntry.Status = models.EmailStatusFailed
+		errMsg := err.Error()
+		logEntry.ErrorMessage = &errMsg
+		logEntry.UpdatedAt = time.Now()
+		_ = s.repo.UpdateLog(ctx, logEntry)
+		return nil, fmt.Errorf("failed to send email: %w", err)
+	}
+
+	logEntry.Status = models.EmailStatusSent
+	logEntry.ProviderMessageID = &messageID
+	now := time.Now()
+	logEntry.SentAt = &now
+	logEntry.UpdatedAt = now
+	if err := s.repo.UpdateLog(ctx, logEntry); err != nil {
+		s.logger.Error("Failed to update email log after successful send", err, map[string]interface{}{
+			"log_id":  logEntry.ID.String(),
+			"user_id": user.ID.String(),
+		})
+	}
+
+	return &logEntry.ID, nil
+}
+
+// prepareReengagementEmail renders the re-engagement subject and bodies
+func (s *EmailService) prepareReengagementEmail(user *models.User, content *models.ReengagementEmailContent, unsubToken string) (subject, htmlBody, textBody string, err error) {
+	name := user.DisplayName
+	if name == "" {
+		name = user.Username
+	}
+
+	data := reengagementEmailData{
+		Title:       fmt.Sprintf("Top clips from the last %d days", content.MilestoneDays),
+		SiteURL:     s.baseURL,
+		SettingsURL: s.baseURL + "/settings",
+	}
+	if content.FromFollows {
+		data.Intro = fmt.Sprintf("Hi %s, here's what the streamers and people you follow posted while you were away.", name)
+	} else {
+		data.Intro = fmt.Sprintf("Hi %s, here's what the community voted up while you were away.", name)
+	}
+	if unsubToken != "" {
+		data.UnsubscribeURL = fmt.Sprintf("%s/api/v1/notifications/unsubscribe?token=%s", s.baseURL, unsubToken)
+	}
+
+	data.Clips = make([]reengagementEmailClip, len(content.Clips))
+	for i, clip := range content.Clips {
+		view := reengagementEmailClip{
+			Title:       clip.Title,
+			Broadcaster: clip.BroadcasterName,
+			URL:         fmt.Sprintf("%s/clips/%s", s.baseURL, clip.ID),
+			VoteScore:   clip.VoteScore,
+		}
+		if clip.GameName != nil {
+			view.Game = *clip.GameName
+		}
+		if clip.ThumbnailURL != nil {
+			view.ThumbnailURL = *clip.ThumbnailURL
+		}
+		data.Clips[i] = view
+	}
+
+	var htmlBuf, textBuf bytes.Buffer
+	if err := reengagementHTMLTemplate.Execute(&htmlBuf, data); err != nil {
+		return "", "", "", err
+	}
+	if err := reengagementTextTemplate.Execute(&textBuf, data); err != nil {
+		return "", "", "", err
+	}
+
+	if content.FromFollows {
+		subject = "You missed some great clips from your follows"
+	} else {
+		subject = "You missed some great clips on clpr"
+	}
+
+	return subject, htmlBuf.String(), textBuf.String(), nil
+}
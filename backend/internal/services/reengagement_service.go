@@ -0,0 +1,245 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const (
+	// reengagementBatchSize caps how many users are emailed per run
+	reengagementBatchSize = 200
+	// reengagementMaxAttempts is how many times a failed email is retried
+	reengagementMaxAttempts = 3
+	// reengagementMaxClips caps how many clips are shown in an email
+	reengagementMaxClips = 5
+	// ReengagementMinGap is the least time between two re-engagement emails to a user
+	ReengagementMinGap = 7 * 24 * time.Hour
+	// ReengagementReturnWindow is how soon after an email a user must be active
+	// to count as returned in campaign metrics
+	ReengagementReturnWindow = 7 * 24 * time.Hour
+)
+
+// ReengagementRepositoryInterface defines the repository methods used by ReengagementService
+type ReengagementRepositoryInterface interface {
+	ListDue(ctx context.Context, milestones []int, now time.Time, minGap time.Duration, maxAttempts, limit int) ([]models.ReengagementCandidate, error)
+	Claim(ctx context.Context, email *models.ReengagementEmail, maxAttempts int) (bool, error)
+	Complete(ctx context.Context, email *models.ReengagementEmail) error
+	LastActivity(ctx context.Context, userID uuid.UUID) (time.Time, error)
+	TopClipsFromFollows(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]models.ReengagementClip, error)
+	TopClips(ctx context.Context, since time.Time, limit int) ([]models.ReengagementClip, error)
+	CampaignMetrics(ctx context.Context, start, end time.Time, returnWindow time.Duration) ([]models.ReengagementCampaignMetrics, error)
+}
+
+// ReengagementSender sends a rendered re-engagement email, returning the ID of
+// its email log when one was written
+type ReengagementSender interface {
+	SendReengagementEmail(ctx context.Context, user *models.User, content *models.ReengagementEmailContent) (*uuid.UUID, error)
+}
+
+// ReengagementService emails users who have been inactive for 14, 30 or 60
+// days with the top clips they missed from their follows
+type ReengagementService struct {
+	repo      ReengagementRepositoryInterface
+	prefsRepo NotificationPreferencesReader
+	userRepo  NotificationDigestUserReader
+	sender    ReengagementSender
+	throttle  BulkEmailThrottle
+}
+
+// NewReengagementService creates a new ReengagementService
+func NewReengagementService(
+	repo ReengagementRepositoryInterface,
+	prefsRepo NotificationPreferencesReader,
+	userRepo NotificationDigestUserReader,
+	sender ReengagementSender,
+) *ReengagementService {
+	return &ReengagementService{
+		repo:      repo,
+		prefsRepo: prefsRepo,
+		userRepo:  userRepo,
+		sender:    sender,
+	}
+}
+
+// SetThrottle sets the bulk email throttle the service obeys
+func (s *ReengagementService) SetThrottle(throttle BulkEmailThrottle) {
+	s.throttle = throttle
+}
+
+// SendDue emails users who reached an inactivity milestone, returning how
+// many emails were sent. When the throttle stops sends, the rest stay due for
+// a later run.
+func (s *ReengagementService) SendDue(ctx context.Context, now time.Time) (int, error) {
+	candidates, err := s.repo.ListDue(ctx, models.ReengagementMilestones, now, ReengagementMinGap, reengagementMaxAttempts, reengagementBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list users due a re-engagement email: %w", err)
+	}
+
+	sent := 0
+	for _, candidate := range candidates {
+		if ctx.Err() != nil {
+			return sent, ctx.Err()
+		}
+
+		decision, err := checkBulkEmail(ctx, s.throttle, s.prefsRepo, candidate.UserID)
+		if err != nil {
+			return sent, fmt.Errorf("failed to check email throttle: %w", err)
+		}
+		if decision == BulkEmailThrottled {
+			return sent, nil
+		}
+		if decision == BulkEmailOutsideWindow {
+			continue
+		}
+
+		email := &models.ReengagementEmail{
+			UserID:        candidate.UserID,
+			MilestoneDays: candidate.MilestoneDays,
+			InactiveSince: candidate.InactiveSince,
+		}
+		ok, err := s.sendEmail(ctx, email)
+		if err != nil {
+			utils.GetLogger().Error("Failed to send re-engagement email", err, map[string]interface{}{
+				"user_id":   candidate.UserID.String(),
+				"milestone": candidate.MilestoneDays,
+			})
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+// CampaignMetrics summarizes the emails of each milestone created in the period
+func (s *ReengagementService) CampaignMetrics(ctx context.Context, start, end time.Time) ([]models.ReengagementCampaignMetrics, error) {
+	metrics, err := s.repo.CampaignMetrics(ctx, start, end, ReengagementReturnWindow)
+	if err != nil {
+		return nil, err
+	}
+	for i := range metrics {
+		metrics[i].ClickRate = percentOf(metrics[i].Clicked, metrics[i].Sent)
+		metrics[i].ReturnRate = percentOf(metrics[i].Returned, metrics[i].Sent)
+	}
+	return metrics, nil
+}
+
+// sendEmail claims, builds and sends one email, reporting whether it was sent
+func (s *ReengagementService) sendEmail(ctx context.Context, email *models.ReengagementEmail) (bool, error) {
+	claimed, err := s.repo.Claim(ctx, email, reengagementMaxAttempts)
+	if err != nil {
+		return false, err
+	}
+	if !claimed {
+		// Already handled for this streak, possibly by another instance
+		return false, nil
+	}
+
+	sendErr := s.deliver(ctx, email)
+	if sendErr != nil {
+		msg := sendErr.Error()
+		email.Status = models.ReengagementStatusFailed
+		email.ErrorMessage = &msg
+	}
+	if err := s.repo.Complete(ctx, email); err != nil {
+		return false, err
+	}
+	if sendErr != nil {
+		return false, sendErr
+	}
+	return email.Status == models.ReengagementStatusSent, nil
+}
+
+// deliver builds and sends a claimed email, setting its status and content
+// counts. Users who came back or opted out since they were listed are skipped.
+func (s *ReengagementService) deliver(ctx context.Context, email *models.ReengagementEmail) error {
+	lastActivity, err := s.repo.LastActivity(ctx, email.UserID)
+	if err != nil {
+		return err
+	}
+	if lastActivity.After(email.InactiveSince) {
+		skipReengagement(email, models.ReengagementSkipActive)
+		return nil
+	}
+
+	prefs, err := s.prefsRepo.GetPreferences(ctx, email.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	if !prefs.EmailEnabled || !prefs.NotifyReengagement || prefs.EmailDigest == models.EmailDigestNever {
+		skipReengagement(email, models.ReengagementSkipOptedOut)
+		return nil
+	}
+
+	user, err := s.userRepo.GetByID(ctx, email.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Email == nil || *user.Email == "" {
+		skipReengagement(email, models.ReengagementSkipNoEmail)
+		return nil
+	}
+
+	content, err := s.buildContent(ctx, email)
+	if err != nil {
+		return err
+	}
+	email.ClipCount = len(content.Clips)
+	email.FromFollows = content.FromFollows
+	if len(content.Clips) == 0 {
+		skipReengagement(email, models.ReengagementSkipNoClips)
+		return nil
+	}
+
+	logID, err := s.sender.SendReengagementEmail(ctx, user, content)
+	if err != nil {
+		return err
+	}
+	email.Status = models.ReengagementStatusSent
+	email.EmailLogID = logID
+	return nil
+}
+
+// buildContent picks the top clips the user missed from their follows, or
+// from the whole site when their follows posted nothing
+func (s *ReengagementService) buildContent(ctx context.Context, email *models.ReengagementEmail) (*models.ReengagementEmailContent, error) {
+	content := &models.ReengagementEmailContent{
+		MilestoneDays: email.MilestoneDays,
+		InactiveSince: email.InactiveSince,
+		FromFollows:   true,
+	}
+
+	clips, err := s.repo.TopClipsFromFollows(ctx, email.UserID, email.InactiveSince, reengagementMaxClips)
+	if err != nil {
+		return nil, err
+	}
+	if len(clips) == 0 {
+		content.FromFollows = false
+		if clips, err = s.repo.TopClips(ctx, email.InactiveSince, reengagementMaxClips); err != nil {
+			return nil, err
+		}
+	}
+	content.Clips = clips
+	return content, nil
+}
+
+func skipReengagement(email *models.ReengagementEmail, reason string) {
+	email.Status = models.ReengagementStatusSkipped
+	email.SkipReason = &reason
+}
+
+// percentOf returns part as a percentage of total, or nil when total is zero
+func percentOf(part, total int) *float64 {
+	if total == 0 {
+		return nil
+	}
+	percent := float64(part) / float64(total) * 100
+	return &percent
+}
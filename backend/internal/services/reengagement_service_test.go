@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// MockReengagementRepository is a mock implementation of ReengagementRepositoryInterface
+type MockReengagementRepository struct {
+	mock.Mock
+}
+
+func (m *MockReengagementRepository) ListDue(ctx context.Context, milestones []int, now time.Time, minGap time.Duration, maxAttempts, limit int) ([]models.ReengagementCandidate, error) {
+	args := m.Called(ctx, milestones, now, minGap, maxAttempts, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ReengagementCandidate), args.Error(1)
+}
+
+func (m *MockReengagementRepository) Claim(ctx context.Context, email *models.ReengagementEmail, maxAttempts int) (bool, error) {
+	args := m.Called(ctx, email, maxAttempts)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReengagementRepository) Complete(ctx context.Context, email *models.ReengagementEmail) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
+
+func (m *MockReengagementRepository) LastActivity(ctx context.Context, userID uuid.UUID) (time.Time, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockReengagementRepository) TopClipsFromFollows(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]models.ReengagementClip, error) {
+	args := m.Called(ctx, userID, since, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ReengagementClip), args.Error(1)
+}
+
+func (m *MockReengagementRepository) TopClips(ctx context.Context, since time.Time, limit int) ([]models.ReengagementClip, error) {
+	args := m.Called(ctx, since, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ReengagementClip), args.Error(1)
+}
+
+func (m *MockReengagementRepository) CampaignMetrics(ctx context.Context, start, end time.Time, returnWindow time.Duration) ([]models.ReengagementCampaignMetrics, error) {
+	args := m.Called(ctx, start, end, returnWindow)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ReengagementCampaignMetrics), args.Error(1)
+}
+
+// MockReengagementSender is a mock implementation of ReengagementSender
+type MockReengagementSender struct {
+	mock.Mock
+}
+
+func (m *MockReengagementSender) SendReengagementEmail(ctx context.Context, user *models.User, content *models.ReengagementEmailContent) (*uuid.UUID, error) {
+	args := m.Called(ctx, user, content)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*uuid.UUID), args.Error(1)
+}
+
+func reengagementPrefs() *models.NotificationPreferences {
+	prefs := digestPrefs(models.EmailDigestImmediate)
+	prefs.NotifyReengagement = true
+	return prefs
+}
+
+func setupReengagementServiceTest(prefs map[uuid.UUID]*models.NotificationPreferences) (*ReengagementService, *MockReengagementRepository, *MockReengagementSender) {
+	repo := new(MockReengagementRepository)
+	sender := new(MockReengagementSender)
+	prefsRepo := new(MockNotificationPreferencesReader)
+	users := new(MockUserRepository)
+	for userID, userPrefs := range prefs {
+		prefsRepo.On("GetPreferences", mock.Anything, userID).Return(userPrefs, nil)
+		expectEmailRecipients(users, userID)
+	}
+	svc := NewReengagementService(repo, prefsRepo, users, sender)
+	return svc, repo, sender
+}
+
+// expectReengagementDue expects each run at now to list the candidates
+func expectReengagementDue(repo *MockReengagementRepository, now time.Time, candidates []models.ReengagementCandidate) {
+	repo.On("ListDue", mock.Anything, models.ReengagementMilestones, now, ReengagementMinGap, reengagementMaxAttempts, reengagementBatchSize).
+		Return(candidates, nil)
+}
+
+// reengagementEmailFor matches the re-engagement email of a user
+func reengagementEmailFor(userID uuid.UUID) interface{} {
+	return mock.MatchedBy(func(email *models.ReengagementEmail) bool { return email.UserID == userID })
+}
+
+// expectReengagementEmail expects the user's email to be claimed once, and
+// returns the email as it is completed
+func expectReengagementEmail(repo *MockReengagementRepository, userID uuid.UUID) *models.ReengagementEmail {
+	completed := &models.ReengagementEmail{}
+	repo.On("Claim", mock.Anything, reengagementEmailFor(userID), reengagementMaxAttempts).Run(func(args mock.Arguments) {
+		email := args.Get(1).(*models.ReengagementEmail)
+		email.ID = uuid.New()
+		email.Status = models.ReengagementStatusPending
+	}).Return(true, nil).Once()
+	repo.On("Complete", mock.Anything, reengagementEmailFor(userID)).Run(func(args mock.Arguments) {
+		*completed = *args.Get(1).(*models.ReengagementEmail)
+	}).Return(nil).Once()
+	return completed
+}
+
+func TestReengagementSendDue(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	inactiveSince := now.AddDate(0, 0, -31)
+	userID := uuid.New()
+
+	svc, repo, sender := setupReengagementServiceTest(map[uuid.UUID]*models.NotificationPreferences{userID: reengagementPrefs()})
+	expectReengagementDue(repo, now, []models.ReengagementCandidate{{UserID: userID, MilestoneDays: 30, InactiveSince: inactiveSince}})
+	email := expectReengagementEmail(repo, userID)
+	repo.On("LastActivity", mock.Anything, userID).Return(inactiveSince, nil).Once()
+	repo.On("TopClipsFromFollows", mock.Anything, userID, inactiveSince, reengagementMaxClips).
+		Return([]models.ReengagementClip{{ID: uuid.New(), Title: "Clutch ace"}}, nil).Once()
+	logID := uuid.New()
+	sender.On("SendReengagementEmail", mock.Anything, mock.AnythingOfType("*models.User"), mock.MatchedBy(func(content *models.ReengagementEmailContent) bool {
+		return content.FromFollows && content.MilestoneDays == 30 && len(content.Clips) == 1
+	})).Return(&logID, nil).Once()
+
+	sent, err := svc.SendDue(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+
+	assert.Equal(t, models.ReengagementStatusSent, email.Status)
+	assert.Equal(t, 1, email.ClipCount)
+	assert.Equal(t, &logID, email.EmailLogID)
+
+	// Already handled for this streak
+	repo.On("Claim", mock.Anything, reengagementEmailFor(userID), reengagementMaxAttempts).Return(false, nil).Once()
+	sent, err = svc.SendDue(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+
+	repo.AssertExpectations(t)
+	sender.AssertExpectations(t)
+}
+
+func TestReengagementFallsBackToSiteClips(t *testing.T) {
+	now := time.Now()
+	userID := uuid.New()
+	inactiveSince := now.AddDate(0, 0, -15)
+
+	svc, repo, sender := setupReengagementServiceTest(map[uuid.UUID]*models.NotificationPreferences{userID: reengagementPrefs()})
+	expectReengagementDue(repo, now, []models.ReengagementCandidate{{UserID: userID, MilestoneDays: 14, InactiveSince: inactiveSince}})
+	email := expectReengagementEmail(repo, userID)
+	repo.On("LastActivity", mock.Anything, userID).Return(inactiveSince, nil).Once()
+	repo.On("TopClipsFromFollows", mock.Anything, userID, inactiveSince, reengagementMaxClips).Return(nil, nil).Once()
+	repo.On("TopClips", mock.Anything, inactiveSince, reengagementMaxClips).
+		Return([]models.ReengagementClip{{ID: uuid.New()}, {ID: uuid.New()}}, nil).Once()
+	logID := uuid.New()
+	sender.On("SendReengagementEmail", mock.Anything, mock.AnythingOfType("*models.User"), mock.MatchedBy(func(content *models.ReengagementEmailContent) bool {
+		return !content.FromFollows
+	})).Return(&logID, nil).Once()
+
+	sent, err := svc.SendDue(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.False(t, email.FromFollows)
+	assert.Equal(t, 2, email.ClipCount)
+
+	repo.AssertExpectations(t)
+	sender.AssertExpectations(t)
+}
+
+func TestReengagementSkips(t *testing.T) {
+	now := time.Now()
+	inactiveSince := now.AddDate(0, 0, -61)
+	clips := []models.ReengagementClip{{ID: uuid.New()}}
+
+	returned := uuid.New()
+	optedOut := uuid.New()
+	noClips := uuid.New()
+
+	optedOutPrefs := reengagementPrefs()
+	optedOutPrefs.NotifyReengagement = false
+
+	tests := []struct {
+		name   string
+		userID uuid.UUID
+		prefs  *models.NotificationPreferences
+		active time.Time
+		clips  []models.ReengagementClip
+		reason string
+	}{
+		{"user came back", returned, reengagementPrefs(), now.Add(-time.Hour), clips, models.ReengagementSkipActive},
+		{"opted out", optedOut, optedOutPrefs, inactiveSince, clips, models.ReengagementSkipOptedOut},
+		{"nothing to show", noClips, reengagementPrefs(), inactiveSince, nil, models.ReengagementSkipNoClips},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, sender := setupReengagementServiceTest(map[uuid.UUID]*models.NotificationPreferences{tt.userID: tt.prefs})
+			expectReengagementDue(repo, now, []models.ReengagementCandidate{{UserID: tt.userID, MilestoneDays: 60, InactiveSince: inactiveSince}})
+			email := expectReengagementEmail(repo, tt.userID)
+			repo.On("LastActivity", mock.Anything, tt.userID).Return(tt.active, nil).Once()
+			repo.On("TopClipsFromFollows", mock.Anything, tt.userID, inactiveSince, reengagementMaxClips).Return(tt.clips, nil)
+			repo.On("TopClips", mock.Anything, inactiveSince, reengagementMaxClips).Return(nil, nil)
+
+			sent, err := svc.SendDue(context.Background(), now)
+			require.NoError(t, err)
+			assert.Equal(t, 0, sent)
+			sender.AssertNotCalled(t, "SendReengagementEmail", mock.Anything, mock.Anything, mock.Anything)
+
+			assert.Equal(t, models.ReengagementStatusSkipped, email.Status)
+			require.NotNil(t, email.SkipReason)
+			assert.Equal(t, tt.reason, *email.SkipReason)
+		})
+	}
+}
+
+func TestReengagementCampaignMetricsRates(t *testing.T) {
+	svc, repo, _ := setupReengagementServiceTest(nil)
+	start, end := time.Now().AddDate(0, 0, -30), time.Now()
+	repo.On("CampaignMetrics", mock.Anything, start, end, ReengagementReturnWindow).Return([]models.ReengagementCampaignMetrics{
+		{MilestoneDays: 14, Sent: 8, Clicked: 2, Returned: 4},
+		{MilestoneDays: 30, Skipped: 3, Suppressed: 3},
+	}, nil).Once()
+
+	metrics, err := svc.CampaignMetrics(context.Background(), start, end)
+	require.NoError(t, err)
+	require.Len(t, metrics, 2)
+	require.NotNil(t, metrics[0].ClickRate)
+	assert.InDelta(t, 25.0, *metrics[0].ClickRate, 0.001)
+	assert.InDelta(t, 50.0, *metrics[0].ReturnRate, 0.001)
+	assert.Nil(t, metrics[1].ClickRate)
+	assert.Nil(t, metrics[1].ReturnRate)
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+// myReportsListLimit caps how many of their own reports a user lists per page
+const myReportsListLimit = 100
+
+var reportOutcomesTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "report_outcomes_total",
+		Help: "Total number of reports resolved, by outcome",
+	},
+	[]string{"status"}, // "actioned", "dismissed"
+)
+
+// ReportOutcomeRepositoryInterface defines the repository methods used by ReportOutcomeService
+type ReportOutcomeRepositoryInterface interface {
+	RecordReporterOutcome(ctx context.Context, reporterID uuid.UUID, actioned bool) error
+	ListReportsByReporter(ctx context.Context, reporterID uuid.UUID, status string, limit, offset int) ([]models.ReporterReport, int, error)
+}
+
+// ReportOutcomeNotifier tells reporters how their reports were resolved
+type ReportOutcomeNotifier interface {
+	NotifyReportOutcome(ctx context.Context, userID, reportID uuid.UUID, reportableType string, actioned bool) error
+}
+
+// ReportOutcomeTrustUpdater recalculates a reporter's trust score
+type ReportOutcomeTrustUpdater interface {
+	UpdateScoreRealtime(ctx context.Context, userID uuid.UUID, reason string) error
+}
+
+// ReportOutcomeService closes the loop with reporters. When a report is
+// actioned or dismissed, the reporter is notified and the report counts toward
+// their correct or incorrect reports, which feed their trust score.
+type ReportOutcomeService struct {
+	repo     ReportOutcomeRepositoryInterface
+	notifier ReportOutcomeNotifier
+	trust    ReportOutcomeTrustUpdater
+}
+
+// NewReportOutcomeService creates a new ReportOutcomeService. The notifier
+// and trust updater are optional.
+func NewReportOutcomeService(repo ReportOutcomeRepositoryInterface, notifier ReportOutcomeNotifier, trust ReportOutcomeTrustUpdater) *ReportOutcomeService {
+	return &ReportOutcomeService{
+		repo:     repo,
+		notifier: notifier,
+		trust:    trust,
+	}
+}
+
+// RecordOutcomes records the outcome of reports that were just resolved as
+// actioned or dismissed. Only the first resolution of a report counts, so
+// callers pass reports that were pending or reviewed before. Failures are
+// logged rather than returned, since the resolution has already been saved.
+func (s *ReportOutcomeService) RecordOutcomes(ctx context.Context, reports []models.Report, status string) {
+	if status != "actioned" && status != "dismissed" {
+		return
+	}
+	actioned := status == "actioned"
+	reason := models.TrustScoreReasonReportDismissed
+	if actioned {
+		reason = models.TrustScoreReasonReportActioned
+	}
+
+	for _, report := range reports {
+		reportOutcomesTotal.WithLabelValues(status).Inc()
+
+		if err := s.repo.RecordReporterOutcome(ctx, report.ReporterID, actioned); err != nil {
+			utils.GetLogger().Error("Failed to record report outcome", err, map[string]interface{}{
+				"report_id":   report.ID.String(),
+				"reporter_id": report.ReporterID.String(),
+			})
+			continue
+		}
+		if s.trust != nil {
+			_ = s.trust.UpdateScoreRealtime(ctx, report.ReporterID, reason)
+		}
+		if s.notifier != nil {
+			if err := s.notifier.NotifyReportOutcome(ctx, report.ReporterID, report.ID, report.ReportableType, actioned); err != nil {
+				utils.GetLogger().Error("Failed to notify reporter of report outcome", err, map[string]interface{}{
+					"report_id":   report.ID.String(),
+					"reporter_id": report.ReporterID.String(),
+				})
+			}
+		}
+	}
+}
+
+// ListMyReports returns a user's own reports, newest first, and the total
+// number of matching reports
+func (s *ReportOutcomeService) ListMyReports(ctx context.Context, reporterID uuid.UUID, status string, limit, offset int) ([]models.ReporterReport, int, error) {
+	if limit <= 0 || limit > myReportsListLimit {
+		limit = myReportsListLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.repo.ListReportsByReporter(ctx, reporterID, status, limit, offset)
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// MockReportOutcomeRepository is a mock implementation of ReportOutcomeRepositoryInterface
+type MockReportOutcomeRepository struct {
+	mock.Mock
+}
+
+func (m *MockReportOutcomeRepository) RecordReporterOutcome(ctx context.Context, reporterID uuid.UUID, actioned bool) error {
+	args := m.Called(ctx, reporterID, actioned)
+	return args.Error(0)
+}
+
+func (m *MockReportOutcomeRepository) ListReportsByReporter(ctx context.Context, reporterID uuid.UUID, status string, limit, offset int) ([]models.ReporterReport, int, error) {
+	args := m.Called(ctx, reporterID, status, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]models.ReporterReport), args.Int(1), args.Error(2)
+}
+
+// MockReportOutcomeNotifier is a mock implementation of ReportOutcomeNotifier
+type MockReportOutcomeNotifier struct {
+	mock.Mock
+}
+
+func (m *MockReportOutcomeNotifier) NotifyReportOutcome(ctx context.Context, userID, reportID uuid.UUID, reportableType string, actioned bool) error {
+	args := m.Called(ctx, userID, reportID, reportableType, actioned)
+	return args.Error(0)
+}
+
+// MockReportOutcomeTrustUpdater is a mock implementation of ReportOutcomeTrustUpdater
+type MockReportOutcomeTrustUpdater struct {
+	mock.Mock
+}
+
+func (m *MockReportOutcomeTrustUpdater) UpdateScoreRealtime(ctx context.Context, userID uuid.UUID, reason string) error {
+	args := m.Called(ctx, userID, reason)
+	return args.Error(0)
+}
+
+// expectReportOutcome expects a report's outcome to be recorded, counted
+// toward its reporter's trust score and sent to the reporter
+func expectReportOutcome(repo *MockReportOutcomeRepository, trust *MockReportOutcomeTrustUpdater, notifier *MockReportOutcomeNotifier, report models.Report, actioned bool) {
+	reason := models.TrustScoreReasonReportDismissed
+	if actioned {
+		reason = models.TrustScoreReasonReportActioned
+	}
+	repo.On("RecordReporterOutcome", mock.Anything, report.ReporterID, actioned).Return(nil).Once()
+	trust.On("UpdateScoreRealtime", mock.Anything, report.ReporterID, reason).Return(nil).Once()
+	notifier.On("NotifyReportOutcome", mock.Anything, report.ReporterID, report.ID, report.ReportableType, actioned).Return(nil).Once()
+}
+
+func TestReportOutcomeService_RecordOutcomes(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockReportOutcomeRepository)
+	notifier := new(MockReportOutcomeNotifier)
+	trust := new(MockReportOutcomeTrustUpdater)
+	svc := NewReportOutcomeService(repo, notifier, trust)
+
+	alice, bob := uuid.New(), uuid.New()
+	reports := []models.Report{
+		{ID: uuid.New(), ReporterID: alice, ReportableType: "clip"},
+		{ID: uuid.New(), ReporterID: bob, ReportableType: "clip"},
+	}
+
+	expectReportOutcome(repo, trust, notifier, reports[0], true)
+	expectReportOutcome(repo, trust, notifier, reports[1], true)
+	svc.RecordOutcomes(ctx, reports, "actioned")
+
+	expectReportOutcome(repo, trust, notifier, reports[0], false)
+	svc.RecordOutcomes(ctx, reports[:1], "dismissed")
+	notifier.AssertNumberOfCalls(t, "NotifyReportOutcome", 3)
+
+	// Reports that are still open have no outcome
+	svc.RecordOutcomes(ctx, reports, "reviewed")
+	repo.AssertNumberOfCalls(t, "RecordReporterOutcome", 3)
+
+	// A reporter whose outcome couldn't be saved isn't told about it
+	expectReportOutcome(repo, trust, notifier, reports[0], false)
+	repo.On("RecordReporterOutcome", mock.Anything, bob, false).Return(errors.New("db down")).Once()
+	svc.RecordOutcomes(ctx, reports, "dismissed")
+
+	repo.AssertExpectations(t)
+	trust.AssertExpectations(t)
+	notifier.AssertExpectations(t)
+	notifier.AssertNotCalled(t, "NotifyReportOutcome", mock.Anything, bob, reports[1].ID, "clip", false)
+	trust.AssertNotCalled(t, "UpdateScoreRealtime", mock.Anything, bob, models.TrustScoreReasonReportDismissed)
+}
+
+func TestReportOutcomeService_OptionalDependencies(t *testing.T) {
+	repo := new(MockReportOutcomeRepository)
+	svc := NewReportOutcomeService(repo, nil, nil)
+	reporter := uuid.New()
+
+	repo.On("RecordReporterOutcome", mock.Anything, reporter, true).Return(nil).Once()
+	svc.RecordOutcomes(context.Background(), []models.Report{{ID: uuid.New(), ReporterID: reporter}}, "actioned")
+
+	repo.On("ListReportsByReporter", mock.Anything, reporter, "", myReportsListLimit, 0).Return([]models.ReporterReport{}, 0, nil).Once()
+	_, _, err := svc.ListMyReports(context.Background(), reporter, "", 500, -1)
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
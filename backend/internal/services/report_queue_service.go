@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+const (
+	// reportQueueListLimit caps how many report groups are listed per page
+	reportQueueListLimit = 100
+
+	// Priority score weights. They add up to the maximum score of 100.
+	reportPriorityCountWeight = 40.0
+	reportPriorityTrustWeight = 35.0
+	reportPriorityReachWeight = 25.0
+
+	// reportPriorityCountSaturation is the report count that earns the full count weight
+	reportPriorityCountSaturation = 10
+	// reportPriorityReachSaturation is the reach that earns the full reach weight
+	reportPriorityReachSaturation = 100000
+)
+
+// ReportQueueRepositoryInterface defines the repository methods used by ReportQueueService
+type ReportQueueRepositoryInterface interface {
+	ListReportGroupStats(ctx context.Context, groupID *uuid.UUID) ([]models.ReportGroupStats, error)
+	SetReportGroupPriority(ctx context.Context, stats models.ReportGroupStats, score float64) error
+	ListReportQueue(ctx context.Context, filters models.ReportQueueFilters) ([]models.ReportGroup, int, error)
+	GetReportGroup(ctx context.Context, id uuid.UUID) (*models.ReportGroup, error)
+	ListReportsByGroup(ctx context.Context, groupID uuid.UUID) ([]models.Report, error)
+	ResolveReportGroup(ctx context.Context, id uuid.UUID, resolution string, reviewerID uuid.UUID) ([]models.Report, error)
+}
+
+// ReportQueueService scores report groups and serves them to moderators,
+// highest priority first. Reports against the same item share an open group,
+// so the item is reviewed once however many users reported it.
+type ReportQueueService struct {
+	repo ReportQueueRepositoryInterface
+}
+
+// NewReportQueueService creates a new ReportQueueService
+func NewReportQueueService(repo ReportQueueRepositoryInterface) *ReportQueueService {
+	return &ReportQueueService{repo: repo}
+}
+
+// reportPriorityScore scores a report group from 0 to 100. The report count
+// and the reported item's reach are scaled logarithmically, so the first few
+// reports and views count the most; the reporters' mean trust score is linear.
+func reportPriorityScore(stats models.ReportGroupStats) float64 {
+	count := logScale(float64(stats.ReportCount), reportPriorityCountSaturation)
+	trust := math.Max(0, math.Min(stats.AvgReporterTrust, 100)) / 100
+	reach := logScale(float64(stats.Reach), reportPriorityReachSaturation)
+
+	score := reportPriorityCountWeight*count + reportPriorityTrustWeight*trust + reportPriorityReachWeight*reach
+	return math.Round(score*100) / 100
+}
+
+// logScale maps v to 0-1 on a log scale that reaches 1 at saturation
+func logScale(v, saturation float64) float64 {
+	if v <= 0 {
+		return 0
+	}
+	return math.Min(1, math.Log1p(v)/math.Log1p(saturation))
+}
+
+// ScoreGroup recomputes an open group's priority score
+func (s *ReportQueueService) ScoreGroup(ctx context.Context, groupID uuid.UUID) error {
+	stats, err := s.repo.ListReportGroupStats(ctx, &groupID)
+	if err != nil {
+		return err
+	}
+	for _, st := range stats {
+		if err := s.repo.SetReportGroupPriority(ctx, st, reportPriorityScore(st)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RefreshPriorities recomputes the priority score of every open group, so
+// scores follow changes in reach and reporter trust. It returns the number of
+// groups scored.
+func (s *ReportQueueService) RefreshPriorities(ctx context.Context) (int, error) {
+	stats, err := s.repo.ListReportGroupStats(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	for i, st := range stats {
+		if err := s.repo.SetReportGroupPriority(ctx, st, reportPriorityScore(st)); err != nil {
+			return i, err
+		}
+	}
+	return len(stats), nil
+}
+
+// ListQueue returns report groups, highest priority first, and the total
+// number of matching groups. Open groups are listed unless filtered otherwise.
+func (s *ReportQueueService) ListQueue(ctx context.Context, filters models.ReportQueueFilters) ([]models.ReportGroup, int, error) {
+	if filters.Limit <= 0 || filters.Limit > reportQueueListLimit {
+		filters.Limit = reportQueueListLimit
+	}
+	if filters.Offset < 0 {
+		filters.Offset = 0
+	}
+	return s.repo.ListReportQueue(ctx, filters)
+}
+
+// GetGroup returns a report group with its reports
+func (s *ReportQueueService) GetGroup(ctx context.Context, id uuid.UUID) (*models.ReportGroupDetail, error) {
+	group, err := s.repo.GetReportGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := s.repo.ListReportsByGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ReportGroupDetail{ReportGroup: *group, Reports: reports}, nil
+}
+
+// ResolveGroup resolves an open group as actioned or dismissed, giving all
+// of its open reports that status. It returns the resolved group and the
+// reports it resolved.
+func (s *ReportQueueService) ResolveGroup(ctx context.Context, id uuid.UUID, resolution string, reviewerID uuid.UUID) (*models.ReportGroup, []models.Report, error) {
+	if resolution != "actioned" && resolution != "dismissed" {
+		return nil, nil, fmt.Errorf("invalid report group resolution %q", resolution)
+	}
+	reports, err := s.repo.ResolveReportGroup(ctx, id, resolution, reviewerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	group, err := s.repo.GetReportGroup(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return group, reports, nil
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+// MockReportQueueRepository is a mock implementation of ReportQueueRepositoryInterface
+type MockReportQueueRepository struct {
+	mock.Mock
+}
+
+func (m *MockReportQueueRepository) ListReportGroupStats(ctx context.Context, groupID *uuid.UUID) ([]models.ReportGroupStats, error) {
+	args := m.Called(ctx, groupID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ReportGroupStats), args.Error(1)
+}
+
+func (m *MockReportQueueRepository) SetReportGroupPriority(ctx context.Context, stats models.ReportGroupStats, score float64) error {
+	args := m.Called(ctx, stats, score)
+	return args.Error(0)
+}
+
+func (m *MockReportQueueRepository) ListReportQueue(ctx context.Context, filters models.ReportQueueFilters) ([]models.ReportGroup, int, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]models.ReportGroup), args.Int(1), args.Error(2)
+}
+
+func (m *MockReportQueueRepository) GetReportGroup(ctx context.Context, id uuid.UUID) (*models.ReportGroup, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ReportGroup), args.Error(1)
+}
+
+func (m *MockReportQueueRepository) ListReportsByGroup(ctx context.Context, groupID uuid.UUID) ([]models.Report, error) {
+	args := m.Called(ctx, groupID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Report), args.Error(1)
+}
+
+func (m *MockReportQueueRepository) ResolveReportGroup(ctx context.Context, id uuid.UUID, resolution string, reviewerID uuid.UUID) ([]models.Report, error) {
+	args := m.Called(ctx, id, resolution, reviewerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Report), args.Error(1)
+}
+
+func TestReportPriorityScore(t *testing.T) {
+	assert.Equal(t, 0.0, reportPriorityScore(models.ReportGroupStats{}))
+
+	// Each input saturates at its full weight
+	assert.Equal(t, 100.0, reportPriorityScore(models.ReportGroupStats{ReportCount: 50, AvgReporterTrust: 100, Reach: 5000000}))
+	assert.Equal(t, 40.0, reportPriorityScore(models.ReportGroupStats{ReportCount: 10}))
+	assert.Equal(t, 35.0, reportPriorityScore(models.ReportGroupStats{AvgReporterTrust: 100}))
+	assert.Equal(t, 25.0, reportPriorityScore(models.ReportGroupStats{Reach: 100000}))
+
+	// One report from a trusted user on popular content outranks several
+	// reports from new accounts on content nobody has seen
+	trusted := reportPriorityScore(models.ReportGroupStats{ReportCount: 1, AvgReporterTrust: 90, Reach: 50000})
+	piledOn := reportPriorityScore(models.ReportGroupStats{ReportCount: 4, AvgReporterTrust: 5, Reach: 10})
+	assert.Greater(t, trusted, piledOn)
+
+	// More reports, more trust and more reach each raise the score
+	base := models.ReportGroupStats{ReportCount: 2, AvgReporterTrust: 40, Reach: 1000}
+	more := base
+	more.ReportCount = 3
+	assert.Greater(t, reportPriorityScore(more), reportPriorityScore(base))
+	more = base
+	more.AvgReporterTrust = 60
+	assert.Greater(t, reportPriorityScore(more), reportPriorityScore(base))
+	more = base
+	more.Reach = 2000
+	assert.Greater(t, reportPriorityScore(more), reportPriorityScore(base))
+}
+
+func TestReportQueueService_Scoring(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockReportQueueRepository)
+	svc := NewReportQueueService(repo)
+
+	a, b := uuid.New(), uuid.New()
+	statsA := models.ReportGroupStats{GroupID: a, ReportCount: 3, AvgReporterTrust: 80, Reach: 20000}
+	statsB := models.ReportGroupStats{GroupID: b, ReportCount: 1, AvgReporterTrust: 10, Reach: 5}
+	repo.On("ListReportGroupStats", ctx, &a).Return([]models.ReportGroupStats{statsA}, nil).Once()
+	repo.On("ListReportGroupStats", ctx, (*uuid.UUID)(nil)).Return([]models.ReportGroupStats{statsA, statsB}, nil).Once()
+	repo.On("SetReportGroupPriority", ctx, statsA, reportPriorityScore(statsA)).Return(nil).Twice()
+	repo.On("SetReportGroupPriority", ctx, statsB, reportPriorityScore(statsB)).Return(nil).Once()
+
+	require.NoError(t, svc.ScoreGroup(ctx, a))
+	repo.AssertNumberOfCalls(t, "SetReportGroupPriority", 1)
+
+	scored, err := svc.RefreshPriorities(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, scored)
+	assert.Greater(t, reportPriorityScore(statsA), reportPriorityScore(statsB))
+
+	repo.AssertExpectations(t)
+}
+
+func TestReportQueueService_ListQueueClampsPaging(t *testing.T) {
+	repo := new(MockReportQueueRepository)
+	repo.On("ListReportQueue", mock.Anything, models.ReportQueueFilters{
+		Status: models.ReportGroupOpen,
+		Limit:  reportQueueListLimit,
+		Offset: 0,
+	}).Return([]models.ReportGroup{}, 0, nil).Once()
+	svc := NewReportQueueService(repo)
+
+	_, _, err := svc.ListQueue(context.Background(), models.ReportQueueFilters{Status: models.ReportGroupOpen, Limit: 1000, Offset: -5})
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestReportQueueService_ResolveGroup(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockReportQueueRepository)
+	svc := NewReportQueueService(repo)
+	moderatorID := uuid.New()
+	id := uuid.New()
+
+	_, _, err := svc.ResolveGroup(ctx, id, "pending", moderatorID)
+	assert.Error(t, err)
+	repo.AssertNotCalled(t, "ResolveReportGroup", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	actioned := "actioned"
+	repo.On("ResolveReportGroup", ctx, id, "actioned", moderatorID).Return([]models.Report{
+		{ID: uuid.New(), GroupID: &id, Status: "actioned"},
+		{ID: uuid.New(), GroupID: &id, Status: "actioned"},
+		{ID: uuid.New(), GroupID: &id, Status: "actioned"},
+	}, nil).Once()
+	repo.On("GetReportGroup", ctx, id).Return(&models.ReportGroup{
+		ID:          id,
+		Status:      models.ReportGroupResolved,
+		Resolution:  &actioned,
+		ResolvedBy:  &moderatorID,
+		ReportCount: 3,
+	}, nil)
+	group, reports, err := svc.ResolveGroup(ctx, id, "actioned", moderatorID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ReportGroupResolved, group.Status)
+	assert.Len(t, reports, 3)
+
+	// A resolved group can't be resolved again
+	repo.On("ResolveReportGroup", ctx, id, "dismissed", moderatorID).Return(nil, repository.ErrReportGroupNotFound).Once()
+	_, _, err = svc.ResolveGroup(ctx, id, "dismissed", moderatorID)
+	assert.ErrorIs(t, err, repository.ErrReportGroupNotFound)
+
+	missing := uuid.New()
+	repo.On("ResolveReportGroup", ctx, missing, "dismissed", moderatorID).Return(nil, repository.ErrReportGroupNotFound).Once()
+	_, _, err = svc.ResolveGroup(ctx, missing, "dismissed", moderatorID)
+	assert.ErrorIs(t, err, repository.ErrReportGroupNotFound)
+
+	repo.On("ListReportsByGroup", ctx, id).Return([]models.Report{{ID: uuid.New(), GroupID: &id, Reason: "spam"}}, nil).Once()
+	detail, err := svc.GetGroup(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, id, detail.ID)
+	require.Len(t, detail.Reports, 1)
+	assert.Equal(t, id, *detail.Reports[0].GroupID)
+
+	repo.AssertExpectations(t)
+}
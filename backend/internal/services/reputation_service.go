@@ -2,17 +2,70 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/subculture-collective/clipper/internal/models"
 	"github.com/subculture-collective/clipper/internal/repository"
 )
 
+// karmaRuleCacheTTL bounds how long karma rule changes take to reach other
+// API instances
+const karmaRuleCacheTTL = time.Minute
+
+// Karma rule errors
+var (
+	// ErrKarmaRulesUnavailable is returned when no karma rule repository is set
+	ErrKarmaRulesUnavailable = errors.New("karma rules are not available")
+	// ErrKarmaRuleInvalidEventType is returned for event types that aren't lowercase snake_case
+	ErrKarmaRuleInvalidEventType = errors.New("event_type must be lowercase letters, digits and underscores, starting with a letter")
+)
+
+var karmaEventTypePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+var karmaEventsTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "karma_events_total",
+		Help: "Total number of karma events handled, by event type and outcome",
+	},
+	[]string{"event_type", "outcome"}, // outcome: "awarded", "limited", "duplicate", "no_rule"
+)
+
+// KarmaRuleRepositoryInterface defines the repository methods used for karma rules
+type KarmaRuleRepositoryInterface interface {
+	ListRules(ctx context.Context) ([]models.KarmaRule, error)
+	GetRule(ctx context.Context, eventType string) (*models.KarmaRule, error)
+	CreateRule(ctx context.Context, rule *models.KarmaRule) error
+	UpdateRule(ctx context.Context, rule *models.KarmaRule) error
+	DeleteRule(ctx context.Context, eventType string) error
+	ApplyEvent(ctx context.Context, userID, sourceID uuid.UUID, rule *models.KarmaRule, now time.Time) (int, bool, error)
+	RevokeEvent(ctx context.Context, userID uuid.UUID, eventType string, sourceID uuid.UUID) (int, error)
+}
+
+// KarmaAwarder awards karma for events under the karma rules. An event is
+// identified by its type, user and source, so replaying it awards nothing.
+type KarmaAwarder interface {
+	AwardKarma(ctx context.Context, userID uuid.UUID, eventType string, sourceID uuid.UUID) (int, error)
+	RevokeKarma(ctx context.Context, userID uuid.UUID, eventType string, sourceID uuid.UUID) (int, error)
+}
+
 // ReputationService handles reputation-related business logic
 type ReputationService struct {
 	reputationRepo *repository.ReputationRepository
 	userRepo       *repository.UserRepository
+	karmaRules     KarmaRuleRepositoryInterface
+	now            func() time.Time
+
+	mu            sync.Mutex
+	rules         map[string]models.KarmaRule
+	rulesLoadedAt time.Time
 }
 
 // NewReputationService creates a new reputation service
@@ -20,7 +73,166 @@ func NewReputationService(reputationRepo *repository.ReputationRepository, userR
 	return &ReputationService{
 		reputationRepo: reputationRepo,
 		userRepo:       userRepo,
+		now:            time.Now,
+	}
+}
+
+// SetKarmaRuleRepository enables karma rules, which set the karma events award
+func (s *ReputationService) SetKarmaRuleRepository(karmaRules KarmaRuleRepositoryInterface) {
+	s.karmaRules = karmaRules
+}
+
+// ListKarmaRules returns all karma rules
+func (s *ReputationService) ListKarmaRules(ctx context.Context) ([]models.KarmaRule, error) {
+	if s.karmaRules == nil {
+		return nil, ErrKarmaRulesUnavailable
+	}
+	return s.karmaRules.ListRules(ctx)
+}
+
+// GetKarmaRule returns the karma rule for an event type
+func (s *ReputationService) GetKarmaRule(ctx context.Context, eventType string) (*models.KarmaRule, error) {
+	if s.karmaRules == nil {
+		return nil, ErrKarmaRulesUnavailable
+	}
+	return s.karmaRules.GetRule(ctx, eventType)
+}
+
+// CreateKarmaRule creates the karma rule for an event type
+func (s *ReputationService) CreateKarmaRule(ctx context.Context, adminID uuid.UUID, req *models.KarmaRuleRequest) (*models.KarmaRule, error) {
+	if s.karmaRules == nil {
+		return nil, ErrKarmaRulesUnavailable
+	}
+	eventType := strings.TrimSpace(req.EventType)
+	if !karmaEventTypePattern.MatchString(eventType) {
+		return nil, ErrKarmaRuleInvalidEventType
+	}
+
+	rule := &models.KarmaRule{EventType: eventType, Enabled: true}
+	applyKarmaRuleRequest(rule, adminID, req)
+	if err := s.karmaRules.CreateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	s.invalidateKarmaRules()
+	return rule, nil
+}
+
+// UpdateKarmaRule replaces a karma rule's settings
+func (s *ReputationService) UpdateKarmaRule(ctx context.Context, adminID uuid.UUID, eventType string, req *models.KarmaRuleRequest) (*models.KarmaRule, error) {
+	if s.karmaRules == nil {
+		return nil, ErrKarmaRulesUnavailable
+	}
+	rule, err := s.karmaRules.GetRule(ctx, eventType)
+	if err != nil {
+		return nil, err
+	}
+
+	applyKarmaRuleRequest(rule, adminID, req)
+	if err := s.karmaRules.UpdateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	s.invalidateKarmaRules()
+	return rule, nil
+}
+
+// DeleteKarmaRule deletes a karma rule. Its event type awards no karma until
+// a rule is created for it again.
+func (s *ReputationService) DeleteKarmaRule(ctx context.Context, eventType string) error {
+	if s.karmaRules == nil {
+		return ErrKarmaRulesUnavailable
+	}
+	if err := s.karmaRules.DeleteRule(ctx, eventType); err != nil {
+		return err
 	}
+	s.invalidateKarmaRules()
+	return nil
+}
+
+func applyKarmaRuleRequest(rule *models.KarmaRule, adminID uuid.UUID, req *models.KarmaRuleRequest) {
+	rule.Points = req.Points
+	rule.DailyCap = req.DailyCap
+	rule.CooldownSeconds = req.CooldownSeconds
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+	rule.Description = nil
+	if req.Description != nil {
+		if description := strings.TrimSpace(*req.Description); description != "" {
+			rule.Description = &description
+		}
+	}
+	rule.UpdatedBy = &adminID
+}
+
+// AwardKarma awards a user the karma an event earns under its karma rule,
+// after the rule's daily cap and cooldown. Events without an enabled rule
+// award nothing, and so does an event already handled for the same source.
+// It returns the karma awarded.
+func (s *ReputationService) AwardKarma(ctx context.Context, userID uuid.UUID, eventType string, sourceID uuid.UUID) (int, error) {
+	if s.karmaRules == nil {
+		return 0, ErrKarmaRulesUnavailable
+	}
+	rules, err := s.cachedKarmaRules(ctx)
+	if err != nil {
+		return 0, err
+	}
+	rule, ok := rules[eventType]
+	if !ok || !rule.Enabled {
+		karmaEventsTotal.WithLabelValues(eventType, "no_rule").Inc()
+		return 0, nil
+	}
+
+	points, recorded, err := s.karmaRules.ApplyEvent(ctx, userID, sourceID, &rule, s.now())
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case !recorded:
+		karmaEventsTotal.WithLabelValues(eventType, "duplicate").Inc()
+	case points != rule.Points:
+		karmaEventsTotal.WithLabelValues(eventType, "limited").Inc()
+	default:
+		karmaEventsTotal.WithLabelValues(eventType, "awarded").Inc()
+	}
+	return points, nil
+}
+
+// RevokeKarma takes back the karma an event awarded, e.g. when the comment
+// it was for is deleted. It returns the karma taken back.
+func (s *ReputationService) RevokeKarma(ctx context.Context, userID uuid.UUID, eventType string, sourceID uuid.UUID) (int, error) {
+	if s.karmaRules == nil {
+		return 0, ErrKarmaRulesUnavailable
+	}
+	return s.karmaRules.RevokeEvent(ctx, userID, eventType, sourceID)
+}
+
+// cachedKarmaRules returns the karma rules by event type, reloading them once
+// the cached copy is older than karmaRuleCacheTTL
+func (s *ReputationService) cachedKarmaRules(ctx context.Context) (map[string]models.KarmaRule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.rules != nil && s.now().Sub(s.rulesLoadedAt) < karmaRuleCacheTTL {
+		return s.rules, nil
+	}
+
+	list, err := s.karmaRules.ListRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rules := make(map[string]models.KarmaRule, len(list))
+	for _, rule := range list {
+		rules[rule.EventType] = rule
+	}
+	s.rules = rules
+	s.rulesLoadedAt = s.now()
+	return rules, nil
+}
+
+func (s *ReputationService) invalidateKarmaRules() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rules = nil
 }
 
 // GetUserReputation retrieves complete reputation info for a user
@@ -248,6 +460,30 @@ var badgeDefinitions = map[string]models.Badge{
 		Category:    "achievement",
 		Requirement: "100 comments",
 	},
+	"well_received": {
+		ID:          "well_received",
+		Name:        "Well Received",
+		Description: "Had 100+ comments upvoted",
+		Icon:        "👏",
+		Category:    "achievement",
+		Requirement: "100 upvoted comments",
+	},
+	"season_champion": {
+		ID:          "season_champion",
+		Name:        "Season Champion",
+		Description: "Topped a season's karma leaderboard",
+		Icon:        "🥇",
+		Category:    "achievement",
+		Requirement: "Rank 1 on a season karma leaderboard",
+	},
+	"season_top_ten": {
+		ID:          "season_top_ten",
+		Name:        "Season Top 10",
+		Description: "Finished a season in the karma leaderboard's top 10",
+		Icon:        "🏅",
+		Category:    "achievement",
+		Requirement: "Top 10 on a season karma leaderboard",
+	},
 	"curator": {
 		ID:          "curator",
 		Name:        "Curator",
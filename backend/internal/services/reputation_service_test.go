@@ -1,7 +1,15 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
 )
 
 func TestGetUserRank(t *testing.T) {
@@ -131,3 +139,228 @@ func TestCanUserPerformAction(t *testing.T) {
 		})
 	}
 }
+
+// MockKarmaRuleRepository is a mock implementation of KarmaRuleRepositoryInterface
+type MockKarmaRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockKarmaRuleRepository) ListRules(ctx context.Context) ([]models.KarmaRule, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.KarmaRule), args.Error(1)
+}
+
+func (m *MockKarmaRuleRepository) GetRule(ctx context.Context, eventType string) (*models.KarmaRule, error) {
+	args := m.Called(ctx, eventType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.KarmaRule), args.Error(1)
+}
+
+func (m *MockKarmaRuleRepository) CreateRule(ctx context.Context, rule *models.KarmaRule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockKarmaRuleRepository) UpdateRule(ctx context.Context, rule *models.KarmaRule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockKarmaRuleRepository) DeleteRule(ctx context.Context, eventType string) error {
+	args := m.Called(ctx, eventType)
+	return args.Error(0)
+}
+
+func (m *MockKarmaRuleRepository) ApplyEvent(ctx context.Context, userID, sourceID uuid.UUID, rule *models.KarmaRule, now time.Time) (int, bool, error) {
+	args := m.Called(ctx, userID, sourceID, rule, now)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+
+func (m *MockKarmaRuleRepository) RevokeEvent(ctx context.Context, userID uuid.UUID, eventType string, sourceID uuid.UUID) (int, error) {
+	args := m.Called(ctx, userID, eventType, sourceID)
+	return args.Int(0), args.Error(1)
+}
+
+func setupKarmaRuleServiceTest() (*ReputationService, *MockKarmaRuleRepository) {
+	repo := new(MockKarmaRuleRepository)
+	s := NewReputationService(nil, nil)
+	s.SetKarmaRuleRepository(repo)
+	return s, repo
+}
+
+// karmaRuleWithPoints matches the karma rule an event is applied under by
+// its points
+func karmaRuleWithPoints(points int) interface{} {
+	return mock.MatchedBy(func(rule *models.KarmaRule) bool { return rule.Points == points })
+}
+
+func TestAwardKarmaIsIdempotent(t *testing.T) {
+	s, repo := setupKarmaRuleServiceTest()
+	ctx := context.Background()
+	userID, submissionID := uuid.New(), uuid.New()
+	repo.On("ListRules", ctx).Return([]models.KarmaRule{{EventType: models.KarmaEventSubmissionApproved, Points: 10, Enabled: true}}, nil).Once()
+	repo.On("ApplyEvent", ctx, userID, submissionID, karmaRuleWithPoints(10), mock.Anything).Return(10, true, nil).Once()
+	repo.On("ApplyEvent", ctx, userID, submissionID, karmaRuleWithPoints(10), mock.Anything).Return(0, false, nil).Twice()
+
+	total := 0
+	for i := 0; i < 3; i++ {
+		points, err := s.AwardKarma(ctx, userID, models.KarmaEventSubmissionApproved, submissionID)
+		if err != nil {
+			t.Fatalf("AwardKarma() error = %v", err)
+		}
+		total += points
+	}
+	if total != 10 {
+		t.Errorf("karma after replays = %d, want 10", total)
+	}
+	repo.AssertExpectations(t)
+}
+
+func TestAwardKarmaAppliesDailyCap(t *testing.T) {
+	cap3 := 3
+	s, repo := setupKarmaRuleServiceTest()
+	ctx := context.Background()
+	userID := uuid.New()
+	repo.On("ListRules", ctx).Return([]models.KarmaRule{{EventType: models.KarmaEventCommentCreated, Points: 2, DailyCap: &cap3, Enabled: true}}, nil).Once()
+	capped := mock.MatchedBy(func(rule *models.KarmaRule) bool { return rule.DailyCap != nil && *rule.DailyCap == 3 })
+	for _, points := range []int{2, 1, 0} {
+		repo.On("ApplyEvent", ctx, userID, mock.Anything, capped, mock.Anything).Return(points, true, nil).Once()
+	}
+
+	var got []int
+	for i := 0; i < 3; i++ {
+		points, err := s.AwardKarma(ctx, userID, models.KarmaEventCommentCreated, uuid.New())
+		if err != nil {
+			t.Fatalf("AwardKarma() error = %v", err)
+		}
+		got = append(got, points)
+	}
+	if got[0] != 2 || got[1] != 1 || got[2] != 0 {
+		t.Errorf("awards = %v, want [2 1 0]", got)
+	}
+	repo.AssertExpectations(t)
+}
+
+func TestAwardKarmaWithoutEnabledRule(t *testing.T) {
+	s, repo := setupKarmaRuleServiceTest()
+	ctx := context.Background()
+	userID := uuid.New()
+	repo.On("ListRules", ctx).Return([]models.KarmaRule{{EventType: models.KarmaEventClipClaimed, Points: 10}}, nil).Once()
+
+	for _, eventType := range []string{models.KarmaEventClipClaimed, "unknown_event"} {
+		points, err := s.AwardKarma(ctx, userID, eventType, uuid.New())
+		if err != nil || points != 0 {
+			t.Errorf("AwardKarma(%q) = %d, %v, want 0, nil", eventType, points, err)
+		}
+	}
+	repo.AssertNotCalled(t, "ApplyEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestKarmaRuleChangesReloadRules(t *testing.T) {
+	s, repo := setupKarmaRuleServiceTest()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	ctx := context.Background()
+	adminID, userID := uuid.New(), uuid.New()
+	withPoints := func(points int) []models.KarmaRule {
+		return []models.KarmaRule{{EventType: models.KarmaEventCommentCreated, Points: points, Enabled: true}}
+	}
+
+	repo.On("ListRules", ctx).Return(withPoints(1), nil).Once()
+	repo.On("ApplyEvent", ctx, userID, mock.Anything, karmaRuleWithPoints(1), now).Return(1, true, nil).Twice()
+	if _, err := s.AwardKarma(ctx, userID, models.KarmaEventCommentCreated, uuid.New()); err != nil {
+		t.Fatalf("AwardKarma() error = %v", err)
+	}
+	if _, err := s.AwardKarma(ctx, userID, models.KarmaEventCommentCreated, uuid.New()); err != nil {
+		t.Fatalf("AwardKarma() error = %v", err)
+	}
+	repo.AssertNumberOfCalls(t, "ListRules", 1)
+
+	// Changes made here take effect right away
+	repo.On("GetRule", ctx, models.KarmaEventCommentCreated).Return(&withPoints(1)[0], nil).Once()
+	repo.On("UpdateRule", ctx, karmaRuleWithPoints(3)).Return(nil).Once()
+	if _, err := s.UpdateKarmaRule(ctx, adminID, models.KarmaEventCommentCreated, &models.KarmaRuleRequest{Points: 3}); err != nil {
+		t.Fatalf("UpdateKarmaRule() error = %v", err)
+	}
+	repo.On("ListRules", ctx).Return(withPoints(3), nil).Once()
+	repo.On("ApplyEvent", ctx, userID, mock.Anything, karmaRuleWithPoints(3), now).Return(3, true, nil).Once()
+	points, err := s.AwardKarma(ctx, userID, models.KarmaEventCommentCreated, uuid.New())
+	if err != nil || points != 3 {
+		t.Errorf("AwardKarma() after update = %d, %v, want 3, nil", points, err)
+	}
+
+	// Changes made on other instances take effect once the cache expires
+	now = now.Add(karmaRuleCacheTTL)
+	repo.On("ListRules", ctx).Return(withPoints(5), nil).Once()
+	repo.On("ApplyEvent", ctx, userID, mock.Anything, karmaRuleWithPoints(5), now).Return(5, true, nil).Once()
+	points, err = s.AwardKarma(ctx, userID, models.KarmaEventCommentCreated, uuid.New())
+	if err != nil || points != 5 {
+		t.Errorf("AwardKarma() after cache expiry = %d, %v, want 5, nil", points, err)
+	}
+	repo.AssertExpectations(t)
+}
+
+func TestRevokeKarmaTakesBackAward(t *testing.T) {
+	s, repo := setupKarmaRuleServiceTest()
+	ctx := context.Background()
+	userID, commentID := uuid.New(), uuid.New()
+	repo.On("ListRules", ctx).Return([]models.KarmaRule{{EventType: models.KarmaEventCommentCreated, Points: 1, Enabled: true}}, nil).Once()
+	repo.On("ApplyEvent", ctx, userID, commentID, karmaRuleWithPoints(1), mock.Anything).Return(1, true, nil).Once()
+	repo.On("RevokeEvent", ctx, userID, models.KarmaEventCommentCreated, commentID).Return(1, nil).Once()
+	repo.On("RevokeEvent", ctx, userID, models.KarmaEventCommentCreated, commentID).Return(0, nil).Once()
+
+	karma, err := s.AwardKarma(ctx, userID, models.KarmaEventCommentCreated, commentID)
+	if err != nil {
+		t.Fatalf("AwardKarma() error = %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		revoked, err := s.RevokeKarma(ctx, userID, models.KarmaEventCommentCreated, commentID)
+		if err != nil {
+			t.Fatalf("RevokeKarma() error = %v", err)
+		}
+		karma -= revoked
+	}
+	if karma != 0 {
+		t.Errorf("karma after revoking = %d, want 0", karma)
+	}
+	repo.AssertExpectations(t)
+}
+
+func TestCreateKarmaRuleValidation(t *testing.T) {
+	s, repo := setupKarmaRuleServiceTest()
+	ctx := context.Background()
+	adminID := uuid.New()
+	eventType := func(eventType string) interface{} {
+		return mock.MatchedBy(func(rule *models.KarmaRule) bool { return rule.EventType == eventType })
+	}
+	repo.On("CreateRule", ctx, eventType(models.KarmaEventCommentCreated)).Return(repository.ErrKarmaRuleExists).Once()
+	repo.On("CreateRule", ctx, eventType("playlist_created")).Return(nil).Once()
+
+	if _, err := s.CreateKarmaRule(ctx, adminID, &models.KarmaRuleRequest{EventType: "Bad Event", Points: 1}); !errors.Is(err, ErrKarmaRuleInvalidEventType) {
+		t.Errorf("CreateKarmaRule() invalid event type error = %v, want ErrKarmaRuleInvalidEventType", err)
+	}
+	if _, err := s.CreateKarmaRule(ctx, adminID, &models.KarmaRuleRequest{EventType: models.KarmaEventCommentCreated, Points: 1}); !errors.Is(err, repository.ErrKarmaRuleExists) {
+		t.Errorf("CreateKarmaRule() duplicate error = %v, want ErrKarmaRuleExists", err)
+	}
+
+	rule, err := s.CreateKarmaRule(ctx, adminID, &models.KarmaRuleRequest{EventType: "playlist_created", Points: 2})
+	if err != nil {
+		t.Fatalf("CreateKarmaRule() error = %v", err)
+	}
+	if !rule.Enabled || rule.UpdatedBy == nil || *rule.UpdatedBy != adminID {
+		t.Errorf("CreateKarmaRule() = %+v, want enabled and updated by admin", rule)
+	}
+	repo.AssertExpectations(t)
+}
+
+func TestKarmaRulesUnavailable(t *testing.T) {
+	s := NewReputationService(nil, nil)
+	if _, err := s.AwardKarma(context.Background(), uuid.New(), models.KarmaEventCommentCreated, uuid.New()); !errors.Is(err, ErrKarmaRulesUnavailable) {
+		t.Errorf("AwardKarma() error = %v, want ErrKarmaRulesUnavailable", err)
+	}
+}
@@ -0,0 +1,504 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const (
+	// requestCaptureSessionsKey holds every session, so one read serves any request
+	requestCaptureSessionsKey = "request_capture:sessions"
+	// requestCaptureLocalTTL bounds how long an instance samples from its
+	// in-memory sessions, so a new or stopped session applies everywhere
+	// within this window
+	requestCaptureLocalTTL = 5 * time.Second
+
+	// RequestCaptureMaxDuration is the longest a session samples requests
+	RequestCaptureMaxDuration = time.Hour
+	// RequestCaptureRetention is how long captures stay readable after their session ends
+	RequestCaptureRetention = 24 * time.Hour
+	// RequestCaptureMaxBodyBytes is how much of each request and response body is kept
+	RequestCaptureMaxBodyBytes = 16 * 1024
+
+	requestCaptureDefaultMax = 100
+	requestCaptureMaxMax     = 500
+	requestCaptureMaxActive  = 10
+)
+
+var (
+	// ErrRequestCaptureNotFound is returned for an unknown or expired session
+	ErrRequestCaptureNotFound = errors.New("request capture session not found")
+	// ErrRequestCaptureInvalid is returned for a session outside the capture limits
+	ErrRequestCaptureInvalid = errors.New("invalid request capture session")
+)
+
+// requestCaptureExcludedRoutes are never captured: the capture API itself,
+// which would capture captures, and routes whose bodies are credentials
+var requestCaptureExcludedRoutes = []string{
+	"/api/v1/admin/diagnostics/",
+	"/api/v1/auth/",
+}
+
+// requestCaptureSensitiveHeaders are dropped from captured headers
+var requestCaptureSensitiveHeaders = map[string]bool{
+	"authorization":       true,
+	"proxy-authorization": true,
+	"cookie":              true,
+	"set-cookie":          true,
+	"x-api-key":           true,
+	"x-csrf-token":        true,
+	"stripe-signature":    true,
+	"x-hub-signature-256": true,
+}
+
+// RequestCaptureStore is the Redis storage of sessions and their ring buffers
+type RequestCaptureStore interface {
+	GetJSON(ctx context.Context, key string, dest interface{}) error
+	SetJSON(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	ListPush(ctx context.Context, key string, value interface{}) error
+	ListTrim(ctx context.Context, key string, start, stop int64) error
+	ListRange(ctx context.Context, key string, start, stop int64) ([]string, error)
+	Expire(ctx context.Context, key string, expiration time.Duration) error
+}
+
+// RawRequestCapture is a sampled exchange as the middleware saw it, before redaction
+type RawRequestCapture struct {
+	RequestID             string
+	TraceID               string
+	Method                string
+	Route                 string
+	Path                  string
+	Query                 string
+	Status                int
+	Duration              time.Duration
+	UserHash              string
+	RequestHeaders        map[string][]string
+	RequestBody           []byte
+	RequestBodyTruncated  bool
+	ResponseHeaders       map[string][]string
+	ResponseBody          []byte
+	ResponseBodyTruncated bool
+	Errors                []string
+}
+
+// RequestCaptureService samples requests to routes admins are debugging into
+// per-session ring buffers in Redis, shared by every API instance. Captures
+// are redacted before they are stored.
+type RequestCaptureService struct {
+	store RequestCaptureStore
+	now   func() time.Time
+	rand  func() float64
+
+	mu       sync.RWMutex
+	sessions []models.RequestCaptureSession
+	loadedAt time.Time
+}
+
+// NewRequestCaptureService creates a new RequestCaptureService
+func NewRequestCaptureService(store RequestCaptureStore) *RequestCaptureService {
+	return &RequestCaptureService{
+		store: store,
+		now:   time.Now,
+		rand:  rand.Float64,
+	}
+}
+
+// StartSession starts sampling a route. The route is the pattern it was
+// registered with, such as /api/v1/clips/:id.
+func (s *RequestCaptureService) StartSession(ctx context.Context, adminID uuid.UUID, req *models.StartRequestCaptureRequest) (*models.RequestCaptureSession, error) {
+	duration := time.Duration(req.DurationMinutes) * time.Minute
+	if duration > RequestCaptureMaxDuration {
+		return nil, fmt.Errorf("%w: sessions last at most %v", ErrRequestCaptureInvalid, RequestCaptureMaxDuration)
+	}
+	maxCaptures := req.MaxCaptures
+	if maxCaptures == 0 {
+		maxCaptures = requestCaptureDefaultMax
+	}
+	if maxCaptures > requestCaptureMaxMax {
+		return nil, fmt.Errorf("%w: sessions keep at most %d captures", ErrRequestCaptureInvalid, requestCaptureMaxMax)
+	}
+	if requestCaptureExcluded(req.Route) {
+		return nil, fmt.Errorf("%w: %s can't be captured", ErrRequestCaptureInvalid, req.Route)
+	}
+
+	sessions, err := s.readSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	active := 0
+	for i := range sessions {
+		if sessions[i].Active(now) {
+			active++
+		}
+	}
+	if active >= requestCaptureMaxActive {
+		return nil, fmt.Errorf("%w: at most %d sessions can run at once", ErrRequestCaptureInvalid, requestCaptureMaxActive)
+	}
+
+	session := models.RequestCaptureSession{
+		ID:            uuid.New(),
+		Method:        strings.ToUpper(req.Method),
+		Route:         req.Route,
+		SamplePercent: req.SamplePercent,
+		MaxCaptures:   maxCaptures,
+		Reason:        req.Reason,
+		CreatedBy:     adminID,
+		CreatedAt:     now,
+		ExpiresAt:     now.Add(duration),
+	}
+	if err := s.writeSessions(ctx, append(sessions, session)); err != nil {
+		return nil, err
+	}
+
+	utils.Info("Request capture started", map[string]interface{}{
+		"session_id":     session.ID.String(),
+		"method":         session.Method,
+		"route":          session.Route,
+		"sample_percent": session.SamplePercent,
+		"expires_at":     session.ExpiresAt,
+		"admin_id":       adminID.String(),
+	})
+	return &session, nil
+}
+
+// StopSession stops sampling ahead of the session's expiry. Its captures stay
+// readable for RequestCaptureRetention.
+func (s *RequestCaptureService) StopSession(ctx context.Context, id uuid.UUID) (*models.RequestCaptureSession, error) {
+	sessions, err := s.readSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range sessions {
+		if sessions[i].ID != id {
+			continue
+		}
+		if sessions[i].Active(s.now()) {
+			now := s.now()
+			sessions[i].StoppedAt = &now
+			if err := s.writeSessions(ctx, sessions); err != nil {
+				return nil, err
+			}
+		}
+		return &sessions[i], nil
+	}
+	return nil, ErrRequestCaptureNotFound
+}
+
+// ListSessions returns the sessions whose captures are still readable, newest first
+func (s *RequestCaptureService) ListSessions(ctx context.Context) ([]models.RequestCaptureSession, error) {
+	sessions, err := s.readSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.After(sessions[j].CreatedAt)
+	})
+	return sessions, nil
+}
+
+// ListCaptures returns a session's captures, newest first
+func (s *RequestCaptureService) ListCaptures(ctx context.Context, id uuid.UUID) ([]models.RequestCapture, error) {
+	sessions, err := s.readSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	for i := range sessions {
+		if sessions[i].ID == id {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, ErrRequestCaptureNotFound
+	}
+
+	values, err := s.store.ListRange(ctx, requestCaptureEntriesKey(id), 0, -1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request captures: %w", err)
+	}
+	captures := make([]models.RequestCapture, 0, len(values))
+	for i := len(values) - 1; i >= 0; i-- {
+		var capture models.RequestCapture
+		if err := json.Unmarshal([]byte(values[i]), &capture); err != nil {
+			continue
+		}
+		captures = append(captures, capture)
+	}
+	return captures, nil
+}
+
+// Sample returns the active session covering a request to the route, if the
+// request falls in its sample. It reads sessions from memory, refreshing
+// them from Redis every few seconds, and samples nothing when Redis is down.
+func (s *RequestCaptureService) Sample(ctx context.Context, method, route string) *models.RequestCaptureSession {
+	sessions := s.activeSessions(ctx)
+	for i := range sessions {
+		session := &sessions[i]
+		if session.Method == method && session.Route == route && s.rand()*100 < session.SamplePercent {
+			return session
+		}
+	}
+	return nil
+}
+
+// Record redacts a sampled exchange and adds it to the session's ring buffer.
+// Failures are logged, since a capture must never fail the request.
+func (s *RequestCaptureService) Record(ctx context.Context, session *models.RequestCaptureSession, raw *RawRequestCapture) {
+	capture := redactRequestCapture(session.ID, raw)
+	capture.CapturedAt = s.now()
+
+	data, err := json.Marshal(capture)
+	if err != nil {
+		return
+	}
+	key := requestCaptureEntriesKey(session.ID)
+	err = s.store.ListPush(ctx, key, string(data))
+	if err == nil {
+		err = s.store.ListTrim(ctx, key, -int64(session.MaxCaptures), -1)
+	}
+	if err == nil {
+		err = s.store.Expire(ctx, key, session.ExpiresAt.Add(RequestCaptureRetention).Sub(s.now()))
+	}
+	if err != nil {
+		utils.Warn("Failed to save request capture", map[string]interface{}{
+			"session_id": session.ID.String(),
+			"error":      err.Error(),
+		})
+	}
+}
+
+// activeSessions returns the sessions sampling requests now
+func (s *RequestCaptureService) activeSessions(ctx context.Context) []models.RequestCaptureSession {
+	s.mu.RLock()
+	if s.sessions != nil && s.now().Sub(s.loadedAt) < requestCaptureLocalTTL {
+		sessions := s.sessions
+		s.mu.RUnlock()
+		return sessions
+	}
+	s.mu.RUnlock()
+
+	sessions, err := s.readSessions(ctx)
+	if err != nil {
+		utils.Warn("Failed to load request capture sessions", map[string]interface{}{
+			"error": err.Error(),
+		})
+		sessions = nil
+	}
+	now := s.now()
+	active := []models.RequestCaptureSession{}
+	for i := range sessions {
+		if sessions[i].Active(now) {
+			active = append(active, sessions[i])
+		}
+	}
+
+	s.mu.Lock()
+	s.sessions = active
+	s.loadedAt = now
+	s.mu.Unlock()
+	return active
+}
+
+// readSessions returns the sessions whose captures haven't expired
+func (s *RequestCaptureService) readSessions(ctx context.Context) ([]models.RequestCaptureSession, error) {
+	var sessions []models.RequestCaptureSession
+	if err := s.store.GetJSON(ctx, requestCaptureSessionsKey, &sessions); err != nil {
+		if errors.Is(err, redis.Nil) {
+			return []models.RequestCaptureSession{}, nil
+		}
+		return nil, fmt.Errorf("failed to read request capture sessions: %w", err)
+	}
+
+	now := s.now()
+	kept := sessions[:0]
+	for _, session := range sessions {
+		if now.Before(requestCaptureSessionEnd(&session).Add(RequestCaptureRetention)) {
+			kept = append(kept, session)
+		}
+	}
+	return kept, nil
+}
+
+// writeSessions saves the sessions, keeping the key until the last of their
+// captures expires, and applies them to this instance at once
+func (s *RequestCaptureService) writeSessions(ctx context.Context, sessions []models.RequestCaptureSession) error {
+	var last time.Time
+	for i := range sessions {
+		if end := requestCaptureSessionEnd(&sessions[i]); end.After(last) {
+			last = end
+		}
+	}
+	if err := s.store.SetJSON(ctx, requestCaptureSessionsKey, sessions, last.Add(RequestCaptureRetention).Sub(s.now())); err != nil {
+		return fmt.Errorf("failed to save request capture sessions: %w", err)
+	}
+
+	s.mu.Lock()
+	s.sessions = nil
+	s.mu.Unlock()
+	return nil
+}
+
+// requestCaptureSessionEnd returns when a session stopped or will expire
+func requestCaptureSessionEnd(session *models.RequestCaptureSession) time.Time {
+	if session.StoppedAt != nil && session.StoppedAt.Before(session.ExpiresAt) {
+		return *session.StoppedAt
+	}
+	return session.ExpiresAt
+}
+
+func requestCaptureEntriesKey(id uuid.UUID) string {
+	return "request_capture:entries:" + id.String()
+}
+
+func requestCaptureExcluded(route string) bool {
+	for _, prefix := range requestCaptureExcludedRoutes {
+		if strings.HasPrefix(route, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactRequestCapture builds the stored capture of a raw exchange. Secret
+// headers are dropped, and sensitive JSON fields, query parameters and PII in
+// text are redacted as in logs.
+func redactRequestCapture(sessionID uuid.UUID, raw *RawRequestCapture) models.RequestCapture {
+	capture := models.RequestCapture{
+		ID:                    uuid.New(),
+		SessionID:             sessionID,
+		RequestID:             raw.RequestID,
+		TraceID:               raw.TraceID,
+		Method:                raw.Method,
+		Route:                 raw.Route,
+		Path:                  raw.Path,
+		Query:                 redactCapturedQuery(raw.Query),
+		Status:                raw.Status,
+		DurationMs:            float64(raw.Duration.Microseconds()) / 1000,
+		UserHash:              raw.UserHash,
+		RequestHeaders:        redactCapturedHeaders(raw.RequestHeaders),
+		RequestBody:           redactCapturedBody(headerValue(raw.RequestHeaders, "Content-Type"), raw.RequestBody, raw.RequestBodyTruncated),
+		RequestBodyTruncated:  raw.RequestBodyTruncated,
+		ResponseHeaders:       redactCapturedHeaders(raw.ResponseHeaders),
+		ResponseBody:          redactCapturedBody(headerValue(raw.ResponseHeaders, "Content-Type"), raw.ResponseBody, raw.ResponseBodyTruncated),
+		ResponseBodyTruncated: raw.ResponseBodyTruncated,
+	}
+	for _, e := range raw.Errors {
+		capture.Errors = append(capture.Errors, utils.RedactPII(e))
+	}
+	return capture
+}
+
+func headerValue(headers map[string][]string, name string) string {
+	for key, values := range headers {
+		if strings.EqualFold(key, name) && len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+func redactCapturedHeaders(headers map[string][]string) map[string]string {
+	redacted := make(map[string]string, len(headers))
+	for key, values := range headers {
+		lower := strings.ToLower(key)
+		if requestCaptureSensitiveHeaders[lower] || isSensitiveCaptureKey(lower) {
+			redacted[key] = "[REDACTED]"
+			continue
+		}
+		redacted[key] = utils.RedactPII(strings.Join(values, ", "))
+	}
+	return redacted
+}
+
+func redactCapturedQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return utils.RedactPII(rawQuery)
+	}
+	for key, vals := range values {
+		for i := range vals {
+			if isSensitiveCaptureKey(strings.ToLower(key)) {
+				vals[i] = "[REDACTED]"
+			} else {
+				vals[i] = utils.RedactPII(vals[i])
+			}
+		}
+	}
+	return values.Encode()
+}
+
+// redactCapturedBody redacts a JSON body field by field, other text as a
+// whole, and replaces binary bodies with their size. A truncated JSON body
+// can't be parsed, so it is redacted as text.
+func redactCapturedBody(contentType string, body []byte, truncated bool) string {
+	if len(body) == 0 {
+		return ""
+	}
+	contentType = strings.ToLower(contentType)
+	if strings.Contains(contentType, "json") && !truncated {
+		var value interface{}
+		if err := json.Unmarshal(body, &value); err == nil {
+			if redacted, err := json.Marshal(redactCapturedJSON(value)); err == nil {
+				return string(redacted)
+			}
+		}
+	}
+	if !utf8.Valid(body) || strings.HasPrefix(contentType, "image/") || strings.HasPrefix(contentType, "video/") ||
+		strings.Contains(contentType, "octet-stream") || strings.Contains(contentType, "multipart/") {
+		return fmt.Sprintf("[%d bytes of %s]", len(body), contentType)
+	}
+	return utils.RedactPII(string(body))
+}
+
+func redactCapturedJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if isSensitiveCaptureKey(strings.ToLower(key)) {
+				v[key] = "[REDACTED]"
+			} else {
+				v[key] = redactCapturedJSON(field)
+			}
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = redactCapturedJSON(v[i])
+		}
+		return v
+	case string:
+		return utils.RedactPII(v)
+	default:
+		return v
+	}
+}
+
+// isSensitiveCaptureKey reports whether a lowercase field, parameter or
+// header name holds a credential
+func isSensitiveCaptureKey(key string) bool {
+	for _, marker := range []string{"password", "secret", "token", "api_key", "apikey", "authorization", "cookie", "signature", "mfa_code", "recovery_code"} {
+		if strings.Contains(key, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+func setupRequestCaptureServiceTest(now time.Time, sample float64) (*RequestCaptureService, *MockRedisClient) {
+	store := new(MockRedisClient)
+	svc := NewRequestCaptureService(store)
+	svc.now = func() time.Time { return now }
+	svc.rand = func() float64 { return sample }
+	return svc, store
+}
+
+// expectRequestCaptureSessions serves the sessions list from the returned
+// slice, which holds what the service last saved
+func expectRequestCaptureSessions(store *MockRedisClient) *[]models.RequestCaptureSession {
+	saved := &[]models.RequestCaptureSession{}
+	store.On("GetJSON", mock.Anything, requestCaptureSessionsKey, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(2).(*[]models.RequestCaptureSession) = append([]models.RequestCaptureSession(nil), *saved...)
+	}).Return(nil)
+	store.On("SetJSON", mock.Anything, requestCaptureSessionsKey, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*saved = append([]models.RequestCaptureSession(nil), args.Get(2).([]models.RequestCaptureSession)...)
+	}).Return(nil)
+	return saved
+}
+
+func TestRequestCaptureService_StartSessionLimits(t *testing.T) {
+	ctx := context.Background()
+	svc, store := setupRequestCaptureServiceTest(time.Now(), 0)
+	expectRequestCaptureSessions(store)
+	adminID := uuid.New()
+
+	_, err := svc.StartSession(ctx, adminID, &models.StartRequestCaptureRequest{Method: "GET", Route: "/api/v1/clips/:id", SamplePercent: 10, DurationMinutes: 120})
+	assert.ErrorIs(t, err, ErrRequestCaptureInvalid)
+
+	_, err = svc.StartSession(ctx, adminID, &models.StartRequestCaptureRequest{Method: "GET", Route: "/api/v1/clips/:id", SamplePercent: 10, DurationMinutes: 10, MaxCaptures: 1000})
+	assert.ErrorIs(t, err, ErrRequestCaptureInvalid)
+
+	_, err = svc.StartSession(ctx, adminID, &models.StartRequestCaptureRequest{Method: "POST", Route: "/api/v1/auth/login", SamplePercent: 10, DurationMinutes: 10})
+	assert.ErrorIs(t, err, ErrRequestCaptureInvalid)
+
+	session, err := svc.StartSession(ctx, adminID, &models.StartRequestCaptureRequest{Method: "get", Route: "/api/v1/clips/:id", SamplePercent: 10, DurationMinutes: 10})
+	require.NoError(t, err)
+	assert.Equal(t, "GET", session.Method)
+	assert.Equal(t, requestCaptureDefaultMax, session.MaxCaptures)
+	assert.Equal(t, adminID, session.CreatedBy)
+}
+
+func TestRequestCaptureService_SampleAndStop(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	svc, store := setupRequestCaptureServiceTest(now, 0.2)
+	expectRequestCaptureSessions(store)
+
+	session, err := svc.StartSession(ctx, uuid.New(), &models.StartRequestCaptureRequest{Method: "GET", Route: "/api/v1/clips/:id", SamplePercent: 25, DurationMinutes: 10})
+	require.NoError(t, err)
+
+	sampled := svc.Sample(ctx, "GET", "/api/v1/clips/:id")
+	require.NotNil(t, sampled)
+	assert.Equal(t, session.ID, sampled.ID)
+	assert.Nil(t, svc.Sample(ctx, "POST", "/api/v1/clips/:id"))
+	assert.Nil(t, svc.Sample(ctx, "GET", "/api/v1/clips"))
+
+	// A draw outside the sample percentage isn't captured
+	svc.rand = func() float64 { return 0.3 }
+	assert.Nil(t, svc.Sample(ctx, "GET", "/api/v1/clips/:id"))
+	svc.rand = func() float64 { return 0.2 }
+
+	stopped, err := svc.StopSession(ctx, session.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stopped.StoppedAt)
+	assert.Nil(t, svc.Sample(ctx, "GET", "/api/v1/clips/:id"))
+
+	// Stopped sessions stay listed until their captures expire
+	sessions, err := svc.ListSessions(ctx)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+
+	svc.now = func() time.Time { return now.Add(RequestCaptureRetention + time.Minute) }
+	sessions, err = svc.ListSessions(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+	_, err = svc.StopSession(ctx, session.ID)
+	assert.ErrorIs(t, err, ErrRequestCaptureNotFound)
+}
+
+func TestRequestCaptureService_RecordKeepsNewestCaptures(t *testing.T) {
+	ctx := context.Background()
+	svc, store := setupRequestCaptureServiceTest(time.Now(), 0)
+	expectRequestCaptureSessions(store)
+
+	session, err := svc.StartSession(ctx, uuid.New(), &models.StartRequestCaptureRequest{Method: "GET", Route: "/api/v1/clips/:id", SamplePercent: 100, DurationMinutes: 10, MaxCaptures: 2})
+	require.NoError(t, err)
+
+	// Each capture is pushed, the list cut to the newest two and kept until
+	// the session's captures expire
+	key := requestCaptureEntriesKey(session.ID)
+	var pushed []string
+	store.On("ListPush", mock.Anything, key, mock.AnythingOfType("string")).Run(func(args mock.Arguments) {
+		pushed = append(pushed, args.String(2))
+	}).Return(nil).Times(3)
+	store.On("ListTrim", mock.Anything, key, int64(-2), int64(-1)).Return(nil).Times(3)
+	store.On("Expire", mock.Anything, key, 10*time.Minute+RequestCaptureRetention).Return(nil).Times(3)
+
+	for _, path := range []string{"/api/v1/clips/1", "/api/v1/clips/2", "/api/v1/clips/3"} {
+		svc.Record(ctx, session, &RawRequestCapture{Method: "GET", Route: session.Route, Path: path, Status: 200})
+	}
+	require.Len(t, pushed, 3)
+
+	store.On("ListRange", mock.Anything, key, int64(0), int64(-1)).Return(pushed[1:], nil).Once()
+	captures, err := svc.ListCaptures(ctx, session.ID)
+	require.NoError(t, err)
+	require.Len(t, captures, 2)
+	assert.Equal(t, "/api/v1/clips/3", captures[0].Path)
+	assert.Equal(t, "/api/v1/clips/2", captures[1].Path)
+
+	_, err = svc.ListCaptures(ctx, uuid.New())
+	assert.ErrorIs(t, err, ErrRequestCaptureNotFound)
+	store.AssertExpectations(t)
+}
+
+func TestRedactRequestCapture(t *testing.T) {
+	raw := &RawRequestCapture{
+		Method: "POST",
+		Route:  "/api/v1/submissions",
+		Query:  "access_token=abc123&page=2",
+		RequestHeaders: map[string][]string{
+			"Authorization": {"Bearer abc123"},
+			"Cookie":        {"session=abc123"},
+			"Content-Type":  {"application/json"},
+		},
+		RequestBody: []byte(`{"title":"Great play","password":"hunter2","contact":{"email":"viewer@example.com"}}`),
+		ResponseHeaders: map[string][]string{
+			"Content-Type": {"image/png"},
+		},
+		ResponseBody: []byte{0x89, 0x50, 0x4e, 0x47},
+	}
+
+	capture := redactRequestCapture(uuid.New(), raw)
+
+	assert.Equal(t, "[REDACTED]", capture.RequestHeaders["Authorization"])
+	assert.Equal(t, "[REDACTED]", capture.RequestHeaders["Cookie"])
+	assert.Equal(t, "application/json", capture.RequestHeaders["Content-Type"])
+	assert.Contains(t, capture.Query, "page=2")
+	assert.NotContains(t, capture.Query, "abc123")
+
+	assert.Contains(t, capture.RequestBody, "Great play")
+	assert.NotContains(t, capture.RequestBody, "hunter2")
+	assert.NotContains(t, capture.RequestBody, "viewer@example.com")
+
+	assert.True(t, strings.HasPrefix(capture.ResponseBody, "[4 bytes of image/png]"))
+}
+
+func TestRedactCapturedBody_TruncatedJSONIsRedactedAsText(t *testing.T) {
+	body := redactCapturedBody("application/json", []byte(`{"email":"viewer@example.com","title":"Gre`), true)
+	assert.NotContains(t, body, "viewer@example.com")
+	assert.Contains(t, body, "title")
+}
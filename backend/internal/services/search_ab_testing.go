@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 	"fmt"
+
+	"github.com/subculture-collective/clipper/config"
 )
 
 // Floating point comparison tolerance
@@ -19,6 +21,7 @@ type SearchWeightConfig struct {
 	GameBoost       float64 `json:"game_boost" yaml:"game_boost"`             // Field boost for game name
 	EngagementBoost float64 `json:"engagement_boost" yaml:"engagement_boost"` // Boost factor for engagement score
 	RecencyBoost    float64 `json:"recency_boost" yaml:"recency_boost"`       // Boost factor for recency
+	HypeBoost       float64 `json:"hype_boost" yaml:"hype_boost"`             // Boost factor for the chat hype score
 }
 
 // Validate checks if the configuration is valid
@@ -152,6 +155,34 @@ func DefaultConfigs() []SearchWeightConfig {
 			EngagementBoost: 0.1,
 			RecencyBoost:    0.5,
 		},
+		{
+			Name:            "hype-boosted",
+			Description:     "Baseline plus clips that set chat off",
+			BM25Weight:      0.7,
+			VectorWeight:    0.3,
+			TitleBoost:      3.0,
+			CreatorBoost:    2.0,
+			GameBoost:       1.0,
+			EngagementBoost: 0.1,
+			RecencyBoost:    0.5,
+			HypeBoost:       0.5,
+		},
+	}
+}
+
+// ConfiguredWeights returns the weights set by the HYBRID_SEARCH_* settings
+func ConfiguredWeights(cfg *config.HybridSearchConfig) SearchWeightConfig {
+	return SearchWeightConfig{
+		Name:            "configured",
+		Description:     "Weights from HYBRID_SEARCH_* settings",
+		BM25Weight:      cfg.BM25Weight,
+		VectorWeight:    cfg.VectorWeight,
+		TitleBoost:      cfg.TitleBoost,
+		CreatorBoost:    cfg.CreatorBoost,
+		GameBoost:       cfg.GameBoost,
+		EngagementBoost: cfg.EngagementBoost,
+		RecencyBoost:    cfg.RecencyBoost,
+		HypeBoost:       cfg.HypeBoost,
 	}
 }
 
@@ -185,6 +216,11 @@ func (h *ABTestHarness) CompareConfigs(ctx context.Context, configA, configB Sea
 		return nil, fmt.Errorf("failed to evaluate config B: %w", err)
 	}
 
+	return h.compareReports(configA, configB, reportA, reportB), nil
+}
+
+// compareReports builds the A/B result from the evaluation reports of two configurations
+func (h *ABTestHarness) compareReports(configA, configB SearchWeightConfig, reportA, reportB *EvaluationReport) *ABTestResult {
 	// Calculate improvements
 	improvements := h.calculateImprovements(reportA.Metrics, reportB.Metrics)
 
@@ -202,7 +238,7 @@ func (h *ABTestHarness) CompareConfigs(ctx context.Context, configA, configB Sea
 		Improvements:   improvements,
 		Recommendation: recommendation,
 		StatSummary:    statSummary,
-	}, nil
+	}
 }
 
 // calculateImprovements calculates percentage improvement from A to B
@@ -308,3 +344,26 @@ func (h *ABTestHarness) EvaluateWithSimulated(ctx context.Context, configA, conf
 
 	return h.CompareConfigs(ctx, configA, configB, resultsProvider)
 }
+
+// EvaluateWithLiveSearch compares configurations by running the dataset
+// against the real search stack with each configuration's weights
+func (h *ABTestHarness) EvaluateWithLiveSearch(ctx context.Context, configA, configB SearchWeightConfig) (*ABTestResult, error) {
+	if err := configA.Validate(); err != nil {
+		return nil, fmt.Errorf("config A is invalid: %w", err)
+	}
+	if err := configB.Validate(); err != nil {
+		return nil, fmt.Errorf("config B is invalid: %w", err)
+	}
+
+	reportA, err := h.evalService.EvaluateWithLiveSearch(ctx, configA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate config A: %w", err)
+	}
+
+	reportB, err := h.evalService.EvaluateWithLiveSearch(ctx, configB)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate config B: %w", err)
+	}
+
+	return h.compareReports(configA, configB, reportA, reportB), nil
+}
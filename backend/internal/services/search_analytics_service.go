@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+const (
+	// searchAnalyticsMaxPosition is the lowest result position clicks are recorded for
+	searchAnalyticsMaxPosition = 1000
+	// searchAnalyticsMaxDays is the longest period a report covers
+	searchAnalyticsMaxDays = 365
+	// searchAnalyticsMaxLimit is the most queries a report lists
+	searchAnalyticsMaxLimit = 100
+	// searchAnalyticsMaxPositions is the most positions a report has click-through rates for
+	searchAnalyticsMaxPositions = 50
+)
+
+// ErrSearchAnalyticsInvalid is returned for a click or report request that can't be used
+var ErrSearchAnalyticsInvalid = errors.New("invalid search analytics request")
+
+// searchResultTypes are the result types clicks can be recorded for
+var searchResultTypes = map[string]bool{"clip": true, "creator": true, "game": true, "tag": true}
+
+// SearchAnalyticsRepositoryInterface defines the repository methods used by SearchAnalyticsService
+type SearchAnalyticsRepositoryInterface interface {
+	RecordClick(ctx context.Context, userID *uuid.UUID, click *models.SearchClickRequest) error
+	GetTotals(ctx context.Context, start, end time.Time) (*models.SearchAnalyticsTotals, error)
+	ListTopQueries(ctx context.Context, start, end time.Time, limit int) ([]models.SearchQueryStats, error)
+	ListZeroResultQueries(ctx context.Context, start, end time.Time, limit int) ([]models.SearchQueryStats, error)
+	GetPositionClicks(ctx context.Context, start, end time.Time, positions int) ([]models.SearchPositionCTR, error)
+	GetTrends(ctx context.Context, start, end time.Time, interval string) ([]models.SearchAnalyticsTotals, error)
+}
+
+// SearchAnalyticsOptions selects what a search analytics report covers
+type SearchAnalyticsOptions struct {
+	Days      int    // Days before now the report covers
+	Limit     int    // Queries per list
+	Positions int    // Result positions click-through rates are reported for
+	Interval  string // "day" or "week" trend buckets, default "day"
+}
+
+// SearchAnalyticsService records search result clicks and reports how search
+// is used, so synonyms and boosts can be curated from real queries
+type SearchAnalyticsService struct {
+	repo SearchAnalyticsRepositoryInterface
+	now  func() time.Time
+}
+
+// NewSearchAnalyticsService creates a new SearchAnalyticsService
+func NewSearchAnalyticsService(repo SearchAnalyticsRepositoryInterface) *SearchAnalyticsService {
+	return &SearchAnalyticsService{repo: repo, now: time.Now}
+}
+
+// RecordClick records that a user, or an anonymous visitor when userID is
+// nil, opened a result of a tracked search
+func (s *SearchAnalyticsService) RecordClick(ctx context.Context, userID *uuid.UUID, req *models.SearchClickRequest) error {
+	click := *req
+	click.ResultType = strings.ToLower(strings.TrimSpace(click.ResultType))
+	if !searchResultTypes[click.ResultType] {
+		return fmt.Errorf("%w: result_type must be clip, creator, game or tag", ErrSearchAnalyticsInvalid)
+	}
+	if click.Position < 1 || click.Position > searchAnalyticsMaxPosition {
+		return fmt.Errorf("%w: position must be between 1 and %d", ErrSearchAnalyticsInvalid, searchAnalyticsMaxPosition)
+	}
+	return s.repo.RecordClick(ctx, userID, &click)
+}
+
+// Report builds the search analytics dashboard for the last opts.Days days
+func (s *SearchAnalyticsService) Report(ctx context.Context, opts SearchAnalyticsOptions) (*models.SearchAnalyticsReport, error) {
+	if opts.Days < 1 || opts.Days > searchAnalyticsMaxDays {
+		return nil, fmt.Errorf("%w: days must be between 1 and %d", ErrSearchAnalyticsInvalid, searchAnalyticsMaxDays)
+	}
+	interval := opts.Interval
+	if interval == "" {
+		interval = "day"
+	}
+	if interval != "day" && interval != "week" {
+		return nil, fmt.Errorf("%w: interval must be day or week", ErrSearchAnalyticsInvalid)
+	}
+
+	if opts.Limit <= 0 || opts.Limit > searchAnalyticsMaxLimit {
+		opts.Limit = 20
+	}
+	if opts.Positions <= 0 || opts.Positions > searchAnalyticsMaxPositions {
+		opts.Positions = 10
+	}
+
+	end := s.now().UTC()
+	start := end.AddDate(0, 0, -opts.Days)
+	report := &models.SearchAnalyticsReport{Start: start, End: end, Interval: interval}
+
+	totals, err := s.repo.GetTotals(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	report.Summary = *totals
+	setSearchRates(&report.Summary)
+
+	if report.TopQueries, err = s.repo.ListTopQueries(ctx, start, end, opts.Limit); err != nil {
+		return nil, err
+	}
+	if report.ZeroResultQueries, err = s.repo.ListZeroResultQueries(ctx, start, end, opts.Limit); err != nil {
+		return nil, err
+	}
+	for _, queries := range [][]models.SearchQueryStats{report.TopQueries, report.ZeroResultQueries} {
+		for i := range queries {
+			queries[i].CTR = searchRate(queries[i].ClickedSearches, queries[i].Searches)
+		}
+	}
+
+	if report.CTRByPosition, err = s.repo.GetPositionClicks(ctx, start, end, opts.Positions); err != nil {
+		return nil, err
+	}
+	for i := range report.CTRByPosition {
+		position := &report.CTRByPosition[i]
+		position.CTR = searchRate(position.Clicks, position.Impressions)
+	}
+
+	if report.Trends, err = s.repo.GetTrends(ctx, start, end, interval); err != nil {
+		return nil, err
+	}
+	for i := range report.Trends {
+		setSearchRates(&report.Trends[i])
+	}
+
+	return report, nil
+}
+
+// setSearchRates sets the zero-result rate and click-through rate of totals
+func setSearchRates(totals *models.SearchAnalyticsTotals) {
+	totals.ZeroResultRate = searchRate(totals.ZeroResultSearches, totals.Searches)
+	totals.CTR = searchRate(totals.ClickedSearches, totals.Searches)
+}
+
+// searchRate returns count as a share of total, or 0 without a total
+func searchRate(count, total int) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return float64(count) / float64(total)
+}
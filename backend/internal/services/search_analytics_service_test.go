@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// MockSearchAnalyticsRepository is a mock implementation of SearchAnalyticsRepositoryInterface
+type MockSearchAnalyticsRepository struct {
+	mock.Mock
+}
+
+func (m *MockSearchAnalyticsRepository) RecordClick(ctx context.Context, userID *uuid.UUID, click *models.SearchClickRequest) error {
+	args := m.Called(ctx, userID, click)
+	return args.Error(0)
+}
+
+func (m *MockSearchAnalyticsRepository) GetTotals(ctx context.Context, start, end time.Time) (*models.SearchAnalyticsTotals, error) {
+	args := m.Called(ctx, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SearchAnalyticsTotals), args.Error(1)
+}
+
+func (m *MockSearchAnalyticsRepository) ListTopQueries(ctx context.Context, start, end time.Time, limit int) ([]models.SearchQueryStats, error) {
+	args := m.Called(ctx, start, end, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.SearchQueryStats), args.Error(1)
+}
+
+func (m *MockSearchAnalyticsRepository) ListZeroResultQueries(ctx context.Context, start, end time.Time, limit int) ([]models.SearchQueryStats, error) {
+	args := m.Called(ctx, start, end, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.SearchQueryStats), args.Error(1)
+}
+
+func (m *MockSearchAnalyticsRepository) GetPositionClicks(ctx context.Context, start, end time.Time, positions int) ([]models.SearchPositionCTR, error) {
+	args := m.Called(ctx, start, end, positions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.SearchPositionCTR), args.Error(1)
+}
+
+func (m *MockSearchAnalyticsRepository) GetTrends(ctx context.Context, start, end time.Time, interval string) ([]models.SearchAnalyticsTotals, error) {
+	args := m.Called(ctx, start, end, interval)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.SearchAnalyticsTotals), args.Error(1)
+}
+
+// expectSearchAnalyticsReport serves one report over [start, end) with the
+// given limits and trend interval
+func expectSearchAnalyticsReport(repo *MockSearchAnalyticsRepository, start, end interface{}, limit, positions int, interval string) {
+	repo.On("GetTotals", mock.Anything, start, end).
+		Return(&models.SearchAnalyticsTotals{Searches: 200, UniqueUsers: 50, ZeroResultSearches: 20, ClickedSearches: 80}, nil).Once()
+	repo.On("ListTopQueries", mock.Anything, start, end, limit).
+		Return([]models.SearchQueryStats{{Query: "pentakill", Searches: 40, ClickedSearches: 10}}, nil).Once()
+	repo.On("ListZeroResultQueries", mock.Anything, start, end, limit).
+		Return([]models.SearchQueryStats{{Query: "pentakil", Searches: 5, ZeroResultSearches: 5}}, nil).Once()
+	repo.On("GetPositionClicks", mock.Anything, start, end, positions).
+		Return([]models.SearchPositionCTR{
+			{Position: 1, Impressions: 180, Clicks: 45},
+			{Position: 2, Impressions: 0, Clicks: 0},
+		}, nil).Once()
+	repo.On("GetTrends", mock.Anything, start, end, interval).
+		Return([]models.SearchAnalyticsTotals{{Searches: 10, ZeroResultSearches: 1, ClickedSearches: 5}}, nil).Once()
+}
+
+func TestSearchAnalyticsService_Report(t *testing.T) {
+	repo := new(MockSearchAnalyticsRepository)
+	service := NewSearchAnalyticsService(repo)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	expectSearchAnalyticsReport(repo, now.AddDate(0, 0, -7), now, 5, 2, "day")
+	report, err := service.Report(context.Background(), SearchAnalyticsOptions{Days: 7, Limit: 5, Positions: 2})
+	require.NoError(t, err)
+	assert.Equal(t, "day", report.Interval)
+
+	assert.InDelta(t, 0.1, report.Summary.ZeroResultRate, 1e-9)
+	assert.InDelta(t, 0.4, report.Summary.CTR, 1e-9)
+	assert.InDelta(t, 0.25, report.TopQueries[0].CTR, 1e-9)
+	assert.Zero(t, report.ZeroResultQueries[0].CTR)
+	assert.InDelta(t, 0.25, report.CTRByPosition[0].CTR, 1e-9)
+	assert.Zero(t, report.CTRByPosition[1].CTR)
+	assert.InDelta(t, 0.5, report.Trends[0].CTR, 1e-9)
+	assert.InDelta(t, 0.1, report.Trends[0].ZeroResultRate, 1e-9)
+
+	repo.AssertExpectations(t)
+}
+
+func TestSearchAnalyticsService_ReportDefaults(t *testing.T) {
+	repo := new(MockSearchAnalyticsRepository)
+	service := NewSearchAnalyticsService(repo)
+
+	expectSearchAnalyticsReport(repo, mock.Anything, mock.Anything, 20, 10, "week")
+	_, err := service.Report(context.Background(), SearchAnalyticsOptions{Days: 30, Interval: "week"})
+	require.NoError(t, err)
+
+	repo.AssertExpectations(t)
+}
+
+func TestSearchAnalyticsService_ReportInvalid(t *testing.T) {
+	repo := new(MockSearchAnalyticsRepository)
+	service := NewSearchAnalyticsService(repo)
+
+	_, err := service.Report(context.Background(), SearchAnalyticsOptions{Days: 0})
+	assert.ErrorIs(t, err, ErrSearchAnalyticsInvalid)
+
+	_, err = service.Report(context.Background(), SearchAnalyticsOptions{Days: 7, Interval: "month"})
+	assert.ErrorIs(t, err, ErrSearchAnalyticsInvalid)
+
+	repo.AssertNotCalled(t, "GetTotals", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSearchAnalyticsService_RecordClick(t *testing.T) {
+	repo := new(MockSearchAnalyticsRepository)
+	service := NewSearchAnalyticsService(repo)
+	req := &models.SearchClickRequest{SearchID: uuid.New(), ResultID: uuid.New(), ResultType: " Clip ", Position: 3}
+
+	repo.On("RecordClick", mock.Anything, (*uuid.UUID)(nil), mock.MatchedBy(func(click *models.SearchClickRequest) bool {
+		return click.ResultType == "clip" && click.Position == 3
+	})).Return(nil).Once()
+	require.NoError(t, service.RecordClick(context.Background(), nil, req))
+
+	invalid := *req
+	invalid.ResultType = "playlist"
+	assert.ErrorIs(t, service.RecordClick(context.Background(), nil, &invalid), ErrSearchAnalyticsInvalid)
+
+	invalid = *req
+	invalid.Position = 0
+	assert.ErrorIs(t, service.RecordClick(context.Background(), nil, &invalid), ErrSearchAnalyticsInvalid)
+
+	repo.AssertExpectations(t)
+}
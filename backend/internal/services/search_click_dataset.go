@@ -0,0 +1,108 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// personalQueryPatterns match queries that may identify someone: email
+// addresses, URLs and long digit runs such as phone numbers
+var personalQueryPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`[^\s@]+@[^\s@]+\.[^\s@]+`),
+	regexp.MustCompile(`(?i)(https?://|www\.)`),
+	regexp.MustCompile(`\d[\d\s().-]{5,}\d`),
+}
+
+// ClickDatasetOptions controls how click judgments become an evaluation dataset
+type ClickDatasetOptions struct {
+	Description string
+	// MinClicks is the number of clicked searches a clip needs to be judged relevant
+	MinClicks int
+	// MaxDocuments caps the relevant clips kept per query, most clicked first
+	MaxDocuments int
+	Targets      map[string]MetricTarget
+	Guidelines   []string
+}
+
+// BuildClickEvaluationDataset turns the clips clicked from searches into an
+// evaluation dataset, treating clicks as implicit relevance judgments. A clip
+// clicked in a larger share of the query's searches gets a higher relevance.
+// Judgments must be grouped by query; queries keep their order. Queries that
+// may identify someone are left out, and no user data is written.
+func BuildClickEvaluationDataset(judgments []models.SearchClickJudgment, opts ClickDatasetOptions) *EvaluationDataset {
+	dataset := &EvaluationDataset{
+		Version:              "1.0",
+		Description:          opts.Description,
+		EvaluationQueries:    []EvaluationQuery{},
+		MetricTargets:        opts.Targets,
+		EvaluationGuidelines: opts.Guidelines,
+	}
+
+	var current *EvaluationQuery
+	flush := func() {
+		if current != nil && len(current.RelevantDocuments) > 0 {
+			dataset.EvaluationQueries = append(dataset.EvaluationQueries, *current)
+		}
+		current = nil
+	}
+	lastQuery := ""
+	for _, j := range judgments {
+		if j.Query != lastQuery {
+			flush()
+			lastQuery = j.Query
+			if !isPersonalQuery(j.Query) {
+				current = &EvaluationQuery{
+					Query:       j.Query,
+					Description: fmt.Sprintf("Mined from %d searches by %d users", j.Searches, j.UniqueUsers),
+				}
+			}
+		}
+		if current == nil || j.ClickedSearches < opts.MinClicks {
+			continue
+		}
+		if opts.MaxDocuments > 0 && len(current.RelevantDocuments) >= opts.MaxDocuments {
+			continue
+		}
+		current.RelevantDocuments = append(current.RelevantDocuments, RelevantDocument{
+			ClipID:    j.ClipID.String(),
+			Relevance: clickRelevance(j.ClickedSearches, j.Searches),
+			Reason:    fmt.Sprintf("Clicked in %d of %d searches, average position %.1f", j.ClickedSearches, j.Searches, j.AvgPosition),
+		})
+	}
+	flush()
+
+	for i := range dataset.EvaluationQueries {
+		dataset.EvaluationQueries[i].ID = fmt.Sprintf("log-%03d", i+1)
+	}
+	return dataset
+}
+
+// clickRelevance grades a clip on the 1-4 relevance scale by the share of the
+// query's searches that clicked it
+func clickRelevance(clickedSearches, searches int) int {
+	if searches <= 0 {
+		return 1
+	}
+	share := float64(clickedSearches) / float64(searches)
+	switch {
+	case share >= 0.5:
+		return 4
+	case share >= 0.25:
+		return 3
+	case share >= 0.1:
+		return 2
+	default:
+		return 1
+	}
+}
+
+func isPersonalQuery(query string) bool {
+	for _, pattern := range personalQueryPatterns {
+		if pattern.MatchString(query) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+func TestBuildClickEvaluationDataset(t *testing.T) {
+	clipA, clipB, clipC, clipD := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	judgments := []models.SearchClickJudgment{
+		{Query: "pentakill", Searches: 40, UniqueUsers: 12, ClipID: clipA, ClickedSearches: 25, AvgPosition: 1.2},
+		{Query: "pentakill", Searches: 40, UniqueUsers: 12, ClipID: clipB, ClickedSearches: 5, AvgPosition: 4},
+		{Query: "pentakill", Searches: 40, UniqueUsers: 12, ClipID: clipC, ClickedSearches: 1, AvgPosition: 9},
+		{Query: "call me at 555 123 4567", Searches: 8, UniqueUsers: 3, ClipID: clipD, ClickedSearches: 4, AvgPosition: 1},
+		{Query: "call me at 555 123 4567", Searches: 8, UniqueUsers: 3, ClipID: clipA, ClickedSearches: 3, AvgPosition: 2},
+		{Query: "speedrun", Searches: 10, UniqueUsers: 4, ClipID: clipD, ClickedSearches: 3, AvgPosition: 2},
+		{Query: "ace clutch", Searches: 9, UniqueUsers: 3, ClipID: clipC, ClickedSearches: 1, AvgPosition: 3},
+	}
+
+	dataset := BuildClickEvaluationDataset(judgments, ClickDatasetOptions{MinClicks: 2, Description: "from logs"})
+
+	assert.Equal(t, "from logs", dataset.Description)
+	require.Len(t, dataset.EvaluationQueries, 2)
+
+	first := dataset.EvaluationQueries[0]
+	assert.Equal(t, "log-001", first.ID)
+	assert.Equal(t, "pentakill", first.Query)
+	assert.Equal(t, "Mined from 40 searches by 12 users", first.Description)
+	require.Len(t, first.RelevantDocuments, 2)
+	assert.Equal(t, clipA.String(), first.RelevantDocuments[0].ClipID)
+	assert.Equal(t, 4, first.RelevantDocuments[0].Relevance)
+	assert.Equal(t, 2, first.RelevantDocuments[1].Relevance)
+
+	second := dataset.EvaluationQueries[1]
+	assert.Equal(t, "log-002", second.ID)
+	assert.Equal(t, "speedrun", second.Query)
+	assert.Equal(t, 3, second.RelevantDocuments[0].Relevance)
+}
+
+func TestBuildClickEvaluationDataset_MaxDocuments(t *testing.T) {
+	judgments := []models.SearchClickJudgment{
+		{Query: "clutch", Searches: 10, UniqueUsers: 5, ClipID: uuid.New(), ClickedSearches: 6},
+		{Query: "clutch", Searches: 10, UniqueUsers: 5, ClipID: uuid.New(), ClickedSearches: 3},
+	}
+
+	dataset := BuildClickEvaluationDataset(judgments, ClickDatasetOptions{MaxDocuments: 1})
+	require.Len(t, dataset.EvaluationQueries, 1)
+	assert.Len(t, dataset.EvaluationQueries[0].RelevantDocuments, 1)
+}
+
+func TestIsPersonalQuery(t *testing.T) {
+	assert.True(t, isPersonalQuery("streamer@example.com clips"))
+	assert.True(t, isPersonalQuery("https://twitch.tv/somebody"))
+	assert.True(t, isPersonalQuery("+1 (555) 123-4567"))
+	assert.False(t, isPersonalQuery("valorant ace 2024"))
+	assert.False(t, isPersonalQuery("pentakill"))
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// durationFacetBucket is a clip duration range counted in search facets,
+// from seconds up to but excluding to
+type durationFacetBucket struct {
+	key   string
+	label string
+	from  float64
+	to    float64 // 0 for the open-ended last bucket
+}
+
+// durationFacetBuckets keep the common 30 and 60 second clip lengths away
+// from bucket edges
+var durationFacetBuckets = []durationFacetBucket{
+	{key: "under_10s", label: "Under 10s", from: 0, to: 10},
+	{key: "10s_to_20s", label: "10 to 20s", from: 10, to: 20},
+	{key: "20s_to_40s", label: "20 to 40s", from: 20, to: 40},
+	{key: "40s_and_over", label: "40s and over", from: 40},
+}
+
+// ErrInvalidSearchRange is returned for negative or inverted duration and
+// view count ranges
+var ErrInvalidSearchRange = errors.New("min_duration, max_duration, min_views and max_views must not be negative, and each minimum must not exceed its maximum")
+
+// ValidateSearchRanges checks the duration and view count ranges of a search
+func ValidateSearchRanges(req *models.SearchRequest) error {
+	if !validRange(req.MinDuration, req.MaxDuration) || !validRange(req.MinViews, req.MaxViews) {
+		return ErrInvalidSearchRange
+	}
+	return nil
+}
+
+func validRange[T int | float64](lower, upper *T) bool {
+	if (lower != nil && *lower < 0) || (upper != nil && *upper < 0) {
+		return false
+	}
+	return lower == nil || upper == nil || *lower <= *upper
+}
+
+// durationFacetRanges builds the ranges of the duration facet aggregation
+func durationFacetRanges() []map[string]interface{} {
+	ranges := make([]map[string]interface{}, 0, len(durationFacetBuckets))
+	for _, bucket := range durationFacetBuckets {
+		r := map[string]interface{}{"key": bucket.key, "from": bucket.from}
+		if bucket.to > 0 {
+			r["to"] = bucket.to
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges
+}
+
+// parseDurationFacets reads the duration facet aggregation, keeping the
+// bucket order. Clips without a duration aren't counted.
+func parseDurationFacets(raw interface{}) []models.DurationFacetBucket {
+	agg, _ := raw.(map[string]interface{})
+	list, _ := agg["buckets"].([]interface{})
+	counts := make(map[string]int, len(list))
+	for _, item := range list {
+		bucket, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		key, _ := bucket["key"].(string)
+		count, _ := bucket["doc_count"].(float64)
+		counts[key] = int(count)
+	}
+	if len(counts) == 0 {
+		return nil
+	}
+
+	facets := make([]models.DurationFacetBucket, 0, len(durationFacetBuckets))
+	for _, bucket := range durationFacetBuckets {
+		facet := models.DurationFacetBucket{
+			Key:   bucket.key,
+			Label: bucket.label,
+			Min:   bucket.from,
+			Count: counts[bucket.key],
+		}
+		if bucket.to > 0 {
+			to := bucket.to
+			facet.Max = &to
+		}
+		facets = append(facets, facet)
+	}
+	return facets
+}
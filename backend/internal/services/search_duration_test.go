@@ -0,0 +1,65 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+func TestValidateSearchRanges(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	i := func(v int) *int { return &v }
+
+	valid := []models.SearchRequest{
+		{},
+		{MinDuration: f(10), MaxDuration: f(10)},
+		{MaxDuration: f(60), MinViews: i(0)},
+		{MinViews: i(100), MaxViews: i(5000)},
+	}
+	for _, req := range valid {
+		assert.NoError(t, ValidateSearchRanges(&req))
+	}
+
+	invalid := []models.SearchRequest{
+		{MinDuration: f(-1)},
+		{MinDuration: f(30), MaxDuration: f(20)},
+		{MaxViews: i(-5)},
+		{MinViews: i(10), MaxViews: i(9)},
+	}
+	for _, req := range invalid {
+		assert.ErrorIs(t, ValidateSearchRanges(&req), ErrInvalidSearchRange)
+	}
+}
+
+func TestParseDurationFacets(t *testing.T) {
+	ranges := durationFacetRanges()
+	require.Len(t, ranges, len(durationFacetBuckets))
+	assert.Equal(t, map[string]interface{}{"key": "20s_to_40s", "from": 20.0, "to": 40.0}, ranges[2])
+	assert.NotContains(t, ranges[3], "to")
+
+	facets := (&OpenSearchService{}).parseFacetsFromResult(map[string]interface{}{
+		"aggregations": map[string]interface{}{
+			"durations": map[string]interface{}{
+				"buckets": []interface{}{
+					map[string]interface{}{"key": "under_10s", "from": 0.0, "to": 10.0, "doc_count": 4.0},
+					map[string]interface{}{"key": "20s_to_40s", "from": 20.0, "to": 40.0, "doc_count": 31.0},
+					map[string]interface{}{"key": "40s_and_over", "from": 40.0, "doc_count": 12.0},
+				},
+			},
+		},
+	})
+
+	require.Len(t, facets.Durations, 4)
+	assert.Equal(t, "under_10s", facets.Durations[0].Key)
+	assert.Equal(t, 4, facets.Durations[0].Count)
+	assert.Equal(t, 0, facets.Durations[1].Count)
+	assert.Equal(t, 20.0, facets.Durations[2].Min)
+	require.NotNil(t, facets.Durations[2].Max)
+	assert.Equal(t, 40.0, *facets.Durations[2].Max)
+	assert.Nil(t, facets.Durations[3].Max)
+	assert.Equal(t, "40s and over", facets.Durations[3].Label)
+
+	assert.Nil(t, parseDurationFacets(nil))
+}
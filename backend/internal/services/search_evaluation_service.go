@@ -2,15 +2,31 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"math"
 	"os"
 	"sort"
+	"strings"
 
 	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
 	"gopkg.in/yaml.v3"
 )
 
+// liveSearchResultLimit is how many results each query fetches in live
+// evaluation, enough for the @20 metrics
+const liveSearchResultLimit = 20
+
+// ErrLiveSearchUnavailable is returned when live evaluation is requested
+// without a hybrid search service
+var ErrLiveSearchUnavailable = errors.New("live search evaluation requires a hybrid search service")
+
+// ErrNoPersonalizedQueries is returned when personalization is evaluated on
+// a dataset without queries that have a personalization profile
+var ErrNoPersonalizedQueries = errors.New("no evaluation queries have a personalization profile")
+
 // SearchEvaluationService evaluates search quality using standard IR metrics
 type SearchEvaluationService struct {
 	hybridSearchService *HybridSearchService
@@ -32,6 +48,50 @@ type EvaluationQuery struct {
 	Query             string             `yaml:"query"`
 	Description       string             `yaml:"description"`
 	RelevantDocuments []RelevantDocument `yaml:"relevant_documents"`
+
+	// Personalization is the profile of the user running the query. Live
+	// evaluation personalizes the query's results with it; queries without
+	// one run anonymously.
+	Personalization *EvaluationPersonalization `yaml:"personalization,omitempty"`
+}
+
+// EvaluationPersonalization lists the follows, downvoted games and interests
+// of the user an evaluation query is run for, by Twitch ID, and the
+// languages they watch
+type EvaluationPersonalization struct {
+	FollowedBroadcasters []string `yaml:"followed_broadcasters"`
+	FollowedGames        []string `yaml:"followed_games"`
+	DownvotedGames       []string `yaml:"downvoted_games"`
+	InterestBroadcasters []string `yaml:"interest_broadcasters,omitempty"`
+	InterestGames        []string `yaml:"interest_games,omitempty"`
+	Languages            []string `yaml:"languages,omitempty"`
+}
+
+// Profile returns the personalization profile the query is searched with
+func (p *EvaluationPersonalization) Profile() *models.SearchPersonalizationProfile {
+	if p == nil {
+		return nil
+	}
+	profile := models.NewSearchPersonalizationProfile()
+	for _, id := range p.FollowedBroadcasters {
+		profile.FollowedBroadcasters[id] = true
+	}
+	for _, id := range p.FollowedGames {
+		profile.FollowedGames[id] = true
+	}
+	for _, id := range p.DownvotedGames {
+		profile.DownvotedGames[id] = true
+	}
+	for _, id := range p.InterestBroadcasters {
+		profile.InterestBroadcasters[id] = true
+	}
+	for _, id := range p.InterestGames {
+		profile.InterestGames[id] = true
+	}
+	for _, language := range p.Languages {
+		profile.Languages[strings.ToLower(language)] = true
+	}
+	return profile
 }
 
 // RelevantDocument represents a document with its relevance score
@@ -64,6 +124,7 @@ type EvaluationResult struct {
 	Recall20         float64           `json:"recall_at_20"`
 	RetrievedResults int               `json:"retrieved_results"`
 	RelevantResults  int               `json:"relevant_results"`
+	Personalized     bool              `json:"personalized,omitempty"`
 	QueryResults     []QueryResultItem `json:"query_results,omitempty"`
 }
 
@@ -97,6 +158,15 @@ type EvaluationReport struct {
 	Status       map[string]string       `json:"status,omitempty"` // "pass", "warning", "critical"
 }
 
+// PersonalizationEvaluationReport compares the dataset queries that have a
+// personalization profile searched anonymously and with their profile
+type PersonalizationEvaluationReport struct {
+	Baseline     *EvaluationReport `json:"baseline"`
+	Personalized *EvaluationReport `json:"personalized"`
+	NDCG10Lift   float64           `json:"ndcg_at_10_lift"`
+	MRRLift      float64           `json:"mrr_lift"`
+}
+
 // NewSearchEvaluationService creates a new search evaluation service
 func NewSearchEvaluationService(hybridSearchService *HybridSearchService) *SearchEvaluationService {
 	return &SearchEvaluationService{
@@ -294,17 +364,28 @@ func (s *SearchEvaluationService) EvaluateDataset(ctx context.Context, resultsPr
 		return nil, fmt.Errorf("no dataset loaded")
 	}
 
-	results := make([]EvaluationResult, 0, len(s.dataset.EvaluationQueries))
+	return s.evaluateQueries(ctx, s.dataset.EvaluationQueries, func(evalQuery EvaluationQuery) ([]string, bool, error) {
+		retrievedIDs, err := resultsProvider(evalQuery.Query)
+		return retrievedIDs, false, err
+	}), nil
+}
 
-	for _, evalQuery := range s.dataset.EvaluationQueries {
+// evaluateQueries evaluates queries with the results resultsProvider returns
+// for each, and whether those results were personalized
+func (s *SearchEvaluationService) evaluateQueries(ctx context.Context, queries []EvaluationQuery, resultsProvider func(evalQuery EvaluationQuery) ([]string, bool, error)) *EvaluationReport {
+	results := make([]EvaluationResult, 0, len(queries))
+
+	for _, evalQuery := range queries {
 		// Get search results
-		retrievedIDs, err := resultsProvider(evalQuery.Query)
+		retrievedIDs, personalized, err := resultsProvider(evalQuery)
 		if err != nil {
 			// Log error but continue with empty results
 			retrievedIDs = []string{}
+			personalized = false
 		}
 
 		result := s.EvaluateQuery(ctx, evalQuery, retrievedIDs)
+		result.Personalized = personalized
 		results = append(results, result)
 	}
 
@@ -319,7 +400,7 @@ func (s *SearchEvaluationService) EvaluateDataset(ctx context.Context, resultsPr
 		QueryResults: results,
 		Targets:      s.dataset.MetricTargets,
 		Status:       status,
-	}, nil
+	}
 }
 
 // calculateAggregateMetrics computes mean metrics across all queries
@@ -455,6 +536,131 @@ func (s *SearchEvaluationService) EvaluateWithSimulatedResults(ctx context.Conte
 	})
 }
 
+// EvaluateWithLiveSearch runs the dataset against the real OpenSearch and
+// pgvector stack, with hybrid search ranking by weights. Queries with a
+// personalization profile are personalized with it. Queries that fail score
+// as empty results; an error is returned only when every query fails.
+func (s *SearchEvaluationService) EvaluateWithLiveSearch(ctx context.Context, weights SearchWeightConfig) (*EvaluationReport, error) {
+	searcher, err := s.liveSearcher(weights)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.evaluateLive(ctx, s.dataset.EvaluationQueries, func(evalQuery EvaluationQuery) ([]string, bool, error) {
+		return searchClipIDs(ctx, searcher, evalQuery.Query, evalQuery.Personalization.Profile())
+	})
+}
+
+// EvaluatePersonalization runs the dataset queries that have a
+// personalization profile against live search twice, anonymously and with
+// their profile, and reports how much personalization changes the metrics
+func (s *SearchEvaluationService) EvaluatePersonalization(ctx context.Context, weights SearchWeightConfig) (*PersonalizationEvaluationReport, error) {
+	searcher, err := s.liveSearcher(weights)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.comparePersonalization(ctx, func(evalQuery EvaluationQuery, profile *models.SearchPersonalizationProfile) ([]string, bool, error) {
+		return searchClipIDs(ctx, searcher, evalQuery.Query, profile)
+	})
+}
+
+// comparePersonalization evaluates the personalized queries of the dataset
+// with the results search returns for them without and with their profile
+func (s *SearchEvaluationService) comparePersonalization(ctx context.Context, search func(evalQuery EvaluationQuery, profile *models.SearchPersonalizationProfile) ([]string, bool, error)) (*PersonalizationEvaluationReport, error) {
+	queries := []EvaluationQuery{}
+	for _, evalQuery := range s.dataset.EvaluationQueries {
+		if evalQuery.Personalization != nil {
+			queries = append(queries, evalQuery)
+		}
+	}
+	if len(queries) == 0 {
+		return nil, ErrNoPersonalizedQueries
+	}
+
+	baseline, err := s.evaluateLive(ctx, queries, func(evalQuery EvaluationQuery) ([]string, bool, error) {
+		return search(evalQuery, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	personalized, err := s.evaluateLive(ctx, queries, func(evalQuery EvaluationQuery) ([]string, bool, error) {
+		return search(evalQuery, evalQuery.Personalization.Profile())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &PersonalizationEvaluationReport{
+		Baseline:     baseline,
+		Personalized: personalized,
+		NDCG10Lift:   personalized.Metrics.MeanNDCG10 - baseline.Metrics.MeanNDCG10,
+		MRRLift:      personalized.Metrics.MeanMRR - baseline.Metrics.MeanMRR,
+	}, nil
+}
+
+// liveSearcher checks live evaluation can run and returns the hybrid search
+// service ranking by weights
+func (s *SearchEvaluationService) liveSearcher(weights SearchWeightConfig) (*HybridSearchService, error) {
+	if s.hybridSearchService == nil {
+		return nil, ErrLiveSearchUnavailable
+	}
+	if s.dataset == nil {
+		return nil, fmt.Errorf("no dataset loaded")
+	}
+	if err := weights.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid weights: %w", err)
+	}
+	return s.hybridSearchService.WithWeights(weights), nil
+}
+
+// evaluateLive evaluates queries with provider, counting failed searches.
+// It fails only when every query fails, and logs partial failures.
+func (s *SearchEvaluationService) evaluateLive(ctx context.Context, queries []EvaluationQuery, provider func(evalQuery EvaluationQuery) ([]string, bool, error)) (*EvaluationReport, error) {
+	failed := 0
+	var lastErr error
+	report := s.evaluateQueries(ctx, queries, func(evalQuery EvaluationQuery) ([]string, bool, error) {
+		ids, personalized, err := provider(evalQuery)
+		if err != nil {
+			failed++
+			lastErr = err
+		}
+		return ids, personalized, err
+	})
+
+	total := len(queries)
+	if total > 0 && failed == total {
+		return nil, fmt.Errorf("live search failed for all %d queries: %w", total, lastErr)
+	}
+	if failed > 0 {
+		log.Printf("Warning: live search failed for %d of %d queries, last error: %v", failed, total, lastErr)
+	}
+
+	return report, nil
+}
+
+// searchClipIDs runs a clip search, personalized with profile when it is
+// set, and returns the result IDs in rank order and whether they were
+// personalized
+func searchClipIDs(ctx context.Context, searcher *HybridSearchService, query string, profile *models.SearchPersonalizationProfile) ([]string, bool, error) {
+	resp, err := searcher.SearchWithProfile(ctx, &models.SearchRequest{
+		Query: query,
+		Type:  "clips",
+		Sort:  "relevance",
+		Page:  1,
+		Limit: liveSearchResultLimit,
+	}, profile)
+	if err != nil {
+		return nil, false, err
+	}
+
+	ids := make([]string, len(resp.Results.Clips))
+	for i, clip := range resp.Results.Clips {
+		ids[i] = clip.ID.String()
+	}
+	return ids, resp.Personalized, nil
+}
+
 // ConvertClipIDsToUUIDs attempts to convert clip IDs to UUIDs for actual search
 // If the ID is already a valid UUID, it's used as-is
 // Otherwise, a deterministic UUID is generated from the string
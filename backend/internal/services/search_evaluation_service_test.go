@@ -2,11 +2,15 @@ package services
 
 import (
 	"context"
+	"errors"
 	"os"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/models"
 )
 
 func TestCalculateNDCG(t *testing.T) {
@@ -460,6 +464,128 @@ metric_targets:
 	assert.NotNil(t, report.Status)
 }
 
+func TestSearchEvaluationService_EvaluateWithLiveSearch_RequiresSearchService(t *testing.T) {
+	service := NewSearchEvaluationService(nil)
+
+	_, err := service.EvaluateWithLiveSearch(context.Background(), DefaultConfigs()[0])
+	assert.ErrorIs(t, err, ErrLiveSearchUnavailable)
+}
+
+func TestSearchEvaluationService_ComparePersonalization(t *testing.T) {
+	yamlContent := `
+version: "1.0"
+evaluation_queries:
+  - id: "anon-001"
+    query: "clutch"
+    relevant_documents:
+      - clip_id: "clip-other"
+        relevance: 4
+  - id: "personal-001"
+    query: "valorant ace"
+    personalization:
+      followed_broadcasters: ["b-followed"]
+      downvoted_games: ["g-downvoted"]
+      interest_games: ["g-interest"]
+      languages: ["EN"]
+    relevant_documents:
+      - clip_id: "clip-followed"
+        relevance: 4
+      - clip_id: "clip-other"
+        relevance: 2
+`
+	tmpFile, err := os.CreateTemp("", "test_eval_personalization_*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+	_, err = tmpFile.WriteString(yamlContent)
+	require.NoError(t, err)
+	tmpFile.Close()
+
+	service := NewSearchEvaluationService(nil)
+	require.NoError(t, service.LoadDataset(tmpFile.Name()))
+
+	dataset := service.GetDataset()
+	assert.Nil(t, dataset.EvaluationQueries[0].Personalization)
+	profile := dataset.EvaluationQueries[1].Personalization.Profile()
+	require.NotNil(t, profile)
+	assert.True(t, profile.FollowedBroadcasters["b-followed"])
+	assert.True(t, profile.DownvotedGames["g-downvoted"])
+	assert.True(t, profile.InterestGames["g-interest"])
+	assert.True(t, profile.Languages["en"], "languages are lowercased like clip languages")
+	assert.Empty(t, profile.FollowedGames)
+
+	// Search ranks a downvoted game's clip first and the followed
+	// broadcaster's clip last, personalized like hybrid search
+	names := map[uuid.UUID]string{}
+	clip := func(name, broadcasterID, gameID string) models.Clip {
+		c := models.Clip{ID: uuid.New(), BroadcasterID: &broadcasterID, GameID: &gameID}
+		names[c.ID] = name
+		return c
+	}
+	clips := []models.Clip{
+		clip("clip-downvoted", "b-other", "g-downvoted"),
+		clip("clip-other", "b-other", "g-other"),
+		clip("clip-followed", "b-followed", "g-other"),
+	}
+	weights := SearchPersonalizationWeights{FollowedBroadcasterBoost: 0.5, DownvotedGamePenalty: 0.5}
+	search := func(evalQuery EvaluationQuery, profile *models.SearchPersonalizationProfile) ([]string, bool, error) {
+		ranked := clips
+		if profile != nil {
+			ranked = personalizeClips(clips, profile, weights)
+		}
+		ids := make([]string, len(ranked))
+		for i, c := range ranked {
+			ids[i] = names[c.ID]
+		}
+		return ids, profile != nil, nil
+	}
+
+	report, err := service.comparePersonalization(context.Background(), search)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, report.Baseline.Metrics.QueryCount, "only personalized queries are compared")
+	assert.False(t, report.Baseline.QueryResults[0].Personalized)
+	assert.True(t, report.Personalized.QueryResults[0].Personalized)
+	assert.Equal(t, "clip-followed", report.Personalized.QueryResults[0].QueryResults[0].ClipID)
+	assert.Greater(t, report.NDCG10Lift, 0.0)
+	assert.Greater(t, report.MRRLift, 0.0)
+
+	failing := func(evalQuery EvaluationQuery, profile *models.SearchPersonalizationProfile) ([]string, bool, error) {
+		return nil, false, errors.New("search down")
+	}
+	_, err = service.comparePersonalization(context.Background(), failing)
+	assert.Error(t, err)
+
+	dataset.EvaluationQueries = dataset.EvaluationQueries[:1]
+	_, err = service.comparePersonalization(context.Background(), search)
+	assert.ErrorIs(t, err, ErrNoPersonalizedQueries)
+}
+
+func TestSearchEvaluationService_EvaluatePersonalization_RequiresSearchService(t *testing.T) {
+	service := NewSearchEvaluationService(nil)
+
+	_, err := service.EvaluatePersonalization(context.Background(), DefaultConfigs()[0])
+	assert.ErrorIs(t, err, ErrLiveSearchUnavailable)
+}
+
+func TestConfiguredWeights(t *testing.T) {
+	weights := ConfiguredWeights(&config.HybridSearchConfig{
+		BM25Weight:      0.6,
+		VectorWeight:    0.4,
+		TitleBoost:      4.0,
+		CreatorBoost:    2.0,
+		GameBoost:       1.5,
+		EngagementBoost: 0.2,
+		RecencyBoost:    0.3,
+	})
+
+	assert.Equal(t, "configured", weights.Name)
+	assert.Equal(t, 0.6, weights.BM25Weight)
+	assert.Equal(t, 0.4, weights.VectorWeight)
+	assert.Equal(t, 1.5, weights.GameBoost)
+	assert.Equal(t, 0.3, weights.RecencyBoost)
+	assert.NoError(t, weights.Validate())
+}
+
 func TestSearchEvaluationService_SimulateResults(t *testing.T) {
 	service := NewSearchEvaluationService(nil)
 
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+const (
+	defaultHighlightPreTag  = "<mark>"
+	defaultHighlightPostTag = "</mark>"
+)
+
+// clipHighlightFields are the clip fields matched terms are highlighted in
+var clipHighlightFields = []string{"title", "creator_name", "broadcaster_name", "game_name"}
+
+// highlightPreTagPattern allows only plain inline formatting tags, with an
+// optional class, as highlight tags; results are rendered as HTML
+var highlightPreTagPattern = regexp.MustCompile(`^<(mark|em|strong|b|i|u|span)(?: class="[A-Za-z0-9 _-]{1,64}")?>$`)
+
+// ErrInvalidHighlightTags is returned for highlight tags that aren't an
+// allowed opening tag and its matching closing tag
+var ErrInvalidHighlightTags = errors.New("highlight_pre_tag must be one of <mark>, <em>, <strong>, <b>, <i>, <u> or <span>, optionally with a class, and highlight_post_tag its closing tag")
+
+// ResolveHighlightTags returns the tags to wrap matched terms in: the given
+// ones, which must be set together, or <mark> and </mark>
+func ResolveHighlightTags(preTag, postTag *string) (string, string, error) {
+	if preTag == nil && postTag == nil {
+		return defaultHighlightPreTag, defaultHighlightPostTag, nil
+	}
+	if preTag == nil || postTag == nil {
+		return "", "", ErrInvalidHighlightTags
+	}
+
+	match := highlightPreTagPattern.FindStringSubmatch(*preTag)
+	if match == nil || *postTag != "</"+match[1]+">" {
+		return "", "", ErrInvalidHighlightTags
+	}
+	return *preTag, *postTag, nil
+}
+
+// buildClipHighlight builds the highlight clause of a clip search, or nil
+// when there is no query text to highlight. Field text is HTML-escaped by
+// OpenSearch before the tags are added, and whole fields are returned since
+// they are short.
+func (s *OpenSearchService) buildClipHighlight(req *models.SearchRequest) map[string]interface{} {
+	if req.Query == "" {
+		return nil
+	}
+
+	preTag, postTag, err := ResolveHighlightTags(req.HighlightPreTag, req.HighlightPostTag)
+	if err != nil {
+		preTag, postTag = defaultHighlightPreTag, defaultHighlightPostTag
+	}
+
+	fields := make(map[string]interface{}, len(clipHighlightFields)+1)
+	for _, field := range clipHighlightFields {
+		fields[field] = map[string]interface{}{}
+	}
+	// Titles may only match through the query language's analyzer
+	if language := clipQueryLanguage(req); language != "" {
+		fields["title."+language] = map[string]interface{}{}
+	}
+
+	return map[string]interface{}{
+		"pre_tags":            []string{preTag},
+		"post_tags":           []string{postTag},
+		"encoder":             "html",
+		"number_of_fragments": 0,
+		"fields":              fields,
+	}
+}
+
+// parseClipHighlights extracts the highlights of each clip hit, keyed by
+// clip ID. Language title subfields are reported as the title.
+func parseClipHighlights(result map[string]interface{}) map[string]models.SearchHighlights {
+	hits, _ := result["hits"].(map[string]interface{})
+	hitsList, _ := hits["hits"].([]interface{})
+
+	highlights := make(map[string]models.SearchHighlights)
+	for _, hit := range hitsList {
+		hitMap, ok := hit.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, _ := hitMap["_id"].(string)
+		fields, ok := hitMap["highlight"].(map[string]interface{})
+		if id == "" || !ok {
+			continue
+		}
+
+		clipHighlights := make(models.SearchHighlights)
+		for field, raw := range fields {
+			fragments := highlightFragments(raw)
+			if len(fragments) == 0 {
+				continue
+			}
+			if strings.HasPrefix(field, "title.") {
+				if _, ok := fields["title"]; ok {
+					continue
+				}
+				field = "title"
+			}
+			clipHighlights[field] = fragments
+		}
+		if len(clipHighlights) > 0 {
+			highlights[id] = clipHighlights
+		}
+	}
+
+	if len(highlights) == 0 {
+		return nil
+	}
+	return highlights
+}
+
+func highlightFragments(raw interface{}) []string {
+	list, _ := raw.([]interface{})
+	fragments := make([]string, 0, len(list))
+	for _, item := range list {
+		if fragment, ok := item.(string); ok {
+			fragments = append(fragments, fragment)
+		}
+	}
+	return fragments
+}
+
+// highlightsForClips keeps the highlights of the given clips, for responses
+// built from a subset of the searched clips
+func highlightsForClips(highlights map[string]models.SearchHighlights, clips []models.Clip) map[string]models.SearchHighlights {
+	if len(highlights) == 0 {
+		return nil
+	}
+	kept := make(map[string]models.SearchHighlights)
+	for _, clip := range clips {
+		if h, ok := highlights[clip.ID.String()]; ok {
+			kept[clip.ID.String()] = h
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	return kept
+}
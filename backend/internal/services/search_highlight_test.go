@@ -0,0 +1,100 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+func TestResolveHighlightTags(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	pre, post, err := ResolveHighlightTags(nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "<mark>", pre)
+	assert.Equal(t, "</mark>", post)
+
+	pre, post, err = ResolveHighlightTags(str(`<span class="hit match-1">`), str("</span>"))
+	require.NoError(t, err)
+	assert.Equal(t, `<span class="hit match-1">`, pre)
+	assert.Equal(t, "</span>", post)
+
+	invalid := [][2]*string{
+		{str("<em>"), nil},
+		{str("<em>"), str("</strong>")},
+		{str("<script>"), str("</script>")},
+		{str(`<span onclick="x()">`), str("</span>")},
+		{str(`<span class="a"><img src=x>`), str("</span>")},
+		{str(`<mark class="x" >`), str("</mark>")},
+	}
+	for _, tags := range invalid {
+		_, _, err := ResolveHighlightTags(tags[0], tags[1])
+		assert.ErrorIs(t, err, ErrInvalidHighlightTags)
+	}
+}
+
+func TestOpenSearchService_BuildClipHighlight(t *testing.T) {
+	service := &OpenSearchService{}
+	assert.Nil(t, service.buildClipHighlight(&models.SearchRequest{}))
+
+	pre, post := "<em>", "</em>"
+	highlight := service.buildClipHighlight(&models.SearchRequest{
+		Query:            "la mejor jugada",
+		HighlightPreTag:  &pre,
+		HighlightPostTag: &post,
+	})
+	require.NotNil(t, highlight)
+	assert.Equal(t, "html", highlight["encoder"])
+	assert.Equal(t, []string{"<em>"}, highlight["pre_tags"])
+	assert.Equal(t, []string{"</em>"}, highlight["post_tags"])
+
+	fields := highlight["fields"].(map[string]interface{})
+	for _, field := range []string{"title", "title.es", "creator_name", "broadcaster_name", "game_name"} {
+		assert.Contains(t, fields, field)
+	}
+
+	// Invalid tags never reach OpenSearch
+	bad := "<script>"
+	highlight = service.buildClipHighlight(&models.SearchRequest{Query: "ace", HighlightPreTag: &bad, HighlightPostTag: &post})
+	assert.Equal(t, []string{"<mark>"}, highlight["pre_tags"])
+}
+
+func TestParseClipHighlights(t *testing.T) {
+	clipA, clipB, clipC := uuid.New(), uuid.New(), uuid.New()
+	result := map[string]interface{}{
+		"hits": map[string]interface{}{
+			"hits": []interface{}{
+				map[string]interface{}{
+					"_id": clipA.String(),
+					"highlight": map[string]interface{}{
+						"title.es":  []interface{}{"la mejor <mark>jugada</mark>"},
+						"game_name": []interface{}{"<mark>Valorant</mark>"},
+					},
+				},
+				map[string]interface{}{
+					"_id": clipB.String(),
+					"highlight": map[string]interface{}{
+						"title":    []interface{}{"<mark>ace</mark> &lt;3"},
+						"title.en": []interface{}{"<mark>aces</mark>"},
+					},
+				},
+				map[string]interface{}{"_id": clipC.String()},
+			},
+		},
+	}
+
+	highlights := parseClipHighlights(result)
+	require.Len(t, highlights, 2)
+	assert.Equal(t, []string{"la mejor <mark>jugada</mark>"}, highlights[clipA.String()]["title"])
+	assert.Equal(t, []string{"<mark>Valorant</mark>"}, highlights[clipA.String()]["game_name"])
+	assert.Equal(t, models.SearchHighlights{"title": {"<mark>ace</mark> &lt;3"}}, highlights[clipB.String()])
+
+	kept := highlightsForClips(highlights, []models.Clip{{ID: clipB}, {ID: clipC}})
+	assert.Len(t, kept, 1)
+	assert.Contains(t, kept, clipB.String())
+	assert.Nil(t, highlightsForClips(highlights, []models.Clip{{ID: clipC}}))
+	assert.Nil(t, parseClipHighlights(map[string]interface{}{}))
+}
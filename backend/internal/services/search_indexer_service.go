@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"net/http"
 	"strings"
 	"time"
 
@@ -20,6 +21,14 @@ import (
 // SearchIndexerService handles indexing operations for OpenSearch
 type SearchIndexerService struct {
 	osClient *opensearch.Client
+
+	// knnEnabled indexes clip embeddings as kNN vectors; rrfRankConstant
+	// configures the pipeline that fuses hybrid search results
+	knnEnabled      bool
+	rrfRankConstant int
+
+	// synonyms are baked into the search analyzer of new indices
+	synonyms *models.SearchSynonymRules
 }
 
 // NewSearchIndexerService creates a new SearchIndexerService
@@ -34,15 +43,69 @@ const (
 	UsersIndex = "users"
 	GamesIndex = "games"
 	TagsIndex  = "tags"
+
+	// ClipsHybridSearchPipeline fuses the BM25 and kNN sub-queries of a hybrid
+	// clip search with reciprocal rank fusion
+	ClipsHybridSearchPipeline = "clips-hybrid-rrf"
+
+	clipEmbeddingField     = "embedding"
+	defaultRRFRankConstant = 60
+
+	// synonymSearchAnalyzer applies the search synonyms to queries on the
+	// text fields in searchSynonymFields
+	synonymSearchAnalyzer = "synonym_search"
+	searchSynonymFilter   = "search_synonyms"
+	// synonymsVersionMeta records in the index mapping which synonym rules
+	// the index was built with
+	synonymsVersionMeta = "synonyms_version"
 )
 
+// searchSynonymFields are the text fields of each index searched with synonyms
+var searchSynonymFields = map[string][]string{
+	ClipsIndex: {"title", "creator_name", "broadcaster_name", "game_name"},
+	UsersIndex: {"username", "display_name"},
+	GamesIndex: {"name"},
+	TagsIndex:  {"name"},
+}
+
+// EnableKNN indexes clip embeddings as kNN vectors and creates the hybrid
+// search pipeline on InitializeIndices. index.knn is a static setting, so an
+// existing clips index must be rebuilt before it accepts vectors.
+func (s *SearchIndexerService) EnableKNN(rrfRankConstant int) {
+	if rrfRankConstant <= 0 {
+		rrfRankConstant = defaultRRFRankConstant
+	}
+	s.knnEnabled = true
+	s.rrfRankConstant = rrfRankConstant
+}
+
+// KNNEnabled reports whether clip embeddings are indexed as kNN vectors
+func (s *SearchIndexerService) KNNEnabled() bool {
+	return s.knnEnabled
+}
+
+// SetSynonyms sets the synonym rules built into indices created from now on.
+// Analyzers are fixed when an index is created, so existing indices keep
+// their rules until rebuilt.
+func (s *SearchIndexerService) SetSynonyms(rules *models.SearchSynonymRules) {
+	s.synonyms = rules
+}
+
+// synonymRules returns the synonym rules for new indices, or none
+func (s *SearchIndexerService) synonymRules() *models.SearchSynonymRules {
+	if s.synonyms == nil {
+		return BuildSearchSynonymRules(nil)
+	}
+	return s.synonyms
+}
+
 // InitializeIndices creates all required indices with proper mappings
 func (s *SearchIndexerService) InitializeIndices(ctx context.Context) error {
 	indices := map[string]string{
-		ClipsIndex: getClipIndexMapping(),
-		UsersIndex: getUserIndexMapping(),
-		GamesIndex: getGameIndexMapping(),
-		TagsIndex:  getTagIndexMapping(),
+		ClipsIndex: s.clipIndexMapping(),
+		UsersIndex: s.userIndexMapping(),
+		GamesIndex: s.gameIndexMapping(),
+		TagsIndex:  s.tagIndexMapping(),
 	}
 
 	for indexName, mapping := range indices {
@@ -52,9 +115,59 @@ func (s *SearchIndexerService) InitializeIndices(ctx context.Context) error {
 		utils.Info("Index ready", map[string]interface{}{"index": indexName})
 	}
 
+	if s.knnEnabled {
+		if err := s.putHybridSearchPipeline(ctx); err != nil {
+			return fmt.Errorf("failed to create search pipeline %s: %w", ClipsHybridSearchPipeline, err)
+		}
+		utils.Info("Search pipeline ready", map[string]interface{}{"pipeline": ClipsHybridSearchPipeline})
+	}
+
+	return nil
+}
+
+// putHybridSearchPipeline creates or updates the RRF search pipeline used by
+// hybrid clip search. Search pipelines have no typed client, so the request
+// is sent raw.
+func (s *SearchIndexerService) putHybridSearchPipeline(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, "/_search/pipeline/"+ClipsHybridSearchPipeline,
+		bytes.NewReader(hybridSearchPipelineBody(s.rrfRankConstant)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := s.osClient.GetClient().Perform(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		body, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("%s - %s", res.Status, string(body))
+	}
+
 	return nil
 }
 
+// hybridSearchPipelineBody returns the definition of the RRF search pipeline
+func hybridSearchPipelineBody(rankConstant int) []byte {
+	body, _ := json.Marshal(map[string]interface{}{
+		"description": "Fuses BM25 and kNN clip results with reciprocal rank fusion",
+		"phase_results_processors": []map[string]interface{}{
+			{
+				"score-ranker-processor": map[string]interface{}{
+					"combination": map[string]interface{}{
+						"technique":     "rrf",
+						"rank_constant": rankConstant,
+					},
+				},
+			},
+		},
+	})
+	return body
+}
+
 // createIndexIfNotExists creates an index if it doesn't exist
 func (s *SearchIndexerService) createIndexIfNotExists(ctx context.Context, indexName, mapping string) error {
 	// Check if index exists
@@ -95,6 +208,19 @@ func (s *SearchIndexerService) createIndexIfNotExists(ctx context.Context, index
 
 // IndexClip indexes a single clip
 func (s *SearchIndexerService) IndexClip(ctx context.Context, clip *models.Clip) error {
+	doc := s.buildClipDocument(clip)
+
+	// Clips are usually loaded without their embedding; merge into the
+	// existing document so its indexed vector is kept
+	if s.knnEnabled && !hasClipEmbedding(clip) {
+		return s.upsertDocument(ctx, ClipsIndex, clip.ID.String(), doc)
+	}
+
+	return s.indexDocument(ctx, ClipsIndex, clip.ID.String(), doc)
+}
+
+// buildClipDocument builds the search document for a clip
+func (s *SearchIndexerService) buildClipDocument(clip *models.Clip) map[string]interface{} {
 	// Calculate engagement score (combines votes, comments, favorites, views)
 	engagementScore := calculateEngagementScore(clip)
 
@@ -104,7 +230,7 @@ func (s *SearchIndexerService) IndexClip(ctx context.Context, clip *models.Clip)
 	doc := map[string]interface{}{
 		"id":               clip.ID.String(),
 		"twitch_clip_id":   clip.TwitchClipID,
-		"title":            clip.Title,
+		"title":            clip.PreferredTitle(),
 		"creator_name":     clip.CreatorName,
 		"creator_id":       clip.CreatorID,
 		"broadcaster_name": clip.BroadcasterName,
@@ -112,7 +238,9 @@ func (s *SearchIndexerService) IndexClip(ctx context.Context, clip *models.Clip)
 		"game_id":          clip.GameID,
 		"game_name":        clip.GameName,
 		"language":         clip.Language,
+		"title_language":   clipTitleLanguage(clip),
 		"view_count":       clip.ViewCount,
+		"duration":         clip.Duration,
 		"vote_score":       clip.VoteScore,
 		"comment_count":    clip.CommentCount,
 		"favorite_count":   clip.FavoriteCount,
@@ -130,7 +258,57 @@ func (s *SearchIndexerService) IndexClip(ctx context.Context, clip *models.Clip)
 		doc["submitted_by_user_id"] = clip.SubmittedByUserID.String()
 	}
 
-	return s.indexDocument(ctx, ClipsIndex, clip.ID.String(), doc)
+	addAccessibilityFields(doc, clip)
+
+	if clip.HypeScore != nil {
+		doc["hype_score"] = *clip.HypeScore
+	}
+
+	if s.knnEnabled && hasClipEmbedding(clip) {
+		doc[clipEmbeddingField] = clip.Embedding
+	}
+
+	return doc
+}
+
+// hasClipEmbedding reports whether the clip carries an embedding that fits
+// the kNN vector field
+func hasClipEmbedding(clip *models.Clip) bool {
+	return len(clip.Embedding) == EmbeddingDimensions
+}
+
+// writeClipBulkAction appends the bulk action and document for a clip to buf.
+// Like IndexClip, clips without an embedding are merged into the existing
+// document when vectors are indexed.
+func (s *SearchIndexerService) writeClipBulkAction(buf *bytes.Buffer, indexName string, clip *models.Clip) error {
+	action := "index"
+	var body interface{} = s.buildClipDocument(clip)
+	if s.knnEnabled && !hasClipEmbedding(clip) {
+		action = "update"
+		body = map[string]interface{}{"doc": body, "doc_as_upsert": true}
+	}
+
+	meta := map[string]interface{}{
+		action: map[string]interface{}{
+			"_index": indexName,
+			"_id":    clip.ID.String(),
+		},
+	}
+	metaJSON, err := json.Marshal(meta)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata for clip %s: %w", clip.ID, err)
+	}
+	buf.Write(metaJSON)
+	buf.WriteByte('\n')
+
+	docJSON, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal document for clip %s: %w", clip.ID, err)
+	}
+	buf.Write(docJSON)
+	buf.WriteByte('\n')
+
+	return nil
 }
 
 // IndexUser indexes a single user
@@ -207,6 +385,34 @@ func (s *SearchIndexerService) indexDocument(ctx context.Context, indexName, doc
 	return nil
 }
 
+// upsertDocument merges doc into an existing document, creating it if missing
+func (s *SearchIndexerService) upsertDocument(ctx context.Context, indexName, docID string, doc map[string]interface{}) error {
+	data, err := json.Marshal(map[string]interface{}{"doc": doc, "doc_as_upsert": true})
+	if err != nil {
+		return fmt.Errorf("failed to marshal document: %w", err)
+	}
+
+	req := opensearchapi.UpdateRequest{
+		Index:      indexName,
+		DocumentID: docID,
+		Body:       bytes.NewReader(data),
+		Refresh:    "false",
+	}
+
+	res, err := req.Do(ctx, s.osClient.GetClient())
+	if err != nil {
+		return fmt.Errorf("failed to update document: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("failed to update document: %s - %s", res.Status(), string(body))
+	}
+
+	return nil
+}
+
 // DeleteClip removes a clip from the index
 func (s *SearchIndexerService) DeleteClip(ctx context.Context, clipID uuid.UUID) error {
 	return s.deleteDocument(ctx, ClipsIndex, clipID.String())
@@ -277,6 +483,18 @@ func calculateRecencyScore(clip *models.Clip) float64 {
 	return score
 }
 
+// addAccessibilityFields adds the accessibility search filters to a clip
+// document. Clips without accessibility metadata index as uncaptioned.
+func addAccessibilityFields(doc map[string]interface{}, clip *models.Clip) {
+	var accessibility models.ClipAccessibility
+	if clip.Accessibility != nil {
+		accessibility = *clip.Accessibility
+	}
+	doc["has_captions"] = accessibility.HasCaptions
+	doc["photosensitivity_warning"] = accessibility.PhotosensitivityWarning
+	doc["audio_description"] = accessibility.AudioDescription
+}
+
 // BulkIndexClips indexes multiple clips in a batch
 func (s *SearchIndexerService) BulkIndexClips(ctx context.Context, clips []models.Clip) error {
 	if len(clips) == 0 {
@@ -284,56 +502,48 @@ func (s *SearchIndexerService) BulkIndexClips(ctx context.Context, clips []model
 	}
 
 	var buf bytes.Buffer
-	for _, clip := range clips {
-		// Action metadata
+	for i := range clips {
+		if err := s.writeClipBulkAction(&buf, ClipsIndex, &clips[i]); err != nil {
+			return err
+		}
+	}
+
+	return s.bulkRequest(ctx, &buf)
+}
+
+// UpdateClipEmbeddings sets the kNN vectors of already indexed clips. It is a
+// no-op unless kNN indexing is enabled.
+func (s *SearchIndexerService) UpdateClipEmbeddings(ctx context.Context, embeddings map[uuid.UUID][]float32) error {
+	if !s.knnEnabled || len(embeddings) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for clipID, embedding := range embeddings {
+		if len(embedding) != EmbeddingDimensions {
+			continue
+		}
 		meta := map[string]interface{}{
-			"index": map[string]interface{}{
+			"update": map[string]interface{}{
 				"_index": ClipsIndex,
-				"_id":    clip.ID.String(),
+				"_id":    clipID.String(),
 			},
 		}
 		metaJSON, _ := json.Marshal(meta)
 		buf.Write(metaJSON)
 		buf.WriteByte('\n')
 
-		// Document data
-		engagementScore := calculateEngagementScore(&clip)
-		recencyScore := calculateRecencyScore(&clip)
-
-		doc := map[string]interface{}{
-			"id":               clip.ID.String(),
-			"twitch_clip_id":   clip.TwitchClipID,
-			"title":            clip.Title,
-			"creator_name":     clip.CreatorName,
-			"creator_id":       clip.CreatorID,
-			"broadcaster_name": clip.BroadcasterName,
-			"broadcaster_id":   clip.BroadcasterID,
-			"game_id":          clip.GameID,
-			"game_name":        clip.GameName,
-			"language":         clip.Language,
-			"view_count":       clip.ViewCount,
-			"vote_score":       clip.VoteScore,
-			"comment_count":    clip.CommentCount,
-			"favorite_count":   clip.FavoriteCount,
-			"is_featured":      clip.IsFeatured,
-			"is_nsfw":          clip.IsNSFW,
-			"is_removed":       clip.IsRemoved,
-			"created_at":       clip.CreatedAt,
-			"imported_at":      clip.ImportedAt,
-			"engagement_score": engagementScore,
-			"recency_score":    recencyScore,
-		}
-
-		// Include submitted_by_user_id so we can filter out unsubmitted clips
-		if clip.SubmittedByUserID != nil {
-			doc["submitted_by_user_id"] = clip.SubmittedByUserID.String()
-		}
-
-		docJSON, _ := json.Marshal(doc)
+		docJSON, _ := json.Marshal(map[string]interface{}{
+			"doc": map[string]interface{}{clipEmbeddingField: embedding},
+		})
 		buf.Write(docJSON)
 		buf.WriteByte('\n')
 	}
 
+	if buf.Len() == 0 {
+		return nil
+	}
+
 	return s.bulkRequest(ctx, &buf)
 }
 
@@ -369,27 +579,107 @@ func (s *SearchIndexerService) bulkRequest(ctx context.Context, body *bytes.Buff
 
 // Index mapping definitions with proper analyzers
 
+// clipIndexMapping returns the clips index mapping, with the kNN vector field
+// when embeddings are indexed
+func (s *SearchIndexerService) clipIndexMapping() string {
+	mapping := getClipIndexMapping()
+	if s.knnEnabled {
+		mapping = getClipIndexMappingWithKNN()
+	}
+	return withSearchSynonyms(mapping, searchSynonymFields[ClipsIndex], s.synonymRules())
+}
+
+// userIndexMapping returns the users index mapping with search synonyms
+func (s *SearchIndexerService) userIndexMapping() string {
+	return withSearchSynonyms(getUserIndexMapping(), searchSynonymFields[UsersIndex], s.synonymRules())
+}
+
+// gameIndexMapping returns the games index mapping with search synonyms
+func (s *SearchIndexerService) gameIndexMapping() string {
+	return withSearchSynonyms(getGameIndexMapping(), searchSynonymFields[GamesIndex], s.synonymRules())
+}
+
+// tagIndexMapping returns the tags index mapping with search synonyms
+func (s *SearchIndexerService) tagIndexMapping() string {
+	return withSearchSynonyms(getTagIndexMapping(), searchSynonymFields[TagsIndex], s.synonymRules())
+}
+
+// withSearchSynonyms adds a search analyzer that expands synonyms to the
+// given text fields and records the rules version in the mapping metadata.
+// synonym_graph handles multi-word synonyms but only works at search time,
+// so documents are indexed as before. Without rules the analyzer matches the
+// standard analyzer.
+func withSearchSynonyms(base string, fields []string, rules *models.SearchSynonymRules) string {
+	var mapping map[string]interface{}
+	// Base mappings are constants, so decoding cannot fail
+	_ = json.Unmarshal([]byte(base), &mapping)
+
+	analysis := mapping["settings"].(map[string]interface{})["analysis"].(map[string]interface{})
+	filters := []string{"lowercase"}
+	if len(rules.Rules) > 0 {
+		analysis["filter"] = map[string]interface{}{
+			searchSynonymFilter: map[string]interface{}{
+				"type":     "synonym_graph",
+				"synonyms": rules.Rules,
+				"lenient":  true,
+			},
+		}
+		filters = append(filters, searchSynonymFilter)
+	}
+	analysis["analyzer"].(map[string]interface{})[synonymSearchAnalyzer] = map[string]interface{}{
+		"type":      "custom",
+		"tokenizer": "standard",
+		"filter":    filters,
+	}
+
+	mappings := mapping["mappings"].(map[string]interface{})
+	properties := mappings["properties"].(map[string]interface{})
+	for _, field := range fields {
+		if property, ok := properties[field].(map[string]interface{}); ok {
+			property["search_analyzer"] = synonymSearchAnalyzer
+		}
+	}
+	mappings["_meta"] = map[string]interface{}{synonymsVersionMeta: rules.Version}
+
+	data, _ := json.Marshal(mapping)
+	return string(data)
+}
+
+// getClipIndexMappingWithKNN extends the clips mapping with an HNSW vector
+// field for clip embeddings. The Lucene engine applies search filters during
+// the kNN search instead of after it.
+func getClipIndexMappingWithKNN() string {
+	var mapping map[string]interface{}
+	// The base mapping is a constant, so decoding cannot fail
+	_ = json.Unmarshal([]byte(getClipIndexMapping()), &mapping)
+
+	settings := mapping["settings"].(map[string]interface{})
+	settings["index"].(map[string]interface{})["knn"] = true
+
+	properties := mapping["mappings"].(map[string]interface{})["properties"].(map[string]interface{})
+	properties[clipEmbeddingField] = map[string]interface{}{
+		"type":      "knn_vector",
+		"dimension": EmbeddingDimensions,
+		"method": map[string]interface{}{
+			"name":       "hnsw",
+			"space_type": "cosinesimil",
+			"engine":     "lucene",
+			"parameters": map[string]interface{}{
+				"m":               16,
+				"ef_construction": 128,
+			},
+		},
+	}
+
+	data, _ := json.Marshal(mapping)
+	return string(data)
+}
+
 func getClipIndexMapping() string {
 	return `{
 "settings": {
 "analysis": {
 "analyzer": {
-"english_analyzer": {
-"type": "standard",
-"stopwords": "_english_"
-},
-"spanish_analyzer": {
-"type": "standard",
-"stopwords": "_spanish_"
-},
-"french_analyzer": {
-"type": "standard",
-"stopwords": "_french_"
-},
-"german_analyzer": {
-"type": "standard",
-"stopwords": "_german_"
-},
 "standard_multilang": {
 "type": "standard"
 }
@@ -409,12 +699,16 @@ func getClipIndexMapping() string {
 "analyzer": "standard_multilang",
 "fields": {
 "keyword": {"type": "keyword"},
-"en": {"type": "text", "analyzer": "english_analyzer"},
-"es": {"type": "text", "analyzer": "spanish_analyzer"},
-"fr": {"type": "text", "analyzer": "french_analyzer"},
-"de": {"type": "text", "analyzer": "german_analyzer"}
+"en": {"type": "text", "analyzer": "english"},
+"es": {"type": "text", "analyzer": "spanish"},
+"pt": {"type": "text", "analyzer": "portuguese"},
+"de": {"type": "text", "analyzer": "german"},
+"fr": {"type": "text", "analyzer": "french"},
+"ja": {"type": "text", "analyzer": "cjk"},
+"ko": {"type": "text", "analyzer": "cjk"}
 }
 },
+"title_language": {"type": "keyword"},
 "creator_name": {
 "type": "text",
 "analyzer": "standard_multilang",
@@ -441,16 +735,21 @@ func getClipIndexMapping() string {
 },
 "language": {"type": "keyword"},
 "view_count": {"type": "integer"},
+"duration": {"type": "float"},
 "vote_score": {"type": "integer"},
 "comment_count": {"type": "integer"},
 "favorite_count": {"type": "integer"},
 "is_featured": {"type": "boolean"},
 "is_nsfw": {"type": "boolean"},
 "is_removed": {"type": "boolean"},
+"has_captions": {"type": "boolean"},
+"photosensitivity_warning": {"type": "boolean"},
+"audio_description": {"type": "boolean"},
 "created_at": {"type": "date"},
 "imported_at": {"type": "date"},
 "engagement_score": {"type": "float"},
-"recency_score": {"type": "float"}
+"recency_score": {"type": "float"},
+"hype_score": {"type": "float"}
 }
 }
 }`
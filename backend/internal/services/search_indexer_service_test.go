@@ -1,6 +1,9 @@
 package services
 
 import (
+	"bytes"
+	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
@@ -216,3 +219,140 @@ func TestEngagementScoreWeights(t *testing.T) {
 	assert.Equal(t, expected, score,
 		"Engagement score calculation should match expected weights")
 }
+
+func TestClipIndexMappingWithKNN(t *testing.T) {
+	indexer := &SearchIndexerService{}
+	assert.NotContains(t, indexer.clipIndexMapping(), "knn_vector")
+
+	indexer.EnableKNN(0)
+	assert.Equal(t, defaultRRFRankConstant, indexer.rrfRankConstant)
+
+	var mapping map[string]interface{}
+	if !assert.NoError(t, json.Unmarshal([]byte(indexer.clipIndexMapping()), &mapping)) {
+		return
+	}
+	settings := mapping["settings"].(map[string]interface{})["index"].(map[string]interface{})
+	assert.Equal(t, true, settings["knn"])
+
+	properties := mapping["mappings"].(map[string]interface{})["properties"].(map[string]interface{})
+	embedding := properties[clipEmbeddingField].(map[string]interface{})
+	assert.Equal(t, "knn_vector", embedding["type"])
+	assert.Equal(t, float64(EmbeddingDimensions), embedding["dimension"])
+	assert.Contains(t, properties, "has_captions", "kNN mapping must keep the base fields")
+}
+
+func TestClipIndexMappingLanguageAnalyzers(t *testing.T) {
+	indexer := &SearchIndexerService{}
+
+	var mapping map[string]interface{}
+	if !assert.NoError(t, json.Unmarshal([]byte(indexer.clipIndexMapping()), &mapping)) {
+		return
+	}
+	properties := mapping["mappings"].(map[string]interface{})["properties"].(map[string]interface{})
+	titleFields := properties["title"].(map[string]interface{})["fields"].(map[string]interface{})
+	for _, language := range SearchLanguages {
+		assert.Contains(t, titleFields, language)
+	}
+	assert.Equal(t, "portuguese", titleFields["pt"].(map[string]interface{})["analyzer"])
+	assert.Equal(t, "keyword", properties["title_language"].(map[string]interface{})["type"])
+
+	language := "pt-br"
+	doc := indexer.buildClipDocument(&models.Clip{Title: "pentakill", Language: &language})
+	assert.Equal(t, "pt", doc["title_language"])
+}
+
+func TestIndexMappingsWithSynonyms(t *testing.T) {
+	indexer := &SearchIndexerService{}
+
+	decode := func(raw string) (analysis, properties, meta map[string]interface{}) {
+		var mapping map[string]interface{}
+		if !assert.NoError(t, json.Unmarshal([]byte(raw), &mapping)) {
+			t.FailNow()
+		}
+		analysis = mapping["settings"].(map[string]interface{})["analysis"].(map[string]interface{})
+		mappings := mapping["mappings"].(map[string]interface{})
+		return analysis, mappings["properties"].(map[string]interface{}), mappings["_meta"].(map[string]interface{})
+	}
+
+	// Without synonyms the search analyzer is plain and no filter is defined
+	analysis, properties, meta := decode(indexer.gameIndexMapping())
+	assert.NotContains(t, analysis, "filter")
+	assert.Equal(t, synonymSearchAnalyzer, properties["name"].(map[string]interface{})["search_analyzer"])
+	assert.Equal(t, BuildSearchSynonymRules(nil).Version, meta[synonymsVersionMeta])
+
+	rules := BuildSearchSynonymRules([]models.SearchSynonym{
+		{Kind: models.SearchSynonymEquivalent, Terms: []string{"gta", "grand theft auto v"}, Enabled: true},
+	})
+	indexer.SetSynonyms(rules)
+	indexer.EnableKNN(0)
+
+	analysis, properties, meta = decode(indexer.clipIndexMapping())
+	filter := analysis["filter"].(map[string]interface{})[searchSynonymFilter].(map[string]interface{})
+	assert.Equal(t, "synonym_graph", filter["type"])
+	assert.Equal(t, []interface{}{"gta, grand theft auto v"}, filter["synonyms"])
+	analyzer := analysis["analyzer"].(map[string]interface{})[synonymSearchAnalyzer].(map[string]interface{})
+	assert.Equal(t, []interface{}{"lowercase", searchSynonymFilter}, analyzer["filter"])
+	assert.Equal(t, rules.Version, meta[synonymsVersionMeta])
+
+	for _, field := range searchSynonymFields[ClipsIndex] {
+		property := properties[field].(map[string]interface{})
+		assert.Equal(t, synonymSearchAnalyzer, property["search_analyzer"], field)
+		assert.Equal(t, "standard_multilang", property["analyzer"], field)
+	}
+	assert.Contains(t, properties, clipEmbeddingField, "Synonyms must keep the kNN field")
+}
+
+func TestHybridSearchPipelineBody(t *testing.T) {
+	var pipeline map[string]interface{}
+	assert.NoError(t, json.Unmarshal(hybridSearchPipelineBody(40), &pipeline))
+
+	processors := pipeline["phase_results_processors"].([]interface{})
+	ranker := processors[0].(map[string]interface{})["score-ranker-processor"].(map[string]interface{})
+	combination := ranker["combination"].(map[string]interface{})
+	assert.Equal(t, "rrf", combination["technique"])
+	assert.Equal(t, float64(40), combination["rank_constant"])
+}
+
+func TestWriteClipBulkAction(t *testing.T) {
+	withEmbedding := models.Clip{ID: uuid.New(), Embedding: make([]float32, EmbeddingDimensions)}
+	withoutEmbedding := models.Clip{ID: uuid.New()}
+
+	actions := func(indexer *SearchIndexerService, clip *models.Clip) (string, map[string]interface{}) {
+		var buf bytes.Buffer
+		assert.NoError(t, indexer.writeClipBulkAction(&buf, ClipsIndex, clip))
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if !assert.Len(t, lines, 2) {
+			t.FailNow()
+		}
+		var meta, body map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(lines[0]), &meta))
+		assert.NoError(t, json.Unmarshal([]byte(lines[1]), &body))
+		for action := range meta {
+			return action, body
+		}
+		return "", body
+	}
+
+	t.Run("kNN disabled indexes without vector", func(t *testing.T) {
+		action, body := actions(&SearchIndexerService{}, &withEmbedding)
+		assert.Equal(t, "index", action)
+		assert.NotContains(t, body, clipEmbeddingField)
+	})
+
+	t.Run("kNN enabled indexes vector", func(t *testing.T) {
+		indexer := &SearchIndexerService{}
+		indexer.EnableKNN(60)
+		action, body := actions(indexer, &withEmbedding)
+		assert.Equal(t, "index", action)
+		assert.Len(t, body[clipEmbeddingField], EmbeddingDimensions)
+	})
+
+	t.Run("kNN enabled keeps indexed vector of clips loaded without one", func(t *testing.T) {
+		indexer := &SearchIndexerService{}
+		indexer.EnableKNN(60)
+		action, body := actions(indexer, &withoutEmbedding)
+		assert.Equal(t, "update", action)
+		assert.Equal(t, true, body["doc_as_upsert"])
+		assert.NotContains(t, body["doc"], clipEmbeddingField)
+	})
+}
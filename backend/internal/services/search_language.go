@@ -0,0 +1,162 @@
+package services
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// SearchLanguages are the languages clip titles are analyzed in. Each has a
+// title.<code> field in the clips index.
+var SearchLanguages = []string{"en", "es", "pt", "de", "fr", "ja", "ko"}
+
+// preferredLanguageBoost is added to the relevance of clips whose title is
+// in the searcher's preferred language
+const preferredLanguageBoost = 2.0
+
+// languageStopwords are frequent words that mark a Latin-script title as
+// written in a language. Words common to several languages count for each.
+var languageStopwords = map[string]map[string]bool{
+	"en": wordSet("the and is of to in this that with what how my you for when it was on he she they just best his her"),
+	"es": wordSet("el la los las de que y en es un una por con para del al muy pero cuando mejor como este esta lo se"),
+	"pt": wordSet("o os a as do da dos das de um uma não com é no na que mais muito quando melhor isso esse essa pra para"),
+	"de": wordSet("der die das und ist nicht ein eine mit ich auf zu den dem wie so auch bester beste wenn mein"),
+	"fr": wordSet("le la les des et est un une du au pas avec ce cette je il que qui pour dans sur mon meilleur quand"),
+}
+
+// languageLetters are letters that mark a Latin-script title as written in
+// a language; they count more than stopwords
+var languageLetters = map[string]string{
+	"es": "ñ¿¡",
+	"pt": "ãõç",
+	"de": "ßäöü",
+	"fr": "èêëàâùûœç",
+}
+
+func wordSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(words) {
+		set[word] = true
+	}
+	return set
+}
+
+// IsSearchLanguage reports whether titles are analyzed in the language code
+func IsSearchLanguage(code string) bool {
+	for _, language := range SearchLanguages {
+		if language == code {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeSearchLanguage reduces a language code such as Twitch's "pt-br"
+// to its search language, or "" when titles aren't analyzed in it
+func NormalizeSearchLanguage(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if i := strings.IndexAny(code, "-_"); i >= 0 {
+		code = code[:i]
+	}
+	if !IsSearchLanguage(code) {
+		return ""
+	}
+	return code
+}
+
+// DetectLanguage guesses the search language text is written in, or returns
+// "" when unsure. Japanese and Korean are told apart by script; Latin-script
+// languages by their stopwords and letters. Short or ambiguous text, such as
+// a title of names alone, stays undetected.
+func DetectLanguage(text string) string {
+	var kana, hangul int
+	for _, r := range text {
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			kana++
+		case unicode.Is(unicode.Hangul, r):
+			hangul++
+		}
+	}
+	if kana > 0 || hangul > 0 {
+		if kana >= hangul {
+			return "ja"
+		}
+		return "ko"
+	}
+
+	lower := strings.ToLower(text)
+	scores := make(map[string]int)
+	words := strings.FieldsFunc(lower, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, word := range words {
+		for language, stopwords := range languageStopwords {
+			if stopwords[word] {
+				scores[language]++
+			}
+		}
+	}
+	for language, letters := range languageLetters {
+		for _, r := range lower {
+			if strings.ContainsRune(letters, r) {
+				scores[language] += 2
+			}
+		}
+	}
+
+	best, bestScore, tied := "", 0, false
+	for _, language := range SearchLanguages {
+		switch score := scores[language]; {
+		case score > bestScore:
+			best, bestScore, tied = language, score, false
+		case score == bestScore && score > 0:
+			tied = true
+		}
+	}
+	if tied {
+		return ""
+	}
+	return best
+}
+
+// clipTitleLanguage returns the language a clip's title is indexed in: the
+// detected language of the title, else the clip's broadcast language when
+// titles are analyzed in it
+func clipTitleLanguage(clip *models.Clip) string {
+	if language := DetectLanguage(clip.PreferredTitle()); language != "" {
+		return language
+	}
+	if clip.Language != nil {
+		return NormalizeSearchLanguage(*clip.Language)
+	}
+	return ""
+}
+
+// ResolveQueryLanguage returns the search language a search's query is
+// written in: the language filtered on, else the language detected from the
+// query, else the preferred language. It returns "" when none applies.
+func ResolveQueryLanguage(req *models.SearchRequest) string {
+	if req.Language != nil {
+		if language := NormalizeSearchLanguage(*req.Language); language != "" {
+			return language
+		}
+	}
+	if language := DetectLanguage(req.Query); language != "" {
+		return language
+	}
+	if req.PreferredLanguage != nil {
+		return NormalizeSearchLanguage(*req.PreferredLanguage)
+	}
+	return ""
+}
+
+// clipQueryLanguage returns the language whose title analyzer matches req's
+// query, using the one hybrid search resolved when set
+func clipQueryLanguage(req *models.SearchRequest) string {
+	if req.QueryLanguage != "" {
+		return NormalizeSearchLanguage(req.QueryLanguage)
+	}
+	return ResolveQueryLanguage(req)
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"the best clutch of the year", "en"},
+		{"la mejor jugada del año", "es"},
+		{"não acredito nessa jogada", "pt"},
+		{"das ist der beste Clip", "de"},
+		{"c'est le meilleur clip de la soirée", "fr"},
+		{"神プレイすぎる", "ja"},
+		{"역대급 클러치", "ko"},
+		{"xQc pentakill", ""},
+		{"de", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, DetectLanguage(tt.text), tt.text)
+	}
+}
+
+func TestNormalizeSearchLanguage(t *testing.T) {
+	assert.Equal(t, "pt", NormalizeSearchLanguage("pt-br"))
+	assert.Equal(t, "en", NormalizeSearchLanguage(" EN_gb "))
+	assert.Equal(t, "ko", NormalizeSearchLanguage("ko"))
+	assert.Empty(t, NormalizeSearchLanguage("zh"))
+	assert.Empty(t, NormalizeSearchLanguage(""))
+}
+
+func TestResolveQueryLanguage(t *testing.T) {
+	es, ptBR, zh := "es", "pt-BR", "zh"
+
+	assert.Equal(t, "es", ResolveQueryLanguage(&models.SearchRequest{Query: "the best clutch", Language: &es}))
+	assert.Equal(t, "en", ResolveQueryLanguage(&models.SearchRequest{Query: "the best clutch", PreferredLanguage: &ptBR}))
+	assert.Equal(t, "pt", ResolveQueryLanguage(&models.SearchRequest{Query: "pentakill", PreferredLanguage: &ptBR}))
+	assert.Empty(t, ResolveQueryLanguage(&models.SearchRequest{Query: "pentakill", Language: &zh}))
+}
+
+func TestClipTitleLanguage(t *testing.T) {
+	ja := "ja"
+	assert.Equal(t, "es", clipTitleLanguage(&models.Clip{Title: "la mejor jugada", Language: &ja}))
+	assert.Equal(t, "ja", clipTitleLanguage(&models.Clip{Title: "xQc pentakill", Language: &ja}))
+	assert.Empty(t, clipTitleLanguage(&models.Clip{Title: "xQc pentakill"}))
+}
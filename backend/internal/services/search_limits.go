@@ -260,8 +260,12 @@ func getAllowedSearchFields() map[string]bool {
 		// Language-specific title fields for better search relevance
 		"title.en", // English
 		"title.es", // Spanish
-		"title.fr", // French
+		"title.pt", // Portuguese
 		"title.de", // German
+		"title.fr", // French
+		"title.ja", // Japanese
+		"title.ko", // Korean
+		"title_language",
 		"creator_name",
 		"creator_id",
 		"broadcaster_name",
@@ -280,6 +284,7 @@ func getAllowedSearchFields() map[string]bool {
 		"is_nsfw",
 		"engagement_score",
 		"recency_score",
+		"hype_score",
 		// User fields
 		"username",
 		"display_name",
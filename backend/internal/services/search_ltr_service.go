@@ -0,0 +1,426 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// LTRCandidates is how many fused candidates the learning-to-rank model
+// re-scores. Pages beyond them keep the fused ranking.
+const LTRCandidates = 100
+
+const (
+	// ltrTrafficKey buckets users for the A/B test the way feature flags do
+	ltrTrafficKey = "search.ltr"
+
+	// ltrCTRWindow is how far back clicks count towards a clip's CTR
+	ltrCTRWindow = 90 * 24 * time.Hour
+
+	// ltrCTRPriorSearches smooths CTRs so a click on a rarely searched
+	// query doesn't count as a perfect click-through rate
+	ltrCTRPriorSearches = 10
+
+	// ltrRecencyHalfLife is the age at which a clip's recency halves
+	ltrRecencyHalfLife = 14 * 24 * time.Hour
+)
+
+// LTRFeatureNames are the features of a search candidate, in the order
+// models weigh them:
+//   - rank: the candidate's fused rank scaled to (0,1]
+//   - historical_ctr: the share of the query's searches that clicked the clip
+//   - engagement: log(1 + vote score, if positive, + comments + favorites)
+//   - recency: 1 for a new clip, halving every 14 days
+//   - follow_affinity: +1 for a followed broadcaster, +1 for a followed game
+//     and -1 for a consistently downvoted game
+var LTRFeatureNames = []string{"rank", "historical_ctr", "engagement", "recency", "follow_affinity"}
+
+// ErrInvalidLTRModel is returned for models that don't match LTRFeatureNames
+var ErrInvalidLTRModel = errors.New("invalid learning-to-rank model")
+
+// LTRModel is a logistic regression over standardized candidate features.
+// Candidates are ranked by Score; as only the order matters, the logistic
+// function isn't applied.
+type LTRModel struct {
+	Version   string             `json:"version"`
+	TrainedAt time.Time          `json:"trained_at"`
+	Features  []string           `json:"features"`
+	Means     []float64          `json:"means"`
+	Scales    []float64          `json:"scales"`
+	Weights   []float64          `json:"weights"`
+	Bias      float64            `json:"bias"`
+	Report    *LTRTrainingReport `json:"report,omitempty"`
+}
+
+// Validate checks that the model weighs LTRFeatureNames in order, with a
+// positive scale for each
+func (m *LTRModel) Validate() error {
+	if !slices.Equal(m.Features, LTRFeatureNames) {
+		return fmt.Errorf("%w: features %v, want %v", ErrInvalidLTRModel, m.Features, LTRFeatureNames)
+	}
+	n := len(LTRFeatureNames)
+	if len(m.Means) != n || len(m.Scales) != n || len(m.Weights) != n {
+		return fmt.Errorf("%w: means, scales and weights need %d values each", ErrInvalidLTRModel, n)
+	}
+	for i, scale := range m.Scales {
+		if scale <= 0 || math.IsNaN(scale) || math.IsNaN(m.Weights[i]) || math.IsNaN(m.Means[i]) {
+			return fmt.Errorf("%w: %s has an invalid scale or weight", ErrInvalidLTRModel, m.Features[i])
+		}
+	}
+	return nil
+}
+
+// Score rates a candidate by its features; higher ranks first
+func (m *LTRModel) Score(features []float64) float64 {
+	score := m.Bias
+	for i, x := range features {
+		score += m.Weights[i] * (x - m.Means[i]) / m.Scales[i]
+	}
+	return score
+}
+
+// LoadLTRModel reads and validates a model exported by train-ltr
+func LoadLTRModel(path string) (*LTRModel, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read learning-to-rank model: %w", err)
+	}
+	var model LTRModel
+	if err := json.Unmarshal(data, &model); err != nil {
+		return nil, fmt.Errorf("failed to parse learning-to-rank model: %w", err)
+	}
+	if err := model.Validate(); err != nil {
+		return nil, err
+	}
+	return &model, nil
+}
+
+// SearchLTRRepositoryInterface defines the click history the
+// learning-to-rank features are computed from
+type SearchLTRRepositoryInterface interface {
+	GetQueryClipClicks(ctx context.Context, query string, clipIDs []uuid.UUID, start, end time.Time) (int, map[uuid.UUID]int, error)
+}
+
+// SearchLTRService re-ranks fused search candidates with a learning-to-rank
+// model, for the share of users in the A/B test's treatment arm
+type SearchLTRService struct {
+	repo           SearchLTRRepositoryInterface
+	model          *LTRModel
+	trafficPercent int
+	now            func() time.Time
+}
+
+// NewSearchLTRService creates a new learning-to-rank service. trafficPercent
+// of signed-in users, bucketed by user ID, get the model's ranking; anonymous
+// searches only do at 100. Training builds features with a nil model.
+func NewSearchLTRService(repo SearchLTRRepositoryInterface, model *LTRModel, trafficPercent int) *SearchLTRService {
+	return &SearchLTRService{
+		repo:           repo,
+		model:          model,
+		trafficPercent: trafficPercent,
+		now:            time.Now,
+	}
+}
+
+// Ranker returns the A/B arm of a search by userID
+func (s *SearchLTRService) Ranker(userID *uuid.UUID) string {
+	switch {
+	case s.trafficPercent >= 100:
+		return models.SearchRankerLTR
+	case s.trafficPercent <= 0 || userID == nil:
+		return models.SearchRankerControl
+	case ltrBucket(*userID) < s.trafficPercent:
+		return models.SearchRankerLTR
+	default:
+		return models.SearchRankerControl
+	}
+}
+
+// ltrBucket places a user in [0, 100), independently of feature flag buckets
+func ltrBucket(userID uuid.UUID) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(ltrTrafficKey))
+	_, _ = h.Write([]byte{':'})
+	_, _ = h.Write(userID[:])
+	return int(h.Sum32() % 100)
+}
+
+// Features returns the features of clips, in their fused order, as searched
+// for query at the given time. CTRs only count searches before then.
+func (s *SearchLTRService) Features(ctx context.Context, query string, clips []models.Clip, profile *models.SearchPersonalizationProfile, at time.Time) ([][]float64, error) {
+	clipIDs := make([]uuid.UUID, len(clips))
+	for i, clip := range clips {
+		clipIDs[i] = clip.ID
+	}
+	searches, clicks, err := s.repo.GetQueryClipClicks(ctx, query, clipIDs, at.Add(-ltrCTRWindow), at)
+	if err != nil {
+		return nil, err
+	}
+
+	features := make([][]float64, len(clips))
+	for i, clip := range clips {
+		ctr := float64(clicks[clip.ID]) / float64(searches+ltrCTRPriorSearches)
+		features[i] = ltrFeatureVector(i, len(clips), clip, ctr, profile, at)
+	}
+	return features, nil
+}
+
+func ltrFeatureVector(position, n int, clip models.Clip, ctr float64, profile *models.SearchPersonalizationProfile, at time.Time) []float64 {
+	rank := 1.0 - float64(position)/float64(n)
+
+	engagement := math.Log1p(float64(max(clip.VoteScore, 0) + clip.CommentCount + clip.FavoriteCount))
+
+	age := max(at.Sub(clip.CreatedAt), 0)
+	recency := math.Exp2(-float64(age) / float64(ltrRecencyHalfLife))
+
+	var affinity float64
+	if !profile.IsEmpty() {
+		if clip.BroadcasterID != nil && profile.FollowedBroadcasters[*clip.BroadcasterID] {
+			affinity++
+		}
+		if clip.GameID != nil {
+			if profile.FollowedGames[*clip.GameID] {
+				affinity++
+			}
+			if profile.DownvotedGames[*clip.GameID] {
+				affinity--
+			}
+		}
+	}
+
+	return []float64{rank, ctr, engagement, recency, affinity}
+}
+
+// Rerank orders the fused candidates by the model's score. The sort is
+// stable, so ties keep their fused order.
+func (s *SearchLTRService) Rerank(ctx context.Context, query string, clips []models.Clip, profile *models.SearchPersonalizationProfile) ([]models.Clip, error) {
+	if s.model == nil {
+		return nil, fmt.Errorf("%w: no model loaded", ErrInvalidLTRModel)
+	}
+	if len(clips) < 2 {
+		return clips, nil
+	}
+
+	features, err := s.Features(ctx, query, clips, profile, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	type scoredClip struct {
+		clip  models.Clip
+		score float64
+	}
+	scored := make([]scoredClip, len(clips))
+	for i, clip := range clips {
+		scored[i] = scoredClip{clip: clip, score: s.model.Score(features[i])}
+	}
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].score > scored[j].score
+	})
+
+	reranked := make([]models.Clip, len(scored))
+	for i, sc := range scored {
+		reranked[i] = sc.clip
+	}
+	return reranked, nil
+}
+
+// LTRExample is one candidate of a training search. SearchID groups the
+// candidates of a search, which are kept in their fused order.
+type LTRExample struct {
+	SearchID string
+	Features []float64
+	Clicked  bool
+}
+
+// LTRTrainingOptions tunes model training
+type LTRTrainingOptions struct {
+	Epochs       int     // Full passes of gradient descent
+	LearningRate float64 // Step size
+	L2           float64 // Weight regularization
+	HoldoutShare float64 // Share (0-1) of searches held out to evaluate the model
+}
+
+// DefaultLTRTrainingOptions returns the options train-ltr uses by default
+func DefaultLTRTrainingOptions() LTRTrainingOptions {
+	return LTRTrainingOptions{Epochs: 500, LearningRate: 0.1, L2: 0.001, HoldoutShare: 0.2}
+}
+
+// LTRTrainingReport describes the data a model was trained on and how it
+// ranks the held-out searches' clicked clips compared with the fused ranking
+type LTRTrainingReport struct {
+	Searches        int     `json:"searches"`
+	Examples        int     `json:"examples"`
+	Positives       int     `json:"positives"`
+	LogLoss         float64 `json:"log_loss"`
+	HoldoutSearches int     `json:"holdout_searches"`
+	BaselineMRR     float64 `json:"baseline_mrr"`
+	ModelMRR        float64 `json:"model_mrr"`
+}
+
+// TrainLTRModel fits a logistic regression predicting clicks from the
+// examples' features. Searches are split into training and holdout sets by
+// a hash of their ID, so reruns on the same data split them the same way.
+func TrainLTRModel(examples []LTRExample, opts LTRTrainingOptions) (*LTRModel, error) {
+	var train, holdout []LTRExample
+	trainSearches := map[string]bool{}
+	holdoutSearches := map[string]bool{}
+	for _, ex := range examples {
+		if len(ex.Features) != len(LTRFeatureNames) {
+			return nil, fmt.Errorf("%w: example has %d features, want %d", ErrInvalidLTRModel, len(ex.Features), len(LTRFeatureNames))
+		}
+		if ltrHeldOut(ex.SearchID, opts.HoldoutShare) {
+			holdout = append(holdout, ex)
+			holdoutSearches[ex.SearchID] = true
+		} else {
+			train = append(train, ex)
+			trainSearches[ex.SearchID] = true
+		}
+	}
+
+	positives := 0
+	for _, ex := range train {
+		if ex.Clicked {
+			positives++
+		}
+	}
+	if positives == 0 || positives == len(train) {
+		return nil, errors.New("training needs both clicked and unclicked candidates")
+	}
+
+	model := &LTRModel{
+		Features: slices.Clone(LTRFeatureNames),
+		Weights:  make([]float64, len(LTRFeatureNames)),
+	}
+	model.Means, model.Scales = ltrStandardization(train)
+
+	n := float64(len(train))
+	gradient := make([]float64, len(model.Weights))
+	for epoch := 0; epoch < opts.Epochs; epoch++ {
+		clear(gradient)
+		var biasGradient float64
+		for _, ex := range train {
+			residual := ltrSigmoid(model.Score(ex.Features)) - ltrLabel(ex)
+			for i, x := range ex.Features {
+				gradient[i] += residual * (x - model.Means[i]) / model.Scales[i]
+			}
+			biasGradient += residual
+		}
+		for i := range model.Weights {
+			model.Weights[i] -= opts.LearningRate * (gradient[i]/n + opts.L2*model.Weights[i])
+		}
+		model.Bias -= opts.LearningRate * biasGradient / n
+	}
+
+	var logLoss float64
+	for _, ex := range train {
+		p := math.Min(math.Max(ltrSigmoid(model.Score(ex.Features)), 1e-12), 1-1e-12)
+		if ex.Clicked {
+			logLoss -= math.Log(p)
+		} else {
+			logLoss -= math.Log(1 - p)
+		}
+	}
+
+	baselineMRR, modelMRR := ltrHoldoutMRR(holdout, model)
+	model.Report = &LTRTrainingReport{
+		Searches:        len(trainSearches),
+		Examples:        len(train),
+		Positives:       positives,
+		LogLoss:         logLoss / n,
+		HoldoutSearches: len(holdoutSearches),
+		BaselineMRR:     baselineMRR,
+		ModelMRR:        modelMRR,
+	}
+	return model, nil
+}
+
+func ltrHeldOut(searchID string, share float64) bool {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(searchID))
+	return float64(h.Sum32()%100) < share*100
+}
+
+func ltrLabel(ex LTRExample) float64 {
+	if ex.Clicked {
+		return 1
+	}
+	return 0
+}
+
+func ltrSigmoid(x float64) float64 {
+	return 1 / (1 + math.Exp(-x))
+}
+
+// ltrStandardization returns each feature's mean and standard deviation, or
+// a scale of 1 for features that don't vary
+func ltrStandardization(examples []LTRExample) ([]float64, []float64) {
+	means := make([]float64, len(LTRFeatureNames))
+	scales := make([]float64, len(LTRFeatureNames))
+	n := float64(len(examples))
+	for _, ex := range examples {
+		for i, x := range ex.Features {
+			means[i] += x / n
+		}
+	}
+	for _, ex := range examples {
+		for i, x := range ex.Features {
+			scales[i] += (x - means[i]) * (x - means[i]) / n
+		}
+	}
+	for i := range scales {
+		scales[i] = math.Sqrt(scales[i])
+		if scales[i] < 1e-9 {
+			scales[i] = 1
+		}
+	}
+	return means, scales
+}
+
+// ltrHoldoutMRR returns the mean reciprocal rank of the first clicked clip of
+// each held-out search, in fused order and in the model's order
+func ltrHoldoutMRR(examples []LTRExample, model *LTRModel) (float64, float64) {
+	var order []string
+	bySearch := map[string][]LTRExample{}
+	for _, ex := range examples {
+		if _, ok := bySearch[ex.SearchID]; !ok {
+			order = append(order, ex.SearchID)
+		}
+		bySearch[ex.SearchID] = append(bySearch[ex.SearchID], ex)
+	}
+	if len(order) == 0 {
+		return 0, 0
+	}
+
+	var baseline, reranked float64
+	for _, id := range order {
+		candidates := bySearch[id]
+		baseline += ltrReciprocalRank(candidates)
+
+		sorted := slices.Clone(candidates)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return model.Score(sorted[i].Features) > model.Score(sorted[j].Features)
+		})
+		reranked += ltrReciprocalRank(sorted)
+	}
+	return baseline / float64(len(order)), reranked / float64(len(order))
+}
+
+func ltrReciprocalRank(candidates []LTRExample) float64 {
+	for i, ex := range candidates {
+		if ex.Clicked {
+			return 1 / float64(i+1)
+		}
+	}
+	return 0
+}
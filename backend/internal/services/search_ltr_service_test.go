@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// MockSearchLTRRepository is a mock implementation of SearchLTRRepositoryInterface
+type MockSearchLTRRepository struct {
+	mock.Mock
+}
+
+func (m *MockSearchLTRRepository) GetQueryClipClicks(ctx context.Context, query string, clipIDs []uuid.UUID, start, end time.Time) (int, map[uuid.UUID]int, error) {
+	args := m.Called(ctx, query, clipIDs, start, end)
+	if args.Get(1) == nil {
+		return args.Int(0), nil, args.Error(2)
+	}
+	return args.Int(0), args.Get(1).(map[uuid.UUID]int), args.Error(2)
+}
+
+func TestSearchLTRService_Ranker(t *testing.T) {
+	userID := uuid.New()
+	assert.Equal(t, models.SearchRankerLTR, NewSearchLTRService(nil, nil, 100).Ranker(nil))
+	assert.Equal(t, models.SearchRankerControl, NewSearchLTRService(nil, nil, 0).Ranker(&userID))
+	assert.Equal(t, models.SearchRankerControl, NewSearchLTRService(nil, nil, 50).Ranker(nil))
+
+	// Users keep their arm, and about half land in each
+	svc := NewSearchLTRService(nil, nil, 50)
+	assert.Equal(t, svc.Ranker(&userID), svc.Ranker(&userID))
+	inLTR := 0
+	for i := 0; i < 1000; i++ {
+		id := uuid.New()
+		if svc.Ranker(&id) == models.SearchRankerLTR {
+			inLTR++
+		}
+	}
+	assert.InDelta(t, 500, inLTR, 100)
+}
+
+func TestSearchLTRService_Features(t *testing.T) {
+	at := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	broadcaster, game := "b1", "g1"
+	clips := []models.Clip{
+		{ID: uuid.New(), CreatedAt: at, VoteScore: -5, BroadcasterID: &broadcaster, GameID: &game},
+		{ID: uuid.New(), CreatedAt: at.Add(-ltrRecencyHalfLife), VoteScore: 10, CommentCount: 5, FavoriteCount: 4},
+	}
+	repo := new(MockSearchLTRRepository)
+	repo.On("GetQueryClipClicks", mock.Anything, "ace", []uuid.UUID{clips[0].ID, clips[1].ID}, at.Add(-ltrCTRWindow), at).
+		Return(30, map[uuid.UUID]int{clips[1].ID: 8}, nil).Once()
+	profile := models.NewSearchPersonalizationProfile()
+	profile.FollowedBroadcasters[broadcaster] = true
+	profile.DownvotedGames[game] = true
+
+	features, err := NewSearchLTRService(repo, nil, 0).Features(context.Background(), "ace", clips, profile, at)
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+
+	assert.Equal(t, []float64{1, 0, 0, 1, 0}, features[0])
+	assert.InDelta(t, 0.5, features[1][0], 1e-9)
+	assert.InDelta(t, 0.2, features[1][1], 1e-9)
+	assert.InDelta(t, math.Log(20), features[1][2], 1e-9)
+	assert.InDelta(t, 0.5, features[1][3], 1e-9)
+	assert.Zero(t, features[1][4])
+}
+
+func TestSearchLTRService_Rerank(t *testing.T) {
+	clips := []models.Clip{
+		{ID: uuid.New(), CreatedAt: time.Now()},
+		{ID: uuid.New(), CreatedAt: time.Now()},
+		{ID: uuid.New(), CreatedAt: time.Now()},
+	}
+	repo := new(MockSearchLTRRepository)
+	repo.On("GetQueryClipClicks", mock.Anything, "ace", []uuid.UUID{clips[0].ID, clips[1].ID, clips[2].ID}, mock.Anything, mock.Anything).
+		Return(90, map[uuid.UUID]int{clips[2].ID: 50}, nil)
+	model := &LTRModel{
+		Features: LTRFeatureNames,
+		Means:    make([]float64, 5),
+		Scales:   []float64{1, 1, 1, 1, 1},
+		Weights:  []float64{1, 10, 0, 0, 0},
+	}
+	require.NoError(t, model.Validate())
+
+	ranked, err := NewSearchLTRService(repo, model, 100).Rerank(context.Background(), "ace", clips, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{clips[2].ID, clips[0].ID, clips[1].ID}, []uuid.UUID{ranked[0].ID, ranked[1].ID, ranked[2].ID})
+
+	_, err = NewSearchLTRService(repo, nil, 100).Rerank(context.Background(), "ace", clips, nil)
+	assert.ErrorIs(t, err, ErrInvalidLTRModel)
+}
+
+func TestTrainLTRModel(t *testing.T) {
+	// Clicks follow CTR rather than the fused rank
+	var examples []LTRExample
+	for s := 0; s < 200; s++ {
+		id := uuid.New().String()
+		for i := 0; i < 10; i++ {
+			ctr := 0.0
+			if i == (s*7)%10 {
+				ctr = 0.3
+			}
+			examples = append(examples, LTRExample{
+				SearchID: id,
+				Features: []float64{1 - float64(i)/10, ctr, float64(s % 3), 0.5, 0},
+				Clicked:  ctr > 0,
+			})
+		}
+	}
+
+	model, err := TrainLTRModel(examples, DefaultLTRTrainingOptions())
+	require.NoError(t, err)
+	require.NoError(t, model.Validate())
+	assert.Greater(t, model.Weights[1], model.Weights[0])
+	assert.Equal(t, 1.0, model.Scales[3], "constant features keep a unit scale")
+
+	report := model.Report
+	assert.Equal(t, 200, report.Searches+report.HoldoutSearches)
+	assert.Positive(t, report.HoldoutSearches)
+	assert.Greater(t, report.ModelMRR, report.BaselineMRR)
+	assert.InDelta(t, 1.0, report.ModelMRR, 1e-9)
+
+	_, err = TrainLTRModel(nil, DefaultLTRTrainingOptions())
+	assert.Error(t, err)
+}
+
+func TestLoadLTRModel(t *testing.T) {
+	dir := t.TempDir()
+	write := func(model LTRModel) string {
+		data, err := json.Marshal(model)
+		require.NoError(t, err)
+		path := filepath.Join(dir, uuid.New().String()+".json")
+		require.NoError(t, os.WriteFile(path, data, 0o644))
+		return path
+	}
+
+	valid := LTRModel{
+		Version:  "v1",
+		Features: LTRFeatureNames,
+		Means:    make([]float64, 5),
+		Scales:   []float64{1, 1, 1, 1, 1},
+		Weights:  []float64{1, 2, 0.5, 0.1, 0.3},
+	}
+	model, err := LoadLTRModel(write(valid))
+	require.NoError(t, err)
+	assert.Equal(t, "v1", model.Version)
+
+	reordered := valid
+	reordered.Features = []string{"historical_ctr", "rank", "engagement", "recency", "follow_affinity"}
+	_, err = LoadLTRModel(write(reordered))
+	assert.ErrorIs(t, err, ErrInvalidLTRModel)
+
+	zeroScale := valid
+	zeroScale.Scales = []float64{1, 0, 1, 1, 1}
+	_, err = LoadLTRModel(write(zeroScale))
+	assert.ErrorIs(t, err, ErrInvalidLTRModel)
+
+	_, err = LoadLTRModel(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
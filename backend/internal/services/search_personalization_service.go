@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// SearchPersonalizationWeights sets how far personalization moves a clip.
+// Scores are on the rank-scaled (0,1] scale of the results, so a boost of
+// 0.3 lifts a clip about six places on a page of 20.
+type SearchPersonalizationWeights struct {
+	FollowedBroadcasterBoost float64 `json:"followed_broadcaster_boost"`
+	FollowedGameBoost        float64 `json:"followed_game_boost"`
+	DownvotedGamePenalty     float64 `json:"downvoted_game_penalty"`
+	InterestBoost            float64 `json:"interest_boost"`
+	LanguageBoost            float64 `json:"language_boost"`
+}
+
+// ConfiguredPersonalizationWeights returns the personalization weights set
+// by the HYBRID_SEARCH_* environment, or nil when personalization is off
+func ConfiguredPersonalizationWeights(cfg *config.HybridSearchConfig) *SearchPersonalizationWeights {
+	if !cfg.Personalization {
+		return nil
+	}
+	return &SearchPersonalizationWeights{
+		FollowedBroadcasterBoost: cfg.FollowedBroadcasterBoost,
+		FollowedGameBoost:        cfg.FollowedGameBoost,
+		DownvotedGamePenalty:     cfg.DownvotedGamePenalty,
+		InterestBoost:            cfg.InterestBoost,
+		LanguageBoost:            cfg.LanguageBoost,
+	}
+}
+
+// SearchPersonalizer loads the personalization profile of a searching user
+type SearchPersonalizer interface {
+	ProfileFor(ctx context.Context, userID uuid.UUID) (*models.SearchPersonalizationProfile, error)
+}
+
+// SearchPersonalizationRepositoryInterface defines the storage the search
+// personalization service needs
+type SearchPersonalizationRepositoryInterface interface {
+	GetProfile(ctx context.Context, userID uuid.UUID, minDownvotes int, downvoteShare, languageShare float64) (*models.SearchPersonalizationProfile, error)
+}
+
+// SearchPersonalizationService builds search personalization profiles from
+// a user's follows, votes, interests and watch history
+type SearchPersonalizationService struct {
+	repo          SearchPersonalizationRepositoryInterface
+	minDownvotes  int
+	downvoteShare float64
+	languageShare float64
+}
+
+// NewSearchPersonalizationService creates a new search personalization
+// service. A game counts as consistently downvoted once the user has at least
+// minDownvotes downvotes on its clips, making up at least downvoteShare of
+// their votes there. A language is in the profile once at least
+// languageShare of the clips the user watched or upvoted are in it.
+func NewSearchPersonalizationService(repo SearchPersonalizationRepositoryInterface, minDownvotes int, downvoteShare, languageShare float64) *SearchPersonalizationService {
+	if minDownvotes < 1 {
+		minDownvotes = 1
+	}
+	return &SearchPersonalizationService{
+		repo:          repo,
+		minDownvotes:  minDownvotes,
+		downvoteShare: downvoteShare,
+		languageShare: languageShare,
+	}
+}
+
+// ProfileFor returns the user's personalization profile, or nil when they
+// turned personalized search off or have nothing to personalize with
+func (s *SearchPersonalizationService) ProfileFor(ctx context.Context, userID uuid.UUID) (*models.SearchPersonalizationProfile, error) {
+	profile, err := s.repo.GetProfile(ctx, userID, s.minDownvotes, s.downvoteShare, s.languageShare)
+	if err != nil {
+		return nil, err
+	}
+	if profile.IsEmpty() {
+		return nil, nil
+	}
+	return profile, nil
+}
+
+// personalizeClips re-orders clips by their rank scaled to (0,1], plus
+// weights.FollowedBroadcasterBoost for followed broadcasters and
+// weights.FollowedGameBoost for followed games, minus
+// weights.DownvotedGamePenalty for consistently downvoted games. Clips of
+// interests the user doesn't already follow get weights.InterestBoost once,
+// and clips in the user's languages get weights.LanguageBoost. The sort is
+// stable, so clips the profile says nothing about keep their order.
+func personalizeClips(clips []models.Clip, profile *models.SearchPersonalizationProfile, weights SearchPersonalizationWeights) []models.Clip {
+	if profile.IsEmpty() || len(clips) < 2 {
+		return clips
+	}
+
+	type scoredClip struct {
+		clip  models.Clip
+		score float64
+	}
+
+	n := len(clips)
+	scored := make([]scoredClip, n)
+	for i, clip := range clips {
+		score := 1.0 - float64(i)/float64(n)
+		interest := false
+		if clip.BroadcasterID != nil {
+			if profile.FollowedBroadcasters[*clip.BroadcasterID] {
+				score += weights.FollowedBroadcasterBoost
+			} else if profile.InterestBroadcasters[*clip.BroadcasterID] {
+				interest = true
+			}
+		}
+		if clip.GameID != nil {
+			if profile.FollowedGames[*clip.GameID] {
+				score += weights.FollowedGameBoost
+			} else if profile.InterestGames[*clip.GameID] {
+				interest = true
+			}
+			if profile.DownvotedGames[*clip.GameID] {
+				score -= weights.DownvotedGamePenalty
+			}
+		}
+		if interest {
+			score += weights.InterestBoost
+		}
+		if clip.Language != nil && profile.Languages[strings.ToLower(*clip.Language)] {
+			score += weights.LanguageBoost
+		}
+		scored[i] = scoredClip{clip: clip, score: score}
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].score > scored[j].score
+	})
+
+	personalized := make([]models.Clip, n)
+	for i, sc := range scored {
+		personalized[i] = sc.clip
+	}
+	return personalized
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/config"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// MockSearchPersonalizationRepository is a mock implementation of SearchPersonalizationRepositoryInterface
+type MockSearchPersonalizationRepository struct {
+	mock.Mock
+}
+
+func (m *MockSearchPersonalizationRepository) GetProfile(ctx context.Context, userID uuid.UUID, minDownvotes int, downvoteShare, languageShare float64) (*models.SearchPersonalizationProfile, error) {
+	args := m.Called(ctx, userID, minDownvotes, downvoteShare, languageShare)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SearchPersonalizationProfile), args.Error(1)
+}
+
+// MockSearchPersonalizer is a mock implementation of SearchPersonalizer
+type MockSearchPersonalizer struct {
+	mock.Mock
+}
+
+func (m *MockSearchPersonalizer) ProfileFor(ctx context.Context, userID uuid.UUID) (*models.SearchPersonalizationProfile, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SearchPersonalizationProfile), args.Error(1)
+}
+
+func personalizationTestClip(broadcasterID, gameID string) models.Clip {
+	return models.Clip{ID: uuid.New(), BroadcasterID: &broadcasterID, GameID: &gameID}
+}
+
+func TestPersonalizeClips(t *testing.T) {
+	clips := []models.Clip{
+		personalizationTestClip("b1", "downvoted"),
+		personalizationTestClip("b2", "g1"),
+		personalizationTestClip("b3", "g2"),
+		personalizationTestClip("followed", "g1"),
+		personalizationTestClip("b4", "followed-game"),
+	}
+	weights := SearchPersonalizationWeights{
+		FollowedBroadcasterBoost: 0.5,
+		FollowedGameBoost:        0.3,
+		DownvotedGamePenalty:     0.5,
+	}
+	profile := models.NewSearchPersonalizationProfile()
+	profile.FollowedBroadcasters["followed"] = true
+	profile.FollowedGames["followed-game"] = true
+	profile.DownvotedGames["downvoted"] = true
+
+	order := func(clips []models.Clip) []uuid.UUID {
+		out := make([]uuid.UUID, len(clips))
+		for i, clip := range clips {
+			out[i] = clip.ID
+		}
+		return out
+	}
+
+	// Rank scores are 1.0, 0.8, 0.6, 0.4 and 0.2 before personalization
+	personalized := personalizeClips(clips, profile, weights)
+	assert.Equal(t, []uuid.UUID{clips[3].ID, clips[1].ID, clips[2].ID, clips[0].ID, clips[4].ID}, order(personalized))
+
+	assert.Equal(t, order(clips), order(personalizeClips(clips, nil, weights)), "no profile keeps the order")
+	assert.Equal(t, order(clips), order(personalizeClips(clips, profile, SearchPersonalizationWeights{})),
+		"zero weights keep the order")
+
+	withoutIDs := []models.Clip{{ID: uuid.New()}, {ID: uuid.New()}}
+	assert.Equal(t, order(withoutIDs), order(personalizeClips(withoutIDs, profile, weights)))
+}
+
+func TestPersonalizeClips_InterestsAndLanguages(t *testing.T) {
+	language := func(clip models.Clip, language string) models.Clip {
+		clip.Language = &language
+		return clip
+	}
+	clips := []models.Clip{
+		language(personalizationTestClip("b1", "g1"), "de"),
+		language(personalizationTestClip("b2", "g1"), "de"),
+		language(personalizationTestClip("followed", "interest-game"), "de"),
+		language(personalizationTestClip("interest", "interest-game"), "de"),
+		language(personalizationTestClip("b3", "g2"), "EN"),
+	}
+	weights := SearchPersonalizationWeights{
+		FollowedBroadcasterBoost: 0.3,
+		InterestBoost:            0.3,
+		LanguageBoost:            0.7,
+	}
+	profile := models.NewSearchPersonalizationProfile()
+	profile.FollowedBroadcasters["followed"] = true
+	profile.InterestBroadcasters["interest"] = true
+	profile.InterestBroadcasters["followed"] = true
+	profile.InterestGames["interest-game"] = true
+	profile.Languages["en"] = true
+
+	// Rank scores are 1.0, 0.8, 0.6, 0.4 and 0.2 before personalization. The
+	// followed broadcaster's clip gets the follow boost and the interest
+	// boost for its game; the interest broadcaster's clip gets one interest
+	// boost for matching twice; the English clip gets the language boost.
+	personalized := personalizeClips(clips, profile, weights)
+	ids := make([]uuid.UUID, len(personalized))
+	for i, clip := range personalized {
+		ids[i] = clip.ID
+	}
+	assert.Equal(t, []uuid.UUID{clips[2].ID, clips[0].ID, clips[4].ID, clips[1].ID, clips[3].ID}, ids)
+}
+
+func TestSearchPersonalizationService_ProfileFor(t *testing.T) {
+	ctx := context.Background()
+
+	// A minimum of zero downvotes is raised to one
+	repo := new(MockSearchPersonalizationRepository)
+	getProfile := func() *mock.Call {
+		return repo.On("GetProfile", ctx, mock.Anything, 1, 0.7, 0.2)
+	}
+	svc := NewSearchPersonalizationService(repo, 0, 0.7, 0.2)
+
+	getProfile().Return(models.NewSearchPersonalizationProfile(), nil).Once()
+	profile, err := svc.ProfileFor(ctx, uuid.New())
+	require.NoError(t, err)
+	assert.Nil(t, profile, "empty profiles are not personalized")
+
+	languages := models.NewSearchPersonalizationProfile()
+	languages.Languages["en"] = true
+	getProfile().Return(languages, nil).Once()
+	profile, err = svc.ProfileFor(ctx, uuid.New())
+	require.NoError(t, err)
+	assert.NotNil(t, profile, "languages alone personalize")
+
+	games := models.NewSearchPersonalizationProfile()
+	games.FollowedGames["g1"] = true
+	getProfile().Return(games, nil).Once()
+	profile, err = svc.ProfileFor(ctx, uuid.New())
+	require.NoError(t, err)
+	assert.Equal(t, games, profile)
+
+	getProfile().Return(nil, errors.New("db down")).Once()
+	_, err = svc.ProfileFor(ctx, uuid.New())
+	assert.Error(t, err)
+
+	repo.AssertExpectations(t)
+}
+
+func TestConfiguredPersonalizationWeights(t *testing.T) {
+	assert.Nil(t, ConfiguredPersonalizationWeights(&config.HybridSearchConfig{FollowedGameBoost: 0.2}))
+
+	weights := ConfiguredPersonalizationWeights(&config.HybridSearchConfig{
+		Personalization:          true,
+		FollowedBroadcasterBoost: 0.3,
+		FollowedGameBoost:        0.15,
+		DownvotedGamePenalty:     0.4,
+		InterestBoost:            0.1,
+		LanguageBoost:            0.2,
+	})
+	require.NotNil(t, weights)
+	assert.Equal(t, SearchPersonalizationWeights{
+		FollowedBroadcasterBoost: 0.3,
+		FollowedGameBoost:        0.15,
+		DownvotedGamePenalty:     0.4,
+		InterestBoost:            0.1,
+		LanguageBoost:            0.2,
+	}, *weights)
+}
+
+func TestHybridSearchService_PersonalizationProfile(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	profile := models.NewSearchPersonalizationProfile()
+	profile.FollowedBroadcasters["b1"] = true
+	personalizer := new(MockSearchPersonalizer)
+	personalizer.On("ProfileFor", ctx, userID).Return(profile, nil).Once()
+
+	service := NewHybridSearchService(&HybridSearchConfig{
+		OpenSearchService: &OpenSearchService{},
+		Personalization:   &SearchPersonalizationWeights{FollowedBroadcasterBoost: 0.3},
+		Personalizer:      personalizer,
+	})
+
+	assert.Nil(t, service.personalizationProfile(ctx, &models.SearchRequest{Query: "clutch"}), "anonymous searches are not personalized")
+	assert.Nil(t, service.personalizationProfile(ctx, &models.SearchRequest{Query: "clutch", Sort: "recent", UserID: &userID}))
+	personalizer.AssertNotCalled(t, "ProfileFor", mock.Anything, mock.Anything)
+
+	assert.Equal(t, profile, service.personalizationProfile(ctx, &models.SearchRequest{Query: "clutch", UserID: &userID}))
+	assert.Nil(t, service.personalizationProfile(ctx, &models.SearchRequest{Query: "clutch", UserID: &userID, Personalization: models.SearchPersonalizationOff}),
+		"personalization=off searches for no one in particular")
+	personalizer.AssertNumberOfCalls(t, "ProfileFor", 1)
+	assert.True(t, service.personalizes(&models.SearchRequest{Sort: "relevance"}, profile))
+	assert.False(t, service.personalizes(&models.SearchRequest{Sort: "popular"}, profile))
+	assert.False(t, service.personalizes(&models.SearchRequest{}, nil))
+
+	personalizer.On("ProfileFor", ctx, userID).Return(nil, errors.New("db down")).Once()
+	assert.Nil(t, service.personalizationProfile(ctx, &models.SearchRequest{Query: "clutch", UserID: &userID}),
+		"failing to load the profile searches without it")
+
+	weighted := service.WithWeights(DefaultConfigs()[1])
+	assert.NotNil(t, weighted.personalization)
+	assert.Equal(t, personalizer, weighted.personalizer)
+
+	disabled := NewHybridSearchService(&HybridSearchConfig{OpenSearchService: &OpenSearchService{}, Personalizer: personalizer})
+	assert.Nil(t, disabled.personalizationProfile(ctx, &models.SearchRequest{Query: "clutch", UserID: &userID}))
+	assert.False(t, disabled.personalizes(&models.SearchRequest{}, profile))
+
+	personalizer.AssertExpectations(t)
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+const searchSynonymMaxTermLength = 100
+
+// ErrSearchSynonymInvalid is returned for a synonym whose terms or target can't be used
+var ErrSearchSynonymInvalid = errors.New("invalid search synonym")
+
+// SearchSynonymRepositoryInterface defines the repository methods used by SearchSynonymService
+type SearchSynonymRepositoryInterface interface {
+	List(ctx context.Context, term string) ([]models.SearchSynonym, error)
+	ListEnabled(ctx context.Context) ([]models.SearchSynonym, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.SearchSynonym, error)
+	Create(ctx context.Context, synonym *models.SearchSynonym) error
+	Update(ctx context.Context, synonym *models.SearchSynonym) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// SearchSynonymService manages the synonyms applied to search. The search
+// indices bake the rules into their analyzers, so changes take effect when
+// search-index-manager sync-synonyms rebuilds them.
+type SearchSynonymService struct {
+	repo SearchSynonymRepositoryInterface
+}
+
+// NewSearchSynonymService creates a new SearchSynonymService
+func NewSearchSynonymService(repo SearchSynonymRepositoryInterface) *SearchSynonymService {
+	return &SearchSynonymService{repo: repo}
+}
+
+// ListSynonyms returns every synonym, or those containing term when set
+func (s *SearchSynonymService) ListSynonyms(ctx context.Context, term string) ([]models.SearchSynonym, error) {
+	return s.repo.List(ctx, normalizeSynonymTerm(term))
+}
+
+// GetSynonym returns a synonym by ID
+func (s *SearchSynonymService) GetSynonym(ctx context.Context, id uuid.UUID) (*models.SearchSynonym, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// CreateSynonym creates a synonym
+func (s *SearchSynonymService) CreateSynonym(ctx context.Context, adminID uuid.UUID, req *models.CreateSearchSynonymRequest) (*models.SearchSynonym, error) {
+	synonym := &models.SearchSynonym{
+		Kind:        req.Kind,
+		Terms:       req.Terms,
+		Target:      req.Target,
+		Description: req.Description,
+		Enabled:     true,
+		CreatedBy:   &adminID,
+	}
+	if req.Enabled != nil {
+		synonym.Enabled = *req.Enabled
+	}
+	if err := normalizeSearchSynonym(synonym); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, synonym); err != nil {
+		return nil, err
+	}
+	return synonym, nil
+}
+
+// UpdateSynonym changes a synonym's terms, target, description or switch.
+// The kind can't change; delete and recreate the synonym instead.
+func (s *SearchSynonymService) UpdateSynonym(ctx context.Context, id, adminID uuid.UUID, req *models.UpdateSearchSynonymRequest) (*models.SearchSynonym, error) {
+	synonym, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Terms != nil {
+		synonym.Terms = *req.Terms
+	}
+	if req.Target != nil {
+		synonym.Target = req.Target
+	}
+	if req.Description != nil {
+		synonym.Description = req.Description
+	}
+	if req.Enabled != nil {
+		synonym.Enabled = *req.Enabled
+	}
+	if err := normalizeSearchSynonym(synonym); err != nil {
+		return nil, err
+	}
+	synonym.UpdatedBy = &adminID
+
+	if err := s.repo.Update(ctx, synonym); err != nil {
+		return nil, err
+	}
+	return synonym, nil
+}
+
+// DeleteSynonym removes a synonym
+func (s *SearchSynonymService) DeleteSynonym(ctx context.Context, id uuid.UUID) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// GetRules returns the analyzer rules built from the enabled synonyms
+func (s *SearchSynonymService) GetRules(ctx context.Context) (*models.SearchSynonymRules, error) {
+	synonyms, err := s.repo.ListEnabled(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return BuildSearchSynonymRules(synonyms), nil
+}
+
+// BuildSearchSynonymRules turns the enabled synonyms into synonym filter
+// rules. Rules are sorted, so the version only changes when a rule does.
+func BuildSearchSynonymRules(synonyms []models.SearchSynonym) *models.SearchSynonymRules {
+	rules := make([]string, 0, len(synonyms))
+	for i := range synonyms {
+		if synonyms[i].Enabled {
+			rules = append(rules, synonyms[i].Rule())
+		}
+	}
+	sort.Strings(rules)
+
+	sum := sha256.Sum256([]byte(strings.Join(rules, "\n")))
+	return &models.SearchSynonymRules{
+		Rules:   rules,
+		Version: hex.EncodeToString(sum[:])[:16],
+	}
+}
+
+// normalizeSearchSynonym lowercases and deduplicates a synonym's terms and
+// checks they form a valid rule
+func normalizeSearchSynonym(synonym *models.SearchSynonym) error {
+	terms := make([]string, 0, len(synonym.Terms))
+	seen := map[string]bool{}
+	for _, raw := range synonym.Terms {
+		term := normalizeSynonymTerm(raw)
+		if err := validateSynonymTerm(term); err != nil {
+			return err
+		}
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	synonym.Terms = terms
+
+	switch synonym.Kind {
+	case models.SearchSynonymEquivalent:
+		if synonym.Target != nil {
+			return fmt.Errorf("%w: equivalent synonyms have no target", ErrSearchSynonymInvalid)
+		}
+		if len(terms) < 2 {
+			return fmt.Errorf("%w: equivalent synonyms need at least two distinct terms", ErrSearchSynonymInvalid)
+		}
+	case models.SearchSynonymAlias:
+		if synonym.Target == nil {
+			return fmt.Errorf("%w: aliases need a target", ErrSearchSynonymInvalid)
+		}
+		target := normalizeSynonymTerm(*synonym.Target)
+		if err := validateSynonymTerm(target); err != nil {
+			return err
+		}
+		if seen[target] {
+			return fmt.Errorf("%w: an alias can't target one of its own terms", ErrSearchSynonymInvalid)
+		}
+		synonym.Target = &target
+	default:
+		return fmt.Errorf("%w: kind must be equivalent or alias", ErrSearchSynonymInvalid)
+	}
+	return nil
+}
+
+// normalizeSynonymTerm lowercases a term and collapses its whitespace, as
+// the search analyzer does before applying synonyms
+func normalizeSynonymTerm(term string) string {
+	return strings.Join(strings.Fields(strings.ToLower(term)), " ")
+}
+
+// validateSynonymTerm rejects terms that would break the Solr rule format
+func validateSynonymTerm(term string) error {
+	if term == "" {
+		return fmt.Errorf("%w: terms can't be empty", ErrSearchSynonymInvalid)
+	}
+	if len(term) > searchSynonymMaxTermLength {
+		return fmt.Errorf("%w: terms can't be longer than %d characters", ErrSearchSynonymInvalid, searchSynonymMaxTermLength)
+	}
+	if strings.ContainsAny(term, `,\#`) || strings.Contains(term, "=>") {
+		return fmt.Errorf("%w: %q can't contain ',', '=>', '\\' or '#'", ErrSearchSynonymInvalid, term)
+	}
+	return nil
+}
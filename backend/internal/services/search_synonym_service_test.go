@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+// MockSearchSynonymRepository is a mock implementation of SearchSynonymRepositoryInterface
+type MockSearchSynonymRepository struct {
+	mock.Mock
+}
+
+func (m *MockSearchSynonymRepository) List(ctx context.Context, term string) ([]models.SearchSynonym, error) {
+	args := m.Called(ctx, term)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.SearchSynonym), args.Error(1)
+}
+
+func (m *MockSearchSynonymRepository) ListEnabled(ctx context.Context) ([]models.SearchSynonym, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.SearchSynonym), args.Error(1)
+}
+
+func (m *MockSearchSynonymRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.SearchSynonym, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SearchSynonym), args.Error(1)
+}
+
+func (m *MockSearchSynonymRepository) Create(ctx context.Context, synonym *models.SearchSynonym) error {
+	args := m.Called(ctx, synonym)
+	return args.Error(0)
+}
+
+func (m *MockSearchSynonymRepository) Update(ctx context.Context, synonym *models.SearchSynonym) error {
+	args := m.Called(ctx, synonym)
+	return args.Error(0)
+}
+
+func (m *MockSearchSynonymRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestSearchSynonymService_CreateSynonym(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockSearchSynonymRepository)
+	service := NewSearchSynonymService(repo)
+	adminID := uuid.New()
+
+	repo.On("Create", ctx, mock.AnythingOfType("*models.SearchSynonym")).Return(nil)
+
+	t.Run("Normalizes and deduplicates terms", func(t *testing.T) {
+		synonym, err := service.CreateSynonym(ctx, adminID, &models.CreateSearchSynonymRequest{
+			Kind:  models.SearchSynonymEquivalent,
+			Terms: []string{" GTA ", "Grand  Theft Auto V", "gta"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"gta", "grand theft auto v"}, synonym.Terms)
+		assert.True(t, synonym.Enabled)
+		assert.Equal(t, "gta, grand theft auto v", synonym.Rule())
+	})
+
+	t.Run("Alias expands one way", func(t *testing.T) {
+		synonym, err := service.CreateSynonym(ctx, adminID, &models.CreateSearchSynonymRequest{
+			Kind:   models.SearchSynonymAlias,
+			Terms:  []string{"xqc", "xqcow"},
+			Target: stringPtr("XQC Ow"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "xqc ow", *synonym.Target)
+		assert.Equal(t, "xqc, xqcow => xqc, xqcow, xqc ow", synonym.Rule())
+	})
+
+	invalid := []struct {
+		name string
+		req  models.CreateSearchSynonymRequest
+	}{
+		{"Equivalent needs two distinct terms", models.CreateSearchSynonymRequest{Kind: models.SearchSynonymEquivalent, Terms: []string{"gta", "GTA"}}},
+		{"Equivalent has no target", models.CreateSearchSynonymRequest{Kind: models.SearchSynonymEquivalent, Terms: []string{"a1", "b1"}, Target: stringPtr("c1")}},
+		{"Alias needs a target", models.CreateSearchSynonymRequest{Kind: models.SearchSynonymAlias, Terms: []string{"xqc"}}},
+		{"Alias can't target itself", models.CreateSearchSynonymRequest{Kind: models.SearchSynonymAlias, Terms: []string{"xqc"}, Target: stringPtr("XQC")}},
+		{"Rule syntax is rejected", models.CreateSearchSynonymRequest{Kind: models.SearchSynonymEquivalent, Terms: []string{"gta => gta v", "gta"}}},
+		{"Commas are rejected", models.CreateSearchSynonymRequest{Kind: models.SearchSynonymEquivalent, Terms: []string{"gta, gta v", "gta"}}},
+		{"Blank terms are rejected", models.CreateSearchSynonymRequest{Kind: models.SearchSynonymEquivalent, Terms: []string{"gta", "  "}}},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := service.CreateSynonym(ctx, adminID, &tc.req)
+			assert.ErrorIs(t, err, ErrSearchSynonymInvalid)
+		})
+	}
+
+	repo.AssertNumberOfCalls(t, "Create", 2)
+}
+
+func TestSearchSynonymService_UpdateSynonym(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockSearchSynonymRepository)
+	service := NewSearchSynonymService(repo)
+	adminID := uuid.New()
+
+	synonym := &models.SearchSynonym{
+		ID:      uuid.New(),
+		Kind:    models.SearchSynonymEquivalent,
+		Terms:   []string{"lol", "league of legends"},
+		Enabled: true,
+	}
+	repo.On("GetByID", ctx, synonym.ID).Return(synonym, nil).Once()
+	repo.On("Update", ctx, mock.MatchedBy(func(s *models.SearchSynonym) bool {
+		return s.ID == synonym.ID && !s.Enabled
+	})).Return(nil).Once()
+
+	disabled := false
+	updated, err := service.UpdateSynonym(ctx, synonym.ID, adminID, &models.UpdateSearchSynonymRequest{Enabled: &disabled})
+	require.NoError(t, err)
+	assert.False(t, updated.Enabled)
+	assert.Equal(t, []string{"lol", "league of legends"}, updated.Terms)
+
+	repo.On("ListEnabled", ctx).Return([]models.SearchSynonym{*updated}, nil).Once()
+	rules, err := service.GetRules(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, rules.Rules, "Disabled synonyms must not reach the analyzer")
+
+	missing := uuid.New()
+	repo.On("GetByID", ctx, missing).Return(nil, repository.ErrSearchSynonymNotFound).Once()
+	_, err = service.UpdateSynonym(ctx, missing, adminID, &models.UpdateSearchSynonymRequest{Enabled: &disabled})
+	assert.ErrorIs(t, err, repository.ErrSearchSynonymNotFound)
+
+	repo.AssertExpectations(t)
+}
+
+func TestBuildSearchSynonymRules(t *testing.T) {
+	gta := models.SearchSynonym{Kind: models.SearchSynonymEquivalent, Terms: []string{"gta", "grand theft auto v"}, Enabled: true}
+	lol := models.SearchSynonym{Kind: models.SearchSynonymEquivalent, Terms: []string{"lol", "league of legends"}, Enabled: true}
+	off := models.SearchSynonym{Kind: models.SearchSynonymEquivalent, Terms: []string{"cs", "counter strike"}, Enabled: false}
+
+	rules := BuildSearchSynonymRules([]models.SearchSynonym{lol, gta, off})
+	assert.Equal(t, []string{"gta, grand theft auto v", "lol, league of legends"}, rules.Rules)
+	assert.Len(t, rules.Version, 16)
+
+	reordered := BuildSearchSynonymRules([]models.SearchSynonym{gta, lol})
+	assert.Equal(t, rules.Version, reordered.Version, "Version must not depend on row order")
+
+	assert.NotEqual(t, rules.Version, BuildSearchSynonymRules([]models.SearchSynonym{gta}).Version)
+	assert.NotEqual(t, rules.Version, BuildSearchSynonymRules(nil).Version)
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	jwtpkg "github.com/subculture-collective/clipper/pkg/jwt"
+)
+
+// SessionRepositoryInterface defines the session repository methods used by SessionService
+type SessionRepositoryInterface interface {
+	ListActive(ctx context.Context, userID uuid.UUID) ([]*models.UserSession, error)
+	Revoke(ctx context.Context, userID, sessionID uuid.UUID) error
+	RevokeAllExcept(ctx context.Context, userID, keepSessionID uuid.UUID) (int, error)
+	GetSessionIDByTokenHash(ctx context.Context, userID uuid.UUID, tokenHash string) (uuid.UUID, error)
+}
+
+// SessionService lists and revokes a user's signed-in devices
+type SessionService struct {
+	repo SessionRepositoryInterface
+}
+
+// NewSessionService creates a new session service
+func NewSessionService(repo SessionRepositoryInterface) *SessionService {
+	return &SessionService{repo: repo}
+}
+
+// ListSessions returns the user's active sessions, flagging the one the
+// caller's refresh token belongs to
+func (s *SessionService) ListSessions(ctx context.Context, userID uuid.UUID, currentRefreshToken string) ([]*models.UserSession, error) {
+	sessions, err := s.repo.ListActive(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	currentID := s.currentSessionID(ctx, userID, currentRefreshToken)
+	for _, session := range sessions {
+		userAgent := ""
+		if session.UserAgent != nil {
+			userAgent = *session.UserAgent
+		}
+		session.DeviceName = describeDevice(userAgent)
+		session.Current = currentID != uuid.Nil && session.ID == currentID
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession signs one of the user's devices out
+func (s *SessionService) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	return s.repo.Revoke(ctx, userID, sessionID)
+}
+
+// RevokeOtherSessions signs out every device except the one holding currentRefreshToken
+func (s *SessionService) RevokeOtherSessions(ctx context.Context, userID uuid.UUID, currentRefreshToken string) (int, error) {
+	return s.repo.RevokeAllExcept(ctx, userID, s.currentSessionID(ctx, userID, currentRefreshToken))
+}
+
+// RevokeAllSessions signs the user out everywhere, e.g. after their role or credentials change
+func (s *SessionService) RevokeAllSessions(ctx context.Context, userID uuid.UUID) (int, error) {
+	return s.repo.RevokeAllExcept(ctx, userID, uuid.Nil)
+}
+
+// currentSessionID resolves the session for a refresh token, or uuid.Nil if unknown
+func (s *SessionService) currentSessionID(ctx context.Context, userID uuid.UUID, refreshToken string) uuid.UUID {
+	if refreshToken == "" {
+		return uuid.Nil
+	}
+	sessionID, err := s.repo.GetSessionIDByTokenHash(ctx, userID, jwtpkg.HashToken(refreshToken))
+	if err != nil {
+		return uuid.Nil
+	}
+	return sessionID
+}
+
+// describeDevice builds a short "Browser on OS" label from a user agent
+func describeDevice(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+	if ua == "" {
+		return "Unknown device"
+	}
+
+	browser := ""
+	switch {
+	case strings.Contains(ua, "edg/") || strings.Contains(ua, "edge/"):
+		browser = "Edge"
+	case strings.Contains(ua, "opr/") || strings.Contains(ua, "opera"):
+		browser = "Opera"
+	case strings.Contains(ua, "firefox/") || strings.Contains(ua, "fxios/"):
+		browser = "Firefox"
+	case strings.Contains(ua, "chrome/") || strings.Contains(ua, "crios/"):
+		browser = "Chrome"
+	case strings.Contains(ua, "safari/"):
+		browser = "Safari"
+	case strings.Contains(ua, "okhttp") || strings.Contains(ua, "cfnetwork") || strings.Contains(ua, "expo"):
+		browser = "Clipper app"
+	}
+
+	platform := ""
+	switch {
+	case strings.Contains(ua, "windows"):
+		platform = "Windows"
+	case strings.Contains(ua, "android"):
+		platform = "Android"
+	case strings.Contains(ua, "iphone") || strings.Contains(ua, "ipad") || strings.Contains(ua, "ios"):
+		platform = "iOS"
+	case strings.Contains(ua, "mac os x") || strings.Contains(ua, "macintosh"):
+		platform = "macOS"
+	case strings.Contains(ua, "cros"):
+		platform = "ChromeOS"
+	case strings.Contains(ua, "linux"):
+		platform = "Linux"
+	}
+
+	switch {
+	case browser != "" && platform != "":
+		return browser + " on " + platform
+	case browser != "":
+		return browser
+	case platform != "":
+		return platform + " device"
+	default:
+		return "Unknown device"
+	}
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	jwtpkg "github.com/subculture-collective/clipper/pkg/jwt"
+)
+
+// MockSessionRepository is a mock implementation of SessionRepositoryInterface
+type MockSessionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSessionRepository) ListActive(ctx context.Context, userID uuid.UUID) ([]*models.UserSession, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.UserSession), args.Error(1)
+}
+
+func (m *MockSessionRepository) Revoke(ctx context.Context, userID, sessionID uuid.UUID) error {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepository) RevokeAllExcept(ctx context.Context, userID, keepSessionID uuid.UUID) (int, error) {
+	args := m.Called(ctx, userID, keepSessionID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockSessionRepository) GetSessionIDByTokenHash(ctx context.Context, userID uuid.UUID, tokenHash string) (uuid.UUID, error) {
+	args := m.Called(ctx, userID, tokenHash)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func TestSessionService_ListSessionsMarksCurrent(t *testing.T) {
+	ctx := context.Background()
+	userID, desktopID, phoneID := uuid.New(), uuid.New(), uuid.New()
+	desktopUA := "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Safari/537.36"
+	phoneUA := "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1"
+
+	repo := new(MockSessionRepository)
+	repo.On("ListActive", ctx, userID).Return([]*models.UserSession{
+		{ID: desktopID, UserAgent: &desktopUA, LastActiveAt: time.Now()},
+		{ID: phoneID, UserAgent: &phoneUA, LastActiveAt: time.Now().Add(-time.Hour)},
+	}, nil)
+	repo.On("GetSessionIDByTokenHash", ctx, userID, jwtpkg.HashToken("desktop-refresh-token")).Return(desktopID, nil)
+	svc := NewSessionService(repo)
+
+	sessions, err := svc.ListSessions(ctx, userID, "desktop-refresh-token")
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+
+	assert.Equal(t, desktopID, sessions[0].ID)
+	assert.True(t, sessions[0].Current)
+	assert.Equal(t, "Chrome on Windows", sessions[0].DeviceName)
+	assert.False(t, sessions[1].Current)
+	assert.Equal(t, "Safari on iOS", sessions[1].DeviceName)
+
+	// Without a refresh token no session is flagged as current
+	sessions, err = svc.ListSessions(ctx, userID, "")
+	require.NoError(t, err)
+	for _, session := range sessions {
+		assert.False(t, session.Current)
+	}
+	repo.AssertNumberOfCalls(t, "GetSessionIDByTokenHash", 1)
+}
+
+func TestSessionService_RevokeSession(t *testing.T) {
+	ctx := context.Background()
+	userID, phoneID, unknownID := uuid.New(), uuid.New(), uuid.New()
+
+	repo := new(MockSessionRepository)
+	repo.On("Revoke", ctx, userID, phoneID).Return(nil).Once()
+	repo.On("Revoke", ctx, userID, unknownID).Return(repository.ErrSessionNotFound)
+	svc := NewSessionService(repo)
+
+	require.NoError(t, svc.RevokeSession(ctx, userID, phoneID))
+
+	err := svc.RevokeSession(ctx, userID, unknownID)
+	assert.ErrorIs(t, err, repository.ErrSessionNotFound)
+	repo.AssertExpectations(t)
+}
+
+func TestSessionService_RevokeOtherSessionsKeepsCurrent(t *testing.T) {
+	ctx := context.Background()
+	userID, desktopID := uuid.New(), uuid.New()
+
+	repo := new(MockSessionRepository)
+	repo.On("GetSessionIDByTokenHash", ctx, userID, jwtpkg.HashToken("desktop-refresh-token")).Return(desktopID, nil)
+	repo.On("GetSessionIDByTokenHash", ctx, userID, jwtpkg.HashToken("stale-token")).Return(uuid.Nil, repository.ErrSessionNotFound)
+	repo.On("RevokeAllExcept", ctx, userID, desktopID).Return(1, nil).Once()
+	repo.On("RevokeAllExcept", ctx, userID, uuid.Nil).Return(2, nil).Once()
+	svc := NewSessionService(repo)
+
+	revoked, err := svc.RevokeOtherSessions(ctx, userID, "desktop-refresh-token")
+	require.NoError(t, err)
+	assert.Equal(t, 1, revoked)
+
+	// An unknown token keeps nothing
+	revoked, err = svc.RevokeOtherSessions(ctx, userID, "stale-token")
+	require.NoError(t, err)
+	assert.Equal(t, 2, revoked)
+	repo.AssertExpectations(t)
+}
+
+func TestSessionService_RevokeAllSessions(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	repo := new(MockSessionRepository)
+	repo.On("RevokeAllExcept", ctx, userID, uuid.Nil).Return(2, nil).Once()
+	svc := NewSessionService(repo)
+
+	revoked, err := svc.RevokeAllSessions(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, revoked)
+	repo.AssertExpectations(t)
+}
+
+func TestDescribeDevice(t *testing.T) {
+	tests := []struct {
+		userAgent string
+		expected  string
+	}{
+		{"Mozilla/5.0 (Macintosh; Intel Mac OS X 14_0) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Safari/605.1.15", "Safari on macOS"},
+		{"Mozilla/5.0 (X11; Linux x86_64; rv:121.0) Gecko/20100101 Firefox/121.0", "Firefox on Linux"},
+		{"Mozilla/5.0 (Linux; Android 14) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Mobile Safari/537.36", "Chrome on Android"},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Safari/537.36 Edg/120.0", "Edge on Windows"},
+		{"Mozilla/5.0 (X11; CrOS x86_64 14541.0.0) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Safari/537.36", "Chrome on ChromeOS"},
+		{"okhttp/4.12.0", "Clipper app"},
+		{"curl/8.4.0", "Unknown device"},
+		{"", "Unknown device"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, describeDevice(tt.userAgent), tt.userAgent)
+	}
+}
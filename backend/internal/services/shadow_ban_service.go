@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const (
+	// shadowBanExpiryBatchSize caps how many expired shadow bans are lifted per run
+	shadowBanExpiryBatchSize = 100
+	// shadowBanHistoryLimit caps how many past shadow bans are listed for a user
+	shadowBanHistoryLimit = 50
+)
+
+// ShadowBanRepositoryInterface defines the repository methods used by ShadowBanService
+type ShadowBanRepositoryInterface interface {
+	Create(ctx context.Context, ban *models.UserShadowBan) error
+	Lift(ctx context.Context, userID uuid.UUID, liftedBy *uuid.UUID, reason *string) (*models.UserShadowBan, error)
+	LiftExpired(ctx context.Context, limit int) ([]models.UserShadowBan, error)
+	GetActive(ctx context.Context, userID uuid.UUID) (*models.UserShadowBan, error)
+	ListForUser(ctx context.Context, userID uuid.UUID, limit int) ([]models.UserShadowBan, error)
+	IsShadowBanned(ctx context.Context, userID uuid.UUID) (bool, error)
+}
+
+// ShadowBanChecker reports whether a user is shadow banned, for the features
+// that keep shadow-banned users' activity from reaching others
+type ShadowBanChecker interface {
+	IsShadowBanned(ctx context.Context, userID uuid.UUID) bool
+}
+
+// ShadowBanRequest describes a shadow ban. Without a duration it lasts until
+// an admin lifts it.
+type ShadowBanRequest struct {
+	Reason   string
+	Duration *time.Duration
+}
+
+// ShadowBanService manages shadow bans. Shadow-banned users keep using the
+// site and see their own comments and submissions as usual, but listings,
+// search indexing and notifications leave their activity out for everyone else.
+type ShadowBanService struct {
+	repo     ShadowBanRepositoryInterface
+	auditLog UserBanAuditLogger
+}
+
+// NewShadowBanService creates a new ShadowBanService
+func NewShadowBanService(repo ShadowBanRepositoryInterface, auditLog UserBanAuditLogger) *ShadowBanService {
+	return &ShadowBanService{
+		repo:     repo,
+		auditLog: auditLog,
+	}
+}
+
+// ShadowBanUser shadow bans a user, replacing any shadow ban they already have
+func (s *ShadowBanService) ShadowBanUser(ctx context.Context, userID, moderatorID uuid.UUID, req ShadowBanRequest) (*models.UserShadowBan, error) {
+	if req.Reason == "" {
+		return nil, ErrBanReasonRequired
+	}
+	if req.Duration != nil && *req.Duration <= 0 {
+		return nil, ErrInvalidBanDuration
+	}
+
+	now := time.Now()
+	ban := &models.UserShadowBan{
+		UserID:         userID,
+		Reason:         req.Reason,
+		ShadowBannedBy: &moderatorID,
+		StartsAt:       now,
+	}
+	if req.Duration != nil {
+		expiresAt := now.Add(*req.Duration)
+		ban.ExpiresAt = &expiresAt
+	}
+
+	if err := s.repo.Create(ctx, ban); err != nil {
+		return nil, err
+	}
+
+	metadata := map[string]interface{}{
+		"shadow_ban_id": ban.ID.String(),
+	}
+	if ban.ExpiresAt != nil {
+		metadata["expires_at"] = ban.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	s.logAudit(ctx, repository.AuditActionShadowBanUser, moderatorID, userID, AuditLogOptions{
+		Reason:   &req.Reason,
+		Metadata: metadata,
+	})
+
+	return ban, nil
+}
+
+// LiftShadowBan ends a user's shadow ban before it expires
+func (s *ShadowBanService) LiftShadowBan(ctx context.Context, userID, moderatorID uuid.UUID, reason string) error {
+	if reason == "" {
+		reason = "No reason provided"
+	}
+
+	ban, err := s.repo.Lift(ctx, userID, &moderatorID, &reason)
+	if err != nil {
+		return err
+	}
+
+	s.logAudit(ctx, repository.AuditActionLiftShadowBan, moderatorID, userID, AuditLogOptions{
+		Reason: &reason,
+		Metadata: map[string]interface{}{
+			"shadow_ban_id": ban.ID.String(),
+		},
+	})
+	return nil
+}
+
+// LiftExpiredBans lifts shadow bans that have expired, returning how many
+func (s *ShadowBanService) LiftExpiredBans(ctx context.Context) (int, error) {
+	bans, err := s.repo.LiftExpired(ctx, shadowBanExpiryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	reason := "Shadow ban expired"
+	for _, ban := range bans {
+		// The expiry was part of the moderator's decision, so the lift is
+		// recorded under them
+		actor := ban.UserID
+		if ban.ShadowBannedBy != nil {
+			actor = *ban.ShadowBannedBy
+		}
+		s.logAudit(ctx, repository.AuditActionLiftShadowBan, actor, ban.UserID, AuditLogOptions{
+			Reason: &reason,
+			Metadata: map[string]interface{}{
+				"shadow_ban_id": ban.ID.String(),
+				"automatic":     true,
+			},
+		})
+	}
+
+	return len(bans), nil
+}
+
+// GetShadowBan returns a user's active shadow ban, or nil if they have none
+func (s *ShadowBanService) GetShadowBan(ctx context.Context, userID uuid.UUID) (*models.UserShadowBan, error) {
+	ban, err := s.repo.GetActive(ctx, userID)
+	if errors.Is(err, repository.ErrUserShadowBanNotFound) {
+		return nil, nil
+	}
+	return ban, err
+}
+
+// ListShadowBans returns a user's shadow ban history, newest first
+func (s *ShadowBanService) ListShadowBans(ctx context.Context, userID uuid.UUID) ([]models.UserShadowBan, error) {
+	return s.repo.ListForUser(ctx, userID, shadowBanHistoryLimit)
+}
+
+// IsShadowBanned reports whether a user is shadow banned. Lookup failures
+// count as not shadow banned, so an outage doesn't silence everyone.
+func (s *ShadowBanService) IsShadowBanned(ctx context.Context, userID uuid.UUID) bool {
+	shadowBanned, err := s.repo.IsShadowBanned(ctx, userID)
+	if err != nil {
+		utils.GetLogger().Error("Failed to check shadow ban", err, map[string]interface{}{
+			"user_id": userID.String(),
+		})
+		return false
+	}
+	return shadowBanned
+}
+
+// logAudit records a shadow ban event without failing the change itself
+func (s *ShadowBanService) logAudit(ctx context.Context, action string, actor, target uuid.UUID, opts AuditLogOptions) {
+	if s.auditLog == nil {
+		return
+	}
+	if err := s.auditLog.LogAction(ctx, action, actor, target, "user", opts); err != nil {
+		utils.GetLogger().Error("Failed to record shadow ban audit log", err, map[string]interface{}{
+			"action":  action,
+			"user_id": target.String(),
+		})
+	}
+}
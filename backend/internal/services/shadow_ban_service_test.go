@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+// MockShadowBanRepository is a mock implementation of ShadowBanRepositoryInterface
+type MockShadowBanRepository struct {
+	mock.Mock
+}
+
+func (m *MockShadowBanRepository) Create(ctx context.Context, ban *models.UserShadowBan) error {
+	args := m.Called(ctx, ban)
+	return args.Error(0)
+}
+
+func (m *MockShadowBanRepository) Lift(ctx context.Context, userID uuid.UUID, liftedBy *uuid.UUID, reason *string) (*models.UserShadowBan, error) {
+	args := m.Called(ctx, userID, liftedBy, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UserShadowBan), args.Error(1)
+}
+
+func (m *MockShadowBanRepository) LiftExpired(ctx context.Context, limit int) ([]models.UserShadowBan, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.UserShadowBan), args.Error(1)
+}
+
+func (m *MockShadowBanRepository) GetActive(ctx context.Context, userID uuid.UUID) (*models.UserShadowBan, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UserShadowBan), args.Error(1)
+}
+
+func (m *MockShadowBanRepository) ListForUser(ctx context.Context, userID uuid.UUID, limit int) ([]models.UserShadowBan, error) {
+	args := m.Called(ctx, userID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.UserShadowBan), args.Error(1)
+}
+
+func (m *MockShadowBanRepository) IsShadowBanned(ctx context.Context, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+// liftedBy matches the moderator and reason a shadow ban is lifted with
+func liftedBy(moderatorID uuid.UUID, reason string) (interface{}, interface{}) {
+	return mock.MatchedBy(func(by *uuid.UUID) bool { return by != nil && *by == moderatorID }),
+		mock.MatchedBy(func(r *string) bool { return r != nil && *r == reason })
+}
+
+func TestShadowBanService_ShadowBanAndLift(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockShadowBanRepository)
+	audit := new(MockAuditLogService)
+	audit.On("LogAction", ctx, mock.Anything, mock.Anything, mock.Anything, "user", mock.Anything).Return(nil)
+	svc := NewShadowBanService(repo, audit)
+	userID, modID := uuid.New(), uuid.New()
+
+	repo.On("Create", ctx, mock.MatchedBy(func(ban *models.UserShadowBan) bool {
+		return ban.UserID == userID && *ban.ShadowBannedBy == modID && ban.Reason == "spam rings"
+	})).Run(func(args mock.Arguments) {
+		ban := args.Get(1).(*models.UserShadowBan)
+		ban.ID = uuid.New()
+		ban.CreatedAt = time.Now()
+	}).Return(nil).Twice()
+	repo.On("IsShadowBanned", ctx, userID).Return(true, nil).Once()
+
+	ban, err := svc.ShadowBanUser(ctx, userID, modID, ShadowBanRequest{Reason: "spam rings"})
+	require.NoError(t, err)
+	assert.Nil(t, ban.ExpiresAt, "without a duration the shadow ban lasts until lifted")
+	assert.True(t, svc.IsShadowBanned(ctx, userID))
+
+	duration := 48 * time.Hour
+	ban, err = svc.ShadowBanUser(ctx, userID, modID, ShadowBanRequest{Reason: "spam rings", Duration: &duration})
+	require.NoError(t, err)
+	require.NotNil(t, ban.ExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(duration), *ban.ExpiresAt, time.Minute)
+
+	repo.On("GetActive", ctx, userID).Return(ban, nil).Once()
+	active, err := svc.GetShadowBan(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, ban.ID, active.ID)
+
+	by, reason := liftedBy(modID, "appeal accepted")
+	repo.On("Lift", ctx, userID, by, reason).Return(ban, nil).Once()
+	repo.On("IsShadowBanned", ctx, userID).Return(false, nil).Once()
+	repo.On("GetActive", ctx, userID).Return(nil, repository.ErrUserShadowBanNotFound).Once()
+	require.NoError(t, svc.LiftShadowBan(ctx, userID, modID, "appeal accepted"))
+	assert.False(t, svc.IsShadowBanned(ctx, userID))
+	active, err = svc.GetShadowBan(ctx, userID)
+	require.NoError(t, err)
+	assert.Nil(t, active)
+
+	repo.On("ListForUser", ctx, userID, shadowBanHistoryLimit).Return([]models.UserShadowBan{*ban, {ID: uuid.New(), UserID: userID}}, nil).Once()
+	history, err := svc.ListShadowBans(ctx, userID)
+	require.NoError(t, err)
+	assert.Len(t, history, 2)
+
+	audit.AssertNumberOfCalls(t, "LogAction", 3)
+	audit.AssertCalled(t, "LogAction", ctx, repository.AuditActionShadowBanUser, modID, userID, "user", mock.Anything)
+	audit.AssertCalled(t, "LogAction", ctx, repository.AuditActionLiftShadowBan, modID, userID, "user", auditReason("appeal accepted"))
+
+	by, reason = liftedBy(modID, "No reason provided")
+	repo.On("Lift", ctx, userID, by, reason).Return(nil, repository.ErrUserShadowBanNotFound).Once()
+	err = svc.LiftShadowBan(ctx, userID, modID, "")
+	assert.ErrorIs(t, err, repository.ErrUserShadowBanNotFound)
+
+	repo.AssertExpectations(t)
+}
+
+func TestShadowBanService_Validation(t *testing.T) {
+	repo := new(MockShadowBanRepository)
+	svc := NewShadowBanService(repo, nil)
+
+	_, err := svc.ShadowBanUser(context.Background(), uuid.New(), uuid.New(), ShadowBanRequest{})
+	assert.ErrorIs(t, err, ErrBanReasonRequired)
+
+	negative := -time.Hour
+	_, err = svc.ShadowBanUser(context.Background(), uuid.New(), uuid.New(), ShadowBanRequest{Reason: "spam", Duration: &negative})
+	assert.ErrorIs(t, err, ErrInvalidBanDuration)
+
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestShadowBanService_LiftExpiredBans(t *testing.T) {
+	modID := uuid.New()
+	expired := time.Now().Add(-time.Minute)
+	expiredBan := models.UserShadowBan{ID: uuid.New(), UserID: uuid.New(), ShadowBannedBy: &modID, ExpiresAt: &expired}
+	repo := new(MockShadowBanRepository)
+	repo.On("LiftExpired", mock.Anything, shadowBanExpiryBatchSize).Return([]models.UserShadowBan{expiredBan}, nil).Once()
+	audit := new(MockAuditLogService)
+	audit.On("LogAction", mock.Anything, mock.Anything, mock.Anything, mock.Anything, "user", mock.Anything).Return(nil)
+	svc := NewShadowBanService(repo, audit)
+
+	lifted, err := svc.LiftExpiredBans(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, lifted)
+
+	repo.AssertExpectations(t)
+	audit.AssertNumberOfCalls(t, "LogAction", 1)
+	audit.AssertCalled(t, "LogAction", mock.Anything, repository.AuditActionLiftShadowBan, modID, expiredBan.UserID, "user", auditMetadata("automatic", true))
+}
+
+func TestShadowBanService_IsShadowBannedFailsOpen(t *testing.T) {
+	repo := new(MockShadowBanRepository)
+	repo.On("IsShadowBanned", mock.Anything, mock.Anything).Return(false, errors.New("connection refused")).Once()
+	svc := NewShadowBanService(repo, nil)
+
+	assert.False(t, svc.IsShadowBanned(context.Background(), uuid.New()))
+	repo.AssertExpectations(t)
+}
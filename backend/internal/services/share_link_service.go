@@ -0,0 +1,302 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	redispkg "github.com/subculture-collective/clipper/pkg/redis"
+)
+
+const (
+	shareLinkCodeLength      = 8
+	shareLinkCodeAlphabet    = "abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	shareLinkTTL             = 90 * 24 * time.Hour
+	shareLinkHourlyLimit     = 30
+	shareEventDedupWindow    = time.Hour
+	shareClickIPLimit        = 60
+	shareSignupAttributionTo = 30 * time.Minute
+)
+
+var (
+	// ErrInvalidShareChannel is returned when the share channel is not recognized
+	ErrInvalidShareChannel = errors.New("invalid share channel")
+	// ErrShareClipUnavailable is returned when the clip cannot be shared
+	ErrShareClipUnavailable = errors.New("clip is not available for sharing")
+	// ErrShareLinkRateLimited is returned when a user creates too many share links
+	ErrShareLinkRateLimited = errors.New("too many share links created")
+	// ErrShareLinkExpired is returned when a share link is past its expiry
+	ErrShareLinkExpired = errors.New("share link has expired")
+	// ErrShareLinkDisabled is returned when a share link was disabled for abuse
+	ErrShareLinkDisabled = errors.New("share link has been disabled")
+)
+
+// shareChannelAliases normalizes client-provided channel names
+var shareChannelAliases = map[string]string{
+	"":        models.ShareChannelCopy,
+	"copy":    models.ShareChannelCopy,
+	"link":    models.ShareChannelCopy,
+	"discord": models.ShareChannelDiscord,
+	"twitter": models.ShareChannelTwitter,
+	"x":       models.ShareChannelTwitter,
+	"reddit":  models.ShareChannelReddit,
+	"embed":   models.ShareChannelEmbed,
+	"other":   models.ShareChannelOther,
+}
+
+// ShareLinkRepositoryInterface defines the share link repository methods used by ShareLinkService
+type ShareLinkRepositoryInterface interface {
+	Create(ctx context.Context, link *models.ShareLink) error
+	GetByCode(ctx context.Context, code string) (*models.ShareLink, error)
+	FindActive(ctx context.Context, userID, clipID uuid.UUID, channel string) (*models.ShareLink, error)
+	CountCreatedSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error)
+	RecordEvent(ctx context.Context, linkID uuid.UUID, eventType string, userID *uuid.UUID, ipHash, referrer string) (bool, error)
+	Disable(ctx context.Context, code, reason string) error
+	GetClipAnalytics(ctx context.Context, clipID uuid.UUID) (*models.ShareAnalytics, error)
+	GetCreatorAnalytics(ctx context.Context, creatorName string) (*models.ShareAnalytics, error)
+}
+
+// ShareClipLookup defines the clip lookup used when creating share links
+type ShareClipLookup interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Clip, error)
+}
+
+// ShareLinkService manages clip short links and share attribution
+type ShareLinkService struct {
+	repo    ShareLinkRepositoryInterface
+	clips   ShareClipLookup
+	redis   *redispkg.Client
+	baseURL string
+	now     func() time.Time
+}
+
+// NewShareLinkService creates a new share link service
+func NewShareLinkService(repo ShareLinkRepositoryInterface, clips ShareClipLookup, redisClient *redispkg.Client, baseURL string) *ShareLinkService {
+	return &ShareLinkService{
+		repo:    repo,
+		clips:   clips,
+		redis:   redisClient,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		now:     time.Now,
+	}
+}
+
+// NormalizeShareChannel maps a client channel name to a known share channel
+func NormalizeShareChannel(channel string) (string, error) {
+	normalized, ok := shareChannelAliases[strings.ToLower(strings.TrimSpace(channel))]
+	if !ok {
+		return "", ErrInvalidShareChannel
+	}
+	return normalized, nil
+}
+
+// CreateShareLink returns a short link for sharing a clip on the given channel.
+// Signed-in users reuse their active link for the same clip and channel.
+func (s *ShareLinkService) CreateShareLink(ctx context.Context, clipID uuid.UUID, userID *uuid.UUID, channel string) (*models.ShareLink, error) {
+	channel, err := NormalizeShareChannel(channel)
+	if err != nil {
+		return nil, err
+	}
+
+	clip, err := s.clips.GetByID(ctx, clipID)
+	if err != nil {
+		return nil, ErrShareClipUnavailable
+	}
+	if clip.IsRemoved || clip.IsHidden {
+		return nil, ErrShareClipUnavailable
+	}
+
+	if userID != nil {
+		existing, err := s.repo.FindActive(ctx, *userID, clipID, channel)
+		if err == nil {
+			existing.URL = s.ShareURL(existing.Code)
+			return existing, nil
+		}
+		if !errors.Is(err, repository.ErrShareLinkNotFound) {
+			return nil, err
+		}
+
+		count, err := s.repo.CountCreatedSince(ctx, *userID, s.now().Add(-time.Hour))
+		if err != nil {
+			return nil, err
+		}
+		if count >= shareLinkHourlyLimit {
+			return nil, ErrShareLinkRateLimited
+		}
+	}
+
+	link := &models.ShareLink{
+		ID:        uuid.New(),
+		ClipID:    clipID,
+		UserID:    userID,
+		Channel:   channel,
+		ExpiresAt: s.now().Add(shareLinkTTL),
+	}
+
+	// Retry on the rare code collision
+	for attempt := 0; attempt < 3; attempt++ {
+		link.Code, err = generateShareCode()
+		if err != nil {
+			return nil, err
+		}
+		err = s.repo.Create(ctx, link)
+		if !errors.Is(err, repository.ErrShareLinkCodeTaken) {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create share link: %w", err)
+	}
+
+	link.URL = s.ShareURL(link.Code)
+	return link, nil
+}
+
+// Resolve looks up an active share link by code
+func (s *ShareLinkService) Resolve(ctx context.Context, code string) (*models.ShareLink, error) {
+	link, err := s.repo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if link.IsDisabled {
+		return nil, ErrShareLinkDisabled
+	}
+	if !s.now().Before(link.ExpiresAt) {
+		return nil, ErrShareLinkExpired
+	}
+	return link, nil
+}
+
+// RecordClick attributes a short link visit, ignoring repeat clicks from the same client
+func (s *ShareLinkService) RecordClick(ctx context.Context, link *models.ShareLink, clientIP, referrer string) error {
+	ipHash := hashIP(clientIP)
+	if !s.allowEvent(ctx, link, models.ShareEventClick, ipHash) {
+		return nil
+	}
+	_, err := s.repo.RecordEvent(ctx, link.ID, models.ShareEventClick, nil, ipHash, referrer)
+	return err
+}
+
+// RecordView attributes a clip view to the share link that brought the viewer in
+func (s *ShareLinkService) RecordView(ctx context.Context, code string, clipID uuid.UUID, userID *uuid.UUID, clientIP string) error {
+	link, err := s.Resolve(ctx, code)
+	if err != nil || link.ClipID != clipID {
+		return nil
+	}
+
+	ipHash := hashIP(clientIP)
+	if !s.allowEvent(ctx, link, models.ShareEventView, ipHash) {
+		return nil
+	}
+	_, err = s.repo.RecordEvent(ctx, link.ID, models.ShareEventView, userID, ipHash, "")
+	return err
+}
+
+// AttributeSignup credits a share link with a sign-up when the account was created
+// shortly after the link was followed
+func (s *ShareLinkService) AttributeSignup(ctx context.Context, code string, user *models.User) error {
+	if user == nil {
+		return nil
+	}
+
+	link, err := s.repo.GetByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, repository.ErrShareLinkNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !isAttributableSignup(link, user, s.now()) {
+		return nil
+	}
+
+	_, err = s.repo.RecordEvent(ctx, link.ID, models.ShareEventSignup, &user.ID, "", "")
+	return err
+}
+
+// DisableShareLink blocks a share link from resolving
+func (s *ShareLinkService) DisableShareLink(ctx context.Context, code, reason string) error {
+	return s.repo.Disable(ctx, code, reason)
+}
+
+// GetClipAnalytics returns share analytics for a clip
+func (s *ShareLinkService) GetClipAnalytics(ctx context.Context, clipID uuid.UUID) (*models.ShareAnalytics, error) {
+	return s.repo.GetClipAnalytics(ctx, clipID)
+}
+
+// GetCreatorAnalytics returns share analytics across a creator's clips
+func (s *ShareLinkService) GetCreatorAnalytics(ctx context.Context, creatorName string) (*models.ShareAnalytics, error) {
+	return s.repo.GetCreatorAnalytics(ctx, creatorName)
+}
+
+// ShareURL builds the public short link URL for a code
+func (s *ShareLinkService) ShareURL(code string) string {
+	return fmt.Sprintf("%s/s/%s", s.baseURL, code)
+}
+
+// ClipURL builds the frontend clip URL a short link redirects to
+func (s *ShareLinkService) ClipURL(link *models.ShareLink) string {
+	return fmt.Sprintf("%s/clips/%s?ref=%s", s.baseURL, link.ClipID, link.Code)
+}
+
+// allowEvent deduplicates events per client and caps clicks from a single IP
+func (s *ShareLinkService) allowEvent(ctx context.Context, link *models.ShareLink, eventType, ipHash string) bool {
+	if s.redis == nil {
+		return true
+	}
+
+	dedupKey := fmt.Sprintf("share:%s:%s:%s", eventType, link.Code, ipHash)
+	first, err := s.redis.SetNX(ctx, dedupKey, 1, shareEventDedupWindow)
+	if err != nil {
+		// Fail open so attribution keeps working if Redis is unavailable
+		return true
+	}
+	if !first {
+		return false
+	}
+
+	if eventType == models.ShareEventClick {
+		ipKey := fmt.Sprintf("share:ip:%s", ipHash)
+		count, err := s.redis.Increment(ctx, ipKey)
+		if err == nil && count == 1 {
+			_ = s.redis.Expire(ctx, ipKey, time.Minute)
+		}
+		if err == nil && count > shareClickIPLimit {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isAttributableSignup reports whether the user signed up after following the link
+func isAttributableSignup(link *models.ShareLink, user *models.User, now time.Time) bool {
+	if user.CreatedAt.Before(link.CreatedAt) {
+		return false
+	}
+	if link.UserID != nil && *link.UserID == user.ID {
+		return false
+	}
+	return now.Sub(user.CreatedAt) <= shareSignupAttributionTo
+}
+
+// generateShareCode returns a random short code without ambiguous characters
+func generateShareCode() (string, error) {
+	max := big.NewInt(int64(len(shareLinkCodeAlphabet)))
+	code := make([]byte, shareLinkCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate share code: %w", err)
+		}
+		code[i] = shareLinkCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
@@ -0,0 +1,242 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+// MockShareLinkRepository is a mock implementation of ShareLinkRepositoryInterface
+type MockShareLinkRepository struct {
+	mock.Mock
+}
+
+func (m *MockShareLinkRepository) Create(ctx context.Context, link *models.ShareLink) error {
+	args := m.Called(ctx, link)
+	return args.Error(0)
+}
+
+func (m *MockShareLinkRepository) GetByCode(ctx context.Context, code string) (*models.ShareLink, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ShareLink), args.Error(1)
+}
+
+func (m *MockShareLinkRepository) FindActive(ctx context.Context, userID, clipID uuid.UUID, channel string) (*models.ShareLink, error) {
+	args := m.Called(ctx, userID, clipID, channel)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ShareLink), args.Error(1)
+}
+
+func (m *MockShareLinkRepository) CountCreatedSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	args := m.Called(ctx, userID, since)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockShareLinkRepository) RecordEvent(ctx context.Context, linkID uuid.UUID, eventType string, userID *uuid.UUID, ipHash, referrer string) (bool, error) {
+	args := m.Called(ctx, linkID, eventType, userID, ipHash, referrer)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockShareLinkRepository) Disable(ctx context.Context, code, reason string) error {
+	args := m.Called(ctx, code, reason)
+	return args.Error(0)
+}
+
+func (m *MockShareLinkRepository) GetClipAnalytics(ctx context.Context, clipID uuid.UUID) (*models.ShareAnalytics, error) {
+	args := m.Called(ctx, clipID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ShareAnalytics), args.Error(1)
+}
+
+func (m *MockShareLinkRepository) GetCreatorAnalytics(ctx context.Context, creatorName string) (*models.ShareAnalytics, error) {
+	args := m.Called(ctx, creatorName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ShareAnalytics), args.Error(1)
+}
+
+// setupShareLinkServiceTest builds a ShareLinkService over mocks
+func setupShareLinkServiceTest() (*ShareLinkService, *MockShareLinkRepository, *MockClipRepository) {
+	repo := new(MockShareLinkRepository)
+	clips := new(MockClipRepository)
+	return NewShareLinkService(repo, clips, nil, "https://clpr.tv/"), repo, clips
+}
+
+func TestNormalizeShareChannel(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{"", models.ShareChannelCopy, false},
+		{"Discord", models.ShareChannelDiscord, false},
+		{" x ", models.ShareChannelTwitter, false},
+		{"twitter", models.ShareChannelTwitter, false},
+		{"myspace", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			channel, err := NormalizeShareChannel(tt.input)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidShareChannel)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, channel)
+		})
+	}
+}
+
+func TestGenerateShareCode(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		code, err := generateShareCode()
+		require.NoError(t, err)
+		assert.Len(t, code, shareLinkCodeLength)
+		assert.NotContains(t, code, "0")
+		assert.NotContains(t, code, "l")
+		seen[code] = true
+	}
+	assert.Len(t, seen, 100)
+}
+
+func TestCreateShareLink(t *testing.T) {
+	ctx := context.Background()
+	service, repo, clips := setupShareLinkServiceTest()
+	clip := &models.Clip{ID: uuid.New()}
+	userID := uuid.New()
+
+	clips.On("GetByID", ctx, clip.ID).Return(clip, nil)
+	repo.On("FindActive", ctx, userID, clip.ID, models.ShareChannelDiscord).Return(nil, repository.ErrShareLinkNotFound).Once()
+	repo.On("CountCreatedSince", ctx, userID, mock.AnythingOfType("time.Time")).Return(0, nil).Once()
+	repo.On("Create", ctx, mock.AnythingOfType("*models.ShareLink")).Return(nil).Once()
+
+	link, err := service.CreateShareLink(ctx, clip.ID, &userID, "discord")
+	require.NoError(t, err)
+	assert.Equal(t, models.ShareChannelDiscord, link.Channel)
+	assert.Equal(t, "https://clpr.tv/s/"+link.Code, link.URL)
+	assert.WithinDuration(t, time.Now().Add(shareLinkTTL), link.ExpiresAt, time.Minute)
+
+	// Same sharer, clip and channel reuse the existing link
+	existing := *link
+	existing.URL = ""
+	repo.On("FindActive", ctx, userID, clip.ID, models.ShareChannelDiscord).Return(&existing, nil).Once()
+
+	again, err := service.CreateShareLink(ctx, clip.ID, &userID, "discord")
+	require.NoError(t, err)
+	assert.Equal(t, link.Code, again.Code)
+	assert.Equal(t, link.URL, again.URL)
+	repo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestCreateShareLink_Rejections(t *testing.T) {
+	ctx := context.Background()
+	service, repo, clips := setupShareLinkServiceTest()
+	removed := &models.Clip{ID: uuid.New(), IsRemoved: true}
+	clip := &models.Clip{ID: uuid.New()}
+	missingID := uuid.New()
+	userID := uuid.New()
+
+	clips.On("GetByID", ctx, removed.ID).Return(removed, nil)
+	clips.On("GetByID", ctx, clip.ID).Return(clip, nil)
+	clips.On("GetByID", ctx, missingID).Return(nil, errors.New("clip not found"))
+
+	_, err := service.CreateShareLink(ctx, removed.ID, &userID, "copy")
+	assert.ErrorIs(t, err, ErrShareClipUnavailable)
+
+	_, err = service.CreateShareLink(ctx, missingID, nil, "copy")
+	assert.ErrorIs(t, err, ErrShareClipUnavailable)
+
+	_, err = service.CreateShareLink(ctx, clip.ID, nil, "fax")
+	assert.ErrorIs(t, err, ErrInvalidShareChannel)
+
+	repo.On("FindActive", ctx, userID, clip.ID, models.ShareChannelCopy).Return(nil, repository.ErrShareLinkNotFound)
+	repo.On("CountCreatedSince", ctx, userID, mock.AnythingOfType("time.Time")).Return(shareLinkHourlyLimit, nil)
+	_, err = service.CreateShareLink(ctx, clip.ID, &userID, "copy")
+	assert.ErrorIs(t, err, ErrShareLinkRateLimited)
+
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestResolveShareLink(t *testing.T) {
+	ctx := context.Background()
+	service, repo, _ := setupShareLinkServiceTest()
+	link := &models.ShareLink{ID: uuid.New(), Code: "abc123", ClipID: uuid.New(), ExpiresAt: time.Now().Add(shareLinkTTL)}
+
+	repo.On("GetByCode", ctx, link.Code).Return(link, nil).Twice()
+
+	resolved, err := service.Resolve(ctx, link.Code)
+	require.NoError(t, err)
+	assert.Equal(t, link.ClipID, resolved.ClipID)
+
+	service.now = func() time.Time { return time.Now().Add(shareLinkTTL + time.Hour) }
+	_, err = service.Resolve(ctx, link.Code)
+	assert.ErrorIs(t, err, ErrShareLinkExpired)
+
+	service.now = time.Now
+	repo.On("Disable", ctx, link.Code, "spam").Return(nil).Once()
+	require.NoError(t, service.DisableShareLink(ctx, link.Code, "spam"))
+
+	disabled := *link
+	disabled.IsDisabled = true
+	repo.On("GetByCode", ctx, link.Code).Return(&disabled, nil).Once()
+	_, err = service.Resolve(ctx, link.Code)
+	assert.ErrorIs(t, err, ErrShareLinkDisabled)
+
+	repo.On("GetByCode", ctx, "missing").Return(nil, repository.ErrShareLinkNotFound)
+	_, err = service.Resolve(ctx, "missing")
+	assert.ErrorIs(t, err, repository.ErrShareLinkNotFound)
+
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "RecordEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRecordView_OnlyForSharedClip(t *testing.T) {
+	ctx := context.Background()
+	service, repo, _ := setupShareLinkServiceTest()
+	link := &models.ShareLink{ID: uuid.New(), Code: "abc123", ClipID: uuid.New(), ExpiresAt: time.Now().Add(shareLinkTTL)}
+
+	repo.On("GetByCode", ctx, link.Code).Return(link, nil)
+	repo.On("RecordEvent", ctx, link.ID, models.ShareEventView, (*uuid.UUID)(nil), hashIP("127.0.0.1"), "").Return(true, nil).Once()
+
+	require.NoError(t, service.RecordView(ctx, link.Code, uuid.New(), nil, "127.0.0.1"))
+	repo.AssertNotCalled(t, "RecordEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	require.NoError(t, service.RecordView(ctx, link.Code, link.ClipID, nil, "127.0.0.1"))
+	repo.AssertExpectations(t)
+}
+
+func TestIsAttributableSignup(t *testing.T) {
+	now := time.Now()
+	sharer := uuid.New()
+	link := &models.ShareLink{UserID: &sharer, CreatedAt: now.Add(-time.Hour)}
+
+	newUser := &models.User{ID: uuid.New(), CreatedAt: now.Add(-time.Minute)}
+	assert.True(t, isAttributableSignup(link, newUser, now))
+
+	existingUser := &models.User{ID: uuid.New(), CreatedAt: now.Add(-48 * time.Hour)}
+	assert.False(t, isAttributableSignup(link, existingUser, now))
+
+	staleSignup := &models.User{ID: uuid.New(), CreatedAt: now.Add(-shareSignupAttributionTo - time.Minute)}
+	assert.False(t, isAttributableSignup(link, staleSignup, now))
+
+	self := &models.User{ID: sharer, CreatedAt: now.Add(-time.Minute)}
+	assert.False(t, isAttributableSignup(link, self, now))
+}
@@ -0,0 +1,247 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/pagination"
+	redispkg "github.com/subculture-collective/clipper/pkg/redis"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const (
+	// SocialFeedSort is the sort order social feed cursors are issued for
+	SocialFeedSort = "social"
+
+	// socialFeedMaxSources caps how many followed users a feed is built from
+	socialFeedMaxSources = 500
+	// socialFeedWindow is how far back the social feed goes
+	socialFeedWindow = 30 * 24 * time.Hour
+	// socialFeedPerUser caps how much of each followed user's activity is read
+	socialFeedPerUser = 50
+	// socialFeedListLimit caps the page size
+	socialFeedListLimit = 100
+	// socialActivityCacheTTL is how long a user's recent activity is cached
+	socialActivityCacheTTL = 2 * time.Minute
+)
+
+// SocialFeedRepositoryInterface defines the repository methods used by SocialFeedService
+type SocialFeedRepositoryInterface interface {
+	ListActivitySources(ctx context.Context, viewerID uuid.UUID, limit int) ([]uuid.UUID, error)
+	ListRecentActivity(ctx context.Context, userIDs []uuid.UUID, since time.Time, perUser int) ([]models.SocialActivity, error)
+}
+
+// SocialActivityCache caches each user's recent activity, so users followed
+// by many viewers are read once per TTL
+type SocialActivityCache interface {
+	// Get returns the cached activity of the users that have it
+	Get(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID][]models.SocialActivity, error)
+	Set(ctx context.Context, activity map[uuid.UUID][]models.SocialActivity) error
+}
+
+// RedisSocialActivityCache caches users' recent activity in Redis
+//
+//nolint:revive // exported type needed for wiring
+type RedisSocialActivityCache struct {
+	client *redispkg.Client
+}
+
+// NewRedisSocialActivityCache constructs a Redis-backed cache
+func NewRedisSocialActivityCache(client *redispkg.Client) *RedisSocialActivityCache {
+	if client == nil {
+		return nil
+	}
+	return &RedisSocialActivityCache{client: client}
+}
+
+func socialActivityKey(userID uuid.UUID) string {
+	return fmt.Sprintf("social_feed:activity:%s", userID)
+}
+
+// Get returns the cached activity of the users that have it
+func (c *RedisSocialActivityCache) Get(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID][]models.SocialActivity, error) {
+	cached := make(map[uuid.UUID][]models.SocialActivity)
+	if c == nil || c.client == nil || len(userIDs) == 0 {
+		return cached, nil
+	}
+
+	keys := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		keys[i] = socialActivityKey(id)
+	}
+	values, err := c.client.MGet(ctx, keys...)
+	if err != nil {
+		return cached, err
+	}
+	for i, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			continue
+		}
+		var activity []models.SocialActivity
+		if err := json.Unmarshal([]byte(raw), &activity); err != nil {
+			continue
+		}
+		cached[userIDs[i]] = activity
+	}
+	return cached, nil
+}
+
+// Set caches users' activity for socialActivityCacheTTL
+func (c *RedisSocialActivityCache) Set(ctx context.Context, activity map[uuid.UUID][]models.SocialActivity) error {
+	if c == nil || c.client == nil || len(activity) == 0 {
+		return nil
+	}
+
+	pipe := c.client.Pipeline()
+	for userID, items := range activity {
+		data, err := json.Marshal(items)
+		if err != nil {
+			return err
+		}
+		pipe.Set(ctx, socialActivityKey(userID), data, socialActivityCacheTTL)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// SocialFeedService builds the social feed: what the users a viewer follows
+// recently did. Feeds are assembled on read from each followed user's recent
+// activity, which is cached per user rather than per viewer. Who a viewer may
+// see is checked on every read, so privacy changes apply at once.
+type SocialFeedService struct {
+	repo  SocialFeedRepositoryInterface
+	cache SocialActivityCache
+	now   func() time.Time
+}
+
+// NewSocialFeedService creates a new SocialFeedService. The cache is optional.
+func NewSocialFeedService(repo SocialFeedRepositoryInterface, cache SocialActivityCache) *SocialFeedService {
+	return &SocialFeedService{
+		repo:  repo,
+		cache: cache,
+		now:   time.Now,
+	}
+}
+
+// GetFeed returns a page of the viewer's social feed, newest first, starting
+// after the cursor if one is given
+func (s *SocialFeedService) GetFeed(ctx context.Context, viewerID uuid.UUID, cursor *pagination.Cursor, limit int) ([]models.SocialActivity, pagination.PageInfo, error) {
+	if limit <= 0 || limit > socialFeedListLimit {
+		limit = socialFeedListLimit
+	}
+
+	sources, err := s.repo.ListActivitySources(ctx, viewerID, socialFeedMaxSources)
+	if err != nil {
+		return nil, pagination.PageInfo{}, err
+	}
+	activity, err := s.activityFor(ctx, sources)
+	if err != nil {
+		return nil, pagination.PageInfo{}, err
+	}
+
+	since := s.now().Add(-socialFeedWindow)
+	items := make([]models.SocialActivity, 0, limit+1)
+	for _, a := range activity {
+		if a.OccurredAt.Before(since) {
+			continue
+		}
+		if cursor != nil && !socialActivityAfter(a, cursor) {
+			continue
+		}
+		items = append(items, a)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return socialActivityBefore(items[i], items[j])
+	})
+	if len(items) > limit+1 {
+		items = items[:limit+1]
+	}
+
+	keys := make([]pagination.Cursor, len(items))
+	for i, a := range items {
+		keys[i] = pagination.Cursor{Sort: SocialFeedSort, Time: a.OccurredAt, ID: a.ID}
+		if a.Badge != nil {
+			if badge, err := GetBadgeDefinition(a.Badge.ID); err == nil {
+				items[i].Badge = badge
+			}
+		}
+	}
+	items, page := pagination.Trim(items, keys, limit)
+	return items, page, nil
+}
+
+// activityFor returns the recent activity of the given users, reading cached
+// activity first and caching what had to be read from the database
+func (s *SocialFeedService) activityFor(ctx context.Context, userIDs []uuid.UUID) ([]models.SocialActivity, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	cached := map[uuid.UUID][]models.SocialActivity{}
+	if s.cache != nil {
+		var err error
+		if cached, err = s.cache.Get(ctx, userIDs); err != nil {
+			utils.GetLogger().Warn("Failed to read cached social activity", map[string]interface{}{
+				"error": err.Error(),
+			})
+			cached = map[uuid.UUID][]models.SocialActivity{}
+		}
+	}
+
+	var missing []uuid.UUID
+	for _, id := range userIDs {
+		if _, ok := cached[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		fresh, err := s.repo.ListRecentActivity(ctx, missing, s.now().Add(-socialFeedWindow), socialFeedPerUser)
+		if err != nil {
+			return nil, err
+		}
+		// Users without recent activity are cached too, as an empty list
+		loaded := make(map[uuid.UUID][]models.SocialActivity, len(missing))
+		for _, id := range missing {
+			loaded[id] = []models.SocialActivity{}
+		}
+		for _, a := range fresh {
+			loaded[a.Actor.ID] = append(loaded[a.Actor.ID], a)
+		}
+		if s.cache != nil {
+			if err := s.cache.Set(ctx, loaded); err != nil {
+				utils.GetLogger().Warn("Failed to cache social activity", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+		}
+		for id, items := range loaded {
+			cached[id] = items
+		}
+	}
+
+	var activity []models.SocialActivity
+	for _, id := range userIDs {
+		activity = append(activity, cached[id]...)
+	}
+	return activity, nil
+}
+
+// socialActivityBefore reports whether a comes before b in the feed: newer
+// first, with the ID breaking ties
+func socialActivityBefore(a, b models.SocialActivity) bool {
+	if !a.OccurredAt.Equal(b.OccurredAt) {
+		return a.OccurredAt.After(b.OccurredAt)
+	}
+	return a.ID.String() > b.ID.String()
+}
+
+// socialActivityAfter reports whether activity comes after the cursor
+func socialActivityAfter(a models.SocialActivity, cursor *pagination.Cursor) bool {
+	return socialActivityBefore(models.SocialActivity{ID: cursor.ID, OccurredAt: cursor.Time}, a)
+}
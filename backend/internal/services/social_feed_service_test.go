@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/pagination"
+)
+
+// MockSocialFeedRepository is a mock implementation of SocialFeedRepositoryInterface
+type MockSocialFeedRepository struct {
+	mock.Mock
+}
+
+func (m *MockSocialFeedRepository) ListActivitySources(ctx context.Context, viewerID uuid.UUID, limit int) ([]uuid.UUID, error) {
+	args := m.Called(ctx, viewerID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockSocialFeedRepository) ListRecentActivity(ctx context.Context, userIDs []uuid.UUID, since time.Time, perUser int) ([]models.SocialActivity, error) {
+	args := m.Called(ctx, userIDs, since, perUser)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.SocialActivity), args.Error(1)
+}
+
+// MockSocialActivityCache is a mock implementation of SocialActivityCache
+type MockSocialActivityCache struct {
+	mock.Mock
+}
+
+func (m *MockSocialActivityCache) Get(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID][]models.SocialActivity, error) {
+	args := m.Called(ctx, userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID][]models.SocialActivity), args.Error(1)
+}
+
+func (m *MockSocialActivityCache) Set(ctx context.Context, activity map[uuid.UUID][]models.SocialActivity) error {
+	args := m.Called(ctx, activity)
+	return args.Error(0)
+}
+
+func setupSocialFeedServiceTest() (*SocialFeedService, *MockSocialFeedRepository, *MockSocialActivityCache) {
+	repo := new(MockSocialFeedRepository)
+	cache := new(MockSocialActivityCache)
+	svc := NewSocialFeedService(repo, cache)
+	svc.now = func() time.Time { return time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC) }
+	return svc, repo, cache
+}
+
+func socialActivity(actor uuid.UUID, kind string, at time.Time) models.SocialActivity {
+	return models.SocialActivity{
+		ID:         uuid.New(),
+		Type:       kind,
+		Actor:      models.ClipSubmitterInfo{ID: actor},
+		OccurredAt: at,
+	}
+}
+
+func TestSocialFeedService_GetFeed(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, cache := setupSocialFeedServiceTest()
+	now := svc.now()
+	viewer, alice, bob := uuid.New(), uuid.New(), uuid.New()
+
+	clip := socialActivity(alice, models.SocialActivityClipSubmitted, now.Add(-time.Hour))
+	feed := socialActivity(bob, models.SocialActivityFeedCreated, now.Add(-2*time.Hour))
+	badge := socialActivity(alice, models.SocialActivityBadgeEarned, now.Add(-3*time.Hour))
+	badge.Badge = &models.Badge{ID: "veteran"}
+	old := socialActivity(bob, models.SocialActivityClipSubmitted, now.Add(-40*24*time.Hour))
+
+	sources := []uuid.UUID{alice, bob}
+	repo.On("ListActivitySources", ctx, viewer, socialFeedMaxSources).Return(sources, nil).Twice()
+	cache.On("Get", ctx, sources).Return(map[uuid.UUID][]models.SocialActivity{}, nil).Once()
+	repo.On("ListRecentActivity", ctx, sources, now.Add(-socialFeedWindow), socialFeedPerUser).
+		Return([]models.SocialActivity{clip, badge, feed}, nil).Once()
+	cache.On("Set", ctx, map[uuid.UUID][]models.SocialActivity{alice: {clip, badge}, bob: {feed}}).Return(nil).Once()
+	// The second page is built from cached activity, which may have aged out
+	// of the feed window
+	cache.On("Get", ctx, sources).Return(map[uuid.UUID][]models.SocialActivity{alice: {clip, badge}, bob: {feed, old}}, nil).Once()
+
+	items, page, err := svc.GetFeed(ctx, viewer, nil, 2)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, clip.ID, items[0].ID)
+	assert.Equal(t, feed.ID, items[1].ID)
+	require.True(t, page.HasMore)
+	require.NotNil(t, page.NextCursor)
+
+	cursor, err := pagination.Decode(*page.NextCursor, SocialFeedSort)
+	require.NoError(t, err)
+	items, page, err = svc.GetFeed(ctx, viewer, cursor, 2)
+	require.NoError(t, err)
+	require.Len(t, items, 1, "activity older than the feed window is left out")
+	assert.Equal(t, badge.ID, items[0].ID)
+	require.NotNil(t, items[0].Badge)
+	assert.NotEmpty(t, items[0].Badge.Name, "badges are filled in from their definitions")
+	assert.False(t, page.HasMore)
+
+	repo.AssertExpectations(t)
+	cache.AssertExpectations(t)
+}
+
+func TestSocialFeedService_GetFeedCachesIdleUsers(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, cache := setupSocialFeedServiceTest()
+	viewer, idle := uuid.New(), uuid.New()
+
+	sources := []uuid.UUID{idle}
+	repo.On("ListActivitySources", ctx, viewer, socialFeedMaxSources).Return(sources, nil).Twice()
+	cache.On("Get", ctx, sources).Return(map[uuid.UUID][]models.SocialActivity{}, nil).Once()
+	repo.On("ListRecentActivity", ctx, sources, svc.now().Add(-socialFeedWindow), socialFeedPerUser).Return(nil, nil).Once()
+	cache.On("Set", ctx, map[uuid.UUID][]models.SocialActivity{idle: {}}).Return(nil).Once()
+	cache.On("Get", ctx, sources).Return(map[uuid.UUID][]models.SocialActivity{idle: {}}, nil).Once()
+
+	for i := 0; i < 2; i++ {
+		items, page, err := svc.GetFeed(ctx, viewer, nil, 20)
+		require.NoError(t, err)
+		assert.Empty(t, items)
+		assert.False(t, page.HasMore)
+	}
+	repo.AssertExpectations(t)
+	cache.AssertExpectations(t)
+}
+
+func TestSocialFeedService_GetFeedOnlyReadsSources(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, cache := setupSocialFeedServiceTest()
+	viewer, shared := uuid.New(), uuid.New()
+	activity := socialActivity(shared, models.SocialActivityFeedCreated, svc.now().Add(-time.Minute))
+
+	// Users who stopped sharing activity are no longer sources, so their
+	// cached activity isn't read
+	sources := []uuid.UUID{shared}
+	repo.On("ListActivitySources", ctx, viewer, socialFeedMaxSources).Return(sources, nil).Once()
+	cache.On("Get", ctx, sources).Return(map[uuid.UUID][]models.SocialActivity{}, nil).Once()
+	repo.On("ListRecentActivity", ctx, sources, svc.now().Add(-socialFeedWindow), socialFeedPerUser).
+		Return([]models.SocialActivity{activity}, nil).Once()
+	cache.On("Set", ctx, map[uuid.UUID][]models.SocialActivity{shared: {activity}}).Return(nil).Once()
+
+	items, _, err := svc.GetFeed(ctx, viewer, nil, 20)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, shared, items[0].Actor.ID)
+	repo.AssertExpectations(t)
+	cache.AssertExpectations(t)
+}
@@ -0,0 +1,397 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+const (
+	// BulkSubmissionAsyncThreshold is the largest bulk request moderated inline;
+	// larger ones run in the background and are tracked as a job
+	BulkSubmissionAsyncThreshold = 25
+	// BulkSubmissionMaxItems caps how many submissions one request can moderate
+	BulkSubmissionMaxItems = 500
+
+	// bulkSubmissionMaxAttempts is how many times an item is tried when it fails
+	// with an error that may be transient
+	bulkSubmissionMaxAttempts = 3
+	// bulkSubmissionRetryBackoff is the delay before the first retry; it grows per attempt
+	bulkSubmissionRetryBackoff = 200 * time.Millisecond
+	// bulkSubmissionJobTimeout bounds how long a background job may run
+	bulkSubmissionJobTimeout = 15 * time.Minute
+	// bulkSubmissionJobTTL is how long a job's status can be looked up
+	bulkSubmissionJobTTL = 24 * time.Hour
+)
+
+var (
+	// ErrBulkSubmissionJobNotFound is returned for an unknown or expired job
+	ErrBulkSubmissionJobNotFound = errors.New("bulk moderation job not found")
+	// ErrBulkSubmissionJobsUnavailable is returned when there is no Redis to track jobs in
+	ErrBulkSubmissionJobsUnavailable = errors.New("bulk moderation jobs are unavailable")
+)
+
+// bulkSubmissionApplyFunc applies an action to one pending submission,
+// returning the created clip's ID for approvals
+type bulkSubmissionApplyFunc func(ctx context.Context, submission *models.ClipSubmission) (*uuid.UUID, error)
+
+// BulkApproveSubmissions approves each pending submission on its own: the
+// clip is created and the submission approved in one transaction per item,
+// so a failing item doesn't affect the rest. Items failing with an error that
+// may be transient are retried before being reported.
+func (s *SubmissionService) BulkApproveSubmissions(ctx context.Context, submissionIDs []uuid.UUID, reviewerID uuid.UUID) (*models.BulkSubmissionResult, error) {
+	return s.moderateBulk(ctx, models.BulkSubmissionActionApprove, submissionIDs, reviewerID, "", nil)
+}
+
+// BulkRejectSubmissions rejects each pending submission on its own, reporting
+// per-item results like BulkApproveSubmissions
+func (s *SubmissionService) BulkRejectSubmissions(ctx context.Context, submissionIDs []uuid.UUID, reviewerID uuid.UUID, reason string) (*models.BulkSubmissionResult, error) {
+	return s.moderateBulk(ctx, models.BulkSubmissionActionReject, submissionIDs, reviewerID, reason, nil)
+}
+
+// BulkJobsAvailable reports whether large bulk requests can run in the background
+func (s *SubmissionService) BulkJobsAvailable() bool {
+	return s.redisClient != nil
+}
+
+// StartBulkModerationJob moderates submissions in the background and returns
+// the job to poll. The job runs in this process; if it stops mid-way, items
+// already moderated stay moderated and resending the request reports them as
+// not_pending.
+func (s *SubmissionService) StartBulkModerationJob(ctx context.Context, action string, submissionIDs []uuid.UUID, reviewerID uuid.UUID, reason string) (*models.BulkSubmissionJob, error) {
+	if !s.BulkJobsAvailable() {
+		return nil, ErrBulkSubmissionJobsUnavailable
+	}
+
+	now := time.Now()
+	job := &models.BulkSubmissionJob{
+		ID:         uuid.New(),
+		Action:     action,
+		Status:     models.BulkSubmissionJobQueued,
+		ReviewerID: reviewerID,
+		Total:      len(uniqueSubmissionIDs(submissionIDs)),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := s.saveBulkJob(ctx, job); err != nil {
+		return nil, err
+	}
+
+	// The job outlives the request that started it
+	jobCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), bulkSubmissionJobTimeout)
+	go func() {
+		defer cancel()
+		s.runBulkModerationJob(jobCtx, *job, submissionIDs, reason)
+	}()
+
+	return job, nil
+}
+
+// GetBulkModerationJob returns a bulk moderation job's progress and, once
+// completed, its per-item results
+func (s *SubmissionService) GetBulkModerationJob(ctx context.Context, jobID uuid.UUID) (*models.BulkSubmissionJob, error) {
+	if !s.BulkJobsAvailable() {
+		return nil, ErrBulkSubmissionJobNotFound
+	}
+
+	var job models.BulkSubmissionJob
+	if err := s.redisClient.GetJSON(ctx, bulkJobKey(jobID), &job); err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrBulkSubmissionJobNotFound
+		}
+		return nil, fmt.Errorf("failed to get bulk moderation job: %w", err)
+	}
+	return &job, nil
+}
+
+func (s *SubmissionService) runBulkModerationJob(ctx context.Context, job models.BulkSubmissionJob, submissionIDs []uuid.UUID, reason string) {
+	job.Status = models.BulkSubmissionJobRunning
+	job.UpdatedAt = time.Now()
+	s.saveBulkJobQuietly(ctx, &job)
+
+	progress := func(processed int) {
+		job.Processed = processed
+		job.UpdatedAt = time.Now()
+		s.saveBulkJobQuietly(ctx, &job)
+	}
+
+	result, err := s.moderateBulk(ctx, job.Action, submissionIDs, job.ReviewerID, reason, progress)
+	if err != nil {
+		// The submissions couldn't be loaded, so nothing was moderated
+		result = failedBulkResult(job.Action, submissionIDs, err)
+	}
+
+	now := time.Now()
+	job.Status = models.BulkSubmissionJobCompleted
+	job.Processed = result.Total
+	job.Result = result
+	job.UpdatedAt = now
+	job.CompletedAt = &now
+	s.saveBulkJobQuietly(ctx, &job)
+}
+
+func bulkJobKey(jobID uuid.UUID) string {
+	return "submission_bulk_job:" + jobID.String()
+}
+
+func (s *SubmissionService) saveBulkJob(ctx context.Context, job *models.BulkSubmissionJob) error {
+	if err := s.redisClient.SetJSON(ctx, bulkJobKey(job.ID), job, bulkSubmissionJobTTL); err != nil {
+		return fmt.Errorf("failed to save bulk moderation job: %w", err)
+	}
+	return nil
+}
+
+func (s *SubmissionService) saveBulkJobQuietly(ctx context.Context, job *models.BulkSubmissionJob) {
+	if err := s.saveBulkJob(ctx, job); err != nil {
+		s.logger.Warn("Failed to save bulk moderation job progress", map[string]interface{}{
+			"job_id": job.ID.String(),
+			"error":  err.Error(),
+		})
+	}
+}
+
+// moderateBulk loads the submissions, applies the action to each, and writes
+// one audit log entry for the request
+func (s *SubmissionService) moderateBulk(
+	ctx context.Context,
+	action string,
+	submissionIDs []uuid.UUID,
+	reviewerID uuid.UUID,
+	reason string,
+	progress func(processed int),
+) (*models.BulkSubmissionResult, error) {
+	submissionIDs = uniqueSubmissionIDs(submissionIDs)
+
+	submissions, err := s.submissionRepo.GetByIDs(ctx, submissionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submissions: %w", err)
+	}
+
+	var apply bulkSubmissionApplyFunc
+	switch action {
+	case models.BulkSubmissionActionApprove:
+		apply = func(ctx context.Context, submission *models.ClipSubmission) (*uuid.UUID, error) {
+			return s.approveBulkItem(ctx, submission, reviewerID)
+		}
+	case models.BulkSubmissionActionReject:
+		apply = func(ctx context.Context, submission *models.ClipSubmission) (*uuid.UUID, error) {
+			return nil, s.rejectBulkItem(ctx, submission, reviewerID, reason)
+		}
+	default:
+		return nil, fmt.Errorf("unknown bulk moderation action: %s", action)
+	}
+
+	result := runBulkSubmissions(ctx, action, submissionIDs, submissions, apply, progress)
+	s.logBulkModeration(ctx, result, reviewerID, reason)
+	return result, nil
+}
+
+func (s *SubmissionService) approveBulkItem(ctx context.Context, submission *models.ClipSubmission, reviewerID uuid.UUID) (*uuid.UUID, error) {
+	if err := s.checkClaim(ctx, submission.ID, reviewerID); err != nil {
+		return nil, err
+	}
+
+	clip := buildClipFromSubmission(submission)
+	s.applyDisplayTitle(ctx, clip)
+	if err := s.submissionRepo.ApproveWithClip(ctx, submission.ID, reviewerID, clip); err != nil {
+		return nil, err
+	}
+
+	s.completeClaim(ctx, submission.ID, reviewerID)
+	s.afterClipCreated(ctx, submission, clip)
+	s.afterSubmissionApproved(ctx, submission, reviewerID)
+	return &clip.ID, nil
+}
+
+func (s *SubmissionService) rejectBulkItem(ctx context.Context, submission *models.ClipSubmission, reviewerID uuid.UUID, reason string) error {
+	if err := s.checkClaim(ctx, submission.ID, reviewerID); err != nil {
+		return err
+	}
+	if err := s.submissionRepo.RejectPending(ctx, submission.ID, reviewerID, reason); err != nil {
+		return err
+	}
+
+	s.completeClaim(ctx, submission.ID, reviewerID)
+	s.afterSubmissionRejected(ctx, submission, reviewerID, reason)
+	return nil
+}
+
+func (s *SubmissionService) logBulkModeration(ctx context.Context, result *models.BulkSubmissionResult, reviewerID uuid.UUID, reason string) {
+	if s.auditLogRepo == nil {
+		return
+	}
+
+	succeeded := make([]uuid.UUID, 0, result.Succeeded)
+	failed := make([]uuid.UUID, 0, result.Failed)
+	for _, item := range result.Items {
+		if item.Status == models.BulkSubmissionItemFailed {
+			failed = append(failed, item.SubmissionID)
+		} else {
+			succeeded = append(succeeded, item.SubmissionID)
+		}
+	}
+
+	auditLog := &models.ModerationAuditLog{
+		ID:          uuid.New(),
+		Action:      "bulk_" + result.Action,
+		EntityType:  "clip_submission",
+		EntityID:    uuid.Nil, // No single entity; use Nil UUID for bulk actions
+		ModeratorID: reviewerID,
+		Metadata: map[string]interface{}{
+			"submission_count": result.Total,
+			"submission_ids":   succeeded,
+			"failed_ids":       failed,
+		},
+		CreatedAt: time.Now(),
+	}
+	if reason != "" {
+		auditLog.Reason = &reason
+	}
+	if err := s.auditLogRepo.Create(ctx, auditLog); err != nil {
+		// Log error but don't fail
+		fmt.Printf("Failed to create audit log: %v\n", err)
+	}
+}
+
+// runBulkSubmissions applies an action to each submission in order and
+// collects per-item results. Items that are missing or no longer pending fail
+// without being tried; errors that may be transient are retried with backoff.
+func runBulkSubmissions(
+	ctx context.Context,
+	action string,
+	submissionIDs []uuid.UUID,
+	submissions []*models.ClipSubmission,
+	apply bulkSubmissionApplyFunc,
+	progress func(processed int),
+) *models.BulkSubmissionResult {
+	byID := make(map[uuid.UUID]*models.ClipSubmission, len(submissions))
+	for _, submission := range submissions {
+		byID[submission.ID] = submission
+	}
+
+	successStatus := models.BulkSubmissionItemApproved
+	if action == models.BulkSubmissionActionReject {
+		successStatus = models.BulkSubmissionItemRejected
+	}
+
+	result := &models.BulkSubmissionResult{
+		Action: action,
+		Total:  len(submissionIDs),
+		Items:  make([]models.BulkSubmissionItemResult, 0, len(submissionIDs)),
+	}
+
+	for i, id := range submissionIDs {
+		item := models.BulkSubmissionItemResult{SubmissionID: id}
+
+		submission, ok := byID[id]
+		switch {
+		case !ok:
+			setBulkItemError(&item, repository.ErrSubmissionNotFound)
+		case submission.Status != "pending":
+			setBulkItemError(&item, repository.ErrSubmissionNotPending)
+		default:
+			clipID, err := applyWithRetry(ctx, submission, apply, &item.Attempts)
+			if err != nil {
+				setBulkItemError(&item, err)
+			} else {
+				item.Status = successStatus
+				item.ClipID = clipID
+			}
+		}
+
+		if item.Status == models.BulkSubmissionItemFailed {
+			result.Failed++
+		} else {
+			result.Succeeded++
+		}
+		result.Items = append(result.Items, item)
+
+		if progress != nil {
+			progress(i + 1)
+		}
+	}
+
+	return result
+}
+
+// applyWithRetry applies an action, retrying errors that may be transient
+func applyWithRetry(ctx context.Context, submission *models.ClipSubmission, apply bulkSubmissionApplyFunc, attempts *int) (*uuid.UUID, error) {
+	for attempt := 1; ; attempt++ {
+		*attempts = attempt
+		clipID, err := apply(ctx, submission)
+		if err == nil || !isRetryableBulkError(err) || attempt >= bulkSubmissionMaxAttempts {
+			return clipID, err
+		}
+
+		select {
+		case <-time.After(bulkSubmissionRetryBackoff * time.Duration(attempt)):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// isRetryableBulkError reports whether trying an item again might succeed
+func isRetryableBulkError(err error) bool {
+	return !errors.Is(err, repository.ErrSubmissionNotFound) &&
+		!errors.Is(err, repository.ErrSubmissionNotPending) &&
+		!errors.Is(err, repository.ErrSubmissionClipExists) &&
+		!errors.Is(err, repository.ErrModerationItemClaimed) &&
+		!errors.Is(err, context.Canceled) &&
+		!errors.Is(err, context.DeadlineExceeded)
+}
+
+func setBulkItemError(item *models.BulkSubmissionItemResult, err error) {
+	item.Status = models.BulkSubmissionItemFailed
+	item.Error = err.Error()
+	switch {
+	case errors.Is(err, repository.ErrSubmissionNotFound):
+		item.ErrorCode = models.BulkSubmissionErrorNotFound
+	case errors.Is(err, repository.ErrSubmissionNotPending):
+		item.ErrorCode = models.BulkSubmissionErrorNotPending
+	case errors.Is(err, repository.ErrSubmissionClipExists):
+		item.ErrorCode = models.BulkSubmissionErrorClipExists
+	case errors.Is(err, repository.ErrModerationItemClaimed):
+		item.ErrorCode = models.BulkSubmissionErrorClaimed
+	default:
+		item.ErrorCode = models.BulkSubmissionErrorInternal
+		item.Error = "failed to moderate submission"
+		item.Retryable = true
+	}
+}
+
+// failedBulkResult reports every item failed when none could be attempted
+func failedBulkResult(action string, submissionIDs []uuid.UUID, err error) *models.BulkSubmissionResult {
+	submissionIDs = uniqueSubmissionIDs(submissionIDs)
+	result := &models.BulkSubmissionResult{
+		Action: action,
+		Total:  len(submissionIDs),
+		Failed: len(submissionIDs),
+		Items:  make([]models.BulkSubmissionItemResult, 0, len(submissionIDs)),
+	}
+	for _, id := range submissionIDs {
+		item := models.BulkSubmissionItemResult{SubmissionID: id}
+		setBulkItemError(&item, err)
+		result.Items = append(result.Items, item)
+	}
+	return result
+}
+
+// uniqueSubmissionIDs drops repeated IDs, keeping the first occurrence's order
+func uniqueSubmissionIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+func pendingSubmissions(n int) ([]uuid.UUID, []*models.ClipSubmission) {
+	ids := make([]uuid.UUID, 0, n)
+	submissions := make([]*models.ClipSubmission, 0, n)
+	for i := 0; i < n; i++ {
+		id := uuid.New()
+		ids = append(ids, id)
+		submissions = append(submissions, &models.ClipSubmission{ID: id, Status: "pending"})
+	}
+	return ids, submissions
+}
+
+func TestRunBulkSubmissions_PartialFailure(t *testing.T) {
+	ids, submissions := pendingSubmissions(3)
+	submissions[1].Status = "approved"
+	missingID := uuid.New()
+	ids = append(ids, missingID)
+
+	applied := map[uuid.UUID]int{}
+	apply := func(ctx context.Context, submission *models.ClipSubmission) (*uuid.UUID, error) {
+		applied[submission.ID]++
+		if submission.ID == ids[2] {
+			return nil, repository.ErrSubmissionClipExists
+		}
+		clipID := uuid.New()
+		return &clipID, nil
+	}
+
+	result := runBulkSubmissions(context.Background(), models.BulkSubmissionActionApprove, ids, submissions, apply, nil)
+
+	assert.Equal(t, 4, result.Total)
+	assert.Equal(t, 1, result.Succeeded)
+	assert.Equal(t, 3, result.Failed)
+	require.Len(t, result.Items, 4)
+
+	assert.Equal(t, models.BulkSubmissionItemApproved, result.Items[0].Status)
+	assert.NotNil(t, result.Items[0].ClipID)
+	assert.Equal(t, models.BulkSubmissionErrorNotPending, result.Items[1].ErrorCode)
+	assert.Equal(t, models.BulkSubmissionErrorClipExists, result.Items[2].ErrorCode)
+	assert.False(t, result.Items[2].Retryable)
+	assert.Equal(t, models.BulkSubmissionErrorNotFound, result.Items[3].ErrorCode)
+
+	// Items that can't succeed are never tried
+	assert.Zero(t, applied[ids[1]])
+	assert.Zero(t, applied[missingID])
+	assert.Equal(t, 1, applied[ids[2]])
+}
+
+func TestRunBulkSubmissions_RetriesTransientErrors(t *testing.T) {
+	ids, submissions := pendingSubmissions(2)
+
+	calls := map[uuid.UUID]int{}
+	apply := func(ctx context.Context, submission *models.ClipSubmission) (*uuid.UUID, error) {
+		calls[submission.ID]++
+		// The first item recovers on its second try; the second never does
+		if submission.ID == ids[0] && calls[submission.ID] > 1 {
+			return nil, nil
+		}
+		return nil, errors.New("connection reset")
+	}
+
+	result := runBulkSubmissions(context.Background(), models.BulkSubmissionActionReject, ids, submissions, apply, nil)
+
+	assert.Equal(t, models.BulkSubmissionItemRejected, result.Items[0].Status)
+	assert.Equal(t, 2, result.Items[0].Attempts)
+
+	assert.Equal(t, models.BulkSubmissionItemFailed, result.Items[1].Status)
+	assert.Equal(t, models.BulkSubmissionErrorInternal, result.Items[1].ErrorCode)
+	assert.True(t, result.Items[1].Retryable)
+	assert.Equal(t, bulkSubmissionMaxAttempts, result.Items[1].Attempts)
+	assert.Equal(t, bulkSubmissionMaxAttempts, calls[ids[1]])
+}
+
+func TestRunBulkSubmissions_ReportsProgress(t *testing.T) {
+	ids, submissions := pendingSubmissions(3)
+
+	var progress []int
+	apply := func(ctx context.Context, submission *models.ClipSubmission) (*uuid.UUID, error) {
+		return nil, nil
+	}
+
+	runBulkSubmissions(context.Background(), models.BulkSubmissionActionReject, ids, submissions, apply, func(processed int) {
+		progress = append(progress, processed)
+	})
+
+	assert.Equal(t, []int{1, 2, 3}, progress)
+}
+
+func TestUniqueSubmissionIDs(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+
+	assert.Equal(t, []uuid.UUID{a, b}, uniqueSubmissionIDs([]uuid.UUID{a, b, a, b, a}))
+}
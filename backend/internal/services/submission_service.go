@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -13,6 +14,7 @@ import (
 	"github.com/subculture-collective/clipper/internal/models"
 	"github.com/subculture-collective/clipper/internal/repository"
 	"github.com/subculture-collective/clipper/internal/utils"
+	"github.com/subculture-collective/clipper/pkg/metrics"
 	redispkg "github.com/subculture-collective/clipper/pkg/redis"
 	"github.com/subculture-collective/clipper/pkg/twitch"
 	pkgutils "github.com/subculture-collective/clipper/pkg/utils"
@@ -33,6 +35,13 @@ type SubmissionService struct {
 	moderationEvents    *ModerationEventService
 	webhookService      *OutboundWebhookService
 	cacheService        *CacheService
+	invalidationBus     *CacheInvalidationBus
+	broadcasterApproval *BroadcasterApprovalService
+	automod             ContentAutomod
+	claims              ModerationClaimGuard
+	titles              ClipTitleNormalizer
+	karma               KarmaAwarder
+	achievements        AchievementTracker
 	cfg                 *config.Config
 	logger              *pkgutils.StructuredLogger
 
@@ -90,6 +99,86 @@ func NewSubmissionService(
 	}
 }
 
+// SetTitleNormalizer sets the normalizer that gives approved clips a display title
+func (s *SubmissionService) SetTitleNormalizer(titles ClipTitleNormalizer) {
+	s.titles = titles
+}
+
+// SetCacheInvalidationBus makes created clips publish an invalidation event
+// instead of clearing the feed caches directly
+func (s *SubmissionService) SetCacheInvalidationBus(bus *CacheInvalidationBus) {
+	s.invalidationBus = bus
+}
+
+// applyDisplayTitle gives a clip about to be saved its display title
+func (s *SubmissionService) applyDisplayTitle(ctx context.Context, clip *models.Clip) {
+	if s.titles != nil {
+		s.titles.ApplyDisplayTitle(ctx, clip)
+	}
+}
+
+// SetBroadcasterApprovalService sets the service that holds clips for broadcaster approval
+func (s *SubmissionService) SetBroadcasterApprovalService(broadcasterApproval *BroadcasterApprovalService) {
+	s.broadcasterApproval = broadcasterApproval
+}
+
+// SetAutomod evaluates new submissions against the automoderation rules
+func (s *SubmissionService) SetAutomod(automod ContentAutomod) {
+	s.automod = automod
+}
+
+// SetClaimGuard keeps moderators from reviewing submissions another moderator claimed
+func (s *SubmissionService) SetClaimGuard(claims ModerationClaimGuard) {
+	s.claims = claims
+}
+
+// SetKarmaAwarder awards submission karma under the karma rules instead of
+// the built-in amounts
+func (s *SubmissionService) SetKarmaAwarder(karma KarmaAwarder) {
+	s.karma = karma
+}
+
+// SetAchievementTracker counts approved submissions toward achievements
+func (s *SubmissionService) SetAchievementTracker(achievements AchievementTracker) {
+	s.achievements = achievements
+}
+
+// recordApprovalProgress counts an approved submission toward the submitter's achievements
+func (s *SubmissionService) recordApprovalProgress(ctx context.Context, submission *models.ClipSubmission) {
+	if s.achievements != nil {
+		s.achievements.RecordProgress(ctx, submission.UserID, models.AchievementMetricSubmissionsApproved, submission.ID)
+	}
+}
+
+// checkClaim refuses a review of a submission claimed by another moderator
+func (s *SubmissionService) checkClaim(ctx context.Context, submissionID, reviewerID uuid.UUID) error {
+	if s.claims == nil {
+		return nil
+	}
+	return s.claims.CheckClaim(ctx, models.ModerationClaimItemSubmission, submissionID, reviewerID)
+}
+
+// completeClaim resolves the reviewer's claim on a submission they reviewed
+func (s *SubmissionService) completeClaim(ctx context.Context, submissionID, reviewerID uuid.UUID) {
+	if s.claims != nil {
+		s.claims.CompleteClaim(ctx, models.ModerationClaimItemSubmission, submissionID, reviewerID)
+	}
+}
+
+// submissionAutomodText returns the text of a submission automoderation
+// rules are evaluated against. The clip URL is left out, so link policies
+// only apply to links in the submitted text.
+func submissionAutomodText(req *SubmitClipRequest) string {
+	var parts []string
+	for _, field := range []*string{req.CustomTitle, req.BroadcasterNameOverride, req.SubmissionReason} {
+		if field != nil && *field != "" {
+			parts = append(parts, *field)
+		}
+	}
+	parts = append(parts, req.Tags...)
+	return strings.Join(parts, "\n")
+}
+
 // GetAbuseDetector returns the abuse detector instance
 func (s *SubmissionService) GetAbuseDetector() *SubmissionAbuseDetector {
 	return s.abuseDetector
@@ -252,6 +341,28 @@ func (s *SubmissionService) GetClipMetadata(ctx context.Context, clipURLOrID str
 
 // SubmitClip handles clip submission with validation and duplicate detection
 func (s *SubmissionService) SubmitClip(ctx context.Context, userID uuid.UUID, req *SubmitClipRequest, ip string, deviceFingerprint string) (*models.ClipSubmission, error) {
+	submission, err := s.submitClip(ctx, userID, req, ip, deviceFingerprint)
+	metrics.RecordSubmission(submissionOutcome(submission, err))
+	return submission, err
+}
+
+// submissionOutcome labels a submission attempt for metrics
+func submissionOutcome(submission *models.ClipSubmission, err error) string {
+	var validationErr *ValidationError
+	var rateLimitErr *RateLimitError
+	switch {
+	case errors.As(err, &validationErr):
+		return "invalid"
+	case errors.As(err, &rateLimitErr):
+		return "rate_limited"
+	case err != nil || submission == nil:
+		return "error"
+	default:
+		return submission.Status
+	}
+}
+
+func (s *SubmissionService) submitClip(ctx context.Context, userID uuid.UUID, req *SubmitClipRequest, ip string, deviceFingerprint string) (*models.ClipSubmission, error) {
 	// Validate and normalize input fields first
 	if err := s.validateSubmissionInput(req); err != nil {
 		return nil, err
@@ -355,7 +466,7 @@ func (s *SubmissionService) SubmitClip(ctx context.Context, userID uuid.UUID, re
 		}
 
 		// Award karma for claiming
-		if err := s.awardKarma(ctx, userID, 10); err != nil {
+		if err := s.awardKarma(ctx, userID, models.KarmaEventClipClaimed, clipExistence.Clip.ID, 10); err != nil {
 			// Log error but don't fail
 			log.Printf("Failed to award karma: %v\n", err)
 		}
@@ -497,8 +608,37 @@ func (s *SubmissionService) SubmitClip(ctx context.Context, userID uuid.UUID, re
 		ViewCount:       twitchClip.ViewCount,
 	}
 
+	// Channels whose broadcaster requires approval hold the clip, even for trusted submitters
+	var approvalPolicy *models.BroadcasterApprovalPolicy
+	if s.broadcasterApproval != nil {
+		approvalPolicy, err = s.broadcasterApproval.PolicyFor(ctx, twitchClip.BroadcasterID, user)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Automoderation can reject the submission or hold it for moderator review
+	var verdict *models.AutomodVerdict
+	if s.automod != nil {
+		verdict = s.automod.Evaluate(ctx, &AutomodContent{
+			Target:   models.AutomodTargetSubmission,
+			Text:     submissionAutomodText(req),
+			AuthorID: userID,
+		})
+	}
+
 	// Check for auto-approval
-	if s.shouldAutoApprove(user) {
+	if verdict != nil && verdict.Action == models.AutomodActionRemove {
+		reason := "Removed by automoderation"
+		submission.Status = "rejected"
+		submission.RejectionReason = &reason
+		approvalPolicy = nil
+	} else if verdict.Hides() {
+		// Held and shadow-hidden submissions wait for moderator review
+		approvalPolicy = nil
+	} else if approvalPolicy != nil {
+		submission.Status = models.SubmissionStatusAwaitingBroadcaster
+	} else if s.shouldAutoApprove(user) {
 		submission.Status = "approved"
 		submission.ReviewedBy = &userID
 		submission.ReviewedAt = &submission.CreatedAt
@@ -513,7 +653,7 @@ func (s *SubmissionService) SubmitClip(ctx context.Context, userID uuid.UUID, re
 		submission.ClipID = &clipID
 
 		// Award karma
-		if err := s.awardKarma(ctx, userID, 10); err != nil {
+		if err := s.awardKarma(ctx, userID, models.KarmaEventSubmissionApproved, submission.ID, 10); err != nil {
 			// Log error but don't fail
 			fmt.Printf("Failed to award karma: %v\n", err)
 		}
@@ -524,6 +664,25 @@ func (s *SubmissionService) SubmitClip(ctx context.Context, userID uuid.UUID, re
 		return nil, fmt.Errorf("failed to create submission: %w", err)
 	}
 
+	if verdict != nil {
+		s.automod.Report(ctx, models.AutomodTargetSubmission, submission.ID, verdict)
+	}
+
+	if submission.Status == "approved" {
+		s.recordApprovalProgress(ctx, submission)
+	}
+
+	if approvalPolicy != nil {
+		if err := s.broadcasterApproval.HoldSubmission(ctx, approvalPolicy, submission); err != nil {
+			// Fall back to moderator review so the submission is never stuck without a queue
+			log.Printf("Failed to queue submission %s for broadcaster approval: %v", submission.ID, err)
+			if err := s.submissionRepo.SetStatus(ctx, submission.ID, "pending"); err != nil {
+				return nil, fmt.Errorf("failed to update submission status: %w", err)
+			}
+			submission.Status = "pending"
+		}
+	}
+
 	// Trigger webhook for clip submission
 	if s.webhookService != nil {
 		webhookData := map[string]interface{}{
@@ -980,6 +1139,12 @@ func (s *SubmissionService) checkDuplicates(ctx context.Context, twitchClipID st
 				Message: "This clip is already pending review. You'll be notified once it's been reviewed by our moderators.",
 			}
 		}
+		if submission.Status == models.SubmissionStatusAwaitingBroadcaster {
+			return &ValidationError{
+				Field:   "clip_url",
+				Message: "This clip is already waiting for the broadcaster's approval",
+			}
+		}
 		if submission.Status == "approved" {
 			return &ValidationError{
 				Field:   "clip_url",
@@ -1138,6 +1303,21 @@ func (s *SubmissionService) shouldAutoApprove(user *models.User) bool {
 
 // createClipFromSubmission creates a clip in the main clips table
 func (s *SubmissionService) createClipFromSubmission(ctx context.Context, submission *models.ClipSubmission) (uuid.UUID, error) {
+	clip := buildClipFromSubmission(submission)
+	s.applyDisplayTitle(ctx, clip)
+
+	// Create the clip
+	if err := s.clipRepo.Create(ctx, clip); err != nil {
+		return uuid.Nil, err
+	}
+
+	s.afterClipCreated(ctx, submission, clip)
+
+	return clip.ID, nil
+}
+
+// buildClipFromSubmission builds the clip a submission becomes once approved
+func buildClipFromSubmission(submission *models.ClipSubmission) *models.Clip {
 	emptyStr := ""
 	title := utils.StringOrDefault(submission.CustomTitle, submission.Title)
 	creatorName := utils.StringOrDefault(submission.CreatorName, &emptyStr)
@@ -1167,12 +1347,11 @@ func (s *SubmissionService) createClipFromSubmission(ctx context.Context, submis
 		SubmittedByUserID: &submission.UserID,
 		SubmittedAt:       &submission.CreatedAt, // Use submission creation time as when it was submitted
 	}
+	return clip
+}
 
-	// Create the clip
-	if err := s.clipRepo.Create(ctx, clip); err != nil {
-		return uuid.Nil, err
-	}
-
+// afterClipCreated runs the best-effort follow-ups to creating a submission's clip
+func (s *SubmissionService) afterClipCreated(ctx context.Context, submission *models.ClipSubmission, clip *models.Clip) {
 	// Auto-upvote: Create an upvote from the submitter
 	// This encourages engagement and shows creator approval
 	if s.voteRepo != nil {
@@ -1187,7 +1366,14 @@ func (s *SubmissionService) createClipFromSubmission(ctx context.Context, submis
 	}
 
 	// Invalidate feed caches so the new clip appears immediately
-	if s.cacheService != nil {
+	if s.invalidationBus != nil {
+		if err := s.invalidationBus.Publish(ctx, NewClipInvalidationEvent(CacheTopicClipCreated, clip)); err != nil {
+			s.logger.Warn("Failed to publish cache invalidation for clip", map[string]interface{}{
+				"clip_id": clip.ID,
+				"error":   err.Error(),
+			})
+		}
+	} else if s.cacheService != nil {
 		if err := s.cacheService.InvalidateOnNewClip(ctx, clip); err != nil {
 			// Log error but don't fail the clip creation
 			s.logger.Warn("Failed to invalidate feed caches for clip", map[string]interface{}{
@@ -1196,18 +1382,23 @@ func (s *SubmissionService) createClipFromSubmission(ctx context.Context, submis
 			})
 		}
 	}
-
-	return clip.ID, nil
 }
 
-// awardKarma awards karma points to a user
-func (s *SubmissionService) awardKarma(ctx context.Context, userID uuid.UUID, points int) error {
+// awardKarma awards a user the karma for a submission event. With a karma
+// awarder set, the event's karma rule decides the points and replays award
+// nothing; otherwise the default points are awarded.
+func (s *SubmissionService) awardKarma(ctx context.Context, userID uuid.UUID, eventType string, sourceID uuid.UUID, defaultPoints int) error {
+	if s.karma != nil {
+		_, err := s.karma.AwardKarma(ctx, userID, eventType, sourceID)
+		return err
+	}
+
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return err
 	}
 
-	user.KarmaPoints += points
+	user.KarmaPoints += defaultPoints
 	return s.userRepo.Update(ctx, user)
 }
 
@@ -1233,6 +1424,10 @@ func (s *SubmissionService) ApproveSubmission(ctx context.Context, submissionID,
 		return fmt.Errorf("submission is not pending")
 	}
 
+	if err := s.checkClaim(ctx, submissionID, reviewerID); err != nil {
+		return err
+	}
+
 	// Create clip
 	clipID, err := s.createClipFromSubmission(ctx, submission)
 	if err != nil {
@@ -1265,11 +1460,24 @@ func (s *SubmissionService) ApproveSubmission(ctx context.Context, submissionID,
 		}
 	}
 
+	s.completeClaim(ctx, submissionID, reviewerID)
+	s.afterSubmissionApproved(ctx, submission, reviewerID)
+
+	return nil
+}
+
+// afterSubmissionApproved awards karma, counts the approval toward
+// achievements, notifies the submitter and publishes the approval webhook.
+// Failures are logged, not returned.
+func (s *SubmissionService) afterSubmissionApproved(ctx context.Context, submission *models.ClipSubmission, reviewerID uuid.UUID) {
+	submissionID := submission.ID
+
 	// Award karma to submitter
-	if err := s.awardKarma(ctx, submission.UserID, 10); err != nil {
+	if err := s.awardKarma(ctx, submission.UserID, models.KarmaEventSubmissionApproved, submissionID, 10); err != nil {
 		// Log error but don't fail
 		fmt.Printf("Failed to award karma: %v\n", err)
 	}
+	s.recordApprovalProgress(ctx, submission)
 
 	// Send notification to submitter
 	if s.notificationService != nil {
@@ -1298,8 +1506,6 @@ func (s *SubmissionService) ApproveSubmission(ctx context.Context, submissionID,
 			log.Printf("Failed to trigger webhook event: %v", err)
 		}
 	}
-
-	return nil
 }
 
 // RejectSubmission rejects a submission
@@ -1313,6 +1519,10 @@ func (s *SubmissionService) RejectSubmission(ctx context.Context, submissionID,
 		return fmt.Errorf("submission is not pending")
 	}
 
+	if err := s.checkClaim(ctx, submissionID, reviewerID); err != nil {
+		return err
+	}
+
 	// Update submission status
 	if err := s.submissionRepo.UpdateStatus(ctx, submissionID, "rejected", reviewerID, &reason); err != nil {
 		return fmt.Errorf("failed to update submission status: %w", err)
@@ -1335,8 +1545,19 @@ func (s *SubmissionService) RejectSubmission(ctx context.Context, submissionID,
 		}
 	}
 
+	s.completeClaim(ctx, submissionID, reviewerID)
+	s.afterSubmissionRejected(ctx, submission, reviewerID, reason)
+
+	return nil
+}
+
+// afterSubmissionRejected penalizes karma, notifies the submitter and
+// publishes the rejection webhook. Failures are logged, not returned.
+func (s *SubmissionService) afterSubmissionRejected(ctx context.Context, submission *models.ClipSubmission, reviewerID uuid.UUID, reason string) {
+	submissionID := submission.ID
+
 	// Penalize karma
-	if err := s.awardKarma(ctx, submission.UserID, -5); err != nil {
+	if err := s.awardKarma(ctx, submission.UserID, models.KarmaEventSubmissionRejected, submissionID, -5); err != nil {
 		// Log error but don't fail
 		fmt.Printf("Failed to penalize karma: %v\n", err)
 	}
@@ -1369,116 +1590,138 @@ func (s *SubmissionService) RejectSubmission(ctx context.Context, submissionID,
 			log.Printf("Failed to trigger webhook event: %v", err)
 		}
 	}
-
-	return nil
 }
 
-// BulkApproveSubmissions approves multiple submissions
-func (s *SubmissionService) BulkApproveSubmissions(ctx context.Context, submissionIDs []uuid.UUID, reviewerID uuid.UUID) error {
-	// Get submissions to validate they're all pending
-	submissions, err := s.submissionRepo.GetByIDs(ctx, submissionIDs)
+// ReleaseBroadcasterHold moves a submission on once the broadcaster approved it
+// or the review deadline passed. Submitters who qualify for auto-approval go
+// live immediately; everyone else joins the moderation queue.
+func (s *SubmissionService) ReleaseBroadcasterHold(ctx context.Context, submissionID uuid.UUID, expired bool) error {
+	submission, err := s.submissionRepo.GetByID(ctx, submissionID)
 	if err != nil {
-		return fmt.Errorf("failed to get submissions: %w", err)
+		return fmt.Errorf("failed to get submission: %w", err)
 	}
 
-	// Validate all are pending
-	for _, submission := range submissions {
-		if submission.Status != "pending" {
-			return fmt.Errorf("submission %s is not pending", submission.ID)
-		}
+	if submission.Status != models.SubmissionStatusAwaitingBroadcaster {
+		return fmt.Errorf("submission is not awaiting broadcaster approval")
 	}
 
-	// Create clips for all submissions
-	for _, submission := range submissions {
-		if _, err := s.createClipFromSubmission(ctx, submission); err != nil {
-			return fmt.Errorf("failed to create clip for submission %s: %w", submission.ID, err)
+	submitter, err := s.userRepo.GetByID(ctx, submission.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get submitter: %w", err)
+	}
+
+	if !s.shouldAutoApprove(submitter) {
+		if err := s.submissionRepo.SetStatus(ctx, submissionID, "pending"); err != nil {
+			return fmt.Errorf("failed to update submission status: %w", err)
 		}
+
+		if s.notificationService != nil {
+			clipTitle := getClipTitle(submission)
+			if err := s.notificationService.NotifySubmissionBroadcasterApproved(ctx, submission.UserID, submissionID, clipTitle, expired); err != nil {
+				// Log error but don't fail
+				fmt.Printf("Failed to send notification: %v\n", err)
+			}
+		}
+		return nil
 	}
 
-	// Bulk update status
-	if err := s.submissionRepo.BulkUpdateStatus(ctx, submissionIDs, "approved", reviewerID, nil); err != nil {
-		return fmt.Errorf("failed to bulk update submission status: %w", err)
+	// Create clip
+	clipID, err := s.createClipFromSubmission(ctx, submission)
+	if err != nil {
+		return fmt.Errorf("failed to create clip: %w", err)
 	}
 
-	// Create audit log
-	if s.auditLogRepo != nil {
-		metadata := map[string]interface{}{
-			"submission_count": len(submissionIDs),
-			"submission_ids":   submissionIDs,
-		}
-		auditLog := &models.ModerationAuditLog{
-			ID:          uuid.New(),
-			Action:      "bulk_approve",
-			EntityType:  "clip_submission",
-			EntityID:    uuid.Nil, // Use uuid.Nil as entity ID for bulk actions
-			ModeratorID: reviewerID,
-			Metadata:    metadata,
-			CreatedAt:   time.Now(),
-		}
-		if err := s.auditLogRepo.Create(ctx, auditLog); err != nil {
+	// Auto-approved submissions are reviewed by their submitter, as in SubmitClip
+	if err := s.submissionRepo.UpdateStatus(ctx, submissionID, "approved", submission.UserID, nil); err != nil {
+		return fmt.Errorf("failed to update submission status: %w", err)
+	}
+
+	if err := s.submissionRepo.UpdateClipID(ctx, submissionID, clipID); err != nil {
+		return fmt.Errorf("failed to update submission clip ID: %w", err)
+	}
+
+	// Award karma to submitter
+	if err := s.awardKarma(ctx, submission.UserID, models.KarmaEventSubmissionApproved, submissionID, 10); err != nil {
+		// Log error but don't fail
+		fmt.Printf("Failed to award karma: %v\n", err)
+	}
+	s.recordApprovalProgress(ctx, submission)
+
+	// Send notification to submitter
+	if s.notificationService != nil {
+		clipTitle := getClipTitle(submission)
+		if err := s.notificationService.NotifySubmissionApproved(ctx, submission.UserID, submissionID, clipTitle); err != nil {
 			// Log error but don't fail
-			fmt.Printf("Failed to create audit log: %v\n", err)
+			fmt.Printf("Failed to send notification: %v\n", err)
 		}
 	}
 
-	// Award karma to submitters
-	for _, submission := range submissions {
-		if err := s.awardKarma(ctx, submission.UserID, 10); err != nil {
-			// Log error but don't fail
-			fmt.Printf("Failed to award karma: %v\n", err)
+	// Trigger webhook for approval
+	if s.webhookService != nil {
+		webhookData := map[string]interface{}{
+			"submission_id":   submissionID.String(),
+			"user_id":         submission.UserID.String(),
+			"twitch_clip_id":  submission.TwitchClipID,
+			"twitch_clip_url": submission.TwitchClipURL,
+			"auto_approved":   true,
+			"approved_at":     time.Now(),
+		}
+		if submission.CustomTitle != nil {
+			webhookData["custom_title"] = *submission.CustomTitle
+		}
+
+		if err := s.webhookService.TriggerEvent(ctx, models.WebhookEventClipApproved, submissionID, webhookData); err != nil {
+			log.Printf("Failed to trigger webhook event: %v", err)
 		}
 	}
 
 	return nil
 }
 
-// BulkRejectSubmissions rejects multiple submissions
-func (s *SubmissionService) BulkRejectSubmissions(ctx context.Context, submissionIDs []uuid.UUID, reviewerID uuid.UUID, reason string) error {
-	// Get submissions to validate they're all pending
-	submissions, err := s.submissionRepo.GetByIDs(ctx, submissionIDs)
+// RejectBroadcasterHold rejects a submission the broadcaster declined. Unlike
+// moderator rejections the submitter's karma is left alone, since the clip was
+// turned down by channel preference rather than for breaking the rules.
+func (s *SubmissionService) RejectBroadcasterHold(ctx context.Context, submissionID, broadcasterUserID uuid.UUID, reason string) error {
+	submission, err := s.submissionRepo.GetByID(ctx, submissionID)
 	if err != nil {
-		return fmt.Errorf("failed to get submissions: %w", err)
+		return fmt.Errorf("failed to get submission: %w", err)
 	}
 
-	// Validate all are pending
-	for _, submission := range submissions {
-		if submission.Status != "pending" {
-			return fmt.Errorf("submission %s is not pending", submission.ID)
-		}
+	if submission.Status != models.SubmissionStatusAwaitingBroadcaster {
+		return fmt.Errorf("submission is not awaiting broadcaster approval")
 	}
 
-	// Bulk update status
-	if err := s.submissionRepo.BulkUpdateStatus(ctx, submissionIDs, "rejected", reviewerID, &reason); err != nil {
-		return fmt.Errorf("failed to bulk update submission status: %w", err)
+	if err := s.submissionRepo.UpdateStatus(ctx, submissionID, "rejected", broadcasterUserID, &reason); err != nil {
+		return fmt.Errorf("failed to update submission status: %w", err)
 	}
 
-	// Create audit log
-	if s.auditLogRepo != nil {
-		metadata := map[string]interface{}{
-			"submission_count": len(submissionIDs),
-			"submission_ids":   submissionIDs,
-		}
-		auditLog := &models.ModerationAuditLog{
-			ID:          uuid.New(),
-			Action:      "bulk_reject",
-			EntityType:  "clip_submission",
-			EntityID:    uuid.Nil, // No single entity; use Nil UUID for bulk actions
-			ModeratorID: reviewerID,
-			Reason:      &reason,
-			Metadata:    metadata,
-			CreatedAt:   time.Now(),
-		}
-		if err := s.auditLogRepo.Create(ctx, auditLog); err != nil {
+	// Send notification to submitter
+	if s.notificationService != nil {
+		clipTitle := getClipTitle(submission)
+		if err := s.notificationService.NotifySubmissionRejected(ctx, submission.UserID, submissionID, clipTitle, "Declined by the broadcaster: "+reason); err != nil {
 			// Log error but don't fail
-			fmt.Printf("Failed to create audit log: %v\n", err)
+			fmt.Printf("Failed to send notification: %v\n", err)
 		}
 	}
 
-	// Penalize karma
-	for _, submission := range submissions {
-		if err := s.awardKarma(ctx, submission.UserID, -5); err != nil {
-			// Log error but don't fail
-			fmt.Printf("Failed to penalize karma: %v\n", err)
+	// Trigger webhook for rejection
+	if s.webhookService != nil {
+		webhookData := map[string]interface{}{
+			"submission_id":    submissionID.String(),
+			"user_id":          submission.UserID.String(),
+			"twitch_clip_id":   submission.TwitchClipID,
+			"twitch_clip_url":  submission.TwitchClipURL,
+			"reviewer_id":      broadcasterUserID.String(),
+			"rejected_by":      "broadcaster",
+			"rejection_reason": reason,
+			"rejected_at":      time.Now(),
+		}
+		if submission.CustomTitle != nil {
+			webhookData["custom_title"] = *submission.CustomTitle
+		}
+
+		if err := s.webhookService.TriggerEvent(ctx, models.WebhookEventClipRejected, submissionID, webhookData); err != nil {
+			log.Printf("Failed to trigger webhook event: %v", err)
 		}
 	}
 
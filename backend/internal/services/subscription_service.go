@@ -66,6 +66,13 @@ type SubscriptionService struct {
 	auditLogSvc    *AuditLogService
 	dunningService *DunningService
 	emailService   *EmailService
+	bundles        BundlePurchaseWebhookHandler
+}
+
+// BundlePurchaseWebhookHandler handles Stripe events for one-time playlist bundle purchases
+type BundlePurchaseWebhookHandler interface {
+	HandleCheckoutCompleted(ctx context.Context, event stripe.Event) error
+	HandleChargeRefunded(ctx context.Context, event stripe.Event) error
 }
 
 // NewSubscriptionService creates a new subscription service
@@ -94,6 +101,11 @@ func NewSubscriptionService(
 	}
 }
 
+// SetBundlePurchaseHandler sets the service that grants and refunds playlist bundle purchases
+func (s *SubscriptionService) SetBundlePurchaseHandler(bundles BundlePurchaseWebhookHandler) {
+	s.bundles = bundles
+}
+
 // GetRepository exposes the underlying subscription repository for tests and auxiliary services
 // This maintains clear separation while allowing integration tests to inspect persisted state.
 func (s *SubscriptionService) GetRepository() repository.SubscriptionRepositoryInterface {
@@ -388,6 +400,16 @@ func (s *SubscriptionService) processWebhookWithRetry(ctx context.Context, event
 		return s.handlePaymentIntentFailed(ctx, event)
 	case "charge.dispute.created":
 		return s.handleDisputeCreated(ctx, event)
+	case "checkout.session.completed", "checkout.session.async_payment_succeeded":
+		if s.bundles == nil {
+			return nil
+		}
+		return s.bundles.HandleCheckoutCompleted(ctx, event)
+	case "charge.refunded":
+		if s.bundles == nil {
+			return nil
+		}
+		return s.bundles.HandleChargeRefunded(ctx, event)
 	default:
 		logWebhookWarn("Unhandled webhook event type", map[string]interface{}{
 			"event_type": event.Type,
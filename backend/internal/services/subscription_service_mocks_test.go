@@ -93,6 +93,24 @@ func (m *MockUserRepository) Update(ctx context.Context, user *models.User) erro
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) GetByTwitchID(ctx context.Context, twitchID string) (*models.User, error) {
+	args := m.Called(ctx, twitchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateLastLogin(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ClearDeviceTokenIfMatches(ctx context.Context, userID uuid.UUID, deviceToken string) error {
+	args := m.Called(ctx, userID, deviceToken)
+	return args.Error(0)
+}
+
 // MockWebhookRepository is a mock implementation of WebhookRepositoryInterface
 type MockWebhookRepository struct {
 	mock.Mock
@@ -131,6 +149,11 @@ func (m *MockAuditLogService) LogSubscriptionEvent(ctx context.Context, userID u
 	return args.Error(0)
 }
 
+func (m *MockAuditLogService) LogAction(ctx context.Context, action string, actorID uuid.UUID, targetID uuid.UUID, entityType string, opts AuditLogOptions) error {
+	args := m.Called(ctx, action, actorID, targetID, entityType, opts)
+	return args.Error(0)
+}
+
 // MockDunningService is a mock implementation of DunningService for testing
 type MockDunningService struct {
 	mock.Mock
@@ -0,0 +1,493 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/subculture-collective/clipper/internal/models"
+	redispkg "github.com/subculture-collective/clipper/pkg/redis"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+// Syndication formats
+const (
+	SyndicationFormatRSS  = "rss"
+	SyndicationFormatAtom = "atom"
+)
+
+const (
+	// syndicationMaxItems caps how many clips a syndication feed lists
+	syndicationMaxItems = 50
+	// syndicationCacheTTL is how long a rendered syndication feed is cached
+	syndicationCacheTTL = 5 * time.Minute
+	// SyndicationMaxAge is how long clients and proxies may reuse a feed, in seconds
+	SyndicationMaxAge = 300
+)
+
+// ErrSyndicationNotFound is returned for feeds, broadcasters and tags that
+// have no public syndication feed
+var ErrSyndicationNotFound = errors.New("syndication feed not found")
+
+// ErrSyndicationFormat is returned for unsupported syndication formats
+var ErrSyndicationFormat = errors.New("format must be rss or atom")
+
+// SyndicationFeedSource defines the feed methods used by SyndicationService
+type SyndicationFeedSource interface {
+	GetFeed(ctx context.Context, feedID uuid.UUID, requestingUserID *uuid.UUID) (*models.Feed, error)
+	GetFeedClips(ctx context.Context, feedID uuid.UUID, requestingUserID *uuid.UUID) ([]*models.FeedItemWithClip, error)
+}
+
+// SyndicationClipRepository defines the clip methods used by SyndicationService
+type SyndicationClipRepository interface {
+	ListClipsByBroadcaster(ctx context.Context, broadcasterID, sort string, limit, offset int) ([]models.Clip, int, error)
+	GetByIDs(ctx context.Context, clipIDs []uuid.UUID) ([]models.Clip, error)
+}
+
+// SyndicationTagRepository defines the tag methods used by SyndicationService
+type SyndicationTagRepository interface {
+	GetBySlug(ctx context.Context, slug string) (*models.Tag, error)
+	GetClipsByTag(ctx context.Context, tagSlug string, limit, offset int) ([]uuid.UUID, error)
+}
+
+// SyndicationDocument is a rendered RSS or Atom feed
+type SyndicationDocument struct {
+	ContentType string    `json:"content_type"`
+	Body        []byte    `json:"body"`
+	ETag        string    `json:"etag"`
+	Updated     time.Time `json:"updated"`
+}
+
+// SyndicationService renders custom feeds, broadcasters' clips and tagged
+// clips as RSS 2.0 or Atom 1.0, so they can be followed in feed readers and
+// used to trigger automations. Rendered feeds are cached briefly.
+type SyndicationService struct {
+	feeds   SyndicationFeedSource
+	clips   SyndicationClipRepository
+	tags    SyndicationTagRepository
+	redis   *redispkg.Client
+	baseURL string
+}
+
+// NewSyndicationService creates a new SyndicationService. Redis is optional.
+func NewSyndicationService(
+	feeds SyndicationFeedSource,
+	clips SyndicationClipRepository,
+	tags SyndicationTagRepository,
+	redisClient *redispkg.Client,
+	baseURL string,
+) *SyndicationService {
+	return &SyndicationService{
+		feeds:   feeds,
+		clips:   clips,
+		tags:    tags,
+		redis:   redisClient,
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// syndicationChannel describes a syndication feed independent of its format
+type syndicationChannel struct {
+	Title       string
+	Description string
+	Link        string
+	SelfURL     string
+	Entries     []syndicationEntry
+}
+
+// syndicationEntry is one clip of a syndication feed
+type syndicationEntry struct {
+	ID          uuid.UUID
+	Title       string
+	Link        string
+	Author      string
+	Category    string
+	Thumbnail   string
+	PublishedAt time.Time
+}
+
+// FeedDocument renders a public custom feed's clips, most recently added first
+func (s *SyndicationService) FeedDocument(ctx context.Context, feedID uuid.UUID, format string) (*SyndicationDocument, error) {
+	return s.document(ctx, fmt.Sprintf("feed:%s", feedID), format, func() (*syndicationChannel, error) {
+		feed, err := s.feeds.GetFeed(ctx, feedID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrSyndicationNotFound, err)
+		}
+		items, err := s.feeds.GetFeedClips(ctx, feedID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// Feed items are stored in curated order; readers want the newest first
+		newest := make([]*models.FeedItemWithClip, 0, len(items))
+		for _, item := range items {
+			if item.Clip != nil {
+				newest = append(newest, item)
+			}
+		}
+		sortFeedItemsByAddedAt(newest)
+
+		channel := &syndicationChannel{
+			Title:   feed.Name,
+			Link:    fmt.Sprintf("%s/feeds/%s", s.baseURL, feed.ID),
+			SelfURL: fmt.Sprintf("%s/api/v1/feeds/%s/rss", s.baseURL, feed.ID),
+		}
+		if feed.Description != nil {
+			channel.Description = *feed.Description
+		}
+		for _, item := range newest {
+			if len(channel.Entries) == syndicationMaxItems {
+				break
+			}
+			if entry, ok := s.entry(item.Clip, item.AddedAt); ok {
+				channel.Entries = append(channel.Entries, entry)
+			}
+		}
+		return channel, nil
+	})
+}
+
+// BroadcasterDocument renders a broadcaster's newest clips
+func (s *SyndicationService) BroadcasterDocument(ctx context.Context, broadcasterID, format string) (*SyndicationDocument, error) {
+	return s.document(ctx, fmt.Sprintf("broadcaster:%s", broadcasterID), format, func() (*syndicationChannel, error) {
+		clips, _, err := s.clips.ListClipsByBroadcaster(ctx, broadcasterID, "recent", syndicationMaxItems, 0)
+		if err != nil {
+			return nil, err
+		}
+		if len(clips) == 0 {
+			return nil, ErrSyndicationNotFound
+		}
+
+		name := clips[0].BroadcasterName
+		channel := &syndicationChannel{
+			Title:       fmt.Sprintf("%s clips", name),
+			Description: fmt.Sprintf("The newest clips of %s", name),
+			Link:        fmt.Sprintf("%s/broadcaster/%s", s.baseURL, broadcasterID),
+			SelfURL:     fmt.Sprintf("%s/api/v1/broadcasters/%s/clips.rss", s.baseURL, broadcasterID),
+		}
+		for i := range clips {
+			if entry, ok := s.entry(&clips[i], clips[i].CreatedAt); ok {
+				channel.Entries = append(channel.Entries, entry)
+			}
+		}
+		return channel, nil
+	})
+}
+
+// TagDocument renders the clips most recently given a tag
+func (s *SyndicationService) TagDocument(ctx context.Context, slug, format string) (*SyndicationDocument, error) {
+	return s.document(ctx, fmt.Sprintf("tag:%s", slug), format, func() (*syndicationChannel, error) {
+		tag, err := s.tags.GetBySlug(ctx, slug)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrSyndicationNotFound, err)
+		}
+		clipIDs, err := s.tags.GetClipsByTag(ctx, slug, syndicationMaxItems, 0)
+		if err != nil {
+			return nil, err
+		}
+		clips, err := s.clips.GetByIDs(ctx, clipIDs)
+		if err != nil {
+			return nil, err
+		}
+		byID := make(map[uuid.UUID]*models.Clip, len(clips))
+		for i := range clips {
+			byID[clips[i].ID] = &clips[i]
+		}
+
+		channel := &syndicationChannel{
+			Title:       fmt.Sprintf("Clips tagged %s", tag.Name),
+			Description: fmt.Sprintf("The newest clips tagged %s", tag.Name),
+			Link:        fmt.Sprintf("%s/tag/%s", s.baseURL, tag.Slug),
+			SelfURL:     fmt.Sprintf("%s/api/v1/tags/%s/clips.rss", s.baseURL, tag.Slug),
+		}
+		if tag.Description != nil {
+			channel.Description = *tag.Description
+		}
+		// Keep the tag's order, newest tagging first
+		for _, id := range clipIDs {
+			if clip, ok := byID[id]; ok {
+				if entry, ok := s.entry(clip, clip.CreatedAt); ok {
+					channel.Entries = append(channel.Entries, entry)
+				}
+			}
+		}
+		return channel, nil
+	})
+}
+
+// entry turns a clip into a feed entry, leaving out clips that aren't public
+func (s *SyndicationService) entry(clip *models.Clip, publishedAt time.Time) (syndicationEntry, bool) {
+	if clip.IsRemoved || clip.IsHidden {
+		return syndicationEntry{}, false
+	}
+	entry := syndicationEntry{
+		ID:          clip.ID,
+		Title:       clip.Title,
+		Link:        fmt.Sprintf("%s/clip/%s", s.baseURL, clip.ID),
+		Author:      clip.BroadcasterName,
+		PublishedAt: publishedAt.UTC(),
+	}
+	if clip.DisplayTitle != nil && *clip.DisplayTitle != "" {
+		entry.Title = *clip.DisplayTitle
+	}
+	if clip.GameName != nil {
+		entry.Category = *clip.GameName
+	}
+	if clip.ThumbnailURL != nil {
+		entry.Thumbnail = *clip.ThumbnailURL
+	}
+	return entry, true
+}
+
+// document returns a cached rendering of a feed, building and caching it on a miss
+func (s *SyndicationService) document(ctx context.Context, key, format string, build func() (*syndicationChannel, error)) (*SyndicationDocument, error) {
+	if format == "" {
+		format = SyndicationFormatRSS
+	}
+	if format != SyndicationFormatRSS && format != SyndicationFormatAtom {
+		return nil, ErrSyndicationFormat
+	}
+
+	cacheKey := fmt.Sprintf("syndication:%s:%s", key, format)
+	if s.redis != nil {
+		var cached SyndicationDocument
+		err := s.redis.GetJSON(ctx, cacheKey, &cached)
+		if err == nil {
+			return &cached, nil
+		}
+		if !errors.Is(err, redis.Nil) {
+			utils.GetLogger().Warn("Failed to read cached syndication feed", map[string]interface{}{
+				"key":   cacheKey,
+				"error": err.Error(),
+			})
+		}
+	}
+
+	channel, err := build()
+	if err != nil {
+		return nil, err
+	}
+	doc, err := renderSyndication(channel, format, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	if s.redis != nil {
+		if err := s.redis.SetJSON(ctx, cacheKey, doc, syndicationCacheTTL); err != nil {
+			utils.GetLogger().Warn("Failed to cache syndication feed", map[string]interface{}{
+				"key":   cacheKey,
+				"error": err.Error(),
+			})
+		}
+	}
+	return doc, nil
+}
+
+// renderSyndication renders a channel in the given format. Feeds without
+// entries report now as their update time.
+func renderSyndication(channel *syndicationChannel, format string, now time.Time) (*SyndicationDocument, error) {
+	updated := now
+	if len(channel.Entries) > 0 {
+		updated = channel.Entries[0].PublishedAt
+		for _, entry := range channel.Entries[1:] {
+			if entry.PublishedAt.After(updated) {
+				updated = entry.PublishedAt
+			}
+		}
+	}
+
+	var (
+		body        []byte
+		err         error
+		contentType string
+	)
+	switch format {
+	case SyndicationFormatAtom:
+		contentType = "application/atom+xml; charset=utf-8"
+		body, err = xml.MarshalIndent(atomFeedFrom(channel, updated), "", "  ")
+	default:
+		contentType = "application/rss+xml; charset=utf-8"
+		body, err = xml.MarshalIndent(rssFeedFrom(channel, updated), "", "  ")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s feed: %w", format, err)
+	}
+	body = append([]byte(xml.Header), body...)
+
+	sum := sha256.Sum256(body)
+	return &SyndicationDocument{
+		ContentType: contentType,
+		Body:        body,
+		ETag:        `"` + hex.EncodeToString(sum[:16]) + `"`,
+		Updated:     updated,
+	}, nil
+}
+
+// sortFeedItemsByAddedAt orders feed items newest first
+func sortFeedItemsByAddedAt(items []*models.FeedItemWithClip) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].AddedAt.After(items[j].AddedAt)
+	})
+}
+
+// RSS 2.0 documents, with the Atom self link and Media RSS thumbnails
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Atom    string     `xml:"xmlns:atom,attr"`
+	Media   string     `xml:"xmlns:media,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string      `xml:"title"`
+	Link          string      `xml:"link"`
+	Description   string      `xml:"description"`
+	SelfLink      rssAtomLink `xml:"atom:link"`
+	LastBuildDate string      `xml:"lastBuildDate"`
+	TTL           int         `xml:"ttl"`
+	Items         []rssItem   `xml:"item"`
+}
+
+type rssAtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title     string             `xml:"title"`
+	Link      string             `xml:"link"`
+	GUID      rssGUID            `xml:"guid"`
+	PubDate   string             `xml:"pubDate"`
+	Author    string             `xml:"media:credit,omitempty"`
+	Category  string             `xml:"category,omitempty"`
+	Thumbnail *rssMediaThumbnail `xml:"media:thumbnail,omitempty"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssMediaThumbnail struct {
+	URL string `xml:"url,attr"`
+}
+
+func rssFeedFrom(channel *syndicationChannel, updated time.Time) *rssFeed {
+	description := channel.Description
+	if description == "" {
+		description = channel.Title
+	}
+	feed := &rssFeed{
+		Version: "2.0",
+		Atom:    "http://www.w3.org/2005/Atom",
+		Media:   "http://search.yahoo.com/mrss/",
+		Channel: rssChannel{
+			Title:         channel.Title,
+			Link:          channel.Link,
+			Description:   description,
+			SelfLink:      rssAtomLink{Href: channel.SelfURL, Rel: "self", Type: "application/rss+xml"},
+			LastBuildDate: updated.Format(time.RFC1123Z),
+			TTL:           SyndicationMaxAge / 60,
+			Items:         make([]rssItem, 0, len(channel.Entries)),
+		},
+	}
+	for _, entry := range channel.Entries {
+		item := rssItem{
+			Title:    entry.Title,
+			Link:     entry.Link,
+			GUID:     rssGUID{IsPermaLink: true, Value: entry.Link},
+			PubDate:  entry.PublishedAt.Format(time.RFC1123Z),
+			Author:   entry.Author,
+			Category: entry.Category,
+		}
+		if entry.Thumbnail != "" {
+			item.Thumbnail = &rssMediaThumbnail{URL: entry.Thumbnail}
+		}
+		feed.Channel.Items = append(feed.Channel.Items, item)
+	}
+	return feed
+}
+
+// Atom 1.0 documents
+
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Author   atomPerson  `xml:"author"`
+	Links    []atomLink  `xml:"link"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomEntry struct {
+	ID        string        `xml:"id"`
+	Title     string        `xml:"title"`
+	Updated   string        `xml:"updated"`
+	Published string        `xml:"published"`
+	Author    *atomPerson   `xml:"author,omitempty"`
+	Links     []atomLink    `xml:"link"`
+	Category  *atomCategory `xml:"category,omitempty"`
+}
+
+func atomFeedFrom(channel *syndicationChannel, updated time.Time) *atomFeed {
+	feed := &atomFeed{
+		ID:       channel.SelfURL,
+		Title:    channel.Title,
+		Subtitle: channel.Description,
+		Updated:  updated.Format(time.RFC3339),
+		Author:   atomPerson{Name: "Clipper"},
+		Links: []atomLink{
+			{Href: channel.SelfURL + "?format=atom", Rel: "self", Type: "application/atom+xml"},
+			{Href: channel.Link, Rel: "alternate", Type: "text/html"},
+		},
+		Entries: make([]atomEntry, 0, len(channel.Entries)),
+	}
+	for _, entry := range channel.Entries {
+		published := entry.PublishedAt.Format(time.RFC3339)
+		item := atomEntry{
+			ID:        "urn:uuid:" + entry.ID.String(),
+			Title:     entry.Title,
+			Updated:   published,
+			Published: published,
+			Links:     []atomLink{{Href: entry.Link, Rel: "alternate", Type: "text/html"}},
+		}
+		if entry.Thumbnail != "" {
+			item.Links = append(item.Links, atomLink{Href: entry.Thumbnail, Rel: "enclosure", Type: "image/jpeg"})
+		}
+		if entry.Author != "" {
+			item.Author = &atomPerson{Name: entry.Author}
+		}
+		if entry.Category != "" {
+			item.Category = &atomCategory{Term: entry.Category}
+		}
+		feed.Entries = append(feed.Entries, item)
+	}
+	return feed
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// MockSyndicationFeedSource is a mock implementation of SyndicationFeedSource
+type MockSyndicationFeedSource struct {
+	mock.Mock
+}
+
+func (m *MockSyndicationFeedSource) GetFeed(ctx context.Context, feedID uuid.UUID, requestingUserID *uuid.UUID) (*models.Feed, error) {
+	args := m.Called(ctx, feedID, requestingUserID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Feed), args.Error(1)
+}
+
+func (m *MockSyndicationFeedSource) GetFeedClips(ctx context.Context, feedID uuid.UUID, requestingUserID *uuid.UUID) ([]*models.FeedItemWithClip, error) {
+	args := m.Called(ctx, feedID, requestingUserID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.FeedItemWithClip), args.Error(1)
+}
+
+func syndicationClip(title string, created time.Time) *models.Clip {
+	game := "Chess & Checkers"
+	thumb := "https://clips.example.com/thumb.jpg"
+	return &models.Clip{
+		ID:              uuid.New(),
+		Title:           title,
+		BroadcasterName: "streamer",
+		GameName:        &game,
+		ThumbnailURL:    &thumb,
+		CreatedAt:       created,
+	}
+}
+
+func TestSyndicationService_FeedDocument(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	older := syndicationClip("Older <clip>", now.Add(-48*time.Hour))
+	newer := syndicationClip("Newer clip", now.Add(-72*time.Hour))
+	hidden := syndicationClip("Hidden clip", now)
+	hidden.IsHidden = true
+
+	feed := &models.Feed{ID: uuid.New(), Name: "Best plays", IsPublic: true}
+	feeds := new(MockSyndicationFeedSource)
+	feeds.On("GetFeed", ctx, feed.ID, (*uuid.UUID)(nil)).Return(feed, nil)
+	feeds.On("GetFeedClips", ctx, feed.ID, (*uuid.UUID)(nil)).Return([]*models.FeedItemWithClip{
+		{FeedItem: models.FeedItem{Position: 0, AddedAt: now.Add(-2 * time.Hour)}, Clip: older},
+		{FeedItem: models.FeedItem{Position: 1, AddedAt: now.Add(-time.Hour)}, Clip: newer},
+		{FeedItem: models.FeedItem{Position: 2, AddedAt: now}, Clip: hidden},
+	}, nil)
+	svc := NewSyndicationService(feeds, nil, nil, nil, "https://clipper.example.com/")
+
+	doc, err := svc.FeedDocument(ctx, feed.ID, "")
+	require.NoError(t, err)
+	assert.Equal(t, "application/rss+xml; charset=utf-8", doc.ContentType)
+	assert.True(t, strings.HasPrefix(doc.ETag, `"`) && strings.HasSuffix(doc.ETag, `"`))
+	assert.Equal(t, now.Add(-time.Hour), doc.Updated)
+
+	var rss rssFeed
+	require.NoError(t, xml.Unmarshal(doc.Body, &rss), "RSS output must be well-formed XML")
+	assert.Equal(t, "2.0", rss.Version)
+	assert.Equal(t, "Best plays", rss.Channel.Title)
+	require.Len(t, rss.Channel.Items, 2, "hidden clips are left out")
+	assert.Equal(t, "Newer clip", rss.Channel.Items[0].Title, "most recently added first")
+	assert.Equal(t, "Older <clip>", rss.Channel.Items[1].Title)
+	assert.Equal(t, "https://clipper.example.com/clip/"+newer.ID.String(), rss.Channel.Items[0].Link)
+	assert.Equal(t, now.Add(-time.Hour).Format(time.RFC1123Z), rss.Channel.Items[0].PubDate)
+
+	atom, err := svc.FeedDocument(ctx, feed.ID, SyndicationFormatAtom)
+	require.NoError(t, err)
+	assert.Equal(t, "application/atom+xml; charset=utf-8", atom.ContentType)
+	assert.NotEqual(t, doc.ETag, atom.ETag)
+
+	var parsed atomFeed
+	require.NoError(t, xml.Unmarshal(atom.Body, &parsed), "Atom output must be well-formed XML")
+	assert.Equal(t, "http://www.w3.org/2005/Atom", parsed.XMLName.Space)
+	require.Len(t, parsed.Entries, 2)
+	assert.Equal(t, "urn:uuid:"+newer.ID.String(), parsed.Entries[0].ID)
+	require.NotNil(t, parsed.Entries[0].Category)
+	assert.Equal(t, "Chess & Checkers", parsed.Entries[0].Category.Term)
+}
+
+func TestSyndicationService_FeedDocumentErrors(t *testing.T) {
+	ctx := context.Background()
+	private := &models.Feed{ID: uuid.New(), Name: "Mine", IsPublic: false}
+	// Feeds are read anonymously, so private feeds aren't found
+	feeds := new(MockSyndicationFeedSource)
+	feeds.On("GetFeed", ctx, private.ID, (*uuid.UUID)(nil)).Return(nil, errors.New("unauthorized access to private feed"))
+	svc := NewSyndicationService(feeds, nil, nil, nil, "https://clipper.example.com")
+
+	_, err := svc.FeedDocument(ctx, private.ID, SyndicationFormatRSS)
+	assert.ErrorIs(t, err, ErrSyndicationNotFound, "private feeds have no syndication feed")
+
+	_, err = svc.FeedDocument(ctx, private.ID, "json")
+	assert.ErrorIs(t, err, ErrSyndicationFormat)
+}
+
+func TestRenderSyndicationETagFollowsContent(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	channel := &syndicationChannel{Title: "Clips", Link: "https://clipper.example.com", SelfURL: "https://clipper.example.com/api/v1/feeds/x/rss"}
+
+	empty, err := renderSyndication(channel, SyndicationFormatRSS, now)
+	require.NoError(t, err)
+	assert.Equal(t, now, empty.Updated, "empty feeds report the render time")
+
+	again, err := renderSyndication(channel, SyndicationFormatRSS, now)
+	require.NoError(t, err)
+	assert.Equal(t, empty.ETag, again.ETag)
+
+	channel.Entries = []syndicationEntry{{ID: uuid.New(), Title: "New", Link: "https://clipper.example.com/clip/1", PublishedAt: now}}
+	changed, err := renderSyndication(channel, SyndicationFormatRSS, now)
+	require.NoError(t, err)
+	assert.NotEqual(t, empty.ETag, changed.ETag)
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const (
+	// tagGraphMinClips is how many clips a tag pair must share to be related
+	tagGraphMinClips = 3
+	// tagGraphRelatedPerTag is how many related tags the graph keeps per tag
+	tagGraphRelatedPerTag = 25
+	// tagExpansionTerms is how many related tags expand a search for a tag
+	tagExpansionTerms = 3
+	// tagExpansionMinScore is the similarity a related tag needs to expand searches
+	tagExpansionMinScore = 0.2
+	// tagExpansionMaxQueryLength skips expansion for queries too long to be a tag
+	tagExpansionMaxQueryLength = 50
+)
+
+// TagGraphRepositoryInterface defines the repository methods used by TagGraphService
+type TagGraphRepositoryInterface interface {
+	GetBySlug(ctx context.Context, slug string) (*models.Tag, error)
+	RebuildCooccurrences(ctx context.Context, minClips, perTag int) (int64, error)
+	ListRelatedTags(ctx context.Context, tagID uuid.UUID, limit int) ([]models.RelatedTag, error)
+}
+
+// TagGraphService maintains the tag co-occurrence graph, linking tags that
+// often tag the same clips. It backs related tag discovery and expands
+// searches for a tag with its closest related tags.
+type TagGraphService struct {
+	repo TagGraphRepositoryInterface
+}
+
+// NewTagGraphService creates a new TagGraphService
+func NewTagGraphService(repo TagGraphRepositoryInterface) *TagGraphService {
+	return &TagGraphService{repo: repo}
+}
+
+// Rebuild recomputes the graph from the current clip tags and returns the
+// number of related tag pairs stored
+func (s *TagGraphService) Rebuild(ctx context.Context) (int, error) {
+	pairs, err := s.repo.RebuildCooccurrences(ctx, tagGraphMinClips, tagGraphRelatedPerTag)
+	if err != nil {
+		return 0, err
+	}
+	return int(pairs), nil
+}
+
+// ListRelatedTags returns the tags most often found with a tag, strongest first
+func (s *TagGraphService) ListRelatedTags(ctx context.Context, tagID uuid.UUID, limit int) ([]models.RelatedTag, error) {
+	return s.repo.ListRelatedTags(ctx, tagID, limit)
+}
+
+// ExpandQuery returns the names of tags closely related to the tag a search
+// query names, or nil when the query isn't a tag. Expansion is best effort;
+// lookup failures leave the query unexpanded.
+func (s *TagGraphService) ExpandQuery(ctx context.Context, query string) []string {
+	query = strings.TrimSpace(query)
+	if query == "" || len(query) > tagExpansionMaxQueryLength {
+		return nil
+	}
+
+	slug := utils.Slugify(query)
+	if slug == "" {
+		return nil
+	}
+	tag, err := s.repo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil
+	}
+
+	related, err := s.repo.ListRelatedTags(ctx, tag.ID, tagExpansionTerms)
+	if err != nil {
+		return nil
+	}
+
+	var terms []string
+	for _, r := range related {
+		if r.Score >= tagExpansionMinScore {
+			terms = append(terms, r.Name)
+		}
+	}
+	return terms
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+)
+
+// MockTagGraphRepository is a mock implementation of TagGraphRepositoryInterface
+type MockTagGraphRepository struct {
+	mock.Mock
+}
+
+func (m *MockTagGraphRepository) GetBySlug(ctx context.Context, slug string) (*models.Tag, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Tag), args.Error(1)
+}
+
+func (m *MockTagGraphRepository) RebuildCooccurrences(ctx context.Context, minClips, perTag int) (int64, error) {
+	args := m.Called(ctx, minClips, perTag)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTagGraphRepository) ListRelatedTags(ctx context.Context, tagID uuid.UUID, limit int) ([]models.RelatedTag, error) {
+	args := m.Called(ctx, tagID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.RelatedTag), args.Error(1)
+}
+
+func TestTagGraphExpandQuery(t *testing.T) {
+	speedrun := &models.Tag{ID: uuid.New(), Name: "Speedrun", Slug: "speedrun"}
+	repo := new(MockTagGraphRepository)
+	repo.On("GetBySlug", mock.Anything, "speedrun").Return(speedrun, nil).Once()
+	repo.On("GetBySlug", mock.Anything, mock.Anything).Return(nil, errors.New("tag not found"))
+	repo.On("ListRelatedTags", mock.Anything, speedrun.ID, tagExpansionTerms).Return([]models.RelatedTag{
+		{Tag: models.Tag{Name: "World Record"}, Score: 0.6},
+		{Tag: models.Tag{Name: "Any%"}, Score: 0.4},
+		{Tag: models.Tag{Name: "Glitch"}, Score: 0.1},
+	}, nil).Once()
+	repo.On("RebuildCooccurrences", mock.Anything, tagGraphMinClips, tagGraphRelatedPerTag).Return(int64(42), nil).Once()
+	svc := NewTagGraphService(repo)
+	ctx := context.Background()
+
+	assert.Equal(t, []string{"World Record", "Any%"}, svc.ExpandQuery(ctx, "  SpeedRun "), "weakly related tags don't expand")
+	assert.Nil(t, svc.ExpandQuery(ctx, "speedrun fails compilation"), "queries that aren't a tag")
+	assert.Nil(t, svc.ExpandQuery(ctx, ""))
+
+	pairs, err := svc.Rebuild(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 42, pairs)
+	repo.AssertExpectations(t)
+	repo.AssertNumberOfCalls(t, "GetBySlug", 2)
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/subculture-collective/clipper/internal/models"
 	"gopkg.in/yaml.v3"
 )
 
@@ -78,6 +80,26 @@ type ToxicityScore struct {
 	ReasonCodes     []string           `json:"reason_codes"`
 }
 
+// ApplyThreshold judges the score against threshold: the comment is toxic
+// when its confidence reaches it, and the categories that reach it become the
+// reason codes, highest first
+func (ts *ToxicityScore) ApplyThreshold(threshold float64) {
+	ts.Toxic = ts.ConfidenceScore >= threshold
+	ts.ReasonCodes = []string{}
+	for category, value := range ts.Categories {
+		if value >= threshold {
+			ts.ReasonCodes = append(ts.ReasonCodes, category)
+		}
+	}
+	sort.Slice(ts.ReasonCodes, func(i, j int) bool {
+		a, b := ts.Categories[ts.ReasonCodes[i]], ts.Categories[ts.ReasonCodes[j]]
+		if a != b {
+			return a > b
+		}
+		return ts.ReasonCodes[i] < ts.ReasonCodes[j]
+	})
+}
+
 // PerspectiveAPIRequest represents a request to the Perspective API
 type PerspectiveAPIRequest struct {
 	Comment             CommentText            `json:"comment"`
@@ -124,6 +146,11 @@ func NewToxicityClassifier(apiKey, apiURL string, enabled bool, threshold float6
 	return tc
 }
 
+// Threshold returns the site-wide confidence threshold for flagging comments
+func (tc *ToxicityClassifier) Threshold() float64 {
+	return tc.threshold
+}
+
 // ClassifyComment analyzes a comment for toxicity
 func (tc *ToxicityClassifier) ClassifyComment(ctx context.Context, content string) (*ToxicityScore, error) {
 	// If classifier is disabled, return safe default
@@ -518,8 +545,9 @@ func (tc *ToxicityClassifier) calculateContextMultiplier(content string) float64
 	return multiplier
 }
 
-// AddToModerationQueue adds a toxic comment to the moderation queue
-func (tc *ToxicityClassifier) AddToModerationQueue(ctx context.Context, commentID uuid.UUID, score *ToxicityScore) error {
+// AddToModerationQueue adds a toxic comment to the moderation queue, with its
+// scores and the threshold it was flagged at in the entry's metadata
+func (tc *ToxicityClassifier) AddToModerationQueue(ctx context.Context, commentID uuid.UUID, score *ToxicityScore, threshold float64) error {
 	if tc.db == nil {
 		return fmt.Errorf("database connection not available")
 	}
@@ -529,13 +557,19 @@ func (tc *ToxicityClassifier) AddToModerationQueue(ctx context.Context, commentI
 	if len(score.ReasonCodes) > 0 {
 		// Map Perspective API categories to our reason codes
 		categoryMap := map[string]string{
-			"TOXICITY":          "toxic",
-			"SEVERE_TOXICITY":   "toxic",
-			"IDENTITY_ATTACK":   "harassment",
-			"INSULT":            "offensive",
-			"PROFANITY":         "offensive",
-			"THREAT":            "harassment",
-			"SEXUALLY_EXPLICIT": "inappropriate",
+			"TOXICITY":                    "toxic",
+			"SEVERE_TOXICITY":             "toxic",
+			"IDENTITY_ATTACK":             "harassment",
+			"INSULT":                      "offensive",
+			"PROFANITY":                   "offensive",
+			"THREAT":                      "harassment",
+			"SEXUALLY_EXPLICIT":           "inappropriate",
+			string(CategoryHateSpeech):    "harassment",
+			string(CategoryHarassment):    "harassment",
+			string(CategoryViolence):      "harassment",
+			string(CategoryProfanity):     "offensive",
+			string(CategorySexualContent): "inappropriate",
+			string(CategorySpam):          "spam",
 		}
 
 		if mappedReason, ok := categoryMap[score.ReasonCodes[0]]; ok {
@@ -552,23 +586,38 @@ func (tc *ToxicityClassifier) AddToModerationQueue(ctx context.Context, commentI
 		priority = 50 // Minimum priority for auto-flagged items
 	}
 
-	// Insert into moderation queue
-	_, err := tc.db.Exec(ctx, `
+	metadata, err := json.Marshal(map[string]interface{}{
+		"toxicity": models.ToxicityAssessment{
+			ConfidenceScore: score.ConfidenceScore,
+			Threshold:       threshold,
+			Categories:      score.Categories,
+			ReasonCodes:     score.ReasonCodes,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal toxicity metadata: %w", err)
+	}
+
+	// Insert into moderation queue. A comment already pending review, e.g.
+	// from a report or automod, keeps its reason.
+	_, err = tc.db.Exec(ctx, `
 		INSERT INTO moderation_queue 
-		(content_type, content_id, reason, priority, status, auto_flagged, confidence_score)
-		VALUES ($1, $2, $3, $4, 'pending', true, $5)
+		(content_type, content_id, reason, priority, status, auto_flagged, confidence_score, metadata)
+		VALUES ($1, $2, $3, $4, 'pending', true, $5, $6)
 		ON CONFLICT (content_type, content_id) WHERE status = 'pending'
 		DO UPDATE SET 
 			confidence_score = EXCLUDED.confidence_score,
-			priority = EXCLUDED.priority,
-			reason = EXCLUDED.reason
-	`, "comment", commentID, reason, priority, score.ConfidenceScore)
+			priority = GREATEST(moderation_queue.priority, EXCLUDED.priority),
+			metadata = COALESCE(moderation_queue.metadata, '{}'::jsonb) || EXCLUDED.metadata
+	`, "comment", commentID, reason, priority, score.ConfidenceScore, metadata)
 
 	return err
 }
 
-// RecordPrediction records a toxicity prediction for metrics tracking
-func (tc *ToxicityClassifier) RecordPrediction(ctx context.Context, commentID uuid.UUID, score *ToxicityScore) error {
+// RecordPrediction records a toxicity prediction for metrics tracking and
+// moderator context. threshold is the one the score was judged against, or
+// nil when flagging is off for the comment.
+func (tc *ToxicityClassifier) RecordPrediction(ctx context.Context, commentID uuid.UUID, score *ToxicityScore, threshold *float64) error {
 	if tc.db == nil {
 		return fmt.Errorf("database connection not available")
 	}
@@ -581,16 +630,17 @@ func (tc *ToxicityClassifier) RecordPrediction(ctx context.Context, commentID uu
 
 	_, err = tc.db.Exec(ctx, `
 		INSERT INTO toxicity_predictions 
-		(comment_id, toxic, confidence_score, categories, reason_codes, created_at)
-		VALUES ($1, $2, $3, $4, $5, NOW())
+		(comment_id, toxic, confidence_score, categories, reason_codes, threshold, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
 		ON CONFLICT (comment_id) 
 		DO UPDATE SET 
 			toxic = EXCLUDED.toxic,
 			confidence_score = EXCLUDED.confidence_score,
 			categories = EXCLUDED.categories,
 			reason_codes = EXCLUDED.reason_codes,
+			threshold = EXCLUDED.threshold,
 			updated_at = NOW()
-	`, commentID, score.Toxic, score.ConfidenceScore, categoriesJSON, score.ReasonCodes)
+	`, commentID, score.Toxic, score.ConfidenceScore, categoriesJSON, score.ReasonCodes, threshold)
 
 	return err
 }
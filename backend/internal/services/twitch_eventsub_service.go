@@ -0,0 +1,267 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/pkg/twitch"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const (
+	// eventSubMaxMessageAge is how old a message may be before it is treated
+	// as a replay, per Twitch's guidance
+	eventSubMaxMessageAge = 10 * time.Minute
+	// eventSubDedupWindow covers Twitch's redelivery of unacknowledged messages
+	eventSubDedupWindow = 10 * time.Minute
+)
+
+var (
+	// ErrEventSubInvalidSignature is returned for a message whose signature does not match
+	ErrEventSubInvalidSignature = errors.New("invalid eventsub signature")
+	// ErrEventSubStaleMessage is returned for a message outside the replay window
+	ErrEventSubStaleMessage = errors.New("eventsub message is too old")
+)
+
+// eventSubSubscriptionTypes are the events subscribed to for each followed broadcaster
+var eventSubSubscriptionTypes = []string{
+	twitch.EventSubTypeStreamOnline,
+	twitch.EventSubTypeStreamOffline,
+	twitch.EventSubTypeClipCreate,
+}
+
+// EventSubLiveStatusHandler applies stream online/offline events
+type EventSubLiveStatusHandler interface {
+	HandleStreamOnline(ctx context.Context, event *twitch.EventSubStreamOnlineEvent) error
+	HandleStreamOffline(ctx context.Context, event *twitch.EventSubStreamOfflineEvent) error
+}
+
+// EventSubClipImporter imports a newly created clip
+type EventSubClipImporter interface {
+	FetchClipByURL(ctx context.Context, clipURLOrID string) (*models.Clip, error)
+}
+
+// EventSubSubscriber creates EventSub subscriptions with Twitch
+type EventSubSubscriber interface {
+	CreateEventSubSubscription(ctx context.Context, subType, version string, condition map[string]string, transport twitch.EventSubTransport) error
+}
+
+// EventSubBroadcasterSource lists the broadcasters to subscribe to
+type EventSubBroadcasterSource interface {
+	GetAllFollowedBroadcasterIDs(ctx context.Context) ([]string, error)
+}
+
+// EventSubChatRecorder counts chat messages for clip hype scoring
+type EventSubChatRecorder interface {
+	RecordChatMessage(broadcasterID string, emotes int)
+}
+
+// EventSubMessageDeduper remembers message IDs that were already handled
+type EventSubMessageDeduper interface {
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
+}
+
+// TwitchEventSubService handles Twitch EventSub webhook messages and keeps
+// subscriptions in place for followed broadcasters
+type TwitchEventSubService struct {
+	secret       string
+	callbackURL  string
+	liveStatus   EventSubLiveStatusHandler
+	clips        EventSubClipImporter
+	chat         EventSubChatRecorder
+	chatBotID    string
+	subscriber   EventSubSubscriber
+	broadcasters EventSubBroadcasterSource
+	deduper      EventSubMessageDeduper
+	logger       *utils.StructuredLogger
+	now          func() time.Time
+}
+
+// NewTwitchEventSubService creates a new TwitchEventSubService. secret signs
+// webhook messages and callbackURL is where Twitch delivers them.
+func NewTwitchEventSubService(
+	secret, callbackURL string,
+	liveStatus EventSubLiveStatusHandler,
+	subscriber EventSubSubscriber,
+	broadcasters EventSubBroadcasterSource,
+) *TwitchEventSubService {
+	return &TwitchEventSubService{
+		secret:       secret,
+		callbackURL:  callbackURL,
+		liveStatus:   liveStatus,
+		subscriber:   subscriber,
+		broadcasters: broadcasters,
+		logger:       utils.GetLogger(),
+		now:          time.Now,
+	}
+}
+
+// SetClipImporter imports clips from clip creation events. Without it those
+// events are ignored.
+func (s *TwitchEventSubService) SetClipImporter(clips EventSubClipImporter) {
+	s.clips = clips
+}
+
+// SetChatRecorder records chat messages in followed broadcasters' channels,
+// read through the chat bot account botUserID. The bot must have granted
+// user:read:chat and user:bot, and each broadcaster channel:bot or made it a
+// moderator; subscriptions for other channels are refused by Twitch.
+func (s *TwitchEventSubService) SetChatRecorder(chat EventSubChatRecorder, botUserID string) {
+	s.chat = chat
+	s.chatBotID = botUserID
+}
+
+// SetDeduper drops redelivered messages. Without it handlers must tolerate
+// the same message more than once.
+func (s *TwitchEventSubService) SetDeduper(deduper EventSubMessageDeduper) {
+	s.deduper = deduper
+}
+
+// VerifyMessage checks a message's signature and rejects replays outside the
+// allowed window
+func (s *TwitchEventSubService) VerifyMessage(messageID, timestamp string, body []byte, signature string) error {
+	if !twitch.VerifyEventSubSignature(s.secret, messageID, timestamp, body, signature) {
+		return ErrEventSubInvalidSignature
+	}
+
+	sentAt, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return fmt.Errorf("%w: invalid timestamp", ErrEventSubStaleMessage)
+	}
+	if age := s.now().Sub(sentAt); age > eventSubMaxMessageAge || age < -eventSubMaxMessageAge {
+		return ErrEventSubStaleMessage
+	}
+	return nil
+}
+
+// SeenMessage reports whether a message ID was already handled, recording
+// it if not
+func (s *TwitchEventSubService) SeenMessage(ctx context.Context, messageID string) bool {
+	if s.deduper == nil {
+		return false
+	}
+	first, err := s.deduper.SetNX(ctx, "eventsub:message:"+messageID, 1, eventSubDedupWindow)
+	if err != nil {
+		// Fail open; status updates are idempotent
+		return false
+	}
+	return !first
+}
+
+// HandleNotification applies a notification message
+func (s *TwitchEventSubService) HandleNotification(ctx context.Context, msg *twitch.EventSubMessage) error {
+	switch msg.Subscription.Type {
+	case twitch.EventSubTypeStreamOnline:
+		var event twitch.EventSubStreamOnlineEvent
+		if err := json.Unmarshal(msg.Event, &event); err != nil {
+			return fmt.Errorf("failed to decode stream.online event: %w", err)
+		}
+		return s.liveStatus.HandleStreamOnline(ctx, &event)
+
+	case twitch.EventSubTypeStreamOffline:
+		var event twitch.EventSubStreamOfflineEvent
+		if err := json.Unmarshal(msg.Event, &event); err != nil {
+			return fmt.Errorf("failed to decode stream.offline event: %w", err)
+		}
+		return s.liveStatus.HandleStreamOffline(ctx, &event)
+
+	case twitch.EventSubTypeClipCreate:
+		if s.clips == nil {
+			return nil
+		}
+		var event twitch.EventSubClipEvent
+		if err := json.Unmarshal(msg.Event, &event); err != nil {
+			return fmt.Errorf("failed to decode clip event: %w", err)
+		}
+		if event.ID == "" {
+			return fmt.Errorf("clip event has no clip id")
+		}
+		if _, err := s.clips.FetchClipByURL(ctx, event.ID); err != nil {
+			return fmt.Errorf("failed to import clip %s: %w", event.ID, err)
+		}
+		return nil
+
+	case twitch.EventSubTypeChatMessage:
+		if s.chat == nil {
+			return nil
+		}
+		var event twitch.EventSubChatMessageEvent
+		if err := json.Unmarshal(msg.Event, &event); err != nil {
+			return fmt.Errorf("failed to decode chat message event: %w", err)
+		}
+		s.chat.RecordChatMessage(event.BroadcasterUserID, event.Message.EmoteCount())
+		return nil
+
+	default:
+		s.logger.Warn("Ignoring unsupported EventSub notification", map[string]interface{}{
+			"type": msg.Subscription.Type,
+		})
+		return nil
+	}
+}
+
+// HandleRevocation records that Twitch revoked a subscription. The next
+// subscription sync recreates it if it is still wanted and allowed.
+func (s *TwitchEventSubService) HandleRevocation(msg *twitch.EventSubMessage) {
+	s.logger.Warn("EventSub subscription revoked", map[string]interface{}{
+		"subscription_id": msg.Subscription.ID,
+		"type":            msg.Subscription.Type,
+		"status":          msg.Subscription.Status,
+		"condition":       msg.Subscription.Condition,
+	})
+}
+
+// SyncSubscriptions makes sure every followed broadcaster has a subscription
+// for each event type, and for chat messages when chat is recorded,
+// returning how many subscriptions were requested
+func (s *TwitchEventSubService) SyncSubscriptions(ctx context.Context) (int, error) {
+	broadcasterIDs, err := s.broadcasters.GetAllFollowedBroadcasterIDs(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get followed broadcasters: %w", err)
+	}
+
+	transport := twitch.EventSubTransport{
+		Callback: s.callbackURL,
+		Secret:   s.secret,
+	}
+
+	requested := 0
+	failures := map[string]int{}
+	lastErrs := map[string]error{}
+	subscribe := func(subType string, condition map[string]string) {
+		if err := s.subscriber.CreateEventSubSubscription(ctx, subType, "1", condition, transport); err != nil {
+			failures[subType]++
+			lastErrs[subType] = err
+			return
+		}
+		requested++
+	}
+	for _, broadcasterID := range broadcasterIDs {
+		for _, subType := range eventSubSubscriptionTypes {
+			if err := ctx.Err(); err != nil {
+				return requested, err
+			}
+			subscribe(subType, map[string]string{"broadcaster_user_id": broadcasterID})
+		}
+		if s.chat != nil && s.chatBotID != "" {
+			if err := ctx.Err(); err != nil {
+				return requested, err
+			}
+			subscribe(twitch.EventSubTypeChatMessage, map[string]string{"broadcaster_user_id": broadcasterID, "user_id": s.chatBotID})
+		}
+	}
+
+	// One line per event type keeps a type Twitch rejects from flooding the logs
+	for subType, count := range failures {
+		s.logger.Warn("Failed to create EventSub subscriptions", map[string]interface{}{
+			"type":       subType,
+			"failures":   count,
+			"last_error": lastErrs[subType].Error(),
+		})
+	}
+	return requested, nil
+}
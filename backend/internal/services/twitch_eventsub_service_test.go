@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/pkg/twitch"
+)
+
+// MockEventSubLiveStatusHandler is a mock implementation of EventSubLiveStatusHandler
+type MockEventSubLiveStatusHandler struct {
+	mock.Mock
+}
+
+func (m *MockEventSubLiveStatusHandler) HandleStreamOnline(ctx context.Context, event *twitch.EventSubStreamOnlineEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockEventSubLiveStatusHandler) HandleStreamOffline(ctx context.Context, event *twitch.EventSubStreamOfflineEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+// MockEventSubClipImporter is a mock implementation of EventSubClipImporter
+type MockEventSubClipImporter struct {
+	mock.Mock
+}
+
+func (m *MockEventSubClipImporter) FetchClipByURL(ctx context.Context, clipURLOrID string) (*models.Clip, error) {
+	args := m.Called(ctx, clipURLOrID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Clip), args.Error(1)
+}
+
+// MockEventSubChatRecorder is a mock implementation of EventSubChatRecorder
+type MockEventSubChatRecorder struct {
+	mock.Mock
+}
+
+func (m *MockEventSubChatRecorder) RecordChatMessage(broadcasterID string, emotes int) {
+	m.Called(broadcasterID, emotes)
+}
+
+// MockEventSubSubscriber is a mock implementation of EventSubSubscriber
+type MockEventSubSubscriber struct {
+	mock.Mock
+}
+
+func (m *MockEventSubSubscriber) CreateEventSubSubscription(ctx context.Context, subType, version string, condition map[string]string, transport twitch.EventSubTransport) error {
+	args := m.Called(ctx, subType, version, condition, transport)
+	return args.Error(0)
+}
+
+// MockEventSubBroadcasterSource is a mock implementation of EventSubBroadcasterSource
+type MockEventSubBroadcasterSource struct {
+	mock.Mock
+}
+
+func (m *MockEventSubBroadcasterSource) GetAllFollowedBroadcasterIDs(ctx context.Context) ([]string, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func signEventSub(secret, messageID, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(messageID + timestamp))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestTwitchEventSubService_VerifyMessage(t *testing.T) {
+	const secret = "eventsub-test-secret"
+	svc := NewTwitchEventSubService(secret, "https://clpr.tv/api/v1/webhooks/twitch/eventsub", nil, nil, nil)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	body := []byte(`{"subscription":{"type":"stream.online"}}`)
+	timestamp := now.Add(-time.Minute).Format(time.RFC3339Nano)
+	signature := signEventSub(secret, "msg-1", timestamp, body)
+
+	assert.NoError(t, svc.VerifyMessage("msg-1", timestamp, body, signature))
+	assert.ErrorIs(t, svc.VerifyMessage("msg-1", timestamp, []byte(`{}`), signature), ErrEventSubInvalidSignature)
+	assert.ErrorIs(t, svc.VerifyMessage("msg-2", timestamp, body, signature), ErrEventSubInvalidSignature)
+
+	stale := now.Add(-11 * time.Minute).Format(time.RFC3339Nano)
+	assert.ErrorIs(t, svc.VerifyMessage("msg-1", stale, body, signEventSub(secret, "msg-1", stale, body)), ErrEventSubStaleMessage)
+}
+
+func TestTwitchEventSubService_SeenMessage(t *testing.T) {
+	svc := NewTwitchEventSubService("secret", "", nil, nil, nil)
+	assert.False(t, svc.SeenMessage(context.Background(), "msg-1"), "without a deduper nothing is dropped")
+
+	deduper := new(MockRedisClient)
+	deduper.On("SetNX", mock.Anything, "eventsub:message:msg-1", mock.Anything, eventSubDedupWindow).Return(true, nil).Once()
+	deduper.On("SetNX", mock.Anything, "eventsub:message:msg-1", mock.Anything, eventSubDedupWindow).Return(false, nil).Once()
+	deduper.On("SetNX", mock.Anything, "eventsub:message:msg-2", mock.Anything, eventSubDedupWindow).Return(true, nil).Once()
+	svc.SetDeduper(deduper)
+
+	assert.False(t, svc.SeenMessage(context.Background(), "msg-1"))
+	assert.True(t, svc.SeenMessage(context.Background(), "msg-1"))
+	assert.False(t, svc.SeenMessage(context.Background(), "msg-2"))
+
+	// Fails open when the store is unavailable
+	deduper.On("SetNX", mock.Anything, "eventsub:message:msg-3", mock.Anything, eventSubDedupWindow).Return(false, errors.New("redis down")).Once()
+	assert.False(t, svc.SeenMessage(context.Background(), "msg-3"))
+
+	deduper.AssertExpectations(t)
+}
+
+func TestTwitchEventSubService_HandleNotification(t *testing.T) {
+	live := new(MockEventSubLiveStatusHandler)
+	clips := new(MockEventSubClipImporter)
+	svc := NewTwitchEventSubService("secret", "", live, nil, nil)
+	ctx := context.Background()
+
+	live.On("HandleStreamOnline", ctx, mock.MatchedBy(func(event *twitch.EventSubStreamOnlineEvent) bool {
+		return event.BroadcasterUserID == "1337" && event.BroadcasterUserLogin == "cool_user"
+	})).Return(nil).Once()
+	err := svc.HandleNotification(ctx, &twitch.EventSubMessage{
+		Subscription: twitch.EventSubSubscription{Type: twitch.EventSubTypeStreamOnline},
+		Event:        []byte(`{"id":"9001","broadcaster_user_id":"1337","broadcaster_user_login":"cool_user","type":"live","started_at":"2026-10-16T11:59:00Z"}`),
+	})
+	require.NoError(t, err)
+
+	live.On("HandleStreamOffline", ctx, mock.MatchedBy(func(event *twitch.EventSubStreamOfflineEvent) bool {
+		return event.BroadcasterUserID == "1337"
+	})).Return(nil).Once()
+	err = svc.HandleNotification(ctx, &twitch.EventSubMessage{
+		Subscription: twitch.EventSubSubscription{Type: twitch.EventSubTypeStreamOffline},
+		Event:        []byte(`{"broadcaster_user_id":"1337"}`),
+	})
+	require.NoError(t, err)
+	live.AssertExpectations(t)
+
+	clipEvent := &twitch.EventSubMessage{
+		Subscription: twitch.EventSubSubscription{Type: twitch.EventSubTypeClipCreate},
+		Event:        []byte(`{"id":"AwkwardHelplessSalamanderSwiftRage","broadcaster_user_id":"1337"}`),
+	}
+	require.NoError(t, svc.HandleNotification(ctx, clipEvent), "clip events are ignored without an importer")
+
+	clips.On("FetchClipByURL", ctx, "AwkwardHelplessSalamanderSwiftRage").Return(&models.Clip{}, nil).Once()
+	svc.SetClipImporter(clips)
+	require.NoError(t, svc.HandleNotification(ctx, clipEvent))
+	clips.AssertExpectations(t)
+
+	err = svc.HandleNotification(ctx, &twitch.EventSubMessage{
+		Subscription: twitch.EventSubSubscription{Type: twitch.EventSubTypeStreamOnline},
+		Event:        []byte(`not json`),
+	})
+	assert.Error(t, err)
+	live.AssertNumberOfCalls(t, "HandleStreamOnline", 1)
+}
+
+func TestTwitchEventSubService_SyncSubscriptions(t *testing.T) {
+	subscriber := new(MockEventSubSubscriber)
+	broadcasters := new(MockEventSubBroadcasterSource)
+	svc := NewTwitchEventSubService("secret", "https://clpr.tv/api/v1/webhooks/twitch/eventsub", nil, subscriber, broadcasters)
+	ctx := context.Background()
+
+	transport := twitch.EventSubTransport{Callback: "https://clpr.tv/api/v1/webhooks/twitch/eventsub", Secret: "secret"}
+	broadcasters.On("GetAllFollowedBroadcasterIDs", ctx).Return([]string{"1", "2"}, nil)
+	subscriber.On("CreateEventSubSubscription", ctx, twitch.EventSubTypeClipCreate, "1", mock.Anything, transport).
+		Return(errors.New("unsupported subscription type"))
+	subscriber.On("CreateEventSubSubscription", ctx, mock.Anything, "1", mock.Anything, transport).Return(nil)
+
+	requested, err := svc.SyncSubscriptions(ctx)
+	require.NoError(t, err, "a rejected event type does not fail the sync")
+	assert.Equal(t, 4, requested)
+
+	subscriber.AssertNumberOfCalls(t, "CreateEventSubSubscription", 6)
+	subscriber.AssertCalled(t, "CreateEventSubSubscription", ctx, twitch.EventSubTypeStreamOnline, "1", map[string]string{"broadcaster_user_id": "1"}, transport)
+	subscriber.AssertCalled(t, "CreateEventSubSubscription", ctx, twitch.EventSubTypeStreamOffline, "1", map[string]string{"broadcaster_user_id": "2"}, transport)
+}
+
+func TestTwitchEventSubService_ChatMessages(t *testing.T) {
+	subscriber := new(MockEventSubSubscriber)
+	broadcasters := new(MockEventSubBroadcasterSource)
+	broadcasters.On("GetAllFollowedBroadcasterIDs", mock.Anything).Return([]string{"1"}, nil)
+	subscriber.On("CreateEventSubSubscription", mock.Anything, mock.Anything, "1", mock.Anything, mock.Anything).Return(nil)
+	chat := new(MockEventSubChatRecorder)
+	chat.On("RecordChatMessage", "1", 2).Once()
+	svc := NewTwitchEventSubService("secret", "https://clpr.tv/api/v1/webhooks/twitch/eventsub", nil, subscriber, broadcasters)
+	ctx := context.Background()
+
+	chatEvent := &twitch.EventSubMessage{
+		Subscription: twitch.EventSubSubscription{Type: twitch.EventSubTypeChatMessage},
+		Event: []byte(`{"broadcaster_user_id":"1","chatter_user_id":"42","message_id":"m-1","message":{"text":"PogChamp no way PogChamp",` +
+			`"fragments":[{"type":"emote","text":"PogChamp"},{"type":"text","text":" no way "},{"type":"emote","text":"PogChamp"}]}}`),
+	}
+	require.NoError(t, svc.HandleNotification(ctx, chatEvent), "chat messages are ignored without a recorder")
+
+	svc.SetChatRecorder(chat, "999")
+	require.NoError(t, svc.HandleNotification(ctx, chatEvent))
+	chat.AssertExpectations(t)
+
+	requested, err := svc.SyncSubscriptions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, len(eventSubSubscriptionTypes)+1, requested)
+	subscriber.AssertCalled(t, "CreateEventSubSubscription", ctx, twitch.EventSubTypeChatMessage, "1",
+		map[string]string{"broadcaster_user_id": "1", "user_id": "999"}, mock.Anything)
+}
@@ -0,0 +1,441 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+// Upload errors
+var (
+	// ErrUploadInvalid is returned when an upload's context, type or size isn't allowed
+	ErrUploadInvalid = errors.New("invalid upload")
+	// ErrUploadNotUploaded is returned when an upload is completed before the file was sent
+	ErrUploadNotUploaded = errors.New("file has not been uploaded yet")
+	// ErrUploadRejected is returned when an uploaded file failed its checks
+	ErrUploadRejected = errors.New("upload rejected")
+	// ErrUploadNotReady is returned when an upload is used before it was completed
+	ErrUploadNotReady = errors.New("upload has not been completed")
+	// ErrUploadInUse is returned when an upload is already used by something else
+	ErrUploadInUse = errors.New("upload is already in use")
+)
+
+// uploadCompletionGrace is how long after its URL expires a pending upload
+// can still be completed before the cleanup job deletes it
+const uploadCompletionGrace = 15 * time.Minute
+
+var (
+	uploadImageTypes = []string{"image/jpeg", "image/png", "image/gif", "image/webp"}
+	uploadVideoTypes = []string{"video/mp4", "video/webm"}
+)
+
+// UploadContextPolicy limits what can be uploaded in a context
+type UploadContextPolicy struct {
+	MaxBytes     int64
+	ContentTypes []string
+	// SingleAttachment detaches the previous upload when a new one is
+	// attached to the same target, like a replaced avatar
+	SingleAttachment bool
+}
+
+// uploadContextPolicies holds the limits of each upload context
+var uploadContextPolicies = map[string]UploadContextPolicy{
+	models.UploadContextAvatar: {
+		MaxBytes:         2 << 20,
+		ContentTypes:     uploadImageTypes,
+		SingleAttachment: true,
+	},
+	models.UploadContextAdCreative: {
+		MaxBytes:         25 << 20,
+		ContentTypes:     append(append([]string{}, uploadImageTypes...), uploadVideoTypes...),
+		SingleAttachment: true,
+	},
+	models.UploadContextReportEvidence: {
+		MaxBytes:     10 << 20,
+		ContentTypes: append(append([]string{"application/pdf"}, uploadImageTypes...), uploadVideoTypes...),
+	},
+	models.UploadContextCommunityAsset: {
+		MaxBytes:     5 << 20,
+		ContentTypes: uploadImageTypes,
+	},
+}
+
+// UploadPolicy returns the limits of an upload context
+func UploadPolicy(uploadContext string) (UploadContextPolicy, bool) {
+	policy, ok := uploadContextPolicies[uploadContext]
+	return policy, ok
+}
+
+// Allows reports whether a content type may be uploaded under the policy
+func (p UploadContextPolicy) Allows(contentType string) bool {
+	for _, allowed := range p.ContentTypes {
+		if allowed == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeContentType strips parameters and case from a content type
+func normalizeContentType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(contentType))
+	}
+	return mediaType
+}
+
+// UploadRepositoryInterface defines the repository methods used by UploadService
+type UploadRepositoryInterface interface {
+	Create(ctx context.Context, upload *models.Upload) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Upload, error)
+	GetByObjectKey(ctx context.Context, objectKey string) (*models.Upload, error)
+	MarkReady(ctx context.Context, id uuid.UUID, contentType string, sizeBytes int64) error
+	MarkRejected(ctx context.Context, id uuid.UUID, reason string) error
+	Attach(ctx context.Context, id uuid.UUID, attachedType, attachedID string, replace bool) error
+	Detach(ctx context.Context, id uuid.UUID, attachedType, attachedID string) error
+	ListAttached(ctx context.Context, attachedType, attachedID string) ([]models.Upload, error)
+	ListOrphans(ctx context.Context, cutoff time.Time, limit int) ([]models.Upload, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// UploadService handles file uploads shared by avatars, ad creatives, report
+// evidence and community assets. Clients upload straight to storage through
+// a presigned URL, then complete the upload so its size and sniffed type are
+// checked against its context and, when a scanner is set, it is scanned for
+// malware. Features attach ready uploads they use; anything never attached
+// or later replaced is deleted by CleanupOrphans.
+type UploadService struct {
+	repo          UploadRepositoryInterface
+	storage       UploadStorage
+	scanner       UploadScanner
+	presignExpiry time.Duration
+	orphanTTL     time.Duration
+	now           func() time.Time
+}
+
+// NewUploadService creates a new UploadService
+func NewUploadService(repo UploadRepositoryInterface, storage UploadStorage, presignExpiry, orphanTTL time.Duration) *UploadService {
+	return &UploadService{
+		repo:          repo,
+		storage:       storage,
+		presignExpiry: presignExpiry,
+		orphanTTL:     orphanTTL,
+		now:           time.Now,
+	}
+}
+
+// SetScanner scans completed uploads for malware
+func (s *UploadService) SetScanner(scanner UploadScanner) {
+	s.scanner = scanner
+}
+
+// withURL sets the public URL of uploads that passed their checks
+func (s *UploadService) withURL(upload *models.Upload) *models.Upload {
+	if upload.Status == models.UploadStatusReady || upload.Status == models.UploadStatusAttached {
+		upload.URL = s.storage.URL(upload.ObjectKey)
+	}
+	return upload
+}
+
+// CreateUpload checks a file against its context and returns where to upload it
+func (s *UploadService) CreateUpload(ctx context.Context, userID uuid.UUID, req *models.CreateUploadRequest) (*models.UploadTicket, error) {
+	policy, ok := UploadPolicy(req.Context)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown context %q", ErrUploadInvalid, req.Context)
+	}
+	if req.SizeBytes <= 0 || req.SizeBytes > policy.MaxBytes {
+		return nil, fmt.Errorf("%w: %s uploads must be at most %d bytes", ErrUploadInvalid, req.Context, policy.MaxBytes)
+	}
+	contentType := normalizeContentType(req.ContentType)
+	if !policy.Allows(contentType) {
+		return nil, fmt.Errorf("%w: %s uploads must be one of %s", ErrUploadInvalid, req.Context, strings.Join(policy.ContentTypes, ", "))
+	}
+
+	id := uuid.New()
+	key := req.Context + "/" + id.String()
+	uploadURL, headers, err := s.storage.PresignPut(ctx, key, contentType, req.SizeBytes, s.presignExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to presign upload: %w", err)
+	}
+
+	urlExpiresAt := s.now().Add(s.presignExpiry)
+	upload := &models.Upload{
+		ID:                  id,
+		UserID:              &userID,
+		Context:             req.Context,
+		ObjectKey:           key,
+		Filename:            path.Base(strings.ReplaceAll(strings.TrimSpace(req.Filename), "\\", "/")),
+		DeclaredContentType: contentType,
+		SizeBytes:           req.SizeBytes,
+		Status:              models.UploadStatusPending,
+		ExpiresAt:           urlExpiresAt.Add(uploadCompletionGrace),
+	}
+	if err := s.repo.Create(ctx, upload); err != nil {
+		return nil, err
+	}
+
+	return &models.UploadTicket{
+		Upload:    upload,
+		UploadURL: uploadURL,
+		Method:    http.MethodPut,
+		Headers:   headers,
+		ExpiresAt: urlExpiresAt,
+	}, nil
+}
+
+// getOwned returns an upload of the user, hiding those of others
+func (s *UploadService) getOwned(ctx context.Context, userID, uploadID uuid.UUID) (*models.Upload, error) {
+	upload, err := s.repo.GetByID(ctx, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if upload.UserID == nil || *upload.UserID != userID {
+		return nil, repository.ErrUploadNotFound
+	}
+	return upload, nil
+}
+
+// GetUpload returns an upload of the user
+func (s *UploadService) GetUpload(ctx context.Context, userID, uploadID uuid.UUID) (*models.Upload, error) {
+	upload, err := s.getOwned(ctx, userID, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	return s.withURL(upload), nil
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// CompleteUpload checks an uploaded file's size, sniffed type and, when a
+// scanner is set, content. Files failing a check are deleted and the upload
+// rejected. Completing an upload again returns it unchanged.
+func (s *UploadService) CompleteUpload(ctx context.Context, userID, uploadID uuid.UUID) (*models.Upload, error) {
+	upload, err := s.getOwned(ctx, userID, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	switch upload.Status {
+	case models.UploadStatusPending:
+	case models.UploadStatusRejected:
+		return upload, fmt.Errorf("%w: %s", ErrUploadRejected, getStringValue(upload.RejectionReason))
+	default:
+		return s.withURL(upload), nil
+	}
+
+	policy, ok := UploadPolicy(upload.Context)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown context %q", ErrUploadInvalid, upload.Context)
+	}
+
+	body, size, err := s.storage.Open(ctx, upload.ObjectKey)
+	if errors.Is(err, ErrUploadObjectNotFound) {
+		return nil, ErrUploadNotUploaded
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	if size > policy.MaxBytes {
+		return s.reject(ctx, upload, fmt.Sprintf("file is larger than %d bytes", policy.MaxBytes))
+	}
+
+	// The type is sniffed from the content, not taken from the client
+	reader := bufio.NewReaderSize(body, 512)
+	head, err := reader.Peek(512)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	contentType := normalizeContentType(http.DetectContentType(head))
+	if !policy.Allows(contentType) {
+		return s.reject(ctx, upload, fmt.Sprintf("file type %s is not allowed", contentType))
+	}
+
+	counter := &countingReader{r: io.LimitReader(reader, policy.MaxBytes+1)}
+	if s.scanner != nil {
+		signature, err := s.scanner.Scan(ctx, counter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan upload: %w", err)
+		}
+		if signature != "" {
+			utils.Warn("Malware detected in upload", map[string]interface{}{
+				"upload_id": upload.ID.String(),
+				"user_id":   userID.String(),
+				"signature": signature,
+			})
+			return s.reject(ctx, upload, "malware detected")
+		}
+	}
+	if _, err := io.Copy(io.Discard, counter); err != nil {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	if counter.n > policy.MaxBytes {
+		return s.reject(ctx, upload, fmt.Sprintf("file is larger than %d bytes", policy.MaxBytes))
+	}
+
+	if err := s.repo.MarkReady(ctx, upload.ID, contentType, counter.n); err != nil {
+		return nil, err
+	}
+	upload.Status = models.UploadStatusReady
+	upload.ContentType = &contentType
+	upload.SizeBytes = counter.n
+	return s.withURL(upload), nil
+}
+
+// reject deletes a file that failed its checks and records why
+func (s *UploadService) reject(ctx context.Context, upload *models.Upload, reason string) (*models.Upload, error) {
+	if err := s.storage.Delete(ctx, upload.ObjectKey); err != nil {
+		utils.Warn("Failed to delete rejected upload", map[string]interface{}{
+			"upload_id": upload.ID.String(),
+			"error":     err.Error(),
+		})
+	}
+	if err := s.repo.MarkRejected(ctx, upload.ID, reason); err != nil {
+		return nil, err
+	}
+	upload.Status = models.UploadStatusRejected
+	upload.RejectionReason = &reason
+	return upload, fmt.Errorf("%w: %s", ErrUploadRejected, reason)
+}
+
+// Resolve returns a completed upload of the user for the given context, so
+// features can check an upload before saving what it will be attached to
+func (s *UploadService) Resolve(ctx context.Context, userID, uploadID uuid.UUID, uploadContext string) (*models.Upload, error) {
+	upload, err := s.getOwned(ctx, userID, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAttachable(upload, uploadContext); err != nil {
+		return nil, err
+	}
+	return s.withURL(upload), nil
+}
+
+// ResolveURL is Resolve for the public URL of an upload, for features that
+// store URLs. It returns nil for URLs not pointing to an upload, which are
+// left to the caller.
+func (s *UploadService) ResolveURL(ctx context.Context, userID uuid.UUID, rawURL, uploadContext string) (*models.Upload, error) {
+	prefix := s.storage.URL("")
+	if prefix == "" || !strings.HasPrefix(rawURL, prefix) {
+		return nil, nil
+	}
+	upload, err := s.repo.GetByObjectKey(ctx, strings.TrimPrefix(rawURL, prefix))
+	if errors.Is(err, repository.ErrUploadNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if upload.UserID == nil || *upload.UserID != userID {
+		return nil, repository.ErrUploadNotFound
+	}
+	if err := checkAttachable(upload, uploadContext); err != nil {
+		return nil, err
+	}
+	return s.withURL(upload), nil
+}
+
+// checkAttachable checks an upload is of the context and passed its checks
+func checkAttachable(upload *models.Upload, uploadContext string) error {
+	if upload.Context != uploadContext {
+		return fmt.Errorf("%w: expected a %s upload", ErrUploadInvalid, uploadContext)
+	}
+	if upload.Status != models.UploadStatusReady && upload.Status != models.UploadStatusAttached {
+		return ErrUploadNotReady
+	}
+	return nil
+}
+
+// Attach marks a completed upload of the user as used by a target, such as
+// a report, and returns it with its URL. Uploads of a single attachment
+// context replace the one attached to the target before.
+func (s *UploadService) Attach(ctx context.Context, userID, uploadID uuid.UUID, uploadContext, attachedType, attachedID string) (*models.Upload, error) {
+	upload, err := s.getOwned(ctx, userID, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAttachable(upload, uploadContext); err != nil {
+		return nil, err
+	}
+	if upload.Status == models.UploadStatusAttached {
+		if getStringValue(upload.AttachedType) == attachedType && getStringValue(upload.AttachedID) == attachedID {
+			return s.withURL(upload), nil
+		}
+		return nil, ErrUploadInUse
+	}
+
+	policy, _ := UploadPolicy(upload.Context)
+	if err := s.repo.Attach(ctx, upload.ID, attachedType, attachedID, policy.SingleAttachment); err != nil {
+		return nil, err
+	}
+	upload.Status = models.UploadStatusAttached
+	upload.AttachedType = &attachedType
+	upload.AttachedID = &attachedID
+	return s.withURL(upload), nil
+}
+
+// Detach stops a target from using an upload, so the cleanup job deletes it
+func (s *UploadService) Detach(ctx context.Context, uploadID uuid.UUID, attachedType, attachedID string) error {
+	return s.repo.Detach(ctx, uploadID, attachedType, attachedID)
+}
+
+// ListAttached returns the uploads attached to a target with their URLs
+func (s *UploadService) ListAttached(ctx context.Context, attachedType, attachedID string) ([]models.Upload, error) {
+	uploads, err := s.repo.ListAttached(ctx, attachedType, attachedID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range uploads {
+		s.withURL(&uploads[i])
+	}
+	return uploads, nil
+}
+
+// CleanupOrphans deletes up to limit uploads nothing uses, with their files:
+// pending ones never completed, ready ones not attached within the orphan
+// TTL, rejected ones past it and replaced ones. Returns how many were deleted.
+func (s *UploadService) CleanupOrphans(ctx context.Context, limit int) (int, error) {
+	orphans, err := s.repo.ListOrphans(ctx, s.now().Add(-s.orphanTTL), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, upload := range orphans {
+		if err := s.storage.Delete(ctx, upload.ObjectKey); err != nil {
+			utils.Warn("Failed to delete orphaned upload", map[string]interface{}{
+				"upload_id": upload.ID.String(),
+				"error":     err.Error(),
+			})
+			continue
+		}
+		if err := s.repo.Delete(ctx, upload.ID); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
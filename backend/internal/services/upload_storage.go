@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUploadObjectNotFound is returned when nothing was uploaded under a key
+var ErrUploadObjectNotFound = errors.New("upload object not found")
+
+// UploadStorage stores uploaded files. Clients upload straight to it through
+// presigned URLs, so files never pass through the API.
+type UploadStorage interface {
+	// PresignPut returns a URL a file of exactly the given size and type can
+	// be PUT to until expiry, and the headers the request must carry
+	PresignPut(ctx context.Context, key, contentType string, size int64, expiry time.Duration) (string, map[string]string, error)
+	// Open reads an uploaded object and returns its size
+	Open(ctx context.Context, key string) (io.ReadCloser, int64, error)
+	Delete(ctx context.Context, key string) error
+	// URL returns the public URL of an object
+	URL(key string) string
+}
+
+// S3StorageConfig configures an S3-compatible bucket (AWS S3, R2, MinIO)
+type S3StorageConfig struct {
+	Endpoint        string // e.g. https://s3.us-east-1.amazonaws.com
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	UsePathStyle    bool   // Address the bucket in the path instead of the host name
+	PublicBaseURL   string // Where objects are served from, e.g. a CDN; defaults to the bucket URL
+}
+
+// S3UploadStorage stores uploads in an S3-compatible bucket. Every request,
+// including the API's own, uses SigV4 presigned URLs.
+type S3UploadStorage struct {
+	config     S3StorageConfig
+	endpoint   *url.URL
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewS3UploadStorage creates a new S3UploadStorage
+func NewS3UploadStorage(config S3StorageConfig) (*S3UploadStorage, error) {
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid storage endpoint %q", config.Endpoint)
+	}
+	if config.Bucket == "" {
+		return nil, errors.New("storage bucket is required")
+	}
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+	return &S3UploadStorage{
+		config:     config,
+		endpoint:   endpoint,
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+		now:        time.Now,
+	}, nil
+}
+
+// objectURL returns the bucket URL of an object, unsigned
+func (s *S3UploadStorage) objectURL(key string) *url.URL {
+	u := *s.endpoint
+	base := strings.TrimSuffix(s.endpoint.Path, "/")
+	path, rawPath := base+"/"+key, base+"/"+uriEncode(key, false)
+	if s.config.UsePathStyle {
+		path = base + "/" + s.config.Bucket + "/" + key
+		rawPath = base + "/" + uriEncode(s.config.Bucket, true) + "/" + uriEncode(key, false)
+	} else {
+		u.Host = s.config.Bucket + "." + u.Host
+	}
+	u.Path, u.RawPath = path, rawPath
+	return &u
+}
+
+// URL returns the public URL of an object
+func (s *S3UploadStorage) URL(key string) string {
+	if s.config.PublicBaseURL != "" {
+		return strings.TrimSuffix(s.config.PublicBaseURL, "/") + "/" + key
+	}
+	return s.objectURL(key).String()
+}
+
+// PresignPut returns a URL the file can be PUT to. Its size and type are
+// signed, so the bucket rejects anything else.
+func (s *S3UploadStorage) PresignPut(ctx context.Context, key, contentType string, size int64, expiry time.Duration) (string, map[string]string, error) {
+	headers := map[string]string{
+		"Content-Length": strconv.FormatInt(size, 10),
+		"Content-Type":   contentType,
+	}
+	return s.presign(http.MethodPut, key, headers, expiry), headers, nil
+}
+
+// Open reads an uploaded object
+func (s *S3UploadStorage) Open(ctx context.Context, key string) (io.ReadCloser, int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.presign(http.MethodGet, key, nil, 5*time.Minute), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read upload: %w", err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, 0, ErrUploadObjectNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, 0, fmt.Errorf("failed to read upload: %s - %s", resp.Status, string(body))
+	}
+	return resp.Body, resp.ContentLength, nil
+}
+
+// Delete removes an object. Deleting a missing object succeeds.
+func (s *S3UploadStorage) Delete(ctx context.Context, key string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.presign(http.MethodDelete, key, nil, 5*time.Minute), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to delete upload: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 && resp.StatusCode != http.StatusNotFound {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to delete upload: %s - %s", resp.Status, string(body))
+	}
+	return nil
+}
+
+// presign builds an AWS Signature Version 4 presigned URL. The given headers
+// are signed along with the host and must be sent unchanged.
+func (s *S3UploadStorage) presign(method, key string, headers map[string]string, expiry time.Duration) string {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	scope := date + "/" + s.config.Region + "/s3/aws4_request"
+
+	u := s.objectURL(key)
+
+	signed := map[string]string{"host": u.Host}
+	for name, value := range headers {
+		signed[strings.ToLower(name)] = strings.TrimSpace(value)
+	}
+	names := make([]string, 0, len(signed))
+	for name := range signed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + signed[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	query := map[string]string{
+		"X-Amz-Algorithm":     "AWS4-HMAC-SHA256",
+		"X-Amz-Credential":    s.config.AccessKeyID + "/" + scope,
+		"X-Amz-Date":          amzDate,
+		"X-Amz-Expires":       strconv.Itoa(int(expiry.Seconds())),
+		"X-Amz-SignedHeaders": signedHeaders,
+	}
+	params := make([]string, 0, len(query))
+	for name, value := range query {
+		params = append(params, uriEncode(name, true)+"="+uriEncode(value, true))
+	}
+	sort.Strings(params)
+	canonicalQuery := strings.Join(params, "&")
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		u.RawPath,
+		canonicalQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.config.SecretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, s.config.Region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	u.RawQuery = canonicalQuery + "&X-Amz-Signature=" + signature
+	return u.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// uriEncode percent-encodes everything but unreserved characters, as SigV4
+// requires. Slashes are kept unless encodeSlash is set.
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+const (
+	// userBanOffenseWindow is how far back earlier bans count towards the ladder
+	userBanOffenseWindow = 365 * 24 * time.Hour
+	// userBanExpiryBatchSize caps how many expired bans are lifted per run
+	userBanExpiryBatchSize = 100
+	// userBanHistoryLimit caps how many past bans are listed for a user
+	userBanHistoryLimit = 50
+)
+
+// userBanLadder is how long each ban in the offense window lasts when no
+// duration is given. Offenses past the end of the ladder are permanent.
+var userBanLadder = []time.Duration{
+	24 * time.Hour,
+	7 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+}
+
+var (
+	// ErrBanReasonRequired is returned when a ban has no reason
+	ErrBanReasonRequired = errors.New("ban reason is required")
+	// ErrInvalidBanDuration is returned for a ban duration that is not positive
+	ErrInvalidBanDuration = errors.New("ban duration must be positive")
+)
+
+// UserBanRepositoryInterface defines the repository methods used by UserBanService
+type UserBanRepositoryInterface interface {
+	Create(ctx context.Context, ban *models.UserBan) error
+	Lift(ctx context.Context, userID uuid.UUID, liftedBy *uuid.UUID, reason *string) (*models.UserBan, error)
+	LiftExpired(ctx context.Context, limit int) ([]models.UserBan, error)
+	GetActive(ctx context.Context, userID uuid.UUID) (*models.UserBan, error)
+	ListForUser(ctx context.Context, userID uuid.UUID, limit int) ([]models.UserBan, error)
+	CountOffensesSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error)
+}
+
+// UserBanAuditLogger records ban and reinstatement events
+type UserBanAuditLogger interface {
+	LogAction(ctx context.Context, action string, actor uuid.UUID, target uuid.UUID, entityType string, opts AuditLogOptions) error
+}
+
+// BanRequest describes a site-wide ban. Without a duration or Permanent, the
+// length comes from the ban ladder for the user's offense count.
+type BanRequest struct {
+	Reason    string
+	Duration  *time.Duration
+	Permanent bool
+}
+
+// UserBanService manages time-boxed and permanent site-wide bans
+type UserBanService struct {
+	repo     UserBanRepositoryInterface
+	auditLog UserBanAuditLogger
+}
+
+// NewUserBanService creates a new UserBanService
+func NewUserBanService(repo UserBanRepositoryInterface, auditLog UserBanAuditLogger) *UserBanService {
+	return &UserBanService{
+		repo:     repo,
+		auditLog: auditLog,
+	}
+}
+
+// BanUser bans a user, replacing any ban they already have
+func (s *UserBanService) BanUser(ctx context.Context, userID, moderatorID uuid.UUID, req BanRequest) (*models.UserBan, error) {
+	if req.Reason == "" {
+		return nil, ErrBanReasonRequired
+	}
+	if req.Duration != nil && *req.Duration <= 0 {
+		return nil, ErrInvalidBanDuration
+	}
+
+	now := time.Now()
+	previous, err := s.repo.CountOffensesSince(ctx, userID, now.Add(-userBanOffenseWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	ban := &models.UserBan{
+		UserID:        userID,
+		Reason:        req.Reason,
+		BannedBy:      &moderatorID,
+		OffenseNumber: previous + 1,
+		StartsAt:      now,
+	}
+	switch {
+	case req.Permanent:
+		// No expiry
+	case req.Duration != nil:
+		expiresAt := now.Add(*req.Duration)
+		ban.ExpiresAt = &expiresAt
+	default:
+		if duration, ok := ladderDuration(ban.OffenseNumber); ok {
+			expiresAt := now.Add(duration)
+			ban.ExpiresAt = &expiresAt
+		}
+	}
+
+	if err := s.repo.Create(ctx, ban); err != nil {
+		return nil, err
+	}
+
+	metadata := map[string]interface{}{
+		"ban_id":         ban.ID.String(),
+		"offense_number": ban.OffenseNumber,
+		"permanent":      ban.IsPermanent(),
+	}
+	if ban.ExpiresAt != nil {
+		metadata["expires_at"] = ban.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	s.logAudit(ctx, "ban_user", moderatorID, userID, AuditLogOptions{
+		Reason:   &req.Reason,
+		Metadata: metadata,
+	})
+
+	return ban, nil
+}
+
+// ladderDuration returns the ban length for a user's nth offense, or false
+// once the ladder is exhausted and the ban is permanent
+func ladderDuration(offenseNumber int) (time.Duration, bool) {
+	if offenseNumber < 1 {
+		offenseNumber = 1
+	}
+	if offenseNumber > len(userBanLadder) {
+		return 0, false
+	}
+	return userBanLadder[offenseNumber-1], true
+}
+
+// UnbanUser lifts a user's ban before it expires
+func (s *UserBanService) UnbanUser(ctx context.Context, userID, moderatorID uuid.UUID, reason string) error {
+	if reason == "" {
+		reason = "No reason provided"
+	}
+
+	ban, err := s.repo.Lift(ctx, userID, &moderatorID, &reason)
+	if err != nil {
+		return err
+	}
+
+	metadata := map[string]interface{}{}
+	if ban != nil {
+		metadata["ban_id"] = ban.ID.String()
+	}
+	s.logAudit(ctx, "unban_user", moderatorID, userID, AuditLogOptions{
+		Reason:   &reason,
+		Metadata: metadata,
+	})
+	return nil
+}
+
+// LiftExpiredBans reinstates users whose ban has expired, returning how many
+func (s *UserBanService) LiftExpiredBans(ctx context.Context) (int, error) {
+	bans, err := s.repo.LiftExpired(ctx, userBanExpiryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	reason := "Ban expired"
+	for _, ban := range bans {
+		// The expiry was part of the banning moderator's decision, so the
+		// reinstatement is recorded under them
+		actor := ban.UserID
+		if ban.BannedBy != nil {
+			actor = *ban.BannedBy
+		}
+		s.logAudit(ctx, "unban_user", actor, ban.UserID, AuditLogOptions{
+			Reason: &reason,
+			Metadata: map[string]interface{}{
+				"ban_id":    ban.ID.String(),
+				"automatic": true,
+			},
+		})
+	}
+
+	return len(bans), nil
+}
+
+// GetBanStatus returns the user's current ban, if any
+func (s *UserBanService) GetBanStatus(ctx context.Context, userID uuid.UUID) (*models.UserBanStatus, error) {
+	ban, err := s.repo.GetActive(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserBanNotFound) {
+			return &models.UserBanStatus{Banned: false}, nil
+		}
+		return nil, fmt.Errorf("failed to get ban status: %w", err)
+	}
+
+	startsAt := ban.StartsAt
+	return &models.UserBanStatus{
+		Banned:    true,
+		Reason:    ban.Reason,
+		BannedAt:  &startsAt,
+		ExpiresAt: ban.ExpiresAt,
+		Permanent: ban.IsPermanent(),
+	}, nil
+}
+
+// ListBans returns a user's ban history, newest first
+func (s *UserBanService) ListBans(ctx context.Context, userID uuid.UUID) ([]models.UserBan, error) {
+	return s.repo.ListForUser(ctx, userID, userBanHistoryLimit)
+}
+
+// logAudit records a ban event without failing the ban itself
+func (s *UserBanService) logAudit(ctx context.Context, action string, actor, target uuid.UUID, opts AuditLogOptions) {
+	if s.auditLog == nil {
+		return
+	}
+	if err := s.auditLog.LogAction(ctx, action, actor, target, "user", opts); err != nil {
+		utils.GetLogger().Error("Failed to record ban audit log", err, map[string]interface{}{
+			"action":  action,
+			"user_id": target.String(),
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/subculture-collective/clipper/internal/models"
+	"github.com/subculture-collective/clipper/internal/repository"
+)
+
+// MockUserBanRepository is a mock implementation of UserBanRepositoryInterface
+type MockUserBanRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserBanRepository) Create(ctx context.Context, ban *models.UserBan) error {
+	args := m.Called(ctx, ban)
+	return args.Error(0)
+}
+
+func (m *MockUserBanRepository) Lift(ctx context.Context, userID uuid.UUID, liftedBy *uuid.UUID, reason *string) (*models.UserBan, error) {
+	args := m.Called(ctx, userID, liftedBy, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UserBan), args.Error(1)
+}
+
+func (m *MockUserBanRepository) LiftExpired(ctx context.Context, limit int) ([]models.UserBan, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.UserBan), args.Error(1)
+}
+
+func (m *MockUserBanRepository) GetActive(ctx context.Context, userID uuid.UUID) (*models.UserBan, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UserBan), args.Error(1)
+}
+
+func (m *MockUserBanRepository) ListForUser(ctx context.Context, userID uuid.UUID, limit int) ([]models.UserBan, error) {
+	args := m.Called(ctx, userID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.UserBan), args.Error(1)
+}
+
+func (m *MockUserBanRepository) CountOffensesSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	args := m.Called(ctx, userID, since)
+	return args.Int(0), args.Error(1)
+}
+
+// auditMetadata matches audit log options carrying the given metadata value
+func auditMetadata(key string, value interface{}) interface{} {
+	return mock.MatchedBy(func(opts AuditLogOptions) bool {
+		return opts.Metadata[key] == value
+	})
+}
+
+// auditReason matches audit log options with the given reason
+func auditReason(reason string) interface{} {
+	return mock.MatchedBy(func(opts AuditLogOptions) bool {
+		return opts.Reason != nil && *opts.Reason == reason
+	})
+}
+
+func TestUserBanService_BanUserLadder(t *testing.T) {
+	repo := new(MockUserBanRepository)
+	svc := NewUserBanService(repo, nil)
+	ctx := context.Background()
+	userID, modID := uuid.New(), uuid.New()
+
+	repo.On("Create", ctx, mock.AnythingOfType("*models.UserBan")).Return(nil)
+
+	want := []time.Duration{24 * time.Hour, 7 * 24 * time.Hour, 30 * 24 * time.Hour}
+	for i, duration := range want {
+		repo.On("CountOffensesSince", ctx, userID, mock.Anything).Return(i, nil).Once()
+
+		ban, err := svc.BanUser(ctx, userID, modID, BanRequest{Reason: "spam"})
+		require.NoError(t, err)
+		assert.Equal(t, i+1, ban.OffenseNumber)
+		require.NotNil(t, ban.ExpiresAt)
+		assert.WithinDuration(t, time.Now().Add(duration), *ban.ExpiresAt, time.Minute)
+	}
+
+	repo.On("CountOffensesSince", ctx, userID, mock.Anything).Return(3, nil).Once()
+	ban, err := svc.BanUser(ctx, userID, modID, BanRequest{Reason: "spam"})
+	require.NoError(t, err)
+	assert.Equal(t, 4, ban.OffenseNumber)
+	assert.True(t, ban.IsPermanent(), "offenses past the ladder are permanent")
+}
+
+func TestUserBanService_BanUserCountsOffensesInWindow(t *testing.T) {
+	repo := new(MockUserBanRepository)
+	svc := NewUserBanService(repo, nil)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	repo.On("CountOffensesSince", ctx, userID, mock.MatchedBy(func(since time.Time) bool {
+		return since.Sub(time.Now().Add(-userBanOffenseWindow)).Abs() < time.Minute
+	})).Return(0, nil).Once()
+	repo.On("Create", ctx, mock.Anything).Return(nil).Once()
+
+	_, err := svc.BanUser(ctx, userID, uuid.New(), BanRequest{Reason: "spam"})
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestUserBanService_BanUserOverridesLadder(t *testing.T) {
+	repo := new(MockUserBanRepository)
+	audit := new(MockAuditLogService)
+	svc := NewUserBanService(repo, audit)
+	ctx := context.Background()
+	userID, modID := uuid.New(), uuid.New()
+
+	repo.On("Create", ctx, mock.AnythingOfType("*models.UserBan")).Return(nil)
+
+	duration := 3 * time.Hour
+	repo.On("CountOffensesSince", ctx, userID, mock.Anything).Return(0, nil).Once()
+	audit.On("LogAction", ctx, "ban_user", modID, userID, "user", auditMetadata("permanent", false)).Return(nil).Once()
+
+	ban, err := svc.BanUser(ctx, userID, modID, BanRequest{Reason: "cool off", Duration: &duration})
+	require.NoError(t, err)
+	require.NotNil(t, ban.ExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(duration), *ban.ExpiresAt, time.Minute)
+
+	repo.On("CountOffensesSince", ctx, userID, mock.Anything).Return(1, nil).Once()
+	audit.On("LogAction", ctx, "ban_user", modID, userID, "user", auditMetadata("permanent", true)).Return(nil).Once()
+
+	ban, err = svc.BanUser(ctx, userID, modID, BanRequest{Reason: "ban evasion", Permanent: true})
+	require.NoError(t, err)
+	assert.True(t, ban.IsPermanent())
+	assert.Equal(t, 2, ban.OffenseNumber)
+
+	audit.AssertExpectations(t)
+}
+
+func TestUserBanService_BanUserValidation(t *testing.T) {
+	repo := new(MockUserBanRepository)
+	svc := NewUserBanService(repo, nil)
+
+	_, err := svc.BanUser(context.Background(), uuid.New(), uuid.New(), BanRequest{})
+	assert.ErrorIs(t, err, ErrBanReasonRequired)
+
+	negative := -time.Hour
+	_, err = svc.BanUser(context.Background(), uuid.New(), uuid.New(), BanRequest{Reason: "spam", Duration: &negative})
+	assert.ErrorIs(t, err, ErrInvalidBanDuration)
+
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestUserBanService_UnbanUser(t *testing.T) {
+	repo := new(MockUserBanRepository)
+	audit := new(MockAuditLogService)
+	svc := NewUserBanService(repo, audit)
+	ctx := context.Background()
+	userID, modID := uuid.New(), uuid.New()
+
+	ban := &models.UserBan{ID: uuid.New(), UserID: userID, Reason: "mistake"}
+	repo.On("Lift", ctx, userID, &modID, mock.MatchedBy(func(reason *string) bool {
+		return reason != nil && *reason == "wrong user"
+	})).Return(ban, nil).Once()
+	audit.On("LogAction", ctx, "unban_user", modID, userID, "user", auditReason("wrong user")).Return(nil).Once()
+
+	require.NoError(t, svc.UnbanUser(ctx, userID, modID, "wrong user"))
+
+	repo.AssertExpectations(t)
+	audit.AssertExpectations(t)
+}
+
+func TestUserBanService_LiftExpiredBans(t *testing.T) {
+	repo := new(MockUserBanRepository)
+	audit := new(MockAuditLogService)
+	svc := NewUserBanService(repo, audit)
+	ctx := context.Background()
+
+	modID := uuid.New()
+	expired := time.Now().Add(-time.Minute)
+	expiredBan := models.UserBan{ID: uuid.New(), UserID: uuid.New(), BannedBy: &modID, ExpiresAt: &expired}
+	repo.On("LiftExpired", ctx, userBanExpiryBatchSize).Return([]models.UserBan{expiredBan}, nil).Once()
+	// Reinstatements are recorded under the banning moderator
+	audit.On("LogAction", ctx, "unban_user", modID, expiredBan.UserID, "user", auditMetadata("automatic", true)).Return(nil).Once()
+
+	lifted, err := svc.LiftExpiredBans(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, lifted)
+
+	audit.AssertExpectations(t)
+}
+
+func TestUserBanService_GetBanStatus(t *testing.T) {
+	repo := new(MockUserBanRepository)
+	svc := NewUserBanService(repo, nil)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	repo.On("GetActive", ctx, userID).Return(nil, repository.ErrUserBanNotFound).Once()
+
+	status, err := svc.GetBanStatus(ctx, userID)
+	require.NoError(t, err)
+	assert.False(t, status.Banned)
+
+	expiresAt := time.Now().Add(24 * time.Hour)
+	repo.On("GetActive", ctx, userID).Return(&models.UserBan{
+		UserID:    userID,
+		Reason:    "harassment",
+		StartsAt:  time.Now(),
+		ExpiresAt: &expiresAt,
+	}, nil).Once()
+
+	status, err = svc.GetBanStatus(ctx, userID)
+	require.NoError(t, err)
+	assert.True(t, status.Banned)
+	assert.Equal(t, "harassment", status.Reason)
+	assert.False(t, status.Permanent)
+	require.NotNil(t, status.ExpiresAt)
+	assert.Equal(t, expiresAt, *status.ExpiresAt)
+}
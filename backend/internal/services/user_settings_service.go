@@ -28,6 +28,7 @@ type UserSettingsService struct {
 	subscriptionRepo    *repository.SubscriptionRepository
 	consentRepo         *repository.ConsentRepository
 	auditLogService     *AuditLogService
+	uploadService       *UploadService
 }
 
 // NewUserSettingsService creates a new user settings service
@@ -59,18 +60,36 @@ func NewUserSettingsService(
 	}
 }
 
+// SetUploadService enables uploaded avatars
+func (s *UserSettingsService) SetUploadService(uploadService *UploadService) {
+	s.uploadService = uploadService
+}
+
 // UpdateProfile updates user's display name and bio
 func (s *UserSettingsService) UpdateProfile(ctx context.Context, userID uuid.UUID, displayName string, bio *string) error {
 	return s.userRepo.UpdateProfile(ctx, userID, displayName, bio)
 }
 
+// UpdateAvatar sets the user's avatar to a completed avatar upload. The
+// previous uploaded avatar is detached and later deleted.
+func (s *UserSettingsService) UpdateAvatar(ctx context.Context, userID, uploadID uuid.UUID) (*models.Upload, error) {
+	upload, err := s.uploadService.Attach(ctx, userID, uploadID, models.UploadContextAvatar, "user", userID.String())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.UpdateAvatar(ctx, userID, upload.ID, upload.URL); err != nil {
+		return nil, err
+	}
+	return upload, nil
+}
+
 // GetSettings retrieves user settings
 func (s *UserSettingsService) GetSettings(ctx context.Context, userID uuid.UUID) (*models.UserSettings, error) {
 	return s.userSettingsRepo.GetByUserID(ctx, userID)
 }
 
 // UpdateSettings updates user settings
-func (s *UserSettingsService) UpdateSettings(ctx context.Context, userID uuid.UUID, profileVisibility *string, showKarmaPublicly *bool) error {
+func (s *UserSettingsService) UpdateSettings(ctx context.Context, userID uuid.UUID, profileVisibility *string, showKarmaPublicly *bool, personalizedSearch *bool, showProfileViews *bool, shareActivity *bool) error {
 	// Validate profile visibility if provided
 	if profileVisibility != nil {
 		validValues := map[string]bool{"public": true, "private": true, "followers": true}
@@ -79,7 +98,7 @@ func (s *UserSettingsService) UpdateSettings(ctx context.Context, userID uuid.UU
 		}
 	}
 
-	return s.userSettingsRepo.Update(ctx, userID, profileVisibility, showKarmaPublicly)
+	return s.userSettingsRepo.Update(ctx, userID, profileVisibility, showKarmaPublicly, personalizedSearch, showProfileViews, shareActivity)
 }
 
 // ExportUserData exports all user data as a JSON structure
@@ -390,6 +409,11 @@ For our Terms of Service, visit: https://clipper.gg/terms
 		return nil, err
 	}
 
+	// Log audit event so the export shows in the user's data activity
+	if err := s.auditLogService.LogDataExportGenerated(ctx, userID); err != nil {
+		log.Printf("Failed to log data export: %v", err)
+	}
+
 	return buf.Bytes(), nil
 }
 
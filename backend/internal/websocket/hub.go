@@ -22,12 +22,12 @@ type ChannelHub struct {
 	Unregister chan *ChatClient
 	Mutex      sync.RWMutex
 	DB         *pgxpool.Pool
-	Redis      *redis.Client
+	Redis      redis.UniversalClient
 	Stop       chan struct{}
 }
 
 // NewChannelHub creates a new channel hub
-func NewChannelHub(channelID string, db *pgxpool.Pool, redisClient *redis.Client) *ChannelHub {
+func NewChannelHub(channelID string, db *pgxpool.Pool, redisClient redis.UniversalClient) *ChannelHub {
 	return &ChannelHub{
 		ID:         channelID,
 		Clients:    make(map[*ChatClient]bool),
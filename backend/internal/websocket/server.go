@@ -15,7 +15,7 @@ import (
 // Server represents the WebSocket server
 type Server struct {
 	DB             *pgxpool.Pool
-	Redis          *redis.Client
+	Redis          redis.UniversalClient
 	Upgrader       websocket.Upgrader
 	Hubs           map[string]*ChannelHub
 	HubsMux        sync.RWMutex
@@ -24,7 +24,7 @@ type Server struct {
 }
 
 // NewServer creates a new WebSocket server
-func NewServer(db *pgxpool.Pool, redisClient *redis.Client, cfg *config.WebSocketConfig) *Server {
+func NewServer(db *pgxpool.Pool, redisClient redis.UniversalClient, cfg *config.WebSocketConfig) *Server {
 	// Validate allowed origins configuration
 	validateAllowedOrigins(cfg.AllowedOrigins)
 
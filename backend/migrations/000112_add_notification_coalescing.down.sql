@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_notifications_user_group_open;
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS aggregate_data,
+    DROP COLUMN IF EXISTS aggregate_count,
+    DROP COLUMN IF EXISTS group_key;
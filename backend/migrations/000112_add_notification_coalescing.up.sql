@@ -0,0 +1,11 @@
+-- Coalesce bursts of similar notifications (e.g. comments on a viral clip) into a single row
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS group_key VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS aggregate_count INT NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS aggregate_data JSONB,
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP;
+
+-- Lookup of the open (unread) aggregate for a user and group within the rolling window
+CREATE INDEX IF NOT EXISTS idx_notifications_user_group_open
+    ON notifications(user_id, group_key, created_at DESC)
+    WHERE group_key IS NOT NULL AND is_read = false;
@@ -0,0 +1,22 @@
+-- Record which provider an account signed up with so non-Twitch viewers can be told apart
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS auth_provider VARCHAR(20) NOT NULL DEFAULT 'twitch';
+
+-- External login identities (Google, Discord, ...) linked to a user account
+CREATE TABLE IF NOT EXISTS user_auth_identities (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(20) NOT NULL,
+    provider_user_id VARCHAR(255) NOT NULL,
+    email VARCHAR(255),
+    email_verified BOOLEAN NOT NULL DEFAULT false,
+    display_name VARCHAR(255),
+    avatar_url TEXT,
+    metadata JSONB,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_login_at TIMESTAMP,
+    CONSTRAINT uq_user_auth_identities_provider_user UNIQUE (provider, provider_user_id),
+    CONSTRAINT uq_user_auth_identities_user_provider UNIQUE (user_id, provider)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_auth_identities_user ON user_auth_identities(user_id);
@@ -0,0 +1,36 @@
+-- Short links created when a clip is shared, attributed to the sharing channel
+CREATE TABLE IF NOT EXISTS share_links (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(16) NOT NULL UNIQUE,
+    clip_id UUID NOT NULL REFERENCES clips(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    channel VARCHAR(20) NOT NULL DEFAULT 'copy',
+    click_count INT NOT NULL DEFAULT 0,
+    view_count INT NOT NULL DEFAULT 0,
+    signup_count INT NOT NULL DEFAULT 0,
+    is_disabled BOOLEAN NOT NULL DEFAULT false,
+    disabled_reason TEXT,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_clicked_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_share_links_clip ON share_links(clip_id);
+CREATE INDEX IF NOT EXISTS idx_share_links_user ON share_links(user_id, created_at DESC);
+
+-- Individual clicks, views and sign-ups attributed to a share link
+CREATE TABLE IF NOT EXISTS share_link_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    share_link_id UUID NOT NULL REFERENCES share_links(id) ON DELETE CASCADE,
+    event_type VARCHAR(20) NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    ip_hash VARCHAR(64),
+    referrer TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_share_link_events_link ON share_link_events(share_link_id, created_at DESC);
+
+-- A user can only be attributed as a sign-up once
+CREATE UNIQUE INDEX IF NOT EXISTS uq_share_link_events_signup
+    ON share_link_events(user_id) WHERE event_type = 'signup';
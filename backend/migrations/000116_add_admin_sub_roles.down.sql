@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_admin_roles;
+
+ALTER TABLE users DROP COLUMN IF EXISTS admin_roles;
@@ -0,0 +1,6 @@
+-- Scoped admin sub-roles (support, trust_safety, finance) granted on top of the base role
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS admin_roles TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_users_admin_roles ON users USING GIN (admin_roles)
+    WHERE admin_roles <> '{}';
@@ -0,0 +1,2 @@
+ALTER TABLE user_mfa DROP COLUMN IF EXISTS recovery_token_expires_at;
+ALTER TABLE user_mfa DROP COLUMN IF EXISTS recovery_token_hash;
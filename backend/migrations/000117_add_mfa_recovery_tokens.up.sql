@@ -0,0 +1,7 @@
+-- Add one-time recovery tokens for users who lost their authenticator and backup codes
+
+ALTER TABLE user_mfa ADD COLUMN IF NOT EXISTS recovery_token_hash VARCHAR(64);
+ALTER TABLE user_mfa ADD COLUMN IF NOT EXISTS recovery_token_expires_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN user_mfa.recovery_token_hash IS 'SHA-256 hash of the pending emailed MFA recovery token';
+COMMENT ON COLUMN user_mfa.recovery_token_expires_at IS 'Expiry of the pending MFA recovery token (1 hour after request)';
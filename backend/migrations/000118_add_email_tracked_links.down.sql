@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_email_logs_clicks;
+DROP TABLE IF EXISTS email_tracked_links;
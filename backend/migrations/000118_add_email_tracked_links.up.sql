@@ -0,0 +1,22 @@
+-- First-party tracked links rewritten into outgoing emails (served from /r/:token)
+CREATE TABLE IF NOT EXISTS email_tracked_links (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    token VARCHAR(32) NOT NULL UNIQUE,
+    email_notification_log_id UUID REFERENCES email_notification_logs(id) ON DELETE SET NULL,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    recipient VARCHAR(255) NOT NULL,
+    template VARCHAR(100) NOT NULL,
+    target_url TEXT NOT NULL,
+    click_count INT NOT NULL DEFAULT 0,
+    first_clicked_at TIMESTAMP,
+    last_clicked_at TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_tracked_links_user ON email_tracked_links(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_email_tracked_links_template ON email_tracked_links(template, created_at DESC);
+
+-- Supports joining first-party click logs to later user activity
+CREATE INDEX IF NOT EXISTS idx_email_logs_clicks ON email_logs(user_id, clicked_at)
+    WHERE status = 'click';
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_refresh_tokens_user_session;
+
+ALTER TABLE refresh_tokens
+    DROP COLUMN IF EXISTS user_agent,
+    DROP COLUMN IF EXISTS ip_address,
+    DROP COLUMN IF EXISTS session_id;
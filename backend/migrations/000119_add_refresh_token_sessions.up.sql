@@ -0,0 +1,16 @@
+-- Group rotated refresh tokens into sessions and record the device each session belongs to
+ALTER TABLE refresh_tokens
+    ADD COLUMN IF NOT EXISTS session_id UUID,
+    ADD COLUMN IF NOT EXISTS ip_address VARCHAR(45),
+    ADD COLUMN IF NOT EXISTS user_agent TEXT;
+
+-- Existing tokens each become their own session
+UPDATE refresh_tokens SET session_id = id WHERE session_id IS NULL;
+
+ALTER TABLE refresh_tokens
+    ALTER COLUMN session_id SET DEFAULT gen_random_uuid(),
+    ALTER COLUMN session_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_session
+    ON refresh_tokens(user_id, session_id)
+    WHERE is_revoked = false;
@@ -0,0 +1,13 @@
+-- RSA keys used to sign JWTs. The newest key whose activates_at has passed signs new
+-- tokens; older keys keep verifying until expires_at so outstanding tokens stay valid.
+CREATE TABLE IF NOT EXISTS jwt_signing_keys (
+    kid VARCHAR(64) PRIMARY KEY,
+    private_key_encrypted TEXT NOT NULL,
+    activates_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_jwt_signing_keys_activates_at ON jwt_signing_keys(activates_at DESC);
+
+COMMENT ON TABLE jwt_signing_keys IS 'JWT signing keys (private keys encrypted with AES-256-GCM) for rotation and JWKS publishing';
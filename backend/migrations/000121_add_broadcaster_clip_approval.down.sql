@@ -0,0 +1,5 @@
+-- Hand held submissions to the regular moderation queue
+UPDATE clip_submissions SET status = 'pending', updated_at = NOW() WHERE status = 'awaiting_broadcaster';
+
+DROP TABLE IF EXISTS broadcaster_clip_reviews;
+DROP TABLE IF EXISTS broadcaster_approval_policies;
@@ -0,0 +1,31 @@
+-- Per-broadcaster policy: when require_approval is set, new submissions of the
+-- broadcaster's clips are held until the broadcaster approves or rejects them.
+CREATE TABLE IF NOT EXISTS broadcaster_approval_policies (
+    broadcaster_id VARCHAR(50) PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    require_approval BOOLEAN NOT NULL DEFAULT FALSE,
+    auto_approve_after_days INT CHECK (auto_approve_after_days IS NULL OR auto_approve_after_days BETWEEN 1 AND 30),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_broadcaster_approval_policies_user ON broadcaster_approval_policies(user_id);
+
+-- One row per held submission. Pending reviews past auto_approve_at are
+-- released automatically so an inactive broadcaster cannot block clips forever.
+CREATE TABLE IF NOT EXISTS broadcaster_clip_reviews (
+    submission_id UUID PRIMARY KEY REFERENCES clip_submissions(id) ON DELETE CASCADE,
+    broadcaster_id VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected', 'expired')),
+    auto_approve_at TIMESTAMPTZ NOT NULL,
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMPTZ,
+    rejection_reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_broadcaster_clip_reviews_queue ON broadcaster_clip_reviews(broadcaster_id, created_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_broadcaster_clip_reviews_expiry ON broadcaster_clip_reviews(auto_approve_at) WHERE status = 'pending';
+
+COMMENT ON TABLE broadcaster_approval_policies IS 'Broadcaster opt-in to approve clips of their channel before they appear publicly';
+COMMENT ON TABLE broadcaster_clip_reviews IS 'Broadcaster approval queue for clip submissions held by an approval policy';
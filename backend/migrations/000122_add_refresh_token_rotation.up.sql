@@ -0,0 +1,4 @@
+-- Record when a refresh token was consumed by rotation. Presenting a rotated
+-- token again means it was copied, so the whole session is revoked.
+ALTER TABLE refresh_tokens
+    ADD COLUMN IF NOT EXISTS rotated_at TIMESTAMP;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_webhook_deliveries_subscription_created;
+DROP TABLE IF EXISTS api_key_usage_hourly;
@@ -0,0 +1,14 @@
+-- Hourly usage rollups for personal API keys
+CREATE TABLE IF NOT EXISTS api_key_usage_hourly (
+    api_key_id UUID NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+    hour TIMESTAMP NOT NULL,
+    request_count BIGINT NOT NULL DEFAULT 0,
+    error_count BIGINT NOT NULL DEFAULT 0,
+    rate_limited_count BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (api_key_id, hour)
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_key_usage_hourly_hour ON api_key_usage_hourly(hour);
+
+-- Developer usage reports group a user's webhook deliveries by hour
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_created ON webhook_deliveries(subscription_id, created_at);
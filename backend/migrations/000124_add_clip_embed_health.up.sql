@@ -0,0 +1,12 @@
+-- Per-clip Twitch embed health reported by players, used to pick playback
+-- fallbacks and to mirror clips whose embeds fail often
+CREATE TABLE IF NOT EXISTS clip_embed_health (
+    clip_id UUID PRIMARY KEY REFERENCES clips(id) ON DELETE CASCADE,
+    embed_loads BIGINT NOT NULL DEFAULT 0,
+    embed_failures BIGINT NOT NULL DEFAULT 0,
+    last_failure_reason VARCHAR(50),
+    last_failure_at TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_clip_embed_health_failures ON clip_embed_health(embed_failures DESC) WHERE embed_failures > 0;
@@ -0,0 +1,3 @@
+-- Master toggle for push notifications; per-type preferences still apply
+ALTER TABLE notification_preferences
+    ADD COLUMN IF NOT EXISTS push_enabled BOOLEAN NOT NULL DEFAULT true;
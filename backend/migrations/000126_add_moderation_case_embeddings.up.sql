@@ -0,0 +1,14 @@
+-- Title embeddings of moderation cases (submissions and reports), used to show
+-- moderators similar past decisions
+CREATE TABLE IF NOT EXISTS moderation_case_embeddings (
+    case_type VARCHAR(20) NOT NULL,
+    case_id UUID NOT NULL,
+    embedding vector(768) NOT NULL,
+    embedding_model VARCHAR(100),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (case_type, case_id),
+    CONSTRAINT moderation_case_embeddings_valid_type CHECK (case_type IN ('submission', 'report'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_moderation_case_embeddings_hnsw ON moderation_case_embeddings
+USING hnsw (embedding vector_cosine_ops);
@@ -0,0 +1,21 @@
+-- One row per user and digest period. The unique period keeps a digest from
+-- being sent twice, and the latest sent period_end keeps a notification from
+-- appearing in two digests.
+CREATE TABLE IF NOT EXISTS notification_digests (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    frequency VARCHAR(20) NOT NULL,
+    period_start TIMESTAMP NOT NULL,
+    period_end TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    notification_count INT NOT NULL DEFAULT 0,
+    attempts INT NOT NULL DEFAULT 1,
+    error_message TEXT,
+    sent_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT notification_digests_valid_frequency CHECK (frequency IN ('daily', 'weekly')),
+    CONSTRAINT notification_digests_valid_status CHECK (status IN ('pending', 'sent', 'skipped', 'failed')),
+    CONSTRAINT notification_digests_unique_period UNIQUE (user_id, frequency, period_end)
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_digests_user_sent ON notification_digests(user_id, period_end DESC) WHERE status = 'sent';
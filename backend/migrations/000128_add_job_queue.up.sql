@@ -0,0 +1,35 @@
+-- Persistent background job queue, worked by cmd/worker. Workers claim pending
+-- jobs with FOR UPDATE SKIP LOCKED, so any number of them can run side by side.
+CREATE TABLE IF NOT EXISTS jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    max_attempts INT NOT NULL DEFAULT 5,
+    run_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    unique_key VARCHAR(255),
+    locked_by VARCHAR(255),
+    locked_at TIMESTAMP,
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP,
+    CONSTRAINT jobs_valid_status CHECK (status IN ('pending', 'running', 'completed', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_pending ON jobs(run_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_jobs_running ON jobs(locked_at) WHERE status = 'running';
+CREATE INDEX IF NOT EXISTS idx_jobs_finished ON jobs(updated_at) WHERE status IN ('completed', 'failed');
+
+-- At most one queued or running job per unique key
+CREATE UNIQUE INDEX IF NOT EXISTS idx_jobs_unique_key_active ON jobs(unique_key)
+WHERE unique_key IS NOT NULL AND status IN ('pending', 'running');
+
+-- Next run of each periodic job. Claiming a due schedule is a single conditional
+-- update, so only one worker enqueues each run however many are running.
+CREATE TABLE IF NOT EXISTS job_schedules (
+    name VARCHAR(100) PRIMARY KEY,
+    next_run_at TIMESTAMP NOT NULL,
+    last_run_at TIMESTAMP
+);
@@ -0,0 +1,29 @@
+-- One row per site-wide ban. users.is_banned stays the flag checked at login;
+-- these rows record why, for how long, and how each ban ended.
+CREATE TABLE IF NOT EXISTS user_bans (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    banned_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    offense_number INT NOT NULL DEFAULT 1,
+    starts_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP, -- NULL for permanent bans
+    lifted_at TIMESTAMP,
+    lifted_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    lift_type VARCHAR(20),
+    lift_reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT user_bans_valid_lift_type CHECK (lift_type IN ('expired', 'lifted', 'superseded')),
+    CONSTRAINT user_bans_valid_expiry CHECK (expires_at IS NULL OR expires_at > starts_at)
+);
+
+-- At most one active ban per user
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_bans_active ON user_bans(user_id) WHERE lifted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_user_bans_expiring ON user_bans(expires_at) WHERE lifted_at IS NULL AND expires_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_user_bans_user_history ON user_bans(user_id, created_at DESC);
+
+-- Bans made before ban records were kept are permanent
+INSERT INTO user_bans (user_id, reason, starts_at, created_at)
+SELECT id, 'Banned before ban records were kept', updated_at, updated_at
+FROM users
+WHERE is_banned = true;
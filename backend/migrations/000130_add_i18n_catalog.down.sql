@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS i18n_missing_keys;
+DROP TABLE IF EXISTS i18n_strings;
+DROP TABLE IF EXISTS i18n_locales;
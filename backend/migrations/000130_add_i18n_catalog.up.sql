@@ -0,0 +1,42 @@
+-- Locales the UI string catalog is served in. A locale without a string falls
+-- back to fallback_locale, then to that locale's fallback, and so on.
+CREATE TABLE IF NOT EXISTS i18n_locales (
+    code VARCHAR(16) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    fallback_locale VARCHAR(16) REFERENCES i18n_locales(code) ON DELETE SET NULL,
+    is_enabled BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT i18n_locales_no_self_fallback CHECK (fallback_locale IS NULL OR fallback_locale <> code)
+);
+
+-- Translated strings, keyed by dotted key (e.g. "nav.home")
+CREATE TABLE IF NOT EXISTS i18n_strings (
+    locale VARCHAR(16) NOT NULL REFERENCES i18n_locales(code) ON DELETE CASCADE,
+    key VARCHAR(255) NOT NULL,
+    value TEXT NOT NULL,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (locale, key)
+);
+
+-- Keys clients asked for that no locale in the chain could resolve
+CREATE TABLE IF NOT EXISTS i18n_missing_keys (
+    locale VARCHAR(16) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    occurrences BIGINT NOT NULL DEFAULT 1,
+    first_seen_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (locale, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_i18n_missing_keys_last_seen ON i18n_missing_keys(last_seen_at DESC);
+
+INSERT INTO i18n_locales (code, name, fallback_locale) VALUES
+    ('en', 'English', NULL)
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO i18n_locales (code, name, fallback_locale) VALUES
+    ('es', 'Español', 'en'),
+    ('fr', 'Français', 'en')
+ON CONFLICT (code) DO NOTHING;
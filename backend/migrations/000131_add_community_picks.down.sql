@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS community_pick_results;
+DROP TABLE IF EXISTS community_pick_votes;
+DROP TABLE IF EXISTS community_pick_nominations;
+DROP TABLE IF EXISTS community_pick_rounds;
@@ -0,0 +1,58 @@
+-- Community picks: users nominate clips to a curated discovery list and vote
+-- on them until the round's deadline, when the top N are added to the list.
+CREATE TABLE IF NOT EXISTS community_pick_rounds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    list_id UUID NOT NULL REFERENCES playlists(id) ON DELETE CASCADE,
+    theme VARCHAR(200) NOT NULL,
+    description TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    top_n INT NOT NULL DEFAULT 10,
+    max_nominations_per_user INT NOT NULL DEFAULT 3,
+    max_votes_per_user INT NOT NULL DEFAULT 5,
+    voting_ends_at TIMESTAMP NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    published_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT community_pick_rounds_valid_status CHECK (status IN ('open', 'published', 'cancelled')),
+    CONSTRAINT community_pick_rounds_valid_top_n CHECK (top_n BETWEEN 1 AND 100),
+    CONSTRAINT community_pick_rounds_valid_limits CHECK (max_nominations_per_user >= 1 AND max_votes_per_user >= 1)
+);
+
+CREATE TABLE IF NOT EXISTS community_pick_nominations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    round_id UUID NOT NULL REFERENCES community_pick_rounds(id) ON DELETE CASCADE,
+    clip_id UUID NOT NULL REFERENCES clips(id) ON DELETE CASCADE,
+    nominated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE(round_id, clip_id)
+);
+
+-- weight is fixed when the vote is cast so later account changes don't move
+-- closed tallies
+CREATE TABLE IF NOT EXISTS community_pick_votes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    round_id UUID NOT NULL REFERENCES community_pick_rounds(id) ON DELETE CASCADE,
+    nomination_id UUID NOT NULL REFERENCES community_pick_nominations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    weight NUMERIC(5, 3) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE(nomination_id, user_id)
+);
+
+-- Final standings of published rounds
+CREATE TABLE IF NOT EXISTS community_pick_results (
+    round_id UUID NOT NULL REFERENCES community_pick_rounds(id) ON DELETE CASCADE,
+    clip_id UUID NOT NULL REFERENCES clips(id) ON DELETE CASCADE,
+    rank INT NOT NULL,
+    vote_count INT NOT NULL,
+    weighted_score NUMERIC(10, 3) NOT NULL,
+    picked BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (round_id, clip_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_community_pick_rounds_list ON community_pick_rounds(list_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_community_pick_rounds_due ON community_pick_rounds(voting_ends_at) WHERE status = 'open';
+CREATE INDEX IF NOT EXISTS idx_community_pick_nominations_user ON community_pick_nominations(round_id, nominated_by);
+CREATE INDEX IF NOT EXISTS idx_community_pick_votes_user ON community_pick_votes(round_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_community_pick_results_rank ON community_pick_results(round_id, rank);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS creator_ledger_entries;
+DROP TABLE IF EXISTS playlist_bundle_entitlements;
+DROP TABLE IF EXISTS playlist_bundles;
@@ -0,0 +1,52 @@
+-- Playlist bundles: creators sell access to a premium playlist's clips as a
+-- one-time Stripe payment. Buyers get an entitlement, and each sale or refund
+-- is split between the creator and the platform in the creator ledger.
+CREATE TABLE IF NOT EXISTS playlist_bundles (
+    playlist_id UUID PRIMARY KEY REFERENCES playlists(id) ON DELETE CASCADE,
+    price_cents INT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'usd',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT playlist_bundles_valid_price CHECK (price_cents BETWEEN 100 AND 50000)
+);
+
+CREATE TABLE IF NOT EXISTS playlist_bundle_entitlements (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    playlist_id UUID NOT NULL REFERENCES playlists(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    amount_cents BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    stripe_checkout_session_id VARCHAR(255) UNIQUE,
+    stripe_payment_intent_id VARCHAR(255) UNIQUE,
+    granted_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMP,
+    CONSTRAINT playlist_bundle_entitlements_valid_status CHECK (status IN ('active', 'refunded'))
+);
+
+-- A user holds at most one live entitlement per playlist
+CREATE UNIQUE INDEX IF NOT EXISTS idx_playlist_bundle_entitlements_active
+    ON playlist_bundle_entitlements(playlist_id, user_id) WHERE status = 'active';
+CREATE INDEX IF NOT EXISTS idx_playlist_bundle_entitlements_user ON playlist_bundle_entitlements(user_id, granted_at DESC);
+
+-- Amounts are positive for sales and negative for refunds, so a creator's
+-- balance is the sum of creator_cents. stripe_event_id makes webhook replays
+-- a no-op.
+CREATE TABLE IF NOT EXISTS creator_ledger_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    creator_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    entitlement_id UUID REFERENCES playlist_bundle_entitlements(id) ON DELETE SET NULL,
+    entry_type VARCHAR(20) NOT NULL,
+    gross_cents BIGINT NOT NULL,
+    platform_fee_cents BIGINT NOT NULL,
+    creator_cents BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    stripe_event_id VARCHAR(255) UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT creator_ledger_entries_valid_type CHECK (entry_type IN ('bundle_sale', 'bundle_refund')),
+    CONSTRAINT creator_ledger_entries_balanced CHECK (gross_cents = platform_fee_cents + creator_cents)
+);
+
+CREATE INDEX IF NOT EXISTS idx_creator_ledger_entries_creator ON creator_ledger_entries(creator_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_creator_ledger_entries_entitlement ON creator_ledger_entries(entitlement_id);
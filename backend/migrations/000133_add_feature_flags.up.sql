@@ -0,0 +1,18 @@
+-- Feature flags gate new functionality without a redeploy. A flag is off
+-- unless enabled; when enabled, targeting rules are checked in order and the
+-- first match decides, otherwise users fall into a stable percentage rollout.
+CREATE TABLE IF NOT EXISTS feature_flags (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    key VARCHAR(100) NOT NULL UNIQUE,
+    description TEXT,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    rollout_percentage INT NOT NULL DEFAULT 0,
+    rules JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT feature_flags_valid_key CHECK (key ~ '^[a-z0-9][a-z0-9_.-]*$'),
+    CONSTRAINT feature_flags_valid_rollout CHECK (rollout_percentage BETWEEN 0 AND 100),
+    CONSTRAINT feature_flags_rules_array CHECK (jsonb_typeof(rules) = 'array')
+);
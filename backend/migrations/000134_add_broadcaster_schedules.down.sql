@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS broadcaster_schedule_reminder_deliveries;
+DROP TABLE IF EXISTS broadcaster_schedule_reminders;
+DROP TABLE IF EXISTS broadcaster_schedule_syncs;
+DROP TABLE IF EXISTS broadcaster_schedules;
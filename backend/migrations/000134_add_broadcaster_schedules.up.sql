@@ -0,0 +1,51 @@
+-- Broadcaster stream schedules pulled from the Twitch Schedule API. Each row
+-- is one scheduled occurrence; recurring segments have a row per occurrence.
+CREATE TABLE IF NOT EXISTS broadcaster_schedules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    broadcaster_id VARCHAR(100) NOT NULL,
+    segment_id TEXT NOT NULL, -- Twitch segment ID
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    category_id VARCHAR(100),
+    category_name VARCHAR(255),
+    start_time TIMESTAMPTZ NOT NULL,
+    end_time TIMESTAMPTZ,
+    is_recurring BOOLEAN NOT NULL DEFAULT false,
+    is_canceled BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (broadcaster_id, segment_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_broadcaster_schedules_broadcaster_start ON broadcaster_schedules(broadcaster_id, start_time);
+
+-- One row per synced broadcaster, so a broadcaster without a schedule isn't
+-- fetched again on every request
+CREATE TABLE IF NOT EXISTS broadcaster_schedule_syncs (
+    broadcaster_id VARCHAR(100) PRIMARY KEY,
+    broadcaster_name VARCHAR(100),
+    broadcaster_login VARCHAR(100),
+    vacation_start TIMESTAMPTZ,
+    vacation_end TIMESTAMPTZ,
+    synced_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Users opt in to reminders per followed broadcaster
+CREATE TABLE IF NOT EXISTS broadcaster_schedule_reminders (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    broadcaster_id VARCHAR(100) NOT NULL,
+    minutes_before INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, broadcaster_id),
+    CONSTRAINT broadcaster_schedule_reminders_valid_minutes CHECK (minutes_before BETWEEN 5 AND 1440)
+);
+
+CREATE INDEX IF NOT EXISTS idx_broadcaster_schedule_reminders_broadcaster ON broadcaster_schedule_reminders(broadcaster_id);
+
+-- Reminders already sent, so each user is reminded once per occurrence
+CREATE TABLE IF NOT EXISTS broadcaster_schedule_reminder_deliveries (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    schedule_id UUID NOT NULL REFERENCES broadcaster_schedules(id) ON DELETE CASCADE,
+    sent_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, schedule_id)
+);
@@ -0,0 +1,16 @@
+-- Accessibility metadata for clips, set by creators and moderators. Captions
+-- can also be detected from transcripts; a manual setting always wins.
+CREATE TABLE IF NOT EXISTS clip_accessibility (
+    clip_id UUID PRIMARY KEY REFERENCES clips(id) ON DELETE CASCADE,
+    has_captions BOOLEAN NOT NULL DEFAULT false,
+    captions_source VARCHAR(20), -- manual or transcript
+    photosensitivity_warning BOOLEAN NOT NULL DEFAULT false,
+    audio_description BOOLEAN NOT NULL DEFAULT false,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT clip_accessibility_valid_captions_source CHECK (captions_source IN ('manual', 'transcript'))
+);
+
+-- Partial indexes for the "only captioned" and photosensitivity feed filters
+CREATE INDEX IF NOT EXISTS idx_clip_accessibility_captioned ON clip_accessibility(clip_id) WHERE has_captions = true;
+CREATE INDEX IF NOT EXISTS idx_clip_accessibility_photosensitive ON clip_accessibility(clip_id) WHERE photosensitivity_warning = true;
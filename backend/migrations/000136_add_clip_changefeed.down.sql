@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS trg_clips_outbox ON clips;
+DROP FUNCTION IF EXISTS clip_outbox_record_change();
+DROP FUNCTION IF EXISTS clip_changefeed_document(clips);
+DROP TABLE IF EXISTS clip_outbox;
@@ -0,0 +1,84 @@
+-- Transactional outbox behind the public clip changefeed. A trigger on clips
+-- records create/update/delete events for public clips (not removed, not
+-- hidden) in the writing transaction. The changefeed relay later assigns
+-- sequence numbers in commit order, so readers never see a sequence appear
+-- below one they have already read.
+CREATE TABLE IF NOT EXISTS clip_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    event_id UUID NOT NULL DEFAULT gen_random_uuid(),
+    clip_id UUID NOT NULL,
+    event_type VARCHAR(10) NOT NULL,
+    document JSONB, -- Public clip fields at the time of the change; NULL for deletes
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    sequence BIGINT UNIQUE, -- Assigned by the relay; NULL until then
+    published_at TIMESTAMPTZ, -- When the event was handed to webhook delivery
+    CONSTRAINT clip_outbox_valid_event_type CHECK (event_type IN ('create', 'update', 'delete'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_clip_outbox_unsequenced ON clip_outbox(id) WHERE sequence IS NULL;
+CREATE INDEX IF NOT EXISTS idx_clip_outbox_unpublished ON clip_outbox(sequence) WHERE sequence IS NOT NULL AND published_at IS NULL;
+
+-- The clip fields partners receive. Engagement counters are left out: they
+-- change constantly and would flood the feed.
+CREATE OR REPLACE FUNCTION clip_changefeed_document(c clips) RETURNS JSONB AS $$
+    SELECT jsonb_build_object(
+        'id', c.id,
+        'twitch_clip_id', c.twitch_clip_id,
+        'twitch_clip_url', c.twitch_clip_url,
+        'embed_url', c.embed_url,
+        'title', c.title,
+        'creator_name', c.creator_name,
+        'creator_id', c.creator_id,
+        'broadcaster_name', c.broadcaster_name,
+        'broadcaster_id', c.broadcaster_id,
+        'game_id', c.game_id,
+        'game_name', c.game_name,
+        'language', c.language,
+        'thumbnail_url', c.thumbnail_url,
+        'duration', c.duration,
+        'is_nsfw', c.is_nsfw,
+        'created_at', c.created_at
+    );
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION clip_outbox_record_change() RETURNS trigger AS $$
+DECLARE
+    was_public BOOLEAN := false;
+    is_public BOOLEAN := false;
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        was_public := OLD.is_removed IS FALSE AND OLD.is_hidden IS FALSE;
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        is_public := NEW.is_removed IS FALSE AND NEW.is_hidden IS FALSE;
+    END IF;
+
+    IF is_public AND NOT was_public THEN
+        INSERT INTO clip_outbox (clip_id, event_type, document)
+        VALUES (NEW.id, 'create', clip_changefeed_document(NEW));
+    ELSIF was_public AND NOT is_public THEN
+        INSERT INTO clip_outbox (clip_id, event_type)
+        VALUES (OLD.id, 'delete');
+    ELSIF is_public AND clip_changefeed_document(OLD) IS DISTINCT FROM clip_changefeed_document(NEW) THEN
+        INSERT INTO clip_outbox (clip_id, event_type, document)
+        VALUES (NEW.id, 'update', clip_changefeed_document(NEW));
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_clips_outbox ON clips;
+CREATE TRIGGER trg_clips_outbox
+    AFTER INSERT OR UPDATE OR DELETE ON clips
+    FOR EACH ROW
+    EXECUTE FUNCTION clip_outbox_record_change();
+
+-- Seed the feed with the current catalog so a partner starting from sequence
+-- 0 receives every public clip. These events are already published: existing
+-- webhook subscribers shouldn't get one delivery per clip.
+INSERT INTO clip_outbox (clip_id, event_type, document, occurred_at, sequence, published_at)
+SELECT c.id, 'create', clip_changefeed_document(c), NOW(),
+       ROW_NUMBER() OVER (ORDER BY c.created_at, c.id), NOW()
+FROM clips c
+WHERE c.is_removed = false AND c.is_hidden = false;
@@ -0,0 +1,21 @@
+-- Search synonyms let gaming terms and streamer nicknames match their full
+-- names. Equivalent sets match each other both ways; aliases expand a term to
+-- its target one way. The search indices bake the enabled rules into their
+-- analyzers, so changes apply when search-index-manager rebuilds them.
+CREATE TABLE IF NOT EXISTS search_synonyms (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind VARCHAR(20) NOT NULL,
+    terms TEXT[] NOT NULL,
+    target TEXT,
+    description TEXT,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT search_synonyms_valid_kind CHECK (kind IN ('equivalent', 'alias')),
+    CONSTRAINT search_synonyms_terms_present CHECK (cardinality(terms) > 0),
+    CONSTRAINT search_synonyms_alias_target CHECK ((kind = 'alias') = (target IS NOT NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_search_synonyms_terms ON search_synonyms USING GIN (terms);
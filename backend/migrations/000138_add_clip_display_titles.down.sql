@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS broadcaster_title_preferences;
+ALTER TABLE clips DROP COLUMN IF EXISTS display_title;
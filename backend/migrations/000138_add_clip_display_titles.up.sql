@@ -0,0 +1,18 @@
+-- Cleaned-up clip titles (emoji spam, shouting and repeated punctuation
+-- removed) used for search and SEO. NULL when normalization is off for the
+-- broadcaster or found nothing to change; the original title is kept as is.
+ALTER TABLE clips ADD COLUMN IF NOT EXISTS display_title TEXT;
+
+COMMENT ON COLUMN clips.display_title IS 'Normalized title for search and SEO; NULL means the original title is used';
+
+-- Per-broadcaster opt-out of title normalization. Broadcasters without a row
+-- get normalized titles.
+CREATE TABLE IF NOT EXISTS broadcaster_title_preferences (
+    broadcaster_id VARCHAR(50) PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    normalize_titles BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE broadcaster_title_preferences IS 'Broadcaster choice of whether clip titles of their channel are normalized for display';
@@ -0,0 +1,5 @@
+-- Lets users turn off search results re-ranked by the broadcasters and games
+-- they follow and the games they downvote. On by default.
+ALTER TABLE user_settings ADD COLUMN IF NOT EXISTS personalized_search BOOLEAN NOT NULL DEFAULT TRUE;
+
+COMMENT ON COLUMN user_settings.personalized_search IS 'Whether search results are personalized from the user''s follows and votes';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_appeals_resolved_at;
+DROP TABLE IF EXISTS moderation_shifts;
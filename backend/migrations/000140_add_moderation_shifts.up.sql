@@ -0,0 +1,26 @@
+-- Moderation shifts: when a moderator team works, in the team's local time.
+-- An end-of-shift handoff report covers each shift, and can be emailed when
+-- it ends.
+CREATE TABLE IF NOT EXISTS moderation_shifts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC', -- IANA time zone the shift times are in
+    start_time VARCHAR(5) NOT NULL, -- Local start time, HH:MM
+    duration_minutes INT NOT NULL,
+    moderator_ids UUID[] NOT NULL DEFAULT '{}', -- The shift's team; empty means every moderator
+    email_recipients TEXT[] NOT NULL DEFAULT '{}',
+    email_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    last_reported_end TIMESTAMPTZ, -- End of the last shift whose report was emailed
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT moderation_shifts_valid_start_time CHECK (start_time ~ '^([01][0-9]|2[0-3]):[0-5][0-9]$'),
+    CONSTRAINT moderation_shifts_valid_duration CHECK (duration_minutes BETWEEN 15 AND 1440)
+);
+
+CREATE INDEX IF NOT EXISTS idx_moderation_shifts_email ON moderation_shifts(email_enabled) WHERE email_enabled;
+
+-- Handoff reports count appeals resolved during a shift
+CREATE INDEX IF NOT EXISTS idx_appeals_resolved_at ON moderation_appeals(resolved_at) WHERE resolved_at IS NOT NULL;
+
+COMMENT ON TABLE moderation_shifts IS 'Moderator shift schedules that end-of-shift handoff reports are generated for';
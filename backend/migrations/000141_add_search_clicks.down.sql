@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_search_queries_created_query;
+DROP TABLE IF EXISTS search_clicks;
@@ -0,0 +1,21 @@
+-- Search result clicks: which result of a tracked search a user opened, and
+-- at what position, for click-through rates in search analytics.
+CREATE TABLE IF NOT EXISTS search_clicks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    search_query_id UUID NOT NULL REFERENCES search_queries(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    result_id UUID NOT NULL,
+    result_type VARCHAR(20) NOT NULL,
+    position INT NOT NULL, -- 1-based rank of the result in the search's results
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT search_clicks_valid_position CHECK (position >= 1),
+    CONSTRAINT search_clicks_valid_result_type CHECK (result_type IN ('clip', 'creator', 'game', 'tag'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_search_clicks_search_query ON search_clicks(search_query_id);
+CREATE INDEX IF NOT EXISTS idx_search_clicks_created ON search_clicks(created_at DESC);
+
+-- Analytics group queries case-insensitively
+CREATE INDEX IF NOT EXISTS idx_search_queries_created_query ON search_queries(created_at, lower(btrim(query)));
+
+COMMENT ON TABLE search_clicks IS 'Search results opened from tracked searches, for click-through analytics';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_community_discussions_community_created;
+DROP INDEX IF EXISTS idx_community_members_community_joined;
+DROP TABLE IF EXISTS community_digests;
+DROP TABLE IF EXISTS community_digest_settings;
@@ -0,0 +1,29 @@
+-- Weekly community digests: a pinned discussion posted in each active
+-- community summarising the week's top clips, top contributors and
+-- membership growth. Owners can opt out or customise the post templates.
+CREATE TABLE IF NOT EXISTS community_digest_settings (
+    community_id UUID PRIMARY KEY REFERENCES communities(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    title_template TEXT, -- NULL uses the default template
+    body_template TEXT,  -- NULL uses the default template
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS community_digests (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    community_id UUID NOT NULL REFERENCES communities(id) ON DELETE CASCADE,
+    discussion_id UUID NOT NULL REFERENCES community_discussions(id) ON DELETE CASCADE,
+    week_start DATE NOT NULL, -- Monday (UTC) of the week the digest covers
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE(community_id, week_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_community_digests_discussion ON community_digests(discussion_id);
+CREATE INDEX IF NOT EXISTS idx_community_digests_created ON community_digests(created_at DESC);
+
+-- Weekly activity lookups scan members and comments by time
+CREATE INDEX IF NOT EXISTS idx_community_members_community_joined ON community_members(community_id, joined_at);
+CREATE INDEX IF NOT EXISTS idx_community_discussions_community_created ON community_discussions(community_id, created_at);
+
+COMMENT ON TABLE community_digest_settings IS 'Per-community opt-out and templates for weekly digest posts';
+COMMENT ON TABLE community_digests IS 'Weekly digest discussions posted in communities, one per community and week';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS clip_ingestion_rule_hits;
+DROP TABLE IF EXISTS clip_ingestion_rules;
@@ -0,0 +1,36 @@
+-- Clip ingestion rules: conditions on game, broadcaster, language and views
+-- evaluated against clips during sync. Matching clips are routed into a
+-- discovery list, custom feed or community automatically.
+CREATE TABLE IF NOT EXISTS clip_ingestion_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(200) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    game_ids TEXT[] NOT NULL DEFAULT '{}',        -- Empty matches any game
+    broadcaster_ids TEXT[] NOT NULL DEFAULT '{}', -- Empty matches any broadcaster
+    languages TEXT[] NOT NULL DEFAULT '{}',       -- Empty matches any language
+    min_views INT,
+    max_views INT,
+    target_type VARCHAR(20) NOT NULL CHECK (target_type IN ('discovery_list', 'feed', 'community')),
+    target_id UUID NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    match_count BIGINT NOT NULL DEFAULT 0,
+    last_matched_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (min_views IS NULL OR max_views IS NULL OR min_views <= max_views)
+);
+
+-- One row per clip a rule routed, so clips re-seen by later syncs are only
+-- routed and counted once
+CREATE TABLE IF NOT EXISTS clip_ingestion_rule_hits (
+    rule_id UUID NOT NULL REFERENCES clip_ingestion_rules(id) ON DELETE CASCADE,
+    clip_id UUID NOT NULL REFERENCES clips(id) ON DELETE CASCADE,
+    matched_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (rule_id, clip_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_clip_ingestion_rule_hits_matched ON clip_ingestion_rule_hits(rule_id, matched_at DESC);
+CREATE INDEX IF NOT EXISTS idx_clip_ingestion_rule_hits_clip ON clip_ingestion_rule_hits(clip_id);
+
+COMMENT ON TABLE clip_ingestion_rules IS 'Admin rules routing synced clips into discovery lists, feeds and communities';
+COMMENT ON TABLE clip_ingestion_rule_hits IS 'Clips routed by each ingestion rule';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_search_queries_ranker;
+ALTER TABLE search_queries DROP COLUMN IF EXISTS ranker;
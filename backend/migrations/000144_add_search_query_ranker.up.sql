@@ -0,0 +1,6 @@
+-- The ranker that ordered a search's clips while learning-to-rank is being
+-- A/B tested: 'ltr' for the re-ranked arm, 'control' for the fused ranking.
+-- NULL for searches outside the test.
+ALTER TABLE search_queries ADD COLUMN IF NOT EXISTS ranker VARCHAR(20);
+
+CREATE INDEX IF NOT EXISTS idx_search_queries_ranker ON search_queries(ranker, created_at) WHERE ranker IS NOT NULL;
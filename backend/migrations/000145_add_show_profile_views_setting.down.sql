@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_daily_analytics_profile_views;
+ALTER TABLE user_settings DROP COLUMN IF EXISTS show_profile_views;
@@ -0,0 +1,11 @@
+-- Lets users show how often their profile is viewed to visitors. Owners
+-- always see their own counts; hidden from others by default.
+ALTER TABLE user_settings ADD COLUMN IF NOT EXISTS show_profile_views BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN user_settings.show_profile_views IS 'Whether the profile view counter is shown to other users';
+
+-- Daily profile view rollups live in daily_analytics
+-- (metric_type 'profile_views', entity_type 'user')
+CREATE INDEX IF NOT EXISTS idx_daily_analytics_profile_views
+    ON daily_analytics(entity_id, date)
+    WHERE metric_type = 'profile_views' AND entity_type = 'user';
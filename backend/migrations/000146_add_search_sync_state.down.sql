@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS search_sync_state;
+
+DROP INDEX IF EXISTS idx_clip_accessibility_updated_at;
+DROP INDEX IF EXISTS idx_users_updated_at;
+DROP INDEX IF EXISTS idx_tags_updated_at;
+DROP INDEX IF EXISTS idx_clips_updated_at;
+
+DROP TRIGGER IF EXISTS update_tags_updated_at ON tags;
+DROP TRIGGER IF EXISTS update_clips_updated_at ON clips;
+
+ALTER TABLE tags DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE clips DROP COLUMN IF EXISTS updated_at;
@@ -0,0 +1,40 @@
+-- Incremental search reindexing picks up rows changed since the last run, so
+-- clips and tags get an updated_at like users already have. Existing rows
+-- are backfilled with their import or creation time so the first run doesn't
+-- treat the whole catalog as changed.
+ALTER TABLE clips ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP;
+UPDATE clips SET updated_at = COALESCE(imported_at, created_at, NOW()) WHERE updated_at IS NULL;
+ALTER TABLE clips ALTER COLUMN updated_at SET DEFAULT NOW();
+ALTER TABLE clips ALTER COLUMN updated_at SET NOT NULL;
+
+ALTER TABLE tags ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP;
+UPDATE tags SET updated_at = COALESCE(created_at, NOW()) WHERE updated_at IS NULL;
+ALTER TABLE tags ALTER COLUMN updated_at SET DEFAULT NOW();
+ALTER TABLE tags ALTER COLUMN updated_at SET NOT NULL;
+
+DROP TRIGGER IF EXISTS update_clips_updated_at ON clips;
+CREATE TRIGGER update_clips_updated_at BEFORE UPDATE ON clips
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_tags_updated_at ON tags;
+CREATE TRIGGER update_tags_updated_at BEFORE UPDATE ON tags
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE INDEX IF NOT EXISTS idx_clips_updated_at ON clips(updated_at);
+CREATE INDEX IF NOT EXISTS idx_tags_updated_at ON tags(updated_at);
+CREATE INDEX IF NOT EXISTS idx_users_updated_at ON users(updated_at);
+CREATE INDEX IF NOT EXISTS idx_clip_accessibility_updated_at ON clip_accessibility(updated_at);
+
+-- Checkpoint of each search index: rows changed at or after synced_through
+-- (less a small overlap for late commits) are reindexed by the next
+-- incremental run. Full rebuilds reset it to when they started reading.
+CREATE TABLE IF NOT EXISTS search_sync_state (
+    index_name VARCHAR(50) PRIMARY KEY,
+    synced_through TIMESTAMP NOT NULL,
+    last_run_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_mode VARCHAR(20) NOT NULL, -- rebuild or incremental
+    documents_indexed BIGINT NOT NULL DEFAULT 0,
+    documents_deleted BIGINT NOT NULL DEFAULT 0
+);
+
+COMMENT ON TABLE search_sync_state IS 'Incremental search reindexing checkpoint per index';
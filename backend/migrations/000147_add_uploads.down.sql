@@ -0,0 +1,3 @@
+ALTER TABLE users DROP COLUMN IF EXISTS avatar_upload_id;
+DROP TRIGGER IF EXISTS update_uploads_updated_at ON uploads;
+DROP TABLE IF EXISTS uploads;
@@ -0,0 +1,38 @@
+-- Files uploaded straight to object storage through presigned URLs, shared by
+-- avatars, ad creatives, report evidence and community assets. An upload is
+-- pending until the client completes it, then ready once its size, sniffed
+-- type and virus scan pass, and attached once something uses it. Pending
+-- uploads past expires_at, ready ones never attached and detached ones are
+-- orphans, deleted with their objects by the cleanup job.
+CREATE TABLE IF NOT EXISTS uploads (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    context VARCHAR(30) NOT NULL,
+    object_key VARCHAR(255) NOT NULL UNIQUE,
+    filename VARCHAR(255) NOT NULL,
+    declared_content_type VARCHAR(100) NOT NULL,
+    content_type VARCHAR(100), -- sniffed from the uploaded bytes
+    size_bytes BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    rejection_reason TEXT,
+    attached_type VARCHAR(30),
+    attached_id VARCHAR(64),
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT uploads_valid_context CHECK (context IN ('avatar', 'ad_creative', 'report_evidence', 'community_asset')),
+    CONSTRAINT uploads_valid_status CHECK (status IN ('pending', 'ready', 'rejected', 'attached', 'detached'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_uploads_user ON uploads(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_uploads_attached ON uploads(attached_type, attached_id) WHERE status = 'attached';
+CREATE INDEX IF NOT EXISTS idx_uploads_orphans ON uploads(status, updated_at) WHERE status <> 'attached';
+
+CREATE TRIGGER update_uploads_updated_at BEFORE UPDATE ON uploads
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE uploads IS 'Presigned file uploads shared by avatars, ad creatives, report evidence and community assets';
+
+-- Set while a user's avatar is an upload, so logging in through Twitch no
+-- longer overwrites it with the Twitch profile image
+ALTER TABLE users ADD COLUMN IF NOT EXISTS avatar_upload_id UUID REFERENCES uploads(id) ON DELETE SET NULL;
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS trg_clips_game_patch ON clips;
+DROP FUNCTION IF EXISTS tag_clip_game_patch();
+DROP FUNCTION IF EXISTS game_patch_for(VARCHAR, TIMESTAMP);
+ALTER TABLE clips DROP COLUMN IF EXISTS game_patch_id;
+DROP TRIGGER IF EXISTS update_game_patches_updated_at ON game_patches;
+DROP TABLE IF EXISTS game_patches;
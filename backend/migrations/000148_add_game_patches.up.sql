@@ -0,0 +1,58 @@
+-- Patches (versions) of games, entered by admins or imported from IGDB. A
+-- patch is in effect from released_at until the game's next patch, and clips
+-- are tagged with the patch in effect when they were created on Twitch.
+CREATE TABLE IF NOT EXISTS game_patches (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    game_id UUID NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    version VARCHAR(50) NOT NULL,
+    name VARCHAR(200),
+    notes_url TEXT,
+    released_at TIMESTAMP NOT NULL,
+    source VARCHAR(20) NOT NULL DEFAULT 'admin',
+    igdb_id VARCHAR(50),
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT game_patches_unique_version UNIQUE (game_id, version),
+    CONSTRAINT game_patches_valid_source CHECK (source IN ('admin', 'igdb'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_game_patches_game_released ON game_patches(game_id, released_at DESC);
+
+CREATE TRIGGER update_game_patches_updated_at BEFORE UPDATE ON game_patches
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE game_patches IS 'Game patches, each in effect from released_at until the next patch of its game';
+
+ALTER TABLE clips ADD COLUMN IF NOT EXISTS game_patch_id UUID REFERENCES game_patches(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_clips_game_patch ON clips(game_patch_id) WHERE game_patch_id IS NOT NULL;
+
+-- game_patch_for returns the patch of a Twitch game in effect at a time
+CREATE OR REPLACE FUNCTION game_patch_for(p_twitch_game_id VARCHAR, p_at TIMESTAMP)
+RETURNS UUID AS $$
+    SELECT gp.id
+    FROM game_patches gp
+    JOIN games g ON g.id = gp.game_id
+    WHERE g.twitch_game_id = p_twitch_game_id AND gp.released_at <= p_at
+    ORDER BY gp.released_at DESC
+    LIMIT 1
+$$ LANGUAGE sql STABLE;
+
+-- Tag new clips, and clips whose game or creation time changes, with their patch
+CREATE OR REPLACE FUNCTION tag_clip_game_patch()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.game_id IS NULL THEN
+        NEW.game_patch_id := NULL;
+    ELSE
+        NEW.game_patch_id := game_patch_for(NEW.game_id, NEW.created_at);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_clips_game_patch ON clips;
+CREATE TRIGGER trg_clips_game_patch
+    BEFORE INSERT OR UPDATE OF game_id, created_at ON clips
+    FOR EACH ROW EXECUTE FUNCTION tag_clip_game_patch();
@@ -0,0 +1,3 @@
+ALTER TABLE notification_preferences DROP COLUMN IF EXISTS notify_reengagement;
+
+DROP TABLE IF EXISTS reengagement_emails;
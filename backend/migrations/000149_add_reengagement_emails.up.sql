@@ -0,0 +1,32 @@
+-- Re-engagement emails sent to users who have been inactive for 14, 30 or 60
+-- days. A user's inactivity streak is identified by their last activity
+-- (inactive_since), so each milestone is emailed at most once per streak and
+-- a user who comes back and leaves again starts over.
+CREATE TABLE IF NOT EXISTS reengagement_emails (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    milestone_days INT NOT NULL,
+    inactive_since TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    skip_reason VARCHAR(30),
+    clip_count INT NOT NULL DEFAULT 0,
+    from_follows BOOLEAN NOT NULL DEFAULT false,
+    email_log_id UUID REFERENCES email_notification_logs(id) ON DELETE SET NULL,
+    attempts INT NOT NULL DEFAULT 1,
+    error_message TEXT,
+    sent_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT reengagement_emails_valid_milestone CHECK (milestone_days IN (14, 30, 60)),
+    CONSTRAINT reengagement_emails_valid_status CHECK (status IN ('pending', 'sent', 'skipped', 'failed')),
+    CONSTRAINT reengagement_emails_unique_milestone UNIQUE (user_id, inactive_since, milestone_days)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reengagement_emails_user_sent ON reengagement_emails(user_id, sent_at DESC) WHERE status = 'sent';
+CREATE INDEX IF NOT EXISTS idx_reengagement_emails_created ON reengagement_emails(created_at DESC);
+
+-- Users can turn re-engagement emails off without losing other emails
+ALTER TABLE notification_preferences
+ADD COLUMN IF NOT EXISTS notify_reengagement BOOLEAN NOT NULL DEFAULT true;
+
+COMMENT ON TABLE reengagement_emails IS 'Re-engagement emails to inactive users, one per user, inactivity streak and milestone';
+COMMENT ON COLUMN notification_preferences.notify_reengagement IS 'Receive emails with highlights after a period of inactivity';
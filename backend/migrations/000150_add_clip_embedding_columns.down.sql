@@ -0,0 +1 @@
+DROP TABLE IF EXISTS clip_embedding_columns;
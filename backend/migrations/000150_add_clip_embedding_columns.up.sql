@@ -0,0 +1,38 @@
+-- Registry of the clips columns holding embeddings. Switching embedding
+-- models, which may change the vector dimensions, adds a new column that is
+-- backfilled with the new model before search is switched to it.
+CREATE TABLE IF NOT EXISTS clip_embedding_columns (
+    column_name VARCHAR(63) PRIMARY KEY,
+    provider VARCHAR(50),
+    model VARCHAR(100) NOT NULL,
+    dimensions INTEGER NOT NULL CHECK (dimensions > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'backfilling'
+        CHECK (status IN ('backfilling', 'ready', 'active', 'retired')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    backfilled_at TIMESTAMP,
+    activated_at TIMESTAMP,
+    retired_at TIMESTAMP
+);
+
+-- At most one column is active
+CREATE UNIQUE INDEX IF NOT EXISTS idx_clip_embedding_columns_active
+    ON clip_embedding_columns (status) WHERE status = 'active';
+
+-- The original column, labelled with the model most of its embeddings came from
+INSERT INTO clip_embedding_columns (column_name, model, dimensions, status, backfilled_at, activated_at)
+SELECT 'embedding',
+       COALESCE(
+           (SELECT embedding_model FROM clips
+            WHERE embedding_model IS NOT NULL
+            GROUP BY embedding_model
+            ORDER BY COUNT(*) DESC
+            LIMIT 1),
+           'text-embedding-3-small'
+       ),
+       768, 'active', NOW(), NOW()
+ON CONFLICT (column_name) DO NOTHING;
+
+COMMENT ON TABLE clip_embedding_columns IS
+'Clips columns holding embeddings and the model that produced them. Search reads the column named by EMBEDDING_COLUMN; cmd/migrate-embeddings manages the rest.';
+COMMENT ON COLUMN clip_embedding_columns.status IS
+'backfilling: being filled with its model; ready: backfilled and indexed; active: read by search; retired: replaced and safe to drop';
@@ -0,0 +1,19 @@
+-- Progress checkpoints of backfill jobs, so a job interrupted partway through
+-- resumes after the last batch it finished instead of starting over
+CREATE TABLE IF NOT EXISTS backfill_state (
+    name VARCHAR(100) PRIMARY KEY,
+    status VARCHAR(20) NOT NULL DEFAULT 'running'
+        CHECK (status IN ('running', 'completed', 'failed')),
+    cursor TEXT NOT NULL DEFAULT '',
+    processed BIGINT NOT NULL DEFAULT 0,
+    failed BIGINT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    started_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP
+);
+
+COMMENT ON TABLE backfill_state IS
+'Checkpoints of the backfill commands, one row per job such as search:clips. Run a command with -resume to continue from its checkpoint.';
+COMMENT ON COLUMN backfill_state.cursor IS
+'Sort key of the last item of the last batch finished, in the job''s own encoding. Every batch before it is done.';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS clip_embed_daily_stats;
+DROP TABLE IF EXISTS embed_domain_rules;
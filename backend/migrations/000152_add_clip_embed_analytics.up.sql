@@ -0,0 +1,36 @@
+-- Domains allowed or blocked from embedding clips. Rules without a
+-- broadcaster are platform-wide and set by admins; the others are set by the
+-- broadcaster whose clips they cover. A rule on a domain covers its subdomains.
+CREATE TABLE IF NOT EXISTS embed_domain_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    broadcaster_id VARCHAR(50),
+    domain VARCHAR(253) NOT NULL,
+    rule VARCHAR(10) NOT NULL CHECK (rule IN ('allow', 'block')),
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_embed_domain_rules_scope_domain
+    ON embed_domain_rules ((COALESCE(broadcaster_id, '')), domain);
+
+-- Daily embed traffic per embedding site and clip
+CREATE TABLE IF NOT EXISTS clip_embed_daily_stats (
+    day DATE NOT NULL,
+    domain VARCHAR(253) NOT NULL,
+    clip_id UUID NOT NULL REFERENCES clips(id) ON DELETE CASCADE,
+    broadcaster_id VARCHAR(50),
+    load_count BIGINT NOT NULL DEFAULT 0,
+    click_count BIGINT NOT NULL DEFAULT 0,
+    blocked_count BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (day, domain, clip_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_clip_embed_daily_stats_broadcaster_day
+    ON clip_embed_daily_stats(broadcaster_id, day);
+
+COMMENT ON COLUMN clip_embed_daily_stats.domain IS
+'Host of the page embedding the clip, from the Referer of the embed player, or unknown when none was sent';
+COMMENT ON COLUMN clip_embed_daily_stats.click_count IS
+'Visits to clpr.tv from the embed player''s link';
+COMMENT ON COLUMN clip_embed_daily_stats.blocked_count IS
+'Embed player loads refused by a domain rule';
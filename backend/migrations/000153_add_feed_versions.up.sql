@@ -0,0 +1,3 @@
+-- Feed versions for optimistic concurrency: every change to a feed's clips
+-- bumps the version, and edits made against an older version are refused
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_users_username_lower;
+DROP TABLE IF EXISTS comment_mentions;
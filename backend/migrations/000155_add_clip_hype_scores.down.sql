@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS clip_hype_scores;
+DROP TABLE IF EXISTS chat_activity;
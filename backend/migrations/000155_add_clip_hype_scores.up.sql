@@ -0,0 +1,31 @@
+-- Chat activity of followed broadcasters' channels in 10-second buckets,
+-- recorded from EventSub chat messages, so clips can be scored against the
+-- chat around the moment they were clipped
+CREATE TABLE IF NOT EXISTS chat_activity (
+    broadcaster_id VARCHAR(50) NOT NULL,
+    bucket_start TIMESTAMP NOT NULL,
+    message_count INTEGER NOT NULL DEFAULT 0,
+    emote_count INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (broadcaster_id, bucket_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_activity_bucket_start ON chat_activity(bucket_start);
+
+-- Hype score of a clip, from how far chat outpaced its usual rate while the
+-- clipped moment played and how much of it was emotes
+CREATE TABLE IF NOT EXISTS clip_hype_scores (
+    clip_id UUID PRIMARY KEY REFERENCES clips(id) ON DELETE CASCADE,
+    hype_score REAL NOT NULL CHECK (hype_score >= 0 AND hype_score <= 1),
+    message_count INTEGER NOT NULL DEFAULT 0,
+    emote_count INTEGER NOT NULL DEFAULT 0,
+    messages_per_minute REAL NOT NULL DEFAULT 0,
+    baseline_messages_per_minute REAL NOT NULL DEFAULT 0,
+    scored_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_clip_hype_scores_scored_at ON clip_hype_scores(scored_at);
+
+COMMENT ON COLUMN clip_hype_scores.message_count IS
+'Chat messages sent while the clipped moment played, plus a short reaction delay';
+COMMENT ON COLUMN clip_hype_scores.baseline_messages_per_minute IS
+'Chat rate over the 10 minutes before the clipped moment';
@@ -0,0 +1,11 @@
+-- Emoji reactions on comments, from a fixed set. A user can give a comment
+-- several reactions but each one once.
+CREATE TABLE IF NOT EXISTS comment_reactions (
+    comment_id UUID NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reaction VARCHAR(20) NOT NULL CHECK (reaction IN ('thumbs_up', 'heart', 'laugh', 'wow', 'sad', 'fire')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (comment_id, user_id, reaction)
+);
+
+CREATE INDEX IF NOT EXISTS idx_comment_reactions_user ON comment_reactions(user_id, created_at DESC);
@@ -0,0 +1,17 @@
+-- Bulk email throttle, a single row admins adjust while sends are running
+CREATE TABLE IF NOT EXISTS email_throttle_settings (
+    id SMALLINT PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    paused BOOLEAN NOT NULL DEFAULT false,
+    max_per_minute INTEGER NOT NULL DEFAULT 100 CHECK (max_per_minute > 0),
+    ramp_start_per_minute INTEGER NOT NULL DEFAULT 10 CHECK (ramp_start_per_minute >= 0),
+    ramp_minutes INTEGER NOT NULL DEFAULT 60 CHECK (ramp_minutes >= 0),
+    default_window JSONB NOT NULL DEFAULT '{"timezone": "UTC", "start_hour": 9, "end_hour": 21}',
+    windows JSONB NOT NULL DEFAULT '[]',
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO email_throttle_settings (id) VALUES (1) ON CONFLICT (id) DO NOTHING;
+
+-- Time zone bulk email send windows are applied in, IANA name
+ALTER TABLE notification_preferences ADD COLUMN IF NOT EXISTS timezone VARCHAR(64);
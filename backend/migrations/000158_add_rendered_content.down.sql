@@ -0,0 +1,2 @@
+ALTER TABLE community_discussions DROP COLUMN IF EXISTS rendered_content;
+ALTER TABLE comments DROP COLUMN IF EXISTS rendered_content;
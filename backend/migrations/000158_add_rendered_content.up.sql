@@ -0,0 +1,4 @@
+-- Sanitized HTML rendered from Markdown when content is written. NULL for
+-- content written before rendering was stored; it is rendered when read.
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS rendered_content TEXT;
+ALTER TABLE community_discussions ADD COLUMN IF NOT EXISTS rendered_content TEXT;
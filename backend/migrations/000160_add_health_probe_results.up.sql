@@ -0,0 +1,16 @@
+-- Periodic health probe results: one row per dependency probe, and one per
+-- endpoint group for each interval it served traffic in
+CREATE TABLE IF NOT EXISTS health_probe_results (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    target_type VARCHAR(20) NOT NULL CHECK (target_type IN ('dependency', 'endpoint_group')),
+    target VARCHAR(100) NOT NULL,
+    healthy BOOLEAN NOT NULL,
+    latency_ms INTEGER,
+    requests INTEGER NOT NULL DEFAULT 0,
+    server_errors INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    checked_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_health_probe_results_target ON health_probe_results(target_type, target, checked_at DESC);
+CREATE INDEX IF NOT EXISTS idx_health_probe_results_checked_at ON health_probe_results(checked_at);
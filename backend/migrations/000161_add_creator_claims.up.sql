@@ -0,0 +1,19 @@
+-- Claims by users to be the Twitch creator of clips, proven by putting a
+-- verification code in the Twitch channel's description. A verified claim
+-- attributes the creator's clips to the user like a linked Twitch account.
+CREATE TABLE IF NOT EXISTS creator_claims (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    twitch_creator_id VARCHAR(50) NOT NULL,
+    twitch_login VARCHAR(100) NOT NULL,
+    verification_code VARCHAR(32) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'verified', 'expired', 'superseded')),
+    expires_at TIMESTAMP NOT NULL,
+    verified_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- A creator's clips are attributed to one user at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_creator_claims_verified ON creator_claims(twitch_creator_id) WHERE status = 'verified';
+CREATE INDEX IF NOT EXISTS idx_creator_claims_user ON creator_claims(user_id, created_at DESC);
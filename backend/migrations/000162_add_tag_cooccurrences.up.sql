@@ -0,0 +1,13 @@
+-- Tag co-occurrence graph: pairs of tags that often tag the same clips,
+-- rebuilt by a nightly job. Each tag keeps its strongest related tags,
+-- scored by the Jaccard similarity of the clips they tag.
+CREATE TABLE IF NOT EXISTS tag_cooccurrences (
+    tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    related_tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    clip_count INTEGER NOT NULL,
+    score DOUBLE PRECISION NOT NULL,
+    computed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tag_id, related_tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tag_cooccurrences_score ON tag_cooccurrences(tag_id, score DESC);
@@ -0,0 +1,10 @@
+-- Pinned comments: top-level comments the clip's broadcaster, creator or a
+-- moderator pinned, listed first on the clip's comments.
+CREATE TABLE IF NOT EXISTS comment_pins (
+    comment_id UUID PRIMARY KEY REFERENCES comments(id) ON DELETE CASCADE,
+    clip_id UUID NOT NULL REFERENCES clips(id) ON DELETE CASCADE,
+    pinned_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_comment_pins_clip ON comment_pins(clip_id, created_at DESC);
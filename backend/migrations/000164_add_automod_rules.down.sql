@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_comments_automod_state;
+ALTER TABLE comments DROP COLUMN IF EXISTS automod_state;
+DROP TABLE IF EXISTS automod_rules;
@@ -0,0 +1,41 @@
+-- Automoderation rules: keyword, regex, link and author conditions evaluated
+-- when comments are posted and clips are submitted. Matching content is
+-- flagged, held for review, shadow-hidden or removed, and queued for
+-- moderators with the matching rules in the queue entry's metadata.
+CREATE TABLE IF NOT EXISTS automod_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(200) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    targets TEXT[] NOT NULL DEFAULT '{comment,submission}',
+    keywords TEXT[] NOT NULL DEFAULT '{}',     -- Empty skips the keyword condition
+    patterns TEXT[] NOT NULL DEFAULT '{}',     -- Empty skips the regex condition
+    link_policy VARCHAR(20) NOT NULL DEFAULT 'none' CHECK (link_policy IN ('none', 'block', 'allowlist', 'blocklist')),
+    link_domains TEXT[] NOT NULL DEFAULT '{}',
+    min_karma INT,                             -- Matches authors with less karma
+    min_account_age_days INT CHECK (min_account_age_days IS NULL OR min_account_age_days > 0),
+    action VARCHAR(20) NOT NULL CHECK (action IN ('flag', 'hold', 'shadow_hide', 'remove')),
+    community_id UUID REFERENCES communities(id) ON DELETE CASCADE, -- NULL for global rules
+    overrides_rule_id UUID REFERENCES automod_rules(id) ON DELETE CASCADE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    match_count BIGINT NOT NULL DEFAULT 0,
+    last_matched_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (overrides_rule_id IS NULL OR community_id IS NOT NULL)
+);
+
+-- A community overrides each global rule at most once
+CREATE UNIQUE INDEX IF NOT EXISTS uq_automod_rules_override ON automod_rules(community_id, overrides_rule_id)
+WHERE overrides_rule_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_automod_rules_community ON automod_rules(community_id);
+
+-- Comments held for review or shadow-hidden by automoderation are only
+-- shown to their authors until a moderator approves them
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS automod_state VARCHAR(20)
+    CHECK (automod_state IS NULL OR automod_state IN ('held', 'shadow_hidden'));
+
+CREATE INDEX IF NOT EXISTS idx_comments_automod_state ON comments(automod_state)
+WHERE automod_state IS NOT NULL;
+
+COMMENT ON TABLE automod_rules IS 'Admin automoderation rules for comments and clip submissions';
+COMMENT ON COLUMN comments.automod_state IS 'held or shadow_hidden while automoderation keeps the comment from other users';
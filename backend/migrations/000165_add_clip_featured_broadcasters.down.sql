@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_clip_featured_broadcasters_broadcaster;
+DROP TABLE IF EXISTS clip_featured_broadcasters;
@@ -0,0 +1,14 @@
+-- Featured broadcasters: streamers who appear in a clip alongside its primary
+-- broadcaster (clips.broadcaster_id). Featured clips count towards each
+-- broadcaster's profile and reach the feeds of their followers.
+CREATE TABLE IF NOT EXISTS clip_featured_broadcasters (
+    clip_id UUID NOT NULL REFERENCES clips(id) ON DELETE CASCADE,
+    broadcaster_id VARCHAR(50) NOT NULL,
+    broadcaster_name VARCHAR(100) NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    added_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (clip_id, broadcaster_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_clip_featured_broadcasters_broadcaster ON clip_featured_broadcasters(broadcaster_id);
@@ -0,0 +1,7 @@
+CREATE TRIGGER trg_auto_flag_toxic_comment
+    AFTER INSERT OR UPDATE ON toxicity_predictions
+    FOR EACH ROW
+    EXECUTE FUNCTION auto_flag_toxic_comment();
+
+ALTER TABLE toxicity_predictions DROP COLUMN IF EXISTS threshold;
+DROP TABLE IF EXISTS community_toxicity_settings;
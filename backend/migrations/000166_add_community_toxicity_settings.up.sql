@@ -0,0 +1,16 @@
+-- Per-community toxicity sensitivity. Comments on clips in a community are
+-- auto-flagged at the threshold its sensitivity sets; the strictest applies
+-- when a clip is in several communities.
+CREATE TABLE IF NOT EXISTS community_toxicity_settings (
+    community_id UUID PRIMARY KEY REFERENCES communities(id) ON DELETE CASCADE,
+    sensitivity VARCHAR(10) NOT NULL DEFAULT 'medium' CHECK (sensitivity IN ('off', 'low', 'medium', 'high')),
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- The threshold a prediction was judged against, for moderator context
+ALTER TABLE toxicity_predictions ADD COLUMN IF NOT EXISTS threshold DECIMAL(3,2);
+
+-- Flagging moves to the application, which applies community thresholds
+-- instead of the fixed 0.85 the trigger used
+DROP TRIGGER IF EXISTS trg_auto_flag_toxic_comment ON toxicity_predictions;
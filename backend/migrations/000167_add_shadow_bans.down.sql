@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS user_shadow_bans;
+DROP INDEX IF EXISTS idx_users_shadow_banned;
+ALTER TABLE users DROP COLUMN IF EXISTS is_shadow_banned;
@@ -0,0 +1,28 @@
+-- Shadow bans hide a user's comments, clips and notifications from everyone
+-- else while the user keeps seeing their own content as usual.
+-- users.is_shadow_banned is the flag checked by listings, search indexing and
+-- notifications; user_shadow_bans records why, for how long, and how each ended.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_shadow_banned BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS idx_users_shadow_banned ON users(id) WHERE is_shadow_banned = true;
+
+CREATE TABLE IF NOT EXISTS user_shadow_bans (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    shadow_banned_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    starts_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP, -- NULL for shadow bans without an expiry
+    lifted_at TIMESTAMP,
+    lifted_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    lift_type VARCHAR(20),
+    lift_reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT user_shadow_bans_valid_lift_type CHECK (lift_type IN ('expired', 'lifted', 'superseded')),
+    CONSTRAINT user_shadow_bans_valid_expiry CHECK (expires_at IS NULL OR expires_at > starts_at)
+);
+
+-- At most one active shadow ban per user
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_shadow_bans_active ON user_shadow_bans(user_id) WHERE lifted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_user_shadow_bans_expiring ON user_shadow_bans(expires_at) WHERE lifted_at IS NULL AND expires_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_user_shadow_bans_user_history ON user_shadow_bans(user_id, created_at DESC);
@@ -0,0 +1,29 @@
+CREATE OR REPLACE FUNCTION update_moderation_appeals_resolved()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.status != 'pending' AND OLD.status = 'pending' THEN
+        NEW.resolved_at = NOW();
+        -- Ensure resolved_by is set by application code
+        IF NEW.resolved_by IS NULL THEN
+            RAISE EXCEPTION 'resolved_by must be set when changing status from pending';
+        END IF;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Appeals in review go back to the queue
+UPDATE moderation_appeals SET status = 'pending' WHERE status = 'in_review';
+
+DROP INDEX IF EXISTS idx_appeals_assigned_to;
+DROP INDEX IF EXISTS uq_appeals_action_open;
+CREATE UNIQUE INDEX uq_appeals_action_pending ON moderation_appeals(moderation_action_id)
+WHERE status = 'pending';
+
+ALTER TABLE moderation_appeals DROP CONSTRAINT moderation_appeals_valid_status;
+ALTER TABLE moderation_appeals ADD CONSTRAINT moderation_appeals_valid_status
+    CHECK (status IN ('pending', 'approved', 'rejected'));
+
+ALTER TABLE moderation_appeals
+    DROP COLUMN IF EXISTS assigned_at,
+    DROP COLUMN IF EXISTS assigned_to;
@@ -0,0 +1,33 @@
+-- Appeal workflow: moderators take appeals into review before deciding them,
+-- and assignment is timed for SLA reporting
+
+ALTER TABLE moderation_appeals
+    ADD COLUMN assigned_to UUID REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN assigned_at TIMESTAMP;
+
+ALTER TABLE moderation_appeals DROP CONSTRAINT moderation_appeals_valid_status;
+ALTER TABLE moderation_appeals ADD CONSTRAINT moderation_appeals_valid_status
+    CHECK (status IN ('pending', 'in_review', 'approved', 'rejected'));
+
+-- One open appeal per moderation action, whether or not it is in review
+DROP INDEX IF EXISTS uq_appeals_action_pending;
+CREATE UNIQUE INDEX uq_appeals_action_open ON moderation_appeals(moderation_action_id)
+WHERE status IN ('pending', 'in_review');
+
+CREATE INDEX idx_appeals_assigned_to ON moderation_appeals(assigned_to, status)
+WHERE assigned_to IS NOT NULL;
+
+-- Appeals are resolved from review as well as straight from pending
+CREATE OR REPLACE FUNCTION update_moderation_appeals_resolved()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.status IN ('approved', 'rejected') AND OLD.status IN ('pending', 'in_review') THEN
+        NEW.resolved_at = NOW();
+        -- Ensure resolved_by is set by application code
+        IF NEW.resolved_by IS NULL THEN
+            RAISE EXCEPTION 'resolved_by must be set when resolving an appeal';
+        END IF;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
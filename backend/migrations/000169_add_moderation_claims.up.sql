@@ -0,0 +1,29 @@
+-- Moderation claims: a moderator claims a moderation event or pending
+-- submission before reviewing it, so two moderators don't review the same
+-- item. Claims expire when they aren't resolved in time, and resolved claims
+-- are kept for time-to-resolution and workload reporting.
+
+CREATE TABLE moderation_claims (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    item_type VARCHAR(20) NOT NULL, -- 'event', 'submission'
+    item_id UUID NOT NULL,
+    claimed_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    claimed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL,
+    released_at TIMESTAMP,
+    release_reason VARCHAR(20), -- 'resolved', 'released', 'expired'
+    CONSTRAINT moderation_claims_valid_item_type CHECK (item_type IN ('event', 'submission')),
+    CONSTRAINT moderation_claims_valid_release_reason CHECK (
+        (released_at IS NULL AND release_reason IS NULL) OR
+        (released_at IS NOT NULL AND release_reason IN ('resolved', 'released', 'expired'))
+    )
+);
+
+-- One active claim per item
+CREATE UNIQUE INDEX uq_moderation_claims_active ON moderation_claims(item_type, item_id)
+WHERE released_at IS NULL;
+
+CREATE INDEX idx_moderation_claims_expires ON moderation_claims(expires_at)
+WHERE released_at IS NULL;
+
+CREATE INDEX idx_moderation_claims_moderator ON moderation_claims(claimed_by, released_at DESC);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS dmca_case_events;
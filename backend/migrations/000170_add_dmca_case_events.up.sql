@@ -0,0 +1,23 @@
+-- DMCA case history: every step a takedown notice, its counter-notices and
+-- the strikes it caused go through, including the steps the DMCA job takes
+-- on its own (reinstatement after the waiting period, strike expiry). The
+-- moderation audit log needs a moderator, so system steps are only recorded
+-- here.
+
+CREATE TABLE dmca_case_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    dmca_notice_id UUID NOT NULL REFERENCES dmca_notices(id) ON DELETE CASCADE,
+    counter_notice_id UUID REFERENCES dmca_counter_notices(id) ON DELETE CASCADE,
+    strike_id UUID REFERENCES dmca_strikes(id) ON DELETE CASCADE,
+    event VARCHAR(50) NOT NULL,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL, -- NULL for the DMCA job and anonymous submitters
+    from_status VARCHAR(50),
+    to_status VARCHAR(50),
+    notes TEXT,
+    metadata JSONB,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_dmca_case_events_notice ON dmca_case_events(dmca_notice_id, created_at);
+CREATE INDEX idx_dmca_case_events_counter_notice ON dmca_case_events(counter_notice_id)
+WHERE counter_notice_id IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS ban_evasion_flags;
+DROP TABLE IF EXISTS account_signals;
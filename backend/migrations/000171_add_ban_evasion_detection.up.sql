@@ -0,0 +1,42 @@
+-- Ban evasion detection: signals collected at sign-in (hashed IP address,
+-- hashed device fingerprint, email domain) are linked to accounts, and a
+-- detection job flags new accounts that share signals with banned users into
+-- the moderation queue for review.
+
+CREATE TABLE account_signals (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    signal_type VARCHAR(20) NOT NULL, -- 'ip', 'device', 'email_domain'
+    signal_value VARCHAR(255) NOT NULL, -- Keyed hash for IPs and devices, the domain itself for emails
+    first_seen_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    seen_count INT NOT NULL DEFAULT 1,
+    CONSTRAINT account_signals_valid_type CHECK (signal_type IN ('ip', 'device', 'email_domain')),
+    CONSTRAINT account_signals_unique UNIQUE (user_id, signal_type, signal_value)
+);
+
+CREATE INDEX idx_account_signals_value ON account_signals(signal_type, signal_value);
+CREATE INDEX idx_account_signals_last_seen ON account_signals(last_seen_at);
+
+-- One flag per suspect and banned account pair. Dismissed flags are kept so
+-- the same pair isn't raised again.
+CREATE TABLE ban_evasion_flags (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    banned_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    confidence DECIMAL(3,2) NOT NULL,
+    matched_signals TEXT[] NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- 'pending', 'confirmed', 'dismissed'
+    queue_item_id UUID REFERENCES moderation_queue(id) ON DELETE SET NULL,
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP,
+    review_notes TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT ban_evasion_flags_valid_status CHECK (status IN ('pending', 'confirmed', 'dismissed')),
+    CONSTRAINT ban_evasion_flags_valid_confidence CHECK (confidence >= 0 AND confidence <= 1),
+    CONSTRAINT ban_evasion_flags_distinct_users CHECK (user_id <> banned_user_id),
+    CONSTRAINT ban_evasion_flags_unique_pair UNIQUE (user_id, banned_user_id)
+);
+
+CREATE INDEX idx_ban_evasion_flags_status ON ban_evasion_flags(status, confidence DESC, created_at);
+CREATE INDEX idx_ban_evasion_flags_banned_user ON ban_evasion_flags(banned_user_id);
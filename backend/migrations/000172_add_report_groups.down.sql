@@ -0,0 +1,2 @@
+ALTER TABLE reports DROP COLUMN IF EXISTS group_id;
+DROP TABLE IF EXISTS report_groups;
@@ -0,0 +1,51 @@
+-- Report groups: reports against the same clip, comment or user are grouped
+-- while the group is open, so moderators review each reported item once. Each
+-- group carries a priority score from its report count, the reporters' trust
+-- scores and the reported content's reach.
+
+CREATE TABLE report_groups (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    reportable_type VARCHAR(20) NOT NULL, -- 'clip', 'comment', 'user'
+    reportable_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open', -- 'open', 'resolved'
+    report_count INT NOT NULL DEFAULT 0,
+    avg_reporter_trust DECIMAL(5,2) NOT NULL DEFAULT 0,
+    reach BIGINT NOT NULL DEFAULT 0, -- Clip views, the comment's clip views, or the user's followers
+    priority_score DECIMAL(5,2) NOT NULL DEFAULT 0, -- 0-100
+    scored_at TIMESTAMP,
+    first_reported_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_reported_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    resolution VARCHAR(20), -- 'actioned', 'dismissed'
+    resolved_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMP,
+    CONSTRAINT report_groups_valid_type CHECK (reportable_type IN ('clip', 'comment', 'user')),
+    CONSTRAINT report_groups_valid_status CHECK (status IN ('open', 'resolved')),
+    CONSTRAINT report_groups_valid_resolution CHECK (
+        (status = 'open' AND resolution IS NULL) OR
+        (status = 'resolved' AND resolution IN ('actioned', 'dismissed'))
+    )
+);
+
+-- One open group per reported item
+CREATE UNIQUE INDEX uq_report_groups_open ON report_groups(reportable_type, reportable_id)
+WHERE status = 'open';
+
+CREATE INDEX idx_report_groups_queue ON report_groups(status, priority_score DESC, last_reported_at DESC);
+
+ALTER TABLE reports ADD COLUMN group_id UUID REFERENCES report_groups(id) ON DELETE SET NULL;
+CREATE INDEX idx_reports_group ON reports(group_id);
+
+-- Group the reports that are still open. They are scored on the next
+-- priority refresh.
+INSERT INTO report_groups (reportable_type, reportable_id, report_count, first_reported_at, last_reported_at)
+SELECT reportable_type, reportable_id, COUNT(*), MIN(created_at), MAX(created_at)
+FROM reports
+WHERE status IN ('pending', 'reviewed')
+GROUP BY reportable_type, reportable_id;
+
+UPDATE reports r
+SET group_id = g.id
+FROM report_groups g
+WHERE r.status IN ('pending', 'reviewed')
+  AND g.reportable_type = r.reportable_type
+  AND g.reportable_id = r.reportable_id;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS karma_events;
+DROP TABLE IF EXISTS karma_rules;
@@ -0,0 +1,38 @@
+-- Karma rules: how much karma each event awards, with optional daily caps and
+-- cooldowns. Editable by admins without a deploy.
+CREATE TABLE karma_rules (
+    event_type VARCHAR(50) PRIMARY KEY, -- 'comment_created', 'submission_approved', ...
+    points INT NOT NULL,
+    daily_cap INT, -- Most karma a user earns from the event per UTC day; NULL for no cap
+    cooldown_seconds INT NOT NULL DEFAULT 0, -- Time between awards to the same user
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    description TEXT,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT karma_rules_valid_event_type CHECK (event_type ~ '^[a-z][a-z0-9_]*$'),
+    CONSTRAINT karma_rules_valid_daily_cap CHECK (daily_cap IS NULL OR daily_cap > 0),
+    CONSTRAINT karma_rules_valid_cooldown CHECK (cooldown_seconds >= 0)
+);
+
+-- Karma events: one row per event handled, so a replayed event awards nothing.
+-- Events that earned no karma because of a cap or cooldown are kept too.
+CREATE TABLE karma_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_type VARCHAR(50) NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source_id UUID NOT NULL, -- The comment, submission or clip the event is about
+    points INT NOT NULL, -- Karma awarded, after caps and cooldowns
+    revoked_at TIMESTAMP, -- Set when the award was taken back, e.g. the comment was deleted
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_karma_events UNIQUE (event_type, user_id, source_id)
+);
+
+CREATE INDEX idx_karma_events_user_type ON karma_events(user_id, event_type, created_at DESC);
+
+-- The awards that were hard-coded
+INSERT INTO karma_rules (event_type, points, description) VALUES
+    ('comment_created', 1, 'Posting a comment. Taken back when the author deletes it.'),
+    ('submission_approved', 10, 'A clip submission being approved'),
+    ('submission_rejected', -5, 'A clip submission being rejected'),
+    ('clip_claimed', 10, 'Claiming an existing clip by submitting it');
@@ -0,0 +1,5 @@
+DELETE FROM user_badges WHERE badge_id = 'well_received';
+DROP TABLE IF EXISTS user_achievements;
+DROP TABLE IF EXISTS user_achievement_metrics;
+DROP TABLE IF EXISTS achievement_events;
+DROP TABLE IF EXISTS achievements;
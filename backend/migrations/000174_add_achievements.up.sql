@@ -0,0 +1,84 @@
+-- Achievements: goals measured by a per-user metric, such as comments posted.
+-- Completing one can award a badge.
+CREATE TABLE achievements (
+    id VARCHAR(50) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL,
+    icon VARCHAR(20) NOT NULL,
+    metric VARCHAR(50) NOT NULL, -- 'comments_posted', 'upvoted_comments', 'submissions_approved'
+    target INT NOT NULL CHECK (target > 0),
+    badge_id VARCHAR(50), -- Badge awarded on completion, from the badge definitions
+    sort_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_achievements_metric ON achievements(metric, target);
+
+-- Achievement events: each event counts toward a metric once, so replays and
+-- repeated votes don't inflate progress
+CREATE TABLE achievement_events (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    metric VARCHAR(50) NOT NULL,
+    source_id UUID NOT NULL, -- The comment or submission the event is about
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, metric, source_id)
+);
+
+-- Per-user metric totals, incremented as events are recorded
+CREATE TABLE user_achievement_metrics (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    metric VARCHAR(50) NOT NULL,
+    value INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, metric)
+);
+
+-- Completed achievements
+CREATE TABLE user_achievements (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    achievement_id VARCHAR(50) NOT NULL REFERENCES achievements(id) ON DELETE CASCADE,
+    completed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, achievement_id)
+);
+
+INSERT INTO achievements (id, name, description, icon, metric, target, badge_id, sort_order) VALUES
+    ('first_comment', 'First Words', 'Post your first comment', '💬', 'comments_posted', 1, NULL, 10),
+    ('conversationalist', 'Conversationalist', 'Post 100 comments', '🗣️', 'comments_posted', 100, 'conversationalist', 20),
+    ('good_point', 'Good Point', 'Get 10 of your comments upvoted', '👍', 'upvoted_comments', 10, NULL, 30),
+    ('well_received', 'Well Received', 'Get 100 of your comments upvoted', '👏', 'upvoted_comments', 100, 'well_received', 40),
+    ('first_approved_submission', 'First Clip', 'Get your first clip submission approved', '🎬', 'submissions_approved', 1, NULL, 50),
+    ('clip_scout', 'Clip Scout', 'Get 25 clip submissions approved', '🔭', 'submissions_approved', 25, NULL, 60);
+
+-- Backfill progress from existing activity. Completions are recorded without
+-- notifications.
+INSERT INTO achievement_events (user_id, metric, source_id, created_at)
+SELECT user_id, 'comments_posted', id, COALESCE(created_at, NOW())
+FROM comments;
+
+INSERT INTO achievement_events (user_id, metric, source_id, created_at)
+SELECT cm.user_id, 'upvoted_comments', cm.id, COALESCE(MIN(cv.created_at), NOW())
+FROM comments cm
+JOIN comment_votes cv ON cv.comment_id = cm.id AND cv.vote_type = 1 AND cv.user_id <> cm.user_id
+GROUP BY cm.user_id, cm.id;
+
+INSERT INTO achievement_events (user_id, metric, source_id, created_at)
+SELECT user_id, 'submissions_approved', id, COALESCE(reviewed_at, created_at, NOW())
+FROM clip_submissions
+WHERE status = 'approved';
+
+INSERT INTO user_achievement_metrics (user_id, metric, value)
+SELECT user_id, metric, COUNT(*)
+FROM achievement_events
+GROUP BY user_id, metric;
+
+INSERT INTO user_achievements (user_id, achievement_id)
+SELECT m.user_id, a.id
+FROM user_achievement_metrics m
+JOIN achievements a ON a.metric = m.metric AND a.target <= m.value;
+
+INSERT INTO user_badges (user_id, badge_id)
+SELECT ua.user_id, a.badge_id
+FROM user_achievements ua
+JOIN achievements a ON a.id = ua.achievement_id
+WHERE a.badge_id IS NOT NULL
+ON CONFLICT (user_id, badge_id) DO NOTHING;
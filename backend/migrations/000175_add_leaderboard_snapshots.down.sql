@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_karma_history_created;
+DROP TABLE IF EXISTS leaderboard_snapshot_entries;
+DROP TABLE IF EXISTS leaderboard_snapshots;
@@ -0,0 +1,35 @@
+-- Leaderboard snapshots: weekly, monthly and seasonal leaderboards, global and
+-- per game or community, computed periodically by the leaderboard scheduler
+CREATE TABLE leaderboard_snapshots (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    leaderboard_type VARCHAR(20) NOT NULL, -- 'karma', 'engagement'
+    period VARCHAR(20) NOT NULL, -- 'weekly', 'monthly', 'season'
+    period_key VARCHAR(20) NOT NULL, -- '2026-W42', '2026-10', '2026-Q4'
+    period_start TIMESTAMP NOT NULL,
+    period_end TIMESTAMP NOT NULL,
+    scope VARCHAR(20) NOT NULL, -- 'global', 'game', 'community'
+    scope_id VARCHAR(100) NOT NULL DEFAULT '', -- Game or community ID; empty for global
+    entry_count INT NOT NULL DEFAULT 0,
+    finalized BOOLEAN NOT NULL DEFAULT false, -- Computed after the period ended; no longer changes
+    badges_awarded_at TIMESTAMP, -- When season-end badges were awarded from the snapshot
+    computed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_leaderboard_snapshots UNIQUE (leaderboard_type, period, period_key, scope, scope_id),
+    CONSTRAINT leaderboard_snapshots_valid_type CHECK (leaderboard_type IN ('karma', 'engagement')),
+    CONSTRAINT leaderboard_snapshots_valid_period CHECK (period IN ('weekly', 'monthly', 'season')),
+    CONSTRAINT leaderboard_snapshots_valid_scope CHECK (scope IN ('global', 'game', 'community'))
+);
+
+CREATE INDEX idx_leaderboard_snapshots_badges ON leaderboard_snapshots(period, finalized)
+    WHERE badges_awarded_at IS NULL;
+
+-- Top users of each snapshot
+CREATE TABLE leaderboard_snapshot_entries (
+    snapshot_id UUID NOT NULL REFERENCES leaderboard_snapshots(id) ON DELETE CASCADE,
+    rank INT NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    score INT NOT NULL,
+    PRIMARY KEY (snapshot_id, rank)
+);
+
+CREATE INDEX idx_leaderboard_snapshot_entries_user ON leaderboard_snapshot_entries(user_id);
+CREATE INDEX idx_karma_history_created ON karma_history(created_at);
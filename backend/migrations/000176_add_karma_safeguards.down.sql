@@ -0,0 +1,90 @@
+DROP INDEX IF EXISTS idx_karma_history_decay;
+DROP TABLE IF EXISTS vote_ring_members;
+DROP TABLE IF EXISTS vote_rings;
+
+-- Restore the vote karma triggers without diminishing returns
+CREATE OR REPLACE FUNCTION award_karma_on_clip_vote()
+RETURNS TRIGGER AS $$
+DECLARE
+    v_clip_owner_id UUID;
+BEGIN
+    -- Get the clip owner
+    SELECT user_id INTO v_clip_owner_id
+    FROM clip_submissions
+    WHERE twitch_clip_id = (SELECT twitch_clip_id FROM clips WHERE id = NEW.clip_id)
+    LIMIT 1;
+
+    -- If clip has an owner (from submissions), award karma
+    IF v_clip_owner_id IS NOT NULL THEN
+        IF TG_OP = 'INSERT' THEN
+            -- Award karma based on vote type
+            IF NEW.vote_type = 1 THEN
+                PERFORM update_user_karma(v_clip_owner_id, 1, 'clip_vote', NEW.clip_id);
+            ELSIF NEW.vote_type = -1 THEN
+                PERFORM update_user_karma(v_clip_owner_id, -1, 'clip_vote', NEW.clip_id);
+            END IF;
+        ELSIF TG_OP = 'UPDATE' THEN
+            -- Adjust karma if vote changed
+            IF OLD.vote_type != NEW.vote_type THEN
+                IF NEW.vote_type = 1 THEN
+                    PERFORM update_user_karma(v_clip_owner_id, 2, 'clip_vote', NEW.clip_id);
+                ELSIF NEW.vote_type = -1 THEN
+                    PERFORM update_user_karma(v_clip_owner_id, -2, 'clip_vote', NEW.clip_id);
+                END IF;
+            END IF;
+        ELSIF TG_OP = 'DELETE' THEN
+            -- Remove karma when vote is deleted
+            IF OLD.vote_type = 1 THEN
+                PERFORM update_user_karma(v_clip_owner_id, -1, 'clip_vote', OLD.clip_id);
+            ELSIF OLD.vote_type = -1 THEN
+                PERFORM update_user_karma(v_clip_owner_id, 1, 'clip_vote', OLD.clip_id);
+            END IF;
+        END IF;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION award_karma_on_comment_vote()
+RETURNS TRIGGER AS $$
+DECLARE
+    v_comment_owner_id UUID;
+BEGIN
+    -- Get the comment owner
+    SELECT user_id INTO v_comment_owner_id
+    FROM comments
+    WHERE id = COALESCE(NEW.comment_id, OLD.comment_id);
+
+    IF TG_OP = 'INSERT' THEN
+        -- Award karma based on vote type
+        IF NEW.vote_type = 1 THEN
+            PERFORM update_user_karma(v_comment_owner_id, 1, 'comment_vote', NEW.comment_id);
+        ELSIF NEW.vote_type = -1 THEN
+            PERFORM update_user_karma(v_comment_owner_id, -1, 'comment_vote', NEW.comment_id);
+        END IF;
+    ELSIF TG_OP = 'UPDATE' THEN
+        -- Adjust karma if vote changed
+        IF OLD.vote_type != NEW.vote_type THEN
+            IF NEW.vote_type = 1 THEN
+                PERFORM update_user_karma(v_comment_owner_id, 2, 'comment_vote', NEW.comment_id);
+            ELSIF NEW.vote_type = -1 THEN
+                PERFORM update_user_karma(v_comment_owner_id, -2, 'comment_vote', NEW.comment_id);
+            END IF;
+        END IF;
+    ELSIF TG_OP = 'DELETE' THEN
+        -- Remove karma when vote is deleted
+        IF OLD.vote_type = 1 THEN
+            PERFORM update_user_karma(v_comment_owner_id, -1, 'comment_vote', OLD.comment_id);
+        ELSIF OLD.vote_type = -1 THEN
+            PERFORM update_user_karma(v_comment_owner_id, 1, 'comment_vote', OLD.comment_id);
+        END IF;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP FUNCTION IF EXISTS apply_vote_karma(TEXT, UUID, UUID, VARCHAR, UUID, INT, INT);
+DROP FUNCTION IF EXISTS vote_karma_amount(UUID, UUID, INT);
+DROP TABLE IF EXISTS karma_vote_awards;
@@ -0,0 +1,175 @@
+-- Karma safeguards: votes record the karma they award in a ledger so repeated
+-- votes between the same users earn diminishing returns and can be reversed
+-- exactly, clusters of accounts upvoting each other are flagged as vote rings
+-- into the moderation queue, and inactive accounts' karma decays.
+
+-- Karma each clip and comment vote awarded the content's owner. Votes cast
+-- before this migration have no row and are reversed by their vote type.
+CREATE TABLE karma_vote_awards (
+    voter_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    recipient_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source VARCHAR(50) NOT NULL, -- 'clip_vote', 'comment_vote'
+    source_id UUID NOT NULL, -- The voted clip or comment
+    vote_type SMALLINT NOT NULL,
+    amount INT NOT NULL, -- Karma awarded after diminishing returns; 0 once reversed
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (voter_id, source, source_id)
+);
+
+CREATE INDEX idx_karma_vote_awards_pair ON karma_vote_awards(voter_id, recipient_id, created_at DESC);
+CREATE INDEX idx_karma_vote_awards_created ON karma_vote_awards(created_at);
+
+-- Returns the karma a new vote awards. A voter's first 3 votes on the same
+-- user's content within 30 days count in full; after that only the 4th, 8th,
+-- 16th and so on do, so karma from one voter grows logarithmically. Votes on
+-- your own content award nothing.
+CREATE OR REPLACE FUNCTION vote_karma_amount(p_voter_id UUID, p_recipient_id UUID, p_vote_type INT)
+RETURNS INT AS $$
+DECLARE
+    v_ordinal INT;
+BEGIN
+    IF p_recipient_id IS NULL OR p_recipient_id = p_voter_id THEN
+        RETURN 0;
+    END IF;
+
+    SELECT COUNT(*) + 1 INTO v_ordinal
+    FROM karma_vote_awards
+    WHERE voter_id = p_voter_id AND recipient_id = p_recipient_id
+      AND created_at > NOW() - INTERVAL '30 days';
+
+    IF v_ordinal <= 3 OR (v_ordinal & (v_ordinal - 1)) = 0 THEN
+        RETURN p_vote_type;
+    END IF;
+    RETURN 0;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Applies the karma change of a vote being cast, changed or removed. Changes
+-- and removals reverse what the ledger says the vote awarded.
+CREATE OR REPLACE FUNCTION apply_vote_karma(
+    p_op TEXT,
+    p_voter_id UUID,
+    p_recipient_id UUID,
+    p_source VARCHAR(50),
+    p_source_id UUID,
+    p_old_vote_type INT,
+    p_new_vote_type INT
+)
+RETURNS VOID AS $$
+DECLARE
+    v_award karma_vote_awards%ROWTYPE;
+    v_amount INT;
+BEGIN
+    SELECT * INTO v_award
+    FROM karma_vote_awards
+    WHERE voter_id = p_voter_id AND source = p_source AND source_id = p_source_id;
+
+    IF p_op = 'INSERT' THEN
+        IF p_recipient_id IS NULL THEN
+            RETURN;
+        END IF;
+        v_amount := vote_karma_amount(p_voter_id, p_recipient_id, p_new_vote_type);
+        INSERT INTO karma_vote_awards (voter_id, recipient_id, source, source_id, vote_type, amount)
+        VALUES (p_voter_id, p_recipient_id, p_source, p_source_id, p_new_vote_type, v_amount)
+        ON CONFLICT (voter_id, source, source_id) DO UPDATE SET
+            recipient_id = EXCLUDED.recipient_id,
+            vote_type = EXCLUDED.vote_type,
+            amount = EXCLUDED.amount,
+            created_at = NOW();
+        PERFORM update_user_karma(p_recipient_id, v_amount, p_source, p_source_id);
+    ELSIF p_op = 'UPDATE' THEN
+        IF p_old_vote_type = p_new_vote_type THEN
+            RETURN;
+        END IF;
+        IF v_award.voter_id IS NULL THEN
+            -- Vote cast before the ledger
+            PERFORM update_user_karma(p_recipient_id, p_new_vote_type - p_old_vote_type, p_source, p_source_id);
+            RETURN;
+        END IF;
+        -- A changed vote keeps the weight it was cast with
+        v_amount := v_award.amount * p_old_vote_type * p_new_vote_type;
+        UPDATE karma_vote_awards
+        SET vote_type = p_new_vote_type, amount = v_amount
+        WHERE voter_id = p_voter_id AND source = p_source AND source_id = p_source_id;
+        PERFORM update_user_karma(v_award.recipient_id, v_amount - v_award.amount, p_source, p_source_id);
+    ELSIF p_op = 'DELETE' THEN
+        IF v_award.voter_id IS NULL THEN
+            PERFORM update_user_karma(p_recipient_id, -p_old_vote_type, p_source, p_source_id);
+            RETURN;
+        END IF;
+        DELETE FROM karma_vote_awards
+        WHERE voter_id = p_voter_id AND source = p_source AND source_id = p_source_id;
+        PERFORM update_user_karma(v_award.recipient_id, -v_award.amount, p_source, p_source_id);
+    END IF;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION award_karma_on_clip_vote()
+RETURNS TRIGGER AS $$
+DECLARE
+    v_clip_owner_id UUID;
+BEGIN
+    -- The clip's owner is whoever submitted it
+    SELECT user_id INTO v_clip_owner_id
+    FROM clip_submissions
+    WHERE twitch_clip_id = (SELECT twitch_clip_id FROM clips WHERE id = COALESCE(NEW.clip_id, OLD.clip_id))
+    LIMIT 1;
+
+    PERFORM apply_vote_karma(
+        TG_OP, COALESCE(NEW.user_id, OLD.user_id), v_clip_owner_id, 'clip_vote',
+        COALESCE(NEW.clip_id, OLD.clip_id), OLD.vote_type, NEW.vote_type
+    );
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION award_karma_on_comment_vote()
+RETURNS TRIGGER AS $$
+DECLARE
+    v_comment_owner_id UUID;
+BEGIN
+    SELECT user_id INTO v_comment_owner_id
+    FROM comments
+    WHERE id = COALESCE(NEW.comment_id, OLD.comment_id);
+
+    PERFORM apply_vote_karma(
+        TG_OP, COALESCE(NEW.user_id, OLD.user_id), v_comment_owner_id, 'comment_vote',
+        COALESCE(NEW.comment_id, OLD.comment_id), OLD.vote_type, NEW.vote_type
+    );
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Clusters of accounts that upvote each other's content. One ring per member
+-- set; dismissed rings are kept so the same set isn't raised again.
+CREATE TABLE vote_rings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    member_key TEXT NOT NULL UNIQUE, -- Sorted member IDs
+    member_count INT NOT NULL,
+    reciprocal_votes INT NOT NULL, -- Upvotes between members within the detection window
+    window_start TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- 'pending', 'confirmed', 'dismissed'
+    karma_reversed INT NOT NULL DEFAULT 0,
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP,
+    review_notes TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT vote_rings_valid_status CHECK (status IN ('pending', 'confirmed', 'dismissed'))
+);
+
+CREATE INDEX idx_vote_rings_status ON vote_rings(status, reciprocal_votes DESC, created_at);
+
+CREATE TABLE vote_ring_members (
+    ring_id UUID NOT NULL REFERENCES vote_rings(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    votes_given INT NOT NULL, -- Upvotes on other members' content
+    votes_received INT NOT NULL, -- Upvotes from other members
+    queue_item_id UUID REFERENCES moderation_queue(id) ON DELETE SET NULL,
+    PRIMARY KEY (ring_id, user_id)
+);
+
+CREATE INDEX idx_vote_ring_members_user ON vote_ring_members(user_id);
+CREATE INDEX idx_vote_ring_members_queue_item ON vote_ring_members(queue_item_id);
+
+-- Finds each user's last inactivity decay
+CREATE INDEX idx_karma_history_decay ON karma_history(user_id, created_at DESC) WHERE source = 'inactivity_decay';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_user_badges_user_awarded;
+DROP INDEX IF EXISTS idx_feeds_user_created;
+DROP INDEX IF EXISTS idx_clips_submitted_by_submitted_at;
+
+ALTER TABLE user_settings DROP COLUMN IF EXISTS share_activity;
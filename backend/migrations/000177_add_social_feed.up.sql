@@ -0,0 +1,16 @@
+-- Lets users keep their activity out of their followers' social feeds.
+-- Activity is shared by default; private profiles never share it.
+ALTER TABLE user_settings ADD COLUMN IF NOT EXISTS share_activity BOOLEAN NOT NULL DEFAULT TRUE;
+
+COMMENT ON COLUMN user_settings.share_activity IS 'Whether submitted clips, created feeds and earned badges appear in followers'' social feeds';
+
+-- The social feed reads each followed user's recent activity
+CREATE INDEX IF NOT EXISTS idx_clips_submitted_by_submitted_at
+    ON clips(submitted_by_user_id, submitted_at DESC)
+    WHERE submitted_by_user_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_feeds_user_created
+    ON feeds(user_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_user_badges_user_awarded
+    ON user_badges(user_id, awarded_at DESC);
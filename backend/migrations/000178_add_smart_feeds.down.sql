@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS feed_smart_rules;
+
+ALTER TABLE feeds DROP COLUMN IF EXISTS is_smart;
@@ -0,0 +1,23 @@
+-- Smart feeds: feeds whose clips are picked by rules on game, broadcaster,
+-- tags, votes and language instead of curated by hand. A materialization job
+-- keeps each smart feed's clips in step with its rules.
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS is_smart BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS feed_smart_rules (
+    feed_id UUID PRIMARY KEY REFERENCES feeds(id) ON DELETE CASCADE,
+    game_ids TEXT[] NOT NULL DEFAULT '{}',        -- Empty matches any game
+    broadcaster_ids TEXT[] NOT NULL DEFAULT '{}', -- Empty matches any broadcaster
+    tags TEXT[] NOT NULL DEFAULT '{}',            -- Tag slugs; empty matches any clip
+    languages TEXT[] NOT NULL DEFAULT '{}',       -- Empty matches any language
+    min_votes INT,
+    max_clips INT NOT NULL DEFAULT 100 CHECK (max_clips BETWEEN 1 AND 500),
+    rules_changed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_materialized_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_feed_smart_rules_materialized ON feed_smart_rules(last_materialized_at NULLS FIRST);
+
+COMMENT ON TABLE feed_smart_rules IS 'Rules that auto-populate smart feeds with matching clips';
+COMMENT ON COLUMN feeds.is_smart IS 'Whether the feed''s clips are managed by its smart feed rules';
@@ -0,0 +1,3 @@
+ALTER TABLE feed_items DROP COLUMN IF EXISTS added_by_user_id;
+
+DROP TABLE IF EXISTS feed_collaborators;
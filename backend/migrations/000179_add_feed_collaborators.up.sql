@@ -0,0 +1,24 @@
+-- Collaborative feeds: feed owners invite co-curators, each with the roles
+-- they may use. Invites stay pending until the invitee accepts them.
+CREATE TABLE IF NOT EXISTS feed_collaborators (
+    feed_id UUID NOT NULL REFERENCES feeds(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    invited_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    roles TEXT[] NOT NULL CHECK (
+        cardinality(roles) > 0
+        AND roles <@ ARRAY['add_clips', 'remove_clips', 'reorder_clips', 'edit_metadata']::TEXT[]
+    ),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted')),
+    invited_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    accepted_at TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (feed_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_feed_collaborators_user ON feed_collaborators(user_id, status);
+
+-- Who added each clip; NULL for clips added before attribution and by smart feed rules
+ALTER TABLE feed_items ADD COLUMN IF NOT EXISTS added_by_user_id UUID REFERENCES users(id) ON DELETE SET NULL;
+
+COMMENT ON TABLE feed_collaborators IS 'Co-curators invited to edit a feed, with the roles they may use';
+COMMENT ON COLUMN feed_items.added_by_user_id IS 'User who added the clip to the feed';
@@ -0,0 +1,2 @@
+ALTER TABLE refresh_tokens
+    DROP COLUMN IF EXISTS mfa_verified_at;
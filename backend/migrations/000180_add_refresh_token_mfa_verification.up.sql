@@ -0,0 +1,5 @@
+-- Record when a session passed its second factor. Every token in the session
+-- carries the timestamp forward through rotation; staff routes require it when
+-- the account has MFA enabled.
+ALTER TABLE refresh_tokens
+    ADD COLUMN IF NOT EXISTS mfa_verified_at TIMESTAMP;
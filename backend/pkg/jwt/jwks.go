@@ -0,0 +1,81 @@
+package jwt
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"sort"
+)
+
+// JWK is an RSA public key in JSON Web Key format (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// JWKSet is the document served at /.well-known/jwks.json
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// KeyID derives a stable key ID from an RSA public key using its RFC 7638 thumbprint
+func KeyID(publicKey *rsa.PublicKey) string {
+	// Members must be in lexicographic order with no whitespace
+	thumbprintInput, _ := json.Marshal(struct {
+		E   string `json:"e"`
+		Kty string `json:"kty"`
+		N   string `json:"n"`
+	}{
+		E:   encodeExponent(publicKey.E),
+		Kty: "RSA",
+		N:   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+	})
+	sum := sha256.Sum256(thumbprintInput)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// PublicJWK converts an RSA public key to a signing JWK
+func PublicJWK(publicKey *rsa.PublicKey) JWK {
+	return JWK{
+		Kty: "RSA",
+		Use: "sig",
+		Alg: "RS256",
+		Kid: KeyID(publicKey),
+		N:   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+		E:   encodeExponent(publicKey.E),
+	}
+}
+
+// JWKS returns the public half of every key that verifies tokens
+func (m *Manager) JWKS() JWKSet {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	set := JWKSet{Keys: make([]JWK, 0, len(m.privateKeys))}
+	// Active key first so naive clients that take keys[0] keep working
+	if m.publicKey != nil {
+		set.Keys = append(set.Keys, PublicJWK(m.publicKey))
+	}
+	kids := make([]string, 0, len(m.privateKeys))
+	for kid := range m.privateKeys {
+		if kid != m.activeKID {
+			kids = append(kids, kid)
+		}
+	}
+	sort.Strings(kids)
+	for _, kid := range kids {
+		set.Keys = append(set.Keys, PublicJWK(&m.privateKeys[kid].PublicKey))
+	}
+	return set
+}
+
+// encodeExponent base64url encodes an RSA public exponent as a big-endian integer
+func encodeExponent(e int) string {
+	return base64.RawURLEncoding.EncodeToString(big.NewInt(int64(e)).Bytes())
+}
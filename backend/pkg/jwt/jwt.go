@@ -9,6 +9,8 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -22,6 +24,15 @@ var (
 	ErrTokenExpired = errors.New("token has expired")
 	// ErrInvalidSigningMethod is returned when token uses wrong signing method
 	ErrInvalidSigningMethod = errors.New("invalid signing method")
+	// ErrUnknownKey is returned when a key ID is not registered with the manager
+	ErrUnknownKey = errors.New("unknown signing key")
+)
+
+const (
+	// AccessTokenTTL is how long access tokens are valid
+	AccessTokenTTL = 15 * time.Minute
+	// RefreshTokenTTL is how long refresh tokens are valid
+	RefreshTokenTTL = 7 * 24 * time.Hour
 )
 
 // Claims represents the JWT claims
@@ -29,63 +40,210 @@ type Claims struct {
 	UserID uuid.UUID `json:"sub"`
 	Role   string    `json:"role"`
 	JTI    string    `json:"jti"` // JWT ID for revocation
+	// ImpersonatorID is set when a support agent is acting as this user
+	ImpersonatorID *uuid.UUID `json:"imp,omitempty"`
+	// MFAVerified is set when the session has passed a second factor
+	MFAVerified bool `json:"mfa,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// Manager handles JWT token generation and validation
+// Manager handles JWT token generation and validation.
+// It holds every key that may still verify tokens; the active key signs new ones
+// and its ID is sent in the "kid" header.
 type Manager struct {
-	privateKey *rsa.PrivateKey
-	publicKey  *rsa.PublicKey
+	mu          sync.RWMutex
+	privateKey  *rsa.PrivateKey
+	publicKey   *rsa.PublicKey
+	activeKID   string
+	privateKeys map[string]*rsa.PrivateKey
 }
 
 // NewManager creates a new JWT manager with RSA keys
 func NewManager(privateKeyPEM string) (*Manager, error) {
+	privateKey, err := ParsePrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manager{privateKeys: make(map[string]*rsa.PrivateKey)}
+	if err := m.SetActiveKey(m.AddKey(privateKey)); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ParsePrivateKey parses a PKCS8 or PKCS1 PEM encoded RSA private key
+func ParsePrivateKey(privateKeyPEM string) (*rsa.PrivateKey, error) {
 	block, _ := pem.Decode([]byte(privateKeyPEM))
 	if block == nil {
 		return nil, errors.New("failed to parse PEM block containing the key")
 	}
 
-	var privateKey *rsa.PrivateKey
-
 	// Try PKCS8 first (modern format)
 	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
 	if err != nil {
 		// Try PKCS1 format as fallback
-		privateKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse private key: %w", err)
 		}
-	} else {
-		// PKCS8 key parsed successfully, convert to RSA
-		var ok bool
-		privateKey, ok = key.(*rsa.PrivateKey)
-		if !ok {
-			return nil, errors.New("key is not RSA private key")
-		}
+		return privateKey, nil
 	}
 
-	return &Manager{
-		privateKey: privateKey,
-		publicKey:  &privateKey.PublicKey,
-	}, nil
+	// PKCS8 key parsed successfully, convert to RSA
+	privateKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("key is not RSA private key")
+	}
+	return privateKey, nil
+}
+
+// EncodePrivateKey encodes an RSA private key as a PKCS1 PEM block
+func EncodePrivateKey(privateKey *rsa.PrivateKey) string {
+	return string(pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	}))
+}
+
+// AddKey registers a key for verification and returns its key ID.
+// The key does not sign tokens until it is made active with SetActiveKey.
+func (m *Manager) AddKey(privateKey *rsa.PrivateKey) string {
+	kid := KeyID(&privateKey.PublicKey)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.privateKeys[kid] = privateKey
+	return kid
+}
+
+// SetActiveKey makes a registered key the one used to sign new tokens
+func (m *Manager) SetActiveKey(kid string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	privateKey, ok := m.privateKeys[kid]
+	if !ok {
+		return ErrUnknownKey
+	}
+	m.activeKID = kid
+	m.privateKey = privateKey
+	m.publicKey = &privateKey.PublicKey
+	return nil
+}
+
+// RemoveKey stops a key from verifying tokens. The active key cannot be removed.
+func (m *Manager) RemoveKey(kid string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if kid == m.activeKID {
+		return errors.New("cannot remove the active signing key")
+	}
+	delete(m.privateKeys, kid)
+	return nil
+}
+
+// ActiveKeyID returns the ID of the key currently signing tokens
+func (m *Manager) ActiveKeyID() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.activeKID
+}
+
+// ActivePrivateKey returns the key currently signing tokens
+func (m *Manager) ActivePrivateKey() *rsa.PrivateKey {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.privateKey
+}
+
+// KeyIDs returns the IDs of all keys that verify tokens, sorted
+func (m *Manager) KeyIDs() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	kids := make([]string, 0, len(m.privateKeys))
+	for kid := range m.privateKeys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+	return kids
+}
+
+// sign signs claims with the active key and stamps its key ID in the header
+func (m *Manager) sign(claims Claims) (string, error) {
+	m.mu.RLock()
+	privateKey, kid := m.privateKey, m.activeKID
+	m.mu.RUnlock()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	return token.SignedString(privateKey)
+}
+
+// verificationKey returns the public key for a token's "kid" header.
+// Tokens issued before key IDs were introduced have no kid and use the active key.
+func (m *Manager) verificationKey(token *jwt.Token) (*rsa.PublicKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return m.publicKey, nil
+	}
+	privateKey, ok := m.privateKeys[kid]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return &privateKey.PublicKey, nil
 }
 
 // GenerateAccessToken generates a short-lived access token (15 minutes)
 func (m *Manager) GenerateAccessToken(userID uuid.UUID, role string) (string, error) {
+	return m.generateAccessToken(userID, role, false)
+}
+
+// GenerateMFAVerifiedAccessToken generates a short-lived access token (15 minutes)
+// for a session that has passed a second factor
+func (m *Manager) GenerateMFAVerifiedAccessToken(userID uuid.UUID, role string) (string, error) {
+	return m.generateAccessToken(userID, role, true)
+}
+
+func (m *Manager) generateAccessToken(userID uuid.UUID, role string, mfaVerified bool) (string, error) {
 	now := time.Now()
 	claims := Claims{
-		UserID: userID,
-		Role:   role,
-		JTI:    uuid.New().String(),
+		UserID:      userID,
+		Role:        role,
+		JTI:         uuid.New().String(),
+		MFAVerified: mfaVerified,
 		RegisteredClaims: jwt.RegisteredClaims{
 			IssuedAt:  jwt.NewNumericDate(now),
-			ExpiresAt: jwt.NewNumericDate(now.Add(15 * time.Minute)),
+			ExpiresAt: jwt.NewNumericDate(now.Add(AccessTokenTTL)),
 			NotBefore: jwt.NewNumericDate(now),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
-	return token.SignedString(m.privateKey)
+	return m.sign(claims)
+}
+
+// GenerateImpersonationToken generates a short-lived access token (15 minutes) for a staff
+// member acting as another user. No refresh token is issued for impersonated sessions.
+func (m *Manager) GenerateImpersonationToken(userID uuid.UUID, role string, impersonatorID uuid.UUID) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		UserID:         userID,
+		Role:           role,
+		JTI:            uuid.New().String(),
+		ImpersonatorID: &impersonatorID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(AccessTokenTTL)),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+	}
+
+	return m.sign(claims)
 }
 
 // GenerateRefreshToken generates a long-lived refresh token (7 days)
@@ -97,13 +255,12 @@ func (m *Manager) GenerateRefreshToken(userID uuid.UUID) (string, error) {
 		JTI:    uuid.New().String(),
 		RegisteredClaims: jwt.RegisteredClaims{
 			IssuedAt:  jwt.NewNumericDate(now),
-			ExpiresAt: jwt.NewNumericDate(now.Add(7 * 24 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(now.Add(RefreshTokenTTL)),
 			NotBefore: jwt.NewNumericDate(now),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
-	return token.SignedString(m.privateKey)
+	return m.sign(claims)
 }
 
 // ValidateToken validates a JWT token and returns the claims
@@ -113,7 +270,7 @@ func (m *Manager) ValidateToken(tokenString string) (*Claims, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
 			return nil, ErrInvalidSigningMethod
 		}
-		return m.publicKey, nil
+		return m.verificationKey(token)
 	})
 
 	if err != nil {
@@ -164,12 +321,7 @@ func GenerateRSAKeyPair() (privateKeyPEM, publicKeyPEM string, err error) {
 	}
 
 	// Encode private key to PEM
-	privateKeyBytes := x509.MarshalPKCS1PrivateKey(privateKey)
-	privateKeyBlock := &pem.Block{
-		Type:  "RSA PRIVATE KEY",
-		Bytes: privateKeyBytes,
-	}
-	privateKeyPEM = string(pem.EncodeToMemory(privateKeyBlock))
+	privateKeyPEM = EncodePrivateKey(privateKey)
 
 	// Encode public key to PEM
 	publicKeyBytes, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
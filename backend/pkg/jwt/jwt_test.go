@@ -94,6 +94,52 @@ func TestGenerateAccessToken(t *testing.T) {
 	}
 }
 
+func TestGenerateImpersonationToken(t *testing.T) {
+	privateKey, _, err := GenerateRSAKeyPair()
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	manager, err := NewManager(privateKey)
+	if err != nil {
+		t.Fatalf("Failed to create manager: %v", err)
+	}
+
+	userID := uuid.New()
+	impersonatorID := uuid.New()
+
+	token, err := manager.GenerateImpersonationToken(userID, "user", impersonatorID)
+	if err != nil {
+		t.Fatalf("Failed to generate impersonation token: %v", err)
+	}
+
+	claims, err := manager.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("Failed to validate token: %v", err)
+	}
+
+	if claims.UserID != userID {
+		t.Errorf("Expected user ID %s, got %s", userID, claims.UserID)
+	}
+
+	if claims.ImpersonatorID == nil || *claims.ImpersonatorID != impersonatorID {
+		t.Errorf("Expected impersonator ID %s, got %v", impersonatorID, claims.ImpersonatorID)
+	}
+
+	// Regular access tokens carry no impersonator
+	accessToken, err := manager.GenerateAccessToken(userID, "user")
+	if err != nil {
+		t.Fatalf("Failed to generate access token: %v", err)
+	}
+	accessClaims, err := manager.ValidateToken(accessToken)
+	if err != nil {
+		t.Fatalf("Failed to validate token: %v", err)
+	}
+	if accessClaims.ImpersonatorID != nil {
+		t.Error("Expected no impersonator on regular access token")
+	}
+}
+
 func TestGenerateRefreshToken(t *testing.T) {
 	privateKey, _, err := GenerateRSAKeyPair()
 	if err != nil {
@@ -267,3 +313,92 @@ func TestTokenExpiration(t *testing.T) {
 		t.Errorf("Refresh token expiration not as expected. Diff: %v", diff)
 	}
 }
+
+func TestKeyRotation(t *testing.T) {
+	oldKeyPEM, _, err := GenerateRSAKeyPair()
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	manager, err := NewManager(oldKeyPEM)
+	if err != nil {
+		t.Fatalf("Failed to create manager: %v", err)
+	}
+	oldKID := manager.ActiveKeyID()
+
+	userID := uuid.New()
+	oldToken, err := manager.GenerateAccessToken(userID, "user")
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	newKeyPEM, _, err := GenerateRSAKeyPair()
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	newKey, err := ParsePrivateKey(newKeyPEM)
+	if err != nil {
+		t.Fatalf("Failed to parse key: %v", err)
+	}
+	newKID := manager.AddKey(newKey)
+	if err := manager.SetActiveKey(newKID); err != nil {
+		t.Fatalf("Failed to activate key: %v", err)
+	}
+
+	newToken, err := manager.GenerateAccessToken(userID, "user")
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	// Tokens from both keys verify while the old key is registered
+	for _, token := range []string{oldToken, newToken} {
+		if _, err := manager.ValidateToken(token); err != nil {
+			t.Errorf("Expected token to validate after rotation, got %v", err)
+		}
+	}
+
+	jwks := manager.JWKS()
+	if len(jwks.Keys) != 2 || jwks.Keys[0].Kid != newKID || jwks.Keys[1].Kid != oldKID {
+		t.Errorf("Expected JWKS with active key first, got %+v", jwks.Keys)
+	}
+
+	if err := manager.RemoveKey(newKID); err == nil {
+		t.Error("Expected error removing the active key")
+	}
+	if err := manager.RemoveKey(oldKID); err != nil {
+		t.Fatalf("Failed to remove old key: %v", err)
+	}
+	if _, err := manager.ValidateToken(oldToken); err != ErrInvalidToken {
+		t.Errorf("Expected retired key token to be rejected, got %v", err)
+	}
+	if _, err := manager.ValidateToken(newToken); err != nil {
+		t.Errorf("Expected active key token to validate, got %v", err)
+	}
+
+	if err := manager.SetActiveKey("missing"); err != ErrUnknownKey {
+		t.Errorf("Expected ErrUnknownKey, got %v", err)
+	}
+}
+
+func TestKeyID_IsStable(t *testing.T) {
+	privateKeyPEM, _, err := GenerateRSAKeyPair()
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	privateKey, err := ParsePrivateKey(privateKeyPEM)
+	if err != nil {
+		t.Fatalf("Failed to parse key: %v", err)
+	}
+
+	reparsed, err := ParsePrivateKey(EncodePrivateKey(privateKey))
+	if err != nil {
+		t.Fatalf("Failed to parse encoded key: %v", err)
+	}
+	if KeyID(&privateKey.PublicKey) != KeyID(&reparsed.PublicKey) {
+		t.Error("Expected key ID to survive a PEM round trip")
+	}
+
+	jwk := PublicJWK(&privateKey.PublicKey)
+	if jwk.E != "AQAB" || jwk.Kty != "RSA" || jwk.Alg != "RS256" {
+		t.Errorf("Unexpected JWK fields: %+v", jwk)
+	}
+}
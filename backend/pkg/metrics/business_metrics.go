@@ -0,0 +1,219 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// OtherLabel replaces label values that would grow a metric's cardinality
+// past its guard
+const OtherLabel = "other"
+
+// LabelSet bounds the values of a label that comes from user input or data.
+// Values outside the allowed list, and new values once max distinct values
+// have been seen, are reported as OtherLabel.
+type LabelSet struct {
+	mu      sync.Mutex
+	allowed map[string]struct{}
+	seen    map[string]struct{}
+	max     int
+}
+
+// NewLabelSet returns a guard that only passes the given values
+func NewLabelSet(values ...string) *LabelSet {
+	allowed := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		allowed[v] = struct{}{}
+	}
+	return &LabelSet{allowed: allowed}
+}
+
+// NewLabelLimit returns a guard that passes the first max distinct values seen
+func NewLabelLimit(max int) *LabelSet {
+	return &LabelSet{seen: make(map[string]struct{}), max: max}
+}
+
+// Value returns v if it may be used as a label value, or OtherLabel
+func (l *LabelSet) Value(v string) string {
+	if v == "" {
+		return "none"
+	}
+	if l.allowed != nil {
+		if _, ok := l.allowed[v]; ok {
+			return v
+		}
+		return OtherLabel
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.seen[v]; ok {
+		return v
+	}
+	if len(l.seen) >= l.max {
+		return OtherLabel
+	}
+	l.seen[v] = struct{}{}
+	return v
+}
+
+var (
+	submissionOutcomes = NewLabelSet("approved", "pending", "awaiting_broadcaster", "invalid", "rate_limited", "error")
+	voteTargets        = NewLabelSet("clip", "comment")
+	voteDirections     = NewLabelSet("up", "down", "remove")
+	searchBackends     = NewLabelSet("hybrid", "opensearch", "postgres")
+	searchOutcomes     = NewLabelSet("success", "fallback", "error", "unavailable")
+	webhookProviders   = NewLabelSet("stripe", "sendgrid", "twitch_eventsub")
+	emailStatuses      = NewLabelSet("sent", "failed", "suppressed", "rate_limited")
+	adPlatforms        = NewLabelSet("web", "ios", "android")
+	// Slot IDs are chosen by clients, so only the first few dozen are kept
+	adSlots = NewLabelLimit(50)
+)
+
+var (
+	// SubmissionsTotal tracks clip submissions by outcome
+	SubmissionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "clip_submissions_total",
+			Help: "Total number of clip submissions by outcome",
+		},
+		[]string{"outcome"}, // approved, pending, awaiting_broadcaster, invalid, rate_limited, error
+	)
+
+	// VotesTotal tracks votes cast on clips and comments
+	VotesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "votes_total",
+			Help: "Total number of votes cast",
+		},
+		[]string{"target", "direction"}, // target: clip, comment; direction: up, down, remove
+	)
+
+	// SearchRequestsTotal tracks search requests by the backend that served them
+	SearchRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "search_requests_total",
+			Help: "Total number of search requests by backend",
+		},
+		[]string{"backend", "outcome"},
+	)
+
+	// WebhooksReceivedTotal tracks inbound webhook deliveries by provider
+	WebhooksReceivedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "webhooks_received_total",
+			Help: "Total number of inbound webhook deliveries",
+		},
+		[]string{"provider", "outcome"}, // outcome: accepted, rejected, error
+	)
+
+	// EmailsTotal tracks outgoing emails by status
+	EmailsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "emails_total",
+			Help: "Total number of emails by delivery status",
+		},
+		[]string{"status"}, // sent, failed, suppressed, rate_limited
+	)
+
+	// AdImpressionsTotal tracks ads served
+	AdImpressionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ad_impressions_total",
+			Help: "Total number of ad impressions served",
+		},
+		[]string{"platform", "slot"},
+	)
+
+	// AdViewableImpressionsTotal tracks impressions that met the viewability threshold
+	AdViewableImpressionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ad_viewable_impressions_total",
+			Help: "Total number of ad impressions that met the viewability threshold",
+		},
+		[]string{"platform", "slot"},
+	)
+
+	// AdClicksTotal tracks ad clicks; CTR is ad_clicks_total / ad_impressions_total
+	AdClicksTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ad_clicks_total",
+			Help: "Total number of ad clicks",
+		},
+		[]string{"platform", "slot"},
+	)
+)
+
+// RecordSubmission counts a clip submission by outcome
+func RecordSubmission(outcome string) {
+	SubmissionsTotal.WithLabelValues(submissionOutcomes.Value(outcome)).Inc()
+}
+
+// RecordVote counts a vote on a clip or comment. voteType is 1, -1 or 0 for
+// a removed vote.
+func RecordVote(target string, voteType int16) {
+	direction := "remove"
+	switch voteType {
+	case 1:
+		direction = "up"
+	case -1:
+		direction = "down"
+	}
+	VotesTotal.WithLabelValues(voteTargets.Value(target), voteDirections.Value(direction)).Inc()
+}
+
+// RecordSearch counts a search request served by backend
+func RecordSearch(backend, outcome string) {
+	SearchRequestsTotal.WithLabelValues(searchBackends.Value(backend), searchOutcomes.Value(outcome)).Inc()
+}
+
+// RecordWebhookReceived counts an inbound webhook by the HTTP status it was
+// answered with
+func RecordWebhookReceived(provider string, status int) {
+	outcome := "accepted"
+	switch {
+	case status >= 500:
+		outcome = "error"
+	case status >= 400:
+		outcome = "rejected"
+	}
+	WebhooksReceivedTotal.WithLabelValues(webhookProviders.Value(provider), outcome).Inc()
+}
+
+// RecordEmail counts an email by delivery status
+func RecordEmail(status string) {
+	EmailsTotal.WithLabelValues(emailStatuses.Value(status)).Inc()
+}
+
+// AdLabels returns the bounded platform and slot labels for an ad impression
+func AdLabels(platform string, slotID *string) (string, string) {
+	slot := ""
+	if slotID != nil {
+		slot = *slotID
+	}
+	return adPlatforms.Value(platform), adSlots.Value(slot)
+}
+
+func registerBusinessMetrics() {
+	register := func(c prometheus.Collector) {
+		if err := prometheus.Register(c); err != nil {
+			if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
+				panic(err)
+			}
+		}
+	}
+
+	register(SubmissionsTotal)
+	register(VotesTotal)
+	register(SearchRequestsTotal)
+	register(WebhooksReceivedTotal)
+	register(EmailsTotal)
+	register(AdImpressionsTotal)
+	register(AdViewableImpressionsTotal)
+	register(AdClicksTotal)
+}
+
+func init() {
+	registerBusinessMetrics()
+}
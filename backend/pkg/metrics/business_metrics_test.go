@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelSetAllowedValues(t *testing.T) {
+	set := NewLabelSet("clip", "comment")
+
+	assert.Equal(t, "clip", set.Value("clip"))
+	assert.Equal(t, OtherLabel, set.Value("playlist"))
+	assert.Equal(t, "none", set.Value(""))
+}
+
+func TestLabelLimitCapsDistinctValues(t *testing.T) {
+	limit := NewLabelLimit(2)
+
+	assert.Equal(t, "a", limit.Value("a"))
+	assert.Equal(t, "b", limit.Value("b"))
+	assert.Equal(t, OtherLabel, limit.Value("c"))
+	// Values seen before the cap was reached keep their label
+	assert.Equal(t, "a", limit.Value("a"))
+}
+
+func TestRecordVote(t *testing.T) {
+	VotesTotal.Reset()
+
+	RecordVote("clip", 1)
+	RecordVote("clip", -1)
+	RecordVote("comment", 0)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(VotesTotal.WithLabelValues("clip", "up")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(VotesTotal.WithLabelValues("clip", "down")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(VotesTotal.WithLabelValues("comment", "remove")))
+}
+
+func TestRecordWebhookReceived(t *testing.T) {
+	WebhooksReceivedTotal.Reset()
+
+	RecordWebhookReceived("stripe", http.StatusOK)
+	RecordWebhookReceived("stripe", http.StatusBadRequest)
+	RecordWebhookReceived("sendgrid", http.StatusInternalServerError)
+	RecordWebhookReceived("unknown", http.StatusNoContent)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(WebhooksReceivedTotal.WithLabelValues("stripe", "accepted")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(WebhooksReceivedTotal.WithLabelValues("stripe", "rejected")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(WebhooksReceivedTotal.WithLabelValues("sendgrid", "error")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(WebhooksReceivedTotal.WithLabelValues(OtherLabel, "accepted")))
+}
+
+func TestAdLabelsBoundSlots(t *testing.T) {
+	platform, slot := AdLabels("web", nil)
+	assert.Equal(t, "web", platform)
+	assert.Equal(t, "none", slot)
+
+	platform, _ = AdLabels("smart-fridge", nil)
+	assert.Equal(t, OtherLabel, platform)
+
+	// Flood the slot guard with client-chosen IDs
+	for i := 0; i < 100; i++ {
+		id := fmt.Sprintf("slot-%d", i)
+		AdLabels("web", &id)
+	}
+	id := "slot-new"
+	_, slot = AdLabels("web", &id)
+	assert.Equal(t, OtherLabel, slot)
+}
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// CacheInvalidationsPublished tracks invalidation events published on the cache invalidation bus
+	CacheInvalidationsPublished = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_invalidations_published_total",
+			Help: "Total number of cache invalidation events published",
+		},
+		[]string{"topic", "status"}, // status: success, local (Redis unavailable), error
+	)
+
+	// CacheInvalidationsHandled tracks invalidation events handled by each subscriber
+	CacheInvalidationsHandled = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_invalidations_handled_total",
+			Help: "Total number of cache invalidation events handled by subscribers",
+		},
+		[]string{"topic", "subscriber", "status"}, // status: success, error
+	)
+
+	// CacheInvalidationLag tracks the time from publishing an invalidation to a
+	// subscriber having cleared its keys
+	CacheInvalidationLag = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "cache_invalidation_lag_seconds",
+			Help:    "Time from publishing a cache invalidation event to a subscriber finishing it",
+			Buckets: []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+		},
+		[]string{"topic", "subscriber"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(CacheInvalidationsPublished)
+	prometheus.MustRegister(CacheInvalidationsHandled)
+	prometheus.MustRegister(CacheInvalidationLag)
+}
@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -52,6 +54,17 @@ var (
 	)
 )
 
+// ObserveJobRun records the duration and outcome of one run of a background job
+func ObserveJobRun(jobName string, duration time.Duration, err error) {
+	JobExecutionDuration.WithLabelValues(jobName).Observe(duration.Seconds())
+	if err != nil {
+		JobExecutionTotal.WithLabelValues(jobName, "failed").Inc()
+		return
+	}
+	JobExecutionTotal.WithLabelValues(jobName, "success").Inc()
+	JobLastSuccessTimestamp.WithLabelValues(jobName).Set(float64(time.Now().Unix()))
+}
+
 func registerJobMetrics() {
 	// Helper function to register metrics, ignoring AlreadyRegisteredError
 	register := func(c prometheus.Collector) {
@@ -1,6 +1,7 @@
 package metrics
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -100,3 +101,17 @@ func TestJobQueueSize(t *testing.T) {
 	updatedSize := testutil.ToFloat64(JobQueueSize.WithLabelValues(jobName))
 	assert.Equal(t, float64(10), updatedSize, "Updated queue size should be 10")
 }
+
+func TestObserveJobRun(t *testing.T) {
+	JobExecutionTotal.Reset()
+	JobLastSuccessTimestamp.Reset()
+
+	jobName := "test_observed_job"
+
+	ObserveJobRun(jobName, 2*time.Second, nil)
+	ObserveJobRun(jobName, time.Second, errors.New("boom"))
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(JobExecutionTotal.WithLabelValues(jobName, "success")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(JobExecutionTotal.WithLabelValues(jobName, "failed")))
+	assert.InDelta(t, float64(time.Now().Unix()), testutil.ToFloat64(JobLastSuccessTimestamp.WithLabelValues(jobName)), 1)
+}
@@ -80,6 +80,61 @@ var (
 		[]string{"type"}, // "clip" or "query"
 	)
 
+	// Embedding API spend metrics
+	EmbeddingAPIRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "embedding_api_requests_total",
+			Help: "Total number of embedding API requests by model",
+		},
+		[]string{"model", "status"}, // "success" or "error"
+	)
+
+	EmbeddingBatchInputs = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "embedding_batch_inputs",
+			Help:    "Number of texts sent in each embedding API request",
+			Buckets: []float64{1, 5, 10, 25, 50, 100, 250, 500, 1000, 2048},
+		},
+		[]string{"model"},
+	)
+
+	EmbeddingTokensTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "embedding_tokens_total",
+			Help: "Total number of tokens billed for embeddings by model",
+		},
+		[]string{"model"},
+	)
+
+	EmbeddingCostUSDTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "embedding_cost_usd_total",
+			Help: "Estimated embedding API cost in US dollars by model",
+		},
+		[]string{"model"},
+	)
+
+	EmbeddingMonthlySpendUSD = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "embedding_monthly_spend_usd",
+			Help: "Estimated embedding API spend this calendar month (UTC) in US dollars",
+		},
+	)
+
+	EmbeddingMonthlyBudgetUSD = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "embedding_monthly_budget_usd",
+			Help: "Monthly embedding API budget in US dollars, 0 when unlimited",
+		},
+	)
+
+	EmbeddingBudgetRejections = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "embedding_budget_rejections_total",
+			Help: "Total number of embedding requests refused because the monthly budget is spent",
+		},
+	)
+
 	// Indexing metrics
 	ClipsWithEmbeddings = prometheus.NewGauge(
 		prometheus.GaugeOpts{
@@ -145,6 +200,23 @@ var (
 		},
 		[]string{"reason"}, // "timeout", "error"
 	)
+
+	// Embedding migration dual-read metrics
+	EmbeddingDualReadTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "embedding_dual_read_total",
+			Help: "Total number of searches also ranked with the embedding column being migrated to",
+		},
+		[]string{"status"}, // "success", "error"
+	)
+
+	EmbeddingDualReadOverlap = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "embedding_dual_read_overlap_ratio",
+			Help:    "Share of a search's top results that the embedding column being migrated to also ranks in its top results",
+			Buckets: []float64{0, 0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 1},
+		},
+	)
 )
 
 func init() {
@@ -159,6 +231,13 @@ func init() {
 	prometheus.MustRegister(EmbeddingGenerationTotal)
 	prometheus.MustRegister(EmbeddingGenerationErrors)
 	prometheus.MustRegister(EmbeddingGenerationDuration)
+	prometheus.MustRegister(EmbeddingAPIRequests)
+	prometheus.MustRegister(EmbeddingBatchInputs)
+	prometheus.MustRegister(EmbeddingTokensTotal)
+	prometheus.MustRegister(EmbeddingCostUSDTotal)
+	prometheus.MustRegister(EmbeddingMonthlySpendUSD)
+	prometheus.MustRegister(EmbeddingMonthlyBudgetUSD)
+	prometheus.MustRegister(EmbeddingBudgetRejections)
 
 	prometheus.MustRegister(ClipsWithEmbeddings)
 	prometheus.MustRegister(ClipsWithoutEmbeddings)
@@ -169,4 +248,7 @@ func init() {
 	prometheus.MustRegister(BM25SearchDuration)
 	prometheus.MustRegister(SearchFallbackTotal)
 	prometheus.MustRegister(SearchFallbackDuration)
+
+	prometheus.MustRegister(EmbeddingDualReadTotal)
+	prometheus.MustRegister(EmbeddingDualReadOverlap)
 }
@@ -0,0 +1,169 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrUnavailable is returned without contacting Redis while the client is
+// degraded and waiting to retry. Callers treat it like any other Redis error:
+// caches miss and rate limiters fall back to in-memory limits.
+var ErrUnavailable = errors.New("redis unavailable (degraded mode)")
+
+// unavailableReplyPrefixes are server replies meaning Redis cannot serve
+// commands right now, as opposed to errors in the command itself
+var unavailableReplyPrefixes = []string{"LOADING", "MASTERDOWN", "CLUSTERDOWN", "READONLY", "TRYAGAIN"}
+
+// Status describes the connection mode and health of a client
+type Status struct {
+	Mode          string     `json:"mode"`
+	Degraded      bool       `json:"degraded"`
+	DegradedSince *time.Time `json:"degraded_since,omitempty"`
+	LastError     string     `json:"last_error,omitempty"`
+}
+
+// healthHook bounds every command with the operation timeout and tracks
+// whether Redis is reachable. After a failure, commands other than PING fail
+// fast with ErrUnavailable until the retry interval has passed, so requests
+// don't each wait out the timeout while Redis is down.
+type healthHook struct {
+	opTimeout     time.Duration
+	retryInterval time.Duration
+
+	degradedSince atomic.Int64 // unix nanoseconds, 0 while healthy
+	lastFailure   atomic.Int64 // unix nanoseconds
+	lastError     atomic.Value // string
+}
+
+func newHealthHook(opTimeout, retryInterval time.Duration) *healthHook {
+	return &healthHook{
+		opTimeout:     opTimeout,
+		retryInterval: retryInterval,
+	}
+}
+
+// DialHook leaves dialing to the client, which has its own dial timeout
+func (h *healthHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+// ProcessHook applies the operation timeout and records the outcome of a command
+func (h *healthHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if cmd.Name() != "ping" && h.failFast() {
+			cmd.SetErr(ErrUnavailable)
+			return ErrUnavailable
+		}
+
+		ctx, cancel := h.withTimeout(ctx)
+		defer cancel()
+
+		err := next(ctx, cmd)
+		h.record(err)
+		return err
+	}
+}
+
+// ProcessPipelineHook applies the operation timeout to a whole pipeline
+func (h *healthHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if h.failFast() {
+			for _, cmd := range cmds {
+				cmd.SetErr(ErrUnavailable)
+			}
+			return ErrUnavailable
+		}
+
+		ctx, cancel := h.withTimeout(ctx)
+		defer cancel()
+
+		err := next(ctx, cmds)
+		h.record(err)
+		return err
+	}
+}
+
+// withTimeout bounds ctx by the operation timeout unless it already has a
+// sooner deadline
+func (h *healthHook) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if h.opTimeout <= 0 {
+		return ctx, func() {}
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= h.opTimeout {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, h.opTimeout)
+}
+
+// failFast reports whether commands should skip Redis because it failed
+// within the retry interval
+func (h *healthHook) failFast() bool {
+	if h.degradedSince.Load() == 0 {
+		return false
+	}
+	return time.Since(time.Unix(0, h.lastFailure.Load())) < h.retryInterval
+}
+
+// record updates the health state from the result of a command
+func (h *healthHook) record(err error) {
+	if errors.Is(err, context.Canceled) {
+		// The caller gave up; that says nothing about Redis
+		return
+	}
+
+	if !isUnavailableError(err) {
+		if since := h.degradedSince.Swap(0); since != 0 {
+			log.Printf("Redis available again after %v in degraded mode", time.Since(time.Unix(0, since)).Round(time.Second))
+		}
+		return
+	}
+
+	now := time.Now().UnixNano()
+	h.lastFailure.Store(now)
+	h.lastError.Store(err.Error())
+	if h.degradedSince.CompareAndSwap(0, now) {
+		log.Printf("WARNING: Redis unavailable, entering degraded mode (caching bypassed, rate limits per instance): %v", err)
+	}
+}
+
+// status returns the current health state
+func (h *healthHook) status() Status {
+	var status Status
+	if since := h.degradedSince.Load(); since != 0 {
+		t := time.Unix(0, since)
+		status.Degraded = true
+		status.DegradedSince = &t
+		if lastErr, ok := h.lastError.Load().(string); ok {
+			status.LastError = lastErr
+		}
+	}
+	return status
+}
+
+// isUnavailableError reports whether err means Redis could not serve the
+// command, rather than a missing key or an error in the command itself
+func isUnavailableError(err error) bool {
+	if err == nil || errors.Is(err, redis.Nil) {
+		return false
+	}
+
+	var replyErr redis.Error
+	if errors.As(err, &replyErr) {
+		for _, prefix := range unavailableReplyPrefixes {
+			if redis.HasErrorPrefix(err, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/redis/go-redis/extra/redisotel/v9"
@@ -13,9 +14,18 @@ import (
 	"github.com/subculture-collective/clipper/config"
 )
 
+// Connection modes for RedisConfig.Mode
+const (
+	ModeStandalone = "standalone"
+	ModeSentinel   = "sentinel"
+	ModeCluster    = "cluster"
+)
+
 // Client wraps the Redis client
 type Client struct {
-	client *redis.Client
+	client redis.UniversalClient
+	mode   string
+	health *healthHook
 }
 
 // NewClient creates a new Redis client
@@ -25,20 +35,22 @@ func NewClient(cfg *config.RedisConfig) (*Client, error) {
 
 // NewClientWithTracing creates a new Redis client with optional tracing
 func NewClientWithTracing(cfg *config.RedisConfig, enableTracing bool) (*Client, error) {
-	rdb := redis.NewClient(&redis.Options{
-		Addr:         fmt.Sprintf("%s:%s", cfg.Host, cfg.Port),
-		Password:     cfg.Password,
-		DB:           cfg.DB,
-		DialTimeout:  5 * time.Second,
-		ReadTimeout:  3 * time.Second,
-		WriteTimeout: 3 * time.Second,
-		PoolSize:     10,
-		MinIdleConns: 2,
-	})
+	mode := normalizeMode(cfg.Mode)
+	rdb, err := newUniversalClient(cfg, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	health := newHealthHook(
+		time.Duration(cfg.OperationTimeoutMs)*time.Millisecond,
+		time.Duration(cfg.DegradedRetrySeconds)*time.Second,
+	)
+	rdb.AddHook(health)
 
 	// Add tracing if enabled
 	if enableTracing {
 		if err := redisotel.InstrumentTracing(rdb); err != nil {
+			_ = rdb.Close()
 			return nil, fmt.Errorf("failed to instrument Redis tracing: %w", err)
 		}
 		log.Println("Redis tracing enabled")
@@ -49,12 +61,103 @@ func NewClientWithTracing(cfg *config.RedisConfig, enableTracing bool) (*Client,
 	defer cancel()
 
 	if err := rdb.Ping(ctx).Err(); err != nil {
-		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+		_ = rdb.Close()
+		return nil, fmt.Errorf("failed to connect to Redis (%s mode): %w", mode, err)
 	}
 
-	log.Println("Redis connection established successfully")
+	log.Printf("Redis connection established successfully (%s mode)", mode)
 
-	return &Client{client: rdb}, nil
+	return &Client{client: rdb, mode: mode, health: health}, nil
+}
+
+// normalizeMode maps an empty mode to standalone
+func normalizeMode(mode string) string {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode == "" {
+		return ModeStandalone
+	}
+	return mode
+}
+
+// newUniversalClient builds the go-redis client for the configured mode
+func newUniversalClient(cfg *config.RedisConfig, mode string) (redis.UniversalClient, error) {
+	addrs := cfg.Addrs
+	if len(addrs) == 0 {
+		addrs = []string{fmt.Sprintf("%s:%s", cfg.Host, cfg.Port)}
+	}
+
+	switch mode {
+	case ModeStandalone:
+		return redis.NewClient(&redis.Options{
+			Addr:         fmt.Sprintf("%s:%s", cfg.Host, cfg.Port),
+			Password:     cfg.Password,
+			DB:           cfg.DB,
+			DialTimeout:  5 * time.Second,
+			ReadTimeout:  3 * time.Second,
+			WriteTimeout: 3 * time.Second,
+			PoolSize:     10,
+			MinIdleConns: 2,
+		}), nil
+	case ModeSentinel:
+		if cfg.MasterName == "" {
+			return nil, fmt.Errorf("REDIS_SENTINEL_MASTER is required in sentinel mode")
+		}
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       cfg.MasterName,
+			SentinelAddrs:    addrs,
+			SentinelPassword: cfg.SentinelPassword,
+			Password:         cfg.Password,
+			DB:               cfg.DB,
+			DialTimeout:      5 * time.Second,
+			ReadTimeout:      3 * time.Second,
+			WriteTimeout:     3 * time.Second,
+			PoolSize:         10,
+			MinIdleConns:     2,
+		}), nil
+	case ModeCluster:
+		if cfg.DB != 0 {
+			return nil, fmt.Errorf("REDIS_DB must be 0 in cluster mode, got %d", cfg.DB)
+		}
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:        addrs,
+			Password:     cfg.Password,
+			DialTimeout:  5 * time.Second,
+			ReadTimeout:  3 * time.Second,
+			WriteTimeout: 3 * time.Second,
+			PoolSize:     10,
+			MinIdleConns: 2,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unsupported REDIS_MODE %q (expected %s, %s or %s)", mode, ModeStandalone, ModeSentinel, ModeCluster)
+	}
+}
+
+// Mode returns the connection mode: standalone, sentinel or cluster
+func (c *Client) Mode() string {
+	return c.mode
+}
+
+// Status reports the connection mode and whether the client is degraded.
+// A degraded client fails commands fast, so callers fall back (cache bypass,
+// in-memory rate limits) until Redis answers again.
+func (c *Client) Status() Status {
+	var status Status
+	if c.health != nil {
+		status = c.health.status()
+	}
+	status.Mode = c.mode
+	return status
+}
+
+// forEachNode runs fn against every node holding keys: each master in
+// cluster mode, the client itself otherwise
+func (c *Client) forEachNode(ctx context.Context, fn func(ctx context.Context, node redis.Cmdable) error) error {
+	if cluster, ok := c.client.(*redis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			return fn(ctx, node)
+		})
+	}
+	return fn(ctx, c.client)
 }
 
 // Close closes the Redis connection
@@ -97,19 +200,21 @@ func (c *Client) Delete(ctx context.Context, key string) error {
 
 // DeletePattern removes all keys matching a pattern
 func (c *Client) DeletePattern(ctx context.Context, pattern string) error {
-	iter := c.client.Scan(ctx, 0, pattern, 0).Iterator()
-	pipe := c.client.Pipeline()
+	return c.forEachNode(ctx, func(ctx context.Context, node redis.Cmdable) error {
+		iter := node.Scan(ctx, 0, pattern, 0).Iterator()
+		pipe := node.Pipeline()
 
-	for iter.Next(ctx) {
-		pipe.Del(ctx, iter.Val())
-	}
+		for iter.Next(ctx) {
+			pipe.Del(ctx, iter.Val())
+		}
 
-	if err := iter.Err(); err != nil {
-		return fmt.Errorf("failed to scan keys: %w", err)
-	}
+		if err := iter.Err(); err != nil {
+			return fmt.Errorf("failed to scan keys: %w", err)
+		}
 
-	_, err := pipe.Exec(ctx)
-	return err
+		_, err := pipe.Exec(ctx)
+		return err
+	})
 }
 
 // Exists checks if a key exists
@@ -169,12 +274,45 @@ func (c *Client) GetWithTTL(ctx context.Context, key string) (string, time.Durat
 
 // MGet retrieves multiple values at once
 func (c *Client) MGet(ctx context.Context, keys ...string) ([]interface{}, error) {
-	return c.client.MGet(ctx, keys...).Result()
+	if c.mode != ModeCluster {
+		return c.client.MGet(ctx, keys...).Result()
+	}
+
+	// Keys may live in different slots, so fetch them individually in one pipeline
+	pipe := c.client.Pipeline()
+	cmds := make([]*redis.StringCmd, len(keys))
+	for i, key := range keys {
+		cmds[i] = pipe.Get(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(keys))
+	for i, cmd := range cmds {
+		if val, err := cmd.Result(); err == nil {
+			values[i] = val
+		}
+	}
+	return values, nil
 }
 
 // MSet sets multiple key-value pairs at once
 func (c *Client) MSet(ctx context.Context, pairs ...interface{}) error {
-	return c.client.MSet(ctx, pairs...).Err()
+	if c.mode != ModeCluster {
+		return c.client.MSet(ctx, pairs...).Err()
+	}
+	if len(pairs)%2 != 0 {
+		return fmt.Errorf("MSet requires key-value pairs, got %d arguments", len(pairs))
+	}
+
+	// Keys may live in different slots, so set them individually in one pipeline
+	pipe := c.client.Pipeline()
+	for i := 0; i < len(pairs); i += 2 {
+		pipe.Set(ctx, fmt.Sprint(pairs[i]), pairs[i+1], 0)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
 }
 
 // ZAdd adds a member with score to a sorted set
@@ -274,7 +412,25 @@ func (c *Client) GetStats(ctx context.Context) (map[string]string, error) {
 
 // Keys returns all keys matching a pattern
 func (c *Client) Keys(ctx context.Context, pattern string) ([]string, error) {
-	return c.client.Keys(ctx, pattern).Result()
+	if c.mode != ModeCluster {
+		return c.client.Keys(ctx, pattern).Result()
+	}
+
+	var (
+		mu   sync.Mutex
+		keys []string
+	)
+	err := c.forEachNode(ctx, func(ctx context.Context, node redis.Cmdable) error {
+		nodeKeys, err := node.Keys(ctx, pattern).Result()
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		keys = append(keys, nodeKeys...)
+		mu.Unlock()
+		return nil
+	})
+	return keys, err
 }
 
 // GetClient returns the underlying redis client
@@ -282,7 +438,9 @@ func (c *Client) Keys(ctx context.Context, pattern string) ([]string, error) {
 // Direct access bypasses wrapper logic and makes it harder to change the Redis implementation.
 // Prefer adding specific methods to the Client wrapper for new operations.
 // Currently used by: embedding service (requires direct client for caching operations)
-func (c *Client) GetClient() *redis.Client {
+// The concrete type depends on the mode: *redis.Client for standalone and
+// sentinel, *redis.ClusterClient for cluster.
+func (c *Client) GetClient() redis.UniversalClient {
 	return c.client
 }
 
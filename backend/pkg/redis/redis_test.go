@@ -1,8 +1,15 @@
 package redis
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/subculture-collective/clipper/config"
 )
 
 // TestGetStatsLineHandling tests that the GetStats method correctly handles
@@ -76,3 +83,163 @@ func TestGetStatsLineHandling(t *testing.T) {
 		})
 	}
 }
+
+// TestNewUniversalClientModes tests client selection and validation per mode
+func TestNewUniversalClientModes(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      config.RedisConfig
+		wantType string
+		wantErr  bool
+	}{
+		{
+			name:     "Standalone by default",
+			cfg:      config.RedisConfig{Host: "localhost", Port: "6379"},
+			wantType: "*redis.Client",
+		},
+		{
+			name:     "Sentinel",
+			cfg:      config.RedisConfig{Mode: "sentinel", Addrs: []string{"s1:26379", "s2:26379"}, MasterName: "mymaster"},
+			wantType: "*redis.Client",
+		},
+		{
+			name:    "Sentinel without master name",
+			cfg:     config.RedisConfig{Mode: "sentinel", Addrs: []string{"s1:26379"}},
+			wantErr: true,
+		},
+		{
+			name:     "Cluster",
+			cfg:      config.RedisConfig{Mode: "CLUSTER", Addrs: []string{"n1:6379", "n2:6379"}},
+			wantType: "*redis.ClusterClient",
+		},
+		{
+			name:    "Cluster with non-zero DB",
+			cfg:     config.RedisConfig{Mode: "cluster", Host: "localhost", Port: "6379", DB: 1},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown mode",
+			cfg:     config.RedisConfig{Mode: "replicated"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := newUniversalClient(&tt.cfg, normalizeMode(tt.cfg.Mode))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			defer client.Close()
+
+			if got := fmt.Sprintf("%T", client); got != tt.wantType {
+				t.Errorf("Expected client type %s, got %s", tt.wantType, got)
+			}
+		})
+	}
+}
+
+// replyError is a Redis server reply error
+type replyError string
+
+func (e replyError) Error() string { return string(e) }
+
+func (replyError) RedisError() {}
+
+// TestIsUnavailableError tests which errors put the client in degraded mode
+func TestIsUnavailableError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "No error", err: nil, want: false},
+		{name: "Missing key", err: goredis.Nil, want: false},
+		{name: "Cross slot reply", err: goredis.ErrCrossSlot, want: false},
+		{name: "Loading reply", err: replyError("LOADING Redis is loading the dataset in memory"), want: true},
+		{name: "Cluster down reply", err: replyError("CLUSTERDOWN The cluster is down"), want: true},
+		{name: "Script error reply", err: replyError("ERR Error running script"), want: false},
+		{name: "Connection refused", err: errors.New("dial tcp 127.0.0.1:6379: connect: connection refused"), want: true},
+		{name: "Timeout", err: context.DeadlineExceeded, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isUnavailableError(tt.err); got != tt.want {
+				t.Errorf("isUnavailableError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestHealthHookDegradedMode tests that failures short-circuit commands until
+// the retry interval passes and that a success clears degraded mode
+func TestHealthHookDegradedMode(t *testing.T) {
+	hook := newHealthHook(time.Second, time.Hour)
+	ctx := context.Background()
+
+	calls := 0
+	var nextErr error
+	process := hook.ProcessHook(func(ctx context.Context, cmd goredis.Cmder) error {
+		calls++
+		return nextErr
+	})
+
+	nextErr = errors.New("connection refused")
+	_ = process(ctx, goredis.NewStringCmd(ctx, "get", "key"))
+	if !hook.status().Degraded {
+		t.Fatal("Expected degraded mode after a connection error")
+	}
+
+	cmd := goredis.NewStringCmd(ctx, "get", "key")
+	if err := process(ctx, cmd); !errors.Is(err, ErrUnavailable) || !errors.Is(cmd.Err(), ErrUnavailable) {
+		t.Errorf("Expected ErrUnavailable while degraded, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected degraded commands to skip Redis, got %d calls", calls)
+	}
+
+	// PING still reaches Redis so health checks can detect recovery
+	nextErr = nil
+	if err := process(ctx, goredis.NewStatusCmd(ctx, "ping")); err != nil {
+		t.Fatalf("Unexpected ping error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected ping to reach Redis, got %d calls", calls)
+	}
+	if hook.status().Degraded {
+		t.Error("Expected a successful command to clear degraded mode")
+	}
+}
+
+// TestHealthHookOperationTimeout tests that the operation timeout only
+// shortens deadlines
+func TestHealthHookOperationTimeout(t *testing.T) {
+	hook := newHealthHook(50*time.Millisecond, time.Second)
+
+	var deadline time.Time
+	process := hook.ProcessHook(func(ctx context.Context, cmd goredis.Cmder) error {
+		deadline, _ = ctx.Deadline()
+		return nil
+	})
+
+	ctx := context.Background()
+	_ = process(ctx, goredis.NewStringCmd(ctx, "get", "key"))
+	if remaining := time.Until(deadline); remaining <= 0 || remaining > 50*time.Millisecond {
+		t.Errorf("Expected the operation timeout to apply, got %v remaining", remaining)
+	}
+
+	shortCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	want, _ := shortCtx.Deadline()
+	_ = process(shortCtx, goredis.NewStringCmd(shortCtx, "get", "key"))
+	if !deadline.Equal(want) {
+		t.Errorf("Expected the caller's sooner deadline to be kept")
+	}
+}
@@ -460,6 +460,53 @@ func (c *Client) GetChannelFollowers(ctx context.Context, broadcasterID string,
 	return &followersResp, nil
 }
 
+// GetSchedule fetches a page of a broadcaster's stream schedule, starting at
+// startTime when set. Broadcasters who never created a schedule return an
+// APIError with status 404.
+func (c *Client) GetSchedule(ctx context.Context, broadcasterID string, startTime time.Time, first int, after string) (*ScheduleResponse, error) {
+	params := url.Values{}
+	params.Set("broadcaster_id", broadcasterID)
+
+	if !startTime.IsZero() {
+		params.Set("start_time", startTime.UTC().Format(time.RFC3339))
+	}
+	if first > 0 {
+		if first > 25 {
+			first = 25
+		}
+		params.Set("first", fmt.Sprintf("%d", first))
+	}
+	if after != "" {
+		params.Set("after", after)
+	}
+
+	resp, err := c.doRequest(ctx, "GET", "/schedule", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch schedule: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, &APIError{
+			StatusCode: resp.StatusCode,
+			Message:    fmt.Sprintf("schedule request failed: %s", string(body)),
+		}
+	}
+
+	var scheduleResp ScheduleResponse
+	if err := json.NewDecoder(resp.Body).Decode(&scheduleResp); err != nil {
+		return nil, fmt.Errorf("failed to decode schedule response: %w", err)
+	}
+
+	logger := utils.GetLogger()
+	logger.Debug("Fetched schedule", map[string]interface{}{
+		"count":          len(scheduleResp.Data.Segments),
+		"broadcaster_id": broadcasterID,
+	})
+	return &scheduleResp, nil
+}
+
 // GetStreamStatusByUsername fetches stream status for a specific username with caching
 func (c *Client) GetStreamStatusByUsername(ctx context.Context, username string) (*Stream, *User, error) {
 	if username == "" {
@@ -672,3 +672,64 @@ func TestBanUser_RetryOn5xx(t *testing.T) {
 		})
 	}
 }
+
+func TestScheduleResponseDecoding(t *testing.T) {
+	body := `{
+		"data": {
+			"segments": [
+				{
+					"id": "seg1",
+					"start_time": "2026-10-20T18:00:00Z",
+					"end_time": "2026-10-20T21:00:00Z",
+					"title": "Ranked grind",
+					"canceled_until": null,
+					"category": {"id": "509658", "name": "Just Chatting"},
+					"is_recurring": true
+				},
+				{
+					"id": "seg2",
+					"start_time": "2026-10-21T18:00:00Z",
+					"end_time": null,
+					"title": "",
+					"canceled_until": "2026-10-21T21:00:00Z",
+					"category": null,
+					"is_recurring": false
+				}
+			],
+			"broadcaster_id": "141981764",
+			"broadcaster_name": "TwitchDev",
+			"broadcaster_login": "twitchdev",
+			"vacation": null
+		},
+		"pagination": {"cursor": "next"}
+	}`
+
+	var resp ScheduleResponse
+	if err := json.Unmarshal([]byte(body), &resp); err != nil {
+		t.Fatalf("failed to decode schedule response: %v", err)
+	}
+
+	if len(resp.Data.Segments) != 2 {
+		t.Fatalf("expected 2 segments, got %d", len(resp.Data.Segments))
+	}
+	first := resp.Data.Segments[0]
+	if first.Category == nil || first.Category.Name != "Just Chatting" {
+		t.Errorf("expected category Just Chatting, got %+v", first.Category)
+	}
+	if first.EndTime == nil || !first.EndTime.Equal(time.Date(2026, 10, 20, 21, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected end time %v", first.EndTime)
+	}
+	second := resp.Data.Segments[1]
+	if second.EndTime != nil || second.Category != nil {
+		t.Errorf("expected nil end time and category, got %v and %+v", second.EndTime, second.Category)
+	}
+	if second.CanceledUntil == nil {
+		t.Error("expected canceled_until to be set")
+	}
+	if resp.Data.Vacation != nil {
+		t.Errorf("expected no vacation, got %+v", resp.Data.Vacation)
+	}
+	if resp.Pagination.Cursor != "next" {
+		t.Errorf("expected cursor next, got %s", resp.Pagination.Cursor)
+	}
+}
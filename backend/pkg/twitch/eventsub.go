@@ -0,0 +1,205 @@
+package twitch
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/subculture-collective/clipper/pkg/utils"
+)
+
+// EventSub webhook headers
+// See: https://dev.twitch.tv/docs/eventsub/handling-webhook-events/
+const (
+	EventSubHeaderMessageID        = "Twitch-Eventsub-Message-Id"
+	EventSubHeaderMessageTimestamp = "Twitch-Eventsub-Message-Timestamp"
+	EventSubHeaderMessageSignature = "Twitch-Eventsub-Message-Signature"
+	EventSubHeaderMessageType      = "Twitch-Eventsub-Message-Type"
+)
+
+// EventSub message types
+const (
+	EventSubMessageVerification = "webhook_callback_verification"
+	EventSubMessageNotification = "notification"
+	EventSubMessageRevocation   = "revocation"
+)
+
+// EventSub subscription types
+const (
+	EventSubTypeStreamOnline  = "stream.online"
+	EventSubTypeStreamOffline = "stream.offline"
+	EventSubTypeClipCreate    = "channel.clip.create"
+	EventSubTypeChatMessage   = "channel.chat.message"
+)
+
+// EventSubSubscription describes an EventSub subscription
+type EventSubSubscription struct {
+	ID        string            `json:"id"`
+	Type      string            `json:"type"`
+	Version   string            `json:"version"`
+	Status    string            `json:"status"`
+	Condition map[string]string `json:"condition"`
+	Transport EventSubTransport `json:"transport"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// EventSubTransport is where Twitch delivers events for a subscription
+type EventSubTransport struct {
+	Method   string `json:"method"`
+	Callback string `json:"callback"`
+	Secret   string `json:"secret,omitempty"`
+}
+
+// EventSubMessage is the body of an EventSub webhook request. Challenge is
+// set for verification requests and Event for notifications.
+type EventSubMessage struct {
+	Challenge    string               `json:"challenge,omitempty"`
+	Subscription EventSubSubscription `json:"subscription"`
+	Event        json.RawMessage      `json:"event,omitempty"`
+}
+
+// EventSubStreamOnlineEvent is the event of a stream.online notification
+type EventSubStreamOnlineEvent struct {
+	ID                   string    `json:"id"`
+	BroadcasterUserID    string    `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string    `json:"broadcaster_user_login"`
+	BroadcasterUserName  string    `json:"broadcaster_user_name"`
+	Type                 string    `json:"type"`
+	StartedAt            time.Time `json:"started_at"`
+}
+
+// EventSubStreamOfflineEvent is the event of a stream.offline notification
+type EventSubStreamOfflineEvent struct {
+	BroadcasterUserID    string `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string `json:"broadcaster_user_login"`
+	BroadcasterUserName  string `json:"broadcaster_user_name"`
+}
+
+// EventSubClipEvent is the event of a clip creation notification
+type EventSubClipEvent struct {
+	ID                string `json:"id"`
+	BroadcasterUserID string `json:"broadcaster_user_id"`
+}
+
+// EventSubChatMessageEvent is the event of a channel.chat.message notification
+type EventSubChatMessageEvent struct {
+	BroadcasterUserID string                  `json:"broadcaster_user_id"`
+	ChatterUserID     string                  `json:"chatter_user_id"`
+	MessageID         string                  `json:"message_id"`
+	MessageType       string                  `json:"message_type"`
+	Message           EventSubChatMessageBody `json:"message"`
+}
+
+// EventSubChatMessageBody is the text of a chat message split into fragments
+type EventSubChatMessageBody struct {
+	Text      string                        `json:"text"`
+	Fragments []EventSubChatMessageFragment `json:"fragments"`
+}
+
+// EventSubChatMessageFragment is a run of text, an emote, a cheermote or a
+// mention in a chat message
+type EventSubChatMessageFragment struct {
+	Type string `json:"type"` // "text", "emote", "cheermote" or "mention"
+	Text string `json:"text"`
+}
+
+// EmoteCount returns how many emotes the message contains
+func (m EventSubChatMessageBody) EmoteCount() int {
+	count := 0
+	for _, fragment := range m.Fragments {
+		if fragment.Type == "emote" {
+			count++
+		}
+	}
+	return count
+}
+
+// VerifyEventSubSignature checks a webhook request's
+// Twitch-Eventsub-Message-Signature against the subscription secret. The
+// signature is "sha256=" followed by the hex HMAC-SHA256 of the message ID,
+// timestamp and raw body.
+func VerifyEventSubSignature(secret, messageID, timestamp string, body []byte, signature string) bool {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(messageID))
+	mac.Write([]byte(timestamp))
+	mac.Write(body)
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// CreateEventSubSubscription subscribes a webhook callback to an event type
+// for a condition (e.g. broadcaster_user_id). An existing identical
+// subscription is not an error.
+// See: https://dev.twitch.tv/docs/api/reference/#create-eventsub-subscription
+func (c *Client) CreateEventSubSubscription(ctx context.Context, subType, version string, condition map[string]string, transport EventSubTransport) error {
+	if err := c.circuitBreaker.Allow(); err != nil {
+		return err
+	}
+
+	token, err := c.authManager.GetToken(ctx)
+	if err != nil {
+		c.circuitBreaker.RecordFailure()
+		return err
+	}
+
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return fmt.Errorf("rate limit wait cancelled: %w", err)
+	}
+
+	transport.Method = "webhook"
+	jsonBody, err := json.Marshal(map[string]interface{}{
+		"type":      subType,
+		"version":   version,
+		"condition": condition,
+		"transport": transport,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal subscription request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", baseURL+"/eventsub/subscriptions", bytes.NewReader(jsonBody))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token) // #nosec G101 (value is an OAuth token, not hardcoded secret)
+	req.Header.Set("Client-Id", c.clientID)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.circuitBreaker.RecordFailure()
+		return fmt.Errorf("failed to create subscription: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusAccepted:
+		c.circuitBreaker.RecordSuccess()
+		logger := utils.GetLogger()
+		logger.Debug("Created EventSub subscription", map[string]interface{}{
+			"type":      subType,
+			"condition": condition,
+		})
+		return nil
+	case http.StatusConflict:
+		// Already subscribed
+		c.circuitBreaker.RecordSuccess()
+		return nil
+	case http.StatusUnauthorized:
+		// Token might be invalid; refresh so the next attempt uses a new one
+		_ = c.authManager.RefreshToken(ctx)
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return &APIError{
+		StatusCode: resp.StatusCode,
+		Message:    fmt.Sprintf("create subscription request failed: %s", string(body)),
+	}
+}
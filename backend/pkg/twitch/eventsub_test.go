@@ -0,0 +1,45 @@
+package twitch
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestVerifyEventSubSignature(t *testing.T) {
+	secret := "s3cr3t-eventsub-secret"
+	messageID := "e76c6bd4-55c9-4987-8304-da1588d8988b"
+	timestamp := "2026-10-16T12:00:00.000000000Z"
+	body := []byte(`{"subscription":{"type":"stream.online"},"event":{"broadcaster_user_id":"1337"}}`)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(messageID + timestamp))
+	mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name      string
+		secret    string
+		messageID string
+		body      []byte
+		signature string
+		want      bool
+	}{
+		{"valid", secret, messageID, body, signature, true},
+		{"wrong secret", "another-secret", messageID, body, signature, false},
+		{"tampered body", secret, messageID, []byte(`{}`), signature, false},
+		{"different message id", secret, "other-id", body, signature, false},
+		{"missing prefix", secret, messageID, body, signature[len("sha256="):], false},
+		{"empty signature", secret, messageID, body, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := VerifyEventSubSignature(tt.secret, tt.messageID, timestamp, tt.body, tt.signature)
+			if got != tt.want {
+				t.Errorf("VerifyEventSubSignature() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
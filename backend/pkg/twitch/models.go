@@ -211,3 +211,42 @@ type BanData struct {
 	CreatedAt     string `json:"created_at,omitempty"`
 	EndTime       string `json:"end_time,omitempty"` // For temporary bans
 }
+
+// ScheduleCategory is the game or category of a schedule segment
+type ScheduleCategory struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// ScheduleSegment is one scheduled broadcast. Recurring segments appear once
+// per occurrence, each with its own ID.
+type ScheduleSegment struct {
+	ID            string            `json:"id"`
+	StartTime     time.Time         `json:"start_time"`
+	EndTime       *time.Time        `json:"end_time"`
+	Title         string            `json:"title"`
+	CanceledUntil *time.Time        `json:"canceled_until"` // Set when the occurrence is canceled
+	Category      *ScheduleCategory `json:"category"`
+	IsRecurring   bool              `json:"is_recurring"`
+}
+
+// ScheduleVacation is a period during which a broadcaster's schedule is paused
+type ScheduleVacation struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
+// Schedule represents a broadcaster's stream schedule
+type Schedule struct {
+	Segments         []ScheduleSegment `json:"segments"`
+	BroadcasterID    string            `json:"broadcaster_id"`
+	BroadcasterName  string            `json:"broadcaster_name"`
+	BroadcasterLogin string            `json:"broadcaster_login"`
+	Vacation         *ScheduleVacation `json:"vacation"`
+}
+
+// ScheduleResponse represents the response from the schedule endpoint
+type ScheduleResponse struct {
+	Data       Schedule   `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
@@ -390,6 +390,12 @@ func Fatal(message string, err error, fields ...map[string]interface{}) {
 	GetLogger().Fatal(message, err, fields...)
 }
 
+// HashForLogging hashes a user ID or other identifier the way request logs
+// do, so records can be matched with logs without storing the value
+func HashForLogging(value string) string {
+	return hashForLogging(value)
+}
+
 // hashForLogging creates a SHA-256 hash prefix for PII protection in logs
 func hashForLogging(value string) string {
 	hash := sha256.Sum256([]byte(value))
@@ -2,6 +2,7 @@ import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
 import { useEffect, useRef, useState } from 'react';
 import { Link } from 'react-router-dom';
 import { useClickOutside } from '../../hooks/useClickOutside';
+import { useNotificationStream } from '../../hooks/useNotificationStream';
 import {
     getNotifications,
     getUnreadCount,
@@ -18,14 +19,15 @@ export function NotificationBell() {
     const queryClient = useQueryClient();
     const { isAuthenticated, isLoading } = useAuth();
     const isAuthReady = isAuthenticated && !isLoading;
+    const isStreaming = useNotificationStream(isAuthReady);
 
-    // Get unread count
+    // Get unread count; poll every 30 seconds while the live stream is unavailable
     const { data: unreadCount = 0, refetch: refetchCount } = useQuery({
         queryKey: ['notifications', 'count'],
         queryFn: getUnreadCount,
         enabled: isAuthReady,
         retry: false,
-        refetchInterval: isAuthReady ? 30000 : false, // Poll every 30 seconds
+        refetchInterval: isAuthReady && !isStreaming ? 30000 : false,
     });
 
     // Get recent notifications (for dropdown)
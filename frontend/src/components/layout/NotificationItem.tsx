@@ -21,6 +21,8 @@ export function NotificationItem({
                 return '🎉';
             case 'badge_earned':
                 return '🏅';
+            case 'achievement_completed':
+                return '🏆';
             case 'rank_up':
                 return '⬆️';
             case 'favorited_clip_comment':
@@ -32,12 +32,13 @@ export function UserAppealsStatus() {
   const getStatusBadge = (status: string) => {
     const styles = {
       pending: 'bg-yellow-100 text-yellow-800 dark:bg-yellow-900/30 dark:text-yellow-300',
+      in_review: 'bg-blue-100 text-blue-800 dark:bg-blue-900/30 dark:text-blue-300',
       approved: 'bg-green-100 text-green-800 dark:bg-green-900/30 dark:text-green-300',
       rejected: 'bg-red-100 text-red-800 dark:bg-red-900/30 dark:text-red-300',
     };
     return (
       <span className={`px-2 py-1 rounded text-xs font-medium ${styles[status as keyof typeof styles]}`}>
-        {status.charAt(0).toUpperCase() + status.slice(1)}
+        {status === 'in_review' ? 'In review' : status.charAt(0).toUpperCase() + status.slice(1)}
       </span>
     );
   };
@@ -111,7 +112,7 @@ export function UserAppealsStatus() {
               </p>
             </div>
 
-            {appeal.status === 'pending' && (
+            {(appeal.status === 'pending' || appeal.status === 'in_review') && (
               <Alert variant="info">
                 Your appeal is being reviewed by our moderation team. 
                 We'll notify you once a decision has been made.
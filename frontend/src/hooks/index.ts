@@ -16,6 +16,7 @@ export type { KeyboardControlHandlers } from './useKeyboardControls';
 export { useWatchHistory } from './useWatchHistory';
 export type { UseWatchHistoryOptions, UseWatchHistoryReturn } from './useWatchHistory';
 export { useChatWebSocket } from './useChatWebSocket';
+export { useNotificationStream } from './useNotificationStream';
 export { useDesktopNotifications } from './useDesktopNotifications';
 export { useCheckBanStatus } from './useCheckBanStatus';
 export type { UseBanStatusReturn } from './useCheckBanStatus';
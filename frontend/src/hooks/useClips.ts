@@ -17,16 +17,15 @@ import {
 export const useClipFeed = (filters?: ClipFeedFilters) => {
     return useInfiniteQuery({
         queryKey: ['clips', filters],
-        // Use page-based pagination to align with tests and mocks
-        queryFn: ({ pageParam = 1 }) =>
-            clipApi.fetchClips({ pageParam, filters } as unknown as {
-                pageParam: number;
-                filters?: ClipFeedFilters;
-            }),
+        // Each page continues from the previous page's opaque cursor
+        queryFn: ({ pageParam }) =>
+            clipApi.fetchClips({ cursor: pageParam, filters }),
         getNextPageParam: lastPage => {
-            return lastPage.has_more ? (lastPage.page ?? 1) + 1 : undefined;
+            return lastPage.has_more ?
+                    (lastPage.next_cursor ?? undefined)
+                :   undefined;
         },
-        initialPageParam: 1,
+        initialPageParam: undefined as string | undefined,
     });
 };
 
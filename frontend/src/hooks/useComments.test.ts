@@ -88,7 +88,7 @@ describe('useComments', () => {
       expect(commentApi.fetchComments).toHaveBeenCalledWith({
         clipId: 'clip-1',
         sort: 'best',
-        pageParam: 1,
+        cursor: undefined,
         limit: 10,
         includeReplies: true,
       });
@@ -141,7 +141,7 @@ describe('useComments', () => {
       expect(commentApi.fetchComments).toHaveBeenCalledWith({
         clipId: 'clip-1',
         sort: 'new',
-        pageParam: 1,
+        cursor: undefined,
         limit: 10,
         includeReplies: true,
       });
@@ -151,6 +151,7 @@ describe('useComments', () => {
       const page1Response: CommentFeedResponse = {
         ...mockCommentFeedResponse,
         has_more: true,
+        next_cursor: 'next-page-cursor',
       };
 
       vi.mocked(commentApi.fetchComments).mockResolvedValue(page1Response);
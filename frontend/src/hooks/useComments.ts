@@ -20,18 +20,18 @@ const ITEMS_PER_PAGE = 10;
 export const useComments = (clipId: string, sort: CommentSortOption = 'best') => {
   return useInfiniteQuery({
     queryKey: ['comments', clipId, sort, 'with-replies'],
-    queryFn: ({ pageParam = 1 }) =>
+    queryFn: ({ pageParam }) =>
       commentApi.fetchComments({
         clipId,
         sort,
-        pageParam,
+        cursor: pageParam,
         limit: ITEMS_PER_PAGE,
         includeReplies: true, // Fetch nested replies for tree structure
       }),
     getNextPageParam: (lastPage) => {
-      return lastPage.has_more ? lastPage.page + 1 : undefined;
+      return lastPage.has_more ? lastPage.next_cursor ?? undefined : undefined;
     },
-    initialPageParam: 1,
+    initialPageParam: undefined as string | undefined,
     enabled: !!clipId,
   });
 };
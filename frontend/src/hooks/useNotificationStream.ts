@@ -0,0 +1,73 @@
+/**
+ * React hook for real-time notifications
+ * Keeps the notification queries fresh from the server's event stream.
+ * Returns whether the stream is connected so callers can poll while it is not.
+ */
+
+import { useEffect, useState } from 'react';
+import { useQueryClient } from '@tanstack/react-query';
+
+const API_BASE_URL =
+  import.meta.env.VITE_API_BASE_URL || 'http://localhost:8080/api/v1';
+
+// Wait before reopening a stream the server refused (e.g. expired session or unavailable)
+const RECONNECT_DELAY_MS = 60000;
+
+interface UnreadCountEvent {
+  unread_count: number;
+}
+
+export function useNotificationStream(enabled: boolean): boolean {
+  const queryClient = useQueryClient();
+  const [connected, setConnected] = useState(false);
+
+  useEffect(() => {
+    if (!enabled || typeof EventSource === 'undefined') {
+      setConnected(false);
+      return;
+    }
+
+    let source: EventSource | null = null;
+    let reconnectTimer: ReturnType<typeof setTimeout> | null = null;
+    let cancelled = false;
+
+    const connect = () => {
+      source = new EventSource(`${API_BASE_URL}/notifications/stream`, {
+        withCredentials: true,
+      });
+
+      source.onopen = () => setConnected(true);
+
+      source.addEventListener('unread_count', (event) => {
+        const data = JSON.parse((event as MessageEvent).data) as UnreadCountEvent;
+        queryClient.setQueryData(['notifications', 'count'], data.unread_count);
+      });
+
+      source.addEventListener('notification', () => {
+        queryClient.invalidateQueries({ queryKey: ['notifications', 'recent'] });
+        queryClient.invalidateQueries({ queryKey: ['notifications', 'list'] });
+      });
+
+      source.onerror = () => {
+        setConnected(false);
+        // EventSource retries dropped connections itself; refused ones are closed for good
+        if (source?.readyState === EventSource.CLOSED && !cancelled) {
+          reconnectTimer = setTimeout(connect, RECONNECT_DELAY_MS);
+        }
+      };
+    };
+
+    connect();
+
+    return () => {
+      cancelled = true;
+      if (reconnectTimer) {
+        clearTimeout(reconnectTimer);
+      }
+      source?.close();
+      setConnected(false);
+    };
+  }, [enabled, queryClient]);
+
+  return connected;
+}
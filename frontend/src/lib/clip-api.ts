@@ -75,27 +75,24 @@ export async function fetchClips({
         pagination: {
             limit: number;
             offset: number;
-            total: number;
+            total?: number;
             total_pages?: number;
             has_more: boolean;
-            cursor?: string;
+            next_cursor: string | null;
         };
     }>('/feeds/clips', { params });
 
+    const { pagination } = response.data;
     return {
         clips: response.data.clips,
-        total: response.data.pagination.total,
+        // Cursor pages don't count the full result set
+        total: pagination.total ?? response.data.clips.length,
         // Only calculate page for offset-based pagination
         page:
-            response.data.pagination.cursor ?
-                1
-            :   Math.floor(
-                    response.data.pagination.offset /
-                        response.data.pagination.limit,
-                ) + 1,
-        limit: response.data.pagination.limit,
-        has_more: response.data.pagination.has_more,
-        cursor: response.data.pagination.cursor,
+            cursor ? 1 : Math.floor(pagination.offset / pagination.limit) + 1,
+        limit: pagination.limit,
+        has_more: pagination.has_more,
+        next_cursor: pagination.next_cursor,
     };
 }
 
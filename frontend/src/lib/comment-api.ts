@@ -68,25 +68,26 @@ const normalizeComment = (comment: ApiComment, depth = 0): Comment => {
 export async function fetchComments({
   clipId,
   sort = 'best',
-  pageParam = 1,
+  cursor,
   limit = 10,
   includeReplies = false,
 }: {
   clipId: string;
   sort?: CommentSortOption;
-  pageParam?: number;
+  cursor?: string;
   limit?: number;
   includeReplies?: boolean;
 }): Promise<CommentFeedResponse> {
   const response = await apiClient.get<{
     comments: ApiComment[];
     total?: number;
-    next_cursor?: number;
+    next_cursor: string | null;
     has_more: boolean;
   }>(`/clips/${clipId}/comments`, {
     params: {
       sort,
-      cursor: (pageParam - 1) * limit,
+      // Opaque cursor from the previous page's next_cursor
+      ...(cursor ? { cursor } : {}),
       limit,
       include_replies: includeReplies,
     },
@@ -97,9 +98,9 @@ export async function fetchComments({
   return {
     comments,
     total: response.data.total || comments.length,
-    page: pageParam,
     limit,
     has_more: response.data.has_more,
+    next_cursor: response.data.next_cursor,
   };
 }
 
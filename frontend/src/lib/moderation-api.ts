@@ -132,7 +132,9 @@ export interface ModerationAppeal {
     user_id: string;
     moderation_action_id: string;
     reason: string;
-    status: 'pending' | 'approved' | 'rejected';
+    status: 'pending' | 'in_review' | 'approved' | 'rejected';
+    assigned_to?: string;
+    assigned_at?: string;
     resolved_by?: string;
     resolution?: string;
     created_at: string;
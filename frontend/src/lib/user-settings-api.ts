@@ -7,6 +7,7 @@ export interface UserSettings {
   user_id: string;
   profile_visibility: 'public' | 'private' | 'followers';
   show_karma_publicly: boolean;
+  personalized_search: boolean;
   created_at: string;
   updated_at: string;
 }
@@ -19,6 +20,7 @@ export interface UpdateProfileRequest {
 export interface UpdateSettingsRequest {
   profile_visibility?: 'public' | 'private' | 'followers';
   show_karma_publicly?: boolean;
+  personalized_search?: boolean;
 }
 
 export interface DeleteAccountRequest {
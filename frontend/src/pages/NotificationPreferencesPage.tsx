@@ -160,6 +160,13 @@ export function NotificationPreferencesPage() {
                   checked={formData.in_app_enabled ?? true}
                   onChange={() => handleToggle('in_app_enabled')}
                 />
+
+                <ToggleSwitch
+                  label="Push Notifications"
+                  description="Send notifications to your registered mobile devices"
+                  checked={formData.push_enabled ?? true}
+                  onChange={() => handleToggle('push_enabled')}
+                />
               </div>
             </div>
 
@@ -415,6 +422,13 @@ export function NotificationPreferencesPage() {
                   checked={formData.notify_platform_announcements ?? true}
                   onChange={() => handleToggle('notify_platform_announcements')}
                 />
+
+                <ToggleSwitch
+                  label="Missed Highlights"
+                  description="Top clips from your follows when you haven't visited in a while"
+                  checked={formData.notify_reengagement ?? true}
+                  onChange={() => handleToggle('notify_reengagement')}
+                />
               </div>
             </div>
 
@@ -136,6 +136,7 @@ export function SettingsPage() {
             setSettingsData({
                 profile_visibility: settings.profile_visibility,
                 show_karma_publicly: settings.show_karma_publicly,
+                personalized_search: settings.personalized_search,
             });
         }
     }, [settings]);
@@ -525,6 +526,21 @@ export function SettingsPage() {
                                                 })
                                             }
                                         />
+                                        <Toggle
+                                            label='Personalized Search'
+                                            helperText='Rank search results from broadcasters and games you follow higher, and games you downvote lower'
+                                            checked={
+                                                settingsData.personalized_search ??
+                                                true
+                                            }
+                                            onChange={(e) =>
+                                                setSettingsData({
+                                                    ...settingsData,
+                                                    personalized_search:
+                                                        e.target.checked,
+                                                })
+                                            }
+                                        />
                                         <div className='flex gap-3'>
                                             <Button
                                                 type='submit'
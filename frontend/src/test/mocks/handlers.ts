@@ -140,6 +140,7 @@ export const handlers = [
     http.get(`${API_BASE_URL}/clips/:id/comments`, ({ params, request }) => {
         const url = new URL(request.url);
         const sort = url.searchParams.get('sort') || 'best';
+        // The mock's opaque cursor is the offset of the next page
         const cursor = parseInt(url.searchParams.get('cursor') || '0');
         const limit = parseInt(url.searchParams.get('limit') || '10');
 
@@ -178,8 +179,11 @@ export const handlers = [
         return HttpResponse.json({
             comments: paginatedComments,
             total: clipComments.length,
-            next_cursor: cursor + paginatedComments.length,
-            has_more: paginatedComments.length === limit,
+            next_cursor:
+                cursor + limit < clipComments.length ?
+                    String(cursor + limit)
+                :   null,
+            has_more: cursor + limit < clipComments.length,
         });
     }),
 
@@ -66,7 +66,7 @@ export interface ClipFeedResponse {
     has_more: boolean;
     has_next?: boolean;
     has_prev?: boolean;
-    cursor?: string; // Cursor for cursor-based pagination
+    next_cursor?: string | null; // Opaque cursor for the next page
 }
 
 export type SortOption =
@@ -28,9 +28,10 @@ export interface Comment {
 export interface CommentFeedResponse {
   comments: Comment[];
   total: number;
-  page: number;
+  page?: number;
   limit: number;
   has_more: boolean;
+  next_cursor?: string | null; // Opaque cursor for the next page
 }
 
 export type CommentSortOption = 'best' | 'top' | 'new' | 'old' | 'controversial';
@@ -22,6 +22,7 @@ export type NotificationType =
   | 'mention'
   | 'vote_milestone'
   | 'badge_earned'
+  | 'achievement_completed'
   | 'rank_up'
   | 'favorited_clip_comment'
   | 'content_removed'
@@ -31,6 +32,7 @@ export type NotificationType =
   | 'submission_approved'
   | 'submission_rejected'
   | 'new_report'
+  | 'report_outcome'
   | 'pending_submissions'
   | 'system_alert'
   | 'clip_comment'
@@ -44,6 +46,7 @@ export interface NotificationPreferences {
   in_app_enabled: boolean;
   email_enabled: boolean;
   email_digest: 'immediate' | 'daily' | 'weekly' | 'never';
+  push_enabled: boolean;
   
   // Account & Security
   notify_login_new_device: boolean;
@@ -82,6 +85,7 @@ export interface NotificationPreferences {
   notify_marketing: boolean;
   notify_policy_updates: boolean;
   notify_platform_announcements: boolean;
+  notify_reengagement: boolean;
   
   updated_at: string;
 }